@@ -36,6 +36,276 @@ import (
 	strings "strings"
 )
 
+type ClosedExecutionInfo struct {
+	DomainId   *string `json:"domainId,omitempty"`
+	WorkflowId *string `json:"workflowId,omitempty"`
+	RunId      *string `json:"runId,omitempty"`
+	CloseTime  *int64  `json:"closeTime,omitempty"`
+}
+
+// ToWire translates a ClosedExecutionInfo struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *ClosedExecutionInfo) ToWire() (wire.Value, error) {
+	var (
+		fields [4]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.DomainId != nil {
+		w, err = wire.NewValueString(*(v.DomainId)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 10, Value: w}
+		i++
+	}
+	if v.WorkflowId != nil {
+		w, err = wire.NewValueString(*(v.WorkflowId)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 20, Value: w}
+		i++
+	}
+	if v.RunId != nil {
+		w, err = wire.NewValueString(*(v.RunId)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 30, Value: w}
+		i++
+	}
+	if v.CloseTime != nil {
+		w, err = wire.NewValueI64(*(v.CloseTime)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 40, Value: w}
+		i++
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+// FromWire deserializes a ClosedExecutionInfo struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a ClosedExecutionInfo struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v ClosedExecutionInfo
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *ClosedExecutionInfo) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 10:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.DomainId = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 20:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.WorkflowId = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 30:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.RunId = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 40:
+			if field.Value.Type() == wire.TI64 {
+				var x int64
+				x, err = field.Value.GetI64(), error(nil)
+				v.CloseTime = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+
+	return nil
+}
+
+// String returns a readable string representation of a ClosedExecutionInfo
+// struct.
+func (v *ClosedExecutionInfo) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [4]string
+	i := 0
+	if v.DomainId != nil {
+		fields[i] = fmt.Sprintf("DomainId: %v", *(v.DomainId))
+		i++
+	}
+	if v.WorkflowId != nil {
+		fields[i] = fmt.Sprintf("WorkflowId: %v", *(v.WorkflowId))
+		i++
+	}
+	if v.RunId != nil {
+		fields[i] = fmt.Sprintf("RunId: %v", *(v.RunId))
+		i++
+	}
+	if v.CloseTime != nil {
+		fields[i] = fmt.Sprintf("CloseTime: %v", *(v.CloseTime))
+		i++
+	}
+
+	return fmt.Sprintf("ClosedExecutionInfo{%v}", strings.Join(fields[:i], ", "))
+}
+
+// Equals returns true if all the fields of this ClosedExecutionInfo match the
+// provided ClosedExecutionInfo.
+//
+// This function performs a deep comparison.
+func (v *ClosedExecutionInfo) Equals(rhs *ClosedExecutionInfo) bool {
+	if v == nil {
+		return rhs == nil
+	} else if rhs == nil {
+		return false
+	}
+	if !_String_EqualsPtr(v.DomainId, rhs.DomainId) {
+		return false
+	}
+	if !_String_EqualsPtr(v.WorkflowId, rhs.WorkflowId) {
+		return false
+	}
+	if !_String_EqualsPtr(v.RunId, rhs.RunId) {
+		return false
+	}
+	if !_I64_EqualsPtr(v.CloseTime, rhs.CloseTime) {
+		return false
+	}
+
+	return true
+}
+
+// MarshalLogObject implements zapcore.ObjectMarshaler, enabling
+// fast logging of ClosedExecutionInfo.
+func (v *ClosedExecutionInfo) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+	if v == nil {
+		return nil
+	}
+	if v.DomainId != nil {
+		enc.AddString("domainId", *v.DomainId)
+	}
+	if v.WorkflowId != nil {
+		enc.AddString("workflowId", *v.WorkflowId)
+	}
+	if v.RunId != nil {
+		enc.AddString("runId", *v.RunId)
+	}
+	if v.CloseTime != nil {
+		enc.AddInt64("closeTime", *v.CloseTime)
+	}
+	return err
+}
+
+// GetDomainId returns the value of DomainId if it is set or its
+// zero value if it is unset.
+func (v *ClosedExecutionInfo) GetDomainId() (o string) {
+	if v != nil && v.DomainId != nil {
+		return *v.DomainId
+	}
+
+	return
+}
+
+// IsSetDomainId returns true if DomainId is not nil.
+func (v *ClosedExecutionInfo) IsSetDomainId() bool {
+	return v != nil && v.DomainId != nil
+}
+
+// GetWorkflowId returns the value of WorkflowId if it is set or its
+// zero value if it is unset.
+func (v *ClosedExecutionInfo) GetWorkflowId() (o string) {
+	if v != nil && v.WorkflowId != nil {
+		return *v.WorkflowId
+	}
+
+	return
+}
+
+// IsSetWorkflowId returns true if WorkflowId is not nil.
+func (v *ClosedExecutionInfo) IsSetWorkflowId() bool {
+	return v != nil && v.WorkflowId != nil
+}
+
+// GetRunId returns the value of RunId if it is set or its
+// zero value if it is unset.
+func (v *ClosedExecutionInfo) GetRunId() (o string) {
+	if v != nil && v.RunId != nil {
+		return *v.RunId
+	}
+
+	return
+}
+
+// IsSetRunId returns true if RunId is not nil.
+func (v *ClosedExecutionInfo) IsSetRunId() bool {
+	return v != nil && v.RunId != nil
+}
+
+// GetCloseTime returns the value of CloseTime if it is set or its
+// zero value if it is unset.
+func (v *ClosedExecutionInfo) GetCloseTime() (o int64) {
+	if v != nil && v.CloseTime != nil {
+		return *v.CloseTime
+	}
+
+	return
+}
+
+// IsSetCloseTime returns true if CloseTime is not nil.
+func (v *ClosedExecutionInfo) IsSetCloseTime() bool {
+	return v != nil && v.CloseTime != nil
+}
+
 type DescribeWorkflowExecutionRequest struct {
 	Domain    *string                   `json:"domain,omitempty"`
 	Execution *shared.WorkflowExecution `json:"execution,omitempty"`
@@ -237,6 +507,8 @@ type DescribeWorkflowExecutionResponse struct {
 	HistoryAddr            *string `json:"historyAddr,omitempty"`
 	MutableStateInCache    *string `json:"mutableStateInCache,omitempty"`
 	MutableStateInDatabase *string `json:"mutableStateInDatabase,omitempty"`
+	LastFirstEventId       *int64  `json:"lastFirstEventId,omitempty"`
+	LastEventTaskId        *int64  `json:"lastEventTaskId,omitempty"`
 }
 
 // ToWire translates a DescribeWorkflowExecutionResponse struct into a Thrift-level intermediate
@@ -256,7 +528,7 @@ type DescribeWorkflowExecutionResponse struct {
 //   }
 func (v *DescribeWorkflowExecutionResponse) ToWire() (wire.Value, error) {
 	var (
-		fields [4]wire.Field
+		fields [6]wire.Field
 		i      int = 0
 		w      wire.Value
 		err    error
@@ -294,6 +566,22 @@ func (v *DescribeWorkflowExecutionResponse) ToWire() (wire.Value, error) {
 		fields[i] = wire.Field{ID: 50, Value: w}
 		i++
 	}
+	if v.LastFirstEventId != nil {
+		w, err = wire.NewValueI64(*(v.LastFirstEventId)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 60, Value: w}
+		i++
+	}
+	if v.LastEventTaskId != nil {
+		w, err = wire.NewValueI64(*(v.LastEventTaskId)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 70, Value: w}
+		i++
+	}
 
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
@@ -359,6 +647,26 @@ func (v *DescribeWorkflowExecutionResponse) FromWire(w wire.Value) error {
 					return err
 				}
 
+			}
+		case 60:
+			if field.Value.Type() == wire.TI64 {
+				var x int64
+				x, err = field.Value.GetI64(), error(nil)
+				v.LastFirstEventId = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 70:
+			if field.Value.Type() == wire.TI64 {
+				var x int64
+				x, err = field.Value.GetI64(), error(nil)
+				v.LastEventTaskId = &x
+				if err != nil {
+					return err
+				}
+
 			}
 		}
 	}
@@ -373,7 +681,7 @@ func (v *DescribeWorkflowExecutionResponse) String() string {
 		return "<nil>"
 	}
 
-	var fields [4]string
+	var fields [6]string
 	i := 0
 	if v.ShardId != nil {
 		fields[i] = fmt.Sprintf("ShardId: %v", *(v.ShardId))
@@ -391,6 +699,14 @@ func (v *DescribeWorkflowExecutionResponse) String() string {
 		fields[i] = fmt.Sprintf("MutableStateInDatabase: %v", *(v.MutableStateInDatabase))
 		i++
 	}
+	if v.LastFirstEventId != nil {
+		fields[i] = fmt.Sprintf("LastFirstEventId: %v", *(v.LastFirstEventId))
+		i++
+	}
+	if v.LastEventTaskId != nil {
+		fields[i] = fmt.Sprintf("LastEventTaskId: %v", *(v.LastEventTaskId))
+		i++
+	}
 
 	return fmt.Sprintf("DescribeWorkflowExecutionResponse{%v}", strings.Join(fields[:i], ", "))
 }
@@ -417,6 +733,12 @@ func (v *DescribeWorkflowExecutionResponse) Equals(rhs *DescribeWorkflowExecutio
 	if !_String_EqualsPtr(v.MutableStateInDatabase, rhs.MutableStateInDatabase) {
 		return false
 	}
+	if !_I64_EqualsPtr(v.LastFirstEventId, rhs.LastFirstEventId) {
+		return false
+	}
+	if !_I64_EqualsPtr(v.LastEventTaskId, rhs.LastEventTaskId) {
+		return false
+	}
 
 	return true
 }
@@ -439,6 +761,12 @@ func (v *DescribeWorkflowExecutionResponse) MarshalLogObject(enc zapcore.ObjectE
 	if v.MutableStateInDatabase != nil {
 		enc.AddString("mutableStateInDatabase", *v.MutableStateInDatabase)
 	}
+	if v.LastFirstEventId != nil {
+		enc.AddInt64("lastFirstEventId", *v.LastFirstEventId)
+	}
+	if v.LastEventTaskId != nil {
+		enc.AddInt64("lastEventTaskId", *v.LastEventTaskId)
+	}
 	return err
 }
 
@@ -502,95 +830,89 @@ func (v *DescribeWorkflowExecutionResponse) IsSetMutableStateInDatabase() bool {
 	return v != nil && v.MutableStateInDatabase != nil
 }
 
-type GetWorkflowExecutionRawHistoryRequest struct {
-	Domain          *string                   `json:"domain,omitempty"`
-	Execution       *shared.WorkflowExecution `json:"execution,omitempty"`
-	FirstEventId    *int64                    `json:"firstEventId,omitempty"`
-	NextEventId     *int64                    `json:"nextEventId,omitempty"`
-	MaximumPageSize *int32                    `json:"maximumPageSize,omitempty"`
-	NextPageToken   []byte                    `json:"nextPageToken,omitempty"`
+// GetLastFirstEventId returns the value of LastFirstEventId if it is set or its
+// zero value if it is unset.
+func (v *DescribeWorkflowExecutionResponse) GetLastFirstEventId() (o int64) {
+	if v != nil && v.LastFirstEventId != nil {
+		return *v.LastFirstEventId
+	}
+
+	return
 }
 
-// ToWire translates a GetWorkflowExecutionRawHistoryRequest struct into a Thrift-level intermediate
-// representation. This intermediate representation may be serialized
-// into bytes using a ThriftRW protocol implementation.
-//
-// An error is returned if the struct or any of its fields failed to
-// validate.
-//
-//   x, err := v.ToWire()
-//   if err != nil {
-//     return err
-//   }
-//
+// IsSetLastFirstEventId returns true if LastFirstEventId is not nil.
+func (v *DescribeWorkflowExecutionResponse) IsSetLastFirstEventId() bool {
+	return v != nil && v.LastFirstEventId != nil
+}
+
+// GetLastEventTaskId returns the value of LastEventTaskId if it is set or its
+// zero value if it is unset.
+func (v *DescribeWorkflowExecutionResponse) GetLastEventTaskId() (o int64) {
+	if v != nil && v.LastEventTaskId != nil {
+		return *v.LastEventTaskId
+	}
+
+	return
+}
+
+// IsSetLastEventTaskId returns true if LastEventTaskId is not nil.
+func (v *DescribeWorkflowExecutionResponse) IsSetLastEventTaskId() bool {
+	return v != nil && v.LastEventTaskId != nil
+}
+
+type DescribeWorkflowShardRequest struct {
+	DomainId   *string `json:"domainId,omitempty"`
+	WorkflowId *string `json:"workflowId,omitempty"`
+}
+
+// ToWire translates a DescribeWorkflowShardRequest struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
 //   if err := binaryProtocol.Encode(x, writer); err != nil {
 //     return err
 //   }
-func (v *GetWorkflowExecutionRawHistoryRequest) ToWire() (wire.Value, error) {
+func (v *DescribeWorkflowShardRequest) ToWire() (wire.Value, error) {
 	var (
-		fields [6]wire.Field
+		fields [2]wire.Field
 		i      int = 0
 		w      wire.Value
 		err    error
 	)
 
-	if v.Domain != nil {
-		w, err = wire.NewValueString(*(v.Domain)), error(nil)
+	if v.DomainId != nil {
+		w, err = wire.NewValueString(*(v.DomainId)), error(nil)
 		if err != nil {
 			return w, err
 		}
 		fields[i] = wire.Field{ID: 10, Value: w}
 		i++
 	}
-	if v.Execution != nil {
-		w, err = v.Execution.ToWire()
+	if v.WorkflowId != nil {
+		w, err = wire.NewValueString(*(v.WorkflowId)), error(nil)
 		if err != nil {
 			return w, err
 		}
 		fields[i] = wire.Field{ID: 20, Value: w}
 		i++
 	}
-	if v.FirstEventId != nil {
-		w, err = wire.NewValueI64(*(v.FirstEventId)), error(nil)
-		if err != nil {
-			return w, err
-		}
-		fields[i] = wire.Field{ID: 30, Value: w}
-		i++
-	}
-	if v.NextEventId != nil {
-		w, err = wire.NewValueI64(*(v.NextEventId)), error(nil)
-		if err != nil {
-			return w, err
-		}
-		fields[i] = wire.Field{ID: 40, Value: w}
-		i++
-	}
-	if v.MaximumPageSize != nil {
-		w, err = wire.NewValueI32(*(v.MaximumPageSize)), error(nil)
-		if err != nil {
-			return w, err
-		}
-		fields[i] = wire.Field{ID: 50, Value: w}
-		i++
-	}
-	if v.NextPageToken != nil {
-		w, err = wire.NewValueBinary(v.NextPageToken), error(nil)
-		if err != nil {
-			return w, err
-		}
-		fields[i] = wire.Field{ID: 60, Value: w}
-		i++
-	}
 
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
 
-// FromWire deserializes a GetWorkflowExecutionRawHistoryRequest struct from its Thrift-level
+// FromWire deserializes a DescribeWorkflowShardRequest struct from its Thrift-level
 // representation. The Thrift-level representation may be obtained
 // from a ThriftRW protocol implementation.
 //
-// An error is returned if we were unable to build a GetWorkflowExecutionRawHistoryRequest struct
+// An error is returned if we were unable to build a DescribeWorkflowShardRequest struct
 // from the provided intermediate representation.
 //
 //   x, err := binaryProtocol.Decode(reader, wire.TStruct)
@@ -598,12 +920,12 @@ func (v *GetWorkflowExecutionRawHistoryRequest) ToWire() (wire.Value, error) {
 //     return nil, err
 //   }
 //
-//   var v GetWorkflowExecutionRawHistoryRequest
+//   var v DescribeWorkflowShardRequest
 //   if err := v.FromWire(x); err != nil {
 //     return nil, err
 //   }
 //   return &v, nil
-func (v *GetWorkflowExecutionRawHistoryRequest) FromWire(w wire.Value) error {
+func (v *DescribeWorkflowShardRequest) FromWire(w wire.Value) error {
 	var err error
 
 	for _, field := range w.GetStruct().Fields {
@@ -612,53 +934,17 @@ func (v *GetWorkflowExecutionRawHistoryRequest) FromWire(w wire.Value) error {
 			if field.Value.Type() == wire.TBinary {
 				var x string
 				x, err = field.Value.GetString(), error(nil)
-				v.Domain = &x
+				v.DomainId = &x
 				if err != nil {
 					return err
 				}
 
 			}
 		case 20:
-			if field.Value.Type() == wire.TStruct {
-				v.Execution, err = _WorkflowExecution_Read(field.Value)
-				if err != nil {
-					return err
-				}
-
-			}
-		case 30:
-			if field.Value.Type() == wire.TI64 {
-				var x int64
-				x, err = field.Value.GetI64(), error(nil)
-				v.FirstEventId = &x
-				if err != nil {
-					return err
-				}
-
-			}
-		case 40:
-			if field.Value.Type() == wire.TI64 {
-				var x int64
-				x, err = field.Value.GetI64(), error(nil)
-				v.NextEventId = &x
-				if err != nil {
-					return err
-				}
-
-			}
-		case 50:
-			if field.Value.Type() == wire.TI32 {
-				var x int32
-				x, err = field.Value.GetI32(), error(nil)
-				v.MaximumPageSize = &x
-				if err != nil {
-					return err
-				}
-
-			}
-		case 60:
 			if field.Value.Type() == wire.TBinary {
-				v.NextPageToken, err = field.Value.GetBinary(), error(nil)
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.WorkflowId = &x
 				if err != nil {
 					return err
 				}
@@ -670,89 +956,41 @@ func (v *GetWorkflowExecutionRawHistoryRequest) FromWire(w wire.Value) error {
 	return nil
 }
 
-// String returns a readable string representation of a GetWorkflowExecutionRawHistoryRequest
+// String returns a readable string representation of a DescribeWorkflowShardRequest
 // struct.
-func (v *GetWorkflowExecutionRawHistoryRequest) String() string {
+func (v *DescribeWorkflowShardRequest) String() string {
 	if v == nil {
 		return "<nil>"
 	}
 
-	var fields [6]string
+	var fields [2]string
 	i := 0
-	if v.Domain != nil {
-		fields[i] = fmt.Sprintf("Domain: %v", *(v.Domain))
-		i++
-	}
-	if v.Execution != nil {
-		fields[i] = fmt.Sprintf("Execution: %v", v.Execution)
-		i++
-	}
-	if v.FirstEventId != nil {
-		fields[i] = fmt.Sprintf("FirstEventId: %v", *(v.FirstEventId))
-		i++
-	}
-	if v.NextEventId != nil {
-		fields[i] = fmt.Sprintf("NextEventId: %v", *(v.NextEventId))
-		i++
-	}
-	if v.MaximumPageSize != nil {
-		fields[i] = fmt.Sprintf("MaximumPageSize: %v", *(v.MaximumPageSize))
+	if v.DomainId != nil {
+		fields[i] = fmt.Sprintf("DomainId: %v", *(v.DomainId))
 		i++
 	}
-	if v.NextPageToken != nil {
-		fields[i] = fmt.Sprintf("NextPageToken: %v", v.NextPageToken)
+	if v.WorkflowId != nil {
+		fields[i] = fmt.Sprintf("WorkflowId: %v", *(v.WorkflowId))
 		i++
 	}
 
-	return fmt.Sprintf("GetWorkflowExecutionRawHistoryRequest{%v}", strings.Join(fields[:i], ", "))
-}
-
-func _I64_EqualsPtr(lhs, rhs *int64) bool {
-	if lhs != nil && rhs != nil {
-
-		x := *lhs
-		y := *rhs
-		return (x == y)
-	}
-	return lhs == nil && rhs == nil
-}
-
-func _I32_EqualsPtr(lhs, rhs *int32) bool {
-	if lhs != nil && rhs != nil {
-
-		x := *lhs
-		y := *rhs
-		return (x == y)
-	}
-	return lhs == nil && rhs == nil
+	return fmt.Sprintf("DescribeWorkflowShardRequest{%v}", strings.Join(fields[:i], ", "))
 }
 
-// Equals returns true if all the fields of this GetWorkflowExecutionRawHistoryRequest match the
-// provided GetWorkflowExecutionRawHistoryRequest.
+// Equals returns true if all the fields of this DescribeWorkflowShardRequest match the
+// provided DescribeWorkflowShardRequest.
 //
 // This function performs a deep comparison.
-func (v *GetWorkflowExecutionRawHistoryRequest) Equals(rhs *GetWorkflowExecutionRawHistoryRequest) bool {
+func (v *DescribeWorkflowShardRequest) Equals(rhs *DescribeWorkflowShardRequest) bool {
 	if v == nil {
 		return rhs == nil
 	} else if rhs == nil {
 		return false
 	}
-	if !_String_EqualsPtr(v.Domain, rhs.Domain) {
-		return false
-	}
-	if !((v.Execution == nil && rhs.Execution == nil) || (v.Execution != nil && rhs.Execution != nil && v.Execution.Equals(rhs.Execution))) {
-		return false
-	}
-	if !_I64_EqualsPtr(v.FirstEventId, rhs.FirstEventId) {
-		return false
-	}
-	if !_I64_EqualsPtr(v.NextEventId, rhs.NextEventId) {
-		return false
-	}
-	if !_I32_EqualsPtr(v.MaximumPageSize, rhs.MaximumPageSize) {
+	if !_String_EqualsPtr(v.DomainId, rhs.DomainId) {
 		return false
 	}
-	if !((v.NextPageToken == nil && rhs.NextPageToken == nil) || (v.NextPageToken != nil && rhs.NextPageToken != nil && bytes.Equal(v.NextPageToken, rhs.NextPageToken))) {
+	if !_String_EqualsPtr(v.WorkflowId, rhs.WorkflowId) {
 		return false
 	}
 
@@ -760,197 +998,100 @@ func (v *GetWorkflowExecutionRawHistoryRequest) Equals(rhs *GetWorkflowExecution
 }
 
 // MarshalLogObject implements zapcore.ObjectMarshaler, enabling
-// fast logging of GetWorkflowExecutionRawHistoryRequest.
-func (v *GetWorkflowExecutionRawHistoryRequest) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+// fast logging of DescribeWorkflowShardRequest.
+func (v *DescribeWorkflowShardRequest) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
 	if v == nil {
 		return nil
 	}
-	if v.Domain != nil {
-		enc.AddString("domain", *v.Domain)
-	}
-	if v.Execution != nil {
-		err = multierr.Append(err, enc.AddObject("execution", v.Execution))
-	}
-	if v.FirstEventId != nil {
-		enc.AddInt64("firstEventId", *v.FirstEventId)
-	}
-	if v.NextEventId != nil {
-		enc.AddInt64("nextEventId", *v.NextEventId)
-	}
-	if v.MaximumPageSize != nil {
-		enc.AddInt32("maximumPageSize", *v.MaximumPageSize)
+	if v.DomainId != nil {
+		enc.AddString("domainId", *v.DomainId)
 	}
-	if v.NextPageToken != nil {
-		enc.AddString("nextPageToken", base64.StdEncoding.EncodeToString(v.NextPageToken))
+	if v.WorkflowId != nil {
+		enc.AddString("workflowId", *v.WorkflowId)
 	}
 	return err
 }
 
-// GetDomain returns the value of Domain if it is set or its
+// GetDomainId returns the value of DomainId if it is set or its
 // zero value if it is unset.
-func (v *GetWorkflowExecutionRawHistoryRequest) GetDomain() (o string) {
-	if v != nil && v.Domain != nil {
-		return *v.Domain
+func (v *DescribeWorkflowShardRequest) GetDomainId() (o string) {
+	if v != nil && v.DomainId != nil {
+		return *v.DomainId
 	}
 
 	return
 }
 
-// IsSetDomain returns true if Domain is not nil.
-func (v *GetWorkflowExecutionRawHistoryRequest) IsSetDomain() bool {
-	return v != nil && v.Domain != nil
+// IsSetDomainId returns true if DomainId is not nil.
+func (v *DescribeWorkflowShardRequest) IsSetDomainId() bool {
+	return v != nil && v.DomainId != nil
 }
 
-// GetExecution returns the value of Execution if it is set or its
+// GetWorkflowId returns the value of WorkflowId if it is set or its
 // zero value if it is unset.
-func (v *GetWorkflowExecutionRawHistoryRequest) GetExecution() (o *shared.WorkflowExecution) {
-	if v != nil && v.Execution != nil {
-		return v.Execution
+func (v *DescribeWorkflowShardRequest) GetWorkflowId() (o string) {
+	if v != nil && v.WorkflowId != nil {
+		return *v.WorkflowId
 	}
 
 	return
 }
 
-// IsSetExecution returns true if Execution is not nil.
-func (v *GetWorkflowExecutionRawHistoryRequest) IsSetExecution() bool {
-	return v != nil && v.Execution != nil
+// IsSetWorkflowId returns true if WorkflowId is not nil.
+func (v *DescribeWorkflowShardRequest) IsSetWorkflowId() bool {
+	return v != nil && v.WorkflowId != nil
 }
 
-// GetFirstEventId returns the value of FirstEventId if it is set or its
-// zero value if it is unset.
-func (v *GetWorkflowExecutionRawHistoryRequest) GetFirstEventId() (o int64) {
-	if v != nil && v.FirstEventId != nil {
-		return *v.FirstEventId
-	}
-
-	return
+type DescribeWorkflowShardResponse struct {
+	ShardId                 *int32           `json:"shardId,omitempty"`
+	HistoryAddr             *string          `json:"historyAddr,omitempty"`
+	ShardOwner              *string          `json:"shardOwner,omitempty"`
+	RangeId                 *int64           `json:"rangeId,omitempty"`
+	TransferAckLevel        *int64           `json:"transferAckLevel,omitempty"`
+	TimerAckLevel           *int64           `json:"timerAckLevel,omitempty"`
+	ReplicationAckLevel     *int64           `json:"replicationAckLevel,omitempty"`
+	ClusterTransferAckLevel map[string]int64 `json:"clusterTransferAckLevel,omitempty"`
+	ClusterTimerAckLevel    map[string]int64 `json:"clusterTimerAckLevel,omitempty"`
+	CurrentRunId            *string          `json:"currentRunId,omitempty"`
+	MutableStateExists      *bool            `json:"mutableStateExists,omitempty"`
 }
 
-// IsSetFirstEventId returns true if FirstEventId is not nil.
-func (v *GetWorkflowExecutionRawHistoryRequest) IsSetFirstEventId() bool {
-	return v != nil && v.FirstEventId != nil
-}
+type _Map_String_I64_MapItemList map[string]int64
 
-// GetNextEventId returns the value of NextEventId if it is set or its
-// zero value if it is unset.
-func (v *GetWorkflowExecutionRawHistoryRequest) GetNextEventId() (o int64) {
-	if v != nil && v.NextEventId != nil {
-		return *v.NextEventId
-	}
+func (m _Map_String_I64_MapItemList) ForEach(f func(wire.MapItem) error) error {
+	for k, v := range m {
+		kw, err := wire.NewValueString(k), error(nil)
+		if err != nil {
+			return err
+		}
 
-	return
+		vw, err := wire.NewValueI64(v), error(nil)
+		if err != nil {
+			return err
+		}
+		err = f(wire.MapItem{Key: kw, Value: vw})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
-// IsSetNextEventId returns true if NextEventId is not nil.
-func (v *GetWorkflowExecutionRawHistoryRequest) IsSetNextEventId() bool {
-	return v != nil && v.NextEventId != nil
+func (m _Map_String_I64_MapItemList) Size() int {
+	return len(m)
 }
 
-// GetMaximumPageSize returns the value of MaximumPageSize if it is set or its
-// zero value if it is unset.
-func (v *GetWorkflowExecutionRawHistoryRequest) GetMaximumPageSize() (o int32) {
-	if v != nil && v.MaximumPageSize != nil {
-		return *v.MaximumPageSize
-	}
-
-	return
+func (_Map_String_I64_MapItemList) KeyType() wire.Type {
+	return wire.TBinary
 }
 
-// IsSetMaximumPageSize returns true if MaximumPageSize is not nil.
-func (v *GetWorkflowExecutionRawHistoryRequest) IsSetMaximumPageSize() bool {
-	return v != nil && v.MaximumPageSize != nil
+func (_Map_String_I64_MapItemList) ValueType() wire.Type {
+	return wire.TI64
 }
 
-// GetNextPageToken returns the value of NextPageToken if it is set or its
-// zero value if it is unset.
-func (v *GetWorkflowExecutionRawHistoryRequest) GetNextPageToken() (o []byte) {
-	if v != nil && v.NextPageToken != nil {
-		return v.NextPageToken
-	}
-
-	return
-}
-
-// IsSetNextPageToken returns true if NextPageToken is not nil.
-func (v *GetWorkflowExecutionRawHistoryRequest) IsSetNextPageToken() bool {
-	return v != nil && v.NextPageToken != nil
-}
-
-type GetWorkflowExecutionRawHistoryResponse struct {
-	NextPageToken     []byte                             `json:"nextPageToken,omitempty"`
-	HistoryBatches    []*shared.DataBlob                 `json:"historyBatches,omitempty"`
-	ReplicationInfo   map[string]*shared.ReplicationInfo `json:"replicationInfo,omitempty"`
-	EventStoreVersion *int32                             `json:"eventStoreVersion,omitempty"`
-}
-
-type _List_DataBlob_ValueList []*shared.DataBlob
-
-func (v _List_DataBlob_ValueList) ForEach(f func(wire.Value) error) error {
-	for i, x := range v {
-		if x == nil {
-			return fmt.Errorf("invalid [%v]: value is nil", i)
-		}
-		w, err := x.ToWire()
-		if err != nil {
-			return err
-		}
-		err = f(w)
-		if err != nil {
-			return err
-		}
-	}
-	return nil
-}
-
-func (v _List_DataBlob_ValueList) Size() int {
-	return len(v)
-}
-
-func (_List_DataBlob_ValueList) ValueType() wire.Type {
-	return wire.TStruct
-}
-
-func (_List_DataBlob_ValueList) Close() {}
-
-type _Map_String_ReplicationInfo_MapItemList map[string]*shared.ReplicationInfo
-
-func (m _Map_String_ReplicationInfo_MapItemList) ForEach(f func(wire.MapItem) error) error {
-	for k, v := range m {
-		if v == nil {
-			return fmt.Errorf("invalid [%v]: value is nil", k)
-		}
-		kw, err := wire.NewValueString(k), error(nil)
-		if err != nil {
-			return err
-		}
-
-		vw, err := v.ToWire()
-		if err != nil {
-			return err
-		}
-		err = f(wire.MapItem{Key: kw, Value: vw})
-		if err != nil {
-			return err
-		}
-	}
-	return nil
-}
-
-func (m _Map_String_ReplicationInfo_MapItemList) Size() int {
-	return len(m)
-}
-
-func (_Map_String_ReplicationInfo_MapItemList) KeyType() wire.Type {
-	return wire.TBinary
-}
-
-func (_Map_String_ReplicationInfo_MapItemList) ValueType() wire.Type {
-	return wire.TStruct
-}
-
-func (_Map_String_ReplicationInfo_MapItemList) Close() {}
+func (_Map_String_I64_MapItemList) Close() {}
 
-// ToWire translates a GetWorkflowExecutionRawHistoryResponse struct into a Thrift-level intermediate
+// ToWire translates a DescribeWorkflowShardResponse struct into a Thrift-level intermediate
 // representation. This intermediate representation may be serialized
 // into bytes using a ThriftRW protocol implementation.
 //
@@ -965,97 +1106,123 @@ func (_Map_String_ReplicationInfo_MapItemList) Close() {}
 //   if err := binaryProtocol.Encode(x, writer); err != nil {
 //     return err
 //   }
-func (v *GetWorkflowExecutionRawHistoryResponse) ToWire() (wire.Value, error) {
+func (v *DescribeWorkflowShardResponse) ToWire() (wire.Value, error) {
 	var (
-		fields [4]wire.Field
+		fields [11]wire.Field
 		i      int = 0
 		w      wire.Value
 		err    error
 	)
 
-	if v.NextPageToken != nil {
-		w, err = wire.NewValueBinary(v.NextPageToken), error(nil)
+	if v.ShardId != nil {
+		w, err = wire.NewValueI32(*(v.ShardId)), error(nil)
 		if err != nil {
 			return w, err
 		}
 		fields[i] = wire.Field{ID: 10, Value: w}
 		i++
 	}
-	if v.HistoryBatches != nil {
-		w, err = wire.NewValueList(_List_DataBlob_ValueList(v.HistoryBatches)), error(nil)
+	if v.HistoryAddr != nil {
+		w, err = wire.NewValueString(*(v.HistoryAddr)), error(nil)
 		if err != nil {
 			return w, err
 		}
 		fields[i] = wire.Field{ID: 20, Value: w}
 		i++
 	}
-	if v.ReplicationInfo != nil {
-		w, err = wire.NewValueMap(_Map_String_ReplicationInfo_MapItemList(v.ReplicationInfo)), error(nil)
+	if v.ShardOwner != nil {
+		w, err = wire.NewValueString(*(v.ShardOwner)), error(nil)
 		if err != nil {
 			return w, err
 		}
 		fields[i] = wire.Field{ID: 30, Value: w}
 		i++
 	}
-	if v.EventStoreVersion != nil {
-		w, err = wire.NewValueI32(*(v.EventStoreVersion)), error(nil)
+	if v.RangeId != nil {
+		w, err = wire.NewValueI64(*(v.RangeId)), error(nil)
 		if err != nil {
 			return w, err
 		}
 		fields[i] = wire.Field{ID: 40, Value: w}
 		i++
 	}
-
-	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
-}
-
-func _DataBlob_Read(w wire.Value) (*shared.DataBlob, error) {
-	var v shared.DataBlob
-	err := v.FromWire(w)
-	return &v, err
-}
-
-func _List_DataBlob_Read(l wire.ValueList) ([]*shared.DataBlob, error) {
-	if l.ValueType() != wire.TStruct {
-		return nil, nil
+	if v.TransferAckLevel != nil {
+		w, err = wire.NewValueI64(*(v.TransferAckLevel)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 50, Value: w}
+		i++
 	}
-
-	o := make([]*shared.DataBlob, 0, l.Size())
-	err := l.ForEach(func(x wire.Value) error {
-		i, err := _DataBlob_Read(x)
+	if v.TimerAckLevel != nil {
+		w, err = wire.NewValueI64(*(v.TimerAckLevel)), error(nil)
 		if err != nil {
-			return err
+			return w, err
 		}
-		o = append(o, i)
-		return nil
-	})
-	l.Close()
-	return o, err
-}
+		fields[i] = wire.Field{ID: 60, Value: w}
+		i++
+	}
+	if v.ReplicationAckLevel != nil {
+		w, err = wire.NewValueI64(*(v.ReplicationAckLevel)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 70, Value: w}
+		i++
+	}
+	if v.ClusterTransferAckLevel != nil {
+		w, err = wire.NewValueMap(_Map_String_I64_MapItemList(v.ClusterTransferAckLevel)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 80, Value: w}
+		i++
+	}
+	if v.ClusterTimerAckLevel != nil {
+		w, err = wire.NewValueMap(_Map_String_I64_MapItemList(v.ClusterTimerAckLevel)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 90, Value: w}
+		i++
+	}
+	if v.CurrentRunId != nil {
+		w, err = wire.NewValueString(*(v.CurrentRunId)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 100, Value: w}
+		i++
+	}
+	if v.MutableStateExists != nil {
+		w, err = wire.NewValueBool(*(v.MutableStateExists)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 110, Value: w}
+		i++
+	}
 
-func _ReplicationInfo_Read(w wire.Value) (*shared.ReplicationInfo, error) {
-	var v shared.ReplicationInfo
-	err := v.FromWire(w)
-	return &v, err
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
 
-func _Map_String_ReplicationInfo_Read(m wire.MapItemList) (map[string]*shared.ReplicationInfo, error) {
+func _Map_String_I64_Read(m wire.MapItemList) (map[string]int64, error) {
 	if m.KeyType() != wire.TBinary {
 		return nil, nil
 	}
 
-	if m.ValueType() != wire.TStruct {
+	if m.ValueType() != wire.TI64 {
 		return nil, nil
 	}
 
-	o := make(map[string]*shared.ReplicationInfo, m.Size())
+	o := make(map[string]int64, m.Size())
 	err := m.ForEach(func(x wire.MapItem) error {
 		k, err := x.Key.GetString(), error(nil)
 		if err != nil {
 			return err
 		}
 
-		v, err := _ReplicationInfo_Read(x.Value)
+		v, err := x.Value.GetI64(), error(nil)
 		if err != nil {
 			return err
 		}
@@ -1067,11 +1234,11 @@ func _Map_String_ReplicationInfo_Read(m wire.MapItemList) (map[string]*shared.Re
 	return o, err
 }
 
-// FromWire deserializes a GetWorkflowExecutionRawHistoryResponse struct from its Thrift-level
+// FromWire deserializes a DescribeWorkflowShardResponse struct from its Thrift-level
 // representation. The Thrift-level representation may be obtained
 // from a ThriftRW protocol implementation.
 //
-// An error is returned if we were unable to build a GetWorkflowExecutionRawHistoryResponse struct
+// An error is returned if we were unable to build a DescribeWorkflowShardResponse struct
 // from the provided intermediate representation.
 //
 //   x, err := binaryProtocol.Decode(reader, wire.TStruct)
@@ -1079,45 +1246,117 @@ func _Map_String_ReplicationInfo_Read(m wire.MapItemList) (map[string]*shared.Re
 //     return nil, err
 //   }
 //
-//   var v GetWorkflowExecutionRawHistoryResponse
+//   var v DescribeWorkflowShardResponse
 //   if err := v.FromWire(x); err != nil {
 //     return nil, err
 //   }
 //   return &v, nil
-func (v *GetWorkflowExecutionRawHistoryResponse) FromWire(w wire.Value) error {
+func (v *DescribeWorkflowShardResponse) FromWire(w wire.Value) error {
 	var err error
 
 	for _, field := range w.GetStruct().Fields {
 		switch field.ID {
 		case 10:
-			if field.Value.Type() == wire.TBinary {
-				v.NextPageToken, err = field.Value.GetBinary(), error(nil)
+			if field.Value.Type() == wire.TI32 {
+				var x int32
+				x, err = field.Value.GetI32(), error(nil)
+				v.ShardId = &x
 				if err != nil {
 					return err
 				}
 
 			}
 		case 20:
-			if field.Value.Type() == wire.TList {
-				v.HistoryBatches, err = _List_DataBlob_Read(field.Value.GetList())
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.HistoryAddr = &x
 				if err != nil {
 					return err
 				}
 
 			}
 		case 30:
-			if field.Value.Type() == wire.TMap {
-				v.ReplicationInfo, err = _Map_String_ReplicationInfo_Read(field.Value.GetMap())
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.ShardOwner = &x
 				if err != nil {
 					return err
 				}
 
 			}
 		case 40:
-			if field.Value.Type() == wire.TI32 {
-				var x int32
-				x, err = field.Value.GetI32(), error(nil)
-				v.EventStoreVersion = &x
+			if field.Value.Type() == wire.TI64 {
+				var x int64
+				x, err = field.Value.GetI64(), error(nil)
+				v.RangeId = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 50:
+			if field.Value.Type() == wire.TI64 {
+				var x int64
+				x, err = field.Value.GetI64(), error(nil)
+				v.TransferAckLevel = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 60:
+			if field.Value.Type() == wire.TI64 {
+				var x int64
+				x, err = field.Value.GetI64(), error(nil)
+				v.TimerAckLevel = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 70:
+			if field.Value.Type() == wire.TI64 {
+				var x int64
+				x, err = field.Value.GetI64(), error(nil)
+				v.ReplicationAckLevel = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 80:
+			if field.Value.Type() == wire.TMap {
+				v.ClusterTransferAckLevel, err = _Map_String_I64_Read(field.Value.GetMap())
+				if err != nil {
+					return err
+				}
+
+			}
+		case 90:
+			if field.Value.Type() == wire.TMap {
+				v.ClusterTimerAckLevel, err = _Map_String_I64_Read(field.Value.GetMap())
+				if err != nil {
+					return err
+				}
+
+			}
+		case 100:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.CurrentRunId = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 110:
+			if field.Value.Type() == wire.TBool {
+				var x bool
+				x, err = field.Value.GetBool(), error(nil)
+				v.MutableStateExists = &x
 				if err != nil {
 					return err
 				}
@@ -1129,218 +1368,17163 @@ func (v *GetWorkflowExecutionRawHistoryResponse) FromWire(w wire.Value) error {
 	return nil
 }
 
-// String returns a readable string representation of a GetWorkflowExecutionRawHistoryResponse
+// String returns a readable string representation of a DescribeWorkflowShardResponse
 // struct.
-func (v *GetWorkflowExecutionRawHistoryResponse) String() string {
+func (v *DescribeWorkflowShardResponse) String() string {
 	if v == nil {
 		return "<nil>"
 	}
 
-	var fields [4]string
+	var fields [11]string
 	i := 0
-	if v.NextPageToken != nil {
-		fields[i] = fmt.Sprintf("NextPageToken: %v", v.NextPageToken)
+	if v.ShardId != nil {
+		fields[i] = fmt.Sprintf("ShardId: %v", *(v.ShardId))
 		i++
 	}
-	if v.HistoryBatches != nil {
-		fields[i] = fmt.Sprintf("HistoryBatches: %v", v.HistoryBatches)
+	if v.HistoryAddr != nil {
+		fields[i] = fmt.Sprintf("HistoryAddr: %v", *(v.HistoryAddr))
 		i++
 	}
-	if v.ReplicationInfo != nil {
-		fields[i] = fmt.Sprintf("ReplicationInfo: %v", v.ReplicationInfo)
+	if v.ShardOwner != nil {
+		fields[i] = fmt.Sprintf("ShardOwner: %v", *(v.ShardOwner))
 		i++
 	}
-	if v.EventStoreVersion != nil {
-		fields[i] = fmt.Sprintf("EventStoreVersion: %v", *(v.EventStoreVersion))
+	if v.RangeId != nil {
+		fields[i] = fmt.Sprintf("RangeId: %v", *(v.RangeId))
+		i++
+	}
+	if v.TransferAckLevel != nil {
+		fields[i] = fmt.Sprintf("TransferAckLevel: %v", *(v.TransferAckLevel))
+		i++
+	}
+	if v.TimerAckLevel != nil {
+		fields[i] = fmt.Sprintf("TimerAckLevel: %v", *(v.TimerAckLevel))
+		i++
+	}
+	if v.ReplicationAckLevel != nil {
+		fields[i] = fmt.Sprintf("ReplicationAckLevel: %v", *(v.ReplicationAckLevel))
+		i++
+	}
+	if v.ClusterTransferAckLevel != nil {
+		fields[i] = fmt.Sprintf("ClusterTransferAckLevel: %v", v.ClusterTransferAckLevel)
+		i++
+	}
+	if v.ClusterTimerAckLevel != nil {
+		fields[i] = fmt.Sprintf("ClusterTimerAckLevel: %v", v.ClusterTimerAckLevel)
+		i++
+	}
+	if v.CurrentRunId != nil {
+		fields[i] = fmt.Sprintf("CurrentRunId: %v", *(v.CurrentRunId))
+		i++
+	}
+	if v.MutableStateExists != nil {
+		fields[i] = fmt.Sprintf("MutableStateExists: %v", *(v.MutableStateExists))
 		i++
 	}
 
-	return fmt.Sprintf("GetWorkflowExecutionRawHistoryResponse{%v}", strings.Join(fields[:i], ", "))
+	return fmt.Sprintf("DescribeWorkflowShardResponse{%v}", strings.Join(fields[:i], ", "))
 }
 
-func _List_DataBlob_Equals(lhs, rhs []*shared.DataBlob) bool {
+func _Map_String_I64_Equals(lhs, rhs map[string]int64) bool {
 	if len(lhs) != len(rhs) {
 		return false
 	}
 
-	for i, lv := range lhs {
-		rv := rhs[i]
-		if !lv.Equals(rv) {
+	for lk, lv := range lhs {
+		rv, ok := rhs[lk]
+		if !ok {
+			return false
+		}
+		if !(lv == rv) {
 			return false
 		}
 	}
-
 	return true
 }
 
-func _Map_String_ReplicationInfo_Equals(lhs, rhs map[string]*shared.ReplicationInfo) bool {
-	if len(lhs) != len(rhs) {
+func _Bool_EqualsPtr(lhs, rhs *bool) bool {
+	if lhs != nil && rhs != nil {
+
+		x := *lhs
+		y := *rhs
+		return (x == y)
+	}
+	return lhs == nil && rhs == nil
+}
+
+// Equals returns true if all the fields of this DescribeWorkflowShardResponse match the
+// provided DescribeWorkflowShardResponse.
+//
+// This function performs a deep comparison.
+func (v *DescribeWorkflowShardResponse) Equals(rhs *DescribeWorkflowShardResponse) bool {
+	if v == nil {
+		return rhs == nil
+	} else if rhs == nil {
+		return false
+	}
+	if !_I32_EqualsPtr(v.ShardId, rhs.ShardId) {
+		return false
+	}
+	if !_String_EqualsPtr(v.HistoryAddr, rhs.HistoryAddr) {
+		return false
+	}
+	if !_String_EqualsPtr(v.ShardOwner, rhs.ShardOwner) {
+		return false
+	}
+	if !_I64_EqualsPtr(v.RangeId, rhs.RangeId) {
+		return false
+	}
+	if !_I64_EqualsPtr(v.TransferAckLevel, rhs.TransferAckLevel) {
+		return false
+	}
+	if !_I64_EqualsPtr(v.TimerAckLevel, rhs.TimerAckLevel) {
+		return false
+	}
+	if !_I64_EqualsPtr(v.ReplicationAckLevel, rhs.ReplicationAckLevel) {
+		return false
+	}
+	if !((v.ClusterTransferAckLevel == nil && rhs.ClusterTransferAckLevel == nil) || (v.ClusterTransferAckLevel != nil && rhs.ClusterTransferAckLevel != nil && _Map_String_I64_Equals(v.ClusterTransferAckLevel, rhs.ClusterTransferAckLevel))) {
+		return false
+	}
+	if !((v.ClusterTimerAckLevel == nil && rhs.ClusterTimerAckLevel == nil) || (v.ClusterTimerAckLevel != nil && rhs.ClusterTimerAckLevel != nil && _Map_String_I64_Equals(v.ClusterTimerAckLevel, rhs.ClusterTimerAckLevel))) {
+		return false
+	}
+	if !_String_EqualsPtr(v.CurrentRunId, rhs.CurrentRunId) {
 		return false
 	}
+	if !_Bool_EqualsPtr(v.MutableStateExists, rhs.MutableStateExists) {
+		return false
+	}
+
+	return true
+}
+
+type _Map_String_I64_Zapper map[string]int64
+
+// MarshalLogObject implements zapcore.ObjectMarshaler, enabling
+// fast logging of _Map_String_I64_Zapper.
+func (m _Map_String_I64_Zapper) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+	for k, v := range m {
+		enc.AddInt64((string)(k), v)
+	}
+	return err
+}
+
+// MarshalLogObject implements zapcore.ObjectMarshaler, enabling
+// fast logging of DescribeWorkflowShardResponse.
+func (v *DescribeWorkflowShardResponse) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+	if v == nil {
+		return nil
+	}
+	if v.ShardId != nil {
+		enc.AddInt32("shardId", *v.ShardId)
+	}
+	if v.HistoryAddr != nil {
+		enc.AddString("historyAddr", *v.HistoryAddr)
+	}
+	if v.ShardOwner != nil {
+		enc.AddString("shardOwner", *v.ShardOwner)
+	}
+	if v.RangeId != nil {
+		enc.AddInt64("rangeId", *v.RangeId)
+	}
+	if v.TransferAckLevel != nil {
+		enc.AddInt64("transferAckLevel", *v.TransferAckLevel)
+	}
+	if v.TimerAckLevel != nil {
+		enc.AddInt64("timerAckLevel", *v.TimerAckLevel)
+	}
+	if v.ReplicationAckLevel != nil {
+		enc.AddInt64("replicationAckLevel", *v.ReplicationAckLevel)
+	}
+	if v.ClusterTransferAckLevel != nil {
+		err = multierr.Append(err, enc.AddObject("clusterTransferAckLevel", (_Map_String_I64_Zapper)(v.ClusterTransferAckLevel)))
+	}
+	if v.ClusterTimerAckLevel != nil {
+		err = multierr.Append(err, enc.AddObject("clusterTimerAckLevel", (_Map_String_I64_Zapper)(v.ClusterTimerAckLevel)))
+	}
+	if v.CurrentRunId != nil {
+		enc.AddString("currentRunId", *v.CurrentRunId)
+	}
+	if v.MutableStateExists != nil {
+		enc.AddBool("mutableStateExists", *v.MutableStateExists)
+	}
+	return err
+}
+
+// GetShardId returns the value of ShardId if it is set or its
+// zero value if it is unset.
+func (v *DescribeWorkflowShardResponse) GetShardId() (o int32) {
+	if v != nil && v.ShardId != nil {
+		return *v.ShardId
+	}
+
+	return
+}
+
+// IsSetShardId returns true if ShardId is not nil.
+func (v *DescribeWorkflowShardResponse) IsSetShardId() bool {
+	return v != nil && v.ShardId != nil
+}
+
+// GetHistoryAddr returns the value of HistoryAddr if it is set or its
+// zero value if it is unset.
+func (v *DescribeWorkflowShardResponse) GetHistoryAddr() (o string) {
+	if v != nil && v.HistoryAddr != nil {
+		return *v.HistoryAddr
+	}
+
+	return
+}
+
+// IsSetHistoryAddr returns true if HistoryAddr is not nil.
+func (v *DescribeWorkflowShardResponse) IsSetHistoryAddr() bool {
+	return v != nil && v.HistoryAddr != nil
+}
+
+// GetShardOwner returns the value of ShardOwner if it is set or its
+// zero value if it is unset.
+func (v *DescribeWorkflowShardResponse) GetShardOwner() (o string) {
+	if v != nil && v.ShardOwner != nil {
+		return *v.ShardOwner
+	}
+
+	return
+}
+
+// IsSetShardOwner returns true if ShardOwner is not nil.
+func (v *DescribeWorkflowShardResponse) IsSetShardOwner() bool {
+	return v != nil && v.ShardOwner != nil
+}
+
+// GetRangeId returns the value of RangeId if it is set or its
+// zero value if it is unset.
+func (v *DescribeWorkflowShardResponse) GetRangeId() (o int64) {
+	if v != nil && v.RangeId != nil {
+		return *v.RangeId
+	}
+
+	return
+}
+
+// IsSetRangeId returns true if RangeId is not nil.
+func (v *DescribeWorkflowShardResponse) IsSetRangeId() bool {
+	return v != nil && v.RangeId != nil
+}
+
+// GetTransferAckLevel returns the value of TransferAckLevel if it is set or its
+// zero value if it is unset.
+func (v *DescribeWorkflowShardResponse) GetTransferAckLevel() (o int64) {
+	if v != nil && v.TransferAckLevel != nil {
+		return *v.TransferAckLevel
+	}
+
+	return
+}
+
+// IsSetTransferAckLevel returns true if TransferAckLevel is not nil.
+func (v *DescribeWorkflowShardResponse) IsSetTransferAckLevel() bool {
+	return v != nil && v.TransferAckLevel != nil
+}
+
+// GetTimerAckLevel returns the value of TimerAckLevel if it is set or its
+// zero value if it is unset.
+func (v *DescribeWorkflowShardResponse) GetTimerAckLevel() (o int64) {
+	if v != nil && v.TimerAckLevel != nil {
+		return *v.TimerAckLevel
+	}
+
+	return
+}
+
+// IsSetTimerAckLevel returns true if TimerAckLevel is not nil.
+func (v *DescribeWorkflowShardResponse) IsSetTimerAckLevel() bool {
+	return v != nil && v.TimerAckLevel != nil
+}
+
+// GetReplicationAckLevel returns the value of ReplicationAckLevel if it is set or its
+// zero value if it is unset.
+func (v *DescribeWorkflowShardResponse) GetReplicationAckLevel() (o int64) {
+	if v != nil && v.ReplicationAckLevel != nil {
+		return *v.ReplicationAckLevel
+	}
+
+	return
+}
+
+// IsSetReplicationAckLevel returns true if ReplicationAckLevel is not nil.
+func (v *DescribeWorkflowShardResponse) IsSetReplicationAckLevel() bool {
+	return v != nil && v.ReplicationAckLevel != nil
+}
+
+// GetClusterTransferAckLevel returns the value of ClusterTransferAckLevel if it is set or its
+// zero value if it is unset.
+func (v *DescribeWorkflowShardResponse) GetClusterTransferAckLevel() (o map[string]int64) {
+	if v != nil && v.ClusterTransferAckLevel != nil {
+		return v.ClusterTransferAckLevel
+	}
+
+	return
+}
+
+// IsSetClusterTransferAckLevel returns true if ClusterTransferAckLevel is not nil.
+func (v *DescribeWorkflowShardResponse) IsSetClusterTransferAckLevel() bool {
+	return v != nil && v.ClusterTransferAckLevel != nil
+}
+
+// GetClusterTimerAckLevel returns the value of ClusterTimerAckLevel if it is set or its
+// zero value if it is unset.
+func (v *DescribeWorkflowShardResponse) GetClusterTimerAckLevel() (o map[string]int64) {
+	if v != nil && v.ClusterTimerAckLevel != nil {
+		return v.ClusterTimerAckLevel
+	}
+
+	return
+}
+
+// IsSetClusterTimerAckLevel returns true if ClusterTimerAckLevel is not nil.
+func (v *DescribeWorkflowShardResponse) IsSetClusterTimerAckLevel() bool {
+	return v != nil && v.ClusterTimerAckLevel != nil
+}
+
+// GetCurrentRunId returns the value of CurrentRunId if it is set or its
+// zero value if it is unset.
+func (v *DescribeWorkflowShardResponse) GetCurrentRunId() (o string) {
+	if v != nil && v.CurrentRunId != nil {
+		return *v.CurrentRunId
+	}
+
+	return
+}
+
+// IsSetCurrentRunId returns true if CurrentRunId is not nil.
+func (v *DescribeWorkflowShardResponse) IsSetCurrentRunId() bool {
+	return v != nil && v.CurrentRunId != nil
+}
+
+// GetMutableStateExists returns the value of MutableStateExists if it is set or its
+// zero value if it is unset.
+func (v *DescribeWorkflowShardResponse) GetMutableStateExists() (o bool) {
+	if v != nil && v.MutableStateExists != nil {
+		return *v.MutableStateExists
+	}
+
+	return
+}
+
+// IsSetMutableStateExists returns true if MutableStateExists is not nil.
+func (v *DescribeWorkflowShardResponse) IsSetMutableStateExists() bool {
+	return v != nil && v.MutableStateExists != nil
+}
+
+type GetWorkflowExecutionRawHistoryRequest struct {
+	Domain          *string                   `json:"domain,omitempty"`
+	Execution       *shared.WorkflowExecution `json:"execution,omitempty"`
+	FirstEventId    *int64                    `json:"firstEventId,omitempty"`
+	NextEventId     *int64                    `json:"nextEventId,omitempty"`
+	MaximumPageSize *int32                    `json:"maximumPageSize,omitempty"`
+	NextPageToken   []byte                    `json:"nextPageToken,omitempty"`
+}
+
+// ToWire translates a GetWorkflowExecutionRawHistoryRequest struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *GetWorkflowExecutionRawHistoryRequest) ToWire() (wire.Value, error) {
+	var (
+		fields [6]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.Domain != nil {
+		w, err = wire.NewValueString(*(v.Domain)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 10, Value: w}
+		i++
+	}
+	if v.Execution != nil {
+		w, err = v.Execution.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 20, Value: w}
+		i++
+	}
+	if v.FirstEventId != nil {
+		w, err = wire.NewValueI64(*(v.FirstEventId)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 30, Value: w}
+		i++
+	}
+	if v.NextEventId != nil {
+		w, err = wire.NewValueI64(*(v.NextEventId)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 40, Value: w}
+		i++
+	}
+	if v.MaximumPageSize != nil {
+		w, err = wire.NewValueI32(*(v.MaximumPageSize)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 50, Value: w}
+		i++
+	}
+	if v.NextPageToken != nil {
+		w, err = wire.NewValueBinary(v.NextPageToken), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 60, Value: w}
+		i++
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+// FromWire deserializes a GetWorkflowExecutionRawHistoryRequest struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a GetWorkflowExecutionRawHistoryRequest struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v GetWorkflowExecutionRawHistoryRequest
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *GetWorkflowExecutionRawHistoryRequest) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 10:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.Domain = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 20:
+			if field.Value.Type() == wire.TStruct {
+				v.Execution, err = _WorkflowExecution_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 30:
+			if field.Value.Type() == wire.TI64 {
+				var x int64
+				x, err = field.Value.GetI64(), error(nil)
+				v.FirstEventId = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 40:
+			if field.Value.Type() == wire.TI64 {
+				var x int64
+				x, err = field.Value.GetI64(), error(nil)
+				v.NextEventId = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 50:
+			if field.Value.Type() == wire.TI32 {
+				var x int32
+				x, err = field.Value.GetI32(), error(nil)
+				v.MaximumPageSize = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 60:
+			if field.Value.Type() == wire.TBinary {
+				v.NextPageToken, err = field.Value.GetBinary(), error(nil)
+				if err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+
+	return nil
+}
+
+// String returns a readable string representation of a GetWorkflowExecutionRawHistoryRequest
+// struct.
+func (v *GetWorkflowExecutionRawHistoryRequest) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [6]string
+	i := 0
+	if v.Domain != nil {
+		fields[i] = fmt.Sprintf("Domain: %v", *(v.Domain))
+		i++
+	}
+	if v.Execution != nil {
+		fields[i] = fmt.Sprintf("Execution: %v", v.Execution)
+		i++
+	}
+	if v.FirstEventId != nil {
+		fields[i] = fmt.Sprintf("FirstEventId: %v", *(v.FirstEventId))
+		i++
+	}
+	if v.NextEventId != nil {
+		fields[i] = fmt.Sprintf("NextEventId: %v", *(v.NextEventId))
+		i++
+	}
+	if v.MaximumPageSize != nil {
+		fields[i] = fmt.Sprintf("MaximumPageSize: %v", *(v.MaximumPageSize))
+		i++
+	}
+	if v.NextPageToken != nil {
+		fields[i] = fmt.Sprintf("NextPageToken: %v", v.NextPageToken)
+		i++
+	}
+
+	return fmt.Sprintf("GetWorkflowExecutionRawHistoryRequest{%v}", strings.Join(fields[:i], ", "))
+}
+
+func _I64_EqualsPtr(lhs, rhs *int64) bool {
+	if lhs != nil && rhs != nil {
+
+		x := *lhs
+		y := *rhs
+		return (x == y)
+	}
+	return lhs == nil && rhs == nil
+}
+
+func _I32_EqualsPtr(lhs, rhs *int32) bool {
+	if lhs != nil && rhs != nil {
+
+		x := *lhs
+		y := *rhs
+		return (x == y)
+	}
+	return lhs == nil && rhs == nil
+}
+
+// Equals returns true if all the fields of this GetWorkflowExecutionRawHistoryRequest match the
+// provided GetWorkflowExecutionRawHistoryRequest.
+//
+// This function performs a deep comparison.
+func (v *GetWorkflowExecutionRawHistoryRequest) Equals(rhs *GetWorkflowExecutionRawHistoryRequest) bool {
+	if v == nil {
+		return rhs == nil
+	} else if rhs == nil {
+		return false
+	}
+	if !_String_EqualsPtr(v.Domain, rhs.Domain) {
+		return false
+	}
+	if !((v.Execution == nil && rhs.Execution == nil) || (v.Execution != nil && rhs.Execution != nil && v.Execution.Equals(rhs.Execution))) {
+		return false
+	}
+	if !_I64_EqualsPtr(v.FirstEventId, rhs.FirstEventId) {
+		return false
+	}
+	if !_I64_EqualsPtr(v.NextEventId, rhs.NextEventId) {
+		return false
+	}
+	if !_I32_EqualsPtr(v.MaximumPageSize, rhs.MaximumPageSize) {
+		return false
+	}
+	if !((v.NextPageToken == nil && rhs.NextPageToken == nil) || (v.NextPageToken != nil && rhs.NextPageToken != nil && bytes.Equal(v.NextPageToken, rhs.NextPageToken))) {
+		return false
+	}
+
+	return true
+}
+
+// MarshalLogObject implements zapcore.ObjectMarshaler, enabling
+// fast logging of GetWorkflowExecutionRawHistoryRequest.
+func (v *GetWorkflowExecutionRawHistoryRequest) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+	if v == nil {
+		return nil
+	}
+	if v.Domain != nil {
+		enc.AddString("domain", *v.Domain)
+	}
+	if v.Execution != nil {
+		err = multierr.Append(err, enc.AddObject("execution", v.Execution))
+	}
+	if v.FirstEventId != nil {
+		enc.AddInt64("firstEventId", *v.FirstEventId)
+	}
+	if v.NextEventId != nil {
+		enc.AddInt64("nextEventId", *v.NextEventId)
+	}
+	if v.MaximumPageSize != nil {
+		enc.AddInt32("maximumPageSize", *v.MaximumPageSize)
+	}
+	if v.NextPageToken != nil {
+		enc.AddString("nextPageToken", base64.StdEncoding.EncodeToString(v.NextPageToken))
+	}
+	return err
+}
+
+// GetDomain returns the value of Domain if it is set or its
+// zero value if it is unset.
+func (v *GetWorkflowExecutionRawHistoryRequest) GetDomain() (o string) {
+	if v != nil && v.Domain != nil {
+		return *v.Domain
+	}
+
+	return
+}
+
+// IsSetDomain returns true if Domain is not nil.
+func (v *GetWorkflowExecutionRawHistoryRequest) IsSetDomain() bool {
+	return v != nil && v.Domain != nil
+}
+
+// GetExecution returns the value of Execution if it is set or its
+// zero value if it is unset.
+func (v *GetWorkflowExecutionRawHistoryRequest) GetExecution() (o *shared.WorkflowExecution) {
+	if v != nil && v.Execution != nil {
+		return v.Execution
+	}
+
+	return
+}
+
+// IsSetExecution returns true if Execution is not nil.
+func (v *GetWorkflowExecutionRawHistoryRequest) IsSetExecution() bool {
+	return v != nil && v.Execution != nil
+}
+
+// GetFirstEventId returns the value of FirstEventId if it is set or its
+// zero value if it is unset.
+func (v *GetWorkflowExecutionRawHistoryRequest) GetFirstEventId() (o int64) {
+	if v != nil && v.FirstEventId != nil {
+		return *v.FirstEventId
+	}
+
+	return
+}
+
+// IsSetFirstEventId returns true if FirstEventId is not nil.
+func (v *GetWorkflowExecutionRawHistoryRequest) IsSetFirstEventId() bool {
+	return v != nil && v.FirstEventId != nil
+}
+
+// GetNextEventId returns the value of NextEventId if it is set or its
+// zero value if it is unset.
+func (v *GetWorkflowExecutionRawHistoryRequest) GetNextEventId() (o int64) {
+	if v != nil && v.NextEventId != nil {
+		return *v.NextEventId
+	}
+
+	return
+}
+
+// IsSetNextEventId returns true if NextEventId is not nil.
+func (v *GetWorkflowExecutionRawHistoryRequest) IsSetNextEventId() bool {
+	return v != nil && v.NextEventId != nil
+}
+
+// GetMaximumPageSize returns the value of MaximumPageSize if it is set or its
+// zero value if it is unset.
+func (v *GetWorkflowExecutionRawHistoryRequest) GetMaximumPageSize() (o int32) {
+	if v != nil && v.MaximumPageSize != nil {
+		return *v.MaximumPageSize
+	}
+
+	return
+}
+
+// IsSetMaximumPageSize returns true if MaximumPageSize is not nil.
+func (v *GetWorkflowExecutionRawHistoryRequest) IsSetMaximumPageSize() bool {
+	return v != nil && v.MaximumPageSize != nil
+}
+
+// GetNextPageToken returns the value of NextPageToken if it is set or its
+// zero value if it is unset.
+func (v *GetWorkflowExecutionRawHistoryRequest) GetNextPageToken() (o []byte) {
+	if v != nil && v.NextPageToken != nil {
+		return v.NextPageToken
+	}
+
+	return
+}
+
+// IsSetNextPageToken returns true if NextPageToken is not nil.
+func (v *GetWorkflowExecutionRawHistoryRequest) IsSetNextPageToken() bool {
+	return v != nil && v.NextPageToken != nil
+}
+
+type GetWorkflowExecutionRawHistoryResponse struct {
+	NextPageToken     []byte                             `json:"nextPageToken,omitempty"`
+	HistoryBatches    []*shared.DataBlob                 `json:"historyBatches,omitempty"`
+	ReplicationInfo   map[string]*shared.ReplicationInfo `json:"replicationInfo,omitempty"`
+	EventStoreVersion *int32                             `json:"eventStoreVersion,omitempty"`
+}
+
+type _List_DataBlob_ValueList []*shared.DataBlob
+
+func (v _List_DataBlob_ValueList) ForEach(f func(wire.Value) error) error {
+	for i, x := range v {
+		if x == nil {
+			return fmt.Errorf("invalid [%v]: value is nil", i)
+		}
+		w, err := x.ToWire()
+		if err != nil {
+			return err
+		}
+		err = f(w)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (v _List_DataBlob_ValueList) Size() int {
+	return len(v)
+}
+
+func (_List_DataBlob_ValueList) ValueType() wire.Type {
+	return wire.TStruct
+}
+
+func (_List_DataBlob_ValueList) Close() {}
+
+type _Map_String_ReplicationInfo_MapItemList map[string]*shared.ReplicationInfo
+
+func (m _Map_String_ReplicationInfo_MapItemList) ForEach(f func(wire.MapItem) error) error {
+	for k, v := range m {
+		if v == nil {
+			return fmt.Errorf("invalid [%v]: value is nil", k)
+		}
+		kw, err := wire.NewValueString(k), error(nil)
+		if err != nil {
+			return err
+		}
+
+		vw, err := v.ToWire()
+		if err != nil {
+			return err
+		}
+		err = f(wire.MapItem{Key: kw, Value: vw})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m _Map_String_ReplicationInfo_MapItemList) Size() int {
+	return len(m)
+}
+
+func (_Map_String_ReplicationInfo_MapItemList) KeyType() wire.Type {
+	return wire.TBinary
+}
+
+func (_Map_String_ReplicationInfo_MapItemList) ValueType() wire.Type {
+	return wire.TStruct
+}
+
+func (_Map_String_ReplicationInfo_MapItemList) Close() {}
+
+// ToWire translates a GetWorkflowExecutionRawHistoryResponse struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *GetWorkflowExecutionRawHistoryResponse) ToWire() (wire.Value, error) {
+	var (
+		fields [4]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.NextPageToken != nil {
+		w, err = wire.NewValueBinary(v.NextPageToken), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 10, Value: w}
+		i++
+	}
+	if v.HistoryBatches != nil {
+		w, err = wire.NewValueList(_List_DataBlob_ValueList(v.HistoryBatches)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 20, Value: w}
+		i++
+	}
+	if v.ReplicationInfo != nil {
+		w, err = wire.NewValueMap(_Map_String_ReplicationInfo_MapItemList(v.ReplicationInfo)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 30, Value: w}
+		i++
+	}
+	if v.EventStoreVersion != nil {
+		w, err = wire.NewValueI32(*(v.EventStoreVersion)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 40, Value: w}
+		i++
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+func _DataBlob_Read(w wire.Value) (*shared.DataBlob, error) {
+	var v shared.DataBlob
+	err := v.FromWire(w)
+	return &v, err
+}
+
+func _List_DataBlob_Read(l wire.ValueList) ([]*shared.DataBlob, error) {
+	if l.ValueType() != wire.TStruct {
+		return nil, nil
+	}
+
+	o := make([]*shared.DataBlob, 0, l.Size())
+	err := l.ForEach(func(x wire.Value) error {
+		i, err := _DataBlob_Read(x)
+		if err != nil {
+			return err
+		}
+		o = append(o, i)
+		return nil
+	})
+	l.Close()
+	return o, err
+}
+
+func _ReplicationInfo_Read(w wire.Value) (*shared.ReplicationInfo, error) {
+	var v shared.ReplicationInfo
+	err := v.FromWire(w)
+	return &v, err
+}
+
+func _Map_String_ReplicationInfo_Read(m wire.MapItemList) (map[string]*shared.ReplicationInfo, error) {
+	if m.KeyType() != wire.TBinary {
+		return nil, nil
+	}
+
+	if m.ValueType() != wire.TStruct {
+		return nil, nil
+	}
+
+	o := make(map[string]*shared.ReplicationInfo, m.Size())
+	err := m.ForEach(func(x wire.MapItem) error {
+		k, err := x.Key.GetString(), error(nil)
+		if err != nil {
+			return err
+		}
+
+		v, err := _ReplicationInfo_Read(x.Value)
+		if err != nil {
+			return err
+		}
+
+		o[k] = v
+		return nil
+	})
+	m.Close()
+	return o, err
+}
+
+// FromWire deserializes a GetWorkflowExecutionRawHistoryResponse struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a GetWorkflowExecutionRawHistoryResponse struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v GetWorkflowExecutionRawHistoryResponse
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *GetWorkflowExecutionRawHistoryResponse) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 10:
+			if field.Value.Type() == wire.TBinary {
+				v.NextPageToken, err = field.Value.GetBinary(), error(nil)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 20:
+			if field.Value.Type() == wire.TList {
+				v.HistoryBatches, err = _List_DataBlob_Read(field.Value.GetList())
+				if err != nil {
+					return err
+				}
+
+			}
+		case 30:
+			if field.Value.Type() == wire.TMap {
+				v.ReplicationInfo, err = _Map_String_ReplicationInfo_Read(field.Value.GetMap())
+				if err != nil {
+					return err
+				}
+
+			}
+		case 40:
+			if field.Value.Type() == wire.TI32 {
+				var x int32
+				x, err = field.Value.GetI32(), error(nil)
+				v.EventStoreVersion = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+
+	return nil
+}
+
+// String returns a readable string representation of a GetWorkflowExecutionRawHistoryResponse
+// struct.
+func (v *GetWorkflowExecutionRawHistoryResponse) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [4]string
+	i := 0
+	if v.NextPageToken != nil {
+		fields[i] = fmt.Sprintf("NextPageToken: %v", v.NextPageToken)
+		i++
+	}
+	if v.HistoryBatches != nil {
+		fields[i] = fmt.Sprintf("HistoryBatches: %v", v.HistoryBatches)
+		i++
+	}
+	if v.ReplicationInfo != nil {
+		fields[i] = fmt.Sprintf("ReplicationInfo: %v", v.ReplicationInfo)
+		i++
+	}
+	if v.EventStoreVersion != nil {
+		fields[i] = fmt.Sprintf("EventStoreVersion: %v", *(v.EventStoreVersion))
+		i++
+	}
+
+	return fmt.Sprintf("GetWorkflowExecutionRawHistoryResponse{%v}", strings.Join(fields[:i], ", "))
+}
+
+func _List_DataBlob_Equals(lhs, rhs []*shared.DataBlob) bool {
+	if len(lhs) != len(rhs) {
+		return false
+	}
+
+	for i, lv := range lhs {
+		rv := rhs[i]
+		if !lv.Equals(rv) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func _Map_String_ReplicationInfo_Equals(lhs, rhs map[string]*shared.ReplicationInfo) bool {
+	if len(lhs) != len(rhs) {
+		return false
+	}
+
+	for lk, lv := range lhs {
+		rv, ok := rhs[lk]
+		if !ok {
+			return false
+		}
+		if !lv.Equals(rv) {
+			return false
+		}
+	}
+	return true
+}
+
+// Equals returns true if all the fields of this GetWorkflowExecutionRawHistoryResponse match the
+// provided GetWorkflowExecutionRawHistoryResponse.
+//
+// This function performs a deep comparison.
+func (v *GetWorkflowExecutionRawHistoryResponse) Equals(rhs *GetWorkflowExecutionRawHistoryResponse) bool {
+	if v == nil {
+		return rhs == nil
+	} else if rhs == nil {
+		return false
+	}
+	if !((v.NextPageToken == nil && rhs.NextPageToken == nil) || (v.NextPageToken != nil && rhs.NextPageToken != nil && bytes.Equal(v.NextPageToken, rhs.NextPageToken))) {
+		return false
+	}
+	if !((v.HistoryBatches == nil && rhs.HistoryBatches == nil) || (v.HistoryBatches != nil && rhs.HistoryBatches != nil && _List_DataBlob_Equals(v.HistoryBatches, rhs.HistoryBatches))) {
+		return false
+	}
+	if !((v.ReplicationInfo == nil && rhs.ReplicationInfo == nil) || (v.ReplicationInfo != nil && rhs.ReplicationInfo != nil && _Map_String_ReplicationInfo_Equals(v.ReplicationInfo, rhs.ReplicationInfo))) {
+		return false
+	}
+	if !_I32_EqualsPtr(v.EventStoreVersion, rhs.EventStoreVersion) {
+		return false
+	}
+
+	return true
+}
+
+type _List_DataBlob_Zapper []*shared.DataBlob
+
+// MarshalLogArray implements zapcore.ArrayMarshaler, enabling
+// fast logging of _List_DataBlob_Zapper.
+func (l _List_DataBlob_Zapper) MarshalLogArray(enc zapcore.ArrayEncoder) (err error) {
+	for _, v := range l {
+		err = multierr.Append(err, enc.AppendObject(v))
+	}
+	return err
+}
+
+type _Map_String_ReplicationInfo_Zapper map[string]*shared.ReplicationInfo
+
+// MarshalLogObject implements zapcore.ObjectMarshaler, enabling
+// fast logging of _Map_String_ReplicationInfo_Zapper.
+func (m _Map_String_ReplicationInfo_Zapper) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+	for k, v := range m {
+		err = multierr.Append(err, enc.AddObject((string)(k), v))
+	}
+	return err
+}
+
+// MarshalLogObject implements zapcore.ObjectMarshaler, enabling
+// fast logging of GetWorkflowExecutionRawHistoryResponse.
+func (v *GetWorkflowExecutionRawHistoryResponse) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+	if v == nil {
+		return nil
+	}
+	if v.NextPageToken != nil {
+		enc.AddString("nextPageToken", base64.StdEncoding.EncodeToString(v.NextPageToken))
+	}
+	if v.HistoryBatches != nil {
+		err = multierr.Append(err, enc.AddArray("historyBatches", (_List_DataBlob_Zapper)(v.HistoryBatches)))
+	}
+	if v.ReplicationInfo != nil {
+		err = multierr.Append(err, enc.AddObject("replicationInfo", (_Map_String_ReplicationInfo_Zapper)(v.ReplicationInfo)))
+	}
+	if v.EventStoreVersion != nil {
+		enc.AddInt32("eventStoreVersion", *v.EventStoreVersion)
+	}
+	return err
+}
+
+// GetNextPageToken returns the value of NextPageToken if it is set or its
+// zero value if it is unset.
+func (v *GetWorkflowExecutionRawHistoryResponse) GetNextPageToken() (o []byte) {
+	if v != nil && v.NextPageToken != nil {
+		return v.NextPageToken
+	}
+
+	return
+}
+
+// IsSetNextPageToken returns true if NextPageToken is not nil.
+func (v *GetWorkflowExecutionRawHistoryResponse) IsSetNextPageToken() bool {
+	return v != nil && v.NextPageToken != nil
+}
+
+// GetHistoryBatches returns the value of HistoryBatches if it is set or its
+// zero value if it is unset.
+func (v *GetWorkflowExecutionRawHistoryResponse) GetHistoryBatches() (o []*shared.DataBlob) {
+	if v != nil && v.HistoryBatches != nil {
+		return v.HistoryBatches
+	}
+
+	return
+}
+
+// IsSetHistoryBatches returns true if HistoryBatches is not nil.
+func (v *GetWorkflowExecutionRawHistoryResponse) IsSetHistoryBatches() bool {
+	return v != nil && v.HistoryBatches != nil
+}
+
+// GetReplicationInfo returns the value of ReplicationInfo if it is set or its
+// zero value if it is unset.
+func (v *GetWorkflowExecutionRawHistoryResponse) GetReplicationInfo() (o map[string]*shared.ReplicationInfo) {
+	if v != nil && v.ReplicationInfo != nil {
+		return v.ReplicationInfo
+	}
+
+	return
+}
+
+// IsSetReplicationInfo returns true if ReplicationInfo is not nil.
+func (v *GetWorkflowExecutionRawHistoryResponse) IsSetReplicationInfo() bool {
+	return v != nil && v.ReplicationInfo != nil
+}
+
+// GetEventStoreVersion returns the value of EventStoreVersion if it is set or its
+// zero value if it is unset.
+func (v *GetWorkflowExecutionRawHistoryResponse) GetEventStoreVersion() (o int32) {
+	if v != nil && v.EventStoreVersion != nil {
+		return *v.EventStoreVersion
+	}
+
+	return
+}
+
+// IsSetEventStoreVersion returns true if EventStoreVersion is not nil.
+func (v *GetWorkflowExecutionRawHistoryResponse) IsSetEventStoreVersion() bool {
+	return v != nil && v.EventStoreVersion != nil
+}
+
+type ImportWorkflowExecutionRequest struct {
+	Domain   *string `json:"domain,omitempty"`
+	Snapshot []byte  `json:"snapshot,omitempty"`
+}
+
+// ToWire translates a ImportWorkflowExecutionRequest struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *ImportWorkflowExecutionRequest) ToWire() (wire.Value, error) {
+	var (
+		fields [2]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.Domain != nil {
+		w, err = wire.NewValueString(*(v.Domain)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 10, Value: w}
+		i++
+	}
+	if v.Snapshot != nil {
+		w, err = wire.NewValueBinary(v.Snapshot), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 20, Value: w}
+		i++
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+// FromWire deserializes a ImportWorkflowExecutionRequest struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a ImportWorkflowExecutionRequest struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v ImportWorkflowExecutionRequest
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *ImportWorkflowExecutionRequest) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 10:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.Domain = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 20:
+			if field.Value.Type() == wire.TBinary {
+				v.Snapshot, err = field.Value.GetBinary(), error(nil)
+				if err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+
+	return nil
+}
+
+// String returns a readable string representation of a ImportWorkflowExecutionRequest
+// struct.
+func (v *ImportWorkflowExecutionRequest) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [2]string
+	i := 0
+	if v.Domain != nil {
+		fields[i] = fmt.Sprintf("Domain: %v", *(v.Domain))
+		i++
+	}
+	if v.Snapshot != nil {
+		fields[i] = fmt.Sprintf("Snapshot: %v", v.Snapshot)
+		i++
+	}
+
+	return fmt.Sprintf("ImportWorkflowExecutionRequest{%v}", strings.Join(fields[:i], ", "))
+}
+
+// Equals returns true if all the fields of this ImportWorkflowExecutionRequest match the
+// provided ImportWorkflowExecutionRequest.
+//
+// This function performs a deep comparison.
+func (v *ImportWorkflowExecutionRequest) Equals(rhs *ImportWorkflowExecutionRequest) bool {
+	if v == nil {
+		return rhs == nil
+	} else if rhs == nil {
+		return false
+	}
+	if !_String_EqualsPtr(v.Domain, rhs.Domain) {
+		return false
+	}
+	if !((v.Snapshot == nil && rhs.Snapshot == nil) || (v.Snapshot != nil && rhs.Snapshot != nil && bytes.Equal(v.Snapshot, rhs.Snapshot))) {
+		return false
+	}
+
+	return true
+}
+
+// MarshalLogObject implements zapcore.ObjectMarshaler, enabling
+// fast logging of ImportWorkflowExecutionRequest.
+func (v *ImportWorkflowExecutionRequest) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+	if v == nil {
+		return nil
+	}
+	if v.Domain != nil {
+		enc.AddString("domain", *v.Domain)
+	}
+	if v.Snapshot != nil {
+		enc.AddString("snapshot", base64.StdEncoding.EncodeToString(v.Snapshot))
+	}
+	return err
+}
+
+// GetDomain returns the value of Domain if it is set or its
+// zero value if it is unset.
+func (v *ImportWorkflowExecutionRequest) GetDomain() (o string) {
+	if v != nil && v.Domain != nil {
+		return *v.Domain
+	}
+
+	return
+}
+
+// IsSetDomain returns true if Domain is not nil.
+func (v *ImportWorkflowExecutionRequest) IsSetDomain() bool {
+	return v != nil && v.Domain != nil
+}
+
+// GetSnapshot returns the value of Snapshot if it is set or its
+// zero value if it is unset.
+func (v *ImportWorkflowExecutionRequest) GetSnapshot() (o []byte) {
+	if v != nil && v.Snapshot != nil {
+		return v.Snapshot
+	}
+
+	return
+}
+
+// IsSetSnapshot returns true if Snapshot is not nil.
+func (v *ImportWorkflowExecutionRequest) IsSetSnapshot() bool {
+	return v != nil && v.Snapshot != nil
+}
+
+type ImportWorkflowExecutionResponse struct {
+	RunId *string `json:"runId,omitempty"`
+}
+
+// ToWire translates a ImportWorkflowExecutionResponse struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *ImportWorkflowExecutionResponse) ToWire() (wire.Value, error) {
+	var (
+		fields [1]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.RunId != nil {
+		w, err = wire.NewValueString(*(v.RunId)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 10, Value: w}
+		i++
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+// FromWire deserializes a ImportWorkflowExecutionResponse struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a ImportWorkflowExecutionResponse struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v ImportWorkflowExecutionResponse
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *ImportWorkflowExecutionResponse) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 10:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.RunId = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+
+	return nil
+}
+
+// String returns a readable string representation of a ImportWorkflowExecutionResponse
+// struct.
+func (v *ImportWorkflowExecutionResponse) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [1]string
+	i := 0
+	if v.RunId != nil {
+		fields[i] = fmt.Sprintf("RunId: %v", *(v.RunId))
+		i++
+	}
+
+	return fmt.Sprintf("ImportWorkflowExecutionResponse{%v}", strings.Join(fields[:i], ", "))
+}
+
+// Equals returns true if all the fields of this ImportWorkflowExecutionResponse match the
+// provided ImportWorkflowExecutionResponse.
+//
+// This function performs a deep comparison.
+func (v *ImportWorkflowExecutionResponse) Equals(rhs *ImportWorkflowExecutionResponse) bool {
+	if v == nil {
+		return rhs == nil
+	} else if rhs == nil {
+		return false
+	}
+	if !_String_EqualsPtr(v.RunId, rhs.RunId) {
+		return false
+	}
+
+	return true
+}
+
+// MarshalLogObject implements zapcore.ObjectMarshaler, enabling
+// fast logging of ImportWorkflowExecutionResponse.
+func (v *ImportWorkflowExecutionResponse) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+	if v == nil {
+		return nil
+	}
+	if v.RunId != nil {
+		enc.AddString("runId", *v.RunId)
+	}
+	return err
+}
+
+// GetRunId returns the value of RunId if it is set or its
+// zero value if it is unset.
+func (v *ImportWorkflowExecutionResponse) GetRunId() (o string) {
+	if v != nil && v.RunId != nil {
+		return *v.RunId
+	}
+
+	return
+}
+
+// IsSetRunId returns true if RunId is not nil.
+func (v *ImportWorkflowExecutionResponse) IsSetRunId() bool {
+	return v != nil && v.RunId != nil
+}
+
+type ListRecentlyClosedExecutionsRequest struct {
+	ShardId         *int32 `json:"shardId,omitempty"`
+	ClosedAfter     *int64 `json:"closedAfter,omitempty"`
+	MaximumPageSize *int32 `json:"maximumPageSize,omitempty"`
+	NextPageToken   []byte `json:"nextPageToken,omitempty"`
+}
+
+// ToWire translates a ListRecentlyClosedExecutionsRequest struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *ListRecentlyClosedExecutionsRequest) ToWire() (wire.Value, error) {
+	var (
+		fields [4]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.ShardId != nil {
+		w, err = wire.NewValueI32(*(v.ShardId)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 10, Value: w}
+		i++
+	}
+	if v.ClosedAfter != nil {
+		w, err = wire.NewValueI64(*(v.ClosedAfter)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 20, Value: w}
+		i++
+	}
+	if v.MaximumPageSize != nil {
+		w, err = wire.NewValueI32(*(v.MaximumPageSize)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 30, Value: w}
+		i++
+	}
+	if v.NextPageToken != nil {
+		w, err = wire.NewValueBinary(v.NextPageToken), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 40, Value: w}
+		i++
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+// FromWire deserializes a ListRecentlyClosedExecutionsRequest struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a ListRecentlyClosedExecutionsRequest struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v ListRecentlyClosedExecutionsRequest
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *ListRecentlyClosedExecutionsRequest) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 10:
+			if field.Value.Type() == wire.TI32 {
+				var x int32
+				x, err = field.Value.GetI32(), error(nil)
+				v.ShardId = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 20:
+			if field.Value.Type() == wire.TI64 {
+				var x int64
+				x, err = field.Value.GetI64(), error(nil)
+				v.ClosedAfter = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 30:
+			if field.Value.Type() == wire.TI32 {
+				var x int32
+				x, err = field.Value.GetI32(), error(nil)
+				v.MaximumPageSize = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 40:
+			if field.Value.Type() == wire.TBinary {
+				v.NextPageToken, err = field.Value.GetBinary(), error(nil)
+				if err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+
+	return nil
+}
+
+// String returns a readable string representation of a ListRecentlyClosedExecutionsRequest
+// struct.
+func (v *ListRecentlyClosedExecutionsRequest) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [4]string
+	i := 0
+	if v.ShardId != nil {
+		fields[i] = fmt.Sprintf("ShardId: %v", *(v.ShardId))
+		i++
+	}
+	if v.ClosedAfter != nil {
+		fields[i] = fmt.Sprintf("ClosedAfter: %v", *(v.ClosedAfter))
+		i++
+	}
+	if v.MaximumPageSize != nil {
+		fields[i] = fmt.Sprintf("MaximumPageSize: %v", *(v.MaximumPageSize))
+		i++
+	}
+	if v.NextPageToken != nil {
+		fields[i] = fmt.Sprintf("NextPageToken: %v", v.NextPageToken)
+		i++
+	}
+
+	return fmt.Sprintf("ListRecentlyClosedExecutionsRequest{%v}", strings.Join(fields[:i], ", "))
+}
+
+// Equals returns true if all the fields of this ListRecentlyClosedExecutionsRequest match the
+// provided ListRecentlyClosedExecutionsRequest.
+//
+// This function performs a deep comparison.
+func (v *ListRecentlyClosedExecutionsRequest) Equals(rhs *ListRecentlyClosedExecutionsRequest) bool {
+	if v == nil {
+		return rhs == nil
+	} else if rhs == nil {
+		return false
+	}
+	if !_I32_EqualsPtr(v.ShardId, rhs.ShardId) {
+		return false
+	}
+	if !_I64_EqualsPtr(v.ClosedAfter, rhs.ClosedAfter) {
+		return false
+	}
+	if !_I32_EqualsPtr(v.MaximumPageSize, rhs.MaximumPageSize) {
+		return false
+	}
+	if !((v.NextPageToken == nil && rhs.NextPageToken == nil) || (v.NextPageToken != nil && rhs.NextPageToken != nil && bytes.Equal(v.NextPageToken, rhs.NextPageToken))) {
+		return false
+	}
+
+	return true
+}
+
+// MarshalLogObject implements zapcore.ObjectMarshaler, enabling
+// fast logging of ListRecentlyClosedExecutionsRequest.
+func (v *ListRecentlyClosedExecutionsRequest) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+	if v == nil {
+		return nil
+	}
+	if v.ShardId != nil {
+		enc.AddInt32("shardId", *v.ShardId)
+	}
+	if v.ClosedAfter != nil {
+		enc.AddInt64("closedAfter", *v.ClosedAfter)
+	}
+	if v.MaximumPageSize != nil {
+		enc.AddInt32("maximumPageSize", *v.MaximumPageSize)
+	}
+	if v.NextPageToken != nil {
+		enc.AddString("nextPageToken", base64.StdEncoding.EncodeToString(v.NextPageToken))
+	}
+	return err
+}
+
+// GetShardId returns the value of ShardId if it is set or its
+// zero value if it is unset.
+func (v *ListRecentlyClosedExecutionsRequest) GetShardId() (o int32) {
+	if v != nil && v.ShardId != nil {
+		return *v.ShardId
+	}
+
+	return
+}
+
+// IsSetShardId returns true if ShardId is not nil.
+func (v *ListRecentlyClosedExecutionsRequest) IsSetShardId() bool {
+	return v != nil && v.ShardId != nil
+}
+
+// GetClosedAfter returns the value of ClosedAfter if it is set or its
+// zero value if it is unset.
+func (v *ListRecentlyClosedExecutionsRequest) GetClosedAfter() (o int64) {
+	if v != nil && v.ClosedAfter != nil {
+		return *v.ClosedAfter
+	}
+
+	return
+}
+
+// IsSetClosedAfter returns true if ClosedAfter is not nil.
+func (v *ListRecentlyClosedExecutionsRequest) IsSetClosedAfter() bool {
+	return v != nil && v.ClosedAfter != nil
+}
+
+// GetMaximumPageSize returns the value of MaximumPageSize if it is set or its
+// zero value if it is unset.
+func (v *ListRecentlyClosedExecutionsRequest) GetMaximumPageSize() (o int32) {
+	if v != nil && v.MaximumPageSize != nil {
+		return *v.MaximumPageSize
+	}
+
+	return
+}
+
+// IsSetMaximumPageSize returns true if MaximumPageSize is not nil.
+func (v *ListRecentlyClosedExecutionsRequest) IsSetMaximumPageSize() bool {
+	return v != nil && v.MaximumPageSize != nil
+}
+
+// GetNextPageToken returns the value of NextPageToken if it is set or its
+// zero value if it is unset.
+func (v *ListRecentlyClosedExecutionsRequest) GetNextPageToken() (o []byte) {
+	if v != nil && v.NextPageToken != nil {
+		return v.NextPageToken
+	}
+
+	return
+}
+
+// IsSetNextPageToken returns true if NextPageToken is not nil.
+func (v *ListRecentlyClosedExecutionsRequest) IsSetNextPageToken() bool {
+	return v != nil && v.NextPageToken != nil
+}
+
+type ListRecentlyClosedExecutionsResponse struct {
+	Executions    []*ClosedExecutionInfo `json:"executions,omitempty"`
+	NextPageToken []byte                 `json:"nextPageToken,omitempty"`
+}
+
+type _List_ClosedExecutionInfo_ValueList []*ClosedExecutionInfo
+
+func (v _List_ClosedExecutionInfo_ValueList) ForEach(f func(wire.Value) error) error {
+	for i, x := range v {
+		if x == nil {
+			return fmt.Errorf("invalid [%v]: value is nil", i)
+		}
+		w, err := x.ToWire()
+		if err != nil {
+			return err
+		}
+		err = f(w)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (v _List_ClosedExecutionInfo_ValueList) Size() int {
+	return len(v)
+}
+
+func (_List_ClosedExecutionInfo_ValueList) ValueType() wire.Type {
+	return wire.TStruct
+}
+
+func (_List_ClosedExecutionInfo_ValueList) Close() {}
+
+// ToWire translates a ListRecentlyClosedExecutionsResponse struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *ListRecentlyClosedExecutionsResponse) ToWire() (wire.Value, error) {
+	var (
+		fields [2]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.Executions != nil {
+		w, err = wire.NewValueList(_List_ClosedExecutionInfo_ValueList(v.Executions)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 10, Value: w}
+		i++
+	}
+	if v.NextPageToken != nil {
+		w, err = wire.NewValueBinary(v.NextPageToken), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 20, Value: w}
+		i++
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+func _ClosedExecutionInfo_Read(w wire.Value) (*ClosedExecutionInfo, error) {
+	var v ClosedExecutionInfo
+	err := v.FromWire(w)
+	return &v, err
+}
+
+func _List_ClosedExecutionInfo_Read(l wire.ValueList) ([]*ClosedExecutionInfo, error) {
+	if l.ValueType() != wire.TStruct {
+		return nil, nil
+	}
+
+	o := make([]*ClosedExecutionInfo, 0, l.Size())
+	err := l.ForEach(func(x wire.Value) error {
+		i, err := _ClosedExecutionInfo_Read(x)
+		if err != nil {
+			return err
+		}
+		o = append(o, i)
+		return nil
+	})
+	l.Close()
+	return o, err
+}
+
+// FromWire deserializes a ListRecentlyClosedExecutionsResponse struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a ListRecentlyClosedExecutionsResponse struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v ListRecentlyClosedExecutionsResponse
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *ListRecentlyClosedExecutionsResponse) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 10:
+			if field.Value.Type() == wire.TList {
+				v.Executions, err = _List_ClosedExecutionInfo_Read(field.Value.GetList())
+				if err != nil {
+					return err
+				}
+
+			}
+		case 20:
+			if field.Value.Type() == wire.TBinary {
+				v.NextPageToken, err = field.Value.GetBinary(), error(nil)
+				if err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+
+	return nil
+}
+
+// String returns a readable string representation of a ListRecentlyClosedExecutionsResponse
+// struct.
+func (v *ListRecentlyClosedExecutionsResponse) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [2]string
+	i := 0
+	if v.Executions != nil {
+		fields[i] = fmt.Sprintf("Executions: %v", v.Executions)
+		i++
+	}
+	if v.NextPageToken != nil {
+		fields[i] = fmt.Sprintf("NextPageToken: %v", v.NextPageToken)
+		i++
+	}
+
+	return fmt.Sprintf("ListRecentlyClosedExecutionsResponse{%v}", strings.Join(fields[:i], ", "))
+}
+
+func _List_ClosedExecutionInfo_Equals(lhs, rhs []*ClosedExecutionInfo) bool {
+	if len(lhs) != len(rhs) {
+		return false
+	}
+
+	for i, lv := range lhs {
+		rv := rhs[i]
+		if !lv.Equals(rv) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Equals returns true if all the fields of this ListRecentlyClosedExecutionsResponse match the
+// provided ListRecentlyClosedExecutionsResponse.
+//
+// This function performs a deep comparison.
+func (v *ListRecentlyClosedExecutionsResponse) Equals(rhs *ListRecentlyClosedExecutionsResponse) bool {
+	if v == nil {
+		return rhs == nil
+	} else if rhs == nil {
+		return false
+	}
+	if !((v.Executions == nil && rhs.Executions == nil) || (v.Executions != nil && rhs.Executions != nil && _List_ClosedExecutionInfo_Equals(v.Executions, rhs.Executions))) {
+		return false
+	}
+	if !((v.NextPageToken == nil && rhs.NextPageToken == nil) || (v.NextPageToken != nil && rhs.NextPageToken != nil && bytes.Equal(v.NextPageToken, rhs.NextPageToken))) {
+		return false
+	}
+
+	return true
+}
+
+type _List_ClosedExecutionInfo_Zapper []*ClosedExecutionInfo
+
+// MarshalLogArray implements zapcore.ArrayMarshaler, enabling
+// fast logging of _List_ClosedExecutionInfo_Zapper.
+func (l _List_ClosedExecutionInfo_Zapper) MarshalLogArray(enc zapcore.ArrayEncoder) (err error) {
+	for _, v := range l {
+		err = multierr.Append(err, enc.AppendObject(v))
+	}
+	return err
+}
+
+// MarshalLogObject implements zapcore.ObjectMarshaler, enabling
+// fast logging of ListRecentlyClosedExecutionsResponse.
+func (v *ListRecentlyClosedExecutionsResponse) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+	if v == nil {
+		return nil
+	}
+	if v.Executions != nil {
+		err = multierr.Append(err, enc.AddArray("executions", (_List_ClosedExecutionInfo_Zapper)(v.Executions)))
+	}
+	if v.NextPageToken != nil {
+		enc.AddString("nextPageToken", base64.StdEncoding.EncodeToString(v.NextPageToken))
+	}
+	return err
+}
+
+// GetExecutions returns the value of Executions if it is set or its
+// zero value if it is unset.
+func (v *ListRecentlyClosedExecutionsResponse) GetExecutions() (o []*ClosedExecutionInfo) {
+	if v != nil && v.Executions != nil {
+		return v.Executions
+	}
+
+	return
+}
+
+// IsSetExecutions returns true if Executions is not nil.
+func (v *ListRecentlyClosedExecutionsResponse) IsSetExecutions() bool {
+	return v != nil && v.Executions != nil
+}
+
+// GetNextPageToken returns the value of NextPageToken if it is set or its
+// zero value if it is unset.
+func (v *ListRecentlyClosedExecutionsResponse) GetNextPageToken() (o []byte) {
+	if v != nil && v.NextPageToken != nil {
+		return v.NextPageToken
+	}
+
+	return
+}
+
+// IsSetNextPageToken returns true if NextPageToken is not nil.
+func (v *ListRecentlyClosedExecutionsResponse) IsSetNextPageToken() bool {
+	return v != nil && v.NextPageToken != nil
+}
+
+type ListWorkflowExecutionRunsRequest struct {
+	Domain     *string `json:"domain,omitempty"`
+	WorkflowId *string `json:"workflowId,omitempty"`
+}
+
+// ToWire translates a ListWorkflowExecutionRunsRequest struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *ListWorkflowExecutionRunsRequest) ToWire() (wire.Value, error) {
+	var (
+		fields [2]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.Domain != nil {
+		w, err = wire.NewValueString(*(v.Domain)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 10, Value: w}
+		i++
+	}
+	if v.WorkflowId != nil {
+		w, err = wire.NewValueString(*(v.WorkflowId)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 20, Value: w}
+		i++
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+// FromWire deserializes a ListWorkflowExecutionRunsRequest struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a ListWorkflowExecutionRunsRequest struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v ListWorkflowExecutionRunsRequest
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *ListWorkflowExecutionRunsRequest) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 10:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.Domain = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 20:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.WorkflowId = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+
+	return nil
+}
+
+// String returns a readable string representation of a ListWorkflowExecutionRunsRequest
+// struct.
+func (v *ListWorkflowExecutionRunsRequest) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [2]string
+	i := 0
+	if v.Domain != nil {
+		fields[i] = fmt.Sprintf("Domain: %v", *(v.Domain))
+		i++
+	}
+	if v.WorkflowId != nil {
+		fields[i] = fmt.Sprintf("WorkflowId: %v", *(v.WorkflowId))
+		i++
+	}
+
+	return fmt.Sprintf("ListWorkflowExecutionRunsRequest{%v}", strings.Join(fields[:i], ", "))
+}
+
+// Equals returns true if all the fields of this ListWorkflowExecutionRunsRequest match the
+// provided ListWorkflowExecutionRunsRequest.
+//
+// This function performs a deep comparison.
+func (v *ListWorkflowExecutionRunsRequest) Equals(rhs *ListWorkflowExecutionRunsRequest) bool {
+	if v == nil {
+		return rhs == nil
+	} else if rhs == nil {
+		return false
+	}
+	if !_String_EqualsPtr(v.Domain, rhs.Domain) {
+		return false
+	}
+	if !_String_EqualsPtr(v.WorkflowId, rhs.WorkflowId) {
+		return false
+	}
+
+	return true
+}
+
+// MarshalLogObject implements zapcore.ObjectMarshaler, enabling
+// fast logging of ListWorkflowExecutionRunsRequest.
+func (v *ListWorkflowExecutionRunsRequest) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+	if v == nil {
+		return nil
+	}
+	if v.Domain != nil {
+		enc.AddString("domain", *v.Domain)
+	}
+	if v.WorkflowId != nil {
+		enc.AddString("workflowId", *v.WorkflowId)
+	}
+	return err
+}
+
+// GetDomain returns the value of Domain if it is set or its
+// zero value if it is unset.
+func (v *ListWorkflowExecutionRunsRequest) GetDomain() (o string) {
+	if v != nil && v.Domain != nil {
+		return *v.Domain
+	}
+
+	return
+}
+
+// IsSetDomain returns true if Domain is not nil.
+func (v *ListWorkflowExecutionRunsRequest) IsSetDomain() bool {
+	return v != nil && v.Domain != nil
+}
+
+// GetWorkflowId returns the value of WorkflowId if it is set or its
+// zero value if it is unset.
+func (v *ListWorkflowExecutionRunsRequest) GetWorkflowId() (o string) {
+	if v != nil && v.WorkflowId != nil {
+		return *v.WorkflowId
+	}
+
+	return
+}
+
+// IsSetWorkflowId returns true if WorkflowId is not nil.
+func (v *ListWorkflowExecutionRunsRequest) IsSetWorkflowId() bool {
+	return v != nil && v.WorkflowId != nil
+}
+
+type ListWorkflowExecutionRunsResponse struct {
+	Runs []*WorkflowExecutionRunInfo `json:"runs,omitempty"`
+}
+
+type _List_WorkflowExecutionRunInfo_ValueList []*WorkflowExecutionRunInfo
+
+func (v _List_WorkflowExecutionRunInfo_ValueList) ForEach(f func(wire.Value) error) error {
+	for i, x := range v {
+		if x == nil {
+			return fmt.Errorf("invalid [%v]: value is nil", i)
+		}
+		w, err := x.ToWire()
+		if err != nil {
+			return err
+		}
+		err = f(w)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (v _List_WorkflowExecutionRunInfo_ValueList) Size() int {
+	return len(v)
+}
+
+func (_List_WorkflowExecutionRunInfo_ValueList) ValueType() wire.Type {
+	return wire.TStruct
+}
+
+func (_List_WorkflowExecutionRunInfo_ValueList) Close() {}
+
+// ToWire translates a ListWorkflowExecutionRunsResponse struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *ListWorkflowExecutionRunsResponse) ToWire() (wire.Value, error) {
+	var (
+		fields [1]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.Runs != nil {
+		w, err = wire.NewValueList(_List_WorkflowExecutionRunInfo_ValueList(v.Runs)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 10, Value: w}
+		i++
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+func _WorkflowExecutionRunInfo_Read(w wire.Value) (*WorkflowExecutionRunInfo, error) {
+	var v WorkflowExecutionRunInfo
+	err := v.FromWire(w)
+	return &v, err
+}
+
+func _List_WorkflowExecutionRunInfo_Read(l wire.ValueList) ([]*WorkflowExecutionRunInfo, error) {
+	if l.ValueType() != wire.TStruct {
+		return nil, nil
+	}
+
+	o := make([]*WorkflowExecutionRunInfo, 0, l.Size())
+	err := l.ForEach(func(x wire.Value) error {
+		i, err := _WorkflowExecutionRunInfo_Read(x)
+		if err != nil {
+			return err
+		}
+		o = append(o, i)
+		return nil
+	})
+	l.Close()
+	return o, err
+}
+
+// FromWire deserializes a ListWorkflowExecutionRunsResponse struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a ListWorkflowExecutionRunsResponse struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v ListWorkflowExecutionRunsResponse
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *ListWorkflowExecutionRunsResponse) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 10:
+			if field.Value.Type() == wire.TList {
+				v.Runs, err = _List_WorkflowExecutionRunInfo_Read(field.Value.GetList())
+				if err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+
+	return nil
+}
+
+// String returns a readable string representation of a ListWorkflowExecutionRunsResponse
+// struct.
+func (v *ListWorkflowExecutionRunsResponse) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [1]string
+	i := 0
+	if v.Runs != nil {
+		fields[i] = fmt.Sprintf("Runs: %v", v.Runs)
+		i++
+	}
+
+	return fmt.Sprintf("ListWorkflowExecutionRunsResponse{%v}", strings.Join(fields[:i], ", "))
+}
+
+func _List_WorkflowExecutionRunInfo_Equals(lhs, rhs []*WorkflowExecutionRunInfo) bool {
+	if len(lhs) != len(rhs) {
+		return false
+	}
+
+	for i, lv := range lhs {
+		rv := rhs[i]
+		if !lv.Equals(rv) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Equals returns true if all the fields of this ListWorkflowExecutionRunsResponse match the
+// provided ListWorkflowExecutionRunsResponse.
+//
+// This function performs a deep comparison.
+func (v *ListWorkflowExecutionRunsResponse) Equals(rhs *ListWorkflowExecutionRunsResponse) bool {
+	if v == nil {
+		return rhs == nil
+	} else if rhs == nil {
+		return false
+	}
+	if !((v.Runs == nil && rhs.Runs == nil) || (v.Runs != nil && rhs.Runs != nil && _List_WorkflowExecutionRunInfo_Equals(v.Runs, rhs.Runs))) {
+		return false
+	}
+
+	return true
+}
+
+type _List_WorkflowExecutionRunInfo_Zapper []*WorkflowExecutionRunInfo
+
+// MarshalLogArray implements zapcore.ArrayMarshaler, enabling
+// fast logging of _List_WorkflowExecutionRunInfo_Zapper.
+func (l _List_WorkflowExecutionRunInfo_Zapper) MarshalLogArray(enc zapcore.ArrayEncoder) (err error) {
+	for _, v := range l {
+		err = multierr.Append(err, enc.AppendObject(v))
+	}
+	return err
+}
+
+// MarshalLogObject implements zapcore.ObjectMarshaler, enabling
+// fast logging of ListWorkflowExecutionRunsResponse.
+func (v *ListWorkflowExecutionRunsResponse) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+	if v == nil {
+		return nil
+	}
+	if v.Runs != nil {
+		err = multierr.Append(err, enc.AddArray("runs", (_List_WorkflowExecutionRunInfo_Zapper)(v.Runs)))
+	}
+	return err
+}
+
+// GetRuns returns the value of Runs if it is set or its
+// zero value if it is unset.
+func (v *ListWorkflowExecutionRunsResponse) GetRuns() (o []*WorkflowExecutionRunInfo) {
+	if v != nil && v.Runs != nil {
+		return v.Runs
+	}
+
+	return
+}
+
+// IsSetRuns returns true if Runs is not nil.
+func (v *ListWorkflowExecutionRunsResponse) IsSetRuns() bool {
+	return v != nil && v.Runs != nil
+}
+
+type PurgeWorkflowExecutionRequest struct {
+	Domain             *string                   `json:"domain,omitempty"`
+	Execution          *shared.WorkflowExecution `json:"execution,omitempty"`
+	CurrentClusterOnly *bool                     `json:"currentClusterOnly,omitempty"`
+}
+
+// ToWire translates a PurgeWorkflowExecutionRequest struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *PurgeWorkflowExecutionRequest) ToWire() (wire.Value, error) {
+	var (
+		fields [3]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.Domain != nil {
+		w, err = wire.NewValueString(*(v.Domain)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 10, Value: w}
+		i++
+	}
+	if v.Execution != nil {
+		w, err = v.Execution.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 20, Value: w}
+		i++
+	}
+	if v.CurrentClusterOnly != nil {
+		w, err = wire.NewValueBool(*(v.CurrentClusterOnly)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 30, Value: w}
+		i++
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+// FromWire deserializes a PurgeWorkflowExecutionRequest struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a PurgeWorkflowExecutionRequest struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v PurgeWorkflowExecutionRequest
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *PurgeWorkflowExecutionRequest) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 10:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.Domain = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 20:
+			if field.Value.Type() == wire.TStruct {
+				v.Execution, err = _WorkflowExecution_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 30:
+			if field.Value.Type() == wire.TBool {
+				var x bool
+				x, err = field.Value.GetBool(), error(nil)
+				v.CurrentClusterOnly = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+
+	return nil
+}
+
+// String returns a readable string representation of a PurgeWorkflowExecutionRequest
+// struct.
+func (v *PurgeWorkflowExecutionRequest) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [3]string
+	i := 0
+	if v.Domain != nil {
+		fields[i] = fmt.Sprintf("Domain: %v", *(v.Domain))
+		i++
+	}
+	if v.Execution != nil {
+		fields[i] = fmt.Sprintf("Execution: %v", v.Execution)
+		i++
+	}
+	if v.CurrentClusterOnly != nil {
+		fields[i] = fmt.Sprintf("CurrentClusterOnly: %v", *(v.CurrentClusterOnly))
+		i++
+	}
+
+	return fmt.Sprintf("PurgeWorkflowExecutionRequest{%v}", strings.Join(fields[:i], ", "))
+}
+
+// Equals returns true if all the fields of this PurgeWorkflowExecutionRequest match the
+// provided PurgeWorkflowExecutionRequest.
+//
+// This function performs a deep comparison.
+func (v *PurgeWorkflowExecutionRequest) Equals(rhs *PurgeWorkflowExecutionRequest) bool {
+	if v == nil {
+		return rhs == nil
+	} else if rhs == nil {
+		return false
+	}
+	if !_String_EqualsPtr(v.Domain, rhs.Domain) {
+		return false
+	}
+	if !((v.Execution == nil && rhs.Execution == nil) || (v.Execution != nil && rhs.Execution != nil && v.Execution.Equals(rhs.Execution))) {
+		return false
+	}
+	if !_Bool_EqualsPtr(v.CurrentClusterOnly, rhs.CurrentClusterOnly) {
+		return false
+	}
+
+	return true
+}
+
+// MarshalLogObject implements zapcore.ObjectMarshaler, enabling
+// fast logging of PurgeWorkflowExecutionRequest.
+func (v *PurgeWorkflowExecutionRequest) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+	if v == nil {
+		return nil
+	}
+	if v.Domain != nil {
+		enc.AddString("domain", *v.Domain)
+	}
+	if v.Execution != nil {
+		err = multierr.Append(err, enc.AddObject("execution", v.Execution))
+	}
+	if v.CurrentClusterOnly != nil {
+		enc.AddBool("currentClusterOnly", *v.CurrentClusterOnly)
+	}
+	return err
+}
+
+// GetDomain returns the value of Domain if it is set or its
+// zero value if it is unset.
+func (v *PurgeWorkflowExecutionRequest) GetDomain() (o string) {
+	if v != nil && v.Domain != nil {
+		return *v.Domain
+	}
+
+	return
+}
+
+// IsSetDomain returns true if Domain is not nil.
+func (v *PurgeWorkflowExecutionRequest) IsSetDomain() bool {
+	return v != nil && v.Domain != nil
+}
+
+// GetExecution returns the value of Execution if it is set or its
+// zero value if it is unset.
+func (v *PurgeWorkflowExecutionRequest) GetExecution() (o *shared.WorkflowExecution) {
+	if v != nil && v.Execution != nil {
+		return v.Execution
+	}
+
+	return
+}
+
+// IsSetExecution returns true if Execution is not nil.
+func (v *PurgeWorkflowExecutionRequest) IsSetExecution() bool {
+	return v != nil && v.Execution != nil
+}
+
+// GetCurrentClusterOnly returns the value of CurrentClusterOnly if it is set or its
+// zero value if it is unset.
+func (v *PurgeWorkflowExecutionRequest) GetCurrentClusterOnly() (o bool) {
+	if v != nil && v.CurrentClusterOnly != nil {
+		return *v.CurrentClusterOnly
+	}
+
+	return
+}
+
+// IsSetCurrentClusterOnly returns true if CurrentClusterOnly is not nil.
+func (v *PurgeWorkflowExecutionRequest) IsSetCurrentClusterOnly() bool {
+	return v != nil && v.CurrentClusterOnly != nil
+}
+
+type PurgeWorkflowExecutionResponse struct {
+	Steps []*PurgeWorkflowExecutionStep `json:"steps,omitempty"`
+}
+
+type _List_PurgeWorkflowExecutionStep_ValueList []*PurgeWorkflowExecutionStep
+
+func (v _List_PurgeWorkflowExecutionStep_ValueList) ForEach(f func(wire.Value) error) error {
+	for i, x := range v {
+		if x == nil {
+			return fmt.Errorf("invalid [%v]: value is nil", i)
+		}
+		w, err := x.ToWire()
+		if err != nil {
+			return err
+		}
+		err = f(w)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (v _List_PurgeWorkflowExecutionStep_ValueList) Size() int {
+	return len(v)
+}
+
+func (_List_PurgeWorkflowExecutionStep_ValueList) ValueType() wire.Type {
+	return wire.TStruct
+}
+
+func (_List_PurgeWorkflowExecutionStep_ValueList) Close() {}
+
+// ToWire translates a PurgeWorkflowExecutionResponse struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *PurgeWorkflowExecutionResponse) ToWire() (wire.Value, error) {
+	var (
+		fields [1]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.Steps != nil {
+		w, err = wire.NewValueList(_List_PurgeWorkflowExecutionStep_ValueList(v.Steps)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 10, Value: w}
+		i++
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+func _PurgeWorkflowExecutionStep_Read(w wire.Value) (*PurgeWorkflowExecutionStep, error) {
+	var v PurgeWorkflowExecutionStep
+	err := v.FromWire(w)
+	return &v, err
+}
+
+func _List_PurgeWorkflowExecutionStep_Read(l wire.ValueList) ([]*PurgeWorkflowExecutionStep, error) {
+	if l.ValueType() != wire.TStruct {
+		return nil, nil
+	}
+
+	o := make([]*PurgeWorkflowExecutionStep, 0, l.Size())
+	err := l.ForEach(func(x wire.Value) error {
+		i, err := _PurgeWorkflowExecutionStep_Read(x)
+		if err != nil {
+			return err
+		}
+		o = append(o, i)
+		return nil
+	})
+	l.Close()
+	return o, err
+}
+
+// FromWire deserializes a PurgeWorkflowExecutionResponse struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a PurgeWorkflowExecutionResponse struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v PurgeWorkflowExecutionResponse
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *PurgeWorkflowExecutionResponse) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 10:
+			if field.Value.Type() == wire.TList {
+				v.Steps, err = _List_PurgeWorkflowExecutionStep_Read(field.Value.GetList())
+				if err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+
+	return nil
+}
+
+// String returns a readable string representation of a PurgeWorkflowExecutionResponse
+// struct.
+func (v *PurgeWorkflowExecutionResponse) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [1]string
+	i := 0
+	if v.Steps != nil {
+		fields[i] = fmt.Sprintf("Steps: %v", v.Steps)
+		i++
+	}
+
+	return fmt.Sprintf("PurgeWorkflowExecutionResponse{%v}", strings.Join(fields[:i], ", "))
+}
+
+func _List_PurgeWorkflowExecutionStep_Equals(lhs, rhs []*PurgeWorkflowExecutionStep) bool {
+	if len(lhs) != len(rhs) {
+		return false
+	}
+
+	for i, lv := range lhs {
+		rv := rhs[i]
+		if !lv.Equals(rv) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Equals returns true if all the fields of this PurgeWorkflowExecutionResponse match the
+// provided PurgeWorkflowExecutionResponse.
+//
+// This function performs a deep comparison.
+func (v *PurgeWorkflowExecutionResponse) Equals(rhs *PurgeWorkflowExecutionResponse) bool {
+	if v == nil {
+		return rhs == nil
+	} else if rhs == nil {
+		return false
+	}
+	if !((v.Steps == nil && rhs.Steps == nil) || (v.Steps != nil && rhs.Steps != nil && _List_PurgeWorkflowExecutionStep_Equals(v.Steps, rhs.Steps))) {
+		return false
+	}
+
+	return true
+}
+
+type _List_PurgeWorkflowExecutionStep_Zapper []*PurgeWorkflowExecutionStep
+
+// MarshalLogArray implements zapcore.ArrayMarshaler, enabling
+// fast logging of _List_PurgeWorkflowExecutionStep_Zapper.
+func (l _List_PurgeWorkflowExecutionStep_Zapper) MarshalLogArray(enc zapcore.ArrayEncoder) (err error) {
+	for _, v := range l {
+		err = multierr.Append(err, enc.AppendObject(v))
+	}
+	return err
+}
+
+// MarshalLogObject implements zapcore.ObjectMarshaler, enabling
+// fast logging of PurgeWorkflowExecutionResponse.
+func (v *PurgeWorkflowExecutionResponse) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+	if v == nil {
+		return nil
+	}
+	if v.Steps != nil {
+		err = multierr.Append(err, enc.AddArray("steps", (_List_PurgeWorkflowExecutionStep_Zapper)(v.Steps)))
+	}
+	return err
+}
+
+// GetSteps returns the value of Steps if it is set or its
+// zero value if it is unset.
+func (v *PurgeWorkflowExecutionResponse) GetSteps() (o []*PurgeWorkflowExecutionStep) {
+	if v != nil && v.Steps != nil {
+		return v.Steps
+	}
+
+	return
+}
+
+// IsSetSteps returns true if Steps is not nil.
+func (v *PurgeWorkflowExecutionResponse) IsSetSteps() bool {
+	return v != nil && v.Steps != nil
+}
+
+type PurgeWorkflowExecutionStep struct {
+	Name         *string `json:"name,omitempty"`
+	DeletedCount *int64  `json:"deletedCount,omitempty"`
+	Verified     *bool   `json:"verified,omitempty"`
+	Details      *string `json:"details,omitempty"`
+}
+
+// ToWire translates a PurgeWorkflowExecutionStep struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *PurgeWorkflowExecutionStep) ToWire() (wire.Value, error) {
+	var (
+		fields [4]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.Name != nil {
+		w, err = wire.NewValueString(*(v.Name)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 10, Value: w}
+		i++
+	}
+	if v.DeletedCount != nil {
+		w, err = wire.NewValueI64(*(v.DeletedCount)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 20, Value: w}
+		i++
+	}
+	if v.Verified != nil {
+		w, err = wire.NewValueBool(*(v.Verified)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 30, Value: w}
+		i++
+	}
+	if v.Details != nil {
+		w, err = wire.NewValueString(*(v.Details)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 40, Value: w}
+		i++
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+// FromWire deserializes a PurgeWorkflowExecutionStep struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a PurgeWorkflowExecutionStep struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v PurgeWorkflowExecutionStep
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *PurgeWorkflowExecutionStep) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 10:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.Name = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 20:
+			if field.Value.Type() == wire.TI64 {
+				var x int64
+				x, err = field.Value.GetI64(), error(nil)
+				v.DeletedCount = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 30:
+			if field.Value.Type() == wire.TBool {
+				var x bool
+				x, err = field.Value.GetBool(), error(nil)
+				v.Verified = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 40:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.Details = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+
+	return nil
+}
+
+// String returns a readable string representation of a PurgeWorkflowExecutionStep
+// struct.
+func (v *PurgeWorkflowExecutionStep) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [4]string
+	i := 0
+	if v.Name != nil {
+		fields[i] = fmt.Sprintf("Name: %v", *(v.Name))
+		i++
+	}
+	if v.DeletedCount != nil {
+		fields[i] = fmt.Sprintf("DeletedCount: %v", *(v.DeletedCount))
+		i++
+	}
+	if v.Verified != nil {
+		fields[i] = fmt.Sprintf("Verified: %v", *(v.Verified))
+		i++
+	}
+	if v.Details != nil {
+		fields[i] = fmt.Sprintf("Details: %v", *(v.Details))
+		i++
+	}
+
+	return fmt.Sprintf("PurgeWorkflowExecutionStep{%v}", strings.Join(fields[:i], ", "))
+}
+
+// Equals returns true if all the fields of this PurgeWorkflowExecutionStep match the
+// provided PurgeWorkflowExecutionStep.
+//
+// This function performs a deep comparison.
+func (v *PurgeWorkflowExecutionStep) Equals(rhs *PurgeWorkflowExecutionStep) bool {
+	if v == nil {
+		return rhs == nil
+	} else if rhs == nil {
+		return false
+	}
+	if !_String_EqualsPtr(v.Name, rhs.Name) {
+		return false
+	}
+	if !_I64_EqualsPtr(v.DeletedCount, rhs.DeletedCount) {
+		return false
+	}
+	if !_Bool_EqualsPtr(v.Verified, rhs.Verified) {
+		return false
+	}
+	if !_String_EqualsPtr(v.Details, rhs.Details) {
+		return false
+	}
+
+	return true
+}
+
+// MarshalLogObject implements zapcore.ObjectMarshaler, enabling
+// fast logging of PurgeWorkflowExecutionStep.
+func (v *PurgeWorkflowExecutionStep) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+	if v == nil {
+		return nil
+	}
+	if v.Name != nil {
+		enc.AddString("name", *v.Name)
+	}
+	if v.DeletedCount != nil {
+		enc.AddInt64("deletedCount", *v.DeletedCount)
+	}
+	if v.Verified != nil {
+		enc.AddBool("verified", *v.Verified)
+	}
+	if v.Details != nil {
+		enc.AddString("details", *v.Details)
+	}
+	return err
+}
+
+// GetName returns the value of Name if it is set or its
+// zero value if it is unset.
+func (v *PurgeWorkflowExecutionStep) GetName() (o string) {
+	if v != nil && v.Name != nil {
+		return *v.Name
+	}
+
+	return
+}
+
+// IsSetName returns true if Name is not nil.
+func (v *PurgeWorkflowExecutionStep) IsSetName() bool {
+	return v != nil && v.Name != nil
+}
+
+// GetDeletedCount returns the value of DeletedCount if it is set or its
+// zero value if it is unset.
+func (v *PurgeWorkflowExecutionStep) GetDeletedCount() (o int64) {
+	if v != nil && v.DeletedCount != nil {
+		return *v.DeletedCount
+	}
+
+	return
+}
+
+// IsSetDeletedCount returns true if DeletedCount is not nil.
+func (v *PurgeWorkflowExecutionStep) IsSetDeletedCount() bool {
+	return v != nil && v.DeletedCount != nil
+}
+
+// GetVerified returns the value of Verified if it is set or its
+// zero value if it is unset.
+func (v *PurgeWorkflowExecutionStep) GetVerified() (o bool) {
+	if v != nil && v.Verified != nil {
+		return *v.Verified
+	}
+
+	return
+}
+
+// IsSetVerified returns true if Verified is not nil.
+func (v *PurgeWorkflowExecutionStep) IsSetVerified() bool {
+	return v != nil && v.Verified != nil
+}
+
+// GetDetails returns the value of Details if it is set or its
+// zero value if it is unset.
+func (v *PurgeWorkflowExecutionStep) GetDetails() (o string) {
+	if v != nil && v.Details != nil {
+		return *v.Details
+	}
+
+	return
+}
+
+// IsSetDetails returns true if Details is not nil.
+func (v *PurgeWorkflowExecutionStep) IsSetDetails() bool {
+	return v != nil && v.Details != nil
+}
+
+type WorkflowExecutionRunInfo struct {
+	RunId               *string                              `json:"runId,omitempty"`
+	FirstExecutionRunId *string                              `json:"firstExecutionRunId,omitempty"`
+	StartTime           *int64                               `json:"startTime,omitempty"`
+	State               *int32                               `json:"state,omitempty"`
+	CloseStatus         *shared.WorkflowExecutionCloseStatus `json:"closeStatus,omitempty"`
+	IsCurrent           *bool                                `json:"isCurrent,omitempty"`
+}
+
+// ToWire translates a WorkflowExecutionRunInfo struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *WorkflowExecutionRunInfo) ToWire() (wire.Value, error) {
+	var (
+		fields [6]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.RunId != nil {
+		w, err = wire.NewValueString(*(v.RunId)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 10, Value: w}
+		i++
+	}
+	if v.FirstExecutionRunId != nil {
+		w, err = wire.NewValueString(*(v.FirstExecutionRunId)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 20, Value: w}
+		i++
+	}
+	if v.StartTime != nil {
+		w, err = wire.NewValueI64(*(v.StartTime)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 30, Value: w}
+		i++
+	}
+	if v.State != nil {
+		w, err = wire.NewValueI32(*(v.State)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 40, Value: w}
+		i++
+	}
+	if v.CloseStatus != nil {
+		w, err = v.CloseStatus.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 50, Value: w}
+		i++
+	}
+	if v.IsCurrent != nil {
+		w, err = wire.NewValueBool(*(v.IsCurrent)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 60, Value: w}
+		i++
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+func _WorkflowExecutionCloseStatus_Read(w wire.Value) (shared.WorkflowExecutionCloseStatus, error) {
+	var v shared.WorkflowExecutionCloseStatus
+	err := v.FromWire(w)
+	return v, err
+}
+
+// FromWire deserializes a WorkflowExecutionRunInfo struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a WorkflowExecutionRunInfo struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v WorkflowExecutionRunInfo
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *WorkflowExecutionRunInfo) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 10:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.RunId = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 20:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.FirstExecutionRunId = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 30:
+			if field.Value.Type() == wire.TI64 {
+				var x int64
+				x, err = field.Value.GetI64(), error(nil)
+				v.StartTime = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 40:
+			if field.Value.Type() == wire.TI32 {
+				var x int32
+				x, err = field.Value.GetI32(), error(nil)
+				v.State = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 50:
+			if field.Value.Type() == wire.TI32 {
+				var x shared.WorkflowExecutionCloseStatus
+				x, err = _WorkflowExecutionCloseStatus_Read(field.Value)
+				v.CloseStatus = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 60:
+			if field.Value.Type() == wire.TBool {
+				var x bool
+				x, err = field.Value.GetBool(), error(nil)
+				v.IsCurrent = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+
+	return nil
+}
+
+// String returns a readable string representation of a WorkflowExecutionRunInfo
+// struct.
+func (v *WorkflowExecutionRunInfo) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [6]string
+	i := 0
+	if v.RunId != nil {
+		fields[i] = fmt.Sprintf("RunId: %v", *(v.RunId))
+		i++
+	}
+	if v.FirstExecutionRunId != nil {
+		fields[i] = fmt.Sprintf("FirstExecutionRunId: %v", *(v.FirstExecutionRunId))
+		i++
+	}
+	if v.StartTime != nil {
+		fields[i] = fmt.Sprintf("StartTime: %v", *(v.StartTime))
+		i++
+	}
+	if v.State != nil {
+		fields[i] = fmt.Sprintf("State: %v", *(v.State))
+		i++
+	}
+	if v.CloseStatus != nil {
+		fields[i] = fmt.Sprintf("CloseStatus: %v", *(v.CloseStatus))
+		i++
+	}
+	if v.IsCurrent != nil {
+		fields[i] = fmt.Sprintf("IsCurrent: %v", *(v.IsCurrent))
+		i++
+	}
+
+	return fmt.Sprintf("WorkflowExecutionRunInfo{%v}", strings.Join(fields[:i], ", "))
+}
+
+func _WorkflowExecutionCloseStatus_EqualsPtr(lhs, rhs *shared.WorkflowExecutionCloseStatus) bool {
+	if lhs != nil && rhs != nil {
+
+		x := *lhs
+		y := *rhs
+		return x.Equals(y)
+	}
+	return lhs == nil && rhs == nil
+}
+
+// Equals returns true if all the fields of this WorkflowExecutionRunInfo match the
+// provided WorkflowExecutionRunInfo.
+//
+// This function performs a deep comparison.
+func (v *WorkflowExecutionRunInfo) Equals(rhs *WorkflowExecutionRunInfo) bool {
+	if v == nil {
+		return rhs == nil
+	} else if rhs == nil {
+		return false
+	}
+	if !_String_EqualsPtr(v.RunId, rhs.RunId) {
+		return false
+	}
+	if !_String_EqualsPtr(v.FirstExecutionRunId, rhs.FirstExecutionRunId) {
+		return false
+	}
+	if !_I64_EqualsPtr(v.StartTime, rhs.StartTime) {
+		return false
+	}
+	if !_I32_EqualsPtr(v.State, rhs.State) {
+		return false
+	}
+	if !_WorkflowExecutionCloseStatus_EqualsPtr(v.CloseStatus, rhs.CloseStatus) {
+		return false
+	}
+	if !_Bool_EqualsPtr(v.IsCurrent, rhs.IsCurrent) {
+		return false
+	}
+
+	return true
+}
+
+// MarshalLogObject implements zapcore.ObjectMarshaler, enabling
+// fast logging of WorkflowExecutionRunInfo.
+func (v *WorkflowExecutionRunInfo) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+	if v == nil {
+		return nil
+	}
+	if v.RunId != nil {
+		enc.AddString("runId", *v.RunId)
+	}
+	if v.FirstExecutionRunId != nil {
+		enc.AddString("firstExecutionRunId", *v.FirstExecutionRunId)
+	}
+	if v.StartTime != nil {
+		enc.AddInt64("startTime", *v.StartTime)
+	}
+	if v.State != nil {
+		enc.AddInt32("state", *v.State)
+	}
+	if v.CloseStatus != nil {
+		err = multierr.Append(err, enc.AddObject("closeStatus", *v.CloseStatus))
+	}
+	if v.IsCurrent != nil {
+		enc.AddBool("isCurrent", *v.IsCurrent)
+	}
+	return err
+}
+
+// GetRunId returns the value of RunId if it is set or its
+// zero value if it is unset.
+func (v *WorkflowExecutionRunInfo) GetRunId() (o string) {
+	if v != nil && v.RunId != nil {
+		return *v.RunId
+	}
+
+	return
+}
+
+// IsSetRunId returns true if RunId is not nil.
+func (v *WorkflowExecutionRunInfo) IsSetRunId() bool {
+	return v != nil && v.RunId != nil
+}
+
+// GetFirstExecutionRunId returns the value of FirstExecutionRunId if it is set or its
+// zero value if it is unset.
+func (v *WorkflowExecutionRunInfo) GetFirstExecutionRunId() (o string) {
+	if v != nil && v.FirstExecutionRunId != nil {
+		return *v.FirstExecutionRunId
+	}
+
+	return
+}
+
+// IsSetFirstExecutionRunId returns true if FirstExecutionRunId is not nil.
+func (v *WorkflowExecutionRunInfo) IsSetFirstExecutionRunId() bool {
+	return v != nil && v.FirstExecutionRunId != nil
+}
+
+// GetStartTime returns the value of StartTime if it is set or its
+// zero value if it is unset.
+func (v *WorkflowExecutionRunInfo) GetStartTime() (o int64) {
+	if v != nil && v.StartTime != nil {
+		return *v.StartTime
+	}
+
+	return
+}
+
+// IsSetStartTime returns true if StartTime is not nil.
+func (v *WorkflowExecutionRunInfo) IsSetStartTime() bool {
+	return v != nil && v.StartTime != nil
+}
+
+// GetState returns the value of State if it is set or its
+// zero value if it is unset.
+func (v *WorkflowExecutionRunInfo) GetState() (o int32) {
+	if v != nil && v.State != nil {
+		return *v.State
+	}
+
+	return
+}
+
+// IsSetState returns true if State is not nil.
+func (v *WorkflowExecutionRunInfo) IsSetState() bool {
+	return v != nil && v.State != nil
+}
+
+// GetCloseStatus returns the value of CloseStatus if it is set or its
+// zero value if it is unset.
+func (v *WorkflowExecutionRunInfo) GetCloseStatus() (o shared.WorkflowExecutionCloseStatus) {
+	if v != nil && v.CloseStatus != nil {
+		return *v.CloseStatus
+	}
+
+	return
+}
+
+// IsSetCloseStatus returns true if CloseStatus is not nil.
+func (v *WorkflowExecutionRunInfo) IsSetCloseStatus() bool {
+	return v != nil && v.CloseStatus != nil
+}
+
+// GetIsCurrent returns the value of IsCurrent if it is set or its
+// zero value if it is unset.
+func (v *WorkflowExecutionRunInfo) GetIsCurrent() (o bool) {
+	if v != nil && v.IsCurrent != nil {
+		return *v.IsCurrent
+	}
+
+	return
+}
+
+// IsSetIsCurrent returns true if IsCurrent is not nil.
+func (v *WorkflowExecutionRunInfo) IsSetIsCurrent() bool {
+	return v != nil && v.IsCurrent != nil
+}
+
+// ThriftModule represents the IDL file used to generate this package.
+var ThriftModule = &thriftreflect.ThriftModule{
+	Name:     "admin",
+	Package:  "github.com/uber/cadence/.gen/go/admin",
+	FilePath: "admin.thrift",
+	SHA1:     "9eae929b1532a929daea6372eb9e1c66c397cc75",
+	Includes: []*thriftreflect.ThriftModule{
+		shared.ThriftModule,
+	},
+	Raw: rawIDL,
+}
+
+const rawIDL = "// Copyright (c) 2017 Uber Technologies, Inc.\n//\n// Permission is hereby granted, free of charge, to any person obtaining a copy\n// of this software and associated documentation files (the \"Software\"), to deal\n// in the Software without restriction, including without limitation the rights\n// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell\n// copies of the Software, and to permit persons to whom the Software is\n// furnished to do so, subject to the following conditions:\n//\n// The above copyright notice and this permission notice shall be included in\n// all copies or substantial portions of the Software.\n//\n// THE SOFTWARE IS PROVIDED \"AS IS\", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR\n// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,\n// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE\n// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER\n// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,\n// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN\n// THE SOFTWARE.\n\nnamespace java com.uber.cadence.admin\n\ninclude \"shared.thrift\"\n\n/**\n* AdminService provides advanced APIs for debugging and analysis with admin privillege\n**/\nservice AdminService {\n  /**\n  * DescribeWorkflowExecution returns information about the internal states of workflow execution.\n  **/\n  DescribeWorkflowExecutionResponse DescribeWorkflowExecution(1: DescribeWorkflowExecutionRequest request)\n    throws (\n      1: shared.BadRequestError         badRequestError,\n      2: shared.InternalServiceError    internalServiceError,\n      3: shared.EntityNotExistsError    entityNotExistError,\n      4: shared.AccessDeniedError       accessDeniedError,\n    )\n\n  /**\n  * DescribeHistoryHost returns information about the internal states of a history host\n  **/\n  shared.DescribeHistoryHostResponse DescribeHistoryHost(1: shared.DescribeHistoryHostRequest request)\n    throws (\n      1: shared.BadRequestError       badRequestError,\n      2: shared.InternalServiceError  internalServiceError,\n      3: shared.AccessDeniedError     accessDeniedError,\n    )\n\n  /**\n  * DrainHistoryHost makes a history host stop acquiring shards and release the shards it owns after\n  * finishing their in-flight writes and persisting their ack levels, so the host can be stopped without\n  * failing requests or reprocessing tasks.\n  **/\n  shared.DrainHistoryHostResponse DrainHistoryHost(1: shared.DrainHistoryHostRequest request)\n    throws (\n      1: shared.BadRequestError       badRequestError,\n      2: shared.InternalServiceError  internalServiceError,\n      3: shared.AccessDeniedError     accessDeniedError,\n    )\n\n  /**\n  * UndrainHistoryHost adds a drained history host back to the membership ring, so it acquires\n  * shards again.\n  **/\n  shared.UndrainHistoryHostResponse UndrainHistoryHost(1: shared.UndrainHistoryHostRequest request)\n    throws (\n      1: shared.BadRequestError       badRequestError,\n      2: shared.InternalServiceError  internalServiceError,\n      3: shared.AccessDeniedError     accessDeniedError,\n    )\n\n  /**\n  * DescribeClientVersions returns the client implementations and versions which completed decisions\n  * for the domain since the history hosts started, so operators can find domains running outdated clients.\n  **/\n  shared.DescribeClientVersionsResponse DescribeClientVersions(1: shared.DescribeClientVersionsRequest request)\n    throws (\n      1: shared.BadRequestError       badRequestError,\n      2: shared.InternalServiceError  internalServiceError,\n      3: shared.AccessDeniedError     accessDeniedError,\n    )\n\n  /**\n  * GetDomainOpenExecutionCount returns the approximate number of open executions of the domain. The count is\n  * maintained by the history shards and periodically reconciled by the scanner.\n  **/\n  shared.GetDomainOpenExecutionCountResponse GetDomainOpenExecutionCount(1: shared.GetDomainOpenExecutionCountRequest request)\n    throws (\n      1: shared.BadRequestError       badRequestError,\n      2: shared.InternalServiceError  internalServiceError,\n      3: shared.EntityNotExistsError  entityNotExistError,\n      4: shared.AccessDeniedError     accessDeniedError,\n    )\n\n  /**\n  * SetActivityPaused pauses or unpauses a pending activity. A paused activity is not dispatched to workers\n  * and its retries do not consume attempts until it is unpaused.\n  **/\n  void SetActivityPaused(1: shared.SetActivityPausedRequest request)\n    throws (\n      1: shared.BadRequestError         badRequestError,\n      2: shared.InternalServiceError    internalServiceError,\n      3: shared.EntityNotExistsError    entityNotExistError,\n      4: shared.DomainNotActiveError    domainNotActiveError,\n      5: shared.AccessDeniedError       accessDeniedError,\n    )\n\n  /**\n  * SetWorkflowExecutionNotes attaches free-form operator notes to a running workflow execution, replacing the\n  * previous ones, or removes them if the notes are empty. The notes are not part of the history and are returned\n  * by DescribeWorkflowExecution.\n  **/\n  void SetWorkflowExecutionNotes(1: shared.SetWorkflowExecutionNotesRequest request)\n    throws (\n      1: shared.BadRequestError         badRequestError,\n      2: shared.InternalServiceError    internalServiceError,\n      3: shared.EntityNotExistsError    entityNotExistError,\n      4: shared.AccessDeniedError       accessDeniedError,\n    )\n\n  /**\n  * SetWorkflowExecutionPaused pauses or resumes a running workflow execution. No decision task of a paused\n  * workflow is dispatched to workers, signals and other events keep being recorded and are delivered with the\n  * first decision task after the workflow is resumed.\n  **/\n  void SetWorkflowExecutionPaused(1: shared.SetWorkflowExecutionPausedRequest request)\n    throws (\n      1: shared.BadRequestError         badRequestError,\n      2: shared.InternalServiceError    internalServiceError,\n      3: shared.EntityNotExistsError    entityNotExistError,\n      4: shared.DomainNotActiveError    domainNotActiveError,\n      5: shared.AccessDeniedError       accessDeniedError,\n    )\n\n  /**\n  * SetCronSchedulePaused pauses or resumes the cron schedule of a workflow without terminating it. Runs which\n  * are waiting for their cron schedule do not start while the schedule is paused, the schedule carries over to\n  * the following runs.\n  **/\n  void SetCronSchedulePaused(1: shared.SetCronSchedulePausedRequest request)\n    throws (\n      1: shared.BadRequestError         badRequestError,\n      2: shared.InternalServiceError    internalServiceError,\n      3: shared.EntityNotExistsError    entityNotExistError,\n      4: shared.DomainNotActiveError    domainNotActiveError,\n      5: shared.AccessDeniedError       accessDeniedError,\n    )\n\n  /**\n  * CreateSchedule creates a schedule which periodically starts a workflow. Schedules are persisted in their own\n  * table and fired by the scheduler running in the worker service, independently of cron workflows.\n  **/\n  void CreateSchedule(1: shared.CreateScheduleRequest request)\n    throws (\n      1: shared.BadRequestError         badRequestError,\n      2: shared.InternalServiceError    internalServiceError,\n      3: shared.EntityNotExistsError    entityNotExistError,\n      4: shared.AccessDeniedError       accessDeniedError,\n    )\n\n  /**\n  * DescribeSchedule returns a schedule along with its next fire time.\n  **/\n  shared.DescribeScheduleResponse DescribeSchedule(1: shared.DescribeScheduleRequest request)\n    throws (\n      1: shared.BadRequestError         badRequestError,\n      2: shared.InternalServiceError    internalServiceError,\n      3: shared.EntityNotExistsError    entityNotExistError,\n      4: shared.AccessDeniedError       accessDeniedError,\n    )\n\n  /**\n  * DeleteSchedule deletes a schedule, workflows already started by the schedule are not affected.\n  **/\n  void DeleteSchedule(1: shared.DeleteScheduleRequest request)\n    throws (\n      1: shared.BadRequestError         badRequestError,\n      2: shared.InternalServiceError    internalServiceError,\n      3: shared.EntityNotExistsError    entityNotExistError,\n      4: shared.AccessDeniedError       accessDeniedError,\n    )\n\n  /**\n  * ListSchedules returns the schedules of a domain.\n  **/\n  shared.ListSchedulesResponse ListSchedules(1: shared.ListSchedulesRequest request)\n    throws (\n      1: shared.BadRequestError         badRequestError,\n      2: shared.InternalServiceError    internalServiceError,\n      3: shared.EntityNotExistsError    entityNotExistError,\n      4: shared.AccessDeniedError       accessDeniedError,\n    )\n\n  /**\n  * SetSchedulePaused pauses or resumes a schedule. Fire times missed while the schedule was paused are started\n  * when it is resumed if they are still within the catch-up window of the schedule.\n  **/\n  void SetSchedulePaused(1: shared.SetSchedulePausedRequest request)\n    throws (\n      1: shared.BadRequestError         badRequestError,\n      2: shared.InternalServiceError    internalServiceError,\n      3: shared.EntityNotExistsError    entityNotExistError,\n      4: shared.AccessDeniedError       accessDeniedError,\n    )\n\n  /**\n  * BackfillSchedule starts the workflows of every fire time of a schedule in the given time range.\n  **/\n  void BackfillSchedule(1: shared.BackfillScheduleRequest request)\n    throws (\n      1: shared.BadRequestError         badRequestError,\n      2: shared.InternalServiceError    internalServiceError,\n      3: shared.EntityNotExistsError    entityNotExistError,\n      4: shared.AccessDeniedError       accessDeniedError,\n    )\n\n  /**\n  * UpdateTaskListVersionSets replaces the worker build ID version sets of a decision task list. Decision tasks of an\n  * execution are dispatched to pollers of the default build of the set containing the build which last completed a\n  * decision of the execution, and decision tasks of new executions to the default build of the newest set.\n  **/\n  void UpdateTaskListVersionSets(1: shared.UpdateTaskListVersionSetsRequest request)\n    throws (\n      1: shared.BadRequestError         badRequestError,\n      2: shared.InternalServiceError    internalServiceError,\n      3: shared.EntityNotExistsError    entityNotExistError,\n      4: shared.AccessDeniedError       accessDeniedError,\n    )\n\n  /**\n  * DescribeTaskJourney returns the stages an activity or decision task recently went through in matching, from its\n  * creation to being recorded as started, to debug tasks reported as lost.\n  **/\n  shared.DescribeTaskJourneyResponse DescribeTaskJourney(1: shared.DescribeTaskJourneyRequest request)\n    throws (\n      1: shared.BadRequestError       badRequestError,\n      2: shared.InternalServiceError  internalServiceError,\n      3: shared.AccessDeniedError     accessDeniedError,\n    )\n\n  /**\n  * DescribeWorkflowShard returns the shard a workflow ID maps to, the history host owning it, the persisted\n  * ack levels of the shard, and whether the mutable state of the current run of the workflow exists.\n  **/\n  /**\n  * TerminateAllWorkflowRuns terminates the current run of a workflow ID together with the runs its chain continues\n  * as new into while it is being terminated, and temporarily rejects continue as new, cron and retry of the\n  * workflow ID so that a terminated cron workflow does not fire again.\n  **/\n  shared.TerminateAllWorkflowRunsResponse TerminateAllWorkflowRuns(1: shared.TerminateAllWorkflowRunsRequest request)\n    throws (\n      1: shared.BadRequestError         badRequestError,\n      2: shared.InternalServiceError    internalServiceError,\n      3: shared.EntityNotExistsError    entityNotExistError,\n      4: shared.DomainNotActiveError    domainNotActiveError,\n      5: shared.AccessDeniedError       accessDeniedError,\n    )\n\n  DescribeWorkflowShardResponse DescribeWorkflowShard(1: DescribeWorkflowShardRequest request)\n    throws (\n      1: shared.BadRequestError         badRequestError,\n      2: shared.InternalServiceError    internalServiceError,\n      3: shared.ServiceBusyError        serviceBusyError,\n      4: shared.AccessDeniedError       accessDeniedError,\n    )\n\n  /**\n  * ListWorkflowExecutionRuns returns all runs of a workflow ID known to the execution store, with their state and\n  * close status, ordered by start time.\n  **/\n  ListWorkflowExecutionRunsResponse ListWorkflowExecutionRuns(1: ListWorkflowExecutionRunsRequest request)\n    throws (\n      1: shared.BadRequestError         badRequestError,\n      2: shared.InternalServiceError    internalServiceError,\n      3: shared.ServiceBusyError        serviceBusyError,\n      4: shared.AccessDeniedError       accessDeniedError,\n    )\n\n  /**\n  * ListRecentlyClosedExecutions returns the executions of a history shard closed since the given time, in close\n  * time order a page at a time, from the closed execution index of the execution store.\n  **/\n  ListRecentlyClosedExecutionsResponse ListRecentlyClosedExecutions(1: ListRecentlyClosedExecutionsRequest request)\n    throws (\n      1: shared.BadRequestError         badRequestError,\n      2: shared.InternalServiceError    internalServiceError,\n      3: shared.ServiceBusyError        serviceBusyError,\n      4: shared.AccessDeniedError       accessDeniedError,\n    )\n\n  /**\n  * ImportWorkflowExecution recreates a closed workflow execution from a snapshot written by the export admin command\n  * as a new run of its workflow in the given domain, restoring its mutable state and history.\n  **/\n  ImportWorkflowExecutionResponse ImportWorkflowExecution(1: ImportWorkflowExecutionRequest request)\n    throws (\n      1: shared.BadRequestError         badRequestError,\n      2: shared.InternalServiceError    internalServiceError,\n      3: shared.ServiceBusyError        serviceBusyError,\n      4: shared.AccessDeniedError       accessDeniedError,\n    )\n\n  /**\n  * PurgeWorkflowExecution permanently deletes every trace of a closed workflow run: its mutable state, current\n  * record, history, visibility records, archived history blobs and buffered replication tasks, in every cluster of\n  * the domain. It returns a report of what was deleted and whether each deletion could be verified by reading it back.\n  **/\n  PurgeWorkflowExecutionResponse PurgeWorkflowExecution(1: PurgeWorkflowExecutionRequest request)\n    throws (\n      1: shared.BadRequestError         badRequestError,\n      2: shared.InternalServiceError    internalServiceError,\n      3: shared.ServiceBusyError        serviceBusyError,\n      4: shared.AccessDeniedError       accessDeniedError,\n    )\n\n  /**\n  * Returns the raw history of specified workflow execution.  It fails with 'EntityNotExistError' if speficied workflow\n  * execution in unknown to the service.\n  **/\n  GetWorkflowExecutionRawHistoryResponse GetWorkflowExecutionRawHistory(1: GetWorkflowExecutionRawHistoryRequest getRequest)\n    throws (\n      1: shared.BadRequestError badRequestError,\n      2: shared.InternalServiceError internalServiceError,\n      3: shared.EntityNotExistsError entityNotExistError,\n      4: shared.ServiceBusyError serviceBusyError,\n    )\n}\n\nstruct DescribeWorkflowExecutionRequest {\n  10: optional string                       domain\n  20: optional shared.WorkflowExecution     execution\n}\n\nstruct DescribeWorkflowExecutionResponse{\n  10: optional string shardId\n  20: optional string historyAddr\n  40: optional string mutableStateInCache\n  50: optional string mutableStateInDatabase\n  60: optional i64 (js.type = \"Long\") lastFirstEventId\n  70: optional i64 (js.type = \"Long\") lastEventTaskId\n}\n\nstruct DescribeWorkflowShardRequest {\n  10: optional string domainId\n  20: optional string workflowId\n}\n\nstruct DescribeWorkflowShardResponse {\n  10: optional i32 shardId\n  20: optional string historyAddr\n  30: optional string shardOwner\n  40: optional i64 (js.type = \"Long\") rangeId\n  50: optional i64 (js.type = \"Long\") transferAckLevel\n  60: optional i64 (js.type = \"Long\") timerAckLevel\n  70: optional i64 (js.type = \"Long\") replicationAckLevel\n  80: optional map<string, i64> clusterTransferAckLevel\n  90: optional map<string, i64> clusterTimerAckLevel\n  100: optional string currentRunId\n  110: optional bool mutableStateExists\n}\n\nstruct ListWorkflowExecutionRunsRequest {\n  10: optional string domain\n  20: optional string workflowId\n}\n\nstruct WorkflowExecutionRunInfo {\n  10: optional string runId\n  20: optional string firstExecutionRunId\n  30: optional i64 (js.type = \"Long\") startTime\n  40: optional i32 state\n  50: optional shared.WorkflowExecutionCloseStatus closeStatus\n  60: optional bool isCurrent\n}\n\nstruct ListWorkflowExecutionRunsResponse {\n  10: optional list<WorkflowExecutionRunInfo> runs\n}\n\nstruct ListRecentlyClosedExecutionsRequest {\n  10: optional i32 shardId\n  20: optional i64 (js.type = \"Long\") closedAfter\n  30: optional i32 maximumPageSize\n  40: optional binary nextPageToken\n}\n\nstruct ClosedExecutionInfo {\n  10: optional string domainId\n  20: optional string workflowId\n  30: optional string runId\n  40: optional i64 (js.type = \"Long\") closeTime\n}\n\nstruct ListRecentlyClosedExecutionsResponse {\n  10: optional list<ClosedExecutionInfo> executions\n  20: optional binary nextPageToken\n}\n\nstruct ImportWorkflowExecutionRequest {\n  10: optional string domain\n  20: optional binary snapshot\n}\n\nstruct ImportWorkflowExecutionResponse {\n  10: optional string runId\n}\n\nstruct PurgeWorkflowExecutionRequest {\n  10: optional string domain\n  20: optional shared.WorkflowExecution execution\n  // only purge the cluster receiving the request, set when the purge is forwarded to the other clusters of a global\n  // domain\n  30: optional bool currentClusterOnly\n}\n\nstruct PurgeWorkflowExecutionStep {\n  10: optional string name\n  20: optional i64 deletedCount\n  30: optional bool verified\n  40: optional string details\n}\n\nstruct PurgeWorkflowExecutionResponse {\n  10: optional list<PurgeWorkflowExecutionStep> steps\n}\n\nstruct GetWorkflowExecutionRawHistoryRequest {\n  10: optional string domain\n  20: optional shared.WorkflowExecution execution\n  30: optional i64 (js.type = \"Long\") firstEventId\n  40: optional i64 (js.type = \"Long\") nextEventId\n  50: optional i32 maximumPageSize\n  60: optional binary nextPageToken\n}\n\nstruct GetWorkflowExecutionRawHistoryResponse {\n  10: optional binary nextPageToken\n  20: optional list<shared.DataBlob> historyBatches\n  30: optional map<string, shared.ReplicationInfo> replicationInfo\n  40: optional i32 eventStoreVersion\n}"
+
+// AdminService_BackfillSchedule_Args represents the arguments for the AdminService.BackfillSchedule function.
+//
+// The arguments for BackfillSchedule are sent and received over the wire as this struct.
+type AdminService_BackfillSchedule_Args struct {
+	Request *shared.BackfillScheduleRequest `json:"request,omitempty"`
+}
+
+// ToWire translates a AdminService_BackfillSchedule_Args struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *AdminService_BackfillSchedule_Args) ToWire() (wire.Value, error) {
+	var (
+		fields [1]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.Request != nil {
+		w, err = v.Request.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 1, Value: w}
+		i++
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+func _BackfillScheduleRequest_Read(w wire.Value) (*shared.BackfillScheduleRequest, error) {
+	var v shared.BackfillScheduleRequest
+	err := v.FromWire(w)
+	return &v, err
+}
+
+// FromWire deserializes a AdminService_BackfillSchedule_Args struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a AdminService_BackfillSchedule_Args struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v AdminService_BackfillSchedule_Args
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *AdminService_BackfillSchedule_Args) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 1:
+			if field.Value.Type() == wire.TStruct {
+				v.Request, err = _BackfillScheduleRequest_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+
+	return nil
+}
+
+// String returns a readable string representation of a AdminService_BackfillSchedule_Args
+// struct.
+func (v *AdminService_BackfillSchedule_Args) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [1]string
+	i := 0
+	if v.Request != nil {
+		fields[i] = fmt.Sprintf("Request: %v", v.Request)
+		i++
+	}
+
+	return fmt.Sprintf("AdminService_BackfillSchedule_Args{%v}", strings.Join(fields[:i], ", "))
+}
+
+// Equals returns true if all the fields of this AdminService_BackfillSchedule_Args match the
+// provided AdminService_BackfillSchedule_Args.
+//
+// This function performs a deep comparison.
+func (v *AdminService_BackfillSchedule_Args) Equals(rhs *AdminService_BackfillSchedule_Args) bool {
+	if v == nil {
+		return rhs == nil
+	} else if rhs == nil {
+		return false
+	}
+	if !((v.Request == nil && rhs.Request == nil) || (v.Request != nil && rhs.Request != nil && v.Request.Equals(rhs.Request))) {
+		return false
+	}
+
+	return true
+}
+
+// MarshalLogObject implements zapcore.ObjectMarshaler, enabling
+// fast logging of AdminService_BackfillSchedule_Args.
+func (v *AdminService_BackfillSchedule_Args) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+	if v == nil {
+		return nil
+	}
+	if v.Request != nil {
+		err = multierr.Append(err, enc.AddObject("request", v.Request))
+	}
+	return err
+}
+
+// GetRequest returns the value of Request if it is set or its
+// zero value if it is unset.
+func (v *AdminService_BackfillSchedule_Args) GetRequest() (o *shared.BackfillScheduleRequest) {
+	if v != nil && v.Request != nil {
+		return v.Request
+	}
+
+	return
+}
+
+// IsSetRequest returns true if Request is not nil.
+func (v *AdminService_BackfillSchedule_Args) IsSetRequest() bool {
+	return v != nil && v.Request != nil
+}
+
+// MethodName returns the name of the Thrift function as specified in
+// the IDL, for which this struct represent the arguments.
+//
+// This will always be "BackfillSchedule" for this struct.
+func (v *AdminService_BackfillSchedule_Args) MethodName() string {
+	return "BackfillSchedule"
+}
+
+// EnvelopeType returns the kind of value inside this struct.
+//
+// This will always be Call for this struct.
+func (v *AdminService_BackfillSchedule_Args) EnvelopeType() wire.EnvelopeType {
+	return wire.Call
+}
+
+// AdminService_BackfillSchedule_Helper provides functions that aid in handling the
+// parameters and return values of the AdminService.BackfillSchedule
+// function.
+var AdminService_BackfillSchedule_Helper = struct {
+	// Args accepts the parameters of BackfillSchedule in-order and returns
+	// the arguments struct for the function.
+	Args func(
+		request *shared.BackfillScheduleRequest,
+	) *AdminService_BackfillSchedule_Args
+
+	// IsException returns true if the given error can be thrown
+	// by BackfillSchedule.
+	//
+	// An error can be thrown by BackfillSchedule only if the
+	// corresponding exception type was mentioned in the 'throws'
+	// section for it in the Thrift file.
+	IsException func(error) bool
+
+	// WrapResponse returns the result struct for BackfillSchedule
+	// given the error returned by it. The provided error may
+	// be nil if BackfillSchedule did not fail.
+	//
+	// This allows mapping errors returned by BackfillSchedule into a
+	// serializable result struct. WrapResponse returns a
+	// non-nil error if the provided error cannot be thrown by
+	// BackfillSchedule
+	//
+	//   err := BackfillSchedule(args)
+	//   result, err := AdminService_BackfillSchedule_Helper.WrapResponse(err)
+	//   if err != nil {
+	//     return fmt.Errorf("unexpected error from BackfillSchedule: %v", err)
+	//   }
+	//   serialize(result)
+	WrapResponse func(error) (*AdminService_BackfillSchedule_Result, error)
+
+	// UnwrapResponse takes the result struct for BackfillSchedule
+	// and returns the erorr returned by it (if any).
+	//
+	// The error is non-nil only if BackfillSchedule threw an
+	// exception.
+	//
+	//   result := deserialize(bytes)
+	//   err := AdminService_BackfillSchedule_Helper.UnwrapResponse(result)
+	UnwrapResponse func(*AdminService_BackfillSchedule_Result) error
+}{}
+
+func init() {
+	AdminService_BackfillSchedule_Helper.Args = func(
+		request *shared.BackfillScheduleRequest,
+	) *AdminService_BackfillSchedule_Args {
+		return &AdminService_BackfillSchedule_Args{
+			Request: request,
+		}
+	}
+
+	AdminService_BackfillSchedule_Helper.IsException = func(err error) bool {
+		switch err.(type) {
+		case *shared.BadRequestError:
+			return true
+		case *shared.InternalServiceError:
+			return true
+		case *shared.EntityNotExistsError:
+			return true
+		case *shared.AccessDeniedError:
+			return true
+		default:
+			return false
+		}
+	}
+
+	AdminService_BackfillSchedule_Helper.WrapResponse = func(err error) (*AdminService_BackfillSchedule_Result, error) {
+		if err == nil {
+			return &AdminService_BackfillSchedule_Result{}, nil
+		}
+
+		switch e := err.(type) {
+		case *shared.BadRequestError:
+			if e == nil {
+				return nil, errors.New("WrapResponse received non-nil error type with nil value for AdminService_BackfillSchedule_Result.BadRequestError")
+			}
+			return &AdminService_BackfillSchedule_Result{BadRequestError: e}, nil
+		case *shared.InternalServiceError:
+			if e == nil {
+				return nil, errors.New("WrapResponse received non-nil error type with nil value for AdminService_BackfillSchedule_Result.InternalServiceError")
+			}
+			return &AdminService_BackfillSchedule_Result{InternalServiceError: e}, nil
+		case *shared.EntityNotExistsError:
+			if e == nil {
+				return nil, errors.New("WrapResponse received non-nil error type with nil value for AdminService_BackfillSchedule_Result.EntityNotExistError")
+			}
+			return &AdminService_BackfillSchedule_Result{EntityNotExistError: e}, nil
+		case *shared.AccessDeniedError:
+			if e == nil {
+				return nil, errors.New("WrapResponse received non-nil error type with nil value for AdminService_BackfillSchedule_Result.AccessDeniedError")
+			}
+			return &AdminService_BackfillSchedule_Result{AccessDeniedError: e}, nil
+		}
+
+		return nil, err
+	}
+	AdminService_BackfillSchedule_Helper.UnwrapResponse = func(result *AdminService_BackfillSchedule_Result) (err error) {
+		if result.BadRequestError != nil {
+			err = result.BadRequestError
+			return
+		}
+		if result.InternalServiceError != nil {
+			err = result.InternalServiceError
+			return
+		}
+		if result.EntityNotExistError != nil {
+			err = result.EntityNotExistError
+			return
+		}
+		if result.AccessDeniedError != nil {
+			err = result.AccessDeniedError
+			return
+		}
+		return
+	}
+
+}
+
+// AdminService_BackfillSchedule_Result represents the result of a AdminService.BackfillSchedule function call.
+//
+// The result of a BackfillSchedule execution is sent and received over the wire as this struct.
+type AdminService_BackfillSchedule_Result struct {
+	BadRequestError      *shared.BadRequestError      `json:"badRequestError,omitempty"`
+	InternalServiceError *shared.InternalServiceError `json:"internalServiceError,omitempty"`
+	EntityNotExistError  *shared.EntityNotExistsError `json:"entityNotExistError,omitempty"`
+	AccessDeniedError    *shared.AccessDeniedError    `json:"accessDeniedError,omitempty"`
+}
+
+// ToWire translates a AdminService_BackfillSchedule_Result struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *AdminService_BackfillSchedule_Result) ToWire() (wire.Value, error) {
+	var (
+		fields [4]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.BadRequestError != nil {
+		w, err = v.BadRequestError.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 1, Value: w}
+		i++
+	}
+	if v.InternalServiceError != nil {
+		w, err = v.InternalServiceError.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 2, Value: w}
+		i++
+	}
+	if v.EntityNotExistError != nil {
+		w, err = v.EntityNotExistError.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 3, Value: w}
+		i++
+	}
+	if v.AccessDeniedError != nil {
+		w, err = v.AccessDeniedError.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 4, Value: w}
+		i++
+	}
+
+	if i > 1 {
+		return wire.Value{}, fmt.Errorf("AdminService_BackfillSchedule_Result should have at most one field: got %v fields", i)
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+// FromWire deserializes a AdminService_BackfillSchedule_Result struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a AdminService_BackfillSchedule_Result struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v AdminService_BackfillSchedule_Result
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *AdminService_BackfillSchedule_Result) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 1:
+			if field.Value.Type() == wire.TStruct {
+				v.BadRequestError, err = _BadRequestError_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 2:
+			if field.Value.Type() == wire.TStruct {
+				v.InternalServiceError, err = _InternalServiceError_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 3:
+			if field.Value.Type() == wire.TStruct {
+				v.EntityNotExistError, err = _EntityNotExistsError_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 4:
+			if field.Value.Type() == wire.TStruct {
+				v.AccessDeniedError, err = _AccessDeniedError_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+
+	count := 0
+	if v.BadRequestError != nil {
+		count++
+	}
+	if v.InternalServiceError != nil {
+		count++
+	}
+	if v.EntityNotExistError != nil {
+		count++
+	}
+	if v.AccessDeniedError != nil {
+		count++
+	}
+	if count > 1 {
+		return fmt.Errorf("AdminService_BackfillSchedule_Result should have at most one field: got %v fields", count)
+	}
+
+	return nil
+}
+
+// String returns a readable string representation of a AdminService_BackfillSchedule_Result
+// struct.
+func (v *AdminService_BackfillSchedule_Result) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [4]string
+	i := 0
+	if v.BadRequestError != nil {
+		fields[i] = fmt.Sprintf("BadRequestError: %v", v.BadRequestError)
+		i++
+	}
+	if v.InternalServiceError != nil {
+		fields[i] = fmt.Sprintf("InternalServiceError: %v", v.InternalServiceError)
+		i++
+	}
+	if v.EntityNotExistError != nil {
+		fields[i] = fmt.Sprintf("EntityNotExistError: %v", v.EntityNotExistError)
+		i++
+	}
+	if v.AccessDeniedError != nil {
+		fields[i] = fmt.Sprintf("AccessDeniedError: %v", v.AccessDeniedError)
+		i++
+	}
+
+	return fmt.Sprintf("AdminService_BackfillSchedule_Result{%v}", strings.Join(fields[:i], ", "))
+}
+
+// Equals returns true if all the fields of this AdminService_BackfillSchedule_Result match the
+// provided AdminService_BackfillSchedule_Result.
+//
+// This function performs a deep comparison.
+func (v *AdminService_BackfillSchedule_Result) Equals(rhs *AdminService_BackfillSchedule_Result) bool {
+	if v == nil {
+		return rhs == nil
+	} else if rhs == nil {
+		return false
+	}
+	if !((v.BadRequestError == nil && rhs.BadRequestError == nil) || (v.BadRequestError != nil && rhs.BadRequestError != nil && v.BadRequestError.Equals(rhs.BadRequestError))) {
+		return false
+	}
+	if !((v.InternalServiceError == nil && rhs.InternalServiceError == nil) || (v.InternalServiceError != nil && rhs.InternalServiceError != nil && v.InternalServiceError.Equals(rhs.InternalServiceError))) {
+		return false
+	}
+	if !((v.EntityNotExistError == nil && rhs.EntityNotExistError == nil) || (v.EntityNotExistError != nil && rhs.EntityNotExistError != nil && v.EntityNotExistError.Equals(rhs.EntityNotExistError))) {
+		return false
+	}
+	if !((v.AccessDeniedError == nil && rhs.AccessDeniedError == nil) || (v.AccessDeniedError != nil && rhs.AccessDeniedError != nil && v.AccessDeniedError.Equals(rhs.AccessDeniedError))) {
+		return false
+	}
+
+	return true
+}
+
+// MarshalLogObject implements zapcore.ObjectMarshaler, enabling
+// fast logging of AdminService_BackfillSchedule_Result.
+func (v *AdminService_BackfillSchedule_Result) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+	if v == nil {
+		return nil
+	}
+	if v.BadRequestError != nil {
+		err = multierr.Append(err, enc.AddObject("badRequestError", v.BadRequestError))
+	}
+	if v.InternalServiceError != nil {
+		err = multierr.Append(err, enc.AddObject("internalServiceError", v.InternalServiceError))
+	}
+	if v.EntityNotExistError != nil {
+		err = multierr.Append(err, enc.AddObject("entityNotExistError", v.EntityNotExistError))
+	}
+	if v.AccessDeniedError != nil {
+		err = multierr.Append(err, enc.AddObject("accessDeniedError", v.AccessDeniedError))
+	}
+	return err
+}
+
+// GetBadRequestError returns the value of BadRequestError if it is set or its
+// zero value if it is unset.
+func (v *AdminService_BackfillSchedule_Result) GetBadRequestError() (o *shared.BadRequestError) {
+	if v != nil && v.BadRequestError != nil {
+		return v.BadRequestError
+	}
+
+	return
+}
+
+// IsSetBadRequestError returns true if BadRequestError is not nil.
+func (v *AdminService_BackfillSchedule_Result) IsSetBadRequestError() bool {
+	return v != nil && v.BadRequestError != nil
+}
+
+// GetInternalServiceError returns the value of InternalServiceError if it is set or its
+// zero value if it is unset.
+func (v *AdminService_BackfillSchedule_Result) GetInternalServiceError() (o *shared.InternalServiceError) {
+	if v != nil && v.InternalServiceError != nil {
+		return v.InternalServiceError
+	}
+
+	return
+}
+
+// IsSetInternalServiceError returns true if InternalServiceError is not nil.
+func (v *AdminService_BackfillSchedule_Result) IsSetInternalServiceError() bool {
+	return v != nil && v.InternalServiceError != nil
+}
+
+// GetEntityNotExistError returns the value of EntityNotExistError if it is set or its
+// zero value if it is unset.
+func (v *AdminService_BackfillSchedule_Result) GetEntityNotExistError() (o *shared.EntityNotExistsError) {
+	if v != nil && v.EntityNotExistError != nil {
+		return v.EntityNotExistError
+	}
+
+	return
+}
+
+// IsSetEntityNotExistError returns true if EntityNotExistError is not nil.
+func (v *AdminService_BackfillSchedule_Result) IsSetEntityNotExistError() bool {
+	return v != nil && v.EntityNotExistError != nil
+}
+
+// GetAccessDeniedError returns the value of AccessDeniedError if it is set or its
+// zero value if it is unset.
+func (v *AdminService_BackfillSchedule_Result) GetAccessDeniedError() (o *shared.AccessDeniedError) {
+	if v != nil && v.AccessDeniedError != nil {
+		return v.AccessDeniedError
+	}
+
+	return
+}
+
+// IsSetAccessDeniedError returns true if AccessDeniedError is not nil.
+func (v *AdminService_BackfillSchedule_Result) IsSetAccessDeniedError() bool {
+	return v != nil && v.AccessDeniedError != nil
+}
+
+// MethodName returns the name of the Thrift function as specified in
+// the IDL, for which this struct represent the result.
+//
+// This will always be "BackfillSchedule" for this struct.
+func (v *AdminService_BackfillSchedule_Result) MethodName() string {
+	return "BackfillSchedule"
+}
+
+// EnvelopeType returns the kind of value inside this struct.
+//
+// This will always be Reply for this struct.
+func (v *AdminService_BackfillSchedule_Result) EnvelopeType() wire.EnvelopeType {
+	return wire.Reply
+}
+
+// AdminService_CreateSchedule_Args represents the arguments for the AdminService.CreateSchedule function.
+//
+// The arguments for CreateSchedule are sent and received over the wire as this struct.
+type AdminService_CreateSchedule_Args struct {
+	Request *shared.CreateScheduleRequest `json:"request,omitempty"`
+}
+
+// ToWire translates a AdminService_CreateSchedule_Args struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *AdminService_CreateSchedule_Args) ToWire() (wire.Value, error) {
+	var (
+		fields [1]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.Request != nil {
+		w, err = v.Request.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 1, Value: w}
+		i++
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+func _CreateScheduleRequest_Read(w wire.Value) (*shared.CreateScheduleRequest, error) {
+	var v shared.CreateScheduleRequest
+	err := v.FromWire(w)
+	return &v, err
+}
+
+// FromWire deserializes a AdminService_CreateSchedule_Args struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a AdminService_CreateSchedule_Args struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v AdminService_CreateSchedule_Args
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *AdminService_CreateSchedule_Args) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 1:
+			if field.Value.Type() == wire.TStruct {
+				v.Request, err = _CreateScheduleRequest_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+
+	return nil
+}
+
+// String returns a readable string representation of a AdminService_CreateSchedule_Args
+// struct.
+func (v *AdminService_CreateSchedule_Args) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [1]string
+	i := 0
+	if v.Request != nil {
+		fields[i] = fmt.Sprintf("Request: %v", v.Request)
+		i++
+	}
+
+	return fmt.Sprintf("AdminService_CreateSchedule_Args{%v}", strings.Join(fields[:i], ", "))
+}
+
+// Equals returns true if all the fields of this AdminService_CreateSchedule_Args match the
+// provided AdminService_CreateSchedule_Args.
+//
+// This function performs a deep comparison.
+func (v *AdminService_CreateSchedule_Args) Equals(rhs *AdminService_CreateSchedule_Args) bool {
+	if v == nil {
+		return rhs == nil
+	} else if rhs == nil {
+		return false
+	}
+	if !((v.Request == nil && rhs.Request == nil) || (v.Request != nil && rhs.Request != nil && v.Request.Equals(rhs.Request))) {
+		return false
+	}
+
+	return true
+}
+
+// MarshalLogObject implements zapcore.ObjectMarshaler, enabling
+// fast logging of AdminService_CreateSchedule_Args.
+func (v *AdminService_CreateSchedule_Args) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+	if v == nil {
+		return nil
+	}
+	if v.Request != nil {
+		err = multierr.Append(err, enc.AddObject("request", v.Request))
+	}
+	return err
+}
+
+// GetRequest returns the value of Request if it is set or its
+// zero value if it is unset.
+func (v *AdminService_CreateSchedule_Args) GetRequest() (o *shared.CreateScheduleRequest) {
+	if v != nil && v.Request != nil {
+		return v.Request
+	}
+
+	return
+}
+
+// IsSetRequest returns true if Request is not nil.
+func (v *AdminService_CreateSchedule_Args) IsSetRequest() bool {
+	return v != nil && v.Request != nil
+}
+
+// MethodName returns the name of the Thrift function as specified in
+// the IDL, for which this struct represent the arguments.
+//
+// This will always be "CreateSchedule" for this struct.
+func (v *AdminService_CreateSchedule_Args) MethodName() string {
+	return "CreateSchedule"
+}
+
+// EnvelopeType returns the kind of value inside this struct.
+//
+// This will always be Call for this struct.
+func (v *AdminService_CreateSchedule_Args) EnvelopeType() wire.EnvelopeType {
+	return wire.Call
+}
+
+// AdminService_CreateSchedule_Helper provides functions that aid in handling the
+// parameters and return values of the AdminService.CreateSchedule
+// function.
+var AdminService_CreateSchedule_Helper = struct {
+	// Args accepts the parameters of CreateSchedule in-order and returns
+	// the arguments struct for the function.
+	Args func(
+		request *shared.CreateScheduleRequest,
+	) *AdminService_CreateSchedule_Args
+
+	// IsException returns true if the given error can be thrown
+	// by CreateSchedule.
+	//
+	// An error can be thrown by CreateSchedule only if the
+	// corresponding exception type was mentioned in the 'throws'
+	// section for it in the Thrift file.
+	IsException func(error) bool
+
+	// WrapResponse returns the result struct for CreateSchedule
+	// given the error returned by it. The provided error may
+	// be nil if CreateSchedule did not fail.
+	//
+	// This allows mapping errors returned by CreateSchedule into a
+	// serializable result struct. WrapResponse returns a
+	// non-nil error if the provided error cannot be thrown by
+	// CreateSchedule
+	//
+	//   err := CreateSchedule(args)
+	//   result, err := AdminService_CreateSchedule_Helper.WrapResponse(err)
+	//   if err != nil {
+	//     return fmt.Errorf("unexpected error from CreateSchedule: %v", err)
+	//   }
+	//   serialize(result)
+	WrapResponse func(error) (*AdminService_CreateSchedule_Result, error)
+
+	// UnwrapResponse takes the result struct for CreateSchedule
+	// and returns the erorr returned by it (if any).
+	//
+	// The error is non-nil only if CreateSchedule threw an
+	// exception.
+	//
+	//   result := deserialize(bytes)
+	//   err := AdminService_CreateSchedule_Helper.UnwrapResponse(result)
+	UnwrapResponse func(*AdminService_CreateSchedule_Result) error
+}{}
+
+func init() {
+	AdminService_CreateSchedule_Helper.Args = func(
+		request *shared.CreateScheduleRequest,
+	) *AdminService_CreateSchedule_Args {
+		return &AdminService_CreateSchedule_Args{
+			Request: request,
+		}
+	}
+
+	AdminService_CreateSchedule_Helper.IsException = func(err error) bool {
+		switch err.(type) {
+		case *shared.BadRequestError:
+			return true
+		case *shared.InternalServiceError:
+			return true
+		case *shared.EntityNotExistsError:
+			return true
+		case *shared.AccessDeniedError:
+			return true
+		default:
+			return false
+		}
+	}
+
+	AdminService_CreateSchedule_Helper.WrapResponse = func(err error) (*AdminService_CreateSchedule_Result, error) {
+		if err == nil {
+			return &AdminService_CreateSchedule_Result{}, nil
+		}
+
+		switch e := err.(type) {
+		case *shared.BadRequestError:
+			if e == nil {
+				return nil, errors.New("WrapResponse received non-nil error type with nil value for AdminService_CreateSchedule_Result.BadRequestError")
+			}
+			return &AdminService_CreateSchedule_Result{BadRequestError: e}, nil
+		case *shared.InternalServiceError:
+			if e == nil {
+				return nil, errors.New("WrapResponse received non-nil error type with nil value for AdminService_CreateSchedule_Result.InternalServiceError")
+			}
+			return &AdminService_CreateSchedule_Result{InternalServiceError: e}, nil
+		case *shared.EntityNotExistsError:
+			if e == nil {
+				return nil, errors.New("WrapResponse received non-nil error type with nil value for AdminService_CreateSchedule_Result.EntityNotExistError")
+			}
+			return &AdminService_CreateSchedule_Result{EntityNotExistError: e}, nil
+		case *shared.AccessDeniedError:
+			if e == nil {
+				return nil, errors.New("WrapResponse received non-nil error type with nil value for AdminService_CreateSchedule_Result.AccessDeniedError")
+			}
+			return &AdminService_CreateSchedule_Result{AccessDeniedError: e}, nil
+		}
+
+		return nil, err
+	}
+	AdminService_CreateSchedule_Helper.UnwrapResponse = func(result *AdminService_CreateSchedule_Result) (err error) {
+		if result.BadRequestError != nil {
+			err = result.BadRequestError
+			return
+		}
+		if result.InternalServiceError != nil {
+			err = result.InternalServiceError
+			return
+		}
+		if result.EntityNotExistError != nil {
+			err = result.EntityNotExistError
+			return
+		}
+		if result.AccessDeniedError != nil {
+			err = result.AccessDeniedError
+			return
+		}
+		return
+	}
+
+}
+
+// AdminService_CreateSchedule_Result represents the result of a AdminService.CreateSchedule function call.
+//
+// The result of a CreateSchedule execution is sent and received over the wire as this struct.
+type AdminService_CreateSchedule_Result struct {
+	BadRequestError      *shared.BadRequestError      `json:"badRequestError,omitempty"`
+	InternalServiceError *shared.InternalServiceError `json:"internalServiceError,omitempty"`
+	EntityNotExistError  *shared.EntityNotExistsError `json:"entityNotExistError,omitempty"`
+	AccessDeniedError    *shared.AccessDeniedError    `json:"accessDeniedError,omitempty"`
+}
+
+// ToWire translates a AdminService_CreateSchedule_Result struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *AdminService_CreateSchedule_Result) ToWire() (wire.Value, error) {
+	var (
+		fields [4]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.BadRequestError != nil {
+		w, err = v.BadRequestError.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 1, Value: w}
+		i++
+	}
+	if v.InternalServiceError != nil {
+		w, err = v.InternalServiceError.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 2, Value: w}
+		i++
+	}
+	if v.EntityNotExistError != nil {
+		w, err = v.EntityNotExistError.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 3, Value: w}
+		i++
+	}
+	if v.AccessDeniedError != nil {
+		w, err = v.AccessDeniedError.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 4, Value: w}
+		i++
+	}
+
+	if i > 1 {
+		return wire.Value{}, fmt.Errorf("AdminService_CreateSchedule_Result should have at most one field: got %v fields", i)
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+// FromWire deserializes a AdminService_CreateSchedule_Result struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a AdminService_CreateSchedule_Result struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v AdminService_CreateSchedule_Result
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *AdminService_CreateSchedule_Result) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 1:
+			if field.Value.Type() == wire.TStruct {
+				v.BadRequestError, err = _BadRequestError_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 2:
+			if field.Value.Type() == wire.TStruct {
+				v.InternalServiceError, err = _InternalServiceError_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 3:
+			if field.Value.Type() == wire.TStruct {
+				v.EntityNotExistError, err = _EntityNotExistsError_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 4:
+			if field.Value.Type() == wire.TStruct {
+				v.AccessDeniedError, err = _AccessDeniedError_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+
+	count := 0
+	if v.BadRequestError != nil {
+		count++
+	}
+	if v.InternalServiceError != nil {
+		count++
+	}
+	if v.EntityNotExistError != nil {
+		count++
+	}
+	if v.AccessDeniedError != nil {
+		count++
+	}
+	if count > 1 {
+		return fmt.Errorf("AdminService_CreateSchedule_Result should have at most one field: got %v fields", count)
+	}
+
+	return nil
+}
+
+// String returns a readable string representation of a AdminService_CreateSchedule_Result
+// struct.
+func (v *AdminService_CreateSchedule_Result) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [4]string
+	i := 0
+	if v.BadRequestError != nil {
+		fields[i] = fmt.Sprintf("BadRequestError: %v", v.BadRequestError)
+		i++
+	}
+	if v.InternalServiceError != nil {
+		fields[i] = fmt.Sprintf("InternalServiceError: %v", v.InternalServiceError)
+		i++
+	}
+	if v.EntityNotExistError != nil {
+		fields[i] = fmt.Sprintf("EntityNotExistError: %v", v.EntityNotExistError)
+		i++
+	}
+	if v.AccessDeniedError != nil {
+		fields[i] = fmt.Sprintf("AccessDeniedError: %v", v.AccessDeniedError)
+		i++
+	}
+
+	return fmt.Sprintf("AdminService_CreateSchedule_Result{%v}", strings.Join(fields[:i], ", "))
+}
+
+// Equals returns true if all the fields of this AdminService_CreateSchedule_Result match the
+// provided AdminService_CreateSchedule_Result.
+//
+// This function performs a deep comparison.
+func (v *AdminService_CreateSchedule_Result) Equals(rhs *AdminService_CreateSchedule_Result) bool {
+	if v == nil {
+		return rhs == nil
+	} else if rhs == nil {
+		return false
+	}
+	if !((v.BadRequestError == nil && rhs.BadRequestError == nil) || (v.BadRequestError != nil && rhs.BadRequestError != nil && v.BadRequestError.Equals(rhs.BadRequestError))) {
+		return false
+	}
+	if !((v.InternalServiceError == nil && rhs.InternalServiceError == nil) || (v.InternalServiceError != nil && rhs.InternalServiceError != nil && v.InternalServiceError.Equals(rhs.InternalServiceError))) {
+		return false
+	}
+	if !((v.EntityNotExistError == nil && rhs.EntityNotExistError == nil) || (v.EntityNotExistError != nil && rhs.EntityNotExistError != nil && v.EntityNotExistError.Equals(rhs.EntityNotExistError))) {
+		return false
+	}
+	if !((v.AccessDeniedError == nil && rhs.AccessDeniedError == nil) || (v.AccessDeniedError != nil && rhs.AccessDeniedError != nil && v.AccessDeniedError.Equals(rhs.AccessDeniedError))) {
+		return false
+	}
+
+	return true
+}
+
+// MarshalLogObject implements zapcore.ObjectMarshaler, enabling
+// fast logging of AdminService_CreateSchedule_Result.
+func (v *AdminService_CreateSchedule_Result) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+	if v == nil {
+		return nil
+	}
+	if v.BadRequestError != nil {
+		err = multierr.Append(err, enc.AddObject("badRequestError", v.BadRequestError))
+	}
+	if v.InternalServiceError != nil {
+		err = multierr.Append(err, enc.AddObject("internalServiceError", v.InternalServiceError))
+	}
+	if v.EntityNotExistError != nil {
+		err = multierr.Append(err, enc.AddObject("entityNotExistError", v.EntityNotExistError))
+	}
+	if v.AccessDeniedError != nil {
+		err = multierr.Append(err, enc.AddObject("accessDeniedError", v.AccessDeniedError))
+	}
+	return err
+}
+
+// GetBadRequestError returns the value of BadRequestError if it is set or its
+// zero value if it is unset.
+func (v *AdminService_CreateSchedule_Result) GetBadRequestError() (o *shared.BadRequestError) {
+	if v != nil && v.BadRequestError != nil {
+		return v.BadRequestError
+	}
+
+	return
+}
+
+// IsSetBadRequestError returns true if BadRequestError is not nil.
+func (v *AdminService_CreateSchedule_Result) IsSetBadRequestError() bool {
+	return v != nil && v.BadRequestError != nil
+}
+
+// GetInternalServiceError returns the value of InternalServiceError if it is set or its
+// zero value if it is unset.
+func (v *AdminService_CreateSchedule_Result) GetInternalServiceError() (o *shared.InternalServiceError) {
+	if v != nil && v.InternalServiceError != nil {
+		return v.InternalServiceError
+	}
+
+	return
+}
+
+// IsSetInternalServiceError returns true if InternalServiceError is not nil.
+func (v *AdminService_CreateSchedule_Result) IsSetInternalServiceError() bool {
+	return v != nil && v.InternalServiceError != nil
+}
+
+// GetEntityNotExistError returns the value of EntityNotExistError if it is set or its
+// zero value if it is unset.
+func (v *AdminService_CreateSchedule_Result) GetEntityNotExistError() (o *shared.EntityNotExistsError) {
+	if v != nil && v.EntityNotExistError != nil {
+		return v.EntityNotExistError
+	}
+
+	return
+}
+
+// IsSetEntityNotExistError returns true if EntityNotExistError is not nil.
+func (v *AdminService_CreateSchedule_Result) IsSetEntityNotExistError() bool {
+	return v != nil && v.EntityNotExistError != nil
+}
+
+// GetAccessDeniedError returns the value of AccessDeniedError if it is set or its
+// zero value if it is unset.
+func (v *AdminService_CreateSchedule_Result) GetAccessDeniedError() (o *shared.AccessDeniedError) {
+	if v != nil && v.AccessDeniedError != nil {
+		return v.AccessDeniedError
+	}
+
+	return
+}
+
+// IsSetAccessDeniedError returns true if AccessDeniedError is not nil.
+func (v *AdminService_CreateSchedule_Result) IsSetAccessDeniedError() bool {
+	return v != nil && v.AccessDeniedError != nil
+}
+
+// MethodName returns the name of the Thrift function as specified in
+// the IDL, for which this struct represent the result.
+//
+// This will always be "CreateSchedule" for this struct.
+func (v *AdminService_CreateSchedule_Result) MethodName() string {
+	return "CreateSchedule"
+}
+
+// EnvelopeType returns the kind of value inside this struct.
+//
+// This will always be Reply for this struct.
+func (v *AdminService_CreateSchedule_Result) EnvelopeType() wire.EnvelopeType {
+	return wire.Reply
+}
+
+// AdminService_DeleteSchedule_Args represents the arguments for the AdminService.DeleteSchedule function.
+//
+// The arguments for DeleteSchedule are sent and received over the wire as this struct.
+type AdminService_DeleteSchedule_Args struct {
+	Request *shared.DeleteScheduleRequest `json:"request,omitempty"`
+}
+
+// ToWire translates a AdminService_DeleteSchedule_Args struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *AdminService_DeleteSchedule_Args) ToWire() (wire.Value, error) {
+	var (
+		fields [1]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.Request != nil {
+		w, err = v.Request.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 1, Value: w}
+		i++
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+func _DeleteScheduleRequest_Read(w wire.Value) (*shared.DeleteScheduleRequest, error) {
+	var v shared.DeleteScheduleRequest
+	err := v.FromWire(w)
+	return &v, err
+}
+
+// FromWire deserializes a AdminService_DeleteSchedule_Args struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a AdminService_DeleteSchedule_Args struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v AdminService_DeleteSchedule_Args
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *AdminService_DeleteSchedule_Args) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 1:
+			if field.Value.Type() == wire.TStruct {
+				v.Request, err = _DeleteScheduleRequest_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+
+	return nil
+}
+
+// String returns a readable string representation of a AdminService_DeleteSchedule_Args
+// struct.
+func (v *AdminService_DeleteSchedule_Args) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [1]string
+	i := 0
+	if v.Request != nil {
+		fields[i] = fmt.Sprintf("Request: %v", v.Request)
+		i++
+	}
+
+	return fmt.Sprintf("AdminService_DeleteSchedule_Args{%v}", strings.Join(fields[:i], ", "))
+}
+
+// Equals returns true if all the fields of this AdminService_DeleteSchedule_Args match the
+// provided AdminService_DeleteSchedule_Args.
+//
+// This function performs a deep comparison.
+func (v *AdminService_DeleteSchedule_Args) Equals(rhs *AdminService_DeleteSchedule_Args) bool {
+	if v == nil {
+		return rhs == nil
+	} else if rhs == nil {
+		return false
+	}
+	if !((v.Request == nil && rhs.Request == nil) || (v.Request != nil && rhs.Request != nil && v.Request.Equals(rhs.Request))) {
+		return false
+	}
+
+	return true
+}
+
+// MarshalLogObject implements zapcore.ObjectMarshaler, enabling
+// fast logging of AdminService_DeleteSchedule_Args.
+func (v *AdminService_DeleteSchedule_Args) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+	if v == nil {
+		return nil
+	}
+	if v.Request != nil {
+		err = multierr.Append(err, enc.AddObject("request", v.Request))
+	}
+	return err
+}
+
+// GetRequest returns the value of Request if it is set or its
+// zero value if it is unset.
+func (v *AdminService_DeleteSchedule_Args) GetRequest() (o *shared.DeleteScheduleRequest) {
+	if v != nil && v.Request != nil {
+		return v.Request
+	}
+
+	return
+}
+
+// IsSetRequest returns true if Request is not nil.
+func (v *AdminService_DeleteSchedule_Args) IsSetRequest() bool {
+	return v != nil && v.Request != nil
+}
+
+// MethodName returns the name of the Thrift function as specified in
+// the IDL, for which this struct represent the arguments.
+//
+// This will always be "DeleteSchedule" for this struct.
+func (v *AdminService_DeleteSchedule_Args) MethodName() string {
+	return "DeleteSchedule"
+}
+
+// EnvelopeType returns the kind of value inside this struct.
+//
+// This will always be Call for this struct.
+func (v *AdminService_DeleteSchedule_Args) EnvelopeType() wire.EnvelopeType {
+	return wire.Call
+}
+
+// AdminService_DeleteSchedule_Helper provides functions that aid in handling the
+// parameters and return values of the AdminService.DeleteSchedule
+// function.
+var AdminService_DeleteSchedule_Helper = struct {
+	// Args accepts the parameters of DeleteSchedule in-order and returns
+	// the arguments struct for the function.
+	Args func(
+		request *shared.DeleteScheduleRequest,
+	) *AdminService_DeleteSchedule_Args
+
+	// IsException returns true if the given error can be thrown
+	// by DeleteSchedule.
+	//
+	// An error can be thrown by DeleteSchedule only if the
+	// corresponding exception type was mentioned in the 'throws'
+	// section for it in the Thrift file.
+	IsException func(error) bool
+
+	// WrapResponse returns the result struct for DeleteSchedule
+	// given the error returned by it. The provided error may
+	// be nil if DeleteSchedule did not fail.
+	//
+	// This allows mapping errors returned by DeleteSchedule into a
+	// serializable result struct. WrapResponse returns a
+	// non-nil error if the provided error cannot be thrown by
+	// DeleteSchedule
+	//
+	//   err := DeleteSchedule(args)
+	//   result, err := AdminService_DeleteSchedule_Helper.WrapResponse(err)
+	//   if err != nil {
+	//     return fmt.Errorf("unexpected error from DeleteSchedule: %v", err)
+	//   }
+	//   serialize(result)
+	WrapResponse func(error) (*AdminService_DeleteSchedule_Result, error)
+
+	// UnwrapResponse takes the result struct for DeleteSchedule
+	// and returns the erorr returned by it (if any).
+	//
+	// The error is non-nil only if DeleteSchedule threw an
+	// exception.
+	//
+	//   result := deserialize(bytes)
+	//   err := AdminService_DeleteSchedule_Helper.UnwrapResponse(result)
+	UnwrapResponse func(*AdminService_DeleteSchedule_Result) error
+}{}
+
+func init() {
+	AdminService_DeleteSchedule_Helper.Args = func(
+		request *shared.DeleteScheduleRequest,
+	) *AdminService_DeleteSchedule_Args {
+		return &AdminService_DeleteSchedule_Args{
+			Request: request,
+		}
+	}
+
+	AdminService_DeleteSchedule_Helper.IsException = func(err error) bool {
+		switch err.(type) {
+		case *shared.BadRequestError:
+			return true
+		case *shared.InternalServiceError:
+			return true
+		case *shared.EntityNotExistsError:
+			return true
+		case *shared.AccessDeniedError:
+			return true
+		default:
+			return false
+		}
+	}
+
+	AdminService_DeleteSchedule_Helper.WrapResponse = func(err error) (*AdminService_DeleteSchedule_Result, error) {
+		if err == nil {
+			return &AdminService_DeleteSchedule_Result{}, nil
+		}
+
+		switch e := err.(type) {
+		case *shared.BadRequestError:
+			if e == nil {
+				return nil, errors.New("WrapResponse received non-nil error type with nil value for AdminService_DeleteSchedule_Result.BadRequestError")
+			}
+			return &AdminService_DeleteSchedule_Result{BadRequestError: e}, nil
+		case *shared.InternalServiceError:
+			if e == nil {
+				return nil, errors.New("WrapResponse received non-nil error type with nil value for AdminService_DeleteSchedule_Result.InternalServiceError")
+			}
+			return &AdminService_DeleteSchedule_Result{InternalServiceError: e}, nil
+		case *shared.EntityNotExistsError:
+			if e == nil {
+				return nil, errors.New("WrapResponse received non-nil error type with nil value for AdminService_DeleteSchedule_Result.EntityNotExistError")
+			}
+			return &AdminService_DeleteSchedule_Result{EntityNotExistError: e}, nil
+		case *shared.AccessDeniedError:
+			if e == nil {
+				return nil, errors.New("WrapResponse received non-nil error type with nil value for AdminService_DeleteSchedule_Result.AccessDeniedError")
+			}
+			return &AdminService_DeleteSchedule_Result{AccessDeniedError: e}, nil
+		}
+
+		return nil, err
+	}
+	AdminService_DeleteSchedule_Helper.UnwrapResponse = func(result *AdminService_DeleteSchedule_Result) (err error) {
+		if result.BadRequestError != nil {
+			err = result.BadRequestError
+			return
+		}
+		if result.InternalServiceError != nil {
+			err = result.InternalServiceError
+			return
+		}
+		if result.EntityNotExistError != nil {
+			err = result.EntityNotExistError
+			return
+		}
+		if result.AccessDeniedError != nil {
+			err = result.AccessDeniedError
+			return
+		}
+		return
+	}
+
+}
+
+// AdminService_DeleteSchedule_Result represents the result of a AdminService.DeleteSchedule function call.
+//
+// The result of a DeleteSchedule execution is sent and received over the wire as this struct.
+type AdminService_DeleteSchedule_Result struct {
+	BadRequestError      *shared.BadRequestError      `json:"badRequestError,omitempty"`
+	InternalServiceError *shared.InternalServiceError `json:"internalServiceError,omitempty"`
+	EntityNotExistError  *shared.EntityNotExistsError `json:"entityNotExistError,omitempty"`
+	AccessDeniedError    *shared.AccessDeniedError    `json:"accessDeniedError,omitempty"`
+}
+
+// ToWire translates a AdminService_DeleteSchedule_Result struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *AdminService_DeleteSchedule_Result) ToWire() (wire.Value, error) {
+	var (
+		fields [4]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.BadRequestError != nil {
+		w, err = v.BadRequestError.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 1, Value: w}
+		i++
+	}
+	if v.InternalServiceError != nil {
+		w, err = v.InternalServiceError.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 2, Value: w}
+		i++
+	}
+	if v.EntityNotExistError != nil {
+		w, err = v.EntityNotExistError.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 3, Value: w}
+		i++
+	}
+	if v.AccessDeniedError != nil {
+		w, err = v.AccessDeniedError.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 4, Value: w}
+		i++
+	}
+
+	if i > 1 {
+		return wire.Value{}, fmt.Errorf("AdminService_DeleteSchedule_Result should have at most one field: got %v fields", i)
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+// FromWire deserializes a AdminService_DeleteSchedule_Result struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a AdminService_DeleteSchedule_Result struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v AdminService_DeleteSchedule_Result
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *AdminService_DeleteSchedule_Result) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 1:
+			if field.Value.Type() == wire.TStruct {
+				v.BadRequestError, err = _BadRequestError_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 2:
+			if field.Value.Type() == wire.TStruct {
+				v.InternalServiceError, err = _InternalServiceError_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 3:
+			if field.Value.Type() == wire.TStruct {
+				v.EntityNotExistError, err = _EntityNotExistsError_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 4:
+			if field.Value.Type() == wire.TStruct {
+				v.AccessDeniedError, err = _AccessDeniedError_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+
+	count := 0
+	if v.BadRequestError != nil {
+		count++
+	}
+	if v.InternalServiceError != nil {
+		count++
+	}
+	if v.EntityNotExistError != nil {
+		count++
+	}
+	if v.AccessDeniedError != nil {
+		count++
+	}
+	if count > 1 {
+		return fmt.Errorf("AdminService_DeleteSchedule_Result should have at most one field: got %v fields", count)
+	}
+
+	return nil
+}
+
+// String returns a readable string representation of a AdminService_DeleteSchedule_Result
+// struct.
+func (v *AdminService_DeleteSchedule_Result) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [4]string
+	i := 0
+	if v.BadRequestError != nil {
+		fields[i] = fmt.Sprintf("BadRequestError: %v", v.BadRequestError)
+		i++
+	}
+	if v.InternalServiceError != nil {
+		fields[i] = fmt.Sprintf("InternalServiceError: %v", v.InternalServiceError)
+		i++
+	}
+	if v.EntityNotExistError != nil {
+		fields[i] = fmt.Sprintf("EntityNotExistError: %v", v.EntityNotExistError)
+		i++
+	}
+	if v.AccessDeniedError != nil {
+		fields[i] = fmt.Sprintf("AccessDeniedError: %v", v.AccessDeniedError)
+		i++
+	}
+
+	return fmt.Sprintf("AdminService_DeleteSchedule_Result{%v}", strings.Join(fields[:i], ", "))
+}
+
+// Equals returns true if all the fields of this AdminService_DeleteSchedule_Result match the
+// provided AdminService_DeleteSchedule_Result.
+//
+// This function performs a deep comparison.
+func (v *AdminService_DeleteSchedule_Result) Equals(rhs *AdminService_DeleteSchedule_Result) bool {
+	if v == nil {
+		return rhs == nil
+	} else if rhs == nil {
+		return false
+	}
+	if !((v.BadRequestError == nil && rhs.BadRequestError == nil) || (v.BadRequestError != nil && rhs.BadRequestError != nil && v.BadRequestError.Equals(rhs.BadRequestError))) {
+		return false
+	}
+	if !((v.InternalServiceError == nil && rhs.InternalServiceError == nil) || (v.InternalServiceError != nil && rhs.InternalServiceError != nil && v.InternalServiceError.Equals(rhs.InternalServiceError))) {
+		return false
+	}
+	if !((v.EntityNotExistError == nil && rhs.EntityNotExistError == nil) || (v.EntityNotExistError != nil && rhs.EntityNotExistError != nil && v.EntityNotExistError.Equals(rhs.EntityNotExistError))) {
+		return false
+	}
+	if !((v.AccessDeniedError == nil && rhs.AccessDeniedError == nil) || (v.AccessDeniedError != nil && rhs.AccessDeniedError != nil && v.AccessDeniedError.Equals(rhs.AccessDeniedError))) {
+		return false
+	}
+
+	return true
+}
+
+// MarshalLogObject implements zapcore.ObjectMarshaler, enabling
+// fast logging of AdminService_DeleteSchedule_Result.
+func (v *AdminService_DeleteSchedule_Result) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+	if v == nil {
+		return nil
+	}
+	if v.BadRequestError != nil {
+		err = multierr.Append(err, enc.AddObject("badRequestError", v.BadRequestError))
+	}
+	if v.InternalServiceError != nil {
+		err = multierr.Append(err, enc.AddObject("internalServiceError", v.InternalServiceError))
+	}
+	if v.EntityNotExistError != nil {
+		err = multierr.Append(err, enc.AddObject("entityNotExistError", v.EntityNotExistError))
+	}
+	if v.AccessDeniedError != nil {
+		err = multierr.Append(err, enc.AddObject("accessDeniedError", v.AccessDeniedError))
+	}
+	return err
+}
+
+// GetBadRequestError returns the value of BadRequestError if it is set or its
+// zero value if it is unset.
+func (v *AdminService_DeleteSchedule_Result) GetBadRequestError() (o *shared.BadRequestError) {
+	if v != nil && v.BadRequestError != nil {
+		return v.BadRequestError
+	}
+
+	return
+}
+
+// IsSetBadRequestError returns true if BadRequestError is not nil.
+func (v *AdminService_DeleteSchedule_Result) IsSetBadRequestError() bool {
+	return v != nil && v.BadRequestError != nil
+}
+
+// GetInternalServiceError returns the value of InternalServiceError if it is set or its
+// zero value if it is unset.
+func (v *AdminService_DeleteSchedule_Result) GetInternalServiceError() (o *shared.InternalServiceError) {
+	if v != nil && v.InternalServiceError != nil {
+		return v.InternalServiceError
+	}
+
+	return
+}
+
+// IsSetInternalServiceError returns true if InternalServiceError is not nil.
+func (v *AdminService_DeleteSchedule_Result) IsSetInternalServiceError() bool {
+	return v != nil && v.InternalServiceError != nil
+}
+
+// GetEntityNotExistError returns the value of EntityNotExistError if it is set or its
+// zero value if it is unset.
+func (v *AdminService_DeleteSchedule_Result) GetEntityNotExistError() (o *shared.EntityNotExistsError) {
+	if v != nil && v.EntityNotExistError != nil {
+		return v.EntityNotExistError
+	}
+
+	return
+}
+
+// IsSetEntityNotExistError returns true if EntityNotExistError is not nil.
+func (v *AdminService_DeleteSchedule_Result) IsSetEntityNotExistError() bool {
+	return v != nil && v.EntityNotExistError != nil
+}
+
+// GetAccessDeniedError returns the value of AccessDeniedError if it is set or its
+// zero value if it is unset.
+func (v *AdminService_DeleteSchedule_Result) GetAccessDeniedError() (o *shared.AccessDeniedError) {
+	if v != nil && v.AccessDeniedError != nil {
+		return v.AccessDeniedError
+	}
+
+	return
+}
+
+// IsSetAccessDeniedError returns true if AccessDeniedError is not nil.
+func (v *AdminService_DeleteSchedule_Result) IsSetAccessDeniedError() bool {
+	return v != nil && v.AccessDeniedError != nil
+}
+
+// MethodName returns the name of the Thrift function as specified in
+// the IDL, for which this struct represent the result.
+//
+// This will always be "DeleteSchedule" for this struct.
+func (v *AdminService_DeleteSchedule_Result) MethodName() string {
+	return "DeleteSchedule"
+}
+
+// EnvelopeType returns the kind of value inside this struct.
+//
+// This will always be Reply for this struct.
+func (v *AdminService_DeleteSchedule_Result) EnvelopeType() wire.EnvelopeType {
+	return wire.Reply
+}
+
+// AdminService_DescribeClientVersions_Args represents the arguments for the AdminService.DescribeClientVersions function.
+//
+// The arguments for DescribeClientVersions are sent and received over the wire as this struct.
+type AdminService_DescribeClientVersions_Args struct {
+	Request *shared.DescribeClientVersionsRequest `json:"request,omitempty"`
+}
+
+// ToWire translates a AdminService_DescribeClientVersions_Args struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *AdminService_DescribeClientVersions_Args) ToWire() (wire.Value, error) {
+	var (
+		fields [1]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.Request != nil {
+		w, err = v.Request.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 1, Value: w}
+		i++
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+func _DescribeClientVersionsRequest_Read(w wire.Value) (*shared.DescribeClientVersionsRequest, error) {
+	var v shared.DescribeClientVersionsRequest
+	err := v.FromWire(w)
+	return &v, err
+}
+
+// FromWire deserializes a AdminService_DescribeClientVersions_Args struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a AdminService_DescribeClientVersions_Args struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v AdminService_DescribeClientVersions_Args
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *AdminService_DescribeClientVersions_Args) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 1:
+			if field.Value.Type() == wire.TStruct {
+				v.Request, err = _DescribeClientVersionsRequest_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+
+	return nil
+}
+
+// String returns a readable string representation of a AdminService_DescribeClientVersions_Args
+// struct.
+func (v *AdminService_DescribeClientVersions_Args) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [1]string
+	i := 0
+	if v.Request != nil {
+		fields[i] = fmt.Sprintf("Request: %v", v.Request)
+		i++
+	}
+
+	return fmt.Sprintf("AdminService_DescribeClientVersions_Args{%v}", strings.Join(fields[:i], ", "))
+}
+
+// Equals returns true if all the fields of this AdminService_DescribeClientVersions_Args match the
+// provided AdminService_DescribeClientVersions_Args.
+//
+// This function performs a deep comparison.
+func (v *AdminService_DescribeClientVersions_Args) Equals(rhs *AdminService_DescribeClientVersions_Args) bool {
+	if v == nil {
+		return rhs == nil
+	} else if rhs == nil {
+		return false
+	}
+	if !((v.Request == nil && rhs.Request == nil) || (v.Request != nil && rhs.Request != nil && v.Request.Equals(rhs.Request))) {
+		return false
+	}
+
+	return true
+}
+
+// MarshalLogObject implements zapcore.ObjectMarshaler, enabling
+// fast logging of AdminService_DescribeClientVersions_Args.
+func (v *AdminService_DescribeClientVersions_Args) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+	if v == nil {
+		return nil
+	}
+	if v.Request != nil {
+		err = multierr.Append(err, enc.AddObject("request", v.Request))
+	}
+	return err
+}
+
+// GetRequest returns the value of Request if it is set or its
+// zero value if it is unset.
+func (v *AdminService_DescribeClientVersions_Args) GetRequest() (o *shared.DescribeClientVersionsRequest) {
+	if v != nil && v.Request != nil {
+		return v.Request
+	}
+
+	return
+}
+
+// IsSetRequest returns true if Request is not nil.
+func (v *AdminService_DescribeClientVersions_Args) IsSetRequest() bool {
+	return v != nil && v.Request != nil
+}
+
+// MethodName returns the name of the Thrift function as specified in
+// the IDL, for which this struct represent the arguments.
+//
+// This will always be "DescribeClientVersions" for this struct.
+func (v *AdminService_DescribeClientVersions_Args) MethodName() string {
+	return "DescribeClientVersions"
+}
+
+// EnvelopeType returns the kind of value inside this struct.
+//
+// This will always be Call for this struct.
+func (v *AdminService_DescribeClientVersions_Args) EnvelopeType() wire.EnvelopeType {
+	return wire.Call
+}
+
+// AdminService_DescribeClientVersions_Helper provides functions that aid in handling the
+// parameters and return values of the AdminService.DescribeClientVersions
+// function.
+var AdminService_DescribeClientVersions_Helper = struct {
+	// Args accepts the parameters of DescribeClientVersions in-order and returns
+	// the arguments struct for the function.
+	Args func(
+		request *shared.DescribeClientVersionsRequest,
+	) *AdminService_DescribeClientVersions_Args
+
+	// IsException returns true if the given error can be thrown
+	// by DescribeClientVersions.
+	//
+	// An error can be thrown by DescribeClientVersions only if the
+	// corresponding exception type was mentioned in the 'throws'
+	// section for it in the Thrift file.
+	IsException func(error) bool
+
+	// WrapResponse returns the result struct for DescribeClientVersions
+	// given its return value and error.
+	//
+	// This allows mapping values and errors returned by
+	// DescribeClientVersions into a serializable result struct.
+	// WrapResponse returns a non-nil error if the provided
+	// error cannot be thrown by DescribeClientVersions
+	//
+	//   value, err := DescribeClientVersions(args)
+	//   result, err := AdminService_DescribeClientVersions_Helper.WrapResponse(value, err)
+	//   if err != nil {
+	//     return fmt.Errorf("unexpected error from DescribeClientVersions: %v", err)
+	//   }
+	//   serialize(result)
+	WrapResponse func(*shared.DescribeClientVersionsResponse, error) (*AdminService_DescribeClientVersions_Result, error)
+
+	// UnwrapResponse takes the result struct for DescribeClientVersions
+	// and returns the value or error returned by it.
+	//
+	// The error is non-nil only if DescribeClientVersions threw an
+	// exception.
+	//
+	//   result := deserialize(bytes)
+	//   value, err := AdminService_DescribeClientVersions_Helper.UnwrapResponse(result)
+	UnwrapResponse func(*AdminService_DescribeClientVersions_Result) (*shared.DescribeClientVersionsResponse, error)
+}{}
+
+func init() {
+	AdminService_DescribeClientVersions_Helper.Args = func(
+		request *shared.DescribeClientVersionsRequest,
+	) *AdminService_DescribeClientVersions_Args {
+		return &AdminService_DescribeClientVersions_Args{
+			Request: request,
+		}
+	}
+
+	AdminService_DescribeClientVersions_Helper.IsException = func(err error) bool {
+		switch err.(type) {
+		case *shared.BadRequestError:
+			return true
+		case *shared.InternalServiceError:
+			return true
+		case *shared.AccessDeniedError:
+			return true
+		default:
+			return false
+		}
+	}
+
+	AdminService_DescribeClientVersions_Helper.WrapResponse = func(success *shared.DescribeClientVersionsResponse, err error) (*AdminService_DescribeClientVersions_Result, error) {
+		if err == nil {
+			return &AdminService_DescribeClientVersions_Result{Success: success}, nil
+		}
+
+		switch e := err.(type) {
+		case *shared.BadRequestError:
+			if e == nil {
+				return nil, errors.New("WrapResponse received non-nil error type with nil value for AdminService_DescribeClientVersions_Result.BadRequestError")
+			}
+			return &AdminService_DescribeClientVersions_Result{BadRequestError: e}, nil
+		case *shared.InternalServiceError:
+			if e == nil {
+				return nil, errors.New("WrapResponse received non-nil error type with nil value for AdminService_DescribeClientVersions_Result.InternalServiceError")
+			}
+			return &AdminService_DescribeClientVersions_Result{InternalServiceError: e}, nil
+		case *shared.AccessDeniedError:
+			if e == nil {
+				return nil, errors.New("WrapResponse received non-nil error type with nil value for AdminService_DescribeClientVersions_Result.AccessDeniedError")
+			}
+			return &AdminService_DescribeClientVersions_Result{AccessDeniedError: e}, nil
+		}
+
+		return nil, err
+	}
+	AdminService_DescribeClientVersions_Helper.UnwrapResponse = func(result *AdminService_DescribeClientVersions_Result) (success *shared.DescribeClientVersionsResponse, err error) {
+		if result.BadRequestError != nil {
+			err = result.BadRequestError
+			return
+		}
+		if result.InternalServiceError != nil {
+			err = result.InternalServiceError
+			return
+		}
+		if result.AccessDeniedError != nil {
+			err = result.AccessDeniedError
+			return
+		}
+
+		if result.Success != nil {
+			success = result.Success
+			return
+		}
+
+		err = errors.New("expected a non-void result")
+		return
+	}
+
+}
+
+// AdminService_DescribeClientVersions_Result represents the result of a AdminService.DescribeClientVersions function call.
+//
+// The result of a DescribeClientVersions execution is sent and received over the wire as this struct.
+//
+// Success is set only if the function did not throw an exception.
+type AdminService_DescribeClientVersions_Result struct {
+	// Value returned by DescribeClientVersions after a successful execution.
+	Success              *shared.DescribeClientVersionsResponse `json:"success,omitempty"`
+	BadRequestError      *shared.BadRequestError                `json:"badRequestError,omitempty"`
+	InternalServiceError *shared.InternalServiceError           `json:"internalServiceError,omitempty"`
+	AccessDeniedError    *shared.AccessDeniedError              `json:"accessDeniedError,omitempty"`
+}
+
+// ToWire translates a AdminService_DescribeClientVersions_Result struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *AdminService_DescribeClientVersions_Result) ToWire() (wire.Value, error) {
+	var (
+		fields [4]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.Success != nil {
+		w, err = v.Success.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 0, Value: w}
+		i++
+	}
+	if v.BadRequestError != nil {
+		w, err = v.BadRequestError.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 1, Value: w}
+		i++
+	}
+	if v.InternalServiceError != nil {
+		w, err = v.InternalServiceError.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 2, Value: w}
+		i++
+	}
+	if v.AccessDeniedError != nil {
+		w, err = v.AccessDeniedError.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 3, Value: w}
+		i++
+	}
+
+	if i != 1 {
+		return wire.Value{}, fmt.Errorf("AdminService_DescribeClientVersions_Result should have exactly one field: got %v fields", i)
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+func _DescribeClientVersionsResponse_Read(w wire.Value) (*shared.DescribeClientVersionsResponse, error) {
+	var v shared.DescribeClientVersionsResponse
+	err := v.FromWire(w)
+	return &v, err
+}
+
+func _BadRequestError_Read(w wire.Value) (*shared.BadRequestError, error) {
+	var v shared.BadRequestError
+	err := v.FromWire(w)
+	return &v, err
+}
+
+func _InternalServiceError_Read(w wire.Value) (*shared.InternalServiceError, error) {
+	var v shared.InternalServiceError
+	err := v.FromWire(w)
+	return &v, err
+}
+
+func _AccessDeniedError_Read(w wire.Value) (*shared.AccessDeniedError, error) {
+	var v shared.AccessDeniedError
+	err := v.FromWire(w)
+	return &v, err
+}
+
+// FromWire deserializes a AdminService_DescribeClientVersions_Result struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a AdminService_DescribeClientVersions_Result struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v AdminService_DescribeClientVersions_Result
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *AdminService_DescribeClientVersions_Result) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 0:
+			if field.Value.Type() == wire.TStruct {
+				v.Success, err = _DescribeClientVersionsResponse_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 1:
+			if field.Value.Type() == wire.TStruct {
+				v.BadRequestError, err = _BadRequestError_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 2:
+			if field.Value.Type() == wire.TStruct {
+				v.InternalServiceError, err = _InternalServiceError_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 3:
+			if field.Value.Type() == wire.TStruct {
+				v.AccessDeniedError, err = _AccessDeniedError_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+
+	count := 0
+	if v.Success != nil {
+		count++
+	}
+	if v.BadRequestError != nil {
+		count++
+	}
+	if v.InternalServiceError != nil {
+		count++
+	}
+	if v.AccessDeniedError != nil {
+		count++
+	}
+	if count != 1 {
+		return fmt.Errorf("AdminService_DescribeClientVersions_Result should have exactly one field: got %v fields", count)
+	}
+
+	return nil
+}
+
+// String returns a readable string representation of a AdminService_DescribeClientVersions_Result
+// struct.
+func (v *AdminService_DescribeClientVersions_Result) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [4]string
+	i := 0
+	if v.Success != nil {
+		fields[i] = fmt.Sprintf("Success: %v", v.Success)
+		i++
+	}
+	if v.BadRequestError != nil {
+		fields[i] = fmt.Sprintf("BadRequestError: %v", v.BadRequestError)
+		i++
+	}
+	if v.InternalServiceError != nil {
+		fields[i] = fmt.Sprintf("InternalServiceError: %v", v.InternalServiceError)
+		i++
+	}
+	if v.AccessDeniedError != nil {
+		fields[i] = fmt.Sprintf("AccessDeniedError: %v", v.AccessDeniedError)
+		i++
+	}
+
+	return fmt.Sprintf("AdminService_DescribeClientVersions_Result{%v}", strings.Join(fields[:i], ", "))
+}
+
+// Equals returns true if all the fields of this AdminService_DescribeClientVersions_Result match the
+// provided AdminService_DescribeClientVersions_Result.
+//
+// This function performs a deep comparison.
+func (v *AdminService_DescribeClientVersions_Result) Equals(rhs *AdminService_DescribeClientVersions_Result) bool {
+	if v == nil {
+		return rhs == nil
+	} else if rhs == nil {
+		return false
+	}
+	if !((v.Success == nil && rhs.Success == nil) || (v.Success != nil && rhs.Success != nil && v.Success.Equals(rhs.Success))) {
+		return false
+	}
+	if !((v.BadRequestError == nil && rhs.BadRequestError == nil) || (v.BadRequestError != nil && rhs.BadRequestError != nil && v.BadRequestError.Equals(rhs.BadRequestError))) {
+		return false
+	}
+	if !((v.InternalServiceError == nil && rhs.InternalServiceError == nil) || (v.InternalServiceError != nil && rhs.InternalServiceError != nil && v.InternalServiceError.Equals(rhs.InternalServiceError))) {
+		return false
+	}
+	if !((v.AccessDeniedError == nil && rhs.AccessDeniedError == nil) || (v.AccessDeniedError != nil && rhs.AccessDeniedError != nil && v.AccessDeniedError.Equals(rhs.AccessDeniedError))) {
+		return false
+	}
+
+	return true
+}
+
+// MarshalLogObject implements zapcore.ObjectMarshaler, enabling
+// fast logging of AdminService_DescribeClientVersions_Result.
+func (v *AdminService_DescribeClientVersions_Result) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+	if v == nil {
+		return nil
+	}
+	if v.Success != nil {
+		err = multierr.Append(err, enc.AddObject("success", v.Success))
+	}
+	if v.BadRequestError != nil {
+		err = multierr.Append(err, enc.AddObject("badRequestError", v.BadRequestError))
+	}
+	if v.InternalServiceError != nil {
+		err = multierr.Append(err, enc.AddObject("internalServiceError", v.InternalServiceError))
+	}
+	if v.AccessDeniedError != nil {
+		err = multierr.Append(err, enc.AddObject("accessDeniedError", v.AccessDeniedError))
+	}
+	return err
+}
+
+// GetSuccess returns the value of Success if it is set or its
+// zero value if it is unset.
+func (v *AdminService_DescribeClientVersions_Result) GetSuccess() (o *shared.DescribeClientVersionsResponse) {
+	if v != nil && v.Success != nil {
+		return v.Success
+	}
+
+	return
+}
+
+// IsSetSuccess returns true if Success is not nil.
+func (v *AdminService_DescribeClientVersions_Result) IsSetSuccess() bool {
+	return v != nil && v.Success != nil
+}
+
+// GetBadRequestError returns the value of BadRequestError if it is set or its
+// zero value if it is unset.
+func (v *AdminService_DescribeClientVersions_Result) GetBadRequestError() (o *shared.BadRequestError) {
+	if v != nil && v.BadRequestError != nil {
+		return v.BadRequestError
+	}
+
+	return
+}
+
+// IsSetBadRequestError returns true if BadRequestError is not nil.
+func (v *AdminService_DescribeClientVersions_Result) IsSetBadRequestError() bool {
+	return v != nil && v.BadRequestError != nil
+}
+
+// GetInternalServiceError returns the value of InternalServiceError if it is set or its
+// zero value if it is unset.
+func (v *AdminService_DescribeClientVersions_Result) GetInternalServiceError() (o *shared.InternalServiceError) {
+	if v != nil && v.InternalServiceError != nil {
+		return v.InternalServiceError
+	}
+
+	return
+}
+
+// IsSetInternalServiceError returns true if InternalServiceError is not nil.
+func (v *AdminService_DescribeClientVersions_Result) IsSetInternalServiceError() bool {
+	return v != nil && v.InternalServiceError != nil
+}
+
+// GetAccessDeniedError returns the value of AccessDeniedError if it is set or its
+// zero value if it is unset.
+func (v *AdminService_DescribeClientVersions_Result) GetAccessDeniedError() (o *shared.AccessDeniedError) {
+	if v != nil && v.AccessDeniedError != nil {
+		return v.AccessDeniedError
+	}
+
+	return
+}
+
+// IsSetAccessDeniedError returns true if AccessDeniedError is not nil.
+func (v *AdminService_DescribeClientVersions_Result) IsSetAccessDeniedError() bool {
+	return v != nil && v.AccessDeniedError != nil
+}
+
+// MethodName returns the name of the Thrift function as specified in
+// the IDL, for which this struct represent the result.
+//
+// This will always be "DescribeClientVersions" for this struct.
+func (v *AdminService_DescribeClientVersions_Result) MethodName() string {
+	return "DescribeClientVersions"
+}
+
+// EnvelopeType returns the kind of value inside this struct.
+//
+// This will always be Reply for this struct.
+func (v *AdminService_DescribeClientVersions_Result) EnvelopeType() wire.EnvelopeType {
+	return wire.Reply
+}
+
+// AdminService_DescribeHistoryHost_Args represents the arguments for the AdminService.DescribeHistoryHost function.
+//
+// The arguments for DescribeHistoryHost are sent and received over the wire as this struct.
+type AdminService_DescribeHistoryHost_Args struct {
+	Request *shared.DescribeHistoryHostRequest `json:"request,omitempty"`
+}
+
+// ToWire translates a AdminService_DescribeHistoryHost_Args struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *AdminService_DescribeHistoryHost_Args) ToWire() (wire.Value, error) {
+	var (
+		fields [1]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.Request != nil {
+		w, err = v.Request.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 1, Value: w}
+		i++
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+func _DescribeHistoryHostRequest_Read(w wire.Value) (*shared.DescribeHistoryHostRequest, error) {
+	var v shared.DescribeHistoryHostRequest
+	err := v.FromWire(w)
+	return &v, err
+}
+
+// FromWire deserializes a AdminService_DescribeHistoryHost_Args struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a AdminService_DescribeHistoryHost_Args struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v AdminService_DescribeHistoryHost_Args
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *AdminService_DescribeHistoryHost_Args) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 1:
+			if field.Value.Type() == wire.TStruct {
+				v.Request, err = _DescribeHistoryHostRequest_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+
+	return nil
+}
+
+// String returns a readable string representation of a AdminService_DescribeHistoryHost_Args
+// struct.
+func (v *AdminService_DescribeHistoryHost_Args) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [1]string
+	i := 0
+	if v.Request != nil {
+		fields[i] = fmt.Sprintf("Request: %v", v.Request)
+		i++
+	}
+
+	return fmt.Sprintf("AdminService_DescribeHistoryHost_Args{%v}", strings.Join(fields[:i], ", "))
+}
+
+// Equals returns true if all the fields of this AdminService_DescribeHistoryHost_Args match the
+// provided AdminService_DescribeHistoryHost_Args.
+//
+// This function performs a deep comparison.
+func (v *AdminService_DescribeHistoryHost_Args) Equals(rhs *AdminService_DescribeHistoryHost_Args) bool {
+	if v == nil {
+		return rhs == nil
+	} else if rhs == nil {
+		return false
+	}
+	if !((v.Request == nil && rhs.Request == nil) || (v.Request != nil && rhs.Request != nil && v.Request.Equals(rhs.Request))) {
+		return false
+	}
+
+	return true
+}
+
+// MarshalLogObject implements zapcore.ObjectMarshaler, enabling
+// fast logging of AdminService_DescribeHistoryHost_Args.
+func (v *AdminService_DescribeHistoryHost_Args) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+	if v == nil {
+		return nil
+	}
+	if v.Request != nil {
+		err = multierr.Append(err, enc.AddObject("request", v.Request))
+	}
+	return err
+}
+
+// GetRequest returns the value of Request if it is set or its
+// zero value if it is unset.
+func (v *AdminService_DescribeHistoryHost_Args) GetRequest() (o *shared.DescribeHistoryHostRequest) {
+	if v != nil && v.Request != nil {
+		return v.Request
+	}
+
+	return
+}
+
+// IsSetRequest returns true if Request is not nil.
+func (v *AdminService_DescribeHistoryHost_Args) IsSetRequest() bool {
+	return v != nil && v.Request != nil
+}
+
+// MethodName returns the name of the Thrift function as specified in
+// the IDL, for which this struct represent the arguments.
+//
+// This will always be "DescribeHistoryHost" for this struct.
+func (v *AdminService_DescribeHistoryHost_Args) MethodName() string {
+	return "DescribeHistoryHost"
+}
+
+// EnvelopeType returns the kind of value inside this struct.
+//
+// This will always be Call for this struct.
+func (v *AdminService_DescribeHistoryHost_Args) EnvelopeType() wire.EnvelopeType {
+	return wire.Call
+}
+
+// AdminService_DescribeHistoryHost_Helper provides functions that aid in handling the
+// parameters and return values of the AdminService.DescribeHistoryHost
+// function.
+var AdminService_DescribeHistoryHost_Helper = struct {
+	// Args accepts the parameters of DescribeHistoryHost in-order and returns
+	// the arguments struct for the function.
+	Args func(
+		request *shared.DescribeHistoryHostRequest,
+	) *AdminService_DescribeHistoryHost_Args
+
+	// IsException returns true if the given error can be thrown
+	// by DescribeHistoryHost.
+	//
+	// An error can be thrown by DescribeHistoryHost only if the
+	// corresponding exception type was mentioned in the 'throws'
+	// section for it in the Thrift file.
+	IsException func(error) bool
+
+	// WrapResponse returns the result struct for DescribeHistoryHost
+	// given its return value and error.
+	//
+	// This allows mapping values and errors returned by
+	// DescribeHistoryHost into a serializable result struct.
+	// WrapResponse returns a non-nil error if the provided
+	// error cannot be thrown by DescribeHistoryHost
+	//
+	//   value, err := DescribeHistoryHost(args)
+	//   result, err := AdminService_DescribeHistoryHost_Helper.WrapResponse(value, err)
+	//   if err != nil {
+	//     return fmt.Errorf("unexpected error from DescribeHistoryHost: %v", err)
+	//   }
+	//   serialize(result)
+	WrapResponse func(*shared.DescribeHistoryHostResponse, error) (*AdminService_DescribeHistoryHost_Result, error)
+
+	// UnwrapResponse takes the result struct for DescribeHistoryHost
+	// and returns the value or error returned by it.
+	//
+	// The error is non-nil only if DescribeHistoryHost threw an
+	// exception.
+	//
+	//   result := deserialize(bytes)
+	//   value, err := AdminService_DescribeHistoryHost_Helper.UnwrapResponse(result)
+	UnwrapResponse func(*AdminService_DescribeHistoryHost_Result) (*shared.DescribeHistoryHostResponse, error)
+}{}
+
+func init() {
+	AdminService_DescribeHistoryHost_Helper.Args = func(
+		request *shared.DescribeHistoryHostRequest,
+	) *AdminService_DescribeHistoryHost_Args {
+		return &AdminService_DescribeHistoryHost_Args{
+			Request: request,
+		}
+	}
+
+	AdminService_DescribeHistoryHost_Helper.IsException = func(err error) bool {
+		switch err.(type) {
+		case *shared.BadRequestError:
+			return true
+		case *shared.InternalServiceError:
+			return true
+		case *shared.AccessDeniedError:
+			return true
+		default:
+			return false
+		}
+	}
+
+	AdminService_DescribeHistoryHost_Helper.WrapResponse = func(success *shared.DescribeHistoryHostResponse, err error) (*AdminService_DescribeHistoryHost_Result, error) {
+		if err == nil {
+			return &AdminService_DescribeHistoryHost_Result{Success: success}, nil
+		}
+
+		switch e := err.(type) {
+		case *shared.BadRequestError:
+			if e == nil {
+				return nil, errors.New("WrapResponse received non-nil error type with nil value for AdminService_DescribeHistoryHost_Result.BadRequestError")
+			}
+			return &AdminService_DescribeHistoryHost_Result{BadRequestError: e}, nil
+		case *shared.InternalServiceError:
+			if e == nil {
+				return nil, errors.New("WrapResponse received non-nil error type with nil value for AdminService_DescribeHistoryHost_Result.InternalServiceError")
+			}
+			return &AdminService_DescribeHistoryHost_Result{InternalServiceError: e}, nil
+		case *shared.AccessDeniedError:
+			if e == nil {
+				return nil, errors.New("WrapResponse received non-nil error type with nil value for AdminService_DescribeHistoryHost_Result.AccessDeniedError")
+			}
+			return &AdminService_DescribeHistoryHost_Result{AccessDeniedError: e}, nil
+		}
+
+		return nil, err
+	}
+	AdminService_DescribeHistoryHost_Helper.UnwrapResponse = func(result *AdminService_DescribeHistoryHost_Result) (success *shared.DescribeHistoryHostResponse, err error) {
+		if result.BadRequestError != nil {
+			err = result.BadRequestError
+			return
+		}
+		if result.InternalServiceError != nil {
+			err = result.InternalServiceError
+			return
+		}
+		if result.AccessDeniedError != nil {
+			err = result.AccessDeniedError
+			return
+		}
+
+		if result.Success != nil {
+			success = result.Success
+			return
+		}
+
+		err = errors.New("expected a non-void result")
+		return
+	}
+
+}
+
+// AdminService_DescribeHistoryHost_Result represents the result of a AdminService.DescribeHistoryHost function call.
+//
+// The result of a DescribeHistoryHost execution is sent and received over the wire as this struct.
+//
+// Success is set only if the function did not throw an exception.
+type AdminService_DescribeHistoryHost_Result struct {
+	// Value returned by DescribeHistoryHost after a successful execution.
+	Success              *shared.DescribeHistoryHostResponse `json:"success,omitempty"`
+	BadRequestError      *shared.BadRequestError             `json:"badRequestError,omitempty"`
+	InternalServiceError *shared.InternalServiceError        `json:"internalServiceError,omitempty"`
+	AccessDeniedError    *shared.AccessDeniedError           `json:"accessDeniedError,omitempty"`
+}
+
+// ToWire translates a AdminService_DescribeHistoryHost_Result struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *AdminService_DescribeHistoryHost_Result) ToWire() (wire.Value, error) {
+	var (
+		fields [4]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.Success != nil {
+		w, err = v.Success.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 0, Value: w}
+		i++
+	}
+	if v.BadRequestError != nil {
+		w, err = v.BadRequestError.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 1, Value: w}
+		i++
+	}
+	if v.InternalServiceError != nil {
+		w, err = v.InternalServiceError.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 2, Value: w}
+		i++
+	}
+	if v.AccessDeniedError != nil {
+		w, err = v.AccessDeniedError.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 3, Value: w}
+		i++
+	}
+
+	if i != 1 {
+		return wire.Value{}, fmt.Errorf("AdminService_DescribeHistoryHost_Result should have exactly one field: got %v fields", i)
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+func _DescribeHistoryHostResponse_Read(w wire.Value) (*shared.DescribeHistoryHostResponse, error) {
+	var v shared.DescribeHistoryHostResponse
+	err := v.FromWire(w)
+	return &v, err
+}
+
+// FromWire deserializes a AdminService_DescribeHistoryHost_Result struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a AdminService_DescribeHistoryHost_Result struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v AdminService_DescribeHistoryHost_Result
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *AdminService_DescribeHistoryHost_Result) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 0:
+			if field.Value.Type() == wire.TStruct {
+				v.Success, err = _DescribeHistoryHostResponse_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 1:
+			if field.Value.Type() == wire.TStruct {
+				v.BadRequestError, err = _BadRequestError_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 2:
+			if field.Value.Type() == wire.TStruct {
+				v.InternalServiceError, err = _InternalServiceError_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 3:
+			if field.Value.Type() == wire.TStruct {
+				v.AccessDeniedError, err = _AccessDeniedError_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+
+	count := 0
+	if v.Success != nil {
+		count++
+	}
+	if v.BadRequestError != nil {
+		count++
+	}
+	if v.InternalServiceError != nil {
+		count++
+	}
+	if v.AccessDeniedError != nil {
+		count++
+	}
+	if count != 1 {
+		return fmt.Errorf("AdminService_DescribeHistoryHost_Result should have exactly one field: got %v fields", count)
+	}
+
+	return nil
+}
+
+// String returns a readable string representation of a AdminService_DescribeHistoryHost_Result
+// struct.
+func (v *AdminService_DescribeHistoryHost_Result) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [4]string
+	i := 0
+	if v.Success != nil {
+		fields[i] = fmt.Sprintf("Success: %v", v.Success)
+		i++
+	}
+	if v.BadRequestError != nil {
+		fields[i] = fmt.Sprintf("BadRequestError: %v", v.BadRequestError)
+		i++
+	}
+	if v.InternalServiceError != nil {
+		fields[i] = fmt.Sprintf("InternalServiceError: %v", v.InternalServiceError)
+		i++
+	}
+	if v.AccessDeniedError != nil {
+		fields[i] = fmt.Sprintf("AccessDeniedError: %v", v.AccessDeniedError)
+		i++
+	}
+
+	return fmt.Sprintf("AdminService_DescribeHistoryHost_Result{%v}", strings.Join(fields[:i], ", "))
+}
+
+// Equals returns true if all the fields of this AdminService_DescribeHistoryHost_Result match the
+// provided AdminService_DescribeHistoryHost_Result.
+//
+// This function performs a deep comparison.
+func (v *AdminService_DescribeHistoryHost_Result) Equals(rhs *AdminService_DescribeHistoryHost_Result) bool {
+	if v == nil {
+		return rhs == nil
+	} else if rhs == nil {
+		return false
+	}
+	if !((v.Success == nil && rhs.Success == nil) || (v.Success != nil && rhs.Success != nil && v.Success.Equals(rhs.Success))) {
+		return false
+	}
+	if !((v.BadRequestError == nil && rhs.BadRequestError == nil) || (v.BadRequestError != nil && rhs.BadRequestError != nil && v.BadRequestError.Equals(rhs.BadRequestError))) {
+		return false
+	}
+	if !((v.InternalServiceError == nil && rhs.InternalServiceError == nil) || (v.InternalServiceError != nil && rhs.InternalServiceError != nil && v.InternalServiceError.Equals(rhs.InternalServiceError))) {
+		return false
+	}
+	if !((v.AccessDeniedError == nil && rhs.AccessDeniedError == nil) || (v.AccessDeniedError != nil && rhs.AccessDeniedError != nil && v.AccessDeniedError.Equals(rhs.AccessDeniedError))) {
+		return false
+	}
+
+	return true
+}
+
+// MarshalLogObject implements zapcore.ObjectMarshaler, enabling
+// fast logging of AdminService_DescribeHistoryHost_Result.
+func (v *AdminService_DescribeHistoryHost_Result) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+	if v == nil {
+		return nil
+	}
+	if v.Success != nil {
+		err = multierr.Append(err, enc.AddObject("success", v.Success))
+	}
+	if v.BadRequestError != nil {
+		err = multierr.Append(err, enc.AddObject("badRequestError", v.BadRequestError))
+	}
+	if v.InternalServiceError != nil {
+		err = multierr.Append(err, enc.AddObject("internalServiceError", v.InternalServiceError))
+	}
+	if v.AccessDeniedError != nil {
+		err = multierr.Append(err, enc.AddObject("accessDeniedError", v.AccessDeniedError))
+	}
+	return err
+}
+
+// GetSuccess returns the value of Success if it is set or its
+// zero value if it is unset.
+func (v *AdminService_DescribeHistoryHost_Result) GetSuccess() (o *shared.DescribeHistoryHostResponse) {
+	if v != nil && v.Success != nil {
+		return v.Success
+	}
+
+	return
+}
+
+// IsSetSuccess returns true if Success is not nil.
+func (v *AdminService_DescribeHistoryHost_Result) IsSetSuccess() bool {
+	return v != nil && v.Success != nil
+}
+
+// GetBadRequestError returns the value of BadRequestError if it is set or its
+// zero value if it is unset.
+func (v *AdminService_DescribeHistoryHost_Result) GetBadRequestError() (o *shared.BadRequestError) {
+	if v != nil && v.BadRequestError != nil {
+		return v.BadRequestError
+	}
+
+	return
+}
+
+// IsSetBadRequestError returns true if BadRequestError is not nil.
+func (v *AdminService_DescribeHistoryHost_Result) IsSetBadRequestError() bool {
+	return v != nil && v.BadRequestError != nil
+}
+
+// GetInternalServiceError returns the value of InternalServiceError if it is set or its
+// zero value if it is unset.
+func (v *AdminService_DescribeHistoryHost_Result) GetInternalServiceError() (o *shared.InternalServiceError) {
+	if v != nil && v.InternalServiceError != nil {
+		return v.InternalServiceError
+	}
+
+	return
+}
+
+// IsSetInternalServiceError returns true if InternalServiceError is not nil.
+func (v *AdminService_DescribeHistoryHost_Result) IsSetInternalServiceError() bool {
+	return v != nil && v.InternalServiceError != nil
+}
+
+// GetAccessDeniedError returns the value of AccessDeniedError if it is set or its
+// zero value if it is unset.
+func (v *AdminService_DescribeHistoryHost_Result) GetAccessDeniedError() (o *shared.AccessDeniedError) {
+	if v != nil && v.AccessDeniedError != nil {
+		return v.AccessDeniedError
+	}
+
+	return
+}
+
+// IsSetAccessDeniedError returns true if AccessDeniedError is not nil.
+func (v *AdminService_DescribeHistoryHost_Result) IsSetAccessDeniedError() bool {
+	return v != nil && v.AccessDeniedError != nil
+}
+
+// MethodName returns the name of the Thrift function as specified in
+// the IDL, for which this struct represent the result.
+//
+// This will always be "DescribeHistoryHost" for this struct.
+func (v *AdminService_DescribeHistoryHost_Result) MethodName() string {
+	return "DescribeHistoryHost"
+}
+
+// EnvelopeType returns the kind of value inside this struct.
+//
+// This will always be Reply for this struct.
+func (v *AdminService_DescribeHistoryHost_Result) EnvelopeType() wire.EnvelopeType {
+	return wire.Reply
+}
+
+// AdminService_DescribeSchedule_Args represents the arguments for the AdminService.DescribeSchedule function.
+//
+// The arguments for DescribeSchedule are sent and received over the wire as this struct.
+type AdminService_DescribeSchedule_Args struct {
+	Request *shared.DescribeScheduleRequest `json:"request,omitempty"`
+}
+
+// ToWire translates a AdminService_DescribeSchedule_Args struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *AdminService_DescribeSchedule_Args) ToWire() (wire.Value, error) {
+	var (
+		fields [1]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.Request != nil {
+		w, err = v.Request.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 1, Value: w}
+		i++
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+func _DescribeScheduleRequest_Read(w wire.Value) (*shared.DescribeScheduleRequest, error) {
+	var v shared.DescribeScheduleRequest
+	err := v.FromWire(w)
+	return &v, err
+}
+
+// FromWire deserializes a AdminService_DescribeSchedule_Args struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a AdminService_DescribeSchedule_Args struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v AdminService_DescribeSchedule_Args
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *AdminService_DescribeSchedule_Args) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 1:
+			if field.Value.Type() == wire.TStruct {
+				v.Request, err = _DescribeScheduleRequest_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+
+	return nil
+}
+
+// String returns a readable string representation of a AdminService_DescribeSchedule_Args
+// struct.
+func (v *AdminService_DescribeSchedule_Args) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [1]string
+	i := 0
+	if v.Request != nil {
+		fields[i] = fmt.Sprintf("Request: %v", v.Request)
+		i++
+	}
+
+	return fmt.Sprintf("AdminService_DescribeSchedule_Args{%v}", strings.Join(fields[:i], ", "))
+}
+
+// Equals returns true if all the fields of this AdminService_DescribeSchedule_Args match the
+// provided AdminService_DescribeSchedule_Args.
+//
+// This function performs a deep comparison.
+func (v *AdminService_DescribeSchedule_Args) Equals(rhs *AdminService_DescribeSchedule_Args) bool {
+	if v == nil {
+		return rhs == nil
+	} else if rhs == nil {
+		return false
+	}
+	if !((v.Request == nil && rhs.Request == nil) || (v.Request != nil && rhs.Request != nil && v.Request.Equals(rhs.Request))) {
+		return false
+	}
+
+	return true
+}
+
+// MarshalLogObject implements zapcore.ObjectMarshaler, enabling
+// fast logging of AdminService_DescribeSchedule_Args.
+func (v *AdminService_DescribeSchedule_Args) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+	if v == nil {
+		return nil
+	}
+	if v.Request != nil {
+		err = multierr.Append(err, enc.AddObject("request", v.Request))
+	}
+	return err
+}
+
+// GetRequest returns the value of Request if it is set or its
+// zero value if it is unset.
+func (v *AdminService_DescribeSchedule_Args) GetRequest() (o *shared.DescribeScheduleRequest) {
+	if v != nil && v.Request != nil {
+		return v.Request
+	}
+
+	return
+}
+
+// IsSetRequest returns true if Request is not nil.
+func (v *AdminService_DescribeSchedule_Args) IsSetRequest() bool {
+	return v != nil && v.Request != nil
+}
+
+// MethodName returns the name of the Thrift function as specified in
+// the IDL, for which this struct represent the arguments.
+//
+// This will always be "DescribeSchedule" for this struct.
+func (v *AdminService_DescribeSchedule_Args) MethodName() string {
+	return "DescribeSchedule"
+}
+
+// EnvelopeType returns the kind of value inside this struct.
+//
+// This will always be Call for this struct.
+func (v *AdminService_DescribeSchedule_Args) EnvelopeType() wire.EnvelopeType {
+	return wire.Call
+}
+
+// AdminService_DescribeSchedule_Helper provides functions that aid in handling the
+// parameters and return values of the AdminService.DescribeSchedule
+// function.
+var AdminService_DescribeSchedule_Helper = struct {
+	// Args accepts the parameters of DescribeSchedule in-order and returns
+	// the arguments struct for the function.
+	Args func(
+		request *shared.DescribeScheduleRequest,
+	) *AdminService_DescribeSchedule_Args
+
+	// IsException returns true if the given error can be thrown
+	// by DescribeSchedule.
+	//
+	// An error can be thrown by DescribeSchedule only if the
+	// corresponding exception type was mentioned in the 'throws'
+	// section for it in the Thrift file.
+	IsException func(error) bool
+
+	// WrapResponse returns the result struct for DescribeSchedule
+	// given its return value and error.
+	//
+	// This allows mapping values and errors returned by
+	// DescribeSchedule into a serializable result struct.
+	// WrapResponse returns a non-nil error if the provided
+	// error cannot be thrown by DescribeSchedule
+	//
+	//   value, err := DescribeSchedule(args)
+	//   result, err := AdminService_DescribeSchedule_Helper.WrapResponse(value, err)
+	//   if err != nil {
+	//     return fmt.Errorf("unexpected error from DescribeSchedule: %v", err)
+	//   }
+	//   serialize(result)
+	WrapResponse func(*shared.DescribeScheduleResponse, error) (*AdminService_DescribeSchedule_Result, error)
+
+	// UnwrapResponse takes the result struct for DescribeSchedule
+	// and returns the value or error returned by it.
+	//
+	// The error is non-nil only if DescribeSchedule threw an
+	// exception.
+	//
+	//   result := deserialize(bytes)
+	//   value, err := AdminService_DescribeSchedule_Helper.UnwrapResponse(result)
+	UnwrapResponse func(*AdminService_DescribeSchedule_Result) (*shared.DescribeScheduleResponse, error)
+}{}
+
+func init() {
+	AdminService_DescribeSchedule_Helper.Args = func(
+		request *shared.DescribeScheduleRequest,
+	) *AdminService_DescribeSchedule_Args {
+		return &AdminService_DescribeSchedule_Args{
+			Request: request,
+		}
+	}
+
+	AdminService_DescribeSchedule_Helper.IsException = func(err error) bool {
+		switch err.(type) {
+		case *shared.BadRequestError:
+			return true
+		case *shared.InternalServiceError:
+			return true
+		case *shared.EntityNotExistsError:
+			return true
+		case *shared.AccessDeniedError:
+			return true
+		default:
+			return false
+		}
+	}
+
+	AdminService_DescribeSchedule_Helper.WrapResponse = func(success *shared.DescribeScheduleResponse, err error) (*AdminService_DescribeSchedule_Result, error) {
+		if err == nil {
+			return &AdminService_DescribeSchedule_Result{Success: success}, nil
+		}
+
+		switch e := err.(type) {
+		case *shared.BadRequestError:
+			if e == nil {
+				return nil, errors.New("WrapResponse received non-nil error type with nil value for AdminService_DescribeSchedule_Result.BadRequestError")
+			}
+			return &AdminService_DescribeSchedule_Result{BadRequestError: e}, nil
+		case *shared.InternalServiceError:
+			if e == nil {
+				return nil, errors.New("WrapResponse received non-nil error type with nil value for AdminService_DescribeSchedule_Result.InternalServiceError")
+			}
+			return &AdminService_DescribeSchedule_Result{InternalServiceError: e}, nil
+		case *shared.EntityNotExistsError:
+			if e == nil {
+				return nil, errors.New("WrapResponse received non-nil error type with nil value for AdminService_DescribeSchedule_Result.EntityNotExistError")
+			}
+			return &AdminService_DescribeSchedule_Result{EntityNotExistError: e}, nil
+		case *shared.AccessDeniedError:
+			if e == nil {
+				return nil, errors.New("WrapResponse received non-nil error type with nil value for AdminService_DescribeSchedule_Result.AccessDeniedError")
+			}
+			return &AdminService_DescribeSchedule_Result{AccessDeniedError: e}, nil
+		}
+
+		return nil, err
+	}
+	AdminService_DescribeSchedule_Helper.UnwrapResponse = func(result *AdminService_DescribeSchedule_Result) (success *shared.DescribeScheduleResponse, err error) {
+		if result.BadRequestError != nil {
+			err = result.BadRequestError
+			return
+		}
+		if result.InternalServiceError != nil {
+			err = result.InternalServiceError
+			return
+		}
+		if result.EntityNotExistError != nil {
+			err = result.EntityNotExistError
+			return
+		}
+		if result.AccessDeniedError != nil {
+			err = result.AccessDeniedError
+			return
+		}
+
+		if result.Success != nil {
+			success = result.Success
+			return
+		}
+
+		err = errors.New("expected a non-void result")
+		return
+	}
+
+}
+
+// AdminService_DescribeSchedule_Result represents the result of a AdminService.DescribeSchedule function call.
+//
+// The result of a DescribeSchedule execution is sent and received over the wire as this struct.
+//
+// Success is set only if the function did not throw an exception.
+type AdminService_DescribeSchedule_Result struct {
+	// Value returned by DescribeSchedule after a successful execution.
+	Success              *shared.DescribeScheduleResponse `json:"success,omitempty"`
+	BadRequestError      *shared.BadRequestError          `json:"badRequestError,omitempty"`
+	InternalServiceError *shared.InternalServiceError     `json:"internalServiceError,omitempty"`
+	EntityNotExistError  *shared.EntityNotExistsError     `json:"entityNotExistError,omitempty"`
+	AccessDeniedError    *shared.AccessDeniedError        `json:"accessDeniedError,omitempty"`
+}
+
+// ToWire translates a AdminService_DescribeSchedule_Result struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *AdminService_DescribeSchedule_Result) ToWire() (wire.Value, error) {
+	var (
+		fields [5]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.Success != nil {
+		w, err = v.Success.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 0, Value: w}
+		i++
+	}
+	if v.BadRequestError != nil {
+		w, err = v.BadRequestError.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 1, Value: w}
+		i++
+	}
+	if v.InternalServiceError != nil {
+		w, err = v.InternalServiceError.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 2, Value: w}
+		i++
+	}
+	if v.EntityNotExistError != nil {
+		w, err = v.EntityNotExistError.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 3, Value: w}
+		i++
+	}
+	if v.AccessDeniedError != nil {
+		w, err = v.AccessDeniedError.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 4, Value: w}
+		i++
+	}
+
+	if i != 1 {
+		return wire.Value{}, fmt.Errorf("AdminService_DescribeSchedule_Result should have exactly one field: got %v fields", i)
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+func _DescribeScheduleResponse_Read(w wire.Value) (*shared.DescribeScheduleResponse, error) {
+	var v shared.DescribeScheduleResponse
+	err := v.FromWire(w)
+	return &v, err
+}
+
+// FromWire deserializes a AdminService_DescribeSchedule_Result struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a AdminService_DescribeSchedule_Result struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v AdminService_DescribeSchedule_Result
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *AdminService_DescribeSchedule_Result) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 0:
+			if field.Value.Type() == wire.TStruct {
+				v.Success, err = _DescribeScheduleResponse_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 1:
+			if field.Value.Type() == wire.TStruct {
+				v.BadRequestError, err = _BadRequestError_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 2:
+			if field.Value.Type() == wire.TStruct {
+				v.InternalServiceError, err = _InternalServiceError_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 3:
+			if field.Value.Type() == wire.TStruct {
+				v.EntityNotExistError, err = _EntityNotExistsError_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 4:
+			if field.Value.Type() == wire.TStruct {
+				v.AccessDeniedError, err = _AccessDeniedError_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+
+	count := 0
+	if v.Success != nil {
+		count++
+	}
+	if v.BadRequestError != nil {
+		count++
+	}
+	if v.InternalServiceError != nil {
+		count++
+	}
+	if v.EntityNotExistError != nil {
+		count++
+	}
+	if v.AccessDeniedError != nil {
+		count++
+	}
+	if count != 1 {
+		return fmt.Errorf("AdminService_DescribeSchedule_Result should have exactly one field: got %v fields", count)
+	}
+
+	return nil
+}
+
+// String returns a readable string representation of a AdminService_DescribeSchedule_Result
+// struct.
+func (v *AdminService_DescribeSchedule_Result) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [5]string
+	i := 0
+	if v.Success != nil {
+		fields[i] = fmt.Sprintf("Success: %v", v.Success)
+		i++
+	}
+	if v.BadRequestError != nil {
+		fields[i] = fmt.Sprintf("BadRequestError: %v", v.BadRequestError)
+		i++
+	}
+	if v.InternalServiceError != nil {
+		fields[i] = fmt.Sprintf("InternalServiceError: %v", v.InternalServiceError)
+		i++
+	}
+	if v.EntityNotExistError != nil {
+		fields[i] = fmt.Sprintf("EntityNotExistError: %v", v.EntityNotExistError)
+		i++
+	}
+	if v.AccessDeniedError != nil {
+		fields[i] = fmt.Sprintf("AccessDeniedError: %v", v.AccessDeniedError)
+		i++
+	}
+
+	return fmt.Sprintf("AdminService_DescribeSchedule_Result{%v}", strings.Join(fields[:i], ", "))
+}
+
+// Equals returns true if all the fields of this AdminService_DescribeSchedule_Result match the
+// provided AdminService_DescribeSchedule_Result.
+//
+// This function performs a deep comparison.
+func (v *AdminService_DescribeSchedule_Result) Equals(rhs *AdminService_DescribeSchedule_Result) bool {
+	if v == nil {
+		return rhs == nil
+	} else if rhs == nil {
+		return false
+	}
+	if !((v.Success == nil && rhs.Success == nil) || (v.Success != nil && rhs.Success != nil && v.Success.Equals(rhs.Success))) {
+		return false
+	}
+	if !((v.BadRequestError == nil && rhs.BadRequestError == nil) || (v.BadRequestError != nil && rhs.BadRequestError != nil && v.BadRequestError.Equals(rhs.BadRequestError))) {
+		return false
+	}
+	if !((v.InternalServiceError == nil && rhs.InternalServiceError == nil) || (v.InternalServiceError != nil && rhs.InternalServiceError != nil && v.InternalServiceError.Equals(rhs.InternalServiceError))) {
+		return false
+	}
+	if !((v.EntityNotExistError == nil && rhs.EntityNotExistError == nil) || (v.EntityNotExistError != nil && rhs.EntityNotExistError != nil && v.EntityNotExistError.Equals(rhs.EntityNotExistError))) {
+		return false
+	}
+	if !((v.AccessDeniedError == nil && rhs.AccessDeniedError == nil) || (v.AccessDeniedError != nil && rhs.AccessDeniedError != nil && v.AccessDeniedError.Equals(rhs.AccessDeniedError))) {
+		return false
+	}
+
+	return true
+}
+
+// MarshalLogObject implements zapcore.ObjectMarshaler, enabling
+// fast logging of AdminService_DescribeSchedule_Result.
+func (v *AdminService_DescribeSchedule_Result) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+	if v == nil {
+		return nil
+	}
+	if v.Success != nil {
+		err = multierr.Append(err, enc.AddObject("success", v.Success))
+	}
+	if v.BadRequestError != nil {
+		err = multierr.Append(err, enc.AddObject("badRequestError", v.BadRequestError))
+	}
+	if v.InternalServiceError != nil {
+		err = multierr.Append(err, enc.AddObject("internalServiceError", v.InternalServiceError))
+	}
+	if v.EntityNotExistError != nil {
+		err = multierr.Append(err, enc.AddObject("entityNotExistError", v.EntityNotExistError))
+	}
+	if v.AccessDeniedError != nil {
+		err = multierr.Append(err, enc.AddObject("accessDeniedError", v.AccessDeniedError))
+	}
+	return err
+}
+
+// GetSuccess returns the value of Success if it is set or its
+// zero value if it is unset.
+func (v *AdminService_DescribeSchedule_Result) GetSuccess() (o *shared.DescribeScheduleResponse) {
+	if v != nil && v.Success != nil {
+		return v.Success
+	}
+
+	return
+}
+
+// IsSetSuccess returns true if Success is not nil.
+func (v *AdminService_DescribeSchedule_Result) IsSetSuccess() bool {
+	return v != nil && v.Success != nil
+}
+
+// GetBadRequestError returns the value of BadRequestError if it is set or its
+// zero value if it is unset.
+func (v *AdminService_DescribeSchedule_Result) GetBadRequestError() (o *shared.BadRequestError) {
+	if v != nil && v.BadRequestError != nil {
+		return v.BadRequestError
+	}
+
+	return
+}
+
+// IsSetBadRequestError returns true if BadRequestError is not nil.
+func (v *AdminService_DescribeSchedule_Result) IsSetBadRequestError() bool {
+	return v != nil && v.BadRequestError != nil
+}
+
+// GetInternalServiceError returns the value of InternalServiceError if it is set or its
+// zero value if it is unset.
+func (v *AdminService_DescribeSchedule_Result) GetInternalServiceError() (o *shared.InternalServiceError) {
+	if v != nil && v.InternalServiceError != nil {
+		return v.InternalServiceError
+	}
+
+	return
+}
+
+// IsSetInternalServiceError returns true if InternalServiceError is not nil.
+func (v *AdminService_DescribeSchedule_Result) IsSetInternalServiceError() bool {
+	return v != nil && v.InternalServiceError != nil
+}
+
+// GetEntityNotExistError returns the value of EntityNotExistError if it is set or its
+// zero value if it is unset.
+func (v *AdminService_DescribeSchedule_Result) GetEntityNotExistError() (o *shared.EntityNotExistsError) {
+	if v != nil && v.EntityNotExistError != nil {
+		return v.EntityNotExistError
+	}
+
+	return
+}
+
+// IsSetEntityNotExistError returns true if EntityNotExistError is not nil.
+func (v *AdminService_DescribeSchedule_Result) IsSetEntityNotExistError() bool {
+	return v != nil && v.EntityNotExistError != nil
+}
+
+// GetAccessDeniedError returns the value of AccessDeniedError if it is set or its
+// zero value if it is unset.
+func (v *AdminService_DescribeSchedule_Result) GetAccessDeniedError() (o *shared.AccessDeniedError) {
+	if v != nil && v.AccessDeniedError != nil {
+		return v.AccessDeniedError
+	}
+
+	return
+}
+
+// IsSetAccessDeniedError returns true if AccessDeniedError is not nil.
+func (v *AdminService_DescribeSchedule_Result) IsSetAccessDeniedError() bool {
+	return v != nil && v.AccessDeniedError != nil
+}
+
+// MethodName returns the name of the Thrift function as specified in
+// the IDL, for which this struct represent the result.
+//
+// This will always be "DescribeSchedule" for this struct.
+func (v *AdminService_DescribeSchedule_Result) MethodName() string {
+	return "DescribeSchedule"
+}
+
+// EnvelopeType returns the kind of value inside this struct.
+//
+// This will always be Reply for this struct.
+func (v *AdminService_DescribeSchedule_Result) EnvelopeType() wire.EnvelopeType {
+	return wire.Reply
+}
+
+// AdminService_DescribeTaskJourney_Args represents the arguments for the AdminService.DescribeTaskJourney function.
+//
+// The arguments for DescribeTaskJourney are sent and received over the wire as this struct.
+type AdminService_DescribeTaskJourney_Args struct {
+	Request *shared.DescribeTaskJourneyRequest `json:"request,omitempty"`
+}
+
+// ToWire translates a AdminService_DescribeTaskJourney_Args struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *AdminService_DescribeTaskJourney_Args) ToWire() (wire.Value, error) {
+	var (
+		fields [1]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.Request != nil {
+		w, err = v.Request.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 1, Value: w}
+		i++
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+func _DescribeTaskJourneyRequest_Read(w wire.Value) (*shared.DescribeTaskJourneyRequest, error) {
+	var v shared.DescribeTaskJourneyRequest
+	err := v.FromWire(w)
+	return &v, err
+}
+
+// FromWire deserializes a AdminService_DescribeTaskJourney_Args struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a AdminService_DescribeTaskJourney_Args struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v AdminService_DescribeTaskJourney_Args
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *AdminService_DescribeTaskJourney_Args) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 1:
+			if field.Value.Type() == wire.TStruct {
+				v.Request, err = _DescribeTaskJourneyRequest_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+
+	return nil
+}
+
+// String returns a readable string representation of a AdminService_DescribeTaskJourney_Args
+// struct.
+func (v *AdminService_DescribeTaskJourney_Args) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [1]string
+	i := 0
+	if v.Request != nil {
+		fields[i] = fmt.Sprintf("Request: %v", v.Request)
+		i++
+	}
+
+	return fmt.Sprintf("AdminService_DescribeTaskJourney_Args{%v}", strings.Join(fields[:i], ", "))
+}
+
+// Equals returns true if all the fields of this AdminService_DescribeTaskJourney_Args match the
+// provided AdminService_DescribeTaskJourney_Args.
+//
+// This function performs a deep comparison.
+func (v *AdminService_DescribeTaskJourney_Args) Equals(rhs *AdminService_DescribeTaskJourney_Args) bool {
+	if v == nil {
+		return rhs == nil
+	} else if rhs == nil {
+		return false
+	}
+	if !((v.Request == nil && rhs.Request == nil) || (v.Request != nil && rhs.Request != nil && v.Request.Equals(rhs.Request))) {
+		return false
+	}
+
+	return true
+}
+
+// MarshalLogObject implements zapcore.ObjectMarshaler, enabling
+// fast logging of AdminService_DescribeTaskJourney_Args.
+func (v *AdminService_DescribeTaskJourney_Args) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+	if v == nil {
+		return nil
+	}
+	if v.Request != nil {
+		err = multierr.Append(err, enc.AddObject("request", v.Request))
+	}
+	return err
+}
+
+// GetRequest returns the value of Request if it is set or its
+// zero value if it is unset.
+func (v *AdminService_DescribeTaskJourney_Args) GetRequest() (o *shared.DescribeTaskJourneyRequest) {
+	if v != nil && v.Request != nil {
+		return v.Request
+	}
+
+	return
+}
+
+// IsSetRequest returns true if Request is not nil.
+func (v *AdminService_DescribeTaskJourney_Args) IsSetRequest() bool {
+	return v != nil && v.Request != nil
+}
+
+// MethodName returns the name of the Thrift function as specified in
+// the IDL, for which this struct represent the arguments.
+//
+// This will always be "DescribeTaskJourney" for this struct.
+func (v *AdminService_DescribeTaskJourney_Args) MethodName() string {
+	return "DescribeTaskJourney"
+}
+
+// EnvelopeType returns the kind of value inside this struct.
+//
+// This will always be Call for this struct.
+func (v *AdminService_DescribeTaskJourney_Args) EnvelopeType() wire.EnvelopeType {
+	return wire.Call
+}
+
+// AdminService_DescribeTaskJourney_Helper provides functions that aid in handling the
+// parameters and return values of the AdminService.DescribeTaskJourney
+// function.
+var AdminService_DescribeTaskJourney_Helper = struct {
+	// Args accepts the parameters of DescribeTaskJourney in-order and returns
+	// the arguments struct for the function.
+	Args func(
+		request *shared.DescribeTaskJourneyRequest,
+	) *AdminService_DescribeTaskJourney_Args
+
+	// IsException returns true if the given error can be thrown
+	// by DescribeTaskJourney.
+	//
+	// An error can be thrown by DescribeTaskJourney only if the
+	// corresponding exception type was mentioned in the 'throws'
+	// section for it in the Thrift file.
+	IsException func(error) bool
+
+	// WrapResponse returns the result struct for DescribeTaskJourney
+	// given its return value and error.
+	//
+	// This allows mapping values and errors returned by
+	// DescribeTaskJourney into a serializable result struct.
+	// WrapResponse returns a non-nil error if the provided
+	// error cannot be thrown by DescribeTaskJourney
+	//
+	//   value, err := DescribeTaskJourney(args)
+	//   result, err := AdminService_DescribeTaskJourney_Helper.WrapResponse(value, err)
+	//   if err != nil {
+	//     return fmt.Errorf("unexpected error from DescribeTaskJourney: %v", err)
+	//   }
+	//   serialize(result)
+	WrapResponse func(*shared.DescribeTaskJourneyResponse, error) (*AdminService_DescribeTaskJourney_Result, error)
+
+	// UnwrapResponse takes the result struct for DescribeTaskJourney
+	// and returns the value or error returned by it.
+	//
+	// The error is non-nil only if DescribeTaskJourney threw an
+	// exception.
+	//
+	//   result := deserialize(bytes)
+	//   value, err := AdminService_DescribeTaskJourney_Helper.UnwrapResponse(result)
+	UnwrapResponse func(*AdminService_DescribeTaskJourney_Result) (*shared.DescribeTaskJourneyResponse, error)
+}{}
+
+func init() {
+	AdminService_DescribeTaskJourney_Helper.Args = func(
+		request *shared.DescribeTaskJourneyRequest,
+	) *AdminService_DescribeTaskJourney_Args {
+		return &AdminService_DescribeTaskJourney_Args{
+			Request: request,
+		}
+	}
+
+	AdminService_DescribeTaskJourney_Helper.IsException = func(err error) bool {
+		switch err.(type) {
+		case *shared.BadRequestError:
+			return true
+		case *shared.InternalServiceError:
+			return true
+		case *shared.AccessDeniedError:
+			return true
+		default:
+			return false
+		}
+	}
+
+	AdminService_DescribeTaskJourney_Helper.WrapResponse = func(success *shared.DescribeTaskJourneyResponse, err error) (*AdminService_DescribeTaskJourney_Result, error) {
+		if err == nil {
+			return &AdminService_DescribeTaskJourney_Result{Success: success}, nil
+		}
+
+		switch e := err.(type) {
+		case *shared.BadRequestError:
+			if e == nil {
+				return nil, errors.New("WrapResponse received non-nil error type with nil value for AdminService_DescribeTaskJourney_Result.BadRequestError")
+			}
+			return &AdminService_DescribeTaskJourney_Result{BadRequestError: e}, nil
+		case *shared.InternalServiceError:
+			if e == nil {
+				return nil, errors.New("WrapResponse received non-nil error type with nil value for AdminService_DescribeTaskJourney_Result.InternalServiceError")
+			}
+			return &AdminService_DescribeTaskJourney_Result{InternalServiceError: e}, nil
+		case *shared.AccessDeniedError:
+			if e == nil {
+				return nil, errors.New("WrapResponse received non-nil error type with nil value for AdminService_DescribeTaskJourney_Result.AccessDeniedError")
+			}
+			return &AdminService_DescribeTaskJourney_Result{AccessDeniedError: e}, nil
+		}
+
+		return nil, err
+	}
+	AdminService_DescribeTaskJourney_Helper.UnwrapResponse = func(result *AdminService_DescribeTaskJourney_Result) (success *shared.DescribeTaskJourneyResponse, err error) {
+		if result.BadRequestError != nil {
+			err = result.BadRequestError
+			return
+		}
+		if result.InternalServiceError != nil {
+			err = result.InternalServiceError
+			return
+		}
+		if result.AccessDeniedError != nil {
+			err = result.AccessDeniedError
+			return
+		}
+
+		if result.Success != nil {
+			success = result.Success
+			return
+		}
+
+		err = errors.New("expected a non-void result")
+		return
+	}
+
+}
+
+// AdminService_DescribeTaskJourney_Result represents the result of a AdminService.DescribeTaskJourney function call.
+//
+// The result of a DescribeTaskJourney execution is sent and received over the wire as this struct.
+//
+// Success is set only if the function did not throw an exception.
+type AdminService_DescribeTaskJourney_Result struct {
+	// Value returned by DescribeTaskJourney after a successful execution.
+	Success              *shared.DescribeTaskJourneyResponse `json:"success,omitempty"`
+	BadRequestError      *shared.BadRequestError             `json:"badRequestError,omitempty"`
+	InternalServiceError *shared.InternalServiceError        `json:"internalServiceError,omitempty"`
+	AccessDeniedError    *shared.AccessDeniedError           `json:"accessDeniedError,omitempty"`
+}
+
+// ToWire translates a AdminService_DescribeTaskJourney_Result struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *AdminService_DescribeTaskJourney_Result) ToWire() (wire.Value, error) {
+	var (
+		fields [4]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.Success != nil {
+		w, err = v.Success.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 0, Value: w}
+		i++
+	}
+	if v.BadRequestError != nil {
+		w, err = v.BadRequestError.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 1, Value: w}
+		i++
+	}
+	if v.InternalServiceError != nil {
+		w, err = v.InternalServiceError.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 2, Value: w}
+		i++
+	}
+	if v.AccessDeniedError != nil {
+		w, err = v.AccessDeniedError.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 3, Value: w}
+		i++
+	}
+
+	if i != 1 {
+		return wire.Value{}, fmt.Errorf("AdminService_DescribeTaskJourney_Result should have exactly one field: got %v fields", i)
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+func _DescribeTaskJourneyResponse_Read(w wire.Value) (*shared.DescribeTaskJourneyResponse, error) {
+	var v shared.DescribeTaskJourneyResponse
+	err := v.FromWire(w)
+	return &v, err
+}
+
+// FromWire deserializes a AdminService_DescribeTaskJourney_Result struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a AdminService_DescribeTaskJourney_Result struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v AdminService_DescribeTaskJourney_Result
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *AdminService_DescribeTaskJourney_Result) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 0:
+			if field.Value.Type() == wire.TStruct {
+				v.Success, err = _DescribeTaskJourneyResponse_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 1:
+			if field.Value.Type() == wire.TStruct {
+				v.BadRequestError, err = _BadRequestError_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 2:
+			if field.Value.Type() == wire.TStruct {
+				v.InternalServiceError, err = _InternalServiceError_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 3:
+			if field.Value.Type() == wire.TStruct {
+				v.AccessDeniedError, err = _AccessDeniedError_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+
+	count := 0
+	if v.Success != nil {
+		count++
+	}
+	if v.BadRequestError != nil {
+		count++
+	}
+	if v.InternalServiceError != nil {
+		count++
+	}
+	if v.AccessDeniedError != nil {
+		count++
+	}
+	if count != 1 {
+		return fmt.Errorf("AdminService_DescribeTaskJourney_Result should have exactly one field: got %v fields", count)
+	}
+
+	return nil
+}
+
+// String returns a readable string representation of a AdminService_DescribeTaskJourney_Result
+// struct.
+func (v *AdminService_DescribeTaskJourney_Result) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [4]string
+	i := 0
+	if v.Success != nil {
+		fields[i] = fmt.Sprintf("Success: %v", v.Success)
+		i++
+	}
+	if v.BadRequestError != nil {
+		fields[i] = fmt.Sprintf("BadRequestError: %v", v.BadRequestError)
+		i++
+	}
+	if v.InternalServiceError != nil {
+		fields[i] = fmt.Sprintf("InternalServiceError: %v", v.InternalServiceError)
+		i++
+	}
+	if v.AccessDeniedError != nil {
+		fields[i] = fmt.Sprintf("AccessDeniedError: %v", v.AccessDeniedError)
+		i++
+	}
+
+	return fmt.Sprintf("AdminService_DescribeTaskJourney_Result{%v}", strings.Join(fields[:i], ", "))
+}
+
+// Equals returns true if all the fields of this AdminService_DescribeTaskJourney_Result match the
+// provided AdminService_DescribeTaskJourney_Result.
+//
+// This function performs a deep comparison.
+func (v *AdminService_DescribeTaskJourney_Result) Equals(rhs *AdminService_DescribeTaskJourney_Result) bool {
+	if v == nil {
+		return rhs == nil
+	} else if rhs == nil {
+		return false
+	}
+	if !((v.Success == nil && rhs.Success == nil) || (v.Success != nil && rhs.Success != nil && v.Success.Equals(rhs.Success))) {
+		return false
+	}
+	if !((v.BadRequestError == nil && rhs.BadRequestError == nil) || (v.BadRequestError != nil && rhs.BadRequestError != nil && v.BadRequestError.Equals(rhs.BadRequestError))) {
+		return false
+	}
+	if !((v.InternalServiceError == nil && rhs.InternalServiceError == nil) || (v.InternalServiceError != nil && rhs.InternalServiceError != nil && v.InternalServiceError.Equals(rhs.InternalServiceError))) {
+		return false
+	}
+	if !((v.AccessDeniedError == nil && rhs.AccessDeniedError == nil) || (v.AccessDeniedError != nil && rhs.AccessDeniedError != nil && v.AccessDeniedError.Equals(rhs.AccessDeniedError))) {
+		return false
+	}
+
+	return true
+}
+
+// MarshalLogObject implements zapcore.ObjectMarshaler, enabling
+// fast logging of AdminService_DescribeTaskJourney_Result.
+func (v *AdminService_DescribeTaskJourney_Result) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+	if v == nil {
+		return nil
+	}
+	if v.Success != nil {
+		err = multierr.Append(err, enc.AddObject("success", v.Success))
+	}
+	if v.BadRequestError != nil {
+		err = multierr.Append(err, enc.AddObject("badRequestError", v.BadRequestError))
+	}
+	if v.InternalServiceError != nil {
+		err = multierr.Append(err, enc.AddObject("internalServiceError", v.InternalServiceError))
+	}
+	if v.AccessDeniedError != nil {
+		err = multierr.Append(err, enc.AddObject("accessDeniedError", v.AccessDeniedError))
+	}
+	return err
+}
+
+// GetSuccess returns the value of Success if it is set or its
+// zero value if it is unset.
+func (v *AdminService_DescribeTaskJourney_Result) GetSuccess() (o *shared.DescribeTaskJourneyResponse) {
+	if v != nil && v.Success != nil {
+		return v.Success
+	}
+
+	return
+}
+
+// IsSetSuccess returns true if Success is not nil.
+func (v *AdminService_DescribeTaskJourney_Result) IsSetSuccess() bool {
+	return v != nil && v.Success != nil
+}
+
+// GetBadRequestError returns the value of BadRequestError if it is set or its
+// zero value if it is unset.
+func (v *AdminService_DescribeTaskJourney_Result) GetBadRequestError() (o *shared.BadRequestError) {
+	if v != nil && v.BadRequestError != nil {
+		return v.BadRequestError
+	}
+
+	return
+}
+
+// IsSetBadRequestError returns true if BadRequestError is not nil.
+func (v *AdminService_DescribeTaskJourney_Result) IsSetBadRequestError() bool {
+	return v != nil && v.BadRequestError != nil
+}
+
+// GetInternalServiceError returns the value of InternalServiceError if it is set or its
+// zero value if it is unset.
+func (v *AdminService_DescribeTaskJourney_Result) GetInternalServiceError() (o *shared.InternalServiceError) {
+	if v != nil && v.InternalServiceError != nil {
+		return v.InternalServiceError
+	}
+
+	return
+}
+
+// IsSetInternalServiceError returns true if InternalServiceError is not nil.
+func (v *AdminService_DescribeTaskJourney_Result) IsSetInternalServiceError() bool {
+	return v != nil && v.InternalServiceError != nil
+}
+
+// GetAccessDeniedError returns the value of AccessDeniedError if it is set or its
+// zero value if it is unset.
+func (v *AdminService_DescribeTaskJourney_Result) GetAccessDeniedError() (o *shared.AccessDeniedError) {
+	if v != nil && v.AccessDeniedError != nil {
+		return v.AccessDeniedError
+	}
+
+	return
+}
+
+// IsSetAccessDeniedError returns true if AccessDeniedError is not nil.
+func (v *AdminService_DescribeTaskJourney_Result) IsSetAccessDeniedError() bool {
+	return v != nil && v.AccessDeniedError != nil
+}
+
+// MethodName returns the name of the Thrift function as specified in
+// the IDL, for which this struct represent the result.
+//
+// This will always be "DescribeTaskJourney" for this struct.
+func (v *AdminService_DescribeTaskJourney_Result) MethodName() string {
+	return "DescribeTaskJourney"
+}
+
+// EnvelopeType returns the kind of value inside this struct.
+//
+// This will always be Reply for this struct.
+func (v *AdminService_DescribeTaskJourney_Result) EnvelopeType() wire.EnvelopeType {
+	return wire.Reply
+}
+
+// AdminService_DescribeWorkflowExecution_Args represents the arguments for the AdminService.DescribeWorkflowExecution function.
+//
+// The arguments for DescribeWorkflowExecution are sent and received over the wire as this struct.
+type AdminService_DescribeWorkflowExecution_Args struct {
+	Request *DescribeWorkflowExecutionRequest `json:"request,omitempty"`
+}
+
+// ToWire translates a AdminService_DescribeWorkflowExecution_Args struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *AdminService_DescribeWorkflowExecution_Args) ToWire() (wire.Value, error) {
+	var (
+		fields [1]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.Request != nil {
+		w, err = v.Request.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 1, Value: w}
+		i++
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+func _DescribeWorkflowExecutionRequest_Read(w wire.Value) (*DescribeWorkflowExecutionRequest, error) {
+	var v DescribeWorkflowExecutionRequest
+	err := v.FromWire(w)
+	return &v, err
+}
+
+// FromWire deserializes a AdminService_DescribeWorkflowExecution_Args struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a AdminService_DescribeWorkflowExecution_Args struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v AdminService_DescribeWorkflowExecution_Args
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *AdminService_DescribeWorkflowExecution_Args) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 1:
+			if field.Value.Type() == wire.TStruct {
+				v.Request, err = _DescribeWorkflowExecutionRequest_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+
+	return nil
+}
+
+// String returns a readable string representation of a AdminService_DescribeWorkflowExecution_Args
+// struct.
+func (v *AdminService_DescribeWorkflowExecution_Args) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [1]string
+	i := 0
+	if v.Request != nil {
+		fields[i] = fmt.Sprintf("Request: %v", v.Request)
+		i++
+	}
+
+	return fmt.Sprintf("AdminService_DescribeWorkflowExecution_Args{%v}", strings.Join(fields[:i], ", "))
+}
+
+// Equals returns true if all the fields of this AdminService_DescribeWorkflowExecution_Args match the
+// provided AdminService_DescribeWorkflowExecution_Args.
+//
+// This function performs a deep comparison.
+func (v *AdminService_DescribeWorkflowExecution_Args) Equals(rhs *AdminService_DescribeWorkflowExecution_Args) bool {
+	if v == nil {
+		return rhs == nil
+	} else if rhs == nil {
+		return false
+	}
+	if !((v.Request == nil && rhs.Request == nil) || (v.Request != nil && rhs.Request != nil && v.Request.Equals(rhs.Request))) {
+		return false
+	}
+
+	return true
+}
+
+// MarshalLogObject implements zapcore.ObjectMarshaler, enabling
+// fast logging of AdminService_DescribeWorkflowExecution_Args.
+func (v *AdminService_DescribeWorkflowExecution_Args) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+	if v == nil {
+		return nil
+	}
+	if v.Request != nil {
+		err = multierr.Append(err, enc.AddObject("request", v.Request))
+	}
+	return err
+}
+
+// GetRequest returns the value of Request if it is set or its
+// zero value if it is unset.
+func (v *AdminService_DescribeWorkflowExecution_Args) GetRequest() (o *DescribeWorkflowExecutionRequest) {
+	if v != nil && v.Request != nil {
+		return v.Request
+	}
+
+	return
+}
+
+// IsSetRequest returns true if Request is not nil.
+func (v *AdminService_DescribeWorkflowExecution_Args) IsSetRequest() bool {
+	return v != nil && v.Request != nil
+}
+
+// MethodName returns the name of the Thrift function as specified in
+// the IDL, for which this struct represent the arguments.
+//
+// This will always be "DescribeWorkflowExecution" for this struct.
+func (v *AdminService_DescribeWorkflowExecution_Args) MethodName() string {
+	return "DescribeWorkflowExecution"
+}
+
+// EnvelopeType returns the kind of value inside this struct.
+//
+// This will always be Call for this struct.
+func (v *AdminService_DescribeWorkflowExecution_Args) EnvelopeType() wire.EnvelopeType {
+	return wire.Call
+}
+
+// AdminService_DescribeWorkflowExecution_Helper provides functions that aid in handling the
+// parameters and return values of the AdminService.DescribeWorkflowExecution
+// function.
+var AdminService_DescribeWorkflowExecution_Helper = struct {
+	// Args accepts the parameters of DescribeWorkflowExecution in-order and returns
+	// the arguments struct for the function.
+	Args func(
+		request *DescribeWorkflowExecutionRequest,
+	) *AdminService_DescribeWorkflowExecution_Args
+
+	// IsException returns true if the given error can be thrown
+	// by DescribeWorkflowExecution.
+	//
+	// An error can be thrown by DescribeWorkflowExecution only if the
+	// corresponding exception type was mentioned in the 'throws'
+	// section for it in the Thrift file.
+	IsException func(error) bool
+
+	// WrapResponse returns the result struct for DescribeWorkflowExecution
+	// given its return value and error.
+	//
+	// This allows mapping values and errors returned by
+	// DescribeWorkflowExecution into a serializable result struct.
+	// WrapResponse returns a non-nil error if the provided
+	// error cannot be thrown by DescribeWorkflowExecution
+	//
+	//   value, err := DescribeWorkflowExecution(args)
+	//   result, err := AdminService_DescribeWorkflowExecution_Helper.WrapResponse(value, err)
+	//   if err != nil {
+	//     return fmt.Errorf("unexpected error from DescribeWorkflowExecution: %v", err)
+	//   }
+	//   serialize(result)
+	WrapResponse func(*DescribeWorkflowExecutionResponse, error) (*AdminService_DescribeWorkflowExecution_Result, error)
+
+	// UnwrapResponse takes the result struct for DescribeWorkflowExecution
+	// and returns the value or error returned by it.
+	//
+	// The error is non-nil only if DescribeWorkflowExecution threw an
+	// exception.
+	//
+	//   result := deserialize(bytes)
+	//   value, err := AdminService_DescribeWorkflowExecution_Helper.UnwrapResponse(result)
+	UnwrapResponse func(*AdminService_DescribeWorkflowExecution_Result) (*DescribeWorkflowExecutionResponse, error)
+}{}
+
+func init() {
+	AdminService_DescribeWorkflowExecution_Helper.Args = func(
+		request *DescribeWorkflowExecutionRequest,
+	) *AdminService_DescribeWorkflowExecution_Args {
+		return &AdminService_DescribeWorkflowExecution_Args{
+			Request: request,
+		}
+	}
+
+	AdminService_DescribeWorkflowExecution_Helper.IsException = func(err error) bool {
+		switch err.(type) {
+		case *shared.BadRequestError:
+			return true
+		case *shared.InternalServiceError:
+			return true
+		case *shared.EntityNotExistsError:
+			return true
+		case *shared.AccessDeniedError:
+			return true
+		default:
+			return false
+		}
+	}
+
+	AdminService_DescribeWorkflowExecution_Helper.WrapResponse = func(success *DescribeWorkflowExecutionResponse, err error) (*AdminService_DescribeWorkflowExecution_Result, error) {
+		if err == nil {
+			return &AdminService_DescribeWorkflowExecution_Result{Success: success}, nil
+		}
+
+		switch e := err.(type) {
+		case *shared.BadRequestError:
+			if e == nil {
+				return nil, errors.New("WrapResponse received non-nil error type with nil value for AdminService_DescribeWorkflowExecution_Result.BadRequestError")
+			}
+			return &AdminService_DescribeWorkflowExecution_Result{BadRequestError: e}, nil
+		case *shared.InternalServiceError:
+			if e == nil {
+				return nil, errors.New("WrapResponse received non-nil error type with nil value for AdminService_DescribeWorkflowExecution_Result.InternalServiceError")
+			}
+			return &AdminService_DescribeWorkflowExecution_Result{InternalServiceError: e}, nil
+		case *shared.EntityNotExistsError:
+			if e == nil {
+				return nil, errors.New("WrapResponse received non-nil error type with nil value for AdminService_DescribeWorkflowExecution_Result.EntityNotExistError")
+			}
+			return &AdminService_DescribeWorkflowExecution_Result{EntityNotExistError: e}, nil
+		case *shared.AccessDeniedError:
+			if e == nil {
+				return nil, errors.New("WrapResponse received non-nil error type with nil value for AdminService_DescribeWorkflowExecution_Result.AccessDeniedError")
+			}
+			return &AdminService_DescribeWorkflowExecution_Result{AccessDeniedError: e}, nil
+		}
+
+		return nil, err
+	}
+	AdminService_DescribeWorkflowExecution_Helper.UnwrapResponse = func(result *AdminService_DescribeWorkflowExecution_Result) (success *DescribeWorkflowExecutionResponse, err error) {
+		if result.BadRequestError != nil {
+			err = result.BadRequestError
+			return
+		}
+		if result.InternalServiceError != nil {
+			err = result.InternalServiceError
+			return
+		}
+		if result.EntityNotExistError != nil {
+			err = result.EntityNotExistError
+			return
+		}
+		if result.AccessDeniedError != nil {
+			err = result.AccessDeniedError
+			return
+		}
+
+		if result.Success != nil {
+			success = result.Success
+			return
+		}
+
+		err = errors.New("expected a non-void result")
+		return
+	}
+
+}
+
+// AdminService_DescribeWorkflowExecution_Result represents the result of a AdminService.DescribeWorkflowExecution function call.
+//
+// The result of a DescribeWorkflowExecution execution is sent and received over the wire as this struct.
+//
+// Success is set only if the function did not throw an exception.
+type AdminService_DescribeWorkflowExecution_Result struct {
+	// Value returned by DescribeWorkflowExecution after a successful execution.
+	Success              *DescribeWorkflowExecutionResponse `json:"success,omitempty"`
+	BadRequestError      *shared.BadRequestError            `json:"badRequestError,omitempty"`
+	InternalServiceError *shared.InternalServiceError       `json:"internalServiceError,omitempty"`
+	EntityNotExistError  *shared.EntityNotExistsError       `json:"entityNotExistError,omitempty"`
+	AccessDeniedError    *shared.AccessDeniedError          `json:"accessDeniedError,omitempty"`
+}
+
+// ToWire translates a AdminService_DescribeWorkflowExecution_Result struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *AdminService_DescribeWorkflowExecution_Result) ToWire() (wire.Value, error) {
+	var (
+		fields [5]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.Success != nil {
+		w, err = v.Success.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 0, Value: w}
+		i++
+	}
+	if v.BadRequestError != nil {
+		w, err = v.BadRequestError.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 1, Value: w}
+		i++
+	}
+	if v.InternalServiceError != nil {
+		w, err = v.InternalServiceError.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 2, Value: w}
+		i++
+	}
+	if v.EntityNotExistError != nil {
+		w, err = v.EntityNotExistError.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 3, Value: w}
+		i++
+	}
+	if v.AccessDeniedError != nil {
+		w, err = v.AccessDeniedError.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 4, Value: w}
+		i++
+	}
+
+	if i != 1 {
+		return wire.Value{}, fmt.Errorf("AdminService_DescribeWorkflowExecution_Result should have exactly one field: got %v fields", i)
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+func _DescribeWorkflowExecutionResponse_Read(w wire.Value) (*DescribeWorkflowExecutionResponse, error) {
+	var v DescribeWorkflowExecutionResponse
+	err := v.FromWire(w)
+	return &v, err
+}
+
+func _EntityNotExistsError_Read(w wire.Value) (*shared.EntityNotExistsError, error) {
+	var v shared.EntityNotExistsError
+	err := v.FromWire(w)
+	return &v, err
+}
+
+// FromWire deserializes a AdminService_DescribeWorkflowExecution_Result struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a AdminService_DescribeWorkflowExecution_Result struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v AdminService_DescribeWorkflowExecution_Result
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *AdminService_DescribeWorkflowExecution_Result) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 0:
+			if field.Value.Type() == wire.TStruct {
+				v.Success, err = _DescribeWorkflowExecutionResponse_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 1:
+			if field.Value.Type() == wire.TStruct {
+				v.BadRequestError, err = _BadRequestError_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 2:
+			if field.Value.Type() == wire.TStruct {
+				v.InternalServiceError, err = _InternalServiceError_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 3:
+			if field.Value.Type() == wire.TStruct {
+				v.EntityNotExistError, err = _EntityNotExistsError_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 4:
+			if field.Value.Type() == wire.TStruct {
+				v.AccessDeniedError, err = _AccessDeniedError_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+
+	count := 0
+	if v.Success != nil {
+		count++
+	}
+	if v.BadRequestError != nil {
+		count++
+	}
+	if v.InternalServiceError != nil {
+		count++
+	}
+	if v.EntityNotExistError != nil {
+		count++
+	}
+	if v.AccessDeniedError != nil {
+		count++
+	}
+	if count != 1 {
+		return fmt.Errorf("AdminService_DescribeWorkflowExecution_Result should have exactly one field: got %v fields", count)
+	}
+
+	return nil
+}
+
+// String returns a readable string representation of a AdminService_DescribeWorkflowExecution_Result
+// struct.
+func (v *AdminService_DescribeWorkflowExecution_Result) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [5]string
+	i := 0
+	if v.Success != nil {
+		fields[i] = fmt.Sprintf("Success: %v", v.Success)
+		i++
+	}
+	if v.BadRequestError != nil {
+		fields[i] = fmt.Sprintf("BadRequestError: %v", v.BadRequestError)
+		i++
+	}
+	if v.InternalServiceError != nil {
+		fields[i] = fmt.Sprintf("InternalServiceError: %v", v.InternalServiceError)
+		i++
+	}
+	if v.EntityNotExistError != nil {
+		fields[i] = fmt.Sprintf("EntityNotExistError: %v", v.EntityNotExistError)
+		i++
+	}
+	if v.AccessDeniedError != nil {
+		fields[i] = fmt.Sprintf("AccessDeniedError: %v", v.AccessDeniedError)
+		i++
+	}
+
+	return fmt.Sprintf("AdminService_DescribeWorkflowExecution_Result{%v}", strings.Join(fields[:i], ", "))
+}
+
+// Equals returns true if all the fields of this AdminService_DescribeWorkflowExecution_Result match the
+// provided AdminService_DescribeWorkflowExecution_Result.
+//
+// This function performs a deep comparison.
+func (v *AdminService_DescribeWorkflowExecution_Result) Equals(rhs *AdminService_DescribeWorkflowExecution_Result) bool {
+	if v == nil {
+		return rhs == nil
+	} else if rhs == nil {
+		return false
+	}
+	if !((v.Success == nil && rhs.Success == nil) || (v.Success != nil && rhs.Success != nil && v.Success.Equals(rhs.Success))) {
+		return false
+	}
+	if !((v.BadRequestError == nil && rhs.BadRequestError == nil) || (v.BadRequestError != nil && rhs.BadRequestError != nil && v.BadRequestError.Equals(rhs.BadRequestError))) {
+		return false
+	}
+	if !((v.InternalServiceError == nil && rhs.InternalServiceError == nil) || (v.InternalServiceError != nil && rhs.InternalServiceError != nil && v.InternalServiceError.Equals(rhs.InternalServiceError))) {
+		return false
+	}
+	if !((v.EntityNotExistError == nil && rhs.EntityNotExistError == nil) || (v.EntityNotExistError != nil && rhs.EntityNotExistError != nil && v.EntityNotExistError.Equals(rhs.EntityNotExistError))) {
+		return false
+	}
+	if !((v.AccessDeniedError == nil && rhs.AccessDeniedError == nil) || (v.AccessDeniedError != nil && rhs.AccessDeniedError != nil && v.AccessDeniedError.Equals(rhs.AccessDeniedError))) {
+		return false
+	}
+
+	return true
+}
+
+// MarshalLogObject implements zapcore.ObjectMarshaler, enabling
+// fast logging of AdminService_DescribeWorkflowExecution_Result.
+func (v *AdminService_DescribeWorkflowExecution_Result) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+	if v == nil {
+		return nil
+	}
+	if v.Success != nil {
+		err = multierr.Append(err, enc.AddObject("success", v.Success))
+	}
+	if v.BadRequestError != nil {
+		err = multierr.Append(err, enc.AddObject("badRequestError", v.BadRequestError))
+	}
+	if v.InternalServiceError != nil {
+		err = multierr.Append(err, enc.AddObject("internalServiceError", v.InternalServiceError))
+	}
+	if v.EntityNotExistError != nil {
+		err = multierr.Append(err, enc.AddObject("entityNotExistError", v.EntityNotExistError))
+	}
+	if v.AccessDeniedError != nil {
+		err = multierr.Append(err, enc.AddObject("accessDeniedError", v.AccessDeniedError))
+	}
+	return err
+}
+
+// GetSuccess returns the value of Success if it is set or its
+// zero value if it is unset.
+func (v *AdminService_DescribeWorkflowExecution_Result) GetSuccess() (o *DescribeWorkflowExecutionResponse) {
+	if v != nil && v.Success != nil {
+		return v.Success
+	}
+
+	return
+}
+
+// IsSetSuccess returns true if Success is not nil.
+func (v *AdminService_DescribeWorkflowExecution_Result) IsSetSuccess() bool {
+	return v != nil && v.Success != nil
+}
+
+// GetBadRequestError returns the value of BadRequestError if it is set or its
+// zero value if it is unset.
+func (v *AdminService_DescribeWorkflowExecution_Result) GetBadRequestError() (o *shared.BadRequestError) {
+	if v != nil && v.BadRequestError != nil {
+		return v.BadRequestError
+	}
+
+	return
+}
+
+// IsSetBadRequestError returns true if BadRequestError is not nil.
+func (v *AdminService_DescribeWorkflowExecution_Result) IsSetBadRequestError() bool {
+	return v != nil && v.BadRequestError != nil
+}
+
+// GetInternalServiceError returns the value of InternalServiceError if it is set or its
+// zero value if it is unset.
+func (v *AdminService_DescribeWorkflowExecution_Result) GetInternalServiceError() (o *shared.InternalServiceError) {
+	if v != nil && v.InternalServiceError != nil {
+		return v.InternalServiceError
+	}
+
+	return
+}
+
+// IsSetInternalServiceError returns true if InternalServiceError is not nil.
+func (v *AdminService_DescribeWorkflowExecution_Result) IsSetInternalServiceError() bool {
+	return v != nil && v.InternalServiceError != nil
+}
+
+// GetEntityNotExistError returns the value of EntityNotExistError if it is set or its
+// zero value if it is unset.
+func (v *AdminService_DescribeWorkflowExecution_Result) GetEntityNotExistError() (o *shared.EntityNotExistsError) {
+	if v != nil && v.EntityNotExistError != nil {
+		return v.EntityNotExistError
+	}
+
+	return
+}
+
+// IsSetEntityNotExistError returns true if EntityNotExistError is not nil.
+func (v *AdminService_DescribeWorkflowExecution_Result) IsSetEntityNotExistError() bool {
+	return v != nil && v.EntityNotExistError != nil
+}
+
+// GetAccessDeniedError returns the value of AccessDeniedError if it is set or its
+// zero value if it is unset.
+func (v *AdminService_DescribeWorkflowExecution_Result) GetAccessDeniedError() (o *shared.AccessDeniedError) {
+	if v != nil && v.AccessDeniedError != nil {
+		return v.AccessDeniedError
+	}
+
+	return
+}
+
+// IsSetAccessDeniedError returns true if AccessDeniedError is not nil.
+func (v *AdminService_DescribeWorkflowExecution_Result) IsSetAccessDeniedError() bool {
+	return v != nil && v.AccessDeniedError != nil
+}
+
+// MethodName returns the name of the Thrift function as specified in
+// the IDL, for which this struct represent the result.
+//
+// This will always be "DescribeWorkflowExecution" for this struct.
+func (v *AdminService_DescribeWorkflowExecution_Result) MethodName() string {
+	return "DescribeWorkflowExecution"
+}
+
+// EnvelopeType returns the kind of value inside this struct.
+//
+// This will always be Reply for this struct.
+func (v *AdminService_DescribeWorkflowExecution_Result) EnvelopeType() wire.EnvelopeType {
+	return wire.Reply
+}
+
+// AdminService_DescribeWorkflowShard_Args represents the arguments for the AdminService.DescribeWorkflowShard function.
+//
+// The arguments for DescribeWorkflowShard are sent and received over the wire as this struct.
+type AdminService_DescribeWorkflowShard_Args struct {
+	Request *DescribeWorkflowShardRequest `json:"request,omitempty"`
+}
+
+// ToWire translates a AdminService_DescribeWorkflowShard_Args struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *AdminService_DescribeWorkflowShard_Args) ToWire() (wire.Value, error) {
+	var (
+		fields [1]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.Request != nil {
+		w, err = v.Request.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 1, Value: w}
+		i++
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+func _DescribeWorkflowShardRequest_Read(w wire.Value) (*DescribeWorkflowShardRequest, error) {
+	var v DescribeWorkflowShardRequest
+	err := v.FromWire(w)
+	return &v, err
+}
+
+// FromWire deserializes a AdminService_DescribeWorkflowShard_Args struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a AdminService_DescribeWorkflowShard_Args struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v AdminService_DescribeWorkflowShard_Args
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *AdminService_DescribeWorkflowShard_Args) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 1:
+			if field.Value.Type() == wire.TStruct {
+				v.Request, err = _DescribeWorkflowShardRequest_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+
+	return nil
+}
+
+// String returns a readable string representation of a AdminService_DescribeWorkflowShard_Args
+// struct.
+func (v *AdminService_DescribeWorkflowShard_Args) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [1]string
+	i := 0
+	if v.Request != nil {
+		fields[i] = fmt.Sprintf("Request: %v", v.Request)
+		i++
+	}
+
+	return fmt.Sprintf("AdminService_DescribeWorkflowShard_Args{%v}", strings.Join(fields[:i], ", "))
+}
+
+// Equals returns true if all the fields of this AdminService_DescribeWorkflowShard_Args match the
+// provided AdminService_DescribeWorkflowShard_Args.
+//
+// This function performs a deep comparison.
+func (v *AdminService_DescribeWorkflowShard_Args) Equals(rhs *AdminService_DescribeWorkflowShard_Args) bool {
+	if v == nil {
+		return rhs == nil
+	} else if rhs == nil {
+		return false
+	}
+	if !((v.Request == nil && rhs.Request == nil) || (v.Request != nil && rhs.Request != nil && v.Request.Equals(rhs.Request))) {
+		return false
+	}
+
+	return true
+}
+
+// MarshalLogObject implements zapcore.ObjectMarshaler, enabling
+// fast logging of AdminService_DescribeWorkflowShard_Args.
+func (v *AdminService_DescribeWorkflowShard_Args) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+	if v == nil {
+		return nil
+	}
+	if v.Request != nil {
+		err = multierr.Append(err, enc.AddObject("request", v.Request))
+	}
+	return err
+}
+
+// GetRequest returns the value of Request if it is set or its
+// zero value if it is unset.
+func (v *AdminService_DescribeWorkflowShard_Args) GetRequest() (o *DescribeWorkflowShardRequest) {
+	if v != nil && v.Request != nil {
+		return v.Request
+	}
+
+	return
+}
+
+// IsSetRequest returns true if Request is not nil.
+func (v *AdminService_DescribeWorkflowShard_Args) IsSetRequest() bool {
+	return v != nil && v.Request != nil
+}
+
+// MethodName returns the name of the Thrift function as specified in
+// the IDL, for which this struct represent the arguments.
+//
+// This will always be "DescribeWorkflowShard" for this struct.
+func (v *AdminService_DescribeWorkflowShard_Args) MethodName() string {
+	return "DescribeWorkflowShard"
+}
+
+// EnvelopeType returns the kind of value inside this struct.
+//
+// This will always be Call for this struct.
+func (v *AdminService_DescribeWorkflowShard_Args) EnvelopeType() wire.EnvelopeType {
+	return wire.Call
+}
+
+// AdminService_DescribeWorkflowShard_Helper provides functions that aid in handling the
+// parameters and return values of the AdminService.DescribeWorkflowShard
+// function.
+var AdminService_DescribeWorkflowShard_Helper = struct {
+	// Args accepts the parameters of DescribeWorkflowShard in-order and returns
+	// the arguments struct for the function.
+	Args func(
+		request *DescribeWorkflowShardRequest,
+	) *AdminService_DescribeWorkflowShard_Args
+
+	// IsException returns true if the given error can be thrown
+	// by DescribeWorkflowShard.
+	//
+	// An error can be thrown by DescribeWorkflowShard only if the
+	// corresponding exception type was mentioned in the 'throws'
+	// section for it in the Thrift file.
+	IsException func(error) bool
+
+	// WrapResponse returns the result struct for DescribeWorkflowShard
+	// given its return value and error.
+	//
+	// This allows mapping values and errors returned by
+	// DescribeWorkflowShard into a serializable result struct.
+	// WrapResponse returns a non-nil error if the provided
+	// error cannot be thrown by DescribeWorkflowShard
+	//
+	//   value, err := DescribeWorkflowShard(args)
+	//   result, err := AdminService_DescribeWorkflowShard_Helper.WrapResponse(value, err)
+	//   if err != nil {
+	//     return fmt.Errorf("unexpected error from DescribeWorkflowShard: %v", err)
+	//   }
+	//   serialize(result)
+	WrapResponse func(*DescribeWorkflowShardResponse, error) (*AdminService_DescribeWorkflowShard_Result, error)
+
+	// UnwrapResponse takes the result struct for DescribeWorkflowShard
+	// and returns the value or error returned by it.
+	//
+	// The error is non-nil only if DescribeWorkflowShard threw an
+	// exception.
+	//
+	//   result := deserialize(bytes)
+	//   value, err := AdminService_DescribeWorkflowShard_Helper.UnwrapResponse(result)
+	UnwrapResponse func(*AdminService_DescribeWorkflowShard_Result) (*DescribeWorkflowShardResponse, error)
+}{}
+
+func init() {
+	AdminService_DescribeWorkflowShard_Helper.Args = func(
+		request *DescribeWorkflowShardRequest,
+	) *AdminService_DescribeWorkflowShard_Args {
+		return &AdminService_DescribeWorkflowShard_Args{
+			Request: request,
+		}
+	}
+
+	AdminService_DescribeWorkflowShard_Helper.IsException = func(err error) bool {
+		switch err.(type) {
+		case *shared.BadRequestError:
+			return true
+		case *shared.InternalServiceError:
+			return true
+		case *shared.ServiceBusyError:
+			return true
+		case *shared.AccessDeniedError:
+			return true
+		default:
+			return false
+		}
+	}
+
+	AdminService_DescribeWorkflowShard_Helper.WrapResponse = func(success *DescribeWorkflowShardResponse, err error) (*AdminService_DescribeWorkflowShard_Result, error) {
+		if err == nil {
+			return &AdminService_DescribeWorkflowShard_Result{Success: success}, nil
+		}
+
+		switch e := err.(type) {
+		case *shared.BadRequestError:
+			if e == nil {
+				return nil, errors.New("WrapResponse received non-nil error type with nil value for AdminService_DescribeWorkflowShard_Result.BadRequestError")
+			}
+			return &AdminService_DescribeWorkflowShard_Result{BadRequestError: e}, nil
+		case *shared.InternalServiceError:
+			if e == nil {
+				return nil, errors.New("WrapResponse received non-nil error type with nil value for AdminService_DescribeWorkflowShard_Result.InternalServiceError")
+			}
+			return &AdminService_DescribeWorkflowShard_Result{InternalServiceError: e}, nil
+		case *shared.ServiceBusyError:
+			if e == nil {
+				return nil, errors.New("WrapResponse received non-nil error type with nil value for AdminService_DescribeWorkflowShard_Result.ServiceBusyError")
+			}
+			return &AdminService_DescribeWorkflowShard_Result{ServiceBusyError: e}, nil
+		case *shared.AccessDeniedError:
+			if e == nil {
+				return nil, errors.New("WrapResponse received non-nil error type with nil value for AdminService_DescribeWorkflowShard_Result.AccessDeniedError")
+			}
+			return &AdminService_DescribeWorkflowShard_Result{AccessDeniedError: e}, nil
+		}
+
+		return nil, err
+	}
+	AdminService_DescribeWorkflowShard_Helper.UnwrapResponse = func(result *AdminService_DescribeWorkflowShard_Result) (success *DescribeWorkflowShardResponse, err error) {
+		if result.BadRequestError != nil {
+			err = result.BadRequestError
+			return
+		}
+		if result.InternalServiceError != nil {
+			err = result.InternalServiceError
+			return
+		}
+		if result.ServiceBusyError != nil {
+			err = result.ServiceBusyError
+			return
+		}
+		if result.AccessDeniedError != nil {
+			err = result.AccessDeniedError
+			return
+		}
+
+		if result.Success != nil {
+			success = result.Success
+			return
+		}
+
+		err = errors.New("expected a non-void result")
+		return
+	}
+
+}
+
+// AdminService_DescribeWorkflowShard_Result represents the result of a AdminService.DescribeWorkflowShard function call.
+//
+// The result of a DescribeWorkflowShard execution is sent and received over the wire as this struct.
+//
+// Success is set only if the function did not throw an exception.
+type AdminService_DescribeWorkflowShard_Result struct {
+	// Value returned by DescribeWorkflowShard after a successful execution.
+	Success              *DescribeWorkflowShardResponse `json:"success,omitempty"`
+	BadRequestError      *shared.BadRequestError        `json:"badRequestError,omitempty"`
+	InternalServiceError *shared.InternalServiceError   `json:"internalServiceError,omitempty"`
+	ServiceBusyError     *shared.ServiceBusyError       `json:"serviceBusyError,omitempty"`
+	AccessDeniedError    *shared.AccessDeniedError      `json:"accessDeniedError,omitempty"`
+}
+
+// ToWire translates a AdminService_DescribeWorkflowShard_Result struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *AdminService_DescribeWorkflowShard_Result) ToWire() (wire.Value, error) {
+	var (
+		fields [5]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.Success != nil {
+		w, err = v.Success.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 0, Value: w}
+		i++
+	}
+	if v.BadRequestError != nil {
+		w, err = v.BadRequestError.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 1, Value: w}
+		i++
+	}
+	if v.InternalServiceError != nil {
+		w, err = v.InternalServiceError.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 2, Value: w}
+		i++
+	}
+	if v.ServiceBusyError != nil {
+		w, err = v.ServiceBusyError.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 3, Value: w}
+		i++
+	}
+	if v.AccessDeniedError != nil {
+		w, err = v.AccessDeniedError.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 4, Value: w}
+		i++
+	}
+
+	if i != 1 {
+		return wire.Value{}, fmt.Errorf("AdminService_DescribeWorkflowShard_Result should have exactly one field: got %v fields", i)
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+func _DescribeWorkflowShardResponse_Read(w wire.Value) (*DescribeWorkflowShardResponse, error) {
+	var v DescribeWorkflowShardResponse
+	err := v.FromWire(w)
+	return &v, err
+}
+
+func _ServiceBusyError_Read(w wire.Value) (*shared.ServiceBusyError, error) {
+	var v shared.ServiceBusyError
+	err := v.FromWire(w)
+	return &v, err
+}
+
+// FromWire deserializes a AdminService_DescribeWorkflowShard_Result struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a AdminService_DescribeWorkflowShard_Result struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v AdminService_DescribeWorkflowShard_Result
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *AdminService_DescribeWorkflowShard_Result) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 0:
+			if field.Value.Type() == wire.TStruct {
+				v.Success, err = _DescribeWorkflowShardResponse_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 1:
+			if field.Value.Type() == wire.TStruct {
+				v.BadRequestError, err = _BadRequestError_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 2:
+			if field.Value.Type() == wire.TStruct {
+				v.InternalServiceError, err = _InternalServiceError_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 3:
+			if field.Value.Type() == wire.TStruct {
+				v.ServiceBusyError, err = _ServiceBusyError_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 4:
+			if field.Value.Type() == wire.TStruct {
+				v.AccessDeniedError, err = _AccessDeniedError_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+
+	count := 0
+	if v.Success != nil {
+		count++
+	}
+	if v.BadRequestError != nil {
+		count++
+	}
+	if v.InternalServiceError != nil {
+		count++
+	}
+	if v.ServiceBusyError != nil {
+		count++
+	}
+	if v.AccessDeniedError != nil {
+		count++
+	}
+	if count != 1 {
+		return fmt.Errorf("AdminService_DescribeWorkflowShard_Result should have exactly one field: got %v fields", count)
+	}
+
+	return nil
+}
+
+// String returns a readable string representation of a AdminService_DescribeWorkflowShard_Result
+// struct.
+func (v *AdminService_DescribeWorkflowShard_Result) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [5]string
+	i := 0
+	if v.Success != nil {
+		fields[i] = fmt.Sprintf("Success: %v", v.Success)
+		i++
+	}
+	if v.BadRequestError != nil {
+		fields[i] = fmt.Sprintf("BadRequestError: %v", v.BadRequestError)
+		i++
+	}
+	if v.InternalServiceError != nil {
+		fields[i] = fmt.Sprintf("InternalServiceError: %v", v.InternalServiceError)
+		i++
+	}
+	if v.ServiceBusyError != nil {
+		fields[i] = fmt.Sprintf("ServiceBusyError: %v", v.ServiceBusyError)
+		i++
+	}
+	if v.AccessDeniedError != nil {
+		fields[i] = fmt.Sprintf("AccessDeniedError: %v", v.AccessDeniedError)
+		i++
+	}
+
+	return fmt.Sprintf("AdminService_DescribeWorkflowShard_Result{%v}", strings.Join(fields[:i], ", "))
+}
+
+// Equals returns true if all the fields of this AdminService_DescribeWorkflowShard_Result match the
+// provided AdminService_DescribeWorkflowShard_Result.
+//
+// This function performs a deep comparison.
+func (v *AdminService_DescribeWorkflowShard_Result) Equals(rhs *AdminService_DescribeWorkflowShard_Result) bool {
+	if v == nil {
+		return rhs == nil
+	} else if rhs == nil {
+		return false
+	}
+	if !((v.Success == nil && rhs.Success == nil) || (v.Success != nil && rhs.Success != nil && v.Success.Equals(rhs.Success))) {
+		return false
+	}
+	if !((v.BadRequestError == nil && rhs.BadRequestError == nil) || (v.BadRequestError != nil && rhs.BadRequestError != nil && v.BadRequestError.Equals(rhs.BadRequestError))) {
+		return false
+	}
+	if !((v.InternalServiceError == nil && rhs.InternalServiceError == nil) || (v.InternalServiceError != nil && rhs.InternalServiceError != nil && v.InternalServiceError.Equals(rhs.InternalServiceError))) {
+		return false
+	}
+	if !((v.ServiceBusyError == nil && rhs.ServiceBusyError == nil) || (v.ServiceBusyError != nil && rhs.ServiceBusyError != nil && v.ServiceBusyError.Equals(rhs.ServiceBusyError))) {
+		return false
+	}
+	if !((v.AccessDeniedError == nil && rhs.AccessDeniedError == nil) || (v.AccessDeniedError != nil && rhs.AccessDeniedError != nil && v.AccessDeniedError.Equals(rhs.AccessDeniedError))) {
+		return false
+	}
+
+	return true
+}
+
+// MarshalLogObject implements zapcore.ObjectMarshaler, enabling
+// fast logging of AdminService_DescribeWorkflowShard_Result.
+func (v *AdminService_DescribeWorkflowShard_Result) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+	if v == nil {
+		return nil
+	}
+	if v.Success != nil {
+		err = multierr.Append(err, enc.AddObject("success", v.Success))
+	}
+	if v.BadRequestError != nil {
+		err = multierr.Append(err, enc.AddObject("badRequestError", v.BadRequestError))
+	}
+	if v.InternalServiceError != nil {
+		err = multierr.Append(err, enc.AddObject("internalServiceError", v.InternalServiceError))
+	}
+	if v.ServiceBusyError != nil {
+		err = multierr.Append(err, enc.AddObject("serviceBusyError", v.ServiceBusyError))
+	}
+	if v.AccessDeniedError != nil {
+		err = multierr.Append(err, enc.AddObject("accessDeniedError", v.AccessDeniedError))
+	}
+	return err
+}
+
+// GetSuccess returns the value of Success if it is set or its
+// zero value if it is unset.
+func (v *AdminService_DescribeWorkflowShard_Result) GetSuccess() (o *DescribeWorkflowShardResponse) {
+	if v != nil && v.Success != nil {
+		return v.Success
+	}
+
+	return
+}
+
+// IsSetSuccess returns true if Success is not nil.
+func (v *AdminService_DescribeWorkflowShard_Result) IsSetSuccess() bool {
+	return v != nil && v.Success != nil
+}
+
+// GetBadRequestError returns the value of BadRequestError if it is set or its
+// zero value if it is unset.
+func (v *AdminService_DescribeWorkflowShard_Result) GetBadRequestError() (o *shared.BadRequestError) {
+	if v != nil && v.BadRequestError != nil {
+		return v.BadRequestError
+	}
+
+	return
+}
+
+// IsSetBadRequestError returns true if BadRequestError is not nil.
+func (v *AdminService_DescribeWorkflowShard_Result) IsSetBadRequestError() bool {
+	return v != nil && v.BadRequestError != nil
+}
+
+// GetInternalServiceError returns the value of InternalServiceError if it is set or its
+// zero value if it is unset.
+func (v *AdminService_DescribeWorkflowShard_Result) GetInternalServiceError() (o *shared.InternalServiceError) {
+	if v != nil && v.InternalServiceError != nil {
+		return v.InternalServiceError
+	}
+
+	return
+}
+
+// IsSetInternalServiceError returns true if InternalServiceError is not nil.
+func (v *AdminService_DescribeWorkflowShard_Result) IsSetInternalServiceError() bool {
+	return v != nil && v.InternalServiceError != nil
+}
+
+// GetServiceBusyError returns the value of ServiceBusyError if it is set or its
+// zero value if it is unset.
+func (v *AdminService_DescribeWorkflowShard_Result) GetServiceBusyError() (o *shared.ServiceBusyError) {
+	if v != nil && v.ServiceBusyError != nil {
+		return v.ServiceBusyError
+	}
+
+	return
+}
+
+// IsSetServiceBusyError returns true if ServiceBusyError is not nil.
+func (v *AdminService_DescribeWorkflowShard_Result) IsSetServiceBusyError() bool {
+	return v != nil && v.ServiceBusyError != nil
+}
+
+// GetAccessDeniedError returns the value of AccessDeniedError if it is set or its
+// zero value if it is unset.
+func (v *AdminService_DescribeWorkflowShard_Result) GetAccessDeniedError() (o *shared.AccessDeniedError) {
+	if v != nil && v.AccessDeniedError != nil {
+		return v.AccessDeniedError
+	}
+
+	return
+}
+
+// IsSetAccessDeniedError returns true if AccessDeniedError is not nil.
+func (v *AdminService_DescribeWorkflowShard_Result) IsSetAccessDeniedError() bool {
+	return v != nil && v.AccessDeniedError != nil
+}
+
+// MethodName returns the name of the Thrift function as specified in
+// the IDL, for which this struct represent the result.
+//
+// This will always be "DescribeWorkflowShard" for this struct.
+func (v *AdminService_DescribeWorkflowShard_Result) MethodName() string {
+	return "DescribeWorkflowShard"
+}
+
+// EnvelopeType returns the kind of value inside this struct.
+//
+// This will always be Reply for this struct.
+func (v *AdminService_DescribeWorkflowShard_Result) EnvelopeType() wire.EnvelopeType {
+	return wire.Reply
+}
+
+// AdminService_DrainHistoryHost_Args represents the arguments for the AdminService.DrainHistoryHost function.
+//
+// The arguments for DrainHistoryHost are sent and received over the wire as this struct.
+type AdminService_DrainHistoryHost_Args struct {
+	Request *shared.DrainHistoryHostRequest `json:"request,omitempty"`
+}
+
+// ToWire translates a AdminService_DrainHistoryHost_Args struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *AdminService_DrainHistoryHost_Args) ToWire() (wire.Value, error) {
+	var (
+		fields [1]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.Request != nil {
+		w, err = v.Request.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 1, Value: w}
+		i++
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+func _DrainHistoryHostRequest_Read(w wire.Value) (*shared.DrainHistoryHostRequest, error) {
+	var v shared.DrainHistoryHostRequest
+	err := v.FromWire(w)
+	return &v, err
+}
+
+// FromWire deserializes a AdminService_DrainHistoryHost_Args struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a AdminService_DrainHistoryHost_Args struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v AdminService_DrainHistoryHost_Args
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *AdminService_DrainHistoryHost_Args) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 1:
+			if field.Value.Type() == wire.TStruct {
+				v.Request, err = _DrainHistoryHostRequest_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+
+	return nil
+}
+
+// String returns a readable string representation of a AdminService_DrainHistoryHost_Args
+// struct.
+func (v *AdminService_DrainHistoryHost_Args) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [1]string
+	i := 0
+	if v.Request != nil {
+		fields[i] = fmt.Sprintf("Request: %v", v.Request)
+		i++
+	}
+
+	return fmt.Sprintf("AdminService_DrainHistoryHost_Args{%v}", strings.Join(fields[:i], ", "))
+}
+
+// Equals returns true if all the fields of this AdminService_DrainHistoryHost_Args match the
+// provided AdminService_DrainHistoryHost_Args.
+//
+// This function performs a deep comparison.
+func (v *AdminService_DrainHistoryHost_Args) Equals(rhs *AdminService_DrainHistoryHost_Args) bool {
+	if v == nil {
+		return rhs == nil
+	} else if rhs == nil {
+		return false
+	}
+	if !((v.Request == nil && rhs.Request == nil) || (v.Request != nil && rhs.Request != nil && v.Request.Equals(rhs.Request))) {
+		return false
+	}
+
+	return true
+}
+
+// MarshalLogObject implements zapcore.ObjectMarshaler, enabling
+// fast logging of AdminService_DrainHistoryHost_Args.
+func (v *AdminService_DrainHistoryHost_Args) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+	if v == nil {
+		return nil
+	}
+	if v.Request != nil {
+		err = multierr.Append(err, enc.AddObject("request", v.Request))
+	}
+	return err
+}
+
+// GetRequest returns the value of Request if it is set or its
+// zero value if it is unset.
+func (v *AdminService_DrainHistoryHost_Args) GetRequest() (o *shared.DrainHistoryHostRequest) {
+	if v != nil && v.Request != nil {
+		return v.Request
+	}
+
+	return
+}
+
+// IsSetRequest returns true if Request is not nil.
+func (v *AdminService_DrainHistoryHost_Args) IsSetRequest() bool {
+	return v != nil && v.Request != nil
+}
+
+// MethodName returns the name of the Thrift function as specified in
+// the IDL, for which this struct represent the arguments.
+//
+// This will always be "DrainHistoryHost" for this struct.
+func (v *AdminService_DrainHistoryHost_Args) MethodName() string {
+	return "DrainHistoryHost"
+}
+
+// EnvelopeType returns the kind of value inside this struct.
+//
+// This will always be Call for this struct.
+func (v *AdminService_DrainHistoryHost_Args) EnvelopeType() wire.EnvelopeType {
+	return wire.Call
+}
+
+// AdminService_DrainHistoryHost_Helper provides functions that aid in handling the
+// parameters and return values of the AdminService.DrainHistoryHost
+// function.
+var AdminService_DrainHistoryHost_Helper = struct {
+	// Args accepts the parameters of DrainHistoryHost in-order and returns
+	// the arguments struct for the function.
+	Args func(
+		request *shared.DrainHistoryHostRequest,
+	) *AdminService_DrainHistoryHost_Args
+
+	// IsException returns true if the given error can be thrown
+	// by DrainHistoryHost.
+	//
+	// An error can be thrown by DrainHistoryHost only if the
+	// corresponding exception type was mentioned in the 'throws'
+	// section for it in the Thrift file.
+	IsException func(error) bool
+
+	// WrapResponse returns the result struct for DrainHistoryHost
+	// given its return value and error.
+	//
+	// This allows mapping values and errors returned by
+	// DrainHistoryHost into a serializable result struct.
+	// WrapResponse returns a non-nil error if the provided
+	// error cannot be thrown by DrainHistoryHost
+	//
+	//   value, err := DrainHistoryHost(args)
+	//   result, err := AdminService_DrainHistoryHost_Helper.WrapResponse(value, err)
+	//   if err != nil {
+	//     return fmt.Errorf("unexpected error from DrainHistoryHost: %v", err)
+	//   }
+	//   serialize(result)
+	WrapResponse func(*shared.DrainHistoryHostResponse, error) (*AdminService_DrainHistoryHost_Result, error)
+
+	// UnwrapResponse takes the result struct for DrainHistoryHost
+	// and returns the value or error returned by it.
+	//
+	// The error is non-nil only if DrainHistoryHost threw an
+	// exception.
+	//
+	//   result := deserialize(bytes)
+	//   value, err := AdminService_DrainHistoryHost_Helper.UnwrapResponse(result)
+	UnwrapResponse func(*AdminService_DrainHistoryHost_Result) (*shared.DrainHistoryHostResponse, error)
+}{}
+
+func init() {
+	AdminService_DrainHistoryHost_Helper.Args = func(
+		request *shared.DrainHistoryHostRequest,
+	) *AdminService_DrainHistoryHost_Args {
+		return &AdminService_DrainHistoryHost_Args{
+			Request: request,
+		}
+	}
+
+	AdminService_DrainHistoryHost_Helper.IsException = func(err error) bool {
+		switch err.(type) {
+		case *shared.BadRequestError:
+			return true
+		case *shared.InternalServiceError:
+			return true
+		case *shared.AccessDeniedError:
+			return true
+		default:
+			return false
+		}
+	}
+
+	AdminService_DrainHistoryHost_Helper.WrapResponse = func(success *shared.DrainHistoryHostResponse, err error) (*AdminService_DrainHistoryHost_Result, error) {
+		if err == nil {
+			return &AdminService_DrainHistoryHost_Result{Success: success}, nil
+		}
+
+		switch e := err.(type) {
+		case *shared.BadRequestError:
+			if e == nil {
+				return nil, errors.New("WrapResponse received non-nil error type with nil value for AdminService_DrainHistoryHost_Result.BadRequestError")
+			}
+			return &AdminService_DrainHistoryHost_Result{BadRequestError: e}, nil
+		case *shared.InternalServiceError:
+			if e == nil {
+				return nil, errors.New("WrapResponse received non-nil error type with nil value for AdminService_DrainHistoryHost_Result.InternalServiceError")
+			}
+			return &AdminService_DrainHistoryHost_Result{InternalServiceError: e}, nil
+		case *shared.AccessDeniedError:
+			if e == nil {
+				return nil, errors.New("WrapResponse received non-nil error type with nil value for AdminService_DrainHistoryHost_Result.AccessDeniedError")
+			}
+			return &AdminService_DrainHistoryHost_Result{AccessDeniedError: e}, nil
+		}
+
+		return nil, err
+	}
+	AdminService_DrainHistoryHost_Helper.UnwrapResponse = func(result *AdminService_DrainHistoryHost_Result) (success *shared.DrainHistoryHostResponse, err error) {
+		if result.BadRequestError != nil {
+			err = result.BadRequestError
+			return
+		}
+		if result.InternalServiceError != nil {
+			err = result.InternalServiceError
+			return
+		}
+		if result.AccessDeniedError != nil {
+			err = result.AccessDeniedError
+			return
+		}
+
+		if result.Success != nil {
+			success = result.Success
+			return
+		}
+
+		err = errors.New("expected a non-void result")
+		return
+	}
+
+}
+
+// AdminService_DrainHistoryHost_Result represents the result of a AdminService.DrainHistoryHost function call.
+//
+// The result of a DrainHistoryHost execution is sent and received over the wire as this struct.
+//
+// Success is set only if the function did not throw an exception.
+type AdminService_DrainHistoryHost_Result struct {
+	// Value returned by DrainHistoryHost after a successful execution.
+	Success              *shared.DrainHistoryHostResponse `json:"success,omitempty"`
+	BadRequestError      *shared.BadRequestError          `json:"badRequestError,omitempty"`
+	InternalServiceError *shared.InternalServiceError     `json:"internalServiceError,omitempty"`
+	AccessDeniedError    *shared.AccessDeniedError        `json:"accessDeniedError,omitempty"`
+}
+
+// ToWire translates a AdminService_DrainHistoryHost_Result struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *AdminService_DrainHistoryHost_Result) ToWire() (wire.Value, error) {
+	var (
+		fields [4]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.Success != nil {
+		w, err = v.Success.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 0, Value: w}
+		i++
+	}
+	if v.BadRequestError != nil {
+		w, err = v.BadRequestError.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 1, Value: w}
+		i++
+	}
+	if v.InternalServiceError != nil {
+		w, err = v.InternalServiceError.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 2, Value: w}
+		i++
+	}
+	if v.AccessDeniedError != nil {
+		w, err = v.AccessDeniedError.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 3, Value: w}
+		i++
+	}
+
+	if i != 1 {
+		return wire.Value{}, fmt.Errorf("AdminService_DrainHistoryHost_Result should have exactly one field: got %v fields", i)
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+func _DrainHistoryHostResponse_Read(w wire.Value) (*shared.DrainHistoryHostResponse, error) {
+	var v shared.DrainHistoryHostResponse
+	err := v.FromWire(w)
+	return &v, err
+}
+
+// FromWire deserializes a AdminService_DrainHistoryHost_Result struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a AdminService_DrainHistoryHost_Result struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v AdminService_DrainHistoryHost_Result
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *AdminService_DrainHistoryHost_Result) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 0:
+			if field.Value.Type() == wire.TStruct {
+				v.Success, err = _DrainHistoryHostResponse_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 1:
+			if field.Value.Type() == wire.TStruct {
+				v.BadRequestError, err = _BadRequestError_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 2:
+			if field.Value.Type() == wire.TStruct {
+				v.InternalServiceError, err = _InternalServiceError_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 3:
+			if field.Value.Type() == wire.TStruct {
+				v.AccessDeniedError, err = _AccessDeniedError_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+
+	count := 0
+	if v.Success != nil {
+		count++
+	}
+	if v.BadRequestError != nil {
+		count++
+	}
+	if v.InternalServiceError != nil {
+		count++
+	}
+	if v.AccessDeniedError != nil {
+		count++
+	}
+	if count != 1 {
+		return fmt.Errorf("AdminService_DrainHistoryHost_Result should have exactly one field: got %v fields", count)
+	}
+
+	return nil
+}
+
+// String returns a readable string representation of a AdminService_DrainHistoryHost_Result
+// struct.
+func (v *AdminService_DrainHistoryHost_Result) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [4]string
+	i := 0
+	if v.Success != nil {
+		fields[i] = fmt.Sprintf("Success: %v", v.Success)
+		i++
+	}
+	if v.BadRequestError != nil {
+		fields[i] = fmt.Sprintf("BadRequestError: %v", v.BadRequestError)
+		i++
+	}
+	if v.InternalServiceError != nil {
+		fields[i] = fmt.Sprintf("InternalServiceError: %v", v.InternalServiceError)
+		i++
+	}
+	if v.AccessDeniedError != nil {
+		fields[i] = fmt.Sprintf("AccessDeniedError: %v", v.AccessDeniedError)
+		i++
+	}
+
+	return fmt.Sprintf("AdminService_DrainHistoryHost_Result{%v}", strings.Join(fields[:i], ", "))
+}
+
+// Equals returns true if all the fields of this AdminService_DrainHistoryHost_Result match the
+// provided AdminService_DrainHistoryHost_Result.
+//
+// This function performs a deep comparison.
+func (v *AdminService_DrainHistoryHost_Result) Equals(rhs *AdminService_DrainHistoryHost_Result) bool {
+	if v == nil {
+		return rhs == nil
+	} else if rhs == nil {
+		return false
+	}
+	if !((v.Success == nil && rhs.Success == nil) || (v.Success != nil && rhs.Success != nil && v.Success.Equals(rhs.Success))) {
+		return false
+	}
+	if !((v.BadRequestError == nil && rhs.BadRequestError == nil) || (v.BadRequestError != nil && rhs.BadRequestError != nil && v.BadRequestError.Equals(rhs.BadRequestError))) {
+		return false
+	}
+	if !((v.InternalServiceError == nil && rhs.InternalServiceError == nil) || (v.InternalServiceError != nil && rhs.InternalServiceError != nil && v.InternalServiceError.Equals(rhs.InternalServiceError))) {
+		return false
+	}
+	if !((v.AccessDeniedError == nil && rhs.AccessDeniedError == nil) || (v.AccessDeniedError != nil && rhs.AccessDeniedError != nil && v.AccessDeniedError.Equals(rhs.AccessDeniedError))) {
+		return false
+	}
+
+	return true
+}
+
+// MarshalLogObject implements zapcore.ObjectMarshaler, enabling
+// fast logging of AdminService_DrainHistoryHost_Result.
+func (v *AdminService_DrainHistoryHost_Result) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+	if v == nil {
+		return nil
+	}
+	if v.Success != nil {
+		err = multierr.Append(err, enc.AddObject("success", v.Success))
+	}
+	if v.BadRequestError != nil {
+		err = multierr.Append(err, enc.AddObject("badRequestError", v.BadRequestError))
+	}
+	if v.InternalServiceError != nil {
+		err = multierr.Append(err, enc.AddObject("internalServiceError", v.InternalServiceError))
+	}
+	if v.AccessDeniedError != nil {
+		err = multierr.Append(err, enc.AddObject("accessDeniedError", v.AccessDeniedError))
+	}
+	return err
+}
+
+// GetSuccess returns the value of Success if it is set or its
+// zero value if it is unset.
+func (v *AdminService_DrainHistoryHost_Result) GetSuccess() (o *shared.DrainHistoryHostResponse) {
+	if v != nil && v.Success != nil {
+		return v.Success
+	}
+
+	return
+}
+
+// IsSetSuccess returns true if Success is not nil.
+func (v *AdminService_DrainHistoryHost_Result) IsSetSuccess() bool {
+	return v != nil && v.Success != nil
+}
+
+// GetBadRequestError returns the value of BadRequestError if it is set or its
+// zero value if it is unset.
+func (v *AdminService_DrainHistoryHost_Result) GetBadRequestError() (o *shared.BadRequestError) {
+	if v != nil && v.BadRequestError != nil {
+		return v.BadRequestError
+	}
+
+	return
+}
+
+// IsSetBadRequestError returns true if BadRequestError is not nil.
+func (v *AdminService_DrainHistoryHost_Result) IsSetBadRequestError() bool {
+	return v != nil && v.BadRequestError != nil
+}
+
+// GetInternalServiceError returns the value of InternalServiceError if it is set or its
+// zero value if it is unset.
+func (v *AdminService_DrainHistoryHost_Result) GetInternalServiceError() (o *shared.InternalServiceError) {
+	if v != nil && v.InternalServiceError != nil {
+		return v.InternalServiceError
+	}
+
+	return
+}
+
+// IsSetInternalServiceError returns true if InternalServiceError is not nil.
+func (v *AdminService_DrainHistoryHost_Result) IsSetInternalServiceError() bool {
+	return v != nil && v.InternalServiceError != nil
+}
+
+// GetAccessDeniedError returns the value of AccessDeniedError if it is set or its
+// zero value if it is unset.
+func (v *AdminService_DrainHistoryHost_Result) GetAccessDeniedError() (o *shared.AccessDeniedError) {
+	if v != nil && v.AccessDeniedError != nil {
+		return v.AccessDeniedError
+	}
+
+	return
+}
+
+// IsSetAccessDeniedError returns true if AccessDeniedError is not nil.
+func (v *AdminService_DrainHistoryHost_Result) IsSetAccessDeniedError() bool {
+	return v != nil && v.AccessDeniedError != nil
+}
+
+// MethodName returns the name of the Thrift function as specified in
+// the IDL, for which this struct represent the result.
+//
+// This will always be "DrainHistoryHost" for this struct.
+func (v *AdminService_DrainHistoryHost_Result) MethodName() string {
+	return "DrainHistoryHost"
+}
+
+// EnvelopeType returns the kind of value inside this struct.
+//
+// This will always be Reply for this struct.
+func (v *AdminService_DrainHistoryHost_Result) EnvelopeType() wire.EnvelopeType {
+	return wire.Reply
+}
+
+// AdminService_GetDomainOpenExecutionCount_Args represents the arguments for the AdminService.GetDomainOpenExecutionCount function.
+//
+// The arguments for GetDomainOpenExecutionCount are sent and received over the wire as this struct.
+type AdminService_GetDomainOpenExecutionCount_Args struct {
+	Request *shared.GetDomainOpenExecutionCountRequest `json:"request,omitempty"`
+}
+
+// ToWire translates a AdminService_GetDomainOpenExecutionCount_Args struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *AdminService_GetDomainOpenExecutionCount_Args) ToWire() (wire.Value, error) {
+	var (
+		fields [1]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.Request != nil {
+		w, err = v.Request.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 1, Value: w}
+		i++
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+func _GetDomainOpenExecutionCountRequest_Read(w wire.Value) (*shared.GetDomainOpenExecutionCountRequest, error) {
+	var v shared.GetDomainOpenExecutionCountRequest
+	err := v.FromWire(w)
+	return &v, err
+}
+
+// FromWire deserializes a AdminService_GetDomainOpenExecutionCount_Args struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a AdminService_GetDomainOpenExecutionCount_Args struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v AdminService_GetDomainOpenExecutionCount_Args
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *AdminService_GetDomainOpenExecutionCount_Args) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 1:
+			if field.Value.Type() == wire.TStruct {
+				v.Request, err = _GetDomainOpenExecutionCountRequest_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+
+	return nil
+}
+
+// String returns a readable string representation of a AdminService_GetDomainOpenExecutionCount_Args
+// struct.
+func (v *AdminService_GetDomainOpenExecutionCount_Args) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [1]string
+	i := 0
+	if v.Request != nil {
+		fields[i] = fmt.Sprintf("Request: %v", v.Request)
+		i++
+	}
+
+	return fmt.Sprintf("AdminService_GetDomainOpenExecutionCount_Args{%v}", strings.Join(fields[:i], ", "))
+}
+
+// Equals returns true if all the fields of this AdminService_GetDomainOpenExecutionCount_Args match the
+// provided AdminService_GetDomainOpenExecutionCount_Args.
+//
+// This function performs a deep comparison.
+func (v *AdminService_GetDomainOpenExecutionCount_Args) Equals(rhs *AdminService_GetDomainOpenExecutionCount_Args) bool {
+	if v == nil {
+		return rhs == nil
+	} else if rhs == nil {
+		return false
+	}
+	if !((v.Request == nil && rhs.Request == nil) || (v.Request != nil && rhs.Request != nil && v.Request.Equals(rhs.Request))) {
+		return false
+	}
+
+	return true
+}
+
+// MarshalLogObject implements zapcore.ObjectMarshaler, enabling
+// fast logging of AdminService_GetDomainOpenExecutionCount_Args.
+func (v *AdminService_GetDomainOpenExecutionCount_Args) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+	if v == nil {
+		return nil
+	}
+	if v.Request != nil {
+		err = multierr.Append(err, enc.AddObject("request", v.Request))
+	}
+	return err
+}
+
+// GetRequest returns the value of Request if it is set or its
+// zero value if it is unset.
+func (v *AdminService_GetDomainOpenExecutionCount_Args) GetRequest() (o *shared.GetDomainOpenExecutionCountRequest) {
+	if v != nil && v.Request != nil {
+		return v.Request
+	}
+
+	return
+}
+
+// IsSetRequest returns true if Request is not nil.
+func (v *AdminService_GetDomainOpenExecutionCount_Args) IsSetRequest() bool {
+	return v != nil && v.Request != nil
+}
+
+// MethodName returns the name of the Thrift function as specified in
+// the IDL, for which this struct represent the arguments.
+//
+// This will always be "GetDomainOpenExecutionCount" for this struct.
+func (v *AdminService_GetDomainOpenExecutionCount_Args) MethodName() string {
+	return "GetDomainOpenExecutionCount"
+}
+
+// EnvelopeType returns the kind of value inside this struct.
+//
+// This will always be Call for this struct.
+func (v *AdminService_GetDomainOpenExecutionCount_Args) EnvelopeType() wire.EnvelopeType {
+	return wire.Call
+}
+
+// AdminService_GetDomainOpenExecutionCount_Helper provides functions that aid in handling the
+// parameters and return values of the AdminService.GetDomainOpenExecutionCount
+// function.
+var AdminService_GetDomainOpenExecutionCount_Helper = struct {
+	// Args accepts the parameters of GetDomainOpenExecutionCount in-order and returns
+	// the arguments struct for the function.
+	Args func(
+		request *shared.GetDomainOpenExecutionCountRequest,
+	) *AdminService_GetDomainOpenExecutionCount_Args
+
+	// IsException returns true if the given error can be thrown
+	// by GetDomainOpenExecutionCount.
+	//
+	// An error can be thrown by GetDomainOpenExecutionCount only if the
+	// corresponding exception type was mentioned in the 'throws'
+	// section for it in the Thrift file.
+	IsException func(error) bool
+
+	// WrapResponse returns the result struct for GetDomainOpenExecutionCount
+	// given its return value and error.
+	//
+	// This allows mapping values and errors returned by
+	// GetDomainOpenExecutionCount into a serializable result struct.
+	// WrapResponse returns a non-nil error if the provided
+	// error cannot be thrown by GetDomainOpenExecutionCount
+	//
+	//   value, err := GetDomainOpenExecutionCount(args)
+	//   result, err := AdminService_GetDomainOpenExecutionCount_Helper.WrapResponse(value, err)
+	//   if err != nil {
+	//     return fmt.Errorf("unexpected error from GetDomainOpenExecutionCount: %v", err)
+	//   }
+	//   serialize(result)
+	WrapResponse func(*shared.GetDomainOpenExecutionCountResponse, error) (*AdminService_GetDomainOpenExecutionCount_Result, error)
+
+	// UnwrapResponse takes the result struct for GetDomainOpenExecutionCount
+	// and returns the value or error returned by it.
+	//
+	// The error is non-nil only if GetDomainOpenExecutionCount threw an
+	// exception.
+	//
+	//   result := deserialize(bytes)
+	//   value, err := AdminService_GetDomainOpenExecutionCount_Helper.UnwrapResponse(result)
+	UnwrapResponse func(*AdminService_GetDomainOpenExecutionCount_Result) (*shared.GetDomainOpenExecutionCountResponse, error)
+}{}
+
+func init() {
+	AdminService_GetDomainOpenExecutionCount_Helper.Args = func(
+		request *shared.GetDomainOpenExecutionCountRequest,
+	) *AdminService_GetDomainOpenExecutionCount_Args {
+		return &AdminService_GetDomainOpenExecutionCount_Args{
+			Request: request,
+		}
+	}
+
+	AdminService_GetDomainOpenExecutionCount_Helper.IsException = func(err error) bool {
+		switch err.(type) {
+		case *shared.BadRequestError:
+			return true
+		case *shared.InternalServiceError:
+			return true
+		case *shared.EntityNotExistsError:
+			return true
+		case *shared.AccessDeniedError:
+			return true
+		default:
+			return false
+		}
+	}
+
+	AdminService_GetDomainOpenExecutionCount_Helper.WrapResponse = func(success *shared.GetDomainOpenExecutionCountResponse, err error) (*AdminService_GetDomainOpenExecutionCount_Result, error) {
+		if err == nil {
+			return &AdminService_GetDomainOpenExecutionCount_Result{Success: success}, nil
+		}
+
+		switch e := err.(type) {
+		case *shared.BadRequestError:
+			if e == nil {
+				return nil, errors.New("WrapResponse received non-nil error type with nil value for AdminService_GetDomainOpenExecutionCount_Result.BadRequestError")
+			}
+			return &AdminService_GetDomainOpenExecutionCount_Result{BadRequestError: e}, nil
+		case *shared.InternalServiceError:
+			if e == nil {
+				return nil, errors.New("WrapResponse received non-nil error type with nil value for AdminService_GetDomainOpenExecutionCount_Result.InternalServiceError")
+			}
+			return &AdminService_GetDomainOpenExecutionCount_Result{InternalServiceError: e}, nil
+		case *shared.EntityNotExistsError:
+			if e == nil {
+				return nil, errors.New("WrapResponse received non-nil error type with nil value for AdminService_GetDomainOpenExecutionCount_Result.EntityNotExistError")
+			}
+			return &AdminService_GetDomainOpenExecutionCount_Result{EntityNotExistError: e}, nil
+		case *shared.AccessDeniedError:
+			if e == nil {
+				return nil, errors.New("WrapResponse received non-nil error type with nil value for AdminService_GetDomainOpenExecutionCount_Result.AccessDeniedError")
+			}
+			return &AdminService_GetDomainOpenExecutionCount_Result{AccessDeniedError: e}, nil
+		}
+
+		return nil, err
+	}
+	AdminService_GetDomainOpenExecutionCount_Helper.UnwrapResponse = func(result *AdminService_GetDomainOpenExecutionCount_Result) (success *shared.GetDomainOpenExecutionCountResponse, err error) {
+		if result.BadRequestError != nil {
+			err = result.BadRequestError
+			return
+		}
+		if result.InternalServiceError != nil {
+			err = result.InternalServiceError
+			return
+		}
+		if result.EntityNotExistError != nil {
+			err = result.EntityNotExistError
+			return
+		}
+		if result.AccessDeniedError != nil {
+			err = result.AccessDeniedError
+			return
+		}
+
+		if result.Success != nil {
+			success = result.Success
+			return
+		}
+
+		err = errors.New("expected a non-void result")
+		return
+	}
+
+}
+
+// AdminService_GetDomainOpenExecutionCount_Result represents the result of a AdminService.GetDomainOpenExecutionCount function call.
+//
+// The result of a GetDomainOpenExecutionCount execution is sent and received over the wire as this struct.
+//
+// Success is set only if the function did not throw an exception.
+type AdminService_GetDomainOpenExecutionCount_Result struct {
+	// Value returned by GetDomainOpenExecutionCount after a successful execution.
+	Success              *shared.GetDomainOpenExecutionCountResponse `json:"success,omitempty"`
+	BadRequestError      *shared.BadRequestError                     `json:"badRequestError,omitempty"`
+	InternalServiceError *shared.InternalServiceError                `json:"internalServiceError,omitempty"`
+	EntityNotExistError  *shared.EntityNotExistsError                `json:"entityNotExistError,omitempty"`
+	AccessDeniedError    *shared.AccessDeniedError                   `json:"accessDeniedError,omitempty"`
+}
+
+// ToWire translates a AdminService_GetDomainOpenExecutionCount_Result struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *AdminService_GetDomainOpenExecutionCount_Result) ToWire() (wire.Value, error) {
+	var (
+		fields [5]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.Success != nil {
+		w, err = v.Success.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 0, Value: w}
+		i++
+	}
+	if v.BadRequestError != nil {
+		w, err = v.BadRequestError.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 1, Value: w}
+		i++
+	}
+	if v.InternalServiceError != nil {
+		w, err = v.InternalServiceError.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 2, Value: w}
+		i++
+	}
+	if v.EntityNotExistError != nil {
+		w, err = v.EntityNotExistError.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 3, Value: w}
+		i++
+	}
+	if v.AccessDeniedError != nil {
+		w, err = v.AccessDeniedError.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 4, Value: w}
+		i++
+	}
+
+	if i != 1 {
+		return wire.Value{}, fmt.Errorf("AdminService_GetDomainOpenExecutionCount_Result should have exactly one field: got %v fields", i)
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+func _GetDomainOpenExecutionCountResponse_Read(w wire.Value) (*shared.GetDomainOpenExecutionCountResponse, error) {
+	var v shared.GetDomainOpenExecutionCountResponse
+	err := v.FromWire(w)
+	return &v, err
+}
+
+// FromWire deserializes a AdminService_GetDomainOpenExecutionCount_Result struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a AdminService_GetDomainOpenExecutionCount_Result struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v AdminService_GetDomainOpenExecutionCount_Result
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *AdminService_GetDomainOpenExecutionCount_Result) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 0:
+			if field.Value.Type() == wire.TStruct {
+				v.Success, err = _GetDomainOpenExecutionCountResponse_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 1:
+			if field.Value.Type() == wire.TStruct {
+				v.BadRequestError, err = _BadRequestError_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 2:
+			if field.Value.Type() == wire.TStruct {
+				v.InternalServiceError, err = _InternalServiceError_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 3:
+			if field.Value.Type() == wire.TStruct {
+				v.EntityNotExistError, err = _EntityNotExistsError_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 4:
+			if field.Value.Type() == wire.TStruct {
+				v.AccessDeniedError, err = _AccessDeniedError_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+
+	count := 0
+	if v.Success != nil {
+		count++
+	}
+	if v.BadRequestError != nil {
+		count++
+	}
+	if v.InternalServiceError != nil {
+		count++
+	}
+	if v.EntityNotExistError != nil {
+		count++
+	}
+	if v.AccessDeniedError != nil {
+		count++
+	}
+	if count != 1 {
+		return fmt.Errorf("AdminService_GetDomainOpenExecutionCount_Result should have exactly one field: got %v fields", count)
+	}
+
+	return nil
+}
+
+// String returns a readable string representation of a AdminService_GetDomainOpenExecutionCount_Result
+// struct.
+func (v *AdminService_GetDomainOpenExecutionCount_Result) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [5]string
+	i := 0
+	if v.Success != nil {
+		fields[i] = fmt.Sprintf("Success: %v", v.Success)
+		i++
+	}
+	if v.BadRequestError != nil {
+		fields[i] = fmt.Sprintf("BadRequestError: %v", v.BadRequestError)
+		i++
+	}
+	if v.InternalServiceError != nil {
+		fields[i] = fmt.Sprintf("InternalServiceError: %v", v.InternalServiceError)
+		i++
+	}
+	if v.EntityNotExistError != nil {
+		fields[i] = fmt.Sprintf("EntityNotExistError: %v", v.EntityNotExistError)
+		i++
+	}
+	if v.AccessDeniedError != nil {
+		fields[i] = fmt.Sprintf("AccessDeniedError: %v", v.AccessDeniedError)
+		i++
+	}
+
+	return fmt.Sprintf("AdminService_GetDomainOpenExecutionCount_Result{%v}", strings.Join(fields[:i], ", "))
+}
+
+// Equals returns true if all the fields of this AdminService_GetDomainOpenExecutionCount_Result match the
+// provided AdminService_GetDomainOpenExecutionCount_Result.
+//
+// This function performs a deep comparison.
+func (v *AdminService_GetDomainOpenExecutionCount_Result) Equals(rhs *AdminService_GetDomainOpenExecutionCount_Result) bool {
+	if v == nil {
+		return rhs == nil
+	} else if rhs == nil {
+		return false
+	}
+	if !((v.Success == nil && rhs.Success == nil) || (v.Success != nil && rhs.Success != nil && v.Success.Equals(rhs.Success))) {
+		return false
+	}
+	if !((v.BadRequestError == nil && rhs.BadRequestError == nil) || (v.BadRequestError != nil && rhs.BadRequestError != nil && v.BadRequestError.Equals(rhs.BadRequestError))) {
+		return false
+	}
+	if !((v.InternalServiceError == nil && rhs.InternalServiceError == nil) || (v.InternalServiceError != nil && rhs.InternalServiceError != nil && v.InternalServiceError.Equals(rhs.InternalServiceError))) {
+		return false
+	}
+	if !((v.EntityNotExistError == nil && rhs.EntityNotExistError == nil) || (v.EntityNotExistError != nil && rhs.EntityNotExistError != nil && v.EntityNotExistError.Equals(rhs.EntityNotExistError))) {
+		return false
+	}
+	if !((v.AccessDeniedError == nil && rhs.AccessDeniedError == nil) || (v.AccessDeniedError != nil && rhs.AccessDeniedError != nil && v.AccessDeniedError.Equals(rhs.AccessDeniedError))) {
+		return false
+	}
+
+	return true
+}
+
+// MarshalLogObject implements zapcore.ObjectMarshaler, enabling
+// fast logging of AdminService_GetDomainOpenExecutionCount_Result.
+func (v *AdminService_GetDomainOpenExecutionCount_Result) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+	if v == nil {
+		return nil
+	}
+	if v.Success != nil {
+		err = multierr.Append(err, enc.AddObject("success", v.Success))
+	}
+	if v.BadRequestError != nil {
+		err = multierr.Append(err, enc.AddObject("badRequestError", v.BadRequestError))
+	}
+	if v.InternalServiceError != nil {
+		err = multierr.Append(err, enc.AddObject("internalServiceError", v.InternalServiceError))
+	}
+	if v.EntityNotExistError != nil {
+		err = multierr.Append(err, enc.AddObject("entityNotExistError", v.EntityNotExistError))
+	}
+	if v.AccessDeniedError != nil {
+		err = multierr.Append(err, enc.AddObject("accessDeniedError", v.AccessDeniedError))
+	}
+	return err
+}
+
+// GetSuccess returns the value of Success if it is set or its
+// zero value if it is unset.
+func (v *AdminService_GetDomainOpenExecutionCount_Result) GetSuccess() (o *shared.GetDomainOpenExecutionCountResponse) {
+	if v != nil && v.Success != nil {
+		return v.Success
+	}
+
+	return
+}
+
+// IsSetSuccess returns true if Success is not nil.
+func (v *AdminService_GetDomainOpenExecutionCount_Result) IsSetSuccess() bool {
+	return v != nil && v.Success != nil
+}
+
+// GetBadRequestError returns the value of BadRequestError if it is set or its
+// zero value if it is unset.
+func (v *AdminService_GetDomainOpenExecutionCount_Result) GetBadRequestError() (o *shared.BadRequestError) {
+	if v != nil && v.BadRequestError != nil {
+		return v.BadRequestError
+	}
+
+	return
+}
+
+// IsSetBadRequestError returns true if BadRequestError is not nil.
+func (v *AdminService_GetDomainOpenExecutionCount_Result) IsSetBadRequestError() bool {
+	return v != nil && v.BadRequestError != nil
+}
+
+// GetInternalServiceError returns the value of InternalServiceError if it is set or its
+// zero value if it is unset.
+func (v *AdminService_GetDomainOpenExecutionCount_Result) GetInternalServiceError() (o *shared.InternalServiceError) {
+	if v != nil && v.InternalServiceError != nil {
+		return v.InternalServiceError
+	}
+
+	return
+}
+
+// IsSetInternalServiceError returns true if InternalServiceError is not nil.
+func (v *AdminService_GetDomainOpenExecutionCount_Result) IsSetInternalServiceError() bool {
+	return v != nil && v.InternalServiceError != nil
+}
+
+// GetEntityNotExistError returns the value of EntityNotExistError if it is set or its
+// zero value if it is unset.
+func (v *AdminService_GetDomainOpenExecutionCount_Result) GetEntityNotExistError() (o *shared.EntityNotExistsError) {
+	if v != nil && v.EntityNotExistError != nil {
+		return v.EntityNotExistError
+	}
+
+	return
+}
+
+// IsSetEntityNotExistError returns true if EntityNotExistError is not nil.
+func (v *AdminService_GetDomainOpenExecutionCount_Result) IsSetEntityNotExistError() bool {
+	return v != nil && v.EntityNotExistError != nil
+}
+
+// GetAccessDeniedError returns the value of AccessDeniedError if it is set or its
+// zero value if it is unset.
+func (v *AdminService_GetDomainOpenExecutionCount_Result) GetAccessDeniedError() (o *shared.AccessDeniedError) {
+	if v != nil && v.AccessDeniedError != nil {
+		return v.AccessDeniedError
+	}
+
+	return
+}
+
+// IsSetAccessDeniedError returns true if AccessDeniedError is not nil.
+func (v *AdminService_GetDomainOpenExecutionCount_Result) IsSetAccessDeniedError() bool {
+	return v != nil && v.AccessDeniedError != nil
+}
+
+// MethodName returns the name of the Thrift function as specified in
+// the IDL, for which this struct represent the result.
+//
+// This will always be "GetDomainOpenExecutionCount" for this struct.
+func (v *AdminService_GetDomainOpenExecutionCount_Result) MethodName() string {
+	return "GetDomainOpenExecutionCount"
+}
+
+// EnvelopeType returns the kind of value inside this struct.
+//
+// This will always be Reply for this struct.
+func (v *AdminService_GetDomainOpenExecutionCount_Result) EnvelopeType() wire.EnvelopeType {
+	return wire.Reply
+}
+
+// AdminService_GetWorkflowExecutionRawHistory_Args represents the arguments for the AdminService.GetWorkflowExecutionRawHistory function.
+//
+// The arguments for GetWorkflowExecutionRawHistory are sent and received over the wire as this struct.
+type AdminService_GetWorkflowExecutionRawHistory_Args struct {
+	GetRequest *GetWorkflowExecutionRawHistoryRequest `json:"getRequest,omitempty"`
+}
+
+// ToWire translates a AdminService_GetWorkflowExecutionRawHistory_Args struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *AdminService_GetWorkflowExecutionRawHistory_Args) ToWire() (wire.Value, error) {
+	var (
+		fields [1]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.GetRequest != nil {
+		w, err = v.GetRequest.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 1, Value: w}
+		i++
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+func _GetWorkflowExecutionRawHistoryRequest_Read(w wire.Value) (*GetWorkflowExecutionRawHistoryRequest, error) {
+	var v GetWorkflowExecutionRawHistoryRequest
+	err := v.FromWire(w)
+	return &v, err
+}
+
+// FromWire deserializes a AdminService_GetWorkflowExecutionRawHistory_Args struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a AdminService_GetWorkflowExecutionRawHistory_Args struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v AdminService_GetWorkflowExecutionRawHistory_Args
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *AdminService_GetWorkflowExecutionRawHistory_Args) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 1:
+			if field.Value.Type() == wire.TStruct {
+				v.GetRequest, err = _GetWorkflowExecutionRawHistoryRequest_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+
+	return nil
+}
+
+// String returns a readable string representation of a AdminService_GetWorkflowExecutionRawHistory_Args
+// struct.
+func (v *AdminService_GetWorkflowExecutionRawHistory_Args) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [1]string
+	i := 0
+	if v.GetRequest != nil {
+		fields[i] = fmt.Sprintf("GetRequest: %v", v.GetRequest)
+		i++
+	}
+
+	return fmt.Sprintf("AdminService_GetWorkflowExecutionRawHistory_Args{%v}", strings.Join(fields[:i], ", "))
+}
+
+// Equals returns true if all the fields of this AdminService_GetWorkflowExecutionRawHistory_Args match the
+// provided AdminService_GetWorkflowExecutionRawHistory_Args.
+//
+// This function performs a deep comparison.
+func (v *AdminService_GetWorkflowExecutionRawHistory_Args) Equals(rhs *AdminService_GetWorkflowExecutionRawHistory_Args) bool {
+	if v == nil {
+		return rhs == nil
+	} else if rhs == nil {
+		return false
+	}
+	if !((v.GetRequest == nil && rhs.GetRequest == nil) || (v.GetRequest != nil && rhs.GetRequest != nil && v.GetRequest.Equals(rhs.GetRequest))) {
+		return false
+	}
+
+	return true
+}
+
+// MarshalLogObject implements zapcore.ObjectMarshaler, enabling
+// fast logging of AdminService_GetWorkflowExecutionRawHistory_Args.
+func (v *AdminService_GetWorkflowExecutionRawHistory_Args) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+	if v == nil {
+		return nil
+	}
+	if v.GetRequest != nil {
+		err = multierr.Append(err, enc.AddObject("getRequest", v.GetRequest))
+	}
+	return err
+}
+
+// GetGetRequest returns the value of GetRequest if it is set or its
+// zero value if it is unset.
+func (v *AdminService_GetWorkflowExecutionRawHistory_Args) GetGetRequest() (o *GetWorkflowExecutionRawHistoryRequest) {
+	if v != nil && v.GetRequest != nil {
+		return v.GetRequest
+	}
+
+	return
+}
+
+// IsSetGetRequest returns true if GetRequest is not nil.
+func (v *AdminService_GetWorkflowExecutionRawHistory_Args) IsSetGetRequest() bool {
+	return v != nil && v.GetRequest != nil
+}
+
+// MethodName returns the name of the Thrift function as specified in
+// the IDL, for which this struct represent the arguments.
+//
+// This will always be "GetWorkflowExecutionRawHistory" for this struct.
+func (v *AdminService_GetWorkflowExecutionRawHistory_Args) MethodName() string {
+	return "GetWorkflowExecutionRawHistory"
+}
+
+// EnvelopeType returns the kind of value inside this struct.
+//
+// This will always be Call for this struct.
+func (v *AdminService_GetWorkflowExecutionRawHistory_Args) EnvelopeType() wire.EnvelopeType {
+	return wire.Call
+}
+
+// AdminService_GetWorkflowExecutionRawHistory_Helper provides functions that aid in handling the
+// parameters and return values of the AdminService.GetWorkflowExecutionRawHistory
+// function.
+var AdminService_GetWorkflowExecutionRawHistory_Helper = struct {
+	// Args accepts the parameters of GetWorkflowExecutionRawHistory in-order and returns
+	// the arguments struct for the function.
+	Args func(
+		getRequest *GetWorkflowExecutionRawHistoryRequest,
+	) *AdminService_GetWorkflowExecutionRawHistory_Args
+
+	// IsException returns true if the given error can be thrown
+	// by GetWorkflowExecutionRawHistory.
+	//
+	// An error can be thrown by GetWorkflowExecutionRawHistory only if the
+	// corresponding exception type was mentioned in the 'throws'
+	// section for it in the Thrift file.
+	IsException func(error) bool
+
+	// WrapResponse returns the result struct for GetWorkflowExecutionRawHistory
+	// given its return value and error.
+	//
+	// This allows mapping values and errors returned by
+	// GetWorkflowExecutionRawHistory into a serializable result struct.
+	// WrapResponse returns a non-nil error if the provided
+	// error cannot be thrown by GetWorkflowExecutionRawHistory
+	//
+	//   value, err := GetWorkflowExecutionRawHistory(args)
+	//   result, err := AdminService_GetWorkflowExecutionRawHistory_Helper.WrapResponse(value, err)
+	//   if err != nil {
+	//     return fmt.Errorf("unexpected error from GetWorkflowExecutionRawHistory: %v", err)
+	//   }
+	//   serialize(result)
+	WrapResponse func(*GetWorkflowExecutionRawHistoryResponse, error) (*AdminService_GetWorkflowExecutionRawHistory_Result, error)
+
+	// UnwrapResponse takes the result struct for GetWorkflowExecutionRawHistory
+	// and returns the value or error returned by it.
+	//
+	// The error is non-nil only if GetWorkflowExecutionRawHistory threw an
+	// exception.
+	//
+	//   result := deserialize(bytes)
+	//   value, err := AdminService_GetWorkflowExecutionRawHistory_Helper.UnwrapResponse(result)
+	UnwrapResponse func(*AdminService_GetWorkflowExecutionRawHistory_Result) (*GetWorkflowExecutionRawHistoryResponse, error)
+}{}
+
+func init() {
+	AdminService_GetWorkflowExecutionRawHistory_Helper.Args = func(
+		getRequest *GetWorkflowExecutionRawHistoryRequest,
+	) *AdminService_GetWorkflowExecutionRawHistory_Args {
+		return &AdminService_GetWorkflowExecutionRawHistory_Args{
+			GetRequest: getRequest,
+		}
+	}
+
+	AdminService_GetWorkflowExecutionRawHistory_Helper.IsException = func(err error) bool {
+		switch err.(type) {
+		case *shared.BadRequestError:
+			return true
+		case *shared.InternalServiceError:
+			return true
+		case *shared.EntityNotExistsError:
+			return true
+		case *shared.ServiceBusyError:
+			return true
+		default:
+			return false
+		}
+	}
+
+	AdminService_GetWorkflowExecutionRawHistory_Helper.WrapResponse = func(success *GetWorkflowExecutionRawHistoryResponse, err error) (*AdminService_GetWorkflowExecutionRawHistory_Result, error) {
+		if err == nil {
+			return &AdminService_GetWorkflowExecutionRawHistory_Result{Success: success}, nil
+		}
+
+		switch e := err.(type) {
+		case *shared.BadRequestError:
+			if e == nil {
+				return nil, errors.New("WrapResponse received non-nil error type with nil value for AdminService_GetWorkflowExecutionRawHistory_Result.BadRequestError")
+			}
+			return &AdminService_GetWorkflowExecutionRawHistory_Result{BadRequestError: e}, nil
+		case *shared.InternalServiceError:
+			if e == nil {
+				return nil, errors.New("WrapResponse received non-nil error type with nil value for AdminService_GetWorkflowExecutionRawHistory_Result.InternalServiceError")
+			}
+			return &AdminService_GetWorkflowExecutionRawHistory_Result{InternalServiceError: e}, nil
+		case *shared.EntityNotExistsError:
+			if e == nil {
+				return nil, errors.New("WrapResponse received non-nil error type with nil value for AdminService_GetWorkflowExecutionRawHistory_Result.EntityNotExistError")
+			}
+			return &AdminService_GetWorkflowExecutionRawHistory_Result{EntityNotExistError: e}, nil
+		case *shared.ServiceBusyError:
+			if e == nil {
+				return nil, errors.New("WrapResponse received non-nil error type with nil value for AdminService_GetWorkflowExecutionRawHistory_Result.ServiceBusyError")
+			}
+			return &AdminService_GetWorkflowExecutionRawHistory_Result{ServiceBusyError: e}, nil
+		}
+
+		return nil, err
+	}
+	AdminService_GetWorkflowExecutionRawHistory_Helper.UnwrapResponse = func(result *AdminService_GetWorkflowExecutionRawHistory_Result) (success *GetWorkflowExecutionRawHistoryResponse, err error) {
+		if result.BadRequestError != nil {
+			err = result.BadRequestError
+			return
+		}
+		if result.InternalServiceError != nil {
+			err = result.InternalServiceError
+			return
+		}
+		if result.EntityNotExistError != nil {
+			err = result.EntityNotExistError
+			return
+		}
+		if result.ServiceBusyError != nil {
+			err = result.ServiceBusyError
+			return
+		}
+
+		if result.Success != nil {
+			success = result.Success
+			return
+		}
+
+		err = errors.New("expected a non-void result")
+		return
+	}
+
+}
+
+// AdminService_GetWorkflowExecutionRawHistory_Result represents the result of a AdminService.GetWorkflowExecutionRawHistory function call.
+//
+// The result of a GetWorkflowExecutionRawHistory execution is sent and received over the wire as this struct.
+//
+// Success is set only if the function did not throw an exception.
+type AdminService_GetWorkflowExecutionRawHistory_Result struct {
+	// Value returned by GetWorkflowExecutionRawHistory after a successful execution.
+	Success              *GetWorkflowExecutionRawHistoryResponse `json:"success,omitempty"`
+	BadRequestError      *shared.BadRequestError                 `json:"badRequestError,omitempty"`
+	InternalServiceError *shared.InternalServiceError            `json:"internalServiceError,omitempty"`
+	EntityNotExistError  *shared.EntityNotExistsError            `json:"entityNotExistError,omitempty"`
+	ServiceBusyError     *shared.ServiceBusyError                `json:"serviceBusyError,omitempty"`
+}
+
+// ToWire translates a AdminService_GetWorkflowExecutionRawHistory_Result struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *AdminService_GetWorkflowExecutionRawHistory_Result) ToWire() (wire.Value, error) {
+	var (
+		fields [5]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.Success != nil {
+		w, err = v.Success.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 0, Value: w}
+		i++
+	}
+	if v.BadRequestError != nil {
+		w, err = v.BadRequestError.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 1, Value: w}
+		i++
+	}
+	if v.InternalServiceError != nil {
+		w, err = v.InternalServiceError.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 2, Value: w}
+		i++
+	}
+	if v.EntityNotExistError != nil {
+		w, err = v.EntityNotExistError.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 3, Value: w}
+		i++
+	}
+	if v.ServiceBusyError != nil {
+		w, err = v.ServiceBusyError.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 4, Value: w}
+		i++
+	}
+
+	if i != 1 {
+		return wire.Value{}, fmt.Errorf("AdminService_GetWorkflowExecutionRawHistory_Result should have exactly one field: got %v fields", i)
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+func _GetWorkflowExecutionRawHistoryResponse_Read(w wire.Value) (*GetWorkflowExecutionRawHistoryResponse, error) {
+	var v GetWorkflowExecutionRawHistoryResponse
+	err := v.FromWire(w)
+	return &v, err
+}
+
+// FromWire deserializes a AdminService_GetWorkflowExecutionRawHistory_Result struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a AdminService_GetWorkflowExecutionRawHistory_Result struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v AdminService_GetWorkflowExecutionRawHistory_Result
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *AdminService_GetWorkflowExecutionRawHistory_Result) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 0:
+			if field.Value.Type() == wire.TStruct {
+				v.Success, err = _GetWorkflowExecutionRawHistoryResponse_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 1:
+			if field.Value.Type() == wire.TStruct {
+				v.BadRequestError, err = _BadRequestError_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 2:
+			if field.Value.Type() == wire.TStruct {
+				v.InternalServiceError, err = _InternalServiceError_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 3:
+			if field.Value.Type() == wire.TStruct {
+				v.EntityNotExistError, err = _EntityNotExistsError_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 4:
+			if field.Value.Type() == wire.TStruct {
+				v.ServiceBusyError, err = _ServiceBusyError_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+
+	count := 0
+	if v.Success != nil {
+		count++
+	}
+	if v.BadRequestError != nil {
+		count++
+	}
+	if v.InternalServiceError != nil {
+		count++
+	}
+	if v.EntityNotExistError != nil {
+		count++
+	}
+	if v.ServiceBusyError != nil {
+		count++
+	}
+	if count != 1 {
+		return fmt.Errorf("AdminService_GetWorkflowExecutionRawHistory_Result should have exactly one field: got %v fields", count)
+	}
+
+	return nil
+}
+
+// String returns a readable string representation of a AdminService_GetWorkflowExecutionRawHistory_Result
+// struct.
+func (v *AdminService_GetWorkflowExecutionRawHistory_Result) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [5]string
+	i := 0
+	if v.Success != nil {
+		fields[i] = fmt.Sprintf("Success: %v", v.Success)
+		i++
+	}
+	if v.BadRequestError != nil {
+		fields[i] = fmt.Sprintf("BadRequestError: %v", v.BadRequestError)
+		i++
+	}
+	if v.InternalServiceError != nil {
+		fields[i] = fmt.Sprintf("InternalServiceError: %v", v.InternalServiceError)
+		i++
+	}
+	if v.EntityNotExistError != nil {
+		fields[i] = fmt.Sprintf("EntityNotExistError: %v", v.EntityNotExistError)
+		i++
+	}
+	if v.ServiceBusyError != nil {
+		fields[i] = fmt.Sprintf("ServiceBusyError: %v", v.ServiceBusyError)
+		i++
+	}
+
+	return fmt.Sprintf("AdminService_GetWorkflowExecutionRawHistory_Result{%v}", strings.Join(fields[:i], ", "))
+}
+
+// Equals returns true if all the fields of this AdminService_GetWorkflowExecutionRawHistory_Result match the
+// provided AdminService_GetWorkflowExecutionRawHistory_Result.
+//
+// This function performs a deep comparison.
+func (v *AdminService_GetWorkflowExecutionRawHistory_Result) Equals(rhs *AdminService_GetWorkflowExecutionRawHistory_Result) bool {
+	if v == nil {
+		return rhs == nil
+	} else if rhs == nil {
+		return false
+	}
+	if !((v.Success == nil && rhs.Success == nil) || (v.Success != nil && rhs.Success != nil && v.Success.Equals(rhs.Success))) {
+		return false
+	}
+	if !((v.BadRequestError == nil && rhs.BadRequestError == nil) || (v.BadRequestError != nil && rhs.BadRequestError != nil && v.BadRequestError.Equals(rhs.BadRequestError))) {
+		return false
+	}
+	if !((v.InternalServiceError == nil && rhs.InternalServiceError == nil) || (v.InternalServiceError != nil && rhs.InternalServiceError != nil && v.InternalServiceError.Equals(rhs.InternalServiceError))) {
+		return false
+	}
+	if !((v.EntityNotExistError == nil && rhs.EntityNotExistError == nil) || (v.EntityNotExistError != nil && rhs.EntityNotExistError != nil && v.EntityNotExistError.Equals(rhs.EntityNotExistError))) {
+		return false
+	}
+	if !((v.ServiceBusyError == nil && rhs.ServiceBusyError == nil) || (v.ServiceBusyError != nil && rhs.ServiceBusyError != nil && v.ServiceBusyError.Equals(rhs.ServiceBusyError))) {
+		return false
+	}
+
+	return true
+}
+
+// MarshalLogObject implements zapcore.ObjectMarshaler, enabling
+// fast logging of AdminService_GetWorkflowExecutionRawHistory_Result.
+func (v *AdminService_GetWorkflowExecutionRawHistory_Result) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+	if v == nil {
+		return nil
+	}
+	if v.Success != nil {
+		err = multierr.Append(err, enc.AddObject("success", v.Success))
+	}
+	if v.BadRequestError != nil {
+		err = multierr.Append(err, enc.AddObject("badRequestError", v.BadRequestError))
+	}
+	if v.InternalServiceError != nil {
+		err = multierr.Append(err, enc.AddObject("internalServiceError", v.InternalServiceError))
+	}
+	if v.EntityNotExistError != nil {
+		err = multierr.Append(err, enc.AddObject("entityNotExistError", v.EntityNotExistError))
+	}
+	if v.ServiceBusyError != nil {
+		err = multierr.Append(err, enc.AddObject("serviceBusyError", v.ServiceBusyError))
+	}
+	return err
+}
+
+// GetSuccess returns the value of Success if it is set or its
+// zero value if it is unset.
+func (v *AdminService_GetWorkflowExecutionRawHistory_Result) GetSuccess() (o *GetWorkflowExecutionRawHistoryResponse) {
+	if v != nil && v.Success != nil {
+		return v.Success
+	}
+
+	return
+}
+
+// IsSetSuccess returns true if Success is not nil.
+func (v *AdminService_GetWorkflowExecutionRawHistory_Result) IsSetSuccess() bool {
+	return v != nil && v.Success != nil
+}
+
+// GetBadRequestError returns the value of BadRequestError if it is set or its
+// zero value if it is unset.
+func (v *AdminService_GetWorkflowExecutionRawHistory_Result) GetBadRequestError() (o *shared.BadRequestError) {
+	if v != nil && v.BadRequestError != nil {
+		return v.BadRequestError
+	}
+
+	return
+}
+
+// IsSetBadRequestError returns true if BadRequestError is not nil.
+func (v *AdminService_GetWorkflowExecutionRawHistory_Result) IsSetBadRequestError() bool {
+	return v != nil && v.BadRequestError != nil
+}
+
+// GetInternalServiceError returns the value of InternalServiceError if it is set or its
+// zero value if it is unset.
+func (v *AdminService_GetWorkflowExecutionRawHistory_Result) GetInternalServiceError() (o *shared.InternalServiceError) {
+	if v != nil && v.InternalServiceError != nil {
+		return v.InternalServiceError
+	}
+
+	return
+}
+
+// IsSetInternalServiceError returns true if InternalServiceError is not nil.
+func (v *AdminService_GetWorkflowExecutionRawHistory_Result) IsSetInternalServiceError() bool {
+	return v != nil && v.InternalServiceError != nil
+}
+
+// GetEntityNotExistError returns the value of EntityNotExistError if it is set or its
+// zero value if it is unset.
+func (v *AdminService_GetWorkflowExecutionRawHistory_Result) GetEntityNotExistError() (o *shared.EntityNotExistsError) {
+	if v != nil && v.EntityNotExistError != nil {
+		return v.EntityNotExistError
+	}
+
+	return
+}
+
+// IsSetEntityNotExistError returns true if EntityNotExistError is not nil.
+func (v *AdminService_GetWorkflowExecutionRawHistory_Result) IsSetEntityNotExistError() bool {
+	return v != nil && v.EntityNotExistError != nil
+}
+
+// GetServiceBusyError returns the value of ServiceBusyError if it is set or its
+// zero value if it is unset.
+func (v *AdminService_GetWorkflowExecutionRawHistory_Result) GetServiceBusyError() (o *shared.ServiceBusyError) {
+	if v != nil && v.ServiceBusyError != nil {
+		return v.ServiceBusyError
+	}
+
+	return
+}
+
+// IsSetServiceBusyError returns true if ServiceBusyError is not nil.
+func (v *AdminService_GetWorkflowExecutionRawHistory_Result) IsSetServiceBusyError() bool {
+	return v != nil && v.ServiceBusyError != nil
+}
+
+// MethodName returns the name of the Thrift function as specified in
+// the IDL, for which this struct represent the result.
+//
+// This will always be "GetWorkflowExecutionRawHistory" for this struct.
+func (v *AdminService_GetWorkflowExecutionRawHistory_Result) MethodName() string {
+	return "GetWorkflowExecutionRawHistory"
+}
+
+// EnvelopeType returns the kind of value inside this struct.
+//
+// This will always be Reply for this struct.
+func (v *AdminService_GetWorkflowExecutionRawHistory_Result) EnvelopeType() wire.EnvelopeType {
+	return wire.Reply
+}
+
+// AdminService_ImportWorkflowExecution_Args represents the arguments for the AdminService.ImportWorkflowExecution function.
+//
+// The arguments for ImportWorkflowExecution are sent and received over the wire as this struct.
+type AdminService_ImportWorkflowExecution_Args struct {
+	Request *ImportWorkflowExecutionRequest `json:"request,omitempty"`
+}
+
+// ToWire translates a AdminService_ImportWorkflowExecution_Args struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *AdminService_ImportWorkflowExecution_Args) ToWire() (wire.Value, error) {
+	var (
+		fields [1]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.Request != nil {
+		w, err = v.Request.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 1, Value: w}
+		i++
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+func _ImportWorkflowExecutionRequest_Read(w wire.Value) (*ImportWorkflowExecutionRequest, error) {
+	var v ImportWorkflowExecutionRequest
+	err := v.FromWire(w)
+	return &v, err
+}
+
+// FromWire deserializes a AdminService_ImportWorkflowExecution_Args struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a AdminService_ImportWorkflowExecution_Args struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v AdminService_ImportWorkflowExecution_Args
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *AdminService_ImportWorkflowExecution_Args) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 1:
+			if field.Value.Type() == wire.TStruct {
+				v.Request, err = _ImportWorkflowExecutionRequest_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+
+	return nil
+}
+
+// String returns a readable string representation of a AdminService_ImportWorkflowExecution_Args
+// struct.
+func (v *AdminService_ImportWorkflowExecution_Args) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [1]string
+	i := 0
+	if v.Request != nil {
+		fields[i] = fmt.Sprintf("Request: %v", v.Request)
+		i++
+	}
+
+	return fmt.Sprintf("AdminService_ImportWorkflowExecution_Args{%v}", strings.Join(fields[:i], ", "))
+}
+
+// Equals returns true if all the fields of this AdminService_ImportWorkflowExecution_Args match the
+// provided AdminService_ImportWorkflowExecution_Args.
+//
+// This function performs a deep comparison.
+func (v *AdminService_ImportWorkflowExecution_Args) Equals(rhs *AdminService_ImportWorkflowExecution_Args) bool {
+	if v == nil {
+		return rhs == nil
+	} else if rhs == nil {
+		return false
+	}
+	if !((v.Request == nil && rhs.Request == nil) || (v.Request != nil && rhs.Request != nil && v.Request.Equals(rhs.Request))) {
+		return false
+	}
+
+	return true
+}
+
+// MarshalLogObject implements zapcore.ObjectMarshaler, enabling
+// fast logging of AdminService_ImportWorkflowExecution_Args.
+func (v *AdminService_ImportWorkflowExecution_Args) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+	if v == nil {
+		return nil
+	}
+	if v.Request != nil {
+		err = multierr.Append(err, enc.AddObject("request", v.Request))
+	}
+	return err
+}
+
+// GetRequest returns the value of Request if it is set or its
+// zero value if it is unset.
+func (v *AdminService_ImportWorkflowExecution_Args) GetRequest() (o *ImportWorkflowExecutionRequest) {
+	if v != nil && v.Request != nil {
+		return v.Request
+	}
+
+	return
+}
+
+// IsSetRequest returns true if Request is not nil.
+func (v *AdminService_ImportWorkflowExecution_Args) IsSetRequest() bool {
+	return v != nil && v.Request != nil
+}
+
+// MethodName returns the name of the Thrift function as specified in
+// the IDL, for which this struct represent the arguments.
+//
+// This will always be "ImportWorkflowExecution" for this struct.
+func (v *AdminService_ImportWorkflowExecution_Args) MethodName() string {
+	return "ImportWorkflowExecution"
+}
+
+// EnvelopeType returns the kind of value inside this struct.
+//
+// This will always be Call for this struct.
+func (v *AdminService_ImportWorkflowExecution_Args) EnvelopeType() wire.EnvelopeType {
+	return wire.Call
+}
+
+// AdminService_ImportWorkflowExecution_Helper provides functions that aid in handling the
+// parameters and return values of the AdminService.ImportWorkflowExecution
+// function.
+var AdminService_ImportWorkflowExecution_Helper = struct {
+	// Args accepts the parameters of ImportWorkflowExecution in-order and returns
+	// the arguments struct for the function.
+	Args func(
+		request *ImportWorkflowExecutionRequest,
+	) *AdminService_ImportWorkflowExecution_Args
+
+	// IsException returns true if the given error can be thrown
+	// by ImportWorkflowExecution.
+	//
+	// An error can be thrown by ImportWorkflowExecution only if the
+	// corresponding exception type was mentioned in the 'throws'
+	// section for it in the Thrift file.
+	IsException func(error) bool
+
+	// WrapResponse returns the result struct for ImportWorkflowExecution
+	// given its return value and error.
+	//
+	// This allows mapping values and errors returned by
+	// ImportWorkflowExecution into a serializable result struct.
+	// WrapResponse returns a non-nil error if the provided
+	// error cannot be thrown by ImportWorkflowExecution
+	//
+	//   value, err := ImportWorkflowExecution(args)
+	//   result, err := AdminService_ImportWorkflowExecution_Helper.WrapResponse(value, err)
+	//   if err != nil {
+	//     return fmt.Errorf("unexpected error from ImportWorkflowExecution: %v", err)
+	//   }
+	//   serialize(result)
+	WrapResponse func(*ImportWorkflowExecutionResponse, error) (*AdminService_ImportWorkflowExecution_Result, error)
+
+	// UnwrapResponse takes the result struct for ImportWorkflowExecution
+	// and returns the value or error returned by it.
+	//
+	// The error is non-nil only if ImportWorkflowExecution threw an
+	// exception.
+	//
+	//   result := deserialize(bytes)
+	//   value, err := AdminService_ImportWorkflowExecution_Helper.UnwrapResponse(result)
+	UnwrapResponse func(*AdminService_ImportWorkflowExecution_Result) (*ImportWorkflowExecutionResponse, error)
+}{}
+
+func init() {
+	AdminService_ImportWorkflowExecution_Helper.Args = func(
+		request *ImportWorkflowExecutionRequest,
+	) *AdminService_ImportWorkflowExecution_Args {
+		return &AdminService_ImportWorkflowExecution_Args{
+			Request: request,
+		}
+	}
+
+	AdminService_ImportWorkflowExecution_Helper.IsException = func(err error) bool {
+		switch err.(type) {
+		case *shared.BadRequestError:
+			return true
+		case *shared.InternalServiceError:
+			return true
+		case *shared.ServiceBusyError:
+			return true
+		case *shared.AccessDeniedError:
+			return true
+		default:
+			return false
+		}
+	}
+
+	AdminService_ImportWorkflowExecution_Helper.WrapResponse = func(success *ImportWorkflowExecutionResponse, err error) (*AdminService_ImportWorkflowExecution_Result, error) {
+		if err == nil {
+			return &AdminService_ImportWorkflowExecution_Result{Success: success}, nil
+		}
+
+		switch e := err.(type) {
+		case *shared.BadRequestError:
+			if e == nil {
+				return nil, errors.New("WrapResponse received non-nil error type with nil value for AdminService_ImportWorkflowExecution_Result.BadRequestError")
+			}
+			return &AdminService_ImportWorkflowExecution_Result{BadRequestError: e}, nil
+		case *shared.InternalServiceError:
+			if e == nil {
+				return nil, errors.New("WrapResponse received non-nil error type with nil value for AdminService_ImportWorkflowExecution_Result.InternalServiceError")
+			}
+			return &AdminService_ImportWorkflowExecution_Result{InternalServiceError: e}, nil
+		case *shared.ServiceBusyError:
+			if e == nil {
+				return nil, errors.New("WrapResponse received non-nil error type with nil value for AdminService_ImportWorkflowExecution_Result.ServiceBusyError")
+			}
+			return &AdminService_ImportWorkflowExecution_Result{ServiceBusyError: e}, nil
+		case *shared.AccessDeniedError:
+			if e == nil {
+				return nil, errors.New("WrapResponse received non-nil error type with nil value for AdminService_ImportWorkflowExecution_Result.AccessDeniedError")
+			}
+			return &AdminService_ImportWorkflowExecution_Result{AccessDeniedError: e}, nil
+		}
+
+		return nil, err
+	}
+	AdminService_ImportWorkflowExecution_Helper.UnwrapResponse = func(result *AdminService_ImportWorkflowExecution_Result) (success *ImportWorkflowExecutionResponse, err error) {
+		if result.BadRequestError != nil {
+			err = result.BadRequestError
+			return
+		}
+		if result.InternalServiceError != nil {
+			err = result.InternalServiceError
+			return
+		}
+		if result.ServiceBusyError != nil {
+			err = result.ServiceBusyError
+			return
+		}
+		if result.AccessDeniedError != nil {
+			err = result.AccessDeniedError
+			return
+		}
+
+		if result.Success != nil {
+			success = result.Success
+			return
+		}
+
+		err = errors.New("expected a non-void result")
+		return
+	}
+
+}
+
+// AdminService_ImportWorkflowExecution_Result represents the result of a AdminService.ImportWorkflowExecution function call.
+//
+// The result of a ImportWorkflowExecution execution is sent and received over the wire as this struct.
+//
+// Success is set only if the function did not throw an exception.
+type AdminService_ImportWorkflowExecution_Result struct {
+	// Value returned by ImportWorkflowExecution after a successful execution.
+	Success              *ImportWorkflowExecutionResponse `json:"success,omitempty"`
+	BadRequestError      *shared.BadRequestError            `json:"badRequestError,omitempty"`
+	InternalServiceError *shared.InternalServiceError       `json:"internalServiceError,omitempty"`
+	ServiceBusyError     *shared.ServiceBusyError           `json:"serviceBusyError,omitempty"`
+	AccessDeniedError    *shared.AccessDeniedError          `json:"accessDeniedError,omitempty"`
+}
+
+// ToWire translates a AdminService_ImportWorkflowExecution_Result struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *AdminService_ImportWorkflowExecution_Result) ToWire() (wire.Value, error) {
+	var (
+		fields [5]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.Success != nil {
+		w, err = v.Success.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 0, Value: w}
+		i++
+	}
+	if v.BadRequestError != nil {
+		w, err = v.BadRequestError.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 1, Value: w}
+		i++
+	}
+	if v.InternalServiceError != nil {
+		w, err = v.InternalServiceError.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 2, Value: w}
+		i++
+	}
+	if v.ServiceBusyError != nil {
+		w, err = v.ServiceBusyError.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 3, Value: w}
+		i++
+	}
+	if v.AccessDeniedError != nil {
+		w, err = v.AccessDeniedError.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 4, Value: w}
+		i++
+	}
+
+	if i != 1 {
+		return wire.Value{}, fmt.Errorf("AdminService_ImportWorkflowExecution_Result should have exactly one field: got %v fields", i)
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+func _ImportWorkflowExecutionResponse_Read(w wire.Value) (*ImportWorkflowExecutionResponse, error) {
+	var v ImportWorkflowExecutionResponse
+	err := v.FromWire(w)
+	return &v, err
+}
+
+// FromWire deserializes a AdminService_ImportWorkflowExecution_Result struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a AdminService_ImportWorkflowExecution_Result struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v AdminService_ImportWorkflowExecution_Result
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *AdminService_ImportWorkflowExecution_Result) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 0:
+			if field.Value.Type() == wire.TStruct {
+				v.Success, err = _ImportWorkflowExecutionResponse_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 1:
+			if field.Value.Type() == wire.TStruct {
+				v.BadRequestError, err = _BadRequestError_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 2:
+			if field.Value.Type() == wire.TStruct {
+				v.InternalServiceError, err = _InternalServiceError_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 3:
+			if field.Value.Type() == wire.TStruct {
+				v.ServiceBusyError, err = _ServiceBusyError_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 4:
+			if field.Value.Type() == wire.TStruct {
+				v.AccessDeniedError, err = _AccessDeniedError_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+
+	count := 0
+	if v.Success != nil {
+		count++
+	}
+	if v.BadRequestError != nil {
+		count++
+	}
+	if v.InternalServiceError != nil {
+		count++
+	}
+	if v.ServiceBusyError != nil {
+		count++
+	}
+	if v.AccessDeniedError != nil {
+		count++
+	}
+	if count != 1 {
+		return fmt.Errorf("AdminService_ImportWorkflowExecution_Result should have exactly one field: got %v fields", count)
+	}
+
+	return nil
+}
+
+// String returns a readable string representation of a AdminService_ImportWorkflowExecution_Result
+// struct.
+func (v *AdminService_ImportWorkflowExecution_Result) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [5]string
+	i := 0
+	if v.Success != nil {
+		fields[i] = fmt.Sprintf("Success: %v", v.Success)
+		i++
+	}
+	if v.BadRequestError != nil {
+		fields[i] = fmt.Sprintf("BadRequestError: %v", v.BadRequestError)
+		i++
+	}
+	if v.InternalServiceError != nil {
+		fields[i] = fmt.Sprintf("InternalServiceError: %v", v.InternalServiceError)
+		i++
+	}
+	if v.ServiceBusyError != nil {
+		fields[i] = fmt.Sprintf("ServiceBusyError: %v", v.ServiceBusyError)
+		i++
+	}
+	if v.AccessDeniedError != nil {
+		fields[i] = fmt.Sprintf("AccessDeniedError: %v", v.AccessDeniedError)
+		i++
+	}
+
+	return fmt.Sprintf("AdminService_ImportWorkflowExecution_Result{%v}", strings.Join(fields[:i], ", "))
+}
+
+// Equals returns true if all the fields of this AdminService_ImportWorkflowExecution_Result match the
+// provided AdminService_ImportWorkflowExecution_Result.
+//
+// This function performs a deep comparison.
+func (v *AdminService_ImportWorkflowExecution_Result) Equals(rhs *AdminService_ImportWorkflowExecution_Result) bool {
+	if v == nil {
+		return rhs == nil
+	} else if rhs == nil {
+		return false
+	}
+	if !((v.Success == nil && rhs.Success == nil) || (v.Success != nil && rhs.Success != nil && v.Success.Equals(rhs.Success))) {
+		return false
+	}
+	if !((v.BadRequestError == nil && rhs.BadRequestError == nil) || (v.BadRequestError != nil && rhs.BadRequestError != nil && v.BadRequestError.Equals(rhs.BadRequestError))) {
+		return false
+	}
+	if !((v.InternalServiceError == nil && rhs.InternalServiceError == nil) || (v.InternalServiceError != nil && rhs.InternalServiceError != nil && v.InternalServiceError.Equals(rhs.InternalServiceError))) {
+		return false
+	}
+	if !((v.ServiceBusyError == nil && rhs.ServiceBusyError == nil) || (v.ServiceBusyError != nil && rhs.ServiceBusyError != nil && v.ServiceBusyError.Equals(rhs.ServiceBusyError))) {
+		return false
+	}
+	if !((v.AccessDeniedError == nil && rhs.AccessDeniedError == nil) || (v.AccessDeniedError != nil && rhs.AccessDeniedError != nil && v.AccessDeniedError.Equals(rhs.AccessDeniedError))) {
+		return false
+	}
+
+	return true
+}
+
+// MarshalLogObject implements zapcore.ObjectMarshaler, enabling
+// fast logging of AdminService_ImportWorkflowExecution_Result.
+func (v *AdminService_ImportWorkflowExecution_Result) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+	if v == nil {
+		return nil
+	}
+	if v.Success != nil {
+		err = multierr.Append(err, enc.AddObject("success", v.Success))
+	}
+	if v.BadRequestError != nil {
+		err = multierr.Append(err, enc.AddObject("badRequestError", v.BadRequestError))
+	}
+	if v.InternalServiceError != nil {
+		err = multierr.Append(err, enc.AddObject("internalServiceError", v.InternalServiceError))
+	}
+	if v.ServiceBusyError != nil {
+		err = multierr.Append(err, enc.AddObject("serviceBusyError", v.ServiceBusyError))
+	}
+	if v.AccessDeniedError != nil {
+		err = multierr.Append(err, enc.AddObject("accessDeniedError", v.AccessDeniedError))
+	}
+	return err
+}
+
+// GetSuccess returns the value of Success if it is set or its
+// zero value if it is unset.
+func (v *AdminService_ImportWorkflowExecution_Result) GetSuccess() (o *ImportWorkflowExecutionResponse) {
+	if v != nil && v.Success != nil {
+		return v.Success
+	}
+
+	return
+}
+
+// IsSetSuccess returns true if Success is not nil.
+func (v *AdminService_ImportWorkflowExecution_Result) IsSetSuccess() bool {
+	return v != nil && v.Success != nil
+}
+
+// GetBadRequestError returns the value of BadRequestError if it is set or its
+// zero value if it is unset.
+func (v *AdminService_ImportWorkflowExecution_Result) GetBadRequestError() (o *shared.BadRequestError) {
+	if v != nil && v.BadRequestError != nil {
+		return v.BadRequestError
+	}
+
+	return
+}
+
+// IsSetBadRequestError returns true if BadRequestError is not nil.
+func (v *AdminService_ImportWorkflowExecution_Result) IsSetBadRequestError() bool {
+	return v != nil && v.BadRequestError != nil
+}
+
+// GetInternalServiceError returns the value of InternalServiceError if it is set or its
+// zero value if it is unset.
+func (v *AdminService_ImportWorkflowExecution_Result) GetInternalServiceError() (o *shared.InternalServiceError) {
+	if v != nil && v.InternalServiceError != nil {
+		return v.InternalServiceError
+	}
+
+	return
+}
+
+// IsSetInternalServiceError returns true if InternalServiceError is not nil.
+func (v *AdminService_ImportWorkflowExecution_Result) IsSetInternalServiceError() bool {
+	return v != nil && v.InternalServiceError != nil
+}
+
+// GetServiceBusyError returns the value of ServiceBusyError if it is set or its
+// zero value if it is unset.
+func (v *AdminService_ImportWorkflowExecution_Result) GetServiceBusyError() (o *shared.ServiceBusyError) {
+	if v != nil && v.ServiceBusyError != nil {
+		return v.ServiceBusyError
+	}
+
+	return
+}
+
+// IsSetServiceBusyError returns true if ServiceBusyError is not nil.
+func (v *AdminService_ImportWorkflowExecution_Result) IsSetServiceBusyError() bool {
+	return v != nil && v.ServiceBusyError != nil
+}
+
+// GetAccessDeniedError returns the value of AccessDeniedError if it is set or its
+// zero value if it is unset.
+func (v *AdminService_ImportWorkflowExecution_Result) GetAccessDeniedError() (o *shared.AccessDeniedError) {
+	if v != nil && v.AccessDeniedError != nil {
+		return v.AccessDeniedError
+	}
+
+	return
+}
+
+// IsSetAccessDeniedError returns true if AccessDeniedError is not nil.
+func (v *AdminService_ImportWorkflowExecution_Result) IsSetAccessDeniedError() bool {
+	return v != nil && v.AccessDeniedError != nil
+}
+
+// MethodName returns the name of the Thrift function as specified in
+// the IDL, for which this struct represent the result.
+//
+// This will always be "ImportWorkflowExecution" for this struct.
+func (v *AdminService_ImportWorkflowExecution_Result) MethodName() string {
+	return "ImportWorkflowExecution"
+}
+
+// EnvelopeType returns the kind of value inside this struct.
+//
+// This will always be Reply for this struct.
+func (v *AdminService_ImportWorkflowExecution_Result) EnvelopeType() wire.EnvelopeType {
+	return wire.Reply
+}
+
+// AdminService_ListRecentlyClosedExecutions_Args represents the arguments for the AdminService.ListRecentlyClosedExecutions function.
+//
+// The arguments for ListRecentlyClosedExecutions are sent and received over the wire as this struct.
+type AdminService_ListRecentlyClosedExecutions_Args struct {
+	Request *ListRecentlyClosedExecutionsRequest `json:"request,omitempty"`
+}
+
+// ToWire translates a AdminService_ListRecentlyClosedExecutions_Args struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *AdminService_ListRecentlyClosedExecutions_Args) ToWire() (wire.Value, error) {
+	var (
+		fields [1]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.Request != nil {
+		w, err = v.Request.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 1, Value: w}
+		i++
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+func _ListRecentlyClosedExecutionsRequest_Read(w wire.Value) (*ListRecentlyClosedExecutionsRequest, error) {
+	var v ListRecentlyClosedExecutionsRequest
+	err := v.FromWire(w)
+	return &v, err
+}
+
+// FromWire deserializes a AdminService_ListRecentlyClosedExecutions_Args struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a AdminService_ListRecentlyClosedExecutions_Args struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v AdminService_ListRecentlyClosedExecutions_Args
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *AdminService_ListRecentlyClosedExecutions_Args) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 1:
+			if field.Value.Type() == wire.TStruct {
+				v.Request, err = _ListRecentlyClosedExecutionsRequest_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+
+	return nil
+}
+
+// String returns a readable string representation of a AdminService_ListRecentlyClosedExecutions_Args
+// struct.
+func (v *AdminService_ListRecentlyClosedExecutions_Args) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [1]string
+	i := 0
+	if v.Request != nil {
+		fields[i] = fmt.Sprintf("Request: %v", v.Request)
+		i++
+	}
+
+	return fmt.Sprintf("AdminService_ListRecentlyClosedExecutions_Args{%v}", strings.Join(fields[:i], ", "))
+}
+
+// Equals returns true if all the fields of this AdminService_ListRecentlyClosedExecutions_Args match the
+// provided AdminService_ListRecentlyClosedExecutions_Args.
+//
+// This function performs a deep comparison.
+func (v *AdminService_ListRecentlyClosedExecutions_Args) Equals(rhs *AdminService_ListRecentlyClosedExecutions_Args) bool {
+	if v == nil {
+		return rhs == nil
+	} else if rhs == nil {
+		return false
+	}
+	if !((v.Request == nil && rhs.Request == nil) || (v.Request != nil && rhs.Request != nil && v.Request.Equals(rhs.Request))) {
+		return false
+	}
+
+	return true
+}
+
+// MarshalLogObject implements zapcore.ObjectMarshaler, enabling
+// fast logging of AdminService_ListRecentlyClosedExecutions_Args.
+func (v *AdminService_ListRecentlyClosedExecutions_Args) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+	if v == nil {
+		return nil
+	}
+	if v.Request != nil {
+		err = multierr.Append(err, enc.AddObject("request", v.Request))
+	}
+	return err
+}
+
+// GetRequest returns the value of Request if it is set or its
+// zero value if it is unset.
+func (v *AdminService_ListRecentlyClosedExecutions_Args) GetRequest() (o *ListRecentlyClosedExecutionsRequest) {
+	if v != nil && v.Request != nil {
+		return v.Request
+	}
+
+	return
+}
+
+// IsSetRequest returns true if Request is not nil.
+func (v *AdminService_ListRecentlyClosedExecutions_Args) IsSetRequest() bool {
+	return v != nil && v.Request != nil
+}
+
+// MethodName returns the name of the Thrift function as specified in
+// the IDL, for which this struct represent the arguments.
+//
+// This will always be "ListRecentlyClosedExecutions" for this struct.
+func (v *AdminService_ListRecentlyClosedExecutions_Args) MethodName() string {
+	return "ListRecentlyClosedExecutions"
+}
+
+// EnvelopeType returns the kind of value inside this struct.
+//
+// This will always be Call for this struct.
+func (v *AdminService_ListRecentlyClosedExecutions_Args) EnvelopeType() wire.EnvelopeType {
+	return wire.Call
+}
+
+// AdminService_ListRecentlyClosedExecutions_Helper provides functions that aid in handling the
+// parameters and return values of the AdminService.ListRecentlyClosedExecutions
+// function.
+var AdminService_ListRecentlyClosedExecutions_Helper = struct {
+	// Args accepts the parameters of ListRecentlyClosedExecutions in-order and returns
+	// the arguments struct for the function.
+	Args func(
+		request *ListRecentlyClosedExecutionsRequest,
+	) *AdminService_ListRecentlyClosedExecutions_Args
+
+	// IsException returns true if the given error can be thrown
+	// by ListRecentlyClosedExecutions.
+	//
+	// An error can be thrown by ListRecentlyClosedExecutions only if the
+	// corresponding exception type was mentioned in the 'throws'
+	// section for it in the Thrift file.
+	IsException func(error) bool
+
+	// WrapResponse returns the result struct for ListRecentlyClosedExecutions
+	// given its return value and error.
+	//
+	// This allows mapping values and errors returned by
+	// ListRecentlyClosedExecutions into a serializable result struct.
+	// WrapResponse returns a non-nil error if the provided
+	// error cannot be thrown by ListRecentlyClosedExecutions
+	//
+	//   value, err := ListRecentlyClosedExecutions(args)
+	//   result, err := AdminService_ListRecentlyClosedExecutions_Helper.WrapResponse(value, err)
+	//   if err != nil {
+	//     return fmt.Errorf("unexpected error from ListRecentlyClosedExecutions: %v", err)
+	//   }
+	//   serialize(result)
+	WrapResponse func(*ListRecentlyClosedExecutionsResponse, error) (*AdminService_ListRecentlyClosedExecutions_Result, error)
+
+	// UnwrapResponse takes the result struct for ListRecentlyClosedExecutions
+	// and returns the value or error returned by it.
+	//
+	// The error is non-nil only if ListRecentlyClosedExecutions threw an
+	// exception.
+	//
+	//   result := deserialize(bytes)
+	//   value, err := AdminService_ListRecentlyClosedExecutions_Helper.UnwrapResponse(result)
+	UnwrapResponse func(*AdminService_ListRecentlyClosedExecutions_Result) (*ListRecentlyClosedExecutionsResponse, error)
+}{}
+
+func init() {
+	AdminService_ListRecentlyClosedExecutions_Helper.Args = func(
+		request *ListRecentlyClosedExecutionsRequest,
+	) *AdminService_ListRecentlyClosedExecutions_Args {
+		return &AdminService_ListRecentlyClosedExecutions_Args{
+			Request: request,
+		}
+	}
+
+	AdminService_ListRecentlyClosedExecutions_Helper.IsException = func(err error) bool {
+		switch err.(type) {
+		case *shared.BadRequestError:
+			return true
+		case *shared.InternalServiceError:
+			return true
+		case *shared.ServiceBusyError:
+			return true
+		case *shared.AccessDeniedError:
+			return true
+		default:
+			return false
+		}
+	}
+
+	AdminService_ListRecentlyClosedExecutions_Helper.WrapResponse = func(success *ListRecentlyClosedExecutionsResponse, err error) (*AdminService_ListRecentlyClosedExecutions_Result, error) {
+		if err == nil {
+			return &AdminService_ListRecentlyClosedExecutions_Result{Success: success}, nil
+		}
+
+		switch e := err.(type) {
+		case *shared.BadRequestError:
+			if e == nil {
+				return nil, errors.New("WrapResponse received non-nil error type with nil value for AdminService_ListRecentlyClosedExecutions_Result.BadRequestError")
+			}
+			return &AdminService_ListRecentlyClosedExecutions_Result{BadRequestError: e}, nil
+		case *shared.InternalServiceError:
+			if e == nil {
+				return nil, errors.New("WrapResponse received non-nil error type with nil value for AdminService_ListRecentlyClosedExecutions_Result.InternalServiceError")
+			}
+			return &AdminService_ListRecentlyClosedExecutions_Result{InternalServiceError: e}, nil
+		case *shared.ServiceBusyError:
+			if e == nil {
+				return nil, errors.New("WrapResponse received non-nil error type with nil value for AdminService_ListRecentlyClosedExecutions_Result.ServiceBusyError")
+			}
+			return &AdminService_ListRecentlyClosedExecutions_Result{ServiceBusyError: e}, nil
+		case *shared.AccessDeniedError:
+			if e == nil {
+				return nil, errors.New("WrapResponse received non-nil error type with nil value for AdminService_ListRecentlyClosedExecutions_Result.AccessDeniedError")
+			}
+			return &AdminService_ListRecentlyClosedExecutions_Result{AccessDeniedError: e}, nil
+		}
+
+		return nil, err
+	}
+	AdminService_ListRecentlyClosedExecutions_Helper.UnwrapResponse = func(result *AdminService_ListRecentlyClosedExecutions_Result) (success *ListRecentlyClosedExecutionsResponse, err error) {
+		if result.BadRequestError != nil {
+			err = result.BadRequestError
+			return
+		}
+		if result.InternalServiceError != nil {
+			err = result.InternalServiceError
+			return
+		}
+		if result.ServiceBusyError != nil {
+			err = result.ServiceBusyError
+			return
+		}
+		if result.AccessDeniedError != nil {
+			err = result.AccessDeniedError
+			return
+		}
+
+		if result.Success != nil {
+			success = result.Success
+			return
+		}
+
+		err = errors.New("expected a non-void result")
+		return
+	}
+
+}
+
+// AdminService_ListRecentlyClosedExecutions_Result represents the result of a AdminService.ListRecentlyClosedExecutions function call.
+//
+// The result of a ListRecentlyClosedExecutions execution is sent and received over the wire as this struct.
+//
+// Success is set only if the function did not throw an exception.
+type AdminService_ListRecentlyClosedExecutions_Result struct {
+	// Value returned by ListRecentlyClosedExecutions after a successful execution.
+	Success              *ListRecentlyClosedExecutionsResponse `json:"success,omitempty"`
+	BadRequestError      *shared.BadRequestError            `json:"badRequestError,omitempty"`
+	InternalServiceError *shared.InternalServiceError       `json:"internalServiceError,omitempty"`
+	ServiceBusyError     *shared.ServiceBusyError           `json:"serviceBusyError,omitempty"`
+	AccessDeniedError    *shared.AccessDeniedError          `json:"accessDeniedError,omitempty"`
+}
+
+// ToWire translates a AdminService_ListRecentlyClosedExecutions_Result struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *AdminService_ListRecentlyClosedExecutions_Result) ToWire() (wire.Value, error) {
+	var (
+		fields [5]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.Success != nil {
+		w, err = v.Success.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 0, Value: w}
+		i++
+	}
+	if v.BadRequestError != nil {
+		w, err = v.BadRequestError.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 1, Value: w}
+		i++
+	}
+	if v.InternalServiceError != nil {
+		w, err = v.InternalServiceError.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 2, Value: w}
+		i++
+	}
+	if v.ServiceBusyError != nil {
+		w, err = v.ServiceBusyError.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 3, Value: w}
+		i++
+	}
+	if v.AccessDeniedError != nil {
+		w, err = v.AccessDeniedError.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 4, Value: w}
+		i++
+	}
+
+	if i != 1 {
+		return wire.Value{}, fmt.Errorf("AdminService_ListRecentlyClosedExecutions_Result should have exactly one field: got %v fields", i)
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+func _ListRecentlyClosedExecutionsResponse_Read(w wire.Value) (*ListRecentlyClosedExecutionsResponse, error) {
+	var v ListRecentlyClosedExecutionsResponse
+	err := v.FromWire(w)
+	return &v, err
+}
+
+// FromWire deserializes a AdminService_ListRecentlyClosedExecutions_Result struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a AdminService_ListRecentlyClosedExecutions_Result struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v AdminService_ListRecentlyClosedExecutions_Result
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *AdminService_ListRecentlyClosedExecutions_Result) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 0:
+			if field.Value.Type() == wire.TStruct {
+				v.Success, err = _ListRecentlyClosedExecutionsResponse_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 1:
+			if field.Value.Type() == wire.TStruct {
+				v.BadRequestError, err = _BadRequestError_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 2:
+			if field.Value.Type() == wire.TStruct {
+				v.InternalServiceError, err = _InternalServiceError_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 3:
+			if field.Value.Type() == wire.TStruct {
+				v.ServiceBusyError, err = _ServiceBusyError_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 4:
+			if field.Value.Type() == wire.TStruct {
+				v.AccessDeniedError, err = _AccessDeniedError_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+
+	count := 0
+	if v.Success != nil {
+		count++
+	}
+	if v.BadRequestError != nil {
+		count++
+	}
+	if v.InternalServiceError != nil {
+		count++
+	}
+	if v.ServiceBusyError != nil {
+		count++
+	}
+	if v.AccessDeniedError != nil {
+		count++
+	}
+	if count != 1 {
+		return fmt.Errorf("AdminService_ListRecentlyClosedExecutions_Result should have exactly one field: got %v fields", count)
+	}
+
+	return nil
+}
+
+// String returns a readable string representation of a AdminService_ListRecentlyClosedExecutions_Result
+// struct.
+func (v *AdminService_ListRecentlyClosedExecutions_Result) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [5]string
+	i := 0
+	if v.Success != nil {
+		fields[i] = fmt.Sprintf("Success: %v", v.Success)
+		i++
+	}
+	if v.BadRequestError != nil {
+		fields[i] = fmt.Sprintf("BadRequestError: %v", v.BadRequestError)
+		i++
+	}
+	if v.InternalServiceError != nil {
+		fields[i] = fmt.Sprintf("InternalServiceError: %v", v.InternalServiceError)
+		i++
+	}
+	if v.ServiceBusyError != nil {
+		fields[i] = fmt.Sprintf("ServiceBusyError: %v", v.ServiceBusyError)
+		i++
+	}
+	if v.AccessDeniedError != nil {
+		fields[i] = fmt.Sprintf("AccessDeniedError: %v", v.AccessDeniedError)
+		i++
+	}
+
+	return fmt.Sprintf("AdminService_ListRecentlyClosedExecutions_Result{%v}", strings.Join(fields[:i], ", "))
+}
+
+// Equals returns true if all the fields of this AdminService_ListRecentlyClosedExecutions_Result match the
+// provided AdminService_ListRecentlyClosedExecutions_Result.
+//
+// This function performs a deep comparison.
+func (v *AdminService_ListRecentlyClosedExecutions_Result) Equals(rhs *AdminService_ListRecentlyClosedExecutions_Result) bool {
+	if v == nil {
+		return rhs == nil
+	} else if rhs == nil {
+		return false
+	}
+	if !((v.Success == nil && rhs.Success == nil) || (v.Success != nil && rhs.Success != nil && v.Success.Equals(rhs.Success))) {
+		return false
+	}
+	if !((v.BadRequestError == nil && rhs.BadRequestError == nil) || (v.BadRequestError != nil && rhs.BadRequestError != nil && v.BadRequestError.Equals(rhs.BadRequestError))) {
+		return false
+	}
+	if !((v.InternalServiceError == nil && rhs.InternalServiceError == nil) || (v.InternalServiceError != nil && rhs.InternalServiceError != nil && v.InternalServiceError.Equals(rhs.InternalServiceError))) {
+		return false
+	}
+	if !((v.ServiceBusyError == nil && rhs.ServiceBusyError == nil) || (v.ServiceBusyError != nil && rhs.ServiceBusyError != nil && v.ServiceBusyError.Equals(rhs.ServiceBusyError))) {
+		return false
+	}
+	if !((v.AccessDeniedError == nil && rhs.AccessDeniedError == nil) || (v.AccessDeniedError != nil && rhs.AccessDeniedError != nil && v.AccessDeniedError.Equals(rhs.AccessDeniedError))) {
+		return false
+	}
+
+	return true
+}
+
+// MarshalLogObject implements zapcore.ObjectMarshaler, enabling
+// fast logging of AdminService_ListRecentlyClosedExecutions_Result.
+func (v *AdminService_ListRecentlyClosedExecutions_Result) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+	if v == nil {
+		return nil
+	}
+	if v.Success != nil {
+		err = multierr.Append(err, enc.AddObject("success", v.Success))
+	}
+	if v.BadRequestError != nil {
+		err = multierr.Append(err, enc.AddObject("badRequestError", v.BadRequestError))
+	}
+	if v.InternalServiceError != nil {
+		err = multierr.Append(err, enc.AddObject("internalServiceError", v.InternalServiceError))
+	}
+	if v.ServiceBusyError != nil {
+		err = multierr.Append(err, enc.AddObject("serviceBusyError", v.ServiceBusyError))
+	}
+	if v.AccessDeniedError != nil {
+		err = multierr.Append(err, enc.AddObject("accessDeniedError", v.AccessDeniedError))
+	}
+	return err
+}
+
+// GetSuccess returns the value of Success if it is set or its
+// zero value if it is unset.
+func (v *AdminService_ListRecentlyClosedExecutions_Result) GetSuccess() (o *ListRecentlyClosedExecutionsResponse) {
+	if v != nil && v.Success != nil {
+		return v.Success
+	}
+
+	return
+}
+
+// IsSetSuccess returns true if Success is not nil.
+func (v *AdminService_ListRecentlyClosedExecutions_Result) IsSetSuccess() bool {
+	return v != nil && v.Success != nil
+}
+
+// GetBadRequestError returns the value of BadRequestError if it is set or its
+// zero value if it is unset.
+func (v *AdminService_ListRecentlyClosedExecutions_Result) GetBadRequestError() (o *shared.BadRequestError) {
+	if v != nil && v.BadRequestError != nil {
+		return v.BadRequestError
+	}
+
+	return
+}
+
+// IsSetBadRequestError returns true if BadRequestError is not nil.
+func (v *AdminService_ListRecentlyClosedExecutions_Result) IsSetBadRequestError() bool {
+	return v != nil && v.BadRequestError != nil
+}
+
+// GetInternalServiceError returns the value of InternalServiceError if it is set or its
+// zero value if it is unset.
+func (v *AdminService_ListRecentlyClosedExecutions_Result) GetInternalServiceError() (o *shared.InternalServiceError) {
+	if v != nil && v.InternalServiceError != nil {
+		return v.InternalServiceError
+	}
+
+	return
+}
+
+// IsSetInternalServiceError returns true if InternalServiceError is not nil.
+func (v *AdminService_ListRecentlyClosedExecutions_Result) IsSetInternalServiceError() bool {
+	return v != nil && v.InternalServiceError != nil
+}
+
+// GetServiceBusyError returns the value of ServiceBusyError if it is set or its
+// zero value if it is unset.
+func (v *AdminService_ListRecentlyClosedExecutions_Result) GetServiceBusyError() (o *shared.ServiceBusyError) {
+	if v != nil && v.ServiceBusyError != nil {
+		return v.ServiceBusyError
+	}
+
+	return
+}
+
+// IsSetServiceBusyError returns true if ServiceBusyError is not nil.
+func (v *AdminService_ListRecentlyClosedExecutions_Result) IsSetServiceBusyError() bool {
+	return v != nil && v.ServiceBusyError != nil
+}
+
+// GetAccessDeniedError returns the value of AccessDeniedError if it is set or its
+// zero value if it is unset.
+func (v *AdminService_ListRecentlyClosedExecutions_Result) GetAccessDeniedError() (o *shared.AccessDeniedError) {
+	if v != nil && v.AccessDeniedError != nil {
+		return v.AccessDeniedError
+	}
+
+	return
+}
+
+// IsSetAccessDeniedError returns true if AccessDeniedError is not nil.
+func (v *AdminService_ListRecentlyClosedExecutions_Result) IsSetAccessDeniedError() bool {
+	return v != nil && v.AccessDeniedError != nil
+}
+
+// MethodName returns the name of the Thrift function as specified in
+// the IDL, for which this struct represent the result.
+//
+// This will always be "ListRecentlyClosedExecutions" for this struct.
+func (v *AdminService_ListRecentlyClosedExecutions_Result) MethodName() string {
+	return "ListRecentlyClosedExecutions"
+}
+
+// EnvelopeType returns the kind of value inside this struct.
+//
+// This will always be Reply for this struct.
+func (v *AdminService_ListRecentlyClosedExecutions_Result) EnvelopeType() wire.EnvelopeType {
+	return wire.Reply
+}
+
+// AdminService_ListSchedules_Args represents the arguments for the AdminService.ListSchedules function.
+//
+// The arguments for ListSchedules are sent and received over the wire as this struct.
+type AdminService_ListSchedules_Args struct {
+	Request *shared.ListSchedulesRequest `json:"request,omitempty"`
+}
+
+// ToWire translates a AdminService_ListSchedules_Args struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *AdminService_ListSchedules_Args) ToWire() (wire.Value, error) {
+	var (
+		fields [1]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.Request != nil {
+		w, err = v.Request.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 1, Value: w}
+		i++
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+func _ListSchedulesRequest_Read(w wire.Value) (*shared.ListSchedulesRequest, error) {
+	var v shared.ListSchedulesRequest
+	err := v.FromWire(w)
+	return &v, err
+}
+
+// FromWire deserializes a AdminService_ListSchedules_Args struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a AdminService_ListSchedules_Args struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v AdminService_ListSchedules_Args
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *AdminService_ListSchedules_Args) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 1:
+			if field.Value.Type() == wire.TStruct {
+				v.Request, err = _ListSchedulesRequest_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+
+	return nil
+}
+
+// String returns a readable string representation of a AdminService_ListSchedules_Args
+// struct.
+func (v *AdminService_ListSchedules_Args) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [1]string
+	i := 0
+	if v.Request != nil {
+		fields[i] = fmt.Sprintf("Request: %v", v.Request)
+		i++
+	}
+
+	return fmt.Sprintf("AdminService_ListSchedules_Args{%v}", strings.Join(fields[:i], ", "))
+}
+
+// Equals returns true if all the fields of this AdminService_ListSchedules_Args match the
+// provided AdminService_ListSchedules_Args.
+//
+// This function performs a deep comparison.
+func (v *AdminService_ListSchedules_Args) Equals(rhs *AdminService_ListSchedules_Args) bool {
+	if v == nil {
+		return rhs == nil
+	} else if rhs == nil {
+		return false
+	}
+	if !((v.Request == nil && rhs.Request == nil) || (v.Request != nil && rhs.Request != nil && v.Request.Equals(rhs.Request))) {
+		return false
+	}
+
+	return true
+}
+
+// MarshalLogObject implements zapcore.ObjectMarshaler, enabling
+// fast logging of AdminService_ListSchedules_Args.
+func (v *AdminService_ListSchedules_Args) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+	if v == nil {
+		return nil
+	}
+	if v.Request != nil {
+		err = multierr.Append(err, enc.AddObject("request", v.Request))
+	}
+	return err
+}
+
+// GetRequest returns the value of Request if it is set or its
+// zero value if it is unset.
+func (v *AdminService_ListSchedules_Args) GetRequest() (o *shared.ListSchedulesRequest) {
+	if v != nil && v.Request != nil {
+		return v.Request
+	}
+
+	return
+}
+
+// IsSetRequest returns true if Request is not nil.
+func (v *AdminService_ListSchedules_Args) IsSetRequest() bool {
+	return v != nil && v.Request != nil
+}
+
+// MethodName returns the name of the Thrift function as specified in
+// the IDL, for which this struct represent the arguments.
+//
+// This will always be "ListSchedules" for this struct.
+func (v *AdminService_ListSchedules_Args) MethodName() string {
+	return "ListSchedules"
+}
+
+// EnvelopeType returns the kind of value inside this struct.
+//
+// This will always be Call for this struct.
+func (v *AdminService_ListSchedules_Args) EnvelopeType() wire.EnvelopeType {
+	return wire.Call
+}
+
+// AdminService_ListSchedules_Helper provides functions that aid in handling the
+// parameters and return values of the AdminService.ListSchedules
+// function.
+var AdminService_ListSchedules_Helper = struct {
+	// Args accepts the parameters of ListSchedules in-order and returns
+	// the arguments struct for the function.
+	Args func(
+		request *shared.ListSchedulesRequest,
+	) *AdminService_ListSchedules_Args
+
+	// IsException returns true if the given error can be thrown
+	// by ListSchedules.
+	//
+	// An error can be thrown by ListSchedules only if the
+	// corresponding exception type was mentioned in the 'throws'
+	// section for it in the Thrift file.
+	IsException func(error) bool
+
+	// WrapResponse returns the result struct for ListSchedules
+	// given its return value and error.
+	//
+	// This allows mapping values and errors returned by
+	// ListSchedules into a serializable result struct.
+	// WrapResponse returns a non-nil error if the provided
+	// error cannot be thrown by ListSchedules
+	//
+	//   value, err := ListSchedules(args)
+	//   result, err := AdminService_ListSchedules_Helper.WrapResponse(value, err)
+	//   if err != nil {
+	//     return fmt.Errorf("unexpected error from ListSchedules: %v", err)
+	//   }
+	//   serialize(result)
+	WrapResponse func(*shared.ListSchedulesResponse, error) (*AdminService_ListSchedules_Result, error)
+
+	// UnwrapResponse takes the result struct for ListSchedules
+	// and returns the value or error returned by it.
+	//
+	// The error is non-nil only if ListSchedules threw an
+	// exception.
+	//
+	//   result := deserialize(bytes)
+	//   value, err := AdminService_ListSchedules_Helper.UnwrapResponse(result)
+	UnwrapResponse func(*AdminService_ListSchedules_Result) (*shared.ListSchedulesResponse, error)
+}{}
+
+func init() {
+	AdminService_ListSchedules_Helper.Args = func(
+		request *shared.ListSchedulesRequest,
+	) *AdminService_ListSchedules_Args {
+		return &AdminService_ListSchedules_Args{
+			Request: request,
+		}
+	}
+
+	AdminService_ListSchedules_Helper.IsException = func(err error) bool {
+		switch err.(type) {
+		case *shared.BadRequestError:
+			return true
+		case *shared.InternalServiceError:
+			return true
+		case *shared.EntityNotExistsError:
+			return true
+		case *shared.AccessDeniedError:
+			return true
+		default:
+			return false
+		}
+	}
+
+	AdminService_ListSchedules_Helper.WrapResponse = func(success *shared.ListSchedulesResponse, err error) (*AdminService_ListSchedules_Result, error) {
+		if err == nil {
+			return &AdminService_ListSchedules_Result{Success: success}, nil
+		}
+
+		switch e := err.(type) {
+		case *shared.BadRequestError:
+			if e == nil {
+				return nil, errors.New("WrapResponse received non-nil error type with nil value for AdminService_ListSchedules_Result.BadRequestError")
+			}
+			return &AdminService_ListSchedules_Result{BadRequestError: e}, nil
+		case *shared.InternalServiceError:
+			if e == nil {
+				return nil, errors.New("WrapResponse received non-nil error type with nil value for AdminService_ListSchedules_Result.InternalServiceError")
+			}
+			return &AdminService_ListSchedules_Result{InternalServiceError: e}, nil
+		case *shared.EntityNotExistsError:
+			if e == nil {
+				return nil, errors.New("WrapResponse received non-nil error type with nil value for AdminService_ListSchedules_Result.EntityNotExistError")
+			}
+			return &AdminService_ListSchedules_Result{EntityNotExistError: e}, nil
+		case *shared.AccessDeniedError:
+			if e == nil {
+				return nil, errors.New("WrapResponse received non-nil error type with nil value for AdminService_ListSchedules_Result.AccessDeniedError")
+			}
+			return &AdminService_ListSchedules_Result{AccessDeniedError: e}, nil
+		}
+
+		return nil, err
+	}
+	AdminService_ListSchedules_Helper.UnwrapResponse = func(result *AdminService_ListSchedules_Result) (success *shared.ListSchedulesResponse, err error) {
+		if result.BadRequestError != nil {
+			err = result.BadRequestError
+			return
+		}
+		if result.InternalServiceError != nil {
+			err = result.InternalServiceError
+			return
+		}
+		if result.EntityNotExistError != nil {
+			err = result.EntityNotExistError
+			return
+		}
+		if result.AccessDeniedError != nil {
+			err = result.AccessDeniedError
+			return
+		}
+
+		if result.Success != nil {
+			success = result.Success
+			return
+		}
+
+		err = errors.New("expected a non-void result")
+		return
+	}
+
+}
+
+// AdminService_ListSchedules_Result represents the result of a AdminService.ListSchedules function call.
+//
+// The result of a ListSchedules execution is sent and received over the wire as this struct.
+//
+// Success is set only if the function did not throw an exception.
+type AdminService_ListSchedules_Result struct {
+	// Value returned by ListSchedules after a successful execution.
+	Success              *shared.ListSchedulesResponse `json:"success,omitempty"`
+	BadRequestError      *shared.BadRequestError       `json:"badRequestError,omitempty"`
+	InternalServiceError *shared.InternalServiceError  `json:"internalServiceError,omitempty"`
+	EntityNotExistError  *shared.EntityNotExistsError  `json:"entityNotExistError,omitempty"`
+	AccessDeniedError    *shared.AccessDeniedError     `json:"accessDeniedError,omitempty"`
+}
+
+// ToWire translates a AdminService_ListSchedules_Result struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *AdminService_ListSchedules_Result) ToWire() (wire.Value, error) {
+	var (
+		fields [5]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.Success != nil {
+		w, err = v.Success.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 0, Value: w}
+		i++
+	}
+	if v.BadRequestError != nil {
+		w, err = v.BadRequestError.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 1, Value: w}
+		i++
+	}
+	if v.InternalServiceError != nil {
+		w, err = v.InternalServiceError.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 2, Value: w}
+		i++
+	}
+	if v.EntityNotExistError != nil {
+		w, err = v.EntityNotExistError.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 3, Value: w}
+		i++
+	}
+	if v.AccessDeniedError != nil {
+		w, err = v.AccessDeniedError.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 4, Value: w}
+		i++
+	}
+
+	if i != 1 {
+		return wire.Value{}, fmt.Errorf("AdminService_ListSchedules_Result should have exactly one field: got %v fields", i)
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+func _ListSchedulesResponse_Read(w wire.Value) (*shared.ListSchedulesResponse, error) {
+	var v shared.ListSchedulesResponse
+	err := v.FromWire(w)
+	return &v, err
+}
+
+// FromWire deserializes a AdminService_ListSchedules_Result struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a AdminService_ListSchedules_Result struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v AdminService_ListSchedules_Result
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *AdminService_ListSchedules_Result) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 0:
+			if field.Value.Type() == wire.TStruct {
+				v.Success, err = _ListSchedulesResponse_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 1:
+			if field.Value.Type() == wire.TStruct {
+				v.BadRequestError, err = _BadRequestError_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 2:
+			if field.Value.Type() == wire.TStruct {
+				v.InternalServiceError, err = _InternalServiceError_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 3:
+			if field.Value.Type() == wire.TStruct {
+				v.EntityNotExistError, err = _EntityNotExistsError_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 4:
+			if field.Value.Type() == wire.TStruct {
+				v.AccessDeniedError, err = _AccessDeniedError_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+
+	count := 0
+	if v.Success != nil {
+		count++
+	}
+	if v.BadRequestError != nil {
+		count++
+	}
+	if v.InternalServiceError != nil {
+		count++
+	}
+	if v.EntityNotExistError != nil {
+		count++
+	}
+	if v.AccessDeniedError != nil {
+		count++
+	}
+	if count != 1 {
+		return fmt.Errorf("AdminService_ListSchedules_Result should have exactly one field: got %v fields", count)
+	}
+
+	return nil
+}
+
+// String returns a readable string representation of a AdminService_ListSchedules_Result
+// struct.
+func (v *AdminService_ListSchedules_Result) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [5]string
+	i := 0
+	if v.Success != nil {
+		fields[i] = fmt.Sprintf("Success: %v", v.Success)
+		i++
+	}
+	if v.BadRequestError != nil {
+		fields[i] = fmt.Sprintf("BadRequestError: %v", v.BadRequestError)
+		i++
+	}
+	if v.InternalServiceError != nil {
+		fields[i] = fmt.Sprintf("InternalServiceError: %v", v.InternalServiceError)
+		i++
+	}
+	if v.EntityNotExistError != nil {
+		fields[i] = fmt.Sprintf("EntityNotExistError: %v", v.EntityNotExistError)
+		i++
+	}
+	if v.AccessDeniedError != nil {
+		fields[i] = fmt.Sprintf("AccessDeniedError: %v", v.AccessDeniedError)
+		i++
+	}
+
+	return fmt.Sprintf("AdminService_ListSchedules_Result{%v}", strings.Join(fields[:i], ", "))
+}
+
+// Equals returns true if all the fields of this AdminService_ListSchedules_Result match the
+// provided AdminService_ListSchedules_Result.
+//
+// This function performs a deep comparison.
+func (v *AdminService_ListSchedules_Result) Equals(rhs *AdminService_ListSchedules_Result) bool {
+	if v == nil {
+		return rhs == nil
+	} else if rhs == nil {
+		return false
+	}
+	if !((v.Success == nil && rhs.Success == nil) || (v.Success != nil && rhs.Success != nil && v.Success.Equals(rhs.Success))) {
+		return false
+	}
+	if !((v.BadRequestError == nil && rhs.BadRequestError == nil) || (v.BadRequestError != nil && rhs.BadRequestError != nil && v.BadRequestError.Equals(rhs.BadRequestError))) {
+		return false
+	}
+	if !((v.InternalServiceError == nil && rhs.InternalServiceError == nil) || (v.InternalServiceError != nil && rhs.InternalServiceError != nil && v.InternalServiceError.Equals(rhs.InternalServiceError))) {
+		return false
+	}
+	if !((v.EntityNotExistError == nil && rhs.EntityNotExistError == nil) || (v.EntityNotExistError != nil && rhs.EntityNotExistError != nil && v.EntityNotExistError.Equals(rhs.EntityNotExistError))) {
+		return false
+	}
+	if !((v.AccessDeniedError == nil && rhs.AccessDeniedError == nil) || (v.AccessDeniedError != nil && rhs.AccessDeniedError != nil && v.AccessDeniedError.Equals(rhs.AccessDeniedError))) {
+		return false
+	}
+
+	return true
+}
+
+// MarshalLogObject implements zapcore.ObjectMarshaler, enabling
+// fast logging of AdminService_ListSchedules_Result.
+func (v *AdminService_ListSchedules_Result) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+	if v == nil {
+		return nil
+	}
+	if v.Success != nil {
+		err = multierr.Append(err, enc.AddObject("success", v.Success))
+	}
+	if v.BadRequestError != nil {
+		err = multierr.Append(err, enc.AddObject("badRequestError", v.BadRequestError))
+	}
+	if v.InternalServiceError != nil {
+		err = multierr.Append(err, enc.AddObject("internalServiceError", v.InternalServiceError))
+	}
+	if v.EntityNotExistError != nil {
+		err = multierr.Append(err, enc.AddObject("entityNotExistError", v.EntityNotExistError))
+	}
+	if v.AccessDeniedError != nil {
+		err = multierr.Append(err, enc.AddObject("accessDeniedError", v.AccessDeniedError))
+	}
+	return err
+}
+
+// GetSuccess returns the value of Success if it is set or its
+// zero value if it is unset.
+func (v *AdminService_ListSchedules_Result) GetSuccess() (o *shared.ListSchedulesResponse) {
+	if v != nil && v.Success != nil {
+		return v.Success
+	}
+
+	return
+}
+
+// IsSetSuccess returns true if Success is not nil.
+func (v *AdminService_ListSchedules_Result) IsSetSuccess() bool {
+	return v != nil && v.Success != nil
+}
+
+// GetBadRequestError returns the value of BadRequestError if it is set or its
+// zero value if it is unset.
+func (v *AdminService_ListSchedules_Result) GetBadRequestError() (o *shared.BadRequestError) {
+	if v != nil && v.BadRequestError != nil {
+		return v.BadRequestError
+	}
+
+	return
+}
+
+// IsSetBadRequestError returns true if BadRequestError is not nil.
+func (v *AdminService_ListSchedules_Result) IsSetBadRequestError() bool {
+	return v != nil && v.BadRequestError != nil
+}
+
+// GetInternalServiceError returns the value of InternalServiceError if it is set or its
+// zero value if it is unset.
+func (v *AdminService_ListSchedules_Result) GetInternalServiceError() (o *shared.InternalServiceError) {
+	if v != nil && v.InternalServiceError != nil {
+		return v.InternalServiceError
+	}
+
+	return
+}
+
+// IsSetInternalServiceError returns true if InternalServiceError is not nil.
+func (v *AdminService_ListSchedules_Result) IsSetInternalServiceError() bool {
+	return v != nil && v.InternalServiceError != nil
+}
+
+// GetEntityNotExistError returns the value of EntityNotExistError if it is set or its
+// zero value if it is unset.
+func (v *AdminService_ListSchedules_Result) GetEntityNotExistError() (o *shared.EntityNotExistsError) {
+	if v != nil && v.EntityNotExistError != nil {
+		return v.EntityNotExistError
+	}
+
+	return
+}
+
+// IsSetEntityNotExistError returns true if EntityNotExistError is not nil.
+func (v *AdminService_ListSchedules_Result) IsSetEntityNotExistError() bool {
+	return v != nil && v.EntityNotExistError != nil
+}
+
+// GetAccessDeniedError returns the value of AccessDeniedError if it is set or its
+// zero value if it is unset.
+func (v *AdminService_ListSchedules_Result) GetAccessDeniedError() (o *shared.AccessDeniedError) {
+	if v != nil && v.AccessDeniedError != nil {
+		return v.AccessDeniedError
+	}
+
+	return
+}
+
+// IsSetAccessDeniedError returns true if AccessDeniedError is not nil.
+func (v *AdminService_ListSchedules_Result) IsSetAccessDeniedError() bool {
+	return v != nil && v.AccessDeniedError != nil
+}
+
+// MethodName returns the name of the Thrift function as specified in
+// the IDL, for which this struct represent the result.
+//
+// This will always be "ListSchedules" for this struct.
+func (v *AdminService_ListSchedules_Result) MethodName() string {
+	return "ListSchedules"
+}
+
+// EnvelopeType returns the kind of value inside this struct.
+//
+// This will always be Reply for this struct.
+func (v *AdminService_ListSchedules_Result) EnvelopeType() wire.EnvelopeType {
+	return wire.Reply
+}
+
+// AdminService_ListWorkflowExecutionRuns_Args represents the arguments for the AdminService.ListWorkflowExecutionRuns function.
+//
+// The arguments for ListWorkflowExecutionRuns are sent and received over the wire as this struct.
+type AdminService_ListWorkflowExecutionRuns_Args struct {
+	Request *ListWorkflowExecutionRunsRequest `json:"request,omitempty"`
+}
+
+// ToWire translates a AdminService_ListWorkflowExecutionRuns_Args struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *AdminService_ListWorkflowExecutionRuns_Args) ToWire() (wire.Value, error) {
+	var (
+		fields [1]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.Request != nil {
+		w, err = v.Request.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 1, Value: w}
+		i++
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+func _ListWorkflowExecutionRunsRequest_Read(w wire.Value) (*ListWorkflowExecutionRunsRequest, error) {
+	var v ListWorkflowExecutionRunsRequest
+	err := v.FromWire(w)
+	return &v, err
+}
+
+// FromWire deserializes a AdminService_ListWorkflowExecutionRuns_Args struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a AdminService_ListWorkflowExecutionRuns_Args struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v AdminService_ListWorkflowExecutionRuns_Args
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *AdminService_ListWorkflowExecutionRuns_Args) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 1:
+			if field.Value.Type() == wire.TStruct {
+				v.Request, err = _ListWorkflowExecutionRunsRequest_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+
+	return nil
+}
+
+// String returns a readable string representation of a AdminService_ListWorkflowExecutionRuns_Args
+// struct.
+func (v *AdminService_ListWorkflowExecutionRuns_Args) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [1]string
+	i := 0
+	if v.Request != nil {
+		fields[i] = fmt.Sprintf("Request: %v", v.Request)
+		i++
+	}
+
+	return fmt.Sprintf("AdminService_ListWorkflowExecutionRuns_Args{%v}", strings.Join(fields[:i], ", "))
+}
+
+// Equals returns true if all the fields of this AdminService_ListWorkflowExecutionRuns_Args match the
+// provided AdminService_ListWorkflowExecutionRuns_Args.
+//
+// This function performs a deep comparison.
+func (v *AdminService_ListWorkflowExecutionRuns_Args) Equals(rhs *AdminService_ListWorkflowExecutionRuns_Args) bool {
+	if v == nil {
+		return rhs == nil
+	} else if rhs == nil {
+		return false
+	}
+	if !((v.Request == nil && rhs.Request == nil) || (v.Request != nil && rhs.Request != nil && v.Request.Equals(rhs.Request))) {
+		return false
+	}
+
+	return true
+}
+
+// MarshalLogObject implements zapcore.ObjectMarshaler, enabling
+// fast logging of AdminService_ListWorkflowExecutionRuns_Args.
+func (v *AdminService_ListWorkflowExecutionRuns_Args) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+	if v == nil {
+		return nil
+	}
+	if v.Request != nil {
+		err = multierr.Append(err, enc.AddObject("request", v.Request))
+	}
+	return err
+}
+
+// GetRequest returns the value of Request if it is set or its
+// zero value if it is unset.
+func (v *AdminService_ListWorkflowExecutionRuns_Args) GetRequest() (o *ListWorkflowExecutionRunsRequest) {
+	if v != nil && v.Request != nil {
+		return v.Request
+	}
+
+	return
+}
+
+// IsSetRequest returns true if Request is not nil.
+func (v *AdminService_ListWorkflowExecutionRuns_Args) IsSetRequest() bool {
+	return v != nil && v.Request != nil
+}
+
+// MethodName returns the name of the Thrift function as specified in
+// the IDL, for which this struct represent the arguments.
+//
+// This will always be "ListWorkflowExecutionRuns" for this struct.
+func (v *AdminService_ListWorkflowExecutionRuns_Args) MethodName() string {
+	return "ListWorkflowExecutionRuns"
+}
+
+// EnvelopeType returns the kind of value inside this struct.
+//
+// This will always be Call for this struct.
+func (v *AdminService_ListWorkflowExecutionRuns_Args) EnvelopeType() wire.EnvelopeType {
+	return wire.Call
+}
+
+// AdminService_ListWorkflowExecutionRuns_Helper provides functions that aid in handling the
+// parameters and return values of the AdminService.ListWorkflowExecutionRuns
+// function.
+var AdminService_ListWorkflowExecutionRuns_Helper = struct {
+	// Args accepts the parameters of ListWorkflowExecutionRuns in-order and returns
+	// the arguments struct for the function.
+	Args func(
+		request *ListWorkflowExecutionRunsRequest,
+	) *AdminService_ListWorkflowExecutionRuns_Args
+
+	// IsException returns true if the given error can be thrown
+	// by ListWorkflowExecutionRuns.
+	//
+	// An error can be thrown by ListWorkflowExecutionRuns only if the
+	// corresponding exception type was mentioned in the 'throws'
+	// section for it in the Thrift file.
+	IsException func(error) bool
+
+	// WrapResponse returns the result struct for ListWorkflowExecutionRuns
+	// given its return value and error.
+	//
+	// This allows mapping values and errors returned by
+	// ListWorkflowExecutionRuns into a serializable result struct.
+	// WrapResponse returns a non-nil error if the provided
+	// error cannot be thrown by ListWorkflowExecutionRuns
+	//
+	//   value, err := ListWorkflowExecutionRuns(args)
+	//   result, err := AdminService_ListWorkflowExecutionRuns_Helper.WrapResponse(value, err)
+	//   if err != nil {
+	//     return fmt.Errorf("unexpected error from ListWorkflowExecutionRuns: %v", err)
+	//   }
+	//   serialize(result)
+	WrapResponse func(*ListWorkflowExecutionRunsResponse, error) (*AdminService_ListWorkflowExecutionRuns_Result, error)
+
+	// UnwrapResponse takes the result struct for ListWorkflowExecutionRuns
+	// and returns the value or error returned by it.
+	//
+	// The error is non-nil only if ListWorkflowExecutionRuns threw an
+	// exception.
+	//
+	//   result := deserialize(bytes)
+	//   value, err := AdminService_ListWorkflowExecutionRuns_Helper.UnwrapResponse(result)
+	UnwrapResponse func(*AdminService_ListWorkflowExecutionRuns_Result) (*ListWorkflowExecutionRunsResponse, error)
+}{}
+
+func init() {
+	AdminService_ListWorkflowExecutionRuns_Helper.Args = func(
+		request *ListWorkflowExecutionRunsRequest,
+	) *AdminService_ListWorkflowExecutionRuns_Args {
+		return &AdminService_ListWorkflowExecutionRuns_Args{
+			Request: request,
+		}
+	}
+
+	AdminService_ListWorkflowExecutionRuns_Helper.IsException = func(err error) bool {
+		switch err.(type) {
+		case *shared.BadRequestError:
+			return true
+		case *shared.InternalServiceError:
+			return true
+		case *shared.ServiceBusyError:
+			return true
+		case *shared.AccessDeniedError:
+			return true
+		default:
+			return false
+		}
+	}
+
+	AdminService_ListWorkflowExecutionRuns_Helper.WrapResponse = func(success *ListWorkflowExecutionRunsResponse, err error) (*AdminService_ListWorkflowExecutionRuns_Result, error) {
+		if err == nil {
+			return &AdminService_ListWorkflowExecutionRuns_Result{Success: success}, nil
+		}
+
+		switch e := err.(type) {
+		case *shared.BadRequestError:
+			if e == nil {
+				return nil, errors.New("WrapResponse received non-nil error type with nil value for AdminService_ListWorkflowExecutionRuns_Result.BadRequestError")
+			}
+			return &AdminService_ListWorkflowExecutionRuns_Result{BadRequestError: e}, nil
+		case *shared.InternalServiceError:
+			if e == nil {
+				return nil, errors.New("WrapResponse received non-nil error type with nil value for AdminService_ListWorkflowExecutionRuns_Result.InternalServiceError")
+			}
+			return &AdminService_ListWorkflowExecutionRuns_Result{InternalServiceError: e}, nil
+		case *shared.ServiceBusyError:
+			if e == nil {
+				return nil, errors.New("WrapResponse received non-nil error type with nil value for AdminService_ListWorkflowExecutionRuns_Result.ServiceBusyError")
+			}
+			return &AdminService_ListWorkflowExecutionRuns_Result{ServiceBusyError: e}, nil
+		case *shared.AccessDeniedError:
+			if e == nil {
+				return nil, errors.New("WrapResponse received non-nil error type with nil value for AdminService_ListWorkflowExecutionRuns_Result.AccessDeniedError")
+			}
+			return &AdminService_ListWorkflowExecutionRuns_Result{AccessDeniedError: e}, nil
+		}
+
+		return nil, err
+	}
+	AdminService_ListWorkflowExecutionRuns_Helper.UnwrapResponse = func(result *AdminService_ListWorkflowExecutionRuns_Result) (success *ListWorkflowExecutionRunsResponse, err error) {
+		if result.BadRequestError != nil {
+			err = result.BadRequestError
+			return
+		}
+		if result.InternalServiceError != nil {
+			err = result.InternalServiceError
+			return
+		}
+		if result.ServiceBusyError != nil {
+			err = result.ServiceBusyError
+			return
+		}
+		if result.AccessDeniedError != nil {
+			err = result.AccessDeniedError
+			return
+		}
+
+		if result.Success != nil {
+			success = result.Success
+			return
+		}
+
+		err = errors.New("expected a non-void result")
+		return
+	}
+
+}
+
+// AdminService_ListWorkflowExecutionRuns_Result represents the result of a AdminService.ListWorkflowExecutionRuns function call.
+//
+// The result of a ListWorkflowExecutionRuns execution is sent and received over the wire as this struct.
+//
+// Success is set only if the function did not throw an exception.
+type AdminService_ListWorkflowExecutionRuns_Result struct {
+	// Value returned by ListWorkflowExecutionRuns after a successful execution.
+	Success              *ListWorkflowExecutionRunsResponse `json:"success,omitempty"`
+	BadRequestError      *shared.BadRequestError            `json:"badRequestError,omitempty"`
+	InternalServiceError *shared.InternalServiceError       `json:"internalServiceError,omitempty"`
+	ServiceBusyError     *shared.ServiceBusyError           `json:"serviceBusyError,omitempty"`
+	AccessDeniedError    *shared.AccessDeniedError          `json:"accessDeniedError,omitempty"`
+}
+
+// ToWire translates a AdminService_ListWorkflowExecutionRuns_Result struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *AdminService_ListWorkflowExecutionRuns_Result) ToWire() (wire.Value, error) {
+	var (
+		fields [5]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.Success != nil {
+		w, err = v.Success.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 0, Value: w}
+		i++
+	}
+	if v.BadRequestError != nil {
+		w, err = v.BadRequestError.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 1, Value: w}
+		i++
+	}
+	if v.InternalServiceError != nil {
+		w, err = v.InternalServiceError.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 2, Value: w}
+		i++
+	}
+	if v.ServiceBusyError != nil {
+		w, err = v.ServiceBusyError.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 3, Value: w}
+		i++
+	}
+	if v.AccessDeniedError != nil {
+		w, err = v.AccessDeniedError.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 4, Value: w}
+		i++
+	}
+
+	if i != 1 {
+		return wire.Value{}, fmt.Errorf("AdminService_ListWorkflowExecutionRuns_Result should have exactly one field: got %v fields", i)
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+func _ListWorkflowExecutionRunsResponse_Read(w wire.Value) (*ListWorkflowExecutionRunsResponse, error) {
+	var v ListWorkflowExecutionRunsResponse
+	err := v.FromWire(w)
+	return &v, err
+}
+
+// FromWire deserializes a AdminService_ListWorkflowExecutionRuns_Result struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a AdminService_ListWorkflowExecutionRuns_Result struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v AdminService_ListWorkflowExecutionRuns_Result
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *AdminService_ListWorkflowExecutionRuns_Result) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 0:
+			if field.Value.Type() == wire.TStruct {
+				v.Success, err = _ListWorkflowExecutionRunsResponse_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 1:
+			if field.Value.Type() == wire.TStruct {
+				v.BadRequestError, err = _BadRequestError_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 2:
+			if field.Value.Type() == wire.TStruct {
+				v.InternalServiceError, err = _InternalServiceError_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 3:
+			if field.Value.Type() == wire.TStruct {
+				v.ServiceBusyError, err = _ServiceBusyError_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 4:
+			if field.Value.Type() == wire.TStruct {
+				v.AccessDeniedError, err = _AccessDeniedError_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+
+	count := 0
+	if v.Success != nil {
+		count++
+	}
+	if v.BadRequestError != nil {
+		count++
+	}
+	if v.InternalServiceError != nil {
+		count++
+	}
+	if v.ServiceBusyError != nil {
+		count++
+	}
+	if v.AccessDeniedError != nil {
+		count++
+	}
+	if count != 1 {
+		return fmt.Errorf("AdminService_ListWorkflowExecutionRuns_Result should have exactly one field: got %v fields", count)
+	}
+
+	return nil
+}
+
+// String returns a readable string representation of a AdminService_ListWorkflowExecutionRuns_Result
+// struct.
+func (v *AdminService_ListWorkflowExecutionRuns_Result) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [5]string
+	i := 0
+	if v.Success != nil {
+		fields[i] = fmt.Sprintf("Success: %v", v.Success)
+		i++
+	}
+	if v.BadRequestError != nil {
+		fields[i] = fmt.Sprintf("BadRequestError: %v", v.BadRequestError)
+		i++
+	}
+	if v.InternalServiceError != nil {
+		fields[i] = fmt.Sprintf("InternalServiceError: %v", v.InternalServiceError)
+		i++
+	}
+	if v.ServiceBusyError != nil {
+		fields[i] = fmt.Sprintf("ServiceBusyError: %v", v.ServiceBusyError)
+		i++
+	}
+	if v.AccessDeniedError != nil {
+		fields[i] = fmt.Sprintf("AccessDeniedError: %v", v.AccessDeniedError)
+		i++
+	}
+
+	return fmt.Sprintf("AdminService_ListWorkflowExecutionRuns_Result{%v}", strings.Join(fields[:i], ", "))
+}
+
+// Equals returns true if all the fields of this AdminService_ListWorkflowExecutionRuns_Result match the
+// provided AdminService_ListWorkflowExecutionRuns_Result.
+//
+// This function performs a deep comparison.
+func (v *AdminService_ListWorkflowExecutionRuns_Result) Equals(rhs *AdminService_ListWorkflowExecutionRuns_Result) bool {
+	if v == nil {
+		return rhs == nil
+	} else if rhs == nil {
+		return false
+	}
+	if !((v.Success == nil && rhs.Success == nil) || (v.Success != nil && rhs.Success != nil && v.Success.Equals(rhs.Success))) {
+		return false
+	}
+	if !((v.BadRequestError == nil && rhs.BadRequestError == nil) || (v.BadRequestError != nil && rhs.BadRequestError != nil && v.BadRequestError.Equals(rhs.BadRequestError))) {
+		return false
+	}
+	if !((v.InternalServiceError == nil && rhs.InternalServiceError == nil) || (v.InternalServiceError != nil && rhs.InternalServiceError != nil && v.InternalServiceError.Equals(rhs.InternalServiceError))) {
+		return false
+	}
+	if !((v.ServiceBusyError == nil && rhs.ServiceBusyError == nil) || (v.ServiceBusyError != nil && rhs.ServiceBusyError != nil && v.ServiceBusyError.Equals(rhs.ServiceBusyError))) {
+		return false
+	}
+	if !((v.AccessDeniedError == nil && rhs.AccessDeniedError == nil) || (v.AccessDeniedError != nil && rhs.AccessDeniedError != nil && v.AccessDeniedError.Equals(rhs.AccessDeniedError))) {
+		return false
+	}
+
+	return true
+}
+
+// MarshalLogObject implements zapcore.ObjectMarshaler, enabling
+// fast logging of AdminService_ListWorkflowExecutionRuns_Result.
+func (v *AdminService_ListWorkflowExecutionRuns_Result) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+	if v == nil {
+		return nil
+	}
+	if v.Success != nil {
+		err = multierr.Append(err, enc.AddObject("success", v.Success))
+	}
+	if v.BadRequestError != nil {
+		err = multierr.Append(err, enc.AddObject("badRequestError", v.BadRequestError))
+	}
+	if v.InternalServiceError != nil {
+		err = multierr.Append(err, enc.AddObject("internalServiceError", v.InternalServiceError))
+	}
+	if v.ServiceBusyError != nil {
+		err = multierr.Append(err, enc.AddObject("serviceBusyError", v.ServiceBusyError))
+	}
+	if v.AccessDeniedError != nil {
+		err = multierr.Append(err, enc.AddObject("accessDeniedError", v.AccessDeniedError))
+	}
+	return err
+}
+
+// GetSuccess returns the value of Success if it is set or its
+// zero value if it is unset.
+func (v *AdminService_ListWorkflowExecutionRuns_Result) GetSuccess() (o *ListWorkflowExecutionRunsResponse) {
+	if v != nil && v.Success != nil {
+		return v.Success
+	}
+
+	return
+}
+
+// IsSetSuccess returns true if Success is not nil.
+func (v *AdminService_ListWorkflowExecutionRuns_Result) IsSetSuccess() bool {
+	return v != nil && v.Success != nil
+}
+
+// GetBadRequestError returns the value of BadRequestError if it is set or its
+// zero value if it is unset.
+func (v *AdminService_ListWorkflowExecutionRuns_Result) GetBadRequestError() (o *shared.BadRequestError) {
+	if v != nil && v.BadRequestError != nil {
+		return v.BadRequestError
+	}
+
+	return
+}
+
+// IsSetBadRequestError returns true if BadRequestError is not nil.
+func (v *AdminService_ListWorkflowExecutionRuns_Result) IsSetBadRequestError() bool {
+	return v != nil && v.BadRequestError != nil
+}
+
+// GetInternalServiceError returns the value of InternalServiceError if it is set or its
+// zero value if it is unset.
+func (v *AdminService_ListWorkflowExecutionRuns_Result) GetInternalServiceError() (o *shared.InternalServiceError) {
+	if v != nil && v.InternalServiceError != nil {
+		return v.InternalServiceError
+	}
+
+	return
+}
+
+// IsSetInternalServiceError returns true if InternalServiceError is not nil.
+func (v *AdminService_ListWorkflowExecutionRuns_Result) IsSetInternalServiceError() bool {
+	return v != nil && v.InternalServiceError != nil
+}
+
+// GetServiceBusyError returns the value of ServiceBusyError if it is set or its
+// zero value if it is unset.
+func (v *AdminService_ListWorkflowExecutionRuns_Result) GetServiceBusyError() (o *shared.ServiceBusyError) {
+	if v != nil && v.ServiceBusyError != nil {
+		return v.ServiceBusyError
+	}
+
+	return
+}
+
+// IsSetServiceBusyError returns true if ServiceBusyError is not nil.
+func (v *AdminService_ListWorkflowExecutionRuns_Result) IsSetServiceBusyError() bool {
+	return v != nil && v.ServiceBusyError != nil
+}
+
+// GetAccessDeniedError returns the value of AccessDeniedError if it is set or its
+// zero value if it is unset.
+func (v *AdminService_ListWorkflowExecutionRuns_Result) GetAccessDeniedError() (o *shared.AccessDeniedError) {
+	if v != nil && v.AccessDeniedError != nil {
+		return v.AccessDeniedError
+	}
+
+	return
+}
+
+// IsSetAccessDeniedError returns true if AccessDeniedError is not nil.
+func (v *AdminService_ListWorkflowExecutionRuns_Result) IsSetAccessDeniedError() bool {
+	return v != nil && v.AccessDeniedError != nil
+}
+
+// MethodName returns the name of the Thrift function as specified in
+// the IDL, for which this struct represent the result.
+//
+// This will always be "ListWorkflowExecutionRuns" for this struct.
+func (v *AdminService_ListWorkflowExecutionRuns_Result) MethodName() string {
+	return "ListWorkflowExecutionRuns"
+}
+
+// EnvelopeType returns the kind of value inside this struct.
+//
+// This will always be Reply for this struct.
+func (v *AdminService_ListWorkflowExecutionRuns_Result) EnvelopeType() wire.EnvelopeType {
+	return wire.Reply
+}
+
+// AdminService_PurgeWorkflowExecution_Args represents the arguments for the AdminService.PurgeWorkflowExecution function.
+//
+// The arguments for PurgeWorkflowExecution are sent and received over the wire as this struct.
+type AdminService_PurgeWorkflowExecution_Args struct {
+	Request *PurgeWorkflowExecutionRequest `json:"request,omitempty"`
+}
+
+// ToWire translates a AdminService_PurgeWorkflowExecution_Args struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *AdminService_PurgeWorkflowExecution_Args) ToWire() (wire.Value, error) {
+	var (
+		fields [1]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.Request != nil {
+		w, err = v.Request.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 1, Value: w}
+		i++
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+func _PurgeWorkflowExecutionRequest_Read(w wire.Value) (*PurgeWorkflowExecutionRequest, error) {
+	var v PurgeWorkflowExecutionRequest
+	err := v.FromWire(w)
+	return &v, err
+}
+
+// FromWire deserializes a AdminService_PurgeWorkflowExecution_Args struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a AdminService_PurgeWorkflowExecution_Args struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v AdminService_PurgeWorkflowExecution_Args
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *AdminService_PurgeWorkflowExecution_Args) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 1:
+			if field.Value.Type() == wire.TStruct {
+				v.Request, err = _PurgeWorkflowExecutionRequest_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+
+	return nil
+}
+
+// String returns a readable string representation of a AdminService_PurgeWorkflowExecution_Args
+// struct.
+func (v *AdminService_PurgeWorkflowExecution_Args) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [1]string
+	i := 0
+	if v.Request != nil {
+		fields[i] = fmt.Sprintf("Request: %v", v.Request)
+		i++
+	}
+
+	return fmt.Sprintf("AdminService_PurgeWorkflowExecution_Args{%v}", strings.Join(fields[:i], ", "))
+}
+
+// Equals returns true if all the fields of this AdminService_PurgeWorkflowExecution_Args match the
+// provided AdminService_PurgeWorkflowExecution_Args.
+//
+// This function performs a deep comparison.
+func (v *AdminService_PurgeWorkflowExecution_Args) Equals(rhs *AdminService_PurgeWorkflowExecution_Args) bool {
+	if v == nil {
+		return rhs == nil
+	} else if rhs == nil {
+		return false
+	}
+	if !((v.Request == nil && rhs.Request == nil) || (v.Request != nil && rhs.Request != nil && v.Request.Equals(rhs.Request))) {
+		return false
+	}
+
+	return true
+}
+
+// MarshalLogObject implements zapcore.ObjectMarshaler, enabling
+// fast logging of AdminService_PurgeWorkflowExecution_Args.
+func (v *AdminService_PurgeWorkflowExecution_Args) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+	if v == nil {
+		return nil
+	}
+	if v.Request != nil {
+		err = multierr.Append(err, enc.AddObject("request", v.Request))
+	}
+	return err
+}
+
+// GetRequest returns the value of Request if it is set or its
+// zero value if it is unset.
+func (v *AdminService_PurgeWorkflowExecution_Args) GetRequest() (o *PurgeWorkflowExecutionRequest) {
+	if v != nil && v.Request != nil {
+		return v.Request
+	}
+
+	return
+}
+
+// IsSetRequest returns true if Request is not nil.
+func (v *AdminService_PurgeWorkflowExecution_Args) IsSetRequest() bool {
+	return v != nil && v.Request != nil
+}
+
+// MethodName returns the name of the Thrift function as specified in
+// the IDL, for which this struct represent the arguments.
+//
+// This will always be "PurgeWorkflowExecution" for this struct.
+func (v *AdminService_PurgeWorkflowExecution_Args) MethodName() string {
+	return "PurgeWorkflowExecution"
+}
+
+// EnvelopeType returns the kind of value inside this struct.
+//
+// This will always be Call for this struct.
+func (v *AdminService_PurgeWorkflowExecution_Args) EnvelopeType() wire.EnvelopeType {
+	return wire.Call
+}
+
+// AdminService_PurgeWorkflowExecution_Helper provides functions that aid in handling the
+// parameters and return values of the AdminService.PurgeWorkflowExecution
+// function.
+var AdminService_PurgeWorkflowExecution_Helper = struct {
+	// Args accepts the parameters of PurgeWorkflowExecution in-order and returns
+	// the arguments struct for the function.
+	Args func(
+		request *PurgeWorkflowExecutionRequest,
+	) *AdminService_PurgeWorkflowExecution_Args
+
+	// IsException returns true if the given error can be thrown
+	// by PurgeWorkflowExecution.
+	//
+	// An error can be thrown by PurgeWorkflowExecution only if the
+	// corresponding exception type was mentioned in the 'throws'
+	// section for it in the Thrift file.
+	IsException func(error) bool
+
+	// WrapResponse returns the result struct for PurgeWorkflowExecution
+	// given its return value and error.
+	//
+	// This allows mapping values and errors returned by
+	// PurgeWorkflowExecution into a serializable result struct.
+	// WrapResponse returns a non-nil error if the provided
+	// error cannot be thrown by PurgeWorkflowExecution
+	//
+	//   value, err := PurgeWorkflowExecution(args)
+	//   result, err := AdminService_PurgeWorkflowExecution_Helper.WrapResponse(value, err)
+	//   if err != nil {
+	//     return fmt.Errorf("unexpected error from PurgeWorkflowExecution: %v", err)
+	//   }
+	//   serialize(result)
+	WrapResponse func(*PurgeWorkflowExecutionResponse, error) (*AdminService_PurgeWorkflowExecution_Result, error)
+
+	// UnwrapResponse takes the result struct for PurgeWorkflowExecution
+	// and returns the value or error returned by it.
+	//
+	// The error is non-nil only if PurgeWorkflowExecution threw an
+	// exception.
+	//
+	//   result := deserialize(bytes)
+	//   value, err := AdminService_PurgeWorkflowExecution_Helper.UnwrapResponse(result)
+	UnwrapResponse func(*AdminService_PurgeWorkflowExecution_Result) (*PurgeWorkflowExecutionResponse, error)
+}{}
+
+func init() {
+	AdminService_PurgeWorkflowExecution_Helper.Args = func(
+		request *PurgeWorkflowExecutionRequest,
+	) *AdminService_PurgeWorkflowExecution_Args {
+		return &AdminService_PurgeWorkflowExecution_Args{
+			Request: request,
+		}
+	}
+
+	AdminService_PurgeWorkflowExecution_Helper.IsException = func(err error) bool {
+		switch err.(type) {
+		case *shared.BadRequestError:
+			return true
+		case *shared.InternalServiceError:
+			return true
+		case *shared.ServiceBusyError:
+			return true
+		case *shared.AccessDeniedError:
+			return true
+		default:
+			return false
+		}
+	}
+
+	AdminService_PurgeWorkflowExecution_Helper.WrapResponse = func(success *PurgeWorkflowExecutionResponse, err error) (*AdminService_PurgeWorkflowExecution_Result, error) {
+		if err == nil {
+			return &AdminService_PurgeWorkflowExecution_Result{Success: success}, nil
+		}
+
+		switch e := err.(type) {
+		case *shared.BadRequestError:
+			if e == nil {
+				return nil, errors.New("WrapResponse received non-nil error type with nil value for AdminService_PurgeWorkflowExecution_Result.BadRequestError")
+			}
+			return &AdminService_PurgeWorkflowExecution_Result{BadRequestError: e}, nil
+		case *shared.InternalServiceError:
+			if e == nil {
+				return nil, errors.New("WrapResponse received non-nil error type with nil value for AdminService_PurgeWorkflowExecution_Result.InternalServiceError")
+			}
+			return &AdminService_PurgeWorkflowExecution_Result{InternalServiceError: e}, nil
+		case *shared.ServiceBusyError:
+			if e == nil {
+				return nil, errors.New("WrapResponse received non-nil error type with nil value for AdminService_PurgeWorkflowExecution_Result.ServiceBusyError")
+			}
+			return &AdminService_PurgeWorkflowExecution_Result{ServiceBusyError: e}, nil
+		case *shared.AccessDeniedError:
+			if e == nil {
+				return nil, errors.New("WrapResponse received non-nil error type with nil value for AdminService_PurgeWorkflowExecution_Result.AccessDeniedError")
+			}
+			return &AdminService_PurgeWorkflowExecution_Result{AccessDeniedError: e}, nil
+		}
+
+		return nil, err
+	}
+	AdminService_PurgeWorkflowExecution_Helper.UnwrapResponse = func(result *AdminService_PurgeWorkflowExecution_Result) (success *PurgeWorkflowExecutionResponse, err error) {
+		if result.BadRequestError != nil {
+			err = result.BadRequestError
+			return
+		}
+		if result.InternalServiceError != nil {
+			err = result.InternalServiceError
+			return
+		}
+		if result.ServiceBusyError != nil {
+			err = result.ServiceBusyError
+			return
+		}
+		if result.AccessDeniedError != nil {
+			err = result.AccessDeniedError
+			return
+		}
+
+		if result.Success != nil {
+			success = result.Success
+			return
+		}
+
+		err = errors.New("expected a non-void result")
+		return
+	}
+
+}
+
+// AdminService_PurgeWorkflowExecution_Result represents the result of a AdminService.PurgeWorkflowExecution function call.
+//
+// The result of a PurgeWorkflowExecution execution is sent and received over the wire as this struct.
+//
+// Success is set only if the function did not throw an exception.
+type AdminService_PurgeWorkflowExecution_Result struct {
+	// Value returned by PurgeWorkflowExecution after a successful execution.
+	Success              *PurgeWorkflowExecutionResponse `json:"success,omitempty"`
+	BadRequestError      *shared.BadRequestError            `json:"badRequestError,omitempty"`
+	InternalServiceError *shared.InternalServiceError       `json:"internalServiceError,omitempty"`
+	ServiceBusyError     *shared.ServiceBusyError           `json:"serviceBusyError,omitempty"`
+	AccessDeniedError    *shared.AccessDeniedError          `json:"accessDeniedError,omitempty"`
+}
+
+// ToWire translates a AdminService_PurgeWorkflowExecution_Result struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *AdminService_PurgeWorkflowExecution_Result) ToWire() (wire.Value, error) {
+	var (
+		fields [5]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.Success != nil {
+		w, err = v.Success.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 0, Value: w}
+		i++
+	}
+	if v.BadRequestError != nil {
+		w, err = v.BadRequestError.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 1, Value: w}
+		i++
+	}
+	if v.InternalServiceError != nil {
+		w, err = v.InternalServiceError.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 2, Value: w}
+		i++
+	}
+	if v.ServiceBusyError != nil {
+		w, err = v.ServiceBusyError.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 3, Value: w}
+		i++
+	}
+	if v.AccessDeniedError != nil {
+		w, err = v.AccessDeniedError.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 4, Value: w}
+		i++
+	}
+
+	if i != 1 {
+		return wire.Value{}, fmt.Errorf("AdminService_PurgeWorkflowExecution_Result should have exactly one field: got %v fields", i)
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+func _PurgeWorkflowExecutionResponse_Read(w wire.Value) (*PurgeWorkflowExecutionResponse, error) {
+	var v PurgeWorkflowExecutionResponse
+	err := v.FromWire(w)
+	return &v, err
+}
+
+// FromWire deserializes a AdminService_PurgeWorkflowExecution_Result struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a AdminService_PurgeWorkflowExecution_Result struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v AdminService_PurgeWorkflowExecution_Result
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *AdminService_PurgeWorkflowExecution_Result) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 0:
+			if field.Value.Type() == wire.TStruct {
+				v.Success, err = _PurgeWorkflowExecutionResponse_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 1:
+			if field.Value.Type() == wire.TStruct {
+				v.BadRequestError, err = _BadRequestError_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 2:
+			if field.Value.Type() == wire.TStruct {
+				v.InternalServiceError, err = _InternalServiceError_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 3:
+			if field.Value.Type() == wire.TStruct {
+				v.ServiceBusyError, err = _ServiceBusyError_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 4:
+			if field.Value.Type() == wire.TStruct {
+				v.AccessDeniedError, err = _AccessDeniedError_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+
+	count := 0
+	if v.Success != nil {
+		count++
+	}
+	if v.BadRequestError != nil {
+		count++
+	}
+	if v.InternalServiceError != nil {
+		count++
+	}
+	if v.ServiceBusyError != nil {
+		count++
+	}
+	if v.AccessDeniedError != nil {
+		count++
+	}
+	if count != 1 {
+		return fmt.Errorf("AdminService_PurgeWorkflowExecution_Result should have exactly one field: got %v fields", count)
+	}
+
+	return nil
+}
+
+// String returns a readable string representation of a AdminService_PurgeWorkflowExecution_Result
+// struct.
+func (v *AdminService_PurgeWorkflowExecution_Result) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [5]string
+	i := 0
+	if v.Success != nil {
+		fields[i] = fmt.Sprintf("Success: %v", v.Success)
+		i++
+	}
+	if v.BadRequestError != nil {
+		fields[i] = fmt.Sprintf("BadRequestError: %v", v.BadRequestError)
+		i++
+	}
+	if v.InternalServiceError != nil {
+		fields[i] = fmt.Sprintf("InternalServiceError: %v", v.InternalServiceError)
+		i++
+	}
+	if v.ServiceBusyError != nil {
+		fields[i] = fmt.Sprintf("ServiceBusyError: %v", v.ServiceBusyError)
+		i++
+	}
+	if v.AccessDeniedError != nil {
+		fields[i] = fmt.Sprintf("AccessDeniedError: %v", v.AccessDeniedError)
+		i++
+	}
+
+	return fmt.Sprintf("AdminService_PurgeWorkflowExecution_Result{%v}", strings.Join(fields[:i], ", "))
+}
+
+// Equals returns true if all the fields of this AdminService_PurgeWorkflowExecution_Result match the
+// provided AdminService_PurgeWorkflowExecution_Result.
+//
+// This function performs a deep comparison.
+func (v *AdminService_PurgeWorkflowExecution_Result) Equals(rhs *AdminService_PurgeWorkflowExecution_Result) bool {
+	if v == nil {
+		return rhs == nil
+	} else if rhs == nil {
+		return false
+	}
+	if !((v.Success == nil && rhs.Success == nil) || (v.Success != nil && rhs.Success != nil && v.Success.Equals(rhs.Success))) {
+		return false
+	}
+	if !((v.BadRequestError == nil && rhs.BadRequestError == nil) || (v.BadRequestError != nil && rhs.BadRequestError != nil && v.BadRequestError.Equals(rhs.BadRequestError))) {
+		return false
+	}
+	if !((v.InternalServiceError == nil && rhs.InternalServiceError == nil) || (v.InternalServiceError != nil && rhs.InternalServiceError != nil && v.InternalServiceError.Equals(rhs.InternalServiceError))) {
+		return false
+	}
+	if !((v.ServiceBusyError == nil && rhs.ServiceBusyError == nil) || (v.ServiceBusyError != nil && rhs.ServiceBusyError != nil && v.ServiceBusyError.Equals(rhs.ServiceBusyError))) {
+		return false
+	}
+	if !((v.AccessDeniedError == nil && rhs.AccessDeniedError == nil) || (v.AccessDeniedError != nil && rhs.AccessDeniedError != nil && v.AccessDeniedError.Equals(rhs.AccessDeniedError))) {
+		return false
+	}
+
+	return true
+}
+
+// MarshalLogObject implements zapcore.ObjectMarshaler, enabling
+// fast logging of AdminService_PurgeWorkflowExecution_Result.
+func (v *AdminService_PurgeWorkflowExecution_Result) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+	if v == nil {
+		return nil
+	}
+	if v.Success != nil {
+		err = multierr.Append(err, enc.AddObject("success", v.Success))
+	}
+	if v.BadRequestError != nil {
+		err = multierr.Append(err, enc.AddObject("badRequestError", v.BadRequestError))
+	}
+	if v.InternalServiceError != nil {
+		err = multierr.Append(err, enc.AddObject("internalServiceError", v.InternalServiceError))
+	}
+	if v.ServiceBusyError != nil {
+		err = multierr.Append(err, enc.AddObject("serviceBusyError", v.ServiceBusyError))
+	}
+	if v.AccessDeniedError != nil {
+		err = multierr.Append(err, enc.AddObject("accessDeniedError", v.AccessDeniedError))
+	}
+	return err
+}
+
+// GetSuccess returns the value of Success if it is set or its
+// zero value if it is unset.
+func (v *AdminService_PurgeWorkflowExecution_Result) GetSuccess() (o *PurgeWorkflowExecutionResponse) {
+	if v != nil && v.Success != nil {
+		return v.Success
+	}
+
+	return
+}
+
+// IsSetSuccess returns true if Success is not nil.
+func (v *AdminService_PurgeWorkflowExecution_Result) IsSetSuccess() bool {
+	return v != nil && v.Success != nil
+}
+
+// GetBadRequestError returns the value of BadRequestError if it is set or its
+// zero value if it is unset.
+func (v *AdminService_PurgeWorkflowExecution_Result) GetBadRequestError() (o *shared.BadRequestError) {
+	if v != nil && v.BadRequestError != nil {
+		return v.BadRequestError
+	}
+
+	return
+}
+
+// IsSetBadRequestError returns true if BadRequestError is not nil.
+func (v *AdminService_PurgeWorkflowExecution_Result) IsSetBadRequestError() bool {
+	return v != nil && v.BadRequestError != nil
+}
+
+// GetInternalServiceError returns the value of InternalServiceError if it is set or its
+// zero value if it is unset.
+func (v *AdminService_PurgeWorkflowExecution_Result) GetInternalServiceError() (o *shared.InternalServiceError) {
+	if v != nil && v.InternalServiceError != nil {
+		return v.InternalServiceError
+	}
+
+	return
+}
+
+// IsSetInternalServiceError returns true if InternalServiceError is not nil.
+func (v *AdminService_PurgeWorkflowExecution_Result) IsSetInternalServiceError() bool {
+	return v != nil && v.InternalServiceError != nil
+}
+
+// GetServiceBusyError returns the value of ServiceBusyError if it is set or its
+// zero value if it is unset.
+func (v *AdminService_PurgeWorkflowExecution_Result) GetServiceBusyError() (o *shared.ServiceBusyError) {
+	if v != nil && v.ServiceBusyError != nil {
+		return v.ServiceBusyError
+	}
+
+	return
+}
+
+// IsSetServiceBusyError returns true if ServiceBusyError is not nil.
+func (v *AdminService_PurgeWorkflowExecution_Result) IsSetServiceBusyError() bool {
+	return v != nil && v.ServiceBusyError != nil
+}
+
+// GetAccessDeniedError returns the value of AccessDeniedError if it is set or its
+// zero value if it is unset.
+func (v *AdminService_PurgeWorkflowExecution_Result) GetAccessDeniedError() (o *shared.AccessDeniedError) {
+	if v != nil && v.AccessDeniedError != nil {
+		return v.AccessDeniedError
+	}
+
+	return
+}
+
+// IsSetAccessDeniedError returns true if AccessDeniedError is not nil.
+func (v *AdminService_PurgeWorkflowExecution_Result) IsSetAccessDeniedError() bool {
+	return v != nil && v.AccessDeniedError != nil
+}
+
+// MethodName returns the name of the Thrift function as specified in
+// the IDL, for which this struct represent the result.
+//
+// This will always be "PurgeWorkflowExecution" for this struct.
+func (v *AdminService_PurgeWorkflowExecution_Result) MethodName() string {
+	return "PurgeWorkflowExecution"
+}
+
+// EnvelopeType returns the kind of value inside this struct.
+//
+// This will always be Reply for this struct.
+func (v *AdminService_PurgeWorkflowExecution_Result) EnvelopeType() wire.EnvelopeType {
+	return wire.Reply
+}
+
+// AdminService_SetActivityPaused_Args represents the arguments for the AdminService.SetActivityPaused function.
+//
+// The arguments for SetActivityPaused are sent and received over the wire as this struct.
+type AdminService_SetActivityPaused_Args struct {
+	Request *shared.SetActivityPausedRequest `json:"request,omitempty"`
+}
+
+// ToWire translates a AdminService_SetActivityPaused_Args struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *AdminService_SetActivityPaused_Args) ToWire() (wire.Value, error) {
+	var (
+		fields [1]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.Request != nil {
+		w, err = v.Request.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 1, Value: w}
+		i++
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+func _SetActivityPausedRequest_Read(w wire.Value) (*shared.SetActivityPausedRequest, error) {
+	var v shared.SetActivityPausedRequest
+	err := v.FromWire(w)
+	return &v, err
+}
+
+// FromWire deserializes a AdminService_SetActivityPaused_Args struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a AdminService_SetActivityPaused_Args struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v AdminService_SetActivityPaused_Args
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *AdminService_SetActivityPaused_Args) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 1:
+			if field.Value.Type() == wire.TStruct {
+				v.Request, err = _SetActivityPausedRequest_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+
+	return nil
+}
+
+// String returns a readable string representation of a AdminService_SetActivityPaused_Args
+// struct.
+func (v *AdminService_SetActivityPaused_Args) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [1]string
+	i := 0
+	if v.Request != nil {
+		fields[i] = fmt.Sprintf("Request: %v", v.Request)
+		i++
+	}
+
+	return fmt.Sprintf("AdminService_SetActivityPaused_Args{%v}", strings.Join(fields[:i], ", "))
+}
+
+// Equals returns true if all the fields of this AdminService_SetActivityPaused_Args match the
+// provided AdminService_SetActivityPaused_Args.
+//
+// This function performs a deep comparison.
+func (v *AdminService_SetActivityPaused_Args) Equals(rhs *AdminService_SetActivityPaused_Args) bool {
+	if v == nil {
+		return rhs == nil
+	} else if rhs == nil {
+		return false
+	}
+	if !((v.Request == nil && rhs.Request == nil) || (v.Request != nil && rhs.Request != nil && v.Request.Equals(rhs.Request))) {
+		return false
+	}
+
+	return true
+}
+
+// MarshalLogObject implements zapcore.ObjectMarshaler, enabling
+// fast logging of AdminService_SetActivityPaused_Args.
+func (v *AdminService_SetActivityPaused_Args) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+	if v == nil {
+		return nil
+	}
+	if v.Request != nil {
+		err = multierr.Append(err, enc.AddObject("request", v.Request))
+	}
+	return err
+}
+
+// GetRequest returns the value of Request if it is set or its
+// zero value if it is unset.
+func (v *AdminService_SetActivityPaused_Args) GetRequest() (o *shared.SetActivityPausedRequest) {
+	if v != nil && v.Request != nil {
+		return v.Request
+	}
+
+	return
+}
+
+// IsSetRequest returns true if Request is not nil.
+func (v *AdminService_SetActivityPaused_Args) IsSetRequest() bool {
+	return v != nil && v.Request != nil
+}
+
+// MethodName returns the name of the Thrift function as specified in
+// the IDL, for which this struct represent the arguments.
+//
+// This will always be "SetActivityPaused" for this struct.
+func (v *AdminService_SetActivityPaused_Args) MethodName() string {
+	return "SetActivityPaused"
+}
+
+// EnvelopeType returns the kind of value inside this struct.
+//
+// This will always be Call for this struct.
+func (v *AdminService_SetActivityPaused_Args) EnvelopeType() wire.EnvelopeType {
+	return wire.Call
+}
+
+// AdminService_SetActivityPaused_Helper provides functions that aid in handling the
+// parameters and return values of the AdminService.SetActivityPaused
+// function.
+var AdminService_SetActivityPaused_Helper = struct {
+	// Args accepts the parameters of SetActivityPaused in-order and returns
+	// the arguments struct for the function.
+	Args func(
+		request *shared.SetActivityPausedRequest,
+	) *AdminService_SetActivityPaused_Args
+
+	// IsException returns true if the given error can be thrown
+	// by SetActivityPaused.
+	//
+	// An error can be thrown by SetActivityPaused only if the
+	// corresponding exception type was mentioned in the 'throws'
+	// section for it in the Thrift file.
+	IsException func(error) bool
+
+	// WrapResponse returns the result struct for SetActivityPaused
+	// given the error returned by it. The provided error may
+	// be nil if SetActivityPaused did not fail.
+	//
+	// This allows mapping errors returned by SetActivityPaused into a
+	// serializable result struct. WrapResponse returns a
+	// non-nil error if the provided error cannot be thrown by
+	// SetActivityPaused
+	//
+	//   err := SetActivityPaused(args)
+	//   result, err := AdminService_SetActivityPaused_Helper.WrapResponse(err)
+	//   if err != nil {
+	//     return fmt.Errorf("unexpected error from SetActivityPaused: %v", err)
+	//   }
+	//   serialize(result)
+	WrapResponse func(error) (*AdminService_SetActivityPaused_Result, error)
+
+	// UnwrapResponse takes the result struct for SetActivityPaused
+	// and returns the erorr returned by it (if any).
+	//
+	// The error is non-nil only if SetActivityPaused threw an
+	// exception.
+	//
+	//   result := deserialize(bytes)
+	//   err := AdminService_SetActivityPaused_Helper.UnwrapResponse(result)
+	UnwrapResponse func(*AdminService_SetActivityPaused_Result) error
+}{}
+
+func init() {
+	AdminService_SetActivityPaused_Helper.Args = func(
+		request *shared.SetActivityPausedRequest,
+	) *AdminService_SetActivityPaused_Args {
+		return &AdminService_SetActivityPaused_Args{
+			Request: request,
+		}
+	}
+
+	AdminService_SetActivityPaused_Helper.IsException = func(err error) bool {
+		switch err.(type) {
+		case *shared.BadRequestError:
+			return true
+		case *shared.InternalServiceError:
+			return true
+		case *shared.EntityNotExistsError:
+			return true
+		case *shared.DomainNotActiveError:
+			return true
+		case *shared.AccessDeniedError:
+			return true
+		default:
+			return false
+		}
+	}
+
+	AdminService_SetActivityPaused_Helper.WrapResponse = func(err error) (*AdminService_SetActivityPaused_Result, error) {
+		if err == nil {
+			return &AdminService_SetActivityPaused_Result{}, nil
+		}
+
+		switch e := err.(type) {
+		case *shared.BadRequestError:
+			if e == nil {
+				return nil, errors.New("WrapResponse received non-nil error type with nil value for AdminService_SetActivityPaused_Result.BadRequestError")
+			}
+			return &AdminService_SetActivityPaused_Result{BadRequestError: e}, nil
+		case *shared.InternalServiceError:
+			if e == nil {
+				return nil, errors.New("WrapResponse received non-nil error type with nil value for AdminService_SetActivityPaused_Result.InternalServiceError")
+			}
+			return &AdminService_SetActivityPaused_Result{InternalServiceError: e}, nil
+		case *shared.EntityNotExistsError:
+			if e == nil {
+				return nil, errors.New("WrapResponse received non-nil error type with nil value for AdminService_SetActivityPaused_Result.EntityNotExistError")
+			}
+			return &AdminService_SetActivityPaused_Result{EntityNotExistError: e}, nil
+		case *shared.DomainNotActiveError:
+			if e == nil {
+				return nil, errors.New("WrapResponse received non-nil error type with nil value for AdminService_SetActivityPaused_Result.DomainNotActiveError")
+			}
+			return &AdminService_SetActivityPaused_Result{DomainNotActiveError: e}, nil
+		case *shared.AccessDeniedError:
+			if e == nil {
+				return nil, errors.New("WrapResponse received non-nil error type with nil value for AdminService_SetActivityPaused_Result.AccessDeniedError")
+			}
+			return &AdminService_SetActivityPaused_Result{AccessDeniedError: e}, nil
+		}
+
+		return nil, err
+	}
+	AdminService_SetActivityPaused_Helper.UnwrapResponse = func(result *AdminService_SetActivityPaused_Result) (err error) {
+		if result.BadRequestError != nil {
+			err = result.BadRequestError
+			return
+		}
+		if result.InternalServiceError != nil {
+			err = result.InternalServiceError
+			return
+		}
+		if result.EntityNotExistError != nil {
+			err = result.EntityNotExistError
+			return
+		}
+		if result.DomainNotActiveError != nil {
+			err = result.DomainNotActiveError
+			return
+		}
+		if result.AccessDeniedError != nil {
+			err = result.AccessDeniedError
+			return
+		}
+		return
+	}
+
+}
+
+// AdminService_SetActivityPaused_Result represents the result of a AdminService.SetActivityPaused function call.
+//
+// The result of a SetActivityPaused execution is sent and received over the wire as this struct.
+type AdminService_SetActivityPaused_Result struct {
+	BadRequestError      *shared.BadRequestError      `json:"badRequestError,omitempty"`
+	InternalServiceError *shared.InternalServiceError `json:"internalServiceError,omitempty"`
+	EntityNotExistError  *shared.EntityNotExistsError `json:"entityNotExistError,omitempty"`
+	DomainNotActiveError *shared.DomainNotActiveError `json:"domainNotActiveError,omitempty"`
+	AccessDeniedError    *shared.AccessDeniedError    `json:"accessDeniedError,omitempty"`
+}
+
+// ToWire translates a AdminService_SetActivityPaused_Result struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *AdminService_SetActivityPaused_Result) ToWire() (wire.Value, error) {
+	var (
+		fields [5]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.BadRequestError != nil {
+		w, err = v.BadRequestError.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 1, Value: w}
+		i++
+	}
+	if v.InternalServiceError != nil {
+		w, err = v.InternalServiceError.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 2, Value: w}
+		i++
+	}
+	if v.EntityNotExistError != nil {
+		w, err = v.EntityNotExistError.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 3, Value: w}
+		i++
+	}
+	if v.DomainNotActiveError != nil {
+		w, err = v.DomainNotActiveError.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 4, Value: w}
+		i++
+	}
+	if v.AccessDeniedError != nil {
+		w, err = v.AccessDeniedError.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 5, Value: w}
+		i++
+	}
+
+	if i > 1 {
+		return wire.Value{}, fmt.Errorf("AdminService_SetActivityPaused_Result should have at most one field: got %v fields", i)
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+func _DomainNotActiveError_Read(w wire.Value) (*shared.DomainNotActiveError, error) {
+	var v shared.DomainNotActiveError
+	err := v.FromWire(w)
+	return &v, err
+}
+
+// FromWire deserializes a AdminService_SetActivityPaused_Result struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a AdminService_SetActivityPaused_Result struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v AdminService_SetActivityPaused_Result
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *AdminService_SetActivityPaused_Result) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 1:
+			if field.Value.Type() == wire.TStruct {
+				v.BadRequestError, err = _BadRequestError_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 2:
+			if field.Value.Type() == wire.TStruct {
+				v.InternalServiceError, err = _InternalServiceError_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 3:
+			if field.Value.Type() == wire.TStruct {
+				v.EntityNotExistError, err = _EntityNotExistsError_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 4:
+			if field.Value.Type() == wire.TStruct {
+				v.DomainNotActiveError, err = _DomainNotActiveError_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 5:
+			if field.Value.Type() == wire.TStruct {
+				v.AccessDeniedError, err = _AccessDeniedError_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+
+	count := 0
+	if v.BadRequestError != nil {
+		count++
+	}
+	if v.InternalServiceError != nil {
+		count++
+	}
+	if v.EntityNotExistError != nil {
+		count++
+	}
+	if v.DomainNotActiveError != nil {
+		count++
+	}
+	if v.AccessDeniedError != nil {
+		count++
+	}
+	if count > 1 {
+		return fmt.Errorf("AdminService_SetActivityPaused_Result should have at most one field: got %v fields", count)
+	}
+
+	return nil
+}
+
+// String returns a readable string representation of a AdminService_SetActivityPaused_Result
+// struct.
+func (v *AdminService_SetActivityPaused_Result) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [5]string
+	i := 0
+	if v.BadRequestError != nil {
+		fields[i] = fmt.Sprintf("BadRequestError: %v", v.BadRequestError)
+		i++
+	}
+	if v.InternalServiceError != nil {
+		fields[i] = fmt.Sprintf("InternalServiceError: %v", v.InternalServiceError)
+		i++
+	}
+	if v.EntityNotExistError != nil {
+		fields[i] = fmt.Sprintf("EntityNotExistError: %v", v.EntityNotExistError)
+		i++
+	}
+	if v.DomainNotActiveError != nil {
+		fields[i] = fmt.Sprintf("DomainNotActiveError: %v", v.DomainNotActiveError)
+		i++
+	}
+	if v.AccessDeniedError != nil {
+		fields[i] = fmt.Sprintf("AccessDeniedError: %v", v.AccessDeniedError)
+		i++
+	}
+
+	return fmt.Sprintf("AdminService_SetActivityPaused_Result{%v}", strings.Join(fields[:i], ", "))
+}
+
+// Equals returns true if all the fields of this AdminService_SetActivityPaused_Result match the
+// provided AdminService_SetActivityPaused_Result.
+//
+// This function performs a deep comparison.
+func (v *AdminService_SetActivityPaused_Result) Equals(rhs *AdminService_SetActivityPaused_Result) bool {
+	if v == nil {
+		return rhs == nil
+	} else if rhs == nil {
+		return false
+	}
+	if !((v.BadRequestError == nil && rhs.BadRequestError == nil) || (v.BadRequestError != nil && rhs.BadRequestError != nil && v.BadRequestError.Equals(rhs.BadRequestError))) {
+		return false
+	}
+	if !((v.InternalServiceError == nil && rhs.InternalServiceError == nil) || (v.InternalServiceError != nil && rhs.InternalServiceError != nil && v.InternalServiceError.Equals(rhs.InternalServiceError))) {
+		return false
+	}
+	if !((v.EntityNotExistError == nil && rhs.EntityNotExistError == nil) || (v.EntityNotExistError != nil && rhs.EntityNotExistError != nil && v.EntityNotExistError.Equals(rhs.EntityNotExistError))) {
+		return false
+	}
+	if !((v.DomainNotActiveError == nil && rhs.DomainNotActiveError == nil) || (v.DomainNotActiveError != nil && rhs.DomainNotActiveError != nil && v.DomainNotActiveError.Equals(rhs.DomainNotActiveError))) {
+		return false
+	}
+	if !((v.AccessDeniedError == nil && rhs.AccessDeniedError == nil) || (v.AccessDeniedError != nil && rhs.AccessDeniedError != nil && v.AccessDeniedError.Equals(rhs.AccessDeniedError))) {
+		return false
+	}
+
+	return true
+}
+
+// MarshalLogObject implements zapcore.ObjectMarshaler, enabling
+// fast logging of AdminService_SetActivityPaused_Result.
+func (v *AdminService_SetActivityPaused_Result) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+	if v == nil {
+		return nil
+	}
+	if v.BadRequestError != nil {
+		err = multierr.Append(err, enc.AddObject("badRequestError", v.BadRequestError))
+	}
+	if v.InternalServiceError != nil {
+		err = multierr.Append(err, enc.AddObject("internalServiceError", v.InternalServiceError))
+	}
+	if v.EntityNotExistError != nil {
+		err = multierr.Append(err, enc.AddObject("entityNotExistError", v.EntityNotExistError))
+	}
+	if v.DomainNotActiveError != nil {
+		err = multierr.Append(err, enc.AddObject("domainNotActiveError", v.DomainNotActiveError))
+	}
+	if v.AccessDeniedError != nil {
+		err = multierr.Append(err, enc.AddObject("accessDeniedError", v.AccessDeniedError))
+	}
+	return err
+}
+
+// GetBadRequestError returns the value of BadRequestError if it is set or its
+// zero value if it is unset.
+func (v *AdminService_SetActivityPaused_Result) GetBadRequestError() (o *shared.BadRequestError) {
+	if v != nil && v.BadRequestError != nil {
+		return v.BadRequestError
+	}
+
+	return
+}
+
+// IsSetBadRequestError returns true if BadRequestError is not nil.
+func (v *AdminService_SetActivityPaused_Result) IsSetBadRequestError() bool {
+	return v != nil && v.BadRequestError != nil
+}
+
+// GetInternalServiceError returns the value of InternalServiceError if it is set or its
+// zero value if it is unset.
+func (v *AdminService_SetActivityPaused_Result) GetInternalServiceError() (o *shared.InternalServiceError) {
+	if v != nil && v.InternalServiceError != nil {
+		return v.InternalServiceError
+	}
+
+	return
+}
+
+// IsSetInternalServiceError returns true if InternalServiceError is not nil.
+func (v *AdminService_SetActivityPaused_Result) IsSetInternalServiceError() bool {
+	return v != nil && v.InternalServiceError != nil
+}
+
+// GetEntityNotExistError returns the value of EntityNotExistError if it is set or its
+// zero value if it is unset.
+func (v *AdminService_SetActivityPaused_Result) GetEntityNotExistError() (o *shared.EntityNotExistsError) {
+	if v != nil && v.EntityNotExistError != nil {
+		return v.EntityNotExistError
+	}
+
+	return
+}
+
+// IsSetEntityNotExistError returns true if EntityNotExistError is not nil.
+func (v *AdminService_SetActivityPaused_Result) IsSetEntityNotExistError() bool {
+	return v != nil && v.EntityNotExistError != nil
+}
+
+// GetDomainNotActiveError returns the value of DomainNotActiveError if it is set or its
+// zero value if it is unset.
+func (v *AdminService_SetActivityPaused_Result) GetDomainNotActiveError() (o *shared.DomainNotActiveError) {
+	if v != nil && v.DomainNotActiveError != nil {
+		return v.DomainNotActiveError
+	}
+
+	return
+}
+
+// IsSetDomainNotActiveError returns true if DomainNotActiveError is not nil.
+func (v *AdminService_SetActivityPaused_Result) IsSetDomainNotActiveError() bool {
+	return v != nil && v.DomainNotActiveError != nil
+}
+
+// GetAccessDeniedError returns the value of AccessDeniedError if it is set or its
+// zero value if it is unset.
+func (v *AdminService_SetActivityPaused_Result) GetAccessDeniedError() (o *shared.AccessDeniedError) {
+	if v != nil && v.AccessDeniedError != nil {
+		return v.AccessDeniedError
+	}
+
+	return
+}
+
+// IsSetAccessDeniedError returns true if AccessDeniedError is not nil.
+func (v *AdminService_SetActivityPaused_Result) IsSetAccessDeniedError() bool {
+	return v != nil && v.AccessDeniedError != nil
+}
+
+// MethodName returns the name of the Thrift function as specified in
+// the IDL, for which this struct represent the result.
+//
+// This will always be "SetActivityPaused" for this struct.
+func (v *AdminService_SetActivityPaused_Result) MethodName() string {
+	return "SetActivityPaused"
+}
+
+// EnvelopeType returns the kind of value inside this struct.
+//
+// This will always be Reply for this struct.
+func (v *AdminService_SetActivityPaused_Result) EnvelopeType() wire.EnvelopeType {
+	return wire.Reply
+}
+
+// AdminService_SetCronSchedulePaused_Args represents the arguments for the AdminService.SetCronSchedulePaused function.
+//
+// The arguments for SetCronSchedulePaused are sent and received over the wire as this struct.
+type AdminService_SetCronSchedulePaused_Args struct {
+	Request *shared.SetCronSchedulePausedRequest `json:"request,omitempty"`
+}
+
+// ToWire translates a AdminService_SetCronSchedulePaused_Args struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *AdminService_SetCronSchedulePaused_Args) ToWire() (wire.Value, error) {
+	var (
+		fields [1]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.Request != nil {
+		w, err = v.Request.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 1, Value: w}
+		i++
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+func _SetCronSchedulePausedRequest_Read(w wire.Value) (*shared.SetCronSchedulePausedRequest, error) {
+	var v shared.SetCronSchedulePausedRequest
+	err := v.FromWire(w)
+	return &v, err
+}
+
+// FromWire deserializes a AdminService_SetCronSchedulePaused_Args struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a AdminService_SetCronSchedulePaused_Args struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v AdminService_SetCronSchedulePaused_Args
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *AdminService_SetCronSchedulePaused_Args) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 1:
+			if field.Value.Type() == wire.TStruct {
+				v.Request, err = _SetCronSchedulePausedRequest_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+
+	return nil
+}
+
+// String returns a readable string representation of a AdminService_SetCronSchedulePaused_Args
+// struct.
+func (v *AdminService_SetCronSchedulePaused_Args) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [1]string
+	i := 0
+	if v.Request != nil {
+		fields[i] = fmt.Sprintf("Request: %v", v.Request)
+		i++
+	}
+
+	return fmt.Sprintf("AdminService_SetCronSchedulePaused_Args{%v}", strings.Join(fields[:i], ", "))
+}
+
+// Equals returns true if all the fields of this AdminService_SetCronSchedulePaused_Args match the
+// provided AdminService_SetCronSchedulePaused_Args.
+//
+// This function performs a deep comparison.
+func (v *AdminService_SetCronSchedulePaused_Args) Equals(rhs *AdminService_SetCronSchedulePaused_Args) bool {
+	if v == nil {
+		return rhs == nil
+	} else if rhs == nil {
+		return false
+	}
+	if !((v.Request == nil && rhs.Request == nil) || (v.Request != nil && rhs.Request != nil && v.Request.Equals(rhs.Request))) {
+		return false
+	}
+
+	return true
+}
+
+// MarshalLogObject implements zapcore.ObjectMarshaler, enabling
+// fast logging of AdminService_SetCronSchedulePaused_Args.
+func (v *AdminService_SetCronSchedulePaused_Args) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+	if v == nil {
+		return nil
+	}
+	if v.Request != nil {
+		err = multierr.Append(err, enc.AddObject("request", v.Request))
+	}
+	return err
+}
+
+// GetRequest returns the value of Request if it is set or its
+// zero value if it is unset.
+func (v *AdminService_SetCronSchedulePaused_Args) GetRequest() (o *shared.SetCronSchedulePausedRequest) {
+	if v != nil && v.Request != nil {
+		return v.Request
+	}
+
+	return
+}
+
+// IsSetRequest returns true if Request is not nil.
+func (v *AdminService_SetCronSchedulePaused_Args) IsSetRequest() bool {
+	return v != nil && v.Request != nil
+}
+
+// MethodName returns the name of the Thrift function as specified in
+// the IDL, for which this struct represent the arguments.
+//
+// This will always be "SetCronSchedulePaused" for this struct.
+func (v *AdminService_SetCronSchedulePaused_Args) MethodName() string {
+	return "SetCronSchedulePaused"
+}
+
+// EnvelopeType returns the kind of value inside this struct.
+//
+// This will always be Call for this struct.
+func (v *AdminService_SetCronSchedulePaused_Args) EnvelopeType() wire.EnvelopeType {
+	return wire.Call
+}
+
+// AdminService_SetCronSchedulePaused_Helper provides functions that aid in handling the
+// parameters and return values of the AdminService.SetCronSchedulePaused
+// function.
+var AdminService_SetCronSchedulePaused_Helper = struct {
+	// Args accepts the parameters of SetCronSchedulePaused in-order and returns
+	// the arguments struct for the function.
+	Args func(
+		request *shared.SetCronSchedulePausedRequest,
+	) *AdminService_SetCronSchedulePaused_Args
+
+	// IsException returns true if the given error can be thrown
+	// by SetCronSchedulePaused.
+	//
+	// An error can be thrown by SetCronSchedulePaused only if the
+	// corresponding exception type was mentioned in the 'throws'
+	// section for it in the Thrift file.
+	IsException func(error) bool
+
+	// WrapResponse returns the result struct for SetCronSchedulePaused
+	// given the error returned by it. The provided error may
+	// be nil if SetCronSchedulePaused did not fail.
+	//
+	// This allows mapping errors returned by SetCronSchedulePaused into a
+	// serializable result struct. WrapResponse returns a
+	// non-nil error if the provided error cannot be thrown by
+	// SetCronSchedulePaused
+	//
+	//   err := SetCronSchedulePaused(args)
+	//   result, err := AdminService_SetCronSchedulePaused_Helper.WrapResponse(err)
+	//   if err != nil {
+	//     return fmt.Errorf("unexpected error from SetCronSchedulePaused: %v", err)
+	//   }
+	//   serialize(result)
+	WrapResponse func(error) (*AdminService_SetCronSchedulePaused_Result, error)
+
+	// UnwrapResponse takes the result struct for SetCronSchedulePaused
+	// and returns the erorr returned by it (if any).
+	//
+	// The error is non-nil only if SetCronSchedulePaused threw an
+	// exception.
+	//
+	//   result := deserialize(bytes)
+	//   err := AdminService_SetCronSchedulePaused_Helper.UnwrapResponse(result)
+	UnwrapResponse func(*AdminService_SetCronSchedulePaused_Result) error
+}{}
+
+func init() {
+	AdminService_SetCronSchedulePaused_Helper.Args = func(
+		request *shared.SetCronSchedulePausedRequest,
+	) *AdminService_SetCronSchedulePaused_Args {
+		return &AdminService_SetCronSchedulePaused_Args{
+			Request: request,
+		}
+	}
+
+	AdminService_SetCronSchedulePaused_Helper.IsException = func(err error) bool {
+		switch err.(type) {
+		case *shared.BadRequestError:
+			return true
+		case *shared.InternalServiceError:
+			return true
+		case *shared.EntityNotExistsError:
+			return true
+		case *shared.DomainNotActiveError:
+			return true
+		case *shared.AccessDeniedError:
+			return true
+		default:
+			return false
+		}
+	}
+
+	AdminService_SetCronSchedulePaused_Helper.WrapResponse = func(err error) (*AdminService_SetCronSchedulePaused_Result, error) {
+		if err == nil {
+			return &AdminService_SetCronSchedulePaused_Result{}, nil
+		}
+
+		switch e := err.(type) {
+		case *shared.BadRequestError:
+			if e == nil {
+				return nil, errors.New("WrapResponse received non-nil error type with nil value for AdminService_SetCronSchedulePaused_Result.BadRequestError")
+			}
+			return &AdminService_SetCronSchedulePaused_Result{BadRequestError: e}, nil
+		case *shared.InternalServiceError:
+			if e == nil {
+				return nil, errors.New("WrapResponse received non-nil error type with nil value for AdminService_SetCronSchedulePaused_Result.InternalServiceError")
+			}
+			return &AdminService_SetCronSchedulePaused_Result{InternalServiceError: e}, nil
+		case *shared.EntityNotExistsError:
+			if e == nil {
+				return nil, errors.New("WrapResponse received non-nil error type with nil value for AdminService_SetCronSchedulePaused_Result.EntityNotExistError")
+			}
+			return &AdminService_SetCronSchedulePaused_Result{EntityNotExistError: e}, nil
+		case *shared.DomainNotActiveError:
+			if e == nil {
+				return nil, errors.New("WrapResponse received non-nil error type with nil value for AdminService_SetCronSchedulePaused_Result.DomainNotActiveError")
+			}
+			return &AdminService_SetCronSchedulePaused_Result{DomainNotActiveError: e}, nil
+		case *shared.AccessDeniedError:
+			if e == nil {
+				return nil, errors.New("WrapResponse received non-nil error type with nil value for AdminService_SetCronSchedulePaused_Result.AccessDeniedError")
+			}
+			return &AdminService_SetCronSchedulePaused_Result{AccessDeniedError: e}, nil
+		}
+
+		return nil, err
+	}
+	AdminService_SetCronSchedulePaused_Helper.UnwrapResponse = func(result *AdminService_SetCronSchedulePaused_Result) (err error) {
+		if result.BadRequestError != nil {
+			err = result.BadRequestError
+			return
+		}
+		if result.InternalServiceError != nil {
+			err = result.InternalServiceError
+			return
+		}
+		if result.EntityNotExistError != nil {
+			err = result.EntityNotExistError
+			return
+		}
+		if result.DomainNotActiveError != nil {
+			err = result.DomainNotActiveError
+			return
+		}
+		if result.AccessDeniedError != nil {
+			err = result.AccessDeniedError
+			return
+		}
+		return
+	}
+
+}
+
+// AdminService_SetCronSchedulePaused_Result represents the result of a AdminService.SetCronSchedulePaused function call.
+//
+// The result of a SetCronSchedulePaused execution is sent and received over the wire as this struct.
+type AdminService_SetCronSchedulePaused_Result struct {
+	BadRequestError      *shared.BadRequestError      `json:"badRequestError,omitempty"`
+	InternalServiceError *shared.InternalServiceError `json:"internalServiceError,omitempty"`
+	EntityNotExistError  *shared.EntityNotExistsError `json:"entityNotExistError,omitempty"`
+	DomainNotActiveError *shared.DomainNotActiveError `json:"domainNotActiveError,omitempty"`
+	AccessDeniedError    *shared.AccessDeniedError    `json:"accessDeniedError,omitempty"`
+}
+
+// ToWire translates a AdminService_SetCronSchedulePaused_Result struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *AdminService_SetCronSchedulePaused_Result) ToWire() (wire.Value, error) {
+	var (
+		fields [5]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.BadRequestError != nil {
+		w, err = v.BadRequestError.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 1, Value: w}
+		i++
+	}
+	if v.InternalServiceError != nil {
+		w, err = v.InternalServiceError.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 2, Value: w}
+		i++
+	}
+	if v.EntityNotExistError != nil {
+		w, err = v.EntityNotExistError.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 3, Value: w}
+		i++
+	}
+	if v.DomainNotActiveError != nil {
+		w, err = v.DomainNotActiveError.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 4, Value: w}
+		i++
+	}
+	if v.AccessDeniedError != nil {
+		w, err = v.AccessDeniedError.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 5, Value: w}
+		i++
+	}
+
+	if i > 1 {
+		return wire.Value{}, fmt.Errorf("AdminService_SetCronSchedulePaused_Result should have at most one field: got %v fields", i)
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+// FromWire deserializes a AdminService_SetCronSchedulePaused_Result struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a AdminService_SetCronSchedulePaused_Result struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v AdminService_SetCronSchedulePaused_Result
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *AdminService_SetCronSchedulePaused_Result) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 1:
+			if field.Value.Type() == wire.TStruct {
+				v.BadRequestError, err = _BadRequestError_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 2:
+			if field.Value.Type() == wire.TStruct {
+				v.InternalServiceError, err = _InternalServiceError_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 3:
+			if field.Value.Type() == wire.TStruct {
+				v.EntityNotExistError, err = _EntityNotExistsError_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 4:
+			if field.Value.Type() == wire.TStruct {
+				v.DomainNotActiveError, err = _DomainNotActiveError_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 5:
+			if field.Value.Type() == wire.TStruct {
+				v.AccessDeniedError, err = _AccessDeniedError_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+
+	count := 0
+	if v.BadRequestError != nil {
+		count++
+	}
+	if v.InternalServiceError != nil {
+		count++
+	}
+	if v.EntityNotExistError != nil {
+		count++
+	}
+	if v.DomainNotActiveError != nil {
+		count++
+	}
+	if v.AccessDeniedError != nil {
+		count++
+	}
+	if count > 1 {
+		return fmt.Errorf("AdminService_SetCronSchedulePaused_Result should have at most one field: got %v fields", count)
+	}
+
+	return nil
+}
+
+// String returns a readable string representation of a AdminService_SetCronSchedulePaused_Result
+// struct.
+func (v *AdminService_SetCronSchedulePaused_Result) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [5]string
+	i := 0
+	if v.BadRequestError != nil {
+		fields[i] = fmt.Sprintf("BadRequestError: %v", v.BadRequestError)
+		i++
+	}
+	if v.InternalServiceError != nil {
+		fields[i] = fmt.Sprintf("InternalServiceError: %v", v.InternalServiceError)
+		i++
+	}
+	if v.EntityNotExistError != nil {
+		fields[i] = fmt.Sprintf("EntityNotExistError: %v", v.EntityNotExistError)
+		i++
+	}
+	if v.DomainNotActiveError != nil {
+		fields[i] = fmt.Sprintf("DomainNotActiveError: %v", v.DomainNotActiveError)
+		i++
+	}
+	if v.AccessDeniedError != nil {
+		fields[i] = fmt.Sprintf("AccessDeniedError: %v", v.AccessDeniedError)
+		i++
+	}
+
+	return fmt.Sprintf("AdminService_SetCronSchedulePaused_Result{%v}", strings.Join(fields[:i], ", "))
+}
+
+// Equals returns true if all the fields of this AdminService_SetCronSchedulePaused_Result match the
+// provided AdminService_SetCronSchedulePaused_Result.
+//
+// This function performs a deep comparison.
+func (v *AdminService_SetCronSchedulePaused_Result) Equals(rhs *AdminService_SetCronSchedulePaused_Result) bool {
+	if v == nil {
+		return rhs == nil
+	} else if rhs == nil {
+		return false
+	}
+	if !((v.BadRequestError == nil && rhs.BadRequestError == nil) || (v.BadRequestError != nil && rhs.BadRequestError != nil && v.BadRequestError.Equals(rhs.BadRequestError))) {
+		return false
+	}
+	if !((v.InternalServiceError == nil && rhs.InternalServiceError == nil) || (v.InternalServiceError != nil && rhs.InternalServiceError != nil && v.InternalServiceError.Equals(rhs.InternalServiceError))) {
+		return false
+	}
+	if !((v.EntityNotExistError == nil && rhs.EntityNotExistError == nil) || (v.EntityNotExistError != nil && rhs.EntityNotExistError != nil && v.EntityNotExistError.Equals(rhs.EntityNotExistError))) {
+		return false
+	}
+	if !((v.DomainNotActiveError == nil && rhs.DomainNotActiveError == nil) || (v.DomainNotActiveError != nil && rhs.DomainNotActiveError != nil && v.DomainNotActiveError.Equals(rhs.DomainNotActiveError))) {
+		return false
+	}
+	if !((v.AccessDeniedError == nil && rhs.AccessDeniedError == nil) || (v.AccessDeniedError != nil && rhs.AccessDeniedError != nil && v.AccessDeniedError.Equals(rhs.AccessDeniedError))) {
+		return false
+	}
+
+	return true
+}
+
+// MarshalLogObject implements zapcore.ObjectMarshaler, enabling
+// fast logging of AdminService_SetCronSchedulePaused_Result.
+func (v *AdminService_SetCronSchedulePaused_Result) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+	if v == nil {
+		return nil
+	}
+	if v.BadRequestError != nil {
+		err = multierr.Append(err, enc.AddObject("badRequestError", v.BadRequestError))
+	}
+	if v.InternalServiceError != nil {
+		err = multierr.Append(err, enc.AddObject("internalServiceError", v.InternalServiceError))
+	}
+	if v.EntityNotExistError != nil {
+		err = multierr.Append(err, enc.AddObject("entityNotExistError", v.EntityNotExistError))
+	}
+	if v.DomainNotActiveError != nil {
+		err = multierr.Append(err, enc.AddObject("domainNotActiveError", v.DomainNotActiveError))
+	}
+	if v.AccessDeniedError != nil {
+		err = multierr.Append(err, enc.AddObject("accessDeniedError", v.AccessDeniedError))
+	}
+	return err
+}
+
+// GetBadRequestError returns the value of BadRequestError if it is set or its
+// zero value if it is unset.
+func (v *AdminService_SetCronSchedulePaused_Result) GetBadRequestError() (o *shared.BadRequestError) {
+	if v != nil && v.BadRequestError != nil {
+		return v.BadRequestError
+	}
+
+	return
+}
+
+// IsSetBadRequestError returns true if BadRequestError is not nil.
+func (v *AdminService_SetCronSchedulePaused_Result) IsSetBadRequestError() bool {
+	return v != nil && v.BadRequestError != nil
+}
+
+// GetInternalServiceError returns the value of InternalServiceError if it is set or its
+// zero value if it is unset.
+func (v *AdminService_SetCronSchedulePaused_Result) GetInternalServiceError() (o *shared.InternalServiceError) {
+	if v != nil && v.InternalServiceError != nil {
+		return v.InternalServiceError
+	}
+
+	return
+}
+
+// IsSetInternalServiceError returns true if InternalServiceError is not nil.
+func (v *AdminService_SetCronSchedulePaused_Result) IsSetInternalServiceError() bool {
+	return v != nil && v.InternalServiceError != nil
+}
+
+// GetEntityNotExistError returns the value of EntityNotExistError if it is set or its
+// zero value if it is unset.
+func (v *AdminService_SetCronSchedulePaused_Result) GetEntityNotExistError() (o *shared.EntityNotExistsError) {
+	if v != nil && v.EntityNotExistError != nil {
+		return v.EntityNotExistError
+	}
+
+	return
+}
+
+// IsSetEntityNotExistError returns true if EntityNotExistError is not nil.
+func (v *AdminService_SetCronSchedulePaused_Result) IsSetEntityNotExistError() bool {
+	return v != nil && v.EntityNotExistError != nil
+}
+
+// GetDomainNotActiveError returns the value of DomainNotActiveError if it is set or its
+// zero value if it is unset.
+func (v *AdminService_SetCronSchedulePaused_Result) GetDomainNotActiveError() (o *shared.DomainNotActiveError) {
+	if v != nil && v.DomainNotActiveError != nil {
+		return v.DomainNotActiveError
+	}
+
+	return
+}
+
+// IsSetDomainNotActiveError returns true if DomainNotActiveError is not nil.
+func (v *AdminService_SetCronSchedulePaused_Result) IsSetDomainNotActiveError() bool {
+	return v != nil && v.DomainNotActiveError != nil
+}
+
+// GetAccessDeniedError returns the value of AccessDeniedError if it is set or its
+// zero value if it is unset.
+func (v *AdminService_SetCronSchedulePaused_Result) GetAccessDeniedError() (o *shared.AccessDeniedError) {
+	if v != nil && v.AccessDeniedError != nil {
+		return v.AccessDeniedError
+	}
+
+	return
+}
+
+// IsSetAccessDeniedError returns true if AccessDeniedError is not nil.
+func (v *AdminService_SetCronSchedulePaused_Result) IsSetAccessDeniedError() bool {
+	return v != nil && v.AccessDeniedError != nil
+}
+
+// MethodName returns the name of the Thrift function as specified in
+// the IDL, for which this struct represent the result.
+//
+// This will always be "SetCronSchedulePaused" for this struct.
+func (v *AdminService_SetCronSchedulePaused_Result) MethodName() string {
+	return "SetCronSchedulePaused"
+}
+
+// EnvelopeType returns the kind of value inside this struct.
+//
+// This will always be Reply for this struct.
+func (v *AdminService_SetCronSchedulePaused_Result) EnvelopeType() wire.EnvelopeType {
+	return wire.Reply
+}
+
+// AdminService_SetSchedulePaused_Args represents the arguments for the AdminService.SetSchedulePaused function.
+//
+// The arguments for SetSchedulePaused are sent and received over the wire as this struct.
+type AdminService_SetSchedulePaused_Args struct {
+	Request *shared.SetSchedulePausedRequest `json:"request,omitempty"`
+}
+
+// ToWire translates a AdminService_SetSchedulePaused_Args struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *AdminService_SetSchedulePaused_Args) ToWire() (wire.Value, error) {
+	var (
+		fields [1]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.Request != nil {
+		w, err = v.Request.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 1, Value: w}
+		i++
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+func _SetSchedulePausedRequest_Read(w wire.Value) (*shared.SetSchedulePausedRequest, error) {
+	var v shared.SetSchedulePausedRequest
+	err := v.FromWire(w)
+	return &v, err
+}
+
+// FromWire deserializes a AdminService_SetSchedulePaused_Args struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a AdminService_SetSchedulePaused_Args struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v AdminService_SetSchedulePaused_Args
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *AdminService_SetSchedulePaused_Args) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 1:
+			if field.Value.Type() == wire.TStruct {
+				v.Request, err = _SetSchedulePausedRequest_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+
+	return nil
+}
+
+// String returns a readable string representation of a AdminService_SetSchedulePaused_Args
+// struct.
+func (v *AdminService_SetSchedulePaused_Args) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [1]string
+	i := 0
+	if v.Request != nil {
+		fields[i] = fmt.Sprintf("Request: %v", v.Request)
+		i++
+	}
+
+	return fmt.Sprintf("AdminService_SetSchedulePaused_Args{%v}", strings.Join(fields[:i], ", "))
+}
+
+// Equals returns true if all the fields of this AdminService_SetSchedulePaused_Args match the
+// provided AdminService_SetSchedulePaused_Args.
+//
+// This function performs a deep comparison.
+func (v *AdminService_SetSchedulePaused_Args) Equals(rhs *AdminService_SetSchedulePaused_Args) bool {
+	if v == nil {
+		return rhs == nil
+	} else if rhs == nil {
+		return false
+	}
+	if !((v.Request == nil && rhs.Request == nil) || (v.Request != nil && rhs.Request != nil && v.Request.Equals(rhs.Request))) {
+		return false
+	}
+
+	return true
+}
+
+// MarshalLogObject implements zapcore.ObjectMarshaler, enabling
+// fast logging of AdminService_SetSchedulePaused_Args.
+func (v *AdminService_SetSchedulePaused_Args) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+	if v == nil {
+		return nil
+	}
+	if v.Request != nil {
+		err = multierr.Append(err, enc.AddObject("request", v.Request))
+	}
+	return err
+}
+
+// GetRequest returns the value of Request if it is set or its
+// zero value if it is unset.
+func (v *AdminService_SetSchedulePaused_Args) GetRequest() (o *shared.SetSchedulePausedRequest) {
+	if v != nil && v.Request != nil {
+		return v.Request
+	}
+
+	return
+}
+
+// IsSetRequest returns true if Request is not nil.
+func (v *AdminService_SetSchedulePaused_Args) IsSetRequest() bool {
+	return v != nil && v.Request != nil
+}
+
+// MethodName returns the name of the Thrift function as specified in
+// the IDL, for which this struct represent the arguments.
+//
+// This will always be "SetSchedulePaused" for this struct.
+func (v *AdminService_SetSchedulePaused_Args) MethodName() string {
+	return "SetSchedulePaused"
+}
+
+// EnvelopeType returns the kind of value inside this struct.
+//
+// This will always be Call for this struct.
+func (v *AdminService_SetSchedulePaused_Args) EnvelopeType() wire.EnvelopeType {
+	return wire.Call
+}
+
+// AdminService_SetSchedulePaused_Helper provides functions that aid in handling the
+// parameters and return values of the AdminService.SetSchedulePaused
+// function.
+var AdminService_SetSchedulePaused_Helper = struct {
+	// Args accepts the parameters of SetSchedulePaused in-order and returns
+	// the arguments struct for the function.
+	Args func(
+		request *shared.SetSchedulePausedRequest,
+	) *AdminService_SetSchedulePaused_Args
+
+	// IsException returns true if the given error can be thrown
+	// by SetSchedulePaused.
+	//
+	// An error can be thrown by SetSchedulePaused only if the
+	// corresponding exception type was mentioned in the 'throws'
+	// section for it in the Thrift file.
+	IsException func(error) bool
+
+	// WrapResponse returns the result struct for SetSchedulePaused
+	// given the error returned by it. The provided error may
+	// be nil if SetSchedulePaused did not fail.
+	//
+	// This allows mapping errors returned by SetSchedulePaused into a
+	// serializable result struct. WrapResponse returns a
+	// non-nil error if the provided error cannot be thrown by
+	// SetSchedulePaused
+	//
+	//   err := SetSchedulePaused(args)
+	//   result, err := AdminService_SetSchedulePaused_Helper.WrapResponse(err)
+	//   if err != nil {
+	//     return fmt.Errorf("unexpected error from SetSchedulePaused: %v", err)
+	//   }
+	//   serialize(result)
+	WrapResponse func(error) (*AdminService_SetSchedulePaused_Result, error)
+
+	// UnwrapResponse takes the result struct for SetSchedulePaused
+	// and returns the erorr returned by it (if any).
+	//
+	// The error is non-nil only if SetSchedulePaused threw an
+	// exception.
+	//
+	//   result := deserialize(bytes)
+	//   err := AdminService_SetSchedulePaused_Helper.UnwrapResponse(result)
+	UnwrapResponse func(*AdminService_SetSchedulePaused_Result) error
+}{}
+
+func init() {
+	AdminService_SetSchedulePaused_Helper.Args = func(
+		request *shared.SetSchedulePausedRequest,
+	) *AdminService_SetSchedulePaused_Args {
+		return &AdminService_SetSchedulePaused_Args{
+			Request: request,
+		}
+	}
+
+	AdminService_SetSchedulePaused_Helper.IsException = func(err error) bool {
+		switch err.(type) {
+		case *shared.BadRequestError:
+			return true
+		case *shared.InternalServiceError:
+			return true
+		case *shared.EntityNotExistsError:
+			return true
+		case *shared.AccessDeniedError:
+			return true
+		default:
+			return false
+		}
+	}
+
+	AdminService_SetSchedulePaused_Helper.WrapResponse = func(err error) (*AdminService_SetSchedulePaused_Result, error) {
+		if err == nil {
+			return &AdminService_SetSchedulePaused_Result{}, nil
+		}
+
+		switch e := err.(type) {
+		case *shared.BadRequestError:
+			if e == nil {
+				return nil, errors.New("WrapResponse received non-nil error type with nil value for AdminService_SetSchedulePaused_Result.BadRequestError")
+			}
+			return &AdminService_SetSchedulePaused_Result{BadRequestError: e}, nil
+		case *shared.InternalServiceError:
+			if e == nil {
+				return nil, errors.New("WrapResponse received non-nil error type with nil value for AdminService_SetSchedulePaused_Result.InternalServiceError")
+			}
+			return &AdminService_SetSchedulePaused_Result{InternalServiceError: e}, nil
+		case *shared.EntityNotExistsError:
+			if e == nil {
+				return nil, errors.New("WrapResponse received non-nil error type with nil value for AdminService_SetSchedulePaused_Result.EntityNotExistError")
+			}
+			return &AdminService_SetSchedulePaused_Result{EntityNotExistError: e}, nil
+		case *shared.AccessDeniedError:
+			if e == nil {
+				return nil, errors.New("WrapResponse received non-nil error type with nil value for AdminService_SetSchedulePaused_Result.AccessDeniedError")
+			}
+			return &AdminService_SetSchedulePaused_Result{AccessDeniedError: e}, nil
+		}
+
+		return nil, err
+	}
+	AdminService_SetSchedulePaused_Helper.UnwrapResponse = func(result *AdminService_SetSchedulePaused_Result) (err error) {
+		if result.BadRequestError != nil {
+			err = result.BadRequestError
+			return
+		}
+		if result.InternalServiceError != nil {
+			err = result.InternalServiceError
+			return
+		}
+		if result.EntityNotExistError != nil {
+			err = result.EntityNotExistError
+			return
+		}
+		if result.AccessDeniedError != nil {
+			err = result.AccessDeniedError
+			return
+		}
+		return
+	}
+
+}
+
+// AdminService_SetSchedulePaused_Result represents the result of a AdminService.SetSchedulePaused function call.
+//
+// The result of a SetSchedulePaused execution is sent and received over the wire as this struct.
+type AdminService_SetSchedulePaused_Result struct {
+	BadRequestError      *shared.BadRequestError      `json:"badRequestError,omitempty"`
+	InternalServiceError *shared.InternalServiceError `json:"internalServiceError,omitempty"`
+	EntityNotExistError  *shared.EntityNotExistsError `json:"entityNotExistError,omitempty"`
+	AccessDeniedError    *shared.AccessDeniedError    `json:"accessDeniedError,omitempty"`
+}
+
+// ToWire translates a AdminService_SetSchedulePaused_Result struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *AdminService_SetSchedulePaused_Result) ToWire() (wire.Value, error) {
+	var (
+		fields [4]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.BadRequestError != nil {
+		w, err = v.BadRequestError.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 1, Value: w}
+		i++
+	}
+	if v.InternalServiceError != nil {
+		w, err = v.InternalServiceError.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 2, Value: w}
+		i++
+	}
+	if v.EntityNotExistError != nil {
+		w, err = v.EntityNotExistError.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 3, Value: w}
+		i++
+	}
+	if v.AccessDeniedError != nil {
+		w, err = v.AccessDeniedError.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 4, Value: w}
+		i++
+	}
+
+	if i > 1 {
+		return wire.Value{}, fmt.Errorf("AdminService_SetSchedulePaused_Result should have at most one field: got %v fields", i)
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+// FromWire deserializes a AdminService_SetSchedulePaused_Result struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a AdminService_SetSchedulePaused_Result struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v AdminService_SetSchedulePaused_Result
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *AdminService_SetSchedulePaused_Result) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 1:
+			if field.Value.Type() == wire.TStruct {
+				v.BadRequestError, err = _BadRequestError_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 2:
+			if field.Value.Type() == wire.TStruct {
+				v.InternalServiceError, err = _InternalServiceError_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 3:
+			if field.Value.Type() == wire.TStruct {
+				v.EntityNotExistError, err = _EntityNotExistsError_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 4:
+			if field.Value.Type() == wire.TStruct {
+				v.AccessDeniedError, err = _AccessDeniedError_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+
+	count := 0
+	if v.BadRequestError != nil {
+		count++
+	}
+	if v.InternalServiceError != nil {
+		count++
+	}
+	if v.EntityNotExistError != nil {
+		count++
+	}
+	if v.AccessDeniedError != nil {
+		count++
+	}
+	if count > 1 {
+		return fmt.Errorf("AdminService_SetSchedulePaused_Result should have at most one field: got %v fields", count)
+	}
+
+	return nil
+}
+
+// String returns a readable string representation of a AdminService_SetSchedulePaused_Result
+// struct.
+func (v *AdminService_SetSchedulePaused_Result) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [4]string
+	i := 0
+	if v.BadRequestError != nil {
+		fields[i] = fmt.Sprintf("BadRequestError: %v", v.BadRequestError)
+		i++
+	}
+	if v.InternalServiceError != nil {
+		fields[i] = fmt.Sprintf("InternalServiceError: %v", v.InternalServiceError)
+		i++
+	}
+	if v.EntityNotExistError != nil {
+		fields[i] = fmt.Sprintf("EntityNotExistError: %v", v.EntityNotExistError)
+		i++
+	}
+	if v.AccessDeniedError != nil {
+		fields[i] = fmt.Sprintf("AccessDeniedError: %v", v.AccessDeniedError)
+		i++
+	}
+
+	return fmt.Sprintf("AdminService_SetSchedulePaused_Result{%v}", strings.Join(fields[:i], ", "))
+}
+
+// Equals returns true if all the fields of this AdminService_SetSchedulePaused_Result match the
+// provided AdminService_SetSchedulePaused_Result.
+//
+// This function performs a deep comparison.
+func (v *AdminService_SetSchedulePaused_Result) Equals(rhs *AdminService_SetSchedulePaused_Result) bool {
+	if v == nil {
+		return rhs == nil
+	} else if rhs == nil {
+		return false
+	}
+	if !((v.BadRequestError == nil && rhs.BadRequestError == nil) || (v.BadRequestError != nil && rhs.BadRequestError != nil && v.BadRequestError.Equals(rhs.BadRequestError))) {
+		return false
+	}
+	if !((v.InternalServiceError == nil && rhs.InternalServiceError == nil) || (v.InternalServiceError != nil && rhs.InternalServiceError != nil && v.InternalServiceError.Equals(rhs.InternalServiceError))) {
+		return false
+	}
+	if !((v.EntityNotExistError == nil && rhs.EntityNotExistError == nil) || (v.EntityNotExistError != nil && rhs.EntityNotExistError != nil && v.EntityNotExistError.Equals(rhs.EntityNotExistError))) {
+		return false
+	}
+	if !((v.AccessDeniedError == nil && rhs.AccessDeniedError == nil) || (v.AccessDeniedError != nil && rhs.AccessDeniedError != nil && v.AccessDeniedError.Equals(rhs.AccessDeniedError))) {
+		return false
+	}
+
+	return true
+}
+
+// MarshalLogObject implements zapcore.ObjectMarshaler, enabling
+// fast logging of AdminService_SetSchedulePaused_Result.
+func (v *AdminService_SetSchedulePaused_Result) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+	if v == nil {
+		return nil
+	}
+	if v.BadRequestError != nil {
+		err = multierr.Append(err, enc.AddObject("badRequestError", v.BadRequestError))
+	}
+	if v.InternalServiceError != nil {
+		err = multierr.Append(err, enc.AddObject("internalServiceError", v.InternalServiceError))
+	}
+	if v.EntityNotExistError != nil {
+		err = multierr.Append(err, enc.AddObject("entityNotExistError", v.EntityNotExistError))
+	}
+	if v.AccessDeniedError != nil {
+		err = multierr.Append(err, enc.AddObject("accessDeniedError", v.AccessDeniedError))
+	}
+	return err
+}
+
+// GetBadRequestError returns the value of BadRequestError if it is set or its
+// zero value if it is unset.
+func (v *AdminService_SetSchedulePaused_Result) GetBadRequestError() (o *shared.BadRequestError) {
+	if v != nil && v.BadRequestError != nil {
+		return v.BadRequestError
+	}
+
+	return
+}
+
+// IsSetBadRequestError returns true if BadRequestError is not nil.
+func (v *AdminService_SetSchedulePaused_Result) IsSetBadRequestError() bool {
+	return v != nil && v.BadRequestError != nil
+}
+
+// GetInternalServiceError returns the value of InternalServiceError if it is set or its
+// zero value if it is unset.
+func (v *AdminService_SetSchedulePaused_Result) GetInternalServiceError() (o *shared.InternalServiceError) {
+	if v != nil && v.InternalServiceError != nil {
+		return v.InternalServiceError
+	}
+
+	return
+}
+
+// IsSetInternalServiceError returns true if InternalServiceError is not nil.
+func (v *AdminService_SetSchedulePaused_Result) IsSetInternalServiceError() bool {
+	return v != nil && v.InternalServiceError != nil
+}
+
+// GetEntityNotExistError returns the value of EntityNotExistError if it is set or its
+// zero value if it is unset.
+func (v *AdminService_SetSchedulePaused_Result) GetEntityNotExistError() (o *shared.EntityNotExistsError) {
+	if v != nil && v.EntityNotExistError != nil {
+		return v.EntityNotExistError
+	}
+
+	return
+}
+
+// IsSetEntityNotExistError returns true if EntityNotExistError is not nil.
+func (v *AdminService_SetSchedulePaused_Result) IsSetEntityNotExistError() bool {
+	return v != nil && v.EntityNotExistError != nil
+}
+
+// GetAccessDeniedError returns the value of AccessDeniedError if it is set or its
+// zero value if it is unset.
+func (v *AdminService_SetSchedulePaused_Result) GetAccessDeniedError() (o *shared.AccessDeniedError) {
+	if v != nil && v.AccessDeniedError != nil {
+		return v.AccessDeniedError
+	}
+
+	return
+}
+
+// IsSetAccessDeniedError returns true if AccessDeniedError is not nil.
+func (v *AdminService_SetSchedulePaused_Result) IsSetAccessDeniedError() bool {
+	return v != nil && v.AccessDeniedError != nil
+}
+
+// MethodName returns the name of the Thrift function as specified in
+// the IDL, for which this struct represent the result.
+//
+// This will always be "SetSchedulePaused" for this struct.
+func (v *AdminService_SetSchedulePaused_Result) MethodName() string {
+	return "SetSchedulePaused"
+}
+
+// EnvelopeType returns the kind of value inside this struct.
+//
+// This will always be Reply for this struct.
+func (v *AdminService_SetSchedulePaused_Result) EnvelopeType() wire.EnvelopeType {
+	return wire.Reply
+}
+
+// AdminService_SetWorkflowExecutionNotes_Args represents the arguments for the AdminService.SetWorkflowExecutionNotes function.
+//
+// The arguments for SetWorkflowExecutionNotes are sent and received over the wire as this struct.
+type AdminService_SetWorkflowExecutionNotes_Args struct {
+	Request *shared.SetWorkflowExecutionNotesRequest `json:"request,omitempty"`
+}
+
+// ToWire translates a AdminService_SetWorkflowExecutionNotes_Args struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *AdminService_SetWorkflowExecutionNotes_Args) ToWire() (wire.Value, error) {
+	var (
+		fields [1]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.Request != nil {
+		w, err = v.Request.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 1, Value: w}
+		i++
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+func _SetWorkflowExecutionNotesRequest_Read(w wire.Value) (*shared.SetWorkflowExecutionNotesRequest, error) {
+	var v shared.SetWorkflowExecutionNotesRequest
+	err := v.FromWire(w)
+	return &v, err
+}
+
+// FromWire deserializes a AdminService_SetWorkflowExecutionNotes_Args struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a AdminService_SetWorkflowExecutionNotes_Args struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v AdminService_SetWorkflowExecutionNotes_Args
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *AdminService_SetWorkflowExecutionNotes_Args) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 1:
+			if field.Value.Type() == wire.TStruct {
+				v.Request, err = _SetWorkflowExecutionNotesRequest_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+
+	return nil
+}
+
+// String returns a readable string representation of a AdminService_SetWorkflowExecutionNotes_Args
+// struct.
+func (v *AdminService_SetWorkflowExecutionNotes_Args) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [1]string
+	i := 0
+	if v.Request != nil {
+		fields[i] = fmt.Sprintf("Request: %v", v.Request)
+		i++
+	}
+
+	return fmt.Sprintf("AdminService_SetWorkflowExecutionNotes_Args{%v}", strings.Join(fields[:i], ", "))
+}
+
+// Equals returns true if all the fields of this AdminService_SetWorkflowExecutionNotes_Args match the
+// provided AdminService_SetWorkflowExecutionNotes_Args.
+//
+// This function performs a deep comparison.
+func (v *AdminService_SetWorkflowExecutionNotes_Args) Equals(rhs *AdminService_SetWorkflowExecutionNotes_Args) bool {
+	if v == nil {
+		return rhs == nil
+	} else if rhs == nil {
+		return false
+	}
+	if !((v.Request == nil && rhs.Request == nil) || (v.Request != nil && rhs.Request != nil && v.Request.Equals(rhs.Request))) {
+		return false
+	}
+
+	return true
+}
+
+// MarshalLogObject implements zapcore.ObjectMarshaler, enabling
+// fast logging of AdminService_SetWorkflowExecutionNotes_Args.
+func (v *AdminService_SetWorkflowExecutionNotes_Args) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+	if v == nil {
+		return nil
+	}
+	if v.Request != nil {
+		err = multierr.Append(err, enc.AddObject("request", v.Request))
+	}
+	return err
+}
+
+// GetRequest returns the value of Request if it is set or its
+// zero value if it is unset.
+func (v *AdminService_SetWorkflowExecutionNotes_Args) GetRequest() (o *shared.SetWorkflowExecutionNotesRequest) {
+	if v != nil && v.Request != nil {
+		return v.Request
+	}
+
+	return
+}
+
+// IsSetRequest returns true if Request is not nil.
+func (v *AdminService_SetWorkflowExecutionNotes_Args) IsSetRequest() bool {
+	return v != nil && v.Request != nil
+}
+
+// MethodName returns the name of the Thrift function as specified in
+// the IDL, for which this struct represent the arguments.
+//
+// This will always be "SetWorkflowExecutionNotes" for this struct.
+func (v *AdminService_SetWorkflowExecutionNotes_Args) MethodName() string {
+	return "SetWorkflowExecutionNotes"
+}
+
+// EnvelopeType returns the kind of value inside this struct.
+//
+// This will always be Call for this struct.
+func (v *AdminService_SetWorkflowExecutionNotes_Args) EnvelopeType() wire.EnvelopeType {
+	return wire.Call
+}
+
+// AdminService_SetWorkflowExecutionNotes_Helper provides functions that aid in handling the
+// parameters and return values of the AdminService.SetWorkflowExecutionNotes
+// function.
+var AdminService_SetWorkflowExecutionNotes_Helper = struct {
+	// Args accepts the parameters of SetWorkflowExecutionNotes in-order and returns
+	// the arguments struct for the function.
+	Args func(
+		request *shared.SetWorkflowExecutionNotesRequest,
+	) *AdminService_SetWorkflowExecutionNotes_Args
+
+	// IsException returns true if the given error can be thrown
+	// by SetWorkflowExecutionNotes.
+	//
+	// An error can be thrown by SetWorkflowExecutionNotes only if the
+	// corresponding exception type was mentioned in the 'throws'
+	// section for it in the Thrift file.
+	IsException func(error) bool
+
+	// WrapResponse returns the result struct for SetWorkflowExecutionNotes
+	// given the error returned by it. The provided error may
+	// be nil if SetWorkflowExecutionNotes did not fail.
+	//
+	// This allows mapping errors returned by SetWorkflowExecutionNotes into a
+	// serializable result struct. WrapResponse returns a
+	// non-nil error if the provided error cannot be thrown by
+	// SetWorkflowExecutionNotes
+	//
+	//   err := SetWorkflowExecutionNotes(args)
+	//   result, err := AdminService_SetWorkflowExecutionNotes_Helper.WrapResponse(err)
+	//   if err != nil {
+	//     return fmt.Errorf("unexpected error from SetWorkflowExecutionNotes: %v", err)
+	//   }
+	//   serialize(result)
+	WrapResponse func(error) (*AdminService_SetWorkflowExecutionNotes_Result, error)
+
+	// UnwrapResponse takes the result struct for SetWorkflowExecutionNotes
+	// and returns the erorr returned by it (if any).
+	//
+	// The error is non-nil only if SetWorkflowExecutionNotes threw an
+	// exception.
+	//
+	//   result := deserialize(bytes)
+	//   err := AdminService_SetWorkflowExecutionNotes_Helper.UnwrapResponse(result)
+	UnwrapResponse func(*AdminService_SetWorkflowExecutionNotes_Result) error
+}{}
+
+func init() {
+	AdminService_SetWorkflowExecutionNotes_Helper.Args = func(
+		request *shared.SetWorkflowExecutionNotesRequest,
+	) *AdminService_SetWorkflowExecutionNotes_Args {
+		return &AdminService_SetWorkflowExecutionNotes_Args{
+			Request: request,
+		}
+	}
+
+	AdminService_SetWorkflowExecutionNotes_Helper.IsException = func(err error) bool {
+		switch err.(type) {
+		case *shared.BadRequestError:
+			return true
+		case *shared.InternalServiceError:
+			return true
+		case *shared.EntityNotExistsError:
+			return true
+		case *shared.AccessDeniedError:
+			return true
+		default:
+			return false
+		}
+	}
+
+	AdminService_SetWorkflowExecutionNotes_Helper.WrapResponse = func(err error) (*AdminService_SetWorkflowExecutionNotes_Result, error) {
+		if err == nil {
+			return &AdminService_SetWorkflowExecutionNotes_Result{}, nil
+		}
+
+		switch e := err.(type) {
+		case *shared.BadRequestError:
+			if e == nil {
+				return nil, errors.New("WrapResponse received non-nil error type with nil value for AdminService_SetWorkflowExecutionNotes_Result.BadRequestError")
+			}
+			return &AdminService_SetWorkflowExecutionNotes_Result{BadRequestError: e}, nil
+		case *shared.InternalServiceError:
+			if e == nil {
+				return nil, errors.New("WrapResponse received non-nil error type with nil value for AdminService_SetWorkflowExecutionNotes_Result.InternalServiceError")
+			}
+			return &AdminService_SetWorkflowExecutionNotes_Result{InternalServiceError: e}, nil
+		case *shared.EntityNotExistsError:
+			if e == nil {
+				return nil, errors.New("WrapResponse received non-nil error type with nil value for AdminService_SetWorkflowExecutionNotes_Result.EntityNotExistError")
+			}
+			return &AdminService_SetWorkflowExecutionNotes_Result{EntityNotExistError: e}, nil
+		case *shared.AccessDeniedError:
+			if e == nil {
+				return nil, errors.New("WrapResponse received non-nil error type with nil value for AdminService_SetWorkflowExecutionNotes_Result.AccessDeniedError")
+			}
+			return &AdminService_SetWorkflowExecutionNotes_Result{AccessDeniedError: e}, nil
+		}
+
+		return nil, err
+	}
+	AdminService_SetWorkflowExecutionNotes_Helper.UnwrapResponse = func(result *AdminService_SetWorkflowExecutionNotes_Result) (err error) {
+		if result.BadRequestError != nil {
+			err = result.BadRequestError
+			return
+		}
+		if result.InternalServiceError != nil {
+			err = result.InternalServiceError
+			return
+		}
+		if result.EntityNotExistError != nil {
+			err = result.EntityNotExistError
+			return
+		}
+		if result.AccessDeniedError != nil {
+			err = result.AccessDeniedError
+			return
+		}
+		return
+	}
+
+}
+
+// AdminService_SetWorkflowExecutionNotes_Result represents the result of a AdminService.SetWorkflowExecutionNotes function call.
+//
+// The result of a SetWorkflowExecutionNotes execution is sent and received over the wire as this struct.
+type AdminService_SetWorkflowExecutionNotes_Result struct {
+	BadRequestError      *shared.BadRequestError      `json:"badRequestError,omitempty"`
+	InternalServiceError *shared.InternalServiceError `json:"internalServiceError,omitempty"`
+	EntityNotExistError  *shared.EntityNotExistsError `json:"entityNotExistError,omitempty"`
+	AccessDeniedError    *shared.AccessDeniedError    `json:"accessDeniedError,omitempty"`
+}
+
+// ToWire translates a AdminService_SetWorkflowExecutionNotes_Result struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *AdminService_SetWorkflowExecutionNotes_Result) ToWire() (wire.Value, error) {
+	var (
+		fields [4]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.BadRequestError != nil {
+		w, err = v.BadRequestError.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 1, Value: w}
+		i++
+	}
+	if v.InternalServiceError != nil {
+		w, err = v.InternalServiceError.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 2, Value: w}
+		i++
+	}
+	if v.EntityNotExistError != nil {
+		w, err = v.EntityNotExistError.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 3, Value: w}
+		i++
+	}
+	if v.AccessDeniedError != nil {
+		w, err = v.AccessDeniedError.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 4, Value: w}
+		i++
+	}
+
+	if i > 1 {
+		return wire.Value{}, fmt.Errorf("AdminService_SetWorkflowExecutionNotes_Result should have at most one field: got %v fields", i)
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+// FromWire deserializes a AdminService_SetWorkflowExecutionNotes_Result struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a AdminService_SetWorkflowExecutionNotes_Result struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v AdminService_SetWorkflowExecutionNotes_Result
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *AdminService_SetWorkflowExecutionNotes_Result) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 1:
+			if field.Value.Type() == wire.TStruct {
+				v.BadRequestError, err = _BadRequestError_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 2:
+			if field.Value.Type() == wire.TStruct {
+				v.InternalServiceError, err = _InternalServiceError_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 3:
+			if field.Value.Type() == wire.TStruct {
+				v.EntityNotExistError, err = _EntityNotExistsError_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 4:
+			if field.Value.Type() == wire.TStruct {
+				v.AccessDeniedError, err = _AccessDeniedError_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+
+	count := 0
+	if v.BadRequestError != nil {
+		count++
+	}
+	if v.InternalServiceError != nil {
+		count++
+	}
+	if v.EntityNotExistError != nil {
+		count++
+	}
+	if v.AccessDeniedError != nil {
+		count++
+	}
+	if count > 1 {
+		return fmt.Errorf("AdminService_SetWorkflowExecutionNotes_Result should have at most one field: got %v fields", count)
+	}
+
+	return nil
+}
+
+// String returns a readable string representation of a AdminService_SetWorkflowExecutionNotes_Result
+// struct.
+func (v *AdminService_SetWorkflowExecutionNotes_Result) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [4]string
+	i := 0
+	if v.BadRequestError != nil {
+		fields[i] = fmt.Sprintf("BadRequestError: %v", v.BadRequestError)
+		i++
+	}
+	if v.InternalServiceError != nil {
+		fields[i] = fmt.Sprintf("InternalServiceError: %v", v.InternalServiceError)
+		i++
+	}
+	if v.EntityNotExistError != nil {
+		fields[i] = fmt.Sprintf("EntityNotExistError: %v", v.EntityNotExistError)
+		i++
+	}
+	if v.AccessDeniedError != nil {
+		fields[i] = fmt.Sprintf("AccessDeniedError: %v", v.AccessDeniedError)
+		i++
+	}
+
+	return fmt.Sprintf("AdminService_SetWorkflowExecutionNotes_Result{%v}", strings.Join(fields[:i], ", "))
+}
+
+// Equals returns true if all the fields of this AdminService_SetWorkflowExecutionNotes_Result match the
+// provided AdminService_SetWorkflowExecutionNotes_Result.
+//
+// This function performs a deep comparison.
+func (v *AdminService_SetWorkflowExecutionNotes_Result) Equals(rhs *AdminService_SetWorkflowExecutionNotes_Result) bool {
+	if v == nil {
+		return rhs == nil
+	} else if rhs == nil {
+		return false
+	}
+	if !((v.BadRequestError == nil && rhs.BadRequestError == nil) || (v.BadRequestError != nil && rhs.BadRequestError != nil && v.BadRequestError.Equals(rhs.BadRequestError))) {
+		return false
+	}
+	if !((v.InternalServiceError == nil && rhs.InternalServiceError == nil) || (v.InternalServiceError != nil && rhs.InternalServiceError != nil && v.InternalServiceError.Equals(rhs.InternalServiceError))) {
+		return false
+	}
+	if !((v.EntityNotExistError == nil && rhs.EntityNotExistError == nil) || (v.EntityNotExistError != nil && rhs.EntityNotExistError != nil && v.EntityNotExistError.Equals(rhs.EntityNotExistError))) {
+		return false
+	}
+	if !((v.AccessDeniedError == nil && rhs.AccessDeniedError == nil) || (v.AccessDeniedError != nil && rhs.AccessDeniedError != nil && v.AccessDeniedError.Equals(rhs.AccessDeniedError))) {
+		return false
+	}
+
+	return true
+}
+
+// MarshalLogObject implements zapcore.ObjectMarshaler, enabling
+// fast logging of AdminService_SetWorkflowExecutionNotes_Result.
+func (v *AdminService_SetWorkflowExecutionNotes_Result) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+	if v == nil {
+		return nil
+	}
+	if v.BadRequestError != nil {
+		err = multierr.Append(err, enc.AddObject("badRequestError", v.BadRequestError))
+	}
+	if v.InternalServiceError != nil {
+		err = multierr.Append(err, enc.AddObject("internalServiceError", v.InternalServiceError))
+	}
+	if v.EntityNotExistError != nil {
+		err = multierr.Append(err, enc.AddObject("entityNotExistError", v.EntityNotExistError))
+	}
+	if v.AccessDeniedError != nil {
+		err = multierr.Append(err, enc.AddObject("accessDeniedError", v.AccessDeniedError))
+	}
+	return err
+}
+
+// GetBadRequestError returns the value of BadRequestError if it is set or its
+// zero value if it is unset.
+func (v *AdminService_SetWorkflowExecutionNotes_Result) GetBadRequestError() (o *shared.BadRequestError) {
+	if v != nil && v.BadRequestError != nil {
+		return v.BadRequestError
+	}
+
+	return
+}
+
+// IsSetBadRequestError returns true if BadRequestError is not nil.
+func (v *AdminService_SetWorkflowExecutionNotes_Result) IsSetBadRequestError() bool {
+	return v != nil && v.BadRequestError != nil
+}
+
+// GetInternalServiceError returns the value of InternalServiceError if it is set or its
+// zero value if it is unset.
+func (v *AdminService_SetWorkflowExecutionNotes_Result) GetInternalServiceError() (o *shared.InternalServiceError) {
+	if v != nil && v.InternalServiceError != nil {
+		return v.InternalServiceError
+	}
+
+	return
+}
+
+// IsSetInternalServiceError returns true if InternalServiceError is not nil.
+func (v *AdminService_SetWorkflowExecutionNotes_Result) IsSetInternalServiceError() bool {
+	return v != nil && v.InternalServiceError != nil
+}
+
+// GetEntityNotExistError returns the value of EntityNotExistError if it is set or its
+// zero value if it is unset.
+func (v *AdminService_SetWorkflowExecutionNotes_Result) GetEntityNotExistError() (o *shared.EntityNotExistsError) {
+	if v != nil && v.EntityNotExistError != nil {
+		return v.EntityNotExistError
+	}
+
+	return
+}
+
+// IsSetEntityNotExistError returns true if EntityNotExistError is not nil.
+func (v *AdminService_SetWorkflowExecutionNotes_Result) IsSetEntityNotExistError() bool {
+	return v != nil && v.EntityNotExistError != nil
+}
+
+// GetAccessDeniedError returns the value of AccessDeniedError if it is set or its
+// zero value if it is unset.
+func (v *AdminService_SetWorkflowExecutionNotes_Result) GetAccessDeniedError() (o *shared.AccessDeniedError) {
+	if v != nil && v.AccessDeniedError != nil {
+		return v.AccessDeniedError
+	}
+
+	return
+}
+
+// IsSetAccessDeniedError returns true if AccessDeniedError is not nil.
+func (v *AdminService_SetWorkflowExecutionNotes_Result) IsSetAccessDeniedError() bool {
+	return v != nil && v.AccessDeniedError != nil
+}
+
+// MethodName returns the name of the Thrift function as specified in
+// the IDL, for which this struct represent the result.
+//
+// This will always be "SetWorkflowExecutionNotes" for this struct.
+func (v *AdminService_SetWorkflowExecutionNotes_Result) MethodName() string {
+	return "SetWorkflowExecutionNotes"
+}
+
+// EnvelopeType returns the kind of value inside this struct.
+//
+// This will always be Reply for this struct.
+func (v *AdminService_SetWorkflowExecutionNotes_Result) EnvelopeType() wire.EnvelopeType {
+	return wire.Reply
+}
+
+// AdminService_SetWorkflowExecutionPaused_Args represents the arguments for the AdminService.SetWorkflowExecutionPaused function.
+//
+// The arguments for SetWorkflowExecutionPaused are sent and received over the wire as this struct.
+type AdminService_SetWorkflowExecutionPaused_Args struct {
+	Request *shared.SetWorkflowExecutionPausedRequest `json:"request,omitempty"`
+}
+
+// ToWire translates a AdminService_SetWorkflowExecutionPaused_Args struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *AdminService_SetWorkflowExecutionPaused_Args) ToWire() (wire.Value, error) {
+	var (
+		fields [1]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.Request != nil {
+		w, err = v.Request.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 1, Value: w}
+		i++
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+func _SetWorkflowExecutionPausedRequest_Read(w wire.Value) (*shared.SetWorkflowExecutionPausedRequest, error) {
+	var v shared.SetWorkflowExecutionPausedRequest
+	err := v.FromWire(w)
+	return &v, err
+}
+
+// FromWire deserializes a AdminService_SetWorkflowExecutionPaused_Args struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a AdminService_SetWorkflowExecutionPaused_Args struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v AdminService_SetWorkflowExecutionPaused_Args
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *AdminService_SetWorkflowExecutionPaused_Args) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 1:
+			if field.Value.Type() == wire.TStruct {
+				v.Request, err = _SetWorkflowExecutionPausedRequest_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+
+	return nil
+}
+
+// String returns a readable string representation of a AdminService_SetWorkflowExecutionPaused_Args
+// struct.
+func (v *AdminService_SetWorkflowExecutionPaused_Args) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [1]string
+	i := 0
+	if v.Request != nil {
+		fields[i] = fmt.Sprintf("Request: %v", v.Request)
+		i++
+	}
+
+	return fmt.Sprintf("AdminService_SetWorkflowExecutionPaused_Args{%v}", strings.Join(fields[:i], ", "))
+}
+
+// Equals returns true if all the fields of this AdminService_SetWorkflowExecutionPaused_Args match the
+// provided AdminService_SetWorkflowExecutionPaused_Args.
+//
+// This function performs a deep comparison.
+func (v *AdminService_SetWorkflowExecutionPaused_Args) Equals(rhs *AdminService_SetWorkflowExecutionPaused_Args) bool {
+	if v == nil {
+		return rhs == nil
+	} else if rhs == nil {
+		return false
+	}
+	if !((v.Request == nil && rhs.Request == nil) || (v.Request != nil && rhs.Request != nil && v.Request.Equals(rhs.Request))) {
+		return false
+	}
+
+	return true
+}
+
+// MarshalLogObject implements zapcore.ObjectMarshaler, enabling
+// fast logging of AdminService_SetWorkflowExecutionPaused_Args.
+func (v *AdminService_SetWorkflowExecutionPaused_Args) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+	if v == nil {
+		return nil
+	}
+	if v.Request != nil {
+		err = multierr.Append(err, enc.AddObject("request", v.Request))
+	}
+	return err
+}
+
+// GetRequest returns the value of Request if it is set or its
+// zero value if it is unset.
+func (v *AdminService_SetWorkflowExecutionPaused_Args) GetRequest() (o *shared.SetWorkflowExecutionPausedRequest) {
+	if v != nil && v.Request != nil {
+		return v.Request
+	}
+
+	return
+}
+
+// IsSetRequest returns true if Request is not nil.
+func (v *AdminService_SetWorkflowExecutionPaused_Args) IsSetRequest() bool {
+	return v != nil && v.Request != nil
+}
+
+// MethodName returns the name of the Thrift function as specified in
+// the IDL, for which this struct represent the arguments.
+//
+// This will always be "SetWorkflowExecutionPaused" for this struct.
+func (v *AdminService_SetWorkflowExecutionPaused_Args) MethodName() string {
+	return "SetWorkflowExecutionPaused"
+}
+
+// EnvelopeType returns the kind of value inside this struct.
+//
+// This will always be Call for this struct.
+func (v *AdminService_SetWorkflowExecutionPaused_Args) EnvelopeType() wire.EnvelopeType {
+	return wire.Call
+}
+
+// AdminService_SetWorkflowExecutionPaused_Helper provides functions that aid in handling the
+// parameters and return values of the AdminService.SetWorkflowExecutionPaused
+// function.
+var AdminService_SetWorkflowExecutionPaused_Helper = struct {
+	// Args accepts the parameters of SetWorkflowExecutionPaused in-order and returns
+	// the arguments struct for the function.
+	Args func(
+		request *shared.SetWorkflowExecutionPausedRequest,
+	) *AdminService_SetWorkflowExecutionPaused_Args
+
+	// IsException returns true if the given error can be thrown
+	// by SetWorkflowExecutionPaused.
+	//
+	// An error can be thrown by SetWorkflowExecutionPaused only if the
+	// corresponding exception type was mentioned in the 'throws'
+	// section for it in the Thrift file.
+	IsException func(error) bool
+
+	// WrapResponse returns the result struct for SetWorkflowExecutionPaused
+	// given the error returned by it. The provided error may
+	// be nil if SetWorkflowExecutionPaused did not fail.
+	//
+	// This allows mapping errors returned by SetWorkflowExecutionPaused into a
+	// serializable result struct. WrapResponse returns a
+	// non-nil error if the provided error cannot be thrown by
+	// SetWorkflowExecutionPaused
+	//
+	//   err := SetWorkflowExecutionPaused(args)
+	//   result, err := AdminService_SetWorkflowExecutionPaused_Helper.WrapResponse(err)
+	//   if err != nil {
+	//     return fmt.Errorf("unexpected error from SetWorkflowExecutionPaused: %v", err)
+	//   }
+	//   serialize(result)
+	WrapResponse func(error) (*AdminService_SetWorkflowExecutionPaused_Result, error)
+
+	// UnwrapResponse takes the result struct for SetWorkflowExecutionPaused
+	// and returns the erorr returned by it (if any).
+	//
+	// The error is non-nil only if SetWorkflowExecutionPaused threw an
+	// exception.
+	//
+	//   result := deserialize(bytes)
+	//   err := AdminService_SetWorkflowExecutionPaused_Helper.UnwrapResponse(result)
+	UnwrapResponse func(*AdminService_SetWorkflowExecutionPaused_Result) error
+}{}
+
+func init() {
+	AdminService_SetWorkflowExecutionPaused_Helper.Args = func(
+		request *shared.SetWorkflowExecutionPausedRequest,
+	) *AdminService_SetWorkflowExecutionPaused_Args {
+		return &AdminService_SetWorkflowExecutionPaused_Args{
+			Request: request,
+		}
+	}
+
+	AdminService_SetWorkflowExecutionPaused_Helper.IsException = func(err error) bool {
+		switch err.(type) {
+		case *shared.BadRequestError:
+			return true
+		case *shared.InternalServiceError:
+			return true
+		case *shared.EntityNotExistsError:
+			return true
+		case *shared.DomainNotActiveError:
+			return true
+		case *shared.AccessDeniedError:
+			return true
+		default:
+			return false
+		}
+	}
+
+	AdminService_SetWorkflowExecutionPaused_Helper.WrapResponse = func(err error) (*AdminService_SetWorkflowExecutionPaused_Result, error) {
+		if err == nil {
+			return &AdminService_SetWorkflowExecutionPaused_Result{}, nil
+		}
+
+		switch e := err.(type) {
+		case *shared.BadRequestError:
+			if e == nil {
+				return nil, errors.New("WrapResponse received non-nil error type with nil value for AdminService_SetWorkflowExecutionPaused_Result.BadRequestError")
+			}
+			return &AdminService_SetWorkflowExecutionPaused_Result{BadRequestError: e}, nil
+		case *shared.InternalServiceError:
+			if e == nil {
+				return nil, errors.New("WrapResponse received non-nil error type with nil value for AdminService_SetWorkflowExecutionPaused_Result.InternalServiceError")
+			}
+			return &AdminService_SetWorkflowExecutionPaused_Result{InternalServiceError: e}, nil
+		case *shared.EntityNotExistsError:
+			if e == nil {
+				return nil, errors.New("WrapResponse received non-nil error type with nil value for AdminService_SetWorkflowExecutionPaused_Result.EntityNotExistError")
+			}
+			return &AdminService_SetWorkflowExecutionPaused_Result{EntityNotExistError: e}, nil
+		case *shared.DomainNotActiveError:
+			if e == nil {
+				return nil, errors.New("WrapResponse received non-nil error type with nil value for AdminService_SetWorkflowExecutionPaused_Result.DomainNotActiveError")
+			}
+			return &AdminService_SetWorkflowExecutionPaused_Result{DomainNotActiveError: e}, nil
+		case *shared.AccessDeniedError:
+			if e == nil {
+				return nil, errors.New("WrapResponse received non-nil error type with nil value for AdminService_SetWorkflowExecutionPaused_Result.AccessDeniedError")
+			}
+			return &AdminService_SetWorkflowExecutionPaused_Result{AccessDeniedError: e}, nil
+		}
+
+		return nil, err
+	}
+	AdminService_SetWorkflowExecutionPaused_Helper.UnwrapResponse = func(result *AdminService_SetWorkflowExecutionPaused_Result) (err error) {
+		if result.BadRequestError != nil {
+			err = result.BadRequestError
+			return
+		}
+		if result.InternalServiceError != nil {
+			err = result.InternalServiceError
+			return
+		}
+		if result.EntityNotExistError != nil {
+			err = result.EntityNotExistError
+			return
+		}
+		if result.DomainNotActiveError != nil {
+			err = result.DomainNotActiveError
+			return
+		}
+		if result.AccessDeniedError != nil {
+			err = result.AccessDeniedError
+			return
+		}
+		return
+	}
+
+}
+
+// AdminService_SetWorkflowExecutionPaused_Result represents the result of a AdminService.SetWorkflowExecutionPaused function call.
+//
+// The result of a SetWorkflowExecutionPaused execution is sent and received over the wire as this struct.
+type AdminService_SetWorkflowExecutionPaused_Result struct {
+	BadRequestError      *shared.BadRequestError      `json:"badRequestError,omitempty"`
+	InternalServiceError *shared.InternalServiceError `json:"internalServiceError,omitempty"`
+	EntityNotExistError  *shared.EntityNotExistsError `json:"entityNotExistError,omitempty"`
+	DomainNotActiveError *shared.DomainNotActiveError `json:"domainNotActiveError,omitempty"`
+	AccessDeniedError    *shared.AccessDeniedError    `json:"accessDeniedError,omitempty"`
+}
+
+// ToWire translates a AdminService_SetWorkflowExecutionPaused_Result struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *AdminService_SetWorkflowExecutionPaused_Result) ToWire() (wire.Value, error) {
+	var (
+		fields [5]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.BadRequestError != nil {
+		w, err = v.BadRequestError.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 1, Value: w}
+		i++
+	}
+	if v.InternalServiceError != nil {
+		w, err = v.InternalServiceError.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 2, Value: w}
+		i++
+	}
+	if v.EntityNotExistError != nil {
+		w, err = v.EntityNotExistError.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 3, Value: w}
+		i++
+	}
+	if v.DomainNotActiveError != nil {
+		w, err = v.DomainNotActiveError.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 4, Value: w}
+		i++
+	}
+	if v.AccessDeniedError != nil {
+		w, err = v.AccessDeniedError.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 5, Value: w}
+		i++
+	}
+
+	if i > 1 {
+		return wire.Value{}, fmt.Errorf("AdminService_SetWorkflowExecutionPaused_Result should have at most one field: got %v fields", i)
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+// FromWire deserializes a AdminService_SetWorkflowExecutionPaused_Result struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a AdminService_SetWorkflowExecutionPaused_Result struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v AdminService_SetWorkflowExecutionPaused_Result
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *AdminService_SetWorkflowExecutionPaused_Result) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 1:
+			if field.Value.Type() == wire.TStruct {
+				v.BadRequestError, err = _BadRequestError_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 2:
+			if field.Value.Type() == wire.TStruct {
+				v.InternalServiceError, err = _InternalServiceError_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 3:
+			if field.Value.Type() == wire.TStruct {
+				v.EntityNotExistError, err = _EntityNotExistsError_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 4:
+			if field.Value.Type() == wire.TStruct {
+				v.DomainNotActiveError, err = _DomainNotActiveError_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 5:
+			if field.Value.Type() == wire.TStruct {
+				v.AccessDeniedError, err = _AccessDeniedError_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+
+	count := 0
+	if v.BadRequestError != nil {
+		count++
+	}
+	if v.InternalServiceError != nil {
+		count++
+	}
+	if v.EntityNotExistError != nil {
+		count++
+	}
+	if v.DomainNotActiveError != nil {
+		count++
+	}
+	if v.AccessDeniedError != nil {
+		count++
+	}
+	if count > 1 {
+		return fmt.Errorf("AdminService_SetWorkflowExecutionPaused_Result should have at most one field: got %v fields", count)
+	}
+
+	return nil
+}
+
+// String returns a readable string representation of a AdminService_SetWorkflowExecutionPaused_Result
+// struct.
+func (v *AdminService_SetWorkflowExecutionPaused_Result) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [5]string
+	i := 0
+	if v.BadRequestError != nil {
+		fields[i] = fmt.Sprintf("BadRequestError: %v", v.BadRequestError)
+		i++
+	}
+	if v.InternalServiceError != nil {
+		fields[i] = fmt.Sprintf("InternalServiceError: %v", v.InternalServiceError)
+		i++
+	}
+	if v.EntityNotExistError != nil {
+		fields[i] = fmt.Sprintf("EntityNotExistError: %v", v.EntityNotExistError)
+		i++
+	}
+	if v.DomainNotActiveError != nil {
+		fields[i] = fmt.Sprintf("DomainNotActiveError: %v", v.DomainNotActiveError)
+		i++
+	}
+	if v.AccessDeniedError != nil {
+		fields[i] = fmt.Sprintf("AccessDeniedError: %v", v.AccessDeniedError)
+		i++
+	}
 
-	for lk, lv := range lhs {
-		rv, ok := rhs[lk]
-		if !ok {
-			return false
-		}
-		if !lv.Equals(rv) {
-			return false
-		}
-	}
-	return true
+	return fmt.Sprintf("AdminService_SetWorkflowExecutionPaused_Result{%v}", strings.Join(fields[:i], ", "))
 }
 
-// Equals returns true if all the fields of this GetWorkflowExecutionRawHistoryResponse match the
-// provided GetWorkflowExecutionRawHistoryResponse.
+// Equals returns true if all the fields of this AdminService_SetWorkflowExecutionPaused_Result match the
+// provided AdminService_SetWorkflowExecutionPaused_Result.
 //
 // This function performs a deep comparison.
-func (v *GetWorkflowExecutionRawHistoryResponse) Equals(rhs *GetWorkflowExecutionRawHistoryResponse) bool {
+func (v *AdminService_SetWorkflowExecutionPaused_Result) Equals(rhs *AdminService_SetWorkflowExecutionPaused_Result) bool {
 	if v == nil {
 		return rhs == nil
 	} else if rhs == nil {
 		return false
 	}
-	if !((v.NextPageToken == nil && rhs.NextPageToken == nil) || (v.NextPageToken != nil && rhs.NextPageToken != nil && bytes.Equal(v.NextPageToken, rhs.NextPageToken))) {
+	if !((v.BadRequestError == nil && rhs.BadRequestError == nil) || (v.BadRequestError != nil && rhs.BadRequestError != nil && v.BadRequestError.Equals(rhs.BadRequestError))) {
 		return false
 	}
-	if !((v.HistoryBatches == nil && rhs.HistoryBatches == nil) || (v.HistoryBatches != nil && rhs.HistoryBatches != nil && _List_DataBlob_Equals(v.HistoryBatches, rhs.HistoryBatches))) {
+	if !((v.InternalServiceError == nil && rhs.InternalServiceError == nil) || (v.InternalServiceError != nil && rhs.InternalServiceError != nil && v.InternalServiceError.Equals(rhs.InternalServiceError))) {
 		return false
 	}
-	if !((v.ReplicationInfo == nil && rhs.ReplicationInfo == nil) || (v.ReplicationInfo != nil && rhs.ReplicationInfo != nil && _Map_String_ReplicationInfo_Equals(v.ReplicationInfo, rhs.ReplicationInfo))) {
+	if !((v.EntityNotExistError == nil && rhs.EntityNotExistError == nil) || (v.EntityNotExistError != nil && rhs.EntityNotExistError != nil && v.EntityNotExistError.Equals(rhs.EntityNotExistError))) {
 		return false
 	}
-	if !_I32_EqualsPtr(v.EventStoreVersion, rhs.EventStoreVersion) {
+	if !((v.DomainNotActiveError == nil && rhs.DomainNotActiveError == nil) || (v.DomainNotActiveError != nil && rhs.DomainNotActiveError != nil && v.DomainNotActiveError.Equals(rhs.DomainNotActiveError))) {
 		return false
 	}
-
-	return true
-}
-
-type _List_DataBlob_Zapper []*shared.DataBlob
-
-// MarshalLogArray implements zapcore.ArrayMarshaler, enabling
-// fast logging of _List_DataBlob_Zapper.
-func (l _List_DataBlob_Zapper) MarshalLogArray(enc zapcore.ArrayEncoder) (err error) {
-	for _, v := range l {
-		err = multierr.Append(err, enc.AppendObject(v))
+	if !((v.AccessDeniedError == nil && rhs.AccessDeniedError == nil) || (v.AccessDeniedError != nil && rhs.AccessDeniedError != nil && v.AccessDeniedError.Equals(rhs.AccessDeniedError))) {
+		return false
 	}
-	return err
-}
 
-type _Map_String_ReplicationInfo_Zapper map[string]*shared.ReplicationInfo
-
-// MarshalLogObject implements zapcore.ObjectMarshaler, enabling
-// fast logging of _Map_String_ReplicationInfo_Zapper.
-func (m _Map_String_ReplicationInfo_Zapper) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
-	for k, v := range m {
-		err = multierr.Append(err, enc.AddObject((string)(k), v))
-	}
-	return err
+	return true
 }
 
 // MarshalLogObject implements zapcore.ObjectMarshaler, enabling
-// fast logging of GetWorkflowExecutionRawHistoryResponse.
-func (v *GetWorkflowExecutionRawHistoryResponse) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+// fast logging of AdminService_SetWorkflowExecutionPaused_Result.
+func (v *AdminService_SetWorkflowExecutionPaused_Result) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
 	if v == nil {
 		return nil
 	}
-	if v.NextPageToken != nil {
-		enc.AddString("nextPageToken", base64.StdEncoding.EncodeToString(v.NextPageToken))
+	if v.BadRequestError != nil {
+		err = multierr.Append(err, enc.AddObject("badRequestError", v.BadRequestError))
 	}
-	if v.HistoryBatches != nil {
-		err = multierr.Append(err, enc.AddArray("historyBatches", (_List_DataBlob_Zapper)(v.HistoryBatches)))
+	if v.InternalServiceError != nil {
+		err = multierr.Append(err, enc.AddObject("internalServiceError", v.InternalServiceError))
 	}
-	if v.ReplicationInfo != nil {
-		err = multierr.Append(err, enc.AddObject("replicationInfo", (_Map_String_ReplicationInfo_Zapper)(v.ReplicationInfo)))
+	if v.EntityNotExistError != nil {
+		err = multierr.Append(err, enc.AddObject("entityNotExistError", v.EntityNotExistError))
 	}
-	if v.EventStoreVersion != nil {
-		enc.AddInt32("eventStoreVersion", *v.EventStoreVersion)
+	if v.DomainNotActiveError != nil {
+		err = multierr.Append(err, enc.AddObject("domainNotActiveError", v.DomainNotActiveError))
+	}
+	if v.AccessDeniedError != nil {
+		err = multierr.Append(err, enc.AddObject("accessDeniedError", v.AccessDeniedError))
 	}
 	return err
 }
 
-// GetNextPageToken returns the value of NextPageToken if it is set or its
+// GetBadRequestError returns the value of BadRequestError if it is set or its
 // zero value if it is unset.
-func (v *GetWorkflowExecutionRawHistoryResponse) GetNextPageToken() (o []byte) {
-	if v != nil && v.NextPageToken != nil {
-		return v.NextPageToken
+func (v *AdminService_SetWorkflowExecutionPaused_Result) GetBadRequestError() (o *shared.BadRequestError) {
+	if v != nil && v.BadRequestError != nil {
+		return v.BadRequestError
 	}
 
 	return
 }
 
-// IsSetNextPageToken returns true if NextPageToken is not nil.
-func (v *GetWorkflowExecutionRawHistoryResponse) IsSetNextPageToken() bool {
-	return v != nil && v.NextPageToken != nil
+// IsSetBadRequestError returns true if BadRequestError is not nil.
+func (v *AdminService_SetWorkflowExecutionPaused_Result) IsSetBadRequestError() bool {
+	return v != nil && v.BadRequestError != nil
 }
 
-// GetHistoryBatches returns the value of HistoryBatches if it is set or its
+// GetInternalServiceError returns the value of InternalServiceError if it is set or its
 // zero value if it is unset.
-func (v *GetWorkflowExecutionRawHistoryResponse) GetHistoryBatches() (o []*shared.DataBlob) {
-	if v != nil && v.HistoryBatches != nil {
-		return v.HistoryBatches
+func (v *AdminService_SetWorkflowExecutionPaused_Result) GetInternalServiceError() (o *shared.InternalServiceError) {
+	if v != nil && v.InternalServiceError != nil {
+		return v.InternalServiceError
 	}
 
 	return
 }
 
-// IsSetHistoryBatches returns true if HistoryBatches is not nil.
-func (v *GetWorkflowExecutionRawHistoryResponse) IsSetHistoryBatches() bool {
-	return v != nil && v.HistoryBatches != nil
+// IsSetInternalServiceError returns true if InternalServiceError is not nil.
+func (v *AdminService_SetWorkflowExecutionPaused_Result) IsSetInternalServiceError() bool {
+	return v != nil && v.InternalServiceError != nil
 }
 
-// GetReplicationInfo returns the value of ReplicationInfo if it is set or its
+// GetEntityNotExistError returns the value of EntityNotExistError if it is set or its
 // zero value if it is unset.
-func (v *GetWorkflowExecutionRawHistoryResponse) GetReplicationInfo() (o map[string]*shared.ReplicationInfo) {
-	if v != nil && v.ReplicationInfo != nil {
-		return v.ReplicationInfo
+func (v *AdminService_SetWorkflowExecutionPaused_Result) GetEntityNotExistError() (o *shared.EntityNotExistsError) {
+	if v != nil && v.EntityNotExistError != nil {
+		return v.EntityNotExistError
 	}
 
 	return
 }
 
-// IsSetReplicationInfo returns true if ReplicationInfo is not nil.
-func (v *GetWorkflowExecutionRawHistoryResponse) IsSetReplicationInfo() bool {
-	return v != nil && v.ReplicationInfo != nil
+// IsSetEntityNotExistError returns true if EntityNotExistError is not nil.
+func (v *AdminService_SetWorkflowExecutionPaused_Result) IsSetEntityNotExistError() bool {
+	return v != nil && v.EntityNotExistError != nil
 }
 
-// GetEventStoreVersion returns the value of EventStoreVersion if it is set or its
+// GetDomainNotActiveError returns the value of DomainNotActiveError if it is set or its
 // zero value if it is unset.
-func (v *GetWorkflowExecutionRawHistoryResponse) GetEventStoreVersion() (o int32) {
-	if v != nil && v.EventStoreVersion != nil {
-		return *v.EventStoreVersion
+func (v *AdminService_SetWorkflowExecutionPaused_Result) GetDomainNotActiveError() (o *shared.DomainNotActiveError) {
+	if v != nil && v.DomainNotActiveError != nil {
+		return v.DomainNotActiveError
 	}
 
 	return
 }
 
-// IsSetEventStoreVersion returns true if EventStoreVersion is not nil.
-func (v *GetWorkflowExecutionRawHistoryResponse) IsSetEventStoreVersion() bool {
-	return v != nil && v.EventStoreVersion != nil
+// IsSetDomainNotActiveError returns true if DomainNotActiveError is not nil.
+func (v *AdminService_SetWorkflowExecutionPaused_Result) IsSetDomainNotActiveError() bool {
+	return v != nil && v.DomainNotActiveError != nil
 }
 
-// ThriftModule represents the IDL file used to generate this package.
-var ThriftModule = &thriftreflect.ThriftModule{
-	Name:     "admin",
-	Package:  "github.com/uber/cadence/.gen/go/admin",
-	FilePath: "admin.thrift",
-	SHA1:     "5136f687aeefc01dd9b57994864926bb8f277b9f",
-	Includes: []*thriftreflect.ThriftModule{
-		shared.ThriftModule,
-	},
-	Raw: rawIDL,
+// GetAccessDeniedError returns the value of AccessDeniedError if it is set or its
+// zero value if it is unset.
+func (v *AdminService_SetWorkflowExecutionPaused_Result) GetAccessDeniedError() (o *shared.AccessDeniedError) {
+	if v != nil && v.AccessDeniedError != nil {
+		return v.AccessDeniedError
+	}
+
+	return
 }
 
-const rawIDL = "// Copyright (c) 2017 Uber Technologies, Inc.\n//\n// Permission is hereby granted, free of charge, to any person obtaining a copy\n// of this software and associated documentation files (the \"Software\"), to deal\n// in the Software without restriction, including without limitation the rights\n// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell\n// copies of the Software, and to permit persons to whom the Software is\n// furnished to do so, subject to the following conditions:\n//\n// The above copyright notice and this permission notice shall be included in\n// all copies or substantial portions of the Software.\n//\n// THE SOFTWARE IS PROVIDED \"AS IS\", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR\n// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,\n// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE\n// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER\n// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,\n// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN\n// THE SOFTWARE.\n\nnamespace java com.uber.cadence.admin\n\ninclude \"shared.thrift\"\n\n/**\n* AdminService provides advanced APIs for debugging and analysis with admin privillege\n**/\nservice AdminService {\n  /**\n  * DescribeWorkflowExecution returns information about the internal states of workflow execution.\n  **/\n  DescribeWorkflowExecutionResponse DescribeWorkflowExecution(1: DescribeWorkflowExecutionRequest request)\n    throws (\n      1: shared.BadRequestError         badRequestError,\n      2: shared.InternalServiceError    internalServiceError,\n      3: shared.EntityNotExistsError    entityNotExistError,\n      4: shared.AccessDeniedError       accessDeniedError,\n    )\n\n  /**\n  * DescribeHistoryHost returns information about the internal states of a history host\n  **/\n  shared.DescribeHistoryHostResponse DescribeHistoryHost(1: shared.DescribeHistoryHostRequest request)\n    throws (\n      1: shared.BadRequestError       badRequestError,\n      2: shared.InternalServiceError  internalServiceError,\n      3: shared.AccessDeniedError     accessDeniedError,\n    )\n\n  /**\n  * Returns the raw history of specified workflow execution.  It fails with 'EntityNotExistError' if speficied workflow\n  * execution in unknown to the service.\n  **/\n  GetWorkflowExecutionRawHistoryResponse GetWorkflowExecutionRawHistory(1: GetWorkflowExecutionRawHistoryRequest getRequest)\n    throws (\n      1: shared.BadRequestError badRequestError,\n      2: shared.InternalServiceError internalServiceError,\n      3: shared.EntityNotExistsError entityNotExistError,\n      4: shared.ServiceBusyError serviceBusyError,\n    )\n}\n\nstruct DescribeWorkflowExecutionRequest {\n  10: optional string                       domain\n  20: optional shared.WorkflowExecution     execution\n}\n\nstruct DescribeWorkflowExecutionResponse{\n  10: optional string shardId\n  20: optional string historyAddr\n  40: optional string mutableStateInCache\n  50: optional string mutableStateInDatabase\n}\n\nstruct GetWorkflowExecutionRawHistoryRequest {\n  10: optional string domain\n  20: optional shared.WorkflowExecution execution\n  30: optional i64 (js.type = \"Long\") firstEventId\n  40: optional i64 (js.type = \"Long\") nextEventId\n  50: optional i32 maximumPageSize\n  60: optional binary nextPageToken\n}\n\nstruct GetWorkflowExecutionRawHistoryResponse {\n  10: optional binary nextPageToken\n  20: optional list<shared.DataBlob> historyBatches\n  30: optional map<string, shared.ReplicationInfo> replicationInfo\n  40: optional i32 eventStoreVersion\n}"
+// IsSetAccessDeniedError returns true if AccessDeniedError is not nil.
+func (v *AdminService_SetWorkflowExecutionPaused_Result) IsSetAccessDeniedError() bool {
+	return v != nil && v.AccessDeniedError != nil
+}
 
-// AdminService_DescribeHistoryHost_Args represents the arguments for the AdminService.DescribeHistoryHost function.
+// MethodName returns the name of the Thrift function as specified in
+// the IDL, for which this struct represent the result.
 //
-// The arguments for DescribeHistoryHost are sent and received over the wire as this struct.
-type AdminService_DescribeHistoryHost_Args struct {
-	Request *shared.DescribeHistoryHostRequest `json:"request,omitempty"`
+// This will always be "SetWorkflowExecutionPaused" for this struct.
+func (v *AdminService_SetWorkflowExecutionPaused_Result) MethodName() string {
+	return "SetWorkflowExecutionPaused"
 }
 
-// ToWire translates a AdminService_DescribeHistoryHost_Args struct into a Thrift-level intermediate
+// EnvelopeType returns the kind of value inside this struct.
+//
+// This will always be Reply for this struct.
+func (v *AdminService_SetWorkflowExecutionPaused_Result) EnvelopeType() wire.EnvelopeType {
+	return wire.Reply
+}
+
+// AdminService_TerminateAllWorkflowRuns_Args represents the arguments for the AdminService.TerminateAllWorkflowRuns function.
+//
+// The arguments for TerminateAllWorkflowRuns are sent and received over the wire as this struct.
+type AdminService_TerminateAllWorkflowRuns_Args struct {
+	Request *shared.TerminateAllWorkflowRunsRequest `json:"request,omitempty"`
+}
+
+// ToWire translates a AdminService_TerminateAllWorkflowRuns_Args struct into a Thrift-level intermediate
 // representation. This intermediate representation may be serialized
 // into bytes using a ThriftRW protocol implementation.
 //
@@ -1355,7 +18539,7 @@ type AdminService_DescribeHistoryHost_Args struct {
 //   if err := binaryProtocol.Encode(x, writer); err != nil {
 //     return err
 //   }
-func (v *AdminService_DescribeHistoryHost_Args) ToWire() (wire.Value, error) {
+func (v *AdminService_TerminateAllWorkflowRuns_Args) ToWire() (wire.Value, error) {
 	var (
 		fields [1]wire.Field
 		i      int = 0
@@ -1375,17 +18559,17 @@ func (v *AdminService_DescribeHistoryHost_Args) ToWire() (wire.Value, error) {
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
 
-func _DescribeHistoryHostRequest_Read(w wire.Value) (*shared.DescribeHistoryHostRequest, error) {
-	var v shared.DescribeHistoryHostRequest
+func _TerminateAllWorkflowRunsRequest_Read(w wire.Value) (*shared.TerminateAllWorkflowRunsRequest, error) {
+	var v shared.TerminateAllWorkflowRunsRequest
 	err := v.FromWire(w)
 	return &v, err
 }
 
-// FromWire deserializes a AdminService_DescribeHistoryHost_Args struct from its Thrift-level
+// FromWire deserializes a AdminService_TerminateAllWorkflowRuns_Args struct from its Thrift-level
 // representation. The Thrift-level representation may be obtained
 // from a ThriftRW protocol implementation.
 //
-// An error is returned if we were unable to build a AdminService_DescribeHistoryHost_Args struct
+// An error is returned if we were unable to build a AdminService_TerminateAllWorkflowRuns_Args struct
 // from the provided intermediate representation.
 //
 //   x, err := binaryProtocol.Decode(reader, wire.TStruct)
@@ -1393,19 +18577,19 @@ func _DescribeHistoryHostRequest_Read(w wire.Value) (*shared.DescribeHistoryHost
 //     return nil, err
 //   }
 //
-//   var v AdminService_DescribeHistoryHost_Args
+//   var v AdminService_TerminateAllWorkflowRuns_Args
 //   if err := v.FromWire(x); err != nil {
 //     return nil, err
 //   }
 //   return &v, nil
-func (v *AdminService_DescribeHistoryHost_Args) FromWire(w wire.Value) error {
+func (v *AdminService_TerminateAllWorkflowRuns_Args) FromWire(w wire.Value) error {
 	var err error
 
 	for _, field := range w.GetStruct().Fields {
 		switch field.ID {
 		case 1:
 			if field.Value.Type() == wire.TStruct {
-				v.Request, err = _DescribeHistoryHostRequest_Read(field.Value)
+				v.Request, err = _TerminateAllWorkflowRunsRequest_Read(field.Value)
 				if err != nil {
 					return err
 				}
@@ -1417,9 +18601,9 @@ func (v *AdminService_DescribeHistoryHost_Args) FromWire(w wire.Value) error {
 	return nil
 }
 
-// String returns a readable string representation of a AdminService_DescribeHistoryHost_Args
+// String returns a readable string representation of a AdminService_TerminateAllWorkflowRuns_Args
 // struct.
-func (v *AdminService_DescribeHistoryHost_Args) String() string {
+func (v *AdminService_TerminateAllWorkflowRuns_Args) String() string {
 	if v == nil {
 		return "<nil>"
 	}
@@ -1431,14 +18615,14 @@ func (v *AdminService_DescribeHistoryHost_Args) String() string {
 		i++
 	}
 
-	return fmt.Sprintf("AdminService_DescribeHistoryHost_Args{%v}", strings.Join(fields[:i], ", "))
+	return fmt.Sprintf("AdminService_TerminateAllWorkflowRuns_Args{%v}", strings.Join(fields[:i], ", "))
 }
 
-// Equals returns true if all the fields of this AdminService_DescribeHistoryHost_Args match the
-// provided AdminService_DescribeHistoryHost_Args.
+// Equals returns true if all the fields of this AdminService_TerminateAllWorkflowRuns_Args match the
+// provided AdminService_TerminateAllWorkflowRuns_Args.
 //
 // This function performs a deep comparison.
-func (v *AdminService_DescribeHistoryHost_Args) Equals(rhs *AdminService_DescribeHistoryHost_Args) bool {
+func (v *AdminService_TerminateAllWorkflowRuns_Args) Equals(rhs *AdminService_TerminateAllWorkflowRuns_Args) bool {
 	if v == nil {
 		return rhs == nil
 	} else if rhs == nil {
@@ -1452,8 +18636,8 @@ func (v *AdminService_DescribeHistoryHost_Args) Equals(rhs *AdminService_Describ
 }
 
 // MarshalLogObject implements zapcore.ObjectMarshaler, enabling
-// fast logging of AdminService_DescribeHistoryHost_Args.
-func (v *AdminService_DescribeHistoryHost_Args) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+// fast logging of AdminService_TerminateAllWorkflowRuns_Args.
+func (v *AdminService_TerminateAllWorkflowRuns_Args) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
 	if v == nil {
 		return nil
 	}
@@ -1465,7 +18649,7 @@ func (v *AdminService_DescribeHistoryHost_Args) MarshalLogObject(enc zapcore.Obj
 
 // GetRequest returns the value of Request if it is set or its
 // zero value if it is unset.
-func (v *AdminService_DescribeHistoryHost_Args) GetRequest() (o *shared.DescribeHistoryHostRequest) {
+func (v *AdminService_TerminateAllWorkflowRuns_Args) GetRequest() (o *shared.TerminateAllWorkflowRunsRequest) {
 	if v != nil && v.Request != nil {
 		return v.Request
 	}
@@ -1474,85 +18658,89 @@ func (v *AdminService_DescribeHistoryHost_Args) GetRequest() (o *shared.Describe
 }
 
 // IsSetRequest returns true if Request is not nil.
-func (v *AdminService_DescribeHistoryHost_Args) IsSetRequest() bool {
+func (v *AdminService_TerminateAllWorkflowRuns_Args) IsSetRequest() bool {
 	return v != nil && v.Request != nil
 }
 
 // MethodName returns the name of the Thrift function as specified in
 // the IDL, for which this struct represent the arguments.
 //
-// This will always be "DescribeHistoryHost" for this struct.
-func (v *AdminService_DescribeHistoryHost_Args) MethodName() string {
-	return "DescribeHistoryHost"
+// This will always be "TerminateAllWorkflowRuns" for this struct.
+func (v *AdminService_TerminateAllWorkflowRuns_Args) MethodName() string {
+	return "TerminateAllWorkflowRuns"
 }
 
 // EnvelopeType returns the kind of value inside this struct.
 //
 // This will always be Call for this struct.
-func (v *AdminService_DescribeHistoryHost_Args) EnvelopeType() wire.EnvelopeType {
+func (v *AdminService_TerminateAllWorkflowRuns_Args) EnvelopeType() wire.EnvelopeType {
 	return wire.Call
 }
 
-// AdminService_DescribeHistoryHost_Helper provides functions that aid in handling the
-// parameters and return values of the AdminService.DescribeHistoryHost
+// AdminService_TerminateAllWorkflowRuns_Helper provides functions that aid in handling the
+// parameters and return values of the AdminService.TerminateAllWorkflowRuns
 // function.
-var AdminService_DescribeHistoryHost_Helper = struct {
-	// Args accepts the parameters of DescribeHistoryHost in-order and returns
+var AdminService_TerminateAllWorkflowRuns_Helper = struct {
+	// Args accepts the parameters of TerminateAllWorkflowRuns in-order and returns
 	// the arguments struct for the function.
 	Args func(
-		request *shared.DescribeHistoryHostRequest,
-	) *AdminService_DescribeHistoryHost_Args
+		request *shared.TerminateAllWorkflowRunsRequest,
+	) *AdminService_TerminateAllWorkflowRuns_Args
 
 	// IsException returns true if the given error can be thrown
-	// by DescribeHistoryHost.
+	// by TerminateAllWorkflowRuns.
 	//
-	// An error can be thrown by DescribeHistoryHost only if the
+	// An error can be thrown by TerminateAllWorkflowRuns only if the
 	// corresponding exception type was mentioned in the 'throws'
 	// section for it in the Thrift file.
 	IsException func(error) bool
 
-	// WrapResponse returns the result struct for DescribeHistoryHost
+	// WrapResponse returns the result struct for TerminateAllWorkflowRuns
 	// given its return value and error.
 	//
 	// This allows mapping values and errors returned by
-	// DescribeHistoryHost into a serializable result struct.
+	// TerminateAllWorkflowRuns into a serializable result struct.
 	// WrapResponse returns a non-nil error if the provided
-	// error cannot be thrown by DescribeHistoryHost
+	// error cannot be thrown by TerminateAllWorkflowRuns
 	//
-	//   value, err := DescribeHistoryHost(args)
-	//   result, err := AdminService_DescribeHistoryHost_Helper.WrapResponse(value, err)
+	//   value, err := TerminateAllWorkflowRuns(args)
+	//   result, err := AdminService_TerminateAllWorkflowRuns_Helper.WrapResponse(value, err)
 	//   if err != nil {
-	//     return fmt.Errorf("unexpected error from DescribeHistoryHost: %v", err)
+	//     return fmt.Errorf("unexpected error from TerminateAllWorkflowRuns: %v", err)
 	//   }
 	//   serialize(result)
-	WrapResponse func(*shared.DescribeHistoryHostResponse, error) (*AdminService_DescribeHistoryHost_Result, error)
+	WrapResponse func(*shared.TerminateAllWorkflowRunsResponse, error) (*AdminService_TerminateAllWorkflowRuns_Result, error)
 
-	// UnwrapResponse takes the result struct for DescribeHistoryHost
+	// UnwrapResponse takes the result struct for TerminateAllWorkflowRuns
 	// and returns the value or error returned by it.
 	//
-	// The error is non-nil only if DescribeHistoryHost threw an
+	// The error is non-nil only if TerminateAllWorkflowRuns threw an
 	// exception.
 	//
 	//   result := deserialize(bytes)
-	//   value, err := AdminService_DescribeHistoryHost_Helper.UnwrapResponse(result)
-	UnwrapResponse func(*AdminService_DescribeHistoryHost_Result) (*shared.DescribeHistoryHostResponse, error)
+	//   value, err := AdminService_TerminateAllWorkflowRuns_Helper.UnwrapResponse(result)
+	UnwrapResponse func(*AdminService_TerminateAllWorkflowRuns_Result) (*shared.TerminateAllWorkflowRunsResponse, error)
 }{}
 
 func init() {
-	AdminService_DescribeHistoryHost_Helper.Args = func(
-		request *shared.DescribeHistoryHostRequest,
-	) *AdminService_DescribeHistoryHost_Args {
-		return &AdminService_DescribeHistoryHost_Args{
+	AdminService_TerminateAllWorkflowRuns_Helper.Args = func(
+		request *shared.TerminateAllWorkflowRunsRequest,
+	) *AdminService_TerminateAllWorkflowRuns_Args {
+		return &AdminService_TerminateAllWorkflowRuns_Args{
 			Request: request,
 		}
 	}
 
-	AdminService_DescribeHistoryHost_Helper.IsException = func(err error) bool {
+	AdminService_TerminateAllWorkflowRuns_Helper.IsException = func(err error) bool {
 		switch err.(type) {
 		case *shared.BadRequestError:
 			return true
 		case *shared.InternalServiceError:
 			return true
+		case *shared.EntityNotExistsError:
+			return true
+		case *shared.DomainNotActiveError:
+			return true
 		case *shared.AccessDeniedError:
 			return true
 		default:
@@ -1560,32 +18748,42 @@ func init() {
 		}
 	}
 
-	AdminService_DescribeHistoryHost_Helper.WrapResponse = func(success *shared.DescribeHistoryHostResponse, err error) (*AdminService_DescribeHistoryHost_Result, error) {
+	AdminService_TerminateAllWorkflowRuns_Helper.WrapResponse = func(success *shared.TerminateAllWorkflowRunsResponse, err error) (*AdminService_TerminateAllWorkflowRuns_Result, error) {
 		if err == nil {
-			return &AdminService_DescribeHistoryHost_Result{Success: success}, nil
+			return &AdminService_TerminateAllWorkflowRuns_Result{Success: success}, nil
 		}
 
 		switch e := err.(type) {
 		case *shared.BadRequestError:
 			if e == nil {
-				return nil, errors.New("WrapResponse received non-nil error type with nil value for AdminService_DescribeHistoryHost_Result.BadRequestError")
+				return nil, errors.New("WrapResponse received non-nil error type with nil value for AdminService_TerminateAllWorkflowRuns_Result.BadRequestError")
 			}
-			return &AdminService_DescribeHistoryHost_Result{BadRequestError: e}, nil
+			return &AdminService_TerminateAllWorkflowRuns_Result{BadRequestError: e}, nil
 		case *shared.InternalServiceError:
 			if e == nil {
-				return nil, errors.New("WrapResponse received non-nil error type with nil value for AdminService_DescribeHistoryHost_Result.InternalServiceError")
+				return nil, errors.New("WrapResponse received non-nil error type with nil value for AdminService_TerminateAllWorkflowRuns_Result.InternalServiceError")
 			}
-			return &AdminService_DescribeHistoryHost_Result{InternalServiceError: e}, nil
+			return &AdminService_TerminateAllWorkflowRuns_Result{InternalServiceError: e}, nil
+		case *shared.EntityNotExistsError:
+			if e == nil {
+				return nil, errors.New("WrapResponse received non-nil error type with nil value for AdminService_TerminateAllWorkflowRuns_Result.EntityNotExistError")
+			}
+			return &AdminService_TerminateAllWorkflowRuns_Result{EntityNotExistError: e}, nil
+		case *shared.DomainNotActiveError:
+			if e == nil {
+				return nil, errors.New("WrapResponse received non-nil error type with nil value for AdminService_TerminateAllWorkflowRuns_Result.DomainNotActiveError")
+			}
+			return &AdminService_TerminateAllWorkflowRuns_Result{DomainNotActiveError: e}, nil
 		case *shared.AccessDeniedError:
 			if e == nil {
-				return nil, errors.New("WrapResponse received non-nil error type with nil value for AdminService_DescribeHistoryHost_Result.AccessDeniedError")
+				return nil, errors.New("WrapResponse received non-nil error type with nil value for AdminService_TerminateAllWorkflowRuns_Result.AccessDeniedError")
 			}
-			return &AdminService_DescribeHistoryHost_Result{AccessDeniedError: e}, nil
+			return &AdminService_TerminateAllWorkflowRuns_Result{AccessDeniedError: e}, nil
 		}
 
 		return nil, err
 	}
-	AdminService_DescribeHistoryHost_Helper.UnwrapResponse = func(result *AdminService_DescribeHistoryHost_Result) (success *shared.DescribeHistoryHostResponse, err error) {
+	AdminService_TerminateAllWorkflowRuns_Helper.UnwrapResponse = func(result *AdminService_TerminateAllWorkflowRuns_Result) (success *shared.TerminateAllWorkflowRunsResponse, err error) {
 		if result.BadRequestError != nil {
 			err = result.BadRequestError
 			return
@@ -1594,6 +18792,14 @@ func init() {
 			err = result.InternalServiceError
 			return
 		}
+		if result.EntityNotExistError != nil {
+			err = result.EntityNotExistError
+			return
+		}
+		if result.DomainNotActiveError != nil {
+			err = result.DomainNotActiveError
+			return
+		}
 		if result.AccessDeniedError != nil {
 			err = result.AccessDeniedError
 			return
@@ -1610,20 +18816,22 @@ func init() {
 
 }
 
-// AdminService_DescribeHistoryHost_Result represents the result of a AdminService.DescribeHistoryHost function call.
+// AdminService_TerminateAllWorkflowRuns_Result represents the result of a AdminService.TerminateAllWorkflowRuns function call.
 //
-// The result of a DescribeHistoryHost execution is sent and received over the wire as this struct.
+// The result of a TerminateAllWorkflowRuns execution is sent and received over the wire as this struct.
 //
 // Success is set only if the function did not throw an exception.
-type AdminService_DescribeHistoryHost_Result struct {
-	// Value returned by DescribeHistoryHost after a successful execution.
-	Success              *shared.DescribeHistoryHostResponse `json:"success,omitempty"`
-	BadRequestError      *shared.BadRequestError             `json:"badRequestError,omitempty"`
-	InternalServiceError *shared.InternalServiceError        `json:"internalServiceError,omitempty"`
-	AccessDeniedError    *shared.AccessDeniedError           `json:"accessDeniedError,omitempty"`
+type AdminService_TerminateAllWorkflowRuns_Result struct {
+	// Value returned by TerminateAllWorkflowRuns after a successful execution.
+	Success              *shared.TerminateAllWorkflowRunsResponse `json:"success,omitempty"`
+	BadRequestError      *shared.BadRequestError                  `json:"badRequestError,omitempty"`
+	InternalServiceError *shared.InternalServiceError             `json:"internalServiceError,omitempty"`
+	EntityNotExistError  *shared.EntityNotExistsError             `json:"entityNotExistError,omitempty"`
+	DomainNotActiveError *shared.DomainNotActiveError             `json:"domainNotActiveError,omitempty"`
+	AccessDeniedError    *shared.AccessDeniedError                `json:"accessDeniedError,omitempty"`
 }
 
-// ToWire translates a AdminService_DescribeHistoryHost_Result struct into a Thrift-level intermediate
+// ToWire translates a AdminService_TerminateAllWorkflowRuns_Result struct into a Thrift-level intermediate
 // representation. This intermediate representation may be serialized
 // into bytes using a ThriftRW protocol implementation.
 //
@@ -1638,9 +18846,9 @@ type AdminService_DescribeHistoryHost_Result struct {
 //   if err := binaryProtocol.Encode(x, writer); err != nil {
 //     return err
 //   }
-func (v *AdminService_DescribeHistoryHost_Result) ToWire() (wire.Value, error) {
+func (v *AdminService_TerminateAllWorkflowRuns_Result) ToWire() (wire.Value, error) {
 	var (
-		fields [4]wire.Field
+		fields [6]wire.Field
 		i      int = 0
 		w      wire.Value
 		err    error
@@ -1670,51 +18878,49 @@ func (v *AdminService_DescribeHistoryHost_Result) ToWire() (wire.Value, error) {
 		fields[i] = wire.Field{ID: 2, Value: w}
 		i++
 	}
-	if v.AccessDeniedError != nil {
-		w, err = v.AccessDeniedError.ToWire()
+	if v.EntityNotExistError != nil {
+		w, err = v.EntityNotExistError.ToWire()
 		if err != nil {
 			return w, err
 		}
 		fields[i] = wire.Field{ID: 3, Value: w}
 		i++
 	}
-
-	if i != 1 {
-		return wire.Value{}, fmt.Errorf("AdminService_DescribeHistoryHost_Result should have exactly one field: got %v fields", i)
+	if v.DomainNotActiveError != nil {
+		w, err = v.DomainNotActiveError.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 4, Value: w}
+		i++
+	}
+	if v.AccessDeniedError != nil {
+		w, err = v.AccessDeniedError.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 5, Value: w}
+		i++
 	}
 
-	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
-}
-
-func _DescribeHistoryHostResponse_Read(w wire.Value) (*shared.DescribeHistoryHostResponse, error) {
-	var v shared.DescribeHistoryHostResponse
-	err := v.FromWire(w)
-	return &v, err
-}
-
-func _BadRequestError_Read(w wire.Value) (*shared.BadRequestError, error) {
-	var v shared.BadRequestError
-	err := v.FromWire(w)
-	return &v, err
-}
-
-func _InternalServiceError_Read(w wire.Value) (*shared.InternalServiceError, error) {
-	var v shared.InternalServiceError
-	err := v.FromWire(w)
-	return &v, err
+	if i != 1 {
+		return wire.Value{}, fmt.Errorf("AdminService_TerminateAllWorkflowRuns_Result should have exactly one field: got %v fields", i)
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
 
-func _AccessDeniedError_Read(w wire.Value) (*shared.AccessDeniedError, error) {
-	var v shared.AccessDeniedError
+func _TerminateAllWorkflowRunsResponse_Read(w wire.Value) (*shared.TerminateAllWorkflowRunsResponse, error) {
+	var v shared.TerminateAllWorkflowRunsResponse
 	err := v.FromWire(w)
 	return &v, err
 }
 
-// FromWire deserializes a AdminService_DescribeHistoryHost_Result struct from its Thrift-level
+// FromWire deserializes a AdminService_TerminateAllWorkflowRuns_Result struct from its Thrift-level
 // representation. The Thrift-level representation may be obtained
 // from a ThriftRW protocol implementation.
 //
-// An error is returned if we were unable to build a AdminService_DescribeHistoryHost_Result struct
+// An error is returned if we were unable to build a AdminService_TerminateAllWorkflowRuns_Result struct
 // from the provided intermediate representation.
 //
 //   x, err := binaryProtocol.Decode(reader, wire.TStruct)
@@ -1722,19 +18928,19 @@ func _AccessDeniedError_Read(w wire.Value) (*shared.AccessDeniedError, error) {
 //     return nil, err
 //   }
 //
-//   var v AdminService_DescribeHistoryHost_Result
+//   var v AdminService_TerminateAllWorkflowRuns_Result
 //   if err := v.FromWire(x); err != nil {
 //     return nil, err
 //   }
 //   return &v, nil
-func (v *AdminService_DescribeHistoryHost_Result) FromWire(w wire.Value) error {
+func (v *AdminService_TerminateAllWorkflowRuns_Result) FromWire(w wire.Value) error {
 	var err error
 
 	for _, field := range w.GetStruct().Fields {
 		switch field.ID {
 		case 0:
 			if field.Value.Type() == wire.TStruct {
-				v.Success, err = _DescribeHistoryHostResponse_Read(field.Value)
+				v.Success, err = _TerminateAllWorkflowRunsResponse_Read(field.Value)
 				if err != nil {
 					return err
 				}
@@ -1757,6 +18963,22 @@ func (v *AdminService_DescribeHistoryHost_Result) FromWire(w wire.Value) error {
 
 			}
 		case 3:
+			if field.Value.Type() == wire.TStruct {
+				v.EntityNotExistError, err = _EntityNotExistsError_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 4:
+			if field.Value.Type() == wire.TStruct {
+				v.DomainNotActiveError, err = _DomainNotActiveError_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 5:
 			if field.Value.Type() == wire.TStruct {
 				v.AccessDeniedError, err = _AccessDeniedError_Read(field.Value)
 				if err != nil {
@@ -1777,24 +18999,30 @@ func (v *AdminService_DescribeHistoryHost_Result) FromWire(w wire.Value) error {
 	if v.InternalServiceError != nil {
 		count++
 	}
+	if v.EntityNotExistError != nil {
+		count++
+	}
+	if v.DomainNotActiveError != nil {
+		count++
+	}
 	if v.AccessDeniedError != nil {
 		count++
 	}
 	if count != 1 {
-		return fmt.Errorf("AdminService_DescribeHistoryHost_Result should have exactly one field: got %v fields", count)
+		return fmt.Errorf("AdminService_TerminateAllWorkflowRuns_Result should have exactly one field: got %v fields", count)
 	}
 
 	return nil
 }
 
-// String returns a readable string representation of a AdminService_DescribeHistoryHost_Result
+// String returns a readable string representation of a AdminService_TerminateAllWorkflowRuns_Result
 // struct.
-func (v *AdminService_DescribeHistoryHost_Result) String() string {
+func (v *AdminService_TerminateAllWorkflowRuns_Result) String() string {
 	if v == nil {
 		return "<nil>"
 	}
 
-	var fields [4]string
+	var fields [6]string
 	i := 0
 	if v.Success != nil {
 		fields[i] = fmt.Sprintf("Success: %v", v.Success)
@@ -1808,19 +19036,27 @@ func (v *AdminService_DescribeHistoryHost_Result) String() string {
 		fields[i] = fmt.Sprintf("InternalServiceError: %v", v.InternalServiceError)
 		i++
 	}
+	if v.EntityNotExistError != nil {
+		fields[i] = fmt.Sprintf("EntityNotExistError: %v", v.EntityNotExistError)
+		i++
+	}
+	if v.DomainNotActiveError != nil {
+		fields[i] = fmt.Sprintf("DomainNotActiveError: %v", v.DomainNotActiveError)
+		i++
+	}
 	if v.AccessDeniedError != nil {
 		fields[i] = fmt.Sprintf("AccessDeniedError: %v", v.AccessDeniedError)
 		i++
 	}
 
-	return fmt.Sprintf("AdminService_DescribeHistoryHost_Result{%v}", strings.Join(fields[:i], ", "))
+	return fmt.Sprintf("AdminService_TerminateAllWorkflowRuns_Result{%v}", strings.Join(fields[:i], ", "))
 }
 
-// Equals returns true if all the fields of this AdminService_DescribeHistoryHost_Result match the
-// provided AdminService_DescribeHistoryHost_Result.
+// Equals returns true if all the fields of this AdminService_TerminateAllWorkflowRuns_Result match the
+// provided AdminService_TerminateAllWorkflowRuns_Result.
 //
 // This function performs a deep comparison.
-func (v *AdminService_DescribeHistoryHost_Result) Equals(rhs *AdminService_DescribeHistoryHost_Result) bool {
+func (v *AdminService_TerminateAllWorkflowRuns_Result) Equals(rhs *AdminService_TerminateAllWorkflowRuns_Result) bool {
 	if v == nil {
 		return rhs == nil
 	} else if rhs == nil {
@@ -1835,6 +19071,12 @@ func (v *AdminService_DescribeHistoryHost_Result) Equals(rhs *AdminService_Descr
 	if !((v.InternalServiceError == nil && rhs.InternalServiceError == nil) || (v.InternalServiceError != nil && rhs.InternalServiceError != nil && v.InternalServiceError.Equals(rhs.InternalServiceError))) {
 		return false
 	}
+	if !((v.EntityNotExistError == nil && rhs.EntityNotExistError == nil) || (v.EntityNotExistError != nil && rhs.EntityNotExistError != nil && v.EntityNotExistError.Equals(rhs.EntityNotExistError))) {
+		return false
+	}
+	if !((v.DomainNotActiveError == nil && rhs.DomainNotActiveError == nil) || (v.DomainNotActiveError != nil && rhs.DomainNotActiveError != nil && v.DomainNotActiveError.Equals(rhs.DomainNotActiveError))) {
+		return false
+	}
 	if !((v.AccessDeniedError == nil && rhs.AccessDeniedError == nil) || (v.AccessDeniedError != nil && rhs.AccessDeniedError != nil && v.AccessDeniedError.Equals(rhs.AccessDeniedError))) {
 		return false
 	}
@@ -1843,8 +19085,8 @@ func (v *AdminService_DescribeHistoryHost_Result) Equals(rhs *AdminService_Descr
 }
 
 // MarshalLogObject implements zapcore.ObjectMarshaler, enabling
-// fast logging of AdminService_DescribeHistoryHost_Result.
-func (v *AdminService_DescribeHistoryHost_Result) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+// fast logging of AdminService_TerminateAllWorkflowRuns_Result.
+func (v *AdminService_TerminateAllWorkflowRuns_Result) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
 	if v == nil {
 		return nil
 	}
@@ -1857,6 +19099,12 @@ func (v *AdminService_DescribeHistoryHost_Result) MarshalLogObject(enc zapcore.O
 	if v.InternalServiceError != nil {
 		err = multierr.Append(err, enc.AddObject("internalServiceError", v.InternalServiceError))
 	}
+	if v.EntityNotExistError != nil {
+		err = multierr.Append(err, enc.AddObject("entityNotExistError", v.EntityNotExistError))
+	}
+	if v.DomainNotActiveError != nil {
+		err = multierr.Append(err, enc.AddObject("domainNotActiveError", v.DomainNotActiveError))
+	}
 	if v.AccessDeniedError != nil {
 		err = multierr.Append(err, enc.AddObject("accessDeniedError", v.AccessDeniedError))
 	}
@@ -1865,7 +19113,7 @@ func (v *AdminService_DescribeHistoryHost_Result) MarshalLogObject(enc zapcore.O
 
 // GetSuccess returns the value of Success if it is set or its
 // zero value if it is unset.
-func (v *AdminService_DescribeHistoryHost_Result) GetSuccess() (o *shared.DescribeHistoryHostResponse) {
+func (v *AdminService_TerminateAllWorkflowRuns_Result) GetSuccess() (o *shared.TerminateAllWorkflowRunsResponse) {
 	if v != nil && v.Success != nil {
 		return v.Success
 	}
@@ -1874,13 +19122,13 @@ func (v *AdminService_DescribeHistoryHost_Result) GetSuccess() (o *shared.Descri
 }
 
 // IsSetSuccess returns true if Success is not nil.
-func (v *AdminService_DescribeHistoryHost_Result) IsSetSuccess() bool {
+func (v *AdminService_TerminateAllWorkflowRuns_Result) IsSetSuccess() bool {
 	return v != nil && v.Success != nil
 }
 
 // GetBadRequestError returns the value of BadRequestError if it is set or its
 // zero value if it is unset.
-func (v *AdminService_DescribeHistoryHost_Result) GetBadRequestError() (o *shared.BadRequestError) {
+func (v *AdminService_TerminateAllWorkflowRuns_Result) GetBadRequestError() (o *shared.BadRequestError) {
 	if v != nil && v.BadRequestError != nil {
 		return v.BadRequestError
 	}
@@ -1889,13 +19137,13 @@ func (v *AdminService_DescribeHistoryHost_Result) GetBadRequestError() (o *share
 }
 
 // IsSetBadRequestError returns true if BadRequestError is not nil.
-func (v *AdminService_DescribeHistoryHost_Result) IsSetBadRequestError() bool {
+func (v *AdminService_TerminateAllWorkflowRuns_Result) IsSetBadRequestError() bool {
 	return v != nil && v.BadRequestError != nil
 }
 
 // GetInternalServiceError returns the value of InternalServiceError if it is set or its
 // zero value if it is unset.
-func (v *AdminService_DescribeHistoryHost_Result) GetInternalServiceError() (o *shared.InternalServiceError) {
+func (v *AdminService_TerminateAllWorkflowRuns_Result) GetInternalServiceError() (o *shared.InternalServiceError) {
 	if v != nil && v.InternalServiceError != nil {
 		return v.InternalServiceError
 	}
@@ -1904,13 +19152,43 @@ func (v *AdminService_DescribeHistoryHost_Result) GetInternalServiceError() (o *
 }
 
 // IsSetInternalServiceError returns true if InternalServiceError is not nil.
-func (v *AdminService_DescribeHistoryHost_Result) IsSetInternalServiceError() bool {
+func (v *AdminService_TerminateAllWorkflowRuns_Result) IsSetInternalServiceError() bool {
 	return v != nil && v.InternalServiceError != nil
 }
 
+// GetEntityNotExistError returns the value of EntityNotExistError if it is set or its
+// zero value if it is unset.
+func (v *AdminService_TerminateAllWorkflowRuns_Result) GetEntityNotExistError() (o *shared.EntityNotExistsError) {
+	if v != nil && v.EntityNotExistError != nil {
+		return v.EntityNotExistError
+	}
+
+	return
+}
+
+// IsSetEntityNotExistError returns true if EntityNotExistError is not nil.
+func (v *AdminService_TerminateAllWorkflowRuns_Result) IsSetEntityNotExistError() bool {
+	return v != nil && v.EntityNotExistError != nil
+}
+
+// GetDomainNotActiveError returns the value of DomainNotActiveError if it is set or its
+// zero value if it is unset.
+func (v *AdminService_TerminateAllWorkflowRuns_Result) GetDomainNotActiveError() (o *shared.DomainNotActiveError) {
+	if v != nil && v.DomainNotActiveError != nil {
+		return v.DomainNotActiveError
+	}
+
+	return
+}
+
+// IsSetDomainNotActiveError returns true if DomainNotActiveError is not nil.
+func (v *AdminService_TerminateAllWorkflowRuns_Result) IsSetDomainNotActiveError() bool {
+	return v != nil && v.DomainNotActiveError != nil
+}
+
 // GetAccessDeniedError returns the value of AccessDeniedError if it is set or its
 // zero value if it is unset.
-func (v *AdminService_DescribeHistoryHost_Result) GetAccessDeniedError() (o *shared.AccessDeniedError) {
+func (v *AdminService_TerminateAllWorkflowRuns_Result) GetAccessDeniedError() (o *shared.AccessDeniedError) {
 	if v != nil && v.AccessDeniedError != nil {
 		return v.AccessDeniedError
 	}
@@ -1919,33 +19197,33 @@ func (v *AdminService_DescribeHistoryHost_Result) GetAccessDeniedError() (o *sha
 }
 
 // IsSetAccessDeniedError returns true if AccessDeniedError is not nil.
-func (v *AdminService_DescribeHistoryHost_Result) IsSetAccessDeniedError() bool {
+func (v *AdminService_TerminateAllWorkflowRuns_Result) IsSetAccessDeniedError() bool {
 	return v != nil && v.AccessDeniedError != nil
 }
 
 // MethodName returns the name of the Thrift function as specified in
 // the IDL, for which this struct represent the result.
 //
-// This will always be "DescribeHistoryHost" for this struct.
-func (v *AdminService_DescribeHistoryHost_Result) MethodName() string {
-	return "DescribeHistoryHost"
+// This will always be "TerminateAllWorkflowRuns" for this struct.
+func (v *AdminService_TerminateAllWorkflowRuns_Result) MethodName() string {
+	return "TerminateAllWorkflowRuns"
 }
 
 // EnvelopeType returns the kind of value inside this struct.
 //
 // This will always be Reply for this struct.
-func (v *AdminService_DescribeHistoryHost_Result) EnvelopeType() wire.EnvelopeType {
+func (v *AdminService_TerminateAllWorkflowRuns_Result) EnvelopeType() wire.EnvelopeType {
 	return wire.Reply
 }
 
-// AdminService_DescribeWorkflowExecution_Args represents the arguments for the AdminService.DescribeWorkflowExecution function.
+// AdminService_UndrainHistoryHost_Args represents the arguments for the AdminService.UndrainHistoryHost function.
 //
-// The arguments for DescribeWorkflowExecution are sent and received over the wire as this struct.
-type AdminService_DescribeWorkflowExecution_Args struct {
-	Request *DescribeWorkflowExecutionRequest `json:"request,omitempty"`
+// The arguments for UndrainHistoryHost are sent and received over the wire as this struct.
+type AdminService_UndrainHistoryHost_Args struct {
+	Request *shared.UndrainHistoryHostRequest `json:"request,omitempty"`
 }
 
-// ToWire translates a AdminService_DescribeWorkflowExecution_Args struct into a Thrift-level intermediate
+// ToWire translates a AdminService_UndrainHistoryHost_Args struct into a Thrift-level intermediate
 // representation. This intermediate representation may be serialized
 // into bytes using a ThriftRW protocol implementation.
 //
@@ -1960,7 +19238,7 @@ type AdminService_DescribeWorkflowExecution_Args struct {
 //   if err := binaryProtocol.Encode(x, writer); err != nil {
 //     return err
 //   }
-func (v *AdminService_DescribeWorkflowExecution_Args) ToWire() (wire.Value, error) {
+func (v *AdminService_UndrainHistoryHost_Args) ToWire() (wire.Value, error) {
 	var (
 		fields [1]wire.Field
 		i      int = 0
@@ -1980,17 +19258,17 @@ func (v *AdminService_DescribeWorkflowExecution_Args) ToWire() (wire.Value, erro
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
 
-func _DescribeWorkflowExecutionRequest_Read(w wire.Value) (*DescribeWorkflowExecutionRequest, error) {
-	var v DescribeWorkflowExecutionRequest
+func _UndrainHistoryHostRequest_Read(w wire.Value) (*shared.UndrainHistoryHostRequest, error) {
+	var v shared.UndrainHistoryHostRequest
 	err := v.FromWire(w)
 	return &v, err
 }
 
-// FromWire deserializes a AdminService_DescribeWorkflowExecution_Args struct from its Thrift-level
+// FromWire deserializes a AdminService_UndrainHistoryHost_Args struct from its Thrift-level
 // representation. The Thrift-level representation may be obtained
 // from a ThriftRW protocol implementation.
 //
-// An error is returned if we were unable to build a AdminService_DescribeWorkflowExecution_Args struct
+// An error is returned if we were unable to build a AdminService_UndrainHistoryHost_Args struct
 // from the provided intermediate representation.
 //
 //   x, err := binaryProtocol.Decode(reader, wire.TStruct)
@@ -1998,19 +19276,19 @@ func _DescribeWorkflowExecutionRequest_Read(w wire.Value) (*DescribeWorkflowExec
 //     return nil, err
 //   }
 //
-//   var v AdminService_DescribeWorkflowExecution_Args
+//   var v AdminService_UndrainHistoryHost_Args
 //   if err := v.FromWire(x); err != nil {
 //     return nil, err
 //   }
 //   return &v, nil
-func (v *AdminService_DescribeWorkflowExecution_Args) FromWire(w wire.Value) error {
+func (v *AdminService_UndrainHistoryHost_Args) FromWire(w wire.Value) error {
 	var err error
 
 	for _, field := range w.GetStruct().Fields {
 		switch field.ID {
 		case 1:
 			if field.Value.Type() == wire.TStruct {
-				v.Request, err = _DescribeWorkflowExecutionRequest_Read(field.Value)
+				v.Request, err = _UndrainHistoryHostRequest_Read(field.Value)
 				if err != nil {
 					return err
 				}
@@ -2022,9 +19300,9 @@ func (v *AdminService_DescribeWorkflowExecution_Args) FromWire(w wire.Value) err
 	return nil
 }
 
-// String returns a readable string representation of a AdminService_DescribeWorkflowExecution_Args
+// String returns a readable string representation of a AdminService_UndrainHistoryHost_Args
 // struct.
-func (v *AdminService_DescribeWorkflowExecution_Args) String() string {
+func (v *AdminService_UndrainHistoryHost_Args) String() string {
 	if v == nil {
 		return "<nil>"
 	}
@@ -2036,14 +19314,14 @@ func (v *AdminService_DescribeWorkflowExecution_Args) String() string {
 		i++
 	}
 
-	return fmt.Sprintf("AdminService_DescribeWorkflowExecution_Args{%v}", strings.Join(fields[:i], ", "))
+	return fmt.Sprintf("AdminService_UndrainHistoryHost_Args{%v}", strings.Join(fields[:i], ", "))
 }
 
-// Equals returns true if all the fields of this AdminService_DescribeWorkflowExecution_Args match the
-// provided AdminService_DescribeWorkflowExecution_Args.
+// Equals returns true if all the fields of this AdminService_UndrainHistoryHost_Args match the
+// provided AdminService_UndrainHistoryHost_Args.
 //
 // This function performs a deep comparison.
-func (v *AdminService_DescribeWorkflowExecution_Args) Equals(rhs *AdminService_DescribeWorkflowExecution_Args) bool {
+func (v *AdminService_UndrainHistoryHost_Args) Equals(rhs *AdminService_UndrainHistoryHost_Args) bool {
 	if v == nil {
 		return rhs == nil
 	} else if rhs == nil {
@@ -2057,8 +19335,8 @@ func (v *AdminService_DescribeWorkflowExecution_Args) Equals(rhs *AdminService_D
 }
 
 // MarshalLogObject implements zapcore.ObjectMarshaler, enabling
-// fast logging of AdminService_DescribeWorkflowExecution_Args.
-func (v *AdminService_DescribeWorkflowExecution_Args) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+// fast logging of AdminService_UndrainHistoryHost_Args.
+func (v *AdminService_UndrainHistoryHost_Args) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
 	if v == nil {
 		return nil
 	}
@@ -2070,7 +19348,7 @@ func (v *AdminService_DescribeWorkflowExecution_Args) MarshalLogObject(enc zapco
 
 // GetRequest returns the value of Request if it is set or its
 // zero value if it is unset.
-func (v *AdminService_DescribeWorkflowExecution_Args) GetRequest() (o *DescribeWorkflowExecutionRequest) {
+func (v *AdminService_UndrainHistoryHost_Args) GetRequest() (o *shared.UndrainHistoryHostRequest) {
 	if v != nil && v.Request != nil {
 		return v.Request
 	}
@@ -2079,87 +19357,85 @@ func (v *AdminService_DescribeWorkflowExecution_Args) GetRequest() (o *DescribeW
 }
 
 // IsSetRequest returns true if Request is not nil.
-func (v *AdminService_DescribeWorkflowExecution_Args) IsSetRequest() bool {
+func (v *AdminService_UndrainHistoryHost_Args) IsSetRequest() bool {
 	return v != nil && v.Request != nil
 }
 
 // MethodName returns the name of the Thrift function as specified in
 // the IDL, for which this struct represent the arguments.
 //
-// This will always be "DescribeWorkflowExecution" for this struct.
-func (v *AdminService_DescribeWorkflowExecution_Args) MethodName() string {
-	return "DescribeWorkflowExecution"
+// This will always be "UndrainHistoryHost" for this struct.
+func (v *AdminService_UndrainHistoryHost_Args) MethodName() string {
+	return "UndrainHistoryHost"
 }
 
 // EnvelopeType returns the kind of value inside this struct.
 //
 // This will always be Call for this struct.
-func (v *AdminService_DescribeWorkflowExecution_Args) EnvelopeType() wire.EnvelopeType {
+func (v *AdminService_UndrainHistoryHost_Args) EnvelopeType() wire.EnvelopeType {
 	return wire.Call
 }
 
-// AdminService_DescribeWorkflowExecution_Helper provides functions that aid in handling the
-// parameters and return values of the AdminService.DescribeWorkflowExecution
+// AdminService_UndrainHistoryHost_Helper provides functions that aid in handling the
+// parameters and return values of the AdminService.UndrainHistoryHost
 // function.
-var AdminService_DescribeWorkflowExecution_Helper = struct {
-	// Args accepts the parameters of DescribeWorkflowExecution in-order and returns
+var AdminService_UndrainHistoryHost_Helper = struct {
+	// Args accepts the parameters of UndrainHistoryHost in-order and returns
 	// the arguments struct for the function.
 	Args func(
-		request *DescribeWorkflowExecutionRequest,
-	) *AdminService_DescribeWorkflowExecution_Args
+		request *shared.UndrainHistoryHostRequest,
+	) *AdminService_UndrainHistoryHost_Args
 
 	// IsException returns true if the given error can be thrown
-	// by DescribeWorkflowExecution.
+	// by UndrainHistoryHost.
 	//
-	// An error can be thrown by DescribeWorkflowExecution only if the
+	// An error can be thrown by UndrainHistoryHost only if the
 	// corresponding exception type was mentioned in the 'throws'
 	// section for it in the Thrift file.
 	IsException func(error) bool
 
-	// WrapResponse returns the result struct for DescribeWorkflowExecution
+	// WrapResponse returns the result struct for UndrainHistoryHost
 	// given its return value and error.
 	//
 	// This allows mapping values and errors returned by
-	// DescribeWorkflowExecution into a serializable result struct.
+	// UndrainHistoryHost into a serializable result struct.
 	// WrapResponse returns a non-nil error if the provided
-	// error cannot be thrown by DescribeWorkflowExecution
+	// error cannot be thrown by UndrainHistoryHost
 	//
-	//   value, err := DescribeWorkflowExecution(args)
-	//   result, err := AdminService_DescribeWorkflowExecution_Helper.WrapResponse(value, err)
+	//   value, err := UndrainHistoryHost(args)
+	//   result, err := AdminService_UndrainHistoryHost_Helper.WrapResponse(value, err)
 	//   if err != nil {
-	//     return fmt.Errorf("unexpected error from DescribeWorkflowExecution: %v", err)
+	//     return fmt.Errorf("unexpected error from UndrainHistoryHost: %v", err)
 	//   }
 	//   serialize(result)
-	WrapResponse func(*DescribeWorkflowExecutionResponse, error) (*AdminService_DescribeWorkflowExecution_Result, error)
+	WrapResponse func(*shared.UndrainHistoryHostResponse, error) (*AdminService_UndrainHistoryHost_Result, error)
 
-	// UnwrapResponse takes the result struct for DescribeWorkflowExecution
+	// UnwrapResponse takes the result struct for UndrainHistoryHost
 	// and returns the value or error returned by it.
 	//
-	// The error is non-nil only if DescribeWorkflowExecution threw an
+	// The error is non-nil only if UndrainHistoryHost threw an
 	// exception.
 	//
 	//   result := deserialize(bytes)
-	//   value, err := AdminService_DescribeWorkflowExecution_Helper.UnwrapResponse(result)
-	UnwrapResponse func(*AdminService_DescribeWorkflowExecution_Result) (*DescribeWorkflowExecutionResponse, error)
+	//   value, err := AdminService_UndrainHistoryHost_Helper.UnwrapResponse(result)
+	UnwrapResponse func(*AdminService_UndrainHistoryHost_Result) (*shared.UndrainHistoryHostResponse, error)
 }{}
 
 func init() {
-	AdminService_DescribeWorkflowExecution_Helper.Args = func(
-		request *DescribeWorkflowExecutionRequest,
-	) *AdminService_DescribeWorkflowExecution_Args {
-		return &AdminService_DescribeWorkflowExecution_Args{
+	AdminService_UndrainHistoryHost_Helper.Args = func(
+		request *shared.UndrainHistoryHostRequest,
+	) *AdminService_UndrainHistoryHost_Args {
+		return &AdminService_UndrainHistoryHost_Args{
 			Request: request,
 		}
 	}
 
-	AdminService_DescribeWorkflowExecution_Helper.IsException = func(err error) bool {
+	AdminService_UndrainHistoryHost_Helper.IsException = func(err error) bool {
 		switch err.(type) {
 		case *shared.BadRequestError:
 			return true
 		case *shared.InternalServiceError:
 			return true
-		case *shared.EntityNotExistsError:
-			return true
 		case *shared.AccessDeniedError:
 			return true
 		default:
@@ -2167,37 +19443,32 @@ func init() {
 		}
 	}
 
-	AdminService_DescribeWorkflowExecution_Helper.WrapResponse = func(success *DescribeWorkflowExecutionResponse, err error) (*AdminService_DescribeWorkflowExecution_Result, error) {
+	AdminService_UndrainHistoryHost_Helper.WrapResponse = func(success *shared.UndrainHistoryHostResponse, err error) (*AdminService_UndrainHistoryHost_Result, error) {
 		if err == nil {
-			return &AdminService_DescribeWorkflowExecution_Result{Success: success}, nil
+			return &AdminService_UndrainHistoryHost_Result{Success: success}, nil
 		}
 
 		switch e := err.(type) {
 		case *shared.BadRequestError:
 			if e == nil {
-				return nil, errors.New("WrapResponse received non-nil error type with nil value for AdminService_DescribeWorkflowExecution_Result.BadRequestError")
+				return nil, errors.New("WrapResponse received non-nil error type with nil value for AdminService_UndrainHistoryHost_Result.BadRequestError")
 			}
-			return &AdminService_DescribeWorkflowExecution_Result{BadRequestError: e}, nil
+			return &AdminService_UndrainHistoryHost_Result{BadRequestError: e}, nil
 		case *shared.InternalServiceError:
 			if e == nil {
-				return nil, errors.New("WrapResponse received non-nil error type with nil value for AdminService_DescribeWorkflowExecution_Result.InternalServiceError")
-			}
-			return &AdminService_DescribeWorkflowExecution_Result{InternalServiceError: e}, nil
-		case *shared.EntityNotExistsError:
-			if e == nil {
-				return nil, errors.New("WrapResponse received non-nil error type with nil value for AdminService_DescribeWorkflowExecution_Result.EntityNotExistError")
+				return nil, errors.New("WrapResponse received non-nil error type with nil value for AdminService_UndrainHistoryHost_Result.InternalServiceError")
 			}
-			return &AdminService_DescribeWorkflowExecution_Result{EntityNotExistError: e}, nil
+			return &AdminService_UndrainHistoryHost_Result{InternalServiceError: e}, nil
 		case *shared.AccessDeniedError:
 			if e == nil {
-				return nil, errors.New("WrapResponse received non-nil error type with nil value for AdminService_DescribeWorkflowExecution_Result.AccessDeniedError")
+				return nil, errors.New("WrapResponse received non-nil error type with nil value for AdminService_UndrainHistoryHost_Result.AccessDeniedError")
 			}
-			return &AdminService_DescribeWorkflowExecution_Result{AccessDeniedError: e}, nil
+			return &AdminService_UndrainHistoryHost_Result{AccessDeniedError: e}, nil
 		}
 
 		return nil, err
 	}
-	AdminService_DescribeWorkflowExecution_Helper.UnwrapResponse = func(result *AdminService_DescribeWorkflowExecution_Result) (success *DescribeWorkflowExecutionResponse, err error) {
+	AdminService_UndrainHistoryHost_Helper.UnwrapResponse = func(result *AdminService_UndrainHistoryHost_Result) (success *shared.UndrainHistoryHostResponse, err error) {
 		if result.BadRequestError != nil {
 			err = result.BadRequestError
 			return
@@ -2206,10 +19477,6 @@ func init() {
 			err = result.InternalServiceError
 			return
 		}
-		if result.EntityNotExistError != nil {
-			err = result.EntityNotExistError
-			return
-		}
 		if result.AccessDeniedError != nil {
 			err = result.AccessDeniedError
 			return
@@ -2226,21 +19493,20 @@ func init() {
 
 }
 
-// AdminService_DescribeWorkflowExecution_Result represents the result of a AdminService.DescribeWorkflowExecution function call.
+// AdminService_UndrainHistoryHost_Result represents the result of a AdminService.UndrainHistoryHost function call.
 //
-// The result of a DescribeWorkflowExecution execution is sent and received over the wire as this struct.
+// The result of a UndrainHistoryHost execution is sent and received over the wire as this struct.
 //
 // Success is set only if the function did not throw an exception.
-type AdminService_DescribeWorkflowExecution_Result struct {
-	// Value returned by DescribeWorkflowExecution after a successful execution.
-	Success              *DescribeWorkflowExecutionResponse `json:"success,omitempty"`
-	BadRequestError      *shared.BadRequestError            `json:"badRequestError,omitempty"`
-	InternalServiceError *shared.InternalServiceError       `json:"internalServiceError,omitempty"`
-	EntityNotExistError  *shared.EntityNotExistsError       `json:"entityNotExistError,omitempty"`
-	AccessDeniedError    *shared.AccessDeniedError          `json:"accessDeniedError,omitempty"`
+type AdminService_UndrainHistoryHost_Result struct {
+	// Value returned by UndrainHistoryHost after a successful execution.
+	Success              *shared.UndrainHistoryHostResponse `json:"success,omitempty"`
+	BadRequestError      *shared.BadRequestError          `json:"badRequestError,omitempty"`
+	InternalServiceError *shared.InternalServiceError     `json:"internalServiceError,omitempty"`
+	AccessDeniedError    *shared.AccessDeniedError        `json:"accessDeniedError,omitempty"`
 }
 
-// ToWire translates a AdminService_DescribeWorkflowExecution_Result struct into a Thrift-level intermediate
+// ToWire translates a AdminService_UndrainHistoryHost_Result struct into a Thrift-level intermediate
 // representation. This intermediate representation may be serialized
 // into bytes using a ThriftRW protocol implementation.
 //
@@ -2255,9 +19521,9 @@ type AdminService_DescribeWorkflowExecution_Result struct {
 //   if err := binaryProtocol.Encode(x, writer); err != nil {
 //     return err
 //   }
-func (v *AdminService_DescribeWorkflowExecution_Result) ToWire() (wire.Value, error) {
+func (v *AdminService_UndrainHistoryHost_Result) ToWire() (wire.Value, error) {
 	var (
-		fields [5]wire.Field
+		fields [4]wire.Field
 		i      int = 0
 		w      wire.Value
 		err    error
@@ -2287,47 +19553,33 @@ func (v *AdminService_DescribeWorkflowExecution_Result) ToWire() (wire.Value, er
 		fields[i] = wire.Field{ID: 2, Value: w}
 		i++
 	}
-	if v.EntityNotExistError != nil {
-		w, err = v.EntityNotExistError.ToWire()
-		if err != nil {
-			return w, err
-		}
-		fields[i] = wire.Field{ID: 3, Value: w}
-		i++
-	}
 	if v.AccessDeniedError != nil {
 		w, err = v.AccessDeniedError.ToWire()
 		if err != nil {
 			return w, err
 		}
-		fields[i] = wire.Field{ID: 4, Value: w}
+		fields[i] = wire.Field{ID: 3, Value: w}
 		i++
 	}
 
 	if i != 1 {
-		return wire.Value{}, fmt.Errorf("AdminService_DescribeWorkflowExecution_Result should have exactly one field: got %v fields", i)
+		return wire.Value{}, fmt.Errorf("AdminService_UndrainHistoryHost_Result should have exactly one field: got %v fields", i)
 	}
 
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
 
-func _DescribeWorkflowExecutionResponse_Read(w wire.Value) (*DescribeWorkflowExecutionResponse, error) {
-	var v DescribeWorkflowExecutionResponse
-	err := v.FromWire(w)
-	return &v, err
-}
-
-func _EntityNotExistsError_Read(w wire.Value) (*shared.EntityNotExistsError, error) {
-	var v shared.EntityNotExistsError
+func _UndrainHistoryHostResponse_Read(w wire.Value) (*shared.UndrainHistoryHostResponse, error) {
+	var v shared.UndrainHistoryHostResponse
 	err := v.FromWire(w)
 	return &v, err
 }
 
-// FromWire deserializes a AdminService_DescribeWorkflowExecution_Result struct from its Thrift-level
+// FromWire deserializes a AdminService_UndrainHistoryHost_Result struct from its Thrift-level
 // representation. The Thrift-level representation may be obtained
 // from a ThriftRW protocol implementation.
 //
-// An error is returned if we were unable to build a AdminService_DescribeWorkflowExecution_Result struct
+// An error is returned if we were unable to build a AdminService_UndrainHistoryHost_Result struct
 // from the provided intermediate representation.
 //
 //   x, err := binaryProtocol.Decode(reader, wire.TStruct)
@@ -2335,19 +19587,19 @@ func _EntityNotExistsError_Read(w wire.Value) (*shared.EntityNotExistsError, err
 //     return nil, err
 //   }
 //
-//   var v AdminService_DescribeWorkflowExecution_Result
+//   var v AdminService_UndrainHistoryHost_Result
 //   if err := v.FromWire(x); err != nil {
 //     return nil, err
 //   }
 //   return &v, nil
-func (v *AdminService_DescribeWorkflowExecution_Result) FromWire(w wire.Value) error {
+func (v *AdminService_UndrainHistoryHost_Result) FromWire(w wire.Value) error {
 	var err error
 
 	for _, field := range w.GetStruct().Fields {
 		switch field.ID {
 		case 0:
 			if field.Value.Type() == wire.TStruct {
-				v.Success, err = _DescribeWorkflowExecutionResponse_Read(field.Value)
+				v.Success, err = _UndrainHistoryHostResponse_Read(field.Value)
 				if err != nil {
 					return err
 				}
@@ -2370,14 +19622,6 @@ func (v *AdminService_DescribeWorkflowExecution_Result) FromWire(w wire.Value) e
 
 			}
 		case 3:
-			if field.Value.Type() == wire.TStruct {
-				v.EntityNotExistError, err = _EntityNotExistsError_Read(field.Value)
-				if err != nil {
-					return err
-				}
-
-			}
-		case 4:
 			if field.Value.Type() == wire.TStruct {
 				v.AccessDeniedError, err = _AccessDeniedError_Read(field.Value)
 				if err != nil {
@@ -2398,27 +19642,24 @@ func (v *AdminService_DescribeWorkflowExecution_Result) FromWire(w wire.Value) e
 	if v.InternalServiceError != nil {
 		count++
 	}
-	if v.EntityNotExistError != nil {
-		count++
-	}
 	if v.AccessDeniedError != nil {
 		count++
 	}
 	if count != 1 {
-		return fmt.Errorf("AdminService_DescribeWorkflowExecution_Result should have exactly one field: got %v fields", count)
+		return fmt.Errorf("AdminService_UndrainHistoryHost_Result should have exactly one field: got %v fields", count)
 	}
 
 	return nil
 }
 
-// String returns a readable string representation of a AdminService_DescribeWorkflowExecution_Result
+// String returns a readable string representation of a AdminService_UndrainHistoryHost_Result
 // struct.
-func (v *AdminService_DescribeWorkflowExecution_Result) String() string {
+func (v *AdminService_UndrainHistoryHost_Result) String() string {
 	if v == nil {
 		return "<nil>"
 	}
 
-	var fields [5]string
+	var fields [4]string
 	i := 0
 	if v.Success != nil {
 		fields[i] = fmt.Sprintf("Success: %v", v.Success)
@@ -2432,23 +19673,19 @@ func (v *AdminService_DescribeWorkflowExecution_Result) String() string {
 		fields[i] = fmt.Sprintf("InternalServiceError: %v", v.InternalServiceError)
 		i++
 	}
-	if v.EntityNotExistError != nil {
-		fields[i] = fmt.Sprintf("EntityNotExistError: %v", v.EntityNotExistError)
-		i++
-	}
 	if v.AccessDeniedError != nil {
 		fields[i] = fmt.Sprintf("AccessDeniedError: %v", v.AccessDeniedError)
 		i++
 	}
 
-	return fmt.Sprintf("AdminService_DescribeWorkflowExecution_Result{%v}", strings.Join(fields[:i], ", "))
+	return fmt.Sprintf("AdminService_UndrainHistoryHost_Result{%v}", strings.Join(fields[:i], ", "))
 }
 
-// Equals returns true if all the fields of this AdminService_DescribeWorkflowExecution_Result match the
-// provided AdminService_DescribeWorkflowExecution_Result.
+// Equals returns true if all the fields of this AdminService_UndrainHistoryHost_Result match the
+// provided AdminService_UndrainHistoryHost_Result.
 //
 // This function performs a deep comparison.
-func (v *AdminService_DescribeWorkflowExecution_Result) Equals(rhs *AdminService_DescribeWorkflowExecution_Result) bool {
+func (v *AdminService_UndrainHistoryHost_Result) Equals(rhs *AdminService_UndrainHistoryHost_Result) bool {
 	if v == nil {
 		return rhs == nil
 	} else if rhs == nil {
@@ -2463,9 +19700,6 @@ func (v *AdminService_DescribeWorkflowExecution_Result) Equals(rhs *AdminService
 	if !((v.InternalServiceError == nil && rhs.InternalServiceError == nil) || (v.InternalServiceError != nil && rhs.InternalServiceError != nil && v.InternalServiceError.Equals(rhs.InternalServiceError))) {
 		return false
 	}
-	if !((v.EntityNotExistError == nil && rhs.EntityNotExistError == nil) || (v.EntityNotExistError != nil && rhs.EntityNotExistError != nil && v.EntityNotExistError.Equals(rhs.EntityNotExistError))) {
-		return false
-	}
 	if !((v.AccessDeniedError == nil && rhs.AccessDeniedError == nil) || (v.AccessDeniedError != nil && rhs.AccessDeniedError != nil && v.AccessDeniedError.Equals(rhs.AccessDeniedError))) {
 		return false
 	}
@@ -2474,8 +19708,8 @@ func (v *AdminService_DescribeWorkflowExecution_Result) Equals(rhs *AdminService
 }
 
 // MarshalLogObject implements zapcore.ObjectMarshaler, enabling
-// fast logging of AdminService_DescribeWorkflowExecution_Result.
-func (v *AdminService_DescribeWorkflowExecution_Result) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+// fast logging of AdminService_UndrainHistoryHost_Result.
+func (v *AdminService_UndrainHistoryHost_Result) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
 	if v == nil {
 		return nil
 	}
@@ -2488,9 +19722,6 @@ func (v *AdminService_DescribeWorkflowExecution_Result) MarshalLogObject(enc zap
 	if v.InternalServiceError != nil {
 		err = multierr.Append(err, enc.AddObject("internalServiceError", v.InternalServiceError))
 	}
-	if v.EntityNotExistError != nil {
-		err = multierr.Append(err, enc.AddObject("entityNotExistError", v.EntityNotExistError))
-	}
 	if v.AccessDeniedError != nil {
 		err = multierr.Append(err, enc.AddObject("accessDeniedError", v.AccessDeniedError))
 	}
@@ -2499,7 +19730,7 @@ func (v *AdminService_DescribeWorkflowExecution_Result) MarshalLogObject(enc zap
 
 // GetSuccess returns the value of Success if it is set or its
 // zero value if it is unset.
-func (v *AdminService_DescribeWorkflowExecution_Result) GetSuccess() (o *DescribeWorkflowExecutionResponse) {
+func (v *AdminService_UndrainHistoryHost_Result) GetSuccess() (o *shared.UndrainHistoryHostResponse) {
 	if v != nil && v.Success != nil {
 		return v.Success
 	}
@@ -2508,13 +19739,13 @@ func (v *AdminService_DescribeWorkflowExecution_Result) GetSuccess() (o *Describ
 }
 
 // IsSetSuccess returns true if Success is not nil.
-func (v *AdminService_DescribeWorkflowExecution_Result) IsSetSuccess() bool {
+func (v *AdminService_UndrainHistoryHost_Result) IsSetSuccess() bool {
 	return v != nil && v.Success != nil
 }
 
 // GetBadRequestError returns the value of BadRequestError if it is set or its
 // zero value if it is unset.
-func (v *AdminService_DescribeWorkflowExecution_Result) GetBadRequestError() (o *shared.BadRequestError) {
+func (v *AdminService_UndrainHistoryHost_Result) GetBadRequestError() (o *shared.BadRequestError) {
 	if v != nil && v.BadRequestError != nil {
 		return v.BadRequestError
 	}
@@ -2522,44 +19753,29 @@ func (v *AdminService_DescribeWorkflowExecution_Result) GetBadRequestError() (o
 	return
 }
 
-// IsSetBadRequestError returns true if BadRequestError is not nil.
-func (v *AdminService_DescribeWorkflowExecution_Result) IsSetBadRequestError() bool {
-	return v != nil && v.BadRequestError != nil
-}
-
-// GetInternalServiceError returns the value of InternalServiceError if it is set or its
-// zero value if it is unset.
-func (v *AdminService_DescribeWorkflowExecution_Result) GetInternalServiceError() (o *shared.InternalServiceError) {
-	if v != nil && v.InternalServiceError != nil {
-		return v.InternalServiceError
-	}
-
-	return
-}
-
-// IsSetInternalServiceError returns true if InternalServiceError is not nil.
-func (v *AdminService_DescribeWorkflowExecution_Result) IsSetInternalServiceError() bool {
-	return v != nil && v.InternalServiceError != nil
+// IsSetBadRequestError returns true if BadRequestError is not nil.
+func (v *AdminService_UndrainHistoryHost_Result) IsSetBadRequestError() bool {
+	return v != nil && v.BadRequestError != nil
 }
 
-// GetEntityNotExistError returns the value of EntityNotExistError if it is set or its
+// GetInternalServiceError returns the value of InternalServiceError if it is set or its
 // zero value if it is unset.
-func (v *AdminService_DescribeWorkflowExecution_Result) GetEntityNotExistError() (o *shared.EntityNotExistsError) {
-	if v != nil && v.EntityNotExistError != nil {
-		return v.EntityNotExistError
+func (v *AdminService_UndrainHistoryHost_Result) GetInternalServiceError() (o *shared.InternalServiceError) {
+	if v != nil && v.InternalServiceError != nil {
+		return v.InternalServiceError
 	}
 
 	return
 }
 
-// IsSetEntityNotExistError returns true if EntityNotExistError is not nil.
-func (v *AdminService_DescribeWorkflowExecution_Result) IsSetEntityNotExistError() bool {
-	return v != nil && v.EntityNotExistError != nil
+// IsSetInternalServiceError returns true if InternalServiceError is not nil.
+func (v *AdminService_UndrainHistoryHost_Result) IsSetInternalServiceError() bool {
+	return v != nil && v.InternalServiceError != nil
 }
 
 // GetAccessDeniedError returns the value of AccessDeniedError if it is set or its
 // zero value if it is unset.
-func (v *AdminService_DescribeWorkflowExecution_Result) GetAccessDeniedError() (o *shared.AccessDeniedError) {
+func (v *AdminService_UndrainHistoryHost_Result) GetAccessDeniedError() (o *shared.AccessDeniedError) {
 	if v != nil && v.AccessDeniedError != nil {
 		return v.AccessDeniedError
 	}
@@ -2568,33 +19784,33 @@ func (v *AdminService_DescribeWorkflowExecution_Result) GetAccessDeniedError() (
 }
 
 // IsSetAccessDeniedError returns true if AccessDeniedError is not nil.
-func (v *AdminService_DescribeWorkflowExecution_Result) IsSetAccessDeniedError() bool {
+func (v *AdminService_UndrainHistoryHost_Result) IsSetAccessDeniedError() bool {
 	return v != nil && v.AccessDeniedError != nil
 }
 
 // MethodName returns the name of the Thrift function as specified in
 // the IDL, for which this struct represent the result.
 //
-// This will always be "DescribeWorkflowExecution" for this struct.
-func (v *AdminService_DescribeWorkflowExecution_Result) MethodName() string {
-	return "DescribeWorkflowExecution"
+// This will always be "UndrainHistoryHost" for this struct.
+func (v *AdminService_UndrainHistoryHost_Result) MethodName() string {
+	return "UndrainHistoryHost"
 }
 
 // EnvelopeType returns the kind of value inside this struct.
 //
 // This will always be Reply for this struct.
-func (v *AdminService_DescribeWorkflowExecution_Result) EnvelopeType() wire.EnvelopeType {
+func (v *AdminService_UndrainHistoryHost_Result) EnvelopeType() wire.EnvelopeType {
 	return wire.Reply
 }
 
-// AdminService_GetWorkflowExecutionRawHistory_Args represents the arguments for the AdminService.GetWorkflowExecutionRawHistory function.
+// AdminService_UpdateTaskListVersionSets_Args represents the arguments for the AdminService.UpdateTaskListVersionSets function.
 //
-// The arguments for GetWorkflowExecutionRawHistory are sent and received over the wire as this struct.
-type AdminService_GetWorkflowExecutionRawHistory_Args struct {
-	GetRequest *GetWorkflowExecutionRawHistoryRequest `json:"getRequest,omitempty"`
+// The arguments for UpdateTaskListVersionSets are sent and received over the wire as this struct.
+type AdminService_UpdateTaskListVersionSets_Args struct {
+	Request *shared.UpdateTaskListVersionSetsRequest `json:"request,omitempty"`
 }
 
-// ToWire translates a AdminService_GetWorkflowExecutionRawHistory_Args struct into a Thrift-level intermediate
+// ToWire translates a AdminService_UpdateTaskListVersionSets_Args struct into a Thrift-level intermediate
 // representation. This intermediate representation may be serialized
 // into bytes using a ThriftRW protocol implementation.
 //
@@ -2609,7 +19825,7 @@ type AdminService_GetWorkflowExecutionRawHistory_Args struct {
 //   if err := binaryProtocol.Encode(x, writer); err != nil {
 //     return err
 //   }
-func (v *AdminService_GetWorkflowExecutionRawHistory_Args) ToWire() (wire.Value, error) {
+func (v *AdminService_UpdateTaskListVersionSets_Args) ToWire() (wire.Value, error) {
 	var (
 		fields [1]wire.Field
 		i      int = 0
@@ -2617,8 +19833,8 @@ func (v *AdminService_GetWorkflowExecutionRawHistory_Args) ToWire() (wire.Value,
 		err    error
 	)
 
-	if v.GetRequest != nil {
-		w, err = v.GetRequest.ToWire()
+	if v.Request != nil {
+		w, err = v.Request.ToWire()
 		if err != nil {
 			return w, err
 		}
@@ -2629,17 +19845,17 @@ func (v *AdminService_GetWorkflowExecutionRawHistory_Args) ToWire() (wire.Value,
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
 
-func _GetWorkflowExecutionRawHistoryRequest_Read(w wire.Value) (*GetWorkflowExecutionRawHistoryRequest, error) {
-	var v GetWorkflowExecutionRawHistoryRequest
+func _UpdateTaskListVersionSetsRequest_Read(w wire.Value) (*shared.UpdateTaskListVersionSetsRequest, error) {
+	var v shared.UpdateTaskListVersionSetsRequest
 	err := v.FromWire(w)
 	return &v, err
 }
 
-// FromWire deserializes a AdminService_GetWorkflowExecutionRawHistory_Args struct from its Thrift-level
+// FromWire deserializes a AdminService_UpdateTaskListVersionSets_Args struct from its Thrift-level
 // representation. The Thrift-level representation may be obtained
 // from a ThriftRW protocol implementation.
 //
-// An error is returned if we were unable to build a AdminService_GetWorkflowExecutionRawHistory_Args struct
+// An error is returned if we were unable to build a AdminService_UpdateTaskListVersionSets_Args struct
 // from the provided intermediate representation.
 //
 //   x, err := binaryProtocol.Decode(reader, wire.TStruct)
@@ -2647,19 +19863,19 @@ func _GetWorkflowExecutionRawHistoryRequest_Read(w wire.Value) (*GetWorkflowExec
 //     return nil, err
 //   }
 //
-//   var v AdminService_GetWorkflowExecutionRawHistory_Args
+//   var v AdminService_UpdateTaskListVersionSets_Args
 //   if err := v.FromWire(x); err != nil {
 //     return nil, err
 //   }
 //   return &v, nil
-func (v *AdminService_GetWorkflowExecutionRawHistory_Args) FromWire(w wire.Value) error {
+func (v *AdminService_UpdateTaskListVersionSets_Args) FromWire(w wire.Value) error {
 	var err error
 
 	for _, field := range w.GetStruct().Fields {
 		switch field.ID {
 		case 1:
 			if field.Value.Type() == wire.TStruct {
-				v.GetRequest, err = _GetWorkflowExecutionRawHistoryRequest_Read(field.Value)
+				v.Request, err = _UpdateTaskListVersionSetsRequest_Read(field.Value)
 				if err != nil {
 					return err
 				}
@@ -2671,34 +19887,34 @@ func (v *AdminService_GetWorkflowExecutionRawHistory_Args) FromWire(w wire.Value
 	return nil
 }
 
-// String returns a readable string representation of a AdminService_GetWorkflowExecutionRawHistory_Args
+// String returns a readable string representation of a AdminService_UpdateTaskListVersionSets_Args
 // struct.
-func (v *AdminService_GetWorkflowExecutionRawHistory_Args) String() string {
+func (v *AdminService_UpdateTaskListVersionSets_Args) String() string {
 	if v == nil {
 		return "<nil>"
 	}
 
 	var fields [1]string
 	i := 0
-	if v.GetRequest != nil {
-		fields[i] = fmt.Sprintf("GetRequest: %v", v.GetRequest)
+	if v.Request != nil {
+		fields[i] = fmt.Sprintf("Request: %v", v.Request)
 		i++
 	}
 
-	return fmt.Sprintf("AdminService_GetWorkflowExecutionRawHistory_Args{%v}", strings.Join(fields[:i], ", "))
+	return fmt.Sprintf("AdminService_UpdateTaskListVersionSets_Args{%v}", strings.Join(fields[:i], ", "))
 }
 
-// Equals returns true if all the fields of this AdminService_GetWorkflowExecutionRawHistory_Args match the
-// provided AdminService_GetWorkflowExecutionRawHistory_Args.
+// Equals returns true if all the fields of this AdminService_UpdateTaskListVersionSets_Args match the
+// provided AdminService_UpdateTaskListVersionSets_Args.
 //
 // This function performs a deep comparison.
-func (v *AdminService_GetWorkflowExecutionRawHistory_Args) Equals(rhs *AdminService_GetWorkflowExecutionRawHistory_Args) bool {
+func (v *AdminService_UpdateTaskListVersionSets_Args) Equals(rhs *AdminService_UpdateTaskListVersionSets_Args) bool {
 	if v == nil {
 		return rhs == nil
 	} else if rhs == nil {
 		return false
 	}
-	if !((v.GetRequest == nil && rhs.GetRequest == nil) || (v.GetRequest != nil && rhs.GetRequest != nil && v.GetRequest.Equals(rhs.GetRequest))) {
+	if !((v.Request == nil && rhs.Request == nil) || (v.Request != nil && rhs.Request != nil && v.Request.Equals(rhs.Request))) {
 		return false
 	}
 
@@ -2706,102 +19922,103 @@ func (v *AdminService_GetWorkflowExecutionRawHistory_Args) Equals(rhs *AdminServ
 }
 
 // MarshalLogObject implements zapcore.ObjectMarshaler, enabling
-// fast logging of AdminService_GetWorkflowExecutionRawHistory_Args.
-func (v *AdminService_GetWorkflowExecutionRawHistory_Args) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+// fast logging of AdminService_UpdateTaskListVersionSets_Args.
+func (v *AdminService_UpdateTaskListVersionSets_Args) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
 	if v == nil {
 		return nil
 	}
-	if v.GetRequest != nil {
-		err = multierr.Append(err, enc.AddObject("getRequest", v.GetRequest))
+	if v.Request != nil {
+		err = multierr.Append(err, enc.AddObject("request", v.Request))
 	}
 	return err
 }
 
-// GetGetRequest returns the value of GetRequest if it is set or its
+// GetRequest returns the value of Request if it is set or its
 // zero value if it is unset.
-func (v *AdminService_GetWorkflowExecutionRawHistory_Args) GetGetRequest() (o *GetWorkflowExecutionRawHistoryRequest) {
-	if v != nil && v.GetRequest != nil {
-		return v.GetRequest
+func (v *AdminService_UpdateTaskListVersionSets_Args) GetRequest() (o *shared.UpdateTaskListVersionSetsRequest) {
+	if v != nil && v.Request != nil {
+		return v.Request
 	}
 
 	return
 }
 
-// IsSetGetRequest returns true if GetRequest is not nil.
-func (v *AdminService_GetWorkflowExecutionRawHistory_Args) IsSetGetRequest() bool {
-	return v != nil && v.GetRequest != nil
+// IsSetRequest returns true if Request is not nil.
+func (v *AdminService_UpdateTaskListVersionSets_Args) IsSetRequest() bool {
+	return v != nil && v.Request != nil
 }
 
 // MethodName returns the name of the Thrift function as specified in
 // the IDL, for which this struct represent the arguments.
 //
-// This will always be "GetWorkflowExecutionRawHistory" for this struct.
-func (v *AdminService_GetWorkflowExecutionRawHistory_Args) MethodName() string {
-	return "GetWorkflowExecutionRawHistory"
+// This will always be "UpdateTaskListVersionSets" for this struct.
+func (v *AdminService_UpdateTaskListVersionSets_Args) MethodName() string {
+	return "UpdateTaskListVersionSets"
 }
 
 // EnvelopeType returns the kind of value inside this struct.
 //
 // This will always be Call for this struct.
-func (v *AdminService_GetWorkflowExecutionRawHistory_Args) EnvelopeType() wire.EnvelopeType {
+func (v *AdminService_UpdateTaskListVersionSets_Args) EnvelopeType() wire.EnvelopeType {
 	return wire.Call
 }
 
-// AdminService_GetWorkflowExecutionRawHistory_Helper provides functions that aid in handling the
-// parameters and return values of the AdminService.GetWorkflowExecutionRawHistory
+// AdminService_UpdateTaskListVersionSets_Helper provides functions that aid in handling the
+// parameters and return values of the AdminService.UpdateTaskListVersionSets
 // function.
-var AdminService_GetWorkflowExecutionRawHistory_Helper = struct {
-	// Args accepts the parameters of GetWorkflowExecutionRawHistory in-order and returns
+var AdminService_UpdateTaskListVersionSets_Helper = struct {
+	// Args accepts the parameters of UpdateTaskListVersionSets in-order and returns
 	// the arguments struct for the function.
 	Args func(
-		getRequest *GetWorkflowExecutionRawHistoryRequest,
-	) *AdminService_GetWorkflowExecutionRawHistory_Args
+		request *shared.UpdateTaskListVersionSetsRequest,
+	) *AdminService_UpdateTaskListVersionSets_Args
 
 	// IsException returns true if the given error can be thrown
-	// by GetWorkflowExecutionRawHistory.
+	// by UpdateTaskListVersionSets.
 	//
-	// An error can be thrown by GetWorkflowExecutionRawHistory only if the
+	// An error can be thrown by UpdateTaskListVersionSets only if the
 	// corresponding exception type was mentioned in the 'throws'
 	// section for it in the Thrift file.
 	IsException func(error) bool
 
-	// WrapResponse returns the result struct for GetWorkflowExecutionRawHistory
-	// given its return value and error.
+	// WrapResponse returns the result struct for UpdateTaskListVersionSets
+	// given the error returned by it. The provided error may
+	// be nil if UpdateTaskListVersionSets did not fail.
 	//
-	// This allows mapping values and errors returned by
-	// GetWorkflowExecutionRawHistory into a serializable result struct.
-	// WrapResponse returns a non-nil error if the provided
-	// error cannot be thrown by GetWorkflowExecutionRawHistory
+	// This allows mapping errors returned by UpdateTaskListVersionSets into a
+	// serializable result struct. WrapResponse returns a
+	// non-nil error if the provided error cannot be thrown by
+	// UpdateTaskListVersionSets
 	//
-	//   value, err := GetWorkflowExecutionRawHistory(args)
-	//   result, err := AdminService_GetWorkflowExecutionRawHistory_Helper.WrapResponse(value, err)
+	//   err := UpdateTaskListVersionSets(args)
+	//   result, err := AdminService_UpdateTaskListVersionSets_Helper.WrapResponse(err)
 	//   if err != nil {
-	//     return fmt.Errorf("unexpected error from GetWorkflowExecutionRawHistory: %v", err)
+	//     return fmt.Errorf("unexpected error from UpdateTaskListVersionSets: %v", err)
 	//   }
 	//   serialize(result)
-	WrapResponse func(*GetWorkflowExecutionRawHistoryResponse, error) (*AdminService_GetWorkflowExecutionRawHistory_Result, error)
+	WrapResponse func(error) (*AdminService_UpdateTaskListVersionSets_Result, error)
 
-	// UnwrapResponse takes the result struct for GetWorkflowExecutionRawHistory
-	// and returns the value or error returned by it.
+	// UnwrapResponse takes the result struct for UpdateTaskListVersionSets
+	// and returns the erorr returned by it (if any).
 	//
-	// The error is non-nil only if GetWorkflowExecutionRawHistory threw an
+	// The error is non-nil only if UpdateTaskListVersionSets threw an
 	// exception.
 	//
 	//   result := deserialize(bytes)
-	//   value, err := AdminService_GetWorkflowExecutionRawHistory_Helper.UnwrapResponse(result)
-	UnwrapResponse func(*AdminService_GetWorkflowExecutionRawHistory_Result) (*GetWorkflowExecutionRawHistoryResponse, error)
+	//   err := AdminService_UpdateTaskListVersionSets_Helper.UnwrapResponse(result)
+	UnwrapResponse func(*AdminService_UpdateTaskListVersionSets_Result) error
 }{}
 
 func init() {
-	AdminService_GetWorkflowExecutionRawHistory_Helper.Args = func(
-		getRequest *GetWorkflowExecutionRawHistoryRequest,
-	) *AdminService_GetWorkflowExecutionRawHistory_Args {
-		return &AdminService_GetWorkflowExecutionRawHistory_Args{
-			GetRequest: getRequest,
+	AdminService_UpdateTaskListVersionSets_Helper.Args = func(
+		request *shared.UpdateTaskListVersionSetsRequest,
+	) *AdminService_UpdateTaskListVersionSets_Args {
+		return &AdminService_UpdateTaskListVersionSets_Args{
+			Request: request,
 		}
 	}
 
-	AdminService_GetWorkflowExecutionRawHistory_Helper.IsException = func(err error) bool {
+	AdminService_UpdateTaskListVersionSets_Helper.IsException = func(err error) bool {
 		switch err.(type) {
 		case *shared.BadRequestError:
 			return true
@@ -2809,44 +20026,44 @@ func init() {
 			return true
 		case *shared.EntityNotExistsError:
 			return true
-		case *shared.ServiceBusyError:
+		case *shared.AccessDeniedError:
 			return true
 		default:
 			return false
 		}
 	}
 
-	AdminService_GetWorkflowExecutionRawHistory_Helper.WrapResponse = func(success *GetWorkflowExecutionRawHistoryResponse, err error) (*AdminService_GetWorkflowExecutionRawHistory_Result, error) {
+	AdminService_UpdateTaskListVersionSets_Helper.WrapResponse = func(err error) (*AdminService_UpdateTaskListVersionSets_Result, error) {
 		if err == nil {
-			return &AdminService_GetWorkflowExecutionRawHistory_Result{Success: success}, nil
+			return &AdminService_UpdateTaskListVersionSets_Result{}, nil
 		}
 
 		switch e := err.(type) {
 		case *shared.BadRequestError:
 			if e == nil {
-				return nil, errors.New("WrapResponse received non-nil error type with nil value for AdminService_GetWorkflowExecutionRawHistory_Result.BadRequestError")
+				return nil, errors.New("WrapResponse received non-nil error type with nil value for AdminService_UpdateTaskListVersionSets_Result.BadRequestError")
 			}
-			return &AdminService_GetWorkflowExecutionRawHistory_Result{BadRequestError: e}, nil
+			return &AdminService_UpdateTaskListVersionSets_Result{BadRequestError: e}, nil
 		case *shared.InternalServiceError:
 			if e == nil {
-				return nil, errors.New("WrapResponse received non-nil error type with nil value for AdminService_GetWorkflowExecutionRawHistory_Result.InternalServiceError")
+				return nil, errors.New("WrapResponse received non-nil error type with nil value for AdminService_UpdateTaskListVersionSets_Result.InternalServiceError")
 			}
-			return &AdminService_GetWorkflowExecutionRawHistory_Result{InternalServiceError: e}, nil
+			return &AdminService_UpdateTaskListVersionSets_Result{InternalServiceError: e}, nil
 		case *shared.EntityNotExistsError:
 			if e == nil {
-				return nil, errors.New("WrapResponse received non-nil error type with nil value for AdminService_GetWorkflowExecutionRawHistory_Result.EntityNotExistError")
+				return nil, errors.New("WrapResponse received non-nil error type with nil value for AdminService_UpdateTaskListVersionSets_Result.EntityNotExistError")
 			}
-			return &AdminService_GetWorkflowExecutionRawHistory_Result{EntityNotExistError: e}, nil
-		case *shared.ServiceBusyError:
+			return &AdminService_UpdateTaskListVersionSets_Result{EntityNotExistError: e}, nil
+		case *shared.AccessDeniedError:
 			if e == nil {
-				return nil, errors.New("WrapResponse received non-nil error type with nil value for AdminService_GetWorkflowExecutionRawHistory_Result.ServiceBusyError")
+				return nil, errors.New("WrapResponse received non-nil error type with nil value for AdminService_UpdateTaskListVersionSets_Result.AccessDeniedError")
 			}
-			return &AdminService_GetWorkflowExecutionRawHistory_Result{ServiceBusyError: e}, nil
+			return &AdminService_UpdateTaskListVersionSets_Result{AccessDeniedError: e}, nil
 		}
 
 		return nil, err
 	}
-	AdminService_GetWorkflowExecutionRawHistory_Helper.UnwrapResponse = func(result *AdminService_GetWorkflowExecutionRawHistory_Result) (success *GetWorkflowExecutionRawHistoryResponse, err error) {
+	AdminService_UpdateTaskListVersionSets_Helper.UnwrapResponse = func(result *AdminService_UpdateTaskListVersionSets_Result) (err error) {
 		if result.BadRequestError != nil {
 			err = result.BadRequestError
 			return
@@ -2859,37 +20076,26 @@ func init() {
 			err = result.EntityNotExistError
 			return
 		}
-		if result.ServiceBusyError != nil {
-			err = result.ServiceBusyError
-			return
-		}
-
-		if result.Success != nil {
-			success = result.Success
+		if result.AccessDeniedError != nil {
+			err = result.AccessDeniedError
 			return
 		}
-
-		err = errors.New("expected a non-void result")
 		return
 	}
 
 }
 
-// AdminService_GetWorkflowExecutionRawHistory_Result represents the result of a AdminService.GetWorkflowExecutionRawHistory function call.
+// AdminService_UpdateTaskListVersionSets_Result represents the result of a AdminService.UpdateTaskListVersionSets function call.
 //
-// The result of a GetWorkflowExecutionRawHistory execution is sent and received over the wire as this struct.
-//
-// Success is set only if the function did not throw an exception.
-type AdminService_GetWorkflowExecutionRawHistory_Result struct {
-	// Value returned by GetWorkflowExecutionRawHistory after a successful execution.
-	Success              *GetWorkflowExecutionRawHistoryResponse `json:"success,omitempty"`
-	BadRequestError      *shared.BadRequestError                 `json:"badRequestError,omitempty"`
-	InternalServiceError *shared.InternalServiceError            `json:"internalServiceError,omitempty"`
-	EntityNotExistError  *shared.EntityNotExistsError            `json:"entityNotExistError,omitempty"`
-	ServiceBusyError     *shared.ServiceBusyError                `json:"serviceBusyError,omitempty"`
+// The result of a UpdateTaskListVersionSets execution is sent and received over the wire as this struct.
+type AdminService_UpdateTaskListVersionSets_Result struct {
+	BadRequestError      *shared.BadRequestError      `json:"badRequestError,omitempty"`
+	InternalServiceError *shared.InternalServiceError `json:"internalServiceError,omitempty"`
+	EntityNotExistError  *shared.EntityNotExistsError `json:"entityNotExistError,omitempty"`
+	AccessDeniedError    *shared.AccessDeniedError    `json:"accessDeniedError,omitempty"`
 }
 
-// ToWire translates a AdminService_GetWorkflowExecutionRawHistory_Result struct into a Thrift-level intermediate
+// ToWire translates a AdminService_UpdateTaskListVersionSets_Result struct into a Thrift-level intermediate
 // representation. This intermediate representation may be serialized
 // into bytes using a ThriftRW protocol implementation.
 //
@@ -2904,22 +20110,14 @@ type AdminService_GetWorkflowExecutionRawHistory_Result struct {
 //   if err := binaryProtocol.Encode(x, writer); err != nil {
 //     return err
 //   }
-func (v *AdminService_GetWorkflowExecutionRawHistory_Result) ToWire() (wire.Value, error) {
+func (v *AdminService_UpdateTaskListVersionSets_Result) ToWire() (wire.Value, error) {
 	var (
-		fields [5]wire.Field
+		fields [4]wire.Field
 		i      int = 0
 		w      wire.Value
 		err    error
 	)
 
-	if v.Success != nil {
-		w, err = v.Success.ToWire()
-		if err != nil {
-			return w, err
-		}
-		fields[i] = wire.Field{ID: 0, Value: w}
-		i++
-	}
 	if v.BadRequestError != nil {
 		w, err = v.BadRequestError.ToWire()
 		if err != nil {
@@ -2944,8 +20142,8 @@ func (v *AdminService_GetWorkflowExecutionRawHistory_Result) ToWire() (wire.Valu
 		fields[i] = wire.Field{ID: 3, Value: w}
 		i++
 	}
-	if v.ServiceBusyError != nil {
-		w, err = v.ServiceBusyError.ToWire()
+	if v.AccessDeniedError != nil {
+		w, err = v.AccessDeniedError.ToWire()
 		if err != nil {
 			return w, err
 		}
@@ -2953,30 +20151,18 @@ func (v *AdminService_GetWorkflowExecutionRawHistory_Result) ToWire() (wire.Valu
 		i++
 	}
 
-	if i != 1 {
-		return wire.Value{}, fmt.Errorf("AdminService_GetWorkflowExecutionRawHistory_Result should have exactly one field: got %v fields", i)
+	if i > 1 {
+		return wire.Value{}, fmt.Errorf("AdminService_UpdateTaskListVersionSets_Result should have at most one field: got %v fields", i)
 	}
 
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
 
-func _GetWorkflowExecutionRawHistoryResponse_Read(w wire.Value) (*GetWorkflowExecutionRawHistoryResponse, error) {
-	var v GetWorkflowExecutionRawHistoryResponse
-	err := v.FromWire(w)
-	return &v, err
-}
-
-func _ServiceBusyError_Read(w wire.Value) (*shared.ServiceBusyError, error) {
-	var v shared.ServiceBusyError
-	err := v.FromWire(w)
-	return &v, err
-}
-
-// FromWire deserializes a AdminService_GetWorkflowExecutionRawHistory_Result struct from its Thrift-level
+// FromWire deserializes a AdminService_UpdateTaskListVersionSets_Result struct from its Thrift-level
 // representation. The Thrift-level representation may be obtained
 // from a ThriftRW protocol implementation.
 //
-// An error is returned if we were unable to build a AdminService_GetWorkflowExecutionRawHistory_Result struct
+// An error is returned if we were unable to build a AdminService_UpdateTaskListVersionSets_Result struct
 // from the provided intermediate representation.
 //
 //   x, err := binaryProtocol.Decode(reader, wire.TStruct)
@@ -2984,24 +20170,16 @@ func _ServiceBusyError_Read(w wire.Value) (*shared.ServiceBusyError, error) {
 //     return nil, err
 //   }
 //
-//   var v AdminService_GetWorkflowExecutionRawHistory_Result
+//   var v AdminService_UpdateTaskListVersionSets_Result
 //   if err := v.FromWire(x); err != nil {
 //     return nil, err
 //   }
 //   return &v, nil
-func (v *AdminService_GetWorkflowExecutionRawHistory_Result) FromWire(w wire.Value) error {
+func (v *AdminService_UpdateTaskListVersionSets_Result) FromWire(w wire.Value) error {
 	var err error
 
 	for _, field := range w.GetStruct().Fields {
 		switch field.ID {
-		case 0:
-			if field.Value.Type() == wire.TStruct {
-				v.Success, err = _GetWorkflowExecutionRawHistoryResponse_Read(field.Value)
-				if err != nil {
-					return err
-				}
-
-			}
 		case 1:
 			if field.Value.Type() == wire.TStruct {
 				v.BadRequestError, err = _BadRequestError_Read(field.Value)
@@ -3028,7 +20206,7 @@ func (v *AdminService_GetWorkflowExecutionRawHistory_Result) FromWire(w wire.Val
 			}
 		case 4:
 			if field.Value.Type() == wire.TStruct {
-				v.ServiceBusyError, err = _ServiceBusyError_Read(field.Value)
+				v.AccessDeniedError, err = _AccessDeniedError_Read(field.Value)
 				if err != nil {
 					return err
 				}
@@ -3038,9 +20216,6 @@ func (v *AdminService_GetWorkflowExecutionRawHistory_Result) FromWire(w wire.Val
 	}
 
 	count := 0
-	if v.Success != nil {
-		count++
-	}
 	if v.BadRequestError != nil {
 		count++
 	}
@@ -3050,29 +20225,25 @@ func (v *AdminService_GetWorkflowExecutionRawHistory_Result) FromWire(w wire.Val
 	if v.EntityNotExistError != nil {
 		count++
 	}
-	if v.ServiceBusyError != nil {
+	if v.AccessDeniedError != nil {
 		count++
 	}
-	if count != 1 {
-		return fmt.Errorf("AdminService_GetWorkflowExecutionRawHistory_Result should have exactly one field: got %v fields", count)
+	if count > 1 {
+		return fmt.Errorf("AdminService_UpdateTaskListVersionSets_Result should have at most one field: got %v fields", count)
 	}
 
 	return nil
 }
 
-// String returns a readable string representation of a AdminService_GetWorkflowExecutionRawHistory_Result
+// String returns a readable string representation of a AdminService_UpdateTaskListVersionSets_Result
 // struct.
-func (v *AdminService_GetWorkflowExecutionRawHistory_Result) String() string {
+func (v *AdminService_UpdateTaskListVersionSets_Result) String() string {
 	if v == nil {
 		return "<nil>"
 	}
 
-	var fields [5]string
+	var fields [4]string
 	i := 0
-	if v.Success != nil {
-		fields[i] = fmt.Sprintf("Success: %v", v.Success)
-		i++
-	}
 	if v.BadRequestError != nil {
 		fields[i] = fmt.Sprintf("BadRequestError: %v", v.BadRequestError)
 		i++
@@ -3085,27 +20256,24 @@ func (v *AdminService_GetWorkflowExecutionRawHistory_Result) String() string {
 		fields[i] = fmt.Sprintf("EntityNotExistError: %v", v.EntityNotExistError)
 		i++
 	}
-	if v.ServiceBusyError != nil {
-		fields[i] = fmt.Sprintf("ServiceBusyError: %v", v.ServiceBusyError)
+	if v.AccessDeniedError != nil {
+		fields[i] = fmt.Sprintf("AccessDeniedError: %v", v.AccessDeniedError)
 		i++
 	}
 
-	return fmt.Sprintf("AdminService_GetWorkflowExecutionRawHistory_Result{%v}", strings.Join(fields[:i], ", "))
+	return fmt.Sprintf("AdminService_UpdateTaskListVersionSets_Result{%v}", strings.Join(fields[:i], ", "))
 }
 
-// Equals returns true if all the fields of this AdminService_GetWorkflowExecutionRawHistory_Result match the
-// provided AdminService_GetWorkflowExecutionRawHistory_Result.
+// Equals returns true if all the fields of this AdminService_UpdateTaskListVersionSets_Result match the
+// provided AdminService_UpdateTaskListVersionSets_Result.
 //
 // This function performs a deep comparison.
-func (v *AdminService_GetWorkflowExecutionRawHistory_Result) Equals(rhs *AdminService_GetWorkflowExecutionRawHistory_Result) bool {
+func (v *AdminService_UpdateTaskListVersionSets_Result) Equals(rhs *AdminService_UpdateTaskListVersionSets_Result) bool {
 	if v == nil {
 		return rhs == nil
 	} else if rhs == nil {
 		return false
 	}
-	if !((v.Success == nil && rhs.Success == nil) || (v.Success != nil && rhs.Success != nil && v.Success.Equals(rhs.Success))) {
-		return false
-	}
 	if !((v.BadRequestError == nil && rhs.BadRequestError == nil) || (v.BadRequestError != nil && rhs.BadRequestError != nil && v.BadRequestError.Equals(rhs.BadRequestError))) {
 		return false
 	}
@@ -3115,7 +20283,7 @@ func (v *AdminService_GetWorkflowExecutionRawHistory_Result) Equals(rhs *AdminSe
 	if !((v.EntityNotExistError == nil && rhs.EntityNotExistError == nil) || (v.EntityNotExistError != nil && rhs.EntityNotExistError != nil && v.EntityNotExistError.Equals(rhs.EntityNotExistError))) {
 		return false
 	}
-	if !((v.ServiceBusyError == nil && rhs.ServiceBusyError == nil) || (v.ServiceBusyError != nil && rhs.ServiceBusyError != nil && v.ServiceBusyError.Equals(rhs.ServiceBusyError))) {
+	if !((v.AccessDeniedError == nil && rhs.AccessDeniedError == nil) || (v.AccessDeniedError != nil && rhs.AccessDeniedError != nil && v.AccessDeniedError.Equals(rhs.AccessDeniedError))) {
 		return false
 	}
 
@@ -3123,14 +20291,11 @@ func (v *AdminService_GetWorkflowExecutionRawHistory_Result) Equals(rhs *AdminSe
 }
 
 // MarshalLogObject implements zapcore.ObjectMarshaler, enabling
-// fast logging of AdminService_GetWorkflowExecutionRawHistory_Result.
-func (v *AdminService_GetWorkflowExecutionRawHistory_Result) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+// fast logging of AdminService_UpdateTaskListVersionSets_Result.
+func (v *AdminService_UpdateTaskListVersionSets_Result) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
 	if v == nil {
 		return nil
 	}
-	if v.Success != nil {
-		err = multierr.Append(err, enc.AddObject("success", v.Success))
-	}
 	if v.BadRequestError != nil {
 		err = multierr.Append(err, enc.AddObject("badRequestError", v.BadRequestError))
 	}
@@ -3140,30 +20305,15 @@ func (v *AdminService_GetWorkflowExecutionRawHistory_Result) MarshalLogObject(en
 	if v.EntityNotExistError != nil {
 		err = multierr.Append(err, enc.AddObject("entityNotExistError", v.EntityNotExistError))
 	}
-	if v.ServiceBusyError != nil {
-		err = multierr.Append(err, enc.AddObject("serviceBusyError", v.ServiceBusyError))
+	if v.AccessDeniedError != nil {
+		err = multierr.Append(err, enc.AddObject("accessDeniedError", v.AccessDeniedError))
 	}
 	return err
 }
 
-// GetSuccess returns the value of Success if it is set or its
-// zero value if it is unset.
-func (v *AdminService_GetWorkflowExecutionRawHistory_Result) GetSuccess() (o *GetWorkflowExecutionRawHistoryResponse) {
-	if v != nil && v.Success != nil {
-		return v.Success
-	}
-
-	return
-}
-
-// IsSetSuccess returns true if Success is not nil.
-func (v *AdminService_GetWorkflowExecutionRawHistory_Result) IsSetSuccess() bool {
-	return v != nil && v.Success != nil
-}
-
 // GetBadRequestError returns the value of BadRequestError if it is set or its
 // zero value if it is unset.
-func (v *AdminService_GetWorkflowExecutionRawHistory_Result) GetBadRequestError() (o *shared.BadRequestError) {
+func (v *AdminService_UpdateTaskListVersionSets_Result) GetBadRequestError() (o *shared.BadRequestError) {
 	if v != nil && v.BadRequestError != nil {
 		return v.BadRequestError
 	}
@@ -3172,13 +20322,13 @@ func (v *AdminService_GetWorkflowExecutionRawHistory_Result) GetBadRequestError(
 }
 
 // IsSetBadRequestError returns true if BadRequestError is not nil.
-func (v *AdminService_GetWorkflowExecutionRawHistory_Result) IsSetBadRequestError() bool {
+func (v *AdminService_UpdateTaskListVersionSets_Result) IsSetBadRequestError() bool {
 	return v != nil && v.BadRequestError != nil
 }
 
 // GetInternalServiceError returns the value of InternalServiceError if it is set or its
 // zero value if it is unset.
-func (v *AdminService_GetWorkflowExecutionRawHistory_Result) GetInternalServiceError() (o *shared.InternalServiceError) {
+func (v *AdminService_UpdateTaskListVersionSets_Result) GetInternalServiceError() (o *shared.InternalServiceError) {
 	if v != nil && v.InternalServiceError != nil {
 		return v.InternalServiceError
 	}
@@ -3187,13 +20337,13 @@ func (v *AdminService_GetWorkflowExecutionRawHistory_Result) GetInternalServiceE
 }
 
 // IsSetInternalServiceError returns true if InternalServiceError is not nil.
-func (v *AdminService_GetWorkflowExecutionRawHistory_Result) IsSetInternalServiceError() bool {
+func (v *AdminService_UpdateTaskListVersionSets_Result) IsSetInternalServiceError() bool {
 	return v != nil && v.InternalServiceError != nil
 }
 
 // GetEntityNotExistError returns the value of EntityNotExistError if it is set or its
 // zero value if it is unset.
-func (v *AdminService_GetWorkflowExecutionRawHistory_Result) GetEntityNotExistError() (o *shared.EntityNotExistsError) {
+func (v *AdminService_UpdateTaskListVersionSets_Result) GetEntityNotExistError() (o *shared.EntityNotExistsError) {
 	if v != nil && v.EntityNotExistError != nil {
 		return v.EntityNotExistError
 	}
@@ -3202,36 +20352,36 @@ func (v *AdminService_GetWorkflowExecutionRawHistory_Result) GetEntityNotExistEr
 }
 
 // IsSetEntityNotExistError returns true if EntityNotExistError is not nil.
-func (v *AdminService_GetWorkflowExecutionRawHistory_Result) IsSetEntityNotExistError() bool {
+func (v *AdminService_UpdateTaskListVersionSets_Result) IsSetEntityNotExistError() bool {
 	return v != nil && v.EntityNotExistError != nil
 }
 
-// GetServiceBusyError returns the value of ServiceBusyError if it is set or its
+// GetAccessDeniedError returns the value of AccessDeniedError if it is set or its
 // zero value if it is unset.
-func (v *AdminService_GetWorkflowExecutionRawHistory_Result) GetServiceBusyError() (o *shared.ServiceBusyError) {
-	if v != nil && v.ServiceBusyError != nil {
-		return v.ServiceBusyError
+func (v *AdminService_UpdateTaskListVersionSets_Result) GetAccessDeniedError() (o *shared.AccessDeniedError) {
+	if v != nil && v.AccessDeniedError != nil {
+		return v.AccessDeniedError
 	}
 
 	return
 }
 
-// IsSetServiceBusyError returns true if ServiceBusyError is not nil.
-func (v *AdminService_GetWorkflowExecutionRawHistory_Result) IsSetServiceBusyError() bool {
-	return v != nil && v.ServiceBusyError != nil
+// IsSetAccessDeniedError returns true if AccessDeniedError is not nil.
+func (v *AdminService_UpdateTaskListVersionSets_Result) IsSetAccessDeniedError() bool {
+	return v != nil && v.AccessDeniedError != nil
 }
 
 // MethodName returns the name of the Thrift function as specified in
 // the IDL, for which this struct represent the result.
 //
-// This will always be "GetWorkflowExecutionRawHistory" for this struct.
-func (v *AdminService_GetWorkflowExecutionRawHistory_Result) MethodName() string {
-	return "GetWorkflowExecutionRawHistory"
+// This will always be "UpdateTaskListVersionSets" for this struct.
+func (v *AdminService_UpdateTaskListVersionSets_Result) MethodName() string {
+	return "UpdateTaskListVersionSets"
 }
 
 // EnvelopeType returns the kind of value inside this struct.
 //
 // This will always be Reply for this struct.
-func (v *AdminService_GetWorkflowExecutionRawHistory_Result) EnvelopeType() wire.EnvelopeType {
+func (v *AdminService_UpdateTaskListVersionSets_Result) EnvelopeType() wire.EnvelopeType {
 	return wire.Reply
 }
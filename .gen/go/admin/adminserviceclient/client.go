@@ -36,23 +36,155 @@ import (
 
 // Interface is a client for the AdminService service.
 type Interface interface {
+	BackfillSchedule(
+		ctx context.Context,
+		Request *shared.BackfillScheduleRequest,
+		opts ...yarpc.CallOption,
+	) error
+
+	CreateSchedule(
+		ctx context.Context,
+		Request *shared.CreateScheduleRequest,
+		opts ...yarpc.CallOption,
+	) error
+
+	DeleteSchedule(
+		ctx context.Context,
+		Request *shared.DeleteScheduleRequest,
+		opts ...yarpc.CallOption,
+	) error
+
+	DescribeClientVersions(
+		ctx context.Context,
+		Request *shared.DescribeClientVersionsRequest,
+		opts ...yarpc.CallOption,
+	) (*shared.DescribeClientVersionsResponse, error)
+
 	DescribeHistoryHost(
 		ctx context.Context,
 		Request *shared.DescribeHistoryHostRequest,
 		opts ...yarpc.CallOption,
 	) (*shared.DescribeHistoryHostResponse, error)
 
+	DescribeSchedule(
+		ctx context.Context,
+		Request *shared.DescribeScheduleRequest,
+		opts ...yarpc.CallOption,
+	) (*shared.DescribeScheduleResponse, error)
+
+	DescribeTaskJourney(
+		ctx context.Context,
+		Request *shared.DescribeTaskJourneyRequest,
+		opts ...yarpc.CallOption,
+	) (*shared.DescribeTaskJourneyResponse, error)
+
 	DescribeWorkflowExecution(
 		ctx context.Context,
 		Request *admin.DescribeWorkflowExecutionRequest,
 		opts ...yarpc.CallOption,
 	) (*admin.DescribeWorkflowExecutionResponse, error)
 
+	DescribeWorkflowShard(
+		ctx context.Context,
+		Request *admin.DescribeWorkflowShardRequest,
+		opts ...yarpc.CallOption,
+	) (*admin.DescribeWorkflowShardResponse, error)
+
+	DrainHistoryHost(
+		ctx context.Context,
+		Request *shared.DrainHistoryHostRequest,
+		opts ...yarpc.CallOption,
+	) (*shared.DrainHistoryHostResponse, error)
+
+	GetDomainOpenExecutionCount(
+		ctx context.Context,
+		Request *shared.GetDomainOpenExecutionCountRequest,
+		opts ...yarpc.CallOption,
+	) (*shared.GetDomainOpenExecutionCountResponse, error)
+
 	GetWorkflowExecutionRawHistory(
 		ctx context.Context,
 		GetRequest *admin.GetWorkflowExecutionRawHistoryRequest,
 		opts ...yarpc.CallOption,
 	) (*admin.GetWorkflowExecutionRawHistoryResponse, error)
+
+	ImportWorkflowExecution(
+		ctx context.Context,
+		Request *admin.ImportWorkflowExecutionRequest,
+		opts ...yarpc.CallOption,
+	) (*admin.ImportWorkflowExecutionResponse, error)
+
+	ListRecentlyClosedExecutions(
+		ctx context.Context,
+		Request *admin.ListRecentlyClosedExecutionsRequest,
+		opts ...yarpc.CallOption,
+	) (*admin.ListRecentlyClosedExecutionsResponse, error)
+
+	ListSchedules(
+		ctx context.Context,
+		Request *shared.ListSchedulesRequest,
+		opts ...yarpc.CallOption,
+	) (*shared.ListSchedulesResponse, error)
+
+	ListWorkflowExecutionRuns(
+		ctx context.Context,
+		Request *admin.ListWorkflowExecutionRunsRequest,
+		opts ...yarpc.CallOption,
+	) (*admin.ListWorkflowExecutionRunsResponse, error)
+
+	PurgeWorkflowExecution(
+		ctx context.Context,
+		Request *admin.PurgeWorkflowExecutionRequest,
+		opts ...yarpc.CallOption,
+	) (*admin.PurgeWorkflowExecutionResponse, error)
+
+	SetActivityPaused(
+		ctx context.Context,
+		Request *shared.SetActivityPausedRequest,
+		opts ...yarpc.CallOption,
+	) error
+
+	SetCronSchedulePaused(
+		ctx context.Context,
+		Request *shared.SetCronSchedulePausedRequest,
+		opts ...yarpc.CallOption,
+	) error
+
+	SetSchedulePaused(
+		ctx context.Context,
+		Request *shared.SetSchedulePausedRequest,
+		opts ...yarpc.CallOption,
+	) error
+
+	SetWorkflowExecutionNotes(
+		ctx context.Context,
+		Request *shared.SetWorkflowExecutionNotesRequest,
+		opts ...yarpc.CallOption,
+	) error
+
+	SetWorkflowExecutionPaused(
+		ctx context.Context,
+		Request *shared.SetWorkflowExecutionPausedRequest,
+		opts ...yarpc.CallOption,
+	) error
+
+	TerminateAllWorkflowRuns(
+		ctx context.Context,
+		Request *shared.TerminateAllWorkflowRunsRequest,
+		opts ...yarpc.CallOption,
+	) (*shared.TerminateAllWorkflowRunsResponse, error)
+
+	UndrainHistoryHost(
+		ctx context.Context,
+		Request *shared.UndrainHistoryHostRequest,
+		opts ...yarpc.CallOption,
+	) (*shared.UndrainHistoryHostResponse, error)
+
+	UpdateTaskListVersionSets(
+		ctx context.Context,
+		Request *shared.UpdateTaskListVersionSetsRequest,
+		opts ...yarpc.CallOption,
+	) error
 }
 
 // New builds a new client for the AdminService service.
@@ -79,6 +211,98 @@ type client struct {
 	c thrift.Client
 }
 
+func (c client) BackfillSchedule(
+	ctx context.Context,
+	_Request *shared.BackfillScheduleRequest,
+	opts ...yarpc.CallOption,
+) (err error) {
+
+	args := admin.AdminService_BackfillSchedule_Helper.Args(_Request)
+
+	var body wire.Value
+	body, err = c.c.Call(ctx, args, opts...)
+	if err != nil {
+		return
+	}
+
+	var result admin.AdminService_BackfillSchedule_Result
+	if err = result.FromWire(body); err != nil {
+		return
+	}
+
+	err = admin.AdminService_BackfillSchedule_Helper.UnwrapResponse(&result)
+	return
+}
+
+func (c client) CreateSchedule(
+	ctx context.Context,
+	_Request *shared.CreateScheduleRequest,
+	opts ...yarpc.CallOption,
+) (err error) {
+
+	args := admin.AdminService_CreateSchedule_Helper.Args(_Request)
+
+	var body wire.Value
+	body, err = c.c.Call(ctx, args, opts...)
+	if err != nil {
+		return
+	}
+
+	var result admin.AdminService_CreateSchedule_Result
+	if err = result.FromWire(body); err != nil {
+		return
+	}
+
+	err = admin.AdminService_CreateSchedule_Helper.UnwrapResponse(&result)
+	return
+}
+
+func (c client) DeleteSchedule(
+	ctx context.Context,
+	_Request *shared.DeleteScheduleRequest,
+	opts ...yarpc.CallOption,
+) (err error) {
+
+	args := admin.AdminService_DeleteSchedule_Helper.Args(_Request)
+
+	var body wire.Value
+	body, err = c.c.Call(ctx, args, opts...)
+	if err != nil {
+		return
+	}
+
+	var result admin.AdminService_DeleteSchedule_Result
+	if err = result.FromWire(body); err != nil {
+		return
+	}
+
+	err = admin.AdminService_DeleteSchedule_Helper.UnwrapResponse(&result)
+	return
+}
+
+func (c client) DescribeClientVersions(
+	ctx context.Context,
+	_Request *shared.DescribeClientVersionsRequest,
+	opts ...yarpc.CallOption,
+) (success *shared.DescribeClientVersionsResponse, err error) {
+
+	args := admin.AdminService_DescribeClientVersions_Helper.Args(_Request)
+
+	var body wire.Value
+	body, err = c.c.Call(ctx, args, opts...)
+	if err != nil {
+		return
+	}
+
+	var result admin.AdminService_DescribeClientVersions_Result
+	if err = result.FromWire(body); err != nil {
+		return
+	}
+
+	success, err = admin.AdminService_DescribeClientVersions_Helper.UnwrapResponse(&result)
+	return
+}
+
 func (c client) DescribeHistoryHost(
 	ctx context.Context,
 	_Request *shared.DescribeHistoryHostRequest,
@@ -102,6 +326,52 @@ func (c client) DescribeHistoryHost(
 	return
 }
 
+func (c client) DescribeSchedule(
+	ctx context.Context,
+	_Request *shared.DescribeScheduleRequest,
+	opts ...yarpc.CallOption,
+) (success *shared.DescribeScheduleResponse, err error) {
+
+	args := admin.AdminService_DescribeSchedule_Helper.Args(_Request)
+
+	var body wire.Value
+	body, err = c.c.Call(ctx, args, opts...)
+	if err != nil {
+		return
+	}
+
+	var result admin.AdminService_DescribeSchedule_Result
+	if err = result.FromWire(body); err != nil {
+		return
+	}
+
+	success, err = admin.AdminService_DescribeSchedule_Helper.UnwrapResponse(&result)
+	return
+}
+
+func (c client) DescribeTaskJourney(
+	ctx context.Context,
+	_Request *shared.DescribeTaskJourneyRequest,
+	opts ...yarpc.CallOption,
+) (success *shared.DescribeTaskJourneyResponse, err error) {
+
+	args := admin.AdminService_DescribeTaskJourney_Helper.Args(_Request)
+
+	var body wire.Value
+	body, err = c.c.Call(ctx, args, opts...)
+	if err != nil {
+		return
+	}
+
+	var result admin.AdminService_DescribeTaskJourney_Result
+	if err = result.FromWire(body); err != nil {
+		return
+	}
+
+	success, err = admin.AdminService_DescribeTaskJourney_Helper.UnwrapResponse(&result)
+	return
+}
+
 func (c client) DescribeWorkflowExecution(
 	ctx context.Context,
 	_Request *admin.DescribeWorkflowExecutionRequest,
@@ -125,6 +395,75 @@ func (c client) DescribeWorkflowExecution(
 	return
 }
 
+func (c client) DescribeWorkflowShard(
+	ctx context.Context,
+	_Request *admin.DescribeWorkflowShardRequest,
+	opts ...yarpc.CallOption,
+) (success *admin.DescribeWorkflowShardResponse, err error) {
+
+	args := admin.AdminService_DescribeWorkflowShard_Helper.Args(_Request)
+
+	var body wire.Value
+	body, err = c.c.Call(ctx, args, opts...)
+	if err != nil {
+		return
+	}
+
+	var result admin.AdminService_DescribeWorkflowShard_Result
+	if err = result.FromWire(body); err != nil {
+		return
+	}
+
+	success, err = admin.AdminService_DescribeWorkflowShard_Helper.UnwrapResponse(&result)
+	return
+}
+
+func (c client) DrainHistoryHost(
+	ctx context.Context,
+	_Request *shared.DrainHistoryHostRequest,
+	opts ...yarpc.CallOption,
+) (success *shared.DrainHistoryHostResponse, err error) {
+
+	args := admin.AdminService_DrainHistoryHost_Helper.Args(_Request)
+
+	var body wire.Value
+	body, err = c.c.Call(ctx, args, opts...)
+	if err != nil {
+		return
+	}
+
+	var result admin.AdminService_DrainHistoryHost_Result
+	if err = result.FromWire(body); err != nil {
+		return
+	}
+
+	success, err = admin.AdminService_DrainHistoryHost_Helper.UnwrapResponse(&result)
+	return
+}
+
+func (c client) GetDomainOpenExecutionCount(
+	ctx context.Context,
+	_Request *shared.GetDomainOpenExecutionCountRequest,
+	opts ...yarpc.CallOption,
+) (success *shared.GetDomainOpenExecutionCountResponse, err error) {
+
+	args := admin.AdminService_GetDomainOpenExecutionCount_Helper.Args(_Request)
+
+	var body wire.Value
+	body, err = c.c.Call(ctx, args, opts...)
+	if err != nil {
+		return
+	}
+
+	var result admin.AdminService_GetDomainOpenExecutionCount_Result
+	if err = result.FromWire(body); err != nil {
+		return
+	}
+
+	success, err = admin.AdminService_GetDomainOpenExecutionCount_Helper.UnwrapResponse(&result)
+	return
+}
+
 func (c client) GetWorkflowExecutionRawHistory(
 	ctx context.Context,
 	_GetRequest *admin.GetWorkflowExecutionRawHistoryRequest,
@@ -147,3 +486,302 @@ func (c client) GetWorkflowExecutionRawHistory(
 	success, err = admin.AdminService_GetWorkflowExecutionRawHistory_Helper.UnwrapResponse(&result)
 	return
 }
+
+func (c client) ImportWorkflowExecution(
+	ctx context.Context,
+	_Request *admin.ImportWorkflowExecutionRequest,
+	opts ...yarpc.CallOption,
+) (success *admin.ImportWorkflowExecutionResponse, err error) {
+
+	args := admin.AdminService_ImportWorkflowExecution_Helper.Args(_Request)
+
+	var body wire.Value
+	body, err = c.c.Call(ctx, args, opts...)
+	if err != nil {
+		return
+	}
+
+	var result admin.AdminService_ImportWorkflowExecution_Result
+	if err = result.FromWire(body); err != nil {
+		return
+	}
+
+	success, err = admin.AdminService_ImportWorkflowExecution_Helper.UnwrapResponse(&result)
+	return
+}
+
+func (c client) ListRecentlyClosedExecutions(
+	ctx context.Context,
+	_Request *admin.ListRecentlyClosedExecutionsRequest,
+	opts ...yarpc.CallOption,
+) (success *admin.ListRecentlyClosedExecutionsResponse, err error) {
+
+	args := admin.AdminService_ListRecentlyClosedExecutions_Helper.Args(_Request)
+
+	var body wire.Value
+	body, err = c.c.Call(ctx, args, opts...)
+	if err != nil {
+		return
+	}
+
+	var result admin.AdminService_ListRecentlyClosedExecutions_Result
+	if err = result.FromWire(body); err != nil {
+		return
+	}
+
+	success, err = admin.AdminService_ListRecentlyClosedExecutions_Helper.UnwrapResponse(&result)
+	return
+}
+
+func (c client) ListSchedules(
+	ctx context.Context,
+	_Request *shared.ListSchedulesRequest,
+	opts ...yarpc.CallOption,
+) (success *shared.ListSchedulesResponse, err error) {
+
+	args := admin.AdminService_ListSchedules_Helper.Args(_Request)
+
+	var body wire.Value
+	body, err = c.c.Call(ctx, args, opts...)
+	if err != nil {
+		return
+	}
+
+	var result admin.AdminService_ListSchedules_Result
+	if err = result.FromWire(body); err != nil {
+		return
+	}
+
+	success, err = admin.AdminService_ListSchedules_Helper.UnwrapResponse(&result)
+	return
+}
+
+func (c client) ListWorkflowExecutionRuns(
+	ctx context.Context,
+	_Request *admin.ListWorkflowExecutionRunsRequest,
+	opts ...yarpc.CallOption,
+) (success *admin.ListWorkflowExecutionRunsResponse, err error) {
+
+	args := admin.AdminService_ListWorkflowExecutionRuns_Helper.Args(_Request)
+
+	var body wire.Value
+	body, err = c.c.Call(ctx, args, opts...)
+	if err != nil {
+		return
+	}
+
+	var result admin.AdminService_ListWorkflowExecutionRuns_Result
+	if err = result.FromWire(body); err != nil {
+		return
+	}
+
+	success, err = admin.AdminService_ListWorkflowExecutionRuns_Helper.UnwrapResponse(&result)
+	return
+}
+
+func (c client) PurgeWorkflowExecution(
+	ctx context.Context,
+	_Request *admin.PurgeWorkflowExecutionRequest,
+	opts ...yarpc.CallOption,
+) (success *admin.PurgeWorkflowExecutionResponse, err error) {
+
+	args := admin.AdminService_PurgeWorkflowExecution_Helper.Args(_Request)
+
+	var body wire.Value
+	body, err = c.c.Call(ctx, args, opts...)
+	if err != nil {
+		return
+	}
+
+	var result admin.AdminService_PurgeWorkflowExecution_Result
+	if err = result.FromWire(body); err != nil {
+		return
+	}
+
+	success, err = admin.AdminService_PurgeWorkflowExecution_Helper.UnwrapResponse(&result)
+	return
+}
+
+func (c client) SetActivityPaused(
+	ctx context.Context,
+	_Request *shared.SetActivityPausedRequest,
+	opts ...yarpc.CallOption,
+) (err error) {
+
+	args := admin.AdminService_SetActivityPaused_Helper.Args(_Request)
+
+	var body wire.Value
+	body, err = c.c.Call(ctx, args, opts...)
+	if err != nil {
+		return
+	}
+
+	var result admin.AdminService_SetActivityPaused_Result
+	if err = result.FromWire(body); err != nil {
+		return
+	}
+
+	err = admin.AdminService_SetActivityPaused_Helper.UnwrapResponse(&result)
+	return
+}
+
+func (c client) SetCronSchedulePaused(
+	ctx context.Context,
+	_Request *shared.SetCronSchedulePausedRequest,
+	opts ...yarpc.CallOption,
+) (err error) {
+
+	args := admin.AdminService_SetCronSchedulePaused_Helper.Args(_Request)
+
+	var body wire.Value
+	body, err = c.c.Call(ctx, args, opts...)
+	if err != nil {
+		return
+	}
+
+	var result admin.AdminService_SetCronSchedulePaused_Result
+	if err = result.FromWire(body); err != nil {
+		return
+	}
+
+	err = admin.AdminService_SetCronSchedulePaused_Helper.UnwrapResponse(&result)
+	return
+}
+
+func (c client) SetSchedulePaused(
+	ctx context.Context,
+	_Request *shared.SetSchedulePausedRequest,
+	opts ...yarpc.CallOption,
+) (err error) {
+
+	args := admin.AdminService_SetSchedulePaused_Helper.Args(_Request)
+
+	var body wire.Value
+	body, err = c.c.Call(ctx, args, opts...)
+	if err != nil {
+		return
+	}
+
+	var result admin.AdminService_SetSchedulePaused_Result
+	if err = result.FromWire(body); err != nil {
+		return
+	}
+
+	err = admin.AdminService_SetSchedulePaused_Helper.UnwrapResponse(&result)
+	return
+}
+
+func (c client) SetWorkflowExecutionNotes(
+	ctx context.Context,
+	_Request *shared.SetWorkflowExecutionNotesRequest,
+	opts ...yarpc.CallOption,
+) (err error) {
+
+	args := admin.AdminService_SetWorkflowExecutionNotes_Helper.Args(_Request)
+
+	var body wire.Value
+	body, err = c.c.Call(ctx, args, opts...)
+	if err != nil {
+		return
+	}
+
+	var result admin.AdminService_SetWorkflowExecutionNotes_Result
+	if err = result.FromWire(body); err != nil {
+		return
+	}
+
+	err = admin.AdminService_SetWorkflowExecutionNotes_Helper.UnwrapResponse(&result)
+	return
+}
+
+func (c client) SetWorkflowExecutionPaused(
+	ctx context.Context,
+	_Request *shared.SetWorkflowExecutionPausedRequest,
+	opts ...yarpc.CallOption,
+) (err error) {
+
+	args := admin.AdminService_SetWorkflowExecutionPaused_Helper.Args(_Request)
+
+	var body wire.Value
+	body, err = c.c.Call(ctx, args, opts...)
+	if err != nil {
+		return
+	}
+
+	var result admin.AdminService_SetWorkflowExecutionPaused_Result
+	if err = result.FromWire(body); err != nil {
+		return
+	}
+
+	err = admin.AdminService_SetWorkflowExecutionPaused_Helper.UnwrapResponse(&result)
+	return
+}
+
+func (c client) TerminateAllWorkflowRuns(
+	ctx context.Context,
+	_Request *shared.TerminateAllWorkflowRunsRequest,
+	opts ...yarpc.CallOption,
+) (success *shared.TerminateAllWorkflowRunsResponse, err error) {
+
+	args := admin.AdminService_TerminateAllWorkflowRuns_Helper.Args(_Request)
+
+	var body wire.Value
+	body, err = c.c.Call(ctx, args, opts...)
+	if err != nil {
+		return
+	}
+
+	var result admin.AdminService_TerminateAllWorkflowRuns_Result
+	if err = result.FromWire(body); err != nil {
+		return
+	}
+
+	success, err = admin.AdminService_TerminateAllWorkflowRuns_Helper.UnwrapResponse(&result)
+	return
+}
+
+func (c client) UndrainHistoryHost(
+	ctx context.Context,
+	_Request *shared.UndrainHistoryHostRequest,
+	opts ...yarpc.CallOption,
+) (success *shared.UndrainHistoryHostResponse, err error) {
+
+	args := admin.AdminService_UndrainHistoryHost_Helper.Args(_Request)
+
+	var body wire.Value
+	body, err = c.c.Call(ctx, args, opts...)
+	if err != nil {
+		return
+	}
+
+	var result admin.AdminService_UndrainHistoryHost_Result
+	if err = result.FromWire(body); err != nil {
+		return
+	}
+
+	success, err = admin.AdminService_UndrainHistoryHost_Helper.UnwrapResponse(&result)
+	return
+}
+
+func (c client) UpdateTaskListVersionSets(
+	ctx context.Context,
+	_Request *shared.UpdateTaskListVersionSetsRequest,
+	opts ...yarpc.CallOption,
+) (err error) {
+
+	args := admin.AdminService_UpdateTaskListVersionSets_Helper.Args(_Request)
+
+	var body wire.Value
+	body, err = c.c.Call(ctx, args, opts...)
+	if err != nil {
+		return
+	}
+
+	var result admin.AdminService_UpdateTaskListVersionSets_Result
+	if err = result.FromWire(body); err != nil {
+		return
+	}
+
+	err = admin.AdminService_UpdateTaskListVersionSets_Helper.UnwrapResponse(&result)
+	return
+}
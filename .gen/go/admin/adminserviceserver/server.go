@@ -34,20 +34,130 @@ import (
 
 // Interface is the server-side interface for the AdminService service.
 type Interface interface {
+	BackfillSchedule(
+		ctx context.Context,
+		Request *shared.BackfillScheduleRequest,
+	) error
+
+	CreateSchedule(
+		ctx context.Context,
+		Request *shared.CreateScheduleRequest,
+	) error
+
+	DeleteSchedule(
+		ctx context.Context,
+		Request *shared.DeleteScheduleRequest,
+	) error
+
+	DescribeClientVersions(
+		ctx context.Context,
+		Request *shared.DescribeClientVersionsRequest,
+	) (*shared.DescribeClientVersionsResponse, error)
+
 	DescribeHistoryHost(
 		ctx context.Context,
 		Request *shared.DescribeHistoryHostRequest,
 	) (*shared.DescribeHistoryHostResponse, error)
 
+	DescribeSchedule(
+		ctx context.Context,
+		Request *shared.DescribeScheduleRequest,
+	) (*shared.DescribeScheduleResponse, error)
+
+	DescribeTaskJourney(
+		ctx context.Context,
+		Request *shared.DescribeTaskJourneyRequest,
+	) (*shared.DescribeTaskJourneyResponse, error)
+
 	DescribeWorkflowExecution(
 		ctx context.Context,
 		Request *admin.DescribeWorkflowExecutionRequest,
 	) (*admin.DescribeWorkflowExecutionResponse, error)
 
+	DescribeWorkflowShard(
+		ctx context.Context,
+		Request *admin.DescribeWorkflowShardRequest,
+	) (*admin.DescribeWorkflowShardResponse, error)
+
+	DrainHistoryHost(
+		ctx context.Context,
+		Request *shared.DrainHistoryHostRequest,
+	) (*shared.DrainHistoryHostResponse, error)
+
+	GetDomainOpenExecutionCount(
+		ctx context.Context,
+		Request *shared.GetDomainOpenExecutionCountRequest,
+	) (*shared.GetDomainOpenExecutionCountResponse, error)
+
 	GetWorkflowExecutionRawHistory(
 		ctx context.Context,
 		GetRequest *admin.GetWorkflowExecutionRawHistoryRequest,
 	) (*admin.GetWorkflowExecutionRawHistoryResponse, error)
+
+	ImportWorkflowExecution(
+		ctx context.Context,
+		Request *admin.ImportWorkflowExecutionRequest,
+	) (*admin.ImportWorkflowExecutionResponse, error)
+
+	ListRecentlyClosedExecutions(
+		ctx context.Context,
+		Request *admin.ListRecentlyClosedExecutionsRequest,
+	) (*admin.ListRecentlyClosedExecutionsResponse, error)
+
+	ListSchedules(
+		ctx context.Context,
+		Request *shared.ListSchedulesRequest,
+	) (*shared.ListSchedulesResponse, error)
+
+	ListWorkflowExecutionRuns(
+		ctx context.Context,
+		Request *admin.ListWorkflowExecutionRunsRequest,
+	) (*admin.ListWorkflowExecutionRunsResponse, error)
+
+	PurgeWorkflowExecution(
+		ctx context.Context,
+		Request *admin.PurgeWorkflowExecutionRequest,
+	) (*admin.PurgeWorkflowExecutionResponse, error)
+
+	SetActivityPaused(
+		ctx context.Context,
+		Request *shared.SetActivityPausedRequest,
+	) error
+
+	SetCronSchedulePaused(
+		ctx context.Context,
+		Request *shared.SetCronSchedulePausedRequest,
+	) error
+
+	SetSchedulePaused(
+		ctx context.Context,
+		Request *shared.SetSchedulePausedRequest,
+	) error
+
+	SetWorkflowExecutionNotes(
+		ctx context.Context,
+		Request *shared.SetWorkflowExecutionNotesRequest,
+	) error
+
+	SetWorkflowExecutionPaused(
+		ctx context.Context,
+		Request *shared.SetWorkflowExecutionPausedRequest,
+	) error
+
+	TerminateAllWorkflowRuns(
+		ctx context.Context,
+		Request *shared.TerminateAllWorkflowRunsRequest,
+	) (*shared.TerminateAllWorkflowRunsResponse, error)
+
+	UndrainHistoryHost(
+		ctx context.Context,
+		Request *shared.UndrainHistoryHostRequest,
+	) (*shared.UndrainHistoryHostResponse, error)
+
+	UpdateTaskListVersionSets(
+		ctx context.Context,
+		Request *shared.UpdateTaskListVersionSetsRequest,
+	) error
 }
 
 // New prepares an implementation of the AdminService service for
@@ -61,6 +171,50 @@ func New(impl Interface, opts ...thrift.RegisterOption) []transport.Procedure {
 		Name: "AdminService",
 		Methods: []thrift.Method{
 
+			thrift.Method{
+				Name: "BackfillSchedule",
+				HandlerSpec: thrift.HandlerSpec{
+
+					Type:  transport.Unary,
+					Unary: thrift.UnaryHandler(h.BackfillSchedule),
+				},
+				Signature:    "BackfillSchedule(Request *shared.BackfillScheduleRequest)",
+				ThriftModule: admin.ThriftModule,
+			},
+
+			thrift.Method{
+				Name: "CreateSchedule",
+				HandlerSpec: thrift.HandlerSpec{
+
+					Type:  transport.Unary,
+					Unary: thrift.UnaryHandler(h.CreateSchedule),
+				},
+				Signature:    "CreateSchedule(Request *shared.CreateScheduleRequest)",
+				ThriftModule: admin.ThriftModule,
+			},
+
+			thrift.Method{
+				Name: "DeleteSchedule",
+				HandlerSpec: thrift.HandlerSpec{
+
+					Type:  transport.Unary,
+					Unary: thrift.UnaryHandler(h.DeleteSchedule),
+				},
+				Signature:    "DeleteSchedule(Request *shared.DeleteScheduleRequest)",
+				ThriftModule: admin.ThriftModule,
+			},
+
+			thrift.Method{
+				Name: "DescribeClientVersions",
+				HandlerSpec: thrift.HandlerSpec{
+
+					Type:  transport.Unary,
+					Unary: thrift.UnaryHandler(h.DescribeClientVersions),
+				},
+				Signature:    "DescribeClientVersions(Request *shared.DescribeClientVersionsRequest) (*shared.DescribeClientVersionsResponse)",
+				ThriftModule: admin.ThriftModule,
+			},
+
 			thrift.Method{
 				Name: "DescribeHistoryHost",
 				HandlerSpec: thrift.HandlerSpec{
@@ -72,6 +226,28 @@ func New(impl Interface, opts ...thrift.RegisterOption) []transport.Procedure {
 				ThriftModule: admin.ThriftModule,
 			},
 
+			thrift.Method{
+				Name: "DescribeSchedule",
+				HandlerSpec: thrift.HandlerSpec{
+
+					Type:  transport.Unary,
+					Unary: thrift.UnaryHandler(h.DescribeSchedule),
+				},
+				Signature:    "DescribeSchedule(Request *shared.DescribeScheduleRequest) (*shared.DescribeScheduleResponse)",
+				ThriftModule: admin.ThriftModule,
+			},
+
+			thrift.Method{
+				Name: "DescribeTaskJourney",
+				HandlerSpec: thrift.HandlerSpec{
+
+					Type:  transport.Unary,
+					Unary: thrift.UnaryHandler(h.DescribeTaskJourney),
+				},
+				Signature:    "DescribeTaskJourney(Request *shared.DescribeTaskJourneyRequest) (*shared.DescribeTaskJourneyResponse)",
+				ThriftModule: admin.ThriftModule,
+			},
+
 			thrift.Method{
 				Name: "DescribeWorkflowExecution",
 				HandlerSpec: thrift.HandlerSpec{
@@ -83,6 +259,39 @@ func New(impl Interface, opts ...thrift.RegisterOption) []transport.Procedure {
 				ThriftModule: admin.ThriftModule,
 			},
 
+			thrift.Method{
+				Name: "DescribeWorkflowShard",
+				HandlerSpec: thrift.HandlerSpec{
+
+					Type:  transport.Unary,
+					Unary: thrift.UnaryHandler(h.DescribeWorkflowShard),
+				},
+				Signature:    "DescribeWorkflowShard(Request *admin.DescribeWorkflowShardRequest) (*admin.DescribeWorkflowShardResponse)",
+				ThriftModule: admin.ThriftModule,
+			},
+
+			thrift.Method{
+				Name: "DrainHistoryHost",
+				HandlerSpec: thrift.HandlerSpec{
+
+					Type:  transport.Unary,
+					Unary: thrift.UnaryHandler(h.DrainHistoryHost),
+				},
+				Signature:    "DrainHistoryHost(Request *shared.DrainHistoryHostRequest) (*shared.DrainHistoryHostResponse)",
+				ThriftModule: admin.ThriftModule,
+			},
+
+			thrift.Method{
+				Name: "GetDomainOpenExecutionCount",
+				HandlerSpec: thrift.HandlerSpec{
+
+					Type:  transport.Unary,
+					Unary: thrift.UnaryHandler(h.GetDomainOpenExecutionCount),
+				},
+				Signature:    "GetDomainOpenExecutionCount(Request *shared.GetDomainOpenExecutionCountRequest) (*shared.GetDomainOpenExecutionCountResponse)",
+				ThriftModule: admin.ThriftModule,
+			},
+
 			thrift.Method{
 				Name: "GetWorkflowExecutionRawHistory",
 				HandlerSpec: thrift.HandlerSpec{
@@ -93,26 +302,169 @@ func New(impl Interface, opts ...thrift.RegisterOption) []transport.Procedure {
 				Signature:    "GetWorkflowExecutionRawHistory(GetRequest *admin.GetWorkflowExecutionRawHistoryRequest) (*admin.GetWorkflowExecutionRawHistoryResponse)",
 				ThriftModule: admin.ThriftModule,
 			},
+
+			thrift.Method{
+				Name: "ImportWorkflowExecution",
+				HandlerSpec: thrift.HandlerSpec{
+
+					Type:  transport.Unary,
+					Unary: thrift.UnaryHandler(h.ImportWorkflowExecution),
+				},
+				Signature:    "ImportWorkflowExecution(Request *admin.ImportWorkflowExecutionRequest) (*admin.ImportWorkflowExecutionResponse)",
+				ThriftModule: admin.ThriftModule,
+			},
+
+			thrift.Method{
+				Name: "ListRecentlyClosedExecutions",
+				HandlerSpec: thrift.HandlerSpec{
+
+					Type:  transport.Unary,
+					Unary: thrift.UnaryHandler(h.ListRecentlyClosedExecutions),
+				},
+				Signature:    "ListRecentlyClosedExecutions(Request *admin.ListRecentlyClosedExecutionsRequest) (*admin.ListRecentlyClosedExecutionsResponse)",
+				ThriftModule: admin.ThriftModule,
+			},
+
+			thrift.Method{
+				Name: "ListSchedules",
+				HandlerSpec: thrift.HandlerSpec{
+
+					Type:  transport.Unary,
+					Unary: thrift.UnaryHandler(h.ListSchedules),
+				},
+				Signature:    "ListSchedules(Request *shared.ListSchedulesRequest) (*shared.ListSchedulesResponse)",
+				ThriftModule: admin.ThriftModule,
+			},
+
+			thrift.Method{
+				Name: "ListWorkflowExecutionRuns",
+				HandlerSpec: thrift.HandlerSpec{
+
+					Type:  transport.Unary,
+					Unary: thrift.UnaryHandler(h.ListWorkflowExecutionRuns),
+				},
+				Signature:    "ListWorkflowExecutionRuns(Request *admin.ListWorkflowExecutionRunsRequest) (*admin.ListWorkflowExecutionRunsResponse)",
+				ThriftModule: admin.ThriftModule,
+			},
+
+			thrift.Method{
+				Name: "PurgeWorkflowExecution",
+				HandlerSpec: thrift.HandlerSpec{
+
+					Type:  transport.Unary,
+					Unary: thrift.UnaryHandler(h.PurgeWorkflowExecution),
+				},
+				Signature:    "PurgeWorkflowExecution(Request *admin.PurgeWorkflowExecutionRequest) (*admin.PurgeWorkflowExecutionResponse)",
+				ThriftModule: admin.ThriftModule,
+			},
+
+			thrift.Method{
+				Name: "SetActivityPaused",
+				HandlerSpec: thrift.HandlerSpec{
+
+					Type:  transport.Unary,
+					Unary: thrift.UnaryHandler(h.SetActivityPaused),
+				},
+				Signature:    "SetActivityPaused(Request *shared.SetActivityPausedRequest)",
+				ThriftModule: admin.ThriftModule,
+			},
+
+			thrift.Method{
+				Name: "SetCronSchedulePaused",
+				HandlerSpec: thrift.HandlerSpec{
+
+					Type:  transport.Unary,
+					Unary: thrift.UnaryHandler(h.SetCronSchedulePaused),
+				},
+				Signature:    "SetCronSchedulePaused(Request *shared.SetCronSchedulePausedRequest)",
+				ThriftModule: admin.ThriftModule,
+			},
+
+			thrift.Method{
+				Name: "SetSchedulePaused",
+				HandlerSpec: thrift.HandlerSpec{
+
+					Type:  transport.Unary,
+					Unary: thrift.UnaryHandler(h.SetSchedulePaused),
+				},
+				Signature:    "SetSchedulePaused(Request *shared.SetSchedulePausedRequest)",
+				ThriftModule: admin.ThriftModule,
+			},
+
+			thrift.Method{
+				Name: "SetWorkflowExecutionNotes",
+				HandlerSpec: thrift.HandlerSpec{
+
+					Type:  transport.Unary,
+					Unary: thrift.UnaryHandler(h.SetWorkflowExecutionNotes),
+				},
+				Signature:    "SetWorkflowExecutionNotes(Request *shared.SetWorkflowExecutionNotesRequest)",
+				ThriftModule: admin.ThriftModule,
+			},
+
+			thrift.Method{
+				Name: "SetWorkflowExecutionPaused",
+				HandlerSpec: thrift.HandlerSpec{
+
+					Type:  transport.Unary,
+					Unary: thrift.UnaryHandler(h.SetWorkflowExecutionPaused),
+				},
+				Signature:    "SetWorkflowExecutionPaused(Request *shared.SetWorkflowExecutionPausedRequest)",
+				ThriftModule: admin.ThriftModule,
+			},
+
+			thrift.Method{
+				Name: "TerminateAllWorkflowRuns",
+				HandlerSpec: thrift.HandlerSpec{
+
+					Type:  transport.Unary,
+					Unary: thrift.UnaryHandler(h.TerminateAllWorkflowRuns),
+				},
+				Signature:    "TerminateAllWorkflowRuns(Request *shared.TerminateAllWorkflowRunsRequest) (*shared.TerminateAllWorkflowRunsResponse)",
+				ThriftModule: admin.ThriftModule,
+			},
+
+			thrift.Method{
+				Name: "UndrainHistoryHost",
+				HandlerSpec: thrift.HandlerSpec{
+
+					Type:  transport.Unary,
+					Unary: thrift.UnaryHandler(h.UndrainHistoryHost),
+				},
+				Signature:    "UndrainHistoryHost(Request *shared.UndrainHistoryHostRequest) (*shared.UndrainHistoryHostResponse)",
+				ThriftModule: admin.ThriftModule,
+			},
+
+			thrift.Method{
+				Name: "UpdateTaskListVersionSets",
+				HandlerSpec: thrift.HandlerSpec{
+
+					Type:  transport.Unary,
+					Unary: thrift.UnaryHandler(h.UpdateTaskListVersionSets),
+				},
+				Signature:    "UpdateTaskListVersionSets(Request *shared.UpdateTaskListVersionSetsRequest)",
+				ThriftModule: admin.ThriftModule,
+			},
 		},
 	}
 
-	procedures := make([]transport.Procedure, 0, 3)
+	procedures := make([]transport.Procedure, 0, 25)
 	procedures = append(procedures, thrift.BuildProcedures(service, opts...)...)
 	return procedures
 }
 
 type handler struct{ impl Interface }
 
-func (h handler) DescribeHistoryHost(ctx context.Context, body wire.Value) (thrift.Response, error) {
-	var args admin.AdminService_DescribeHistoryHost_Args
+func (h handler) BackfillSchedule(ctx context.Context, body wire.Value) (thrift.Response, error) {
+	var args admin.AdminService_BackfillSchedule_Args
 	if err := args.FromWire(body); err != nil {
 		return thrift.Response{}, err
 	}
 
-	success, err := h.impl.DescribeHistoryHost(ctx, args.Request)
+	err := h.impl.BackfillSchedule(ctx, args.Request)
 
 	hadError := err != nil
-	result, err := admin.AdminService_DescribeHistoryHost_Helper.WrapResponse(success, err)
+	result, err := admin.AdminService_BackfillSchedule_Helper.WrapResponse(err)
 
 	var response thrift.Response
 	if err == nil {
@@ -122,16 +474,16 @@ func (h handler) DescribeHistoryHost(ctx context.Context, body wire.Value) (thri
 	return response, err
 }
 
-func (h handler) DescribeWorkflowExecution(ctx context.Context, body wire.Value) (thrift.Response, error) {
-	var args admin.AdminService_DescribeWorkflowExecution_Args
+func (h handler) CreateSchedule(ctx context.Context, body wire.Value) (thrift.Response, error) {
+	var args admin.AdminService_CreateSchedule_Args
 	if err := args.FromWire(body); err != nil {
 		return thrift.Response{}, err
 	}
 
-	success, err := h.impl.DescribeWorkflowExecution(ctx, args.Request)
+	err := h.impl.CreateSchedule(ctx, args.Request)
 
 	hadError := err != nil
-	result, err := admin.AdminService_DescribeWorkflowExecution_Helper.WrapResponse(success, err)
+	result, err := admin.AdminService_CreateSchedule_Helper.WrapResponse(err)
 
 	var response thrift.Response
 	if err == nil {
@@ -141,16 +493,434 @@ func (h handler) DescribeWorkflowExecution(ctx context.Context, body wire.Value)
 	return response, err
 }
 
-func (h handler) GetWorkflowExecutionRawHistory(ctx context.Context, body wire.Value) (thrift.Response, error) {
-	var args admin.AdminService_GetWorkflowExecutionRawHistory_Args
+func (h handler) DeleteSchedule(ctx context.Context, body wire.Value) (thrift.Response, error) {
+	var args admin.AdminService_DeleteSchedule_Args
 	if err := args.FromWire(body); err != nil {
 		return thrift.Response{}, err
 	}
 
-	success, err := h.impl.GetWorkflowExecutionRawHistory(ctx, args.GetRequest)
+	err := h.impl.DeleteSchedule(ctx, args.Request)
 
 	hadError := err != nil
-	result, err := admin.AdminService_GetWorkflowExecutionRawHistory_Helper.WrapResponse(success, err)
+	result, err := admin.AdminService_DeleteSchedule_Helper.WrapResponse(err)
+
+	var response thrift.Response
+	if err == nil {
+		response.IsApplicationError = hadError
+		response.Body = result
+	}
+	return response, err
+}
+
+func (h handler) DescribeClientVersions(ctx context.Context, body wire.Value) (thrift.Response, error) {
+	var args admin.AdminService_DescribeClientVersions_Args
+	if err := args.FromWire(body); err != nil {
+		return thrift.Response{}, err
+	}
+
+	success, err := h.impl.DescribeClientVersions(ctx, args.Request)
+
+	hadError := err != nil
+	result, err := admin.AdminService_DescribeClientVersions_Helper.WrapResponse(success, err)
+
+	var response thrift.Response
+	if err == nil {
+		response.IsApplicationError = hadError
+		response.Body = result
+	}
+	return response, err
+}
+
+func (h handler) DescribeHistoryHost(ctx context.Context, body wire.Value) (thrift.Response, error) {
+	var args admin.AdminService_DescribeHistoryHost_Args
+	if err := args.FromWire(body); err != nil {
+		return thrift.Response{}, err
+	}
+
+	success, err := h.impl.DescribeHistoryHost(ctx, args.Request)
+
+	hadError := err != nil
+	result, err := admin.AdminService_DescribeHistoryHost_Helper.WrapResponse(success, err)
+
+	var response thrift.Response
+	if err == nil {
+		response.IsApplicationError = hadError
+		response.Body = result
+	}
+	return response, err
+}
+
+func (h handler) DescribeSchedule(ctx context.Context, body wire.Value) (thrift.Response, error) {
+	var args admin.AdminService_DescribeSchedule_Args
+	if err := args.FromWire(body); err != nil {
+		return thrift.Response{}, err
+	}
+
+	success, err := h.impl.DescribeSchedule(ctx, args.Request)
+
+	hadError := err != nil
+	result, err := admin.AdminService_DescribeSchedule_Helper.WrapResponse(success, err)
+
+	var response thrift.Response
+	if err == nil {
+		response.IsApplicationError = hadError
+		response.Body = result
+	}
+	return response, err
+}
+
+func (h handler) DescribeTaskJourney(ctx context.Context, body wire.Value) (thrift.Response, error) {
+	var args admin.AdminService_DescribeTaskJourney_Args
+	if err := args.FromWire(body); err != nil {
+		return thrift.Response{}, err
+	}
+
+	success, err := h.impl.DescribeTaskJourney(ctx, args.Request)
+
+	hadError := err != nil
+	result, err := admin.AdminService_DescribeTaskJourney_Helper.WrapResponse(success, err)
+
+	var response thrift.Response
+	if err == nil {
+		response.IsApplicationError = hadError
+		response.Body = result
+	}
+	return response, err
+}
+
+func (h handler) DescribeWorkflowExecution(ctx context.Context, body wire.Value) (thrift.Response, error) {
+	var args admin.AdminService_DescribeWorkflowExecution_Args
+	if err := args.FromWire(body); err != nil {
+		return thrift.Response{}, err
+	}
+
+	success, err := h.impl.DescribeWorkflowExecution(ctx, args.Request)
+
+	hadError := err != nil
+	result, err := admin.AdminService_DescribeWorkflowExecution_Helper.WrapResponse(success, err)
+
+	var response thrift.Response
+	if err == nil {
+		response.IsApplicationError = hadError
+		response.Body = result
+	}
+	return response, err
+}
+
+func (h handler) DescribeWorkflowShard(ctx context.Context, body wire.Value) (thrift.Response, error) {
+	var args admin.AdminService_DescribeWorkflowShard_Args
+	if err := args.FromWire(body); err != nil {
+		return thrift.Response{}, err
+	}
+
+	success, err := h.impl.DescribeWorkflowShard(ctx, args.Request)
+
+	hadError := err != nil
+	result, err := admin.AdminService_DescribeWorkflowShard_Helper.WrapResponse(success, err)
+
+	var response thrift.Response
+	if err == nil {
+		response.IsApplicationError = hadError
+		response.Body = result
+	}
+	return response, err
+}
+
+func (h handler) DrainHistoryHost(ctx context.Context, body wire.Value) (thrift.Response, error) {
+	var args admin.AdminService_DrainHistoryHost_Args
+	if err := args.FromWire(body); err != nil {
+		return thrift.Response{}, err
+	}
+
+	success, err := h.impl.DrainHistoryHost(ctx, args.Request)
+
+	hadError := err != nil
+	result, err := admin.AdminService_DrainHistoryHost_Helper.WrapResponse(success, err)
+
+	var response thrift.Response
+	if err == nil {
+		response.IsApplicationError = hadError
+		response.Body = result
+	}
+	return response, err
+}
+
+func (h handler) GetDomainOpenExecutionCount(ctx context.Context, body wire.Value) (thrift.Response, error) {
+	var args admin.AdminService_GetDomainOpenExecutionCount_Args
+	if err := args.FromWire(body); err != nil {
+		return thrift.Response{}, err
+	}
+
+	success, err := h.impl.GetDomainOpenExecutionCount(ctx, args.Request)
+
+	hadError := err != nil
+	result, err := admin.AdminService_GetDomainOpenExecutionCount_Helper.WrapResponse(success, err)
+
+	var response thrift.Response
+	if err == nil {
+		response.IsApplicationError = hadError
+		response.Body = result
+	}
+	return response, err
+}
+
+func (h handler) GetWorkflowExecutionRawHistory(ctx context.Context, body wire.Value) (thrift.Response, error) {
+	var args admin.AdminService_GetWorkflowExecutionRawHistory_Args
+	if err := args.FromWire(body); err != nil {
+		return thrift.Response{}, err
+	}
+
+	success, err := h.impl.GetWorkflowExecutionRawHistory(ctx, args.GetRequest)
+
+	hadError := err != nil
+	result, err := admin.AdminService_GetWorkflowExecutionRawHistory_Helper.WrapResponse(success, err)
+
+	var response thrift.Response
+	if err == nil {
+		response.IsApplicationError = hadError
+		response.Body = result
+	}
+	return response, err
+}
+
+func (h handler) ImportWorkflowExecution(ctx context.Context, body wire.Value) (thrift.Response, error) {
+	var args admin.AdminService_ImportWorkflowExecution_Args
+	if err := args.FromWire(body); err != nil {
+		return thrift.Response{}, err
+	}
+
+	success, err := h.impl.ImportWorkflowExecution(ctx, args.Request)
+
+	hadError := err != nil
+	result, err := admin.AdminService_ImportWorkflowExecution_Helper.WrapResponse(success, err)
+
+	var response thrift.Response
+	if err == nil {
+		response.IsApplicationError = hadError
+		response.Body = result
+	}
+	return response, err
+}
+
+func (h handler) ListRecentlyClosedExecutions(ctx context.Context, body wire.Value) (thrift.Response, error) {
+	var args admin.AdminService_ListRecentlyClosedExecutions_Args
+	if err := args.FromWire(body); err != nil {
+		return thrift.Response{}, err
+	}
+
+	success, err := h.impl.ListRecentlyClosedExecutions(ctx, args.Request)
+
+	hadError := err != nil
+	result, err := admin.AdminService_ListRecentlyClosedExecutions_Helper.WrapResponse(success, err)
+
+	var response thrift.Response
+	if err == nil {
+		response.IsApplicationError = hadError
+		response.Body = result
+	}
+	return response, err
+}
+
+func (h handler) ListSchedules(ctx context.Context, body wire.Value) (thrift.Response, error) {
+	var args admin.AdminService_ListSchedules_Args
+	if err := args.FromWire(body); err != nil {
+		return thrift.Response{}, err
+	}
+
+	success, err := h.impl.ListSchedules(ctx, args.Request)
+
+	hadError := err != nil
+	result, err := admin.AdminService_ListSchedules_Helper.WrapResponse(success, err)
+
+	var response thrift.Response
+	if err == nil {
+		response.IsApplicationError = hadError
+		response.Body = result
+	}
+	return response, err
+}
+
+func (h handler) ListWorkflowExecutionRuns(ctx context.Context, body wire.Value) (thrift.Response, error) {
+	var args admin.AdminService_ListWorkflowExecutionRuns_Args
+	if err := args.FromWire(body); err != nil {
+		return thrift.Response{}, err
+	}
+
+	success, err := h.impl.ListWorkflowExecutionRuns(ctx, args.Request)
+
+	hadError := err != nil
+	result, err := admin.AdminService_ListWorkflowExecutionRuns_Helper.WrapResponse(success, err)
+
+	var response thrift.Response
+	if err == nil {
+		response.IsApplicationError = hadError
+		response.Body = result
+	}
+	return response, err
+}
+
+func (h handler) PurgeWorkflowExecution(ctx context.Context, body wire.Value) (thrift.Response, error) {
+	var args admin.AdminService_PurgeWorkflowExecution_Args
+	if err := args.FromWire(body); err != nil {
+		return thrift.Response{}, err
+	}
+
+	success, err := h.impl.PurgeWorkflowExecution(ctx, args.Request)
+
+	hadError := err != nil
+	result, err := admin.AdminService_PurgeWorkflowExecution_Helper.WrapResponse(success, err)
+
+	var response thrift.Response
+	if err == nil {
+		response.IsApplicationError = hadError
+		response.Body = result
+	}
+	return response, err
+}
+
+func (h handler) SetActivityPaused(ctx context.Context, body wire.Value) (thrift.Response, error) {
+	var args admin.AdminService_SetActivityPaused_Args
+	if err := args.FromWire(body); err != nil {
+		return thrift.Response{}, err
+	}
+
+	err := h.impl.SetActivityPaused(ctx, args.Request)
+
+	hadError := err != nil
+	result, err := admin.AdminService_SetActivityPaused_Helper.WrapResponse(err)
+
+	var response thrift.Response
+	if err == nil {
+		response.IsApplicationError = hadError
+		response.Body = result
+	}
+	return response, err
+}
+
+func (h handler) SetCronSchedulePaused(ctx context.Context, body wire.Value) (thrift.Response, error) {
+	var args admin.AdminService_SetCronSchedulePaused_Args
+	if err := args.FromWire(body); err != nil {
+		return thrift.Response{}, err
+	}
+
+	err := h.impl.SetCronSchedulePaused(ctx, args.Request)
+
+	hadError := err != nil
+	result, err := admin.AdminService_SetCronSchedulePaused_Helper.WrapResponse(err)
+
+	var response thrift.Response
+	if err == nil {
+		response.IsApplicationError = hadError
+		response.Body = result
+	}
+	return response, err
+}
+
+func (h handler) SetSchedulePaused(ctx context.Context, body wire.Value) (thrift.Response, error) {
+	var args admin.AdminService_SetSchedulePaused_Args
+	if err := args.FromWire(body); err != nil {
+		return thrift.Response{}, err
+	}
+
+	err := h.impl.SetSchedulePaused(ctx, args.Request)
+
+	hadError := err != nil
+	result, err := admin.AdminService_SetSchedulePaused_Helper.WrapResponse(err)
+
+	var response thrift.Response
+	if err == nil {
+		response.IsApplicationError = hadError
+		response.Body = result
+	}
+	return response, err
+}
+
+func (h handler) SetWorkflowExecutionNotes(ctx context.Context, body wire.Value) (thrift.Response, error) {
+	var args admin.AdminService_SetWorkflowExecutionNotes_Args
+	if err := args.FromWire(body); err != nil {
+		return thrift.Response{}, err
+	}
+
+	err := h.impl.SetWorkflowExecutionNotes(ctx, args.Request)
+
+	hadError := err != nil
+	result, err := admin.AdminService_SetWorkflowExecutionNotes_Helper.WrapResponse(err)
+
+	var response thrift.Response
+	if err == nil {
+		response.IsApplicationError = hadError
+		response.Body = result
+	}
+	return response, err
+}
+
+func (h handler) SetWorkflowExecutionPaused(ctx context.Context, body wire.Value) (thrift.Response, error) {
+	var args admin.AdminService_SetWorkflowExecutionPaused_Args
+	if err := args.FromWire(body); err != nil {
+		return thrift.Response{}, err
+	}
+
+	err := h.impl.SetWorkflowExecutionPaused(ctx, args.Request)
+
+	hadError := err != nil
+	result, err := admin.AdminService_SetWorkflowExecutionPaused_Helper.WrapResponse(err)
+
+	var response thrift.Response
+	if err == nil {
+		response.IsApplicationError = hadError
+		response.Body = result
+	}
+	return response, err
+}
+
+func (h handler) TerminateAllWorkflowRuns(ctx context.Context, body wire.Value) (thrift.Response, error) {
+	var args admin.AdminService_TerminateAllWorkflowRuns_Args
+	if err := args.FromWire(body); err != nil {
+		return thrift.Response{}, err
+	}
+
+	success, err := h.impl.TerminateAllWorkflowRuns(ctx, args.Request)
+
+	hadError := err != nil
+	result, err := admin.AdminService_TerminateAllWorkflowRuns_Helper.WrapResponse(success, err)
+
+	var response thrift.Response
+	if err == nil {
+		response.IsApplicationError = hadError
+		response.Body = result
+	}
+	return response, err
+}
+
+func (h handler) UndrainHistoryHost(ctx context.Context, body wire.Value) (thrift.Response, error) {
+	var args admin.AdminService_UndrainHistoryHost_Args
+	if err := args.FromWire(body); err != nil {
+		return thrift.Response{}, err
+	}
+
+	success, err := h.impl.UndrainHistoryHost(ctx, args.Request)
+
+	hadError := err != nil
+	result, err := admin.AdminService_UndrainHistoryHost_Helper.WrapResponse(success, err)
+
+	var response thrift.Response
+	if err == nil {
+		response.IsApplicationError = hadError
+		response.Body = result
+	}
+	return response, err
+}
+
+func (h handler) UpdateTaskListVersionSets(ctx context.Context, body wire.Value) (thrift.Response, error) {
+	var args admin.AdminService_UpdateTaskListVersionSets_Args
+	if err := args.FromWire(body); err != nil {
+		return thrift.Response{}, err
+	}
+
+	err := h.impl.UpdateTaskListVersionSets(ctx, args.Request)
+
+	hadError := err != nil
+	result, err := admin.AdminService_UpdateTaskListVersionSets_Helper.WrapResponse(err)
 
 	var response thrift.Response
 	if err == nil {
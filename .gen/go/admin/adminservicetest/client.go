@@ -63,6 +63,132 @@ func (m *MockClient) EXPECT() *_MockClientRecorder {
 	return m.recorder
 }
 
+// BackfillSchedule responds to a BackfillSchedule call based on the mock expectations. This
+// call will fail if the mock does not expect this call. Use EXPECT to expect
+// a call to this function.
+//
+// 	client.EXPECT().BackfillSchedule(gomock.Any(), ...).Return(...)
+// 	... := client.BackfillSchedule(...)
+func (m *MockClient) BackfillSchedule(
+	ctx context.Context,
+	_Request *shared.BackfillScheduleRequest,
+	opts ...yarpc.CallOption,
+) (err error) {
+
+	args := []interface{}{ctx, _Request}
+	for _, o := range opts {
+		args = append(args, o)
+	}
+	i := 0
+	ret := m.ctrl.Call(m, "BackfillSchedule", args...)
+	err, _ = ret[i].(error)
+	return
+}
+
+func (mr *_MockClientRecorder) BackfillSchedule(
+	ctx interface{},
+	_Request interface{},
+	opts ...interface{},
+) *gomock.Call {
+	args := append([]interface{}{ctx, _Request}, opts...)
+	return mr.mock.ctrl.RecordCall(mr.mock, "BackfillSchedule", args...)
+}
+
+// CreateSchedule responds to a CreateSchedule call based on the mock expectations. This
+// call will fail if the mock does not expect this call. Use EXPECT to expect
+// a call to this function.
+//
+// 	client.EXPECT().CreateSchedule(gomock.Any(), ...).Return(...)
+// 	... := client.CreateSchedule(...)
+func (m *MockClient) CreateSchedule(
+	ctx context.Context,
+	_Request *shared.CreateScheduleRequest,
+	opts ...yarpc.CallOption,
+) (err error) {
+
+	args := []interface{}{ctx, _Request}
+	for _, o := range opts {
+		args = append(args, o)
+	}
+	i := 0
+	ret := m.ctrl.Call(m, "CreateSchedule", args...)
+	err, _ = ret[i].(error)
+	return
+}
+
+func (mr *_MockClientRecorder) CreateSchedule(
+	ctx interface{},
+	_Request interface{},
+	opts ...interface{},
+) *gomock.Call {
+	args := append([]interface{}{ctx, _Request}, opts...)
+	return mr.mock.ctrl.RecordCall(mr.mock, "CreateSchedule", args...)
+}
+
+// DeleteSchedule responds to a DeleteSchedule call based on the mock expectations. This
+// call will fail if the mock does not expect this call. Use EXPECT to expect
+// a call to this function.
+//
+// 	client.EXPECT().DeleteSchedule(gomock.Any(), ...).Return(...)
+// 	... := client.DeleteSchedule(...)
+func (m *MockClient) DeleteSchedule(
+	ctx context.Context,
+	_Request *shared.DeleteScheduleRequest,
+	opts ...yarpc.CallOption,
+) (err error) {
+
+	args := []interface{}{ctx, _Request}
+	for _, o := range opts {
+		args = append(args, o)
+	}
+	i := 0
+	ret := m.ctrl.Call(m, "DeleteSchedule", args...)
+	err, _ = ret[i].(error)
+	return
+}
+
+func (mr *_MockClientRecorder) DeleteSchedule(
+	ctx interface{},
+	_Request interface{},
+	opts ...interface{},
+) *gomock.Call {
+	args := append([]interface{}{ctx, _Request}, opts...)
+	return mr.mock.ctrl.RecordCall(mr.mock, "DeleteSchedule", args...)
+}
+
+// DescribeClientVersions responds to a DescribeClientVersions call based on the mock expectations. This
+// call will fail if the mock does not expect this call. Use EXPECT to expect
+// a call to this function.
+//
+// 	client.EXPECT().DescribeClientVersions(gomock.Any(), ...).Return(...)
+// 	... := client.DescribeClientVersions(...)
+func (m *MockClient) DescribeClientVersions(
+	ctx context.Context,
+	_Request *shared.DescribeClientVersionsRequest,
+	opts ...yarpc.CallOption,
+) (success *shared.DescribeClientVersionsResponse, err error) {
+
+	args := []interface{}{ctx, _Request}
+	for _, o := range opts {
+		args = append(args, o)
+	}
+	i := 0
+	ret := m.ctrl.Call(m, "DescribeClientVersions", args...)
+	success, _ = ret[i].(*shared.DescribeClientVersionsResponse)
+	i++
+	err, _ = ret[i].(error)
+	return
+}
+
+func (mr *_MockClientRecorder) DescribeClientVersions(
+	ctx interface{},
+	_Request interface{},
+	opts ...interface{},
+) *gomock.Call {
+	args := append([]interface{}{ctx, _Request}, opts...)
+	return mr.mock.ctrl.RecordCall(mr.mock, "DescribeClientVersions", args...)
+}
+
 // DescribeHistoryHost responds to a DescribeHistoryHost call based on the mock expectations. This
 // call will fail if the mock does not expect this call. Use EXPECT to expect
 // a call to this function.
@@ -96,6 +222,72 @@ func (mr *_MockClientRecorder) DescribeHistoryHost(
 	return mr.mock.ctrl.RecordCall(mr.mock, "DescribeHistoryHost", args...)
 }
 
+// DescribeSchedule responds to a DescribeSchedule call based on the mock expectations. This
+// call will fail if the mock does not expect this call. Use EXPECT to expect
+// a call to this function.
+//
+// 	client.EXPECT().DescribeSchedule(gomock.Any(), ...).Return(...)
+// 	... := client.DescribeSchedule(...)
+func (m *MockClient) DescribeSchedule(
+	ctx context.Context,
+	_Request *shared.DescribeScheduleRequest,
+	opts ...yarpc.CallOption,
+) (success *shared.DescribeScheduleResponse, err error) {
+
+	args := []interface{}{ctx, _Request}
+	for _, o := range opts {
+		args = append(args, o)
+	}
+	i := 0
+	ret := m.ctrl.Call(m, "DescribeSchedule", args...)
+	success, _ = ret[i].(*shared.DescribeScheduleResponse)
+	i++
+	err, _ = ret[i].(error)
+	return
+}
+
+func (mr *_MockClientRecorder) DescribeSchedule(
+	ctx interface{},
+	_Request interface{},
+	opts ...interface{},
+) *gomock.Call {
+	args := append([]interface{}{ctx, _Request}, opts...)
+	return mr.mock.ctrl.RecordCall(mr.mock, "DescribeSchedule", args...)
+}
+
+// DescribeTaskJourney responds to a DescribeTaskJourney call based on the mock expectations. This
+// call will fail if the mock does not expect this call. Use EXPECT to expect
+// a call to this function.
+//
+// 	client.EXPECT().DescribeTaskJourney(gomock.Any(), ...).Return(...)
+// 	... := client.DescribeTaskJourney(...)
+func (m *MockClient) DescribeTaskJourney(
+	ctx context.Context,
+	_Request *shared.DescribeTaskJourneyRequest,
+	opts ...yarpc.CallOption,
+) (success *shared.DescribeTaskJourneyResponse, err error) {
+
+	args := []interface{}{ctx, _Request}
+	for _, o := range opts {
+		args = append(args, o)
+	}
+	i := 0
+	ret := m.ctrl.Call(m, "DescribeTaskJourney", args...)
+	success, _ = ret[i].(*shared.DescribeTaskJourneyResponse)
+	i++
+	err, _ = ret[i].(error)
+	return
+}
+
+func (mr *_MockClientRecorder) DescribeTaskJourney(
+	ctx interface{},
+	_Request interface{},
+	opts ...interface{},
+) *gomock.Call {
+	args := append([]interface{}{ctx, _Request}, opts...)
+	return mr.mock.ctrl.RecordCall(mr.mock, "DescribeTaskJourney", args...)
+}
+
 // DescribeWorkflowExecution responds to a DescribeWorkflowExecution call based on the mock expectations. This
 // call will fail if the mock does not expect this call. Use EXPECT to expect
 // a call to this function.
@@ -129,6 +321,105 @@ func (mr *_MockClientRecorder) DescribeWorkflowExecution(
 	return mr.mock.ctrl.RecordCall(mr.mock, "DescribeWorkflowExecution", args...)
 }
 
+// DescribeWorkflowShard responds to a DescribeWorkflowShard call based on the mock expectations. This
+// call will fail if the mock does not expect this call. Use EXPECT to expect
+// a call to this function.
+//
+// 	client.EXPECT().DescribeWorkflowShard(gomock.Any(), ...).Return(...)
+// 	... := client.DescribeWorkflowShard(...)
+func (m *MockClient) DescribeWorkflowShard(
+	ctx context.Context,
+	_Request *admin.DescribeWorkflowShardRequest,
+	opts ...yarpc.CallOption,
+) (success *admin.DescribeWorkflowShardResponse, err error) {
+
+	args := []interface{}{ctx, _Request}
+	for _, o := range opts {
+		args = append(args, o)
+	}
+	i := 0
+	ret := m.ctrl.Call(m, "DescribeWorkflowShard", args...)
+	success, _ = ret[i].(*admin.DescribeWorkflowShardResponse)
+	i++
+	err, _ = ret[i].(error)
+	return
+}
+
+func (mr *_MockClientRecorder) DescribeWorkflowShard(
+	ctx interface{},
+	_Request interface{},
+	opts ...interface{},
+) *gomock.Call {
+	args := append([]interface{}{ctx, _Request}, opts...)
+	return mr.mock.ctrl.RecordCall(mr.mock, "DescribeWorkflowShard", args...)
+}
+
+// DrainHistoryHost responds to a DrainHistoryHost call based on the mock expectations. This
+// call will fail if the mock does not expect this call. Use EXPECT to expect
+// a call to this function.
+//
+// 	client.EXPECT().DrainHistoryHost(gomock.Any(), ...).Return(...)
+// 	... := client.DrainHistoryHost(...)
+func (m *MockClient) DrainHistoryHost(
+	ctx context.Context,
+	_Request *shared.DrainHistoryHostRequest,
+	opts ...yarpc.CallOption,
+) (success *shared.DrainHistoryHostResponse, err error) {
+
+	args := []interface{}{ctx, _Request}
+	for _, o := range opts {
+		args = append(args, o)
+	}
+	i := 0
+	ret := m.ctrl.Call(m, "DrainHistoryHost", args...)
+	success, _ = ret[i].(*shared.DrainHistoryHostResponse)
+	i++
+	err, _ = ret[i].(error)
+	return
+}
+
+func (mr *_MockClientRecorder) DrainHistoryHost(
+	ctx interface{},
+	_Request interface{},
+	opts ...interface{},
+) *gomock.Call {
+	args := append([]interface{}{ctx, _Request}, opts...)
+	return mr.mock.ctrl.RecordCall(mr.mock, "DrainHistoryHost", args...)
+}
+
+// GetDomainOpenExecutionCount responds to a GetDomainOpenExecutionCount call based on the mock expectations. This
+// call will fail if the mock does not expect this call. Use EXPECT to expect
+// a call to this function.
+//
+// 	client.EXPECT().GetDomainOpenExecutionCount(gomock.Any(), ...).Return(...)
+// 	... := client.GetDomainOpenExecutionCount(...)
+func (m *MockClient) GetDomainOpenExecutionCount(
+	ctx context.Context,
+	_Request *shared.GetDomainOpenExecutionCountRequest,
+	opts ...yarpc.CallOption,
+) (success *shared.GetDomainOpenExecutionCountResponse, err error) {
+
+	args := []interface{}{ctx, _Request}
+	for _, o := range opts {
+		args = append(args, o)
+	}
+	i := 0
+	ret := m.ctrl.Call(m, "GetDomainOpenExecutionCount", args...)
+	success, _ = ret[i].(*shared.GetDomainOpenExecutionCountResponse)
+	i++
+	err, _ = ret[i].(error)
+	return
+}
+
+func (mr *_MockClientRecorder) GetDomainOpenExecutionCount(
+	ctx interface{},
+	_Request interface{},
+	opts ...interface{},
+) *gomock.Call {
+	args := append([]interface{}{ctx, _Request}, opts...)
+	return mr.mock.ctrl.RecordCall(mr.mock, "GetDomainOpenExecutionCount", args...)
+}
+
 // GetWorkflowExecutionRawHistory responds to a GetWorkflowExecutionRawHistory call based on the mock expectations. This
 // call will fail if the mock does not expect this call. Use EXPECT to expect
 // a call to this function.
@@ -161,3 +452,420 @@ func (mr *_MockClientRecorder) GetWorkflowExecutionRawHistory(
 	args := append([]interface{}{ctx, _GetRequest}, opts...)
 	return mr.mock.ctrl.RecordCall(mr.mock, "GetWorkflowExecutionRawHistory", args...)
 }
+
+// ImportWorkflowExecution responds to a ImportWorkflowExecution call based on the mock expectations. This
+// call will fail if the mock does not expect this call. Use EXPECT to expect
+// a call to this function.
+//
+// 	client.EXPECT().ImportWorkflowExecution(gomock.Any(), ...).Return(...)
+// 	... := client.ImportWorkflowExecution(...)
+func (m *MockClient) ImportWorkflowExecution(
+	ctx context.Context,
+	_Request *admin.ImportWorkflowExecutionRequest,
+	opts ...yarpc.CallOption,
+) (success *admin.ImportWorkflowExecutionResponse, err error) {
+
+	args := []interface{}{ctx, _Request}
+	for _, o := range opts {
+		args = append(args, o)
+	}
+	i := 0
+	ret := m.ctrl.Call(m, "ImportWorkflowExecution", args...)
+	success, _ = ret[i].(*admin.ImportWorkflowExecutionResponse)
+	i++
+	err, _ = ret[i].(error)
+	return
+}
+
+func (mr *_MockClientRecorder) ImportWorkflowExecution(
+	ctx interface{},
+	_Request interface{},
+	opts ...interface{},
+) *gomock.Call {
+	args := append([]interface{}{ctx, _Request}, opts...)
+	return mr.mock.ctrl.RecordCall(mr.mock, "ImportWorkflowExecution", args...)
+}
+
+// ListRecentlyClosedExecutions responds to a ListRecentlyClosedExecutions call based on the mock expectations. This
+// call will fail if the mock does not expect this call. Use EXPECT to expect
+// a call to this function.
+//
+// 	client.EXPECT().ListRecentlyClosedExecutions(gomock.Any(), ...).Return(...)
+// 	... := client.ListRecentlyClosedExecutions(...)
+func (m *MockClient) ListRecentlyClosedExecutions(
+	ctx context.Context,
+	_Request *admin.ListRecentlyClosedExecutionsRequest,
+	opts ...yarpc.CallOption,
+) (success *admin.ListRecentlyClosedExecutionsResponse, err error) {
+
+	args := []interface{}{ctx, _Request}
+	for _, o := range opts {
+		args = append(args, o)
+	}
+	i := 0
+	ret := m.ctrl.Call(m, "ListRecentlyClosedExecutions", args...)
+	success, _ = ret[i].(*admin.ListRecentlyClosedExecutionsResponse)
+	i++
+	err, _ = ret[i].(error)
+	return
+}
+
+func (mr *_MockClientRecorder) ListRecentlyClosedExecutions(
+	ctx interface{},
+	_Request interface{},
+	opts ...interface{},
+) *gomock.Call {
+	args := append([]interface{}{ctx, _Request}, opts...)
+	return mr.mock.ctrl.RecordCall(mr.mock, "ListRecentlyClosedExecutions", args...)
+}
+
+// ListSchedules responds to a ListSchedules call based on the mock expectations. This
+// call will fail if the mock does not expect this call. Use EXPECT to expect
+// a call to this function.
+//
+// 	client.EXPECT().ListSchedules(gomock.Any(), ...).Return(...)
+// 	... := client.ListSchedules(...)
+func (m *MockClient) ListSchedules(
+	ctx context.Context,
+	_Request *shared.ListSchedulesRequest,
+	opts ...yarpc.CallOption,
+) (success *shared.ListSchedulesResponse, err error) {
+
+	args := []interface{}{ctx, _Request}
+	for _, o := range opts {
+		args = append(args, o)
+	}
+	i := 0
+	ret := m.ctrl.Call(m, "ListSchedules", args...)
+	success, _ = ret[i].(*shared.ListSchedulesResponse)
+	i++
+	err, _ = ret[i].(error)
+	return
+}
+
+func (mr *_MockClientRecorder) ListSchedules(
+	ctx interface{},
+	_Request interface{},
+	opts ...interface{},
+) *gomock.Call {
+	args := append([]interface{}{ctx, _Request}, opts...)
+	return mr.mock.ctrl.RecordCall(mr.mock, "ListSchedules", args...)
+}
+
+// ListWorkflowExecutionRuns responds to a ListWorkflowExecutionRuns call based on the mock expectations. This
+// call will fail if the mock does not expect this call. Use EXPECT to expect
+// a call to this function.
+//
+// 	client.EXPECT().ListWorkflowExecutionRuns(gomock.Any(), ...).Return(...)
+// 	... := client.ListWorkflowExecutionRuns(...)
+func (m *MockClient) ListWorkflowExecutionRuns(
+	ctx context.Context,
+	_Request *admin.ListWorkflowExecutionRunsRequest,
+	opts ...yarpc.CallOption,
+) (success *admin.ListWorkflowExecutionRunsResponse, err error) {
+
+	args := []interface{}{ctx, _Request}
+	for _, o := range opts {
+		args = append(args, o)
+	}
+	i := 0
+	ret := m.ctrl.Call(m, "ListWorkflowExecutionRuns", args...)
+	success, _ = ret[i].(*admin.ListWorkflowExecutionRunsResponse)
+	i++
+	err, _ = ret[i].(error)
+	return
+}
+
+func (mr *_MockClientRecorder) ListWorkflowExecutionRuns(
+	ctx interface{},
+	_Request interface{},
+	opts ...interface{},
+) *gomock.Call {
+	args := append([]interface{}{ctx, _Request}, opts...)
+	return mr.mock.ctrl.RecordCall(mr.mock, "ListWorkflowExecutionRuns", args...)
+}
+
+// PurgeWorkflowExecution responds to a PurgeWorkflowExecution call based on the mock expectations. This
+// call will fail if the mock does not expect this call. Use EXPECT to expect
+// a call to this function.
+//
+// 	client.EXPECT().PurgeWorkflowExecution(gomock.Any(), ...).Return(...)
+// 	... := client.PurgeWorkflowExecution(...)
+func (m *MockClient) PurgeWorkflowExecution(
+	ctx context.Context,
+	_Request *admin.PurgeWorkflowExecutionRequest,
+	opts ...yarpc.CallOption,
+) (success *admin.PurgeWorkflowExecutionResponse, err error) {
+
+	args := []interface{}{ctx, _Request}
+	for _, o := range opts {
+		args = append(args, o)
+	}
+	i := 0
+	ret := m.ctrl.Call(m, "PurgeWorkflowExecution", args...)
+	success, _ = ret[i].(*admin.PurgeWorkflowExecutionResponse)
+	i++
+	err, _ = ret[i].(error)
+	return
+}
+
+func (mr *_MockClientRecorder) PurgeWorkflowExecution(
+	ctx interface{},
+	_Request interface{},
+	opts ...interface{},
+) *gomock.Call {
+	args := append([]interface{}{ctx, _Request}, opts...)
+	return mr.mock.ctrl.RecordCall(mr.mock, "PurgeWorkflowExecution", args...)
+}
+
+// SetActivityPaused responds to a SetActivityPaused call based on the mock expectations. This
+// call will fail if the mock does not expect this call. Use EXPECT to expect
+// a call to this function.
+//
+// 	client.EXPECT().SetActivityPaused(gomock.Any(), ...).Return(...)
+// 	... := client.SetActivityPaused(...)
+func (m *MockClient) SetActivityPaused(
+	ctx context.Context,
+	_Request *shared.SetActivityPausedRequest,
+	opts ...yarpc.CallOption,
+) (err error) {
+
+	args := []interface{}{ctx, _Request}
+	for _, o := range opts {
+		args = append(args, o)
+	}
+	i := 0
+	ret := m.ctrl.Call(m, "SetActivityPaused", args...)
+	err, _ = ret[i].(error)
+	return
+}
+
+func (mr *_MockClientRecorder) SetActivityPaused(
+	ctx interface{},
+	_Request interface{},
+	opts ...interface{},
+) *gomock.Call {
+	args := append([]interface{}{ctx, _Request}, opts...)
+	return mr.mock.ctrl.RecordCall(mr.mock, "SetActivityPaused", args...)
+}
+
+// SetCronSchedulePaused responds to a SetCronSchedulePaused call based on the mock expectations. This
+// call will fail if the mock does not expect this call. Use EXPECT to expect
+// a call to this function.
+//
+// 	client.EXPECT().SetCronSchedulePaused(gomock.Any(), ...).Return(...)
+// 	... := client.SetCronSchedulePaused(...)
+func (m *MockClient) SetCronSchedulePaused(
+	ctx context.Context,
+	_Request *shared.SetCronSchedulePausedRequest,
+	opts ...yarpc.CallOption,
+) (err error) {
+
+	args := []interface{}{ctx, _Request}
+	for _, o := range opts {
+		args = append(args, o)
+	}
+	i := 0
+	ret := m.ctrl.Call(m, "SetCronSchedulePaused", args...)
+	err, _ = ret[i].(error)
+	return
+}
+
+func (mr *_MockClientRecorder) SetCronSchedulePaused(
+	ctx interface{},
+	_Request interface{},
+	opts ...interface{},
+) *gomock.Call {
+	args := append([]interface{}{ctx, _Request}, opts...)
+	return mr.mock.ctrl.RecordCall(mr.mock, "SetCronSchedulePaused", args...)
+}
+
+// SetSchedulePaused responds to a SetSchedulePaused call based on the mock expectations. This
+// call will fail if the mock does not expect this call. Use EXPECT to expect
+// a call to this function.
+//
+// 	client.EXPECT().SetSchedulePaused(gomock.Any(), ...).Return(...)
+// 	... := client.SetSchedulePaused(...)
+func (m *MockClient) SetSchedulePaused(
+	ctx context.Context,
+	_Request *shared.SetSchedulePausedRequest,
+	opts ...yarpc.CallOption,
+) (err error) {
+
+	args := []interface{}{ctx, _Request}
+	for _, o := range opts {
+		args = append(args, o)
+	}
+	i := 0
+	ret := m.ctrl.Call(m, "SetSchedulePaused", args...)
+	err, _ = ret[i].(error)
+	return
+}
+
+func (mr *_MockClientRecorder) SetSchedulePaused(
+	ctx interface{},
+	_Request interface{},
+	opts ...interface{},
+) *gomock.Call {
+	args := append([]interface{}{ctx, _Request}, opts...)
+	return mr.mock.ctrl.RecordCall(mr.mock, "SetSchedulePaused", args...)
+}
+
+// SetWorkflowExecutionNotes responds to a SetWorkflowExecutionNotes call based on the mock expectations. This
+// call will fail if the mock does not expect this call. Use EXPECT to expect
+// a call to this function.
+//
+// 	client.EXPECT().SetWorkflowExecutionNotes(gomock.Any(), ...).Return(...)
+// 	... := client.SetWorkflowExecutionNotes(...)
+func (m *MockClient) SetWorkflowExecutionNotes(
+	ctx context.Context,
+	_Request *shared.SetWorkflowExecutionNotesRequest,
+	opts ...yarpc.CallOption,
+) (err error) {
+
+	args := []interface{}{ctx, _Request}
+	for _, o := range opts {
+		args = append(args, o)
+	}
+	i := 0
+	ret := m.ctrl.Call(m, "SetWorkflowExecutionNotes", args...)
+	err, _ = ret[i].(error)
+	return
+}
+
+func (mr *_MockClientRecorder) SetWorkflowExecutionNotes(
+	ctx interface{},
+	_Request interface{},
+	opts ...interface{},
+) *gomock.Call {
+	args := append([]interface{}{ctx, _Request}, opts...)
+	return mr.mock.ctrl.RecordCall(mr.mock, "SetWorkflowExecutionNotes", args...)
+}
+
+// SetWorkflowExecutionPaused responds to a SetWorkflowExecutionPaused call based on the mock expectations. This
+// call will fail if the mock does not expect this call. Use EXPECT to expect
+// a call to this function.
+//
+// 	client.EXPECT().SetWorkflowExecutionPaused(gomock.Any(), ...).Return(...)
+// 	... := client.SetWorkflowExecutionPaused(...)
+func (m *MockClient) SetWorkflowExecutionPaused(
+	ctx context.Context,
+	_Request *shared.SetWorkflowExecutionPausedRequest,
+	opts ...yarpc.CallOption,
+) (err error) {
+
+	args := []interface{}{ctx, _Request}
+	for _, o := range opts {
+		args = append(args, o)
+	}
+	i := 0
+	ret := m.ctrl.Call(m, "SetWorkflowExecutionPaused", args...)
+	err, _ = ret[i].(error)
+	return
+}
+
+func (mr *_MockClientRecorder) SetWorkflowExecutionPaused(
+	ctx interface{},
+	_Request interface{},
+	opts ...interface{},
+) *gomock.Call {
+	args := append([]interface{}{ctx, _Request}, opts...)
+	return mr.mock.ctrl.RecordCall(mr.mock, "SetWorkflowExecutionPaused", args...)
+}
+
+// TerminateAllWorkflowRuns responds to a TerminateAllWorkflowRuns call based on the mock expectations. This
+// call will fail if the mock does not expect this call. Use EXPECT to expect
+// a call to this function.
+//
+// 	client.EXPECT().TerminateAllWorkflowRuns(gomock.Any(), ...).Return(...)
+// 	... := client.TerminateAllWorkflowRuns(...)
+func (m *MockClient) TerminateAllWorkflowRuns(
+	ctx context.Context,
+	_Request *shared.TerminateAllWorkflowRunsRequest,
+	opts ...yarpc.CallOption,
+) (success *shared.TerminateAllWorkflowRunsResponse, err error) {
+
+	args := []interface{}{ctx, _Request}
+	for _, o := range opts {
+		args = append(args, o)
+	}
+	i := 0
+	ret := m.ctrl.Call(m, "TerminateAllWorkflowRuns", args...)
+	success, _ = ret[i].(*shared.TerminateAllWorkflowRunsResponse)
+	i++
+	err, _ = ret[i].(error)
+	return
+}
+
+func (mr *_MockClientRecorder) TerminateAllWorkflowRuns(
+	ctx interface{},
+	_Request interface{},
+	opts ...interface{},
+) *gomock.Call {
+	args := append([]interface{}{ctx, _Request}, opts...)
+	return mr.mock.ctrl.RecordCall(mr.mock, "TerminateAllWorkflowRuns", args...)
+}
+
+// UndrainHistoryHost responds to a UndrainHistoryHost call based on the mock expectations. This
+// call will fail if the mock does not expect this call. Use EXPECT to expect
+// a call to this function.
+//
+// 	client.EXPECT().UndrainHistoryHost(gomock.Any(), ...).Return(...)
+// 	... := client.UndrainHistoryHost(...)
+func (m *MockClient) UndrainHistoryHost(
+	ctx context.Context,
+	_Request *shared.UndrainHistoryHostRequest,
+	opts ...yarpc.CallOption,
+) (success *shared.UndrainHistoryHostResponse, err error) {
+
+	args := []interface{}{ctx, _Request}
+	for _, o := range opts {
+		args = append(args, o)
+	}
+	i := 0
+	ret := m.ctrl.Call(m, "UndrainHistoryHost", args...)
+	success, _ = ret[i].(*shared.UndrainHistoryHostResponse)
+	i++
+	err, _ = ret[i].(error)
+	return
+}
+
+func (mr *_MockClientRecorder) UndrainHistoryHost(
+	ctx interface{},
+	_Request interface{},
+	opts ...interface{},
+) *gomock.Call {
+	args := append([]interface{}{ctx, _Request}, opts...)
+	return mr.mock.ctrl.RecordCall(mr.mock, "UndrainHistoryHost", args...)
+}
+
+// UpdateTaskListVersionSets responds to a UpdateTaskListVersionSets call based on the mock expectations. This
+// call will fail if the mock does not expect this call. Use EXPECT to expect
+// a call to this function.
+//
+// 	client.EXPECT().UpdateTaskListVersionSets(gomock.Any(), ...).Return(...)
+// 	... := client.UpdateTaskListVersionSets(...)
+func (m *MockClient) UpdateTaskListVersionSets(
+	ctx context.Context,
+	_Request *shared.UpdateTaskListVersionSetsRequest,
+	opts ...yarpc.CallOption,
+) (err error) {
+
+	args := []interface{}{ctx, _Request}
+	for _, o := range opts {
+		args = append(args, o)
+	}
+	i := 0
+	ret := m.ctrl.Call(m, "UpdateTaskListVersionSets", args...)
+	err, _ = ret[i].(error)
+	return
+}
+
+func (mr *_MockClientRecorder) UpdateTaskListVersionSets(
+	ctx interface{},
+	_Request interface{},
+	opts ...interface{},
+) *gomock.Call {
+	args := append([]interface{}{ctx, _Request}, opts...)
+	return mr.mock.ctrl.RecordCall(mr.mock, "UpdateTaskListVersionSets", args...)
+}
@@ -71,6 +71,12 @@ type Interface interface {
 		opts ...yarpc.CallOption,
 	) (*shared.GetSearchAttributesResponse, error)
 
+	GetTaskListScalingHints(
+		ctx context.Context,
+		Request *shared.GetTaskListScalingHintsRequest,
+		opts ...yarpc.CallOption,
+	) (*shared.GetTaskListScalingHintsResponse, error)
+
 	GetWorkflowExecutionHistory(
 		ctx context.Context,
 		GetRequest *shared.GetWorkflowExecutionHistoryRequest,
@@ -244,6 +250,12 @@ type Interface interface {
 		UpdateRequest *shared.UpdateDomainRequest,
 		opts ...yarpc.CallOption,
 	) (*shared.UpdateDomainResponse, error)
+
+	UpdateWorkflowExecution(
+		ctx context.Context,
+		UpdateRequest *shared.UpdateWorkflowExecutionRequest,
+		opts ...yarpc.CallOption,
+	) (*shared.UpdateWorkflowExecutionResponse, error)
 }
 
 // New builds a new client for the WorkflowService service.
@@ -407,6 +419,29 @@ func (c client) GetSearchAttributes(
 	return
 }
 
+func (c client) GetTaskListScalingHints(
+	ctx context.Context,
+	_Request *shared.GetTaskListScalingHintsRequest,
+	opts ...yarpc.CallOption,
+) (success *shared.GetTaskListScalingHintsResponse, err error) {
+
+	args := cadence.WorkflowService_GetTaskListScalingHints_Helper.Args(_Request)
+
+	var body wire.Value
+	body, err = c.c.Call(ctx, args, opts...)
+	if err != nil {
+		return
+	}
+
+	var result cadence.WorkflowService_GetTaskListScalingHints_Result
+	if err = result.FromWire(body); err != nil {
+		return
+	}
+
+	success, err = cadence.WorkflowService_GetTaskListScalingHints_Helper.UnwrapResponse(&result)
+	return
+}
+
 func (c client) GetWorkflowExecutionHistory(
 	ctx context.Context,
 	_GetRequest *shared.GetWorkflowExecutionHistoryRequest,
@@ -1073,3 +1108,26 @@ func (c client) UpdateDomain(
 	success, err = cadence.WorkflowService_UpdateDomain_Helper.UnwrapResponse(&result)
 	return
 }
+
+func (c client) UpdateWorkflowExecution(
+	ctx context.Context,
+	_UpdateRequest *shared.UpdateWorkflowExecutionRequest,
+	opts ...yarpc.CallOption,
+) (success *shared.UpdateWorkflowExecutionResponse, err error) {
+
+	args := cadence.WorkflowService_UpdateWorkflowExecution_Helper.Args(_UpdateRequest)
+
+	var body wire.Value
+	body, err = c.c.Call(ctx, args, opts...)
+	if err != nil {
+		return
+	}
+
+	var result cadence.WorkflowService_UpdateWorkflowExecution_Result
+	if err = result.FromWire(body); err != nil {
+		return
+	}
+
+	success, err = cadence.WorkflowService_UpdateWorkflowExecution_Helper.UnwrapResponse(&result)
+	return
+}
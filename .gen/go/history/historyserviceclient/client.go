@@ -36,6 +36,12 @@ import (
 
 // Interface is a client for the HistoryService service.
 type Interface interface {
+	DescribeClientVersions(
+		ctx context.Context,
+		Request *shared.DescribeClientVersionsRequest,
+		opts ...yarpc.CallOption,
+	) (*shared.DescribeClientVersionsResponse, error)
+
 	DescribeHistoryHost(
 		ctx context.Context,
 		Request *shared.DescribeHistoryHostRequest,
@@ -54,12 +60,42 @@ type Interface interface {
 		opts ...yarpc.CallOption,
 	) (*shared.DescribeWorkflowExecutionResponse, error)
 
+	DrainHistoryHost(
+		ctx context.Context,
+		Request *shared.DrainHistoryHostRequest,
+		opts ...yarpc.CallOption,
+	) (*shared.DrainHistoryHostResponse, error)
+
+	GetDomainOpenExecutionCount(
+		ctx context.Context,
+		Request *shared.GetDomainOpenExecutionCountRequest,
+		opts ...yarpc.CallOption,
+	) (*shared.GetDomainOpenExecutionCountResponse, error)
+
 	GetMutableState(
 		ctx context.Context,
 		GetRequest *history.GetMutableStateRequest,
 		opts ...yarpc.CallOption,
 	) (*history.GetMutableStateResponse, error)
 
+	ImportWorkflowExecution(
+		ctx context.Context,
+		ImportRequest *history.ImportWorkflowExecutionRequest,
+		opts ...yarpc.CallOption,
+	) (*history.ImportWorkflowExecutionResponse, error)
+
+	PurgeWorkflowExecution(
+		ctx context.Context,
+		PurgeRequest *history.PurgeWorkflowExecutionRequest,
+		opts ...yarpc.CallOption,
+	) error
+
+	ReconcileDomainOpenExecutionCounts(
+		ctx context.Context,
+		Request *history.ReconcileDomainOpenExecutionCountsRequest,
+		opts ...yarpc.CallOption,
+	) error
+
 	RecordActivityTaskHeartbeat(
 		ctx context.Context,
 		HeartbeatRequest *history.RecordActivityTaskHeartbeatRequest,
@@ -156,6 +192,30 @@ type Interface interface {
 		opts ...yarpc.CallOption,
 	) error
 
+	SetActivityPaused(
+		ctx context.Context,
+		PauseRequest *history.SetActivityPausedRequest,
+		opts ...yarpc.CallOption,
+	) error
+
+	SetCronSchedulePaused(
+		ctx context.Context,
+		PauseRequest *history.SetCronSchedulePausedRequest,
+		opts ...yarpc.CallOption,
+	) error
+
+	SetWorkflowExecutionNotes(
+		ctx context.Context,
+		NotesRequest *history.SetWorkflowExecutionNotesRequest,
+		opts ...yarpc.CallOption,
+	) error
+
+	SetWorkflowExecutionPaused(
+		ctx context.Context,
+		PauseRequest *history.SetWorkflowExecutionPausedRequest,
+		opts ...yarpc.CallOption,
+	) error
+
 	SignalWithStartWorkflowExecution(
 		ctx context.Context,
 		SignalWithStartRequest *history.SignalWithStartWorkflowExecutionRequest,
@@ -186,11 +246,29 @@ type Interface interface {
 		opts ...yarpc.CallOption,
 	) error
 
+	TerminateAllWorkflowRuns(
+		ctx context.Context,
+		TerminateRequest *history.TerminateAllWorkflowRunsRequest,
+		opts ...yarpc.CallOption,
+	) (*shared.TerminateAllWorkflowRunsResponse, error)
+
 	TerminateWorkflowExecution(
 		ctx context.Context,
 		TerminateRequest *history.TerminateWorkflowExecutionRequest,
 		opts ...yarpc.CallOption,
 	) error
+
+	UndrainHistoryHost(
+		ctx context.Context,
+		Request *shared.UndrainHistoryHostRequest,
+		opts ...yarpc.CallOption,
+	) (*shared.UndrainHistoryHostResponse, error)
+
+	UpdateWorkflowExecution(
+		ctx context.Context,
+		UpdateRequest *history.UpdateWorkflowExecutionRequest,
+		opts ...yarpc.CallOption,
+	) (*shared.UpdateWorkflowExecutionResponse, error)
 }
 
 // New builds a new client for the HistoryService service.
@@ -217,6 +295,29 @@ type client struct {
 	c thrift.Client
 }
 
+func (c client) DescribeClientVersions(
+	ctx context.Context,
+	_Request *shared.DescribeClientVersionsRequest,
+	opts ...yarpc.CallOption,
+) (success *shared.DescribeClientVersionsResponse, err error) {
+
+	args := history.HistoryService_DescribeClientVersions_Helper.Args(_Request)
+
+	var body wire.Value
+	body, err = c.c.Call(ctx, args, opts...)
+	if err != nil {
+		return
+	}
+
+	var result history.HistoryService_DescribeClientVersions_Result
+	if err = result.FromWire(body); err != nil {
+		return
+	}
+
+	success, err = history.HistoryService_DescribeClientVersions_Helper.UnwrapResponse(&result)
+	return
+}
+
 func (c client) DescribeHistoryHost(
 	ctx context.Context,
 	_Request *shared.DescribeHistoryHostRequest,
@@ -286,6 +387,52 @@ func (c client) DescribeWorkflowExecution(
 	return
 }
 
+func (c client) DrainHistoryHost(
+	ctx context.Context,
+	_Request *shared.DrainHistoryHostRequest,
+	opts ...yarpc.CallOption,
+) (success *shared.DrainHistoryHostResponse, err error) {
+
+	args := history.HistoryService_DrainHistoryHost_Helper.Args(_Request)
+
+	var body wire.Value
+	body, err = c.c.Call(ctx, args, opts...)
+	if err != nil {
+		return
+	}
+
+	var result history.HistoryService_DrainHistoryHost_Result
+	if err = result.FromWire(body); err != nil {
+		return
+	}
+
+	success, err = history.HistoryService_DrainHistoryHost_Helper.UnwrapResponse(&result)
+	return
+}
+
+func (c client) GetDomainOpenExecutionCount(
+	ctx context.Context,
+	_Request *shared.GetDomainOpenExecutionCountRequest,
+	opts ...yarpc.CallOption,
+) (success *shared.GetDomainOpenExecutionCountResponse, err error) {
+
+	args := history.HistoryService_GetDomainOpenExecutionCount_Helper.Args(_Request)
+
+	var body wire.Value
+	body, err = c.c.Call(ctx, args, opts...)
+	if err != nil {
+		return
+	}
+
+	var result history.HistoryService_GetDomainOpenExecutionCount_Result
+	if err = result.FromWire(body); err != nil {
+		return
+	}
+
+	success, err = history.HistoryService_GetDomainOpenExecutionCount_Helper.UnwrapResponse(&result)
+	return
+}
+
 func (c client) GetMutableState(
 	ctx context.Context,
 	_GetRequest *history.GetMutableStateRequest,
@@ -309,6 +456,75 @@ func (c client) GetMutableState(
 	return
 }
 
+func (c client) ImportWorkflowExecution(
+	ctx context.Context,
+	_ImportRequest *history.ImportWorkflowExecutionRequest,
+	opts ...yarpc.CallOption,
+) (success *history.ImportWorkflowExecutionResponse, err error) {
+
+	args := history.HistoryService_ImportWorkflowExecution_Helper.Args(_ImportRequest)
+
+	var body wire.Value
+	body, err = c.c.Call(ctx, args, opts...)
+	if err != nil {
+		return
+	}
+
+	var result history.HistoryService_ImportWorkflowExecution_Result
+	if err = result.FromWire(body); err != nil {
+		return
+	}
+
+	success, err = history.HistoryService_ImportWorkflowExecution_Helper.UnwrapResponse(&result)
+	return
+}
+
+func (c client) PurgeWorkflowExecution(
+	ctx context.Context,
+	_PurgeRequest *history.PurgeWorkflowExecutionRequest,
+	opts ...yarpc.CallOption,
+) (err error) {
+
+	args := history.HistoryService_PurgeWorkflowExecution_Helper.Args(_PurgeRequest)
+
+	var body wire.Value
+	body, err = c.c.Call(ctx, args, opts...)
+	if err != nil {
+		return
+	}
+
+	var result history.HistoryService_PurgeWorkflowExecution_Result
+	if err = result.FromWire(body); err != nil {
+		return
+	}
+
+	err = history.HistoryService_PurgeWorkflowExecution_Helper.UnwrapResponse(&result)
+	return
+}
+
+func (c client) ReconcileDomainOpenExecutionCounts(
+	ctx context.Context,
+	_Request *history.ReconcileDomainOpenExecutionCountsRequest,
+	opts ...yarpc.CallOption,
+) (err error) {
+
+	args := history.HistoryService_ReconcileDomainOpenExecutionCounts_Helper.Args(_Request)
+
+	var body wire.Value
+	body, err = c.c.Call(ctx, args, opts...)
+	if err != nil {
+		return
+	}
+
+	var result history.HistoryService_ReconcileDomainOpenExecutionCounts_Result
+	if err = result.FromWire(body); err != nil {
+		return
+	}
+
+	err = history.HistoryService_ReconcileDomainOpenExecutionCounts_Helper.UnwrapResponse(&result)
+	return
+}
+
 func (c client) RecordActivityTaskHeartbeat(
 	ctx context.Context,
 	_HeartbeatRequest *history.RecordActivityTaskHeartbeatRequest,
@@ -677,6 +893,98 @@ func (c client) ScheduleDecisionTask(
 	return
 }
 
+func (c client) SetActivityPaused(
+	ctx context.Context,
+	_PauseRequest *history.SetActivityPausedRequest,
+	opts ...yarpc.CallOption,
+) (err error) {
+
+	args := history.HistoryService_SetActivityPaused_Helper.Args(_PauseRequest)
+
+	var body wire.Value
+	body, err = c.c.Call(ctx, args, opts...)
+	if err != nil {
+		return
+	}
+
+	var result history.HistoryService_SetActivityPaused_Result
+	if err = result.FromWire(body); err != nil {
+		return
+	}
+
+	err = history.HistoryService_SetActivityPaused_Helper.UnwrapResponse(&result)
+	return
+}
+
+func (c client) SetCronSchedulePaused(
+	ctx context.Context,
+	_PauseRequest *history.SetCronSchedulePausedRequest,
+	opts ...yarpc.CallOption,
+) (err error) {
+
+	args := history.HistoryService_SetCronSchedulePaused_Helper.Args(_PauseRequest)
+
+	var body wire.Value
+	body, err = c.c.Call(ctx, args, opts...)
+	if err != nil {
+		return
+	}
+
+	var result history.HistoryService_SetCronSchedulePaused_Result
+	if err = result.FromWire(body); err != nil {
+		return
+	}
+
+	err = history.HistoryService_SetCronSchedulePaused_Helper.UnwrapResponse(&result)
+	return
+}
+
+func (c client) SetWorkflowExecutionNotes(
+	ctx context.Context,
+	_NotesRequest *history.SetWorkflowExecutionNotesRequest,
+	opts ...yarpc.CallOption,
+) (err error) {
+
+	args := history.HistoryService_SetWorkflowExecutionNotes_Helper.Args(_NotesRequest)
+
+	var body wire.Value
+	body, err = c.c.Call(ctx, args, opts...)
+	if err != nil {
+		return
+	}
+
+	var result history.HistoryService_SetWorkflowExecutionNotes_Result
+	if err = result.FromWire(body); err != nil {
+		return
+	}
+
+	err = history.HistoryService_SetWorkflowExecutionNotes_Helper.UnwrapResponse(&result)
+	return
+}
+
+func (c client) SetWorkflowExecutionPaused(
+	ctx context.Context,
+	_PauseRequest *history.SetWorkflowExecutionPausedRequest,
+	opts ...yarpc.CallOption,
+) (err error) {
+
+	args := history.HistoryService_SetWorkflowExecutionPaused_Helper.Args(_PauseRequest)
+
+	var body wire.Value
+	body, err = c.c.Call(ctx, args, opts...)
+	if err != nil {
+		return
+	}
+
+	var result history.HistoryService_SetWorkflowExecutionPaused_Result
+	if err = result.FromWire(body); err != nil {
+		return
+	}
+
+	err = history.HistoryService_SetWorkflowExecutionPaused_Helper.UnwrapResponse(&result)
+	return
+}
+
 func (c client) SignalWithStartWorkflowExecution(
 	ctx context.Context,
 	_SignalWithStartRequest *history.SignalWithStartWorkflowExecutionRequest,
@@ -792,6 +1100,29 @@ func (c client) SyncShardStatus(
 	return
 }
 
+func (c client) TerminateAllWorkflowRuns(
+	ctx context.Context,
+	_TerminateRequest *history.TerminateAllWorkflowRunsRequest,
+	opts ...yarpc.CallOption,
+) (success *shared.TerminateAllWorkflowRunsResponse, err error) {
+
+	args := history.HistoryService_TerminateAllWorkflowRuns_Helper.Args(_TerminateRequest)
+
+	var body wire.Value
+	body, err = c.c.Call(ctx, args, opts...)
+	if err != nil {
+		return
+	}
+
+	var result history.HistoryService_TerminateAllWorkflowRuns_Result
+	if err = result.FromWire(body); err != nil {
+		return
+	}
+
+	success, err = history.HistoryService_TerminateAllWorkflowRuns_Helper.UnwrapResponse(&result)
+	return
+}
+
 func (c client) TerminateWorkflowExecution(
 	ctx context.Context,
 	_TerminateRequest *history.TerminateWorkflowExecutionRequest,
@@ -814,3 +1145,49 @@ func (c client) TerminateWorkflowExecution(
 	err = history.HistoryService_TerminateWorkflowExecution_Helper.UnwrapResponse(&result)
 	return
 }
+
+func (c client) UndrainHistoryHost(
+	ctx context.Context,
+	_Request *shared.UndrainHistoryHostRequest,
+	opts ...yarpc.CallOption,
+) (success *shared.UndrainHistoryHostResponse, err error) {
+
+	args := history.HistoryService_UndrainHistoryHost_Helper.Args(_Request)
+
+	var body wire.Value
+	body, err = c.c.Call(ctx, args, opts...)
+	if err != nil {
+		return
+	}
+
+	var result history.HistoryService_UndrainHistoryHost_Result
+	if err = result.FromWire(body); err != nil {
+		return
+	}
+
+	success, err = history.HistoryService_UndrainHistoryHost_Helper.UnwrapResponse(&result)
+	return
+}
+
+func (c client) UpdateWorkflowExecution(
+	ctx context.Context,
+	_UpdateRequest *history.UpdateWorkflowExecutionRequest,
+	opts ...yarpc.CallOption,
+) (success *shared.UpdateWorkflowExecutionResponse, err error) {
+
+	args := history.HistoryService_UpdateWorkflowExecution_Helper.Args(_UpdateRequest)
+
+	var body wire.Value
+	body, err = c.c.Call(ctx, args, opts...)
+	if err != nil {
+		return
+	}
+
+	var result history.HistoryService_UpdateWorkflowExecution_Result
+	if err = result.FromWire(body); err != nil {
+		return
+	}
+
+	success, err = history.HistoryService_UpdateWorkflowExecution_Helper.UnwrapResponse(&result)
+	return
+}
@@ -34,6 +34,11 @@ import (
 
 // Interface is the server-side interface for the HistoryService service.
 type Interface interface {
+	DescribeClientVersions(
+		ctx context.Context,
+		Request *shared.DescribeClientVersionsRequest,
+	) (*shared.DescribeClientVersionsResponse, error)
+
 	DescribeHistoryHost(
 		ctx context.Context,
 		Request *shared.DescribeHistoryHostRequest,
@@ -49,11 +54,36 @@ type Interface interface {
 		DescribeRequest *history.DescribeWorkflowExecutionRequest,
 	) (*shared.DescribeWorkflowExecutionResponse, error)
 
+	DrainHistoryHost(
+		ctx context.Context,
+		Request *shared.DrainHistoryHostRequest,
+	) (*shared.DrainHistoryHostResponse, error)
+
+	GetDomainOpenExecutionCount(
+		ctx context.Context,
+		Request *shared.GetDomainOpenExecutionCountRequest,
+	) (*shared.GetDomainOpenExecutionCountResponse, error)
+
 	GetMutableState(
 		ctx context.Context,
 		GetRequest *history.GetMutableStateRequest,
 	) (*history.GetMutableStateResponse, error)
 
+	ImportWorkflowExecution(
+		ctx context.Context,
+		ImportRequest *history.ImportWorkflowExecutionRequest,
+	) (*history.ImportWorkflowExecutionResponse, error)
+
+	PurgeWorkflowExecution(
+		ctx context.Context,
+		PurgeRequest *history.PurgeWorkflowExecutionRequest,
+	) error
+
+	ReconcileDomainOpenExecutionCounts(
+		ctx context.Context,
+		Request *history.ReconcileDomainOpenExecutionCountsRequest,
+	) error
+
 	RecordActivityTaskHeartbeat(
 		ctx context.Context,
 		HeartbeatRequest *history.RecordActivityTaskHeartbeatRequest,
@@ -134,6 +164,26 @@ type Interface interface {
 		ScheduleRequest *history.ScheduleDecisionTaskRequest,
 	) error
 
+	SetActivityPaused(
+		ctx context.Context,
+		PauseRequest *history.SetActivityPausedRequest,
+	) error
+
+	SetCronSchedulePaused(
+		ctx context.Context,
+		PauseRequest *history.SetCronSchedulePausedRequest,
+	) error
+
+	SetWorkflowExecutionNotes(
+		ctx context.Context,
+		NotesRequest *history.SetWorkflowExecutionNotesRequest,
+	) error
+
+	SetWorkflowExecutionPaused(
+		ctx context.Context,
+		PauseRequest *history.SetWorkflowExecutionPausedRequest,
+	) error
+
 	SignalWithStartWorkflowExecution(
 		ctx context.Context,
 		SignalWithStartRequest *history.SignalWithStartWorkflowExecutionRequest,
@@ -159,10 +209,25 @@ type Interface interface {
 		SyncShardStatusRequest *history.SyncShardStatusRequest,
 	) error
 
+	TerminateAllWorkflowRuns(
+		ctx context.Context,
+		TerminateRequest *history.TerminateAllWorkflowRunsRequest,
+	) (*shared.TerminateAllWorkflowRunsResponse, error)
+
 	TerminateWorkflowExecution(
 		ctx context.Context,
 		TerminateRequest *history.TerminateWorkflowExecutionRequest,
 	) error
+
+	UndrainHistoryHost(
+		ctx context.Context,
+		Request *shared.UndrainHistoryHostRequest,
+	) (*shared.UndrainHistoryHostResponse, error)
+
+	UpdateWorkflowExecution(
+		ctx context.Context,
+		UpdateRequest *history.UpdateWorkflowExecutionRequest,
+	) (*shared.UpdateWorkflowExecutionResponse, error)
 }
 
 // New prepares an implementation of the HistoryService service for
@@ -176,6 +241,17 @@ func New(impl Interface, opts ...thrift.RegisterOption) []transport.Procedure {
 		Name: "HistoryService",
 		Methods: []thrift.Method{
 
+			thrift.Method{
+				Name: "DescribeClientVersions",
+				HandlerSpec: thrift.HandlerSpec{
+
+					Type:  transport.Unary,
+					Unary: thrift.UnaryHandler(h.DescribeClientVersions),
+				},
+				Signature:    "DescribeClientVersions(Request *shared.DescribeClientVersionsRequest) (*shared.DescribeClientVersionsResponse)",
+				ThriftModule: history.ThriftModule,
+			},
+
 			thrift.Method{
 				Name: "DescribeHistoryHost",
 				HandlerSpec: thrift.HandlerSpec{
@@ -209,6 +285,28 @@ func New(impl Interface, opts ...thrift.RegisterOption) []transport.Procedure {
 				ThriftModule: history.ThriftModule,
 			},
 
+			thrift.Method{
+				Name: "DrainHistoryHost",
+				HandlerSpec: thrift.HandlerSpec{
+
+					Type:  transport.Unary,
+					Unary: thrift.UnaryHandler(h.DrainHistoryHost),
+				},
+				Signature:    "DrainHistoryHost(Request *shared.DrainHistoryHostRequest) (*shared.DrainHistoryHostResponse)",
+				ThriftModule: history.ThriftModule,
+			},
+
+			thrift.Method{
+				Name: "GetDomainOpenExecutionCount",
+				HandlerSpec: thrift.HandlerSpec{
+
+					Type:  transport.Unary,
+					Unary: thrift.UnaryHandler(h.GetDomainOpenExecutionCount),
+				},
+				Signature:    "GetDomainOpenExecutionCount(Request *shared.GetDomainOpenExecutionCountRequest) (*shared.GetDomainOpenExecutionCountResponse)",
+				ThriftModule: history.ThriftModule,
+			},
+
 			thrift.Method{
 				Name: "GetMutableState",
 				HandlerSpec: thrift.HandlerSpec{
@@ -220,6 +318,39 @@ func New(impl Interface, opts ...thrift.RegisterOption) []transport.Procedure {
 				ThriftModule: history.ThriftModule,
 			},
 
+			thrift.Method{
+				Name: "ImportWorkflowExecution",
+				HandlerSpec: thrift.HandlerSpec{
+
+					Type:  transport.Unary,
+					Unary: thrift.UnaryHandler(h.ImportWorkflowExecution),
+				},
+				Signature:    "ImportWorkflowExecution(ImportRequest *history.ImportWorkflowExecutionRequest) (*history.ImportWorkflowExecutionResponse)",
+				ThriftModule: history.ThriftModule,
+			},
+
+			thrift.Method{
+				Name: "PurgeWorkflowExecution",
+				HandlerSpec: thrift.HandlerSpec{
+
+					Type:  transport.Unary,
+					Unary: thrift.UnaryHandler(h.PurgeWorkflowExecution),
+				},
+				Signature:    "PurgeWorkflowExecution(PurgeRequest *history.PurgeWorkflowExecutionRequest)",
+				ThriftModule: history.ThriftModule,
+			},
+
+			thrift.Method{
+				Name: "ReconcileDomainOpenExecutionCounts",
+				HandlerSpec: thrift.HandlerSpec{
+
+					Type:  transport.Unary,
+					Unary: thrift.UnaryHandler(h.ReconcileDomainOpenExecutionCounts),
+				},
+				Signature:    "ReconcileDomainOpenExecutionCounts(Request *history.ReconcileDomainOpenExecutionCountsRequest)",
+				ThriftModule: history.ThriftModule,
+			},
+
 			thrift.Method{
 				Name: "RecordActivityTaskHeartbeat",
 				HandlerSpec: thrift.HandlerSpec{
@@ -396,6 +527,50 @@ func New(impl Interface, opts ...thrift.RegisterOption) []transport.Procedure {
 				ThriftModule: history.ThriftModule,
 			},
 
+			thrift.Method{
+				Name: "SetActivityPaused",
+				HandlerSpec: thrift.HandlerSpec{
+
+					Type:  transport.Unary,
+					Unary: thrift.UnaryHandler(h.SetActivityPaused),
+				},
+				Signature:    "SetActivityPaused(PauseRequest *history.SetActivityPausedRequest)",
+				ThriftModule: history.ThriftModule,
+			},
+
+			thrift.Method{
+				Name: "SetCronSchedulePaused",
+				HandlerSpec: thrift.HandlerSpec{
+
+					Type:  transport.Unary,
+					Unary: thrift.UnaryHandler(h.SetCronSchedulePaused),
+				},
+				Signature:    "SetCronSchedulePaused(PauseRequest *history.SetCronSchedulePausedRequest)",
+				ThriftModule: history.ThriftModule,
+			},
+
+			thrift.Method{
+				Name: "SetWorkflowExecutionNotes",
+				HandlerSpec: thrift.HandlerSpec{
+
+					Type:  transport.Unary,
+					Unary: thrift.UnaryHandler(h.SetWorkflowExecutionNotes),
+				},
+				Signature:    "SetWorkflowExecutionNotes(NotesRequest *history.SetWorkflowExecutionNotesRequest)",
+				ThriftModule: history.ThriftModule,
+			},
+
+			thrift.Method{
+				Name: "SetWorkflowExecutionPaused",
+				HandlerSpec: thrift.HandlerSpec{
+
+					Type:  transport.Unary,
+					Unary: thrift.UnaryHandler(h.SetWorkflowExecutionPaused),
+				},
+				Signature:    "SetWorkflowExecutionPaused(PauseRequest *history.SetWorkflowExecutionPausedRequest)",
+				ThriftModule: history.ThriftModule,
+			},
+
 			thrift.Method{
 				Name: "SignalWithStartWorkflowExecution",
 				HandlerSpec: thrift.HandlerSpec{
@@ -451,6 +626,17 @@ func New(impl Interface, opts ...thrift.RegisterOption) []transport.Procedure {
 				ThriftModule: history.ThriftModule,
 			},
 
+			thrift.Method{
+				Name: "TerminateAllWorkflowRuns",
+				HandlerSpec: thrift.HandlerSpec{
+
+					Type:  transport.Unary,
+					Unary: thrift.UnaryHandler(h.TerminateAllWorkflowRuns),
+				},
+				Signature:    "TerminateAllWorkflowRuns(TerminateRequest *history.TerminateAllWorkflowRunsRequest) (*shared.TerminateAllWorkflowRunsResponse)",
+				ThriftModule: history.ThriftModule,
+			},
+
 			thrift.Method{
 				Name: "TerminateWorkflowExecution",
 				HandlerSpec: thrift.HandlerSpec{
@@ -461,16 +647,57 @@ func New(impl Interface, opts ...thrift.RegisterOption) []transport.Procedure {
 				Signature:    "TerminateWorkflowExecution(TerminateRequest *history.TerminateWorkflowExecutionRequest)",
 				ThriftModule: history.ThriftModule,
 			},
+
+			thrift.Method{
+				Name: "UndrainHistoryHost",
+				HandlerSpec: thrift.HandlerSpec{
+
+					Type:  transport.Unary,
+					Unary: thrift.UnaryHandler(h.UndrainHistoryHost),
+				},
+				Signature:    "UndrainHistoryHost(Request *shared.UndrainHistoryHostRequest) (*shared.UndrainHistoryHostResponse)",
+				ThriftModule: history.ThriftModule,
+			},
+
+			thrift.Method{
+				Name: "UpdateWorkflowExecution",
+				HandlerSpec: thrift.HandlerSpec{
+
+					Type:  transport.Unary,
+					Unary: thrift.UnaryHandler(h.UpdateWorkflowExecution),
+				},
+				Signature:    "UpdateWorkflowExecution(UpdateRequest *history.UpdateWorkflowExecutionRequest) (*shared.UpdateWorkflowExecutionResponse)",
+				ThriftModule: history.ThriftModule,
+			},
 		},
 	}
 
-	procedures := make([]transport.Procedure, 0, 26)
+	procedures := make([]transport.Procedure, 0, 39)
 	procedures = append(procedures, thrift.BuildProcedures(service, opts...)...)
 	return procedures
 }
 
 type handler struct{ impl Interface }
 
+func (h handler) DescribeClientVersions(ctx context.Context, body wire.Value) (thrift.Response, error) {
+	var args history.HistoryService_DescribeClientVersions_Args
+	if err := args.FromWire(body); err != nil {
+		return thrift.Response{}, err
+	}
+
+	success, err := h.impl.DescribeClientVersions(ctx, args.Request)
+
+	hadError := err != nil
+	result, err := history.HistoryService_DescribeClientVersions_Helper.WrapResponse(success, err)
+
+	var response thrift.Response
+	if err == nil {
+		response.IsApplicationError = hadError
+		response.Body = result
+	}
+	return response, err
+}
+
 func (h handler) DescribeHistoryHost(ctx context.Context, body wire.Value) (thrift.Response, error) {
 	var args history.HistoryService_DescribeHistoryHost_Args
 	if err := args.FromWire(body); err != nil {
@@ -528,6 +755,44 @@ func (h handler) DescribeWorkflowExecution(ctx context.Context, body wire.Value)
 	return response, err
 }
 
+func (h handler) DrainHistoryHost(ctx context.Context, body wire.Value) (thrift.Response, error) {
+	var args history.HistoryService_DrainHistoryHost_Args
+	if err := args.FromWire(body); err != nil {
+		return thrift.Response{}, err
+	}
+
+	success, err := h.impl.DrainHistoryHost(ctx, args.Request)
+
+	hadError := err != nil
+	result, err := history.HistoryService_DrainHistoryHost_Helper.WrapResponse(success, err)
+
+	var response thrift.Response
+	if err == nil {
+		response.IsApplicationError = hadError
+		response.Body = result
+	}
+	return response, err
+}
+
+func (h handler) GetDomainOpenExecutionCount(ctx context.Context, body wire.Value) (thrift.Response, error) {
+	var args history.HistoryService_GetDomainOpenExecutionCount_Args
+	if err := args.FromWire(body); err != nil {
+		return thrift.Response{}, err
+	}
+
+	success, err := h.impl.GetDomainOpenExecutionCount(ctx, args.Request)
+
+	hadError := err != nil
+	result, err := history.HistoryService_GetDomainOpenExecutionCount_Helper.WrapResponse(success, err)
+
+	var response thrift.Response
+	if err == nil {
+		response.IsApplicationError = hadError
+		response.Body = result
+	}
+	return response, err
+}
+
 func (h handler) GetMutableState(ctx context.Context, body wire.Value) (thrift.Response, error) {
 	var args history.HistoryService_GetMutableState_Args
 	if err := args.FromWire(body); err != nil {
@@ -547,6 +812,63 @@ func (h handler) GetMutableState(ctx context.Context, body wire.Value) (thrift.R
 	return response, err
 }
 
+func (h handler) ImportWorkflowExecution(ctx context.Context, body wire.Value) (thrift.Response, error) {
+	var args history.HistoryService_ImportWorkflowExecution_Args
+	if err := args.FromWire(body); err != nil {
+		return thrift.Response{}, err
+	}
+
+	success, err := h.impl.ImportWorkflowExecution(ctx, args.ImportRequest)
+
+	hadError := err != nil
+	result, err := history.HistoryService_ImportWorkflowExecution_Helper.WrapResponse(success, err)
+
+	var response thrift.Response
+	if err == nil {
+		response.IsApplicationError = hadError
+		response.Body = result
+	}
+	return response, err
+}
+
+func (h handler) PurgeWorkflowExecution(ctx context.Context, body wire.Value) (thrift.Response, error) {
+	var args history.HistoryService_PurgeWorkflowExecution_Args
+	if err := args.FromWire(body); err != nil {
+		return thrift.Response{}, err
+	}
+
+	err := h.impl.PurgeWorkflowExecution(ctx, args.PurgeRequest)
+
+	hadError := err != nil
+	result, err := history.HistoryService_PurgeWorkflowExecution_Helper.WrapResponse(err)
+
+	var response thrift.Response
+	if err == nil {
+		response.IsApplicationError = hadError
+		response.Body = result
+	}
+	return response, err
+}
+
+func (h handler) ReconcileDomainOpenExecutionCounts(ctx context.Context, body wire.Value) (thrift.Response, error) {
+	var args history.HistoryService_ReconcileDomainOpenExecutionCounts_Args
+	if err := args.FromWire(body); err != nil {
+		return thrift.Response{}, err
+	}
+
+	err := h.impl.ReconcileDomainOpenExecutionCounts(ctx, args.Request)
+
+	hadError := err != nil
+	result, err := history.HistoryService_ReconcileDomainOpenExecutionCounts_Helper.WrapResponse(err)
+
+	var response thrift.Response
+	if err == nil {
+		response.IsApplicationError = hadError
+		response.Body = result
+	}
+	return response, err
+}
+
 func (h handler) RecordActivityTaskHeartbeat(ctx context.Context, body wire.Value) (thrift.Response, error) {
 	var args history.HistoryService_RecordActivityTaskHeartbeat_Args
 	if err := args.FromWire(body); err != nil {
@@ -851,6 +1173,82 @@ func (h handler) ScheduleDecisionTask(ctx context.Context, body wire.Value) (thr
 	return response, err
 }
 
+func (h handler) SetActivityPaused(ctx context.Context, body wire.Value) (thrift.Response, error) {
+	var args history.HistoryService_SetActivityPaused_Args
+	if err := args.FromWire(body); err != nil {
+		return thrift.Response{}, err
+	}
+
+	err := h.impl.SetActivityPaused(ctx, args.PauseRequest)
+
+	hadError := err != nil
+	result, err := history.HistoryService_SetActivityPaused_Helper.WrapResponse(err)
+
+	var response thrift.Response
+	if err == nil {
+		response.IsApplicationError = hadError
+		response.Body = result
+	}
+	return response, err
+}
+
+func (h handler) SetCronSchedulePaused(ctx context.Context, body wire.Value) (thrift.Response, error) {
+	var args history.HistoryService_SetCronSchedulePaused_Args
+	if err := args.FromWire(body); err != nil {
+		return thrift.Response{}, err
+	}
+
+	err := h.impl.SetCronSchedulePaused(ctx, args.PauseRequest)
+
+	hadError := err != nil
+	result, err := history.HistoryService_SetCronSchedulePaused_Helper.WrapResponse(err)
+
+	var response thrift.Response
+	if err == nil {
+		response.IsApplicationError = hadError
+		response.Body = result
+	}
+	return response, err
+}
+
+func (h handler) SetWorkflowExecutionNotes(ctx context.Context, body wire.Value) (thrift.Response, error) {
+	var args history.HistoryService_SetWorkflowExecutionNotes_Args
+	if err := args.FromWire(body); err != nil {
+		return thrift.Response{}, err
+	}
+
+	err := h.impl.SetWorkflowExecutionNotes(ctx, args.NotesRequest)
+
+	hadError := err != nil
+	result, err := history.HistoryService_SetWorkflowExecutionNotes_Helper.WrapResponse(err)
+
+	var response thrift.Response
+	if err == nil {
+		response.IsApplicationError = hadError
+		response.Body = result
+	}
+	return response, err
+}
+
+func (h handler) SetWorkflowExecutionPaused(ctx context.Context, body wire.Value) (thrift.Response, error) {
+	var args history.HistoryService_SetWorkflowExecutionPaused_Args
+	if err := args.FromWire(body); err != nil {
+		return thrift.Response{}, err
+	}
+
+	err := h.impl.SetWorkflowExecutionPaused(ctx, args.PauseRequest)
+
+	hadError := err != nil
+	result, err := history.HistoryService_SetWorkflowExecutionPaused_Helper.WrapResponse(err)
+
+	var response thrift.Response
+	if err == nil {
+		response.IsApplicationError = hadError
+		response.Body = result
+	}
+	return response, err
+}
+
 func (h handler) SignalWithStartWorkflowExecution(ctx context.Context, body wire.Value) (thrift.Response, error) {
 	var args history.HistoryService_SignalWithStartWorkflowExecution_Args
 	if err := args.FromWire(body); err != nil {
@@ -946,6 +1344,25 @@ func (h handler) SyncShardStatus(ctx context.Context, body wire.Value) (thrift.R
 	return response, err
 }
 
+func (h handler) TerminateAllWorkflowRuns(ctx context.Context, body wire.Value) (thrift.Response, error) {
+	var args history.HistoryService_TerminateAllWorkflowRuns_Args
+	if err := args.FromWire(body); err != nil {
+		return thrift.Response{}, err
+	}
+
+	success, err := h.impl.TerminateAllWorkflowRuns(ctx, args.TerminateRequest)
+
+	hadError := err != nil
+	result, err := history.HistoryService_TerminateAllWorkflowRuns_Helper.WrapResponse(success, err)
+
+	var response thrift.Response
+	if err == nil {
+		response.IsApplicationError = hadError
+		response.Body = result
+	}
+	return response, err
+}
+
 func (h handler) TerminateWorkflowExecution(ctx context.Context, body wire.Value) (thrift.Response, error) {
 	var args history.HistoryService_TerminateWorkflowExecution_Args
 	if err := args.FromWire(body); err != nil {
@@ -964,3 +1381,41 @@ func (h handler) TerminateWorkflowExecution(ctx context.Context, body wire.Value
 	}
 	return response, err
 }
+
+func (h handler) UndrainHistoryHost(ctx context.Context, body wire.Value) (thrift.Response, error) {
+	var args history.HistoryService_UndrainHistoryHost_Args
+	if err := args.FromWire(body); err != nil {
+		return thrift.Response{}, err
+	}
+
+	success, err := h.impl.UndrainHistoryHost(ctx, args.Request)
+
+	hadError := err != nil
+	result, err := history.HistoryService_UndrainHistoryHost_Helper.WrapResponse(success, err)
+
+	var response thrift.Response
+	if err == nil {
+		response.IsApplicationError = hadError
+		response.Body = result
+	}
+	return response, err
+}
+
+func (h handler) UpdateWorkflowExecution(ctx context.Context, body wire.Value) (thrift.Response, error) {
+	var args history.HistoryService_UpdateWorkflowExecution_Args
+	if err := args.FromWire(body); err != nil {
+		return thrift.Response{}, err
+	}
+
+	success, err := h.impl.UpdateWorkflowExecution(ctx, args.UpdateRequest)
+
+	hadError := err != nil
+	result, err := history.HistoryService_UpdateWorkflowExecution_Helper.WrapResponse(success, err)
+
+	var response thrift.Response
+	if err == nil {
+		response.IsApplicationError = hadError
+		response.Body = result
+	}
+	return response, err
+}
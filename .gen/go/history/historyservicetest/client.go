@@ -63,6 +63,39 @@ func (m *MockClient) EXPECT() *_MockClientRecorder {
 	return m.recorder
 }
 
+// DescribeClientVersions responds to a DescribeClientVersions call based on the mock expectations. This
+// call will fail if the mock does not expect this call. Use EXPECT to expect
+// a call to this function.
+//
+// 	client.EXPECT().DescribeClientVersions(gomock.Any(), ...).Return(...)
+// 	... := client.DescribeClientVersions(...)
+func (m *MockClient) DescribeClientVersions(
+	ctx context.Context,
+	_Request *shared.DescribeClientVersionsRequest,
+	opts ...yarpc.CallOption,
+) (success *shared.DescribeClientVersionsResponse, err error) {
+
+	args := []interface{}{ctx, _Request}
+	for _, o := range opts {
+		args = append(args, o)
+	}
+	i := 0
+	ret := m.ctrl.Call(m, "DescribeClientVersions", args...)
+	success, _ = ret[i].(*shared.DescribeClientVersionsResponse)
+	i++
+	err, _ = ret[i].(error)
+	return
+}
+
+func (mr *_MockClientRecorder) DescribeClientVersions(
+	ctx interface{},
+	_Request interface{},
+	opts ...interface{},
+) *gomock.Call {
+	args := append([]interface{}{ctx, _Request}, opts...)
+	return mr.mock.ctrl.RecordCall(mr.mock, "DescribeClientVersions", args...)
+}
+
 // DescribeHistoryHost responds to a DescribeHistoryHost call based on the mock expectations. This
 // call will fail if the mock does not expect this call. Use EXPECT to expect
 // a call to this function.
@@ -162,6 +195,72 @@ func (mr *_MockClientRecorder) DescribeWorkflowExecution(
 	return mr.mock.ctrl.RecordCall(mr.mock, "DescribeWorkflowExecution", args...)
 }
 
+// DrainHistoryHost responds to a DrainHistoryHost call based on the mock expectations. This
+// call will fail if the mock does not expect this call. Use EXPECT to expect
+// a call to this function.
+//
+// 	client.EXPECT().DrainHistoryHost(gomock.Any(), ...).Return(...)
+// 	... := client.DrainHistoryHost(...)
+func (m *MockClient) DrainHistoryHost(
+	ctx context.Context,
+	_Request *shared.DrainHistoryHostRequest,
+	opts ...yarpc.CallOption,
+) (success *shared.DrainHistoryHostResponse, err error) {
+
+	args := []interface{}{ctx, _Request}
+	for _, o := range opts {
+		args = append(args, o)
+	}
+	i := 0
+	ret := m.ctrl.Call(m, "DrainHistoryHost", args...)
+	success, _ = ret[i].(*shared.DrainHistoryHostResponse)
+	i++
+	err, _ = ret[i].(error)
+	return
+}
+
+func (mr *_MockClientRecorder) DrainHistoryHost(
+	ctx interface{},
+	_Request interface{},
+	opts ...interface{},
+) *gomock.Call {
+	args := append([]interface{}{ctx, _Request}, opts...)
+	return mr.mock.ctrl.RecordCall(mr.mock, "DrainHistoryHost", args...)
+}
+
+// GetDomainOpenExecutionCount responds to a GetDomainOpenExecutionCount call based on the mock expectations. This
+// call will fail if the mock does not expect this call. Use EXPECT to expect
+// a call to this function.
+//
+// 	client.EXPECT().GetDomainOpenExecutionCount(gomock.Any(), ...).Return(...)
+// 	... := client.GetDomainOpenExecutionCount(...)
+func (m *MockClient) GetDomainOpenExecutionCount(
+	ctx context.Context,
+	_Request *shared.GetDomainOpenExecutionCountRequest,
+	opts ...yarpc.CallOption,
+) (success *shared.GetDomainOpenExecutionCountResponse, err error) {
+
+	args := []interface{}{ctx, _Request}
+	for _, o := range opts {
+		args = append(args, o)
+	}
+	i := 0
+	ret := m.ctrl.Call(m, "GetDomainOpenExecutionCount", args...)
+	success, _ = ret[i].(*shared.GetDomainOpenExecutionCountResponse)
+	i++
+	err, _ = ret[i].(error)
+	return
+}
+
+func (mr *_MockClientRecorder) GetDomainOpenExecutionCount(
+	ctx interface{},
+	_Request interface{},
+	opts ...interface{},
+) *gomock.Call {
+	args := append([]interface{}{ctx, _Request}, opts...)
+	return mr.mock.ctrl.RecordCall(mr.mock, "GetDomainOpenExecutionCount", args...)
+}
+
 // GetMutableState responds to a GetMutableState call based on the mock expectations. This
 // call will fail if the mock does not expect this call. Use EXPECT to expect
 // a call to this function.
@@ -195,6 +294,101 @@ func (mr *_MockClientRecorder) GetMutableState(
 	return mr.mock.ctrl.RecordCall(mr.mock, "GetMutableState", args...)
 }
 
+// ImportWorkflowExecution responds to a ImportWorkflowExecution call based on the mock expectations. This
+// call will fail if the mock does not expect this call. Use EXPECT to expect
+// a call to this function.
+//
+// 	client.EXPECT().ImportWorkflowExecution(gomock.Any(), ...).Return(...)
+// 	... := client.ImportWorkflowExecution(...)
+func (m *MockClient) ImportWorkflowExecution(
+	ctx context.Context,
+	_ImportRequest *history.ImportWorkflowExecutionRequest,
+	opts ...yarpc.CallOption,
+) (success *history.ImportWorkflowExecutionResponse, err error) {
+
+	args := []interface{}{ctx, _ImportRequest}
+	for _, o := range opts {
+		args = append(args, o)
+	}
+	i := 0
+	ret := m.ctrl.Call(m, "ImportWorkflowExecution", args...)
+	success, _ = ret[i].(*history.ImportWorkflowExecutionResponse)
+	i++
+	err, _ = ret[i].(error)
+	return
+}
+
+func (mr *_MockClientRecorder) ImportWorkflowExecution(
+	ctx interface{},
+	_ImportRequest interface{},
+	opts ...interface{},
+) *gomock.Call {
+	args := append([]interface{}{ctx, _ImportRequest}, opts...)
+	return mr.mock.ctrl.RecordCall(mr.mock, "ImportWorkflowExecution", args...)
+}
+
+// PurgeWorkflowExecution responds to a PurgeWorkflowExecution call based on the mock expectations. This
+// call will fail if the mock does not expect this call. Use EXPECT to expect
+// a call to this function.
+//
+// 	client.EXPECT().PurgeWorkflowExecution(gomock.Any(), ...).Return(...)
+// 	... := client.PurgeWorkflowExecution(...)
+func (m *MockClient) PurgeWorkflowExecution(
+	ctx context.Context,
+	_PurgeRequest *history.PurgeWorkflowExecutionRequest,
+	opts ...yarpc.CallOption,
+) (err error) {
+
+	args := []interface{}{ctx, _PurgeRequest}
+	for _, o := range opts {
+		args = append(args, o)
+	}
+	i := 0
+	ret := m.ctrl.Call(m, "PurgeWorkflowExecution", args...)
+	err, _ = ret[i].(error)
+	return
+}
+
+func (mr *_MockClientRecorder) PurgeWorkflowExecution(
+	ctx interface{},
+	_PurgeRequest interface{},
+	opts ...interface{},
+) *gomock.Call {
+	args := append([]interface{}{ctx, _PurgeRequest}, opts...)
+	return mr.mock.ctrl.RecordCall(mr.mock, "PurgeWorkflowExecution", args...)
+}
+
+// ReconcileDomainOpenExecutionCounts responds to a ReconcileDomainOpenExecutionCounts call based on the mock expectations. This
+// call will fail if the mock does not expect this call. Use EXPECT to expect
+// a call to this function.
+//
+// 	client.EXPECT().ReconcileDomainOpenExecutionCounts(gomock.Any(), ...).Return(...)
+// 	... := client.ReconcileDomainOpenExecutionCounts(...)
+func (m *MockClient) ReconcileDomainOpenExecutionCounts(
+	ctx context.Context,
+	_Request *history.ReconcileDomainOpenExecutionCountsRequest,
+	opts ...yarpc.CallOption,
+) (err error) {
+
+	args := []interface{}{ctx, _Request}
+	for _, o := range opts {
+		args = append(args, o)
+	}
+	i := 0
+	ret := m.ctrl.Call(m, "ReconcileDomainOpenExecutionCounts", args...)
+	err, _ = ret[i].(error)
+	return
+}
+
+func (mr *_MockClientRecorder) ReconcileDomainOpenExecutionCounts(
+	ctx interface{},
+	_Request interface{},
+	opts ...interface{},
+) *gomock.Call {
+	args := append([]interface{}{ctx, _Request}, opts...)
+	return mr.mock.ctrl.RecordCall(mr.mock, "ReconcileDomainOpenExecutionCounts", args...)
+}
+
 // RecordActivityTaskHeartbeat responds to a RecordActivityTaskHeartbeat call based on the mock expectations. This
 // call will fail if the mock does not expect this call. Use EXPECT to expect
 // a call to this function.
@@ -703,6 +897,130 @@ func (mr *_MockClientRecorder) ScheduleDecisionTask(
 	return mr.mock.ctrl.RecordCall(mr.mock, "ScheduleDecisionTask", args...)
 }
 
+// SetActivityPaused responds to a SetActivityPaused call based on the mock expectations. This
+// call will fail if the mock does not expect this call. Use EXPECT to expect
+// a call to this function.
+//
+// 	client.EXPECT().SetActivityPaused(gomock.Any(), ...).Return(...)
+// 	... := client.SetActivityPaused(...)
+func (m *MockClient) SetActivityPaused(
+	ctx context.Context,
+	_PauseRequest *history.SetActivityPausedRequest,
+	opts ...yarpc.CallOption,
+) (err error) {
+
+	args := []interface{}{ctx, _PauseRequest}
+	for _, o := range opts {
+		args = append(args, o)
+	}
+	i := 0
+	ret := m.ctrl.Call(m, "SetActivityPaused", args...)
+	err, _ = ret[i].(error)
+	return
+}
+
+func (mr *_MockClientRecorder) SetActivityPaused(
+	ctx interface{},
+	_PauseRequest interface{},
+	opts ...interface{},
+) *gomock.Call {
+	args := append([]interface{}{ctx, _PauseRequest}, opts...)
+	return mr.mock.ctrl.RecordCall(mr.mock, "SetActivityPaused", args...)
+}
+
+// SetCronSchedulePaused responds to a SetCronSchedulePaused call based on the mock expectations. This
+// call will fail if the mock does not expect this call. Use EXPECT to expect
+// a call to this function.
+//
+// 	client.EXPECT().SetCronSchedulePaused(gomock.Any(), ...).Return(...)
+// 	... := client.SetCronSchedulePaused(...)
+func (m *MockClient) SetCronSchedulePaused(
+	ctx context.Context,
+	_PauseRequest *history.SetCronSchedulePausedRequest,
+	opts ...yarpc.CallOption,
+) (err error) {
+
+	args := []interface{}{ctx, _PauseRequest}
+	for _, o := range opts {
+		args = append(args, o)
+	}
+	i := 0
+	ret := m.ctrl.Call(m, "SetCronSchedulePaused", args...)
+	err, _ = ret[i].(error)
+	return
+}
+
+func (mr *_MockClientRecorder) SetCronSchedulePaused(
+	ctx interface{},
+	_PauseRequest interface{},
+	opts ...interface{},
+) *gomock.Call {
+	args := append([]interface{}{ctx, _PauseRequest}, opts...)
+	return mr.mock.ctrl.RecordCall(mr.mock, "SetCronSchedulePaused", args...)
+}
+
+// SetWorkflowExecutionNotes responds to a SetWorkflowExecutionNotes call based on the mock expectations. This
+// call will fail if the mock does not expect this call. Use EXPECT to expect
+// a call to this function.
+//
+// 	client.EXPECT().SetWorkflowExecutionNotes(gomock.Any(), ...).Return(...)
+// 	... := client.SetWorkflowExecutionNotes(...)
+func (m *MockClient) SetWorkflowExecutionNotes(
+	ctx context.Context,
+	_NotesRequest *history.SetWorkflowExecutionNotesRequest,
+	opts ...yarpc.CallOption,
+) (err error) {
+
+	args := []interface{}{ctx, _NotesRequest}
+	for _, o := range opts {
+		args = append(args, o)
+	}
+	i := 0
+	ret := m.ctrl.Call(m, "SetWorkflowExecutionNotes", args...)
+	err, _ = ret[i].(error)
+	return
+}
+
+func (mr *_MockClientRecorder) SetWorkflowExecutionNotes(
+	ctx interface{},
+	_NotesRequest interface{},
+	opts ...interface{},
+) *gomock.Call {
+	args := append([]interface{}{ctx, _NotesRequest}, opts...)
+	return mr.mock.ctrl.RecordCall(mr.mock, "SetWorkflowExecutionNotes", args...)
+}
+
+// SetWorkflowExecutionPaused responds to a SetWorkflowExecutionPaused call based on the mock expectations. This
+// call will fail if the mock does not expect this call. Use EXPECT to expect
+// a call to this function.
+//
+// 	client.EXPECT().SetWorkflowExecutionPaused(gomock.Any(), ...).Return(...)
+// 	... := client.SetWorkflowExecutionPaused(...)
+func (m *MockClient) SetWorkflowExecutionPaused(
+	ctx context.Context,
+	_PauseRequest *history.SetWorkflowExecutionPausedRequest,
+	opts ...yarpc.CallOption,
+) (err error) {
+
+	args := []interface{}{ctx, _PauseRequest}
+	for _, o := range opts {
+		args = append(args, o)
+	}
+	i := 0
+	ret := m.ctrl.Call(m, "SetWorkflowExecutionPaused", args...)
+	err, _ = ret[i].(error)
+	return
+}
+
+func (mr *_MockClientRecorder) SetWorkflowExecutionPaused(
+	ctx interface{},
+	_PauseRequest interface{},
+	opts ...interface{},
+) *gomock.Call {
+	args := append([]interface{}{ctx, _PauseRequest}, opts...)
+	return mr.mock.ctrl.RecordCall(mr.mock, "SetWorkflowExecutionPaused", args...)
+}
+
 // SignalWithStartWorkflowExecution responds to a SignalWithStartWorkflowExecution call based on the mock expectations. This
 // call will fail if the mock does not expect this call. Use EXPECT to expect
 // a call to this function.
@@ -862,6 +1180,39 @@ func (mr *_MockClientRecorder) SyncShardStatus(
 	return mr.mock.ctrl.RecordCall(mr.mock, "SyncShardStatus", args...)
 }
 
+// TerminateAllWorkflowRuns responds to a TerminateAllWorkflowRuns call based on the mock expectations. This
+// call will fail if the mock does not expect this call. Use EXPECT to expect
+// a call to this function.
+//
+// 	client.EXPECT().TerminateAllWorkflowRuns(gomock.Any(), ...).Return(...)
+// 	... := client.TerminateAllWorkflowRuns(...)
+func (m *MockClient) TerminateAllWorkflowRuns(
+	ctx context.Context,
+	_TerminateRequest *history.TerminateAllWorkflowRunsRequest,
+	opts ...yarpc.CallOption,
+) (success *shared.TerminateAllWorkflowRunsResponse, err error) {
+
+	args := []interface{}{ctx, _TerminateRequest}
+	for _, o := range opts {
+		args = append(args, o)
+	}
+	i := 0
+	ret := m.ctrl.Call(m, "TerminateAllWorkflowRuns", args...)
+	success, _ = ret[i].(*shared.TerminateAllWorkflowRunsResponse)
+	i++
+	err, _ = ret[i].(error)
+	return
+}
+
+func (mr *_MockClientRecorder) TerminateAllWorkflowRuns(
+	ctx interface{},
+	_TerminateRequest interface{},
+	opts ...interface{},
+) *gomock.Call {
+	args := append([]interface{}{ctx, _TerminateRequest}, opts...)
+	return mr.mock.ctrl.RecordCall(mr.mock, "TerminateAllWorkflowRuns", args...)
+}
+
 // TerminateWorkflowExecution responds to a TerminateWorkflowExecution call based on the mock expectations. This
 // call will fail if the mock does not expect this call. Use EXPECT to expect
 // a call to this function.
@@ -892,3 +1243,69 @@ func (mr *_MockClientRecorder) TerminateWorkflowExecution(
 	args := append([]interface{}{ctx, _TerminateRequest}, opts...)
 	return mr.mock.ctrl.RecordCall(mr.mock, "TerminateWorkflowExecution", args...)
 }
+
+// UndrainHistoryHost responds to a UndrainHistoryHost call based on the mock expectations. This
+// call will fail if the mock does not expect this call. Use EXPECT to expect
+// a call to this function.
+//
+// 	client.EXPECT().UndrainHistoryHost(gomock.Any(), ...).Return(...)
+// 	... := client.UndrainHistoryHost(...)
+func (m *MockClient) UndrainHistoryHost(
+	ctx context.Context,
+	_Request *shared.UndrainHistoryHostRequest,
+	opts ...yarpc.CallOption,
+) (success *shared.UndrainHistoryHostResponse, err error) {
+
+	args := []interface{}{ctx, _Request}
+	for _, o := range opts {
+		args = append(args, o)
+	}
+	i := 0
+	ret := m.ctrl.Call(m, "UndrainHistoryHost", args...)
+	success, _ = ret[i].(*shared.UndrainHistoryHostResponse)
+	i++
+	err, _ = ret[i].(error)
+	return
+}
+
+func (mr *_MockClientRecorder) UndrainHistoryHost(
+	ctx interface{},
+	_Request interface{},
+	opts ...interface{},
+) *gomock.Call {
+	args := append([]interface{}{ctx, _Request}, opts...)
+	return mr.mock.ctrl.RecordCall(mr.mock, "UndrainHistoryHost", args...)
+}
+
+// UpdateWorkflowExecution responds to a UpdateWorkflowExecution call based on the mock expectations. This
+// call will fail if the mock does not expect this call. Use EXPECT to expect
+// a call to this function.
+//
+// 	client.EXPECT().UpdateWorkflowExecution(gomock.Any(), ...).Return(...)
+// 	... := client.UpdateWorkflowExecution(...)
+func (m *MockClient) UpdateWorkflowExecution(
+	ctx context.Context,
+	_UpdateRequest *history.UpdateWorkflowExecutionRequest,
+	opts ...yarpc.CallOption,
+) (success *shared.UpdateWorkflowExecutionResponse, err error) {
+
+	args := []interface{}{ctx, _UpdateRequest}
+	for _, o := range opts {
+		args = append(args, o)
+	}
+	i := 0
+	ret := m.ctrl.Call(m, "UpdateWorkflowExecution", args...)
+	success, _ = ret[i].(*shared.UpdateWorkflowExecutionResponse)
+	i++
+	err, _ = ret[i].(error)
+	return
+}
+
+func (mr *_MockClientRecorder) UpdateWorkflowExecution(
+	ctx interface{},
+	_UpdateRequest interface{},
+	opts ...interface{},
+) *gomock.Call {
+	args := append([]interface{}{ctx, _UpdateRequest}, opts...)
+	return mr.mock.ctrl.RecordCall(mr.mock, "UpdateWorkflowExecution", args...)
+}
@@ -438,6 +438,7 @@ type AddDecisionTaskRequest struct {
 	TaskList                      *shared.TaskList          `json:"taskList,omitempty"`
 	ScheduleId                    *int64                    `json:"scheduleId,omitempty"`
 	ScheduleToStartTimeoutSeconds *int32                    `json:"scheduleToStartTimeoutSeconds,omitempty"`
+	WorkerBuildId                 *string                   `json:"workerBuildId,omitempty"`
 }
 
 // ToWire translates a AddDecisionTaskRequest struct into a Thrift-level intermediate
@@ -457,7 +458,7 @@ type AddDecisionTaskRequest struct {
 //   }
 func (v *AddDecisionTaskRequest) ToWire() (wire.Value, error) {
 	var (
-		fields [5]wire.Field
+		fields [6]wire.Field
 		i      int = 0
 		w      wire.Value
 		err    error
@@ -503,6 +504,14 @@ func (v *AddDecisionTaskRequest) ToWire() (wire.Value, error) {
 		fields[i] = wire.Field{ID: 50, Value: w}
 		i++
 	}
+	if v.WorkerBuildId != nil {
+		w, err = wire.NewValueString(*(v.WorkerBuildId)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 60, Value: w}
+		i++
+	}
 
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
@@ -574,6 +583,16 @@ func (v *AddDecisionTaskRequest) FromWire(w wire.Value) error {
 					return err
 				}
 
+			}
+		case 60:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.WorkerBuildId = &x
+				if err != nil {
+					return err
+				}
+
 			}
 		}
 	}
@@ -588,7 +607,7 @@ func (v *AddDecisionTaskRequest) String() string {
 		return "<nil>"
 	}
 
-	var fields [5]string
+	var fields [6]string
 	i := 0
 	if v.DomainUUID != nil {
 		fields[i] = fmt.Sprintf("DomainUUID: %v", *(v.DomainUUID))
@@ -610,6 +629,10 @@ func (v *AddDecisionTaskRequest) String() string {
 		fields[i] = fmt.Sprintf("ScheduleToStartTimeoutSeconds: %v", *(v.ScheduleToStartTimeoutSeconds))
 		i++
 	}
+	if v.WorkerBuildId != nil {
+		fields[i] = fmt.Sprintf("WorkerBuildId: %v", *(v.WorkerBuildId))
+		i++
+	}
 
 	return fmt.Sprintf("AddDecisionTaskRequest{%v}", strings.Join(fields[:i], ", "))
 }
@@ -639,6 +662,9 @@ func (v *AddDecisionTaskRequest) Equals(rhs *AddDecisionTaskRequest) bool {
 	if !_I32_EqualsPtr(v.ScheduleToStartTimeoutSeconds, rhs.ScheduleToStartTimeoutSeconds) {
 		return false
 	}
+	if !_String_EqualsPtr(v.WorkerBuildId, rhs.WorkerBuildId) {
+		return false
+	}
 
 	return true
 }
@@ -664,6 +690,9 @@ func (v *AddDecisionTaskRequest) MarshalLogObject(enc zapcore.ObjectEncoder) (er
 	if v.ScheduleToStartTimeoutSeconds != nil {
 		enc.AddInt32("scheduleToStartTimeoutSeconds", *v.ScheduleToStartTimeoutSeconds)
 	}
+	if v.WorkerBuildId != nil {
+		enc.AddString("workerBuildId", *v.WorkerBuildId)
+	}
 	return err
 }
 
@@ -742,6 +771,21 @@ func (v *AddDecisionTaskRequest) IsSetScheduleToStartTimeoutSeconds() bool {
 	return v != nil && v.ScheduleToStartTimeoutSeconds != nil
 }
 
+// GetWorkerBuildId returns the value of WorkerBuildId if it is set or its
+// zero value if it is unset.
+func (v *AddDecisionTaskRequest) GetWorkerBuildId() (o string) {
+	if v != nil && v.WorkerBuildId != nil {
+		return *v.WorkerBuildId
+	}
+
+	return
+}
+
+// IsSetWorkerBuildId returns true if WorkerBuildId is not nil.
+func (v *AddDecisionTaskRequest) IsSetWorkerBuildId() bool {
+	return v != nil && v.WorkerBuildId != nil
+}
+
 type CancelOutstandingPollRequest struct {
 	DomainUUID   *string          `json:"domainUUID,omitempty"`
 	TaskListType *int32           `json:"taskListType,omitempty"`
@@ -1010,12 +1054,12 @@ func (v *CancelOutstandingPollRequest) IsSetPollerID() bool {
 	return v != nil && v.PollerID != nil
 }
 
-type DescribeTaskListRequest struct {
-	DomainUUID  *string                         `json:"domainUUID,omitempty"`
-	DescRequest *shared.DescribeTaskListRequest `json:"descRequest,omitempty"`
+type DescribeTaskJourneyRequest struct {
+	DomainUUID     *string                            `json:"domainUUID,omitempty"`
+	JourneyRequest *shared.DescribeTaskJourneyRequest `json:"journeyRequest,omitempty"`
 }
 
-// ToWire translates a DescribeTaskListRequest struct into a Thrift-level intermediate
+// ToWire translates a DescribeTaskJourneyRequest struct into a Thrift-level intermediate
 // representation. This intermediate representation may be serialized
 // into bytes using a ThriftRW protocol implementation.
 //
@@ -1030,7 +1074,7 @@ type DescribeTaskListRequest struct {
 //   if err := binaryProtocol.Encode(x, writer); err != nil {
 //     return err
 //   }
-func (v *DescribeTaskListRequest) ToWire() (wire.Value, error) {
+func (v *DescribeTaskJourneyRequest) ToWire() (wire.Value, error) {
 	var (
 		fields [2]wire.Field
 		i      int = 0
@@ -1046,8 +1090,8 @@ func (v *DescribeTaskListRequest) ToWire() (wire.Value, error) {
 		fields[i] = wire.Field{ID: 10, Value: w}
 		i++
 	}
-	if v.DescRequest != nil {
-		w, err = v.DescRequest.ToWire()
+	if v.JourneyRequest != nil {
+		w, err = v.JourneyRequest.ToWire()
 		if err != nil {
 			return w, err
 		}
@@ -1058,17 +1102,17 @@ func (v *DescribeTaskListRequest) ToWire() (wire.Value, error) {
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
 
-func _DescribeTaskListRequest_Read(w wire.Value) (*shared.DescribeTaskListRequest, error) {
-	var v shared.DescribeTaskListRequest
+func _DescribeTaskJourneyRequest_Read(w wire.Value) (*shared.DescribeTaskJourneyRequest, error) {
+	var v shared.DescribeTaskJourneyRequest
 	err := v.FromWire(w)
 	return &v, err
 }
 
-// FromWire deserializes a DescribeTaskListRequest struct from its Thrift-level
+// FromWire deserializes a DescribeTaskJourneyRequest struct from its Thrift-level
 // representation. The Thrift-level representation may be obtained
 // from a ThriftRW protocol implementation.
 //
-// An error is returned if we were unable to build a DescribeTaskListRequest struct
+// An error is returned if we were unable to build a DescribeTaskJourneyRequest struct
 // from the provided intermediate representation.
 //
 //   x, err := binaryProtocol.Decode(reader, wire.TStruct)
@@ -1076,12 +1120,12 @@ func _DescribeTaskListRequest_Read(w wire.Value) (*shared.DescribeTaskListReques
 //     return nil, err
 //   }
 //
-//   var v DescribeTaskListRequest
+//   var v DescribeTaskJourneyRequest
 //   if err := v.FromWire(x); err != nil {
 //     return nil, err
 //   }
 //   return &v, nil
-func (v *DescribeTaskListRequest) FromWire(w wire.Value) error {
+func (v *DescribeTaskJourneyRequest) FromWire(w wire.Value) error {
 	var err error
 
 	for _, field := range w.GetStruct().Fields {
@@ -1098,7 +1142,7 @@ func (v *DescribeTaskListRequest) FromWire(w wire.Value) error {
 			}
 		case 20:
 			if field.Value.Type() == wire.TStruct {
-				v.DescRequest, err = _DescribeTaskListRequest_Read(field.Value)
+				v.JourneyRequest, err = _DescribeTaskJourneyRequest_Read(field.Value)
 				if err != nil {
 					return err
 				}
@@ -1110,9 +1154,9 @@ func (v *DescribeTaskListRequest) FromWire(w wire.Value) error {
 	return nil
 }
 
-// String returns a readable string representation of a DescribeTaskListRequest
+// String returns a readable string representation of a DescribeTaskJourneyRequest
 // struct.
-func (v *DescribeTaskListRequest) String() string {
+func (v *DescribeTaskJourneyRequest) String() string {
 	if v == nil {
 		return "<nil>"
 	}
@@ -1123,19 +1167,19 @@ func (v *DescribeTaskListRequest) String() string {
 		fields[i] = fmt.Sprintf("DomainUUID: %v", *(v.DomainUUID))
 		i++
 	}
-	if v.DescRequest != nil {
-		fields[i] = fmt.Sprintf("DescRequest: %v", v.DescRequest)
+	if v.JourneyRequest != nil {
+		fields[i] = fmt.Sprintf("JourneyRequest: %v", v.JourneyRequest)
 		i++
 	}
 
-	return fmt.Sprintf("DescribeTaskListRequest{%v}", strings.Join(fields[:i], ", "))
+	return fmt.Sprintf("DescribeTaskJourneyRequest{%v}", strings.Join(fields[:i], ", "))
 }
 
-// Equals returns true if all the fields of this DescribeTaskListRequest match the
-// provided DescribeTaskListRequest.
+// Equals returns true if all the fields of this DescribeTaskJourneyRequest match the
+// provided DescribeTaskJourneyRequest.
 //
 // This function performs a deep comparison.
-func (v *DescribeTaskListRequest) Equals(rhs *DescribeTaskListRequest) bool {
+func (v *DescribeTaskJourneyRequest) Equals(rhs *DescribeTaskJourneyRequest) bool {
 	if v == nil {
 		return rhs == nil
 	} else if rhs == nil {
@@ -1144,7 +1188,7 @@ func (v *DescribeTaskListRequest) Equals(rhs *DescribeTaskListRequest) bool {
 	if !_String_EqualsPtr(v.DomainUUID, rhs.DomainUUID) {
 		return false
 	}
-	if !((v.DescRequest == nil && rhs.DescRequest == nil) || (v.DescRequest != nil && rhs.DescRequest != nil && v.DescRequest.Equals(rhs.DescRequest))) {
+	if !((v.JourneyRequest == nil && rhs.JourneyRequest == nil) || (v.JourneyRequest != nil && rhs.JourneyRequest != nil && v.JourneyRequest.Equals(rhs.JourneyRequest))) {
 		return false
 	}
 
@@ -1152,23 +1196,23 @@ func (v *DescribeTaskListRequest) Equals(rhs *DescribeTaskListRequest) bool {
 }
 
 // MarshalLogObject implements zapcore.ObjectMarshaler, enabling
-// fast logging of DescribeTaskListRequest.
-func (v *DescribeTaskListRequest) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+// fast logging of DescribeTaskJourneyRequest.
+func (v *DescribeTaskJourneyRequest) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
 	if v == nil {
 		return nil
 	}
 	if v.DomainUUID != nil {
 		enc.AddString("domainUUID", *v.DomainUUID)
 	}
-	if v.DescRequest != nil {
-		err = multierr.Append(err, enc.AddObject("descRequest", v.DescRequest))
+	if v.JourneyRequest != nil {
+		err = multierr.Append(err, enc.AddObject("journeyRequest", v.JourneyRequest))
 	}
 	return err
 }
 
 // GetDomainUUID returns the value of DomainUUID if it is set or its
 // zero value if it is unset.
-func (v *DescribeTaskListRequest) GetDomainUUID() (o string) {
+func (v *DescribeTaskJourneyRequest) GetDomainUUID() (o string) {
 	if v != nil && v.DomainUUID != nil {
 		return *v.DomainUUID
 	}
@@ -1177,32 +1221,31 @@ func (v *DescribeTaskListRequest) GetDomainUUID() (o string) {
 }
 
 // IsSetDomainUUID returns true if DomainUUID is not nil.
-func (v *DescribeTaskListRequest) IsSetDomainUUID() bool {
+func (v *DescribeTaskJourneyRequest) IsSetDomainUUID() bool {
 	return v != nil && v.DomainUUID != nil
 }
 
-// GetDescRequest returns the value of DescRequest if it is set or its
+// GetJourneyRequest returns the value of JourneyRequest if it is set or its
 // zero value if it is unset.
-func (v *DescribeTaskListRequest) GetDescRequest() (o *shared.DescribeTaskListRequest) {
-	if v != nil && v.DescRequest != nil {
-		return v.DescRequest
+func (v *DescribeTaskJourneyRequest) GetJourneyRequest() (o *shared.DescribeTaskJourneyRequest) {
+	if v != nil && v.JourneyRequest != nil {
+		return v.JourneyRequest
 	}
 
 	return
 }
 
-// IsSetDescRequest returns true if DescRequest is not nil.
-func (v *DescribeTaskListRequest) IsSetDescRequest() bool {
-	return v != nil && v.DescRequest != nil
+// IsSetJourneyRequest returns true if JourneyRequest is not nil.
+func (v *DescribeTaskJourneyRequest) IsSetJourneyRequest() bool {
+	return v != nil && v.JourneyRequest != nil
 }
 
-type PollForActivityTaskRequest struct {
-	DomainUUID  *string                            `json:"domainUUID,omitempty"`
-	PollerID    *string                            `json:"pollerID,omitempty"`
-	PollRequest *shared.PollForActivityTaskRequest `json:"pollRequest,omitempty"`
+type DescribeTaskListRequest struct {
+	DomainUUID  *string                         `json:"domainUUID,omitempty"`
+	DescRequest *shared.DescribeTaskListRequest `json:"descRequest,omitempty"`
 }
 
-// ToWire translates a PollForActivityTaskRequest struct into a Thrift-level intermediate
+// ToWire translates a DescribeTaskListRequest struct into a Thrift-level intermediate
 // representation. This intermediate representation may be serialized
 // into bytes using a ThriftRW protocol implementation.
 //
@@ -1217,9 +1260,9 @@ type PollForActivityTaskRequest struct {
 //   if err := binaryProtocol.Encode(x, writer); err != nil {
 //     return err
 //   }
-func (v *PollForActivityTaskRequest) ToWire() (wire.Value, error) {
+func (v *DescribeTaskListRequest) ToWire() (wire.Value, error) {
 	var (
-		fields [3]wire.Field
+		fields [2]wire.Field
 		i      int = 0
 		w      wire.Value
 		err    error
@@ -1233,16 +1276,8 @@ func (v *PollForActivityTaskRequest) ToWire() (wire.Value, error) {
 		fields[i] = wire.Field{ID: 10, Value: w}
 		i++
 	}
-	if v.PollerID != nil {
-		w, err = wire.NewValueString(*(v.PollerID)), error(nil)
-		if err != nil {
-			return w, err
-		}
-		fields[i] = wire.Field{ID: 15, Value: w}
-		i++
-	}
-	if v.PollRequest != nil {
-		w, err = v.PollRequest.ToWire()
+	if v.DescRequest != nil {
+		w, err = v.DescRequest.ToWire()
 		if err != nil {
 			return w, err
 		}
@@ -1253,17 +1288,17 @@ func (v *PollForActivityTaskRequest) ToWire() (wire.Value, error) {
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
 
-func _PollForActivityTaskRequest_Read(w wire.Value) (*shared.PollForActivityTaskRequest, error) {
-	var v shared.PollForActivityTaskRequest
+func _DescribeTaskListRequest_Read(w wire.Value) (*shared.DescribeTaskListRequest, error) {
+	var v shared.DescribeTaskListRequest
 	err := v.FromWire(w)
 	return &v, err
 }
 
-// FromWire deserializes a PollForActivityTaskRequest struct from its Thrift-level
+// FromWire deserializes a DescribeTaskListRequest struct from its Thrift-level
 // representation. The Thrift-level representation may be obtained
 // from a ThriftRW protocol implementation.
 //
-// An error is returned if we were unable to build a PollForActivityTaskRequest struct
+// An error is returned if we were unable to build a DescribeTaskListRequest struct
 // from the provided intermediate representation.
 //
 //   x, err := binaryProtocol.Decode(reader, wire.TStruct)
@@ -1271,12 +1306,12 @@ func _PollForActivityTaskRequest_Read(w wire.Value) (*shared.PollForActivityTask
 //     return nil, err
 //   }
 //
-//   var v PollForActivityTaskRequest
+//   var v DescribeTaskListRequest
 //   if err := v.FromWire(x); err != nil {
 //     return nil, err
 //   }
 //   return &v, nil
-func (v *PollForActivityTaskRequest) FromWire(w wire.Value) error {
+func (v *DescribeTaskListRequest) FromWire(w wire.Value) error {
 	var err error
 
 	for _, field := range w.GetStruct().Fields {
@@ -1290,20 +1325,10 @@ func (v *PollForActivityTaskRequest) FromWire(w wire.Value) error {
 					return err
 				}
 
-			}
-		case 15:
-			if field.Value.Type() == wire.TBinary {
-				var x string
-				x, err = field.Value.GetString(), error(nil)
-				v.PollerID = &x
-				if err != nil {
-					return err
-				}
-
 			}
 		case 20:
 			if field.Value.Type() == wire.TStruct {
-				v.PollRequest, err = _PollForActivityTaskRequest_Read(field.Value)
+				v.DescRequest, err = _DescribeTaskListRequest_Read(field.Value)
 				if err != nil {
 					return err
 				}
@@ -1315,36 +1340,32 @@ func (v *PollForActivityTaskRequest) FromWire(w wire.Value) error {
 	return nil
 }
 
-// String returns a readable string representation of a PollForActivityTaskRequest
+// String returns a readable string representation of a DescribeTaskListRequest
 // struct.
-func (v *PollForActivityTaskRequest) String() string {
+func (v *DescribeTaskListRequest) String() string {
 	if v == nil {
 		return "<nil>"
 	}
 
-	var fields [3]string
+	var fields [2]string
 	i := 0
 	if v.DomainUUID != nil {
 		fields[i] = fmt.Sprintf("DomainUUID: %v", *(v.DomainUUID))
 		i++
 	}
-	if v.PollerID != nil {
-		fields[i] = fmt.Sprintf("PollerID: %v", *(v.PollerID))
-		i++
-	}
-	if v.PollRequest != nil {
-		fields[i] = fmt.Sprintf("PollRequest: %v", v.PollRequest)
+	if v.DescRequest != nil {
+		fields[i] = fmt.Sprintf("DescRequest: %v", v.DescRequest)
 		i++
 	}
 
-	return fmt.Sprintf("PollForActivityTaskRequest{%v}", strings.Join(fields[:i], ", "))
+	return fmt.Sprintf("DescribeTaskListRequest{%v}", strings.Join(fields[:i], ", "))
 }
 
-// Equals returns true if all the fields of this PollForActivityTaskRequest match the
-// provided PollForActivityTaskRequest.
+// Equals returns true if all the fields of this DescribeTaskListRequest match the
+// provided DescribeTaskListRequest.
 //
 // This function performs a deep comparison.
-func (v *PollForActivityTaskRequest) Equals(rhs *PollForActivityTaskRequest) bool {
+func (v *DescribeTaskListRequest) Equals(rhs *DescribeTaskListRequest) bool {
 	if v == nil {
 		return rhs == nil
 	} else if rhs == nil {
@@ -1353,10 +1374,7 @@ func (v *PollForActivityTaskRequest) Equals(rhs *PollForActivityTaskRequest) boo
 	if !_String_EqualsPtr(v.DomainUUID, rhs.DomainUUID) {
 		return false
 	}
-	if !_String_EqualsPtr(v.PollerID, rhs.PollerID) {
-		return false
-	}
-	if !((v.PollRequest == nil && rhs.PollRequest == nil) || (v.PollRequest != nil && rhs.PollRequest != nil && v.PollRequest.Equals(rhs.PollRequest))) {
+	if !((v.DescRequest == nil && rhs.DescRequest == nil) || (v.DescRequest != nil && rhs.DescRequest != nil && v.DescRequest.Equals(rhs.DescRequest))) {
 		return false
 	}
 
@@ -1364,26 +1382,23 @@ func (v *PollForActivityTaskRequest) Equals(rhs *PollForActivityTaskRequest) boo
 }
 
 // MarshalLogObject implements zapcore.ObjectMarshaler, enabling
-// fast logging of PollForActivityTaskRequest.
-func (v *PollForActivityTaskRequest) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+// fast logging of DescribeTaskListRequest.
+func (v *DescribeTaskListRequest) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
 	if v == nil {
 		return nil
 	}
 	if v.DomainUUID != nil {
 		enc.AddString("domainUUID", *v.DomainUUID)
 	}
-	if v.PollerID != nil {
-		enc.AddString("pollerID", *v.PollerID)
-	}
-	if v.PollRequest != nil {
-		err = multierr.Append(err, enc.AddObject("pollRequest", v.PollRequest))
+	if v.DescRequest != nil {
+		err = multierr.Append(err, enc.AddObject("descRequest", v.DescRequest))
 	}
 	return err
 }
 
 // GetDomainUUID returns the value of DomainUUID if it is set or its
 // zero value if it is unset.
-func (v *PollForActivityTaskRequest) GetDomainUUID() (o string) {
+func (v *DescribeTaskListRequest) GetDomainUUID() (o string) {
 	if v != nil && v.DomainUUID != nil {
 		return *v.DomainUUID
 	}
@@ -1392,47 +1407,31 @@ func (v *PollForActivityTaskRequest) GetDomainUUID() (o string) {
 }
 
 // IsSetDomainUUID returns true if DomainUUID is not nil.
-func (v *PollForActivityTaskRequest) IsSetDomainUUID() bool {
+func (v *DescribeTaskListRequest) IsSetDomainUUID() bool {
 	return v != nil && v.DomainUUID != nil
 }
 
-// GetPollerID returns the value of PollerID if it is set or its
-// zero value if it is unset.
-func (v *PollForActivityTaskRequest) GetPollerID() (o string) {
-	if v != nil && v.PollerID != nil {
-		return *v.PollerID
-	}
-
-	return
-}
-
-// IsSetPollerID returns true if PollerID is not nil.
-func (v *PollForActivityTaskRequest) IsSetPollerID() bool {
-	return v != nil && v.PollerID != nil
-}
-
-// GetPollRequest returns the value of PollRequest if it is set or its
+// GetDescRequest returns the value of DescRequest if it is set or its
 // zero value if it is unset.
-func (v *PollForActivityTaskRequest) GetPollRequest() (o *shared.PollForActivityTaskRequest) {
-	if v != nil && v.PollRequest != nil {
-		return v.PollRequest
+func (v *DescribeTaskListRequest) GetDescRequest() (o *shared.DescribeTaskListRequest) {
+	if v != nil && v.DescRequest != nil {
+		return v.DescRequest
 	}
 
 	return
 }
 
-// IsSetPollRequest returns true if PollRequest is not nil.
-func (v *PollForActivityTaskRequest) IsSetPollRequest() bool {
-	return v != nil && v.PollRequest != nil
+// IsSetDescRequest returns true if DescRequest is not nil.
+func (v *DescribeTaskListRequest) IsSetDescRequest() bool {
+	return v != nil && v.DescRequest != nil
 }
 
-type PollForDecisionTaskRequest struct {
-	DomainUUID  *string                            `json:"domainUUID,omitempty"`
-	PollerID    *string                            `json:"pollerID,omitempty"`
-	PollRequest *shared.PollForDecisionTaskRequest `json:"pollRequest,omitempty"`
+type GetTaskListScalingHintsRequest struct {
+	DomainUUID   *string                                `json:"domainUUID,omitempty"`
+	HintsRequest *shared.GetTaskListScalingHintsRequest `json:"hintsRequest,omitempty"`
 }
 
-// ToWire translates a PollForDecisionTaskRequest struct into a Thrift-level intermediate
+// ToWire translates a GetTaskListScalingHintsRequest struct into a Thrift-level intermediate
 // representation. This intermediate representation may be serialized
 // into bytes using a ThriftRW protocol implementation.
 //
@@ -1447,9 +1446,9 @@ type PollForDecisionTaskRequest struct {
 //   if err := binaryProtocol.Encode(x, writer); err != nil {
 //     return err
 //   }
-func (v *PollForDecisionTaskRequest) ToWire() (wire.Value, error) {
+func (v *GetTaskListScalingHintsRequest) ToWire() (wire.Value, error) {
 	var (
-		fields [3]wire.Field
+		fields [2]wire.Field
 		i      int = 0
 		w      wire.Value
 		err    error
@@ -1463,16 +1462,8 @@ func (v *PollForDecisionTaskRequest) ToWire() (wire.Value, error) {
 		fields[i] = wire.Field{ID: 10, Value: w}
 		i++
 	}
-	if v.PollerID != nil {
-		w, err = wire.NewValueString(*(v.PollerID)), error(nil)
-		if err != nil {
-			return w, err
-		}
-		fields[i] = wire.Field{ID: 15, Value: w}
-		i++
-	}
-	if v.PollRequest != nil {
-		w, err = v.PollRequest.ToWire()
+	if v.HintsRequest != nil {
+		w, err = v.HintsRequest.ToWire()
 		if err != nil {
 			return w, err
 		}
@@ -1483,17 +1474,17 @@ func (v *PollForDecisionTaskRequest) ToWire() (wire.Value, error) {
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
 
-func _PollForDecisionTaskRequest_Read(w wire.Value) (*shared.PollForDecisionTaskRequest, error) {
-	var v shared.PollForDecisionTaskRequest
+func _GetTaskListScalingHintsRequest_Read(w wire.Value) (*shared.GetTaskListScalingHintsRequest, error) {
+	var v shared.GetTaskListScalingHintsRequest
 	err := v.FromWire(w)
 	return &v, err
 }
 
-// FromWire deserializes a PollForDecisionTaskRequest struct from its Thrift-level
+// FromWire deserializes a GetTaskListScalingHintsRequest struct from its Thrift-level
 // representation. The Thrift-level representation may be obtained
 // from a ThriftRW protocol implementation.
 //
-// An error is returned if we were unable to build a PollForDecisionTaskRequest struct
+// An error is returned if we were unable to build a GetTaskListScalingHintsRequest struct
 // from the provided intermediate representation.
 //
 //   x, err := binaryProtocol.Decode(reader, wire.TStruct)
@@ -1501,12 +1492,12 @@ func _PollForDecisionTaskRequest_Read(w wire.Value) (*shared.PollForDecisionTask
 //     return nil, err
 //   }
 //
-//   var v PollForDecisionTaskRequest
+//   var v GetTaskListScalingHintsRequest
 //   if err := v.FromWire(x); err != nil {
 //     return nil, err
 //   }
 //   return &v, nil
-func (v *PollForDecisionTaskRequest) FromWire(w wire.Value) error {
+func (v *GetTaskListScalingHintsRequest) FromWire(w wire.Value) error {
 	var err error
 
 	for _, field := range w.GetStruct().Fields {
@@ -1520,20 +1511,10 @@ func (v *PollForDecisionTaskRequest) FromWire(w wire.Value) error {
 					return err
 				}
 
-			}
-		case 15:
-			if field.Value.Type() == wire.TBinary {
-				var x string
-				x, err = field.Value.GetString(), error(nil)
-				v.PollerID = &x
-				if err != nil {
-					return err
-				}
-
 			}
 		case 20:
 			if field.Value.Type() == wire.TStruct {
-				v.PollRequest, err = _PollForDecisionTaskRequest_Read(field.Value)
+				v.HintsRequest, err = _GetTaskListScalingHintsRequest_Read(field.Value)
 				if err != nil {
 					return err
 				}
@@ -1545,36 +1526,32 @@ func (v *PollForDecisionTaskRequest) FromWire(w wire.Value) error {
 	return nil
 }
 
-// String returns a readable string representation of a PollForDecisionTaskRequest
+// String returns a readable string representation of a GetTaskListScalingHintsRequest
 // struct.
-func (v *PollForDecisionTaskRequest) String() string {
+func (v *GetTaskListScalingHintsRequest) String() string {
 	if v == nil {
 		return "<nil>"
 	}
 
-	var fields [3]string
+	var fields [2]string
 	i := 0
 	if v.DomainUUID != nil {
 		fields[i] = fmt.Sprintf("DomainUUID: %v", *(v.DomainUUID))
 		i++
 	}
-	if v.PollerID != nil {
-		fields[i] = fmt.Sprintf("PollerID: %v", *(v.PollerID))
-		i++
-	}
-	if v.PollRequest != nil {
-		fields[i] = fmt.Sprintf("PollRequest: %v", v.PollRequest)
+	if v.HintsRequest != nil {
+		fields[i] = fmt.Sprintf("HintsRequest: %v", v.HintsRequest)
 		i++
 	}
 
-	return fmt.Sprintf("PollForDecisionTaskRequest{%v}", strings.Join(fields[:i], ", "))
+	return fmt.Sprintf("GetTaskListScalingHintsRequest{%v}", strings.Join(fields[:i], ", "))
 }
 
-// Equals returns true if all the fields of this PollForDecisionTaskRequest match the
-// provided PollForDecisionTaskRequest.
+// Equals returns true if all the fields of this GetTaskListScalingHintsRequest match the
+// provided GetTaskListScalingHintsRequest.
 //
 // This function performs a deep comparison.
-func (v *PollForDecisionTaskRequest) Equals(rhs *PollForDecisionTaskRequest) bool {
+func (v *GetTaskListScalingHintsRequest) Equals(rhs *GetTaskListScalingHintsRequest) bool {
 	if v == nil {
 		return rhs == nil
 	} else if rhs == nil {
@@ -1583,10 +1560,7 @@ func (v *PollForDecisionTaskRequest) Equals(rhs *PollForDecisionTaskRequest) boo
 	if !_String_EqualsPtr(v.DomainUUID, rhs.DomainUUID) {
 		return false
 	}
-	if !_String_EqualsPtr(v.PollerID, rhs.PollerID) {
-		return false
-	}
-	if !((v.PollRequest == nil && rhs.PollRequest == nil) || (v.PollRequest != nil && rhs.PollRequest != nil && v.PollRequest.Equals(rhs.PollRequest))) {
+	if !((v.HintsRequest == nil && rhs.HintsRequest == nil) || (v.HintsRequest != nil && rhs.HintsRequest != nil && v.HintsRequest.Equals(rhs.HintsRequest))) {
 		return false
 	}
 
@@ -1594,26 +1568,23 @@ func (v *PollForDecisionTaskRequest) Equals(rhs *PollForDecisionTaskRequest) boo
 }
 
 // MarshalLogObject implements zapcore.ObjectMarshaler, enabling
-// fast logging of PollForDecisionTaskRequest.
-func (v *PollForDecisionTaskRequest) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+// fast logging of GetTaskListScalingHintsRequest.
+func (v *GetTaskListScalingHintsRequest) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
 	if v == nil {
 		return nil
 	}
 	if v.DomainUUID != nil {
 		enc.AddString("domainUUID", *v.DomainUUID)
 	}
-	if v.PollerID != nil {
-		enc.AddString("pollerID", *v.PollerID)
-	}
-	if v.PollRequest != nil {
-		err = multierr.Append(err, enc.AddObject("pollRequest", v.PollRequest))
+	if v.HintsRequest != nil {
+		err = multierr.Append(err, enc.AddObject("hintsRequest", v.HintsRequest))
 	}
 	return err
 }
 
 // GetDomainUUID returns the value of DomainUUID if it is set or its
 // zero value if it is unset.
-func (v *PollForDecisionTaskRequest) GetDomainUUID() (o string) {
+func (v *GetTaskListScalingHintsRequest) GetDomainUUID() (o string) {
 	if v != nil && v.DomainUUID != nil {
 		return *v.DomainUUID
 	}
@@ -1622,60 +1593,32 @@ func (v *PollForDecisionTaskRequest) GetDomainUUID() (o string) {
 }
 
 // IsSetDomainUUID returns true if DomainUUID is not nil.
-func (v *PollForDecisionTaskRequest) IsSetDomainUUID() bool {
+func (v *GetTaskListScalingHintsRequest) IsSetDomainUUID() bool {
 	return v != nil && v.DomainUUID != nil
 }
 
-// GetPollerID returns the value of PollerID if it is set or its
+// GetHintsRequest returns the value of HintsRequest if it is set or its
 // zero value if it is unset.
-func (v *PollForDecisionTaskRequest) GetPollerID() (o string) {
-	if v != nil && v.PollerID != nil {
-		return *v.PollerID
+func (v *GetTaskListScalingHintsRequest) GetHintsRequest() (o *shared.GetTaskListScalingHintsRequest) {
+	if v != nil && v.HintsRequest != nil {
+		return v.HintsRequest
 	}
 
 	return
 }
 
-// IsSetPollerID returns true if PollerID is not nil.
-func (v *PollForDecisionTaskRequest) IsSetPollerID() bool {
-	return v != nil && v.PollerID != nil
-}
-
-// GetPollRequest returns the value of PollRequest if it is set or its
-// zero value if it is unset.
-func (v *PollForDecisionTaskRequest) GetPollRequest() (o *shared.PollForDecisionTaskRequest) {
-	if v != nil && v.PollRequest != nil {
-		return v.PollRequest
-	}
-
-	return
-}
-
-// IsSetPollRequest returns true if PollRequest is not nil.
-func (v *PollForDecisionTaskRequest) IsSetPollRequest() bool {
-	return v != nil && v.PollRequest != nil
+// IsSetHintsRequest returns true if HintsRequest is not nil.
+func (v *GetTaskListScalingHintsRequest) IsSetHintsRequest() bool {
+	return v != nil && v.HintsRequest != nil
 }
 
-type PollForDecisionTaskResponse struct {
-	TaskToken                 []byte                        `json:"taskToken,omitempty"`
-	WorkflowExecution         *shared.WorkflowExecution     `json:"workflowExecution,omitempty"`
-	WorkflowType              *shared.WorkflowType          `json:"workflowType,omitempty"`
-	PreviousStartedEventId    *int64                        `json:"previousStartedEventId,omitempty"`
-	StartedEventId            *int64                        `json:"startedEventId,omitempty"`
-	Attempt                   *int64                        `json:"attempt,omitempty"`
-	NextEventId               *int64                        `json:"nextEventId,omitempty"`
-	BacklogCountHint          *int64                        `json:"backlogCountHint,omitempty"`
-	StickyExecutionEnabled    *bool                         `json:"stickyExecutionEnabled,omitempty"`
-	Query                     *shared.WorkflowQuery         `json:"query,omitempty"`
-	DecisionInfo              *shared.TransientDecisionInfo `json:"decisionInfo,omitempty"`
-	WorkflowExecutionTaskList *shared.TaskList              `json:"WorkflowExecutionTaskList,omitempty"`
-	EventStoreVersion         *int32                        `json:"eventStoreVersion,omitempty"`
-	BranchToken               []byte                        `json:"branchToken,omitempty"`
-	ScheduledTimestamp        *int64                        `json:"scheduledTimestamp,omitempty"`
-	StartedTimestamp          *int64                        `json:"startedTimestamp,omitempty"`
+type PollForActivityTaskRequest struct {
+	DomainUUID  *string                            `json:"domainUUID,omitempty"`
+	PollerID    *string                            `json:"pollerID,omitempty"`
+	PollRequest *shared.PollForActivityTaskRequest `json:"pollRequest,omitempty"`
 }
 
-// ToWire translates a PollForDecisionTaskResponse struct into a Thrift-level intermediate
+// ToWire translates a PollForActivityTaskRequest struct into a Thrift-level intermediate
 // representation. This intermediate representation may be serialized
 // into bytes using a ThriftRW protocol implementation.
 //
@@ -1690,169 +1633,53 @@ type PollForDecisionTaskResponse struct {
 //   if err := binaryProtocol.Encode(x, writer); err != nil {
 //     return err
 //   }
-func (v *PollForDecisionTaskResponse) ToWire() (wire.Value, error) {
+func (v *PollForActivityTaskRequest) ToWire() (wire.Value, error) {
 	var (
-		fields [16]wire.Field
+		fields [3]wire.Field
 		i      int = 0
 		w      wire.Value
 		err    error
 	)
 
-	if v.TaskToken != nil {
-		w, err = wire.NewValueBinary(v.TaskToken), error(nil)
+	if v.DomainUUID != nil {
+		w, err = wire.NewValueString(*(v.DomainUUID)), error(nil)
 		if err != nil {
 			return w, err
 		}
 		fields[i] = wire.Field{ID: 10, Value: w}
 		i++
 	}
-	if v.WorkflowExecution != nil {
-		w, err = v.WorkflowExecution.ToWire()
-		if err != nil {
-			return w, err
-		}
-		fields[i] = wire.Field{ID: 20, Value: w}
-		i++
-	}
-	if v.WorkflowType != nil {
-		w, err = v.WorkflowType.ToWire()
-		if err != nil {
-			return w, err
-		}
-		fields[i] = wire.Field{ID: 30, Value: w}
-		i++
-	}
-	if v.PreviousStartedEventId != nil {
-		w, err = wire.NewValueI64(*(v.PreviousStartedEventId)), error(nil)
-		if err != nil {
-			return w, err
-		}
-		fields[i] = wire.Field{ID: 40, Value: w}
-		i++
-	}
-	if v.StartedEventId != nil {
-		w, err = wire.NewValueI64(*(v.StartedEventId)), error(nil)
-		if err != nil {
-			return w, err
-		}
-		fields[i] = wire.Field{ID: 50, Value: w}
-		i++
-	}
-	if v.Attempt != nil {
-		w, err = wire.NewValueI64(*(v.Attempt)), error(nil)
-		if err != nil {
-			return w, err
-		}
-		fields[i] = wire.Field{ID: 51, Value: w}
-		i++
-	}
-	if v.NextEventId != nil {
-		w, err = wire.NewValueI64(*(v.NextEventId)), error(nil)
-		if err != nil {
-			return w, err
-		}
-		fields[i] = wire.Field{ID: 60, Value: w}
-		i++
-	}
-	if v.BacklogCountHint != nil {
-		w, err = wire.NewValueI64(*(v.BacklogCountHint)), error(nil)
-		if err != nil {
-			return w, err
-		}
-		fields[i] = wire.Field{ID: 65, Value: w}
-		i++
-	}
-	if v.StickyExecutionEnabled != nil {
-		w, err = wire.NewValueBool(*(v.StickyExecutionEnabled)), error(nil)
-		if err != nil {
-			return w, err
-		}
-		fields[i] = wire.Field{ID: 70, Value: w}
-		i++
-	}
-	if v.Query != nil {
-		w, err = v.Query.ToWire()
-		if err != nil {
-			return w, err
-		}
-		fields[i] = wire.Field{ID: 80, Value: w}
-		i++
-	}
-	if v.DecisionInfo != nil {
-		w, err = v.DecisionInfo.ToWire()
-		if err != nil {
-			return w, err
-		}
-		fields[i] = wire.Field{ID: 90, Value: w}
-		i++
-	}
-	if v.WorkflowExecutionTaskList != nil {
-		w, err = v.WorkflowExecutionTaskList.ToWire()
-		if err != nil {
-			return w, err
-		}
-		fields[i] = wire.Field{ID: 100, Value: w}
-		i++
-	}
-	if v.EventStoreVersion != nil {
-		w, err = wire.NewValueI32(*(v.EventStoreVersion)), error(nil)
-		if err != nil {
-			return w, err
-		}
-		fields[i] = wire.Field{ID: 110, Value: w}
-		i++
-	}
-	if v.BranchToken != nil {
-		w, err = wire.NewValueBinary(v.BranchToken), error(nil)
-		if err != nil {
-			return w, err
-		}
-		fields[i] = wire.Field{ID: 120, Value: w}
-		i++
-	}
-	if v.ScheduledTimestamp != nil {
-		w, err = wire.NewValueI64(*(v.ScheduledTimestamp)), error(nil)
+	if v.PollerID != nil {
+		w, err = wire.NewValueString(*(v.PollerID)), error(nil)
 		if err != nil {
 			return w, err
 		}
-		fields[i] = wire.Field{ID: 130, Value: w}
+		fields[i] = wire.Field{ID: 15, Value: w}
 		i++
 	}
-	if v.StartedTimestamp != nil {
-		w, err = wire.NewValueI64(*(v.StartedTimestamp)), error(nil)
+	if v.PollRequest != nil {
+		w, err = v.PollRequest.ToWire()
 		if err != nil {
 			return w, err
 		}
-		fields[i] = wire.Field{ID: 140, Value: w}
+		fields[i] = wire.Field{ID: 20, Value: w}
 		i++
 	}
 
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
 
-func _WorkflowType_Read(w wire.Value) (*shared.WorkflowType, error) {
-	var v shared.WorkflowType
-	err := v.FromWire(w)
-	return &v, err
-}
-
-func _WorkflowQuery_Read(w wire.Value) (*shared.WorkflowQuery, error) {
-	var v shared.WorkflowQuery
-	err := v.FromWire(w)
-	return &v, err
-}
-
-func _TransientDecisionInfo_Read(w wire.Value) (*shared.TransientDecisionInfo, error) {
-	var v shared.TransientDecisionInfo
+func _PollForActivityTaskRequest_Read(w wire.Value) (*shared.PollForActivityTaskRequest, error) {
+	var v shared.PollForActivityTaskRequest
 	err := v.FromWire(w)
 	return &v, err
 }
 
-// FromWire deserializes a PollForDecisionTaskResponse struct from its Thrift-level
+// FromWire deserializes a PollForActivityTaskRequest struct from its Thrift-level
 // representation. The Thrift-level representation may be obtained
 // from a ThriftRW protocol implementation.
 //
-// An error is returned if we were unable to build a PollForDecisionTaskResponse struct
+// An error is returned if we were unable to build a PollForActivityTaskRequest struct
 // from the provided intermediate representation.
 //
 //   x, err := binaryProtocol.Decode(reader, wire.TStruct)
@@ -1860,311 +1687,92 @@ func _TransientDecisionInfo_Read(w wire.Value) (*shared.TransientDecisionInfo, e
 //     return nil, err
 //   }
 //
-//   var v PollForDecisionTaskResponse
+//   var v PollForActivityTaskRequest
 //   if err := v.FromWire(x); err != nil {
 //     return nil, err
 //   }
 //   return &v, nil
-func (v *PollForDecisionTaskResponse) FromWire(w wire.Value) error {
+func (v *PollForActivityTaskRequest) FromWire(w wire.Value) error {
 	var err error
 
 	for _, field := range w.GetStruct().Fields {
 		switch field.ID {
 		case 10:
 			if field.Value.Type() == wire.TBinary {
-				v.TaskToken, err = field.Value.GetBinary(), error(nil)
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.DomainUUID = &x
 				if err != nil {
 					return err
 				}
 
 			}
-		case 20:
-			if field.Value.Type() == wire.TStruct {
-				v.WorkflowExecution, err = _WorkflowExecution_Read(field.Value)
+		case 15:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.PollerID = &x
 				if err != nil {
 					return err
 				}
 
 			}
-		case 30:
+		case 20:
 			if field.Value.Type() == wire.TStruct {
-				v.WorkflowType, err = _WorkflowType_Read(field.Value)
-				if err != nil {
-					return err
-				}
-
-			}
-		case 40:
-			if field.Value.Type() == wire.TI64 {
-				var x int64
-				x, err = field.Value.GetI64(), error(nil)
-				v.PreviousStartedEventId = &x
+				v.PollRequest, err = _PollForActivityTaskRequest_Read(field.Value)
 				if err != nil {
 					return err
 				}
 
 			}
-		case 50:
-			if field.Value.Type() == wire.TI64 {
-				var x int64
-				x, err = field.Value.GetI64(), error(nil)
-				v.StartedEventId = &x
-				if err != nil {
-					return err
-				}
+		}
+	}
 
-			}
-		case 51:
-			if field.Value.Type() == wire.TI64 {
-				var x int64
-				x, err = field.Value.GetI64(), error(nil)
-				v.Attempt = &x
-				if err != nil {
-					return err
-				}
+	return nil
+}
 
-			}
-		case 60:
-			if field.Value.Type() == wire.TI64 {
-				var x int64
-				x, err = field.Value.GetI64(), error(nil)
-				v.NextEventId = &x
-				if err != nil {
-					return err
-				}
+// String returns a readable string representation of a PollForActivityTaskRequest
+// struct.
+func (v *PollForActivityTaskRequest) String() string {
+	if v == nil {
+		return "<nil>"
+	}
 
-			}
-		case 65:
-			if field.Value.Type() == wire.TI64 {
-				var x int64
-				x, err = field.Value.GetI64(), error(nil)
-				v.BacklogCountHint = &x
-				if err != nil {
-					return err
-				}
-
-			}
-		case 70:
-			if field.Value.Type() == wire.TBool {
-				var x bool
-				x, err = field.Value.GetBool(), error(nil)
-				v.StickyExecutionEnabled = &x
-				if err != nil {
-					return err
-				}
-
-			}
-		case 80:
-			if field.Value.Type() == wire.TStruct {
-				v.Query, err = _WorkflowQuery_Read(field.Value)
-				if err != nil {
-					return err
-				}
-
-			}
-		case 90:
-			if field.Value.Type() == wire.TStruct {
-				v.DecisionInfo, err = _TransientDecisionInfo_Read(field.Value)
-				if err != nil {
-					return err
-				}
-
-			}
-		case 100:
-			if field.Value.Type() == wire.TStruct {
-				v.WorkflowExecutionTaskList, err = _TaskList_Read(field.Value)
-				if err != nil {
-					return err
-				}
-
-			}
-		case 110:
-			if field.Value.Type() == wire.TI32 {
-				var x int32
-				x, err = field.Value.GetI32(), error(nil)
-				v.EventStoreVersion = &x
-				if err != nil {
-					return err
-				}
-
-			}
-		case 120:
-			if field.Value.Type() == wire.TBinary {
-				v.BranchToken, err = field.Value.GetBinary(), error(nil)
-				if err != nil {
-					return err
-				}
-
-			}
-		case 130:
-			if field.Value.Type() == wire.TI64 {
-				var x int64
-				x, err = field.Value.GetI64(), error(nil)
-				v.ScheduledTimestamp = &x
-				if err != nil {
-					return err
-				}
-
-			}
-		case 140:
-			if field.Value.Type() == wire.TI64 {
-				var x int64
-				x, err = field.Value.GetI64(), error(nil)
-				v.StartedTimestamp = &x
-				if err != nil {
-					return err
-				}
-
-			}
-		}
-	}
-
-	return nil
-}
-
-// String returns a readable string representation of a PollForDecisionTaskResponse
-// struct.
-func (v *PollForDecisionTaskResponse) String() string {
-	if v == nil {
-		return "<nil>"
-	}
-
-	var fields [16]string
+	var fields [3]string
 	i := 0
-	if v.TaskToken != nil {
-		fields[i] = fmt.Sprintf("TaskToken: %v", v.TaskToken)
-		i++
-	}
-	if v.WorkflowExecution != nil {
-		fields[i] = fmt.Sprintf("WorkflowExecution: %v", v.WorkflowExecution)
-		i++
-	}
-	if v.WorkflowType != nil {
-		fields[i] = fmt.Sprintf("WorkflowType: %v", v.WorkflowType)
-		i++
-	}
-	if v.PreviousStartedEventId != nil {
-		fields[i] = fmt.Sprintf("PreviousStartedEventId: %v", *(v.PreviousStartedEventId))
-		i++
-	}
-	if v.StartedEventId != nil {
-		fields[i] = fmt.Sprintf("StartedEventId: %v", *(v.StartedEventId))
-		i++
-	}
-	if v.Attempt != nil {
-		fields[i] = fmt.Sprintf("Attempt: %v", *(v.Attempt))
-		i++
-	}
-	if v.NextEventId != nil {
-		fields[i] = fmt.Sprintf("NextEventId: %v", *(v.NextEventId))
-		i++
-	}
-	if v.BacklogCountHint != nil {
-		fields[i] = fmt.Sprintf("BacklogCountHint: %v", *(v.BacklogCountHint))
-		i++
-	}
-	if v.StickyExecutionEnabled != nil {
-		fields[i] = fmt.Sprintf("StickyExecutionEnabled: %v", *(v.StickyExecutionEnabled))
-		i++
-	}
-	if v.Query != nil {
-		fields[i] = fmt.Sprintf("Query: %v", v.Query)
-		i++
-	}
-	if v.DecisionInfo != nil {
-		fields[i] = fmt.Sprintf("DecisionInfo: %v", v.DecisionInfo)
-		i++
-	}
-	if v.WorkflowExecutionTaskList != nil {
-		fields[i] = fmt.Sprintf("WorkflowExecutionTaskList: %v", v.WorkflowExecutionTaskList)
-		i++
-	}
-	if v.EventStoreVersion != nil {
-		fields[i] = fmt.Sprintf("EventStoreVersion: %v", *(v.EventStoreVersion))
-		i++
-	}
-	if v.BranchToken != nil {
-		fields[i] = fmt.Sprintf("BranchToken: %v", v.BranchToken)
+	if v.DomainUUID != nil {
+		fields[i] = fmt.Sprintf("DomainUUID: %v", *(v.DomainUUID))
 		i++
 	}
-	if v.ScheduledTimestamp != nil {
-		fields[i] = fmt.Sprintf("ScheduledTimestamp: %v", *(v.ScheduledTimestamp))
+	if v.PollerID != nil {
+		fields[i] = fmt.Sprintf("PollerID: %v", *(v.PollerID))
 		i++
 	}
-	if v.StartedTimestamp != nil {
-		fields[i] = fmt.Sprintf("StartedTimestamp: %v", *(v.StartedTimestamp))
+	if v.PollRequest != nil {
+		fields[i] = fmt.Sprintf("PollRequest: %v", v.PollRequest)
 		i++
 	}
 
-	return fmt.Sprintf("PollForDecisionTaskResponse{%v}", strings.Join(fields[:i], ", "))
-}
-
-func _Bool_EqualsPtr(lhs, rhs *bool) bool {
-	if lhs != nil && rhs != nil {
-
-		x := *lhs
-		y := *rhs
-		return (x == y)
-	}
-	return lhs == nil && rhs == nil
+	return fmt.Sprintf("PollForActivityTaskRequest{%v}", strings.Join(fields[:i], ", "))
 }
 
-// Equals returns true if all the fields of this PollForDecisionTaskResponse match the
-// provided PollForDecisionTaskResponse.
+// Equals returns true if all the fields of this PollForActivityTaskRequest match the
+// provided PollForActivityTaskRequest.
 //
 // This function performs a deep comparison.
-func (v *PollForDecisionTaskResponse) Equals(rhs *PollForDecisionTaskResponse) bool {
+func (v *PollForActivityTaskRequest) Equals(rhs *PollForActivityTaskRequest) bool {
 	if v == nil {
 		return rhs == nil
 	} else if rhs == nil {
 		return false
 	}
-	if !((v.TaskToken == nil && rhs.TaskToken == nil) || (v.TaskToken != nil && rhs.TaskToken != nil && bytes.Equal(v.TaskToken, rhs.TaskToken))) {
-		return false
-	}
-	if !((v.WorkflowExecution == nil && rhs.WorkflowExecution == nil) || (v.WorkflowExecution != nil && rhs.WorkflowExecution != nil && v.WorkflowExecution.Equals(rhs.WorkflowExecution))) {
-		return false
-	}
-	if !((v.WorkflowType == nil && rhs.WorkflowType == nil) || (v.WorkflowType != nil && rhs.WorkflowType != nil && v.WorkflowType.Equals(rhs.WorkflowType))) {
-		return false
-	}
-	if !_I64_EqualsPtr(v.PreviousStartedEventId, rhs.PreviousStartedEventId) {
-		return false
-	}
-	if !_I64_EqualsPtr(v.StartedEventId, rhs.StartedEventId) {
-		return false
-	}
-	if !_I64_EqualsPtr(v.Attempt, rhs.Attempt) {
-		return false
-	}
-	if !_I64_EqualsPtr(v.NextEventId, rhs.NextEventId) {
-		return false
-	}
-	if !_I64_EqualsPtr(v.BacklogCountHint, rhs.BacklogCountHint) {
-		return false
-	}
-	if !_Bool_EqualsPtr(v.StickyExecutionEnabled, rhs.StickyExecutionEnabled) {
-		return false
-	}
-	if !((v.Query == nil && rhs.Query == nil) || (v.Query != nil && rhs.Query != nil && v.Query.Equals(rhs.Query))) {
-		return false
-	}
-	if !((v.DecisionInfo == nil && rhs.DecisionInfo == nil) || (v.DecisionInfo != nil && rhs.DecisionInfo != nil && v.DecisionInfo.Equals(rhs.DecisionInfo))) {
-		return false
-	}
-	if !((v.WorkflowExecutionTaskList == nil && rhs.WorkflowExecutionTaskList == nil) || (v.WorkflowExecutionTaskList != nil && rhs.WorkflowExecutionTaskList != nil && v.WorkflowExecutionTaskList.Equals(rhs.WorkflowExecutionTaskList))) {
-		return false
-	}
-	if !_I32_EqualsPtr(v.EventStoreVersion, rhs.EventStoreVersion) {
-		return false
-	}
-	if !((v.BranchToken == nil && rhs.BranchToken == nil) || (v.BranchToken != nil && rhs.BranchToken != nil && bytes.Equal(v.BranchToken, rhs.BranchToken))) {
+	if !_String_EqualsPtr(v.DomainUUID, rhs.DomainUUID) {
 		return false
 	}
-	if !_I64_EqualsPtr(v.ScheduledTimestamp, rhs.ScheduledTimestamp) {
+	if !_String_EqualsPtr(v.PollerID, rhs.PollerID) {
 		return false
 	}
-	if !_I64_EqualsPtr(v.StartedTimestamp, rhs.StartedTimestamp) {
+	if !((v.PollRequest == nil && rhs.PollRequest == nil) || (v.PollRequest != nil && rhs.PollRequest != nil && v.PollRequest.Equals(rhs.PollRequest))) {
 		return false
 	}
 
@@ -2172,309 +1780,3454 @@ func (v *PollForDecisionTaskResponse) Equals(rhs *PollForDecisionTaskResponse) b
 }
 
 // MarshalLogObject implements zapcore.ObjectMarshaler, enabling
-// fast logging of PollForDecisionTaskResponse.
-func (v *PollForDecisionTaskResponse) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+// fast logging of PollForActivityTaskRequest.
+func (v *PollForActivityTaskRequest) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
 	if v == nil {
 		return nil
 	}
-	if v.TaskToken != nil {
-		enc.AddString("taskToken", base64.StdEncoding.EncodeToString(v.TaskToken))
-	}
-	if v.WorkflowExecution != nil {
-		err = multierr.Append(err, enc.AddObject("workflowExecution", v.WorkflowExecution))
-	}
-	if v.WorkflowType != nil {
-		err = multierr.Append(err, enc.AddObject("workflowType", v.WorkflowType))
-	}
-	if v.PreviousStartedEventId != nil {
-		enc.AddInt64("previousStartedEventId", *v.PreviousStartedEventId)
-	}
-	if v.StartedEventId != nil {
-		enc.AddInt64("startedEventId", *v.StartedEventId)
+	if v.DomainUUID != nil {
+		enc.AddString("domainUUID", *v.DomainUUID)
 	}
-	if v.Attempt != nil {
-		enc.AddInt64("attempt", *v.Attempt)
+	if v.PollerID != nil {
+		enc.AddString("pollerID", *v.PollerID)
 	}
-	if v.NextEventId != nil {
-		enc.AddInt64("nextEventId", *v.NextEventId)
+	if v.PollRequest != nil {
+		err = multierr.Append(err, enc.AddObject("pollRequest", v.PollRequest))
 	}
-	if v.BacklogCountHint != nil {
-		enc.AddInt64("backlogCountHint", *v.BacklogCountHint)
-	}
-	if v.StickyExecutionEnabled != nil {
-		enc.AddBool("stickyExecutionEnabled", *v.StickyExecutionEnabled)
-	}
-	if v.Query != nil {
-		err = multierr.Append(err, enc.AddObject("query", v.Query))
-	}
-	if v.DecisionInfo != nil {
-		err = multierr.Append(err, enc.AddObject("decisionInfo", v.DecisionInfo))
-	}
-	if v.WorkflowExecutionTaskList != nil {
-		err = multierr.Append(err, enc.AddObject("WorkflowExecutionTaskList", v.WorkflowExecutionTaskList))
-	}
-	if v.EventStoreVersion != nil {
-		enc.AddInt32("eventStoreVersion", *v.EventStoreVersion)
-	}
-	if v.BranchToken != nil {
-		enc.AddString("branchToken", base64.StdEncoding.EncodeToString(v.BranchToken))
-	}
-	if v.ScheduledTimestamp != nil {
-		enc.AddInt64("scheduledTimestamp", *v.ScheduledTimestamp)
-	}
-	if v.StartedTimestamp != nil {
-		enc.AddInt64("startedTimestamp", *v.StartedTimestamp)
-	}
-	return err
-}
-
-// GetTaskToken returns the value of TaskToken if it is set or its
-// zero value if it is unset.
-func (v *PollForDecisionTaskResponse) GetTaskToken() (o []byte) {
-	if v != nil && v.TaskToken != nil {
-		return v.TaskToken
+	return err
+}
+
+// GetDomainUUID returns the value of DomainUUID if it is set or its
+// zero value if it is unset.
+func (v *PollForActivityTaskRequest) GetDomainUUID() (o string) {
+	if v != nil && v.DomainUUID != nil {
+		return *v.DomainUUID
 	}
 
 	return
 }
 
-// IsSetTaskToken returns true if TaskToken is not nil.
-func (v *PollForDecisionTaskResponse) IsSetTaskToken() bool {
-	return v != nil && v.TaskToken != nil
+// IsSetDomainUUID returns true if DomainUUID is not nil.
+func (v *PollForActivityTaskRequest) IsSetDomainUUID() bool {
+	return v != nil && v.DomainUUID != nil
 }
 
-// GetWorkflowExecution returns the value of WorkflowExecution if it is set or its
+// GetPollerID returns the value of PollerID if it is set or its
 // zero value if it is unset.
-func (v *PollForDecisionTaskResponse) GetWorkflowExecution() (o *shared.WorkflowExecution) {
-	if v != nil && v.WorkflowExecution != nil {
-		return v.WorkflowExecution
+func (v *PollForActivityTaskRequest) GetPollerID() (o string) {
+	if v != nil && v.PollerID != nil {
+		return *v.PollerID
 	}
 
 	return
 }
 
-// IsSetWorkflowExecution returns true if WorkflowExecution is not nil.
-func (v *PollForDecisionTaskResponse) IsSetWorkflowExecution() bool {
-	return v != nil && v.WorkflowExecution != nil
+// IsSetPollerID returns true if PollerID is not nil.
+func (v *PollForActivityTaskRequest) IsSetPollerID() bool {
+	return v != nil && v.PollerID != nil
 }
 
-// GetWorkflowType returns the value of WorkflowType if it is set or its
+// GetPollRequest returns the value of PollRequest if it is set or its
 // zero value if it is unset.
-func (v *PollForDecisionTaskResponse) GetWorkflowType() (o *shared.WorkflowType) {
-	if v != nil && v.WorkflowType != nil {
-		return v.WorkflowType
+func (v *PollForActivityTaskRequest) GetPollRequest() (o *shared.PollForActivityTaskRequest) {
+	if v != nil && v.PollRequest != nil {
+		return v.PollRequest
 	}
 
 	return
 }
 
-// IsSetWorkflowType returns true if WorkflowType is not nil.
-func (v *PollForDecisionTaskResponse) IsSetWorkflowType() bool {
-	return v != nil && v.WorkflowType != nil
+// IsSetPollRequest returns true if PollRequest is not nil.
+func (v *PollForActivityTaskRequest) IsSetPollRequest() bool {
+	return v != nil && v.PollRequest != nil
 }
 
-// GetPreviousStartedEventId returns the value of PreviousStartedEventId if it is set or its
-// zero value if it is unset.
-func (v *PollForDecisionTaskResponse) GetPreviousStartedEventId() (o int64) {
-	if v != nil && v.PreviousStartedEventId != nil {
-		return *v.PreviousStartedEventId
-	}
-
-	return
+type PollForDecisionTaskRequest struct {
+	DomainUUID  *string                            `json:"domainUUID,omitempty"`
+	PollerID    *string                            `json:"pollerID,omitempty"`
+	PollRequest *shared.PollForDecisionTaskRequest `json:"pollRequest,omitempty"`
 }
 
-// IsSetPreviousStartedEventId returns true if PreviousStartedEventId is not nil.
-func (v *PollForDecisionTaskResponse) IsSetPreviousStartedEventId() bool {
-	return v != nil && v.PreviousStartedEventId != nil
-}
+// ToWire translates a PollForDecisionTaskRequest struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *PollForDecisionTaskRequest) ToWire() (wire.Value, error) {
+	var (
+		fields [3]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
 
-// GetStartedEventId returns the value of StartedEventId if it is set or its
-// zero value if it is unset.
-func (v *PollForDecisionTaskResponse) GetStartedEventId() (o int64) {
-	if v != nil && v.StartedEventId != nil {
-		return *v.StartedEventId
+	if v.DomainUUID != nil {
+		w, err = wire.NewValueString(*(v.DomainUUID)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 10, Value: w}
+		i++
 	}
-
-	return
-}
-
-// IsSetStartedEventId returns true if StartedEventId is not nil.
-func (v *PollForDecisionTaskResponse) IsSetStartedEventId() bool {
-	return v != nil && v.StartedEventId != nil
-}
-
-// GetAttempt returns the value of Attempt if it is set or its
-// zero value if it is unset.
-func (v *PollForDecisionTaskResponse) GetAttempt() (o int64) {
-	if v != nil && v.Attempt != nil {
-		return *v.Attempt
+	if v.PollerID != nil {
+		w, err = wire.NewValueString(*(v.PollerID)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 15, Value: w}
+		i++
+	}
+	if v.PollRequest != nil {
+		w, err = v.PollRequest.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 20, Value: w}
+		i++
 	}
 
-	return
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
 
-// IsSetAttempt returns true if Attempt is not nil.
-func (v *PollForDecisionTaskResponse) IsSetAttempt() bool {
-	return v != nil && v.Attempt != nil
+func _PollForDecisionTaskRequest_Read(w wire.Value) (*shared.PollForDecisionTaskRequest, error) {
+	var v shared.PollForDecisionTaskRequest
+	err := v.FromWire(w)
+	return &v, err
 }
 
-// GetNextEventId returns the value of NextEventId if it is set or its
-// zero value if it is unset.
-func (v *PollForDecisionTaskResponse) GetNextEventId() (o int64) {
-	if v != nil && v.NextEventId != nil {
-		return *v.NextEventId
-	}
+// FromWire deserializes a PollForDecisionTaskRequest struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a PollForDecisionTaskRequest struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v PollForDecisionTaskRequest
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *PollForDecisionTaskRequest) FromWire(w wire.Value) error {
+	var err error
 
-	return
-}
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 10:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.DomainUUID = &x
+				if err != nil {
+					return err
+				}
 
-// IsSetNextEventId returns true if NextEventId is not nil.
-func (v *PollForDecisionTaskResponse) IsSetNextEventId() bool {
-	return v != nil && v.NextEventId != nil
-}
+			}
+		case 15:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.PollerID = &x
+				if err != nil {
+					return err
+				}
 
-// GetBacklogCountHint returns the value of BacklogCountHint if it is set or its
-// zero value if it is unset.
-func (v *PollForDecisionTaskResponse) GetBacklogCountHint() (o int64) {
-	if v != nil && v.BacklogCountHint != nil {
-		return *v.BacklogCountHint
+			}
+		case 20:
+			if field.Value.Type() == wire.TStruct {
+				v.PollRequest, err = _PollForDecisionTaskRequest_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		}
 	}
 
-	return
+	return nil
 }
 
-// IsSetBacklogCountHint returns true if BacklogCountHint is not nil.
-func (v *PollForDecisionTaskResponse) IsSetBacklogCountHint() bool {
-	return v != nil && v.BacklogCountHint != nil
-}
+// String returns a readable string representation of a PollForDecisionTaskRequest
+// struct.
+func (v *PollForDecisionTaskRequest) String() string {
+	if v == nil {
+		return "<nil>"
+	}
 
-// GetStickyExecutionEnabled returns the value of StickyExecutionEnabled if it is set or its
-// zero value if it is unset.
-func (v *PollForDecisionTaskResponse) GetStickyExecutionEnabled() (o bool) {
-	if v != nil && v.StickyExecutionEnabled != nil {
-		return *v.StickyExecutionEnabled
+	var fields [3]string
+	i := 0
+	if v.DomainUUID != nil {
+		fields[i] = fmt.Sprintf("DomainUUID: %v", *(v.DomainUUID))
+		i++
+	}
+	if v.PollerID != nil {
+		fields[i] = fmt.Sprintf("PollerID: %v", *(v.PollerID))
+		i++
+	}
+	if v.PollRequest != nil {
+		fields[i] = fmt.Sprintf("PollRequest: %v", v.PollRequest)
+		i++
 	}
 
-	return
+	return fmt.Sprintf("PollForDecisionTaskRequest{%v}", strings.Join(fields[:i], ", "))
 }
 
-// IsSetStickyExecutionEnabled returns true if StickyExecutionEnabled is not nil.
-func (v *PollForDecisionTaskResponse) IsSetStickyExecutionEnabled() bool {
-	return v != nil && v.StickyExecutionEnabled != nil
+// Equals returns true if all the fields of this PollForDecisionTaskRequest match the
+// provided PollForDecisionTaskRequest.
+//
+// This function performs a deep comparison.
+func (v *PollForDecisionTaskRequest) Equals(rhs *PollForDecisionTaskRequest) bool {
+	if v == nil {
+		return rhs == nil
+	} else if rhs == nil {
+		return false
+	}
+	if !_String_EqualsPtr(v.DomainUUID, rhs.DomainUUID) {
+		return false
+	}
+	if !_String_EqualsPtr(v.PollerID, rhs.PollerID) {
+		return false
+	}
+	if !((v.PollRequest == nil && rhs.PollRequest == nil) || (v.PollRequest != nil && rhs.PollRequest != nil && v.PollRequest.Equals(rhs.PollRequest))) {
+		return false
+	}
+
+	return true
+}
+
+// MarshalLogObject implements zapcore.ObjectMarshaler, enabling
+// fast logging of PollForDecisionTaskRequest.
+func (v *PollForDecisionTaskRequest) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+	if v == nil {
+		return nil
+	}
+	if v.DomainUUID != nil {
+		enc.AddString("domainUUID", *v.DomainUUID)
+	}
+	if v.PollerID != nil {
+		enc.AddString("pollerID", *v.PollerID)
+	}
+	if v.PollRequest != nil {
+		err = multierr.Append(err, enc.AddObject("pollRequest", v.PollRequest))
+	}
+	return err
+}
+
+// GetDomainUUID returns the value of DomainUUID if it is set or its
+// zero value if it is unset.
+func (v *PollForDecisionTaskRequest) GetDomainUUID() (o string) {
+	if v != nil && v.DomainUUID != nil {
+		return *v.DomainUUID
+	}
+
+	return
+}
+
+// IsSetDomainUUID returns true if DomainUUID is not nil.
+func (v *PollForDecisionTaskRequest) IsSetDomainUUID() bool {
+	return v != nil && v.DomainUUID != nil
+}
+
+// GetPollerID returns the value of PollerID if it is set or its
+// zero value if it is unset.
+func (v *PollForDecisionTaskRequest) GetPollerID() (o string) {
+	if v != nil && v.PollerID != nil {
+		return *v.PollerID
+	}
+
+	return
+}
+
+// IsSetPollerID returns true if PollerID is not nil.
+func (v *PollForDecisionTaskRequest) IsSetPollerID() bool {
+	return v != nil && v.PollerID != nil
+}
+
+// GetPollRequest returns the value of PollRequest if it is set or its
+// zero value if it is unset.
+func (v *PollForDecisionTaskRequest) GetPollRequest() (o *shared.PollForDecisionTaskRequest) {
+	if v != nil && v.PollRequest != nil {
+		return v.PollRequest
+	}
+
+	return
+}
+
+// IsSetPollRequest returns true if PollRequest is not nil.
+func (v *PollForDecisionTaskRequest) IsSetPollRequest() bool {
+	return v != nil && v.PollRequest != nil
+}
+
+type PollForDecisionTaskResponse struct {
+	TaskToken                 []byte                        `json:"taskToken,omitempty"`
+	WorkflowExecution         *shared.WorkflowExecution     `json:"workflowExecution,omitempty"`
+	WorkflowType              *shared.WorkflowType          `json:"workflowType,omitempty"`
+	PreviousStartedEventId    *int64                        `json:"previousStartedEventId,omitempty"`
+	StartedEventId            *int64                        `json:"startedEventId,omitempty"`
+	Attempt                   *int64                        `json:"attempt,omitempty"`
+	NextEventId               *int64                        `json:"nextEventId,omitempty"`
+	BacklogCountHint          *int64                        `json:"backlogCountHint,omitempty"`
+	StickyExecutionEnabled    *bool                         `json:"stickyExecutionEnabled,omitempty"`
+	Query                     *shared.WorkflowQuery         `json:"query,omitempty"`
+	DecisionInfo              *shared.TransientDecisionInfo `json:"decisionInfo,omitempty"`
+	WorkflowExecutionTaskList *shared.TaskList              `json:"WorkflowExecutionTaskList,omitempty"`
+	EventStoreVersion         *int32                        `json:"eventStoreVersion,omitempty"`
+	BranchToken               []byte                        `json:"branchToken,omitempty"`
+	ScheduledTimestamp        *int64                        `json:"scheduledTimestamp,omitempty"`
+	StartedTimestamp          *int64                        `json:"startedTimestamp,omitempty"`
+	ContinueAsNewSuggested    *bool                         `json:"continueAsNewSuggested,omitempty"`
+	PendingUpdates            []*shared.WorkflowUpdate      `json:"pendingUpdates,omitempty"`
+	ScalingHints              *shared.TaskListScalingHints  `json:"scalingHints,omitempty"`
+}
+
+type _List_WorkflowUpdate_ValueList []*shared.WorkflowUpdate
+
+func (v _List_WorkflowUpdate_ValueList) ForEach(f func(wire.Value) error) error {
+	for i, x := range v {
+		if x == nil {
+			return fmt.Errorf("invalid [%v]: value is nil", i)
+		}
+		w, err := x.ToWire()
+		if err != nil {
+			return err
+		}
+		err = f(w)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (v _List_WorkflowUpdate_ValueList) Size() int {
+	return len(v)
+}
+
+func (_List_WorkflowUpdate_ValueList) ValueType() wire.Type {
+	return wire.TStruct
+}
+
+func (_List_WorkflowUpdate_ValueList) Close() {}
+
+// ToWire translates a PollForDecisionTaskResponse struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *PollForDecisionTaskResponse) ToWire() (wire.Value, error) {
+	var (
+		fields [19]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.TaskToken != nil {
+		w, err = wire.NewValueBinary(v.TaskToken), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 10, Value: w}
+		i++
+	}
+	if v.WorkflowExecution != nil {
+		w, err = v.WorkflowExecution.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 20, Value: w}
+		i++
+	}
+	if v.WorkflowType != nil {
+		w, err = v.WorkflowType.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 30, Value: w}
+		i++
+	}
+	if v.PreviousStartedEventId != nil {
+		w, err = wire.NewValueI64(*(v.PreviousStartedEventId)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 40, Value: w}
+		i++
+	}
+	if v.StartedEventId != nil {
+		w, err = wire.NewValueI64(*(v.StartedEventId)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 50, Value: w}
+		i++
+	}
+	if v.Attempt != nil {
+		w, err = wire.NewValueI64(*(v.Attempt)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 51, Value: w}
+		i++
+	}
+	if v.NextEventId != nil {
+		w, err = wire.NewValueI64(*(v.NextEventId)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 60, Value: w}
+		i++
+	}
+	if v.BacklogCountHint != nil {
+		w, err = wire.NewValueI64(*(v.BacklogCountHint)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 65, Value: w}
+		i++
+	}
+	if v.StickyExecutionEnabled != nil {
+		w, err = wire.NewValueBool(*(v.StickyExecutionEnabled)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 70, Value: w}
+		i++
+	}
+	if v.Query != nil {
+		w, err = v.Query.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 80, Value: w}
+		i++
+	}
+	if v.DecisionInfo != nil {
+		w, err = v.DecisionInfo.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 90, Value: w}
+		i++
+	}
+	if v.WorkflowExecutionTaskList != nil {
+		w, err = v.WorkflowExecutionTaskList.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 100, Value: w}
+		i++
+	}
+	if v.EventStoreVersion != nil {
+		w, err = wire.NewValueI32(*(v.EventStoreVersion)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 110, Value: w}
+		i++
+	}
+	if v.BranchToken != nil {
+		w, err = wire.NewValueBinary(v.BranchToken), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 120, Value: w}
+		i++
+	}
+	if v.ScheduledTimestamp != nil {
+		w, err = wire.NewValueI64(*(v.ScheduledTimestamp)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 130, Value: w}
+		i++
+	}
+	if v.StartedTimestamp != nil {
+		w, err = wire.NewValueI64(*(v.StartedTimestamp)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 140, Value: w}
+		i++
+	}
+	if v.ContinueAsNewSuggested != nil {
+		w, err = wire.NewValueBool(*(v.ContinueAsNewSuggested)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 150, Value: w}
+		i++
+	}
+	if v.PendingUpdates != nil {
+		w, err = wire.NewValueList(_List_WorkflowUpdate_ValueList(v.PendingUpdates)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 160, Value: w}
+		i++
+	}
+	if v.ScalingHints != nil {
+		w, err = v.ScalingHints.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 170, Value: w}
+		i++
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+func _WorkflowType_Read(w wire.Value) (*shared.WorkflowType, error) {
+	var v shared.WorkflowType
+	err := v.FromWire(w)
+	return &v, err
+}
+
+func _WorkflowQuery_Read(w wire.Value) (*shared.WorkflowQuery, error) {
+	var v shared.WorkflowQuery
+	err := v.FromWire(w)
+	return &v, err
+}
+
+func _TransientDecisionInfo_Read(w wire.Value) (*shared.TransientDecisionInfo, error) {
+	var v shared.TransientDecisionInfo
+	err := v.FromWire(w)
+	return &v, err
+}
+
+func _WorkflowUpdate_Read(w wire.Value) (*shared.WorkflowUpdate, error) {
+	var v shared.WorkflowUpdate
+	err := v.FromWire(w)
+	return &v, err
+}
+
+func _List_WorkflowUpdate_Read(l wire.ValueList) ([]*shared.WorkflowUpdate, error) {
+	if l.ValueType() != wire.TStruct {
+		return nil, nil
+	}
+
+	o := make([]*shared.WorkflowUpdate, 0, l.Size())
+	err := l.ForEach(func(x wire.Value) error {
+		i, err := _WorkflowUpdate_Read(x)
+		if err != nil {
+			return err
+		}
+		o = append(o, i)
+		return nil
+	})
+	l.Close()
+	return o, err
+}
+
+func _TaskListScalingHints_Read(w wire.Value) (*shared.TaskListScalingHints, error) {
+	var v shared.TaskListScalingHints
+	err := v.FromWire(w)
+	return &v, err
+}
+
+// FromWire deserializes a PollForDecisionTaskResponse struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a PollForDecisionTaskResponse struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v PollForDecisionTaskResponse
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *PollForDecisionTaskResponse) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 10:
+			if field.Value.Type() == wire.TBinary {
+				v.TaskToken, err = field.Value.GetBinary(), error(nil)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 20:
+			if field.Value.Type() == wire.TStruct {
+				v.WorkflowExecution, err = _WorkflowExecution_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 30:
+			if field.Value.Type() == wire.TStruct {
+				v.WorkflowType, err = _WorkflowType_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 40:
+			if field.Value.Type() == wire.TI64 {
+				var x int64
+				x, err = field.Value.GetI64(), error(nil)
+				v.PreviousStartedEventId = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 50:
+			if field.Value.Type() == wire.TI64 {
+				var x int64
+				x, err = field.Value.GetI64(), error(nil)
+				v.StartedEventId = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 51:
+			if field.Value.Type() == wire.TI64 {
+				var x int64
+				x, err = field.Value.GetI64(), error(nil)
+				v.Attempt = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 60:
+			if field.Value.Type() == wire.TI64 {
+				var x int64
+				x, err = field.Value.GetI64(), error(nil)
+				v.NextEventId = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 65:
+			if field.Value.Type() == wire.TI64 {
+				var x int64
+				x, err = field.Value.GetI64(), error(nil)
+				v.BacklogCountHint = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 70:
+			if field.Value.Type() == wire.TBool {
+				var x bool
+				x, err = field.Value.GetBool(), error(nil)
+				v.StickyExecutionEnabled = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 80:
+			if field.Value.Type() == wire.TStruct {
+				v.Query, err = _WorkflowQuery_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 90:
+			if field.Value.Type() == wire.TStruct {
+				v.DecisionInfo, err = _TransientDecisionInfo_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 100:
+			if field.Value.Type() == wire.TStruct {
+				v.WorkflowExecutionTaskList, err = _TaskList_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 110:
+			if field.Value.Type() == wire.TI32 {
+				var x int32
+				x, err = field.Value.GetI32(), error(nil)
+				v.EventStoreVersion = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 120:
+			if field.Value.Type() == wire.TBinary {
+				v.BranchToken, err = field.Value.GetBinary(), error(nil)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 130:
+			if field.Value.Type() == wire.TI64 {
+				var x int64
+				x, err = field.Value.GetI64(), error(nil)
+				v.ScheduledTimestamp = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 140:
+			if field.Value.Type() == wire.TI64 {
+				var x int64
+				x, err = field.Value.GetI64(), error(nil)
+				v.StartedTimestamp = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 150:
+			if field.Value.Type() == wire.TBool {
+				var x bool
+				x, err = field.Value.GetBool(), error(nil)
+				v.ContinueAsNewSuggested = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 160:
+			if field.Value.Type() == wire.TList {
+				v.PendingUpdates, err = _List_WorkflowUpdate_Read(field.Value.GetList())
+				if err != nil {
+					return err
+				}
+
+			}
+		case 170:
+			if field.Value.Type() == wire.TStruct {
+				v.ScalingHints, err = _TaskListScalingHints_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+
+	return nil
+}
+
+// String returns a readable string representation of a PollForDecisionTaskResponse
+// struct.
+func (v *PollForDecisionTaskResponse) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [19]string
+	i := 0
+	if v.TaskToken != nil {
+		fields[i] = fmt.Sprintf("TaskToken: %v", v.TaskToken)
+		i++
+	}
+	if v.WorkflowExecution != nil {
+		fields[i] = fmt.Sprintf("WorkflowExecution: %v", v.WorkflowExecution)
+		i++
+	}
+	if v.WorkflowType != nil {
+		fields[i] = fmt.Sprintf("WorkflowType: %v", v.WorkflowType)
+		i++
+	}
+	if v.PreviousStartedEventId != nil {
+		fields[i] = fmt.Sprintf("PreviousStartedEventId: %v", *(v.PreviousStartedEventId))
+		i++
+	}
+	if v.StartedEventId != nil {
+		fields[i] = fmt.Sprintf("StartedEventId: %v", *(v.StartedEventId))
+		i++
+	}
+	if v.Attempt != nil {
+		fields[i] = fmt.Sprintf("Attempt: %v", *(v.Attempt))
+		i++
+	}
+	if v.NextEventId != nil {
+		fields[i] = fmt.Sprintf("NextEventId: %v", *(v.NextEventId))
+		i++
+	}
+	if v.BacklogCountHint != nil {
+		fields[i] = fmt.Sprintf("BacklogCountHint: %v", *(v.BacklogCountHint))
+		i++
+	}
+	if v.StickyExecutionEnabled != nil {
+		fields[i] = fmt.Sprintf("StickyExecutionEnabled: %v", *(v.StickyExecutionEnabled))
+		i++
+	}
+	if v.Query != nil {
+		fields[i] = fmt.Sprintf("Query: %v", v.Query)
+		i++
+	}
+	if v.DecisionInfo != nil {
+		fields[i] = fmt.Sprintf("DecisionInfo: %v", v.DecisionInfo)
+		i++
+	}
+	if v.WorkflowExecutionTaskList != nil {
+		fields[i] = fmt.Sprintf("WorkflowExecutionTaskList: %v", v.WorkflowExecutionTaskList)
+		i++
+	}
+	if v.EventStoreVersion != nil {
+		fields[i] = fmt.Sprintf("EventStoreVersion: %v", *(v.EventStoreVersion))
+		i++
+	}
+	if v.BranchToken != nil {
+		fields[i] = fmt.Sprintf("BranchToken: %v", v.BranchToken)
+		i++
+	}
+	if v.ScheduledTimestamp != nil {
+		fields[i] = fmt.Sprintf("ScheduledTimestamp: %v", *(v.ScheduledTimestamp))
+		i++
+	}
+	if v.StartedTimestamp != nil {
+		fields[i] = fmt.Sprintf("StartedTimestamp: %v", *(v.StartedTimestamp))
+		i++
+	}
+	if v.ContinueAsNewSuggested != nil {
+		fields[i] = fmt.Sprintf("ContinueAsNewSuggested: %v", *(v.ContinueAsNewSuggested))
+		i++
+	}
+	if v.PendingUpdates != nil {
+		fields[i] = fmt.Sprintf("PendingUpdates: %v", v.PendingUpdates)
+		i++
+	}
+	if v.ScalingHints != nil {
+		fields[i] = fmt.Sprintf("ScalingHints: %v", v.ScalingHints)
+		i++
+	}
+
+	return fmt.Sprintf("PollForDecisionTaskResponse{%v}", strings.Join(fields[:i], ", "))
+}
+
+func _Bool_EqualsPtr(lhs, rhs *bool) bool {
+	if lhs != nil && rhs != nil {
+
+		x := *lhs
+		y := *rhs
+		return (x == y)
+	}
+	return lhs == nil && rhs == nil
+}
+
+func _List_WorkflowUpdate_Equals(lhs, rhs []*shared.WorkflowUpdate) bool {
+	if len(lhs) != len(rhs) {
+		return false
+	}
+
+	for i, lv := range lhs {
+		rv := rhs[i]
+		if !lv.Equals(rv) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Equals returns true if all the fields of this PollForDecisionTaskResponse match the
+// provided PollForDecisionTaskResponse.
+//
+// This function performs a deep comparison.
+func (v *PollForDecisionTaskResponse) Equals(rhs *PollForDecisionTaskResponse) bool {
+	if v == nil {
+		return rhs == nil
+	} else if rhs == nil {
+		return false
+	}
+	if !((v.TaskToken == nil && rhs.TaskToken == nil) || (v.TaskToken != nil && rhs.TaskToken != nil && bytes.Equal(v.TaskToken, rhs.TaskToken))) {
+		return false
+	}
+	if !((v.WorkflowExecution == nil && rhs.WorkflowExecution == nil) || (v.WorkflowExecution != nil && rhs.WorkflowExecution != nil && v.WorkflowExecution.Equals(rhs.WorkflowExecution))) {
+		return false
+	}
+	if !((v.WorkflowType == nil && rhs.WorkflowType == nil) || (v.WorkflowType != nil && rhs.WorkflowType != nil && v.WorkflowType.Equals(rhs.WorkflowType))) {
+		return false
+	}
+	if !_I64_EqualsPtr(v.PreviousStartedEventId, rhs.PreviousStartedEventId) {
+		return false
+	}
+	if !_I64_EqualsPtr(v.StartedEventId, rhs.StartedEventId) {
+		return false
+	}
+	if !_I64_EqualsPtr(v.Attempt, rhs.Attempt) {
+		return false
+	}
+	if !_I64_EqualsPtr(v.NextEventId, rhs.NextEventId) {
+		return false
+	}
+	if !_I64_EqualsPtr(v.BacklogCountHint, rhs.BacklogCountHint) {
+		return false
+	}
+	if !_Bool_EqualsPtr(v.StickyExecutionEnabled, rhs.StickyExecutionEnabled) {
+		return false
+	}
+	if !((v.Query == nil && rhs.Query == nil) || (v.Query != nil && rhs.Query != nil && v.Query.Equals(rhs.Query))) {
+		return false
+	}
+	if !((v.DecisionInfo == nil && rhs.DecisionInfo == nil) || (v.DecisionInfo != nil && rhs.DecisionInfo != nil && v.DecisionInfo.Equals(rhs.DecisionInfo))) {
+		return false
+	}
+	if !((v.WorkflowExecutionTaskList == nil && rhs.WorkflowExecutionTaskList == nil) || (v.WorkflowExecutionTaskList != nil && rhs.WorkflowExecutionTaskList != nil && v.WorkflowExecutionTaskList.Equals(rhs.WorkflowExecutionTaskList))) {
+		return false
+	}
+	if !_I32_EqualsPtr(v.EventStoreVersion, rhs.EventStoreVersion) {
+		return false
+	}
+	if !((v.BranchToken == nil && rhs.BranchToken == nil) || (v.BranchToken != nil && rhs.BranchToken != nil && bytes.Equal(v.BranchToken, rhs.BranchToken))) {
+		return false
+	}
+	if !_I64_EqualsPtr(v.ScheduledTimestamp, rhs.ScheduledTimestamp) {
+		return false
+	}
+	if !_I64_EqualsPtr(v.StartedTimestamp, rhs.StartedTimestamp) {
+		return false
+	}
+	if !_Bool_EqualsPtr(v.ContinueAsNewSuggested, rhs.ContinueAsNewSuggested) {
+		return false
+	}
+	if !((v.PendingUpdates == nil && rhs.PendingUpdates == nil) || (v.PendingUpdates != nil && rhs.PendingUpdates != nil && _List_WorkflowUpdate_Equals(v.PendingUpdates, rhs.PendingUpdates))) {
+		return false
+	}
+	if !((v.ScalingHints == nil && rhs.ScalingHints == nil) || (v.ScalingHints != nil && rhs.ScalingHints != nil && v.ScalingHints.Equals(rhs.ScalingHints))) {
+		return false
+	}
+
+	return true
+}
+
+type _List_WorkflowUpdate_Zapper []*shared.WorkflowUpdate
+
+// MarshalLogArray implements zapcore.ArrayMarshaler, enabling
+// fast logging of _List_WorkflowUpdate_Zapper.
+func (l _List_WorkflowUpdate_Zapper) MarshalLogArray(enc zapcore.ArrayEncoder) (err error) {
+	for _, v := range l {
+		err = multierr.Append(err, enc.AppendObject(v))
+	}
+	return err
+}
+
+// MarshalLogObject implements zapcore.ObjectMarshaler, enabling
+// fast logging of PollForDecisionTaskResponse.
+func (v *PollForDecisionTaskResponse) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+	if v == nil {
+		return nil
+	}
+	if v.TaskToken != nil {
+		enc.AddString("taskToken", base64.StdEncoding.EncodeToString(v.TaskToken))
+	}
+	if v.WorkflowExecution != nil {
+		err = multierr.Append(err, enc.AddObject("workflowExecution", v.WorkflowExecution))
+	}
+	if v.WorkflowType != nil {
+		err = multierr.Append(err, enc.AddObject("workflowType", v.WorkflowType))
+	}
+	if v.PreviousStartedEventId != nil {
+		enc.AddInt64("previousStartedEventId", *v.PreviousStartedEventId)
+	}
+	if v.StartedEventId != nil {
+		enc.AddInt64("startedEventId", *v.StartedEventId)
+	}
+	if v.Attempt != nil {
+		enc.AddInt64("attempt", *v.Attempt)
+	}
+	if v.NextEventId != nil {
+		enc.AddInt64("nextEventId", *v.NextEventId)
+	}
+	if v.BacklogCountHint != nil {
+		enc.AddInt64("backlogCountHint", *v.BacklogCountHint)
+	}
+	if v.StickyExecutionEnabled != nil {
+		enc.AddBool("stickyExecutionEnabled", *v.StickyExecutionEnabled)
+	}
+	if v.Query != nil {
+		err = multierr.Append(err, enc.AddObject("query", v.Query))
+	}
+	if v.DecisionInfo != nil {
+		err = multierr.Append(err, enc.AddObject("decisionInfo", v.DecisionInfo))
+	}
+	if v.WorkflowExecutionTaskList != nil {
+		err = multierr.Append(err, enc.AddObject("WorkflowExecutionTaskList", v.WorkflowExecutionTaskList))
+	}
+	if v.EventStoreVersion != nil {
+		enc.AddInt32("eventStoreVersion", *v.EventStoreVersion)
+	}
+	if v.BranchToken != nil {
+		enc.AddString("branchToken", base64.StdEncoding.EncodeToString(v.BranchToken))
+	}
+	if v.ScheduledTimestamp != nil {
+		enc.AddInt64("scheduledTimestamp", *v.ScheduledTimestamp)
+	}
+	if v.StartedTimestamp != nil {
+		enc.AddInt64("startedTimestamp", *v.StartedTimestamp)
+	}
+	if v.ContinueAsNewSuggested != nil {
+		enc.AddBool("continueAsNewSuggested", *v.ContinueAsNewSuggested)
+	}
+	if v.PendingUpdates != nil {
+		err = multierr.Append(err, enc.AddArray("pendingUpdates", (_List_WorkflowUpdate_Zapper)(v.PendingUpdates)))
+	}
+	if v.ScalingHints != nil {
+		err = multierr.Append(err, enc.AddObject("scalingHints", v.ScalingHints))
+	}
+	return err
+}
+
+// GetTaskToken returns the value of TaskToken if it is set or its
+// zero value if it is unset.
+func (v *PollForDecisionTaskResponse) GetTaskToken() (o []byte) {
+	if v != nil && v.TaskToken != nil {
+		return v.TaskToken
+	}
+
+	return
+}
+
+// IsSetTaskToken returns true if TaskToken is not nil.
+func (v *PollForDecisionTaskResponse) IsSetTaskToken() bool {
+	return v != nil && v.TaskToken != nil
+}
+
+// GetWorkflowExecution returns the value of WorkflowExecution if it is set or its
+// zero value if it is unset.
+func (v *PollForDecisionTaskResponse) GetWorkflowExecution() (o *shared.WorkflowExecution) {
+	if v != nil && v.WorkflowExecution != nil {
+		return v.WorkflowExecution
+	}
+
+	return
+}
+
+// IsSetWorkflowExecution returns true if WorkflowExecution is not nil.
+func (v *PollForDecisionTaskResponse) IsSetWorkflowExecution() bool {
+	return v != nil && v.WorkflowExecution != nil
+}
+
+// GetWorkflowType returns the value of WorkflowType if it is set or its
+// zero value if it is unset.
+func (v *PollForDecisionTaskResponse) GetWorkflowType() (o *shared.WorkflowType) {
+	if v != nil && v.WorkflowType != nil {
+		return v.WorkflowType
+	}
+
+	return
+}
+
+// IsSetWorkflowType returns true if WorkflowType is not nil.
+func (v *PollForDecisionTaskResponse) IsSetWorkflowType() bool {
+	return v != nil && v.WorkflowType != nil
+}
+
+// GetPreviousStartedEventId returns the value of PreviousStartedEventId if it is set or its
+// zero value if it is unset.
+func (v *PollForDecisionTaskResponse) GetPreviousStartedEventId() (o int64) {
+	if v != nil && v.PreviousStartedEventId != nil {
+		return *v.PreviousStartedEventId
+	}
+
+	return
+}
+
+// IsSetPreviousStartedEventId returns true if PreviousStartedEventId is not nil.
+func (v *PollForDecisionTaskResponse) IsSetPreviousStartedEventId() bool {
+	return v != nil && v.PreviousStartedEventId != nil
+}
+
+// GetStartedEventId returns the value of StartedEventId if it is set or its
+// zero value if it is unset.
+func (v *PollForDecisionTaskResponse) GetStartedEventId() (o int64) {
+	if v != nil && v.StartedEventId != nil {
+		return *v.StartedEventId
+	}
+
+	return
+}
+
+// IsSetStartedEventId returns true if StartedEventId is not nil.
+func (v *PollForDecisionTaskResponse) IsSetStartedEventId() bool {
+	return v != nil && v.StartedEventId != nil
+}
+
+// GetAttempt returns the value of Attempt if it is set or its
+// zero value if it is unset.
+func (v *PollForDecisionTaskResponse) GetAttempt() (o int64) {
+	if v != nil && v.Attempt != nil {
+		return *v.Attempt
+	}
+
+	return
+}
+
+// IsSetAttempt returns true if Attempt is not nil.
+func (v *PollForDecisionTaskResponse) IsSetAttempt() bool {
+	return v != nil && v.Attempt != nil
+}
+
+// GetNextEventId returns the value of NextEventId if it is set or its
+// zero value if it is unset.
+func (v *PollForDecisionTaskResponse) GetNextEventId() (o int64) {
+	if v != nil && v.NextEventId != nil {
+		return *v.NextEventId
+	}
+
+	return
+}
+
+// IsSetNextEventId returns true if NextEventId is not nil.
+func (v *PollForDecisionTaskResponse) IsSetNextEventId() bool {
+	return v != nil && v.NextEventId != nil
+}
+
+// GetBacklogCountHint returns the value of BacklogCountHint if it is set or its
+// zero value if it is unset.
+func (v *PollForDecisionTaskResponse) GetBacklogCountHint() (o int64) {
+	if v != nil && v.BacklogCountHint != nil {
+		return *v.BacklogCountHint
+	}
+
+	return
+}
+
+// IsSetBacklogCountHint returns true if BacklogCountHint is not nil.
+func (v *PollForDecisionTaskResponse) IsSetBacklogCountHint() bool {
+	return v != nil && v.BacklogCountHint != nil
+}
+
+// GetStickyExecutionEnabled returns the value of StickyExecutionEnabled if it is set or its
+// zero value if it is unset.
+func (v *PollForDecisionTaskResponse) GetStickyExecutionEnabled() (o bool) {
+	if v != nil && v.StickyExecutionEnabled != nil {
+		return *v.StickyExecutionEnabled
+	}
+
+	return
+}
+
+// IsSetStickyExecutionEnabled returns true if StickyExecutionEnabled is not nil.
+func (v *PollForDecisionTaskResponse) IsSetStickyExecutionEnabled() bool {
+	return v != nil && v.StickyExecutionEnabled != nil
+}
+
+// GetQuery returns the value of Query if it is set or its
+// zero value if it is unset.
+func (v *PollForDecisionTaskResponse) GetQuery() (o *shared.WorkflowQuery) {
+	if v != nil && v.Query != nil {
+		return v.Query
+	}
+
+	return
+}
+
+// IsSetQuery returns true if Query is not nil.
+func (v *PollForDecisionTaskResponse) IsSetQuery() bool {
+	return v != nil && v.Query != nil
+}
+
+// GetDecisionInfo returns the value of DecisionInfo if it is set or its
+// zero value if it is unset.
+func (v *PollForDecisionTaskResponse) GetDecisionInfo() (o *shared.TransientDecisionInfo) {
+	if v != nil && v.DecisionInfo != nil {
+		return v.DecisionInfo
+	}
+
+	return
+}
+
+// IsSetDecisionInfo returns true if DecisionInfo is not nil.
+func (v *PollForDecisionTaskResponse) IsSetDecisionInfo() bool {
+	return v != nil && v.DecisionInfo != nil
+}
+
+// GetWorkflowExecutionTaskList returns the value of WorkflowExecutionTaskList if it is set or its
+// zero value if it is unset.
+func (v *PollForDecisionTaskResponse) GetWorkflowExecutionTaskList() (o *shared.TaskList) {
+	if v != nil && v.WorkflowExecutionTaskList != nil {
+		return v.WorkflowExecutionTaskList
+	}
+
+	return
+}
+
+// IsSetWorkflowExecutionTaskList returns true if WorkflowExecutionTaskList is not nil.
+func (v *PollForDecisionTaskResponse) IsSetWorkflowExecutionTaskList() bool {
+	return v != nil && v.WorkflowExecutionTaskList != nil
+}
+
+// GetEventStoreVersion returns the value of EventStoreVersion if it is set or its
+// zero value if it is unset.
+func (v *PollForDecisionTaskResponse) GetEventStoreVersion() (o int32) {
+	if v != nil && v.EventStoreVersion != nil {
+		return *v.EventStoreVersion
+	}
+
+	return
+}
+
+// IsSetEventStoreVersion returns true if EventStoreVersion is not nil.
+func (v *PollForDecisionTaskResponse) IsSetEventStoreVersion() bool {
+	return v != nil && v.EventStoreVersion != nil
+}
+
+// GetBranchToken returns the value of BranchToken if it is set or its
+// zero value if it is unset.
+func (v *PollForDecisionTaskResponse) GetBranchToken() (o []byte) {
+	if v != nil && v.BranchToken != nil {
+		return v.BranchToken
+	}
+
+	return
+}
+
+// IsSetBranchToken returns true if BranchToken is not nil.
+func (v *PollForDecisionTaskResponse) IsSetBranchToken() bool {
+	return v != nil && v.BranchToken != nil
+}
+
+// GetScheduledTimestamp returns the value of ScheduledTimestamp if it is set or its
+// zero value if it is unset.
+func (v *PollForDecisionTaskResponse) GetScheduledTimestamp() (o int64) {
+	if v != nil && v.ScheduledTimestamp != nil {
+		return *v.ScheduledTimestamp
+	}
+
+	return
+}
+
+// IsSetScheduledTimestamp returns true if ScheduledTimestamp is not nil.
+func (v *PollForDecisionTaskResponse) IsSetScheduledTimestamp() bool {
+	return v != nil && v.ScheduledTimestamp != nil
+}
+
+// GetStartedTimestamp returns the value of StartedTimestamp if it is set or its
+// zero value if it is unset.
+func (v *PollForDecisionTaskResponse) GetStartedTimestamp() (o int64) {
+	if v != nil && v.StartedTimestamp != nil {
+		return *v.StartedTimestamp
+	}
+
+	return
+}
+
+// IsSetStartedTimestamp returns true if StartedTimestamp is not nil.
+func (v *PollForDecisionTaskResponse) IsSetStartedTimestamp() bool {
+	return v != nil && v.StartedTimestamp != nil
+}
+
+// GetContinueAsNewSuggested returns the value of ContinueAsNewSuggested if it is set or its
+// zero value if it is unset.
+func (v *PollForDecisionTaskResponse) GetContinueAsNewSuggested() (o bool) {
+	if v != nil && v.ContinueAsNewSuggested != nil {
+		return *v.ContinueAsNewSuggested
+	}
+
+	return
+}
+
+// IsSetContinueAsNewSuggested returns true if ContinueAsNewSuggested is not nil.
+func (v *PollForDecisionTaskResponse) IsSetContinueAsNewSuggested() bool {
+	return v != nil && v.ContinueAsNewSuggested != nil
+}
+
+// GetPendingUpdates returns the value of PendingUpdates if it is set or its
+// zero value if it is unset.
+func (v *PollForDecisionTaskResponse) GetPendingUpdates() (o []*shared.WorkflowUpdate) {
+	if v != nil && v.PendingUpdates != nil {
+		return v.PendingUpdates
+	}
+
+	return
+}
+
+// IsSetPendingUpdates returns true if PendingUpdates is not nil.
+func (v *PollForDecisionTaskResponse) IsSetPendingUpdates() bool {
+	return v != nil && v.PendingUpdates != nil
+}
+
+// GetScalingHints returns the value of ScalingHints if it is set or its
+// zero value if it is unset.
+func (v *PollForDecisionTaskResponse) GetScalingHints() (o *shared.TaskListScalingHints) {
+	if v != nil && v.ScalingHints != nil {
+		return v.ScalingHints
+	}
+
+	return
+}
+
+// IsSetScalingHints returns true if ScalingHints is not nil.
+func (v *PollForDecisionTaskResponse) IsSetScalingHints() bool {
+	return v != nil && v.ScalingHints != nil
+}
+
+type QueryWorkflowRequest struct {
+	DomainUUID   *string                      `json:"domainUUID,omitempty"`
+	TaskList     *shared.TaskList             `json:"taskList,omitempty"`
+	QueryRequest *shared.QueryWorkflowRequest `json:"queryRequest,omitempty"`
+}
+
+// ToWire translates a QueryWorkflowRequest struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *QueryWorkflowRequest) ToWire() (wire.Value, error) {
+	var (
+		fields [3]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.DomainUUID != nil {
+		w, err = wire.NewValueString(*(v.DomainUUID)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 10, Value: w}
+		i++
+	}
+	if v.TaskList != nil {
+		w, err = v.TaskList.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 20, Value: w}
+		i++
+	}
+	if v.QueryRequest != nil {
+		w, err = v.QueryRequest.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 30, Value: w}
+		i++
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+func _QueryWorkflowRequest_Read(w wire.Value) (*shared.QueryWorkflowRequest, error) {
+	var v shared.QueryWorkflowRequest
+	err := v.FromWire(w)
+	return &v, err
+}
+
+// FromWire deserializes a QueryWorkflowRequest struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a QueryWorkflowRequest struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v QueryWorkflowRequest
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *QueryWorkflowRequest) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 10:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.DomainUUID = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 20:
+			if field.Value.Type() == wire.TStruct {
+				v.TaskList, err = _TaskList_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 30:
+			if field.Value.Type() == wire.TStruct {
+				v.QueryRequest, err = _QueryWorkflowRequest_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+
+	return nil
+}
+
+// String returns a readable string representation of a QueryWorkflowRequest
+// struct.
+func (v *QueryWorkflowRequest) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [3]string
+	i := 0
+	if v.DomainUUID != nil {
+		fields[i] = fmt.Sprintf("DomainUUID: %v", *(v.DomainUUID))
+		i++
+	}
+	if v.TaskList != nil {
+		fields[i] = fmt.Sprintf("TaskList: %v", v.TaskList)
+		i++
+	}
+	if v.QueryRequest != nil {
+		fields[i] = fmt.Sprintf("QueryRequest: %v", v.QueryRequest)
+		i++
+	}
+
+	return fmt.Sprintf("QueryWorkflowRequest{%v}", strings.Join(fields[:i], ", "))
+}
+
+// Equals returns true if all the fields of this QueryWorkflowRequest match the
+// provided QueryWorkflowRequest.
+//
+// This function performs a deep comparison.
+func (v *QueryWorkflowRequest) Equals(rhs *QueryWorkflowRequest) bool {
+	if v == nil {
+		return rhs == nil
+	} else if rhs == nil {
+		return false
+	}
+	if !_String_EqualsPtr(v.DomainUUID, rhs.DomainUUID) {
+		return false
+	}
+	if !((v.TaskList == nil && rhs.TaskList == nil) || (v.TaskList != nil && rhs.TaskList != nil && v.TaskList.Equals(rhs.TaskList))) {
+		return false
+	}
+	if !((v.QueryRequest == nil && rhs.QueryRequest == nil) || (v.QueryRequest != nil && rhs.QueryRequest != nil && v.QueryRequest.Equals(rhs.QueryRequest))) {
+		return false
+	}
+
+	return true
+}
+
+// MarshalLogObject implements zapcore.ObjectMarshaler, enabling
+// fast logging of QueryWorkflowRequest.
+func (v *QueryWorkflowRequest) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+	if v == nil {
+		return nil
+	}
+	if v.DomainUUID != nil {
+		enc.AddString("domainUUID", *v.DomainUUID)
+	}
+	if v.TaskList != nil {
+		err = multierr.Append(err, enc.AddObject("taskList", v.TaskList))
+	}
+	if v.QueryRequest != nil {
+		err = multierr.Append(err, enc.AddObject("queryRequest", v.QueryRequest))
+	}
+	return err
+}
+
+// GetDomainUUID returns the value of DomainUUID if it is set or its
+// zero value if it is unset.
+func (v *QueryWorkflowRequest) GetDomainUUID() (o string) {
+	if v != nil && v.DomainUUID != nil {
+		return *v.DomainUUID
+	}
+
+	return
+}
+
+// IsSetDomainUUID returns true if DomainUUID is not nil.
+func (v *QueryWorkflowRequest) IsSetDomainUUID() bool {
+	return v != nil && v.DomainUUID != nil
+}
+
+// GetTaskList returns the value of TaskList if it is set or its
+// zero value if it is unset.
+func (v *QueryWorkflowRequest) GetTaskList() (o *shared.TaskList) {
+	if v != nil && v.TaskList != nil {
+		return v.TaskList
+	}
+
+	return
+}
+
+// IsSetTaskList returns true if TaskList is not nil.
+func (v *QueryWorkflowRequest) IsSetTaskList() bool {
+	return v != nil && v.TaskList != nil
+}
+
+// GetQueryRequest returns the value of QueryRequest if it is set or its
+// zero value if it is unset.
+func (v *QueryWorkflowRequest) GetQueryRequest() (o *shared.QueryWorkflowRequest) {
+	if v != nil && v.QueryRequest != nil {
+		return v.QueryRequest
+	}
+
+	return
+}
+
+// IsSetQueryRequest returns true if QueryRequest is not nil.
+func (v *QueryWorkflowRequest) IsSetQueryRequest() bool {
+	return v != nil && v.QueryRequest != nil
+}
+
+type RespondQueryTaskCompletedRequest struct {
+	DomainUUID       *string                                  `json:"domainUUID,omitempty"`
+	TaskList         *shared.TaskList                         `json:"taskList,omitempty"`
+	TaskID           *string                                  `json:"taskID,omitempty"`
+	CompletedRequest *shared.RespondQueryTaskCompletedRequest `json:"completedRequest,omitempty"`
+}
+
+// ToWire translates a RespondQueryTaskCompletedRequest struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *RespondQueryTaskCompletedRequest) ToWire() (wire.Value, error) {
+	var (
+		fields [4]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.DomainUUID != nil {
+		w, err = wire.NewValueString(*(v.DomainUUID)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 10, Value: w}
+		i++
+	}
+	if v.TaskList != nil {
+		w, err = v.TaskList.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 20, Value: w}
+		i++
+	}
+	if v.TaskID != nil {
+		w, err = wire.NewValueString(*(v.TaskID)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 30, Value: w}
+		i++
+	}
+	if v.CompletedRequest != nil {
+		w, err = v.CompletedRequest.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 40, Value: w}
+		i++
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+func _RespondQueryTaskCompletedRequest_Read(w wire.Value) (*shared.RespondQueryTaskCompletedRequest, error) {
+	var v shared.RespondQueryTaskCompletedRequest
+	err := v.FromWire(w)
+	return &v, err
+}
+
+// FromWire deserializes a RespondQueryTaskCompletedRequest struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a RespondQueryTaskCompletedRequest struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v RespondQueryTaskCompletedRequest
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *RespondQueryTaskCompletedRequest) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 10:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.DomainUUID = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 20:
+			if field.Value.Type() == wire.TStruct {
+				v.TaskList, err = _TaskList_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 30:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.TaskID = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 40:
+			if field.Value.Type() == wire.TStruct {
+				v.CompletedRequest, err = _RespondQueryTaskCompletedRequest_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+
+	return nil
+}
+
+// String returns a readable string representation of a RespondQueryTaskCompletedRequest
+// struct.
+func (v *RespondQueryTaskCompletedRequest) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [4]string
+	i := 0
+	if v.DomainUUID != nil {
+		fields[i] = fmt.Sprintf("DomainUUID: %v", *(v.DomainUUID))
+		i++
+	}
+	if v.TaskList != nil {
+		fields[i] = fmt.Sprintf("TaskList: %v", v.TaskList)
+		i++
+	}
+	if v.TaskID != nil {
+		fields[i] = fmt.Sprintf("TaskID: %v", *(v.TaskID))
+		i++
+	}
+	if v.CompletedRequest != nil {
+		fields[i] = fmt.Sprintf("CompletedRequest: %v", v.CompletedRequest)
+		i++
+	}
+
+	return fmt.Sprintf("RespondQueryTaskCompletedRequest{%v}", strings.Join(fields[:i], ", "))
+}
+
+// Equals returns true if all the fields of this RespondQueryTaskCompletedRequest match the
+// provided RespondQueryTaskCompletedRequest.
+//
+// This function performs a deep comparison.
+func (v *RespondQueryTaskCompletedRequest) Equals(rhs *RespondQueryTaskCompletedRequest) bool {
+	if v == nil {
+		return rhs == nil
+	} else if rhs == nil {
+		return false
+	}
+	if !_String_EqualsPtr(v.DomainUUID, rhs.DomainUUID) {
+		return false
+	}
+	if !((v.TaskList == nil && rhs.TaskList == nil) || (v.TaskList != nil && rhs.TaskList != nil && v.TaskList.Equals(rhs.TaskList))) {
+		return false
+	}
+	if !_String_EqualsPtr(v.TaskID, rhs.TaskID) {
+		return false
+	}
+	if !((v.CompletedRequest == nil && rhs.CompletedRequest == nil) || (v.CompletedRequest != nil && rhs.CompletedRequest != nil && v.CompletedRequest.Equals(rhs.CompletedRequest))) {
+		return false
+	}
+
+	return true
+}
+
+// MarshalLogObject implements zapcore.ObjectMarshaler, enabling
+// fast logging of RespondQueryTaskCompletedRequest.
+func (v *RespondQueryTaskCompletedRequest) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+	if v == nil {
+		return nil
+	}
+	if v.DomainUUID != nil {
+		enc.AddString("domainUUID", *v.DomainUUID)
+	}
+	if v.TaskList != nil {
+		err = multierr.Append(err, enc.AddObject("taskList", v.TaskList))
+	}
+	if v.TaskID != nil {
+		enc.AddString("taskID", *v.TaskID)
+	}
+	if v.CompletedRequest != nil {
+		err = multierr.Append(err, enc.AddObject("completedRequest", v.CompletedRequest))
+	}
+	return err
+}
+
+// GetDomainUUID returns the value of DomainUUID if it is set or its
+// zero value if it is unset.
+func (v *RespondQueryTaskCompletedRequest) GetDomainUUID() (o string) {
+	if v != nil && v.DomainUUID != nil {
+		return *v.DomainUUID
+	}
+
+	return
+}
+
+// IsSetDomainUUID returns true if DomainUUID is not nil.
+func (v *RespondQueryTaskCompletedRequest) IsSetDomainUUID() bool {
+	return v != nil && v.DomainUUID != nil
+}
+
+// GetTaskList returns the value of TaskList if it is set or its
+// zero value if it is unset.
+func (v *RespondQueryTaskCompletedRequest) GetTaskList() (o *shared.TaskList) {
+	if v != nil && v.TaskList != nil {
+		return v.TaskList
+	}
+
+	return
+}
+
+// IsSetTaskList returns true if TaskList is not nil.
+func (v *RespondQueryTaskCompletedRequest) IsSetTaskList() bool {
+	return v != nil && v.TaskList != nil
+}
+
+// GetTaskID returns the value of TaskID if it is set or its
+// zero value if it is unset.
+func (v *RespondQueryTaskCompletedRequest) GetTaskID() (o string) {
+	if v != nil && v.TaskID != nil {
+		return *v.TaskID
+	}
+
+	return
+}
+
+// IsSetTaskID returns true if TaskID is not nil.
+func (v *RespondQueryTaskCompletedRequest) IsSetTaskID() bool {
+	return v != nil && v.TaskID != nil
+}
+
+// GetCompletedRequest returns the value of CompletedRequest if it is set or its
+// zero value if it is unset.
+func (v *RespondQueryTaskCompletedRequest) GetCompletedRequest() (o *shared.RespondQueryTaskCompletedRequest) {
+	if v != nil && v.CompletedRequest != nil {
+		return v.CompletedRequest
+	}
+
+	return
+}
+
+// IsSetCompletedRequest returns true if CompletedRequest is not nil.
+func (v *RespondQueryTaskCompletedRequest) IsSetCompletedRequest() bool {
+	return v != nil && v.CompletedRequest != nil
+}
+
+type UpdateTaskListVersionSetsRequest struct {
+	DomainUUID  *string                      `json:"domainUUID,omitempty"`
+	TaskList    *shared.TaskList             `json:"taskList,omitempty"`
+	VersionSets []*shared.TaskListVersionSet `json:"versionSets,omitempty"`
+}
+
+type _List_TaskListVersionSet_ValueList []*shared.TaskListVersionSet
+
+func (v _List_TaskListVersionSet_ValueList) ForEach(f func(wire.Value) error) error {
+	for i, x := range v {
+		if x == nil {
+			return fmt.Errorf("invalid [%v]: value is nil", i)
+		}
+		w, err := x.ToWire()
+		if err != nil {
+			return err
+		}
+		err = f(w)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (v _List_TaskListVersionSet_ValueList) Size() int {
+	return len(v)
+}
+
+func (_List_TaskListVersionSet_ValueList) ValueType() wire.Type {
+	return wire.TStruct
+}
+
+func (_List_TaskListVersionSet_ValueList) Close() {}
+
+// ToWire translates a UpdateTaskListVersionSetsRequest struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *UpdateTaskListVersionSetsRequest) ToWire() (wire.Value, error) {
+	var (
+		fields [3]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.DomainUUID != nil {
+		w, err = wire.NewValueString(*(v.DomainUUID)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 10, Value: w}
+		i++
+	}
+	if v.TaskList != nil {
+		w, err = v.TaskList.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 20, Value: w}
+		i++
+	}
+	if v.VersionSets != nil {
+		w, err = wire.NewValueList(_List_TaskListVersionSet_ValueList(v.VersionSets)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 30, Value: w}
+		i++
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+func _TaskListVersionSet_Read(w wire.Value) (*shared.TaskListVersionSet, error) {
+	var v shared.TaskListVersionSet
+	err := v.FromWire(w)
+	return &v, err
+}
+
+func _List_TaskListVersionSet_Read(l wire.ValueList) ([]*shared.TaskListVersionSet, error) {
+	if l.ValueType() != wire.TStruct {
+		return nil, nil
+	}
+
+	o := make([]*shared.TaskListVersionSet, 0, l.Size())
+	err := l.ForEach(func(x wire.Value) error {
+		i, err := _TaskListVersionSet_Read(x)
+		if err != nil {
+			return err
+		}
+		o = append(o, i)
+		return nil
+	})
+	l.Close()
+	return o, err
+}
+
+// FromWire deserializes a UpdateTaskListVersionSetsRequest struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a UpdateTaskListVersionSetsRequest struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v UpdateTaskListVersionSetsRequest
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *UpdateTaskListVersionSetsRequest) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 10:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.DomainUUID = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 20:
+			if field.Value.Type() == wire.TStruct {
+				v.TaskList, err = _TaskList_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 30:
+			if field.Value.Type() == wire.TList {
+				v.VersionSets, err = _List_TaskListVersionSet_Read(field.Value.GetList())
+				if err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+
+	return nil
+}
+
+// String returns a readable string representation of a UpdateTaskListVersionSetsRequest
+// struct.
+func (v *UpdateTaskListVersionSetsRequest) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [3]string
+	i := 0
+	if v.DomainUUID != nil {
+		fields[i] = fmt.Sprintf("DomainUUID: %v", *(v.DomainUUID))
+		i++
+	}
+	if v.TaskList != nil {
+		fields[i] = fmt.Sprintf("TaskList: %v", v.TaskList)
+		i++
+	}
+	if v.VersionSets != nil {
+		fields[i] = fmt.Sprintf("VersionSets: %v", v.VersionSets)
+		i++
+	}
+
+	return fmt.Sprintf("UpdateTaskListVersionSetsRequest{%v}", strings.Join(fields[:i], ", "))
+}
+
+func _List_TaskListVersionSet_Equals(lhs, rhs []*shared.TaskListVersionSet) bool {
+	if len(lhs) != len(rhs) {
+		return false
+	}
+
+	for i, lv := range lhs {
+		rv := rhs[i]
+		if !lv.Equals(rv) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Equals returns true if all the fields of this UpdateTaskListVersionSetsRequest match the
+// provided UpdateTaskListVersionSetsRequest.
+//
+// This function performs a deep comparison.
+func (v *UpdateTaskListVersionSetsRequest) Equals(rhs *UpdateTaskListVersionSetsRequest) bool {
+	if v == nil {
+		return rhs == nil
+	} else if rhs == nil {
+		return false
+	}
+	if !_String_EqualsPtr(v.DomainUUID, rhs.DomainUUID) {
+		return false
+	}
+	if !((v.TaskList == nil && rhs.TaskList == nil) || (v.TaskList != nil && rhs.TaskList != nil && v.TaskList.Equals(rhs.TaskList))) {
+		return false
+	}
+	if !((v.VersionSets == nil && rhs.VersionSets == nil) || (v.VersionSets != nil && rhs.VersionSets != nil && _List_TaskListVersionSet_Equals(v.VersionSets, rhs.VersionSets))) {
+		return false
+	}
+
+	return true
+}
+
+type _List_TaskListVersionSet_Zapper []*shared.TaskListVersionSet
+
+// MarshalLogArray implements zapcore.ArrayMarshaler, enabling
+// fast logging of _List_TaskListVersionSet_Zapper.
+func (l _List_TaskListVersionSet_Zapper) MarshalLogArray(enc zapcore.ArrayEncoder) (err error) {
+	for _, v := range l {
+		err = multierr.Append(err, enc.AppendObject(v))
+	}
+	return err
+}
+
+// MarshalLogObject implements zapcore.ObjectMarshaler, enabling
+// fast logging of UpdateTaskListVersionSetsRequest.
+func (v *UpdateTaskListVersionSetsRequest) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+	if v == nil {
+		return nil
+	}
+	if v.DomainUUID != nil {
+		enc.AddString("domainUUID", *v.DomainUUID)
+	}
+	if v.TaskList != nil {
+		err = multierr.Append(err, enc.AddObject("taskList", v.TaskList))
+	}
+	if v.VersionSets != nil {
+		err = multierr.Append(err, enc.AddArray("versionSets", (_List_TaskListVersionSet_Zapper)(v.VersionSets)))
+	}
+	return err
+}
+
+// GetDomainUUID returns the value of DomainUUID if it is set or its
+// zero value if it is unset.
+func (v *UpdateTaskListVersionSetsRequest) GetDomainUUID() (o string) {
+	if v != nil && v.DomainUUID != nil {
+		return *v.DomainUUID
+	}
+
+	return
+}
+
+// IsSetDomainUUID returns true if DomainUUID is not nil.
+func (v *UpdateTaskListVersionSetsRequest) IsSetDomainUUID() bool {
+	return v != nil && v.DomainUUID != nil
+}
+
+// GetTaskList returns the value of TaskList if it is set or its
+// zero value if it is unset.
+func (v *UpdateTaskListVersionSetsRequest) GetTaskList() (o *shared.TaskList) {
+	if v != nil && v.TaskList != nil {
+		return v.TaskList
+	}
+
+	return
+}
+
+// IsSetTaskList returns true if TaskList is not nil.
+func (v *UpdateTaskListVersionSetsRequest) IsSetTaskList() bool {
+	return v != nil && v.TaskList != nil
+}
+
+// GetVersionSets returns the value of VersionSets if it is set or its
+// zero value if it is unset.
+func (v *UpdateTaskListVersionSetsRequest) GetVersionSets() (o []*shared.TaskListVersionSet) {
+	if v != nil && v.VersionSets != nil {
+		return v.VersionSets
+	}
+
+	return
+}
+
+// IsSetVersionSets returns true if VersionSets is not nil.
+func (v *UpdateTaskListVersionSetsRequest) IsSetVersionSets() bool {
+	return v != nil && v.VersionSets != nil
+}
+
+// ThriftModule represents the IDL file used to generate this package.
+var ThriftModule = &thriftreflect.ThriftModule{
+	Name:     "matching",
+	Package:  "github.com/uber/cadence/.gen/go/matching",
+	FilePath: "matching.thrift",
+	SHA1:     "0177ef3440b16ad3d5244bb3c62d54ed97aa8297",
+	Includes: []*thriftreflect.ThriftModule{
+		shared.ThriftModule,
+	},
+	Raw: rawIDL,
+}
+
+const rawIDL = "// Copyright (c) 2017 Uber Technologies, Inc.\n//\n// Permission is hereby granted, free of charge, to any person obtaining a copy\n// of this software and associated documentation files (the \"Software\"), to deal\n// in the Software without restriction, including without limitation the rights\n// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell\n// copies of the Software, and to permit persons to whom the Software is\n// furnished to do so, subject to the following conditions:\n//\n// The above copyright notice and this permission notice shall be included in\n// all copies or substantial portions of the Software.\n//\n// THE SOFTWARE IS PROVIDED \"AS IS\", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR\n// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,\n// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE\n// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER\n// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,\n// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN\n// THE SOFTWARE.\n\ninclude \"shared.thrift\"\n\nnamespace java com.uber.cadence.matching\n\nstruct PollForDecisionTaskRequest {\n  10: optional string domainUUID\n  15: optional string pollerID\n  20: optional shared.PollForDecisionTaskRequest pollRequest\n}\n\nstruct PollForDecisionTaskResponse {\n  10: optional binary taskToken\n  20: optional shared.WorkflowExecution workflowExecution\n  30: optional shared.WorkflowType workflowType\n  40: optional i64 (js.type = \"Long\") previousStartedEventId\n  50: optional i64 (js.type = \"Long\") startedEventId\n  51: optional i64 (js.type = \"Long\") attempt\n  60: optional i64 (js.type = \"Long\") nextEventId\n  65: optional i64 (js.type = \"Long\") backlogCountHint\n  70: optional bool stickyExecutionEnabled\n  80: optional shared.WorkflowQuery query\n  90: optional shared.TransientDecisionInfo decisionInfo\n  100: optional shared.TaskList WorkflowExecutionTaskList\n  110: optional i32 eventStoreVersion\n  120: optional binary branchToken\n  130:  optional i64 (js.type = \"Long\") scheduledTimestamp\n  140:  optional i64 (js.type = \"Long\") startedTimestamp\n  150: optional bool continueAsNewSuggested\n  160: optional list<shared.WorkflowUpdate> pendingUpdates\n  170: optional shared.TaskListScalingHints scalingHints\n}\n\nstruct PollForActivityTaskRequest {\n  10: optional string domainUUID\n  15: optional string pollerID\n  20: optional shared.PollForActivityTaskRequest pollRequest\n}\n\nstruct AddDecisionTaskRequest {\n  10: optional string domainUUID\n  20: optional shared.WorkflowExecution execution\n  30: optional shared.TaskList taskList\n  40: optional i64 (js.type = \"Long\") scheduleId\n  50: optional i32 scheduleToStartTimeoutSeconds\n  // binary checksum of the worker which last completed a decision of the execution\n  60: optional string workerBuildId\n}\n\nstruct AddActivityTaskRequest {\n  10: optional string domainUUID\n  20: optional shared.WorkflowExecution execution\n  30: optional string sourceDomainUUID\n  40: optional shared.TaskList taskList\n  50: optional i64 (js.type = \"Long\") scheduleId\n  60: optional i32 scheduleToStartTimeoutSeconds\n}\n\nstruct QueryWorkflowRequest {\n  10: optional string domainUUID\n  20: optional shared.TaskList taskList\n  30: optional shared.QueryWorkflowRequest queryRequest\n}\n\nstruct RespondQueryTaskCompletedRequest {\n  10: optional string domainUUID\n  20: optional shared.TaskList taskList\n  30: optional string taskID\n  40: optional shared.RespondQueryTaskCompletedRequest completedRequest\n}\n\nstruct CancelOutstandingPollRequest {\n  10: optional string domainUUID\n  20: optional i32 taskListType\n  30: optional shared.TaskList taskList\n  40: optional string pollerID\n}\n\nstruct DescribeTaskListRequest {\n  10: optional string domainUUID\n  20: optional shared.DescribeTaskListRequest descRequest\n}\n\nstruct GetTaskListScalingHintsRequest {\n  10: optional string domainUUID\n  20: optional shared.GetTaskListScalingHintsRequest hintsRequest\n}\n\nstruct DescribeTaskJourneyRequest {\n  10: optional string domainUUID\n  20: optional shared.DescribeTaskJourneyRequest journeyRequest\n}\n\nstruct UpdateTaskListVersionSetsRequest {\n  10: optional string domainUUID\n  20: optional shared.TaskList taskList\n  30: optional list<shared.TaskListVersionSet> versionSets\n}\n\n/**\n* MatchingService API is exposed to provide support for polling from long running applications.\n* Such applications are expected to have a worker which regularly polls for DecisionTask and ActivityTask.  For each\n* DecisionTask, application is expected to process the history of events for that session and respond back with next\n* decisions.  For each ActivityTask, application is expected to execute the actual logic for that task and respond back\n* with completion or failure.\n**/\nservice MatchingService {\n  /**\n  * PollForDecisionTask is called by frontend to process DecisionTask from a specific taskList.  A\n  * DecisionTask is dispatched to callers for active workflow executions, with pending decisions.\n  **/\n  PollForDecisionTaskResponse PollForDecisionTask(1: PollForDecisionTaskRequest pollRequest)\n    throws (\n      1: shared.BadRequestError badRequestError,\n      2: shared.InternalServiceError internalServiceError,\n      3: shared.LimitExceededError limitExceededError,\n      4: shared.ServiceBusyError serviceBusyError,\n    )\n\n  /**\n  * PollForActivityTask is called by frontend to process ActivityTask from a specific taskList.  ActivityTask\n  * is dispatched to callers whenever a ScheduleTask decision is made for a workflow execution.\n  **/\n  shared.PollForActivityTaskResponse PollForActivityTask(1: PollForActivityTaskRequest pollRequest)\n    throws (\n      1: shared.BadRequestError badRequestError,\n      2: shared.InternalServiceError internalServiceError,\n      3: shared.LimitExceededError limitExceededError,\n      4: shared.ServiceBusyError serviceBusyError,\n    )\n\n  /**\n  * AddDecisionTask is called by the history service when a decision task is scheduled, so that it can be dispatched\n  * by the MatchingEngine.\n  **/\n  void AddDecisionTask(1: AddDecisionTaskRequest addRequest)\n    throws (\n      1: shared.BadRequestError badRequestError,\n      2: shared.InternalServiceError internalServiceError,\n      3: shared.ServiceBusyError serviceBusyError,\n      4: shared.LimitExceededError limitExceededError,\n      5: shared.DomainNotActiveError domainNotActiveError,\n    )\n\n  /**\n  * AddActivityTask is called by the history service when a decision task is scheduled, so that it can be dispatched\n  * by the MatchingEngine.\n  **/\n  void AddActivityTask(1: AddActivityTaskRequest addRequest)\n    throws (\n      1: shared.BadRequestError badRequestError,\n      2: shared.InternalServiceError internalServiceError,\n      3: shared.ServiceBusyError serviceBusyError,\n      4: shared.LimitExceededError limitExceededError,\n      5: shared.DomainNotActiveError domainNotActiveError,\n    )\n\n  /**\n  * QueryWorkflow is called by frontend to query a workflow.\n  **/\n  shared.QueryWorkflowResponse QueryWorkflow(1: QueryWorkflowRequest queryRequest)\n    throws (\n      1: shared.BadRequestError badRequestError,\n      2: shared.InternalServiceError internalServiceError,\n      3: shared.EntityNotExistsError entityNotExistError,\n      4: shared.QueryFailedError queryFailedError,\n      5: shared.LimitExceededError limitExceededError,\n      6: shared.ServiceBusyError serviceBusyError,\n    )\n\n  /**\n  * RespondQueryTaskCompleted is called by frontend to respond query completed.\n  **/\n  void RespondQueryTaskCompleted(1: RespondQueryTaskCompletedRequest request)\n    throws (\n      1: shared.BadRequestError badRequestError,\n      2: shared.InternalServiceError internalServiceError,\n      3: shared.EntityNotExistsError entityNotExistError,\n      4: shared.LimitExceededError limitExceededError,\n      5: shared.ServiceBusyError serviceBusyError,\n    )\n\n  /**\n    * CancelOutstandingPoll is called by frontend to unblock long polls on matching for zombie pollers.\n    * Our rpc stack does not support context propagation, so when a client connection goes away frontend sees\n    * cancellation of context for that handler, but any corresponding calls (long-poll) to matching service does not\n    * see the cancellation propagated so it can unblock corresponding long-polls on its end.  This results is tasks\n    * being dispatched to zombie pollers in this situation.  This API is added so everytime frontend makes a long-poll\n    * api call to matching it passes in a pollerID and then calls this API when it detects client connection is closed\n    * to unblock long polls for this poller and prevent tasks being sent to these zombie pollers.\n    **/\n  void CancelOutstandingPoll(1: CancelOutstandingPollRequest request)\n    throws (\n      1: shared.BadRequestError badRequestError,\n      2: shared.InternalServiceError internalServiceError,\n      3: shared.ServiceBusyError serviceBusyError,\n    )\n\n  /**\n  * DescribeTaskList returns information about the target tasklist, right now this API returns the\n  * pollers which polled this tasklist in last few minutes.\n  **/\n  shared.DescribeTaskListResponse DescribeTaskList(1: DescribeTaskListRequest request)\n    throws (\n        1: shared.BadRequestError badRequestError,\n        2: shared.InternalServiceError internalServiceError,\n        3: shared.EntityNotExistsError entityNotExistError,\n        4: shared.ServiceBusyError serviceBusyError,\n      )\n\n  /**\n  * GetTaskListScalingHints returns the backlog and dispatch rate of a tasklist partition, to scale its pollers.\n  **/\n  shared.GetTaskListScalingHintsResponse GetTaskListScalingHints(1: GetTaskListScalingHintsRequest request)\n    throws (\n        1: shared.BadRequestError badRequestError,\n        2: shared.InternalServiceError internalServiceError,\n        3: shared.EntityNotExistsError entityNotExistError,\n        4: shared.ServiceBusyError serviceBusyError,\n      )\n\n  /**\n  * UpdateTaskListVersionSets replaces the worker build ID version sets of a decision task list.\n  **/\n  void UpdateTaskListVersionSets(1: UpdateTaskListVersionSetsRequest request)\n    throws (\n        1: shared.BadRequestError badRequestError,\n        2: shared.InternalServiceError internalServiceError,\n        3: shared.ServiceBusyError serviceBusyError,\n      )\n\n  /**\n  * DescribeTaskJourney returns the stages recently reached by a task on the partitions of a task list owned by\n  * the host.\n  **/\n  shared.DescribeTaskJourneyResponse DescribeTaskJourney(1: DescribeTaskJourneyRequest request)\n    throws (\n        1: shared.BadRequestError badRequestError,\n        2: shared.InternalServiceError internalServiceError,\n        3: shared.EntityNotExistsError entityNotExistError,\n        4: shared.ServiceBusyError serviceBusyError,\n      )\n}\n"
+
+// MatchingService_AddActivityTask_Args represents the arguments for the MatchingService.AddActivityTask function.
+//
+// The arguments for AddActivityTask are sent and received over the wire as this struct.
+type MatchingService_AddActivityTask_Args struct {
+	AddRequest *AddActivityTaskRequest `json:"addRequest,omitempty"`
+}
+
+// ToWire translates a MatchingService_AddActivityTask_Args struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *MatchingService_AddActivityTask_Args) ToWire() (wire.Value, error) {
+	var (
+		fields [1]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.AddRequest != nil {
+		w, err = v.AddRequest.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 1, Value: w}
+		i++
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+func _AddActivityTaskRequest_Read(w wire.Value) (*AddActivityTaskRequest, error) {
+	var v AddActivityTaskRequest
+	err := v.FromWire(w)
+	return &v, err
+}
+
+// FromWire deserializes a MatchingService_AddActivityTask_Args struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a MatchingService_AddActivityTask_Args struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v MatchingService_AddActivityTask_Args
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *MatchingService_AddActivityTask_Args) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 1:
+			if field.Value.Type() == wire.TStruct {
+				v.AddRequest, err = _AddActivityTaskRequest_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+
+	return nil
+}
+
+// String returns a readable string representation of a MatchingService_AddActivityTask_Args
+// struct.
+func (v *MatchingService_AddActivityTask_Args) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [1]string
+	i := 0
+	if v.AddRequest != nil {
+		fields[i] = fmt.Sprintf("AddRequest: %v", v.AddRequest)
+		i++
+	}
+
+	return fmt.Sprintf("MatchingService_AddActivityTask_Args{%v}", strings.Join(fields[:i], ", "))
+}
+
+// Equals returns true if all the fields of this MatchingService_AddActivityTask_Args match the
+// provided MatchingService_AddActivityTask_Args.
+//
+// This function performs a deep comparison.
+func (v *MatchingService_AddActivityTask_Args) Equals(rhs *MatchingService_AddActivityTask_Args) bool {
+	if v == nil {
+		return rhs == nil
+	} else if rhs == nil {
+		return false
+	}
+	if !((v.AddRequest == nil && rhs.AddRequest == nil) || (v.AddRequest != nil && rhs.AddRequest != nil && v.AddRequest.Equals(rhs.AddRequest))) {
+		return false
+	}
+
+	return true
+}
+
+// MarshalLogObject implements zapcore.ObjectMarshaler, enabling
+// fast logging of MatchingService_AddActivityTask_Args.
+func (v *MatchingService_AddActivityTask_Args) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+	if v == nil {
+		return nil
+	}
+	if v.AddRequest != nil {
+		err = multierr.Append(err, enc.AddObject("addRequest", v.AddRequest))
+	}
+	return err
+}
+
+// GetAddRequest returns the value of AddRequest if it is set or its
+// zero value if it is unset.
+func (v *MatchingService_AddActivityTask_Args) GetAddRequest() (o *AddActivityTaskRequest) {
+	if v != nil && v.AddRequest != nil {
+		return v.AddRequest
+	}
+
+	return
+}
+
+// IsSetAddRequest returns true if AddRequest is not nil.
+func (v *MatchingService_AddActivityTask_Args) IsSetAddRequest() bool {
+	return v != nil && v.AddRequest != nil
+}
+
+// MethodName returns the name of the Thrift function as specified in
+// the IDL, for which this struct represent the arguments.
+//
+// This will always be "AddActivityTask" for this struct.
+func (v *MatchingService_AddActivityTask_Args) MethodName() string {
+	return "AddActivityTask"
+}
+
+// EnvelopeType returns the kind of value inside this struct.
+//
+// This will always be Call for this struct.
+func (v *MatchingService_AddActivityTask_Args) EnvelopeType() wire.EnvelopeType {
+	return wire.Call
+}
+
+// MatchingService_AddActivityTask_Helper provides functions that aid in handling the
+// parameters and return values of the MatchingService.AddActivityTask
+// function.
+var MatchingService_AddActivityTask_Helper = struct {
+	// Args accepts the parameters of AddActivityTask in-order and returns
+	// the arguments struct for the function.
+	Args func(
+		addRequest *AddActivityTaskRequest,
+	) *MatchingService_AddActivityTask_Args
+
+	// IsException returns true if the given error can be thrown
+	// by AddActivityTask.
+	//
+	// An error can be thrown by AddActivityTask only if the
+	// corresponding exception type was mentioned in the 'throws'
+	// section for it in the Thrift file.
+	IsException func(error) bool
+
+	// WrapResponse returns the result struct for AddActivityTask
+	// given the error returned by it. The provided error may
+	// be nil if AddActivityTask did not fail.
+	//
+	// This allows mapping errors returned by AddActivityTask into a
+	// serializable result struct. WrapResponse returns a
+	// non-nil error if the provided error cannot be thrown by
+	// AddActivityTask
+	//
+	//   err := AddActivityTask(args)
+	//   result, err := MatchingService_AddActivityTask_Helper.WrapResponse(err)
+	//   if err != nil {
+	//     return fmt.Errorf("unexpected error from AddActivityTask: %v", err)
+	//   }
+	//   serialize(result)
+	WrapResponse func(error) (*MatchingService_AddActivityTask_Result, error)
+
+	// UnwrapResponse takes the result struct for AddActivityTask
+	// and returns the erorr returned by it (if any).
+	//
+	// The error is non-nil only if AddActivityTask threw an
+	// exception.
+	//
+	//   result := deserialize(bytes)
+	//   err := MatchingService_AddActivityTask_Helper.UnwrapResponse(result)
+	UnwrapResponse func(*MatchingService_AddActivityTask_Result) error
+}{}
+
+func init() {
+	MatchingService_AddActivityTask_Helper.Args = func(
+		addRequest *AddActivityTaskRequest,
+	) *MatchingService_AddActivityTask_Args {
+		return &MatchingService_AddActivityTask_Args{
+			AddRequest: addRequest,
+		}
+	}
+
+	MatchingService_AddActivityTask_Helper.IsException = func(err error) bool {
+		switch err.(type) {
+		case *shared.BadRequestError:
+			return true
+		case *shared.InternalServiceError:
+			return true
+		case *shared.ServiceBusyError:
+			return true
+		case *shared.LimitExceededError:
+			return true
+		case *shared.DomainNotActiveError:
+			return true
+		default:
+			return false
+		}
+	}
+
+	MatchingService_AddActivityTask_Helper.WrapResponse = func(err error) (*MatchingService_AddActivityTask_Result, error) {
+		if err == nil {
+			return &MatchingService_AddActivityTask_Result{}, nil
+		}
+
+		switch e := err.(type) {
+		case *shared.BadRequestError:
+			if e == nil {
+				return nil, errors.New("WrapResponse received non-nil error type with nil value for MatchingService_AddActivityTask_Result.BadRequestError")
+			}
+			return &MatchingService_AddActivityTask_Result{BadRequestError: e}, nil
+		case *shared.InternalServiceError:
+			if e == nil {
+				return nil, errors.New("WrapResponse received non-nil error type with nil value for MatchingService_AddActivityTask_Result.InternalServiceError")
+			}
+			return &MatchingService_AddActivityTask_Result{InternalServiceError: e}, nil
+		case *shared.ServiceBusyError:
+			if e == nil {
+				return nil, errors.New("WrapResponse received non-nil error type with nil value for MatchingService_AddActivityTask_Result.ServiceBusyError")
+			}
+			return &MatchingService_AddActivityTask_Result{ServiceBusyError: e}, nil
+		case *shared.LimitExceededError:
+			if e == nil {
+				return nil, errors.New("WrapResponse received non-nil error type with nil value for MatchingService_AddActivityTask_Result.LimitExceededError")
+			}
+			return &MatchingService_AddActivityTask_Result{LimitExceededError: e}, nil
+		case *shared.DomainNotActiveError:
+			if e == nil {
+				return nil, errors.New("WrapResponse received non-nil error type with nil value for MatchingService_AddActivityTask_Result.DomainNotActiveError")
+			}
+			return &MatchingService_AddActivityTask_Result{DomainNotActiveError: e}, nil
+		}
+
+		return nil, err
+	}
+	MatchingService_AddActivityTask_Helper.UnwrapResponse = func(result *MatchingService_AddActivityTask_Result) (err error) {
+		if result.BadRequestError != nil {
+			err = result.BadRequestError
+			return
+		}
+		if result.InternalServiceError != nil {
+			err = result.InternalServiceError
+			return
+		}
+		if result.ServiceBusyError != nil {
+			err = result.ServiceBusyError
+			return
+		}
+		if result.LimitExceededError != nil {
+			err = result.LimitExceededError
+			return
+		}
+		if result.DomainNotActiveError != nil {
+			err = result.DomainNotActiveError
+			return
+		}
+		return
+	}
+
+}
+
+// MatchingService_AddActivityTask_Result represents the result of a MatchingService.AddActivityTask function call.
+//
+// The result of a AddActivityTask execution is sent and received over the wire as this struct.
+type MatchingService_AddActivityTask_Result struct {
+	BadRequestError      *shared.BadRequestError      `json:"badRequestError,omitempty"`
+	InternalServiceError *shared.InternalServiceError `json:"internalServiceError,omitempty"`
+	ServiceBusyError     *shared.ServiceBusyError     `json:"serviceBusyError,omitempty"`
+	LimitExceededError   *shared.LimitExceededError   `json:"limitExceededError,omitempty"`
+	DomainNotActiveError *shared.DomainNotActiveError `json:"domainNotActiveError,omitempty"`
+}
+
+// ToWire translates a MatchingService_AddActivityTask_Result struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *MatchingService_AddActivityTask_Result) ToWire() (wire.Value, error) {
+	var (
+		fields [5]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.BadRequestError != nil {
+		w, err = v.BadRequestError.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 1, Value: w}
+		i++
+	}
+	if v.InternalServiceError != nil {
+		w, err = v.InternalServiceError.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 2, Value: w}
+		i++
+	}
+	if v.ServiceBusyError != nil {
+		w, err = v.ServiceBusyError.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 3, Value: w}
+		i++
+	}
+	if v.LimitExceededError != nil {
+		w, err = v.LimitExceededError.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 4, Value: w}
+		i++
+	}
+	if v.DomainNotActiveError != nil {
+		w, err = v.DomainNotActiveError.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 5, Value: w}
+		i++
+	}
+
+	if i > 1 {
+		return wire.Value{}, fmt.Errorf("MatchingService_AddActivityTask_Result should have at most one field: got %v fields", i)
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+func _BadRequestError_Read(w wire.Value) (*shared.BadRequestError, error) {
+	var v shared.BadRequestError
+	err := v.FromWire(w)
+	return &v, err
+}
+
+func _InternalServiceError_Read(w wire.Value) (*shared.InternalServiceError, error) {
+	var v shared.InternalServiceError
+	err := v.FromWire(w)
+	return &v, err
+}
+
+func _ServiceBusyError_Read(w wire.Value) (*shared.ServiceBusyError, error) {
+	var v shared.ServiceBusyError
+	err := v.FromWire(w)
+	return &v, err
+}
+
+func _LimitExceededError_Read(w wire.Value) (*shared.LimitExceededError, error) {
+	var v shared.LimitExceededError
+	err := v.FromWire(w)
+	return &v, err
+}
+
+func _DomainNotActiveError_Read(w wire.Value) (*shared.DomainNotActiveError, error) {
+	var v shared.DomainNotActiveError
+	err := v.FromWire(w)
+	return &v, err
+}
+
+// FromWire deserializes a MatchingService_AddActivityTask_Result struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a MatchingService_AddActivityTask_Result struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v MatchingService_AddActivityTask_Result
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *MatchingService_AddActivityTask_Result) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 1:
+			if field.Value.Type() == wire.TStruct {
+				v.BadRequestError, err = _BadRequestError_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 2:
+			if field.Value.Type() == wire.TStruct {
+				v.InternalServiceError, err = _InternalServiceError_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 3:
+			if field.Value.Type() == wire.TStruct {
+				v.ServiceBusyError, err = _ServiceBusyError_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 4:
+			if field.Value.Type() == wire.TStruct {
+				v.LimitExceededError, err = _LimitExceededError_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 5:
+			if field.Value.Type() == wire.TStruct {
+				v.DomainNotActiveError, err = _DomainNotActiveError_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+
+	count := 0
+	if v.BadRequestError != nil {
+		count++
+	}
+	if v.InternalServiceError != nil {
+		count++
+	}
+	if v.ServiceBusyError != nil {
+		count++
+	}
+	if v.LimitExceededError != nil {
+		count++
+	}
+	if v.DomainNotActiveError != nil {
+		count++
+	}
+	if count > 1 {
+		return fmt.Errorf("MatchingService_AddActivityTask_Result should have at most one field: got %v fields", count)
+	}
+
+	return nil
+}
+
+// String returns a readable string representation of a MatchingService_AddActivityTask_Result
+// struct.
+func (v *MatchingService_AddActivityTask_Result) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [5]string
+	i := 0
+	if v.BadRequestError != nil {
+		fields[i] = fmt.Sprintf("BadRequestError: %v", v.BadRequestError)
+		i++
+	}
+	if v.InternalServiceError != nil {
+		fields[i] = fmt.Sprintf("InternalServiceError: %v", v.InternalServiceError)
+		i++
+	}
+	if v.ServiceBusyError != nil {
+		fields[i] = fmt.Sprintf("ServiceBusyError: %v", v.ServiceBusyError)
+		i++
+	}
+	if v.LimitExceededError != nil {
+		fields[i] = fmt.Sprintf("LimitExceededError: %v", v.LimitExceededError)
+		i++
+	}
+	if v.DomainNotActiveError != nil {
+		fields[i] = fmt.Sprintf("DomainNotActiveError: %v", v.DomainNotActiveError)
+		i++
+	}
+
+	return fmt.Sprintf("MatchingService_AddActivityTask_Result{%v}", strings.Join(fields[:i], ", "))
+}
+
+// Equals returns true if all the fields of this MatchingService_AddActivityTask_Result match the
+// provided MatchingService_AddActivityTask_Result.
+//
+// This function performs a deep comparison.
+func (v *MatchingService_AddActivityTask_Result) Equals(rhs *MatchingService_AddActivityTask_Result) bool {
+	if v == nil {
+		return rhs == nil
+	} else if rhs == nil {
+		return false
+	}
+	if !((v.BadRequestError == nil && rhs.BadRequestError == nil) || (v.BadRequestError != nil && rhs.BadRequestError != nil && v.BadRequestError.Equals(rhs.BadRequestError))) {
+		return false
+	}
+	if !((v.InternalServiceError == nil && rhs.InternalServiceError == nil) || (v.InternalServiceError != nil && rhs.InternalServiceError != nil && v.InternalServiceError.Equals(rhs.InternalServiceError))) {
+		return false
+	}
+	if !((v.ServiceBusyError == nil && rhs.ServiceBusyError == nil) || (v.ServiceBusyError != nil && rhs.ServiceBusyError != nil && v.ServiceBusyError.Equals(rhs.ServiceBusyError))) {
+		return false
+	}
+	if !((v.LimitExceededError == nil && rhs.LimitExceededError == nil) || (v.LimitExceededError != nil && rhs.LimitExceededError != nil && v.LimitExceededError.Equals(rhs.LimitExceededError))) {
+		return false
+	}
+	if !((v.DomainNotActiveError == nil && rhs.DomainNotActiveError == nil) || (v.DomainNotActiveError != nil && rhs.DomainNotActiveError != nil && v.DomainNotActiveError.Equals(rhs.DomainNotActiveError))) {
+		return false
+	}
+
+	return true
+}
+
+// MarshalLogObject implements zapcore.ObjectMarshaler, enabling
+// fast logging of MatchingService_AddActivityTask_Result.
+func (v *MatchingService_AddActivityTask_Result) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+	if v == nil {
+		return nil
+	}
+	if v.BadRequestError != nil {
+		err = multierr.Append(err, enc.AddObject("badRequestError", v.BadRequestError))
+	}
+	if v.InternalServiceError != nil {
+		err = multierr.Append(err, enc.AddObject("internalServiceError", v.InternalServiceError))
+	}
+	if v.ServiceBusyError != nil {
+		err = multierr.Append(err, enc.AddObject("serviceBusyError", v.ServiceBusyError))
+	}
+	if v.LimitExceededError != nil {
+		err = multierr.Append(err, enc.AddObject("limitExceededError", v.LimitExceededError))
+	}
+	if v.DomainNotActiveError != nil {
+		err = multierr.Append(err, enc.AddObject("domainNotActiveError", v.DomainNotActiveError))
+	}
+	return err
+}
+
+// GetBadRequestError returns the value of BadRequestError if it is set or its
+// zero value if it is unset.
+func (v *MatchingService_AddActivityTask_Result) GetBadRequestError() (o *shared.BadRequestError) {
+	if v != nil && v.BadRequestError != nil {
+		return v.BadRequestError
+	}
+
+	return
+}
+
+// IsSetBadRequestError returns true if BadRequestError is not nil.
+func (v *MatchingService_AddActivityTask_Result) IsSetBadRequestError() bool {
+	return v != nil && v.BadRequestError != nil
+}
+
+// GetInternalServiceError returns the value of InternalServiceError if it is set or its
+// zero value if it is unset.
+func (v *MatchingService_AddActivityTask_Result) GetInternalServiceError() (o *shared.InternalServiceError) {
+	if v != nil && v.InternalServiceError != nil {
+		return v.InternalServiceError
+	}
+
+	return
+}
+
+// IsSetInternalServiceError returns true if InternalServiceError is not nil.
+func (v *MatchingService_AddActivityTask_Result) IsSetInternalServiceError() bool {
+	return v != nil && v.InternalServiceError != nil
+}
+
+// GetServiceBusyError returns the value of ServiceBusyError if it is set or its
+// zero value if it is unset.
+func (v *MatchingService_AddActivityTask_Result) GetServiceBusyError() (o *shared.ServiceBusyError) {
+	if v != nil && v.ServiceBusyError != nil {
+		return v.ServiceBusyError
+	}
+
+	return
+}
+
+// IsSetServiceBusyError returns true if ServiceBusyError is not nil.
+func (v *MatchingService_AddActivityTask_Result) IsSetServiceBusyError() bool {
+	return v != nil && v.ServiceBusyError != nil
+}
+
+// GetLimitExceededError returns the value of LimitExceededError if it is set or its
+// zero value if it is unset.
+func (v *MatchingService_AddActivityTask_Result) GetLimitExceededError() (o *shared.LimitExceededError) {
+	if v != nil && v.LimitExceededError != nil {
+		return v.LimitExceededError
+	}
+
+	return
+}
+
+// IsSetLimitExceededError returns true if LimitExceededError is not nil.
+func (v *MatchingService_AddActivityTask_Result) IsSetLimitExceededError() bool {
+	return v != nil && v.LimitExceededError != nil
+}
+
+// GetDomainNotActiveError returns the value of DomainNotActiveError if it is set or its
+// zero value if it is unset.
+func (v *MatchingService_AddActivityTask_Result) GetDomainNotActiveError() (o *shared.DomainNotActiveError) {
+	if v != nil && v.DomainNotActiveError != nil {
+		return v.DomainNotActiveError
+	}
+
+	return
+}
+
+// IsSetDomainNotActiveError returns true if DomainNotActiveError is not nil.
+func (v *MatchingService_AddActivityTask_Result) IsSetDomainNotActiveError() bool {
+	return v != nil && v.DomainNotActiveError != nil
+}
+
+// MethodName returns the name of the Thrift function as specified in
+// the IDL, for which this struct represent the result.
+//
+// This will always be "AddActivityTask" for this struct.
+func (v *MatchingService_AddActivityTask_Result) MethodName() string {
+	return "AddActivityTask"
+}
+
+// EnvelopeType returns the kind of value inside this struct.
+//
+// This will always be Reply for this struct.
+func (v *MatchingService_AddActivityTask_Result) EnvelopeType() wire.EnvelopeType {
+	return wire.Reply
+}
+
+// MatchingService_AddDecisionTask_Args represents the arguments for the MatchingService.AddDecisionTask function.
+//
+// The arguments for AddDecisionTask are sent and received over the wire as this struct.
+type MatchingService_AddDecisionTask_Args struct {
+	AddRequest *AddDecisionTaskRequest `json:"addRequest,omitempty"`
+}
+
+// ToWire translates a MatchingService_AddDecisionTask_Args struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *MatchingService_AddDecisionTask_Args) ToWire() (wire.Value, error) {
+	var (
+		fields [1]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.AddRequest != nil {
+		w, err = v.AddRequest.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 1, Value: w}
+		i++
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+func _AddDecisionTaskRequest_Read(w wire.Value) (*AddDecisionTaskRequest, error) {
+	var v AddDecisionTaskRequest
+	err := v.FromWire(w)
+	return &v, err
+}
+
+// FromWire deserializes a MatchingService_AddDecisionTask_Args struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a MatchingService_AddDecisionTask_Args struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v MatchingService_AddDecisionTask_Args
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *MatchingService_AddDecisionTask_Args) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 1:
+			if field.Value.Type() == wire.TStruct {
+				v.AddRequest, err = _AddDecisionTaskRequest_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+
+	return nil
+}
+
+// String returns a readable string representation of a MatchingService_AddDecisionTask_Args
+// struct.
+func (v *MatchingService_AddDecisionTask_Args) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [1]string
+	i := 0
+	if v.AddRequest != nil {
+		fields[i] = fmt.Sprintf("AddRequest: %v", v.AddRequest)
+		i++
+	}
+
+	return fmt.Sprintf("MatchingService_AddDecisionTask_Args{%v}", strings.Join(fields[:i], ", "))
+}
+
+// Equals returns true if all the fields of this MatchingService_AddDecisionTask_Args match the
+// provided MatchingService_AddDecisionTask_Args.
+//
+// This function performs a deep comparison.
+func (v *MatchingService_AddDecisionTask_Args) Equals(rhs *MatchingService_AddDecisionTask_Args) bool {
+	if v == nil {
+		return rhs == nil
+	} else if rhs == nil {
+		return false
+	}
+	if !((v.AddRequest == nil && rhs.AddRequest == nil) || (v.AddRequest != nil && rhs.AddRequest != nil && v.AddRequest.Equals(rhs.AddRequest))) {
+		return false
+	}
+
+	return true
+}
+
+// MarshalLogObject implements zapcore.ObjectMarshaler, enabling
+// fast logging of MatchingService_AddDecisionTask_Args.
+func (v *MatchingService_AddDecisionTask_Args) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+	if v == nil {
+		return nil
+	}
+	if v.AddRequest != nil {
+		err = multierr.Append(err, enc.AddObject("addRequest", v.AddRequest))
+	}
+	return err
+}
+
+// GetAddRequest returns the value of AddRequest if it is set or its
+// zero value if it is unset.
+func (v *MatchingService_AddDecisionTask_Args) GetAddRequest() (o *AddDecisionTaskRequest) {
+	if v != nil && v.AddRequest != nil {
+		return v.AddRequest
+	}
+
+	return
+}
+
+// IsSetAddRequest returns true if AddRequest is not nil.
+func (v *MatchingService_AddDecisionTask_Args) IsSetAddRequest() bool {
+	return v != nil && v.AddRequest != nil
+}
+
+// MethodName returns the name of the Thrift function as specified in
+// the IDL, for which this struct represent the arguments.
+//
+// This will always be "AddDecisionTask" for this struct.
+func (v *MatchingService_AddDecisionTask_Args) MethodName() string {
+	return "AddDecisionTask"
+}
+
+// EnvelopeType returns the kind of value inside this struct.
+//
+// This will always be Call for this struct.
+func (v *MatchingService_AddDecisionTask_Args) EnvelopeType() wire.EnvelopeType {
+	return wire.Call
+}
+
+// MatchingService_AddDecisionTask_Helper provides functions that aid in handling the
+// parameters and return values of the MatchingService.AddDecisionTask
+// function.
+var MatchingService_AddDecisionTask_Helper = struct {
+	// Args accepts the parameters of AddDecisionTask in-order and returns
+	// the arguments struct for the function.
+	Args func(
+		addRequest *AddDecisionTaskRequest,
+	) *MatchingService_AddDecisionTask_Args
+
+	// IsException returns true if the given error can be thrown
+	// by AddDecisionTask.
+	//
+	// An error can be thrown by AddDecisionTask only if the
+	// corresponding exception type was mentioned in the 'throws'
+	// section for it in the Thrift file.
+	IsException func(error) bool
+
+	// WrapResponse returns the result struct for AddDecisionTask
+	// given the error returned by it. The provided error may
+	// be nil if AddDecisionTask did not fail.
+	//
+	// This allows mapping errors returned by AddDecisionTask into a
+	// serializable result struct. WrapResponse returns a
+	// non-nil error if the provided error cannot be thrown by
+	// AddDecisionTask
+	//
+	//   err := AddDecisionTask(args)
+	//   result, err := MatchingService_AddDecisionTask_Helper.WrapResponse(err)
+	//   if err != nil {
+	//     return fmt.Errorf("unexpected error from AddDecisionTask: %v", err)
+	//   }
+	//   serialize(result)
+	WrapResponse func(error) (*MatchingService_AddDecisionTask_Result, error)
+
+	// UnwrapResponse takes the result struct for AddDecisionTask
+	// and returns the erorr returned by it (if any).
+	//
+	// The error is non-nil only if AddDecisionTask threw an
+	// exception.
+	//
+	//   result := deserialize(bytes)
+	//   err := MatchingService_AddDecisionTask_Helper.UnwrapResponse(result)
+	UnwrapResponse func(*MatchingService_AddDecisionTask_Result) error
+}{}
+
+func init() {
+	MatchingService_AddDecisionTask_Helper.Args = func(
+		addRequest *AddDecisionTaskRequest,
+	) *MatchingService_AddDecisionTask_Args {
+		return &MatchingService_AddDecisionTask_Args{
+			AddRequest: addRequest,
+		}
+	}
+
+	MatchingService_AddDecisionTask_Helper.IsException = func(err error) bool {
+		switch err.(type) {
+		case *shared.BadRequestError:
+			return true
+		case *shared.InternalServiceError:
+			return true
+		case *shared.ServiceBusyError:
+			return true
+		case *shared.LimitExceededError:
+			return true
+		case *shared.DomainNotActiveError:
+			return true
+		default:
+			return false
+		}
+	}
+
+	MatchingService_AddDecisionTask_Helper.WrapResponse = func(err error) (*MatchingService_AddDecisionTask_Result, error) {
+		if err == nil {
+			return &MatchingService_AddDecisionTask_Result{}, nil
+		}
+
+		switch e := err.(type) {
+		case *shared.BadRequestError:
+			if e == nil {
+				return nil, errors.New("WrapResponse received non-nil error type with nil value for MatchingService_AddDecisionTask_Result.BadRequestError")
+			}
+			return &MatchingService_AddDecisionTask_Result{BadRequestError: e}, nil
+		case *shared.InternalServiceError:
+			if e == nil {
+				return nil, errors.New("WrapResponse received non-nil error type with nil value for MatchingService_AddDecisionTask_Result.InternalServiceError")
+			}
+			return &MatchingService_AddDecisionTask_Result{InternalServiceError: e}, nil
+		case *shared.ServiceBusyError:
+			if e == nil {
+				return nil, errors.New("WrapResponse received non-nil error type with nil value for MatchingService_AddDecisionTask_Result.ServiceBusyError")
+			}
+			return &MatchingService_AddDecisionTask_Result{ServiceBusyError: e}, nil
+		case *shared.LimitExceededError:
+			if e == nil {
+				return nil, errors.New("WrapResponse received non-nil error type with nil value for MatchingService_AddDecisionTask_Result.LimitExceededError")
+			}
+			return &MatchingService_AddDecisionTask_Result{LimitExceededError: e}, nil
+		case *shared.DomainNotActiveError:
+			if e == nil {
+				return nil, errors.New("WrapResponse received non-nil error type with nil value for MatchingService_AddDecisionTask_Result.DomainNotActiveError")
+			}
+			return &MatchingService_AddDecisionTask_Result{DomainNotActiveError: e}, nil
+		}
+
+		return nil, err
+	}
+	MatchingService_AddDecisionTask_Helper.UnwrapResponse = func(result *MatchingService_AddDecisionTask_Result) (err error) {
+		if result.BadRequestError != nil {
+			err = result.BadRequestError
+			return
+		}
+		if result.InternalServiceError != nil {
+			err = result.InternalServiceError
+			return
+		}
+		if result.ServiceBusyError != nil {
+			err = result.ServiceBusyError
+			return
+		}
+		if result.LimitExceededError != nil {
+			err = result.LimitExceededError
+			return
+		}
+		if result.DomainNotActiveError != nil {
+			err = result.DomainNotActiveError
+			return
+		}
+		return
+	}
+
+}
+
+// MatchingService_AddDecisionTask_Result represents the result of a MatchingService.AddDecisionTask function call.
+//
+// The result of a AddDecisionTask execution is sent and received over the wire as this struct.
+type MatchingService_AddDecisionTask_Result struct {
+	BadRequestError      *shared.BadRequestError      `json:"badRequestError,omitempty"`
+	InternalServiceError *shared.InternalServiceError `json:"internalServiceError,omitempty"`
+	ServiceBusyError     *shared.ServiceBusyError     `json:"serviceBusyError,omitempty"`
+	LimitExceededError   *shared.LimitExceededError   `json:"limitExceededError,omitempty"`
+	DomainNotActiveError *shared.DomainNotActiveError `json:"domainNotActiveError,omitempty"`
+}
+
+// ToWire translates a MatchingService_AddDecisionTask_Result struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *MatchingService_AddDecisionTask_Result) ToWire() (wire.Value, error) {
+	var (
+		fields [5]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.BadRequestError != nil {
+		w, err = v.BadRequestError.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 1, Value: w}
+		i++
+	}
+	if v.InternalServiceError != nil {
+		w, err = v.InternalServiceError.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 2, Value: w}
+		i++
+	}
+	if v.ServiceBusyError != nil {
+		w, err = v.ServiceBusyError.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 3, Value: w}
+		i++
+	}
+	if v.LimitExceededError != nil {
+		w, err = v.LimitExceededError.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 4, Value: w}
+		i++
+	}
+	if v.DomainNotActiveError != nil {
+		w, err = v.DomainNotActiveError.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 5, Value: w}
+		i++
+	}
+
+	if i > 1 {
+		return wire.Value{}, fmt.Errorf("MatchingService_AddDecisionTask_Result should have at most one field: got %v fields", i)
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+// FromWire deserializes a MatchingService_AddDecisionTask_Result struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a MatchingService_AddDecisionTask_Result struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v MatchingService_AddDecisionTask_Result
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *MatchingService_AddDecisionTask_Result) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 1:
+			if field.Value.Type() == wire.TStruct {
+				v.BadRequestError, err = _BadRequestError_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 2:
+			if field.Value.Type() == wire.TStruct {
+				v.InternalServiceError, err = _InternalServiceError_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 3:
+			if field.Value.Type() == wire.TStruct {
+				v.ServiceBusyError, err = _ServiceBusyError_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 4:
+			if field.Value.Type() == wire.TStruct {
+				v.LimitExceededError, err = _LimitExceededError_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 5:
+			if field.Value.Type() == wire.TStruct {
+				v.DomainNotActiveError, err = _DomainNotActiveError_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+
+	count := 0
+	if v.BadRequestError != nil {
+		count++
+	}
+	if v.InternalServiceError != nil {
+		count++
+	}
+	if v.ServiceBusyError != nil {
+		count++
+	}
+	if v.LimitExceededError != nil {
+		count++
+	}
+	if v.DomainNotActiveError != nil {
+		count++
+	}
+	if count > 1 {
+		return fmt.Errorf("MatchingService_AddDecisionTask_Result should have at most one field: got %v fields", count)
+	}
+
+	return nil
+}
+
+// String returns a readable string representation of a MatchingService_AddDecisionTask_Result
+// struct.
+func (v *MatchingService_AddDecisionTask_Result) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [5]string
+	i := 0
+	if v.BadRequestError != nil {
+		fields[i] = fmt.Sprintf("BadRequestError: %v", v.BadRequestError)
+		i++
+	}
+	if v.InternalServiceError != nil {
+		fields[i] = fmt.Sprintf("InternalServiceError: %v", v.InternalServiceError)
+		i++
+	}
+	if v.ServiceBusyError != nil {
+		fields[i] = fmt.Sprintf("ServiceBusyError: %v", v.ServiceBusyError)
+		i++
+	}
+	if v.LimitExceededError != nil {
+		fields[i] = fmt.Sprintf("LimitExceededError: %v", v.LimitExceededError)
+		i++
+	}
+	if v.DomainNotActiveError != nil {
+		fields[i] = fmt.Sprintf("DomainNotActiveError: %v", v.DomainNotActiveError)
+		i++
+	}
+
+	return fmt.Sprintf("MatchingService_AddDecisionTask_Result{%v}", strings.Join(fields[:i], ", "))
 }
 
-// GetQuery returns the value of Query if it is set or its
-// zero value if it is unset.
-func (v *PollForDecisionTaskResponse) GetQuery() (o *shared.WorkflowQuery) {
-	if v != nil && v.Query != nil {
-		return v.Query
+// Equals returns true if all the fields of this MatchingService_AddDecisionTask_Result match the
+// provided MatchingService_AddDecisionTask_Result.
+//
+// This function performs a deep comparison.
+func (v *MatchingService_AddDecisionTask_Result) Equals(rhs *MatchingService_AddDecisionTask_Result) bool {
+	if v == nil {
+		return rhs == nil
+	} else if rhs == nil {
+		return false
+	}
+	if !((v.BadRequestError == nil && rhs.BadRequestError == nil) || (v.BadRequestError != nil && rhs.BadRequestError != nil && v.BadRequestError.Equals(rhs.BadRequestError))) {
+		return false
+	}
+	if !((v.InternalServiceError == nil && rhs.InternalServiceError == nil) || (v.InternalServiceError != nil && rhs.InternalServiceError != nil && v.InternalServiceError.Equals(rhs.InternalServiceError))) {
+		return false
+	}
+	if !((v.ServiceBusyError == nil && rhs.ServiceBusyError == nil) || (v.ServiceBusyError != nil && rhs.ServiceBusyError != nil && v.ServiceBusyError.Equals(rhs.ServiceBusyError))) {
+		return false
+	}
+	if !((v.LimitExceededError == nil && rhs.LimitExceededError == nil) || (v.LimitExceededError != nil && rhs.LimitExceededError != nil && v.LimitExceededError.Equals(rhs.LimitExceededError))) {
+		return false
+	}
+	if !((v.DomainNotActiveError == nil && rhs.DomainNotActiveError == nil) || (v.DomainNotActiveError != nil && rhs.DomainNotActiveError != nil && v.DomainNotActiveError.Equals(rhs.DomainNotActiveError))) {
+		return false
 	}
 
-	return
+	return true
 }
 
-// IsSetQuery returns true if Query is not nil.
-func (v *PollForDecisionTaskResponse) IsSetQuery() bool {
-	return v != nil && v.Query != nil
+// MarshalLogObject implements zapcore.ObjectMarshaler, enabling
+// fast logging of MatchingService_AddDecisionTask_Result.
+func (v *MatchingService_AddDecisionTask_Result) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+	if v == nil {
+		return nil
+	}
+	if v.BadRequestError != nil {
+		err = multierr.Append(err, enc.AddObject("badRequestError", v.BadRequestError))
+	}
+	if v.InternalServiceError != nil {
+		err = multierr.Append(err, enc.AddObject("internalServiceError", v.InternalServiceError))
+	}
+	if v.ServiceBusyError != nil {
+		err = multierr.Append(err, enc.AddObject("serviceBusyError", v.ServiceBusyError))
+	}
+	if v.LimitExceededError != nil {
+		err = multierr.Append(err, enc.AddObject("limitExceededError", v.LimitExceededError))
+	}
+	if v.DomainNotActiveError != nil {
+		err = multierr.Append(err, enc.AddObject("domainNotActiveError", v.DomainNotActiveError))
+	}
+	return err
 }
 
-// GetDecisionInfo returns the value of DecisionInfo if it is set or its
+// GetBadRequestError returns the value of BadRequestError if it is set or its
 // zero value if it is unset.
-func (v *PollForDecisionTaskResponse) GetDecisionInfo() (o *shared.TransientDecisionInfo) {
-	if v != nil && v.DecisionInfo != nil {
-		return v.DecisionInfo
+func (v *MatchingService_AddDecisionTask_Result) GetBadRequestError() (o *shared.BadRequestError) {
+	if v != nil && v.BadRequestError != nil {
+		return v.BadRequestError
 	}
 
 	return
 }
 
-// IsSetDecisionInfo returns true if DecisionInfo is not nil.
-func (v *PollForDecisionTaskResponse) IsSetDecisionInfo() bool {
-	return v != nil && v.DecisionInfo != nil
+// IsSetBadRequestError returns true if BadRequestError is not nil.
+func (v *MatchingService_AddDecisionTask_Result) IsSetBadRequestError() bool {
+	return v != nil && v.BadRequestError != nil
 }
 
-// GetWorkflowExecutionTaskList returns the value of WorkflowExecutionTaskList if it is set or its
+// GetInternalServiceError returns the value of InternalServiceError if it is set or its
 // zero value if it is unset.
-func (v *PollForDecisionTaskResponse) GetWorkflowExecutionTaskList() (o *shared.TaskList) {
-	if v != nil && v.WorkflowExecutionTaskList != nil {
-		return v.WorkflowExecutionTaskList
+func (v *MatchingService_AddDecisionTask_Result) GetInternalServiceError() (o *shared.InternalServiceError) {
+	if v != nil && v.InternalServiceError != nil {
+		return v.InternalServiceError
 	}
 
 	return
 }
 
-// IsSetWorkflowExecutionTaskList returns true if WorkflowExecutionTaskList is not nil.
-func (v *PollForDecisionTaskResponse) IsSetWorkflowExecutionTaskList() bool {
-	return v != nil && v.WorkflowExecutionTaskList != nil
+// IsSetInternalServiceError returns true if InternalServiceError is not nil.
+func (v *MatchingService_AddDecisionTask_Result) IsSetInternalServiceError() bool {
+	return v != nil && v.InternalServiceError != nil
 }
 
-// GetEventStoreVersion returns the value of EventStoreVersion if it is set or its
+// GetServiceBusyError returns the value of ServiceBusyError if it is set or its
 // zero value if it is unset.
-func (v *PollForDecisionTaskResponse) GetEventStoreVersion() (o int32) {
-	if v != nil && v.EventStoreVersion != nil {
-		return *v.EventStoreVersion
+func (v *MatchingService_AddDecisionTask_Result) GetServiceBusyError() (o *shared.ServiceBusyError) {
+	if v != nil && v.ServiceBusyError != nil {
+		return v.ServiceBusyError
 	}
 
 	return
 }
 
-// IsSetEventStoreVersion returns true if EventStoreVersion is not nil.
-func (v *PollForDecisionTaskResponse) IsSetEventStoreVersion() bool {
-	return v != nil && v.EventStoreVersion != nil
+// IsSetServiceBusyError returns true if ServiceBusyError is not nil.
+func (v *MatchingService_AddDecisionTask_Result) IsSetServiceBusyError() bool {
+	return v != nil && v.ServiceBusyError != nil
 }
 
-// GetBranchToken returns the value of BranchToken if it is set or its
+// GetLimitExceededError returns the value of LimitExceededError if it is set or its
 // zero value if it is unset.
-func (v *PollForDecisionTaskResponse) GetBranchToken() (o []byte) {
-	if v != nil && v.BranchToken != nil {
-		return v.BranchToken
+func (v *MatchingService_AddDecisionTask_Result) GetLimitExceededError() (o *shared.LimitExceededError) {
+	if v != nil && v.LimitExceededError != nil {
+		return v.LimitExceededError
 	}
 
 	return
 }
 
-// IsSetBranchToken returns true if BranchToken is not nil.
-func (v *PollForDecisionTaskResponse) IsSetBranchToken() bool {
-	return v != nil && v.BranchToken != nil
+// IsSetLimitExceededError returns true if LimitExceededError is not nil.
+func (v *MatchingService_AddDecisionTask_Result) IsSetLimitExceededError() bool {
+	return v != nil && v.LimitExceededError != nil
 }
 
-// GetScheduledTimestamp returns the value of ScheduledTimestamp if it is set or its
+// GetDomainNotActiveError returns the value of DomainNotActiveError if it is set or its
 // zero value if it is unset.
-func (v *PollForDecisionTaskResponse) GetScheduledTimestamp() (o int64) {
-	if v != nil && v.ScheduledTimestamp != nil {
-		return *v.ScheduledTimestamp
+func (v *MatchingService_AddDecisionTask_Result) GetDomainNotActiveError() (o *shared.DomainNotActiveError) {
+	if v != nil && v.DomainNotActiveError != nil {
+		return v.DomainNotActiveError
 	}
 
 	return
 }
 
-// IsSetScheduledTimestamp returns true if ScheduledTimestamp is not nil.
-func (v *PollForDecisionTaskResponse) IsSetScheduledTimestamp() bool {
-	return v != nil && v.ScheduledTimestamp != nil
+// IsSetDomainNotActiveError returns true if DomainNotActiveError is not nil.
+func (v *MatchingService_AddDecisionTask_Result) IsSetDomainNotActiveError() bool {
+	return v != nil && v.DomainNotActiveError != nil
 }
 
-// GetStartedTimestamp returns the value of StartedTimestamp if it is set or its
-// zero value if it is unset.
-func (v *PollForDecisionTaskResponse) GetStartedTimestamp() (o int64) {
-	if v != nil && v.StartedTimestamp != nil {
-		return *v.StartedTimestamp
-	}
-
-	return
+// MethodName returns the name of the Thrift function as specified in
+// the IDL, for which this struct represent the result.
+//
+// This will always be "AddDecisionTask" for this struct.
+func (v *MatchingService_AddDecisionTask_Result) MethodName() string {
+	return "AddDecisionTask"
 }
 
-// IsSetStartedTimestamp returns true if StartedTimestamp is not nil.
-func (v *PollForDecisionTaskResponse) IsSetStartedTimestamp() bool {
-	return v != nil && v.StartedTimestamp != nil
+// EnvelopeType returns the kind of value inside this struct.
+//
+// This will always be Reply for this struct.
+func (v *MatchingService_AddDecisionTask_Result) EnvelopeType() wire.EnvelopeType {
+	return wire.Reply
 }
 
-type QueryWorkflowRequest struct {
-	DomainUUID   *string                      `json:"domainUUID,omitempty"`
-	TaskList     *shared.TaskList             `json:"taskList,omitempty"`
-	QueryRequest *shared.QueryWorkflowRequest `json:"queryRequest,omitempty"`
+// MatchingService_CancelOutstandingPoll_Args represents the arguments for the MatchingService.CancelOutstandingPoll function.
+//
+// The arguments for CancelOutstandingPoll are sent and received over the wire as this struct.
+type MatchingService_CancelOutstandingPoll_Args struct {
+	Request *CancelOutstandingPollRequest `json:"request,omitempty"`
 }
 
-// ToWire translates a QueryWorkflowRequest struct into a Thrift-level intermediate
+// ToWire translates a MatchingService_CancelOutstandingPoll_Args struct into a Thrift-level intermediate
 // representation. This intermediate representation may be serialized
 // into bytes using a ThriftRW protocol implementation.
 //
@@ -2489,53 +5242,37 @@ type QueryWorkflowRequest struct {
 //   if err := binaryProtocol.Encode(x, writer); err != nil {
 //     return err
 //   }
-func (v *QueryWorkflowRequest) ToWire() (wire.Value, error) {
+func (v *MatchingService_CancelOutstandingPoll_Args) ToWire() (wire.Value, error) {
 	var (
-		fields [3]wire.Field
+		fields [1]wire.Field
 		i      int = 0
 		w      wire.Value
 		err    error
 	)
 
-	if v.DomainUUID != nil {
-		w, err = wire.NewValueString(*(v.DomainUUID)), error(nil)
-		if err != nil {
-			return w, err
-		}
-		fields[i] = wire.Field{ID: 10, Value: w}
-		i++
-	}
-	if v.TaskList != nil {
-		w, err = v.TaskList.ToWire()
-		if err != nil {
-			return w, err
-		}
-		fields[i] = wire.Field{ID: 20, Value: w}
-		i++
-	}
-	if v.QueryRequest != nil {
-		w, err = v.QueryRequest.ToWire()
+	if v.Request != nil {
+		w, err = v.Request.ToWire()
 		if err != nil {
 			return w, err
 		}
-		fields[i] = wire.Field{ID: 30, Value: w}
+		fields[i] = wire.Field{ID: 1, Value: w}
 		i++
 	}
 
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
 
-func _QueryWorkflowRequest_Read(w wire.Value) (*shared.QueryWorkflowRequest, error) {
-	var v shared.QueryWorkflowRequest
+func _CancelOutstandingPollRequest_Read(w wire.Value) (*CancelOutstandingPollRequest, error) {
+	var v CancelOutstandingPollRequest
 	err := v.FromWire(w)
 	return &v, err
 }
 
-// FromWire deserializes a QueryWorkflowRequest struct from its Thrift-level
+// FromWire deserializes a MatchingService_CancelOutstandingPoll_Args struct from its Thrift-level
 // representation. The Thrift-level representation may be obtained
 // from a ThriftRW protocol implementation.
 //
-// An error is returned if we were unable to build a QueryWorkflowRequest struct
+// An error is returned if we were unable to build a MatchingService_CancelOutstandingPoll_Args struct
 // from the provided intermediate representation.
 //
 //   x, err := binaryProtocol.Decode(reader, wire.TStruct)
@@ -2543,37 +5280,19 @@ func _QueryWorkflowRequest_Read(w wire.Value) (*shared.QueryWorkflowRequest, err
 //     return nil, err
 //   }
 //
-//   var v QueryWorkflowRequest
+//   var v MatchingService_CancelOutstandingPoll_Args
 //   if err := v.FromWire(x); err != nil {
 //     return nil, err
 //   }
 //   return &v, nil
-func (v *QueryWorkflowRequest) FromWire(w wire.Value) error {
+func (v *MatchingService_CancelOutstandingPoll_Args) FromWire(w wire.Value) error {
 	var err error
 
 	for _, field := range w.GetStruct().Fields {
 		switch field.ID {
-		case 10:
-			if field.Value.Type() == wire.TBinary {
-				var x string
-				x, err = field.Value.GetString(), error(nil)
-				v.DomainUUID = &x
-				if err != nil {
-					return err
-				}
-
-			}
-		case 20:
-			if field.Value.Type() == wire.TStruct {
-				v.TaskList, err = _TaskList_Read(field.Value)
-				if err != nil {
-					return err
-				}
-
-			}
-		case 30:
+		case 1:
 			if field.Value.Type() == wire.TStruct {
-				v.QueryRequest, err = _QueryWorkflowRequest_Read(field.Value)
+				v.Request, err = _CancelOutstandingPollRequest_Read(field.Value)
 				if err != nil {
 					return err
 				}
@@ -2585,48 +5304,34 @@ func (v *QueryWorkflowRequest) FromWire(w wire.Value) error {
 	return nil
 }
 
-// String returns a readable string representation of a QueryWorkflowRequest
+// String returns a readable string representation of a MatchingService_CancelOutstandingPoll_Args
 // struct.
-func (v *QueryWorkflowRequest) String() string {
+func (v *MatchingService_CancelOutstandingPoll_Args) String() string {
 	if v == nil {
 		return "<nil>"
 	}
 
-	var fields [3]string
+	var fields [1]string
 	i := 0
-	if v.DomainUUID != nil {
-		fields[i] = fmt.Sprintf("DomainUUID: %v", *(v.DomainUUID))
-		i++
-	}
-	if v.TaskList != nil {
-		fields[i] = fmt.Sprintf("TaskList: %v", v.TaskList)
-		i++
-	}
-	if v.QueryRequest != nil {
-		fields[i] = fmt.Sprintf("QueryRequest: %v", v.QueryRequest)
+	if v.Request != nil {
+		fields[i] = fmt.Sprintf("Request: %v", v.Request)
 		i++
 	}
 
-	return fmt.Sprintf("QueryWorkflowRequest{%v}", strings.Join(fields[:i], ", "))
+	return fmt.Sprintf("MatchingService_CancelOutstandingPoll_Args{%v}", strings.Join(fields[:i], ", "))
 }
 
-// Equals returns true if all the fields of this QueryWorkflowRequest match the
-// provided QueryWorkflowRequest.
+// Equals returns true if all the fields of this MatchingService_CancelOutstandingPoll_Args match the
+// provided MatchingService_CancelOutstandingPoll_Args.
 //
 // This function performs a deep comparison.
-func (v *QueryWorkflowRequest) Equals(rhs *QueryWorkflowRequest) bool {
+func (v *MatchingService_CancelOutstandingPoll_Args) Equals(rhs *MatchingService_CancelOutstandingPoll_Args) bool {
 	if v == nil {
-		return rhs == nil
-	} else if rhs == nil {
-		return false
-	}
-	if !_String_EqualsPtr(v.DomainUUID, rhs.DomainUUID) {
-		return false
-	}
-	if !((v.TaskList == nil && rhs.TaskList == nil) || (v.TaskList != nil && rhs.TaskList != nil && v.TaskList.Equals(rhs.TaskList))) {
+		return rhs == nil
+	} else if rhs == nil {
 		return false
 	}
-	if !((v.QueryRequest == nil && rhs.QueryRequest == nil) || (v.QueryRequest != nil && rhs.QueryRequest != nil && v.QueryRequest.Equals(rhs.QueryRequest))) {
+	if !((v.Request == nil && rhs.Request == nil) || (v.Request != nil && rhs.Request != nil && v.Request.Equals(rhs.Request))) {
 		return false
 	}
 
@@ -2634,76 +5339,168 @@ func (v *QueryWorkflowRequest) Equals(rhs *QueryWorkflowRequest) bool {
 }
 
 // MarshalLogObject implements zapcore.ObjectMarshaler, enabling
-// fast logging of QueryWorkflowRequest.
-func (v *QueryWorkflowRequest) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+// fast logging of MatchingService_CancelOutstandingPoll_Args.
+func (v *MatchingService_CancelOutstandingPoll_Args) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
 	if v == nil {
 		return nil
 	}
-	if v.DomainUUID != nil {
-		enc.AddString("domainUUID", *v.DomainUUID)
-	}
-	if v.TaskList != nil {
-		err = multierr.Append(err, enc.AddObject("taskList", v.TaskList))
-	}
-	if v.QueryRequest != nil {
-		err = multierr.Append(err, enc.AddObject("queryRequest", v.QueryRequest))
+	if v.Request != nil {
+		err = multierr.Append(err, enc.AddObject("request", v.Request))
 	}
 	return err
 }
 
-// GetDomainUUID returns the value of DomainUUID if it is set or its
+// GetRequest returns the value of Request if it is set or its
 // zero value if it is unset.
-func (v *QueryWorkflowRequest) GetDomainUUID() (o string) {
-	if v != nil && v.DomainUUID != nil {
-		return *v.DomainUUID
+func (v *MatchingService_CancelOutstandingPoll_Args) GetRequest() (o *CancelOutstandingPollRequest) {
+	if v != nil && v.Request != nil {
+		return v.Request
 	}
 
 	return
 }
 
-// IsSetDomainUUID returns true if DomainUUID is not nil.
-func (v *QueryWorkflowRequest) IsSetDomainUUID() bool {
-	return v != nil && v.DomainUUID != nil
+// IsSetRequest returns true if Request is not nil.
+func (v *MatchingService_CancelOutstandingPoll_Args) IsSetRequest() bool {
+	return v != nil && v.Request != nil
 }
 
-// GetTaskList returns the value of TaskList if it is set or its
-// zero value if it is unset.
-func (v *QueryWorkflowRequest) GetTaskList() (o *shared.TaskList) {
-	if v != nil && v.TaskList != nil {
-		return v.TaskList
-	}
-
-	return
+// MethodName returns the name of the Thrift function as specified in
+// the IDL, for which this struct represent the arguments.
+//
+// This will always be "CancelOutstandingPoll" for this struct.
+func (v *MatchingService_CancelOutstandingPoll_Args) MethodName() string {
+	return "CancelOutstandingPoll"
 }
 
-// IsSetTaskList returns true if TaskList is not nil.
-func (v *QueryWorkflowRequest) IsSetTaskList() bool {
-	return v != nil && v.TaskList != nil
+// EnvelopeType returns the kind of value inside this struct.
+//
+// This will always be Call for this struct.
+func (v *MatchingService_CancelOutstandingPoll_Args) EnvelopeType() wire.EnvelopeType {
+	return wire.Call
 }
 
-// GetQueryRequest returns the value of QueryRequest if it is set or its
-// zero value if it is unset.
-func (v *QueryWorkflowRequest) GetQueryRequest() (o *shared.QueryWorkflowRequest) {
-	if v != nil && v.QueryRequest != nil {
-		return v.QueryRequest
+// MatchingService_CancelOutstandingPoll_Helper provides functions that aid in handling the
+// parameters and return values of the MatchingService.CancelOutstandingPoll
+// function.
+var MatchingService_CancelOutstandingPoll_Helper = struct {
+	// Args accepts the parameters of CancelOutstandingPoll in-order and returns
+	// the arguments struct for the function.
+	Args func(
+		request *CancelOutstandingPollRequest,
+	) *MatchingService_CancelOutstandingPoll_Args
+
+	// IsException returns true if the given error can be thrown
+	// by CancelOutstandingPoll.
+	//
+	// An error can be thrown by CancelOutstandingPoll only if the
+	// corresponding exception type was mentioned in the 'throws'
+	// section for it in the Thrift file.
+	IsException func(error) bool
+
+	// WrapResponse returns the result struct for CancelOutstandingPoll
+	// given the error returned by it. The provided error may
+	// be nil if CancelOutstandingPoll did not fail.
+	//
+	// This allows mapping errors returned by CancelOutstandingPoll into a
+	// serializable result struct. WrapResponse returns a
+	// non-nil error if the provided error cannot be thrown by
+	// CancelOutstandingPoll
+	//
+	//   err := CancelOutstandingPoll(args)
+	//   result, err := MatchingService_CancelOutstandingPoll_Helper.WrapResponse(err)
+	//   if err != nil {
+	//     return fmt.Errorf("unexpected error from CancelOutstandingPoll: %v", err)
+	//   }
+	//   serialize(result)
+	WrapResponse func(error) (*MatchingService_CancelOutstandingPoll_Result, error)
+
+	// UnwrapResponse takes the result struct for CancelOutstandingPoll
+	// and returns the erorr returned by it (if any).
+	//
+	// The error is non-nil only if CancelOutstandingPoll threw an
+	// exception.
+	//
+	//   result := deserialize(bytes)
+	//   err := MatchingService_CancelOutstandingPoll_Helper.UnwrapResponse(result)
+	UnwrapResponse func(*MatchingService_CancelOutstandingPoll_Result) error
+}{}
+
+func init() {
+	MatchingService_CancelOutstandingPoll_Helper.Args = func(
+		request *CancelOutstandingPollRequest,
+	) *MatchingService_CancelOutstandingPoll_Args {
+		return &MatchingService_CancelOutstandingPoll_Args{
+			Request: request,
+		}
 	}
 
-	return
-}
+	MatchingService_CancelOutstandingPoll_Helper.IsException = func(err error) bool {
+		switch err.(type) {
+		case *shared.BadRequestError:
+			return true
+		case *shared.InternalServiceError:
+			return true
+		case *shared.ServiceBusyError:
+			return true
+		default:
+			return false
+		}
+	}
+
+	MatchingService_CancelOutstandingPoll_Helper.WrapResponse = func(err error) (*MatchingService_CancelOutstandingPoll_Result, error) {
+		if err == nil {
+			return &MatchingService_CancelOutstandingPoll_Result{}, nil
+		}
+
+		switch e := err.(type) {
+		case *shared.BadRequestError:
+			if e == nil {
+				return nil, errors.New("WrapResponse received non-nil error type with nil value for MatchingService_CancelOutstandingPoll_Result.BadRequestError")
+			}
+			return &MatchingService_CancelOutstandingPoll_Result{BadRequestError: e}, nil
+		case *shared.InternalServiceError:
+			if e == nil {
+				return nil, errors.New("WrapResponse received non-nil error type with nil value for MatchingService_CancelOutstandingPoll_Result.InternalServiceError")
+			}
+			return &MatchingService_CancelOutstandingPoll_Result{InternalServiceError: e}, nil
+		case *shared.ServiceBusyError:
+			if e == nil {
+				return nil, errors.New("WrapResponse received non-nil error type with nil value for MatchingService_CancelOutstandingPoll_Result.ServiceBusyError")
+			}
+			return &MatchingService_CancelOutstandingPoll_Result{ServiceBusyError: e}, nil
+		}
+
+		return nil, err
+	}
+	MatchingService_CancelOutstandingPoll_Helper.UnwrapResponse = func(result *MatchingService_CancelOutstandingPoll_Result) (err error) {
+		if result.BadRequestError != nil {
+			err = result.BadRequestError
+			return
+		}
+		if result.InternalServiceError != nil {
+			err = result.InternalServiceError
+			return
+		}
+		if result.ServiceBusyError != nil {
+			err = result.ServiceBusyError
+			return
+		}
+		return
+	}
 
-// IsSetQueryRequest returns true if QueryRequest is not nil.
-func (v *QueryWorkflowRequest) IsSetQueryRequest() bool {
-	return v != nil && v.QueryRequest != nil
 }
 
-type RespondQueryTaskCompletedRequest struct {
-	DomainUUID       *string                                  `json:"domainUUID,omitempty"`
-	TaskList         *shared.TaskList                         `json:"taskList,omitempty"`
-	TaskID           *string                                  `json:"taskID,omitempty"`
-	CompletedRequest *shared.RespondQueryTaskCompletedRequest `json:"completedRequest,omitempty"`
+// MatchingService_CancelOutstandingPoll_Result represents the result of a MatchingService.CancelOutstandingPoll function call.
+//
+// The result of a CancelOutstandingPoll execution is sent and received over the wire as this struct.
+type MatchingService_CancelOutstandingPoll_Result struct {
+	BadRequestError      *shared.BadRequestError      `json:"badRequestError,omitempty"`
+	InternalServiceError *shared.InternalServiceError `json:"internalServiceError,omitempty"`
+	ServiceBusyError     *shared.ServiceBusyError     `json:"serviceBusyError,omitempty"`
 }
 
-// ToWire translates a RespondQueryTaskCompletedRequest struct into a Thrift-level intermediate
+// ToWire translates a MatchingService_CancelOutstandingPoll_Result struct into a Thrift-level intermediate
 // representation. This intermediate representation may be serialized
 // into bytes using a ThriftRW protocol implementation.
 //
@@ -2718,61 +5515,51 @@ type RespondQueryTaskCompletedRequest struct {
 //   if err := binaryProtocol.Encode(x, writer); err != nil {
 //     return err
 //   }
-func (v *RespondQueryTaskCompletedRequest) ToWire() (wire.Value, error) {
+func (v *MatchingService_CancelOutstandingPoll_Result) ToWire() (wire.Value, error) {
 	var (
-		fields [4]wire.Field
+		fields [3]wire.Field
 		i      int = 0
 		w      wire.Value
 		err    error
 	)
 
-	if v.DomainUUID != nil {
-		w, err = wire.NewValueString(*(v.DomainUUID)), error(nil)
+	if v.BadRequestError != nil {
+		w, err = v.BadRequestError.ToWire()
 		if err != nil {
 			return w, err
 		}
-		fields[i] = wire.Field{ID: 10, Value: w}
+		fields[i] = wire.Field{ID: 1, Value: w}
 		i++
 	}
-	if v.TaskList != nil {
-		w, err = v.TaskList.ToWire()
+	if v.InternalServiceError != nil {
+		w, err = v.InternalServiceError.ToWire()
 		if err != nil {
 			return w, err
 		}
-		fields[i] = wire.Field{ID: 20, Value: w}
+		fields[i] = wire.Field{ID: 2, Value: w}
 		i++
 	}
-	if v.TaskID != nil {
-		w, err = wire.NewValueString(*(v.TaskID)), error(nil)
+	if v.ServiceBusyError != nil {
+		w, err = v.ServiceBusyError.ToWire()
 		if err != nil {
 			return w, err
 		}
-		fields[i] = wire.Field{ID: 30, Value: w}
+		fields[i] = wire.Field{ID: 3, Value: w}
 		i++
 	}
-	if v.CompletedRequest != nil {
-		w, err = v.CompletedRequest.ToWire()
-		if err != nil {
-			return w, err
-		}
-		fields[i] = wire.Field{ID: 40, Value: w}
-		i++
+
+	if i > 1 {
+		return wire.Value{}, fmt.Errorf("MatchingService_CancelOutstandingPoll_Result should have at most one field: got %v fields", i)
 	}
 
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
 
-func _RespondQueryTaskCompletedRequest_Read(w wire.Value) (*shared.RespondQueryTaskCompletedRequest, error) {
-	var v shared.RespondQueryTaskCompletedRequest
-	err := v.FromWire(w)
-	return &v, err
-}
-
-// FromWire deserializes a RespondQueryTaskCompletedRequest struct from its Thrift-level
+// FromWire deserializes a MatchingService_CancelOutstandingPoll_Result struct from its Thrift-level
 // representation. The Thrift-level representation may be obtained
 // from a ThriftRW protocol implementation.
 //
-// An error is returned if we were unable to build a RespondQueryTaskCompletedRequest struct
+// An error is returned if we were unable to build a MatchingService_CancelOutstandingPoll_Result struct
 // from the provided intermediate representation.
 //
 //   x, err := binaryProtocol.Decode(reader, wire.TStruct)
@@ -2780,47 +5567,35 @@ func _RespondQueryTaskCompletedRequest_Read(w wire.Value) (*shared.RespondQueryT
 //     return nil, err
 //   }
 //
-//   var v RespondQueryTaskCompletedRequest
+//   var v MatchingService_CancelOutstandingPoll_Result
 //   if err := v.FromWire(x); err != nil {
 //     return nil, err
 //   }
 //   return &v, nil
-func (v *RespondQueryTaskCompletedRequest) FromWire(w wire.Value) error {
+func (v *MatchingService_CancelOutstandingPoll_Result) FromWire(w wire.Value) error {
 	var err error
 
 	for _, field := range w.GetStruct().Fields {
 		switch field.ID {
-		case 10:
-			if field.Value.Type() == wire.TBinary {
-				var x string
-				x, err = field.Value.GetString(), error(nil)
-				v.DomainUUID = &x
-				if err != nil {
-					return err
-				}
-
-			}
-		case 20:
+		case 1:
 			if field.Value.Type() == wire.TStruct {
-				v.TaskList, err = _TaskList_Read(field.Value)
+				v.BadRequestError, err = _BadRequestError_Read(field.Value)
 				if err != nil {
 					return err
 				}
 
 			}
-		case 30:
-			if field.Value.Type() == wire.TBinary {
-				var x string
-				x, err = field.Value.GetString(), error(nil)
-				v.TaskID = &x
+		case 2:
+			if field.Value.Type() == wire.TStruct {
+				v.InternalServiceError, err = _InternalServiceError_Read(field.Value)
 				if err != nil {
 					return err
 				}
 
 			}
-		case 40:
+		case 3:
 			if field.Value.Type() == wire.TStruct {
-				v.CompletedRequest, err = _RespondQueryTaskCompletedRequest_Read(field.Value)
+				v.ServiceBusyError, err = _ServiceBusyError_Read(field.Value)
 				if err != nil {
 					return err
 				}
@@ -2829,58 +5604,65 @@ func (v *RespondQueryTaskCompletedRequest) FromWire(w wire.Value) error {
 		}
 	}
 
+	count := 0
+	if v.BadRequestError != nil {
+		count++
+	}
+	if v.InternalServiceError != nil {
+		count++
+	}
+	if v.ServiceBusyError != nil {
+		count++
+	}
+	if count > 1 {
+		return fmt.Errorf("MatchingService_CancelOutstandingPoll_Result should have at most one field: got %v fields", count)
+	}
+
 	return nil
 }
 
-// String returns a readable string representation of a RespondQueryTaskCompletedRequest
+// String returns a readable string representation of a MatchingService_CancelOutstandingPoll_Result
 // struct.
-func (v *RespondQueryTaskCompletedRequest) String() string {
+func (v *MatchingService_CancelOutstandingPoll_Result) String() string {
 	if v == nil {
 		return "<nil>"
 	}
 
-	var fields [4]string
+	var fields [3]string
 	i := 0
-	if v.DomainUUID != nil {
-		fields[i] = fmt.Sprintf("DomainUUID: %v", *(v.DomainUUID))
-		i++
-	}
-	if v.TaskList != nil {
-		fields[i] = fmt.Sprintf("TaskList: %v", v.TaskList)
+	if v.BadRequestError != nil {
+		fields[i] = fmt.Sprintf("BadRequestError: %v", v.BadRequestError)
 		i++
 	}
-	if v.TaskID != nil {
-		fields[i] = fmt.Sprintf("TaskID: %v", *(v.TaskID))
+	if v.InternalServiceError != nil {
+		fields[i] = fmt.Sprintf("InternalServiceError: %v", v.InternalServiceError)
 		i++
 	}
-	if v.CompletedRequest != nil {
-		fields[i] = fmt.Sprintf("CompletedRequest: %v", v.CompletedRequest)
+	if v.ServiceBusyError != nil {
+		fields[i] = fmt.Sprintf("ServiceBusyError: %v", v.ServiceBusyError)
 		i++
 	}
 
-	return fmt.Sprintf("RespondQueryTaskCompletedRequest{%v}", strings.Join(fields[:i], ", "))
+	return fmt.Sprintf("MatchingService_CancelOutstandingPoll_Result{%v}", strings.Join(fields[:i], ", "))
 }
 
-// Equals returns true if all the fields of this RespondQueryTaskCompletedRequest match the
-// provided RespondQueryTaskCompletedRequest.
+// Equals returns true if all the fields of this MatchingService_CancelOutstandingPoll_Result match the
+// provided MatchingService_CancelOutstandingPoll_Result.
 //
 // This function performs a deep comparison.
-func (v *RespondQueryTaskCompletedRequest) Equals(rhs *RespondQueryTaskCompletedRequest) bool {
+func (v *MatchingService_CancelOutstandingPoll_Result) Equals(rhs *MatchingService_CancelOutstandingPoll_Result) bool {
 	if v == nil {
 		return rhs == nil
 	} else if rhs == nil {
 		return false
 	}
-	if !_String_EqualsPtr(v.DomainUUID, rhs.DomainUUID) {
-		return false
-	}
-	if !((v.TaskList == nil && rhs.TaskList == nil) || (v.TaskList != nil && rhs.TaskList != nil && v.TaskList.Equals(rhs.TaskList))) {
+	if !((v.BadRequestError == nil && rhs.BadRequestError == nil) || (v.BadRequestError != nil && rhs.BadRequestError != nil && v.BadRequestError.Equals(rhs.BadRequestError))) {
 		return false
 	}
-	if !_String_EqualsPtr(v.TaskID, rhs.TaskID) {
+	if !((v.InternalServiceError == nil && rhs.InternalServiceError == nil) || (v.InternalServiceError != nil && rhs.InternalServiceError != nil && v.InternalServiceError.Equals(rhs.InternalServiceError))) {
 		return false
 	}
-	if !((v.CompletedRequest == nil && rhs.CompletedRequest == nil) || (v.CompletedRequest != nil && rhs.CompletedRequest != nil && v.CompletedRequest.Equals(rhs.CompletedRequest))) {
+	if !((v.ServiceBusyError == nil && rhs.ServiceBusyError == nil) || (v.ServiceBusyError != nil && rhs.ServiceBusyError != nil && v.ServiceBusyError.Equals(rhs.ServiceBusyError))) {
 		return false
 	}
 
@@ -2888,108 +5670,91 @@ func (v *RespondQueryTaskCompletedRequest) Equals(rhs *RespondQueryTaskCompleted
 }
 
 // MarshalLogObject implements zapcore.ObjectMarshaler, enabling
-// fast logging of RespondQueryTaskCompletedRequest.
-func (v *RespondQueryTaskCompletedRequest) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+// fast logging of MatchingService_CancelOutstandingPoll_Result.
+func (v *MatchingService_CancelOutstandingPoll_Result) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
 	if v == nil {
 		return nil
 	}
-	if v.DomainUUID != nil {
-		enc.AddString("domainUUID", *v.DomainUUID)
-	}
-	if v.TaskList != nil {
-		err = multierr.Append(err, enc.AddObject("taskList", v.TaskList))
+	if v.BadRequestError != nil {
+		err = multierr.Append(err, enc.AddObject("badRequestError", v.BadRequestError))
 	}
-	if v.TaskID != nil {
-		enc.AddString("taskID", *v.TaskID)
+	if v.InternalServiceError != nil {
+		err = multierr.Append(err, enc.AddObject("internalServiceError", v.InternalServiceError))
 	}
-	if v.CompletedRequest != nil {
-		err = multierr.Append(err, enc.AddObject("completedRequest", v.CompletedRequest))
+	if v.ServiceBusyError != nil {
+		err = multierr.Append(err, enc.AddObject("serviceBusyError", v.ServiceBusyError))
 	}
 	return err
 }
 
-// GetDomainUUID returns the value of DomainUUID if it is set or its
+// GetBadRequestError returns the value of BadRequestError if it is set or its
 // zero value if it is unset.
-func (v *RespondQueryTaskCompletedRequest) GetDomainUUID() (o string) {
-	if v != nil && v.DomainUUID != nil {
-		return *v.DomainUUID
+func (v *MatchingService_CancelOutstandingPoll_Result) GetBadRequestError() (o *shared.BadRequestError) {
+	if v != nil && v.BadRequestError != nil {
+		return v.BadRequestError
 	}
 
 	return
 }
 
-// IsSetDomainUUID returns true if DomainUUID is not nil.
-func (v *RespondQueryTaskCompletedRequest) IsSetDomainUUID() bool {
-	return v != nil && v.DomainUUID != nil
+// IsSetBadRequestError returns true if BadRequestError is not nil.
+func (v *MatchingService_CancelOutstandingPoll_Result) IsSetBadRequestError() bool {
+	return v != nil && v.BadRequestError != nil
 }
 
-// GetTaskList returns the value of TaskList if it is set or its
+// GetInternalServiceError returns the value of InternalServiceError if it is set or its
 // zero value if it is unset.
-func (v *RespondQueryTaskCompletedRequest) GetTaskList() (o *shared.TaskList) {
-	if v != nil && v.TaskList != nil {
-		return v.TaskList
+func (v *MatchingService_CancelOutstandingPoll_Result) GetInternalServiceError() (o *shared.InternalServiceError) {
+	if v != nil && v.InternalServiceError != nil {
+		return v.InternalServiceError
 	}
 
 	return
 }
 
-// IsSetTaskList returns true if TaskList is not nil.
-func (v *RespondQueryTaskCompletedRequest) IsSetTaskList() bool {
-	return v != nil && v.TaskList != nil
+// IsSetInternalServiceError returns true if InternalServiceError is not nil.
+func (v *MatchingService_CancelOutstandingPoll_Result) IsSetInternalServiceError() bool {
+	return v != nil && v.InternalServiceError != nil
 }
 
-// GetTaskID returns the value of TaskID if it is set or its
+// GetServiceBusyError returns the value of ServiceBusyError if it is set or its
 // zero value if it is unset.
-func (v *RespondQueryTaskCompletedRequest) GetTaskID() (o string) {
-	if v != nil && v.TaskID != nil {
-		return *v.TaskID
+func (v *MatchingService_CancelOutstandingPoll_Result) GetServiceBusyError() (o *shared.ServiceBusyError) {
+	if v != nil && v.ServiceBusyError != nil {
+		return v.ServiceBusyError
 	}
 
 	return
 }
 
-// IsSetTaskID returns true if TaskID is not nil.
-func (v *RespondQueryTaskCompletedRequest) IsSetTaskID() bool {
-	return v != nil && v.TaskID != nil
-}
-
-// GetCompletedRequest returns the value of CompletedRequest if it is set or its
-// zero value if it is unset.
-func (v *RespondQueryTaskCompletedRequest) GetCompletedRequest() (o *shared.RespondQueryTaskCompletedRequest) {
-	if v != nil && v.CompletedRequest != nil {
-		return v.CompletedRequest
-	}
-
-	return
+// IsSetServiceBusyError returns true if ServiceBusyError is not nil.
+func (v *MatchingService_CancelOutstandingPoll_Result) IsSetServiceBusyError() bool {
+	return v != nil && v.ServiceBusyError != nil
 }
 
-// IsSetCompletedRequest returns true if CompletedRequest is not nil.
-func (v *RespondQueryTaskCompletedRequest) IsSetCompletedRequest() bool {
-	return v != nil && v.CompletedRequest != nil
+// MethodName returns the name of the Thrift function as specified in
+// the IDL, for which this struct represent the result.
+//
+// This will always be "CancelOutstandingPoll" for this struct.
+func (v *MatchingService_CancelOutstandingPoll_Result) MethodName() string {
+	return "CancelOutstandingPoll"
 }
 
-// ThriftModule represents the IDL file used to generate this package.
-var ThriftModule = &thriftreflect.ThriftModule{
-	Name:     "matching",
-	Package:  "github.com/uber/cadence/.gen/go/matching",
-	FilePath: "matching.thrift",
-	SHA1:     "410017888044f54d7611677d65085b9b43499857",
-	Includes: []*thriftreflect.ThriftModule{
-		shared.ThriftModule,
-	},
-	Raw: rawIDL,
+// EnvelopeType returns the kind of value inside this struct.
+//
+// This will always be Reply for this struct.
+func (v *MatchingService_CancelOutstandingPoll_Result) EnvelopeType() wire.EnvelopeType {
+	return wire.Reply
 }
 
-const rawIDL = "// Copyright (c) 2017 Uber Technologies, Inc.\n//\n// Permission is hereby granted, free of charge, to any person obtaining a copy\n// of this software and associated documentation files (the \"Software\"), to deal\n// in the Software without restriction, including without limitation the rights\n// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell\n// copies of the Software, and to permit persons to whom the Software is\n// furnished to do so, subject to the following conditions:\n//\n// The above copyright notice and this permission notice shall be included in\n// all copies or substantial portions of the Software.\n//\n// THE SOFTWARE IS PROVIDED \"AS IS\", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR\n// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,\n// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE\n// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER\n// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,\n// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN\n// THE SOFTWARE.\n\ninclude \"shared.thrift\"\n\nnamespace java com.uber.cadence.matching\n\nstruct PollForDecisionTaskRequest {\n  10: optional string domainUUID\n  15: optional string pollerID\n  20: optional shared.PollForDecisionTaskRequest pollRequest\n}\n\nstruct PollForDecisionTaskResponse {\n  10: optional binary taskToken\n  20: optional shared.WorkflowExecution workflowExecution\n  30: optional shared.WorkflowType workflowType\n  40: optional i64 (js.type = \"Long\") previousStartedEventId\n  50: optional i64 (js.type = \"Long\") startedEventId\n  51: optional i64 (js.type = \"Long\") attempt\n  60: optional i64 (js.type = \"Long\") nextEventId\n  65: optional i64 (js.type = \"Long\") backlogCountHint\n  70: optional bool stickyExecutionEnabled\n  80: optional shared.WorkflowQuery query\n  90: optional shared.TransientDecisionInfo decisionInfo\n  100: optional shared.TaskList WorkflowExecutionTaskList\n  110: optional i32 eventStoreVersion\n  120: optional binary branchToken\n  130:  optional i64 (js.type = \"Long\") scheduledTimestamp\n  140:  optional i64 (js.type = \"Long\") startedTimestamp\n}\n\nstruct PollForActivityTaskRequest {\n  10: optional string domainUUID\n  15: optional string pollerID\n  20: optional shared.PollForActivityTaskRequest pollRequest\n}\n\nstruct AddDecisionTaskRequest {\n  10: optional string domainUUID\n  20: optional shared.WorkflowExecution execution\n  30: optional shared.TaskList taskList\n  40: optional i64 (js.type = \"Long\") scheduleId\n  50: optional i32 scheduleToStartTimeoutSeconds\n}\n\nstruct AddActivityTaskRequest {\n  10: optional string domainUUID\n  20: optional shared.WorkflowExecution execution\n  30: optional string sourceDomainUUID\n  40: optional shared.TaskList taskList\n  50: optional i64 (js.type = \"Long\") scheduleId\n  60: optional i32 scheduleToStartTimeoutSeconds\n}\n\nstruct QueryWorkflowRequest {\n  10: optional string domainUUID\n  20: optional shared.TaskList taskList\n  30: optional shared.QueryWorkflowRequest queryRequest\n}\n\nstruct RespondQueryTaskCompletedRequest {\n  10: optional string domainUUID\n  20: optional shared.TaskList taskList\n  30: optional string taskID\n  40: optional shared.RespondQueryTaskCompletedRequest completedRequest\n}\n\nstruct CancelOutstandingPollRequest {\n  10: optional string domainUUID\n  20: optional i32 taskListType\n  30: optional shared.TaskList taskList\n  40: optional string pollerID\n}\n\nstruct DescribeTaskListRequest {\n  10: optional string domainUUID\n  20: optional shared.DescribeTaskListRequest descRequest\n}\n\n/**\n* MatchingService API is exposed to provide support for polling from long running applications.\n* Such applications are expected to have a worker which regularly polls for DecisionTask and ActivityTask.  For each\n* DecisionTask, application is expected to process the history of events for that session and respond back with next\n* decisions.  For each ActivityTask, application is expected to execute the actual logic for that task and respond back\n* with completion or failure.\n**/\nservice MatchingService {\n  /**\n  * PollForDecisionTask is called by frontend to process DecisionTask from a specific taskList.  A\n  * DecisionTask is dispatched to callers for active workflow executions, with pending decisions.\n  **/\n  PollForDecisionTaskResponse PollForDecisionTask(1: PollForDecisionTaskRequest pollRequest)\n    throws (\n      1: shared.BadRequestError badRequestError,\n      2: shared.InternalServiceError internalServiceError,\n      3: shared.LimitExceededError limitExceededError,\n      4: shared.ServiceBusyError serviceBusyError,\n    )\n\n  /**\n  * PollForActivityTask is called by frontend to process ActivityTask from a specific taskList.  ActivityTask\n  * is dispatched to callers whenever a ScheduleTask decision is made for a workflow execution.\n  **/\n  shared.PollForActivityTaskResponse PollForActivityTask(1: PollForActivityTaskRequest pollRequest)\n    throws (\n      1: shared.BadRequestError badRequestError,\n      2: shared.InternalServiceError internalServiceError,\n      3: shared.LimitExceededError limitExceededError,\n      4: shared.ServiceBusyError serviceBusyError,\n    )\n\n  /**\n  * AddDecisionTask is called by the history service when a decision task is scheduled, so that it can be dispatched\n  * by the MatchingEngine.\n  **/\n  void AddDecisionTask(1: AddDecisionTaskRequest addRequest)\n    throws (\n      1: shared.BadRequestError badRequestError,\n      2: shared.InternalServiceError internalServiceError,\n      3: shared.ServiceBusyError serviceBusyError,\n      4: shared.LimitExceededError limitExceededError,\n      5: shared.DomainNotActiveError domainNotActiveError,\n    )\n\n  /**\n  * AddActivityTask is called by the history service when a decision task is scheduled, so that it can be dispatched\n  * by the MatchingEngine.\n  **/\n  void AddActivityTask(1: AddActivityTaskRequest addRequest)\n    throws (\n      1: shared.BadRequestError badRequestError,\n      2: shared.InternalServiceError internalServiceError,\n      3: shared.ServiceBusyError serviceBusyError,\n      4: shared.LimitExceededError limitExceededError,\n      5: shared.DomainNotActiveError domainNotActiveError,\n    )\n\n  /**\n  * QueryWorkflow is called by frontend to query a workflow.\n  **/\n  shared.QueryWorkflowResponse QueryWorkflow(1: QueryWorkflowRequest queryRequest)\n    throws (\n      1: shared.BadRequestError badRequestError,\n      2: shared.InternalServiceError internalServiceError,\n      3: shared.EntityNotExistsError entityNotExistError,\n      4: shared.QueryFailedError queryFailedError,\n      5: shared.LimitExceededError limitExceededError,\n      6: shared.ServiceBusyError serviceBusyError,\n    )\n\n  /**\n  * RespondQueryTaskCompleted is called by frontend to respond query completed.\n  **/\n  void RespondQueryTaskCompleted(1: RespondQueryTaskCompletedRequest request)\n    throws (\n      1: shared.BadRequestError badRequestError,\n      2: shared.InternalServiceError internalServiceError,\n      3: shared.EntityNotExistsError entityNotExistError,\n      4: shared.LimitExceededError limitExceededError,\n      5: shared.ServiceBusyError serviceBusyError,\n    )\n\n  /**\n    * CancelOutstandingPoll is called by frontend to unblock long polls on matching for zombie pollers.\n    * Our rpc stack does not support context propagation, so when a client connection goes away frontend sees\n    * cancellation of context for that handler, but any corresponding calls (long-poll) to matching service does not\n    * see the cancellation propagated so it can unblock corresponding long-polls on its end.  This results is tasks\n    * being dispatched to zombie pollers in this situation.  This API is added so everytime frontend makes a long-poll\n    * api call to matching it passes in a pollerID and then calls this API when it detects client connection is closed\n    * to unblock long polls for this poller and prevent tasks being sent to these zombie pollers.\n    **/\n  void CancelOutstandingPoll(1: CancelOutstandingPollRequest request)\n    throws (\n      1: shared.BadRequestError badRequestError,\n      2: shared.InternalServiceError internalServiceError,\n      3: shared.ServiceBusyError serviceBusyError,\n    )\n\n  /**\n  * DescribeTaskList returns information about the target tasklist, right now this API returns the\n  * pollers which polled this tasklist in last few minutes.\n  **/\n  shared.DescribeTaskListResponse DescribeTaskList(1: DescribeTaskListRequest request)\n    throws (\n        1: shared.BadRequestError badRequestError,\n        2: shared.InternalServiceError internalServiceError,\n        3: shared.EntityNotExistsError entityNotExistError,\n        4: shared.ServiceBusyError serviceBusyError,\n      )\n}\n"
-
-// MatchingService_AddActivityTask_Args represents the arguments for the MatchingService.AddActivityTask function.
+// MatchingService_DescribeTaskJourney_Args represents the arguments for the MatchingService.DescribeTaskJourney function.
 //
-// The arguments for AddActivityTask are sent and received over the wire as this struct.
-type MatchingService_AddActivityTask_Args struct {
-	AddRequest *AddActivityTaskRequest `json:"addRequest,omitempty"`
+// The arguments for DescribeTaskJourney are sent and received over the wire as this struct.
+type MatchingService_DescribeTaskJourney_Args struct {
+	Request *DescribeTaskJourneyRequest `json:"request,omitempty"`
 }
 
-// ToWire translates a MatchingService_AddActivityTask_Args struct into a Thrift-level intermediate
+// ToWire translates a MatchingService_DescribeTaskJourney_Args struct into a Thrift-level intermediate
 // representation. This intermediate representation may be serialized
 // into bytes using a ThriftRW protocol implementation.
 //
@@ -3004,7 +5769,7 @@ type MatchingService_AddActivityTask_Args struct {
 //   if err := binaryProtocol.Encode(x, writer); err != nil {
 //     return err
 //   }
-func (v *MatchingService_AddActivityTask_Args) ToWire() (wire.Value, error) {
+func (v *MatchingService_DescribeTaskJourney_Args) ToWire() (wire.Value, error) {
 	var (
 		fields [1]wire.Field
 		i      int = 0
@@ -3012,8 +5777,8 @@ func (v *MatchingService_AddActivityTask_Args) ToWire() (wire.Value, error) {
 		err    error
 	)
 
-	if v.AddRequest != nil {
-		w, err = v.AddRequest.ToWire()
+	if v.Request != nil {
+		w, err = v.Request.ToWire()
 		if err != nil {
 			return w, err
 		}
@@ -3024,17 +5789,17 @@ func (v *MatchingService_AddActivityTask_Args) ToWire() (wire.Value, error) {
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
 
-func _AddActivityTaskRequest_Read(w wire.Value) (*AddActivityTaskRequest, error) {
-	var v AddActivityTaskRequest
+func _DescribeTaskJourneyRequest_1_Read(w wire.Value) (*DescribeTaskJourneyRequest, error) {
+	var v DescribeTaskJourneyRequest
 	err := v.FromWire(w)
 	return &v, err
 }
 
-// FromWire deserializes a MatchingService_AddActivityTask_Args struct from its Thrift-level
+// FromWire deserializes a MatchingService_DescribeTaskJourney_Args struct from its Thrift-level
 // representation. The Thrift-level representation may be obtained
 // from a ThriftRW protocol implementation.
 //
-// An error is returned if we were unable to build a MatchingService_AddActivityTask_Args struct
+// An error is returned if we were unable to build a MatchingService_DescribeTaskJourney_Args struct
 // from the provided intermediate representation.
 //
 //   x, err := binaryProtocol.Decode(reader, wire.TStruct)
@@ -3042,19 +5807,19 @@ func _AddActivityTaskRequest_Read(w wire.Value) (*AddActivityTaskRequest, error)
 //     return nil, err
 //   }
 //
-//   var v MatchingService_AddActivityTask_Args
+//   var v MatchingService_DescribeTaskJourney_Args
 //   if err := v.FromWire(x); err != nil {
 //     return nil, err
 //   }
 //   return &v, nil
-func (v *MatchingService_AddActivityTask_Args) FromWire(w wire.Value) error {
+func (v *MatchingService_DescribeTaskJourney_Args) FromWire(w wire.Value) error {
 	var err error
 
 	for _, field := range w.GetStruct().Fields {
 		switch field.ID {
 		case 1:
 			if field.Value.Type() == wire.TStruct {
-				v.AddRequest, err = _AddActivityTaskRequest_Read(field.Value)
+				v.Request, err = _DescribeTaskJourneyRequest_1_Read(field.Value)
 				if err != nil {
 					return err
 				}
@@ -3066,34 +5831,34 @@ func (v *MatchingService_AddActivityTask_Args) FromWire(w wire.Value) error {
 	return nil
 }
 
-// String returns a readable string representation of a MatchingService_AddActivityTask_Args
+// String returns a readable string representation of a MatchingService_DescribeTaskJourney_Args
 // struct.
-func (v *MatchingService_AddActivityTask_Args) String() string {
+func (v *MatchingService_DescribeTaskJourney_Args) String() string {
 	if v == nil {
 		return "<nil>"
 	}
 
 	var fields [1]string
 	i := 0
-	if v.AddRequest != nil {
-		fields[i] = fmt.Sprintf("AddRequest: %v", v.AddRequest)
+	if v.Request != nil {
+		fields[i] = fmt.Sprintf("Request: %v", v.Request)
 		i++
 	}
 
-	return fmt.Sprintf("MatchingService_AddActivityTask_Args{%v}", strings.Join(fields[:i], ", "))
+	return fmt.Sprintf("MatchingService_DescribeTaskJourney_Args{%v}", strings.Join(fields[:i], ", "))
 }
 
-// Equals returns true if all the fields of this MatchingService_AddActivityTask_Args match the
-// provided MatchingService_AddActivityTask_Args.
+// Equals returns true if all the fields of this MatchingService_DescribeTaskJourney_Args match the
+// provided MatchingService_DescribeTaskJourney_Args.
 //
 // This function performs a deep comparison.
-func (v *MatchingService_AddActivityTask_Args) Equals(rhs *MatchingService_AddActivityTask_Args) bool {
+func (v *MatchingService_DescribeTaskJourney_Args) Equals(rhs *MatchingService_DescribeTaskJourney_Args) bool {
 	if v == nil {
 		return rhs == nil
 	} else if rhs == nil {
 		return false
 	}
-	if !((v.AddRequest == nil && rhs.AddRequest == nil) || (v.AddRequest != nil && rhs.AddRequest != nil && v.AddRequest.Equals(rhs.AddRequest))) {
+	if !((v.Request == nil && rhs.Request == nil) || (v.Request != nil && rhs.Request != nil && v.Request.Equals(rhs.Request))) {
 		return false
 	}
 
@@ -3101,155 +5866,147 @@ func (v *MatchingService_AddActivityTask_Args) Equals(rhs *MatchingService_AddAc
 }
 
 // MarshalLogObject implements zapcore.ObjectMarshaler, enabling
-// fast logging of MatchingService_AddActivityTask_Args.
-func (v *MatchingService_AddActivityTask_Args) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+// fast logging of MatchingService_DescribeTaskJourney_Args.
+func (v *MatchingService_DescribeTaskJourney_Args) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
 	if v == nil {
 		return nil
 	}
-	if v.AddRequest != nil {
-		err = multierr.Append(err, enc.AddObject("addRequest", v.AddRequest))
+	if v.Request != nil {
+		err = multierr.Append(err, enc.AddObject("request", v.Request))
 	}
 	return err
 }
 
-// GetAddRequest returns the value of AddRequest if it is set or its
+// GetRequest returns the value of Request if it is set or its
 // zero value if it is unset.
-func (v *MatchingService_AddActivityTask_Args) GetAddRequest() (o *AddActivityTaskRequest) {
-	if v != nil && v.AddRequest != nil {
-		return v.AddRequest
+func (v *MatchingService_DescribeTaskJourney_Args) GetRequest() (o *DescribeTaskJourneyRequest) {
+	if v != nil && v.Request != nil {
+		return v.Request
 	}
 
 	return
 }
 
-// IsSetAddRequest returns true if AddRequest is not nil.
-func (v *MatchingService_AddActivityTask_Args) IsSetAddRequest() bool {
-	return v != nil && v.AddRequest != nil
+// IsSetRequest returns true if Request is not nil.
+func (v *MatchingService_DescribeTaskJourney_Args) IsSetRequest() bool {
+	return v != nil && v.Request != nil
 }
 
 // MethodName returns the name of the Thrift function as specified in
 // the IDL, for which this struct represent the arguments.
 //
-// This will always be "AddActivityTask" for this struct.
-func (v *MatchingService_AddActivityTask_Args) MethodName() string {
-	return "AddActivityTask"
+// This will always be "DescribeTaskJourney" for this struct.
+func (v *MatchingService_DescribeTaskJourney_Args) MethodName() string {
+	return "DescribeTaskJourney"
 }
 
 // EnvelopeType returns the kind of value inside this struct.
 //
 // This will always be Call for this struct.
-func (v *MatchingService_AddActivityTask_Args) EnvelopeType() wire.EnvelopeType {
+func (v *MatchingService_DescribeTaskJourney_Args) EnvelopeType() wire.EnvelopeType {
 	return wire.Call
 }
 
-// MatchingService_AddActivityTask_Helper provides functions that aid in handling the
-// parameters and return values of the MatchingService.AddActivityTask
+// MatchingService_DescribeTaskJourney_Helper provides functions that aid in handling the
+// parameters and return values of the MatchingService.DescribeTaskJourney
 // function.
-var MatchingService_AddActivityTask_Helper = struct {
-	// Args accepts the parameters of AddActivityTask in-order and returns
+var MatchingService_DescribeTaskJourney_Helper = struct {
+	// Args accepts the parameters of DescribeTaskJourney in-order and returns
 	// the arguments struct for the function.
 	Args func(
-		addRequest *AddActivityTaskRequest,
-	) *MatchingService_AddActivityTask_Args
+		request *DescribeTaskJourneyRequest,
+	) *MatchingService_DescribeTaskJourney_Args
 
 	// IsException returns true if the given error can be thrown
-	// by AddActivityTask.
+	// by DescribeTaskJourney.
 	//
-	// An error can be thrown by AddActivityTask only if the
+	// An error can be thrown by DescribeTaskJourney only if the
 	// corresponding exception type was mentioned in the 'throws'
 	// section for it in the Thrift file.
 	IsException func(error) bool
 
-	// WrapResponse returns the result struct for AddActivityTask
-	// given the error returned by it. The provided error may
-	// be nil if AddActivityTask did not fail.
+	// WrapResponse returns the result struct for DescribeTaskJourney
+	// given its return value and error.
 	//
-	// This allows mapping errors returned by AddActivityTask into a
-	// serializable result struct. WrapResponse returns a
-	// non-nil error if the provided error cannot be thrown by
-	// AddActivityTask
+	// This allows mapping values and errors returned by
+	// DescribeTaskJourney into a serializable result struct.
+	// WrapResponse returns a non-nil error if the provided
+	// error cannot be thrown by DescribeTaskJourney
 	//
-	//   err := AddActivityTask(args)
-	//   result, err := MatchingService_AddActivityTask_Helper.WrapResponse(err)
+	//   value, err := DescribeTaskJourney(args)
+	//   result, err := MatchingService_DescribeTaskJourney_Helper.WrapResponse(value, err)
 	//   if err != nil {
-	//     return fmt.Errorf("unexpected error from AddActivityTask: %v", err)
+	//     return fmt.Errorf("unexpected error from DescribeTaskJourney: %v", err)
 	//   }
 	//   serialize(result)
-	WrapResponse func(error) (*MatchingService_AddActivityTask_Result, error)
+	WrapResponse func(*shared.DescribeTaskJourneyResponse, error) (*MatchingService_DescribeTaskJourney_Result, error)
 
-	// UnwrapResponse takes the result struct for AddActivityTask
-	// and returns the erorr returned by it (if any).
+	// UnwrapResponse takes the result struct for DescribeTaskJourney
+	// and returns the value or error returned by it.
 	//
-	// The error is non-nil only if AddActivityTask threw an
+	// The error is non-nil only if DescribeTaskJourney threw an
 	// exception.
 	//
 	//   result := deserialize(bytes)
-	//   err := MatchingService_AddActivityTask_Helper.UnwrapResponse(result)
-	UnwrapResponse func(*MatchingService_AddActivityTask_Result) error
+	//   value, err := MatchingService_DescribeTaskJourney_Helper.UnwrapResponse(result)
+	UnwrapResponse func(*MatchingService_DescribeTaskJourney_Result) (*shared.DescribeTaskJourneyResponse, error)
 }{}
 
 func init() {
-	MatchingService_AddActivityTask_Helper.Args = func(
-		addRequest *AddActivityTaskRequest,
-	) *MatchingService_AddActivityTask_Args {
-		return &MatchingService_AddActivityTask_Args{
-			AddRequest: addRequest,
+	MatchingService_DescribeTaskJourney_Helper.Args = func(
+		request *DescribeTaskJourneyRequest,
+	) *MatchingService_DescribeTaskJourney_Args {
+		return &MatchingService_DescribeTaskJourney_Args{
+			Request: request,
 		}
 	}
 
-	MatchingService_AddActivityTask_Helper.IsException = func(err error) bool {
+	MatchingService_DescribeTaskJourney_Helper.IsException = func(err error) bool {
 		switch err.(type) {
 		case *shared.BadRequestError:
 			return true
 		case *shared.InternalServiceError:
 			return true
-		case *shared.ServiceBusyError:
-			return true
-		case *shared.LimitExceededError:
+		case *shared.EntityNotExistsError:
 			return true
-		case *shared.DomainNotActiveError:
+		case *shared.ServiceBusyError:
 			return true
 		default:
 			return false
 		}
 	}
 
-	MatchingService_AddActivityTask_Helper.WrapResponse = func(err error) (*MatchingService_AddActivityTask_Result, error) {
+	MatchingService_DescribeTaskJourney_Helper.WrapResponse = func(success *shared.DescribeTaskJourneyResponse, err error) (*MatchingService_DescribeTaskJourney_Result, error) {
 		if err == nil {
-			return &MatchingService_AddActivityTask_Result{}, nil
+			return &MatchingService_DescribeTaskJourney_Result{Success: success}, nil
 		}
 
 		switch e := err.(type) {
 		case *shared.BadRequestError:
 			if e == nil {
-				return nil, errors.New("WrapResponse received non-nil error type with nil value for MatchingService_AddActivityTask_Result.BadRequestError")
+				return nil, errors.New("WrapResponse received non-nil error type with nil value for MatchingService_DescribeTaskJourney_Result.BadRequestError")
 			}
-			return &MatchingService_AddActivityTask_Result{BadRequestError: e}, nil
+			return &MatchingService_DescribeTaskJourney_Result{BadRequestError: e}, nil
 		case *shared.InternalServiceError:
 			if e == nil {
-				return nil, errors.New("WrapResponse received non-nil error type with nil value for MatchingService_AddActivityTask_Result.InternalServiceError")
-			}
-			return &MatchingService_AddActivityTask_Result{InternalServiceError: e}, nil
-		case *shared.ServiceBusyError:
-			if e == nil {
-				return nil, errors.New("WrapResponse received non-nil error type with nil value for MatchingService_AddActivityTask_Result.ServiceBusyError")
+				return nil, errors.New("WrapResponse received non-nil error type with nil value for MatchingService_DescribeTaskJourney_Result.InternalServiceError")
 			}
-			return &MatchingService_AddActivityTask_Result{ServiceBusyError: e}, nil
-		case *shared.LimitExceededError:
+			return &MatchingService_DescribeTaskJourney_Result{InternalServiceError: e}, nil
+		case *shared.EntityNotExistsError:
 			if e == nil {
-				return nil, errors.New("WrapResponse received non-nil error type with nil value for MatchingService_AddActivityTask_Result.LimitExceededError")
+				return nil, errors.New("WrapResponse received non-nil error type with nil value for MatchingService_DescribeTaskJourney_Result.EntityNotExistError")
 			}
-			return &MatchingService_AddActivityTask_Result{LimitExceededError: e}, nil
-		case *shared.DomainNotActiveError:
+			return &MatchingService_DescribeTaskJourney_Result{EntityNotExistError: e}, nil
+		case *shared.ServiceBusyError:
 			if e == nil {
-				return nil, errors.New("WrapResponse received non-nil error type with nil value for MatchingService_AddActivityTask_Result.DomainNotActiveError")
+				return nil, errors.New("WrapResponse received non-nil error type with nil value for MatchingService_DescribeTaskJourney_Result.ServiceBusyError")
 			}
-			return &MatchingService_AddActivityTask_Result{DomainNotActiveError: e}, nil
+			return &MatchingService_DescribeTaskJourney_Result{ServiceBusyError: e}, nil
 		}
 
 		return nil, err
 	}
-	MatchingService_AddActivityTask_Helper.UnwrapResponse = func(result *MatchingService_AddActivityTask_Result) (err error) {
+	MatchingService_DescribeTaskJourney_Helper.UnwrapResponse = func(result *MatchingService_DescribeTaskJourney_Result) (success *shared.DescribeTaskJourneyResponse, err error) {
 		if result.BadRequestError != nil {
 			err = result.BadRequestError
 			return
@@ -3258,35 +6015,41 @@ func init() {
 			err = result.InternalServiceError
 			return
 		}
-		if result.ServiceBusyError != nil {
-			err = result.ServiceBusyError
+		if result.EntityNotExistError != nil {
+			err = result.EntityNotExistError
 			return
 		}
-		if result.LimitExceededError != nil {
-			err = result.LimitExceededError
+		if result.ServiceBusyError != nil {
+			err = result.ServiceBusyError
 			return
 		}
-		if result.DomainNotActiveError != nil {
-			err = result.DomainNotActiveError
+
+		if result.Success != nil {
+			success = result.Success
 			return
 		}
+
+		err = errors.New("expected a non-void result")
 		return
 	}
 
 }
 
-// MatchingService_AddActivityTask_Result represents the result of a MatchingService.AddActivityTask function call.
+// MatchingService_DescribeTaskJourney_Result represents the result of a MatchingService.DescribeTaskJourney function call.
 //
-// The result of a AddActivityTask execution is sent and received over the wire as this struct.
-type MatchingService_AddActivityTask_Result struct {
-	BadRequestError      *shared.BadRequestError      `json:"badRequestError,omitempty"`
-	InternalServiceError *shared.InternalServiceError `json:"internalServiceError,omitempty"`
-	ServiceBusyError     *shared.ServiceBusyError     `json:"serviceBusyError,omitempty"`
-	LimitExceededError   *shared.LimitExceededError   `json:"limitExceededError,omitempty"`
-	DomainNotActiveError *shared.DomainNotActiveError `json:"domainNotActiveError,omitempty"`
+// The result of a DescribeTaskJourney execution is sent and received over the wire as this struct.
+//
+// Success is set only if the function did not throw an exception.
+type MatchingService_DescribeTaskJourney_Result struct {
+	// Value returned by DescribeTaskJourney after a successful execution.
+	Success              *shared.DescribeTaskJourneyResponse `json:"success,omitempty"`
+	BadRequestError      *shared.BadRequestError          `json:"badRequestError,omitempty"`
+	InternalServiceError *shared.InternalServiceError     `json:"internalServiceError,omitempty"`
+	EntityNotExistError  *shared.EntityNotExistsError     `json:"entityNotExistError,omitempty"`
+	ServiceBusyError     *shared.ServiceBusyError         `json:"serviceBusyError,omitempty"`
 }
 
-// ToWire translates a MatchingService_AddActivityTask_Result struct into a Thrift-level intermediate
+// ToWire translates a MatchingService_DescribeTaskJourney_Result struct into a Thrift-level intermediate
 // representation. This intermediate representation may be serialized
 // into bytes using a ThriftRW protocol implementation.
 //
@@ -3301,7 +6064,7 @@ type MatchingService_AddActivityTask_Result struct {
 //   if err := binaryProtocol.Encode(x, writer); err != nil {
 //     return err
 //   }
-func (v *MatchingService_AddActivityTask_Result) ToWire() (wire.Value, error) {
+func (v *MatchingService_DescribeTaskJourney_Result) ToWire() (wire.Value, error) {
 	var (
 		fields [5]wire.Field
 		i      int = 0
@@ -3309,6 +6072,14 @@ func (v *MatchingService_AddActivityTask_Result) ToWire() (wire.Value, error) {
 		err    error
 	)
 
+	if v.Success != nil {
+		w, err = v.Success.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 0, Value: w}
+		i++
+	}
 	if v.BadRequestError != nil {
 		w, err = v.BadRequestError.ToWire()
 		if err != nil {
@@ -3325,73 +6096,47 @@ func (v *MatchingService_AddActivityTask_Result) ToWire() (wire.Value, error) {
 		fields[i] = wire.Field{ID: 2, Value: w}
 		i++
 	}
-	if v.ServiceBusyError != nil {
-		w, err = v.ServiceBusyError.ToWire()
+	if v.EntityNotExistError != nil {
+		w, err = v.EntityNotExistError.ToWire()
 		if err != nil {
 			return w, err
 		}
 		fields[i] = wire.Field{ID: 3, Value: w}
 		i++
 	}
-	if v.LimitExceededError != nil {
-		w, err = v.LimitExceededError.ToWire()
+	if v.ServiceBusyError != nil {
+		w, err = v.ServiceBusyError.ToWire()
 		if err != nil {
 			return w, err
 		}
 		fields[i] = wire.Field{ID: 4, Value: w}
 		i++
 	}
-	if v.DomainNotActiveError != nil {
-		w, err = v.DomainNotActiveError.ToWire()
-		if err != nil {
-			return w, err
-		}
-		fields[i] = wire.Field{ID: 5, Value: w}
-		i++
-	}
 
-	if i > 1 {
-		return wire.Value{}, fmt.Errorf("MatchingService_AddActivityTask_Result should have at most one field: got %v fields", i)
+	if i != 1 {
+		return wire.Value{}, fmt.Errorf("MatchingService_DescribeTaskJourney_Result should have exactly one field: got %v fields", i)
 	}
 
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
 
-func _BadRequestError_Read(w wire.Value) (*shared.BadRequestError, error) {
-	var v shared.BadRequestError
-	err := v.FromWire(w)
-	return &v, err
-}
-
-func _InternalServiceError_Read(w wire.Value) (*shared.InternalServiceError, error) {
-	var v shared.InternalServiceError
-	err := v.FromWire(w)
-	return &v, err
-}
-
-func _ServiceBusyError_Read(w wire.Value) (*shared.ServiceBusyError, error) {
-	var v shared.ServiceBusyError
-	err := v.FromWire(w)
-	return &v, err
-}
-
-func _LimitExceededError_Read(w wire.Value) (*shared.LimitExceededError, error) {
-	var v shared.LimitExceededError
+func _DescribeTaskJourneyResponse_Read(w wire.Value) (*shared.DescribeTaskJourneyResponse, error) {
+	var v shared.DescribeTaskJourneyResponse
 	err := v.FromWire(w)
 	return &v, err
 }
 
-func _DomainNotActiveError_Read(w wire.Value) (*shared.DomainNotActiveError, error) {
-	var v shared.DomainNotActiveError
+func _EntityNotExistsError_Read(w wire.Value) (*shared.EntityNotExistsError, error) {
+	var v shared.EntityNotExistsError
 	err := v.FromWire(w)
 	return &v, err
 }
 
-// FromWire deserializes a MatchingService_AddActivityTask_Result struct from its Thrift-level
+// FromWire deserializes a MatchingService_DescribeTaskJourney_Result struct from its Thrift-level
 // representation. The Thrift-level representation may be obtained
 // from a ThriftRW protocol implementation.
 //
-// An error is returned if we were unable to build a MatchingService_AddActivityTask_Result struct
+// An error is returned if we were unable to build a MatchingService_DescribeTaskJourney_Result struct
 // from the provided intermediate representation.
 //
 //   x, err := binaryProtocol.Decode(reader, wire.TStruct)
@@ -3399,51 +6144,51 @@ func _DomainNotActiveError_Read(w wire.Value) (*shared.DomainNotActiveError, err
 //     return nil, err
 //   }
 //
-//   var v MatchingService_AddActivityTask_Result
+//   var v MatchingService_DescribeTaskJourney_Result
 //   if err := v.FromWire(x); err != nil {
 //     return nil, err
 //   }
 //   return &v, nil
-func (v *MatchingService_AddActivityTask_Result) FromWire(w wire.Value) error {
+func (v *MatchingService_DescribeTaskJourney_Result) FromWire(w wire.Value) error {
 	var err error
 
 	for _, field := range w.GetStruct().Fields {
 		switch field.ID {
-		case 1:
+		case 0:
 			if field.Value.Type() == wire.TStruct {
-				v.BadRequestError, err = _BadRequestError_Read(field.Value)
+				v.Success, err = _DescribeTaskJourneyResponse_Read(field.Value)
 				if err != nil {
 					return err
 				}
 
 			}
-		case 2:
+		case 1:
 			if field.Value.Type() == wire.TStruct {
-				v.InternalServiceError, err = _InternalServiceError_Read(field.Value)
+				v.BadRequestError, err = _BadRequestError_Read(field.Value)
 				if err != nil {
 					return err
 				}
 
 			}
-		case 3:
+		case 2:
 			if field.Value.Type() == wire.TStruct {
-				v.ServiceBusyError, err = _ServiceBusyError_Read(field.Value)
+				v.InternalServiceError, err = _InternalServiceError_Read(field.Value)
 				if err != nil {
 					return err
 				}
 
 			}
-		case 4:
+		case 3:
 			if field.Value.Type() == wire.TStruct {
-				v.LimitExceededError, err = _LimitExceededError_Read(field.Value)
+				v.EntityNotExistError, err = _EntityNotExistsError_Read(field.Value)
 				if err != nil {
 					return err
 				}
 
 			}
-		case 5:
+		case 4:
 			if field.Value.Type() == wire.TStruct {
-				v.DomainNotActiveError, err = _DomainNotActiveError_Read(field.Value)
+				v.ServiceBusyError, err = _ServiceBusyError_Read(field.Value)
 				if err != nil {
 					return err
 				}
@@ -3453,37 +6198,41 @@ func (v *MatchingService_AddActivityTask_Result) FromWire(w wire.Value) error {
 	}
 
 	count := 0
-	if v.BadRequestError != nil {
+	if v.Success != nil {
 		count++
 	}
-	if v.InternalServiceError != nil {
+	if v.BadRequestError != nil {
 		count++
 	}
-	if v.ServiceBusyError != nil {
+	if v.InternalServiceError != nil {
 		count++
 	}
-	if v.LimitExceededError != nil {
+	if v.EntityNotExistError != nil {
 		count++
 	}
-	if v.DomainNotActiveError != nil {
+	if v.ServiceBusyError != nil {
 		count++
 	}
-	if count > 1 {
-		return fmt.Errorf("MatchingService_AddActivityTask_Result should have at most one field: got %v fields", count)
+	if count != 1 {
+		return fmt.Errorf("MatchingService_DescribeTaskJourney_Result should have exactly one field: got %v fields", count)
 	}
 
 	return nil
 }
 
-// String returns a readable string representation of a MatchingService_AddActivityTask_Result
+// String returns a readable string representation of a MatchingService_DescribeTaskJourney_Result
 // struct.
-func (v *MatchingService_AddActivityTask_Result) String() string {
+func (v *MatchingService_DescribeTaskJourney_Result) String() string {
 	if v == nil {
 		return "<nil>"
 	}
 
 	var fields [5]string
 	i := 0
+	if v.Success != nil {
+		fields[i] = fmt.Sprintf("Success: %v", v.Success)
+		i++
+	}
 	if v.BadRequestError != nil {
 		fields[i] = fmt.Sprintf("BadRequestError: %v", v.BadRequestError)
 		i++
@@ -3492,45 +6241,41 @@ func (v *MatchingService_AddActivityTask_Result) String() string {
 		fields[i] = fmt.Sprintf("InternalServiceError: %v", v.InternalServiceError)
 		i++
 	}
-	if v.ServiceBusyError != nil {
-		fields[i] = fmt.Sprintf("ServiceBusyError: %v", v.ServiceBusyError)
-		i++
-	}
-	if v.LimitExceededError != nil {
-		fields[i] = fmt.Sprintf("LimitExceededError: %v", v.LimitExceededError)
+	if v.EntityNotExistError != nil {
+		fields[i] = fmt.Sprintf("EntityNotExistError: %v", v.EntityNotExistError)
 		i++
 	}
-	if v.DomainNotActiveError != nil {
-		fields[i] = fmt.Sprintf("DomainNotActiveError: %v", v.DomainNotActiveError)
+	if v.ServiceBusyError != nil {
+		fields[i] = fmt.Sprintf("ServiceBusyError: %v", v.ServiceBusyError)
 		i++
 	}
 
-	return fmt.Sprintf("MatchingService_AddActivityTask_Result{%v}", strings.Join(fields[:i], ", "))
+	return fmt.Sprintf("MatchingService_DescribeTaskJourney_Result{%v}", strings.Join(fields[:i], ", "))
 }
 
-// Equals returns true if all the fields of this MatchingService_AddActivityTask_Result match the
-// provided MatchingService_AddActivityTask_Result.
+// Equals returns true if all the fields of this MatchingService_DescribeTaskJourney_Result match the
+// provided MatchingService_DescribeTaskJourney_Result.
 //
 // This function performs a deep comparison.
-func (v *MatchingService_AddActivityTask_Result) Equals(rhs *MatchingService_AddActivityTask_Result) bool {
+func (v *MatchingService_DescribeTaskJourney_Result) Equals(rhs *MatchingService_DescribeTaskJourney_Result) bool {
 	if v == nil {
 		return rhs == nil
 	} else if rhs == nil {
 		return false
 	}
-	if !((v.BadRequestError == nil && rhs.BadRequestError == nil) || (v.BadRequestError != nil && rhs.BadRequestError != nil && v.BadRequestError.Equals(rhs.BadRequestError))) {
+	if !((v.Success == nil && rhs.Success == nil) || (v.Success != nil && rhs.Success != nil && v.Success.Equals(rhs.Success))) {
 		return false
 	}
-	if !((v.InternalServiceError == nil && rhs.InternalServiceError == nil) || (v.InternalServiceError != nil && rhs.InternalServiceError != nil && v.InternalServiceError.Equals(rhs.InternalServiceError))) {
+	if !((v.BadRequestError == nil && rhs.BadRequestError == nil) || (v.BadRequestError != nil && rhs.BadRequestError != nil && v.BadRequestError.Equals(rhs.BadRequestError))) {
 		return false
 	}
-	if !((v.ServiceBusyError == nil && rhs.ServiceBusyError == nil) || (v.ServiceBusyError != nil && rhs.ServiceBusyError != nil && v.ServiceBusyError.Equals(rhs.ServiceBusyError))) {
+	if !((v.InternalServiceError == nil && rhs.InternalServiceError == nil) || (v.InternalServiceError != nil && rhs.InternalServiceError != nil && v.InternalServiceError.Equals(rhs.InternalServiceError))) {
 		return false
 	}
-	if !((v.LimitExceededError == nil && rhs.LimitExceededError == nil) || (v.LimitExceededError != nil && rhs.LimitExceededError != nil && v.LimitExceededError.Equals(rhs.LimitExceededError))) {
+	if !((v.EntityNotExistError == nil && rhs.EntityNotExistError == nil) || (v.EntityNotExistError != nil && rhs.EntityNotExistError != nil && v.EntityNotExistError.Equals(rhs.EntityNotExistError))) {
 		return false
 	}
-	if !((v.DomainNotActiveError == nil && rhs.DomainNotActiveError == nil) || (v.DomainNotActiveError != nil && rhs.DomainNotActiveError != nil && v.DomainNotActiveError.Equals(rhs.DomainNotActiveError))) {
+	if !((v.ServiceBusyError == nil && rhs.ServiceBusyError == nil) || (v.ServiceBusyError != nil && rhs.ServiceBusyError != nil && v.ServiceBusyError.Equals(rhs.ServiceBusyError))) {
 		return false
 	}
 
@@ -3538,32 +6283,47 @@ func (v *MatchingService_AddActivityTask_Result) Equals(rhs *MatchingService_Add
 }
 
 // MarshalLogObject implements zapcore.ObjectMarshaler, enabling
-// fast logging of MatchingService_AddActivityTask_Result.
-func (v *MatchingService_AddActivityTask_Result) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+// fast logging of MatchingService_DescribeTaskJourney_Result.
+func (v *MatchingService_DescribeTaskJourney_Result) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
 	if v == nil {
 		return nil
 	}
+	if v.Success != nil {
+		err = multierr.Append(err, enc.AddObject("success", v.Success))
+	}
 	if v.BadRequestError != nil {
 		err = multierr.Append(err, enc.AddObject("badRequestError", v.BadRequestError))
 	}
 	if v.InternalServiceError != nil {
 		err = multierr.Append(err, enc.AddObject("internalServiceError", v.InternalServiceError))
 	}
+	if v.EntityNotExistError != nil {
+		err = multierr.Append(err, enc.AddObject("entityNotExistError", v.EntityNotExistError))
+	}
 	if v.ServiceBusyError != nil {
 		err = multierr.Append(err, enc.AddObject("serviceBusyError", v.ServiceBusyError))
 	}
-	if v.LimitExceededError != nil {
-		err = multierr.Append(err, enc.AddObject("limitExceededError", v.LimitExceededError))
-	}
-	if v.DomainNotActiveError != nil {
-		err = multierr.Append(err, enc.AddObject("domainNotActiveError", v.DomainNotActiveError))
-	}
 	return err
 }
 
+// GetSuccess returns the value of Success if it is set or its
+// zero value if it is unset.
+func (v *MatchingService_DescribeTaskJourney_Result) GetSuccess() (o *shared.DescribeTaskJourneyResponse) {
+	if v != nil && v.Success != nil {
+		return v.Success
+	}
+
+	return
+}
+
+// IsSetSuccess returns true if Success is not nil.
+func (v *MatchingService_DescribeTaskJourney_Result) IsSetSuccess() bool {
+	return v != nil && v.Success != nil
+}
+
 // GetBadRequestError returns the value of BadRequestError if it is set or its
 // zero value if it is unset.
-func (v *MatchingService_AddActivityTask_Result) GetBadRequestError() (o *shared.BadRequestError) {
+func (v *MatchingService_DescribeTaskJourney_Result) GetBadRequestError() (o *shared.BadRequestError) {
 	if v != nil && v.BadRequestError != nil {
 		return v.BadRequestError
 	}
@@ -3572,13 +6332,13 @@ func (v *MatchingService_AddActivityTask_Result) GetBadRequestError() (o *shared
 }
 
 // IsSetBadRequestError returns true if BadRequestError is not nil.
-func (v *MatchingService_AddActivityTask_Result) IsSetBadRequestError() bool {
+func (v *MatchingService_DescribeTaskJourney_Result) IsSetBadRequestError() bool {
 	return v != nil && v.BadRequestError != nil
 }
 
 // GetInternalServiceError returns the value of InternalServiceError if it is set or its
 // zero value if it is unset.
-func (v *MatchingService_AddActivityTask_Result) GetInternalServiceError() (o *shared.InternalServiceError) {
+func (v *MatchingService_DescribeTaskJourney_Result) GetInternalServiceError() (o *shared.InternalServiceError) {
 	if v != nil && v.InternalServiceError != nil {
 		return v.InternalServiceError
 	}
@@ -3587,78 +6347,63 @@ func (v *MatchingService_AddActivityTask_Result) GetInternalServiceError() (o *s
 }
 
 // IsSetInternalServiceError returns true if InternalServiceError is not nil.
-func (v *MatchingService_AddActivityTask_Result) IsSetInternalServiceError() bool {
+func (v *MatchingService_DescribeTaskJourney_Result) IsSetInternalServiceError() bool {
 	return v != nil && v.InternalServiceError != nil
 }
 
-// GetServiceBusyError returns the value of ServiceBusyError if it is set or its
-// zero value if it is unset.
-func (v *MatchingService_AddActivityTask_Result) GetServiceBusyError() (o *shared.ServiceBusyError) {
-	if v != nil && v.ServiceBusyError != nil {
-		return v.ServiceBusyError
-	}
-
-	return
-}
-
-// IsSetServiceBusyError returns true if ServiceBusyError is not nil.
-func (v *MatchingService_AddActivityTask_Result) IsSetServiceBusyError() bool {
-	return v != nil && v.ServiceBusyError != nil
-}
-
-// GetLimitExceededError returns the value of LimitExceededError if it is set or its
+// GetEntityNotExistError returns the value of EntityNotExistError if it is set or its
 // zero value if it is unset.
-func (v *MatchingService_AddActivityTask_Result) GetLimitExceededError() (o *shared.LimitExceededError) {
-	if v != nil && v.LimitExceededError != nil {
-		return v.LimitExceededError
+func (v *MatchingService_DescribeTaskJourney_Result) GetEntityNotExistError() (o *shared.EntityNotExistsError) {
+	if v != nil && v.EntityNotExistError != nil {
+		return v.EntityNotExistError
 	}
 
 	return
 }
 
-// IsSetLimitExceededError returns true if LimitExceededError is not nil.
-func (v *MatchingService_AddActivityTask_Result) IsSetLimitExceededError() bool {
-	return v != nil && v.LimitExceededError != nil
+// IsSetEntityNotExistError returns true if EntityNotExistError is not nil.
+func (v *MatchingService_DescribeTaskJourney_Result) IsSetEntityNotExistError() bool {
+	return v != nil && v.EntityNotExistError != nil
 }
 
-// GetDomainNotActiveError returns the value of DomainNotActiveError if it is set or its
+// GetServiceBusyError returns the value of ServiceBusyError if it is set or its
 // zero value if it is unset.
-func (v *MatchingService_AddActivityTask_Result) GetDomainNotActiveError() (o *shared.DomainNotActiveError) {
-	if v != nil && v.DomainNotActiveError != nil {
-		return v.DomainNotActiveError
+func (v *MatchingService_DescribeTaskJourney_Result) GetServiceBusyError() (o *shared.ServiceBusyError) {
+	if v != nil && v.ServiceBusyError != nil {
+		return v.ServiceBusyError
 	}
 
 	return
 }
 
-// IsSetDomainNotActiveError returns true if DomainNotActiveError is not nil.
-func (v *MatchingService_AddActivityTask_Result) IsSetDomainNotActiveError() bool {
-	return v != nil && v.DomainNotActiveError != nil
+// IsSetServiceBusyError returns true if ServiceBusyError is not nil.
+func (v *MatchingService_DescribeTaskJourney_Result) IsSetServiceBusyError() bool {
+	return v != nil && v.ServiceBusyError != nil
 }
 
 // MethodName returns the name of the Thrift function as specified in
 // the IDL, for which this struct represent the result.
 //
-// This will always be "AddActivityTask" for this struct.
-func (v *MatchingService_AddActivityTask_Result) MethodName() string {
-	return "AddActivityTask"
+// This will always be "DescribeTaskJourney" for this struct.
+func (v *MatchingService_DescribeTaskJourney_Result) MethodName() string {
+	return "DescribeTaskJourney"
 }
 
 // EnvelopeType returns the kind of value inside this struct.
 //
 // This will always be Reply for this struct.
-func (v *MatchingService_AddActivityTask_Result) EnvelopeType() wire.EnvelopeType {
+func (v *MatchingService_DescribeTaskJourney_Result) EnvelopeType() wire.EnvelopeType {
 	return wire.Reply
 }
 
-// MatchingService_AddDecisionTask_Args represents the arguments for the MatchingService.AddDecisionTask function.
+// MatchingService_DescribeTaskList_Args represents the arguments for the MatchingService.DescribeTaskList function.
 //
-// The arguments for AddDecisionTask are sent and received over the wire as this struct.
-type MatchingService_AddDecisionTask_Args struct {
-	AddRequest *AddDecisionTaskRequest `json:"addRequest,omitempty"`
+// The arguments for DescribeTaskList are sent and received over the wire as this struct.
+type MatchingService_DescribeTaskList_Args struct {
+	Request *DescribeTaskListRequest `json:"request,omitempty"`
 }
 
-// ToWire translates a MatchingService_AddDecisionTask_Args struct into a Thrift-level intermediate
+// ToWire translates a MatchingService_DescribeTaskList_Args struct into a Thrift-level intermediate
 // representation. This intermediate representation may be serialized
 // into bytes using a ThriftRW protocol implementation.
 //
@@ -3673,7 +6418,7 @@ type MatchingService_AddDecisionTask_Args struct {
 //   if err := binaryProtocol.Encode(x, writer); err != nil {
 //     return err
 //   }
-func (v *MatchingService_AddDecisionTask_Args) ToWire() (wire.Value, error) {
+func (v *MatchingService_DescribeTaskList_Args) ToWire() (wire.Value, error) {
 	var (
 		fields [1]wire.Field
 		i      int = 0
@@ -3681,8 +6426,8 @@ func (v *MatchingService_AddDecisionTask_Args) ToWire() (wire.Value, error) {
 		err    error
 	)
 
-	if v.AddRequest != nil {
-		w, err = v.AddRequest.ToWire()
+	if v.Request != nil {
+		w, err = v.Request.ToWire()
 		if err != nil {
 			return w, err
 		}
@@ -3693,17 +6438,17 @@ func (v *MatchingService_AddDecisionTask_Args) ToWire() (wire.Value, error) {
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
 
-func _AddDecisionTaskRequest_Read(w wire.Value) (*AddDecisionTaskRequest, error) {
-	var v AddDecisionTaskRequest
+func _DescribeTaskListRequest_1_Read(w wire.Value) (*DescribeTaskListRequest, error) {
+	var v DescribeTaskListRequest
 	err := v.FromWire(w)
 	return &v, err
 }
 
-// FromWire deserializes a MatchingService_AddDecisionTask_Args struct from its Thrift-level
+// FromWire deserializes a MatchingService_DescribeTaskList_Args struct from its Thrift-level
 // representation. The Thrift-level representation may be obtained
 // from a ThriftRW protocol implementation.
 //
-// An error is returned if we were unable to build a MatchingService_AddDecisionTask_Args struct
+// An error is returned if we were unable to build a MatchingService_DescribeTaskList_Args struct
 // from the provided intermediate representation.
 //
 //   x, err := binaryProtocol.Decode(reader, wire.TStruct)
@@ -3711,19 +6456,19 @@ func _AddDecisionTaskRequest_Read(w wire.Value) (*AddDecisionTaskRequest, error)
 //     return nil, err
 //   }
 //
-//   var v MatchingService_AddDecisionTask_Args
+//   var v MatchingService_DescribeTaskList_Args
 //   if err := v.FromWire(x); err != nil {
 //     return nil, err
 //   }
 //   return &v, nil
-func (v *MatchingService_AddDecisionTask_Args) FromWire(w wire.Value) error {
+func (v *MatchingService_DescribeTaskList_Args) FromWire(w wire.Value) error {
 	var err error
 
 	for _, field := range w.GetStruct().Fields {
 		switch field.ID {
 		case 1:
 			if field.Value.Type() == wire.TStruct {
-				v.AddRequest, err = _AddDecisionTaskRequest_Read(field.Value)
+				v.Request, err = _DescribeTaskListRequest_1_Read(field.Value)
 				if err != nil {
 					return err
 				}
@@ -3735,34 +6480,34 @@ func (v *MatchingService_AddDecisionTask_Args) FromWire(w wire.Value) error {
 	return nil
 }
 
-// String returns a readable string representation of a MatchingService_AddDecisionTask_Args
+// String returns a readable string representation of a MatchingService_DescribeTaskList_Args
 // struct.
-func (v *MatchingService_AddDecisionTask_Args) String() string {
+func (v *MatchingService_DescribeTaskList_Args) String() string {
 	if v == nil {
 		return "<nil>"
 	}
 
 	var fields [1]string
 	i := 0
-	if v.AddRequest != nil {
-		fields[i] = fmt.Sprintf("AddRequest: %v", v.AddRequest)
+	if v.Request != nil {
+		fields[i] = fmt.Sprintf("Request: %v", v.Request)
 		i++
 	}
 
-	return fmt.Sprintf("MatchingService_AddDecisionTask_Args{%v}", strings.Join(fields[:i], ", "))
+	return fmt.Sprintf("MatchingService_DescribeTaskList_Args{%v}", strings.Join(fields[:i], ", "))
 }
 
-// Equals returns true if all the fields of this MatchingService_AddDecisionTask_Args match the
-// provided MatchingService_AddDecisionTask_Args.
+// Equals returns true if all the fields of this MatchingService_DescribeTaskList_Args match the
+// provided MatchingService_DescribeTaskList_Args.
 //
 // This function performs a deep comparison.
-func (v *MatchingService_AddDecisionTask_Args) Equals(rhs *MatchingService_AddDecisionTask_Args) bool {
+func (v *MatchingService_DescribeTaskList_Args) Equals(rhs *MatchingService_DescribeTaskList_Args) bool {
 	if v == nil {
 		return rhs == nil
 	} else if rhs == nil {
 		return false
 	}
-	if !((v.AddRequest == nil && rhs.AddRequest == nil) || (v.AddRequest != nil && rhs.AddRequest != nil && v.AddRequest.Equals(rhs.AddRequest))) {
+	if !((v.Request == nil && rhs.Request == nil) || (v.Request != nil && rhs.Request != nil && v.Request.Equals(rhs.Request))) {
 		return false
 	}
 
@@ -3770,155 +6515,147 @@ func (v *MatchingService_AddDecisionTask_Args) Equals(rhs *MatchingService_AddDe
 }
 
 // MarshalLogObject implements zapcore.ObjectMarshaler, enabling
-// fast logging of MatchingService_AddDecisionTask_Args.
-func (v *MatchingService_AddDecisionTask_Args) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+// fast logging of MatchingService_DescribeTaskList_Args.
+func (v *MatchingService_DescribeTaskList_Args) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
 	if v == nil {
 		return nil
 	}
-	if v.AddRequest != nil {
-		err = multierr.Append(err, enc.AddObject("addRequest", v.AddRequest))
+	if v.Request != nil {
+		err = multierr.Append(err, enc.AddObject("request", v.Request))
 	}
 	return err
 }
 
-// GetAddRequest returns the value of AddRequest if it is set or its
+// GetRequest returns the value of Request if it is set or its
 // zero value if it is unset.
-func (v *MatchingService_AddDecisionTask_Args) GetAddRequest() (o *AddDecisionTaskRequest) {
-	if v != nil && v.AddRequest != nil {
-		return v.AddRequest
+func (v *MatchingService_DescribeTaskList_Args) GetRequest() (o *DescribeTaskListRequest) {
+	if v != nil && v.Request != nil {
+		return v.Request
 	}
 
 	return
 }
 
-// IsSetAddRequest returns true if AddRequest is not nil.
-func (v *MatchingService_AddDecisionTask_Args) IsSetAddRequest() bool {
-	return v != nil && v.AddRequest != nil
+// IsSetRequest returns true if Request is not nil.
+func (v *MatchingService_DescribeTaskList_Args) IsSetRequest() bool {
+	return v != nil && v.Request != nil
 }
 
 // MethodName returns the name of the Thrift function as specified in
 // the IDL, for which this struct represent the arguments.
 //
-// This will always be "AddDecisionTask" for this struct.
-func (v *MatchingService_AddDecisionTask_Args) MethodName() string {
-	return "AddDecisionTask"
+// This will always be "DescribeTaskList" for this struct.
+func (v *MatchingService_DescribeTaskList_Args) MethodName() string {
+	return "DescribeTaskList"
 }
 
 // EnvelopeType returns the kind of value inside this struct.
 //
 // This will always be Call for this struct.
-func (v *MatchingService_AddDecisionTask_Args) EnvelopeType() wire.EnvelopeType {
+func (v *MatchingService_DescribeTaskList_Args) EnvelopeType() wire.EnvelopeType {
 	return wire.Call
 }
 
-// MatchingService_AddDecisionTask_Helper provides functions that aid in handling the
-// parameters and return values of the MatchingService.AddDecisionTask
+// MatchingService_DescribeTaskList_Helper provides functions that aid in handling the
+// parameters and return values of the MatchingService.DescribeTaskList
 // function.
-var MatchingService_AddDecisionTask_Helper = struct {
-	// Args accepts the parameters of AddDecisionTask in-order and returns
+var MatchingService_DescribeTaskList_Helper = struct {
+	// Args accepts the parameters of DescribeTaskList in-order and returns
 	// the arguments struct for the function.
 	Args func(
-		addRequest *AddDecisionTaskRequest,
-	) *MatchingService_AddDecisionTask_Args
+		request *DescribeTaskListRequest,
+	) *MatchingService_DescribeTaskList_Args
 
 	// IsException returns true if the given error can be thrown
-	// by AddDecisionTask.
+	// by DescribeTaskList.
 	//
-	// An error can be thrown by AddDecisionTask only if the
+	// An error can be thrown by DescribeTaskList only if the
 	// corresponding exception type was mentioned in the 'throws'
 	// section for it in the Thrift file.
 	IsException func(error) bool
 
-	// WrapResponse returns the result struct for AddDecisionTask
-	// given the error returned by it. The provided error may
-	// be nil if AddDecisionTask did not fail.
+	// WrapResponse returns the result struct for DescribeTaskList
+	// given its return value and error.
 	//
-	// This allows mapping errors returned by AddDecisionTask into a
-	// serializable result struct. WrapResponse returns a
-	// non-nil error if the provided error cannot be thrown by
-	// AddDecisionTask
+	// This allows mapping values and errors returned by
+	// DescribeTaskList into a serializable result struct.
+	// WrapResponse returns a non-nil error if the provided
+	// error cannot be thrown by DescribeTaskList
 	//
-	//   err := AddDecisionTask(args)
-	//   result, err := MatchingService_AddDecisionTask_Helper.WrapResponse(err)
+	//   value, err := DescribeTaskList(args)
+	//   result, err := MatchingService_DescribeTaskList_Helper.WrapResponse(value, err)
 	//   if err != nil {
-	//     return fmt.Errorf("unexpected error from AddDecisionTask: %v", err)
+	//     return fmt.Errorf("unexpected error from DescribeTaskList: %v", err)
 	//   }
 	//   serialize(result)
-	WrapResponse func(error) (*MatchingService_AddDecisionTask_Result, error)
+	WrapResponse func(*shared.DescribeTaskListResponse, error) (*MatchingService_DescribeTaskList_Result, error)
 
-	// UnwrapResponse takes the result struct for AddDecisionTask
-	// and returns the erorr returned by it (if any).
+	// UnwrapResponse takes the result struct for DescribeTaskList
+	// and returns the value or error returned by it.
 	//
-	// The error is non-nil only if AddDecisionTask threw an
+	// The error is non-nil only if DescribeTaskList threw an
 	// exception.
 	//
 	//   result := deserialize(bytes)
-	//   err := MatchingService_AddDecisionTask_Helper.UnwrapResponse(result)
-	UnwrapResponse func(*MatchingService_AddDecisionTask_Result) error
+	//   value, err := MatchingService_DescribeTaskList_Helper.UnwrapResponse(result)
+	UnwrapResponse func(*MatchingService_DescribeTaskList_Result) (*shared.DescribeTaskListResponse, error)
 }{}
 
 func init() {
-	MatchingService_AddDecisionTask_Helper.Args = func(
-		addRequest *AddDecisionTaskRequest,
-	) *MatchingService_AddDecisionTask_Args {
-		return &MatchingService_AddDecisionTask_Args{
-			AddRequest: addRequest,
+	MatchingService_DescribeTaskList_Helper.Args = func(
+		request *DescribeTaskListRequest,
+	) *MatchingService_DescribeTaskList_Args {
+		return &MatchingService_DescribeTaskList_Args{
+			Request: request,
 		}
 	}
 
-	MatchingService_AddDecisionTask_Helper.IsException = func(err error) bool {
+	MatchingService_DescribeTaskList_Helper.IsException = func(err error) bool {
 		switch err.(type) {
 		case *shared.BadRequestError:
 			return true
 		case *shared.InternalServiceError:
 			return true
-		case *shared.ServiceBusyError:
-			return true
-		case *shared.LimitExceededError:
+		case *shared.EntityNotExistsError:
 			return true
-		case *shared.DomainNotActiveError:
+		case *shared.ServiceBusyError:
 			return true
 		default:
 			return false
 		}
 	}
 
-	MatchingService_AddDecisionTask_Helper.WrapResponse = func(err error) (*MatchingService_AddDecisionTask_Result, error) {
+	MatchingService_DescribeTaskList_Helper.WrapResponse = func(success *shared.DescribeTaskListResponse, err error) (*MatchingService_DescribeTaskList_Result, error) {
 		if err == nil {
-			return &MatchingService_AddDecisionTask_Result{}, nil
+			return &MatchingService_DescribeTaskList_Result{Success: success}, nil
 		}
 
 		switch e := err.(type) {
 		case *shared.BadRequestError:
 			if e == nil {
-				return nil, errors.New("WrapResponse received non-nil error type with nil value for MatchingService_AddDecisionTask_Result.BadRequestError")
+				return nil, errors.New("WrapResponse received non-nil error type with nil value for MatchingService_DescribeTaskList_Result.BadRequestError")
 			}
-			return &MatchingService_AddDecisionTask_Result{BadRequestError: e}, nil
+			return &MatchingService_DescribeTaskList_Result{BadRequestError: e}, nil
 		case *shared.InternalServiceError:
 			if e == nil {
-				return nil, errors.New("WrapResponse received non-nil error type with nil value for MatchingService_AddDecisionTask_Result.InternalServiceError")
-			}
-			return &MatchingService_AddDecisionTask_Result{InternalServiceError: e}, nil
-		case *shared.ServiceBusyError:
-			if e == nil {
-				return nil, errors.New("WrapResponse received non-nil error type with nil value for MatchingService_AddDecisionTask_Result.ServiceBusyError")
+				return nil, errors.New("WrapResponse received non-nil error type with nil value for MatchingService_DescribeTaskList_Result.InternalServiceError")
 			}
-			return &MatchingService_AddDecisionTask_Result{ServiceBusyError: e}, nil
-		case *shared.LimitExceededError:
+			return &MatchingService_DescribeTaskList_Result{InternalServiceError: e}, nil
+		case *shared.EntityNotExistsError:
 			if e == nil {
-				return nil, errors.New("WrapResponse received non-nil error type with nil value for MatchingService_AddDecisionTask_Result.LimitExceededError")
+				return nil, errors.New("WrapResponse received non-nil error type with nil value for MatchingService_DescribeTaskList_Result.EntityNotExistError")
 			}
-			return &MatchingService_AddDecisionTask_Result{LimitExceededError: e}, nil
-		case *shared.DomainNotActiveError:
+			return &MatchingService_DescribeTaskList_Result{EntityNotExistError: e}, nil
+		case *shared.ServiceBusyError:
 			if e == nil {
-				return nil, errors.New("WrapResponse received non-nil error type with nil value for MatchingService_AddDecisionTask_Result.DomainNotActiveError")
+				return nil, errors.New("WrapResponse received non-nil error type with nil value for MatchingService_DescribeTaskList_Result.ServiceBusyError")
 			}
-			return &MatchingService_AddDecisionTask_Result{DomainNotActiveError: e}, nil
+			return &MatchingService_DescribeTaskList_Result{ServiceBusyError: e}, nil
 		}
 
 		return nil, err
 	}
-	MatchingService_AddDecisionTask_Helper.UnwrapResponse = func(result *MatchingService_AddDecisionTask_Result) (err error) {
+	MatchingService_DescribeTaskList_Helper.UnwrapResponse = func(result *MatchingService_DescribeTaskList_Result) (success *shared.DescribeTaskListResponse, err error) {
 		if result.BadRequestError != nil {
 			err = result.BadRequestError
 			return
@@ -3927,35 +6664,41 @@ func init() {
 			err = result.InternalServiceError
 			return
 		}
-		if result.ServiceBusyError != nil {
-			err = result.ServiceBusyError
+		if result.EntityNotExistError != nil {
+			err = result.EntityNotExistError
 			return
 		}
-		if result.LimitExceededError != nil {
-			err = result.LimitExceededError
+		if result.ServiceBusyError != nil {
+			err = result.ServiceBusyError
 			return
 		}
-		if result.DomainNotActiveError != nil {
-			err = result.DomainNotActiveError
+
+		if result.Success != nil {
+			success = result.Success
 			return
 		}
+
+		err = errors.New("expected a non-void result")
 		return
 	}
 
 }
 
-// MatchingService_AddDecisionTask_Result represents the result of a MatchingService.AddDecisionTask function call.
+// MatchingService_DescribeTaskList_Result represents the result of a MatchingService.DescribeTaskList function call.
 //
-// The result of a AddDecisionTask execution is sent and received over the wire as this struct.
-type MatchingService_AddDecisionTask_Result struct {
-	BadRequestError      *shared.BadRequestError      `json:"badRequestError,omitempty"`
-	InternalServiceError *shared.InternalServiceError `json:"internalServiceError,omitempty"`
-	ServiceBusyError     *shared.ServiceBusyError     `json:"serviceBusyError,omitempty"`
-	LimitExceededError   *shared.LimitExceededError   `json:"limitExceededError,omitempty"`
-	DomainNotActiveError *shared.DomainNotActiveError `json:"domainNotActiveError,omitempty"`
+// The result of a DescribeTaskList execution is sent and received over the wire as this struct.
+//
+// Success is set only if the function did not throw an exception.
+type MatchingService_DescribeTaskList_Result struct {
+	// Value returned by DescribeTaskList after a successful execution.
+	Success              *shared.DescribeTaskListResponse `json:"success,omitempty"`
+	BadRequestError      *shared.BadRequestError          `json:"badRequestError,omitempty"`
+	InternalServiceError *shared.InternalServiceError     `json:"internalServiceError,omitempty"`
+	EntityNotExistError  *shared.EntityNotExistsError     `json:"entityNotExistError,omitempty"`
+	ServiceBusyError     *shared.ServiceBusyError         `json:"serviceBusyError,omitempty"`
 }
 
-// ToWire translates a MatchingService_AddDecisionTask_Result struct into a Thrift-level intermediate
+// ToWire translates a MatchingService_DescribeTaskList_Result struct into a Thrift-level intermediate
 // representation. This intermediate representation may be serialized
 // into bytes using a ThriftRW protocol implementation.
 //
@@ -3970,7 +6713,7 @@ type MatchingService_AddDecisionTask_Result struct {
 //   if err := binaryProtocol.Encode(x, writer); err != nil {
 //     return err
 //   }
-func (v *MatchingService_AddDecisionTask_Result) ToWire() (wire.Value, error) {
+func (v *MatchingService_DescribeTaskList_Result) ToWire() (wire.Value, error) {
 	var (
 		fields [5]wire.Field
 		i      int = 0
@@ -3978,6 +6721,14 @@ func (v *MatchingService_AddDecisionTask_Result) ToWire() (wire.Value, error) {
 		err    error
 	)
 
+	if v.Success != nil {
+		w, err = v.Success.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 0, Value: w}
+		i++
+	}
 	if v.BadRequestError != nil {
 		w, err = v.BadRequestError.ToWire()
 		if err != nil {
@@ -3994,43 +6745,41 @@ func (v *MatchingService_AddDecisionTask_Result) ToWire() (wire.Value, error) {
 		fields[i] = wire.Field{ID: 2, Value: w}
 		i++
 	}
-	if v.ServiceBusyError != nil {
-		w, err = v.ServiceBusyError.ToWire()
+	if v.EntityNotExistError != nil {
+		w, err = v.EntityNotExistError.ToWire()
 		if err != nil {
 			return w, err
 		}
 		fields[i] = wire.Field{ID: 3, Value: w}
 		i++
 	}
-	if v.LimitExceededError != nil {
-		w, err = v.LimitExceededError.ToWire()
+	if v.ServiceBusyError != nil {
+		w, err = v.ServiceBusyError.ToWire()
 		if err != nil {
 			return w, err
 		}
 		fields[i] = wire.Field{ID: 4, Value: w}
 		i++
 	}
-	if v.DomainNotActiveError != nil {
-		w, err = v.DomainNotActiveError.ToWire()
-		if err != nil {
-			return w, err
-		}
-		fields[i] = wire.Field{ID: 5, Value: w}
-		i++
-	}
 
-	if i > 1 {
-		return wire.Value{}, fmt.Errorf("MatchingService_AddDecisionTask_Result should have at most one field: got %v fields", i)
+	if i != 1 {
+		return wire.Value{}, fmt.Errorf("MatchingService_DescribeTaskList_Result should have exactly one field: got %v fields", i)
 	}
 
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
 
-// FromWire deserializes a MatchingService_AddDecisionTask_Result struct from its Thrift-level
+func _DescribeTaskListResponse_Read(w wire.Value) (*shared.DescribeTaskListResponse, error) {
+	var v shared.DescribeTaskListResponse
+	err := v.FromWire(w)
+	return &v, err
+}
+
+// FromWire deserializes a MatchingService_DescribeTaskList_Result struct from its Thrift-level
 // representation. The Thrift-level representation may be obtained
 // from a ThriftRW protocol implementation.
 //
-// An error is returned if we were unable to build a MatchingService_AddDecisionTask_Result struct
+// An error is returned if we were unable to build a MatchingService_DescribeTaskList_Result struct
 // from the provided intermediate representation.
 //
 //   x, err := binaryProtocol.Decode(reader, wire.TStruct)
@@ -4038,51 +6787,51 @@ func (v *MatchingService_AddDecisionTask_Result) ToWire() (wire.Value, error) {
 //     return nil, err
 //   }
 //
-//   var v MatchingService_AddDecisionTask_Result
+//   var v MatchingService_DescribeTaskList_Result
 //   if err := v.FromWire(x); err != nil {
 //     return nil, err
 //   }
 //   return &v, nil
-func (v *MatchingService_AddDecisionTask_Result) FromWire(w wire.Value) error {
+func (v *MatchingService_DescribeTaskList_Result) FromWire(w wire.Value) error {
 	var err error
 
 	for _, field := range w.GetStruct().Fields {
 		switch field.ID {
-		case 1:
+		case 0:
 			if field.Value.Type() == wire.TStruct {
-				v.BadRequestError, err = _BadRequestError_Read(field.Value)
+				v.Success, err = _DescribeTaskListResponse_Read(field.Value)
 				if err != nil {
 					return err
 				}
 
 			}
-		case 2:
+		case 1:
 			if field.Value.Type() == wire.TStruct {
-				v.InternalServiceError, err = _InternalServiceError_Read(field.Value)
+				v.BadRequestError, err = _BadRequestError_Read(field.Value)
 				if err != nil {
 					return err
 				}
 
 			}
-		case 3:
+		case 2:
 			if field.Value.Type() == wire.TStruct {
-				v.ServiceBusyError, err = _ServiceBusyError_Read(field.Value)
+				v.InternalServiceError, err = _InternalServiceError_Read(field.Value)
 				if err != nil {
 					return err
 				}
 
 			}
-		case 4:
+		case 3:
 			if field.Value.Type() == wire.TStruct {
-				v.LimitExceededError, err = _LimitExceededError_Read(field.Value)
+				v.EntityNotExistError, err = _EntityNotExistsError_Read(field.Value)
 				if err != nil {
 					return err
 				}
 
 			}
-		case 5:
+		case 4:
 			if field.Value.Type() == wire.TStruct {
-				v.DomainNotActiveError, err = _DomainNotActiveError_Read(field.Value)
+				v.ServiceBusyError, err = _ServiceBusyError_Read(field.Value)
 				if err != nil {
 					return err
 				}
@@ -4092,37 +6841,41 @@ func (v *MatchingService_AddDecisionTask_Result) FromWire(w wire.Value) error {
 	}
 
 	count := 0
-	if v.BadRequestError != nil {
+	if v.Success != nil {
 		count++
 	}
-	if v.InternalServiceError != nil {
+	if v.BadRequestError != nil {
 		count++
 	}
-	if v.ServiceBusyError != nil {
+	if v.InternalServiceError != nil {
 		count++
 	}
-	if v.LimitExceededError != nil {
+	if v.EntityNotExistError != nil {
 		count++
 	}
-	if v.DomainNotActiveError != nil {
+	if v.ServiceBusyError != nil {
 		count++
 	}
-	if count > 1 {
-		return fmt.Errorf("MatchingService_AddDecisionTask_Result should have at most one field: got %v fields", count)
+	if count != 1 {
+		return fmt.Errorf("MatchingService_DescribeTaskList_Result should have exactly one field: got %v fields", count)
 	}
 
 	return nil
 }
 
-// String returns a readable string representation of a MatchingService_AddDecisionTask_Result
+// String returns a readable string representation of a MatchingService_DescribeTaskList_Result
 // struct.
-func (v *MatchingService_AddDecisionTask_Result) String() string {
+func (v *MatchingService_DescribeTaskList_Result) String() string {
 	if v == nil {
 		return "<nil>"
 	}
 
 	var fields [5]string
 	i := 0
+	if v.Success != nil {
+		fields[i] = fmt.Sprintf("Success: %v", v.Success)
+		i++
+	}
 	if v.BadRequestError != nil {
 		fields[i] = fmt.Sprintf("BadRequestError: %v", v.BadRequestError)
 		i++
@@ -4131,45 +6884,41 @@ func (v *MatchingService_AddDecisionTask_Result) String() string {
 		fields[i] = fmt.Sprintf("InternalServiceError: %v", v.InternalServiceError)
 		i++
 	}
-	if v.ServiceBusyError != nil {
-		fields[i] = fmt.Sprintf("ServiceBusyError: %v", v.ServiceBusyError)
-		i++
-	}
-	if v.LimitExceededError != nil {
-		fields[i] = fmt.Sprintf("LimitExceededError: %v", v.LimitExceededError)
+	if v.EntityNotExistError != nil {
+		fields[i] = fmt.Sprintf("EntityNotExistError: %v", v.EntityNotExistError)
 		i++
 	}
-	if v.DomainNotActiveError != nil {
-		fields[i] = fmt.Sprintf("DomainNotActiveError: %v", v.DomainNotActiveError)
+	if v.ServiceBusyError != nil {
+		fields[i] = fmt.Sprintf("ServiceBusyError: %v", v.ServiceBusyError)
 		i++
 	}
 
-	return fmt.Sprintf("MatchingService_AddDecisionTask_Result{%v}", strings.Join(fields[:i], ", "))
+	return fmt.Sprintf("MatchingService_DescribeTaskList_Result{%v}", strings.Join(fields[:i], ", "))
 }
 
-// Equals returns true if all the fields of this MatchingService_AddDecisionTask_Result match the
-// provided MatchingService_AddDecisionTask_Result.
+// Equals returns true if all the fields of this MatchingService_DescribeTaskList_Result match the
+// provided MatchingService_DescribeTaskList_Result.
 //
 // This function performs a deep comparison.
-func (v *MatchingService_AddDecisionTask_Result) Equals(rhs *MatchingService_AddDecisionTask_Result) bool {
+func (v *MatchingService_DescribeTaskList_Result) Equals(rhs *MatchingService_DescribeTaskList_Result) bool {
 	if v == nil {
 		return rhs == nil
 	} else if rhs == nil {
 		return false
 	}
-	if !((v.BadRequestError == nil && rhs.BadRequestError == nil) || (v.BadRequestError != nil && rhs.BadRequestError != nil && v.BadRequestError.Equals(rhs.BadRequestError))) {
+	if !((v.Success == nil && rhs.Success == nil) || (v.Success != nil && rhs.Success != nil && v.Success.Equals(rhs.Success))) {
 		return false
 	}
-	if !((v.InternalServiceError == nil && rhs.InternalServiceError == nil) || (v.InternalServiceError != nil && rhs.InternalServiceError != nil && v.InternalServiceError.Equals(rhs.InternalServiceError))) {
+	if !((v.BadRequestError == nil && rhs.BadRequestError == nil) || (v.BadRequestError != nil && rhs.BadRequestError != nil && v.BadRequestError.Equals(rhs.BadRequestError))) {
 		return false
 	}
-	if !((v.ServiceBusyError == nil && rhs.ServiceBusyError == nil) || (v.ServiceBusyError != nil && rhs.ServiceBusyError != nil && v.ServiceBusyError.Equals(rhs.ServiceBusyError))) {
+	if !((v.InternalServiceError == nil && rhs.InternalServiceError == nil) || (v.InternalServiceError != nil && rhs.InternalServiceError != nil && v.InternalServiceError.Equals(rhs.InternalServiceError))) {
 		return false
 	}
-	if !((v.LimitExceededError == nil && rhs.LimitExceededError == nil) || (v.LimitExceededError != nil && rhs.LimitExceededError != nil && v.LimitExceededError.Equals(rhs.LimitExceededError))) {
+	if !((v.EntityNotExistError == nil && rhs.EntityNotExistError == nil) || (v.EntityNotExistError != nil && rhs.EntityNotExistError != nil && v.EntityNotExistError.Equals(rhs.EntityNotExistError))) {
 		return false
 	}
-	if !((v.DomainNotActiveError == nil && rhs.DomainNotActiveError == nil) || (v.DomainNotActiveError != nil && rhs.DomainNotActiveError != nil && v.DomainNotActiveError.Equals(rhs.DomainNotActiveError))) {
+	if !((v.ServiceBusyError == nil && rhs.ServiceBusyError == nil) || (v.ServiceBusyError != nil && rhs.ServiceBusyError != nil && v.ServiceBusyError.Equals(rhs.ServiceBusyError))) {
 		return false
 	}
 
@@ -4177,32 +6926,47 @@ func (v *MatchingService_AddDecisionTask_Result) Equals(rhs *MatchingService_Add
 }
 
 // MarshalLogObject implements zapcore.ObjectMarshaler, enabling
-// fast logging of MatchingService_AddDecisionTask_Result.
-func (v *MatchingService_AddDecisionTask_Result) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+// fast logging of MatchingService_DescribeTaskList_Result.
+func (v *MatchingService_DescribeTaskList_Result) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
 	if v == nil {
 		return nil
 	}
+	if v.Success != nil {
+		err = multierr.Append(err, enc.AddObject("success", v.Success))
+	}
 	if v.BadRequestError != nil {
 		err = multierr.Append(err, enc.AddObject("badRequestError", v.BadRequestError))
 	}
 	if v.InternalServiceError != nil {
 		err = multierr.Append(err, enc.AddObject("internalServiceError", v.InternalServiceError))
 	}
+	if v.EntityNotExistError != nil {
+		err = multierr.Append(err, enc.AddObject("entityNotExistError", v.EntityNotExistError))
+	}
 	if v.ServiceBusyError != nil {
 		err = multierr.Append(err, enc.AddObject("serviceBusyError", v.ServiceBusyError))
 	}
-	if v.LimitExceededError != nil {
-		err = multierr.Append(err, enc.AddObject("limitExceededError", v.LimitExceededError))
-	}
-	if v.DomainNotActiveError != nil {
-		err = multierr.Append(err, enc.AddObject("domainNotActiveError", v.DomainNotActiveError))
-	}
 	return err
 }
 
+// GetSuccess returns the value of Success if it is set or its
+// zero value if it is unset.
+func (v *MatchingService_DescribeTaskList_Result) GetSuccess() (o *shared.DescribeTaskListResponse) {
+	if v != nil && v.Success != nil {
+		return v.Success
+	}
+
+	return
+}
+
+// IsSetSuccess returns true if Success is not nil.
+func (v *MatchingService_DescribeTaskList_Result) IsSetSuccess() bool {
+	return v != nil && v.Success != nil
+}
+
 // GetBadRequestError returns the value of BadRequestError if it is set or its
 // zero value if it is unset.
-func (v *MatchingService_AddDecisionTask_Result) GetBadRequestError() (o *shared.BadRequestError) {
+func (v *MatchingService_DescribeTaskList_Result) GetBadRequestError() (o *shared.BadRequestError) {
 	if v != nil && v.BadRequestError != nil {
 		return v.BadRequestError
 	}
@@ -4211,13 +6975,13 @@ func (v *MatchingService_AddDecisionTask_Result) GetBadRequestError() (o *shared
 }
 
 // IsSetBadRequestError returns true if BadRequestError is not nil.
-func (v *MatchingService_AddDecisionTask_Result) IsSetBadRequestError() bool {
+func (v *MatchingService_DescribeTaskList_Result) IsSetBadRequestError() bool {
 	return v != nil && v.BadRequestError != nil
 }
 
 // GetInternalServiceError returns the value of InternalServiceError if it is set or its
 // zero value if it is unset.
-func (v *MatchingService_AddDecisionTask_Result) GetInternalServiceError() (o *shared.InternalServiceError) {
+func (v *MatchingService_DescribeTaskList_Result) GetInternalServiceError() (o *shared.InternalServiceError) {
 	if v != nil && v.InternalServiceError != nil {
 		return v.InternalServiceError
 	}
@@ -4226,78 +6990,63 @@ func (v *MatchingService_AddDecisionTask_Result) GetInternalServiceError() (o *s
 }
 
 // IsSetInternalServiceError returns true if InternalServiceError is not nil.
-func (v *MatchingService_AddDecisionTask_Result) IsSetInternalServiceError() bool {
+func (v *MatchingService_DescribeTaskList_Result) IsSetInternalServiceError() bool {
 	return v != nil && v.InternalServiceError != nil
 }
 
-// GetServiceBusyError returns the value of ServiceBusyError if it is set or its
-// zero value if it is unset.
-func (v *MatchingService_AddDecisionTask_Result) GetServiceBusyError() (o *shared.ServiceBusyError) {
-	if v != nil && v.ServiceBusyError != nil {
-		return v.ServiceBusyError
-	}
-
-	return
-}
-
-// IsSetServiceBusyError returns true if ServiceBusyError is not nil.
-func (v *MatchingService_AddDecisionTask_Result) IsSetServiceBusyError() bool {
-	return v != nil && v.ServiceBusyError != nil
-}
-
-// GetLimitExceededError returns the value of LimitExceededError if it is set or its
+// GetEntityNotExistError returns the value of EntityNotExistError if it is set or its
 // zero value if it is unset.
-func (v *MatchingService_AddDecisionTask_Result) GetLimitExceededError() (o *shared.LimitExceededError) {
-	if v != nil && v.LimitExceededError != nil {
-		return v.LimitExceededError
+func (v *MatchingService_DescribeTaskList_Result) GetEntityNotExistError() (o *shared.EntityNotExistsError) {
+	if v != nil && v.EntityNotExistError != nil {
+		return v.EntityNotExistError
 	}
 
 	return
 }
 
-// IsSetLimitExceededError returns true if LimitExceededError is not nil.
-func (v *MatchingService_AddDecisionTask_Result) IsSetLimitExceededError() bool {
-	return v != nil && v.LimitExceededError != nil
+// IsSetEntityNotExistError returns true if EntityNotExistError is not nil.
+func (v *MatchingService_DescribeTaskList_Result) IsSetEntityNotExistError() bool {
+	return v != nil && v.EntityNotExistError != nil
 }
 
-// GetDomainNotActiveError returns the value of DomainNotActiveError if it is set or its
+// GetServiceBusyError returns the value of ServiceBusyError if it is set or its
 // zero value if it is unset.
-func (v *MatchingService_AddDecisionTask_Result) GetDomainNotActiveError() (o *shared.DomainNotActiveError) {
-	if v != nil && v.DomainNotActiveError != nil {
-		return v.DomainNotActiveError
+func (v *MatchingService_DescribeTaskList_Result) GetServiceBusyError() (o *shared.ServiceBusyError) {
+	if v != nil && v.ServiceBusyError != nil {
+		return v.ServiceBusyError
 	}
 
 	return
 }
 
-// IsSetDomainNotActiveError returns true if DomainNotActiveError is not nil.
-func (v *MatchingService_AddDecisionTask_Result) IsSetDomainNotActiveError() bool {
-	return v != nil && v.DomainNotActiveError != nil
+// IsSetServiceBusyError returns true if ServiceBusyError is not nil.
+func (v *MatchingService_DescribeTaskList_Result) IsSetServiceBusyError() bool {
+	return v != nil && v.ServiceBusyError != nil
 }
 
 // MethodName returns the name of the Thrift function as specified in
 // the IDL, for which this struct represent the result.
 //
-// This will always be "AddDecisionTask" for this struct.
-func (v *MatchingService_AddDecisionTask_Result) MethodName() string {
-	return "AddDecisionTask"
+// This will always be "DescribeTaskList" for this struct.
+func (v *MatchingService_DescribeTaskList_Result) MethodName() string {
+	return "DescribeTaskList"
 }
 
 // EnvelopeType returns the kind of value inside this struct.
 //
 // This will always be Reply for this struct.
-func (v *MatchingService_AddDecisionTask_Result) EnvelopeType() wire.EnvelopeType {
+func (v *MatchingService_DescribeTaskList_Result) EnvelopeType() wire.EnvelopeType {
 	return wire.Reply
 }
 
-// MatchingService_CancelOutstandingPoll_Args represents the arguments for the MatchingService.CancelOutstandingPoll function.
+// MatchingService_GetTaskListScalingHints_Args represents the arguments for the MatchingService.GetTaskListScalingHints function.
 //
-// The arguments for CancelOutstandingPoll are sent and received over the wire as this struct.
-type MatchingService_CancelOutstandingPoll_Args struct {
-	Request *CancelOutstandingPollRequest `json:"request,omitempty"`
+// The arguments for GetTaskListScalingHints are sent and received over the wire as this struct.
+type MatchingService_GetTaskListScalingHints_Args struct {
+	Request *GetTaskListScalingHintsRequest `json:"request,omitempty"`
 }
 
-// ToWire translates a MatchingService_CancelOutstandingPoll_Args struct into a Thrift-level intermediate
+// ToWire translates a MatchingService_GetTaskListScalingHints_Args struct into a Thrift-level intermediate
 // representation. This intermediate representation may be serialized
 // into bytes using a ThriftRW protocol implementation.
 //
@@ -4312,7 +7061,7 @@ type MatchingService_CancelOutstandingPoll_Args struct {
 //   if err := binaryProtocol.Encode(x, writer); err != nil {
 //     return err
 //   }
-func (v *MatchingService_CancelOutstandingPoll_Args) ToWire() (wire.Value, error) {
+func (v *MatchingService_GetTaskListScalingHints_Args) ToWire() (wire.Value, error) {
 	var (
 		fields [1]wire.Field
 		i      int = 0
@@ -4332,17 +7081,17 @@ func (v *MatchingService_CancelOutstandingPoll_Args) ToWire() (wire.Value, error
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
 
-func _CancelOutstandingPollRequest_Read(w wire.Value) (*CancelOutstandingPollRequest, error) {
-	var v CancelOutstandingPollRequest
+func _GetTaskListScalingHintsRequest_1_Read(w wire.Value) (*GetTaskListScalingHintsRequest, error) {
+	var v GetTaskListScalingHintsRequest
 	err := v.FromWire(w)
 	return &v, err
 }
 
-// FromWire deserializes a MatchingService_CancelOutstandingPoll_Args struct from its Thrift-level
+// FromWire deserializes a MatchingService_GetTaskListScalingHints_Args struct from its Thrift-level
 // representation. The Thrift-level representation may be obtained
 // from a ThriftRW protocol implementation.
 //
-// An error is returned if we were unable to build a MatchingService_CancelOutstandingPoll_Args struct
+// An error is returned if we were unable to build a MatchingService_GetTaskListScalingHints_Args struct
 // from the provided intermediate representation.
 //
 //   x, err := binaryProtocol.Decode(reader, wire.TStruct)
@@ -4350,19 +7099,19 @@ func _CancelOutstandingPollRequest_Read(w wire.Value) (*CancelOutstandingPollReq
 //     return nil, err
 //   }
 //
-//   var v MatchingService_CancelOutstandingPoll_Args
+//   var v MatchingService_GetTaskListScalingHints_Args
 //   if err := v.FromWire(x); err != nil {
 //     return nil, err
 //   }
 //   return &v, nil
-func (v *MatchingService_CancelOutstandingPoll_Args) FromWire(w wire.Value) error {
+func (v *MatchingService_GetTaskListScalingHints_Args) FromWire(w wire.Value) error {
 	var err error
 
 	for _, field := range w.GetStruct().Fields {
 		switch field.ID {
 		case 1:
 			if field.Value.Type() == wire.TStruct {
-				v.Request, err = _CancelOutstandingPollRequest_Read(field.Value)
+				v.Request, err = _GetTaskListScalingHintsRequest_1_Read(field.Value)
 				if err != nil {
 					return err
 				}
@@ -4374,9 +7123,9 @@ func (v *MatchingService_CancelOutstandingPoll_Args) FromWire(w wire.Value) erro
 	return nil
 }
 
-// String returns a readable string representation of a MatchingService_CancelOutstandingPoll_Args
+// String returns a readable string representation of a MatchingService_GetTaskListScalingHints_Args
 // struct.
-func (v *MatchingService_CancelOutstandingPoll_Args) String() string {
+func (v *MatchingService_GetTaskListScalingHints_Args) String() string {
 	if v == nil {
 		return "<nil>"
 	}
@@ -4388,14 +7137,14 @@ func (v *MatchingService_CancelOutstandingPoll_Args) String() string {
 		i++
 	}
 
-	return fmt.Sprintf("MatchingService_CancelOutstandingPoll_Args{%v}", strings.Join(fields[:i], ", "))
+	return fmt.Sprintf("MatchingService_GetTaskListScalingHints_Args{%v}", strings.Join(fields[:i], ", "))
 }
 
-// Equals returns true if all the fields of this MatchingService_CancelOutstandingPoll_Args match the
-// provided MatchingService_CancelOutstandingPoll_Args.
+// Equals returns true if all the fields of this MatchingService_GetTaskListScalingHints_Args match the
+// provided MatchingService_GetTaskListScalingHints_Args.
 //
 // This function performs a deep comparison.
-func (v *MatchingService_CancelOutstandingPoll_Args) Equals(rhs *MatchingService_CancelOutstandingPoll_Args) bool {
+func (v *MatchingService_GetTaskListScalingHints_Args) Equals(rhs *MatchingService_GetTaskListScalingHints_Args) bool {
 	if v == nil {
 		return rhs == nil
 	} else if rhs == nil {
@@ -4409,8 +7158,8 @@ func (v *MatchingService_CancelOutstandingPoll_Args) Equals(rhs *MatchingService
 }
 
 // MarshalLogObject implements zapcore.ObjectMarshaler, enabling
-// fast logging of MatchingService_CancelOutstandingPoll_Args.
-func (v *MatchingService_CancelOutstandingPoll_Args) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+// fast logging of MatchingService_GetTaskListScalingHints_Args.
+func (v *MatchingService_GetTaskListScalingHints_Args) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
 	if v == nil {
 		return nil
 	}
@@ -4422,7 +7171,7 @@ func (v *MatchingService_CancelOutstandingPoll_Args) MarshalLogObject(enc zapcor
 
 // GetRequest returns the value of Request if it is set or its
 // zero value if it is unset.
-func (v *MatchingService_CancelOutstandingPoll_Args) GetRequest() (o *CancelOutstandingPollRequest) {
+func (v *MatchingService_GetTaskListScalingHints_Args) GetRequest() (o *GetTaskListScalingHintsRequest) {
 	if v != nil && v.Request != nil {
 		return v.Request
 	}
@@ -4431,86 +7180,87 @@ func (v *MatchingService_CancelOutstandingPoll_Args) GetRequest() (o *CancelOuts
 }
 
 // IsSetRequest returns true if Request is not nil.
-func (v *MatchingService_CancelOutstandingPoll_Args) IsSetRequest() bool {
+func (v *MatchingService_GetTaskListScalingHints_Args) IsSetRequest() bool {
 	return v != nil && v.Request != nil
 }
 
 // MethodName returns the name of the Thrift function as specified in
 // the IDL, for which this struct represent the arguments.
 //
-// This will always be "CancelOutstandingPoll" for this struct.
-func (v *MatchingService_CancelOutstandingPoll_Args) MethodName() string {
-	return "CancelOutstandingPoll"
+// This will always be "GetTaskListScalingHints" for this struct.
+func (v *MatchingService_GetTaskListScalingHints_Args) MethodName() string {
+	return "GetTaskListScalingHints"
 }
 
 // EnvelopeType returns the kind of value inside this struct.
 //
 // This will always be Call for this struct.
-func (v *MatchingService_CancelOutstandingPoll_Args) EnvelopeType() wire.EnvelopeType {
+func (v *MatchingService_GetTaskListScalingHints_Args) EnvelopeType() wire.EnvelopeType {
 	return wire.Call
 }
 
-// MatchingService_CancelOutstandingPoll_Helper provides functions that aid in handling the
-// parameters and return values of the MatchingService.CancelOutstandingPoll
+// MatchingService_GetTaskListScalingHints_Helper provides functions that aid in handling the
+// parameters and return values of the MatchingService.GetTaskListScalingHints
 // function.
-var MatchingService_CancelOutstandingPoll_Helper = struct {
-	// Args accepts the parameters of CancelOutstandingPoll in-order and returns
+var MatchingService_GetTaskListScalingHints_Helper = struct {
+	// Args accepts the parameters of GetTaskListScalingHints in-order and returns
 	// the arguments struct for the function.
 	Args func(
-		request *CancelOutstandingPollRequest,
-	) *MatchingService_CancelOutstandingPoll_Args
+		request *GetTaskListScalingHintsRequest,
+	) *MatchingService_GetTaskListScalingHints_Args
 
 	// IsException returns true if the given error can be thrown
-	// by CancelOutstandingPoll.
+	// by GetTaskListScalingHints.
 	//
-	// An error can be thrown by CancelOutstandingPoll only if the
+	// An error can be thrown by GetTaskListScalingHints only if the
 	// corresponding exception type was mentioned in the 'throws'
 	// section for it in the Thrift file.
 	IsException func(error) bool
 
-	// WrapResponse returns the result struct for CancelOutstandingPoll
-	// given the error returned by it. The provided error may
-	// be nil if CancelOutstandingPoll did not fail.
+	// WrapResponse returns the result struct for GetTaskListScalingHints
+	// given its return value and error.
 	//
-	// This allows mapping errors returned by CancelOutstandingPoll into a
-	// serializable result struct. WrapResponse returns a
-	// non-nil error if the provided error cannot be thrown by
-	// CancelOutstandingPoll
+	// This allows mapping values and errors returned by
+	// GetTaskListScalingHints into a serializable result struct.
+	// WrapResponse returns a non-nil error if the provided
+	// error cannot be thrown by GetTaskListScalingHints
 	//
-	//   err := CancelOutstandingPoll(args)
-	//   result, err := MatchingService_CancelOutstandingPoll_Helper.WrapResponse(err)
+	//   value, err := GetTaskListScalingHints(args)
+	//   result, err := MatchingService_GetTaskListScalingHints_Helper.WrapResponse(value, err)
 	//   if err != nil {
-	//     return fmt.Errorf("unexpected error from CancelOutstandingPoll: %v", err)
+	//     return fmt.Errorf("unexpected error from GetTaskListScalingHints: %v", err)
 	//   }
 	//   serialize(result)
-	WrapResponse func(error) (*MatchingService_CancelOutstandingPoll_Result, error)
+	WrapResponse func(*shared.GetTaskListScalingHintsResponse, error) (*MatchingService_GetTaskListScalingHints_Result, error)
 
-	// UnwrapResponse takes the result struct for CancelOutstandingPoll
-	// and returns the erorr returned by it (if any).
+	// UnwrapResponse takes the result struct for GetTaskListScalingHints
+	// and returns the value or error returned by it.
 	//
-	// The error is non-nil only if CancelOutstandingPoll threw an
+	// The error is non-nil only if GetTaskListScalingHints threw an
 	// exception.
 	//
 	//   result := deserialize(bytes)
-	//   err := MatchingService_CancelOutstandingPoll_Helper.UnwrapResponse(result)
-	UnwrapResponse func(*MatchingService_CancelOutstandingPoll_Result) error
+	//   value, err := MatchingService_GetTaskListScalingHints_Helper.UnwrapResponse(result)
+	UnwrapResponse func(*MatchingService_GetTaskListScalingHints_Result) (*shared.GetTaskListScalingHintsResponse, error)
 }{}
 
 func init() {
-	MatchingService_CancelOutstandingPoll_Helper.Args = func(
-		request *CancelOutstandingPollRequest,
-	) *MatchingService_CancelOutstandingPoll_Args {
-		return &MatchingService_CancelOutstandingPoll_Args{
+	MatchingService_GetTaskListScalingHints_Helper.Args = func(
+		request *GetTaskListScalingHintsRequest,
+	) *MatchingService_GetTaskListScalingHints_Args {
+		return &MatchingService_GetTaskListScalingHints_Args{
 			Request: request,
 		}
 	}
 
-	MatchingService_CancelOutstandingPoll_Helper.IsException = func(err error) bool {
+	MatchingService_GetTaskListScalingHints_Helper.IsException = func(err error) bool {
 		switch err.(type) {
 		case *shared.BadRequestError:
 			return true
 		case *shared.InternalServiceError:
 			return true
+		case *shared.EntityNotExistsError:
+			return true
 		case *shared.ServiceBusyError:
 			return true
 		default:
@@ -4518,32 +7268,37 @@ func init() {
 		}
 	}
 
-	MatchingService_CancelOutstandingPoll_Helper.WrapResponse = func(err error) (*MatchingService_CancelOutstandingPoll_Result, error) {
+	MatchingService_GetTaskListScalingHints_Helper.WrapResponse = func(success *shared.GetTaskListScalingHintsResponse, err error) (*MatchingService_GetTaskListScalingHints_Result, error) {
 		if err == nil {
-			return &MatchingService_CancelOutstandingPoll_Result{}, nil
+			return &MatchingService_GetTaskListScalingHints_Result{Success: success}, nil
 		}
 
 		switch e := err.(type) {
 		case *shared.BadRequestError:
 			if e == nil {
-				return nil, errors.New("WrapResponse received non-nil error type with nil value for MatchingService_CancelOutstandingPoll_Result.BadRequestError")
+				return nil, errors.New("WrapResponse received non-nil error type with nil value for MatchingService_GetTaskListScalingHints_Result.BadRequestError")
 			}
-			return &MatchingService_CancelOutstandingPoll_Result{BadRequestError: e}, nil
+			return &MatchingService_GetTaskListScalingHints_Result{BadRequestError: e}, nil
 		case *shared.InternalServiceError:
 			if e == nil {
-				return nil, errors.New("WrapResponse received non-nil error type with nil value for MatchingService_CancelOutstandingPoll_Result.InternalServiceError")
+				return nil, errors.New("WrapResponse received non-nil error type with nil value for MatchingService_GetTaskListScalingHints_Result.InternalServiceError")
 			}
-			return &MatchingService_CancelOutstandingPoll_Result{InternalServiceError: e}, nil
+			return &MatchingService_GetTaskListScalingHints_Result{InternalServiceError: e}, nil
+		case *shared.EntityNotExistsError:
+			if e == nil {
+				return nil, errors.New("WrapResponse received non-nil error type with nil value for MatchingService_GetTaskListScalingHints_Result.EntityNotExistError")
+			}
+			return &MatchingService_GetTaskListScalingHints_Result{EntityNotExistError: e}, nil
 		case *shared.ServiceBusyError:
 			if e == nil {
-				return nil, errors.New("WrapResponse received non-nil error type with nil value for MatchingService_CancelOutstandingPoll_Result.ServiceBusyError")
+				return nil, errors.New("WrapResponse received non-nil error type with nil value for MatchingService_GetTaskListScalingHints_Result.ServiceBusyError")
 			}
-			return &MatchingService_CancelOutstandingPoll_Result{ServiceBusyError: e}, nil
+			return &MatchingService_GetTaskListScalingHints_Result{ServiceBusyError: e}, nil
 		}
 
 		return nil, err
 	}
-	MatchingService_CancelOutstandingPoll_Helper.UnwrapResponse = func(result *MatchingService_CancelOutstandingPoll_Result) (err error) {
+	MatchingService_GetTaskListScalingHints_Helper.UnwrapResponse = func(result *MatchingService_GetTaskListScalingHints_Result) (success *shared.GetTaskListScalingHintsResponse, err error) {
 		if result.BadRequestError != nil {
 			err = result.BadRequestError
 			return
@@ -4552,25 +7307,41 @@ func init() {
 			err = result.InternalServiceError
 			return
 		}
+		if result.EntityNotExistError != nil {
+			err = result.EntityNotExistError
+			return
+		}
 		if result.ServiceBusyError != nil {
 			err = result.ServiceBusyError
 			return
 		}
+
+		if result.Success != nil {
+			success = result.Success
+			return
+		}
+
+		err = errors.New("expected a non-void result")
 		return
 	}
 
 }
 
-// MatchingService_CancelOutstandingPoll_Result represents the result of a MatchingService.CancelOutstandingPoll function call.
+// MatchingService_GetTaskListScalingHints_Result represents the result of a MatchingService.GetTaskListScalingHints function call.
 //
-// The result of a CancelOutstandingPoll execution is sent and received over the wire as this struct.
-type MatchingService_CancelOutstandingPoll_Result struct {
-	BadRequestError      *shared.BadRequestError      `json:"badRequestError,omitempty"`
-	InternalServiceError *shared.InternalServiceError `json:"internalServiceError,omitempty"`
-	ServiceBusyError     *shared.ServiceBusyError     `json:"serviceBusyError,omitempty"`
+// The result of a GetTaskListScalingHints execution is sent and received over the wire as this struct.
+//
+// Success is set only if the function did not throw an exception.
+type MatchingService_GetTaskListScalingHints_Result struct {
+	// Value returned by GetTaskListScalingHints after a successful execution.
+	Success              *shared.GetTaskListScalingHintsResponse `json:"success,omitempty"`
+	BadRequestError      *shared.BadRequestError          `json:"badRequestError,omitempty"`
+	InternalServiceError *shared.InternalServiceError     `json:"internalServiceError,omitempty"`
+	EntityNotExistError  *shared.EntityNotExistsError     `json:"entityNotExistError,omitempty"`
+	ServiceBusyError     *shared.ServiceBusyError         `json:"serviceBusyError,omitempty"`
 }
 
-// ToWire translates a MatchingService_CancelOutstandingPoll_Result struct into a Thrift-level intermediate
+// ToWire translates a MatchingService_GetTaskListScalingHints_Result struct into a Thrift-level intermediate
 // representation. This intermediate representation may be serialized
 // into bytes using a ThriftRW protocol implementation.
 //
@@ -4585,14 +7356,22 @@ type MatchingService_CancelOutstandingPoll_Result struct {
 //   if err := binaryProtocol.Encode(x, writer); err != nil {
 //     return err
 //   }
-func (v *MatchingService_CancelOutstandingPoll_Result) ToWire() (wire.Value, error) {
+func (v *MatchingService_GetTaskListScalingHints_Result) ToWire() (wire.Value, error) {
 	var (
-		fields [3]wire.Field
+		fields [5]wire.Field
 		i      int = 0
 		w      wire.Value
 		err    error
 	)
 
+	if v.Success != nil {
+		w, err = v.Success.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 0, Value: w}
+		i++
+	}
 	if v.BadRequestError != nil {
 		w, err = v.BadRequestError.ToWire()
 		if err != nil {
@@ -4609,27 +7388,41 @@ func (v *MatchingService_CancelOutstandingPoll_Result) ToWire() (wire.Value, err
 		fields[i] = wire.Field{ID: 2, Value: w}
 		i++
 	}
+	if v.EntityNotExistError != nil {
+		w, err = v.EntityNotExistError.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 3, Value: w}
+		i++
+	}
 	if v.ServiceBusyError != nil {
 		w, err = v.ServiceBusyError.ToWire()
 		if err != nil {
 			return w, err
 		}
-		fields[i] = wire.Field{ID: 3, Value: w}
+		fields[i] = wire.Field{ID: 4, Value: w}
 		i++
 	}
 
-	if i > 1 {
-		return wire.Value{}, fmt.Errorf("MatchingService_CancelOutstandingPoll_Result should have at most one field: got %v fields", i)
+	if i != 1 {
+		return wire.Value{}, fmt.Errorf("MatchingService_GetTaskListScalingHints_Result should have exactly one field: got %v fields", i)
 	}
 
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
 
-// FromWire deserializes a MatchingService_CancelOutstandingPoll_Result struct from its Thrift-level
+func _GetTaskListScalingHintsResponse_Read(w wire.Value) (*shared.GetTaskListScalingHintsResponse, error) {
+	var v shared.GetTaskListScalingHintsResponse
+	err := v.FromWire(w)
+	return &v, err
+}
+
+// FromWire deserializes a MatchingService_GetTaskListScalingHints_Result struct from its Thrift-level
 // representation. The Thrift-level representation may be obtained
 // from a ThriftRW protocol implementation.
 //
-// An error is returned if we were unable to build a MatchingService_CancelOutstandingPoll_Result struct
+// An error is returned if we were unable to build a MatchingService_GetTaskListScalingHints_Result struct
 // from the provided intermediate representation.
 //
 //   x, err := binaryProtocol.Decode(reader, wire.TStruct)
@@ -4637,16 +7430,24 @@ func (v *MatchingService_CancelOutstandingPoll_Result) ToWire() (wire.Value, err
 //     return nil, err
 //   }
 //
-//   var v MatchingService_CancelOutstandingPoll_Result
+//   var v MatchingService_GetTaskListScalingHints_Result
 //   if err := v.FromWire(x); err != nil {
 //     return nil, err
 //   }
 //   return &v, nil
-func (v *MatchingService_CancelOutstandingPoll_Result) FromWire(w wire.Value) error {
+func (v *MatchingService_GetTaskListScalingHints_Result) FromWire(w wire.Value) error {
 	var err error
 
 	for _, field := range w.GetStruct().Fields {
 		switch field.ID {
+		case 0:
+			if field.Value.Type() == wire.TStruct {
+				v.Success, err = _GetTaskListScalingHintsResponse_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
 		case 1:
 			if field.Value.Type() == wire.TStruct {
 				v.BadRequestError, err = _BadRequestError_Read(field.Value)
@@ -4664,6 +7465,14 @@ func (v *MatchingService_CancelOutstandingPoll_Result) FromWire(w wire.Value) er
 
 			}
 		case 3:
+			if field.Value.Type() == wire.TStruct {
+				v.EntityNotExistError, err = _EntityNotExistsError_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 4:
 			if field.Value.Type() == wire.TStruct {
 				v.ServiceBusyError, err = _ServiceBusyError_Read(field.Value)
 				if err != nil {
@@ -4675,31 +7484,41 @@ func (v *MatchingService_CancelOutstandingPoll_Result) FromWire(w wire.Value) er
 	}
 
 	count := 0
+	if v.Success != nil {
+		count++
+	}
 	if v.BadRequestError != nil {
 		count++
 	}
 	if v.InternalServiceError != nil {
 		count++
 	}
+	if v.EntityNotExistError != nil {
+		count++
+	}
 	if v.ServiceBusyError != nil {
 		count++
 	}
-	if count > 1 {
-		return fmt.Errorf("MatchingService_CancelOutstandingPoll_Result should have at most one field: got %v fields", count)
+	if count != 1 {
+		return fmt.Errorf("MatchingService_GetTaskListScalingHints_Result should have exactly one field: got %v fields", count)
 	}
 
 	return nil
 }
 
-// String returns a readable string representation of a MatchingService_CancelOutstandingPoll_Result
+// String returns a readable string representation of a MatchingService_GetTaskListScalingHints_Result
 // struct.
-func (v *MatchingService_CancelOutstandingPoll_Result) String() string {
+func (v *MatchingService_GetTaskListScalingHints_Result) String() string {
 	if v == nil {
 		return "<nil>"
 	}
 
-	var fields [3]string
+	var fields [5]string
 	i := 0
+	if v.Success != nil {
+		fields[i] = fmt.Sprintf("Success: %v", v.Success)
+		i++
+	}
 	if v.BadRequestError != nil {
 		fields[i] = fmt.Sprintf("BadRequestError: %v", v.BadRequestError)
 		i++
@@ -4708,30 +7527,40 @@ func (v *MatchingService_CancelOutstandingPoll_Result) String() string {
 		fields[i] = fmt.Sprintf("InternalServiceError: %v", v.InternalServiceError)
 		i++
 	}
+	if v.EntityNotExistError != nil {
+		fields[i] = fmt.Sprintf("EntityNotExistError: %v", v.EntityNotExistError)
+		i++
+	}
 	if v.ServiceBusyError != nil {
 		fields[i] = fmt.Sprintf("ServiceBusyError: %v", v.ServiceBusyError)
 		i++
 	}
 
-	return fmt.Sprintf("MatchingService_CancelOutstandingPoll_Result{%v}", strings.Join(fields[:i], ", "))
+	return fmt.Sprintf("MatchingService_GetTaskListScalingHints_Result{%v}", strings.Join(fields[:i], ", "))
 }
 
-// Equals returns true if all the fields of this MatchingService_CancelOutstandingPoll_Result match the
-// provided MatchingService_CancelOutstandingPoll_Result.
+// Equals returns true if all the fields of this MatchingService_GetTaskListScalingHints_Result match the
+// provided MatchingService_GetTaskListScalingHints_Result.
 //
 // This function performs a deep comparison.
-func (v *MatchingService_CancelOutstandingPoll_Result) Equals(rhs *MatchingService_CancelOutstandingPoll_Result) bool {
+func (v *MatchingService_GetTaskListScalingHints_Result) Equals(rhs *MatchingService_GetTaskListScalingHints_Result) bool {
 	if v == nil {
 		return rhs == nil
 	} else if rhs == nil {
 		return false
 	}
+	if !((v.Success == nil && rhs.Success == nil) || (v.Success != nil && rhs.Success != nil && v.Success.Equals(rhs.Success))) {
+		return false
+	}
 	if !((v.BadRequestError == nil && rhs.BadRequestError == nil) || (v.BadRequestError != nil && rhs.BadRequestError != nil && v.BadRequestError.Equals(rhs.BadRequestError))) {
 		return false
 	}
 	if !((v.InternalServiceError == nil && rhs.InternalServiceError == nil) || (v.InternalServiceError != nil && rhs.InternalServiceError != nil && v.InternalServiceError.Equals(rhs.InternalServiceError))) {
 		return false
 	}
+	if !((v.EntityNotExistError == nil && rhs.EntityNotExistError == nil) || (v.EntityNotExistError != nil && rhs.EntityNotExistError != nil && v.EntityNotExistError.Equals(rhs.EntityNotExistError))) {
+		return false
+	}
 	if !((v.ServiceBusyError == nil && rhs.ServiceBusyError == nil) || (v.ServiceBusyError != nil && rhs.ServiceBusyError != nil && v.ServiceBusyError.Equals(rhs.ServiceBusyError))) {
 		return false
 	}
@@ -4740,26 +7569,47 @@ func (v *MatchingService_CancelOutstandingPoll_Result) Equals(rhs *MatchingServi
 }
 
 // MarshalLogObject implements zapcore.ObjectMarshaler, enabling
-// fast logging of MatchingService_CancelOutstandingPoll_Result.
-func (v *MatchingService_CancelOutstandingPoll_Result) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+// fast logging of MatchingService_GetTaskListScalingHints_Result.
+func (v *MatchingService_GetTaskListScalingHints_Result) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
 	if v == nil {
 		return nil
 	}
+	if v.Success != nil {
+		err = multierr.Append(err, enc.AddObject("success", v.Success))
+	}
 	if v.BadRequestError != nil {
 		err = multierr.Append(err, enc.AddObject("badRequestError", v.BadRequestError))
 	}
 	if v.InternalServiceError != nil {
 		err = multierr.Append(err, enc.AddObject("internalServiceError", v.InternalServiceError))
 	}
+	if v.EntityNotExistError != nil {
+		err = multierr.Append(err, enc.AddObject("entityNotExistError", v.EntityNotExistError))
+	}
 	if v.ServiceBusyError != nil {
 		err = multierr.Append(err, enc.AddObject("serviceBusyError", v.ServiceBusyError))
 	}
 	return err
 }
 
+// GetSuccess returns the value of Success if it is set or its
+// zero value if it is unset.
+func (v *MatchingService_GetTaskListScalingHints_Result) GetSuccess() (o *shared.GetTaskListScalingHintsResponse) {
+	if v != nil && v.Success != nil {
+		return v.Success
+	}
+
+	return
+}
+
+// IsSetSuccess returns true if Success is not nil.
+func (v *MatchingService_GetTaskListScalingHints_Result) IsSetSuccess() bool {
+	return v != nil && v.Success != nil
+}
+
 // GetBadRequestError returns the value of BadRequestError if it is set or its
 // zero value if it is unset.
-func (v *MatchingService_CancelOutstandingPoll_Result) GetBadRequestError() (o *shared.BadRequestError) {
+func (v *MatchingService_GetTaskListScalingHints_Result) GetBadRequestError() (o *shared.BadRequestError) {
 	if v != nil && v.BadRequestError != nil {
 		return v.BadRequestError
 	}
@@ -4768,13 +7618,13 @@ func (v *MatchingService_CancelOutstandingPoll_Result) GetBadRequestError() (o *
 }
 
 // IsSetBadRequestError returns true if BadRequestError is not nil.
-func (v *MatchingService_CancelOutstandingPoll_Result) IsSetBadRequestError() bool {
+func (v *MatchingService_GetTaskListScalingHints_Result) IsSetBadRequestError() bool {
 	return v != nil && v.BadRequestError != nil
 }
 
 // GetInternalServiceError returns the value of InternalServiceError if it is set or its
 // zero value if it is unset.
-func (v *MatchingService_CancelOutstandingPoll_Result) GetInternalServiceError() (o *shared.InternalServiceError) {
+func (v *MatchingService_GetTaskListScalingHints_Result) GetInternalServiceError() (o *shared.InternalServiceError) {
 	if v != nil && v.InternalServiceError != nil {
 		return v.InternalServiceError
 	}
@@ -4783,13 +7633,28 @@ func (v *MatchingService_CancelOutstandingPoll_Result) GetInternalServiceError()
 }
 
 // IsSetInternalServiceError returns true if InternalServiceError is not nil.
-func (v *MatchingService_CancelOutstandingPoll_Result) IsSetInternalServiceError() bool {
+func (v *MatchingService_GetTaskListScalingHints_Result) IsSetInternalServiceError() bool {
 	return v != nil && v.InternalServiceError != nil
 }
 
+// GetEntityNotExistError returns the value of EntityNotExistError if it is set or its
+// zero value if it is unset.
+func (v *MatchingService_GetTaskListScalingHints_Result) GetEntityNotExistError() (o *shared.EntityNotExistsError) {
+	if v != nil && v.EntityNotExistError != nil {
+		return v.EntityNotExistError
+	}
+
+	return
+}
+
+// IsSetEntityNotExistError returns true if EntityNotExistError is not nil.
+func (v *MatchingService_GetTaskListScalingHints_Result) IsSetEntityNotExistError() bool {
+	return v != nil && v.EntityNotExistError != nil
+}
+
 // GetServiceBusyError returns the value of ServiceBusyError if it is set or its
 // zero value if it is unset.
-func (v *MatchingService_CancelOutstandingPoll_Result) GetServiceBusyError() (o *shared.ServiceBusyError) {
+func (v *MatchingService_GetTaskListScalingHints_Result) GetServiceBusyError() (o *shared.ServiceBusyError) {
 	if v != nil && v.ServiceBusyError != nil {
 		return v.ServiceBusyError
 	}
@@ -4798,33 +7663,33 @@ func (v *MatchingService_CancelOutstandingPoll_Result) GetServiceBusyError() (o
 }
 
 // IsSetServiceBusyError returns true if ServiceBusyError is not nil.
-func (v *MatchingService_CancelOutstandingPoll_Result) IsSetServiceBusyError() bool {
+func (v *MatchingService_GetTaskListScalingHints_Result) IsSetServiceBusyError() bool {
 	return v != nil && v.ServiceBusyError != nil
 }
 
 // MethodName returns the name of the Thrift function as specified in
 // the IDL, for which this struct represent the result.
 //
-// This will always be "CancelOutstandingPoll" for this struct.
-func (v *MatchingService_CancelOutstandingPoll_Result) MethodName() string {
-	return "CancelOutstandingPoll"
+// This will always be "GetTaskListScalingHints" for this struct.
+func (v *MatchingService_GetTaskListScalingHints_Result) MethodName() string {
+	return "GetTaskListScalingHints"
 }
 
 // EnvelopeType returns the kind of value inside this struct.
 //
 // This will always be Reply for this struct.
-func (v *MatchingService_CancelOutstandingPoll_Result) EnvelopeType() wire.EnvelopeType {
+func (v *MatchingService_GetTaskListScalingHints_Result) EnvelopeType() wire.EnvelopeType {
 	return wire.Reply
 }
 
-// MatchingService_DescribeTaskList_Args represents the arguments for the MatchingService.DescribeTaskList function.
+// MatchingService_PollForActivityTask_Args represents the arguments for the MatchingService.PollForActivityTask function.
 //
-// The arguments for DescribeTaskList are sent and received over the wire as this struct.
-type MatchingService_DescribeTaskList_Args struct {
-	Request *DescribeTaskListRequest `json:"request,omitempty"`
+// The arguments for PollForActivityTask are sent and received over the wire as this struct.
+type MatchingService_PollForActivityTask_Args struct {
+	PollRequest *PollForActivityTaskRequest `json:"pollRequest,omitempty"`
 }
 
-// ToWire translates a MatchingService_DescribeTaskList_Args struct into a Thrift-level intermediate
+// ToWire translates a MatchingService_PollForActivityTask_Args struct into a Thrift-level intermediate
 // representation. This intermediate representation may be serialized
 // into bytes using a ThriftRW protocol implementation.
 //
@@ -4839,7 +7704,7 @@ type MatchingService_DescribeTaskList_Args struct {
 //   if err := binaryProtocol.Encode(x, writer); err != nil {
 //     return err
 //   }
-func (v *MatchingService_DescribeTaskList_Args) ToWire() (wire.Value, error) {
+func (v *MatchingService_PollForActivityTask_Args) ToWire() (wire.Value, error) {
 	var (
 		fields [1]wire.Field
 		i      int = 0
@@ -4847,8 +7712,8 @@ func (v *MatchingService_DescribeTaskList_Args) ToWire() (wire.Value, error) {
 		err    error
 	)
 
-	if v.Request != nil {
-		w, err = v.Request.ToWire()
+	if v.PollRequest != nil {
+		w, err = v.PollRequest.ToWire()
 		if err != nil {
 			return w, err
 		}
@@ -4859,17 +7724,17 @@ func (v *MatchingService_DescribeTaskList_Args) ToWire() (wire.Value, error) {
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
 
-func _DescribeTaskListRequest_1_Read(w wire.Value) (*DescribeTaskListRequest, error) {
-	var v DescribeTaskListRequest
+func _PollForActivityTaskRequest_1_Read(w wire.Value) (*PollForActivityTaskRequest, error) {
+	var v PollForActivityTaskRequest
 	err := v.FromWire(w)
 	return &v, err
 }
 
-// FromWire deserializes a MatchingService_DescribeTaskList_Args struct from its Thrift-level
+// FromWire deserializes a MatchingService_PollForActivityTask_Args struct from its Thrift-level
 // representation. The Thrift-level representation may be obtained
 // from a ThriftRW protocol implementation.
 //
-// An error is returned if we were unable to build a MatchingService_DescribeTaskList_Args struct
+// An error is returned if we were unable to build a MatchingService_PollForActivityTask_Args struct
 // from the provided intermediate representation.
 //
 //   x, err := binaryProtocol.Decode(reader, wire.TStruct)
@@ -4877,19 +7742,19 @@ func _DescribeTaskListRequest_1_Read(w wire.Value) (*DescribeTaskListRequest, er
 //     return nil, err
 //   }
 //
-//   var v MatchingService_DescribeTaskList_Args
+//   var v MatchingService_PollForActivityTask_Args
 //   if err := v.FromWire(x); err != nil {
 //     return nil, err
 //   }
 //   return &v, nil
-func (v *MatchingService_DescribeTaskList_Args) FromWire(w wire.Value) error {
+func (v *MatchingService_PollForActivityTask_Args) FromWire(w wire.Value) error {
 	var err error
 
 	for _, field := range w.GetStruct().Fields {
 		switch field.ID {
 		case 1:
 			if field.Value.Type() == wire.TStruct {
-				v.Request, err = _DescribeTaskListRequest_1_Read(field.Value)
+				v.PollRequest, err = _PollForActivityTaskRequest_1_Read(field.Value)
 				if err != nil {
 					return err
 				}
@@ -4901,34 +7766,34 @@ func (v *MatchingService_DescribeTaskList_Args) FromWire(w wire.Value) error {
 	return nil
 }
 
-// String returns a readable string representation of a MatchingService_DescribeTaskList_Args
+// String returns a readable string representation of a MatchingService_PollForActivityTask_Args
 // struct.
-func (v *MatchingService_DescribeTaskList_Args) String() string {
+func (v *MatchingService_PollForActivityTask_Args) String() string {
 	if v == nil {
 		return "<nil>"
 	}
 
 	var fields [1]string
 	i := 0
-	if v.Request != nil {
-		fields[i] = fmt.Sprintf("Request: %v", v.Request)
+	if v.PollRequest != nil {
+		fields[i] = fmt.Sprintf("PollRequest: %v", v.PollRequest)
 		i++
 	}
 
-	return fmt.Sprintf("MatchingService_DescribeTaskList_Args{%v}", strings.Join(fields[:i], ", "))
+	return fmt.Sprintf("MatchingService_PollForActivityTask_Args{%v}", strings.Join(fields[:i], ", "))
 }
 
-// Equals returns true if all the fields of this MatchingService_DescribeTaskList_Args match the
-// provided MatchingService_DescribeTaskList_Args.
+// Equals returns true if all the fields of this MatchingService_PollForActivityTask_Args match the
+// provided MatchingService_PollForActivityTask_Args.
 //
 // This function performs a deep comparison.
-func (v *MatchingService_DescribeTaskList_Args) Equals(rhs *MatchingService_DescribeTaskList_Args) bool {
+func (v *MatchingService_PollForActivityTask_Args) Equals(rhs *MatchingService_PollForActivityTask_Args) bool {
 	if v == nil {
 		return rhs == nil
 	} else if rhs == nil {
 		return false
 	}
-	if !((v.Request == nil && rhs.Request == nil) || (v.Request != nil && rhs.Request != nil && v.Request.Equals(rhs.Request))) {
+	if !((v.PollRequest == nil && rhs.PollRequest == nil) || (v.PollRequest != nil && rhs.PollRequest != nil && v.PollRequest.Equals(rhs.PollRequest))) {
 		return false
 	}
 
@@ -4936,108 +7801,108 @@ func (v *MatchingService_DescribeTaskList_Args) Equals(rhs *MatchingService_Desc
 }
 
 // MarshalLogObject implements zapcore.ObjectMarshaler, enabling
-// fast logging of MatchingService_DescribeTaskList_Args.
-func (v *MatchingService_DescribeTaskList_Args) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+// fast logging of MatchingService_PollForActivityTask_Args.
+func (v *MatchingService_PollForActivityTask_Args) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
 	if v == nil {
 		return nil
 	}
-	if v.Request != nil {
-		err = multierr.Append(err, enc.AddObject("request", v.Request))
+	if v.PollRequest != nil {
+		err = multierr.Append(err, enc.AddObject("pollRequest", v.PollRequest))
 	}
 	return err
 }
 
-// GetRequest returns the value of Request if it is set or its
+// GetPollRequest returns the value of PollRequest if it is set or its
 // zero value if it is unset.
-func (v *MatchingService_DescribeTaskList_Args) GetRequest() (o *DescribeTaskListRequest) {
-	if v != nil && v.Request != nil {
-		return v.Request
+func (v *MatchingService_PollForActivityTask_Args) GetPollRequest() (o *PollForActivityTaskRequest) {
+	if v != nil && v.PollRequest != nil {
+		return v.PollRequest
 	}
 
 	return
 }
 
-// IsSetRequest returns true if Request is not nil.
-func (v *MatchingService_DescribeTaskList_Args) IsSetRequest() bool {
-	return v != nil && v.Request != nil
+// IsSetPollRequest returns true if PollRequest is not nil.
+func (v *MatchingService_PollForActivityTask_Args) IsSetPollRequest() bool {
+	return v != nil && v.PollRequest != nil
 }
 
 // MethodName returns the name of the Thrift function as specified in
 // the IDL, for which this struct represent the arguments.
 //
-// This will always be "DescribeTaskList" for this struct.
-func (v *MatchingService_DescribeTaskList_Args) MethodName() string {
-	return "DescribeTaskList"
+// This will always be "PollForActivityTask" for this struct.
+func (v *MatchingService_PollForActivityTask_Args) MethodName() string {
+	return "PollForActivityTask"
 }
 
 // EnvelopeType returns the kind of value inside this struct.
 //
 // This will always be Call for this struct.
-func (v *MatchingService_DescribeTaskList_Args) EnvelopeType() wire.EnvelopeType {
+func (v *MatchingService_PollForActivityTask_Args) EnvelopeType() wire.EnvelopeType {
 	return wire.Call
 }
 
-// MatchingService_DescribeTaskList_Helper provides functions that aid in handling the
-// parameters and return values of the MatchingService.DescribeTaskList
+// MatchingService_PollForActivityTask_Helper provides functions that aid in handling the
+// parameters and return values of the MatchingService.PollForActivityTask
 // function.
-var MatchingService_DescribeTaskList_Helper = struct {
-	// Args accepts the parameters of DescribeTaskList in-order and returns
+var MatchingService_PollForActivityTask_Helper = struct {
+	// Args accepts the parameters of PollForActivityTask in-order and returns
 	// the arguments struct for the function.
 	Args func(
-		request *DescribeTaskListRequest,
-	) *MatchingService_DescribeTaskList_Args
+		pollRequest *PollForActivityTaskRequest,
+	) *MatchingService_PollForActivityTask_Args
 
 	// IsException returns true if the given error can be thrown
-	// by DescribeTaskList.
+	// by PollForActivityTask.
 	//
-	// An error can be thrown by DescribeTaskList only if the
+	// An error can be thrown by PollForActivityTask only if the
 	// corresponding exception type was mentioned in the 'throws'
 	// section for it in the Thrift file.
 	IsException func(error) bool
 
-	// WrapResponse returns the result struct for DescribeTaskList
+	// WrapResponse returns the result struct for PollForActivityTask
 	// given its return value and error.
 	//
 	// This allows mapping values and errors returned by
-	// DescribeTaskList into a serializable result struct.
+	// PollForActivityTask into a serializable result struct.
 	// WrapResponse returns a non-nil error if the provided
-	// error cannot be thrown by DescribeTaskList
+	// error cannot be thrown by PollForActivityTask
 	//
-	//   value, err := DescribeTaskList(args)
-	//   result, err := MatchingService_DescribeTaskList_Helper.WrapResponse(value, err)
+	//   value, err := PollForActivityTask(args)
+	//   result, err := MatchingService_PollForActivityTask_Helper.WrapResponse(value, err)
 	//   if err != nil {
-	//     return fmt.Errorf("unexpected error from DescribeTaskList: %v", err)
+	//     return fmt.Errorf("unexpected error from PollForActivityTask: %v", err)
 	//   }
 	//   serialize(result)
-	WrapResponse func(*shared.DescribeTaskListResponse, error) (*MatchingService_DescribeTaskList_Result, error)
+	WrapResponse func(*shared.PollForActivityTaskResponse, error) (*MatchingService_PollForActivityTask_Result, error)
 
-	// UnwrapResponse takes the result struct for DescribeTaskList
+	// UnwrapResponse takes the result struct for PollForActivityTask
 	// and returns the value or error returned by it.
 	//
-	// The error is non-nil only if DescribeTaskList threw an
+	// The error is non-nil only if PollForActivityTask threw an
 	// exception.
 	//
 	//   result := deserialize(bytes)
-	//   value, err := MatchingService_DescribeTaskList_Helper.UnwrapResponse(result)
-	UnwrapResponse func(*MatchingService_DescribeTaskList_Result) (*shared.DescribeTaskListResponse, error)
+	//   value, err := MatchingService_PollForActivityTask_Helper.UnwrapResponse(result)
+	UnwrapResponse func(*MatchingService_PollForActivityTask_Result) (*shared.PollForActivityTaskResponse, error)
 }{}
 
 func init() {
-	MatchingService_DescribeTaskList_Helper.Args = func(
-		request *DescribeTaskListRequest,
-	) *MatchingService_DescribeTaskList_Args {
-		return &MatchingService_DescribeTaskList_Args{
-			Request: request,
+	MatchingService_PollForActivityTask_Helper.Args = func(
+		pollRequest *PollForActivityTaskRequest,
+	) *MatchingService_PollForActivityTask_Args {
+		return &MatchingService_PollForActivityTask_Args{
+			PollRequest: pollRequest,
 		}
 	}
 
-	MatchingService_DescribeTaskList_Helper.IsException = func(err error) bool {
+	MatchingService_PollForActivityTask_Helper.IsException = func(err error) bool {
 		switch err.(type) {
 		case *shared.BadRequestError:
 			return true
 		case *shared.InternalServiceError:
 			return true
-		case *shared.EntityNotExistsError:
+		case *shared.LimitExceededError:
 			return true
 		case *shared.ServiceBusyError:
 			return true
@@ -5046,37 +7911,37 @@ func init() {
 		}
 	}
 
-	MatchingService_DescribeTaskList_Helper.WrapResponse = func(success *shared.DescribeTaskListResponse, err error) (*MatchingService_DescribeTaskList_Result, error) {
+	MatchingService_PollForActivityTask_Helper.WrapResponse = func(success *shared.PollForActivityTaskResponse, err error) (*MatchingService_PollForActivityTask_Result, error) {
 		if err == nil {
-			return &MatchingService_DescribeTaskList_Result{Success: success}, nil
+			return &MatchingService_PollForActivityTask_Result{Success: success}, nil
 		}
 
 		switch e := err.(type) {
 		case *shared.BadRequestError:
 			if e == nil {
-				return nil, errors.New("WrapResponse received non-nil error type with nil value for MatchingService_DescribeTaskList_Result.BadRequestError")
+				return nil, errors.New("WrapResponse received non-nil error type with nil value for MatchingService_PollForActivityTask_Result.BadRequestError")
 			}
-			return &MatchingService_DescribeTaskList_Result{BadRequestError: e}, nil
+			return &MatchingService_PollForActivityTask_Result{BadRequestError: e}, nil
 		case *shared.InternalServiceError:
 			if e == nil {
-				return nil, errors.New("WrapResponse received non-nil error type with nil value for MatchingService_DescribeTaskList_Result.InternalServiceError")
+				return nil, errors.New("WrapResponse received non-nil error type with nil value for MatchingService_PollForActivityTask_Result.InternalServiceError")
 			}
-			return &MatchingService_DescribeTaskList_Result{InternalServiceError: e}, nil
-		case *shared.EntityNotExistsError:
+			return &MatchingService_PollForActivityTask_Result{InternalServiceError: e}, nil
+		case *shared.LimitExceededError:
 			if e == nil {
-				return nil, errors.New("WrapResponse received non-nil error type with nil value for MatchingService_DescribeTaskList_Result.EntityNotExistError")
+				return nil, errors.New("WrapResponse received non-nil error type with nil value for MatchingService_PollForActivityTask_Result.LimitExceededError")
 			}
-			return &MatchingService_DescribeTaskList_Result{EntityNotExistError: e}, nil
+			return &MatchingService_PollForActivityTask_Result{LimitExceededError: e}, nil
 		case *shared.ServiceBusyError:
 			if e == nil {
-				return nil, errors.New("WrapResponse received non-nil error type with nil value for MatchingService_DescribeTaskList_Result.ServiceBusyError")
+				return nil, errors.New("WrapResponse received non-nil error type with nil value for MatchingService_PollForActivityTask_Result.ServiceBusyError")
 			}
-			return &MatchingService_DescribeTaskList_Result{ServiceBusyError: e}, nil
+			return &MatchingService_PollForActivityTask_Result{ServiceBusyError: e}, nil
 		}
 
 		return nil, err
 	}
-	MatchingService_DescribeTaskList_Helper.UnwrapResponse = func(result *MatchingService_DescribeTaskList_Result) (success *shared.DescribeTaskListResponse, err error) {
+	MatchingService_PollForActivityTask_Helper.UnwrapResponse = func(result *MatchingService_PollForActivityTask_Result) (success *shared.PollForActivityTaskResponse, err error) {
 		if result.BadRequestError != nil {
 			err = result.BadRequestError
 			return
@@ -5085,8 +7950,8 @@ func init() {
 			err = result.InternalServiceError
 			return
 		}
-		if result.EntityNotExistError != nil {
-			err = result.EntityNotExistError
+		if result.LimitExceededError != nil {
+			err = result.LimitExceededError
 			return
 		}
 		if result.ServiceBusyError != nil {
@@ -5105,21 +7970,21 @@ func init() {
 
 }
 
-// MatchingService_DescribeTaskList_Result represents the result of a MatchingService.DescribeTaskList function call.
+// MatchingService_PollForActivityTask_Result represents the result of a MatchingService.PollForActivityTask function call.
 //
-// The result of a DescribeTaskList execution is sent and received over the wire as this struct.
+// The result of a PollForActivityTask execution is sent and received over the wire as this struct.
 //
 // Success is set only if the function did not throw an exception.
-type MatchingService_DescribeTaskList_Result struct {
-	// Value returned by DescribeTaskList after a successful execution.
-	Success              *shared.DescribeTaskListResponse `json:"success,omitempty"`
-	BadRequestError      *shared.BadRequestError          `json:"badRequestError,omitempty"`
-	InternalServiceError *shared.InternalServiceError     `json:"internalServiceError,omitempty"`
-	EntityNotExistError  *shared.EntityNotExistsError     `json:"entityNotExistError,omitempty"`
-	ServiceBusyError     *shared.ServiceBusyError         `json:"serviceBusyError,omitempty"`
+type MatchingService_PollForActivityTask_Result struct {
+	// Value returned by PollForActivityTask after a successful execution.
+	Success              *shared.PollForActivityTaskResponse `json:"success,omitempty"`
+	BadRequestError      *shared.BadRequestError             `json:"badRequestError,omitempty"`
+	InternalServiceError *shared.InternalServiceError        `json:"internalServiceError,omitempty"`
+	LimitExceededError   *shared.LimitExceededError          `json:"limitExceededError,omitempty"`
+	ServiceBusyError     *shared.ServiceBusyError            `json:"serviceBusyError,omitempty"`
 }
 
-// ToWire translates a MatchingService_DescribeTaskList_Result struct into a Thrift-level intermediate
+// ToWire translates a MatchingService_PollForActivityTask_Result struct into a Thrift-level intermediate
 // representation. This intermediate representation may be serialized
 // into bytes using a ThriftRW protocol implementation.
 //
@@ -5134,7 +7999,7 @@ type MatchingService_DescribeTaskList_Result struct {
 //   if err := binaryProtocol.Encode(x, writer); err != nil {
 //     return err
 //   }
-func (v *MatchingService_DescribeTaskList_Result) ToWire() (wire.Value, error) {
+func (v *MatchingService_PollForActivityTask_Result) ToWire() (wire.Value, error) {
 	var (
 		fields [5]wire.Field
 		i      int = 0
@@ -5166,8 +8031,8 @@ func (v *MatchingService_DescribeTaskList_Result) ToWire() (wire.Value, error) {
 		fields[i] = wire.Field{ID: 2, Value: w}
 		i++
 	}
-	if v.EntityNotExistError != nil {
-		w, err = v.EntityNotExistError.ToWire()
+	if v.LimitExceededError != nil {
+		w, err = v.LimitExceededError.ToWire()
 		if err != nil {
 			return w, err
 		}
@@ -5184,29 +8049,23 @@ func (v *MatchingService_DescribeTaskList_Result) ToWire() (wire.Value, error) {
 	}
 
 	if i != 1 {
-		return wire.Value{}, fmt.Errorf("MatchingService_DescribeTaskList_Result should have exactly one field: got %v fields", i)
+		return wire.Value{}, fmt.Errorf("MatchingService_PollForActivityTask_Result should have exactly one field: got %v fields", i)
 	}
 
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
 
-func _DescribeTaskListResponse_Read(w wire.Value) (*shared.DescribeTaskListResponse, error) {
-	var v shared.DescribeTaskListResponse
-	err := v.FromWire(w)
-	return &v, err
-}
-
-func _EntityNotExistsError_Read(w wire.Value) (*shared.EntityNotExistsError, error) {
-	var v shared.EntityNotExistsError
+func _PollForActivityTaskResponse_Read(w wire.Value) (*shared.PollForActivityTaskResponse, error) {
+	var v shared.PollForActivityTaskResponse
 	err := v.FromWire(w)
 	return &v, err
 }
 
-// FromWire deserializes a MatchingService_DescribeTaskList_Result struct from its Thrift-level
+// FromWire deserializes a MatchingService_PollForActivityTask_Result struct from its Thrift-level
 // representation. The Thrift-level representation may be obtained
 // from a ThriftRW protocol implementation.
 //
-// An error is returned if we were unable to build a MatchingService_DescribeTaskList_Result struct
+// An error is returned if we were unable to build a MatchingService_PollForActivityTask_Result struct
 // from the provided intermediate representation.
 //
 //   x, err := binaryProtocol.Decode(reader, wire.TStruct)
@@ -5214,19 +8073,19 @@ func _EntityNotExistsError_Read(w wire.Value) (*shared.EntityNotExistsError, err
 //     return nil, err
 //   }
 //
-//   var v MatchingService_DescribeTaskList_Result
+//   var v MatchingService_PollForActivityTask_Result
 //   if err := v.FromWire(x); err != nil {
 //     return nil, err
 //   }
 //   return &v, nil
-func (v *MatchingService_DescribeTaskList_Result) FromWire(w wire.Value) error {
+func (v *MatchingService_PollForActivityTask_Result) FromWire(w wire.Value) error {
 	var err error
 
 	for _, field := range w.GetStruct().Fields {
 		switch field.ID {
 		case 0:
 			if field.Value.Type() == wire.TStruct {
-				v.Success, err = _DescribeTaskListResponse_Read(field.Value)
+				v.Success, err = _PollForActivityTaskResponse_Read(field.Value)
 				if err != nil {
 					return err
 				}
@@ -5250,7 +8109,7 @@ func (v *MatchingService_DescribeTaskList_Result) FromWire(w wire.Value) error {
 			}
 		case 3:
 			if field.Value.Type() == wire.TStruct {
-				v.EntityNotExistError, err = _EntityNotExistsError_Read(field.Value)
+				v.LimitExceededError, err = _LimitExceededError_Read(field.Value)
 				if err != nil {
 					return err
 				}
@@ -5277,22 +8136,22 @@ func (v *MatchingService_DescribeTaskList_Result) FromWire(w wire.Value) error {
 	if v.InternalServiceError != nil {
 		count++
 	}
-	if v.EntityNotExistError != nil {
+	if v.LimitExceededError != nil {
 		count++
 	}
 	if v.ServiceBusyError != nil {
 		count++
 	}
 	if count != 1 {
-		return fmt.Errorf("MatchingService_DescribeTaskList_Result should have exactly one field: got %v fields", count)
+		return fmt.Errorf("MatchingService_PollForActivityTask_Result should have exactly one field: got %v fields", count)
 	}
 
 	return nil
 }
 
-// String returns a readable string representation of a MatchingService_DescribeTaskList_Result
+// String returns a readable string representation of a MatchingService_PollForActivityTask_Result
 // struct.
-func (v *MatchingService_DescribeTaskList_Result) String() string {
+func (v *MatchingService_PollForActivityTask_Result) String() string {
 	if v == nil {
 		return "<nil>"
 	}
@@ -5311,8 +8170,8 @@ func (v *MatchingService_DescribeTaskList_Result) String() string {
 		fields[i] = fmt.Sprintf("InternalServiceError: %v", v.InternalServiceError)
 		i++
 	}
-	if v.EntityNotExistError != nil {
-		fields[i] = fmt.Sprintf("EntityNotExistError: %v", v.EntityNotExistError)
+	if v.LimitExceededError != nil {
+		fields[i] = fmt.Sprintf("LimitExceededError: %v", v.LimitExceededError)
 		i++
 	}
 	if v.ServiceBusyError != nil {
@@ -5320,14 +8179,14 @@ func (v *MatchingService_DescribeTaskList_Result) String() string {
 		i++
 	}
 
-	return fmt.Sprintf("MatchingService_DescribeTaskList_Result{%v}", strings.Join(fields[:i], ", "))
+	return fmt.Sprintf("MatchingService_PollForActivityTask_Result{%v}", strings.Join(fields[:i], ", "))
 }
 
-// Equals returns true if all the fields of this MatchingService_DescribeTaskList_Result match the
-// provided MatchingService_DescribeTaskList_Result.
+// Equals returns true if all the fields of this MatchingService_PollForActivityTask_Result match the
+// provided MatchingService_PollForActivityTask_Result.
 //
 // This function performs a deep comparison.
-func (v *MatchingService_DescribeTaskList_Result) Equals(rhs *MatchingService_DescribeTaskList_Result) bool {
+func (v *MatchingService_PollForActivityTask_Result) Equals(rhs *MatchingService_PollForActivityTask_Result) bool {
 	if v == nil {
 		return rhs == nil
 	} else if rhs == nil {
@@ -5342,7 +8201,7 @@ func (v *MatchingService_DescribeTaskList_Result) Equals(rhs *MatchingService_De
 	if !((v.InternalServiceError == nil && rhs.InternalServiceError == nil) || (v.InternalServiceError != nil && rhs.InternalServiceError != nil && v.InternalServiceError.Equals(rhs.InternalServiceError))) {
 		return false
 	}
-	if !((v.EntityNotExistError == nil && rhs.EntityNotExistError == nil) || (v.EntityNotExistError != nil && rhs.EntityNotExistError != nil && v.EntityNotExistError.Equals(rhs.EntityNotExistError))) {
+	if !((v.LimitExceededError == nil && rhs.LimitExceededError == nil) || (v.LimitExceededError != nil && rhs.LimitExceededError != nil && v.LimitExceededError.Equals(rhs.LimitExceededError))) {
 		return false
 	}
 	if !((v.ServiceBusyError == nil && rhs.ServiceBusyError == nil) || (v.ServiceBusyError != nil && rhs.ServiceBusyError != nil && v.ServiceBusyError.Equals(rhs.ServiceBusyError))) {
@@ -5353,8 +8212,8 @@ func (v *MatchingService_DescribeTaskList_Result) Equals(rhs *MatchingService_De
 }
 
 // MarshalLogObject implements zapcore.ObjectMarshaler, enabling
-// fast logging of MatchingService_DescribeTaskList_Result.
-func (v *MatchingService_DescribeTaskList_Result) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+// fast logging of MatchingService_PollForActivityTask_Result.
+func (v *MatchingService_PollForActivityTask_Result) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
 	if v == nil {
 		return nil
 	}
@@ -5367,8 +8226,8 @@ func (v *MatchingService_DescribeTaskList_Result) MarshalLogObject(enc zapcore.O
 	if v.InternalServiceError != nil {
 		err = multierr.Append(err, enc.AddObject("internalServiceError", v.InternalServiceError))
 	}
-	if v.EntityNotExistError != nil {
-		err = multierr.Append(err, enc.AddObject("entityNotExistError", v.EntityNotExistError))
+	if v.LimitExceededError != nil {
+		err = multierr.Append(err, enc.AddObject("limitExceededError", v.LimitExceededError))
 	}
 	if v.ServiceBusyError != nil {
 		err = multierr.Append(err, enc.AddObject("serviceBusyError", v.ServiceBusyError))
@@ -5378,7 +8237,7 @@ func (v *MatchingService_DescribeTaskList_Result) MarshalLogObject(enc zapcore.O
 
 // GetSuccess returns the value of Success if it is set or its
 // zero value if it is unset.
-func (v *MatchingService_DescribeTaskList_Result) GetSuccess() (o *shared.DescribeTaskListResponse) {
+func (v *MatchingService_PollForActivityTask_Result) GetSuccess() (o *shared.PollForActivityTaskResponse) {
 	if v != nil && v.Success != nil {
 		return v.Success
 	}
@@ -5387,13 +8246,13 @@ func (v *MatchingService_DescribeTaskList_Result) GetSuccess() (o *shared.Descri
 }
 
 // IsSetSuccess returns true if Success is not nil.
-func (v *MatchingService_DescribeTaskList_Result) IsSetSuccess() bool {
+func (v *MatchingService_PollForActivityTask_Result) IsSetSuccess() bool {
 	return v != nil && v.Success != nil
 }
 
 // GetBadRequestError returns the value of BadRequestError if it is set or its
 // zero value if it is unset.
-func (v *MatchingService_DescribeTaskList_Result) GetBadRequestError() (o *shared.BadRequestError) {
+func (v *MatchingService_PollForActivityTask_Result) GetBadRequestError() (o *shared.BadRequestError) {
 	if v != nil && v.BadRequestError != nil {
 		return v.BadRequestError
 	}
@@ -5402,13 +8261,13 @@ func (v *MatchingService_DescribeTaskList_Result) GetBadRequestError() (o *share
 }
 
 // IsSetBadRequestError returns true if BadRequestError is not nil.
-func (v *MatchingService_DescribeTaskList_Result) IsSetBadRequestError() bool {
+func (v *MatchingService_PollForActivityTask_Result) IsSetBadRequestError() bool {
 	return v != nil && v.BadRequestError != nil
 }
 
 // GetInternalServiceError returns the value of InternalServiceError if it is set or its
 // zero value if it is unset.
-func (v *MatchingService_DescribeTaskList_Result) GetInternalServiceError() (o *shared.InternalServiceError) {
+func (v *MatchingService_PollForActivityTask_Result) GetInternalServiceError() (o *shared.InternalServiceError) {
 	if v != nil && v.InternalServiceError != nil {
 		return v.InternalServiceError
 	}
@@ -5417,28 +8276,28 @@ func (v *MatchingService_DescribeTaskList_Result) GetInternalServiceError() (o *
 }
 
 // IsSetInternalServiceError returns true if InternalServiceError is not nil.
-func (v *MatchingService_DescribeTaskList_Result) IsSetInternalServiceError() bool {
+func (v *MatchingService_PollForActivityTask_Result) IsSetInternalServiceError() bool {
 	return v != nil && v.InternalServiceError != nil
 }
 
-// GetEntityNotExistError returns the value of EntityNotExistError if it is set or its
+// GetLimitExceededError returns the value of LimitExceededError if it is set or its
 // zero value if it is unset.
-func (v *MatchingService_DescribeTaskList_Result) GetEntityNotExistError() (o *shared.EntityNotExistsError) {
-	if v != nil && v.EntityNotExistError != nil {
-		return v.EntityNotExistError
+func (v *MatchingService_PollForActivityTask_Result) GetLimitExceededError() (o *shared.LimitExceededError) {
+	if v != nil && v.LimitExceededError != nil {
+		return v.LimitExceededError
 	}
 
 	return
 }
 
-// IsSetEntityNotExistError returns true if EntityNotExistError is not nil.
-func (v *MatchingService_DescribeTaskList_Result) IsSetEntityNotExistError() bool {
-	return v != nil && v.EntityNotExistError != nil
+// IsSetLimitExceededError returns true if LimitExceededError is not nil.
+func (v *MatchingService_PollForActivityTask_Result) IsSetLimitExceededError() bool {
+	return v != nil && v.LimitExceededError != nil
 }
 
 // GetServiceBusyError returns the value of ServiceBusyError if it is set or its
 // zero value if it is unset.
-func (v *MatchingService_DescribeTaskList_Result) GetServiceBusyError() (o *shared.ServiceBusyError) {
+func (v *MatchingService_PollForActivityTask_Result) GetServiceBusyError() (o *shared.ServiceBusyError) {
 	if v != nil && v.ServiceBusyError != nil {
 		return v.ServiceBusyError
 	}
@@ -5447,33 +8306,33 @@ func (v *MatchingService_DescribeTaskList_Result) GetServiceBusyError() (o *shar
 }
 
 // IsSetServiceBusyError returns true if ServiceBusyError is not nil.
-func (v *MatchingService_DescribeTaskList_Result) IsSetServiceBusyError() bool {
+func (v *MatchingService_PollForActivityTask_Result) IsSetServiceBusyError() bool {
 	return v != nil && v.ServiceBusyError != nil
 }
 
 // MethodName returns the name of the Thrift function as specified in
 // the IDL, for which this struct represent the result.
 //
-// This will always be "DescribeTaskList" for this struct.
-func (v *MatchingService_DescribeTaskList_Result) MethodName() string {
-	return "DescribeTaskList"
+// This will always be "PollForActivityTask" for this struct.
+func (v *MatchingService_PollForActivityTask_Result) MethodName() string {
+	return "PollForActivityTask"
 }
 
 // EnvelopeType returns the kind of value inside this struct.
 //
 // This will always be Reply for this struct.
-func (v *MatchingService_DescribeTaskList_Result) EnvelopeType() wire.EnvelopeType {
+func (v *MatchingService_PollForActivityTask_Result) EnvelopeType() wire.EnvelopeType {
 	return wire.Reply
 }
 
-// MatchingService_PollForActivityTask_Args represents the arguments for the MatchingService.PollForActivityTask function.
+// MatchingService_PollForDecisionTask_Args represents the arguments for the MatchingService.PollForDecisionTask function.
 //
-// The arguments for PollForActivityTask are sent and received over the wire as this struct.
-type MatchingService_PollForActivityTask_Args struct {
-	PollRequest *PollForActivityTaskRequest `json:"pollRequest,omitempty"`
+// The arguments for PollForDecisionTask are sent and received over the wire as this struct.
+type MatchingService_PollForDecisionTask_Args struct {
+	PollRequest *PollForDecisionTaskRequest `json:"pollRequest,omitempty"`
 }
 
-// ToWire translates a MatchingService_PollForActivityTask_Args struct into a Thrift-level intermediate
+// ToWire translates a MatchingService_PollForDecisionTask_Args struct into a Thrift-level intermediate
 // representation. This intermediate representation may be serialized
 // into bytes using a ThriftRW protocol implementation.
 //
@@ -5488,7 +8347,7 @@ type MatchingService_PollForActivityTask_Args struct {
 //   if err := binaryProtocol.Encode(x, writer); err != nil {
 //     return err
 //   }
-func (v *MatchingService_PollForActivityTask_Args) ToWire() (wire.Value, error) {
+func (v *MatchingService_PollForDecisionTask_Args) ToWire() (wire.Value, error) {
 	var (
 		fields [1]wire.Field
 		i      int = 0
@@ -5508,17 +8367,17 @@ func (v *MatchingService_PollForActivityTask_Args) ToWire() (wire.Value, error)
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
 
-func _PollForActivityTaskRequest_1_Read(w wire.Value) (*PollForActivityTaskRequest, error) {
-	var v PollForActivityTaskRequest
+func _PollForDecisionTaskRequest_1_Read(w wire.Value) (*PollForDecisionTaskRequest, error) {
+	var v PollForDecisionTaskRequest
 	err := v.FromWire(w)
 	return &v, err
 }
 
-// FromWire deserializes a MatchingService_PollForActivityTask_Args struct from its Thrift-level
+// FromWire deserializes a MatchingService_PollForDecisionTask_Args struct from its Thrift-level
 // representation. The Thrift-level representation may be obtained
 // from a ThriftRW protocol implementation.
 //
-// An error is returned if we were unable to build a MatchingService_PollForActivityTask_Args struct
+// An error is returned if we were unable to build a MatchingService_PollForDecisionTask_Args struct
 // from the provided intermediate representation.
 //
 //   x, err := binaryProtocol.Decode(reader, wire.TStruct)
@@ -5526,19 +8385,19 @@ func _PollForActivityTaskRequest_1_Read(w wire.Value) (*PollForActivityTaskReque
 //     return nil, err
 //   }
 //
-//   var v MatchingService_PollForActivityTask_Args
+//   var v MatchingService_PollForDecisionTask_Args
 //   if err := v.FromWire(x); err != nil {
 //     return nil, err
 //   }
 //   return &v, nil
-func (v *MatchingService_PollForActivityTask_Args) FromWire(w wire.Value) error {
+func (v *MatchingService_PollForDecisionTask_Args) FromWire(w wire.Value) error {
 	var err error
 
 	for _, field := range w.GetStruct().Fields {
 		switch field.ID {
 		case 1:
 			if field.Value.Type() == wire.TStruct {
-				v.PollRequest, err = _PollForActivityTaskRequest_1_Read(field.Value)
+				v.PollRequest, err = _PollForDecisionTaskRequest_1_Read(field.Value)
 				if err != nil {
 					return err
 				}
@@ -5550,9 +8409,9 @@ func (v *MatchingService_PollForActivityTask_Args) FromWire(w wire.Value) error
 	return nil
 }
 
-// String returns a readable string representation of a MatchingService_PollForActivityTask_Args
+// String returns a readable string representation of a MatchingService_PollForDecisionTask_Args
 // struct.
-func (v *MatchingService_PollForActivityTask_Args) String() string {
+func (v *MatchingService_PollForDecisionTask_Args) String() string {
 	if v == nil {
 		return "<nil>"
 	}
@@ -5564,14 +8423,14 @@ func (v *MatchingService_PollForActivityTask_Args) String() string {
 		i++
 	}
 
-	return fmt.Sprintf("MatchingService_PollForActivityTask_Args{%v}", strings.Join(fields[:i], ", "))
+	return fmt.Sprintf("MatchingService_PollForDecisionTask_Args{%v}", strings.Join(fields[:i], ", "))
 }
 
-// Equals returns true if all the fields of this MatchingService_PollForActivityTask_Args match the
-// provided MatchingService_PollForActivityTask_Args.
+// Equals returns true if all the fields of this MatchingService_PollForDecisionTask_Args match the
+// provided MatchingService_PollForDecisionTask_Args.
 //
 // This function performs a deep comparison.
-func (v *MatchingService_PollForActivityTask_Args) Equals(rhs *MatchingService_PollForActivityTask_Args) bool {
+func (v *MatchingService_PollForDecisionTask_Args) Equals(rhs *MatchingService_PollForDecisionTask_Args) bool {
 	if v == nil {
 		return rhs == nil
 	} else if rhs == nil {
@@ -5585,8 +8444,8 @@ func (v *MatchingService_PollForActivityTask_Args) Equals(rhs *MatchingService_P
 }
 
 // MarshalLogObject implements zapcore.ObjectMarshaler, enabling
-// fast logging of MatchingService_PollForActivityTask_Args.
-func (v *MatchingService_PollForActivityTask_Args) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+// fast logging of MatchingService_PollForDecisionTask_Args.
+func (v *MatchingService_PollForDecisionTask_Args) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
 	if v == nil {
 		return nil
 	}
@@ -5598,7 +8457,7 @@ func (v *MatchingService_PollForActivityTask_Args) MarshalLogObject(enc zapcore.
 
 // GetPollRequest returns the value of PollRequest if it is set or its
 // zero value if it is unset.
-func (v *MatchingService_PollForActivityTask_Args) GetPollRequest() (o *PollForActivityTaskRequest) {
+func (v *MatchingService_PollForDecisionTask_Args) GetPollRequest() (o *PollForDecisionTaskRequest) {
 	if v != nil && v.PollRequest != nil {
 		return v.PollRequest
 	}
@@ -5607,80 +8466,80 @@ func (v *MatchingService_PollForActivityTask_Args) GetPollRequest() (o *PollForA
 }
 
 // IsSetPollRequest returns true if PollRequest is not nil.
-func (v *MatchingService_PollForActivityTask_Args) IsSetPollRequest() bool {
+func (v *MatchingService_PollForDecisionTask_Args) IsSetPollRequest() bool {
 	return v != nil && v.PollRequest != nil
 }
 
 // MethodName returns the name of the Thrift function as specified in
 // the IDL, for which this struct represent the arguments.
 //
-// This will always be "PollForActivityTask" for this struct.
-func (v *MatchingService_PollForActivityTask_Args) MethodName() string {
-	return "PollForActivityTask"
+// This will always be "PollForDecisionTask" for this struct.
+func (v *MatchingService_PollForDecisionTask_Args) MethodName() string {
+	return "PollForDecisionTask"
 }
 
 // EnvelopeType returns the kind of value inside this struct.
 //
 // This will always be Call for this struct.
-func (v *MatchingService_PollForActivityTask_Args) EnvelopeType() wire.EnvelopeType {
+func (v *MatchingService_PollForDecisionTask_Args) EnvelopeType() wire.EnvelopeType {
 	return wire.Call
 }
 
-// MatchingService_PollForActivityTask_Helper provides functions that aid in handling the
-// parameters and return values of the MatchingService.PollForActivityTask
+// MatchingService_PollForDecisionTask_Helper provides functions that aid in handling the
+// parameters and return values of the MatchingService.PollForDecisionTask
 // function.
-var MatchingService_PollForActivityTask_Helper = struct {
-	// Args accepts the parameters of PollForActivityTask in-order and returns
+var MatchingService_PollForDecisionTask_Helper = struct {
+	// Args accepts the parameters of PollForDecisionTask in-order and returns
 	// the arguments struct for the function.
 	Args func(
-		pollRequest *PollForActivityTaskRequest,
-	) *MatchingService_PollForActivityTask_Args
+		pollRequest *PollForDecisionTaskRequest,
+	) *MatchingService_PollForDecisionTask_Args
 
 	// IsException returns true if the given error can be thrown
-	// by PollForActivityTask.
+	// by PollForDecisionTask.
 	//
-	// An error can be thrown by PollForActivityTask only if the
+	// An error can be thrown by PollForDecisionTask only if the
 	// corresponding exception type was mentioned in the 'throws'
 	// section for it in the Thrift file.
 	IsException func(error) bool
 
-	// WrapResponse returns the result struct for PollForActivityTask
+	// WrapResponse returns the result struct for PollForDecisionTask
 	// given its return value and error.
 	//
 	// This allows mapping values and errors returned by
-	// PollForActivityTask into a serializable result struct.
+	// PollForDecisionTask into a serializable result struct.
 	// WrapResponse returns a non-nil error if the provided
-	// error cannot be thrown by PollForActivityTask
+	// error cannot be thrown by PollForDecisionTask
 	//
-	//   value, err := PollForActivityTask(args)
-	//   result, err := MatchingService_PollForActivityTask_Helper.WrapResponse(value, err)
+	//   value, err := PollForDecisionTask(args)
+	//   result, err := MatchingService_PollForDecisionTask_Helper.WrapResponse(value, err)
 	//   if err != nil {
-	//     return fmt.Errorf("unexpected error from PollForActivityTask: %v", err)
+	//     return fmt.Errorf("unexpected error from PollForDecisionTask: %v", err)
 	//   }
 	//   serialize(result)
-	WrapResponse func(*shared.PollForActivityTaskResponse, error) (*MatchingService_PollForActivityTask_Result, error)
+	WrapResponse func(*PollForDecisionTaskResponse, error) (*MatchingService_PollForDecisionTask_Result, error)
 
-	// UnwrapResponse takes the result struct for PollForActivityTask
+	// UnwrapResponse takes the result struct for PollForDecisionTask
 	// and returns the value or error returned by it.
 	//
-	// The error is non-nil only if PollForActivityTask threw an
+	// The error is non-nil only if PollForDecisionTask threw an
 	// exception.
 	//
 	//   result := deserialize(bytes)
-	//   value, err := MatchingService_PollForActivityTask_Helper.UnwrapResponse(result)
-	UnwrapResponse func(*MatchingService_PollForActivityTask_Result) (*shared.PollForActivityTaskResponse, error)
+	//   value, err := MatchingService_PollForDecisionTask_Helper.UnwrapResponse(result)
+	UnwrapResponse func(*MatchingService_PollForDecisionTask_Result) (*PollForDecisionTaskResponse, error)
 }{}
 
 func init() {
-	MatchingService_PollForActivityTask_Helper.Args = func(
-		pollRequest *PollForActivityTaskRequest,
-	) *MatchingService_PollForActivityTask_Args {
-		return &MatchingService_PollForActivityTask_Args{
+	MatchingService_PollForDecisionTask_Helper.Args = func(
+		pollRequest *PollForDecisionTaskRequest,
+	) *MatchingService_PollForDecisionTask_Args {
+		return &MatchingService_PollForDecisionTask_Args{
 			PollRequest: pollRequest,
 		}
 	}
 
-	MatchingService_PollForActivityTask_Helper.IsException = func(err error) bool {
+	MatchingService_PollForDecisionTask_Helper.IsException = func(err error) bool {
 		switch err.(type) {
 		case *shared.BadRequestError:
 			return true
@@ -5695,37 +8554,37 @@ func init() {
 		}
 	}
 
-	MatchingService_PollForActivityTask_Helper.WrapResponse = func(success *shared.PollForActivityTaskResponse, err error) (*MatchingService_PollForActivityTask_Result, error) {
+	MatchingService_PollForDecisionTask_Helper.WrapResponse = func(success *PollForDecisionTaskResponse, err error) (*MatchingService_PollForDecisionTask_Result, error) {
 		if err == nil {
-			return &MatchingService_PollForActivityTask_Result{Success: success}, nil
+			return &MatchingService_PollForDecisionTask_Result{Success: success}, nil
 		}
 
 		switch e := err.(type) {
 		case *shared.BadRequestError:
 			if e == nil {
-				return nil, errors.New("WrapResponse received non-nil error type with nil value for MatchingService_PollForActivityTask_Result.BadRequestError")
+				return nil, errors.New("WrapResponse received non-nil error type with nil value for MatchingService_PollForDecisionTask_Result.BadRequestError")
 			}
-			return &MatchingService_PollForActivityTask_Result{BadRequestError: e}, nil
+			return &MatchingService_PollForDecisionTask_Result{BadRequestError: e}, nil
 		case *shared.InternalServiceError:
 			if e == nil {
-				return nil, errors.New("WrapResponse received non-nil error type with nil value for MatchingService_PollForActivityTask_Result.InternalServiceError")
+				return nil, errors.New("WrapResponse received non-nil error type with nil value for MatchingService_PollForDecisionTask_Result.InternalServiceError")
 			}
-			return &MatchingService_PollForActivityTask_Result{InternalServiceError: e}, nil
+			return &MatchingService_PollForDecisionTask_Result{InternalServiceError: e}, nil
 		case *shared.LimitExceededError:
 			if e == nil {
-				return nil, errors.New("WrapResponse received non-nil error type with nil value for MatchingService_PollForActivityTask_Result.LimitExceededError")
+				return nil, errors.New("WrapResponse received non-nil error type with nil value for MatchingService_PollForDecisionTask_Result.LimitExceededError")
 			}
-			return &MatchingService_PollForActivityTask_Result{LimitExceededError: e}, nil
+			return &MatchingService_PollForDecisionTask_Result{LimitExceededError: e}, nil
 		case *shared.ServiceBusyError:
 			if e == nil {
-				return nil, errors.New("WrapResponse received non-nil error type with nil value for MatchingService_PollForActivityTask_Result.ServiceBusyError")
+				return nil, errors.New("WrapResponse received non-nil error type with nil value for MatchingService_PollForDecisionTask_Result.ServiceBusyError")
 			}
-			return &MatchingService_PollForActivityTask_Result{ServiceBusyError: e}, nil
+			return &MatchingService_PollForDecisionTask_Result{ServiceBusyError: e}, nil
 		}
 
 		return nil, err
 	}
-	MatchingService_PollForActivityTask_Helper.UnwrapResponse = func(result *MatchingService_PollForActivityTask_Result) (success *shared.PollForActivityTaskResponse, err error) {
+	MatchingService_PollForDecisionTask_Helper.UnwrapResponse = func(result *MatchingService_PollForDecisionTask_Result) (success *PollForDecisionTaskResponse, err error) {
 		if result.BadRequestError != nil {
 			err = result.BadRequestError
 			return
@@ -5754,21 +8613,21 @@ func init() {
 
 }
 
-// MatchingService_PollForActivityTask_Result represents the result of a MatchingService.PollForActivityTask function call.
+// MatchingService_PollForDecisionTask_Result represents the result of a MatchingService.PollForDecisionTask function call.
 //
-// The result of a PollForActivityTask execution is sent and received over the wire as this struct.
+// The result of a PollForDecisionTask execution is sent and received over the wire as this struct.
 //
 // Success is set only if the function did not throw an exception.
-type MatchingService_PollForActivityTask_Result struct {
-	// Value returned by PollForActivityTask after a successful execution.
-	Success              *shared.PollForActivityTaskResponse `json:"success,omitempty"`
-	BadRequestError      *shared.BadRequestError             `json:"badRequestError,omitempty"`
-	InternalServiceError *shared.InternalServiceError        `json:"internalServiceError,omitempty"`
-	LimitExceededError   *shared.LimitExceededError          `json:"limitExceededError,omitempty"`
-	ServiceBusyError     *shared.ServiceBusyError            `json:"serviceBusyError,omitempty"`
+type MatchingService_PollForDecisionTask_Result struct {
+	// Value returned by PollForDecisionTask after a successful execution.
+	Success              *PollForDecisionTaskResponse `json:"success,omitempty"`
+	BadRequestError      *shared.BadRequestError      `json:"badRequestError,omitempty"`
+	InternalServiceError *shared.InternalServiceError `json:"internalServiceError,omitempty"`
+	LimitExceededError   *shared.LimitExceededError   `json:"limitExceededError,omitempty"`
+	ServiceBusyError     *shared.ServiceBusyError     `json:"serviceBusyError,omitempty"`
 }
 
-// ToWire translates a MatchingService_PollForActivityTask_Result struct into a Thrift-level intermediate
+// ToWire translates a MatchingService_PollForDecisionTask_Result struct into a Thrift-level intermediate
 // representation. This intermediate representation may be serialized
 // into bytes using a ThriftRW protocol implementation.
 //
@@ -5783,7 +8642,7 @@ type MatchingService_PollForActivityTask_Result struct {
 //   if err := binaryProtocol.Encode(x, writer); err != nil {
 //     return err
 //   }
-func (v *MatchingService_PollForActivityTask_Result) ToWire() (wire.Value, error) {
+func (v *MatchingService_PollForDecisionTask_Result) ToWire() (wire.Value, error) {
 	var (
 		fields [5]wire.Field
 		i      int = 0
@@ -5833,23 +8692,23 @@ func (v *MatchingService_PollForActivityTask_Result) ToWire() (wire.Value, error
 	}
 
 	if i != 1 {
-		return wire.Value{}, fmt.Errorf("MatchingService_PollForActivityTask_Result should have exactly one field: got %v fields", i)
+		return wire.Value{}, fmt.Errorf("MatchingService_PollForDecisionTask_Result should have exactly one field: got %v fields", i)
 	}
 
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
 
-func _PollForActivityTaskResponse_Read(w wire.Value) (*shared.PollForActivityTaskResponse, error) {
-	var v shared.PollForActivityTaskResponse
+func _PollForDecisionTaskResponse_Read(w wire.Value) (*PollForDecisionTaskResponse, error) {
+	var v PollForDecisionTaskResponse
 	err := v.FromWire(w)
 	return &v, err
 }
 
-// FromWire deserializes a MatchingService_PollForActivityTask_Result struct from its Thrift-level
+// FromWire deserializes a MatchingService_PollForDecisionTask_Result struct from its Thrift-level
 // representation. The Thrift-level representation may be obtained
 // from a ThriftRW protocol implementation.
 //
-// An error is returned if we were unable to build a MatchingService_PollForActivityTask_Result struct
+// An error is returned if we were unable to build a MatchingService_PollForDecisionTask_Result struct
 // from the provided intermediate representation.
 //
 //   x, err := binaryProtocol.Decode(reader, wire.TStruct)
@@ -5857,19 +8716,19 @@ func _PollForActivityTaskResponse_Read(w wire.Value) (*shared.PollForActivityTas
 //     return nil, err
 //   }
 //
-//   var v MatchingService_PollForActivityTask_Result
+//   var v MatchingService_PollForDecisionTask_Result
 //   if err := v.FromWire(x); err != nil {
 //     return nil, err
 //   }
 //   return &v, nil
-func (v *MatchingService_PollForActivityTask_Result) FromWire(w wire.Value) error {
+func (v *MatchingService_PollForDecisionTask_Result) FromWire(w wire.Value) error {
 	var err error
 
 	for _, field := range w.GetStruct().Fields {
 		switch field.ID {
 		case 0:
 			if field.Value.Type() == wire.TStruct {
-				v.Success, err = _PollForActivityTaskResponse_Read(field.Value)
+				v.Success, err = _PollForDecisionTaskResponse_Read(field.Value)
 				if err != nil {
 					return err
 				}
@@ -5927,15 +8786,15 @@ func (v *MatchingService_PollForActivityTask_Result) FromWire(w wire.Value) erro
 		count++
 	}
 	if count != 1 {
-		return fmt.Errorf("MatchingService_PollForActivityTask_Result should have exactly one field: got %v fields", count)
+		return fmt.Errorf("MatchingService_PollForDecisionTask_Result should have exactly one field: got %v fields", count)
 	}
 
 	return nil
 }
 
-// String returns a readable string representation of a MatchingService_PollForActivityTask_Result
+// String returns a readable string representation of a MatchingService_PollForDecisionTask_Result
 // struct.
-func (v *MatchingService_PollForActivityTask_Result) String() string {
+func (v *MatchingService_PollForDecisionTask_Result) String() string {
 	if v == nil {
 		return "<nil>"
 	}
@@ -5963,14 +8822,14 @@ func (v *MatchingService_PollForActivityTask_Result) String() string {
 		i++
 	}
 
-	return fmt.Sprintf("MatchingService_PollForActivityTask_Result{%v}", strings.Join(fields[:i], ", "))
+	return fmt.Sprintf("MatchingService_PollForDecisionTask_Result{%v}", strings.Join(fields[:i], ", "))
 }
 
-// Equals returns true if all the fields of this MatchingService_PollForActivityTask_Result match the
-// provided MatchingService_PollForActivityTask_Result.
+// Equals returns true if all the fields of this MatchingService_PollForDecisionTask_Result match the
+// provided MatchingService_PollForDecisionTask_Result.
 //
 // This function performs a deep comparison.
-func (v *MatchingService_PollForActivityTask_Result) Equals(rhs *MatchingService_PollForActivityTask_Result) bool {
+func (v *MatchingService_PollForDecisionTask_Result) Equals(rhs *MatchingService_PollForDecisionTask_Result) bool {
 	if v == nil {
 		return rhs == nil
 	} else if rhs == nil {
@@ -5996,8 +8855,8 @@ func (v *MatchingService_PollForActivityTask_Result) Equals(rhs *MatchingService
 }
 
 // MarshalLogObject implements zapcore.ObjectMarshaler, enabling
-// fast logging of MatchingService_PollForActivityTask_Result.
-func (v *MatchingService_PollForActivityTask_Result) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+// fast logging of MatchingService_PollForDecisionTask_Result.
+func (v *MatchingService_PollForDecisionTask_Result) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
 	if v == nil {
 		return nil
 	}
@@ -6021,7 +8880,7 @@ func (v *MatchingService_PollForActivityTask_Result) MarshalLogObject(enc zapcor
 
 // GetSuccess returns the value of Success if it is set or its
 // zero value if it is unset.
-func (v *MatchingService_PollForActivityTask_Result) GetSuccess() (o *shared.PollForActivityTaskResponse) {
+func (v *MatchingService_PollForDecisionTask_Result) GetSuccess() (o *PollForDecisionTaskResponse) {
 	if v != nil && v.Success != nil {
 		return v.Success
 	}
@@ -6030,13 +8889,13 @@ func (v *MatchingService_PollForActivityTask_Result) GetSuccess() (o *shared.Pol
 }
 
 // IsSetSuccess returns true if Success is not nil.
-func (v *MatchingService_PollForActivityTask_Result) IsSetSuccess() bool {
+func (v *MatchingService_PollForDecisionTask_Result) IsSetSuccess() bool {
 	return v != nil && v.Success != nil
 }
 
 // GetBadRequestError returns the value of BadRequestError if it is set or its
 // zero value if it is unset.
-func (v *MatchingService_PollForActivityTask_Result) GetBadRequestError() (o *shared.BadRequestError) {
+func (v *MatchingService_PollForDecisionTask_Result) GetBadRequestError() (o *shared.BadRequestError) {
 	if v != nil && v.BadRequestError != nil {
 		return v.BadRequestError
 	}
@@ -6045,13 +8904,13 @@ func (v *MatchingService_PollForActivityTask_Result) GetBadRequestError() (o *sh
 }
 
 // IsSetBadRequestError returns true if BadRequestError is not nil.
-func (v *MatchingService_PollForActivityTask_Result) IsSetBadRequestError() bool {
+func (v *MatchingService_PollForDecisionTask_Result) IsSetBadRequestError() bool {
 	return v != nil && v.BadRequestError != nil
 }
 
 // GetInternalServiceError returns the value of InternalServiceError if it is set or its
 // zero value if it is unset.
-func (v *MatchingService_PollForActivityTask_Result) GetInternalServiceError() (o *shared.InternalServiceError) {
+func (v *MatchingService_PollForDecisionTask_Result) GetInternalServiceError() (o *shared.InternalServiceError) {
 	if v != nil && v.InternalServiceError != nil {
 		return v.InternalServiceError
 	}
@@ -6060,13 +8919,13 @@ func (v *MatchingService_PollForActivityTask_Result) GetInternalServiceError() (
 }
 
 // IsSetInternalServiceError returns true if InternalServiceError is not nil.
-func (v *MatchingService_PollForActivityTask_Result) IsSetInternalServiceError() bool {
+func (v *MatchingService_PollForDecisionTask_Result) IsSetInternalServiceError() bool {
 	return v != nil && v.InternalServiceError != nil
 }
 
 // GetLimitExceededError returns the value of LimitExceededError if it is set or its
 // zero value if it is unset.
-func (v *MatchingService_PollForActivityTask_Result) GetLimitExceededError() (o *shared.LimitExceededError) {
+func (v *MatchingService_PollForDecisionTask_Result) GetLimitExceededError() (o *shared.LimitExceededError) {
 	if v != nil && v.LimitExceededError != nil {
 		return v.LimitExceededError
 	}
@@ -6075,13 +8934,13 @@ func (v *MatchingService_PollForActivityTask_Result) GetLimitExceededError() (o
 }
 
 // IsSetLimitExceededError returns true if LimitExceededError is not nil.
-func (v *MatchingService_PollForActivityTask_Result) IsSetLimitExceededError() bool {
+func (v *MatchingService_PollForDecisionTask_Result) IsSetLimitExceededError() bool {
 	return v != nil && v.LimitExceededError != nil
 }
 
 // GetServiceBusyError returns the value of ServiceBusyError if it is set or its
 // zero value if it is unset.
-func (v *MatchingService_PollForActivityTask_Result) GetServiceBusyError() (o *shared.ServiceBusyError) {
+func (v *MatchingService_PollForDecisionTask_Result) GetServiceBusyError() (o *shared.ServiceBusyError) {
 	if v != nil && v.ServiceBusyError != nil {
 		return v.ServiceBusyError
 	}
@@ -6090,33 +8949,33 @@ func (v *MatchingService_PollForActivityTask_Result) GetServiceBusyError() (o *s
 }
 
 // IsSetServiceBusyError returns true if ServiceBusyError is not nil.
-func (v *MatchingService_PollForActivityTask_Result) IsSetServiceBusyError() bool {
+func (v *MatchingService_PollForDecisionTask_Result) IsSetServiceBusyError() bool {
 	return v != nil && v.ServiceBusyError != nil
 }
 
 // MethodName returns the name of the Thrift function as specified in
 // the IDL, for which this struct represent the result.
 //
-// This will always be "PollForActivityTask" for this struct.
-func (v *MatchingService_PollForActivityTask_Result) MethodName() string {
-	return "PollForActivityTask"
+// This will always be "PollForDecisionTask" for this struct.
+func (v *MatchingService_PollForDecisionTask_Result) MethodName() string {
+	return "PollForDecisionTask"
 }
 
 // EnvelopeType returns the kind of value inside this struct.
 //
 // This will always be Reply for this struct.
-func (v *MatchingService_PollForActivityTask_Result) EnvelopeType() wire.EnvelopeType {
+func (v *MatchingService_PollForDecisionTask_Result) EnvelopeType() wire.EnvelopeType {
 	return wire.Reply
 }
 
-// MatchingService_PollForDecisionTask_Args represents the arguments for the MatchingService.PollForDecisionTask function.
+// MatchingService_QueryWorkflow_Args represents the arguments for the MatchingService.QueryWorkflow function.
 //
-// The arguments for PollForDecisionTask are sent and received over the wire as this struct.
-type MatchingService_PollForDecisionTask_Args struct {
-	PollRequest *PollForDecisionTaskRequest `json:"pollRequest,omitempty"`
+// The arguments for QueryWorkflow are sent and received over the wire as this struct.
+type MatchingService_QueryWorkflow_Args struct {
+	QueryRequest *QueryWorkflowRequest `json:"queryRequest,omitempty"`
 }
 
-// ToWire translates a MatchingService_PollForDecisionTask_Args struct into a Thrift-level intermediate
+// ToWire translates a MatchingService_QueryWorkflow_Args struct into a Thrift-level intermediate
 // representation. This intermediate representation may be serialized
 // into bytes using a ThriftRW protocol implementation.
 //
@@ -6131,7 +8990,7 @@ type MatchingService_PollForDecisionTask_Args struct {
 //   if err := binaryProtocol.Encode(x, writer); err != nil {
 //     return err
 //   }
-func (v *MatchingService_PollForDecisionTask_Args) ToWire() (wire.Value, error) {
+func (v *MatchingService_QueryWorkflow_Args) ToWire() (wire.Value, error) {
 	var (
 		fields [1]wire.Field
 		i      int = 0
@@ -6139,8 +8998,8 @@ func (v *MatchingService_PollForDecisionTask_Args) ToWire() (wire.Value, error)
 		err    error
 	)
 
-	if v.PollRequest != nil {
-		w, err = v.PollRequest.ToWire()
+	if v.QueryRequest != nil {
+		w, err = v.QueryRequest.ToWire()
 		if err != nil {
 			return w, err
 		}
@@ -6151,17 +9010,17 @@ func (v *MatchingService_PollForDecisionTask_Args) ToWire() (wire.Value, error)
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
 
-func _PollForDecisionTaskRequest_1_Read(w wire.Value) (*PollForDecisionTaskRequest, error) {
-	var v PollForDecisionTaskRequest
+func _QueryWorkflowRequest_1_Read(w wire.Value) (*QueryWorkflowRequest, error) {
+	var v QueryWorkflowRequest
 	err := v.FromWire(w)
 	return &v, err
 }
 
-// FromWire deserializes a MatchingService_PollForDecisionTask_Args struct from its Thrift-level
+// FromWire deserializes a MatchingService_QueryWorkflow_Args struct from its Thrift-level
 // representation. The Thrift-level representation may be obtained
 // from a ThriftRW protocol implementation.
 //
-// An error is returned if we were unable to build a MatchingService_PollForDecisionTask_Args struct
+// An error is returned if we were unable to build a MatchingService_QueryWorkflow_Args struct
 // from the provided intermediate representation.
 //
 //   x, err := binaryProtocol.Decode(reader, wire.TStruct)
@@ -6169,19 +9028,19 @@ func _PollForDecisionTaskRequest_1_Read(w wire.Value) (*PollForDecisionTaskReque
 //     return nil, err
 //   }
 //
-//   var v MatchingService_PollForDecisionTask_Args
+//   var v MatchingService_QueryWorkflow_Args
 //   if err := v.FromWire(x); err != nil {
 //     return nil, err
 //   }
 //   return &v, nil
-func (v *MatchingService_PollForDecisionTask_Args) FromWire(w wire.Value) error {
+func (v *MatchingService_QueryWorkflow_Args) FromWire(w wire.Value) error {
 	var err error
 
 	for _, field := range w.GetStruct().Fields {
 		switch field.ID {
 		case 1:
 			if field.Value.Type() == wire.TStruct {
-				v.PollRequest, err = _PollForDecisionTaskRequest_1_Read(field.Value)
+				v.QueryRequest, err = _QueryWorkflowRequest_1_Read(field.Value)
 				if err != nil {
 					return err
 				}
@@ -6193,34 +9052,34 @@ func (v *MatchingService_PollForDecisionTask_Args) FromWire(w wire.Value) error
 	return nil
 }
 
-// String returns a readable string representation of a MatchingService_PollForDecisionTask_Args
+// String returns a readable string representation of a MatchingService_QueryWorkflow_Args
 // struct.
-func (v *MatchingService_PollForDecisionTask_Args) String() string {
+func (v *MatchingService_QueryWorkflow_Args) String() string {
 	if v == nil {
 		return "<nil>"
 	}
 
 	var fields [1]string
 	i := 0
-	if v.PollRequest != nil {
-		fields[i] = fmt.Sprintf("PollRequest: %v", v.PollRequest)
+	if v.QueryRequest != nil {
+		fields[i] = fmt.Sprintf("QueryRequest: %v", v.QueryRequest)
 		i++
 	}
 
-	return fmt.Sprintf("MatchingService_PollForDecisionTask_Args{%v}", strings.Join(fields[:i], ", "))
+	return fmt.Sprintf("MatchingService_QueryWorkflow_Args{%v}", strings.Join(fields[:i], ", "))
 }
 
-// Equals returns true if all the fields of this MatchingService_PollForDecisionTask_Args match the
-// provided MatchingService_PollForDecisionTask_Args.
+// Equals returns true if all the fields of this MatchingService_QueryWorkflow_Args match the
+// provided MatchingService_QueryWorkflow_Args.
 //
 // This function performs a deep comparison.
-func (v *MatchingService_PollForDecisionTask_Args) Equals(rhs *MatchingService_PollForDecisionTask_Args) bool {
+func (v *MatchingService_QueryWorkflow_Args) Equals(rhs *MatchingService_QueryWorkflow_Args) bool {
 	if v == nil {
 		return rhs == nil
 	} else if rhs == nil {
 		return false
 	}
-	if !((v.PollRequest == nil && rhs.PollRequest == nil) || (v.PollRequest != nil && rhs.PollRequest != nil && v.PollRequest.Equals(rhs.PollRequest))) {
+	if !((v.QueryRequest == nil && rhs.QueryRequest == nil) || (v.QueryRequest != nil && rhs.QueryRequest != nil && v.QueryRequest.Equals(rhs.QueryRequest))) {
 		return false
 	}
 
@@ -6228,107 +9087,111 @@ func (v *MatchingService_PollForDecisionTask_Args) Equals(rhs *MatchingService_P
 }
 
 // MarshalLogObject implements zapcore.ObjectMarshaler, enabling
-// fast logging of MatchingService_PollForDecisionTask_Args.
-func (v *MatchingService_PollForDecisionTask_Args) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+// fast logging of MatchingService_QueryWorkflow_Args.
+func (v *MatchingService_QueryWorkflow_Args) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
 	if v == nil {
 		return nil
 	}
-	if v.PollRequest != nil {
-		err = multierr.Append(err, enc.AddObject("pollRequest", v.PollRequest))
+	if v.QueryRequest != nil {
+		err = multierr.Append(err, enc.AddObject("queryRequest", v.QueryRequest))
 	}
 	return err
 }
 
-// GetPollRequest returns the value of PollRequest if it is set or its
+// GetQueryRequest returns the value of QueryRequest if it is set or its
 // zero value if it is unset.
-func (v *MatchingService_PollForDecisionTask_Args) GetPollRequest() (o *PollForDecisionTaskRequest) {
-	if v != nil && v.PollRequest != nil {
-		return v.PollRequest
+func (v *MatchingService_QueryWorkflow_Args) GetQueryRequest() (o *QueryWorkflowRequest) {
+	if v != nil && v.QueryRequest != nil {
+		return v.QueryRequest
 	}
 
 	return
 }
 
-// IsSetPollRequest returns true if PollRequest is not nil.
-func (v *MatchingService_PollForDecisionTask_Args) IsSetPollRequest() bool {
-	return v != nil && v.PollRequest != nil
+// IsSetQueryRequest returns true if QueryRequest is not nil.
+func (v *MatchingService_QueryWorkflow_Args) IsSetQueryRequest() bool {
+	return v != nil && v.QueryRequest != nil
 }
 
 // MethodName returns the name of the Thrift function as specified in
 // the IDL, for which this struct represent the arguments.
 //
-// This will always be "PollForDecisionTask" for this struct.
-func (v *MatchingService_PollForDecisionTask_Args) MethodName() string {
-	return "PollForDecisionTask"
+// This will always be "QueryWorkflow" for this struct.
+func (v *MatchingService_QueryWorkflow_Args) MethodName() string {
+	return "QueryWorkflow"
 }
 
 // EnvelopeType returns the kind of value inside this struct.
 //
 // This will always be Call for this struct.
-func (v *MatchingService_PollForDecisionTask_Args) EnvelopeType() wire.EnvelopeType {
+func (v *MatchingService_QueryWorkflow_Args) EnvelopeType() wire.EnvelopeType {
 	return wire.Call
 }
 
-// MatchingService_PollForDecisionTask_Helper provides functions that aid in handling the
-// parameters and return values of the MatchingService.PollForDecisionTask
+// MatchingService_QueryWorkflow_Helper provides functions that aid in handling the
+// parameters and return values of the MatchingService.QueryWorkflow
 // function.
-var MatchingService_PollForDecisionTask_Helper = struct {
-	// Args accepts the parameters of PollForDecisionTask in-order and returns
+var MatchingService_QueryWorkflow_Helper = struct {
+	// Args accepts the parameters of QueryWorkflow in-order and returns
 	// the arguments struct for the function.
 	Args func(
-		pollRequest *PollForDecisionTaskRequest,
-	) *MatchingService_PollForDecisionTask_Args
+		queryRequest *QueryWorkflowRequest,
+	) *MatchingService_QueryWorkflow_Args
 
 	// IsException returns true if the given error can be thrown
-	// by PollForDecisionTask.
+	// by QueryWorkflow.
 	//
-	// An error can be thrown by PollForDecisionTask only if the
+	// An error can be thrown by QueryWorkflow only if the
 	// corresponding exception type was mentioned in the 'throws'
 	// section for it in the Thrift file.
 	IsException func(error) bool
 
-	// WrapResponse returns the result struct for PollForDecisionTask
+	// WrapResponse returns the result struct for QueryWorkflow
 	// given its return value and error.
 	//
 	// This allows mapping values and errors returned by
-	// PollForDecisionTask into a serializable result struct.
+	// QueryWorkflow into a serializable result struct.
 	// WrapResponse returns a non-nil error if the provided
-	// error cannot be thrown by PollForDecisionTask
+	// error cannot be thrown by QueryWorkflow
 	//
-	//   value, err := PollForDecisionTask(args)
-	//   result, err := MatchingService_PollForDecisionTask_Helper.WrapResponse(value, err)
+	//   value, err := QueryWorkflow(args)
+	//   result, err := MatchingService_QueryWorkflow_Helper.WrapResponse(value, err)
 	//   if err != nil {
-	//     return fmt.Errorf("unexpected error from PollForDecisionTask: %v", err)
+	//     return fmt.Errorf("unexpected error from QueryWorkflow: %v", err)
 	//   }
 	//   serialize(result)
-	WrapResponse func(*PollForDecisionTaskResponse, error) (*MatchingService_PollForDecisionTask_Result, error)
+	WrapResponse func(*shared.QueryWorkflowResponse, error) (*MatchingService_QueryWorkflow_Result, error)
 
-	// UnwrapResponse takes the result struct for PollForDecisionTask
+	// UnwrapResponse takes the result struct for QueryWorkflow
 	// and returns the value or error returned by it.
 	//
-	// The error is non-nil only if PollForDecisionTask threw an
+	// The error is non-nil only if QueryWorkflow threw an
 	// exception.
 	//
 	//   result := deserialize(bytes)
-	//   value, err := MatchingService_PollForDecisionTask_Helper.UnwrapResponse(result)
-	UnwrapResponse func(*MatchingService_PollForDecisionTask_Result) (*PollForDecisionTaskResponse, error)
+	//   value, err := MatchingService_QueryWorkflow_Helper.UnwrapResponse(result)
+	UnwrapResponse func(*MatchingService_QueryWorkflow_Result) (*shared.QueryWorkflowResponse, error)
 }{}
 
 func init() {
-	MatchingService_PollForDecisionTask_Helper.Args = func(
-		pollRequest *PollForDecisionTaskRequest,
-	) *MatchingService_PollForDecisionTask_Args {
-		return &MatchingService_PollForDecisionTask_Args{
-			PollRequest: pollRequest,
+	MatchingService_QueryWorkflow_Helper.Args = func(
+		queryRequest *QueryWorkflowRequest,
+	) *MatchingService_QueryWorkflow_Args {
+		return &MatchingService_QueryWorkflow_Args{
+			QueryRequest: queryRequest,
 		}
 	}
 
-	MatchingService_PollForDecisionTask_Helper.IsException = func(err error) bool {
+	MatchingService_QueryWorkflow_Helper.IsException = func(err error) bool {
 		switch err.(type) {
 		case *shared.BadRequestError:
 			return true
 		case *shared.InternalServiceError:
 			return true
+		case *shared.EntityNotExistsError:
+			return true
+		case *shared.QueryFailedError:
+			return true
 		case *shared.LimitExceededError:
 			return true
 		case *shared.ServiceBusyError:
@@ -6338,37 +9201,47 @@ func init() {
 		}
 	}
 
-	MatchingService_PollForDecisionTask_Helper.WrapResponse = func(success *PollForDecisionTaskResponse, err error) (*MatchingService_PollForDecisionTask_Result, error) {
+	MatchingService_QueryWorkflow_Helper.WrapResponse = func(success *shared.QueryWorkflowResponse, err error) (*MatchingService_QueryWorkflow_Result, error) {
 		if err == nil {
-			return &MatchingService_PollForDecisionTask_Result{Success: success}, nil
+			return &MatchingService_QueryWorkflow_Result{Success: success}, nil
 		}
 
 		switch e := err.(type) {
 		case *shared.BadRequestError:
 			if e == nil {
-				return nil, errors.New("WrapResponse received non-nil error type with nil value for MatchingService_PollForDecisionTask_Result.BadRequestError")
+				return nil, errors.New("WrapResponse received non-nil error type with nil value for MatchingService_QueryWorkflow_Result.BadRequestError")
 			}
-			return &MatchingService_PollForDecisionTask_Result{BadRequestError: e}, nil
+			return &MatchingService_QueryWorkflow_Result{BadRequestError: e}, nil
 		case *shared.InternalServiceError:
 			if e == nil {
-				return nil, errors.New("WrapResponse received non-nil error type with nil value for MatchingService_PollForDecisionTask_Result.InternalServiceError")
+				return nil, errors.New("WrapResponse received non-nil error type with nil value for MatchingService_QueryWorkflow_Result.InternalServiceError")
 			}
-			return &MatchingService_PollForDecisionTask_Result{InternalServiceError: e}, nil
+			return &MatchingService_QueryWorkflow_Result{InternalServiceError: e}, nil
+		case *shared.EntityNotExistsError:
+			if e == nil {
+				return nil, errors.New("WrapResponse received non-nil error type with nil value for MatchingService_QueryWorkflow_Result.EntityNotExistError")
+			}
+			return &MatchingService_QueryWorkflow_Result{EntityNotExistError: e}, nil
+		case *shared.QueryFailedError:
+			if e == nil {
+				return nil, errors.New("WrapResponse received non-nil error type with nil value for MatchingService_QueryWorkflow_Result.QueryFailedError")
+			}
+			return &MatchingService_QueryWorkflow_Result{QueryFailedError: e}, nil
 		case *shared.LimitExceededError:
 			if e == nil {
-				return nil, errors.New("WrapResponse received non-nil error type with nil value for MatchingService_PollForDecisionTask_Result.LimitExceededError")
+				return nil, errors.New("WrapResponse received non-nil error type with nil value for MatchingService_QueryWorkflow_Result.LimitExceededError")
 			}
-			return &MatchingService_PollForDecisionTask_Result{LimitExceededError: e}, nil
+			return &MatchingService_QueryWorkflow_Result{LimitExceededError: e}, nil
 		case *shared.ServiceBusyError:
 			if e == nil {
-				return nil, errors.New("WrapResponse received non-nil error type with nil value for MatchingService_PollForDecisionTask_Result.ServiceBusyError")
+				return nil, errors.New("WrapResponse received non-nil error type with nil value for MatchingService_QueryWorkflow_Result.ServiceBusyError")
 			}
-			return &MatchingService_PollForDecisionTask_Result{ServiceBusyError: e}, nil
+			return &MatchingService_QueryWorkflow_Result{ServiceBusyError: e}, nil
 		}
 
 		return nil, err
 	}
-	MatchingService_PollForDecisionTask_Helper.UnwrapResponse = func(result *MatchingService_PollForDecisionTask_Result) (success *PollForDecisionTaskResponse, err error) {
+	MatchingService_QueryWorkflow_Helper.UnwrapResponse = func(result *MatchingService_QueryWorkflow_Result) (success *shared.QueryWorkflowResponse, err error) {
 		if result.BadRequestError != nil {
 			err = result.BadRequestError
 			return
@@ -6377,6 +9250,14 @@ func init() {
 			err = result.InternalServiceError
 			return
 		}
+		if result.EntityNotExistError != nil {
+			err = result.EntityNotExistError
+			return
+		}
+		if result.QueryFailedError != nil {
+			err = result.QueryFailedError
+			return
+		}
 		if result.LimitExceededError != nil {
 			err = result.LimitExceededError
 			return
@@ -6397,21 +9278,23 @@ func init() {
 
 }
 
-// MatchingService_PollForDecisionTask_Result represents the result of a MatchingService.PollForDecisionTask function call.
+// MatchingService_QueryWorkflow_Result represents the result of a MatchingService.QueryWorkflow function call.
 //
-// The result of a PollForDecisionTask execution is sent and received over the wire as this struct.
+// The result of a QueryWorkflow execution is sent and received over the wire as this struct.
 //
 // Success is set only if the function did not throw an exception.
-type MatchingService_PollForDecisionTask_Result struct {
-	// Value returned by PollForDecisionTask after a successful execution.
-	Success              *PollForDecisionTaskResponse `json:"success,omitempty"`
-	BadRequestError      *shared.BadRequestError      `json:"badRequestError,omitempty"`
-	InternalServiceError *shared.InternalServiceError `json:"internalServiceError,omitempty"`
-	LimitExceededError   *shared.LimitExceededError   `json:"limitExceededError,omitempty"`
-	ServiceBusyError     *shared.ServiceBusyError     `json:"serviceBusyError,omitempty"`
+type MatchingService_QueryWorkflow_Result struct {
+	// Value returned by QueryWorkflow after a successful execution.
+	Success              *shared.QueryWorkflowResponse `json:"success,omitempty"`
+	BadRequestError      *shared.BadRequestError       `json:"badRequestError,omitempty"`
+	InternalServiceError *shared.InternalServiceError  `json:"internalServiceError,omitempty"`
+	EntityNotExistError  *shared.EntityNotExistsError  `json:"entityNotExistError,omitempty"`
+	QueryFailedError     *shared.QueryFailedError      `json:"queryFailedError,omitempty"`
+	LimitExceededError   *shared.LimitExceededError    `json:"limitExceededError,omitempty"`
+	ServiceBusyError     *shared.ServiceBusyError      `json:"serviceBusyError,omitempty"`
 }
 
-// ToWire translates a MatchingService_PollForDecisionTask_Result struct into a Thrift-level intermediate
+// ToWire translates a MatchingService_QueryWorkflow_Result struct into a Thrift-level intermediate
 // representation. This intermediate representation may be serialized
 // into bytes using a ThriftRW protocol implementation.
 //
@@ -6426,9 +9309,9 @@ type MatchingService_PollForDecisionTask_Result struct {
 //   if err := binaryProtocol.Encode(x, writer); err != nil {
 //     return err
 //   }
-func (v *MatchingService_PollForDecisionTask_Result) ToWire() (wire.Value, error) {
+func (v *MatchingService_QueryWorkflow_Result) ToWire() (wire.Value, error) {
 	var (
-		fields [5]wire.Field
+		fields [7]wire.Field
 		i      int = 0
 		w      wire.Value
 		err    error
@@ -6458,12 +9341,28 @@ func (v *MatchingService_PollForDecisionTask_Result) ToWire() (wire.Value, error
 		fields[i] = wire.Field{ID: 2, Value: w}
 		i++
 	}
+	if v.EntityNotExistError != nil {
+		w, err = v.EntityNotExistError.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 3, Value: w}
+		i++
+	}
+	if v.QueryFailedError != nil {
+		w, err = v.QueryFailedError.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 4, Value: w}
+		i++
+	}
 	if v.LimitExceededError != nil {
 		w, err = v.LimitExceededError.ToWire()
 		if err != nil {
 			return w, err
 		}
-		fields[i] = wire.Field{ID: 3, Value: w}
+		fields[i] = wire.Field{ID: 5, Value: w}
 		i++
 	}
 	if v.ServiceBusyError != nil {
@@ -6471,28 +9370,34 @@ func (v *MatchingService_PollForDecisionTask_Result) ToWire() (wire.Value, error
 		if err != nil {
 			return w, err
 		}
-		fields[i] = wire.Field{ID: 4, Value: w}
+		fields[i] = wire.Field{ID: 6, Value: w}
 		i++
 	}
 
 	if i != 1 {
-		return wire.Value{}, fmt.Errorf("MatchingService_PollForDecisionTask_Result should have exactly one field: got %v fields", i)
+		return wire.Value{}, fmt.Errorf("MatchingService_QueryWorkflow_Result should have exactly one field: got %v fields", i)
 	}
 
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
 
-func _PollForDecisionTaskResponse_Read(w wire.Value) (*PollForDecisionTaskResponse, error) {
-	var v PollForDecisionTaskResponse
+func _QueryWorkflowResponse_Read(w wire.Value) (*shared.QueryWorkflowResponse, error) {
+	var v shared.QueryWorkflowResponse
 	err := v.FromWire(w)
 	return &v, err
 }
 
-// FromWire deserializes a MatchingService_PollForDecisionTask_Result struct from its Thrift-level
+func _QueryFailedError_Read(w wire.Value) (*shared.QueryFailedError, error) {
+	var v shared.QueryFailedError
+	err := v.FromWire(w)
+	return &v, err
+}
+
+// FromWire deserializes a MatchingService_QueryWorkflow_Result struct from its Thrift-level
 // representation. The Thrift-level representation may be obtained
 // from a ThriftRW protocol implementation.
 //
-// An error is returned if we were unable to build a MatchingService_PollForDecisionTask_Result struct
+// An error is returned if we were unable to build a MatchingService_QueryWorkflow_Result struct
 // from the provided intermediate representation.
 //
 //   x, err := binaryProtocol.Decode(reader, wire.TStruct)
@@ -6500,19 +9405,19 @@ func _PollForDecisionTaskResponse_Read(w wire.Value) (*PollForDecisionTaskRespon
 //     return nil, err
 //   }
 //
-//   var v MatchingService_PollForDecisionTask_Result
+//   var v MatchingService_QueryWorkflow_Result
 //   if err := v.FromWire(x); err != nil {
 //     return nil, err
 //   }
 //   return &v, nil
-func (v *MatchingService_PollForDecisionTask_Result) FromWire(w wire.Value) error {
+func (v *MatchingService_QueryWorkflow_Result) FromWire(w wire.Value) error {
 	var err error
 
 	for _, field := range w.GetStruct().Fields {
 		switch field.ID {
 		case 0:
 			if field.Value.Type() == wire.TStruct {
-				v.Success, err = _PollForDecisionTaskResponse_Read(field.Value)
+				v.Success, err = _QueryWorkflowResponse_Read(field.Value)
 				if err != nil {
 					return err
 				}
@@ -6536,13 +9441,29 @@ func (v *MatchingService_PollForDecisionTask_Result) FromWire(w wire.Value) erro
 			}
 		case 3:
 			if field.Value.Type() == wire.TStruct {
-				v.LimitExceededError, err = _LimitExceededError_Read(field.Value)
+				v.EntityNotExistError, err = _EntityNotExistsError_Read(field.Value)
 				if err != nil {
 					return err
 				}
 
 			}
 		case 4:
+			if field.Value.Type() == wire.TStruct {
+				v.QueryFailedError, err = _QueryFailedError_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 5:
+			if field.Value.Type() == wire.TStruct {
+				v.LimitExceededError, err = _LimitExceededError_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 6:
 			if field.Value.Type() == wire.TStruct {
 				v.ServiceBusyError, err = _ServiceBusyError_Read(field.Value)
 				if err != nil {
@@ -6563,6 +9484,12 @@ func (v *MatchingService_PollForDecisionTask_Result) FromWire(w wire.Value) erro
 	if v.InternalServiceError != nil {
 		count++
 	}
+	if v.EntityNotExistError != nil {
+		count++
+	}
+	if v.QueryFailedError != nil {
+		count++
+	}
 	if v.LimitExceededError != nil {
 		count++
 	}
@@ -6570,20 +9497,20 @@ func (v *MatchingService_PollForDecisionTask_Result) FromWire(w wire.Value) erro
 		count++
 	}
 	if count != 1 {
-		return fmt.Errorf("MatchingService_PollForDecisionTask_Result should have exactly one field: got %v fields", count)
+		return fmt.Errorf("MatchingService_QueryWorkflow_Result should have exactly one field: got %v fields", count)
 	}
 
 	return nil
 }
 
-// String returns a readable string representation of a MatchingService_PollForDecisionTask_Result
+// String returns a readable string representation of a MatchingService_QueryWorkflow_Result
 // struct.
-func (v *MatchingService_PollForDecisionTask_Result) String() string {
+func (v *MatchingService_QueryWorkflow_Result) String() string {
 	if v == nil {
 		return "<nil>"
 	}
 
-	var fields [5]string
+	var fields [7]string
 	i := 0
 	if v.Success != nil {
 		fields[i] = fmt.Sprintf("Success: %v", v.Success)
@@ -6597,6 +9524,14 @@ func (v *MatchingService_PollForDecisionTask_Result) String() string {
 		fields[i] = fmt.Sprintf("InternalServiceError: %v", v.InternalServiceError)
 		i++
 	}
+	if v.EntityNotExistError != nil {
+		fields[i] = fmt.Sprintf("EntityNotExistError: %v", v.EntityNotExistError)
+		i++
+	}
+	if v.QueryFailedError != nil {
+		fields[i] = fmt.Sprintf("QueryFailedError: %v", v.QueryFailedError)
+		i++
+	}
 	if v.LimitExceededError != nil {
 		fields[i] = fmt.Sprintf("LimitExceededError: %v", v.LimitExceededError)
 		i++
@@ -6606,14 +9541,14 @@ func (v *MatchingService_PollForDecisionTask_Result) String() string {
 		i++
 	}
 
-	return fmt.Sprintf("MatchingService_PollForDecisionTask_Result{%v}", strings.Join(fields[:i], ", "))
+	return fmt.Sprintf("MatchingService_QueryWorkflow_Result{%v}", strings.Join(fields[:i], ", "))
 }
 
-// Equals returns true if all the fields of this MatchingService_PollForDecisionTask_Result match the
-// provided MatchingService_PollForDecisionTask_Result.
+// Equals returns true if all the fields of this MatchingService_QueryWorkflow_Result match the
+// provided MatchingService_QueryWorkflow_Result.
 //
 // This function performs a deep comparison.
-func (v *MatchingService_PollForDecisionTask_Result) Equals(rhs *MatchingService_PollForDecisionTask_Result) bool {
+func (v *MatchingService_QueryWorkflow_Result) Equals(rhs *MatchingService_QueryWorkflow_Result) bool {
 	if v == nil {
 		return rhs == nil
 	} else if rhs == nil {
@@ -6628,6 +9563,12 @@ func (v *MatchingService_PollForDecisionTask_Result) Equals(rhs *MatchingService
 	if !((v.InternalServiceError == nil && rhs.InternalServiceError == nil) || (v.InternalServiceError != nil && rhs.InternalServiceError != nil && v.InternalServiceError.Equals(rhs.InternalServiceError))) {
 		return false
 	}
+	if !((v.EntityNotExistError == nil && rhs.EntityNotExistError == nil) || (v.EntityNotExistError != nil && rhs.EntityNotExistError != nil && v.EntityNotExistError.Equals(rhs.EntityNotExistError))) {
+		return false
+	}
+	if !((v.QueryFailedError == nil && rhs.QueryFailedError == nil) || (v.QueryFailedError != nil && rhs.QueryFailedError != nil && v.QueryFailedError.Equals(rhs.QueryFailedError))) {
+		return false
+	}
 	if !((v.LimitExceededError == nil && rhs.LimitExceededError == nil) || (v.LimitExceededError != nil && rhs.LimitExceededError != nil && v.LimitExceededError.Equals(rhs.LimitExceededError))) {
 		return false
 	}
@@ -6639,8 +9580,8 @@ func (v *MatchingService_PollForDecisionTask_Result) Equals(rhs *MatchingService
 }
 
 // MarshalLogObject implements zapcore.ObjectMarshaler, enabling
-// fast logging of MatchingService_PollForDecisionTask_Result.
-func (v *MatchingService_PollForDecisionTask_Result) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+// fast logging of MatchingService_QueryWorkflow_Result.
+func (v *MatchingService_QueryWorkflow_Result) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
 	if v == nil {
 		return nil
 	}
@@ -6653,6 +9594,12 @@ func (v *MatchingService_PollForDecisionTask_Result) MarshalLogObject(enc zapcor
 	if v.InternalServiceError != nil {
 		err = multierr.Append(err, enc.AddObject("internalServiceError", v.InternalServiceError))
 	}
+	if v.EntityNotExistError != nil {
+		err = multierr.Append(err, enc.AddObject("entityNotExistError", v.EntityNotExistError))
+	}
+	if v.QueryFailedError != nil {
+		err = multierr.Append(err, enc.AddObject("queryFailedError", v.QueryFailedError))
+	}
 	if v.LimitExceededError != nil {
 		err = multierr.Append(err, enc.AddObject("limitExceededError", v.LimitExceededError))
 	}
@@ -6664,7 +9611,7 @@ func (v *MatchingService_PollForDecisionTask_Result) MarshalLogObject(enc zapcor
 
 // GetSuccess returns the value of Success if it is set or its
 // zero value if it is unset.
-func (v *MatchingService_PollForDecisionTask_Result) GetSuccess() (o *PollForDecisionTaskResponse) {
+func (v *MatchingService_QueryWorkflow_Result) GetSuccess() (o *shared.QueryWorkflowResponse) {
 	if v != nil && v.Success != nil {
 		return v.Success
 	}
@@ -6673,13 +9620,13 @@ func (v *MatchingService_PollForDecisionTask_Result) GetSuccess() (o *PollForDec
 }
 
 // IsSetSuccess returns true if Success is not nil.
-func (v *MatchingService_PollForDecisionTask_Result) IsSetSuccess() bool {
+func (v *MatchingService_QueryWorkflow_Result) IsSetSuccess() bool {
 	return v != nil && v.Success != nil
 }
 
 // GetBadRequestError returns the value of BadRequestError if it is set or its
 // zero value if it is unset.
-func (v *MatchingService_PollForDecisionTask_Result) GetBadRequestError() (o *shared.BadRequestError) {
+func (v *MatchingService_QueryWorkflow_Result) GetBadRequestError() (o *shared.BadRequestError) {
 	if v != nil && v.BadRequestError != nil {
 		return v.BadRequestError
 	}
@@ -6688,13 +9635,13 @@ func (v *MatchingService_PollForDecisionTask_Result) GetBadRequestError() (o *sh
 }
 
 // IsSetBadRequestError returns true if BadRequestError is not nil.
-func (v *MatchingService_PollForDecisionTask_Result) IsSetBadRequestError() bool {
+func (v *MatchingService_QueryWorkflow_Result) IsSetBadRequestError() bool {
 	return v != nil && v.BadRequestError != nil
 }
 
 // GetInternalServiceError returns the value of InternalServiceError if it is set or its
 // zero value if it is unset.
-func (v *MatchingService_PollForDecisionTask_Result) GetInternalServiceError() (o *shared.InternalServiceError) {
+func (v *MatchingService_QueryWorkflow_Result) GetInternalServiceError() (o *shared.InternalServiceError) {
 	if v != nil && v.InternalServiceError != nil {
 		return v.InternalServiceError
 	}
@@ -6703,13 +9650,43 @@ func (v *MatchingService_PollForDecisionTask_Result) GetInternalServiceError() (
 }
 
 // IsSetInternalServiceError returns true if InternalServiceError is not nil.
-func (v *MatchingService_PollForDecisionTask_Result) IsSetInternalServiceError() bool {
+func (v *MatchingService_QueryWorkflow_Result) IsSetInternalServiceError() bool {
 	return v != nil && v.InternalServiceError != nil
 }
 
+// GetEntityNotExistError returns the value of EntityNotExistError if it is set or its
+// zero value if it is unset.
+func (v *MatchingService_QueryWorkflow_Result) GetEntityNotExistError() (o *shared.EntityNotExistsError) {
+	if v != nil && v.EntityNotExistError != nil {
+		return v.EntityNotExistError
+	}
+
+	return
+}
+
+// IsSetEntityNotExistError returns true if EntityNotExistError is not nil.
+func (v *MatchingService_QueryWorkflow_Result) IsSetEntityNotExistError() bool {
+	return v != nil && v.EntityNotExistError != nil
+}
+
+// GetQueryFailedError returns the value of QueryFailedError if it is set or its
+// zero value if it is unset.
+func (v *MatchingService_QueryWorkflow_Result) GetQueryFailedError() (o *shared.QueryFailedError) {
+	if v != nil && v.QueryFailedError != nil {
+		return v.QueryFailedError
+	}
+
+	return
+}
+
+// IsSetQueryFailedError returns true if QueryFailedError is not nil.
+func (v *MatchingService_QueryWorkflow_Result) IsSetQueryFailedError() bool {
+	return v != nil && v.QueryFailedError != nil
+}
+
 // GetLimitExceededError returns the value of LimitExceededError if it is set or its
 // zero value if it is unset.
-func (v *MatchingService_PollForDecisionTask_Result) GetLimitExceededError() (o *shared.LimitExceededError) {
+func (v *MatchingService_QueryWorkflow_Result) GetLimitExceededError() (o *shared.LimitExceededError) {
 	if v != nil && v.LimitExceededError != nil {
 		return v.LimitExceededError
 	}
@@ -6718,13 +9695,13 @@ func (v *MatchingService_PollForDecisionTask_Result) GetLimitExceededError() (o
 }
 
 // IsSetLimitExceededError returns true if LimitExceededError is not nil.
-func (v *MatchingService_PollForDecisionTask_Result) IsSetLimitExceededError() bool {
+func (v *MatchingService_QueryWorkflow_Result) IsSetLimitExceededError() bool {
 	return v != nil && v.LimitExceededError != nil
 }
 
 // GetServiceBusyError returns the value of ServiceBusyError if it is set or its
 // zero value if it is unset.
-func (v *MatchingService_PollForDecisionTask_Result) GetServiceBusyError() (o *shared.ServiceBusyError) {
+func (v *MatchingService_QueryWorkflow_Result) GetServiceBusyError() (o *shared.ServiceBusyError) {
 	if v != nil && v.ServiceBusyError != nil {
 		return v.ServiceBusyError
 	}
@@ -6733,33 +9710,33 @@ func (v *MatchingService_PollForDecisionTask_Result) GetServiceBusyError() (o *s
 }
 
 // IsSetServiceBusyError returns true if ServiceBusyError is not nil.
-func (v *MatchingService_PollForDecisionTask_Result) IsSetServiceBusyError() bool {
+func (v *MatchingService_QueryWorkflow_Result) IsSetServiceBusyError() bool {
 	return v != nil && v.ServiceBusyError != nil
 }
 
 // MethodName returns the name of the Thrift function as specified in
 // the IDL, for which this struct represent the result.
 //
-// This will always be "PollForDecisionTask" for this struct.
-func (v *MatchingService_PollForDecisionTask_Result) MethodName() string {
-	return "PollForDecisionTask"
+// This will always be "QueryWorkflow" for this struct.
+func (v *MatchingService_QueryWorkflow_Result) MethodName() string {
+	return "QueryWorkflow"
 }
 
 // EnvelopeType returns the kind of value inside this struct.
 //
 // This will always be Reply for this struct.
-func (v *MatchingService_PollForDecisionTask_Result) EnvelopeType() wire.EnvelopeType {
+func (v *MatchingService_QueryWorkflow_Result) EnvelopeType() wire.EnvelopeType {
 	return wire.Reply
 }
 
-// MatchingService_QueryWorkflow_Args represents the arguments for the MatchingService.QueryWorkflow function.
+// MatchingService_RespondQueryTaskCompleted_Args represents the arguments for the MatchingService.RespondQueryTaskCompleted function.
 //
-// The arguments for QueryWorkflow are sent and received over the wire as this struct.
-type MatchingService_QueryWorkflow_Args struct {
-	QueryRequest *QueryWorkflowRequest `json:"queryRequest,omitempty"`
+// The arguments for RespondQueryTaskCompleted are sent and received over the wire as this struct.
+type MatchingService_RespondQueryTaskCompleted_Args struct {
+	Request *RespondQueryTaskCompletedRequest `json:"request,omitempty"`
 }
 
-// ToWire translates a MatchingService_QueryWorkflow_Args struct into a Thrift-level intermediate
+// ToWire translates a MatchingService_RespondQueryTaskCompleted_Args struct into a Thrift-level intermediate
 // representation. This intermediate representation may be serialized
 // into bytes using a ThriftRW protocol implementation.
 //
@@ -6774,7 +9751,7 @@ type MatchingService_QueryWorkflow_Args struct {
 //   if err := binaryProtocol.Encode(x, writer); err != nil {
 //     return err
 //   }
-func (v *MatchingService_QueryWorkflow_Args) ToWire() (wire.Value, error) {
+func (v *MatchingService_RespondQueryTaskCompleted_Args) ToWire() (wire.Value, error) {
 	var (
 		fields [1]wire.Field
 		i      int = 0
@@ -6782,8 +9759,8 @@ func (v *MatchingService_QueryWorkflow_Args) ToWire() (wire.Value, error) {
 		err    error
 	)
 
-	if v.QueryRequest != nil {
-		w, err = v.QueryRequest.ToWire()
+	if v.Request != nil {
+		w, err = v.Request.ToWire()
 		if err != nil {
 			return w, err
 		}
@@ -6794,17 +9771,17 @@ func (v *MatchingService_QueryWorkflow_Args) ToWire() (wire.Value, error) {
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
 
-func _QueryWorkflowRequest_1_Read(w wire.Value) (*QueryWorkflowRequest, error) {
-	var v QueryWorkflowRequest
+func _RespondQueryTaskCompletedRequest_1_Read(w wire.Value) (*RespondQueryTaskCompletedRequest, error) {
+	var v RespondQueryTaskCompletedRequest
 	err := v.FromWire(w)
 	return &v, err
 }
 
-// FromWire deserializes a MatchingService_QueryWorkflow_Args struct from its Thrift-level
+// FromWire deserializes a MatchingService_RespondQueryTaskCompleted_Args struct from its Thrift-level
 // representation. The Thrift-level representation may be obtained
 // from a ThriftRW protocol implementation.
 //
-// An error is returned if we were unable to build a MatchingService_QueryWorkflow_Args struct
+// An error is returned if we were unable to build a MatchingService_RespondQueryTaskCompleted_Args struct
 // from the provided intermediate representation.
 //
 //   x, err := binaryProtocol.Decode(reader, wire.TStruct)
@@ -6812,19 +9789,19 @@ func _QueryWorkflowRequest_1_Read(w wire.Value) (*QueryWorkflowRequest, error) {
 //     return nil, err
 //   }
 //
-//   var v MatchingService_QueryWorkflow_Args
+//   var v MatchingService_RespondQueryTaskCompleted_Args
 //   if err := v.FromWire(x); err != nil {
 //     return nil, err
 //   }
 //   return &v, nil
-func (v *MatchingService_QueryWorkflow_Args) FromWire(w wire.Value) error {
+func (v *MatchingService_RespondQueryTaskCompleted_Args) FromWire(w wire.Value) error {
 	var err error
 
 	for _, field := range w.GetStruct().Fields {
 		switch field.ID {
 		case 1:
 			if field.Value.Type() == wire.TStruct {
-				v.QueryRequest, err = _QueryWorkflowRequest_1_Read(field.Value)
+				v.Request, err = _RespondQueryTaskCompletedRequest_1_Read(field.Value)
 				if err != nil {
 					return err
 				}
@@ -6836,34 +9813,34 @@ func (v *MatchingService_QueryWorkflow_Args) FromWire(w wire.Value) error {
 	return nil
 }
 
-// String returns a readable string representation of a MatchingService_QueryWorkflow_Args
+// String returns a readable string representation of a MatchingService_RespondQueryTaskCompleted_Args
 // struct.
-func (v *MatchingService_QueryWorkflow_Args) String() string {
+func (v *MatchingService_RespondQueryTaskCompleted_Args) String() string {
 	if v == nil {
 		return "<nil>"
 	}
 
 	var fields [1]string
 	i := 0
-	if v.QueryRequest != nil {
-		fields[i] = fmt.Sprintf("QueryRequest: %v", v.QueryRequest)
+	if v.Request != nil {
+		fields[i] = fmt.Sprintf("Request: %v", v.Request)
 		i++
 	}
 
-	return fmt.Sprintf("MatchingService_QueryWorkflow_Args{%v}", strings.Join(fields[:i], ", "))
+	return fmt.Sprintf("MatchingService_RespondQueryTaskCompleted_Args{%v}", strings.Join(fields[:i], ", "))
 }
 
-// Equals returns true if all the fields of this MatchingService_QueryWorkflow_Args match the
-// provided MatchingService_QueryWorkflow_Args.
+// Equals returns true if all the fields of this MatchingService_RespondQueryTaskCompleted_Args match the
+// provided MatchingService_RespondQueryTaskCompleted_Args.
 //
 // This function performs a deep comparison.
-func (v *MatchingService_QueryWorkflow_Args) Equals(rhs *MatchingService_QueryWorkflow_Args) bool {
+func (v *MatchingService_RespondQueryTaskCompleted_Args) Equals(rhs *MatchingService_RespondQueryTaskCompleted_Args) bool {
 	if v == nil {
 		return rhs == nil
 	} else if rhs == nil {
 		return false
 	}
-	if !((v.QueryRequest == nil && rhs.QueryRequest == nil) || (v.QueryRequest != nil && rhs.QueryRequest != nil && v.QueryRequest.Equals(rhs.QueryRequest))) {
+	if !((v.Request == nil && rhs.Request == nil) || (v.Request != nil && rhs.Request != nil && v.Request.Equals(rhs.Request))) {
 		return false
 	}
 
@@ -6871,102 +9848,103 @@ func (v *MatchingService_QueryWorkflow_Args) Equals(rhs *MatchingService_QueryWo
 }
 
 // MarshalLogObject implements zapcore.ObjectMarshaler, enabling
-// fast logging of MatchingService_QueryWorkflow_Args.
-func (v *MatchingService_QueryWorkflow_Args) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+// fast logging of MatchingService_RespondQueryTaskCompleted_Args.
+func (v *MatchingService_RespondQueryTaskCompleted_Args) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
 	if v == nil {
 		return nil
 	}
-	if v.QueryRequest != nil {
-		err = multierr.Append(err, enc.AddObject("queryRequest", v.QueryRequest))
+	if v.Request != nil {
+		err = multierr.Append(err, enc.AddObject("request", v.Request))
 	}
 	return err
 }
 
-// GetQueryRequest returns the value of QueryRequest if it is set or its
+// GetRequest returns the value of Request if it is set or its
 // zero value if it is unset.
-func (v *MatchingService_QueryWorkflow_Args) GetQueryRequest() (o *QueryWorkflowRequest) {
-	if v != nil && v.QueryRequest != nil {
-		return v.QueryRequest
+func (v *MatchingService_RespondQueryTaskCompleted_Args) GetRequest() (o *RespondQueryTaskCompletedRequest) {
+	if v != nil && v.Request != nil {
+		return v.Request
 	}
 
 	return
 }
 
-// IsSetQueryRequest returns true if QueryRequest is not nil.
-func (v *MatchingService_QueryWorkflow_Args) IsSetQueryRequest() bool {
-	return v != nil && v.QueryRequest != nil
+// IsSetRequest returns true if Request is not nil.
+func (v *MatchingService_RespondQueryTaskCompleted_Args) IsSetRequest() bool {
+	return v != nil && v.Request != nil
 }
 
 // MethodName returns the name of the Thrift function as specified in
 // the IDL, for which this struct represent the arguments.
 //
-// This will always be "QueryWorkflow" for this struct.
-func (v *MatchingService_QueryWorkflow_Args) MethodName() string {
-	return "QueryWorkflow"
+// This will always be "RespondQueryTaskCompleted" for this struct.
+func (v *MatchingService_RespondQueryTaskCompleted_Args) MethodName() string {
+	return "RespondQueryTaskCompleted"
 }
 
 // EnvelopeType returns the kind of value inside this struct.
 //
 // This will always be Call for this struct.
-func (v *MatchingService_QueryWorkflow_Args) EnvelopeType() wire.EnvelopeType {
+func (v *MatchingService_RespondQueryTaskCompleted_Args) EnvelopeType() wire.EnvelopeType {
 	return wire.Call
 }
 
-// MatchingService_QueryWorkflow_Helper provides functions that aid in handling the
-// parameters and return values of the MatchingService.QueryWorkflow
+// MatchingService_RespondQueryTaskCompleted_Helper provides functions that aid in handling the
+// parameters and return values of the MatchingService.RespondQueryTaskCompleted
 // function.
-var MatchingService_QueryWorkflow_Helper = struct {
-	// Args accepts the parameters of QueryWorkflow in-order and returns
+var MatchingService_RespondQueryTaskCompleted_Helper = struct {
+	// Args accepts the parameters of RespondQueryTaskCompleted in-order and returns
 	// the arguments struct for the function.
 	Args func(
-		queryRequest *QueryWorkflowRequest,
-	) *MatchingService_QueryWorkflow_Args
+		request *RespondQueryTaskCompletedRequest,
+	) *MatchingService_RespondQueryTaskCompleted_Args
 
 	// IsException returns true if the given error can be thrown
-	// by QueryWorkflow.
+	// by RespondQueryTaskCompleted.
 	//
-	// An error can be thrown by QueryWorkflow only if the
+	// An error can be thrown by RespondQueryTaskCompleted only if the
 	// corresponding exception type was mentioned in the 'throws'
 	// section for it in the Thrift file.
 	IsException func(error) bool
 
-	// WrapResponse returns the result struct for QueryWorkflow
-	// given its return value and error.
+	// WrapResponse returns the result struct for RespondQueryTaskCompleted
+	// given the error returned by it. The provided error may
+	// be nil if RespondQueryTaskCompleted did not fail.
 	//
-	// This allows mapping values and errors returned by
-	// QueryWorkflow into a serializable result struct.
-	// WrapResponse returns a non-nil error if the provided
-	// error cannot be thrown by QueryWorkflow
+	// This allows mapping errors returned by RespondQueryTaskCompleted into a
+	// serializable result struct. WrapResponse returns a
+	// non-nil error if the provided error cannot be thrown by
+	// RespondQueryTaskCompleted
 	//
-	//   value, err := QueryWorkflow(args)
-	//   result, err := MatchingService_QueryWorkflow_Helper.WrapResponse(value, err)
+	//   err := RespondQueryTaskCompleted(args)
+	//   result, err := MatchingService_RespondQueryTaskCompleted_Helper.WrapResponse(err)
 	//   if err != nil {
-	//     return fmt.Errorf("unexpected error from QueryWorkflow: %v", err)
+	//     return fmt.Errorf("unexpected error from RespondQueryTaskCompleted: %v", err)
 	//   }
 	//   serialize(result)
-	WrapResponse func(*shared.QueryWorkflowResponse, error) (*MatchingService_QueryWorkflow_Result, error)
+	WrapResponse func(error) (*MatchingService_RespondQueryTaskCompleted_Result, error)
 
-	// UnwrapResponse takes the result struct for QueryWorkflow
-	// and returns the value or error returned by it.
+	// UnwrapResponse takes the result struct for RespondQueryTaskCompleted
+	// and returns the erorr returned by it (if any).
 	//
-	// The error is non-nil only if QueryWorkflow threw an
+	// The error is non-nil only if RespondQueryTaskCompleted threw an
 	// exception.
 	//
 	//   result := deserialize(bytes)
-	//   value, err := MatchingService_QueryWorkflow_Helper.UnwrapResponse(result)
-	UnwrapResponse func(*MatchingService_QueryWorkflow_Result) (*shared.QueryWorkflowResponse, error)
+	//   err := MatchingService_RespondQueryTaskCompleted_Helper.UnwrapResponse(result)
+	UnwrapResponse func(*MatchingService_RespondQueryTaskCompleted_Result) error
 }{}
 
 func init() {
-	MatchingService_QueryWorkflow_Helper.Args = func(
-		queryRequest *QueryWorkflowRequest,
-	) *MatchingService_QueryWorkflow_Args {
-		return &MatchingService_QueryWorkflow_Args{
-			QueryRequest: queryRequest,
+	MatchingService_RespondQueryTaskCompleted_Helper.Args = func(
+		request *RespondQueryTaskCompletedRequest,
+	) *MatchingService_RespondQueryTaskCompleted_Args {
+		return &MatchingService_RespondQueryTaskCompleted_Args{
+			Request: request,
 		}
 	}
 
-	MatchingService_QueryWorkflow_Helper.IsException = func(err error) bool {
+	MatchingService_RespondQueryTaskCompleted_Helper.IsException = func(err error) bool {
 		switch err.(type) {
 		case *shared.BadRequestError:
 			return true
@@ -6974,8 +9952,6 @@ func init() {
 			return true
 		case *shared.EntityNotExistsError:
 			return true
-		case *shared.QueryFailedError:
-			return true
 		case *shared.LimitExceededError:
 			return true
 		case *shared.ServiceBusyError:
@@ -6985,47 +9961,42 @@ func init() {
 		}
 	}
 
-	MatchingService_QueryWorkflow_Helper.WrapResponse = func(success *shared.QueryWorkflowResponse, err error) (*MatchingService_QueryWorkflow_Result, error) {
+	MatchingService_RespondQueryTaskCompleted_Helper.WrapResponse = func(err error) (*MatchingService_RespondQueryTaskCompleted_Result, error) {
 		if err == nil {
-			return &MatchingService_QueryWorkflow_Result{Success: success}, nil
+			return &MatchingService_RespondQueryTaskCompleted_Result{}, nil
 		}
 
 		switch e := err.(type) {
 		case *shared.BadRequestError:
 			if e == nil {
-				return nil, errors.New("WrapResponse received non-nil error type with nil value for MatchingService_QueryWorkflow_Result.BadRequestError")
+				return nil, errors.New("WrapResponse received non-nil error type with nil value for MatchingService_RespondQueryTaskCompleted_Result.BadRequestError")
 			}
-			return &MatchingService_QueryWorkflow_Result{BadRequestError: e}, nil
+			return &MatchingService_RespondQueryTaskCompleted_Result{BadRequestError: e}, nil
 		case *shared.InternalServiceError:
 			if e == nil {
-				return nil, errors.New("WrapResponse received non-nil error type with nil value for MatchingService_QueryWorkflow_Result.InternalServiceError")
+				return nil, errors.New("WrapResponse received non-nil error type with nil value for MatchingService_RespondQueryTaskCompleted_Result.InternalServiceError")
 			}
-			return &MatchingService_QueryWorkflow_Result{InternalServiceError: e}, nil
+			return &MatchingService_RespondQueryTaskCompleted_Result{InternalServiceError: e}, nil
 		case *shared.EntityNotExistsError:
 			if e == nil {
-				return nil, errors.New("WrapResponse received non-nil error type with nil value for MatchingService_QueryWorkflow_Result.EntityNotExistError")
-			}
-			return &MatchingService_QueryWorkflow_Result{EntityNotExistError: e}, nil
-		case *shared.QueryFailedError:
-			if e == nil {
-				return nil, errors.New("WrapResponse received non-nil error type with nil value for MatchingService_QueryWorkflow_Result.QueryFailedError")
+				return nil, errors.New("WrapResponse received non-nil error type with nil value for MatchingService_RespondQueryTaskCompleted_Result.EntityNotExistError")
 			}
-			return &MatchingService_QueryWorkflow_Result{QueryFailedError: e}, nil
+			return &MatchingService_RespondQueryTaskCompleted_Result{EntityNotExistError: e}, nil
 		case *shared.LimitExceededError:
 			if e == nil {
-				return nil, errors.New("WrapResponse received non-nil error type with nil value for MatchingService_QueryWorkflow_Result.LimitExceededError")
+				return nil, errors.New("WrapResponse received non-nil error type with nil value for MatchingService_RespondQueryTaskCompleted_Result.LimitExceededError")
 			}
-			return &MatchingService_QueryWorkflow_Result{LimitExceededError: e}, nil
+			return &MatchingService_RespondQueryTaskCompleted_Result{LimitExceededError: e}, nil
 		case *shared.ServiceBusyError:
 			if e == nil {
-				return nil, errors.New("WrapResponse received non-nil error type with nil value for MatchingService_QueryWorkflow_Result.ServiceBusyError")
+				return nil, errors.New("WrapResponse received non-nil error type with nil value for MatchingService_RespondQueryTaskCompleted_Result.ServiceBusyError")
 			}
-			return &MatchingService_QueryWorkflow_Result{ServiceBusyError: e}, nil
+			return &MatchingService_RespondQueryTaskCompleted_Result{ServiceBusyError: e}, nil
 		}
 
 		return nil, err
 	}
-	MatchingService_QueryWorkflow_Helper.UnwrapResponse = func(result *MatchingService_QueryWorkflow_Result) (success *shared.QueryWorkflowResponse, err error) {
+	MatchingService_RespondQueryTaskCompleted_Helper.UnwrapResponse = func(result *MatchingService_RespondQueryTaskCompleted_Result) (err error) {
 		if result.BadRequestError != nil {
 			err = result.BadRequestError
 			return
@@ -7038,10 +10009,6 @@ func init() {
 			err = result.EntityNotExistError
 			return
 		}
-		if result.QueryFailedError != nil {
-			err = result.QueryFailedError
-			return
-		}
 		if result.LimitExceededError != nil {
 			err = result.LimitExceededError
 			return
@@ -7050,35 +10017,23 @@ func init() {
 			err = result.ServiceBusyError
 			return
 		}
-
-		if result.Success != nil {
-			success = result.Success
-			return
-		}
-
-		err = errors.New("expected a non-void result")
 		return
 	}
 
 }
 
-// MatchingService_QueryWorkflow_Result represents the result of a MatchingService.QueryWorkflow function call.
-//
-// The result of a QueryWorkflow execution is sent and received over the wire as this struct.
+// MatchingService_RespondQueryTaskCompleted_Result represents the result of a MatchingService.RespondQueryTaskCompleted function call.
 //
-// Success is set only if the function did not throw an exception.
-type MatchingService_QueryWorkflow_Result struct {
-	// Value returned by QueryWorkflow after a successful execution.
-	Success              *shared.QueryWorkflowResponse `json:"success,omitempty"`
-	BadRequestError      *shared.BadRequestError       `json:"badRequestError,omitempty"`
-	InternalServiceError *shared.InternalServiceError  `json:"internalServiceError,omitempty"`
-	EntityNotExistError  *shared.EntityNotExistsError  `json:"entityNotExistError,omitempty"`
-	QueryFailedError     *shared.QueryFailedError      `json:"queryFailedError,omitempty"`
-	LimitExceededError   *shared.LimitExceededError    `json:"limitExceededError,omitempty"`
-	ServiceBusyError     *shared.ServiceBusyError      `json:"serviceBusyError,omitempty"`
+// The result of a RespondQueryTaskCompleted execution is sent and received over the wire as this struct.
+type MatchingService_RespondQueryTaskCompleted_Result struct {
+	BadRequestError      *shared.BadRequestError      `json:"badRequestError,omitempty"`
+	InternalServiceError *shared.InternalServiceError `json:"internalServiceError,omitempty"`
+	EntityNotExistError  *shared.EntityNotExistsError `json:"entityNotExistError,omitempty"`
+	LimitExceededError   *shared.LimitExceededError   `json:"limitExceededError,omitempty"`
+	ServiceBusyError     *shared.ServiceBusyError     `json:"serviceBusyError,omitempty"`
 }
 
-// ToWire translates a MatchingService_QueryWorkflow_Result struct into a Thrift-level intermediate
+// ToWire translates a MatchingService_RespondQueryTaskCompleted_Result struct into a Thrift-level intermediate
 // representation. This intermediate representation may be serialized
 // into bytes using a ThriftRW protocol implementation.
 //
@@ -7093,22 +10048,14 @@ type MatchingService_QueryWorkflow_Result struct {
 //   if err := binaryProtocol.Encode(x, writer); err != nil {
 //     return err
 //   }
-func (v *MatchingService_QueryWorkflow_Result) ToWire() (wire.Value, error) {
+func (v *MatchingService_RespondQueryTaskCompleted_Result) ToWire() (wire.Value, error) {
 	var (
-		fields [7]wire.Field
+		fields [5]wire.Field
 		i      int = 0
 		w      wire.Value
 		err    error
 	)
 
-	if v.Success != nil {
-		w, err = v.Success.ToWire()
-		if err != nil {
-			return w, err
-		}
-		fields[i] = wire.Field{ID: 0, Value: w}
-		i++
-	}
 	if v.BadRequestError != nil {
 		w, err = v.BadRequestError.ToWire()
 		if err != nil {
@@ -7133,20 +10080,12 @@ func (v *MatchingService_QueryWorkflow_Result) ToWire() (wire.Value, error) {
 		fields[i] = wire.Field{ID: 3, Value: w}
 		i++
 	}
-	if v.QueryFailedError != nil {
-		w, err = v.QueryFailedError.ToWire()
-		if err != nil {
-			return w, err
-		}
-		fields[i] = wire.Field{ID: 4, Value: w}
-		i++
-	}
 	if v.LimitExceededError != nil {
 		w, err = v.LimitExceededError.ToWire()
 		if err != nil {
 			return w, err
 		}
-		fields[i] = wire.Field{ID: 5, Value: w}
+		fields[i] = wire.Field{ID: 4, Value: w}
 		i++
 	}
 	if v.ServiceBusyError != nil {
@@ -7154,34 +10093,22 @@ func (v *MatchingService_QueryWorkflow_Result) ToWire() (wire.Value, error) {
 		if err != nil {
 			return w, err
 		}
-		fields[i] = wire.Field{ID: 6, Value: w}
+		fields[i] = wire.Field{ID: 5, Value: w}
 		i++
 	}
 
-	if i != 1 {
-		return wire.Value{}, fmt.Errorf("MatchingService_QueryWorkflow_Result should have exactly one field: got %v fields", i)
+	if i > 1 {
+		return wire.Value{}, fmt.Errorf("MatchingService_RespondQueryTaskCompleted_Result should have at most one field: got %v fields", i)
 	}
 
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
 
-func _QueryWorkflowResponse_Read(w wire.Value) (*shared.QueryWorkflowResponse, error) {
-	var v shared.QueryWorkflowResponse
-	err := v.FromWire(w)
-	return &v, err
-}
-
-func _QueryFailedError_Read(w wire.Value) (*shared.QueryFailedError, error) {
-	var v shared.QueryFailedError
-	err := v.FromWire(w)
-	return &v, err
-}
-
-// FromWire deserializes a MatchingService_QueryWorkflow_Result struct from its Thrift-level
+// FromWire deserializes a MatchingService_RespondQueryTaskCompleted_Result struct from its Thrift-level
 // representation. The Thrift-level representation may be obtained
 // from a ThriftRW protocol implementation.
 //
-// An error is returned if we were unable to build a MatchingService_QueryWorkflow_Result struct
+// An error is returned if we were unable to build a MatchingService_RespondQueryTaskCompleted_Result struct
 // from the provided intermediate representation.
 //
 //   x, err := binaryProtocol.Decode(reader, wire.TStruct)
@@ -7189,24 +10116,16 @@ func _QueryFailedError_Read(w wire.Value) (*shared.QueryFailedError, error) {
 //     return nil, err
 //   }
 //
-//   var v MatchingService_QueryWorkflow_Result
+//   var v MatchingService_RespondQueryTaskCompleted_Result
 //   if err := v.FromWire(x); err != nil {
 //     return nil, err
 //   }
 //   return &v, nil
-func (v *MatchingService_QueryWorkflow_Result) FromWire(w wire.Value) error {
+func (v *MatchingService_RespondQueryTaskCompleted_Result) FromWire(w wire.Value) error {
 	var err error
 
 	for _, field := range w.GetStruct().Fields {
 		switch field.ID {
-		case 0:
-			if field.Value.Type() == wire.TStruct {
-				v.Success, err = _QueryWorkflowResponse_Read(field.Value)
-				if err != nil {
-					return err
-				}
-
-			}
 		case 1:
 			if field.Value.Type() == wire.TStruct {
 				v.BadRequestError, err = _BadRequestError_Read(field.Value)
@@ -7232,14 +10151,6 @@ func (v *MatchingService_QueryWorkflow_Result) FromWire(w wire.Value) error {
 
 			}
 		case 4:
-			if field.Value.Type() == wire.TStruct {
-				v.QueryFailedError, err = _QueryFailedError_Read(field.Value)
-				if err != nil {
-					return err
-				}
-
-			}
-		case 5:
 			if field.Value.Type() == wire.TStruct {
 				v.LimitExceededError, err = _LimitExceededError_Read(field.Value)
 				if err != nil {
@@ -7247,7 +10158,7 @@ func (v *MatchingService_QueryWorkflow_Result) FromWire(w wire.Value) error {
 				}
 
 			}
-		case 6:
+		case 5:
 			if field.Value.Type() == wire.TStruct {
 				v.ServiceBusyError, err = _ServiceBusyError_Read(field.Value)
 				if err != nil {
@@ -7259,9 +10170,6 @@ func (v *MatchingService_QueryWorkflow_Result) FromWire(w wire.Value) error {
 	}
 
 	count := 0
-	if v.Success != nil {
-		count++
-	}
 	if v.BadRequestError != nil {
 		count++
 	}
@@ -7271,35 +10179,28 @@ func (v *MatchingService_QueryWorkflow_Result) FromWire(w wire.Value) error {
 	if v.EntityNotExistError != nil {
 		count++
 	}
-	if v.QueryFailedError != nil {
-		count++
-	}
 	if v.LimitExceededError != nil {
 		count++
 	}
 	if v.ServiceBusyError != nil {
 		count++
 	}
-	if count != 1 {
-		return fmt.Errorf("MatchingService_QueryWorkflow_Result should have exactly one field: got %v fields", count)
+	if count > 1 {
+		return fmt.Errorf("MatchingService_RespondQueryTaskCompleted_Result should have at most one field: got %v fields", count)
 	}
 
 	return nil
 }
 
-// String returns a readable string representation of a MatchingService_QueryWorkflow_Result
+// String returns a readable string representation of a MatchingService_RespondQueryTaskCompleted_Result
 // struct.
-func (v *MatchingService_QueryWorkflow_Result) String() string {
+func (v *MatchingService_RespondQueryTaskCompleted_Result) String() string {
 	if v == nil {
 		return "<nil>"
 	}
 
-	var fields [7]string
+	var fields [5]string
 	i := 0
-	if v.Success != nil {
-		fields[i] = fmt.Sprintf("Success: %v", v.Success)
-		i++
-	}
 	if v.BadRequestError != nil {
 		fields[i] = fmt.Sprintf("BadRequestError: %v", v.BadRequestError)
 		i++
@@ -7312,10 +10213,6 @@ func (v *MatchingService_QueryWorkflow_Result) String() string {
 		fields[i] = fmt.Sprintf("EntityNotExistError: %v", v.EntityNotExistError)
 		i++
 	}
-	if v.QueryFailedError != nil {
-		fields[i] = fmt.Sprintf("QueryFailedError: %v", v.QueryFailedError)
-		i++
-	}
 	if v.LimitExceededError != nil {
 		fields[i] = fmt.Sprintf("LimitExceededError: %v", v.LimitExceededError)
 		i++
@@ -7325,22 +10222,19 @@ func (v *MatchingService_QueryWorkflow_Result) String() string {
 		i++
 	}
 
-	return fmt.Sprintf("MatchingService_QueryWorkflow_Result{%v}", strings.Join(fields[:i], ", "))
+	return fmt.Sprintf("MatchingService_RespondQueryTaskCompleted_Result{%v}", strings.Join(fields[:i], ", "))
 }
 
-// Equals returns true if all the fields of this MatchingService_QueryWorkflow_Result match the
-// provided MatchingService_QueryWorkflow_Result.
+// Equals returns true if all the fields of this MatchingService_RespondQueryTaskCompleted_Result match the
+// provided MatchingService_RespondQueryTaskCompleted_Result.
 //
 // This function performs a deep comparison.
-func (v *MatchingService_QueryWorkflow_Result) Equals(rhs *MatchingService_QueryWorkflow_Result) bool {
+func (v *MatchingService_RespondQueryTaskCompleted_Result) Equals(rhs *MatchingService_RespondQueryTaskCompleted_Result) bool {
 	if v == nil {
 		return rhs == nil
 	} else if rhs == nil {
 		return false
 	}
-	if !((v.Success == nil && rhs.Success == nil) || (v.Success != nil && rhs.Success != nil && v.Success.Equals(rhs.Success))) {
-		return false
-	}
 	if !((v.BadRequestError == nil && rhs.BadRequestError == nil) || (v.BadRequestError != nil && rhs.BadRequestError != nil && v.BadRequestError.Equals(rhs.BadRequestError))) {
 		return false
 	}
@@ -7350,9 +10244,6 @@ func (v *MatchingService_QueryWorkflow_Result) Equals(rhs *MatchingService_Query
 	if !((v.EntityNotExistError == nil && rhs.EntityNotExistError == nil) || (v.EntityNotExistError != nil && rhs.EntityNotExistError != nil && v.EntityNotExistError.Equals(rhs.EntityNotExistError))) {
 		return false
 	}
-	if !((v.QueryFailedError == nil && rhs.QueryFailedError == nil) || (v.QueryFailedError != nil && rhs.QueryFailedError != nil && v.QueryFailedError.Equals(rhs.QueryFailedError))) {
-		return false
-	}
 	if !((v.LimitExceededError == nil && rhs.LimitExceededError == nil) || (v.LimitExceededError != nil && rhs.LimitExceededError != nil && v.LimitExceededError.Equals(rhs.LimitExceededError))) {
 		return false
 	}
@@ -7364,14 +10255,11 @@ func (v *MatchingService_QueryWorkflow_Result) Equals(rhs *MatchingService_Query
 }
 
 // MarshalLogObject implements zapcore.ObjectMarshaler, enabling
-// fast logging of MatchingService_QueryWorkflow_Result.
-func (v *MatchingService_QueryWorkflow_Result) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+// fast logging of MatchingService_RespondQueryTaskCompleted_Result.
+func (v *MatchingService_RespondQueryTaskCompleted_Result) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
 	if v == nil {
 		return nil
 	}
-	if v.Success != nil {
-		err = multierr.Append(err, enc.AddObject("success", v.Success))
-	}
 	if v.BadRequestError != nil {
 		err = multierr.Append(err, enc.AddObject("badRequestError", v.BadRequestError))
 	}
@@ -7381,36 +10269,18 @@ func (v *MatchingService_QueryWorkflow_Result) MarshalLogObject(enc zapcore.Obje
 	if v.EntityNotExistError != nil {
 		err = multierr.Append(err, enc.AddObject("entityNotExistError", v.EntityNotExistError))
 	}
-	if v.QueryFailedError != nil {
-		err = multierr.Append(err, enc.AddObject("queryFailedError", v.QueryFailedError))
-	}
 	if v.LimitExceededError != nil {
 		err = multierr.Append(err, enc.AddObject("limitExceededError", v.LimitExceededError))
-	}
-	if v.ServiceBusyError != nil {
-		err = multierr.Append(err, enc.AddObject("serviceBusyError", v.ServiceBusyError))
-	}
-	return err
-}
-
-// GetSuccess returns the value of Success if it is set or its
-// zero value if it is unset.
-func (v *MatchingService_QueryWorkflow_Result) GetSuccess() (o *shared.QueryWorkflowResponse) {
-	if v != nil && v.Success != nil {
-		return v.Success
-	}
-
-	return
-}
-
-// IsSetSuccess returns true if Success is not nil.
-func (v *MatchingService_QueryWorkflow_Result) IsSetSuccess() bool {
-	return v != nil && v.Success != nil
+	}
+	if v.ServiceBusyError != nil {
+		err = multierr.Append(err, enc.AddObject("serviceBusyError", v.ServiceBusyError))
+	}
+	return err
 }
 
 // GetBadRequestError returns the value of BadRequestError if it is set or its
 // zero value if it is unset.
-func (v *MatchingService_QueryWorkflow_Result) GetBadRequestError() (o *shared.BadRequestError) {
+func (v *MatchingService_RespondQueryTaskCompleted_Result) GetBadRequestError() (o *shared.BadRequestError) {
 	if v != nil && v.BadRequestError != nil {
 		return v.BadRequestError
 	}
@@ -7419,13 +10289,13 @@ func (v *MatchingService_QueryWorkflow_Result) GetBadRequestError() (o *shared.B
 }
 
 // IsSetBadRequestError returns true if BadRequestError is not nil.
-func (v *MatchingService_QueryWorkflow_Result) IsSetBadRequestError() bool {
+func (v *MatchingService_RespondQueryTaskCompleted_Result) IsSetBadRequestError() bool {
 	return v != nil && v.BadRequestError != nil
 }
 
 // GetInternalServiceError returns the value of InternalServiceError if it is set or its
 // zero value if it is unset.
-func (v *MatchingService_QueryWorkflow_Result) GetInternalServiceError() (o *shared.InternalServiceError) {
+func (v *MatchingService_RespondQueryTaskCompleted_Result) GetInternalServiceError() (o *shared.InternalServiceError) {
 	if v != nil && v.InternalServiceError != nil {
 		return v.InternalServiceError
 	}
@@ -7434,13 +10304,13 @@ func (v *MatchingService_QueryWorkflow_Result) GetInternalServiceError() (o *sha
 }
 
 // IsSetInternalServiceError returns true if InternalServiceError is not nil.
-func (v *MatchingService_QueryWorkflow_Result) IsSetInternalServiceError() bool {
+func (v *MatchingService_RespondQueryTaskCompleted_Result) IsSetInternalServiceError() bool {
 	return v != nil && v.InternalServiceError != nil
 }
 
 // GetEntityNotExistError returns the value of EntityNotExistError if it is set or its
 // zero value if it is unset.
-func (v *MatchingService_QueryWorkflow_Result) GetEntityNotExistError() (o *shared.EntityNotExistsError) {
+func (v *MatchingService_RespondQueryTaskCompleted_Result) GetEntityNotExistError() (o *shared.EntityNotExistsError) {
 	if v != nil && v.EntityNotExistError != nil {
 		return v.EntityNotExistError
 	}
@@ -7449,28 +10319,13 @@ func (v *MatchingService_QueryWorkflow_Result) GetEntityNotExistError() (o *shar
 }
 
 // IsSetEntityNotExistError returns true if EntityNotExistError is not nil.
-func (v *MatchingService_QueryWorkflow_Result) IsSetEntityNotExistError() bool {
+func (v *MatchingService_RespondQueryTaskCompleted_Result) IsSetEntityNotExistError() bool {
 	return v != nil && v.EntityNotExistError != nil
 }
 
-// GetQueryFailedError returns the value of QueryFailedError if it is set or its
-// zero value if it is unset.
-func (v *MatchingService_QueryWorkflow_Result) GetQueryFailedError() (o *shared.QueryFailedError) {
-	if v != nil && v.QueryFailedError != nil {
-		return v.QueryFailedError
-	}
-
-	return
-}
-
-// IsSetQueryFailedError returns true if QueryFailedError is not nil.
-func (v *MatchingService_QueryWorkflow_Result) IsSetQueryFailedError() bool {
-	return v != nil && v.QueryFailedError != nil
-}
-
 // GetLimitExceededError returns the value of LimitExceededError if it is set or its
 // zero value if it is unset.
-func (v *MatchingService_QueryWorkflow_Result) GetLimitExceededError() (o *shared.LimitExceededError) {
+func (v *MatchingService_RespondQueryTaskCompleted_Result) GetLimitExceededError() (o *shared.LimitExceededError) {
 	if v != nil && v.LimitExceededError != nil {
 		return v.LimitExceededError
 	}
@@ -7479,13 +10334,13 @@ func (v *MatchingService_QueryWorkflow_Result) GetLimitExceededError() (o *share
 }
 
 // IsSetLimitExceededError returns true if LimitExceededError is not nil.
-func (v *MatchingService_QueryWorkflow_Result) IsSetLimitExceededError() bool {
+func (v *MatchingService_RespondQueryTaskCompleted_Result) IsSetLimitExceededError() bool {
 	return v != nil && v.LimitExceededError != nil
 }
 
 // GetServiceBusyError returns the value of ServiceBusyError if it is set or its
 // zero value if it is unset.
-func (v *MatchingService_QueryWorkflow_Result) GetServiceBusyError() (o *shared.ServiceBusyError) {
+func (v *MatchingService_RespondQueryTaskCompleted_Result) GetServiceBusyError() (o *shared.ServiceBusyError) {
 	if v != nil && v.ServiceBusyError != nil {
 		return v.ServiceBusyError
 	}
@@ -7494,33 +10349,33 @@ func (v *MatchingService_QueryWorkflow_Result) GetServiceBusyError() (o *shared.
 }
 
 // IsSetServiceBusyError returns true if ServiceBusyError is not nil.
-func (v *MatchingService_QueryWorkflow_Result) IsSetServiceBusyError() bool {
+func (v *MatchingService_RespondQueryTaskCompleted_Result) IsSetServiceBusyError() bool {
 	return v != nil && v.ServiceBusyError != nil
 }
 
 // MethodName returns the name of the Thrift function as specified in
 // the IDL, for which this struct represent the result.
 //
-// This will always be "QueryWorkflow" for this struct.
-func (v *MatchingService_QueryWorkflow_Result) MethodName() string {
-	return "QueryWorkflow"
+// This will always be "RespondQueryTaskCompleted" for this struct.
+func (v *MatchingService_RespondQueryTaskCompleted_Result) MethodName() string {
+	return "RespondQueryTaskCompleted"
 }
 
 // EnvelopeType returns the kind of value inside this struct.
 //
 // This will always be Reply for this struct.
-func (v *MatchingService_QueryWorkflow_Result) EnvelopeType() wire.EnvelopeType {
+func (v *MatchingService_RespondQueryTaskCompleted_Result) EnvelopeType() wire.EnvelopeType {
 	return wire.Reply
 }
 
-// MatchingService_RespondQueryTaskCompleted_Args represents the arguments for the MatchingService.RespondQueryTaskCompleted function.
+// MatchingService_UpdateTaskListVersionSets_Args represents the arguments for the MatchingService.UpdateTaskListVersionSets function.
 //
-// The arguments for RespondQueryTaskCompleted are sent and received over the wire as this struct.
-type MatchingService_RespondQueryTaskCompleted_Args struct {
-	Request *RespondQueryTaskCompletedRequest `json:"request,omitempty"`
+// The arguments for UpdateTaskListVersionSets are sent and received over the wire as this struct.
+type MatchingService_UpdateTaskListVersionSets_Args struct {
+	Request *UpdateTaskListVersionSetsRequest `json:"request,omitempty"`
 }
 
-// ToWire translates a MatchingService_RespondQueryTaskCompleted_Args struct into a Thrift-level intermediate
+// ToWire translates a MatchingService_UpdateTaskListVersionSets_Args struct into a Thrift-level intermediate
 // representation. This intermediate representation may be serialized
 // into bytes using a ThriftRW protocol implementation.
 //
@@ -7535,7 +10390,7 @@ type MatchingService_RespondQueryTaskCompleted_Args struct {
 //   if err := binaryProtocol.Encode(x, writer); err != nil {
 //     return err
 //   }
-func (v *MatchingService_RespondQueryTaskCompleted_Args) ToWire() (wire.Value, error) {
+func (v *MatchingService_UpdateTaskListVersionSets_Args) ToWire() (wire.Value, error) {
 	var (
 		fields [1]wire.Field
 		i      int = 0
@@ -7555,17 +10410,17 @@ func (v *MatchingService_RespondQueryTaskCompleted_Args) ToWire() (wire.Value, e
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
 
-func _RespondQueryTaskCompletedRequest_1_Read(w wire.Value) (*RespondQueryTaskCompletedRequest, error) {
-	var v RespondQueryTaskCompletedRequest
+func _UpdateTaskListVersionSetsRequest_Read(w wire.Value) (*UpdateTaskListVersionSetsRequest, error) {
+	var v UpdateTaskListVersionSetsRequest
 	err := v.FromWire(w)
 	return &v, err
 }
 
-// FromWire deserializes a MatchingService_RespondQueryTaskCompleted_Args struct from its Thrift-level
+// FromWire deserializes a MatchingService_UpdateTaskListVersionSets_Args struct from its Thrift-level
 // representation. The Thrift-level representation may be obtained
 // from a ThriftRW protocol implementation.
 //
-// An error is returned if we were unable to build a MatchingService_RespondQueryTaskCompleted_Args struct
+// An error is returned if we were unable to build a MatchingService_UpdateTaskListVersionSets_Args struct
 // from the provided intermediate representation.
 //
 //   x, err := binaryProtocol.Decode(reader, wire.TStruct)
@@ -7573,19 +10428,19 @@ func _RespondQueryTaskCompletedRequest_1_Read(w wire.Value) (*RespondQueryTaskCo
 //     return nil, err
 //   }
 //
-//   var v MatchingService_RespondQueryTaskCompleted_Args
+//   var v MatchingService_UpdateTaskListVersionSets_Args
 //   if err := v.FromWire(x); err != nil {
 //     return nil, err
 //   }
 //   return &v, nil
-func (v *MatchingService_RespondQueryTaskCompleted_Args) FromWire(w wire.Value) error {
+func (v *MatchingService_UpdateTaskListVersionSets_Args) FromWire(w wire.Value) error {
 	var err error
 
 	for _, field := range w.GetStruct().Fields {
 		switch field.ID {
 		case 1:
 			if field.Value.Type() == wire.TStruct {
-				v.Request, err = _RespondQueryTaskCompletedRequest_1_Read(field.Value)
+				v.Request, err = _UpdateTaskListVersionSetsRequest_Read(field.Value)
 				if err != nil {
 					return err
 				}
@@ -7597,9 +10452,9 @@ func (v *MatchingService_RespondQueryTaskCompleted_Args) FromWire(w wire.Value)
 	return nil
 }
 
-// String returns a readable string representation of a MatchingService_RespondQueryTaskCompleted_Args
+// String returns a readable string representation of a MatchingService_UpdateTaskListVersionSets_Args
 // struct.
-func (v *MatchingService_RespondQueryTaskCompleted_Args) String() string {
+func (v *MatchingService_UpdateTaskListVersionSets_Args) String() string {
 	if v == nil {
 		return "<nil>"
 	}
@@ -7611,14 +10466,14 @@ func (v *MatchingService_RespondQueryTaskCompleted_Args) String() string {
 		i++
 	}
 
-	return fmt.Sprintf("MatchingService_RespondQueryTaskCompleted_Args{%v}", strings.Join(fields[:i], ", "))
+	return fmt.Sprintf("MatchingService_UpdateTaskListVersionSets_Args{%v}", strings.Join(fields[:i], ", "))
 }
 
-// Equals returns true if all the fields of this MatchingService_RespondQueryTaskCompleted_Args match the
-// provided MatchingService_RespondQueryTaskCompleted_Args.
+// Equals returns true if all the fields of this MatchingService_UpdateTaskListVersionSets_Args match the
+// provided MatchingService_UpdateTaskListVersionSets_Args.
 //
 // This function performs a deep comparison.
-func (v *MatchingService_RespondQueryTaskCompleted_Args) Equals(rhs *MatchingService_RespondQueryTaskCompleted_Args) bool {
+func (v *MatchingService_UpdateTaskListVersionSets_Args) Equals(rhs *MatchingService_UpdateTaskListVersionSets_Args) bool {
 	if v == nil {
 		return rhs == nil
 	} else if rhs == nil {
@@ -7632,8 +10487,8 @@ func (v *MatchingService_RespondQueryTaskCompleted_Args) Equals(rhs *MatchingSer
 }
 
 // MarshalLogObject implements zapcore.ObjectMarshaler, enabling
-// fast logging of MatchingService_RespondQueryTaskCompleted_Args.
-func (v *MatchingService_RespondQueryTaskCompleted_Args) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+// fast logging of MatchingService_UpdateTaskListVersionSets_Args.
+func (v *MatchingService_UpdateTaskListVersionSets_Args) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
 	if v == nil {
 		return nil
 	}
@@ -7645,7 +10500,7 @@ func (v *MatchingService_RespondQueryTaskCompleted_Args) MarshalLogObject(enc za
 
 // GetRequest returns the value of Request if it is set or its
 // zero value if it is unset.
-func (v *MatchingService_RespondQueryTaskCompleted_Args) GetRequest() (o *RespondQueryTaskCompletedRequest) {
+func (v *MatchingService_UpdateTaskListVersionSets_Args) GetRequest() (o *UpdateTaskListVersionSetsRequest) {
 	if v != nil && v.Request != nil {
 		return v.Request
 	}
@@ -7654,90 +10509,86 @@ func (v *MatchingService_RespondQueryTaskCompleted_Args) GetRequest() (o *Respon
 }
 
 // IsSetRequest returns true if Request is not nil.
-func (v *MatchingService_RespondQueryTaskCompleted_Args) IsSetRequest() bool {
+func (v *MatchingService_UpdateTaskListVersionSets_Args) IsSetRequest() bool {
 	return v != nil && v.Request != nil
 }
 
 // MethodName returns the name of the Thrift function as specified in
 // the IDL, for which this struct represent the arguments.
 //
-// This will always be "RespondQueryTaskCompleted" for this struct.
-func (v *MatchingService_RespondQueryTaskCompleted_Args) MethodName() string {
-	return "RespondQueryTaskCompleted"
+// This will always be "UpdateTaskListVersionSets" for this struct.
+func (v *MatchingService_UpdateTaskListVersionSets_Args) MethodName() string {
+	return "UpdateTaskListVersionSets"
 }
 
 // EnvelopeType returns the kind of value inside this struct.
 //
 // This will always be Call for this struct.
-func (v *MatchingService_RespondQueryTaskCompleted_Args) EnvelopeType() wire.EnvelopeType {
+func (v *MatchingService_UpdateTaskListVersionSets_Args) EnvelopeType() wire.EnvelopeType {
 	return wire.Call
 }
 
-// MatchingService_RespondQueryTaskCompleted_Helper provides functions that aid in handling the
-// parameters and return values of the MatchingService.RespondQueryTaskCompleted
+// MatchingService_UpdateTaskListVersionSets_Helper provides functions that aid in handling the
+// parameters and return values of the MatchingService.UpdateTaskListVersionSets
 // function.
-var MatchingService_RespondQueryTaskCompleted_Helper = struct {
-	// Args accepts the parameters of RespondQueryTaskCompleted in-order and returns
+var MatchingService_UpdateTaskListVersionSets_Helper = struct {
+	// Args accepts the parameters of UpdateTaskListVersionSets in-order and returns
 	// the arguments struct for the function.
 	Args func(
-		request *RespondQueryTaskCompletedRequest,
-	) *MatchingService_RespondQueryTaskCompleted_Args
+		request *UpdateTaskListVersionSetsRequest,
+	) *MatchingService_UpdateTaskListVersionSets_Args
 
 	// IsException returns true if the given error can be thrown
-	// by RespondQueryTaskCompleted.
+	// by UpdateTaskListVersionSets.
 	//
-	// An error can be thrown by RespondQueryTaskCompleted only if the
+	// An error can be thrown by UpdateTaskListVersionSets only if the
 	// corresponding exception type was mentioned in the 'throws'
 	// section for it in the Thrift file.
 	IsException func(error) bool
 
-	// WrapResponse returns the result struct for RespondQueryTaskCompleted
+	// WrapResponse returns the result struct for UpdateTaskListVersionSets
 	// given the error returned by it. The provided error may
-	// be nil if RespondQueryTaskCompleted did not fail.
+	// be nil if UpdateTaskListVersionSets did not fail.
 	//
-	// This allows mapping errors returned by RespondQueryTaskCompleted into a
+	// This allows mapping errors returned by UpdateTaskListVersionSets into a
 	// serializable result struct. WrapResponse returns a
 	// non-nil error if the provided error cannot be thrown by
-	// RespondQueryTaskCompleted
+	// UpdateTaskListVersionSets
 	//
-	//   err := RespondQueryTaskCompleted(args)
-	//   result, err := MatchingService_RespondQueryTaskCompleted_Helper.WrapResponse(err)
+	//   err := UpdateTaskListVersionSets(args)
+	//   result, err := MatchingService_UpdateTaskListVersionSets_Helper.WrapResponse(err)
 	//   if err != nil {
-	//     return fmt.Errorf("unexpected error from RespondQueryTaskCompleted: %v", err)
+	//     return fmt.Errorf("unexpected error from UpdateTaskListVersionSets: %v", err)
 	//   }
 	//   serialize(result)
-	WrapResponse func(error) (*MatchingService_RespondQueryTaskCompleted_Result, error)
+	WrapResponse func(error) (*MatchingService_UpdateTaskListVersionSets_Result, error)
 
-	// UnwrapResponse takes the result struct for RespondQueryTaskCompleted
+	// UnwrapResponse takes the result struct for UpdateTaskListVersionSets
 	// and returns the erorr returned by it (if any).
 	//
-	// The error is non-nil only if RespondQueryTaskCompleted threw an
+	// The error is non-nil only if UpdateTaskListVersionSets threw an
 	// exception.
 	//
 	//   result := deserialize(bytes)
-	//   err := MatchingService_RespondQueryTaskCompleted_Helper.UnwrapResponse(result)
-	UnwrapResponse func(*MatchingService_RespondQueryTaskCompleted_Result) error
+	//   err := MatchingService_UpdateTaskListVersionSets_Helper.UnwrapResponse(result)
+	UnwrapResponse func(*MatchingService_UpdateTaskListVersionSets_Result) error
 }{}
 
 func init() {
-	MatchingService_RespondQueryTaskCompleted_Helper.Args = func(
-		request *RespondQueryTaskCompletedRequest,
-	) *MatchingService_RespondQueryTaskCompleted_Args {
-		return &MatchingService_RespondQueryTaskCompleted_Args{
+	MatchingService_UpdateTaskListVersionSets_Helper.Args = func(
+		request *UpdateTaskListVersionSetsRequest,
+	) *MatchingService_UpdateTaskListVersionSets_Args {
+		return &MatchingService_UpdateTaskListVersionSets_Args{
 			Request: request,
 		}
 	}
 
-	MatchingService_RespondQueryTaskCompleted_Helper.IsException = func(err error) bool {
+	MatchingService_UpdateTaskListVersionSets_Helper.IsException = func(err error) bool {
 		switch err.(type) {
 		case *shared.BadRequestError:
 			return true
 		case *shared.InternalServiceError:
 			return true
-		case *shared.EntityNotExistsError:
-			return true
-		case *shared.LimitExceededError:
-			return true
 		case *shared.ServiceBusyError:
 			return true
 		default:
@@ -7745,42 +10596,32 @@ func init() {
 		}
 	}
 
-	MatchingService_RespondQueryTaskCompleted_Helper.WrapResponse = func(err error) (*MatchingService_RespondQueryTaskCompleted_Result, error) {
+	MatchingService_UpdateTaskListVersionSets_Helper.WrapResponse = func(err error) (*MatchingService_UpdateTaskListVersionSets_Result, error) {
 		if err == nil {
-			return &MatchingService_RespondQueryTaskCompleted_Result{}, nil
+			return &MatchingService_UpdateTaskListVersionSets_Result{}, nil
 		}
 
 		switch e := err.(type) {
 		case *shared.BadRequestError:
 			if e == nil {
-				return nil, errors.New("WrapResponse received non-nil error type with nil value for MatchingService_RespondQueryTaskCompleted_Result.BadRequestError")
+				return nil, errors.New("WrapResponse received non-nil error type with nil value for MatchingService_UpdateTaskListVersionSets_Result.BadRequestError")
 			}
-			return &MatchingService_RespondQueryTaskCompleted_Result{BadRequestError: e}, nil
+			return &MatchingService_UpdateTaskListVersionSets_Result{BadRequestError: e}, nil
 		case *shared.InternalServiceError:
 			if e == nil {
-				return nil, errors.New("WrapResponse received non-nil error type with nil value for MatchingService_RespondQueryTaskCompleted_Result.InternalServiceError")
-			}
-			return &MatchingService_RespondQueryTaskCompleted_Result{InternalServiceError: e}, nil
-		case *shared.EntityNotExistsError:
-			if e == nil {
-				return nil, errors.New("WrapResponse received non-nil error type with nil value for MatchingService_RespondQueryTaskCompleted_Result.EntityNotExistError")
-			}
-			return &MatchingService_RespondQueryTaskCompleted_Result{EntityNotExistError: e}, nil
-		case *shared.LimitExceededError:
-			if e == nil {
-				return nil, errors.New("WrapResponse received non-nil error type with nil value for MatchingService_RespondQueryTaskCompleted_Result.LimitExceededError")
+				return nil, errors.New("WrapResponse received non-nil error type with nil value for MatchingService_UpdateTaskListVersionSets_Result.InternalServiceError")
 			}
-			return &MatchingService_RespondQueryTaskCompleted_Result{LimitExceededError: e}, nil
+			return &MatchingService_UpdateTaskListVersionSets_Result{InternalServiceError: e}, nil
 		case *shared.ServiceBusyError:
 			if e == nil {
-				return nil, errors.New("WrapResponse received non-nil error type with nil value for MatchingService_RespondQueryTaskCompleted_Result.ServiceBusyError")
+				return nil, errors.New("WrapResponse received non-nil error type with nil value for MatchingService_UpdateTaskListVersionSets_Result.ServiceBusyError")
 			}
-			return &MatchingService_RespondQueryTaskCompleted_Result{ServiceBusyError: e}, nil
+			return &MatchingService_UpdateTaskListVersionSets_Result{ServiceBusyError: e}, nil
 		}
 
 		return nil, err
 	}
-	MatchingService_RespondQueryTaskCompleted_Helper.UnwrapResponse = func(result *MatchingService_RespondQueryTaskCompleted_Result) (err error) {
+	MatchingService_UpdateTaskListVersionSets_Helper.UnwrapResponse = func(result *MatchingService_UpdateTaskListVersionSets_Result) (err error) {
 		if result.BadRequestError != nil {
 			err = result.BadRequestError
 			return
@@ -7789,14 +10630,6 @@ func init() {
 			err = result.InternalServiceError
 			return
 		}
-		if result.EntityNotExistError != nil {
-			err = result.EntityNotExistError
-			return
-		}
-		if result.LimitExceededError != nil {
-			err = result.LimitExceededError
-			return
-		}
 		if result.ServiceBusyError != nil {
 			err = result.ServiceBusyError
 			return
@@ -7806,18 +10639,16 @@ func init() {
 
 }
 
-// MatchingService_RespondQueryTaskCompleted_Result represents the result of a MatchingService.RespondQueryTaskCompleted function call.
+// MatchingService_UpdateTaskListVersionSets_Result represents the result of a MatchingService.UpdateTaskListVersionSets function call.
 //
-// The result of a RespondQueryTaskCompleted execution is sent and received over the wire as this struct.
-type MatchingService_RespondQueryTaskCompleted_Result struct {
+// The result of a UpdateTaskListVersionSets execution is sent and received over the wire as this struct.
+type MatchingService_UpdateTaskListVersionSets_Result struct {
 	BadRequestError      *shared.BadRequestError      `json:"badRequestError,omitempty"`
 	InternalServiceError *shared.InternalServiceError `json:"internalServiceError,omitempty"`
-	EntityNotExistError  *shared.EntityNotExistsError `json:"entityNotExistError,omitempty"`
-	LimitExceededError   *shared.LimitExceededError   `json:"limitExceededError,omitempty"`
 	ServiceBusyError     *shared.ServiceBusyError     `json:"serviceBusyError,omitempty"`
 }
 
-// ToWire translates a MatchingService_RespondQueryTaskCompleted_Result struct into a Thrift-level intermediate
+// ToWire translates a MatchingService_UpdateTaskListVersionSets_Result struct into a Thrift-level intermediate
 // representation. This intermediate representation may be serialized
 // into bytes using a ThriftRW protocol implementation.
 //
@@ -7832,9 +10663,9 @@ type MatchingService_RespondQueryTaskCompleted_Result struct {
 //   if err := binaryProtocol.Encode(x, writer); err != nil {
 //     return err
 //   }
-func (v *MatchingService_RespondQueryTaskCompleted_Result) ToWire() (wire.Value, error) {
+func (v *MatchingService_UpdateTaskListVersionSets_Result) ToWire() (wire.Value, error) {
 	var (
-		fields [5]wire.Field
+		fields [3]wire.Field
 		i      int = 0
 		w      wire.Value
 		err    error
@@ -7856,43 +10687,27 @@ func (v *MatchingService_RespondQueryTaskCompleted_Result) ToWire() (wire.Value,
 		fields[i] = wire.Field{ID: 2, Value: w}
 		i++
 	}
-	if v.EntityNotExistError != nil {
-		w, err = v.EntityNotExistError.ToWire()
-		if err != nil {
-			return w, err
-		}
-		fields[i] = wire.Field{ID: 3, Value: w}
-		i++
-	}
-	if v.LimitExceededError != nil {
-		w, err = v.LimitExceededError.ToWire()
-		if err != nil {
-			return w, err
-		}
-		fields[i] = wire.Field{ID: 4, Value: w}
-		i++
-	}
 	if v.ServiceBusyError != nil {
 		w, err = v.ServiceBusyError.ToWire()
 		if err != nil {
 			return w, err
 		}
-		fields[i] = wire.Field{ID: 5, Value: w}
+		fields[i] = wire.Field{ID: 3, Value: w}
 		i++
 	}
 
 	if i > 1 {
-		return wire.Value{}, fmt.Errorf("MatchingService_RespondQueryTaskCompleted_Result should have at most one field: got %v fields", i)
+		return wire.Value{}, fmt.Errorf("MatchingService_UpdateTaskListVersionSets_Result should have at most one field: got %v fields", i)
 	}
 
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
 
-// FromWire deserializes a MatchingService_RespondQueryTaskCompleted_Result struct from its Thrift-level
+// FromWire deserializes a MatchingService_UpdateTaskListVersionSets_Result struct from its Thrift-level
 // representation. The Thrift-level representation may be obtained
 // from a ThriftRW protocol implementation.
 //
-// An error is returned if we were unable to build a MatchingService_RespondQueryTaskCompleted_Result struct
+// An error is returned if we were unable to build a MatchingService_UpdateTaskListVersionSets_Result struct
 // from the provided intermediate representation.
 //
 //   x, err := binaryProtocol.Decode(reader, wire.TStruct)
@@ -7900,12 +10715,12 @@ func (v *MatchingService_RespondQueryTaskCompleted_Result) ToWire() (wire.Value,
 //     return nil, err
 //   }
 //
-//   var v MatchingService_RespondQueryTaskCompleted_Result
+//   var v MatchingService_UpdateTaskListVersionSets_Result
 //   if err := v.FromWire(x); err != nil {
 //     return nil, err
 //   }
 //   return &v, nil
-func (v *MatchingService_RespondQueryTaskCompleted_Result) FromWire(w wire.Value) error {
+func (v *MatchingService_UpdateTaskListVersionSets_Result) FromWire(w wire.Value) error {
 	var err error
 
 	for _, field := range w.GetStruct().Fields {
@@ -7927,22 +10742,6 @@ func (v *MatchingService_RespondQueryTaskCompleted_Result) FromWire(w wire.Value
 
 			}
 		case 3:
-			if field.Value.Type() == wire.TStruct {
-				v.EntityNotExistError, err = _EntityNotExistsError_Read(field.Value)
-				if err != nil {
-					return err
-				}
-
-			}
-		case 4:
-			if field.Value.Type() == wire.TStruct {
-				v.LimitExceededError, err = _LimitExceededError_Read(field.Value)
-				if err != nil {
-					return err
-				}
-
-			}
-		case 5:
 			if field.Value.Type() == wire.TStruct {
 				v.ServiceBusyError, err = _ServiceBusyError_Read(field.Value)
 				if err != nil {
@@ -7960,30 +10759,24 @@ func (v *MatchingService_RespondQueryTaskCompleted_Result) FromWire(w wire.Value
 	if v.InternalServiceError != nil {
 		count++
 	}
-	if v.EntityNotExistError != nil {
-		count++
-	}
-	if v.LimitExceededError != nil {
-		count++
-	}
 	if v.ServiceBusyError != nil {
 		count++
 	}
 	if count > 1 {
-		return fmt.Errorf("MatchingService_RespondQueryTaskCompleted_Result should have at most one field: got %v fields", count)
+		return fmt.Errorf("MatchingService_UpdateTaskListVersionSets_Result should have at most one field: got %v fields", count)
 	}
 
 	return nil
 }
 
-// String returns a readable string representation of a MatchingService_RespondQueryTaskCompleted_Result
+// String returns a readable string representation of a MatchingService_UpdateTaskListVersionSets_Result
 // struct.
-func (v *MatchingService_RespondQueryTaskCompleted_Result) String() string {
+func (v *MatchingService_UpdateTaskListVersionSets_Result) String() string {
 	if v == nil {
 		return "<nil>"
 	}
 
-	var fields [5]string
+	var fields [3]string
 	i := 0
 	if v.BadRequestError != nil {
 		fields[i] = fmt.Sprintf("BadRequestError: %v", v.BadRequestError)
@@ -7993,27 +10786,19 @@ func (v *MatchingService_RespondQueryTaskCompleted_Result) String() string {
 		fields[i] = fmt.Sprintf("InternalServiceError: %v", v.InternalServiceError)
 		i++
 	}
-	if v.EntityNotExistError != nil {
-		fields[i] = fmt.Sprintf("EntityNotExistError: %v", v.EntityNotExistError)
-		i++
-	}
-	if v.LimitExceededError != nil {
-		fields[i] = fmt.Sprintf("LimitExceededError: %v", v.LimitExceededError)
-		i++
-	}
 	if v.ServiceBusyError != nil {
 		fields[i] = fmt.Sprintf("ServiceBusyError: %v", v.ServiceBusyError)
 		i++
 	}
 
-	return fmt.Sprintf("MatchingService_RespondQueryTaskCompleted_Result{%v}", strings.Join(fields[:i], ", "))
+	return fmt.Sprintf("MatchingService_UpdateTaskListVersionSets_Result{%v}", strings.Join(fields[:i], ", "))
 }
 
-// Equals returns true if all the fields of this MatchingService_RespondQueryTaskCompleted_Result match the
-// provided MatchingService_RespondQueryTaskCompleted_Result.
+// Equals returns true if all the fields of this MatchingService_UpdateTaskListVersionSets_Result match the
+// provided MatchingService_UpdateTaskListVersionSets_Result.
 //
 // This function performs a deep comparison.
-func (v *MatchingService_RespondQueryTaskCompleted_Result) Equals(rhs *MatchingService_RespondQueryTaskCompleted_Result) bool {
+func (v *MatchingService_UpdateTaskListVersionSets_Result) Equals(rhs *MatchingService_UpdateTaskListVersionSets_Result) bool {
 	if v == nil {
 		return rhs == nil
 	} else if rhs == nil {
@@ -8025,12 +10810,6 @@ func (v *MatchingService_RespondQueryTaskCompleted_Result) Equals(rhs *MatchingS
 	if !((v.InternalServiceError == nil && rhs.InternalServiceError == nil) || (v.InternalServiceError != nil && rhs.InternalServiceError != nil && v.InternalServiceError.Equals(rhs.InternalServiceError))) {
 		return false
 	}
-	if !((v.EntityNotExistError == nil && rhs.EntityNotExistError == nil) || (v.EntityNotExistError != nil && rhs.EntityNotExistError != nil && v.EntityNotExistError.Equals(rhs.EntityNotExistError))) {
-		return false
-	}
-	if !((v.LimitExceededError == nil && rhs.LimitExceededError == nil) || (v.LimitExceededError != nil && rhs.LimitExceededError != nil && v.LimitExceededError.Equals(rhs.LimitExceededError))) {
-		return false
-	}
 	if !((v.ServiceBusyError == nil && rhs.ServiceBusyError == nil) || (v.ServiceBusyError != nil && rhs.ServiceBusyError != nil && v.ServiceBusyError.Equals(rhs.ServiceBusyError))) {
 		return false
 	}
@@ -8039,8 +10818,8 @@ func (v *MatchingService_RespondQueryTaskCompleted_Result) Equals(rhs *MatchingS
 }
 
 // MarshalLogObject implements zapcore.ObjectMarshaler, enabling
-// fast logging of MatchingService_RespondQueryTaskCompleted_Result.
-func (v *MatchingService_RespondQueryTaskCompleted_Result) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+// fast logging of MatchingService_UpdateTaskListVersionSets_Result.
+func (v *MatchingService_UpdateTaskListVersionSets_Result) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
 	if v == nil {
 		return nil
 	}
@@ -8050,12 +10829,6 @@ func (v *MatchingService_RespondQueryTaskCompleted_Result) MarshalLogObject(enc
 	if v.InternalServiceError != nil {
 		err = multierr.Append(err, enc.AddObject("internalServiceError", v.InternalServiceError))
 	}
-	if v.EntityNotExistError != nil {
-		err = multierr.Append(err, enc.AddObject("entityNotExistError", v.EntityNotExistError))
-	}
-	if v.LimitExceededError != nil {
-		err = multierr.Append(err, enc.AddObject("limitExceededError", v.LimitExceededError))
-	}
 	if v.ServiceBusyError != nil {
 		err = multierr.Append(err, enc.AddObject("serviceBusyError", v.ServiceBusyError))
 	}
@@ -8064,7 +10837,7 @@ func (v *MatchingService_RespondQueryTaskCompleted_Result) MarshalLogObject(enc
 
 // GetBadRequestError returns the value of BadRequestError if it is set or its
 // zero value if it is unset.
-func (v *MatchingService_RespondQueryTaskCompleted_Result) GetBadRequestError() (o *shared.BadRequestError) {
+func (v *MatchingService_UpdateTaskListVersionSets_Result) GetBadRequestError() (o *shared.BadRequestError) {
 	if v != nil && v.BadRequestError != nil {
 		return v.BadRequestError
 	}
@@ -8073,13 +10846,13 @@ func (v *MatchingService_RespondQueryTaskCompleted_Result) GetBadRequestError()
 }
 
 // IsSetBadRequestError returns true if BadRequestError is not nil.
-func (v *MatchingService_RespondQueryTaskCompleted_Result) IsSetBadRequestError() bool {
+func (v *MatchingService_UpdateTaskListVersionSets_Result) IsSetBadRequestError() bool {
 	return v != nil && v.BadRequestError != nil
 }
 
 // GetInternalServiceError returns the value of InternalServiceError if it is set or its
 // zero value if it is unset.
-func (v *MatchingService_RespondQueryTaskCompleted_Result) GetInternalServiceError() (o *shared.InternalServiceError) {
+func (v *MatchingService_UpdateTaskListVersionSets_Result) GetInternalServiceError() (o *shared.InternalServiceError) {
 	if v != nil && v.InternalServiceError != nil {
 		return v.InternalServiceError
 	}
@@ -8088,43 +10861,13 @@ func (v *MatchingService_RespondQueryTaskCompleted_Result) GetInternalServiceErr
 }
 
 // IsSetInternalServiceError returns true if InternalServiceError is not nil.
-func (v *MatchingService_RespondQueryTaskCompleted_Result) IsSetInternalServiceError() bool {
+func (v *MatchingService_UpdateTaskListVersionSets_Result) IsSetInternalServiceError() bool {
 	return v != nil && v.InternalServiceError != nil
 }
 
-// GetEntityNotExistError returns the value of EntityNotExistError if it is set or its
-// zero value if it is unset.
-func (v *MatchingService_RespondQueryTaskCompleted_Result) GetEntityNotExistError() (o *shared.EntityNotExistsError) {
-	if v != nil && v.EntityNotExistError != nil {
-		return v.EntityNotExistError
-	}
-
-	return
-}
-
-// IsSetEntityNotExistError returns true if EntityNotExistError is not nil.
-func (v *MatchingService_RespondQueryTaskCompleted_Result) IsSetEntityNotExistError() bool {
-	return v != nil && v.EntityNotExistError != nil
-}
-
-// GetLimitExceededError returns the value of LimitExceededError if it is set or its
-// zero value if it is unset.
-func (v *MatchingService_RespondQueryTaskCompleted_Result) GetLimitExceededError() (o *shared.LimitExceededError) {
-	if v != nil && v.LimitExceededError != nil {
-		return v.LimitExceededError
-	}
-
-	return
-}
-
-// IsSetLimitExceededError returns true if LimitExceededError is not nil.
-func (v *MatchingService_RespondQueryTaskCompleted_Result) IsSetLimitExceededError() bool {
-	return v != nil && v.LimitExceededError != nil
-}
-
 // GetServiceBusyError returns the value of ServiceBusyError if it is set or its
 // zero value if it is unset.
-func (v *MatchingService_RespondQueryTaskCompleted_Result) GetServiceBusyError() (o *shared.ServiceBusyError) {
+func (v *MatchingService_UpdateTaskListVersionSets_Result) GetServiceBusyError() (o *shared.ServiceBusyError) {
 	if v != nil && v.ServiceBusyError != nil {
 		return v.ServiceBusyError
 	}
@@ -8133,21 +10876,21 @@ func (v *MatchingService_RespondQueryTaskCompleted_Result) GetServiceBusyError()
 }
 
 // IsSetServiceBusyError returns true if ServiceBusyError is not nil.
-func (v *MatchingService_RespondQueryTaskCompleted_Result) IsSetServiceBusyError() bool {
+func (v *MatchingService_UpdateTaskListVersionSets_Result) IsSetServiceBusyError() bool {
 	return v != nil && v.ServiceBusyError != nil
 }
 
 // MethodName returns the name of the Thrift function as specified in
 // the IDL, for which this struct represent the result.
 //
-// This will always be "RespondQueryTaskCompleted" for this struct.
-func (v *MatchingService_RespondQueryTaskCompleted_Result) MethodName() string {
-	return "RespondQueryTaskCompleted"
+// This will always be "UpdateTaskListVersionSets" for this struct.
+func (v *MatchingService_UpdateTaskListVersionSets_Result) MethodName() string {
+	return "UpdateTaskListVersionSets"
 }
 
 // EnvelopeType returns the kind of value inside this struct.
 //
 // This will always be Reply for this struct.
-func (v *MatchingService_RespondQueryTaskCompleted_Result) EnvelopeType() wire.EnvelopeType {
+func (v *MatchingService_UpdateTaskListVersionSets_Result) EnvelopeType() wire.EnvelopeType {
 	return wire.Reply
 }
@@ -54,12 +54,24 @@ type Interface interface {
 		opts ...yarpc.CallOption,
 	) error
 
+	DescribeTaskJourney(
+		ctx context.Context,
+		Request *matching.DescribeTaskJourneyRequest,
+		opts ...yarpc.CallOption,
+	) (*shared.DescribeTaskJourneyResponse, error)
+
 	DescribeTaskList(
 		ctx context.Context,
 		Request *matching.DescribeTaskListRequest,
 		opts ...yarpc.CallOption,
 	) (*shared.DescribeTaskListResponse, error)
 
+	GetTaskListScalingHints(
+		ctx context.Context,
+		Request *matching.GetTaskListScalingHintsRequest,
+		opts ...yarpc.CallOption,
+	) (*shared.GetTaskListScalingHintsResponse, error)
+
 	PollForActivityTask(
 		ctx context.Context,
 		PollRequest *matching.PollForActivityTaskRequest,
@@ -83,6 +95,12 @@ type Interface interface {
 		Request *matching.RespondQueryTaskCompletedRequest,
 		opts ...yarpc.CallOption,
 	) error
+
+	UpdateTaskListVersionSets(
+		ctx context.Context,
+		Request *matching.UpdateTaskListVersionSetsRequest,
+		opts ...yarpc.CallOption,
+	) error
 }
 
 // New builds a new client for the MatchingService service.
@@ -178,6 +196,29 @@ func (c client) CancelOutstandingPoll(
 	return
 }
 
+func (c client) DescribeTaskJourney(
+	ctx context.Context,
+	_Request *matching.DescribeTaskJourneyRequest,
+	opts ...yarpc.CallOption,
+) (success *shared.DescribeTaskJourneyResponse, err error) {
+
+	args := matching.MatchingService_DescribeTaskJourney_Helper.Args(_Request)
+
+	var body wire.Value
+	body, err = c.c.Call(ctx, args, opts...)
+	if err != nil {
+		return
+	}
+
+	var result matching.MatchingService_DescribeTaskJourney_Result
+	if err = result.FromWire(body); err != nil {
+		return
+	}
+
+	success, err = matching.MatchingService_DescribeTaskJourney_Helper.UnwrapResponse(&result)
+	return
+}
+
 func (c client) DescribeTaskList(
 	ctx context.Context,
 	_Request *matching.DescribeTaskListRequest,
@@ -201,6 +242,29 @@ func (c client) DescribeTaskList(
 	return
 }
 
+func (c client) GetTaskListScalingHints(
+	ctx context.Context,
+	_Request *matching.GetTaskListScalingHintsRequest,
+	opts ...yarpc.CallOption,
+) (success *shared.GetTaskListScalingHintsResponse, err error) {
+
+	args := matching.MatchingService_GetTaskListScalingHints_Helper.Args(_Request)
+
+	var body wire.Value
+	body, err = c.c.Call(ctx, args, opts...)
+	if err != nil {
+		return
+	}
+
+	var result matching.MatchingService_GetTaskListScalingHints_Result
+	if err = result.FromWire(body); err != nil {
+		return
+	}
+
+	success, err = matching.MatchingService_GetTaskListScalingHints_Helper.UnwrapResponse(&result)
+	return
+}
+
 func (c client) PollForActivityTask(
 	ctx context.Context,
 	_PollRequest *matching.PollForActivityTaskRequest,
@@ -292,3 +356,26 @@ func (c client) RespondQueryTaskCompleted(
 	err = matching.MatchingService_RespondQueryTaskCompleted_Helper.UnwrapResponse(&result)
 	return
 }
+
+func (c client) UpdateTaskListVersionSets(
+	ctx context.Context,
+	_Request *matching.UpdateTaskListVersionSetsRequest,
+	opts ...yarpc.CallOption,
+) (err error) {
+
+	args := matching.MatchingService_UpdateTaskListVersionSets_Helper.Args(_Request)
+
+	var body wire.Value
+	body, err = c.c.Call(ctx, args, opts...)
+	if err != nil {
+		return
+	}
+
+	var result matching.MatchingService_UpdateTaskListVersionSets_Result
+	if err = result.FromWire(body); err != nil {
+		return
+	}
+
+	err = matching.MatchingService_UpdateTaskListVersionSets_Helper.UnwrapResponse(&result)
+	return
+}
@@ -49,11 +49,21 @@ type Interface interface {
 		Request *matching.CancelOutstandingPollRequest,
 	) error
 
+	DescribeTaskJourney(
+		ctx context.Context,
+		Request *matching.DescribeTaskJourneyRequest,
+	) (*shared.DescribeTaskJourneyResponse, error)
+
 	DescribeTaskList(
 		ctx context.Context,
 		Request *matching.DescribeTaskListRequest,
 	) (*shared.DescribeTaskListResponse, error)
 
+	GetTaskListScalingHints(
+		ctx context.Context,
+		Request *matching.GetTaskListScalingHintsRequest,
+	) (*shared.GetTaskListScalingHintsResponse, error)
+
 	PollForActivityTask(
 		ctx context.Context,
 		PollRequest *matching.PollForActivityTaskRequest,
@@ -73,6 +83,11 @@ type Interface interface {
 		ctx context.Context,
 		Request *matching.RespondQueryTaskCompletedRequest,
 	) error
+
+	UpdateTaskListVersionSets(
+		ctx context.Context,
+		Request *matching.UpdateTaskListVersionSetsRequest,
+	) error
 }
 
 // New prepares an implementation of the MatchingService service for
@@ -119,6 +134,17 @@ func New(impl Interface, opts ...thrift.RegisterOption) []transport.Procedure {
 				ThriftModule: matching.ThriftModule,
 			},
 
+			thrift.Method{
+				Name: "DescribeTaskJourney",
+				HandlerSpec: thrift.HandlerSpec{
+
+					Type:  transport.Unary,
+					Unary: thrift.UnaryHandler(h.DescribeTaskJourney),
+				},
+				Signature:    "DescribeTaskJourney(Request *matching.DescribeTaskJourneyRequest) (*shared.DescribeTaskJourneyResponse)",
+				ThriftModule: matching.ThriftModule,
+			},
+
 			thrift.Method{
 				Name: "DescribeTaskList",
 				HandlerSpec: thrift.HandlerSpec{
@@ -130,6 +156,17 @@ func New(impl Interface, opts ...thrift.RegisterOption) []transport.Procedure {
 				ThriftModule: matching.ThriftModule,
 			},
 
+			thrift.Method{
+				Name: "GetTaskListScalingHints",
+				HandlerSpec: thrift.HandlerSpec{
+
+					Type:  transport.Unary,
+					Unary: thrift.UnaryHandler(h.GetTaskListScalingHints),
+				},
+				Signature:    "GetTaskListScalingHints(Request *matching.GetTaskListScalingHintsRequest) (*shared.GetTaskListScalingHintsResponse)",
+				ThriftModule: matching.ThriftModule,
+			},
+
 			thrift.Method{
 				Name: "PollForActivityTask",
 				HandlerSpec: thrift.HandlerSpec{
@@ -173,10 +210,21 @@ func New(impl Interface, opts ...thrift.RegisterOption) []transport.Procedure {
 				Signature:    "RespondQueryTaskCompleted(Request *matching.RespondQueryTaskCompletedRequest)",
 				ThriftModule: matching.ThriftModule,
 			},
+
+			thrift.Method{
+				Name: "UpdateTaskListVersionSets",
+				HandlerSpec: thrift.HandlerSpec{
+
+					Type:  transport.Unary,
+					Unary: thrift.UnaryHandler(h.UpdateTaskListVersionSets),
+				},
+				Signature:    "UpdateTaskListVersionSets(Request *matching.UpdateTaskListVersionSetsRequest)",
+				ThriftModule: matching.ThriftModule,
+			},
 		},
 	}
 
-	procedures := make([]transport.Procedure, 0, 8)
+	procedures := make([]transport.Procedure, 0, 11)
 	procedures = append(procedures, thrift.BuildProcedures(service, opts...)...)
 	return procedures
 }
@@ -240,6 +288,25 @@ func (h handler) CancelOutstandingPoll(ctx context.Context, body wire.Value) (th
 	return response, err
 }
 
+func (h handler) DescribeTaskJourney(ctx context.Context, body wire.Value) (thrift.Response, error) {
+	var args matching.MatchingService_DescribeTaskJourney_Args
+	if err := args.FromWire(body); err != nil {
+		return thrift.Response{}, err
+	}
+
+	success, err := h.impl.DescribeTaskJourney(ctx, args.Request)
+
+	hadError := err != nil
+	result, err := matching.MatchingService_DescribeTaskJourney_Helper.WrapResponse(success, err)
+
+	var response thrift.Response
+	if err == nil {
+		response.IsApplicationError = hadError
+		response.Body = result
+	}
+	return response, err
+}
+
 func (h handler) DescribeTaskList(ctx context.Context, body wire.Value) (thrift.Response, error) {
 	var args matching.MatchingService_DescribeTaskList_Args
 	if err := args.FromWire(body); err != nil {
@@ -259,6 +326,25 @@ func (h handler) DescribeTaskList(ctx context.Context, body wire.Value) (thrift.
 	return response, err
 }
 
+func (h handler) GetTaskListScalingHints(ctx context.Context, body wire.Value) (thrift.Response, error) {
+	var args matching.MatchingService_GetTaskListScalingHints_Args
+	if err := args.FromWire(body); err != nil {
+		return thrift.Response{}, err
+	}
+
+	success, err := h.impl.GetTaskListScalingHints(ctx, args.Request)
+
+	hadError := err != nil
+	result, err := matching.MatchingService_GetTaskListScalingHints_Helper.WrapResponse(success, err)
+
+	var response thrift.Response
+	if err == nil {
+		response.IsApplicationError = hadError
+		response.Body = result
+	}
+	return response, err
+}
+
 func (h handler) PollForActivityTask(ctx context.Context, body wire.Value) (thrift.Response, error) {
 	var args matching.MatchingService_PollForActivityTask_Args
 	if err := args.FromWire(body); err != nil {
@@ -334,3 +420,22 @@ func (h handler) RespondQueryTaskCompleted(ctx context.Context, body wire.Value)
 	}
 	return response, err
 }
+
+func (h handler) UpdateTaskListVersionSets(ctx context.Context, body wire.Value) (thrift.Response, error) {
+	var args matching.MatchingService_UpdateTaskListVersionSets_Args
+	if err := args.FromWire(body); err != nil {
+		return thrift.Response{}, err
+	}
+
+	err := h.impl.UpdateTaskListVersionSets(ctx, args.Request)
+
+	hadError := err != nil
+	result, err := matching.MatchingService_UpdateTaskListVersionSets_Helper.WrapResponse(err)
+
+	var response thrift.Response
+	if err == nil {
+		response.IsApplicationError = hadError
+		response.Body = result
+	}
+	return response, err
+}
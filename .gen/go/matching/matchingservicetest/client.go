@@ -156,6 +156,39 @@ func (mr *_MockClientRecorder) CancelOutstandingPoll(
 	return mr.mock.ctrl.RecordCall(mr.mock, "CancelOutstandingPoll", args...)
 }
 
+// DescribeTaskJourney responds to a DescribeTaskJourney call based on the mock expectations. This
+// call will fail if the mock does not expect this call. Use EXPECT to expect
+// a call to this function.
+//
+// 	client.EXPECT().DescribeTaskJourney(gomock.Any(), ...).Return(...)
+// 	... := client.DescribeTaskJourney(...)
+func (m *MockClient) DescribeTaskJourney(
+	ctx context.Context,
+	_Request *matching.DescribeTaskJourneyRequest,
+	opts ...yarpc.CallOption,
+) (success *shared.DescribeTaskJourneyResponse, err error) {
+
+	args := []interface{}{ctx, _Request}
+	for _, o := range opts {
+		args = append(args, o)
+	}
+	i := 0
+	ret := m.ctrl.Call(m, "DescribeTaskJourney", args...)
+	success, _ = ret[i].(*shared.DescribeTaskJourneyResponse)
+	i++
+	err, _ = ret[i].(error)
+	return
+}
+
+func (mr *_MockClientRecorder) DescribeTaskJourney(
+	ctx interface{},
+	_Request interface{},
+	opts ...interface{},
+) *gomock.Call {
+	args := append([]interface{}{ctx, _Request}, opts...)
+	return mr.mock.ctrl.RecordCall(mr.mock, "DescribeTaskJourney", args...)
+}
+
 // DescribeTaskList responds to a DescribeTaskList call based on the mock expectations. This
 // call will fail if the mock does not expect this call. Use EXPECT to expect
 // a call to this function.
@@ -189,6 +222,39 @@ func (mr *_MockClientRecorder) DescribeTaskList(
 	return mr.mock.ctrl.RecordCall(mr.mock, "DescribeTaskList", args...)
 }
 
+// GetTaskListScalingHints responds to a GetTaskListScalingHints call based on the mock expectations. This
+// call will fail if the mock does not expect this call. Use EXPECT to expect
+// a call to this function.
+//
+// 	client.EXPECT().GetTaskListScalingHints(gomock.Any(), ...).Return(...)
+// 	... := client.GetTaskListScalingHints(...)
+func (m *MockClient) GetTaskListScalingHints(
+	ctx context.Context,
+	_Request *matching.GetTaskListScalingHintsRequest,
+	opts ...yarpc.CallOption,
+) (success *shared.GetTaskListScalingHintsResponse, err error) {
+
+	args := []interface{}{ctx, _Request}
+	for _, o := range opts {
+		args = append(args, o)
+	}
+	i := 0
+	ret := m.ctrl.Call(m, "GetTaskListScalingHints", args...)
+	success, _ = ret[i].(*shared.GetTaskListScalingHintsResponse)
+	i++
+	err, _ = ret[i].(error)
+	return
+}
+
+func (mr *_MockClientRecorder) GetTaskListScalingHints(
+	ctx interface{},
+	_Request interface{},
+	opts ...interface{},
+) *gomock.Call {
+	args := append([]interface{}{ctx, _Request}, opts...)
+	return mr.mock.ctrl.RecordCall(mr.mock, "GetTaskListScalingHints", args...)
+}
+
 // PollForActivityTask responds to a PollForActivityTask call based on the mock expectations. This
 // call will fail if the mock does not expect this call. Use EXPECT to expect
 // a call to this function.
@@ -318,3 +384,34 @@ func (mr *_MockClientRecorder) RespondQueryTaskCompleted(
 	args := append([]interface{}{ctx, _Request}, opts...)
 	return mr.mock.ctrl.RecordCall(mr.mock, "RespondQueryTaskCompleted", args...)
 }
+
+// UpdateTaskListVersionSets responds to a UpdateTaskListVersionSets call based on the mock expectations. This
+// call will fail if the mock does not expect this call. Use EXPECT to expect
+// a call to this function.
+//
+// 	client.EXPECT().UpdateTaskListVersionSets(gomock.Any(), ...).Return(...)
+// 	... := client.UpdateTaskListVersionSets(...)
+func (m *MockClient) UpdateTaskListVersionSets(
+	ctx context.Context,
+	_Request *matching.UpdateTaskListVersionSetsRequest,
+	opts ...yarpc.CallOption,
+) (err error) {
+
+	args := []interface{}{ctx, _Request}
+	for _, o := range opts {
+		args = append(args, o)
+	}
+	i := 0
+	ret := m.ctrl.Call(m, "UpdateTaskListVersionSets", args...)
+	err, _ = ret[i].(error)
+	return
+}
+
+func (mr *_MockClientRecorder) UpdateTaskListVersionSets(
+	ctx interface{},
+	_Request interface{},
+	opts ...interface{},
+) *gomock.Call {
+	args := append([]interface{}{ctx, _Request}, opts...)
+	return mr.mock.ctrl.RecordCall(mr.mock, "UpdateTaskListVersionSets", args...)
+}
@@ -66,6 +66,7 @@ type ActivityInfo struct {
 	RetryNonRetryableErrors       []string `json:"retryNonRetryableErrors,omitempty"`
 	RetryLastFailureReason        *string  `json:"retryLastFailureReason,omitempty"`
 	RetryLastWorkerIdentity       *string  `json:"retryLastWorkerIdentity,omitempty"`
+	Paused                        *bool    `json:"paused,omitempty"`
 }
 
 type _List_String_ValueList []string
@@ -111,7 +112,7 @@ func (_List_String_ValueList) Close() {}
 //   }
 func (v *ActivityInfo) ToWire() (wire.Value, error) {
 	var (
-		fields [30]wire.Field
+		fields [31]wire.Field
 		i      int = 0
 		w      wire.Value
 		err    error
@@ -357,6 +358,14 @@ func (v *ActivityInfo) ToWire() (wire.Value, error) {
 		fields[i] = wire.Field{ID: 68, Value: w}
 		i++
 	}
+	if v.Paused != nil {
+		w, err = wire.NewValueBool(*(v.Paused)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 70, Value: w}
+		i++
+	}
 
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
@@ -694,6 +703,16 @@ func (v *ActivityInfo) FromWire(w wire.Value) error {
 					return err
 				}
 
+			}
+		case 70:
+			if field.Value.Type() == wire.TBool {
+				var x bool
+				x, err = field.Value.GetBool(), error(nil)
+				v.Paused = &x
+				if err != nil {
+					return err
+				}
+
 			}
 		}
 	}
@@ -708,7 +727,7 @@ func (v *ActivityInfo) String() string {
 		return "<nil>"
 	}
 
-	var fields [30]string
+	var fields [31]string
 	i := 0
 	if v.Version != nil {
 		fields[i] = fmt.Sprintf("Version: %v", *(v.Version))
@@ -830,6 +849,10 @@ func (v *ActivityInfo) String() string {
 		fields[i] = fmt.Sprintf("RetryLastWorkerIdentity: %v", *(v.RetryLastWorkerIdentity))
 		i++
 	}
+	if v.Paused != nil {
+		fields[i] = fmt.Sprintf("Paused: %v", *(v.Paused))
+		i++
+	}
 
 	return fmt.Sprintf("ActivityInfo{%v}", strings.Join(fields[:i], ", "))
 }
@@ -999,6 +1022,9 @@ func (v *ActivityInfo) Equals(rhs *ActivityInfo) bool {
 	if !_String_EqualsPtr(v.RetryLastWorkerIdentity, rhs.RetryLastWorkerIdentity) {
 		return false
 	}
+	if !_Bool_EqualsPtr(v.Paused, rhs.Paused) {
+		return false
+	}
 
 	return true
 }
@@ -1110,6 +1136,9 @@ func (v *ActivityInfo) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
 	if v.RetryLastWorkerIdentity != nil {
 		enc.AddString("retryLastWorkerIdentity", *v.RetryLastWorkerIdentity)
 	}
+	if v.Paused != nil {
+		enc.AddBool("paused", *v.Paused)
+	}
 	return err
 }
 
@@ -1563,6 +1592,21 @@ func (v *ActivityInfo) IsSetRetryLastWorkerIdentity() bool {
 	return v != nil && v.RetryLastWorkerIdentity != nil
 }
 
+// GetPaused returns the value of Paused if it is set or its
+// zero value if it is unset.
+func (v *ActivityInfo) GetPaused() (o bool) {
+	if v != nil && v.Paused != nil {
+		return *v.Paused
+	}
+
+	return
+}
+
+// IsSetPaused returns true if Paused is not nil.
+func (v *ActivityInfo) IsSetPaused() bool {
+	return v != nil && v.Paused != nil
+}
+
 type ChildExecutionInfo struct {
 	Version                *int64  `json:"version,omitempty"`
 	InitiatedEventBatchID  *int64  `json:"initiatedEventBatchID,omitempty"`
@@ -2180,23 +2224,33 @@ func (v *ChildExecutionInfo) IsSetWorkflowTypeName() bool {
 }
 
 type DomainInfo struct {
-	Name                        *string           `json:"name,omitempty"`
-	Description                 *string           `json:"description,omitempty"`
-	Owner                       *string           `json:"owner,omitempty"`
-	Status                      *int32            `json:"status,omitempty"`
-	RetentionDays               *int16            `json:"retentionDays,omitempty"`
-	EmitMetric                  *bool             `json:"emitMetric,omitempty"`
-	ArchivalBucket              *string           `json:"archivalBucket,omitempty"`
-	ArchivalStatus              *int16            `json:"archivalStatus,omitempty"`
-	ConfigVersion               *int64            `json:"configVersion,omitempty"`
-	NotificationVersion         *int64            `json:"notificationVersion,omitempty"`
-	FailoverNotificationVersion *int64            `json:"failoverNotificationVersion,omitempty"`
-	FailoverVersion             *int64            `json:"failoverVersion,omitempty"`
-	ActiveClusterName           *string           `json:"activeClusterName,omitempty"`
-	Clusters                    []string          `json:"clusters,omitempty"`
-	Data                        map[string]string `json:"data,omitempty"`
-	BadBinaries                 []byte            `json:"badBinaries,omitempty"`
-	BadBinariesEncoding         *string           `json:"badBinariesEncoding,omitempty"`
+	Name                           *string           `json:"name,omitempty"`
+	Description                    *string           `json:"description,omitempty"`
+	Owner                          *string           `json:"owner,omitempty"`
+	Status                         *int32            `json:"status,omitempty"`
+	RetentionDays                  *int16            `json:"retentionDays,omitempty"`
+	EmitMetric                     *bool             `json:"emitMetric,omitempty"`
+	ArchivalBucket                 *string           `json:"archivalBucket,omitempty"`
+	ArchivalStatus                 *int16            `json:"archivalStatus,omitempty"`
+	ConfigVersion                  *int64            `json:"configVersion,omitempty"`
+	NotificationVersion            *int64            `json:"notificationVersion,omitempty"`
+	FailoverNotificationVersion    *int64            `json:"failoverNotificationVersion,omitempty"`
+	FailoverVersion                *int64            `json:"failoverVersion,omitempty"`
+	ActiveClusterName              *string           `json:"activeClusterName,omitempty"`
+	Clusters                       []string          `json:"clusters,omitempty"`
+	Data                           map[string]string `json:"data,omitempty"`
+	BadBinaries                    []byte            `json:"badBinaries,omitempty"`
+	BadBinariesEncoding            *string           `json:"badBinariesEncoding,omitempty"`
+	RetentionRunCount              *int32            `json:"retentionRunCount,omitempty"`
+	DefaultExecutionTimeoutSeconds *int32            `json:"defaultExecutionTimeoutSeconds,omitempty"`
+	MaxExecutionTimeoutSeconds     *int32            `json:"maxExecutionTimeoutSeconds,omitempty"`
+	DefaultTaskTimeoutSeconds      *int32            `json:"defaultTaskTimeoutSeconds,omitempty"`
+	MaxTaskTimeoutSeconds          *int32            `json:"maxTaskTimeoutSeconds,omitempty"`
+	DefaultActivityTimeoutSeconds  *int32            `json:"defaultActivityTimeoutSeconds,omitempty"`
+	MaxActivityTimeoutSeconds      *int32            `json:"maxActivityTimeoutSeconds,omitempty"`
+	EncryptionKeyAlias             *string           `json:"encryptionKeyAlias,omitempty"`
+	ResidencyTag                   *string           `json:"residencyTag,omitempty"`
+	ResidencyAllowedClusters       []string          `json:"residencyAllowedClusters,omitempty"`
 }
 
 type _Map_String_String_MapItemList map[string]string
@@ -2251,7 +2305,7 @@ func (_Map_String_String_MapItemList) Close() {}
 //   }
 func (v *DomainInfo) ToWire() (wire.Value, error) {
 	var (
-		fields [17]wire.Field
+		fields [27]wire.Field
 		i      int = 0
 		w      wire.Value
 		err    error
@@ -2393,6 +2447,86 @@ func (v *DomainInfo) ToWire() (wire.Value, error) {
 		fields[i] = wire.Field{ID: 40, Value: w}
 		i++
 	}
+	if v.RetentionRunCount != nil {
+		w, err = wire.NewValueI32(*(v.RetentionRunCount)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 42, Value: w}
+		i++
+	}
+	if v.DefaultExecutionTimeoutSeconds != nil {
+		w, err = wire.NewValueI32(*(v.DefaultExecutionTimeoutSeconds)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 44, Value: w}
+		i++
+	}
+	if v.MaxExecutionTimeoutSeconds != nil {
+		w, err = wire.NewValueI32(*(v.MaxExecutionTimeoutSeconds)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 46, Value: w}
+		i++
+	}
+	if v.DefaultTaskTimeoutSeconds != nil {
+		w, err = wire.NewValueI32(*(v.DefaultTaskTimeoutSeconds)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 48, Value: w}
+		i++
+	}
+	if v.MaxTaskTimeoutSeconds != nil {
+		w, err = wire.NewValueI32(*(v.MaxTaskTimeoutSeconds)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 50, Value: w}
+		i++
+	}
+	if v.DefaultActivityTimeoutSeconds != nil {
+		w, err = wire.NewValueI32(*(v.DefaultActivityTimeoutSeconds)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 52, Value: w}
+		i++
+	}
+	if v.MaxActivityTimeoutSeconds != nil {
+		w, err = wire.NewValueI32(*(v.MaxActivityTimeoutSeconds)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 54, Value: w}
+		i++
+	}
+	if v.EncryptionKeyAlias != nil {
+		w, err = wire.NewValueString(*(v.EncryptionKeyAlias)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 56, Value: w}
+		i++
+	}
+	if v.ResidencyTag != nil {
+		w, err = wire.NewValueString(*(v.ResidencyTag)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 58, Value: w}
+		i++
+	}
+	if v.ResidencyAllowedClusters != nil {
+		w, err = wire.NewValueList(_List_String_ValueList(v.ResidencyAllowedClusters)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 60, Value: w}
+		i++
+	}
 
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
@@ -2610,6 +2744,104 @@ func (v *DomainInfo) FromWire(w wire.Value) error {
 					return err
 				}
 
+			}
+		case 42:
+			if field.Value.Type() == wire.TI32 {
+				var x int32
+				x, err = field.Value.GetI32(), error(nil)
+				v.RetentionRunCount = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 44:
+			if field.Value.Type() == wire.TI32 {
+				var x int32
+				x, err = field.Value.GetI32(), error(nil)
+				v.DefaultExecutionTimeoutSeconds = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 46:
+			if field.Value.Type() == wire.TI32 {
+				var x int32
+				x, err = field.Value.GetI32(), error(nil)
+				v.MaxExecutionTimeoutSeconds = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 48:
+			if field.Value.Type() == wire.TI32 {
+				var x int32
+				x, err = field.Value.GetI32(), error(nil)
+				v.DefaultTaskTimeoutSeconds = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 50:
+			if field.Value.Type() == wire.TI32 {
+				var x int32
+				x, err = field.Value.GetI32(), error(nil)
+				v.MaxTaskTimeoutSeconds = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 52:
+			if field.Value.Type() == wire.TI32 {
+				var x int32
+				x, err = field.Value.GetI32(), error(nil)
+				v.DefaultActivityTimeoutSeconds = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 54:
+			if field.Value.Type() == wire.TI32 {
+				var x int32
+				x, err = field.Value.GetI32(), error(nil)
+				v.MaxActivityTimeoutSeconds = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 56:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.EncryptionKeyAlias = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 58:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.ResidencyTag = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 60:
+			if field.Value.Type() == wire.TList {
+				v.ResidencyAllowedClusters, err = _List_String_Read(field.Value.GetList())
+				if err != nil {
+					return err
+				}
+
 			}
 		}
 	}
@@ -2624,7 +2856,7 @@ func (v *DomainInfo) String() string {
 		return "<nil>"
 	}
 
-	var fields [17]string
+	var fields [27]string
 	i := 0
 	if v.Name != nil {
 		fields[i] = fmt.Sprintf("Name: %v", *(v.Name))
@@ -2694,6 +2926,46 @@ func (v *DomainInfo) String() string {
 		fields[i] = fmt.Sprintf("BadBinariesEncoding: %v", *(v.BadBinariesEncoding))
 		i++
 	}
+	if v.RetentionRunCount != nil {
+		fields[i] = fmt.Sprintf("RetentionRunCount: %v", *(v.RetentionRunCount))
+		i++
+	}
+	if v.DefaultExecutionTimeoutSeconds != nil {
+		fields[i] = fmt.Sprintf("DefaultExecutionTimeoutSeconds: %v", *(v.DefaultExecutionTimeoutSeconds))
+		i++
+	}
+	if v.MaxExecutionTimeoutSeconds != nil {
+		fields[i] = fmt.Sprintf("MaxExecutionTimeoutSeconds: %v", *(v.MaxExecutionTimeoutSeconds))
+		i++
+	}
+	if v.DefaultTaskTimeoutSeconds != nil {
+		fields[i] = fmt.Sprintf("DefaultTaskTimeoutSeconds: %v", *(v.DefaultTaskTimeoutSeconds))
+		i++
+	}
+	if v.MaxTaskTimeoutSeconds != nil {
+		fields[i] = fmt.Sprintf("MaxTaskTimeoutSeconds: %v", *(v.MaxTaskTimeoutSeconds))
+		i++
+	}
+	if v.DefaultActivityTimeoutSeconds != nil {
+		fields[i] = fmt.Sprintf("DefaultActivityTimeoutSeconds: %v", *(v.DefaultActivityTimeoutSeconds))
+		i++
+	}
+	if v.MaxActivityTimeoutSeconds != nil {
+		fields[i] = fmt.Sprintf("MaxActivityTimeoutSeconds: %v", *(v.MaxActivityTimeoutSeconds))
+		i++
+	}
+	if v.EncryptionKeyAlias != nil {
+		fields[i] = fmt.Sprintf("EncryptionKeyAlias: %v", *(v.EncryptionKeyAlias))
+		i++
+	}
+	if v.ResidencyTag != nil {
+		fields[i] = fmt.Sprintf("ResidencyTag: %v", *(v.ResidencyTag))
+		i++
+	}
+	if v.ResidencyAllowedClusters != nil {
+		fields[i] = fmt.Sprintf("ResidencyAllowedClusters: %v", v.ResidencyAllowedClusters)
+		i++
+	}
 
 	return fmt.Sprintf("DomainInfo{%v}", strings.Join(fields[:i], ", "))
 }
@@ -2786,6 +3058,36 @@ func (v *DomainInfo) Equals(rhs *DomainInfo) bool {
 	if !_String_EqualsPtr(v.BadBinariesEncoding, rhs.BadBinariesEncoding) {
 		return false
 	}
+	if !_I32_EqualsPtr(v.RetentionRunCount, rhs.RetentionRunCount) {
+		return false
+	}
+	if !_I32_EqualsPtr(v.DefaultExecutionTimeoutSeconds, rhs.DefaultExecutionTimeoutSeconds) {
+		return false
+	}
+	if !_I32_EqualsPtr(v.MaxExecutionTimeoutSeconds, rhs.MaxExecutionTimeoutSeconds) {
+		return false
+	}
+	if !_I32_EqualsPtr(v.DefaultTaskTimeoutSeconds, rhs.DefaultTaskTimeoutSeconds) {
+		return false
+	}
+	if !_I32_EqualsPtr(v.MaxTaskTimeoutSeconds, rhs.MaxTaskTimeoutSeconds) {
+		return false
+	}
+	if !_I32_EqualsPtr(v.DefaultActivityTimeoutSeconds, rhs.DefaultActivityTimeoutSeconds) {
+		return false
+	}
+	if !_I32_EqualsPtr(v.MaxActivityTimeoutSeconds, rhs.MaxActivityTimeoutSeconds) {
+		return false
+	}
+	if !_String_EqualsPtr(v.EncryptionKeyAlias, rhs.EncryptionKeyAlias) {
+		return false
+	}
+	if !_String_EqualsPtr(v.ResidencyTag, rhs.ResidencyTag) {
+		return false
+	}
+	if !((v.ResidencyAllowedClusters == nil && rhs.ResidencyAllowedClusters == nil) || (v.ResidencyAllowedClusters != nil && rhs.ResidencyAllowedClusters != nil && _List_String_Equals(v.ResidencyAllowedClusters, rhs.ResidencyAllowedClusters))) {
+		return false
+	}
 
 	return true
 }
@@ -2858,6 +3160,36 @@ func (v *DomainInfo) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
 	if v.BadBinariesEncoding != nil {
 		enc.AddString("badBinariesEncoding", *v.BadBinariesEncoding)
 	}
+	if v.RetentionRunCount != nil {
+		enc.AddInt32("retentionRunCount", *v.RetentionRunCount)
+	}
+	if v.DefaultExecutionTimeoutSeconds != nil {
+		enc.AddInt32("defaultExecutionTimeoutSeconds", *v.DefaultExecutionTimeoutSeconds)
+	}
+	if v.MaxExecutionTimeoutSeconds != nil {
+		enc.AddInt32("maxExecutionTimeoutSeconds", *v.MaxExecutionTimeoutSeconds)
+	}
+	if v.DefaultTaskTimeoutSeconds != nil {
+		enc.AddInt32("defaultTaskTimeoutSeconds", *v.DefaultTaskTimeoutSeconds)
+	}
+	if v.MaxTaskTimeoutSeconds != nil {
+		enc.AddInt32("maxTaskTimeoutSeconds", *v.MaxTaskTimeoutSeconds)
+	}
+	if v.DefaultActivityTimeoutSeconds != nil {
+		enc.AddInt32("defaultActivityTimeoutSeconds", *v.DefaultActivityTimeoutSeconds)
+	}
+	if v.MaxActivityTimeoutSeconds != nil {
+		enc.AddInt32("maxActivityTimeoutSeconds", *v.MaxActivityTimeoutSeconds)
+	}
+	if v.EncryptionKeyAlias != nil {
+		enc.AddString("encryptionKeyAlias", *v.EncryptionKeyAlias)
+	}
+	if v.ResidencyTag != nil {
+		enc.AddString("residencyTag", *v.ResidencyTag)
+	}
+	if v.ResidencyAllowedClusters != nil {
+		err = multierr.Append(err, enc.AddArray("residencyAllowedClusters", (_List_String_Zapper)(v.ResidencyAllowedClusters)))
+	}
 	return err
 }
 
@@ -3116,36 +3448,186 @@ func (v *DomainInfo) IsSetBadBinariesEncoding() bool {
 	return v != nil && v.BadBinariesEncoding != nil
 }
 
-type HistoryTreeInfo struct {
-	CreatedTimeNanos *int64                       `json:"createdTimeNanos,omitempty"`
-	Ancestors        []*shared.HistoryBranchRange `json:"ancestors,omitempty"`
-	Info             *string                      `json:"info,omitempty"`
+// GetRetentionRunCount returns the value of RetentionRunCount if it is set or its
+// zero value if it is unset.
+func (v *DomainInfo) GetRetentionRunCount() (o int32) {
+	if v != nil && v.RetentionRunCount != nil {
+		return *v.RetentionRunCount
+	}
+
+	return
 }
 
-type _List_HistoryBranchRange_ValueList []*shared.HistoryBranchRange
+// IsSetRetentionRunCount returns true if RetentionRunCount is not nil.
+func (v *DomainInfo) IsSetRetentionRunCount() bool {
+	return v != nil && v.RetentionRunCount != nil
+}
 
-func (v _List_HistoryBranchRange_ValueList) ForEach(f func(wire.Value) error) error {
-	for i, x := range v {
-		if x == nil {
-			return fmt.Errorf("invalid [%v]: value is nil", i)
-		}
-		w, err := x.ToWire()
-		if err != nil {
-			return err
-		}
-		err = f(w)
-		if err != nil {
-			return err
-		}
+// GetDefaultExecutionTimeoutSeconds returns the value of DefaultExecutionTimeoutSeconds if it is set or its
+// zero value if it is unset.
+func (v *DomainInfo) GetDefaultExecutionTimeoutSeconds() (o int32) {
+	if v != nil && v.DefaultExecutionTimeoutSeconds != nil {
+		return *v.DefaultExecutionTimeoutSeconds
 	}
-	return nil
+
+	return
 }
 
-func (v _List_HistoryBranchRange_ValueList) Size() int {
-	return len(v)
+// IsSetDefaultExecutionTimeoutSeconds returns true if DefaultExecutionTimeoutSeconds is not nil.
+func (v *DomainInfo) IsSetDefaultExecutionTimeoutSeconds() bool {
+	return v != nil && v.DefaultExecutionTimeoutSeconds != nil
 }
 
-func (_List_HistoryBranchRange_ValueList) ValueType() wire.Type {
+// GetMaxExecutionTimeoutSeconds returns the value of MaxExecutionTimeoutSeconds if it is set or its
+// zero value if it is unset.
+func (v *DomainInfo) GetMaxExecutionTimeoutSeconds() (o int32) {
+	if v != nil && v.MaxExecutionTimeoutSeconds != nil {
+		return *v.MaxExecutionTimeoutSeconds
+	}
+
+	return
+}
+
+// IsSetMaxExecutionTimeoutSeconds returns true if MaxExecutionTimeoutSeconds is not nil.
+func (v *DomainInfo) IsSetMaxExecutionTimeoutSeconds() bool {
+	return v != nil && v.MaxExecutionTimeoutSeconds != nil
+}
+
+// GetDefaultTaskTimeoutSeconds returns the value of DefaultTaskTimeoutSeconds if it is set or its
+// zero value if it is unset.
+func (v *DomainInfo) GetDefaultTaskTimeoutSeconds() (o int32) {
+	if v != nil && v.DefaultTaskTimeoutSeconds != nil {
+		return *v.DefaultTaskTimeoutSeconds
+	}
+
+	return
+}
+
+// IsSetDefaultTaskTimeoutSeconds returns true if DefaultTaskTimeoutSeconds is not nil.
+func (v *DomainInfo) IsSetDefaultTaskTimeoutSeconds() bool {
+	return v != nil && v.DefaultTaskTimeoutSeconds != nil
+}
+
+// GetMaxTaskTimeoutSeconds returns the value of MaxTaskTimeoutSeconds if it is set or its
+// zero value if it is unset.
+func (v *DomainInfo) GetMaxTaskTimeoutSeconds() (o int32) {
+	if v != nil && v.MaxTaskTimeoutSeconds != nil {
+		return *v.MaxTaskTimeoutSeconds
+	}
+
+	return
+}
+
+// IsSetMaxTaskTimeoutSeconds returns true if MaxTaskTimeoutSeconds is not nil.
+func (v *DomainInfo) IsSetMaxTaskTimeoutSeconds() bool {
+	return v != nil && v.MaxTaskTimeoutSeconds != nil
+}
+
+// GetDefaultActivityTimeoutSeconds returns the value of DefaultActivityTimeoutSeconds if it is set or its
+// zero value if it is unset.
+func (v *DomainInfo) GetDefaultActivityTimeoutSeconds() (o int32) {
+	if v != nil && v.DefaultActivityTimeoutSeconds != nil {
+		return *v.DefaultActivityTimeoutSeconds
+	}
+
+	return
+}
+
+// IsSetDefaultActivityTimeoutSeconds returns true if DefaultActivityTimeoutSeconds is not nil.
+func (v *DomainInfo) IsSetDefaultActivityTimeoutSeconds() bool {
+	return v != nil && v.DefaultActivityTimeoutSeconds != nil
+}
+
+// GetMaxActivityTimeoutSeconds returns the value of MaxActivityTimeoutSeconds if it is set or its
+// zero value if it is unset.
+func (v *DomainInfo) GetMaxActivityTimeoutSeconds() (o int32) {
+	if v != nil && v.MaxActivityTimeoutSeconds != nil {
+		return *v.MaxActivityTimeoutSeconds
+	}
+
+	return
+}
+
+// IsSetMaxActivityTimeoutSeconds returns true if MaxActivityTimeoutSeconds is not nil.
+func (v *DomainInfo) IsSetMaxActivityTimeoutSeconds() bool {
+	return v != nil && v.MaxActivityTimeoutSeconds != nil
+}
+
+// GetEncryptionKeyAlias returns the value of EncryptionKeyAlias if it is set or its
+// zero value if it is unset.
+func (v *DomainInfo) GetEncryptionKeyAlias() (o string) {
+	if v != nil && v.EncryptionKeyAlias != nil {
+		return *v.EncryptionKeyAlias
+	}
+
+	return
+}
+
+// IsSetEncryptionKeyAlias returns true if EncryptionKeyAlias is not nil.
+func (v *DomainInfo) IsSetEncryptionKeyAlias() bool {
+	return v != nil && v.EncryptionKeyAlias != nil
+}
+
+// GetResidencyTag returns the value of ResidencyTag if it is set or its
+// zero value if it is unset.
+func (v *DomainInfo) GetResidencyTag() (o string) {
+	if v != nil && v.ResidencyTag != nil {
+		return *v.ResidencyTag
+	}
+
+	return
+}
+
+// IsSetResidencyTag returns true if ResidencyTag is not nil.
+func (v *DomainInfo) IsSetResidencyTag() bool {
+	return v != nil && v.ResidencyTag != nil
+}
+
+// GetResidencyAllowedClusters returns the value of ResidencyAllowedClusters if it is set or its
+// zero value if it is unset.
+func (v *DomainInfo) GetResidencyAllowedClusters() (o []string) {
+	if v != nil && v.ResidencyAllowedClusters != nil {
+		return v.ResidencyAllowedClusters
+	}
+
+	return
+}
+
+// IsSetResidencyAllowedClusters returns true if ResidencyAllowedClusters is not nil.
+func (v *DomainInfo) IsSetResidencyAllowedClusters() bool {
+	return v != nil && v.ResidencyAllowedClusters != nil
+}
+
+type HistoryTreeInfo struct {
+	CreatedTimeNanos *int64                       `json:"createdTimeNanos,omitempty"`
+	Ancestors        []*shared.HistoryBranchRange `json:"ancestors,omitempty"`
+	Info             *string                      `json:"info,omitempty"`
+}
+
+type _List_HistoryBranchRange_ValueList []*shared.HistoryBranchRange
+
+func (v _List_HistoryBranchRange_ValueList) ForEach(f func(wire.Value) error) error {
+	for i, x := range v {
+		if x == nil {
+			return fmt.Errorf("invalid [%v]: value is nil", i)
+		}
+		w, err := x.ToWire()
+		if err != nil {
+			return err
+		}
+		err = f(w)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (v _List_HistoryBranchRange_ValueList) Size() int {
+	return len(v)
+}
+
+func (_List_HistoryBranchRange_ValueList) ValueType() wire.Type {
 	return wire.TStruct
 }
 
@@ -3419,12 +3901,13 @@ func (v *HistoryTreeInfo) IsSetInfo() bool {
 	return v != nil && v.Info != nil
 }
 
-type ReplicationInfo struct {
-	Version     *int64 `json:"version,omitempty"`
-	LastEventID *int64 `json:"lastEventID,omitempty"`
+type HotExecution struct {
+	DomainID   []byte  `json:"domainID,omitempty"`
+	WorkflowID *string `json:"workflowID,omitempty"`
+	RunID      []byte  `json:"runID,omitempty"`
 }
 
-// ToWire translates a ReplicationInfo struct into a Thrift-level intermediate
+// ToWire translates a HotExecution struct into a Thrift-level intermediate
 // representation. This intermediate representation may be serialized
 // into bytes using a ThriftRW protocol implementation.
 //
@@ -3439,39 +3922,47 @@ type ReplicationInfo struct {
 //   if err := binaryProtocol.Encode(x, writer); err != nil {
 //     return err
 //   }
-func (v *ReplicationInfo) ToWire() (wire.Value, error) {
+func (v *HotExecution) ToWire() (wire.Value, error) {
 	var (
-		fields [2]wire.Field
+		fields [3]wire.Field
 		i      int = 0
 		w      wire.Value
 		err    error
 	)
 
-	if v.Version != nil {
-		w, err = wire.NewValueI64(*(v.Version)), error(nil)
+	if v.DomainID != nil {
+		w, err = wire.NewValueBinary(v.DomainID), error(nil)
 		if err != nil {
 			return w, err
 		}
 		fields[i] = wire.Field{ID: 10, Value: w}
 		i++
 	}
-	if v.LastEventID != nil {
-		w, err = wire.NewValueI64(*(v.LastEventID)), error(nil)
+	if v.WorkflowID != nil {
+		w, err = wire.NewValueString(*(v.WorkflowID)), error(nil)
 		if err != nil {
 			return w, err
 		}
 		fields[i] = wire.Field{ID: 12, Value: w}
 		i++
 	}
+	if v.RunID != nil {
+		w, err = wire.NewValueBinary(v.RunID), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 14, Value: w}
+		i++
+	}
 
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
 
-// FromWire deserializes a ReplicationInfo struct from its Thrift-level
+// FromWire deserializes a HotExecution struct from its Thrift-level
 // representation. The Thrift-level representation may be obtained
 // from a ThriftRW protocol implementation.
 //
-// An error is returned if we were unable to build a ReplicationInfo struct
+// An error is returned if we were unable to build a HotExecution struct
 // from the provided intermediate representation.
 //
 //   x, err := binaryProtocol.Decode(reader, wire.TStruct)
@@ -3479,31 +3970,37 @@ func (v *ReplicationInfo) ToWire() (wire.Value, error) {
 //     return nil, err
 //   }
 //
-//   var v ReplicationInfo
+//   var v HotExecution
 //   if err := v.FromWire(x); err != nil {
 //     return nil, err
 //   }
 //   return &v, nil
-func (v *ReplicationInfo) FromWire(w wire.Value) error {
+func (v *HotExecution) FromWire(w wire.Value) error {
 	var err error
 
 	for _, field := range w.GetStruct().Fields {
 		switch field.ID {
 		case 10:
-			if field.Value.Type() == wire.TI64 {
-				var x int64
-				x, err = field.Value.GetI64(), error(nil)
-				v.Version = &x
+			if field.Value.Type() == wire.TBinary {
+				v.DomainID, err = field.Value.GetBinary(), error(nil)
 				if err != nil {
 					return err
 				}
 
 			}
 		case 12:
-			if field.Value.Type() == wire.TI64 {
-				var x int64
-				x, err = field.Value.GetI64(), error(nil)
-				v.LastEventID = &x
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.WorkflowID = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 14:
+			if field.Value.Type() == wire.TBinary {
+				v.RunID, err = field.Value.GetBinary(), error(nil)
 				if err != nil {
 					return err
 				}
@@ -3515,41 +4012,48 @@ func (v *ReplicationInfo) FromWire(w wire.Value) error {
 	return nil
 }
 
-// String returns a readable string representation of a ReplicationInfo
+// String returns a readable string representation of a HotExecution
 // struct.
-func (v *ReplicationInfo) String() string {
+func (v *HotExecution) String() string {
 	if v == nil {
 		return "<nil>"
 	}
 
-	var fields [2]string
+	var fields [3]string
 	i := 0
-	if v.Version != nil {
-		fields[i] = fmt.Sprintf("Version: %v", *(v.Version))
+	if v.DomainID != nil {
+		fields[i] = fmt.Sprintf("DomainID: %v", v.DomainID)
 		i++
 	}
-	if v.LastEventID != nil {
-		fields[i] = fmt.Sprintf("LastEventID: %v", *(v.LastEventID))
+	if v.WorkflowID != nil {
+		fields[i] = fmt.Sprintf("WorkflowID: %v", *(v.WorkflowID))
+		i++
+	}
+	if v.RunID != nil {
+		fields[i] = fmt.Sprintf("RunID: %v", v.RunID)
 		i++
 	}
 
-	return fmt.Sprintf("ReplicationInfo{%v}", strings.Join(fields[:i], ", "))
+	return fmt.Sprintf("HotExecution{%v}", strings.Join(fields[:i], ", "))
 }
 
-// Equals returns true if all the fields of this ReplicationInfo match the
-// provided ReplicationInfo.
+// Equals returns true if all the fields of this HotExecution match the
+// provided HotExecution.
 //
 // This function performs a deep comparison.
-func (v *ReplicationInfo) Equals(rhs *ReplicationInfo) bool {
+func (v *HotExecution) Equals(rhs *HotExecution) bool {
 	if v == nil {
 		return rhs == nil
 	} else if rhs == nil {
 		return false
 	}
-	if !_I64_EqualsPtr(v.Version, rhs.Version) {
+	if !((v.DomainID == nil && rhs.DomainID == nil) || (v.DomainID != nil && rhs.DomainID != nil && bytes.Equal(v.DomainID, rhs.DomainID))) {
 		return false
 	}
-	if !_I64_EqualsPtr(v.LastEventID, rhs.LastEventID) {
+	if !_String_EqualsPtr(v.WorkflowID, rhs.WorkflowID) {
+		return false
+	}
+	if !((v.RunID == nil && rhs.RunID == nil) || (v.RunID != nil && rhs.RunID != nil && bytes.Equal(v.RunID, rhs.RunID))) {
 		return false
 	}
 
@@ -3557,96 +4061,296 @@ func (v *ReplicationInfo) Equals(rhs *ReplicationInfo) bool {
 }
 
 // MarshalLogObject implements zapcore.ObjectMarshaler, enabling
-// fast logging of ReplicationInfo.
-func (v *ReplicationInfo) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+// fast logging of HotExecution.
+func (v *HotExecution) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
 	if v == nil {
 		return nil
 	}
-	if v.Version != nil {
-		enc.AddInt64("version", *v.Version)
+	if v.DomainID != nil {
+		enc.AddString("domainID", base64.StdEncoding.EncodeToString(v.DomainID))
 	}
-	if v.LastEventID != nil {
-		enc.AddInt64("lastEventID", *v.LastEventID)
+	if v.WorkflowID != nil {
+		enc.AddString("workflowID", *v.WorkflowID)
+	}
+	if v.RunID != nil {
+		enc.AddString("runID", base64.StdEncoding.EncodeToString(v.RunID))
 	}
 	return err
 }
 
-// GetVersion returns the value of Version if it is set or its
+// GetDomainID returns the value of DomainID if it is set or its
 // zero value if it is unset.
-func (v *ReplicationInfo) GetVersion() (o int64) {
-	if v != nil && v.Version != nil {
-		return *v.Version
+func (v *HotExecution) GetDomainID() (o []byte) {
+	if v != nil && v.DomainID != nil {
+		return v.DomainID
 	}
 
 	return
 }
 
-// IsSetVersion returns true if Version is not nil.
-func (v *ReplicationInfo) IsSetVersion() bool {
-	return v != nil && v.Version != nil
+// IsSetDomainID returns true if DomainID is not nil.
+func (v *HotExecution) IsSetDomainID() bool {
+	return v != nil && v.DomainID != nil
 }
 
-// GetLastEventID returns the value of LastEventID if it is set or its
+// GetWorkflowID returns the value of WorkflowID if it is set or its
 // zero value if it is unset.
-func (v *ReplicationInfo) GetLastEventID() (o int64) {
-	if v != nil && v.LastEventID != nil {
-		return *v.LastEventID
+func (v *HotExecution) GetWorkflowID() (o string) {
+	if v != nil && v.WorkflowID != nil {
+		return *v.WorkflowID
 	}
 
 	return
 }
 
-// IsSetLastEventID returns true if LastEventID is not nil.
-func (v *ReplicationInfo) IsSetLastEventID() bool {
-	return v != nil && v.LastEventID != nil
-}
-
-type ReplicationTaskInfo struct {
-	DomainID                []byte                      `json:"domainID,omitempty"`
-	WorkflowID              *string                     `json:"workflowID,omitempty"`
-	RunID                   []byte                      `json:"runID,omitempty"`
-	TaskType                *int16                      `json:"taskType,omitempty"`
-	Version                 *int64                      `json:"version,omitempty"`
-	FirstEventID            *int64                      `json:"firstEventID,omitempty"`
-	NextEventID             *int64                      `json:"nextEventID,omitempty"`
-	ScheduledID             *int64                      `json:"scheduledID,omitempty"`
-	EventStoreVersion       *int32                      `json:"eventStoreVersion,omitempty"`
-	NewRunEventStoreVersion *int32                      `json:"newRunEventStoreVersion,omitempty"`
-	BranchToken             []byte                      `json:"branch_token,omitempty"`
-	LastReplicationInfo     map[string]*ReplicationInfo `json:"lastReplicationInfo,omitempty"`
-	NewRunBranchToken       []byte                      `json:"newRunBranchToken,omitempty"`
-	ResetWorkflow           *bool                       `json:"resetWorkflow,omitempty"`
+// IsSetWorkflowID returns true if WorkflowID is not nil.
+func (v *HotExecution) IsSetWorkflowID() bool {
+	return v != nil && v.WorkflowID != nil
 }
 
-type _Map_String_ReplicationInfo_MapItemList map[string]*ReplicationInfo
-
-func (m _Map_String_ReplicationInfo_MapItemList) ForEach(f func(wire.MapItem) error) error {
-	for k, v := range m {
-		if v == nil {
-			return fmt.Errorf("invalid [%v]: value is nil", k)
-		}
-		kw, err := wire.NewValueString(k), error(nil)
-		if err != nil {
-			return err
-		}
-
-		vw, err := v.ToWire()
-		if err != nil {
-			return err
-		}
-		err = f(wire.MapItem{Key: kw, Value: vw})
-		if err != nil {
-			return err
-		}
+// GetRunID returns the value of RunID if it is set or its
+// zero value if it is unset.
+func (v *HotExecution) GetRunID() (o []byte) {
+	if v != nil && v.RunID != nil {
+		return v.RunID
 	}
-	return nil
+
+	return
 }
 
-func (m _Map_String_ReplicationInfo_MapItemList) Size() int {
-	return len(m)
+// IsSetRunID returns true if RunID is not nil.
+func (v *HotExecution) IsSetRunID() bool {
+	return v != nil && v.RunID != nil
 }
 
-func (_Map_String_ReplicationInfo_MapItemList) KeyType() wire.Type {
+type ReplicationInfo struct {
+	Version     *int64 `json:"version,omitempty"`
+	LastEventID *int64 `json:"lastEventID,omitempty"`
+}
+
+// ToWire translates a ReplicationInfo struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *ReplicationInfo) ToWire() (wire.Value, error) {
+	var (
+		fields [2]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.Version != nil {
+		w, err = wire.NewValueI64(*(v.Version)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 10, Value: w}
+		i++
+	}
+	if v.LastEventID != nil {
+		w, err = wire.NewValueI64(*(v.LastEventID)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 12, Value: w}
+		i++
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+// FromWire deserializes a ReplicationInfo struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a ReplicationInfo struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v ReplicationInfo
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *ReplicationInfo) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 10:
+			if field.Value.Type() == wire.TI64 {
+				var x int64
+				x, err = field.Value.GetI64(), error(nil)
+				v.Version = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 12:
+			if field.Value.Type() == wire.TI64 {
+				var x int64
+				x, err = field.Value.GetI64(), error(nil)
+				v.LastEventID = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+
+	return nil
+}
+
+// String returns a readable string representation of a ReplicationInfo
+// struct.
+func (v *ReplicationInfo) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [2]string
+	i := 0
+	if v.Version != nil {
+		fields[i] = fmt.Sprintf("Version: %v", *(v.Version))
+		i++
+	}
+	if v.LastEventID != nil {
+		fields[i] = fmt.Sprintf("LastEventID: %v", *(v.LastEventID))
+		i++
+	}
+
+	return fmt.Sprintf("ReplicationInfo{%v}", strings.Join(fields[:i], ", "))
+}
+
+// Equals returns true if all the fields of this ReplicationInfo match the
+// provided ReplicationInfo.
+//
+// This function performs a deep comparison.
+func (v *ReplicationInfo) Equals(rhs *ReplicationInfo) bool {
+	if v == nil {
+		return rhs == nil
+	} else if rhs == nil {
+		return false
+	}
+	if !_I64_EqualsPtr(v.Version, rhs.Version) {
+		return false
+	}
+	if !_I64_EqualsPtr(v.LastEventID, rhs.LastEventID) {
+		return false
+	}
+
+	return true
+}
+
+// MarshalLogObject implements zapcore.ObjectMarshaler, enabling
+// fast logging of ReplicationInfo.
+func (v *ReplicationInfo) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+	if v == nil {
+		return nil
+	}
+	if v.Version != nil {
+		enc.AddInt64("version", *v.Version)
+	}
+	if v.LastEventID != nil {
+		enc.AddInt64("lastEventID", *v.LastEventID)
+	}
+	return err
+}
+
+// GetVersion returns the value of Version if it is set or its
+// zero value if it is unset.
+func (v *ReplicationInfo) GetVersion() (o int64) {
+	if v != nil && v.Version != nil {
+		return *v.Version
+	}
+
+	return
+}
+
+// IsSetVersion returns true if Version is not nil.
+func (v *ReplicationInfo) IsSetVersion() bool {
+	return v != nil && v.Version != nil
+}
+
+// GetLastEventID returns the value of LastEventID if it is set or its
+// zero value if it is unset.
+func (v *ReplicationInfo) GetLastEventID() (o int64) {
+	if v != nil && v.LastEventID != nil {
+		return *v.LastEventID
+	}
+
+	return
+}
+
+// IsSetLastEventID returns true if LastEventID is not nil.
+func (v *ReplicationInfo) IsSetLastEventID() bool {
+	return v != nil && v.LastEventID != nil
+}
+
+type ReplicationTaskInfo struct {
+	DomainID                []byte                      `json:"domainID,omitempty"`
+	WorkflowID              *string                     `json:"workflowID,omitempty"`
+	RunID                   []byte                      `json:"runID,omitempty"`
+	TaskType                *int16                      `json:"taskType,omitempty"`
+	Version                 *int64                      `json:"version,omitempty"`
+	FirstEventID            *int64                      `json:"firstEventID,omitempty"`
+	NextEventID             *int64                      `json:"nextEventID,omitempty"`
+	ScheduledID             *int64                      `json:"scheduledID,omitempty"`
+	EventStoreVersion       *int32                      `json:"eventStoreVersion,omitempty"`
+	NewRunEventStoreVersion *int32                      `json:"newRunEventStoreVersion,omitempty"`
+	BranchToken             []byte                      `json:"branch_token,omitempty"`
+	LastReplicationInfo     map[string]*ReplicationInfo `json:"lastReplicationInfo,omitempty"`
+	NewRunBranchToken       []byte                      `json:"newRunBranchToken,omitempty"`
+	ResetWorkflow           *bool                       `json:"resetWorkflow,omitempty"`
+}
+
+type _Map_String_ReplicationInfo_MapItemList map[string]*ReplicationInfo
+
+func (m _Map_String_ReplicationInfo_MapItemList) ForEach(f func(wire.MapItem) error) error {
+	for k, v := range m {
+		if v == nil {
+			return fmt.Errorf("invalid [%v]: value is nil", k)
+		}
+		kw, err := wire.NewValueString(k), error(nil)
+		if err != nil {
+			return err
+		}
+
+		vw, err := v.ToWire()
+		if err != nil {
+			return err
+		}
+		err = f(wire.MapItem{Key: kw, Value: vw})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m _Map_String_ReplicationInfo_MapItemList) Size() int {
+	return len(m)
+}
+
+func (_Map_String_ReplicationInfo_MapItemList) KeyType() wire.Type {
 	return wire.TBinary
 }
 
@@ -4584,15 +5288,25 @@ func (v *RequestCancelInfo) IsSetCancelRequestID() bool {
 }
 
 type ShardInfo struct {
-	StolenSinceRenew          *int32           `json:"stolenSinceRenew,omitempty"`
-	UpdatedAtNanos            *int64           `json:"updatedAtNanos,omitempty"`
-	ReplicationAckLevel       *int64           `json:"replicationAckLevel,omitempty"`
-	TransferAckLevel          *int64           `json:"transferAckLevel,omitempty"`
-	TimerAckLevelNanos        *int64           `json:"timerAckLevelNanos,omitempty"`
-	DomainNotificationVersion *int64           `json:"domainNotificationVersion,omitempty"`
-	ClusterTransferAckLevel   map[string]int64 `json:"clusterTransferAckLevel,omitempty"`
-	ClusterTimerAckLevel      map[string]int64 `json:"clusterTimerAckLevel,omitempty"`
-	Owner                     *string          `json:"owner,omitempty"`
+	StolenSinceRenew                     *int32           `json:"stolenSinceRenew,omitempty"`
+	UpdatedAtNanos                       *int64           `json:"updatedAtNanos,omitempty"`
+	ReplicationAckLevel                  *int64           `json:"replicationAckLevel,omitempty"`
+	TransferAckLevel                     *int64           `json:"transferAckLevel,omitempty"`
+	TimerAckLevelNanos                   *int64           `json:"timerAckLevelNanos,omitempty"`
+	DomainNotificationVersion            *int64           `json:"domainNotificationVersion,omitempty"`
+	ClusterTransferAckLevel              map[string]int64 `json:"clusterTransferAckLevel,omitempty"`
+	ClusterTimerAckLevel                 map[string]int64 `json:"clusterTimerAckLevel,omitempty"`
+	Owner                                *string          `json:"owner,omitempty"`
+	DomainOpenExecutionCounts            map[string]int64 `json:"domainOpenExecutionCounts,omitempty"`
+	VisibilityAckLevel                   *int64           `json:"visibilityAckLevel,omitempty"`
+	QueueAlarmStuckSinceNanos            map[string]int64 `json:"queueAlarmStuckSinceNanos,omitempty"`
+	QueueAlarmBlockingTaskIDs            map[string]int64 `json:"queueAlarmBlockingTaskIDs,omitempty"`
+	QueueAlarmBlockingTaskTimestampNanos map[string]int64 `json:"queueAlarmBlockingTaskTimestampNanos,omitempty"`
+	HotExecutions                        []*HotExecution  `json:"hotExecutions,omitempty"`
+	LeaseExpiresAtNanos                  *int64           `json:"leaseExpiresAtNanos,omitempty"`
+	TasksPerSecond                       *float64         `json:"tasksPerSecond,omitempty"`
+	TaskThroughputUpdatedAtNanos         *int64           `json:"taskThroughputUpdatedAtNanos,omitempty"`
+	Placement                            []string         `json:"placement,omitempty"`
 }
 
 type _Map_String_I64_MapItemList map[string]int64
@@ -4630,6 +5344,35 @@ func (_Map_String_I64_MapItemList) ValueType() wire.Type {
 
 func (_Map_String_I64_MapItemList) Close() {}
 
+type _List_HotExecution_ValueList []*HotExecution
+
+func (v _List_HotExecution_ValueList) ForEach(f func(wire.Value) error) error {
+	for i, x := range v {
+		if x == nil {
+			return fmt.Errorf("invalid [%v]: value is nil", i)
+		}
+		w, err := x.ToWire()
+		if err != nil {
+			return err
+		}
+		err = f(w)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (v _List_HotExecution_ValueList) Size() int {
+	return len(v)
+}
+
+func (_List_HotExecution_ValueList) ValueType() wire.Type {
+	return wire.TStruct
+}
+
+func (_List_HotExecution_ValueList) Close() {}
+
 // ToWire translates a ShardInfo struct into a Thrift-level intermediate
 // representation. This intermediate representation may be serialized
 // into bytes using a ThriftRW protocol implementation.
@@ -4647,7 +5390,7 @@ func (_Map_String_I64_MapItemList) Close() {}
 //   }
 func (v *ShardInfo) ToWire() (wire.Value, error) {
 	var (
-		fields [9]wire.Field
+		fields [19]wire.Field
 		i      int = 0
 		w      wire.Value
 		err    error
@@ -4725,10 +5468,90 @@ func (v *ShardInfo) ToWire() (wire.Value, error) {
 		fields[i] = wire.Field{ID: 38, Value: w}
 		i++
 	}
-
-	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
-}
-
+	if v.DomainOpenExecutionCounts != nil {
+		w, err = wire.NewValueMap(_Map_String_I64_MapItemList(v.DomainOpenExecutionCounts)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 40, Value: w}
+		i++
+	}
+	if v.VisibilityAckLevel != nil {
+		w, err = wire.NewValueI64(*(v.VisibilityAckLevel)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 44, Value: w}
+		i++
+	}
+	if v.QueueAlarmStuckSinceNanos != nil {
+		w, err = wire.NewValueMap(_Map_String_I64_MapItemList(v.QueueAlarmStuckSinceNanos)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 46, Value: w}
+		i++
+	}
+	if v.QueueAlarmBlockingTaskIDs != nil {
+		w, err = wire.NewValueMap(_Map_String_I64_MapItemList(v.QueueAlarmBlockingTaskIDs)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 48, Value: w}
+		i++
+	}
+	if v.QueueAlarmBlockingTaskTimestampNanos != nil {
+		w, err = wire.NewValueMap(_Map_String_I64_MapItemList(v.QueueAlarmBlockingTaskTimestampNanos)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 50, Value: w}
+		i++
+	}
+	if v.HotExecutions != nil {
+		w, err = wire.NewValueList(_List_HotExecution_ValueList(v.HotExecutions)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 52, Value: w}
+		i++
+	}
+	if v.LeaseExpiresAtNanos != nil {
+		w, err = wire.NewValueI64(*(v.LeaseExpiresAtNanos)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 54, Value: w}
+		i++
+	}
+	if v.TasksPerSecond != nil {
+		w, err = wire.NewValueDouble(*(v.TasksPerSecond)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 56, Value: w}
+		i++
+	}
+	if v.TaskThroughputUpdatedAtNanos != nil {
+		w, err = wire.NewValueI64(*(v.TaskThroughputUpdatedAtNanos)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 58, Value: w}
+		i++
+	}
+	if v.Placement != nil {
+		w, err = wire.NewValueList(_List_String_ValueList(v.Placement)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 60, Value: w}
+		i++
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
 func _Map_String_I64_Read(m wire.MapItemList) (map[string]int64, error) {
 	if m.KeyType() != wire.TBinary {
 		return nil, nil
@@ -4757,6 +5580,30 @@ func _Map_String_I64_Read(m wire.MapItemList) (map[string]int64, error) {
 	return o, err
 }
 
+func _HotExecution_Read(w wire.Value) (*HotExecution, error) {
+	var v HotExecution
+	err := v.FromWire(w)
+	return &v, err
+}
+
+func _List_HotExecution_Read(l wire.ValueList) ([]*HotExecution, error) {
+	if l.ValueType() != wire.TStruct {
+		return nil, nil
+	}
+
+	o := make([]*HotExecution, 0, l.Size())
+	err := l.ForEach(func(x wire.Value) error {
+		i, err := _HotExecution_Read(x)
+		if err != nil {
+			return err
+		}
+		o = append(o, i)
+		return nil
+	})
+	l.Close()
+	return o, err
+}
+
 // FromWire deserializes a ShardInfo struct from its Thrift-level
 // representation. The Thrift-level representation may be obtained
 // from a ThriftRW protocol implementation.
@@ -4864,6 +5711,94 @@ func (v *ShardInfo) FromWire(w wire.Value) error {
 					return err
 				}
 
+			}
+		case 40:
+			if field.Value.Type() == wire.TMap {
+				v.DomainOpenExecutionCounts, err = _Map_String_I64_Read(field.Value.GetMap())
+				if err != nil {
+					return err
+				}
+
+			}
+		case 44:
+			if field.Value.Type() == wire.TI64 {
+				var x int64
+				x, err = field.Value.GetI64(), error(nil)
+				v.VisibilityAckLevel = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 46:
+			if field.Value.Type() == wire.TMap {
+				v.QueueAlarmStuckSinceNanos, err = _Map_String_I64_Read(field.Value.GetMap())
+				if err != nil {
+					return err
+				}
+
+			}
+		case 48:
+			if field.Value.Type() == wire.TMap {
+				v.QueueAlarmBlockingTaskIDs, err = _Map_String_I64_Read(field.Value.GetMap())
+				if err != nil {
+					return err
+				}
+
+			}
+		case 50:
+			if field.Value.Type() == wire.TMap {
+				v.QueueAlarmBlockingTaskTimestampNanos, err = _Map_String_I64_Read(field.Value.GetMap())
+				if err != nil {
+					return err
+				}
+
+			}
+		case 52:
+			if field.Value.Type() == wire.TList {
+				v.HotExecutions, err = _List_HotExecution_Read(field.Value.GetList())
+				if err != nil {
+					return err
+				}
+
+			}
+		case 54:
+			if field.Value.Type() == wire.TI64 {
+				var x int64
+				x, err = field.Value.GetI64(), error(nil)
+				v.LeaseExpiresAtNanos = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 56:
+			if field.Value.Type() == wire.TDouble {
+				var x float64
+				x, err = field.Value.GetDouble(), error(nil)
+				v.TasksPerSecond = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 58:
+			if field.Value.Type() == wire.TI64 {
+				var x int64
+				x, err = field.Value.GetI64(), error(nil)
+				v.TaskThroughputUpdatedAtNanos = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 60:
+			if field.Value.Type() == wire.TList {
+				v.Placement, err = _List_String_Read(field.Value.GetList())
+				if err != nil {
+					return err
+				}
+
 			}
 		}
 	}
@@ -4878,7 +5813,7 @@ func (v *ShardInfo) String() string {
 		return "<nil>"
 	}
 
-	var fields [9]string
+	var fields [19]string
 	i := 0
 	if v.StolenSinceRenew != nil {
 		fields[i] = fmt.Sprintf("StolenSinceRenew: %v", *(v.StolenSinceRenew))
@@ -4916,6 +5851,46 @@ func (v *ShardInfo) String() string {
 		fields[i] = fmt.Sprintf("Owner: %v", *(v.Owner))
 		i++
 	}
+	if v.DomainOpenExecutionCounts != nil {
+		fields[i] = fmt.Sprintf("DomainOpenExecutionCounts: %v", v.DomainOpenExecutionCounts)
+		i++
+	}
+	if v.VisibilityAckLevel != nil {
+		fields[i] = fmt.Sprintf("VisibilityAckLevel: %v", *(v.VisibilityAckLevel))
+		i++
+	}
+	if v.QueueAlarmStuckSinceNanos != nil {
+		fields[i] = fmt.Sprintf("QueueAlarmStuckSinceNanos: %v", v.QueueAlarmStuckSinceNanos)
+		i++
+	}
+	if v.QueueAlarmBlockingTaskIDs != nil {
+		fields[i] = fmt.Sprintf("QueueAlarmBlockingTaskIDs: %v", v.QueueAlarmBlockingTaskIDs)
+		i++
+	}
+	if v.QueueAlarmBlockingTaskTimestampNanos != nil {
+		fields[i] = fmt.Sprintf("QueueAlarmBlockingTaskTimestampNanos: %v", v.QueueAlarmBlockingTaskTimestampNanos)
+		i++
+	}
+	if v.HotExecutions != nil {
+		fields[i] = fmt.Sprintf("HotExecutions: %v", v.HotExecutions)
+		i++
+	}
+	if v.LeaseExpiresAtNanos != nil {
+		fields[i] = fmt.Sprintf("LeaseExpiresAtNanos: %v", *(v.LeaseExpiresAtNanos))
+		i++
+	}
+	if v.TasksPerSecond != nil {
+		fields[i] = fmt.Sprintf("TasksPerSecond: %v", *(v.TasksPerSecond))
+		i++
+	}
+	if v.TaskThroughputUpdatedAtNanos != nil {
+		fields[i] = fmt.Sprintf("TaskThroughputUpdatedAtNanos: %v", *(v.TaskThroughputUpdatedAtNanos))
+		i++
+	}
+	if v.Placement != nil {
+		fields[i] = fmt.Sprintf("Placement: %v", v.Placement)
+		i++
+	}
 
 	return fmt.Sprintf("ShardInfo{%v}", strings.Join(fields[:i], ", "))
 }
@@ -4937,6 +5912,21 @@ func _Map_String_I64_Equals(lhs, rhs map[string]int64) bool {
 	return true
 }
 
+func _List_HotExecution_Equals(lhs, rhs []*HotExecution) bool {
+	if len(lhs) != len(rhs) {
+		return false
+	}
+
+	for i, lv := range lhs {
+		rv := rhs[i]
+		if !lv.Equals(rv) {
+			return false
+		}
+	}
+
+	return true
+}
+
 // Equals returns true if all the fields of this ShardInfo match the
 // provided ShardInfo.
 //
@@ -4974,6 +5964,36 @@ func (v *ShardInfo) Equals(rhs *ShardInfo) bool {
 	if !_String_EqualsPtr(v.Owner, rhs.Owner) {
 		return false
 	}
+	if !((v.DomainOpenExecutionCounts == nil && rhs.DomainOpenExecutionCounts == nil) || (v.DomainOpenExecutionCounts != nil && rhs.DomainOpenExecutionCounts != nil && _Map_String_I64_Equals(v.DomainOpenExecutionCounts, rhs.DomainOpenExecutionCounts))) {
+		return false
+	}
+	if !_I64_EqualsPtr(v.VisibilityAckLevel, rhs.VisibilityAckLevel) {
+		return false
+	}
+	if !((v.QueueAlarmStuckSinceNanos == nil && rhs.QueueAlarmStuckSinceNanos == nil) || (v.QueueAlarmStuckSinceNanos != nil && rhs.QueueAlarmStuckSinceNanos != nil && _Map_String_I64_Equals(v.QueueAlarmStuckSinceNanos, rhs.QueueAlarmStuckSinceNanos))) {
+		return false
+	}
+	if !((v.QueueAlarmBlockingTaskIDs == nil && rhs.QueueAlarmBlockingTaskIDs == nil) || (v.QueueAlarmBlockingTaskIDs != nil && rhs.QueueAlarmBlockingTaskIDs != nil && _Map_String_I64_Equals(v.QueueAlarmBlockingTaskIDs, rhs.QueueAlarmBlockingTaskIDs))) {
+		return false
+	}
+	if !((v.QueueAlarmBlockingTaskTimestampNanos == nil && rhs.QueueAlarmBlockingTaskTimestampNanos == nil) || (v.QueueAlarmBlockingTaskTimestampNanos != nil && rhs.QueueAlarmBlockingTaskTimestampNanos != nil && _Map_String_I64_Equals(v.QueueAlarmBlockingTaskTimestampNanos, rhs.QueueAlarmBlockingTaskTimestampNanos))) {
+		return false
+	}
+	if !((v.HotExecutions == nil && rhs.HotExecutions == nil) || (v.HotExecutions != nil && rhs.HotExecutions != nil && _List_HotExecution_Equals(v.HotExecutions, rhs.HotExecutions))) {
+		return false
+	}
+	if !_I64_EqualsPtr(v.LeaseExpiresAtNanos, rhs.LeaseExpiresAtNanos) {
+		return false
+	}
+	if !_Double_EqualsPtr(v.TasksPerSecond, rhs.TasksPerSecond) {
+		return false
+	}
+	if !_I64_EqualsPtr(v.TaskThroughputUpdatedAtNanos, rhs.TaskThroughputUpdatedAtNanos) {
+		return false
+	}
+	if !((v.Placement == nil && rhs.Placement == nil) || (v.Placement != nil && rhs.Placement != nil && _List_String_Equals(v.Placement, rhs.Placement))) {
+		return false
+	}
 
 	return true
 }
@@ -4989,6 +6009,17 @@ func (m _Map_String_I64_Zapper) MarshalLogObject(enc zapcore.ObjectEncoder) (err
 	return err
 }
 
+type _List_HotExecution_Zapper []*HotExecution
+
+// MarshalLogArray implements zapcore.ArrayMarshaler, enabling
+// fast logging of _List_HotExecution_Zapper.
+func (l _List_HotExecution_Zapper) MarshalLogArray(enc zapcore.ArrayEncoder) (err error) {
+	for _, v := range l {
+		err = multierr.Append(err, enc.AppendObject(v))
+	}
+	return err
+}
+
 // MarshalLogObject implements zapcore.ObjectMarshaler, enabling
 // fast logging of ShardInfo.
 func (v *ShardInfo) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
@@ -5022,6 +6053,36 @@ func (v *ShardInfo) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
 	if v.Owner != nil {
 		enc.AddString("owner", *v.Owner)
 	}
+	if v.DomainOpenExecutionCounts != nil {
+		err = multierr.Append(err, enc.AddObject("domainOpenExecutionCounts", (_Map_String_I64_Zapper)(v.DomainOpenExecutionCounts)))
+	}
+	if v.VisibilityAckLevel != nil {
+		enc.AddInt64("visibilityAckLevel", *v.VisibilityAckLevel)
+	}
+	if v.QueueAlarmStuckSinceNanos != nil {
+		err = multierr.Append(err, enc.AddObject("queueAlarmStuckSinceNanos", (_Map_String_I64_Zapper)(v.QueueAlarmStuckSinceNanos)))
+	}
+	if v.QueueAlarmBlockingTaskIDs != nil {
+		err = multierr.Append(err, enc.AddObject("queueAlarmBlockingTaskIDs", (_Map_String_I64_Zapper)(v.QueueAlarmBlockingTaskIDs)))
+	}
+	if v.QueueAlarmBlockingTaskTimestampNanos != nil {
+		err = multierr.Append(err, enc.AddObject("queueAlarmBlockingTaskTimestampNanos", (_Map_String_I64_Zapper)(v.QueueAlarmBlockingTaskTimestampNanos)))
+	}
+	if v.HotExecutions != nil {
+		err = multierr.Append(err, enc.AddArray("hotExecutions", (_List_HotExecution_Zapper)(v.HotExecutions)))
+	}
+	if v.LeaseExpiresAtNanos != nil {
+		enc.AddInt64("leaseExpiresAtNanos", *v.LeaseExpiresAtNanos)
+	}
+	if v.TasksPerSecond != nil {
+		enc.AddFloat64("tasksPerSecond", *v.TasksPerSecond)
+	}
+	if v.TaskThroughputUpdatedAtNanos != nil {
+		enc.AddInt64("taskThroughputUpdatedAtNanos", *v.TaskThroughputUpdatedAtNanos)
+	}
+	if v.Placement != nil {
+		err = multierr.Append(err, enc.AddArray("placement", (_List_String_Zapper)(v.Placement)))
+	}
 	return err
 }
 
@@ -5160,27 +6221,177 @@ func (v *ShardInfo) IsSetOwner() bool {
 	return v != nil && v.Owner != nil
 }
 
-type SignalInfo struct {
-	Version   *int64  `json:"version,omitempty"`
-	RequestID *string `json:"requestID,omitempty"`
-	Name      *string `json:"name,omitempty"`
-	Input     []byte  `json:"input,omitempty"`
-	Control   []byte  `json:"control,omitempty"`
+// GetDomainOpenExecutionCounts returns the value of DomainOpenExecutionCounts if it is set or its
+// zero value if it is unset.
+func (v *ShardInfo) GetDomainOpenExecutionCounts() (o map[string]int64) {
+	if v != nil && v.DomainOpenExecutionCounts != nil {
+		return v.DomainOpenExecutionCounts
+	}
+
+	return
 }
 
-// ToWire translates a SignalInfo struct into a Thrift-level intermediate
-// representation. This intermediate representation may be serialized
-// into bytes using a ThriftRW protocol implementation.
-//
-// An error is returned if the struct or any of its fields failed to
-// validate.
-//
-//   x, err := v.ToWire()
-//   if err != nil {
-//     return err
-//   }
-//
-//   if err := binaryProtocol.Encode(x, writer); err != nil {
+// IsSetDomainOpenExecutionCounts returns true if DomainOpenExecutionCounts is not nil.
+func (v *ShardInfo) IsSetDomainOpenExecutionCounts() bool {
+	return v != nil && v.DomainOpenExecutionCounts != nil
+}
+
+// GetVisibilityAckLevel returns the value of VisibilityAckLevel if it is set or its
+// zero value if it is unset.
+func (v *ShardInfo) GetVisibilityAckLevel() (o int64) {
+	if v != nil && v.VisibilityAckLevel != nil {
+		return *v.VisibilityAckLevel
+	}
+
+	return
+}
+
+// IsSetVisibilityAckLevel returns true if VisibilityAckLevel is not nil.
+func (v *ShardInfo) IsSetVisibilityAckLevel() bool {
+	return v != nil && v.VisibilityAckLevel != nil
+}
+
+// GetQueueAlarmStuckSinceNanos returns the value of QueueAlarmStuckSinceNanos if it is set or its
+// zero value if it is unset.
+func (v *ShardInfo) GetQueueAlarmStuckSinceNanos() (o map[string]int64) {
+	if v != nil && v.QueueAlarmStuckSinceNanos != nil {
+		return v.QueueAlarmStuckSinceNanos
+	}
+
+	return
+}
+
+// IsSetQueueAlarmStuckSinceNanos returns true if QueueAlarmStuckSinceNanos is not nil.
+func (v *ShardInfo) IsSetQueueAlarmStuckSinceNanos() bool {
+	return v != nil && v.QueueAlarmStuckSinceNanos != nil
+}
+
+// GetQueueAlarmBlockingTaskIDs returns the value of QueueAlarmBlockingTaskIDs if it is set or its
+// zero value if it is unset.
+func (v *ShardInfo) GetQueueAlarmBlockingTaskIDs() (o map[string]int64) {
+	if v != nil && v.QueueAlarmBlockingTaskIDs != nil {
+		return v.QueueAlarmBlockingTaskIDs
+	}
+
+	return
+}
+
+// IsSetQueueAlarmBlockingTaskIDs returns true if QueueAlarmBlockingTaskIDs is not nil.
+func (v *ShardInfo) IsSetQueueAlarmBlockingTaskIDs() bool {
+	return v != nil && v.QueueAlarmBlockingTaskIDs != nil
+}
+
+// GetQueueAlarmBlockingTaskTimestampNanos returns the value of QueueAlarmBlockingTaskTimestampNanos if it is set or its
+// zero value if it is unset.
+func (v *ShardInfo) GetQueueAlarmBlockingTaskTimestampNanos() (o map[string]int64) {
+	if v != nil && v.QueueAlarmBlockingTaskTimestampNanos != nil {
+		return v.QueueAlarmBlockingTaskTimestampNanos
+	}
+
+	return
+}
+
+// IsSetQueueAlarmBlockingTaskTimestampNanos returns true if QueueAlarmBlockingTaskTimestampNanos is not nil.
+func (v *ShardInfo) IsSetQueueAlarmBlockingTaskTimestampNanos() bool {
+	return v != nil && v.QueueAlarmBlockingTaskTimestampNanos != nil
+}
+
+// GetHotExecutions returns the value of HotExecutions if it is set or its
+// zero value if it is unset.
+func (v *ShardInfo) GetHotExecutions() (o []*HotExecution) {
+	if v != nil && v.HotExecutions != nil {
+		return v.HotExecutions
+	}
+
+	return
+}
+
+// IsSetHotExecutions returns true if HotExecutions is not nil.
+func (v *ShardInfo) IsSetHotExecutions() bool {
+	return v != nil && v.HotExecutions != nil
+}
+
+// GetLeaseExpiresAtNanos returns the value of LeaseExpiresAtNanos if it is set or its
+// zero value if it is unset.
+func (v *ShardInfo) GetLeaseExpiresAtNanos() (o int64) {
+	if v != nil && v.LeaseExpiresAtNanos != nil {
+		return *v.LeaseExpiresAtNanos
+	}
+
+	return
+}
+
+// IsSetLeaseExpiresAtNanos returns true if LeaseExpiresAtNanos is not nil.
+func (v *ShardInfo) IsSetLeaseExpiresAtNanos() bool {
+	return v != nil && v.LeaseExpiresAtNanos != nil
+}
+
+// GetTasksPerSecond returns the value of TasksPerSecond if it is set or its
+// zero value if it is unset.
+func (v *ShardInfo) GetTasksPerSecond() (o float64) {
+	if v != nil && v.TasksPerSecond != nil {
+		return *v.TasksPerSecond
+	}
+
+	return
+}
+
+// IsSetTasksPerSecond returns true if TasksPerSecond is not nil.
+func (v *ShardInfo) IsSetTasksPerSecond() bool {
+	return v != nil && v.TasksPerSecond != nil
+}
+
+// GetTaskThroughputUpdatedAtNanos returns the value of TaskThroughputUpdatedAtNanos if it is set or its
+// zero value if it is unset.
+func (v *ShardInfo) GetTaskThroughputUpdatedAtNanos() (o int64) {
+	if v != nil && v.TaskThroughputUpdatedAtNanos != nil {
+		return *v.TaskThroughputUpdatedAtNanos
+	}
+
+	return
+}
+
+// IsSetTaskThroughputUpdatedAtNanos returns true if TaskThroughputUpdatedAtNanos is not nil.
+func (v *ShardInfo) IsSetTaskThroughputUpdatedAtNanos() bool {
+	return v != nil && v.TaskThroughputUpdatedAtNanos != nil
+}
+
+// GetPlacement returns the value of Placement if it is set or its
+// zero value if it is unset.
+func (v *ShardInfo) GetPlacement() (o []string) {
+	if v != nil && v.Placement != nil {
+		return v.Placement
+	}
+
+	return
+}
+
+// IsSetPlacement returns true if Placement is not nil.
+func (v *ShardInfo) IsSetPlacement() bool {
+	return v != nil && v.Placement != nil
+}
+
+type SignalInfo struct {
+	Version   *int64  `json:"version,omitempty"`
+	RequestID *string `json:"requestID,omitempty"`
+	Name      *string `json:"name,omitempty"`
+	Input     []byte  `json:"input,omitempty"`
+	Control   []byte  `json:"control,omitempty"`
+}
+
+// ToWire translates a SignalInfo struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
 //     return err
 //   }
 func (v *SignalInfo) ToWire() (wire.Value, error) {
@@ -5783,12 +6994,42 @@ func (v *TaskInfo) IsSetCreatedTimeNanos() bool {
 }
 
 type TaskListInfo struct {
-	Kind             *int16 `json:"kind,omitempty"`
-	AckLevel         *int64 `json:"ackLevel,omitempty"`
-	ExpiryTimeNanos  *int64 `json:"expiryTimeNanos,omitempty"`
-	LastUpdatedNanos *int64 `json:"lastUpdatedNanos,omitempty"`
+	Kind             *int16                       `json:"kind,omitempty"`
+	AckLevel         *int64                       `json:"ackLevel,omitempty"`
+	ExpiryTimeNanos  *int64                       `json:"expiryTimeNanos,omitempty"`
+	LastUpdatedNanos *int64                       `json:"lastUpdatedNanos,omitempty"`
+	VersionSets      []*shared.TaskListVersionSet `json:"versionSets,omitempty"`
+}
+
+type _List_TaskListVersionSet_ValueList []*shared.TaskListVersionSet
+
+func (v _List_TaskListVersionSet_ValueList) ForEach(f func(wire.Value) error) error {
+	for i, x := range v {
+		if x == nil {
+			return fmt.Errorf("invalid [%v]: value is nil", i)
+		}
+		w, err := x.ToWire()
+		if err != nil {
+			return err
+		}
+		err = f(w)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (v _List_TaskListVersionSet_ValueList) Size() int {
+	return len(v)
+}
+
+func (_List_TaskListVersionSet_ValueList) ValueType() wire.Type {
+	return wire.TStruct
 }
 
+func (_List_TaskListVersionSet_ValueList) Close() {}
+
 // ToWire translates a TaskListInfo struct into a Thrift-level intermediate
 // representation. This intermediate representation may be serialized
 // into bytes using a ThriftRW protocol implementation.
@@ -5806,7 +7047,7 @@ type TaskListInfo struct {
 //   }
 func (v *TaskListInfo) ToWire() (wire.Value, error) {
 	var (
-		fields [4]wire.Field
+		fields [5]wire.Field
 		i      int = 0
 		w      wire.Value
 		err    error
@@ -5844,10 +7085,42 @@ func (v *TaskListInfo) ToWire() (wire.Value, error) {
 		fields[i] = wire.Field{ID: 16, Value: w}
 		i++
 	}
+	if v.VersionSets != nil {
+		w, err = wire.NewValueList(_List_TaskListVersionSet_ValueList(v.VersionSets)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 18, Value: w}
+		i++
+	}
 
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
 
+func _TaskListVersionSet_Read(w wire.Value) (*shared.TaskListVersionSet, error) {
+	var v shared.TaskListVersionSet
+	err := v.FromWire(w)
+	return &v, err
+}
+
+func _List_TaskListVersionSet_Read(l wire.ValueList) ([]*shared.TaskListVersionSet, error) {
+	if l.ValueType() != wire.TStruct {
+		return nil, nil
+	}
+
+	o := make([]*shared.TaskListVersionSet, 0, l.Size())
+	err := l.ForEach(func(x wire.Value) error {
+		i, err := _TaskListVersionSet_Read(x)
+		if err != nil {
+			return err
+		}
+		o = append(o, i)
+		return nil
+	})
+	l.Close()
+	return o, err
+}
+
 // FromWire deserializes a TaskListInfo struct from its Thrift-level
 // representation. The Thrift-level representation may be obtained
 // from a ThriftRW protocol implementation.
@@ -5909,6 +7182,14 @@ func (v *TaskListInfo) FromWire(w wire.Value) error {
 					return err
 				}
 
+			}
+		case 18:
+			if field.Value.Type() == wire.TList {
+				v.VersionSets, err = _List_TaskListVersionSet_Read(field.Value.GetList())
+				if err != nil {
+					return err
+				}
+
 			}
 		}
 	}
@@ -5923,7 +7204,7 @@ func (v *TaskListInfo) String() string {
 		return "<nil>"
 	}
 
-	var fields [4]string
+	var fields [5]string
 	i := 0
 	if v.Kind != nil {
 		fields[i] = fmt.Sprintf("Kind: %v", *(v.Kind))
@@ -5941,10 +7222,29 @@ func (v *TaskListInfo) String() string {
 		fields[i] = fmt.Sprintf("LastUpdatedNanos: %v", *(v.LastUpdatedNanos))
 		i++
 	}
+	if v.VersionSets != nil {
+		fields[i] = fmt.Sprintf("VersionSets: %v", v.VersionSets)
+		i++
+	}
 
 	return fmt.Sprintf("TaskListInfo{%v}", strings.Join(fields[:i], ", "))
 }
 
+func _List_TaskListVersionSet_Equals(lhs, rhs []*shared.TaskListVersionSet) bool {
+	if len(lhs) != len(rhs) {
+		return false
+	}
+
+	for i, lv := range lhs {
+		rv := rhs[i]
+		if !lv.Equals(rv) {
+			return false
+		}
+	}
+
+	return true
+}
+
 // Equals returns true if all the fields of this TaskListInfo match the
 // provided TaskListInfo.
 //
@@ -5967,10 +7267,24 @@ func (v *TaskListInfo) Equals(rhs *TaskListInfo) bool {
 	if !_I64_EqualsPtr(v.LastUpdatedNanos, rhs.LastUpdatedNanos) {
 		return false
 	}
+	if !((v.VersionSets == nil && rhs.VersionSets == nil) || (v.VersionSets != nil && rhs.VersionSets != nil && _List_TaskListVersionSet_Equals(v.VersionSets, rhs.VersionSets))) {
+		return false
+	}
 
 	return true
 }
 
+type _List_TaskListVersionSet_Zapper []*shared.TaskListVersionSet
+
+// MarshalLogArray implements zapcore.ArrayMarshaler, enabling
+// fast logging of _List_TaskListVersionSet_Zapper.
+func (l _List_TaskListVersionSet_Zapper) MarshalLogArray(enc zapcore.ArrayEncoder) (err error) {
+	for _, v := range l {
+		err = multierr.Append(err, enc.AppendObject(v))
+	}
+	return err
+}
+
 // MarshalLogObject implements zapcore.ObjectMarshaler, enabling
 // fast logging of TaskListInfo.
 func (v *TaskListInfo) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
@@ -5989,6 +7303,9 @@ func (v *TaskListInfo) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
 	if v.LastUpdatedNanos != nil {
 		enc.AddInt64("lastUpdatedNanos", *v.LastUpdatedNanos)
 	}
+	if v.VersionSets != nil {
+		err = multierr.Append(err, enc.AddArray("versionSets", (_List_TaskListVersionSet_Zapper)(v.VersionSets)))
+	}
 	return err
 }
 
@@ -6052,6 +7369,21 @@ func (v *TaskListInfo) IsSetLastUpdatedNanos() bool {
 	return v != nil && v.LastUpdatedNanos != nil
 }
 
+// GetVersionSets returns the value of VersionSets if it is set or its
+// zero value if it is unset.
+func (v *TaskListInfo) GetVersionSets() (o []*shared.TaskListVersionSet) {
+	if v != nil && v.VersionSets != nil {
+		return v.VersionSets
+	}
+
+	return
+}
+
+// IsSetVersionSets returns true if VersionSets is not nil.
+func (v *TaskListInfo) IsSetVersionSets() bool {
+	return v != nil && v.VersionSets != nil
+}
+
 type TimerInfo struct {
 	Version         *int64 `json:"version,omitempty"`
 	StartedID       *int64 `json:"startedID,omitempty"`
@@ -7305,77 +8637,387 @@ func (v *TransferTaskInfo) IsSetTargetRunID() bool {
 
 // GetTaskList returns the value of TaskList if it is set or its
 // zero value if it is unset.
-func (v *TransferTaskInfo) GetTaskList() (o string) {
-	if v != nil && v.TaskList != nil {
-		return *v.TaskList
+func (v *TransferTaskInfo) GetTaskList() (o string) {
+	if v != nil && v.TaskList != nil {
+		return *v.TaskList
+	}
+
+	return
+}
+
+// IsSetTaskList returns true if TaskList is not nil.
+func (v *TransferTaskInfo) IsSetTaskList() bool {
+	return v != nil && v.TaskList != nil
+}
+
+// GetTargetChildWorkflowOnly returns the value of TargetChildWorkflowOnly if it is set or its
+// zero value if it is unset.
+func (v *TransferTaskInfo) GetTargetChildWorkflowOnly() (o bool) {
+	if v != nil && v.TargetChildWorkflowOnly != nil {
+		return *v.TargetChildWorkflowOnly
+	}
+
+	return
+}
+
+// IsSetTargetChildWorkflowOnly returns true if TargetChildWorkflowOnly is not nil.
+func (v *TransferTaskInfo) IsSetTargetChildWorkflowOnly() bool {
+	return v != nil && v.TargetChildWorkflowOnly != nil
+}
+
+// GetScheduleID returns the value of ScheduleID if it is set or its
+// zero value if it is unset.
+func (v *TransferTaskInfo) GetScheduleID() (o int64) {
+	if v != nil && v.ScheduleID != nil {
+		return *v.ScheduleID
+	}
+
+	return
+}
+
+// IsSetScheduleID returns true if ScheduleID is not nil.
+func (v *TransferTaskInfo) IsSetScheduleID() bool {
+	return v != nil && v.ScheduleID != nil
+}
+
+// GetVersion returns the value of Version if it is set or its
+// zero value if it is unset.
+func (v *TransferTaskInfo) GetVersion() (o int64) {
+	if v != nil && v.Version != nil {
+		return *v.Version
+	}
+
+	return
+}
+
+// IsSetVersion returns true if Version is not nil.
+func (v *TransferTaskInfo) IsSetVersion() bool {
+	return v != nil && v.Version != nil
+}
+
+// GetVisibilityTimestampNanos returns the value of VisibilityTimestampNanos if it is set or its
+// zero value if it is unset.
+func (v *TransferTaskInfo) GetVisibilityTimestampNanos() (o int64) {
+	if v != nil && v.VisibilityTimestampNanos != nil {
+		return *v.VisibilityTimestampNanos
+	}
+
+	return
+}
+
+// IsSetVisibilityTimestampNanos returns true if VisibilityTimestampNanos is not nil.
+func (v *TransferTaskInfo) IsSetVisibilityTimestampNanos() bool {
+	return v != nil && v.VisibilityTimestampNanos != nil
+}
+
+type UpdateInfo struct {
+	Version   *int64  `json:"version,omitempty"`
+	Name      *string `json:"name,omitempty"`
+	Input     []byte  `json:"input,omitempty"`
+	Result    []byte  `json:"result,omitempty"`
+	Completed *bool   `json:"completed,omitempty"`
+}
+
+// ToWire translates a UpdateInfo struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *UpdateInfo) ToWire() (wire.Value, error) {
+	var (
+		fields [5]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.Version != nil {
+		w, err = wire.NewValueI64(*(v.Version)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 10, Value: w}
+		i++
+	}
+	if v.Name != nil {
+		w, err = wire.NewValueString(*(v.Name)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 12, Value: w}
+		i++
+	}
+	if v.Input != nil {
+		w, err = wire.NewValueBinary(v.Input), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 14, Value: w}
+		i++
+	}
+	if v.Result != nil {
+		w, err = wire.NewValueBinary(v.Result), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 16, Value: w}
+		i++
+	}
+	if v.Completed != nil {
+		w, err = wire.NewValueBool(*(v.Completed)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 18, Value: w}
+		i++
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+// FromWire deserializes a UpdateInfo struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a UpdateInfo struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v UpdateInfo
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *UpdateInfo) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 10:
+			if field.Value.Type() == wire.TI64 {
+				var x int64
+				x, err = field.Value.GetI64(), error(nil)
+				v.Version = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 12:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.Name = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 14:
+			if field.Value.Type() == wire.TBinary {
+				v.Input, err = field.Value.GetBinary(), error(nil)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 16:
+			if field.Value.Type() == wire.TBinary {
+				v.Result, err = field.Value.GetBinary(), error(nil)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 18:
+			if field.Value.Type() == wire.TBool {
+				var x bool
+				x, err = field.Value.GetBool(), error(nil)
+				v.Completed = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+
+	return nil
+}
+
+// String returns a readable string representation of a UpdateInfo
+// struct.
+func (v *UpdateInfo) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [5]string
+	i := 0
+	if v.Version != nil {
+		fields[i] = fmt.Sprintf("Version: %v", *(v.Version))
+		i++
+	}
+	if v.Name != nil {
+		fields[i] = fmt.Sprintf("Name: %v", *(v.Name))
+		i++
+	}
+	if v.Input != nil {
+		fields[i] = fmt.Sprintf("Input: %v", v.Input)
+		i++
+	}
+	if v.Result != nil {
+		fields[i] = fmt.Sprintf("Result: %v", v.Result)
+		i++
+	}
+	if v.Completed != nil {
+		fields[i] = fmt.Sprintf("Completed: %v", *(v.Completed))
+		i++
+	}
+
+	return fmt.Sprintf("UpdateInfo{%v}", strings.Join(fields[:i], ", "))
+}
+
+// Equals returns true if all the fields of this UpdateInfo match the
+// provided UpdateInfo.
+//
+// This function performs a deep comparison.
+func (v *UpdateInfo) Equals(rhs *UpdateInfo) bool {
+	if v == nil {
+		return rhs == nil
+	} else if rhs == nil {
+		return false
+	}
+	if !_I64_EqualsPtr(v.Version, rhs.Version) {
+		return false
+	}
+	if !_String_EqualsPtr(v.Name, rhs.Name) {
+		return false
+	}
+	if !((v.Input == nil && rhs.Input == nil) || (v.Input != nil && rhs.Input != nil && bytes.Equal(v.Input, rhs.Input))) {
+		return false
+	}
+	if !((v.Result == nil && rhs.Result == nil) || (v.Result != nil && rhs.Result != nil && bytes.Equal(v.Result, rhs.Result))) {
+		return false
+	}
+	if !_Bool_EqualsPtr(v.Completed, rhs.Completed) {
+		return false
+	}
+
+	return true
+}
+
+// MarshalLogObject implements zapcore.ObjectMarshaler, enabling
+// fast logging of UpdateInfo.
+func (v *UpdateInfo) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+	if v == nil {
+		return nil
+	}
+	if v.Version != nil {
+		enc.AddInt64("version", *v.Version)
+	}
+	if v.Name != nil {
+		enc.AddString("name", *v.Name)
+	}
+	if v.Input != nil {
+		enc.AddString("input", base64.StdEncoding.EncodeToString(v.Input))
+	}
+	if v.Result != nil {
+		enc.AddString("result", base64.StdEncoding.EncodeToString(v.Result))
+	}
+	if v.Completed != nil {
+		enc.AddBool("completed", *v.Completed)
+	}
+	return err
+}
+
+// GetVersion returns the value of Version if it is set or its
+// zero value if it is unset.
+func (v *UpdateInfo) GetVersion() (o int64) {
+	if v != nil && v.Version != nil {
+		return *v.Version
 	}
 
 	return
 }
 
-// IsSetTaskList returns true if TaskList is not nil.
-func (v *TransferTaskInfo) IsSetTaskList() bool {
-	return v != nil && v.TaskList != nil
+// IsSetVersion returns true if Version is not nil.
+func (v *UpdateInfo) IsSetVersion() bool {
+	return v != nil && v.Version != nil
 }
 
-// GetTargetChildWorkflowOnly returns the value of TargetChildWorkflowOnly if it is set or its
+// GetName returns the value of Name if it is set or its
 // zero value if it is unset.
-func (v *TransferTaskInfo) GetTargetChildWorkflowOnly() (o bool) {
-	if v != nil && v.TargetChildWorkflowOnly != nil {
-		return *v.TargetChildWorkflowOnly
+func (v *UpdateInfo) GetName() (o string) {
+	if v != nil && v.Name != nil {
+		return *v.Name
 	}
 
 	return
 }
 
-// IsSetTargetChildWorkflowOnly returns true if TargetChildWorkflowOnly is not nil.
-func (v *TransferTaskInfo) IsSetTargetChildWorkflowOnly() bool {
-	return v != nil && v.TargetChildWorkflowOnly != nil
+// IsSetName returns true if Name is not nil.
+func (v *UpdateInfo) IsSetName() bool {
+	return v != nil && v.Name != nil
 }
 
-// GetScheduleID returns the value of ScheduleID if it is set or its
+// GetInput returns the value of Input if it is set or its
 // zero value if it is unset.
-func (v *TransferTaskInfo) GetScheduleID() (o int64) {
-	if v != nil && v.ScheduleID != nil {
-		return *v.ScheduleID
+func (v *UpdateInfo) GetInput() (o []byte) {
+	if v != nil && v.Input != nil {
+		return v.Input
 	}
 
 	return
 }
 
-// IsSetScheduleID returns true if ScheduleID is not nil.
-func (v *TransferTaskInfo) IsSetScheduleID() bool {
-	return v != nil && v.ScheduleID != nil
+// IsSetInput returns true if Input is not nil.
+func (v *UpdateInfo) IsSetInput() bool {
+	return v != nil && v.Input != nil
 }
 
-// GetVersion returns the value of Version if it is set or its
+// GetResult returns the value of Result if it is set or its
 // zero value if it is unset.
-func (v *TransferTaskInfo) GetVersion() (o int64) {
-	if v != nil && v.Version != nil {
-		return *v.Version
+func (v *UpdateInfo) GetResult() (o []byte) {
+	if v != nil && v.Result != nil {
+		return v.Result
 	}
 
 	return
 }
 
-// IsSetVersion returns true if Version is not nil.
-func (v *TransferTaskInfo) IsSetVersion() bool {
-	return v != nil && v.Version != nil
+// IsSetResult returns true if Result is not nil.
+func (v *UpdateInfo) IsSetResult() bool {
+	return v != nil && v.Result != nil
 }
 
-// GetVisibilityTimestampNanos returns the value of VisibilityTimestampNanos if it is set or its
+// GetCompleted returns the value of Completed if it is set or its
 // zero value if it is unset.
-func (v *TransferTaskInfo) GetVisibilityTimestampNanos() (o int64) {
-	if v != nil && v.VisibilityTimestampNanos != nil {
-		return *v.VisibilityTimestampNanos
+func (v *UpdateInfo) GetCompleted() (o bool) {
+	if v != nil && v.Completed != nil {
+		return *v.Completed
 	}
 
 	return
 }
 
-// IsSetVisibilityTimestampNanos returns true if VisibilityTimestampNanos is not nil.
-func (v *TransferTaskInfo) IsSetVisibilityTimestampNanos() bool {
-	return v != nil && v.VisibilityTimestampNanos != nil
+// IsSetCompleted returns true if Completed is not nil.
+func (v *UpdateInfo) IsSetCompleted() bool {
+	return v != nil && v.Completed != nil
 }
 
 type WorkflowExecutionInfo struct {
@@ -7435,6 +9077,20 @@ type WorkflowExecutionInfo struct {
 	AutoResetPoints                 []byte                      `json:"autoResetPoints,omitempty"`
 	AutoResetPointsEncoding         *string                     `json:"autoResetPointsEncoding,omitempty"`
 	SearchAttributes                map[string][]byte           `json:"searchAttributes,omitempty"`
+	CompletedActivityRequestIDs     []string                    `json:"completedActivityRequestIDs,omitempty"`
+	FirstExecutionRunID             []byte                      `json:"firstExecutionRunID,omitempty"`
+	Paused                          *bool                       `json:"paused,omitempty"`
+	CronPaused                      *bool                       `json:"cronPaused,omitempty"`
+	CronTimezone                    *string                     `json:"cronTimezone,omitempty"`
+	DecisionStartedIdentity         *string                     `json:"decisionStartedIdentity,omitempty"`
+	DecisionStartedBinaryChecksum   *string                     `json:"decisionStartedBinaryChecksum,omitempty"`
+	ResetBaseRunID                  []byte                      `json:"resetBaseRunID,omitempty"`
+	ResetBaseEventID                *int64                      `json:"resetBaseEventID,omitempty"`
+	ResetReason                     *string                     `json:"resetReason,omitempty"`
+	Notes                           *string                     `json:"notes,omitempty"`
+	NotesIdentity                   *string                     `json:"notesIdentity,omitempty"`
+	NotesLastUpdatedTimeNanos       *int64                      `json:"notesLastUpdatedTimeNanos,omitempty"`
+	DecisionHeartbeatCount          *int64                      `json:"decisionHeartbeatCount,omitempty"`
 }
 
 type _Map_String_Binary_MapItemList map[string][]byte
@@ -7492,7 +9148,7 @@ func (_Map_String_Binary_MapItemList) Close() {}
 //   }
 func (v *WorkflowExecutionInfo) ToWire() (wire.Value, error) {
 	var (
-		fields [56]wire.Field
+		fields [70]wire.Field
 		i      int = 0
 		w      wire.Value
 		err    error
@@ -7946,6 +9602,118 @@ func (v *WorkflowExecutionInfo) ToWire() (wire.Value, error) {
 		fields[i] = wire.Field{ID: 118, Value: w}
 		i++
 	}
+	if v.CompletedActivityRequestIDs != nil {
+		w, err = wire.NewValueList(_List_String_ValueList(v.CompletedActivityRequestIDs)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 120, Value: w}
+		i++
+	}
+	if v.FirstExecutionRunID != nil {
+		w, err = wire.NewValueBinary(v.FirstExecutionRunID), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 122, Value: w}
+		i++
+	}
+	if v.Paused != nil {
+		w, err = wire.NewValueBool(*(v.Paused)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 124, Value: w}
+		i++
+	}
+	if v.CronPaused != nil {
+		w, err = wire.NewValueBool(*(v.CronPaused)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 126, Value: w}
+		i++
+	}
+	if v.CronTimezone != nil {
+		w, err = wire.NewValueString(*(v.CronTimezone)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 128, Value: w}
+		i++
+	}
+	if v.DecisionStartedIdentity != nil {
+		w, err = wire.NewValueString(*(v.DecisionStartedIdentity)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 130, Value: w}
+		i++
+	}
+	if v.DecisionStartedBinaryChecksum != nil {
+		w, err = wire.NewValueString(*(v.DecisionStartedBinaryChecksum)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 132, Value: w}
+		i++
+	}
+	if v.ResetBaseRunID != nil {
+		w, err = wire.NewValueBinary(v.ResetBaseRunID), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 134, Value: w}
+		i++
+	}
+	if v.ResetBaseEventID != nil {
+		w, err = wire.NewValueI64(*(v.ResetBaseEventID)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 136, Value: w}
+		i++
+	}
+	if v.ResetReason != nil {
+		w, err = wire.NewValueString(*(v.ResetReason)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 138, Value: w}
+		i++
+	}
+	if v.Notes != nil {
+		w, err = wire.NewValueString(*(v.Notes)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 140, Value: w}
+		i++
+	}
+	if v.NotesIdentity != nil {
+		w, err = wire.NewValueString(*(v.NotesIdentity)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 142, Value: w}
+		i++
+	}
+	if v.NotesLastUpdatedTimeNanos != nil {
+		w, err = wire.NewValueI64(*(v.NotesLastUpdatedTimeNanos)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 144, Value: w}
+		i++
+	}
+	if v.DecisionHeartbeatCount != nil {
+		w, err = wire.NewValueI64(*(v.DecisionHeartbeatCount)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 146, Value: w}
+		i++
+	}
 
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
@@ -8400,143 +10168,277 @@ func (v *WorkflowExecutionInfo) FromWire(w wire.Value) error {
 				}
 
 			}
-		case 92:
-			if field.Value.Type() == wire.TDouble {
-				var x float64
-				x, err = field.Value.GetDouble(), error(nil)
-				v.RetryBackoffCoefficient = &x
+		case 92:
+			if field.Value.Type() == wire.TDouble {
+				var x float64
+				x, err = field.Value.GetDouble(), error(nil)
+				v.RetryBackoffCoefficient = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 94:
+			if field.Value.Type() == wire.TI64 {
+				var x int64
+				x, err = field.Value.GetI64(), error(nil)
+				v.RetryExpirationTimeNanos = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 96:
+			if field.Value.Type() == wire.TList {
+				v.RetryNonRetryableErrors, err = _List_String_Read(field.Value.GetList())
+				if err != nil {
+					return err
+				}
+
+			}
+		case 98:
+			if field.Value.Type() == wire.TBool {
+				var x bool
+				x, err = field.Value.GetBool(), error(nil)
+				v.HasRetryPolicy = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 100:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.CronSchedule = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 102:
+			if field.Value.Type() == wire.TI32 {
+				var x int32
+				x, err = field.Value.GetI32(), error(nil)
+				v.EventStoreVersion = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 104:
+			if field.Value.Type() == wire.TBinary {
+				v.EventBranchToken, err = field.Value.GetBinary(), error(nil)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 106:
+			if field.Value.Type() == wire.TI64 {
+				var x int64
+				x, err = field.Value.GetI64(), error(nil)
+				v.SignalCount = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 108:
+			if field.Value.Type() == wire.TI64 {
+				var x int64
+				x, err = field.Value.GetI64(), error(nil)
+				v.HistorySize = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 110:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.ClientLibraryVersion = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 112:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.ClientFeatureVersion = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 114:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.ClientImpl = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 115:
+			if field.Value.Type() == wire.TBinary {
+				v.AutoResetPoints, err = field.Value.GetBinary(), error(nil)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 116:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.AutoResetPointsEncoding = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 118:
+			if field.Value.Type() == wire.TMap {
+				v.SearchAttributes, err = _Map_String_Binary_Read(field.Value.GetMap())
+				if err != nil {
+					return err
+				}
+
+			}
+		case 120:
+			if field.Value.Type() == wire.TList {
+				v.CompletedActivityRequestIDs, err = _List_String_Read(field.Value.GetList())
 				if err != nil {
 					return err
 				}
 
 			}
-		case 94:
-			if field.Value.Type() == wire.TI64 {
-				var x int64
-				x, err = field.Value.GetI64(), error(nil)
-				v.RetryExpirationTimeNanos = &x
+		case 122:
+			if field.Value.Type() == wire.TBinary {
+				v.FirstExecutionRunID, err = field.Value.GetBinary(), error(nil)
 				if err != nil {
 					return err
 				}
 
 			}
-		case 96:
-			if field.Value.Type() == wire.TList {
-				v.RetryNonRetryableErrors, err = _List_String_Read(field.Value.GetList())
+		case 124:
+			if field.Value.Type() == wire.TBool {
+				var x bool
+				x, err = field.Value.GetBool(), error(nil)
+				v.Paused = &x
 				if err != nil {
 					return err
 				}
 
 			}
-		case 98:
+		case 126:
 			if field.Value.Type() == wire.TBool {
 				var x bool
 				x, err = field.Value.GetBool(), error(nil)
-				v.HasRetryPolicy = &x
+				v.CronPaused = &x
 				if err != nil {
 					return err
 				}
 
 			}
-		case 100:
+		case 128:
 			if field.Value.Type() == wire.TBinary {
 				var x string
 				x, err = field.Value.GetString(), error(nil)
-				v.CronSchedule = &x
+				v.CronTimezone = &x
 				if err != nil {
 					return err
 				}
 
 			}
-		case 102:
-			if field.Value.Type() == wire.TI32 {
-				var x int32
-				x, err = field.Value.GetI32(), error(nil)
-				v.EventStoreVersion = &x
+		case 130:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.DecisionStartedIdentity = &x
 				if err != nil {
 					return err
 				}
 
 			}
-		case 104:
+		case 132:
 			if field.Value.Type() == wire.TBinary {
-				v.EventBranchToken, err = field.Value.GetBinary(), error(nil)
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.DecisionStartedBinaryChecksum = &x
 				if err != nil {
 					return err
 				}
 
 			}
-		case 106:
-			if field.Value.Type() == wire.TI64 {
-				var x int64
-				x, err = field.Value.GetI64(), error(nil)
-				v.SignalCount = &x
+		case 134:
+			if field.Value.Type() == wire.TBinary {
+				v.ResetBaseRunID, err = field.Value.GetBinary(), error(nil)
 				if err != nil {
 					return err
 				}
 
 			}
-		case 108:
+		case 136:
 			if field.Value.Type() == wire.TI64 {
 				var x int64
 				x, err = field.Value.GetI64(), error(nil)
-				v.HistorySize = &x
+				v.ResetBaseEventID = &x
 				if err != nil {
 					return err
 				}
 
 			}
-		case 110:
+		case 138:
 			if field.Value.Type() == wire.TBinary {
 				var x string
 				x, err = field.Value.GetString(), error(nil)
-				v.ClientLibraryVersion = &x
+				v.ResetReason = &x
 				if err != nil {
 					return err
 				}
 
 			}
-		case 112:
+		case 140:
 			if field.Value.Type() == wire.TBinary {
 				var x string
 				x, err = field.Value.GetString(), error(nil)
-				v.ClientFeatureVersion = &x
+				v.Notes = &x
 				if err != nil {
 					return err
 				}
 
 			}
-		case 114:
+		case 142:
 			if field.Value.Type() == wire.TBinary {
 				var x string
 				x, err = field.Value.GetString(), error(nil)
-				v.ClientImpl = &x
-				if err != nil {
-					return err
-				}
-
-			}
-		case 115:
-			if field.Value.Type() == wire.TBinary {
-				v.AutoResetPoints, err = field.Value.GetBinary(), error(nil)
+				v.NotesIdentity = &x
 				if err != nil {
 					return err
 				}
 
 			}
-		case 116:
-			if field.Value.Type() == wire.TBinary {
-				var x string
-				x, err = field.Value.GetString(), error(nil)
-				v.AutoResetPointsEncoding = &x
+		case 144:
+			if field.Value.Type() == wire.TI64 {
+				var x int64
+				x, err = field.Value.GetI64(), error(nil)
+				v.NotesLastUpdatedTimeNanos = &x
 				if err != nil {
 					return err
 				}
 
 			}
-		case 118:
-			if field.Value.Type() == wire.TMap {
-				v.SearchAttributes, err = _Map_String_Binary_Read(field.Value.GetMap())
+		case 146:
+			if field.Value.Type() == wire.TI64 {
+				var x int64
+				x, err = field.Value.GetI64(), error(nil)
+				v.DecisionHeartbeatCount = &x
 				if err != nil {
 					return err
 				}
@@ -8555,7 +10457,7 @@ func (v *WorkflowExecutionInfo) String() string {
 		return "<nil>"
 	}
 
-	var fields [56]string
+	var fields [70]string
 	i := 0
 	if v.ParentDomainID != nil {
 		fields[i] = fmt.Sprintf("ParentDomainID: %v", v.ParentDomainID)
@@ -8781,6 +10683,62 @@ func (v *WorkflowExecutionInfo) String() string {
 		fields[i] = fmt.Sprintf("SearchAttributes: %v", v.SearchAttributes)
 		i++
 	}
+	if v.CompletedActivityRequestIDs != nil {
+		fields[i] = fmt.Sprintf("CompletedActivityRequestIDs: %v", v.CompletedActivityRequestIDs)
+		i++
+	}
+	if v.FirstExecutionRunID != nil {
+		fields[i] = fmt.Sprintf("FirstExecutionRunID: %v", v.FirstExecutionRunID)
+		i++
+	}
+	if v.Paused != nil {
+		fields[i] = fmt.Sprintf("Paused: %v", *(v.Paused))
+		i++
+	}
+	if v.CronPaused != nil {
+		fields[i] = fmt.Sprintf("CronPaused: %v", *(v.CronPaused))
+		i++
+	}
+	if v.CronTimezone != nil {
+		fields[i] = fmt.Sprintf("CronTimezone: %v", *(v.CronTimezone))
+		i++
+	}
+	if v.DecisionStartedIdentity != nil {
+		fields[i] = fmt.Sprintf("DecisionStartedIdentity: %v", *(v.DecisionStartedIdentity))
+		i++
+	}
+	if v.DecisionStartedBinaryChecksum != nil {
+		fields[i] = fmt.Sprintf("DecisionStartedBinaryChecksum: %v", *(v.DecisionStartedBinaryChecksum))
+		i++
+	}
+	if v.ResetBaseRunID != nil {
+		fields[i] = fmt.Sprintf("ResetBaseRunID: %v", v.ResetBaseRunID)
+		i++
+	}
+	if v.ResetBaseEventID != nil {
+		fields[i] = fmt.Sprintf("ResetBaseEventID: %v", *(v.ResetBaseEventID))
+		i++
+	}
+	if v.ResetReason != nil {
+		fields[i] = fmt.Sprintf("ResetReason: %v", *(v.ResetReason))
+		i++
+	}
+	if v.Notes != nil {
+		fields[i] = fmt.Sprintf("Notes: %v", *(v.Notes))
+		i++
+	}
+	if v.NotesIdentity != nil {
+		fields[i] = fmt.Sprintf("NotesIdentity: %v", *(v.NotesIdentity))
+		i++
+	}
+	if v.NotesLastUpdatedTimeNanos != nil {
+		fields[i] = fmt.Sprintf("NotesLastUpdatedTimeNanos: %v", *(v.NotesLastUpdatedTimeNanos))
+		i++
+	}
+	if v.DecisionHeartbeatCount != nil {
+		fields[i] = fmt.Sprintf("DecisionHeartbeatCount: %v", *(v.DecisionHeartbeatCount))
+		i++
+	}
 
 	return fmt.Sprintf("WorkflowExecutionInfo{%v}", strings.Join(fields[:i], ", "))
 }
@@ -8980,6 +10938,48 @@ func (v *WorkflowExecutionInfo) Equals(rhs *WorkflowExecutionInfo) bool {
 	if !((v.SearchAttributes == nil && rhs.SearchAttributes == nil) || (v.SearchAttributes != nil && rhs.SearchAttributes != nil && _Map_String_Binary_Equals(v.SearchAttributes, rhs.SearchAttributes))) {
 		return false
 	}
+	if !((v.CompletedActivityRequestIDs == nil && rhs.CompletedActivityRequestIDs == nil) || (v.CompletedActivityRequestIDs != nil && rhs.CompletedActivityRequestIDs != nil && _List_String_Equals(v.CompletedActivityRequestIDs, rhs.CompletedActivityRequestIDs))) {
+		return false
+	}
+	if !((v.FirstExecutionRunID == nil && rhs.FirstExecutionRunID == nil) || (v.FirstExecutionRunID != nil && rhs.FirstExecutionRunID != nil && bytes.Equal(v.FirstExecutionRunID, rhs.FirstExecutionRunID))) {
+		return false
+	}
+	if !_Bool_EqualsPtr(v.Paused, rhs.Paused) {
+		return false
+	}
+	if !_Bool_EqualsPtr(v.CronPaused, rhs.CronPaused) {
+		return false
+	}
+	if !_String_EqualsPtr(v.CronTimezone, rhs.CronTimezone) {
+		return false
+	}
+	if !_String_EqualsPtr(v.DecisionStartedIdentity, rhs.DecisionStartedIdentity) {
+		return false
+	}
+	if !_String_EqualsPtr(v.DecisionStartedBinaryChecksum, rhs.DecisionStartedBinaryChecksum) {
+		return false
+	}
+	if !((v.ResetBaseRunID == nil && rhs.ResetBaseRunID == nil) || (v.ResetBaseRunID != nil && rhs.ResetBaseRunID != nil && bytes.Equal(v.ResetBaseRunID, rhs.ResetBaseRunID))) {
+		return false
+	}
+	if !_I64_EqualsPtr(v.ResetBaseEventID, rhs.ResetBaseEventID) {
+		return false
+	}
+	if !_String_EqualsPtr(v.ResetReason, rhs.ResetReason) {
+		return false
+	}
+	if !_String_EqualsPtr(v.Notes, rhs.Notes) {
+		return false
+	}
+	if !_String_EqualsPtr(v.NotesIdentity, rhs.NotesIdentity) {
+		return false
+	}
+	if !_I64_EqualsPtr(v.NotesLastUpdatedTimeNanos, rhs.NotesLastUpdatedTimeNanos) {
+		return false
+	}
+	if !_I64_EqualsPtr(v.DecisionHeartbeatCount, rhs.DecisionHeartbeatCount) {
+		return false
+	}
 
 	return true
 }
@@ -9169,6 +11169,48 @@ func (v *WorkflowExecutionInfo) MarshalLogObject(enc zapcore.ObjectEncoder) (err
 	if v.SearchAttributes != nil {
 		err = multierr.Append(err, enc.AddObject("searchAttributes", (_Map_String_Binary_Zapper)(v.SearchAttributes)))
 	}
+	if v.CompletedActivityRequestIDs != nil {
+		err = multierr.Append(err, enc.AddArray("completedActivityRequestIDs", (_List_String_Zapper)(v.CompletedActivityRequestIDs)))
+	}
+	if v.FirstExecutionRunID != nil {
+		enc.AddString("firstExecutionRunID", base64.StdEncoding.EncodeToString(v.FirstExecutionRunID))
+	}
+	if v.Paused != nil {
+		enc.AddBool("paused", *v.Paused)
+	}
+	if v.CronPaused != nil {
+		enc.AddBool("cronPaused", *v.CronPaused)
+	}
+	if v.CronTimezone != nil {
+		enc.AddString("cronTimezone", *v.CronTimezone)
+	}
+	if v.DecisionStartedIdentity != nil {
+		enc.AddString("decisionStartedIdentity", *v.DecisionStartedIdentity)
+	}
+	if v.DecisionStartedBinaryChecksum != nil {
+		enc.AddString("decisionStartedBinaryChecksum", *v.DecisionStartedBinaryChecksum)
+	}
+	if v.ResetBaseRunID != nil {
+		enc.AddString("resetBaseRunID", base64.StdEncoding.EncodeToString(v.ResetBaseRunID))
+	}
+	if v.ResetBaseEventID != nil {
+		enc.AddInt64("resetBaseEventID", *v.ResetBaseEventID)
+	}
+	if v.ResetReason != nil {
+		enc.AddString("resetReason", *v.ResetReason)
+	}
+	if v.Notes != nil {
+		enc.AddString("notes", *v.Notes)
+	}
+	if v.NotesIdentity != nil {
+		enc.AddString("notesIdentity", *v.NotesIdentity)
+	}
+	if v.NotesLastUpdatedTimeNanos != nil {
+		enc.AddInt64("notesLastUpdatedTimeNanos", *v.NotesLastUpdatedTimeNanos)
+	}
+	if v.DecisionHeartbeatCount != nil {
+		enc.AddInt64("decisionHeartbeatCount", *v.DecisionHeartbeatCount)
+	}
 	return err
 }
 
@@ -10012,16 +12054,226 @@ func (v *WorkflowExecutionInfo) IsSetSearchAttributes() bool {
 	return v != nil && v.SearchAttributes != nil
 }
 
+// GetCompletedActivityRequestIDs returns the value of CompletedActivityRequestIDs if it is set or its
+// zero value if it is unset.
+func (v *WorkflowExecutionInfo) GetCompletedActivityRequestIDs() (o []string) {
+	if v != nil && v.CompletedActivityRequestIDs != nil {
+		return v.CompletedActivityRequestIDs
+	}
+
+	return
+}
+
+// IsSetCompletedActivityRequestIDs returns true if CompletedActivityRequestIDs is not nil.
+func (v *WorkflowExecutionInfo) IsSetCompletedActivityRequestIDs() bool {
+	return v != nil && v.CompletedActivityRequestIDs != nil
+}
+
+// GetFirstExecutionRunID returns the value of FirstExecutionRunID if it is set or its
+// zero value if it is unset.
+func (v *WorkflowExecutionInfo) GetFirstExecutionRunID() (o []byte) {
+	if v != nil && v.FirstExecutionRunID != nil {
+		return v.FirstExecutionRunID
+	}
+
+	return
+}
+
+// IsSetFirstExecutionRunID returns true if FirstExecutionRunID is not nil.
+func (v *WorkflowExecutionInfo) IsSetFirstExecutionRunID() bool {
+	return v != nil && v.FirstExecutionRunID != nil
+}
+
+// GetPaused returns the value of Paused if it is set or its
+// zero value if it is unset.
+func (v *WorkflowExecutionInfo) GetPaused() (o bool) {
+	if v != nil && v.Paused != nil {
+		return *v.Paused
+	}
+
+	return
+}
+
+// IsSetPaused returns true if Paused is not nil.
+func (v *WorkflowExecutionInfo) IsSetPaused() bool {
+	return v != nil && v.Paused != nil
+}
+
+// GetCronPaused returns the value of CronPaused if it is set or its
+// zero value if it is unset.
+func (v *WorkflowExecutionInfo) GetCronPaused() (o bool) {
+	if v != nil && v.CronPaused != nil {
+		return *v.CronPaused
+	}
+
+	return
+}
+
+// IsSetCronPaused returns true if CronPaused is not nil.
+func (v *WorkflowExecutionInfo) IsSetCronPaused() bool {
+	return v != nil && v.CronPaused != nil
+}
+
+// GetCronTimezone returns the value of CronTimezone if it is set or its
+// zero value if it is unset.
+func (v *WorkflowExecutionInfo) GetCronTimezone() (o string) {
+	if v != nil && v.CronTimezone != nil {
+		return *v.CronTimezone
+	}
+
+	return
+}
+
+// IsSetCronTimezone returns true if CronTimezone is not nil.
+func (v *WorkflowExecutionInfo) IsSetCronTimezone() bool {
+	return v != nil && v.CronTimezone != nil
+}
+
+// GetDecisionStartedIdentity returns the value of DecisionStartedIdentity if it is set or its
+// zero value if it is unset.
+func (v *WorkflowExecutionInfo) GetDecisionStartedIdentity() (o string) {
+	if v != nil && v.DecisionStartedIdentity != nil {
+		return *v.DecisionStartedIdentity
+	}
+
+	return
+}
+
+// IsSetDecisionStartedIdentity returns true if DecisionStartedIdentity is not nil.
+func (v *WorkflowExecutionInfo) IsSetDecisionStartedIdentity() bool {
+	return v != nil && v.DecisionStartedIdentity != nil
+}
+
+// GetDecisionStartedBinaryChecksum returns the value of DecisionStartedBinaryChecksum if it is set or its
+// zero value if it is unset.
+func (v *WorkflowExecutionInfo) GetDecisionStartedBinaryChecksum() (o string) {
+	if v != nil && v.DecisionStartedBinaryChecksum != nil {
+		return *v.DecisionStartedBinaryChecksum
+	}
+
+	return
+}
+
+// IsSetDecisionStartedBinaryChecksum returns true if DecisionStartedBinaryChecksum is not nil.
+func (v *WorkflowExecutionInfo) IsSetDecisionStartedBinaryChecksum() bool {
+	return v != nil && v.DecisionStartedBinaryChecksum != nil
+}
+
+// GetResetBaseRunID returns the value of ResetBaseRunID if it is set or its
+// zero value if it is unset.
+func (v *WorkflowExecutionInfo) GetResetBaseRunID() (o []byte) {
+	if v != nil && v.ResetBaseRunID != nil {
+		return v.ResetBaseRunID
+	}
+
+	return
+}
+
+// IsSetResetBaseRunID returns true if ResetBaseRunID is not nil.
+func (v *WorkflowExecutionInfo) IsSetResetBaseRunID() bool {
+	return v != nil && v.ResetBaseRunID != nil
+}
+
+// GetResetBaseEventID returns the value of ResetBaseEventID if it is set or its
+// zero value if it is unset.
+func (v *WorkflowExecutionInfo) GetResetBaseEventID() (o int64) {
+	if v != nil && v.ResetBaseEventID != nil {
+		return *v.ResetBaseEventID
+	}
+
+	return
+}
+
+// IsSetResetBaseEventID returns true if ResetBaseEventID is not nil.
+func (v *WorkflowExecutionInfo) IsSetResetBaseEventID() bool {
+	return v != nil && v.ResetBaseEventID != nil
+}
+
+// GetResetReason returns the value of ResetReason if it is set or its
+// zero value if it is unset.
+func (v *WorkflowExecutionInfo) GetResetReason() (o string) {
+	if v != nil && v.ResetReason != nil {
+		return *v.ResetReason
+	}
+
+	return
+}
+
+// IsSetResetReason returns true if ResetReason is not nil.
+func (v *WorkflowExecutionInfo) IsSetResetReason() bool {
+	return v != nil && v.ResetReason != nil
+}
+
+// GetNotes returns the value of Notes if it is set or its
+// zero value if it is unset.
+func (v *WorkflowExecutionInfo) GetNotes() (o string) {
+	if v != nil && v.Notes != nil {
+		return *v.Notes
+	}
+
+	return
+}
+
+// IsSetNotes returns true if Notes is not nil.
+func (v *WorkflowExecutionInfo) IsSetNotes() bool {
+	return v != nil && v.Notes != nil
+}
+
+// GetNotesIdentity returns the value of NotesIdentity if it is set or its
+// zero value if it is unset.
+func (v *WorkflowExecutionInfo) GetNotesIdentity() (o string) {
+	if v != nil && v.NotesIdentity != nil {
+		return *v.NotesIdentity
+	}
+
+	return
+}
+
+// IsSetNotesIdentity returns true if NotesIdentity is not nil.
+func (v *WorkflowExecutionInfo) IsSetNotesIdentity() bool {
+	return v != nil && v.NotesIdentity != nil
+}
+
+// GetNotesLastUpdatedTimeNanos returns the value of NotesLastUpdatedTimeNanos if it is set or its
+// zero value if it is unset.
+func (v *WorkflowExecutionInfo) GetNotesLastUpdatedTimeNanos() (o int64) {
+	if v != nil && v.NotesLastUpdatedTimeNanos != nil {
+		return *v.NotesLastUpdatedTimeNanos
+	}
+
+	return
+}
+
+// IsSetNotesLastUpdatedTimeNanos returns true if NotesLastUpdatedTimeNanos is not nil.
+func (v *WorkflowExecutionInfo) IsSetNotesLastUpdatedTimeNanos() bool {
+	return v != nil && v.NotesLastUpdatedTimeNanos != nil
+}
+
+// GetDecisionHeartbeatCount returns the value of DecisionHeartbeatCount if it is set or its
+// zero value if it is unset.
+func (v *WorkflowExecutionInfo) GetDecisionHeartbeatCount() (o int64) {
+	if v != nil && v.DecisionHeartbeatCount != nil {
+		return *v.DecisionHeartbeatCount
+	}
+
+	return
+}
+
+// IsSetDecisionHeartbeatCount returns true if DecisionHeartbeatCount is not nil.
+func (v *WorkflowExecutionInfo) IsSetDecisionHeartbeatCount() bool {
+	return v != nil && v.DecisionHeartbeatCount != nil
+}
+
 // ThriftModule represents the IDL file used to generate this package.
 var ThriftModule = &thriftreflect.ThriftModule{
 	Name:     "sqlblobs",
 	Package:  "github.com/uber/cadence/.gen/go/sqlblobs",
 	FilePath: "sqlblobs.thrift",
-	SHA1:     "4f4e526848fec9acd5f55496a1a6a88688b93379",
+	SHA1:     "d1137d991368fcf640e907bce575866411eedf2a",
 	Includes: []*thriftreflect.ThriftModule{
 		shared.ThriftModule,
 	},
 	Raw: rawIDL,
 }
 
-const rawIDL = "// Copyright (c) 2017 Uber Technologies, Inc.\n//\n// Permission is hereby granted, free of charge, to any person obtaining a copy\n// of this software and associated documentation files (the \"Software\"), to deal\n// in the Software without restriction, including without limitation the rights\n// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell\n// copies of the Software, and to permit persons to whom the Software is\n// furnished to do so, subject to the following conditions:\n//\n// The above copyright notice and this permission notice shall be included in\n// all copies or substantial portions of the Software.\n//\n// THE SOFTWARE IS PROVIDED \"AS IS\", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR\n// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,\n// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE\n// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER\n// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,\n// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN\n// THE SOFTWARE.\n\nnamespace java com.uber.cadence.sqlblobs\n\ninclude \"shared.thrift\"\n\nstruct ShardInfo {\n  10: optional i32 stolenSinceRenew\n  12: optional i64 (js.type = \"Long\") updatedAtNanos\n  14: optional i64 (js.type = \"Long\") replicationAckLevel\n  16: optional i64 (js.type = \"Long\") transferAckLevel\n  18: optional i64 (js.type = \"Long\") timerAckLevelNanos\n  24: optional i64 (js.type = \"Long\") domainNotificationVersion\n  34: optional map<string, i64> clusterTransferAckLevel\n  36: optional map<string, i64> clusterTimerAckLevel\n  38: optional string owner\n}\n\nstruct DomainInfo {\n  10: optional string name\n  12: optional string description\n  14: optional string owner\n  16: optional i32 status\n  18: optional i16 retentionDays\n  20: optional bool emitMetric\n  22: optional string archivalBucket\n  24: optional i16 archivalStatus\n  26: optional i64 (js.type = \"Long\") configVersion\n  28: optional i64 (js.type = \"Long\") notificationVersion\n  30: optional i64 (js.type = \"Long\") failoverNotificationVersion\n  32: optional i64 (js.type = \"Long\") failoverVersion\n  34: optional string activeClusterName\n  36: optional list<string> clusters\n  38: optional map<string, string> data\n  39: optional binary badBinaries\n  40: optional string badBinariesEncoding\n}\n\nstruct HistoryTreeInfo {\n  10: optional i64 (js.type = \"Long\") createdTimeNanos // For fork operation to prevent race condition of leaking event data when forking branches fail. Also can be used for clean up leaked data\n  12: optional list<shared.HistoryBranchRange> ancestors\n  14: optional string info // For lookup back to workflow during debugging, also background cleanup when fork operation cannot finish self cleanup due to crash.\n}\n\nstruct ReplicationInfo {\n  10: optional i64 (js.type = \"Long\") version\n  12: optional i64 (js.type = \"Long\") lastEventID\n}\n\nstruct WorkflowExecutionInfo {\n  10: optional binary parentDomainID\n  12: optional string parentWorkflowID\n  14: optional binary parentRunID\n  16: optional i64 (js.type = \"Long\") initiatedID\n  18: optional i64 (js.type = \"Long\") completionEventBatchID\n  20: optional binary completionEvent\n  22: optional string completionEventEncoding\n  24: optional string taskList\n  26: optional string workflowTypeName\n  28: optional i32 workflowTimeoutSeconds\n  30: optional i32 decisionTaskTimeoutSeconds\n  32: optional binary executionContext\n  34: optional i32 state\n  36: optional i32 closeStatus\n  38: optional i64 (js.type = \"Long\") startVersion\n  40: optional i64 (js.type = \"Long\") currentVersion\n  44: optional i64 (js.type = \"Long\") lastWriteEventID\n  46: optional map<string, ReplicationInfo> lastReplicationInfo\n  48: optional i64 (js.type = \"Long\") lastEventTaskID\n  50: optional i64 (js.type = \"Long\") lastFirstEventID\n  52: optional i64 (js.type = \"Long\") lastProcessedEvent\n  54: optional i64 (js.type = \"Long\") startTimeNanos\n  56: optional i64 (js.type = \"Long\") lastUpdatedTimeNanos\n  58: optional i64 (js.type = \"Long\") decisionVersion\n  60: optional i64 (js.type = \"Long\") decisionScheduleID\n  62: optional i64 (js.type = \"Long\") decisionStartedID\n  64: optional i32 decisionTimeout\n  66: optional i64 (js.type = \"Long\") decisionAttempt\n  68: optional i64 (js.type = \"Long\") decisionStartedTimestampNanos\n  69: optional i64 (js.type = \"Long\") decisionScheduledTimestampNanos\n  70: optional bool cancelRequested\n  72: optional string createRequestID\n  74: optional string decisionRequestID\n  76: optional string cancelRequestID\n  78: optional string stickyTaskList\n  80: optional i64 (js.type = \"Long\") stickyScheduleToStartTimeout\n  82: optional i64 (js.type = \"Long\") retryAttempt\n  84: optional i32 retryInitialIntervalSeconds\n  86: optional i32 retryMaximumIntervalSeconds\n  88: optional i32 retryMaximumAttempts\n  90: optional i32 retryExpirationSeconds\n  92: optional double retryBackoffCoefficient\n  94: optional i64 (js.type = \"Long\") retryExpirationTimeNanos\n  96: optional list<string> retryNonRetryableErrors\n  98: optional bool hasRetryPolicy\n  100: optional string cronSchedule\n  102: optional i32 eventStoreVersion\n  104: optional binary eventBranchToken\n  106: optional i64 (js.type = \"Long\") signalCount\n  108: optional i64 (js.type = \"Long\") historySize\n  110: optional string clientLibraryVersion\n  112: optional string clientFeatureVersion\n  114: optional string clientImpl\n  115: optional binary autoResetPoints\n  116: optional string autoResetPointsEncoding\n  118: optional map<string, binary> searchAttributes\n}\n\nstruct ActivityInfo {\n  10: optional i64 (js.type = \"Long\") version\n  12: optional i64 (js.type = \"Long\") scheduledEventBatchID\n  14: optional binary scheduledEvent\n  16: optional string scheduledEventEncoding\n  18: optional i64 (js.type = \"Long\") scheduledTimeNanos\n  20: optional i64 (js.type = \"Long\") startedID\n  22: optional binary startedEvent\n  24: optional string startedEventEncoding\n  26: optional i64 (js.type = \"Long\") startedTimeNanos\n  28: optional string activityID\n  30: optional string requestID\n  32: optional i32 scheduleToStartTimeoutSeconds\n  34: optional i32 scheduleToCloseTimeoutSeconds\n  36: optional i32 startToCloseTimeoutSeconds\n  38: optional i32 heartbeatTimeoutSeconds\n  40: optional bool cancelRequested\n  42: optional i64 (js.type = \"Long\") cancelRequestID\n  44: optional i32 timerTaskStatus\n  46: optional i32 attempt\n  48: optional string taskList\n  50: optional string startedIdentity\n  52: optional bool hasRetryPolicy\n  54: optional i32 retryInitialIntervalSeconds\n  56: optional i32 retryMaximumIntervalSeconds\n  58: optional i32 retryMaximumAttempts\n  60: optional i64 (js.type = \"Long\") retryExpirationTimeNanos\n  62: optional double retryBackoffCoefficient\n  64: optional list<string> retryNonRetryableErrors\n  66: optional string retryLastFailureReason\n  68: optional string retryLastWorkerIdentity\n}\n\nstruct ChildExecutionInfo {\n  10: optional i64 (js.type = \"Long\") version\n  12: optional i64 (js.type = \"Long\") initiatedEventBatchID\n  14: optional i64 (js.type = \"Long\") startedID\n  16: optional binary initiatedEvent\n  18: optional string initiatedEventEncoding\n  20: optional string startedWorkflowID\n  22: optional binary startedRunID\n  24: optional binary startedEvent\n  26: optional string startedEventEncoding\n  28: optional string createRequestID\n  30: optional string domainName\n  32: optional string workflowTypeName\n}\n\nstruct SignalInfo {\n  10: optional i64 (js.type = \"Long\") version\n  12: optional string requestID\n  14: optional string name\n  16: optional binary input\n  18: optional binary control\n}\n\nstruct RequestCancelInfo {\n  10: optional i64 (js.type = \"Long\") version\n  12: optional string cancelRequestID\n}\n\nstruct TimerInfo {\n  10: optional i64 (js.type = \"Long\") version\n  12: optional i64 (js.type = \"Long\") startedID\n  14: optional i64 (js.type = \"Long\") expiryTimeNanos\n  16: optional i64 (js.type = \"Long\") taskID\n}\n\nstruct TaskInfo {\n  10: optional string workflowID\n  12: optional binary runID\n  13: optional i64 (js.type = \"Long\") scheduleID\n  14: optional i64 (js.type = \"Long\") expiryTimeNanos\n  15: optional i64 (js.type = \"Long\") createdTimeNanos\n}\n\nstruct TaskListInfo {\n  10: optional i16 kind // {Normal, Sticky}\n  12: optional i64 (js.type = \"Long\") ackLevel\n  14: optional i64 (js.type = \"Long\") expiryTimeNanos\n  16: optional i64 (js.type = \"Long\") lastUpdatedNanos\n}\n\nstruct TransferTaskInfo {\n  10: optional binary domainID\n  12: optional string workflowID\n  14: optional binary runID\n  16: optional i16 taskType\n  18: optional binary targetDomainID\n  20: optional string targetWorkflowID\n  22: optional binary targetRunID\n  24: optional string taskList\n  26: optional bool targetChildWorkflowOnly\n  28: optional i64 (js.type = \"Long\") scheduleID\n  30: optional i64 (js.type = \"Long\") version\n  32: optional i64 (js.type = \"Long\") visibilityTimestampNanos\n}\n\nstruct TimerTaskInfo {\n  10: optional binary domainID\n  12: optional string workflowID\n  14: optional binary runID\n  16: optional i16 taskType\n  18: optional i16 timeoutType\n  20: optional i64 (js.type = \"Long\") version\n  22: optional i64 (js.type = \"Long\") scheduleAttempt\n  24: optional i64 (js.type = \"Long\") eventID\n}\n\nstruct ReplicationTaskInfo {\n  10: optional binary domainID\n  12: optional string workflowID\n  14: optional binary runID\n  16: optional i16 taskType\n  18: optional i64 (js.type = \"Long\") version\n  20: optional i64 (js.type = \"Long\") firstEventID\n  22: optional i64 (js.type = \"Long\") nextEventID\n  24: optional i64 (js.type = \"Long\") scheduledID\n  26: optional i32 eventStoreVersion\n  28: optional i32 newRunEventStoreVersion\n  30: optional binary branch_token\n  32: optional map<string, ReplicationInfo> lastReplicationInfo\n  34: optional binary newRunBranchToken\n  36: optional bool resetWorkflow\n}"
+const rawIDL = "// Copyright (c) 2017 Uber Technologies, Inc.\n//\n// Permission is hereby granted, free of charge, to any person obtaining a copy\n// of this software and associated documentation files (the \"Software\"), to deal\n// in the Software without restriction, including without limitation the rights\n// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell\n// copies of the Software, and to permit persons to whom the Software is\n// furnished to do so, subject to the following conditions:\n//\n// The above copyright notice and this permission notice shall be included in\n// all copies or substantial portions of the Software.\n//\n// THE SOFTWARE IS PROVIDED \"AS IS\", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR\n// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,\n// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE\n// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER\n// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,\n// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN\n// THE SOFTWARE.\n\nnamespace java com.uber.cadence.sqlblobs\n\ninclude \"shared.thrift\"\n\nstruct ShardInfo {\n  10: optional i32 stolenSinceRenew\n  12: optional i64 (js.type = \"Long\") updatedAtNanos\n  14: optional i64 (js.type = \"Long\") replicationAckLevel\n  16: optional i64 (js.type = \"Long\") transferAckLevel\n  18: optional i64 (js.type = \"Long\") timerAckLevelNanos\n  24: optional i64 (js.type = \"Long\") domainNotificationVersion\n  34: optional map<string, i64> clusterTransferAckLevel\n  36: optional map<string, i64> clusterTimerAckLevel\n  38: optional string owner\n  40: optional map<string, i64> domainOpenExecutionCounts\n  44: optional i64 (js.type = \"Long\") visibilityAckLevel\n  46: optional map<string, i64> queueAlarmStuckSinceNanos\n  48: optional map<string, i64> queueAlarmBlockingTaskIDs\n  50: optional map<string, i64> queueAlarmBlockingTaskTimestampNanos\n  52: optional list<HotExecution> hotExecutions\n  54: optional i64 (js.type = \"Long\") leaseExpiresAtNanos\n  56: optional double tasksPerSecond\n  58: optional i64 (js.type = \"Long\") taskThroughputUpdatedAtNanos\n  60: optional list<string> placement\n}\n\nstruct HotExecution {\n  10: optional binary domainID\n  12: optional string workflowID\n  14: optional binary runID\n}\n\nstruct DomainInfo {\n  10: optional string name\n  12: optional string description\n  14: optional string owner\n  16: optional i32 status\n  18: optional i16 retentionDays\n  20: optional bool emitMetric\n  22: optional string archivalBucket\n  24: optional i16 archivalStatus\n  26: optional i64 (js.type = \"Long\") configVersion\n  28: optional i64 (js.type = \"Long\") notificationVersion\n  30: optional i64 (js.type = \"Long\") failoverNotificationVersion\n  32: optional i64 (js.type = \"Long\") failoverVersion\n  34: optional string activeClusterName\n  36: optional list<string> clusters\n  38: optional map<string, string> data\n  39: optional binary badBinaries\n  40: optional string badBinariesEncoding\n  42: optional i32 retentionRunCount\n  44: optional i32 defaultExecutionTimeoutSeconds\n  46: optional i32 maxExecutionTimeoutSeconds\n  48: optional i32 defaultTaskTimeoutSeconds\n  50: optional i32 maxTaskTimeoutSeconds\n  52: optional i32 defaultActivityTimeoutSeconds\n  54: optional i32 maxActivityTimeoutSeconds\n  56: optional string encryptionKeyAlias\n  58: optional string residencyTag\n  60: optional list<string> residencyAllowedClusters\n}\n\nstruct HistoryTreeInfo {\n  10: optional i64 (js.type = \"Long\") createdTimeNanos // For fork operation to prevent race condition of leaking event data when forking branches fail. Also can be used for clean up leaked data\n  12: optional list<shared.HistoryBranchRange> ancestors\n  14: optional string info // For lookup back to workflow during debugging, also background cleanup when fork operation cannot finish self cleanup due to crash.\n}\n\nstruct ReplicationInfo {\n  10: optional i64 (js.type = \"Long\") version\n  12: optional i64 (js.type = \"Long\") lastEventID\n}\n\nstruct WorkflowExecutionInfo {\n  10: optional binary parentDomainID\n  12: optional string parentWorkflowID\n  14: optional binary parentRunID\n  16: optional i64 (js.type = \"Long\") initiatedID\n  18: optional i64 (js.type = \"Long\") completionEventBatchID\n  20: optional binary completionEvent\n  22: optional string completionEventEncoding\n  24: optional string taskList\n  26: optional string workflowTypeName\n  28: optional i32 workflowTimeoutSeconds\n  30: optional i32 decisionTaskTimeoutSeconds\n  32: optional binary executionContext\n  34: optional i32 state\n  36: optional i32 closeStatus\n  38: optional i64 (js.type = \"Long\") startVersion\n  40: optional i64 (js.type = \"Long\") currentVersion\n  44: optional i64 (js.type = \"Long\") lastWriteEventID\n  46: optional map<string, ReplicationInfo> lastReplicationInfo\n  48: optional i64 (js.type = \"Long\") lastEventTaskID\n  50: optional i64 (js.type = \"Long\") lastFirstEventID\n  52: optional i64 (js.type = \"Long\") lastProcessedEvent\n  54: optional i64 (js.type = \"Long\") startTimeNanos\n  56: optional i64 (js.type = \"Long\") lastUpdatedTimeNanos\n  58: optional i64 (js.type = \"Long\") decisionVersion\n  60: optional i64 (js.type = \"Long\") decisionScheduleID\n  62: optional i64 (js.type = \"Long\") decisionStartedID\n  64: optional i32 decisionTimeout\n  66: optional i64 (js.type = \"Long\") decisionAttempt\n  68: optional i64 (js.type = \"Long\") decisionStartedTimestampNanos\n  69: optional i64 (js.type = \"Long\") decisionScheduledTimestampNanos\n  70: optional bool cancelRequested\n  72: optional string createRequestID\n  74: optional string decisionRequestID\n  76: optional string cancelRequestID\n  78: optional string stickyTaskList\n  80: optional i64 (js.type = \"Long\") stickyScheduleToStartTimeout\n  82: optional i64 (js.type = \"Long\") retryAttempt\n  84: optional i32 retryInitialIntervalSeconds\n  86: optional i32 retryMaximumIntervalSeconds\n  88: optional i32 retryMaximumAttempts\n  90: optional i32 retryExpirationSeconds\n  92: optional double retryBackoffCoefficient\n  94: optional i64 (js.type = \"Long\") retryExpirationTimeNanos\n  96: optional list<string> retryNonRetryableErrors\n  98: optional bool hasRetryPolicy\n  100: optional string cronSchedule\n  102: optional i32 eventStoreVersion\n  104: optional binary eventBranchToken\n  106: optional i64 (js.type = \"Long\") signalCount\n  108: optional i64 (js.type = \"Long\") historySize\n  110: optional string clientLibraryVersion\n  112: optional string clientFeatureVersion\n  114: optional string clientImpl\n  115: optional binary autoResetPoints\n  116: optional string autoResetPointsEncoding\n  118: optional map<string, binary> searchAttributes\n  120: optional list<string> completedActivityRequestIDs\n  122: optional binary firstExecutionRunID\n  124: optional bool paused\n  126: optional bool cronPaused\n  128: optional string cronTimezone\n  130: optional string decisionStartedIdentity\n  132: optional string decisionStartedBinaryChecksum\n  134: optional binary resetBaseRunID\n  136: optional i64 (js.type = \"Long\") resetBaseEventID\n  138: optional string resetReason\n  140: optional string notes\n  142: optional string notesIdentity\n  144: optional i64 (js.type = \"Long\") notesLastUpdatedTimeNanos\n  146: optional i64 (js.type = \"Long\") decisionHeartbeatCount\n}\n\nstruct ActivityInfo {\n  10: optional i64 (js.type = \"Long\") version\n  12: optional i64 (js.type = \"Long\") scheduledEventBatchID\n  14: optional binary scheduledEvent\n  16: optional string scheduledEventEncoding\n  18: optional i64 (js.type = \"Long\") scheduledTimeNanos\n  20: optional i64 (js.type = \"Long\") startedID\n  22: optional binary startedEvent\n  24: optional string startedEventEncoding\n  26: optional i64 (js.type = \"Long\") startedTimeNanos\n  28: optional string activityID\n  30: optional string requestID\n  32: optional i32 scheduleToStartTimeoutSeconds\n  34: optional i32 scheduleToCloseTimeoutSeconds\n  36: optional i32 startToCloseTimeoutSeconds\n  38: optional i32 heartbeatTimeoutSeconds\n  40: optional bool cancelRequested\n  42: optional i64 (js.type = \"Long\") cancelRequestID\n  44: optional i32 timerTaskStatus\n  46: optional i32 attempt\n  48: optional string taskList\n  50: optional string startedIdentity\n  52: optional bool hasRetryPolicy\n  54: optional i32 retryInitialIntervalSeconds\n  56: optional i32 retryMaximumIntervalSeconds\n  58: optional i32 retryMaximumAttempts\n  60: optional i64 (js.type = \"Long\") retryExpirationTimeNanos\n  62: optional double retryBackoffCoefficient\n  64: optional list<string> retryNonRetryableErrors\n  66: optional string retryLastFailureReason\n  68: optional string retryLastWorkerIdentity\n  70: optional bool paused\n}\n\nstruct ChildExecutionInfo {\n  10: optional i64 (js.type = \"Long\") version\n  12: optional i64 (js.type = \"Long\") initiatedEventBatchID\n  14: optional i64 (js.type = \"Long\") startedID\n  16: optional binary initiatedEvent\n  18: optional string initiatedEventEncoding\n  20: optional string startedWorkflowID\n  22: optional binary startedRunID\n  24: optional binary startedEvent\n  26: optional string startedEventEncoding\n  28: optional string createRequestID\n  30: optional string domainName\n  32: optional string workflowTypeName\n}\n\nstruct SignalInfo {\n  10: optional i64 (js.type = \"Long\") version\n  12: optional string requestID\n  14: optional string name\n  16: optional binary input\n  18: optional binary control\n}\n\nstruct UpdateInfo {\n  10: optional i64 (js.type = \"Long\") version\n  12: optional string name\n  14: optional binary input\n  16: optional binary result\n  18: optional bool completed\n}\n\nstruct RequestCancelInfo {\n  10: optional i64 (js.type = \"Long\") version\n  12: optional string cancelRequestID\n}\n\nstruct TimerInfo {\n  10: optional i64 (js.type = \"Long\") version\n  12: optional i64 (js.type = \"Long\") startedID\n  14: optional i64 (js.type = \"Long\") expiryTimeNanos\n  16: optional i64 (js.type = \"Long\") taskID\n}\n\nstruct TaskInfo {\n  10: optional string workflowID\n  12: optional binary runID\n  13: optional i64 (js.type = \"Long\") scheduleID\n  14: optional i64 (js.type = \"Long\") expiryTimeNanos\n  15: optional i64 (js.type = \"Long\") createdTimeNanos\n}\n\nstruct TaskListInfo {\n  10: optional i16 kind // {Normal, Sticky}\n  12: optional i64 (js.type = \"Long\") ackLevel\n  14: optional i64 (js.type = \"Long\") expiryTimeNanos\n  16: optional i64 (js.type = \"Long\") lastUpdatedNanos\n  18: optional list<shared.TaskListVersionSet> versionSets\n}\n\nstruct TransferTaskInfo {\n  10: optional binary domainID\n  12: optional string workflowID\n  14: optional binary runID\n  16: optional i16 taskType\n  18: optional binary targetDomainID\n  20: optional string targetWorkflowID\n  22: optional binary targetRunID\n  24: optional string taskList\n  26: optional bool targetChildWorkflowOnly\n  28: optional i64 (js.type = \"Long\") scheduleID\n  30: optional i64 (js.type = \"Long\") version\n  32: optional i64 (js.type = \"Long\") visibilityTimestampNanos\n}\n\nstruct TimerTaskInfo {\n  10: optional binary domainID\n  12: optional string workflowID\n  14: optional binary runID\n  16: optional i16 taskType\n  18: optional i16 timeoutType\n  20: optional i64 (js.type = \"Long\") version\n  22: optional i64 (js.type = \"Long\") scheduleAttempt\n  24: optional i64 (js.type = \"Long\") eventID\n}\n\nstruct ReplicationTaskInfo {\n  10: optional binary domainID\n  12: optional string workflowID\n  14: optional binary runID\n  16: optional i16 taskType\n  18: optional i64 (js.type = \"Long\") version\n  20: optional i64 (js.type = \"Long\") firstEventID\n  22: optional i64 (js.type = \"Long\") nextEventID\n  24: optional i64 (js.type = \"Long\") scheduledID\n  26: optional i32 eventStoreVersion\n  28: optional i32 newRunEventStoreVersion\n  30: optional binary branch_token\n  32: optional map<string, ReplicationInfo> lastReplicationInfo\n  34: optional binary newRunBranchToken\n  36: optional bool resetWorkflow\n}"
@@ -71,6 +71,22 @@ func (c *clientImpl) DescribeHistoryHost(
 	return client.DescribeHistoryHost(ctx, request, opts...)
 }
 
+func (c *clientImpl) DescribeTaskJourney(
+	ctx context.Context,
+	request *shared.DescribeTaskJourneyRequest,
+	opts ...yarpc.CallOption,
+) (*shared.DescribeTaskJourneyResponse, error) {
+
+	opts = common.AggregateYarpcOptions(ctx, opts...)
+	client, err := c.getRandomClient()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := c.createContext(ctx)
+	defer cancel()
+	return client.DescribeTaskJourney(ctx, request, opts...)
+}
+
 func (c *clientImpl) DescribeWorkflowExecution(
 	ctx context.Context,
 	request *admin.DescribeWorkflowExecutionRequest,
@@ -87,6 +103,86 @@ func (c *clientImpl) DescribeWorkflowExecution(
 	return client.DescribeWorkflowExecution(ctx, request, opts...)
 }
 
+func (c *clientImpl) DescribeWorkflowShard(
+	ctx context.Context,
+	request *admin.DescribeWorkflowShardRequest,
+	opts ...yarpc.CallOption,
+) (*admin.DescribeWorkflowShardResponse, error) {
+
+	opts = common.AggregateYarpcOptions(ctx, opts...)
+	client, err := c.getRandomClient()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := c.createContext(ctx)
+	defer cancel()
+	return client.DescribeWorkflowShard(ctx, request, opts...)
+}
+
+func (c *clientImpl) ListWorkflowExecutionRuns(
+	ctx context.Context,
+	request *admin.ListWorkflowExecutionRunsRequest,
+	opts ...yarpc.CallOption,
+) (*admin.ListWorkflowExecutionRunsResponse, error) {
+
+	opts = common.AggregateYarpcOptions(ctx, opts...)
+	client, err := c.getRandomClient()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := c.createContext(ctx)
+	defer cancel()
+	return client.ListWorkflowExecutionRuns(ctx, request, opts...)
+}
+
+func (c *clientImpl) ListRecentlyClosedExecutions(
+	ctx context.Context,
+	request *admin.ListRecentlyClosedExecutionsRequest,
+	opts ...yarpc.CallOption,
+) (*admin.ListRecentlyClosedExecutionsResponse, error) {
+
+	opts = common.AggregateYarpcOptions(ctx, opts...)
+	client, err := c.getRandomClient()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := c.createContext(ctx)
+	defer cancel()
+	return client.ListRecentlyClosedExecutions(ctx, request, opts...)
+}
+
+func (c *clientImpl) ImportWorkflowExecution(
+	ctx context.Context,
+	request *admin.ImportWorkflowExecutionRequest,
+	opts ...yarpc.CallOption,
+) (*admin.ImportWorkflowExecutionResponse, error) {
+
+	opts = common.AggregateYarpcOptions(ctx, opts...)
+	client, err := c.getRandomClient()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := c.createContext(ctx)
+	defer cancel()
+	return client.ImportWorkflowExecution(ctx, request, opts...)
+}
+
+func (c *clientImpl) PurgeWorkflowExecution(
+	ctx context.Context,
+	request *admin.PurgeWorkflowExecutionRequest,
+	opts ...yarpc.CallOption,
+) (*admin.PurgeWorkflowExecutionResponse, error) {
+
+	opts = common.AggregateYarpcOptions(ctx, opts...)
+	client, err := c.getRandomClient()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := c.createContext(ctx)
+	defer cancel()
+	return client.PurgeWorkflowExecution(ctx, request, opts...)
+}
+
 func (c *clientImpl) GetWorkflowExecutionRawHistory(
 	ctx context.Context,
 	request *admin.GetWorkflowExecutionRawHistoryRequest,
@@ -103,6 +199,262 @@ func (c *clientImpl) GetWorkflowExecutionRawHistory(
 	return client.GetWorkflowExecutionRawHistory(ctx, request, opts...)
 }
 
+func (c *clientImpl) SetActivityPaused(
+	ctx context.Context,
+	request *shared.SetActivityPausedRequest,
+	opts ...yarpc.CallOption,
+) error {
+
+	opts = common.AggregateYarpcOptions(ctx, opts...)
+	client, err := c.getRandomClient()
+	if err != nil {
+		return err
+	}
+	ctx, cancel := c.createContext(ctx)
+	defer cancel()
+	return client.SetActivityPaused(ctx, request, opts...)
+}
+
+func (c *clientImpl) SetWorkflowExecutionNotes(
+	ctx context.Context,
+	request *shared.SetWorkflowExecutionNotesRequest,
+	opts ...yarpc.CallOption,
+) error {
+
+	opts = common.AggregateYarpcOptions(ctx, opts...)
+	client, err := c.getRandomClient()
+	if err != nil {
+		return err
+	}
+	ctx, cancel := c.createContext(ctx)
+	defer cancel()
+	return client.SetWorkflowExecutionNotes(ctx, request, opts...)
+}
+
+func (c *clientImpl) SetWorkflowExecutionPaused(
+	ctx context.Context,
+	request *shared.SetWorkflowExecutionPausedRequest,
+	opts ...yarpc.CallOption,
+) error {
+
+	opts = common.AggregateYarpcOptions(ctx, opts...)
+	client, err := c.getRandomClient()
+	if err != nil {
+		return err
+	}
+	ctx, cancel := c.createContext(ctx)
+	defer cancel()
+	return client.SetWorkflowExecutionPaused(ctx, request, opts...)
+}
+
+func (c *clientImpl) SetCronSchedulePaused(
+	ctx context.Context,
+	request *shared.SetCronSchedulePausedRequest,
+	opts ...yarpc.CallOption,
+) error {
+
+	opts = common.AggregateYarpcOptions(ctx, opts...)
+	client, err := c.getRandomClient()
+	if err != nil {
+		return err
+	}
+	ctx, cancel := c.createContext(ctx)
+	defer cancel()
+	return client.SetCronSchedulePaused(ctx, request, opts...)
+}
+
+func (c *clientImpl) TerminateAllWorkflowRuns(
+	ctx context.Context,
+	request *shared.TerminateAllWorkflowRunsRequest,
+	opts ...yarpc.CallOption,
+) (*shared.TerminateAllWorkflowRunsResponse, error) {
+
+	opts = common.AggregateYarpcOptions(ctx, opts...)
+	client, err := c.getRandomClient()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := c.createContext(ctx)
+	defer cancel()
+	return client.TerminateAllWorkflowRuns(ctx, request, opts...)
+}
+
+func (c *clientImpl) CreateSchedule(
+	ctx context.Context,
+	request *shared.CreateScheduleRequest,
+	opts ...yarpc.CallOption,
+) error {
+
+	opts = common.AggregateYarpcOptions(ctx, opts...)
+	client, err := c.getRandomClient()
+	if err != nil {
+		return err
+	}
+	ctx, cancel := c.createContext(ctx)
+	defer cancel()
+	return client.CreateSchedule(ctx, request, opts...)
+}
+
+func (c *clientImpl) DescribeSchedule(
+	ctx context.Context,
+	request *shared.DescribeScheduleRequest,
+	opts ...yarpc.CallOption,
+) (*shared.DescribeScheduleResponse, error) {
+
+	opts = common.AggregateYarpcOptions(ctx, opts...)
+	client, err := c.getRandomClient()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := c.createContext(ctx)
+	defer cancel()
+	return client.DescribeSchedule(ctx, request, opts...)
+}
+
+func (c *clientImpl) DeleteSchedule(
+	ctx context.Context,
+	request *shared.DeleteScheduleRequest,
+	opts ...yarpc.CallOption,
+) error {
+
+	opts = common.AggregateYarpcOptions(ctx, opts...)
+	client, err := c.getRandomClient()
+	if err != nil {
+		return err
+	}
+	ctx, cancel := c.createContext(ctx)
+	defer cancel()
+	return client.DeleteSchedule(ctx, request, opts...)
+}
+
+func (c *clientImpl) ListSchedules(
+	ctx context.Context,
+	request *shared.ListSchedulesRequest,
+	opts ...yarpc.CallOption,
+) (*shared.ListSchedulesResponse, error) {
+
+	opts = common.AggregateYarpcOptions(ctx, opts...)
+	client, err := c.getRandomClient()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := c.createContext(ctx)
+	defer cancel()
+	return client.ListSchedules(ctx, request, opts...)
+}
+
+func (c *clientImpl) SetSchedulePaused(
+	ctx context.Context,
+	request *shared.SetSchedulePausedRequest,
+	opts ...yarpc.CallOption,
+) error {
+
+	opts = common.AggregateYarpcOptions(ctx, opts...)
+	client, err := c.getRandomClient()
+	if err != nil {
+		return err
+	}
+	ctx, cancel := c.createContext(ctx)
+	defer cancel()
+	return client.SetSchedulePaused(ctx, request, opts...)
+}
+
+func (c *clientImpl) BackfillSchedule(
+	ctx context.Context,
+	request *shared.BackfillScheduleRequest,
+	opts ...yarpc.CallOption,
+) error {
+
+	opts = common.AggregateYarpcOptions(ctx, opts...)
+	client, err := c.getRandomClient()
+	if err != nil {
+		return err
+	}
+	ctx, cancel := c.createContext(ctx)
+	defer cancel()
+	return client.BackfillSchedule(ctx, request, opts...)
+}
+
+func (c *clientImpl) DrainHistoryHost(
+	ctx context.Context,
+	request *shared.DrainHistoryHostRequest,
+	opts ...yarpc.CallOption,
+) (*shared.DrainHistoryHostResponse, error) {
+
+	opts = common.AggregateYarpcOptions(ctx, opts...)
+	client, err := c.getRandomClient()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := c.createContext(ctx)
+	defer cancel()
+	return client.DrainHistoryHost(ctx, request, opts...)
+}
+
+func (c *clientImpl) UndrainHistoryHost(
+	ctx context.Context,
+	request *shared.UndrainHistoryHostRequest,
+	opts ...yarpc.CallOption,
+) (*shared.UndrainHistoryHostResponse, error) {
+
+	opts = common.AggregateYarpcOptions(ctx, opts...)
+	client, err := c.getRandomClient()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := c.createContext(ctx)
+	defer cancel()
+	return client.UndrainHistoryHost(ctx, request, opts...)
+}
+
+func (c *clientImpl) DescribeClientVersions(
+	ctx context.Context,
+	request *shared.DescribeClientVersionsRequest,
+	opts ...yarpc.CallOption,
+) (*shared.DescribeClientVersionsResponse, error) {
+
+	opts = common.AggregateYarpcOptions(ctx, opts...)
+	client, err := c.getRandomClient()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := c.createContext(ctx)
+	defer cancel()
+	return client.DescribeClientVersions(ctx, request, opts...)
+}
+
+func (c *clientImpl) GetDomainOpenExecutionCount(
+	ctx context.Context,
+	request *shared.GetDomainOpenExecutionCountRequest,
+	opts ...yarpc.CallOption,
+) (*shared.GetDomainOpenExecutionCountResponse, error) {
+
+	opts = common.AggregateYarpcOptions(ctx, opts...)
+	client, err := c.getRandomClient()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := c.createContext(ctx)
+	defer cancel()
+	return client.GetDomainOpenExecutionCount(ctx, request, opts...)
+}
+
+func (c *clientImpl) UpdateTaskListVersionSets(
+	ctx context.Context,
+	request *shared.UpdateTaskListVersionSetsRequest,
+	opts ...yarpc.CallOption,
+) error {
+
+	opts = common.AggregateYarpcOptions(ctx, opts...)
+	client, err := c.getRandomClient()
+	if err != nil {
+		return err
+	}
+	ctx, cancel := c.createContext(ctx)
+	defer cancel()
+	return client.UpdateTaskListVersionSets(ctx, request, opts...)
+}
+
 func (c *clientImpl) createContext(parent context.Context) (context.Context, context.CancelFunc) {
 	if parent == nil {
 		return context.WithTimeout(context.Background(), c.timeout)
@@ -62,6 +62,24 @@ func (c *metricClient) DescribeHistoryHost(
 	return resp, err
 }
 
+func (c *metricClient) DescribeTaskJourney(
+	ctx context.Context,
+	request *shared.DescribeTaskJourneyRequest,
+	opts ...yarpc.CallOption,
+) (*shared.DescribeTaskJourneyResponse, error) {
+
+	c.metricsClient.IncCounter(metrics.AdminClientDescribeTaskJourneyScope, metrics.CadenceClientRequests)
+
+	sw := c.metricsClient.StartTimer(metrics.AdminClientDescribeTaskJourneyScope, metrics.CadenceClientLatency)
+	resp, err := c.client.DescribeTaskJourney(ctx, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.AdminClientDescribeTaskJourneyScope, metrics.CadenceClientFailures)
+	}
+	return resp, err
+}
+
 func (c *metricClient) DescribeWorkflowExecution(
 	ctx context.Context,
 	request *admin.DescribeWorkflowExecutionRequest,
@@ -80,6 +98,96 @@ func (c *metricClient) DescribeWorkflowExecution(
 	return resp, err
 }
 
+func (c *metricClient) DescribeWorkflowShard(
+	ctx context.Context,
+	request *admin.DescribeWorkflowShardRequest,
+	opts ...yarpc.CallOption,
+) (*admin.DescribeWorkflowShardResponse, error) {
+
+	c.metricsClient.IncCounter(metrics.AdminClientDescribeWorkflowShardScope, metrics.CadenceClientRequests)
+
+	sw := c.metricsClient.StartTimer(metrics.AdminClientDescribeWorkflowShardScope, metrics.CadenceClientLatency)
+	resp, err := c.client.DescribeWorkflowShard(ctx, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.AdminClientDescribeWorkflowShardScope, metrics.CadenceClientFailures)
+	}
+	return resp, err
+}
+
+func (c *metricClient) ListWorkflowExecutionRuns(
+	ctx context.Context,
+	request *admin.ListWorkflowExecutionRunsRequest,
+	opts ...yarpc.CallOption,
+) (*admin.ListWorkflowExecutionRunsResponse, error) {
+
+	c.metricsClient.IncCounter(metrics.AdminClientListWorkflowExecutionRunsScope, metrics.CadenceClientRequests)
+
+	sw := c.metricsClient.StartTimer(metrics.AdminClientListWorkflowExecutionRunsScope, metrics.CadenceClientLatency)
+	resp, err := c.client.ListWorkflowExecutionRuns(ctx, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.AdminClientListWorkflowExecutionRunsScope, metrics.CadenceClientFailures)
+	}
+	return resp, err
+}
+
+func (c *metricClient) ListRecentlyClosedExecutions(
+	ctx context.Context,
+	request *admin.ListRecentlyClosedExecutionsRequest,
+	opts ...yarpc.CallOption,
+) (*admin.ListRecentlyClosedExecutionsResponse, error) {
+
+	c.metricsClient.IncCounter(metrics.AdminClientListRecentlyClosedExecutionsScope, metrics.CadenceClientRequests)
+
+	sw := c.metricsClient.StartTimer(metrics.AdminClientListRecentlyClosedExecutionsScope, metrics.CadenceClientLatency)
+	resp, err := c.client.ListRecentlyClosedExecutions(ctx, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.AdminClientListRecentlyClosedExecutionsScope, metrics.CadenceClientFailures)
+	}
+	return resp, err
+}
+
+func (c *metricClient) ImportWorkflowExecution(
+	ctx context.Context,
+	request *admin.ImportWorkflowExecutionRequest,
+	opts ...yarpc.CallOption,
+) (*admin.ImportWorkflowExecutionResponse, error) {
+
+	c.metricsClient.IncCounter(metrics.AdminClientImportWorkflowExecutionScope, metrics.CadenceClientRequests)
+
+	sw := c.metricsClient.StartTimer(metrics.AdminClientImportWorkflowExecutionScope, metrics.CadenceClientLatency)
+	resp, err := c.client.ImportWorkflowExecution(ctx, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.AdminClientImportWorkflowExecutionScope, metrics.CadenceClientFailures)
+	}
+	return resp, err
+}
+
+func (c *metricClient) PurgeWorkflowExecution(
+	ctx context.Context,
+	request *admin.PurgeWorkflowExecutionRequest,
+	opts ...yarpc.CallOption,
+) (*admin.PurgeWorkflowExecutionResponse, error) {
+
+	c.metricsClient.IncCounter(metrics.AdminClientPurgeWorkflowExecutionScope, metrics.CadenceClientRequests)
+
+	sw := c.metricsClient.StartTimer(metrics.AdminClientPurgeWorkflowExecutionScope, metrics.CadenceClientLatency)
+	resp, err := c.client.PurgeWorkflowExecution(ctx, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.AdminClientPurgeWorkflowExecutionScope, metrics.CadenceClientFailures)
+	}
+	return resp, err
+}
+
 func (c *metricClient) GetWorkflowExecutionRawHistory(
 	ctx context.Context,
 	request *admin.GetWorkflowExecutionRawHistoryRequest,
@@ -97,3 +205,291 @@ func (c *metricClient) GetWorkflowExecutionRawHistory(
 	}
 	return resp, err
 }
+
+func (c *metricClient) SetActivityPaused(
+	ctx context.Context,
+	request *shared.SetActivityPausedRequest,
+	opts ...yarpc.CallOption,
+) error {
+
+	c.metricsClient.IncCounter(metrics.AdminClientSetActivityPausedScope, metrics.CadenceClientRequests)
+
+	sw := c.metricsClient.StartTimer(metrics.AdminClientSetActivityPausedScope, metrics.CadenceClientLatency)
+	err := c.client.SetActivityPaused(ctx, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.AdminClientSetActivityPausedScope, metrics.CadenceClientFailures)
+	}
+	return err
+}
+
+func (c *metricClient) SetWorkflowExecutionNotes(
+	ctx context.Context,
+	request *shared.SetWorkflowExecutionNotesRequest,
+	opts ...yarpc.CallOption,
+) error {
+
+	c.metricsClient.IncCounter(metrics.AdminClientSetWorkflowExecutionNotesScope, metrics.CadenceClientRequests)
+
+	sw := c.metricsClient.StartTimer(metrics.AdminClientSetWorkflowExecutionNotesScope, metrics.CadenceClientLatency)
+	err := c.client.SetWorkflowExecutionNotes(ctx, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.AdminClientSetWorkflowExecutionNotesScope, metrics.CadenceClientFailures)
+	}
+	return err
+}
+
+func (c *metricClient) SetWorkflowExecutionPaused(
+	ctx context.Context,
+	request *shared.SetWorkflowExecutionPausedRequest,
+	opts ...yarpc.CallOption,
+) error {
+
+	c.metricsClient.IncCounter(metrics.AdminClientSetWorkflowExecutionPausedScope, metrics.CadenceClientRequests)
+
+	sw := c.metricsClient.StartTimer(metrics.AdminClientSetWorkflowExecutionPausedScope, metrics.CadenceClientLatency)
+	err := c.client.SetWorkflowExecutionPaused(ctx, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.AdminClientSetWorkflowExecutionPausedScope, metrics.CadenceClientFailures)
+	}
+	return err
+}
+
+func (c *metricClient) SetCronSchedulePaused(
+	ctx context.Context,
+	request *shared.SetCronSchedulePausedRequest,
+	opts ...yarpc.CallOption,
+) error {
+
+	c.metricsClient.IncCounter(metrics.AdminClientSetCronSchedulePausedScope, metrics.CadenceClientRequests)
+
+	sw := c.metricsClient.StartTimer(metrics.AdminClientSetCronSchedulePausedScope, metrics.CadenceClientLatency)
+	err := c.client.SetCronSchedulePaused(ctx, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.AdminClientSetCronSchedulePausedScope, metrics.CadenceClientFailures)
+	}
+	return err
+}
+
+func (c *metricClient) TerminateAllWorkflowRuns(
+	ctx context.Context,
+	request *shared.TerminateAllWorkflowRunsRequest,
+	opts ...yarpc.CallOption,
+) (*shared.TerminateAllWorkflowRunsResponse, error) {
+
+	c.metricsClient.IncCounter(metrics.AdminClientTerminateAllWorkflowRunsScope, metrics.CadenceClientRequests)
+
+	sw := c.metricsClient.StartTimer(metrics.AdminClientTerminateAllWorkflowRunsScope, metrics.CadenceClientLatency)
+	resp, err := c.client.TerminateAllWorkflowRuns(ctx, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.AdminClientTerminateAllWorkflowRunsScope, metrics.CadenceClientFailures)
+	}
+	return resp, err
+}
+
+func (c *metricClient) CreateSchedule(
+	ctx context.Context,
+	request *shared.CreateScheduleRequest,
+	opts ...yarpc.CallOption,
+) error {
+
+	c.metricsClient.IncCounter(metrics.AdminClientCreateScheduleScope, metrics.CadenceClientRequests)
+
+	sw := c.metricsClient.StartTimer(metrics.AdminClientCreateScheduleScope, metrics.CadenceClientLatency)
+	err := c.client.CreateSchedule(ctx, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.AdminClientCreateScheduleScope, metrics.CadenceClientFailures)
+	}
+	return err
+}
+
+func (c *metricClient) DescribeSchedule(
+	ctx context.Context,
+	request *shared.DescribeScheduleRequest,
+	opts ...yarpc.CallOption,
+) (*shared.DescribeScheduleResponse, error) {
+
+	c.metricsClient.IncCounter(metrics.AdminClientDescribeScheduleScope, metrics.CadenceClientRequests)
+
+	sw := c.metricsClient.StartTimer(metrics.AdminClientDescribeScheduleScope, metrics.CadenceClientLatency)
+	resp, err := c.client.DescribeSchedule(ctx, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.AdminClientDescribeScheduleScope, metrics.CadenceClientFailures)
+	}
+	return resp, err
+}
+
+func (c *metricClient) DeleteSchedule(
+	ctx context.Context,
+	request *shared.DeleteScheduleRequest,
+	opts ...yarpc.CallOption,
+) error {
+
+	c.metricsClient.IncCounter(metrics.AdminClientDeleteScheduleScope, metrics.CadenceClientRequests)
+
+	sw := c.metricsClient.StartTimer(metrics.AdminClientDeleteScheduleScope, metrics.CadenceClientLatency)
+	err := c.client.DeleteSchedule(ctx, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.AdminClientDeleteScheduleScope, metrics.CadenceClientFailures)
+	}
+	return err
+}
+
+func (c *metricClient) ListSchedules(
+	ctx context.Context,
+	request *shared.ListSchedulesRequest,
+	opts ...yarpc.CallOption,
+) (*shared.ListSchedulesResponse, error) {
+
+	c.metricsClient.IncCounter(metrics.AdminClientListSchedulesScope, metrics.CadenceClientRequests)
+
+	sw := c.metricsClient.StartTimer(metrics.AdminClientListSchedulesScope, metrics.CadenceClientLatency)
+	resp, err := c.client.ListSchedules(ctx, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.AdminClientListSchedulesScope, metrics.CadenceClientFailures)
+	}
+	return resp, err
+}
+
+func (c *metricClient) SetSchedulePaused(
+	ctx context.Context,
+	request *shared.SetSchedulePausedRequest,
+	opts ...yarpc.CallOption,
+) error {
+
+	c.metricsClient.IncCounter(metrics.AdminClientSetSchedulePausedScope, metrics.CadenceClientRequests)
+
+	sw := c.metricsClient.StartTimer(metrics.AdminClientSetSchedulePausedScope, metrics.CadenceClientLatency)
+	err := c.client.SetSchedulePaused(ctx, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.AdminClientSetSchedulePausedScope, metrics.CadenceClientFailures)
+	}
+	return err
+}
+
+func (c *metricClient) BackfillSchedule(
+	ctx context.Context,
+	request *shared.BackfillScheduleRequest,
+	opts ...yarpc.CallOption,
+) error {
+
+	c.metricsClient.IncCounter(metrics.AdminClientBackfillScheduleScope, metrics.CadenceClientRequests)
+
+	sw := c.metricsClient.StartTimer(metrics.AdminClientBackfillScheduleScope, metrics.CadenceClientLatency)
+	err := c.client.BackfillSchedule(ctx, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.AdminClientBackfillScheduleScope, metrics.CadenceClientFailures)
+	}
+	return err
+}
+
+func (c *metricClient) DrainHistoryHost(
+	ctx context.Context,
+	request *shared.DrainHistoryHostRequest,
+	opts ...yarpc.CallOption,
+) (*shared.DrainHistoryHostResponse, error) {
+
+	c.metricsClient.IncCounter(metrics.AdminClientDrainHistoryHostScope, metrics.CadenceClientRequests)
+
+	sw := c.metricsClient.StartTimer(metrics.AdminClientDrainHistoryHostScope, metrics.CadenceClientLatency)
+	resp, err := c.client.DrainHistoryHost(ctx, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.AdminClientDrainHistoryHostScope, metrics.CadenceClientFailures)
+	}
+	return resp, err
+}
+
+func (c *metricClient) UndrainHistoryHost(
+	ctx context.Context,
+	request *shared.UndrainHistoryHostRequest,
+	opts ...yarpc.CallOption,
+) (*shared.UndrainHistoryHostResponse, error) {
+
+	c.metricsClient.IncCounter(metrics.AdminClientUndrainHistoryHostScope, metrics.CadenceClientRequests)
+
+	sw := c.metricsClient.StartTimer(metrics.AdminClientUndrainHistoryHostScope, metrics.CadenceClientLatency)
+	resp, err := c.client.UndrainHistoryHost(ctx, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.AdminClientUndrainHistoryHostScope, metrics.CadenceClientFailures)
+	}
+	return resp, err
+}
+
+func (c *metricClient) DescribeClientVersions(
+	ctx context.Context,
+	request *shared.DescribeClientVersionsRequest,
+	opts ...yarpc.CallOption,
+) (*shared.DescribeClientVersionsResponse, error) {
+
+	c.metricsClient.IncCounter(metrics.AdminClientDescribeClientVersionsScope, metrics.CadenceClientRequests)
+
+	sw := c.metricsClient.StartTimer(metrics.AdminClientDescribeClientVersionsScope, metrics.CadenceClientLatency)
+	resp, err := c.client.DescribeClientVersions(ctx, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.AdminClientDescribeClientVersionsScope, metrics.CadenceClientFailures)
+	}
+	return resp, err
+}
+
+func (c *metricClient) GetDomainOpenExecutionCount(
+	ctx context.Context,
+	request *shared.GetDomainOpenExecutionCountRequest,
+	opts ...yarpc.CallOption,
+) (*shared.GetDomainOpenExecutionCountResponse, error) {
+
+	c.metricsClient.IncCounter(metrics.AdminClientGetDomainOpenExecutionCountScope, metrics.CadenceClientRequests)
+
+	sw := c.metricsClient.StartTimer(metrics.AdminClientGetDomainOpenExecutionCountScope, metrics.CadenceClientLatency)
+	resp, err := c.client.GetDomainOpenExecutionCount(ctx, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.AdminClientGetDomainOpenExecutionCountScope, metrics.CadenceClientFailures)
+	}
+	return resp, err
+}
+
+func (c *metricClient) UpdateTaskListVersionSets(
+	ctx context.Context,
+	request *shared.UpdateTaskListVersionSetsRequest,
+	opts ...yarpc.CallOption,
+) error {
+
+	c.metricsClient.IncCounter(metrics.AdminClientUpdateTaskListVersionSetsScope, metrics.CadenceClientRequests)
+
+	sw := c.metricsClient.StartTimer(metrics.AdminClientUpdateTaskListVersionSetsScope, metrics.CadenceClientLatency)
+	err := c.client.UpdateTaskListVersionSets(ctx, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.AdminClientUpdateTaskListVersionSetsScope, metrics.CadenceClientFailures)
+	}
+	return err
+}
@@ -62,6 +62,22 @@ func (c *retryableClient) DescribeHistoryHost(
 	return resp, err
 }
 
+func (c *retryableClient) DescribeTaskJourney(
+	ctx context.Context,
+	request *shared.DescribeTaskJourneyRequest,
+	opts ...yarpc.CallOption,
+) (*shared.DescribeTaskJourneyResponse, error) {
+
+	var resp *shared.DescribeTaskJourneyResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.DescribeTaskJourney(ctx, request, opts...)
+		return err
+	}
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
+
 func (c *retryableClient) DescribeWorkflowExecution(
 	ctx context.Context,
 	request *admin.DescribeWorkflowExecutionRequest,
@@ -78,6 +94,80 @@ func (c *retryableClient) DescribeWorkflowExecution(
 	return resp, err
 }
 
+func (c *retryableClient) DescribeWorkflowShard(
+	ctx context.Context,
+	request *admin.DescribeWorkflowShardRequest,
+	opts ...yarpc.CallOption,
+) (*admin.DescribeWorkflowShardResponse, error) {
+
+	var resp *admin.DescribeWorkflowShardResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.DescribeWorkflowShard(ctx, request, opts...)
+		return err
+	}
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
+
+func (c *retryableClient) ListWorkflowExecutionRuns(
+	ctx context.Context,
+	request *admin.ListWorkflowExecutionRunsRequest,
+	opts ...yarpc.CallOption,
+) (*admin.ListWorkflowExecutionRunsResponse, error) {
+
+	var resp *admin.ListWorkflowExecutionRunsResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.ListWorkflowExecutionRuns(ctx, request, opts...)
+		return err
+	}
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
+
+func (c *retryableClient) ListRecentlyClosedExecutions(
+	ctx context.Context,
+	request *admin.ListRecentlyClosedExecutionsRequest,
+	opts ...yarpc.CallOption,
+) (*admin.ListRecentlyClosedExecutionsResponse, error) {
+
+	var resp *admin.ListRecentlyClosedExecutionsResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.ListRecentlyClosedExecutions(ctx, request, opts...)
+		return err
+	}
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
+
+// ImportWorkflowExecution is not retried, every attempt of the import creates a new run of the workflow
+func (c *retryableClient) ImportWorkflowExecution(
+	ctx context.Context,
+	request *admin.ImportWorkflowExecutionRequest,
+	opts ...yarpc.CallOption,
+) (*admin.ImportWorkflowExecutionResponse, error) {
+
+	return c.client.ImportWorkflowExecution(ctx, request, opts...)
+}
+
+func (c *retryableClient) PurgeWorkflowExecution(
+	ctx context.Context,
+	request *admin.PurgeWorkflowExecutionRequest,
+	opts ...yarpc.CallOption,
+) (*admin.PurgeWorkflowExecutionResponse, error) {
+
+	var resp *admin.PurgeWorkflowExecutionResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.PurgeWorkflowExecution(ctx, request, opts...)
+		return err
+	}
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
+
 func (c *retryableClient) GetWorkflowExecutionRawHistory(
 	ctx context.Context,
 	request *admin.GetWorkflowExecutionRawHistoryRequest,
@@ -93,3 +183,223 @@ func (c *retryableClient) GetWorkflowExecutionRawHistory(
 	err := backoff.Retry(op, c.policy, c.isRetryable)
 	return resp, err
 }
+
+func (c *retryableClient) SetActivityPaused(
+	ctx context.Context,
+	request *shared.SetActivityPausedRequest,
+	opts ...yarpc.CallOption,
+) error {
+
+	op := func() error {
+		return c.client.SetActivityPaused(ctx, request, opts...)
+	}
+	return backoff.Retry(op, c.policy, c.isRetryable)
+}
+
+func (c *retryableClient) SetWorkflowExecutionNotes(
+	ctx context.Context,
+	request *shared.SetWorkflowExecutionNotesRequest,
+	opts ...yarpc.CallOption,
+) error {
+
+	op := func() error {
+		return c.client.SetWorkflowExecutionNotes(ctx, request, opts...)
+	}
+	return backoff.Retry(op, c.policy, c.isRetryable)
+}
+
+func (c *retryableClient) SetWorkflowExecutionPaused(
+	ctx context.Context,
+	request *shared.SetWorkflowExecutionPausedRequest,
+	opts ...yarpc.CallOption,
+) error {
+
+	op := func() error {
+		return c.client.SetWorkflowExecutionPaused(ctx, request, opts...)
+	}
+	return backoff.Retry(op, c.policy, c.isRetryable)
+}
+
+func (c *retryableClient) SetCronSchedulePaused(
+	ctx context.Context,
+	request *shared.SetCronSchedulePausedRequest,
+	opts ...yarpc.CallOption,
+) error {
+
+	op := func() error {
+		return c.client.SetCronSchedulePaused(ctx, request, opts...)
+	}
+	return backoff.Retry(op, c.policy, c.isRetryable)
+}
+
+func (c *retryableClient) TerminateAllWorkflowRuns(
+	ctx context.Context,
+	request *shared.TerminateAllWorkflowRunsRequest,
+	opts ...yarpc.CallOption,
+) (*shared.TerminateAllWorkflowRunsResponse, error) {
+
+	var resp *shared.TerminateAllWorkflowRunsResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.TerminateAllWorkflowRuns(ctx, request, opts...)
+		return err
+	}
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
+
+func (c *retryableClient) CreateSchedule(
+	ctx context.Context,
+	request *shared.CreateScheduleRequest,
+	opts ...yarpc.CallOption,
+) error {
+
+	op := func() error {
+		return c.client.CreateSchedule(ctx, request, opts...)
+	}
+	return backoff.Retry(op, c.policy, c.isRetryable)
+}
+
+func (c *retryableClient) DescribeSchedule(
+	ctx context.Context,
+	request *shared.DescribeScheduleRequest,
+	opts ...yarpc.CallOption,
+) (*shared.DescribeScheduleResponse, error) {
+
+	var resp *shared.DescribeScheduleResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.DescribeSchedule(ctx, request, opts...)
+		return err
+	}
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
+
+func (c *retryableClient) DeleteSchedule(
+	ctx context.Context,
+	request *shared.DeleteScheduleRequest,
+	opts ...yarpc.CallOption,
+) error {
+
+	op := func() error {
+		return c.client.DeleteSchedule(ctx, request, opts...)
+	}
+	return backoff.Retry(op, c.policy, c.isRetryable)
+}
+
+func (c *retryableClient) ListSchedules(
+	ctx context.Context,
+	request *shared.ListSchedulesRequest,
+	opts ...yarpc.CallOption,
+) (*shared.ListSchedulesResponse, error) {
+
+	var resp *shared.ListSchedulesResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.ListSchedules(ctx, request, opts...)
+		return err
+	}
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
+
+func (c *retryableClient) SetSchedulePaused(
+	ctx context.Context,
+	request *shared.SetSchedulePausedRequest,
+	opts ...yarpc.CallOption,
+) error {
+
+	op := func() error {
+		return c.client.SetSchedulePaused(ctx, request, opts...)
+	}
+	return backoff.Retry(op, c.policy, c.isRetryable)
+}
+
+func (c *retryableClient) BackfillSchedule(
+	ctx context.Context,
+	request *shared.BackfillScheduleRequest,
+	opts ...yarpc.CallOption,
+) error {
+
+	op := func() error {
+		return c.client.BackfillSchedule(ctx, request, opts...)
+	}
+	return backoff.Retry(op, c.policy, c.isRetryable)
+}
+
+func (c *retryableClient) DrainHistoryHost(
+	ctx context.Context,
+	request *shared.DrainHistoryHostRequest,
+	opts ...yarpc.CallOption,
+) (*shared.DrainHistoryHostResponse, error) {
+
+	var resp *shared.DrainHistoryHostResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.DrainHistoryHost(ctx, request, opts...)
+		return err
+	}
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
+
+func (c *retryableClient) UndrainHistoryHost(
+	ctx context.Context,
+	request *shared.UndrainHistoryHostRequest,
+	opts ...yarpc.CallOption,
+) (*shared.UndrainHistoryHostResponse, error) {
+
+	var resp *shared.UndrainHistoryHostResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.UndrainHistoryHost(ctx, request, opts...)
+		return err
+	}
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
+
+func (c *retryableClient) DescribeClientVersions(
+	ctx context.Context,
+	request *shared.DescribeClientVersionsRequest,
+	opts ...yarpc.CallOption,
+) (*shared.DescribeClientVersionsResponse, error) {
+
+	var resp *shared.DescribeClientVersionsResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.DescribeClientVersions(ctx, request, opts...)
+		return err
+	}
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
+
+func (c *retryableClient) GetDomainOpenExecutionCount(
+	ctx context.Context,
+	request *shared.GetDomainOpenExecutionCountRequest,
+	opts ...yarpc.CallOption,
+) (*shared.GetDomainOpenExecutionCountResponse, error) {
+
+	var resp *shared.GetDomainOpenExecutionCountResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.GetDomainOpenExecutionCount(ctx, request, opts...)
+		return err
+	}
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
+
+func (c *retryableClient) UpdateTaskListVersionSets(
+	ctx context.Context,
+	request *shared.UpdateTaskListVersionSetsRequest,
+	opts ...yarpc.CallOption,
+) error {
+
+	op := func() error {
+		return c.client.UpdateTaskListVersionSets(ctx, request, opts...)
+	}
+	return backoff.Retry(op, c.policy, c.isRetryable)
+}
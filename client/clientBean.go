@@ -60,6 +60,13 @@ type (
 		Get(name string, address string) (*yarpc.Dispatcher, error)
 	}
 
+	// StoppableDispatcherProvider is a dispatcher provider running background work for the dispatchers it vends,
+	// Stop stops that work and the dispatchers
+	StoppableDispatcherProvider interface {
+		DispatcherProvider
+		Stop()
+	}
+
 	clientBeanImpl struct {
 		historyClient         history.Client
 		matchingClient        matching.Client
@@ -0,0 +1,425 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/uber/cadence/.gen/go/health/metaclient"
+	"github.com/uber/cadence/common/log"
+	"github.com/uber/cadence/common/log/tag"
+	"github.com/uber/cadence/common/metrics"
+	"go.uber.org/yarpc"
+	"go.uber.org/yarpc/api/peer"
+	"go.uber.org/yarpc/api/transport"
+	"go.uber.org/yarpc/peer/roundrobin"
+	"go.uber.org/yarpc/transport/tchannel"
+)
+
+const (
+	defaultHealthCheckInterval = time.Second * 5
+	defaultHealthCheckTimeout  = time.Second
+	defaultUnhealthyThreshold  = 3
+)
+
+type (
+	// ClusterConnectionOptions are the options of the connections to the remote frontends
+	ClusterConnectionOptions struct {
+		// RefreshInterval is the interval at which the address of the remote frontends is resolved
+		RefreshInterval time.Duration
+		// HealthCheckInterval is the interval at which the remote frontends are health checked
+		HealthCheckInterval time.Duration
+		// HealthCheckTimeout is the timeout of a single health check
+		HealthCheckTimeout time.Duration
+		// UnhealthyThreshold is the number of consecutive failed health checks after which
+		// a remote frontend stops receiving requests, until it passes a health check again
+		UnhealthyThreshold int
+	}
+
+	healthCheckedDispatcherProvider struct {
+		options       ClusterConnectionOptions
+		metricsClient metrics.Client
+		logger        log.Logger
+
+		sync.Mutex
+		connections []*clusterConnection
+		dispatchers []*yarpc.Dispatcher
+	}
+
+	// clusterConnection keeps the peers of an outbound to the healthy hosts resolved from its address,
+	// the requests fail over among them through the round robin peer list
+	clusterConnection struct {
+		address          string
+		options          ClusterConnectionOptions
+		resolve          func() ([]string, error)
+		newHealthChecker func(host string) (hostHealthChecker, error)
+		peerList         peerListUpdater
+		metricsScope     metrics.Scope
+		logger           log.Logger
+
+		hosts        map[string]*remoteHost
+		currentPeers map[string]struct{}
+		lastResolve  time.Time
+
+		shutdownCh chan struct{}
+		shutdownWG sync.WaitGroup
+	}
+
+	remoteHost struct {
+		checker  hostHealthChecker
+		failures int
+	}
+
+	hostHealthChecker interface {
+		Health(ctx context.Context) error
+		Stop()
+	}
+
+	peerListUpdater interface {
+		Update(updates peer.ListUpdates) error
+	}
+
+	metaHealthChecker struct {
+		outbound *tchannel.Outbound
+		client   metaclient.Interface
+	}
+)
+
+var _ StoppableDispatcherProvider = (*healthCheckedDispatcherProvider)(nil)
+
+// NewHealthCheckedDispatcherProvider creates a dispatcher provider which resolves the address to the hosts behind it
+// and only sends the requests to the ones passing their health checks
+func NewHealthCheckedDispatcherProvider(
+	options ClusterConnectionOptions,
+	metricsClient metrics.Client,
+	logger log.Logger,
+) DispatcherProvider {
+	if options.RefreshInterval <= 0 {
+		options.RefreshInterval = defaultRefreshInterval
+	}
+	if options.HealthCheckInterval <= 0 {
+		options.HealthCheckInterval = defaultHealthCheckInterval
+	}
+	if options.HealthCheckTimeout <= 0 {
+		options.HealthCheckTimeout = defaultHealthCheckTimeout
+	}
+	if options.UnhealthyThreshold <= 0 {
+		options.UnhealthyThreshold = defaultUnhealthyThreshold
+	}
+	return &healthCheckedDispatcherProvider{
+		options:       options,
+		metricsClient: metricsClient,
+		logger:        logger,
+	}
+}
+
+func (p *healthCheckedDispatcherProvider) Get(serviceName string, address string) (*yarpc.Dispatcher, error) {
+	host, port, err := net.SplitHostPort(address)
+	if err != nil {
+		return nil, fmt.Errorf("incorrect DNS:Port format")
+	}
+
+	tchanTransport, err := tchannel.NewTransport(
+		tchannel.ServiceName(serviceName),
+		// this aim to get rid of the annoying popup about accepting incoming network connections
+		tchannel.ListenAddr("127.0.0.1:0"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	peerList := roundrobin.New(tchanTransport)
+	outbound := tchanTransport.NewOutbound(peerList)
+
+	p.logger.Info("Creating health checked RPC dispatcher outbound", tag.Service(serviceName), tag.Address(address))
+
+	dispatcher := yarpc.NewDispatcher(yarpc.Config{
+		Name: crossDCCaller,
+		Outbounds: yarpc.Outbounds{
+			serviceName: transport.Outbounds{
+				Unary:       outbound,
+				ServiceName: serviceName,
+			},
+		},
+	})
+	if err := dispatcher.Start(); err != nil {
+		return nil, err
+	}
+
+	connection := newClusterConnection(
+		address,
+		p.options,
+		func() ([]string, error) {
+			return resolveHosts(host, port)
+		},
+		func(hostPort string) (hostHealthChecker, error) {
+			return newMetaHealthChecker(tchanTransport, serviceName, hostPort)
+		},
+		peerList,
+		p.metricsClient.Scope(metrics.ClusterConnectionScope, metrics.TargetAddressTag(address)),
+		p.logger.WithTags(tag.Service(serviceName), tag.Address(address)),
+	)
+	// the resolved hosts receive requests right away, until they fail their health checks
+	connection.refresh()
+	connection.Start()
+
+	p.Lock()
+	defer p.Unlock()
+	p.connections = append(p.connections, connection)
+	p.dispatchers = append(p.dispatchers, dispatcher)
+	return dispatcher, nil
+}
+
+// Stop stops the health checks of the dispatchers vended by the provider and the dispatchers
+func (p *healthCheckedDispatcherProvider) Stop() {
+	p.Lock()
+	defer p.Unlock()
+	for _, connection := range p.connections {
+		connection.Stop()
+	}
+	for _, dispatcher := range p.dispatchers {
+		if err := dispatcher.Stop(); err != nil {
+			p.logger.Warn("Failed to stop health checked RPC dispatcher", tag.Error(err))
+		}
+	}
+	p.connections = nil
+	p.dispatchers = nil
+}
+
+func newClusterConnection(
+	address string,
+	options ClusterConnectionOptions,
+	resolve func() ([]string, error),
+	newHealthChecker func(host string) (hostHealthChecker, error),
+	peerList peerListUpdater,
+	metricsScope metrics.Scope,
+	logger log.Logger,
+) *clusterConnection {
+	return &clusterConnection{
+		address:          address,
+		options:          options,
+		resolve:          resolve,
+		newHealthChecker: newHealthChecker,
+		peerList:         peerList,
+		metricsScope:     metricsScope,
+		logger:           logger,
+		hosts:            make(map[string]*remoteHost),
+		currentPeers:     make(map[string]struct{}),
+		shutdownCh:       make(chan struct{}),
+	}
+}
+
+func (c *clusterConnection) Start() {
+	c.shutdownWG.Add(1)
+	go c.healthCheckLoop()
+}
+
+// Stop stops the health checks and closes the outbounds of the health checkers
+func (c *clusterConnection) Stop() {
+	close(c.shutdownCh)
+	c.shutdownWG.Wait()
+	for host, remote := range c.hosts {
+		remote.checker.Stop()
+		delete(c.hosts, host)
+	}
+}
+
+func (c *clusterConnection) healthCheckLoop() {
+	defer c.shutdownWG.Done()
+
+	ticker := time.NewTicker(c.options.HealthCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-c.shutdownCh:
+			return
+		case <-ticker.C:
+			c.checkHosts()
+			c.refresh()
+		}
+	}
+}
+
+// refresh resolves the hosts once the refresh interval elapsed and updates the peers to the healthy hosts
+func (c *clusterConnection) refresh() {
+	if now := time.Now(); now.Sub(c.lastResolve) >= c.options.RefreshInterval {
+		c.lastResolve = now
+		hosts, err := c.resolve()
+		if err != nil {
+			c.logger.Error("Failed to update DNS", tag.Error(err))
+		} else {
+			c.updateHosts(hosts)
+		}
+	}
+	c.updatePeers()
+}
+
+func (c *clusterConnection) updateHosts(hosts []string) {
+	resolved := make(map[string]struct{}, len(hosts))
+	for _, host := range hosts {
+		resolved[host] = struct{}{}
+		if _, ok := c.hosts[host]; ok {
+			continue
+		}
+		checker, err := c.newHealthChecker(host)
+		if err != nil {
+			c.logger.Error("Failed to create remote host health checker", tag.Error(err), tag.Address(host))
+			continue
+		}
+		c.hosts[host] = &remoteHost{checker: checker}
+	}
+	for host, remote := range c.hosts {
+		if _, ok := resolved[host]; !ok {
+			remote.checker.Stop()
+			delete(c.hosts, host)
+		}
+	}
+}
+
+func (c *clusterConnection) checkHosts() {
+	var wg sync.WaitGroup
+	var lock sync.Mutex
+	errs := make(map[string]error, len(c.hosts))
+	for host, remote := range c.hosts {
+		wg.Add(1)
+		go func(host string, checker hostHealthChecker) {
+			defer wg.Done()
+			ctx, cancel := context.WithTimeout(context.Background(), c.options.HealthCheckTimeout)
+			defer cancel()
+			err := checker.Health(ctx)
+			lock.Lock()
+			defer lock.Unlock()
+			errs[host] = err
+		}(host, remote.checker)
+	}
+	wg.Wait()
+
+	for host, err := range errs {
+		remote := c.hosts[host]
+		if err == nil {
+			if remote.failures >= c.options.UnhealthyThreshold {
+				c.logger.Info("Remote host passed health check, restoring it", tag.Address(host))
+			}
+			remote.failures = 0
+			continue
+		}
+		c.metricsScope.IncCounter(metrics.ClusterConnectionHealthCheckFailures)
+		remote.failures++
+		if remote.failures == c.options.UnhealthyThreshold {
+			c.metricsScope.IncCounter(metrics.ClusterConnectionHostsEjected)
+			c.logger.Warn("Remote host failed health checks, ejecting it", tag.Error(err), tag.Address(host))
+		}
+	}
+}
+
+func (c *clusterConnection) updatePeers() {
+	peers := make(map[string]struct{}, len(c.hosts))
+	for host, remote := range c.hosts {
+		if remote.failures < c.options.UnhealthyThreshold {
+			peers[host] = struct{}{}
+		}
+	}
+	c.metricsScope.UpdateGauge(metrics.ClusterConnectionHealthyHosts, float64(len(peers)))
+	if len(peers) == 0 {
+		// with no healthy host left the requests are still sent to every host rather than failing right away
+		for host := range c.hosts {
+			peers[host] = struct{}{}
+		}
+	}
+
+	updates := peer.ListUpdates{
+		Additions: make([]peer.Identifier, 0),
+		Removals:  make([]peer.Identifier, 0),
+	}
+	for addr := range c.currentPeers {
+		if _, ok := peers[addr]; !ok {
+			updates.Removals = append(updates.Removals, aPeer{addrPort: addr})
+		}
+	}
+	for addr := range peers {
+		if _, ok := c.currentPeers[addr]; !ok {
+			updates.Additions = append(updates.Additions, aPeer{addrPort: addr})
+		}
+	}
+	if len(updates.Additions) == 0 && len(updates.Removals) == 0 {
+		return
+	}
+
+	if len(updates.Additions) > 0 {
+		c.logger.Info("Add remote peers", tag.Addresses(identifiersToStringList(updates.Additions)))
+	}
+	if len(updates.Removals) > 0 {
+		c.logger.Info("Remove remote peers", tag.Addresses(identifiersToStringList(updates.Removals)))
+	}
+	if err := c.peerList.Update(updates); err != nil {
+		c.logger.Error("Failed to update peerList", tag.Error(err))
+		return
+	}
+	c.currentPeers = peers
+}
+
+func resolveHosts(dnsAddress string, port string) ([]string, error) {
+	ips, err := net.DefaultResolver.LookupHost(context.Background(), dnsAddress)
+	if err != nil {
+		return nil, err
+	}
+	hosts := make([]string, 0, len(ips))
+	for _, ip := range ips {
+		hosts = append(hosts, net.JoinHostPort(ip, port))
+	}
+	return hosts, nil
+}
+
+func newMetaHealthChecker(tchanTransport *tchannel.Transport, serviceName string, host string) (*metaHealthChecker, error) {
+	outbound := tchanTransport.NewSingleOutbound(host)
+	if err := outbound.Start(); err != nil {
+		return nil, err
+	}
+	return &metaHealthChecker{
+		outbound: outbound,
+		client: metaclient.New(&transport.OutboundConfig{
+			CallerName: crossDCCaller,
+			Outbounds: transport.Outbounds{
+				ServiceName: serviceName,
+				Unary:       outbound,
+			},
+		}),
+	}, nil
+}
+
+func (c *metaHealthChecker) Health(ctx context.Context) error {
+	status, err := c.client.Health(ctx)
+	if err != nil {
+		return err
+	}
+	if !status.GetOk() {
+		return fmt.Errorf("remote host is not healthy: %v", status.GetMsg())
+	}
+	return nil
+}
+
+func (c *metaHealthChecker) Stop() {
+	c.outbound.Stop()
+}
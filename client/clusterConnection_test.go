@@ -0,0 +1,159 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package client
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"github.com/uber-go/tally"
+	"github.com/uber/cadence/common/log/loggerimpl"
+	"github.com/uber/cadence/common/metrics"
+	"go.uber.org/yarpc/api/peer"
+)
+
+type (
+	clusterConnectionSuite struct {
+		suite.Suite
+		*require.Assertions
+
+		hosts      []string
+		unhealthy  map[string]bool
+		stopped    []string
+		peers      map[string]struct{}
+		connection *clusterConnection
+	}
+
+	testHealthChecker struct {
+		host  string
+		suite *clusterConnectionSuite
+	}
+)
+
+func TestClusterConnectionSuite(t *testing.T) {
+	suite.Run(t, new(clusterConnectionSuite))
+}
+
+func (s *clusterConnectionSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+	s.hosts = []string{"10.0.0.1:7933", "10.0.0.2:7933"}
+	s.unhealthy = make(map[string]bool)
+	s.stopped = nil
+	s.peers = make(map[string]struct{})
+	s.connection = newClusterConnection(
+		"frontend:7933",
+		ClusterConnectionOptions{UnhealthyThreshold: 2},
+		func() ([]string, error) {
+			return s.hosts, nil
+		},
+		func(host string) (hostHealthChecker, error) {
+			return &testHealthChecker{host: host, suite: s}, nil
+		},
+		s,
+		metrics.NewClient(tally.NoopScope, metrics.Common).Scope(metrics.ClusterConnectionScope),
+		loggerimpl.NewNopLogger(),
+	)
+}
+
+func (s *clusterConnectionSuite) TestEjectAndRestoreUnhealthyHost() {
+	s.connection.refresh()
+	s.Equal([]string{"10.0.0.1:7933", "10.0.0.2:7933"}, s.currentPeers())
+
+	// the host keeps receiving requests until it reaches the unhealthy threshold
+	s.unhealthy["10.0.0.1:7933"] = true
+	s.connection.checkHosts()
+	s.connection.updatePeers()
+	s.Equal([]string{"10.0.0.1:7933", "10.0.0.2:7933"}, s.currentPeers())
+	s.connection.checkHosts()
+	s.connection.updatePeers()
+	s.Equal([]string{"10.0.0.2:7933"}, s.currentPeers())
+
+	s.unhealthy["10.0.0.1:7933"] = false
+	s.connection.checkHosts()
+	s.connection.updatePeers()
+	s.Equal([]string{"10.0.0.1:7933", "10.0.0.2:7933"}, s.currentPeers())
+}
+
+func (s *clusterConnectionSuite) TestAllHostsUnhealthy() {
+	s.connection.refresh()
+	s.unhealthy["10.0.0.1:7933"] = true
+	s.unhealthy["10.0.0.2:7933"] = true
+	for i := 0; i < 2; i++ {
+		s.connection.checkHosts()
+		s.connection.updatePeers()
+	}
+	// the requests are not failed right away when no host is healthy
+	s.Equal([]string{"10.0.0.1:7933", "10.0.0.2:7933"}, s.currentPeers())
+}
+
+func (s *clusterConnectionSuite) TestResolvedHostsChange() {
+	s.connection.refresh()
+	s.hosts = []string{"10.0.0.2:7933", "10.0.0.3:7933"}
+	s.connection.lastResolve = time.Time{}
+	s.connection.refresh()
+	s.Equal([]string{"10.0.0.2:7933", "10.0.0.3:7933"}, s.currentPeers())
+	s.Equal([]string{"10.0.0.1:7933"}, s.stopped)
+}
+
+func (s *clusterConnectionSuite) TestStop() {
+	s.connection.options.HealthCheckInterval = time.Hour
+	s.connection.refresh()
+	s.connection.Start()
+	s.connection.Stop()
+	sort.Strings(s.stopped)
+	s.Equal([]string{"10.0.0.1:7933", "10.0.0.2:7933"}, s.stopped)
+	s.Empty(s.connection.hosts)
+}
+
+func (s *clusterConnectionSuite) Update(updates peer.ListUpdates) error {
+	for _, removal := range updates.Removals {
+		delete(s.peers, removal.Identifier())
+	}
+	for _, addition := range updates.Additions {
+		s.peers[addition.Identifier()] = struct{}{}
+	}
+	return nil
+}
+
+func (s *clusterConnectionSuite) currentPeers() []string {
+	peers := make([]string, 0, len(s.peers))
+	for addr := range s.peers {
+		peers = append(peers, addr)
+	}
+	sort.Strings(peers)
+	return peers
+}
+
+func (c *testHealthChecker) Health(ctx context.Context) error {
+	if c.suite.unhealthy[c.host] {
+		return errors.New("remote host unavailable")
+	}
+	return nil
+}
+
+func (c *testHealthChecker) Stop() {
+	c.suite.stopped = append(c.suite.stopped, c.host)
+}
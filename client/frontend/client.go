@@ -124,6 +124,22 @@ func (c *clientImpl) DescribeWorkflowExecution(
 	return client.DescribeWorkflowExecution(ctx, request, opts...)
 }
 
+func (c *clientImpl) GetTaskListScalingHints(
+	ctx context.Context,
+	request *shared.GetTaskListScalingHintsRequest,
+	opts ...yarpc.CallOption,
+) (*shared.GetTaskListScalingHintsResponse, error) {
+
+	opts = common.AggregateYarpcOptions(ctx, opts...)
+	client, err := c.getRandomClient()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := c.createContext(ctx)
+	defer cancel()
+	return client.GetTaskListScalingHints(ctx, request, opts...)
+}
+
 func (c *clientImpl) GetWorkflowExecutionHistory(
 	ctx context.Context,
 	request *shared.GetWorkflowExecutionHistoryRequest,
@@ -619,6 +635,22 @@ func (c *clientImpl) UpdateDomain(
 	return client.UpdateDomain(ctx, request, opts...)
 }
 
+func (c *clientImpl) UpdateWorkflowExecution(
+	ctx context.Context,
+	request *shared.UpdateWorkflowExecutionRequest,
+	opts ...yarpc.CallOption,
+) (*shared.UpdateWorkflowExecutionResponse, error) {
+
+	opts = common.AggregateYarpcOptions(ctx, opts...)
+	client, err := c.getRandomClient()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := c.createLongPollContext(ctx)
+	defer cancel()
+	return client.UpdateWorkflowExecution(ctx, request, opts...)
+}
+
 func (c *clientImpl) createContext(parent context.Context) (context.Context, context.CancelFunc) {
 	if parent == nil {
 		return context.WithTimeout(context.Background(), c.timeout)
@@ -114,6 +114,24 @@ func (c *metricClient) DescribeWorkflowExecution(
 	return resp, err
 }
 
+func (c *metricClient) GetTaskListScalingHints(
+	ctx context.Context,
+	request *shared.GetTaskListScalingHintsRequest,
+	opts ...yarpc.CallOption,
+) (*shared.GetTaskListScalingHintsResponse, error) {
+
+	c.metricsClient.IncCounter(metrics.FrontendClientGetTaskListScalingHintsScope, metrics.CadenceClientRequests)
+
+	sw := c.metricsClient.StartTimer(metrics.FrontendClientGetTaskListScalingHintsScope, metrics.CadenceClientLatency)
+	resp, err := c.client.GetTaskListScalingHints(ctx, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.FrontendClientGetTaskListScalingHintsScope, metrics.CadenceClientFailures)
+	}
+	return resp, err
+}
+
 func (c *metricClient) GetWorkflowExecutionHistory(
 	ctx context.Context,
 	request *shared.GetWorkflowExecutionHistoryRequest,
@@ -670,3 +688,21 @@ func (c *metricClient) UpdateDomain(
 	}
 	return resp, err
 }
+
+func (c *metricClient) UpdateWorkflowExecution(
+	ctx context.Context,
+	request *shared.UpdateWorkflowExecutionRequest,
+	opts ...yarpc.CallOption,
+) (*shared.UpdateWorkflowExecutionResponse, error) {
+
+	c.metricsClient.IncCounter(metrics.FrontendClientUpdateWorkflowExecutionScope, metrics.CadenceClientRequests)
+
+	sw := c.metricsClient.StartTimer(metrics.FrontendClientUpdateWorkflowExecutionScope, metrics.CadenceClientLatency)
+	resp, err := c.client.UpdateWorkflowExecution(ctx, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.FrontendClientUpdateWorkflowExecutionScope, metrics.CadenceClientFailures)
+	}
+	return resp, err
+}
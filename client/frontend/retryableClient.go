@@ -105,6 +105,22 @@ func (c *retryableClient) DescribeWorkflowExecution(
 	return resp, err
 }
 
+func (c *retryableClient) GetTaskListScalingHints(
+	ctx context.Context,
+	request *shared.GetTaskListScalingHintsRequest,
+	opts ...yarpc.CallOption,
+) (*shared.GetTaskListScalingHintsResponse, error) {
+
+	var resp *shared.GetTaskListScalingHintsResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.GetTaskListScalingHints(ctx, request, opts...)
+		return err
+	}
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
+
 func (c *retryableClient) GetWorkflowExecutionHistory(
 	ctx context.Context,
 	request *shared.GetWorkflowExecutionHistoryRequest,
@@ -551,3 +567,19 @@ func (c *retryableClient) UpdateDomain(
 	err := backoff.Retry(op, c.policy, c.isRetryable)
 	return resp, err
 }
+
+func (c *retryableClient) UpdateWorkflowExecution(
+	ctx context.Context,
+	request *shared.UpdateWorkflowExecutionRequest,
+	opts ...yarpc.CallOption,
+) (*shared.UpdateWorkflowExecutionResponse, error) {
+
+	var resp *shared.UpdateWorkflowExecutionResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.UpdateWorkflowExecution(ctx, request, opts...)
+		return err
+	}
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
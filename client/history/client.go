@@ -107,6 +107,47 @@ func (c *clientImpl) GetMutableState(
 	return response, nil
 }
 
+func (c *clientImpl) ImportWorkflowExecution(
+	ctx context.Context,
+	request *h.ImportWorkflowExecutionRequest,
+	opts ...yarpc.CallOption) (*h.ImportWorkflowExecutionResponse, error) {
+	client, err := c.getClientForWorkflowID(request.GetWorkflowId())
+	if err != nil {
+		return nil, err
+	}
+	opts = common.AggregateYarpcOptions(ctx, opts...)
+	var response *h.ImportWorkflowExecutionResponse
+	op := func(ctx context.Context, client historyserviceclient.Interface) error {
+		var err error
+		ctx, cancel := c.createContext(ctx)
+		defer cancel()
+		response, err = client.ImportWorkflowExecution(ctx, request, opts...)
+		return err
+	}
+	err = c.executeWithRedirect(ctx, client, op)
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+func (c *clientImpl) PurgeWorkflowExecution(
+	ctx context.Context,
+	request *h.PurgeWorkflowExecutionRequest,
+	opts ...yarpc.CallOption) error {
+	client, err := c.getClientForWorkflowID(request.WorkflowExecution.GetWorkflowId())
+	if err != nil {
+		return err
+	}
+	opts = common.AggregateYarpcOptions(ctx, opts...)
+	op := func(ctx context.Context, client historyserviceclient.Interface) error {
+		ctx, cancel := c.createContext(ctx)
+		defer cancel()
+		return client.PurgeWorkflowExecution(ctx, request, opts...)
+	}
+	return c.executeWithRedirect(ctx, client, op)
+}
+
 func (c *clientImpl) DescribeHistoryHost(
 	ctx context.Context,
 	request *workflow.DescribeHistoryHostRequest,
@@ -146,6 +187,92 @@ func (c *clientImpl) DescribeHistoryHost(
 	return response, nil
 }
 
+func (c *clientImpl) DrainHistoryHost(
+	ctx context.Context,
+	request *workflow.DrainHistoryHostRequest,
+	opts ...yarpc.CallOption) (*workflow.DrainHistoryHostResponse, error) {
+
+	ret, err := c.clients.GetClientForClientKey(request.GetHostAddress())
+	if err != nil {
+		return nil, err
+	}
+	client := ret.(historyserviceclient.Interface)
+
+	opts = common.AggregateYarpcOptions(ctx, opts...)
+	ctx, cancel := c.createContext(ctx)
+	defer cancel()
+	return client.DrainHistoryHost(ctx, request, opts...)
+}
+
+func (c *clientImpl) UndrainHistoryHost(
+	ctx context.Context,
+	request *workflow.UndrainHistoryHostRequest,
+	opts ...yarpc.CallOption) (*workflow.UndrainHistoryHostResponse, error) {
+
+	ret, err := c.clients.GetClientForClientKey(request.GetHostAddress())
+	if err != nil {
+		return nil, err
+	}
+	client := ret.(historyserviceclient.Interface)
+
+	opts = common.AggregateYarpcOptions(ctx, opts...)
+	ctx, cancel := c.createContext(ctx)
+	defer cancel()
+	return client.UndrainHistoryHost(ctx, request, opts...)
+}
+
+func (c *clientImpl) DescribeClientVersions(
+	ctx context.Context,
+	request *workflow.DescribeClientVersionsRequest,
+	opts ...yarpc.CallOption) (*workflow.DescribeClientVersionsResponse, error) {
+
+	ret, err := c.clients.GetClientForClientKey(request.GetHostAddress())
+	if err != nil {
+		return nil, err
+	}
+	client := ret.(historyserviceclient.Interface)
+
+	opts = common.AggregateYarpcOptions(ctx, opts...)
+	ctx, cancel := c.createContext(ctx)
+	defer cancel()
+	return client.DescribeClientVersions(ctx, request, opts...)
+}
+
+func (c *clientImpl) GetDomainOpenExecutionCount(
+	ctx context.Context,
+	request *workflow.GetDomainOpenExecutionCountRequest,
+	opts ...yarpc.CallOption) (*workflow.GetDomainOpenExecutionCountResponse, error) {
+
+	ret, err := c.clients.GetClientForClientKey(request.GetHostAddress())
+	if err != nil {
+		return nil, err
+	}
+	client := ret.(historyserviceclient.Interface)
+
+	opts = common.AggregateYarpcOptions(ctx, opts...)
+	ctx, cancel := c.createContext(ctx)
+	defer cancel()
+	return client.GetDomainOpenExecutionCount(ctx, request, opts...)
+}
+
+func (c *clientImpl) ReconcileDomainOpenExecutionCounts(
+	ctx context.Context,
+	request *h.ReconcileDomainOpenExecutionCountsRequest,
+	opts ...yarpc.CallOption) error {
+	client, err := c.getClientForShardID(int(request.GetShardID()))
+	if err != nil {
+		return err
+	}
+	op := func(ctx context.Context, client historyserviceclient.Interface) error {
+		ctx, cancel := c.createContext(ctx)
+		defer cancel()
+		return client.ReconcileDomainOpenExecutionCounts(ctx, request)
+	}
+	err = c.executeWithRedirect(ctx, client, op)
+
+	return err
+}
+
 func (c *clientImpl) DescribeMutableState(
 	ctx context.Context,
 	request *h.DescribeMutableStateRequest,
@@ -467,6 +594,31 @@ func (c *clientImpl) SignalWithStartWorkflowExecution(
 	return response, err
 }
 
+func (c *clientImpl) UpdateWorkflowExecution(
+	ctx context.Context,
+	request *h.UpdateWorkflowExecutionRequest,
+	opts ...yarpc.CallOption) (*workflow.UpdateWorkflowExecutionResponse, error) {
+	client, err := c.getClientForWorkflowID(request.UpdateRequest.WorkflowExecution.GetWorkflowId())
+	if err != nil {
+		return nil, err
+	}
+	opts = common.AggregateYarpcOptions(ctx, opts...)
+	var response *workflow.UpdateWorkflowExecutionResponse
+	op := func(ctx context.Context, client historyserviceclient.Interface) error {
+		var err error
+		ctx, cancel := c.createContext(ctx)
+		defer cancel()
+		response, err = client.UpdateWorkflowExecution(ctx, request, opts...)
+		return err
+	}
+	err = c.executeWithRedirect(ctx, client, op)
+	if err != nil {
+		return nil, err
+	}
+
+	return response, err
+}
+
 func (c *clientImpl) RemoveSignalMutableState(
 	ctx context.Context,
 	request *h.RemoveSignalMutableStateRequest,
@@ -485,6 +637,101 @@ func (c *clientImpl) RemoveSignalMutableState(
 	return err
 }
 
+func (c *clientImpl) SetActivityPaused(
+	ctx context.Context,
+	request *h.SetActivityPausedRequest,
+	opts ...yarpc.CallOption) error {
+	client, err := c.getClientForWorkflowID(*request.PauseRequest.Execution.WorkflowId)
+	if err != nil {
+		return err
+	}
+	op := func(ctx context.Context, client historyserviceclient.Interface) error {
+		ctx, cancel := c.createContext(ctx)
+		defer cancel()
+		return client.SetActivityPaused(ctx, request)
+	}
+	err = c.executeWithRedirect(ctx, client, op)
+
+	return err
+}
+
+func (c *clientImpl) SetWorkflowExecutionNotes(
+	ctx context.Context,
+	request *h.SetWorkflowExecutionNotesRequest,
+	opts ...yarpc.CallOption) error {
+	client, err := c.getClientForWorkflowID(*request.NotesRequest.Execution.WorkflowId)
+	if err != nil {
+		return err
+	}
+	op := func(ctx context.Context, client historyserviceclient.Interface) error {
+		ctx, cancel := c.createContext(ctx)
+		defer cancel()
+		return client.SetWorkflowExecutionNotes(ctx, request)
+	}
+	err = c.executeWithRedirect(ctx, client, op)
+
+	return err
+}
+
+func (c *clientImpl) SetWorkflowExecutionPaused(
+	ctx context.Context,
+	request *h.SetWorkflowExecutionPausedRequest,
+	opts ...yarpc.CallOption) error {
+	client, err := c.getClientForWorkflowID(*request.PauseRequest.Execution.WorkflowId)
+	if err != nil {
+		return err
+	}
+	op := func(ctx context.Context, client historyserviceclient.Interface) error {
+		ctx, cancel := c.createContext(ctx)
+		defer cancel()
+		return client.SetWorkflowExecutionPaused(ctx, request)
+	}
+	err = c.executeWithRedirect(ctx, client, op)
+
+	return err
+}
+
+func (c *clientImpl) SetCronSchedulePaused(
+	ctx context.Context,
+	request *h.SetCronSchedulePausedRequest,
+	opts ...yarpc.CallOption) error {
+	client, err := c.getClientForWorkflowID(*request.PauseRequest.Execution.WorkflowId)
+	if err != nil {
+		return err
+	}
+	op := func(ctx context.Context, client historyserviceclient.Interface) error {
+		ctx, cancel := c.createContext(ctx)
+		defer cancel()
+		return client.SetCronSchedulePaused(ctx, request)
+	}
+	err = c.executeWithRedirect(ctx, client, op)
+
+	return err
+}
+
+func (c *clientImpl) TerminateAllWorkflowRuns(
+	ctx context.Context,
+	request *h.TerminateAllWorkflowRunsRequest,
+	opts ...yarpc.CallOption) (*workflow.TerminateAllWorkflowRunsResponse, error) {
+	client, err := c.getClientForWorkflowID(request.TerminateRequest.GetWorkflowId())
+	if err != nil {
+		return nil, err
+	}
+	opts = common.AggregateYarpcOptions(ctx, opts...)
+	var response *workflow.TerminateAllWorkflowRunsResponse
+	op := func(ctx context.Context, client historyserviceclient.Interface) error {
+		ctx, cancel := c.createContext(ctx)
+		defer cancel()
+		response, err = client.TerminateAllWorkflowRuns(ctx, request, opts...)
+		return err
+	}
+	err = c.executeWithRedirect(ctx, client, op)
+	if err != nil {
+		return nil, err
+	}
+	return response, err
+}
+
 func (c *clientImpl) TerminateWorkflowExecution(
 	ctx context.Context,
 	request *h.TerminateWorkflowExecutionRequest,
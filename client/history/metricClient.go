@@ -70,6 +70,91 @@ func (c *metricClient) DescribeHistoryHost(
 	return resp, err
 }
 
+func (c *metricClient) DrainHistoryHost(
+	context context.Context,
+	request *shared.DrainHistoryHostRequest,
+	opts ...yarpc.CallOption) (*shared.DrainHistoryHostResponse, error) {
+	c.metricsClient.IncCounter(metrics.HistoryClientDrainHistoryHostScope, metrics.CadenceClientRequests)
+
+	sw := c.metricsClient.StartTimer(metrics.HistoryClientDrainHistoryHostScope, metrics.CadenceClientLatency)
+	resp, err := c.client.DrainHistoryHost(context, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.HistoryClientDrainHistoryHostScope, metrics.CadenceClientFailures)
+	}
+
+	return resp, err
+}
+
+func (c *metricClient) UndrainHistoryHost(
+	context context.Context,
+	request *shared.UndrainHistoryHostRequest,
+	opts ...yarpc.CallOption) (*shared.UndrainHistoryHostResponse, error) {
+	c.metricsClient.IncCounter(metrics.HistoryClientUndrainHistoryHostScope, metrics.CadenceClientRequests)
+
+	sw := c.metricsClient.StartTimer(metrics.HistoryClientUndrainHistoryHostScope, metrics.CadenceClientLatency)
+	resp, err := c.client.UndrainHistoryHost(context, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.HistoryClientUndrainHistoryHostScope, metrics.CadenceClientFailures)
+	}
+
+	return resp, err
+}
+
+func (c *metricClient) DescribeClientVersions(
+	context context.Context,
+	request *shared.DescribeClientVersionsRequest,
+	opts ...yarpc.CallOption) (*shared.DescribeClientVersionsResponse, error) {
+	c.metricsClient.IncCounter(metrics.HistoryClientDescribeClientVersionsScope, metrics.CadenceClientRequests)
+
+	sw := c.metricsClient.StartTimer(metrics.HistoryClientDescribeClientVersionsScope, metrics.CadenceClientLatency)
+	resp, err := c.client.DescribeClientVersions(context, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.HistoryClientDescribeClientVersionsScope, metrics.CadenceClientFailures)
+	}
+
+	return resp, err
+}
+
+func (c *metricClient) GetDomainOpenExecutionCount(
+	context context.Context,
+	request *shared.GetDomainOpenExecutionCountRequest,
+	opts ...yarpc.CallOption) (*shared.GetDomainOpenExecutionCountResponse, error) {
+	c.metricsClient.IncCounter(metrics.HistoryClientGetDomainOpenExecutionCountScope, metrics.CadenceClientRequests)
+
+	sw := c.metricsClient.StartTimer(metrics.HistoryClientGetDomainOpenExecutionCountScope, metrics.CadenceClientLatency)
+	resp, err := c.client.GetDomainOpenExecutionCount(context, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.HistoryClientGetDomainOpenExecutionCountScope, metrics.CadenceClientFailures)
+	}
+
+	return resp, err
+}
+
+func (c *metricClient) ReconcileDomainOpenExecutionCounts(
+	context context.Context,
+	request *h.ReconcileDomainOpenExecutionCountsRequest,
+	opts ...yarpc.CallOption) error {
+	c.metricsClient.IncCounter(metrics.HistoryClientReconcileDomainOpenExecutionCountsScope, metrics.CadenceClientRequests)
+
+	sw := c.metricsClient.StartTimer(metrics.HistoryClientReconcileDomainOpenExecutionCountsScope, metrics.CadenceClientLatency)
+	err := c.client.ReconcileDomainOpenExecutionCounts(context, request)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.HistoryClientReconcileDomainOpenExecutionCountsScope, metrics.CadenceClientFailures)
+	}
+
+	return err
+}
+
 func (c *metricClient) DescribeMutableState(
 	context context.Context,
 	request *h.DescribeMutableStateRequest,
@@ -96,6 +181,40 @@ func (c *metricClient) GetMutableState(
 	return resp, err
 }
 
+func (c *metricClient) ImportWorkflowExecution(
+	context context.Context,
+	request *h.ImportWorkflowExecutionRequest,
+	opts ...yarpc.CallOption) (*h.ImportWorkflowExecutionResponse, error) {
+	c.metricsClient.IncCounter(metrics.HistoryClientImportWorkflowExecutionScope, metrics.CadenceClientRequests)
+
+	sw := c.metricsClient.StartTimer(metrics.HistoryClientImportWorkflowExecutionScope, metrics.CadenceClientLatency)
+	resp, err := c.client.ImportWorkflowExecution(context, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.HistoryClientImportWorkflowExecutionScope, metrics.CadenceClientFailures)
+	}
+
+	return resp, err
+}
+
+func (c *metricClient) PurgeWorkflowExecution(
+	context context.Context,
+	request *h.PurgeWorkflowExecutionRequest,
+	opts ...yarpc.CallOption) error {
+	c.metricsClient.IncCounter(metrics.HistoryClientPurgeWorkflowExecutionScope, metrics.CadenceClientRequests)
+
+	sw := c.metricsClient.StartTimer(metrics.HistoryClientPurgeWorkflowExecutionScope, metrics.CadenceClientLatency)
+	err := c.client.PurgeWorkflowExecution(context, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.HistoryClientPurgeWorkflowExecutionScope, metrics.CadenceClientFailures)
+	}
+
+	return err
+}
+
 func (c *metricClient) ResetStickyTaskList(
 	context context.Context,
 	request *h.ResetStickyTaskListRequest,
@@ -317,6 +436,23 @@ func (c *metricClient) SignalWithStartWorkflowExecution(
 	return resp, err
 }
 
+func (c *metricClient) UpdateWorkflowExecution(
+	context context.Context,
+	request *h.UpdateWorkflowExecutionRequest,
+	opts ...yarpc.CallOption) (*shared.UpdateWorkflowExecutionResponse, error) {
+	c.metricsClient.IncCounter(metrics.HistoryClientUpdateWorkflowExecutionScope, metrics.CadenceClientRequests)
+
+	sw := c.metricsClient.StartTimer(metrics.HistoryClientUpdateWorkflowExecutionScope, metrics.CadenceClientLatency)
+	resp, err := c.client.UpdateWorkflowExecution(context, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.HistoryClientUpdateWorkflowExecutionScope, metrics.CadenceClientFailures)
+	}
+
+	return resp, err
+}
+
 func (c *metricClient) RemoveSignalMutableState(
 	context context.Context,
 	request *h.RemoveSignalMutableStateRequest,
@@ -334,6 +470,91 @@ func (c *metricClient) RemoveSignalMutableState(
 	return err
 }
 
+func (c *metricClient) SetActivityPaused(
+	context context.Context,
+	request *h.SetActivityPausedRequest,
+	opts ...yarpc.CallOption) error {
+	c.metricsClient.IncCounter(metrics.HistoryClientSetActivityPausedScope, metrics.CadenceClientRequests)
+
+	sw := c.metricsClient.StartTimer(metrics.HistoryClientSetActivityPausedScope, metrics.CadenceClientLatency)
+	err := c.client.SetActivityPaused(context, request)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.HistoryClientSetActivityPausedScope, metrics.CadenceClientFailures)
+	}
+
+	return err
+}
+
+func (c *metricClient) SetWorkflowExecutionNotes(
+	context context.Context,
+	request *h.SetWorkflowExecutionNotesRequest,
+	opts ...yarpc.CallOption) error {
+	c.metricsClient.IncCounter(metrics.HistoryClientSetWorkflowExecutionNotesScope, metrics.CadenceClientRequests)
+
+	sw := c.metricsClient.StartTimer(metrics.HistoryClientSetWorkflowExecutionNotesScope, metrics.CadenceClientLatency)
+	err := c.client.SetWorkflowExecutionNotes(context, request)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.HistoryClientSetWorkflowExecutionNotesScope, metrics.CadenceClientFailures)
+	}
+
+	return err
+}
+
+func (c *metricClient) SetWorkflowExecutionPaused(
+	context context.Context,
+	request *h.SetWorkflowExecutionPausedRequest,
+	opts ...yarpc.CallOption) error {
+	c.metricsClient.IncCounter(metrics.HistoryClientSetWorkflowExecutionPausedScope, metrics.CadenceClientRequests)
+
+	sw := c.metricsClient.StartTimer(metrics.HistoryClientSetWorkflowExecutionPausedScope, metrics.CadenceClientLatency)
+	err := c.client.SetWorkflowExecutionPaused(context, request)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.HistoryClientSetWorkflowExecutionPausedScope, metrics.CadenceClientFailures)
+	}
+
+	return err
+}
+
+func (c *metricClient) SetCronSchedulePaused(
+	context context.Context,
+	request *h.SetCronSchedulePausedRequest,
+	opts ...yarpc.CallOption) error {
+	c.metricsClient.IncCounter(metrics.HistoryClientSetCronSchedulePausedScope, metrics.CadenceClientRequests)
+
+	sw := c.metricsClient.StartTimer(metrics.HistoryClientSetCronSchedulePausedScope, metrics.CadenceClientLatency)
+	err := c.client.SetCronSchedulePaused(context, request)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.HistoryClientSetCronSchedulePausedScope, metrics.CadenceClientFailures)
+	}
+
+	return err
+}
+
+func (c *metricClient) TerminateAllWorkflowRuns(
+	context context.Context,
+	request *h.TerminateAllWorkflowRunsRequest,
+	opts ...yarpc.CallOption) (*shared.TerminateAllWorkflowRunsResponse, error) {
+	c.metricsClient.IncCounter(metrics.HistoryClientTerminateAllWorkflowRunsScope, metrics.CadenceClientRequests)
+
+	sw := c.metricsClient.StartTimer(metrics.HistoryClientTerminateAllWorkflowRunsScope, metrics.CadenceClientLatency)
+	resp, err := c.client.TerminateAllWorkflowRuns(context, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.HistoryClientTerminateAllWorkflowRunsScope, metrics.CadenceClientFailures)
+	}
+
+	return resp, err
+}
+
 func (c *metricClient) TerminateWorkflowExecution(
 	context context.Context,
 	request *h.TerminateWorkflowExecutionRequest,
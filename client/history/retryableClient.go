@@ -78,6 +78,82 @@ func (c *retryableClient) DescribeHistoryHost(
 	return resp, err
 }
 
+func (c *retryableClient) DrainHistoryHost(
+	ctx context.Context,
+	request *shared.DrainHistoryHostRequest,
+	opts ...yarpc.CallOption) (*shared.DrainHistoryHostResponse, error) {
+
+	var resp *shared.DrainHistoryHostResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.DrainHistoryHost(ctx, request, opts...)
+		return err
+	}
+
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
+
+func (c *retryableClient) UndrainHistoryHost(
+	ctx context.Context,
+	request *shared.UndrainHistoryHostRequest,
+	opts ...yarpc.CallOption) (*shared.UndrainHistoryHostResponse, error) {
+
+	var resp *shared.UndrainHistoryHostResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.UndrainHistoryHost(ctx, request, opts...)
+		return err
+	}
+
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
+
+func (c *retryableClient) DescribeClientVersions(
+	ctx context.Context,
+	request *shared.DescribeClientVersionsRequest,
+	opts ...yarpc.CallOption) (*shared.DescribeClientVersionsResponse, error) {
+
+	var resp *shared.DescribeClientVersionsResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.DescribeClientVersions(ctx, request, opts...)
+		return err
+	}
+
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
+
+func (c *retryableClient) GetDomainOpenExecutionCount(
+	ctx context.Context,
+	request *shared.GetDomainOpenExecutionCountRequest,
+	opts ...yarpc.CallOption) (*shared.GetDomainOpenExecutionCountResponse, error) {
+
+	var resp *shared.GetDomainOpenExecutionCountResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.GetDomainOpenExecutionCount(ctx, request, opts...)
+		return err
+	}
+
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
+
+func (c *retryableClient) ReconcileDomainOpenExecutionCounts(
+	ctx context.Context,
+	request *h.ReconcileDomainOpenExecutionCountsRequest,
+	opts ...yarpc.CallOption) error {
+
+	op := func() error {
+		return c.client.ReconcileDomainOpenExecutionCounts(ctx, request, opts...)
+	}
+
+	return backoff.Retry(op, c.policy, c.isRetryable)
+}
+
 func (c *retryableClient) DescribeMutableState(
 	ctx context.Context,
 	request *h.DescribeMutableStateRequest,
@@ -110,6 +186,27 @@ func (c *retryableClient) GetMutableState(
 	return resp, err
 }
 
+// ImportWorkflowExecution is not retried, every attempt of the import creates a new run of the workflow
+func (c *retryableClient) ImportWorkflowExecution(
+	ctx context.Context,
+	request *h.ImportWorkflowExecutionRequest,
+	opts ...yarpc.CallOption) (*h.ImportWorkflowExecutionResponse, error) {
+
+	return c.client.ImportWorkflowExecution(ctx, request, opts...)
+}
+
+func (c *retryableClient) PurgeWorkflowExecution(
+	ctx context.Context,
+	request *h.PurgeWorkflowExecutionRequest,
+	opts ...yarpc.CallOption) error {
+
+	op := func() error {
+		return c.client.PurgeWorkflowExecution(ctx, request, opts...)
+	}
+
+	return backoff.Retry(op, c.policy, c.isRetryable)
+}
+
 func (c *retryableClient) ResetStickyTaskList(
 	ctx context.Context,
 	request *h.ResetStickyTaskListRequest,
@@ -294,6 +391,22 @@ func (c *retryableClient) SignalWithStartWorkflowExecution(
 	return resp, err
 }
 
+func (c *retryableClient) UpdateWorkflowExecution(
+	ctx context.Context,
+	request *h.UpdateWorkflowExecutionRequest,
+	opts ...yarpc.CallOption) (*shared.UpdateWorkflowExecutionResponse, error) {
+
+	var resp *shared.UpdateWorkflowExecutionResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.UpdateWorkflowExecution(ctx, request, opts...)
+		return err
+	}
+
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
+
 func (c *retryableClient) RemoveSignalMutableState(
 	ctx context.Context,
 	request *h.RemoveSignalMutableStateRequest,
@@ -306,6 +419,70 @@ func (c *retryableClient) RemoveSignalMutableState(
 	return backoff.Retry(op, c.policy, c.isRetryable)
 }
 
+func (c *retryableClient) SetActivityPaused(
+	ctx context.Context,
+	request *h.SetActivityPausedRequest,
+	opts ...yarpc.CallOption) error {
+
+	op := func() error {
+		return c.client.SetActivityPaused(ctx, request, opts...)
+	}
+
+	return backoff.Retry(op, c.policy, c.isRetryable)
+}
+
+func (c *retryableClient) SetWorkflowExecutionNotes(
+	ctx context.Context,
+	request *h.SetWorkflowExecutionNotesRequest,
+	opts ...yarpc.CallOption) error {
+
+	op := func() error {
+		return c.client.SetWorkflowExecutionNotes(ctx, request, opts...)
+	}
+
+	return backoff.Retry(op, c.policy, c.isRetryable)
+}
+
+func (c *retryableClient) SetWorkflowExecutionPaused(
+	ctx context.Context,
+	request *h.SetWorkflowExecutionPausedRequest,
+	opts ...yarpc.CallOption) error {
+
+	op := func() error {
+		return c.client.SetWorkflowExecutionPaused(ctx, request, opts...)
+	}
+
+	return backoff.Retry(op, c.policy, c.isRetryable)
+}
+
+func (c *retryableClient) SetCronSchedulePaused(
+	ctx context.Context,
+	request *h.SetCronSchedulePausedRequest,
+	opts ...yarpc.CallOption) error {
+
+	op := func() error {
+		return c.client.SetCronSchedulePaused(ctx, request, opts...)
+	}
+
+	return backoff.Retry(op, c.policy, c.isRetryable)
+}
+
+func (c *retryableClient) TerminateAllWorkflowRuns(
+	ctx context.Context,
+	request *h.TerminateAllWorkflowRunsRequest,
+	opts ...yarpc.CallOption) (*shared.TerminateAllWorkflowRunsResponse, error) {
+
+	var resp *shared.TerminateAllWorkflowRunsResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.TerminateAllWorkflowRuns(ctx, request, opts...)
+		return err
+	}
+
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
+
 func (c *retryableClient) TerminateWorkflowExecution(
 	ctx context.Context,
 	request *h.TerminateWorkflowExecutionRequest,
@@ -148,6 +148,17 @@ func (c *clientImpl) CancelOutstandingPoll(ctx context.Context, request *m.Cance
 	return client.CancelOutstandingPoll(ctx, request, opts...)
 }
 
+func (c *clientImpl) UpdateTaskListVersionSets(ctx context.Context, request *m.UpdateTaskListVersionSetsRequest, opts ...yarpc.CallOption) error {
+	opts = common.AggregateYarpcOptions(ctx, opts...)
+	client, err := c.getClientForTasklist(request.TaskList.GetName())
+	if err != nil {
+		return err
+	}
+	ctx, cancel := c.createContext(ctx)
+	defer cancel()
+	return client.UpdateTaskListVersionSets(ctx, request, opts...)
+}
+
 func (c *clientImpl) DescribeTaskList(ctx context.Context, request *m.DescribeTaskListRequest, opts ...yarpc.CallOption) (*workflow.DescribeTaskListResponse, error) {
 	opts = common.AggregateYarpcOptions(ctx, opts...)
 	client, err := c.getClientForTasklist(request.DescRequest.TaskList.GetName())
@@ -159,6 +170,28 @@ func (c *clientImpl) DescribeTaskList(ctx context.Context, request *m.DescribeTa
 	return client.DescribeTaskList(ctx, request, opts...)
 }
 
+func (c *clientImpl) GetTaskListScalingHints(ctx context.Context, request *m.GetTaskListScalingHintsRequest, opts ...yarpc.CallOption) (*workflow.GetTaskListScalingHintsResponse, error) {
+	opts = common.AggregateYarpcOptions(ctx, opts...)
+	client, err := c.getClientForTasklist(request.HintsRequest.TaskList.GetName())
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := c.createContext(ctx)
+	defer cancel()
+	return client.GetTaskListScalingHints(ctx, request, opts...)
+}
+
+func (c *clientImpl) DescribeTaskJourney(ctx context.Context, request *m.DescribeTaskJourneyRequest, opts ...yarpc.CallOption) (*workflow.DescribeTaskJourneyResponse, error) {
+	opts = common.AggregateYarpcOptions(ctx, opts...)
+	client, err := c.getClientForTasklist(request.JourneyRequest.TaskList.GetName())
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := c.createContext(ctx)
+	defer cancel()
+	return client.DescribeTaskJourney(ctx, request, opts...)
+}
+
 func (c *clientImpl) createContext(parent context.Context) (context.Context, context.CancelFunc) {
 	if parent == nil {
 		return context.WithTimeout(context.Background(), c.timeout)
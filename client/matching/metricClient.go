@@ -163,6 +163,23 @@ func (c *metricClient) CancelOutstandingPoll(
 	return err
 }
 
+func (c *metricClient) UpdateTaskListVersionSets(
+	ctx context.Context,
+	request *m.UpdateTaskListVersionSetsRequest,
+	opts ...yarpc.CallOption) error {
+	c.metricsClient.IncCounter(metrics.MatchingClientUpdateTaskListVersionSetsScope, metrics.CadenceClientRequests)
+
+	sw := c.metricsClient.StartTimer(metrics.MatchingClientUpdateTaskListVersionSetsScope, metrics.CadenceClientLatency)
+	err := c.client.UpdateTaskListVersionSets(ctx, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.MatchingClientUpdateTaskListVersionSetsScope, metrics.CadenceClientFailures)
+	}
+
+	return err
+}
+
 func (c *metricClient) DescribeTaskList(
 	ctx context.Context,
 	request *m.DescribeTaskListRequest,
@@ -179,3 +196,37 @@ func (c *metricClient) DescribeTaskList(
 
 	return resp, err
 }
+
+func (c *metricClient) GetTaskListScalingHints(
+	ctx context.Context,
+	request *m.GetTaskListScalingHintsRequest,
+	opts ...yarpc.CallOption) (*workflow.GetTaskListScalingHintsResponse, error) {
+	c.metricsClient.IncCounter(metrics.MatchingClientGetTaskListScalingHintsScope, metrics.CadenceClientRequests)
+
+	sw := c.metricsClient.StartTimer(metrics.MatchingClientGetTaskListScalingHintsScope, metrics.CadenceClientLatency)
+	resp, err := c.client.GetTaskListScalingHints(ctx, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.MatchingClientGetTaskListScalingHintsScope, metrics.CadenceClientFailures)
+	}
+
+	return resp, err
+}
+
+func (c *metricClient) DescribeTaskJourney(
+	ctx context.Context,
+	request *m.DescribeTaskJourneyRequest,
+	opts ...yarpc.CallOption) (*workflow.DescribeTaskJourneyResponse, error) {
+	c.metricsClient.IncCounter(metrics.MatchingClientDescribeTaskJourneyScope, metrics.CadenceClientRequests)
+
+	sw := c.metricsClient.StartTimer(metrics.MatchingClientDescribeTaskJourneyScope, metrics.CadenceClientLatency)
+	resp, err := c.client.DescribeTaskJourney(ctx, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.MatchingClientDescribeTaskJourneyScope, metrics.CadenceClientFailures)
+	}
+
+	return resp, err
+}
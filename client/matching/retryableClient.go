@@ -141,6 +141,18 @@ func (c *retryableClient) CancelOutstandingPoll(
 	return backoff.Retry(op, c.policy, c.isRetryable)
 }
 
+func (c *retryableClient) UpdateTaskListVersionSets(
+	ctx context.Context,
+	request *m.UpdateTaskListVersionSetsRequest,
+	opts ...yarpc.CallOption) error {
+
+	op := func() error {
+		return c.client.UpdateTaskListVersionSets(ctx, request, opts...)
+	}
+
+	return backoff.Retry(op, c.policy, c.isRetryable)
+}
+
 func (c *retryableClient) DescribeTaskList(
 	ctx context.Context,
 	request *m.DescribeTaskListRequest,
@@ -156,3 +168,35 @@ func (c *retryableClient) DescribeTaskList(
 	err := backoff.Retry(op, c.policy, c.isRetryable)
 	return resp, err
 }
+
+func (c *retryableClient) GetTaskListScalingHints(
+	ctx context.Context,
+	request *m.GetTaskListScalingHintsRequest,
+	opts ...yarpc.CallOption) (*workflow.GetTaskListScalingHintsResponse, error) {
+
+	var resp *workflow.GetTaskListScalingHintsResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.GetTaskListScalingHints(ctx, request, opts...)
+		return err
+	}
+
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
+
+func (c *retryableClient) DescribeTaskJourney(
+	ctx context.Context,
+	request *m.DescribeTaskJourneyRequest,
+	opts ...yarpc.CallOption) (*workflow.DescribeTaskJourneyResponse, error) {
+
+	var resp *workflow.DescribeTaskJourneyResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.DescribeTaskJourney(ctx, request, opts...)
+		return err
+	}
+
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
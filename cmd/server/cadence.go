@@ -25,6 +25,7 @@ import (
 	"os"
 	"strings"
 
+	"github.com/uber/cadence/common/persistence/sql/storage/kv/memory"
 	"github.com/uber/cadence/common/service/config"
 	"github.com/uber/cadence/tools/cassandra"
 
@@ -42,6 +43,62 @@ func main() {
 
 // startHandler is the handler for the cli start command
 func startHandler(c *cli.Context) {
+	cfg := loadConfig(c)
+
+	dir, err := os.Getwd()
+	if err != nil {
+		log.Fatal("Unable to get current directory")
+	}
+	if err := cassandra.VerifyCompatibleVersion(cfg.Persistence, dir); err != nil {
+		log.Fatal("Incompatible versions", err)
+	}
+
+	startServices(cfg, getServices(c))
+}
+
+// devServerHandler is the handler for the cli dev-server command, it runs all the
+// services in one process, on the embedded store or on the cassandra keyspaces of the config
+func devServerHandler(c *cli.Context) {
+	cfg := loadConfig(c)
+
+	if c.BoolT("embedded-store") {
+		useEmbeddedStore(&cfg.Persistence)
+		startServices(cfg, validServices)
+		return
+	}
+
+	dir, err := os.Getwd()
+	if err != nil {
+		log.Fatal("Unable to get current directory")
+	}
+	if c.BoolT("setup-schema") {
+		if err := cassandra.SetupLatestSchema(cfg.Persistence, dir); err != nil {
+			log.Fatal("Unable to setup schema", err)
+		}
+	}
+	if err := cassandra.VerifyCompatibleVersion(cfg.Persistence, dir); err != nil {
+		log.Fatal("Incompatible versions", err)
+	}
+
+	startServices(cfg, validServices)
+}
+
+// useEmbeddedStore points the default and the visibility stores of the config to the
+// in-memory store of the process, the data is lost when the process exits
+func useEmbeddedStore(cfg *config.Persistence) {
+	cfg.DefaultStore = memory.StoreName
+	cfg.VisibilityStore = memory.StoreName
+	cfg.DualWrite = nil
+	cfg.DataStores = map[string]config.DataStore{
+		memory.StoreName: {
+			CustomDataStoreConfig: &config.CustomDatastoreConfig{Name: memory.StoreName},
+		},
+	}
+	log.Printf("Using the embedded %v store, the data is lost on exit\n", memory.StoreName)
+}
+
+// loadConfig loads and validates the config for the environment and zone
+func loadConfig(c *cli.Context) *config.Config {
 	env := getEnvironment(c)
 	zone := getZone(c)
 	configDir := getConfigDir(c)
@@ -58,21 +115,19 @@ func startHandler(c *cli.Context) {
 	if err := cfg.Validate(); err != nil {
 		log.Fatalf("config validation failed: %v", err)
 	}
+	return &cfg
+}
 
-	dir, err := os.Getwd()
-	if err != nil {
-		log.Fatal("Unable to get current directory")
-	}
-	if err := cassandra.VerifyCompatibleVersion(cfg.Persistence, dir); err != nil {
-		log.Fatal("Incompatible versions", err)
-	}
-
-	services := getServices(c)
+// startServices starts the given services and blocks forever
+func startServices(cfg *config.Config, services []string) {
 	for _, svc := range services {
 		if _, ok := cfg.Services[svc]; !ok {
 			log.Fatalf("`%v` service missing config", svc)
 		}
-		server := newServer(svc, &cfg)
+		if cfg.Persistence.ReadOnly && svc != frontendService {
+			log.Fatalf("`%v` service can't be started on read only persistence, only the frontend can", svc)
+		}
+		server := newServer(svc, cfg)
 		server.Start()
 	}
 
@@ -186,6 +241,23 @@ func buildCLI() *cli.App {
 				startHandler(c)
 			},
 		},
+		{
+			Name:  "dev-server",
+			Usage: "start all cadence services in one process for local development",
+			Flags: []cli.Flag{
+				cli.BoolTFlag{
+					Name:  "embedded-store",
+					Usage: "keep the data in an in-memory store of the process instead of the configured datastores",
+				},
+				cli.BoolTFlag{
+					Name:  "setup-schema",
+					Usage: "create the cassandra keyspaces and update their schema to the latest version, without the embedded store",
+				},
+			},
+			Action: func(c *cli.Context) {
+				devServerHandler(c)
+			},
+		},
 	}
 
 	return app
@@ -26,14 +26,20 @@ import (
 
 	"github.com/uber/cadence/client"
 	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/authorization"
+	"github.com/uber/cadence/common/blobstore/blob"
 	"github.com/uber/cadence/common/blobstore/filestore"
 	"github.com/uber/cadence/common/blobstore/s3store"
 	"github.com/uber/cadence/common/cluster"
 	"github.com/uber/cadence/common/elasticsearch"
 	"github.com/uber/cadence/common/log/loggerimpl"
 	"github.com/uber/cadence/common/log/tag"
+	"github.com/uber/cadence/common/membership/heartbeat"
 	"github.com/uber/cadence/common/messaging"
 	"github.com/uber/cadence/common/metrics"
+	"github.com/uber/cadence/common/persistence"
+	persistencefactory "github.com/uber/cadence/common/persistence/persistence-factory"
+	"github.com/uber/cadence/common/pinot"
 	"github.com/uber/cadence/common/service"
 	"github.com/uber/cadence/common/service/config"
 	"github.com/uber/cadence/common/service/dynamicconfig"
@@ -98,6 +104,29 @@ func (s *server) Stop() {
 	}
 }
 
+// newMembershipFactory returns the membership factory selected by the config
+func (s *server) newMembershipFactory(params service.BootstrapParams) service.MembershipMonitorFactory {
+	switch s.cfg.Membership.Provider {
+	case config.MembershipProviderHeartbeat:
+		pFactory := persistencefactory.New(&s.cfg.Persistence, s.cfg.ClusterMetadata.CurrentClusterName, params.MetricsClient, params.Logger)
+		store, err := pFactory.NewClusterMembershipManager()
+		if err != nil {
+			log.Fatalf("error creating cluster membership manager: %v", err)
+		}
+		return heartbeat.NewFactory(params.Name, config.CadenceServices, store,
+			s.cfg.Membership.HeartbeatInterval, s.cfg.Membership.HeartbeatTTL, params.Logger)
+	case "", config.MembershipProviderRingpop:
+		factory, err := s.cfg.Ringpop.NewFactory(params.Logger, params.Name)
+		if err != nil {
+			log.Fatalf("error creating ringpop factory: %v", err)
+		}
+		return factory
+	default:
+		log.Fatalf("unknown membership provider: %v", s.cfg.Membership.Provider)
+	}
+	return nil
+}
+
 // startService starts a service with the given name and config
 func (s *server) startService() common.Daemon {
 
@@ -108,11 +137,6 @@ func (s *server) startService() common.Daemon {
 	params.Logger = loggerimpl.NewLogger(s.cfg.Log.NewZapLogger())
 	params.PersistenceConfig = s.cfg.Persistence
 
-	params.MembershipFactory, err = s.cfg.Ringpop.NewFactory(params.Logger, params.Name)
-	if err != nil {
-		log.Fatalf("error creating ringpop factory: %v", err)
-	}
-
 	params.DynamicConfig, err = dynamicconfig.NewFileBasedClient(&s.cfg.DynamicConfigClient, params.Logger.WithTags(tag.Service(params.Name)), s.doneC)
 	if err != nil {
 		log.Printf("error creating file based dynamic config client, use no-op config client instead. error: %v", err)
@@ -129,9 +153,15 @@ func (s *server) startService() common.Daemon {
 	enableReadFromArchival := dc.GetBoolProperty(dynamicconfig.EnableReadFromArchival, s.cfg.Archival.EnableReadFromArchival)
 
 	params.DCRedirectionPolicy = s.cfg.DCRedirectionPolicy
+	params.TaskTokenSerializer = s.cfg.TaskToken.NewSerializer()
+	params.Authentication = s.cfg.Authentication
+	params.Authorizer = authorization.NewNopAuthorizer()
+	params.Audit = s.cfg.Audit
 
 	params.MetricsClient = metrics.NewClient(params.MetricScope, service.GetMetricsServiceIdx(params.Name, params.Logger))
 
+	params.MembershipFactory = s.newMembershipFactory(params)
+
 	clusterMetadata := s.cfg.ClusterMetadata
 	params.ClusterMetadata = cluster.NewMetadata(
 		params.Logger,
@@ -145,7 +175,18 @@ func (s *server) startService() common.Daemon {
 		enableReadFromArchival(),
 	)
 
-	if s.cfg.PublicClient.HostPort != "" {
+	if healthCheck := s.cfg.ClusterMetadata.ConnectionHealthCheck; s.cfg.PublicClient.HostPort != "" && healthCheck != nil {
+		params.DispatcherProvider = client.NewHealthCheckedDispatcherProvider(
+			client.ClusterConnectionOptions{
+				RefreshInterval:     s.cfg.PublicClient.RefreshInterval,
+				HealthCheckInterval: healthCheck.Interval,
+				HealthCheckTimeout:  healthCheck.Timeout,
+				UnhealthyThreshold:  healthCheck.UnhealthyThreshold,
+			},
+			params.MetricsClient,
+			params.Logger,
+		)
+	} else if s.cfg.PublicClient.HostPort != "" {
 		params.DispatcherProvider = client.NewDNSYarpcDispatcherProvider(params.Logger, s.cfg.PublicClient.RefreshInterval)
 	} else {
 		log.Fatalf("need to provide an endpoint config for PublicClient")
@@ -153,10 +194,12 @@ func (s *server) startService() common.Daemon {
 
 	params.ESConfig = &s.cfg.ElasticSearch
 	params.ESConfig.Enable = dc.GetBoolProperty(dynamicconfig.EnableVisibilityToKafka, params.ESConfig.Enable)() // force override with dynamic config
+	params.PinotConfig = &s.cfg.Pinot
+	visibilityToKafka := params.ESConfig.Enable || params.PinotConfig.Enable
 	if params.ClusterMetadata.IsGlobalDomainEnabled() {
-		params.MessagingClient = messaging.NewKafkaClient(&s.cfg.Kafka, params.MetricsClient, zap.NewNop(), params.Logger, params.MetricScope, true, params.ESConfig.Enable)
-	} else if params.ESConfig.Enable {
-		params.MessagingClient = messaging.NewKafkaClient(&s.cfg.Kafka, params.MetricsClient, zap.NewNop(), params.Logger, params.MetricScope, false, params.ESConfig.Enable)
+		params.MessagingClient = messaging.NewKafkaClient(&s.cfg.Kafka, params.MetricsClient, zap.NewNop(), params.Logger, params.MetricScope, true, visibilityToKafka)
+	} else if visibilityToKafka {
+		params.MessagingClient = messaging.NewKafkaClient(&s.cfg.Kafka, params.MetricsClient, zap.NewNop(), params.Logger, params.MetricScope, false, visibilityToKafka)
 	} else {
 		params.MessagingClient = nil
 	}
@@ -175,6 +218,14 @@ func (s *server) startService() common.Daemon {
 		}
 	}
 
+	if params.PinotConfig.Enable {
+		pinotClient, err := pinot.NewClient(params.PinotConfig)
+		if err != nil {
+			log.Fatalf("error creating pinot client: %v", err)
+		}
+		params.PinotClient = pinotClient
+	}
+
 	dispatcher, err := params.DispatcherProvider.Get(common.FrontendServiceName, s.cfg.PublicClient.HostPort)
 	if err != nil {
 		log.Fatalf("failed to construct dispatcher: %v", err)
@@ -202,9 +253,28 @@ func (s *server) startService() common.Daemon {
 			}
 			params.BlobstoreClient = s3store.NewClient(s3cli)
 		}
+		if len(s.cfg.Archival.EncryptionKeys) > 0 {
+			keyProvider, err := blob.NewStaticKeyProvider(s.cfg.Archival.EncryptionKeys)
+			if err != nil {
+				log.Fatalf("error creating encryption key provider: %v", err)
+			}
+			params.BlobKeyProvider = keyProvider
+		}
+	}
+
+	if s.cfg.Persistence.PayloadInspector != nil {
+		inspector, err := persistence.NewPayloadInspector(*s.cfg.Persistence.PayloadInspector, params.Logger)
+		if err != nil {
+			log.Fatalf("error creating payload inspector: %v", err)
+		}
+		params.PayloadInspector = inspector
 	}
 
 	params.PersistenceConfig.TransactionSizeLimit = dc.GetIntProperty(dynamicconfig.TransactionSizeLimit, common.DefaultTransactionSizeLimit)
+	params.PersistenceConfig.ExecutionTransactionStatementLimit = dc.GetIntProperty(
+		dynamicconfig.ExecutionTransactionStatementLimit, common.DefaultExecutionTransactionStatementLimit)
+	params.PersistenceConfig.ExecutionTransactionSizeLimit = dc.GetIntProperty(
+		dynamicconfig.ExecutionTransactionSizeLimit, common.DefaultExecutionTransactionSizeLimit)
 
 	params.Logger.Info("Starting service " + s.name)
 
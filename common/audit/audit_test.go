@@ -0,0 +1,139 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package audit
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/log/loggerimpl"
+)
+
+type (
+	auditSuite struct {
+		suite.Suite
+		*require.Assertions
+
+		dir string
+	}
+)
+
+func TestAuditSuite(t *testing.T) {
+	suite.Run(t, new(auditSuite))
+}
+
+func (s *auditSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+
+	dir, err := ioutil.TempDir("", "audit")
+	s.NoError(err)
+	s.dir = dir
+}
+
+func (s *auditSuite) TearDownTest() {
+	os.RemoveAll(s.dir)
+}
+
+func (s *auditSuite) TestNewEntry() {
+	request := &shared.DeprecateDomainRequest{Name: common.StringPtr("test-domain")}
+	entry := NewEntry("alice", "cadence-cli", "DeprecateDomain", "test-domain", request, errors.New("some error"))
+	s.Equal("alice", entry.Caller)
+	s.Equal("cadence-cli", entry.CallerService)
+	s.Equal("DeprecateDomain", entry.Operation)
+	s.Equal("test-domain", entry.Domain)
+	s.Equal("some error", entry.Error)
+	s.Len(entry.RequestHash, 64)
+	s.False(entry.Timestamp.IsZero())
+
+	sameRequest := &shared.DeprecateDomainRequest{Name: common.StringPtr("test-domain")}
+	s.Equal(entry.RequestHash, NewEntry("bob", "", "DeprecateDomain", "test-domain", sameRequest, nil).RequestHash)
+
+	otherRequest := &shared.DeprecateDomainRequest{Name: common.StringPtr("other-domain")}
+	s.NotEqual(entry.RequestHash, NewEntry("alice", "", "DeprecateDomain", "other-domain", otherRequest, nil).RequestHash)
+}
+
+func (s *auditSuite) TestFileSink() {
+	path := filepath.Join(s.dir, "audit.log")
+	sink, err := NewFileSink(path)
+	s.NoError(err)
+
+	first := NewEntry("alice", "", "RegisterDomain", "domain1", &shared.RegisterDomainRequest{Name: common.StringPtr("domain1")}, nil)
+	second := NewEntry("bob", "", "FailoverDomain", "domain2", &shared.UpdateDomainRequest{Name: common.StringPtr("domain2")}, nil)
+	s.NoError(sink.Write(first))
+	s.NoError(sink.Write(second))
+	s.NoError(sink.Close())
+
+	file, err := os.Open(path)
+	s.NoError(err)
+	defer file.Close()
+
+	var entries []*Entry
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		entry := &Entry{}
+		s.NoError(json.Unmarshal(scanner.Bytes(), entry))
+		entries = append(entries, entry)
+	}
+	s.NoError(scanner.Err())
+	s.Len(entries, 2)
+	s.Equal("alice", entries[0].Caller)
+	s.Equal("RegisterDomain", entries[0].Operation)
+	s.Equal(first.RequestHash, entries[0].RequestHash)
+	s.Equal("bob", entries[1].Caller)
+	s.Equal("FailoverDomain", entries[1].Operation)
+	s.Equal(second.RequestHash, entries[1].RequestHash)
+}
+
+func (s *auditSuite) TestConfigNewSink() {
+	logger := loggerimpl.NewNopLogger()
+
+	sink, err := (&Config{}).NewSink(logger, nil, nil)
+	s.NoError(err)
+	s.IsType(&logSink{}, sink)
+
+	sink, err = (&Config{Sink: SinkTypeFile, FilePath: filepath.Join(s.dir, "audit.log")}).NewSink(logger, nil, nil)
+	s.NoError(err)
+	s.IsType(&fileSink{}, sink)
+	s.NoError(sink.Close())
+
+	_, err = (&Config{Sink: SinkTypeFile}).NewSink(logger, nil, nil)
+	s.Error(err)
+	_, err = (&Config{Sink: SinkTypeKafka, KafkaApplication: "audit"}).NewSink(logger, nil, nil)
+	s.Error(err)
+	_, err = (&Config{Sink: SinkTypeStore}).NewSink(logger, nil, nil)
+	s.Error(err)
+	_, err = (&Config{Sink: "unknown"}).NewSink(logger, nil, nil)
+	s.Error(err)
+
+	storeSink := NewNopSink()
+	sink, err = (&Config{Sink: SinkTypeStore}).NewSink(logger, nil, func() (Sink, error) { return storeSink, nil })
+	s.NoError(err)
+	s.Equal(storeSink, sink)
+}
@@ -0,0 +1,75 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package audit
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/uber/cadence/common/log"
+	"github.com/uber/cadence/common/messaging"
+)
+
+type (
+	// Config is the config for recording mutating admin and domain API calls
+	Config struct {
+		// Sink is one of "log", "file", "kafka" or "store", defaults to "log"
+		Sink string `yaml:"sink"`
+		// FilePath is the file audit entries are appended to by the file sink
+		FilePath string `yaml:"filePath"`
+		// KafkaApplication is the application in the kafka config whose topic
+		// audit entries are published to by the kafka sink
+		KafkaApplication string `yaml:"kafkaApplication"`
+	}
+)
+
+// NewSink creates the sink described by the config, newStoreSink creates the sink persisting
+// entries to the persistence store and is only called for the store sink
+func (c *Config) NewSink(logger log.Logger, messagingClient messaging.Client, newStoreSink func() (Sink, error)) (Sink, error) {
+	switch c.Sink {
+	case "", SinkTypeLog:
+		return NewLogSink(logger), nil
+	case SinkTypeFile:
+		if c.FilePath == "" {
+			return nil, errors.New("audit file sink requires filePath")
+		}
+		return NewFileSink(c.FilePath)
+	case SinkTypeKafka:
+		if c.KafkaApplication == "" {
+			return nil, errors.New("audit kafka sink requires kafkaApplication")
+		}
+		if messagingClient == nil {
+			return nil, errors.New("audit kafka sink requires kafka to be configured")
+		}
+		producer, err := messagingClient.NewProducer(c.KafkaApplication)
+		if err != nil {
+			return nil, err
+		}
+		return NewKafkaSink(producer), nil
+	case SinkTypeStore:
+		if newStoreSink == nil {
+			return nil, errors.New("audit store sink is not supported by this service")
+		}
+		return newStoreSink()
+	default:
+		return nil, fmt.Errorf("unknown audit sink %v", c.Sink)
+	}
+}
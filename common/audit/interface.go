@@ -0,0 +1,62 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package audit
+
+import (
+	"time"
+)
+
+const (
+	// SinkTypeLog writes audit entries to the service log
+	SinkTypeLog = "log"
+	// SinkTypeFile appends audit entries as JSON lines to a file
+	SinkTypeFile = "file"
+	// SinkTypeKafka publishes audit entries to a kafka topic
+	SinkTypeKafka = "kafka"
+	// SinkTypeStore persists audit entries to the audit log table of the persistence store
+	SinkTypeStore = "store"
+)
+
+type (
+	// Entry is the audit record of a mutating admin or domain API call
+	Entry struct {
+		// Timestamp is when the call completed
+		Timestamp time.Time `json:"timestamp"`
+		// Caller is the authenticated identity of the caller, "anonymous" if not authenticated
+		Caller string `json:"caller"`
+		// CallerService is the rpc caller name of the client, if known
+		CallerService string `json:"callerService,omitempty"`
+		// Operation is the API name, e.g. UpdateDomain or FailoverDomain
+		Operation string `json:"operation"`
+		// Domain is the domain the call operated on, if any
+		Domain string `json:"domain,omitempty"`
+		// RequestHash is the hex encoded SHA-256 of the JSON encoded request payload
+		RequestHash string `json:"requestHash"`
+		// Error is the error returned to the caller, empty on success
+		Error string `json:"error,omitempty"`
+	}
+
+	// Sink is where audit entries are recorded
+	Sink interface {
+		Write(entry *Entry) error
+		Close() error
+	}
+)
@@ -0,0 +1,54 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package audit
+
+import (
+	"encoding/json"
+
+	"github.com/uber/cadence/common/messaging"
+)
+
+type (
+	kafkaSink struct {
+		producer messaging.Producer
+	}
+)
+
+var _ Sink = (*kafkaSink)(nil)
+
+// NewKafkaSink creates a sink publishing JSON encoded audit entries with the given producer
+func NewKafkaSink(producer messaging.Producer) Sink {
+	return &kafkaSink{
+		producer: producer,
+	}
+}
+
+func (s *kafkaSink) Write(entry *Entry) error {
+	payload, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	return s.producer.Publish(payload)
+}
+
+func (s *kafkaSink) Close() error {
+	return s.producer.Close()
+}
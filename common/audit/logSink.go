@@ -0,0 +1,61 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package audit
+
+import (
+	"errors"
+
+	"github.com/uber/cadence/common/log"
+	"github.com/uber/cadence/common/log/tag"
+)
+
+type (
+	logSink struct {
+		logger log.Logger
+	}
+)
+
+var _ Sink = (*logSink)(nil)
+
+// NewLogSink creates a sink writing audit entries to the given logger
+func NewLogSink(logger log.Logger) Sink {
+	return &logSink{
+		logger: logger,
+	}
+}
+
+func (s *logSink) Write(entry *Entry) error {
+	tags := []tag.Tag{
+		tag.CallerIdentity(entry.Caller),
+		tag.WorkflowHandlerName(entry.Operation),
+		tag.WorkflowDomainName(entry.Domain),
+		tag.AuditRequestHash(entry.RequestHash),
+	}
+	if entry.Error != "" {
+		tags = append(tags, tag.Error(errors.New(entry.Error)))
+	}
+	s.logger.Info("Audit", tags...)
+	return nil
+}
+
+func (s *logSink) Close() error {
+	return nil
+}
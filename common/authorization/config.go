@@ -0,0 +1,45 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package authorization
+
+import "time"
+
+type (
+	// Config is the config for authenticating callers with OIDC / JWT bearer tokens
+	Config struct {
+		// Issuer is the expected issuer of the tokens, it is also used to discover the
+		// JWKS endpoint of the OIDC provider when JWKSURL is not set
+		Issuer string `yaml:"issuer"`
+		// JWKSURL is the endpoint serving the JSON web key set used to verify tokens
+		JWKSURL string `yaml:"jwksURL"`
+		// Audience is the expected audience of the tokens, not validated when empty
+		Audience string `yaml:"audience"`
+		// IdentityClaim is the claim used as the caller identity, defaults to "sub"
+		IdentityClaim string `yaml:"identityClaim"`
+		// KeyRefreshInterval is how often the JSON web key set is refreshed, defaults to 1 hour
+		KeyRefreshInterval time.Duration `yaml:"keyRefreshInterval"`
+	}
+)
+
+// Enabled returns whether bearer token authentication is configured
+func (c *Config) Enabled() bool {
+	return c.Issuer != "" || c.JWKSURL != ""
+}
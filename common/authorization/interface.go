@@ -0,0 +1,72 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package authorization
+
+import (
+	"context"
+)
+
+const (
+	// DecisionDeny means auth decision is deny
+	DecisionDeny Decision = iota + 1
+	// DecisionAllow means auth decision is allow
+	DecisionAllow
+)
+
+type (
+	// Decision is enum type for auth decision
+	Decision int
+
+	// Identity is the authenticated caller of an API
+	Identity struct {
+		// Subject is the caller identity, taken from the configured identity claim of the token
+		Subject string
+		// Issuer is the issuer of the token
+		Issuer string
+		// Claims are all the claims of the token
+		Claims map[string]interface{}
+	}
+
+	// Attributes is input for authority to make decision.
+	// It can be extended in future if required auth on resources like WorkflowType and TaskList
+	Attributes struct {
+		// Identity is nil for anonymous callers
+		Identity   *Identity
+		APIName    string
+		DomainName string
+	}
+
+	// Result is result from authority.
+	Result struct {
+		Decision Decision
+	}
+
+	// Authorizer is an interface for authorization
+	Authorizer interface {
+		Authorize(ctx context.Context, attributes *Attributes) (Result, error)
+	}
+
+	// Authenticator resolves the identity of the caller of an API
+	Authenticator interface {
+		// Authenticate returns the caller identity, or nil for anonymous callers
+		Authenticate(ctx context.Context) (*Identity, error)
+	}
+)
@@ -0,0 +1,267 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package authorization
+
+import (
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/uber/cadence/common/backoff"
+	"github.com/uber/cadence/common/clock"
+	"github.com/uber/cadence/common/log"
+	"github.com/uber/cadence/common/log/tag"
+)
+
+const (
+	defaultKeyRefreshInterval = time.Hour
+	// minKeyRefreshInterval rate limits the refreshes triggered by tokens signed with unknown keys
+	minKeyRefreshInterval = time.Minute
+	keyFetchTimeout       = 10 * time.Second
+	// keyFetchRetryInterval is the initial backoff after a failed refresh, doubled up to minKeyRefreshInterval
+	keyFetchRetryInterval = time.Second
+
+	oidcDiscoveryPath = "/.well-known/openid-configuration"
+)
+
+var (
+	errUnknownSigningKey = errors.New("bearer token signing key is unknown")
+)
+
+type (
+	keyProvider interface {
+		getKey(keyID string) (*rsa.PublicKey, error)
+	}
+
+	jwksKeyProvider struct {
+		issuer          string
+		jwksURL         string
+		refreshInterval time.Duration
+		client          *http.Client
+		timeSource      clock.TimeSource
+		retryPolicy     backoff.RetryPolicy
+		logger          log.Logger
+
+		sync.Mutex
+		keys        map[string]*rsa.PublicKey
+		lastRefresh time.Time
+		// refreshDone is closed when the refresh in flight completes, nil if there is none
+		refreshDone     chan struct{}
+		refreshErr      error
+		refreshFailures int
+		nextRetry       time.Time
+	}
+
+	jsonWebKeySet struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+
+	jsonWebKey struct {
+		KeyType string `json:"kty"`
+		KeyID   string `json:"kid"`
+		Use     string `json:"use"`
+		N       string `json:"n"`
+		E       string `json:"e"`
+	}
+
+	oidcConfiguration struct {
+		JWKSURI string `json:"jwks_uri"`
+	}
+)
+
+func newJWKSKeyProvider(config *Config, timeSource clock.TimeSource, logger log.Logger) *jwksKeyProvider {
+	refreshInterval := config.KeyRefreshInterval
+	if refreshInterval <= 0 {
+		refreshInterval = defaultKeyRefreshInterval
+	}
+	retryPolicy := backoff.NewExponentialRetryPolicy(keyFetchRetryInterval)
+	retryPolicy.SetMaximumInterval(minKeyRefreshInterval)
+	retryPolicy.SetExpirationInterval(backoff.NoInterval)
+	return &jwksKeyProvider{
+		issuer:          strings.TrimSuffix(config.Issuer, "/"),
+		jwksURL:         config.JWKSURL,
+		refreshInterval: refreshInterval,
+		client:          &http.Client{Timeout: keyFetchTimeout},
+		timeSource:      timeSource,
+		retryPolicy:     retryPolicy,
+		logger:          logger,
+	}
+}
+
+func (p *jwksKeyProvider) getKey(keyID string) (*rsa.PublicKey, error) {
+	p.Lock()
+	key, ok := p.lookupKey(keyID)
+	refreshDone := p.refreshDone
+	switch {
+	case !p.refreshDue(ok):
+		err := p.missingKeyError()
+		p.Unlock()
+		if !ok {
+			return nil, err
+		}
+		return key, nil
+	case refreshDone != nil && ok:
+		// keep using the stale key while another caller refreshes the key set
+		p.Unlock()
+		return key, nil
+	case refreshDone == nil:
+		refreshDone = make(chan struct{})
+		p.refreshDone = refreshDone
+		p.Unlock()
+		p.refresh(refreshDone)
+	default:
+		p.Unlock()
+	}
+
+	<-refreshDone
+	p.Lock()
+	defer p.Unlock()
+	if key, ok = p.lookupKey(keyID); !ok {
+		return nil, p.missingKeyError()
+	}
+	return key, nil
+}
+
+// refreshDue returns whether the key set should be fetched again, failed fetches are retried
+// with an exponential backoff so an unavailable issuer is not called on every request
+func (p *jwksKeyProvider) refreshDue(keyFound bool) bool {
+	now := p.timeSource.Now()
+	switch {
+	case p.refreshErr != nil:
+		return !now.Before(p.nextRetry)
+	case keyFound:
+		return now.Sub(p.lastRefresh) >= p.refreshInterval
+	default:
+		return p.keys == nil || now.Sub(p.lastRefresh) >= minKeyRefreshInterval
+	}
+}
+
+func (p *jwksKeyProvider) missingKeyError() error {
+	if p.refreshErr != nil {
+		return p.refreshErr
+	}
+	return errUnknownSigningKey
+}
+
+// refresh fetches the key set without holding the lock and closes refreshDone once the result is stored
+func (p *jwksKeyProvider) refresh(refreshDone chan struct{}) {
+	keys, err := p.fetchKeys()
+
+	p.Lock()
+	defer p.Unlock()
+	now := p.timeSource.Now()
+	p.lastRefresh = now
+	p.refreshErr = err
+	if err != nil {
+		p.logger.Warn("Failed to refresh JSON web key set", tag.Error(err))
+		p.nextRetry = now.Add(p.retryPolicy.ComputeNextDelay(0, p.refreshFailures))
+		p.refreshFailures++
+	} else {
+		p.keys = keys
+		p.refreshFailures = 0
+	}
+	p.refreshDone = nil
+	close(refreshDone)
+}
+
+func (p *jwksKeyProvider) lookupKey(keyID string) (*rsa.PublicKey, bool) {
+	if key, ok := p.keys[keyID]; ok {
+		return key, true
+	}
+	// tokens without key ID can only be verified against a key set with a single key
+	if keyID == "" && len(p.keys) == 1 {
+		for _, key := range p.keys {
+			return key, true
+		}
+	}
+	return nil, false
+}
+
+func (p *jwksKeyProvider) fetchKeys() (map[string]*rsa.PublicKey, error) {
+	jwksURL := p.jwksURL
+	if jwksURL == "" {
+		var oidcConfig oidcConfiguration
+		if err := p.fetch(p.issuer+oidcDiscoveryPath, &oidcConfig); err != nil {
+			return nil, err
+		}
+		if oidcConfig.JWKSURI == "" {
+			return nil, fmt.Errorf("OIDC configuration of %v has no jwks_uri", p.issuer)
+		}
+		jwksURL = oidcConfig.JWKSURI
+	}
+
+	var keySet jsonWebKeySet
+	if err := p.fetch(jwksURL, &keySet); err != nil {
+		return nil, err
+	}
+	keys := make(map[string]*rsa.PublicKey, len(keySet.Keys))
+	for _, jwk := range keySet.Keys {
+		if jwk.KeyType != "RSA" || (jwk.Use != "" && jwk.Use != "sig") {
+			continue
+		}
+		key, err := jwk.rsaPublicKey()
+		if err != nil {
+			p.logger.Warn("Skipping invalid JSON web key", tag.Key(jwk.KeyID), tag.Error(err))
+			continue
+		}
+		keys[jwk.KeyID] = key
+	}
+	return keys, nil
+}
+
+func (p *jwksKeyProvider) fetch(url string, v interface{}) error {
+	resp, err := p.client.Get(url)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("fetching %v returned status %v", url, resp.StatusCode)
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
+
+func (k *jsonWebKey) rsaPublicKey() (*rsa.PublicKey, error) {
+	n, err := base64.RawURLEncoding.DecodeString(k.N)
+	if err != nil {
+		return nil, err
+	}
+	e, err := base64.RawURLEncoding.DecodeString(k.E)
+	if err != nil {
+		return nil, err
+	}
+	exponent := new(big.Int).SetBytes(e)
+	if !exponent.IsInt64() || exponent.Int64() > int64(^uint32(0)>>1) || exponent.Int64() < 2 {
+		return nil, errors.New("invalid RSA exponent")
+	}
+	return &rsa.PublicKey{
+		N: new(big.Int).SetBytes(n),
+		E: int(exponent.Int64()),
+	}, nil
+}
@@ -0,0 +1,211 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package authorization
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"github.com/uber/cadence/common/clock"
+	"github.com/uber/cadence/common/log/loggerimpl"
+)
+
+type (
+	jwksKeyProviderSuite struct {
+		suite.Suite
+		*require.Assertions
+
+		server      *httptest.Server
+		keySet      atomic.Value
+		jwksFetches int32
+		unavailable int32
+		timeSource  *clock.EventTimeSource
+		now         time.Time
+	}
+)
+
+func TestJWKSKeyProviderSuite(t *testing.T) {
+	suite.Run(t, new(jwksKeyProviderSuite))
+}
+
+func (s *jwksKeyProviderSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+	s.now = time.Now()
+	s.timeSource = clock.NewEventTimeSource().Update(s.now)
+	s.jwksFetches = 0
+	s.unavailable = 0
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(oidcDiscoveryPath, func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(oidcConfiguration{JWKSURI: s.server.URL + "/keys"})
+	})
+	mux.HandleFunc("/keys", func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&s.jwksFetches, 1)
+		if atomic.LoadInt32(&s.unavailable) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		json.NewEncoder(w).Encode(s.keySet.Load())
+	})
+	s.server = httptest.NewServer(mux)
+}
+
+func (s *jwksKeyProviderSuite) TearDownTest() {
+	s.server.Close()
+}
+
+func (s *jwksKeyProviderSuite) TestDiscoveryAndRotation() {
+	key1 := s.generateKey()
+	key2 := s.generateKey()
+	s.keySet.Store(jsonWebKeySet{Keys: []jsonWebKey{toJSONWebKey("key1", key1)}})
+	provider := s.newProvider(&Config{Issuer: s.server.URL + "/"})
+
+	key, err := provider.getKey("key1")
+	s.NoError(err)
+	s.Equal(key1.N, key.N)
+	s.Equal(key1.E, key.E)
+
+	// unknown keys do not trigger refreshes more often than the minimum interval
+	_, err = provider.getKey("key2")
+	s.Equal(errUnknownSigningKey, err)
+	s.Equal(int32(1), atomic.LoadInt32(&s.jwksFetches))
+
+	s.keySet.Store(jsonWebKeySet{Keys: []jsonWebKey{toJSONWebKey("key1", key1), toJSONWebKey("key2", key2)}})
+	s.timeSource.Update(s.now.Add(2 * minKeyRefreshInterval))
+	key, err = provider.getKey("key2")
+	s.NoError(err)
+	s.Equal(key2.N, key.N)
+	s.Equal(int32(2), atomic.LoadInt32(&s.jwksFetches))
+}
+
+func (s *jwksKeyProviderSuite) TestKeyWithoutID() {
+	key1 := s.generateKey()
+	s.keySet.Store(jsonWebKeySet{Keys: []jsonWebKey{toJSONWebKey("", key1)}})
+	provider := s.newProvider(&Config{JWKSURL: s.server.URL + "/keys"})
+
+	key, err := provider.getKey("")
+	s.NoError(err)
+	s.Equal(key1.N, key.N)
+}
+
+func (s *jwksKeyProviderSuite) TestStaleKeysUsedOnRefreshFailure() {
+	key1 := s.generateKey()
+	s.keySet.Store(jsonWebKeySet{Keys: []jsonWebKey{toJSONWebKey("key1", key1)}})
+	provider := s.newProvider(&Config{JWKSURL: s.server.URL + "/keys"})
+
+	_, err := provider.getKey("key1")
+	s.NoError(err)
+
+	s.server.Close()
+	s.timeSource.Update(s.now.Add(2 * defaultKeyRefreshInterval))
+	key, err := provider.getKey("key1")
+	s.NoError(err)
+	s.Equal(key1.N, key.N)
+}
+
+func (s *jwksKeyProviderSuite) TestFailedRefreshBackoff() {
+	key1 := s.generateKey()
+	s.keySet.Store(jsonWebKeySet{Keys: []jsonWebKey{toJSONWebKey("key1", key1)}})
+	atomic.StoreInt32(&s.unavailable, 1)
+	provider := s.newProvider(&Config{JWKSURL: s.server.URL + "/keys"})
+
+	_, err := provider.getKey("key1")
+	s.Error(err)
+	s.NotEqual(errUnknownSigningKey, err)
+	s.Equal(int32(1), atomic.LoadInt32(&s.jwksFetches))
+
+	// without any key set the fetch is not retried before the backoff elapses
+	_, err = provider.getKey("key1")
+	s.Error(err)
+	s.Equal(int32(1), atomic.LoadInt32(&s.jwksFetches))
+
+	s.timeSource.Update(s.now.Add(keyFetchRetryInterval))
+	_, err = provider.getKey("key1")
+	s.Error(err)
+	s.Equal(int32(2), atomic.LoadInt32(&s.jwksFetches))
+
+	// the backoff grows with consecutive failures
+	s.timeSource.Update(s.now.Add(2 * keyFetchRetryInterval))
+	_, err = provider.getKey("key1")
+	s.Error(err)
+	s.Equal(int32(2), atomic.LoadInt32(&s.jwksFetches))
+
+	atomic.StoreInt32(&s.unavailable, 0)
+	s.timeSource.Update(s.now.Add(minKeyRefreshInterval))
+	key, err := provider.getKey("key1")
+	s.NoError(err)
+	s.Equal(key1.N, key.N)
+	s.Equal(int32(3), atomic.LoadInt32(&s.jwksFetches))
+}
+
+func (s *jwksKeyProviderSuite) TestConcurrentCallersShareRefresh() {
+	key1 := s.generateKey()
+	s.keySet.Store(jsonWebKeySet{Keys: []jsonWebKey{toJSONWebKey("key1", key1)}})
+	provider := s.newProvider(&Config{JWKSURL: s.server.URL + "/keys"})
+
+	keys := make([]*rsa.PublicKey, 10)
+	errs := make([]error, 10)
+	var wg sync.WaitGroup
+	for i := range keys {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			keys[i], errs[i] = provider.getKey("key1")
+		}(i)
+	}
+	wg.Wait()
+	for i := range keys {
+		s.NoError(errs[i])
+		s.Equal(key1.N, keys[i].N)
+	}
+	s.Equal(int32(1), atomic.LoadInt32(&s.jwksFetches))
+}
+
+func (s *jwksKeyProviderSuite) newProvider(config *Config) *jwksKeyProvider {
+	return newJWKSKeyProvider(config, s.timeSource, loggerimpl.NewDevelopmentForTest(s.Suite))
+}
+
+func (s *jwksKeyProviderSuite) generateKey() *rsa.PublicKey {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	s.NoError(err)
+	return &key.PublicKey
+}
+
+func toJSONWebKey(keyID string, key *rsa.PublicKey) jsonWebKey {
+	return jsonWebKey{
+		KeyType: "RSA",
+		KeyID:   keyID,
+		Use:     "sig",
+		N:       base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+		E:       base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+	}
+}
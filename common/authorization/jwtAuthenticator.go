@@ -0,0 +1,202 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package authorization
+
+import (
+	"context"
+	"crypto"
+	"crypto/rsa"
+	// register the hash functions used by the supported signing algorithms
+	_ "crypto/sha256"
+	_ "crypto/sha512"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/clock"
+	"github.com/uber/cadence/common/log"
+	"go.uber.org/yarpc"
+)
+
+const (
+	bearerPrefix         = "bearer "
+	defaultIdentityClaim = "sub"
+	// allowedClockSkew is the leeway given when validating the token expiration and not before times
+	allowedClockSkew = time.Minute
+)
+
+var (
+	errTokenMalformed     = errors.New("bearer token is malformed")
+	errTokenAlgorithm     = errors.New("bearer token signing algorithm is not supported")
+	errTokenSignature     = errors.New("bearer token signature is invalid")
+	errTokenExpired       = errors.New("bearer token is expired")
+	errTokenNotValidYet   = errors.New("bearer token is not valid yet")
+	errTokenIssuer        = errors.New("bearer token issuer is invalid")
+	errTokenAudience      = errors.New("bearer token audience is invalid")
+	errTokenIdentityClaim = errors.New("bearer token identity claim is missing")
+
+	signingHashes = map[string]crypto.Hash{
+		"RS256": crypto.SHA256,
+		"RS384": crypto.SHA384,
+		"RS512": crypto.SHA512,
+	}
+)
+
+type (
+	jwtAuthenticator struct {
+		config     *Config
+		keys       keyProvider
+		timeSource clock.TimeSource
+	}
+
+	jwtHeader struct {
+		Algorithm string `json:"alg"`
+		KeyID     string `json:"kid"`
+	}
+)
+
+// NewJWTAuthenticator creates an authenticator validating the bearer tokens sent by callers
+// against the keys published by the configured issuer
+func NewJWTAuthenticator(config *Config, logger log.Logger) Authenticator {
+	timeSource := clock.NewRealTimeSource()
+	return newJWTAuthenticator(config, newJWKSKeyProvider(config, timeSource, logger), timeSource)
+}
+
+func newJWTAuthenticator(config *Config, keys keyProvider, timeSource clock.TimeSource) *jwtAuthenticator {
+	return &jwtAuthenticator{
+		config:     config,
+		keys:       keys,
+		timeSource: timeSource,
+	}
+}
+
+// Authenticate validates the bearer token of the call, callers without token are anonymous
+func (a *jwtAuthenticator) Authenticate(ctx context.Context) (*Identity, error) {
+	call := yarpc.CallFromContext(ctx)
+	if call == nil {
+		return nil, nil
+	}
+	header := call.Header(common.AuthorizationHeaderName)
+	if header == "" {
+		return nil, nil
+	}
+	if len(header) <= len(bearerPrefix) || !strings.EqualFold(header[:len(bearerPrefix)], bearerPrefix) {
+		return nil, errTokenMalformed
+	}
+	return a.verify(strings.TrimSpace(header[len(bearerPrefix):]))
+}
+
+func (a *jwtAuthenticator) verify(token string) (*Identity, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, errTokenMalformed
+	}
+
+	var header jwtHeader
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return nil, err
+	}
+	hash, ok := signingHashes[header.Algorithm]
+	if !ok {
+		return nil, errTokenAlgorithm
+	}
+	key, err := a.keys.getKey(header.KeyID)
+	if err != nil {
+		return nil, err
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, errTokenMalformed
+	}
+	hasher := hash.New()
+	hasher.Write([]byte(parts[0] + "." + parts[1]))
+	if err := rsa.VerifyPKCS1v15(key, hash, hasher.Sum(nil), signature); err != nil {
+		return nil, errTokenSignature
+	}
+
+	var claims map[string]interface{}
+	if err := decodeSegment(parts[1], &claims); err != nil {
+		return nil, err
+	}
+	return a.validateClaims(claims)
+}
+
+func (a *jwtAuthenticator) validateClaims(claims map[string]interface{}) (*Identity, error) {
+	now := a.timeSource.Now()
+	expiration, ok := claims["exp"].(float64)
+	if !ok || now.Add(-allowedClockSkew).After(time.Unix(int64(expiration), 0)) {
+		return nil, errTokenExpired
+	}
+	if notBefore, ok := claims["nbf"].(float64); ok && now.Add(allowedClockSkew).Before(time.Unix(int64(notBefore), 0)) {
+		return nil, errTokenNotValidYet
+	}
+
+	issuer, _ := claims["iss"].(string)
+	if a.config.Issuer != "" && issuer != a.config.Issuer {
+		return nil, errTokenIssuer
+	}
+	if a.config.Audience != "" && !hasAudience(claims["aud"], a.config.Audience) {
+		return nil, errTokenAudience
+	}
+
+	identityClaim := a.config.IdentityClaim
+	if identityClaim == "" {
+		identityClaim = defaultIdentityClaim
+	}
+	subject, _ := claims[identityClaim].(string)
+	if subject == "" {
+		return nil, errTokenIdentityClaim
+	}
+
+	return &Identity{
+		Subject: subject,
+		Issuer:  issuer,
+		Claims:  claims,
+	}, nil
+}
+
+func hasAudience(claim interface{}, audience string) bool {
+	switch aud := claim.(type) {
+	case string:
+		return aud == audience
+	case []interface{}:
+		for _, v := range aud {
+			if v == audience {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func decodeSegment(segment string, v interface{}) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return errTokenMalformed
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return errTokenMalformed
+	}
+	return nil
+}
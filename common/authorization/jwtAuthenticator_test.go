@@ -0,0 +1,178 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package authorization
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"github.com/uber/cadence/common/clock"
+)
+
+type (
+	jwtAuthenticatorSuite struct {
+		suite.Suite
+		*require.Assertions
+
+		key           *rsa.PrivateKey
+		now           time.Time
+		authenticator *jwtAuthenticator
+	}
+
+	staticKeyProvider map[string]*rsa.PublicKey
+)
+
+func TestJWTAuthenticatorSuite(t *testing.T) {
+	suite.Run(t, new(jwtAuthenticatorSuite))
+}
+
+func (p staticKeyProvider) getKey(keyID string) (*rsa.PublicKey, error) {
+	if key, ok := p[keyID]; ok {
+		return key, nil
+	}
+	return nil, errUnknownSigningKey
+}
+
+func (s *jwtAuthenticatorSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	s.NoError(err)
+	s.key = key
+	s.now = time.Now()
+	s.authenticator = newJWTAuthenticator(
+		&Config{Issuer: "https://issuer.example.com", Audience: "cadence"},
+		staticKeyProvider{"key1": &key.PublicKey},
+		clock.NewEventTimeSource().Update(s.now),
+	)
+}
+
+func (s *jwtAuthenticatorSuite) TestValidToken() {
+	token := s.signToken("key1", s.validClaims())
+	identity, err := s.authenticator.verify(token)
+	s.NoError(err)
+	s.Equal("user@example.com", identity.Subject)
+	s.Equal("https://issuer.example.com", identity.Issuer)
+}
+
+func (s *jwtAuthenticatorSuite) TestIdentityClaim() {
+	s.authenticator.config.IdentityClaim = "email"
+	claims := s.validClaims()
+	claims["email"] = "other@example.com"
+	identity, err := s.authenticator.verify(s.signToken("key1", claims))
+	s.NoError(err)
+	s.Equal("other@example.com", identity.Subject)
+
+	delete(claims, "email")
+	_, err = s.authenticator.verify(s.signToken("key1", claims))
+	s.Equal(errTokenIdentityClaim, err)
+}
+
+func (s *jwtAuthenticatorSuite) TestAudienceList() {
+	claims := s.validClaims()
+	claims["aud"] = []string{"other", "cadence"}
+	_, err := s.authenticator.verify(s.signToken("key1", claims))
+	s.NoError(err)
+
+	claims["aud"] = []string{"other"}
+	_, err = s.authenticator.verify(s.signToken("key1", claims))
+	s.Equal(errTokenAudience, err)
+}
+
+func (s *jwtAuthenticatorSuite) TestInvalidClaims() {
+	claims := s.validClaims()
+	claims["exp"] = s.now.Add(-time.Hour).Unix()
+	_, err := s.authenticator.verify(s.signToken("key1", claims))
+	s.Equal(errTokenExpired, err)
+
+	claims = s.validClaims()
+	delete(claims, "exp")
+	_, err = s.authenticator.verify(s.signToken("key1", claims))
+	s.Equal(errTokenExpired, err)
+
+	claims = s.validClaims()
+	claims["nbf"] = s.now.Add(time.Hour).Unix()
+	_, err = s.authenticator.verify(s.signToken("key1", claims))
+	s.Equal(errTokenNotValidYet, err)
+
+	claims = s.validClaims()
+	claims["iss"] = "https://other.example.com"
+	_, err = s.authenticator.verify(s.signToken("key1", claims))
+	s.Equal(errTokenIssuer, err)
+}
+
+func (s *jwtAuthenticatorSuite) TestInvalidSignature() {
+	_, err := s.authenticator.verify(s.signToken("key2", s.validClaims()))
+	s.Equal(errUnknownSigningKey, err)
+
+	claims := s.validClaims()
+	parts := strings.Split(s.signToken("key1", claims), ".")
+	claims["sub"] = "admin@example.com"
+	forged := strings.Split(s.signToken("key1", claims), ".")
+	_, err = s.authenticator.verify(parts[0] + "." + forged[1] + "." + parts[2])
+	s.Equal(errTokenSignature, err)
+
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	s.NoError(err)
+	s.key = otherKey
+	_, err = s.authenticator.verify(s.signToken("key1", s.validClaims()))
+	s.Equal(errTokenSignature, err)
+}
+
+func (s *jwtAuthenticatorSuite) TestMalformedToken() {
+	_, err := s.authenticator.verify("not-a-token")
+	s.Equal(errTokenMalformed, err)
+
+	header := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"none"}`))
+	_, err = s.authenticator.verify(header + ".e30.")
+	s.Equal(errTokenAlgorithm, err)
+}
+
+func (s *jwtAuthenticatorSuite) validClaims() map[string]interface{} {
+	return map[string]interface{}{
+		"iss": "https://issuer.example.com",
+		"aud": "cadence",
+		"sub": "user@example.com",
+		"exp": s.now.Add(time.Hour).Unix(),
+	}
+}
+
+func (s *jwtAuthenticatorSuite) signToken(keyID string, claims map[string]interface{}) string {
+	header, err := json.Marshal(jwtHeader{Algorithm: "RS256", KeyID: keyID})
+	s.NoError(err)
+	payload, err := json.Marshal(claims)
+	s.NoError(err)
+
+	signingInput := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	digest := sha256.Sum256([]byte(signingInput))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, s.key, crypto.SHA256, digest[:])
+	s.NoError(err)
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
@@ -21,6 +21,7 @@
 package backoff
 
 import (
+	"fmt"
 	"math"
 	"time"
 
@@ -31,41 +32,84 @@ import (
 // NoBackoff is used to represent backoff when no cron backoff is needed
 const NoBackoff = time.Duration(-1)
 
-// ValidateSchedule validates a cron schedule spec
+// ValidateSchedule validates a cron schedule spec, it must be a standard 5 fields spec or a descriptor
 func ValidateSchedule(cronSchedule string) error {
 	if cronSchedule == "" {
 		return nil
 	}
-	if _, err := cron.Parse(cronSchedule); err != nil {
-		return &workflow.BadRequestError{Message: "Invalid CronSchedule."}
+	if _, err := cron.ParseStandard(cronSchedule); err != nil {
+		return &workflow.BadRequestError{Message: fmt.Sprintf("Invalid CronSchedule %q: %v.", cronSchedule, err)}
+	}
+	return nil
+}
+
+// ValidateScheduleTimezone validates the time zone of a cron schedule, it must be an IANA time zone name and
+// can only be set along a cron schedule
+func ValidateScheduleTimezone(cronSchedule string, timezone string) error {
+	if timezone == "" {
+		return nil
+	}
+	if cronSchedule == "" {
+		return &workflow.BadRequestError{Message: "CronTimezone is set without a CronSchedule."}
+	}
+	if _, err := time.LoadLocation(timezone); err != nil {
+		return &workflow.BadRequestError{Message: fmt.Sprintf("Invalid CronTimezone %q: %v.", timezone, err)}
 	}
 	return nil
 }
 
 // GetBackoffForNextSchedule calculates the backoff time for the next run given
-// a cronSchedule and current time
-func GetBackoffForNextSchedule(cronSchedule string, nowTime time.Time) time.Duration {
+// a cronSchedule evaluated in the timezone, UTC if empty, and current time
+func GetBackoffForNextSchedule(cronSchedule string, timezone string, nowTime time.Time) time.Duration {
 	if len(cronSchedule) == 0 {
 		return NoBackoff
 	}
 
-	schedule, err := cron.ParseStandard(cronSchedule)
+	schedule, location, err := parseSchedule(cronSchedule, timezone)
 	if err != nil {
 		return NoBackoff
 	}
 
-	nowTime = nowTime.In(time.UTC)
+	nowTime = nowTime.In(location)
 	backoffInterval := schedule.Next(nowTime).Sub(nowTime)
 	roundedInterval := time.Second * time.Duration(math.Ceil(backoffInterval.Seconds()))
 	return roundedInterval
 }
 
 // GetBackoffForNextScheduleInSeconds calculates the backoff time in seconds for the
-// next run given a cronSchedule and current time
-func GetBackoffForNextScheduleInSeconds(cronSchedule string, nowTime time.Time) int32 {
-	backoffDuration := GetBackoffForNextSchedule(cronSchedule, nowTime)
+// next run given a cronSchedule evaluated in the timezone, UTC if empty, and current time
+func GetBackoffForNextScheduleInSeconds(cronSchedule string, timezone string, nowTime time.Time) int32 {
+	backoffDuration := GetBackoffForNextSchedule(cronSchedule, timezone, nowTime)
 	if backoffDuration == NoBackoff {
 		return 0
 	}
 	return int32(math.Ceil(backoffDuration.Seconds()))
 }
+
+// GetNextScheduleTime returns the first time strictly after the given time at which the cronSchedule fires,
+// or the zero time if the cronSchedule is not valid
+func GetNextScheduleTime(cronSchedule string, after time.Time) time.Time {
+	if len(cronSchedule) == 0 {
+		return time.Time{}
+	}
+
+	schedule, err := cron.ParseStandard(cronSchedule)
+	if err != nil {
+		return time.Time{}
+	}
+	return schedule.Next(after.In(time.UTC))
+}
+
+func parseSchedule(cronSchedule string, timezone string) (cron.Schedule, *time.Location, error) {
+	schedule, err := cron.ParseStandard(cronSchedule)
+	if err != nil {
+		return nil, nil, err
+	}
+	location := time.UTC
+	if timezone != "" {
+		if location, err = time.LoadLocation(timezone); err != nil {
+			return nil, nil, err
+		}
+	}
+	return schedule, location, nil
+}
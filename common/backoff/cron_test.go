@@ -33,29 +33,69 @@ func Test_NextCronSchedule(t *testing.T) {
 	// every day cron
 	now, _ := time.Parse(time.RFC3339, "2018-12-17T08:00:00-08:00") // UTC: 2018-12-17 16:00:00 +0000 UTC
 	cronSpec := "0 10 * * *"
-	backoff := GetBackoffForNextSchedule(cronSpec, now)
+	backoff := GetBackoffForNextSchedule(cronSpec, "", now)
 	a.Equal(time.Hour*18, backoff)
-	backoff = GetBackoffForNextSchedule(cronSpec, now.Add(backoff))
+	backoff = GetBackoffForNextSchedule(cronSpec, "", now.Add(backoff))
 	a.Equal(time.Hour*24, backoff)
 
 	// every hour cron
 	now, _ = time.Parse(time.RFC3339, "2018-12-17T08:08:00+00:00")
 	cronSpec = "0 * * * *"
-	backoff = GetBackoffForNextSchedule(cronSpec, now)
+	backoff = GetBackoffForNextSchedule(cronSpec, "", now)
 	a.Equal(time.Minute*52, backoff)
-	backoff = GetBackoffForNextSchedule(cronSpec, now.Add(backoff))
+	backoff = GetBackoffForNextSchedule(cronSpec, "", now.Add(backoff))
 	a.Equal(time.Hour, backoff)
 
 	// every minute cron
 	now, _ = time.Parse(time.RFC3339, "2018-12-17T08:08:18+00:00")
 	cronSpec = "* * * * *"
-	backoff = GetBackoffForNextSchedule(cronSpec, now)
+	backoff = GetBackoffForNextSchedule(cronSpec, "", now)
 	a.Equal(time.Second*42, backoff)
-	backoff = GetBackoffForNextSchedule(cronSpec, now.Add(backoff))
+	backoff = GetBackoffForNextSchedule(cronSpec, "", now.Add(backoff))
 	a.Equal(time.Minute, backoff)
 
 	// invalid cron spec
 	cronSpec = "invalid-cron-spec"
-	backoff = GetBackoffForNextSchedule(cronSpec, now)
+	backoff = GetBackoffForNextSchedule(cronSpec, "", now)
 	a.Equal(NoBackoff, backoff)
 }
+
+func Test_NextCronScheduleInTimezone(t *testing.T) {
+	a := assert.New(t)
+
+	// every day at 10 in New York, which is 15:00 UTC in winter
+	now, _ := time.Parse(time.RFC3339, "2018-12-17T08:00:00+00:00")
+	backoff := GetBackoffForNextSchedule("0 10 * * *", "America/New_York", now)
+	a.Equal(time.Hour*7, backoff)
+	a.Equal(int32(7*60*60), GetBackoffForNextScheduleInSeconds("0 10 * * *", "America/New_York", now))
+
+	a.Equal(NoBackoff, GetBackoffForNextSchedule("0 10 * * *", "Invalid/Timezone", now))
+}
+
+func Test_ValidateSchedule(t *testing.T) {
+	a := assert.New(t)
+
+	a.NoError(ValidateSchedule(""))
+	a.NoError(ValidateSchedule("0 10 * * *"))
+	a.NoError(ValidateSchedule("@every 5s"))
+	a.Error(ValidateSchedule("invalid-cron-spec"))
+	// the seconds field is not part of the standard spec used to schedule the runs
+	a.Error(ValidateSchedule("0 0 10 * * *"))
+
+	a.NoError(ValidateScheduleTimezone("", ""))
+	a.NoError(ValidateScheduleTimezone("0 10 * * *", "Europe/Paris"))
+	a.Error(ValidateScheduleTimezone("", "Europe/Paris"))
+	a.Error(ValidateScheduleTimezone("0 10 * * *", "Invalid/Timezone"))
+}
+
+func Test_NextScheduleTime(t *testing.T) {
+	a := assert.New(t)
+
+	after, _ := time.Parse(time.RFC3339, "2018-12-17T08:08:18+00:00")
+	next := GetNextScheduleTime("0 * * * *", after)
+	a.Equal("2018-12-17T09:00:00Z", next.Format(time.RFC3339))
+	a.Equal("2018-12-17T10:00:00Z", GetNextScheduleTime("0 * * * *", next).Format(time.RFC3339))
+
+	a.True(GetNextScheduleTime("invalid-cron-spec", after).IsZero())
+	a.True(GetNextScheduleTime("", after).IsZero())
+}
@@ -23,9 +23,14 @@ package blob
 import (
 	"bytes"
 	"compress/gzip"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/binary"
 	"errors"
 	"fmt"
 	"github.com/uber/cadence/common"
+	"io"
 	"io/ioutil"
 	"strings"
 )
@@ -34,6 +39,7 @@ const (
 	wrappersTag    = "wrappers"
 	encodingKey    = "encoding"
 	compressionKey = "compression"
+	encryptionKey  = "encryption"
 )
 
 // the following constants are all encoding formats that have ever been used
@@ -55,8 +61,19 @@ type (
 
 	// WrappingLayers indicates the values of every wrapping layer of a blob, nil fields indicate that blob was not wrapped with layer.
 	WrappingLayers struct {
-		EncodingFormat *string
-		Compression    *string
+		EncodingFormat     *string
+		Compression        *string
+		EncryptionKeyAlias *string
+	}
+
+	// KeyProvider gives access to the key management service holding the keys blobs are encrypted with.
+	// Every blob is encrypted with a new data key, stored next to the blob encrypted under the key behind
+	// the alias, so the key behind an alias can be rotated without re-encrypting the existing blobs.
+	KeyProvider interface {
+		// GenerateDataKey returns a new data key, in plaintext and encrypted under the key behind keyAlias
+		GenerateDataKey(keyAlias string) (plaintext []byte, ciphertext []byte, err error)
+		// DecryptDataKey returns the plaintext of a data key generated for keyAlias
+		DecryptDataKey(keyAlias string, ciphertext []byte) ([]byte, error)
 	}
 )
 
@@ -95,6 +112,37 @@ func GzipCompressed() WrapFn {
 	}
 }
 
+// Encrypted returns a WrapFn used to encrypt body using AES-GCM with a data key generated for keyAlias
+// and indicates that at the encryption layer the key behind keyAlias was used
+func Encrypted(keyAlias string, keys KeyProvider) WrapFn {
+	return func(b *Blob) error {
+		wrappers := common.StringPtr(b.Tags[wrappersTag])
+		if exists(wrappers, encryptionKey) {
+			return errors.New("encryption layer already specified")
+		}
+		if !IsValidKeyAlias(keyAlias) {
+			return fmt.Errorf("invalid encryption key alias: %v", keyAlias)
+		}
+		plaintextKey, encryptedKey, err := keys.GenerateDataKey(keyAlias)
+		if err != nil {
+			return err
+		}
+		eBody, err := encrypt(plaintextKey, encryptedKey, b.Body)
+		if err != nil {
+			return err
+		}
+		push(wrappers, encryptionKey, keyAlias)
+		b.Tags[wrappersTag] = *wrappers
+		b.Body = eBody
+		return nil
+	}
+}
+
+// IsValidKeyAlias returns true if keyAlias can be recorded in the wrapping layers of a blob
+func IsValidKeyAlias(keyAlias string) bool {
+	return len(keyAlias) != 0 && !strings.ContainsAny(keyAlias, mapSeparator+pairSeparator)
+}
+
 // Wrap returns a deep copy of input blob with all wrapping functions applied. Input blob is not modified.
 func Wrap(blob *Blob, functions ...WrapFn) (*Blob, error) {
 	if blob == nil {
@@ -111,7 +159,13 @@ func Wrap(blob *Blob, functions ...WrapFn) (*Blob, error) {
 
 // Unwrap returns a deep copy of input blob with all layers unwrapped. Input blob is not modified.
 // Returned WrappingLayers indicate the layers that were unwrapped and the values of such each layer.
+// Encrypted blobs can only be unwrapped by UnwrapWithKeys.
 func Unwrap(blob *Blob) (*Blob, *WrappingLayers, error) {
+	return UnwrapWithKeys(blob, nil)
+}
+
+// UnwrapWithKeys is the same as Unwrap, the data keys of encrypted blobs are decrypted by keys.
+func UnwrapWithKeys(blob *Blob, keys KeyProvider) (*Blob, *WrappingLayers, error) {
 	wrappingLayers := &WrappingLayers{}
 	if blob == nil {
 		return nil, wrappingLayers, nil
@@ -137,6 +191,16 @@ func Unwrap(blob *Blob) (*Blob, *WrappingLayers, error) {
 				return nil, nil, err
 			}
 			unwrappedBlob.Body = dBody
+		case encryptionKey:
+			if keys == nil {
+				return nil, nil, errors.New("cannot unwrap encrypted blob without a key provider")
+			}
+			wrappingLayers.EncryptionKeyAlias = common.StringPtr(v)
+			dBody, err := decrypt(keys, v, unwrappedBlob.Body)
+			if err != nil {
+				return nil, nil, err
+			}
+			unwrappedBlob.Body = dBody
 		default:
 			return nil, nil, fmt.Errorf("cannot unwrap encountered unknown wrapping layer: %v", k)
 		}
@@ -160,6 +224,55 @@ func decompress(compression string, data []byte) ([]byte, error) {
 	}
 }
 
+// encrypt seals data with the plaintext data key, the result is laid out as
+// the length of the encrypted data key, the encrypted data key, the nonce and the sealed data
+func encrypt(plaintextKey []byte, encryptedKey []byte, data []byte) ([]byte, error) {
+	gcm, err := newGCM(plaintextKey)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+	header := make([]byte, 4, 4+len(encryptedKey)+len(nonce))
+	binary.BigEndian.PutUint32(header, uint32(len(encryptedKey)))
+	header = append(append(header, encryptedKey...), nonce...)
+	return gcm.Seal(header, nonce, data, nil), nil
+}
+
+func decrypt(keys KeyProvider, keyAlias string, data []byte) ([]byte, error) {
+	if len(data) < 4 {
+		return nil, errors.New("cannot decrypt, encrypted body is malformed")
+	}
+	keyLen := int(binary.BigEndian.Uint32(data))
+	data = data[4:]
+	if len(data) < keyLen {
+		return nil, errors.New("cannot decrypt, encrypted body is malformed")
+	}
+	plaintextKey, err := keys.DecryptDataKey(keyAlias, data[:keyLen])
+	if err != nil {
+		return nil, err
+	}
+	data = data[keyLen:]
+	gcm, err := newGCM(plaintextKey)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) < gcm.NonceSize() {
+		return nil, errors.New("cannot decrypt, encrypted body is malformed")
+	}
+	return gcm.Open(nil, data[:gcm.NonceSize()], data[gcm.NonceSize():], nil)
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
 /**
 
 IMPORTANT:
@@ -21,6 +21,8 @@
 package blob
 
 import (
+	"crypto/rand"
+	"errors"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 	"github.com/uber/cadence/common"
@@ -391,6 +393,39 @@ func (s *BlobWrapperSuite) wrappedBlob(tags map[string]string, body []byte, func
 	return wrappedBlob
 }
 
+func (s *BlobWrapperSuite) TestEncryptedWrapFn() {
+	keys := newTestKeyProvider()
+	body := []byte("test-body")
+	wrappedBlob := s.wrappedBlob(map[string]string{"user_tag_key": "user_tag_value"}, body, JSONEncoded(), GzipCompressed(), Encrypted("alias/tenant", keys))
+	s.Equal("encryption:alias/tenant,compression:compress/gzip,encoding:json,", wrappedBlob.Tags[wrappersTag])
+	s.NotContains(string(wrappedBlob.Body), string(body))
+
+	// the same body is never encrypted twice the same way
+	otherBlob := s.wrappedBlob(map[string]string{"user_tag_key": "user_tag_value"}, body, JSONEncoded(), GzipCompressed(), Encrypted("alias/tenant", keys))
+	s.NotEqual(wrappedBlob.Body, otherBlob.Body)
+
+	_, _, err := Unwrap(wrappedBlob)
+	s.Error(err)
+
+	unwrappedBlob, wrappingLayers, err := UnwrapWithKeys(wrappedBlob, keys)
+	s.NoError(err)
+	s.Equal(body, unwrappedBlob.Body)
+	s.Equal(map[string]string{"user_tag_key": "user_tag_value"}, unwrappedBlob.Tags)
+	s.Equal("alias/tenant", *wrappingLayers.EncryptionKeyAlias)
+	s.Equal(GzipCompression, *wrappingLayers.Compression)
+	s.Equal(JSONEncoding, *wrappingLayers.EncodingFormat)
+
+	// tampering with the body is detected
+	wrappedBlob.Body[len(wrappedBlob.Body)-1]++
+	_, _, err = UnwrapWithKeys(wrappedBlob, keys)
+	s.Error(err)
+
+	_, err = Wrap(NewBlob(body, map[string]string{}), Encrypted("alias/tenant", keys), Encrypted("alias/tenant", keys))
+	s.Error(err)
+	_, err = Wrap(NewBlob(body, map[string]string{}), Encrypted("alias:tenant", keys))
+	s.Error(err)
+}
+
 func (s *BlobWrapperSuite) TestWrapperStack() {
 	// pop of empty stack returns error
 	stack := common.StringPtr("")
@@ -447,3 +482,32 @@ func (s *BlobWrapperSuite) TestWrapperStack() {
 	s.NoError(err)
 	s.Equal("k1:v1,", *stack)
 }
+
+type testKeyProvider struct {
+	dataKeys map[string][]byte
+}
+
+func newTestKeyProvider() *testKeyProvider {
+	return &testKeyProvider{dataKeys: make(map[string][]byte)}
+}
+
+func (p *testKeyProvider) GenerateDataKey(keyAlias string) ([]byte, []byte, error) {
+	plaintext := make([]byte, 32)
+	ciphertext := make([]byte, 16)
+	if _, err := rand.Read(plaintext); err != nil {
+		return nil, nil, err
+	}
+	if _, err := rand.Read(ciphertext); err != nil {
+		return nil, nil, err
+	}
+	p.dataKeys[keyAlias+string(ciphertext)] = plaintext
+	return plaintext, ciphertext, nil
+}
+
+func (p *testKeyProvider) DecryptDataKey(keyAlias string, ciphertext []byte) ([]byte, error) {
+	plaintext, ok := p.dataKeys[keyAlias+string(ciphertext)]
+	if !ok {
+		return nil, errors.New("unknown data key")
+	}
+	return plaintext, nil
+}
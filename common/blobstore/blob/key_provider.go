@@ -0,0 +1,111 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package blob
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+)
+
+const (
+	// masterKeySize is the size of the keys behind the aliases of a static key provider, AES-256 is used
+	masterKeySize = 32
+	dataKeySize   = 32
+)
+
+type (
+	// staticKeyProvider is a KeyProvider whose keys are configured on the hosts, the data keys are
+	// encrypted with AES-GCM under the key behind the alias they are generated for
+	staticKeyProvider struct {
+		masterKeys map[string][]byte
+	}
+)
+
+// NewStaticKeyProvider returns a KeyProvider serving the keys of the given map of key alias to base64
+// encoded 256 bit key. Every host reading or writing encrypted blobs must be configured with the same keys
+func NewStaticKeyProvider(keys map[string]string) (KeyProvider, error) {
+	if len(keys) == 0 {
+		return nil, errors.New("no encryption key is configured")
+	}
+	masterKeys := make(map[string][]byte, len(keys))
+	for alias, encodedKey := range keys {
+		if !IsValidKeyAlias(alias) {
+			return nil, fmt.Errorf("invalid encryption key alias: %v", alias)
+		}
+		key, err := base64.StdEncoding.DecodeString(encodedKey)
+		if err != nil {
+			return nil, fmt.Errorf("encryption key %v is not base64 encoded: %v", alias, err)
+		}
+		if len(key) != masterKeySize {
+			return nil, fmt.Errorf("encryption key %v must be %v bytes long", alias, masterKeySize)
+		}
+		masterKeys[alias] = key
+	}
+	return &staticKeyProvider{masterKeys: masterKeys}, nil
+}
+
+// GenerateDataKey returns a new data key, in plaintext and encrypted under the key behind keyAlias
+func (p *staticKeyProvider) GenerateDataKey(keyAlias string) ([]byte, []byte, error) {
+	masterKey, err := p.masterKey(keyAlias)
+	if err != nil {
+		return nil, nil, err
+	}
+	plaintext := make([]byte, dataKeySize)
+	if _, err := io.ReadFull(rand.Reader, plaintext); err != nil {
+		return nil, nil, err
+	}
+	gcm, err := newGCM(masterKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, nil, err
+	}
+	return plaintext, gcm.Seal(nonce, nonce, plaintext, []byte(keyAlias)), nil
+}
+
+// DecryptDataKey returns the plaintext of a data key generated for keyAlias
+func (p *staticKeyProvider) DecryptDataKey(keyAlias string, ciphertext []byte) ([]byte, error) {
+	masterKey, err := p.masterKey(keyAlias)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := newGCM(masterKey)
+	if err != nil {
+		return nil, err
+	}
+	if len(ciphertext) < gcm.NonceSize() {
+		return nil, errors.New("cannot decrypt, encrypted data key is malformed")
+	}
+	return gcm.Open(nil, ciphertext[:gcm.NonceSize()], ciphertext[gcm.NonceSize():], []byte(keyAlias))
+}
+
+func (p *staticKeyProvider) masterKey(keyAlias string) ([]byte, error) {
+	key, ok := p.masterKeys[keyAlias]
+	if !ok {
+		return nil, fmt.Errorf("unknown encryption key alias: %v", keyAlias)
+	}
+	return key, nil
+}
@@ -0,0 +1,90 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package blob
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type KeyProviderSuite struct {
+	*require.Assertions
+	suite.Suite
+}
+
+func TestKeyProviderSuite(t *testing.T) {
+	suite.Run(t, new(KeyProviderSuite))
+}
+
+func (s *KeyProviderSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+}
+
+func (s *KeyProviderSuite) TestNewStaticKeyProvider() {
+	validKey := base64.StdEncoding.EncodeToString([]byte(strings.Repeat("k", masterKeySize)))
+	testCases := []struct {
+		keys        map[string]string
+		expectError bool
+	}{
+		{keys: nil, expectError: true},
+		{keys: map[string]string{"alias/a": "not base64!"}, expectError: true},
+		{keys: map[string]string{"alias/a": base64.StdEncoding.EncodeToString([]byte("short"))}, expectError: true},
+		{keys: map[string]string{"alias:a": validKey}, expectError: true},
+		{keys: map[string]string{"alias/a": validKey}, expectError: false},
+	}
+	for _, tc := range testCases {
+		_, err := NewStaticKeyProvider(tc.keys)
+		s.Equal(tc.expectError, err != nil)
+	}
+}
+
+func (s *KeyProviderSuite) TestDataKeyRoundTrip() {
+	keys, err := NewStaticKeyProvider(map[string]string{
+		"alias/a": base64.StdEncoding.EncodeToString([]byte(strings.Repeat("a", masterKeySize))),
+		"alias/b": base64.StdEncoding.EncodeToString([]byte(strings.Repeat("b", masterKeySize))),
+	})
+	s.NoError(err)
+
+	plaintext, ciphertext, err := keys.GenerateDataKey("alias/a")
+	s.NoError(err)
+	s.Len(plaintext, dataKeySize)
+	s.NotContains(string(ciphertext), string(plaintext))
+
+	decrypted, err := keys.DecryptDataKey("alias/a", ciphertext)
+	s.NoError(err)
+	s.Equal(plaintext, decrypted)
+
+	_, err = keys.DecryptDataKey("alias/b", ciphertext)
+	s.Error(err)
+	_, _, err = keys.GenerateDataKey("alias/unknown")
+	s.Error(err)
+
+	blob := NewBlob([]byte("history"), map[string]string{})
+	wrapped, err := Wrap(blob, Encrypted("alias/a", keys))
+	s.NoError(err)
+	unwrapped, _, err := UnwrapWithKeys(wrapped, keys)
+	s.NoError(err)
+	s.Equal([]byte("history"), unwrapped.Body)
+}
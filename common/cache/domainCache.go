@@ -77,6 +77,8 @@ type (
 		GetDomainID(name string) (string, error)
 		GetAllDomain() map[string]*DomainCacheEntry
 		GetCacheSize() (sizeOfCacheByName int64, sizeOfCacheByID int64)
+		GetDomainNotificationVersion() int64
+		Refresh() error
 	}
 
 	domainCache struct {
@@ -93,6 +95,12 @@ type (
 		callbackLock     sync.Mutex
 		prepareCallbacks map[int]PrepareCallbackFn
 		callbacks        map[int]CallbackFn
+
+		// refreshLock serializes the background and the forced refreshes
+		refreshLock sync.Mutex
+		// notificationVersion is the domain notification version of the last refresh,
+		// all domain changes before this version are loaded into the cache
+		notificationVersion int64
 	}
 
 	// DomainCacheEntries is DomainCacheEntry slice
@@ -216,6 +224,16 @@ func (c *domainCache) GetCacheSize() (sizeOfCacheByName int64, sizeOfCacheByID i
 	return int64(c.cacheByID.Load().(Cache).Size()), int64(c.cacheNameToID.Load().(Cache).Size())
 }
 
+// GetDomainNotificationVersion returns the domain notification version the cache is caught up to
+func (c *domainCache) GetDomainNotificationVersion() int64 {
+	return atomic.LoadInt64(&c.notificationVersion)
+}
+
+// Refresh reloads the domains from the v2 table without waiting for the background refresh
+func (c *domainCache) Refresh() error {
+	return c.refreshDomains()
+}
+
 // Start start the background refresh of domain
 func (c *domainCache) Start() {
 	if !atomic.CompareAndSwapInt32(&c.status, domainCacheInitialized, domainCacheStarted) {
@@ -343,6 +361,9 @@ func (c *domainCache) refreshLoop() {
 // this function only refresh the domains in the v2 table
 // the domains in the v1 table will be refreshed if cache is stale
 func (c *domainCache) refreshDomains() error {
+	c.refreshLock.Lock()
+	defer c.refreshLock.Unlock()
+
 	// first load the metadata record, then load domains
 	// this can guarantee that domains in the cache are not updated more than metadata record
 	metadata, err := c.metadataMgr.GetMetadata()
@@ -414,6 +435,7 @@ UpdateLoop:
 	c.triggerDomainChangePrepareCallbackLocked()
 	c.cacheByID.Store(newCacheByID)
 	c.cacheNameToID.Store(newCacheNameToID)
+	atomic.StoreInt64(&c.notificationVersion, domainNotificationVersion)
 	c.triggerDomainChangeCallbackLocked(prevEntries, nextEntries)
 	return nil
 }
@@ -609,11 +631,18 @@ func (entry *DomainCacheEntry) duplicate() *DomainCacheEntry {
 		result.info.Data[k] = v
 	}
 	result.config = &persistence.DomainConfig{
-		Retention:      entry.config.Retention,
-		EmitMetric:     entry.config.EmitMetric,
-		ArchivalBucket: entry.config.ArchivalBucket,
-		ArchivalStatus: entry.config.ArchivalStatus,
-		BadBinaries:    copyResetBinary(entry.config.BadBinaries),
+		Retention:          entry.config.Retention,
+		EmitMetric:         entry.config.EmitMetric,
+		ArchivalBucket:     entry.config.ArchivalBucket,
+		ArchivalStatus:     entry.config.ArchivalStatus,
+		BadBinaries:        copyResetBinary(entry.config.BadBinaries),
+		RetentionRunCount:  entry.config.RetentionRunCount,
+		TimeoutPolicy:      entry.config.TimeoutPolicy,
+		EncryptionKeyAlias: entry.config.EncryptionKeyAlias,
+		Residency: persistence.DomainResidency{
+			Tag:             entry.config.Residency.Tag,
+			AllowedClusters: append([]string{}, entry.config.Residency.AllowedClusters...),
+		},
 	}
 	result.replicationConfig = &persistence.DomainReplicationConfig{
 		ActiveClusterName: entry.replicationConfig.ActiveClusterName,
@@ -21,6 +21,7 @@
 package cache
 
 import (
+	"errors"
 	"sync"
 	"testing"
 	"time"
@@ -189,6 +190,24 @@ func (s *domainCacheSuite) TestListDomain() {
 	}, allDomains)
 }
 
+func (s *domainCacheSuite) TestRefresh_NotificationVersion() {
+	listRequest := &persistence.ListDomainsRequest{PageSize: domainCacheRefreshPageSize}
+	s.metadataMgr.On("GetMetadata").Return(&persistence.GetMetadataResponse{NotificationVersion: 5}, nil).Once()
+	s.metadataMgr.On("ListDomains", listRequest).Return(&persistence.ListDomainsResponse{}, nil).Once()
+	s.Equal(int64(0), s.domainCache.GetDomainNotificationVersion())
+	s.Nil(s.domainCache.Refresh())
+	s.Equal(int64(5), s.domainCache.GetDomainNotificationVersion())
+
+	s.metadataMgr.On("GetMetadata").Return(nil, errors.New("some random error")).Once()
+	s.NotNil(s.domainCache.Refresh())
+	s.Equal(int64(5), s.domainCache.GetDomainNotificationVersion())
+
+	s.metadataMgr.On("GetMetadata").Return(&persistence.GetMetadataResponse{NotificationVersion: 9}, nil).Once()
+	s.metadataMgr.On("ListDomains", listRequest).Return(&persistence.ListDomainsResponse{}, nil).Once()
+	s.Nil(s.domainCache.Refresh())
+	s.Equal(int64(9), s.domainCache.GetDomainNotificationVersion())
+}
+
 func (s *domainCacheSuite) TestGetDomain_NonLoaded_GetByName() {
 	s.clusterMetadata.On("IsGlobalDomainEnabled").Return(true)
 	domainRecord := &persistence.GetDomainResponse{
@@ -82,3 +82,15 @@ func (t *ThriftRWEncoder) Decode(binary []byte, val ThriftObject) error {
 
 	return val.FromWire(wireVal)
 }
+
+// Payload returns the thrift binary encoded payload of data encoded by Encode, without its preamble,
+// so that callers can scan the payload without decoding it
+func (t *ThriftRWEncoder) Payload(binary []byte) ([]byte, error) {
+	if len(binary) < 1 {
+		return nil, MissingBinaryEncodingVersion
+	}
+	if binary[0] != preambleVersion0 {
+		return nil, InvalidBinaryEncodingVersion
+	}
+	return binary[1:], nil
+}
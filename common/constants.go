@@ -83,6 +83,8 @@ const (
 const (
 	// VisibilityAppName is used to find kafka topics and ES indexName for visibility
 	VisibilityAppName = "visibility"
+	// PinotVisibilityAppName is used to find the kafka topic streaming visibility records to Pinot
+	PinotVisibilityAppName = "pinot-visibility"
 )
 
 const (
@@ -110,4 +112,23 @@ const (
 const (
 	// DefaultTransactionSizeLimit is the largest allowed transaction size to persistence
 	DefaultTransactionSizeLimit = 14 * 1024 * 1024
+	// DefaultExecutionTransactionStatementLimit is the largest allowed number of statements in a single workflow execution write
+	DefaultExecutionTransactionStatementLimit = 2000
+	// DefaultExecutionTransactionSizeLimit is the largest allowed size of a single workflow execution write to persistence
+	DefaultExecutionTransactionSizeLimit = 14 * 1024 * 1024
+)
+
+// Stages of the journey of an activity or decision task, logged by matching and history with the domain ID,
+// workflow ID, run ID and schedule ID of the task so that its journey can be followed across services
+const (
+	// TaskJourneyStageCreated is when the task is added to a task list
+	TaskJourneyStageCreated = "created"
+	// TaskJourneyStagePersisted is when the task is written to the backlog of a task list
+	TaskJourneyStagePersisted = "persisted"
+	// TaskJourneyStageMatched is when the task is handed to a poller
+	TaskJourneyStageMatched = "matched"
+	// TaskJourneyStageStarted is when the task is recorded as started in history
+	TaskJourneyStageStarted = "started"
+	// TaskJourneyStageCompleted is when history records the completion of the task
+	TaskJourneyStageCompleted = "completed"
 )
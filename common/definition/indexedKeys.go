@@ -24,17 +24,18 @@ import "github.com/uber/cadence/.gen/go/shared"
 
 // valid indexed fields on ES
 const (
-	DomainID      = "DomainID"
-	WorkflowID    = "WorkflowID"
-	RunID         = "RunID"
-	WorkflowType  = "WorkflowType"
-	StartTime     = "StartTime"
-	ExecutionTime = "ExecutionTime"
-	CloseTime     = "CloseTime"
-	CloseStatus   = "CloseStatus"
-	HistoryLength = "HistoryLength"
-	Encoding      = "Encoding"
-	KafkaKey      = "KafkaKey"
+	DomainID            = "DomainID"
+	WorkflowID          = "WorkflowID"
+	RunID               = "RunID"
+	WorkflowType        = "WorkflowType"
+	StartTime           = "StartTime"
+	ExecutionTime       = "ExecutionTime"
+	CloseTime           = "CloseTime"
+	CloseStatus         = "CloseStatus"
+	HistoryLength       = "HistoryLength"
+	Encoding            = "Encoding"
+	KafkaKey            = "KafkaKey"
+	FirstExecutionRunID = "FirstExecutionRunID"
 
 	CustomStringField   = "CustomStringField"
 	CustomKeywordField  = "CustomKeywordField"
@@ -44,6 +45,11 @@ const (
 	CustomDatetimeField = "CustomDatetimeField"
 )
 
+// search attributes maintained by Cadence, stored along the custom search attributes
+const (
+	SLAExceeded = "SLAExceeded"
+)
+
 // valid non-indexed fields on ES
 const (
 	Memo = "Memo"
@@ -67,6 +73,9 @@ func createDefaultIndexedKeys() map[string]interface{} {
 	for k, v := range systemIndexedKeys {
 		defaultIndexedKeys[k] = v
 	}
+	for k, v := range maintainedIndexedKeys {
+		defaultIndexedKeys[k] = v
+	}
 	return defaultIndexedKeys
 }
 
@@ -77,15 +86,16 @@ func GetDefaultIndexedKeys() map[string]interface{} {
 
 // systemIndexedKeys is Cadence created visibility keys
 var systemIndexedKeys = map[string]interface{}{
-	DomainID:      shared.IndexedValueTypeKeyword,
-	WorkflowID:    shared.IndexedValueTypeKeyword,
-	RunID:         shared.IndexedValueTypeKeyword,
-	WorkflowType:  shared.IndexedValueTypeKeyword,
-	StartTime:     shared.IndexedValueTypeInt,
-	ExecutionTime: shared.IndexedValueTypeInt,
-	CloseTime:     shared.IndexedValueTypeInt,
-	CloseStatus:   shared.IndexedValueTypeInt,
-	HistoryLength: shared.IndexedValueTypeInt,
+	DomainID:            shared.IndexedValueTypeKeyword,
+	WorkflowID:          shared.IndexedValueTypeKeyword,
+	RunID:               shared.IndexedValueTypeKeyword,
+	WorkflowType:        shared.IndexedValueTypeKeyword,
+	StartTime:           shared.IndexedValueTypeInt,
+	ExecutionTime:       shared.IndexedValueTypeInt,
+	CloseTime:           shared.IndexedValueTypeInt,
+	CloseStatus:         shared.IndexedValueTypeInt,
+	HistoryLength:       shared.IndexedValueTypeInt,
+	FirstExecutionRunID: shared.IndexedValueTypeKeyword,
 }
 
 // IsSystemIndexedKey return true is key is system added
@@ -93,3 +103,14 @@ func IsSystemIndexedKey(key string) bool {
 	_, ok := systemIndexedKeys[key]
 	return ok
 }
+
+// maintainedIndexedKeys are search attributes set by Cadence on the workflow, workflows cannot upsert them
+var maintainedIndexedKeys = map[string]interface{}{
+	SLAExceeded: shared.IndexedValueTypeBool,
+}
+
+// IsMaintainedIndexedKey return true if key is a search attribute maintained by Cadence
+func IsMaintainedIndexedKey(key string) bool {
+	_, ok := maintainedIndexedKeys[key]
+	return ok
+}
@@ -24,17 +24,18 @@ import "github.com/uber/cadence/.gen/go/indexer"
 
 // All legal fields allowed in elastic search index
 const (
-	DomainID      = "DomainID"
-	WorkflowID    = "WorkflowID"
-	RunID         = "RunID"
-	WorkflowType  = "WorkflowType"
-	StartTime     = "StartTime"
-	ExecutionTime = "ExecutionTime"
-	CloseTime     = "CloseTime"
-	CloseStatus   = "CloseStatus"
-	HistoryLength = "HistoryLength"
-	Memo          = "Memo"
-	Encoding      = "Encoding"
+	DomainID            = "DomainID"
+	WorkflowID          = "WorkflowID"
+	RunID               = "RunID"
+	WorkflowType        = "WorkflowType"
+	StartTime           = "StartTime"
+	ExecutionTime       = "ExecutionTime"
+	CloseTime           = "CloseTime"
+	CloseStatus         = "CloseStatus"
+	HistoryLength       = "HistoryLength"
+	Memo                = "Memo"
+	Encoding            = "Encoding"
+	FirstExecutionRunID = "FirstExecutionRunID"
 
 	KafkaKey = "KafkaKey"
 )
@@ -87,6 +87,13 @@ func (s *searchAttributesValidatorSuite) TestValidateSearchAttributes() {
 	err = validator.ValidateSearchAttributes(attr, domain)
 	s.Equal(`BadRequestError{Message: StartTime is read-only Cadence reservered attribute}`, err.Error())
 
+	fields = map[string][]byte{
+		"SLAExceeded": []byte(`true`),
+	}
+	attr.IndexedFields = fields
+	err = validator.ValidateSearchAttributes(attr, domain)
+	s.Equal(`BadRequestError{Message: SLAExceeded is read-only Cadence reservered attribute}`, err.Error())
+
 	fields = map[string][]byte{
 		"CustomKeywordField": []byte(`123456`),
 	}
@@ -0,0 +1,122 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package common
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+)
+
+const (
+	// signedTaskTokenVersion is the leading byte of a signed task token, unsigned
+	// tokens are plain JSON and always start with '{'
+	signedTaskTokenVersion byte = 1
+
+	signedTaskTokenHeaderSize = 1 + sha256.Size
+)
+
+var (
+	// ErrTaskTokenNotSigned is returned when an unsigned task token is validated
+	ErrTaskTokenNotSigned = errors.New("task token is not signed")
+	// ErrTaskTokenInvalidSignature is returned when a task token signature does not match any signing key
+	ErrTaskTokenInvalidSignature = errors.New("task token signature is invalid")
+	// ErrTaskTokenUnknownVersion is returned when a task token has an unsupported version
+	ErrTaskTokenUnknownVersion = errors.New("task token version is unknown")
+)
+
+type (
+	hmacTaskTokenSerializer struct {
+		jsonTaskTokenSerializer
+		keys          [][]byte
+		allowUnsigned bool
+	}
+)
+
+// NewHMACTaskTokenSerializer creates a TaskTokenSerializer which signs task tokens with HMAC-SHA256.
+// New tokens are signed with the first key, while tokens signed with any of the keys are accepted,
+// so keys can be rotated without invalidating outstanding tokens.
+func NewHMACTaskTokenSerializer(keys []string, allowUnsigned bool) TaskTokenSerializer {
+	hmacKeys := make([][]byte, 0, len(keys))
+	for _, key := range keys {
+		hmacKeys = append(hmacKeys, []byte(key))
+	}
+	return &hmacTaskTokenSerializer{
+		keys:          hmacKeys,
+		allowUnsigned: allowUnsigned,
+	}
+}
+
+func (h *hmacTaskTokenSerializer) Serialize(token *TaskToken) ([]byte, error) {
+	payload, err := json.Marshal(token)
+	if err != nil {
+		return nil, err
+	}
+
+	data := make([]byte, 0, signedTaskTokenHeaderSize+len(payload))
+	data = append(data, signedTaskTokenVersion)
+	data = append(data, signTaskToken(h.keys[0], payload)...)
+	return append(data, payload...), nil
+}
+
+func (h *hmacTaskTokenSerializer) Deserialize(data []byte) (*TaskToken, error) {
+	if !isSignedTaskToken(data) {
+		if !h.allowUnsigned {
+			return nil, ErrTaskTokenNotSigned
+		}
+		return h.jsonTaskTokenSerializer.Deserialize(data)
+	}
+	if data[0] != signedTaskTokenVersion {
+		return nil, ErrTaskTokenUnknownVersion
+	}
+	if len(data) < signedTaskTokenHeaderSize {
+		return nil, ErrTaskTokenInvalidSignature
+	}
+
+	signature, payload := data[1:signedTaskTokenHeaderSize], data[signedTaskTokenHeaderSize:]
+	for _, key := range h.keys {
+		if hmac.Equal(signature, signTaskToken(key, payload)) {
+			var token TaskToken
+			err := json.Unmarshal(payload, &token)
+			return &token, err
+		}
+	}
+	return nil, ErrTaskTokenInvalidSignature
+}
+
+func signTaskToken(key []byte, payload []byte) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write(payload)
+	return mac.Sum(nil)
+}
+
+func isSignedTaskToken(data []byte) bool {
+	return len(data) > 0 && data[0] != '{'
+}
+
+// taskTokenPayload strips the signature of a signed task token without validating it
+func taskTokenPayload(data []byte) []byte {
+	if isSignedTaskToken(data) && data[0] == signedTaskTokenVersion && len(data) >= signedTaskTokenHeaderSize {
+		return data[signedTaskTokenHeaderSize:]
+	}
+	return data
+}
@@ -0,0 +1,118 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type (
+	hmacTaskTokenSerializerSuite struct {
+		suite.Suite
+		*require.Assertions
+
+		token *TaskToken
+	}
+)
+
+func TestHMACTaskTokenSerializerSuite(t *testing.T) {
+	suite.Run(t, new(hmacTaskTokenSerializerSuite))
+}
+
+func (s *hmacTaskTokenSerializerSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+	s.token = &TaskToken{
+		DomainID:        "domainID",
+		WorkflowID:      "workflowID",
+		RunID:           "runID",
+		ScheduleID:      5,
+		ScheduleAttempt: 1,
+	}
+}
+
+func (s *hmacTaskTokenSerializerSuite) TestRoundTrip() {
+	serializer := NewHMACTaskTokenSerializer([]string{"key1"}, false)
+	data, err := serializer.Serialize(s.token)
+	s.NoError(err)
+
+	token, err := serializer.Deserialize(data)
+	s.NoError(err)
+	s.Equal(s.token, token)
+}
+
+func (s *hmacTaskTokenSerializerSuite) TestKeyRotation() {
+	data, err := NewHMACTaskTokenSerializer([]string{"key1"}, false).Serialize(s.token)
+	s.NoError(err)
+
+	token, err := NewHMACTaskTokenSerializer([]string{"key2", "key1"}, false).Deserialize(data)
+	s.NoError(err)
+	s.Equal(s.token, token)
+
+	_, err = NewHMACTaskTokenSerializer([]string{"key2"}, false).Deserialize(data)
+	s.Equal(ErrTaskTokenInvalidSignature, err)
+}
+
+func (s *hmacTaskTokenSerializerSuite) TestTamperedToken() {
+	serializer := NewHMACTaskTokenSerializer([]string{"key1"}, false)
+	data, err := serializer.Serialize(s.token)
+	s.NoError(err)
+
+	forged := *s.token
+	forged.RunID = "otherRunID"
+	forgedData, err := NewHMACTaskTokenSerializer([]string{"otherKey"}, false).Serialize(&forged)
+	s.NoError(err)
+	_, err = serializer.Deserialize(forgedData)
+	s.Equal(ErrTaskTokenInvalidSignature, err)
+
+	data[len(data)-2]++
+	_, err = serializer.Deserialize(data)
+	s.Equal(ErrTaskTokenInvalidSignature, err)
+
+	_, err = serializer.Deserialize([]byte{signedTaskTokenVersion, 1, 2})
+	s.Equal(ErrTaskTokenInvalidSignature, err)
+
+	_, err = serializer.Deserialize([]byte{2, 1, 2})
+	s.Equal(ErrTaskTokenUnknownVersion, err)
+}
+
+func (s *hmacTaskTokenSerializerSuite) TestUnsignedToken() {
+	data, err := NewJSONTaskTokenSerializer().Serialize(s.token)
+	s.NoError(err)
+
+	_, err = NewHMACTaskTokenSerializer([]string{"key1"}, false).Deserialize(data)
+	s.Equal(ErrTaskTokenNotSigned, err)
+
+	token, err := NewHMACTaskTokenSerializer([]string{"key1"}, true).Deserialize(data)
+	s.NoError(err)
+	s.Equal(s.token, token)
+}
+
+func (s *hmacTaskTokenSerializerSuite) TestJSONSerializerReadsSignedToken() {
+	data, err := NewHMACTaskTokenSerializer([]string{"key1"}, false).Serialize(s.token)
+	s.NoError(err)
+
+	token, err := NewJSONTaskTokenSerializer().Deserialize(data)
+	s.NoError(err)
+	s.Equal(s.token, token)
+}
@@ -37,9 +37,10 @@ func (j *jsonTaskTokenSerializer) Serialize(token *TaskToken) ([]byte, error) {
 	return data, err
 }
 
+// Deserialize decodes both unsigned and signed task tokens, signatures are not validated
 func (j *jsonTaskTokenSerializer) Deserialize(data []byte) (*TaskToken, error) {
 	var token TaskToken
-	err := json.Unmarshal(data, &token)
+	err := json.Unmarshal(taskTokenPayload(data), &token)
 
 	return &token, err
 }
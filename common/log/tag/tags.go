@@ -80,11 +80,26 @@ func WorkflowHandlerName(handlerName string) Tag {
 	return newStringTag("wf-handler-name", handlerName)
 }
 
+// CallerIdentity returns tag for CallerIdentity
+func CallerIdentity(identity string) Tag {
+	return newStringTag("caller-identity", identity)
+}
+
+// AuditRequestHash returns tag for the hash of an audited request payload
+func AuditRequestHash(hash string) Tag {
+	return newStringTag("audit-request-hash", hash)
+}
+
 // WorkflowID returns tag for WorkflowID
 func WorkflowID(workflowID string) Tag {
 	return newStringTag("wf-id", workflowID)
 }
 
+// ScheduleID returns tag for the ID of a schedule
+func ScheduleID(scheduleID string) Tag {
+	return newStringTag("schedule-id", scheduleID)
+}
+
 // WorkflowType returns tag for WorkflowType
 func WorkflowType(wfType string) Tag {
 	return newStringTag("wf-type", wfType)
@@ -169,6 +184,11 @@ func WorkflowScheduleID(scheduleID int64) Tag {
 	return newInt64("wf-schedule-id", scheduleID)
 }
 
+// WorkflowTaskJourneyStage returns tag for the stage of the journey of an activity or decision task
+func WorkflowTaskJourneyStage(stage string) Tag {
+	return newStringTag("wf-task-journey-stage", stage)
+}
+
 // WorkflowStartedID returns tag for WorkflowStartedID
 func WorkflowStartedID(id int64) Tag {
 	return newInt64("wf-started-id", id)
@@ -189,6 +209,16 @@ func WorkflowNextEventID(nextEventID int64) Tag {
 	return newInt64("wf-next-event-id", nextEventID)
 }
 
+// WorkflowLastFirstEventID returns tag for WorkflowLastFirstEventID
+func WorkflowLastFirstEventID(lastFirstEventID int64) Tag {
+	return newInt64("wf-last-first-event-id", lastFirstEventID)
+}
+
+// WorkflowLastEventTaskID returns tag for WorkflowLastEventTaskID
+func WorkflowLastEventTaskID(lastEventTaskID int64) Tag {
+	return newInt64("wf-last-event-task-id", lastEventTaskID)
+}
+
 // WorkflowBeginningFirstEventID returns tag for WorkflowBeginningFirstEventID
 func WorkflowBeginningFirstEventID(beginningFirstEventID int64) Tag {
 	return newInt64("wf-begining-first-event-id", beginningFirstEventID)
@@ -365,6 +395,16 @@ func StoreType(storeType string) Tag {
 	return newPredefinedStringTag("store-type", storeType)
 }
 
+// StoreOperationName returns tag for the name of a store operation
+func StoreOperationName(operation string) Tag {
+	return storeOperation(operation)
+}
+
+// StoreTemplate returns tag for the name of the query template of a store operation
+func StoreTemplate(template string) Tag {
+	return newStringTag("store-template", template)
+}
+
 // DetailInfo returns tag for DetailInfo
 func DetailInfo(i string) Tag {
 	return newStringTag("detail-info", i)
@@ -407,6 +447,11 @@ func ShardReplicationAck(shardReplicationAck int64) Tag {
 	return newInt64("shard-replication-ack", shardReplicationAck)
 }
 
+// PreviousShardOwner returns tag for PreviousShardOwner
+func PreviousShardOwner(owner string) Tag {
+	return newStringTag("previous-shard-owner", owner)
+}
+
 // PreviousShardRangeID returns tag for PreviousShardRangeID
 func PreviousShardRangeID(id int64) Tag {
 	return newInt64("previous-shard-range-id", id)
@@ -432,6 +477,11 @@ func MaxLevel(lv int64) Tag {
 	return newInt64("max-level", lv)
 }
 
+// QueueName returns tag for QueueName
+func QueueName(name string) Tag {
+	return newStringTag("queue-name", name)
+}
+
 // ShardTransferAcks returns tag for ShardTransferAcks
 func ShardTransferAcks(shardTransferAcks interface{}) Tag {
 	return newObjectTag("shard-transfer-acks", shardTransferAcks)
@@ -442,6 +492,16 @@ func ShardTimerAcks(shardTimerAcks interface{}) Tag {
 	return newObjectTag("shard-timer-acks", shardTimerAcks)
 }
 
+// ShardDomainNotificationVersion returns tag for ShardDomainNotificationVersion
+func ShardDomainNotificationVersion(version int64) Tag {
+	return newInt64("shard-domain-notification-version", version)
+}
+
+// DomainCacheNotificationVersion returns tag for DomainCacheNotificationVersion
+func DomainCacheNotificationVersion(version int64) Tag {
+	return newInt64("domain-cache-notification-version", version)
+}
+
 // task queue processor
 
 // TaskID returns tag for TaskID
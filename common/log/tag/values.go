@@ -36,6 +36,10 @@ var (
 	WorkflowActionWorkflowSignaled               = workflowAction("add-workflow-signaled-event")
 	WorkflowActionWorkflowRecordMarker           = workflowAction("add-workflow-marker-record-event")
 	WorkflowActionUpsertWorkflowSearchAttributes = workflowAction("add-workflow-upsert-search-attributes-event")
+	WorkflowActionWorkflowUpdateRequested        = workflowAction("add-workflow-update-requested-event")
+	WorkflowActionWorkflowUpdateCompleted        = workflowAction("add-workflow-update-completed-event")
+	WorkflowActionWorkflowPauseUpdated           = workflowAction("add-workflow-pause-updated-event")
+	WorkflowActionCronSchedulePauseUpdated       = workflowAction("add-cron-schedule-pause-updated-event")
 
 	// decision
 	WorkflowActionDecisionTaskScheduled = workflowAction("add-decisiontask-scheduled-event")
@@ -101,6 +105,7 @@ var (
 	ComponentTimerQueue               = component("timer-queue-processor")
 	ComponentTimerBuilder             = component("timer-builder")
 	ComponentReplicatorQueue          = component("replicator-queue-processor")
+	ComponentVisibilityQueue          = component("visibility-queue-processor")
 	ComponentShardController          = component("shard-controller")
 	ComponentShard                    = component("shard")
 	ComponentShardItem                = component("shard-item")
@@ -113,8 +118,11 @@ var (
 	ComponentIndexerProcessor         = component("indexer-processor")
 	ComponentIndexerESProcessor       = component("indexer-es-processor")
 	ComponentESVisibilityManager      = component("es-visibility-manager")
+	ComponentPinotVisibilityManager   = component("pinot-visibility-manager")
 	ComponentArchiver                 = component("archiver")
 	ComponentBatcher                  = component("batcher")
+	ComponentCanary                   = component("canary")
+	ComponentScheduler                = component("scheduler")
 	ComponentWorker                   = component("worker")
 	ComponentServiceResolver          = component("service-resolver")
 )
@@ -174,4 +182,10 @@ var (
 	StoreOperationCreateTask              = storeOperation("create-task")
 	StoreOperationUpdateTaskList          = storeOperation("update-task-list")
 	StoreOperationStopTaskList            = storeOperation("stop-task-list")
+
+	StoreOperationResetWorkflowExecution         = storeOperation("reset-wf-execution")
+	StoreOperationResetMutableState              = storeOperation("reset-mutable-state")
+	StoreOperationGetCurrentExecution            = storeOperation("get-current-execution")
+	StoreOperationDeleteCurrentWorkflowExecution = storeOperation("delete-current-wf-execution")
+	StoreOperationBlockCurrentWorkflowExecution  = storeOperation("block-current-wf-execution")
 )
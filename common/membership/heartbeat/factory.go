@@ -0,0 +1,92 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package heartbeat
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/uber/cadence/common/log"
+	"github.com/uber/cadence/common/membership"
+	"github.com/uber/cadence/common/persistence"
+	tcg "github.com/uber/tchannel-go"
+	"go.uber.org/yarpc"
+	"go.uber.org/yarpc/transport/tchannel"
+)
+
+// Factory creates heartbeat based membership monitors, it implements
+// the MembershipMonitorFactory interface
+type Factory struct {
+	serviceName string
+	services    []string
+	store       persistence.ClusterMembershipManager
+	interval    time.Duration
+	ttl         time.Duration
+	logger      log.Logger
+}
+
+// NewFactory returns a factory of membership monitors backed by the given cluster membership store
+func NewFactory(
+	serviceName string,
+	services []string,
+	store persistence.ClusterMembershipManager,
+	interval time.Duration,
+	ttl time.Duration,
+	logger log.Logger,
+) *Factory {
+	return &Factory{
+		serviceName: serviceName,
+		services:    services,
+		store:       store,
+		interval:    interval,
+		ttl:         ttl,
+		logger:      logger,
+	}
+}
+
+// Create is the implementation for MembershipMonitorFactory.Create
+func (factory *Factory) Create(dispatcher *yarpc.Dispatcher) (membership.Monitor, error) {
+	// use actual listen port (in case service is bound to :0 or 0.0.0.0:0)
+	ch, err := getChannel(dispatcher)
+	if err != nil {
+		return nil, err
+	}
+
+	monitor := NewMonitor(factory.serviceName, ch.PeerInfo().HostPort, factory.services,
+		factory.store, factory.interval, factory.ttl, factory.logger)
+	if err := monitor.Start(); err != nil {
+		return nil, fmt.Errorf("heartbeat membership start failed: %v", err)
+	}
+	return monitor, nil
+}
+
+func getChannel(dispatcher *yarpc.Dispatcher) (*tcg.Channel, error) {
+	t := dispatcher.Inbounds()[0].Transports()[0].(*tchannel.ChannelTransport)
+	ty := reflect.ValueOf(t.Channel())
+	var ch *tcg.Channel
+	var ok bool
+	if ch, ok = ty.Interface().(*tcg.Channel); !ok {
+		return nil, errors.New("Unable to get tchannel out of the dispatcher")
+	}
+	return ch, nil
+}
@@ -0,0 +1,243 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package heartbeat
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/log"
+	"github.com/uber/cadence/common/log/tag"
+	"github.com/uber/cadence/common/membership"
+	"github.com/uber/cadence/common/persistence"
+)
+
+const (
+	defaultHeartbeatInterval = 2 * time.Second
+	defaultHeartbeatTTL      = 10 * time.Second
+	pruneInterval            = time.Minute
+	maxRecordsPruned         = 1000
+)
+
+type monitor struct {
+	started      bool
+	stopped      bool
+	serviceName  string
+	rpcAddress   string
+	sessionStart time.Time
+	interval     time.Duration
+	ttl          time.Duration
+	store        persistence.ClusterMembershipManager
+	rings        map[string]*serviceResolver
+	shutdownCh   chan struct{}
+	shutdownWG   sync.WaitGroup
+	logger       log.Logger
+	mutex        sync.Mutex
+	// evicted stops the heartbeats, the host leaves the rings once its last heartbeat is older than the TTL
+	evicted int32
+}
+
+var _ membership.Monitor = (*monitor)(nil)
+
+// NewMonitor returns a membership monitor which records the heartbeats of this host
+// in the persistence store and builds the rings from the hosts with a recent heartbeat
+func NewMonitor(
+	serviceName string,
+	rpcAddress string,
+	services []string,
+	store persistence.ClusterMembershipManager,
+	interval time.Duration,
+	ttl time.Duration,
+	logger log.Logger,
+) membership.Monitor {
+	if interval <= 0 {
+		interval = defaultHeartbeatInterval
+	}
+	if ttl <= 0 {
+		ttl = defaultHeartbeatTTL
+	}
+	m := &monitor{
+		serviceName:  serviceName,
+		rpcAddress:   rpcAddress,
+		sessionStart: time.Now(),
+		interval:     interval,
+		ttl:          ttl,
+		store:        store,
+		rings:        make(map[string]*serviceResolver),
+		shutdownCh:   make(chan struct{}),
+		logger:       logger,
+	}
+	for _, service := range services {
+		m.rings[service] = newServiceResolver(service, logger)
+	}
+	return m
+}
+
+func (m *monitor) Start() error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if m.started {
+		return nil
+	}
+
+	if err := m.heartbeat(); err != nil {
+		m.logger.Error("Failed to record membership heartbeat.", tag.Error(err))
+		return err
+	}
+	m.refreshRings()
+
+	m.shutdownWG.Add(1)
+	go m.heartbeatWorker()
+
+	m.started = true
+	return nil
+}
+
+func (m *monitor) Stop() {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if m.stopped {
+		return
+	}
+
+	close(m.shutdownCh)
+	if success := common.AwaitWaitGroup(&m.shutdownWG, time.Minute); !success {
+		m.logger.Warn("membership monitor timed out on shutdown.")
+	}
+	m.store.Close()
+	m.stopped = true
+}
+
+func (m *monitor) WhoAmI() (*membership.HostInfo, error) {
+	return membership.NewHostInfo(m.rpcAddress, map[string]string{membership.RoleKey: m.serviceName}), nil
+}
+
+func (m *monitor) GetResolver(service string) (membership.ServiceResolver, error) {
+	ring, found := m.rings[service]
+	if !found {
+		return nil, membership.ErrUnknownService
+	}
+	return ring, nil
+}
+
+func (m *monitor) Lookup(service string, key string) (*membership.HostInfo, error) {
+	ring, err := m.GetResolver(service)
+	if err != nil {
+		return nil, err
+	}
+	return ring.Lookup(key)
+}
+
+func (m *monitor) AddListener(service string, name string, notifyChannel chan<- *membership.ChangedEvent) error {
+	ring, err := m.GetResolver(service)
+	if err != nil {
+		return err
+	}
+	return ring.AddListener(name, notifyChannel)
+}
+
+func (m *monitor) RemoveListener(service string, name string) error {
+	ring, err := m.GetResolver(service)
+	if err != nil {
+		return err
+	}
+	return ring.RemoveListener(name)
+}
+
+func (m *monitor) EvictSelf() error {
+	atomic.StoreInt32(&m.evicted, 1)
+	m.logger.Info("Evicted host from the membership ring.", tag.Service(m.serviceName))
+	return nil
+}
+
+func (m *monitor) RejoinSelf() error {
+	if !atomic.CompareAndSwapInt32(&m.evicted, 1, 0) {
+		return nil
+	}
+	m.logger.Info("Host rejoined the membership ring.", tag.Service(m.serviceName))
+	return m.heartbeat()
+}
+
+func (m *monitor) heartbeat() error {
+	return m.store.UpsertClusterMembership(&persistence.UpsertClusterMembershipRequest{
+		Role:         m.serviceName,
+		RPCAddress:   m.rpcAddress,
+		SessionStart: m.sessionStart,
+		RecordExpiry: m.ttl,
+	})
+}
+
+func (m *monitor) refreshRings() {
+	for service, ring := range m.rings {
+		resp, err := m.store.GetClusterMembers(&persistence.GetClusterMembersRequest{
+			Role:                service,
+			LastHeartbeatWithin: m.ttl,
+		})
+		if err != nil {
+			// keep the current ring until the store is reachable again
+			m.logger.Warn("Error refreshing cluster members.", tag.Service(service), tag.Error(err))
+			continue
+		}
+		addrs := make([]string, 0, len(resp.ActiveMembers))
+		for _, member := range resp.ActiveMembers {
+			addrs = append(addrs, member.RPCAddress)
+		}
+		ring.refresh(addrs)
+	}
+}
+
+func (m *monitor) prune() {
+	err := m.store.PruneClusterMembership(&persistence.PruneClusterMembershipRequest{
+		MaxRecordsPruned: maxRecordsPruned,
+	})
+	if err != nil {
+		m.logger.Warn("Error pruning cluster membership.", tag.Error(err))
+	}
+}
+
+func (m *monitor) heartbeatWorker() {
+	defer m.shutdownWG.Done()
+
+	heartbeatTicker := time.NewTicker(m.interval)
+	defer heartbeatTicker.Stop()
+	pruneTicker := time.NewTicker(pruneInterval)
+	defer pruneTicker.Stop()
+
+	for {
+		select {
+		case <-m.shutdownCh:
+			return
+		case <-heartbeatTicker.C:
+			if atomic.LoadInt32(&m.evicted) == 0 {
+				if err := m.heartbeat(); err != nil {
+					m.logger.Warn("Failed to record membership heartbeat.", tag.Error(err))
+				}
+			}
+			m.refreshRings()
+		case <-pruneTicker.C:
+			m.prune()
+		}
+	}
+}
@@ -0,0 +1,216 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package heartbeat
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"github.com/uber/cadence/common/log/loggerimpl"
+	"github.com/uber/cadence/common/membership"
+	"github.com/uber/cadence/common/persistence"
+)
+
+type (
+	monitorSuite struct {
+		*require.Assertions
+		suite.Suite
+	}
+
+	inMemoryMembershipStore struct {
+		sync.Mutex
+		members map[string]*persistence.ClusterMember
+	}
+)
+
+const testService = "test-service"
+
+func TestMonitorSuite(t *testing.T) {
+	suite.Run(t, new(monitorSuite))
+}
+
+func (s *monitorSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+}
+
+func (s *monitorSuite) TestLookupAndMembershipChanges() {
+	store := newInMemoryMembershipStore()
+	logger := loggerimpl.NewNopLogger()
+	services := []string{testService}
+
+	m1 := NewMonitor(testService, "127.0.0.1:7001", services, store, 50*time.Millisecond, 300*time.Millisecond, logger)
+	s.NoError(m1.Start())
+	defer m1.Stop()
+
+	host, err := m1.Lookup(testService, "key")
+	s.NoError(err)
+	s.Equal("127.0.0.1:7001", host.GetAddress())
+
+	self, err := m1.WhoAmI()
+	s.NoError(err)
+	s.Equal("127.0.0.1:7001", self.GetAddress())
+	role, ok := self.Label(membership.RoleKey)
+	s.True(ok)
+	s.Equal(testService, role)
+
+	listenCh := make(chan *membership.ChangedEvent, 5)
+	s.NoError(m1.AddListener(testService, "test-listener", listenCh))
+
+	m2 := NewMonitor(testService, "127.0.0.1:7002", services, store, 50*time.Millisecond, 300*time.Millisecond, logger)
+	s.NoError(m2.Start())
+
+	select {
+	case e := <-listenCh:
+		s.Equal(1, len(e.HostsAdded))
+		s.Equal("127.0.0.1:7002", e.HostsAdded[0].GetAddress())
+		s.Nil(e.HostsRemoved)
+	case <-time.After(5 * time.Second):
+		s.Fail("Timed out waiting for the new host to be detected")
+	}
+
+	resolver, err := m1.GetResolver(testService)
+	s.NoError(err)
+	s.Equal(2, resolver.MemberCount())
+
+	m2.Stop()
+	select {
+	case e := <-listenCh:
+		s.Equal(1, len(e.HostsRemoved))
+		s.Equal("127.0.0.1:7002", e.HostsRemoved[0].GetAddress())
+		s.Nil(e.HostsAdded)
+	case <-time.After(5 * time.Second):
+		s.Fail("Timed out waiting for the stopped host to expire")
+	}
+	s.Equal(1, resolver.MemberCount())
+}
+
+func (s *monitorSuite) TestEvictAndRejoin() {
+	store := newInMemoryMembershipStore()
+	logger := loggerimpl.NewNopLogger()
+	services := []string{testService}
+
+	m1 := NewMonitor(testService, "127.0.0.1:7001", services, store, 50*time.Millisecond, 300*time.Millisecond, logger)
+	s.NoError(m1.Start())
+	defer m1.Stop()
+	m2 := NewMonitor(testService, "127.0.0.1:7002", services, store, 50*time.Millisecond, 300*time.Millisecond, logger)
+	s.NoError(m2.Start())
+	defer m2.Stop()
+
+	resolver, err := m1.GetResolver(testService)
+	s.NoError(err)
+	s.Eventually(func() bool { return resolver.MemberCount() == 2 }, 5*time.Second, 10*time.Millisecond)
+
+	// the evicted host keeps running but leaves the ring of the other hosts
+	s.NoError(m2.EvictSelf())
+	s.Eventually(func() bool { return resolver.MemberCount() == 1 }, 5*time.Second, 10*time.Millisecond)
+	host, err := m1.Lookup(testService, "key")
+	s.NoError(err)
+	s.Equal("127.0.0.1:7001", host.GetAddress())
+
+	s.NoError(m2.RejoinSelf())
+	s.Eventually(func() bool { return resolver.MemberCount() == 2 }, 5*time.Second, 10*time.Millisecond)
+}
+
+func (s *monitorSuite) TestUnknownService() {
+	m := NewMonitor(testService, "127.0.0.1:7001", []string{testService}, newInMemoryMembershipStore(),
+		time.Second, time.Second, loggerimpl.NewNopLogger())
+	_, err := m.GetResolver("unknown")
+	s.Equal(membership.ErrUnknownService, err)
+	_, err = m.Lookup("unknown", "key")
+	s.Equal(membership.ErrUnknownService, err)
+}
+
+func (s *monitorSuite) TestResolverRefresh() {
+	r := newServiceResolver(testService, loggerimpl.NewNopLogger())
+	_, err := r.Lookup("key")
+	s.Equal(membership.ErrInsufficientHosts, err)
+
+	listenCh := make(chan *membership.ChangedEvent, 5)
+	s.NoError(r.AddListener("test-listener", listenCh))
+	s.Equal(membership.ErrListenerAlreadyExist, r.AddListener("test-listener", listenCh))
+
+	r.refresh([]string{"a:1", "b:1"})
+	e := <-listenCh
+	s.Equal(2, len(e.HostsAdded))
+	s.Equal(2, r.MemberCount())
+
+	// no event when the members did not change
+	r.refresh([]string{"b:1", "a:1"})
+	s.Equal(0, len(listenCh))
+
+	r.refresh([]string{"b:1", "c:1"})
+	e = <-listenCh
+	s.Equal(1, len(e.HostsAdded))
+	s.Equal("c:1", e.HostsAdded[0].GetAddress())
+	s.Equal(1, len(e.HostsRemoved))
+	s.Equal("a:1", e.HostsRemoved[0].GetAddress())
+
+	host, err := r.Lookup("key")
+	s.NoError(err)
+	s.Contains([]string{"b:1", "c:1"}, host.GetAddress())
+}
+
+func newInMemoryMembershipStore() *inMemoryMembershipStore {
+	return &inMemoryMembershipStore{members: make(map[string]*persistence.ClusterMember)}
+}
+
+func (m *inMemoryMembershipStore) Close() {}
+
+func (m *inMemoryMembershipStore) GetName() string {
+	return "in-memory"
+}
+
+func (m *inMemoryMembershipStore) UpsertClusterMembership(request *persistence.UpsertClusterMembershipRequest) error {
+	m.Lock()
+	defer m.Unlock()
+	now := time.Now()
+	m.members[request.Role+"/"+request.RPCAddress] = &persistence.ClusterMember{
+		Role:          request.Role,
+		RPCAddress:    request.RPCAddress,
+		SessionStart:  request.SessionStart,
+		LastHeartbeat: now,
+		RecordExpiry:  now.Add(request.RecordExpiry),
+	}
+	return nil
+}
+
+func (m *inMemoryMembershipStore) GetClusterMembers(request *persistence.GetClusterMembersRequest) (*persistence.GetClusterMembersResponse, error) {
+	m.Lock()
+	defer m.Unlock()
+	now := time.Now()
+	var members []*persistence.ClusterMember
+	for _, member := range m.members {
+		if request.Role != "" && member.Role != request.Role {
+			continue
+		}
+		if persistence.IsActiveClusterMember(member, request.LastHeartbeatWithin, now) {
+			members = append(members, member)
+		}
+	}
+	return &persistence.GetClusterMembersResponse{ActiveMembers: members}, nil
+}
+
+func (m *inMemoryMembershipStore) PruneClusterMembership(request *persistence.PruneClusterMembershipRequest) error {
+	return nil
+}
@@ -0,0 +1,166 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package heartbeat
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/dgryski/go-farm"
+	"github.com/uber/cadence/common/log"
+	"github.com/uber/cadence/common/log/tag"
+	"github.com/uber/cadence/common/membership"
+	"github.com/uber/ringpop-go/hashring"
+)
+
+const replicaPoints = 100
+
+type serviceResolver struct {
+	service string
+	logger  log.Logger
+
+	ringLock sync.RWMutex
+	ring     *hashring.HashRing
+	members  map[string]struct{}
+
+	listenerLock sync.RWMutex
+	listeners    map[string]chan<- *membership.ChangedEvent
+}
+
+var _ membership.ServiceResolver = (*serviceResolver)(nil)
+
+func newServiceResolver(service string, logger log.Logger) *serviceResolver {
+	return &serviceResolver{
+		service:   service,
+		logger:    logger.WithTags(tag.ComponentServiceResolver, tag.Service(service)),
+		ring:      hashring.New(farm.Fingerprint32, replicaPoints),
+		members:   make(map[string]struct{}),
+		listeners: make(map[string]chan<- *membership.ChangedEvent),
+	}
+}
+
+// Lookup finds the host in the ring responsible for serving the given key
+func (r *serviceResolver) Lookup(key string) (*membership.HostInfo, error) {
+	r.ringLock.RLock()
+	defer r.ringLock.RUnlock()
+	addr, found := r.ring.Lookup(key)
+	if !found {
+		return nil, membership.ErrInsufficientHosts
+	}
+	return membership.NewHostInfo(addr, r.getLabelsMap()), nil
+}
+
+// MemberCount returns the number of hosts in the ring
+func (r *serviceResolver) MemberCount() int {
+	r.ringLock.RLock()
+	defer r.ringLock.RUnlock()
+	return r.ring.ServerCount()
+}
+
+func (r *serviceResolver) AddListener(name string, notifyChannel chan<- *membership.ChangedEvent) error {
+	r.listenerLock.Lock()
+	defer r.listenerLock.Unlock()
+	_, ok := r.listeners[name]
+	if ok {
+		return membership.ErrListenerAlreadyExist
+	}
+	r.listeners[name] = notifyChannel
+	return nil
+}
+
+func (r *serviceResolver) RemoveListener(name string) error {
+	r.listenerLock.Lock()
+	defer r.listenerLock.Unlock()
+	delete(r.listeners, name)
+	return nil
+}
+
+// refresh replaces the ring with the given addresses and notifies
+// the listeners if the members changed
+func (r *serviceResolver) refresh(addrs []string) {
+	event := r.updateMembers(addrs)
+	if event == nil {
+		return
+	}
+	r.logger.Info("Service members changed", tag.Addresses(addrs))
+	r.emitEvent(event)
+}
+
+func (r *serviceResolver) updateMembers(addrs []string) *membership.ChangedEvent {
+	r.ringLock.Lock()
+	defer r.ringLock.Unlock()
+
+	members := make(map[string]struct{}, len(addrs))
+	for _, addr := range addrs {
+		members[addr] = struct{}{}
+	}
+
+	event := &membership.ChangedEvent{}
+	for _, addr := range sortedKeys(members) {
+		if _, ok := r.members[addr]; !ok {
+			event.HostsAdded = append(event.HostsAdded, membership.NewHostInfo(addr, r.getLabelsMap()))
+		}
+	}
+	for _, addr := range sortedKeys(r.members) {
+		if _, ok := members[addr]; !ok {
+			event.HostsRemoved = append(event.HostsRemoved, membership.NewHostInfo(addr, r.getLabelsMap()))
+		}
+	}
+	if len(event.HostsAdded) == 0 && len(event.HostsRemoved) == 0 {
+		return nil
+	}
+
+	ring := hashring.New(farm.Fingerprint32, replicaPoints)
+	for addr := range members {
+		ring.AddMembers(membership.NewHostInfo(addr, r.getLabelsMap()))
+	}
+	r.ring = ring
+	r.members = members
+	return event
+}
+
+func (r *serviceResolver) emitEvent(event *membership.ChangedEvent) {
+	r.listenerLock.RLock()
+	defer r.listenerLock.RUnlock()
+
+	for name, ch := range r.listeners {
+		select {
+		case ch <- event:
+		default:
+			r.logger.Error("Failed to send listener notification, channel full", tag.ListenerName(name))
+		}
+	}
+}
+
+func (r *serviceResolver) getLabelsMap() map[string]string {
+	labels := make(map[string]string)
+	labels[membership.RoleKey] = r.service
+	return labels
+}
+
+func sortedKeys(m map[string]struct{}) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
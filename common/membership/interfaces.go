@@ -61,12 +61,19 @@ type (
 		AddListener(service string, name string, notifyChannel chan<- *ChangedEvent) error
 		// RemoveListener removes a listener for this service.
 		RemoveListener(service string, name string) error
+		// EvictSelf removes this host from the ring of its service while it keeps running,
+		// the other hosts stop routing keys to it until RejoinSelf is called
+		EvictSelf() error
+		// RejoinSelf adds the host removed by EvictSelf back to the ring of its service
+		RejoinSelf() error
 	}
 
 	// ServiceResolver provides membership information for a specific cadence service.
 	// It can be used to resolve which member host is responsible for serving a given key.
 	ServiceResolver interface {
 		Lookup(key string) (*HostInfo, error)
+		// MemberCount returns the number of reachable hosts of the service
+		MemberCount() int
 		// AddListener adds a listener which will get notified on the given
 		// channel, whenever membership changes.
 		// @name: The name for identifying the listener
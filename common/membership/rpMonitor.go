@@ -36,6 +36,8 @@ type ringpopMonitor struct {
 	rings    map[string]*ringpopServiceResolver
 	logger   log.Logger
 	mutex    sync.Mutex
+	// evictedRole is the service label removed from this host by EvictSelf
+	evictedRole string
 }
 
 var _ Monitor = (*ringpopMonitor)(nil)
@@ -135,3 +137,45 @@ func (rpo *ringpopMonitor) RemoveListener(service string, name string) error {
 	}
 	return ring.RemoveListener(name)
 }
+
+// EvictSelf removes the service label of this host, so that the rings of the other hosts,
+// which are built from the members labeled with the service, no longer include it
+func (rpo *ringpopMonitor) EvictSelf() error {
+	rpo.mutex.Lock()
+	defer rpo.mutex.Unlock()
+
+	labels, err := rpo.rp.Labels()
+	if err != nil {
+		return err
+	}
+	role, ok := labels.Get(RoleKey)
+	if !ok {
+		return nil
+	}
+	if _, err := labels.Remove(RoleKey); err != nil {
+		return err
+	}
+	rpo.evictedRole = role
+	rpo.logger.Info("Evicted host from the membership ring.", tag.Service(role))
+	return nil
+}
+
+// RejoinSelf restores the service label removed by EvictSelf
+func (rpo *ringpopMonitor) RejoinSelf() error {
+	rpo.mutex.Lock()
+	defer rpo.mutex.Unlock()
+
+	if rpo.evictedRole == "" {
+		return nil
+	}
+	labels, err := rpo.rp.Labels()
+	if err != nil {
+		return err
+	}
+	if err := labels.Set(RoleKey, rpo.evictedRole); err != nil {
+		return err
+	}
+	rpo.logger.Info("Host rejoined the membership ring.", tag.Service(rpo.evictedRole))
+	rpo.evictedRole = ""
+	return nil
+}
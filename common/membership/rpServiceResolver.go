@@ -53,6 +53,7 @@ type ringpopServiceResolver struct {
 
 	ringLock sync.RWMutex
 	ring     *hashring.HashRing
+	members  map[string]struct{}
 
 	listenerLock sync.RWMutex
 	listeners    map[string]chan<- *ChangedEvent
@@ -66,6 +67,7 @@ func newRingpopServiceResolver(service string, rp *ringpop.Ringpop, logger log.L
 		rp:         rp,
 		logger:     logger.WithTags(tag.ComponentServiceResolver, tag.Service(service)),
 		ring:       hashring.New(farm.Fingerprint32, replicaPoints),
+		members:    make(map[string]struct{}),
 		listeners:  make(map[string]chan<- *ChangedEvent),
 		shutdownCh: make(chan struct{}),
 	}
@@ -89,6 +91,7 @@ func (r *ringpopServiceResolver) Start() error {
 	for _, addr := range addrs {
 		labels := r.getLabelsMap()
 		r.ring.AddMembers(NewHostInfo(addr, labels))
+		r.members[addr] = struct{}{}
 	}
 
 	r.shutdownWG.Add(1)
@@ -135,6 +138,13 @@ func (r *ringpopServiceResolver) Lookup(key string) (*HostInfo, error) {
 	return NewHostInfo(addr, r.getLabelsMap()), nil
 }
 
+// MemberCount returns the number of hosts in the ring
+func (r *ringpopServiceResolver) MemberCount() int {
+	r.ringLock.RLock()
+	defer r.ringLock.RUnlock()
+	return r.ring.ServerCount()
+}
+
 func (r *ringpopServiceResolver) AddListener(name string, notifyChannel chan<- *ChangedEvent) error {
 	r.listenerLock.Lock()
 	defer r.listenerLock.Unlock()
@@ -171,7 +181,10 @@ func (r *ringpopServiceResolver) HandleEvent(event events.Event) {
 	}
 }
 
-func (r *ringpopServiceResolver) refresh() {
+// refresh rebuilds the ring from the reachable members and returns the hosts which joined or
+// left the service since the last refresh, or nil if the members did not change. Hosts also
+// leave the service without leaving the ringpop ring when their service label is removed.
+func (r *ringpopServiceResolver) refresh() *ChangedEvent {
 	r.ringLock.Lock()
 	defer r.ringLock.Unlock()
 
@@ -181,15 +194,31 @@ func (r *ringpopServiceResolver) refresh() {
 	if err != nil {
 		// This will happen when service stop and destroy ringpop while there are go-routines pending to call this.
 		r.logger.Warn("Error during ringpop refresh.", tag.Error(err))
-		return
+		return nil
 	}
 
+	members := make(map[string]struct{}, len(addrs))
+	event := &ChangedEvent{}
 	for _, addr := range addrs {
 		host := NewHostInfo(addr, r.getLabelsMap())
 		r.ring.AddMembers(host)
+		members[addr] = struct{}{}
+		if _, ok := r.members[addr]; !ok {
+			event.HostsAdded = append(event.HostsAdded, host)
+		}
+	}
+	for addr := range r.members {
+		if _, ok := members[addr]; !ok {
+			event.HostsRemoved = append(event.HostsRemoved, NewHostInfo(addr, r.getLabelsMap()))
+		}
 	}
+	r.members = members
 
 	r.logger.Debug("Current reachable members", tag.Addresses(addrs))
+	if len(event.HostsAdded) == 0 && len(event.HostsRemoved) == 0 {
+		return nil
+	}
+	return event
 }
 
 func (r *ringpopServiceResolver) emitEvent(rpEvent events.RingChangedEvent) {
@@ -204,8 +233,10 @@ func (r *ringpopServiceResolver) emitEvent(rpEvent events.RingChangedEvent) {
 	for _, addr := range rpEvent.ServersUpdated {
 		event.HostsUpdated = append(event.HostsUpdated, NewHostInfo(addr, r.getLabelsMap()))
 	}
+	r.notifyListeners(event)
+}
 
-	// Notify listeners
+func (r *ringpopServiceResolver) notifyListeners(event *ChangedEvent) {
 	r.listenerLock.RLock()
 	defer r.listenerLock.RUnlock()
 
@@ -229,7 +260,10 @@ func (r *ringpopServiceResolver) refreshRingWorker() {
 		case <-r.shutdownCh:
 			return
 		case <-refreshTicker.C:
-			r.refresh()
+			// label changes do not emit ring changed events, so changes found here are notified
+			if event := r.refresh(); event != nil {
+				r.notifyListeners(event)
+			}
 		}
 	}
 }
@@ -0,0 +1,191 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package messaging
+
+import (
+	"sync"
+	"time"
+
+	"github.com/uber/cadence/common/log"
+	"github.com/uber/cadence/common/log/tag"
+	"github.com/uber/cadence/common/service/dynamicconfig"
+)
+
+type (
+	// BatchingProducerOptions describes how the messages published one by one are grouped into batches
+	BatchingProducerOptions struct {
+		// MaxBatchSize is the maximum number of messages sent in one batch, a size of 1 disables batching
+		MaxBatchSize dynamicconfig.IntPropertyFn
+		// MaxBatchDelay is the longest a message waits for the batch it is part of to fill up
+		MaxBatchDelay dynamicconfig.DurationPropertyFn
+		// MaxInFlightBatches is the maximum number of batches sent concurrently, the publishers are blocked
+		// while it is reached
+		MaxInFlightBatches dynamicconfig.IntPropertyFn
+	}
+
+	batchingProducer struct {
+		producer Producer
+		options  *BatchingProducerOptions
+		logger   log.Logger
+
+		requestCh  chan *publishRequest
+		shutdownCh chan struct{}
+		shutdownWG sync.WaitGroup
+		closeOnce  sync.Once
+
+		inFlightLock  sync.Mutex
+		inFlightCond  *sync.Cond
+		inFlightCount int
+	}
+
+	publishRequest struct {
+		msg    interface{}
+		doneCh chan error
+	}
+)
+
+var _ Producer = (*batchingProducer)(nil)
+
+// NewBatchingProducer creates a producer sending the messages published concurrently in batches, Publish returns
+// once the batch holding the message is sent so the callers keep their delivery guarantee
+func NewBatchingProducer(producer Producer, options *BatchingProducerOptions, logger log.Logger) Producer {
+	p := &batchingProducer{
+		producer:   producer,
+		options:    options,
+		logger:     logger,
+		requestCh:  make(chan *publishRequest),
+		shutdownCh: make(chan struct{}),
+	}
+	p.inFlightCond = sync.NewCond(&p.inFlightLock)
+
+	p.shutdownWG.Add(1)
+	go p.batchLoop()
+	return p
+}
+
+func (p *batchingProducer) Publish(msg interface{}) error {
+	if p.options.MaxBatchSize() <= 1 {
+		return p.producer.Publish(msg)
+	}
+
+	request := &publishRequest{
+		msg:    msg,
+		doneCh: make(chan error, 1),
+	}
+	select {
+	case p.requestCh <- request:
+	case <-p.shutdownCh:
+		return ErrProducerClosed
+	}
+	return <-request.doneCh
+}
+
+func (p *batchingProducer) PublishBatch(msgs []interface{}) error {
+	return p.producer.PublishBatch(msgs)
+}
+
+func (p *batchingProducer) Close() error {
+	p.closeOnce.Do(func() {
+		close(p.shutdownCh)
+		p.shutdownWG.Wait()
+	})
+	return p.producer.Close()
+}
+
+func (p *batchingProducer) batchLoop() {
+	defer p.shutdownWG.Done()
+
+	for {
+		select {
+		case <-p.shutdownCh:
+			p.waitInFlightBatches(1)
+			return
+		case request := <-p.requestCh:
+			batch := p.fillBatch(request)
+			// the loop stops accepting messages while too many batches are in flight, which blocks the publishers
+			p.waitInFlightBatches(p.options.MaxInFlightBatches())
+			p.inFlightLock.Lock()
+			p.inFlightCount++
+			p.inFlightLock.Unlock()
+			go p.sendBatch(batch)
+		}
+	}
+}
+
+// fillBatch collects the messages published until the batch is full or its delay expires
+func (p *batchingProducer) fillBatch(first *publishRequest) []*publishRequest {
+	batch := []*publishRequest{first}
+	maxBatchSize := p.options.MaxBatchSize()
+	timer := time.NewTimer(p.options.MaxBatchDelay())
+	defer timer.Stop()
+
+	for len(batch) < maxBatchSize {
+		select {
+		case request := <-p.requestCh:
+			batch = append(batch, request)
+		case <-timer.C:
+			return batch
+		case <-p.shutdownCh:
+			return batch
+		}
+	}
+	return batch
+}
+
+// waitInFlightBatches blocks until less than the given number of batches are in flight
+func (p *batchingProducer) waitInFlightBatches(limit int) {
+	if limit < 1 {
+		limit = 1
+	}
+	p.inFlightLock.Lock()
+	defer p.inFlightLock.Unlock()
+	for p.inFlightCount >= limit {
+		p.inFlightCond.Wait()
+	}
+}
+
+func (p *batchingProducer) sendBatch(batch []*publishRequest) {
+	defer func() {
+		p.inFlightLock.Lock()
+		p.inFlightCount--
+		p.inFlightLock.Unlock()
+		p.inFlightCond.Broadcast()
+	}()
+
+	msgs := make([]interface{}, 0, len(batch))
+	for _, request := range batch {
+		msgs = append(msgs, request.msg)
+	}
+	err := p.producer.PublishBatch(msgs)
+	if err == nil {
+		for _, request := range batch {
+			request.doneCh <- nil
+		}
+		return
+	}
+
+	// the messages are sent again one by one so each publisher gets the error of its own message, e.g. the
+	// message size limit a history replication task falls back on, the consumers dedup the messages sent twice
+	p.logger.Warn("Failed to publish batch of messages, publishing them one by one", tag.Error(err))
+	for _, request := range batch {
+		request.doneCh <- p.producer.Publish(request.msg)
+	}
+}
@@ -0,0 +1,163 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package messaging
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/uber/cadence/common/log/loggerimpl"
+	"github.com/uber/cadence/common/service/dynamicconfig"
+)
+
+type (
+	batchingProducerSuite struct {
+		suite.Suite
+		producer *fakeProducer
+	}
+
+	fakeProducer struct {
+		sync.Mutex
+		batches      [][]interface{}
+		published    []interface{}
+		batchErr     error
+		publishErrFn func(msg interface{}) error
+		closed       bool
+	}
+)
+
+func TestBatchingProducerSuite(t *testing.T) {
+	suite.Run(t, new(batchingProducerSuite))
+}
+
+func (s *batchingProducerSuite) SetupTest() {
+	s.producer = &fakeProducer{}
+}
+
+func (s *batchingProducerSuite) newBatchingProducer(maxBatchSize int, maxBatchDelay time.Duration) Producer {
+	return NewBatchingProducer(s.producer, &BatchingProducerOptions{
+		MaxBatchSize:       dynamicconfig.GetIntPropertyFn(maxBatchSize),
+		MaxBatchDelay:      dynamicconfig.GetDurationPropertyFn(maxBatchDelay),
+		MaxInFlightBatches: dynamicconfig.GetIntPropertyFn(2),
+	}, loggerimpl.NewDevelopmentForTest(s.Suite))
+}
+
+func (s *batchingProducerSuite) publishConcurrently(producer Producer, count int) []error {
+	errs := make([]error, count)
+	var wg sync.WaitGroup
+	for i := 0; i < count; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errs[i] = producer.Publish(i)
+		}(i)
+	}
+	wg.Wait()
+	return errs
+}
+
+func (s *batchingProducerSuite) TestPublish_BatchingDisabled() {
+	producer := s.newBatchingProducer(1, time.Second)
+	s.NoError(producer.Publish(1))
+	s.NoError(producer.Close())
+
+	s.Equal([]interface{}{1}, s.producer.published)
+	s.Empty(s.producer.batches)
+	s.True(s.producer.closed)
+}
+
+func (s *batchingProducerSuite) TestPublish_Batched() {
+	producer := s.newBatchingProducer(5, time.Hour)
+	errs := s.publishConcurrently(producer, 10)
+	s.NoError(producer.Close())
+
+	for _, err := range errs {
+		s.NoError(err)
+	}
+	s.Len(s.producer.batches, 2)
+	for _, batch := range s.producer.batches {
+		s.Len(batch, 5)
+	}
+	s.Empty(s.producer.published)
+}
+
+func (s *batchingProducerSuite) TestPublish_BatchDelayExpired() {
+	producer := s.newBatchingProducer(100, time.Millisecond)
+	errs := s.publishConcurrently(producer, 3)
+	s.NoError(producer.Close())
+
+	for _, err := range errs {
+		s.NoError(err)
+	}
+	count := 0
+	for _, batch := range s.producer.batches {
+		count += len(batch)
+	}
+	s.Equal(3, count)
+}
+
+func (s *batchingProducerSuite) TestPublish_BatchFailed() {
+	s.producer.batchErr = errors.New("some random batch error")
+	s.producer.publishErrFn = func(msg interface{}) error {
+		if msg.(int) == 0 {
+			return ErrMessageSizeLimit
+		}
+		return nil
+	}
+	producer := s.newBatchingProducer(2, time.Hour)
+	errs := s.publishConcurrently(producer, 2)
+	s.NoError(producer.Close())
+
+	s.Equal(ErrMessageSizeLimit, errs[0])
+	s.NoError(errs[1])
+	s.Len(s.producer.published, 2)
+}
+
+func (s *batchingProducerSuite) TestPublish_Closed() {
+	producer := s.newBatchingProducer(2, time.Hour)
+	s.NoError(producer.Close())
+	s.Equal(ErrProducerClosed, producer.Publish(1))
+}
+
+func (p *fakeProducer) Publish(msg interface{}) error {
+	p.Lock()
+	defer p.Unlock()
+	p.published = append(p.published, msg)
+	if p.publishErrFn != nil {
+		return p.publishErrFn(msg)
+	}
+	return nil
+}
+
+func (p *fakeProducer) PublishBatch(msgs []interface{}) error {
+	p.Lock()
+	defer p.Unlock()
+	p.batches = append(p.batches, msgs)
+	return p.batchErr
+}
+
+func (p *fakeProducer) Close() error {
+	p.closed = true
+	return nil
+}
@@ -25,4 +25,6 @@ import "errors"
 var (
 	// ErrMessageSizeLimit indicate that message is rejected by server due to size limitation
 	ErrMessageSizeLimit = errors.New("message was too large, server rejected it to avoid allocation error")
+	// ErrProducerClosed indicate that the message is published after the producer is closed
+	ErrProducerClosed = errors.New("producer is closed")
 )
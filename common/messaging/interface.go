@@ -53,6 +53,12 @@ type (
 		Nack() error
 	}
 
+	// KeyedMessage is a raw payload published with a partition key
+	KeyedMessage struct {
+		Key   string
+		Value []byte
+	}
+
 	// Producer is the interface used to send replication tasks to other clusters through replicator
 	Producer interface {
 		//PublishBatch(msgs []*replicator.ReplicationTask) error
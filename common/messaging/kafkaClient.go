@@ -141,16 +141,16 @@ func (c *kafkaClient) newConsumerHelper(topic, dlq *uberKafka.Topic, consumerNam
 // NewProducer is used to create a Kafka producer
 func (c *kafkaClient) NewProducer(app string) (Producer, error) {
 	topics := c.config.getTopicsForApplication(app)
-	return c.newProducerHelper(topics.Topic)
+	return c.newProducerHelper(topics.Topic, topics.Compression)
 }
 
 // NewProducerWithClusterName is used to create a Kafka producer for shipping replication tasks
 func (c *kafkaClient) NewProducerWithClusterName(sourceCluster string) (Producer, error) {
 	topics := c.config.getTopicsForCadenceCluster(sourceCluster)
-	return c.newProducerHelper(topics.Topic)
+	return c.newProducerHelper(topics.Topic, topics.Compression)
 }
 
-func (c *kafkaClient) newProducerHelper(topic string) (Producer, error) {
+func (c *kafkaClient) newProducerHelper(topic string, compression string) (Producer, error) {
 	kafkaClusterName := c.config.getKafkaClusterForTopic(topic)
 	brokers := c.config.getBrokersForKafkaCluster(kafkaClusterName)
 
@@ -158,6 +158,17 @@ func (c *kafkaClient) newProducerHelper(topic string) (Producer, error) {
 	config.Producer.Return.Successes = true
 	config.Net.TLS.Enable = c.tlsConfig != nil
 	config.Net.TLS.Config = c.tlsConfig
+	// the consumers decompress the messages on their own, whatever the codec
+	switch compression {
+	case KafkaCompressionGZIP:
+		config.Producer.Compression = sarama.CompressionGZIP
+	case KafkaCompressionSnappy:
+		config.Producer.Compression = sarama.CompressionSnappy
+	case KafkaCompressionLZ4:
+		// lz4 framing is only supported from the 0.10 message format
+		config.Producer.Compression = sarama.CompressionLZ4
+		config.Version = sarama.V0_10_0_0
+	}
 
 	producer, err := sarama.NewSyncProducer(brokers, config)
 	if err != nil {
@@ -57,9 +57,21 @@ type (
 		Topic      string `yaml:"topic"`
 		RetryTopic string `yaml:"retry-topic"`
 		DLQTopic   string `yaml:"dlq-topic"`
+		// Compression is the codec the messages produced to the topic are compressed with, one of gzip, snappy
+		// or lz4. Empty disables compression
+		Compression string `yaml:"compression"`
 	}
 )
 
+const (
+	// KafkaCompressionGZIP refers to gzip compression of the produced messages
+	KafkaCompressionGZIP = "gzip"
+	// KafkaCompressionSnappy refers to snappy compression of the produced messages
+	KafkaCompressionSnappy = "snappy"
+	// KafkaCompressionLZ4 refers to lz4 compression of the produced messages
+	KafkaCompressionLZ4 = "lz4"
+)
+
 // Validate will validate config for kafka
 func (k *KafkaConfig) Validate(checkCluster bool, checkApp bool) {
 	if len(k.Clusters) == 0 {
@@ -80,6 +92,13 @@ func (k *KafkaConfig) Validate(checkCluster bool, checkApp bool) {
 			panic(fmt.Sprintf("Missing Kafka Brokers Config for Cluster %v", topicConfig.Cluster))
 		}
 	}
+	validateCompressionFn := func(compression string) {
+		switch compression {
+		case "", KafkaCompressionGZIP, KafkaCompressionSnappy, KafkaCompressionLZ4:
+		default:
+			panic(fmt.Sprintf("Unknown Kafka Compression %v", compression))
+		}
+	}
 
 	if checkCluster {
 		if len(k.ClusterToTopic) == 0 {
@@ -88,6 +107,7 @@ func (k *KafkaConfig) Validate(checkCluster bool, checkApp bool) {
 		for _, topics := range k.ClusterToTopic {
 			validateTopicsFn(topics.Topic)
 			validateTopicsFn(topics.DLQTopic)
+			validateCompressionFn(topics.Compression)
 		}
 	}
 	if checkApp {
@@ -97,6 +117,7 @@ func (k *KafkaConfig) Validate(checkCluster bool, checkApp bool) {
 		for _, topics := range k.Applications {
 			validateTopicsFn(topics.Topic)
 			validateTopicsFn(topics.DLQTopic)
+			validateCompressionFn(topics.Compression)
 		}
 	}
 }
@@ -161,6 +161,20 @@ func (p *kafkaProducer) getProducerMessage(message interface{}) (*sarama.Produce
 			Value: sarama.ByteEncoder(payload),
 		}
 		return msg, nil
+	case *KeyedMessage:
+		keyedMsg := message.(*KeyedMessage)
+		msg := &sarama.ProducerMessage{
+			Topic: p.topic,
+			Key:   sarama.StringEncoder(keyedMsg.Key),
+			Value: sarama.ByteEncoder(keyedMsg.Value),
+		}
+		return msg, nil
+	case []byte:
+		msg := &sarama.ProducerMessage{
+			Topic: p.topic,
+			Value: sarama.ByteEncoder(message.([]byte)),
+		}
+		return msg, nil
 	default:
 		return nil, errors.New("unknown producer message type")
 	}
@@ -134,6 +134,24 @@ const (
 	PersistenceGetShardScope
 	// PersistenceUpdateShardScope tracks UpdateShard calls made by service to persistence layer
 	PersistenceUpdateShardScope
+	// PersistenceUpsertClusterMembershipScope tracks UpsertClusterMembership calls made by service to persistence layer
+	PersistenceUpsertClusterMembershipScope
+	// PersistenceGetClusterMembersScope tracks GetClusterMembers calls made by service to persistence layer
+	PersistenceGetClusterMembersScope
+	// PersistencePruneClusterMembershipScope tracks PruneClusterMembership calls made by service to persistence layer
+	PersistencePruneClusterMembershipScope
+	// PersistenceRecordAuditEntryScope tracks RecordAuditEntry calls made by service to persistence layer
+	PersistenceRecordAuditEntryScope
+	// PersistenceCreateScheduleScope tracks CreateSchedule calls made by service to persistence layer
+	PersistenceCreateScheduleScope
+	// PersistenceGetScheduleScope tracks GetSchedule calls made by service to persistence layer
+	PersistenceGetScheduleScope
+	// PersistenceUpdateScheduleScope tracks UpdateSchedule calls made by service to persistence layer
+	PersistenceUpdateScheduleScope
+	// PersistenceDeleteScheduleScope tracks DeleteSchedule calls made by service to persistence layer
+	PersistenceDeleteScheduleScope
+	// PersistenceListSchedulesScope tracks ListSchedules calls made by service to persistence layer
+	PersistenceListSchedulesScope
 	// PersistenceCreateWorkflowExecutionScope tracks CreateWorkflowExecution calls made by service to persistence layer
 	PersistenceCreateWorkflowExecutionScope
 	// PersistenceGetWorkflowExecutionScope tracks GetWorkflowExecution calls made by service to persistence layer
@@ -150,6 +168,16 @@ const (
 	PersistenceDeleteCurrentWorkflowExecutionScope
 	// PersistenceGetCurrentExecutionScope tracks GetCurrentExecution calls made by service to persistence layer
 	PersistenceGetCurrentExecutionScope
+	// PersistenceListWorkflowExecutionRunsScope tracks ListWorkflowExecutionRuns calls made by service to persistence layer
+	PersistenceListWorkflowExecutionRunsScope
+	// PersistenceListCurrentExecutionsScope tracks ListCurrentExecutions calls made by service to persistence layer
+	PersistenceListCurrentExecutionsScope
+	// PersistenceBlockCurrentWorkflowExecutionScope tracks BlockCurrentWorkflowExecution calls made by service to persistence layer
+	PersistenceBlockCurrentWorkflowExecutionScope
+	// PersistenceListBufferedReplicationTasksScope tracks ListBufferedReplicationTasks calls made by service to persistence layer
+	PersistenceListBufferedReplicationTasksScope
+	// PersistenceDeleteBufferedReplicationTasksScope tracks DeleteBufferedReplicationTasks calls made by service to persistence layer
+	PersistenceDeleteBufferedReplicationTasksScope
 	// PersistenceGetTransferTasksScope tracks GetTransferTasks calls made by service to persistence layer
 	PersistenceGetTransferTasksScope
 	// PersistenceGetReplicationTasksScope tracks GetReplicationTasks calls made by service to persistence layer
@@ -160,12 +188,24 @@ const (
 	PersistenceRangeCompleteTransferTaskScope
 	// PersistenceCompleteReplicationTaskScope tracks CompleteReplicationTasks calls made by service to persistence layer
 	PersistenceCompleteReplicationTaskScope
+	// PersistenceRangeCompleteReplicationTaskScope tracks RangeCompleteReplicationTasks calls made by service to persistence layer
+	PersistenceRangeCompleteReplicationTaskScope
+	// PersistenceGetVisibilityTasksScope tracks GetVisibilityTasks calls made by service to persistence layer
+	PersistenceGetVisibilityTasksScope
+	// PersistenceCompleteVisibilityTaskScope tracks CompleteVisibilityTask calls made by service to persistence layer
+	PersistenceCompleteVisibilityTaskScope
+	// PersistenceRangeCompleteVisibilityTaskScope tracks RangeCompleteVisibilityTask calls made by service to persistence layer
+	PersistenceRangeCompleteVisibilityTaskScope
 	// PersistenceGetTimerIndexTasksScope tracks GetTimerIndexTasks calls made by service to persistence layer
 	PersistenceGetTimerIndexTasksScope
 	// PersistenceCompleteTimerTaskScope tracks CompleteTimerTasks calls made by service to persistence layer
 	PersistenceCompleteTimerTaskScope
 	// PersistenceRangeCompleteTimerTaskScope tracks CompleteTimerTasks calls made by service to persistence layer
 	PersistenceRangeCompleteTimerTaskScope
+	// PersistenceGetClosedExecutionIndexScope tracks GetClosedExecutionIndex calls made by service to persistence layer
+	PersistenceGetClosedExecutionIndexScope
+	// PersistenceDeleteClosedExecutionIndexScope tracks DeleteClosedExecutionIndex calls made by service to persistence layer
+	PersistenceDeleteClosedExecutionIndexScope
 	// PersistenceCreateTaskScope tracks CreateTask calls made by service to persistence layer
 	PersistenceCreateTaskScope
 	// PersistenceGetTasksScope tracks GetTasks calls made by service to persistence layer
@@ -202,6 +242,8 @@ const (
 	PersistenceListDomainScope
 	// PersistenceGetMetadataScope tracks DeleteDomainByName calls made by service to persistence layer
 	PersistenceGetMetadataScope
+	// PersistencePingScope tracks Ping calls made by service to persistence layer
+	PersistencePingScope
 	// PersistenceRecordWorkflowExecutionStartedScope tracks RecordWorkflowExecutionStarted calls made by service to persistence layer
 	PersistenceRecordWorkflowExecutionStartedScope
 	// PersistenceRecordWorkflowExecutionClosedScope tracks RecordWorkflowExecutionClosed calls made by service to persistence layer
@@ -260,10 +302,36 @@ const (
 	HistoryClientRequestCancelWorkflowExecutionScope
 	// HistoryClientSignalWorkflowExecutionScope tracks RPC calls to history service
 	HistoryClientSignalWorkflowExecutionScope
+	// HistoryClientUpdateWorkflowExecutionScope tracks RPC calls to history service
+	HistoryClientUpdateWorkflowExecutionScope
 	// HistoryClientSignalWithStartWorkflowExecutionScope tracks RPC calls to history service
 	HistoryClientSignalWithStartWorkflowExecutionScope
 	// HistoryClientRemoveSignalMutableStateScope tracks RPC calls to history service
 	HistoryClientRemoveSignalMutableStateScope
+	// HistoryClientSetActivityPausedScope tracks RPC calls to history service
+	HistoryClientSetActivityPausedScope
+	// HistoryClientSetWorkflowExecutionNotesScope tracks RPC calls to history service
+	HistoryClientSetWorkflowExecutionNotesScope
+	// HistoryClientSetWorkflowExecutionPausedScope tracks RPC calls to history service
+	HistoryClientSetWorkflowExecutionPausedScope
+	// HistoryClientSetCronSchedulePausedScope tracks RPC calls to history service
+	HistoryClientSetCronSchedulePausedScope
+	// HistoryClientTerminateAllWorkflowRunsScope tracks RPC calls to history service
+	HistoryClientTerminateAllWorkflowRunsScope
+	// HistoryClientImportWorkflowExecutionScope tracks RPC calls to history service
+	HistoryClientImportWorkflowExecutionScope
+	// HistoryClientPurgeWorkflowExecutionScope tracks RPC calls to history service
+	HistoryClientPurgeWorkflowExecutionScope
+	// HistoryClientDrainHistoryHostScope tracks RPC calls to history service
+	HistoryClientDrainHistoryHostScope
+	// HistoryClientUndrainHistoryHostScope tracks RPC calls to history service
+	HistoryClientUndrainHistoryHostScope
+	// HistoryClientDescribeClientVersionsScope tracks RPC calls to history service
+	HistoryClientDescribeClientVersionsScope
+	// HistoryClientGetDomainOpenExecutionCountScope tracks RPC calls to history service
+	HistoryClientGetDomainOpenExecutionCountScope
+	// HistoryClientReconcileDomainOpenExecutionCountsScope tracks RPC calls to history service
+	HistoryClientReconcileDomainOpenExecutionCountsScope
 	// HistoryClientTerminateWorkflowExecutionScope tracks RPC calls to history service
 	HistoryClientTerminateWorkflowExecutionScope
 	// HistoryClientResetWorkflowExecutionScope tracks RPC calls to history service
@@ -296,12 +364,20 @@ const (
 	MatchingClientCancelOutstandingPollScope
 	// MatchingClientDescribeTaskListScope tracks RPC calls to matching service
 	MatchingClientDescribeTaskListScope
+	// MatchingClientGetTaskListScalingHintsScope tracks RPC calls to matching service
+	MatchingClientGetTaskListScalingHintsScope
+	// MatchingClientUpdateTaskListVersionSetsScope tracks RPC calls to matching service
+	MatchingClientUpdateTaskListVersionSetsScope
+	// MatchingClientDescribeTaskJourneyScope tracks RPC calls to matching service
+	MatchingClientDescribeTaskJourneyScope
 	// FrontendClientDeprecateDomainScope tracks RPC calls to frontend service
 	FrontendClientDeprecateDomainScope
 	// FrontendClientDescribeDomainScope tracks RPC calls to frontend service
 	FrontendClientDescribeDomainScope
 	// FrontendClientDescribeTaskListScope tracks RPC calls to frontend service
 	FrontendClientDescribeTaskListScope
+	// FrontendClientGetTaskListScalingHintsScope tracks RPC calls to frontend service
+	FrontendClientGetTaskListScalingHintsScope
 	// FrontendClientDescribeWorkflowExecutionScope tracks RPC calls to frontend service
 	FrontendClientDescribeWorkflowExecutionScope
 	// FrontendClientGetWorkflowExecutionHistoryScope tracks RPC calls to frontend service
@@ -352,6 +428,8 @@ const (
 	FrontendClientSignalWithStartWorkflowExecutionScope
 	// FrontendClientSignalWorkflowExecutionScope tracks RPC calls to frontend service
 	FrontendClientSignalWorkflowExecutionScope
+	// FrontendClientUpdateWorkflowExecutionScope tracks RPC calls to frontend service
+	FrontendClientUpdateWorkflowExecutionScope
 	// FrontendClientStartWorkflowExecutionScope tracks RPC calls to frontend service
 	FrontendClientStartWorkflowExecutionScope
 	// FrontendClientTerminateWorkflowExecutionScope tracks RPC calls to frontend service
@@ -364,12 +442,58 @@ const (
 	AdminClientDescribeWorkflowExecutionScope
 	// AdminClientGetWorkflowExecutionRawHistoryScope tracks RPC calls to admin service
 	AdminClientGetWorkflowExecutionRawHistoryScope
+	// AdminClientSetActivityPausedScope tracks RPC calls to admin service
+	AdminClientSetActivityPausedScope
+	// AdminClientDrainHistoryHostScope tracks RPC calls to admin service
+	AdminClientDrainHistoryHostScope
+	// AdminClientUndrainHistoryHostScope tracks RPC calls to admin service
+	AdminClientUndrainHistoryHostScope
+	// AdminClientDescribeClientVersionsScope tracks RPC calls to admin service
+	AdminClientDescribeClientVersionsScope
+	// AdminClientGetDomainOpenExecutionCountScope tracks RPC calls to admin service
+	AdminClientGetDomainOpenExecutionCountScope
+	// AdminClientSetWorkflowExecutionNotesScope tracks RPC calls to admin service
+	AdminClientSetWorkflowExecutionNotesScope
+	// AdminClientSetWorkflowExecutionPausedScope tracks RPC calls to admin service
+	AdminClientSetWorkflowExecutionPausedScope
+	// AdminClientSetCronSchedulePausedScope tracks RPC calls to admin service
+	AdminClientSetCronSchedulePausedScope
+	// AdminClientTerminateAllWorkflowRunsScope tracks RPC calls to admin service
+	AdminClientTerminateAllWorkflowRunsScope
+	// AdminClientDescribeWorkflowShardScope tracks RPC calls to admin service
+	AdminClientDescribeWorkflowShardScope
+	// AdminClientListWorkflowExecutionRunsScope tracks RPC calls to admin service
+	AdminClientListWorkflowExecutionRunsScope
+	// AdminClientListRecentlyClosedExecutionsScope tracks RPC calls to admin service
+	AdminClientListRecentlyClosedExecutionsScope
+	// AdminClientImportWorkflowExecutionScope tracks RPC calls to admin service
+	AdminClientImportWorkflowExecutionScope
+	// AdminClientPurgeWorkflowExecutionScope tracks RPC calls to admin service
+	AdminClientPurgeWorkflowExecutionScope
+	// AdminClientCreateScheduleScope tracks RPC calls to admin service
+	AdminClientCreateScheduleScope
+	// AdminClientDescribeScheduleScope tracks RPC calls to admin service
+	AdminClientDescribeScheduleScope
+	// AdminClientDeleteScheduleScope tracks RPC calls to admin service
+	AdminClientDeleteScheduleScope
+	// AdminClientListSchedulesScope tracks RPC calls to admin service
+	AdminClientListSchedulesScope
+	// AdminClientSetSchedulePausedScope tracks RPC calls to admin service
+	AdminClientSetSchedulePausedScope
+	// AdminClientBackfillScheduleScope tracks RPC calls to admin service
+	AdminClientBackfillScheduleScope
+	// AdminClientUpdateTaskListVersionSetsScope tracks RPC calls to admin service
+	AdminClientUpdateTaskListVersionSetsScope
+	// AdminClientDescribeTaskJourneyScope tracks RPC calls to admin service
+	AdminClientDescribeTaskJourneyScope
 	// DCRedirectionDeprecateDomainScope tracks RPC calls for dc redirection
 	DCRedirectionDeprecateDomainScope
 	// DCRedirectionDescribeDomainScope tracks RPC calls for dc redirection
 	DCRedirectionDescribeDomainScope
 	// DCRedirectionDescribeTaskListScope tracks RPC calls for dc redirection
 	DCRedirectionDescribeTaskListScope
+	// DCRedirectionGetTaskListScalingHintsScope tracks RPC calls for dc redirection
+	DCRedirectionGetTaskListScalingHintsScope
 	// DCRedirectionDescribeWorkflowExecutionScope tracks RPC calls for dc redirection
 	DCRedirectionDescribeWorkflowExecutionScope
 	// DCRedirectionGetWorkflowExecutionHistoryScope tracks RPC calls for dc redirection
@@ -434,6 +558,8 @@ const (
 	DCRedirectionTerminateWorkflowExecutionScope
 	// DCRedirectionUpdateDomainScope tracks RPC calls for dc redirection
 	DCRedirectionUpdateDomainScope
+	// DCRedirectionUpdateWorkflowExecutionScope tracks RPC calls for dc redirection
+	DCRedirectionUpdateWorkflowExecutionScope
 
 	// MessagingPublishScope tracks Publish calls made by service to messaging layer
 	MessagingClientPublishScope
@@ -516,6 +642,9 @@ const (
 	// SequentialTaskProcessingScope is used by sequential task processing logic
 	SequentialTaskProcessingScope
 
+	// ClusterConnectionScope is used by the health checks of the connections to remote hosts
+	ClusterConnectionScope
+
 	NumCommonScopes
 )
 
@@ -527,6 +656,50 @@ const (
 	AdminDescribeWorkflowExecutionScope
 	// AdminGetWorkflowExecutionRawHistoryScope is the metric scope for admin.GetWorkflowExecutionRawHistoryScope
 	AdminGetWorkflowExecutionRawHistoryScope
+	// AdminSetActivityPausedScope is the metric scope for admin.SetActivityPaused
+	AdminSetActivityPausedScope
+	// AdminDrainHistoryHostScope is the metric scope for admin.DrainHistoryHost
+	AdminDrainHistoryHostScope
+	// AdminUndrainHistoryHostScope is the metric scope for admin.UndrainHistoryHost
+	AdminUndrainHistoryHostScope
+	// AdminDescribeClientVersionsScope is the metric scope for admin.DescribeClientVersions
+	AdminDescribeClientVersionsScope
+	// AdminGetDomainOpenExecutionCountScope is the metric scope for admin.GetDomainOpenExecutionCount
+	AdminGetDomainOpenExecutionCountScope
+	// AdminSetWorkflowExecutionNotesScope is the metric scope for admin.SetWorkflowExecutionNotes
+	AdminSetWorkflowExecutionNotesScope
+	// AdminSetWorkflowExecutionPausedScope is the metric scope for admin.SetWorkflowExecutionPaused
+	AdminSetWorkflowExecutionPausedScope
+	// AdminSetCronSchedulePausedScope is the metric scope for admin.SetCronSchedulePaused
+	AdminSetCronSchedulePausedScope
+	// AdminTerminateAllWorkflowRunsScope is the metric scope for admin.TerminateAllWorkflowRuns
+	AdminTerminateAllWorkflowRunsScope
+	// AdminDescribeWorkflowShardScope is the metric scope for admin.DescribeWorkflowShard
+	AdminDescribeWorkflowShardScope
+	// AdminListWorkflowExecutionRunsScope is the metric scope for admin.ListWorkflowExecutionRuns
+	AdminListWorkflowExecutionRunsScope
+	// AdminListRecentlyClosedExecutionsScope is the metric scope for admin.ListRecentlyClosedExecutions
+	AdminListRecentlyClosedExecutionsScope
+	// AdminImportWorkflowExecutionScope is the metric scope for admin.ImportWorkflowExecution
+	AdminImportWorkflowExecutionScope
+	// AdminPurgeWorkflowExecutionScope is the metric scope for admin.PurgeWorkflowExecution
+	AdminPurgeWorkflowExecutionScope
+	// AdminCreateScheduleScope is the metric scope for admin.CreateSchedule
+	AdminCreateScheduleScope
+	// AdminDescribeScheduleScope is the metric scope for admin.DescribeSchedule
+	AdminDescribeScheduleScope
+	// AdminDeleteScheduleScope is the metric scope for admin.DeleteSchedule
+	AdminDeleteScheduleScope
+	// AdminListSchedulesScope is the metric scope for admin.ListSchedules
+	AdminListSchedulesScope
+	// AdminSetSchedulePausedScope is the metric scope for admin.SetSchedulePaused
+	AdminSetSchedulePausedScope
+	// AdminBackfillScheduleScope is the metric scope for admin.BackfillSchedule
+	AdminBackfillScheduleScope
+	// AdminUpdateTaskListVersionSetsScope is the metric scope for admin.UpdateTaskListVersionSets
+	AdminUpdateTaskListVersionSetsScope
+	// AdminDescribeTaskJourneyScope is the metric scope for admin.DescribeTaskJourney
+	AdminDescribeTaskJourneyScope
 
 	NumAdminScopes
 )
@@ -565,6 +738,8 @@ const (
 	FrontendGetWorkflowExecutionHistoryScope
 	// FrontendSignalWorkflowExecutionScope is the metric scope for frontend.SignalWorkflowExecution
 	FrontendSignalWorkflowExecutionScope
+	// FrontendUpdateWorkflowExecutionScope is the metric scope for frontend.UpdateWorkflowExecution
+	FrontendUpdateWorkflowExecutionScope
 	// FrontendSignalWithStartWorkflowExecutionScope is the metric scope for frontend.SignalWithStartWorkflowExecution
 	FrontendSignalWithStartWorkflowExecutionScope
 	// FrontendTerminateWorkflowExecutionScope is the metric scope for frontend.TerminateWorkflowExecution
@@ -595,6 +770,8 @@ const (
 	FrontendDescribeWorkflowExecutionScope
 	// FrontendDescribeTaskListScope is the metric scope for frontend.DescribeTaskList
 	FrontendDescribeTaskListScope
+	// FrontendGetTaskListScalingHintsScope is the metric scope for frontend.GetTaskListScalingHints
+	FrontendGetTaskListScalingHintsScope
 	// FrontendResetStickyTaskListScope is the metric scope for frontend.ResetStickyTaskList
 	FrontendResetStickyTaskListScope
 	// FrontendListDomainsScope is the metric scope for frontend.ListDomain
@@ -635,10 +812,36 @@ const (
 	HistoryRecordActivityTaskStartedScope
 	// HistorySignalWorkflowExecutionScope tracks SignalWorkflowExecution API calls received by service
 	HistorySignalWorkflowExecutionScope
+	// HistoryUpdateWorkflowExecutionScope tracks UpdateWorkflowExecution API calls received by service
+	HistoryUpdateWorkflowExecutionScope
 	// HistorySignalWithStartWorkflowExecutionScope tracks SignalWithStartWorkflowExecution API calls received by service
 	HistorySignalWithStartWorkflowExecutionScope
 	// HistoryRemoveSignalMutableStateScope tracks RemoveSignalMutableState API calls received by service
 	HistoryRemoveSignalMutableStateScope
+	// HistorySetActivityPausedScope tracks SetActivityPaused API calls received by service
+	HistorySetActivityPausedScope
+	// HistorySetWorkflowExecutionNotesScope tracks SetWorkflowExecutionNotes API calls received by service
+	HistorySetWorkflowExecutionNotesScope
+	// HistorySetWorkflowExecutionPausedScope tracks SetWorkflowExecutionPaused API calls received by service
+	HistorySetWorkflowExecutionPausedScope
+	// HistorySetCronSchedulePausedScope tracks SetCronSchedulePaused API calls received by service
+	HistorySetCronSchedulePausedScope
+	// HistoryTerminateAllWorkflowRunsScope tracks TerminateAllWorkflowRuns API calls received by service
+	HistoryTerminateAllWorkflowRunsScope
+	// HistoryImportWorkflowExecutionScope tracks ImportWorkflowExecution API calls received by service
+	HistoryImportWorkflowExecutionScope
+	// HistoryPurgeWorkflowExecutionScope tracks PurgeWorkflowExecution API calls received by service
+	HistoryPurgeWorkflowExecutionScope
+	// HistoryDrainHistoryHostScope tracks DrainHistoryHost API calls received by service
+	HistoryDrainHistoryHostScope
+	// HistoryUndrainHistoryHostScope tracks UndrainHistoryHost API calls received by service
+	HistoryUndrainHistoryHostScope
+	// HistoryDescribeClientVersionsScope tracks DescribeClientVersions API calls received by service
+	HistoryDescribeClientVersionsScope
+	// HistoryGetDomainOpenExecutionCountScope tracks GetDomainOpenExecutionCount API calls received by service
+	HistoryGetDomainOpenExecutionCountScope
+	// HistoryReconcileDomainOpenExecutionCountsScope tracks ReconcileDomainOpenExecutionCounts API calls received by service
+	HistoryReconcileDomainOpenExecutionCountsScope
 	// HistoryTerminateWorkflowExecutionScope tracks TerminateWorkflowExecution API calls received by service
 	HistoryTerminateWorkflowExecutionScope
 	// HistoryScheduleDecisionTaskScope tracks ScheduleDecisionTask API calls received by service
@@ -683,6 +886,8 @@ const (
 	TransferActiveTaskResetWorkflowScope
 	// TransferActiveTaskUpsertWorkflowSearchAttributesScope is the scope used for upsert search attributes processing by transfer queue processor
 	TransferActiveTaskUpsertWorkflowSearchAttributesScope
+	// TransferActiveTaskRecordChildExecutionCompletedScope is the scope used for record child execution completed task processing by transfer queue processor
+	TransferActiveTaskRecordChildExecutionCompletedScope
 	// TransferStandbyTaskResetWorkflowScope is the scope used for record workflow started task processing by transfer queue processor
 	TransferStandbyTaskResetWorkflowScope
 	// TransferStandbyTaskActivityScope is the scope used for activity task processing by transfer queue processor
@@ -701,6 +906,8 @@ const (
 	TransferStandbyTaskRecordWorkflowStartedScope
 	// TransferStandbyTaskUpsertWorkflowSearchAttributesScope is the scope used for upsert search attributes processing by transfer queue processor
 	TransferStandbyTaskUpsertWorkflowSearchAttributesScope
+	// TransferStandbyTaskRecordChildExecutionCompletedScope is the scope used for record child execution completed task processing by transfer queue processor
+	TransferStandbyTaskRecordChildExecutionCompletedScope
 	// TimerQueueProcessorScope is the scope used by all metric emitted by timer queue processor
 	TimerQueueProcessorScope
 	// TimerActiveQueueProcessorScope is the scope used by all metric emitted by timer queue processor
@@ -719,6 +926,8 @@ const (
 	TimerActiveTaskActivityRetryTimerScope
 	// TimerActiveTaskWorkflowBackoffTimerScope is the scope used by metric emitted by timer queue processor for processing retry task.
 	TimerActiveTaskWorkflowBackoffTimerScope
+	// TimerActiveTaskWorkflowSLATimerScope is the scope used by metric emitted by timer queue processor for processing workflow SLA deadlines.
+	TimerActiveTaskWorkflowSLATimerScope
 	// TimerActiveTaskDeleteHistoryEventScope is the scope used by metric emitted by timer queue processor for processing history event cleanup
 	TimerActiveTaskDeleteHistoryEventScope
 	// TimerStandbyTaskActivityTimeoutScope is the scope used by metric emitted by timer queue processor for processing activity timeouts
@@ -735,8 +944,18 @@ const (
 	TimerStandbyTaskDeleteHistoryEventScope
 	// TimerStandbyTaskWorkflowBackoffTimerScope is the scope used by metric emitted by timer queue processor for processing retry task.
 	TimerStandbyTaskWorkflowBackoffTimerScope
+	// TimerStandbyTaskWorkflowSLATimerScope is the scope used by metric emitted by timer queue processor for processing workflow SLA deadlines.
+	TimerStandbyTaskWorkflowSLATimerScope
 	// HistoryEventNotificationScope is the scope used by shard history event nitification
 	HistoryEventNotificationScope
+	// VisibilityQueueProcessorScope is the scope used by all metric emitted by visibility queue processor
+	VisibilityQueueProcessorScope
+	// VisibilityTaskRecordWorkflowStartedScope is the scope used for record workflow started task processing by visibility queue processor
+	VisibilityTaskRecordWorkflowStartedScope
+	// VisibilityTaskUpsertWorkflowSearchAttributesScope is the scope used for upsert search attributes processing by visibility queue processor
+	VisibilityTaskUpsertWorkflowSearchAttributesScope
+	// VisibilityTaskCloseExecutionScope is the scope used for close execution task processing by visibility queue processor
+	VisibilityTaskCloseExecutionScope
 	// ReplicatorQueueProcessorScope is the scope used by all metric emitted by replicator queue processor
 	ReplicatorQueueProcessorScope
 	// ReplicatorTaskHistoryScope is the scope used for history task processing by replicator queue processor
@@ -757,6 +976,8 @@ const (
 	HistoryCacheGetOrCreateCurrentScope
 	// HistoryCacheGetCurrentExecutionScope is the scope used by history cache for getting current execution
 	HistoryCacheGetCurrentExecutionScope
+	// HistoryCacheWarmupScope is the scope used by history cache for loading the recently active executions of a shard
+	HistoryCacheWarmupScope
 	// EventsCacheGetEventScope is the scope used by events cache
 	EventsCacheGetEventScope
 	// EventsCachePutEventScope is the scope used by events cache
@@ -777,6 +998,8 @@ const (
 	HistoryResetWorkflowExecutionScope
 	// HistoryProcessDeleteHistoryEventScope tracks ProcessDeleteHistoryEvent processing calls
 	HistoryProcessDeleteHistoryEventScope
+	// HistoryRetentionSweepScope tracks the closed execution index retention sweeps
+	HistoryRetentionSweepScope
 	// WorkflowCompletionStatsScope tracks workflow completion updates
 	WorkflowCompletionStatsScope
 	// ArchiverClientScope is scope used by all metrics emitted by archiver.Client
@@ -805,6 +1028,12 @@ const (
 	MatchingCancelOutstandingPollScope
 	// MatchingDescribeTaskListScope tracks DescribeTaskList API calls received by service
 	MatchingDescribeTaskListScope
+	// MatchingGetTaskListScalingHintsScope tracks GetTaskListScalingHints API calls received by service
+	MatchingGetTaskListScalingHintsScope
+	// MatchingUpdateTaskListVersionSetsScope tracks UpdateTaskListVersionSets API calls received by service
+	MatchingUpdateTaskListVersionSetsScope
+	// MatchingDescribeTaskJourneyScope tracks DescribeTaskJourney API calls received by service
+	MatchingDescribeTaskJourneyScope
 
 	NumMatchingScopes
 )
@@ -841,8 +1070,22 @@ const (
 	ArchiverArchivalWorkflowScope
 	// TaskListScavengerScope is scope used by all metrics emitted by worker.tasklist.Scavenger module
 	TaskListScavengerScope
+	// BufferedReplicationTasksScavengerScope is scope used by all metrics emitted by worker.executions.BufferedReplicationTasksScavenger module
+	BufferedReplicationTasksScavengerScope
+	// CurrentExecutionsScavengerScope is scope used by all metrics emitted by worker.executions.CurrentExecutionsScavenger module
+	CurrentExecutionsScavengerScope
 	// BatcherScope is scope used by all metrics emitted by worker.Batcher module
 	BatcherScope
+	// CanaryScope is scope used by the end to end metrics of the probes run by worker.Canary module
+	CanaryScope
+	// CanaryStartWorkflowScope is scope used by the start workflow step of the canary probes
+	CanaryStartWorkflowScope
+	// CanarySignalWorkflowScope is scope used by the signal workflow step of the canary probes
+	CanarySignalWorkflowScope
+	// CanaryQueryWorkflowScope is scope used by the query workflow step of the canary probes
+	CanaryQueryWorkflowScope
+	// SchedulerScope is scope used by all metrics emitted by worker.Scheduler module
+	SchedulerScope
 
 	NumWorkerScopes
 )
@@ -872,6 +1115,15 @@ var ScopeDefs = map[ServiceIdx]map[int]scopeDefinition{
 		PersistenceCreateShardScope:                              {operation: "CreateShard"},
 		PersistenceGetShardScope:                                 {operation: "GetShard"},
 		PersistenceUpdateShardScope:                              {operation: "UpdateShard"},
+		PersistenceUpsertClusterMembershipScope:                  {operation: "UpsertClusterMembership"},
+		PersistenceGetClusterMembersScope:                        {operation: "GetClusterMembers"},
+		PersistencePruneClusterMembershipScope:                   {operation: "PruneClusterMembership"},
+		PersistenceRecordAuditEntryScope:                         {operation: "RecordAuditEntry"},
+		PersistenceCreateScheduleScope:                           {operation: "CreateSchedule"},
+		PersistenceGetScheduleScope:                              {operation: "GetSchedule"},
+		PersistenceUpdateScheduleScope:                           {operation: "UpdateSchedule"},
+		PersistenceDeleteScheduleScope:                           {operation: "DeleteSchedule"},
+		PersistenceListSchedulesScope:                            {operation: "ListSchedules"},
 		PersistenceCreateWorkflowExecutionScope:                  {operation: "CreateWorkflowExecution"},
 		PersistenceGetWorkflowExecutionScope:                     {operation: "GetWorkflowExecution"},
 		PersistenceUpdateWorkflowExecutionScope:                  {operation: "UpdateWorkflowExecution"},
@@ -880,14 +1132,25 @@ var ScopeDefs = map[ServiceIdx]map[int]scopeDefinition{
 		PersistenceDeleteWorkflowExecutionScope:                  {operation: "DeleteWorkflowExecution"},
 		PersistenceDeleteCurrentWorkflowExecutionScope:           {operation: "DeleteCurrentWorkflowExecution"},
 		PersistenceGetCurrentExecutionScope:                      {operation: "GetCurrentExecution"},
+		PersistenceListWorkflowExecutionRunsScope:                {operation: "ListWorkflowExecutionRuns"},
+		PersistenceListCurrentExecutionsScope:                    {operation: "ListCurrentExecutions"},
+		PersistenceBlockCurrentWorkflowExecutionScope:            {operation: "BlockCurrentWorkflowExecution"},
+		PersistenceListBufferedReplicationTasksScope:             {operation: "ListBufferedReplicationTasks"},
+		PersistenceDeleteBufferedReplicationTasksScope:           {operation: "DeleteBufferedReplicationTasks"},
 		PersistenceGetTransferTasksScope:                         {operation: "GetTransferTasks"},
 		PersistenceGetReplicationTasksScope:                      {operation: "GetReplicationTasks"},
 		PersistenceCompleteTransferTaskScope:                     {operation: "CompleteTransferTask"},
 		PersistenceRangeCompleteTransferTaskScope:                {operation: "RangeCompleteTransferTask"},
 		PersistenceCompleteReplicationTaskScope:                  {operation: "CompleteReplicationTask"},
+		PersistenceRangeCompleteReplicationTaskScope:             {operation: "RangeCompleteReplicationTask"},
+		PersistenceGetVisibilityTasksScope:                       {operation: "GetVisibilityTasks"},
+		PersistenceCompleteVisibilityTaskScope:                   {operation: "CompleteVisibilityTask"},
+		PersistenceRangeCompleteVisibilityTaskScope:              {operation: "RangeCompleteVisibilityTask"},
 		PersistenceGetTimerIndexTasksScope:                       {operation: "GetTimerIndexTasks"},
 		PersistenceCompleteTimerTaskScope:                        {operation: "CompleteTimerTask"},
 		PersistenceRangeCompleteTimerTaskScope:                   {operation: "RangeCompleteTimerTask"},
+		PersistenceGetClosedExecutionIndexScope:                  {operation: "GetClosedExecutionIndex"},
+		PersistenceDeleteClosedExecutionIndexScope:               {operation: "DeleteClosedExecutionIndex"},
 		PersistenceCreateTaskScope:                               {operation: "CreateTask"},
 		PersistenceGetTasksScope:                                 {operation: "GetTasks"},
 		PersistenceCompleteTaskScope:                             {operation: "CompleteTask"},
@@ -906,6 +1169,7 @@ var ScopeDefs = map[ServiceIdx]map[int]scopeDefinition{
 		PersistenceDeleteDomainByNameScope:                       {operation: "DeleteDomainByName"},
 		PersistenceListDomainScope:                               {operation: "ListDomain"},
 		PersistenceGetMetadataScope:                              {operation: "GetMetadata"},
+		PersistencePingScope:                                     {operation: "Ping"},
 		PersistenceRecordWorkflowExecutionStartedScope:           {operation: "RecordWorkflowExecutionStarted"},
 		PersistenceRecordWorkflowExecutionClosedScope:            {operation: "RecordWorkflowExecutionClosed"},
 		PersistenceUpsertWorkflowExecutionScope:                  {operation: "UpsertWorkflowExecution"},
@@ -938,107 +1202,149 @@ var ScopeDefs = map[ServiceIdx]map[int]scopeDefinition{
 
 		ClusterMetadataArchivalConfigScope: {operation: "ArchivalConfig"},
 
-		HistoryClientStartWorkflowExecutionScope:            {operation: "HistoryClientStartWorkflowExecution", tags: map[string]string{CadenceRoleTagName: HistoryRoleTagValue}},
-		HistoryClientRecordActivityTaskHeartbeatScope:       {operation: "HistoryClientRecordActivityTaskHeartbeat", tags: map[string]string{CadenceRoleTagName: HistoryRoleTagValue}},
-		HistoryClientRespondDecisionTaskCompletedScope:      {operation: "HistoryClientRespondDecisionTaskCompleted", tags: map[string]string{CadenceRoleTagName: HistoryRoleTagValue}},
-		HistoryClientRespondDecisionTaskFailedScope:         {operation: "HistoryClientRespondDecisionTaskFailed", tags: map[string]string{CadenceRoleTagName: HistoryRoleTagValue}},
-		HistoryClientRespondActivityTaskCompletedScope:      {operation: "HistoryClientRespondActivityTaskCompleted", tags: map[string]string{CadenceRoleTagName: HistoryRoleTagValue}},
-		HistoryClientRespondActivityTaskFailedScope:         {operation: "HistoryClientRespondActivityTaskFailed", tags: map[string]string{CadenceRoleTagName: HistoryRoleTagValue}},
-		HistoryClientRespondActivityTaskCanceledScope:       {operation: "HistoryClientRespondActivityTaskCanceled", tags: map[string]string{CadenceRoleTagName: HistoryRoleTagValue}},
-		HistoryClientGetMutableStateScope:                   {operation: "HistoryClientGetMutableState", tags: map[string]string{CadenceRoleTagName: HistoryRoleTagValue}},
-		HistoryClientResetStickyTaskListScope:               {operation: "HistoryClientResetStickyTaskListScope", tags: map[string]string{CadenceRoleTagName: HistoryRoleTagValue}},
-		HistoryClientDescribeWorkflowExecutionScope:         {operation: "HistoryClientDescribeWorkflowExecution", tags: map[string]string{CadenceRoleTagName: HistoryRoleTagValue}},
-		HistoryClientRecordDecisionTaskStartedScope:         {operation: "HistoryClientRecordDecisionTaskStarted", tags: map[string]string{CadenceRoleTagName: HistoryRoleTagValue}},
-		HistoryClientRecordActivityTaskStartedScope:         {operation: "HistoryClientRecordActivityTaskStarted", tags: map[string]string{CadenceRoleTagName: HistoryRoleTagValue}},
-		HistoryClientRequestCancelWorkflowExecutionScope:    {operation: "HistoryClientRequestCancelWorkflowExecution", tags: map[string]string{CadenceRoleTagName: HistoryRoleTagValue}},
-		HistoryClientSignalWorkflowExecutionScope:           {operation: "HistoryClientSignalWorkflowExecution", tags: map[string]string{CadenceRoleTagName: HistoryRoleTagValue}},
-		HistoryClientSignalWithStartWorkflowExecutionScope:  {operation: "HistoryClientSignalWithStartWorkflowExecution", tags: map[string]string{CadenceRoleTagName: HistoryRoleTagValue}},
-		HistoryClientRemoveSignalMutableStateScope:          {operation: "HistoryClientRemoveSignalMutableStateScope", tags: map[string]string{CadenceRoleTagName: HistoryRoleTagValue}},
-		HistoryClientTerminateWorkflowExecutionScope:        {operation: "HistoryClientTerminateWorkflowExecution", tags: map[string]string{CadenceRoleTagName: HistoryRoleTagValue}},
-		HistoryClientResetWorkflowExecutionScope:            {operation: "HistoryClientResetWorkflowExecution", tags: map[string]string{CadenceRoleTagName: HistoryRoleTagValue}},
-		HistoryClientScheduleDecisionTaskScope:              {operation: "HistoryClientScheduleDecisionTask", tags: map[string]string{CadenceRoleTagName: HistoryRoleTagValue}},
-		HistoryClientRecordChildExecutionCompletedScope:     {operation: "HistoryClientRecordChildExecutionCompleted", tags: map[string]string{CadenceRoleTagName: HistoryRoleTagValue}},
-		HistoryClientReplicateEventsScope:                   {operation: "HistoryClientReplicateEvents", tags: map[string]string{CadenceRoleTagName: HistoryRoleTagValue}},
-		HistoryClientReplicateRawEventsScope:                {operation: "HistoryClientReplicateRawEvents", tags: map[string]string{CadenceRoleTagName: HistoryRoleTagValue}},
-		HistoryClientSyncShardStatusScope:                   {operation: "HistoryClientSyncShardStatusScope", tags: map[string]string{CadenceRoleTagName: HistoryRoleTagValue}},
-		HistoryClientSyncActivityScope:                      {operation: "HistoryClientSyncActivityScope", tags: map[string]string{CadenceRoleTagName: HistoryRoleTagValue}},
-		MatchingClientPollForDecisionTaskScope:              {operation: "MatchingClientPollForDecisionTask", tags: map[string]string{CadenceRoleTagName: MatchingRoleTagValue}},
-		MatchingClientPollForActivityTaskScope:              {operation: "MatchingClientPollForActivityTask", tags: map[string]string{CadenceRoleTagName: MatchingRoleTagValue}},
-		MatchingClientAddActivityTaskScope:                  {operation: "MatchingClientAddActivityTask", tags: map[string]string{CadenceRoleTagName: MatchingRoleTagValue}},
-		MatchingClientAddDecisionTaskScope:                  {operation: "MatchingClientAddDecisionTask", tags: map[string]string{CadenceRoleTagName: MatchingRoleTagValue}},
-		MatchingClientQueryWorkflowScope:                    {operation: "MatchingClientQueryWorkflow", tags: map[string]string{CadenceRoleTagName: MatchingRoleTagValue}},
-		MatchingClientRespondQueryTaskCompletedScope:        {operation: "MatchingClientRespondQueryTaskCompleted", tags: map[string]string{CadenceRoleTagName: MatchingRoleTagValue}},
-		MatchingClientCancelOutstandingPollScope:            {operation: "MatchingClientCancelOutstandingPoll", tags: map[string]string{CadenceRoleTagName: MatchingRoleTagValue}},
-		MatchingClientDescribeTaskListScope:                 {operation: "MatchingClientDescribeTaskList", tags: map[string]string{CadenceRoleTagName: MatchingRoleTagValue}},
-		FrontendClientDeprecateDomainScope:                  {operation: "FrontendClientDeprecateDomain", tags: map[string]string{CadenceRoleTagName: FrontendRoleTagValue}},
-		FrontendClientDescribeDomainScope:                   {operation: "FrontendClientDescribeDomain", tags: map[string]string{CadenceRoleTagName: FrontendRoleTagValue}},
-		FrontendClientDescribeTaskListScope:                 {operation: "FrontendClientDescribeTaskList", tags: map[string]string{CadenceRoleTagName: FrontendRoleTagValue}},
-		FrontendClientDescribeWorkflowExecutionScope:        {operation: "FrontendClientDescribeWorkflowExecution", tags: map[string]string{CadenceRoleTagName: FrontendRoleTagValue}},
-		FrontendClientGetWorkflowExecutionHistoryScope:      {operation: "FrontendClientGetWorkflowExecutionHistory", tags: map[string]string{CadenceRoleTagName: FrontendRoleTagValue}},
-		FrontendClientListClosedWorkflowExecutionsScope:     {operation: "FrontendClientListClosedWorkflowExecutions", tags: map[string]string{CadenceRoleTagName: FrontendRoleTagValue}},
-		FrontendClientListDomainsScope:                      {operation: "FrontendClientListDomains", tags: map[string]string{CadenceRoleTagName: FrontendRoleTagValue}},
-		FrontendClientListOpenWorkflowExecutionsScope:       {operation: "FrontendClientListOpenWorkflowExecutions", tags: map[string]string{CadenceRoleTagName: FrontendRoleTagValue}},
-		FrontendClientPollForActivityTaskScope:              {operation: "FrontendClientPollForActivityTask", tags: map[string]string{CadenceRoleTagName: FrontendRoleTagValue}},
-		FrontendClientPollForDecisionTaskScope:              {operation: "FrontendClientPollForDecisionTask", tags: map[string]string{CadenceRoleTagName: FrontendRoleTagValue}},
-		FrontendClientQueryWorkflowScope:                    {operation: "FrontendClientQueryWorkflow", tags: map[string]string{CadenceRoleTagName: FrontendRoleTagValue}},
-		FrontendClientRecordActivityTaskHeartbeatScope:      {operation: "FrontendClientRecordActivityTaskHeartbeat", tags: map[string]string{CadenceRoleTagName: FrontendRoleTagValue}},
-		FrontendClientRecordActivityTaskHeartbeatByIDScope:  {operation: "FrontendClientRecordActivityTaskHeartbeatByID", tags: map[string]string{CadenceRoleTagName: FrontendRoleTagValue}},
-		FrontendClientRegisterDomainScope:                   {operation: "FrontendClientRegisterDomain", tags: map[string]string{CadenceRoleTagName: FrontendRoleTagValue}},
-		FrontendClientRequestCancelWorkflowExecutionScope:   {operation: "FrontendClientRequestCancelWorkflowExecution", tags: map[string]string{CadenceRoleTagName: FrontendRoleTagValue}},
-		FrontendClientResetStickyTaskListScope:              {operation: "FrontendClientResetStickyTaskList", tags: map[string]string{CadenceRoleTagName: FrontendRoleTagValue}},
-		FrontendClientResetWorkflowExecutionScope:           {operation: "FrontendClientResetWorkflowExecution", tags: map[string]string{CadenceRoleTagName: FrontendRoleTagValue}},
-		FrontendClientRespondActivityTaskCanceledScope:      {operation: "FrontendClientRespondActivityTaskCanceled", tags: map[string]string{CadenceRoleTagName: FrontendRoleTagValue}},
-		FrontendClientRespondActivityTaskCanceledByIDScope:  {operation: "FrontendClientRespondActivityTaskCanceledByID", tags: map[string]string{CadenceRoleTagName: FrontendRoleTagValue}},
-		FrontendClientRespondActivityTaskCompletedScope:     {operation: "FrontendClientRespondActivityTaskCompleted", tags: map[string]string{CadenceRoleTagName: FrontendRoleTagValue}},
-		FrontendClientRespondActivityTaskCompletedByIDScope: {operation: "FrontendClientRespondActivityTaskCompletedByID", tags: map[string]string{CadenceRoleTagName: FrontendRoleTagValue}},
-		FrontendClientRespondActivityTaskFailedScope:        {operation: "FrontendClientRespondActivityTaskFailed", tags: map[string]string{CadenceRoleTagName: FrontendRoleTagValue}},
-		FrontendClientRespondActivityTaskFailedByIDScope:    {operation: "FrontendClientRespondActivityTaskFailedByID", tags: map[string]string{CadenceRoleTagName: FrontendRoleTagValue}},
-		FrontendClientRespondDecisionTaskCompletedScope:     {operation: "FrontendClientRespondDecisionTaskCompleted", tags: map[string]string{CadenceRoleTagName: FrontendRoleTagValue}},
-		FrontendClientRespondDecisionTaskFailedScope:        {operation: "FrontendClientRespondDecisionTaskFailed", tags: map[string]string{CadenceRoleTagName: FrontendRoleTagValue}},
-		FrontendClientRespondQueryTaskCompletedScope:        {operation: "FrontendClientRespondQueryTaskCompleted", tags: map[string]string{CadenceRoleTagName: FrontendRoleTagValue}},
-		FrontendClientSignalWithStartWorkflowExecutionScope: {operation: "FrontendClientSignalWithStartWorkflowExecution", tags: map[string]string{CadenceRoleTagName: FrontendRoleTagValue}},
-		FrontendClientSignalWorkflowExecutionScope:          {operation: "FrontendClientSignalWorkflowExecution", tags: map[string]string{CadenceRoleTagName: FrontendRoleTagValue}},
-		FrontendClientStartWorkflowExecutionScope:           {operation: "FrontendClientStartWorkflowExecution", tags: map[string]string{CadenceRoleTagName: FrontendRoleTagValue}},
-		FrontendClientTerminateWorkflowExecutionScope:       {operation: "FrontendClientTerminateWorkflowExecution", tags: map[string]string{CadenceRoleTagName: FrontendRoleTagValue}},
-		FrontendClientUpdateDomainScope:                     {operation: "FrontendClientUpdateDomain", tags: map[string]string{CadenceRoleTagName: FrontendRoleTagValue}},
-		AdminClientDescribeHistoryHostScope:                 {operation: "AdminClientDescribeHistoryHost", tags: map[string]string{CadenceRoleTagName: AdminRoleTagValue}},
-		AdminClientDescribeWorkflowExecutionScope:           {operation: "AdminClientDescribeWorkflowExecution", tags: map[string]string{CadenceRoleTagName: AdminRoleTagValue}},
-		AdminClientGetWorkflowExecutionRawHistoryScope:      {operation: "AdminClientGetWorkflowExecutionRawHistory", tags: map[string]string{CadenceRoleTagName: AdminRoleTagValue}},
-		DCRedirectionDeprecateDomainScope:                   {operation: "DCRedirectionDeprecateDomain", tags: map[string]string{CadenceRoleTagName: DCRedirectionRoleTagValue}},
-		DCRedirectionDescribeDomainScope:                    {operation: "DCRedirectionDescribeDomain", tags: map[string]string{CadenceRoleTagName: DCRedirectionRoleTagValue}},
-		DCRedirectionDescribeTaskListScope:                  {operation: "DCRedirectionDescribeTaskList", tags: map[string]string{CadenceRoleTagName: DCRedirectionRoleTagValue}},
-		DCRedirectionDescribeWorkflowExecutionScope:         {operation: "DCRedirectionDescribeWorkflowExecution", tags: map[string]string{CadenceRoleTagName: DCRedirectionRoleTagValue}},
-		DCRedirectionGetWorkflowExecutionHistoryScope:       {operation: "DCRedirectionGetWorkflowExecutionHistory", tags: map[string]string{CadenceRoleTagName: DCRedirectionRoleTagValue}},
-		DCRedirectionListClosedWorkflowExecutionsScope:      {operation: "DCRedirectionListClosedWorkflowExecutions", tags: map[string]string{CadenceRoleTagName: DCRedirectionRoleTagValue}},
-		DCRedirectionListDomainsScope:                       {operation: "DCRedirectionListDomains", tags: map[string]string{CadenceRoleTagName: DCRedirectionRoleTagValue}},
-		DCRedirectionListOpenWorkflowExecutionsScope:        {operation: "DCRedirectionListOpenWorkflowExecutions", tags: map[string]string{CadenceRoleTagName: DCRedirectionRoleTagValue}},
-		DCRedirectionListWorkflowExecutionsScope:            {operation: "DCRedirectionListWorkflowExecutions", tags: map[string]string{CadenceRoleTagName: DCRedirectionRoleTagValue}},
-		DCRedirectionScanWorkflowExecutionsScope:            {operation: "DCRedirectionScanWorkflowExecutions", tags: map[string]string{CadenceRoleTagName: DCRedirectionRoleTagValue}},
-		DCRedirectionCountWorkflowExecutionsScope:           {operation: "DCRedirectionCountWorkflowExecutions", tags: map[string]string{CadenceRoleTagName: DCRedirectionRoleTagValue}},
-		DCRedirectionGetSearchAttributesScope:               {operation: "DCRedirectionGetSearchAttributes", tags: map[string]string{CadenceRoleTagName: DCRedirectionRoleTagValue}},
-		DCRedirectionPollForActivityTaskScope:               {operation: "DCRedirectionPollForActivityTask", tags: map[string]string{CadenceRoleTagName: DCRedirectionRoleTagValue}},
-		DCRedirectionPollForDecisionTaskScope:               {operation: "DCRedirectionPollForDecisionTask", tags: map[string]string{CadenceRoleTagName: DCRedirectionRoleTagValue}},
-		DCRedirectionQueryWorkflowScope:                     {operation: "DCRedirectionQueryWorkflow", tags: map[string]string{CadenceRoleTagName: DCRedirectionRoleTagValue}},
-		DCRedirectionRecordActivityTaskHeartbeatScope:       {operation: "DCRedirectionRecordActivityTaskHeartbeat", tags: map[string]string{CadenceRoleTagName: DCRedirectionRoleTagValue}},
-		DCRedirectionRecordActivityTaskHeartbeatByIDScope:   {operation: "DCRedirectionRecordActivityTaskHeartbeatByID", tags: map[string]string{CadenceRoleTagName: DCRedirectionRoleTagValue}},
-		DCRedirectionRegisterDomainScope:                    {operation: "DCRedirectionRegisterDomain", tags: map[string]string{CadenceRoleTagName: DCRedirectionRoleTagValue}},
-		DCRedirectionRequestCancelWorkflowExecutionScope:    {operation: "DCRedirectionRequestCancelWorkflowExecution", tags: map[string]string{CadenceRoleTagName: DCRedirectionRoleTagValue}},
-		DCRedirectionResetStickyTaskListScope:               {operation: "DCRedirectionResetStickyTaskList", tags: map[string]string{CadenceRoleTagName: DCRedirectionRoleTagValue}},
-		DCRedirectionResetWorkflowExecutionScope:            {operation: "DCRedirectionResetWorkflowExecution", tags: map[string]string{CadenceRoleTagName: DCRedirectionRoleTagValue}},
-		DCRedirectionRespondActivityTaskCanceledScope:       {operation: "DCRedirectionRespondActivityTaskCanceled", tags: map[string]string{CadenceRoleTagName: DCRedirectionRoleTagValue}},
-		DCRedirectionRespondActivityTaskCanceledByIDScope:   {operation: "DCRedirectionRespondActivityTaskCanceledByID", tags: map[string]string{CadenceRoleTagName: DCRedirectionRoleTagValue}},
-		DCRedirectionRespondActivityTaskCompletedScope:      {operation: "DCRedirectionRespondActivityTaskCompleted", tags: map[string]string{CadenceRoleTagName: DCRedirectionRoleTagValue}},
-		DCRedirectionRespondActivityTaskCompletedByIDScope:  {operation: "DCRedirectionRespondActivityTaskCompletedByID", tags: map[string]string{CadenceRoleTagName: DCRedirectionRoleTagValue}},
-		DCRedirectionRespondActivityTaskFailedScope:         {operation: "DCRedirectionRespondActivityTaskFailed", tags: map[string]string{CadenceRoleTagName: DCRedirectionRoleTagValue}},
-		DCRedirectionRespondActivityTaskFailedByIDScope:     {operation: "DCRedirectionRespondActivityTaskFailedByID", tags: map[string]string{CadenceRoleTagName: DCRedirectionRoleTagValue}},
-		DCRedirectionRespondDecisionTaskCompletedScope:      {operation: "DCRedirectionRespondDecisionTaskCompleted", tags: map[string]string{CadenceRoleTagName: DCRedirectionRoleTagValue}},
-		DCRedirectionRespondDecisionTaskFailedScope:         {operation: "DCRedirectionRespondDecisionTaskFailed", tags: map[string]string{CadenceRoleTagName: DCRedirectionRoleTagValue}},
-		DCRedirectionRespondQueryTaskCompletedScope:         {operation: "DCRedirectionRespondQueryTaskCompleted", tags: map[string]string{CadenceRoleTagName: DCRedirectionRoleTagValue}},
-		DCRedirectionSignalWithStartWorkflowExecutionScope:  {operation: "DCRedirectionSignalWithStartWorkflowExecution", tags: map[string]string{CadenceRoleTagName: DCRedirectionRoleTagValue}},
-		DCRedirectionSignalWorkflowExecutionScope:           {operation: "DCRedirectionSignalWorkflowExecution", tags: map[string]string{CadenceRoleTagName: DCRedirectionRoleTagValue}},
-		DCRedirectionStartWorkflowExecutionScope:            {operation: "DCRedirectionStartWorkflowExecution", tags: map[string]string{CadenceRoleTagName: DCRedirectionRoleTagValue}},
-		DCRedirectionTerminateWorkflowExecutionScope:        {operation: "DCRedirectionTerminateWorkflowExecution", tags: map[string]string{CadenceRoleTagName: DCRedirectionRoleTagValue}},
-		DCRedirectionUpdateDomainScope:                      {operation: "DCRedirectionUpdateDomain", tags: map[string]string{CadenceRoleTagName: DCRedirectionRoleTagValue}},
+		HistoryClientStartWorkflowExecutionScope:             {operation: "HistoryClientStartWorkflowExecution", tags: map[string]string{CadenceRoleTagName: HistoryRoleTagValue}},
+		HistoryClientRecordActivityTaskHeartbeatScope:        {operation: "HistoryClientRecordActivityTaskHeartbeat", tags: map[string]string{CadenceRoleTagName: HistoryRoleTagValue}},
+		HistoryClientRespondDecisionTaskCompletedScope:       {operation: "HistoryClientRespondDecisionTaskCompleted", tags: map[string]string{CadenceRoleTagName: HistoryRoleTagValue}},
+		HistoryClientRespondDecisionTaskFailedScope:          {operation: "HistoryClientRespondDecisionTaskFailed", tags: map[string]string{CadenceRoleTagName: HistoryRoleTagValue}},
+		HistoryClientRespondActivityTaskCompletedScope:       {operation: "HistoryClientRespondActivityTaskCompleted", tags: map[string]string{CadenceRoleTagName: HistoryRoleTagValue}},
+		HistoryClientRespondActivityTaskFailedScope:          {operation: "HistoryClientRespondActivityTaskFailed", tags: map[string]string{CadenceRoleTagName: HistoryRoleTagValue}},
+		HistoryClientRespondActivityTaskCanceledScope:        {operation: "HistoryClientRespondActivityTaskCanceled", tags: map[string]string{CadenceRoleTagName: HistoryRoleTagValue}},
+		HistoryClientGetMutableStateScope:                    {operation: "HistoryClientGetMutableState", tags: map[string]string{CadenceRoleTagName: HistoryRoleTagValue}},
+		HistoryClientResetStickyTaskListScope:                {operation: "HistoryClientResetStickyTaskListScope", tags: map[string]string{CadenceRoleTagName: HistoryRoleTagValue}},
+		HistoryClientDescribeWorkflowExecutionScope:          {operation: "HistoryClientDescribeWorkflowExecution", tags: map[string]string{CadenceRoleTagName: HistoryRoleTagValue}},
+		HistoryClientRecordDecisionTaskStartedScope:          {operation: "HistoryClientRecordDecisionTaskStarted", tags: map[string]string{CadenceRoleTagName: HistoryRoleTagValue}},
+		HistoryClientRecordActivityTaskStartedScope:          {operation: "HistoryClientRecordActivityTaskStarted", tags: map[string]string{CadenceRoleTagName: HistoryRoleTagValue}},
+		HistoryClientRequestCancelWorkflowExecutionScope:     {operation: "HistoryClientRequestCancelWorkflowExecution", tags: map[string]string{CadenceRoleTagName: HistoryRoleTagValue}},
+		HistoryClientSignalWorkflowExecutionScope:            {operation: "HistoryClientSignalWorkflowExecution", tags: map[string]string{CadenceRoleTagName: HistoryRoleTagValue}},
+		HistoryClientUpdateWorkflowExecutionScope:            {operation: "HistoryClientUpdateWorkflowExecution", tags: map[string]string{CadenceRoleTagName: HistoryRoleTagValue}},
+		HistoryClientSignalWithStartWorkflowExecutionScope:   {operation: "HistoryClientSignalWithStartWorkflowExecution", tags: map[string]string{CadenceRoleTagName: HistoryRoleTagValue}},
+		HistoryClientRemoveSignalMutableStateScope:           {operation: "HistoryClientRemoveSignalMutableStateScope", tags: map[string]string{CadenceRoleTagName: HistoryRoleTagValue}},
+		HistoryClientSetActivityPausedScope:                  {operation: "HistoryClientSetActivityPaused", tags: map[string]string{CadenceRoleTagName: HistoryRoleTagValue}},
+		HistoryClientSetWorkflowExecutionNotesScope:          {operation: "HistoryClientSetWorkflowExecutionNotes", tags: map[string]string{CadenceRoleTagName: HistoryRoleTagValue}},
+		HistoryClientSetWorkflowExecutionPausedScope:         {operation: "HistoryClientSetWorkflowExecutionPaused", tags: map[string]string{CadenceRoleTagName: HistoryRoleTagValue}},
+		HistoryClientSetCronSchedulePausedScope:              {operation: "HistoryClientSetCronSchedulePaused", tags: map[string]string{CadenceRoleTagName: HistoryRoleTagValue}},
+		HistoryClientTerminateAllWorkflowRunsScope:           {operation: "HistoryClientTerminateAllWorkflowRuns", tags: map[string]string{CadenceRoleTagName: HistoryRoleTagValue}},
+		HistoryClientImportWorkflowExecutionScope:            {operation: "HistoryClientImportWorkflowExecution", tags: map[string]string{CadenceRoleTagName: HistoryRoleTagValue}},
+		HistoryClientPurgeWorkflowExecutionScope:             {operation: "HistoryClientPurgeWorkflowExecution", tags: map[string]string{CadenceRoleTagName: HistoryRoleTagValue}},
+		HistoryClientDrainHistoryHostScope:                   {operation: "HistoryClientDrainHistoryHost", tags: map[string]string{CadenceRoleTagName: HistoryRoleTagValue}},
+		HistoryClientUndrainHistoryHostScope:                 {operation: "HistoryClientUndrainHistoryHost", tags: map[string]string{CadenceRoleTagName: HistoryRoleTagValue}},
+		HistoryClientDescribeClientVersionsScope:             {operation: "HistoryClientDescribeClientVersions", tags: map[string]string{CadenceRoleTagName: HistoryRoleTagValue}},
+		HistoryClientGetDomainOpenExecutionCountScope:        {operation: "HistoryClientGetDomainOpenExecutionCount", tags: map[string]string{CadenceRoleTagName: HistoryRoleTagValue}},
+		HistoryClientReconcileDomainOpenExecutionCountsScope: {operation: "HistoryClientReconcileDomainOpenExecutionCounts", tags: map[string]string{CadenceRoleTagName: HistoryRoleTagValue}},
+		HistoryClientTerminateWorkflowExecutionScope:         {operation: "HistoryClientTerminateWorkflowExecution", tags: map[string]string{CadenceRoleTagName: HistoryRoleTagValue}},
+		HistoryClientResetWorkflowExecutionScope:             {operation: "HistoryClientResetWorkflowExecution", tags: map[string]string{CadenceRoleTagName: HistoryRoleTagValue}},
+		HistoryClientScheduleDecisionTaskScope:               {operation: "HistoryClientScheduleDecisionTask", tags: map[string]string{CadenceRoleTagName: HistoryRoleTagValue}},
+		HistoryClientRecordChildExecutionCompletedScope:      {operation: "HistoryClientRecordChildExecutionCompleted", tags: map[string]string{CadenceRoleTagName: HistoryRoleTagValue}},
+		HistoryClientReplicateEventsScope:                    {operation: "HistoryClientReplicateEvents", tags: map[string]string{CadenceRoleTagName: HistoryRoleTagValue}},
+		HistoryClientReplicateRawEventsScope:                 {operation: "HistoryClientReplicateRawEvents", tags: map[string]string{CadenceRoleTagName: HistoryRoleTagValue}},
+		HistoryClientSyncShardStatusScope:                    {operation: "HistoryClientSyncShardStatusScope", tags: map[string]string{CadenceRoleTagName: HistoryRoleTagValue}},
+		HistoryClientSyncActivityScope:                       {operation: "HistoryClientSyncActivityScope", tags: map[string]string{CadenceRoleTagName: HistoryRoleTagValue}},
+		MatchingClientPollForDecisionTaskScope:               {operation: "MatchingClientPollForDecisionTask", tags: map[string]string{CadenceRoleTagName: MatchingRoleTagValue}},
+		MatchingClientPollForActivityTaskScope:               {operation: "MatchingClientPollForActivityTask", tags: map[string]string{CadenceRoleTagName: MatchingRoleTagValue}},
+		MatchingClientAddActivityTaskScope:                   {operation: "MatchingClientAddActivityTask", tags: map[string]string{CadenceRoleTagName: MatchingRoleTagValue}},
+		MatchingClientAddDecisionTaskScope:                   {operation: "MatchingClientAddDecisionTask", tags: map[string]string{CadenceRoleTagName: MatchingRoleTagValue}},
+		MatchingClientQueryWorkflowScope:                     {operation: "MatchingClientQueryWorkflow", tags: map[string]string{CadenceRoleTagName: MatchingRoleTagValue}},
+		MatchingClientRespondQueryTaskCompletedScope:         {operation: "MatchingClientRespondQueryTaskCompleted", tags: map[string]string{CadenceRoleTagName: MatchingRoleTagValue}},
+		MatchingClientCancelOutstandingPollScope:             {operation: "MatchingClientCancelOutstandingPoll", tags: map[string]string{CadenceRoleTagName: MatchingRoleTagValue}},
+		MatchingClientDescribeTaskListScope:                  {operation: "MatchingClientDescribeTaskList", tags: map[string]string{CadenceRoleTagName: MatchingRoleTagValue}},
+		MatchingClientGetTaskListScalingHintsScope:           {operation: "MatchingClientGetTaskListScalingHints", tags: map[string]string{CadenceRoleTagName: MatchingRoleTagValue}},
+		MatchingClientUpdateTaskListVersionSetsScope:         {operation: "MatchingClientUpdateTaskListVersionSets", tags: map[string]string{CadenceRoleTagName: MatchingRoleTagValue}},
+		MatchingClientDescribeTaskJourneyScope:               {operation: "MatchingClientDescribeTaskJourney", tags: map[string]string{CadenceRoleTagName: MatchingRoleTagValue}},
+		FrontendClientDeprecateDomainScope:                   {operation: "FrontendClientDeprecateDomain", tags: map[string]string{CadenceRoleTagName: FrontendRoleTagValue}},
+		FrontendClientDescribeDomainScope:                    {operation: "FrontendClientDescribeDomain", tags: map[string]string{CadenceRoleTagName: FrontendRoleTagValue}},
+		FrontendClientDescribeTaskListScope:                  {operation: "FrontendClientDescribeTaskList", tags: map[string]string{CadenceRoleTagName: FrontendRoleTagValue}},
+		FrontendClientGetTaskListScalingHintsScope:           {operation: "FrontendClientGetTaskListScalingHints", tags: map[string]string{CadenceRoleTagName: FrontendRoleTagValue}},
+		FrontendClientDescribeWorkflowExecutionScope:         {operation: "FrontendClientDescribeWorkflowExecution", tags: map[string]string{CadenceRoleTagName: FrontendRoleTagValue}},
+		FrontendClientGetWorkflowExecutionHistoryScope:       {operation: "FrontendClientGetWorkflowExecutionHistory", tags: map[string]string{CadenceRoleTagName: FrontendRoleTagValue}},
+		FrontendClientListClosedWorkflowExecutionsScope:      {operation: "FrontendClientListClosedWorkflowExecutions", tags: map[string]string{CadenceRoleTagName: FrontendRoleTagValue}},
+		FrontendClientListDomainsScope:                       {operation: "FrontendClientListDomains", tags: map[string]string{CadenceRoleTagName: FrontendRoleTagValue}},
+		FrontendClientListOpenWorkflowExecutionsScope:        {operation: "FrontendClientListOpenWorkflowExecutions", tags: map[string]string{CadenceRoleTagName: FrontendRoleTagValue}},
+		FrontendClientPollForActivityTaskScope:               {operation: "FrontendClientPollForActivityTask", tags: map[string]string{CadenceRoleTagName: FrontendRoleTagValue}},
+		FrontendClientPollForDecisionTaskScope:               {operation: "FrontendClientPollForDecisionTask", tags: map[string]string{CadenceRoleTagName: FrontendRoleTagValue}},
+		FrontendClientQueryWorkflowScope:                     {operation: "FrontendClientQueryWorkflow", tags: map[string]string{CadenceRoleTagName: FrontendRoleTagValue}},
+		FrontendClientRecordActivityTaskHeartbeatScope:       {operation: "FrontendClientRecordActivityTaskHeartbeat", tags: map[string]string{CadenceRoleTagName: FrontendRoleTagValue}},
+		FrontendClientRecordActivityTaskHeartbeatByIDScope:   {operation: "FrontendClientRecordActivityTaskHeartbeatByID", tags: map[string]string{CadenceRoleTagName: FrontendRoleTagValue}},
+		FrontendClientRegisterDomainScope:                    {operation: "FrontendClientRegisterDomain", tags: map[string]string{CadenceRoleTagName: FrontendRoleTagValue}},
+		FrontendClientRequestCancelWorkflowExecutionScope:    {operation: "FrontendClientRequestCancelWorkflowExecution", tags: map[string]string{CadenceRoleTagName: FrontendRoleTagValue}},
+		FrontendClientResetStickyTaskListScope:               {operation: "FrontendClientResetStickyTaskList", tags: map[string]string{CadenceRoleTagName: FrontendRoleTagValue}},
+		FrontendClientResetWorkflowExecutionScope:            {operation: "FrontendClientResetWorkflowExecution", tags: map[string]string{CadenceRoleTagName: FrontendRoleTagValue}},
+		FrontendClientRespondActivityTaskCanceledScope:       {operation: "FrontendClientRespondActivityTaskCanceled", tags: map[string]string{CadenceRoleTagName: FrontendRoleTagValue}},
+		FrontendClientRespondActivityTaskCanceledByIDScope:   {operation: "FrontendClientRespondActivityTaskCanceledByID", tags: map[string]string{CadenceRoleTagName: FrontendRoleTagValue}},
+		FrontendClientRespondActivityTaskCompletedScope:      {operation: "FrontendClientRespondActivityTaskCompleted", tags: map[string]string{CadenceRoleTagName: FrontendRoleTagValue}},
+		FrontendClientRespondActivityTaskCompletedByIDScope:  {operation: "FrontendClientRespondActivityTaskCompletedByID", tags: map[string]string{CadenceRoleTagName: FrontendRoleTagValue}},
+		FrontendClientRespondActivityTaskFailedScope:         {operation: "FrontendClientRespondActivityTaskFailed", tags: map[string]string{CadenceRoleTagName: FrontendRoleTagValue}},
+		FrontendClientRespondActivityTaskFailedByIDScope:     {operation: "FrontendClientRespondActivityTaskFailedByID", tags: map[string]string{CadenceRoleTagName: FrontendRoleTagValue}},
+		FrontendClientRespondDecisionTaskCompletedScope:      {operation: "FrontendClientRespondDecisionTaskCompleted", tags: map[string]string{CadenceRoleTagName: FrontendRoleTagValue}},
+		FrontendClientRespondDecisionTaskFailedScope:         {operation: "FrontendClientRespondDecisionTaskFailed", tags: map[string]string{CadenceRoleTagName: FrontendRoleTagValue}},
+		FrontendClientRespondQueryTaskCompletedScope:         {operation: "FrontendClientRespondQueryTaskCompleted", tags: map[string]string{CadenceRoleTagName: FrontendRoleTagValue}},
+		FrontendClientSignalWithStartWorkflowExecutionScope:  {operation: "FrontendClientSignalWithStartWorkflowExecution", tags: map[string]string{CadenceRoleTagName: FrontendRoleTagValue}},
+		FrontendClientSignalWorkflowExecutionScope:           {operation: "FrontendClientSignalWorkflowExecution", tags: map[string]string{CadenceRoleTagName: FrontendRoleTagValue}},
+		FrontendClientUpdateWorkflowExecutionScope:           {operation: "FrontendClientUpdateWorkflowExecution", tags: map[string]string{CadenceRoleTagName: FrontendRoleTagValue}},
+		FrontendClientStartWorkflowExecutionScope:            {operation: "FrontendClientStartWorkflowExecution", tags: map[string]string{CadenceRoleTagName: FrontendRoleTagValue}},
+		FrontendClientTerminateWorkflowExecutionScope:        {operation: "FrontendClientTerminateWorkflowExecution", tags: map[string]string{CadenceRoleTagName: FrontendRoleTagValue}},
+		FrontendClientUpdateDomainScope:                      {operation: "FrontendClientUpdateDomain", tags: map[string]string{CadenceRoleTagName: FrontendRoleTagValue}},
+		AdminClientDescribeHistoryHostScope:                  {operation: "AdminClientDescribeHistoryHost", tags: map[string]string{CadenceRoleTagName: AdminRoleTagValue}},
+		AdminClientDescribeWorkflowExecutionScope:            {operation: "AdminClientDescribeWorkflowExecution", tags: map[string]string{CadenceRoleTagName: AdminRoleTagValue}},
+		AdminClientGetWorkflowExecutionRawHistoryScope:       {operation: "AdminClientGetWorkflowExecutionRawHistory", tags: map[string]string{CadenceRoleTagName: AdminRoleTagValue}},
+		AdminClientSetActivityPausedScope:                    {operation: "AdminClientSetActivityPaused", tags: map[string]string{CadenceRoleTagName: AdminRoleTagValue}},
+		AdminClientDrainHistoryHostScope:                     {operation: "AdminClientDrainHistoryHost", tags: map[string]string{CadenceRoleTagName: AdminRoleTagValue}},
+		AdminClientUndrainHistoryHostScope:                   {operation: "AdminClientUndrainHistoryHost", tags: map[string]string{CadenceRoleTagName: AdminRoleTagValue}},
+		AdminClientDescribeClientVersionsScope:               {operation: "AdminClientDescribeClientVersions", tags: map[string]string{CadenceRoleTagName: AdminRoleTagValue}},
+		AdminClientGetDomainOpenExecutionCountScope:          {operation: "AdminClientGetDomainOpenExecutionCount", tags: map[string]string{CadenceRoleTagName: AdminRoleTagValue}},
+		AdminClientSetWorkflowExecutionNotesScope:            {operation: "AdminClientSetWorkflowExecutionNotes", tags: map[string]string{CadenceRoleTagName: AdminRoleTagValue}},
+		AdminClientSetWorkflowExecutionPausedScope:           {operation: "AdminClientSetWorkflowExecutionPaused", tags: map[string]string{CadenceRoleTagName: AdminRoleTagValue}},
+		AdminClientSetCronSchedulePausedScope:                {operation: "AdminClientSetCronSchedulePaused", tags: map[string]string{CadenceRoleTagName: AdminRoleTagValue}},
+		AdminClientTerminateAllWorkflowRunsScope:             {operation: "AdminClientTerminateAllWorkflowRuns", tags: map[string]string{CadenceRoleTagName: AdminRoleTagValue}},
+		AdminClientDescribeWorkflowShardScope:                {operation: "AdminClientDescribeWorkflowShard", tags: map[string]string{CadenceRoleTagName: AdminRoleTagValue}},
+		AdminClientListWorkflowExecutionRunsScope:            {operation: "AdminClientListWorkflowExecutionRuns", tags: map[string]string{CadenceRoleTagName: AdminRoleTagValue}},
+		AdminClientListRecentlyClosedExecutionsScope:         {operation: "AdminClientListRecentlyClosedExecutions", tags: map[string]string{CadenceRoleTagName: AdminRoleTagValue}},
+		AdminClientImportWorkflowExecutionScope:              {operation: "AdminClientImportWorkflowExecution", tags: map[string]string{CadenceRoleTagName: AdminRoleTagValue}},
+		AdminClientPurgeWorkflowExecutionScope:               {operation: "AdminClientPurgeWorkflowExecution", tags: map[string]string{CadenceRoleTagName: AdminRoleTagValue}},
+		AdminClientCreateScheduleScope:                       {operation: "AdminClientCreateSchedule", tags: map[string]string{CadenceRoleTagName: AdminRoleTagValue}},
+		AdminClientDescribeScheduleScope:                     {operation: "AdminClientDescribeSchedule", tags: map[string]string{CadenceRoleTagName: AdminRoleTagValue}},
+		AdminClientDeleteScheduleScope:                       {operation: "AdminClientDeleteSchedule", tags: map[string]string{CadenceRoleTagName: AdminRoleTagValue}},
+		AdminClientListSchedulesScope:                        {operation: "AdminClientListSchedules", tags: map[string]string{CadenceRoleTagName: AdminRoleTagValue}},
+		AdminClientSetSchedulePausedScope:                    {operation: "AdminClientSetSchedulePaused", tags: map[string]string{CadenceRoleTagName: AdminRoleTagValue}},
+		AdminClientBackfillScheduleScope:                     {operation: "AdminClientBackfillSchedule", tags: map[string]string{CadenceRoleTagName: AdminRoleTagValue}},
+		AdminClientUpdateTaskListVersionSetsScope:            {operation: "AdminClientUpdateTaskListVersionSets", tags: map[string]string{CadenceRoleTagName: AdminRoleTagValue}},
+		AdminClientDescribeTaskJourneyScope:                  {operation: "AdminClientDescribeTaskJourney", tags: map[string]string{CadenceRoleTagName: AdminRoleTagValue}},
+		DCRedirectionDeprecateDomainScope:                    {operation: "DCRedirectionDeprecateDomain", tags: map[string]string{CadenceRoleTagName: DCRedirectionRoleTagValue}},
+		DCRedirectionDescribeDomainScope:                     {operation: "DCRedirectionDescribeDomain", tags: map[string]string{CadenceRoleTagName: DCRedirectionRoleTagValue}},
+		DCRedirectionDescribeTaskListScope:                   {operation: "DCRedirectionDescribeTaskList", tags: map[string]string{CadenceRoleTagName: DCRedirectionRoleTagValue}},
+		DCRedirectionGetTaskListScalingHintsScope:            {operation: "DCRedirectionGetTaskListScalingHints", tags: map[string]string{CadenceRoleTagName: DCRedirectionRoleTagValue}},
+		DCRedirectionDescribeWorkflowExecutionScope:          {operation: "DCRedirectionDescribeWorkflowExecution", tags: map[string]string{CadenceRoleTagName: DCRedirectionRoleTagValue}},
+		DCRedirectionGetWorkflowExecutionHistoryScope:        {operation: "DCRedirectionGetWorkflowExecutionHistory", tags: map[string]string{CadenceRoleTagName: DCRedirectionRoleTagValue}},
+		DCRedirectionListClosedWorkflowExecutionsScope:       {operation: "DCRedirectionListClosedWorkflowExecutions", tags: map[string]string{CadenceRoleTagName: DCRedirectionRoleTagValue}},
+		DCRedirectionListDomainsScope:                        {operation: "DCRedirectionListDomains", tags: map[string]string{CadenceRoleTagName: DCRedirectionRoleTagValue}},
+		DCRedirectionListOpenWorkflowExecutionsScope:         {operation: "DCRedirectionListOpenWorkflowExecutions", tags: map[string]string{CadenceRoleTagName: DCRedirectionRoleTagValue}},
+		DCRedirectionListWorkflowExecutionsScope:             {operation: "DCRedirectionListWorkflowExecutions", tags: map[string]string{CadenceRoleTagName: DCRedirectionRoleTagValue}},
+		DCRedirectionScanWorkflowExecutionsScope:             {operation: "DCRedirectionScanWorkflowExecutions", tags: map[string]string{CadenceRoleTagName: DCRedirectionRoleTagValue}},
+		DCRedirectionCountWorkflowExecutionsScope:            {operation: "DCRedirectionCountWorkflowExecutions", tags: map[string]string{CadenceRoleTagName: DCRedirectionRoleTagValue}},
+		DCRedirectionGetSearchAttributesScope:                {operation: "DCRedirectionGetSearchAttributes", tags: map[string]string{CadenceRoleTagName: DCRedirectionRoleTagValue}},
+		DCRedirectionPollForActivityTaskScope:                {operation: "DCRedirectionPollForActivityTask", tags: map[string]string{CadenceRoleTagName: DCRedirectionRoleTagValue}},
+		DCRedirectionPollForDecisionTaskScope:                {operation: "DCRedirectionPollForDecisionTask", tags: map[string]string{CadenceRoleTagName: DCRedirectionRoleTagValue}},
+		DCRedirectionQueryWorkflowScope:                      {operation: "DCRedirectionQueryWorkflow", tags: map[string]string{CadenceRoleTagName: DCRedirectionRoleTagValue}},
+		DCRedirectionRecordActivityTaskHeartbeatScope:        {operation: "DCRedirectionRecordActivityTaskHeartbeat", tags: map[string]string{CadenceRoleTagName: DCRedirectionRoleTagValue}},
+		DCRedirectionRecordActivityTaskHeartbeatByIDScope:    {operation: "DCRedirectionRecordActivityTaskHeartbeatByID", tags: map[string]string{CadenceRoleTagName: DCRedirectionRoleTagValue}},
+		DCRedirectionRegisterDomainScope:                     {operation: "DCRedirectionRegisterDomain", tags: map[string]string{CadenceRoleTagName: DCRedirectionRoleTagValue}},
+		DCRedirectionRequestCancelWorkflowExecutionScope:     {operation: "DCRedirectionRequestCancelWorkflowExecution", tags: map[string]string{CadenceRoleTagName: DCRedirectionRoleTagValue}},
+		DCRedirectionResetStickyTaskListScope:                {operation: "DCRedirectionResetStickyTaskList", tags: map[string]string{CadenceRoleTagName: DCRedirectionRoleTagValue}},
+		DCRedirectionResetWorkflowExecutionScope:             {operation: "DCRedirectionResetWorkflowExecution", tags: map[string]string{CadenceRoleTagName: DCRedirectionRoleTagValue}},
+		DCRedirectionRespondActivityTaskCanceledScope:        {operation: "DCRedirectionRespondActivityTaskCanceled", tags: map[string]string{CadenceRoleTagName: DCRedirectionRoleTagValue}},
+		DCRedirectionRespondActivityTaskCanceledByIDScope:    {operation: "DCRedirectionRespondActivityTaskCanceledByID", tags: map[string]string{CadenceRoleTagName: DCRedirectionRoleTagValue}},
+		DCRedirectionRespondActivityTaskCompletedScope:       {operation: "DCRedirectionRespondActivityTaskCompleted", tags: map[string]string{CadenceRoleTagName: DCRedirectionRoleTagValue}},
+		DCRedirectionRespondActivityTaskCompletedByIDScope:   {operation: "DCRedirectionRespondActivityTaskCompletedByID", tags: map[string]string{CadenceRoleTagName: DCRedirectionRoleTagValue}},
+		DCRedirectionRespondActivityTaskFailedScope:          {operation: "DCRedirectionRespondActivityTaskFailed", tags: map[string]string{CadenceRoleTagName: DCRedirectionRoleTagValue}},
+		DCRedirectionRespondActivityTaskFailedByIDScope:      {operation: "DCRedirectionRespondActivityTaskFailedByID", tags: map[string]string{CadenceRoleTagName: DCRedirectionRoleTagValue}},
+		DCRedirectionRespondDecisionTaskCompletedScope:       {operation: "DCRedirectionRespondDecisionTaskCompleted", tags: map[string]string{CadenceRoleTagName: DCRedirectionRoleTagValue}},
+		DCRedirectionRespondDecisionTaskFailedScope:          {operation: "DCRedirectionRespondDecisionTaskFailed", tags: map[string]string{CadenceRoleTagName: DCRedirectionRoleTagValue}},
+		DCRedirectionRespondQueryTaskCompletedScope:          {operation: "DCRedirectionRespondQueryTaskCompleted", tags: map[string]string{CadenceRoleTagName: DCRedirectionRoleTagValue}},
+		DCRedirectionSignalWithStartWorkflowExecutionScope:   {operation: "DCRedirectionSignalWithStartWorkflowExecution", tags: map[string]string{CadenceRoleTagName: DCRedirectionRoleTagValue}},
+		DCRedirectionSignalWorkflowExecutionScope:            {operation: "DCRedirectionSignalWorkflowExecution", tags: map[string]string{CadenceRoleTagName: DCRedirectionRoleTagValue}},
+		DCRedirectionStartWorkflowExecutionScope:             {operation: "DCRedirectionStartWorkflowExecution", tags: map[string]string{CadenceRoleTagName: DCRedirectionRoleTagValue}},
+		DCRedirectionTerminateWorkflowExecutionScope:         {operation: "DCRedirectionTerminateWorkflowExecution", tags: map[string]string{CadenceRoleTagName: DCRedirectionRoleTagValue}},
+		DCRedirectionUpdateDomainScope:                       {operation: "DCRedirectionUpdateDomain", tags: map[string]string{CadenceRoleTagName: DCRedirectionRoleTagValue}},
+		DCRedirectionUpdateWorkflowExecutionScope:            {operation: "DCRedirectionUpdateWorkflowExecution", tags: map[string]string{CadenceRoleTagName: DCRedirectionRoleTagValue}},
 
 		MessagingClientPublishScope:      {operation: "MessagingClientPublish"},
 		MessagingClientPublishBatchScope: {operation: "MessagingClientPublishBatch"},
@@ -1065,6 +1371,7 @@ var ScopeDefs = map[ServiceIdx]map[int]scopeDefinition{
 		ElasticsearchScanWorkflowExecutionsScope:                   {operation: "ScanWorkflowExecutions"},
 		ElasticsearchCountWorkflowExecutionsScope:                  {operation: "CountWorkflowExecutions"},
 		SequentialTaskProcessingScope:                              {operation: "SequentialTaskProcessing"},
+		ClusterConnectionScope:                                     {operation: "ClusterConnection"},
 	},
 	// Frontend Scope Names
 	Frontend: {
@@ -1072,6 +1379,28 @@ var ScopeDefs = map[ServiceIdx]map[int]scopeDefinition{
 		AdminDescribeHistoryHostScope:            {operation: "DescribeHistoryHost"},
 		AdminDescribeWorkflowExecutionScope:      {operation: "DescribeWorkflowExecution"},
 		AdminGetWorkflowExecutionRawHistoryScope: {operation: "GetWorkflowExecutionRawHistory"},
+		AdminSetActivityPausedScope:              {operation: "SetActivityPaused"},
+		AdminDrainHistoryHostScope:               {operation: "DrainHistoryHost"},
+		AdminUndrainHistoryHostScope:             {operation: "UndrainHistoryHost"},
+		AdminDescribeClientVersionsScope:         {operation: "DescribeClientVersions"},
+		AdminGetDomainOpenExecutionCountScope:    {operation: "GetDomainOpenExecutionCount"},
+		AdminSetWorkflowExecutionNotesScope:      {operation: "SetWorkflowExecutionNotes"},
+		AdminSetWorkflowExecutionPausedScope:     {operation: "SetWorkflowExecutionPaused"},
+		AdminSetCronSchedulePausedScope:          {operation: "SetCronSchedulePaused"},
+		AdminTerminateAllWorkflowRunsScope:       {operation: "TerminateAllWorkflowRuns"},
+		AdminDescribeWorkflowShardScope:          {operation: "DescribeWorkflowShard"},
+		AdminListWorkflowExecutionRunsScope:      {operation: "ListWorkflowExecutionRuns"},
+		AdminListRecentlyClosedExecutionsScope:   {operation: "ListRecentlyClosedExecutions"},
+		AdminImportWorkflowExecutionScope:        {operation: "ImportWorkflowExecution"},
+		AdminPurgeWorkflowExecutionScope:         {operation: "PurgeWorkflowExecution"},
+		AdminCreateScheduleScope:                 {operation: "CreateSchedule"},
+		AdminDescribeScheduleScope:               {operation: "DescribeSchedule"},
+		AdminDeleteScheduleScope:                 {operation: "DeleteSchedule"},
+		AdminListSchedulesScope:                  {operation: "ListSchedules"},
+		AdminSetSchedulePausedScope:              {operation: "SetSchedulePaused"},
+		AdminBackfillScheduleScope:               {operation: "BackfillSchedule"},
+		AdminUpdateTaskListVersionSetsScope:      {operation: "UpdateTaskListVersionSets"},
+		AdminDescribeTaskJourneyScope:            {operation: "DescribeTaskJourney"},
 
 		FrontendStartWorkflowExecutionScope:           {operation: "StartWorkflowExecution"},
 		FrontendPollForDecisionTaskScope:              {operation: "PollForDecisionTask"},
@@ -1089,6 +1418,7 @@ var ScopeDefs = map[ServiceIdx]map[int]scopeDefinition{
 		FrontendRespondActivityTaskCanceledByIDScope:  {operation: "RespondActivityTaskCanceledByID"},
 		FrontendGetWorkflowExecutionHistoryScope:      {operation: "GetWorkflowExecutionHistory"},
 		FrontendSignalWorkflowExecutionScope:          {operation: "SignalWorkflowExecution"},
+		FrontendUpdateWorkflowExecutionScope:          {operation: "UpdateWorkflowExecution"},
 		FrontendSignalWithStartWorkflowExecutionScope: {operation: "SignalWithStartWorkflowExecution"},
 		FrontendTerminateWorkflowExecutionScope:       {operation: "TerminateWorkflowExecution"},
 		FrontendResetWorkflowExecutionScope:           {operation: "ResetWorkflowExecution"},
@@ -1106,6 +1436,7 @@ var ScopeDefs = map[ServiceIdx]map[int]scopeDefinition{
 		FrontendQueryWorkflowScope:                    {operation: "QueryWorkflow"},
 		FrontendDescribeWorkflowExecutionScope:        {operation: "DescribeWorkflowExecution"},
 		FrontendDescribeTaskListScope:                 {operation: "DescribeTaskList"},
+		FrontendGetTaskListScalingHintsScope:          {operation: "GetTaskListScalingHints"},
 		FrontendResetStickyTaskListScope:              {operation: "ResetStickyTaskList"},
 		FrontendGetSearchAttributesScope:              {operation: "GetSearchAttributes"},
 	},
@@ -1124,11 +1455,25 @@ var ScopeDefs = map[ServiceIdx]map[int]scopeDefinition{
 		HistoryRecordDecisionTaskStartedScope:                  {operation: "RecordDecisionTaskStarted"},
 		HistoryRecordActivityTaskStartedScope:                  {operation: "RecordActivityTaskStarted"},
 		HistorySignalWorkflowExecutionScope:                    {operation: "SignalWorkflowExecution"},
+		HistoryUpdateWorkflowExecutionScope:                    {operation: "UpdateWorkflowExecution"},
 		HistorySignalWithStartWorkflowExecutionScope:           {operation: "SignalWithStartWorkflowExecution"},
 		HistoryRemoveSignalMutableStateScope:                   {operation: "RemoveSignalMutableState"},
+		HistorySetActivityPausedScope:                          {operation: "SetActivityPaused"},
+		HistorySetWorkflowExecutionNotesScope:                  {operation: "SetWorkflowExecutionNotes"},
+		HistorySetWorkflowExecutionPausedScope:                 {operation: "SetWorkflowExecutionPaused"},
+		HistorySetCronSchedulePausedScope:                      {operation: "SetCronSchedulePaused"},
+		HistoryTerminateAllWorkflowRunsScope:                   {operation: "TerminateAllWorkflowRuns"},
+		HistoryImportWorkflowExecutionScope:                    {operation: "ImportWorkflowExecution"},
+		HistoryPurgeWorkflowExecutionScope:                     {operation: "PurgeWorkflowExecution"},
+		HistoryDrainHistoryHostScope:                           {operation: "DrainHistoryHost"},
+		HistoryUndrainHistoryHostScope:                         {operation: "UndrainHistoryHost"},
+		HistoryDescribeClientVersionsScope:                     {operation: "DescribeClientVersions"},
+		HistoryGetDomainOpenExecutionCountScope:                {operation: "GetDomainOpenExecutionCount"},
+		HistoryReconcileDomainOpenExecutionCountsScope:         {operation: "ReconcileDomainOpenExecutionCounts"},
 		HistoryTerminateWorkflowExecutionScope:                 {operation: "TerminateWorkflowExecution"},
 		HistoryResetWorkflowExecutionScope:                     {operation: "ResetWorkflowExecution"},
 		HistoryProcessDeleteHistoryEventScope:                  {operation: "ProcessDeleteHistoryEvent"},
+		HistoryRetentionSweepScope:                             {operation: "RetentionSweep"},
 		HistoryScheduleDecisionTaskScope:                       {operation: "ScheduleDecisionTask"},
 		HistoryRecordChildExecutionCompletedScope:              {operation: "RecordChildExecutionCompleted"},
 		HistoryRequestCancelWorkflowExecutionScope:             {operation: "RequestCancelWorkflowExecution"},
@@ -1150,6 +1495,7 @@ var ScopeDefs = map[ServiceIdx]map[int]scopeDefinition{
 		TransferActiveTaskRecordWorkflowStartedScope:           {operation: "TransferActiveTaskRecordWorkflowStarted"},
 		TransferActiveTaskResetWorkflowScope:                   {operation: "TransferActiveTaskResetWorkflow"},
 		TransferActiveTaskUpsertWorkflowSearchAttributesScope:  {operation: "TransferActiveTaskUpsertWorkflowSearchAttributes"},
+		TransferActiveTaskRecordChildExecutionCompletedScope:   {operation: "TransferActiveTaskRecordChildExecutionCompleted"},
 		TransferStandbyTaskActivityScope:                       {operation: "TransferStandbyTaskActivity"},
 		TransferStandbyTaskDecisionScope:                       {operation: "TransferStandbyTaskDecision"},
 		TransferStandbyTaskCloseExecutionScope:                 {operation: "TransferStandbyTaskCloseExecution"},
@@ -1159,6 +1505,7 @@ var ScopeDefs = map[ServiceIdx]map[int]scopeDefinition{
 		TransferStandbyTaskRecordWorkflowStartedScope:          {operation: "TransferStandbyTaskRecordWorkflowStarted"},
 		TransferStandbyTaskResetWorkflowScope:                  {operation: "TransferStandbyTaskResetWorkflow"},
 		TransferStandbyTaskUpsertWorkflowSearchAttributesScope: {operation: "TransferStandbyTaskUpsertWorkflowSearchAttributes"},
+		TransferStandbyTaskRecordChildExecutionCompletedScope:  {operation: "TransferStandbyTaskRecordChildExecutionCompleted"},
 		TimerQueueProcessorScope:                               {operation: "TimerQueueProcessor"},
 		TimerActiveQueueProcessorScope:                         {operation: "TimerActiveQueueProcessor"},
 		TimerStandbyQueueProcessorScope:                        {operation: "TimerStandbyQueueProcessor"},
@@ -1168,6 +1515,7 @@ var ScopeDefs = map[ServiceIdx]map[int]scopeDefinition{
 		TimerActiveTaskWorkflowTimeoutScope:                    {operation: "TimerActiveTaskWorkflowTimeout"},
 		TimerActiveTaskActivityRetryTimerScope:                 {operation: "TimerActiveTaskActivityRetryTimer"},
 		TimerActiveTaskWorkflowBackoffTimerScope:               {operation: "TimerActiveTaskWorkflowBackoffTimer"},
+		TimerActiveTaskWorkflowSLATimerScope:                   {operation: "TimerActiveTaskWorkflowSLATimer"},
 		TimerActiveTaskDeleteHistoryEventScope:                 {operation: "TimerActiveTaskDeleteHistoryEvent"},
 		TimerStandbyTaskActivityTimeoutScope:                   {operation: "TimerStandbyTaskActivityTimeout"},
 		TimerStandbyTaskDecisionTimeoutScope:                   {operation: "TimerStandbyTaskDecisionTimeout"},
@@ -1175,8 +1523,13 @@ var ScopeDefs = map[ServiceIdx]map[int]scopeDefinition{
 		TimerStandbyTaskWorkflowTimeoutScope:                   {operation: "TimerStandbyTaskWorkflowTimeout"},
 		TimerStandbyTaskActivityRetryTimerScope:                {operation: "TimerStandbyTaskActivityRetryTimer"},
 		TimerStandbyTaskWorkflowBackoffTimerScope:              {operation: "TimerStandbyTaskWorkflowBackoffTimer"},
+		TimerStandbyTaskWorkflowSLATimerScope:                  {operation: "TimerStandbyTaskWorkflowSLATimer"},
 		TimerStandbyTaskDeleteHistoryEventScope:                {operation: "TimerStandbyTaskDeleteHistoryEvent"},
 		HistoryEventNotificationScope:                          {operation: "HistoryEventNotification"},
+		VisibilityQueueProcessorScope:                          {operation: "VisibilityQueueProcessor"},
+		VisibilityTaskRecordWorkflowStartedScope:               {operation: "VisibilityTaskRecordWorkflowStarted"},
+		VisibilityTaskUpsertWorkflowSearchAttributesScope:      {operation: "VisibilityTaskUpsertWorkflowSearchAttributes"},
+		VisibilityTaskCloseExecutionScope:                      {operation: "VisibilityTaskCloseExecution"},
 		ReplicatorQueueProcessorScope:                          {operation: "ReplicatorQueueProcessor"},
 		ReplicatorTaskHistoryScope:                             {operation: "ReplicatorTaskHistory"},
 		ReplicatorTaskSyncActivityScope:                        {operation: "ReplicatorTaskSyncActivity"},
@@ -1187,6 +1540,7 @@ var ScopeDefs = map[ServiceIdx]map[int]scopeDefinition{
 		HistoryCacheGetOrCreateScope:                           {operation: "HistoryCacheGetOrCreate", tags: map[string]string{CacheTypeTagName: MutableStateCacheTypeTagValue}},
 		HistoryCacheGetOrCreateCurrentScope:                    {operation: "HistoryCacheGetOrCreateCurrent", tags: map[string]string{CacheTypeTagName: MutableStateCacheTypeTagValue}},
 		HistoryCacheGetCurrentExecutionScope:                   {operation: "HistoryCacheGetCurrentExecution", tags: map[string]string{CacheTypeTagName: MutableStateCacheTypeTagValue}},
+		HistoryCacheWarmupScope:                                {operation: "HistoryCacheWarmup", tags: map[string]string{CacheTypeTagName: MutableStateCacheTypeTagValue}},
 		EventsCacheGetEventScope:                               {operation: "EventsCacheGetEvent", tags: map[string]string{CacheTypeTagName: EventsCacheTypeTagValue}},
 		EventsCachePutEventScope:                               {operation: "EventsCachePutEvent", tags: map[string]string{CacheTypeTagName: EventsCacheTypeTagValue}},
 		EventsCacheDeleteEventScope:                            {operation: "EventsCacheDeleteEvent", tags: map[string]string{CacheTypeTagName: EventsCacheTypeTagValue}},
@@ -1209,25 +1563,35 @@ var ScopeDefs = map[ServiceIdx]map[int]scopeDefinition{
 		MatchingRespondQueryTaskCompletedScope: {operation: "RespondQueryTaskCompleted"},
 		MatchingCancelOutstandingPollScope:     {operation: "CancelOutstandingPoll"},
 		MatchingDescribeTaskListScope:          {operation: "DescribeTaskList"},
+		MatchingGetTaskListScalingHintsScope:   {operation: "GetTaskListScalingHints"},
+		MatchingUpdateTaskListVersionSetsScope: {operation: "UpdateTaskListVersionSets"},
+		MatchingDescribeTaskJourneyScope:       {operation: "DescribeTaskJourney"},
 	},
 	// Worker Scope Names
 	Worker: {
-		ReplicatorScope:                     {operation: "Replicator"},
-		DomainReplicationTaskScope:          {operation: "DomainReplicationTask"},
-		HistoryReplicationTaskScope:         {operation: "HistoryReplicationTask"},
-		HistoryMetadataReplicationTaskScope: {operation: "HistoryMetadataReplicationTask"},
-		SyncShardTaskScope:                  {operation: "SyncShardTask"},
-		SyncActivityTaskScope:               {operation: "SyncActivityTask"},
-		ESProcessorScope:                    {operation: "ESProcessor"},
-		IndexProcessorScope:                 {operation: "IndexProcessor"},
-		ArchiverUploadHistoryActivityScope:  {operation: "ArchiverUploadHistoryActivity"},
-		ArchiverDeleteHistoryActivityScope:  {operation: "ArchiverDeleteHistoryActivity"},
-		ArchiverDeleteBlobActivityScope:     {operation: "ArchiverDeleteBlobActivity"},
-		ArchiverScope:                       {operation: "Archiver"},
-		ArchiverPumpScope:                   {operation: "ArchiverPump"},
-		ArchiverArchivalWorkflowScope:       {operation: "ArchiverArchivalWorkflow"},
-		TaskListScavengerScope:              {operation: "tasklistscavenger"},
-		BatcherScope:                        {operation: "batcher"},
+		ReplicatorScope:                        {operation: "Replicator"},
+		DomainReplicationTaskScope:             {operation: "DomainReplicationTask"},
+		HistoryReplicationTaskScope:            {operation: "HistoryReplicationTask"},
+		HistoryMetadataReplicationTaskScope:    {operation: "HistoryMetadataReplicationTask"},
+		SyncShardTaskScope:                     {operation: "SyncShardTask"},
+		SyncActivityTaskScope:                  {operation: "SyncActivityTask"},
+		ESProcessorScope:                       {operation: "ESProcessor"},
+		IndexProcessorScope:                    {operation: "IndexProcessor"},
+		ArchiverUploadHistoryActivityScope:     {operation: "ArchiverUploadHistoryActivity"},
+		ArchiverDeleteHistoryActivityScope:     {operation: "ArchiverDeleteHistoryActivity"},
+		ArchiverDeleteBlobActivityScope:        {operation: "ArchiverDeleteBlobActivity"},
+		ArchiverScope:                          {operation: "Archiver"},
+		ArchiverPumpScope:                      {operation: "ArchiverPump"},
+		ArchiverArchivalWorkflowScope:          {operation: "ArchiverArchivalWorkflow"},
+		TaskListScavengerScope:                 {operation: "tasklistscavenger"},
+		BufferedReplicationTasksScavengerScope: {operation: "bufferedreplicationtasksscavenger"},
+		CurrentExecutionsScavengerScope:        {operation: "currentexecutionsscavenger"},
+		BatcherScope:                           {operation: "batcher"},
+		CanaryScope:                            {operation: "canary"},
+		CanaryStartWorkflowScope:               {operation: "CanaryStartWorkflow"},
+		CanarySignalWorkflowScope:              {operation: "CanarySignalWorkflow"},
+		CanaryQueryWorkflowScope:               {operation: "CanaryQueryWorkflow"},
+		SchedulerScope:                         {operation: "scheduler"},
 	},
 	// Blobstore Scope Names
 	Blobstore: {
@@ -1258,6 +1622,7 @@ const (
 	CadenceErrContextTimeoutCounter
 	CadenceErrRetryTaskCounter
 	CadenceErrClientVersionNotSupportedCounter
+	CadenceErrAccessDeniedCounter
 	PersistenceRequests
 	PersistenceFailures
 	PersistenceLatency
@@ -1271,7 +1636,10 @@ const (
 	PersistenceErrExecutionAlreadyStartedCounter
 	PersistenceErrDomainAlreadyExistsCounter
 	PersistenceErrBadRequestCounter
+	PersistenceErrTransactionSizeLimitCounter
 	PersistenceSampledCounter
+	PersistenceDualWriteSecondaryFailures
+	PersistenceDualWriteMismatches
 
 	CadenceClientRequests
 	CadenceClientFailures
@@ -1300,6 +1668,10 @@ const (
 	SequentialTaskQueueProcessingLatency
 	SequentialTaskTaskProcessingLatency
 
+	ClusterConnectionHealthCheckFailures
+	ClusterConnectionHostsEjected
+	ClusterConnectionHealthyHosts
+
 	NumCommonMetrics // Needs to be last on this list for iota numbering
 )
 
@@ -1313,12 +1685,14 @@ const (
 	TaskStandbyRetryCounter
 	TaskNotActiveCounter
 	TaskLimitExceededCounter
+	TaskDomainFrozenCounter
 	TaskBatchCompleteCounter
 	TaskProcessingLatency
 	TaskQueueLatency
 
 	AckLevelUpdateCounter
 	AckLevelUpdateFailedCounter
+	StuckQueueAlarmCounter
 	DecisionTypeScheduleActivityCounter
 	DecisionTypeCompleteWorkflowCounter
 	DecisionTypeFailWorkflowCounter
@@ -1340,6 +1714,7 @@ const (
 	ConcurrencyUpdateFailureCounter
 	CadenceErrEventAlreadyStartedCounter
 	CadenceErrShardOwnershipLostCounter
+	CadenceErrWorkflowExecutionPausedCounter
 	HeartbeatTimeoutCounter
 	ScheduleToStartTimeoutCounter
 	StartToCloseTimeoutCounter
@@ -1351,6 +1726,8 @@ const (
 	ShardClosedCounter
 	ShardItemCreatedCounter
 	ShardItemRemovedCounter
+	ShardStolenCounter
+	ShardLeaseRenewFailedCounter
 	ShardInfoReplicationPendingTasksTimer
 	ShardInfoTransferActivePendingTasksTimer
 	ShardInfoTransferStandbyPendingTasksTimer
@@ -1365,6 +1742,8 @@ const (
 	ShardInfoTimerFailoverInProgressTimer
 	ShardInfoTransferFailoverLatencyTimer
 	ShardInfoTimerFailoverLatencyTimer
+	ShardInfoStolenSinceRenewGauge
+	ShardInfoTasksPerSecondGauge
 	MembershipChangedCounter
 	NumShardsGauge
 	GetEngineForShardErrorCounter
@@ -1372,6 +1751,7 @@ const (
 	RemoveEngineForShardLatency
 	CompleteDecisionWithStickyEnabledCounter
 	CompleteDecisionWithStickyDisabledCounter
+	CompleteDecisionByClientVersionCounter
 	HistoryEventNotificationQueueingLatency
 	HistoryEventNotificationFanoutLatency
 	HistoryEventNotificationInFlightMessageGauge
@@ -1379,6 +1759,7 @@ const (
 	EmptyReplicationEventsCounter
 	DuplicateReplicationEventsCounter
 	StaleReplicationEventsCounter
+	ResidencyDroppedReplicationTasksCounter
 	ReplicationEventsSizeTimer
 	BufferReplicationTaskTimer
 	UnbufferReplicationTaskTimer
@@ -1408,16 +1789,23 @@ const (
 	DeleteChildInfoCount
 	DeleteSignalInfoCount
 	DeleteRequestCancelInfoCount
+	TransactionStatementCount
+	TransactionSize
 	WorkflowRetryBackoffTimerCount
 	WorkflowCronBackoffTimerCount
+	WorkflowSLAExceededCount
 	WorkflowCleanupDeleteCount
 	WorkflowCleanupArchiveCount
 	WorkflowCleanupNopCount
+	RetentionSweepExpiredCount
+	RetentionSweepRunCountExceededCount
 	WorkflowSuccessCount
 	WorkflowCancelCount
 	WorkflowFailedCount
 	WorkflowTimeoutCount
 	WorkflowTerminateCount
+	WorkflowHistoryLimitWarnCount
+	WorkflowHistoryLimitTerminateCount
 
 	NumHistoryMetrics
 )
@@ -1436,6 +1824,7 @@ const (
 	SyncMatchLatency
 	AsyncMatchLatency
 	ExpiredTasksCounter
+	ScheduleToStartLatency
 
 	NumMatchingMetrics
 )
@@ -1502,8 +1891,16 @@ const (
 	StoppedCount
 	ExecutorTasksDeferredCount
 	ExecutorTasksDroppedCount
+	BufferedReplicationTasksCount
+	BufferedReplicationTasksPurgedCount
+	DanglingCurrentExecutionsCount
+	DanglingCurrentExecutionsDeletedCount
+	CurrentExecutionStateMismatchCount
 	BatcherProcessorSuccess
 	BatcherProcessorFailures
+	ScheduleFiredCount
+	ScheduleSkippedCount
+	ScheduleMissedCount
 	NumWorkerMetrics
 )
 
@@ -1526,6 +1923,7 @@ var MetricDefs = map[ServiceIdx]map[int]metricDefinition{
 		CadenceErrContextTimeoutCounter:                     {metricName: "cadence_errors_context_timeout", metricType: Counter},
 		CadenceErrRetryTaskCounter:                          {metricName: "cadence_errors_retry_task", metricType: Counter},
 		CadenceErrClientVersionNotSupportedCounter:          {metricName: "cadence_errors_client_version_not_supported", metricType: Counter},
+		CadenceErrAccessDeniedCounter:                       {metricName: "cadence_errors_access_denied", metricType: Counter},
 		PersistenceRequests:                                 {metricName: "persistence_requests", metricType: Counter},
 		PersistenceFailures:                                 {metricName: "persistence_errors", metricType: Counter},
 		PersistenceLatency:                                  {metricName: "persistence_latency", metricType: Timer},
@@ -1539,7 +1937,10 @@ var MetricDefs = map[ServiceIdx]map[int]metricDefinition{
 		PersistenceErrExecutionAlreadyStartedCounter:        {metricName: "persistence_errors_execution_already_started", metricType: Counter},
 		PersistenceErrDomainAlreadyExistsCounter:            {metricName: "persistence_errors_domain_already_exists", metricType: Counter},
 		PersistenceErrBadRequestCounter:                     {metricName: "persistence_errors_bad_request", metricType: Counter},
+		PersistenceErrTransactionSizeLimitCounter:           {metricName: "persistence_errors_transaction_size_limit", metricType: Counter},
 		PersistenceSampledCounter:                           {metricName: "persistence_sampled", metricType: Counter},
+		PersistenceDualWriteSecondaryFailures:               {metricName: "persistence_dual_write_secondary_errors", metricType: Counter},
+		PersistenceDualWriteMismatches:                      {metricName: "persistence_dual_write_mismatches", metricType: Counter},
 		CadenceClientRequests:                               {metricName: "cadence_client_requests", metricType: Counter},
 		CadenceClientFailures:                               {metricName: "cadence_client_errors", metricType: Counter},
 		CadenceClientLatency:                                {metricName: "cadence_client_latency", metricType: Timer},
@@ -1561,6 +1962,9 @@ var MetricDefs = map[ServiceIdx]map[int]metricDefinition{
 		SequentialTaskQueueSize:                             {metricName: "sequentialtask_queue_size", metricType: Timer},
 		SequentialTaskQueueProcessingLatency:                {metricName: "sequentialtask_queue_processing_latency", metricType: Timer},
 		SequentialTaskTaskProcessingLatency:                 {metricName: "sequentialtask_task_processing_latency", metricType: Timer},
+		ClusterConnectionHealthCheckFailures:                {metricName: "cluster_connection_health_check_failures", metricType: Counter},
+		ClusterConnectionHostsEjected:                       {metricName: "cluster_connection_hosts_ejected", metricType: Counter},
+		ClusterConnectionHealthyHosts:                       {metricName: "cluster_connection_healthy_hosts", metricType: Gauge},
 	},
 	Frontend: {},
 	History: {
@@ -1572,11 +1976,13 @@ var MetricDefs = map[ServiceIdx]map[int]metricDefinition{
 		TaskStandbyRetryCounter:                           {metricName: "task_errors_standby_retry_counter", metricType: Counter},
 		TaskNotActiveCounter:                              {metricName: "task_errors_not_active_counter", metricType: Counter},
 		TaskLimitExceededCounter:                          {metricName: "task_errors_limit_exceeded_counter", metricType: Counter},
+		TaskDomainFrozenCounter:                           {metricName: "task_errors_domain_frozen_counter", metricType: Counter},
 		TaskProcessingLatency:                             {metricName: "task_latency_processing", metricType: Timer},
 		TaskQueueLatency:                                  {metricName: "task_latency_queue", metricType: Timer},
 		TaskBatchCompleteCounter:                          {metricName: "task_batch_complete_counter", metricType: Counter},
 		AckLevelUpdateCounter:                             {metricName: "ack_level_update", metricType: Counter},
 		AckLevelUpdateFailedCounter:                       {metricName: "ack_level_update_failed", metricType: Counter},
+		StuckQueueAlarmCounter:                            {metricName: "stuck_queue_alarm", metricType: Counter},
 		DecisionTypeScheduleActivityCounter:               {metricName: "schedule_activity_decision", metricType: Counter},
 		DecisionTypeCompleteWorkflowCounter:               {metricName: "complete_workflow_decision", metricType: Counter},
 		DecisionTypeFailWorkflowCounter:                   {metricName: "fail_workflow_decision", metricType: Counter},
@@ -1598,6 +2004,7 @@ var MetricDefs = map[ServiceIdx]map[int]metricDefinition{
 		ConcurrencyUpdateFailureCounter:                   {metricName: "concurrency_update_failure", metricType: Counter},
 		CadenceErrShardOwnershipLostCounter:               {metricName: "cadence_errors_shard_ownership_lost", metricType: Counter},
 		CadenceErrEventAlreadyStartedCounter:              {metricName: "cadence_errors_event_already_started", metricType: Counter},
+		CadenceErrWorkflowExecutionPausedCounter:          {metricName: "cadence_errors_workflow_execution_paused", metricType: Counter},
 		HeartbeatTimeoutCounter:                           {metricName: "heartbeat_timeout", metricType: Counter},
 		ScheduleToStartTimeoutCounter:                     {metricName: "schedule_to_start_timeout", metricType: Counter},
 		StartToCloseTimeoutCounter:                        {metricName: "start_to_close_timeout", metricType: Counter},
@@ -1609,6 +2016,8 @@ var MetricDefs = map[ServiceIdx]map[int]metricDefinition{
 		ShardClosedCounter:                                {metricName: "shard_closed_count", metricType: Counter},
 		ShardItemCreatedCounter:                           {metricName: "sharditem_created_count", metricType: Counter},
 		ShardItemRemovedCounter:                           {metricName: "sharditem_removed_count", metricType: Counter},
+		ShardStolenCounter:                                {metricName: "shard_stolen_count", metricType: Counter},
+		ShardLeaseRenewFailedCounter:                      {metricName: "shard_lease_renew_failed_count", metricType: Counter},
 		ShardInfoReplicationPendingTasksTimer:             {metricName: "shardinfo_replication_pending_task", metricType: Timer},
 		ShardInfoTransferActivePendingTasksTimer:          {metricName: "shardinfo_transfer_active_pending_task", metricType: Timer},
 		ShardInfoTransferStandbyPendingTasksTimer:         {metricName: "shardinfo_transfer_standby_pending_task", metricType: Timer},
@@ -1623,6 +2032,8 @@ var MetricDefs = map[ServiceIdx]map[int]metricDefinition{
 		ShardInfoTimerFailoverInProgressTimer:             {metricName: "shardinfo_timer_failover_in_progress", metricType: Timer},
 		ShardInfoTransferFailoverLatencyTimer:             {metricName: "shardinfo_transfer_failover_latency", metricType: Timer},
 		ShardInfoTimerFailoverLatencyTimer:                {metricName: "shardinfo_timer_failover_latency", metricType: Timer},
+		ShardInfoStolenSinceRenewGauge:                    {metricName: "shardinfo_stolen_since_renew", metricType: Gauge},
+		ShardInfoTasksPerSecondGauge:                      {metricName: "shardinfo_tasks_per_second", metricType: Gauge},
 		MembershipChangedCounter:                          {metricName: "membership_changed_count", metricType: Counter},
 		NumShardsGauge:                                    {metricName: "numshards_gauge", metricType: Gauge},
 		GetEngineForShardErrorCounter:                     {metricName: "get_engine_for_shard_errors", metricType: Counter},
@@ -1630,6 +2041,7 @@ var MetricDefs = map[ServiceIdx]map[int]metricDefinition{
 		RemoveEngineForShardLatency:                       {metricName: "remove_engine_for_shard_latency", metricType: Timer},
 		CompleteDecisionWithStickyEnabledCounter:          {metricName: "complete_decision_sticky_enabled_count", metricType: Counter},
 		CompleteDecisionWithStickyDisabledCounter:         {metricName: "complete_decision_sticky_disabled_count", metricType: Counter},
+		CompleteDecisionByClientVersionCounter:            {metricName: "complete_decision_by_client_version_count", metricType: Counter},
 		HistoryEventNotificationQueueingLatency:           {metricName: "history_event_notification_queueing_latency", metricType: Timer},
 		HistoryEventNotificationFanoutLatency:             {metricName: "history_event_notification_fanout_latency", metricType: Timer},
 		HistoryEventNotificationInFlightMessageGauge:      {metricName: "history_event_notification_inflight_message_gauge", metricType: Gauge},
@@ -1637,6 +2049,7 @@ var MetricDefs = map[ServiceIdx]map[int]metricDefinition{
 		EmptyReplicationEventsCounter:                     {metricName: "empty_replication_events", metricType: Counter},
 		DuplicateReplicationEventsCounter:                 {metricName: "duplicate_replication_events", metricType: Counter},
 		StaleReplicationEventsCounter:                     {metricName: "stale_replication_events", metricType: Counter},
+		ResidencyDroppedReplicationTasksCounter:           {metricName: "residency_dropped_replication_tasks", metricType: Counter},
 		ReplicationEventsSizeTimer:                        {metricName: "replication_events_size", metricType: Timer},
 		BufferReplicationTaskTimer:                        {metricName: "buffer_replication_tasks", metricType: Timer},
 		UnbufferReplicationTaskTimer:                      {metricName: "unbuffer_replication_tasks", metricType: Timer},
@@ -1666,16 +2079,23 @@ var MetricDefs = map[ServiceIdx]map[int]metricDefinition{
 		DeleteChildInfoCount:                              {metricName: "delete_child_info", metricType: Timer},
 		DeleteSignalInfoCount:                             {metricName: "delete_signal_info", metricType: Timer},
 		DeleteRequestCancelInfoCount:                      {metricName: "delete_request_cancel_info", metricType: Timer},
+		TransactionStatementCount:                         {metricName: "transaction_statement_count", metricType: Timer},
+		TransactionSize:                                   {metricName: "transaction_size", metricType: Timer},
 		WorkflowRetryBackoffTimerCount:                    {metricName: "workflow_retry_backoff_timer", metricType: Counter},
 		WorkflowCronBackoffTimerCount:                     {metricName: "workflow_cron_backoff_timer", metricType: Counter},
+		WorkflowSLAExceededCount:                          {metricName: "workflow_sla_exceeded", metricType: Counter},
 		WorkflowCleanupDeleteCount:                        {metricName: "workflow_cleanup_delete", metricType: Counter},
 		WorkflowCleanupArchiveCount:                       {metricName: "workflow_cleanup_archive", metricType: Counter},
 		WorkflowCleanupNopCount:                           {metricName: "workflow_cleanup_nop", metricType: Counter},
+		RetentionSweepExpiredCount:                        {metricName: "retention_sweep_expired", metricType: Counter},
+		RetentionSweepRunCountExceededCount:               {metricName: "retention_sweep_run_count_exceeded", metricType: Counter},
 		WorkflowSuccessCount:                              {metricName: "workflow_success", metricType: Counter},
 		WorkflowCancelCount:                               {metricName: "workflow_cancel", metricType: Counter},
 		WorkflowFailedCount:                               {metricName: "workflow_failed", metricType: Counter},
 		WorkflowTimeoutCount:                              {metricName: "workflow_timeout", metricType: Counter},
 		WorkflowTerminateCount:                            {metricName: "workflow_terminate", metricType: Counter},
+		WorkflowHistoryLimitWarnCount:                     {metricName: "workflow_history_limit_warn", metricType: Counter},
+		WorkflowHistoryLimitTerminateCount:                {metricName: "workflow_history_limit_terminate", metricType: Counter},
 	},
 	Matching: {
 		PollSuccessCounter:            {metricName: "poll_success"},
@@ -1690,6 +2110,7 @@ var MetricDefs = map[ServiceIdx]map[int]metricDefinition{
 		ExpiredTasksCounter:           {metricName: "tasks_expired"},
 		SyncMatchLatency:              {metricName: "syncmatch_latency", metricType: Timer},
 		AsyncMatchLatency:             {metricName: "asyncmatch_latency", metricType: Timer},
+		ScheduleToStartLatency:        {metricName: "schedule_to_start_latency", metricType: Timer},
 	},
 	Worker: {
 		ReplicatorMessages:                                     {metricName: "replicator_messages"},
@@ -1752,8 +2173,16 @@ var MetricDefs = map[ServiceIdx]map[int]metricDefinition{
 		StoppedCount:                                           {metricName: "stopped", metricType: Counter},
 		ExecutorTasksDeferredCount:                             {metricName: "executor_deferred", metricType: Counter},
 		ExecutorTasksDroppedCount:                              {metricName: "executor_dropped", metricType: Counter},
+		BufferedReplicationTasksCount:                          {metricName: "buffered_replication_tasks", metricType: Counter},
+		BufferedReplicationTasksPurgedCount:                    {metricName: "buffered_replication_tasks_purged", metricType: Counter},
+		DanglingCurrentExecutionsCount:                         {metricName: "dangling_current_executions", metricType: Counter},
+		DanglingCurrentExecutionsDeletedCount:                  {metricName: "dangling_current_executions_deleted", metricType: Counter},
+		CurrentExecutionStateMismatchCount:                     {metricName: "current_execution_state_mismatches", metricType: Counter},
 		BatcherProcessorSuccess:                                {metricName: "batcher_processor_requests", metricType: Counter},
 		BatcherProcessorFailures:                               {metricName: "batcher_processor_errors", metricType: Counter},
+		ScheduleFiredCount:                                     {metricName: "schedule_fired", metricType: Counter},
+		ScheduleSkippedCount:                                   {metricName: "schedule_skipped", metricType: Counter},
+		ScheduleMissedCount:                                    {metricName: "schedule_missed", metricType: Counter},
 	},
 }
 
@@ -30,6 +30,11 @@ const (
 	instance      = "instance"
 	domain        = "domain"
 	targetCluster = "target_cluster"
+	targetAddress = "target_address"
+	taskList      = "tasklist"
+
+	clientImpl           = "client_impl"
+	clientFeatureVersion = "client_feature_version"
 
 	domainAllValue = "all"
 	unknownValue   = "_unknown_"
@@ -55,6 +60,22 @@ type (
 	targetClusterTag struct {
 		value string
 	}
+
+	targetAddressTag struct {
+		value string
+	}
+
+	taskListTag struct {
+		value string
+	}
+
+	clientImplTag struct {
+		value string
+	}
+
+	clientFeatureVersionTag struct {
+		value string
+	}
 )
 
 // DomainTag returns a new domain tag. For timers, this also ensures that we
@@ -124,3 +145,75 @@ func (d targetClusterTag) Key() string {
 func (d targetClusterTag) Value() string {
 	return d.value
 }
+
+// TargetAddressTag returns a new target address tag.
+func TargetAddressTag(value string) Tag {
+	if len(value) == 0 {
+		value = unknownValue
+	}
+	return targetAddressTag{value}
+}
+
+// Key returns the key of the target address tag
+func (d targetAddressTag) Key() string {
+	return targetAddress
+}
+
+// Value returns the value of a target address tag
+func (d targetAddressTag) Value() string {
+	return d.value
+}
+
+// TaskListTag returns a new task list tag.
+func TaskListTag(value string) Tag {
+	if len(value) == 0 {
+		value = unknownValue
+	}
+	return taskListTag{value}
+}
+
+// Key returns the key of the task list tag
+func (t taskListTag) Key() string {
+	return taskList
+}
+
+// Value returns the value of a task list tag
+func (t taskListTag) Value() string {
+	return t.value
+}
+
+// ClientImplTag returns a new client implementation tag.
+func ClientImplTag(value string) Tag {
+	if len(value) == 0 {
+		value = unknownValue
+	}
+	return clientImplTag{value}
+}
+
+// Key returns the key of the client implementation tag
+func (c clientImplTag) Key() string {
+	return clientImpl
+}
+
+// Value returns the value of a client implementation tag
+func (c clientImplTag) Value() string {
+	return c.value
+}
+
+// ClientFeatureVersionTag returns a new client feature version tag.
+func ClientFeatureVersionTag(value string) Tag {
+	if len(value) == 0 {
+		value = unknownValue
+	}
+	return clientFeatureVersionTag{value}
+}
+
+// Key returns the key of the client feature version tag
+func (c clientFeatureVersionTag) Key() string {
+	return clientFeatureVersion
+}
+
+// Value returns the value of a client feature version tag
+func (c clientFeatureVersionTag) Value() string {
+	return c.value
+}
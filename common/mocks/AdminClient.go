@@ -60,6 +60,121 @@ func (_m *AdminClient) DescribeHistoryHost(ctx context.Context, request *shared.
 	return r0, r1
 }
 
+// DescribeTaskJourney provides a mock function with given fields: ctx, request
+func (_m *AdminClient) DescribeTaskJourney(ctx context.Context, request *shared.DescribeTaskJourneyRequest, opts ...yarpc.CallOption) (*shared.DescribeTaskJourneyResponse, error) {
+	ret := _m.Called(ctx, request)
+
+	var r0 *shared.DescribeTaskJourneyResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *shared.DescribeTaskJourneyRequest) *shared.DescribeTaskJourneyResponse); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*shared.DescribeTaskJourneyResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *shared.DescribeTaskJourneyRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DrainHistoryHost provides a mock function with given fields: ctx, request
+func (_m *AdminClient) DrainHistoryHost(ctx context.Context, request *shared.DrainHistoryHostRequest, opts ...yarpc.CallOption) (*shared.DrainHistoryHostResponse, error) {
+	ret := _m.Called(ctx, request)
+
+	var r0 *shared.DrainHistoryHostResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *shared.DrainHistoryHostRequest) *shared.DrainHistoryHostResponse); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*shared.DrainHistoryHostResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *shared.DrainHistoryHostRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UndrainHistoryHost provides a mock function with given fields: ctx, request
+func (_m *AdminClient) UndrainHistoryHost(ctx context.Context, request *shared.UndrainHistoryHostRequest, opts ...yarpc.CallOption) (*shared.UndrainHistoryHostResponse, error) {
+	ret := _m.Called(ctx, request)
+
+	var r0 *shared.UndrainHistoryHostResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *shared.UndrainHistoryHostRequest) *shared.UndrainHistoryHostResponse); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*shared.UndrainHistoryHostResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *shared.UndrainHistoryHostRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DescribeClientVersions provides a mock function with given fields: ctx, request
+func (_m *AdminClient) DescribeClientVersions(ctx context.Context, request *shared.DescribeClientVersionsRequest, opts ...yarpc.CallOption) (*shared.DescribeClientVersionsResponse, error) {
+	ret := _m.Called(ctx, request)
+
+	var r0 *shared.DescribeClientVersionsResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *shared.DescribeClientVersionsRequest) *shared.DescribeClientVersionsResponse); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*shared.DescribeClientVersionsResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *shared.DescribeClientVersionsRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetDomainOpenExecutionCount provides a mock function with given fields: ctx, request
+func (_m *AdminClient) GetDomainOpenExecutionCount(ctx context.Context, request *shared.GetDomainOpenExecutionCountRequest, opts ...yarpc.CallOption) (*shared.GetDomainOpenExecutionCountResponse, error) {
+	ret := _m.Called(ctx, request)
+
+	var r0 *shared.GetDomainOpenExecutionCountResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *shared.GetDomainOpenExecutionCountRequest) *shared.GetDomainOpenExecutionCountResponse); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*shared.GetDomainOpenExecutionCountResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *shared.GetDomainOpenExecutionCountRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // DescribeWorkflowExecution provides a mock function with given fields: ctx, request
 func (_m *AdminClient) DescribeWorkflowExecution(ctx context.Context, request *admin.DescribeWorkflowExecutionRequest, opts ...yarpc.CallOption) (*admin.DescribeWorkflowExecutionResponse, error) {
 	ret := _m.Called(ctx, request)
@@ -83,6 +198,121 @@ func (_m *AdminClient) DescribeWorkflowExecution(ctx context.Context, request *a
 	return r0, r1
 }
 
+// DescribeWorkflowShard provides a mock function with given fields: ctx, request
+func (_m *AdminClient) DescribeWorkflowShard(ctx context.Context, request *admin.DescribeWorkflowShardRequest, opts ...yarpc.CallOption) (*admin.DescribeWorkflowShardResponse, error) {
+	ret := _m.Called(ctx, request)
+
+	var r0 *admin.DescribeWorkflowShardResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *admin.DescribeWorkflowShardRequest) *admin.DescribeWorkflowShardResponse); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*admin.DescribeWorkflowShardResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *admin.DescribeWorkflowShardRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListWorkflowExecutionRuns provides a mock function with given fields: ctx, request
+func (_m *AdminClient) ListWorkflowExecutionRuns(ctx context.Context, request *admin.ListWorkflowExecutionRunsRequest, opts ...yarpc.CallOption) (*admin.ListWorkflowExecutionRunsResponse, error) {
+	ret := _m.Called(ctx, request)
+
+	var r0 *admin.ListWorkflowExecutionRunsResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *admin.ListWorkflowExecutionRunsRequest) *admin.ListWorkflowExecutionRunsResponse); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*admin.ListWorkflowExecutionRunsResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *admin.ListWorkflowExecutionRunsRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListRecentlyClosedExecutions provides a mock function with given fields: ctx, request
+func (_m *AdminClient) ListRecentlyClosedExecutions(ctx context.Context, request *admin.ListRecentlyClosedExecutionsRequest, opts ...yarpc.CallOption) (*admin.ListRecentlyClosedExecutionsResponse, error) {
+	ret := _m.Called(ctx, request)
+
+	var r0 *admin.ListRecentlyClosedExecutionsResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *admin.ListRecentlyClosedExecutionsRequest) *admin.ListRecentlyClosedExecutionsResponse); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*admin.ListRecentlyClosedExecutionsResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *admin.ListRecentlyClosedExecutionsRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ImportWorkflowExecution provides a mock function with given fields: ctx, request
+func (_m *AdminClient) ImportWorkflowExecution(ctx context.Context, request *admin.ImportWorkflowExecutionRequest, opts ...yarpc.CallOption) (*admin.ImportWorkflowExecutionResponse, error) {
+	ret := _m.Called(ctx, request)
+
+	var r0 *admin.ImportWorkflowExecutionResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *admin.ImportWorkflowExecutionRequest) *admin.ImportWorkflowExecutionResponse); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*admin.ImportWorkflowExecutionResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *admin.ImportWorkflowExecutionRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PurgeWorkflowExecution provides a mock function with given fields: ctx, request
+func (_m *AdminClient) PurgeWorkflowExecution(ctx context.Context, request *admin.PurgeWorkflowExecutionRequest, opts ...yarpc.CallOption) (*admin.PurgeWorkflowExecutionResponse, error) {
+	ret := _m.Called(ctx, request)
+
+	var r0 *admin.PurgeWorkflowExecutionResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *admin.PurgeWorkflowExecutionRequest) *admin.PurgeWorkflowExecutionResponse); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*admin.PurgeWorkflowExecutionResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *admin.PurgeWorkflowExecutionRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetWorkflowExecutionRawHistory provides a mock function with given fields: ctx, request
 func (_m *AdminClient) GetWorkflowExecutionRawHistory(ctx context.Context, request *admin.GetWorkflowExecutionRawHistoryRequest, opts ...yarpc.CallOption) (*admin.GetWorkflowExecutionRawHistoryResponse, error) {
 	ret := _m.Called(ctx, request)
@@ -105,3 +335,198 @@ func (_m *AdminClient) GetWorkflowExecutionRawHistory(ctx context.Context, reque
 
 	return r0, r1
 }
+
+// SetActivityPaused provides a mock function with given fields: ctx, request
+func (_m *AdminClient) SetActivityPaused(ctx context.Context, request *shared.SetActivityPausedRequest, opts ...yarpc.CallOption) error {
+	ret := _m.Called(ctx, request)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *shared.SetActivityPausedRequest) error); ok {
+		r0 = rf(ctx, request)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetWorkflowExecutionNotes provides a mock function with given fields: ctx, request
+func (_m *AdminClient) SetWorkflowExecutionNotes(ctx context.Context, request *shared.SetWorkflowExecutionNotesRequest, opts ...yarpc.CallOption) error {
+	ret := _m.Called(ctx, request)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *shared.SetWorkflowExecutionNotesRequest) error); ok {
+		r0 = rf(ctx, request)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetWorkflowExecutionPaused provides a mock function with given fields: ctx, request
+func (_m *AdminClient) SetWorkflowExecutionPaused(ctx context.Context, request *shared.SetWorkflowExecutionPausedRequest, opts ...yarpc.CallOption) error {
+	ret := _m.Called(ctx, request)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *shared.SetWorkflowExecutionPausedRequest) error); ok {
+		r0 = rf(ctx, request)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetCronSchedulePaused provides a mock function with given fields: ctx, request
+func (_m *AdminClient) SetCronSchedulePaused(ctx context.Context, request *shared.SetCronSchedulePausedRequest, opts ...yarpc.CallOption) error {
+	ret := _m.Called(ctx, request)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *shared.SetCronSchedulePausedRequest) error); ok {
+		r0 = rf(ctx, request)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// TerminateAllWorkflowRuns provides a mock function with given fields: ctx, request
+func (_m *AdminClient) TerminateAllWorkflowRuns(ctx context.Context, request *shared.TerminateAllWorkflowRunsRequest, opts ...yarpc.CallOption) (*shared.TerminateAllWorkflowRunsResponse, error) {
+	ret := _m.Called(ctx, request)
+
+	var r0 *shared.TerminateAllWorkflowRunsResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *shared.TerminateAllWorkflowRunsRequest) *shared.TerminateAllWorkflowRunsResponse); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*shared.TerminateAllWorkflowRunsResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *shared.TerminateAllWorkflowRunsRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateSchedule provides a mock function with given fields: ctx, request
+func (_m *AdminClient) CreateSchedule(ctx context.Context, request *shared.CreateScheduleRequest, opts ...yarpc.CallOption) error {
+	ret := _m.Called(ctx, request)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *shared.CreateScheduleRequest) error); ok {
+		r0 = rf(ctx, request)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DescribeSchedule provides a mock function with given fields: ctx, request
+func (_m *AdminClient) DescribeSchedule(ctx context.Context, request *shared.DescribeScheduleRequest, opts ...yarpc.CallOption) (*shared.DescribeScheduleResponse, error) {
+	ret := _m.Called(ctx, request)
+
+	var r0 *shared.DescribeScheduleResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *shared.DescribeScheduleRequest) *shared.DescribeScheduleResponse); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*shared.DescribeScheduleResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *shared.DescribeScheduleRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteSchedule provides a mock function with given fields: ctx, request
+func (_m *AdminClient) DeleteSchedule(ctx context.Context, request *shared.DeleteScheduleRequest, opts ...yarpc.CallOption) error {
+	ret := _m.Called(ctx, request)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *shared.DeleteScheduleRequest) error); ok {
+		r0 = rf(ctx, request)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ListSchedules provides a mock function with given fields: ctx, request
+func (_m *AdminClient) ListSchedules(ctx context.Context, request *shared.ListSchedulesRequest, opts ...yarpc.CallOption) (*shared.ListSchedulesResponse, error) {
+	ret := _m.Called(ctx, request)
+
+	var r0 *shared.ListSchedulesResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *shared.ListSchedulesRequest) *shared.ListSchedulesResponse); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*shared.ListSchedulesResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *shared.ListSchedulesRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SetSchedulePaused provides a mock function with given fields: ctx, request
+func (_m *AdminClient) SetSchedulePaused(ctx context.Context, request *shared.SetSchedulePausedRequest, opts ...yarpc.CallOption) error {
+	ret := _m.Called(ctx, request)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *shared.SetSchedulePausedRequest) error); ok {
+		r0 = rf(ctx, request)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// BackfillSchedule provides a mock function with given fields: ctx, request
+func (_m *AdminClient) BackfillSchedule(ctx context.Context, request *shared.BackfillScheduleRequest, opts ...yarpc.CallOption) error {
+	ret := _m.Called(ctx, request)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *shared.BackfillScheduleRequest) error); ok {
+		r0 = rf(ctx, request)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateTaskListVersionSets provides a mock function with given fields: ctx, request
+func (_m *AdminClient) UpdateTaskListVersionSets(ctx context.Context, request *shared.UpdateTaskListVersionSetsRequest, opts ...yarpc.CallOption) error {
+	ret := _m.Called(ctx, request)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *shared.UpdateTaskListVersionSetsRequest) error); ok {
+		r0 = rf(ctx, request)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
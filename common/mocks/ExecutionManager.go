@@ -56,6 +56,20 @@ func (_m *ExecutionManager) GetShardID() int {
 	return r0
 }
 
+// Ping provides a mock function with given fields:
+func (_m *ExecutionManager) Ping() error {
+	ret := _m.Called()
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func() error); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // CreateWorkflowExecution provides a mock function with given fields: request
 func (_m *ExecutionManager) CreateWorkflowExecution(request *persistence.CreateWorkflowExecutionRequest) (*persistence.CreateWorkflowExecutionResponse, error) {
 	ret := _m.Called(request)
@@ -168,17 +182,26 @@ func (_m *ExecutionManager) DeleteWorkflowExecution(request *persistence.DeleteW
 }
 
 // DeleteCurrentWorkflowExecution provides a mock function with given fields: request
-func (_m *ExecutionManager) DeleteCurrentWorkflowExecution(request *persistence.DeleteCurrentWorkflowExecutionRequest) error {
+func (_m *ExecutionManager) DeleteCurrentWorkflowExecution(request *persistence.DeleteCurrentWorkflowExecutionRequest) (*persistence.DeleteCurrentWorkflowExecutionResponse, error) {
 	ret := _m.Called(request)
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(*persistence.DeleteCurrentWorkflowExecutionRequest) error); ok {
+	var r0 *persistence.DeleteCurrentWorkflowExecutionResponse
+	if rf, ok := ret.Get(0).(func(*persistence.DeleteCurrentWorkflowExecutionRequest) *persistence.DeleteCurrentWorkflowExecutionResponse); ok {
 		r0 = rf(request)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*persistence.DeleteCurrentWorkflowExecutionResponse)
+		}
 	}
 
-	return r0
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*persistence.DeleteCurrentWorkflowExecutionRequest) error); ok {
+		r1 = rf(request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
 // GetCurrentExecution provides a mock function with given fields: request
@@ -204,6 +227,66 @@ func (_m *ExecutionManager) GetCurrentExecution(request *persistence.GetCurrentE
 	return r0, r1
 }
 
+// ListWorkflowExecutionRuns provides a mock function with given fields: request
+func (_m *ExecutionManager) ListWorkflowExecutionRuns(request *persistence.ListWorkflowExecutionRunsRequest) (*persistence.ListWorkflowExecutionRunsResponse, error) {
+	ret := _m.Called(request)
+
+	var r0 *persistence.ListWorkflowExecutionRunsResponse
+	if rf, ok := ret.Get(0).(func(*persistence.ListWorkflowExecutionRunsRequest) *persistence.ListWorkflowExecutionRunsResponse); ok {
+		r0 = rf(request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*persistence.ListWorkflowExecutionRunsResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*persistence.ListWorkflowExecutionRunsRequest) error); ok {
+		r1 = rf(request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListCurrentExecutions provides a mock function with given fields: request
+func (_m *ExecutionManager) ListCurrentExecutions(request *persistence.ListCurrentExecutionsRequest) (*persistence.ListCurrentExecutionsResponse, error) {
+	ret := _m.Called(request)
+
+	var r0 *persistence.ListCurrentExecutionsResponse
+	if rf, ok := ret.Get(0).(func(*persistence.ListCurrentExecutionsRequest) *persistence.ListCurrentExecutionsResponse); ok {
+		r0 = rf(request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*persistence.ListCurrentExecutionsResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*persistence.ListCurrentExecutionsRequest) error); ok {
+		r1 = rf(request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// BlockCurrentWorkflowExecution provides a mock function with given fields: request
+func (_m *ExecutionManager) BlockCurrentWorkflowExecution(request *persistence.BlockCurrentWorkflowExecutionRequest) error {
+	ret := _m.Called(request)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*persistence.BlockCurrentWorkflowExecutionRequest) error); ok {
+		r0 = rf(request)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // GetTransferTasks provides a mock function with given fields: request
 func (_m *ExecutionManager) GetTransferTasks(request *persistence.GetTransferTasksRequest) (*persistence.GetTransferTasksResponse, error) {
 	ret := _m.Called(request)
@@ -292,6 +375,71 @@ func (_m *ExecutionManager) CompleteReplicationTask(request *persistence.Complet
 	return r0
 }
 
+// RangeCompleteReplicationTask provides a mock function with given fields: request
+func (_m *ExecutionManager) RangeCompleteReplicationTask(request *persistence.RangeCompleteReplicationTaskRequest) error {
+	ret := _m.Called(request)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*persistence.RangeCompleteReplicationTaskRequest) error); ok {
+		r0 = rf(request)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetVisibilityTasks provides a mock function with given fields: request
+func (_m *ExecutionManager) GetVisibilityTasks(request *persistence.GetVisibilityTasksRequest) (*persistence.GetVisibilityTasksResponse, error) {
+	ret := _m.Called(request)
+
+	var r0 *persistence.GetVisibilityTasksResponse
+	if rf, ok := ret.Get(0).(func(*persistence.GetVisibilityTasksRequest) *persistence.GetVisibilityTasksResponse); ok {
+		r0 = rf(request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*persistence.GetVisibilityTasksResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*persistence.GetVisibilityTasksRequest) error); ok {
+		r1 = rf(request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CompleteVisibilityTask provides a mock function with given fields: request
+func (_m *ExecutionManager) CompleteVisibilityTask(request *persistence.CompleteVisibilityTaskRequest) error {
+	ret := _m.Called(request)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*persistence.CompleteVisibilityTaskRequest) error); ok {
+		r0 = rf(request)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RangeCompleteVisibilityTask provides a mock function with given fields: request
+func (_m *ExecutionManager) RangeCompleteVisibilityTask(request *persistence.RangeCompleteVisibilityTaskRequest) error {
+	ret := _m.Called(request)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*persistence.RangeCompleteVisibilityTaskRequest) error); ok {
+		r0 = rf(request)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // GetTimerIndexTasks provides a mock function with given fields: request
 func (_m *ExecutionManager) GetTimerIndexTasks(request *persistence.GetTimerIndexTasksRequest) (*persistence.GetTimerIndexTasksResponse, error) {
 	ret := _m.Called(request)
@@ -343,6 +491,80 @@ func (_m *ExecutionManager) RangeCompleteTimerTask(request *persistence.RangeCom
 	return r0
 }
 
+// GetClosedExecutionIndex provides a mock function with given fields: request
+func (_m *ExecutionManager) GetClosedExecutionIndex(request *persistence.GetClosedExecutionIndexRequest) (*persistence.GetClosedExecutionIndexResponse, error) {
+	ret := _m.Called(request)
+
+	var r0 *persistence.GetClosedExecutionIndexResponse
+	if rf, ok := ret.Get(0).(func(*persistence.GetClosedExecutionIndexRequest) *persistence.GetClosedExecutionIndexResponse); ok {
+		r0 = rf(request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*persistence.GetClosedExecutionIndexResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*persistence.GetClosedExecutionIndexRequest) error); ok {
+		r1 = rf(request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteClosedExecutionIndex provides a mock function with given fields: request
+func (_m *ExecutionManager) DeleteClosedExecutionIndex(request *persistence.DeleteClosedExecutionIndexRequest) error {
+	ret := _m.Called(request)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*persistence.DeleteClosedExecutionIndexRequest) error); ok {
+		r0 = rf(request)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ListBufferedReplicationTasks provides a mock function with given fields: request
+func (_m *ExecutionManager) ListBufferedReplicationTasks(request *persistence.ListBufferedReplicationTasksRequest) (*persistence.ListBufferedReplicationTasksResponse, error) {
+	ret := _m.Called(request)
+
+	var r0 *persistence.ListBufferedReplicationTasksResponse
+	if rf, ok := ret.Get(0).(func(*persistence.ListBufferedReplicationTasksRequest) *persistence.ListBufferedReplicationTasksResponse); ok {
+		r0 = rf(request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*persistence.ListBufferedReplicationTasksResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*persistence.ListBufferedReplicationTasksRequest) error); ok {
+		r1 = rf(request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteBufferedReplicationTasks provides a mock function with given fields: request
+func (_m *ExecutionManager) DeleteBufferedReplicationTasks(request *persistence.DeleteBufferedReplicationTasksRequest) error {
+	ret := _m.Called(request)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*persistence.DeleteBufferedReplicationTasksRequest) error); ok {
+		r0 = rf(request)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Close provides a mock function with given fields:
 func (_m *ExecutionManager) Close() {
 	_m.Called()
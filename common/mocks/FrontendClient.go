@@ -149,6 +149,36 @@ func (_m *FrontendClient) DescribeWorkflowExecution(ctx context.Context, Describ
 	return r0, r1
 }
 
+// GetTaskListScalingHints provides a mock function with given fields: ctx, Request, opts
+func (_m *FrontendClient) GetTaskListScalingHints(ctx context.Context, Request *shared.GetTaskListScalingHintsRequest, opts ...yarpc.CallOption) (*shared.GetTaskListScalingHintsResponse, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, Request)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *shared.GetTaskListScalingHintsResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *shared.GetTaskListScalingHintsRequest, ...yarpc.CallOption) *shared.GetTaskListScalingHintsResponse); ok {
+		r0 = rf(ctx, Request, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*shared.GetTaskListScalingHintsResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *shared.GetTaskListScalingHintsRequest, ...yarpc.CallOption) error); ok {
+		r1 = rf(ctx, Request, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetWorkflowExecutionHistory provides a mock function with given fields: ctx, GetRequest, opts
 func (_m *FrontendClient) GetWorkflowExecutionHistory(ctx context.Context, GetRequest *shared.GetWorkflowExecutionHistoryRequest, opts ...yarpc.CallOption) (*shared.GetWorkflowExecutionHistoryResponse, error) {
 	_va := make([]interface{}, len(opts))
@@ -970,3 +1000,33 @@ func (_m *FrontendClient) UpdateDomain(ctx context.Context, UpdateRequest *share
 
 	return r0, r1
 }
+
+// UpdateWorkflowExecution provides a mock function with given fields: ctx, UpdateRequest, opts
+func (_m *FrontendClient) UpdateWorkflowExecution(ctx context.Context, UpdateRequest *shared.UpdateWorkflowExecutionRequest, opts ...yarpc.CallOption) (*shared.UpdateWorkflowExecutionResponse, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, UpdateRequest)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *shared.UpdateWorkflowExecutionResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *shared.UpdateWorkflowExecutionRequest, ...yarpc.CallOption) *shared.UpdateWorkflowExecutionResponse); ok {
+		r0 = rf(ctx, UpdateRequest, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*shared.UpdateWorkflowExecutionResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *shared.UpdateWorkflowExecutionRequest, ...yarpc.CallOption) error); ok {
+		r1 = rf(ctx, UpdateRequest, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
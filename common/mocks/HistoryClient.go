@@ -61,6 +61,112 @@ func (_m *HistoryClient) DescribeHistoryHost(ctx context.Context, request *share
 	return r0, r1
 }
 
+// DrainHistoryHost provides a mock function with given fields: ctx, request
+func (_m *HistoryClient) DrainHistoryHost(ctx context.Context, request *shared.DrainHistoryHostRequest, opts ...yarpc.CallOption) (*shared.DrainHistoryHostResponse, error) {
+	ret := _m.Called(ctx, request)
+
+	var r0 *shared.DrainHistoryHostResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *shared.DrainHistoryHostRequest) *shared.DrainHistoryHostResponse); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*shared.DrainHistoryHostResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *shared.DrainHistoryHostRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UndrainHistoryHost provides a mock function with given fields: ctx, request
+func (_m *HistoryClient) UndrainHistoryHost(ctx context.Context, request *shared.UndrainHistoryHostRequest, opts ...yarpc.CallOption) (*shared.UndrainHistoryHostResponse, error) {
+	ret := _m.Called(ctx, request)
+
+	var r0 *shared.UndrainHistoryHostResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *shared.UndrainHistoryHostRequest) *shared.UndrainHistoryHostResponse); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*shared.UndrainHistoryHostResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *shared.UndrainHistoryHostRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DescribeClientVersions provides a mock function with given fields: ctx, request
+func (_m *HistoryClient) DescribeClientVersions(ctx context.Context, request *shared.DescribeClientVersionsRequest, opts ...yarpc.CallOption) (*shared.DescribeClientVersionsResponse, error) {
+	ret := _m.Called(ctx, request)
+
+	var r0 *shared.DescribeClientVersionsResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *shared.DescribeClientVersionsRequest) *shared.DescribeClientVersionsResponse); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*shared.DescribeClientVersionsResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *shared.DescribeClientVersionsRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetDomainOpenExecutionCount provides a mock function with given fields: ctx, request
+func (_m *HistoryClient) GetDomainOpenExecutionCount(ctx context.Context, request *shared.GetDomainOpenExecutionCountRequest, opts ...yarpc.CallOption) (*shared.GetDomainOpenExecutionCountResponse, error) {
+	ret := _m.Called(ctx, request)
+
+	var r0 *shared.GetDomainOpenExecutionCountResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *shared.GetDomainOpenExecutionCountRequest) *shared.GetDomainOpenExecutionCountResponse); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*shared.GetDomainOpenExecutionCountResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *shared.GetDomainOpenExecutionCountRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReconcileDomainOpenExecutionCounts provides a mock function with given fields: ctx, request
+func (_m *HistoryClient) ReconcileDomainOpenExecutionCounts(ctx context.Context, request *history.ReconcileDomainOpenExecutionCountsRequest, opts ...yarpc.CallOption) error {
+	ret := _m.Called(ctx, request)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *history.ReconcileDomainOpenExecutionCountsRequest) error); ok {
+		r0 = rf(ctx, request)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // DescribeMutableState provides a mock function with given fields: ctx, getRequest
 func (_m *HistoryClient) DescribeMutableState(ctx context.Context, request *history.DescribeMutableStateRequest, opts ...yarpc.CallOption) (*history.DescribeMutableStateResponse, error) {
 	ret := _m.Called(ctx, request)
@@ -107,6 +213,43 @@ func (_m *HistoryClient) GetMutableState(ctx context.Context, getRequest *histor
 	return r0, r1
 }
 
+// ImportWorkflowExecution provides a mock function with given fields: ctx, importRequest
+func (_m *HistoryClient) ImportWorkflowExecution(ctx context.Context, importRequest *history.ImportWorkflowExecutionRequest, opts ...yarpc.CallOption) (*history.ImportWorkflowExecutionResponse, error) {
+	ret := _m.Called(ctx, importRequest)
+
+	var r0 *history.ImportWorkflowExecutionResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *history.ImportWorkflowExecutionRequest) *history.ImportWorkflowExecutionResponse); ok {
+		r0 = rf(ctx, importRequest)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*history.ImportWorkflowExecutionResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *history.ImportWorkflowExecutionRequest) error); ok {
+		r1 = rf(ctx, importRequest)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PurgeWorkflowExecution provides a mock function with given fields: ctx, purgeRequest
+func (_m *HistoryClient) PurgeWorkflowExecution(ctx context.Context, purgeRequest *history.PurgeWorkflowExecutionRequest, opts ...yarpc.CallOption) error {
+	ret := _m.Called(ctx, purgeRequest)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *history.PurgeWorkflowExecutionRequest) error); ok {
+		r0 = rf(ctx, purgeRequest)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // ResetStickyTaskList provides a mock function with given fields: ctx, getRequest
 func (_m *HistoryClient) ResetStickyTaskList(ctx context.Context, request *history.ResetStickyTaskListRequest, opts ...yarpc.CallOption) (*history.ResetStickyTaskListResponse, error) {
 	ret := _m.Called(ctx, request)
@@ -340,6 +483,31 @@ func (_m *HistoryClient) SignalWithStartWorkflowExecution(ctx context.Context,
 	return r0, r1
 }
 
+// UpdateWorkflowExecution provides a mock function with given fields: ctx, updateRequest
+func (_m *HistoryClient) UpdateWorkflowExecution(ctx context.Context,
+	updateRequest *history.UpdateWorkflowExecutionRequest,
+	opts ...yarpc.CallOption) (*shared.UpdateWorkflowExecutionResponse, error) {
+	ret := _m.Called(ctx, updateRequest)
+
+	var r0 *shared.UpdateWorkflowExecutionResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *history.UpdateWorkflowExecutionRequest) *shared.UpdateWorkflowExecutionResponse); ok {
+		r0 = rf(ctx, updateRequest)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*shared.UpdateWorkflowExecutionResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *history.UpdateWorkflowExecutionRequest) error); ok {
+		r1 = rf(ctx, updateRequest)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // RemoveSignalMutableState provides a mock function with given fields: ctx, removeRequest
 func (_m *HistoryClient) RemoveSignalMutableState(ctx context.Context, removeRequest *history.RemoveSignalMutableStateRequest, opts ...yarpc.CallOption) error {
 	ret := _m.Called(ctx, removeRequest)
@@ -354,6 +522,85 @@ func (_m *HistoryClient) RemoveSignalMutableState(ctx context.Context, removeReq
 	return r0
 }
 
+// SetActivityPaused provides a mock function with given fields: ctx, pauseRequest
+func (_m *HistoryClient) SetActivityPaused(ctx context.Context, pauseRequest *history.SetActivityPausedRequest, opts ...yarpc.CallOption) error {
+	ret := _m.Called(ctx, pauseRequest)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *history.SetActivityPausedRequest) error); ok {
+		r0 = rf(ctx, pauseRequest)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetWorkflowExecutionNotes provides a mock function with given fields: ctx, notesRequest
+func (_m *HistoryClient) SetWorkflowExecutionNotes(ctx context.Context, notesRequest *history.SetWorkflowExecutionNotesRequest, opts ...yarpc.CallOption) error {
+	ret := _m.Called(ctx, notesRequest)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *history.SetWorkflowExecutionNotesRequest) error); ok {
+		r0 = rf(ctx, notesRequest)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetWorkflowExecutionPaused provides a mock function with given fields: ctx, pauseRequest
+func (_m *HistoryClient) SetWorkflowExecutionPaused(ctx context.Context, pauseRequest *history.SetWorkflowExecutionPausedRequest, opts ...yarpc.CallOption) error {
+	ret := _m.Called(ctx, pauseRequest)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *history.SetWorkflowExecutionPausedRequest) error); ok {
+		r0 = rf(ctx, pauseRequest)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetCronSchedulePaused provides a mock function with given fields: ctx, pauseRequest
+func (_m *HistoryClient) SetCronSchedulePaused(ctx context.Context, pauseRequest *history.SetCronSchedulePausedRequest, opts ...yarpc.CallOption) error {
+	ret := _m.Called(ctx, pauseRequest)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *history.SetCronSchedulePausedRequest) error); ok {
+		r0 = rf(ctx, pauseRequest)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// TerminateAllWorkflowRuns provides a mock function with given fields: ctx, terminateRequest
+func (_m *HistoryClient) TerminateAllWorkflowRuns(ctx context.Context, terminateRequest *history.TerminateAllWorkflowRunsRequest, opts ...yarpc.CallOption) (*shared.TerminateAllWorkflowRunsResponse, error) {
+	ret := _m.Called(ctx, terminateRequest)
+
+	var r0 *shared.TerminateAllWorkflowRunsResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *history.TerminateAllWorkflowRunsRequest) *shared.TerminateAllWorkflowRunsResponse); ok {
+		r0 = rf(ctx, terminateRequest)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*shared.TerminateAllWorkflowRunsResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *history.TerminateAllWorkflowRunsRequest) error); ok {
+		r1 = rf(ctx, terminateRequest)
+	} else {
+		r1 = ret.Error(0)
+	}
+
+	return r0, r1
+}
+
 // StartWorkflowExecution provides a mock function with given fields: ctx, startRequest
 func (_m *HistoryClient) StartWorkflowExecution(ctx context.Context, startRequest *history.StartWorkflowExecutionRequest, opts ...yarpc.CallOption) (*shared.StartWorkflowExecutionResponse, error) {
 	ret := _m.Called(ctx, startRequest)
@@ -165,6 +165,21 @@ func (_m *MatchingClient) CancelOutstandingPoll(ctx context.Context,
 	return r0
 }
 
+// UpdateTaskListVersionSets provides a mock function with given fields: ctx, request
+func (_m *MatchingClient) UpdateTaskListVersionSets(ctx context.Context,
+	request *matching.UpdateTaskListVersionSetsRequest, opts ...yarpc.CallOption) error {
+	ret := _m.Called(ctx, request)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *matching.UpdateTaskListVersionSetsRequest) error); ok {
+		r0 = rf(ctx, request)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // DescribeTaskList provides a mock function with given fields: ctx, request
 func (_m *MatchingClient) DescribeTaskList(ctx context.Context,
 	request *matching.DescribeTaskListRequest, opts ...yarpc.CallOption) (*shared.DescribeTaskListResponse, error) {
@@ -188,3 +203,51 @@ func (_m *MatchingClient) DescribeTaskList(ctx context.Context,
 
 	return r0, r1
 }
+
+// GetTaskListScalingHints provides a mock function with given fields: ctx, request
+func (_m *MatchingClient) GetTaskListScalingHints(ctx context.Context,
+	request *matching.GetTaskListScalingHintsRequest, opts ...yarpc.CallOption) (*shared.GetTaskListScalingHintsResponse, error) {
+	ret := _m.Called(ctx, request)
+
+	var r0 *shared.GetTaskListScalingHintsResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *matching.GetTaskListScalingHintsRequest) *shared.GetTaskListScalingHintsResponse); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*shared.GetTaskListScalingHintsResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *matching.GetTaskListScalingHintsRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DescribeTaskJourney provides a mock function with given fields: ctx, request
+func (_m *MatchingClient) DescribeTaskJourney(ctx context.Context,
+	request *matching.DescribeTaskJourneyRequest, opts ...yarpc.CallOption) (*shared.DescribeTaskJourneyResponse, error) {
+	ret := _m.Called(ctx, request)
+
+	var r0 *shared.DescribeTaskJourneyResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *matching.DescribeTaskJourneyRequest) *shared.DescribeTaskJourneyResponse); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*shared.DescribeTaskJourneyResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *matching.DescribeTaskJourneyRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
@@ -158,6 +158,20 @@ func (_m *MetadataManager) ListDomains(request *persistence.ListDomainsRequest)
 	return r0, r1
 }
 
+// Ping provides a mock function with given fields:
+func (_m *MetadataManager) Ping() error {
+	ret := _m.Called()
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func() error); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // GetMetadata provides a mock function with given fields: request
 func (_m *MetadataManager) GetMetadata() (*persistence.GetMetadataResponse, error) {
 	ret := _m.Called()
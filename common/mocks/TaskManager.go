@@ -47,6 +47,20 @@ func (_m *TaskManager) Close() {
 	_m.Called()
 }
 
+// Ping provides a mock function with given fields:
+func (_m *TaskManager) Ping() error {
+	ret := _m.Called()
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func() error); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // LeaseTaskList provides a mock function with given fields: request
 func (_m *TaskManager) LeaseTaskList(request *persistence.LeaseTaskListRequest) (*persistence.LeaseTaskListResponse, error) {
 	ret := _m.Called(request)
@@ -0,0 +1,81 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cassandra
+
+import (
+	"github.com/gocql/gocql"
+	"github.com/uber/cadence/common/log"
+	p "github.com/uber/cadence/common/persistence"
+	"github.com/uber/cadence/common/service/config"
+)
+
+const (
+	// auditLogDayFormat is the format of the day partitioning the audit log
+	auditLogDayFormat = "2006-01-02"
+
+	templateInsertAuditEntryQuery = `INSERT INTO audit_log (` +
+		`day, timestamp, entry_id, caller, caller_service, operation, domain, request_hash, error) ` +
+		`VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`
+)
+
+type (
+	cassandraAuditPersistence struct {
+		cassandraStore
+	}
+)
+
+var _ p.AuditStore = (*cassandraAuditPersistence)(nil)
+
+// newAuditPersistence is used to create an instance of AuditManager implementation
+func newAuditPersistence(cfg config.Cassandra, logger log.Logger) (p.AuditStore, error) {
+	cluster := NewCassandraCluster(cfg.Hosts, cfg.Port, cfg.User, cfg.Password, cfg.Datacenter, cfg.Compression)
+	cluster.Keyspace = cfg.Keyspace
+	cluster.ProtoVersion = cassandraProtoVersion
+	cluster.Consistency = gocql.LocalQuorum
+	cluster.SerialConsistency = gocql.LocalSerial
+	cluster.Timeout = defaultSessionTimeout
+
+	session, err := cluster.CreateSession()
+	if err != nil {
+		return nil, err
+	}
+
+	return &cassandraAuditPersistence{cassandraStore: cassandraStore{session: session, logger: logger}}, nil
+}
+
+// RecordAuditEntry appends an entry to the audit log, the entries are partitioned by day
+func (d *cassandraAuditPersistence) RecordAuditEntry(request *p.RecordAuditEntryRequest) error {
+	entry := request.Entry
+	query := d.session.Query(templateInsertAuditEntryQuery,
+		entry.Timestamp.UTC().Format(auditLogDayFormat),
+		entry.Timestamp,
+		gocql.TimeUUID(),
+		entry.Caller,
+		entry.CallerService,
+		entry.Operation,
+		entry.Domain,
+		entry.RequestHash,
+		entry.Error)
+	if err := query.Exec(); err != nil {
+		return convertCommonErrors("RecordAuditEntry", err)
+	}
+	return nil
+}
@@ -0,0 +1,130 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cassandra
+
+import (
+	"time"
+
+	"github.com/gocql/gocql"
+	workflow "github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common/log"
+	p "github.com/uber/cadence/common/persistence"
+	"github.com/uber/cadence/common/service/config"
+)
+
+const (
+	// all cluster members are stored in a single partition
+	clusterMembershipPartition = 0
+
+	templateUpsertClusterMembershipQuery = `INSERT INTO cluster_membership (` +
+		`membership_partition, role, rpc_address, session_start, last_heartbeat, record_expiry) ` +
+		`VALUES (?, ?, ?, ?, ?, ?) USING TTL ?`
+
+	templateGetClusterMembersQuery = `SELECT role, rpc_address, session_start, last_heartbeat, record_expiry ` +
+		`FROM cluster_membership ` +
+		`WHERE membership_partition = ?`
+
+	templateWithRoleSuffix = ` AND role = ?`
+)
+
+type (
+	cassandraClusterMembershipPersistence struct {
+		cassandraStore
+	}
+)
+
+var _ p.ClusterMembershipStore = (*cassandraClusterMembershipPersistence)(nil)
+
+// newClusterMembershipPersistence is used to create an instance of ClusterMembershipManager implementation
+func newClusterMembershipPersistence(cfg config.Cassandra, logger log.Logger) (p.ClusterMembershipStore, error) {
+	cluster := NewCassandraCluster(cfg.Hosts, cfg.Port, cfg.User, cfg.Password, cfg.Datacenter, cfg.Compression)
+	cluster.Keyspace = cfg.Keyspace
+	cluster.ProtoVersion = cassandraProtoVersion
+	cluster.Consistency = gocql.LocalQuorum
+	cluster.SerialConsistency = gocql.LocalSerial
+	cluster.Timeout = defaultSessionTimeout
+
+	session, err := cluster.CreateSession()
+	if err != nil {
+		return nil, err
+	}
+
+	return &cassandraClusterMembershipPersistence{cassandraStore: cassandraStore{session: session, logger: logger}}, nil
+}
+
+// UpsertClusterMembership records the heartbeat of a host, the record expires with the cassandra TTL
+func (d *cassandraClusterMembershipPersistence) UpsertClusterMembership(request *p.UpsertClusterMembershipRequest) error {
+	now := time.Now()
+	ttlSeconds := int64(request.RecordExpiry / time.Second)
+	if ttlSeconds <= 0 {
+		ttlSeconds = 1
+	}
+
+	query := d.session.Query(templateUpsertClusterMembershipQuery,
+		clusterMembershipPartition,
+		request.Role,
+		request.RPCAddress,
+		request.SessionStart,
+		now,
+		now.Add(request.RecordExpiry),
+		ttlSeconds)
+	if err := query.Exec(); err != nil {
+		return convertCommonErrors("UpsertClusterMembership", err)
+	}
+	return nil
+}
+
+// GetClusterMembers returns the hosts with a record which did not expire yet
+func (d *cassandraClusterMembershipPersistence) GetClusterMembers(request *p.GetClusterMembersRequest) (*p.GetClusterMembersResponse, error) {
+	queryString := templateGetClusterMembersQuery
+	args := []interface{}{clusterMembershipPartition}
+	if request.Role != "" {
+		queryString += templateWithRoleSuffix
+		args = append(args, request.Role)
+	}
+
+	iter := d.session.Query(queryString, args...).Iter()
+	if iter == nil {
+		return nil, &workflow.InternalServiceError{
+			Message: "GetClusterMembers operation failed.  Not able to create query iterator.",
+		}
+	}
+
+	now := time.Now()
+	members := []*p.ClusterMember{}
+	member := &p.ClusterMember{}
+	for iter.Scan(&member.Role, &member.RPCAddress, &member.SessionStart, &member.LastHeartbeat, &member.RecordExpiry) {
+		if p.IsActiveClusterMember(member, request.LastHeartbeatWithin, now) {
+			members = append(members, member)
+		}
+		member = &p.ClusterMember{}
+	}
+	if err := iter.Close(); err != nil {
+		return nil, convertCommonErrors("GetClusterMembers", err)
+	}
+
+	return &p.GetClusterMembersResponse{ActiveMembers: members}, nil
+}
+
+// PruneClusterMembership is a noop as expired records are removed by the cassandra TTL
+func (d *cassandraClusterMembershipPersistence) PruneClusterMembership(request *p.PruneClusterMembershipRequest) error {
+	return nil
+}
@@ -27,7 +27,9 @@ import (
 	"strings"
 
 	"github.com/gocql/gocql"
+	"github.com/gocql/gocql/lz4"
 	log "github.com/sirupsen/logrus"
+	"github.com/uber/cadence/common/service/config"
 	"github.com/uber/cadence/tools/cassandra"
 	"github.com/uber/cadence/tools/common/schema"
 )
@@ -35,7 +37,7 @@ import (
 const cassandraPersistenceName = "cassandra"
 
 // NewCassandraCluster creates a cassandra cluster given comma separated list of clusterHosts
-func NewCassandraCluster(clusterHosts string, port int, user, password, dc string) *gocql.ClusterConfig {
+func NewCassandraCluster(clusterHosts string, port int, user, password, dc, compression string) *gocql.ClusterConfig {
 	var hosts []string
 	for _, h := range strings.Split(clusterHosts, ",") {
 		if host := strings.TrimSpace(h); len(host) > 0 {
@@ -57,6 +59,12 @@ func NewCassandraCluster(clusterHosts string, port int, user, password, dc strin
 	if dc != "" {
 		cluster.HostFilter = gocql.DataCentreHostFilter(dc)
 	}
+	switch compression {
+	case config.CassandraCompressionSnappy:
+		cluster.Compressor = gocql.SnappyCompressor{}
+	case config.CassandraCompressionLZ4:
+		cluster.Compressor = lz4.LZ4Compressor{}
+	}
 	cluster.PoolConfig.HostSelectionPolicy = gocql.TokenAwareHostPolicy(gocql.RoundRobinHostPolicy())
 	return cluster
 }
@@ -89,9 +97,10 @@ func DropCassandraKeyspace(s *gocql.Session, keyspace string) (err error) {
 	return
 }
 
-// LoadCassandraSchema loads the schema from the given .cql files on this keyspace
+// LoadCassandraSchema loads the schema from the given .cql files on this keyspace and records it as the given
+// schema version, an empty version loads the schema without versioning
 func LoadCassandraSchema(
-	dir string, fileNames []string, hosts []string, port int, keyspace string, override bool,
+	dir string, fileNames []string, hosts []string, port int, keyspace string, override bool, version string,
 ) (err error) {
 
 	tmpFile, err := ioutil.TempFile("", "_cadence_")
@@ -120,7 +129,8 @@ func LoadCassandraSchema(
 		SetupConfig: schema.SetupConfig{
 			SchemaFilePath:    tmpFile.Name(),
 			Overwrite:         override,
-			DisableVersioning: true,
+			InitialVersion:    version,
+			DisableVersioning: len(version) == 0,
 		},
 	}
 
@@ -68,7 +68,7 @@ func NewHistoryPersistenceFromSession(session *gocql.Session, logger log.Logger)
 // newHistoryPersistence is used to create an instance of HistoryManager implementation
 func newHistoryPersistence(cfg config.Cassandra, logger log.Logger) (p.HistoryStore,
 	error) {
-	cluster := NewCassandraCluster(cfg.Hosts, cfg.Port, cfg.User, cfg.Password, cfg.Datacenter)
+	cluster := NewCassandraCluster(cfg.Hosts, cfg.Port, cfg.User, cfg.Password, cfg.Datacenter, cfg.Compression)
 	cluster.Keyspace = cfg.Keyspace
 	cluster.ProtoVersion = cassandraProtoVersion
 	cluster.Consistency = gocql.LocalQuorum
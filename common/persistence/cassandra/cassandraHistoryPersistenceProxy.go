@@ -0,0 +1,194 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package cassandra
+
+import (
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/log"
+	p "github.com/uber/cadence/common/persistence"
+	"github.com/uber/cadence/common/service/config"
+)
+
+type (
+	// historyManagerProxy routes the history operations to the store of the shard range of the history
+	// shard owning the workflow
+	historyManagerProxy struct {
+		defaultStore     p.HistoryStore
+		ranges           []historyStoreRange
+		numHistoryShards int
+	}
+
+	historyStoreRange struct {
+		minShardID int
+		maxShardID int
+		store      p.HistoryStore
+	}
+
+	// historyV2ManagerProxy routes the history branch operations to the store of the shard range of
+	// the shard of the request
+	historyV2ManagerProxy struct {
+		defaultStore p.HistoryV2Store
+		ranges       []historyV2StoreRange
+	}
+
+	historyV2StoreRange struct {
+		minShardID int
+		maxShardID int
+		store      p.HistoryV2Store
+	}
+)
+
+// newHistoryManagerProxy is used to create a history store spanning the shard ranges of the config,
+// a single cluster history store is returned when no range is configured
+func newHistoryManagerProxy(cfg config.Cassandra, numHistoryShards int, logger log.Logger) (p.HistoryStore, error) {
+	defaultStore, err := newHistoryPersistence(cfg, logger)
+	if err != nil {
+		return nil, err
+	}
+	if len(cfg.ShardRanges) == 0 {
+		return defaultStore, nil
+	}
+	proxy := &historyManagerProxy{defaultStore: defaultStore, numHistoryShards: numHistoryShards}
+	for _, r := range cfg.ShardRanges {
+		store, err := newHistoryPersistence(r.Cassandra, logger)
+		if err != nil {
+			proxy.Close()
+			return nil, err
+		}
+		proxy.ranges = append(proxy.ranges, historyStoreRange{
+			minShardID: r.MinShardID,
+			maxShardID: r.MaxShardID,
+			store:      store,
+		})
+	}
+	return proxy, nil
+}
+
+func (h *historyManagerProxy) GetName() string {
+	return cassandraPersistenceName
+}
+
+func (h *historyManagerProxy) AppendHistoryEvents(request *p.InternalAppendHistoryEventsRequest) error {
+	return h.storeOf(request.Execution.GetWorkflowId()).AppendHistoryEvents(request)
+}
+
+func (h *historyManagerProxy) GetWorkflowExecutionHistory(
+	request *p.InternalGetWorkflowExecutionHistoryRequest,
+) (*p.InternalGetWorkflowExecutionHistoryResponse, error) {
+	return h.storeOf(request.Execution.GetWorkflowId()).GetWorkflowExecutionHistory(request)
+}
+
+func (h *historyManagerProxy) DeleteWorkflowExecutionHistory(request *p.DeleteWorkflowExecutionHistoryRequest) error {
+	return h.storeOf(request.Execution.GetWorkflowId()).DeleteWorkflowExecutionHistory(request)
+}
+
+func (h *historyManagerProxy) Close() {
+	h.defaultStore.Close()
+	for _, r := range h.ranges {
+		r.store.Close()
+	}
+}
+
+func (h *historyManagerProxy) storeOf(workflowID string) p.HistoryStore {
+	shardID := common.WorkflowIDToHistoryShard(workflowID, h.numHistoryShards)
+	for _, r := range h.ranges {
+		if shardID >= r.minShardID && shardID <= r.maxShardID {
+			return r.store
+		}
+	}
+	return h.defaultStore
+}
+
+// newHistoryV2ManagerProxy is used to create a history branch store spanning the shard ranges of the config,
+// a single cluster history branch store is returned when no range is configured
+func newHistoryV2ManagerProxy(cfg config.Cassandra, logger log.Logger) (p.HistoryV2Store, error) {
+	defaultStore, err := newHistoryV2Persistence(cfg, logger)
+	if err != nil {
+		return nil, err
+	}
+	if len(cfg.ShardRanges) == 0 {
+		return defaultStore, nil
+	}
+	proxy := &historyV2ManagerProxy{defaultStore: defaultStore}
+	for _, r := range cfg.ShardRanges {
+		store, err := newHistoryV2Persistence(r.Cassandra, logger)
+		if err != nil {
+			proxy.Close()
+			return nil, err
+		}
+		proxy.ranges = append(proxy.ranges, historyV2StoreRange{
+			minShardID: r.MinShardID,
+			maxShardID: r.MaxShardID,
+			store:      store,
+		})
+	}
+	return proxy, nil
+}
+
+func (h *historyV2ManagerProxy) GetName() string {
+	return cassandraPersistenceName
+}
+
+func (h *historyV2ManagerProxy) AppendHistoryNodes(request *p.InternalAppendHistoryNodesRequest) error {
+	return h.storeOf(request.ShardID).AppendHistoryNodes(request)
+}
+
+func (h *historyV2ManagerProxy) ReadHistoryBranch(
+	request *p.InternalReadHistoryBranchRequest,
+) (*p.InternalReadHistoryBranchResponse, error) {
+	return h.storeOf(request.ShardID).ReadHistoryBranch(request)
+}
+
+func (h *historyV2ManagerProxy) ForkHistoryBranch(
+	request *p.InternalForkHistoryBranchRequest,
+) (*p.InternalForkHistoryBranchResponse, error) {
+	return h.storeOf(request.ShardID).ForkHistoryBranch(request)
+}
+
+func (h *historyV2ManagerProxy) DeleteHistoryBranch(request *p.InternalDeleteHistoryBranchRequest) error {
+	return h.storeOf(request.ShardID).DeleteHistoryBranch(request)
+}
+
+func (h *historyV2ManagerProxy) CompleteForkBranch(request *p.InternalCompleteForkBranchRequest) error {
+	return h.storeOf(request.ShardID).CompleteForkBranch(request)
+}
+
+func (h *historyV2ManagerProxy) GetHistoryTree(request *p.GetHistoryTreeRequest) (*p.GetHistoryTreeResponse, error) {
+	if request.ShardID == nil {
+		return h.defaultStore.GetHistoryTree(request)
+	}
+	return h.storeOf(*request.ShardID).GetHistoryTree(request)
+}
+
+func (h *historyV2ManagerProxy) Close() {
+	h.defaultStore.Close()
+	for _, r := range h.ranges {
+		r.store.Close()
+	}
+}
+
+func (h *historyV2ManagerProxy) storeOf(shardID int) p.HistoryV2Store {
+	for _, r := range h.ranges {
+		if shardID >= r.minShardID && shardID <= r.maxShardID {
+			return r.store
+		}
+	}
+	return h.defaultStore
+}
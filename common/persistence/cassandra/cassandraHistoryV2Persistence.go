@@ -70,7 +70,7 @@ func NewHistoryV2PersistenceFromSession(session *gocql.Session, logger log.Logge
 // newHistoryPersistence is used to create an instance of HistoryManager implementation
 func newHistoryV2Persistence(cfg config.Cassandra, logger log.Logger) (p.HistoryV2Store,
 	error) {
-	cluster := NewCassandraCluster(cfg.Hosts, cfg.Port, cfg.User, cfg.Password, cfg.Datacenter)
+	cluster := NewCassandraCluster(cfg.Hosts, cfg.Port, cfg.User, cfg.Password, cfg.Datacenter, cfg.Compression)
 	cluster.Keyspace = cfg.Keyspace
 	cluster.ProtoVersion = cassandraProtoVersion
 	cluster.Consistency = gocql.LocalQuorum
@@ -153,6 +153,7 @@ func (h *cassandraHistoryV2Persistence) ReadHistoryBranch(request *p.InternalRea
 
 	query := h.session.Query(v2templateReadData,
 		treeID, branchID, request.MinNodeID, request.MaxNodeID)
+	query = withRequestContext(query, request.Context)
 
 	iter := query.PageSize(int(request.PageSize)).PageState(request.NextPageToken).Iter()
 	if iter == nil {
@@ -187,6 +188,9 @@ func (h *cassandraHistoryV2Persistence) ReadHistoryBranch(request *p.InternalRea
 	}
 
 	if err := iter.Close(); err != nil {
+		if ctxErr := requestContextError(request.Context); ctxErr != nil {
+			return nil, ctxErr
+		}
 		return nil, &workflow.InternalServiceError{
 			Message: fmt.Sprintf("ReadHistoryBranch. Close operation failed. Error: %v", err),
 		}
@@ -49,7 +49,17 @@ const (
 		`archival_bucket: ?, ` +
 		`archival_status: ?,` +
 		`bad_binaries: ?,` +
-		`bad_binaries_encoding: ?` +
+		`bad_binaries_encoding: ?,` +
+		`retention_run_count: ?,` +
+		`default_execution_timeout: ?,` +
+		`max_execution_timeout: ?,` +
+		`default_task_timeout: ?,` +
+		`max_task_timeout: ?,` +
+		`default_activity_timeout: ?,` +
+		`max_activity_timeout: ?,` +
+		`encryption_key_alias: ?,` +
+		`residency_tag: ?,` +
+		`residency_allowed_clusters: ?` +
 		`}`
 
 	templateDomainReplicationConfigType = `{` +
@@ -71,7 +81,8 @@ const (
 
 	templateGetDomainByNameQuery = `SELECT domain.id, domain.name, domain.status, domain.description, ` +
 		`domain.owner_email, domain.data, config.retention, config.emit_metric, ` +
-		`config.archival_bucket, config.archival_status, config.bad_binaries, config.bad_binaries_encoding,` +
+		`config.archival_bucket, config.archival_status, config.bad_binaries, config.bad_binaries_encoding, config.retention_run_count, ` +
+		`config.default_execution_timeout, config.max_execution_timeout, config.default_task_timeout, config.max_task_timeout, config.default_activity_timeout, config.max_activity_timeout, config.encryption_key_alias, config.residency_tag, config.residency_allowed_clusters,` +
 		`replication_config.active_cluster_name, replication_config.clusters, ` +
 		`is_global_domain, ` +
 		`config_version, ` +
@@ -107,7 +118,7 @@ type (
 // newMetadataPersistence is used to create an instance of HistoryManager implementation
 func newMetadataPersistence(cfg config.Cassandra, clusterName string, logger log.Logger) (p.MetadataStore,
 	error) {
-	cluster := NewCassandraCluster(cfg.Hosts, cfg.Port, cfg.User, cfg.Password, cfg.Datacenter)
+	cluster := NewCassandraCluster(cfg.Hosts, cfg.Port, cfg.User, cfg.Password, cfg.Datacenter, cfg.Compression)
 	cluster.Keyspace = cfg.Keyspace
 	cluster.ProtoVersion = cassandraProtoVersion
 	cluster.Consistency = gocql.LocalQuorum
@@ -164,6 +175,16 @@ func (m *cassandraMetadataPersistence) CreateDomain(request *p.InternalCreateDom
 		request.Config.ArchivalStatus,
 		request.Config.BadBinaries.Data,
 		string(request.Config.BadBinaries.GetEncoding()),
+		request.Config.RetentionRunCount,
+		request.Config.TimeoutPolicy.DefaultExecutionTimeoutSeconds,
+		request.Config.TimeoutPolicy.MaxExecutionTimeoutSeconds,
+		request.Config.TimeoutPolicy.DefaultTaskTimeoutSeconds,
+		request.Config.TimeoutPolicy.MaxTaskTimeoutSeconds,
+		request.Config.TimeoutPolicy.DefaultActivityTimeoutSeconds,
+		request.Config.TimeoutPolicy.MaxActivityTimeoutSeconds,
+		request.Config.EncryptionKeyAlias,
+		request.Config.Residency.Tag,
+		request.Config.Residency.AllowedClusters,
 		request.ReplicationConfig.ActiveClusterName,
 		p.SerializeClusterConfigs(request.ReplicationConfig.Clusters),
 		request.IsGlobalDomain,
@@ -263,6 +284,16 @@ func (m *cassandraMetadataPersistence) GetDomain(request *p.GetDomainRequest) (*
 		&config.ArchivalStatus,
 		&badBinariesData,
 		&badBinariesDataEncoding,
+		&config.RetentionRunCount,
+		&config.TimeoutPolicy.DefaultExecutionTimeoutSeconds,
+		&config.TimeoutPolicy.MaxExecutionTimeoutSeconds,
+		&config.TimeoutPolicy.DefaultTaskTimeoutSeconds,
+		&config.TimeoutPolicy.MaxTaskTimeoutSeconds,
+		&config.TimeoutPolicy.DefaultActivityTimeoutSeconds,
+		&config.TimeoutPolicy.MaxActivityTimeoutSeconds,
+		&config.EncryptionKeyAlias,
+		&config.Residency.Tag,
+		&config.Residency.AllowedClusters,
 		&replicationConfig.ActiveClusterName,
 		&replicationClusters,
 		&isGlobalDomain,
@@ -315,6 +346,16 @@ func (m *cassandraMetadataPersistence) UpdateDomain(request *p.InternalUpdateDom
 		request.Config.ArchivalStatus,
 		request.Config.BadBinaries.Data,
 		string(request.Config.BadBinaries.GetEncoding()),
+		request.Config.RetentionRunCount,
+		request.Config.TimeoutPolicy.DefaultExecutionTimeoutSeconds,
+		request.Config.TimeoutPolicy.MaxExecutionTimeoutSeconds,
+		request.Config.TimeoutPolicy.DefaultTaskTimeoutSeconds,
+		request.Config.TimeoutPolicy.MaxTaskTimeoutSeconds,
+		request.Config.TimeoutPolicy.DefaultActivityTimeoutSeconds,
+		request.Config.TimeoutPolicy.MaxActivityTimeoutSeconds,
+		request.Config.EncryptionKeyAlias,
+		request.Config.Residency.Tag,
+		request.Config.Residency.AllowedClusters,
 		request.ReplicationConfig.ActiveClusterName,
 		p.SerializeClusterConfigs(request.ReplicationConfig.Clusters),
 		request.ConfigVersion,
@@ -374,6 +415,10 @@ func (m *cassandraMetadataPersistence) GetMetadata() (*p.GetMetadataResponse, er
 	panic("cassandraMetadataPersistence do not support get metadata operation.")
 }
 
+func (m *cassandraMetadataPersistence) Ping() error {
+	panic("cassandraMetadataPersistence do not support ping operation.")
+}
+
 func (m *cassandraMetadataPersistence) deleteDomain(name, ID string) error {
 	query := m.session.Query(templateDeleteDomainByNameQuery, name)
 	if err := query.Exec(); err != nil {
@@ -137,6 +137,10 @@ func (m *metadataManagerProxy) GetMetadata() (*p.GetMetadataResponse, error) {
 	return m.metadataMgrV2.GetMetadata()
 }
 
+func (m *metadataManagerProxy) Ping() error {
+	return m.metadataMgrV2.Ping()
+}
+
 func (m *metadataManagerProxy) Close() {
 	m.metadataMgr.Close()
 	m.metadataMgrV2.Close()
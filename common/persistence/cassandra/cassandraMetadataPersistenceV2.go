@@ -43,7 +43,8 @@ const (
 
 	templateGetDomainByNameQueryV2 = `SELECT domain.id, domain.name, domain.status, domain.description, ` +
 		`domain.owner_email, domain.data, config.retention, config.emit_metric, ` +
-		`config.archival_bucket, config.archival_status, config.bad_binaries, config.bad_binaries_encoding,` +
+		`config.archival_bucket, config.archival_status, config.bad_binaries, config.bad_binaries_encoding, config.retention_run_count, ` +
+		`config.default_execution_timeout, config.max_execution_timeout, config.default_task_timeout, config.max_task_timeout, config.default_activity_timeout, config.max_activity_timeout, config.encryption_key_alias, config.residency_tag, config.residency_allowed_clusters,` +
 		`replication_config.active_cluster_name, replication_config.clusters, ` +
 		`is_global_domain, ` +
 		`config_version, ` +
@@ -82,7 +83,8 @@ const (
 
 	templateListDomainQueryV2 = `SELECT name, domain.id, domain.name, domain.status, domain.description, ` +
 		`domain.owner_email, domain.data, config.retention, config.emit_metric, ` +
-		`config.archival_bucket, config.archival_status, config.bad_binaries, config.bad_binaries_encoding,` +
+		`config.archival_bucket, config.archival_status, config.bad_binaries, config.bad_binaries_encoding, config.retention_run_count, ` +
+		`config.default_execution_timeout, config.max_execution_timeout, config.default_task_timeout, config.max_task_timeout, config.default_activity_timeout, config.max_activity_timeout, config.encryption_key_alias, config.residency_tag, config.residency_allowed_clusters,` +
 		`replication_config.active_cluster_name, replication_config.clusters, ` +
 		`is_global_domain, ` +
 		`config_version, ` +
@@ -102,7 +104,7 @@ type (
 
 // newMetadataPersistenceV2 is used to create an instance of HistoryManager implementation
 func newMetadataPersistenceV2(cfg config.Cassandra, currentClusterName string, logger log.Logger) (p.MetadataStore, error) {
-	cluster := NewCassandraCluster(cfg.Hosts, cfg.Port, cfg.User, cfg.Password, cfg.Datacenter)
+	cluster := NewCassandraCluster(cfg.Hosts, cfg.Port, cfg.User, cfg.Password, cfg.Datacenter, cfg.Compression)
 	cluster.Keyspace = cfg.Keyspace
 	cluster.ProtoVersion = cassandraProtoVersion
 	cluster.Consistency = gocql.LocalQuorum
@@ -172,6 +174,16 @@ func (m *cassandraMetadataPersistenceV2) CreateDomainInV2Table(request *p.Intern
 		request.Config.ArchivalStatus,
 		request.Config.BadBinaries.Data,
 		string(request.Config.BadBinaries.GetEncoding()),
+		request.Config.RetentionRunCount,
+		request.Config.TimeoutPolicy.DefaultExecutionTimeoutSeconds,
+		request.Config.TimeoutPolicy.MaxExecutionTimeoutSeconds,
+		request.Config.TimeoutPolicy.DefaultTaskTimeoutSeconds,
+		request.Config.TimeoutPolicy.MaxTaskTimeoutSeconds,
+		request.Config.TimeoutPolicy.DefaultActivityTimeoutSeconds,
+		request.Config.TimeoutPolicy.MaxActivityTimeoutSeconds,
+		request.Config.EncryptionKeyAlias,
+		request.Config.Residency.Tag,
+		request.Config.Residency.AllowedClusters,
 		request.ReplicationConfig.ActiveClusterName,
 		p.SerializeClusterConfigs(request.ReplicationConfig.Clusters),
 		request.IsGlobalDomain,
@@ -232,6 +244,16 @@ func (m *cassandraMetadataPersistenceV2) UpdateDomain(request *p.InternalUpdateD
 		request.Config.ArchivalStatus,
 		request.Config.BadBinaries.Data,
 		string(request.Config.BadBinaries.GetEncoding()),
+		request.Config.RetentionRunCount,
+		request.Config.TimeoutPolicy.DefaultExecutionTimeoutSeconds,
+		request.Config.TimeoutPolicy.MaxExecutionTimeoutSeconds,
+		request.Config.TimeoutPolicy.DefaultTaskTimeoutSeconds,
+		request.Config.TimeoutPolicy.MaxTaskTimeoutSeconds,
+		request.Config.TimeoutPolicy.DefaultActivityTimeoutSeconds,
+		request.Config.TimeoutPolicy.MaxActivityTimeoutSeconds,
+		request.Config.EncryptionKeyAlias,
+		request.Config.Residency.Tag,
+		request.Config.Residency.AllowedClusters,
 		request.ReplicationConfig.ActiveClusterName,
 		p.SerializeClusterConfigs(request.ReplicationConfig.Clusters),
 		request.ConfigVersion,
@@ -329,6 +351,16 @@ func (m *cassandraMetadataPersistenceV2) GetDomain(request *p.GetDomainRequest)
 		&config.ArchivalStatus,
 		&badBinariesData,
 		&badBinariesDataEncoding,
+		&config.RetentionRunCount,
+		&config.TimeoutPolicy.DefaultExecutionTimeoutSeconds,
+		&config.TimeoutPolicy.MaxExecutionTimeoutSeconds,
+		&config.TimeoutPolicy.DefaultTaskTimeoutSeconds,
+		&config.TimeoutPolicy.MaxTaskTimeoutSeconds,
+		&config.TimeoutPolicy.DefaultActivityTimeoutSeconds,
+		&config.TimeoutPolicy.MaxActivityTimeoutSeconds,
+		&config.EncryptionKeyAlias,
+		&config.Residency.Tag,
+		&config.Residency.AllowedClusters,
 		&replicationConfig.ActiveClusterName,
 		&replicationClusters,
 		&isGlobalDomain,
@@ -389,7 +421,11 @@ func (m *cassandraMetadataPersistenceV2) ListDomains(request *p.ListDomainsReque
 		&name,
 		&domain.Info.ID, &domain.Info.Name, &domain.Info.Status, &domain.Info.Description, &domain.Info.OwnerEmail, &domain.Info.Data,
 		&domain.Config.Retention, &domain.Config.EmitMetric,
-		&domain.Config.ArchivalBucket, &domain.Config.ArchivalStatus, &badBinariesData, &badBinariesDataEncoding,
+		&domain.Config.ArchivalBucket, &domain.Config.ArchivalStatus, &badBinariesData, &badBinariesDataEncoding, &domain.Config.RetentionRunCount,
+		&domain.Config.TimeoutPolicy.DefaultExecutionTimeoutSeconds, &domain.Config.TimeoutPolicy.MaxExecutionTimeoutSeconds,
+		&domain.Config.TimeoutPolicy.DefaultTaskTimeoutSeconds, &domain.Config.TimeoutPolicy.MaxTaskTimeoutSeconds,
+		&domain.Config.TimeoutPolicy.DefaultActivityTimeoutSeconds, &domain.Config.TimeoutPolicy.MaxActivityTimeoutSeconds,
+		&domain.Config.EncryptionKeyAlias, &domain.Config.Residency.Tag, &domain.Config.Residency.AllowedClusters,
 		&domain.ReplicationConfig.ActiveClusterName, &replicationClusters,
 		&domain.IsGlobalDomain, &domain.ConfigVersion, &domain.FailoverVersion,
 		&domain.FailoverNotificationVersion, &domain.NotificationVersion,
@@ -469,6 +505,18 @@ func (m *cassandraMetadataPersistenceV2) GetMetadata() (*p.GetMetadataResponse,
 	return &p.GetMetadataResponse{NotificationVersion: notificationVersion}, nil
 }
 
+func (m *cassandraMetadataPersistenceV2) Ping() error {
+	var notificationVersion int64
+	query := m.session.Query(templateGetMetadataQueryV2, constDomainPartition, domainMetadataRecordName)
+	// the metadata record may not be written yet, reaching the store is enough
+	if err := query.Scan(&notificationVersion); err != nil && err != gocql.ErrNotFound {
+		return &workflow.InternalServiceError{
+			Message: fmt.Sprintf("Ping operation failed. Error: %v", err),
+		}
+	}
+	return nil
+}
+
 func (m *cassandraMetadataPersistenceV2) updateMetadataBatch(batch *gocql.Batch, notificationVersion int64) {
 	var nextVersion int64 = 1
 	var currentVersion *int64
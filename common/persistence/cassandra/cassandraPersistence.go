@@ -22,6 +22,7 @@ package cassandra
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 	"time"
 
@@ -29,6 +30,7 @@ import (
 	workflow "github.com/uber/cadence/.gen/go/shared"
 	"github.com/uber/cadence/common"
 	"github.com/uber/cadence/common/log"
+	"github.com/uber/cadence/common/log/tag"
 	p "github.com/uber/cadence/common/persistence"
 	"github.com/uber/cadence/common/service/config"
 )
@@ -63,6 +65,10 @@ const (
 	rowTypeReplicationDomainID   = "10000000-5000-f000-f000-000000000000"
 	rowTypeReplicationWorkflowID = "20000000-5000-f000-f000-000000000000"
 	rowTypeReplicationRunID      = "30000000-5000-f000-f000-000000000000"
+	// Row Constants for Visibility Task Row
+	rowTypeVisibilityDomainID   = "10000000-6000-f000-f000-000000000000"
+	rowTypeVisibilityWorkflowID = "20000000-6000-f000-f000-000000000000"
+	rowTypeVisibilityRunID      = "30000000-6000-f000-f000-000000000000"
 	// Special TaskId constants
 	rowTypeExecutionTaskID  = int64(-10)
 	rowTypeShardTaskID      = int64(-11)
@@ -82,6 +88,7 @@ const (
 	rowTypeTransferTask
 	rowTypeTimerTask
 	rowTypeReplicationTask
+	rowTypeVisibilityTask
 )
 
 const (
@@ -107,74 +114,16 @@ const (
 		`timer_ack_level: ?, ` +
 		`cluster_transfer_ack_level: ?, ` +
 		`cluster_timer_ack_level: ?, ` +
-		`domain_notification_version: ? ` +
-		`}`
-
-	templateWorkflowExecutionType = `{` +
-		`domain_id: ?, ` +
-		`workflow_id: ?, ` +
-		`run_id: ?, ` +
-		`parent_domain_id: ?, ` +
-		`parent_workflow_id: ?, ` +
-		`parent_run_id: ?, ` +
-		`initiated_id: ?, ` +
-		`completion_event_batch_id: ?, ` +
-		`completion_event: ?, ` +
-		`completion_event_data_encoding: ?, ` +
-		`task_list: ?, ` +
-		`workflow_type_name: ?, ` +
-		`workflow_timeout: ?, ` +
-		`decision_task_timeout: ?, ` +
-		`execution_context: ?, ` +
-		`state: ?, ` +
-		`close_status: ?, ` +
-		`last_first_event_id: ?, ` +
-		`last_event_task_id: ?, ` +
-		`next_event_id: ?, ` +
-		`last_processed_event: ?, ` +
-		`start_time: ?, ` +
-		`last_updated_time: ?, ` +
-		`create_request_id: ?, ` +
-		`signal_count: ?, ` +
-		`history_size: ?, ` +
-		`decision_version: ?, ` +
-		`decision_schedule_id: ?, ` +
-		`decision_started_id: ?, ` +
-		`decision_request_id: ?, ` +
-		`decision_timeout: ?, ` +
-		`decision_attempt: ?, ` +
-		`decision_timestamp: ?, ` +
-		`decision_scheduled_timestamp: ?, ` +
-		`cancel_requested: ?, ` +
-		`cancel_request_id: ?, ` +
-		`sticky_task_list: ?, ` +
-		`sticky_schedule_to_start_timeout: ?,` +
-		`client_library_version: ?, ` +
-		`client_feature_version: ?, ` +
-		`client_impl: ?, ` +
-		`auto_reset_points: ?, ` +
-		`auto_reset_points_encoding: ?, ` +
-		`attempt: ?, ` +
-		`has_retry_policy: ?, ` +
-		`init_interval: ?, ` +
-		`backoff_coefficient: ?, ` +
-		`max_interval: ?, ` +
-		`expiration_time: ?, ` +
-		`max_attempts: ?, ` +
-		`non_retriable_errors: ?, ` +
-		`event_store_version: ?, ` +
-		`branch_token: ?, ` +
-		`cron_schedule: ?, ` +
-		`expiration_seconds: ?, ` +
-		`search_attributes: ? ` +
-		`}`
-
-	templateReplicationStateType = `{` +
-		`current_version: ?, ` +
-		`start_version: ?, ` +
-		`last_write_version: ?, ` +
-		`last_write_event_id: ?, ` +
-		`last_replication_info: ?` +
+		`domain_notification_version: ?, ` +
+		`domain_open_execution_counts: ?, ` +
+		`visibility_ack_level: ?, ` +
+		`queue_alarms: ?, ` +
+		`hot_executions: ?, ` +
+		`lease_expires_at: ?, ` +
+		`tasks_per_second: ?, ` +
+		`task_throughput_updated_at: ?, ` +
+		`placement: ?, ` +
+		`transfer_task_buckets: ? ` +
 		`}`
 
 	templateTransferTaskType = `{` +
@@ -194,6 +143,16 @@ const (
 		`version: ?` +
 		`}`
 
+	templateVisibilityTaskType = `{` +
+		`domain_id: ?, ` +
+		`workflow_id: ?, ` +
+		`run_id: ?, ` +
+		`visibility_ts: ?, ` +
+		`task_id: ?, ` +
+		`type: ?, ` +
+		`version: ?` +
+		`}`
+
 	templateReplicationTaskType = `{` +
 		`domain_id: ?, ` +
 		`workflow_id: ?, ` +
@@ -257,7 +216,8 @@ const (
 		`non_retriable_errors: ?, ` +
 		`last_failure_reason: ?, ` +
 		`last_worker_identity: ?, ` +
-		`event_data_encoding: ?` +
+		`event_data_encoding: ?, ` +
+		`paused: ?` +
 		`}`
 
 	templateTimerInfoType = `{` +
@@ -298,6 +258,15 @@ const (
 		`control: ?` +
 		`}`
 
+	templateUpdateInfoType = `{` +
+		`version: ?,` +
+		`update_id: ?, ` +
+		`update_name: ?, ` +
+		`input: ?, ` +
+		`result: ?, ` +
+		`completed: ?` +
+		`}`
+
 	templateSerializedEventBatch = `{` +
 		`encoding_type: ?, ` +
 		`version: ?, ` +
@@ -310,7 +279,8 @@ const (
 		`type: ?, ` +
 		`ack_level: ?, ` +
 		`kind: ?, ` +
-		`last_updated: ? ` +
+		`last_updated: ?, ` +
+		`version_sets: ? ` +
 		`}`
 
 	templateTaskType = `{` +
@@ -369,18 +339,25 @@ workflow_state = ? ` +
 		`shard_id, type, domain_id, workflow_id, run_id, visibility_ts, task_id, current_run_id, execution, replication_state, workflow_last_write_version, workflow_state) ` +
 		`VALUES(?, ?, ?, ?, ?, ?, ?, ?, {run_id: ?, create_request_id: ?, state: ?, close_status: ?}, {start_version: ?, last_write_version: ?}, ?, ?) IF NOT EXISTS USING TTL 0 `
 
-	templateCreateWorkflowExecutionQuery = `INSERT INTO executions (` +
-		`shard_id, domain_id, workflow_id, run_id, type, execution, next_event_id, visibility_ts, task_id) ` +
-		`VALUES(?, ?, ?, ?, ?, ` + templateWorkflowExecutionType + `, ?, ?, ?) `
-
-	templateCreateWorkflowExecutionWithReplicationQuery = `INSERT INTO executions (` +
-		`shard_id, domain_id, workflow_id, run_id, type, execution, replication_state, next_event_id, visibility_ts, task_id) ` +
-		`VALUES(?, ?, ?, ?, ?, ` + templateWorkflowExecutionType + `, ` + templateReplicationStateType + `, ?, ?, ?) `
+	templateBlockCurrentWorkflowExecutionQuery = `UPDATE executions USING TTL 0 ` +
+		`SET blocked_until = ? ` +
+		`WHERE shard_id = ? ` +
+		`and type = ? ` +
+		`and domain_id = ? ` +
+		`and workflow_id = ? ` +
+		`and run_id = ? ` +
+		`and visibility_ts = ? ` +
+		`and task_id = ? ` +
+		`IF current_run_id = ? `
 
 	templateCreateTransferTaskQuery = `INSERT INTO executions (` +
 		`shard_id, type, domain_id, workflow_id, run_id, transfer, visibility_ts, task_id) ` +
 		`VALUES(?, ?, ?, ?, ?, ` + templateTransferTaskType + `, ?, ?)`
 
+	templateCreateVisibilityTaskQuery = `INSERT INTO executions (` +
+		`shard_id, type, domain_id, workflow_id, run_id, visibility, visibility_ts, task_id) ` +
+		`VALUES(?, ?, ?, ?, ?, ` + templateVisibilityTaskType + `, ?, ?)`
+
 	templateCreateReplicationTaskQuery = `INSERT INTO executions (` +
 		`shard_id, type, domain_id, workflow_id, run_id, replication, visibility_ts, task_id) ` +
 		`VALUES(?, ?, ?, ?, ?, ` + templateReplicationTaskType + `, ?, ?)`
@@ -400,7 +377,7 @@ workflow_state = ? ` +
 		`and task_id = ? ` +
 		`IF range_id = ?`
 
-	templateGetWorkflowExecutionQuery = `SELECT execution, replication_state, activity_map, timer_map, child_executions_map, request_cancel_map, signal_map, signal_requested, buffered_events_list, buffered_replication_tasks_map ` +
+	templateGetWorkflowExecutionQuery = `SELECT execution, replication_state, activity_map, timer_map, child_executions_map, request_cancel_map, signal_map, signal_requested, update_map, buffered_events_list, buffered_replication_tasks_map ` +
 		`FROM executions ` +
 		`WHERE shard_id = ? ` +
 		`and type = ? ` +
@@ -410,7 +387,7 @@ workflow_state = ? ` +
 		`and visibility_ts = ? ` +
 		`and task_id = ?`
 
-	templateGetCurrentExecutionQuery = `SELECT current_run_id, execution, replication_state ` +
+	templateGetCurrentExecutionQuery = `SELECT current_run_id, execution, replication_state, blocked_until ` +
 		`FROM executions ` +
 		`WHERE shard_id = ? ` +
 		`and type = ? ` +
@@ -420,30 +397,20 @@ workflow_state = ? ` +
 		`and visibility_ts = ? ` +
 		`and task_id = ?`
 
-	templateCheckWorkflowExecutionQuery = `UPDATE executions ` +
-		`SET next_event_id = ? ` +
+	templateListWorkflowExecutionRunsQuery = `SELECT run_id, execution ` +
+		`FROM executions ` +
 		`WHERE shard_id = ? ` +
 		`and type = ? ` +
 		`and domain_id = ? ` +
-		`and workflow_id = ? ` +
-		`and run_id = ? ` +
-		`and visibility_ts = ? ` +
-		`and task_id = ? ` +
-		`IF next_event_id = ?`
+		`and workflow_id = ?`
 
-	templateUpdateWorkflowExecutionQuery = `UPDATE executions ` +
-		`SET execution = ` + templateWorkflowExecutionType + `, next_event_id = ? ` +
+	templateListCurrentExecutionsQuery = `SELECT domain_id, workflow_id, run_id, current_run_id, execution ` +
+		`FROM executions ` +
 		`WHERE shard_id = ? ` +
-		`and type = ? ` +
-		`and domain_id = ? ` +
-		`and workflow_id = ? ` +
-		`and run_id = ? ` +
-		`and visibility_ts = ? ` +
-		`and task_id = ? ` +
-		`IF next_event_id = ? `
+		`and type = ?`
 
-	templateUpdateWorkflowExecutionWithReplicationQuery = `UPDATE executions ` +
-		`SET execution = ` + templateWorkflowExecutionType + `, replication_state = ` + templateReplicationStateType + `, next_event_id = ? ` +
+	templateCheckWorkflowExecutionQuery = `UPDATE executions ` +
+		`SET next_event_id = ? ` +
 		`WHERE shard_id = ? ` +
 		`and type = ? ` +
 		`and domain_id = ? ` +
@@ -451,7 +418,7 @@ workflow_state = ? ` +
 		`and run_id = ? ` +
 		`and visibility_ts = ? ` +
 		`and task_id = ? ` +
-		`IF next_event_id = ? `
+		`IF next_event_id = ?`
 
 	templateUpdateActivityInfoQuery = `UPDATE executions ` +
 		`SET activity_map[ ? ] =` + templateActivityInfoType + ` ` +
@@ -553,6 +520,26 @@ workflow_state = ? ` +
 		`and visibility_ts = ? ` +
 		`and task_id = ? `
 
+	templateUpdateUpdateInfoQuery = `UPDATE executions ` +
+		`SET update_map[ ? ] =` + templateUpdateInfoType + ` ` +
+		`WHERE shard_id = ? ` +
+		`and type = ? ` +
+		`and domain_id = ? ` +
+		`and workflow_id = ? ` +
+		`and run_id = ? ` +
+		`and visibility_ts = ? ` +
+		`and task_id = ? `
+
+	templateResetUpdateInfoQuery = `UPDATE executions ` +
+		`SET update_map = ?` +
+		`WHERE shard_id = ? ` +
+		`and type = ? ` +
+		`and domain_id = ? ` +
+		`and workflow_id = ? ` +
+		`and run_id = ? ` +
+		`and visibility_ts = ? ` +
+		`and task_id = ? `
+
 	templateUpdateSignalRequestedQuery = `UPDATE executions ` +
 		`SET signal_requested = signal_requested + ? ` +
 		`WHERE shard_id = ? ` +
@@ -643,6 +630,16 @@ workflow_state = ? ` +
 		`and visibility_ts = ? ` +
 		`and task_id = ? `
 
+	templateDeleteUpdateInfoQuery = `DELETE update_map[ ? ] ` +
+		`FROM executions ` +
+		`WHERE shard_id = ? ` +
+		`and type = ? ` +
+		`and domain_id = ? ` +
+		`and workflow_id = ? ` +
+		`and run_id = ? ` +
+		`and visibility_ts = ? ` +
+		`and task_id = ? `
+
 	templateDeleteWorkflowExecutionMutableStateQuery = `DELETE FROM executions ` +
 		`WHERE shard_id = ? ` +
 		`and type = ? ` +
@@ -675,6 +672,65 @@ workflow_state = ? ` +
 		`and task_id > ? ` +
 		`and task_id <= ?`
 
+	templateGetTransferTasksWithLimitQuery = templateGetTransferTasksQuery + ` LIMIT ?`
+
+	templateCreateBucketedTransferTaskQuery = `INSERT INTO transfer_tasks (` +
+		`shard_id, bucket, task_id, transfer) ` +
+		`VALUES(?, ?, ?, ` + templateTransferTaskType + `)`
+
+	templateGetBucketedTransferTasksQuery = `SELECT transfer ` +
+		`FROM transfer_tasks ` +
+		`WHERE shard_id = ? ` +
+		`and bucket = ? ` +
+		`and task_id > ? ` +
+		`and task_id <= ? ` +
+		`LIMIT ?`
+
+	templateCompleteBucketedTransferTaskQuery = `DELETE FROM transfer_tasks ` +
+		`WHERE shard_id = ? ` +
+		`and bucket = ? ` +
+		`and task_id = ?`
+
+	templateRangeCompleteBucketedTransferTaskQuery = `DELETE FROM transfer_tasks ` +
+		`WHERE shard_id = ? ` +
+		`and bucket = ? ` +
+		`and task_id > ? ` +
+		`and task_id <= ?`
+
+	templateCreateClosedExecutionIndexQuery = `INSERT INTO closed_executions_index (` +
+		`shard_id, close_day, close_time, domain_id, workflow_id, run_id, version) ` +
+		`VALUES(?, ?, ?, ?, ?, ?, ?)`
+
+	templateGetClosedExecutionIndexQuery = `SELECT close_time, domain_id, workflow_id, run_id, version ` +
+		`FROM closed_executions_index ` +
+		`WHERE shard_id = ? ` +
+		`and close_day = ?`
+
+	templateGetClosedExecutionIndexSinceQuery = templateGetClosedExecutionIndexQuery + ` and close_time >= ?`
+
+	templateDeleteClosedExecutionIndexQuery = `DELETE FROM closed_executions_index ` +
+		`WHERE shard_id = ? ` +
+		`and close_day = ? ` +
+		`and close_time = ? ` +
+		`and domain_id = ? ` +
+		`and workflow_id = ? ` +
+		`and run_id = ?`
+
+	templateListBufferedReplicationTasksQuery = `SELECT domain_id, workflow_id, run_id, buffered_replication_tasks_map ` +
+		`FROM executions ` +
+		`WHERE shard_id = ? ` +
+		`and type = ?`
+
+	templateDeleteBufferedReplicationTasksQuery = `DELETE buffered_replication_tasks_map ` +
+		`FROM executions ` +
+		`WHERE shard_id = ? ` +
+		`and type = ? ` +
+		`and domain_id = ? ` +
+		`and workflow_id = ? ` +
+		`and run_id = ? ` +
+		`and visibility_ts = ? ` +
+		`and task_id = ?`
+
 	templateGetReplicationTasksQuery = `SELECT replication ` +
 		`FROM executions ` +
 		`WHERE shard_id = ? ` +
@@ -686,6 +742,17 @@ workflow_state = ? ` +
 		`and task_id > ? ` +
 		`and task_id <= ?`
 
+	templateGetVisibilityTasksQuery = `SELECT visibility ` +
+		`FROM executions ` +
+		`WHERE shard_id = ? ` +
+		`and type = ? ` +
+		`and domain_id = ? ` +
+		`and workflow_id = ? ` +
+		`and run_id = ? ` +
+		`and visibility_ts = ? ` +
+		`and task_id > ? ` +
+		`and task_id <= ?`
+
 	templateCompleteTransferTaskQuery = `DELETE FROM executions ` +
 		`WHERE shard_id = ? ` +
 		`and type = ? ` +
@@ -705,6 +772,15 @@ workflow_state = ? ` +
 		`and task_id > ? ` +
 		`and task_id <= ?`
 
+	templateRangeCompleteReplicationTaskQuery = `DELETE FROM executions ` +
+		`WHERE shard_id = ? ` +
+		`and type = ? ` +
+		`and domain_id = ? ` +
+		`and workflow_id = ? ` +
+		`and run_id = ? ` +
+		`and visibility_ts = ? ` +
+		`and task_id <= ?`
+
 	templateGetTimerTasksQuery = `SELECT timer ` +
 		`FROM executions ` +
 		`WHERE shard_id = ? ` +
@@ -813,6 +889,40 @@ workflow_state = ? ` +
 		`IF range_id = ?`
 )
 
+// the execution create and update queries are generated from the row types for the schema version
+// of the keyspace, see executionRowMapper
+const (
+	// conditionalBatchTemplate names the conditional batch of generated queries in store operation errors,
+	// the statements of the batch are not identified individually
+	conditionalBatchTemplate = "conditionalBatch"
+
+	templateExecutionRowCondition = `WHERE shard_id = ? ` +
+		`and type = ? ` +
+		`and domain_id = ? ` +
+		`and workflow_id = ? ` +
+		`and run_id = ? ` +
+		`and visibility_ts = ? ` +
+		`and task_id = ? ` +
+		`IF next_event_id = ? `
+
+	templateGetSchemaVersionQuery = `SELECT curr_version FROM schema_version WHERE keyspace_name = ?`
+
+	templatePingQuery = `SELECT range_id ` +
+		`FROM executions ` +
+		`WHERE shard_id = ? ` +
+		`and type = ? ` +
+		`and domain_id = ? ` +
+		`and workflow_id = ? ` +
+		`and run_id = ? ` +
+		`and visibility_ts = ? ` +
+		`and task_id = ?`
+
+	templateGetVisibilityTimestampTypeQuery = `SELECT type FROM system_schema.columns ` +
+		`WHERE keyspace_name = ? ` +
+		`and table_name = 'executions' ` +
+		`and column_name = 'visibility_ts'`
+)
+
 var (
 	defaultDateTime            = time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC)
 	defaultVisibilityTimestamp = p.UnixNanoToDBTimestamp(defaultDateTime.UnixNano())
@@ -829,6 +939,24 @@ type (
 		cassandraStore
 		shardID            int
 		currentClusterName string
+		// number of transfer_tasks partitions per shard, zero means transfer tasks
+		// are kept in the executions table
+		transferTaskBuckets int
+		rowMappers          *executionRowMappers
+		// readSession serves the scans of the execution store, it is the write session unless a
+		// separate read session is configured. Queue reads and mutable state reads stay on the
+		// write session since a stale read there loses tasks or corrupts the workflow
+		readSession *gocql.Session
+	}
+
+	// crossPartitionBatch collects the writes to the closed execution index and to the bucketed
+	// transfer_tasks table, those live outside of the shard partition and cannot be part of the conditional
+	// update of the executions table. The transfer tasks are staged in the shard partition by the conditional
+	// update and bucketBatch moves them to their bucket once the update is applied
+	crossPartitionBatch struct {
+		*gocql.Batch
+		bucketBatch         *gocql.Batch
+		transferTaskBuckets int
 	}
 )
 
@@ -836,7 +964,7 @@ var _ p.ExecutionStore = (*cassandraPersistence)(nil)
 
 // newShardPersistence is used to create an instance of ShardManager implementation
 func newShardPersistence(cfg config.Cassandra, clusterName string, logger log.Logger) (p.ShardStore, error) {
-	cluster := NewCassandraCluster(cfg.Hosts, cfg.Port, cfg.User, cfg.Password, cfg.Datacenter)
+	cluster := NewCassandraCluster(cfg.Hosts, cfg.Port, cfg.User, cfg.Password, cfg.Datacenter, cfg.Compression)
 	cluster.Keyspace = cfg.Keyspace
 	cluster.ProtoVersion = cassandraProtoVersion
 	cluster.Consistency = gocql.LocalQuorum
@@ -849,21 +977,183 @@ func newShardPersistence(cfg config.Cassandra, clusterName string, logger log.Lo
 	}
 
 	return &cassandraPersistence{
-		cassandraStore:     cassandraStore{session: session, logger: logger},
-		shardID:            -1,
-		currentClusterName: clusterName,
+		cassandraStore:      cassandraStore{session: session, logger: logger},
+		shardID:             -1,
+		currentClusterName:  clusterName,
+		transferTaskBuckets: cfg.TransferTaskBuckets,
 	}, nil
 }
 
 // NewWorkflowExecutionPersistence is used to create an instance of workflowExecutionManager implementation
 func NewWorkflowExecutionPersistence(shardID int, session *gocql.Session,
 	logger log.Logger) (p.ExecutionStore, error) {
-	return &cassandraPersistence{cassandraStore: cassandraStore{session: session, logger: logger}, shardID: shardID}, nil
+	return newWorkflowExecutionPersistence(shardID, 0, newFixedExecutionRowMappers(latestSchemaVersion), session, session, logger)
+}
+
+func newWorkflowExecutionPersistence(shardID int, transferTaskBuckets int, rowMappers *executionRowMappers,
+	session *gocql.Session, readSession *gocql.Session, logger log.Logger) (p.ExecutionStore, error) {
+	return &cassandraPersistence{
+		cassandraStore:      cassandraStore{session: session, logger: logger},
+		shardID:             shardID,
+		transferTaskBuckets: transferTaskBuckets,
+		rowMappers:          rowMappers,
+		readSession:         readSession,
+	}, nil
+}
+
+// readSchemaVersion returns the schema version of the keyspace of the session
+func readSchemaVersion(session *gocql.Session, keyspace string) (schemaVersion, error) {
+	var version string
+	if err := session.Query(templateGetSchemaVersionQuery, keyspace).Scan(&version); err != nil {
+		return schemaVersion{}, err
+	}
+	return parseSchemaVersion(version)
+}
+
+// validateVisibilityTimestampEncoding checks that the visibility_ts column of the keyspace of the session is a cql
+// timestamp and that the timestamps written to it are encoded at its millisecond precision. The timer tasks are
+// ordered by visibility_ts, so an encoding mismatch silently breaks the order in which they fire.
+func validateVisibilityTimestampEncoding(session *gocql.Session, keyspace string, logger log.Logger) error {
+	var columnType string
+	if err := session.Query(templateGetVisibilityTimestampTypeQuery, keyspace).Scan(&columnType); err != nil {
+		logger.Warn("Unable to read the type of the visibility_ts column", tag.Error(err))
+	} else if columnType != "timestamp" {
+		return fmt.Errorf("visibility_ts of keyspace %v is of type %v, expected timestamp", keyspace, columnType)
+	}
+	return validateTimestampEncoding()
+}
+
+// validateTimestampEncoding checks that the conversions to cql timestamps agree with the millisecond encoding of gocql
+func validateTimestampEncoding() error {
+	now := time.Now().Truncate(time.Millisecond)
+	if p.DBTimestampToUnixNano(p.UnixNanoToDBTimestamp(now.UnixNano())) != now.UnixNano() {
+		return fmt.Errorf("cql timestamp conversion does not round trip %v", now)
+	}
+
+	for _, ts := range []time.Time{defaultDateTime, now} {
+		data, err := gocql.Marshal(gocql.NewNativeType(cassandraProtoVersion, gocql.TypeTimestamp, ""), ts)
+		if err != nil {
+			return err
+		}
+		var encoded int64
+		if err := gocql.Unmarshal(gocql.NewNativeType(cassandraProtoVersion, gocql.TypeBigInt, ""), data, &encoded); err != nil {
+			return err
+		}
+		if converted := p.UnixNanoToDBTimestamp(ts.UnixNano()); converted != encoded {
+			return fmt.Errorf("cql timestamp of %v is encoded as %v, converted as %v", ts, encoded, converted)
+		}
+	}
+	if defaultVisibilityTimestamp != p.UnixNanoToDBTimestamp(defaultDateTime.UnixNano()) {
+		return fmt.Errorf("default visibility timestamp %v does not encode %v", defaultVisibilityTimestamp, defaultDateTime)
+	}
+	return nil
+}
+
+func transferTaskBucket(taskID int64, numBuckets int) int {
+	return int(taskID % int64(numBuckets))
+}
+
+// maxTransferTaskBuckets returns the bucket count of a shard, the count never decreases since the
+// transfer tasks are only read from the buckets below the count
+func maxTransferTaskBuckets(persisted int, configured int) int {
+	if persisted > configured {
+		return persisted
+	}
+	return configured
+}
+
+// readShardTransferTaskBuckets returns the number of transfer task buckets persisted by the shard, zero
+// if the shard does not exist yet
+func readShardTransferTaskBuckets(session *gocql.Session, shardID int) (int, error) {
+	query := session.Query(templateGetShardQuery,
+		shardID,
+		rowTypeShard,
+		rowTypeShardDomainID,
+		rowTypeShardWorkflowID,
+		rowTypeShardRunID,
+		defaultVisibilityTimestamp,
+		rowTypeShardTaskID)
+
+	result := make(map[string]interface{})
+	if err := query.MapScan(result); err != nil {
+		if err == gocql.ErrNotFound {
+			return 0, nil
+		}
+		return 0, err
+	}
+	return createShardInfo("", result["shard"].(map[string]interface{})).TransferTaskBuckets, nil
+}
+
+func (d *cassandraPersistence) newCrossPartitionBatch() *crossPartitionBatch {
+	return &crossPartitionBatch{
+		Batch:               d.session.NewBatch(gocql.LoggedBatch),
+		bucketBatch:         d.session.NewBatch(gocql.LoggedBatch),
+		transferTaskBuckets: d.transferTaskBuckets,
+	}
+}
+
+// newExecutionOperationError returns the internal failure of an execution operation with the operation, the
+// query template, the shard and the execution as fields, so that failures can be grouped by operation instead
+// of by message text
+func (d *cassandraPersistence) newExecutionOperationError(
+	operation string,
+	template string,
+	domainID string,
+	workflowID string,
+	runID string,
+	err error,
+) error {
+
+	return &p.StoreOperationError{
+		Operation:  operation,
+		Template:   template,
+		ShardID:    d.shardID,
+		DomainID:   domainID,
+		WorkflowID: workflowID,
+		RunID:      runID,
+		Msg:        fmt.Sprintf("%v operation failed. Error: %v", operation, err),
+	}
+}
+
+// executeCrossPartitionBatch persists the closed execution index entries ahead of the conditional update
+// of the workflow. Entries left behind by a failed condition are dropped by the retention sweep.
+func (d *cassandraPersistence) executeCrossPartitionBatch(crossBatch *crossPartitionBatch, operation string) error {
+	if len(crossBatch.Entries) == 0 {
+		return nil
+	}
+
+	if err := d.session.ExecuteBatch(crossBatch.Batch); err != nil {
+		if isThrottlingError(err) {
+			return &workflow.ServiceBusyError{
+				Message: fmt.Sprintf("%v operation failed. Error: %v", operation, err),
+			}
+		}
+		return &workflow.InternalServiceError{
+			Message: fmt.Sprintf("%v operation failed. Error: %v", operation, err),
+		}
+	}
+	return nil
+}
+
+// moveTransferTasksToBuckets moves the transfer tasks staged in the shard partition by an applied conditional
+// update to their bucket. A failed move is not surfaced, the staged tasks are still read from the shard
+// partition and are cleaned up by range completion.
+func (d *cassandraPersistence) moveTransferTasksToBuckets(crossBatch *crossPartitionBatch, operation tag.Tag) {
+	if len(crossBatch.bucketBatch.Entries) == 0 {
+		return
+	}
+
+	if err := d.session.ExecuteBatch(crossBatch.bucketBatch); err != nil {
+		d.logger.Warn("Unable to move transfer tasks to their bucket.",
+			operation,
+			tag.ShardID(d.shardID),
+			tag.Error(err))
+	}
 }
 
 // newTaskPersistence is used to create an instance of TaskManager implementation
 func newTaskPersistence(cfg config.Cassandra, logger log.Logger) (p.TaskStore, error) {
-	cluster := NewCassandraCluster(cfg.Hosts, cfg.Port, cfg.User, cfg.Password, cfg.Datacenter)
+	cluster := NewCassandraCluster(cfg.Hosts, cfg.Port, cfg.User, cfg.Password, cfg.Datacenter, cfg.Compression)
 	cluster.Keyspace = cfg.Keyspace
 	cluster.ProtoVersion = cassandraProtoVersion
 	cluster.Consistency = gocql.LocalQuorum
@@ -891,6 +1181,26 @@ func (d *cassandraPersistence) GetShardID() int {
 	return d.shardID
 }
 
+// Ping reads the shard row of the store, the task store has no shard so the row is not found, reaching the
+// store is enough
+func (d *cassandraPersistence) Ping() error {
+	var rangeID int64
+	query := d.session.Query(templatePingQuery,
+		d.shardID,
+		rowTypeShard,
+		rowTypeShardDomainID,
+		rowTypeShardWorkflowID,
+		rowTypeShardRunID,
+		defaultVisibilityTimestamp,
+		rowTypeShardTaskID)
+	if err := query.Scan(&rangeID); err != nil && err != gocql.ErrNotFound {
+		return &workflow.InternalServiceError{
+			Message: fmt.Sprintf("Ping operation failed. Error: %v", err),
+		}
+	}
+	return nil
+}
+
 func (d *cassandraPersistence) CreateShard(request *p.CreateShardRequest) error {
 	cqlNowTimestamp := p.UnixNanoToDBTimestamp(time.Now().UnixNano())
 	shardInfo := request.ShardInfo
@@ -913,6 +1223,15 @@ func (d *cassandraPersistence) CreateShard(request *p.CreateShardRequest) error
 		shardInfo.ClusterTransferAckLevel,
 		shardInfo.ClusterTimerAckLevel,
 		shardInfo.DomainNotificationVersion,
+		shardInfo.DomainOpenExecutionCounts,
+		shardInfo.VisibilityAckLevel,
+		createQueueAlarmsMap(shardInfo.QueueAlarms),
+		createHotExecutionsList(shardInfo.HotExecutions),
+		shardInfo.LeaseExpiresAt,
+		shardInfo.TasksPerSecond,
+		shardInfo.TaskThroughputUpdatedAt,
+		shardInfo.Placement,
+		maxTransferTaskBuckets(shardInfo.TransferTaskBuckets, d.transferTaskBuckets),
 		shardInfo.RangeID)
 
 	previous := make(map[string]interface{})
@@ -988,6 +1307,15 @@ func (d *cassandraPersistence) UpdateShard(request *p.UpdateShardRequest) error
 		shardInfo.ClusterTransferAckLevel,
 		shardInfo.ClusterTimerAckLevel,
 		shardInfo.DomainNotificationVersion,
+		shardInfo.DomainOpenExecutionCounts,
+		shardInfo.VisibilityAckLevel,
+		createQueueAlarmsMap(shardInfo.QueueAlarms),
+		createHotExecutionsList(shardInfo.HotExecutions),
+		shardInfo.LeaseExpiresAt,
+		shardInfo.TasksPerSecond,
+		shardInfo.TaskThroughputUpdatedAt,
+		shardInfo.Placement,
+		maxTransferTaskBuckets(shardInfo.TransferTaskBuckets, d.transferTaskBuckets),
 		shardInfo.RangeID,
 		shardInfo.ShardID,
 		rowTypeShard,
@@ -1032,6 +1360,7 @@ func (d *cassandraPersistence) CreateWorkflowExecution(
 ) (*p.CreateWorkflowExecutionResponse, error) {
 
 	batch := d.session.NewBatch(gocql.LoggedBatch)
+	crossBatch := d.newCrossPartitionBatch()
 
 	executionInfo := request.NewWorkflowSnapshot.ExecutionInfo
 	replicationState := request.NewWorkflowSnapshot.ReplicationState
@@ -1055,7 +1384,9 @@ func (d *cassandraPersistence) CreateWorkflowExecution(
 		return nil, err
 	}
 	if err := applyWorkflowSnapshotBatchAsNew(batch,
+		crossBatch,
 		d.shardID,
+		d.rowMappers.current(),
 		&request.NewWorkflowSnapshot,
 	); err != nil {
 		return nil, err
@@ -1073,6 +1404,10 @@ func (d *cassandraPersistence) CreateWorkflowExecution(
 		request.RangeID,
 	)
 
+	if err := d.executeCrossPartitionBatch(crossBatch, "CreateWorkflowExecution"); err != nil {
+		return nil, err
+	}
+
 	previous := make(map[string]interface{})
 	applied, iter, err := d.session.MapExecuteBatchCAS(batch, previous)
 	defer func() {
@@ -1092,9 +1427,14 @@ func (d *cassandraPersistence) CreateWorkflowExecution(
 			}
 		}
 
-		return nil, &workflow.InternalServiceError{
-			Message: fmt.Sprintf("CreateWorkflowExecution operation failed. Error: %v", err),
-		}
+		return nil, d.newExecutionOperationError(
+			"CreateWorkflowExecution",
+			conditionalBatchTemplate,
+			domainID,
+			workflowID,
+			runID,
+			err,
+		)
 	}
 
 	if !applied {
@@ -1131,6 +1471,8 @@ func (d *cassandraPersistence) CreateWorkflowExecution(
 					replicationState := createReplicationState(previous["replication_state"].(map[string]interface{}))
 					lastWriteVersion := replicationState.LastWriteVersion
 
+					blockedUntil, _ := previous["blocked_until"].(time.Time)
+
 					msg := fmt.Sprintf("Workflow execution already running. WorkflowId: %v, RunId: %v, rangeID: %v, columns: (%v)",
 						executionInfo.WorkflowID, executionInfo.RunID, request.RangeID, strings.Join(columns, ","))
 					return nil, &p.WorkflowExecutionAlreadyStartedError{
@@ -1140,6 +1482,7 @@ func (d *cassandraPersistence) CreateWorkflowExecution(
 						State:            executionInfo.State,
 						CloseStatus:      executionInfo.CloseStatus,
 						LastWriteVersion: lastWriteVersion,
+						BlockedUntil:     blockedUntil,
 					}
 				}
 
@@ -1177,6 +1520,7 @@ func (d *cassandraPersistence) CreateWorkflowExecution(
 		}
 	}
 
+	d.moveTransferTasksToBuckets(crossBatch, tag.StoreOperationCreateWorkflowExecution)
 	return &p.CreateWorkflowExecutionResponse{}, nil
 }
 
@@ -1191,9 +1535,13 @@ func (d *cassandraPersistence) GetWorkflowExecution(request *p.GetWorkflowExecut
 		*execution.RunId,
 		defaultVisibilityTimestamp,
 		rowTypeExecutionTaskID)
+	query = withRequestContext(query, request.Context)
 
 	result := make(map[string]interface{})
 	if err := query.MapScan(result); err != nil {
+		if ctxErr := requestContextError(request.Context); ctxErr != nil {
+			return nil, ctxErr
+		}
 		if err == gocql.ErrNotFound {
 			return nil, &workflow.EntityNotExistsError{
 				Message: fmt.Sprintf("Workflow execution not found.  WorkflowId: %v, RunId: %v",
@@ -1205,20 +1553,29 @@ func (d *cassandraPersistence) GetWorkflowExecution(request *p.GetWorkflowExecut
 			}
 		}
 
-		return nil, &workflow.InternalServiceError{
-			Message: fmt.Sprintf("GetWorkflowExecution operation failed. Error: %v", err),
-		}
+		return nil, d.newExecutionOperationError(
+			"GetWorkflowExecution",
+			"templateGetWorkflowExecutionQuery",
+			request.DomainID,
+			execution.GetWorkflowId(),
+			execution.GetRunId(),
+			err,
+		)
 	}
 
+	// the columns are decoded leniently, a column missing from a row written by an older schema
+	// version is read back empty
 	state := &p.InternalWorkflowMutableState{}
-	info := createWorkflowExecutionInfo(result["execution"].(map[string]interface{}))
+	executionMap, _ := result["execution"].(map[string]interface{})
+	info := createWorkflowExecutionInfo(executionMap)
 	state.ExecutionInfo = info
 
-	replicationState := createReplicationState(result["replication_state"].(map[string]interface{}))
+	replicationStateMap, _ := result["replication_state"].(map[string]interface{})
+	replicationState := createReplicationState(replicationStateMap)
 	state.ReplicationState = replicationState
 
 	activityInfos := make(map[int64]*p.InternalActivityInfo)
-	aMap := result["activity_map"].(map[int64]map[string]interface{})
+	aMap, _ := result["activity_map"].(map[int64]map[string]interface{})
 	for key, value := range aMap {
 		info := createActivityInfo(request.DomainID, value)
 		activityInfos[key] = info
@@ -1226,7 +1583,7 @@ func (d *cassandraPersistence) GetWorkflowExecution(request *p.GetWorkflowExecut
 	state.ActivitInfos = activityInfos
 
 	timerInfos := make(map[string]*p.TimerInfo)
-	tMap := result["timer_map"].(map[string]map[string]interface{})
+	tMap, _ := result["timer_map"].(map[string]map[string]interface{})
 	for key, value := range tMap {
 		info := createTimerInfo(value)
 		timerInfos[key] = info
@@ -1234,7 +1591,7 @@ func (d *cassandraPersistence) GetWorkflowExecution(request *p.GetWorkflowExecut
 	state.TimerInfos = timerInfos
 
 	childExecutionInfos := make(map[int64]*p.InternalChildExecutionInfo)
-	cMap := result["child_executions_map"].(map[int64]map[string]interface{})
+	cMap, _ := result["child_executions_map"].(map[int64]map[string]interface{})
 	for key, value := range cMap {
 		info := createChildExecutionInfo(value)
 		childExecutionInfos[key] = info
@@ -1242,7 +1599,7 @@ func (d *cassandraPersistence) GetWorkflowExecution(request *p.GetWorkflowExecut
 	state.ChildExecutionInfos = childExecutionInfos
 
 	requestCancelInfos := make(map[int64]*p.RequestCancelInfo)
-	rMap := result["request_cancel_map"].(map[int64]map[string]interface{})
+	rMap, _ := result["request_cancel_map"].(map[int64]map[string]interface{})
 	for key, value := range rMap {
 		info := createRequestCancelInfo(value)
 		requestCancelInfos[key] = info
@@ -1250,7 +1607,7 @@ func (d *cassandraPersistence) GetWorkflowExecution(request *p.GetWorkflowExecut
 	state.RequestCancelInfos = requestCancelInfos
 
 	signalInfos := make(map[int64]*p.SignalInfo)
-	sMap := result["signal_map"].(map[int64]map[string]interface{})
+	sMap, _ := result["signal_map"].(map[int64]map[string]interface{})
 	for key, value := range sMap {
 		info := createSignalInfo(value)
 		signalInfos[key] = info
@@ -1258,13 +1615,21 @@ func (d *cassandraPersistence) GetWorkflowExecution(request *p.GetWorkflowExecut
 	state.SignalInfos = signalInfos
 
 	signalRequestedIDs := make(map[string]struct{})
-	sList := result["signal_requested"].([]gocql.UUID)
+	sList, _ := result["signal_requested"].([]gocql.UUID)
 	for _, v := range sList {
 		signalRequestedIDs[v.String()] = struct{}{}
 	}
 	state.SignalRequestedIDs = signalRequestedIDs
 
-	eList := result["buffered_events_list"].([]map[string]interface{})
+	updateInfos := make(map[string]*p.UpdateInfo)
+	uMap, _ := result["update_map"].(map[string]map[string]interface{})
+	for key, value := range uMap {
+		info := createUpdateInfo(value)
+		updateInfos[key] = info
+	}
+	state.UpdateInfos = updateInfos
+
+	eList, _ := result["buffered_events_list"].([]map[string]interface{})
 	bufferedEventsBlobs := make([]*p.DataBlob, 0, len(eList))
 	for _, v := range eList {
 		blob := createHistoryEventBatchBlob(v)
@@ -1278,12 +1643,14 @@ func (d *cassandraPersistence) GetWorkflowExecution(request *p.GetWorkflowExecut
 func (d *cassandraPersistence) UpdateWorkflowExecution(request *p.InternalUpdateWorkflowExecutionRequest) error {
 
 	batch := d.session.NewBatch(gocql.LoggedBatch)
+	crossBatch := d.newCrossPartitionBatch()
 
 	updateWorkflow := request.UpdateWorkflowMutation
 	shardID := d.shardID
 	executionInfo := updateWorkflow.ExecutionInfo
+	rowMapper := d.rowMappers.current()
 
-	if err := applyWorkflowMutationBatch(batch, shardID, &updateWorkflow); err != nil {
+	if err := applyWorkflowMutationBatch(batch, crossBatch, shardID, rowMapper, &updateWorkflow); err != nil {
 		return err
 	}
 
@@ -1311,7 +1678,9 @@ func (d *cassandraPersistence) UpdateWorkflowExecution(request *p.InternalUpdate
 			return err
 		}
 		if err := applyWorkflowSnapshotBatchAsNew(batch,
+			crossBatch,
 			d.shardID,
+			rowMapper,
 			request.NewWorkflowSnapshot,
 		); err != nil {
 			return err
@@ -1357,6 +1726,10 @@ func (d *cassandraPersistence) UpdateWorkflowExecution(request *p.InternalUpdate
 		request.RangeID,
 	)
 
+	if err := d.executeCrossPartitionBatch(crossBatch, "UpdateWorkflowExecution"); err != nil {
+		return err
+	}
+
 	previous := make(map[string]interface{})
 	applied, iter, err := d.session.MapExecuteBatchCAS(batch, previous)
 	defer func() {
@@ -1375,22 +1748,31 @@ func (d *cassandraPersistence) UpdateWorkflowExecution(request *p.InternalUpdate
 				Message: fmt.Sprintf("UpdateWorkflowExecution operation failed. Error: %v", err),
 			}
 		}
-		return &workflow.InternalServiceError{
-			Message: fmt.Sprintf("UpdateWorkflowExecution operation failed. Error: %v", err),
-		}
+		return d.newExecutionOperationError(
+			"UpdateWorkflowExecution",
+			conditionalBatchTemplate,
+			executionInfo.DomainID,
+			executionInfo.WorkflowID,
+			executionInfo.RunID,
+			err,
+		)
 	}
 
 	if !applied {
 		return d.getExecutionConditionalUpdateFailure(previous, iter, executionInfo.RunID, updateWorkflow.Condition, request.RangeID, executionInfo.RunID)
 	}
+
+	d.moveTransferTasksToBuckets(crossBatch, tag.StoreOperationUpdateWorkflowExecution)
 	return nil
 }
 
 func (d *cassandraPersistence) ResetWorkflowExecution(request *p.InternalResetWorkflowExecutionRequest) error {
 
 	batch := d.session.NewBatch(gocql.LoggedBatch)
+	crossBatch := d.newCrossPartitionBatch()
 
 	shardID := d.shardID
+	rowMapper := d.rowMappers.current()
 
 	domainID := request.NewWorkflowSnapshot.ExecutionInfo.DomainID
 	workflowID := request.NewWorkflowSnapshot.ExecutionInfo.WorkflowID
@@ -1450,7 +1832,7 @@ func (d *cassandraPersistence) ResetWorkflowExecution(request *p.InternalResetWo
 	}
 
 	if request.CurrentWorkflowMutation != nil {
-		if err := applyWorkflowMutationBatch(batch, shardID, request.CurrentWorkflowMutation); err != nil {
+		if err := applyWorkflowMutationBatch(batch, crossBatch, shardID, rowMapper, request.CurrentWorkflowMutation); err != nil {
 			return err
 		}
 	} else {
@@ -1468,7 +1850,7 @@ func (d *cassandraPersistence) ResetWorkflowExecution(request *p.InternalResetWo
 		)
 	}
 
-	if err := applyWorkflowSnapshotBatchAsNew(batch, shardID, &request.NewWorkflowSnapshot); err != nil {
+	if err := applyWorkflowSnapshotBatchAsNew(batch, crossBatch, shardID, rowMapper, &request.NewWorkflowSnapshot); err != nil {
 		return err
 	}
 
@@ -1485,6 +1867,10 @@ func (d *cassandraPersistence) ResetWorkflowExecution(request *p.InternalResetWo
 		request.RangeID,
 	)
 
+	if err := d.executeCrossPartitionBatch(crossBatch, "ResetWorkflowExecution"); err != nil {
+		return err
+	}
+
 	previous := make(map[string]interface{})
 	applied, iter, err := d.session.MapExecuteBatchCAS(batch, previous)
 	defer func() {
@@ -1503,20 +1889,27 @@ func (d *cassandraPersistence) ResetWorkflowExecution(request *p.InternalResetWo
 				Message: fmt.Sprintf("ResetWorkflowExecution operation failed. Error: %v", err),
 			}
 		}
-		return &workflow.InternalServiceError{
-			Message: fmt.Sprintf("ResetWorkflowExecution operation failed. Error: %v", err),
-		}
+		return d.newExecutionOperationError(
+			"ResetWorkflowExecution",
+			conditionalBatchTemplate,
+			domainID,
+			workflowID,
+			newRunID,
+			err,
+		)
 	}
 
 	if !applied {
 		return d.getExecutionConditionalUpdateFailure(previous, iter, currentRunID, currentRunNextEventID, request.RangeID, currentRunID)
 	}
 
+	d.moveTransferTasksToBuckets(crossBatch, tag.StoreOperationResetWorkflowExecution)
 	return nil
 }
 
 func (d *cassandraPersistence) ResetMutableState(request *p.InternalResetMutableStateRequest) error {
 	batch := d.session.NewBatch(gocql.LoggedBatch)
+	crossBatch := d.newCrossPartitionBatch()
 
 	resetWorkflow := request.ResetWorkflowSnapshot
 	shardID := d.shardID
@@ -1546,7 +1939,9 @@ func (d *cassandraPersistence) ResetMutableState(request *p.InternalResetMutable
 	)
 
 	if err := applyWorkflowSnapshotBatchAsReset(batch,
+		crossBatch,
 		shardID,
+		d.rowMappers.current(),
 		&resetWorkflow); err != nil {
 		return err
 	}
@@ -1564,6 +1959,10 @@ func (d *cassandraPersistence) ResetMutableState(request *p.InternalResetMutable
 		request.RangeID,
 	)
 
+	if err := d.executeCrossPartitionBatch(crossBatch, "ResetMutableState"); err != nil {
+		return err
+	}
+
 	previous := make(map[string]interface{})
 	applied, iter, err := d.session.MapExecuteBatchCAS(batch, previous)
 	defer func() {
@@ -1582,15 +1981,21 @@ func (d *cassandraPersistence) ResetMutableState(request *p.InternalResetMutable
 				Message: fmt.Sprintf("ResetMutableState operation failed. Error: %v", err),
 			}
 		}
-		return &workflow.InternalServiceError{
-			Message: fmt.Sprintf("ResetMutableState operation failed. Error: %v", err),
-		}
+		return d.newExecutionOperationError(
+			"ResetMutableState",
+			conditionalBatchTemplate,
+			executionInfo.DomainID,
+			executionInfo.WorkflowID,
+			executionInfo.RunID,
+			err,
+		)
 	}
 
 	if !applied {
 		return d.getExecutionConditionalUpdateFailure(previous, iter, executionInfo.RunID, request.ResetWorkflowSnapshot.Condition, request.RangeID, request.PrevRunID)
 	}
 
+	d.moveTransferTasksToBuckets(crossBatch, tag.StoreOperationResetMutableState)
 	return nil
 }
 
@@ -1696,15 +2101,42 @@ func (d *cassandraPersistence) DeleteWorkflowExecution(request *p.DeleteWorkflow
 				Message: fmt.Sprintf("DeleteWorkflowExecution operation failed. Error: %v", err),
 			}
 		}
-		return &workflow.InternalServiceError{
-			Message: fmt.Sprintf("DeleteWorkflowExecution operation failed. Error: %v", err),
-		}
+		return d.newExecutionOperationError(
+			"DeleteWorkflowExecution",
+			"templateDeleteWorkflowExecutionMutableStateQuery",
+			request.DomainID,
+			request.WorkflowID,
+			request.RunID,
+			err,
+		)
 	}
 
 	return nil
 }
 
-func (d *cassandraPersistence) DeleteCurrentWorkflowExecution(request *p.DeleteCurrentWorkflowExecutionRequest) error {
+func (d *cassandraPersistence) DeleteCurrentWorkflowExecution(
+	request *p.DeleteCurrentWorkflowExecutionRequest,
+) (*p.DeleteCurrentWorkflowExecutionResponse, error) {
+
+	runID := request.RunID
+	if request.Force {
+		// read the run the current row points to first, the delete below is still conditioned on it
+		// so a run started in between is never removed
+		current, err := d.GetCurrentExecution(&p.GetCurrentExecutionRequest{
+			DomainID:   request.DomainID,
+			WorkflowID: request.WorkflowID,
+		})
+		if err != nil {
+			if _, ok := err.(*workflow.EntityNotExistsError); ok {
+				return &p.DeleteCurrentWorkflowExecutionResponse{
+					Result: p.DeleteCurrentWorkflowExecutionResultMissing,
+				}, nil
+			}
+			return nil, err
+		}
+		runID = current.RunID
+	}
+
 	query := d.session.Query(templateDeleteWorkflowExecutionCurrentRowQuery,
 		d.shardID,
 		rowTypeExecution,
@@ -1713,21 +2145,41 @@ func (d *cassandraPersistence) DeleteCurrentWorkflowExecution(request *p.DeleteC
 		permanentRunID,
 		defaultVisibilityTimestamp,
 		rowTypeExecutionTaskID,
-		request.RunID)
+		runID)
 
-	err := query.Exec()
+	previous := make(map[string]interface{})
+	applied, err := query.MapScanCAS(previous)
 	if err != nil {
 		if isThrottlingError(err) {
-			return &workflow.ServiceBusyError{
+			return nil, &workflow.ServiceBusyError{
 				Message: fmt.Sprintf("DeleteWorkflowCurrentRow operation failed. Error: %v", err),
 			}
 		}
-		return &workflow.InternalServiceError{
-			Message: fmt.Sprintf("DeleteWorkflowCurrentRow operation failed. Error: %v", err),
-		}
+		return nil, d.newExecutionOperationError(
+			"DeleteWorkflowCurrentRow",
+			"templateDeleteWorkflowExecutionCurrentRowQuery",
+			request.DomainID,
+			request.WorkflowID,
+			runID,
+			err,
+		)
 	}
 
-	return nil
+	if applied {
+		return &p.DeleteCurrentWorkflowExecutionResponse{
+			Result:       p.DeleteCurrentWorkflowExecutionResultDeleted,
+			CurrentRunID: runID,
+		}, nil
+	}
+	if currentRunID, ok := previous["current_run_id"].(gocql.UUID); ok {
+		return &p.DeleteCurrentWorkflowExecutionResponse{
+			Result:       p.DeleteCurrentWorkflowExecutionResultMismatched,
+			CurrentRunID: currentRunID.String(),
+		}, nil
+	}
+	return &p.DeleteCurrentWorkflowExecutionResponse{
+		Result: p.DeleteCurrentWorkflowExecutionResultMissing,
+	}, nil
 }
 
 func (d *cassandraPersistence) GetCurrentExecution(request *p.GetCurrentExecutionRequest) (*p.GetCurrentExecutionResponse,
@@ -1740,9 +2192,13 @@ func (d *cassandraPersistence) GetCurrentExecution(request *p.GetCurrentExecutio
 		permanentRunID,
 		defaultVisibilityTimestamp,
 		rowTypeExecutionTaskID)
+	query = withRequestContext(query, request.Context)
 
 	result := make(map[string]interface{})
 	if err := query.MapScan(result); err != nil {
+		if ctxErr := requestContextError(request.Context); ctxErr != nil {
+			return nil, ctxErr
+		}
 		if err == gocql.ErrNotFound {
 			return nil, &workflow.EntityNotExistsError{
 				Message: fmt.Sprintf("Workflow execution not found.  WorkflowId: %v",
@@ -1754,24 +2210,179 @@ func (d *cassandraPersistence) GetCurrentExecution(request *p.GetCurrentExecutio
 			}
 		}
 
-		return nil, &workflow.InternalServiceError{
-			Message: fmt.Sprintf("GetCurrentExecution operation failed. Error: %v", err),
-		}
+		return nil, d.newExecutionOperationError(
+			"GetCurrentExecution",
+			"templateGetCurrentExecutionQuery",
+			request.DomainID,
+			request.WorkflowID,
+			permanentRunID,
+			err,
+		)
 	}
 
 	currentRunID := result["current_run_id"].(gocql.UUID).String()
 	executionInfo := createWorkflowExecutionInfo(result["execution"].(map[string]interface{}))
 	replicationState := createReplicationState(result["replication_state"].(map[string]interface{}))
+	blockedUntil, _ := result["blocked_until"].(time.Time)
 	return &p.GetCurrentExecutionResponse{
 		RunID:            currentRunID,
 		StartRequestID:   executionInfo.CreateRequestID,
 		State:            executionInfo.State,
 		CloseStatus:      executionInfo.CloseStatus,
 		LastWriteVersion: replicationState.LastWriteVersion,
+		BlockedUntil:     blockedUntil,
 	}, nil
 }
 
+func (d *cassandraPersistence) ListWorkflowExecutionRuns(
+	request *p.ListWorkflowExecutionRunsRequest,
+) (*p.ListWorkflowExecutionRunsResponse, error) {
+
+	query := d.readSession.Query(templateListWorkflowExecutionRunsQuery,
+		d.shardID,
+		rowTypeExecution,
+		request.DomainID,
+		request.WorkflowID,
+	).PageSize(request.PageSize).PageState(request.NextPageToken)
+
+	iter := query.Iter()
+	if iter == nil {
+		return nil, &workflow.InternalServiceError{
+			Message: "ListWorkflowExecutionRuns operation failed.  Not able to create query iterator.",
+		}
+	}
+
+	response := &p.ListWorkflowExecutionRunsResponse{}
+	result := make(map[string]interface{})
+	for iter.MapScan(result) {
+		runID := result["run_id"].(gocql.UUID).String()
+		// the current row shares the partition with the runs of the workflow
+		if runID != permanentRunID {
+			executionInfo := createWorkflowExecutionInfo(result["execution"].(map[string]interface{}))
+			response.Runs = append(response.Runs, &p.WorkflowExecutionRunInfo{
+				RunID:               runID,
+				FirstExecutionRunID: executionInfo.FirstExecutionRunID,
+				StartTimestamp:      executionInfo.StartTimestamp,
+				State:               executionInfo.State,
+				CloseStatus:         executionInfo.CloseStatus,
+			})
+		}
+		result = make(map[string]interface{})
+	}
+	nextPageToken := iter.PageState()
+	response.NextPageToken = make([]byte, len(nextPageToken))
+	copy(response.NextPageToken, nextPageToken)
+
+	if err := iter.Close(); err != nil {
+		if isThrottlingError(err) {
+			return nil, &workflow.ServiceBusyError{
+				Message: fmt.Sprintf("ListWorkflowExecutionRuns operation failed. Error: %v", err),
+			}
+		}
+		return nil, &workflow.InternalServiceError{
+			Message: fmt.Sprintf("ListWorkflowExecutionRuns operation failed. Error: %v", err),
+		}
+	}
+
+	return response, nil
+}
+
+func (d *cassandraPersistence) ListCurrentExecutions(
+	request *p.ListCurrentExecutionsRequest,
+) (*p.ListCurrentExecutionsResponse, error) {
+
+	query := d.readSession.Query(templateListCurrentExecutionsQuery,
+		d.shardID,
+		rowTypeExecution,
+	).PageSize(request.PageSize).PageState(request.NextPageToken)
+
+	iter := query.Iter()
+	if iter == nil {
+		return nil, &workflow.InternalServiceError{
+			Message: "ListCurrentExecutions operation failed.  Not able to create query iterator.",
+		}
+	}
+
+	response := &p.ListCurrentExecutionsResponse{}
+	result := make(map[string]interface{})
+	for iter.MapScan(result) {
+		// current rows share the partition with the runs, only the rows with the permanent run ID are kept
+		if result["run_id"].(gocql.UUID).String() == permanentRunID {
+			executionInfo := createWorkflowExecutionInfo(result["execution"].(map[string]interface{}))
+			response.Executions = append(response.Executions, &p.CurrentExecutionInfo{
+				DomainID:    result["domain_id"].(gocql.UUID).String(),
+				WorkflowID:  result["workflow_id"].(string),
+				RunID:       result["current_run_id"].(gocql.UUID).String(),
+				State:       executionInfo.State,
+				CloseStatus: executionInfo.CloseStatus,
+			})
+		}
+		result = make(map[string]interface{})
+	}
+	nextPageToken := iter.PageState()
+	response.NextPageToken = make([]byte, len(nextPageToken))
+	copy(response.NextPageToken, nextPageToken)
+
+	if err := iter.Close(); err != nil {
+		if isThrottlingError(err) {
+			return nil, &workflow.ServiceBusyError{
+				Message: fmt.Sprintf("ListCurrentExecutions operation failed. Error: %v", err),
+			}
+		}
+		return nil, &workflow.InternalServiceError{
+			Message: fmt.Sprintf("ListCurrentExecutions operation failed. Error: %v", err),
+		}
+	}
+
+	return response, nil
+}
+
+func (d *cassandraPersistence) BlockCurrentWorkflowExecution(
+	request *p.BlockCurrentWorkflowExecutionRequest,
+) error {
+
+	query := d.session.Query(templateBlockCurrentWorkflowExecutionQuery,
+		request.BlockedUntil,
+		d.shardID,
+		rowTypeExecution,
+		request.DomainID,
+		request.WorkflowID,
+		permanentRunID,
+		defaultVisibilityTimestamp,
+		rowTypeExecutionTaskID,
+		request.RunID)
+
+	previous := make(map[string]interface{})
+	applied, err := query.MapScanCAS(previous)
+	if err != nil {
+		if isThrottlingError(err) {
+			return &workflow.ServiceBusyError{
+				Message: fmt.Sprintf("BlockCurrentWorkflowExecution operation failed. Error: %v", err),
+			}
+		}
+		return d.newExecutionOperationError(
+			"BlockCurrentWorkflowExecution",
+			"templateBlockCurrentWorkflowExecutionQuery",
+			request.DomainID,
+			request.WorkflowID,
+			request.RunID,
+			err,
+		)
+	}
+
+	if !applied {
+		return &p.ConditionFailedError{
+			Msg: fmt.Sprintf("BlockCurrentWorkflowExecution operation failed. Current run ID mismatch, expected: %v, actual: %v",
+				request.RunID, previous["current_run_id"]),
+		}
+	}
+	return nil
+}
+
 func (d *cassandraPersistence) GetTransferTasks(request *p.GetTransferTasksRequest) (*p.GetTransferTasksResponse, error) {
+	if d.transferTaskBuckets > 0 {
+		return d.getBucketedTransferTasks(request)
+	}
 
 	// Reading transfer tasks need to be quorum level consistent, otherwise we could loose task
 	query := d.session.Query(templateGetTransferTasksQuery,
@@ -1814,6 +2425,107 @@ func (d *cassandraPersistence) GetTransferTasks(request *p.GetTransferTasksReque
 	return response, nil
 }
 
+// getBucketedTransferTasks reads a page from the shard partition of the executions table and from every
+// transfer_tasks bucket, and merges them by task ID. The page token is the ID of the last returned task.
+func (d *cassandraPersistence) getBucketedTransferTasks(
+	request *p.GetTransferTasksRequest,
+) (*p.GetTransferTasksResponse, error) {
+
+	readLevel := request.ReadLevel
+	if len(request.NextPageToken) > 0 {
+		lastTaskID, err := deserializePageToken(request.NextPageToken)
+		if err != nil {
+			return nil, &workflow.InternalServiceError{
+				Message: fmt.Sprintf("GetTransferTasks operation failed. Error: %v", err),
+			}
+		}
+		readLevel = lastTaskID
+	}
+
+	// tasks created before bucketing was enabled, and tasks not moved to their bucket yet, are in the
+	// executions table
+	queries := []*gocql.Query{d.session.Query(templateGetTransferTasksWithLimitQuery,
+		d.shardID,
+		rowTypeTransferTask,
+		rowTypeTransferDomainID,
+		rowTypeTransferWorkflowID,
+		rowTypeTransferRunID,
+		defaultVisibilityTimestamp,
+		readLevel,
+		request.MaxReadLevel,
+		request.BatchSize,
+	)}
+	for bucket := 0; bucket < d.transferTaskBuckets; bucket++ {
+		queries = append(queries, d.session.Query(templateGetBucketedTransferTasksQuery,
+			d.shardID,
+			bucket,
+			readLevel,
+			request.MaxReadLevel,
+			request.BatchSize,
+		))
+	}
+
+	var tasks []*p.TransferTaskInfo
+	seen := make(map[int64]struct{})
+	hasMore := false
+	for _, query := range queries {
+		partitionTasks, err := readTransferTasks(query)
+		if err != nil {
+			return nil, err
+		}
+		// a full partition page may hide more tasks, which all sort after the last task of this page
+		if len(partitionTasks) >= request.BatchSize {
+			hasMore = true
+		}
+		// a task being moved to its bucket can be read from both its staging row and its bucket
+		for _, task := range partitionTasks {
+			if _, ok := seen[task.TaskID]; ok {
+				continue
+			}
+			seen[task.TaskID] = struct{}{}
+			tasks = append(tasks, task)
+		}
+	}
+
+	sort.Slice(tasks, func(i, j int) bool {
+		return tasks[i].TaskID < tasks[j].TaskID
+	})
+	if len(tasks) > request.BatchSize {
+		tasks = tasks[:request.BatchSize]
+		hasMore = true
+	}
+
+	response := &p.GetTransferTasksResponse{Tasks: tasks}
+	if hasMore && len(tasks) > 0 {
+		response.NextPageToken = serializePageToken(tasks[len(tasks)-1].TaskID)
+	}
+	return response, nil
+}
+
+func readTransferTasks(query *gocql.Query) ([]*p.TransferTaskInfo, error) {
+	iter := query.Iter()
+	if iter == nil {
+		return nil, &workflow.InternalServiceError{
+			Message: "GetTransferTasks operation failed.  Not able to create query iterator.",
+		}
+	}
+
+	var tasks []*p.TransferTaskInfo
+	task := make(map[string]interface{})
+	for iter.MapScan(task) {
+		tasks = append(tasks, createTransferTaskInfo(task["transfer"].(map[string]interface{})))
+		// Reset task map to get it ready for next scan
+		task = make(map[string]interface{})
+	}
+
+	if err := iter.Close(); err != nil {
+		return nil, &workflow.InternalServiceError{
+			Message: fmt.Sprintf("GetTransferTasks operation failed. Error: %v", err),
+		}
+	}
+	return tasks, nil
+}
+
 func (d *cassandraPersistence) GetReplicationTasks(request *p.GetReplicationTasksRequest) (*p.GetReplicationTasksResponse,
 	error) {
 
@@ -1859,7 +2571,10 @@ func (d *cassandraPersistence) GetReplicationTasks(request *p.GetReplicationTask
 }
 
 func (d *cassandraPersistence) CompleteTransferTask(request *p.CompleteTransferTaskRequest) error {
-	query := d.session.Query(templateCompleteTransferTaskQuery,
+	// the task is in the executions table unless it was moved to its bucket, the staged row is deleted
+	// along with the bucket row since the task can be processed before it is moved
+	batch := d.session.NewBatch(gocql.UnloggedBatch)
+	batch.Query(templateCompleteTransferTaskQuery,
 		d.shardID,
 		rowTypeTransferTask,
 		rowTypeTransferDomainID,
@@ -1867,8 +2582,14 @@ func (d *cassandraPersistence) CompleteTransferTask(request *p.CompleteTransferT
 		rowTypeTransferRunID,
 		defaultVisibilityTimestamp,
 		request.TaskID)
+	if d.transferTaskBuckets > 0 {
+		batch.Query(templateCompleteBucketedTransferTaskQuery,
+			d.shardID,
+			transferTaskBucket(request.TaskID, d.transferTaskBuckets),
+			request.TaskID)
+	}
 
-	err := query.Exec()
+	err := d.session.ExecuteBatch(batch)
 	if err != nil {
 		if isThrottlingError(err) {
 			return &workflow.ServiceBusyError{
@@ -1884,7 +2605,8 @@ func (d *cassandraPersistence) CompleteTransferTask(request *p.CompleteTransferT
 }
 
 func (d *cassandraPersistence) RangeCompleteTransferTask(request *p.RangeCompleteTransferTaskRequest) error {
-	query := d.session.Query(templateRangeCompleteTransferTaskQuery,
+	batch := d.session.NewBatch(gocql.UnloggedBatch)
+	batch.Query(templateRangeCompleteTransferTaskQuery,
 		d.shardID,
 		rowTypeTransferTask,
 		rowTypeTransferDomainID,
@@ -1894,8 +2616,16 @@ func (d *cassandraPersistence) RangeCompleteTransferTask(request *p.RangeComplet
 		request.ExclusiveBeginTaskID,
 		request.InclusiveEndTaskID,
 	)
+	for bucket := 0; bucket < d.transferTaskBuckets; bucket++ {
+		batch.Query(templateRangeCompleteBucketedTransferTaskQuery,
+			d.shardID,
+			bucket,
+			request.ExclusiveBeginTaskID,
+			request.InclusiveEndTaskID,
+		)
+	}
 
-	err := query.Exec()
+	err := d.session.ExecuteBatch(batch)
 	if err != nil {
 		if isThrottlingError(err) {
 			return &workflow.ServiceBusyError{
@@ -1910,6 +2640,101 @@ func (d *cassandraPersistence) RangeCompleteTransferTask(request *p.RangeComplet
 	return nil
 }
 
+func (d *cassandraPersistence) GetVisibilityTasks(request *p.GetVisibilityTasksRequest) (*p.GetVisibilityTasksResponse, error) {
+
+	// Reading visibility tasks need to be quorum level consistent, otherwise we could loose task
+	query := d.session.Query(templateGetVisibilityTasksQuery,
+		d.shardID,
+		rowTypeVisibilityTask,
+		rowTypeVisibilityDomainID,
+		rowTypeVisibilityWorkflowID,
+		rowTypeVisibilityRunID,
+		defaultVisibilityTimestamp,
+		request.ReadLevel,
+		request.MaxReadLevel,
+	).PageSize(request.BatchSize).PageState(request.NextPageToken)
+
+	iter := query.Iter()
+	if iter == nil {
+		return nil, &workflow.InternalServiceError{
+			Message: "GetVisibilityTasks operation failed.  Not able to create query iterator.",
+		}
+	}
+
+	response := &p.GetVisibilityTasksResponse{}
+	task := make(map[string]interface{})
+	for iter.MapScan(task) {
+		t := createVisibilityTaskInfo(task["visibility"].(map[string]interface{}))
+		// Reset task map to get it ready for next scan
+		task = make(map[string]interface{})
+
+		response.Tasks = append(response.Tasks, t)
+	}
+	nextPageToken := iter.PageState()
+	response.NextPageToken = make([]byte, len(nextPageToken))
+	copy(response.NextPageToken, nextPageToken)
+
+	if err := iter.Close(); err != nil {
+		return nil, &workflow.InternalServiceError{
+			Message: fmt.Sprintf("GetVisibilityTasks operation failed. Error: %v", err),
+		}
+	}
+
+	return response, nil
+}
+
+func (d *cassandraPersistence) CompleteVisibilityTask(request *p.CompleteVisibilityTaskRequest) error {
+	query := d.session.Query(templateCompleteTransferTaskQuery,
+		d.shardID,
+		rowTypeVisibilityTask,
+		rowTypeVisibilityDomainID,
+		rowTypeVisibilityWorkflowID,
+		rowTypeVisibilityRunID,
+		defaultVisibilityTimestamp,
+		request.TaskID)
+
+	err := query.Exec()
+	if err != nil {
+		if isThrottlingError(err) {
+			return &workflow.ServiceBusyError{
+				Message: fmt.Sprintf("CompleteVisibilityTask operation failed. Error: %v", err),
+			}
+		}
+		return &workflow.InternalServiceError{
+			Message: fmt.Sprintf("CompleteVisibilityTask operation failed. Error: %v", err),
+		}
+	}
+
+	return nil
+}
+
+func (d *cassandraPersistence) RangeCompleteVisibilityTask(request *p.RangeCompleteVisibilityTaskRequest) error {
+	query := d.session.Query(templateRangeCompleteTransferTaskQuery,
+		d.shardID,
+		rowTypeVisibilityTask,
+		rowTypeVisibilityDomainID,
+		rowTypeVisibilityWorkflowID,
+		rowTypeVisibilityRunID,
+		defaultVisibilityTimestamp,
+		request.ExclusiveBeginTaskID,
+		request.InclusiveEndTaskID,
+	)
+
+	err := query.Exec()
+	if err != nil {
+		if isThrottlingError(err) {
+			return &workflow.ServiceBusyError{
+				Message: fmt.Sprintf("RangeCompleteVisibilityTask operation failed. Error: %v", err),
+			}
+		}
+		return &workflow.InternalServiceError{
+			Message: fmt.Sprintf("RangeCompleteVisibilityTask operation failed. Error: %v", err),
+		}
+	}
+
+	return nil
+}
+
 func (d *cassandraPersistence) CompleteReplicationTask(request *p.CompleteReplicationTaskRequest) error {
 	query := d.session.Query(templateCompleteTransferTaskQuery,
 		d.shardID,
@@ -1935,6 +2760,31 @@ func (d *cassandraPersistence) CompleteReplicationTask(request *p.CompleteReplic
 	return nil
 }
 
+func (d *cassandraPersistence) RangeCompleteReplicationTask(request *p.RangeCompleteReplicationTaskRequest) error {
+	query := d.session.Query(templateRangeCompleteReplicationTaskQuery,
+		d.shardID,
+		rowTypeReplicationTask,
+		rowTypeReplicationDomainID,
+		rowTypeReplicationWorkflowID,
+		rowTypeReplicationRunID,
+		defaultVisibilityTimestamp,
+		request.InclusiveEndTaskID)
+
+	err := query.Exec()
+	if err != nil {
+		if isThrottlingError(err) {
+			return &workflow.ServiceBusyError{
+				Message: fmt.Sprintf("RangeCompleteReplicationTask operation failed. Error: %v", err),
+			}
+		}
+		return &workflow.InternalServiceError{
+			Message: fmt.Sprintf("RangeCompleteReplicationTask operation failed. Error: %v", err),
+		}
+	}
+
+	return nil
+}
+
 func (d *cassandraPersistence) CompleteTimerTask(request *p.CompleteTimerTaskRequest) error {
 	ts := p.UnixNanoToDBTimestamp(request.VisibilityTimestamp.UnixNano())
 	query := d.session.Query(templateCompleteTimerTaskQuery,
@@ -1989,6 +2839,165 @@ func (d *cassandraPersistence) RangeCompleteTimerTask(request *p.RangeCompleteTi
 	return nil
 }
 
+func (d *cassandraPersistence) GetClosedExecutionIndex(
+	request *p.GetClosedExecutionIndexRequest,
+) (*p.GetClosedExecutionIndexResponse, error) {
+
+	var query *gocql.Query
+	if request.MinCloseTime.IsZero() {
+		query = d.readSession.Query(templateGetClosedExecutionIndexQuery,
+			d.shardID,
+			closedExecutionIndexDay(request.Day),
+		)
+	} else {
+		query = d.readSession.Query(templateGetClosedExecutionIndexSinceQuery,
+			d.shardID,
+			closedExecutionIndexDay(request.Day),
+			request.MinCloseTime,
+		)
+	}
+	query = query.PageSize(request.BatchSize).PageState(request.NextPageToken)
+
+	iter := query.Iter()
+	if iter == nil {
+		return nil, &workflow.InternalServiceError{
+			Message: "GetClosedExecutionIndex operation failed.  Not able to create query iterator.",
+		}
+	}
+
+	response := &p.GetClosedExecutionIndexResponse{}
+	var closeTime time.Time
+	var domainID, runID gocql.UUID
+	var workflowID string
+	var version int64
+	for iter.Scan(&closeTime, &domainID, &workflowID, &runID, &version) {
+		response.Executions = append(response.Executions, &p.ClosedExecutionIndexInfo{
+			DomainID:   domainID.String(),
+			WorkflowID: workflowID,
+			RunID:      runID.String(),
+			CloseTime:  closeTime,
+			Version:    version,
+		})
+	}
+	nextPageToken := iter.PageState()
+	response.NextPageToken = make([]byte, len(nextPageToken))
+	copy(response.NextPageToken, nextPageToken)
+
+	if err := iter.Close(); err != nil {
+		if isThrottlingError(err) {
+			return nil, &workflow.ServiceBusyError{
+				Message: fmt.Sprintf("GetClosedExecutionIndex operation failed. Error: %v", err),
+			}
+		}
+		return nil, &workflow.InternalServiceError{
+			Message: fmt.Sprintf("GetClosedExecutionIndex operation failed. Error: %v", err),
+		}
+	}
+
+	return response, nil
+}
+
+func (d *cassandraPersistence) DeleteClosedExecutionIndex(request *p.DeleteClosedExecutionIndexRequest) error {
+	query := d.session.Query(templateDeleteClosedExecutionIndexQuery,
+		d.shardID,
+		closedExecutionIndexDay(request.CloseTime),
+		request.CloseTime,
+		request.DomainID,
+		request.WorkflowID,
+		request.RunID,
+	)
+
+	err := query.Exec()
+	if err != nil {
+		if isThrottlingError(err) {
+			return &workflow.ServiceBusyError{
+				Message: fmt.Sprintf("DeleteClosedExecutionIndex operation failed. Error: %v", err),
+			}
+		}
+		return &workflow.InternalServiceError{
+			Message: fmt.Sprintf("DeleteClosedExecutionIndex operation failed. Error: %v", err),
+		}
+	}
+
+	return nil
+}
+
+func (d *cassandraPersistence) ListBufferedReplicationTasks(
+	request *p.ListBufferedReplicationTasksRequest,
+) (*p.ListBufferedReplicationTasksResponse, error) {
+
+	query := d.session.Query(templateListBufferedReplicationTasksQuery,
+		d.shardID,
+		rowTypeExecution,
+	).PageSize(request.PageSize).PageState(request.NextPageToken)
+
+	iter := query.Iter()
+	if iter == nil {
+		return nil, &workflow.InternalServiceError{
+			Message: "ListBufferedReplicationTasks operation failed.  Not able to create query iterator.",
+		}
+	}
+
+	response := &p.ListBufferedReplicationTasksResponse{}
+	var domainID, runID gocql.UUID
+	var workflowID string
+	var tasks map[int64]map[string]interface{}
+	for iter.Scan(&domainID, &workflowID, &runID, &tasks) {
+		// the current row of a workflow never has buffered replication tasks
+		if len(tasks) > 0 && runID.String() != permanentRunID {
+			response.Executions = append(response.Executions, &p.BufferedReplicationTasksInfo{
+				DomainID:   domainID.String(),
+				WorkflowID: workflowID,
+				RunID:      runID.String(),
+				TaskCount:  len(tasks),
+			})
+		}
+		tasks = nil
+	}
+	nextPageToken := iter.PageState()
+	response.NextPageToken = make([]byte, len(nextPageToken))
+	copy(response.NextPageToken, nextPageToken)
+
+	if err := iter.Close(); err != nil {
+		if isThrottlingError(err) {
+			return nil, &workflow.ServiceBusyError{
+				Message: fmt.Sprintf("ListBufferedReplicationTasks operation failed. Error: %v", err),
+			}
+		}
+		return nil, &workflow.InternalServiceError{
+			Message: fmt.Sprintf("ListBufferedReplicationTasks operation failed. Error: %v", err),
+		}
+	}
+
+	return response, nil
+}
+
+func (d *cassandraPersistence) DeleteBufferedReplicationTasks(request *p.DeleteBufferedReplicationTasksRequest) error {
+	query := d.session.Query(templateDeleteBufferedReplicationTasksQuery,
+		d.shardID,
+		rowTypeExecution,
+		request.DomainID,
+		request.WorkflowID,
+		request.RunID,
+		defaultVisibilityTimestamp,
+		rowTypeExecutionTaskID,
+	)
+
+	err := query.Exec()
+	if err != nil {
+		if isThrottlingError(err) {
+			return &workflow.ServiceBusyError{
+				Message: fmt.Sprintf("DeleteBufferedReplicationTasks operation failed. Error: %v", err),
+			}
+		}
+		return &workflow.InternalServiceError{
+			Message: fmt.Sprintf("DeleteBufferedReplicationTasks operation failed. Error: %v", err),
+		}
+	}
+
+	return nil
+}
+
 // From TaskManager interface
 func (d *cassandraPersistence) LeaseTaskList(request *p.LeaseTaskListRequest) (*p.LeaseTaskListResponse, error) {
 	if len(request.TaskList) == 0 {
@@ -2005,6 +3014,7 @@ func (d *cassandraPersistence) LeaseTaskList(request *p.LeaseTaskListRequest) (*
 		taskListTaskID,
 	)
 	var rangeID, ackLevel int64
+	var versionSets [][]string
 	var tlDB map[string]interface{}
 	err := query.Scan(&rangeID, &tlDB)
 	if err != nil {
@@ -2022,6 +3032,7 @@ func (d *cassandraPersistence) LeaseTaskList(request *p.LeaseTaskListRequest) (*
 				0,
 				request.TaskListKind,
 				now,
+				nil,
 			)
 		} else if isThrottlingError(err) {
 			return nil, &workflow.ServiceBusyError{
@@ -2046,6 +3057,7 @@ func (d *cassandraPersistence) LeaseTaskList(request *p.LeaseTaskListRequest) (*
 		}
 		ackLevel = tlDB["ack_level"].(int64)
 		taskListKind := tlDB["kind"].(int)
+		versionSets, _ = tlDB["version_sets"].([][]string)
 		query = d.session.Query(templateUpdateTaskListQuery,
 			rangeID+1,
 			request.DomainID,
@@ -2054,6 +3066,7 @@ func (d *cassandraPersistence) LeaseTaskList(request *p.LeaseTaskListRequest) (*
 			ackLevel,
 			taskListKind,
 			now,
+			versionSets,
 			request.DomainID,
 			&request.TaskList,
 			request.TaskType,
@@ -2089,6 +3102,7 @@ func (d *cassandraPersistence) LeaseTaskList(request *p.LeaseTaskListRequest) (*
 		AckLevel:    ackLevel,
 		Kind:        request.TaskListKind,
 		LastUpdated: now,
+		VersionSets: versionSets,
 	}
 	return &p.LeaseTaskListResponse{TaskListInfo: tli}, nil
 }
@@ -2111,6 +3125,7 @@ func (d *cassandraPersistence) UpdateTaskList(request *p.UpdateTaskListRequest)
 			tli.AckLevel,
 			tli.Kind,
 			time.Now(),
+			tli.VersionSets,
 			stickyTaskListTTL,
 		)
 		err := query.Exec()
@@ -2135,6 +3150,7 @@ func (d *cassandraPersistence) UpdateTaskList(request *p.UpdateTaskListRequest)
 		tli.AckLevel,
 		tli.Kind,
 		time.Now(),
+		tli.VersionSets,
 		tli.DomainID,
 		&tli.Name,
 		tli.TaskType,
@@ -2253,6 +3269,7 @@ func (d *cassandraPersistence) CreateTasks(request *p.CreateTasksRequest) (*p.Cr
 		ackLevel,
 		taskListKind,
 		time.Now(),
+		request.TaskListInfo.VersionSets,
 		domainID,
 		taskList,
 		taskListType,
@@ -2304,6 +3321,7 @@ func (d *cassandraPersistence) GetTasks(request *p.GetTasksRequest) (*p.GetTasks
 		request.ReadLevel,
 		*request.MaxReadLevel,
 	).PageSize(request.BatchSize)
+	query = withRequestContext(query, request.Context)
 
 	iter := query.Iter()
 	if iter == nil {
@@ -2330,6 +3348,9 @@ PopulateTasks:
 	}
 
 	if err := iter.Close(); err != nil {
+		if ctxErr := requestContextError(request.Context); ctxErr != nil {
+			return nil, ctxErr
+		}
 		return nil, &workflow.InternalServiceError{
 			Message: fmt.Sprintf("GetTasks operation failed. Error: %v", err),
 		}
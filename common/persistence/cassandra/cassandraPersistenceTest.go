@@ -21,6 +21,8 @@
 package cassandra
 
 import (
+	"fmt"
+	"io/ioutil"
 	"os"
 	"strings"
 	"time"
@@ -46,6 +48,8 @@ type TestCluster struct {
 	cluster   *gocql.ClusterConfig
 	session   *gocql.Session
 	cfg       config.Cassandra
+
+	readClosedExecutionsByFilter bool
 }
 
 // NewTestCluster returns a new cassandra test cluster
@@ -70,16 +74,45 @@ func NewTestCluster(keyspace string, port int, schemaDir string) *TestCluster {
 	return &result
 }
 
+// SetTransferTaskBuckets sets the number of transfer task buckets of the execution stores
+func (s *TestCluster) SetTransferTaskBuckets(buckets int) {
+	s.cfg.TransferTaskBuckets = buckets
+}
+
+// SetOpenExecutionBuckets sets the number of open execution buckets of the visibility store,
+// a positive value also lists open executions from the bucketed table
+func (s *TestCluster) SetOpenExecutionBuckets(buckets int) {
+	s.cfg.OpenExecutionBuckets = buckets
+}
+
+// SetReadClosedExecutionsByFilter sets whether the visibility store lists filtered closed executions
+// from the tables partitioned by type, status and workflow ID
+func (s *TestCluster) SetReadClosedExecutionsByFilter(enabled bool) {
+	s.readClosedExecutionsByFilter = enabled
+}
+
 // Config returns the persistence config for connecting to this test cluster
 func (s *TestCluster) Config() config.Persistence {
 	cfg := s.cfg
+	var visibilityConfig *config.VisibilityConfig
+	if cfg.OpenExecutionBuckets > 0 || s.readClosedExecutionsByFilter {
+		visibilityConfig = &config.VisibilityConfig{
+			EnableSampling:                         dynamicconfig.GetBoolPropertyFn(false),
+			EnableReadFromClosedExecutionV2:        dynamicconfig.GetBoolPropertyFn(false),
+			EnableReadFromOpenExecutionsByDomain:   dynamicconfig.GetBoolPropertyFn(cfg.OpenExecutionBuckets > 0),
+			EnableReadFromClosedExecutionsByFilter: dynamicconfig.GetBoolPropertyFn(s.readClosedExecutionsByFilter),
+		}
+	}
 	return config.Persistence{
 		DefaultStore:    "test",
 		VisibilityStore: "test",
 		DataStores: map[string]config.DataStore{
 			"test": {Cassandra: &cfg},
 		},
-		TransactionSizeLimit: dynamicconfig.GetIntPropertyFn(common.DefaultTransactionSizeLimit),
+		TransactionSizeLimit:               dynamicconfig.GetIntPropertyFn(common.DefaultTransactionSizeLimit),
+		ExecutionTransactionStatementLimit: dynamicconfig.GetIntPropertyFn(common.DefaultExecutionTransactionStatementLimit),
+		ExecutionTransactionSizeLimit:      dynamicconfig.GetIntPropertyFn(common.DefaultExecutionTransactionSizeLimit),
+		VisibilityConfig:                   visibilityConfig,
 	}
 }
 
@@ -114,7 +147,7 @@ func (s *TestCluster) TearDownTestDatabase() {
 
 // CreateSession from PersistenceTestCluster interface
 func (s *TestCluster) CreateSession() {
-	s.cluster = NewCassandraCluster(s.cfg.Hosts, s.cfg.Port, testUser, testPassword, "")
+	s.cluster = NewCassandraCluster(s.cfg.Hosts, s.cfg.Port, testUser, testPassword, "", "")
 	s.cluster.Consistency = gocql.Consistency(1)
 	s.cluster.Keyspace = "system"
 	s.cluster.Timeout = 40 * time.Second
@@ -146,7 +179,12 @@ func (s *TestCluster) DropDatabase() {
 // LoadSchema from PersistenceTestCluster interface
 func (s *TestCluster) LoadSchema(fileNames []string, schemaDir string) {
 	workflowSchemaDir := schemaDir + "/cadence"
-	err := LoadCassandraSchema(workflowSchemaDir, fileNames, s.cluster.Hosts, s.cluster.Port, s.DatabaseName(), true)
+	// the execution store refuses to start without the schema version of the keyspace
+	version, err := latestVersionedSchema(workflowSchemaDir + "/versioned")
+	if err != nil {
+		log.Fatal(err)
+	}
+	err = LoadCassandraSchema(workflowSchemaDir, fileNames, s.cluster.Hosts, s.cluster.Port, s.DatabaseName(), true, version)
 	if err != nil && !strings.Contains(err.Error(), "AlreadyExists") {
 		log.Fatal(err)
 	}
@@ -155,12 +193,39 @@ func (s *TestCluster) LoadSchema(fileNames []string, schemaDir string) {
 // LoadVisibilitySchema from PersistenceTestCluster interface
 func (s *TestCluster) LoadVisibilitySchema(fileNames []string, schemaDir string) {
 	workflowSchemaDir := schemaDir + "visibility"
-	err := LoadCassandraSchema(workflowSchemaDir, fileNames, s.cluster.Hosts, s.cluster.Port, s.DatabaseName(), false)
+	err := LoadCassandraSchema(workflowSchemaDir, fileNames, s.cluster.Hosts, s.cluster.Port, s.DatabaseName(), false, "")
 	if err != nil && !strings.Contains(err.Error(), "AlreadyExists") {
 		log.Fatal(err)
 	}
 }
 
+// latestVersionedSchema returns the newest schema version of the versioned schema dir, e.g. 0.49
+func latestVersionedSchema(dir string) (string, error) {
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return "", err
+	}
+	latest := ""
+	var latestVersion schemaVersion
+	for _, file := range files {
+		if !file.IsDir() || !strings.HasPrefix(file.Name(), "v") {
+			continue
+		}
+		version, err := parseSchemaVersion(strings.TrimPrefix(file.Name(), "v"))
+		if err != nil {
+			return "", err
+		}
+		if latest == "" || latestVersion.before(version) {
+			latest = strings.TrimPrefix(file.Name(), "v")
+			latestVersion = version
+		}
+	}
+	if latest == "" {
+		return "", fmt.Errorf("no versioned schema under %v", dir)
+	}
+	return latest, nil
+}
+
 func getCadencePackageDir() (string, error) {
 	cadencePackageDir, err := os.Getwd()
 	if err != nil {
@@ -21,6 +21,8 @@
 package cassandra
 
 import (
+	"context"
+	"encoding/binary"
 	"fmt"
 	"time"
 
@@ -32,7 +34,9 @@ import (
 
 func applyWorkflowMutationBatch(
 	batch *gocql.Batch,
+	crossBatch *crossPartitionBatch,
 	shardID int,
+	rowMapper *executionRowMapper,
 	workflowMutation *p.InternalWorkflowMutation,
 ) error {
 
@@ -48,6 +52,7 @@ func applyWorkflowMutationBatch(
 	if err := updateExecution(
 		batch,
 		shardID,
+		rowMapper,
 		executionInfo,
 		replicationState,
 		cqlNowTimestampMillis,
@@ -113,7 +118,17 @@ func applyWorkflowMutationBatch(
 	updateSignalsRequested(
 		batch,
 		workflowMutation.UpsertSignalRequestedIDs,
-		workflowMutation.DeleteSignalRequestedID,
+		workflowMutation.DeleteSignalRequestedIDs,
+		shardID,
+		domainID,
+		workflowID,
+		runID,
+	)
+
+	updateUpdateInfos(
+		batch,
+		workflowMutation.UpsertUpdateInfos,
+		workflowMutation.DeleteUpdateInfo,
 		shardID,
 		domainID,
 		workflowID,
@@ -130,9 +145,17 @@ func applyWorkflowMutationBatch(
 		runID,
 	)
 
-	// transfer / replication / timer tasks
+	createClosedExecutionIndex(
+		crossBatch,
+		shardID,
+		executionInfo,
+		workflowMutation.TransferTasks,
+	)
+
+	// transfer / replication / timer / visibility tasks
 	return applyTasks(
 		batch,
+		crossBatch,
 		shardID,
 		domainID,
 		workflowID,
@@ -140,12 +163,15 @@ func applyWorkflowMutationBatch(
 		workflowMutation.TransferTasks,
 		workflowMutation.ReplicationTasks,
 		workflowMutation.TimerTasks,
+		workflowMutation.VisibilityTasks,
 	)
 }
 
 func applyWorkflowSnapshotBatchAsReset(
 	batch *gocql.Batch,
+	crossBatch *crossPartitionBatch,
 	shardID int,
+	rowMapper *executionRowMapper,
 	workflowSnapshot *p.InternalWorkflowSnapshot,
 ) error {
 
@@ -161,6 +187,7 @@ func applyWorkflowSnapshotBatchAsReset(
 	if err := updateExecution(
 		batch,
 		shardID,
+		rowMapper,
 		executionInfo,
 		replicationState,
 		cqlNowTimestampMillis,
@@ -227,6 +254,15 @@ func applyWorkflowSnapshotBatchAsReset(
 		runID,
 	)
 
+	resetUpdateInfos(
+		batch,
+		workflowSnapshot.UpdateInfos,
+		shardID,
+		domainID,
+		workflowID,
+		runID,
+	)
+
 	deleteBufferedEvents(
 		batch,
 		shardID,
@@ -235,9 +271,17 @@ func applyWorkflowSnapshotBatchAsReset(
 		runID,
 	)
 
-	// transfer / replication / timer tasks
+	createClosedExecutionIndex(
+		crossBatch,
+		shardID,
+		executionInfo,
+		workflowSnapshot.TransferTasks,
+	)
+
+	// transfer / replication / timer / visibility tasks
 	return applyTasks(
 		batch,
+		crossBatch,
 		shardID,
 		domainID,
 		workflowID,
@@ -245,12 +289,15 @@ func applyWorkflowSnapshotBatchAsReset(
 		workflowSnapshot.TransferTasks,
 		workflowSnapshot.ReplicationTasks,
 		workflowSnapshot.TimerTasks,
+		workflowSnapshot.VisibilityTasks,
 	)
 }
 
 func applyWorkflowSnapshotBatchAsNew(
 	batch *gocql.Batch,
+	crossBatch *crossPartitionBatch,
 	shardID int,
+	rowMapper *executionRowMapper,
 	workflowSnapshot *p.InternalWorkflowSnapshot,
 ) error {
 
@@ -265,6 +312,7 @@ func applyWorkflowSnapshotBatchAsNew(
 	if err := createExecution(
 		batch,
 		shardID,
+		rowMapper,
 		executionInfo,
 		replicationState,
 		cqlNowTimestampMillis,
@@ -336,9 +384,27 @@ func applyWorkflowSnapshotBatchAsNew(
 		runID,
 	)
 
-	// transfer / replication / timer tasks
+	updateUpdateInfos(
+		batch,
+		workflowSnapshot.UpdateInfos,
+		nil,
+		shardID,
+		domainID,
+		workflowID,
+		runID,
+	)
+
+	createClosedExecutionIndex(
+		crossBatch,
+		shardID,
+		executionInfo,
+		workflowSnapshot.TransferTasks,
+	)
+
+	// transfer / replication / timer / visibility tasks
 	return applyTasks(
 		batch,
+		crossBatch,
 		shardID,
 		domainID,
 		workflowID,
@@ -346,12 +412,14 @@ func applyWorkflowSnapshotBatchAsNew(
 		workflowSnapshot.TransferTasks,
 		workflowSnapshot.ReplicationTasks,
 		workflowSnapshot.TimerTasks,
+		workflowSnapshot.VisibilityTasks,
 	)
 }
 
 func createExecution(
 	batch *gocql.Batch,
 	shardID int,
+	rowMapper *executionRowMapper,
 	executionInfo *p.InternalWorkflowExecutionInfo,
 	replicationState *p.ReplicationState,
 	cqlNowTimestampMillis int64,
@@ -368,172 +436,27 @@ func createExecution(
 	workflowID := executionInfo.WorkflowID
 	runID := executionInfo.RunID
 
-	parentDomainID := emptyDomainID
-	parentWorkflowID := ""
-	parentRunID := emptyRunID
-	initiatedID := emptyInitiatedID
-	if executionInfo.ParentDomainID != "" {
-		parentDomainID = executionInfo.ParentDomainID
-		parentWorkflowID = executionInfo.ParentWorkflowID
-		parentRunID = executionInfo.ParentRunID
-		initiatedID = executionInfo.InitiatedID
-	}
-
 	// TODO we should set the start time and last update time on business logic layer
 	executionInfo.StartTimestamp = time.Unix(0, p.DBTimestampToUnixNano(cqlNowTimestampMillis))
 	executionInfo.LastUpdatedTimestamp = time.Unix(0, p.DBTimestampToUnixNano(cqlNowTimestampMillis))
 
-	completionData, completionEncoding := p.FromDataBlob(executionInfo.CompletionEvent)
-	if replicationState == nil {
-		// Cross DC feature is currently disabled so we will be creating workflow executions without replication state
-		batch.Query(templateCreateWorkflowExecutionQuery,
-			shardID,
-			domainID,
-			workflowID,
-			runID,
-			rowTypeExecution,
-			domainID,
-			workflowID,
-			runID,
-			parentDomainID,
-			parentWorkflowID,
-			parentRunID,
-			initiatedID,
-			executionInfo.CompletionEventBatchID,
-			completionData,
-			completionEncoding,
-			executionInfo.TaskList,
-			executionInfo.WorkflowTypeName,
-			executionInfo.WorkflowTimeout,
-			executionInfo.DecisionTimeoutValue,
-			executionInfo.ExecutionContext,
-			executionInfo.State,
-			executionInfo.CloseStatus,
-			executionInfo.LastFirstEventID,
-			executionInfo.LastEventTaskID,
-			executionInfo.NextEventID,
-			executionInfo.LastProcessedEvent,
-			executionInfo.StartTimestamp,
-			executionInfo.LastUpdatedTimestamp,
-			executionInfo.CreateRequestID,
-			executionInfo.SignalCount,
-			executionInfo.HistorySize,
-			executionInfo.DecisionVersion,
-			executionInfo.DecisionScheduleID,
-			executionInfo.DecisionStartedID,
-			executionInfo.DecisionRequestID,
-			executionInfo.DecisionTimeout,
-			executionInfo.DecisionAttempt,
-			executionInfo.DecisionStartedTimestamp,
-			executionInfo.DecisionScheduledTimestamp,
-			executionInfo.CancelRequested,
-			executionInfo.CancelRequestID,
-			executionInfo.StickyTaskList,
-			executionInfo.StickyScheduleToStartTimeout,
-			executionInfo.ClientLibraryVersion,
-			executionInfo.ClientFeatureVersion,
-			executionInfo.ClientImpl,
-			executionInfo.AutoResetPoints.Data,
-			executionInfo.AutoResetPoints.GetEncoding(),
-			executionInfo.Attempt,
-			executionInfo.HasRetryPolicy,
-			executionInfo.InitialInterval,
-			executionInfo.BackoffCoefficient,
-			executionInfo.MaximumInterval,
-			executionInfo.ExpirationTime,
-			executionInfo.MaximumAttempts,
-			executionInfo.NonRetriableErrors,
-			executionInfo.EventStoreVersion,
-			executionInfo.BranchToken,
-			executionInfo.CronSchedule,
-			executionInfo.ExpirationSeconds,
-			executionInfo.SearchAttributes,
-			executionInfo.NextEventID,
-			defaultVisibilityTimestamp,
-			rowTypeExecutionTaskID)
-	} else {
-		lastReplicationInfo := make(map[string]map[string]interface{})
-		for k, v := range replicationState.LastReplicationInfo {
-			lastReplicationInfo[k] = createReplicationInfoMap(v)
-		}
-
-		batch.Query(templateCreateWorkflowExecutionWithReplicationQuery,
-			shardID,
-			domainID,
-			workflowID,
-			runID,
-			rowTypeExecution,
-			domainID,
-			workflowID,
-			runID,
-			parentDomainID,
-			parentWorkflowID,
-			parentRunID,
-			initiatedID,
-			executionInfo.CompletionEventBatchID,
-			completionData,
-			completionEncoding,
-			executionInfo.TaskList,
-			executionInfo.WorkflowTypeName,
-			executionInfo.WorkflowTimeout,
-			executionInfo.DecisionTimeoutValue,
-			executionInfo.ExecutionContext,
-			executionInfo.State,
-			executionInfo.CloseStatus,
-			executionInfo.LastFirstEventID,
-			executionInfo.LastEventTaskID,
-			executionInfo.NextEventID,
-			executionInfo.LastProcessedEvent,
-			executionInfo.StartTimestamp,
-			executionInfo.LastUpdatedTimestamp,
-			executionInfo.CreateRequestID,
-			executionInfo.SignalCount,
-			executionInfo.HistorySize,
-			executionInfo.DecisionVersion,
-			executionInfo.DecisionScheduleID,
-			executionInfo.DecisionStartedID,
-			executionInfo.DecisionRequestID,
-			executionInfo.DecisionTimeout,
-			executionInfo.DecisionAttempt,
-			executionInfo.DecisionStartedTimestamp,
-			executionInfo.DecisionScheduledTimestamp,
-			executionInfo.CancelRequested,
-			executionInfo.CancelRequestID,
-			executionInfo.StickyTaskList,
-			executionInfo.StickyScheduleToStartTimeout,
-			executionInfo.ClientLibraryVersion, // client_library_version
-			executionInfo.ClientFeatureVersion, // client_feature_version
-			executionInfo.ClientImpl,           // client_impl
-			executionInfo.AutoResetPoints.Data,
-			executionInfo.AutoResetPoints.GetEncoding(),
-			executionInfo.Attempt,
-			executionInfo.HasRetryPolicy,
-			executionInfo.InitialInterval,
-			executionInfo.BackoffCoefficient,
-			executionInfo.MaximumInterval,
-			executionInfo.ExpirationTime,
-			executionInfo.MaximumAttempts,
-			executionInfo.NonRetriableErrors,
-			executionInfo.EventStoreVersion,
-			executionInfo.BranchToken,
-			executionInfo.CronSchedule,
-			executionInfo.ExpirationSeconds,
-			executionInfo.SearchAttributes,
-			replicationState.CurrentVersion,
-			replicationState.StartVersion,
-			replicationState.LastWriteVersion,
-			replicationState.LastWriteEventID,
-			lastReplicationInfo,
-			executionInfo.NextEventID,
-			defaultVisibilityTimestamp,
-			rowTypeExecutionTaskID)
+	query := rowMapper.createWorkflowExecutionQuery
+	args := []interface{}{shardID, domainID, workflowID, runID, rowTypeExecution}
+	args = append(args, rowMapper.executionValues(executionInfo)...)
+	// workflow executions of local domains are created without replication state
+	if replicationState != nil {
+		query = rowMapper.createWorkflowExecutionWithReplicationQuery
+		args = append(args, rowMapper.replicationStateValues(replicationState)...)
 	}
+	args = append(args, executionInfo.NextEventID, defaultVisibilityTimestamp, rowTypeExecutionTaskID)
+	batch.Query(query, args...)
 	return nil
 }
 
 func updateExecution(
 	batch *gocql.Batch,
 	shardID int,
+	rowMapper *executionRowMapper,
 	executionInfo *p.InternalWorkflowExecutionInfo,
 	replicationState *p.ReplicationState,
 	cqlNowTimestampMillis int64,
@@ -551,173 +474,33 @@ func updateExecution(
 	workflowID := executionInfo.WorkflowID
 	runID := executionInfo.RunID
 
-	parentDomainID := emptyDomainID
-	parentWorkflowID := ""
-	parentRunID := emptyRunID
-	initiatedID := emptyInitiatedID
-	if executionInfo.ParentDomainID != "" {
-		parentDomainID = executionInfo.ParentDomainID
-		parentWorkflowID = executionInfo.ParentWorkflowID
-		parentRunID = executionInfo.ParentRunID
-		initiatedID = executionInfo.InitiatedID
-	}
-
 	// TODO we should set the last update time on business logic layer
 	executionInfo.LastUpdatedTimestamp = time.Unix(0, p.DBTimestampToUnixNano(cqlNowTimestampMillis))
 
-	completionData, completionEncoding := p.FromDataBlob(executionInfo.CompletionEvent)
-	if replicationState == nil {
-		// Updates will be called with null ReplicationState while the feature is disabled
-		batch.Query(templateUpdateWorkflowExecutionQuery,
-			domainID,
-			workflowID,
-			runID,
-			parentDomainID,
-			parentWorkflowID,
-			parentRunID,
-			initiatedID,
-			executionInfo.CompletionEventBatchID,
-			completionData,
-			completionEncoding,
-			executionInfo.TaskList,
-			executionInfo.WorkflowTypeName,
-			executionInfo.WorkflowTimeout,
-			executionInfo.DecisionTimeoutValue,
-			executionInfo.ExecutionContext,
-			executionInfo.State,
-			executionInfo.CloseStatus,
-			executionInfo.LastFirstEventID,
-			executionInfo.LastEventTaskID,
-			executionInfo.NextEventID,
-			executionInfo.LastProcessedEvent,
-			executionInfo.StartTimestamp,
-			executionInfo.LastUpdatedTimestamp,
-			executionInfo.CreateRequestID,
-			executionInfo.SignalCount,
-			executionInfo.HistorySize,
-			executionInfo.DecisionVersion,
-			executionInfo.DecisionScheduleID,
-			executionInfo.DecisionStartedID,
-			executionInfo.DecisionRequestID,
-			executionInfo.DecisionTimeout,
-			executionInfo.DecisionAttempt,
-			executionInfo.DecisionStartedTimestamp,
-			executionInfo.DecisionScheduledTimestamp,
-			executionInfo.CancelRequested,
-			executionInfo.CancelRequestID,
-			executionInfo.StickyTaskList,
-			executionInfo.StickyScheduleToStartTimeout,
-			executionInfo.ClientLibraryVersion,
-			executionInfo.ClientFeatureVersion,
-			executionInfo.ClientImpl,
-			executionInfo.AutoResetPoints.Data,
-			executionInfo.AutoResetPoints.GetEncoding(),
-			executionInfo.Attempt,
-			executionInfo.HasRetryPolicy,
-			executionInfo.InitialInterval,
-			executionInfo.BackoffCoefficient,
-			executionInfo.MaximumInterval,
-			executionInfo.ExpirationTime,
-			executionInfo.MaximumAttempts,
-			executionInfo.NonRetriableErrors,
-			executionInfo.EventStoreVersion,
-			executionInfo.BranchToken,
-			executionInfo.CronSchedule,
-			executionInfo.ExpirationSeconds,
-			executionInfo.SearchAttributes,
-			executionInfo.NextEventID,
-			shardID,
-			rowTypeExecution,
-			domainID,
-			workflowID,
-			runID,
-			defaultVisibilityTimestamp,
-			rowTypeExecutionTaskID,
-			condition)
-	} else {
-		lastReplicationInfo := make(map[string]map[string]interface{})
-		for k, v := range replicationState.LastReplicationInfo {
-			lastReplicationInfo[k] = createReplicationInfoMap(v)
-		}
-
-		batch.Query(templateUpdateWorkflowExecutionWithReplicationQuery,
-			domainID,
-			workflowID,
-			runID,
-			parentDomainID,
-			parentWorkflowID,
-			parentRunID,
-			initiatedID,
-			executionInfo.CompletionEventBatchID,
-			completionData,
-			completionEncoding,
-			executionInfo.TaskList,
-			executionInfo.WorkflowTypeName,
-			executionInfo.WorkflowTimeout,
-			executionInfo.DecisionTimeoutValue,
-			executionInfo.ExecutionContext,
-			executionInfo.State,
-			executionInfo.CloseStatus,
-			executionInfo.LastFirstEventID,
-			executionInfo.LastEventTaskID,
-			executionInfo.NextEventID,
-			executionInfo.LastProcessedEvent,
-			executionInfo.StartTimestamp,
-			executionInfo.LastUpdatedTimestamp,
-			executionInfo.CreateRequestID,
-			executionInfo.SignalCount,
-			executionInfo.HistorySize,
-			executionInfo.DecisionVersion,
-			executionInfo.DecisionScheduleID,
-			executionInfo.DecisionStartedID,
-			executionInfo.DecisionRequestID,
-			executionInfo.DecisionTimeout,
-			executionInfo.DecisionAttempt,
-			executionInfo.DecisionStartedTimestamp,
-			executionInfo.DecisionScheduledTimestamp,
-			executionInfo.CancelRequested,
-			executionInfo.CancelRequestID,
-			executionInfo.StickyTaskList,
-			executionInfo.StickyScheduleToStartTimeout,
-			executionInfo.ClientLibraryVersion,
-			executionInfo.ClientFeatureVersion,
-			executionInfo.ClientImpl,
-			executionInfo.AutoResetPoints.Data,
-			executionInfo.AutoResetPoints.GetEncoding(),
-			executionInfo.Attempt,
-			executionInfo.HasRetryPolicy,
-			executionInfo.InitialInterval,
-			executionInfo.BackoffCoefficient,
-			executionInfo.MaximumInterval,
-			executionInfo.ExpirationTime,
-			executionInfo.MaximumAttempts,
-			executionInfo.NonRetriableErrors,
-			executionInfo.EventStoreVersion,
-			executionInfo.BranchToken,
-			executionInfo.CronSchedule,
-			executionInfo.ExpirationSeconds,
-			executionInfo.SearchAttributes,
-			replicationState.CurrentVersion,
-			replicationState.StartVersion,
-			replicationState.LastWriteVersion,
-			replicationState.LastWriteEventID,
-			lastReplicationInfo,
-			executionInfo.NextEventID,
-			shardID,
-			rowTypeExecution,
-			domainID,
-			workflowID,
-			runID,
-			defaultVisibilityTimestamp,
-			rowTypeExecutionTaskID,
-			condition)
+	query := rowMapper.updateWorkflowExecutionQuery
+	args := rowMapper.executionValues(executionInfo)
+	// updates will be called with null ReplicationState for local domains
+	if replicationState != nil {
+		query = rowMapper.updateWorkflowExecutionWithReplicationQuery
+		args = append(args, rowMapper.replicationStateValues(replicationState)...)
 	}
-
+	args = append(args,
+		executionInfo.NextEventID,
+		shardID,
+		rowTypeExecution,
+		domainID,
+		workflowID,
+		runID,
+		defaultVisibilityTimestamp,
+		rowTypeExecutionTaskID,
+		condition)
+	batch.Query(query, args...)
 	return nil
 }
 
 func applyTasks(
 	batch *gocql.Batch,
+	crossBatch *crossPartitionBatch,
 	shardID int,
 	domainID string,
 	workflowID string,
@@ -725,10 +508,12 @@ func applyTasks(
 	transferTasks []p.Task,
 	replicationTasks []p.Task,
 	timerTasks []p.Task,
+	visibilityTasks []p.Task,
 ) error {
 
 	if err := createTransferTasks(
 		batch,
+		crossBatch,
 		transferTasks,
 		shardID,
 		domainID,
@@ -749,6 +534,17 @@ func applyTasks(
 		return err
 	}
 
+	if err := createVisibilityTasks(
+		batch,
+		visibilityTasks,
+		shardID,
+		domainID,
+		workflowID,
+		runID,
+	); err != nil {
+		return err
+	}
+
 	return createTimerTasks(
 		batch,
 		timerTasks,
@@ -761,6 +557,7 @@ func applyTasks(
 
 func createTransferTasks(
 	batch *gocql.Batch,
+	crossBatch *crossPartitionBatch,
 	transferTasks []p.Task,
 	shardID int,
 	domainID string,
@@ -814,6 +611,15 @@ func createTransferTasks(
 			targetWorkflowID = task.(*p.StartChildExecutionTask).TargetWorkflowID
 			scheduleID = task.(*p.StartChildExecutionTask).InitiatedID
 
+		case p.TransferTaskTypeRecordChildExecutionCompleted:
+			targetDomainID = task.(*p.RecordChildExecutionCompletedTask).TargetDomainID
+			targetWorkflowID = task.(*p.RecordChildExecutionCompletedTask).TargetWorkflowID
+			targetRunID = task.(*p.RecordChildExecutionCompletedTask).TargetRunID
+			if targetRunID == "" {
+				targetRunID = p.TransferTaskTransferTargetRunID
+			}
+			scheduleID = task.(*p.RecordChildExecutionCompletedTask).InitiatedID
+
 		case p.TransferTaskTypeCloseExecution,
 			p.TransferTaskTypeRecordWorkflowStarted,
 			p.TransferTaskTypeResetWorkflow,
@@ -826,6 +632,8 @@ func createTransferTasks(
 			}
 		}
 
+		// the task is always staged in the shard partition so that it commits with the conditional update,
+		// it is moved to its bucket once the update is applied
 		batch.Query(templateCreateTransferTaskQuery,
 			shardID,
 			rowTypeTransferTask,
@@ -848,6 +656,103 @@ func createTransferTasks(
 			task.GetVersion(),
 			defaultVisibilityTimestamp,
 			task.GetTaskID())
+
+		if crossBatch.transferTaskBuckets > 0 {
+			crossBatch.bucketBatch.Query(templateCreateBucketedTransferTaskQuery,
+				shardID,
+				transferTaskBucket(task.GetTaskID(), crossBatch.transferTaskBuckets),
+				task.GetTaskID(),
+				domainID,
+				workflowID,
+				runID,
+				task.GetVisibilityTimestamp(),
+				task.GetTaskID(),
+				targetDomainID,
+				targetWorkflowID,
+				targetRunID,
+				targetChildWorkflowOnly,
+				taskList,
+				task.GetType(),
+				scheduleID,
+				recordVisibility,
+				task.GetVersion())
+			crossBatch.bucketBatch.Query(templateCompleteTransferTaskQuery,
+				shardID,
+				rowTypeTransferTask,
+				rowTypeTransferDomainID,
+				rowTypeTransferWorkflowID,
+				rowTypeTransferRunID,
+				defaultVisibilityTimestamp,
+				task.GetTaskID())
+		}
+	}
+
+	return nil
+}
+
+// createClosedExecutionIndex indexes the execution by its close time when the tasks close it
+func createClosedExecutionIndex(
+	crossBatch *crossPartitionBatch,
+	shardID int,
+	executionInfo *p.InternalWorkflowExecutionInfo,
+	transferTasks []p.Task,
+) {
+
+	for _, task := range transferTasks {
+		if task.GetType() != p.TransferTaskTypeCloseExecution {
+			continue
+		}
+
+		closeTime := executionInfo.LastUpdatedTimestamp
+		crossBatch.Query(templateCreateClosedExecutionIndexQuery,
+			shardID,
+			closedExecutionIndexDay(closeTime),
+			closeTime,
+			executionInfo.DomainID,
+			executionInfo.WorkflowID,
+			executionInfo.RunID,
+			task.GetVersion())
+		return
+	}
+}
+
+func createVisibilityTasks(
+	batch *gocql.Batch,
+	visibilityTasks []p.Task,
+	shardID int,
+	domainID string,
+	workflowID string,
+	runID string,
+) error {
+
+	for _, task := range visibilityTasks {
+		switch task.GetType() {
+		case p.VisibilityTaskTypeRecordWorkflowStarted,
+			p.VisibilityTaskTypeUpsertWorkflowSearchAttributes,
+			p.VisibilityTaskTypeCloseExecution:
+			// No explicit property needs to be set
+
+		default:
+			return &workflow.InternalServiceError{
+				Message: fmt.Sprintf("Unknow visibility type: %v", task.GetType()),
+			}
+		}
+
+		batch.Query(templateCreateVisibilityTaskQuery,
+			shardID,
+			rowTypeVisibilityTask,
+			rowTypeVisibilityDomainID,
+			rowTypeVisibilityWorkflowID,
+			rowTypeVisibilityRunID,
+			domainID,
+			workflowID,
+			runID,
+			task.GetVisibilityTimestamp(),
+			task.GetTaskID(),
+			task.GetType(),
+			task.GetVersion(),
+			defaultVisibilityTimestamp,
+			task.GetTaskID())
 	}
 
 	return nil
@@ -969,6 +874,9 @@ func createTimerTasks(
 		case *p.WorkflowTimeoutTask:
 			// noop
 
+		case *p.WorkflowSLATimerTask:
+			// noop
+
 		case *p.DeleteHistoryEventTask:
 			// noop
 
@@ -1154,6 +1062,7 @@ func updateActivityInfos(
 			a.LastFailureReason,
 			a.LastWorkerIdentity,
 			scheduleEncoding,
+			a.Paused,
 			shardID,
 			rowTypeExecution,
 			domainID,
@@ -1488,10 +1397,74 @@ func resetSignalInfos(
 		rowTypeExecutionTaskID)
 }
 
+func updateUpdateInfos(
+	batch *gocql.Batch,
+	updateInfos []*p.UpdateInfo,
+	deleteInfo *string,
+	shardID int,
+	domainID string,
+	workflowID string,
+	runID string,
+) {
+
+	for _, c := range updateInfos {
+		batch.Query(templateUpdateUpdateInfoQuery,
+			c.UpdateID,
+			c.Version,
+			c.UpdateID,
+			c.UpdateName,
+			c.Input,
+			c.Result,
+			c.Completed,
+			shardID,
+			rowTypeExecution,
+			domainID,
+			workflowID,
+			runID,
+			defaultVisibilityTimestamp,
+			rowTypeExecutionTaskID)
+	}
+
+	// deleteInfo is the updateID for UpdateInfo being deleted
+	if deleteInfo != nil {
+		batch.Query(templateDeleteUpdateInfoQuery,
+			*deleteInfo,
+			shardID,
+			rowTypeExecution,
+			domainID,
+			workflowID,
+			runID,
+			defaultVisibilityTimestamp,
+			rowTypeExecutionTaskID)
+	}
+}
+
+func resetUpdateInfos(
+	batch *gocql.Batch,
+	updateInfos []*p.UpdateInfo,
+	shardID int,
+	domainID string,
+	workflowID string,
+	runID string,
+) {
+
+	batch.Query(templateResetUpdateInfoQuery,
+		resetUpdateInfoMap(updateInfos),
+		shardID,
+		rowTypeExecution,
+		domainID,
+		workflowID,
+		runID,
+		defaultVisibilityTimestamp,
+		rowTypeExecutionTaskID)
+}
+
+// updateSignalsRequested coalesces the signal requested mutations of a transaction into at most one
+// addition and one removal of the set
 func updateSignalsRequested(
 	batch *gocql.Batch,
 	signalReqIDs []string,
-	deleteSignalReqID string,
+	deleteSignalReqIDs []string,
 	shardID int,
 	domainID string,
 	workflowID string,
@@ -1510,10 +1483,9 @@ func updateSignalsRequested(
 			rowTypeExecutionTaskID)
 	}
 
-	if deleteSignalReqID != "" {
-		req := []string{deleteSignalReqID} // for cassandra set binding
+	if len(deleteSignalReqIDs) > 0 {
 		batch.Query(templateDeleteWorkflowExecutionSignalRequestedQuery,
-			req,
+			deleteSignalReqIDs,
 			shardID,
 			rowTypeExecution,
 			domainID,
@@ -1611,6 +1583,29 @@ func createShardInfo(
 			info.ClusterTimerAckLevel = v.(map[string]time.Time)
 		case "domain_notification_version":
 			info.DomainNotificationVersion = v.(int64)
+		case "domain_open_execution_counts":
+			info.DomainOpenExecutionCounts = v.(map[string]int64)
+		case "visibility_ack_level":
+			info.VisibilityAckLevel = v.(int64)
+		case "queue_alarms":
+			info.QueueAlarms = make(map[string]p.QueueAlarm)
+			for queue, alarm := range v.(map[string]map[string]interface{}) {
+				info.QueueAlarms[queue] = createQueueAlarm(alarm)
+			}
+		case "hot_executions":
+			for _, execution := range v.([]map[string]interface{}) {
+				info.HotExecutions = append(info.HotExecutions, createHotExecution(execution))
+			}
+		case "lease_expires_at":
+			info.LeaseExpiresAt = v.(time.Time)
+		case "tasks_per_second":
+			info.TasksPerSecond = v.(float64)
+		case "task_throughput_updated_at":
+			info.TaskThroughputUpdatedAt = v.(time.Time)
+		case "placement":
+			info.Placement = v.([]string)
+		case "transfer_task_buckets":
+			info.TransferTaskBuckets = v.(int)
 		}
 	}
 
@@ -1632,137 +1627,9 @@ func createWorkflowExecutionInfo(
 	result map[string]interface{},
 ) *p.InternalWorkflowExecutionInfo {
 
-	info := &p.InternalWorkflowExecutionInfo{}
-	var completionEventData []byte
-	var completionEventEncoding common.EncodingType
-	var autoResetPoints []byte
-	var autoResetPointsEncoding common.EncodingType
-
-	for k, v := range result {
-		switch k {
-		case "domain_id":
-			info.DomainID = v.(gocql.UUID).String()
-		case "workflow_id":
-			info.WorkflowID = v.(string)
-		case "run_id":
-			info.RunID = v.(gocql.UUID).String()
-		case "parent_domain_id":
-			info.ParentDomainID = v.(gocql.UUID).String()
-			if info.ParentDomainID == emptyDomainID {
-				info.ParentDomainID = ""
-			}
-		case "parent_workflow_id":
-			info.ParentWorkflowID = v.(string)
-		case "parent_run_id":
-			info.ParentRunID = v.(gocql.UUID).String()
-			if info.ParentRunID == emptyRunID {
-				info.ParentRunID = ""
-			}
-		case "initiated_id":
-			info.InitiatedID = v.(int64)
-		case "completion_event_batch_id":
-			info.CompletionEventBatchID = v.(int64)
-		case "completion_event":
-			completionEventData = v.([]byte)
-		case "completion_event_data_encoding":
-			completionEventEncoding = common.EncodingType(v.(string))
-		case "auto_reset_points":
-			autoResetPoints = v.([]byte)
-		case "auto_reset_points_encoding":
-			autoResetPointsEncoding = common.EncodingType(v.(string))
-		case "task_list":
-			info.TaskList = v.(string)
-		case "workflow_type_name":
-			info.WorkflowTypeName = v.(string)
-		case "workflow_timeout":
-			info.WorkflowTimeout = int32(v.(int))
-		case "decision_task_timeout":
-			info.DecisionTimeoutValue = int32(v.(int))
-		case "execution_context":
-			info.ExecutionContext = v.([]byte)
-		case "state":
-			info.State = v.(int)
-		case "close_status":
-			info.CloseStatus = v.(int)
-		case "last_first_event_id":
-			info.LastFirstEventID = v.(int64)
-		case "last_event_task_id":
-			info.LastEventTaskID = v.(int64)
-		case "next_event_id":
-			info.NextEventID = v.(int64)
-		case "last_processed_event":
-			info.LastProcessedEvent = v.(int64)
-		case "start_time":
-			info.StartTimestamp = v.(time.Time)
-		case "last_updated_time":
-			info.LastUpdatedTimestamp = v.(time.Time)
-		case "create_request_id":
-			info.CreateRequestID = v.(gocql.UUID).String()
-		case "signal_count":
-			info.SignalCount = int32(v.(int))
-		case "history_size":
-			info.HistorySize = v.(int64)
-		case "decision_version":
-			info.DecisionVersion = v.(int64)
-		case "decision_schedule_id":
-			info.DecisionScheduleID = v.(int64)
-		case "decision_started_id":
-			info.DecisionStartedID = v.(int64)
-		case "decision_request_id":
-			info.DecisionRequestID = v.(string)
-		case "decision_timeout":
-			info.DecisionTimeout = int32(v.(int))
-		case "decision_attempt":
-			info.DecisionAttempt = v.(int64)
-		case "decision_timestamp":
-			info.DecisionStartedTimestamp = v.(int64)
-		case "decision_scheduled_timestamp":
-			info.DecisionScheduledTimestamp = v.(int64)
-		case "cancel_requested":
-			info.CancelRequested = v.(bool)
-		case "cancel_request_id":
-			info.CancelRequestID = v.(string)
-		case "sticky_task_list":
-			info.StickyTaskList = v.(string)
-		case "sticky_schedule_to_start_timeout":
-			info.StickyScheduleToStartTimeout = int32(v.(int))
-		case "client_library_version":
-			info.ClientLibraryVersion = v.(string)
-		case "client_feature_version":
-			info.ClientFeatureVersion = v.(string)
-		case "client_impl":
-			info.ClientImpl = v.(string)
-		case "attempt":
-			info.Attempt = int32(v.(int))
-		case "has_retry_policy":
-			info.HasRetryPolicy = v.(bool)
-		case "init_interval":
-			info.InitialInterval = int32(v.(int))
-		case "backoff_coefficient":
-			info.BackoffCoefficient = v.(float64)
-		case "max_interval":
-			info.MaximumInterval = int32(v.(int))
-		case "max_attempts":
-			info.MaximumAttempts = int32(v.(int))
-		case "expiration_time":
-			info.ExpirationTime = v.(time.Time)
-		case "non_retriable_errors":
-			info.NonRetriableErrors = v.([]string)
-		case "event_store_version":
-			info.EventStoreVersion = int32(v.(int))
-		case "branch_token":
-			info.BranchToken = v.([]byte)
-		case "cron_schedule":
-			info.CronSchedule = v.(string)
-		case "expiration_seconds":
-			info.ExpirationSeconds = int32(v.(int))
-		case "search_attributes":
-			info.SearchAttributes = v.(map[string][]byte)
-		}
-	}
-	info.CompletionEvent = p.NewDataBlob(completionEventData, completionEventEncoding)
-	info.AutoResetPoints = p.NewDataBlob(autoResetPoints, autoResetPointsEncoding)
-	return info
+	row := &executionRow{}
+	scanUDT(result, row)
+	return row.toExecutionInfo()
 }
 
 func createReplicationState(
@@ -1773,27 +1640,9 @@ func createReplicationState(
 		return nil
 	}
 
-	info := &p.ReplicationState{}
-	for k, v := range result {
-		switch k {
-		case "current_version":
-			info.CurrentVersion = v.(int64)
-		case "start_version":
-			info.StartVersion = v.(int64)
-		case "last_write_version":
-			info.LastWriteVersion = v.(int64)
-		case "last_write_event_id":
-			info.LastWriteEventID = v.(int64)
-		case "last_replication_info":
-			info.LastReplicationInfo = make(map[string]*p.ReplicationInfo)
-			replicationInfoMap := v.(map[string]map[string]interface{})
-			for key, value := range replicationInfoMap {
-				info.LastReplicationInfo[key] = createReplicationInfo(value)
-			}
-		}
-	}
-
-	return info
+	row := &replicationStateRow{}
+	scanUDT(result, row)
+	return row.toReplicationState()
 }
 
 func createTransferTaskInfo(
@@ -1840,6 +1689,33 @@ func createTransferTaskInfo(
 	return info
 }
 
+func createVisibilityTaskInfo(
+	result map[string]interface{},
+) *p.VisibilityTaskInfo {
+
+	info := &p.VisibilityTaskInfo{}
+	for k, v := range result {
+		switch k {
+		case "domain_id":
+			info.DomainID = v.(gocql.UUID).String()
+		case "workflow_id":
+			info.WorkflowID = v.(string)
+		case "run_id":
+			info.RunID = v.(gocql.UUID).String()
+		case "visibility_ts":
+			info.VisibilityTimestamp = v.(time.Time)
+		case "task_id":
+			info.TaskID = v.(int64)
+		case "type":
+			info.TaskType = v.(int)
+		case "version":
+			info.Version = v.(int64)
+		}
+	}
+
+	return info
+}
+
 func createReplicationTaskInfo(
 	result map[string]interface{},
 ) *p.ReplicationTaskInfo {
@@ -1961,6 +1837,8 @@ func createActivityInfo(
 			info.LastWorkerIdentity = v.(string)
 		case "event_data_encoding":
 			sharedEncoding = common.EncodingType(v.(string))
+		case "paused":
+			info.Paused = v.(bool)
 		}
 	}
 	info.DomainID = domainID
@@ -2077,6 +1955,31 @@ func createSignalInfo(
 	return info
 }
 
+func createUpdateInfo(
+	result map[string]interface{},
+) *p.UpdateInfo {
+
+	info := &p.UpdateInfo{}
+	for k, v := range result {
+		switch k {
+		case "version":
+			info.Version = v.(int64)
+		case "update_id":
+			info.UpdateID = v.(string)
+		case "update_name":
+			info.UpdateName = v.(string)
+		case "input":
+			info.Input = v.([]byte)
+		case "result":
+			info.Result = v.([]byte)
+		case "completed":
+			info.Completed = v.(bool)
+		}
+	}
+
+	return info
+}
+
 func resetActivityInfoMap(
 	activityInfos []*p.InternalActivityInfo,
 ) (map[int64]map[string]interface{}, error) {
@@ -2121,6 +2024,7 @@ func resetActivityInfoMap(
 		aInfo["non_retriable_errors"] = a.NonRetriableErrors
 		aInfo["last_failure_reason"] = a.LastFailureReason
 		aInfo["last_worker_identity"] = a.LastWorkerIdentity
+		aInfo["paused"] = a.Paused
 
 		aMap[a.ScheduleID] = aInfo
 	}
@@ -2219,6 +2123,26 @@ func resetSignalInfoMap(
 	return sMap
 }
 
+func resetUpdateInfoMap(
+	updateInfos []*p.UpdateInfo,
+) map[string]map[string]interface{} {
+
+	uMap := make(map[string]map[string]interface{})
+	for _, u := range updateInfos {
+		uInfo := make(map[string]interface{})
+		uInfo["version"] = u.Version
+		uInfo["update_id"] = u.UpdateID
+		uInfo["update_name"] = u.UpdateName
+		uInfo["input"] = u.Input
+		uInfo["result"] = u.Result
+		uInfo["completed"] = u.Completed
+
+		uMap[u.UpdateID] = uInfo
+	}
+
+	return uMap
+}
+
 func createHistoryEventBatchBlob(
 	result map[string]interface{},
 ) *p.DataBlob {
@@ -2320,6 +2244,76 @@ func createReplicationInfoMap(
 	return rInfoMap
 }
 
+func createQueueAlarm(
+	result map[string]interface{},
+) p.QueueAlarm {
+
+	alarm := p.QueueAlarm{}
+	for k, v := range result {
+		switch k {
+		case "stuck_since":
+			alarm.StuckSince = v.(time.Time)
+		case "blocking_task_id":
+			alarm.BlockingTaskID = v.(int64)
+		case "blocking_task_timestamp":
+			alarm.BlockingTaskTimestamp = v.(time.Time)
+		}
+	}
+
+	return alarm
+}
+
+func createQueueAlarmsMap(
+	alarms map[string]p.QueueAlarm,
+) map[string]map[string]interface{} {
+
+	alarmsMap := make(map[string]map[string]interface{})
+	for queue, alarm := range alarms {
+		alarmsMap[queue] = map[string]interface{}{
+			"stuck_since":             alarm.StuckSince,
+			"blocking_task_id":        alarm.BlockingTaskID,
+			"blocking_task_timestamp": alarm.BlockingTaskTimestamp,
+		}
+	}
+
+	return alarmsMap
+}
+
+func createHotExecution(
+	result map[string]interface{},
+) p.HotExecution {
+
+	execution := p.HotExecution{}
+	for k, v := range result {
+		switch k {
+		case "domain_id":
+			execution.DomainID = v.(gocql.UUID).String()
+		case "workflow_id":
+			execution.WorkflowID = v.(string)
+		case "run_id":
+			execution.RunID = v.(gocql.UUID).String()
+		}
+	}
+
+	return execution
+}
+
+func createHotExecutionsList(
+	executions []p.HotExecution,
+) []map[string]interface{} {
+
+	executionsList := make([]map[string]interface{}, 0, len(executions))
+	for _, execution := range executions {
+		executionsList = append(executionsList, map[string]interface{}{
+			"domain_id":   execution.DomainID,
+			"workflow_id": execution.WorkflowID,
+			"run_id":      execution.RunID,
+		})
+	}
+
+	return executionsList
+}
+
 func isTimeoutError(err error) bool {
 	if err == gocql.ErrTimeoutNoResponse {
 		return true
@@ -2331,6 +2325,23 @@ func isTimeoutError(err error) bool {
 	return ok
 }
 
+// withRequestContext cancels the query once the context of the request is done, so that no work is
+// wasted on callers which already gave up
+func withRequestContext(query *gocql.Query, ctx context.Context) *gocql.Query {
+	if ctx == nil {
+		return query
+	}
+	return query.WithContext(ctx)
+}
+
+// requestContextError returns the error of the request context if the query failed because the context is done
+func requestContextError(ctx context.Context) error {
+	if ctx == nil {
+		return nil
+	}
+	return ctx.Err()
+}
+
 func isThrottlingError(err error) bool {
 	if req, ok := err.(gocql.RequestError); ok {
 		// gocql does not expose the constant errOverloaded = 0x1001
@@ -2338,3 +2349,20 @@ func isThrottlingError(err error) bool {
 	}
 	return false
 }
+
+func serializePageToken(lastTaskID int64) []byte {
+	b := make([]byte, 8)
+	binary.LittleEndian.PutUint64(b, uint64(lastTaskID))
+	return b
+}
+
+func deserializePageToken(payload []byte) (int64, error) {
+	if len(payload) != 8 {
+		return 0, fmt.Errorf("invalid token of %v length", len(payload))
+	}
+	return int64(binary.LittleEndian.Uint64(payload)), nil
+}
+
+func closedExecutionIndexDay(closeTime time.Time) time.Time {
+	return closeTime.UTC().Truncate(24 * time.Hour)
+}
@@ -0,0 +1,69 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cassandra
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/log/loggerimpl"
+	p "github.com/uber/cadence/common/persistence"
+)
+
+type (
+	cassandraPersistenceSuite struct {
+		suite.Suite
+	}
+)
+
+func TestCassandraPersistenceSuite(t *testing.T) {
+	suite.Run(t, new(cassandraPersistenceSuite))
+}
+
+func (s *cassandraPersistenceSuite) TestNewExecutionOperationError() {
+	d := &cassandraPersistence{shardID: 7, logger: loggerimpl.NewNopLogger()}
+
+	err := d.newExecutionOperationError(
+		"UpdateWorkflowExecution",
+		conditionalBatchTemplate,
+		"domain-id",
+		"workflow-id",
+		"run-id",
+		errors.New("write failed"),
+	)
+
+	operationErr, ok := err.(*p.StoreOperationError)
+	s.True(ok)
+	s.Equal(&p.StoreOperationError{
+		Operation:  "UpdateWorkflowExecution",
+		Template:   conditionalBatchTemplate,
+		ShardID:    7,
+		DomainID:   "domain-id",
+		WorkflowID: "workflow-id",
+		RunID:      "run-id",
+		Msg:        "UpdateWorkflowExecution operation failed. Error: write failed",
+	}, operationErr)
+	s.Equal(operationErr.Msg, operationErr.InternalServiceError().Message)
+	s.True(common.IsPersistenceTransientError(err))
+	s.True(common.IsWhitelistServiceTransientError(err))
+}
@@ -0,0 +1,587 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cassandra
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/gocql/gocql"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/log"
+	"github.com/uber/cadence/common/log/tag"
+	p "github.com/uber/cadence/common/persistence"
+)
+
+const (
+	// cqlTag is the struct tag naming the UDT field a row field is bound to
+	cqlTag = "cql"
+	// sinceTag is the struct tag naming the schema version that added the UDT field,
+	// fields without it exist in every supported schema version
+	sinceTag = "since"
+
+	// schemaVersionRefreshInterval is how often the schema version of the keyspace is re-read
+	schemaVersionRefreshInterval = time.Minute
+)
+
+type (
+	// schemaVersion is the version of the cassandra schema, e.g. 0.31
+	schemaVersion struct {
+		major int
+		minor int
+	}
+
+	// executionRowMapper binds the execution rows to the UDT fields which exist in the schema version
+	// of the keyspace. Fields added by a newer schema are not written and read back with their zero
+	// value, so the code can be rolled out ahead of the schema upgrade adding them.
+	executionRowMapper struct {
+		version                                     schemaVersion
+		createWorkflowExecutionQuery                string
+		createWorkflowExecutionWithReplicationQuery string
+		updateWorkflowExecutionQuery                string
+		updateWorkflowExecutionWithReplicationQuery string
+	}
+
+	// executionRow is the cassandra representation of the workflow_execution UDT, the templates
+	// and the bind values of the execution queries are both derived from its cql tags, in declaration order
+	executionRow struct {
+		DomainID                      string            `cql:"domain_id"`
+		WorkflowID                    string            `cql:"workflow_id"`
+		RunID                         string            `cql:"run_id"`
+		FirstExecutionRunID           string            `cql:"first_execution_run_id" since:"0.30"`
+		ParentDomainID                string            `cql:"parent_domain_id"`
+		ParentWorkflowID              string            `cql:"parent_workflow_id"`
+		ParentRunID                   string            `cql:"parent_run_id"`
+		InitiatedID                   int64             `cql:"initiated_id"`
+		CompletionEventBatchID        int64             `cql:"completion_event_batch_id"`
+		CompletionEvent               []byte            `cql:"completion_event"`
+		CompletionEventEncoding       string            `cql:"completion_event_data_encoding"`
+		TaskList                      string            `cql:"task_list"`
+		WorkflowTypeName              string            `cql:"workflow_type_name"`
+		WorkflowTimeout               int32             `cql:"workflow_timeout"`
+		DecisionTaskTimeout           int32             `cql:"decision_task_timeout"`
+		ExecutionContext              []byte            `cql:"execution_context"`
+		State                         int               `cql:"state"`
+		CloseStatus                   int               `cql:"close_status"`
+		LastFirstEventID              int64             `cql:"last_first_event_id"`
+		LastEventTaskID               int64             `cql:"last_event_task_id"`
+		NextEventID                   int64             `cql:"next_event_id"`
+		LastProcessedEvent            int64             `cql:"last_processed_event"`
+		StartTime                     time.Time         `cql:"start_time"`
+		LastUpdatedTime               time.Time         `cql:"last_updated_time"`
+		CreateRequestID               string            `cql:"create_request_id"`
+		SignalCount                   int32             `cql:"signal_count"`
+		HistorySize                   int64             `cql:"history_size"`
+		DecisionVersion               int64             `cql:"decision_version"`
+		DecisionScheduleID            int64             `cql:"decision_schedule_id"`
+		DecisionStartedID             int64             `cql:"decision_started_id"`
+		DecisionRequestID             string            `cql:"decision_request_id"`
+		DecisionTimeout               int32             `cql:"decision_timeout"`
+		DecisionAttempt               int64             `cql:"decision_attempt"`
+		DecisionTimestamp             int64             `cql:"decision_timestamp"`
+		DecisionScheduledTimestamp    int64             `cql:"decision_scheduled_timestamp"`
+		CancelRequested               bool              `cql:"cancel_requested"`
+		CancelRequestID               string            `cql:"cancel_request_id"`
+		StickyTaskList                string            `cql:"sticky_task_list"`
+		StickyScheduleToStartTimeout  int32             `cql:"sticky_schedule_to_start_timeout"`
+		ClientLibraryVersion          string            `cql:"client_library_version"`
+		ClientFeatureVersion          string            `cql:"client_feature_version"`
+		ClientImpl                    string            `cql:"client_impl"`
+		AutoResetPoints               []byte            `cql:"auto_reset_points"`
+		AutoResetPointsEncoding       string            `cql:"auto_reset_points_encoding"`
+		Attempt                       int32             `cql:"attempt"`
+		HasRetryPolicy                bool              `cql:"has_retry_policy"`
+		InitInterval                  int32             `cql:"init_interval"`
+		BackoffCoefficient            float64           `cql:"backoff_coefficient"`
+		MaxInterval                   int32             `cql:"max_interval"`
+		ExpirationTime                time.Time         `cql:"expiration_time"`
+		MaxAttempts                   int32             `cql:"max_attempts"`
+		NonRetriableErrors            []string          `cql:"non_retriable_errors"`
+		EventStoreVersion             int32             `cql:"event_store_version"`
+		BranchToken                   []byte            `cql:"branch_token"`
+		CronSchedule                  string            `cql:"cron_schedule"`
+		ExpirationSeconds             int32             `cql:"expiration_seconds"`
+		SearchAttributes              map[string][]byte `cql:"search_attributes"`
+		CompletedActivityRequestIDs   []string          `cql:"completed_activity_request_ids" since:"0.20"`
+		Notes                         string            `cql:"notes" since:"0.22"`
+		NotesIdentity                 string            `cql:"notes_identity" since:"0.22"`
+		NotesLastUpdatedTime          time.Time         `cql:"notes_last_updated_time" since:"0.22"`
+		Paused                        bool              `cql:"paused" since:"0.31"`
+		CronPaused                    bool              `cql:"cron_paused" since:"0.33"`
+		CronTimezone                  string            `cql:"cron_timezone" since:"0.39"`
+		DecisionStartedIdentity       string            `cql:"decision_started_identity" since:"0.40"`
+		DecisionStartedBinaryChecksum string            `cql:"decision_started_binary_checksum" since:"0.40"`
+		ResetBaseRunID                string            `cql:"reset_base_run_id" since:"0.43"`
+		ResetBaseEventID              int64             `cql:"reset_base_event_id" since:"0.43"`
+		ResetReason                   string            `cql:"reset_reason" since:"0.43"`
+		DecisionHeartbeatCount        int64             `cql:"decision_heartbeat_count" since:"0.49"`
+	}
+
+	// replicationStateRow is the cassandra representation of the replication_state UDT
+	replicationStateRow struct {
+		CurrentVersion      int64                             `cql:"current_version"`
+		StartVersion        int64                             `cql:"start_version"`
+		LastWriteVersion    int64                             `cql:"last_write_version"`
+		LastWriteEventID    int64                             `cql:"last_write_event_id"`
+		LastReplicationInfo map[string]map[string]interface{} `cql:"last_replication_info"`
+	}
+
+	// executionRowMappers vends the row mapper of the current schema version of the keyspace. The version
+	// is re-read periodically so the fields added by a schema upgrade are written without a restart.
+	executionRowMappers struct {
+		readVersion func() (schemaVersion, error)
+		logger      log.Logger
+		mapper      atomic.Value // *executionRowMapper
+		shutdownCh  chan struct{}
+	}
+)
+
+// latestSchemaVersion includes every UDT field known to the code
+var latestSchemaVersion = schemaVersion{major: math.MaxInt32}
+
+// parseSchemaVersion parses a schema version of the form major.minor
+func parseSchemaVersion(version string) (schemaVersion, error) {
+	parts := strings.Split(version, ".")
+	if len(parts) != 2 {
+		return schemaVersion{}, fmt.Errorf("invalid schema version %q", version)
+	}
+	major, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return schemaVersion{}, fmt.Errorf("invalid schema version %q: %v", version, err)
+	}
+	minor, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return schemaVersion{}, fmt.Errorf("invalid schema version %q: %v", version, err)
+	}
+	return schemaVersion{major: major, minor: minor}, nil
+}
+
+func (v schemaVersion) before(other schemaVersion) bool {
+	return v.major < other.major || (v.major == other.major && v.minor < other.minor)
+}
+
+// hasField returns whether the UDT field bound to the row field exists in the schema version
+func (v schemaVersion) hasField(field reflect.StructField) bool {
+	since := field.Tag.Get(sinceTag)
+	if since == "" {
+		return true
+	}
+	sinceVersion, err := parseSchemaVersion(since)
+	if err != nil {
+		panic(fmt.Sprintf("row field %v: %v", field.Name, err))
+	}
+	return !v.before(sinceVersion)
+}
+
+// newExecutionRowMappers reads the schema version of the keyspace and keeps it up to date until stop is called,
+// it fails when the version cannot be read since writing fields unknown to the schema fails every update
+func newExecutionRowMappers(
+	readVersion func() (schemaVersion, error),
+	logger log.Logger,
+) (*executionRowMappers, error) {
+
+	version, err := readVersion()
+	if err != nil {
+		return nil, fmt.Errorf("unable to read the cassandra schema version: %v", err)
+	}
+	m := &executionRowMappers{
+		readVersion: readVersion,
+		logger:      logger,
+		shutdownCh:  make(chan struct{}),
+	}
+	m.mapper.Store(newExecutionRowMapper(version))
+	go m.refreshLoop()
+	return m, nil
+}
+
+// newFixedExecutionRowMappers always vends the row mapper of the given schema version
+func newFixedExecutionRowMappers(version schemaVersion) *executionRowMappers {
+	m := &executionRowMappers{}
+	m.mapper.Store(newExecutionRowMapper(version))
+	return m
+}
+
+// current returns the row mapper of the last schema version read
+func (m *executionRowMappers) current() *executionRowMapper {
+	return m.mapper.Load().(*executionRowMapper)
+}
+
+func (m *executionRowMappers) stop() {
+	if m.shutdownCh != nil {
+		close(m.shutdownCh)
+	}
+}
+
+func (m *executionRowMappers) refreshLoop() {
+	ticker := time.NewTicker(schemaVersionRefreshInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-m.shutdownCh:
+			return
+		case <-ticker.C:
+			m.refresh()
+		}
+	}
+}
+
+// refresh re-reads the schema version, the last version read is kept when it cannot be read
+func (m *executionRowMappers) refresh() {
+	version, err := m.readVersion()
+	if err != nil {
+		m.logger.Warn("Unable to refresh the cassandra schema version, keeping the last version read", tag.Error(err))
+		return
+	}
+	if version != m.current().version {
+		m.logger.Info("Cassandra schema version changed", tag.Value(fmt.Sprintf("%v.%v", version.major, version.minor)))
+		m.mapper.Store(newExecutionRowMapper(version))
+	}
+}
+
+func newExecutionRowMapper(
+	version schemaVersion,
+) *executionRowMapper {
+
+	executionType := udtTemplate(executionRow{}, version)
+	replicationStateType := udtTemplate(replicationStateRow{}, version)
+	return &executionRowMapper{
+		version: version,
+		createWorkflowExecutionQuery: `INSERT INTO executions (` +
+			`shard_id, domain_id, workflow_id, run_id, type, execution, next_event_id, visibility_ts, task_id) ` +
+			`VALUES(?, ?, ?, ?, ?, ` + executionType + `, ?, ?, ?) `,
+		createWorkflowExecutionWithReplicationQuery: `INSERT INTO executions (` +
+			`shard_id, domain_id, workflow_id, run_id, type, execution, replication_state, next_event_id, visibility_ts, task_id) ` +
+			`VALUES(?, ?, ?, ?, ?, ` + executionType + `, ` + replicationStateType + `, ?, ?, ?) `,
+		updateWorkflowExecutionQuery: `UPDATE executions ` +
+			`SET execution = ` + executionType + `, next_event_id = ? ` +
+			templateExecutionRowCondition,
+		updateWorkflowExecutionWithReplicationQuery: `UPDATE executions ` +
+			`SET execution = ` + executionType + `, replication_state = ` + replicationStateType + `, next_event_id = ? ` +
+			templateExecutionRowCondition,
+	}
+}
+
+// executionValues returns the values to bind to the markers of the execution UDT
+func (m *executionRowMapper) executionValues(executionInfo *p.InternalWorkflowExecutionInfo) []interface{} {
+	return udtValues(newExecutionRow(executionInfo), m.version)
+}
+
+// replicationStateValues returns the values to bind to the markers of the replication state UDT
+func (m *executionRowMapper) replicationStateValues(replicationState *p.ReplicationState) []interface{} {
+	return udtValues(newReplicationStateRow(replicationState), m.version)
+}
+
+func newExecutionRow(
+	executionInfo *p.InternalWorkflowExecutionInfo,
+) *executionRow {
+
+	firstExecutionRunID := executionInfo.FirstExecutionRunID
+	if firstExecutionRunID == "" {
+		firstExecutionRunID = emptyRunID
+	}
+
+	resetBaseRunID := executionInfo.ResetBaseRunID
+	if resetBaseRunID == "" {
+		resetBaseRunID = emptyRunID
+	}
+
+	parentDomainID := emptyDomainID
+	parentWorkflowID := ""
+	parentRunID := emptyRunID
+	initiatedID := emptyInitiatedID
+	if executionInfo.ParentDomainID != "" {
+		parentDomainID = executionInfo.ParentDomainID
+		parentWorkflowID = executionInfo.ParentWorkflowID
+		parentRunID = executionInfo.ParentRunID
+		initiatedID = executionInfo.InitiatedID
+	}
+
+	completionData, completionEncoding := p.FromDataBlob(executionInfo.CompletionEvent)
+	return &executionRow{
+		DomainID:                      executionInfo.DomainID,
+		WorkflowID:                    executionInfo.WorkflowID,
+		RunID:                         executionInfo.RunID,
+		FirstExecutionRunID:           firstExecutionRunID,
+		ParentDomainID:                parentDomainID,
+		ParentWorkflowID:              parentWorkflowID,
+		ParentRunID:                   parentRunID,
+		InitiatedID:                   initiatedID,
+		CompletionEventBatchID:        executionInfo.CompletionEventBatchID,
+		CompletionEvent:               completionData,
+		CompletionEventEncoding:       completionEncoding,
+		TaskList:                      executionInfo.TaskList,
+		WorkflowTypeName:              executionInfo.WorkflowTypeName,
+		WorkflowTimeout:               executionInfo.WorkflowTimeout,
+		DecisionTaskTimeout:           executionInfo.DecisionTimeoutValue,
+		ExecutionContext:              executionInfo.ExecutionContext,
+		State:                         executionInfo.State,
+		CloseStatus:                   executionInfo.CloseStatus,
+		LastFirstEventID:              executionInfo.LastFirstEventID,
+		LastEventTaskID:               executionInfo.LastEventTaskID,
+		NextEventID:                   executionInfo.NextEventID,
+		LastProcessedEvent:            executionInfo.LastProcessedEvent,
+		StartTime:                     executionInfo.StartTimestamp,
+		LastUpdatedTime:               executionInfo.LastUpdatedTimestamp,
+		CreateRequestID:               executionInfo.CreateRequestID,
+		SignalCount:                   executionInfo.SignalCount,
+		HistorySize:                   executionInfo.HistorySize,
+		DecisionVersion:               executionInfo.DecisionVersion,
+		DecisionScheduleID:            executionInfo.DecisionScheduleID,
+		DecisionStartedID:             executionInfo.DecisionStartedID,
+		DecisionRequestID:             executionInfo.DecisionRequestID,
+		DecisionTimeout:               executionInfo.DecisionTimeout,
+		DecisionAttempt:               executionInfo.DecisionAttempt,
+		DecisionHeartbeatCount:        executionInfo.DecisionHeartbeatCount,
+		DecisionTimestamp:             executionInfo.DecisionStartedTimestamp,
+		DecisionScheduledTimestamp:    executionInfo.DecisionScheduledTimestamp,
+		CancelRequested:               executionInfo.CancelRequested,
+		CancelRequestID:               executionInfo.CancelRequestID,
+		StickyTaskList:                executionInfo.StickyTaskList,
+		StickyScheduleToStartTimeout:  executionInfo.StickyScheduleToStartTimeout,
+		ClientLibraryVersion:          executionInfo.ClientLibraryVersion,
+		ClientFeatureVersion:          executionInfo.ClientFeatureVersion,
+		ClientImpl:                    executionInfo.ClientImpl,
+		AutoResetPoints:               executionInfo.AutoResetPoints.Data,
+		AutoResetPointsEncoding:       string(executionInfo.AutoResetPoints.GetEncoding()),
+		Attempt:                       executionInfo.Attempt,
+		HasRetryPolicy:                executionInfo.HasRetryPolicy,
+		InitInterval:                  executionInfo.InitialInterval,
+		BackoffCoefficient:            executionInfo.BackoffCoefficient,
+		MaxInterval:                   executionInfo.MaximumInterval,
+		ExpirationTime:                executionInfo.ExpirationTime,
+		MaxAttempts:                   executionInfo.MaximumAttempts,
+		NonRetriableErrors:            executionInfo.NonRetriableErrors,
+		EventStoreVersion:             executionInfo.EventStoreVersion,
+		BranchToken:                   executionInfo.BranchToken,
+		CronSchedule:                  executionInfo.CronSchedule,
+		ExpirationSeconds:             executionInfo.ExpirationSeconds,
+		SearchAttributes:              executionInfo.SearchAttributes,
+		CompletedActivityRequestIDs:   executionInfo.CompletedActivityRequestIDs,
+		Paused:                        executionInfo.Paused,
+		CronPaused:                    executionInfo.CronPaused,
+		CronTimezone:                  executionInfo.CronTimezone,
+		DecisionStartedIdentity:       executionInfo.DecisionStartedIdentity,
+		DecisionStartedBinaryChecksum: executionInfo.DecisionStartedBinaryChecksum,
+		ResetBaseRunID:                resetBaseRunID,
+		ResetBaseEventID:              executionInfo.ResetBaseEventID,
+		ResetReason:                   executionInfo.ResetReason,
+		Notes:                         executionInfo.Notes,
+		NotesIdentity:                 executionInfo.NotesIdentity,
+		NotesLastUpdatedTime:          executionInfo.NotesLastUpdatedTime,
+	}
+}
+
+func (r *executionRow) toExecutionInfo() *p.InternalWorkflowExecutionInfo {
+	info := &p.InternalWorkflowExecutionInfo{
+		DomainID:                      r.DomainID,
+		WorkflowID:                    r.WorkflowID,
+		RunID:                         r.RunID,
+		FirstExecutionRunID:           r.FirstExecutionRunID,
+		ParentDomainID:                r.ParentDomainID,
+		ParentWorkflowID:              r.ParentWorkflowID,
+		ParentRunID:                   r.ParentRunID,
+		InitiatedID:                   r.InitiatedID,
+		CompletionEventBatchID:        r.CompletionEventBatchID,
+		CompletionEvent:               p.NewDataBlob(r.CompletionEvent, common.EncodingType(r.CompletionEventEncoding)),
+		TaskList:                      r.TaskList,
+		WorkflowTypeName:              r.WorkflowTypeName,
+		WorkflowTimeout:               r.WorkflowTimeout,
+		DecisionTimeoutValue:          r.DecisionTaskTimeout,
+		ExecutionContext:              r.ExecutionContext,
+		State:                         r.State,
+		CloseStatus:                   r.CloseStatus,
+		LastFirstEventID:              r.LastFirstEventID,
+		LastEventTaskID:               r.LastEventTaskID,
+		NextEventID:                   r.NextEventID,
+		LastProcessedEvent:            r.LastProcessedEvent,
+		StartTimestamp:                r.StartTime,
+		LastUpdatedTimestamp:          r.LastUpdatedTime,
+		CreateRequestID:               r.CreateRequestID,
+		SignalCount:                   r.SignalCount,
+		HistorySize:                   r.HistorySize,
+		DecisionVersion:               r.DecisionVersion,
+		DecisionScheduleID:            r.DecisionScheduleID,
+		DecisionStartedID:             r.DecisionStartedID,
+		DecisionRequestID:             r.DecisionRequestID,
+		DecisionTimeout:               r.DecisionTimeout,
+		DecisionAttempt:               r.DecisionAttempt,
+		DecisionHeartbeatCount:        r.DecisionHeartbeatCount,
+		DecisionStartedTimestamp:      r.DecisionTimestamp,
+		DecisionScheduledTimestamp:    r.DecisionScheduledTimestamp,
+		CancelRequested:               r.CancelRequested,
+		CancelRequestID:               r.CancelRequestID,
+		StickyTaskList:                r.StickyTaskList,
+		StickyScheduleToStartTimeout:  r.StickyScheduleToStartTimeout,
+		ClientLibraryVersion:          r.ClientLibraryVersion,
+		ClientFeatureVersion:          r.ClientFeatureVersion,
+		ClientImpl:                    r.ClientImpl,
+		AutoResetPoints:               p.NewDataBlob(r.AutoResetPoints, common.EncodingType(r.AutoResetPointsEncoding)),
+		Attempt:                       r.Attempt,
+		HasRetryPolicy:                r.HasRetryPolicy,
+		InitialInterval:               r.InitInterval,
+		BackoffCoefficient:            r.BackoffCoefficient,
+		MaximumInterval:               r.MaxInterval,
+		ExpirationTime:                r.ExpirationTime,
+		MaximumAttempts:               r.MaxAttempts,
+		NonRetriableErrors:            r.NonRetriableErrors,
+		EventStoreVersion:             r.EventStoreVersion,
+		BranchToken:                   r.BranchToken,
+		CronSchedule:                  r.CronSchedule,
+		ExpirationSeconds:             r.ExpirationSeconds,
+		SearchAttributes:              r.SearchAttributes,
+		CompletedActivityRequestIDs:   r.CompletedActivityRequestIDs,
+		Paused:                        r.Paused,
+		CronPaused:                    r.CronPaused,
+		CronTimezone:                  r.CronTimezone,
+		DecisionStartedIdentity:       r.DecisionStartedIdentity,
+		DecisionStartedBinaryChecksum: r.DecisionStartedBinaryChecksum,
+		ResetBaseRunID:                r.ResetBaseRunID,
+		ResetBaseEventID:              r.ResetBaseEventID,
+		ResetReason:                   r.ResetReason,
+		Notes:                         r.Notes,
+		NotesIdentity:                 r.NotesIdentity,
+		NotesLastUpdatedTime:          r.NotesLastUpdatedTime,
+	}
+	if info.FirstExecutionRunID == emptyRunID {
+		info.FirstExecutionRunID = ""
+	}
+	if info.ResetBaseRunID == emptyRunID {
+		info.ResetBaseRunID = ""
+	}
+	if info.ParentDomainID == emptyDomainID {
+		info.ParentDomainID = ""
+	}
+	if info.ParentRunID == emptyRunID {
+		info.ParentRunID = ""
+	}
+	return info
+}
+
+func newReplicationStateRow(
+	replicationState *p.ReplicationState,
+) *replicationStateRow {
+
+	lastReplicationInfo := make(map[string]map[string]interface{})
+	for k, v := range replicationState.LastReplicationInfo {
+		lastReplicationInfo[k] = createReplicationInfoMap(v)
+	}
+	return &replicationStateRow{
+		CurrentVersion:      replicationState.CurrentVersion,
+		StartVersion:        replicationState.StartVersion,
+		LastWriteVersion:    replicationState.LastWriteVersion,
+		LastWriteEventID:    replicationState.LastWriteEventID,
+		LastReplicationInfo: lastReplicationInfo,
+	}
+}
+
+func (r *replicationStateRow) toReplicationState() *p.ReplicationState {
+	state := &p.ReplicationState{
+		CurrentVersion:      r.CurrentVersion,
+		StartVersion:        r.StartVersion,
+		LastWriteVersion:    r.LastWriteVersion,
+		LastWriteEventID:    r.LastWriteEventID,
+		LastReplicationInfo: make(map[string]*p.ReplicationInfo),
+	}
+	for k, v := range r.LastReplicationInfo {
+		state.LastReplicationInfo[k] = createReplicationInfo(v)
+	}
+	return state
+}
+
+// cqlColumns returns the UDT field names the fields of the row struct are bound to in the schema version,
+// in declaration order
+func cqlColumns(row interface{}, version schemaVersion) []string {
+	rowType := reflect.TypeOf(row)
+	columns := make([]string, 0, rowType.NumField())
+	for i := 0; i < rowType.NumField(); i++ {
+		if version.hasField(rowType.Field(i)) {
+			columns = append(columns, rowType.Field(i).Tag.Get(cqlTag))
+		}
+	}
+	return columns
+}
+
+// udtTemplate returns the UDT literal with one bind marker per field of the row struct in the schema version,
+// e.g. `{a: ?, b: ?}`
+func udtTemplate(row interface{}, version schemaVersion) string {
+	columns := cqlColumns(row, version)
+	for i, column := range columns {
+		columns[i] = column + `: ?`
+	}
+	return `{` + strings.Join(columns, `, `) + `}`
+}
+
+// udtValues returns the values to bind to the markers of the row struct udtTemplate
+func udtValues(row interface{}, version schemaVersion) []interface{} {
+	rowValue := reflect.Indirect(reflect.ValueOf(row))
+	rowType := rowValue.Type()
+	values := make([]interface{}, 0, rowValue.NumField())
+	for i := 0; i < rowValue.NumField(); i++ {
+		if version.hasField(rowType.Field(i)) {
+			values = append(values, rowValue.Field(i).Interface())
+		}
+	}
+	return values
+}
+
+// scanUDT sets the fields of the row struct pointer from the UDT value read by gocql. UDT fields without
+// a matching row field are ignored, row fields missing from the UDT or holding a value of an unexpected
+// type keep their zero value, so rows written by an older schema version can still be read.
+func scanUDT(result map[string]interface{}, row interface{}) {
+	rowValue := reflect.ValueOf(row).Elem()
+	rowType := rowValue.Type()
+	for i := 0; i < rowType.NumField(); i++ {
+		value, ok := result[rowType.Field(i).Tag.Get(cqlTag)]
+		if !ok || value == nil {
+			continue
+		}
+		setRowField(rowValue.Field(i), value)
+	}
+}
+
+func setRowField(field reflect.Value, value interface{}) {
+	if uuid, ok := value.(gocql.UUID); ok && field.Kind() == reflect.String {
+		field.SetString(uuid.String())
+		return
+	}
+
+	v := reflect.ValueOf(value)
+	switch {
+	case v.Type().AssignableTo(field.Type()):
+		field.Set(v)
+	case isNumericKind(v.Kind()) && isNumericKind(field.Kind()):
+		field.Set(v.Convert(field.Type()))
+	}
+}
+
+func isNumericKind(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64, reflect.Float32, reflect.Float64:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,377 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cassandra
+
+import (
+	"errors"
+	"io/ioutil"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gocql/gocql"
+	"github.com/stretchr/testify/suite"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/log/loggerimpl"
+	p "github.com/uber/cadence/common/persistence"
+)
+
+type (
+	rowMapperSuite struct {
+		suite.Suite
+	}
+)
+
+const (
+	testSchemaFile         = "../../../schema/cassandra/cadence/schema.cql"
+	testVersionedSchemaDir = "../../../schema/cassandra/cadence/versioned/"
+)
+
+func TestRowMapperSuite(t *testing.T) {
+	suite.Run(t, new(rowMapperSuite))
+}
+
+func (s *rowMapperSuite) TestUDTTemplate() {
+	row := struct {
+		A int    `cql:"a"`
+		B string `cql:"b"`
+	}{A: 1, B: "b"}
+
+	s.Equal(`{a: ?, b: ?}`, udtTemplate(row, latestSchemaVersion))
+	s.Equal([]interface{}{1, "b"}, udtValues(row, latestSchemaVersion))
+	s.Equal([]interface{}{1, "b"}, udtValues(&row, latestSchemaVersion))
+}
+
+func (s *rowMapperSuite) TestUDTTemplate_SchemaVersion() {
+	row := struct {
+		A int    `cql:"a"`
+		B string `cql:"b" since:"0.12"`
+	}{A: 1, B: "b"}
+
+	s.Equal(`{a: ?}`, udtTemplate(row, schemaVersion{major: 0, minor: 11}))
+	s.Equal([]interface{}{1}, udtValues(row, schemaVersion{major: 0, minor: 11}))
+	s.Equal(`{a: ?, b: ?}`, udtTemplate(row, schemaVersion{major: 0, minor: 12}))
+	s.Equal([]interface{}{1, "b"}, udtValues(row, schemaVersion{major: 1, minor: 0}))
+}
+
+func (s *rowMapperSuite) TestParseSchemaVersion() {
+	version, err := parseSchemaVersion("0.31")
+	s.NoError(err)
+	s.Equal(schemaVersion{major: 0, minor: 31}, version)
+	s.True(schemaVersion{major: 0, minor: 9}.before(version))
+	s.False(version.before(schemaVersion{major: 0, minor: 9}))
+
+	_, err = parseSchemaVersion("0")
+	s.Error(err)
+	_, err = parseSchemaVersion("0.x")
+	s.Error(err)
+}
+
+func (s *rowMapperSuite) TestExecutionRowMappers_Refresh() {
+	version := schemaVersion{major: 0, minor: 30}
+	var readErr error
+	mappers, err := newExecutionRowMappers(func() (schemaVersion, error) {
+		return version, readErr
+	}, loggerimpl.NewNopLogger())
+	s.NoError(err)
+	defer mappers.stop()
+	s.NotContains(mappers.current().updateWorkflowExecutionQuery, "paused")
+
+	// the fields of the upgraded schema are written once the version is re-read
+	version = latestSchemaVersion
+	mappers.refresh()
+	s.Contains(mappers.current().updateWorkflowExecutionQuery, "paused")
+
+	// an unreadable version keeps the last version read
+	readErr = errors.New("unavailable")
+	mappers.refresh()
+	s.Equal(latestSchemaVersion, mappers.current().version)
+
+	_, err = newExecutionRowMappers(func() (schemaVersion, error) {
+		return schemaVersion{}, readErr
+	}, loggerimpl.NewNopLogger())
+	s.Error(err)
+}
+
+func (s *rowMapperSuite) TestRowsMatchSchema() {
+	s.ElementsMatch(s.schemaColumnNames("workflow_execution"), cqlColumns(executionRow{}, latestSchemaVersion))
+	s.ElementsMatch(s.schemaColumnNames("replication_state"), cqlColumns(replicationStateRow{}, latestSchemaVersion))
+}
+
+func (s *rowMapperSuite) TestSinceTagsMatchSchemaUpgrades() {
+	rowType := reflect.TypeOf(executionRow{})
+	for i := 0; i < rowType.NumField(); i++ {
+		field := rowType.Field(i)
+		since := field.Tag.Get(sinceTag)
+		if since == "" {
+			continue
+		}
+		upgrade := s.readVersionedSchema(since)
+		s.Contains(string(upgrade), "ALTER TYPE workflow_execution ADD "+field.Tag.Get(cqlTag)+" ", field.Name)
+	}
+}
+
+func (s *rowMapperSuite) TestExecutionRowRoundTrip_OlderSchema() {
+	mapper := newExecutionRowMapper(schemaVersion{major: 0, minor: 30})
+	info := s.newExecutionInfo()
+
+	s.Equal(strings.Count(mapper.createWorkflowExecutionQuery, "?"), len(mapper.executionValues(info))+8)
+	s.NotContains(mapper.createWorkflowExecutionQuery, "cron_paused")
+	s.NotContains(mapper.updateWorkflowExecutionQuery, "paused")
+	s.Contains(mapper.updateWorkflowExecutionQuery, "first_execution_run_id")
+
+	// the row written by the older schema has neither the paused nor the cron paused field
+	result := s.readUDT("workflow_execution", newExecutionRow(info))
+	delete(result, "paused")
+	delete(result, "cron_paused")
+	readInfo := createWorkflowExecutionInfo(result)
+	s.False(readInfo.Paused)
+	s.False(readInfo.CronPaused)
+	s.Equal(info.FirstExecutionRunID, readInfo.FirstExecutionRunID)
+}
+
+func (s *rowMapperSuite) TestScanUDT_Lenient() {
+	result := s.readUDT("workflow_execution", newExecutionRow(s.newExecutionInfo()))
+	result["state"] = "running"
+	result["next_event_id"] = nil
+	delete(result, "task_list")
+
+	var info *p.InternalWorkflowExecutionInfo
+	s.NotPanics(func() { info = createWorkflowExecutionInfo(result) })
+	s.Equal(0, info.State)
+	s.Equal(int64(0), info.NextEventID)
+	s.Equal("", info.TaskList)
+	s.Equal("row-mapper-workflow", info.WorkflowID)
+
+	s.NotPanics(func() { createWorkflowExecutionInfo(nil) })
+}
+
+func (s *rowMapperSuite) TestVisibilityTimestampEncoding() {
+	s.NoError(validateTimestampEncoding())
+
+	schema, err := ioutil.ReadFile(testSchemaFile)
+	s.NoError(err)
+	executions := regexp.MustCompile(`(?s)CREATE TABLE executions \((.*?)\n\)`).FindSubmatch(schema)
+	s.NotNil(executions)
+	s.Regexp(`(?m)^\s*visibility_ts\s+timestamp\b`, string(executions[1]))
+}
+
+func (s *rowMapperSuite) TestExecutionRowRoundTrip() {
+	info := s.newExecutionInfo()
+	result := s.readUDT("workflow_execution", newExecutionRow(info))
+	s.Equal(info, createWorkflowExecutionInfo(result))
+}
+
+func (s *rowMapperSuite) TestExecutionRowRoundTrip_NoParent() {
+	info := s.newExecutionInfo()
+	info.ParentDomainID = ""
+	info.ParentWorkflowID = ""
+	info.ParentRunID = ""
+	info.FirstExecutionRunID = ""
+
+	row := newExecutionRow(info)
+	s.Equal(emptyRunID, row.FirstExecutionRunID)
+	s.Equal(emptyDomainID, row.ParentDomainID)
+	s.Equal(emptyRunID, row.ParentRunID)
+	s.Equal(emptyInitiatedID, row.InitiatedID)
+
+	readInfo := createWorkflowExecutionInfo(s.readUDT("workflow_execution", row))
+	s.Equal("", readInfo.ParentDomainID)
+	s.Equal("", readInfo.ParentWorkflowID)
+	s.Equal("", readInfo.ParentRunID)
+	s.Equal("", readInfo.FirstExecutionRunID)
+}
+
+func (s *rowMapperSuite) TestReplicationStateRowRoundTrip() {
+	state := &p.ReplicationState{
+		CurrentVersion:   10,
+		StartVersion:     2,
+		LastWriteVersion: 9,
+		LastWriteEventID: 123,
+		LastReplicationInfo: map[string]*p.ReplicationInfo{
+			"standby": {Version: 8, LastEventID: 100},
+		},
+	}
+	result := s.readUDT("replication_state", newReplicationStateRow(state))
+	s.Equal(state, createReplicationState(result))
+}
+
+func (s *rowMapperSuite) TestExecutionQueryBindings() {
+	info := s.newExecutionInfo()
+	info.State = p.WorkflowStateCreated
+	info.CloseStatus = p.WorkflowCloseStatusNone
+	state := &p.ReplicationState{LastReplicationInfo: map[string]*p.ReplicationInfo{}}
+
+	mapper := newExecutionRowMapper(latestSchemaVersion)
+	batch := gocql.NewBatch(gocql.LoggedBatch)
+	s.NoError(createExecution(batch, 1, mapper, info, nil, 0))
+	s.NoError(createExecution(batch, 1, mapper, info, state, 0))
+	info.State = p.WorkflowStateRunning
+	s.NoError(updateExecution(batch, 1, mapper, info, nil, 0, 5))
+	s.NoError(updateExecution(batch, 1, mapper, info, state, 0, 5))
+
+	s.Len(batch.Entries, 4)
+	for _, entry := range batch.Entries {
+		s.Equal(strings.Count(entry.Stmt, "?"), len(entry.Args), entry.Stmt)
+	}
+}
+
+func (s *rowMapperSuite) newExecutionInfo() *p.InternalWorkflowExecutionInfo {
+	now := time.Unix(1500000000, 0)
+	return &p.InternalWorkflowExecutionInfo{
+		DomainID:                      "4b6ee4ba-9e08-4b7e-b1b5-6c7f4a5c5f01",
+		WorkflowID:                    "row-mapper-workflow",
+		RunID:                         "4b6ee4ba-9e08-4b7e-b1b5-6c7f4a5c5f02",
+		FirstExecutionRunID:           "4b6ee4ba-9e08-4b7e-b1b5-6c7f4a5c5f06",
+		ParentDomainID:                "4b6ee4ba-9e08-4b7e-b1b5-6c7f4a5c5f03",
+		ParentWorkflowID:              "row-mapper-parent",
+		ParentRunID:                   "4b6ee4ba-9e08-4b7e-b1b5-6c7f4a5c5f04",
+		InitiatedID:                   12,
+		CompletionEventBatchID:        13,
+		CompletionEvent:               p.NewDataBlob([]byte("completion"), common.EncodingTypeThriftRW),
+		TaskList:                      "task-list",
+		WorkflowTypeName:              "workflow-type",
+		WorkflowTimeout:               100,
+		DecisionTimeoutValue:          10,
+		ExecutionContext:              []byte("context"),
+		State:                         p.WorkflowStateRunning,
+		CloseStatus:                   p.WorkflowCloseStatusNone,
+		LastFirstEventID:              20,
+		LastEventTaskID:               21,
+		NextEventID:                   22,
+		LastProcessedEvent:            19,
+		StartTimestamp:                now,
+		LastUpdatedTimestamp:          now.Add(time.Minute),
+		CreateRequestID:               "4b6ee4ba-9e08-4b7e-b1b5-6c7f4a5c5f05",
+		SignalCount:                   3,
+		HistorySize:                   4096,
+		DecisionVersion:               1,
+		DecisionScheduleID:            23,
+		DecisionStartedID:             24,
+		DecisionRequestID:             "decision-request-id",
+		DecisionTimeout:               10,
+		DecisionAttempt:               2,
+		DecisionHeartbeatCount:        3,
+		DecisionStartedTimestamp:      now.UnixNano(),
+		DecisionScheduledTimestamp:    now.UnixNano() - 1,
+		CancelRequested:               true,
+		CancelRequestID:               "cancel-request-id",
+		StickyTaskList:                "sticky-task-list",
+		StickyScheduleToStartTimeout:  5,
+		ClientLibraryVersion:          "0.7.0",
+		ClientFeatureVersion:          "1.0.0",
+		ClientImpl:                    "uber-go",
+		AutoResetPoints:               p.NewDataBlob([]byte("reset-points"), common.EncodingTypeThriftRW),
+		Attempt:                       1,
+		HasRetryPolicy:                true,
+		InitialInterval:               1,
+		BackoffCoefficient:            2.0,
+		MaximumInterval:               30,
+		ExpirationTime:                now.Add(time.Hour),
+		MaximumAttempts:               5,
+		NonRetriableErrors:            []string{"bad-error"},
+		EventStoreVersion:             p.EventStoreVersionV2,
+		BranchToken:                   []byte("branch-token"),
+		CronSchedule:                  "@every 1h",
+		ExpirationSeconds:             3600,
+		SearchAttributes:              map[string][]byte{"CustomKeywordField": []byte(`"value"`)},
+		CompletedActivityRequestIDs:   []string{"activity-request-id"},
+		Paused:                        true,
+		CronPaused:                    true,
+		CronTimezone:                  "America/New_York",
+		DecisionStartedIdentity:       "worker-identity",
+		DecisionStartedBinaryChecksum: "binary-checksum",
+		ResetBaseRunID:                "5c4f1c43-5a6b-4f51-9ac3-31b9f3bfa5f5",
+		ResetBaseEventID:              7,
+		ResetReason:                   "bad deployment",
+		Notes:                         "being investigated, do not terminate",
+		NotesIdentity:                 "operator",
+		NotesLastUpdatedTime:          now.Add(time.Minute),
+	}
+}
+
+// readUDT emulates gocql reading back the UDT written with the row values
+func (s *rowMapperSuite) readUDT(udtName string, row interface{}) map[string]interface{} {
+	columnTypes := s.schemaColumnTypes(udtName)
+	columns := cqlColumns(reflect.Indirect(reflect.ValueOf(row)).Interface(), latestSchemaVersion)
+	values := udtValues(row, latestSchemaVersion)
+
+	result := make(map[string]interface{}, len(columns))
+	for i, column := range columns {
+		value := values[i]
+		switch columnTypes[column] {
+		case "uuid":
+			uuid, err := gocql.ParseUUID(value.(string))
+			s.NoError(err)
+			value = uuid
+		case "int":
+			value = int(reflect.ValueOf(value).Int())
+		}
+		result[column] = value
+	}
+	return result
+}
+
+func (s *rowMapperSuite) schemaColumnNames(udtName string) []string {
+	var names []string
+	for name := range s.schemaColumnTypes(udtName) {
+		names = append(names, name)
+	}
+	return names
+}
+
+// schemaColumnTypes returns the cql types of the fields of the UDT declared in schema.cql
+func (s *rowMapperSuite) schemaColumnTypes(udtName string) map[string]string {
+	schema, err := ioutil.ReadFile(testSchemaFile)
+	s.NoError(err)
+
+	match := regexp.MustCompile(`(?s)CREATE TYPE ` + udtName + ` \((.*?)\n\);`).FindSubmatch(schema)
+	s.NotNil(match, udtName)
+
+	columnTypes := make(map[string]string)
+	for _, line := range strings.Split(string(match[1]), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 2 || strings.HasPrefix(fields[0], "--") {
+			continue
+		}
+		columnTypes[fields[0]] = strings.TrimSuffix(fields[1], ",")
+	}
+	return columnTypes
+}
+
+// readVersionedSchema returns the concatenated cql files of the schema version
+func (s *rowMapperSuite) readVersionedSchema(version string) []byte {
+	dir := testVersionedSchemaDir + "v" + version + "/"
+	files, err := ioutil.ReadDir(dir)
+	s.NoError(err)
+
+	var schema []byte
+	for _, file := range files {
+		if strings.HasSuffix(file.Name(), ".cql") {
+			content, err := ioutil.ReadFile(dir + file.Name())
+			s.NoError(err)
+			schema = append(schema, content...)
+		}
+	}
+	return schema
+}
@@ -0,0 +1,280 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cassandra
+
+import (
+	"fmt"
+
+	"github.com/gocql/gocql"
+	workflow "github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common/log"
+	p "github.com/uber/cadence/common/persistence"
+	"github.com/uber/cadence/common/service/config"
+)
+
+const (
+	templateScheduleColumns = `domain_id, schedule_id, cron_schedule, workflow_type_name, task_list, input, ` +
+		`execution_timeout, task_timeout, overlap_policy, catchup_window, paused, last_fire_time, ` +
+		`last_workflow_id, last_run_id, backfill_id, backfill_start_time, backfill_end_time, ` +
+		`backfill_runs_per_minute, backfill_last_run_time, last_backfill_workflow_id, last_backfill_run_id, ` +
+		`identity, version`
+
+	templateCreateScheduleQuery = `INSERT INTO schedules (` + templateScheduleColumns + `) ` +
+		`VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?) IF NOT EXISTS`
+
+	templateGetScheduleQuery = `SELECT ` + templateScheduleColumns + ` ` +
+		`FROM schedules ` +
+		`WHERE domain_id = ? ` +
+		`and schedule_id = ?`
+
+	templateListSchedulesQuery = `SELECT ` + templateScheduleColumns + ` ` +
+		`FROM schedules`
+
+	templateListSchedulesByDomainQuery = templateListSchedulesQuery + ` ` +
+		`WHERE domain_id = ?`
+
+	templateUpdateScheduleQuery = `UPDATE schedules ` +
+		`SET cron_schedule = ?, ` +
+		`workflow_type_name = ?, ` +
+		`task_list = ?, ` +
+		`input = ?, ` +
+		`execution_timeout = ?, ` +
+		`task_timeout = ?, ` +
+		`overlap_policy = ?, ` +
+		`catchup_window = ?, ` +
+		`paused = ?, ` +
+		`last_fire_time = ?, ` +
+		`last_workflow_id = ?, ` +
+		`last_run_id = ?, ` +
+		`backfill_id = ?, ` +
+		`backfill_start_time = ?, ` +
+		`backfill_end_time = ?, ` +
+		`backfill_runs_per_minute = ?, ` +
+		`backfill_last_run_time = ?, ` +
+		`last_backfill_workflow_id = ?, ` +
+		`last_backfill_run_id = ?, ` +
+		`identity = ?, ` +
+		`version = ? ` +
+		`WHERE domain_id = ? ` +
+		`and schedule_id = ? ` +
+		`IF version = ?`
+
+	templateDeleteScheduleQuery = `DELETE FROM schedules ` +
+		`WHERE domain_id = ? ` +
+		`and schedule_id = ?`
+)
+
+type (
+	cassandraSchedulePersistence struct {
+		cassandraStore
+	}
+)
+
+var _ p.ScheduleStore = (*cassandraSchedulePersistence)(nil)
+
+// newSchedulePersistence is used to create an instance of ScheduleManager implementation
+func newSchedulePersistence(cfg config.Cassandra, logger log.Logger) (p.ScheduleStore, error) {
+	cluster := NewCassandraCluster(cfg.Hosts, cfg.Port, cfg.User, cfg.Password, cfg.Datacenter, cfg.Compression)
+	cluster.Keyspace = cfg.Keyspace
+	cluster.ProtoVersion = cassandraProtoVersion
+	cluster.Consistency = gocql.LocalQuorum
+	cluster.SerialConsistency = gocql.LocalSerial
+	cluster.Timeout = defaultSessionTimeout
+
+	session, err := cluster.CreateSession()
+	if err != nil {
+		return nil, err
+	}
+
+	return &cassandraSchedulePersistence{cassandraStore: cassandraStore{session: session, logger: logger}}, nil
+}
+
+// CreateSchedule creates a schedule, or fails with ConditionFailedError if the schedule already exists
+func (d *cassandraSchedulePersistence) CreateSchedule(request *p.CreateScheduleRequest) error {
+	s := request.Schedule
+	query := d.session.Query(templateCreateScheduleQuery,
+		s.DomainID,
+		s.ScheduleID,
+		s.CronSchedule,
+		s.WorkflowTypeName,
+		s.TaskList,
+		s.Input,
+		s.ExecutionStartToCloseTimeoutSeconds,
+		s.TaskStartToCloseTimeoutSeconds,
+		s.OverlapPolicy,
+		s.CatchupWindowSeconds,
+		s.Paused,
+		s.LastFireTime,
+		s.LastWorkflowID,
+		s.LastRunID,
+		s.BackfillID,
+		s.BackfillStartTime,
+		s.BackfillEndTime,
+		s.BackfillRunsPerMinute,
+		s.BackfillLastRunTime,
+		s.LastBackfillWorkflowID,
+		s.LastBackfillRunID,
+		s.Identity,
+		s.Version)
+
+	applied, err := query.MapScanCAS(make(map[string]interface{}))
+	if err != nil {
+		return convertCommonErrors("CreateSchedule", err)
+	}
+	if !applied {
+		return &p.ConditionFailedError{
+			Msg: fmt.Sprintf("Schedule already exists.  ScheduleId: %v", s.ScheduleID),
+		}
+	}
+	return nil
+}
+
+// GetSchedule returns a schedule, or EntityNotExistsError if there is none
+func (d *cassandraSchedulePersistence) GetSchedule(request *p.GetScheduleRequest) (*p.GetScheduleResponse, error) {
+	query := d.session.Query(templateGetScheduleQuery,
+		request.DomainID,
+		request.ScheduleID)
+
+	s := &p.ScheduleInfo{}
+	if err := query.Scan(scheduleScanArgs(s)...); err != nil {
+		if err == gocql.ErrNotFound {
+			return nil, &workflow.EntityNotExistsError{
+				Message: fmt.Sprintf("Schedule not found.  ScheduleId: %v", request.ScheduleID),
+			}
+		}
+		return nil, convertCommonErrors("GetSchedule", err)
+	}
+
+	return &p.GetScheduleResponse{Schedule: s}, nil
+}
+
+// UpdateSchedule updates a schedule if its version has not changed since it was read
+func (d *cassandraSchedulePersistence) UpdateSchedule(request *p.UpdateScheduleRequest) error {
+	s := request.Schedule
+	query := d.session.Query(templateUpdateScheduleQuery,
+		s.CronSchedule,
+		s.WorkflowTypeName,
+		s.TaskList,
+		s.Input,
+		s.ExecutionStartToCloseTimeoutSeconds,
+		s.TaskStartToCloseTimeoutSeconds,
+		s.OverlapPolicy,
+		s.CatchupWindowSeconds,
+		s.Paused,
+		s.LastFireTime,
+		s.LastWorkflowID,
+		s.LastRunID,
+		s.BackfillID,
+		s.BackfillStartTime,
+		s.BackfillEndTime,
+		s.BackfillRunsPerMinute,
+		s.BackfillLastRunTime,
+		s.LastBackfillWorkflowID,
+		s.LastBackfillRunID,
+		s.Identity,
+		s.Version,
+		s.DomainID,
+		s.ScheduleID,
+		request.PreviousVersion)
+
+	previous := make(map[string]interface{})
+	applied, err := query.MapScanCAS(previous)
+	if err != nil {
+		return convertCommonErrors("UpdateSchedule", err)
+	}
+	if !applied {
+		return &p.ConditionFailedError{
+			Msg: fmt.Sprintf("Failed to update schedule.  ScheduleId: %v, expected version: %v, actual version: %v",
+				s.ScheduleID, request.PreviousVersion, previous["version"]),
+		}
+	}
+	return nil
+}
+
+// DeleteSchedule deletes a schedule
+func (d *cassandraSchedulePersistence) DeleteSchedule(request *p.DeleteScheduleRequest) error {
+	query := d.session.Query(templateDeleteScheduleQuery,
+		request.DomainID,
+		request.ScheduleID)
+	if err := query.Exec(); err != nil {
+		return convertCommonErrors("DeleteSchedule", err)
+	}
+	return nil
+}
+
+// ListSchedules returns a page of the schedules of a domain, or of every domain if no domain is given
+func (d *cassandraSchedulePersistence) ListSchedules(request *p.ListSchedulesRequest) (*p.ListSchedulesResponse, error) {
+	var query *gocql.Query
+	if len(request.DomainID) == 0 {
+		query = d.session.Query(templateListSchedulesQuery)
+	} else {
+		query = d.session.Query(templateListSchedulesByDomainQuery, request.DomainID)
+	}
+	iter := query.PageSize(request.PageSize).PageState(request.NextPageToken).Iter()
+	if iter == nil {
+		return nil, &workflow.InternalServiceError{
+			Message: "ListSchedules operation failed.  Not able to create query iterator.",
+		}
+	}
+
+	response := &p.ListSchedulesResponse{}
+	s := &p.ScheduleInfo{}
+	for iter.Scan(scheduleScanArgs(s)...) {
+		response.Schedules = append(response.Schedules, s)
+		s = &p.ScheduleInfo{}
+	}
+
+	nextPageToken := iter.PageState()
+	response.NextPageToken = make([]byte, len(nextPageToken))
+	copy(response.NextPageToken, nextPageToken)
+	if err := iter.Close(); err != nil {
+		return nil, convertCommonErrors("ListSchedules", err)
+	}
+	return response, nil
+}
+
+func scheduleScanArgs(s *p.ScheduleInfo) []interface{} {
+	return []interface{}{
+		&s.DomainID,
+		&s.ScheduleID,
+		&s.CronSchedule,
+		&s.WorkflowTypeName,
+		&s.TaskList,
+		&s.Input,
+		&s.ExecutionStartToCloseTimeoutSeconds,
+		&s.TaskStartToCloseTimeoutSeconds,
+		&s.OverlapPolicy,
+		&s.CatchupWindowSeconds,
+		&s.Paused,
+		&s.LastFireTime,
+		&s.LastWorkflowID,
+		&s.LastRunID,
+		&s.BackfillID,
+		&s.BackfillStartTime,
+		&s.BackfillEndTime,
+		&s.BackfillRunsPerMinute,
+		&s.BackfillLastRunTime,
+		&s.LastBackfillWorkflowID,
+		&s.LastBackfillRunID,
+		&s.Identity,
+		&s.Version,
+	}
+}
@@ -0,0 +1,99 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cassandra
+
+import (
+	"github.com/uber/cadence/common/log"
+	p "github.com/uber/cadence/common/persistence"
+	"github.com/uber/cadence/common/service/config"
+)
+
+type (
+	// shardManagerProxy routes the shard operations to the store of the shard range of the shard
+	shardManagerProxy struct {
+		defaultStore p.ShardStore
+		ranges       []shardStoreRange
+	}
+
+	shardStoreRange struct {
+		minShardID int
+		maxShardID int
+		store      p.ShardStore
+	}
+)
+
+// newShardManagerProxy is used to create a shard store spanning the shard ranges of the config,
+// a single cluster shard store is returned when no range is configured
+func newShardManagerProxy(cfg config.Cassandra, clusterName string, logger log.Logger) (p.ShardStore, error) {
+	defaultStore, err := newShardPersistence(cfg, clusterName, logger)
+	if err != nil {
+		return nil, err
+	}
+	if len(cfg.ShardRanges) == 0 {
+		return defaultStore, nil
+	}
+	proxy := &shardManagerProxy{defaultStore: defaultStore}
+	for _, r := range cfg.ShardRanges {
+		store, err := newShardPersistence(r.Cassandra, clusterName, logger)
+		if err != nil {
+			proxy.Close()
+			return nil, err
+		}
+		proxy.ranges = append(proxy.ranges, shardStoreRange{
+			minShardID: r.MinShardID,
+			maxShardID: r.MaxShardID,
+			store:      store,
+		})
+	}
+	return proxy, nil
+}
+
+func (s *shardManagerProxy) GetName() string {
+	return cassandraPersistenceName
+}
+
+func (s *shardManagerProxy) CreateShard(request *p.CreateShardRequest) error {
+	return s.storeOf(request.ShardInfo.ShardID).CreateShard(request)
+}
+
+func (s *shardManagerProxy) GetShard(request *p.GetShardRequest) (*p.GetShardResponse, error) {
+	return s.storeOf(request.ShardID).GetShard(request)
+}
+
+func (s *shardManagerProxy) UpdateShard(request *p.UpdateShardRequest) error {
+	return s.storeOf(request.ShardInfo.ShardID).UpdateShard(request)
+}
+
+func (s *shardManagerProxy) Close() {
+	s.defaultStore.Close()
+	for _, r := range s.ranges {
+		r.store.Close()
+	}
+}
+
+func (s *shardManagerProxy) storeOf(shardID int) p.ShardStore {
+	for _, r := range s.ranges {
+		if shardID >= r.minShardID && shardID <= r.maxShardID {
+			return r.store
+		}
+	}
+	return s.defaultStore
+}
@@ -0,0 +1,124 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cassandra
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/mocks"
+	p "github.com/uber/cadence/common/persistence"
+)
+
+type (
+	shardManagerProxySuite struct {
+		suite.Suite
+	}
+
+	// historyV2StoreRecorder records the shards of the history branch reads it serves
+	historyV2StoreRecorder struct {
+		p.HistoryV2Store
+		shardIDs []int
+	}
+)
+
+func TestShardManagerProxySuite(t *testing.T) {
+	suite.Run(t, new(shardManagerProxySuite))
+}
+
+func (s *shardManagerProxySuite) TestRoutesByShardRange() {
+	defaultStore := &mocks.ShardManager{}
+	rangeStore := &mocks.ShardManager{}
+	proxy := &shardManagerProxy{
+		defaultStore: defaultStore,
+		ranges:       []shardStoreRange{{minShardID: 1024, maxShardID: 2047, store: rangeStore}},
+	}
+
+	for _, shardID := range []int{0, 1023, 2048} {
+		request := &p.GetShardRequest{ShardID: shardID}
+		defaultStore.On("GetShard", request).Return(&p.GetShardResponse{}, nil).Once()
+		_, err := proxy.GetShard(request)
+		s.NoError(err)
+	}
+	for _, shardID := range []int{1024, 2047} {
+		request := &p.UpdateShardRequest{ShardInfo: &p.ShardInfo{ShardID: shardID}}
+		rangeStore.On("UpdateShard", request).Return(nil).Once()
+		s.NoError(proxy.UpdateShard(request))
+	}
+
+	defaultStore.AssertExpectations(s.T())
+	rangeStore.AssertExpectations(s.T())
+}
+
+func (s *shardManagerProxySuite) TestHistoryV2RoutesByShardRange() {
+	defaultStore := &historyV2StoreRecorder{}
+	rangeStore := &historyV2StoreRecorder{}
+	proxy := &historyV2ManagerProxy{
+		defaultStore: defaultStore,
+		ranges:       []historyV2StoreRange{{minShardID: 1024, maxShardID: 2047, store: rangeStore}},
+	}
+
+	for _, shardID := range []int{0, 1023, 1024, 2047, 2048} {
+		_, err := proxy.ReadHistoryBranch(&p.InternalReadHistoryBranchRequest{ShardID: shardID})
+		s.NoError(err)
+	}
+	s.Equal([]int{0, 1023, 2048}, defaultStore.shardIDs)
+	s.Equal([]int{1024, 2047}, rangeStore.shardIDs)
+}
+
+func (s *shardManagerProxySuite) TestTaskRoutesByTaskListShard() {
+	defaultStore := &mocks.TaskManager{}
+	rangeStore := &mocks.TaskManager{}
+	numHistoryShards := 4
+	domainID := "domainID"
+	taskListName := "taskList"
+	shardID := common.WorkflowIDToHistoryShard(domainID+taskListName, numHistoryShards)
+	proxy := &taskManagerProxy{
+		defaultStore:     defaultStore,
+		ranges:           []taskStoreRange{{minShardID: shardID, maxShardID: shardID, store: rangeStore}},
+		numHistoryShards: numHistoryShards,
+	}
+
+	getRequest := &p.GetTasksRequest{DomainID: domainID, TaskList: taskListName}
+	rangeStore.On("GetTasks", getRequest).Return(&p.GetTasksResponse{}, nil).Once()
+	_, err := proxy.GetTasks(getRequest)
+	s.NoError(err)
+	completeRequest := &p.CompleteTaskRequest{TaskList: &p.TaskListInfo{DomainID: domainID, Name: taskListName}}
+	rangeStore.On("CompleteTask", completeRequest).Return(nil).Once()
+	s.NoError(proxy.CompleteTask(completeRequest))
+
+	proxy.ranges[0].minShardID = shardID + 1
+	proxy.ranges[0].maxShardID = shardID + 1
+	defaultStore.On("GetTasks", getRequest).Return(&p.GetTasksResponse{}, nil).Once()
+	_, err = proxy.GetTasks(getRequest)
+	s.NoError(err)
+
+	defaultStore.AssertExpectations(s.T())
+	rangeStore.AssertExpectations(s.T())
+}
+
+func (r *historyV2StoreRecorder) ReadHistoryBranch(
+	request *p.InternalReadHistoryBranchRequest,
+) (*p.InternalReadHistoryBranchResponse, error) {
+	r.shardIDs = append(r.shardIDs, request.ShardID)
+	return &p.InternalReadHistoryBranchResponse{}, nil
+}
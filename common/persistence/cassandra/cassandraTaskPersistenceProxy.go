@@ -0,0 +1,137 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package cassandra
+
+import (
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/log"
+	p "github.com/uber/cadence/common/persistence"
+	"github.com/uber/cadence/common/service/config"
+)
+
+type (
+	// taskManagerProxy routes the task list operations to the store of the shard range of the task list,
+	// task lists are not owned by history shards so they are spread over the shard ranges by the history
+	// shard their domain and name hash to
+	taskManagerProxy struct {
+		defaultStore     p.TaskStore
+		ranges           []taskStoreRange
+		numHistoryShards int
+	}
+
+	taskStoreRange struct {
+		minShardID int
+		maxShardID int
+		store      p.TaskStore
+	}
+)
+
+// newTaskManagerProxy is used to create a task store spanning the shard ranges of the config,
+// a single cluster task store is returned when no range is configured
+func newTaskManagerProxy(cfg config.Cassandra, numHistoryShards int, logger log.Logger) (p.TaskStore, error) {
+	defaultStore, err := newTaskPersistence(cfg, logger)
+	if err != nil {
+		return nil, err
+	}
+	if len(cfg.ShardRanges) == 0 {
+		return defaultStore, nil
+	}
+	proxy := &taskManagerProxy{defaultStore: defaultStore, numHistoryShards: numHistoryShards}
+	for _, r := range cfg.ShardRanges {
+		store, err := newTaskPersistence(r.Cassandra, logger)
+		if err != nil {
+			proxy.Close()
+			return nil, err
+		}
+		proxy.ranges = append(proxy.ranges, taskStoreRange{
+			minShardID: r.MinShardID,
+			maxShardID: r.MaxShardID,
+			store:      store,
+		})
+	}
+	return proxy, nil
+}
+
+func (t *taskManagerProxy) GetName() string {
+	return cassandraPersistenceName
+}
+
+// Ping pings the store of every shard range
+func (t *taskManagerProxy) Ping() error {
+	if err := t.defaultStore.Ping(); err != nil {
+		return err
+	}
+	for _, r := range t.ranges {
+		if err := r.store.Ping(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (t *taskManagerProxy) LeaseTaskList(request *p.LeaseTaskListRequest) (*p.LeaseTaskListResponse, error) {
+	return t.storeOf(request.DomainID, request.TaskList).LeaseTaskList(request)
+}
+
+func (t *taskManagerProxy) UpdateTaskList(request *p.UpdateTaskListRequest) (*p.UpdateTaskListResponse, error) {
+	return t.storeOf(request.TaskListInfo.DomainID, request.TaskListInfo.Name).UpdateTaskList(request)
+}
+
+func (t *taskManagerProxy) ListTaskList(request *p.ListTaskListRequest) (*p.ListTaskListResponse, error) {
+	// listing task lists is not supported by cassandra, the default store returns the error
+	return t.defaultStore.ListTaskList(request)
+}
+
+func (t *taskManagerProxy) DeleteTaskList(request *p.DeleteTaskListRequest) error {
+	return t.storeOf(request.DomainID, request.TaskListName).DeleteTaskList(request)
+}
+
+func (t *taskManagerProxy) CreateTasks(request *p.CreateTasksRequest) (*p.CreateTasksResponse, error) {
+	return t.storeOf(request.TaskListInfo.DomainID, request.TaskListInfo.Name).CreateTasks(request)
+}
+
+func (t *taskManagerProxy) GetTasks(request *p.GetTasksRequest) (*p.GetTasksResponse, error) {
+	return t.storeOf(request.DomainID, request.TaskList).GetTasks(request)
+}
+
+func (t *taskManagerProxy) CompleteTask(request *p.CompleteTaskRequest) error {
+	return t.storeOf(request.TaskList.DomainID, request.TaskList.Name).CompleteTask(request)
+}
+
+func (t *taskManagerProxy) CompleteTasksLessThan(request *p.CompleteTasksLessThanRequest) (int, error) {
+	return t.storeOf(request.DomainID, request.TaskListName).CompleteTasksLessThan(request)
+}
+
+func (t *taskManagerProxy) Close() {
+	t.defaultStore.Close()
+	for _, r := range t.ranges {
+		r.store.Close()
+	}
+}
+
+func (t *taskManagerProxy) storeOf(domainID string, taskListName string) p.TaskStore {
+	shardID := common.WorkflowIDToHistoryShard(domainID+taskListName, t.numHistoryShards)
+	for _, r := range t.ranges {
+		if shardID >= r.minShardID && shardID <= r.maxShardID {
+			return r.store
+		}
+	}
+	return t.defaultStore
+}
@@ -43,12 +43,12 @@ const (
 
 const (
 	templateCreateWorkflowExecutionStartedWithTTL = `INSERT INTO open_executions (` +
-		`domain_id, domain_partition, workflow_id, run_id, start_time, execution_time, workflow_type_name, memo, encoding) ` +
-		`VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?) using TTL ?`
+		`domain_id, domain_partition, workflow_id, run_id, start_time, execution_time, workflow_type_name, memo, encoding, bucket) ` +
+		`VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?) using TTL ?`
 
 	templateCreateWorkflowExecutionStarted = `INSERT INTO open_executions (` +
-		`domain_id, domain_partition, workflow_id, run_id, start_time, execution_time, workflow_type_name, memo, encoding) ` +
-		`VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`
+		`domain_id, domain_partition, workflow_id, run_id, start_time, execution_time, workflow_type_name, memo, encoding, bucket) ` +
+		`VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
 
 	templateDeleteWorkflowExecutionStarted = `DELETE FROM open_executions ` +
 		`WHERE domain_id = ? ` +
@@ -56,6 +56,27 @@ const (
 		`AND start_time = ? ` +
 		`AND run_id = ?`
 
+	templateGetOpenExecutionBucket = `SELECT bucket ` +
+		`FROM open_executions ` +
+		`WHERE domain_id = ? ` +
+		`AND domain_partition = ? ` +
+		`AND start_time = ? ` +
+		`AND run_id = ?`
+
+	templateCreateOpenExecutionByDomainWithTTL = `INSERT INTO open_executions_by_domain (` +
+		`domain_id, bucket, workflow_id, run_id, start_time, execution_time, workflow_type_name, memo, encoding) ` +
+		`VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?) using TTL ?`
+
+	templateCreateOpenExecutionByDomain = `INSERT INTO open_executions_by_domain (` +
+		`domain_id, bucket, workflow_id, run_id, start_time, execution_time, workflow_type_name, memo, encoding) ` +
+		`VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`
+
+	templateDeleteOpenExecutionByDomain = `DELETE FROM open_executions_by_domain ` +
+		`WHERE domain_id = ? ` +
+		`AND bucket = ? ` +
+		`AND start_time = ? ` +
+		`AND run_id = ?`
+
 	templateCreateWorkflowExecutionClosedWithTTL = `INSERT INTO closed_executions (` +
 		`domain_id, domain_partition, workflow_id, run_id, start_time, execution_time, close_time, workflow_type_name, status, history_length, memo, encoding) ` +
 		`VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?) using TTL ?`
@@ -72,6 +93,30 @@ const (
 		`domain_id, domain_partition, workflow_id, run_id, start_time, execution_time, close_time, workflow_type_name, status, history_length, memo, encoding) ` +
 		`VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
 
+	templateCreateWorkflowExecutionClosedWithTTLByType = `INSERT INTO closed_executions_by_type (` +
+		`domain_id, domain_partition, workflow_id, run_id, start_time, execution_time, close_time, workflow_type_name, status, history_length, memo, encoding) ` +
+		`VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?) using TTL ?`
+
+	templateCreateWorkflowExecutionClosedByType = `INSERT INTO closed_executions_by_type (` +
+		`domain_id, domain_partition, workflow_id, run_id, start_time, execution_time, close_time, workflow_type_name, status, history_length, memo, encoding) ` +
+		`VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+
+	templateCreateWorkflowExecutionClosedWithTTLByStatus = `INSERT INTO closed_executions_by_status (` +
+		`domain_id, domain_partition, workflow_id, run_id, start_time, execution_time, close_time, workflow_type_name, status, history_length, memo, encoding) ` +
+		`VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?) using TTL ?`
+
+	templateCreateWorkflowExecutionClosedByStatus = `INSERT INTO closed_executions_by_status (` +
+		`domain_id, domain_partition, workflow_id, run_id, start_time, execution_time, close_time, workflow_type_name, status, history_length, memo, encoding) ` +
+		`VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+
+	templateCreateWorkflowExecutionClosedWithTTLByID = `INSERT INTO closed_executions_by_workflow_id (` +
+		`domain_id, domain_partition, workflow_id, run_id, start_time, execution_time, close_time, workflow_type_name, status, history_length, memo, encoding) ` +
+		`VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?) using TTL ?`
+
+	templateCreateWorkflowExecutionClosedByID = `INSERT INTO closed_executions_by_workflow_id (` +
+		`domain_id, domain_partition, workflow_id, run_id, start_time, execution_time, close_time, workflow_type_name, status, history_length, memo, encoding) ` +
+		`VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+
 	templateGetOpenWorkflowExecutions = `SELECT workflow_id, run_id, start_time, execution_time, workflow_type_name, memo, encoding ` +
 		`FROM open_executions ` +
 		`WHERE domain_id = ? ` +
@@ -134,16 +179,31 @@ const (
 		`AND run_id = ? ALLOW FILTERING `
 )
 
+var (
+	// closed tables partitioned by the filters of the list closed executions APIs
+	templatesCreateWorkflowExecutionClosedByFilter = []string{
+		templateCreateWorkflowExecutionClosedByType,
+		templateCreateWorkflowExecutionClosedByStatus,
+		templateCreateWorkflowExecutionClosedByID,
+	}
+	templatesCreateWorkflowExecutionClosedWithTTLByFilter = []string{
+		templateCreateWorkflowExecutionClosedWithTTLByType,
+		templateCreateWorkflowExecutionClosedWithTTLByStatus,
+		templateCreateWorkflowExecutionClosedWithTTLByID,
+	}
+)
+
 type (
 	cassandraVisibilityPersistence struct {
 		cassandraStore
-		lowConslevel gocql.Consistency
+		lowConslevel         gocql.Consistency
+		openExecutionBuckets int
 	}
 )
 
 // newVisibilityPersistence is used to create an instance of VisibilityManager implementation
 func newVisibilityPersistence(cfg config.Cassandra, logger log.Logger) (p.VisibilityStore, error) {
-	cluster := NewCassandraCluster(cfg.Hosts, cfg.Port, cfg.User, cfg.Password, cfg.Datacenter)
+	cluster := NewCassandraCluster(cfg.Hosts, cfg.Port, cfg.User, cfg.Password, cfg.Datacenter, cfg.Compression)
 	cluster.Keyspace = cfg.Keyspace
 	cluster.ProtoVersion = cassandraProtoVersion
 	cluster.Consistency = gocql.LocalQuorum
@@ -156,8 +216,9 @@ func newVisibilityPersistence(cfg config.Cassandra, logger log.Logger) (p.Visibi
 	}
 
 	return &cassandraVisibilityPersistence{
-		cassandraStore: cassandraStore{session: session, logger: logger},
-		lowConslevel:   gocql.One,
+		cassandraStore:       cassandraStore{session: session, logger: logger},
+		lowConslevel:         gocql.One,
+		openExecutionBuckets: numOpenExecutionBuckets(&cfg),
 	}, nil
 }
 
@@ -171,10 +232,11 @@ func (v *cassandraVisibilityPersistence) Close() {
 func (v *cassandraVisibilityPersistence) RecordWorkflowExecutionStarted(
 	request *p.InternalRecordWorkflowExecutionStartedRequest) error {
 	ttl := request.WorkflowTimeout + openExecutionTTLBuffer
-	var query *gocql.Query
+	batch := v.session.NewBatch(gocql.LoggedBatch)
+	bucket := openExecutionBucket(request.RunID, v.openExecutionBuckets)
 
 	if ttl > maxCassandraTTL {
-		query = v.session.Query(templateCreateWorkflowExecutionStarted,
+		batch.Query(templateCreateWorkflowExecutionStarted,
 			request.DomainUUID,
 			domainPartition,
 			request.WorkflowID,
@@ -184,9 +246,22 @@ func (v *cassandraVisibilityPersistence) RecordWorkflowExecutionStarted(
 			request.WorkflowTypeName,
 			request.Memo.Data,
 			string(request.Memo.GetEncoding()),
+			bucket,
+		)
+		// duplicate write to the bucketed table listing open executions by domain
+		batch.Query(templateCreateOpenExecutionByDomain,
+			request.DomainUUID,
+			bucket,
+			request.WorkflowID,
+			request.RunID,
+			p.UnixNanoToDBTimestamp(request.StartTimestamp),
+			p.UnixNanoToDBTimestamp(request.ExecutionTimestamp),
+			request.WorkflowTypeName,
+			request.Memo.Data,
+			string(request.Memo.GetEncoding()),
 		)
 	} else {
-		query = v.session.Query(templateCreateWorkflowExecutionStartedWithTTL,
+		batch.Query(templateCreateWorkflowExecutionStartedWithTTL,
 			request.DomainUUID,
 			domainPartition,
 			request.WorkflowID,
@@ -196,11 +271,25 @@ func (v *cassandraVisibilityPersistence) RecordWorkflowExecutionStarted(
 			request.WorkflowTypeName,
 			request.Memo.Data,
 			string(request.Memo.GetEncoding()),
+			bucket,
+			ttl,
+		)
+		// duplicate write to the bucketed table listing open executions by domain
+		batch.Query(templateCreateOpenExecutionByDomainWithTTL,
+			request.DomainUUID,
+			bucket,
+			request.WorkflowID,
+			request.RunID,
+			p.UnixNanoToDBTimestamp(request.StartTimestamp),
+			p.UnixNanoToDBTimestamp(request.ExecutionTimestamp),
+			request.WorkflowTypeName,
+			request.Memo.Data,
+			string(request.Memo.GetEncoding()),
 			ttl,
 		)
 	}
-	query = query.WithTimestamp(p.UnixNanoToDBTimestamp(request.StartTimestamp))
-	err := query.Exec()
+	batch = batch.WithTimestamp(p.UnixNanoToDBTimestamp(request.StartTimestamp))
+	err := v.session.ExecuteBatch(batch)
 	if err != nil {
 		if isThrottlingError(err) {
 			return &workflow.ServiceBusyError{
@@ -217,15 +306,25 @@ func (v *cassandraVisibilityPersistence) RecordWorkflowExecutionStarted(
 
 func (v *cassandraVisibilityPersistence) RecordWorkflowExecutionClosed(
 	request *p.InternalRecordWorkflowExecutionClosedRequest) error {
+	bucket, err := v.getOpenExecutionBucket(request)
+	if err != nil {
+		return err
+	}
 	batch := v.session.NewBatch(gocql.LoggedBatch)
 
-	// First, remove execution from the open table
+	// First, remove execution from the open tables
 	batch.Query(templateDeleteWorkflowExecutionStarted,
 		request.DomainUUID,
 		domainPartition,
 		p.UnixNanoToDBTimestamp(request.StartTimestamp),
 		request.RunID,
 	)
+	batch.Query(templateDeleteOpenExecutionByDomain,
+		request.DomainUUID,
+		bucket,
+		p.UnixNanoToDBTimestamp(request.StartTimestamp),
+		request.RunID,
+	)
 
 	// Next, add a row in the closed table.
 
@@ -299,6 +398,32 @@ func (v *cassandraVisibilityPersistence) RecordWorkflowExecutionClosed(
 		)
 	}
 
+	// duplicate write to the closed tables partitioned by type, status and workflow ID
+	templates := templatesCreateWorkflowExecutionClosedWithTTLByFilter
+	if retention > maxCassandraTTL {
+		templates = templatesCreateWorkflowExecutionClosedByFilter
+	}
+	for _, template := range templates {
+		args := []interface{}{
+			request.DomainUUID,
+			domainPartition,
+			request.WorkflowID,
+			request.RunID,
+			p.UnixNanoToDBTimestamp(request.StartTimestamp),
+			p.UnixNanoToDBTimestamp(request.ExecutionTimestamp),
+			p.UnixNanoToDBTimestamp(request.CloseTimestamp),
+			request.WorkflowTypeName,
+			request.Status,
+			request.HistoryLength,
+			request.Memo.Data,
+			string(request.Memo.GetEncoding()),
+		}
+		if retention <= maxCassandraTTL {
+			args = append(args, retention)
+		}
+		batch.Query(template, args...)
+	}
+
 	// RecordWorkflowExecutionStarted is using StartTimestamp as
 	// the timestamp to issue query to Cassandra
 	// due to the fact that cross DC using mutable state creation time as workflow start time
@@ -310,7 +435,7 @@ func (v *cassandraVisibilityPersistence) RecordWorkflowExecutionClosed(
 		queryTimeStamp = request.StartTimestamp + time.Second.Nanoseconds()
 	}
 	batch = batch.WithTimestamp(p.UnixNanoToDBTimestamp(queryTimeStamp))
-	err := v.session.ExecuteBatch(batch)
+	err = v.session.ExecuteBatch(batch)
 	if err != nil {
 		if isThrottlingError(err) {
 			return &workflow.ServiceBusyError{
@@ -324,6 +449,34 @@ func (v *cassandraVisibilityPersistence) RecordWorkflowExecutionClosed(
 	return nil
 }
 
+// getOpenExecutionBucket reads the bucket persisted with the open execution, so that the row of the
+// open_executions_by_domain table is deleted even after the number of buckets changed. Executions
+// recorded without a bucket, or whose open row is gone, fall back to the bucket of their run ID.
+func (v *cassandraVisibilityPersistence) getOpenExecutionBucket(
+	request *p.InternalRecordWorkflowExecutionClosedRequest) (int, error) {
+	query := v.session.Query(templateGetOpenExecutionBucket,
+		request.DomainUUID,
+		domainPartition,
+		p.UnixNanoToDBTimestamp(request.StartTimestamp),
+		request.RunID,
+	)
+	var bucket *int
+	if err := query.Scan(&bucket); err != nil && err != gocql.ErrNotFound {
+		if isThrottlingError(err) {
+			return 0, &workflow.ServiceBusyError{
+				Message: fmt.Sprintf("RecordWorkflowExecutionClosed operation failed. Error: %v", err),
+			}
+		}
+		return 0, &workflow.InternalServiceError{
+			Message: fmt.Sprintf("RecordWorkflowExecutionClosed operation failed. Error: %v", err),
+		}
+	}
+	if bucket == nil {
+		return openExecutionBucket(request.RunID, v.openExecutionBuckets), nil
+	}
+	return *bucket, nil
+}
+
 func (v *cassandraVisibilityPersistence) UpsertWorkflowExecution(
 	request *p.InternalUpsertWorkflowExecutionRequest) error {
 
@@ -0,0 +1,174 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cassandra
+
+import (
+	"fmt"
+
+	"github.com/gocql/gocql"
+	workflow "github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common/log"
+	p "github.com/uber/cadence/common/persistence"
+	"github.com/uber/cadence/common/service/config"
+)
+
+const (
+	templateGetClosedWorkflowExecutionsByTypeTable = `SELECT workflow_id, run_id, start_time, execution_time, close_time, workflow_type_name, status, history_length, memo, encoding ` +
+		`FROM closed_executions_by_type ` +
+		`WHERE domain_id = ? ` +
+		`AND domain_partition = ? ` +
+		`AND workflow_type_name = ? ` +
+		`AND start_time >= ? ` +
+		`AND start_time <= ? `
+
+	templateGetClosedWorkflowExecutionsByStatusTable = `SELECT workflow_id, run_id, start_time, execution_time, close_time, workflow_type_name, status, history_length, memo, encoding ` +
+		`FROM closed_executions_by_status ` +
+		`WHERE domain_id = ? ` +
+		`AND domain_partition = ? ` +
+		`AND status = ? ` +
+		`AND start_time >= ? ` +
+		`AND start_time <= ? `
+
+	templateGetClosedWorkflowExecutionsByIDTable = `SELECT workflow_id, run_id, start_time, execution_time, close_time, workflow_type_name, status, history_length, memo, encoding ` +
+		`FROM closed_executions_by_workflow_id ` +
+		`WHERE domain_id = ? ` +
+		`AND domain_partition = ? ` +
+		`AND workflow_id = ? ` +
+		`AND start_time >= ? ` +
+		`AND start_time <= ? `
+)
+
+type (
+	// cassandraVisibilityPersistenceClosedByFilter lists closed executions filtered by type, status or workflow ID
+	// from the tables partitioned by that filter instead of the secondary indexes of closed_executions,
+	// all other operations are served by the wrapped store
+	cassandraVisibilityPersistenceClosedByFilter struct {
+		p.VisibilityStore
+		cassandraStore
+		lowConslevel gocql.Consistency
+	}
+)
+
+// NewVisibilityPersistenceClosedByFilter create a wrapper of cassandra visibilityPersistence, with the filtered
+// list closed executions using the closed_executions_by_type, closed_executions_by_status and
+// closed_executions_by_workflow_id tables
+func NewVisibilityPersistenceClosedByFilter(persistence p.VisibilityStore, cfg *config.Cassandra, logger log.Logger) (p.VisibilityStore, error) {
+	cluster := NewCassandraCluster(cfg.Hosts, cfg.Port, cfg.User, cfg.Password, cfg.Datacenter, cfg.Compression)
+	cluster.Keyspace = cfg.Keyspace
+	cluster.ProtoVersion = cassandraProtoVersion
+	cluster.Consistency = gocql.LocalQuorum
+	cluster.SerialConsistency = gocql.LocalSerial
+	cluster.Timeout = defaultSessionTimeout
+
+	session, err := cluster.CreateSession()
+	if err != nil {
+		return nil, err
+	}
+
+	return &cassandraVisibilityPersistenceClosedByFilter{
+		VisibilityStore: persistence,
+		cassandraStore:  cassandraStore{session: session, logger: logger},
+		lowConslevel:    gocql.One,
+	}, nil
+}
+
+// Close releases the resources held by this object
+func (v *cassandraVisibilityPersistenceClosedByFilter) Close() {
+	if v.session != nil {
+		v.session.Close()
+	}
+	v.VisibilityStore.Close()
+}
+
+func (v *cassandraVisibilityPersistenceClosedByFilter) GetName() string {
+	return v.VisibilityStore.GetName()
+}
+
+func (v *cassandraVisibilityPersistenceClosedByFilter) ListClosedWorkflowExecutionsByType(
+	request *p.ListWorkflowExecutionsByTypeRequest) (*p.InternalListWorkflowExecutionsResponse, error) {
+	query := v.session.Query(templateGetClosedWorkflowExecutionsByTypeTable,
+		request.DomainUUID,
+		domainPartition,
+		request.WorkflowTypeName,
+		p.UnixNanoToDBTimestamp(request.EarliestStartTime),
+		p.UnixNanoToDBTimestamp(request.LatestStartTime))
+	return v.listClosedWorkflowExecutions("ListClosedWorkflowExecutionsByType", query, &request.ListWorkflowExecutionsRequest)
+}
+
+func (v *cassandraVisibilityPersistenceClosedByFilter) ListClosedWorkflowExecutionsByWorkflowID(
+	request *p.ListWorkflowExecutionsByWorkflowIDRequest) (*p.InternalListWorkflowExecutionsResponse, error) {
+	query := v.session.Query(templateGetClosedWorkflowExecutionsByIDTable,
+		request.DomainUUID,
+		domainPartition,
+		request.WorkflowID,
+		p.UnixNanoToDBTimestamp(request.EarliestStartTime),
+		p.UnixNanoToDBTimestamp(request.LatestStartTime))
+	return v.listClosedWorkflowExecutions("ListClosedWorkflowExecutionsByWorkflowID", query, &request.ListWorkflowExecutionsRequest)
+}
+
+func (v *cassandraVisibilityPersistenceClosedByFilter) ListClosedWorkflowExecutionsByStatus(
+	request *p.ListClosedWorkflowExecutionsByStatusRequest) (*p.InternalListWorkflowExecutionsResponse, error) {
+	query := v.session.Query(templateGetClosedWorkflowExecutionsByStatusTable,
+		request.DomainUUID,
+		domainPartition,
+		request.Status,
+		p.UnixNanoToDBTimestamp(request.EarliestStartTime),
+		p.UnixNanoToDBTimestamp(request.LatestStartTime))
+	return v.listClosedWorkflowExecutions("ListClosedWorkflowExecutionsByStatus", query, &request.ListWorkflowExecutionsRequest)
+}
+
+func (v *cassandraVisibilityPersistenceClosedByFilter) listClosedWorkflowExecutions(
+	operation string,
+	query *gocql.Query,
+	request *p.ListWorkflowExecutionsRequest,
+) (*p.InternalListWorkflowExecutionsResponse, error) {
+	iter := query.Consistency(v.lowConslevel).PageSize(request.PageSize).PageState(request.NextPageToken).Iter()
+	if iter == nil {
+		// TODO: should return a bad request error if the token is invalid
+		return nil, &workflow.InternalServiceError{
+			Message: fmt.Sprintf("%v operation failed.  Not able to create query iterator.", operation),
+		}
+	}
+
+	response := &p.InternalListWorkflowExecutionsResponse{}
+	response.Executions = make([]*p.VisibilityWorkflowExecutionInfo, 0)
+	wfexecution, has := readClosedWorkflowExecutionRecord(iter)
+	for has {
+		response.Executions = append(response.Executions, wfexecution)
+		wfexecution, has = readClosedWorkflowExecutionRecord(iter)
+	}
+
+	nextPageToken := iter.PageState()
+	response.NextPageToken = make([]byte, len(nextPageToken))
+	copy(response.NextPageToken, nextPageToken)
+	if err := iter.Close(); err != nil {
+		if isThrottlingError(err) {
+			return nil, &workflow.ServiceBusyError{
+				Message: fmt.Sprintf("%v operation failed. Error: %v", operation, err),
+			}
+		}
+		return nil, &workflow.InternalServiceError{
+			Message: fmt.Sprintf("%v operation failed. Error: %v", operation, err),
+		}
+	}
+
+	return response, nil
+}
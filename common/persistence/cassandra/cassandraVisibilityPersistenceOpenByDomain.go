@@ -0,0 +1,220 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cassandra
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/dgryski/go-farm"
+	"github.com/gocql/gocql"
+	workflow "github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common/log"
+	p "github.com/uber/cadence/common/persistence"
+	"github.com/uber/cadence/common/service/config"
+)
+
+const (
+	templateGetOpenExecutionsByDomain = `SELECT workflow_id, run_id, start_time, execution_time, workflow_type_name, memo, encoding ` +
+		`FROM open_executions_by_domain ` +
+		`WHERE domain_id = ? ` +
+		`AND bucket = ? ` +
+		`AND start_time >= ? ` +
+		`AND start_time <= ? ` +
+		`LIMIT ?`
+
+	templateGetOpenExecutionsByDomainAfter = `SELECT workflow_id, run_id, start_time, execution_time, workflow_type_name, memo, encoding ` +
+		`FROM open_executions_by_domain ` +
+		`WHERE domain_id = ? ` +
+		`AND bucket = ? ` +
+		`AND (start_time, run_id) < (?, ?) ` +
+		`AND (start_time) >= (?) ` +
+		`LIMIT ?`
+)
+
+type (
+	// cassandraVisibilityPersistenceOpenByDomain lists open executions from the bucketed
+	// open_executions_by_domain table, all other operations are served by the wrapped store
+	cassandraVisibilityPersistenceOpenByDomain struct {
+		p.VisibilityStore
+		cassandraStore
+		lowConslevel         gocql.Consistency
+		openExecutionBuckets int
+	}
+
+	// openExecutionsByDomainPageToken is the position of the last execution returned in a page
+	openExecutionsByDomainPageToken struct {
+		StartTime int64
+		RunID     string
+	}
+)
+
+// NewVisibilityPersistenceOpenByDomain create a wrapper of cassandra visibilityPersistence, with list open executions
+// using the open_executions_by_domain table
+func NewVisibilityPersistenceOpenByDomain(persistence p.VisibilityStore, cfg *config.Cassandra, logger log.Logger) (p.VisibilityStore, error) {
+	cluster := NewCassandraCluster(cfg.Hosts, cfg.Port, cfg.User, cfg.Password, cfg.Datacenter, cfg.Compression)
+	cluster.Keyspace = cfg.Keyspace
+	cluster.ProtoVersion = cassandraProtoVersion
+	cluster.Consistency = gocql.LocalQuorum
+	cluster.SerialConsistency = gocql.LocalSerial
+	cluster.Timeout = defaultSessionTimeout
+
+	session, err := cluster.CreateSession()
+	if err != nil {
+		return nil, err
+	}
+
+	return &cassandraVisibilityPersistenceOpenByDomain{
+		VisibilityStore:      persistence,
+		cassandraStore:       cassandraStore{session: session, logger: logger},
+		lowConslevel:         gocql.One,
+		openExecutionBuckets: numOpenExecutionBuckets(cfg),
+	}, nil
+}
+
+// Close releases the resources held by this object
+func (v *cassandraVisibilityPersistenceOpenByDomain) Close() {
+	if v.session != nil {
+		v.session.Close()
+	}
+	v.VisibilityStore.Close()
+}
+
+func (v *cassandraVisibilityPersistenceOpenByDomain) GetName() string {
+	return v.VisibilityStore.GetName()
+}
+
+// ListOpenWorkflowExecutions reads a page from every bucket of the domain and merges them by start time.
+// The page token is the start time and run ID of the last returned execution.
+func (v *cassandraVisibilityPersistenceOpenByDomain) ListOpenWorkflowExecutions(
+	request *p.ListWorkflowExecutionsRequest) (*p.InternalListWorkflowExecutionsResponse, error) {
+
+	var token *openExecutionsByDomainPageToken
+	if len(request.NextPageToken) > 0 {
+		token = &openExecutionsByDomainPageToken{}
+		if err := json.Unmarshal(request.NextPageToken, token); err != nil {
+			return nil, &workflow.BadRequestError{
+				Message: fmt.Sprintf("ListOpenWorkflowExecutions operation failed. Invalid page token: %v", err),
+			}
+		}
+	}
+
+	var executions []*p.VisibilityWorkflowExecutionInfo
+	hasMore := false
+	for bucket := 0; bucket < v.openExecutionBuckets; bucket++ {
+		var query *gocql.Query
+		if token == nil {
+			query = v.session.Query(templateGetOpenExecutionsByDomain,
+				request.DomainUUID,
+				bucket,
+				p.UnixNanoToDBTimestamp(request.EarliestStartTime),
+				p.UnixNanoToDBTimestamp(request.LatestStartTime),
+				request.PageSize)
+		} else {
+			query = v.session.Query(templateGetOpenExecutionsByDomainAfter,
+				request.DomainUUID,
+				bucket,
+				p.UnixNanoToDBTimestamp(token.StartTime),
+				token.RunID,
+				p.UnixNanoToDBTimestamp(request.EarliestStartTime),
+				request.PageSize)
+		}
+		bucketExecutions, err := readOpenExecutionsByDomain(query.Consistency(v.lowConslevel))
+		if err != nil {
+			return nil, err
+		}
+		// a full bucket page may hide more executions, which all sort after the last execution of this page
+		if len(bucketExecutions) >= request.PageSize {
+			hasMore = true
+		}
+		executions = append(executions, bucketExecutions...)
+	}
+
+	sort.Slice(executions, func(i, j int) bool {
+		if !executions[i].StartTime.Equal(executions[j].StartTime) {
+			return executions[i].StartTime.After(executions[j].StartTime)
+		}
+		return executions[i].RunID > executions[j].RunID
+	})
+	if len(executions) > request.PageSize {
+		executions = executions[:request.PageSize]
+		hasMore = true
+	}
+
+	response := &p.InternalListWorkflowExecutionsResponse{
+		Executions: make([]*p.VisibilityWorkflowExecutionInfo, 0, len(executions)),
+	}
+	response.Executions = append(response.Executions, executions...)
+	if hasMore && len(executions) > 0 {
+		last := executions[len(executions)-1]
+		nextPageToken, err := json.Marshal(&openExecutionsByDomainPageToken{
+			StartTime: last.StartTime.UnixNano(),
+			RunID:     last.RunID,
+		})
+		if err != nil {
+			return nil, &workflow.InternalServiceError{
+				Message: fmt.Sprintf("ListOpenWorkflowExecutions operation failed. Error: %v", err),
+			}
+		}
+		response.NextPageToken = nextPageToken
+	}
+	return response, nil
+}
+
+func readOpenExecutionsByDomain(query *gocql.Query) ([]*p.VisibilityWorkflowExecutionInfo, error) {
+	iter := query.Iter()
+	if iter == nil {
+		return nil, &workflow.InternalServiceError{
+			Message: "ListOpenWorkflowExecutions operation failed.  Not able to create query iterator.",
+		}
+	}
+
+	var executions []*p.VisibilityWorkflowExecutionInfo
+	wfexecution, has := readOpenWorkflowExecutionRecord(iter)
+	for has {
+		executions = append(executions, wfexecution)
+		wfexecution, has = readOpenWorkflowExecutionRecord(iter)
+	}
+
+	if err := iter.Close(); err != nil {
+		if isThrottlingError(err) {
+			return nil, &workflow.ServiceBusyError{
+				Message: fmt.Sprintf("ListOpenWorkflowExecutions operation failed. Error: %v", err),
+			}
+		}
+		return nil, &workflow.InternalServiceError{
+			Message: fmt.Sprintf("ListOpenWorkflowExecutions operation failed. Error: %v", err),
+		}
+	}
+	return executions, nil
+}
+
+func numOpenExecutionBuckets(cfg *config.Cassandra) int {
+	if cfg.OpenExecutionBuckets > 0 {
+		return cfg.OpenExecutionBuckets
+	}
+	return 1
+}
+
+func openExecutionBucket(runID string, numBuckets int) int {
+	return int(farm.Fingerprint32([]byte(runID)) % uint32(numBuckets))
+}
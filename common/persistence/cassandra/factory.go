@@ -21,10 +21,12 @@
 package cassandra
 
 import (
+	"strings"
 	"sync"
 
 	"github.com/gocql/gocql"
 	"github.com/uber/cadence/common/log"
+	"github.com/uber/cadence/common/log/tag"
 	p "github.com/uber/cadence/common/persistence"
 	"github.com/uber/cadence/common/service/config"
 )
@@ -35,43 +37,65 @@ type (
 		sync.RWMutex
 		cfg              config.Cassandra
 		clusterName      string
+		numHistoryShards int
 		logger           log.Logger
 		execStoreFactory *executionStoreFactory
+		shardRanges      []shardRangeFactory
+	}
+	// shardRangeFactory vends the execution stores of a range of history shards
+	shardRangeFactory struct {
+		minShardID int
+		maxShardID int
+		factory    *Factory
 	}
 	executionStoreFactory struct {
-		session *gocql.Session
-		logger  log.Logger
+		session             *gocql.Session
+		readSession         *gocql.Session
+		transferTaskBuckets int
+		rowMappers          *executionRowMappers
+		logger              log.Logger
 	}
 )
 
 // NewFactory returns an instance of a factory object which can be used to create
-// datastores that are backed by cassandra
-func NewFactory(cfg config.Cassandra, clusterName string, logger log.Logger) *Factory {
+// datastores that are backed by cassandra, numHistoryShards is used to route the
+// operations not carrying a shard ID to the shard ranges of the config
+func NewFactory(cfg config.Cassandra, clusterName string, numHistoryShards int, logger log.Logger) *Factory {
+	var shardRanges []shardRangeFactory
+	for _, r := range cfg.ShardRanges {
+		shardRanges = append(shardRanges, shardRangeFactory{
+			minShardID: r.MinShardID,
+			maxShardID: r.MaxShardID,
+			factory:    NewFactory(r.Cassandra, clusterName, numHistoryShards, logger),
+		})
+	}
 	return &Factory{
-		cfg:         cfg,
-		clusterName: clusterName,
-		logger:      logger,
+		cfg:              cfg,
+		clusterName:      clusterName,
+		numHistoryShards: numHistoryShards,
+		logger:           logger,
+		shardRanges:      shardRanges,
 	}
 }
 
 // NewTaskStore returns a new task store
 func (f *Factory) NewTaskStore() (p.TaskStore, error) {
-	return newTaskPersistence(f.cfg, f.logger)
+	return newTaskManagerProxy(f.cfg, f.numHistoryShards, f.logger)
 }
 
 // NewShardStore returns a new shard store
 func (f *Factory) NewShardStore() (p.ShardStore, error) {
-	return newShardPersistence(f.cfg, f.clusterName, f.logger)
+	return newShardManagerProxy(f.cfg, f.clusterName, f.logger)
 }
 
 // NewHistoryStore returns a new history store
 func (f *Factory) NewHistoryStore() (p.HistoryStore, error) {
-	return newHistoryPersistence(f.cfg, f.logger)
+	return newHistoryManagerProxy(f.cfg, f.numHistoryShards, f.logger)
 }
 
 // NewHistoryV2Store returns a new history store
 func (f *Factory) NewHistoryV2Store() (p.HistoryV2Store, error) {
-	return newHistoryV2Persistence(f.cfg, f.logger)
+	return newHistoryV2ManagerProxy(f.cfg, f.logger)
 }
 
 // NewMetadataStore returns a new metadata store
@@ -91,6 +115,11 @@ func (f *Factory) NewMetadataStoreV2() (p.MetadataStore, error) {
 
 // NewExecutionStore returns an ExecutionStore for a given shardID
 func (f *Factory) NewExecutionStore(shardID int) (p.ExecutionStore, error) {
+	for _, r := range f.shardRanges {
+		if shardID >= r.minShardID && shardID <= r.maxShardID {
+			return r.factory.NewExecutionStore(shardID)
+		}
+	}
 	factory, err := f.executionStoreFactory()
 	if err != nil {
 		return nil, err
@@ -103,6 +132,21 @@ func (f *Factory) NewVisibilityStore() (p.VisibilityStore, error) {
 	return newVisibilityPersistence(f.cfg, f.logger)
 }
 
+// NewClusterMembershipStore returns a cluster membership store
+func (f *Factory) NewClusterMembershipStore() (p.ClusterMembershipStore, error) {
+	return newClusterMembershipPersistence(f.cfg, f.logger)
+}
+
+// NewAuditStore returns an audit store
+func (f *Factory) NewAuditStore() (p.AuditStore, error) {
+	return newAuditPersistence(f.cfg, f.logger)
+}
+
+// NewScheduleStore returns a schedule store
+func (f *Factory) NewScheduleStore() (p.ScheduleStore, error) {
+	return newSchedulePersistence(f.cfg, f.logger)
+}
+
 // Close closes the factory
 func (f *Factory) Close() {
 	f.Lock()
@@ -110,6 +154,9 @@ func (f *Factory) Close() {
 	if f.execStoreFactory != nil {
 		f.execStoreFactory.close()
 	}
+	for _, r := range f.shardRanges {
+		r.factory.Close()
+	}
 }
 
 func (f *Factory) executionStoreFactory() (*executionStoreFactory, error) {
@@ -135,7 +182,41 @@ func (f *Factory) executionStoreFactory() (*executionStoreFactory, error) {
 
 // newExecutionStoreFactory is used to create an instance of ExecutionStoreFactory implementation
 func newExecutionStoreFactory(cfg config.Cassandra, logger log.Logger) (*executionStoreFactory, error) {
-	cluster := NewCassandraCluster(cfg.Hosts, cfg.Port, cfg.User, cfg.Password, cfg.Datacenter)
+	session, err := newExecutionSession(cfg, cfg.WriteSession)
+	if err != nil {
+		return nil, err
+	}
+	readSession := session
+	if cfg.ReadSession != nil {
+		readSession, err = newExecutionSession(cfg, cfg.ReadSession)
+		if err != nil {
+			session.Close()
+			return nil, err
+		}
+	}
+	if err := validateVisibilityTimestampEncoding(session, cfg.Keyspace, logger); err != nil {
+		closeExecutionSessions(session, readSession)
+		return nil, err
+	}
+	rowMappers, err := newExecutionRowMappers(func() (schemaVersion, error) {
+		return readSchemaVersion(session, cfg.Keyspace)
+	}, logger)
+	if err != nil {
+		closeExecutionSessions(session, readSession)
+		return nil, err
+	}
+	return &executionStoreFactory{
+		session:             session,
+		readSession:         readSession,
+		transferTaskBuckets: cfg.TransferTaskBuckets,
+		rowMappers:          rowMappers,
+		logger:              logger,
+	}, nil
+}
+
+// newExecutionSession creates a session of the execution store, the session settings override the defaults
+func newExecutionSession(cfg config.Cassandra, settings *config.CassandraSession) (*gocql.Session, error) {
+	cluster := NewCassandraCluster(cfg.Hosts, cfg.Port, cfg.User, cfg.Password, cfg.Datacenter, cfg.Compression)
 	cluster.Keyspace = cfg.Keyspace
 	cluster.ProtoVersion = cassandraProtoVersion
 	cluster.Consistency = gocql.LocalQuorum
@@ -144,20 +225,51 @@ func newExecutionStoreFactory(cfg config.Cassandra, logger log.Logger) (*executi
 	if cfg.MaxConns > 0 {
 		cluster.NumConns = cfg.MaxConns
 	}
-	session, err := cluster.CreateSession()
-	if err != nil {
-		return nil, err
+	if settings != nil {
+		if settings.Consistency != "" {
+			consistency, err := gocql.ParseConsistencyWrapper(strings.ToUpper(settings.Consistency))
+			if err != nil {
+				return nil, err
+			}
+			cluster.Consistency = consistency
+		}
+		if settings.Timeout > 0 {
+			cluster.Timeout = settings.Timeout
+		}
+		if settings.MaxConns > 0 {
+			cluster.NumConns = settings.MaxConns
+		}
 	}
-	return &executionStoreFactory{session: session, logger: logger}, nil
+	return cluster.CreateSession()
 }
 
 func (f *executionStoreFactory) close() {
-	f.session.Close()
+	f.rowMappers.stop()
+	closeExecutionSessions(f.session, f.readSession)
+}
+
+func closeExecutionSessions(session *gocql.Session, readSession *gocql.Session) {
+	session.Close()
+	if readSession != session {
+		readSession.Close()
+	}
 }
 
 // new implements ExecutionStoreFactory interface
 func (f *executionStoreFactory) new(shardID int) (p.ExecutionStore, error) {
-	pmgr, err := NewWorkflowExecutionPersistence(shardID, f.session, f.logger)
+	// the shard keeps reading the buckets it spread its transfer tasks over when the configured count is lowered
+	persistedBuckets, err := readShardTransferTaskBuckets(f.session, shardID)
+	if err != nil {
+		return nil, err
+	}
+	transferTaskBuckets := maxTransferTaskBuckets(persistedBuckets, f.transferTaskBuckets)
+	if transferTaskBuckets != f.transferTaskBuckets {
+		f.logger.Warn("Transfer task buckets cannot decrease, keeping the buckets persisted by the shard.",
+			tag.ShardID(shardID),
+			tag.Counter(transferTaskBuckets))
+	}
+
+	pmgr, err := newWorkflowExecutionPersistence(shardID, transferTaskBuckets, f.rowMappers, f.session, f.readSession, f.logger)
 	if err != nil {
 		return nil, err
 	}
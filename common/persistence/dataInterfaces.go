@@ -21,6 +21,7 @@
 package persistence
 
 import (
+	"context"
 	"fmt"
 	"time"
 
@@ -61,6 +62,16 @@ const (
 	CreateWorkflowModeContinueAsNew
 )
 
+// Results of deleting the current workflow execution
+const (
+	// The current record pointed to the run and was deleted
+	DeleteCurrentWorkflowExecutionResultDeleted = iota
+	// The current record points to another run and was kept
+	DeleteCurrentWorkflowExecutionResultMismatched
+	// There is no current record for the workflow ID
+	DeleteCurrentWorkflowExecutionResultMissing
+)
+
 // Workflow execution states
 const (
 	WorkflowStateCreated = iota
@@ -102,6 +113,14 @@ const (
 	TransferTaskTypeRecordWorkflowStarted
 	TransferTaskTypeResetWorkflow
 	TransferTaskTypeUpsertWorkflowSearchAttributes
+	TransferTaskTypeRecordChildExecutionCompleted
+)
+
+// Types of visibility tasks
+const (
+	VisibilityTaskTypeRecordWorkflowStarted = iota
+	VisibilityTaskTypeUpsertWorkflowSearchAttributes
+	VisibilityTaskTypeCloseExecution
 )
 
 // Types of replication tasks
@@ -119,6 +138,7 @@ const (
 	TaskTypeDeleteHistoryEvent
 	TaskTypeActivityRetryTimer
 	TaskTypeWorkflowBackoffTimer
+	TaskTypeWorkflowSLATimer
 )
 
 // UnknownNumRowsAffected is returned when the number of rows that an API affected cannot be determined
@@ -177,6 +197,7 @@ type (
 		State            int
 		CloseStatus      int
 		LastWriteVersion int64
+		BlockedUntil     time.Time
 	}
 
 	// TimeoutError is returned when a write operation fails due to a timeout
@@ -189,6 +210,18 @@ type (
 		Msg string
 	}
 
+	// StoreOperationError is returned when an execution store operation fails with an internal error, it carries
+	// the operation, the query template, the shard and the execution so that failures can be grouped by operation
+	StoreOperationError struct {
+		Operation  string
+		Template   string
+		ShardID    int
+		DomainID   string
+		WorkflowID string
+		RunID      string
+		Msg        string
+	}
+
 	// ShardInfo describes a shard
 	ShardInfo struct {
 		ShardID                   int
@@ -204,6 +237,31 @@ type (
 		TransferFailoverLevels    map[string]TransferFailoverLevel // uuid -> TransferFailoverLevel
 		TimerFailoverLevels       map[string]TimerFailoverLevel    // uuid -> TimerFailoverLevel
 		DomainNotificationVersion int64
+		DomainOpenExecutionCounts map[string]int64 // domainID -> approximate number of open executions
+		VisibilityAckLevel        int64
+		QueueAlarms               map[string]QueueAlarm // queue -> alarm raised for the queue
+		HotExecutions             []HotExecution        // most recently active executions, most recent first
+		LeaseExpiresAt            time.Time             // hint of when the owner is considered gone if it does not renew its lease
+		TasksPerSecond            float64               // rolling average of the tasks processed by the shard, used to balance shards
+		TaskThroughputUpdatedAt   time.Time
+		Placement                 []string // shardID -> host identity, only set on the shard placement record
+		TransferTaskBuckets       int      // transfer task partitions used by the shard so far, never decreases
+	}
+
+	// HotExecution is a recently active execution of the shard, loaded into the
+	// caches when the shard is acquired by a new host
+	HotExecution struct {
+		DomainID   string
+		WorkflowID string
+		RunID      string
+	}
+
+	// QueueAlarm is raised when the ack level of a shard queue stops advancing while
+	// new tasks keep being added, the blocking task is the earliest task not yet acked
+	QueueAlarm struct {
+		StuckSince            time.Time
+		BlockingTaskID        int64
+		BlockingTaskTimestamp time.Time
 	}
 
 	// TransferFailoverLevel contains corresponding start / end level
@@ -226,38 +284,47 @@ type (
 
 	// WorkflowExecutionInfo describes a workflow execution
 	WorkflowExecutionInfo struct {
-		DomainID                     string
-		WorkflowID                   string
-		RunID                        string
-		ParentDomainID               string
-		ParentWorkflowID             string
-		ParentRunID                  string
-		InitiatedID                  int64
-		CompletionEventBatchID       int64
-		CompletionEvent              *workflow.HistoryEvent
-		TaskList                     string
-		WorkflowTypeName             string
-		WorkflowTimeout              int32
-		DecisionTimeoutValue         int32
-		ExecutionContext             []byte
-		State                        int
-		CloseStatus                  int
-		LastFirstEventID             int64
-		LastEventTaskID              int64
-		NextEventID                  int64
-		LastProcessedEvent           int64
-		StartTimestamp               time.Time
-		LastUpdatedTimestamp         time.Time
-		CreateRequestID              string
-		SignalCount                  int32
-		DecisionVersion              int64
-		DecisionScheduleID           int64
-		DecisionStartedID            int64
-		DecisionRequestID            string
-		DecisionTimeout              int32
-		DecisionAttempt              int64
-		DecisionStartedTimestamp     int64
-		DecisionScheduledTimestamp   int64
+		DomainID                   string
+		WorkflowID                 string
+		RunID                      string
+		FirstExecutionRunID        string
+		ParentDomainID             string
+		ParentWorkflowID           string
+		ParentRunID                string
+		InitiatedID                int64
+		CompletionEventBatchID     int64
+		CompletionEvent            *workflow.HistoryEvent
+		TaskList                   string
+		WorkflowTypeName           string
+		WorkflowTimeout            int32
+		DecisionTimeoutValue       int32
+		ExecutionContext           []byte
+		State                      int
+		CloseStatus                int
+		LastFirstEventID           int64
+		LastEventTaskID            int64
+		NextEventID                int64
+		LastProcessedEvent         int64
+		StartTimestamp             time.Time
+		LastUpdatedTimestamp       time.Time
+		CreateRequestID            string
+		SignalCount                int32
+		DecisionVersion            int64
+		DecisionScheduleID         int64
+		DecisionStartedID          int64
+		DecisionRequestID          string
+		DecisionTimeout            int32
+		DecisionAttempt            int64
+		DecisionHeartbeatCount     int64
+		DecisionStartedTimestamp   int64
+		DecisionScheduledTimestamp int64
+		// identity and binary checksum of the worker which started the current decision
+		DecisionStartedIdentity       string
+		DecisionStartedBinaryChecksum string
+		// run, decision finish event and reason this run was reset from, empty unless the run was created by a reset
+		ResetBaseRunID               string
+		ResetBaseEventID             int64
+		ResetReason                  string
 		CancelRequested              bool
 		CancelRequestID              string
 		StickyTaskList               string
@@ -282,6 +349,18 @@ type (
 		// Cron
 		CronSchedule      string
 		ExpirationSeconds int32
+		// request IDs of recently completed activities, used to deduplicate completion retries
+		CompletedActivityRequestIDs []string
+		// no decision task is dispatched while the workflow is paused
+		Paused bool
+		// the cron backoff timer does not schedule the first decision while the cron schedule is paused
+		CronPaused bool
+		// IANA time zone the cron schedule is evaluated in, UTC if empty
+		CronTimezone string
+		// notes attached by operators to the execution, they are not part of history
+		Notes                string
+		NotesIdentity        string
+		NotesLastUpdatedTime time.Time
 	}
 
 	// ExecutionStats is the statistics about workflow execution
@@ -317,6 +396,17 @@ type (
 		RecordVisibility        bool
 	}
 
+	// VisibilityTaskInfo describes a visibility task
+	VisibilityTaskInfo struct {
+		DomainID            string
+		WorkflowID          string
+		RunID               string
+		VisibilityTimestamp time.Time
+		TaskID              int64
+		TaskType            int
+		Version             int64
+	}
+
 	// ReplicationTaskInfo describes the replication task created for replication of history events
 	ReplicationTaskInfo struct {
 		DomainID                string
@@ -360,6 +450,9 @@ type (
 		Kind        int
 		Expiry      time.Time
 		LastUpdated time.Time
+		// VersionSets are the sets of compatible worker build IDs of a decision task list, ordered from the
+		// oldest to the newest. The last build ID of a set is the default of the set.
+		VersionSets [][]string
 	}
 
 	// TaskInfo describes either activity or decision task
@@ -451,6 +544,13 @@ type (
 		Version             int64
 	}
 
+	// WorkflowSLATimerTask identifies a timer task firing at the SLA deadline of a workflow.
+	WorkflowSLATimerTask struct {
+		VisibilityTimestamp time.Time
+		TaskID              int64
+		Version             int64
+	}
+
 	// CancelExecutionTask identifies a transfer task for cancel of execution
 	CancelExecutionTask struct {
 		VisibilityTimestamp     time.Time
@@ -475,6 +575,17 @@ type (
 		Version                 int64
 	}
 
+	// RecordChildExecutionCompletedTask identifies a transfer task for notifying parent of child completion
+	RecordChildExecutionCompletedTask struct {
+		VisibilityTimestamp time.Time
+		TaskID              int64
+		TargetDomainID      string
+		TargetWorkflowID    string
+		TargetRunID         string
+		InitiatedID         int64
+		Version             int64
+	}
+
 	// UpsertWorkflowSearchAttributesTask identifies a transfer task for upsert search attributes
 	UpsertWorkflowSearchAttributesTask struct {
 		VisibilityTimestamp time.Time
@@ -482,6 +593,27 @@ type (
 		Version             int64
 	}
 
+	// RecordWorkflowStartedVisibilityTask identifies a visibility task for writing the open execution record
+	RecordWorkflowStartedVisibilityTask struct {
+		VisibilityTimestamp time.Time
+		TaskID              int64
+		Version             int64
+	}
+
+	// UpsertWorkflowSearchAttributesVisibilityTask identifies a visibility task for upsert search attributes
+	UpsertWorkflowSearchAttributesVisibilityTask struct {
+		VisibilityTimestamp time.Time
+		TaskID              int64
+		Version             int64
+	}
+
+	// CloseExecutionVisibilityTask identifies a visibility task for writing the closed execution record
+	CloseExecutionVisibilityTask struct {
+		VisibilityTimestamp time.Time
+		TaskID              int64
+		Version             int64
+	}
+
 	// StartChildExecutionTask identifies a transfer task for starting child execution
 	StartChildExecutionTask struct {
 		VisibilityTimestamp time.Time
@@ -565,6 +697,7 @@ type (
 		RequestCancelInfos  map[int64]*RequestCancelInfo
 		SignalInfos         map[int64]*SignalInfo
 		SignalRequestedIDs  map[string]struct{}
+		UpdateInfos         map[string]*UpdateInfo
 		ExecutionInfo       *WorkflowExecutionInfo
 		ExecutionStats      *ExecutionStats
 		ReplicationState    *ReplicationState
@@ -592,6 +725,7 @@ type (
 		CancelRequestID          int64
 		LastHeartBeatUpdatedTime time.Time
 		TimerTaskStatus          int32
+		Paused                   bool
 		// For retry
 		Attempt            int32
 		DomainID           string
@@ -651,6 +785,16 @@ type (
 		Control         []byte
 	}
 
+	// UpdateInfo has details for pending workflow update
+	UpdateInfo struct {
+		Version    int64
+		UpdateID   string
+		UpdateName string
+		Input      []byte
+		Result     []byte
+		Completed  bool
+	}
+
 	// CreateShardRequest is used to create a shard in executions table
 	CreateShardRequest struct {
 		ShardInfo *ShardInfo
@@ -672,6 +816,134 @@ type (
 		PreviousRangeID int64
 	}
 
+	// ClusterMember is a host of a cadence service which recently heartbeat to the cluster membership store
+	ClusterMember struct {
+		Role          string
+		RPCAddress    string
+		SessionStart  time.Time
+		LastHeartbeat time.Time
+		RecordExpiry  time.Time
+	}
+
+	// UpsertClusterMembershipRequest is used to record the heartbeat of a cluster member
+	UpsertClusterMembershipRequest struct {
+		Role         string
+		RPCAddress   string
+		SessionStart time.Time
+		// RecordExpiry is how long the record is kept without a new heartbeat
+		RecordExpiry time.Duration
+	}
+
+	// GetClusterMembersRequest is used to get the members of the cluster
+	GetClusterMembersRequest struct {
+		// Role filters the members by service name, all members are returned if empty
+		Role string
+		// LastHeartbeatWithin filters out members which did not heartbeat within the duration, if positive
+		LastHeartbeatWithin time.Duration
+	}
+
+	// GetClusterMembersResponse is the response to GetClusterMembers
+	GetClusterMembersResponse struct {
+		ActiveMembers []*ClusterMember
+	}
+
+	// PruneClusterMembershipRequest is used to delete expired cluster membership records
+	PruneClusterMembershipRequest struct {
+		MaxRecordsPruned int
+	}
+
+	// AuditEntry is the record of a mutating admin or domain API call
+	AuditEntry struct {
+		Timestamp     time.Time
+		Caller        string
+		CallerService string
+		Operation     string
+		Domain        string
+		RequestHash   string
+		Error         string
+	}
+
+	// RecordAuditEntryRequest is used to append an entry to the audit log
+	RecordAuditEntryRequest struct {
+		Entry *AuditEntry
+	}
+
+	// ScheduleInfo is a schedule periodically starting a workflow, see workflow.ScheduleInfo for the meaning of the fields
+	ScheduleInfo struct {
+		DomainID                            string
+		ScheduleID                          string
+		CronSchedule                        string
+		WorkflowTypeName                    string
+		TaskList                            string
+		Input                               []byte
+		ExecutionStartToCloseTimeoutSeconds int32
+		TaskStartToCloseTimeoutSeconds      int32
+		OverlapPolicy                       int
+		CatchupWindowSeconds                int32
+		Paused                              bool
+		LastFireTime                        time.Time
+		LastWorkflowID                      string
+		LastRunID                           string
+		// BackfillID identifies the pending backfill, the workflow IDs of its runs are derived from it so
+		// that they are started again by a later backfill over the same range
+		BackfillID            string
+		BackfillStartTime     time.Time
+		BackfillEndTime       time.Time
+		BackfillRunsPerMinute int32
+		// BackfillLastRunTime is when the last workflow of the pending backfill was started, it is used
+		// to throttle the backfill to BackfillRunsPerMinute
+		BackfillLastRunTime time.Time
+		// LastBackfillWorkflowID and LastBackfillRunID are the last workflow started by a backfill, they are
+		// tracked apart from LastWorkflowID as backfill runs are not subject to the overlap policy
+		LastBackfillWorkflowID string
+		LastBackfillRunID      string
+		Identity               string
+		// Version is incremented on every update and used for optimistic concurrency control
+		Version int64
+	}
+
+	// CreateScheduleRequest is used to create a schedule, it fails with ConditionFailedError if the schedule exists
+	CreateScheduleRequest struct {
+		Schedule *ScheduleInfo
+	}
+
+	// GetScheduleRequest is used to get a schedule
+	GetScheduleRequest struct {
+		DomainID   string
+		ScheduleID string
+	}
+
+	// GetScheduleResponse is the response to GetSchedule
+	GetScheduleResponse struct {
+		Schedule *ScheduleInfo
+	}
+
+	// UpdateScheduleRequest is used to update a schedule, it fails with ConditionFailedError if the version of the
+	// persisted schedule is not PreviousVersion
+	UpdateScheduleRequest struct {
+		Schedule        *ScheduleInfo
+		PreviousVersion int64
+	}
+
+	// DeleteScheduleRequest is used to delete a schedule
+	DeleteScheduleRequest struct {
+		DomainID   string
+		ScheduleID string
+	}
+
+	// ListSchedulesRequest is used to list the schedules of a domain, or of all domains if DomainID is empty
+	ListSchedulesRequest struct {
+		DomainID      string
+		PageSize      int
+		NextPageToken []byte
+	}
+
+	// ListSchedulesResponse is the response to ListSchedules
+	ListSchedulesResponse struct {
+		Schedules     []*ScheduleInfo
+		NextPageToken []byte
+	}
+
 	// CreateWorkflowExecutionRequest is used to write a new workflow execution
 	CreateWorkflowExecutionRequest struct {
 		RangeID int64
@@ -692,6 +964,8 @@ type (
 	GetWorkflowExecutionRequest struct {
 		DomainID  string
 		Execution workflow.WorkflowExecution
+		// Context bounds the store query by the deadline of the RPC caller, nil means unbounded
+		Context context.Context
 	}
 
 	// GetWorkflowExecutionResponse is the response to GetworkflowExecutionRequest
@@ -704,6 +978,8 @@ type (
 	GetCurrentExecutionRequest struct {
 		DomainID   string
 		WorkflowID string
+		// Context bounds the store query by the deadline of the RPC caller, nil means unbounded
+		Context context.Context
 	}
 
 	// GetCurrentExecutionResponse is the response to GetCurrentExecution
@@ -713,6 +989,8 @@ type (
 		State            int
 		CloseStatus      int
 		LastWriteVersion int64
+		// continue as new, cron and retry of the current run are rejected until this time
+		BlockedUntil time.Time
 	}
 
 	// UpdateWorkflowExecutionRequest is used to update a workflow execution
@@ -782,13 +1060,16 @@ type (
 		UpsertSignalInfos         []*SignalInfo
 		DeleteSignalInfo          *int64
 		UpsertSignalRequestedIDs  []string
-		DeleteSignalRequestedID   string
+		DeleteSignalRequestedIDs  []string
+		UpsertUpdateInfos         []*UpdateInfo
+		DeleteUpdateInfo          *string
 		NewBufferedEvents         []*workflow.HistoryEvent
 		ClearBufferedEvents       bool
 
 		TransferTasks    []Task
 		ReplicationTasks []Task
 		TimerTasks       []Task
+		VisibilityTasks  []Task
 
 		Condition int64
 	}
@@ -805,10 +1086,12 @@ type (
 		RequestCancelInfos  []*RequestCancelInfo
 		SignalInfos         []*SignalInfo
 		SignalRequestedIDs  []string
+		UpdateInfos         []*UpdateInfo
 
 		TransferTasks    []Task
 		ReplicationTasks []Task
 		TimerTasks       []Task
+		VisibilityTasks  []Task
 
 		Condition int64
 	}
@@ -820,11 +1103,29 @@ type (
 		RunID      string
 	}
 
-	// DeleteCurrentWorkflowExecutionRequest is used to delete the current workflow execution
+	// DeleteCurrentWorkflowExecutionRequest is used to delete the current workflow execution, the current
+	// record is only deleted if it points to RunID unless Force is set
 	DeleteCurrentWorkflowExecutionRequest struct {
 		DomainID   string
 		WorkflowID string
 		RunID      string
+		Force      bool
+	}
+
+	// DeleteCurrentWorkflowExecutionResponse is the response to DeleteCurrentWorkflowExecutionRequest
+	DeleteCurrentWorkflowExecutionResponse struct {
+		Result int
+		// CurrentRunID is the run the current record pointed to, empty if it is missing
+		CurrentRunID string
+	}
+
+	// BlockCurrentWorkflowExecutionRequest is used to temporarily block new runs from being started by the
+	// current workflow execution, the request fails with ConditionFailedError if RunID is no longer current
+	BlockCurrentWorkflowExecutionRequest struct {
+		DomainID     string
+		WorkflowID   string
+		RunID        string
+		BlockedUntil time.Time
 	}
 
 	// GetTransferTasksRequest is used to read tasks from the transfer task queue
@@ -841,6 +1142,20 @@ type (
 		NextPageToken []byte
 	}
 
+	// GetVisibilityTasksRequest is used to read tasks from the visibility task queue
+	GetVisibilityTasksRequest struct {
+		ReadLevel     int64
+		MaxReadLevel  int64
+		BatchSize     int
+		NextPageToken []byte
+	}
+
+	// GetVisibilityTasksResponse is the response to GetVisibilityTasksRequest
+	GetVisibilityTasksResponse struct {
+		Tasks         []*VisibilityTaskInfo
+		NextPageToken []byte
+	}
+
 	// GetReplicationTasksRequest is used to read tasks from the replication task queue
 	GetReplicationTasksRequest struct {
 		ReadLevel     int64
@@ -866,11 +1181,27 @@ type (
 		InclusiveEndTaskID   int64
 	}
 
+	// CompleteVisibilityTaskRequest is used to complete a task in the visibility task queue
+	CompleteVisibilityTaskRequest struct {
+		TaskID int64
+	}
+
+	// RangeCompleteVisibilityTaskRequest is used to complete a range of tasks in the visibility task queue
+	RangeCompleteVisibilityTaskRequest struct {
+		ExclusiveBeginTaskID int64
+		InclusiveEndTaskID   int64
+	}
+
 	// CompleteReplicationTaskRequest is used to complete a task in the replication task queue
 	CompleteReplicationTaskRequest struct {
 		TaskID int64
 	}
 
+	// RangeCompleteReplicationTaskRequest is used to complete a range of tasks in the replication task queue
+	RangeCompleteReplicationTaskRequest struct {
+		InclusiveEndTaskID int64
+	}
+
 	// RangeCompleteTimerTaskRequest is used to complete a range of tasks in the timer task queue
 	RangeCompleteTimerTaskRequest struct {
 		InclusiveBeginTimestamp time.Time
@@ -883,6 +1214,110 @@ type (
 		TaskID              int64
 	}
 
+	// ClosedExecutionIndexInfo describes an entry of the closed execution index of a shard
+	ClosedExecutionIndexInfo struct {
+		DomainID   string
+		WorkflowID string
+		RunID      string
+		CloseTime  time.Time
+		Version    int64
+	}
+
+	// WorkflowExecutionRunInfo describes a run of a workflow ID persisted in the execution store
+	WorkflowExecutionRunInfo struct {
+		RunID               string
+		FirstExecutionRunID string
+		StartTimestamp      time.Time
+		State               int
+		CloseStatus         int
+	}
+
+	// ListWorkflowExecutionRunsRequest is used to read the runs of a workflow ID persisted in the shard
+	ListWorkflowExecutionRunsRequest struct {
+		DomainID      string
+		WorkflowID    string
+		PageSize      int
+		NextPageToken []byte
+	}
+
+	// ListWorkflowExecutionRunsResponse is the response to ListWorkflowExecutionRunsRequest
+	ListWorkflowExecutionRunsResponse struct {
+		Runs          []*WorkflowExecutionRunInfo
+		NextPageToken []byte
+	}
+
+	// CurrentExecutionInfo describes the current row of a workflow ID, the run it points to and the
+	// state recorded for that run on the current row
+	CurrentExecutionInfo struct {
+		DomainID    string
+		WorkflowID  string
+		RunID       string
+		State       int
+		CloseStatus int
+	}
+
+	// ListCurrentExecutionsRequest is used to read the current rows of a shard
+	ListCurrentExecutionsRequest struct {
+		PageSize      int
+		NextPageToken []byte
+	}
+
+	// ListCurrentExecutionsResponse is the response to ListCurrentExecutionsRequest
+	ListCurrentExecutionsResponse struct {
+		Executions    []*CurrentExecutionInfo
+		NextPageToken []byte
+	}
+
+	// BufferedReplicationTasksInfo describes the buffered replication tasks left behind by an execution
+	BufferedReplicationTasksInfo struct {
+		DomainID   string
+		WorkflowID string
+		RunID      string
+		TaskCount  int
+	}
+
+	// ListBufferedReplicationTasksRequest is used to read the executions of a shard with buffered replication tasks
+	ListBufferedReplicationTasksRequest struct {
+		PageSize      int
+		NextPageToken []byte
+	}
+
+	// ListBufferedReplicationTasksResponse is the response to ListBufferedReplicationTasksRequest
+	ListBufferedReplicationTasksResponse struct {
+		Executions    []*BufferedReplicationTasksInfo
+		NextPageToken []byte
+	}
+
+	// DeleteBufferedReplicationTasksRequest is used to purge the buffered replication tasks of an execution
+	DeleteBufferedReplicationTasksRequest struct {
+		DomainID   string
+		WorkflowID string
+		RunID      string
+	}
+
+	// GetClosedExecutionIndexRequest is used to read the executions of a shard closed on the given day (UTC)
+	GetClosedExecutionIndexRequest struct {
+		Day time.Time
+		// MinCloseTime skips the executions of the day closed before it, zero reads the whole day
+		MinCloseTime  time.Time
+		BatchSize     int
+		NextPageToken []byte
+	}
+
+	// GetClosedExecutionIndexResponse is the response to GetClosedExecutionIndexRequest
+	GetClosedExecutionIndexResponse struct {
+		Executions    []*ClosedExecutionIndexInfo
+		NextPageToken []byte
+	}
+
+	// DeleteClosedExecutionIndexRequest is used to remove an entry from the closed execution index
+	DeleteClosedExecutionIndexRequest struct {
+		DomainID   string
+		WorkflowID string
+		RunID      string
+		CloseTime  time.Time
+	}
+
 	// LeaseTaskListRequest is used to request lease of a task list
 	LeaseTaskListRequest struct {
 		DomainID     string
@@ -951,6 +1386,8 @@ type (
 		ReadLevel    int64  // range exclusive
 		MaxReadLevel *int64 // optional: range inclusive when specified
 		BatchSize    int
+		// Context bounds the store query by the lifetime of the reader, nil means unbounded
+		Context context.Context
 	}
 
 	// GetTasksResponse is the response to GetTasksRequests
@@ -1068,6 +1505,32 @@ type (
 		ArchivalBucket string
 		ArchivalStatus workflow.ArchivalStatus
 		BadBinaries    workflow.BadBinaries
+		// RetentionRunCount is the number of most recent completed runs kept per workflow ID, 0 means unlimited
+		RetentionRunCount int32
+		TimeoutPolicy     DomainTimeoutPolicy
+		// EncryptionKeyAlias is the alias of the key the blobs of the domain are encrypted with, empty disables encryption
+		EncryptionKeyAlias string
+		Residency          DomainResidency
+	}
+
+	// DomainResidency describes where the executions of a domain may live, the executions are only placed in and
+	// replicated to the allowed clusters, no allowed clusters means no restriction
+	DomainResidency struct {
+		Tag             string
+		AllowedClusters []string
+	}
+
+	// DomainTimeoutPolicy describes the defaults applied to the timeouts left unset by the requests of a domain,
+	// and the maxima the requested timeouts are lowered to, 0 means no default or no maximum
+	DomainTimeoutPolicy struct {
+		DefaultExecutionTimeoutSeconds int32
+		MaxExecutionTimeoutSeconds     int32
+		DefaultTaskTimeoutSeconds      int32
+		MaxTaskTimeoutSeconds          int32
+		// DefaultActivityTimeoutSeconds is the schedule to close timeout of the activities scheduled without timeouts
+		DefaultActivityTimeoutSeconds int32
+		// MaxActivityTimeoutSeconds caps all the timeouts of an activity
+		MaxActivityTimeoutSeconds int32
 	}
 
 	// DomainReplicationConfig describes the cross DC domain replication configuration
@@ -1201,6 +1664,10 @@ type (
 		DeleteChildInfoCount         int
 		DeleteSignalInfoCount        int
 		DeleteRequestCancelInfoCount int
+
+		// Approximate footprint of the whole write on the datastore
+		TransactionStatementCount int
+		TransactionSize           int
 	}
 
 	//UpdateWorkflowExecutionResponse is response for UpdateWorkflowExecutionRequest
@@ -1224,6 +1691,8 @@ type (
 		Encoding common.EncodingType
 		// The shard to get history node data
 		ShardID *int
+		// The domain of the workflow, not persisted, used by the payload inspection
+		DomainID string
 	}
 
 	// AppendHistoryNodesResponse is a response to AppendHistoryNodesRequest
@@ -1247,6 +1716,8 @@ type (
 		NextPageToken []byte
 		// The shard to get history branch data
 		ShardID *int
+		// Context bounds the store queries by the deadline of the RPC caller, nil means unbounded
+		Context context.Context
 	}
 
 	// ReadHistoryBranchResponse is the response to ReadHistoryBranchRequest
@@ -1263,6 +1734,18 @@ type (
 		LastFirstEventID int64
 	}
 
+	// ReadRawHistoryBranchResponse is the response to ReadHistoryBranchRequest
+	ReadRawHistoryBranchResponse struct {
+		// HistoryEventBlobs history event batches as persisted
+		HistoryEventBlobs []*DataBlob
+		// Token to read next page if there are more events beyond page size.
+		// Use this to set NextPageToken on ReadHistoryBranchRequest to read the next page.
+		// Empty means we have reached the last page, not need to continue
+		NextPageToken []byte
+		// Size of history read from store
+		Size int
+	}
+
 	// ReadHistoryBranchByBatchResponse is the response to ReadHistoryBranchRequest
 	ReadHistoryBranchByBatchResponse struct {
 		// History events by batch
@@ -1289,6 +1772,8 @@ type (
 		Info string
 		// The shard to get history branch data
 		ShardID *int
+		// The ID of the new branch, generated when empty
+		NewBranchID string
 	}
 
 	// ForkHistoryBranchResponse is the response to ForkHistoryBranchRequest
@@ -1359,11 +1844,40 @@ type (
 		UpdateShard(request *UpdateShardRequest) error
 	}
 
+	// ClusterMembershipManager is used to manage the heartbeats of the hosts of the cluster
+	ClusterMembershipManager interface {
+		Closeable
+		GetName() string
+		UpsertClusterMembership(request *UpsertClusterMembershipRequest) error
+		GetClusterMembers(request *GetClusterMembersRequest) (*GetClusterMembersResponse, error)
+		PruneClusterMembership(request *PruneClusterMembershipRequest) error
+	}
+
+	// AuditManager is used to persist the audit log of mutating admin and domain API calls
+	AuditManager interface {
+		Closeable
+		GetName() string
+		RecordAuditEntry(request *RecordAuditEntryRequest) error
+	}
+
+	// ScheduleManager is used to manage schedules
+	ScheduleManager interface {
+		Closeable
+		GetName() string
+		CreateSchedule(request *CreateScheduleRequest) error
+		GetSchedule(request *GetScheduleRequest) (*GetScheduleResponse, error)
+		UpdateSchedule(request *UpdateScheduleRequest) error
+		DeleteSchedule(request *DeleteScheduleRequest) error
+		ListSchedules(request *ListSchedulesRequest) (*ListSchedulesResponse, error)
+	}
+
 	// ExecutionManager is used to manage workflow executions
 	ExecutionManager interface {
 		Closeable
 		GetName() string
 		GetShardID() int
+		// Ping reads a known row of the store to check it is reachable
+		Ping() error
 
 		CreateWorkflowExecution(request *CreateWorkflowExecutionRequest) (*CreateWorkflowExecutionResponse, error)
 		GetWorkflowExecution(request *GetWorkflowExecutionRequest) (*GetWorkflowExecutionResponse, error)
@@ -1371,22 +1885,39 @@ type (
 		ResetMutableState(request *ResetMutableStateRequest) error
 		ResetWorkflowExecution(request *ResetWorkflowExecutionRequest) error
 		DeleteWorkflowExecution(request *DeleteWorkflowExecutionRequest) error
-		DeleteCurrentWorkflowExecution(request *DeleteCurrentWorkflowExecutionRequest) error
+		DeleteCurrentWorkflowExecution(request *DeleteCurrentWorkflowExecutionRequest) (*DeleteCurrentWorkflowExecutionResponse, error)
 		GetCurrentExecution(request *GetCurrentExecutionRequest) (*GetCurrentExecutionResponse, error)
+		ListWorkflowExecutionRuns(request *ListWorkflowExecutionRunsRequest) (*ListWorkflowExecutionRunsResponse, error)
+		ListCurrentExecutions(request *ListCurrentExecutionsRequest) (*ListCurrentExecutionsResponse, error)
+		BlockCurrentWorkflowExecution(request *BlockCurrentWorkflowExecutionRequest) error
 
 		// Transfer task related methods
 		GetTransferTasks(request *GetTransferTasksRequest) (*GetTransferTasksResponse, error)
 		CompleteTransferTask(request *CompleteTransferTaskRequest) error
 		RangeCompleteTransferTask(request *RangeCompleteTransferTaskRequest) error
 
+		// Visibility task related methods
+		GetVisibilityTasks(request *GetVisibilityTasksRequest) (*GetVisibilityTasksResponse, error)
+		CompleteVisibilityTask(request *CompleteVisibilityTaskRequest) error
+		RangeCompleteVisibilityTask(request *RangeCompleteVisibilityTaskRequest) error
+
 		// Replication task related methods
 		GetReplicationTasks(request *GetReplicationTasksRequest) (*GetReplicationTasksResponse, error)
 		CompleteReplicationTask(request *CompleteReplicationTaskRequest) error
+		RangeCompleteReplicationTask(request *RangeCompleteReplicationTaskRequest) error
 
 		// Timer related methods.
 		GetTimerIndexTasks(request *GetTimerIndexTasksRequest) (*GetTimerIndexTasksResponse, error)
 		CompleteTimerTask(request *CompleteTimerTaskRequest) error
 		RangeCompleteTimerTask(request *RangeCompleteTimerTaskRequest) error
+
+		// Closed execution index related methods
+		GetClosedExecutionIndex(request *GetClosedExecutionIndexRequest) (*GetClosedExecutionIndexResponse, error)
+		DeleteClosedExecutionIndex(request *DeleteClosedExecutionIndexRequest) error
+
+		// Buffered replication task related methods
+		ListBufferedReplicationTasks(request *ListBufferedReplicationTasksRequest) (*ListBufferedReplicationTasksResponse, error)
+		DeleteBufferedReplicationTasks(request *DeleteBufferedReplicationTasksRequest) error
 	}
 
 	// ExecutionManagerFactory creates an instance of ExecutionManager for a given shard
@@ -1416,6 +1947,8 @@ type (
 		//  - number of rows actually deleted, if limit is honored
 		//  - UnknownNumRowsDeleted, when all rows below value are deleted
 		CompleteTasksLessThan(request *CompleteTasksLessThanRequest) (int, error)
+		// Ping reads a known row of the store to check it is reachable
+		Ping() error
 	}
 
 	// HistoryManager is used to manage Workflow Execution HistoryEventBatch
@@ -1450,6 +1983,8 @@ type (
 		ReadHistoryBranch(request *ReadHistoryBranchRequest) (*ReadHistoryBranchResponse, error)
 		// ReadHistoryBranchByBatch returns history node data for a branch ByBatch
 		ReadHistoryBranchByBatch(request *ReadHistoryBranchRequest) (*ReadHistoryBranchByBatchResponse, error)
+		// ReadRawHistoryBranch returns history node data for a branch as persisted, validated the same as ReadHistoryBranch
+		ReadRawHistoryBranch(request *ReadHistoryBranchRequest) (*ReadRawHistoryBranchResponse, error)
 		// ForkHistoryBranch forks a new branch from a old branch
 		ForkHistoryBranch(request *ForkHistoryBranchRequest) (*ForkHistoryBranchResponse, error)
 		// CompleteForkBranch will complete the forking process after update mutableState, this is to help preventing data leakage
@@ -1472,6 +2007,8 @@ type (
 		DeleteDomainByName(request *DeleteDomainByNameRequest) error
 		ListDomains(request *ListDomainsRequest) (*ListDomainsResponse, error)
 		GetMetadata() (*GetMetadataResponse, error)
+		// Ping reads a known row of the store to check it is reachable
+		Ping() error
 	}
 )
 
@@ -1507,6 +2044,15 @@ func (e *TransactionSizeLimitError) Error() string {
 	return e.Msg
 }
 
+func (e *StoreOperationError) Error() string {
+	return e.Msg
+}
+
+// InternalServiceError converts the error to the internal service error returned to the callers of the service
+func (e *StoreOperationError) InternalServiceError() *workflow.InternalServiceError {
+	return &workflow.InternalServiceError{Message: e.Msg}
+}
+
 // IsTimeoutError check whether error is TimeoutError
 func IsTimeoutError(err error) bool {
 	_, ok := err.(*TimeoutError)
@@ -1933,6 +2479,41 @@ func (u *WorkflowTimeoutTask) SetVisibilityTimestamp(t time.Time) {
 	u.VisibilityTimestamp = t
 }
 
+// GetType returns the type of the SLA timer task.
+func (u *WorkflowSLATimerTask) GetType() int {
+	return TaskTypeWorkflowSLATimer
+}
+
+// GetVersion returns the version of the SLA timer task
+func (u *WorkflowSLATimerTask) GetVersion() int64 {
+	return u.Version
+}
+
+// SetVersion returns the version of the SLA timer task
+func (u *WorkflowSLATimerTask) SetVersion(version int64) {
+	u.Version = version
+}
+
+// GetTaskID returns the sequence ID of the SLA timer task.
+func (u *WorkflowSLATimerTask) GetTaskID() int64 {
+	return u.TaskID
+}
+
+// SetTaskID sets the sequence ID of the SLA timer task.
+func (u *WorkflowSLATimerTask) SetTaskID(id int64) {
+	u.TaskID = id
+}
+
+// GetVisibilityTimestamp gets the visibility time stamp
+func (u *WorkflowSLATimerTask) GetVisibilityTimestamp() time.Time {
+	return u.VisibilityTimestamp
+}
+
+// SetVisibilityTimestamp gets the visibility time stamp
+func (u *WorkflowSLATimerTask) SetVisibilityTimestamp(t time.Time) {
+	u.VisibilityTimestamp = t
+}
+
 // GetType returns the type of the cancel transfer task
 func (u *CancelExecutionTask) GetType() int {
 	return TransferTaskTypeCancelExecution
@@ -2038,6 +2619,146 @@ func (u *UpsertWorkflowSearchAttributesTask) SetVisibilityTimestamp(timestamp ti
 	u.VisibilityTimestamp = timestamp
 }
 
+// GetType returns the type of the record workflow started visibility task
+func (r *RecordWorkflowStartedVisibilityTask) GetType() int {
+	return VisibilityTaskTypeRecordWorkflowStarted
+}
+
+// GetVersion returns the version of the record workflow started visibility task
+func (r *RecordWorkflowStartedVisibilityTask) GetVersion() int64 {
+	return r.Version
+}
+
+// SetVersion sets the version of the record workflow started visibility task
+func (r *RecordWorkflowStartedVisibilityTask) SetVersion(version int64) {
+	r.Version = version
+}
+
+// GetTaskID returns the sequence ID of the record workflow started visibility task
+func (r *RecordWorkflowStartedVisibilityTask) GetTaskID() int64 {
+	return r.TaskID
+}
+
+// SetTaskID sets the sequence ID of the record workflow started visibility task
+func (r *RecordWorkflowStartedVisibilityTask) SetTaskID(id int64) {
+	r.TaskID = id
+}
+
+// GetVisibilityTimestamp get the visibility timestamp
+func (r *RecordWorkflowStartedVisibilityTask) GetVisibilityTimestamp() time.Time {
+	return r.VisibilityTimestamp
+}
+
+// SetVisibilityTimestamp set the visibility timestamp
+func (r *RecordWorkflowStartedVisibilityTask) SetVisibilityTimestamp(timestamp time.Time) {
+	r.VisibilityTimestamp = timestamp
+}
+
+// GetType returns the type of the upsert search attributes visibility task
+func (u *UpsertWorkflowSearchAttributesVisibilityTask) GetType() int {
+	return VisibilityTaskTypeUpsertWorkflowSearchAttributes
+}
+
+// GetVersion returns the version of the upsert search attributes visibility task
+func (u *UpsertWorkflowSearchAttributesVisibilityTask) GetVersion() int64 {
+	return u.Version
+}
+
+// SetVersion sets the version of the upsert search attributes visibility task
+func (u *UpsertWorkflowSearchAttributesVisibilityTask) SetVersion(version int64) {
+	u.Version = version
+}
+
+// GetTaskID returns the sequence ID of the upsert search attributes visibility task
+func (u *UpsertWorkflowSearchAttributesVisibilityTask) GetTaskID() int64 {
+	return u.TaskID
+}
+
+// SetTaskID sets the sequence ID of the upsert search attributes visibility task
+func (u *UpsertWorkflowSearchAttributesVisibilityTask) SetTaskID(id int64) {
+	u.TaskID = id
+}
+
+// GetVisibilityTimestamp get the visibility timestamp
+func (u *UpsertWorkflowSearchAttributesVisibilityTask) GetVisibilityTimestamp() time.Time {
+	return u.VisibilityTimestamp
+}
+
+// SetVisibilityTimestamp set the visibility timestamp
+func (u *UpsertWorkflowSearchAttributesVisibilityTask) SetVisibilityTimestamp(timestamp time.Time) {
+	u.VisibilityTimestamp = timestamp
+}
+
+// GetType returns the type of the close execution visibility task
+func (c *CloseExecutionVisibilityTask) GetType() int {
+	return VisibilityTaskTypeCloseExecution
+}
+
+// GetVersion returns the version of the close execution visibility task
+func (c *CloseExecutionVisibilityTask) GetVersion() int64 {
+	return c.Version
+}
+
+// SetVersion sets the version of the close execution visibility task
+func (c *CloseExecutionVisibilityTask) SetVersion(version int64) {
+	c.Version = version
+}
+
+// GetTaskID returns the sequence ID of the close execution visibility task
+func (c *CloseExecutionVisibilityTask) GetTaskID() int64 {
+	return c.TaskID
+}
+
+// SetTaskID sets the sequence ID of the close execution visibility task
+func (c *CloseExecutionVisibilityTask) SetTaskID(id int64) {
+	c.TaskID = id
+}
+
+// GetVisibilityTimestamp get the visibility timestamp
+func (c *CloseExecutionVisibilityTask) GetVisibilityTimestamp() time.Time {
+	return c.VisibilityTimestamp
+}
+
+// SetVisibilityTimestamp set the visibility timestamp
+func (c *CloseExecutionVisibilityTask) SetVisibilityTimestamp(timestamp time.Time) {
+	c.VisibilityTimestamp = timestamp
+}
+
+// GetType returns the type of the record child execution completed transfer task
+func (r *RecordChildExecutionCompletedTask) GetType() int {
+	return TransferTaskTypeRecordChildExecutionCompleted
+}
+
+// GetVersion returns the version of the record child execution completed transfer task
+func (r *RecordChildExecutionCompletedTask) GetVersion() int64 {
+	return r.Version
+}
+
+// SetVersion returns the version of the record child execution completed transfer task
+func (r *RecordChildExecutionCompletedTask) SetVersion(version int64) {
+	r.Version = version
+}
+
+// GetTaskID returns the sequence ID of the record child execution completed transfer task
+func (r *RecordChildExecutionCompletedTask) GetTaskID() int64 {
+	return r.TaskID
+}
+
+// SetTaskID sets the sequence ID of the record child execution completed transfer task
+func (r *RecordChildExecutionCompletedTask) SetTaskID(id int64) {
+	r.TaskID = id
+}
+
+// GetVisibilityTimestamp get the visibility timestamp
+func (r *RecordChildExecutionCompletedTask) GetVisibilityTimestamp() time.Time {
+	return r.VisibilityTimestamp
+}
+
+// SetVisibilityTimestamp set the visibility timestamp
+func (r *RecordChildExecutionCompletedTask) SetVisibilityTimestamp(timestamp time.Time) {
+	r.VisibilityTimestamp = timestamp
+}
+
 // GetType returns the type of the start child transfer task
 func (u *StartChildExecutionTask) GetType() int {
 	return TransferTaskTypeStartChildExecution
@@ -2171,6 +2892,34 @@ func (t *TransferTaskInfo) String() string {
 	)
 }
 
+// GetTaskID returns the task ID for visibility task
+func (t *VisibilityTaskInfo) GetTaskID() int64 {
+	return t.TaskID
+}
+
+// GetVersion returns the task version for visibility task
+func (t *VisibilityTaskInfo) GetVersion() int64 {
+	return t.Version
+}
+
+// GetTaskType returns the task type for visibility task
+func (t *VisibilityTaskInfo) GetTaskType() int {
+	return t.TaskType
+}
+
+// GetVisibilityTimestamp returns the visibility timestamp for visibility task
+func (t *VisibilityTaskInfo) GetVisibilityTimestamp() time.Time {
+	return t.VisibilityTimestamp
+}
+
+// String returns string
+func (t *VisibilityTaskInfo) String() string {
+	return fmt.Sprintf(
+		"{DomainID: %v, WorkflowID: %v, RunID: %v, TaskID: %v, TaskType: %v, Version: %v, VisibilityTimestamp: %v}",
+		t.DomainID, t.WorkflowID, t.RunID, t.TaskID, t.TaskType, t.Version, t.VisibilityTimestamp,
+	)
+}
+
 // GetTaskID returns the task ID for replication task
 func (t *ReplicationTaskInfo) GetTaskID() int64 {
 	return t.TaskID
@@ -2317,3 +3066,24 @@ func NewHistoryBranchTokenFromAnother(branchID string, anotherToken []byte) ([]b
 	}
 	return token, nil
 }
+
+// HistoryBranchTreeID returns the ID of the history tree of a branchToken
+func HistoryBranchTreeID(branchToken []byte) (string, error) {
+	var branch workflow.HistoryBranch
+	if err := internalThriftEncoder.Decode(branchToken, &branch); err != nil {
+		return "", err
+	}
+	return branch.GetTreeID(), nil
+}
+
+// IsActiveClusterMember returns true if the member record did not expire and,
+// when lastHeartbeatWithin is positive, heartbeat within that duration
+func IsActiveClusterMember(member *ClusterMember, lastHeartbeatWithin time.Duration, now time.Time) bool {
+	if !member.RecordExpiry.IsZero() && member.RecordExpiry.Before(now) {
+		return false
+	}
+	if lastHeartbeatWithin > 0 && member.LastHeartbeat.Before(now.Add(-lastHeartbeatWithin)) {
+		return false
+	}
+	return true
+}
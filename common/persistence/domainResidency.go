@@ -0,0 +1,62 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package persistence
+
+import (
+	workflow "github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common"
+)
+
+// MergeDomainResidency returns the residency with the values set on the update applied to it, the values not set
+// on the update are kept
+func MergeDomainResidency(residency DomainResidency, update *workflow.DomainResidency) DomainResidency {
+	if update == nil {
+		return residency
+	}
+	if update.Tag != nil {
+		residency.Tag = update.GetTag()
+	}
+	if update.AllowedClusters != nil {
+		residency.AllowedClusters = append([]string{}, update.AllowedClusters...)
+	}
+	return residency
+}
+
+// IsClusterAllowed returns whether the executions of the domain may be placed in or replicated to the cluster
+func (r DomainResidency) IsClusterAllowed(clusterName string) bool {
+	if len(r.AllowedClusters) == 0 {
+		return true
+	}
+	for _, allowed := range r.AllowedClusters {
+		if allowed == clusterName {
+			return true
+		}
+	}
+	return false
+}
+
+// ToThrift converts the residency to its thrift representation
+func (r DomainResidency) ToThrift() *workflow.DomainResidency {
+	return &workflow.DomainResidency{
+		Tag:             common.StringPtr(r.Tag),
+		AllowedClusters: append([]string{}, r.AllowedClusters...),
+	}
+}
@@ -0,0 +1,65 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package persistence
+
+import (
+	workflow "github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common"
+)
+
+// MergeDomainTimeoutPolicy returns the timeout policy with the values set on the update applied to it, the values
+// not set on the update are kept
+func MergeDomainTimeoutPolicy(policy DomainTimeoutPolicy, update *workflow.DomainTimeoutPolicy) DomainTimeoutPolicy {
+	if update == nil {
+		return policy
+	}
+	if update.DefaultExecutionStartToCloseTimeoutSeconds != nil {
+		policy.DefaultExecutionTimeoutSeconds = update.GetDefaultExecutionStartToCloseTimeoutSeconds()
+	}
+	if update.MaxExecutionStartToCloseTimeoutSeconds != nil {
+		policy.MaxExecutionTimeoutSeconds = update.GetMaxExecutionStartToCloseTimeoutSeconds()
+	}
+	if update.DefaultTaskStartToCloseTimeoutSeconds != nil {
+		policy.DefaultTaskTimeoutSeconds = update.GetDefaultTaskStartToCloseTimeoutSeconds()
+	}
+	if update.MaxTaskStartToCloseTimeoutSeconds != nil {
+		policy.MaxTaskTimeoutSeconds = update.GetMaxTaskStartToCloseTimeoutSeconds()
+	}
+	if update.DefaultActivityScheduleToCloseTimeoutSeconds != nil {
+		policy.DefaultActivityTimeoutSeconds = update.GetDefaultActivityScheduleToCloseTimeoutSeconds()
+	}
+	if update.MaxActivityTimeoutSeconds != nil {
+		policy.MaxActivityTimeoutSeconds = update.GetMaxActivityTimeoutSeconds()
+	}
+	return policy
+}
+
+// ToThrift converts the timeout policy to its thrift representation
+func (p DomainTimeoutPolicy) ToThrift() *workflow.DomainTimeoutPolicy {
+	return &workflow.DomainTimeoutPolicy{
+		DefaultExecutionStartToCloseTimeoutSeconds:   common.Int32Ptr(p.DefaultExecutionTimeoutSeconds),
+		MaxExecutionStartToCloseTimeoutSeconds:       common.Int32Ptr(p.MaxExecutionTimeoutSeconds),
+		DefaultTaskStartToCloseTimeoutSeconds:        common.Int32Ptr(p.DefaultTaskTimeoutSeconds),
+		MaxTaskStartToCloseTimeoutSeconds:            common.Int32Ptr(p.MaxTaskTimeoutSeconds),
+		DefaultActivityScheduleToCloseTimeoutSeconds: common.Int32Ptr(p.DefaultActivityTimeoutSeconds),
+		MaxActivityTimeoutSeconds:                    common.Int32Ptr(p.MaxActivityTimeoutSeconds),
+	}
+}
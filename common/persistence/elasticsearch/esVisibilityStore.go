@@ -72,17 +72,18 @@ type (
 	}
 
 	visibilityRecord struct {
-		WorkflowID    string
-		RunID         string
-		WorkflowType  string
-		StartTime     int64
-		ExecutionTime int64
-		CloseTime     int64
-		CloseStatus   workflow.WorkflowExecutionCloseStatus
-		HistoryLength int64
-		Memo          []byte
-		Encoding      string
-		Attr          map[string]interface{}
+		WorkflowID          string
+		RunID               string
+		WorkflowType        string
+		StartTime           int64
+		ExecutionTime       int64
+		CloseTime           int64
+		FirstExecutionRunID string
+		CloseStatus         workflow.WorkflowExecutionCloseStatus
+		HistoryLength       int64
+		Memo                []byte
+		Encoding            string
+		Attr                map[string]interface{}
 	}
 )
 
@@ -118,6 +119,7 @@ func (v *esVisibilityStore) RecordWorkflowExecutionStarted(request *p.InternalRe
 		request.WorkflowTypeName,
 		request.StartTimestamp,
 		request.ExecutionTimestamp,
+		request.FirstExecutionRunID,
 		request.TaskID,
 		request.Memo.Data,
 		request.Memo.GetEncoding(),
@@ -135,6 +137,7 @@ func (v *esVisibilityStore) RecordWorkflowExecutionClosed(request *p.InternalRec
 		request.WorkflowTypeName,
 		request.StartTimestamp,
 		request.ExecutionTimestamp,
+		request.FirstExecutionRunID,
 		request.CloseTimestamp,
 		request.Status,
 		request.HistoryLength,
@@ -155,6 +158,7 @@ func (v *esVisibilityStore) UpsertWorkflowExecution(request *p.InternalUpsertWor
 		request.WorkflowTypeName,
 		request.StartTimestamp,
 		request.ExecutionTimestamp,
+		request.FirstExecutionRunID,
 		request.TaskID,
 		request.Memo.Data,
 		request.Memo.GetEncoding(),
@@ -888,13 +892,14 @@ func (v *esVisibilityStore) convertSearchResultToVisibilityRecord(hit *elastic.S
 	}
 
 	record := &p.VisibilityWorkflowExecutionInfo{
-		WorkflowID:       source.WorkflowID,
-		RunID:            source.RunID,
-		TypeName:         source.WorkflowType,
-		StartTime:        time.Unix(0, source.StartTime),
-		ExecutionTime:    time.Unix(0, source.ExecutionTime),
-		Memo:             p.NewDataBlob(source.Memo, common.EncodingType(source.Encoding)),
-		SearchAttributes: source.Attr,
+		WorkflowID:          source.WorkflowID,
+		RunID:               source.RunID,
+		TypeName:            source.WorkflowType,
+		StartTime:           time.Unix(0, source.StartTime),
+		ExecutionTime:       time.Unix(0, source.ExecutionTime),
+		Memo:                p.NewDataBlob(source.Memo, common.EncodingType(source.Encoding)),
+		SearchAttributes:    source.Attr,
+		FirstExecutionRunID: source.FirstExecutionRunID,
 	}
 	if source.CloseTime != 0 {
 		record.CloseTime = time.Unix(0, source.CloseTime)
@@ -906,7 +911,7 @@ func (v *esVisibilityStore) convertSearchResultToVisibilityRecord(hit *elastic.S
 }
 
 func getVisibilityMessage(domainID string, wid, rid string, workflowTypeName string,
-	startTimeUnixNano, executionTimeUnixNano int64, taskID int64, memo []byte, encoding common.EncodingType,
+	startTimeUnixNano, executionTimeUnixNano int64, firstExecutionRunID string, taskID int64, memo []byte, encoding common.EncodingType,
 	searchAttributes map[string][]byte) *indexer.Message {
 
 	msgType := indexer.MessageTypeIndex
@@ -915,6 +920,9 @@ func getVisibilityMessage(domainID string, wid, rid string, workflowTypeName str
 		es.StartTime:     {Type: &es.FieldTypeInt, IntData: common.Int64Ptr(startTimeUnixNano)},
 		es.ExecutionTime: {Type: &es.FieldTypeInt, IntData: common.Int64Ptr(executionTimeUnixNano)},
 	}
+	if firstExecutionRunID != "" {
+		fields[es.FirstExecutionRunID] = &indexer.Field{Type: &es.FieldTypeString, StringData: common.StringPtr(firstExecutionRunID)}
+	}
 	if len(memo) != 0 {
 		fields[es.Memo] = &indexer.Field{Type: &es.FieldTypeBinary, BinaryData: memo}
 		fields[es.Encoding] = &indexer.Field{Type: &es.FieldTypeString, StringData: common.StringPtr(string(encoding))}
@@ -935,7 +943,7 @@ func getVisibilityMessage(domainID string, wid, rid string, workflowTypeName str
 }
 
 func getVisibilityMessageForCloseExecution(domainID string, wid, rid string, workflowTypeName string,
-	startTimeUnixNano int64, executionTimeUnixNano int64, endTimeUnixNano int64, closeStatus workflow.WorkflowExecutionCloseStatus,
+	startTimeUnixNano int64, executionTimeUnixNano int64, firstExecutionRunID string, endTimeUnixNano int64, closeStatus workflow.WorkflowExecutionCloseStatus,
 	historyLength int64, taskID int64, memo []byte, encoding common.EncodingType,
 	searchAttributes map[string][]byte) *indexer.Message {
 
@@ -948,6 +956,9 @@ func getVisibilityMessageForCloseExecution(domainID string, wid, rid string, wor
 		es.CloseStatus:   {Type: &es.FieldTypeInt, IntData: common.Int64Ptr(int64(closeStatus))},
 		es.HistoryLength: {Type: &es.FieldTypeInt, IntData: common.Int64Ptr(historyLength)},
 	}
+	if firstExecutionRunID != "" {
+		fields[es.FirstExecutionRunID] = &indexer.Field{Type: &es.FieldTypeString, StringData: common.StringPtr(firstExecutionRunID)}
+	}
 	if len(memo) != 0 {
 		fields[es.Memo] = &indexer.Field{Type: &es.FieldTypeBinary, BinaryData: memo}
 		fields[es.Encoding] = &indexer.Field{Type: &es.FieldTypeString, StringData: common.StringPtr(string(encoding))}
@@ -0,0 +1,272 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package persistence
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	exportgen "github.com/uber/cadence/.gen/go/export"
+	workflow "github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/codec"
+)
+
+// ExecutionExportVersion is the version of the execution export format written by EncodeExecutionExport,
+// it must be bumped on every change of the format that older readers can't ignore
+const ExecutionExportVersion = 1
+
+const exportHistoryPageSize = 1000
+
+var (
+	// ErrExecutionExportRunning is the error indicating a running execution can't be imported, its transfer and
+	// timer tasks are not part of the export
+	ErrExecutionExportRunning = errors.New("running workflow executions can't be imported")
+	// ErrExecutionExportEventStoreVersion is the error indicating the history of the execution is not in the
+	// events v2 store
+	ErrExecutionExportEventStoreVersion = errors.New("only executions with a v2 history can be exported")
+)
+
+type (
+	// ExecutionExport is a snapshot of a workflow execution independent of the datastore it was read from:
+	// its mutable state and the batches of its history events as persisted. Visibility records and the
+	// transfer and timer tasks of the execution are not part of it
+	ExecutionExport struct {
+		Version      int
+		ExportedTime time.Time
+		MutableState *WorkflowMutableState
+		History      []*DataBlob
+	}
+)
+
+// ExportWorkflowExecution reads the snapshot of a workflow execution from the execution and history stores
+func ExportWorkflowExecution(executionMgr ExecutionManager, historyV2Mgr HistoryV2Manager,
+	domainID string, execution workflow.WorkflowExecution) (*ExecutionExport, error) {
+
+	resp, err := executionMgr.GetWorkflowExecution(&GetWorkflowExecutionRequest{
+		DomainID:  domainID,
+		Execution: execution,
+	})
+	if err != nil {
+		return nil, err
+	}
+	executionInfo := resp.State.ExecutionInfo
+	if executionInfo.EventStoreVersion != EventStoreVersionV2 {
+		return nil, ErrExecutionExportEventStoreVersion
+	}
+
+	shardID := executionMgr.GetShardID()
+	export := &ExecutionExport{
+		Version:      ExecutionExportVersion,
+		ExportedTime: time.Now(),
+		MutableState: resp.State,
+	}
+	request := &ReadHistoryBranchRequest{
+		BranchToken: executionInfo.GetCurrentBranch(),
+		MinEventID:  common.FirstEventID,
+		MaxEventID:  executionInfo.NextEventID,
+		PageSize:    exportHistoryPageSize,
+		ShardID:     &shardID,
+	}
+	for {
+		historyResp, err := historyV2Mgr.ReadRawHistoryBranch(request)
+		if err != nil {
+			return nil, err
+		}
+		export.History = append(export.History, historyResp.HistoryEventBlobs...)
+		if len(historyResp.NextPageToken) == 0 {
+			return export, nil
+		}
+		request.NextPageToken = historyResp.NextPageToken
+	}
+}
+
+// ImportWorkflowExecution writes the snapshot of a closed workflow execution to the execution and history
+// stores of the shard of the workflow, the history is written to a new tree of the execution. The run
+// becomes the current run of the workflow, an open current run of the workflow fails the import
+func ImportWorkflowExecution(executionMgr ExecutionManager, historyV2Mgr HistoryV2Manager,
+	rangeID int64, export *ExecutionExport) error {
+
+	state := export.MutableState
+	executionInfo := *state.ExecutionInfo
+	if executionInfo.State != WorkflowStateCompleted {
+		return ErrExecutionExportRunning
+	}
+
+	createMode := CreateWorkflowModeBrandNew
+	previousRunID := ""
+	previousLastWriteVersion := common.EmptyVersion
+	currentResp, err := executionMgr.GetCurrentExecution(&GetCurrentExecutionRequest{
+		DomainID:   executionInfo.DomainID,
+		WorkflowID: executionInfo.WorkflowID,
+	})
+	switch err.(type) {
+	case nil:
+		if currentResp.State != WorkflowStateCompleted {
+			return &WorkflowExecutionAlreadyStartedError{
+				Msg:              fmt.Sprintf("Workflow execution is already running. WorkflowId: %v, RunId: %v.", executionInfo.WorkflowID, currentResp.RunID),
+				StartRequestID:   currentResp.StartRequestID,
+				RunID:            currentResp.RunID,
+				State:            currentResp.State,
+				CloseStatus:      currentResp.CloseStatus,
+				LastWriteVersion: currentResp.LastWriteVersion,
+			}
+		}
+		createMode = CreateWorkflowModeWorkflowIDReuse
+		previousRunID = currentResp.RunID
+		previousLastWriteVersion = currentResp.LastWriteVersion
+	case *workflow.EntityNotExistsError:
+	default:
+		return err
+	}
+
+	branchToken, err := NewHistoryBranchToken(executionInfo.RunID)
+	if err != nil {
+		return err
+	}
+	shardID := executionMgr.GetShardID()
+	cleanupInfo := fmt.Sprintf("%v:%v:%v", executionInfo.DomainID, executionInfo.WorkflowID, executionInfo.RunID)
+	serializer := NewPayloadSerializer()
+	isNewBranch := true
+	for _, blob := range export.History {
+		events, err := serializer.DeserializeBatchEvents(blob)
+		if err != nil {
+			return err
+		}
+		if len(events) == 0 {
+			continue
+		}
+		if _, err := historyV2Mgr.AppendHistoryNodes(&AppendHistoryNodesRequest{
+			IsNewBranch:   isNewBranch,
+			Info:          cleanupInfo,
+			BranchToken:   branchToken,
+			Events:        events,
+			TransactionID: events[0].GetEventId(),
+			Encoding:      blob.Encoding,
+			ShardID:       &shardID,
+		}); err != nil {
+			return err
+		}
+		isNewBranch = false
+	}
+	executionInfo.BranchToken = branchToken
+	state.ExecutionInfo = &executionInfo
+
+	_, err = executionMgr.CreateWorkflowExecution(&CreateWorkflowExecutionRequest{
+		RangeID:                  rangeID,
+		CreateWorkflowMode:       createMode,
+		PreviousRunID:            previousRunID,
+		PreviousLastWriteVersion: previousLastWriteVersion,
+		NewWorkflowSnapshot:      NewExecutionExportSnapshot(export),
+	})
+	return err
+}
+
+// RewriteExecutionExportRun rewrites the snapshot of a workflow execution as a new run of its workflow in the
+// given domain, the run IDs the started event refers to are replaced so that the run starts a new chain of runs.
+// It returns the batches of history events of the new run
+func RewriteExecutionExportRun(export *ExecutionExport, domainID string, runID string) ([][]*workflow.HistoryEvent, error) {
+	executionInfo := *export.MutableState.ExecutionInfo
+	executionInfo.DomainID = domainID
+	executionInfo.RunID = runID
+	executionInfo.FirstExecutionRunID = runID
+	export.MutableState.ExecutionInfo = &executionInfo
+
+	serializer := NewPayloadSerializer()
+	batches := make([][]*workflow.HistoryEvent, 0, len(export.History))
+	for _, blob := range export.History {
+		events, err := serializer.DeserializeBatchEvents(blob)
+		if err != nil {
+			return nil, err
+		}
+		if len(events) == 0 {
+			continue
+		}
+		for _, event := range events {
+			if attributes := event.WorkflowExecutionStartedEventAttributes; attributes != nil {
+				attributes.OriginalExecutionRunId = common.StringPtr(runID)
+				attributes.FirstExecutionRunId = common.StringPtr(runID)
+				attributes.ContinuedExecutionRunId = nil
+			}
+		}
+		batches = append(batches, events)
+	}
+	return batches, nil
+}
+
+// NewExecutionExportSnapshot returns the snapshot creating the workflow execution of an export, without tasks
+func NewExecutionExportSnapshot(export *ExecutionExport) WorkflowSnapshot {
+	state := export.MutableState
+	snapshot := WorkflowSnapshot{
+		ExecutionInfo:    state.ExecutionInfo,
+		ExecutionStats:   state.ExecutionStats,
+		ReplicationState: state.ReplicationState,
+		Condition:        state.ExecutionInfo.NextEventID,
+	}
+	for _, info := range state.ActivityInfos {
+		snapshot.ActivityInfos = append(snapshot.ActivityInfos, info)
+	}
+	for _, info := range state.TimerInfos {
+		snapshot.TimerInfos = append(snapshot.TimerInfos, info)
+	}
+	for _, info := range state.ChildExecutionInfos {
+		snapshot.ChildExecutionInfos = append(snapshot.ChildExecutionInfos, info)
+	}
+	for _, info := range state.RequestCancelInfos {
+		snapshot.RequestCancelInfos = append(snapshot.RequestCancelInfos, info)
+	}
+	for _, info := range state.SignalInfos {
+		snapshot.SignalInfos = append(snapshot.SignalInfos, info)
+	}
+	for id := range state.SignalRequestedIDs {
+		snapshot.SignalRequestedIDs = append(snapshot.SignalRequestedIDs, id)
+	}
+	for _, info := range state.UpdateInfos {
+		snapshot.UpdateInfos = append(snapshot.UpdateInfos, info)
+	}
+	if snapshot.ExecutionStats == nil {
+		snapshot.ExecutionStats = &ExecutionStats{}
+	}
+	return snapshot
+}
+
+// EncodeExecutionExport encodes the snapshot of a workflow execution in the export format, the thrift
+// ExecutionExport of export.thrift
+func EncodeExecutionExport(export *ExecutionExport) ([]byte, error) {
+	return codec.NewThriftRWEncoder().Encode(executionExportToThrift(export))
+}
+
+// DecodeExecutionExport decodes the snapshot of a workflow execution from the export format, exports of a
+// newer version of the format are rejected
+func DecodeExecutionExport(data []byte) (*ExecutionExport, error) {
+	encoded := &exportgen.ExecutionExport{}
+	if err := codec.NewThriftRWEncoder().Decode(data, encoded); err != nil {
+		return nil, err
+	}
+	if version := encoded.GetVersion(); version <= 0 || version > ExecutionExportVersion {
+		return nil, fmt.Errorf("unsupported execution export version %v", version)
+	}
+	if encoded.ExecutionInfo == nil {
+		return nil, errors.New("execution export has no execution info")
+	}
+	return executionExportFromThrift(encoded), nil
+}
@@ -0,0 +1,483 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package persistence
+
+import (
+	"time"
+
+	"github.com/uber/cadence/.gen/go/export"
+	"github.com/uber/cadence/common"
+)
+
+// the conversions between an execution export and its wire format, defined in export.thrift. Maps of the
+// mutable state are written as lists, keyed again by the ID each element carries when read back
+
+func executionExportToThrift(e *ExecutionExport) *export.ExecutionExport {
+	state := e.MutableState
+	result := &export.ExecutionExport{
+		Version:           common.Int32Ptr(int32(e.Version)),
+		ExportedTimeNanos: timeToExportNanos(e.ExportedTime),
+		ExecutionInfo:     executionInfoToThrift(state.ExecutionInfo),
+		ReplicationState:  replicationStateToThrift(state.ReplicationState),
+		BufferedEvents:    state.BufferedEvents,
+	}
+	if state.ExecutionStats != nil {
+		result.HistorySize = common.Int64Ptr(state.ExecutionStats.HistorySize)
+	}
+	for _, v := range state.ActivityInfos {
+		result.ActivityInfos = append(result.ActivityInfos, activityInfoToThrift(v))
+	}
+	for _, v := range state.TimerInfos {
+		result.TimerInfos = append(result.TimerInfos, &export.TimerInfo{
+			Version:         common.Int64Ptr(v.Version),
+			TimerID:         common.StringPtr(v.TimerID),
+			StartedID:       common.Int64Ptr(v.StartedID),
+			ExpiryTimeNanos: timeToExportNanos(v.ExpiryTime),
+			TaskID:          common.Int64Ptr(v.TaskID),
+		})
+	}
+	for _, v := range state.ChildExecutionInfos {
+		result.ChildExecutionInfos = append(result.ChildExecutionInfos, &export.ChildExecutionInfo{
+			Version:               common.Int64Ptr(v.Version),
+			InitiatedID:           common.Int64Ptr(v.InitiatedID),
+			InitiatedEventBatchID: common.Int64Ptr(v.InitiatedEventBatchID),
+			InitiatedEvent:        v.InitiatedEvent,
+			StartedID:             common.Int64Ptr(v.StartedID),
+			StartedWorkflowID:     common.StringPtr(v.StartedWorkflowID),
+			StartedRunID:          common.StringPtr(v.StartedRunID),
+			StartedEvent:          v.StartedEvent,
+			CreateRequestID:       common.StringPtr(v.CreateRequestID),
+			DomainName:            common.StringPtr(v.DomainName),
+			WorkflowTypeName:      common.StringPtr(v.WorkflowTypeName),
+		})
+	}
+	for _, v := range state.RequestCancelInfos {
+		result.RequestCancelInfos = append(result.RequestCancelInfos, &export.RequestCancelInfo{
+			Version:         common.Int64Ptr(v.Version),
+			InitiatedID:     common.Int64Ptr(v.InitiatedID),
+			CancelRequestID: common.StringPtr(v.CancelRequestID),
+		})
+	}
+	for _, v := range state.SignalInfos {
+		result.SignalInfos = append(result.SignalInfos, &export.SignalInfo{
+			Version:     common.Int64Ptr(v.Version),
+			InitiatedID: common.Int64Ptr(v.InitiatedID),
+			RequestID:   common.StringPtr(v.SignalRequestID),
+			Name:        common.StringPtr(v.SignalName),
+			Input:       v.Input,
+			Control:     v.Control,
+		})
+	}
+	for id := range state.SignalRequestedIDs {
+		result.SignalRequestedIDs = append(result.SignalRequestedIDs, id)
+	}
+	for _, v := range state.UpdateInfos {
+		result.UpdateInfos = append(result.UpdateInfos, &export.UpdateInfo{
+			Version:   common.Int64Ptr(v.Version),
+			UpdateID:  common.StringPtr(v.UpdateID),
+			Name:      common.StringPtr(v.UpdateName),
+			Input:     v.Input,
+			Result:    v.Result,
+			Completed: common.BoolPtr(v.Completed),
+		})
+	}
+	for _, blob := range e.History {
+		result.History = append(result.History, &export.HistoryBlob{
+			Encoding: common.StringPtr(string(blob.Encoding)),
+			Data:     blob.Data,
+		})
+	}
+	return result
+}
+
+func executionExportFromThrift(e *export.ExecutionExport) *ExecutionExport {
+	state := &WorkflowMutableState{
+		ActivityInfos:       make(map[int64]*ActivityInfo, len(e.ActivityInfos)),
+		TimerInfos:          make(map[string]*TimerInfo, len(e.TimerInfos)),
+		ChildExecutionInfos: make(map[int64]*ChildExecutionInfo, len(e.ChildExecutionInfos)),
+		RequestCancelInfos:  make(map[int64]*RequestCancelInfo, len(e.RequestCancelInfos)),
+		SignalInfos:         make(map[int64]*SignalInfo, len(e.SignalInfos)),
+		SignalRequestedIDs:  make(map[string]struct{}, len(e.SignalRequestedIDs)),
+		UpdateInfos:         make(map[string]*UpdateInfo, len(e.UpdateInfos)),
+		ExecutionStats:      &ExecutionStats{HistorySize: e.GetHistorySize()},
+		ReplicationState:    replicationStateFromThrift(e.ReplicationState),
+		BufferedEvents:      e.BufferedEvents,
+	}
+	if e.ExecutionInfo != nil {
+		state.ExecutionInfo = executionInfoFromThrift(e.ExecutionInfo)
+	}
+	for _, v := range e.ActivityInfos {
+		info := activityInfoFromThrift(v)
+		state.ActivityInfos[info.ScheduleID] = info
+	}
+	for _, v := range e.TimerInfos {
+		state.TimerInfos[v.GetTimerID()] = &TimerInfo{
+			Version:    v.GetVersion(),
+			TimerID:    v.GetTimerID(),
+			StartedID:  v.GetStartedID(),
+			ExpiryTime: timeFromExportNanos(v.ExpiryTimeNanos),
+			TaskID:     v.GetTaskID(),
+		}
+	}
+	for _, v := range e.ChildExecutionInfos {
+		state.ChildExecutionInfos[v.GetInitiatedID()] = &ChildExecutionInfo{
+			Version:               v.GetVersion(),
+			InitiatedID:           v.GetInitiatedID(),
+			InitiatedEventBatchID: v.GetInitiatedEventBatchID(),
+			InitiatedEvent:        v.InitiatedEvent,
+			StartedID:             v.GetStartedID(),
+			StartedWorkflowID:     v.GetStartedWorkflowID(),
+			StartedRunID:          v.GetStartedRunID(),
+			StartedEvent:          v.StartedEvent,
+			CreateRequestID:       v.GetCreateRequestID(),
+			DomainName:            v.GetDomainName(),
+			WorkflowTypeName:      v.GetWorkflowTypeName(),
+		}
+	}
+	for _, v := range e.RequestCancelInfos {
+		state.RequestCancelInfos[v.GetInitiatedID()] = &RequestCancelInfo{
+			Version:         v.GetVersion(),
+			InitiatedID:     v.GetInitiatedID(),
+			CancelRequestID: v.GetCancelRequestID(),
+		}
+	}
+	for _, v := range e.SignalInfos {
+		state.SignalInfos[v.GetInitiatedID()] = &SignalInfo{
+			Version:         v.GetVersion(),
+			InitiatedID:     v.GetInitiatedID(),
+			SignalRequestID: v.GetRequestID(),
+			SignalName:      v.GetName(),
+			Input:           v.Input,
+			Control:         v.Control,
+		}
+	}
+	for _, id := range e.SignalRequestedIDs {
+		state.SignalRequestedIDs[id] = struct{}{}
+	}
+	for _, v := range e.UpdateInfos {
+		state.UpdateInfos[v.GetUpdateID()] = &UpdateInfo{
+			Version:    v.GetVersion(),
+			UpdateID:   v.GetUpdateID(),
+			UpdateName: v.GetName(),
+			Input:      v.Input,
+			Result:     v.Result,
+			Completed:  v.GetCompleted(),
+		}
+	}
+
+	result := &ExecutionExport{
+		Version:      int(e.GetVersion()),
+		ExportedTime: timeFromExportNanos(e.ExportedTimeNanos),
+		MutableState: state,
+	}
+	for _, blob := range e.History {
+		result.History = append(result.History, &DataBlob{
+			Encoding: common.EncodingType(blob.GetEncoding()),
+			Data:     blob.Data,
+		})
+	}
+	return result
+}
+
+func executionInfoToThrift(info *WorkflowExecutionInfo) *export.WorkflowExecutionInfo {
+	result := &export.WorkflowExecutionInfo{
+		DomainID:                        common.StringPtr(info.DomainID),
+		WorkflowID:                      common.StringPtr(info.WorkflowID),
+		RunID:                           common.StringPtr(info.RunID),
+		FirstExecutionRunID:             common.StringPtr(info.FirstExecutionRunID),
+		ParentDomainID:                  common.StringPtr(info.ParentDomainID),
+		ParentWorkflowID:                common.StringPtr(info.ParentWorkflowID),
+		ParentRunID:                     common.StringPtr(info.ParentRunID),
+		InitiatedID:                     common.Int64Ptr(info.InitiatedID),
+		CompletionEventBatchID:          common.Int64Ptr(info.CompletionEventBatchID),
+		CompletionEvent:                 info.CompletionEvent,
+		TaskList:                        common.StringPtr(info.TaskList),
+		WorkflowTypeName:                common.StringPtr(info.WorkflowTypeName),
+		WorkflowTimeoutSeconds:          common.Int32Ptr(info.WorkflowTimeout),
+		DecisionTaskTimeoutSeconds:      common.Int32Ptr(info.DecisionTimeoutValue),
+		ExecutionContext:                info.ExecutionContext,
+		State:                           common.Int32Ptr(int32(info.State)),
+		CloseStatus:                     common.Int32Ptr(int32(info.CloseStatus)),
+		LastFirstEventID:                common.Int64Ptr(info.LastFirstEventID),
+		LastEventTaskID:                 common.Int64Ptr(info.LastEventTaskID),
+		NextEventID:                     common.Int64Ptr(info.NextEventID),
+		LastProcessedEvent:              common.Int64Ptr(info.LastProcessedEvent),
+		StartTimeNanos:                  timeToExportNanos(info.StartTimestamp),
+		LastUpdatedTimeNanos:            timeToExportNanos(info.LastUpdatedTimestamp),
+		CreateRequestID:                 common.StringPtr(info.CreateRequestID),
+		SignalCount:                     common.Int32Ptr(info.SignalCount),
+		DecisionVersion:                 common.Int64Ptr(info.DecisionVersion),
+		DecisionScheduleID:              common.Int64Ptr(info.DecisionScheduleID),
+		DecisionStartedID:               common.Int64Ptr(info.DecisionStartedID),
+		DecisionRequestID:               common.StringPtr(info.DecisionRequestID),
+		DecisionTimeout:                 common.Int32Ptr(info.DecisionTimeout),
+		DecisionAttempt:                 common.Int64Ptr(info.DecisionAttempt),
+		DecisionHeartbeatCount:          common.Int64Ptr(info.DecisionHeartbeatCount),
+		DecisionStartedTimestampNanos:   common.Int64Ptr(info.DecisionStartedTimestamp),
+		DecisionScheduledTimestampNanos: common.Int64Ptr(info.DecisionScheduledTimestamp),
+		DecisionStartedIdentity:         common.StringPtr(info.DecisionStartedIdentity),
+		DecisionStartedBinaryChecksum:   common.StringPtr(info.DecisionStartedBinaryChecksum),
+		ResetBaseRunID:                  common.StringPtr(info.ResetBaseRunID),
+		ResetBaseEventID:                common.Int64Ptr(info.ResetBaseEventID),
+		ResetReason:                     common.StringPtr(info.ResetReason),
+		CancelRequested:                 common.BoolPtr(info.CancelRequested),
+		CancelRequestID:                 common.StringPtr(info.CancelRequestID),
+		StickyTaskList:                  common.StringPtr(info.StickyTaskList),
+		StickyScheduleToStartTimeout:    common.Int32Ptr(info.StickyScheduleToStartTimeout),
+		ClientLibraryVersion:            common.StringPtr(info.ClientLibraryVersion),
+		ClientFeatureVersion:            common.StringPtr(info.ClientFeatureVersion),
+		ClientImpl:                      common.StringPtr(info.ClientImpl),
+		AutoResetPoints:                 info.AutoResetPoints,
+		Attempt:                         common.Int32Ptr(info.Attempt),
+		HasRetryPolicy:                  common.BoolPtr(info.HasRetryPolicy),
+		RetryInitialIntervalSeconds:     common.Int32Ptr(info.InitialInterval),
+		RetryBackoffCoefficient:         common.Float64Ptr(info.BackoffCoefficient),
+		RetryMaximumIntervalSeconds:     common.Int32Ptr(info.MaximumInterval),
+		RetryExpirationTimeNanos:        timeToExportNanos(info.ExpirationTime),
+		RetryMaximumAttempts:            common.Int32Ptr(info.MaximumAttempts),
+		RetryNonRetryableErrors:         info.NonRetriableErrors,
+		RetryExpirationSeconds:          common.Int32Ptr(info.ExpirationSeconds),
+		EventStoreVersion:               common.Int32Ptr(info.EventStoreVersion),
+		BranchToken:                     info.BranchToken,
+		CronSchedule:                    common.StringPtr(info.CronSchedule),
+		CronPaused:                      common.BoolPtr(info.CronPaused),
+		CronTimezone:                    common.StringPtr(info.CronTimezone),
+		CompletedActivityRequestIDs:     info.CompletedActivityRequestIDs,
+		Paused:                          common.BoolPtr(info.Paused),
+		Notes:                           common.StringPtr(info.Notes),
+		NotesIdentity:                   common.StringPtr(info.NotesIdentity),
+		NotesLastUpdatedTimeNanos:       timeToExportNanos(info.NotesLastUpdatedTime),
+	}
+	for key, value := range info.SearchAttributes {
+		result.SearchAttributes = append(result.SearchAttributes, &export.SearchAttribute{
+			Key:   common.StringPtr(key),
+			Value: value,
+		})
+	}
+	return result
+}
+
+func executionInfoFromThrift(info *export.WorkflowExecutionInfo) *WorkflowExecutionInfo {
+	result := &WorkflowExecutionInfo{
+		DomainID:                      info.GetDomainID(),
+		WorkflowID:                    info.GetWorkflowID(),
+		RunID:                         info.GetRunID(),
+		FirstExecutionRunID:           info.GetFirstExecutionRunID(),
+		ParentDomainID:                info.GetParentDomainID(),
+		ParentWorkflowID:              info.GetParentWorkflowID(),
+		ParentRunID:                   info.GetParentRunID(),
+		InitiatedID:                   info.GetInitiatedID(),
+		CompletionEventBatchID:        info.GetCompletionEventBatchID(),
+		CompletionEvent:               info.CompletionEvent,
+		TaskList:                      info.GetTaskList(),
+		WorkflowTypeName:              info.GetWorkflowTypeName(),
+		WorkflowTimeout:               info.GetWorkflowTimeoutSeconds(),
+		DecisionTimeoutValue:          info.GetDecisionTaskTimeoutSeconds(),
+		ExecutionContext:              info.ExecutionContext,
+		State:                         int(info.GetState()),
+		CloseStatus:                   int(info.GetCloseStatus()),
+		LastFirstEventID:              info.GetLastFirstEventID(),
+		LastEventTaskID:               info.GetLastEventTaskID(),
+		NextEventID:                   info.GetNextEventID(),
+		LastProcessedEvent:            info.GetLastProcessedEvent(),
+		StartTimestamp:                timeFromExportNanos(info.StartTimeNanos),
+		LastUpdatedTimestamp:          timeFromExportNanos(info.LastUpdatedTimeNanos),
+		CreateRequestID:               info.GetCreateRequestID(),
+		SignalCount:                   info.GetSignalCount(),
+		DecisionVersion:               info.GetDecisionVersion(),
+		DecisionScheduleID:            info.GetDecisionScheduleID(),
+		DecisionStartedID:             info.GetDecisionStartedID(),
+		DecisionRequestID:             info.GetDecisionRequestID(),
+		DecisionTimeout:               info.GetDecisionTimeout(),
+		DecisionAttempt:               info.GetDecisionAttempt(),
+		DecisionHeartbeatCount:        info.GetDecisionHeartbeatCount(),
+		DecisionStartedTimestamp:      info.GetDecisionStartedTimestampNanos(),
+		DecisionScheduledTimestamp:    info.GetDecisionScheduledTimestampNanos(),
+		DecisionStartedIdentity:       info.GetDecisionStartedIdentity(),
+		DecisionStartedBinaryChecksum: info.GetDecisionStartedBinaryChecksum(),
+		ResetBaseRunID:                info.GetResetBaseRunID(),
+		ResetBaseEventID:              info.GetResetBaseEventID(),
+		ResetReason:                   info.GetResetReason(),
+		CancelRequested:               info.GetCancelRequested(),
+		CancelRequestID:               info.GetCancelRequestID(),
+		StickyTaskList:                info.GetStickyTaskList(),
+		StickyScheduleToStartTimeout:  info.GetStickyScheduleToStartTimeout(),
+		ClientLibraryVersion:          info.GetClientLibraryVersion(),
+		ClientFeatureVersion:          info.GetClientFeatureVersion(),
+		ClientImpl:                    info.GetClientImpl(),
+		AutoResetPoints:               info.AutoResetPoints,
+		SearchAttributes:              make(map[string][]byte, len(info.SearchAttributes)),
+		Attempt:                       info.GetAttempt(),
+		HasRetryPolicy:                info.GetHasRetryPolicy(),
+		InitialInterval:               info.GetRetryInitialIntervalSeconds(),
+		BackoffCoefficient:            info.GetRetryBackoffCoefficient(),
+		MaximumInterval:               info.GetRetryMaximumIntervalSeconds(),
+		ExpirationTime:                timeFromExportNanos(info.RetryExpirationTimeNanos),
+		MaximumAttempts:               info.GetRetryMaximumAttempts(),
+		NonRetriableErrors:            info.RetryNonRetryableErrors,
+		EventStoreVersion:             info.GetEventStoreVersion(),
+		BranchToken:                   info.BranchToken,
+		CronSchedule:                  info.GetCronSchedule(),
+		ExpirationSeconds:             info.GetRetryExpirationSeconds(),
+		CompletedActivityRequestIDs:   info.CompletedActivityRequestIDs,
+		Paused:                        info.GetPaused(),
+		CronPaused:                    info.GetCronPaused(),
+		CronTimezone:                  info.GetCronTimezone(),
+		Notes:                         info.GetNotes(),
+		NotesIdentity:                 info.GetNotesIdentity(),
+		NotesLastUpdatedTime:          timeFromExportNanos(info.NotesLastUpdatedTimeNanos),
+	}
+	for _, attribute := range info.SearchAttributes {
+		result.SearchAttributes[attribute.GetKey()] = attribute.Value
+	}
+	return result
+}
+
+func activityInfoToThrift(info *ActivityInfo) *export.ActivityInfo {
+	return &export.ActivityInfo{
+		Version:                       common.Int64Ptr(info.Version),
+		ScheduleID:                    common.Int64Ptr(info.ScheduleID),
+		ScheduledEventBatchID:         common.Int64Ptr(info.ScheduledEventBatchID),
+		ScheduledEvent:                info.ScheduledEvent,
+		ScheduledTimeNanos:            timeToExportNanos(info.ScheduledTime),
+		StartedID:                     common.Int64Ptr(info.StartedID),
+		StartedEvent:                  info.StartedEvent,
+		StartedTimeNanos:              timeToExportNanos(info.StartedTime),
+		ActivityID:                    common.StringPtr(info.ActivityID),
+		RequestID:                     common.StringPtr(info.RequestID),
+		Details:                       info.Details,
+		ScheduleToStartTimeoutSeconds: common.Int32Ptr(info.ScheduleToStartTimeout),
+		ScheduleToCloseTimeoutSeconds: common.Int32Ptr(info.ScheduleToCloseTimeout),
+		StartToCloseTimeoutSeconds:    common.Int32Ptr(info.StartToCloseTimeout),
+		HeartbeatTimeoutSeconds:       common.Int32Ptr(info.HeartbeatTimeout),
+		CancelRequested:               common.BoolPtr(info.CancelRequested),
+		CancelRequestID:               common.Int64Ptr(info.CancelRequestID),
+		LastHeartbeatUpdatedTimeNanos: timeToExportNanos(info.LastHeartBeatUpdatedTime),
+		TimerTaskStatus:               common.Int32Ptr(info.TimerTaskStatus),
+		Paused:                        common.BoolPtr(info.Paused),
+		Attempt:                       common.Int32Ptr(info.Attempt),
+		DomainID:                      common.StringPtr(info.DomainID),
+		StartedIdentity:               common.StringPtr(info.StartedIdentity),
+		TaskList:                      common.StringPtr(info.TaskList),
+		HasRetryPolicy:                common.BoolPtr(info.HasRetryPolicy),
+		RetryInitialIntervalSeconds:   common.Int32Ptr(info.InitialInterval),
+		RetryBackoffCoefficient:       common.Float64Ptr(info.BackoffCoefficient),
+		RetryMaximumIntervalSeconds:   common.Int32Ptr(info.MaximumInterval),
+		RetryExpirationTimeNanos:      timeToExportNanos(info.ExpirationTime),
+		RetryMaximumAttempts:          common.Int32Ptr(info.MaximumAttempts),
+		RetryNonRetryableErrors:       info.NonRetriableErrors,
+		RetryLastFailureReason:        common.StringPtr(info.LastFailureReason),
+		RetryLastWorkerIdentity:       common.StringPtr(info.LastWorkerIdentity),
+	}
+}
+
+func activityInfoFromThrift(info *export.ActivityInfo) *ActivityInfo {
+	return &ActivityInfo{
+		Version:                  info.GetVersion(),
+		ScheduleID:               info.GetScheduleID(),
+		ScheduledEventBatchID:    info.GetScheduledEventBatchID(),
+		ScheduledEvent:           info.ScheduledEvent,
+		ScheduledTime:            timeFromExportNanos(info.ScheduledTimeNanos),
+		StartedID:                info.GetStartedID(),
+		StartedEvent:             info.StartedEvent,
+		StartedTime:              timeFromExportNanos(info.StartedTimeNanos),
+		ActivityID:               info.GetActivityID(),
+		RequestID:                info.GetRequestID(),
+		Details:                  info.Details,
+		ScheduleToStartTimeout:   info.GetScheduleToStartTimeoutSeconds(),
+		ScheduleToCloseTimeout:   info.GetScheduleToCloseTimeoutSeconds(),
+		StartToCloseTimeout:      info.GetStartToCloseTimeoutSeconds(),
+		HeartbeatTimeout:         info.GetHeartbeatTimeoutSeconds(),
+		CancelRequested:          info.GetCancelRequested(),
+		CancelRequestID:          info.GetCancelRequestID(),
+		LastHeartBeatUpdatedTime: timeFromExportNanos(info.LastHeartbeatUpdatedTimeNanos),
+		TimerTaskStatus:          info.GetTimerTaskStatus(),
+		Paused:                   info.GetPaused(),
+		Attempt:                  info.GetAttempt(),
+		DomainID:                 info.GetDomainID(),
+		StartedIdentity:          info.GetStartedIdentity(),
+		TaskList:                 info.GetTaskList(),
+		HasRetryPolicy:           info.GetHasRetryPolicy(),
+		InitialInterval:          info.GetRetryInitialIntervalSeconds(),
+		BackoffCoefficient:       info.GetRetryBackoffCoefficient(),
+		MaximumInterval:          info.GetRetryMaximumIntervalSeconds(),
+		ExpirationTime:           timeFromExportNanos(info.RetryExpirationTimeNanos),
+		MaximumAttempts:          info.GetRetryMaximumAttempts(),
+		NonRetriableErrors:       info.RetryNonRetryableErrors,
+		LastFailureReason:        info.GetRetryLastFailureReason(),
+		LastWorkerIdentity:       info.GetRetryLastWorkerIdentity(),
+	}
+}
+
+func replicationStateToThrift(state *ReplicationState) *export.ReplicationState {
+	if state == nil {
+		return nil
+	}
+	result := &export.ReplicationState{
+		CurrentVersion:   common.Int64Ptr(state.CurrentVersion),
+		StartVersion:     common.Int64Ptr(state.StartVersion),
+		LastWriteVersion: common.Int64Ptr(state.LastWriteVersion),
+		LastWriteEventID: common.Int64Ptr(state.LastWriteEventID),
+	}
+	for cluster, info := range state.LastReplicationInfo {
+		result.LastReplicationInfo = append(result.LastReplicationInfo, &export.ReplicationInfo{
+			Cluster:     common.StringPtr(cluster),
+			Version:     common.Int64Ptr(info.Version),
+			LastEventID: common.Int64Ptr(info.LastEventID),
+		})
+	}
+	return result
+}
+
+func replicationStateFromThrift(state *export.ReplicationState) *ReplicationState {
+	if state == nil {
+		return nil
+	}
+	result := &ReplicationState{
+		CurrentVersion:      state.GetCurrentVersion(),
+		StartVersion:        state.GetStartVersion(),
+		LastWriteVersion:    state.GetLastWriteVersion(),
+		LastWriteEventID:    state.GetLastWriteEventID(),
+		LastReplicationInfo: make(map[string]*ReplicationInfo, len(state.LastReplicationInfo)),
+	}
+	for _, info := range state.LastReplicationInfo {
+		result.LastReplicationInfo[info.GetCluster()] = &ReplicationInfo{
+			Version:     info.GetVersion(),
+			LastEventID: info.GetLastEventID(),
+		}
+	}
+	return result
+}
+
+// timeToExportNanos leaves unset times out of the export, the unix nanos of the zero time overflow
+func timeToExportNanos(t time.Time) *int64 {
+	if t.IsZero() {
+		return nil
+	}
+	return common.Int64Ptr(t.UnixNano())
+}
+
+func timeFromExportNanos(nanos *int64) time.Time {
+	if nanos == nil {
+		return time.Time{}
+	}
+	return time.Unix(0, *nanos)
+}
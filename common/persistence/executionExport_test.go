@@ -0,0 +1,192 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package persistence
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"github.com/uber/cadence/.gen/go/export"
+	workflow "github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/codec"
+)
+
+type (
+	executionExportSuite struct {
+		suite.Suite
+		*require.Assertions
+	}
+)
+
+func TestExecutionExportSuite(t *testing.T) {
+	suite.Run(t, new(executionExportSuite))
+}
+
+func (s *executionExportSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+}
+
+func (s *executionExportSuite) TestEncodeDecode() {
+	events := []*workflow.HistoryEvent{{
+		EventId:   common.Int64Ptr(common.FirstEventID),
+		EventType: workflow.EventTypeWorkflowExecutionStarted.Ptr(),
+		Timestamp: common.Int64Ptr(time.Now().UnixNano()),
+	}}
+	blob, err := NewPayloadSerializer().SerializeBatchEvents(events, common.EncodingTypeThriftRW)
+	s.NoError(err)
+
+	export := &ExecutionExport{
+		Version:      ExecutionExportVersion,
+		ExportedTime: time.Now().UTC(),
+		MutableState: &WorkflowMutableState{
+			ExecutionInfo: &WorkflowExecutionInfo{
+				DomainID:    "domain-id",
+				WorkflowID:  "workflow-id",
+				RunID:       "run-id",
+				NextEventID: 2,
+				State:       WorkflowStateCompleted,
+			},
+			ActivityInfos: map[int64]*ActivityInfo{},
+		},
+		History: []*DataBlob{blob},
+	}
+	data, err := EncodeExecutionExport(export)
+	s.NoError(err)
+
+	decoded, err := DecodeExecutionExport(data)
+	s.NoError(err)
+	s.Equal(export.MutableState.ExecutionInfo.RunID, decoded.MutableState.ExecutionInfo.RunID)
+	s.Equal(export.MutableState.ExecutionInfo.NextEventID, decoded.MutableState.ExecutionInfo.NextEventID)
+	s.True(export.ExportedTime.Equal(decoded.ExportedTime))
+	decodedEvents, err := NewPayloadSerializer().DeserializeBatchEvents(decoded.History[0])
+	s.NoError(err)
+	s.Equal(events, decodedEvents)
+}
+
+func (s *executionExportSuite) TestEncodeDecode_MutableState() {
+	now := time.Unix(0, time.Now().UnixNano())
+	state := &WorkflowMutableState{
+		ExecutionInfo: &WorkflowExecutionInfo{
+			DomainID:               "domain-id",
+			WorkflowID:             "workflow-id",
+			RunID:                  "run-id",
+			State:                  WorkflowStateCompleted,
+			CloseStatus:            WorkflowCloseStatusCompleted,
+			StartTimestamp:         now,
+			SearchAttributes:       map[string][]byte{"CustomKeywordField": []byte(`"keyword"`)},
+			Notes:                  "being investigated",
+			NotesIdentity:          "operator",
+			NotesLastUpdatedTime:   now,
+			DecisionAttempt:        2,
+			DecisionHeartbeatCount: 3,
+		},
+		ActivityInfos: map[int64]*ActivityInfo{
+			5: {ScheduleID: 5, ActivityID: "activity-id", ScheduledTime: now, NonRetriableErrors: []string{"error"}},
+		},
+		TimerInfos:          map[string]*TimerInfo{"timer-id": {TimerID: "timer-id", StartedID: 6, ExpiryTime: now}},
+		ChildExecutionInfos: map[int64]*ChildExecutionInfo{7: {InitiatedID: 7, StartedRunID: "child-run-id"}},
+		RequestCancelInfos:  map[int64]*RequestCancelInfo{8: {InitiatedID: 8, CancelRequestID: "cancel-id"}},
+		SignalInfos:         map[int64]*SignalInfo{9: {InitiatedID: 9, SignalName: "signal", Input: []byte("input")}},
+		SignalRequestedIDs:  map[string]struct{}{"signal-request-id": {}},
+		UpdateInfos:         map[string]*UpdateInfo{"update-id": {UpdateID: "update-id", UpdateName: "update", Completed: true}},
+		ExecutionStats:      &ExecutionStats{HistorySize: 1024},
+		ReplicationState: &ReplicationState{
+			LastWriteVersion:    10,
+			LastReplicationInfo: map[string]*ReplicationInfo{"standby": {Version: 9, LastEventID: 4}},
+		},
+	}
+	data, err := EncodeExecutionExport(&ExecutionExport{
+		Version:      ExecutionExportVersion,
+		MutableState: state,
+	})
+	s.NoError(err)
+
+	decoded, err := DecodeExecutionExport(data)
+	s.NoError(err)
+	s.True(decoded.ExportedTime.IsZero())
+	s.Equal(state, decoded.MutableState)
+}
+
+func (s *executionExportSuite) TestDecodeUnsupportedVersion() {
+	export := &ExecutionExport{
+		Version:      ExecutionExportVersion + 1,
+		MutableState: &WorkflowMutableState{ExecutionInfo: &WorkflowExecutionInfo{}},
+	}
+	data, err := EncodeExecutionExport(export)
+	s.NoError(err)
+	_, err = DecodeExecutionExport(data)
+	s.Error(err)
+
+	_, err = DecodeExecutionExport([]byte(`{"version":1}`))
+	s.Error(err)
+
+	data, err = codec.NewThriftRWEncoder().Encode(&export.ExecutionExport{
+		Version: common.Int32Ptr(ExecutionExportVersion),
+	})
+	s.NoError(err)
+	_, err = DecodeExecutionExport(data)
+	s.Error(err)
+}
+
+func (s *executionExportSuite) TestRewriteExecutionExportRun() {
+	runID := "run-id"
+	events := []*workflow.HistoryEvent{{
+		EventId:   common.Int64Ptr(common.FirstEventID),
+		EventType: workflow.EventTypeWorkflowExecutionStarted.Ptr(),
+		WorkflowExecutionStartedEventAttributes: &workflow.WorkflowExecutionStartedEventAttributes{
+			OriginalExecutionRunId:  common.StringPtr(runID),
+			FirstExecutionRunId:     common.StringPtr("first-run-id"),
+			ContinuedExecutionRunId: common.StringPtr("previous-run-id"),
+		},
+	}}
+	blob, err := NewPayloadSerializer().SerializeBatchEvents(events, common.EncodingTypeThriftRW)
+	s.NoError(err)
+	empty, err := NewPayloadSerializer().SerializeBatchEvents(nil, common.EncodingTypeThriftRW)
+	s.NoError(err)
+	executionInfo := &WorkflowExecutionInfo{
+		DomainID:            "domain-id",
+		WorkflowID:          "workflow-id",
+		RunID:               runID,
+		FirstExecutionRunID: "first-run-id",
+	}
+	export := &ExecutionExport{
+		Version:      ExecutionExportVersion,
+		MutableState: &WorkflowMutableState{ExecutionInfo: executionInfo},
+		History:      []*DataBlob{blob, empty},
+	}
+
+	batches, err := RewriteExecutionExportRun(export, "new-domain-id", "new-run-id")
+	s.NoError(err)
+	s.Len(batches, 1)
+	attributes := batches[0][0].WorkflowExecutionStartedEventAttributes
+	s.Equal("new-run-id", attributes.GetOriginalExecutionRunId())
+	s.Equal("new-run-id", attributes.GetFirstExecutionRunId())
+	s.Nil(attributes.ContinuedExecutionRunId)
+	s.Equal("new-domain-id", export.MutableState.ExecutionInfo.DomainID)
+	s.Equal("new-run-id", export.MutableState.ExecutionInfo.RunID)
+	s.Equal("new-run-id", export.MutableState.ExecutionInfo.FirstExecutionRunID)
+	s.Equal("workflow-id", export.MutableState.ExecutionInfo.WorkflowID)
+	// the execution info passed in is left untouched
+	s.Equal(runID, executionInfo.RunID)
+}
@@ -0,0 +1,216 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package persistence
+
+import (
+	workflow "github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common/log"
+	"github.com/uber/cadence/common/log/tag"
+)
+
+var (
+	// ErrPersistenceDomainFrozen is the error indicating the mutable state writes of the domain are frozen
+	ErrPersistenceDomainFrozen = &workflow.ServiceBusyError{Message: "Domain is frozen for migration, workflow updates are rejected."}
+)
+
+type (
+	// DomainFrozenFn returns whether the mutable state writes of the domain are frozen
+	DomainFrozenFn func(domainID string) bool
+
+	workflowExecutionFreezeClient struct {
+		isDomainFrozen DomainFrozenFn
+		persistence    ExecutionManager
+		logger         log.Logger
+	}
+)
+
+var _ ExecutionManager = (*workflowExecutionFreezeClient)(nil)
+
+// NewWorkflowExecutionPersistenceFreezeClient creates a client to manage executions that rejects
+// the mutable state writes of frozen domains with a retryable error, reads are always allowed.
+// It is used to quiesce a domain while its executions are migrated to another cluster or store.
+func NewWorkflowExecutionPersistenceFreezeClient(persistence ExecutionManager, isDomainFrozen DomainFrozenFn, logger log.Logger) ExecutionManager {
+	return &workflowExecutionFreezeClient{
+		isDomainFrozen: isDomainFrozen,
+		persistence:    persistence,
+		logger:         logger,
+	}
+}
+
+func (p *workflowExecutionFreezeClient) GetName() string {
+	return p.persistence.GetName()
+}
+
+func (p *workflowExecutionFreezeClient) GetShardID() int {
+	return p.persistence.GetShardID()
+}
+
+func (p *workflowExecutionFreezeClient) Ping() error {
+	return p.persistence.Ping()
+}
+
+func (p *workflowExecutionFreezeClient) CreateWorkflowExecution(request *CreateWorkflowExecutionRequest) (*CreateWorkflowExecutionResponse, error) {
+	if err := p.checkDomainFrozen(request.NewWorkflowSnapshot.ExecutionInfo.DomainID); err != nil {
+		return nil, err
+	}
+
+	return p.persistence.CreateWorkflowExecution(request)
+}
+
+func (p *workflowExecutionFreezeClient) GetWorkflowExecution(request *GetWorkflowExecutionRequest) (*GetWorkflowExecutionResponse, error) {
+	return p.persistence.GetWorkflowExecution(request)
+}
+
+func (p *workflowExecutionFreezeClient) UpdateWorkflowExecution(request *UpdateWorkflowExecutionRequest) (*UpdateWorkflowExecutionResponse, error) {
+	if err := p.checkDomainFrozen(request.UpdateWorkflowMutation.ExecutionInfo.DomainID); err != nil {
+		return nil, err
+	}
+
+	return p.persistence.UpdateWorkflowExecution(request)
+}
+
+func (p *workflowExecutionFreezeClient) ResetMutableState(request *ResetMutableStateRequest) error {
+	if err := p.checkDomainFrozen(request.ResetWorkflowSnapshot.ExecutionInfo.DomainID); err != nil {
+		return err
+	}
+
+	return p.persistence.ResetMutableState(request)
+}
+
+func (p *workflowExecutionFreezeClient) ResetWorkflowExecution(request *ResetWorkflowExecutionRequest) error {
+	if err := p.checkDomainFrozen(request.NewWorkflowSnapshot.ExecutionInfo.DomainID); err != nil {
+		return err
+	}
+
+	return p.persistence.ResetWorkflowExecution(request)
+}
+
+func (p *workflowExecutionFreezeClient) DeleteWorkflowExecution(request *DeleteWorkflowExecutionRequest) error {
+	if err := p.checkDomainFrozen(request.DomainID); err != nil {
+		return err
+	}
+
+	return p.persistence.DeleteWorkflowExecution(request)
+}
+
+func (p *workflowExecutionFreezeClient) DeleteCurrentWorkflowExecution(request *DeleteCurrentWorkflowExecutionRequest) (*DeleteCurrentWorkflowExecutionResponse, error) {
+	if err := p.checkDomainFrozen(request.DomainID); err != nil {
+		return nil, err
+	}
+
+	return p.persistence.DeleteCurrentWorkflowExecution(request)
+}
+
+func (p *workflowExecutionFreezeClient) GetCurrentExecution(request *GetCurrentExecutionRequest) (*GetCurrentExecutionResponse, error) {
+	return p.persistence.GetCurrentExecution(request)
+}
+
+func (p *workflowExecutionFreezeClient) ListWorkflowExecutionRuns(request *ListWorkflowExecutionRunsRequest) (*ListWorkflowExecutionRunsResponse, error) {
+	return p.persistence.ListWorkflowExecutionRuns(request)
+}
+
+func (p *workflowExecutionFreezeClient) ListCurrentExecutions(request *ListCurrentExecutionsRequest) (*ListCurrentExecutionsResponse, error) {
+	return p.persistence.ListCurrentExecutions(request)
+}
+
+func (p *workflowExecutionFreezeClient) BlockCurrentWorkflowExecution(request *BlockCurrentWorkflowExecutionRequest) error {
+	if err := p.checkDomainFrozen(request.DomainID); err != nil {
+		return err
+	}
+
+	return p.persistence.BlockCurrentWorkflowExecution(request)
+}
+
+func (p *workflowExecutionFreezeClient) GetTransferTasks(request *GetTransferTasksRequest) (*GetTransferTasksResponse, error) {
+	return p.persistence.GetTransferTasks(request)
+}
+
+func (p *workflowExecutionFreezeClient) GetReplicationTasks(request *GetReplicationTasksRequest) (*GetReplicationTasksResponse, error) {
+	return p.persistence.GetReplicationTasks(request)
+}
+
+func (p *workflowExecutionFreezeClient) CompleteTransferTask(request *CompleteTransferTaskRequest) error {
+	return p.persistence.CompleteTransferTask(request)
+}
+
+func (p *workflowExecutionFreezeClient) RangeCompleteTransferTask(request *RangeCompleteTransferTaskRequest) error {
+	return p.persistence.RangeCompleteTransferTask(request)
+}
+
+func (p *workflowExecutionFreezeClient) GetVisibilityTasks(request *GetVisibilityTasksRequest) (*GetVisibilityTasksResponse, error) {
+	return p.persistence.GetVisibilityTasks(request)
+}
+
+func (p *workflowExecutionFreezeClient) CompleteVisibilityTask(request *CompleteVisibilityTaskRequest) error {
+	return p.persistence.CompleteVisibilityTask(request)
+}
+
+func (p *workflowExecutionFreezeClient) RangeCompleteVisibilityTask(request *RangeCompleteVisibilityTaskRequest) error {
+	return p.persistence.RangeCompleteVisibilityTask(request)
+}
+
+func (p *workflowExecutionFreezeClient) CompleteReplicationTask(request *CompleteReplicationTaskRequest) error {
+	return p.persistence.CompleteReplicationTask(request)
+}
+
+func (p *workflowExecutionFreezeClient) RangeCompleteReplicationTask(request *RangeCompleteReplicationTaskRequest) error {
+	return p.persistence.RangeCompleteReplicationTask(request)
+}
+
+func (p *workflowExecutionFreezeClient) GetTimerIndexTasks(request *GetTimerIndexTasksRequest) (*GetTimerIndexTasksResponse, error) {
+	return p.persistence.GetTimerIndexTasks(request)
+}
+
+func (p *workflowExecutionFreezeClient) CompleteTimerTask(request *CompleteTimerTaskRequest) error {
+	return p.persistence.CompleteTimerTask(request)
+}
+
+func (p *workflowExecutionFreezeClient) RangeCompleteTimerTask(request *RangeCompleteTimerTaskRequest) error {
+	return p.persistence.RangeCompleteTimerTask(request)
+}
+
+func (p *workflowExecutionFreezeClient) GetClosedExecutionIndex(request *GetClosedExecutionIndexRequest) (*GetClosedExecutionIndexResponse, error) {
+	return p.persistence.GetClosedExecutionIndex(request)
+}
+
+func (p *workflowExecutionFreezeClient) DeleteClosedExecutionIndex(request *DeleteClosedExecutionIndexRequest) error {
+	return p.persistence.DeleteClosedExecutionIndex(request)
+}
+
+func (p *workflowExecutionFreezeClient) ListBufferedReplicationTasks(request *ListBufferedReplicationTasksRequest) (*ListBufferedReplicationTasksResponse, error) {
+	return p.persistence.ListBufferedReplicationTasks(request)
+}
+
+func (p *workflowExecutionFreezeClient) DeleteBufferedReplicationTasks(request *DeleteBufferedReplicationTasksRequest) error {
+	return p.persistence.DeleteBufferedReplicationTasks(request)
+}
+
+func (p *workflowExecutionFreezeClient) Close() {
+	p.persistence.Close()
+}
+
+func (p *workflowExecutionFreezeClient) checkDomainFrozen(domainID string) error {
+	if !p.isDomainFrozen(domainID) {
+		return nil
+	}
+
+	p.logger.Debug("Rejected workflow update of frozen domain", tag.WorkflowDomainID(domainID))
+	return ErrPersistenceDomainFrozen
+}
@@ -0,0 +1,122 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package persistence
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	workflow "github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/log/loggerimpl"
+)
+
+type (
+	executionFreezeClientSuite struct {
+		suite.Suite
+		*require.Assertions
+	}
+
+	testFreezeExecutionManager struct {
+		ExecutionManager
+		updated []*UpdateWorkflowExecutionRequest
+		deleted []*DeleteWorkflowExecutionRequest
+		reads   int
+	}
+)
+
+func TestExecutionFreezeClientSuite(t *testing.T) {
+	suite.Run(t, new(executionFreezeClientSuite))
+}
+
+func (s *executionFreezeClientSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+}
+
+func (s *executionFreezeClientSuite) TestFrozenDomainWritesRejected() {
+	executionMgr := &testFreezeExecutionManager{}
+	client := NewWorkflowExecutionPersistenceFreezeClient(executionMgr, func(domainID string) bool {
+		return domainID == "frozen-domain-id"
+	}, loggerimpl.NewNopLogger())
+
+	_, err := client.UpdateWorkflowExecution(newFreezeTestUpdateRequest("frozen-domain-id"))
+	s.Equal(ErrPersistenceDomainFrozen, err)
+	s.IsType(&workflow.ServiceBusyError{}, err)
+	err = client.DeleteWorkflowExecution(&DeleteWorkflowExecutionRequest{DomainID: "frozen-domain-id"})
+	s.Equal(ErrPersistenceDomainFrozen, err)
+	s.Empty(executionMgr.updated)
+	s.Empty(executionMgr.deleted)
+
+	// the writes of other domains go through
+	_, err = client.UpdateWorkflowExecution(newFreezeTestUpdateRequest("domain-id"))
+	s.NoError(err)
+	s.NoError(client.DeleteWorkflowExecution(&DeleteWorkflowExecutionRequest{DomainID: "domain-id"}))
+	s.Len(executionMgr.updated, 1)
+	s.Len(executionMgr.deleted, 1)
+}
+
+func (s *executionFreezeClientSuite) TestFrozenDomainReadsAllowed() {
+	executionMgr := &testFreezeExecutionManager{}
+	client := NewWorkflowExecutionPersistenceFreezeClient(executionMgr, func(domainID string) bool {
+		return true
+	}, loggerimpl.NewNopLogger())
+
+	_, err := client.GetWorkflowExecution(&GetWorkflowExecutionRequest{
+		DomainID: "frozen-domain-id",
+		Execution: workflow.WorkflowExecution{
+			WorkflowId: common.StringPtr("workflow-id"),
+			RunId:      common.StringPtr("run-id"),
+		},
+	})
+	s.NoError(err)
+	_, err = client.GetCurrentExecution(&GetCurrentExecutionRequest{DomainID: "frozen-domain-id", WorkflowID: "workflow-id"})
+	s.NoError(err)
+	s.Equal(2, executionMgr.reads)
+}
+
+func newFreezeTestUpdateRequest(domainID string) *UpdateWorkflowExecutionRequest {
+	return &UpdateWorkflowExecutionRequest{
+		UpdateWorkflowMutation: WorkflowMutation{
+			ExecutionInfo: &WorkflowExecutionInfo{DomainID: domainID},
+		},
+	}
+}
+
+func (m *testFreezeExecutionManager) UpdateWorkflowExecution(request *UpdateWorkflowExecutionRequest) (*UpdateWorkflowExecutionResponse, error) {
+	m.updated = append(m.updated, request)
+	return &UpdateWorkflowExecutionResponse{}, nil
+}
+
+func (m *testFreezeExecutionManager) DeleteWorkflowExecution(request *DeleteWorkflowExecutionRequest) error {
+	m.deleted = append(m.deleted, request)
+	return nil
+}
+
+func (m *testFreezeExecutionManager) GetWorkflowExecution(request *GetWorkflowExecutionRequest) (*GetWorkflowExecutionResponse, error) {
+	m.reads++
+	return &GetWorkflowExecutionResponse{}, nil
+}
+
+func (m *testFreezeExecutionManager) GetCurrentExecution(request *GetCurrentExecutionRequest) (*GetCurrentExecutionResponse, error) {
+	m.reads++
+	return &GetCurrentExecutionResponse{}, nil
+}
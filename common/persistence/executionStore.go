@@ -21,18 +21,24 @@
 package persistence
 
 import (
+	"fmt"
+
 	workflow "github.com/uber/cadence/.gen/go/shared"
 	"github.com/uber/cadence/common"
 	"github.com/uber/cadence/common/log"
+	"github.com/uber/cadence/common/log/tag"
+	"github.com/uber/cadence/common/service/dynamicconfig"
 )
 
 type (
 	// executionManagerImpl implements ExecutionManager based on ExecutionStore, statsComputer and PayloadSerializer
 	executionManagerImpl struct {
-		serializer    PayloadSerializer
-		persistence   ExecutionStore
-		statsComputer statsComputer
-		logger        log.Logger
+		serializer                PayloadSerializer
+		persistence               ExecutionStore
+		statsComputer             statsComputer
+		logger                    log.Logger
+		transactionStatementLimit dynamicconfig.IntPropertyFn
+		transactionSizeLimit      dynamicconfig.IntPropertyFn
 	}
 )
 
@@ -42,13 +48,17 @@ var _ ExecutionManager = (*executionManagerImpl)(nil)
 func NewExecutionManagerImpl(
 	persistence ExecutionStore,
 	logger log.Logger,
+	transactionStatementLimit dynamicconfig.IntPropertyFn,
+	transactionSizeLimit dynamicconfig.IntPropertyFn,
 ) ExecutionManager {
 
 	return &executionManagerImpl{
-		serializer:    NewPayloadSerializer(),
-		persistence:   persistence,
-		statsComputer: statsComputer{},
-		logger:        logger,
+		serializer:                NewPayloadSerializer(),
+		persistence:               persistence,
+		statsComputer:             statsComputer{},
+		logger:                    logger,
+		transactionStatementLimit: transactionStatementLimit,
+		transactionSizeLimit:      transactionSizeLimit,
 	}
 }
 
@@ -60,6 +70,10 @@ func (m *executionManagerImpl) GetShardID() int {
 	return m.persistence.GetShardID()
 }
 
+func (m *executionManagerImpl) Ping() error {
+	return m.persistence.Ping()
+}
+
 //The below three APIs are related to serialization/deserialization
 func (m *executionManagerImpl) GetWorkflowExecution(
 	request *GetWorkflowExecutionRequest,
@@ -75,6 +89,7 @@ func (m *executionManagerImpl) GetWorkflowExecution(
 			RequestCancelInfos: response.State.RequestCancelInfos,
 			SignalInfos:        response.State.SignalInfos,
 			SignalRequestedIDs: response.State.SignalRequestedIDs,
+			UpdateInfos:        response.State.UpdateInfos,
 			ReplicationState:   response.State.ReplicationState,
 		},
 	}
@@ -117,58 +132,72 @@ func (m *executionManagerImpl) DeserializeExecutionInfo(
 	newInfo := &WorkflowExecutionInfo{
 		CompletionEvent: completionEvent,
 
-		DomainID:                     info.DomainID,
-		WorkflowID:                   info.WorkflowID,
-		RunID:                        info.RunID,
-		ParentDomainID:               info.ParentDomainID,
-		ParentWorkflowID:             info.ParentWorkflowID,
-		ParentRunID:                  info.ParentRunID,
-		InitiatedID:                  info.InitiatedID,
-		CompletionEventBatchID:       info.CompletionEventBatchID,
-		TaskList:                     info.TaskList,
-		WorkflowTypeName:             info.WorkflowTypeName,
-		WorkflowTimeout:              info.WorkflowTimeout,
-		DecisionTimeoutValue:         info.DecisionTimeoutValue,
-		ExecutionContext:             info.ExecutionContext,
-		State:                        info.State,
-		CloseStatus:                  info.CloseStatus,
-		LastFirstEventID:             info.LastFirstEventID,
-		LastEventTaskID:              info.LastEventTaskID,
-		NextEventID:                  info.NextEventID,
-		LastProcessedEvent:           info.LastProcessedEvent,
-		StartTimestamp:               info.StartTimestamp,
-		LastUpdatedTimestamp:         info.LastUpdatedTimestamp,
-		CreateRequestID:              info.CreateRequestID,
-		SignalCount:                  info.SignalCount,
-		DecisionVersion:              info.DecisionVersion,
-		DecisionScheduleID:           info.DecisionScheduleID,
-		DecisionStartedID:            info.DecisionStartedID,
-		DecisionRequestID:            info.DecisionRequestID,
-		DecisionTimeout:              info.DecisionTimeout,
-		DecisionAttempt:              info.DecisionAttempt,
-		DecisionStartedTimestamp:     info.DecisionStartedTimestamp,
-		DecisionScheduledTimestamp:   info.DecisionScheduledTimestamp,
-		CancelRequested:              info.CancelRequested,
-		CancelRequestID:              info.CancelRequestID,
-		StickyTaskList:               info.StickyTaskList,
-		StickyScheduleToStartTimeout: info.StickyScheduleToStartTimeout,
-		ClientLibraryVersion:         info.ClientLibraryVersion,
-		ClientFeatureVersion:         info.ClientFeatureVersion,
-		ClientImpl:                   info.ClientImpl,
-		Attempt:                      info.Attempt,
-		HasRetryPolicy:               info.HasRetryPolicy,
-		InitialInterval:              info.InitialInterval,
-		BackoffCoefficient:           info.BackoffCoefficient,
-		MaximumInterval:              info.MaximumInterval,
-		ExpirationTime:               info.ExpirationTime,
-		MaximumAttempts:              info.MaximumAttempts,
-		NonRetriableErrors:           info.NonRetriableErrors,
-		EventStoreVersion:            info.EventStoreVersion,
-		BranchToken:                  info.BranchToken,
-		CronSchedule:                 info.CronSchedule,
-		ExpirationSeconds:            info.ExpirationSeconds,
-		AutoResetPoints:              autoResetPoints,
-		SearchAttributes:             info.SearchAttributes,
+		DomainID:                      info.DomainID,
+		WorkflowID:                    info.WorkflowID,
+		RunID:                         info.RunID,
+		FirstExecutionRunID:           info.FirstExecutionRunID,
+		ParentDomainID:                info.ParentDomainID,
+		ParentWorkflowID:              info.ParentWorkflowID,
+		ParentRunID:                   info.ParentRunID,
+		InitiatedID:                   info.InitiatedID,
+		CompletionEventBatchID:        info.CompletionEventBatchID,
+		TaskList:                      info.TaskList,
+		WorkflowTypeName:              info.WorkflowTypeName,
+		WorkflowTimeout:               info.WorkflowTimeout,
+		DecisionTimeoutValue:          info.DecisionTimeoutValue,
+		ExecutionContext:              info.ExecutionContext,
+		State:                         info.State,
+		CloseStatus:                   info.CloseStatus,
+		LastFirstEventID:              info.LastFirstEventID,
+		LastEventTaskID:               info.LastEventTaskID,
+		NextEventID:                   info.NextEventID,
+		LastProcessedEvent:            info.LastProcessedEvent,
+		StartTimestamp:                info.StartTimestamp,
+		LastUpdatedTimestamp:          info.LastUpdatedTimestamp,
+		CreateRequestID:               info.CreateRequestID,
+		SignalCount:                   info.SignalCount,
+		DecisionVersion:               info.DecisionVersion,
+		DecisionScheduleID:            info.DecisionScheduleID,
+		DecisionStartedID:             info.DecisionStartedID,
+		DecisionRequestID:             info.DecisionRequestID,
+		DecisionTimeout:               info.DecisionTimeout,
+		DecisionAttempt:               info.DecisionAttempt,
+		DecisionHeartbeatCount:        info.DecisionHeartbeatCount,
+		DecisionStartedTimestamp:      info.DecisionStartedTimestamp,
+		DecisionScheduledTimestamp:    info.DecisionScheduledTimestamp,
+		DecisionStartedIdentity:       info.DecisionStartedIdentity,
+		DecisionStartedBinaryChecksum: info.DecisionStartedBinaryChecksum,
+		ResetBaseRunID:                info.ResetBaseRunID,
+		ResetBaseEventID:              info.ResetBaseEventID,
+		ResetReason:                   info.ResetReason,
+		CancelRequested:               info.CancelRequested,
+		CancelRequestID:               info.CancelRequestID,
+		StickyTaskList:                info.StickyTaskList,
+		StickyScheduleToStartTimeout:  info.StickyScheduleToStartTimeout,
+		ClientLibraryVersion:          info.ClientLibraryVersion,
+		ClientFeatureVersion:          info.ClientFeatureVersion,
+		ClientImpl:                    info.ClientImpl,
+		Attempt:                       info.Attempt,
+		HasRetryPolicy:                info.HasRetryPolicy,
+		InitialInterval:               info.InitialInterval,
+		BackoffCoefficient:            info.BackoffCoefficient,
+		MaximumInterval:               info.MaximumInterval,
+		ExpirationTime:                info.ExpirationTime,
+		MaximumAttempts:               info.MaximumAttempts,
+		NonRetriableErrors:            info.NonRetriableErrors,
+		EventStoreVersion:             info.EventStoreVersion,
+		BranchToken:                   info.BranchToken,
+		CronSchedule:                  info.CronSchedule,
+		ExpirationSeconds:             info.ExpirationSeconds,
+		AutoResetPoints:               autoResetPoints,
+		SearchAttributes:              info.SearchAttributes,
+		CompletedActivityRequestIDs:   info.CompletedActivityRequestIDs,
+		Paused:                        info.Paused,
+		CronPaused:                    info.CronPaused,
+		CronTimezone:                  info.CronTimezone,
+		Notes:                         info.Notes,
+		NotesIdentity:                 info.NotesIdentity,
+		NotesLastUpdatedTime:          info.NotesLastUpdatedTime,
 	}
 	newStats := &ExecutionStats{
 		HistorySize: info.HistorySize,
@@ -284,6 +313,7 @@ func (m *executionManagerImpl) DeserializeActivityInfos(
 			NonRetriableErrors:             v.NonRetriableErrors,
 			LastFailureReason:              v.LastFailureReason,
 			LastWorkerIdentity:             v.LastWorkerIdentity,
+			Paused:                         v.Paused,
 			LastHeartbeatTimeoutVisibility: v.LastHeartbeatTimeoutVisibility,
 		}
 		newInfos[k] = a
@@ -313,6 +343,13 @@ func (m *executionManagerImpl) UpdateWorkflowExecution(
 		NewWorkflowSnapshot:    serializedNewWorkflowSnapshot,
 	}
 	msuss := m.statsComputer.computeMutableStateUpdateStats(newRequest)
+	if err := m.checkTransactionBudget(
+		serializedWorkflowMutation.ExecutionInfo,
+		msuss.TransactionStatementCount,
+		msuss.TransactionSize,
+	); err != nil {
+		return nil, err
+	}
 	err1 := m.persistence.UpdateWorkflowExecution(newRequest)
 	return &UpdateWorkflowExecutionResponse{MutableStateUpdateSessionStats: msuss}, err1
 }
@@ -399,6 +436,7 @@ func (m *executionManagerImpl) SerializeUpsertActivityInfos(
 			NonRetriableErrors:             v.NonRetriableErrors,
 			LastFailureReason:              v.LastFailureReason,
 			LastWorkerIdentity:             v.LastWorkerIdentity,
+			Paused:                         v.Paused,
 			LastHeartbeatTimeoutVisibility: v.LastHeartbeatTimeoutVisibility,
 		}
 		newInfos = append(newInfos, i)
@@ -426,59 +464,73 @@ func (m *executionManagerImpl) SerializeExecutionInfo(
 	}
 
 	return &InternalWorkflowExecutionInfo{
-		DomainID:                     info.DomainID,
-		WorkflowID:                   info.WorkflowID,
-		RunID:                        info.RunID,
-		ParentDomainID:               info.ParentDomainID,
-		ParentWorkflowID:             info.ParentWorkflowID,
-		ParentRunID:                  info.ParentRunID,
-		InitiatedID:                  info.InitiatedID,
-		CompletionEventBatchID:       info.CompletionEventBatchID,
-		CompletionEvent:              completionEvent,
-		TaskList:                     info.TaskList,
-		WorkflowTypeName:             info.WorkflowTypeName,
-		WorkflowTimeout:              info.WorkflowTimeout,
-		DecisionTimeoutValue:         info.DecisionTimeoutValue,
-		ExecutionContext:             info.ExecutionContext,
-		State:                        info.State,
-		CloseStatus:                  info.CloseStatus,
-		LastFirstEventID:             info.LastFirstEventID,
-		LastEventTaskID:              info.LastEventTaskID,
-		NextEventID:                  info.NextEventID,
-		LastProcessedEvent:           info.LastProcessedEvent,
-		StartTimestamp:               info.StartTimestamp,
-		LastUpdatedTimestamp:         info.LastUpdatedTimestamp,
-		CreateRequestID:              info.CreateRequestID,
-		SignalCount:                  info.SignalCount,
-		DecisionVersion:              info.DecisionVersion,
-		DecisionScheduleID:           info.DecisionScheduleID,
-		DecisionStartedID:            info.DecisionStartedID,
-		DecisionRequestID:            info.DecisionRequestID,
-		DecisionTimeout:              info.DecisionTimeout,
-		DecisionAttempt:              info.DecisionAttempt,
-		DecisionStartedTimestamp:     info.DecisionStartedTimestamp,
-		DecisionScheduledTimestamp:   info.DecisionScheduledTimestamp,
-		CancelRequested:              info.CancelRequested,
-		CancelRequestID:              info.CancelRequestID,
-		StickyTaskList:               info.StickyTaskList,
-		StickyScheduleToStartTimeout: info.StickyScheduleToStartTimeout,
-		ClientLibraryVersion:         info.ClientLibraryVersion,
-		ClientFeatureVersion:         info.ClientFeatureVersion,
-		ClientImpl:                   info.ClientImpl,
-		AutoResetPoints:              resetPoints,
-		Attempt:                      info.Attempt,
-		HasRetryPolicy:               info.HasRetryPolicy,
-		InitialInterval:              info.InitialInterval,
-		BackoffCoefficient:           info.BackoffCoefficient,
-		MaximumInterval:              info.MaximumInterval,
-		ExpirationTime:               info.ExpirationTime,
-		MaximumAttempts:              info.MaximumAttempts,
-		NonRetriableErrors:           info.NonRetriableErrors,
-		EventStoreVersion:            info.EventStoreVersion,
-		BranchToken:                  info.BranchToken,
-		CronSchedule:                 info.CronSchedule,
-		ExpirationSeconds:            info.ExpirationSeconds,
-		SearchAttributes:             info.SearchAttributes,
+		DomainID:                      info.DomainID,
+		WorkflowID:                    info.WorkflowID,
+		RunID:                         info.RunID,
+		FirstExecutionRunID:           info.FirstExecutionRunID,
+		ParentDomainID:                info.ParentDomainID,
+		ParentWorkflowID:              info.ParentWorkflowID,
+		ParentRunID:                   info.ParentRunID,
+		InitiatedID:                   info.InitiatedID,
+		CompletionEventBatchID:        info.CompletionEventBatchID,
+		CompletionEvent:               completionEvent,
+		TaskList:                      info.TaskList,
+		WorkflowTypeName:              info.WorkflowTypeName,
+		WorkflowTimeout:               info.WorkflowTimeout,
+		DecisionTimeoutValue:          info.DecisionTimeoutValue,
+		ExecutionContext:              info.ExecutionContext,
+		State:                         info.State,
+		CloseStatus:                   info.CloseStatus,
+		LastFirstEventID:              info.LastFirstEventID,
+		LastEventTaskID:               info.LastEventTaskID,
+		NextEventID:                   info.NextEventID,
+		LastProcessedEvent:            info.LastProcessedEvent,
+		StartTimestamp:                info.StartTimestamp,
+		LastUpdatedTimestamp:          info.LastUpdatedTimestamp,
+		CreateRequestID:               info.CreateRequestID,
+		SignalCount:                   info.SignalCount,
+		DecisionVersion:               info.DecisionVersion,
+		DecisionScheduleID:            info.DecisionScheduleID,
+		DecisionStartedID:             info.DecisionStartedID,
+		DecisionRequestID:             info.DecisionRequestID,
+		DecisionTimeout:               info.DecisionTimeout,
+		DecisionAttempt:               info.DecisionAttempt,
+		DecisionHeartbeatCount:        info.DecisionHeartbeatCount,
+		DecisionStartedTimestamp:      info.DecisionStartedTimestamp,
+		DecisionScheduledTimestamp:    info.DecisionScheduledTimestamp,
+		DecisionStartedIdentity:       info.DecisionStartedIdentity,
+		DecisionStartedBinaryChecksum: info.DecisionStartedBinaryChecksum,
+		ResetBaseRunID:                info.ResetBaseRunID,
+		ResetBaseEventID:              info.ResetBaseEventID,
+		ResetReason:                   info.ResetReason,
+		CancelRequested:               info.CancelRequested,
+		CancelRequestID:               info.CancelRequestID,
+		StickyTaskList:                info.StickyTaskList,
+		StickyScheduleToStartTimeout:  info.StickyScheduleToStartTimeout,
+		ClientLibraryVersion:          info.ClientLibraryVersion,
+		ClientFeatureVersion:          info.ClientFeatureVersion,
+		ClientImpl:                    info.ClientImpl,
+		AutoResetPoints:               resetPoints,
+		Attempt:                       info.Attempt,
+		HasRetryPolicy:                info.HasRetryPolicy,
+		InitialInterval:               info.InitialInterval,
+		BackoffCoefficient:            info.BackoffCoefficient,
+		MaximumInterval:               info.MaximumInterval,
+		ExpirationTime:                info.ExpirationTime,
+		MaximumAttempts:               info.MaximumAttempts,
+		NonRetriableErrors:            info.NonRetriableErrors,
+		EventStoreVersion:             info.EventStoreVersion,
+		BranchToken:                   info.BranchToken,
+		CronSchedule:                  info.CronSchedule,
+		ExpirationSeconds:             info.ExpirationSeconds,
+		SearchAttributes:              info.SearchAttributes,
+		CompletedActivityRequestIDs:   info.CompletedActivityRequestIDs,
+		Paused:                        info.Paused,
+		CronPaused:                    info.CronPaused,
+		CronTimezone:                  info.CronTimezone,
+		Notes:                         info.Notes,
+		NotesIdentity:                 info.NotesIdentity,
+		NotesLastUpdatedTime:          info.NotesLastUpdatedTime,
 
 		// attributes which are not related to mutable state
 		HistorySize: stats.HistorySize,
@@ -568,9 +620,43 @@ func (m *executionManagerImpl) CreateWorkflowExecution(
 		NewWorkflowSnapshot: *serializedNewWorkflowSnapshot,
 	}
 
+	statementCount, size := m.statsComputer.computeWorkflowSnapshotTransactionStats(serializedNewWorkflowSnapshot)
+	if err := m.checkTransactionBudget(serializedNewWorkflowSnapshot.ExecutionInfo, statementCount, size); err != nil {
+		return nil, err
+	}
 	return m.persistence.CreateWorkflowExecution(newRequest)
 }
 
+// checkTransactionBudget rejects writes whose statement count or size would exceed the configured budget,
+// protecting the datastore from a single enormous batch
+func (m *executionManagerImpl) checkTransactionBudget(
+	executionInfo *InternalWorkflowExecutionInfo,
+	statementCount int,
+	size int,
+) error {
+
+	statementLimit := m.transactionStatementLimit()
+	sizeLimit := m.transactionSizeLimit()
+	if statementCount <= statementLimit && size <= sizeLimit {
+		return nil
+	}
+
+	m.logger.Warn("Workflow execution transaction exceeds budget.",
+		tag.WorkflowDomainID(executionInfo.DomainID),
+		tag.WorkflowID(executionInfo.WorkflowID),
+		tag.WorkflowRunID(executionInfo.RunID),
+		tag.Counter(statementCount),
+		tag.WorkflowSize(int64(size)))
+	if statementCount > statementLimit {
+		return &TransactionSizeLimitError{
+			Msg: fmt.Sprintf("transaction of %v statements exceeds limit of %v statements", statementCount, statementLimit),
+		}
+	}
+	return &TransactionSizeLimitError{
+		Msg: fmt.Sprintf("transaction size of %v bytes exceeds limit of %v bytes", size, sizeLimit),
+	}
+}
+
 func (m *executionManagerImpl) SerializeWorkflowMutation(
 	input *WorkflowMutation,
 	encoding common.EncodingType,
@@ -615,13 +701,16 @@ func (m *executionManagerImpl) SerializeWorkflowMutation(
 		UpsertSignalInfos:         input.UpsertSignalInfos,
 		DeleteSignalInfo:          input.DeleteSignalInfo,
 		UpsertSignalRequestedIDs:  input.UpsertSignalRequestedIDs,
-		DeleteSignalRequestedID:   input.DeleteSignalRequestedID,
+		DeleteSignalRequestedIDs:  input.DeleteSignalRequestedIDs,
+		UpsertUpdateInfos:         input.UpsertUpdateInfos,
+		DeleteUpdateInfo:          input.DeleteUpdateInfo,
 		NewBufferedEvents:         serializedNewBufferedEvents,
 		ClearBufferedEvents:       input.ClearBufferedEvents,
 
 		TransferTasks:    input.TransferTasks,
 		ReplicationTasks: input.ReplicationTasks,
 		TimerTasks:       input.TimerTasks,
+		VisibilityTasks:  input.VisibilityTasks,
 
 		Condition: input.Condition,
 	}, nil
@@ -659,10 +748,12 @@ func (m *executionManagerImpl) SerializeWorkflowSnapshot(
 		RequestCancelInfos:  input.RequestCancelInfos,
 		SignalInfos:         input.SignalInfos,
 		SignalRequestedIDs:  input.SignalRequestedIDs,
+		UpdateInfos:         input.UpdateInfos,
 
 		TransferTasks:    input.TransferTasks,
 		ReplicationTasks: input.ReplicationTasks,
 		TimerTasks:       input.TimerTasks,
+		VisibilityTasks:  input.VisibilityTasks,
 
 		Condition: input.Condition,
 	}, nil
@@ -676,7 +767,7 @@ func (m *executionManagerImpl) DeleteWorkflowExecution(
 
 func (m *executionManagerImpl) DeleteCurrentWorkflowExecution(
 	request *DeleteCurrentWorkflowExecutionRequest,
-) error {
+) (*DeleteCurrentWorkflowExecutionResponse, error) {
 	return m.persistence.DeleteCurrentWorkflowExecution(request)
 }
 
@@ -686,6 +777,24 @@ func (m *executionManagerImpl) GetCurrentExecution(
 	return m.persistence.GetCurrentExecution(request)
 }
 
+func (m *executionManagerImpl) ListWorkflowExecutionRuns(
+	request *ListWorkflowExecutionRunsRequest,
+) (*ListWorkflowExecutionRunsResponse, error) {
+	return m.persistence.ListWorkflowExecutionRuns(request)
+}
+
+func (m *executionManagerImpl) ListCurrentExecutions(
+	request *ListCurrentExecutionsRequest,
+) (*ListCurrentExecutionsResponse, error) {
+	return m.persistence.ListCurrentExecutions(request)
+}
+
+func (m *executionManagerImpl) BlockCurrentWorkflowExecution(
+	request *BlockCurrentWorkflowExecutionRequest,
+) error {
+	return m.persistence.BlockCurrentWorkflowExecution(request)
+}
+
 // Transfer task related methods
 func (m *executionManagerImpl) GetTransferTasks(
 	request *GetTransferTasksRequest,
@@ -705,6 +814,25 @@ func (m *executionManagerImpl) RangeCompleteTransferTask(
 	return m.persistence.RangeCompleteTransferTask(request)
 }
 
+// Visibility task related methods
+func (m *executionManagerImpl) GetVisibilityTasks(
+	request *GetVisibilityTasksRequest,
+) (*GetVisibilityTasksResponse, error) {
+	return m.persistence.GetVisibilityTasks(request)
+}
+
+func (m *executionManagerImpl) CompleteVisibilityTask(
+	request *CompleteVisibilityTaskRequest,
+) error {
+	return m.persistence.CompleteVisibilityTask(request)
+}
+
+func (m *executionManagerImpl) RangeCompleteVisibilityTask(
+	request *RangeCompleteVisibilityTaskRequest,
+) error {
+	return m.persistence.RangeCompleteVisibilityTask(request)
+}
+
 // Replication task related methods
 func (m *executionManagerImpl) GetReplicationTasks(
 	request *GetReplicationTasksRequest,
@@ -718,6 +846,12 @@ func (m *executionManagerImpl) CompleteReplicationTask(
 	return m.persistence.CompleteReplicationTask(request)
 }
 
+func (m *executionManagerImpl) RangeCompleteReplicationTask(
+	request *RangeCompleteReplicationTaskRequest,
+) error {
+	return m.persistence.RangeCompleteReplicationTask(request)
+}
+
 // Timer related methods.
 func (m *executionManagerImpl) GetTimerIndexTasks(
 	request *GetTimerIndexTasksRequest,
@@ -737,6 +871,32 @@ func (m *executionManagerImpl) RangeCompleteTimerTask(
 	return m.persistence.RangeCompleteTimerTask(request)
 }
 
+// Closed execution index related methods
+func (m *executionManagerImpl) GetClosedExecutionIndex(
+	request *GetClosedExecutionIndexRequest,
+) (*GetClosedExecutionIndexResponse, error) {
+	return m.persistence.GetClosedExecutionIndex(request)
+}
+
+func (m *executionManagerImpl) DeleteClosedExecutionIndex(
+	request *DeleteClosedExecutionIndexRequest,
+) error {
+	return m.persistence.DeleteClosedExecutionIndex(request)
+}
+
+// Buffered replication task related methods
+func (m *executionManagerImpl) ListBufferedReplicationTasks(
+	request *ListBufferedReplicationTasksRequest,
+) (*ListBufferedReplicationTasksResponse, error) {
+	return m.persistence.ListBufferedReplicationTasks(request)
+}
+
+func (m *executionManagerImpl) DeleteBufferedReplicationTasks(
+	request *DeleteBufferedReplicationTasksRequest,
+) error {
+	return m.persistence.DeleteBufferedReplicationTasks(request)
+}
+
 func (m *executionManagerImpl) Close() {
 	m.persistence.Close()
 }
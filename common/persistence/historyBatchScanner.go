@@ -0,0 +1,287 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package persistence
+
+import (
+	"encoding/binary"
+	"errors"
+
+	workflow "github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/codec"
+	"go.uber.org/thriftrw/wire"
+)
+
+const (
+	// thrift field IDs of History.events, HistoryEvent.eventId and HistoryEvent.version
+	historyEventsFieldID       = 10
+	historyEventIDFieldID      = 10
+	historyEventVersionFieldID = 35
+
+	// thriftFieldStop ends the fields of a struct
+	thriftFieldStop wire.Type = 0
+)
+
+var errTruncatedHistoryBatch = errors.New("truncated history event batch")
+
+type (
+	// historyBatchBounds are the IDs and versions of the first and last events of a history batch,
+	// which is all it takes to validate the batch while reading a branch
+	historyBatchBounds struct {
+		firstEventID int64
+		firstVersion int64
+		lastEventID  int64
+		lastVersion  int64
+		eventCount   int
+	}
+
+	// thriftBinaryScanner walks a thrift binary encoded payload without decoding it
+	thriftBinaryScanner struct {
+		data []byte
+		pos  int
+	}
+)
+
+// newHistoryBatchBounds returns the bounds of deserialized events, empty bounds are returned for an empty batch
+func newHistoryBatchBounds(events []*workflow.HistoryEvent) historyBatchBounds {
+	if len(events) == 0 {
+		return historyBatchBounds{}
+	}
+	first := events[0]
+	last := events[len(events)-1]
+	return historyBatchBounds{
+		firstEventID: first.GetEventId(),
+		firstVersion: first.GetVersion(),
+		lastEventID:  last.GetEventId(),
+		lastVersion:  last.GetVersion(),
+		eventCount:   len(events),
+	}
+}
+
+// scanHistoryBatch returns the bounds of a batch encoded with thriftrw. Only the event IDs and versions
+// are read, everything else of the events is skipped over, so that batches forwarded as persisted are
+// validated without being deserialized. Batches in other encodings are deserialized.
+func scanHistoryBatch(serializer PayloadSerializer, blob *DataBlob) (historyBatchBounds, error) {
+	if blob.GetEncoding() != common.EncodingTypeThriftRW {
+		events, err := serializer.DeserializeBatchEvents(blob)
+		if err != nil {
+			return historyBatchBounds{}, err
+		}
+		return newHistoryBatchBounds(events), nil
+	}
+
+	payload, err := codec.NewThriftRWEncoder().Payload(blob.Data)
+	if err != nil {
+		return historyBatchBounds{}, err
+	}
+	bounds, err := scanHistoryEvents(&thriftBinaryScanner{data: payload})
+	if err != nil {
+		return historyBatchBounds{}, NewCadenceDeserializationError(err.Error())
+	}
+	return bounds, nil
+}
+
+func scanHistoryEvents(s *thriftBinaryScanner) (historyBatchBounds, error) {
+	bounds := historyBatchBounds{}
+	for {
+		fieldType, fieldID, err := s.readFieldHeader()
+		if err != nil || fieldType == thriftFieldStop {
+			return bounds, err
+		}
+		if fieldID != historyEventsFieldID || fieldType != wire.TList {
+			if err := s.skip(fieldType); err != nil {
+				return bounds, err
+			}
+			continue
+		}
+
+		elemType, err := s.readByte()
+		if err != nil {
+			return bounds, err
+		}
+		size, err := s.readSize()
+		if err != nil {
+			return bounds, err
+		}
+		for i := 0; i < size; i++ {
+			if wire.Type(elemType) != wire.TStruct {
+				return bounds, errors.New("history events are not encoded as structs")
+			}
+			eventID, version, err := scanHistoryEvent(s)
+			if err != nil {
+				return bounds, err
+			}
+			if i == 0 {
+				bounds.firstEventID, bounds.firstVersion = eventID, version
+			}
+			bounds.lastEventID, bounds.lastVersion = eventID, version
+		}
+		bounds.eventCount += size
+	}
+}
+
+func scanHistoryEvent(s *thriftBinaryScanner) (int64, int64, error) {
+	eventID := int64(0)
+	version := int64(0)
+	for {
+		fieldType, fieldID, err := s.readFieldHeader()
+		if err != nil || fieldType == thriftFieldStop {
+			return eventID, version, err
+		}
+		switch {
+		case fieldID == historyEventIDFieldID && fieldType == wire.TI64:
+			eventID, err = s.readI64()
+		case fieldID == historyEventVersionFieldID && fieldType == wire.TI64:
+			version, err = s.readI64()
+		default:
+			err = s.skip(fieldType)
+		}
+		if err != nil {
+			return 0, 0, err
+		}
+	}
+}
+
+// readFieldHeader returns the type and ID of the next field of a struct, or thriftFieldStop at the end of the struct
+func (s *thriftBinaryScanner) readFieldHeader() (wire.Type, int16, error) {
+	fieldType, err := s.readByte()
+	if err != nil {
+		return 0, 0, err
+	}
+	if wire.Type(fieldType) == thriftFieldStop {
+		return thriftFieldStop, 0, nil
+	}
+	b, err := s.read(2)
+	if err != nil {
+		return 0, 0, err
+	}
+	return wire.Type(fieldType), int16(binary.BigEndian.Uint16(b)), nil
+}
+
+func (s *thriftBinaryScanner) skip(valueType wire.Type) error {
+	switch valueType {
+	case wire.TBool, wire.TI8:
+		_, err := s.read(1)
+		return err
+	case wire.TI16:
+		_, err := s.read(2)
+		return err
+	case wire.TI32:
+		_, err := s.read(4)
+		return err
+	case wire.TI64, wire.TDouble:
+		_, err := s.read(8)
+		return err
+	case wire.TBinary:
+		size, err := s.readSize()
+		if err != nil {
+			return err
+		}
+		_, err = s.read(size)
+		return err
+	case wire.TStruct:
+		for {
+			fieldType, _, err := s.readFieldHeader()
+			if err != nil || fieldType == thriftFieldStop {
+				return err
+			}
+			if err := s.skip(fieldType); err != nil {
+				return err
+			}
+		}
+	case wire.TMap:
+		keyType, err := s.readByte()
+		if err != nil {
+			return err
+		}
+		valueType, err := s.readByte()
+		if err != nil {
+			return err
+		}
+		size, err := s.readSize()
+		if err != nil {
+			return err
+		}
+		for i := 0; i < size; i++ {
+			if err := s.skip(wire.Type(keyType)); err != nil {
+				return err
+			}
+			if err := s.skip(wire.Type(valueType)); err != nil {
+				return err
+			}
+		}
+		return nil
+	case wire.TSet, wire.TList:
+		elemType, err := s.readByte()
+		if err != nil {
+			return err
+		}
+		size, err := s.readSize()
+		if err != nil {
+			return err
+		}
+		for i := 0; i < size; i++ {
+			if err := s.skip(wire.Type(elemType)); err != nil {
+				return err
+			}
+		}
+		return nil
+	default:
+		return errors.New("unknown thrift type in history event batch")
+	}
+}
+
+func (s *thriftBinaryScanner) readByte() (byte, error) {
+	b, err := s.read(1)
+	if err != nil {
+		return 0, err
+	}
+	return b[0], nil
+}
+
+func (s *thriftBinaryScanner) readI64() (int64, error) {
+	b, err := s.read(8)
+	if err != nil {
+		return 0, err
+	}
+	return int64(binary.BigEndian.Uint64(b)), nil
+}
+
+func (s *thriftBinaryScanner) readSize() (int, error) {
+	b, err := s.read(4)
+	if err != nil {
+		return 0, err
+	}
+	size := int32(binary.BigEndian.Uint32(b))
+	if size < 0 {
+		return 0, errTruncatedHistoryBatch
+	}
+	return int(size), nil
+}
+
+func (s *thriftBinaryScanner) read(n int) ([]byte, error) {
+	if n > len(s.data)-s.pos {
+		return nil, errTruncatedHistoryBatch
+	}
+	b := s.data[s.pos : s.pos+n]
+	s.pos += n
+	return b, nil
+}
@@ -0,0 +1,61 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package persistence
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	workflow "github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common"
+)
+
+func TestScanHistoryBatch(t *testing.T) {
+	serializer := NewPayloadSerializer()
+	events := []*workflow.HistoryEvent{
+		{
+			EventId:   common.Int64Ptr(5),
+			Version:   common.Int64Ptr(12),
+			EventType: workflow.EventTypeWorkflowExecutionStarted.Ptr(),
+			WorkflowExecutionStartedEventAttributes: &workflow.WorkflowExecutionStartedEventAttributes{
+				WorkflowType: &workflow.WorkflowType{Name: common.StringPtr("workflow-type")},
+				Input:        []byte("input"),
+				Memo:         &workflow.Memo{Fields: map[string][]byte{"key": []byte("value")}},
+			},
+		},
+		{EventId: common.Int64Ptr(6), Version: common.Int64Ptr(12)},
+		{EventId: common.Int64Ptr(7), Version: common.Int64Ptr(12), Timestamp: common.Int64Ptr(1000)},
+	}
+	expected := historyBatchBounds{firstEventID: 5, firstVersion: 12, lastEventID: 7, lastVersion: 12, eventCount: 3}
+
+	for _, encoding := range []common.EncodingType{common.EncodingTypeThriftRW, common.EncodingTypeJSON} {
+		blob, err := serializer.SerializeBatchEvents(events, encoding)
+		require.NoError(t, err)
+		bounds, err := scanHistoryBatch(serializer, blob)
+		require.NoError(t, err)
+		require.Equal(t, expected, bounds)
+	}
+
+	blob, err := serializer.SerializeBatchEvents(events, common.EncodingTypeThriftRW)
+	require.NoError(t, err)
+	_, err = scanHistoryBatch(serializer, NewDataBlob(blob.Data[:len(blob.Data)/2], common.EncodingTypeThriftRW))
+	require.Error(t, err)
+}
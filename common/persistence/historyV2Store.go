@@ -85,10 +85,13 @@ func (m *historyV2ManagerImpl) ForkHistoryBranch(request *ForkHistoryBranchReque
 	req := &InternalForkHistoryBranchRequest{
 		ForkBranchInfo: forkBranch,
 		ForkNodeID:     request.ForkNodeID,
-		NewBranchID:    uuid.New(),
+		NewBranchID:    request.NewBranchID,
 		Info:           request.Info,
 		ShardID:        shardID,
 	}
+	if req.NewBranchID == "" {
+		req.NewBranchID = uuid.New()
+	}
 
 	resp, err := m.persistence.ForkHistoryBranch(req)
 	if err != nil {
@@ -257,17 +260,158 @@ func (m *historyV2ManagerImpl) ReadHistoryBranch(request *ReadHistoryBranchReque
 	return resp, nil
 }
 
+// ReadRawHistoryBranch returns history node data for a branch as persisted, so callers forwarding history
+// do not hold the deserialized events. Pagination and validation are the same as ReadHistoryBranch,
+// batches are validated from the IDs and versions of their first and last events, which are scanned
+// from the encoded batches without deserializing them.
+func (m *historyV2ManagerImpl) ReadRawHistoryBranch(request *ReadHistoryBranchRequest) (*ReadRawHistoryBranchResponse, error) {
+	resp, token, logger, err := m.readRawHistoryBranch(request)
+	if err != nil {
+		return nil, err
+	}
+
+	blobs := make([]*DataBlob, 0, len(resp.History))
+	dataSize := 0
+	for _, b := range resp.History {
+		bounds, err := scanHistoryBatch(m.historySerializer, b)
+		if err != nil {
+			return nil, err
+		}
+		valid, err := m.validateHistoryBatch(logger, token, request.MinEventID-1, bounds)
+		if err != nil {
+			return nil, err
+		}
+		if !valid {
+			continue
+		}
+		blobs = append(blobs, b)
+		dataSize += len(b.Data)
+	}
+
+	nextToken, err := m.serializeToken(token, resp.NextPageToken)
+	if err != nil {
+		return nil, err
+	}
+	return &ReadRawHistoryBranchResponse{
+		HistoryEventBlobs: blobs,
+		NextPageToken:     nextToken,
+		Size:              dataSize,
+	}, nil
+}
+
 func (m *historyV2ManagerImpl) readHistoryBranch(byBatch bool, request *ReadHistoryBranchRequest) ([]*workflow.HistoryEvent, []*workflow.History, []byte, int, int64, error) {
+	resp, token, logger, err := m.readRawHistoryBranch(request)
+	if err != nil {
+		return nil, nil, nil, 0, 0, err
+	}
+
+	events := make([]*workflow.HistoryEvent, 0, request.PageSize)
+	historyBatches := make([]*workflow.History, 0, request.PageSize)
+	dataSize := 0
+	// first_event_id of the last batch
+	lastFirstEventID := common.EmptyEventID
+
+	for _, b := range resp.History {
+		es, err := m.historySerializer.DeserializeBatchEvents(b)
+		if err != nil {
+			return nil, nil, nil, 0, 0, err
+		}
+		valid, err := m.validateHistoryBatch(logger, token, request.MinEventID-1, newHistoryBatchBounds(es))
+		if err != nil {
+			return nil, nil, nil, 0, 0, err
+		}
+		if !valid {
+			continue
+		}
+
+		if byBatch {
+			historyBatches = append(historyBatches, &workflow.History{Events: es})
+		} else {
+			events = append(events, es...)
+		}
+		dataSize += len(b.Data)
+		lastFirstEventID = es[0].GetEventId()
+	}
+
+	nextToken, err := m.serializeToken(token, resp.NextPageToken)
+	if err != nil {
+		return nil, nil, nil, 0, 0, err
+	}
+	return events, historyBatches, nextToken, dataSize, lastFirstEventID, nil
+}
+
+// validateHistoryBatch returns whether the batch should be returned, and moves the paging token past it if so,
+// stale batches are skipped and corrupted ones fail the read
+func (m *historyV2ManagerImpl) validateHistoryBatch(
+	logger log.Logger,
+	token *historyV2PagingToken,
+	defaultLastEventID int64,
+	batch historyBatchBounds,
+) (bool, error) {
+	//NOTE: in this method, we need to make sure eventVersion is NOT decreasing(otherwise we skip the events), eventID should be continuous(otherwise return error)
+	if batch.eventCount == 0 {
+		logger.Error("Empty events in a batch")
+		return false, &workflow.InternalServiceError{
+			Message: fmt.Sprintf("corrupted history event batch, empty events"),
+		}
+	}
+
+	if batch.firstVersion != batch.lastVersion || batch.firstEventID+int64(batch.eventCount-1) != batch.lastEventID {
+		// in a single batch, version should be the same, and ID should be continous
+		logger.Error("Corrupted event batch",
+			tag.FirstEventVersion(batch.firstVersion), tag.WorkflowFirstEventID(batch.firstEventID),
+			tag.LastEventVersion(batch.lastVersion), tag.WorkflowNextEventID(batch.lastEventID),
+			tag.Counter(batch.eventCount))
+		return false, &workflow.InternalServiceError{
+			Message: fmt.Sprintf("corrupted history event batch, wrong version and IDs"),
+		}
+	}
+
+	if batch.firstVersion < token.LastEventVersion {
+		// version decrease means the this batch are all stale events, we should skip
+		logger.Info("Stale event batch with smaller version", tag.FirstEventVersion(batch.firstVersion), tag.TokenLastEventVersion(token.LastEventVersion))
+		return false, nil
+	}
+	if batch.firstEventID <= token.LastEventID {
+		// we could see it because first batch of next page has a smaller txn_id
+		logger.Info("Stale event batch with eventID", tag.WorkflowFirstEventID(batch.firstEventID), tag.TokenLastEventID(token.LastEventID))
+		return false, nil
+	}
+	if batch.firstEventID != token.LastEventID+1 {
+		// We assume application layer want to read from MinEventID(inclusive)
+		// However, for getting history from remote cluster, there is scenario that we have to read from middle without knowing the firstEventID.
+		// In that case we don't validate history continuousness for the first page
+		// TODO: in this case, some events returned can be invalid(stale). application layer need to make sure it won't make any problems to XDC
+		if defaultLastEventID == 0 || token.LastEventID != defaultLastEventID {
+			logger.Error("Corrupted incontinouous event batch",
+				tag.FirstEventVersion(batch.firstVersion), tag.WorkflowFirstEventID(batch.firstEventID),
+				tag.LastEventVersion(batch.lastVersion), tag.WorkflowNextEventID(batch.lastEventID),
+				tag.TokenLastEventVersion(token.LastEventVersion), tag.TokenLastEventID(token.LastEventID),
+				tag.Counter(batch.eventCount))
+			return false, &workflow.InternalServiceError{
+				Message: fmt.Sprintf("corrupted history event batch, eventID is not continouous"),
+			}
+		}
+	}
+
+	token.LastEventVersion = batch.firstVersion
+	token.LastEventID = batch.lastEventID
+	return true, nil
+}
+
+// readRawHistoryBranch reads a page of the branch from the store, and returns it along with the paging token
+// for the caller to move with validateHistoryBatch, before serializing it with serializeToken
+func (m *historyV2ManagerImpl) readRawHistoryBranch(request *ReadHistoryBranchRequest) (*InternalReadHistoryBranchResponse, *historyV2PagingToken, log.Logger, error) {
 	var branch workflow.HistoryBranch
 	err := m.thriftEncoder.Decode(request.BranchToken, &branch)
 	if err != nil {
-		return nil, nil, nil, 0, 0, err
+		return nil, nil, nil, err
 	}
 	treeID := *branch.TreeID
 	branchID := *branch.BranchID
 
 	if request.PageSize <= 0 || request.MinEventID >= request.MaxEventID {
-		return nil, nil, nil, 0, 0, &InvalidPersistenceRequestError{
+		return nil, nil, nil, &InvalidPersistenceRequestError{
 			Msg: fmt.Sprintf("no events can be found for pageSize %v, minEventID %v, maxEventID: %v", request.PageSize, request.MinEventID, request.MaxEventID),
 		}
 	}
@@ -275,7 +419,7 @@ func (m *historyV2ManagerImpl) readHistoryBranch(byBatch bool, request *ReadHist
 	defaultLastEventID := request.MinEventID - 1
 	token, err := m.pagingTokenSerializer.Deserialize(request.NextPageToken, defaultLastEventID, common.EmptyVersion)
 	if err != nil {
-		return nil, nil, nil, 0, 0, err
+		return nil, nil, nil, err
 	}
 
 	allBRs := branch.Ancestors
@@ -308,7 +452,7 @@ func (m *historyV2ManagerImpl) readHistoryBranch(byBatch bool, request *ReadHist
 		}
 
 		if token.CurrentRangeIndex == notStartedIndex {
-			return nil, nil, nil, 0, 0, &workflow.InternalServiceError{
+			return nil, nil, nil, &workflow.InternalServiceError{
 				Message: fmt.Sprintf("branchRange is corrupted"),
 			}
 		}
@@ -322,7 +466,7 @@ func (m *historyV2ManagerImpl) readHistoryBranch(byBatch bool, request *ReadHist
 	shardID, err := getShardID(request.ShardID)
 	if err != nil {
 		m.logger.Error("shardID is not set in read history branch operation", tag.Error(err))
-		return nil, nil, nil, 0, 0, &workflow.InternalServiceError{
+		return nil, nil, nil, &workflow.InternalServiceError{
 			Message: err.Error(),
 		}
 	}
@@ -334,112 +478,34 @@ func (m *historyV2ManagerImpl) readHistoryBranch(byBatch bool, request *ReadHist
 		PageSize:      request.PageSize,
 		NextPageToken: token.StoreToken,
 		ShardID:       shardID,
+		Context:       request.Context,
 	}
 
 	resp, err := m.persistence.ReadHistoryBranch(req)
 	if err != nil {
-		return nil, nil, nil, 0, 0, err
+		return nil, nil, nil, err
 	}
 	if len(resp.History) == 0 && len(request.NextPageToken) == 0 {
-		return nil, nil, nil, 0, 0, &workflow.EntityNotExistsError{Message: "Workflow execution history not found."}
-	}
-
-	events := make([]*workflow.HistoryEvent, 0, request.PageSize)
-	historyBatches := make([]*workflow.History, 0, request.PageSize)
-	dataSize := 0
-	// first_event_id of the last batch
-	lastFirstEventID := common.EmptyEventID
-
-	//NOTE: in this method, we need to make sure eventVersion is NOT decreasing(otherwise we skip the events), eventID should be continuous(otherwise return error)
-	logger := m.logger.WithTags(tag.WorkflowBranchID(*branch.BranchID), tag.WorkflowTreeID(*branch.TreeID))
-
-	for _, b := range resp.History {
-		es, err := m.historySerializer.DeserializeBatchEvents(b)
-		if err != nil {
-			return nil, nil, nil, 0, 0, err
-		}
-		if len(es) == 0 {
-			logger.Error("Empty events in a batch")
-			return nil, nil, nil, 0, 0, &workflow.InternalServiceError{
-				Message: fmt.Sprintf("corrupted history event batch, empty events"),
-			}
-		}
-
-		firstEvent := es[0]           // first
-		eventCount := len(es)         // length
-		lastEvent := es[eventCount-1] // last
-
-		if firstEvent.GetVersion() != lastEvent.GetVersion() || firstEvent.GetEventId()+int64(eventCount-1) != lastEvent.GetEventId() {
-			// in a single batch, version should be the same, and ID should be continous
-			logger.Error("Corrupted event batch",
-				tag.FirstEventVersion(firstEvent.GetVersion()), tag.WorkflowFirstEventID(firstEvent.GetEventId()),
-				tag.LastEventVersion(lastEvent.GetVersion()), tag.WorkflowNextEventID(lastEvent.GetEventId()),
-				tag.Counter(eventCount))
-			return nil, nil, nil, 0, 0, &workflow.InternalServiceError{
-				Message: fmt.Sprintf("corrupted history event batch, wrong version and IDs"),
-			}
-		}
-
-		if firstEvent.GetVersion() < token.LastEventVersion {
-			// version decrease means the this batch are all stale events, we should skip
-			logger.Info("Stale event batch with smaller version", tag.FirstEventVersion(firstEvent.GetVersion()), tag.TokenLastEventVersion(token.LastEventVersion))
-			continue
-		}
-		if firstEvent.GetEventId() <= token.LastEventID {
-			// we could see it because first batch of next page has a smaller txn_id
-			logger.Info("Stale event batch with eventID", tag.WorkflowFirstEventID(firstEvent.GetEventId()), tag.TokenLastEventID(token.LastEventID))
-			continue
-		}
-		if firstEvent.GetEventId() != token.LastEventID+1 {
-			// We assume application layer want to read from MinEventID(inclusive)
-			// However, for getting history from remote cluster, there is scenario that we have to read from middle without knowing the firstEventID.
-			// In that case we don't validate history continuousness for the first page
-			// TODO: in this case, some events returned can be invalid(stale). application layer need to make sure it won't make any problems to XDC
-			if defaultLastEventID == 0 || token.LastEventID != defaultLastEventID {
-				logger.Error("Corrupted incontinouous event batch",
-					tag.FirstEventVersion(firstEvent.GetVersion()), tag.WorkflowFirstEventID(firstEvent.GetEventId()),
-					tag.LastEventVersion(lastEvent.GetVersion()), tag.WorkflowNextEventID(lastEvent.GetEventId()),
-					tag.TokenLastEventVersion(token.LastEventVersion), tag.TokenLastEventID(token.LastEventID),
-					tag.Counter(eventCount))
-				return nil, nil, nil, 0, 0, &workflow.InternalServiceError{
-					Message: fmt.Sprintf("corrupted history event batch, eventID is not continouous"),
-				}
-			}
-		}
-
-		token.LastEventVersion = firstEvent.GetVersion()
-		token.LastEventID = lastEvent.GetEventId()
-		if byBatch {
-			historyBatches = append(historyBatches, &workflow.History{Events: es})
-		} else {
-			events = append(events, es...)
-		}
-		dataSize += len(b.Data)
-		lastFirstEventID = firstEvent.GetEventId()
+		return nil, nil, nil, &workflow.EntityNotExistsError{Message: "Workflow execution history not found."}
 	}
+	logger := m.logger.WithTags(tag.WorkflowBranchID(branchID), tag.WorkflowTreeID(treeID))
+	return resp, token, logger, nil
+}
 
-	var nextToken []byte
-	if len(resp.NextPageToken) == 0 {
+// serializeToken moves the paging token to the next page of the store, or to the next branch range
+// once the current one is exhausted, and returns nil when the final page is reached
+func (m *historyV2ManagerImpl) serializeToken(token *historyV2PagingToken, storeNextPageToken []byte) ([]byte, error) {
+	if len(storeNextPageToken) == 0 {
 		if token.CurrentRangeIndex == token.FinalRangeIndex {
 			// this means that we have reached the final page of final branchRange
-			nextToken = nil
-		} else {
-			token.CurrentRangeIndex++
-			token.StoreToken = nil
-			nextToken, err = m.pagingTokenSerializer.Serialize(token)
-			if err != nil {
-				return nil, nil, nil, 0, 0, err
-			}
+			return nil, nil
 		}
+		token.CurrentRangeIndex++
+		token.StoreToken = nil
 	} else {
-		token.StoreToken = resp.NextPageToken
-		nextToken, err = m.pagingTokenSerializer.Serialize(token)
-		if err != nil {
-			return nil, nil, nil, 0, 0, err
-		}
+		token.StoreToken = storeNextPageToken
 	}
-
-	return events, historyBatches, nextToken, dataSize, lastFirstEventID, nil
+	return m.pagingTokenSerializer.Serialize(token)
 }
 
 func (m *historyV2ManagerImpl) Close() {
@@ -170,6 +170,33 @@ func ReadFullPageV2EventsByBatch(historyV2Mgr HistoryV2Manager, req *ReadHistory
 	}
 }
 
+// StreamRawHistoryBranch reads the history batches of a branch as persisted, page by page, and hands each batch
+// to the callback as soon as its page is read, so that callers forwarding history never hold more than a page of
+// the branch in memory. Reading stops once pageSize batches or maxSize bytes went through the callback. Function
+// returns the size of the batches, the token to resume reading from, and an error if present.
+func StreamRawHistoryBranch(historyV2Mgr HistoryV2Manager, req *ReadHistoryBranchRequest, maxSize int,
+	fn func(blob *DataBlob) error) (int, []byte, error) {
+	batches := 0
+	size := 0
+	for {
+		response, err := historyV2Mgr.ReadRawHistoryBranch(req)
+		if err != nil {
+			return 0, nil, err
+		}
+		for _, blob := range response.HistoryEventBlobs {
+			if err := fn(blob); err != nil {
+				return 0, nil, err
+			}
+		}
+		batches += len(response.HistoryEventBlobs)
+		size += response.Size
+		if batches >= req.PageSize || size >= maxSize || len(response.NextPageToken) == 0 {
+			return size, response.NextPageToken, nil
+		}
+		req.NextPageToken = response.NextPageToken
+	}
+}
+
 // GetBeginNodeID gets node id from last ancestor
 func GetBeginNodeID(bi shared.HistoryBranch) int64 {
 	if len(bi.Ancestors) == 0 {
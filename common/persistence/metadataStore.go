@@ -156,11 +156,15 @@ func (m *metadataManagerImpl) serializeDomainConfig(c *DomainConfig) (InternalDo
 		return InternalDomainConfig{}, err
 	}
 	return InternalDomainConfig{
-		Retention:      c.Retention,
-		EmitMetric:     c.EmitMetric,
-		ArchivalBucket: c.ArchivalBucket,
-		ArchivalStatus: c.ArchivalStatus,
-		BadBinaries:    badBinaries,
+		Retention:          c.Retention,
+		EmitMetric:         c.EmitMetric,
+		ArchivalBucket:     c.ArchivalBucket,
+		ArchivalStatus:     c.ArchivalStatus,
+		BadBinaries:        badBinaries,
+		RetentionRunCount:  c.RetentionRunCount,
+		TimeoutPolicy:      c.TimeoutPolicy,
+		EncryptionKeyAlias: c.EncryptionKeyAlias,
+		Residency:          c.Residency,
 	}, nil
 }
 
@@ -176,11 +180,15 @@ func (m *metadataManagerImpl) deserializeDomainConfig(ic *InternalDomainConfig)
 		badBinaries.Binaries = map[string]*shared.BadBinaryInfo{}
 	}
 	return DomainConfig{
-		Retention:      ic.Retention,
-		EmitMetric:     ic.EmitMetric,
-		ArchivalBucket: ic.ArchivalBucket,
-		ArchivalStatus: ic.ArchivalStatus,
-		BadBinaries:    *badBinaries,
+		Retention:          ic.Retention,
+		EmitMetric:         ic.EmitMetric,
+		ArchivalBucket:     ic.ArchivalBucket,
+		ArchivalStatus:     ic.ArchivalStatus,
+		BadBinaries:        *badBinaries,
+		RetentionRunCount:  ic.RetentionRunCount,
+		TimeoutPolicy:      ic.TimeoutPolicy,
+		EncryptionKeyAlias: ic.EncryptionKeyAlias,
+		Residency:          ic.Residency,
 	}, nil
 }
 
@@ -188,6 +196,10 @@ func (m *metadataManagerImpl) GetMetadata() (*GetMetadataResponse, error) {
 	return m.persistence.GetMetadata()
 }
 
+func (m *metadataManagerImpl) Ping() error {
+	return m.persistence.Ping()
+}
+
 func (m *metadataManagerImpl) Close() {
 	m.persistence.Close()
 }
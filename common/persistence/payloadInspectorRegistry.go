@@ -0,0 +1,65 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package persistence
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/uber/cadence/common/log"
+	"github.com/uber/cadence/common/service/config"
+)
+
+type (
+	// PayloadInspectorFn creates a payload inspector from its config
+	PayloadInspectorFn func(cfg config.PayloadInspectorConfig, logger log.Logger) (PayloadInspector, error)
+)
+
+var (
+	payloadInspectorsLock sync.RWMutex
+	payloadInspectors     = make(map[string]PayloadInspectorFn)
+)
+
+// RegisterPayloadInspector makes a payload inspector available under the given name, so that
+// an inspector implementing the data handling policy of an organization can be compiled in and
+// selected by the payloadInspector config of the persistence. It is meant to be called from an
+// init function and panics if the constructor is nil or one is already registered under the name.
+func RegisterPayloadInspector(name string, fn PayloadInspectorFn) {
+	payloadInspectorsLock.Lock()
+	defer payloadInspectorsLock.Unlock()
+	if fn == nil {
+		panic("persistence: RegisterPayloadInspector constructor is nil")
+	}
+	if _, ok := payloadInspectors[name]; ok {
+		panic("persistence: RegisterPayloadInspector called twice for inspector " + name)
+	}
+	payloadInspectors[name] = fn
+}
+
+// NewPayloadInspector creates the payload inspector registered under the config name
+func NewPayloadInspector(cfg config.PayloadInspectorConfig, logger log.Logger) (PayloadInspector, error) {
+	payloadInspectorsLock.RLock()
+	fn, ok := payloadInspectors[cfg.Name]
+	payloadInspectorsLock.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("persistence: no payload inspector registered under %v", cfg.Name)
+	}
+	return fn(cfg, logger)
+}
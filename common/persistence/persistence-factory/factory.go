@@ -25,6 +25,7 @@ import (
 
 	"github.com/uber/cadence/common/clock"
 	"github.com/uber/cadence/common/log"
+	"github.com/uber/cadence/common/log/tag"
 	"github.com/uber/cadence/common/metrics"
 	p "github.com/uber/cadence/common/persistence"
 	"github.com/uber/cadence/common/persistence/cassandra"
@@ -55,6 +56,12 @@ type (
 		NewExecutionManager(shardID int) (p.ExecutionManager, error)
 		// NewVisibilityManager returns a new visibility manager
 		NewVisibilityManager() (p.VisibilityManager, error)
+		// NewClusterMembershipManager returns a new cluster membership manager
+		NewClusterMembershipManager() (p.ClusterMembershipManager, error)
+		// NewAuditManager returns a new audit manager
+		NewAuditManager() (p.AuditManager, error)
+		// NewScheduleManager returns a new schedule manager
+		NewScheduleManager() (p.ScheduleManager, error)
 	}
 	// DataStoreFactory is a low level interface to be implemented by a datastore
 	// Examples of datastores are cassandra, mysql etc
@@ -79,6 +86,12 @@ type (
 		NewExecutionStore(shardID int) (p.ExecutionStore, error)
 		// NewVisibilityStore returns a new visibility store
 		NewVisibilityStore() (p.VisibilityStore, error)
+		// NewClusterMembershipStore returns a new cluster membership store
+		NewClusterMembershipStore() (p.ClusterMembershipStore, error)
+		// NewAuditStore returns a new audit store
+		NewAuditStore() (p.AuditStore, error)
+		// NewScheduleStore returns a new schedule store
+		NewScheduleStore() (p.ScheduleStore, error)
 	}
 	// Datastore represents a datastore
 	Datastore struct {
@@ -91,6 +104,8 @@ type (
 		metricsClient metrics.Client
 		logger        log.Logger
 		datastores    map[storeType]Datastore
+		// secondary is the datastore the writes are mirrored into when dual write is configured
+		secondary *Datastore
 	}
 
 	storeType int
@@ -106,6 +121,9 @@ const (
 	storeTypeMetadata
 	storeTypeExecution
 	storeTypeVisibility
+	storeTypeClusterMembership
+	storeTypeSchedule
+	storeTypeAudit
 )
 
 const (
@@ -118,7 +136,9 @@ const (
 )
 
 var storeTypes = []storeType{
-	storeTypeHistory, storeTypeTask, storeTypeShard, storeTypeMetadata, storeTypeExecution, storeTypeVisibility}
+	storeTypeHistory, storeTypeTask, storeTypeShard, storeTypeMetadata, storeTypeExecution, storeTypeVisibility,
+	storeTypeClusterMembership, storeTypeSchedule,
+	storeTypeAudit}
 
 // New returns an implementation of factory that vends persistence objects based on
 // specified configuration. This factory takes as input a config.Persistence object
@@ -144,13 +164,16 @@ func New(
 
 // NewTaskManager returns a new task manager
 func (f *factoryImpl) NewTaskManager() (p.TaskManager, error) {
-	ds := f.datastores[storeTypeTask]
-	result, err := ds.factory.NewTaskStore()
+	result, err := f.newTaskManager(f.datastores[storeTypeTask])
 	if err != nil {
 		return nil, err
 	}
-	if ds.ratelimit != nil {
-		result = p.NewTaskPersistenceRateLimitedClient(result, ds.ratelimit, f.logger)
+	if f.secondary != nil {
+		secondary, err := f.newTaskManager(*f.secondary)
+		if err != nil {
+			return nil, err
+		}
+		result = p.NewTaskPersistenceDualWriteClient(result, secondary, f.config.DualWrite.VerifyReads, f.metricsClient, f.logger)
 	}
 	if f.metricsClient != nil {
 		result = p.NewTaskPersistenceMetricsClient(result, f.metricsClient, f.logger)
@@ -158,26 +181,67 @@ func (f *factoryImpl) NewTaskManager() (p.TaskManager, error) {
 	return result, nil
 }
 
+func (f *factoryImpl) newTaskManager(ds Datastore) (p.TaskManager, error) {
+	result, err := ds.factory.NewTaskStore()
+	if err != nil {
+		return nil, err
+	}
+	if ds.ratelimit != nil {
+		result = p.NewTaskPersistenceRateLimitedClient(result, ds.ratelimit, f.logger)
+	}
+	return result, nil
+}
+
 // NewShardManager returns a new shard manager
 func (f *factoryImpl) NewShardManager() (p.ShardManager, error) {
-	ds := f.datastores[storeTypeShard]
-	result, err := ds.factory.NewShardStore()
+	result, err := f.newShardManager(f.datastores[storeTypeShard])
 	if err != nil {
 		return nil, err
 	}
-	if ds.ratelimit != nil {
-		result = p.NewShardPersistenceRateLimitedClient(result, ds.ratelimit, f.logger)
+	if f.secondary != nil {
+		secondary, err := f.newShardManager(*f.secondary)
+		if err != nil {
+			return nil, err
+		}
+		result = p.NewShardPersistenceDualWriteClient(result, secondary, f.config.DualWrite.VerifyReads, f.metricsClient, f.logger)
 	}
 	if f.metricsClient != nil {
 		result = p.NewShardPersistenceMetricsClient(result, f.metricsClient, f.logger)
 	}
 	return result, nil
+}
 
+func (f *factoryImpl) newShardManager(ds Datastore) (p.ShardManager, error) {
+	result, err := ds.factory.NewShardStore()
+	if err != nil {
+		return nil, err
+	}
+	if ds.ratelimit != nil {
+		result = p.NewShardPersistenceRateLimitedClient(result, ds.ratelimit, f.logger)
+	}
+	return result, nil
 }
 
 // NewHistoryManager returns a new history manager
 func (f *factoryImpl) NewHistoryManager() (p.HistoryManager, error) {
-	ds := f.datastores[storeTypeHistory]
+	result, err := f.newHistoryManager(f.datastores[storeTypeHistory])
+	if err != nil {
+		return nil, err
+	}
+	if f.secondary != nil {
+		secondary, err := f.newHistoryManager(*f.secondary)
+		if err != nil {
+			return nil, err
+		}
+		result = p.NewHistoryPersistenceDualWriteClient(result, secondary, f.config.DualWrite.VerifyReads, f.metricsClient, f.logger)
+	}
+	if f.metricsClient != nil {
+		result = p.NewHistoryPersistenceMetricsClient(result, f.metricsClient, f.logger)
+	}
+	return result, nil
+}
+
+func (f *factoryImpl) newHistoryManager(ds Datastore) (p.HistoryManager, error) {
 	store, err := ds.factory.NewHistoryStore()
 	if err != nil {
 		return nil, err
@@ -186,15 +250,29 @@ func (f *factoryImpl) NewHistoryManager() (p.HistoryManager, error) {
 	if ds.ratelimit != nil {
 		result = p.NewHistoryPersistenceRateLimitedClient(result, ds.ratelimit, f.logger)
 	}
-	if f.metricsClient != nil {
-		result = p.NewHistoryPersistenceMetricsClient(result, f.metricsClient, f.logger)
-	}
 	return result, nil
 }
 
 // NewHistoryManager returns a new history manager
 func (f *factoryImpl) NewHistoryV2Manager() (p.HistoryV2Manager, error) {
-	ds := f.datastores[storeTypeHistory]
+	result, err := f.newHistoryV2Manager(f.datastores[storeTypeHistory])
+	if err != nil {
+		return nil, err
+	}
+	if f.secondary != nil {
+		secondary, err := f.newHistoryV2Manager(*f.secondary)
+		if err != nil {
+			return nil, err
+		}
+		result = p.NewHistoryV2PersistenceDualWriteClient(result, secondary, f.config.DualWrite.VerifyReads, f.metricsClient, f.logger)
+	}
+	if f.metricsClient != nil {
+		result = p.NewHistoryV2PersistenceMetricsClient(result, f.metricsClient, f.logger)
+	}
+	return result, nil
+}
+
+func (f *factoryImpl) newHistoryV2Manager(ds Datastore) (p.HistoryV2Manager, error) {
 	store, err := ds.factory.NewHistoryV2Store()
 	if err != nil {
 		return nil, err
@@ -203,17 +281,31 @@ func (f *factoryImpl) NewHistoryV2Manager() (p.HistoryV2Manager, error) {
 	if ds.ratelimit != nil {
 		result = p.NewHistoryV2PersistenceRateLimitedClient(result, ds.ratelimit, f.logger)
 	}
-	if f.metricsClient != nil {
-		result = p.NewHistoryV2PersistenceMetricsClient(result, f.metricsClient, f.logger)
-	}
 	return result, nil
 }
 
 // NewMetadataManager returns a new metadata manager
 func (f *factoryImpl) NewMetadataManager(version MetadataVersion) (p.MetadataManager, error) {
+	result, err := f.newMetadataManager(f.datastores[storeTypeMetadata], version)
+	if err != nil {
+		return nil, err
+	}
+	if f.secondary != nil {
+		secondary, err := f.newMetadataManager(*f.secondary, version)
+		if err != nil {
+			return nil, err
+		}
+		result = p.NewMetadataPersistenceDualWriteClient(result, secondary, f.config.DualWrite.VerifyReads, f.metricsClient, f.logger)
+	}
+	if f.metricsClient != nil {
+		result = p.NewMetadataPersistenceMetricsClient(result, f.metricsClient, f.logger)
+	}
+	return result, nil
+}
+
+func (f *factoryImpl) newMetadataManager(ds Datastore, version MetadataVersion) (p.MetadataManager, error) {
 	var err error
 	var store p.MetadataStore
-	ds := f.datastores[storeTypeMetadata]
 	switch version {
 	case MetadataV1:
 		store, err = ds.factory.NewMetadataStoreV1()
@@ -231,22 +323,21 @@ func (f *factoryImpl) NewMetadataManager(version MetadataVersion) (p.MetadataMan
 	if ds.ratelimit != nil {
 		result = p.NewMetadataPersistenceRateLimitedClient(result, ds.ratelimit, f.logger)
 	}
-	if f.metricsClient != nil {
-		result = p.NewMetadataPersistenceMetricsClient(result, f.metricsClient, f.logger)
-	}
 	return result, nil
 }
 
 // NewExecutionManager returns a new execution manager for a given shardID
 func (f *factoryImpl) NewExecutionManager(shardID int) (p.ExecutionManager, error) {
-	ds := f.datastores[storeTypeExecution]
-	store, err := ds.factory.NewExecutionStore(shardID)
+	result, err := f.newExecutionManager(f.datastores[storeTypeExecution], shardID)
 	if err != nil {
 		return nil, err
 	}
-	result := p.NewExecutionManagerImpl(store, f.logger)
-	if ds.ratelimit != nil {
-		result = p.NewWorkflowExecutionPersistenceRateLimitedClient(result, ds.ratelimit, f.logger)
+	if f.secondary != nil {
+		secondary, err := f.newExecutionManager(*f.secondary, shardID)
+		if err != nil {
+			return nil, err
+		}
+		result = p.NewWorkflowExecutionPersistenceDualWriteClient(result, secondary, f.config.DualWrite.VerifyReads, f.metricsClient, f.logger)
 	}
 	if f.metricsClient != nil {
 		result = p.NewWorkflowExecutionPersistenceMetricsClient(result, f.metricsClient, f.logger)
@@ -254,6 +345,19 @@ func (f *factoryImpl) NewExecutionManager(shardID int) (p.ExecutionManager, erro
 	return result, nil
 }
 
+func (f *factoryImpl) newExecutionManager(ds Datastore, shardID int) (p.ExecutionManager, error) {
+	store, err := ds.factory.NewExecutionStore(shardID)
+	if err != nil {
+		return nil, err
+	}
+	result := p.NewExecutionManagerImpl(store, f.logger,
+		f.config.ExecutionTransactionStatementLimit, f.config.ExecutionTransactionSizeLimit)
+	if ds.ratelimit != nil {
+		result = p.NewWorkflowExecutionPersistenceRateLimitedClient(result, ds.ratelimit, f.logger)
+	}
+	return result, nil
+}
+
 // NewVisibilityManager returns a new visibility manager
 func (f *factoryImpl) NewVisibilityManager() (p.VisibilityManager, error) {
 	ds := f.datastores[storeTypeVisibility]
@@ -262,9 +366,24 @@ func (f *factoryImpl) NewVisibilityManager() (p.VisibilityManager, error) {
 		return nil, err
 	}
 	visConfig := f.config.VisibilityConfig
+	// wrapped before v2 so filtered reads ordered by close time take precedence when both are enabled
+	if visConfig != nil && visConfig.EnableReadFromClosedExecutionsByFilter != nil &&
+		visConfig.EnableReadFromClosedExecutionsByFilter() && f.isCassandra() {
+		store, err = cassandra.NewVisibilityPersistenceClosedByFilter(store, f.getCassandraConfig(), f.logger)
+		if err != nil {
+			return nil, err
+		}
+	}
 	if visConfig != nil && visConfig.EnableReadFromClosedExecutionV2() && f.isCassandra() {
 		store, err = cassandra.NewVisibilityPersistenceV2(store, f.getCassandraConfig(), f.logger)
 	}
+	if visConfig != nil && visConfig.EnableReadFromOpenExecutionsByDomain != nil &&
+		visConfig.EnableReadFromOpenExecutionsByDomain() && f.isCassandra() {
+		store, err = cassandra.NewVisibilityPersistenceOpenByDomain(store, f.getCassandraConfig(), f.logger)
+		if err != nil {
+			return nil, err
+		}
+	}
 
 	result := p.NewVisibilityManagerImpl(store, f.logger)
 	if ds.ratelimit != nil {
@@ -280,15 +399,108 @@ func (f *factoryImpl) NewVisibilityManager() (p.VisibilityManager, error) {
 	return result, nil
 }
 
+// NewClusterMembershipManager returns a new cluster membership manager
+func (f *factoryImpl) NewClusterMembershipManager() (p.ClusterMembershipManager, error) {
+	result, err := f.newClusterMembershipManager(f.datastores[storeTypeClusterMembership])
+	if err != nil {
+		return nil, err
+	}
+	if f.secondary != nil {
+		secondary, err := f.newClusterMembershipManager(*f.secondary)
+		if err != nil {
+			return nil, err
+		}
+		result = p.NewClusterMembershipPersistenceDualWriteClient(result, secondary, f.config.DualWrite.VerifyReads, f.metricsClient, f.logger)
+	}
+	if f.metricsClient != nil {
+		result = p.NewClusterMembershipPersistenceMetricsClient(result, f.metricsClient, f.logger)
+	}
+	return result, nil
+}
+
+func (f *factoryImpl) newClusterMembershipManager(ds Datastore) (p.ClusterMembershipManager, error) {
+	result, err := ds.factory.NewClusterMembershipStore()
+	if err != nil {
+		return nil, err
+	}
+	if ds.ratelimit != nil {
+		result = p.NewClusterMembershipPersistenceRateLimitedClient(result, ds.ratelimit, f.logger)
+	}
+	return result, nil
+}
+
+// NewAuditManager returns a new audit manager
+func (f *factoryImpl) NewAuditManager() (p.AuditManager, error) {
+	result, err := f.newAuditManager(f.datastores[storeTypeAudit])
+	if err != nil {
+		return nil, err
+	}
+	if f.secondary != nil {
+		secondary, err := f.newAuditManager(*f.secondary)
+		if err != nil {
+			return nil, err
+		}
+		result = p.NewAuditPersistenceDualWriteClient(result, secondary, f.config.DualWrite.VerifyReads, f.metricsClient, f.logger)
+	}
+	if f.metricsClient != nil {
+		result = p.NewAuditPersistenceMetricsClient(result, f.metricsClient, f.logger)
+	}
+	return result, nil
+}
+
+func (f *factoryImpl) newAuditManager(ds Datastore) (p.AuditManager, error) {
+	result, err := ds.factory.NewAuditStore()
+	if err != nil {
+		return nil, err
+	}
+	if ds.ratelimit != nil {
+		result = p.NewAuditPersistenceRateLimitedClient(result, ds.ratelimit, f.logger)
+	}
+	return result, nil
+}
+
+// NewScheduleManager returns a new schedule manager
+func (f *factoryImpl) NewScheduleManager() (p.ScheduleManager, error) {
+	result, err := f.newScheduleManager(f.datastores[storeTypeSchedule])
+	if err != nil {
+		return nil, err
+	}
+	if f.secondary != nil {
+		secondary, err := f.newScheduleManager(*f.secondary)
+		if err != nil {
+			return nil, err
+		}
+		result = p.NewSchedulePersistenceDualWriteClient(result, secondary, f.config.DualWrite.VerifyReads, f.metricsClient, f.logger)
+	}
+	if f.metricsClient != nil {
+		result = p.NewSchedulePersistenceMetricsClient(result, f.metricsClient, f.logger)
+	}
+	return result, nil
+}
+
+func (f *factoryImpl) newScheduleManager(ds Datastore) (p.ScheduleManager, error) {
+	result, err := ds.factory.NewScheduleStore()
+	if err != nil {
+		return nil, err
+	}
+	if ds.ratelimit != nil {
+		result = p.NewSchedulePersistenceRateLimitedClient(result, ds.ratelimit, f.logger)
+	}
+	return result, nil
+}
+
 // Close closes this factory
 func (f *factoryImpl) Close() {
 	ds := f.datastores[storeTypeExecution]
 	ds.factory.Close()
+	if f.secondary != nil {
+		f.secondary.factory.Close()
+	}
 }
 
 func (f *factoryImpl) isCassandra() bool {
 	cfg := f.config
-	return cfg.DataStores[cfg.VisibilityStore].SQL == nil
+	return cfg.DataStores[cfg.VisibilityStore].Cassandra != nil
 }
 
 func (f *factoryImpl) getCassandraConfig() *config.Cassandra {
@@ -299,14 +511,9 @@ func (f *factoryImpl) getCassandraConfig() *config.Cassandra {
 func (f *factoryImpl) init(clusterName string, limiters map[string]tokenbucket.TokenBucket) {
 	f.datastores = make(map[storeType]Datastore, len(storeTypes))
 	defaultCfg := f.config.DataStores[f.config.DefaultStore]
-	defaultDataStore := Datastore{ratelimit: limiters[f.config.DefaultStore]}
-	switch {
-	case defaultCfg.Cassandra != nil:
-		defaultDataStore.factory = cassandra.NewFactory(*defaultCfg.Cassandra, clusterName, f.logger)
-	case defaultCfg.SQL != nil:
-		defaultDataStore.factory = sql.NewFactory(*defaultCfg.SQL, clusterName, f.logger)
-	default:
-		f.logger.Fatal("invalid config: one of cassandra or sql params must be specified")
+	defaultDataStore := Datastore{
+		factory:   f.newDataStoreFactory(defaultCfg, clusterName),
+		ratelimit: limiters[f.config.DefaultStore],
 	}
 
 	for _, st := range storeTypes {
@@ -316,17 +523,37 @@ func (f *factoryImpl) init(clusterName string, limiters map[string]tokenbucket.T
 	}
 
 	visibilityCfg := f.config.DataStores[f.config.VisibilityStore]
-	visibilityDataStore := Datastore{ratelimit: limiters[f.config.VisibilityStore]}
+	f.datastores[storeTypeVisibility] = Datastore{
+		factory:   f.newDataStoreFactory(visibilityCfg, clusterName),
+		ratelimit: limiters[f.config.VisibilityStore],
+	}
+
+	if dualWrite := f.config.DualWrite; dualWrite != nil {
+		secondaryCfg := f.config.DataStores[dualWrite.SecondaryStore]
+		f.secondary = &Datastore{
+			factory:   f.newDataStoreFactory(secondaryCfg, clusterName),
+			ratelimit: limiters[dualWrite.SecondaryStore],
+		}
+	}
+}
+
+// newDataStoreFactory returns the factory of the cassandra, sql or custom datastore set in the config
+func (f *factoryImpl) newDataStoreFactory(cfg config.DataStore, clusterName string) DataStoreFactory {
 	switch {
-	case defaultCfg.Cassandra != nil:
-		visibilityDataStore.factory = cassandra.NewFactory(*visibilityCfg.Cassandra, clusterName, f.logger)
-	case visibilityCfg.SQL != nil:
-		visibilityDataStore.factory = sql.NewFactory(*visibilityCfg.SQL, clusterName, f.logger)
+	case cfg.Cassandra != nil:
+		return cassandra.NewFactory(*cfg.Cassandra, clusterName, f.config.NumHistoryShards, f.logger)
+	case cfg.SQL != nil:
+		return sql.NewFactory(*cfg.SQL, clusterName, f.logger)
+	case cfg.CustomDataStoreConfig != nil:
+		factory, err := newCustomDataStoreFactory(*cfg.CustomDataStoreConfig, clusterName, f.logger)
+		if err != nil {
+			f.logger.Fatal("invalid config: failed to create custom datastore", tag.Error(err))
+		}
+		return factory
 	default:
-		f.logger.Fatal("invalid config: one of cassandra or sql params must be specified")
+		f.logger.Fatal("invalid config: one of cassandra, sql or custom datastore params must be specified")
 	}
-
-	f.datastores[storeTypeVisibility] = visibilityDataStore
+	return nil
 }
 
 func buildRatelimiters(cfg *config.Persistence) map[string]tokenbucket.TokenBucket {
@@ -339,6 +566,9 @@ func buildRatelimiters(cfg *config.Persistence) map[string]tokenbucket.TokenBuck
 		if ds.SQL != nil {
 			qps = ds.SQL.MaxQPS
 		}
+		if ds.CustomDataStoreConfig != nil {
+			qps = ds.CustomDataStoreConfig.MaxQPS
+		}
 		if qps > 0 {
 			result[dsName] = tokenbucket.New(qps, clock.NewRealTimeSource())
 		}
@@ -0,0 +1,66 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package persistence
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/uber/cadence/common/log"
+	"github.com/uber/cadence/common/service/config"
+)
+
+type (
+	// StoreFactoryFn creates the DataStoreFactory of a custom datastore from its config
+	StoreFactoryFn func(cfg config.CustomDatastoreConfig, clusterName string, logger log.Logger) (DataStoreFactory, error)
+)
+
+var (
+	storeFactoriesLock sync.RWMutex
+	storeFactories     = make(map[string]StoreFactoryFn)
+)
+
+// RegisterStoreFactory makes a custom datastore available under the given name, so that
+// third party stores can be compiled in and selected by the customDatastore config of a
+// datastore. It is meant to be called from an init function and panics if the factory is
+// nil or a factory is already registered under the name.
+func RegisterStoreFactory(name string, factory StoreFactoryFn) {
+	storeFactoriesLock.Lock()
+	defer storeFactoriesLock.Unlock()
+	if factory == nil {
+		panic("persistence: RegisterStoreFactory factory is nil")
+	}
+	if _, ok := storeFactories[name]; ok {
+		panic("persistence: RegisterStoreFactory called twice for store " + name)
+	}
+	storeFactories[name] = factory
+}
+
+// newCustomDataStoreFactory creates the DataStoreFactory of the custom datastore registered under the config name
+func newCustomDataStoreFactory(cfg config.CustomDatastoreConfig, clusterName string, logger log.Logger) (DataStoreFactory, error) {
+	storeFactoriesLock.RLock()
+	factory, ok := storeFactories[cfg.Name]
+	storeFactoriesLock.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("persistence: no store factory registered for custom datastore %v", cfg.Name)
+	}
+	return factory(cfg, clusterName, logger)
+}
@@ -0,0 +1,55 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package persistence
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/uber/cadence/common/log"
+	"github.com/uber/cadence/common/log/loggerimpl"
+	"github.com/uber/cadence/common/service/config"
+)
+
+func TestRegisterStoreFactory(t *testing.T) {
+	var options map[string]string
+	errCreate := errors.New("create failed")
+	RegisterStoreFactory("registry-test", func(cfg config.CustomDatastoreConfig, clusterName string, logger log.Logger) (DataStoreFactory, error) {
+		options = cfg.Options
+		return nil, errCreate
+	})
+	require.Panics(t, func() {
+		RegisterStoreFactory("registry-test", func(config.CustomDatastoreConfig, string, log.Logger) (DataStoreFactory, error) {
+			return nil, nil
+		})
+	})
+	require.Panics(t, func() { RegisterStoreFactory("registry-test-nil", nil) })
+
+	logger := loggerimpl.NewNopLogger()
+	cfg := config.CustomDatastoreConfig{Name: "registry-test", Options: map[string]string{"key": "value"}}
+	_, err := newCustomDataStoreFactory(cfg, "active", logger)
+	require.Equal(t, errCreate, err)
+	require.Equal(t, cfg.Options, options)
+
+	_, err = newCustomDataStoreFactory(config.CustomDatastoreConfig{Name: "registry-test-missing"}, "active", logger)
+	require.Error(t, err)
+}
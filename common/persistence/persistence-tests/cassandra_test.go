@@ -68,6 +68,35 @@ func TestCassandraShardPersistence(t *testing.T) {
 	suite.Run(t, s)
 }
 
+func TestCassandraShardTransferTaskBucketsNeverDecrease(t *testing.T) {
+	s := NewTestBaseWithCassandra(&TestBaseOptions{TransferTaskBuckets: 4})
+	s.SetT(t)
+	s.Setup()
+	defer s.TearDownWorkflowStore()
+
+	shardID := 40
+	s.Nil(s.CreateShard(shardID, "test_transfer_task_buckets", 151))
+	shardInfo, err := s.GetShard(shardID)
+	s.Nil(err)
+	s.Equal(4, shardInfo.TransferTaskBuckets)
+
+	updatedInfo := copyShardInfo(shardInfo)
+	updatedInfo.RangeID = shardInfo.RangeID + 1
+	updatedInfo.TransferTaskBuckets = 8
+	s.Nil(s.UpdateShard(updatedInfo, shardInfo.RangeID))
+	shardInfo, err = s.GetShard(shardID)
+	s.Nil(err)
+	s.Equal(8, shardInfo.TransferTaskBuckets)
+
+	// the shard keeps the count persisted by a configuration using more buckets
+	updatedInfo = copyShardInfo(shardInfo)
+	updatedInfo.RangeID = shardInfo.RangeID + 1
+	s.Nil(s.UpdateShard(updatedInfo, shardInfo.RangeID))
+	shardInfo, err = s.GetShard(shardID)
+	s.Nil(err)
+	s.Equal(8, shardInfo.TransferTaskBuckets)
+}
+
 func TestCassandraVisibilityPersistence(t *testing.T) {
 	s := new(VisibilityPersistenceSuite)
 	s.TestBase = NewTestBaseWithCassandra(&TestBaseOptions{})
@@ -75,6 +104,20 @@ func TestCassandraVisibilityPersistence(t *testing.T) {
 	suite.Run(t, s)
 }
 
+func TestCassandraVisibilityPersistenceWithOpenExecutionsByDomain(t *testing.T) {
+	s := new(VisibilityPersistenceSuite)
+	s.TestBase = NewTestBaseWithCassandra(&TestBaseOptions{OpenExecutionBuckets: 4})
+	s.TestBase.Setup()
+	suite.Run(t, s)
+}
+
+func TestCassandraVisibilityPersistenceWithClosedExecutionsByFilter(t *testing.T) {
+	s := new(VisibilityPersistenceSuite)
+	s.TestBase = NewTestBaseWithCassandra(&TestBaseOptions{ReadClosedExecutionsByFilter: true})
+	s.TestBase.Setup()
+	suite.Run(t, s)
+}
+
 func TestCassandraExecutionManager(t *testing.T) {
 	s := new(ExecutionManagerSuite)
 	s.TestBase = NewTestBaseWithCassandra(&TestBaseOptions{})
@@ -82,6 +125,13 @@ func TestCassandraExecutionManager(t *testing.T) {
 	suite.Run(t, s)
 }
 
+func TestCassandraExecutionManagerWithBucketedTransferTasks(t *testing.T) {
+	s := new(ExecutionManagerSuite)
+	s.TestBase = NewTestBaseWithCassandra(&TestBaseOptions{TransferTaskBuckets: 4})
+	s.TestBase.Setup()
+	suite.Run(t, s)
+}
+
 func TestCassandraExecutionManagerWithEventsV2(t *testing.T) {
 	s := new(ExecutionManagerSuiteForEventsV2)
 	s.TestBase = NewTestBaseWithCassandra(&TestBaseOptions{})
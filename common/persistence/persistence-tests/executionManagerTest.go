@@ -70,6 +70,11 @@ func (s *ExecutionManagerSuite) SetupTest() {
 	s.ClearTasks()
 }
 
+// TestPing test
+func (s *ExecutionManagerSuite) TestPing() {
+	s.NoError(s.ExecutionManager.Ping())
+}
+
 // TestCreateWorkflowExecutionStateCloseStatus test
 func (s *ExecutionManagerSuite) TestCreateWorkflowExecutionStateCloseStatus() {
 	domainID := uuid.New()
@@ -963,6 +968,7 @@ func (s *ExecutionManagerSuite) TestUpdateWorkflow() {
 	s.Equal(common.EmptyEventID, info0.DecisionStartedID)
 	s.Equal(int32(1), info0.DecisionTimeout)
 	s.Equal(int64(0), info0.DecisionAttempt)
+	s.Equal(int64(0), info0.DecisionHeartbeatCount)
 	s.Equal(int64(0), info0.DecisionStartedTimestamp)
 	s.Equal(int64(0), info0.DecisionScheduledTimestamp)
 	s.Empty(info0.StickyTaskList)
@@ -983,6 +989,7 @@ func (s *ExecutionManagerSuite) TestUpdateWorkflow() {
 	updatedInfo.LastProcessedEvent = int64(2)
 	updatedInfo.DecisionVersion = int64(666)
 	updatedInfo.DecisionAttempt = int64(123)
+	updatedInfo.DecisionHeartbeatCount = int64(4)
 	updatedInfo.DecisionStartedTimestamp = int64(321)
 	updatedInfo.DecisionScheduledTimestamp = int64(654)
 	updatedInfo.StickyTaskList = "random sticky tasklist"
@@ -998,6 +1005,18 @@ func (s *ExecutionManagerSuite) TestUpdateWorkflow() {
 	updatedInfo.ExpirationSeconds = math.MaxInt32
 	updatedInfo.ExpirationTime = time.Now()
 	updatedInfo.NonRetriableErrors = []string{"accessDenied", "badRequest"}
+	updatedInfo.CompletedActivityRequestIDs = []string{uuid.New()}
+	updatedInfo.Paused = true
+	updatedInfo.CronPaused = true
+	updatedInfo.CronTimezone = "Europe/Paris"
+	updatedInfo.DecisionStartedIdentity = "decision-worker"
+	updatedInfo.DecisionStartedBinaryChecksum = "decision-checksum"
+	updatedInfo.ResetBaseRunID = "8a3c9f2e-4b1d-4d6e-9f7a-2c5b8e1d3f40"
+	updatedInfo.ResetBaseEventID = int64(11)
+	updatedInfo.ResetReason = "reset-reason"
+	updatedInfo.Notes = "being investigated"
+	updatedInfo.NotesIdentity = "operator"
+	updatedInfo.NotesLastUpdatedTime = time.Unix(1590000000, 0).UTC()
 	searchAttrKey := "env"
 	searchAttrVal := []byte("test")
 	updatedInfo.SearchAttributes = map[string][]byte{searchAttrKey: searchAttrVal}
@@ -1029,6 +1048,7 @@ func (s *ExecutionManagerSuite) TestUpdateWorkflow() {
 	s.Equal(common.EmptyEventID, info1.DecisionStartedID)
 	s.Equal(int32(1), info1.DecisionTimeout)
 	s.Equal(int64(123), info1.DecisionAttempt)
+	s.Equal(int64(4), info1.DecisionHeartbeatCount)
 	s.Equal(int64(321), info1.DecisionStartedTimestamp)
 	s.Equal(int64(654), info1.DecisionScheduledTimestamp)
 	s.Equal(updatedInfo.StickyTaskList, info1.StickyTaskList)
@@ -1045,6 +1065,18 @@ func (s *ExecutionManagerSuite) TestUpdateWorkflow() {
 	s.Equal(updatedInfo.ExpirationSeconds, info1.ExpirationSeconds)
 	s.EqualTimes(updatedInfo.ExpirationTime, info1.ExpirationTime)
 	s.Equal(updatedInfo.NonRetriableErrors, info1.NonRetriableErrors)
+	s.Equal(updatedInfo.CompletedActivityRequestIDs, info1.CompletedActivityRequestIDs)
+	s.True(info1.Paused)
+	s.True(info1.CronPaused)
+	s.Equal("Europe/Paris", info1.CronTimezone)
+	s.Equal("decision-worker", info1.DecisionStartedIdentity)
+	s.Equal("decision-checksum", info1.DecisionStartedBinaryChecksum)
+	s.Equal("8a3c9f2e-4b1d-4d6e-9f7a-2c5b8e1d3f40", info1.ResetBaseRunID)
+	s.Equal(int64(11), info1.ResetBaseEventID)
+	s.Equal("reset-reason", info1.ResetReason)
+	s.Equal("being investigated", info1.Notes)
+	s.Equal("operator", info1.NotesIdentity)
+	s.EqualTimes(time.Unix(1590000000, 0).UTC(), info1.NotesLastUpdatedTime)
 	searchAttrVal1, ok := info1.SearchAttributes[searchAttrKey]
 	s.True(ok)
 	s.Equal(searchAttrVal, searchAttrVal1)
@@ -1080,6 +1112,7 @@ func (s *ExecutionManagerSuite) TestUpdateWorkflow() {
 	s.Equal(common.EmptyEventID, info2.DecisionStartedID)
 	s.Equal(int32(1), info2.DecisionTimeout)
 	s.Equal(int64(123), info2.DecisionAttempt)
+	s.Equal(int64(4), info2.DecisionHeartbeatCount)
 	s.Equal(int64(321), info2.DecisionStartedTimestamp)
 	s.Equal(int64(654), info2.DecisionScheduledTimestamp)
 	s.Equal(updatedInfo.SignalCount, info2.SignalCount)
@@ -1124,6 +1157,7 @@ func (s *ExecutionManagerSuite) TestUpdateWorkflow() {
 	s.Equal(common.EmptyEventID, info3.DecisionStartedID)
 	s.Equal(int32(1), info3.DecisionTimeout)
 	s.Equal(int64(123), info3.DecisionAttempt)
+	s.Equal(int64(4), info3.DecisionHeartbeatCount)
 	s.Equal(int64(321), info3.DecisionStartedTimestamp)
 	s.Equal(int64(654), info3.DecisionScheduledTimestamp)
 	s.Equal(updatedInfo.SignalCount, info3.SignalCount)
@@ -1169,6 +1203,7 @@ func (s *ExecutionManagerSuite) TestUpdateWorkflow() {
 	s.Equal(common.EmptyEventID, info4.DecisionStartedID)
 	s.Equal(int32(1), info4.DecisionTimeout)
 	s.Equal(int64(123), info4.DecisionAttempt)
+	s.Equal(int64(4), info4.DecisionHeartbeatCount)
 	s.Equal(int64(321), info4.DecisionStartedTimestamp)
 	s.Equal(updatedInfo.SignalCount, info4.SignalCount)
 	s.EqualValues(updatedStats.HistorySize, state4.ExecutionStats.HistorySize)
@@ -1271,14 +1306,18 @@ func (s *ExecutionManagerSuite) TestDeleteCurrentWorkflow() {
 	}
 
 	// test wrong run id with conditional delete
-	s.DeleteCurrentWorkflowExecution(fakeInfo)
+	result, err := s.DeleteCurrentWorkflowExecution(fakeInfo, false)
+	s.NoError(err)
+	s.Equal(p.DeleteCurrentWorkflowExecutionResultMismatched, result)
 
 	runID5, err5 := s.GetCurrentWorkflowRunID(domainID, workflowExecution.GetWorkflowId())
 	s.NoError(err5)
 	s.Equal(workflowExecution.GetRunId(), runID5)
 
 	// simulate a timer_task deleting execution after retention
-	s.DeleteCurrentWorkflowExecution(info0.ExecutionInfo)
+	result, err = s.DeleteCurrentWorkflowExecution(info0.ExecutionInfo, false)
+	s.NoError(err)
+	s.Equal(p.DeleteCurrentWorkflowExecutionResultDeleted, result)
 
 	runID0, err1 = s.GetCurrentWorkflowRunID(domainID, workflowExecution.GetWorkflowId())
 	s.Error(err1)
@@ -1286,11 +1325,44 @@ func (s *ExecutionManagerSuite) TestDeleteCurrentWorkflow() {
 	_, ok := err1.(*gen.EntityNotExistsError)
 	s.True(ok)
 
+	result, err = s.DeleteCurrentWorkflowExecution(info0.ExecutionInfo, false)
+	s.NoError(err)
+	s.Equal(p.DeleteCurrentWorkflowExecutionResultMissing, result)
+
 	// execution record should still be there
 	info0, err2 = s.GetWorkflowExecutionInfo(domainID, workflowExecution)
 	s.NoError(err2)
 }
 
+// TestDeleteCurrentWorkflowForce test
+func (s *ExecutionManagerSuite) TestDeleteCurrentWorkflowForce() {
+	domainID := "2c8e5a1f-7d4b-4e9a-b3c6-1f0d8e2a5b47"
+	workflowExecution := gen.WorkflowExecution{
+		WorkflowId: common.StringPtr("delete-current-workflow-force-test"),
+		RunId:      common.StringPtr("9a4d2e7c-3b1f-4c8a-a6e5-0d7b9c2f4e18"),
+	}
+
+	task0, err0 := s.CreateWorkflowExecution(domainID, workflowExecution, "queue1", "wType", 20, 13, nil, 3, 0, 2, nil)
+	s.NoError(err0)
+	s.NotNil(task0, "Expected non empty task identifier.")
+
+	fakeInfo := &p.WorkflowExecutionInfo{
+		DomainID:   domainID,
+		WorkflowID: workflowExecution.GetWorkflowId(),
+		RunID:      uuid.New(),
+	}
+	result, err := s.DeleteCurrentWorkflowExecution(fakeInfo, true)
+	s.NoError(err)
+	s.Equal(p.DeleteCurrentWorkflowExecutionResultDeleted, result)
+
+	_, err = s.GetCurrentWorkflowRunID(domainID, workflowExecution.GetWorkflowId())
+	s.IsType(&gen.EntityNotExistsError{}, err)
+
+	result, err = s.DeleteCurrentWorkflowExecution(fakeInfo, true)
+	s.NoError(err)
+	s.Equal(p.DeleteCurrentWorkflowExecutionResultMissing, result)
+}
+
 // TestUpdateDeleteWorkflow mocks the timer behavoir to clean up workflow.
 func (s *ExecutionManagerSuite) TestUpdateDeleteWorkflow() {
 	finishedCurrentExecutionRetentionTTL := int32(2)
@@ -1323,7 +1395,7 @@ func (s *ExecutionManagerSuite) TestUpdateDeleteWorkflow() {
 	s.Equal(workflowExecution.GetRunId(), runID4)
 
 	// simulate a timer_task deleting execution after retention
-	err5 := s.DeleteCurrentWorkflowExecution(info0.ExecutionInfo)
+	_, err5 := s.DeleteCurrentWorkflowExecution(info0.ExecutionInfo, false)
 	s.NoError(err5)
 	err6 := s.DeleteWorkflowExecution(info0.ExecutionInfo)
 	s.NoError(err6)
@@ -1387,6 +1459,159 @@ func (s *ExecutionManagerSuite) TestGetCurrentWorkflow() {
 	s.Empty(task1, "Expected empty task identifier.")
 }
 
+// TestListWorkflowExecutionRuns test
+func (s *ExecutionManagerSuite) TestListWorkflowExecutionRuns() {
+	domainID := "0f5a5a4e-5d1b-4a0c-9f55-3f5e9d1b6c2a"
+	workflowExecution := gen.WorkflowExecution{
+		WorkflowId: common.StringPtr("list-workflow-execution-runs-test"),
+		RunId:      common.StringPtr("8d3c9f1e-6a2b-4f6e-9c1d-2b7a5e4f3c10"),
+	}
+
+	task0, err0 := s.CreateWorkflowExecution(domainID, workflowExecution, "queue1", "wType", 20, 13, nil, 3, 0, 2, nil)
+	s.NoError(err0)
+	s.NotNil(task0, "Expected non empty task identifier.")
+
+	request := &p.ListWorkflowExecutionRunsRequest{
+		DomainID:   domainID,
+		WorkflowID: workflowExecution.GetWorkflowId(),
+		PageSize:   10,
+	}
+	response, err := s.ExecutionManager.ListWorkflowExecutionRuns(request)
+	s.NoError(err)
+	s.Equal(1, len(response.Runs))
+	s.Equal(workflowExecution.GetRunId(), response.Runs[0].RunID)
+	s.Equal(p.WorkflowStateRunning, response.Runs[0].State)
+
+	info0, err1 := s.GetWorkflowExecutionInfo(domainID, workflowExecution)
+	s.NoError(err1)
+	updatedInfo := copyWorkflowExecutionInfo(info0.ExecutionInfo)
+	updatedStats := copyExecutionStats(info0.ExecutionStats)
+	updatedInfo.State = p.WorkflowStateCompleted
+	updatedInfo.CloseStatus = p.WorkflowCloseStatusCompleted
+	updatedInfo.NextEventID = int64(6)
+	updatedInfo.LastProcessedEvent = int64(2)
+	err2 := s.UpdateWorkflowExecutionAndFinish(updatedInfo, updatedStats, int64(3))
+	s.NoError(err2)
+
+	response, err = s.ExecutionManager.ListWorkflowExecutionRuns(request)
+	s.NoError(err)
+	s.Equal(1, len(response.Runs))
+	s.Equal(workflowExecution.GetRunId(), response.Runs[0].RunID)
+	s.Equal(p.WorkflowStateCompleted, response.Runs[0].State)
+	s.Equal(p.WorkflowCloseStatusCompleted, response.Runs[0].CloseStatus)
+}
+
+// TestListCurrentExecutions test
+func (s *ExecutionManagerSuite) TestListCurrentExecutions() {
+	domainID := "6b2f4c8e-1d3a-4e5f-a7b9-0c2d4e6f8a13"
+	workflowExecution := gen.WorkflowExecution{
+		WorkflowId: common.StringPtr("list-current-executions-test"),
+		RunId:      common.StringPtr("e3a7c1d5-9b2f-4a6e-8d0c-5f1b3e7a9c24"),
+	}
+
+	task0, err0 := s.CreateWorkflowExecution(domainID, workflowExecution, "queue1", "wType", 20, 13, nil, 3, 0, 2, nil)
+	s.NoError(err0)
+	s.NotNil(task0, "Expected non empty task identifier.")
+
+	var current *p.CurrentExecutionInfo
+	var pageToken []byte
+	for {
+		response, err := s.ExecutionManager.ListCurrentExecutions(&p.ListCurrentExecutionsRequest{
+			PageSize:      10,
+			NextPageToken: pageToken,
+		})
+		s.NoError(err)
+		for _, execution := range response.Executions {
+			if execution.DomainID == domainID && execution.WorkflowID == workflowExecution.GetWorkflowId() {
+				current = execution
+			}
+		}
+		if len(response.NextPageToken) == 0 {
+			break
+		}
+		pageToken = response.NextPageToken
+	}
+	s.NotNil(current)
+	s.Equal(workflowExecution.GetRunId(), current.RunID)
+	s.Equal(p.WorkflowStateRunning, current.State)
+}
+
+// TestBlockCurrentWorkflowExecution test
+func (s *ExecutionManagerSuite) TestBlockCurrentWorkflowExecution() {
+	domainID := "6b1f2d3c-7e4a-4c5b-8d9e-0a1b2c3d4e5f"
+	workflowExecution := gen.WorkflowExecution{
+		WorkflowId: common.StringPtr("block-current-workflow-execution-test"),
+		RunId:      common.StringPtr("3e2d1c0b-9a8f-4e7d-b6c5-a4b3c2d1e0f9"),
+	}
+
+	task0, err0 := s.CreateWorkflowExecution(domainID, workflowExecution, "queue1", "wType", 20, 13, nil, 3, 0, 2, nil)
+	s.NoError(err0)
+	s.NotNil(task0, "Expected non empty task identifier.")
+
+	current, err1 := s.ExecutionManager.GetCurrentExecution(&p.GetCurrentExecutionRequest{
+		DomainID:   domainID,
+		WorkflowID: workflowExecution.GetWorkflowId(),
+	})
+	s.NoError(err1)
+	s.True(current.BlockedUntil.IsZero())
+
+	blockedUntil := time.Now().Add(time.Minute)
+	err2 := s.ExecutionManager.BlockCurrentWorkflowExecution(&p.BlockCurrentWorkflowExecutionRequest{
+		DomainID:     domainID,
+		WorkflowID:   workflowExecution.GetWorkflowId(),
+		RunID:        uuid.New(),
+		BlockedUntil: blockedUntil,
+	})
+	s.IsType(&p.ConditionFailedError{}, err2)
+
+	err3 := s.ExecutionManager.BlockCurrentWorkflowExecution(&p.BlockCurrentWorkflowExecutionRequest{
+		DomainID:     domainID,
+		WorkflowID:   workflowExecution.GetWorkflowId(),
+		RunID:        workflowExecution.GetRunId(),
+		BlockedUntil: blockedUntil,
+	})
+	s.NoError(err3)
+
+	current, err1 = s.ExecutionManager.GetCurrentExecution(&p.GetCurrentExecutionRequest{
+		DomainID:   domainID,
+		WorkflowID: workflowExecution.GetWorkflowId(),
+	})
+	s.NoError(err1)
+	s.Equal(workflowExecution.GetRunId(), current.RunID)
+	s.EqualTimes(blockedUntil, current.BlockedUntil)
+}
+
+// TestListBufferedReplicationTasks test
+func (s *ExecutionManagerSuite) TestListBufferedReplicationTasks() {
+	domainID := "4a4e7e3b-0d4f-4a7e-b1c5-9d2c6f8e3a71"
+	workflowExecution := gen.WorkflowExecution{
+		WorkflowId: common.StringPtr("list-buffered-replication-tasks-test"),
+		RunId:      common.StringPtr("c1e9a2f4-7b3d-4e6a-8f5c-0d9b2a7e4c63"),
+	}
+
+	task0, err0 := s.CreateWorkflowExecution(domainID, workflowExecution, "queue1", "wType", 20, 13, nil, 3, 0, 2, nil)
+	s.NoError(err0)
+	s.NotNil(task0, "Expected non empty task identifier.")
+
+	// executions never buffer replication tasks, so none are reported for the shard
+	response, err := s.ExecutionManager.ListBufferedReplicationTasks(&p.ListBufferedReplicationTasksRequest{
+		PageSize: 10,
+	})
+	s.NoError(err)
+	s.Empty(response.Executions)
+
+	err = s.ExecutionManager.DeleteBufferedReplicationTasks(&p.DeleteBufferedReplicationTasksRequest{
+		DomainID:   domainID,
+		WorkflowID: workflowExecution.GetWorkflowId(),
+		RunID:      workflowExecution.GetRunId(),
+	})
+	s.NoError(err)
+
+	info, err1 := s.GetWorkflowExecutionInfo(domainID, workflowExecution)
+	s.NoError(err1)
+	s.Equal(workflowExecution.GetRunId(), info.ExecutionInfo.RunID)
+}
+
 // TestTransferTasksThroughUpdate test
 func (s *ExecutionManagerSuite) TestTransferTasksThroughUpdate() {
 	domainID := "b785a8ba-bd7d-4760-bb05-41b115f3e10a"
@@ -1766,9 +1991,15 @@ func (s *ExecutionManagerSuite) TestReplicationTasks() {
 			expected := replicationTasks[index].(*p.SyncActivityTask)
 			s.Equal(expected.ScheduledID, respTasks[index].ScheduledID)
 		}
-		err = s.CompleteReplicationTask(respTasks[index].GetTaskID())
-		s.NoError(err)
 	}
+
+	err = s.CompleteReplicationTask(respTasks[0].GetTaskID())
+	s.NoError(err)
+	err = s.RangeCompleteReplicationTask(respTasks[len(respTasks)-1].GetTaskID())
+	s.NoError(err)
+	respTasks, err = s.GetReplicationTasks(1, true)
+	s.NoError(err)
+	s.Empty(respTasks)
 }
 
 // TestTransferTasksComplete test
@@ -1869,6 +2100,61 @@ func (s *ExecutionManagerSuite) TestTransferTasksComplete() {
 	s.Empty(txTasks, "expected empty task list.")
 }
 
+// TestVisibilityTasks test
+func (s *ExecutionManagerSuite) TestVisibilityTasks() {
+	domainID := "0a5c1c2e-51c0-4a1f-8b7a-2d7f3b0e6c51"
+	workflowExecution := gen.WorkflowExecution{
+		WorkflowId: common.StringPtr("get-visibility-tasks-test"),
+		RunId:      common.StringPtr("0a5c1c2e-51c0-4a1f-8b7a-2d7f3b0e6c52"),
+	}
+
+	task0, err := s.CreateWorkflowExecution(domainID, workflowExecution, "queue1", "wType", 20, 13, nil, 3, 0, 2, nil)
+	s.NoError(err)
+	s.NotNil(task0, "Expected non empty task identifier.")
+
+	state0, err := s.GetWorkflowExecutionInfo(domainID, workflowExecution)
+	s.NoError(err)
+	updatedInfo := copyWorkflowExecutionInfo(state0.ExecutionInfo)
+	updatedStats := copyExecutionStats(state0.ExecutionStats)
+	updatedInfo.NextEventID = int64(5)
+	updatedInfo.LastProcessedEvent = int64(2)
+
+	readLevel := s.GetTransferReadLevel()
+	now := time.Now()
+	tasks := []p.Task{
+		&p.RecordWorkflowStartedVisibilityTask{VisibilityTimestamp: now, TaskID: readLevel + 10001, Version: 111},
+		&p.UpsertWorkflowSearchAttributesVisibilityTask{VisibilityTimestamp: now, TaskID: readLevel + 10002, Version: 222},
+		&p.CloseExecutionVisibilityTask{VisibilityTimestamp: now, TaskID: readLevel + 10003, Version: 333},
+	}
+	err = s.UpdateWorkflowExecutionWithVisibilityTasks(updatedInfo, updatedStats, int64(3), tasks)
+	s.NoError(err)
+
+	visTasks, err := s.GetVisibilityTasks(readLevel, 1, true) // use page size one to force pagination
+	s.NoError(err)
+	s.Equal(len(tasks), len(visTasks))
+	for index := range tasks {
+		s.Equal(domainID, visTasks[index].DomainID)
+		s.Equal(workflowExecution.GetWorkflowId(), visTasks[index].WorkflowID)
+		s.Equal(workflowExecution.GetRunId(), visTasks[index].RunID)
+		s.Equal(tasks[index].GetTaskID(), visTasks[index].TaskID)
+		s.Equal(tasks[index].GetType(), visTasks[index].TaskType)
+		s.Equal(tasks[index].GetVersion(), visTasks[index].Version)
+		s.True(timeComparator(tasks[index].GetVisibilityTimestamp(), visTasks[index].VisibilityTimestamp, TimePrecision))
+	}
+
+	err = s.CompleteVisibilityTask(visTasks[0].TaskID)
+	s.NoError(err)
+	visTasks, err = s.GetVisibilityTasks(readLevel, 100, true)
+	s.NoError(err)
+	s.Equal(2, len(visTasks))
+
+	err = s.RangeCompleteVisibilityTask(readLevel, visTasks[1].TaskID)
+	s.NoError(err)
+	visTasks, err = s.GetVisibilityTasks(readLevel, 100, true)
+	s.NoError(err)
+	s.Empty(visTasks, "expected empty task list.")
+}
+
 // TestTransferTasksRangeComplete test
 func (s *ExecutionManagerSuite) TestTransferTasksRangeComplete() {
 	domainID := "8bfb47be-5b57-4d55-9109-5fb35e20b1d7"
@@ -2132,6 +2418,7 @@ func (s *ExecutionManagerSuite) TestWorkflowMutableStateActivities() {
 		NonRetriableErrors:       []string{"accessDenied", "badRequest"},
 		LastFailureReason:        "some random error",
 		LastWorkerIdentity:       uuid.New(),
+		Paused:                   true,
 	}}
 	err2 := s.UpdateWorkflowExecution(updatedInfo, updatedStats, []int64{int64(4)}, nil, int64(3), nil, activityInfos, nil, nil, nil)
 	s.NoError(err2)
@@ -2176,6 +2463,7 @@ func (s *ExecutionManagerSuite) TestWorkflowMutableStateActivities() {
 	s.Equal(activityInfos[0].NonRetriableErrors, ai.NonRetriableErrors)
 	s.Equal(activityInfos[0].LastFailureReason, ai.LastFailureReason)
 	s.Equal(activityInfos[0].LastWorkerIdentity, ai.LastWorkerIdentity)
+	s.Equal(activityInfos[0].Paused, ai.Paused)
 
 	err2 = s.UpdateWorkflowExecution(updatedInfo, updatedStats, nil, nil, int64(5), nil, nil, []int64{1}, nil, nil)
 	s.NoError(err2)
@@ -2459,6 +2747,75 @@ func (s *ExecutionManagerSuite) TestWorkflowMutableStateSignalRequested() {
 	s.Equal(0, len(state.SignalRequestedIDs))
 }
 
+// TestWorkflowMutableStateUpdateInfo test
+func (s *ExecutionManagerSuite) TestWorkflowMutableStateUpdateInfo() {
+	domainID := uuid.New()
+	runID := uuid.New()
+	workflowExecution := gen.WorkflowExecution{
+		WorkflowId: common.StringPtr("test-workflow-mutable-update-info-test"),
+		RunId:      common.StringPtr(runID),
+	}
+
+	task0, err0 := s.CreateWorkflowExecution(domainID, workflowExecution, "taskList", "wType", 20, 13, nil, 3, 0, 2, nil)
+	s.NoError(err0)
+	s.NotNil(task0, "Expected non empty task identifier.")
+
+	state0, err1 := s.GetWorkflowExecutionInfo(domainID, workflowExecution)
+	s.NoError(err1)
+	info0 := state0.ExecutionInfo
+	s.NotNil(info0, "Valid Workflow info expected.")
+
+	updatedInfo := copyWorkflowExecutionInfo(info0)
+	updatedStats := copyExecutionStats(state0.ExecutionStats)
+	updatedInfo.NextEventID = int64(5)
+	updatedInfo.LastProcessedEvent = int64(2)
+	updateID := uuid.New()
+	input := []byte("test update input")
+	updateInfos := []*p.UpdateInfo{
+		{
+			Version:    123,
+			UpdateID:   updateID,
+			UpdateName: "my update",
+			Input:      input,
+		}}
+	err2 := s.UpdateWorkflowExecutionForUpdates(updatedInfo, updatedStats, int64(3), updateInfos, nil)
+	s.NoError(err2)
+
+	state, err1 := s.GetWorkflowExecutionInfo(domainID, workflowExecution)
+	s.NoError(err1)
+	s.NotNil(state, "expected valid state.")
+	s.Equal(1, len(state.UpdateInfos))
+	ui, ok := state.UpdateInfos[updateID]
+	s.True(ok)
+	s.NotNil(ui)
+	s.Equal(int64(123), ui.Version)
+	s.Equal(updateID, ui.UpdateID)
+	s.Equal("my update", ui.UpdateName)
+	s.Equal(input, ui.Input)
+	s.False(ui.Completed)
+
+	result := []byte("test update result")
+	updateInfos[0].Result = result
+	updateInfos[0].Completed = true
+	err2 = s.UpdateWorkflowExecutionForUpdates(updatedInfo, updatedStats, int64(5), updateInfos, nil)
+	s.NoError(err2)
+
+	state, err1 = s.GetWorkflowExecutionInfo(domainID, workflowExecution)
+	s.NoError(err1)
+	ui, ok = state.UpdateInfos[updateID]
+	s.True(ok)
+	s.Equal(result, ui.Result)
+	s.True(ui.Completed)
+
+	err2 = s.UpdateWorkflowExecutionForUpdates(updatedInfo, updatedStats, int64(5), nil, common.StringPtr(updateID))
+	s.NoError(err2)
+
+	state, err1 = s.GetWorkflowExecutionInfo(domainID, workflowExecution)
+	s.NoError(err1)
+	s.NotNil(state, "expected valid state.")
+	s.Equal(0, len(state.UpdateInfos))
+}
+
 // TestWorkflowMutableStateInfo test
 func (s *ExecutionManagerSuite) TestWorkflowMutableStateInfo() {
 	domainID := "9ed8818b-3090-4160-9f21-c6b70e64d2dd"
@@ -2558,6 +2915,74 @@ func (s *ExecutionManagerSuite) TestContinueAsNew() {
 	s.Equal(newWorkflowExecution.GetRunId(), newRunID)
 }
 
+// TestClosedExecutionIndex test
+func (s *ExecutionManagerSuite) TestClosedExecutionIndex() {
+	domainID := uuid.New()
+	workflowExecution := gen.WorkflowExecution{
+		WorkflowId: common.StringPtr("closed-execution-index-test"),
+		RunId:      common.StringPtr(uuid.New()),
+	}
+
+	task0, err0 := s.CreateWorkflowExecution(domainID, workflowExecution, "queue1", "wType", 20, 13, nil, 3, 0, 2, nil)
+	s.NoError(err0)
+	s.NotNil(task0, "Expected non empty task identifier.")
+
+	info0, err1 := s.GetWorkflowExecutionInfo(domainID, workflowExecution)
+	s.NoError(err1)
+
+	beforeClose := time.Now().Add(-time.Second)
+	updatedInfo1 := copyWorkflowExecutionInfo(info0.ExecutionInfo)
+	updatedStats1 := copyExecutionStats(info0.ExecutionStats)
+	updatedInfo1.NextEventID = int64(6)
+	updatedInfo1.LastProcessedEvent = int64(2)
+	err2 := s.UpdateWorkflowExecutionAndFinish(updatedInfo1, updatedStats1, int64(3))
+	s.NoError(err2)
+	afterClose := time.Now().Add(time.Second)
+
+	findInIndex := func(minCloseTime time.Time) *p.ClosedExecutionIndexInfo {
+		for _, day := range []time.Time{beforeClose, afterClose} {
+			var token []byte
+			for {
+				response, err := s.ExecutionManager.GetClosedExecutionIndex(&p.GetClosedExecutionIndexRequest{
+					Day:           day,
+					MinCloseTime:  minCloseTime,
+					BatchSize:     10,
+					NextPageToken: token,
+				})
+				s.NoError(err)
+				for _, execution := range response.Executions {
+					if execution.RunID == workflowExecution.GetRunId() {
+						return execution
+					}
+				}
+				token = response.NextPageToken
+				if len(token) == 0 {
+					break
+				}
+			}
+		}
+		return nil
+	}
+
+	indexed := findInIndex(time.Time{})
+	s.NotNil(indexed)
+	s.NotNil(findInIndex(beforeClose))
+	s.Nil(findInIndex(afterClose))
+	s.Equal(domainID, indexed.DomainID)
+	s.Equal(workflowExecution.GetWorkflowId(), indexed.WorkflowID)
+	s.True(indexed.CloseTime.After(beforeClose))
+	s.True(indexed.CloseTime.Before(afterClose))
+
+	err3 := s.ExecutionManager.DeleteClosedExecutionIndex(&p.DeleteClosedExecutionIndexRequest{
+		DomainID:   indexed.DomainID,
+		WorkflowID: indexed.WorkflowID,
+		RunID:      indexed.RunID,
+		CloseTime:  indexed.CloseTime,
+	})
+	s.NoError(err3)
+	s.Nil(findInIndex(time.Time{}))
+}
+
 // TestReplicationTransferTaskTasks test
 func (s *ExecutionManagerSuite) TestReplicationTransferTaskTasks() {
 	domainID := "2466d7de-6602-4ad8-b939-fb8f8c36c711"
@@ -286,6 +286,61 @@ func (s *HistoryV2PersistenceSuite) TestReadBranchByPagination() {
 	s.IsType(&gen.EntityNotExistsError{}, err)
 }
 
+// TestReadRawBranch test
+func (s *HistoryV2PersistenceSuite) TestReadRawBranch() {
+	treeID := uuid.New()
+	bi, err := s.newHistoryBranch(treeID)
+	s.Nil(err)
+
+	events := s.genRandomEvents([]int64{1, 2, 3}, 1)
+	err = s.appendNewBranchAndFirstNode(bi, events, 1, "branchInfo")
+	s.Nil(err)
+	events = s.genRandomEvents([]int64{4, 5, 6, 7}, 1)
+	err = s.appendNewNode(bi, events, 1)
+	s.Nil(err)
+	// stale event batches
+	events = s.genRandomEvents([]int64{5, 6}, 0)
+	err = s.appendNewNode(bi, events, 2)
+	s.Nil(err)
+	events = s.genRandomEvents([]int64{6, 7}, 1)
+	err = s.appendNewNode(bi, events, 3)
+	s.Nil(err)
+	events = s.genRandomEvents([]int64{8}, 1)
+	err = s.appendNewNode(bi, events, 1)
+	s.Nil(err)
+
+	serializer := p.NewPayloadSerializer()
+	historyR := make([]*workflow.HistoryEvent, 0)
+	req := &p.ReadHistoryBranchRequest{
+		BranchToken:   bi,
+		MinEventID:    1,
+		MaxEventID:    9,
+		PageSize:      2,
+		NextPageToken: nil,
+		ShardID:       common.IntPtr(s.ShardInfo.ShardID),
+	}
+	for {
+		resp, err := s.HistoryV2Mgr.ReadRawHistoryBranch(req)
+		s.Nil(err)
+		for _, blob := range resp.HistoryEventBlobs {
+			es, err := serializer.DeserializeBatchEvents(blob)
+			s.Nil(err)
+			historyR = append(historyR, es...)
+		}
+		req.NextPageToken = resp.NextPageToken
+		if len(req.NextPageToken) == 0 {
+			break
+		}
+	}
+
+	historyW := s.read(bi, 1, 9)
+	s.Equal(8, len(historyR))
+	s.True((&workflow.History{Events: historyW}).Equals(&workflow.History{Events: historyR}))
+
+	err = s.deleteHistoryBranch(bi)
+	s.Nil(err)
+}
+
 //TestConcurrentlyCreateAndAppendBranches test
 func (s *HistoryV2PersistenceSuite) TestConcurrentlyCreateAndAppendBranches() {
 	treeID := uuid.New()
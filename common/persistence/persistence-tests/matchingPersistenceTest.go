@@ -66,6 +66,11 @@ func (s *MatchingPersistenceSuite) SetupTest() {
 	s.Assertions = require.New(s.T())
 }
 
+// TestPing test
+func (s *MatchingPersistenceSuite) TestPing() {
+	s.NoError(s.TaskMgr.Ping())
+}
+
 // TestCreateTask test
 func (s *MatchingPersistenceSuite) TestCreateTask() {
 	domainID := "11adbd1b-f164-4ea7-b2f3-2e857a5048f1"
@@ -965,6 +965,11 @@ ListLoop:
 	}
 }
 
+// TestPing test
+func (m *MetadataPersistenceSuiteV2) TestPing() {
+	m.NoError(m.MetadataManagerV2.Ping())
+}
+
 // CreateDomain helper method
 func (m *MetadataPersistenceSuiteV2) CreateDomain(info *p.DomainInfo, config *p.DomainConfig,
 	replicationConfig *p.DomainReplicationConfig, isGlobaldomain bool, configVersion int64, failoverVersion int64) (*p.CreateDomainResponse, error) {
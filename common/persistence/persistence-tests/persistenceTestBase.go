@@ -38,6 +38,7 @@ import (
 	"github.com/uber/cadence/common/persistence/cassandra"
 	pfactory "github.com/uber/cadence/common/persistence/persistence-factory"
 	"github.com/uber/cadence/common/persistence/sql"
+	"github.com/uber/cadence/common/persistence/sql/storage/kv/memory"
 	"github.com/uber/cadence/common/service/config"
 )
 
@@ -49,11 +50,14 @@ type (
 
 	// TestBaseOptions options to configure workflow test base.
 	TestBaseOptions struct {
-		DBName          string
-		DBPort          int              `yaml:"-"`
-		StoreType       string           `yaml:"-"`
-		SchemaDir       string           `yaml:"-"`
-		ClusterMetadata cluster.Metadata `yaml:"-"`
+		DBName                       string
+		DBPort                       int              `yaml:"-"`
+		StoreType                    string           `yaml:"-"`
+		SchemaDir                    string           `yaml:"-"`
+		ClusterMetadata              cluster.Metadata `yaml:"-"`
+		TransferTaskBuckets          int              `yaml:"-"`
+		OpenExecutionBuckets         int              `yaml:"-"`
+		ReadClosedExecutionsByFilter bool             `yaml:"-"`
 	}
 
 	// TestBase wraps the base setup needed to create workflows over persistence layer.
@@ -69,6 +73,7 @@ type (
 		MetadataManagerV2     p.MetadataManager
 		MetadataProxy         p.MetadataManager
 		VisibilityMgr         p.VisibilityManager
+		ScheduleMgr           p.ScheduleManager
 		ShardInfo             *p.ShardInfo
 		TaskIDGenerator       TransferTaskIDGenerator
 		ClusterMetadata       cluster.Metadata
@@ -107,6 +112,9 @@ func NewTestBaseWithCassandra(options *TestBaseOptions) TestBase {
 		options.DBName = "test_" + GenerateRandomDBName(10)
 	}
 	testCluster := cassandra.NewTestCluster(options.DBName, options.DBPort, options.SchemaDir)
+	testCluster.SetTransferTaskBuckets(options.TransferTaskBuckets)
+	testCluster.SetOpenExecutionBuckets(options.OpenExecutionBuckets)
+	testCluster.SetReadClosedExecutionsByFilter(options.ReadClosedExecutionsByFilter)
 	return newTestBase(options, testCluster)
 }
 
@@ -119,6 +127,14 @@ func NewTestBaseWithSQL(options *TestBaseOptions) TestBase {
 	return newTestBase(options, testCluster)
 }
 
+// NewTestBaseWithMemory returns a new persistence test base backed by the embedded store
+func NewTestBaseWithMemory(options *TestBaseOptions) TestBase {
+	if options.DBName == "" {
+		options.DBName = GenerateRandomDBName(10)
+	}
+	return newTestBase(options, memory.NewTestCluster(options.DBName))
+}
+
 // NewTestBase returns a persistence test base backed by either cassandra or sql
 func NewTestBase(options *TestBaseOptions) TestBase {
 	switch options.StoreType {
@@ -197,6 +213,9 @@ func (s *TestBase) Setup() {
 	s.ShardMgr, err = factory.NewShardManager()
 	s.fatalOnError("NewShardManager", err)
 
+	s.ScheduleMgr, err = factory.NewScheduleManager()
+	s.fatalOnError("NewScheduleManager", err)
+
 	s.ExecutionMgrFactory = factory
 	s.ExecutionManager, err = factory.NewExecutionManager(shardID)
 	s.fatalOnError("NewExecutionManager", err)
@@ -708,6 +727,10 @@ func (s *TestBase) UpdateWorkflowExecutionWithReplication(updatedInfo *p.Workflo
 	upsertChildInfos []*p.ChildExecutionInfo, deleteChildInfo *int64, upsertCancelInfos []*p.RequestCancelInfo,
 	deleteCancelInfo *int64, upsertSignalInfos []*p.SignalInfo, deleteSignalInfo *int64, upsertSignalRequestedIDs []string,
 	deleteSignalRequestedID string) error {
+	var deleteSignalRequestedIDs []string
+	if deleteSignalRequestedID != "" {
+		deleteSignalRequestedIDs = []string{deleteSignalRequestedID}
+	}
 	var transferTasks []p.Task
 	var replicationTasks []p.Task
 	for _, task := range txTasks {
@@ -757,7 +780,7 @@ func (s *TestBase) UpdateWorkflowExecutionWithReplication(updatedInfo *p.Workflo
 			UpsertSignalInfos:         upsertSignalInfos,
 			DeleteSignalInfo:          deleteSignalInfo,
 			UpsertSignalRequestedIDs:  upsertSignalRequestedIDs,
-			DeleteSignalRequestedID:   deleteSignalRequestedID,
+			DeleteSignalRequestedIDs:  deleteSignalRequestedIDs,
 		},
 		Encoding: pickRandomEncoding(),
 	})
@@ -781,6 +804,22 @@ func (s *TestBase) UpdateWorkflowExecutionWithTransferTasks(
 	return err
 }
 
+// UpdateWorkflowExecutionWithVisibilityTasks is a utility method to update workflow execution
+func (s *TestBase) UpdateWorkflowExecutionWithVisibilityTasks(
+	updatedInfo *p.WorkflowExecutionInfo, updatedStats *p.ExecutionStats, condition int64, visibilityTasks []p.Task) error {
+	_, err := s.ExecutionManager.UpdateWorkflowExecution(&p.UpdateWorkflowExecutionRequest{
+		UpdateWorkflowMutation: p.WorkflowMutation{
+			ExecutionInfo:   updatedInfo,
+			ExecutionStats:  updatedStats,
+			VisibilityTasks: visibilityTasks,
+			Condition:       condition,
+		},
+		RangeID:  s.ShardInfo.RangeID,
+		Encoding: pickRandomEncoding(),
+	})
+	return err
+}
+
 // UpdateWorkflowExecutionForChildExecutionsInitiated is a utility method to update workflow execution
 func (s *TestBase) UpdateWorkflowExecutionForChildExecutionsInitiated(
 	updatedInfo *p.WorkflowExecutionInfo, updatedStats *p.ExecutionStats, condition int64, transferTasks []p.Task, childInfos []*p.ChildExecutionInfo) error {
@@ -834,6 +873,24 @@ func (s *TestBase) UpdateWorkflowExecutionForSignal(
 	return err
 }
 
+// UpdateWorkflowExecutionForUpdates is a utility method to update workflow execution
+func (s *TestBase) UpdateWorkflowExecutionForUpdates(
+	updatedInfo *p.WorkflowExecutionInfo, updatedStats *p.ExecutionStats, condition int64,
+	upsertUpdateInfos []*p.UpdateInfo, deleteUpdateInfo *string) error {
+	_, err := s.ExecutionManager.UpdateWorkflowExecution(&p.UpdateWorkflowExecutionRequest{
+		UpdateWorkflowMutation: p.WorkflowMutation{
+			ExecutionInfo:     updatedInfo,
+			ExecutionStats:    updatedStats,
+			Condition:         condition,
+			UpsertUpdateInfos: upsertUpdateInfos,
+			DeleteUpdateInfo:  deleteUpdateInfo,
+		},
+		RangeID:  s.ShardInfo.RangeID,
+		Encoding: pickRandomEncoding(),
+	})
+	return err
+}
+
 // UpdateWorkflowExecutionForBufferEvents is a utility method to update workflow execution
 func (s *TestBase) UpdateWorkflowExecutionForBufferEvents(
 	updatedInfo *p.WorkflowExecutionInfo, updatedStats *p.ExecutionStats, rState *p.ReplicationState, condition int64,
@@ -993,12 +1050,17 @@ func (s *TestBase) DeleteWorkflowExecution(info *p.WorkflowExecutionInfo) error
 }
 
 // DeleteCurrentWorkflowExecution is a utility method to delete the workflow current execution
-func (s *TestBase) DeleteCurrentWorkflowExecution(info *p.WorkflowExecutionInfo) error {
-	return s.ExecutionManager.DeleteCurrentWorkflowExecution(&p.DeleteCurrentWorkflowExecutionRequest{
+func (s *TestBase) DeleteCurrentWorkflowExecution(info *p.WorkflowExecutionInfo, force bool) (int, error) {
+	response, err := s.ExecutionManager.DeleteCurrentWorkflowExecution(&p.DeleteCurrentWorkflowExecutionRequest{
 		DomainID:   info.DomainID,
 		WorkflowID: info.WorkflowID,
 		RunID:      info.RunID,
+		Force:      force,
 	})
+	if err != nil {
+		return 0, err
+	}
+	return response.Result, nil
 }
 
 // GetTransferTasks is a utility method to get tasks from transfer task queue
@@ -1063,6 +1125,33 @@ Loop:
 	return result, nil
 }
 
+// GetVisibilityTasks is a utility method to get tasks above the read level from visibility task queue
+func (s *TestBase) GetVisibilityTasks(readLevel int64, batchSize int, getAll bool) ([]*p.VisibilityTaskInfo, error) {
+	result := []*p.VisibilityTaskInfo{}
+	var token []byte
+
+Loop:
+	for {
+		response, err := s.ExecutionManager.GetVisibilityTasks(&p.GetVisibilityTasksRequest{
+			ReadLevel:     readLevel,
+			MaxReadLevel:  int64(math.MaxInt64),
+			BatchSize:     batchSize,
+			NextPageToken: token,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		token = response.NextPageToken
+		result = append(result, response.Tasks...)
+		if len(token) == 0 || !getAll {
+			break Loop
+		}
+	}
+
+	return result, nil
+}
+
 // CompleteTransferTask is a utility method to complete a transfer task
 func (s *TestBase) CompleteTransferTask(taskID int64) error {
 
@@ -1087,6 +1176,29 @@ func (s *TestBase) CompleteReplicationTask(taskID int64) error {
 	})
 }
 
+// RangeCompleteReplicationTask is a utility method to complete a range of replication tasks
+func (s *TestBase) RangeCompleteReplicationTask(inclusiveEndTaskID int64) error {
+	return s.ExecutionManager.RangeCompleteReplicationTask(&p.RangeCompleteReplicationTaskRequest{
+		InclusiveEndTaskID: inclusiveEndTaskID,
+	})
+}
+
+// CompleteVisibilityTask is a utility method to complete a visibility task
+func (s *TestBase) CompleteVisibilityTask(taskID int64) error {
+
+	return s.ExecutionManager.CompleteVisibilityTask(&p.CompleteVisibilityTaskRequest{
+		TaskID: taskID,
+	})
+}
+
+// RangeCompleteVisibilityTask is a utility method to complete a range of visibility tasks
+func (s *TestBase) RangeCompleteVisibilityTask(exclusiveBeginTaskID int64, inclusiveEndTaskID int64) error {
+	return s.ExecutionManager.RangeCompleteVisibilityTask(&p.RangeCompleteVisibilityTaskRequest{
+		ExclusiveBeginTaskID: exclusiveBeginTaskID,
+		InclusiveEndTaskID:   inclusiveEndTaskID,
+	})
+}
+
 // GetTimerIndexTasks is a utility method to get tasks from transfer task queue
 func (s *TestBase) GetTimerIndexTasks(batchSize int, getAll bool) ([]*p.TimerTaskInfo, error) {
 	result := []*p.TimerTaskInfo{}
@@ -121,6 +121,8 @@ func (s *ShardPersistenceSuite) TestUpdateShard() {
 	updatedInfo.StolenSinceRenew = updatedStolenSinceRenew
 	updatedTimerAckLevel := time.Now()
 	updatedInfo.TimerAckLevel = updatedTimerAckLevel
+	updatedDomainOpenExecutionCounts := map[string]int64{"domain-1": 3, "domain-2": 1}
+	updatedInfo.DomainOpenExecutionCounts = updatedDomainOpenExecutionCounts
 	err2 := s.UpdateShard(updatedInfo, shardInfo.RangeID)
 	s.Nil(err2)
 
@@ -133,6 +135,7 @@ func (s *ShardPersistenceSuite) TestUpdateShard() {
 	s.Equal(updatedReplicationAckLevel, info1.ReplicationAckLevel)
 	s.Equal(updatedStolenSinceRenew, info1.StolenSinceRenew)
 	s.EqualTimes(updatedTimerAckLevel, info1.TimerAckLevel)
+	s.Equal(updatedDomainOpenExecutionCounts, info1.DomainOpenExecutionCounts)
 
 	failedUpdateInfo := copyShardInfo(shardInfo)
 	failedUpdateInfo.Owner = "failed_owner"
@@ -163,5 +166,6 @@ func copyShardInfo(sourceInfo *p.ShardInfo) *p.ShardInfo {
 		ReplicationAckLevel: sourceInfo.ReplicationAckLevel,
 		StolenSinceRenew:    sourceInfo.StolenSinceRenew,
 		TimerAckLevel:       sourceInfo.TimerAckLevel,
+		TransferTaskBuckets: sourceInfo.TransferTaskBuckets,
 	}
 }
@@ -0,0 +1,1075 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package persistence
+
+import (
+	"sync"
+
+	"github.com/pborman/uuid"
+	"github.com/uber-go/tally"
+	"github.com/uber/cadence/common/log"
+	"github.com/uber/cadence/common/log/tag"
+	"github.com/uber/cadence/common/metrics"
+)
+
+type (
+	// dualWriteClient holds what the dual write clients share. They migrate a live cluster from one
+	// datastore to another: the primary stays the source of truth and serves the reads, every write is
+	// applied to the secondary after the primary succeeded, and secondary failures never fail the request.
+	// A secondary that missed a write, or that was backfilled later, rejects the next conditional write of
+	// the entity, which is then reconciled by copying the entity from the primary. When verifyReads is set
+	// the reads are also served by the secondary, concurrently, and the responses are compared
+	dualWriteClient struct {
+		verifyReads  bool
+		metricClient metrics.Client
+		logger       log.Logger
+	}
+
+	shardDualWritePersistenceClient struct {
+		dualWriteClient
+		primary   ShardManager
+		secondary ShardManager
+	}
+
+	clusterMembershipDualWritePersistenceClient struct {
+		dualWriteClient
+		primary   ClusterMembershipManager
+		secondary ClusterMembershipManager
+	}
+
+	auditDualWritePersistenceClient struct {
+		dualWriteClient
+		primary   AuditManager
+		secondary AuditManager
+	}
+
+	scheduleDualWritePersistenceClient struct {
+		dualWriteClient
+		primary   ScheduleManager
+		secondary ScheduleManager
+	}
+
+	workflowExecutionDualWritePersistenceClient struct {
+		dualWriteClient
+		primary   ExecutionManager
+		secondary ExecutionManager
+	}
+
+	taskDualWritePersistenceClient struct {
+		dualWriteClient
+		primary   TaskManager
+		secondary TaskManager
+
+		sync.Mutex
+		// rangeIDs are the range IDs of the task lists leased in the secondary, the leases are fenced
+		// by the primary so the secondary is leased unconditionally and its writes use its own range ID
+		rangeIDs map[dualWriteTaskListKey]int64
+	}
+
+	dualWriteTaskListKey struct {
+		domainID string
+		name     string
+		taskType int
+	}
+
+	historyDualWritePersistenceClient struct {
+		dualWriteClient
+		primary   HistoryManager
+		secondary HistoryManager
+	}
+
+	historyV2DualWritePersistenceClient struct {
+		dualWriteClient
+		primary   HistoryV2Manager
+		secondary HistoryV2Manager
+	}
+
+	metadataDualWritePersistenceClient struct {
+		dualWriteClient
+		primary   MetadataManager
+		secondary MetadataManager
+	}
+)
+
+var _ ShardManager = (*shardDualWritePersistenceClient)(nil)
+var _ ClusterMembershipManager = (*clusterMembershipDualWritePersistenceClient)(nil)
+var _ AuditManager = (*auditDualWritePersistenceClient)(nil)
+var _ ScheduleManager = (*scheduleDualWritePersistenceClient)(nil)
+var _ ExecutionManager = (*workflowExecutionDualWritePersistenceClient)(nil)
+var _ TaskManager = (*taskDualWritePersistenceClient)(nil)
+var _ HistoryManager = (*historyDualWritePersistenceClient)(nil)
+var _ HistoryV2Manager = (*historyV2DualWritePersistenceClient)(nil)
+var _ MetadataManager = (*metadataDualWritePersistenceClient)(nil)
+
+// NewShardPersistenceDualWriteClient creates a client to manage shards
+func NewShardPersistenceDualWriteClient(primary ShardManager, secondary ShardManager, verifyReads bool, metricClient metrics.Client, logger log.Logger) ShardManager {
+	return &shardDualWritePersistenceClient{
+		dualWriteClient: newDualWriteClient(verifyReads, metricClient, logger),
+		primary:         primary,
+		secondary:       secondary,
+	}
+}
+
+// NewClusterMembershipPersistenceDualWriteClient creates a client to manage cluster membership
+func NewClusterMembershipPersistenceDualWriteClient(primary ClusterMembershipManager, secondary ClusterMembershipManager, verifyReads bool, metricClient metrics.Client, logger log.Logger) ClusterMembershipManager {
+	return &clusterMembershipDualWritePersistenceClient{
+		dualWriteClient: newDualWriteClient(verifyReads, metricClient, logger),
+		primary:         primary,
+		secondary:       secondary,
+	}
+}
+
+// NewAuditPersistenceDualWriteClient creates a client to manage the audit log
+func NewAuditPersistenceDualWriteClient(primary AuditManager, secondary AuditManager, verifyReads bool, metricClient metrics.Client, logger log.Logger) AuditManager {
+	return &auditDualWritePersistenceClient{
+		dualWriteClient: newDualWriteClient(verifyReads, metricClient, logger),
+		primary:         primary,
+		secondary:       secondary,
+	}
+}
+
+// NewSchedulePersistenceDualWriteClient creates a client to manage schedules
+func NewSchedulePersistenceDualWriteClient(primary ScheduleManager, secondary ScheduleManager, verifyReads bool, metricClient metrics.Client, logger log.Logger) ScheduleManager {
+	return &scheduleDualWritePersistenceClient{
+		dualWriteClient: newDualWriteClient(verifyReads, metricClient, logger),
+		primary:         primary,
+		secondary:       secondary,
+	}
+}
+
+// NewWorkflowExecutionPersistenceDualWriteClient creates a client to manage executions
+func NewWorkflowExecutionPersistenceDualWriteClient(primary ExecutionManager, secondary ExecutionManager, verifyReads bool, metricClient metrics.Client, logger log.Logger) ExecutionManager {
+	return &workflowExecutionDualWritePersistenceClient{
+		dualWriteClient: newDualWriteClient(verifyReads, metricClient, logger),
+		primary:         primary,
+		secondary:       secondary,
+	}
+}
+
+// NewTaskPersistenceDualWriteClient creates a client to manage tasks
+func NewTaskPersistenceDualWriteClient(primary TaskManager, secondary TaskManager, verifyReads bool, metricClient metrics.Client, logger log.Logger) TaskManager {
+	return &taskDualWritePersistenceClient{
+		dualWriteClient: newDualWriteClient(verifyReads, metricClient, logger),
+		primary:         primary,
+		secondary:       secondary,
+		rangeIDs:        make(map[dualWriteTaskListKey]int64),
+	}
+}
+
+// NewHistoryPersistenceDualWriteClient creates a client to manage history events
+func NewHistoryPersistenceDualWriteClient(primary HistoryManager, secondary HistoryManager, verifyReads bool, metricClient metrics.Client, logger log.Logger) HistoryManager {
+	return &historyDualWritePersistenceClient{
+		dualWriteClient: newDualWriteClient(verifyReads, metricClient, logger),
+		primary:         primary,
+		secondary:       secondary,
+	}
+}
+
+// NewHistoryV2PersistenceDualWriteClient creates a client to manage history branches
+func NewHistoryV2PersistenceDualWriteClient(primary HistoryV2Manager, secondary HistoryV2Manager, verifyReads bool, metricClient metrics.Client, logger log.Logger) HistoryV2Manager {
+	return &historyV2DualWritePersistenceClient{
+		dualWriteClient: newDualWriteClient(verifyReads, metricClient, logger),
+		primary:         primary,
+		secondary:       secondary,
+	}
+}
+
+// NewMetadataPersistenceDualWriteClient creates a client to manage domains
+func NewMetadataPersistenceDualWriteClient(primary MetadataManager, secondary MetadataManager, verifyReads bool, metricClient metrics.Client, logger log.Logger) MetadataManager {
+	return &metadataDualWritePersistenceClient{
+		dualWriteClient: newDualWriteClient(verifyReads, metricClient, logger),
+		primary:         primary,
+		secondary:       secondary,
+	}
+}
+
+func newDualWriteClient(verifyReads bool, metricClient metrics.Client, logger log.Logger) dualWriteClient {
+	if metricClient == nil {
+		metricClient = metrics.NewClient(tally.NoopScope, metrics.Common)
+	}
+	return dualWriteClient{
+		verifyReads:  verifyReads,
+		metricClient: metricClient,
+		logger:       logger,
+	}
+}
+
+// write applies a write to the primary, then to the secondary once the primary succeeded, and returns
+// the errors of the primary and of the secondary. The secondary is not written when the primary fails
+func (c *dualWriteClient) write(primary func() error, secondary func() error) (error, error) {
+	if err := primary(); err != nil {
+		return err, nil
+	}
+	return nil, secondary()
+}
+
+// read reads from the primary, and concurrently from the secondary when the reads are verified. The
+// stores may fill in the request they are given, so the secondary must be given its own copy
+func (c *dualWriteClient) read(primary func() error, secondary func() error) (error, error) {
+	if !c.verifyReads {
+		return primary(), nil
+	}
+	secondaryErrCh := make(chan error, 1)
+	go func() {
+		secondaryErrCh <- secondary()
+	}()
+	err := primary()
+	return err, <-secondaryErrCh
+}
+
+func (c *dualWriteClient) writeSecondary(scope int, err error) {
+	if err == nil {
+		return
+	}
+	c.metricClient.IncCounter(scope, metrics.PersistenceDualWriteSecondaryFailures)
+	c.logger.Warn("Dual write to secondary store failed.", tag.Error(err), tag.MetricScope(scope))
+}
+
+func (c *dualWriteClient) verifyRead(scope int, primary interface{}, secondary interface{}, secondaryErr error) {
+	if secondaryErr != nil {
+		c.metricClient.IncCounter(scope, metrics.PersistenceDualWriteMismatches)
+		c.logger.Warn("Dual write verification read from secondary store failed.", tag.Error(secondaryErr), tag.MetricScope(scope))
+		return
+	}
+	// the normalized fields are comparable values, the stores differ in the precision of
+	// timestamps and in how empty collections are returned so the raw responses are not compared
+	if primary != secondary {
+		c.metricClient.IncCounter(scope, metrics.PersistenceDualWriteMismatches)
+		c.logger.Warn("Dual write verification found secondary store out of sync.", tag.MetricScope(scope))
+	}
+}
+
+func (p *shardDualWritePersistenceClient) GetName() string {
+	return p.primary.GetName()
+}
+
+func (p *shardDualWritePersistenceClient) CreateShard(request *CreateShardRequest) error {
+	err, secondaryErr := p.write(
+		func() error { return p.primary.CreateShard(request) },
+		func() error { return p.secondary.CreateShard(request) },
+	)
+	if err == nil && isDualWriteConflict(secondaryErr) {
+		secondaryErr = p.reconcileShard(request.ShardInfo)
+	}
+	p.writeSecondary(metrics.PersistenceCreateShardScope, secondaryErr)
+	return err
+}
+
+func (p *shardDualWritePersistenceClient) GetShard(request *GetShardRequest) (*GetShardResponse, error) {
+	secondaryRequest := *request
+	var response, secondaryResponse *GetShardResponse
+	err, secondaryErr := p.read(
+		func() (err error) {
+			response, err = p.primary.GetShard(request)
+			return err
+		},
+		func() (err error) {
+			secondaryResponse, err = p.secondary.GetShard(&secondaryRequest)
+			return err
+		},
+	)
+	if err == nil && p.verifyReads {
+		p.verifyRead(metrics.PersistenceGetShardScope, normalizeGetShardResponse(response), normalizeGetShardResponse(secondaryResponse), secondaryErr)
+	}
+	return response, err
+}
+
+func (p *shardDualWritePersistenceClient) UpdateShard(request *UpdateShardRequest) error {
+	err, secondaryErr := p.write(
+		func() error { return p.primary.UpdateShard(request) },
+		func() error { return p.secondary.UpdateShard(request) },
+	)
+	if err == nil && isDualWriteConflict(secondaryErr) {
+		secondaryErr = p.reconcileShard(request.ShardInfo)
+	}
+	p.writeSecondary(metrics.PersistenceUpdateShardScope, secondaryErr)
+	return err
+}
+
+func (p *shardDualWritePersistenceClient) Close() {
+	p.primary.Close()
+	p.secondary.Close()
+}
+
+func (p *clusterMembershipDualWritePersistenceClient) GetName() string {
+	return p.primary.GetName()
+}
+
+func (p *clusterMembershipDualWritePersistenceClient) UpsertClusterMembership(request *UpsertClusterMembershipRequest) error {
+	err, secondaryErr := p.write(
+		func() error { return p.primary.UpsertClusterMembership(request) },
+		func() error { return p.secondary.UpsertClusterMembership(request) },
+	)
+	p.writeSecondary(metrics.PersistenceUpsertClusterMembershipScope, secondaryErr)
+	return err
+}
+
+func (p *clusterMembershipDualWritePersistenceClient) GetClusterMembers(request *GetClusterMembersRequest) (*GetClusterMembersResponse, error) {
+	return p.primary.GetClusterMembers(request)
+}
+
+func (p *clusterMembershipDualWritePersistenceClient) PruneClusterMembership(request *PruneClusterMembershipRequest) error {
+	err, secondaryErr := p.write(
+		func() error { return p.primary.PruneClusterMembership(request) },
+		func() error { return p.secondary.PruneClusterMembership(request) },
+	)
+	p.writeSecondary(metrics.PersistencePruneClusterMembershipScope, secondaryErr)
+	return err
+}
+
+func (p *clusterMembershipDualWritePersistenceClient) Close() {
+	p.primary.Close()
+	p.secondary.Close()
+}
+
+func (p *auditDualWritePersistenceClient) GetName() string {
+	return p.primary.GetName()
+}
+
+func (p *auditDualWritePersistenceClient) RecordAuditEntry(request *RecordAuditEntryRequest) error {
+	err, secondaryErr := p.write(
+		func() error { return p.primary.RecordAuditEntry(request) },
+		func() error { return p.secondary.RecordAuditEntry(request) },
+	)
+	p.writeSecondary(metrics.PersistenceRecordAuditEntryScope, secondaryErr)
+	return err
+}
+
+func (p *auditDualWritePersistenceClient) Close() {
+	p.primary.Close()
+	p.secondary.Close()
+}
+
+func (p *scheduleDualWritePersistenceClient) GetName() string {
+	return p.primary.GetName()
+}
+
+func (p *scheduleDualWritePersistenceClient) CreateSchedule(request *CreateScheduleRequest) error {
+	err, secondaryErr := p.write(
+		func() error { return p.primary.CreateSchedule(request) },
+		func() error { return p.secondary.CreateSchedule(request) },
+	)
+	p.writeSecondary(metrics.PersistenceCreateScheduleScope, secondaryErr)
+	return err
+}
+
+func (p *scheduleDualWritePersistenceClient) GetSchedule(request *GetScheduleRequest) (*GetScheduleResponse, error) {
+	secondaryRequest := *request
+	var response, secondaryResponse *GetScheduleResponse
+	err, secondaryErr := p.read(
+		func() (err error) {
+			response, err = p.primary.GetSchedule(request)
+			return err
+		},
+		func() (err error) {
+			secondaryResponse, err = p.secondary.GetSchedule(&secondaryRequest)
+			return err
+		},
+	)
+	if err == nil && p.verifyReads {
+		p.verifyRead(metrics.PersistenceGetScheduleScope, normalizeGetScheduleResponse(response), normalizeGetScheduleResponse(secondaryResponse), secondaryErr)
+	}
+	return response, err
+}
+
+func (p *scheduleDualWritePersistenceClient) UpdateSchedule(request *UpdateScheduleRequest) error {
+	err, secondaryErr := p.write(
+		func() error { return p.primary.UpdateSchedule(request) },
+		func() error { return p.secondary.UpdateSchedule(request) },
+	)
+	p.writeSecondary(metrics.PersistenceUpdateScheduleScope, secondaryErr)
+	return err
+}
+
+func (p *scheduleDualWritePersistenceClient) DeleteSchedule(request *DeleteScheduleRequest) error {
+	err, secondaryErr := p.write(
+		func() error { return p.primary.DeleteSchedule(request) },
+		func() error { return p.secondary.DeleteSchedule(request) },
+	)
+	p.writeSecondary(metrics.PersistenceDeleteScheduleScope, secondaryErr)
+	return err
+}
+
+func (p *scheduleDualWritePersistenceClient) ListSchedules(request *ListSchedulesRequest) (*ListSchedulesResponse, error) {
+	return p.primary.ListSchedules(request)
+}
+
+func (p *scheduleDualWritePersistenceClient) Close() {
+	p.primary.Close()
+	p.secondary.Close()
+}
+
+func (p *workflowExecutionDualWritePersistenceClient) GetName() string {
+	return p.primary.GetName()
+}
+
+func (p *workflowExecutionDualWritePersistenceClient) GetShardID() int {
+	return p.primary.GetShardID()
+}
+
+func (p *workflowExecutionDualWritePersistenceClient) Ping() error {
+	return p.primary.Ping()
+}
+
+func (p *workflowExecutionDualWritePersistenceClient) CreateWorkflowExecution(request *CreateWorkflowExecutionRequest) (*CreateWorkflowExecutionResponse, error) {
+	var response *CreateWorkflowExecutionResponse
+	err, secondaryErr := p.write(
+		func() (err error) {
+			response, err = p.primary.CreateWorkflowExecution(request)
+			return err
+		},
+		func() error {
+			_, err := p.secondary.CreateWorkflowExecution(request)
+			return err
+		},
+	)
+	p.writeSecondary(metrics.PersistenceCreateWorkflowExecutionScope, secondaryErr)
+	return response, err
+}
+
+func (p *workflowExecutionDualWritePersistenceClient) GetWorkflowExecution(request *GetWorkflowExecutionRequest) (*GetWorkflowExecutionResponse, error) {
+	secondaryRequest := *request
+	var response, secondaryResponse *GetWorkflowExecutionResponse
+	err, secondaryErr := p.read(
+		func() (err error) {
+			response, err = p.primary.GetWorkflowExecution(request)
+			return err
+		},
+		func() (err error) {
+			secondaryResponse, err = p.secondary.GetWorkflowExecution(&secondaryRequest)
+			return err
+		},
+	)
+	if err == nil && p.verifyReads {
+		p.verifyRead(metrics.PersistenceGetWorkflowExecutionScope, normalizeGetWorkflowExecutionResponse(response), normalizeGetWorkflowExecutionResponse(secondaryResponse), secondaryErr)
+	}
+	return response, err
+}
+
+func (p *workflowExecutionDualWritePersistenceClient) UpdateWorkflowExecution(request *UpdateWorkflowExecutionRequest) (*UpdateWorkflowExecutionResponse, error) {
+	var response *UpdateWorkflowExecutionResponse
+	err, secondaryErr := p.write(
+		func() (err error) {
+			response, err = p.primary.UpdateWorkflowExecution(request)
+			return err
+		},
+		func() error {
+			_, err := p.secondary.UpdateWorkflowExecution(request)
+			return err
+		},
+	)
+	if err == nil && isDualWriteConflict(secondaryErr) {
+		info := request.UpdateWorkflowMutation.ExecutionInfo
+		secondaryErr = p.reconcileExecution(request.RangeID, info.DomainID, info.WorkflowID, info.RunID)
+		if secondaryErr == nil && request.NewWorkflowSnapshot != nil {
+			info = request.NewWorkflowSnapshot.ExecutionInfo
+			secondaryErr = p.reconcileExecution(request.RangeID, info.DomainID, info.WorkflowID, info.RunID)
+		}
+	}
+	p.writeSecondary(metrics.PersistenceUpdateWorkflowExecutionScope, secondaryErr)
+	return response, err
+}
+
+func (p *workflowExecutionDualWritePersistenceClient) ResetMutableState(request *ResetMutableStateRequest) error {
+	err, secondaryErr := p.write(
+		func() error { return p.primary.ResetMutableState(request) },
+		func() error { return p.secondary.ResetMutableState(request) },
+	)
+	if err == nil && isDualWriteConflict(secondaryErr) {
+		info := request.ResetWorkflowSnapshot.ExecutionInfo
+		secondaryErr = p.reconcileExecution(request.RangeID, info.DomainID, info.WorkflowID, info.RunID)
+	}
+	p.writeSecondary(metrics.PersistenceResetMutableStateScope, secondaryErr)
+	return err
+}
+
+func (p *workflowExecutionDualWritePersistenceClient) ResetWorkflowExecution(request *ResetWorkflowExecutionRequest) error {
+	err, secondaryErr := p.write(
+		func() error { return p.primary.ResetWorkflowExecution(request) },
+		func() error { return p.secondary.ResetWorkflowExecution(request) },
+	)
+	if err == nil && isDualWriteConflict(secondaryErr) {
+		info := request.NewWorkflowSnapshot.ExecutionInfo
+		secondaryErr = p.reconcileExecution(request.RangeID, info.DomainID, info.WorkflowID, info.RunID)
+	}
+	p.writeSecondary(metrics.PersistenceResetWorkflowExecutionScope, secondaryErr)
+	return err
+}
+
+func (p *workflowExecutionDualWritePersistenceClient) DeleteWorkflowExecution(request *DeleteWorkflowExecutionRequest) error {
+	err, secondaryErr := p.write(
+		func() error { return p.primary.DeleteWorkflowExecution(request) },
+		func() error { return p.secondary.DeleteWorkflowExecution(request) },
+	)
+	p.writeSecondary(metrics.PersistenceDeleteWorkflowExecutionScope, secondaryErr)
+	return err
+}
+
+func (p *workflowExecutionDualWritePersistenceClient) DeleteCurrentWorkflowExecution(request *DeleteCurrentWorkflowExecutionRequest) (*DeleteCurrentWorkflowExecutionResponse, error) {
+	var response *DeleteCurrentWorkflowExecutionResponse
+	err, secondaryErr := p.write(
+		func() (err error) {
+			response, err = p.primary.DeleteCurrentWorkflowExecution(request)
+			return err
+		},
+		func() error {
+			_, err := p.secondary.DeleteCurrentWorkflowExecution(request)
+			return err
+		},
+	)
+	p.writeSecondary(metrics.PersistenceDeleteCurrentWorkflowExecutionScope, secondaryErr)
+	return response, err
+}
+
+func (p *workflowExecutionDualWritePersistenceClient) GetCurrentExecution(request *GetCurrentExecutionRequest) (*GetCurrentExecutionResponse, error) {
+	secondaryRequest := *request
+	var response, secondaryResponse *GetCurrentExecutionResponse
+	err, secondaryErr := p.read(
+		func() (err error) {
+			response, err = p.primary.GetCurrentExecution(request)
+			return err
+		},
+		func() (err error) {
+			secondaryResponse, err = p.secondary.GetCurrentExecution(&secondaryRequest)
+			return err
+		},
+	)
+	if err == nil && p.verifyReads {
+		p.verifyRead(metrics.PersistenceGetCurrentExecutionScope, normalizeGetCurrentExecutionResponse(response), normalizeGetCurrentExecutionResponse(secondaryResponse), secondaryErr)
+	}
+	return response, err
+}
+
+func (p *workflowExecutionDualWritePersistenceClient) ListWorkflowExecutionRuns(request *ListWorkflowExecutionRunsRequest) (*ListWorkflowExecutionRunsResponse, error) {
+	return p.primary.ListWorkflowExecutionRuns(request)
+}
+
+func (p *workflowExecutionDualWritePersistenceClient) ListCurrentExecutions(request *ListCurrentExecutionsRequest) (*ListCurrentExecutionsResponse, error) {
+	return p.primary.ListCurrentExecutions(request)
+}
+
+func (p *workflowExecutionDualWritePersistenceClient) BlockCurrentWorkflowExecution(request *BlockCurrentWorkflowExecutionRequest) error {
+	err, secondaryErr := p.write(
+		func() error { return p.primary.BlockCurrentWorkflowExecution(request) },
+		func() error { return p.secondary.BlockCurrentWorkflowExecution(request) },
+	)
+	p.writeSecondary(metrics.PersistenceBlockCurrentWorkflowExecutionScope, secondaryErr)
+	return err
+}
+
+func (p *workflowExecutionDualWritePersistenceClient) GetTransferTasks(request *GetTransferTasksRequest) (*GetTransferTasksResponse, error) {
+	return p.primary.GetTransferTasks(request)
+}
+
+func (p *workflowExecutionDualWritePersistenceClient) GetReplicationTasks(request *GetReplicationTasksRequest) (*GetReplicationTasksResponse, error) {
+	return p.primary.GetReplicationTasks(request)
+}
+
+func (p *workflowExecutionDualWritePersistenceClient) CompleteTransferTask(request *CompleteTransferTaskRequest) error {
+	err, secondaryErr := p.write(
+		func() error { return p.primary.CompleteTransferTask(request) },
+		func() error { return p.secondary.CompleteTransferTask(request) },
+	)
+	p.writeSecondary(metrics.PersistenceCompleteTransferTaskScope, secondaryErr)
+	return err
+}
+
+func (p *workflowExecutionDualWritePersistenceClient) RangeCompleteTransferTask(request *RangeCompleteTransferTaskRequest) error {
+	err, secondaryErr := p.write(
+		func() error { return p.primary.RangeCompleteTransferTask(request) },
+		func() error { return p.secondary.RangeCompleteTransferTask(request) },
+	)
+	p.writeSecondary(metrics.PersistenceRangeCompleteTransferTaskScope, secondaryErr)
+	return err
+}
+
+func (p *workflowExecutionDualWritePersistenceClient) GetVisibilityTasks(request *GetVisibilityTasksRequest) (*GetVisibilityTasksResponse, error) {
+	return p.primary.GetVisibilityTasks(request)
+}
+
+func (p *workflowExecutionDualWritePersistenceClient) CompleteVisibilityTask(request *CompleteVisibilityTaskRequest) error {
+	err, secondaryErr := p.write(
+		func() error { return p.primary.CompleteVisibilityTask(request) },
+		func() error { return p.secondary.CompleteVisibilityTask(request) },
+	)
+	p.writeSecondary(metrics.PersistenceCompleteVisibilityTaskScope, secondaryErr)
+	return err
+}
+
+func (p *workflowExecutionDualWritePersistenceClient) RangeCompleteVisibilityTask(request *RangeCompleteVisibilityTaskRequest) error {
+	err, secondaryErr := p.write(
+		func() error { return p.primary.RangeCompleteVisibilityTask(request) },
+		func() error { return p.secondary.RangeCompleteVisibilityTask(request) },
+	)
+	p.writeSecondary(metrics.PersistenceRangeCompleteVisibilityTaskScope, secondaryErr)
+	return err
+}
+
+func (p *workflowExecutionDualWritePersistenceClient) CompleteReplicationTask(request *CompleteReplicationTaskRequest) error {
+	err, secondaryErr := p.write(
+		func() error { return p.primary.CompleteReplicationTask(request) },
+		func() error { return p.secondary.CompleteReplicationTask(request) },
+	)
+	p.writeSecondary(metrics.PersistenceCompleteReplicationTaskScope, secondaryErr)
+	return err
+}
+
+func (p *workflowExecutionDualWritePersistenceClient) RangeCompleteReplicationTask(request *RangeCompleteReplicationTaskRequest) error {
+	err, secondaryErr := p.write(
+		func() error { return p.primary.RangeCompleteReplicationTask(request) },
+		func() error { return p.secondary.RangeCompleteReplicationTask(request) },
+	)
+	p.writeSecondary(metrics.PersistenceRangeCompleteReplicationTaskScope, secondaryErr)
+	return err
+}
+
+func (p *workflowExecutionDualWritePersistenceClient) GetTimerIndexTasks(request *GetTimerIndexTasksRequest) (*GetTimerIndexTasksResponse, error) {
+	return p.primary.GetTimerIndexTasks(request)
+}
+
+func (p *workflowExecutionDualWritePersistenceClient) CompleteTimerTask(request *CompleteTimerTaskRequest) error {
+	err, secondaryErr := p.write(
+		func() error { return p.primary.CompleteTimerTask(request) },
+		func() error { return p.secondary.CompleteTimerTask(request) },
+	)
+	p.writeSecondary(metrics.PersistenceCompleteTimerTaskScope, secondaryErr)
+	return err
+}
+
+func (p *workflowExecutionDualWritePersistenceClient) RangeCompleteTimerTask(request *RangeCompleteTimerTaskRequest) error {
+	err, secondaryErr := p.write(
+		func() error { return p.primary.RangeCompleteTimerTask(request) },
+		func() error { return p.secondary.RangeCompleteTimerTask(request) },
+	)
+	p.writeSecondary(metrics.PersistenceRangeCompleteTimerTaskScope, secondaryErr)
+	return err
+}
+
+func (p *workflowExecutionDualWritePersistenceClient) GetClosedExecutionIndex(request *GetClosedExecutionIndexRequest) (*GetClosedExecutionIndexResponse, error) {
+	return p.primary.GetClosedExecutionIndex(request)
+}
+
+func (p *workflowExecutionDualWritePersistenceClient) DeleteClosedExecutionIndex(request *DeleteClosedExecutionIndexRequest) error {
+	err, secondaryErr := p.write(
+		func() error { return p.primary.DeleteClosedExecutionIndex(request) },
+		func() error { return p.secondary.DeleteClosedExecutionIndex(request) },
+	)
+	p.writeSecondary(metrics.PersistenceDeleteClosedExecutionIndexScope, secondaryErr)
+	return err
+}
+
+func (p *workflowExecutionDualWritePersistenceClient) ListBufferedReplicationTasks(request *ListBufferedReplicationTasksRequest) (*ListBufferedReplicationTasksResponse, error) {
+	return p.primary.ListBufferedReplicationTasks(request)
+}
+
+func (p *workflowExecutionDualWritePersistenceClient) DeleteBufferedReplicationTasks(request *DeleteBufferedReplicationTasksRequest) error {
+	err, secondaryErr := p.write(
+		func() error { return p.primary.DeleteBufferedReplicationTasks(request) },
+		func() error { return p.secondary.DeleteBufferedReplicationTasks(request) },
+	)
+	p.writeSecondary(metrics.PersistenceDeleteBufferedReplicationTasksScope, secondaryErr)
+	return err
+}
+
+func (p *workflowExecutionDualWritePersistenceClient) Close() {
+	p.primary.Close()
+	p.secondary.Close()
+}
+
+func (p *taskDualWritePersistenceClient) GetName() string {
+	return p.primary.GetName()
+}
+
+func (p *taskDualWritePersistenceClient) Ping() error {
+	return p.primary.Ping()
+}
+
+func (p *taskDualWritePersistenceClient) CreateTasks(request *CreateTasksRequest) (*CreateTasksResponse, error) {
+	var response *CreateTasksResponse
+	err, secondaryErr := p.write(
+		func() (err error) {
+			response, err = p.primary.CreateTasks(request)
+			return err
+		},
+		func() error {
+			info := request.TaskListInfo
+			return p.writeSecondaryTaskList(info.DomainID, info.Name, info.TaskType, info.Kind, func(rangeID int64) error {
+				secondaryInfo := *info
+				secondaryInfo.RangeID = rangeID
+				_, err := p.secondary.CreateTasks(&CreateTasksRequest{TaskListInfo: &secondaryInfo, Tasks: request.Tasks})
+				return err
+			})
+		},
+	)
+	p.writeSecondary(metrics.PersistenceCreateTaskScope, secondaryErr)
+	return response, err
+}
+
+func (p *taskDualWritePersistenceClient) GetTasks(request *GetTasksRequest) (*GetTasksResponse, error) {
+	return p.primary.GetTasks(request)
+}
+
+func (p *taskDualWritePersistenceClient) CompleteTask(request *CompleteTaskRequest) error {
+	err, secondaryErr := p.write(
+		func() error { return p.primary.CompleteTask(request) },
+		func() error { return p.secondary.CompleteTask(request) },
+	)
+	p.writeSecondary(metrics.PersistenceCompleteTaskScope, secondaryErr)
+	return err
+}
+
+func (p *taskDualWritePersistenceClient) CompleteTasksLessThan(request *CompleteTasksLessThanRequest) (int, error) {
+	var response int
+	err, secondaryErr := p.write(
+		func() (err error) {
+			response, err = p.primary.CompleteTasksLessThan(request)
+			return err
+		},
+		func() error {
+			_, err := p.secondary.CompleteTasksLessThan(request)
+			return err
+		},
+	)
+	p.writeSecondary(metrics.PersistenceCompleteTasksLessThanScope, secondaryErr)
+	return response, err
+}
+
+func (p *taskDualWritePersistenceClient) LeaseTaskList(request *LeaseTaskListRequest) (*LeaseTaskListResponse, error) {
+	var response *LeaseTaskListResponse
+	err, secondaryErr := p.write(
+		func() (err error) {
+			response, err = p.primary.LeaseTaskList(request)
+			return err
+		},
+		func() error {
+			_, err := p.leaseSecondary(dualWriteTaskListKey{domainID: request.DomainID, name: request.TaskList, taskType: request.TaskType}, request.TaskListKind)
+			return err
+		},
+	)
+	p.writeSecondary(metrics.PersistenceLeaseTaskListScope, secondaryErr)
+	return response, err
+}
+
+func (p *taskDualWritePersistenceClient) ListTaskList(request *ListTaskListRequest) (*ListTaskListResponse, error) {
+	return p.primary.ListTaskList(request)
+}
+
+func (p *taskDualWritePersistenceClient) DeleteTaskList(request *DeleteTaskListRequest) error {
+	err, secondaryErr := p.write(
+		func() error { return p.primary.DeleteTaskList(request) },
+		func() error {
+			err := p.writeSecondaryTaskList(request.DomainID, request.TaskListName, request.TaskListType, TaskListKindNormal, func(rangeID int64) error {
+				secondaryRequest := *request
+				secondaryRequest.RangeID = rangeID
+				return p.secondary.DeleteTaskList(&secondaryRequest)
+			})
+			p.Lock()
+			delete(p.rangeIDs, dualWriteTaskListKey{domainID: request.DomainID, name: request.TaskListName, taskType: request.TaskListType})
+			p.Unlock()
+			return err
+		},
+	)
+	p.writeSecondary(metrics.PersistenceDeleteTaskListScope, secondaryErr)
+	return err
+}
+
+func (p *taskDualWritePersistenceClient) UpdateTaskList(request *UpdateTaskListRequest) (*UpdateTaskListResponse, error) {
+	var response *UpdateTaskListResponse
+	err, secondaryErr := p.write(
+		func() (err error) {
+			response, err = p.primary.UpdateTaskList(request)
+			return err
+		},
+		func() error {
+			info := request.TaskListInfo
+			return p.writeSecondaryTaskList(info.DomainID, info.Name, info.TaskType, info.Kind, func(rangeID int64) error {
+				secondaryInfo := *info
+				secondaryInfo.RangeID = rangeID
+				_, err := p.secondary.UpdateTaskList(&UpdateTaskListRequest{TaskListInfo: &secondaryInfo})
+				return err
+			})
+		},
+	)
+	p.writeSecondary(metrics.PersistenceUpdateTaskListScope, secondaryErr)
+	return response, err
+}
+
+// writeSecondaryTaskList runs a write of a task list against the secondary with the range ID of the
+// secondary lease, the task list is leased again and the write retried once if the lease was lost
+func (p *taskDualWritePersistenceClient) writeSecondaryTaskList(domainID string, name string, taskType int, kind int, write func(rangeID int64) error) error {
+	key := dualWriteTaskListKey{domainID: domainID, name: name, taskType: taskType}
+	p.Lock()
+	rangeID, ok := p.rangeIDs[key]
+	p.Unlock()
+	if ok {
+		err := write(rangeID)
+		if !isDualWriteConflict(err) {
+			return err
+		}
+	}
+	rangeID, err := p.leaseSecondary(key, kind)
+	if err != nil {
+		return err
+	}
+	return write(rangeID)
+}
+
+func (p *taskDualWritePersistenceClient) leaseSecondary(key dualWriteTaskListKey, kind int) (int64, error) {
+	response, err := p.secondary.LeaseTaskList(&LeaseTaskListRequest{
+		DomainID:     key.domainID,
+		TaskList:     key.name,
+		TaskType:     key.taskType,
+		TaskListKind: kind,
+	})
+	if err != nil {
+		return 0, err
+	}
+	p.Lock()
+	defer p.Unlock()
+	p.rangeIDs[key] = response.TaskListInfo.RangeID
+	return response.TaskListInfo.RangeID, nil
+}
+
+func (p *taskDualWritePersistenceClient) Close() {
+	p.primary.Close()
+	p.secondary.Close()
+}
+
+func (p *historyDualWritePersistenceClient) GetName() string {
+	return p.primary.GetName()
+}
+
+func (p *historyDualWritePersistenceClient) AppendHistoryEvents(request *AppendHistoryEventsRequest) (*AppendHistoryEventsResponse, error) {
+	var response *AppendHistoryEventsResponse
+	err, secondaryErr := p.write(
+		func() (err error) {
+			response, err = p.primary.AppendHistoryEvents(request)
+			return err
+		},
+		func() error {
+			_, err := p.secondary.AppendHistoryEvents(request)
+			return err
+		},
+	)
+	p.writeSecondary(metrics.PersistenceAppendHistoryEventsScope, secondaryErr)
+	return response, err
+}
+
+func (p *historyDualWritePersistenceClient) GetWorkflowExecutionHistory(request *GetWorkflowExecutionHistoryRequest) (*GetWorkflowExecutionHistoryResponse, error) {
+	return p.primary.GetWorkflowExecutionHistory(request)
+}
+
+func (p *historyDualWritePersistenceClient) GetWorkflowExecutionHistoryByBatch(request *GetWorkflowExecutionHistoryRequest) (*GetWorkflowExecutionHistoryByBatchResponse, error) {
+	return p.primary.GetWorkflowExecutionHistoryByBatch(request)
+}
+
+func (p *historyDualWritePersistenceClient) DeleteWorkflowExecutionHistory(request *DeleteWorkflowExecutionHistoryRequest) error {
+	err, secondaryErr := p.write(
+		func() error { return p.primary.DeleteWorkflowExecutionHistory(request) },
+		func() error { return p.secondary.DeleteWorkflowExecutionHistory(request) },
+	)
+	p.writeSecondary(metrics.PersistenceDeleteWorkflowExecutionHistoryScope, secondaryErr)
+	return err
+}
+
+func (p *historyDualWritePersistenceClient) Close() {
+	p.primary.Close()
+	p.secondary.Close()
+}
+
+func (p *historyV2DualWritePersistenceClient) GetName() string {
+	return p.primary.GetName()
+}
+
+func (p *historyV2DualWritePersistenceClient) Close() {
+	p.primary.Close()
+	p.secondary.Close()
+}
+
+func (p *historyV2DualWritePersistenceClient) AppendHistoryNodes(request *AppendHistoryNodesRequest) (*AppendHistoryNodesResponse, error) {
+	var response *AppendHistoryNodesResponse
+	err, secondaryErr := p.write(
+		func() (err error) {
+			response, err = p.primary.AppendHistoryNodes(request)
+			return err
+		},
+		func() error {
+			_, err := p.secondary.AppendHistoryNodes(request)
+			return err
+		},
+	)
+	p.writeSecondary(metrics.PersistenceAppendHistoryNodesScope, secondaryErr)
+	return response, err
+}
+
+func (p *historyV2DualWritePersistenceClient) ReadHistoryBranch(request *ReadHistoryBranchRequest) (*ReadHistoryBranchResponse, error) {
+	return p.primary.ReadHistoryBranch(request)
+}
+
+func (p *historyV2DualWritePersistenceClient) ReadHistoryBranchByBatch(request *ReadHistoryBranchRequest) (*ReadHistoryBranchByBatchResponse, error) {
+	return p.primary.ReadHistoryBranchByBatch(request)
+}
+
+func (p *historyV2DualWritePersistenceClient) ReadRawHistoryBranch(request *ReadHistoryBranchRequest) (*ReadRawHistoryBranchResponse, error) {
+	return p.primary.ReadRawHistoryBranch(request)
+}
+
+func (p *historyV2DualWritePersistenceClient) ForkHistoryBranch(request *ForkHistoryBranchRequest) (*ForkHistoryBranchResponse, error) {
+	if request.NewBranchID == "" {
+		// both stores must fork into the same branch, otherwise the branch tokens diverge
+		forkRequest := *request
+		forkRequest.NewBranchID = uuid.New()
+		request = &forkRequest
+	}
+	var response *ForkHistoryBranchResponse
+	err, secondaryErr := p.write(
+		func() (err error) {
+			response, err = p.primary.ForkHistoryBranch(request)
+			return err
+		},
+		func() error {
+			_, err := p.secondary.ForkHistoryBranch(request)
+			return err
+		},
+	)
+	p.writeSecondary(metrics.PersistenceForkHistoryBranchScope, secondaryErr)
+	return response, err
+}
+
+func (p *historyV2DualWritePersistenceClient) DeleteHistoryBranch(request *DeleteHistoryBranchRequest) error {
+	err, secondaryErr := p.write(
+		func() error { return p.primary.DeleteHistoryBranch(request) },
+		func() error { return p.secondary.DeleteHistoryBranch(request) },
+	)
+	p.writeSecondary(metrics.PersistenceDeleteHistoryBranchScope, secondaryErr)
+	return err
+}
+
+func (p *historyV2DualWritePersistenceClient) CompleteForkBranch(request *CompleteForkBranchRequest) error {
+	err, secondaryErr := p.write(
+		func() error { return p.primary.CompleteForkBranch(request) },
+		func() error { return p.secondary.CompleteForkBranch(request) },
+	)
+	p.writeSecondary(metrics.PersistenceCompleteForkBranchScope, secondaryErr)
+	return err
+}
+
+func (p *historyV2DualWritePersistenceClient) GetHistoryTree(request *GetHistoryTreeRequest) (*GetHistoryTreeResponse, error) {
+	secondaryRequest := *request
+	var response, secondaryResponse *GetHistoryTreeResponse
+	err, secondaryErr := p.read(
+		func() (err error) {
+			response, err = p.primary.GetHistoryTree(request)
+			return err
+		},
+		func() (err error) {
+			secondaryResponse, err = p.secondary.GetHistoryTree(&secondaryRequest)
+			return err
+		},
+	)
+	if err == nil && p.verifyReads {
+		p.verifyRead(metrics.PersistenceGetHistoryTreeScope, normalizeGetHistoryTreeResponse(response), normalizeGetHistoryTreeResponse(secondaryResponse), secondaryErr)
+	}
+	return response, err
+}
+
+func (p *metadataDualWritePersistenceClient) GetName() string {
+	return p.primary.GetName()
+}
+
+func (p *metadataDualWritePersistenceClient) CreateDomain(request *CreateDomainRequest) (*CreateDomainResponse, error) {
+	var response *CreateDomainResponse
+	err, secondaryErr := p.write(
+		func() (err error) {
+			response, err = p.primary.CreateDomain(request)
+			return err
+		},
+		func() error {
+			_, err := p.secondary.CreateDomain(request)
+			return err
+		},
+	)
+	p.writeSecondary(metrics.PersistenceCreateDomainScope, secondaryErr)
+	return response, err
+}
+
+func (p *metadataDualWritePersistenceClient) GetDomain(request *GetDomainRequest) (*GetDomainResponse, error) {
+	secondaryRequest := *request
+	var response, secondaryResponse *GetDomainResponse
+	err, secondaryErr := p.read(
+		func() (err error) {
+			response, err = p.primary.GetDomain(request)
+			return err
+		},
+		func() (err error) {
+			secondaryResponse, err = p.secondary.GetDomain(&secondaryRequest)
+			return err
+		},
+	)
+	if err == nil && p.verifyReads {
+		p.verifyRead(metrics.PersistenceGetDomainScope, normalizeGetDomainResponse(response), normalizeGetDomainResponse(secondaryResponse), secondaryErr)
+	}
+	return response, err
+}
+
+func (p *metadataDualWritePersistenceClient) UpdateDomain(request *UpdateDomainRequest) error {
+	err, secondaryErr := p.write(
+		func() error { return p.primary.UpdateDomain(request) },
+		func() error { return p.secondary.UpdateDomain(request) },
+	)
+	p.writeSecondary(metrics.PersistenceUpdateDomainScope, secondaryErr)
+	return err
+}
+
+func (p *metadataDualWritePersistenceClient) DeleteDomain(request *DeleteDomainRequest) error {
+	err, secondaryErr := p.write(
+		func() error { return p.primary.DeleteDomain(request) },
+		func() error { return p.secondary.DeleteDomain(request) },
+	)
+	p.writeSecondary(metrics.PersistenceDeleteDomainScope, secondaryErr)
+	return err
+}
+
+func (p *metadataDualWritePersistenceClient) DeleteDomainByName(request *DeleteDomainByNameRequest) error {
+	err, secondaryErr := p.write(
+		func() error { return p.primary.DeleteDomainByName(request) },
+		func() error { return p.secondary.DeleteDomainByName(request) },
+	)
+	p.writeSecondary(metrics.PersistenceDeleteDomainByNameScope, secondaryErr)
+	return err
+}
+
+func (p *metadataDualWritePersistenceClient) ListDomains(request *ListDomainsRequest) (*ListDomainsResponse, error) {
+	return p.primary.ListDomains(request)
+}
+
+func (p *metadataDualWritePersistenceClient) GetMetadata() (*GetMetadataResponse, error) {
+	return p.primary.GetMetadata()
+}
+
+func (p *metadataDualWritePersistenceClient) Ping() error {
+	return p.primary.Ping()
+}
+
+func (p *metadataDualWritePersistenceClient) Close() {
+	p.primary.Close()
+	p.secondary.Close()
+}
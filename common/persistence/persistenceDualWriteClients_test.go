@@ -0,0 +1,284 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package persistence
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"github.com/uber-go/tally"
+	workflow "github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common/log/loggerimpl"
+	"github.com/uber/cadence/common/metrics"
+)
+
+type (
+	dualWriteClientsSuite struct {
+		suite.Suite
+		*require.Assertions
+	}
+
+	testDualWriteMetadataManager struct {
+		MetadataManager
+		err     error
+		created []*CreateDomainRequest
+		reads   int
+	}
+
+	testDualWriteHistoryV2Manager struct {
+		HistoryV2Manager
+		forked []*ForkHistoryBranchRequest
+		trees  []*GetHistoryTreeRequest
+	}
+
+	testDualWriteShardManager struct {
+		ShardManager
+		shard   *ShardInfo
+		created []*CreateShardRequest
+		updated []*UpdateShardRequest
+	}
+
+	testDualWriteTaskManager struct {
+		TaskManager
+		rangeID int64
+		leased  []*LeaseTaskListRequest
+		created []*CreateTasksRequest
+	}
+)
+
+var errTestDualWrite = errors.New("dual write store unavailable")
+
+func TestDualWriteClientsSuite(t *testing.T) {
+	suite.Run(t, new(dualWriteClientsSuite))
+}
+
+func (s *dualWriteClientsSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+}
+
+func (s *dualWriteClientsSuite) TestWritesBothStores() {
+	primary := &testDualWriteMetadataManager{}
+	secondary := &testDualWriteMetadataManager{err: errTestDualWrite}
+	client := NewMetadataPersistenceDualWriteClient(primary, secondary, false, nil, loggerimpl.NewNopLogger())
+
+	request := &CreateDomainRequest{Info: &DomainInfo{ID: "domain-id", Name: "domain"}}
+	// the secondary failures are reported but do not fail the request
+	_, err := client.CreateDomain(request)
+	s.NoError(err)
+	s.Len(primary.created, 1)
+	s.Len(secondary.created, 1)
+
+	// the secondary is not written when the primary fails
+	primary.err = errTestDualWrite
+	secondary.err = nil
+	_, err = client.CreateDomain(request)
+	s.Equal(errTestDualWrite, err)
+	s.Len(primary.created, 2)
+	s.Len(secondary.created, 1)
+}
+
+func (s *dualWriteClientsSuite) TestReadsFromPrimary() {
+	primary := &testDualWriteMetadataManager{}
+	secondary := &testDualWriteMetadataManager{}
+	client := NewMetadataPersistenceDualWriteClient(primary, secondary, false, nil, loggerimpl.NewNopLogger())
+	response, err := client.GetDomain(&GetDomainRequest{ID: "domain-id"})
+	s.NoError(err)
+	s.Equal("domain-id", response.Info.ID)
+	s.Equal(1, primary.reads)
+	s.Equal(0, secondary.reads)
+
+	client = NewMetadataPersistenceDualWriteClient(primary, secondary, true, nil, loggerimpl.NewNopLogger())
+	_, err = client.GetDomain(&GetDomainRequest{ID: "domain-id"})
+	s.NoError(err)
+	s.Equal(2, primary.reads)
+	s.Equal(1, secondary.reads)
+}
+
+func (s *dualWriteClientsSuite) TestVerifyReadCopiesRequest() {
+	primary := &testDualWriteHistoryV2Manager{}
+	secondary := &testDualWriteHistoryV2Manager{}
+	client := NewHistoryV2PersistenceDualWriteClient(primary, secondary, true, nil, loggerimpl.NewNopLogger())
+
+	request := &GetHistoryTreeRequest{BranchToken: []byte("token")}
+	_, err := client.GetHistoryTree(request)
+	s.NoError(err)
+	s.Len(primary.trees, 1)
+	s.Len(secondary.trees, 1)
+	s.True(primary.trees[0] == request)
+	s.False(secondary.trees[0] == request)
+	s.Equal(request.BranchToken, secondary.trees[0].BranchToken)
+}
+
+func (s *dualWriteClientsSuite) TestForkHistoryBranchSameBranchID() {
+	primary := &testDualWriteHistoryV2Manager{}
+	secondary := &testDualWriteHistoryV2Manager{}
+	client := NewHistoryV2PersistenceDualWriteClient(primary, secondary, false, nil, loggerimpl.NewNopLogger())
+
+	request := &ForkHistoryBranchRequest{ForkBranchToken: []byte("token"), ForkNodeID: 2}
+	_, err := client.ForkHistoryBranch(request)
+	s.NoError(err)
+	s.Empty(request.NewBranchID)
+	s.Len(primary.forked, 1)
+	s.Len(secondary.forked, 1)
+	s.NotEmpty(primary.forked[0].NewBranchID)
+	s.Equal(primary.forked[0].NewBranchID, secondary.forked[0].NewBranchID)
+}
+
+func (s *dualWriteClientsSuite) TestVerifyReadComparesNormalizedFields() {
+	scope := tally.NewTestScope("", nil)
+	now := time.Now()
+	primary := &testDualWriteShardManager{shard: &ShardInfo{ShardID: 1, RangeID: 5, TimerAckLevel: now}}
+	secondary := &testDualWriteShardManager{shard: &ShardInfo{
+		ShardID:                 1,
+		RangeID:                 5,
+		TimerAckLevel:           now.Truncate(time.Millisecond),
+		ClusterTransferAckLevel: map[string]int64{},
+	}}
+	client := NewShardPersistenceDualWriteClient(primary, secondary, true, metrics.NewClient(scope, metrics.History), loggerimpl.NewNopLogger())
+
+	_, err := client.GetShard(&GetShardRequest{ShardID: 1})
+	s.NoError(err)
+	s.Empty(scope.Snapshot().Counters())
+
+	secondary.shard.RangeID = 4
+	_, err = client.GetShard(&GetShardRequest{ShardID: 1})
+	s.NoError(err)
+	s.Len(scope.Snapshot().Counters(), 1)
+}
+
+func (s *dualWriteClientsSuite) TestReconcileShard() {
+	primary := &testDualWriteShardManager{shard: &ShardInfo{ShardID: 1, RangeID: 5}}
+	secondary := &testDualWriteShardManager{shard: &ShardInfo{ShardID: 1, RangeID: 2}}
+	client := NewShardPersistenceDualWriteClient(primary, secondary, false, nil, loggerimpl.NewNopLogger())
+
+	// the secondary missed the previous range renewals, the shard is copied from the primary
+	err := client.UpdateShard(&UpdateShardRequest{ShardInfo: &ShardInfo{ShardID: 1, RangeID: 6}, PreviousRangeID: 5})
+	s.NoError(err)
+	s.Len(secondary.updated, 2)
+	s.Equal(int64(2), secondary.updated[1].PreviousRangeID)
+	s.Equal(int64(6), secondary.shard.RangeID)
+
+	// the secondary does not have the shard yet
+	secondary.shard = nil
+	err = client.UpdateShard(&UpdateShardRequest{ShardInfo: &ShardInfo{ShardID: 1, RangeID: 7}, PreviousRangeID: 6})
+	s.NoError(err)
+	s.Len(secondary.created, 1)
+	s.Equal(int64(7), secondary.shard.RangeID)
+}
+
+func (s *dualWriteClientsSuite) TestTaskListSecondaryRangeID() {
+	primary := &testDualWriteTaskManager{rangeID: 10}
+	secondary := &testDualWriteTaskManager{rangeID: 3}
+	client := NewTaskPersistenceDualWriteClient(primary, secondary, false, nil, loggerimpl.NewNopLogger())
+
+	response, err := client.LeaseTaskList(&LeaseTaskListRequest{DomainID: "domain-id", TaskList: "tl", RangeID: 10})
+	s.NoError(err)
+	s.Equal(int64(11), response.TaskListInfo.RangeID)
+	// the secondary is leased unconditionally, the primary lease fences the task list
+	s.Len(secondary.leased, 1)
+	s.Equal(int64(0), secondary.leased[0].RangeID)
+
+	info := &TaskListInfo{DomainID: "domain-id", Name: "tl", RangeID: 11}
+	_, err = client.CreateTasks(&CreateTasksRequest{TaskListInfo: info})
+	s.NoError(err)
+	s.Equal(int64(11), primary.created[0].TaskListInfo.RangeID)
+	s.Equal(int64(4), secondary.created[0].TaskListInfo.RangeID)
+
+	// the secondary lease was lost, the task list is leased again
+	secondary.rangeID = 8
+	_, err = client.CreateTasks(&CreateTasksRequest{TaskListInfo: info})
+	s.NoError(err)
+	s.Len(secondary.leased, 2)
+	s.Equal(int64(9), secondary.created[len(secondary.created)-1].TaskListInfo.RangeID)
+}
+
+func (m *testDualWriteShardManager) GetShard(request *GetShardRequest) (*GetShardResponse, error) {
+	if m.shard == nil {
+		return nil, &workflow.EntityNotExistsError{}
+	}
+	shard := *m.shard
+	return &GetShardResponse{ShardInfo: &shard}, nil
+}
+
+func (m *testDualWriteShardManager) CreateShard(request *CreateShardRequest) error {
+	m.created = append(m.created, request)
+	if m.shard != nil {
+		return &ShardAlreadyExistError{}
+	}
+	m.shard = request.ShardInfo
+	return nil
+}
+
+func (m *testDualWriteShardManager) UpdateShard(request *UpdateShardRequest) error {
+	m.updated = append(m.updated, request)
+	if m.shard == nil {
+		return &workflow.EntityNotExistsError{}
+	}
+	if m.shard.RangeID != request.PreviousRangeID {
+		return &ShardOwnershipLostError{ShardID: request.ShardInfo.ShardID}
+	}
+	m.shard = request.ShardInfo
+	return nil
+}
+
+func (m *testDualWriteTaskManager) LeaseTaskList(request *LeaseTaskListRequest) (*LeaseTaskListResponse, error) {
+	m.leased = append(m.leased, request)
+	if request.RangeID > 0 && request.RangeID != m.rangeID {
+		return nil, &ConditionFailedError{}
+	}
+	m.rangeID++
+	return &LeaseTaskListResponse{TaskListInfo: &TaskListInfo{DomainID: request.DomainID, Name: request.TaskList, RangeID: m.rangeID}}, nil
+}
+
+func (m *testDualWriteTaskManager) CreateTasks(request *CreateTasksRequest) (*CreateTasksResponse, error) {
+	if request.TaskListInfo.RangeID != m.rangeID {
+		return nil, &ConditionFailedError{}
+	}
+	m.created = append(m.created, request)
+	return &CreateTasksResponse{}, nil
+}
+
+func (m *testDualWriteMetadataManager) CreateDomain(request *CreateDomainRequest) (*CreateDomainResponse, error) {
+	m.created = append(m.created, request)
+	if m.err != nil {
+		return nil, m.err
+	}
+	return &CreateDomainResponse{ID: request.Info.ID}, nil
+}
+
+func (m *testDualWriteMetadataManager) GetDomain(request *GetDomainRequest) (*GetDomainResponse, error) {
+	m.reads++
+	return &GetDomainResponse{Info: &DomainInfo{ID: request.ID}}, nil
+}
+
+func (m *testDualWriteHistoryV2Manager) ForkHistoryBranch(request *ForkHistoryBranchRequest) (*ForkHistoryBranchResponse, error) {
+	m.forked = append(m.forked, request)
+	return &ForkHistoryBranchResponse{}, nil
+}
+
+func (m *testDualWriteHistoryV2Manager) GetHistoryTree(request *GetHistoryTreeRequest) (*GetHistoryTreeResponse, error) {
+	m.trees = append(m.trees, request)
+	request.TreeID = "tree-id"
+	return &GetHistoryTreeResponse{}, nil
+}
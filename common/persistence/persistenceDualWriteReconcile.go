@@ -0,0 +1,317 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package persistence
+
+import (
+	"time"
+
+	workflow "github.com/uber/cadence/.gen/go/shared"
+)
+
+type (
+	// the normalized fields are the ones compared by the verification reads, they are comparable
+	// values that do not depend on how a store encodes timestamps or empty collections
+
+	dualWriteShardFields struct {
+		rangeID                   int64
+		owner                     string
+		replicationAckLevel       int64
+		transferAckLevel          int64
+		visibilityAckLevel        int64
+		timerAckLevel             int64
+		domainNotificationVersion int64
+	}
+
+	dualWriteExecutionFields struct {
+		runID              string
+		state              int
+		closeStatus        int
+		nextEventID        int64
+		lastFirstEventID   int64
+		lastProcessedEvent int64
+		decisionScheduleID int64
+		activityInfos      int
+		timerInfos         int
+		childInfos         int
+		requestCancelInfos int
+		signalInfos        int
+		signalRequestedIDs int
+		updateInfos        int
+		bufferedEvents     int
+	}
+
+	dualWriteCurrentExecutionFields struct {
+		startRequestID   string
+		runID            string
+		state            int
+		closeStatus      int
+		lastWriteVersion int64
+	}
+
+	dualWriteScheduleFields struct {
+		cronSchedule     string
+		workflowTypeName string
+		taskList         string
+		paused           bool
+		lastFireTime     int64
+		lastWorkflowID   string
+		lastRunID        string
+		version          int64
+	}
+
+	dualWriteHistoryTreeFields struct {
+		branches                  int
+		forkingInProgressBranches int
+	}
+
+	dualWriteDomainFields struct {
+		id                  string
+		name                string
+		status              int
+		retention           int32
+		activeClusterName   string
+		isGlobalDomain      bool
+		configVersion       int64
+		failoverVersion     int64
+		notificationVersion int64
+	}
+)
+
+// isDualWriteConflict returns true when a conditional write was rejected by the secondary,
+// which means the secondary missed writes of the entity and has to be reconciled
+func isDualWriteConflict(err error) bool {
+	switch err.(type) {
+	case *ConditionFailedError,
+		*CurrentWorkflowConditionFailedError,
+		*ShardOwnershipLostError,
+		*ShardAlreadyExistError,
+		*WorkflowExecutionAlreadyStartedError,
+		*workflow.EntityNotExistsError:
+		return true
+	default:
+		return false
+	}
+}
+
+// reconcileShard overwrites the shard of the secondary with the one just written to the primary,
+// after which the range IDs of both stores match again
+func (p *shardDualWritePersistenceClient) reconcileShard(info *ShardInfo) error {
+	response, err := p.secondary.GetShard(&GetShardRequest{ShardID: info.ShardID})
+	if err != nil {
+		if _, ok := err.(*workflow.EntityNotExistsError); ok {
+			return p.secondary.CreateShard(&CreateShardRequest{ShardInfo: info})
+		}
+		return err
+	}
+	return p.secondary.UpdateShard(&UpdateShardRequest{
+		ShardInfo:       info,
+		PreviousRangeID: response.ShardInfo.RangeID,
+	})
+}
+
+// reconcileExecution backfills a run of the secondary from the mutable state of the primary. Only
+// the current run of a workflow is backfilled, recreating it also moves the current record to it,
+// a closed run that is no longer current is left out of sync
+func (p *workflowExecutionDualWritePersistenceClient) reconcileExecution(rangeID int64, domainID string, workflowID string, runID string) error {
+	current, err := p.primary.GetCurrentExecution(&GetCurrentExecutionRequest{
+		DomainID:   domainID,
+		WorkflowID: workflowID,
+	})
+	if err != nil {
+		return err
+	}
+	if current.RunID != runID {
+		return &ConditionFailedError{Msg: "dual write: run is not current and cannot be backfilled"}
+	}
+	response, err := p.primary.GetWorkflowExecution(&GetWorkflowExecutionRequest{
+		DomainID:  domainID,
+		Execution: workflow.WorkflowExecution{WorkflowId: &workflowID, RunId: &runID},
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := p.secondary.DeleteWorkflowExecution(&DeleteWorkflowExecutionRequest{
+		DomainID:   domainID,
+		WorkflowID: workflowID,
+		RunID:      runID,
+	}); err != nil {
+		return err
+	}
+	if _, err := p.secondary.DeleteCurrentWorkflowExecution(&DeleteCurrentWorkflowExecutionRequest{
+		DomainID:   domainID,
+		WorkflowID: workflowID,
+		RunID:      runID,
+		Force:      true,
+	}); err != nil {
+		return err
+	}
+	_, err = p.secondary.CreateWorkflowExecution(&CreateWorkflowExecutionRequest{
+		RangeID:             rangeID,
+		CreateWorkflowMode:  CreateWorkflowModeBrandNew,
+		NewWorkflowSnapshot: mutableStateToSnapshot(response.State),
+	})
+	return err
+}
+
+// mutableStateToSnapshot converts a loaded mutable state to a snapshot without tasks, the tasks
+// are only processed from the primary while it is the source of truth
+func mutableStateToSnapshot(state *WorkflowMutableState) WorkflowSnapshot {
+	snapshot := WorkflowSnapshot{
+		ExecutionInfo:    state.ExecutionInfo,
+		ExecutionStats:   state.ExecutionStats,
+		ReplicationState: state.ReplicationState,
+		Condition:        state.ExecutionInfo.NextEventID,
+	}
+	for _, info := range state.ActivityInfos {
+		snapshot.ActivityInfos = append(snapshot.ActivityInfos, info)
+	}
+	for _, info := range state.TimerInfos {
+		snapshot.TimerInfos = append(snapshot.TimerInfos, info)
+	}
+	for _, info := range state.ChildExecutionInfos {
+		snapshot.ChildExecutionInfos = append(snapshot.ChildExecutionInfos, info)
+	}
+	for _, info := range state.RequestCancelInfos {
+		snapshot.RequestCancelInfos = append(snapshot.RequestCancelInfos, info)
+	}
+	for _, info := range state.SignalInfos {
+		snapshot.SignalInfos = append(snapshot.SignalInfos, info)
+	}
+	for signalID := range state.SignalRequestedIDs {
+		snapshot.SignalRequestedIDs = append(snapshot.SignalRequestedIDs, signalID)
+	}
+	for _, info := range state.UpdateInfos {
+		snapshot.UpdateInfos = append(snapshot.UpdateInfos, info)
+	}
+	return snapshot
+}
+
+// unixMillis truncates a timestamp to the millisecond precision that all the stores keep
+func unixMillis(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.UnixNano() / int64(time.Millisecond)
+}
+
+func normalizeGetShardResponse(response *GetShardResponse) interface{} {
+	if response == nil || response.ShardInfo == nil {
+		return nil
+	}
+	info := response.ShardInfo
+	return dualWriteShardFields{
+		rangeID:                   info.RangeID,
+		owner:                     info.Owner,
+		replicationAckLevel:       info.ReplicationAckLevel,
+		transferAckLevel:          info.TransferAckLevel,
+		visibilityAckLevel:        info.VisibilityAckLevel,
+		timerAckLevel:             unixMillis(info.TimerAckLevel),
+		domainNotificationVersion: info.DomainNotificationVersion,
+	}
+}
+
+func normalizeGetWorkflowExecutionResponse(response *GetWorkflowExecutionResponse) interface{} {
+	if response == nil || response.State == nil || response.State.ExecutionInfo == nil {
+		return nil
+	}
+	state := response.State
+	info := state.ExecutionInfo
+	return dualWriteExecutionFields{
+		runID:              info.RunID,
+		state:              info.State,
+		closeStatus:        info.CloseStatus,
+		nextEventID:        info.NextEventID,
+		lastFirstEventID:   info.LastFirstEventID,
+		lastProcessedEvent: info.LastProcessedEvent,
+		decisionScheduleID: info.DecisionScheduleID,
+		activityInfos:      len(state.ActivityInfos),
+		timerInfos:         len(state.TimerInfos),
+		childInfos:         len(state.ChildExecutionInfos),
+		requestCancelInfos: len(state.RequestCancelInfos),
+		signalInfos:        len(state.SignalInfos),
+		signalRequestedIDs: len(state.SignalRequestedIDs),
+		updateInfos:        len(state.UpdateInfos),
+		bufferedEvents:     len(state.BufferedEvents),
+	}
+}
+
+func normalizeGetCurrentExecutionResponse(response *GetCurrentExecutionResponse) interface{} {
+	if response == nil {
+		return nil
+	}
+	return dualWriteCurrentExecutionFields{
+		startRequestID:   response.StartRequestID,
+		runID:            response.RunID,
+		state:            response.State,
+		closeStatus:      response.CloseStatus,
+		lastWriteVersion: response.LastWriteVersion,
+	}
+}
+
+func normalizeGetScheduleResponse(response *GetScheduleResponse) interface{} {
+	if response == nil || response.Schedule == nil {
+		return nil
+	}
+	schedule := response.Schedule
+	return dualWriteScheduleFields{
+		cronSchedule:     schedule.CronSchedule,
+		workflowTypeName: schedule.WorkflowTypeName,
+		taskList:         schedule.TaskList,
+		paused:           schedule.Paused,
+		lastFireTime:     unixMillis(schedule.LastFireTime),
+		lastWorkflowID:   schedule.LastWorkflowID,
+		lastRunID:        schedule.LastRunID,
+		version:          schedule.Version,
+	}
+}
+
+func normalizeGetHistoryTreeResponse(response *GetHistoryTreeResponse) interface{} {
+	if response == nil {
+		return nil
+	}
+	return dualWriteHistoryTreeFields{
+		branches:                  len(response.Branches),
+		forkingInProgressBranches: len(response.ForkingInProgressBranches),
+	}
+}
+
+func normalizeGetDomainResponse(response *GetDomainResponse) interface{} {
+	if response == nil || response.Info == nil {
+		return nil
+	}
+	fields := dualWriteDomainFields{
+		id:                  response.Info.ID,
+		name:                response.Info.Name,
+		status:              response.Info.Status,
+		isGlobalDomain:      response.IsGlobalDomain,
+		configVersion:       response.ConfigVersion,
+		failoverVersion:     response.FailoverVersion,
+		notificationVersion: response.NotificationVersion,
+	}
+	if response.Config != nil {
+		fields.retention = response.Config.Retention
+	}
+	if response.ReplicationConfig != nil {
+		fields.activeClusterName = response.ReplicationConfig.ActiveClusterName
+	}
+	return fields
+}
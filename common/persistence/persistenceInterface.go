@@ -21,6 +21,7 @@
 package persistence
 
 import (
+	"context"
 	"fmt"
 	"time"
 
@@ -38,6 +39,12 @@ type (
 
 	// ShardStore is a lower level of ShardManager
 	ShardStore = ShardManager
+	// ClusterMembershipStore is a lower level of ClusterMembershipManager
+	ClusterMembershipStore = ClusterMembershipManager
+	// AuditStore is a lower level of AuditManager
+	AuditStore = AuditManager
+	// ScheduleStore is a lower level of ScheduleManager
+	ScheduleStore = ScheduleManager
 	// TaskStore is a lower level of TaskManager
 	TaskStore = TaskManager
 	// MetadataStore is a lower level of MetadataManager
@@ -51,6 +58,7 @@ type (
 		DeleteDomainByName(request *DeleteDomainByNameRequest) error
 		ListDomains(request *ListDomainsRequest) (*InternalListDomainsResponse, error)
 		GetMetadata() (*GetMetadataResponse, error)
+		Ping() error
 	}
 
 	// ExecutionStore is used to manage workflow executions for Persistence layer
@@ -58,6 +66,7 @@ type (
 		Closeable
 		GetName() string
 		GetShardID() int
+		Ping() error
 		//The below three APIs are related to serialization/deserialization
 		GetWorkflowExecution(request *GetWorkflowExecutionRequest) (*InternalGetWorkflowExecutionResponse, error)
 		UpdateWorkflowExecution(request *InternalUpdateWorkflowExecutionRequest) error
@@ -66,22 +75,39 @@ type (
 
 		CreateWorkflowExecution(request *InternalCreateWorkflowExecutionRequest) (*CreateWorkflowExecutionResponse, error)
 		DeleteWorkflowExecution(request *DeleteWorkflowExecutionRequest) error
-		DeleteCurrentWorkflowExecution(request *DeleteCurrentWorkflowExecutionRequest) error
+		DeleteCurrentWorkflowExecution(request *DeleteCurrentWorkflowExecutionRequest) (*DeleteCurrentWorkflowExecutionResponse, error)
 		GetCurrentExecution(request *GetCurrentExecutionRequest) (*GetCurrentExecutionResponse, error)
+		ListWorkflowExecutionRuns(request *ListWorkflowExecutionRunsRequest) (*ListWorkflowExecutionRunsResponse, error)
+		ListCurrentExecutions(request *ListCurrentExecutionsRequest) (*ListCurrentExecutionsResponse, error)
+		BlockCurrentWorkflowExecution(request *BlockCurrentWorkflowExecutionRequest) error
 
 		// Transfer task related methods
 		GetTransferTasks(request *GetTransferTasksRequest) (*GetTransferTasksResponse, error)
 		CompleteTransferTask(request *CompleteTransferTaskRequest) error
 		RangeCompleteTransferTask(request *RangeCompleteTransferTaskRequest) error
 
+		// Visibility task related methods
+		GetVisibilityTasks(request *GetVisibilityTasksRequest) (*GetVisibilityTasksResponse, error)
+		CompleteVisibilityTask(request *CompleteVisibilityTaskRequest) error
+		RangeCompleteVisibilityTask(request *RangeCompleteVisibilityTaskRequest) error
+
 		// Replication task related methods
 		GetReplicationTasks(request *GetReplicationTasksRequest) (*GetReplicationTasksResponse, error)
 		CompleteReplicationTask(request *CompleteReplicationTaskRequest) error
+		RangeCompleteReplicationTask(request *RangeCompleteReplicationTaskRequest) error
 
 		// Timer related methods.
 		GetTimerIndexTasks(request *GetTimerIndexTasksRequest) (*GetTimerIndexTasksResponse, error)
 		CompleteTimerTask(request *CompleteTimerTaskRequest) error
 		RangeCompleteTimerTask(request *RangeCompleteTimerTaskRequest) error
+
+		// Closed execution index related methods
+		GetClosedExecutionIndex(request *GetClosedExecutionIndexRequest) (*GetClosedExecutionIndexResponse, error)
+		DeleteClosedExecutionIndex(request *DeleteClosedExecutionIndexRequest) error
+
+		// Buffered replication task related methods
+		ListBufferedReplicationTasks(request *ListBufferedReplicationTasksRequest) (*ListBufferedReplicationTasksResponse, error)
+		DeleteBufferedReplicationTasks(request *DeleteBufferedReplicationTasksRequest) error
 	}
 
 	// HistoryStore is used to manage Workflow Execution HistoryEventBatch for Persistence layer
@@ -164,38 +190,47 @@ type (
 
 	// InternalWorkflowExecutionInfo describes a workflow execution for Persistence Interface
 	InternalWorkflowExecutionInfo struct {
-		DomainID                     string
-		WorkflowID                   string
-		RunID                        string
-		ParentDomainID               string
-		ParentWorkflowID             string
-		ParentRunID                  string
-		InitiatedID                  int64
-		CompletionEventBatchID       int64
-		CompletionEvent              *DataBlob
-		TaskList                     string
-		WorkflowTypeName             string
-		WorkflowTimeout              int32
-		DecisionTimeoutValue         int32
-		ExecutionContext             []byte
-		State                        int
-		CloseStatus                  int
-		LastFirstEventID             int64
-		LastEventTaskID              int64
-		NextEventID                  int64
-		LastProcessedEvent           int64
-		StartTimestamp               time.Time
-		LastUpdatedTimestamp         time.Time
-		CreateRequestID              string
-		SignalCount                  int32
-		DecisionVersion              int64
-		DecisionScheduleID           int64
-		DecisionStartedID            int64
-		DecisionRequestID            string
-		DecisionTimeout              int32
-		DecisionAttempt              int64
-		DecisionStartedTimestamp     int64
-		DecisionScheduledTimestamp   int64
+		DomainID                   string
+		WorkflowID                 string
+		RunID                      string
+		FirstExecutionRunID        string
+		ParentDomainID             string
+		ParentWorkflowID           string
+		ParentRunID                string
+		InitiatedID                int64
+		CompletionEventBatchID     int64
+		CompletionEvent            *DataBlob
+		TaskList                   string
+		WorkflowTypeName           string
+		WorkflowTimeout            int32
+		DecisionTimeoutValue       int32
+		ExecutionContext           []byte
+		State                      int
+		CloseStatus                int
+		LastFirstEventID           int64
+		LastEventTaskID            int64
+		NextEventID                int64
+		LastProcessedEvent         int64
+		StartTimestamp             time.Time
+		LastUpdatedTimestamp       time.Time
+		CreateRequestID            string
+		SignalCount                int32
+		DecisionVersion            int64
+		DecisionScheduleID         int64
+		DecisionStartedID          int64
+		DecisionRequestID          string
+		DecisionTimeout            int32
+		DecisionAttempt            int64
+		DecisionHeartbeatCount     int64
+		DecisionStartedTimestamp   int64
+		DecisionScheduledTimestamp int64
+		// identity and binary checksum of the worker which started the current decision
+		DecisionStartedIdentity       string
+		DecisionStartedBinaryChecksum string
+		// run, decision finish event and reason this run was reset from, empty unless the run was created by a reset
+		ResetBaseRunID               string
+		ResetBaseEventID             int64
+		ResetReason                  string
 		CancelRequested              bool
 		CancelRequestID              string
 		StickyTaskList               string
@@ -220,6 +255,14 @@ type (
 		ExpirationSeconds int32
 		SearchAttributes  map[string][]byte
 
+		CompletedActivityRequestIDs []string
+		Paused                      bool
+		CronPaused                  bool
+		CronTimezone                string
+		Notes                       string
+		NotesIdentity               string
+		NotesLastUpdatedTime        time.Time
+
 		// attributes which are not related to mutable state at all
 		HistorySize int64
 	}
@@ -232,6 +275,7 @@ type (
 		RequestCancelInfos  map[int64]*RequestCancelInfo
 		SignalInfos         map[int64]*SignalInfo
 		SignalRequestedIDs  map[string]struct{}
+		UpdateInfos         map[string]*UpdateInfo
 		ExecutionInfo       *InternalWorkflowExecutionInfo
 		ReplicationState    *ReplicationState
 		BufferedEvents      []*DataBlob
@@ -258,6 +302,7 @@ type (
 		CancelRequestID          int64
 		LastHeartBeatUpdatedTime time.Time
 		TimerTaskStatus          int32
+		Paused                   bool
 		// For retry
 		Attempt            int32
 		DomainID           string
@@ -351,13 +396,16 @@ type (
 		UpsertSignalInfos         []*SignalInfo
 		DeleteSignalInfo          *int64
 		UpsertSignalRequestedIDs  []string
-		DeleteSignalRequestedID   string
+		DeleteSignalRequestedIDs  []string
+		UpsertUpdateInfos         []*UpdateInfo
+		DeleteUpdateInfo          *string
 		NewBufferedEvents         *DataBlob
 		ClearBufferedEvents       bool
 
 		TransferTasks    []Task
 		TimerTasks       []Task
 		ReplicationTasks []Task
+		VisibilityTasks  []Task
 
 		Condition int64
 	}
@@ -373,10 +421,12 @@ type (
 		RequestCancelInfos  []*RequestCancelInfo
 		SignalInfos         []*SignalInfo
 		SignalRequestedIDs  []string
+		UpdateInfos         []*UpdateInfo
 
 		TransferTasks    []Task
 		TimerTasks       []Task
 		ReplicationTasks []Task
+		VisibilityTasks  []Task
 
 		Condition int64
 	}
@@ -487,6 +537,8 @@ type (
 		NextPageToken []byte
 		// Used in sharded data stores to identify which shard to use
 		ShardID int
+		// Context bounds the store query, nil means unbounded
+		Context context.Context
 	}
 
 	// InternalCompleteForkBranchRequest is used to update some tree/branch meta data for forking
@@ -509,16 +561,17 @@ type (
 
 	// VisibilityWorkflowExecutionInfo is visibility info for internal response
 	VisibilityWorkflowExecutionInfo struct {
-		WorkflowID       string
-		RunID            string
-		TypeName         string
-		StartTime        time.Time
-		ExecutionTime    time.Time
-		CloseTime        time.Time
-		Status           *workflow.WorkflowExecutionCloseStatus
-		HistoryLength    int64
-		Memo             *DataBlob
-		SearchAttributes map[string]interface{}
+		WorkflowID          string
+		RunID               string
+		TypeName            string
+		StartTime           time.Time
+		ExecutionTime       time.Time
+		FirstExecutionRunID string
+		CloseTime           time.Time
+		Status              *workflow.WorkflowExecutionCloseStatus
+		HistoryLength       int64
+		Memo                *DataBlob
+		SearchAttributes    map[string]interface{}
 	}
 
 	// InternalListWorkflowExecutionsResponse is response from ListWorkflowExecutions
@@ -536,47 +589,50 @@ type (
 
 	// InternalRecordWorkflowExecutionStartedRequest request to RecordWorkflowExecutionStarted
 	InternalRecordWorkflowExecutionStartedRequest struct {
-		DomainUUID         string
-		WorkflowID         string
-		RunID              string
-		WorkflowTypeName   string
-		StartTimestamp     int64
-		ExecutionTimestamp int64
-		WorkflowTimeout    int64
-		TaskID             int64
-		Memo               *DataBlob
-		SearchAttributes   map[string][]byte
+		DomainUUID          string
+		WorkflowID          string
+		RunID               string
+		WorkflowTypeName    string
+		StartTimestamp      int64
+		ExecutionTimestamp  int64
+		FirstExecutionRunID string
+		WorkflowTimeout     int64
+		TaskID              int64
+		Memo                *DataBlob
+		SearchAttributes    map[string][]byte
 	}
 
 	// InternalRecordWorkflowExecutionClosedRequest is request to RecordWorkflowExecutionClosed
 	InternalRecordWorkflowExecutionClosedRequest struct {
-		DomainUUID         string
-		WorkflowID         string
-		RunID              string
-		WorkflowTypeName   string
-		StartTimestamp     int64
-		ExecutionTimestamp int64
-		TaskID             int64
-		Memo               *DataBlob
-		SearchAttributes   map[string][]byte
-		CloseTimestamp     int64
-		Status             workflow.WorkflowExecutionCloseStatus
-		HistoryLength      int64
-		RetentionSeconds   int64
+		DomainUUID          string
+		WorkflowID          string
+		RunID               string
+		WorkflowTypeName    string
+		StartTimestamp      int64
+		ExecutionTimestamp  int64
+		FirstExecutionRunID string
+		TaskID              int64
+		Memo                *DataBlob
+		SearchAttributes    map[string][]byte
+		CloseTimestamp      int64
+		Status              workflow.WorkflowExecutionCloseStatus
+		HistoryLength       int64
+		RetentionSeconds    int64
 	}
 
 	// InternalUpsertWorkflowExecutionRequest is request to UpsertWorkflowExecution
 	InternalUpsertWorkflowExecutionRequest struct {
-		DomainUUID         string
-		WorkflowID         string
-		RunID              string
-		WorkflowTypeName   string
-		StartTimestamp     int64
-		ExecutionTimestamp int64
-		WorkflowTimeout    int64
-		TaskID             int64
-		Memo               *DataBlob
-		SearchAttributes   map[string][]byte
+		DomainUUID          string
+		WorkflowID          string
+		RunID               string
+		WorkflowTypeName    string
+		StartTimestamp      int64
+		ExecutionTimestamp  int64
+		FirstExecutionRunID string
+		WorkflowTimeout     int64
+		TaskID              int64
+		Memo                *DataBlob
+		SearchAttributes    map[string][]byte
 	}
 
 	// InternalDomainConfig describes the domain configuration
@@ -587,6 +643,11 @@ type (
 		ArchivalBucket string
 		ArchivalStatus workflow.ArchivalStatus
 		BadBinaries    *DataBlob
+		// RetentionRunCount is the number of most recent completed runs kept per workflow ID, 0 means unlimited
+		RetentionRunCount  int32
+		TimeoutPolicy      DomainTimeoutPolicy
+		EncryptionKeyAlias string
+		Residency          DomainResidency
 	}
 
 	// InternalCreateDomainRequest is used to create the domain
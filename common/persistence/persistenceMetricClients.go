@@ -34,6 +34,24 @@ type (
 		logger       log.Logger
 	}
 
+	clusterMembershipPersistenceClient struct {
+		metricClient metrics.Client
+		persistence  ClusterMembershipManager
+		logger       log.Logger
+	}
+
+	auditPersistenceClient struct {
+		metricClient metrics.Client
+		persistence  AuditManager
+		logger       log.Logger
+	}
+
+	schedulePersistenceClient struct {
+		metricClient metrics.Client
+		persistence  ScheduleManager
+		logger       log.Logger
+	}
+
 	workflowExecutionPersistenceClient struct {
 		metricClient metrics.Client
 		persistence  ExecutionManager
@@ -72,6 +90,9 @@ type (
 )
 
 var _ ShardManager = (*shardPersistenceClient)(nil)
+var _ ClusterMembershipManager = (*clusterMembershipPersistenceClient)(nil)
+var _ AuditManager = (*auditPersistenceClient)(nil)
+var _ ScheduleManager = (*schedulePersistenceClient)(nil)
 var _ ExecutionManager = (*workflowExecutionPersistenceClient)(nil)
 var _ TaskManager = (*taskPersistenceClient)(nil)
 var _ HistoryManager = (*historyPersistenceClient)(nil)
@@ -88,6 +109,33 @@ func NewShardPersistenceMetricsClient(persistence ShardManager, metricClient met
 	}
 }
 
+// NewClusterMembershipPersistenceMetricsClient creates a client to manage cluster membership
+func NewClusterMembershipPersistenceMetricsClient(persistence ClusterMembershipManager, metricClient metrics.Client, logger log.Logger) ClusterMembershipManager {
+	return &clusterMembershipPersistenceClient{
+		persistence:  persistence,
+		metricClient: metricClient,
+		logger:       logger,
+	}
+}
+
+// NewAuditPersistenceMetricsClient creates a client to manage the audit log
+func NewAuditPersistenceMetricsClient(persistence AuditManager, metricClient metrics.Client, logger log.Logger) AuditManager {
+	return &auditPersistenceClient{
+		persistence:  persistence,
+		metricClient: metricClient,
+		logger:       logger,
+	}
+}
+
+// NewSchedulePersistenceMetricsClient creates a client to manage schedules
+func NewSchedulePersistenceMetricsClient(persistence ScheduleManager, metricClient metrics.Client, logger log.Logger) ScheduleManager {
+	return &schedulePersistenceClient{
+		persistence:  persistence,
+		metricClient: metricClient,
+		logger:       logger,
+	}
+}
+
 // NewWorkflowExecutionPersistenceMetricsClient creates a client to manage executions
 func NewWorkflowExecutionPersistenceMetricsClient(persistence ExecutionManager, metricClient metrics.Client, logger log.Logger) ExecutionManager {
 	return &workflowExecutionPersistenceClient{
@@ -210,6 +258,194 @@ func (p *shardPersistenceClient) Close() {
 	p.persistence.Close()
 }
 
+func (p *clusterMembershipPersistenceClient) GetName() string {
+	return p.persistence.GetName()
+}
+
+func (p *clusterMembershipPersistenceClient) UpsertClusterMembership(request *UpsertClusterMembershipRequest) error {
+	p.metricClient.IncCounter(metrics.PersistenceUpsertClusterMembershipScope, metrics.PersistenceRequests)
+
+	sw := p.metricClient.StartTimer(metrics.PersistenceUpsertClusterMembershipScope, metrics.PersistenceLatency)
+	err := p.persistence.UpsertClusterMembership(request)
+	sw.Stop()
+
+	if err != nil {
+		p.updateErrorMetric(metrics.PersistenceUpsertClusterMembershipScope, err)
+	}
+
+	return err
+}
+
+func (p *clusterMembershipPersistenceClient) GetClusterMembers(
+	request *GetClusterMembersRequest) (*GetClusterMembersResponse, error) {
+	p.metricClient.IncCounter(metrics.PersistenceGetClusterMembersScope, metrics.PersistenceRequests)
+
+	sw := p.metricClient.StartTimer(metrics.PersistenceGetClusterMembersScope, metrics.PersistenceLatency)
+	response, err := p.persistence.GetClusterMembers(request)
+	sw.Stop()
+
+	if err != nil {
+		p.updateErrorMetric(metrics.PersistenceGetClusterMembersScope, err)
+	}
+
+	return response, err
+}
+
+func (p *clusterMembershipPersistenceClient) PruneClusterMembership(request *PruneClusterMembershipRequest) error {
+	p.metricClient.IncCounter(metrics.PersistencePruneClusterMembershipScope, metrics.PersistenceRequests)
+
+	sw := p.metricClient.StartTimer(metrics.PersistencePruneClusterMembershipScope, metrics.PersistenceLatency)
+	err := p.persistence.PruneClusterMembership(request)
+	sw.Stop()
+
+	if err != nil {
+		p.updateErrorMetric(metrics.PersistencePruneClusterMembershipScope, err)
+	}
+
+	return err
+}
+
+func (p *clusterMembershipPersistenceClient) updateErrorMetric(scope int, err error) {
+	switch err.(type) {
+	case *workflow.ServiceBusyError:
+		p.metricClient.IncCounter(scope, metrics.PersistenceErrBusyCounter)
+		p.metricClient.IncCounter(scope, metrics.PersistenceFailures)
+	default:
+		p.logger.Error("Operation failed with internal error.", tag.Error(err), tag.MetricScope(scope))
+		p.metricClient.IncCounter(scope, metrics.PersistenceFailures)
+	}
+}
+
+func (p *clusterMembershipPersistenceClient) Close() {
+	p.persistence.Close()
+}
+
+func (p *auditPersistenceClient) GetName() string {
+	return p.persistence.GetName()
+}
+
+func (p *auditPersistenceClient) RecordAuditEntry(request *RecordAuditEntryRequest) error {
+	p.metricClient.IncCounter(metrics.PersistenceRecordAuditEntryScope, metrics.PersistenceRequests)
+
+	sw := p.metricClient.StartTimer(metrics.PersistenceRecordAuditEntryScope, metrics.PersistenceLatency)
+	err := p.persistence.RecordAuditEntry(request)
+	sw.Stop()
+
+	if err != nil {
+		p.updateErrorMetric(metrics.PersistenceRecordAuditEntryScope, err)
+	}
+
+	return err
+}
+
+func (p *auditPersistenceClient) updateErrorMetric(scope int, err error) {
+	switch err.(type) {
+	case *workflow.ServiceBusyError:
+		p.metricClient.IncCounter(scope, metrics.PersistenceErrBusyCounter)
+		p.metricClient.IncCounter(scope, metrics.PersistenceFailures)
+	default:
+		p.logger.Error("Operation failed with internal error.", tag.Error(err), tag.MetricScope(scope))
+		p.metricClient.IncCounter(scope, metrics.PersistenceFailures)
+	}
+}
+
+func (p *auditPersistenceClient) Close() {
+	p.persistence.Close()
+}
+
+func (p *schedulePersistenceClient) GetName() string {
+	return p.persistence.GetName()
+}
+
+func (p *schedulePersistenceClient) CreateSchedule(request *CreateScheduleRequest) error {
+	p.metricClient.IncCounter(metrics.PersistenceCreateScheduleScope, metrics.PersistenceRequests)
+
+	sw := p.metricClient.StartTimer(metrics.PersistenceCreateScheduleScope, metrics.PersistenceLatency)
+	err := p.persistence.CreateSchedule(request)
+	sw.Stop()
+
+	if err != nil {
+		p.updateErrorMetric(metrics.PersistenceCreateScheduleScope, err)
+	}
+
+	return err
+}
+
+func (p *schedulePersistenceClient) GetSchedule(request *GetScheduleRequest) (*GetScheduleResponse, error) {
+	p.metricClient.IncCounter(metrics.PersistenceGetScheduleScope, metrics.PersistenceRequests)
+
+	sw := p.metricClient.StartTimer(metrics.PersistenceGetScheduleScope, metrics.PersistenceLatency)
+	response, err := p.persistence.GetSchedule(request)
+	sw.Stop()
+
+	if err != nil {
+		p.updateErrorMetric(metrics.PersistenceGetScheduleScope, err)
+	}
+
+	return response, err
+}
+
+func (p *schedulePersistenceClient) UpdateSchedule(request *UpdateScheduleRequest) error {
+	p.metricClient.IncCounter(metrics.PersistenceUpdateScheduleScope, metrics.PersistenceRequests)
+
+	sw := p.metricClient.StartTimer(metrics.PersistenceUpdateScheduleScope, metrics.PersistenceLatency)
+	err := p.persistence.UpdateSchedule(request)
+	sw.Stop()
+
+	if err != nil {
+		p.updateErrorMetric(metrics.PersistenceUpdateScheduleScope, err)
+	}
+
+	return err
+}
+
+func (p *schedulePersistenceClient) DeleteSchedule(request *DeleteScheduleRequest) error {
+	p.metricClient.IncCounter(metrics.PersistenceDeleteScheduleScope, metrics.PersistenceRequests)
+
+	sw := p.metricClient.StartTimer(metrics.PersistenceDeleteScheduleScope, metrics.PersistenceLatency)
+	err := p.persistence.DeleteSchedule(request)
+	sw.Stop()
+
+	if err != nil {
+		p.updateErrorMetric(metrics.PersistenceDeleteScheduleScope, err)
+	}
+
+	return err
+}
+
+func (p *schedulePersistenceClient) ListSchedules(request *ListSchedulesRequest) (*ListSchedulesResponse, error) {
+	p.metricClient.IncCounter(metrics.PersistenceListSchedulesScope, metrics.PersistenceRequests)
+
+	sw := p.metricClient.StartTimer(metrics.PersistenceListSchedulesScope, metrics.PersistenceLatency)
+	response, err := p.persistence.ListSchedules(request)
+	sw.Stop()
+
+	if err != nil {
+		p.updateErrorMetric(metrics.PersistenceListSchedulesScope, err)
+	}
+
+	return response, err
+}
+
+func (p *schedulePersistenceClient) updateErrorMetric(scope int, err error) {
+	switch err.(type) {
+	case *ConditionFailedError:
+		p.metricClient.IncCounter(scope, metrics.PersistenceErrConditionFailedCounter)
+	case *workflow.EntityNotExistsError:
+		p.metricClient.IncCounter(scope, metrics.PersistenceErrEntityNotExistsCounter)
+	case *workflow.ServiceBusyError:
+		p.metricClient.IncCounter(scope, metrics.PersistenceErrBusyCounter)
+		p.metricClient.IncCounter(scope, metrics.PersistenceFailures)
+	default:
+		p.logger.Error("Operation failed with internal error.", tag.Error(err), tag.MetricScope(scope))
+		p.metricClient.IncCounter(scope, metrics.PersistenceFailures)
+	}
+}
+
+func (p *schedulePersistenceClient) Close() {
+	p.persistence.Close()
+}
+
 func (p *workflowExecutionPersistenceClient) GetName() string {
 	return p.persistence.GetName()
 }
@@ -218,6 +454,20 @@ func (p *workflowExecutionPersistenceClient) GetShardID() int {
 	return p.persistence.GetShardID()
 }
 
+func (p *workflowExecutionPersistenceClient) Ping() error {
+	p.metricClient.IncCounter(metrics.PersistencePingScope, metrics.PersistenceRequests)
+
+	sw := p.metricClient.StartTimer(metrics.PersistencePingScope, metrics.PersistenceLatency)
+	err := p.persistence.Ping()
+	sw.Stop()
+
+	if err != nil {
+		p.updateErrorMetric(metrics.PersistencePingScope, err)
+	}
+
+	return err
+}
+
 func (p *workflowExecutionPersistenceClient) CreateWorkflowExecution(request *CreateWorkflowExecutionRequest) (*CreateWorkflowExecutionResponse, error) {
 	p.metricClient.IncCounter(metrics.PersistenceCreateWorkflowExecutionScope, metrics.PersistenceRequests)
 
@@ -302,18 +552,18 @@ func (p *workflowExecutionPersistenceClient) DeleteWorkflowExecution(request *De
 	return err
 }
 
-func (p *workflowExecutionPersistenceClient) DeleteCurrentWorkflowExecution(request *DeleteCurrentWorkflowExecutionRequest) error {
+func (p *workflowExecutionPersistenceClient) DeleteCurrentWorkflowExecution(request *DeleteCurrentWorkflowExecutionRequest) (*DeleteCurrentWorkflowExecutionResponse, error) {
 	p.metricClient.IncCounter(metrics.PersistenceDeleteCurrentWorkflowExecutionScope, metrics.PersistenceRequests)
 
 	sw := p.metricClient.StartTimer(metrics.PersistenceDeleteCurrentWorkflowExecutionScope, metrics.PersistenceLatency)
-	err := p.persistence.DeleteCurrentWorkflowExecution(request)
+	response, err := p.persistence.DeleteCurrentWorkflowExecution(request)
 	sw.Stop()
 
 	if err != nil {
 		p.updateErrorMetric(metrics.PersistenceDeleteCurrentWorkflowExecutionScope, err)
 	}
 
-	return err
+	return response, err
 }
 
 func (p *workflowExecutionPersistenceClient) GetCurrentExecution(request *GetCurrentExecutionRequest) (*GetCurrentExecutionResponse, error) {
@@ -330,6 +580,48 @@ func (p *workflowExecutionPersistenceClient) GetCurrentExecution(request *GetCur
 	return response, err
 }
 
+func (p *workflowExecutionPersistenceClient) ListWorkflowExecutionRuns(request *ListWorkflowExecutionRunsRequest) (*ListWorkflowExecutionRunsResponse, error) {
+	p.metricClient.IncCounter(metrics.PersistenceListWorkflowExecutionRunsScope, metrics.PersistenceRequests)
+
+	sw := p.metricClient.StartTimer(metrics.PersistenceListWorkflowExecutionRunsScope, metrics.PersistenceLatency)
+	response, err := p.persistence.ListWorkflowExecutionRuns(request)
+	sw.Stop()
+
+	if err != nil {
+		p.updateErrorMetric(metrics.PersistenceListWorkflowExecutionRunsScope, err)
+	}
+
+	return response, err
+}
+
+func (p *workflowExecutionPersistenceClient) ListCurrentExecutions(request *ListCurrentExecutionsRequest) (*ListCurrentExecutionsResponse, error) {
+	p.metricClient.IncCounter(metrics.PersistenceListCurrentExecutionsScope, metrics.PersistenceRequests)
+
+	sw := p.metricClient.StartTimer(metrics.PersistenceListCurrentExecutionsScope, metrics.PersistenceLatency)
+	response, err := p.persistence.ListCurrentExecutions(request)
+	sw.Stop()
+
+	if err != nil {
+		p.updateErrorMetric(metrics.PersistenceListCurrentExecutionsScope, err)
+	}
+
+	return response, err
+}
+
+func (p *workflowExecutionPersistenceClient) BlockCurrentWorkflowExecution(request *BlockCurrentWorkflowExecutionRequest) error {
+	p.metricClient.IncCounter(metrics.PersistenceBlockCurrentWorkflowExecutionScope, metrics.PersistenceRequests)
+
+	sw := p.metricClient.StartTimer(metrics.PersistenceBlockCurrentWorkflowExecutionScope, metrics.PersistenceLatency)
+	err := p.persistence.BlockCurrentWorkflowExecution(request)
+	sw.Stop()
+
+	if err != nil {
+		p.updateErrorMetric(metrics.PersistenceBlockCurrentWorkflowExecutionScope, err)
+	}
+
+	return err
+}
+
 func (p *workflowExecutionPersistenceClient) GetTransferTasks(request *GetTransferTasksRequest) (*GetTransferTasksResponse, error) {
 	p.metricClient.IncCounter(metrics.PersistenceGetTransferTasksScope, metrics.PersistenceRequests)
 
@@ -386,6 +678,48 @@ func (p *workflowExecutionPersistenceClient) RangeCompleteTransferTask(request *
 	return err
 }
 
+func (p *workflowExecutionPersistenceClient) GetVisibilityTasks(request *GetVisibilityTasksRequest) (*GetVisibilityTasksResponse, error) {
+	p.metricClient.IncCounter(metrics.PersistenceGetVisibilityTasksScope, metrics.PersistenceRequests)
+
+	sw := p.metricClient.StartTimer(metrics.PersistenceGetVisibilityTasksScope, metrics.PersistenceLatency)
+	response, err := p.persistence.GetVisibilityTasks(request)
+	sw.Stop()
+
+	if err != nil {
+		p.updateErrorMetric(metrics.PersistenceGetVisibilityTasksScope, err)
+	}
+
+	return response, err
+}
+
+func (p *workflowExecutionPersistenceClient) CompleteVisibilityTask(request *CompleteVisibilityTaskRequest) error {
+	p.metricClient.IncCounter(metrics.PersistenceCompleteVisibilityTaskScope, metrics.PersistenceRequests)
+
+	sw := p.metricClient.StartTimer(metrics.PersistenceCompleteVisibilityTaskScope, metrics.PersistenceLatency)
+	err := p.persistence.CompleteVisibilityTask(request)
+	sw.Stop()
+
+	if err != nil {
+		p.updateErrorMetric(metrics.PersistenceCompleteVisibilityTaskScope, err)
+	}
+
+	return err
+}
+
+func (p *workflowExecutionPersistenceClient) RangeCompleteVisibilityTask(request *RangeCompleteVisibilityTaskRequest) error {
+	p.metricClient.IncCounter(metrics.PersistenceRangeCompleteVisibilityTaskScope, metrics.PersistenceRequests)
+
+	sw := p.metricClient.StartTimer(metrics.PersistenceRangeCompleteVisibilityTaskScope, metrics.PersistenceLatency)
+	err := p.persistence.RangeCompleteVisibilityTask(request)
+	sw.Stop()
+
+	if err != nil {
+		p.updateErrorMetric(metrics.PersistenceRangeCompleteVisibilityTaskScope, err)
+	}
+
+	return err
+}
+
 func (p *workflowExecutionPersistenceClient) CompleteReplicationTask(request *CompleteReplicationTaskRequest) error {
 	p.metricClient.IncCounter(metrics.PersistenceCompleteReplicationTaskScope, metrics.PersistenceRequests)
 
@@ -400,6 +734,20 @@ func (p *workflowExecutionPersistenceClient) CompleteReplicationTask(request *Co
 	return err
 }
 
+func (p *workflowExecutionPersistenceClient) RangeCompleteReplicationTask(request *RangeCompleteReplicationTaskRequest) error {
+	p.metricClient.IncCounter(metrics.PersistenceRangeCompleteReplicationTaskScope, metrics.PersistenceRequests)
+
+	sw := p.metricClient.StartTimer(metrics.PersistenceRangeCompleteReplicationTaskScope, metrics.PersistenceLatency)
+	err := p.persistence.RangeCompleteReplicationTask(request)
+	sw.Stop()
+
+	if err != nil {
+		p.updateErrorMetric(metrics.PersistenceRangeCompleteReplicationTaskScope, err)
+	}
+
+	return err
+}
+
 func (p *workflowExecutionPersistenceClient) GetTimerIndexTasks(request *GetTimerIndexTasksRequest) (*GetTimerIndexTasksResponse, error) {
 	p.metricClient.IncCounter(metrics.PersistenceGetTimerIndexTasksScope, metrics.PersistenceRequests)
 
@@ -442,6 +790,62 @@ func (p *workflowExecutionPersistenceClient) RangeCompleteTimerTask(request *Ran
 	return err
 }
 
+func (p *workflowExecutionPersistenceClient) GetClosedExecutionIndex(request *GetClosedExecutionIndexRequest) (*GetClosedExecutionIndexResponse, error) {
+	p.metricClient.IncCounter(metrics.PersistenceGetClosedExecutionIndexScope, metrics.PersistenceRequests)
+
+	sw := p.metricClient.StartTimer(metrics.PersistenceGetClosedExecutionIndexScope, metrics.PersistenceLatency)
+	response, err := p.persistence.GetClosedExecutionIndex(request)
+	sw.Stop()
+
+	if err != nil {
+		p.updateErrorMetric(metrics.PersistenceGetClosedExecutionIndexScope, err)
+	}
+
+	return response, err
+}
+
+func (p *workflowExecutionPersistenceClient) DeleteClosedExecutionIndex(request *DeleteClosedExecutionIndexRequest) error {
+	p.metricClient.IncCounter(metrics.PersistenceDeleteClosedExecutionIndexScope, metrics.PersistenceRequests)
+
+	sw := p.metricClient.StartTimer(metrics.PersistenceDeleteClosedExecutionIndexScope, metrics.PersistenceLatency)
+	err := p.persistence.DeleteClosedExecutionIndex(request)
+	sw.Stop()
+
+	if err != nil {
+		p.updateErrorMetric(metrics.PersistenceDeleteClosedExecutionIndexScope, err)
+	}
+
+	return err
+}
+
+func (p *workflowExecutionPersistenceClient) ListBufferedReplicationTasks(request *ListBufferedReplicationTasksRequest) (*ListBufferedReplicationTasksResponse, error) {
+	p.metricClient.IncCounter(metrics.PersistenceListBufferedReplicationTasksScope, metrics.PersistenceRequests)
+
+	sw := p.metricClient.StartTimer(metrics.PersistenceListBufferedReplicationTasksScope, metrics.PersistenceLatency)
+	response, err := p.persistence.ListBufferedReplicationTasks(request)
+	sw.Stop()
+
+	if err != nil {
+		p.updateErrorMetric(metrics.PersistenceListBufferedReplicationTasksScope, err)
+	}
+
+	return response, err
+}
+
+func (p *workflowExecutionPersistenceClient) DeleteBufferedReplicationTasks(request *DeleteBufferedReplicationTasksRequest) error {
+	p.metricClient.IncCounter(metrics.PersistenceDeleteBufferedReplicationTasksScope, metrics.PersistenceRequests)
+
+	sw := p.metricClient.StartTimer(metrics.PersistenceDeleteBufferedReplicationTasksScope, metrics.PersistenceLatency)
+	err := p.persistence.DeleteBufferedReplicationTasks(request)
+	sw.Stop()
+
+	if err != nil {
+		p.updateErrorMetric(metrics.PersistenceDeleteBufferedReplicationTasksScope, err)
+	}
+
+	return err
+}
+
 func (p *workflowExecutionPersistenceClient) updateErrorMetric(scope int, err error) {
 	switch err.(type) {
 	case *WorkflowExecutionAlreadyStartedError:
@@ -454,12 +858,26 @@ func (p *workflowExecutionPersistenceClient) updateErrorMetric(scope int, err er
 		p.metricClient.IncCounter(scope, metrics.PersistenceErrConditionFailedCounter)
 	case *CurrentWorkflowConditionFailedError:
 		p.metricClient.IncCounter(scope, metrics.PersistenceErrCurrentWorkflowConditionFailedCounter)
+	case *TransactionSizeLimitError:
+		p.metricClient.IncCounter(scope, metrics.PersistenceErrTransactionSizeLimitCounter)
 	case *TimeoutError:
 		p.metricClient.IncCounter(scope, metrics.PersistenceErrTimeoutCounter)
 		p.metricClient.IncCounter(scope, metrics.PersistenceFailures)
 	case *workflow.ServiceBusyError:
 		p.metricClient.IncCounter(scope, metrics.PersistenceErrBusyCounter)
 		p.metricClient.IncCounter(scope, metrics.PersistenceFailures)
+	case *StoreOperationError:
+		operationErr := err.(*StoreOperationError)
+		p.logger.Error("Operation failed with internal error.",
+			tag.Error(err),
+			tag.MetricScope(scope),
+			tag.StoreOperationName(operationErr.Operation),
+			tag.StoreTemplate(operationErr.Template),
+			tag.ShardID(operationErr.ShardID),
+			tag.WorkflowDomainID(operationErr.DomainID),
+			tag.WorkflowID(operationErr.WorkflowID),
+			tag.WorkflowRunID(operationErr.RunID))
+		p.metricClient.IncCounter(scope, metrics.PersistenceFailures)
 	default:
 		p.logger.Error("Operation failed with internal error.",
 			tag.Error(err), tag.MetricScope(scope), tag.ShardID(p.GetShardID()))
@@ -475,6 +893,20 @@ func (p *taskPersistenceClient) GetName() string {
 	return p.persistence.GetName()
 }
 
+func (p *taskPersistenceClient) Ping() error {
+	p.metricClient.IncCounter(metrics.PersistencePingScope, metrics.PersistenceRequests)
+
+	sw := p.metricClient.StartTimer(metrics.PersistencePingScope, metrics.PersistenceLatency)
+	err := p.persistence.Ping()
+	sw.Stop()
+
+	if err != nil {
+		p.updateErrorMetric(metrics.PersistencePingScope, err)
+	}
+
+	return err
+}
+
 func (p *taskPersistenceClient) CreateTasks(request *CreateTasksRequest) (*CreateTasksResponse, error) {
 	p.metricClient.IncCounter(metrics.PersistenceCreateTaskScope, metrics.PersistenceRequests)
 
@@ -787,6 +1219,20 @@ func (p *metadataPersistenceClient) GetMetadata() (*GetMetadataResponse, error)
 	return response, err
 }
 
+func (p *metadataPersistenceClient) Ping() error {
+	p.metricClient.IncCounter(metrics.PersistencePingScope, metrics.PersistenceRequests)
+
+	sw := p.metricClient.StartTimer(metrics.PersistencePingScope, metrics.PersistenceLatency)
+	err := p.persistence.Ping()
+	sw.Stop()
+
+	if err != nil {
+		p.updateErrorMetric(metrics.PersistencePingScope, err)
+	}
+
+	return err
+}
+
 func (p *metadataPersistenceClient) Close() {
 	p.persistence.Close()
 }
@@ -1090,6 +1536,18 @@ func (p *historyV2PersistenceClient) ReadHistoryBranchByBatch(request *ReadHisto
 	return response, err
 }
 
+// ReadRawHistoryBranch returns history node data for a branch as persisted
+func (p *historyV2PersistenceClient) ReadRawHistoryBranch(request *ReadHistoryBranchRequest) (*ReadRawHistoryBranchResponse, error) {
+	p.metricClient.IncCounter(metrics.PersistenceReadHistoryBranchScope, metrics.PersistenceRequests)
+	sw := p.metricClient.StartTimer(metrics.PersistenceReadHistoryBranchScope, metrics.PersistenceLatency)
+	response, err := p.persistence.ReadRawHistoryBranch(request)
+	sw.Stop()
+	if err != nil {
+		p.updateErrorMetric(metrics.PersistenceReadHistoryBranchScope, err)
+	}
+	return response, err
+}
+
 // ForkHistoryBranch forks a new branch from a old branch
 func (p *historyV2PersistenceClient) ForkHistoryBranch(request *ForkHistoryBranchRequest) (*ForkHistoryBranchResponse, error) {
 	p.metricClient.IncCounter(metrics.PersistenceForkHistoryBranchScope, metrics.PersistenceRequests)
@@ -0,0 +1,457 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package persistence
+
+import (
+	workflow "github.com/uber/cadence/.gen/go/shared"
+)
+
+type (
+	// PayloadInspector inspects the payloads of the history events and search attributes before they
+	// are persisted. It can scrub them in place based on its policy, or reject the write by returning
+	// an error which is returned to the caller of the persistence API. Visibility records are only
+	// scrubbed, the search attributes it rejects are left out of them instead of failing the write.
+	PayloadInspector interface {
+		// InspectHistoryEvents inspects the events about to be persisted for a workflow of the domain
+		InspectHistoryEvents(domainID string, events []*workflow.HistoryEvent) error
+		// InspectSearchAttributes inspects the search attributes about to be persisted for a workflow of the domain
+		InspectSearchAttributes(domainID string, attributes map[string][]byte) error
+	}
+
+	executionManagerFactoryPayloadInspectionClient struct {
+		inspector   PayloadInspector
+		persistence ExecutionManagerFactory
+	}
+
+	workflowExecutionPayloadInspectionClient struct {
+		inspector   PayloadInspector
+		persistence ExecutionManager
+	}
+
+	historyPayloadInspectionClient struct {
+		inspector   PayloadInspector
+		persistence HistoryManager
+	}
+
+	historyV2PayloadInspectionClient struct {
+		inspector   PayloadInspector
+		persistence HistoryV2Manager
+	}
+
+	visibilityPayloadInspectionClient struct {
+		inspector   PayloadInspector
+		persistence VisibilityManager
+	}
+)
+
+var _ ExecutionManagerFactory = (*executionManagerFactoryPayloadInspectionClient)(nil)
+var _ ExecutionManager = (*workflowExecutionPayloadInspectionClient)(nil)
+var _ HistoryManager = (*historyPayloadInspectionClient)(nil)
+var _ HistoryV2Manager = (*historyV2PayloadInspectionClient)(nil)
+var _ VisibilityManager = (*visibilityPayloadInspectionClient)(nil)
+
+// NewExecutionManagerFactoryPayloadInspectionClient creates a factory of execution managers inspecting
+// the payloads of the mutable state writes
+func NewExecutionManagerFactoryPayloadInspectionClient(persistence ExecutionManagerFactory, inspector PayloadInspector) ExecutionManagerFactory {
+	return &executionManagerFactoryPayloadInspectionClient{
+		inspector:   inspector,
+		persistence: persistence,
+	}
+}
+
+// NewWorkflowExecutionPersistencePayloadInspectionClient creates a client to manage executions that inspects the search attributes and
+// the buffered events of the mutable state writes
+func NewWorkflowExecutionPersistencePayloadInspectionClient(persistence ExecutionManager, inspector PayloadInspector) ExecutionManager {
+	return &workflowExecutionPayloadInspectionClient{
+		inspector:   inspector,
+		persistence: persistence,
+	}
+}
+
+// NewHistoryPersistencePayloadInspectionClient creates a client to manage history that inspects the appended events
+func NewHistoryPersistencePayloadInspectionClient(persistence HistoryManager, inspector PayloadInspector) HistoryManager {
+	return &historyPayloadInspectionClient{
+		inspector:   inspector,
+		persistence: persistence,
+	}
+}
+
+// NewHistoryV2PersistencePayloadInspectionClient creates a client to manage historyV2 that inspects the appended events
+func NewHistoryV2PersistencePayloadInspectionClient(persistence HistoryV2Manager, inspector PayloadInspector) HistoryV2Manager {
+	return &historyV2PayloadInspectionClient{
+		inspector:   inspector,
+		persistence: persistence,
+	}
+}
+
+// NewVisibilityPersistencePayloadInspectionClient creates a client to manage visibility that inspects the recorded search attributes
+func NewVisibilityPersistencePayloadInspectionClient(persistence VisibilityManager, inspector PayloadInspector) VisibilityManager {
+	return &visibilityPayloadInspectionClient{
+		inspector:   inspector,
+		persistence: persistence,
+	}
+}
+
+func (p *executionManagerFactoryPayloadInspectionClient) NewExecutionManager(shardID int) (ExecutionManager, error) {
+	result, err := p.persistence.NewExecutionManager(shardID)
+	if err != nil {
+		return nil, err
+	}
+	return NewWorkflowExecutionPersistencePayloadInspectionClient(result, p.inspector), nil
+}
+
+func (p *executionManagerFactoryPayloadInspectionClient) Close() {
+	p.persistence.Close()
+}
+
+func (p *workflowExecutionPayloadInspectionClient) GetName() string {
+	return p.persistence.GetName()
+}
+
+func (p *workflowExecutionPayloadInspectionClient) GetShardID() int {
+	return p.persistence.GetShardID()
+}
+
+func (p *workflowExecutionPayloadInspectionClient) Ping() error {
+	return p.persistence.Ping()
+}
+
+func (p *workflowExecutionPayloadInspectionClient) CreateWorkflowExecution(request *CreateWorkflowExecutionRequest) (*CreateWorkflowExecutionResponse, error) {
+	if err := p.inspectSnapshot(&request.NewWorkflowSnapshot); err != nil {
+		return nil, err
+	}
+
+	return p.persistence.CreateWorkflowExecution(request)
+}
+
+func (p *workflowExecutionPayloadInspectionClient) GetWorkflowExecution(request *GetWorkflowExecutionRequest) (*GetWorkflowExecutionResponse, error) {
+	return p.persistence.GetWorkflowExecution(request)
+}
+
+func (p *workflowExecutionPayloadInspectionClient) UpdateWorkflowExecution(request *UpdateWorkflowExecutionRequest) (*UpdateWorkflowExecutionResponse, error) {
+	if err := p.inspectMutation(&request.UpdateWorkflowMutation); err != nil {
+		return nil, err
+	}
+	if request.NewWorkflowSnapshot != nil {
+		if err := p.inspectSnapshot(request.NewWorkflowSnapshot); err != nil {
+			return nil, err
+		}
+	}
+
+	return p.persistence.UpdateWorkflowExecution(request)
+}
+
+func (p *workflowExecutionPayloadInspectionClient) ResetMutableState(request *ResetMutableStateRequest) error {
+	if err := p.inspectSnapshot(&request.ResetWorkflowSnapshot); err != nil {
+		return err
+	}
+	if request.CurrentWorkflowMutation != nil {
+		if err := p.inspectMutation(request.CurrentWorkflowMutation); err != nil {
+			return err
+		}
+	}
+
+	return p.persistence.ResetMutableState(request)
+}
+
+func (p *workflowExecutionPayloadInspectionClient) ResetWorkflowExecution(request *ResetWorkflowExecutionRequest) error {
+	if request.CurrentWorkflowMutation != nil {
+		if err := p.inspectMutation(request.CurrentWorkflowMutation); err != nil {
+			return err
+		}
+	}
+	if err := p.inspectSnapshot(&request.NewWorkflowSnapshot); err != nil {
+		return err
+	}
+
+	return p.persistence.ResetWorkflowExecution(request)
+}
+
+func (p *workflowExecutionPayloadInspectionClient) DeleteWorkflowExecution(request *DeleteWorkflowExecutionRequest) error {
+	return p.persistence.DeleteWorkflowExecution(request)
+}
+
+func (p *workflowExecutionPayloadInspectionClient) DeleteCurrentWorkflowExecution(request *DeleteCurrentWorkflowExecutionRequest) (*DeleteCurrentWorkflowExecutionResponse, error) {
+	return p.persistence.DeleteCurrentWorkflowExecution(request)
+}
+
+func (p *workflowExecutionPayloadInspectionClient) GetCurrentExecution(request *GetCurrentExecutionRequest) (*GetCurrentExecutionResponse, error) {
+	return p.persistence.GetCurrentExecution(request)
+}
+
+func (p *workflowExecutionPayloadInspectionClient) ListWorkflowExecutionRuns(request *ListWorkflowExecutionRunsRequest) (*ListWorkflowExecutionRunsResponse, error) {
+	return p.persistence.ListWorkflowExecutionRuns(request)
+}
+
+func (p *workflowExecutionPayloadInspectionClient) ListCurrentExecutions(request *ListCurrentExecutionsRequest) (*ListCurrentExecutionsResponse, error) {
+	return p.persistence.ListCurrentExecutions(request)
+}
+
+func (p *workflowExecutionPayloadInspectionClient) BlockCurrentWorkflowExecution(request *BlockCurrentWorkflowExecutionRequest) error {
+	return p.persistence.BlockCurrentWorkflowExecution(request)
+}
+
+func (p *workflowExecutionPayloadInspectionClient) GetTransferTasks(request *GetTransferTasksRequest) (*GetTransferTasksResponse, error) {
+	return p.persistence.GetTransferTasks(request)
+}
+
+func (p *workflowExecutionPayloadInspectionClient) CompleteTransferTask(request *CompleteTransferTaskRequest) error {
+	return p.persistence.CompleteTransferTask(request)
+}
+
+func (p *workflowExecutionPayloadInspectionClient) RangeCompleteTransferTask(request *RangeCompleteTransferTaskRequest) error {
+	return p.persistence.RangeCompleteTransferTask(request)
+}
+
+func (p *workflowExecutionPayloadInspectionClient) GetVisibilityTasks(request *GetVisibilityTasksRequest) (*GetVisibilityTasksResponse, error) {
+	return p.persistence.GetVisibilityTasks(request)
+}
+
+func (p *workflowExecutionPayloadInspectionClient) CompleteVisibilityTask(request *CompleteVisibilityTaskRequest) error {
+	return p.persistence.CompleteVisibilityTask(request)
+}
+
+func (p *workflowExecutionPayloadInspectionClient) RangeCompleteVisibilityTask(request *RangeCompleteVisibilityTaskRequest) error {
+	return p.persistence.RangeCompleteVisibilityTask(request)
+}
+
+func (p *workflowExecutionPayloadInspectionClient) GetReplicationTasks(request *GetReplicationTasksRequest) (*GetReplicationTasksResponse, error) {
+	return p.persistence.GetReplicationTasks(request)
+}
+
+func (p *workflowExecutionPayloadInspectionClient) CompleteReplicationTask(request *CompleteReplicationTaskRequest) error {
+	return p.persistence.CompleteReplicationTask(request)
+}
+
+func (p *workflowExecutionPayloadInspectionClient) RangeCompleteReplicationTask(request *RangeCompleteReplicationTaskRequest) error {
+	return p.persistence.RangeCompleteReplicationTask(request)
+}
+
+func (p *workflowExecutionPayloadInspectionClient) GetTimerIndexTasks(request *GetTimerIndexTasksRequest) (*GetTimerIndexTasksResponse, error) {
+	return p.persistence.GetTimerIndexTasks(request)
+}
+
+func (p *workflowExecutionPayloadInspectionClient) CompleteTimerTask(request *CompleteTimerTaskRequest) error {
+	return p.persistence.CompleteTimerTask(request)
+}
+
+func (p *workflowExecutionPayloadInspectionClient) RangeCompleteTimerTask(request *RangeCompleteTimerTaskRequest) error {
+	return p.persistence.RangeCompleteTimerTask(request)
+}
+
+func (p *workflowExecutionPayloadInspectionClient) GetClosedExecutionIndex(request *GetClosedExecutionIndexRequest) (*GetClosedExecutionIndexResponse, error) {
+	return p.persistence.GetClosedExecutionIndex(request)
+}
+
+func (p *workflowExecutionPayloadInspectionClient) DeleteClosedExecutionIndex(request *DeleteClosedExecutionIndexRequest) error {
+	return p.persistence.DeleteClosedExecutionIndex(request)
+}
+
+func (p *workflowExecutionPayloadInspectionClient) ListBufferedReplicationTasks(request *ListBufferedReplicationTasksRequest) (*ListBufferedReplicationTasksResponse, error) {
+	return p.persistence.ListBufferedReplicationTasks(request)
+}
+
+func (p *workflowExecutionPayloadInspectionClient) DeleteBufferedReplicationTasks(request *DeleteBufferedReplicationTasksRequest) error {
+	return p.persistence.DeleteBufferedReplicationTasks(request)
+}
+
+func (p *workflowExecutionPayloadInspectionClient) Close() {
+	p.persistence.Close()
+}
+
+func (p *workflowExecutionPayloadInspectionClient) inspectSnapshot(snapshot *WorkflowSnapshot) error {
+	return inspectSearchAttributes(p.inspector, snapshot.ExecutionInfo.DomainID, snapshot.ExecutionInfo.SearchAttributes)
+}
+
+func (p *workflowExecutionPayloadInspectionClient) inspectMutation(mutation *WorkflowMutation) error {
+	domainID := mutation.ExecutionInfo.DomainID
+	if err := inspectSearchAttributes(p.inspector, domainID, mutation.ExecutionInfo.SearchAttributes); err != nil {
+		return err
+	}
+	return inspectHistoryEvents(p.inspector, domainID, mutation.NewBufferedEvents)
+}
+
+func (p *historyPayloadInspectionClient) GetName() string {
+	return p.persistence.GetName()
+}
+
+func (p *historyPayloadInspectionClient) AppendHistoryEvents(request *AppendHistoryEventsRequest) (*AppendHistoryEventsResponse, error) {
+	if err := inspectHistoryEvents(p.inspector, request.DomainID, request.Events); err != nil {
+		return nil, err
+	}
+
+	return p.persistence.AppendHistoryEvents(request)
+}
+
+func (p *historyPayloadInspectionClient) GetWorkflowExecutionHistory(request *GetWorkflowExecutionHistoryRequest) (*GetWorkflowExecutionHistoryResponse, error) {
+	return p.persistence.GetWorkflowExecutionHistory(request)
+}
+
+func (p *historyPayloadInspectionClient) GetWorkflowExecutionHistoryByBatch(request *GetWorkflowExecutionHistoryRequest) (*GetWorkflowExecutionHistoryByBatchResponse, error) {
+	return p.persistence.GetWorkflowExecutionHistoryByBatch(request)
+}
+
+func (p *historyPayloadInspectionClient) DeleteWorkflowExecutionHistory(request *DeleteWorkflowExecutionHistoryRequest) error {
+	return p.persistence.DeleteWorkflowExecutionHistory(request)
+}
+
+func (p *historyPayloadInspectionClient) Close() {
+	p.persistence.Close()
+}
+
+func (p *historyV2PayloadInspectionClient) GetName() string {
+	return p.persistence.GetName()
+}
+
+func (p *historyV2PayloadInspectionClient) AppendHistoryNodes(request *AppendHistoryNodesRequest) (*AppendHistoryNodesResponse, error) {
+	if err := inspectHistoryEvents(p.inspector, request.DomainID, request.Events); err != nil {
+		return nil, err
+	}
+
+	return p.persistence.AppendHistoryNodes(request)
+}
+
+func (p *historyV2PayloadInspectionClient) ReadHistoryBranch(request *ReadHistoryBranchRequest) (*ReadHistoryBranchResponse, error) {
+	return p.persistence.ReadHistoryBranch(request)
+}
+
+func (p *historyV2PayloadInspectionClient) ReadHistoryBranchByBatch(request *ReadHistoryBranchRequest) (*ReadHistoryBranchByBatchResponse, error) {
+	return p.persistence.ReadHistoryBranchByBatch(request)
+}
+
+func (p *historyV2PayloadInspectionClient) ReadRawHistoryBranch(request *ReadHistoryBranchRequest) (*ReadRawHistoryBranchResponse, error) {
+	return p.persistence.ReadRawHistoryBranch(request)
+}
+
+func (p *historyV2PayloadInspectionClient) ForkHistoryBranch(request *ForkHistoryBranchRequest) (*ForkHistoryBranchResponse, error) {
+	return p.persistence.ForkHistoryBranch(request)
+}
+
+func (p *historyV2PayloadInspectionClient) CompleteForkBranch(request *CompleteForkBranchRequest) error {
+	return p.persistence.CompleteForkBranch(request)
+}
+
+func (p *historyV2PayloadInspectionClient) DeleteHistoryBranch(request *DeleteHistoryBranchRequest) error {
+	return p.persistence.DeleteHistoryBranch(request)
+}
+
+func (p *historyV2PayloadInspectionClient) GetHistoryTree(request *GetHistoryTreeRequest) (*GetHistoryTreeResponse, error) {
+	return p.persistence.GetHistoryTree(request)
+}
+
+func (p *historyV2PayloadInspectionClient) Close() {
+	p.persistence.Close()
+}
+
+func (p *visibilityPayloadInspectionClient) GetName() string {
+	return p.persistence.GetName()
+}
+
+func (p *visibilityPayloadInspectionClient) RecordWorkflowExecutionStarted(request *RecordWorkflowExecutionStartedRequest) error {
+	request.SearchAttributes = scrubSearchAttributes(p.inspector, request.DomainUUID, request.SearchAttributes)
+	return p.persistence.RecordWorkflowExecutionStarted(request)
+}
+
+func (p *visibilityPayloadInspectionClient) RecordWorkflowExecutionClosed(request *RecordWorkflowExecutionClosedRequest) error {
+	request.SearchAttributes = scrubSearchAttributes(p.inspector, request.DomainUUID, request.SearchAttributes)
+	return p.persistence.RecordWorkflowExecutionClosed(request)
+}
+
+func (p *visibilityPayloadInspectionClient) UpsertWorkflowExecution(request *UpsertWorkflowExecutionRequest) error {
+	request.SearchAttributes = scrubSearchAttributes(p.inspector, request.DomainUUID, request.SearchAttributes)
+	return p.persistence.UpsertWorkflowExecution(request)
+}
+
+func (p *visibilityPayloadInspectionClient) ListOpenWorkflowExecutions(request *ListWorkflowExecutionsRequest) (*ListWorkflowExecutionsResponse, error) {
+	return p.persistence.ListOpenWorkflowExecutions(request)
+}
+
+func (p *visibilityPayloadInspectionClient) ListClosedWorkflowExecutions(request *ListWorkflowExecutionsRequest) (*ListWorkflowExecutionsResponse, error) {
+	return p.persistence.ListClosedWorkflowExecutions(request)
+}
+
+func (p *visibilityPayloadInspectionClient) ListOpenWorkflowExecutionsByType(request *ListWorkflowExecutionsByTypeRequest) (*ListWorkflowExecutionsResponse, error) {
+	return p.persistence.ListOpenWorkflowExecutionsByType(request)
+}
+
+func (p *visibilityPayloadInspectionClient) ListClosedWorkflowExecutionsByType(request *ListWorkflowExecutionsByTypeRequest) (*ListWorkflowExecutionsResponse, error) {
+	return p.persistence.ListClosedWorkflowExecutionsByType(request)
+}
+
+func (p *visibilityPayloadInspectionClient) ListOpenWorkflowExecutionsByWorkflowID(request *ListWorkflowExecutionsByWorkflowIDRequest) (*ListWorkflowExecutionsResponse, error) {
+	return p.persistence.ListOpenWorkflowExecutionsByWorkflowID(request)
+}
+
+func (p *visibilityPayloadInspectionClient) ListClosedWorkflowExecutionsByWorkflowID(request *ListWorkflowExecutionsByWorkflowIDRequest) (*ListWorkflowExecutionsResponse, error) {
+	return p.persistence.ListClosedWorkflowExecutionsByWorkflowID(request)
+}
+
+func (p *visibilityPayloadInspectionClient) ListClosedWorkflowExecutionsByStatus(request *ListClosedWorkflowExecutionsByStatusRequest) (*ListWorkflowExecutionsResponse, error) {
+	return p.persistence.ListClosedWorkflowExecutionsByStatus(request)
+}
+
+func (p *visibilityPayloadInspectionClient) GetClosedWorkflowExecution(request *GetClosedWorkflowExecutionRequest) (*GetClosedWorkflowExecutionResponse, error) {
+	return p.persistence.GetClosedWorkflowExecution(request)
+}
+
+func (p *visibilityPayloadInspectionClient) DeleteWorkflowExecution(request *VisibilityDeleteWorkflowExecutionRequest) error {
+	return p.persistence.DeleteWorkflowExecution(request)
+}
+
+func (p *visibilityPayloadInspectionClient) ListWorkflowExecutions(request *ListWorkflowExecutionsRequestV2) (*ListWorkflowExecutionsResponse, error) {
+	return p.persistence.ListWorkflowExecutions(request)
+}
+
+func (p *visibilityPayloadInspectionClient) ScanWorkflowExecutions(request *ListWorkflowExecutionsRequestV2) (*ListWorkflowExecutionsResponse, error) {
+	return p.persistence.ScanWorkflowExecutions(request)
+}
+
+func (p *visibilityPayloadInspectionClient) CountWorkflowExecutions(request *CountWorkflowExecutionsRequest) (*CountWorkflowExecutionsResponse, error) {
+	return p.persistence.CountWorkflowExecutions(request)
+}
+
+func (p *visibilityPayloadInspectionClient) Close() {
+	p.persistence.Close()
+}
+
+func inspectHistoryEvents(inspector PayloadInspector, domainID string, events []*workflow.HistoryEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+	return inspector.InspectHistoryEvents(domainID, events)
+}
+
+func inspectSearchAttributes(inspector PayloadInspector, domainID string, attributes map[string][]byte) error {
+	if len(attributes) == 0 {
+		return nil
+	}
+	return inspector.InspectSearchAttributes(domainID, attributes)
+}
+
+// scrubSearchAttributes returns the search attributes to record to visibility. Visibility records are written by
+// the task queues after the mutable state committed, rejecting them would have the task retried forever, so
+// the search attributes are dropped from the record when the inspector rejects them.
+func scrubSearchAttributes(inspector PayloadInspector, domainID string, attributes map[string][]byte) map[string][]byte {
+	if err := inspectSearchAttributes(inspector, domainID, attributes); err != nil {
+		return nil
+	}
+	return attributes
+}
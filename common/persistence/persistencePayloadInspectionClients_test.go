@@ -0,0 +1,177 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package persistence
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	workflow "github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/log"
+	"github.com/uber/cadence/common/log/loggerimpl"
+	"github.com/uber/cadence/common/service/config"
+)
+
+type (
+	payloadInspectionClientsSuite struct {
+		suite.Suite
+		*require.Assertions
+	}
+
+	testPayloadInspector struct {
+		domainIDs []string
+	}
+
+	testVisibilityManager struct {
+		VisibilityManager
+		started []*RecordWorkflowExecutionStartedRequest
+	}
+
+	testHistoryV2Manager struct {
+		HistoryV2Manager
+		appended []*AppendHistoryNodesRequest
+	}
+)
+
+var errTestPayloadRejected = &workflow.BadRequestError{Message: "payload rejected"}
+
+func TestPayloadInspectionClientsSuite(t *testing.T) {
+	suite.Run(t, new(payloadInspectionClientsSuite))
+}
+
+func (s *payloadInspectionClientsSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+}
+
+func (s *payloadInspectionClientsSuite) TestVisibilitySearchAttributes() {
+	inspector := &testPayloadInspector{}
+	visibility := &testVisibilityManager{}
+	client := NewVisibilityPersistencePayloadInspectionClient(visibility, inspector)
+
+	request := &RecordWorkflowExecutionStartedRequest{
+		DomainUUID:       "domain-id",
+		SearchAttributes: map[string][]byte{"CustomStringField": []byte(`"ssn"`), "CustomIntField": []byte("1")},
+	}
+	s.NoError(client.RecordWorkflowExecutionStarted(request))
+	s.Equal([]string{"domain-id"}, inspector.domainIDs)
+	s.Len(visibility.started, 1)
+	s.Equal(map[string][]byte{"CustomIntField": []byte("1")}, visibility.started[0].SearchAttributes)
+
+	// rejected search attributes are left out of the record instead of failing the write
+	request.SearchAttributes = map[string][]byte{"CustomKeywordField": []byte(`"reject"`)}
+	s.NoError(client.RecordWorkflowExecutionStarted(request))
+	s.Len(visibility.started, 2)
+	s.Nil(visibility.started[1].SearchAttributes)
+
+	// writes without search attributes are not inspected
+	s.NoError(client.RecordWorkflowExecutionStarted(&RecordWorkflowExecutionStartedRequest{DomainUUID: "other-domain-id"}))
+	s.Len(inspector.domainIDs, 2)
+	s.Len(visibility.started, 3)
+}
+
+func (s *payloadInspectionClientsSuite) TestHistoryV2Events() {
+	inspector := &testPayloadInspector{}
+	historyV2 := &testHistoryV2Manager{}
+	client := NewHistoryV2PersistencePayloadInspectionClient(historyV2, inspector)
+
+	request := &AppendHistoryNodesRequest{
+		DomainID: "domain-id",
+		Events: []*workflow.HistoryEvent{{
+			EventId:   common.Int64Ptr(common.FirstEventID),
+			EventType: workflow.EventTypeWorkflowExecutionStarted.Ptr(),
+			WorkflowExecutionStartedEventAttributes: &workflow.WorkflowExecutionStartedEventAttributes{
+				Input: []byte("ssn"),
+			},
+		}},
+	}
+	_, err := client.AppendHistoryNodes(request)
+	s.NoError(err)
+	s.Equal([]string{"domain-id"}, inspector.domainIDs)
+	s.Len(historyV2.appended, 1)
+	s.Equal([]byte("***"), historyV2.appended[0].Events[0].WorkflowExecutionStartedEventAttributes.Input)
+
+	request.Events[0].WorkflowExecutionStartedEventAttributes.Input = []byte("reject")
+	_, err = client.AppendHistoryNodes(request)
+	s.Equal(errTestPayloadRejected, err)
+	s.Len(historyV2.appended, 1)
+}
+
+func (s *payloadInspectionClientsSuite) TestRegisterPayloadInspector() {
+	var options map[string]string
+	RegisterPayloadInspector("registry-test", func(cfg config.PayloadInspectorConfig, logger log.Logger) (PayloadInspector, error) {
+		options = cfg.Options
+		return &testPayloadInspector{}, nil
+	})
+	s.Panics(func() {
+		RegisterPayloadInspector("registry-test", func(config.PayloadInspectorConfig, log.Logger) (PayloadInspector, error) {
+			return nil, nil
+		})
+	})
+	s.Panics(func() { RegisterPayloadInspector("registry-test-nil", nil) })
+
+	logger := loggerimpl.NewNopLogger()
+	cfg := config.PayloadInspectorConfig{Name: "registry-test", Options: map[string]string{"policy": "scrub"}}
+	inspector, err := NewPayloadInspector(cfg, logger)
+	s.NoError(err)
+	s.NotNil(inspector)
+	s.Equal(cfg.Options, options)
+
+	_, err = NewPayloadInspector(config.PayloadInspectorConfig{Name: "registry-test-missing"}, logger)
+	s.Error(err)
+}
+
+// InspectHistoryEvents masks the input of the started events and rejects the ones with a reject input
+func (i *testPayloadInspector) InspectHistoryEvents(domainID string, events []*workflow.HistoryEvent) error {
+	i.domainIDs = append(i.domainIDs, domainID)
+	for _, event := range events {
+		attributes := event.WorkflowExecutionStartedEventAttributes
+		if attributes == nil {
+			continue
+		}
+		if string(attributes.Input) == "reject" {
+			return errTestPayloadRejected
+		}
+		attributes.Input = []byte("***")
+	}
+	return nil
+}
+
+// InspectSearchAttributes removes the string attributes and rejects the keyword ones
+func (i *testPayloadInspector) InspectSearchAttributes(domainID string, attributes map[string][]byte) error {
+	i.domainIDs = append(i.domainIDs, domainID)
+	if _, ok := attributes["CustomKeywordField"]; ok {
+		return errTestPayloadRejected
+	}
+	delete(attributes, "CustomStringField")
+	return nil
+}
+
+func (m *testVisibilityManager) RecordWorkflowExecutionStarted(request *RecordWorkflowExecutionStartedRequest) error {
+	m.started = append(m.started, request)
+	return nil
+}
+
+func (m *testHistoryV2Manager) AppendHistoryNodes(request *AppendHistoryNodesRequest) (*AppendHistoryNodesResponse, error) {
+	m.appended = append(m.appended, request)
+	return &AppendHistoryNodesResponse{}, nil
+}
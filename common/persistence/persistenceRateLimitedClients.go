@@ -40,6 +40,24 @@ type (
 		logger      log.Logger
 	}
 
+	clusterMembershipRateLimitedPersistenceClient struct {
+		rateLimiter tokenbucket.TokenBucket
+		persistence ClusterMembershipManager
+		logger      log.Logger
+	}
+
+	auditRateLimitedPersistenceClient struct {
+		rateLimiter tokenbucket.TokenBucket
+		persistence AuditManager
+		logger      log.Logger
+	}
+
+	scheduleRateLimitedPersistenceClient struct {
+		rateLimiter tokenbucket.TokenBucket
+		persistence ScheduleManager
+		logger      log.Logger
+	}
+
 	workflowExecutionRateLimitedPersistenceClient struct {
 		rateLimiter tokenbucket.TokenBucket
 		persistence ExecutionManager
@@ -78,6 +96,9 @@ type (
 )
 
 var _ ShardManager = (*shardRateLimitedPersistenceClient)(nil)
+var _ ClusterMembershipManager = (*clusterMembershipRateLimitedPersistenceClient)(nil)
+var _ AuditManager = (*auditRateLimitedPersistenceClient)(nil)
+var _ ScheduleManager = (*scheduleRateLimitedPersistenceClient)(nil)
 var _ ExecutionManager = (*workflowExecutionRateLimitedPersistenceClient)(nil)
 var _ TaskManager = (*taskRateLimitedPersistenceClient)(nil)
 var _ HistoryManager = (*historyRateLimitedPersistenceClient)(nil)
@@ -94,6 +115,33 @@ func NewShardPersistenceRateLimitedClient(persistence ShardManager, rateLimiter
 	}
 }
 
+// NewClusterMembershipPersistenceRateLimitedClient creates a client to manage cluster membership
+func NewClusterMembershipPersistenceRateLimitedClient(persistence ClusterMembershipManager, rateLimiter tokenbucket.TokenBucket, logger log.Logger) ClusterMembershipManager {
+	return &clusterMembershipRateLimitedPersistenceClient{
+		persistence: persistence,
+		rateLimiter: rateLimiter,
+		logger:      logger,
+	}
+}
+
+// NewAuditPersistenceRateLimitedClient creates a client to manage the audit log
+func NewAuditPersistenceRateLimitedClient(persistence AuditManager, rateLimiter tokenbucket.TokenBucket, logger log.Logger) AuditManager {
+	return &auditRateLimitedPersistenceClient{
+		persistence: persistence,
+		rateLimiter: rateLimiter,
+		logger:      logger,
+	}
+}
+
+// NewSchedulePersistenceRateLimitedClient creates a client to manage schedules
+func NewSchedulePersistenceRateLimitedClient(persistence ScheduleManager, rateLimiter tokenbucket.TokenBucket, logger log.Logger) ScheduleManager {
+	return &scheduleRateLimitedPersistenceClient{
+		persistence: persistence,
+		rateLimiter: rateLimiter,
+		logger:      logger,
+	}
+}
+
 // NewWorkflowExecutionPersistenceRateLimitedClient creates a client to manage executions
 func NewWorkflowExecutionPersistenceRateLimitedClient(persistence ExecutionManager, rateLimiter tokenbucket.TokenBucket, logger log.Logger) ExecutionManager {
 	return &workflowExecutionRateLimitedPersistenceClient{
@@ -183,6 +231,111 @@ func (p *shardRateLimitedPersistenceClient) Close() {
 	p.persistence.Close()
 }
 
+func (p *clusterMembershipRateLimitedPersistenceClient) GetName() string {
+	return p.persistence.GetName()
+}
+
+func (p *clusterMembershipRateLimitedPersistenceClient) UpsertClusterMembership(request *UpsertClusterMembershipRequest) error {
+	if ok, _ := p.rateLimiter.TryConsume(1); !ok {
+		return ErrPersistenceLimitExceeded
+	}
+
+	err := p.persistence.UpsertClusterMembership(request)
+	return err
+}
+
+func (p *clusterMembershipRateLimitedPersistenceClient) GetClusterMembers(request *GetClusterMembersRequest) (*GetClusterMembersResponse, error) {
+	if ok, _ := p.rateLimiter.TryConsume(1); !ok {
+		return nil, ErrPersistenceLimitExceeded
+	}
+
+	response, err := p.persistence.GetClusterMembers(request)
+	return response, err
+}
+
+func (p *clusterMembershipRateLimitedPersistenceClient) PruneClusterMembership(request *PruneClusterMembershipRequest) error {
+	if ok, _ := p.rateLimiter.TryConsume(1); !ok {
+		return ErrPersistenceLimitExceeded
+	}
+
+	err := p.persistence.PruneClusterMembership(request)
+	return err
+}
+
+func (p *clusterMembershipRateLimitedPersistenceClient) Close() {
+	p.persistence.Close()
+}
+
+func (p *auditRateLimitedPersistenceClient) GetName() string {
+	return p.persistence.GetName()
+}
+
+func (p *auditRateLimitedPersistenceClient) RecordAuditEntry(request *RecordAuditEntryRequest) error {
+	if ok, _ := p.rateLimiter.TryConsume(1); !ok {
+		return ErrPersistenceLimitExceeded
+	}
+
+	err := p.persistence.RecordAuditEntry(request)
+	return err
+}
+
+func (p *auditRateLimitedPersistenceClient) Close() {
+	p.persistence.Close()
+}
+
+func (p *scheduleRateLimitedPersistenceClient) GetName() string {
+	return p.persistence.GetName()
+}
+
+func (p *scheduleRateLimitedPersistenceClient) CreateSchedule(request *CreateScheduleRequest) error {
+	if ok, _ := p.rateLimiter.TryConsume(1); !ok {
+		return ErrPersistenceLimitExceeded
+	}
+
+	err := p.persistence.CreateSchedule(request)
+	return err
+}
+
+func (p *scheduleRateLimitedPersistenceClient) GetSchedule(request *GetScheduleRequest) (*GetScheduleResponse, error) {
+	if ok, _ := p.rateLimiter.TryConsume(1); !ok {
+		return nil, ErrPersistenceLimitExceeded
+	}
+
+	response, err := p.persistence.GetSchedule(request)
+	return response, err
+}
+
+func (p *scheduleRateLimitedPersistenceClient) UpdateSchedule(request *UpdateScheduleRequest) error {
+	if ok, _ := p.rateLimiter.TryConsume(1); !ok {
+		return ErrPersistenceLimitExceeded
+	}
+
+	err := p.persistence.UpdateSchedule(request)
+	return err
+}
+
+func (p *scheduleRateLimitedPersistenceClient) DeleteSchedule(request *DeleteScheduleRequest) error {
+	if ok, _ := p.rateLimiter.TryConsume(1); !ok {
+		return ErrPersistenceLimitExceeded
+	}
+
+	err := p.persistence.DeleteSchedule(request)
+	return err
+}
+
+func (p *scheduleRateLimitedPersistenceClient) ListSchedules(request *ListSchedulesRequest) (*ListSchedulesResponse, error) {
+	if ok, _ := p.rateLimiter.TryConsume(1); !ok {
+		return nil, ErrPersistenceLimitExceeded
+	}
+
+	response, err := p.persistence.ListSchedules(request)
+	return response, err
+}
+
+func (p *scheduleRateLimitedPersistenceClient) Close() {
+	p.persistence.Close()
+}
+
 func (p *workflowExecutionRateLimitedPersistenceClient) GetName() string {
 	return p.persistence.GetName()
 }
@@ -191,6 +344,11 @@ func (p *workflowExecutionRateLimitedPersistenceClient) GetShardID() int {
 	return p.persistence.GetShardID()
 }
 
+// Ping is not rate limited, a throttled health check would report the store as unreachable
+func (p *workflowExecutionRateLimitedPersistenceClient) Ping() error {
+	return p.persistence.Ping()
+}
+
 func (p *workflowExecutionRateLimitedPersistenceClient) CreateWorkflowExecution(request *CreateWorkflowExecutionRequest) (*CreateWorkflowExecutionResponse, error) {
 	if ok, _ := p.rateLimiter.TryConsume(1); !ok {
 		return nil, ErrPersistenceLimitExceeded
@@ -254,13 +412,13 @@ func (p *workflowExecutionRateLimitedPersistenceClient) DeleteWorkflowExecution(
 	return err
 }
 
-func (p *workflowExecutionRateLimitedPersistenceClient) DeleteCurrentWorkflowExecution(request *DeleteCurrentWorkflowExecutionRequest) error {
+func (p *workflowExecutionRateLimitedPersistenceClient) DeleteCurrentWorkflowExecution(request *DeleteCurrentWorkflowExecutionRequest) (*DeleteCurrentWorkflowExecutionResponse, error) {
 	if ok, _ := p.rateLimiter.TryConsume(1); !ok {
-		return ErrPersistenceLimitExceeded
+		return nil, ErrPersistenceLimitExceeded
 	}
 
-	err := p.persistence.DeleteCurrentWorkflowExecution(request)
-	return err
+	response, err := p.persistence.DeleteCurrentWorkflowExecution(request)
+	return response, err
 }
 
 func (p *workflowExecutionRateLimitedPersistenceClient) GetCurrentExecution(request *GetCurrentExecutionRequest) (*GetCurrentExecutionResponse, error) {
@@ -272,6 +430,33 @@ func (p *workflowExecutionRateLimitedPersistenceClient) GetCurrentExecution(requ
 	return response, err
 }
 
+func (p *workflowExecutionRateLimitedPersistenceClient) ListWorkflowExecutionRuns(request *ListWorkflowExecutionRunsRequest) (*ListWorkflowExecutionRunsResponse, error) {
+	if ok, _ := p.rateLimiter.TryConsume(1); !ok {
+		return nil, ErrPersistenceLimitExceeded
+	}
+
+	response, err := p.persistence.ListWorkflowExecutionRuns(request)
+	return response, err
+}
+
+func (p *workflowExecutionRateLimitedPersistenceClient) ListCurrentExecutions(request *ListCurrentExecutionsRequest) (*ListCurrentExecutionsResponse, error) {
+	if ok, _ := p.rateLimiter.TryConsume(1); !ok {
+		return nil, ErrPersistenceLimitExceeded
+	}
+
+	response, err := p.persistence.ListCurrentExecutions(request)
+	return response, err
+}
+
+func (p *workflowExecutionRateLimitedPersistenceClient) BlockCurrentWorkflowExecution(request *BlockCurrentWorkflowExecutionRequest) error {
+	if ok, _ := p.rateLimiter.TryConsume(1); !ok {
+		return ErrPersistenceLimitExceeded
+	}
+
+	err := p.persistence.BlockCurrentWorkflowExecution(request)
+	return err
+}
+
 func (p *workflowExecutionRateLimitedPersistenceClient) GetTransferTasks(request *GetTransferTasksRequest) (*GetTransferTasksResponse, error) {
 	if ok, _ := p.rateLimiter.TryConsume(1); !ok {
 		return nil, ErrPersistenceLimitExceeded
@@ -308,6 +493,33 @@ func (p *workflowExecutionRateLimitedPersistenceClient) RangeCompleteTransferTas
 	return err
 }
 
+func (p *workflowExecutionRateLimitedPersistenceClient) GetVisibilityTasks(request *GetVisibilityTasksRequest) (*GetVisibilityTasksResponse, error) {
+	if ok, _ := p.rateLimiter.TryConsume(1); !ok {
+		return nil, ErrPersistenceLimitExceeded
+	}
+
+	response, err := p.persistence.GetVisibilityTasks(request)
+	return response, err
+}
+
+func (p *workflowExecutionRateLimitedPersistenceClient) CompleteVisibilityTask(request *CompleteVisibilityTaskRequest) error {
+	if ok, _ := p.rateLimiter.TryConsume(1); !ok {
+		return ErrPersistenceLimitExceeded
+	}
+
+	err := p.persistence.CompleteVisibilityTask(request)
+	return err
+}
+
+func (p *workflowExecutionRateLimitedPersistenceClient) RangeCompleteVisibilityTask(request *RangeCompleteVisibilityTaskRequest) error {
+	if ok, _ := p.rateLimiter.TryConsume(1); !ok {
+		return ErrPersistenceLimitExceeded
+	}
+
+	err := p.persistence.RangeCompleteVisibilityTask(request)
+	return err
+}
+
 func (p *workflowExecutionRateLimitedPersistenceClient) CompleteReplicationTask(request *CompleteReplicationTaskRequest) error {
 	if ok, _ := p.rateLimiter.TryConsume(1); !ok {
 		return ErrPersistenceLimitExceeded
@@ -317,6 +529,15 @@ func (p *workflowExecutionRateLimitedPersistenceClient) CompleteReplicationTask(
 	return err
 }
 
+func (p *workflowExecutionRateLimitedPersistenceClient) RangeCompleteReplicationTask(request *RangeCompleteReplicationTaskRequest) error {
+	if ok, _ := p.rateLimiter.TryConsume(1); !ok {
+		return ErrPersistenceLimitExceeded
+	}
+
+	err := p.persistence.RangeCompleteReplicationTask(request)
+	return err
+}
+
 func (p *workflowExecutionRateLimitedPersistenceClient) GetTimerIndexTasks(request *GetTimerIndexTasksRequest) (*GetTimerIndexTasksResponse, error) {
 	if ok, _ := p.rateLimiter.TryConsume(1); !ok {
 		return nil, ErrPersistenceLimitExceeded
@@ -344,6 +565,42 @@ func (p *workflowExecutionRateLimitedPersistenceClient) RangeCompleteTimerTask(r
 	return err
 }
 
+func (p *workflowExecutionRateLimitedPersistenceClient) GetClosedExecutionIndex(request *GetClosedExecutionIndexRequest) (*GetClosedExecutionIndexResponse, error) {
+	if ok, _ := p.rateLimiter.TryConsume(1); !ok {
+		return nil, ErrPersistenceLimitExceeded
+	}
+
+	response, err := p.persistence.GetClosedExecutionIndex(request)
+	return response, err
+}
+
+func (p *workflowExecutionRateLimitedPersistenceClient) DeleteClosedExecutionIndex(request *DeleteClosedExecutionIndexRequest) error {
+	if ok, _ := p.rateLimiter.TryConsume(1); !ok {
+		return ErrPersistenceLimitExceeded
+	}
+
+	err := p.persistence.DeleteClosedExecutionIndex(request)
+	return err
+}
+
+func (p *workflowExecutionRateLimitedPersistenceClient) ListBufferedReplicationTasks(request *ListBufferedReplicationTasksRequest) (*ListBufferedReplicationTasksResponse, error) {
+	if ok, _ := p.rateLimiter.TryConsume(1); !ok {
+		return nil, ErrPersistenceLimitExceeded
+	}
+
+	response, err := p.persistence.ListBufferedReplicationTasks(request)
+	return response, err
+}
+
+func (p *workflowExecutionRateLimitedPersistenceClient) DeleteBufferedReplicationTasks(request *DeleteBufferedReplicationTasksRequest) error {
+	if ok, _ := p.rateLimiter.TryConsume(1); !ok {
+		return ErrPersistenceLimitExceeded
+	}
+
+	err := p.persistence.DeleteBufferedReplicationTasks(request)
+	return err
+}
+
 func (p *workflowExecutionRateLimitedPersistenceClient) Close() {
 	p.persistence.Close()
 }
@@ -352,6 +609,11 @@ func (p *taskRateLimitedPersistenceClient) GetName() string {
 	return p.persistence.GetName()
 }
 
+// Ping is not rate limited, a throttled health check would report the store as unreachable
+func (p *taskRateLimitedPersistenceClient) Ping() error {
+	return p.persistence.Ping()
+}
+
 func (p *taskRateLimitedPersistenceClient) CreateTasks(request *CreateTasksRequest) (*CreateTasksResponse, error) {
 	if ok, _ := p.rateLimiter.TryConsume(1); !ok {
 		return nil, ErrPersistenceLimitExceeded
@@ -533,6 +795,11 @@ func (p *metadataRateLimitedPersistenceClient) GetMetadata() (*GetMetadataRespon
 	return response, err
 }
 
+// Ping is not rate limited, a throttled health check would report the store as unreachable
+func (p *metadataRateLimitedPersistenceClient) Ping() error {
+	return p.persistence.Ping()
+}
+
 func (p *metadataRateLimitedPersistenceClient) Close() {
 	p.persistence.Close()
 }
@@ -706,6 +973,15 @@ func (p *historyV2RateLimitedPersistenceClient) ReadHistoryBranchByBatch(request
 	return response, err
 }
 
+// ReadRawHistoryBranch returns history node data for a branch as persisted
+func (p *historyV2RateLimitedPersistenceClient) ReadRawHistoryBranch(request *ReadHistoryBranchRequest) (*ReadRawHistoryBranchResponse, error) {
+	if ok, _ := p.rateLimiter.TryConsume(1); !ok {
+		return nil, ErrPersistenceLimitExceeded
+	}
+	response, err := p.persistence.ReadRawHistoryBranch(request)
+	return response, err
+}
+
 // ForkHistoryBranch forks a new branch from a old branch
 func (p *historyV2RateLimitedPersistenceClient) ForkHistoryBranch(request *ForkHistoryBranchRequest) (*ForkHistoryBranchResponse, error) {
 	if ok, _ := p.rateLimiter.TryConsume(1); !ok {
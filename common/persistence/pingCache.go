@@ -0,0 +1,75 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package persistence
+
+import (
+	"sync"
+	"time"
+
+	"github.com/uber/cadence/common/clock"
+)
+
+// DefaultPingCacheTTL is the duration the result of pinging the stores is reused by the health checks
+const DefaultPingCacheTTL = 5 * time.Second
+
+type (
+	// PingCache pings the stores a service depends on and reuses the result for a short while, so that frequent
+	// health checks do not each reach the stores
+	PingCache struct {
+		sync.Mutex
+		pings      []func() error
+		ttl        time.Duration
+		timeSource clock.TimeSource
+		checkedAt  time.Time
+		err        error
+	}
+)
+
+// NewPingCache creates a ping cache of the given ping functions
+func NewPingCache(ttl time.Duration, timeSource clock.TimeSource, pings ...func() error) *PingCache {
+	return &PingCache{
+		pings:      pings,
+		ttl:        ttl,
+		timeSource: timeSource,
+	}
+}
+
+// Ping returns the error of the first store that cannot be reached, the stores are pinged again once the
+// result of the previous ping is older than the ttl
+func (c *PingCache) Ping() error {
+	c.Lock()
+	defer c.Unlock()
+
+	now := c.timeSource.Now()
+	if !c.checkedAt.IsZero() && now.Sub(c.checkedAt) < c.ttl {
+		return c.err
+	}
+
+	c.err = nil
+	for _, ping := range c.pings {
+		if err := ping(); err != nil {
+			c.err = err
+			break
+		}
+	}
+	c.checkedAt = now
+	return c.err
+}
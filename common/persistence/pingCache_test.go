@@ -0,0 +1,99 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package persistence
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"github.com/uber/cadence/common/clock"
+)
+
+type (
+	pingCacheSuite struct {
+		suite.Suite
+		*require.Assertions
+	}
+)
+
+func TestPingCacheSuite(t *testing.T) {
+	s := new(pingCacheSuite)
+	suite.Run(t, s)
+}
+
+func (s *pingCacheSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+}
+
+func (s *pingCacheSuite) TestPing_ReusesResultWithinTTL() {
+	timeSource := clock.NewEventTimeSource().Update(time.Unix(1000, 0))
+	var executionPings, taskPings int
+	var taskErr error
+	cache := NewPingCache(5*time.Second, timeSource,
+		func() error {
+			executionPings++
+			return nil
+		},
+		func() error {
+			taskPings++
+			return taskErr
+		},
+	)
+
+	s.NoError(cache.Ping())
+	s.NoError(cache.Ping())
+	s.Equal(1, executionPings)
+	s.Equal(1, taskPings)
+
+	taskErr = errors.New("task store unreachable")
+	timeSource.Update(time.Unix(1004, 0))
+	s.NoError(cache.Ping())
+	s.Equal(1, taskPings)
+
+	timeSource.Update(time.Unix(1005, 0))
+	s.Equal(taskErr, cache.Ping())
+	s.Equal(2, executionPings)
+	s.Equal(2, taskPings)
+
+	timeSource.Update(time.Unix(1006, 0))
+	s.Equal(taskErr, cache.Ping())
+	s.Equal(2, taskPings)
+}
+
+func (s *pingCacheSuite) TestPing_StopsAtFirstUnreachableStore() {
+	executionErr := errors.New("execution store unreachable")
+	var taskPings int
+	cache := NewPingCache(time.Second, clock.NewEventTimeSource().Update(time.Unix(1000, 0)),
+		func() error {
+			return executionErr
+		},
+		func() error {
+			taskPings++
+			return nil
+		},
+	)
+
+	s.Equal(executionErr, cache.Ping())
+	s.Equal(0, taskPings)
+}
@@ -0,0 +1,661 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package pinot
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	workflow "github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/definition"
+	es "github.com/uber/cadence/common/elasticsearch"
+	"github.com/uber/cadence/common/log"
+	"github.com/uber/cadence/common/log/tag"
+	"github.com/uber/cadence/common/messaging"
+	p "github.com/uber/cadence/common/persistence"
+	pnt "github.com/uber/cadence/common/pinot"
+	"github.com/xwb1989/sqlparser"
+)
+
+// The pinot visibility store streams one JSON record per visibility update to the pinot visibility kafka topic,
+// keyed by run ID. The Pinot table ingesting the topic must be an upsert table with RunID as primary key and
+// Version as comparison column, so a query only sees the latest record of each run. Custom search attributes
+// are flattened into columns of the same name for filtering, and kept as a JSON string in Attr for reading back.
+const (
+	pinotPersistenceName = "pinot"
+
+	// versionColumn orders the records of a run, it holds the task ID of the update
+	versionColumn = "Version"
+
+	// openCloseStatus is the CloseStatus of the records of open executions
+	openCloseStatus = -1
+
+	defaultPageSize = 1000
+)
+
+type (
+	pinotVisibilityStore struct {
+		client   pnt.Client
+		table    string
+		producer messaging.Producer
+		logger   log.Logger
+	}
+
+	pinotVisibilityPageToken struct {
+		From int
+	}
+)
+
+var _ p.VisibilityStore = (*pinotVisibilityStore)(nil)
+
+var (
+	selectColumns = []string{
+		es.WorkflowID,
+		es.RunID,
+		es.WorkflowType,
+		es.StartTime,
+		es.ExecutionTime,
+		es.CloseTime,
+		es.CloseStatus,
+		es.HistoryLength,
+		es.FirstExecutionRunID,
+		es.Memo,
+		es.Encoding,
+		definition.Attr,
+	}
+
+	systemColumns = map[string]bool{
+		es.DomainID:            true,
+		es.WorkflowID:          true,
+		es.RunID:               true,
+		es.WorkflowType:        true,
+		es.StartTime:           true,
+		es.ExecutionTime:       true,
+		es.CloseTime:           true,
+		es.CloseStatus:         true,
+		es.HistoryLength:       true,
+		es.Memo:                true,
+		es.Encoding:            true,
+		versionColumn:          true,
+		definition.Attr:        true,
+		es.FirstExecutionRunID: true,
+	}
+)
+
+// NewPinotVisibilityStore create a visibility store writing to the pinot visibility topic and querying a Pinot broker
+func NewPinotVisibilityStore(client pnt.Client, table string, producer messaging.Producer, logger log.Logger) p.VisibilityStore {
+	return &pinotVisibilityStore{
+		client:   client,
+		table:    table,
+		producer: producer,
+		logger:   logger.WithTags(tag.ComponentPinotVisibilityManager),
+	}
+}
+
+func (v *pinotVisibilityStore) Close() {}
+
+func (v *pinotVisibilityStore) GetName() string {
+	return pinotPersistenceName
+}
+
+func (v *pinotVisibilityStore) RecordWorkflowExecutionStarted(request *p.InternalRecordWorkflowExecutionStartedRequest) error {
+	record := map[string]interface{}{
+		es.DomainID:            request.DomainUUID,
+		es.WorkflowID:          request.WorkflowID,
+		es.RunID:               request.RunID,
+		es.WorkflowType:        request.WorkflowTypeName,
+		es.StartTime:           request.StartTimestamp,
+		es.ExecutionTime:       request.ExecutionTimestamp,
+		es.CloseTime:           0,
+		es.CloseStatus:         openCloseStatus,
+		es.HistoryLength:       0,
+		es.FirstExecutionRunID: request.FirstExecutionRunID,
+		versionColumn:          request.TaskID,
+	}
+	return v.publish("RecordWorkflowExecutionStarted", record, request.Memo, request.SearchAttributes)
+}
+
+func (v *pinotVisibilityStore) RecordWorkflowExecutionClosed(request *p.InternalRecordWorkflowExecutionClosedRequest) error {
+	record := map[string]interface{}{
+		es.DomainID:            request.DomainUUID,
+		es.WorkflowID:          request.WorkflowID,
+		es.RunID:               request.RunID,
+		es.WorkflowType:        request.WorkflowTypeName,
+		es.StartTime:           request.StartTimestamp,
+		es.ExecutionTime:       request.ExecutionTimestamp,
+		es.CloseTime:           request.CloseTimestamp,
+		es.CloseStatus:         int32(request.Status),
+		es.HistoryLength:       request.HistoryLength,
+		es.FirstExecutionRunID: request.FirstExecutionRunID,
+		versionColumn:          request.TaskID,
+	}
+	return v.publish("RecordWorkflowExecutionClosed", record, request.Memo, request.SearchAttributes)
+}
+
+func (v *pinotVisibilityStore) UpsertWorkflowExecution(request *p.InternalUpsertWorkflowExecutionRequest) error {
+	record := map[string]interface{}{
+		es.DomainID:            request.DomainUUID,
+		es.WorkflowID:          request.WorkflowID,
+		es.RunID:               request.RunID,
+		es.WorkflowType:        request.WorkflowTypeName,
+		es.StartTime:           request.StartTimestamp,
+		es.ExecutionTime:       request.ExecutionTimestamp,
+		es.CloseTime:           0,
+		es.CloseStatus:         openCloseStatus,
+		es.HistoryLength:       0,
+		es.FirstExecutionRunID: request.FirstExecutionRunID,
+		versionColumn:          request.TaskID,
+	}
+	return v.publish("UpsertWorkflowExecution", record, request.Memo, request.SearchAttributes)
+}
+
+func (v *pinotVisibilityStore) ListOpenWorkflowExecutions(
+	request *p.ListWorkflowExecutionsRequest) (*p.InternalListWorkflowExecutionsResponse, error) {
+	return v.listExecutions("ListOpenWorkflowExecutions", request, true, "")
+}
+
+func (v *pinotVisibilityStore) ListClosedWorkflowExecutions(
+	request *p.ListWorkflowExecutionsRequest) (*p.InternalListWorkflowExecutionsResponse, error) {
+	return v.listExecutions("ListClosedWorkflowExecutions", request, false, "")
+}
+
+func (v *pinotVisibilityStore) ListOpenWorkflowExecutionsByType(
+	request *p.ListWorkflowExecutionsByTypeRequest) (*p.InternalListWorkflowExecutionsResponse, error) {
+	filter := fmt.Sprintf("%v = %v", es.WorkflowType, quoteString(request.WorkflowTypeName))
+	return v.listExecutions("ListOpenWorkflowExecutionsByType", &request.ListWorkflowExecutionsRequest, true, filter)
+}
+
+func (v *pinotVisibilityStore) ListClosedWorkflowExecutionsByType(
+	request *p.ListWorkflowExecutionsByTypeRequest) (*p.InternalListWorkflowExecutionsResponse, error) {
+	filter := fmt.Sprintf("%v = %v", es.WorkflowType, quoteString(request.WorkflowTypeName))
+	return v.listExecutions("ListClosedWorkflowExecutionsByType", &request.ListWorkflowExecutionsRequest, false, filter)
+}
+
+func (v *pinotVisibilityStore) ListOpenWorkflowExecutionsByWorkflowID(
+	request *p.ListWorkflowExecutionsByWorkflowIDRequest) (*p.InternalListWorkflowExecutionsResponse, error) {
+	filter := fmt.Sprintf("%v = %v", es.WorkflowID, quoteString(request.WorkflowID))
+	return v.listExecutions("ListOpenWorkflowExecutionsByWorkflowID", &request.ListWorkflowExecutionsRequest, true, filter)
+}
+
+func (v *pinotVisibilityStore) ListClosedWorkflowExecutionsByWorkflowID(
+	request *p.ListWorkflowExecutionsByWorkflowIDRequest) (*p.InternalListWorkflowExecutionsResponse, error) {
+	filter := fmt.Sprintf("%v = %v", es.WorkflowID, quoteString(request.WorkflowID))
+	return v.listExecutions("ListClosedWorkflowExecutionsByWorkflowID", &request.ListWorkflowExecutionsRequest, false, filter)
+}
+
+func (v *pinotVisibilityStore) ListClosedWorkflowExecutionsByStatus(
+	request *p.ListClosedWorkflowExecutionsByStatusRequest) (*p.InternalListWorkflowExecutionsResponse, error) {
+	filter := fmt.Sprintf("%v = %v", es.CloseStatus, int32(request.Status))
+	return v.listExecutions("ListClosedWorkflowExecutionsByStatus", &request.ListWorkflowExecutionsRequest, false, filter)
+}
+
+func (v *pinotVisibilityStore) GetClosedWorkflowExecution(
+	request *p.GetClosedWorkflowExecutionRequest) (*p.InternalGetClosedWorkflowExecutionResponse, error) {
+
+	where := fmt.Sprintf("%v = %v AND %v != %v AND %v = %v",
+		es.DomainID, quoteString(request.DomainUUID),
+		es.CloseStatus, openCloseStatus,
+		es.WorkflowID, quoteString(request.Execution.GetWorkflowId()))
+	if rid := request.Execution.GetRunId(); rid != "" {
+		where += fmt.Sprintf(" AND %v = %v", es.RunID, quoteString(rid))
+	}
+	sql := fmt.Sprintf("SELECT %v FROM %v WHERE %v ORDER BY %v DESC LIMIT 1",
+		strings.Join(selectColumns, ", "), v.table, where, es.CloseTime)
+
+	executions, err := v.query("GetClosedWorkflowExecution", sql)
+	if err != nil {
+		return nil, err
+	}
+	response := &p.InternalGetClosedWorkflowExecutionResponse{}
+	if len(executions) > 0 {
+		response.Execution = executions[0]
+	}
+	return response, nil
+}
+
+// DeleteWorkflowExecution is a no-op since records are expired by the retention of the Pinot table
+func (v *pinotVisibilityStore) DeleteWorkflowExecution(request *p.VisibilityDeleteWorkflowExecutionRequest) error {
+	return nil
+}
+
+func (v *pinotVisibilityStore) ListWorkflowExecutions(
+	request *p.ListWorkflowExecutionsRequestV2) (*p.InternalListWorkflowExecutionsResponse, error) {
+
+	checkPageSize(request)
+	token, err := deserializePageToken(request.NextPageToken)
+	if err != nil {
+		return nil, err
+	}
+
+	sql, err := getListSQL(v.table, request.DomainUUID, request.Query, token.From, request.PageSize)
+	if err != nil {
+		return nil, &workflow.BadRequestError{Message: fmt.Sprintf("Error when parse query: %v", err)}
+	}
+	executions, err := v.query("ListWorkflowExecutions", sql)
+	if err != nil {
+		return nil, err
+	}
+	return getListWorkflowExecutionsResponse(executions, token, request.PageSize)
+}
+
+// ScanWorkflowExecutions pages through the executions matching the query by offset, ignoring the requested order
+func (v *pinotVisibilityStore) ScanWorkflowExecutions(
+	request *p.ListWorkflowExecutionsRequestV2) (*p.InternalListWorkflowExecutionsResponse, error) {
+
+	checkPageSize(request)
+	token, err := deserializePageToken(request.NextPageToken)
+	if err != nil {
+		return nil, err
+	}
+
+	where, _, err := getWhereAndOrderFromQuery(request.DomainUUID, request.Query)
+	if err != nil {
+		return nil, &workflow.BadRequestError{Message: fmt.Sprintf("Error when parse query: %v", err)}
+	}
+	sql := fmt.Sprintf("SELECT %v FROM %v WHERE %v ORDER BY %v DESC LIMIT %d, %d",
+		strings.Join(selectColumns, ", "), v.table, where, es.RunID, token.From, request.PageSize)
+	executions, err := v.query("ScanWorkflowExecutions", sql)
+	if err != nil {
+		return nil, err
+	}
+	return getListWorkflowExecutionsResponse(executions, token, request.PageSize)
+}
+
+func (v *pinotVisibilityStore) CountWorkflowExecutions(request *p.CountWorkflowExecutionsRequest) (
+	*p.CountWorkflowExecutionsResponse, error) {
+
+	where, _, err := getWhereAndOrderFromQuery(request.DomainUUID, request.Query)
+	if err != nil {
+		return nil, &workflow.BadRequestError{Message: fmt.Sprintf("Error when parse query: %v", err)}
+	}
+	sql := fmt.Sprintf("SELECT COUNT(*) FROM %v WHERE %v", v.table, where)
+
+	result, err := v.client.Query(context.Background(), sql)
+	if err != nil {
+		return nil, &workflow.InternalServiceError{
+			Message: fmt.Sprintf("CountWorkflowExecutions failed. Error: %v", err),
+		}
+	}
+	if len(result.Rows) != 1 || len(result.Rows[0]) != 1 {
+		return nil, &workflow.InternalServiceError{
+			Message: "CountWorkflowExecutions failed. Error: unexpected count result",
+		}
+	}
+	count, err := toInt64(result.Rows[0][0])
+	if err != nil {
+		return nil, &workflow.InternalServiceError{
+			Message: fmt.Sprintf("CountWorkflowExecutions failed. Error: %v", err),
+		}
+	}
+	return &p.CountWorkflowExecutionsResponse{Count: count}, nil
+}
+
+func (v *pinotVisibilityStore) publish(operation string, record map[string]interface{}, memo *p.DataBlob,
+	searchAttributes map[string][]byte) error {
+
+	v.checkProducer()
+	if memo != nil && len(memo.Data) != 0 {
+		record[es.Memo] = base64.StdEncoding.EncodeToString(memo.Data)
+		record[es.Encoding] = string(memo.GetEncoding())
+	}
+
+	attr := make(map[string]interface{}, len(searchAttributes))
+	for key, value := range searchAttributes {
+		var decoded interface{}
+		if err := json.Unmarshal(value, &decoded); err != nil {
+			return &workflow.InternalServiceError{
+				Message: fmt.Sprintf("%v failed. Unable to decode search attribute %v: %v", operation, key, err),
+			}
+		}
+		attr[key] = decoded
+		if !systemColumns[key] {
+			record[key] = decoded
+		}
+	}
+	attrJSON, err := json.Marshal(attr)
+	if err != nil {
+		return &workflow.InternalServiceError{
+			Message: fmt.Sprintf("%v failed. Error: %v", operation, err),
+		}
+	}
+	record[definition.Attr] = string(attrJSON)
+
+	data, err := json.Marshal(record)
+	if err != nil {
+		return &workflow.InternalServiceError{
+			Message: fmt.Sprintf("%v failed. Error: %v", operation, err),
+		}
+	}
+	return v.producer.Publish(&messaging.KeyedMessage{
+		Key:   record[es.RunID].(string),
+		Value: data,
+	})
+}
+
+func (v *pinotVisibilityStore) listExecutions(operation string, request *p.ListWorkflowExecutionsRequest,
+	isOpen bool, filter string) (*p.InternalListWorkflowExecutionsResponse, error) {
+
+	token, err := deserializePageToken(request.NextPageToken)
+	if err != nil {
+		return nil, err
+	}
+
+	timeColumn := es.CloseTime
+	statusOperator := "!="
+	if isOpen {
+		timeColumn = es.StartTime
+		statusOperator = "="
+	}
+	where := fmt.Sprintf("%v = %v AND %v %v %v AND %v BETWEEN %d AND %d",
+		es.DomainID, quoteString(request.DomainUUID),
+		es.CloseStatus, statusOperator, openCloseStatus,
+		timeColumn, request.EarliestStartTime, request.LatestStartTime)
+	if filter != "" {
+		where += " AND " + filter
+	}
+	sql := fmt.Sprintf("SELECT %v FROM %v WHERE %v ORDER BY %v DESC, %v DESC LIMIT %d, %d",
+		strings.Join(selectColumns, ", "), v.table, where, timeColumn, es.RunID, token.From, request.PageSize)
+
+	executions, err := v.query(operation, sql)
+	if err != nil {
+		return nil, err
+	}
+	return getListWorkflowExecutionsResponse(executions, token, request.PageSize)
+}
+
+func (v *pinotVisibilityStore) query(operation string, sql string) ([]*p.VisibilityWorkflowExecutionInfo, error) {
+	result, err := v.client.Query(context.Background(), sql)
+	if err != nil {
+		return nil, &workflow.InternalServiceError{
+			Message: fmt.Sprintf("%v failed. Error: %v", operation, err),
+		}
+	}
+
+	executions := make([]*p.VisibilityWorkflowExecutionInfo, 0, len(result.Rows))
+	for _, row := range result.Rows {
+		execution, err := convertRowToVisibilityRecord(result.Columns, row)
+		if err != nil { // log and skip error
+			v.logger.Error("unable to convert pinot row to visibility record", tag.Error(err))
+			continue
+		}
+		executions = append(executions, execution)
+	}
+	return executions, nil
+}
+
+func (v *pinotVisibilityStore) checkProducer() {
+	if v.producer == nil {
+		// must be bug, check history setup
+		panic("message producer is nil")
+	}
+}
+
+func getListWorkflowExecutionsResponse(executions []*p.VisibilityWorkflowExecutionInfo,
+	token *pinotVisibilityPageToken, pageSize int) (*p.InternalListWorkflowExecutionsResponse, error) {
+
+	response := &p.InternalListWorkflowExecutionsResponse{Executions: executions}
+	if len(executions) == pageSize { // this means the response is not the last page
+		nextPageToken, err := serializePageToken(&pinotVisibilityPageToken{From: token.From + pageSize})
+		if err != nil {
+			return nil, err
+		}
+		response.NextPageToken = nextPageToken
+	}
+	return response, nil
+}
+
+// getListSQL converts the query of a list request into a Pinot query of the executions of the domain
+func getListSQL(table, domainID, query string, from, pageSize int) (string, error) {
+	where, orderBy, err := getWhereAndOrderFromQuery(domainID, query)
+	if err != nil {
+		return "", err
+	}
+	if orderBy == "" {
+		orderBy = fmt.Sprintf("%v DESC", es.StartTime)
+	}
+	return fmt.Sprintf("SELECT %v FROM %v WHERE %v ORDER BY %v, %v DESC LIMIT %d, %d",
+		strings.Join(selectColumns, ", "), table, where, orderBy, es.RunID, from, pageSize), nil
+}
+
+// getWhereAndOrderFromQuery parses the visibility query, which the frontend has validated and prefixed
+// custom search attributes with Attr., into a where clause restricted to the domain and an order by list.
+// CloseTime = missing is rewritten to match open executions.
+func getWhereAndOrderFromQuery(domainID, query string) (string, string, error) {
+	domainFilter := fmt.Sprintf("%v = %v", es.DomainID, quoteString(domainID))
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return domainFilter, "", nil
+	}
+
+	var sql string
+	if common.IsJustOrderByClause(query) {
+		sql = "select * from dummy " + query
+	} else {
+		sql = "select * from dummy where " + query
+	}
+	stmt, err := sqlparser.Parse(sql)
+	if err != nil {
+		return "", "", err
+	}
+	selectStmt, ok := stmt.(*sqlparser.Select)
+	if !ok {
+		return "", "", fmt.Errorf("query is not a select statement")
+	}
+
+	err = sqlparser.Walk(func(node sqlparser.SQLNode) (bool, error) {
+		switch n := node.(type) {
+		case *sqlparser.ComparisonExpr:
+			return true, rewriteMissing(n)
+		case *sqlparser.ColName:
+			if n.Name.EqualString("missing") && n.Qualifier.IsEmpty() {
+				return false, fmt.Errorf("missing is only supported on %v", es.CloseTime)
+			}
+			if n.Qualifier.Name.String() == definition.Attr {
+				n.Qualifier = sqlparser.TableName{}
+			}
+		}
+		return true, nil
+	}, selectStmt.Where, selectStmt.OrderBy)
+	if err != nil {
+		return "", "", err
+	}
+
+	where := domainFilter
+	if selectStmt.Where != nil {
+		where = fmt.Sprintf("%v AND (%v)", domainFilter, formatNode(selectStmt.Where.Expr))
+	}
+	var orders []string
+	for _, order := range selectStmt.OrderBy {
+		orders = append(orders, fmt.Sprintf("%v %v", formatNode(order.Expr), strings.ToUpper(order.Direction)))
+	}
+	return where, strings.Join(orders, ", "), nil
+}
+
+func rewriteMissing(expr *sqlparser.ComparisonExpr) error {
+	col, ok := expr.Right.(*sqlparser.ColName)
+	if !ok || !col.Name.EqualString("missing") || !col.Qualifier.IsEmpty() {
+		return nil
+	}
+	left, ok := expr.Left.(*sqlparser.ColName)
+	if !ok || !left.Name.EqualString(es.CloseTime) {
+		return fmt.Errorf("missing is only supported on %v", es.CloseTime)
+	}
+	switch expr.Operator {
+	case sqlparser.EqualStr, sqlparser.NotEqualStr:
+	default:
+		return fmt.Errorf("operator %v is not supported with missing", expr.Operator)
+	}
+	expr.Left = &sqlparser.ColName{Name: sqlparser.NewColIdent(es.CloseStatus)}
+	expr.Right = sqlparser.NewIntVal([]byte(strconv.Itoa(openCloseStatus)))
+	return nil
+}
+
+// formatNode formats the node in the Pinot dialect, identifiers are double quoted
+// and string literals are single quoted with embedded quotes doubled
+func formatNode(node sqlparser.SQLNode) string {
+	buf := sqlparser.NewTrackedBuffer(func(buf *sqlparser.TrackedBuffer, node sqlparser.SQLNode) {
+		switch n := node.(type) {
+		case *sqlparser.ColName:
+			buf.WriteString(quoteIdentifier(n.Name.String()))
+		case *sqlparser.SQLVal:
+			if n.Type == sqlparser.StrVal {
+				buf.WriteString(quoteString(string(n.Val)))
+				return
+			}
+			n.Format(buf)
+		default:
+			node.Format(buf)
+		}
+	})
+	buf.Myprintf("%v", node)
+	return buf.String()
+}
+
+func quoteString(value string) string {
+	return "'" + strings.Replace(value, "'", "''", -1) + "'"
+}
+
+func quoteIdentifier(name string) string {
+	return `"` + strings.Replace(name, `"`, `""`, -1) + `"`
+}
+
+func convertRowToVisibilityRecord(columns []string, row []interface{}) (*p.VisibilityWorkflowExecutionInfo, error) {
+	if len(columns) != len(row) {
+		return nil, fmt.Errorf("row has %v values for %v columns", len(row), len(columns))
+	}
+	values := make(map[string]interface{}, len(columns))
+	for i, column := range columns {
+		values[column] = row[i]
+	}
+
+	startTime, err := toInt64(values[es.StartTime])
+	if err != nil {
+		return nil, err
+	}
+	executionTime, err := toInt64(values[es.ExecutionTime])
+	if err != nil {
+		return nil, err
+	}
+	closeStatus, err := toInt64(values[es.CloseStatus])
+	if err != nil {
+		return nil, err
+	}
+
+	record := &p.VisibilityWorkflowExecutionInfo{
+		WorkflowID:          toString(values[es.WorkflowID]),
+		RunID:               toString(values[es.RunID]),
+		TypeName:            toString(values[es.WorkflowType]),
+		StartTime:           time.Unix(0, startTime),
+		ExecutionTime:       time.Unix(0, executionTime),
+		FirstExecutionRunID: toString(values[es.FirstExecutionRunID]),
+	}
+	if memo := toString(values[es.Memo]); memo != "" {
+		data, err := base64.StdEncoding.DecodeString(memo)
+		if err != nil {
+			return nil, err
+		}
+		record.Memo = p.NewDataBlob(data, common.EncodingType(toString(values[es.Encoding])))
+	}
+	if attr := toString(values[definition.Attr]); attr != "" {
+		dec := json.NewDecoder(strings.NewReader(attr))
+		dec.UseNumber()
+		if err := dec.Decode(&record.SearchAttributes); err != nil {
+			return nil, err
+		}
+	}
+	if closeStatus != openCloseStatus {
+		closeTime, err := toInt64(values[es.CloseTime])
+		if err != nil {
+			return nil, err
+		}
+		historyLength, err := toInt64(values[es.HistoryLength])
+		if err != nil {
+			return nil, err
+		}
+		status := workflow.WorkflowExecutionCloseStatus(closeStatus)
+		record.CloseTime = time.Unix(0, closeTime)
+		record.Status = &status
+		record.HistoryLength = historyLength
+	}
+	return record, nil
+}
+
+func toInt64(value interface{}) (int64, error) {
+	switch v := value.(type) {
+	case json.Number:
+		return v.Int64()
+	case string:
+		return strconv.ParseInt(v, 10, 64)
+	case float64:
+		return int64(v), nil
+	default:
+		return 0, fmt.Errorf("unexpected value %v of type %T for a long column", value, value)
+	}
+}
+
+func toString(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		if v == "null" { // Pinot default null value of string columns
+			return ""
+		}
+		return v
+	case nil:
+		return ""
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
+
+func checkPageSize(request *p.ListWorkflowExecutionsRequestV2) {
+	if request.PageSize == 0 {
+		request.PageSize = defaultPageSize
+	}
+}
+
+func deserializePageToken(data []byte) (*pinotVisibilityPageToken, error) {
+	token := &pinotVisibilityPageToken{}
+	if len(data) == 0 {
+		return token, nil
+	}
+	if err := json.Unmarshal(data, token); err != nil {
+		return nil, &workflow.BadRequestError{
+			Message: fmt.Sprintf("unable to deserialize page token. err: %v", err),
+		}
+	}
+	return token, nil
+}
+
+func serializePageToken(token *pinotVisibilityPageToken) ([]byte, error) {
+	data, err := json.Marshal(token)
+	if err != nil {
+		return nil, &workflow.BadRequestError{
+			Message: fmt.Sprintf("unable to serialize page token. err: %v", err),
+		}
+	}
+	return data, nil
+}
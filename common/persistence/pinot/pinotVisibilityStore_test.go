@@ -0,0 +1,218 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package pinot
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	workflow "github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/log/loggerimpl"
+	"github.com/uber/cadence/common/messaging"
+	"github.com/uber/cadence/common/mocks"
+	p "github.com/uber/cadence/common/persistence"
+	pnt "github.com/uber/cadence/common/pinot"
+)
+
+type (
+	PinotVisibilitySuite struct {
+		suite.Suite
+		*require.Assertions
+		visibilityStore *pinotVisibilityStore
+		client          *fakePinotClient
+		mockProducer    *mocks.KafkaProducer
+	}
+
+	fakePinotClient struct {
+		queries []string
+		result  *pnt.QueryResult
+	}
+)
+
+var (
+	testTable      = "cadence_visibility"
+	testDomainID   = "bfd5c907-f899-4baf-a7b2-2ab85e623ebd"
+	testWorkflowID = "test-wid"
+	testRunID      = "1601da05-4db9-4eeb-89e4-da99481bdfc9"
+	testFirstRunID = "5c0a8e1d-2f4b-4c59-9a57-0d8a3c2b6e41"
+)
+
+func TestPinotVisibilitySuite(t *testing.T) {
+	suite.Run(t, new(PinotVisibilitySuite))
+}
+
+func (s *PinotVisibilitySuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+	s.client = &fakePinotClient{result: &pnt.QueryResult{}}
+	s.mockProducer = &mocks.KafkaProducer{}
+	store := NewPinotVisibilityStore(s.client, testTable, s.mockProducer, loggerimpl.NewNopLogger())
+	s.visibilityStore = store.(*pinotVisibilityStore)
+}
+
+func (s *PinotVisibilitySuite) TestRecordWorkflowExecutionClosed() {
+	request := &p.InternalRecordWorkflowExecutionClosedRequest{
+		DomainUUID:          testDomainID,
+		WorkflowID:          testWorkflowID,
+		RunID:               testRunID,
+		WorkflowTypeName:    "test-wf-type",
+		StartTimestamp:      100,
+		CloseTimestamp:      200,
+		Status:              workflow.WorkflowExecutionCloseStatusFailed,
+		HistoryLength:       10,
+		TaskID:              7,
+		FirstExecutionRunID: testFirstRunID,
+		Memo:                p.NewDataBlob([]byte("memo"), common.EncodingTypeThriftRW),
+		SearchAttributes:    map[string][]byte{"CustomKeywordField": []byte(`"keyword"`)},
+	}
+
+	var record map[string]interface{}
+	s.mockProducer.On("Publish", mock.MatchedBy(func(msg *messaging.KeyedMessage) bool {
+		s.Equal(testRunID, msg.Key)
+		s.NoError(json.Unmarshal(msg.Value, &record))
+		return true
+	})).Return(nil).Once()
+	s.NoError(s.visibilityStore.RecordWorkflowExecutionClosed(request))
+
+	s.Equal(testDomainID, record["DomainID"])
+	s.Equal(float64(200), record["CloseTime"])
+	s.Equal(float64(workflow.WorkflowExecutionCloseStatusFailed), record["CloseStatus"])
+	s.Equal(float64(7), record["Version"])
+	s.Equal(testFirstRunID, record["FirstExecutionRunID"])
+	s.Equal("keyword", record["CustomKeywordField"])
+	s.Equal(`{"CustomKeywordField":"keyword"}`, record["Attr"])
+	s.mockProducer.AssertExpectations(s.T())
+}
+
+func (s *PinotVisibilitySuite) TestGetWhereAndOrderFromQuery() {
+	domainFilter := "DomainID = '" + testDomainID + "'"
+	testCases := []struct {
+		query string
+		where string
+		order string
+	}{
+		{
+			query: "",
+			where: domainFilter,
+		},
+		{
+			query: `WorkflowType = "wf" and Attr.CustomIntField > 3 order by StartTime desc`,
+			where: domainFilter + ` AND ("WorkflowType" = 'wf' and "CustomIntField" > 3)`,
+			order: `"StartTime" DESC`,
+		},
+		{
+			query: `CloseTime = missing`,
+			where: domainFilter + ` AND ("CloseStatus" = -1)`,
+		},
+		{
+			query: `WorkflowID = "a'b"`,
+			where: domainFilter + ` AND ("WorkflowID" = 'a''b')`,
+		},
+		{
+			query: `order by CloseTime asc`,
+			where: domainFilter,
+			order: `"CloseTime" ASC`,
+		},
+	}
+
+	for _, tc := range testCases {
+		where, order, err := getWhereAndOrderFromQuery(testDomainID, tc.query)
+		s.NoError(err, tc.query)
+		s.Equal(tc.where, where, tc.query)
+		s.Equal(tc.order, order, tc.query)
+	}
+
+	_, _, err := getWhereAndOrderFromQuery(testDomainID, `StartTime = missing`)
+	s.Error(err)
+	_, _, err = getWhereAndOrderFromQuery(testDomainID, `WorkflowID = `)
+	s.Error(err)
+}
+
+func (s *PinotVisibilitySuite) TestListWorkflowExecutions() {
+	s.client.result = &pnt.QueryResult{
+		Columns: selectColumns,
+		Rows: [][]interface{}{
+			{testWorkflowID, testRunID, "test-wf-type", json.Number("100"), json.Number("100"), json.Number("200"),
+				json.Number("3"), json.Number("10"), testFirstRunID, "null", "null", `{"CustomIntField":5}`},
+			{testWorkflowID, "run2", "test-wf-type", json.Number("50"), json.Number("50"), json.Number("0"),
+				json.Number("-1"), json.Number("0"), "null", "null", "null", "{}"},
+		},
+	}
+	request := &p.ListWorkflowExecutionsRequestV2{
+		DomainUUID: testDomainID,
+		PageSize:   2,
+		Query:      `WorkflowID = "test-wid"`,
+	}
+
+	resp, err := s.visibilityStore.ListWorkflowExecutions(request)
+	s.NoError(err)
+	s.Equal(1, len(s.client.queries))
+	s.Equal("SELECT WorkflowID, RunID, WorkflowType, StartTime, ExecutionTime, CloseTime, CloseStatus, HistoryLength, "+
+		"FirstExecutionRunID, Memo, Encoding, Attr FROM cadence_visibility WHERE DomainID = '"+testDomainID+"' AND (\"WorkflowID\" = 'test-wid') "+
+		"ORDER BY StartTime DESC, RunID DESC LIMIT 0, 2", s.client.queries[0])
+
+	s.Equal(2, len(resp.Executions))
+	closed := resp.Executions[0]
+	s.Equal(testRunID, closed.RunID)
+	s.Equal(workflow.WorkflowExecutionCloseStatusTerminated, *closed.Status)
+	s.Equal(int64(200), closed.CloseTime.UnixNano())
+	s.Equal(int64(10), closed.HistoryLength)
+	s.Equal(testFirstRunID, closed.FirstExecutionRunID)
+	s.Equal(json.Number("5"), closed.SearchAttributes["CustomIntField"])
+	s.Nil(closed.Memo)
+	s.Nil(resp.Executions[1].Status)
+
+	token, err := deserializePageToken(resp.NextPageToken)
+	s.NoError(err)
+	s.Equal(2, token.From)
+
+	request.NextPageToken = resp.NextPageToken
+	s.client.result = &pnt.QueryResult{}
+	resp, err = s.visibilityStore.ListWorkflowExecutions(request)
+	s.NoError(err)
+	s.Contains(s.client.queries[1], "LIMIT 2, 2")
+	s.Empty(resp.Executions)
+	s.Nil(resp.NextPageToken)
+}
+
+func (s *PinotVisibilitySuite) TestCountWorkflowExecutions() {
+	s.client.result = &pnt.QueryResult{
+		Columns: []string{"count(*)"},
+		Rows:    [][]interface{}{{json.Number("42")}},
+	}
+	resp, err := s.visibilityStore.CountWorkflowExecutions(&p.CountWorkflowExecutionsRequest{
+		DomainUUID: testDomainID,
+		Query:      `CloseTime = missing`,
+	})
+	s.NoError(err)
+	s.Equal(int64(42), resp.Count)
+	s.Equal("SELECT COUNT(*) FROM cadence_visibility WHERE DomainID = '"+testDomainID+"' AND (\"CloseStatus\" = -1)",
+		s.client.queries[0])
+}
+
+func (c *fakePinotClient) Query(ctx context.Context, sql string) (*pnt.QueryResult, error) {
+	c.queries = append(c.queries, sql)
+	return c.result, nil
+}
@@ -146,6 +146,15 @@ func signalInfoFromBlob(b []byte, proto string) (*sqlblobs.SignalInfo, error) {
 	return result, thriftRWDecode(b, proto, result)
 }
 
+func updateInfoToBlob(info *sqlblobs.UpdateInfo) (p.DataBlob, error) {
+	return thriftRWEncode(info)
+}
+
+func updateInfoFromBlob(b []byte, proto string) (*sqlblobs.UpdateInfo, error) {
+	result := &sqlblobs.UpdateInfo{}
+	return result, thriftRWDecode(b, proto, result)
+}
+
 func requestCancelInfoToBlob(info *sqlblobs.RequestCancelInfo) (p.DataBlob, error) {
 	return thriftRWEncode(info)
 }
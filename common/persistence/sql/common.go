@@ -22,14 +22,18 @@ package sql
 
 import (
 	"bytes"
+	"database/sql"
 	"encoding/binary"
 	"encoding/gob"
 	"fmt"
 
 	"github.com/go-sql-driver/mysql"
+	"github.com/lib/pq"
 	workflow "github.com/uber/cadence/.gen/go/shared"
 	"github.com/uber/cadence/common/log"
 	"github.com/uber/cadence/common/persistence"
+	"github.com/uber/cadence/common/persistence/sql/storage/cockroach"
+	"github.com/uber/cadence/common/persistence/sql/storage/kv"
 	"github.com/uber/cadence/common/persistence/sql/storage/sqldb"
 )
 
@@ -49,16 +53,47 @@ func (m *sqlStore) Close() {
 	}
 }
 
+// ping reads the row of the shard, the row may not exist yet, reaching the database is enough
+func (m *sqlStore) ping(shardID int) error {
+	if _, err := m.db.SelectFromShards(&sqldb.ShardsFilter{ShardID: int64(shardID)}); err != nil && err != sql.ErrNoRows {
+		return &workflow.InternalServiceError{
+			Message: fmt.Sprintf("Ping operation failed. Error: %v", err),
+		}
+	}
+	return nil
+}
+
+// maxTxAttempts is the number of times a transaction is attempted when it keeps
+// being aborted by serialization conflicts, which cockroach and the key-value
+// stores surface to the client
+const maxTxAttempts = 5
+
 func (m *sqlStore) txExecute(operation string, f func(tx sqldb.Tx) error) error {
+	var err error
+	for attempt := 1; attempt <= maxTxAttempts; attempt++ {
+		var retry bool
+		if retry, err = m.txExecuteOnce(operation, f); !retry {
+			break
+		}
+	}
+	return err
+}
+
+// txExecuteOnce runs f within a single transaction, it reports whether the
+// transaction was aborted by a serialization conflict and can be retried as a whole
+func (m *sqlStore) txExecuteOnce(operation string, f func(tx sqldb.Tx) error) (bool, error) {
 	tx, err := m.db.BeginTx()
 	if err != nil {
-		return &workflow.InternalServiceError{
+		return false, &workflow.InternalServiceError{
 			Message: fmt.Sprintf("%s failed. Failed to start transaction. Error: %v", operation, err),
 		}
 	}
 	err = f(tx)
 	if err != nil {
 		tx.Rollback()
+		// the stores wrap the errors of the statements they run, the conflict
+		// is classified on the transaction itself so that it is still retried
+		retry := isSerializationFailure(err) || isConflicted(tx)
 		switch err.(type) {
 		case *persistence.ConditionFailedError,
 			*persistence.CurrentWorkflowConditionFailedError,
@@ -66,30 +101,53 @@ func (m *sqlStore) txExecute(operation string, f func(tx sqldb.Tx) error) error
 			*persistence.WorkflowExecutionAlreadyStartedError,
 			*workflow.DomainAlreadyExistsError,
 			*persistence.ShardOwnershipLostError:
-			return err
+			return retry, err
 		default:
-			return &workflow.InternalServiceError{
+			return retry, &workflow.InternalServiceError{
 				Message: fmt.Sprintf("%v: %v", operation, err),
 			}
 		}
 	}
 	if err := tx.Commit(); err != nil {
-		return &workflow.InternalServiceError{
+		return isSerializationFailure(err) || isConflicted(tx), &workflow.InternalServiceError{
 			Message: fmt.Sprintf("%s operation failed. Failed to commit transaction. Error: %v", operation, err),
 		}
 	}
-	return nil
+	return false, nil
 }
 
 // ErrDupEntry MySQL Error 1062 indicates a duplicate primary key i.e. the row already exists,
 // so we don't do the insert and return a ConditionalUpdate error.
 const ErrDupEntry = 1062
 
+// ErrCodeUniqueViolation is the postgres error code returned by cockroach
+// when an insert conflicts with an existing primary key
+const ErrCodeUniqueViolation = "23505"
+
 func isDupEntry(err error) bool {
+	if err == kv.ErrDupEntry {
+		return true
+	}
+	if pqErr, ok := err.(*pq.Error); ok {
+		return pqErr.Code == ErrCodeUniqueViolation
+	}
 	sqlErr, ok := err.(*mysql.MySQLError)
 	return ok && sqlErr.Number == ErrDupEntry
 }
 
+func isSerializationFailure(err error) bool {
+	if err == kv.ErrConflict {
+		return true
+	}
+	pqErr, ok := err.(*pq.Error)
+	return ok && pqErr.Code == cockroach.ErrCodeSerializationFailure
+}
+
+func isConflicted(tx sqldb.Tx) bool {
+	c, ok := tx.(sqldb.ConflictReporter)
+	return ok && c.Conflicted()
+}
+
 func gobSerialize(x interface{}) ([]byte, error) {
 	b := bytes.Buffer{}
 	e := gob.NewEncoder(&b)
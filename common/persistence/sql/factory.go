@@ -46,8 +46,11 @@ type (
 		sync.Mutex
 		sqldb.Interface
 		refCnt int
-		cfg    *config.SQL
+		open   dbOpener
 	}
+
+	// dbOpener opens a new logical connection to the database
+	dbOpener func() (sqldb.Interface, error)
 )
 
 // NewFactory returns an instance of a factory object which can be used to create
@@ -57,7 +60,20 @@ func NewFactory(cfg config.SQL, clusterName string, logger log.Logger) *Factory
 		cfg:         cfg,
 		clusterName: clusterName,
 		logger:      logger,
-		dbConn:      newRefCountedDBConn(&cfg),
+		dbConn: newRefCountedDBConn(func() (sqldb.Interface, error) {
+			return storage.NewSQLDB(&cfg)
+		}),
+	}
+}
+
+// NewFactoryFromDB returns an instance of a factory object whose datastores run their
+// table operations on the databases returned by open, e.g. the tables of a key-value store
+func NewFactoryFromDB(open func() (sqldb.Interface, error), numShards int, clusterName string, logger log.Logger) *Factory {
+	return &Factory{
+		cfg:         config.SQL{NumShards: numShards},
+		clusterName: clusterName,
+		logger:      logger,
+		dbConn:      newRefCountedDBConn(open),
 	}
 }
 
@@ -127,7 +143,38 @@ func (f *Factory) NewExecutionStore(shardID int) (p.ExecutionStore, error) {
 
 // NewVisibilityStore returns a visibility store
 func (f *Factory) NewVisibilityStore() (p.VisibilityStore, error) {
-	return NewSQLVisibilityStore(f.cfg, f.logger)
+	db, err := f.dbConn.open()
+	if err != nil {
+		return nil, err
+	}
+	return newSQLVisibilityStore(db, f.logger), nil
+}
+
+// NewClusterMembershipStore returns a cluster membership store
+func (f *Factory) NewClusterMembershipStore() (p.ClusterMembershipStore, error) {
+	conn, err := f.dbConn.get()
+	if err != nil {
+		return nil, err
+	}
+	return newClusterMembershipPersistence(conn, f.logger)
+}
+
+// NewAuditStore returns an audit store
+func (f *Factory) NewAuditStore() (p.AuditStore, error) {
+	conn, err := f.dbConn.get()
+	if err != nil {
+		return nil, err
+	}
+	return newAuditPersistence(conn, f.logger)
+}
+
+// NewScheduleStore returns a schedule store
+func (f *Factory) NewScheduleStore() (p.ScheduleStore, error) {
+	conn, err := f.dbConn.get()
+	if err != nil {
+		return nil, err
+	}
+	return newSchedulePersistence(conn, f.logger)
 }
 
 // Close closes the factory
@@ -139,8 +186,8 @@ func (f *Factory) Close() {
 // uses reference counting to decide when to close the
 // underlying connection object. The reference count gets incremented
 // everytime get() is called and decremented everytime Close() is called
-func newRefCountedDBConn(cfg *config.SQL) dbConn {
-	return dbConn{cfg: cfg}
+func newRefCountedDBConn(open dbOpener) dbConn {
+	return dbConn{open: open}
 }
 
 // get returns a mysql db connection and increments a reference count
@@ -150,7 +197,7 @@ func (c *dbConn) get() (sqldb.Interface, error) {
 	c.Lock()
 	defer c.Unlock()
 	if c.refCnt == 0 {
-		conn, err := storage.NewSQLDB(c.cfg)
+		conn, err := c.open()
 		if err != nil {
 			return nil, err
 		}
@@ -0,0 +1,65 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package sql
+
+import (
+	"fmt"
+
+	"github.com/pborman/uuid"
+	workflow "github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common/log"
+	"github.com/uber/cadence/common/persistence"
+	"github.com/uber/cadence/common/persistence/sql/storage/sqldb"
+)
+
+type sqlAuditManager struct {
+	sqlStore
+}
+
+// newAuditPersistence creates an instance of AuditManager
+func newAuditPersistence(db sqldb.Interface, log log.Logger) (persistence.AuditManager, error) {
+	return &sqlAuditManager{
+		sqlStore: sqlStore{
+			db:     db,
+			logger: log,
+		},
+	}, nil
+}
+
+func (m *sqlAuditManager) RecordAuditEntry(request *persistence.RecordAuditEntryRequest) error {
+	entry := request.Entry
+	row := &sqldb.AuditLogRow{
+		EntryID:       sqldb.MustParseUUID(uuid.New()),
+		Timestamp:     entry.Timestamp,
+		Caller:        entry.Caller,
+		CallerService: entry.CallerService,
+		Operation:     entry.Operation,
+		Domain:        entry.Domain,
+		RequestHash:   entry.RequestHash,
+		Error:         entry.Error,
+	}
+	if _, err := m.db.InsertIntoAuditLog(row); err != nil {
+		return &workflow.InternalServiceError{
+			Message: fmt.Sprintf("RecordAuditEntry operation failed. Error: %v", err),
+		}
+	}
+	return nil
+}
@@ -0,0 +1,105 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package sql
+
+import (
+	"fmt"
+	"time"
+
+	workflow "github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common/log"
+	"github.com/uber/cadence/common/persistence"
+	"github.com/uber/cadence/common/persistence/sql/storage/sqldb"
+)
+
+type sqlClusterMembershipManager struct {
+	sqlStore
+}
+
+// newClusterMembershipPersistence creates an instance of ClusterMembershipManager
+func newClusterMembershipPersistence(db sqldb.Interface, log log.Logger) (persistence.ClusterMembershipManager, error) {
+	return &sqlClusterMembershipManager{
+		sqlStore: sqlStore{
+			db:     db,
+			logger: log,
+		},
+	}, nil
+}
+
+func (m *sqlClusterMembershipManager) UpsertClusterMembership(request *persistence.UpsertClusterMembershipRequest) error {
+	now := time.Now()
+	row := &sqldb.ClusterMembershipRow{
+		Role:          request.Role,
+		RPCAddress:    request.RPCAddress,
+		SessionStart:  request.SessionStart,
+		LastHeartbeat: now,
+		RecordExpiry:  now.Add(request.RecordExpiry),
+	}
+	if _, err := m.db.UpsertClusterMembership(row); err != nil {
+		return &workflow.InternalServiceError{
+			Message: fmt.Sprintf("UpsertClusterMembership operation failed. Error: %v", err),
+		}
+	}
+	return nil
+}
+
+func (m *sqlClusterMembershipManager) GetClusterMembers(request *persistence.GetClusterMembersRequest) (*persistence.GetClusterMembersResponse, error) {
+	now := time.Now()
+	filter := &sqldb.ClusterMembershipFilter{
+		Role:              request.Role,
+		RecordExpiryAfter: now,
+	}
+	if request.LastHeartbeatWithin > 0 {
+		filter.LastHeartbeatAfter = now.Add(-request.LastHeartbeatWithin)
+	}
+
+	rows, err := m.db.SelectFromClusterMembership(filter)
+	if err != nil {
+		return nil, &workflow.InternalServiceError{
+			Message: fmt.Sprintf("GetClusterMembers operation failed. Error: %v", err),
+		}
+	}
+
+	members := make([]*persistence.ClusterMember, 0, len(rows))
+	for _, row := range rows {
+		members = append(members, &persistence.ClusterMember{
+			Role:          row.Role,
+			RPCAddress:    row.RPCAddress,
+			SessionStart:  row.SessionStart,
+			LastHeartbeat: row.LastHeartbeat,
+			RecordExpiry:  row.RecordExpiry,
+		})
+	}
+	return &persistence.GetClusterMembersResponse{ActiveMembers: members}, nil
+}
+
+func (m *sqlClusterMembershipManager) PruneClusterMembership(request *persistence.PruneClusterMembershipRequest) error {
+	filter := &sqldb.ClusterMembershipFilter{
+		RecordExpiryBefore: time.Now(),
+		MaxRecordsAffected: request.MaxRecordsPruned,
+	}
+	if _, err := m.db.DeleteFromClusterMembership(filter); err != nil {
+		return &workflow.InternalServiceError{
+			Message: fmt.Sprintf("PruneClusterMembership operation failed. Error: %v", err),
+		}
+	}
+	return nil
+}
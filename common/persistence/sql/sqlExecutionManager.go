@@ -82,6 +82,10 @@ func (m *sqlExecutionManager) GetShardID() int {
 	return m.shardID
 }
 
+func (m *sqlExecutionManager) Ping() error {
+	return m.ping(m.shardID)
+}
+
 func (m *sqlExecutionManager) CreateWorkflowExecution(
 	request *p.InternalCreateWorkflowExecutionRequest,
 ) (response *p.CreateWorkflowExecutionResponse, err error) {
@@ -130,6 +134,7 @@ func (m *sqlExecutionManager) createWorkflowExecutionTx(
 				State:            int(row.State),
 				CloseStatus:      int(row.CloseStatus),
 				LastWriteVersion: row.LastWriteVersion,
+				BlockedUntil:     row.BlockedUntil,
 			}
 		case p.CreateWorkflowModeWorkflowIDReuse:
 			if request.PreviousLastWriteVersion != row.LastWriteVersion {
@@ -209,51 +214,63 @@ func (m *sqlExecutionManager) GetWorkflowExecution(
 
 	var state p.InternalWorkflowMutableState
 	state.ExecutionInfo = &p.InternalWorkflowExecutionInfo{
-		DomainID:                     execution.DomainID.String(),
-		WorkflowID:                   execution.WorkflowID,
-		RunID:                        execution.RunID.String(),
-		NextEventID:                  execution.NextEventID,
-		TaskList:                     info.GetTaskList(),
-		WorkflowTypeName:             info.GetWorkflowTypeName(),
-		WorkflowTimeout:              info.GetWorkflowTimeoutSeconds(),
-		DecisionTimeoutValue:         info.GetDecisionTaskTimeoutSeconds(),
-		State:                        int(info.GetState()),
-		CloseStatus:                  int(info.GetCloseStatus()),
-		LastFirstEventID:             info.GetLastFirstEventID(),
-		LastProcessedEvent:           info.GetLastProcessedEvent(),
-		StartTimestamp:               time.Unix(0, info.GetStartTimeNanos()),
-		LastUpdatedTimestamp:         time.Unix(0, info.GetLastUpdatedTimeNanos()),
-		CreateRequestID:              info.GetCreateRequestID(),
-		DecisionVersion:              info.GetDecisionVersion(),
-		DecisionScheduleID:           info.GetDecisionScheduleID(),
-		DecisionStartedID:            info.GetDecisionStartedID(),
-		DecisionRequestID:            info.GetDecisionRequestID(),
-		DecisionTimeout:              info.GetDecisionTimeout(),
-		DecisionAttempt:              info.GetDecisionAttempt(),
-		DecisionStartedTimestamp:     info.GetDecisionStartedTimestampNanos(),
-		DecisionScheduledTimestamp:   info.GetDecisionScheduledTimestampNanos(),
-		StickyTaskList:               info.GetStickyTaskList(),
-		StickyScheduleToStartTimeout: int32(info.GetStickyScheduleToStartTimeout()),
-		ClientLibraryVersion:         info.GetClientLibraryVersion(),
-		ClientFeatureVersion:         info.GetClientFeatureVersion(),
-		ClientImpl:                   info.GetClientImpl(),
-		SignalCount:                  int32(info.GetSignalCount()),
-		HistorySize:                  info.GetHistorySize(),
-		CronSchedule:                 info.GetCronSchedule(),
-		CompletionEventBatchID:       common.EmptyEventID,
-		HasRetryPolicy:               info.GetHasRetryPolicy(),
-		Attempt:                      int32(info.GetRetryAttempt()),
-		InitialInterval:              info.GetRetryInitialIntervalSeconds(),
-		BackoffCoefficient:           info.GetRetryBackoffCoefficient(),
-		MaximumInterval:              info.GetRetryMaximumIntervalSeconds(),
-		MaximumAttempts:              info.GetRetryMaximumAttempts(),
-		ExpirationSeconds:            info.GetRetryExpirationSeconds(),
-		ExpirationTime:               time.Unix(0, info.GetRetryExpirationTimeNanos()),
-		EventStoreVersion:            info.GetEventStoreVersion(),
-		BranchToken:                  info.GetEventBranchToken(),
-		ExecutionContext:             info.GetExecutionContext(),
-		NonRetriableErrors:           info.GetRetryNonRetryableErrors(),
-		SearchAttributes:             info.GetSearchAttributes(),
+		DomainID:                      execution.DomainID.String(),
+		WorkflowID:                    execution.WorkflowID,
+		RunID:                         execution.RunID.String(),
+		NextEventID:                   execution.NextEventID,
+		TaskList:                      info.GetTaskList(),
+		WorkflowTypeName:              info.GetWorkflowTypeName(),
+		WorkflowTimeout:               info.GetWorkflowTimeoutSeconds(),
+		DecisionTimeoutValue:          info.GetDecisionTaskTimeoutSeconds(),
+		State:                         int(info.GetState()),
+		CloseStatus:                   int(info.GetCloseStatus()),
+		LastFirstEventID:              info.GetLastFirstEventID(),
+		LastProcessedEvent:            info.GetLastProcessedEvent(),
+		StartTimestamp:                time.Unix(0, info.GetStartTimeNanos()),
+		LastUpdatedTimestamp:          time.Unix(0, info.GetLastUpdatedTimeNanos()),
+		CreateRequestID:               info.GetCreateRequestID(),
+		DecisionVersion:               info.GetDecisionVersion(),
+		DecisionScheduleID:            info.GetDecisionScheduleID(),
+		DecisionStartedID:             info.GetDecisionStartedID(),
+		DecisionRequestID:             info.GetDecisionRequestID(),
+		DecisionTimeout:               info.GetDecisionTimeout(),
+		DecisionAttempt:               info.GetDecisionAttempt(),
+		DecisionHeartbeatCount:        info.GetDecisionHeartbeatCount(),
+		DecisionStartedTimestamp:      info.GetDecisionStartedTimestampNanos(),
+		DecisionScheduledTimestamp:    info.GetDecisionScheduledTimestampNanos(),
+		DecisionStartedIdentity:       info.GetDecisionStartedIdentity(),
+		DecisionStartedBinaryChecksum: info.GetDecisionStartedBinaryChecksum(),
+		ResetBaseEventID:              info.GetResetBaseEventID(),
+		ResetReason:                   info.GetResetReason(),
+		StickyTaskList:                info.GetStickyTaskList(),
+		StickyScheduleToStartTimeout:  int32(info.GetStickyScheduleToStartTimeout()),
+		ClientLibraryVersion:          info.GetClientLibraryVersion(),
+		ClientFeatureVersion:          info.GetClientFeatureVersion(),
+		ClientImpl:                    info.GetClientImpl(),
+		SignalCount:                   int32(info.GetSignalCount()),
+		HistorySize:                   info.GetHistorySize(),
+		CronSchedule:                  info.GetCronSchedule(),
+		CompletionEventBatchID:        common.EmptyEventID,
+		HasRetryPolicy:                info.GetHasRetryPolicy(),
+		Attempt:                       int32(info.GetRetryAttempt()),
+		InitialInterval:               info.GetRetryInitialIntervalSeconds(),
+		BackoffCoefficient:            info.GetRetryBackoffCoefficient(),
+		MaximumInterval:               info.GetRetryMaximumIntervalSeconds(),
+		MaximumAttempts:               info.GetRetryMaximumAttempts(),
+		ExpirationSeconds:             info.GetRetryExpirationSeconds(),
+		ExpirationTime:                time.Unix(0, info.GetRetryExpirationTimeNanos()),
+		EventStoreVersion:             info.GetEventStoreVersion(),
+		BranchToken:                   info.GetEventBranchToken(),
+		ExecutionContext:              info.GetExecutionContext(),
+		NonRetriableErrors:            info.GetRetryNonRetryableErrors(),
+		SearchAttributes:              info.GetSearchAttributes(),
+		CompletedActivityRequestIDs:   info.GetCompletedActivityRequestIDs(),
+		Paused:                        info.GetPaused(),
+		CronPaused:                    info.GetCronPaused(),
+		CronTimezone:                  info.GetCronTimezone(),
+		Notes:                         info.GetNotes(),
+		NotesIdentity:                 info.GetNotesIdentity(),
+		NotesLastUpdatedTime:          time.Unix(0, info.GetNotesLastUpdatedTimeNanos()),
 	}
 
 	if info.LastWriteEventID != nil {
@@ -268,6 +285,14 @@ func (m *sqlExecutionManager) GetWorkflowExecution(
 		}
 	}
 
+	if info.FirstExecutionRunID != nil {
+		state.ExecutionInfo.FirstExecutionRunID = sqldb.UUID(info.FirstExecutionRunID).String()
+	}
+
+	if info.ResetBaseRunID != nil {
+		state.ExecutionInfo.ResetBaseRunID = sqldb.UUID(info.ResetBaseRunID).String()
+	}
+
 	if info.ParentDomainID != nil {
 		state.ExecutionInfo.ParentDomainID = sqldb.UUID(info.ParentDomainID).String()
 		state.ExecutionInfo.ParentWorkflowID = info.GetParentWorkflowID()
@@ -367,6 +392,20 @@ func (m *sqlExecutionManager) GetWorkflowExecution(
 		}
 	}
 
+	{
+		var err error
+		state.UpdateInfos, err = getUpdateInfoMap(m.db,
+			m.shardID,
+			domainID,
+			wfID,
+			runID)
+		if err != nil {
+			return nil, &workflow.InternalServiceError{
+				Message: fmt.Sprintf("GetWorkflowExecution failed. Failed to get update info. Error: %v", err),
+			}
+		}
+	}
+
 	{
 		var err error
 		state.BufferedEvents, err = getBufferedEvents(m.db,
@@ -650,20 +689,47 @@ func (m *sqlExecutionManager) DeleteWorkflowExecution(
 // its possible for a new run of the same workflow to have started after the run we are deleting
 // here was finished. In that case, current_executions table will have the same workflowID but different
 // runID. The following code will delete the row from current_executions if and only if the runID is
-// same as the one we are trying to delete here
+// same as the one we are trying to delete here, unless the request is forced
 func (m *sqlExecutionManager) DeleteCurrentWorkflowExecution(
 	request *p.DeleteCurrentWorkflowExecutionRequest,
-) error {
+) (*p.DeleteCurrentWorkflowExecutionResponse, error) {
 
 	domainID := sqldb.MustParseUUID(request.DomainID)
 	runID := sqldb.MustParseUUID(request.RunID)
-	_, err := m.db.DeleteFromCurrentExecutions(&sqldb.CurrentExecutionsFilter{
-		ShardID:    int64(m.shardID),
-		DomainID:   domainID,
-		WorkflowID: request.WorkflowID,
-		RunID:      runID,
+	resp := &p.DeleteCurrentWorkflowExecutionResponse{}
+	err := m.txExecute("DeleteCurrentWorkflowExecution", func(tx sqldb.Tx) error {
+		currentRow, err := tx.LockCurrentExecutions(&sqldb.CurrentExecutionsFilter{
+			ShardID:    int64(m.shardID),
+			DomainID:   domainID,
+			WorkflowID: request.WorkflowID,
+		})
+		if err != nil {
+			if err == sql.ErrNoRows {
+				resp.Result = p.DeleteCurrentWorkflowExecutionResultMissing
+				return nil
+			}
+			return err
+		}
+		resp.CurrentRunID = currentRow.RunID.String()
+		if !request.Force && !bytes.Equal(currentRow.RunID, runID) {
+			resp.Result = p.DeleteCurrentWorkflowExecutionResultMismatched
+			return nil
+		}
+		if _, err := tx.DeleteFromCurrentExecutions(&sqldb.CurrentExecutionsFilter{
+			ShardID:    int64(m.shardID),
+			DomainID:   domainID,
+			WorkflowID: request.WorkflowID,
+			RunID:      currentRow.RunID,
+		}); err != nil {
+			return err
+		}
+		resp.Result = p.DeleteCurrentWorkflowExecutionResultDeleted
+		return nil
 	})
-	return err
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
 }
 
 func (m *sqlExecutionManager) GetCurrentExecution(
@@ -689,9 +755,159 @@ func (m *sqlExecutionManager) GetCurrentExecution(
 		State:            int(row.State),
 		CloseStatus:      int(row.CloseStatus),
 		LastWriteVersion: row.LastWriteVersion,
+		BlockedUntil:     row.BlockedUntil,
 	}, nil
 }
 
+func (m *sqlExecutionManager) ListWorkflowExecutionRuns(
+	request *p.ListWorkflowExecutionRunsRequest,
+) (*p.ListWorkflowExecutionRunsResponse, error) {
+
+	// the page token is the last run ID of the previous page, run IDs are never all zeros
+	minRunID := make(sqldb.UUID, 16)
+	if len(request.NextPageToken) > 0 {
+		minRunID = sqldb.UUID(request.NextPageToken)
+	}
+	rows, err := m.db.SelectRunsFromExecutions(&sqldb.ExecutionsFilter{
+		ShardID:    m.shardID,
+		DomainID:   sqldb.MustParseUUID(request.DomainID),
+		WorkflowID: request.WorkflowID,
+		RunID:      minRunID,
+		PageSize:   common.IntPtr(request.PageSize),
+	})
+	if err != nil && err != sql.ErrNoRows {
+		return nil, &workflow.InternalServiceError{
+			Message: fmt.Sprintf("ListWorkflowExecutionRuns operation failed. Select failed. Error: %v", err),
+		}
+	}
+
+	resp := &p.ListWorkflowExecutionRunsResponse{}
+	for _, row := range rows {
+		info, err := workflowExecutionInfoFromBlob(row.Data, row.DataEncoding)
+		if err != nil {
+			return nil, err
+		}
+		run := &p.WorkflowExecutionRunInfo{
+			RunID:          row.RunID.String(),
+			StartTimestamp: time.Unix(0, info.GetStartTimeNanos()),
+			State:          int(info.GetState()),
+			CloseStatus:    int(info.GetCloseStatus()),
+		}
+		if info.FirstExecutionRunID != nil {
+			run.FirstExecutionRunID = sqldb.UUID(info.FirstExecutionRunID).String()
+		}
+		resp.Runs = append(resp.Runs, run)
+	}
+	if len(rows) == request.PageSize {
+		resp.NextPageToken = rows[len(rows)-1].RunID
+	}
+	return resp, nil
+}
+
+type currentExecutionsPageToken struct {
+	DomainID   sqldb.UUID
+	WorkflowID string
+}
+
+func (t *currentExecutionsPageToken) serialize() ([]byte, error) {
+	return json.Marshal(t)
+}
+
+func (t *currentExecutionsPageToken) deserialize(payload []byte) error {
+	return json.Unmarshal(payload, t)
+}
+
+func (m *sqlExecutionManager) ListCurrentExecutions(
+	request *p.ListCurrentExecutionsRequest,
+) (*p.ListCurrentExecutionsResponse, error) {
+
+	// the page token is the last workflow of the previous page
+	pageToken := &currentExecutionsPageToken{
+		DomainID:   make(sqldb.UUID, 16),
+		WorkflowID: "",
+	}
+	if len(request.NextPageToken) > 0 {
+		if err := pageToken.deserialize(request.NextPageToken); err != nil {
+			return nil, &workflow.InternalServiceError{
+				Message: fmt.Sprintf("error deserializing currentExecutionsPageToken: %v", err),
+			}
+		}
+	}
+
+	rows, err := m.db.RangeSelectFromCurrentExecutions(&sqldb.CurrentExecutionsFilter{
+		ShardID:    int64(m.shardID),
+		DomainID:   pageToken.DomainID,
+		WorkflowID: pageToken.WorkflowID,
+		PageSize:   common.IntPtr(request.PageSize),
+	})
+	if err != nil && err != sql.ErrNoRows {
+		return nil, &workflow.InternalServiceError{
+			Message: fmt.Sprintf("ListCurrentExecutions operation failed. Select failed. Error: %v", err),
+		}
+	}
+
+	resp := &p.ListCurrentExecutionsResponse{}
+	for _, row := range rows {
+		resp.Executions = append(resp.Executions, &p.CurrentExecutionInfo{
+			DomainID:    row.DomainID.String(),
+			WorkflowID:  row.WorkflowID,
+			RunID:       row.RunID.String(),
+			State:       row.State,
+			CloseStatus: row.CloseStatus,
+		})
+	}
+	if len(rows) == request.PageSize {
+		last := rows[len(rows)-1]
+		pageToken = &currentExecutionsPageToken{
+			DomainID:   last.DomainID,
+			WorkflowID: last.WorkflowID,
+		}
+		nextToken, err := pageToken.serialize()
+		if err != nil {
+			return nil, &workflow.InternalServiceError{
+				Message: fmt.Sprintf("ListCurrentExecutions: error serializing page token: %v", err),
+			}
+		}
+		resp.NextPageToken = nextToken
+	}
+	return resp, nil
+}
+
+func (m *sqlExecutionManager) BlockCurrentWorkflowExecution(
+	request *p.BlockCurrentWorkflowExecutionRequest,
+) error {
+
+	domainID := sqldb.MustParseUUID(request.DomainID)
+	runID := sqldb.MustParseUUID(request.RunID)
+	return m.txExecute("BlockCurrentWorkflowExecution", func(tx sqldb.Tx) error {
+		if err := assertCurrentExecution(tx, m.shardID, domainID, request.WorkflowID,
+			func(currentRow *sqldb.CurrentExecutionsRow) error {
+				if !bytes.Equal(currentRow.RunID, runID) {
+					return &p.ConditionFailedError{
+						Msg: fmt.Sprintf("BlockCurrentWorkflowExecution operation failed. Current run ID mismatch, expected: %v, actual: %v",
+							request.RunID, currentRow.RunID.String()),
+					}
+				}
+				return nil
+			}); err != nil {
+			return err
+		}
+
+		if _, err := tx.UpdateCurrentExecutionsBlockedUntil(&sqldb.CurrentExecutionsRow{
+			ShardID:      int64(m.shardID),
+			DomainID:     domainID,
+			WorkflowID:   request.WorkflowID,
+			RunID:        runID,
+			BlockedUntil: request.BlockedUntil,
+		}); err != nil {
+			return &workflow.InternalServiceError{
+				Message: fmt.Sprintf("BlockCurrentWorkflowExecution operation failed. Error: %v", err),
+			}
+		}
+		return nil
+	})
+}
+
 func (m *sqlExecutionManager) GetTransferTasks(
 	request *p.GetTransferTasksRequest,
 ) (*p.GetTransferTasksResponse, error) {
@@ -760,6 +976,68 @@ func (m *sqlExecutionManager) RangeCompleteTransferTask(
 	return nil
 }
 
+func (m *sqlExecutionManager) GetVisibilityTasks(
+	request *p.GetVisibilityTasksRequest,
+) (*p.GetVisibilityTasksResponse, error) {
+
+	rows, err := m.db.SelectFromVisibilityTasks(&sqldb.VisibilityTasksFilter{
+		ShardID: m.shardID, MinTaskID: &request.ReadLevel, MaxTaskID: &request.MaxReadLevel})
+	if err != nil {
+		if err != sql.ErrNoRows {
+			return nil, &workflow.InternalServiceError{
+				Message: fmt.Sprintf("GetVisibilityTasks operation failed. Select failed. Error: %v", err),
+			}
+		}
+	}
+	resp := &p.GetVisibilityTasksResponse{Tasks: make([]*p.VisibilityTaskInfo, len(rows))}
+	for i, row := range rows {
+		info, err := transferTaskInfoFromBlob(row.Data, row.DataEncoding)
+		if err != nil {
+			return nil, err
+		}
+		resp.Tasks[i] = &p.VisibilityTaskInfo{
+			TaskID:              row.TaskID,
+			DomainID:            sqldb.UUID(info.DomainID).String(),
+			WorkflowID:          info.GetWorkflowID(),
+			RunID:               sqldb.UUID(info.RunID).String(),
+			VisibilityTimestamp: time.Unix(0, info.GetVisibilityTimestampNanos()),
+			TaskType:            int(info.GetTaskType()),
+			Version:             info.GetVersion(),
+		}
+	}
+	return resp, nil
+}
+
+func (m *sqlExecutionManager) CompleteVisibilityTask(
+	request *p.CompleteVisibilityTaskRequest,
+) error {
+
+	if _, err := m.db.DeleteFromVisibilityTasks(&sqldb.VisibilityTasksFilter{
+		ShardID: m.shardID,
+		TaskID:  &request.TaskID,
+	}); err != nil {
+		return &workflow.InternalServiceError{
+			Message: fmt.Sprintf("CompleteVisibilityTask operation failed. Error: %v", err),
+		}
+	}
+	return nil
+}
+
+func (m *sqlExecutionManager) RangeCompleteVisibilityTask(
+	request *p.RangeCompleteVisibilityTaskRequest,
+) error {
+
+	if _, err := m.db.DeleteFromVisibilityTasks(&sqldb.VisibilityTasksFilter{
+		ShardID:   m.shardID,
+		MinTaskID: &request.ExclusiveBeginTaskID,
+		MaxTaskID: &request.InclusiveEndTaskID}); err != nil {
+		return &workflow.InternalServiceError{
+			Message: fmt.Sprintf("RangeCompleteVisibilityTask operation failed. Error: %v", err),
+		}
+	}
+	return nil
+}
+
 func (m *sqlExecutionManager) GetReplicationTasks(
 	request *p.GetReplicationTasksRequest,
 ) (*p.GetReplicationTasksResponse, error) {
@@ -854,6 +1132,21 @@ func (m *sqlExecutionManager) CompleteReplicationTask(
 	return nil
 }
 
+func (m *sqlExecutionManager) RangeCompleteReplicationTask(
+	request *p.RangeCompleteReplicationTaskRequest,
+) error {
+
+	if _, err := m.db.DeleteFromReplicationTasks(&sqldb.ReplicationTasksFilter{
+		ShardID:   m.shardID,
+		MaxTaskID: &request.InclusiveEndTaskID,
+	}); err != nil {
+		return &workflow.InternalServiceError{
+			Message: fmt.Sprintf("RangeCompleteReplicationTask operation failed. Error: %v", err),
+		}
+	}
+	return nil
+}
+
 type timerTaskPageToken struct {
 	TaskID    int64
 	Timestamp time.Time
@@ -965,3 +1258,195 @@ func (m *sqlExecutionManager) RangeCompleteTimerTask(
 	}
 	return nil
 }
+
+type closedExecutionIndexPageToken struct {
+	CloseTime  time.Time
+	DomainID   sqldb.UUID
+	WorkflowID string
+	RunID      sqldb.UUID
+}
+
+func (t *closedExecutionIndexPageToken) serialize() ([]byte, error) {
+	return json.Marshal(t)
+}
+
+func (t *closedExecutionIndexPageToken) deserialize(payload []byte) error {
+	return json.Unmarshal(payload, t)
+}
+
+func (m *sqlExecutionManager) GetClosedExecutionIndex(
+	request *p.GetClosedExecutionIndexRequest,
+) (*p.GetClosedExecutionIndexResponse, error) {
+
+	day := request.Day.UTC().Truncate(24 * time.Hour)
+	minCloseTime := day
+	if request.MinCloseTime.After(minCloseTime) {
+		minCloseTime = request.MinCloseTime
+	}
+	pageToken := &closedExecutionIndexPageToken{
+		CloseTime:  minCloseTime,
+		DomainID:   make(sqldb.UUID, 16),
+		WorkflowID: "",
+		RunID:      make(sqldb.UUID, 16),
+	}
+	if len(request.NextPageToken) > 0 {
+		if err := pageToken.deserialize(request.NextPageToken); err != nil {
+			return nil, &workflow.InternalServiceError{
+				Message: fmt.Sprintf("error deserializing closedExecutionIndexPageToken: %v", err),
+			}
+		}
+	}
+
+	maxCloseTime := day.Add(24 * time.Hour)
+	rows, err := m.db.SelectFromClosedExecutionsIndex(&sqldb.ClosedExecutionsIndexFilter{
+		ShardID:      m.shardID,
+		CloseTime:    pageToken.CloseTime,
+		DomainID:     pageToken.DomainID,
+		WorkflowID:   pageToken.WorkflowID,
+		RunID:        pageToken.RunID,
+		MaxCloseTime: &maxCloseTime,
+		PageSize:     common.IntPtr(request.BatchSize + 1),
+	})
+	if err != nil && err != sql.ErrNoRows {
+		return nil, &workflow.InternalServiceError{
+			Message: fmt.Sprintf("GetClosedExecutionIndex operation failed. Select failed. Error: %v", err),
+		}
+	}
+
+	resp := &p.GetClosedExecutionIndexResponse{}
+	if len(rows) > request.BatchSize {
+		next := rows[request.BatchSize]
+		pageToken = &closedExecutionIndexPageToken{
+			CloseTime:  next.CloseTime,
+			DomainID:   next.DomainID,
+			WorkflowID: next.WorkflowID,
+			RunID:      next.RunID,
+		}
+		nextToken, err := pageToken.serialize()
+		if err != nil {
+			return nil, &workflow.InternalServiceError{
+				Message: fmt.Sprintf("GetClosedExecutionIndex: error serializing page token: %v", err),
+			}
+		}
+		resp.NextPageToken = nextToken
+		rows = rows[:request.BatchSize]
+	}
+
+	for _, row := range rows {
+		resp.Executions = append(resp.Executions, &p.ClosedExecutionIndexInfo{
+			DomainID:   row.DomainID.String(),
+			WorkflowID: row.WorkflowID,
+			RunID:      row.RunID.String(),
+			CloseTime:  row.CloseTime,
+			Version:    row.Version,
+		})
+	}
+	return resp, nil
+}
+
+func (m *sqlExecutionManager) DeleteClosedExecutionIndex(
+	request *p.DeleteClosedExecutionIndexRequest,
+) error {
+
+	if _, err := m.db.DeleteFromClosedExecutionsIndex(&sqldb.ClosedExecutionsIndexFilter{
+		ShardID:    m.shardID,
+		CloseTime:  request.CloseTime,
+		DomainID:   sqldb.MustParseUUID(request.DomainID),
+		WorkflowID: request.WorkflowID,
+		RunID:      sqldb.MustParseUUID(request.RunID),
+	}); err != nil {
+		return &workflow.InternalServiceError{
+			Message: fmt.Sprintf("DeleteClosedExecutionIndex operation failed. Error: %v", err),
+		}
+	}
+	return nil
+}
+
+type bufferedReplicationTasksPageToken struct {
+	DomainID   sqldb.UUID
+	WorkflowID string
+	RunID      sqldb.UUID
+}
+
+func (t *bufferedReplicationTasksPageToken) serialize() ([]byte, error) {
+	return json.Marshal(t)
+}
+
+func (t *bufferedReplicationTasksPageToken) deserialize(payload []byte) error {
+	return json.Unmarshal(payload, t)
+}
+
+func (m *sqlExecutionManager) ListBufferedReplicationTasks(
+	request *p.ListBufferedReplicationTasksRequest,
+) (*p.ListBufferedReplicationTasksResponse, error) {
+
+	// the page token is the last execution of the previous page
+	pageToken := &bufferedReplicationTasksPageToken{
+		DomainID:   make(sqldb.UUID, 16),
+		WorkflowID: "",
+		RunID:      make(sqldb.UUID, 16),
+	}
+	if len(request.NextPageToken) > 0 {
+		if err := pageToken.deserialize(request.NextPageToken); err != nil {
+			return nil, &workflow.InternalServiceError{
+				Message: fmt.Sprintf("error deserializing bufferedReplicationTasksPageToken: %v", err),
+			}
+		}
+	}
+
+	rows, err := m.db.SelectCountsFromBufferedReplicationTaskMaps(&sqldb.BufferedReplicationTaskMapsFilter{
+		ShardID:    m.shardID,
+		DomainID:   pageToken.DomainID,
+		WorkflowID: pageToken.WorkflowID,
+		RunID:      pageToken.RunID,
+		PageSize:   common.IntPtr(request.PageSize),
+	})
+	if err != nil && err != sql.ErrNoRows {
+		return nil, &workflow.InternalServiceError{
+			Message: fmt.Sprintf("ListBufferedReplicationTasks operation failed. Select failed. Error: %v", err),
+		}
+	}
+
+	resp := &p.ListBufferedReplicationTasksResponse{}
+	for _, row := range rows {
+		resp.Executions = append(resp.Executions, &p.BufferedReplicationTasksInfo{
+			DomainID:   row.DomainID.String(),
+			WorkflowID: row.WorkflowID,
+			RunID:      row.RunID.String(),
+			TaskCount:  row.TaskCount,
+		})
+	}
+	if len(rows) == request.PageSize {
+		last := rows[len(rows)-1]
+		pageToken = &bufferedReplicationTasksPageToken{
+			DomainID:   last.DomainID,
+			WorkflowID: last.WorkflowID,
+			RunID:      last.RunID,
+		}
+		nextToken, err := pageToken.serialize()
+		if err != nil {
+			return nil, &workflow.InternalServiceError{
+				Message: fmt.Sprintf("ListBufferedReplicationTasks: error serializing page token: %v", err),
+			}
+		}
+		resp.NextPageToken = nextToken
+	}
+	return resp, nil
+}
+
+func (m *sqlExecutionManager) DeleteBufferedReplicationTasks(
+	request *p.DeleteBufferedReplicationTasksRequest,
+) error {
+
+	if _, err := m.db.DeleteFromBufferedReplicationTaskMaps(&sqldb.BufferedReplicationTaskMapsFilter{
+		ShardID:    m.shardID,
+		DomainID:   sqldb.MustParseUUID(request.DomainID),
+		WorkflowID: request.WorkflowID,
+		RunID:      sqldb.MustParseUUID(request.RunID),
+	}); err != nil {
+		return &workflow.InternalServiceError{
+			Message: fmt.Sprintf("DeleteBufferedReplicationTasks operation failed. Error: %v", err),
+		}
+	}
+	return nil
+}
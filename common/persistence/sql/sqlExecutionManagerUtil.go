@@ -77,10 +77,20 @@ func applyWorkflowMutationTx(
 		runID,
 		workflowMutation.TransferTasks,
 		workflowMutation.ReplicationTasks,
-		workflowMutation.TimerTasks); err != nil {
+		workflowMutation.TimerTasks,
+		workflowMutation.VisibilityTasks); err != nil {
 		return err
 	}
 
+	if err := createClosedExecutionIndex(tx,
+		shardID,
+		executionInfo,
+		workflowMutation.TransferTasks); err != nil {
+		return &workflow.InternalServiceError{
+			Message: fmt.Sprintf("UpdateWorkflowExecution operation failed. Failed to index closed execution. Error: %v", err),
+		}
+	}
+
 	if err := updateActivityInfos(tx,
 		workflowMutation.UpsertActivityInfos,
 		workflowMutation.DeleteActivityInfos,
@@ -143,7 +153,19 @@ func applyWorkflowMutationTx(
 
 	if err := updateSignalsRequested(tx,
 		workflowMutation.UpsertSignalRequestedIDs,
-		workflowMutation.DeleteSignalRequestedID,
+		workflowMutation.DeleteSignalRequestedIDs,
+		shardID,
+		domainID,
+		workflowID,
+		runID); err != nil {
+		return &workflow.InternalServiceError{
+			Message: fmt.Sprintf("UpdateWorkflowExecution operation failed. Error: %v", err),
+		}
+	}
+
+	if err := updateUpdateInfos(tx,
+		workflowMutation.UpsertUpdateInfos,
+		workflowMutation.DeleteUpdateInfo,
 		shardID,
 		domainID,
 		workflowID,
@@ -223,10 +245,20 @@ func applyWorkflowSnapshotTxAsReset(
 		runID,
 		workflowSnapshot.TransferTasks,
 		workflowSnapshot.ReplicationTasks,
-		workflowSnapshot.TimerTasks); err != nil {
+		workflowSnapshot.TimerTasks,
+		workflowSnapshot.VisibilityTasks); err != nil {
 		return err
 	}
 
+	if err := createClosedExecutionIndex(tx,
+		shardID,
+		executionInfo,
+		workflowSnapshot.TransferTasks); err != nil {
+		return &workflow.InternalServiceError{
+			Message: fmt.Sprintf("UpdateWorkflowExecution operation failed. Failed to index closed execution. Error: %v", err),
+		}
+	}
+
 	if err := deleteActivityInfoMap(tx,
 		shardID,
 		domainID,
@@ -359,6 +391,28 @@ func applyWorkflowSnapshotTxAsReset(
 		}
 	}
 
+	if err := deleteUpdateInfoMap(tx,
+		shardID,
+		domainID,
+		workflowID,
+		runID); err != nil {
+		return &workflow.InternalServiceError{
+			Message: fmt.Sprintf("ResetMutableState operation failed. Failed to clear update info map. Error: %v", err),
+		}
+	}
+
+	if err := updateUpdateInfos(tx,
+		workflowSnapshot.UpdateInfos,
+		nil,
+		shardID,
+		domainID,
+		workflowID,
+		runID); err != nil {
+		return &workflow.InternalServiceError{
+			Message: fmt.Sprintf("ResetMutableState operation failed. Failed to insert into update info map after clearing. Error: %v", err),
+		}
+	}
+
 	if err := deleteBufferedEvents(tx,
 		shardID,
 		domainID,
@@ -399,10 +453,20 @@ func applyWorkflowSnapshotTxAsNew(
 		runID,
 		workflowSnapshot.TransferTasks,
 		workflowSnapshot.ReplicationTasks,
-		workflowSnapshot.TimerTasks); err != nil {
+		workflowSnapshot.TimerTasks,
+		workflowSnapshot.VisibilityTasks); err != nil {
 		return err
 	}
 
+	if err := createClosedExecutionIndex(tx,
+		shardID,
+		executionInfo,
+		workflowSnapshot.TransferTasks); err != nil {
+		return &workflow.InternalServiceError{
+			Message: fmt.Sprintf("UpdateWorkflowExecution operation failed. Failed to index closed execution. Error: %v", err),
+		}
+	}
+
 	if err := updateActivityInfos(tx,
 		workflowSnapshot.ActivityInfos,
 		nil,
@@ -475,6 +539,18 @@ func applyWorkflowSnapshotTxAsNew(
 		}
 	}
 
+	if err := updateUpdateInfos(tx,
+		workflowSnapshot.UpdateInfos,
+		nil,
+		shardID,
+		domainID,
+		workflowID,
+		runID); err != nil {
+		return &workflow.InternalServiceError{
+			Message: fmt.Sprintf("ResetMutableState operation failed. Failed to insert into update info map after clearing. Error: %v", err),
+		}
+	}
+
 	return nil
 }
 
@@ -487,6 +563,7 @@ func applyTasks(
 	transferTasks []p.Task,
 	replicationTasks []p.Task,
 	timerTasks []p.Task,
+	visibilityTasks []p.Task,
 ) error {
 
 	if err := createTransferTasks(tx,
@@ -522,6 +599,17 @@ func applyTasks(
 		}
 	}
 
+	if err := createVisibilityTasks(tx,
+		visibilityTasks,
+		shardID,
+		domainID,
+		workflowID,
+		runID); err != nil {
+		return &workflow.InternalServiceError{
+			Message: fmt.Sprintf("UpdateWorkflowExecution operation failed. Failed to create visibility tasks. Error: %v", err),
+		}
+	}
+
 	return nil
 }
 
@@ -745,6 +833,14 @@ func createTransferTasks(
 			info.TargetWorkflowID = &task.(*p.StartChildExecutionTask).TargetWorkflowID
 			info.ScheduleID = &task.(*p.StartChildExecutionTask).InitiatedID
 
+		case p.TransferTaskTypeRecordChildExecutionCompleted:
+			info.TargetDomainID = sqldb.MustParseUUID(task.(*p.RecordChildExecutionCompletedTask).TargetDomainID)
+			info.TargetWorkflowID = &task.(*p.RecordChildExecutionCompletedTask).TargetWorkflowID
+			if task.(*p.RecordChildExecutionCompletedTask).TargetRunID != "" {
+				info.TargetRunID = sqldb.MustParseUUID(task.(*p.RecordChildExecutionCompletedTask).TargetRunID)
+			}
+			info.ScheduleID = &task.(*p.RecordChildExecutionCompletedTask).InitiatedID
+
 		case p.TransferTaskTypeCloseExecution,
 			p.TransferTaskTypeRecordWorkflowStarted,
 			p.TransferTaskTypeResetWorkflow,
@@ -792,6 +888,103 @@ func createTransferTasks(
 	return nil
 }
 
+// createVisibilityTasks persists visibility tasks, reusing the transfer task blob since
+// visibility tasks carry a subset of its fields
+func createVisibilityTasks(
+	tx sqldb.Tx,
+	visibilityTasks []p.Task,
+	shardID int,
+	domainID sqldb.UUID,
+	workflowID string,
+	runID sqldb.UUID,
+) error {
+
+	if len(visibilityTasks) == 0 {
+		return nil
+	}
+
+	visibilityTasksRows := make([]sqldb.VisibilityTasksRow, len(visibilityTasks))
+	for i, task := range visibilityTasks {
+		switch task.GetType() {
+		case p.VisibilityTaskTypeRecordWorkflowStarted,
+			p.VisibilityTaskTypeUpsertWorkflowSearchAttributes,
+			p.VisibilityTaskTypeCloseExecution:
+			// No explicit property needs to be set
+
+		default:
+			return &workflow.InternalServiceError{
+				Message: fmt.Sprintf("Unknow visibility type: %v", task.GetType()),
+			}
+		}
+
+		info := &sqlblobs.TransferTaskInfo{
+			DomainID:                 domainID,
+			WorkflowID:               &workflowID,
+			RunID:                    runID,
+			TaskType:                 common.Int16Ptr(int16(task.GetType())),
+			Version:                  common.Int64Ptr(task.GetVersion()),
+			VisibilityTimestampNanos: common.Int64Ptr(task.GetVisibilityTimestamp().UnixNano()),
+		}
+		blob, err := transferTaskInfoToBlob(info)
+		if err != nil {
+			return err
+		}
+
+		visibilityTasksRows[i].ShardID = shardID
+		visibilityTasksRows[i].TaskID = task.GetTaskID()
+		visibilityTasksRows[i].Data = blob.Data
+		visibilityTasksRows[i].DataEncoding = string(blob.Encoding)
+	}
+
+	result, err := tx.InsertIntoVisibilityTasks(visibilityTasksRows)
+	if err != nil {
+		return &workflow.InternalServiceError{
+			Message: fmt.Sprintf("Failed to create visibility tasks. Error: %v", err),
+		}
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return &workflow.InternalServiceError{
+			Message: fmt.Sprintf("Failed to create visibility tasks. Could not verify number of rows inserted. Error: %v", err),
+		}
+	}
+
+	if int(rowsAffected) != len(visibilityTasks) {
+		return &workflow.InternalServiceError{
+			Message: fmt.Sprintf("Failed to create visibility tasks. Inserted %v instead of %v rows into visibility_tasks. Error: %v", rowsAffected, len(visibilityTasks), err),
+		}
+	}
+
+	return nil
+}
+
+// createClosedExecutionIndex indexes the execution by its close time when the tasks close it
+func createClosedExecutionIndex(
+	tx sqldb.Tx,
+	shardID int,
+	executionInfo *p.InternalWorkflowExecutionInfo,
+	transferTasks []p.Task,
+) error {
+
+	for _, task := range transferTasks {
+		if task.GetType() != p.TransferTaskTypeCloseExecution {
+			continue
+		}
+
+		_, err := tx.InsertIntoClosedExecutionsIndex([]sqldb.ClosedExecutionsIndexRow{{
+			ShardID:    shardID,
+			CloseTime:  executionInfo.LastUpdatedTimestamp,
+			DomainID:   sqldb.MustParseUUID(executionInfo.DomainID),
+			WorkflowID: executionInfo.WorkflowID,
+			RunID:      sqldb.MustParseUUID(executionInfo.RunID),
+			Version:    task.GetVersion(),
+		}})
+		return err
+	}
+	return nil
+}
+
 func createReplicationTasks(
 	tx sqldb.Tx,
 	replicationTasks []p.Task,
@@ -937,6 +1130,9 @@ func createTimerTasks(
 			case *p.WorkflowTimeoutTask:
 				// noop
 
+			case *p.WorkflowSLATimerTask:
+				// noop
+
 			case *p.DeleteHistoryEventTask:
 				// noop
 
@@ -1156,8 +1352,13 @@ func buildExecutionRow(
 		DecisionRequestID:               &executionInfo.DecisionRequestID,
 		DecisionTimeout:                 &executionInfo.DecisionTimeout,
 		DecisionAttempt:                 &executionInfo.DecisionAttempt,
+		DecisionHeartbeatCount:          &executionInfo.DecisionHeartbeatCount,
 		DecisionStartedTimestampNanos:   &executionInfo.DecisionStartedTimestamp,
 		DecisionScheduledTimestampNanos: &executionInfo.DecisionScheduledTimestamp,
+		DecisionStartedIdentity:         &executionInfo.DecisionStartedIdentity,
+		DecisionStartedBinaryChecksum:   &executionInfo.DecisionStartedBinaryChecksum,
+		ResetBaseEventID:                &executionInfo.ResetBaseEventID,
+		ResetReason:                     &executionInfo.ResetReason,
 		StickyTaskList:                  &executionInfo.StickyTaskList,
 		StickyScheduleToStartTimeout:    common.Int64Ptr(int64(executionInfo.StickyScheduleToStartTimeout)),
 		ClientLibraryVersion:            &executionInfo.ClientLibraryVersion,
@@ -1181,6 +1382,13 @@ func buildExecutionRow(
 		AutoResetPoints:                 executionInfo.AutoResetPoints.Data,
 		AutoResetPointsEncoding:         common.StringPtr(string(executionInfo.AutoResetPoints.GetEncoding())),
 		SearchAttributes:                executionInfo.SearchAttributes,
+		CompletedActivityRequestIDs:     executionInfo.CompletedActivityRequestIDs,
+		Paused:                          &executionInfo.Paused,
+		CronPaused:                      &executionInfo.CronPaused,
+		CronTimezone:                    &executionInfo.CronTimezone,
+		Notes:                           &executionInfo.Notes,
+		NotesIdentity:                   &executionInfo.NotesIdentity,
+		NotesLastUpdatedTimeNanos:       common.Int64Ptr(executionInfo.NotesLastUpdatedTime.UnixNano()),
 	}
 
 	completionEvent := executionInfo.CompletionEvent
@@ -1199,6 +1407,14 @@ func buildExecutionRow(
 		}
 	}
 
+	if executionInfo.FirstExecutionRunID != "" {
+		info.FirstExecutionRunID = sqldb.MustParseUUID(executionInfo.FirstExecutionRunID)
+	}
+
+	if executionInfo.ResetBaseRunID != "" {
+		info.ResetBaseRunID = sqldb.MustParseUUID(executionInfo.ResetBaseRunID)
+	}
+
 	if executionInfo.ParentDomainID != "" {
 		info.ParentDomainID = sqldb.MustParseUUID(executionInfo.ParentDomainID)
 		info.ParentWorkflowID = &executionInfo.ParentWorkflowID
@@ -24,7 +24,6 @@ import (
 	"database/sql"
 	"fmt"
 
-	"github.com/go-sql-driver/mysql"
 	workflow "github.com/uber/cadence/.gen/go/shared"
 	"github.com/uber/cadence/.gen/go/sqlblobs"
 	"github.com/uber/cadence/common"
@@ -100,22 +99,32 @@ func (m *sqlMetadataManagerV2) CreateDomain(request *persistence.InternalCreateD
 		badBinariesEncoding = common.StringPtr(string(request.Config.BadBinaries.GetEncoding()))
 	}
 	domainInfo := &sqlblobs.DomainInfo{
-		Status:                      common.Int32Ptr(int32(request.Info.Status)),
-		Description:                 &request.Info.Description,
-		Owner:                       &request.Info.OwnerEmail,
-		Data:                        request.Info.Data,
-		RetentionDays:               common.Int16Ptr(int16(request.Config.Retention)),
-		EmitMetric:                  &request.Config.EmitMetric,
-		ArchivalBucket:              &request.Config.ArchivalBucket,
-		ArchivalStatus:              common.Int16Ptr(int16(request.Config.ArchivalStatus)),
-		ActiveClusterName:           &request.ReplicationConfig.ActiveClusterName,
-		Clusters:                    clusters,
-		ConfigVersion:               common.Int64Ptr(request.ConfigVersion),
-		FailoverVersion:             common.Int64Ptr(request.FailoverVersion),
-		NotificationVersion:         common.Int64Ptr(metadata.NotificationVersion),
-		FailoverNotificationVersion: common.Int64Ptr(persistence.InitialFailoverNotificationVersion),
-		BadBinaries:                 badBinaries,
-		BadBinariesEncoding:         badBinariesEncoding,
+		Status:                         common.Int32Ptr(int32(request.Info.Status)),
+		Description:                    &request.Info.Description,
+		Owner:                          &request.Info.OwnerEmail,
+		Data:                           request.Info.Data,
+		RetentionDays:                  common.Int16Ptr(int16(request.Config.Retention)),
+		EmitMetric:                     &request.Config.EmitMetric,
+		ArchivalBucket:                 &request.Config.ArchivalBucket,
+		ArchivalStatus:                 common.Int16Ptr(int16(request.Config.ArchivalStatus)),
+		ActiveClusterName:              &request.ReplicationConfig.ActiveClusterName,
+		Clusters:                       clusters,
+		ConfigVersion:                  common.Int64Ptr(request.ConfigVersion),
+		FailoverVersion:                common.Int64Ptr(request.FailoverVersion),
+		NotificationVersion:            common.Int64Ptr(metadata.NotificationVersion),
+		FailoverNotificationVersion:    common.Int64Ptr(persistence.InitialFailoverNotificationVersion),
+		BadBinaries:                    badBinaries,
+		BadBinariesEncoding:            badBinariesEncoding,
+		RetentionRunCount:              common.Int32Ptr(request.Config.RetentionRunCount),
+		DefaultExecutionTimeoutSeconds: common.Int32Ptr(request.Config.TimeoutPolicy.DefaultExecutionTimeoutSeconds),
+		MaxExecutionTimeoutSeconds:     common.Int32Ptr(request.Config.TimeoutPolicy.MaxExecutionTimeoutSeconds),
+		DefaultTaskTimeoutSeconds:      common.Int32Ptr(request.Config.TimeoutPolicy.DefaultTaskTimeoutSeconds),
+		MaxTaskTimeoutSeconds:          common.Int32Ptr(request.Config.TimeoutPolicy.MaxTaskTimeoutSeconds),
+		DefaultActivityTimeoutSeconds:  common.Int32Ptr(request.Config.TimeoutPolicy.DefaultActivityTimeoutSeconds),
+		MaxActivityTimeoutSeconds:      common.Int32Ptr(request.Config.TimeoutPolicy.MaxActivityTimeoutSeconds),
+		EncryptionKeyAlias:             common.StringPtr(request.Config.EncryptionKeyAlias),
+		ResidencyTag:                   common.StringPtr(request.Config.Residency.Tag),
+		ResidencyAllowedClusters:       request.Config.Residency.AllowedClusters,
 	}
 
 	blob, err := domainInfoToBlob(domainInfo)
@@ -132,7 +141,7 @@ func (m *sqlMetadataManagerV2) CreateDomain(request *persistence.InternalCreateD
 			DataEncoding: string(blob.Encoding),
 			IsGlobal:     request.IsGlobalDomain,
 		}); err1 != nil {
-			if sqlErr, ok := err1.(*mysql.MySQLError); ok && sqlErr.Number == ErrDupEntry {
+			if isDupEntry(err1) {
 				return &workflow.DomainAlreadyExistsError{
 					Message: fmt.Sprintf("name: %v", request.Info.Name),
 				}
@@ -223,11 +232,25 @@ func (m *sqlMetadataManagerV2) domainRowToGetDomainResponse(row *sqldb.DomainRow
 			Data:        domainInfo.GetData(),
 		},
 		Config: &persistence.InternalDomainConfig{
-			Retention:      int32(domainInfo.GetRetentionDays()),
-			EmitMetric:     domainInfo.GetEmitMetric(),
-			ArchivalBucket: domainInfo.GetArchivalBucket(),
-			ArchivalStatus: workflow.ArchivalStatus(domainInfo.GetArchivalStatus()),
-			BadBinaries:    badBinaries,
+			Retention:         int32(domainInfo.GetRetentionDays()),
+			EmitMetric:        domainInfo.GetEmitMetric(),
+			ArchivalBucket:    domainInfo.GetArchivalBucket(),
+			ArchivalStatus:    workflow.ArchivalStatus(domainInfo.GetArchivalStatus()),
+			BadBinaries:       badBinaries,
+			RetentionRunCount: domainInfo.GetRetentionRunCount(),
+			TimeoutPolicy: persistence.DomainTimeoutPolicy{
+				DefaultExecutionTimeoutSeconds: domainInfo.GetDefaultExecutionTimeoutSeconds(),
+				MaxExecutionTimeoutSeconds:     domainInfo.GetMaxExecutionTimeoutSeconds(),
+				DefaultTaskTimeoutSeconds:      domainInfo.GetDefaultTaskTimeoutSeconds(),
+				MaxTaskTimeoutSeconds:          domainInfo.GetMaxTaskTimeoutSeconds(),
+				DefaultActivityTimeoutSeconds:  domainInfo.GetDefaultActivityTimeoutSeconds(),
+				MaxActivityTimeoutSeconds:      domainInfo.GetMaxActivityTimeoutSeconds(),
+			},
+			EncryptionKeyAlias: domainInfo.GetEncryptionKeyAlias(),
+			Residency: persistence.DomainResidency{
+				Tag:             domainInfo.GetResidencyTag(),
+				AllowedClusters: domainInfo.GetResidencyAllowedClusters(),
+			},
 		},
 		ReplicationConfig: &persistence.DomainReplicationConfig{
 			ActiveClusterName: persistence.GetOrUseDefaultActiveCluster(m.activeClusterName, domainInfo.GetActiveClusterName()),
@@ -254,22 +277,32 @@ func (m *sqlMetadataManagerV2) UpdateDomain(request *persistence.InternalUpdateD
 		badBinariesEncoding = common.StringPtr(string(request.Config.BadBinaries.GetEncoding()))
 	}
 	domainInfo := &sqlblobs.DomainInfo{
-		Status:                      common.Int32Ptr(int32(request.Info.Status)),
-		Description:                 &request.Info.Description,
-		Owner:                       &request.Info.OwnerEmail,
-		Data:                        request.Info.Data,
-		RetentionDays:               common.Int16Ptr(int16(request.Config.Retention)),
-		EmitMetric:                  &request.Config.EmitMetric,
-		ArchivalBucket:              &request.Config.ArchivalBucket,
-		ArchivalStatus:              common.Int16Ptr(int16(request.Config.ArchivalStatus)),
-		ActiveClusterName:           &request.ReplicationConfig.ActiveClusterName,
-		Clusters:                    clusters,
-		ConfigVersion:               common.Int64Ptr(request.ConfigVersion),
-		FailoverVersion:             common.Int64Ptr(request.FailoverVersion),
-		NotificationVersion:         common.Int64Ptr(request.NotificationVersion),
-		FailoverNotificationVersion: common.Int64Ptr(request.FailoverNotificationVersion),
-		BadBinaries:                 badBinaries,
-		BadBinariesEncoding:         badBinariesEncoding,
+		Status:                         common.Int32Ptr(int32(request.Info.Status)),
+		Description:                    &request.Info.Description,
+		Owner:                          &request.Info.OwnerEmail,
+		Data:                           request.Info.Data,
+		RetentionDays:                  common.Int16Ptr(int16(request.Config.Retention)),
+		EmitMetric:                     &request.Config.EmitMetric,
+		ArchivalBucket:                 &request.Config.ArchivalBucket,
+		ArchivalStatus:                 common.Int16Ptr(int16(request.Config.ArchivalStatus)),
+		ActiveClusterName:              &request.ReplicationConfig.ActiveClusterName,
+		Clusters:                       clusters,
+		ConfigVersion:                  common.Int64Ptr(request.ConfigVersion),
+		FailoverVersion:                common.Int64Ptr(request.FailoverVersion),
+		NotificationVersion:            common.Int64Ptr(request.NotificationVersion),
+		FailoverNotificationVersion:    common.Int64Ptr(request.FailoverNotificationVersion),
+		BadBinaries:                    badBinaries,
+		BadBinariesEncoding:            badBinariesEncoding,
+		RetentionRunCount:              common.Int32Ptr(request.Config.RetentionRunCount),
+		DefaultExecutionTimeoutSeconds: common.Int32Ptr(request.Config.TimeoutPolicy.DefaultExecutionTimeoutSeconds),
+		MaxExecutionTimeoutSeconds:     common.Int32Ptr(request.Config.TimeoutPolicy.MaxExecutionTimeoutSeconds),
+		DefaultTaskTimeoutSeconds:      common.Int32Ptr(request.Config.TimeoutPolicy.DefaultTaskTimeoutSeconds),
+		MaxTaskTimeoutSeconds:          common.Int32Ptr(request.Config.TimeoutPolicy.MaxTaskTimeoutSeconds),
+		DefaultActivityTimeoutSeconds:  common.Int32Ptr(request.Config.TimeoutPolicy.DefaultActivityTimeoutSeconds),
+		MaxActivityTimeoutSeconds:      common.Int32Ptr(request.Config.TimeoutPolicy.MaxActivityTimeoutSeconds),
+		EncryptionKeyAlias:             common.StringPtr(request.Config.EncryptionKeyAlias),
+		ResidencyTag:                   common.StringPtr(request.Config.Residency.Tag),
+		ResidencyAllowedClusters:       request.Config.Residency.AllowedClusters,
 	}
 
 	blob, err := domainInfoToBlob(domainInfo)
@@ -325,6 +358,15 @@ func (m *sqlMetadataManagerV2) GetMetadata() (*persistence.GetMetadataResponse,
 	return &persistence.GetMetadataResponse{NotificationVersion: row.NotificationVersion}, nil
 }
 
+func (m *sqlMetadataManagerV2) Ping() error {
+	if _, err := m.db.SelectFromDomainMetadata(); err != nil {
+		return &workflow.InternalServiceError{
+			Message: fmt.Sprintf("Ping operation failed. Error: %v", err),
+		}
+	}
+	return nil
+}
+
 func (m *sqlMetadataManagerV2) ListDomains(request *persistence.ListDomainsRequest) (*persistence.InternalListDomainsResponse, error) {
 	var pageToken *sqldb.UUID
 	if request.NextPageToken != nil {
@@ -101,7 +101,9 @@ func (s *TestCluster) Config() config.Persistence {
 		DataStores: map[string]config.DataStore{
 			"test": {SQL: &cfg},
 		},
-		TransactionSizeLimit: dynamicconfig.GetIntPropertyFn(common.DefaultTransactionSizeLimit),
+		TransactionSizeLimit:               dynamicconfig.GetIntPropertyFn(common.DefaultTransactionSizeLimit),
+		ExecutionTransactionStatementLimit: dynamicconfig.GetIntPropertyFn(common.DefaultExecutionTransactionStatementLimit),
+		ExecutionTransactionSizeLimit:      dynamicconfig.GetIntPropertyFn(common.DefaultExecutionTransactionSizeLimit),
 	}
 }
 
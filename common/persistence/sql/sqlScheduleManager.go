@@ -0,0 +1,218 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package sql
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	workflow "github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/log"
+	"github.com/uber/cadence/common/persistence"
+	"github.com/uber/cadence/common/persistence/sql/storage/sqldb"
+)
+
+type (
+	sqlScheduleManager struct {
+		sqlStore
+	}
+
+	// schedulesPageToken is the last domain and schedule ID of the previous page
+	schedulesPageToken struct {
+		DomainID   sqldb.UUID
+		ScheduleID string
+	}
+)
+
+// newSchedulePersistence creates an instance of ScheduleManager
+func newSchedulePersistence(db sqldb.Interface, log log.Logger) (persistence.ScheduleManager, error) {
+	return &sqlScheduleManager{
+		sqlStore: sqlStore{
+			db:     db,
+			logger: log,
+		},
+	}, nil
+}
+
+func (m *sqlScheduleManager) CreateSchedule(request *persistence.CreateScheduleRequest) error {
+	if _, err := m.db.InsertIntoSchedules(scheduleToRow(request.Schedule)); err != nil {
+		if isDupEntry(err) {
+			return &persistence.ConditionFailedError{
+				Msg: fmt.Sprintf("Schedule already exists.  ScheduleId: %v", request.Schedule.ScheduleID),
+			}
+		}
+		return &workflow.InternalServiceError{
+			Message: fmt.Sprintf("CreateSchedule operation failed. Error: %v", err),
+		}
+	}
+	return nil
+}
+
+func (m *sqlScheduleManager) GetSchedule(request *persistence.GetScheduleRequest) (*persistence.GetScheduleResponse, error) {
+	row, err := m.db.SelectFromSchedules(&sqldb.SchedulesFilter{
+		DomainID:   sqldb.MustParseUUID(request.DomainID),
+		ScheduleID: request.ScheduleID,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, &workflow.EntityNotExistsError{
+				Message: fmt.Sprintf("Schedule not found.  ScheduleId: %v", request.ScheduleID),
+			}
+		}
+		return nil, &workflow.InternalServiceError{
+			Message: fmt.Sprintf("GetSchedule operation failed. Error: %v", err),
+		}
+	}
+	return &persistence.GetScheduleResponse{Schedule: scheduleFromRow(row)}, nil
+}
+
+func (m *sqlScheduleManager) UpdateSchedule(request *persistence.UpdateScheduleRequest) error {
+	result, err := m.db.UpdateSchedules(scheduleToRow(request.Schedule), request.PreviousVersion)
+	if err != nil {
+		return &workflow.InternalServiceError{
+			Message: fmt.Sprintf("UpdateSchedule operation failed. Error: %v", err),
+		}
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return &workflow.InternalServiceError{
+			Message: fmt.Sprintf("UpdateSchedule operation failed. Failed to check number of rows updated. Error: %v", err),
+		}
+	}
+	if rowsAffected != 1 {
+		return &persistence.ConditionFailedError{
+			Msg: fmt.Sprintf("Failed to update schedule.  ScheduleId: %v, expected version: %v",
+				request.Schedule.ScheduleID, request.PreviousVersion),
+		}
+	}
+	return nil
+}
+
+func (m *sqlScheduleManager) DeleteSchedule(request *persistence.DeleteScheduleRequest) error {
+	if _, err := m.db.DeleteFromSchedules(&sqldb.SchedulesFilter{
+		DomainID:   sqldb.MustParseUUID(request.DomainID),
+		ScheduleID: request.ScheduleID,
+	}); err != nil {
+		return &workflow.InternalServiceError{
+			Message: fmt.Sprintf("DeleteSchedule operation failed. Error: %v", err),
+		}
+	}
+	return nil
+}
+
+func (m *sqlScheduleManager) ListSchedules(request *persistence.ListSchedulesRequest) (*persistence.ListSchedulesResponse, error) {
+	// domain IDs are never all zeros
+	token := schedulesPageToken{DomainID: make(sqldb.UUID, 16)}
+	if len(request.NextPageToken) > 0 {
+		if err := json.Unmarshal(request.NextPageToken, &token); err != nil {
+			return nil, &workflow.InternalServiceError{
+				Message: fmt.Sprintf("ListSchedules operation failed. Invalid page token. Error: %v", err),
+			}
+		}
+	}
+	filter := &sqldb.SchedulesFilter{
+		MinDomainID:   token.DomainID,
+		MinScheduleID: token.ScheduleID,
+		PageSize:      common.IntPtr(request.PageSize),
+	}
+	if len(request.DomainID) > 0 {
+		filter.DomainID = sqldb.MustParseUUID(request.DomainID)
+	}
+	rows, err := m.db.RangeSelectFromSchedules(filter)
+	if err != nil && err != sql.ErrNoRows {
+		return nil, &workflow.InternalServiceError{
+			Message: fmt.Sprintf("ListSchedules operation failed. Select failed. Error: %v", err),
+		}
+	}
+
+	response := &persistence.ListSchedulesResponse{}
+	for i := range rows {
+		response.Schedules = append(response.Schedules, scheduleFromRow(&rows[i]))
+	}
+	if len(rows) == request.PageSize {
+		last := rows[len(rows)-1]
+		response.NextPageToken, err = json.Marshal(&schedulesPageToken{DomainID: last.DomainID, ScheduleID: last.ScheduleID})
+		if err != nil {
+			return nil, &workflow.InternalServiceError{
+				Message: fmt.Sprintf("ListSchedules operation failed. Failed to serialize page token. Error: %v", err),
+			}
+		}
+	}
+	return response, nil
+}
+
+func scheduleToRow(s *persistence.ScheduleInfo) *sqldb.SchedulesRow {
+	return &sqldb.SchedulesRow{
+		DomainID:               sqldb.MustParseUUID(s.DomainID),
+		ScheduleID:             s.ScheduleID,
+		CronSchedule:           s.CronSchedule,
+		WorkflowTypeName:       s.WorkflowTypeName,
+		TaskList:               s.TaskList,
+		Input:                  s.Input,
+		ExecutionTimeout:       s.ExecutionStartToCloseTimeoutSeconds,
+		TaskTimeout:            s.TaskStartToCloseTimeoutSeconds,
+		OverlapPolicy:          s.OverlapPolicy,
+		CatchupWindow:          s.CatchupWindowSeconds,
+		Paused:                 s.Paused,
+		LastFireTime:           s.LastFireTime,
+		LastWorkflowID:         s.LastWorkflowID,
+		LastRunID:              s.LastRunID,
+		BackfillID:             s.BackfillID,
+		BackfillStartTime:      s.BackfillStartTime,
+		BackfillEndTime:        s.BackfillEndTime,
+		BackfillRunsPerMinute:  s.BackfillRunsPerMinute,
+		BackfillLastRunTime:    s.BackfillLastRunTime,
+		LastBackfillWorkflowID: s.LastBackfillWorkflowID,
+		LastBackfillRunID:      s.LastBackfillRunID,
+		Identity:               s.Identity,
+		Version:                s.Version,
+	}
+}
+
+func scheduleFromRow(row *sqldb.SchedulesRow) *persistence.ScheduleInfo {
+	return &persistence.ScheduleInfo{
+		DomainID:                            row.DomainID.String(),
+		ScheduleID:                          row.ScheduleID,
+		CronSchedule:                        row.CronSchedule,
+		WorkflowTypeName:                    row.WorkflowTypeName,
+		TaskList:                            row.TaskList,
+		Input:                               row.Input,
+		ExecutionStartToCloseTimeoutSeconds: row.ExecutionTimeout,
+		TaskStartToCloseTimeoutSeconds:      row.TaskTimeout,
+		OverlapPolicy:                       row.OverlapPolicy,
+		CatchupWindowSeconds:                row.CatchupWindow,
+		Paused:                              row.Paused,
+		LastFireTime:                        row.LastFireTime,
+		LastWorkflowID:                      row.LastWorkflowID,
+		LastRunID:                           row.LastRunID,
+		BackfillID:                          row.BackfillID,
+		BackfillStartTime:                   row.BackfillStartTime,
+		BackfillEndTime:                     row.BackfillEndTime,
+		BackfillRunsPerMinute:               row.BackfillRunsPerMinute,
+		BackfillLastRunTime:                 row.BackfillLastRunTime,
+		LastBackfillWorkflowID:              row.LastBackfillWorkflowID,
+		LastBackfillRunID:                   row.LastBackfillRunID,
+		Identity:                            row.Identity,
+		Version:                             row.Version,
+	}
+}
@@ -109,6 +109,24 @@ func (m *sqlShardManager) GetShard(request *persistence.GetShardRequest) (*persi
 		}
 	}
 
+	queueAlarms := make(map[string]persistence.QueueAlarm, len(shardInfo.QueueAlarmStuckSinceNanos))
+	for queue, stuckSince := range shardInfo.QueueAlarmStuckSinceNanos {
+		queueAlarms[queue] = persistence.QueueAlarm{
+			StuckSince:            time.Unix(0, stuckSince),
+			BlockingTaskID:        shardInfo.QueueAlarmBlockingTaskIDs[queue],
+			BlockingTaskTimestamp: time.Unix(0, shardInfo.QueueAlarmBlockingTaskTimestampNanos[queue]),
+		}
+	}
+
+	var hotExecutions []persistence.HotExecution
+	for _, execution := range shardInfo.HotExecutions {
+		hotExecutions = append(hotExecutions, persistence.HotExecution{
+			DomainID:   sqldb.UUID(execution.DomainID).String(),
+			WorkflowID: execution.GetWorkflowID(),
+			RunID:      sqldb.UUID(execution.RunID).String(),
+		})
+	}
+
 	resp := &persistence.GetShardResponse{ShardInfo: &persistence.ShardInfo{
 		ShardID:                   int(row.ShardID),
 		RangeID:                   row.RangeID,
@@ -121,6 +139,14 @@ func (m *sqlShardManager) GetShard(request *persistence.GetShardRequest) (*persi
 		ClusterTransferAckLevel:   shardInfo.ClusterTransferAckLevel,
 		ClusterTimerAckLevel:      timerAckLevel,
 		DomainNotificationVersion: shardInfo.GetDomainNotificationVersion(),
+		DomainOpenExecutionCounts: shardInfo.DomainOpenExecutionCounts,
+		VisibilityAckLevel:        shardInfo.GetVisibilityAckLevel(),
+		QueueAlarms:               queueAlarms,
+		HotExecutions:             hotExecutions,
+		LeaseExpiresAt:            time.Unix(0, shardInfo.GetLeaseExpiresAtNanos()),
+		TasksPerSecond:            shardInfo.GetTasksPerSecond(),
+		TaskThroughputUpdatedAt:   time.Unix(0, shardInfo.GetTaskThroughputUpdatedAtNanos()),
+		Placement:                 shardInfo.Placement,
 	}}
 
 	return resp, nil
@@ -205,16 +231,44 @@ func shardInfoToShardsRow(s persistence.ShardInfo) (*sqldb.ShardsRow, error) {
 		timerAckLevels[k] = v.UnixNano()
 	}
 
+	queueAlarmStuckSince := make(map[string]int64, len(s.QueueAlarms))
+	queueAlarmBlockingTaskIDs := make(map[string]int64, len(s.QueueAlarms))
+	queueAlarmBlockingTaskTimestamps := make(map[string]int64, len(s.QueueAlarms))
+	for queue, alarm := range s.QueueAlarms {
+		queueAlarmStuckSince[queue] = alarm.StuckSince.UnixNano()
+		queueAlarmBlockingTaskIDs[queue] = alarm.BlockingTaskID
+		queueAlarmBlockingTaskTimestamps[queue] = alarm.BlockingTaskTimestamp.UnixNano()
+	}
+
+	hotExecutions := make([]*sqlblobs.HotExecution, 0, len(s.HotExecutions))
+	for _, execution := range s.HotExecutions {
+		hotExecutions = append(hotExecutions, &sqlblobs.HotExecution{
+			DomainID:   sqldb.MustParseUUID(execution.DomainID),
+			WorkflowID: common.StringPtr(execution.WorkflowID),
+			RunID:      sqldb.MustParseUUID(execution.RunID),
+		})
+	}
+
 	shardInfo := &sqlblobs.ShardInfo{
-		StolenSinceRenew:          common.Int32Ptr(int32(s.StolenSinceRenew)),
-		UpdatedAtNanos:            common.Int64Ptr(s.UpdatedAt.UnixNano()),
-		ReplicationAckLevel:       common.Int64Ptr(s.ReplicationAckLevel),
-		TransferAckLevel:          common.Int64Ptr(s.TransferAckLevel),
-		TimerAckLevelNanos:        common.Int64Ptr(s.TimerAckLevel.UnixNano()),
-		ClusterTransferAckLevel:   s.ClusterTransferAckLevel,
-		ClusterTimerAckLevel:      timerAckLevels,
-		DomainNotificationVersion: common.Int64Ptr(s.DomainNotificationVersion),
-		Owner:                     &s.Owner,
+		StolenSinceRenew:                     common.Int32Ptr(int32(s.StolenSinceRenew)),
+		UpdatedAtNanos:                       common.Int64Ptr(s.UpdatedAt.UnixNano()),
+		ReplicationAckLevel:                  common.Int64Ptr(s.ReplicationAckLevel),
+		TransferAckLevel:                     common.Int64Ptr(s.TransferAckLevel),
+		TimerAckLevelNanos:                   common.Int64Ptr(s.TimerAckLevel.UnixNano()),
+		ClusterTransferAckLevel:              s.ClusterTransferAckLevel,
+		ClusterTimerAckLevel:                 timerAckLevels,
+		DomainNotificationVersion:            common.Int64Ptr(s.DomainNotificationVersion),
+		Owner:                                &s.Owner,
+		DomainOpenExecutionCounts:            s.DomainOpenExecutionCounts,
+		VisibilityAckLevel:                   common.Int64Ptr(s.VisibilityAckLevel),
+		QueueAlarmStuckSinceNanos:            queueAlarmStuckSince,
+		QueueAlarmBlockingTaskIDs:            queueAlarmBlockingTaskIDs,
+		QueueAlarmBlockingTaskTimestampNanos: queueAlarmBlockingTaskTimestamps,
+		HotExecutions:                        hotExecutions,
+		LeaseExpiresAtNanos:                  common.Int64Ptr(s.LeaseExpiresAt.UnixNano()),
+		TasksPerSecond:                       common.Float64Ptr(s.TasksPerSecond),
+		TaskThroughputUpdatedAtNanos:         common.Int64Ptr(s.TaskThroughputUpdatedAt.UnixNano()),
+		Placement:                            s.Placement,
 	}
 
 	blob, err := shardInfoToBlob(shardInfo)
@@ -55,6 +55,11 @@ func newTaskPersistence(db sqldb.Interface, nShards int, log log.Logger) (persis
 	}, nil
 }
 
+// Ping reads the row of the first shard, the task lists are kept in the same database
+func (m *sqlTaskManager) Ping() error {
+	return m.ping(0)
+}
+
 func (m *sqlTaskManager) LeaseTaskList(request *persistence.LeaseTaskListRequest) (*persistence.LeaseTaskListResponse, error) {
 	var rangeID int64
 	var ackLevel int64
@@ -155,6 +160,7 @@ func (m *sqlTaskManager) LeaseTaskList(request *persistence.LeaseTaskListRequest
 			AckLevel:    ackLevel,
 			Kind:        request.TaskListKind,
 			LastUpdated: now,
+			VersionSets: versionSetsFromThrift(tlInfo.VersionSets),
 		}}
 		return nil
 	})
@@ -169,6 +175,7 @@ func (m *sqlTaskManager) UpdateTaskList(request *persistence.UpdateTaskListReque
 		Kind:             common.Int16Ptr(int16(request.TaskListInfo.Kind)),
 		ExpiryTimeNanos:  common.Int64Ptr(0),
 		LastUpdatedNanos: common.TimeNowNanosPtr(),
+		VersionSets:      versionSetsToThrift(request.TaskListInfo.VersionSets),
 	}
 	if request.TaskListInfo.Kind == persistence.TaskListKindSticky {
 		tlInfo.ExpiryTimeNanos = common.Int64Ptr(stickyTaskListTTL().UnixNano())
@@ -296,6 +303,7 @@ func (m *sqlTaskManager) ListTaskList(request *persistence.ListTaskListRequest)
 		resp.Items[i].AckLevel = info.GetAckLevel()
 		resp.Items[i].Expiry = time.Unix(0, info.GetExpiryTimeNanos())
 		resp.Items[i].LastUpdated = time.Unix(0, info.GetLastUpdatedNanos())
+		resp.Items[i].VersionSets = versionSetsFromThrift(info.VersionSets)
 	}
 
 	return resp, nil
@@ -462,3 +470,25 @@ func lockTaskList(tx sqldb.Tx, shardID int, domainID sqldb.UUID, name string, ta
 func stickyTaskListTTL() time.Time {
 	return time.Now().Add(24 * time.Hour)
 }
+
+func versionSetsToThrift(versionSets [][]string) []*workflow.TaskListVersionSet {
+	if len(versionSets) == 0 {
+		return nil
+	}
+	result := make([]*workflow.TaskListVersionSet, 0, len(versionSets))
+	for _, buildIDs := range versionSets {
+		result = append(result, &workflow.TaskListVersionSet{BuildIds: buildIDs})
+	}
+	return result
+}
+
+func versionSetsFromThrift(versionSets []*workflow.TaskListVersionSet) [][]string {
+	if len(versionSets) == 0 {
+		return nil
+	}
+	result := make([][]string, 0, len(versionSets))
+	for _, set := range versionSets {
+		result = append(result, set.BuildIds)
+	}
+	return result
+}
@@ -52,12 +52,16 @@ func NewSQLVisibilityStore(cfg config.SQL, logger log.Logger) (p.VisibilityStore
 	if err != nil {
 		return nil, err
 	}
+	return newSQLVisibilityStore(db, logger), nil
+}
+
+func newSQLVisibilityStore(db sqldb.Interface, logger log.Logger) *sqlVisibilityStore {
 	return &sqlVisibilityStore{
 		sqlStore: sqlStore{
 			db:     db,
 			logger: logger,
 		},
-	}, nil
+	}
 }
 
 func (s *sqlVisibilityStore) RecordWorkflowExecutionStarted(request *p.InternalRecordWorkflowExecutionStartedRequest) error {
@@ -0,0 +1,156 @@
+// Copyright (c) 2019 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cockroach
+
+import (
+	"database/sql"
+	"regexp"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/uber/cadence/common/persistence/sql/storage/mysql"
+	"github.com/uber/cadence/common/persistence/sql/storage/sqldb"
+)
+
+type (
+	// DB represents a logical connection to a CockroachDB cluster. The
+	// queries are the same as the ones sent to mysql, translated on the
+	// fly to the postgres dialect understood by CockroachDB
+	DB struct {
+		*mysql.DB
+		xdb  *sqlx.DB
+		conn *conn
+	}
+
+	// conn wraps a connection to a CockroachDB cluster, it rewrites the
+	// queries and remembers whether a transaction was aborted because of
+	// a serialization conflict
+	conn struct {
+		conn       sqldb.Conn
+		inTx       bool
+		conflicted bool
+	}
+)
+
+// ErrCodeSerializationFailure is the postgres error code returned by cockroach
+// when a transaction was aborted because of a conflict and must be retried by the client
+const ErrCodeSerializationFailure = "40001"
+
+var (
+	onDuplicateKeyUpdateRegex = regexp.MustCompile(`(?is)^\s*INSERT\s+INTO\b(.*?)\s+ON\s+DUPLICATE\s+KEY\s+UPDATE\b.*$`)
+	replaceIntoRegex          = regexp.MustCompile(`(?is)^\s*REPLACE\s+INTO\b`)
+	insertIgnoreIntoRegex     = regexp.MustCompile(`(?is)^\s*INSERT\s+IGNORE\s+INTO\b(.*)$`)
+	lockInShareModeRegex      = regexp.MustCompile(`(?is)\s+LOCK\s+IN\s+SHARE\s+MODE\s*$`)
+)
+
+var _ sqldb.Tx = (*DB)(nil)
+var _ sqldb.Interface = (*DB)(nil)
+var _ sqldb.ConflictReporter = (*DB)(nil)
+var _ sqldb.Conn = (*conn)(nil)
+
+// NewDB returns an instance of DB, which is a logical
+// connection to the underlying CockroachDB cluster
+func NewDB(xdb *sqlx.DB, tx *sqlx.Tx) *DB {
+	c := &conn{conn: xdb}
+	if tx != nil {
+		c.conn = tx
+		c.inTx = true
+	}
+	return &DB{DB: mysql.NewDBWithConn(xdb, tx, c), xdb: xdb, conn: c}
+}
+
+// BeginTx starts a new transaction and returns a reference to the Tx object
+func (db *DB) BeginTx() (sqldb.Tx, error) {
+	xtx, err := db.xdb.Beginx()
+	if err != nil {
+		return nil, err
+	}
+	return NewDB(db.xdb, xtx), nil
+}
+
+// Commit commits a previously started transaction
+func (db *DB) Commit() error {
+	return db.conn.track(db.DB.Commit())
+}
+
+// Conflicted returns true when a statement of the transaction, or its
+// commit, failed because of a serialization conflict
+func (db *DB) Conflicted() bool {
+	return db.conn.conflicted
+}
+
+func (c *conn) Exec(query string, args ...interface{}) (sql.Result, error) {
+	result, err := c.conn.Exec(toCockroachQuery(query), args...)
+	return result, c.track(err)
+}
+
+func (c *conn) NamedExec(query string, arg interface{}) (sql.Result, error) {
+	// bind the named parameters as mysql placeholders first, so the
+	// translated query does not depend on the bind type of the driver
+	query, args, err := sqlx.Named(query, arg)
+	if err != nil {
+		return nil, err
+	}
+	return c.Exec(query, args...)
+}
+
+func (c *conn) Get(dest interface{}, query string, args ...interface{}) error {
+	return c.track(c.conn.Get(dest, toCockroachQuery(query), args...))
+}
+
+func (c *conn) Select(dest interface{}, query string, args ...interface{}) error {
+	return c.track(c.conn.Select(dest, toCockroachQuery(query), args...))
+}
+
+// track records serialization failures before the stores wrap the error,
+// a connection outside of a transaction is shared and does not track them
+func (c *conn) track(err error) error {
+	if c.inTx && isSerializationFailure(err) {
+		c.conflicted = true
+	}
+	return err
+}
+
+func isSerializationFailure(err error) bool {
+	pqErr, ok := err.(*pq.Error)
+	return ok && pqErr.Code == ErrCodeSerializationFailure
+}
+
+// toCockroachQuery rewrites the mysql specific constructs of a query:
+// upserts become UPSERT statements, INSERT IGNORE becomes ON CONFLICT DO NOTHING,
+// LOCK IN SHARE MODE becomes FOR UPDATE and ? placeholders are rebound to $N.
+// Everything else, including SELECT ... FOR UPDATE and DELETE ... LIMIT, is
+// understood as is
+func toCockroachQuery(query string) string {
+	switch {
+	case onDuplicateKeyUpdateRegex.MatchString(query):
+		// every upsert in the stores updates all non key columns, which is what UPSERT does
+		query = onDuplicateKeyUpdateRegex.ReplaceAllString(query, "UPSERT INTO$1")
+	case replaceIntoRegex.MatchString(query):
+		query = replaceIntoRegex.ReplaceAllString(query, "UPSERT INTO")
+	case insertIgnoreIntoRegex.MatchString(query):
+		query = insertIgnoreIntoRegex.ReplaceAllString(query, "INSERT INTO$1 ON CONFLICT DO NOTHING")
+	}
+	// cockroach takes no shared locks, the read lock of the shard and of the
+	// execution is taken exclusively so that concurrent writers are serialized
+	query = lockInShareModeRegex.ReplaceAllString(query, " FOR UPDATE")
+	return sqlx.Rebind(sqlx.DOLLAR, query)
+}
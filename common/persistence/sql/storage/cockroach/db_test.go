@@ -0,0 +1,107 @@
+// Copyright (c) 2019 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cockroach
+
+import (
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeConn struct {
+	err error
+}
+
+func (c *fakeConn) Exec(query string, args ...interface{}) (sql.Result, error) {
+	return nil, c.err
+}
+
+func (c *fakeConn) NamedExec(query string, arg interface{}) (sql.Result, error) {
+	return nil, c.err
+}
+
+func (c *fakeConn) Get(dest interface{}, query string, args ...interface{}) error {
+	return c.err
+}
+
+func (c *fakeConn) Select(dest interface{}, query string, args ...interface{}) error {
+	return c.err
+}
+
+func TestToCockroachQuery(t *testing.T) {
+	testCases := []struct {
+		in  string
+		out string
+	}{
+		{
+			in:  "SELECT range_id FROM shards WHERE shard_id = ? FOR UPDATE",
+			out: "SELECT range_id FROM shards WHERE shard_id = $1 FOR UPDATE",
+		},
+		{
+			in:  "SELECT range_id FROM shards WHERE shard_id = ? LOCK IN SHARE MODE",
+			out: "SELECT range_id FROM shards WHERE shard_id = $1 FOR UPDATE",
+		},
+		{
+			in:  "DELETE FROM cluster_membership WHERE record_expiry < ? LIMIT ?",
+			out: "DELETE FROM cluster_membership WHERE record_expiry < $1 LIMIT $2",
+		},
+		{
+			in: `INSERT INTO
+ cluster_membership (role, rpc_address, session_start, last_heartbeat, record_expiry)
+ VALUES(?, ?, ?, ?, ?)
+ ON DUPLICATE KEY UPDATE
+ session_start = VALUES(session_start), last_heartbeat = VALUES(last_heartbeat), record_expiry = VALUES(record_expiry)`,
+			out: "UPSERT INTO\n cluster_membership (role, rpc_address, session_start, last_heartbeat, record_expiry)\n VALUES($1, $2, $3, $4, $5)",
+		},
+		{
+			in:  "REPLACE INTO executions_visibility (domain_id, workflow_id, run_id, close_time) VALUES (?, ?, ?, ?)",
+			out: "UPSERT INTO executions_visibility (domain_id, workflow_id, run_id, close_time) VALUES ($1, $2, $3, $4)",
+		},
+		{
+			in:  "INSERT IGNORE INTO signals_requested_sets (shard_id, signal_id) VALUES (?, ?)",
+			out: "INSERT INTO signals_requested_sets (shard_id, signal_id) VALUES ($1, $2) ON CONFLICT DO NOTHING",
+		},
+	}
+
+	for _, tc := range testCases {
+		assert.Equal(t, tc.out, toCockroachQuery(tc.in))
+	}
+}
+
+func TestConnTracksSerializationFailures(t *testing.T) {
+	conflict := &pq.Error{Code: ErrCodeSerializationFailure}
+
+	c := &conn{conn: &fakeConn{err: errors.New("some error")}, inTx: true}
+	assert.Error(t, c.Get(nil, "SELECT 1"))
+	assert.False(t, c.conflicted)
+
+	c = &conn{conn: &fakeConn{err: conflict}, inTx: true}
+	_, err := c.Exec("UPDATE shards SET range_id = ?", 1)
+	assert.Equal(t, conflict, err)
+	assert.True(t, c.conflicted)
+
+	c = &conn{conn: &fakeConn{err: conflict}}
+	assert.Equal(t, conflict, c.Select(nil, "SELECT 1"))
+	assert.False(t, c.conflicted)
+}
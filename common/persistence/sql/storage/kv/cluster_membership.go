@@ -0,0 +1,96 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package kv
+
+import (
+	"database/sql"
+
+	"github.com/uber/cadence/common/persistence/sql/storage/sqldb"
+)
+
+const clusterMembershipTable = "cluster_membership"
+
+func clusterMembershipKey(role string) key {
+	return newKey(clusterMembershipTable).str(role)
+}
+
+// UpsertClusterMembership inserts or updates a row in cluster_membership table
+func (db *DB) UpsertClusterMembership(row *sqldb.ClusterMembershipRow) (sql.Result, error) {
+	return db.replaceRows(1, func(int) (key, interface{}) {
+		return clusterMembershipKey(row.Role).str(row.RPCAddress), row
+	})
+}
+
+// SelectFromClusterMembership reads one or more rows from cluster_membership table
+func (db *DB) SelectFromClusterMembership(filter *sqldb.ClusterMembershipFilter) ([]sqldb.ClusterMembershipRow, error) {
+	prefix := newKey(clusterMembershipTable)
+	if filter.Role != "" {
+		prefix = clusterMembershipKey(filter.Role)
+	}
+	kvs, err := db.getRange(prefix, prefix.next(), 0)
+	if err != nil {
+		return nil, err
+	}
+	var rows []sqldb.ClusterMembershipRow
+	for _, kv := range kvs {
+		var row sqldb.ClusterMembershipRow
+		if err := decodeRow(kv.Value, &row); err != nil {
+			return nil, err
+		}
+		if row.LastHeartbeat.After(filter.LastHeartbeatAfter) && row.RecordExpiry.After(filter.RecordExpiryAfter) {
+			rows = append(rows, row)
+		}
+	}
+	return rows, nil
+}
+
+// DeleteFromClusterMembership deletes expired rows from cluster_membership table
+func (db *DB) DeleteFromClusterMembership(filter *sqldb.ClusterMembershipFilter) (sql.Result, error) {
+	var res result
+	err := db.execute(func(txn Txn) error {
+		res = 0
+		prefix := newKey(clusterMembershipTable)
+		kvs, err := txn.GetRange(prefix, prefix.next(), 0)
+		if err != nil {
+			return err
+		}
+		for _, kv := range kvs {
+			if int(res) == filter.MaxRecordsAffected {
+				break
+			}
+			var row sqldb.ClusterMembershipRow
+			if err := decodeRow(kv.Value, &row); err != nil {
+				return err
+			}
+			if row.RecordExpiry.Before(filter.RecordExpiryBefore) {
+				if err := txn.Clear(kv.Key); err != nil {
+					return err
+				}
+				res++
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
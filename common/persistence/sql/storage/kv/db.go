@@ -0,0 +1,330 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package kv
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/gob"
+
+	"github.com/uber/cadence/common/persistence/sql/storage/sqldb"
+)
+
+type (
+	// DB runs the table operations of the sql stores on top of an ordered key-value store.
+	// Every row is stored under the order preserving encoding of its primary key, so the
+	// range queries of the sql stores become range reads of the store. A DB is either
+	// bound to a transaction started by BeginTx, or runs each operation in its own one
+	DB struct {
+		store      Store
+		txn        Txn
+		driverName string
+		// conflicted is whether an operation of the transaction db is bound to was aborted
+		// by a conflict, the sql stores wrap the error before it reaches their retry
+		conflicted bool
+	}
+
+	// result is the sql.Result of a write, the number of rows it affected
+	result int64
+)
+
+// maxConflictRetries is the number of times a single operation not bound to a transaction
+// is retried when it keeps being aborted by conflicts
+const maxConflictRetries = 5
+
+var _ sqldb.Tx = (*DB)(nil)
+var _ sqldb.Interface = (*DB)(nil)
+var _ sqldb.ConflictReporter = (*DB)(nil)
+
+// NewDB returns an instance of DB running the table operations on top of the given store
+func NewDB(store Store, driverName string) *DB {
+	return &DB{store: store, driverName: driverName}
+}
+
+// BeginTx starts a new transaction and returns a reference to the Tx object
+func (db *DB) BeginTx() (sqldb.Tx, error) {
+	txn, err := db.store.Begin()
+	if err != nil {
+		return nil, err
+	}
+	return &DB{store: db.store, txn: txn, driverName: db.driverName}, nil
+}
+
+// Commit commits a previously started transaction
+func (db *DB) Commit() error {
+	return db.track(db.txn.Commit())
+}
+
+// Rollback triggers rollback of a previously started transaction
+func (db *DB) Rollback() error {
+	return db.txn.Rollback()
+}
+
+// Close closes the connection to the store
+func (db *DB) Close() error {
+	return db.store.Close()
+}
+
+// DriverName returns the name the store is registered under
+func (db *DB) DriverName() string {
+	return db.driverName
+}
+
+// Conflicted returns true when an operation of the transaction, or its
+// commit, was aborted by a conflicting transaction
+func (db *DB) Conflicted() bool {
+	return db.conflicted
+}
+
+// execute runs fn within the transaction db is bound to, or else within a new transaction
+// committed right after fn and retried when it is aborted by a conflict
+func (db *DB) execute(fn func(txn Txn) error) error {
+	if db.txn != nil {
+		return db.track(fn(db.txn))
+	}
+	var err error
+	for attempt := 0; attempt < maxConflictRetries; attempt++ {
+		if err = db.executeOnce(fn); err != ErrConflict {
+			break
+		}
+	}
+	return err
+}
+
+// track records the conflicts of the transaction db is bound to
+func (db *DB) track(err error) error {
+	if err == ErrConflict {
+		db.conflicted = true
+	}
+	return err
+}
+
+func (db *DB) executeOnce(fn func(txn Txn) error) error {
+	txn, err := db.store.Begin()
+	if err != nil {
+		return err
+	}
+	if err := fn(txn); err != nil {
+		txn.Rollback()
+		return err
+	}
+	return txn.Commit()
+}
+
+func (r result) LastInsertId() (int64, error) {
+	return 0, nil
+}
+
+func (r result) RowsAffected() (int64, error) {
+	return int64(r), nil
+}
+
+func encodeRow(row interface{}) ([]byte, error) {
+	var b bytes.Buffer
+	if err := gob.NewEncoder(&b).Encode(row); err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
+
+func decodeRow(value []byte, row interface{}) error {
+	return gob.NewDecoder(bytes.NewReader(value)).Decode(row)
+}
+
+// decodeRows decodes the values of kvs into the rows returned by row for each index
+func decodeRows(kvs []KeyValue, row func(i int) interface{}) error {
+	for i, kv := range kvs {
+		if err := decodeRow(kv.Value, row(i)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// getRow reads the row stored under k, it reports whether the row exists
+func getRow(txn Txn, k key, row interface{}) (bool, error) {
+	value, err := txn.Get(k)
+	if err != nil || value == nil {
+		return false, err
+	}
+	return true, decodeRow(value, row)
+}
+
+// getRowOrNoRows reads the row stored under k, it fails with sql.ErrNoRows when the row does not exist
+func getRowOrNoRows(txn Txn, k key, row interface{}) error {
+	found, err := getRow(txn, k, row)
+	if err == nil && !found {
+		return sql.ErrNoRows
+	}
+	return err
+}
+
+// setRow writes the row under k, replacing any existing row
+func setRow(txn Txn, k key, row interface{}) error {
+	value, err := encodeRow(row)
+	if err != nil {
+		return err
+	}
+	return txn.Set(k, value)
+}
+
+// insertRow writes the row under k, it fails with ErrDupEntry when a row already exists
+func insertRow(txn Txn, k key, row interface{}) error {
+	value, err := txn.Get(k)
+	if err != nil {
+		return err
+	}
+	if value != nil {
+		return ErrDupEntry
+	}
+	return setRow(txn, k, row)
+}
+
+// updateRow writes the row under k only when a row already exists, it returns the number of rows updated
+func updateRow(txn Txn, k key, row interface{}) (result, error) {
+	value, err := txn.Get(k)
+	if err != nil || value == nil {
+		return 0, err
+	}
+	return 1, setRow(txn, k, row)
+}
+
+// clearRow deletes the row stored under k, it returns the number of rows deleted
+func clearRow(txn Txn, k key) (result, error) {
+	value, err := txn.Get(k)
+	if err != nil || value == nil {
+		return 0, err
+	}
+	return 1, txn.Clear(k)
+}
+
+// clearRange deletes the rows with begin <= key < end, at most limit of them unless limit
+// is 0, it returns the number of rows deleted
+func clearRange(txn Txn, begin key, end key, limit int) (result, error) {
+	kvs, err := txn.GetRange(begin, end, limit)
+	if err != nil {
+		return 0, err
+	}
+	for _, kv := range kvs {
+		if err := txn.Clear(kv.Key); err != nil {
+			return 0, err
+		}
+	}
+	return result(len(kvs)), nil
+}
+
+func pageSize(size *int) int {
+	if size == nil {
+		return 0
+	}
+	return *size
+}
+
+// get reads the row stored under k, it fails with sql.ErrNoRows when the row does not exist
+func (db *DB) get(k key, row interface{}) error {
+	return db.execute(func(txn Txn) error {
+		return getRowOrNoRows(txn, k, row)
+	})
+}
+
+// getRange returns the entries with begin <= key < end, at most limit of them unless limit is 0
+func (db *DB) getRange(begin key, end key, limit int) ([]KeyValue, error) {
+	var kvs []KeyValue
+	err := db.execute(func(txn Txn) (err error) {
+		kvs, err = txn.GetRange(begin, end, limit)
+		return err
+	})
+	return kvs, err
+}
+
+// insertRows inserts the n rows returned by row along with their key, it fails with
+// ErrDupEntry when any of them already exists
+func (db *DB) insertRows(n int, row func(i int) (key, interface{})) (sql.Result, error) {
+	err := db.execute(func(txn Txn) error {
+		for i := 0; i < n; i++ {
+			k, r := row(i)
+			if err := insertRow(txn, k, r); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result(n), nil
+}
+
+// replaceRows writes the n rows returned by row along with their key, replacing the existing ones
+func (db *DB) replaceRows(n int, row func(i int) (key, interface{})) (sql.Result, error) {
+	err := db.execute(func(txn Txn) error {
+		for i := 0; i < n; i++ {
+			k, r := row(i)
+			if err := setRow(txn, k, r); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result(n), nil
+}
+
+// update writes the row under k only when a row already exists
+func (db *DB) update(k key, row interface{}) (sql.Result, error) {
+	var res result
+	err := db.execute(func(txn Txn) (err error) {
+		res, err = updateRow(txn, k, row)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// delete deletes the row stored under k
+func (db *DB) delete(k key) (sql.Result, error) {
+	var res result
+	err := db.execute(func(txn Txn) (err error) {
+		res, err = clearRow(txn, k)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// deleteRange deletes the rows with begin <= key < end, at most limit of them unless limit is 0
+func (db *DB) deleteRange(begin key, end key, limit int) (sql.Result, error) {
+	var res result
+	err := db.execute(func(txn Txn) (err error) {
+		res, err = clearRange(txn, begin, end, limit)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
@@ -0,0 +1,507 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package kv
+
+import (
+	"bytes"
+	"database/sql"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"github.com/uber/cadence/common/persistence/sql/storage/sqldb"
+)
+
+type (
+	dbSuite struct {
+		suite.Suite
+		*require.Assertions
+		db *DB
+	}
+
+	// memStore is an in memory Store running one transaction at a time
+	memStore struct {
+		data map[string][]byte
+		// conflicts is the number of the next commits aborted with ErrConflict
+		conflicts int
+	}
+
+	memTxn struct {
+		store  *memStore
+		writes map[string][]byte
+	}
+)
+
+func TestDBSuite(t *testing.T) {
+	suite.Run(t, new(dbSuite))
+}
+
+func (s *dbSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+	s.db = NewDB(&memStore{data: make(map[string][]byte)}, "memory")
+}
+
+func (s *dbSuite) TestKeyOrder() {
+	now := time.Now()
+	ordered := []key{
+		newKey("a").int(-5),
+		newKey("a").int(0),
+		newKey("a").int(7),
+		newKey("a").int(7).str(""),
+		newKey("a").int(7).str("x"),
+		newKey("a").int(7).str("x\x00"),
+		newKey("a").int(7).str("x\x00y"),
+		newKey("a").int(7).str("xa"),
+		newKey("a").time(now),
+		newKey("a").time(now.Add(time.Nanosecond)),
+		newKey("a").time(now.Add(time.Second)),
+		newKey("ab"),
+	}
+	for i := 1; i < len(ordered); i++ {
+		s.True(bytes.Compare(ordered[i-1], ordered[i]) < 0, "key %v", i)
+	}
+	prefix := newKey("a").int(7)
+	s.True(bytes.Compare(prefix.str("zzz"), prefix.next()) < 0)
+	s.True(bytes.Compare(newKey("a").int(8), prefix.next()) >= 0)
+}
+
+func (s *dbSuite) TestShards() {
+	row := &sqldb.ShardsRow{ShardID: 1, RangeID: 3, Data: []byte("data"), DataEncoding: "thriftrw"}
+	_, err := s.db.InsertIntoShards(row)
+	s.NoError(err)
+	_, err = s.db.InsertIntoShards(row)
+	s.Equal(ErrDupEntry, err)
+
+	row.RangeID = 4
+	res, err := s.db.UpdateShards(row)
+	s.NoError(err)
+	s.rowsAffected(1, res)
+	res, err = s.db.UpdateShards(&sqldb.ShardsRow{ShardID: 2})
+	s.NoError(err)
+	s.rowsAffected(0, res)
+
+	rangeID, err := s.db.WriteLockShards(&sqldb.ShardsFilter{ShardID: 1})
+	s.NoError(err)
+	s.Equal(4, rangeID)
+	_, err = s.db.SelectFromShards(&sqldb.ShardsFilter{ShardID: 2})
+	s.Equal(sql.ErrNoRows, err)
+}
+
+func (s *dbSuite) TestTasks() {
+	domainID := sqldb.MustParseUUID("3b1ea9a4-c8e4-4a2a-8c5f-7b1d8a3f1a10")
+	var rows []sqldb.TasksRow
+	for taskID := int64(1); taskID <= 5; taskID++ {
+		rows = append(rows, sqldb.TasksRow{DomainID: domainID, TaskListName: "tl", TaskType: 0, TaskID: taskID})
+	}
+	rows = append(rows, sqldb.TasksRow{DomainID: domainID, TaskListName: "tl", TaskType: 1, TaskID: 3})
+	_, err := s.db.InsertIntoTasks(rows)
+	s.NoError(err)
+
+	minTaskID, maxTaskID, size := int64(1), int64(4), 2
+	tasks, err := s.db.SelectFromTasks(&sqldb.TasksFilter{
+		DomainID: domainID, TaskListName: "tl", MinTaskID: &minTaskID, MaxTaskID: &maxTaskID, PageSize: &size,
+	})
+	s.NoError(err)
+	s.Len(tasks, 2)
+	s.Equal(int64(2), tasks[0].TaskID)
+	s.Equal(int64(3), tasks[1].TaskID)
+
+	lessThanEquals, limit := int64(4), 10
+	res, err := s.db.DeleteFromTasks(&sqldb.TasksFilter{
+		DomainID: domainID, TaskListName: "tl", TaskIDLessThanEquals: &lessThanEquals, Limit: &limit,
+	})
+	s.NoError(err)
+	s.rowsAffected(4, res)
+
+	minTaskID, size = 0, 10
+	tasks, err = s.db.SelectFromTasks(&sqldb.TasksFilter{
+		DomainID: domainID, TaskListName: "tl", MinTaskID: &minTaskID, PageSize: &size,
+	})
+	s.NoError(err)
+	s.Len(tasks, 1)
+	s.Equal(int64(5), tasks[0].TaskID)
+}
+
+func (s *dbSuite) TestTimerTasks() {
+	now := time.Now().UTC()
+	_, err := s.db.InsertIntoTimerTasks([]sqldb.TimerTasksRow{
+		{ShardID: 1, VisibilityTimestamp: now, TaskID: 2},
+		{ShardID: 1, VisibilityTimestamp: now, TaskID: 1},
+		{ShardID: 1, VisibilityTimestamp: now.Add(time.Minute), TaskID: 3},
+		{ShardID: 1, VisibilityTimestamp: now.Add(time.Hour), TaskID: 4},
+	})
+	s.NoError(err)
+
+	minTimestamp, maxTimestamp, size := now, now.Add(time.Hour), 10
+	tasks, err := s.db.SelectFromTimerTasks(&sqldb.TimerTasksFilter{
+		ShardID: 1, TaskID: 2, MinVisibilityTimestamp: &minTimestamp, MaxVisibilityTimestamp: &maxTimestamp, PageSize: &size,
+	})
+	s.NoError(err)
+	s.Len(tasks, 2)
+	s.Equal(int64(2), tasks[0].TaskID)
+	s.Equal(int64(3), tasks[1].TaskID)
+	s.True(now.Equal(tasks[0].VisibilityTimestamp))
+
+	res, err := s.db.DeleteFromTimerTasks(&sqldb.TimerTasksFilter{
+		ShardID: 1, MinVisibilityTimestamp: &minTimestamp, MaxVisibilityTimestamp: &maxTimestamp,
+	})
+	s.NoError(err)
+	s.rowsAffected(3, res)
+}
+
+func (s *dbSuite) TestCurrentExecutions() {
+	domainID := sqldb.MustParseUUID("3b1ea9a4-c8e4-4a2a-8c5f-7b1d8a3f1a10")
+	runID := sqldb.MustParseUUID("8a2d6b0e-2f4c-4f8e-9a51-0c6f3c2d9e77")
+	otherRunID := sqldb.MustParseUUID("f0c1b2a3-0000-4000-8000-000000000001")
+	blockedUntil := time.Now().UTC()
+	_, err := s.db.InsertIntoCurrentExecutions(&sqldb.CurrentExecutionsRow{
+		ShardID: 1, DomainID: domainID, WorkflowID: "wid", RunID: runID, State: 1,
+	})
+	s.NoError(err)
+	res, err := s.db.UpdateCurrentExecutionsBlockedUntil(&sqldb.CurrentExecutionsRow{
+		ShardID: 1, DomainID: domainID, WorkflowID: "wid", RunID: otherRunID, BlockedUntil: blockedUntil,
+	})
+	s.NoError(err)
+	s.rowsAffected(0, res)
+	_, err = s.db.UpdateCurrentExecutionsBlockedUntil(&sqldb.CurrentExecutionsRow{
+		ShardID: 1, DomainID: domainID, WorkflowID: "wid", RunID: runID, BlockedUntil: blockedUntil,
+	})
+	s.NoError(err)
+	_, err = s.db.UpdateCurrentExecutions(&sqldb.CurrentExecutionsRow{
+		ShardID: 1, DomainID: domainID, WorkflowID: "wid", RunID: otherRunID, State: 2,
+	})
+	s.NoError(err)
+
+	filter := &sqldb.CurrentExecutionsFilter{ShardID: 1, DomainID: domainID, WorkflowID: "wid"}
+	row, err := s.db.SelectFromCurrentExecutions(filter)
+	s.NoError(err)
+	s.Equal(sqldb.UUID(otherRunID), row.RunID)
+	s.Equal(2, row.State)
+	s.True(blockedUntil.Equal(row.BlockedUntil))
+
+	rows, err := s.db.LockCurrentExecutionsJoinExecutions(filter)
+	s.NoError(err)
+	s.Empty(rows)
+	_, err = s.db.InsertIntoExecutions(&sqldb.ExecutionsRow{
+		ShardID: 1, DomainID: domainID, WorkflowID: "wid", RunID: otherRunID, LastWriteVersion: 12,
+	})
+	s.NoError(err)
+	rows, err = s.db.LockCurrentExecutionsJoinExecutions(filter)
+	s.NoError(err)
+	s.Len(rows, 1)
+	s.Equal(int64(12), rows[0].LastWriteVersion)
+
+	filter.RunID = runID
+	res, err = s.db.DeleteFromCurrentExecutions(filter)
+	s.NoError(err)
+	s.rowsAffected(0, res)
+	filter.RunID = otherRunID
+	res, err = s.db.DeleteFromCurrentExecutions(filter)
+	s.NoError(err)
+	s.rowsAffected(1, res)
+}
+
+func (s *dbSuite) TestExecutionMaps() {
+	domainID := sqldb.MustParseUUID("3b1ea9a4-c8e4-4a2a-8c5f-7b1d8a3f1a10")
+	runID := sqldb.MustParseUUID("8a2d6b0e-2f4c-4f8e-9a51-0c6f3c2d9e77")
+	_, err := s.db.ReplaceIntoTimerInfoMaps([]sqldb.TimerInfoMapsRow{
+		{ShardID: 1, DomainID: domainID, WorkflowID: "wid", RunID: runID, TimerID: "t1", Data: []byte("1")},
+		{ShardID: 1, DomainID: domainID, WorkflowID: "wid", RunID: runID, TimerID: "t2", Data: []byte("2")},
+		{ShardID: 1, DomainID: domainID, WorkflowID: "wid2", RunID: runID, TimerID: "t1"},
+	})
+	s.NoError(err)
+	_, err = s.db.ReplaceIntoTimerInfoMaps([]sqldb.TimerInfoMapsRow{
+		{ShardID: 1, DomainID: domainID, WorkflowID: "wid", RunID: runID, TimerID: "t2", Data: []byte("3")},
+	})
+	s.NoError(err)
+
+	filter := &sqldb.TimerInfoMapsFilter{ShardID: 1, DomainID: domainID, WorkflowID: "wid", RunID: runID}
+	rows, err := s.db.SelectFromTimerInfoMaps(filter)
+	s.NoError(err)
+	s.Len(rows, 2)
+	s.Equal([]byte("3"), rows[1].Data)
+
+	timerID := "t1"
+	_, err = s.db.DeleteFromTimerInfoMaps(&sqldb.TimerInfoMapsFilter{
+		ShardID: 1, DomainID: domainID, WorkflowID: "wid", RunID: runID, TimerID: &timerID,
+	})
+	s.NoError(err)
+	res, err := s.db.DeleteFromTimerInfoMaps(filter)
+	s.NoError(err)
+	s.rowsAffected(1, res)
+	rows, err = s.db.SelectFromTimerInfoMaps(&sqldb.TimerInfoMapsFilter{ShardID: 1, DomainID: domainID, WorkflowID: "wid2", RunID: runID})
+	s.NoError(err)
+	s.Len(rows, 1)
+}
+
+func (s *dbSuite) TestTransaction() {
+	tx, err := s.db.BeginTx()
+	s.NoError(err)
+	_, err = tx.InsertIntoShards(&sqldb.ShardsRow{ShardID: 1})
+	s.NoError(err)
+	s.NoError(tx.Rollback())
+	_, err = s.db.SelectFromShards(&sqldb.ShardsFilter{ShardID: 1})
+	s.Equal(sql.ErrNoRows, err)
+}
+
+func (s *dbSuite) TestConflict() {
+	store := s.db.store.(*memStore)
+
+	// an operation outside of a transaction is retried
+	store.conflicts = 2
+	_, err := s.db.InsertIntoShards(&sqldb.ShardsRow{ShardID: 1})
+	s.NoError(err)
+	s.Equal(0, store.conflicts)
+
+	store.conflicts = maxConflictRetries
+	_, err = s.db.InsertIntoShards(&sqldb.ShardsRow{ShardID: 2})
+	s.Equal(ErrConflict, err)
+
+	// a transaction reports the conflict even when the caller wrapped the error
+	tx, err := s.db.BeginTx()
+	s.NoError(err)
+	s.False(tx.(sqldb.ConflictReporter).Conflicted())
+	_, err = tx.InsertIntoShards(&sqldb.ShardsRow{ShardID: 3})
+	s.NoError(err)
+	store.conflicts = 1
+	s.Equal(ErrConflict, tx.Commit())
+	s.True(tx.(sqldb.ConflictReporter).Conflicted())
+}
+
+func (s *dbSuite) TestDomains() {
+	id := sqldb.MustParseUUID("3b1ea9a4-c8e4-4a2a-8c5f-7b1d8a3f1a10")
+	otherID := sqldb.MustParseUUID("8a2d6b0e-2f4c-4f8e-9a51-0c6f3c2d9e77")
+	_, err := s.db.InsertIntoDomain(&sqldb.DomainRow{ID: id, Name: "d1", IsGlobal: true})
+	s.NoError(err)
+	_, err = s.db.InsertIntoDomain(&sqldb.DomainRow{ID: otherID, Name: "d1"})
+	s.Equal(ErrDupEntry, err)
+	_, err = s.db.InsertIntoDomain(&sqldb.DomainRow{ID: otherID, Name: "d2"})
+	s.NoError(err)
+
+	res, err := s.db.UpdateDomain(&sqldb.DomainRow{ID: id, Name: "d3", Data: []byte("data")})
+	s.NoError(err)
+	s.rowsAffected(1, res)
+	name := "d1"
+	_, err = s.db.SelectFromDomain(&sqldb.DomainFilter{Name: &name})
+	s.Equal(sql.ErrNoRows, err)
+	name = "d3"
+	rows, err := s.db.SelectFromDomain(&sqldb.DomainFilter{Name: &name})
+	s.NoError(err)
+	s.Len(rows, 1)
+	s.Equal(sqldb.UUID(id), rows[0].ID)
+	s.True(rows[0].IsGlobal)
+	s.Equal([]byte("data"), rows[0].Data)
+
+	size := 1
+	rows, err = s.db.SelectFromDomain(&sqldb.DomainFilter{PageSize: &size})
+	s.NoError(err)
+	s.Len(rows, 1)
+	s.Equal(sqldb.UUID(id), rows[0].ID)
+	rows, err = s.db.SelectFromDomain(&sqldb.DomainFilter{GreaterThanID: &rows[0].ID, PageSize: &size})
+	s.NoError(err)
+	s.Len(rows, 1)
+	s.Equal(sqldb.UUID(otherID), rows[0].ID)
+
+	res, err = s.db.DeleteFromDomain(&sqldb.DomainFilter{Name: &name})
+	s.NoError(err)
+	s.rowsAffected(1, res)
+	_, err = s.db.InsertIntoDomain(&sqldb.DomainRow{ID: id, Name: "d3"})
+	s.NoError(err)
+
+	metadata, err := s.db.SelectFromDomainMetadata()
+	s.NoError(err)
+	s.Equal(int64(initialNotificationVersion), metadata.NotificationVersion)
+	res, err = s.db.UpdateDomainMetadata(&sqldb.DomainMetadataRow{NotificationVersion: metadata.NotificationVersion + 1})
+	s.NoError(err)
+	s.rowsAffected(0, res)
+	res, err = s.db.UpdateDomainMetadata(metadata)
+	s.NoError(err)
+	s.rowsAffected(1, res)
+	metadata, err = s.db.SelectFromDomainMetadata()
+	s.NoError(err)
+	s.Equal(int64(initialNotificationVersion+1), metadata.NotificationVersion)
+}
+
+func (s *dbSuite) TestHistoryNodes() {
+	treeID := sqldb.MustParseUUID("3b1ea9a4-c8e4-4a2a-8c5f-7b1d8a3f1a10")
+	branchID := sqldb.MustParseUUID("8a2d6b0e-2f4c-4f8e-9a51-0c6f3c2d9e77")
+	for _, node := range []struct{ nodeID, txnID int64 }{{1, 1}, {3, 2}, {3, 5}, {6, 6}} {
+		txnID := node.txnID
+		_, err := s.db.InsertIntoHistoryNode(&sqldb.HistoryNodeRow{
+			ShardID: 1, TreeID: treeID, BranchID: branchID, NodeID: node.nodeID, TxnID: &txnID,
+		})
+		s.NoError(err)
+	}
+
+	minNodeID, maxNodeID, size := int64(1), int64(6), 10
+	filter := &sqldb.HistoryNodeFilter{
+		ShardID: 1, TreeID: treeID, BranchID: branchID, MinNodeID: &minNodeID, MaxNodeID: &maxNodeID, PageSize: &size,
+	}
+	rows, err := s.db.SelectFromHistoryNode(filter)
+	s.NoError(err)
+	s.Len(rows, 3)
+	// the rows of a node are ordered by descending transaction ID
+	s.Equal(int64(1), *rows[0].TxnID)
+	s.Equal(int64(5), *rows[1].TxnID)
+	s.Equal(int64(2), *rows[2].TxnID)
+
+	minNodeID = 3
+	res, err := s.db.DeleteFromHistoryNode(filter)
+	s.NoError(err)
+	s.rowsAffected(3, res)
+
+	_, err = s.db.InsertIntoHistoryTree(&sqldb.HistoryTreeRow{
+		ShardID: 1, TreeID: treeID, BranchID: branchID, InProgress: true, Data: []byte("data"),
+	})
+	s.NoError(err)
+	_, err = s.db.UpdateHistoryTree(&sqldb.HistoryTreeRow{ShardID: 1, TreeID: treeID, BranchID: branchID})
+	s.NoError(err)
+	trees, err := s.db.SelectFromHistoryTree(&sqldb.HistoryTreeFilter{ShardID: 1, TreeID: treeID})
+	s.NoError(err)
+	s.Len(trees, 1)
+	s.False(trees[0].InProgress)
+	s.Equal([]byte("data"), trees[0].Data)
+}
+
+func (s *dbSuite) TestVisibility() {
+	now := time.Now().UTC()
+	for i, runID := range []string{"r1", "r2", "r3", "r4"} {
+		_, err := s.db.InsertIntoVisibility(&sqldb.VisibilityRow{
+			DomainID: "d", RunID: runID, WorkflowID: "wid", StartTime: now.Add(-time.Duration(i/2) * time.Minute),
+		})
+		s.NoError(err)
+	}
+	closeStatus, historyLength, closeTime := int32(1), int64(10), now
+	_, err := s.db.ReplaceIntoVisibility(&sqldb.VisibilityRow{
+		DomainID: "d", RunID: "r2", WorkflowID: "wid", StartTime: now,
+		CloseStatus: &closeStatus, CloseTime: &closeTime, HistoryLength: &historyLength,
+	})
+	s.NoError(err)
+
+	// the open rows are ordered by descending start time and run ID, and paged past the last row
+	minStartTime, maxStartTime, runID, size := now.Add(-time.Hour), now, "", 2
+	filter := &sqldb.VisibilityFilter{
+		DomainID: "d", MinStartTime: &minStartTime, MaxStartTime: &maxStartTime, RunID: &runID, PageSize: &size,
+	}
+	rows, err := s.db.SelectFromVisibility(filter)
+	s.NoError(err)
+	s.Len(rows, 2)
+	s.Equal("r1", rows[0].RunID)
+	s.Equal("r3", rows[1].RunID)
+	maxStartTime, runID = rows[1].StartTime, rows[1].RunID
+	rows, err = s.db.SelectFromVisibility(filter)
+	s.NoError(err)
+	s.Len(rows, 1)
+	s.Equal("r4", rows[0].RunID)
+
+	runID = "r2"
+	rows, err = s.db.SelectFromVisibility(&sqldb.VisibilityFilter{DomainID: "d", RunID: &runID, Closed: true})
+	s.NoError(err)
+	s.Len(rows, 1)
+	s.Equal(closeStatus, *rows[0].CloseStatus)
+	runID = "r1"
+	_, err = s.db.SelectFromVisibility(&sqldb.VisibilityFilter{DomainID: "d", RunID: &runID, Closed: true})
+	s.Equal(sql.ErrNoRows, err)
+
+	res, err := s.db.DeleteFromVisibility(&sqldb.VisibilityFilter{DomainID: "d", RunID: &runID})
+	s.NoError(err)
+	s.rowsAffected(1, res)
+	maxStartTime, runID = now, ""
+	rows, err = s.db.SelectFromVisibility(filter)
+	s.NoError(err)
+	s.Len(rows, 2)
+	s.Equal("r3", rows[0].RunID)
+}
+
+func (s *dbSuite) rowsAffected(expected int64, res sql.Result) {
+	n, err := res.RowsAffected()
+	s.NoError(err)
+	s.Equal(expected, n)
+}
+
+func (m *memStore) Begin() (Txn, error) {
+	return &memTxn{store: m, writes: make(map[string][]byte)}, nil
+}
+
+func (m *memStore) Close() error {
+	return nil
+}
+
+func (t *memTxn) Get(key []byte) ([]byte, error) {
+	if value, ok := t.writes[string(key)]; ok {
+		return value, nil
+	}
+	return t.store.data[string(key)], nil
+}
+
+func (t *memTxn) Set(key []byte, value []byte) error {
+	t.writes[string(key)] = value
+	return nil
+}
+
+func (t *memTxn) Clear(key []byte) error {
+	t.writes[string(key)] = nil
+	return nil
+}
+
+func (t *memTxn) GetRange(begin []byte, end []byte, limit int) ([]KeyValue, error) {
+	var keys []string
+	for k := range t.store.data {
+		if _, ok := t.writes[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	for k, v := range t.writes {
+		if v != nil {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	var kvs []KeyValue
+	for _, k := range keys {
+		if k >= string(begin) && k < string(end) && (limit == 0 || len(kvs) < limit) {
+			value, _ := t.Get([]byte(k))
+			kvs = append(kvs, KeyValue{Key: []byte(k), Value: value})
+		}
+	}
+	return kvs, nil
+}
+
+func (t *memTxn) Commit() error {
+	if t.store.conflicts > 0 {
+		t.store.conflicts--
+		return ErrConflict
+	}
+	for k, v := range t.writes {
+		if v == nil {
+			delete(t.store.data, k)
+		} else {
+			t.store.data[k] = v
+		}
+	}
+	return nil
+}
+
+func (t *memTxn) Rollback() error {
+	return nil
+}
@@ -0,0 +1,214 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package kv
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/uber/cadence/common/persistence/sql/storage/sqldb"
+)
+
+const (
+	domainsTable        = "domains"
+	domainsByNameTable  = "domains_by_name"
+	domainMetadataTable = "domain_metadata"
+
+	// initialNotificationVersion is the notification version the domain_metadata row is
+	// created with by the sql schemas, the row does not exist until it is first updated
+	initialNotificationVersion = 1
+)
+
+var errMissingArgs = errors.New("missing one or more args for API")
+
+func domainsKey(id sqldb.UUID) key {
+	return newKey(domainsTable).bytes(id)
+}
+
+// domainsByNameKey is the key of the index of the domains by name, its value is the domain ID
+func domainsByNameKey(name string) key {
+	return newKey(domainsByNameTable).str(name)
+}
+
+func domainMetadataKey() key {
+	return newKey(domainMetadataTable)
+}
+
+// InsertIntoDomain inserts a single row into domains table
+func (db *DB) InsertIntoDomain(row *sqldb.DomainRow) (sql.Result, error) {
+	err := db.execute(func(txn Txn) error {
+		if err := insertRow(txn, domainsByNameKey(row.Name), row.ID); err != nil {
+			return err
+		}
+		return insertRow(txn, domainsKey(row.ID), row)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result(1), nil
+}
+
+// UpdateDomain updates a single row in domains table, is_global is left unchanged
+func (db *DB) UpdateDomain(row *sqldb.DomainRow) (sql.Result, error) {
+	var res result
+	err := db.execute(func(txn Txn) error {
+		k := domainsKey(row.ID)
+		var current sqldb.DomainRow
+		found, err := getRow(txn, k, &current)
+		if err != nil || !found {
+			return err
+		}
+		if current.Name != row.Name {
+			if err := insertRow(txn, domainsByNameKey(row.Name), row.ID); err != nil {
+				return err
+			}
+			if err := txn.Clear(domainsByNameKey(current.Name)); err != nil {
+				return err
+			}
+		}
+		current.Name = row.Name
+		current.Data = row.Data
+		current.DataEncoding = row.DataEncoding
+		res = 1
+		return setRow(txn, k, &current)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// SelectFromDomain reads one or more rows from domains table
+func (db *DB) SelectFromDomain(filter *sqldb.DomainFilter) ([]sqldb.DomainRow, error) {
+	switch {
+	case filter.ID != nil || filter.Name != nil:
+		var row sqldb.DomainRow
+		err := db.execute(func(txn Txn) error {
+			id, err := domainID(txn, filter)
+			if err != nil {
+				return err
+			}
+			return getRowOrNoRows(txn, domainsKey(id), &row)
+		})
+		if err != nil {
+			return nil, err
+		}
+		return []sqldb.DomainRow{row}, nil
+	case filter.PageSize != nil && *filter.PageSize > 0:
+		begin := newKey(domainsTable)
+		if filter.GreaterThanID != nil {
+			begin = domainsKey(*filter.GreaterThanID).next()
+		}
+		kvs, err := db.getRange(begin, newKey(domainsTable).next(), *filter.PageSize)
+		if err != nil {
+			return nil, err
+		}
+		rows := make([]sqldb.DomainRow, len(kvs))
+		if err := decodeRows(kvs, func(i int) interface{} { return &rows[i] }); err != nil {
+			return nil, err
+		}
+		return rows, nil
+	default:
+		return nil, errMissingArgs
+	}
+}
+
+// DeleteFromDomain deletes a single row in domains table
+func (db *DB) DeleteFromDomain(filter *sqldb.DomainFilter) (sql.Result, error) {
+	var res result
+	err := db.execute(func(txn Txn) error {
+		id, err := domainID(txn, filter)
+		if err == sql.ErrNoRows {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		var row sqldb.DomainRow
+		found, err := getRow(txn, domainsKey(id), &row)
+		if err != nil || !found {
+			return err
+		}
+		if err := txn.Clear(domainsByNameKey(row.Name)); err != nil {
+			return err
+		}
+		res, err = clearRow(txn, domainsKey(id))
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// domainID returns the ID of the domain selected by the ID or the name of filter, it fails
+// with sql.ErrNoRows when no domain has the name
+func domainID(txn Txn, filter *sqldb.DomainFilter) (sqldb.UUID, error) {
+	if filter.ID != nil {
+		return *filter.ID, nil
+	}
+	var id sqldb.UUID
+	if err := getRowOrNoRows(txn, domainsByNameKey(*filter.Name), &id); err != nil {
+		return nil, err
+	}
+	return id, nil
+}
+
+// LockDomainMetadata acquires a write lock on a single row in domain_metadata table. Reading the row is
+// enough since a serializable transaction is aborted by any concurrent write of the rows it read
+func (db *DB) LockDomainMetadata() error {
+	_, err := db.SelectFromDomainMetadata()
+	return err
+}
+
+// SelectFromDomainMetadata reads a single row in domain_metadata table
+func (db *DB) SelectFromDomainMetadata() (*sqldb.DomainMetadataRow, error) {
+	row := sqldb.DomainMetadataRow{NotificationVersion: initialNotificationVersion}
+	err := db.execute(func(txn Txn) error {
+		_, err := getRow(txn, domainMetadataKey(), &row)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &row, nil
+}
+
+// UpdateDomainMetadata increments the notification version of the single row in domain_metadata
+// table, the row is only updated when its notification version is the one of the given row
+func (db *DB) UpdateDomainMetadata(row *sqldb.DomainMetadataRow) (sql.Result, error) {
+	var res result
+	err := db.execute(func(txn Txn) error {
+		current := sqldb.DomainMetadataRow{NotificationVersion: initialNotificationVersion}
+		if _, err := getRow(txn, domainMetadataKey(), &current); err != nil {
+			return err
+		}
+		if current.NotificationVersion != row.NotificationVersion {
+			return nil
+		}
+		res = 1
+		return setRow(txn, domainMetadataKey(), &sqldb.DomainMetadataRow{NotificationVersion: row.NotificationVersion + 1})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
@@ -0,0 +1,460 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package kv
+
+import (
+	"bytes"
+	"database/sql"
+	"time"
+
+	"github.com/uber/cadence/common/persistence/sql/storage/sqldb"
+)
+
+const (
+	executionsTable                  = "executions"
+	currentExecutionsTable           = "current_executions"
+	transferTasksTable               = "transfer_tasks"
+	visibilityTasksTable             = "visibility_tasks"
+	timerTasksTable                  = "timer_tasks"
+	closedExecutionsIndexTable       = "closed_executions_index"
+	bufferedEventsTable              = "buffered_events"
+	bufferedReplicationTaskMapsTable = "buffered_replication_task_maps"
+	replicationTasksTable            = "replication_tasks"
+)
+
+// executionKey returns the key prefix of the rows of an execution in table
+func executionKey(table string, shardID int64, domainID sqldb.UUID, workflowID string, runID sqldb.UUID) key {
+	return newKey(table).int(shardID).bytes(domainID).str(workflowID).bytes(runID)
+}
+
+func currentExecutionsKey(shardID int64, domainID sqldb.UUID, workflowID string) key {
+	return newKey(currentExecutionsTable).int(shardID).bytes(domainID).str(workflowID)
+}
+
+// shardTaskKey returns the key of a task of a shard in one of the tables keyed by task ID
+func shardTaskKey(table string, shardID int, taskID int64) key {
+	return newKey(table).int(int64(shardID)).int(taskID)
+}
+
+func timerTasksKey(shardID int, visibilityTimestamp time.Time) key {
+	return newKey(timerTasksTable).int(int64(shardID)).time(visibilityTimestamp)
+}
+
+func closedExecutionsIndexKey(shardID int, closeTime time.Time) key {
+	return newKey(closedExecutionsIndexTable).int(int64(shardID)).time(closeTime)
+}
+
+// InsertIntoExecutions inserts a row into executions table
+func (db *DB) InsertIntoExecutions(row *sqldb.ExecutionsRow) (sql.Result, error) {
+	return db.insertRows(1, func(int) (key, interface{}) {
+		return executionKey(executionsTable, int64(row.ShardID), row.DomainID, row.WorkflowID, row.RunID), row
+	})
+}
+
+// UpdateExecutions updates a single row in executions table
+func (db *DB) UpdateExecutions(row *sqldb.ExecutionsRow) (sql.Result, error) {
+	return db.update(executionKey(executionsTable, int64(row.ShardID), row.DomainID, row.WorkflowID, row.RunID), row)
+}
+
+// SelectFromExecutions reads a single row from executions table
+func (db *DB) SelectFromExecutions(filter *sqldb.ExecutionsFilter) (*sqldb.ExecutionsRow, error) {
+	var row sqldb.ExecutionsRow
+	if err := db.get(executionKey(executionsTable, int64(filter.ShardID), filter.DomainID, filter.WorkflowID, filter.RunID), &row); err != nil {
+		return nil, err
+	}
+	return &row, nil
+}
+
+// SelectRunsFromExecutions reads the rows of a workflow ID from executions table
+func (db *DB) SelectRunsFromExecutions(filter *sqldb.ExecutionsFilter) ([]sqldb.ExecutionsRow, error) {
+	prefix := newKey(executionsTable).int(int64(filter.ShardID)).bytes(filter.DomainID).str(filter.WorkflowID)
+	kvs, err := db.getRange(prefix.bytes(filter.RunID).next(), prefix.next(), *filter.PageSize)
+	if err != nil {
+		return nil, err
+	}
+	rows := make([]sqldb.ExecutionsRow, len(kvs))
+	if err := decodeRows(kvs, func(i int) interface{} { return &rows[i] }); err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+// DeleteFromExecutions deletes a single row from executions table
+func (db *DB) DeleteFromExecutions(filter *sqldb.ExecutionsFilter) (sql.Result, error) {
+	return db.delete(executionKey(executionsTable, int64(filter.ShardID), filter.DomainID, filter.WorkflowID, filter.RunID))
+}
+
+// ReadLockExecutions acquires a read lock on a single row in executions table
+func (db *DB) ReadLockExecutions(filter *sqldb.ExecutionsFilter) (int, error) {
+	row, err := db.SelectFromExecutions(filter)
+	if err != nil {
+		return 0, err
+	}
+	return int(row.NextEventID), nil
+}
+
+// WriteLockExecutions acquires a write lock on a single row in executions table
+func (db *DB) WriteLockExecutions(filter *sqldb.ExecutionsFilter) (int, error) {
+	return db.ReadLockExecutions(filter)
+}
+
+// InsertIntoCurrentExecutions inserts a single row into current_executions table
+func (db *DB) InsertIntoCurrentExecutions(row *sqldb.CurrentExecutionsRow) (sql.Result, error) {
+	return db.insertRows(1, func(int) (key, interface{}) {
+		return currentExecutionsKey(row.ShardID, row.DomainID, row.WorkflowID), row
+	})
+}
+
+// UpdateCurrentExecutions updates a single row in current_executions table, blocked_until is left unchanged
+func (db *DB) UpdateCurrentExecutions(row *sqldb.CurrentExecutionsRow) (sql.Result, error) {
+	return db.updateCurrentExecutions(row, func(current *sqldb.CurrentExecutionsRow) bool {
+		blockedUntil := current.BlockedUntil
+		*current = *row
+		current.BlockedUntil = blockedUntil
+		return true
+	})
+}
+
+// UpdateCurrentExecutionsBlockedUntil updates blocked_until of a single row in current_executions table
+func (db *DB) UpdateCurrentExecutionsBlockedUntil(row *sqldb.CurrentExecutionsRow) (sql.Result, error) {
+	return db.updateCurrentExecutions(row, func(current *sqldb.CurrentExecutionsRow) bool {
+		if !bytes.Equal(current.RunID, row.RunID) {
+			return false
+		}
+		current.BlockedUntil = row.BlockedUntil
+		return true
+	})
+}
+
+// updateCurrentExecutions applies update to the current_executions row of the execution of row,
+// the row is only written back when update reports it matched
+func (db *DB) updateCurrentExecutions(row *sqldb.CurrentExecutionsRow, update func(current *sqldb.CurrentExecutionsRow) bool) (sql.Result, error) {
+	var res result
+	err := db.execute(func(txn Txn) error {
+		k := currentExecutionsKey(row.ShardID, row.DomainID, row.WorkflowID)
+		var current sqldb.CurrentExecutionsRow
+		found, err := getRow(txn, k, &current)
+		if err != nil || !found || !update(&current) {
+			return err
+		}
+		res = 1
+		return setRow(txn, k, &current)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// SelectFromCurrentExecutions reads one or more rows from current_executions table
+func (db *DB) SelectFromCurrentExecutions(filter *sqldb.CurrentExecutionsFilter) (*sqldb.CurrentExecutionsRow, error) {
+	var row sqldb.CurrentExecutionsRow
+	err := db.get(currentExecutionsKey(filter.ShardID, filter.DomainID, filter.WorkflowID), &row)
+	return &row, err
+}
+
+// RangeSelectFromCurrentExecutions reads a page of rows of a shard from current_executions table
+func (db *DB) RangeSelectFromCurrentExecutions(filter *sqldb.CurrentExecutionsFilter) ([]sqldb.CurrentExecutionsRow, error) {
+	kvs, err := db.getRange(currentExecutionsKey(filter.ShardID, filter.DomainID, filter.WorkflowID).next(),
+		newKey(currentExecutionsTable).int(filter.ShardID).next(), *filter.PageSize)
+	if err != nil {
+		return nil, err
+	}
+	rows := make([]sqldb.CurrentExecutionsRow, len(kvs))
+	if err := decodeRows(kvs, func(i int) interface{} { return &rows[i] }); err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+// DeleteFromCurrentExecutions deletes a single row in current_executions table
+func (db *DB) DeleteFromCurrentExecutions(filter *sqldb.CurrentExecutionsFilter) (sql.Result, error) {
+	var res result
+	err := db.execute(func(txn Txn) error {
+		k := currentExecutionsKey(filter.ShardID, filter.DomainID, filter.WorkflowID)
+		var row sqldb.CurrentExecutionsRow
+		found, err := getRow(txn, k, &row)
+		if err != nil || !found || !bytes.Equal(row.RunID, filter.RunID) {
+			return err
+		}
+		res, err = clearRow(txn, k)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// LockCurrentExecutions acquires a write lock on a single row in current_executions table
+func (db *DB) LockCurrentExecutions(filter *sqldb.CurrentExecutionsFilter) (*sqldb.CurrentExecutionsRow, error) {
+	return db.SelectFromCurrentExecutions(filter)
+}
+
+// LockCurrentExecutionsJoinExecutions joins a row in current_executions with executions table and acquires a
+// write lock on the result
+func (db *DB) LockCurrentExecutionsJoinExecutions(filter *sqldb.CurrentExecutionsFilter) ([]sqldb.CurrentExecutionsRow, error) {
+	var rows []sqldb.CurrentExecutionsRow
+	err := db.execute(func(txn Txn) error {
+		var current sqldb.CurrentExecutionsRow
+		found, err := getRow(txn, currentExecutionsKey(filter.ShardID, filter.DomainID, filter.WorkflowID), &current)
+		if err != nil || !found {
+			return err
+		}
+		var execution sqldb.ExecutionsRow
+		found, err = getRow(txn, executionKey(executionsTable, current.ShardID, current.DomainID, current.WorkflowID, current.RunID), &execution)
+		if err != nil || !found {
+			return err
+		}
+		current.LastWriteVersion = execution.LastWriteVersion
+		rows = append(rows, current)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+// InsertIntoTransferTasks inserts one or more rows into transfer_tasks table
+func (db *DB) InsertIntoTransferTasks(rows []sqldb.TransferTasksRow) (sql.Result, error) {
+	return db.insertRows(len(rows), func(i int) (key, interface{}) {
+		return shardTaskKey(transferTasksTable, rows[i].ShardID, rows[i].TaskID), &rows[i]
+	})
+}
+
+// SelectFromTransferTasks reads one or more rows from transfer_tasks table
+func (db *DB) SelectFromTransferTasks(filter *sqldb.TransferTasksFilter) ([]sqldb.TransferTasksRow, error) {
+	kvs, err := db.getRange(shardTaskKey(transferTasksTable, filter.ShardID, *filter.MinTaskID).next(),
+		shardTaskKey(transferTasksTable, filter.ShardID, *filter.MaxTaskID).next(), 0)
+	if err != nil {
+		return nil, err
+	}
+	rows := make([]sqldb.TransferTasksRow, len(kvs))
+	if err := decodeRows(kvs, func(i int) interface{} { return &rows[i] }); err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+// DeleteFromTransferTasks deletes one or more rows from transfer_tasks table
+func (db *DB) DeleteFromTransferTasks(filter *sqldb.TransferTasksFilter) (sql.Result, error) {
+	if filter.MinTaskID != nil {
+		return db.deleteRange(shardTaskKey(transferTasksTable, filter.ShardID, *filter.MinTaskID).next(),
+			shardTaskKey(transferTasksTable, filter.ShardID, *filter.MaxTaskID).next(), 0)
+	}
+	return db.delete(shardTaskKey(transferTasksTable, filter.ShardID, *filter.TaskID))
+}
+
+// InsertIntoVisibilityTasks inserts one or more rows into visibility_tasks table
+func (db *DB) InsertIntoVisibilityTasks(rows []sqldb.VisibilityTasksRow) (sql.Result, error) {
+	return db.insertRows(len(rows), func(i int) (key, interface{}) {
+		return shardTaskKey(visibilityTasksTable, rows[i].ShardID, rows[i].TaskID), &rows[i]
+	})
+}
+
+// SelectFromVisibilityTasks reads one or more rows from visibility_tasks table
+func (db *DB) SelectFromVisibilityTasks(filter *sqldb.VisibilityTasksFilter) ([]sqldb.VisibilityTasksRow, error) {
+	kvs, err := db.getRange(shardTaskKey(visibilityTasksTable, filter.ShardID, *filter.MinTaskID).next(),
+		shardTaskKey(visibilityTasksTable, filter.ShardID, *filter.MaxTaskID).next(), 0)
+	if err != nil {
+		return nil, err
+	}
+	rows := make([]sqldb.VisibilityTasksRow, len(kvs))
+	if err := decodeRows(kvs, func(i int) interface{} { return &rows[i] }); err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+// DeleteFromVisibilityTasks deletes one or more rows from visibility_tasks table
+func (db *DB) DeleteFromVisibilityTasks(filter *sqldb.VisibilityTasksFilter) (sql.Result, error) {
+	if filter.MinTaskID != nil {
+		return db.deleteRange(shardTaskKey(visibilityTasksTable, filter.ShardID, *filter.MinTaskID).next(),
+			shardTaskKey(visibilityTasksTable, filter.ShardID, *filter.MaxTaskID).next(), 0)
+	}
+	return db.delete(shardTaskKey(visibilityTasksTable, filter.ShardID, *filter.TaskID))
+}
+
+// InsertIntoTimerTasks inserts one or more rows into timer_tasks table
+func (db *DB) InsertIntoTimerTasks(rows []sqldb.TimerTasksRow) (sql.Result, error) {
+	return db.insertRows(len(rows), func(i int) (key, interface{}) {
+		return timerTasksKey(rows[i].ShardID, rows[i].VisibilityTimestamp).int(rows[i].TaskID), &rows[i]
+	})
+}
+
+// SelectFromTimerTasks reads one or more rows from timer_tasks table
+func (db *DB) SelectFromTimerTasks(filter *sqldb.TimerTasksFilter) ([]sqldb.TimerTasksRow, error) {
+	kvs, err := db.getRange(timerTasksKey(filter.ShardID, *filter.MinVisibilityTimestamp).int(filter.TaskID),
+		timerTasksKey(filter.ShardID, *filter.MaxVisibilityTimestamp), *filter.PageSize)
+	if err != nil {
+		return nil, err
+	}
+	rows := make([]sqldb.TimerTasksRow, len(kvs))
+	if err := decodeRows(kvs, func(i int) interface{} { return &rows[i] }); err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+// DeleteFromTimerTasks deletes one or more rows from timer_tasks table
+func (db *DB) DeleteFromTimerTasks(filter *sqldb.TimerTasksFilter) (sql.Result, error) {
+	if filter.MinVisibilityTimestamp != nil {
+		return db.deleteRange(timerTasksKey(filter.ShardID, *filter.MinVisibilityTimestamp),
+			timerTasksKey(filter.ShardID, *filter.MaxVisibilityTimestamp), 0)
+	}
+	return db.delete(timerTasksKey(filter.ShardID, *filter.VisibilityTimestamp).int(filter.TaskID))
+}
+
+// InsertIntoClosedExecutionsIndex inserts one or more rows into closed_executions_index table
+func (db *DB) InsertIntoClosedExecutionsIndex(rows []sqldb.ClosedExecutionsIndexRow) (sql.Result, error) {
+	return db.insertRows(len(rows), func(i int) (key, interface{}) {
+		row := &rows[i]
+		return closedExecutionsIndexKey(row.ShardID, row.CloseTime).bytes(row.DomainID).str(row.WorkflowID).bytes(row.RunID), row
+	})
+}
+
+// SelectFromClosedExecutionsIndex reads one or more rows from closed_executions_index table
+func (db *DB) SelectFromClosedExecutionsIndex(filter *sqldb.ClosedExecutionsIndexFilter) ([]sqldb.ClosedExecutionsIndexRow, error) {
+	begin := closedExecutionsIndexKey(filter.ShardID, filter.CloseTime).bytes(filter.DomainID).str(filter.WorkflowID).bytes(filter.RunID)
+	kvs, err := db.getRange(begin, closedExecutionsIndexKey(filter.ShardID, *filter.MaxCloseTime), *filter.PageSize)
+	if err != nil {
+		return nil, err
+	}
+	rows := make([]sqldb.ClosedExecutionsIndexRow, len(kvs))
+	if err := decodeRows(kvs, func(i int) interface{} { return &rows[i] }); err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+// DeleteFromClosedExecutionsIndex deletes a single row from closed_executions_index table
+func (db *DB) DeleteFromClosedExecutionsIndex(filter *sqldb.ClosedExecutionsIndexFilter) (sql.Result, error) {
+	return db.delete(closedExecutionsIndexKey(filter.ShardID, filter.CloseTime).bytes(filter.DomainID).str(filter.WorkflowID).bytes(filter.RunID))
+}
+
+// InsertIntoBufferedEvents inserts one or more rows into buffered_events table. The rows of an
+// execution are only ever deleted all together, so their number is used as the key of the next one
+func (db *DB) InsertIntoBufferedEvents(rows []sqldb.BufferedEventsRow) (sql.Result, error) {
+	err := db.execute(func(txn Txn) error {
+		for i := range rows {
+			row := &rows[i]
+			prefix := executionKey(bufferedEventsTable, int64(row.ShardID), row.DomainID, row.WorkflowID, row.RunID)
+			kvs, err := txn.GetRange(prefix, prefix.next(), 0)
+			if err != nil {
+				return err
+			}
+			if err := setRow(txn, prefix.int(int64(len(kvs))), row); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result(len(rows)), nil
+}
+
+// SelectFromBufferedEvents reads one or more rows from buffered_events table
+func (db *DB) SelectFromBufferedEvents(filter *sqldb.BufferedEventsFilter) ([]sqldb.BufferedEventsRow, error) {
+	prefix := executionKey(bufferedEventsTable, int64(filter.ShardID), filter.DomainID, filter.WorkflowID, filter.RunID)
+	kvs, err := db.getRange(prefix, prefix.next(), 0)
+	if err != nil {
+		return nil, err
+	}
+	rows := make([]sqldb.BufferedEventsRow, len(kvs))
+	if err := decodeRows(kvs, func(i int) interface{} { return &rows[i] }); err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+// DeleteFromBufferedEvents deletes one or more rows from buffered_events table
+func (db *DB) DeleteFromBufferedEvents(filter *sqldb.BufferedEventsFilter) (sql.Result, error) {
+	prefix := executionKey(bufferedEventsTable, int64(filter.ShardID), filter.DomainID, filter.WorkflowID, filter.RunID)
+	return db.deleteRange(prefix, prefix.next(), 0)
+}
+
+// SelectCountsFromBufferedReplicationTaskMaps reads the buffered replication task count of every execution of a shard.
+// The rows of buffered_replication_task_maps hold the execution they belong to, consecutive rows of the same
+// execution are counted together
+func (db *DB) SelectCountsFromBufferedReplicationTaskMaps(filter *sqldb.BufferedReplicationTaskMapsFilter) ([]sqldb.BufferedReplicationTaskMapsRow, error) {
+	begin := executionKey(bufferedReplicationTaskMapsTable, int64(filter.ShardID), filter.DomainID, filter.WorkflowID, filter.RunID).next()
+	kvs, err := db.getRange(begin, newKey(bufferedReplicationTaskMapsTable).int(int64(filter.ShardID)).next(), 0)
+	if err != nil {
+		return nil, err
+	}
+	var rows []sqldb.BufferedReplicationTaskMapsRow
+	for _, kv := range kvs {
+		var row sqldb.BufferedReplicationTaskMapsRow
+		if err := decodeRow(kv.Value, &row); err != nil {
+			return nil, err
+		}
+		if n := len(rows); n > 0 && bytes.Equal(rows[n-1].DomainID, row.DomainID) &&
+			rows[n-1].WorkflowID == row.WorkflowID && bytes.Equal(rows[n-1].RunID, row.RunID) {
+			rows[n-1].TaskCount++
+			continue
+		}
+		if len(rows) == *filter.PageSize {
+			break
+		}
+		row.TaskCount = 1
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// DeleteFromBufferedReplicationTaskMaps deletes the buffered replication tasks of an execution
+func (db *DB) DeleteFromBufferedReplicationTaskMaps(filter *sqldb.BufferedReplicationTaskMapsFilter) (sql.Result, error) {
+	prefix := executionKey(bufferedReplicationTaskMapsTable, int64(filter.ShardID), filter.DomainID, filter.WorkflowID, filter.RunID)
+	return db.deleteRange(prefix, prefix.next(), 0)
+}
+
+// InsertIntoReplicationTasks inserts one or more rows into replication_tasks table
+func (db *DB) InsertIntoReplicationTasks(rows []sqldb.ReplicationTasksRow) (sql.Result, error) {
+	return db.insertRows(len(rows), func(i int) (key, interface{}) {
+		return shardTaskKey(replicationTasksTable, rows[i].ShardID, rows[i].TaskID), &rows[i]
+	})
+}
+
+// SelectFromReplicationTasks reads one or more rows from replication_tasks table
+func (db *DB) SelectFromReplicationTasks(filter *sqldb.ReplicationTasksFilter) ([]sqldb.ReplicationTasksRow, error) {
+	kvs, err := db.getRange(shardTaskKey(replicationTasksTable, filter.ShardID, *filter.MinTaskID).next(),
+		shardTaskKey(replicationTasksTable, filter.ShardID, *filter.MaxTaskID).next(), *filter.PageSize)
+	if err != nil {
+		return nil, err
+	}
+	rows := make([]sqldb.ReplicationTasksRow, len(kvs))
+	if err := decodeRows(kvs, func(i int) interface{} { return &rows[i] }); err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+// DeleteFromReplicationTasks deletes one or more rows from replication_tasks table
+func (db *DB) DeleteFromReplicationTasks(filter *sqldb.ReplicationTasksFilter) (sql.Result, error) {
+	if filter.MaxTaskID != nil {
+		return db.deleteRange(newKey(replicationTasksTable).int(int64(filter.ShardID)),
+			shardTaskKey(replicationTasksTable, filter.ShardID, *filter.MaxTaskID).next(), 0)
+	}
+	return db.delete(shardTaskKey(replicationTasksTable, filter.ShardID, *filter.TaskID))
+}
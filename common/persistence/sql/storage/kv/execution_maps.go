@@ -0,0 +1,260 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package kv
+
+import (
+	"database/sql"
+
+	"github.com/uber/cadence/common/persistence/sql/storage/sqldb"
+)
+
+const (
+	activityInfoMapsTable       = "activity_info_maps"
+	timerInfoMapsTable          = "timer_info_maps"
+	childExecutionInfoMapsTable = "child_execution_info_maps"
+	requestCancelInfoMapsTable  = "request_cancel_info_maps"
+	signalInfoMapsTable         = "signal_info_maps"
+	updateInfoMapsTable         = "update_info_maps"
+	signalsRequestedSetsTable   = "signals_requested_sets"
+)
+
+// selectMap reads all the rows of an execution from one of the map tables, the map key
+// being the last column of their primary key
+func (db *DB) selectMap(prefix key, alloc func(n int), row func(i int) interface{}) error {
+	kvs, err := db.getRange(prefix, prefix.next(), 0)
+	if err != nil {
+		return err
+	}
+	alloc(len(kvs))
+	return decodeRows(kvs, row)
+}
+
+// deleteMap deletes the row of mapKey from one of the map tables, or all the rows of the execution when mapKey is nil
+func (db *DB) deleteMap(prefix key, mapKey func(k key) key) (sql.Result, error) {
+	if mapKey != nil {
+		return db.delete(mapKey(prefix))
+	}
+	return db.deleteRange(prefix, prefix.next(), 0)
+}
+
+// ReplaceIntoActivityInfoMaps replaces one or more rows in activity_info_maps table
+func (db *DB) ReplaceIntoActivityInfoMaps(rows []sqldb.ActivityInfoMapsRow) (sql.Result, error) {
+	return db.replaceRows(len(rows), func(i int) (key, interface{}) {
+		row := &rows[i]
+		return executionKey(activityInfoMapsTable, row.ShardID, row.DomainID, row.WorkflowID, row.RunID).int(row.ScheduleID), row
+	})
+}
+
+// SelectFromActivityInfoMaps reads one or more rows from activity_info_maps table
+func (db *DB) SelectFromActivityInfoMaps(filter *sqldb.ActivityInfoMapsFilter) ([]sqldb.ActivityInfoMapsRow, error) {
+	var rows []sqldb.ActivityInfoMapsRow
+	err := db.selectMap(executionKey(activityInfoMapsTable, filter.ShardID, filter.DomainID, filter.WorkflowID, filter.RunID),
+		func(n int) { rows = make([]sqldb.ActivityInfoMapsRow, n) },
+		func(i int) interface{} { return &rows[i] })
+	if err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+// DeleteFromActivityInfoMaps deletes one or more rows from activity_info_maps table
+func (db *DB) DeleteFromActivityInfoMaps(filter *sqldb.ActivityInfoMapsFilter) (sql.Result, error) {
+	var mapKey func(k key) key
+	if filter.ScheduleID != nil {
+		mapKey = func(k key) key { return k.int(*filter.ScheduleID) }
+	}
+	return db.deleteMap(executionKey(activityInfoMapsTable, filter.ShardID, filter.DomainID, filter.WorkflowID, filter.RunID), mapKey)
+}
+
+// ReplaceIntoTimerInfoMaps replaces one or more rows in timer_info_maps table
+func (db *DB) ReplaceIntoTimerInfoMaps(rows []sqldb.TimerInfoMapsRow) (sql.Result, error) {
+	return db.replaceRows(len(rows), func(i int) (key, interface{}) {
+		row := &rows[i]
+		return executionKey(timerInfoMapsTable, row.ShardID, row.DomainID, row.WorkflowID, row.RunID).str(row.TimerID), row
+	})
+}
+
+// SelectFromTimerInfoMaps reads one or more rows from timer_info_maps table
+func (db *DB) SelectFromTimerInfoMaps(filter *sqldb.TimerInfoMapsFilter) ([]sqldb.TimerInfoMapsRow, error) {
+	var rows []sqldb.TimerInfoMapsRow
+	err := db.selectMap(executionKey(timerInfoMapsTable, filter.ShardID, filter.DomainID, filter.WorkflowID, filter.RunID),
+		func(n int) { rows = make([]sqldb.TimerInfoMapsRow, n) },
+		func(i int) interface{} { return &rows[i] })
+	if err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+// DeleteFromTimerInfoMaps deletes one or more rows from timer_info_maps table
+func (db *DB) DeleteFromTimerInfoMaps(filter *sqldb.TimerInfoMapsFilter) (sql.Result, error) {
+	var mapKey func(k key) key
+	if filter.TimerID != nil {
+		mapKey = func(k key) key { return k.str(*filter.TimerID) }
+	}
+	return db.deleteMap(executionKey(timerInfoMapsTable, filter.ShardID, filter.DomainID, filter.WorkflowID, filter.RunID), mapKey)
+}
+
+// ReplaceIntoChildExecutionInfoMaps replaces one or more rows in child_execution_info_maps table
+func (db *DB) ReplaceIntoChildExecutionInfoMaps(rows []sqldb.ChildExecutionInfoMapsRow) (sql.Result, error) {
+	return db.replaceRows(len(rows), func(i int) (key, interface{}) {
+		row := &rows[i]
+		return executionKey(childExecutionInfoMapsTable, row.ShardID, row.DomainID, row.WorkflowID, row.RunID).int(row.InitiatedID), row
+	})
+}
+
+// SelectFromChildExecutionInfoMaps reads one or more rows from child_execution_info_maps table
+func (db *DB) SelectFromChildExecutionInfoMaps(filter *sqldb.ChildExecutionInfoMapsFilter) ([]sqldb.ChildExecutionInfoMapsRow, error) {
+	var rows []sqldb.ChildExecutionInfoMapsRow
+	err := db.selectMap(executionKey(childExecutionInfoMapsTable, filter.ShardID, filter.DomainID, filter.WorkflowID, filter.RunID),
+		func(n int) { rows = make([]sqldb.ChildExecutionInfoMapsRow, n) },
+		func(i int) interface{} { return &rows[i] })
+	if err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+// DeleteFromChildExecutionInfoMaps deletes one or more rows from child_execution_info_maps table
+func (db *DB) DeleteFromChildExecutionInfoMaps(filter *sqldb.ChildExecutionInfoMapsFilter) (sql.Result, error) {
+	var mapKey func(k key) key
+	if filter.InitiatedID != nil {
+		mapKey = func(k key) key { return k.int(*filter.InitiatedID) }
+	}
+	return db.deleteMap(executionKey(childExecutionInfoMapsTable, filter.ShardID, filter.DomainID, filter.WorkflowID, filter.RunID), mapKey)
+}
+
+// ReplaceIntoRequestCancelInfoMaps replaces one or more rows in request_cancel_info_maps table
+func (db *DB) ReplaceIntoRequestCancelInfoMaps(rows []sqldb.RequestCancelInfoMapsRow) (sql.Result, error) {
+	return db.replaceRows(len(rows), func(i int) (key, interface{}) {
+		row := &rows[i]
+		return executionKey(requestCancelInfoMapsTable, row.ShardID, row.DomainID, row.WorkflowID, row.RunID).int(row.InitiatedID), row
+	})
+}
+
+// SelectFromRequestCancelInfoMaps reads one or more rows from request_cancel_info_maps table
+func (db *DB) SelectFromRequestCancelInfoMaps(filter *sqldb.RequestCancelInfoMapsFilter) ([]sqldb.RequestCancelInfoMapsRow, error) {
+	var rows []sqldb.RequestCancelInfoMapsRow
+	err := db.selectMap(executionKey(requestCancelInfoMapsTable, filter.ShardID, filter.DomainID, filter.WorkflowID, filter.RunID),
+		func(n int) { rows = make([]sqldb.RequestCancelInfoMapsRow, n) },
+		func(i int) interface{} { return &rows[i] })
+	if err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+// DeleteFromRequestCancelInfoMaps deletes one or more rows from request_cancel_info_maps table
+func (db *DB) DeleteFromRequestCancelInfoMaps(filter *sqldb.RequestCancelInfoMapsFilter) (sql.Result, error) {
+	var mapKey func(k key) key
+	if filter.InitiatedID != nil {
+		mapKey = func(k key) key { return k.int(*filter.InitiatedID) }
+	}
+	return db.deleteMap(executionKey(requestCancelInfoMapsTable, filter.ShardID, filter.DomainID, filter.WorkflowID, filter.RunID), mapKey)
+}
+
+// ReplaceIntoSignalInfoMaps replaces one or more rows in signal_info_maps table
+func (db *DB) ReplaceIntoSignalInfoMaps(rows []sqldb.SignalInfoMapsRow) (sql.Result, error) {
+	return db.replaceRows(len(rows), func(i int) (key, interface{}) {
+		row := &rows[i]
+		return executionKey(signalInfoMapsTable, row.ShardID, row.DomainID, row.WorkflowID, row.RunID).int(row.InitiatedID), row
+	})
+}
+
+// SelectFromSignalInfoMaps reads one or more rows from signal_info_maps table
+func (db *DB) SelectFromSignalInfoMaps(filter *sqldb.SignalInfoMapsFilter) ([]sqldb.SignalInfoMapsRow, error) {
+	var rows []sqldb.SignalInfoMapsRow
+	err := db.selectMap(executionKey(signalInfoMapsTable, filter.ShardID, filter.DomainID, filter.WorkflowID, filter.RunID),
+		func(n int) { rows = make([]sqldb.SignalInfoMapsRow, n) },
+		func(i int) interface{} { return &rows[i] })
+	if err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+// DeleteFromSignalInfoMaps deletes one or more rows from signal_info_maps table
+func (db *DB) DeleteFromSignalInfoMaps(filter *sqldb.SignalInfoMapsFilter) (sql.Result, error) {
+	var mapKey func(k key) key
+	if filter.InitiatedID != nil {
+		mapKey = func(k key) key { return k.int(*filter.InitiatedID) }
+	}
+	return db.deleteMap(executionKey(signalInfoMapsTable, filter.ShardID, filter.DomainID, filter.WorkflowID, filter.RunID), mapKey)
+}
+
+// ReplaceIntoUpdateInfoMaps replaces one or more rows in update_info_maps table
+func (db *DB) ReplaceIntoUpdateInfoMaps(rows []sqldb.UpdateInfoMapsRow) (sql.Result, error) {
+	return db.replaceRows(len(rows), func(i int) (key, interface{}) {
+		row := &rows[i]
+		return executionKey(updateInfoMapsTable, row.ShardID, row.DomainID, row.WorkflowID, row.RunID).str(row.UpdateID), row
+	})
+}
+
+// SelectFromUpdateInfoMaps reads one or more rows from update_info_maps table
+func (db *DB) SelectFromUpdateInfoMaps(filter *sqldb.UpdateInfoMapsFilter) ([]sqldb.UpdateInfoMapsRow, error) {
+	var rows []sqldb.UpdateInfoMapsRow
+	err := db.selectMap(executionKey(updateInfoMapsTable, filter.ShardID, filter.DomainID, filter.WorkflowID, filter.RunID),
+		func(n int) { rows = make([]sqldb.UpdateInfoMapsRow, n) },
+		func(i int) interface{} { return &rows[i] })
+	if err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+// DeleteFromUpdateInfoMaps deletes one or more rows from update_info_maps table
+func (db *DB) DeleteFromUpdateInfoMaps(filter *sqldb.UpdateInfoMapsFilter) (sql.Result, error) {
+	var mapKey func(k key) key
+	if filter.UpdateID != nil {
+		mapKey = func(k key) key { return k.str(*filter.UpdateID) }
+	}
+	return db.deleteMap(executionKey(updateInfoMapsTable, filter.ShardID, filter.DomainID, filter.WorkflowID, filter.RunID), mapKey)
+}
+
+// InsertIntoSignalsRequestedSets inserts one or more rows into signals_requested_sets table,
+// inserting a row that already exists is not an error
+func (db *DB) InsertIntoSignalsRequestedSets(rows []sqldb.SignalsRequestedSetsRow) (sql.Result, error) {
+	return db.replaceRows(len(rows), func(i int) (key, interface{}) {
+		row := &rows[i]
+		return executionKey(signalsRequestedSetsTable, row.ShardID, row.DomainID, row.WorkflowID, row.RunID).str(row.SignalID), row
+	})
+}
+
+// SelectFromSignalsRequestedSets reads one or more rows from signals_requested_sets table
+func (db *DB) SelectFromSignalsRequestedSets(filter *sqldb.SignalsRequestedSetsFilter) ([]sqldb.SignalsRequestedSetsRow, error) {
+	var rows []sqldb.SignalsRequestedSetsRow
+	err := db.selectMap(executionKey(signalsRequestedSetsTable, filter.ShardID, filter.DomainID, filter.WorkflowID, filter.RunID),
+		func(n int) { rows = make([]sqldb.SignalsRequestedSetsRow, n) },
+		func(i int) interface{} { return &rows[i] })
+	if err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+// DeleteFromSignalsRequestedSets deletes one or more rows from signals_requested_sets table
+func (db *DB) DeleteFromSignalsRequestedSets(filter *sqldb.SignalsRequestedSetsFilter) (sql.Result, error) {
+	var mapKey func(k key) key
+	if filter.SignalID != nil {
+		mapKey = func(k key) key { return k.str(*filter.SignalID) }
+	}
+	return db.deleteMap(executionKey(signalsRequestedSetsTable, filter.ShardID, filter.DomainID, filter.WorkflowID, filter.RunID), mapKey)
+}
@@ -0,0 +1,160 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package kv
+
+import (
+	"database/sql"
+
+	"github.com/uber/cadence/common/persistence/sql/storage/sqldb"
+)
+
+const (
+	eventsTable      = "events"
+	historyNodeTable = "history_node"
+	historyTreeTable = "history_tree"
+)
+
+func eventsKey(domainID sqldb.UUID, workflowID string, runID sqldb.UUID) key {
+	return newKey(eventsTable).bytes(domainID).str(workflowID).bytes(runID)
+}
+
+func historyNodeKey(shardID int, treeID sqldb.UUID, branchID sqldb.UUID) key {
+	return newKey(historyNodeTable).int(int64(shardID)).bytes(treeID).bytes(branchID)
+}
+
+func historyTreeKey(shardID int, treeID sqldb.UUID) key {
+	return newKey(historyTreeTable).int(int64(shardID)).bytes(treeID)
+}
+
+// InsertIntoEvents inserts a row into events table
+func (db *DB) InsertIntoEvents(row *sqldb.EventsRow) (sql.Result, error) {
+	return db.insertRows(1, func(int) (key, interface{}) {
+		return eventsKey(row.DomainID, row.WorkflowID, row.RunID).int(row.FirstEventID), row
+	})
+}
+
+// UpdateEvents updates a row in events table
+func (db *DB) UpdateEvents(row *sqldb.EventsRow) (sql.Result, error) {
+	return db.update(eventsKey(row.DomainID, row.WorkflowID, row.RunID).int(row.FirstEventID), row)
+}
+
+// SelectFromEvents reads one or more rows from events table
+func (db *DB) SelectFromEvents(filter *sqldb.EventsFilter) ([]sqldb.EventsRow, error) {
+	prefix := eventsKey(filter.DomainID, filter.WorkflowID, filter.RunID)
+	kvs, err := db.getRange(prefix.int(*filter.FirstEventID), prefix.int(*filter.NextEventID), *filter.PageSize)
+	if err != nil {
+		return nil, err
+	}
+	rows := make([]sqldb.EventsRow, len(kvs))
+	if err := decodeRows(kvs, func(i int) interface{} { return &rows[i] }); err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+// DeleteFromEvents deletes one or more rows from events table
+func (db *DB) DeleteFromEvents(filter *sqldb.EventsFilter) (sql.Result, error) {
+	prefix := eventsKey(filter.DomainID, filter.WorkflowID, filter.RunID)
+	return db.deleteRange(prefix, prefix.next(), 0)
+}
+
+// LockEvents acquires a write lock on a single row in events table
+func (db *DB) LockEvents(filter *sqldb.EventsFilter) (*sqldb.EventsRow, error) {
+	var row sqldb.EventsRow
+	if err := db.get(eventsKey(filter.DomainID, filter.WorkflowID, filter.RunID).int(*filter.FirstEventID), &row); err != nil {
+		return nil, err
+	}
+	return &row, nil
+}
+
+// InsertIntoHistoryNode inserts a row into history_node table. The rows of a node are ordered by
+// descending transaction ID, so the transaction ID is negated in the key
+func (db *DB) InsertIntoHistoryNode(row *sqldb.HistoryNodeRow) (sql.Result, error) {
+	return db.insertRows(1, func(int) (key, interface{}) {
+		return historyNodeKey(row.ShardID, row.TreeID, row.BranchID).int(row.NodeID).int(-*row.TxnID), row
+	})
+}
+
+// SelectFromHistoryNode reads one or more rows from history_node table
+func (db *DB) SelectFromHistoryNode(filter *sqldb.HistoryNodeFilter) ([]sqldb.HistoryNodeRow, error) {
+	prefix := historyNodeKey(filter.ShardID, filter.TreeID, filter.BranchID)
+	kvs, err := db.getRange(prefix.int(*filter.MinNodeID), prefix.int(*filter.MaxNodeID), *filter.PageSize)
+	if err != nil {
+		return nil, err
+	}
+	rows := make([]sqldb.HistoryNodeRow, len(kvs))
+	if err := decodeRows(kvs, func(i int) interface{} { return &rows[i] }); err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+// DeleteFromHistoryNode deletes one or more rows from history_node table
+func (db *DB) DeleteFromHistoryNode(filter *sqldb.HistoryNodeFilter) (sql.Result, error) {
+	prefix := historyNodeKey(filter.ShardID, filter.TreeID, filter.BranchID)
+	return db.deleteRange(prefix.int(*filter.MinNodeID), prefix.next(), 0)
+}
+
+// InsertIntoHistoryTree inserts a row into history_tree table
+func (db *DB) InsertIntoHistoryTree(row *sqldb.HistoryTreeRow) (sql.Result, error) {
+	return db.insertRows(1, func(int) (key, interface{}) {
+		return historyTreeKey(row.ShardID, row.TreeID).bytes(row.BranchID), row
+	})
+}
+
+// SelectFromHistoryTree reads one or more rows from history_tree table
+func (db *DB) SelectFromHistoryTree(filter *sqldb.HistoryTreeFilter) ([]sqldb.HistoryTreeRow, error) {
+	prefix := historyTreeKey(filter.ShardID, filter.TreeID)
+	kvs, err := db.getRange(prefix, prefix.next(), 0)
+	if err != nil {
+		return nil, err
+	}
+	rows := make([]sqldb.HistoryTreeRow, len(kvs))
+	if err := decodeRows(kvs, func(i int) interface{} { return &rows[i] }); err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+// UpdateHistoryTree updates in_progress of a row in history_tree table
+func (db *DB) UpdateHistoryTree(row *sqldb.HistoryTreeRow) (sql.Result, error) {
+	var res result
+	err := db.execute(func(txn Txn) error {
+		k := historyTreeKey(row.ShardID, row.TreeID).bytes(row.BranchID)
+		var current sqldb.HistoryTreeRow
+		found, err := getRow(txn, k, &current)
+		if err != nil || !found {
+			return err
+		}
+		current.InProgress = row.InProgress
+		res = 1
+		return setRow(txn, k, &current)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// DeleteFromHistoryTree deletes one or more rows from history_tree table
+func (db *DB) DeleteFromHistoryTree(filter *sqldb.HistoryTreeFilter) (sql.Result, error) {
+	return db.delete(historyTreeKey(filter.ShardID, filter.TreeID).bytes(*filter.BranchID))
+}
@@ -0,0 +1,70 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package kv
+
+import "errors"
+
+type (
+	// KeyValue is a single entry of an ordered key-value store
+	KeyValue struct {
+		Key   []byte
+		Value []byte
+	}
+
+	// Txn is a transaction on an ordered key-value store. Transactions must be serializable:
+	// the keys and ranges read by a transaction are expected to conflict with the writes
+	// committed by concurrent transactions, this is how the row locks of the sql stores
+	// are honored by the adapter
+	Txn interface {
+		// Get returns the value of key, or nil when the key does not exist
+		Get(key []byte) ([]byte, error)
+		// Set writes the value of key, overwriting any previous value
+		Set(key []byte, value []byte) error
+		// Clear removes key, it does nothing when the key does not exist
+		Clear(key []byte) error
+		// GetRange returns the entries with begin <= key < end ordered by key, at most
+		// limit of them unless limit is 0
+		GetRange(begin []byte, end []byte, limit int) ([]KeyValue, error)
+		// Commit commits the transaction, it returns ErrConflict when the transaction
+		// was aborted by a conflicting one and can be retried as a whole
+		Commit() error
+		// Rollback discards the writes of the transaction
+		Rollback() error
+	}
+
+	// Store is a transactional ordered key-value store, e.g. FoundationDB or TiKV.
+	// Implementing this small set of primitives is enough to run all the sql stores
+	// on top of it: a store is plugged in by registering a custom datastore factory
+	// returning sql.NewFactoryFromDB over NewDB, as the embedded memory store does
+	Store interface {
+		// Begin starts a new transaction
+		Begin() (Txn, error)
+		// Close closes the connection to the store
+		Close() error
+	}
+)
+
+var (
+	// ErrConflict is returned by a store when a transaction was aborted by a conflicting one
+	ErrConflict = errors.New("kv: transaction conflict")
+	// ErrDupEntry is returned when inserting a row whose primary key already exists
+	ErrDupEntry = errors.New("kv: duplicate entry")
+)
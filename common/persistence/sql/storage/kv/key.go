@@ -0,0 +1,85 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package kv
+
+import (
+	"encoding/binary"
+	"time"
+)
+
+// key is an order preserving encoding of the primary key columns of a row: comparing two
+// encoded keys byte by byte gives the same result as comparing their columns one by one.
+// The first column is always the name of the table the row belongs to
+type key []byte
+
+const (
+	// strings are terminated by terminator0 terminator1, a zero byte within a string is escaped
+	// as terminator0 escaped so that it sorts after the end of the string
+	terminator0 = 0x00
+	terminator1 = 0x01
+	escaped     = 0xff
+)
+
+func newKey(table string) key {
+	return key(nil).str(table)
+}
+
+// int appends an int64 column, big endian with the sign bit flipped so negatives sort first
+func (k key) int(v int64) key {
+	var b [8]byte
+	binary.BigEndian.PutUint64(b[:], uint64(v)^(1<<63))
+	return append(k[:len(k):len(k)], b[:]...)
+}
+
+func (k key) str(s string) key {
+	return k.bytes([]byte(s))
+}
+
+func (k key) bytes(b []byte) key {
+	out := make(key, len(k), len(k)+len(b)+2)
+	copy(out, k)
+	for _, c := range b {
+		out = append(out, c)
+		if c == terminator0 {
+			out = append(out, escaped)
+		}
+	}
+	return append(out, terminator0, terminator1)
+}
+
+func (k key) time(t time.Time) key {
+	return k.int(t.Unix()).int(int64(t.Nanosecond()))
+}
+
+// next returns the first key following all the keys prefixed by k: it is the exclusive end
+// of the range of keys prefixed by k, and the exclusive start of the keys greater than them
+func (k key) next() key {
+	for i := len(k) - 1; i >= 0; i-- {
+		if k[i] != 0xff {
+			out := make(key, i+1)
+			copy(out, k[:i+1])
+			out[i]++
+			return out
+		}
+	}
+	// only reached by keys made of 0xff bytes, which the encoding never produces
+	return append(k[:len(k):len(k)], 0xff)
+}
@@ -0,0 +1,83 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package memory
+
+import (
+	"sync"
+
+	"github.com/uber/cadence/common/log"
+	pfactory "github.com/uber/cadence/common/persistence/persistence-factory"
+	"github.com/uber/cadence/common/persistence/sql"
+	"github.com/uber/cadence/common/persistence/sql/storage/kv"
+	"github.com/uber/cadence/common/persistence/sql/storage/sqldb"
+	"github.com/uber/cadence/common/service/config"
+)
+
+const (
+	// StoreName is the name the embedded store is registered under, it is the name of the
+	// custom datastore configs using the store
+	StoreName = "memory"
+	// DatabaseOption is the option of the custom datastore config naming the database of the datastore,
+	// the datastores of the process naming the same database share their data
+	DatabaseOption = "database"
+
+	defaultDatabase = "cadence"
+)
+
+var (
+	databasesLock sync.Mutex
+	databases     = make(map[string]*Store)
+)
+
+func init() {
+	pfactory.RegisterStoreFactory(StoreName, newDataStoreFactory)
+}
+
+// newDataStoreFactory returns the factory of the sql stores running their tables on the database of the config
+func newDataStoreFactory(cfg config.CustomDatastoreConfig, clusterName string, logger log.Logger) (pfactory.DataStoreFactory, error) {
+	name := cfg.Options[DatabaseOption]
+	if name == "" {
+		name = defaultDatabase
+	}
+	store := Database(name)
+	return sql.NewFactoryFromDB(func() (sqldb.Interface, error) {
+		return kv.NewDB(store, StoreName), nil
+	}, 1, clusterName, logger), nil
+}
+
+// Database returns the store of the named database, the database is created on first use
+func Database(name string) *Store {
+	databasesLock.Lock()
+	defer databasesLock.Unlock()
+	store, ok := databases[name]
+	if !ok {
+		store = NewStore()
+		databases[name] = store
+	}
+	return store
+}
+
+// DropDatabase drops the named database along with all its data
+func DropDatabase(name string) {
+	databasesLock.Lock()
+	defer databasesLock.Unlock()
+	delete(databases, name)
+}
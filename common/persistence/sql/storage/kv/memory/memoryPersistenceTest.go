@@ -0,0 +1,87 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package memory
+
+import (
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/service/config"
+	"github.com/uber/cadence/common/service/dynamicconfig"
+)
+
+// TestCluster allows executing the persistence tests against the embedded store
+type TestCluster struct {
+	dbName string
+}
+
+// NewTestCluster returns a new embedded store test cluster
+func NewTestCluster(dbName string) *TestCluster {
+	return &TestCluster{dbName: dbName}
+}
+
+// DatabaseName from PersistenceTestCluster interface
+func (s *TestCluster) DatabaseName() string {
+	return s.dbName
+}
+
+// SetupTestDatabase from PersistenceTestCluster interface
+func (s *TestCluster) SetupTestDatabase() {
+	s.CreateSession()
+}
+
+// Config returns the persistence config for connecting to this test cluster
+func (s *TestCluster) Config() config.Persistence {
+	return config.Persistence{
+		DefaultStore:    "test",
+		VisibilityStore: "test",
+		DataStores: map[string]config.DataStore{
+			"test": {CustomDataStoreConfig: &config.CustomDatastoreConfig{
+				Name:    StoreName,
+				Options: map[string]string{DatabaseOption: s.dbName},
+			}},
+		},
+		TransactionSizeLimit:               dynamicconfig.GetIntPropertyFn(common.DefaultTransactionSizeLimit),
+		ExecutionTransactionStatementLimit: dynamicconfig.GetIntPropertyFn(common.DefaultExecutionTransactionStatementLimit),
+		ExecutionTransactionSizeLimit:      dynamicconfig.GetIntPropertyFn(common.DefaultExecutionTransactionSizeLimit),
+	}
+}
+
+// TearDownTestDatabase from PersistenceTestCluster interface
+func (s *TestCluster) TearDownTestDatabase() {
+	s.DropDatabase()
+}
+
+// CreateSession from PersistenceTestCluster interface, it creates the database
+func (s *TestCluster) CreateSession() {
+	Database(s.dbName)
+}
+
+// DropDatabase from PersistenceTestCluster interface
+func (s *TestCluster) DropDatabase() {
+	DropDatabase(s.dbName)
+}
+
+// LoadSchema from PersistenceTestCluster interface, the embedded store has no schema
+func (s *TestCluster) LoadSchema(fileNames []string, schemaDir string) {
+}
+
+// LoadVisibilitySchema from PersistenceTestCluster interface, the embedded store has no schema
+func (s *TestCluster) LoadVisibilitySchema(fileNames []string, schemaDir string) {
+}
@@ -0,0 +1,281 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package memory
+
+import (
+	"errors"
+	"sort"
+	"sync"
+
+	"github.com/uber/cadence/common/persistence/sql/storage/kv"
+)
+
+type (
+	// Store is an ordered key-value store held in memory. Its transactions are serializable: they read the
+	// latest committed values, and their commit is aborted with kv.ErrConflict when a transaction committed
+	// since they began wrote any of the keys or ranges they read
+	Store struct {
+		sync.Mutex
+		// keys are the keys of values in order
+		keys   []string
+		values map[string][]byte
+		// seq is the sequence number of the last commit
+		seq int64
+		// commits are the commits that happened after the oldest open transaction began
+		commits []commit
+		open    map[*txn]struct{}
+	}
+
+	// commit is the set of keys written by a committed transaction
+	commit struct {
+		seq  int64
+		keys []string
+	}
+
+	txn struct {
+		store *Store
+		// seq is the sequence number of the last commit when the transaction began
+		seq int64
+		// writes are the values written by the transaction, nil for the cleared keys
+		writes map[string][]byte
+		reads  map[string]struct{}
+		ranges []keyRange
+		done   bool
+	}
+
+	// keyRange is the range of keys with begin <= key < end
+	keyRange struct {
+		begin string
+		end   string
+	}
+)
+
+var errTxnDone = errors.New("memory: transaction already committed or rolled back")
+
+var _ kv.Store = (*Store)(nil)
+
+// NewStore returns an empty Store
+func NewStore() *Store {
+	return &Store{
+		values: make(map[string][]byte),
+		open:   make(map[*txn]struct{}),
+	}
+}
+
+// Begin starts a new transaction
+func (s *Store) Begin() (kv.Txn, error) {
+	s.Lock()
+	defer s.Unlock()
+	t := &txn{
+		store:  s,
+		seq:    s.seq,
+		writes: make(map[string][]byte),
+		reads:  make(map[string]struct{}),
+	}
+	s.open[t] = struct{}{}
+	return t, nil
+}
+
+// Close does nothing, the data of the store outlives the connections to it
+func (s *Store) Close() error {
+	return nil
+}
+
+// Get returns the value of key, or nil when the key does not exist
+func (t *txn) Get(key []byte) ([]byte, error) {
+	if t.done {
+		return nil, errTxnDone
+	}
+	k := string(key)
+	if value, ok := t.writes[k]; ok {
+		return value, nil
+	}
+	t.reads[k] = struct{}{}
+	t.store.Lock()
+	defer t.store.Unlock()
+	return t.store.values[k], nil
+}
+
+// Set writes the value of key, overwriting any previous value
+func (t *txn) Set(key []byte, value []byte) error {
+	if t.done {
+		return errTxnDone
+	}
+	// the copy is never nil, which would stand for a cleared key
+	v := make([]byte, len(value))
+	copy(v, value)
+	t.writes[string(key)] = v
+	return nil
+}
+
+// Clear removes key, it does nothing when the key does not exist
+func (t *txn) Clear(key []byte) error {
+	if t.done {
+		return errTxnDone
+	}
+	t.writes[string(key)] = nil
+	return nil
+}
+
+// GetRange returns the entries with begin <= key < end ordered by key, at most limit of them unless
+// limit is 0. The committed entries are merged with the writes of the transaction
+func (t *txn) GetRange(begin []byte, end []byte, limit int) ([]kv.KeyValue, error) {
+	if t.done {
+		return nil, errTxnDone
+	}
+	b, e := string(begin), string(end)
+	var writes []string
+	for k := range t.writes {
+		if k >= b && k < e {
+			writes = append(writes, k)
+		}
+	}
+	sort.Strings(writes)
+
+	t.store.Lock()
+	defer t.store.Unlock()
+	var kvs []kv.KeyValue
+	i, j := sort.SearchStrings(t.store.keys, b), 0
+	for limit == 0 || len(kvs) < limit {
+		committed := i < len(t.store.keys) && t.store.keys[i] < e
+		written := j < len(writes)
+		var k string
+		var value []byte
+		switch {
+		case committed && written && t.store.keys[i] == writes[j]:
+			k, value = writes[j], t.writes[writes[j]]
+			i++
+			j++
+		case committed && (!written || t.store.keys[i] < writes[j]):
+			k, value = t.store.keys[i], t.store.values[t.store.keys[i]]
+			i++
+		case written:
+			k, value = writes[j], t.writes[writes[j]]
+			j++
+		default:
+			t.ranges = append(t.ranges, keyRange{begin: b, end: e})
+			return kvs, nil
+		}
+		if value != nil {
+			kvs = append(kvs, kv.KeyValue{Key: []byte(k), Value: value})
+		}
+	}
+	// only the keys up to the last one returned were read
+	t.ranges = append(t.ranges, keyRange{begin: b, end: string(kvs[len(kvs)-1].Key) + "\x00"})
+	return kvs, nil
+}
+
+// Commit commits the transaction, it returns kv.ErrConflict when a transaction committed since the
+// transaction began wrote any of the keys or ranges it read
+func (t *txn) Commit() error {
+	s := t.store
+	s.Lock()
+	defer s.Unlock()
+	if t.done {
+		return errTxnDone
+	}
+	conflict := false
+	for _, c := range s.commits {
+		if c.seq > t.seq && t.readAny(c.keys) {
+			conflict = true
+			break
+		}
+	}
+	s.close(t)
+	if conflict {
+		return kv.ErrConflict
+	}
+	if len(t.writes) == 0 {
+		return nil
+	}
+	s.seq++
+	c := commit{seq: s.seq, keys: make([]string, 0, len(t.writes))}
+	for k, value := range t.writes {
+		s.write(k, value)
+		c.keys = append(c.keys, k)
+	}
+	if len(s.open) > 0 {
+		s.commits = append(s.commits, c)
+	}
+	return nil
+}
+
+// Rollback discards the writes of the transaction
+func (t *txn) Rollback() error {
+	t.store.Lock()
+	defer t.store.Unlock()
+	if t.done {
+		return errTxnDone
+	}
+	t.store.close(t)
+	return nil
+}
+
+// readAny reports whether the transaction read any of the keys
+func (t *txn) readAny(keys []string) bool {
+	for _, k := range keys {
+		if _, ok := t.reads[k]; ok {
+			return true
+		}
+		for _, r := range t.ranges {
+			if k >= r.begin && k < r.end {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// close ends the transaction t and forgets the commits no open transaction can conflict with anymore
+func (s *Store) close(t *txn) {
+	t.done = true
+	delete(s.open, t)
+
+	oldest := s.seq
+	for o := range s.open {
+		if o.seq < oldest {
+			oldest = o.seq
+		}
+	}
+	n := 0
+	for n < len(s.commits) && s.commits[n].seq <= oldest {
+		n++
+	}
+	s.commits = s.commits[n:]
+}
+
+// write sets the committed value of k, or removes k when value is nil
+func (s *Store) write(k string, value []byte) {
+	i := sort.SearchStrings(s.keys, k)
+	exists := i < len(s.keys) && s.keys[i] == k
+	switch {
+	case value == nil && exists:
+		s.keys = append(s.keys[:i], s.keys[i+1:]...)
+		delete(s.values, k)
+	case value != nil && !exists:
+		s.keys = append(s.keys, "")
+		copy(s.keys[i+1:], s.keys[i:])
+		s.keys[i] = k
+		fallthrough
+	case value != nil:
+		s.values[k] = value
+	}
+}
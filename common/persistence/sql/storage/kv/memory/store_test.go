@@ -0,0 +1,179 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package memory
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"github.com/uber/cadence/common/persistence/sql/storage/kv"
+)
+
+type storeSuite struct {
+	suite.Suite
+	*require.Assertions
+	store *Store
+}
+
+func TestStoreSuite(t *testing.T) {
+	suite.Run(t, new(storeSuite))
+}
+
+func (s *storeSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+	s.store = NewStore()
+}
+
+func (s *storeSuite) TestGetRange() {
+	s.commit(map[string]string{"a": "1", "b": "2", "c": "3", "d": "4"})
+
+	txn := s.begin()
+	s.NoError(txn.Clear([]byte("b")))
+	s.NoError(txn.Set([]byte("bb"), []byte("5")))
+	s.NoError(txn.Set([]byte("c"), []byte("6")))
+	value, err := txn.Get([]byte("c"))
+	s.NoError(err)
+	s.Equal([]byte("6"), value)
+
+	// the writes of the transaction are merged with the committed values
+	kvs, err := txn.GetRange([]byte("a"), []byte("d"), 0)
+	s.NoError(err)
+	s.Equal([]string{"a=1", "bb=5", "c=6"}, entries(kvs))
+	kvs, err = txn.GetRange([]byte("b"), []byte("z"), 2)
+	s.NoError(err)
+	s.Equal([]string{"bb=5", "c=6"}, entries(kvs))
+	s.NoError(txn.Commit())
+
+	txn = s.begin()
+	kvs, err = txn.GetRange([]byte("a"), []byte("z"), 0)
+	s.NoError(err)
+	s.Equal([]string{"a=1", "bb=5", "c=6", "d=4"}, entries(kvs))
+	s.NoError(txn.Rollback())
+}
+
+func (s *storeSuite) TestRollback() {
+	txn := s.begin()
+	s.NoError(txn.Set([]byte("a"), []byte("1")))
+	s.NoError(txn.Rollback())
+	s.Error(txn.Commit())
+
+	txn = s.begin()
+	value, err := txn.Get([]byte("a"))
+	s.NoError(err)
+	s.Nil(value)
+	s.NoError(txn.Commit())
+}
+
+func (s *storeSuite) TestEmptyValue() {
+	s.commit(map[string]string{"a": ""})
+	txn := s.begin()
+	value, err := txn.Get([]byte("a"))
+	s.NoError(err)
+	s.NotNil(value)
+	s.Empty(value)
+	s.NoError(txn.Commit())
+}
+
+func (s *storeSuite) TestConflictOnKeyRead() {
+	s.commit(map[string]string{"a": "1"})
+
+	txn1, txn2 := s.begin(), s.begin()
+	_, err := txn1.Get([]byte("a"))
+	s.NoError(err)
+	_, err = txn2.Get([]byte("a"))
+	s.NoError(err)
+	s.NoError(txn1.Set([]byte("a"), []byte("2")))
+	s.NoError(txn2.Set([]byte("a"), []byte("3")))
+	s.NoError(txn1.Commit())
+	s.Equal(kv.ErrConflict, txn2.Commit())
+
+	txn := s.begin()
+	value, err := txn.Get([]byte("a"))
+	s.NoError(err)
+	s.Equal([]byte("2"), value)
+	s.NoError(txn.Commit())
+}
+
+func (s *storeSuite) TestConflictOnRangeRead() {
+	s.commit(map[string]string{"a": "1", "c": "3"})
+
+	txn1, txn2, txn3 := s.begin(), s.begin(), s.begin()
+	_, err := txn1.GetRange([]byte("a"), []byte("d"), 0)
+	s.NoError(err)
+	// only the keys up to the last one returned are read
+	_, err = txn2.GetRange([]byte("a"), []byte("d"), 1)
+	s.NoError(err)
+	s.NoError(txn1.Set([]byte("x"), []byte("x")))
+	s.NoError(txn2.Set([]byte("y"), []byte("y")))
+
+	s.NoError(txn3.Set([]byte("b"), []byte("2")))
+	s.NoError(txn3.Commit())
+	s.Equal(kv.ErrConflict, txn1.Commit())
+	s.NoError(txn2.Commit())
+}
+
+func (s *storeSuite) TestNoConflictAfterBegin() {
+	s.commit(map[string]string{"a": "1"})
+	txn1 := s.begin()
+	s.commit(map[string]string{"a": "2"})
+
+	// the commits before a transaction began do not conflict with it
+	txn2 := s.begin()
+	_, err := txn2.Get([]byte("a"))
+	s.NoError(err)
+	s.NoError(txn2.Set([]byte("a"), []byte("3")))
+	s.NoError(txn2.Commit())
+	s.NoError(txn1.Commit())
+	s.Empty(s.store.commits)
+}
+
+func (s *storeSuite) TestDatabase() {
+	db := Database("test")
+	s.True(db == Database("test"))
+	s.False(db == Database("other"))
+	DropDatabase("test")
+	s.False(db == Database("test"))
+	DropDatabase("test")
+	DropDatabase("other")
+}
+
+func (s *storeSuite) begin() kv.Txn {
+	txn, err := s.store.Begin()
+	s.NoError(err)
+	return txn
+}
+
+func (s *storeSuite) commit(values map[string]string) {
+	txn := s.begin()
+	for k, v := range values {
+		s.NoError(txn.Set([]byte(k), []byte(v)))
+	}
+	s.NoError(txn.Commit())
+}
+
+func entries(kvs []kv.KeyValue) []string {
+	var out []string
+	for _, entry := range kvs {
+		out = append(out, string(entry.Key)+"="+string(entry.Value))
+	}
+	return out
+}
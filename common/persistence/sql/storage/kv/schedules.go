@@ -0,0 +1,92 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package kv
+
+import (
+	"database/sql"
+
+	"github.com/uber/cadence/common/persistence/sql/storage/sqldb"
+)
+
+const schedulesTable = "schedules"
+
+func schedulesKey(domainID sqldb.UUID) key {
+	return newKey(schedulesTable).bytes(domainID)
+}
+
+// InsertIntoSchedules inserts a row into schedules table
+func (db *DB) InsertIntoSchedules(row *sqldb.SchedulesRow) (sql.Result, error) {
+	return db.insertRows(1, func(int) (key, interface{}) {
+		return schedulesKey(row.DomainID).str(row.ScheduleID), row
+	})
+}
+
+// UpdateSchedules updates a row in schedules table if its version is previousVersion
+func (db *DB) UpdateSchedules(row *sqldb.SchedulesRow, previousVersion int64) (sql.Result, error) {
+	var res result
+	err := db.execute(func(txn Txn) error {
+		k := schedulesKey(row.DomainID).str(row.ScheduleID)
+		var current sqldb.SchedulesRow
+		found, err := getRow(txn, k, &current)
+		if err != nil || !found || current.Version != previousVersion {
+			return err
+		}
+		res = 1
+		return setRow(txn, k, row)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// SelectFromSchedules reads a single row from schedules table
+func (db *DB) SelectFromSchedules(filter *sqldb.SchedulesFilter) (*sqldb.SchedulesRow, error) {
+	var row sqldb.SchedulesRow
+	if err := db.get(schedulesKey(filter.DomainID).str(filter.ScheduleID), &row); err != nil {
+		return nil, err
+	}
+	return &row, nil
+}
+
+// RangeSelectFromSchedules reads one or more rows from schedules table
+func (db *DB) RangeSelectFromSchedules(filter *sqldb.SchedulesFilter) ([]sqldb.SchedulesRow, error) {
+	begin := schedulesKey(filter.MinDomainID).str(filter.MinScheduleID).next()
+	end := newKey(schedulesTable).next()
+	if filter.DomainID != nil {
+		begin = schedulesKey(filter.DomainID).str(filter.MinScheduleID).next()
+		end = schedulesKey(filter.DomainID).next()
+	}
+	kvs, err := db.getRange(begin, end, *filter.PageSize)
+	if err != nil {
+		return nil, err
+	}
+	rows := make([]sqldb.SchedulesRow, len(kvs))
+	if err := decodeRows(kvs, func(i int) interface{} { return &rows[i] }); err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+// DeleteFromSchedules deletes a single row from schedules table
+func (db *DB) DeleteFromSchedules(filter *sqldb.SchedulesFilter) (sql.Result, error) {
+	return db.delete(schedulesKey(filter.DomainID).str(filter.ScheduleID))
+}
@@ -0,0 +1,69 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package kv
+
+import (
+	"database/sql"
+
+	"github.com/uber/cadence/common/persistence/sql/storage/sqldb"
+)
+
+const shardsTable = "shards"
+
+func shardsKey(shardID int64) key {
+	return newKey(shardsTable).int(shardID)
+}
+
+// InsertIntoShards inserts one or more rows into shards table
+func (db *DB) InsertIntoShards(row *sqldb.ShardsRow) (sql.Result, error) {
+	return db.insertRows(1, func(int) (key, interface{}) {
+		return shardsKey(row.ShardID), row
+	})
+}
+
+// UpdateShards updates one or more rows into shards table
+func (db *DB) UpdateShards(row *sqldb.ShardsRow) (sql.Result, error) {
+	return db.update(shardsKey(row.ShardID), row)
+}
+
+// SelectFromShards reads one or more rows from shards table
+func (db *DB) SelectFromShards(filter *sqldb.ShardsFilter) (*sqldb.ShardsRow, error) {
+	var row sqldb.ShardsRow
+	if err := db.get(shardsKey(filter.ShardID), &row); err != nil {
+		return nil, err
+	}
+	return &row, nil
+}
+
+// ReadLockShards acquires a read lock on a single row in shards table
+func (db *DB) ReadLockShards(filter *sqldb.ShardsFilter) (int, error) {
+	row, err := db.SelectFromShards(filter)
+	if err != nil {
+		return 0, err
+	}
+	return int(row.RangeID), nil
+}
+
+// WriteLockShards acquires a write lock on a single row in shards table. Reading the row is
+// enough since a serializable transaction is aborted by any concurrent write of the rows it read
+func (db *DB) WriteLockShards(filter *sqldb.ShardsFilter) (int, error) {
+	return db.ReadLockShards(filter)
+}
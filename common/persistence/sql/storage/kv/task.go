@@ -0,0 +1,172 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package kv
+
+import (
+	"bytes"
+	"database/sql"
+	"fmt"
+
+	"github.com/uber/cadence/common/persistence/sql/storage/sqldb"
+)
+
+const (
+	tasksTable     = "tasks"
+	taskListsTable = "task_lists"
+)
+
+func tasksKey(domainID sqldb.UUID, taskListName string, taskType int64) key {
+	return newKey(tasksTable).bytes(domainID).str(taskListName).int(taskType)
+}
+
+func taskListsKey(shardID int, domainID sqldb.UUID, name string, taskType int64) key {
+	return newKey(taskListsTable).int(int64(shardID)).bytes(domainID).str(name).int(taskType)
+}
+
+// InsertIntoTasks inserts one or more rows into tasks table
+func (db *DB) InsertIntoTasks(rows []sqldb.TasksRow) (sql.Result, error) {
+	return db.insertRows(len(rows), func(i int) (key, interface{}) {
+		return tasksKey(rows[i].DomainID, rows[i].TaskListName, rows[i].TaskType).int(rows[i].TaskID), &rows[i]
+	})
+}
+
+// SelectFromTasks reads one or more rows from tasks table
+func (db *DB) SelectFromTasks(filter *sqldb.TasksFilter) ([]sqldb.TasksRow, error) {
+	prefix := tasksKey(filter.DomainID, filter.TaskListName, filter.TaskType)
+	end := prefix.next()
+	if filter.MaxTaskID != nil {
+		end = prefix.int(*filter.MaxTaskID).next()
+	}
+	kvs, err := db.getRange(prefix.int(*filter.MinTaskID).next(), end, pageSize(filter.PageSize))
+	if err != nil {
+		return nil, err
+	}
+	rows := make([]sqldb.TasksRow, len(kvs))
+	if err := decodeRows(kvs, func(i int) interface{} { return &rows[i] }); err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+// DeleteFromTasks deletes one or more rows from tasks table
+func (db *DB) DeleteFromTasks(filter *sqldb.TasksFilter) (sql.Result, error) {
+	prefix := tasksKey(filter.DomainID, filter.TaskListName, filter.TaskType)
+	if filter.TaskIDLessThanEquals != nil {
+		if filter.Limit == nil || *filter.Limit == 0 {
+			return nil, fmt.Errorf("missing limit parameter")
+		}
+		return db.deleteRange(prefix, prefix.int(*filter.TaskIDLessThanEquals).next(), *filter.Limit)
+	}
+	return db.delete(prefix.int(*filter.TaskID))
+}
+
+// InsertIntoTaskLists inserts one or more rows into task_lists table
+func (db *DB) InsertIntoTaskLists(row *sqldb.TaskListsRow) (sql.Result, error) {
+	return db.insertRows(1, func(int) (key, interface{}) {
+		return taskListsKey(row.ShardID, row.DomainID, row.Name, row.TaskType), row
+	})
+}
+
+// ReplaceIntoTaskLists replaces one or more rows in task_lists table
+func (db *DB) ReplaceIntoTaskLists(row *sqldb.TaskListsRow) (sql.Result, error) {
+	return db.replaceRows(1, func(int) (key, interface{}) {
+		return taskListsKey(row.ShardID, row.DomainID, row.Name, row.TaskType), row
+	})
+}
+
+// UpdateTaskLists updates a row in task_lists table
+func (db *DB) UpdateTaskLists(row *sqldb.TaskListsRow) (sql.Result, error) {
+	return db.update(taskListsKey(row.ShardID, row.DomainID, row.Name, row.TaskType), row)
+}
+
+// SelectFromTaskLists reads one or more rows from task_lists table
+func (db *DB) SelectFromTaskLists(filter *sqldb.TaskListsFilter) ([]sqldb.TaskListsRow, error) {
+	switch {
+	case filter.DomainID != nil && filter.Name != nil && filter.TaskType != nil:
+		return db.selectFromTaskLists(filter)
+	case filter.DomainIDGreaterThan != nil && filter.NameGreaterThan != nil && filter.TaskTypeGreaterThan != nil && filter.PageSize != nil:
+		return db.rangeSelectFromTaskLists(filter)
+	default:
+		return nil, fmt.Errorf("invalid set of query filter params")
+	}
+}
+
+func (db *DB) selectFromTaskLists(filter *sqldb.TaskListsFilter) ([]sqldb.TaskListsRow, error) {
+	var row sqldb.TaskListsRow
+	if err := db.get(taskListsKey(filter.ShardID, *filter.DomainID, *filter.Name, *filter.TaskType), &row); err != nil {
+		return nil, err
+	}
+	return []sqldb.TaskListsRow{row}, nil
+}
+
+// rangeSelectFromTaskLists returns the rows of a shard whose domain ID, name and task type are all
+// greater than the ones of the filter, like the sql query it compares each column on its own
+func (db *DB) rangeSelectFromTaskLists(filter *sqldb.TaskListsFilter) ([]sqldb.TaskListsRow, error) {
+	prefix := newKey(taskListsTable).int(int64(filter.ShardID))
+	kvs, err := db.getRange(prefix.bytes(*filter.DomainIDGreaterThan).next(), prefix.next(), 0)
+	if err != nil {
+		return nil, err
+	}
+	var rows []sqldb.TaskListsRow
+	for _, kv := range kvs {
+		var row sqldb.TaskListsRow
+		if err := decodeRow(kv.Value, &row); err != nil {
+			return nil, err
+		}
+		if bytes.Compare(row.DomainID, *filter.DomainIDGreaterThan) <= 0 ||
+			row.Name <= *filter.NameGreaterThan || row.TaskType <= *filter.TaskTypeGreaterThan {
+			continue
+		}
+		rows = append(rows, row)
+		if len(rows) == *filter.PageSize {
+			break
+		}
+	}
+	return rows, nil
+}
+
+// DeleteFromTaskLists deletes a row from task_lists table
+func (db *DB) DeleteFromTaskLists(filter *sqldb.TaskListsFilter) (sql.Result, error) {
+	var res result
+	err := db.execute(func(txn Txn) error {
+		k := taskListsKey(filter.ShardID, *filter.DomainID, *filter.Name, *filter.TaskType)
+		var row sqldb.TaskListsRow
+		found, err := getRow(txn, k, &row)
+		if err != nil || !found || row.RangeID != *filter.RangeID {
+			return err
+		}
+		res, err = clearRow(txn, k)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// LockTaskLists locks a row in task_lists table
+func (db *DB) LockTaskLists(filter *sqldb.TaskListsFilter) (int64, error) {
+	rows, err := db.selectFromTaskLists(filter)
+	if err != nil {
+		return 0, err
+	}
+	return rows[0].RangeID, nil
+}
@@ -0,0 +1,184 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package kv
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/uber/cadence/common/persistence/sql/storage/sqldb"
+)
+
+const (
+	visibilityTable            = "executions_visibility"
+	visibilityByStartTimeTable = "executions_visibility_by_start_time"
+)
+
+var errCloseParams = errors.New("missing one of {closeStatus, closeTime, historyLength} params")
+
+func visibilityKey(domainID string, runID string) key {
+	return newKey(visibilityTable).str(domainID).str(runID)
+}
+
+// visibilityByStartTimeKey is the key of the index of the visibility rows of a domain ordered by descending
+// start time and then by run ID, the value of the index is the row itself
+func visibilityByStartTimeKey(domainID string, startTime time.Time) key {
+	return newKey(visibilityByStartTimeTable).str(domainID).int(-startTime.UnixNano())
+}
+
+// InsertIntoVisibility inserts a row into visibility table. If an row already exist,
+// its left as such and no update will be made
+func (db *DB) InsertIntoVisibility(row *sqldb.VisibilityRow) (sql.Result, error) {
+	var res result
+	err := db.execute(func(txn Txn) error {
+		value, err := txn.Get(visibilityKey(row.DomainID, row.RunID))
+		if err != nil || value != nil {
+			return err
+		}
+		res = 1
+		return setVisibilityRow(txn, row)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// ReplaceIntoVisibility replaces an existing row if it exist or creates a new row in visibility table
+func (db *DB) ReplaceIntoVisibility(row *sqldb.VisibilityRow) (sql.Result, error) {
+	if row.CloseStatus == nil || row.CloseTime == nil || row.HistoryLength == nil {
+		return nil, errCloseParams
+	}
+	err := db.execute(func(txn Txn) error {
+		if _, err := clearVisibilityRow(txn, row.DomainID, row.RunID); err != nil {
+			return err
+		}
+		return setVisibilityRow(txn, row)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result(1), nil
+}
+
+// DeleteFromVisibility deletes a row from visibility table if it exist
+func (db *DB) DeleteFromVisibility(filter *sqldb.VisibilityFilter) (sql.Result, error) {
+	var res result
+	err := db.execute(func(txn Txn) (err error) {
+		res, err = clearVisibilityRow(txn, filter.DomainID, *filter.RunID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// SelectFromVisibility reads one or more rows from visibility table
+func (db *DB) SelectFromVisibility(filter *sqldb.VisibilityFilter) ([]sqldb.VisibilityRow, error) {
+	switch {
+	case filter.MinStartTime == nil && filter.RunID != nil && filter.Closed:
+		var row sqldb.VisibilityRow
+		if err := db.get(visibilityKey(filter.DomainID, *filter.RunID), &row); err != nil {
+			return nil, err
+		}
+		if row.CloseStatus == nil {
+			return nil, sql.ErrNoRows
+		}
+		return []sqldb.VisibilityRow{row}, nil
+	case filter.MinStartTime != nil:
+		return db.rangeSelectFromVisibility(filter)
+	default:
+		return nil, errors.New("invalid query filter")
+	}
+}
+
+// rangeSelectFromVisibility reads the rows of a domain started between the min and max start time of filter,
+// ordered by descending start time and run ID. The rows are read past the page token made of the max start
+// time and the run ID, and the rows not matching the optional criteria of filter are skipped
+func (db *DB) rangeSelectFromVisibility(filter *sqldb.VisibilityFilter) ([]sqldb.VisibilityRow, error) {
+	var rows []sqldb.VisibilityRow
+	err := db.execute(func(txn Txn) error {
+		rows = nil
+		begin := visibilityByStartTimeKey(filter.DomainID, *filter.MaxStartTime).str(*filter.RunID).next()
+		end := visibilityByStartTimeKey(filter.DomainID, *filter.MinStartTime).next()
+		for len(rows) < *filter.PageSize {
+			kvs, err := txn.GetRange(begin, end, *filter.PageSize)
+			if err != nil || len(kvs) == 0 {
+				return err
+			}
+			for _, kv := range kvs {
+				var row sqldb.VisibilityRow
+				if err := decodeRow(kv.Value, &row); err != nil {
+					return err
+				}
+				if matchVisibilityFilter(&row, filter) && len(rows) < *filter.PageSize {
+					rows = append(rows, row)
+				}
+			}
+			begin = key(kvs[len(kvs)-1].Key).next()
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+func matchVisibilityFilter(row *sqldb.VisibilityRow, filter *sqldb.VisibilityFilter) bool {
+	switch {
+	case (row.CloseStatus != nil) != filter.Closed:
+		return false
+	case filter.WorkflowID != nil:
+		return row.WorkflowID == *filter.WorkflowID
+	case filter.WorkflowTypeName != nil:
+		return row.WorkflowTypeName == *filter.WorkflowTypeName
+	case filter.CloseStatus != nil:
+		return row.CloseStatus != nil && *row.CloseStatus == *filter.CloseStatus
+	default:
+		return true
+	}
+}
+
+// setVisibilityRow writes the row and its entry in the start time index
+func setVisibilityRow(txn Txn, row *sqldb.VisibilityRow) error {
+	if err := setRow(txn, visibilityKey(row.DomainID, row.RunID), row); err != nil {
+		return err
+	}
+	return setRow(txn, visibilityByStartTimeKey(row.DomainID, row.StartTime).str(row.RunID), row)
+}
+
+// clearVisibilityRow deletes the row of a run and its entry in the start time index, it returns
+// the number of rows deleted
+func clearVisibilityRow(txn Txn, domainID string, runID string) (result, error) {
+	k := visibilityKey(domainID, runID)
+	var row sqldb.VisibilityRow
+	found, err := getRow(txn, k, &row)
+	if err != nil || !found {
+		return 0, err
+	}
+	if err := txn.Clear(visibilityByStartTimeKey(domainID, row.StartTime).str(runID)); err != nil {
+		return 0, err
+	}
+	return 1, txn.Clear(k)
+}
@@ -0,0 +1,46 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package mysql
+
+import (
+	"database/sql"
+
+	"github.com/uber/cadence/common/persistence/sql/storage/sqldb"
+)
+
+const (
+	insertAuditLogQry = `INSERT INTO
+ audit_log (entry_id, timestamp, caller, caller_service, operation, domain, request_hash, error)
+ VALUES(?, ?, ?, ?, ?, ?, ?, ?)`
+)
+
+// InsertIntoAuditLog inserts a row into audit_log table
+func (mdb *DB) InsertIntoAuditLog(row *sqldb.AuditLogRow) (sql.Result, error) {
+	return mdb.conn.Exec(insertAuditLogQry,
+		row.EntryID,
+		mdb.converter.ToMySQLDateTime(row.Timestamp),
+		row.Caller,
+		row.CallerService,
+		row.Operation,
+		row.Domain,
+		row.RequestHash,
+		row.Error)
+}
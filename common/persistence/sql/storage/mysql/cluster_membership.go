@@ -0,0 +1,80 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package mysql
+
+import (
+	"database/sql"
+
+	"github.com/uber/cadence/common/persistence/sql/storage/sqldb"
+)
+
+const (
+	upsertClusterMembershipQry = `INSERT INTO
+ cluster_membership (role, rpc_address, session_start, last_heartbeat, record_expiry)
+ VALUES(?, ?, ?, ?, ?)
+ ON DUPLICATE KEY UPDATE
+ session_start = VALUES(session_start), last_heartbeat = VALUES(last_heartbeat), record_expiry = VALUES(record_expiry)`
+
+	getClusterMembersQry = `SELECT role, rpc_address, session_start, last_heartbeat, record_expiry
+ FROM cluster_membership WHERE last_heartbeat > ? AND record_expiry > ?`
+
+	getClusterMembersByRoleQry = getClusterMembersQry + ` AND role = ?`
+
+	pruneClusterMembershipQry = `DELETE FROM cluster_membership WHERE record_expiry < ? LIMIT ?`
+)
+
+// UpsertClusterMembership inserts or updates a row in cluster_membership table
+func (mdb *DB) UpsertClusterMembership(row *sqldb.ClusterMembershipRow) (sql.Result, error) {
+	return mdb.conn.Exec(upsertClusterMembershipQry,
+		row.Role,
+		row.RPCAddress,
+		mdb.converter.ToMySQLDateTime(row.SessionStart),
+		mdb.converter.ToMySQLDateTime(row.LastHeartbeat),
+		mdb.converter.ToMySQLDateTime(row.RecordExpiry))
+}
+
+// SelectFromClusterMembership reads one or more rows from cluster_membership table
+func (mdb *DB) SelectFromClusterMembership(filter *sqldb.ClusterMembershipFilter) ([]sqldb.ClusterMembershipRow, error) {
+	var rows []sqldb.ClusterMembershipRow
+	var err error
+	lastHeartbeatAfter := mdb.converter.ToMySQLDateTime(filter.LastHeartbeatAfter)
+	recordExpiryAfter := mdb.converter.ToMySQLDateTime(filter.RecordExpiryAfter)
+	if filter.Role != "" {
+		err = mdb.conn.Select(&rows, getClusterMembersByRoleQry, lastHeartbeatAfter, recordExpiryAfter, filter.Role)
+	} else {
+		err = mdb.conn.Select(&rows, getClusterMembersQry, lastHeartbeatAfter, recordExpiryAfter)
+	}
+	if err != nil {
+		return nil, err
+	}
+	for i := range rows {
+		rows[i].SessionStart = mdb.converter.FromMySQLDateTime(rows[i].SessionStart)
+		rows[i].LastHeartbeat = mdb.converter.FromMySQLDateTime(rows[i].LastHeartbeat)
+		rows[i].RecordExpiry = mdb.converter.FromMySQLDateTime(rows[i].RecordExpiry)
+	}
+	return rows, nil
+}
+
+// DeleteFromClusterMembership deletes expired rows from cluster_membership table
+func (mdb *DB) DeleteFromClusterMembership(filter *sqldb.ClusterMembershipFilter) (sql.Result, error) {
+	return mdb.conn.Exec(pruneClusterMembershipQry,
+		mdb.converter.ToMySQLDateTime(filter.RecordExpiryBefore), filter.MaxRecordsAffected)
+}
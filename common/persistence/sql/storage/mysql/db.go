@@ -39,13 +39,18 @@ var _ sqldb.Interface = (*DB)(nil)
 // NewDB returns an instance of DB, which is a logical
 // connection to the underlying mysql database
 func NewDB(xdb *sqlx.DB, tx *sqlx.Tx) *DB {
-	mdb := &DB{db: xdb, tx: tx}
-	mdb.conn = xdb
+	var conn sqldb.Conn = xdb
 	if tx != nil {
-		mdb.conn = tx
+		conn = tx
 	}
-	mdb.converter = &converter{}
-	return mdb
+	return NewDBWithConn(xdb, tx, conn)
+}
+
+// NewDBWithConn returns an instance of DB that sends its queries
+// through the given connection, which lets other dialects reuse
+// the mysql queries by translating them on the fly
+func NewDBWithConn(xdb *sqlx.DB, tx *sqlx.Tx, conn sqldb.Conn) *DB {
+	return &DB{db: xdb, tx: tx, conn: conn, converter: &converter{}}
 }
 
 // BeginTx starts a new transaction and returns a reference to the Tx object
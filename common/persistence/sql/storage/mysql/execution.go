@@ -39,6 +39,9 @@ const (
 	getExecutionQry = `SELECT ` + executionsColumns + ` FROM executions
  WHERE shard_id = ? AND domain_id = ? AND workflow_id = ? AND run_id = ?`
 
+	listExecutionRunsQry = `SELECT ` + executionsColumns + ` FROM executions
+ WHERE shard_id = ? AND domain_id = ? AND workflow_id = ? AND run_id > ? ORDER BY run_id LIMIT ?`
+
 	deleteExecutionQry = `DELETE FROM executions 
  WHERE shard_id = ? AND domain_id = ? AND workflow_id = ? AND run_id = ?`
 
@@ -55,11 +58,16 @@ const (
 	deleteCurrentExecutionQry = "DELETE FROM current_executions WHERE shard_id=? AND domain_id=? AND workflow_id=? AND run_id=?"
 
 	getCurrentExecutionQry = `SELECT
-shard_id, domain_id, workflow_id, run_id, create_request_id, state, close_status, start_version, last_write_version
+shard_id, domain_id, workflow_id, run_id, create_request_id, state, close_status, start_version, last_write_version, blocked_until
 FROM current_executions WHERE shard_id = ? AND domain_id = ? AND workflow_id = ?`
 
+	rangeGetCurrentExecutionsQry = `SELECT
+shard_id, domain_id, workflow_id, run_id, create_request_id, state, close_status, start_version, last_write_version
+FROM current_executions WHERE shard_id = ? AND (domain_id, workflow_id) > (?, ?)
+ORDER BY domain_id, workflow_id LIMIT ?`
+
 	lockCurrentExecutionJoinExecutionsQry = `SELECT
-ce.shard_id, ce.domain_id, ce.workflow_id, ce.run_id, ce.create_request_id, ce.state, ce.close_status, ce.start_version, e.last_write_version
+ce.shard_id, ce.domain_id, ce.workflow_id, ce.run_id, ce.create_request_id, ce.state, ce.close_status, ce.start_version, e.last_write_version, ce.blocked_until
 FROM current_executions ce
 INNER JOIN executions e ON e.shard_id = ce.shard_id AND e.domain_id = ce.domain_id AND e.workflow_id = ce.workflow_id AND e.run_id = ce.run_id
 WHERE ce.shard_id = ? AND ce.domain_id = ? AND ce.workflow_id = ? FOR UPDATE`
@@ -77,6 +85,15 @@ WHERE
 shard_id = :shard_id AND
 domain_id = :domain_id AND
 workflow_id = :workflow_id
+`
+
+	updateCurrentExecutionsBlockedUntilQry = `UPDATE current_executions SET
+blocked_until = :blocked_until
+WHERE
+shard_id = :shard_id AND
+domain_id = :domain_id AND
+workflow_id = :workflow_id AND
+run_id = :run_id
 `
 
 	getTransferTasksQry = `SELECT task_id, data, data_encoding 
@@ -88,6 +105,15 @@ workflow_id = :workflow_id
 	deleteTransferTaskQry      = `DELETE FROM transfer_tasks WHERE shard_id = ? AND task_id = ?`
 	rangeDeleteTransferTaskQry = `DELETE FROM transfer_tasks WHERE shard_id = ? AND task_id > ? AND task_id <= ?`
 
+	getVisibilityTasksQry = `SELECT task_id, data, data_encoding 
+ FROM visibility_tasks WHERE shard_id = ? AND task_id > ? AND task_id <= ?`
+
+	createVisibilityTasksQry = `INSERT INTO visibility_tasks(shard_id, task_id, data, data_encoding) 
+ VALUES(:shard_id, :task_id, :data, :data_encoding)`
+
+	deleteVisibilityTaskQry      = `DELETE FROM visibility_tasks WHERE shard_id = ? AND task_id = ?`
+	rangeDeleteVisibilityTaskQry = `DELETE FROM visibility_tasks WHERE shard_id = ? AND task_id > ? AND task_id <= ?`
+
 	createTimerTasksQry = `INSERT INTO timer_tasks (shard_id, visibility_timestamp, task_id, data, data_encoding)
   VALUES (:shard_id, :visibility_timestamp, :task_id, :data, :data_encoding)`
 
@@ -100,6 +126,18 @@ workflow_id = :workflow_id
 	deleteTimerTaskQry      = `DELETE FROM timer_tasks WHERE shard_id = ? AND visibility_timestamp = ? AND task_id = ?`
 	rangeDeleteTimerTaskQry = `DELETE FROM timer_tasks WHERE shard_id = ? AND visibility_timestamp >= ? AND visibility_timestamp < ?`
 
+	createClosedExecutionsIndexQry = `INSERT INTO closed_executions_index (shard_id, close_time, domain_id, workflow_id, run_id, version)
+  VALUES (:shard_id, :close_time, :domain_id, :workflow_id, :run_id, :version)`
+
+	getClosedExecutionsIndexQry = `SELECT close_time, domain_id, workflow_id, run_id, version FROM closed_executions_index
+  WHERE shard_id = ?
+  AND (close_time, domain_id, workflow_id, run_id) >= (?, ?, ?, ?)
+  AND close_time < ?
+  ORDER BY close_time, domain_id, workflow_id, run_id LIMIT ?`
+
+	deleteClosedExecutionsIndexQry = `DELETE FROM closed_executions_index
+  WHERE shard_id = ? AND close_time = ? AND domain_id = ? AND workflow_id = ? AND run_id = ?`
+
 	createReplicationTasksQry = `INSERT INTO replication_tasks (shard_id, task_id, data, data_encoding) 
   VALUES(:shard_id, :task_id, :data, :data_encoding)`
 
@@ -109,7 +147,8 @@ task_id > ? AND
 task_id <= ? 
 ORDER BY task_id LIMIT ?`
 
-	deleteReplicationTaskQry = `DELETE FROM replication_tasks WHERE shard_id = ? AND task_id = ?`
+	deleteReplicationTaskQry      = `DELETE FROM replication_tasks WHERE shard_id = ? AND task_id = ?`
+	rangeDeleteReplicationTaskQry = `DELETE FROM replication_tasks WHERE shard_id = ? AND task_id <= ?`
 
 	bufferedEventsColumns    = `shard_id, domain_id, workflow_id, run_id, data, data_encoding`
 	createBufferedEventsQury = `INSERT INTO buffered_events(` + bufferedEventsColumns + `)
@@ -118,6 +157,15 @@ VALUES (:shard_id, :domain_id, :workflow_id, :run_id, :data, :data_encoding)`
 	deleteBufferedEventsQury = `DELETE FROM buffered_events WHERE shard_id=? AND domain_id=? AND workflow_id=? AND run_id=?`
 	getBufferedEventsQury    = `SELECT data, data_encoding FROM buffered_events WHERE
 shard_id=? AND domain_id=? AND workflow_id=? AND run_id=?`
+
+	getBufferedReplicationTaskCountsQry = `SELECT domain_id, workflow_id, run_id, COUNT(*) AS task_count FROM buffered_replication_task_maps
+  WHERE shard_id = ?
+  AND (domain_id, workflow_id, run_id) > (?, ?, ?)
+  GROUP BY domain_id, workflow_id, run_id
+  ORDER BY domain_id, workflow_id, run_id LIMIT ?`
+
+	deleteBufferedReplicationTaskMapsQry = `DELETE FROM buffered_replication_task_maps
+  WHERE shard_id = ? AND domain_id = ? AND workflow_id = ? AND run_id = ?`
 )
 
 // InsertIntoExecutions inserts a row into executions table
@@ -140,6 +188,13 @@ func (mdb *DB) SelectFromExecutions(filter *sqldb.ExecutionsFilter) (*sqldb.Exec
 	return &row, err
 }
 
+// SelectRunsFromExecutions reads the rows of a workflow ID from executions table
+func (mdb *DB) SelectRunsFromExecutions(filter *sqldb.ExecutionsFilter) ([]sqldb.ExecutionsRow, error) {
+	var rows []sqldb.ExecutionsRow
+	err := mdb.conn.Select(&rows, listExecutionRunsQry, filter.ShardID, filter.DomainID, filter.WorkflowID, filter.RunID, *filter.PageSize)
+	return rows, err
+}
+
 // DeleteFromExecutions deletes a single row from executions table
 func (mdb *DB) DeleteFromExecutions(filter *sqldb.ExecutionsFilter) (sql.Result, error) {
 	return mdb.conn.Exec(deleteExecutionQry, filter.ShardID, filter.DomainID, filter.WorkflowID, filter.RunID)
@@ -169,13 +224,29 @@ func (mdb *DB) UpdateCurrentExecutions(row *sqldb.CurrentExecutionsRow) (sql.Res
 	return mdb.conn.NamedExec(updateCurrentExecutionsQry, row)
 }
 
+// UpdateCurrentExecutionsBlockedUntil updates blocked_until of a single row in current_executions table
+func (mdb *DB) UpdateCurrentExecutionsBlockedUntil(row *sqldb.CurrentExecutionsRow) (sql.Result, error) {
+	row.BlockedUntil = mdb.converter.ToMySQLDateTime(row.BlockedUntil)
+	return mdb.conn.NamedExec(updateCurrentExecutionsBlockedUntilQry, row)
+}
+
 // SelectFromCurrentExecutions reads one or more rows from current_executions table
 func (mdb *DB) SelectFromCurrentExecutions(filter *sqldb.CurrentExecutionsFilter) (*sqldb.CurrentExecutionsRow, error) {
 	var row sqldb.CurrentExecutionsRow
 	err := mdb.conn.Get(&row, getCurrentExecutionQry, filter.ShardID, filter.DomainID, filter.WorkflowID)
+	if err == nil {
+		row.BlockedUntil = mdb.converter.FromMySQLDateTime(row.BlockedUntil)
+	}
 	return &row, err
 }
 
+// RangeSelectFromCurrentExecutions reads a page of rows of a shard from current_executions table
+func (mdb *DB) RangeSelectFromCurrentExecutions(filter *sqldb.CurrentExecutionsFilter) ([]sqldb.CurrentExecutionsRow, error) {
+	var rows []sqldb.CurrentExecutionsRow
+	err := mdb.conn.Select(&rows, rangeGetCurrentExecutionsQry, filter.ShardID, filter.DomainID, filter.WorkflowID, *filter.PageSize)
+	return rows, err
+}
+
 // DeleteFromCurrentExecutions deletes a single row in current_executions table
 func (mdb *DB) DeleteFromCurrentExecutions(filter *sqldb.CurrentExecutionsFilter) (sql.Result, error) {
 	return mdb.conn.Exec(deleteCurrentExecutionQry, filter.ShardID, filter.DomainID, filter.WorkflowID, filter.RunID)
@@ -193,6 +264,9 @@ func (mdb *DB) LockCurrentExecutions(filter *sqldb.CurrentExecutionsFilter) (*sq
 func (mdb *DB) LockCurrentExecutionsJoinExecutions(filter *sqldb.CurrentExecutionsFilter) ([]sqldb.CurrentExecutionsRow, error) {
 	var rows []sqldb.CurrentExecutionsRow
 	err := mdb.conn.Select(&rows, lockCurrentExecutionJoinExecutionsQry, filter.ShardID, filter.DomainID, filter.WorkflowID)
+	for i := range rows {
+		rows[i].BlockedUntil = mdb.converter.FromMySQLDateTime(rows[i].BlockedUntil)
+	}
 	return rows, err
 }
 
@@ -219,6 +293,29 @@ func (mdb *DB) DeleteFromTransferTasks(filter *sqldb.TransferTasksFilter) (sql.R
 	return mdb.conn.Exec(deleteTransferTaskQry, filter.ShardID, *filter.TaskID)
 }
 
+// InsertIntoVisibilityTasks inserts one or more rows into visibility_tasks table
+func (mdb *DB) InsertIntoVisibilityTasks(rows []sqldb.VisibilityTasksRow) (sql.Result, error) {
+	return mdb.conn.NamedExec(createVisibilityTasksQry, rows)
+}
+
+// SelectFromVisibilityTasks reads one or more rows from visibility_tasks table
+func (mdb *DB) SelectFromVisibilityTasks(filter *sqldb.VisibilityTasksFilter) ([]sqldb.VisibilityTasksRow, error) {
+	var rows []sqldb.VisibilityTasksRow
+	err := mdb.conn.Select(&rows, getVisibilityTasksQry, filter.ShardID, *filter.MinTaskID, *filter.MaxTaskID)
+	if err != nil {
+		return nil, err
+	}
+	return rows, err
+}
+
+// DeleteFromVisibilityTasks deletes one or more rows from visibility_tasks table
+func (mdb *DB) DeleteFromVisibilityTasks(filter *sqldb.VisibilityTasksFilter) (sql.Result, error) {
+	if filter.MinTaskID != nil {
+		return mdb.conn.Exec(rangeDeleteVisibilityTaskQry, filter.ShardID, *filter.MinTaskID, *filter.MaxTaskID)
+	}
+	return mdb.conn.Exec(deleteVisibilityTaskQry, filter.ShardID, *filter.TaskID)
+}
+
 // InsertIntoTimerTasks inserts one or more rows into timer_tasks table
 func (mdb *DB) InsertIntoTimerTasks(rows []sqldb.TimerTasksRow) (sql.Result, error) {
 	for i := range rows {
@@ -254,6 +351,38 @@ func (mdb *DB) DeleteFromTimerTasks(filter *sqldb.TimerTasksFilter) (sql.Result,
 	return mdb.conn.Exec(deleteTimerTaskQry, filter.ShardID, *filter.VisibilityTimestamp, filter.TaskID)
 }
 
+// InsertIntoClosedExecutionsIndex inserts one or more rows into closed_executions_index table
+func (mdb *DB) InsertIntoClosedExecutionsIndex(rows []sqldb.ClosedExecutionsIndexRow) (sql.Result, error) {
+	for i := range rows {
+		rows[i].CloseTime = mdb.converter.ToMySQLDateTime(rows[i].CloseTime)
+	}
+	return mdb.conn.NamedExec(createClosedExecutionsIndexQry, rows)
+}
+
+// SelectFromClosedExecutionsIndex reads one or more rows from closed_executions_index table
+func (mdb *DB) SelectFromClosedExecutionsIndex(filter *sqldb.ClosedExecutionsIndexFilter) ([]sqldb.ClosedExecutionsIndexRow, error) {
+	var rows []sqldb.ClosedExecutionsIndexRow
+	closeTime := mdb.converter.ToMySQLDateTime(filter.CloseTime)
+	maxCloseTime := mdb.converter.ToMySQLDateTime(*filter.MaxCloseTime)
+	err := mdb.conn.Select(&rows, getClosedExecutionsIndexQry, filter.ShardID, closeTime,
+		filter.DomainID, filter.WorkflowID, filter.RunID, maxCloseTime, *filter.PageSize)
+	if err != nil {
+		return nil, err
+	}
+	for i := range rows {
+		rows[i].ShardID = filter.ShardID
+		rows[i].CloseTime = mdb.converter.FromMySQLDateTime(rows[i].CloseTime)
+	}
+	return rows, nil
+}
+
+// DeleteFromClosedExecutionsIndex deletes a single row from closed_executions_index table
+func (mdb *DB) DeleteFromClosedExecutionsIndex(filter *sqldb.ClosedExecutionsIndexFilter) (sql.Result, error) {
+	closeTime := mdb.converter.ToMySQLDateTime(filter.CloseTime)
+	return mdb.conn.Exec(deleteClosedExecutionsIndexQry, filter.ShardID, closeTime,
+		filter.DomainID, filter.WorkflowID, filter.RunID)
+}
+
 // InsertIntoBufferedEvents inserts one or more rows into buffered_events table
 func (mdb *DB) InsertIntoBufferedEvents(rows []sqldb.BufferedEventsRow) (sql.Result, error) {
 	return mdb.conn.NamedExec(createBufferedEventsQury, rows)
@@ -277,6 +406,25 @@ func (mdb *DB) DeleteFromBufferedEvents(filter *sqldb.BufferedEventsFilter) (sql
 	return mdb.conn.Exec(deleteBufferedEventsQury, filter.ShardID, filter.DomainID, filter.WorkflowID, filter.RunID)
 }
 
+// SelectCountsFromBufferedReplicationTaskMaps reads the buffered replication task count of every execution of a shard
+func (mdb *DB) SelectCountsFromBufferedReplicationTaskMaps(filter *sqldb.BufferedReplicationTaskMapsFilter) ([]sqldb.BufferedReplicationTaskMapsRow, error) {
+	var rows []sqldb.BufferedReplicationTaskMapsRow
+	err := mdb.conn.Select(&rows, getBufferedReplicationTaskCountsQry, filter.ShardID,
+		filter.DomainID, filter.WorkflowID, filter.RunID, *filter.PageSize)
+	if err != nil {
+		return nil, err
+	}
+	for i := range rows {
+		rows[i].ShardID = filter.ShardID
+	}
+	return rows, nil
+}
+
+// DeleteFromBufferedReplicationTaskMaps deletes the buffered replication tasks of an execution
+func (mdb *DB) DeleteFromBufferedReplicationTaskMaps(filter *sqldb.BufferedReplicationTaskMapsFilter) (sql.Result, error) {
+	return mdb.conn.Exec(deleteBufferedReplicationTaskMapsQry, filter.ShardID, filter.DomainID, filter.WorkflowID, filter.RunID)
+}
+
 // InsertIntoReplicationTasks inserts one or more rows into replication_tasks table
 func (mdb *DB) InsertIntoReplicationTasks(rows []sqldb.ReplicationTasksRow) (sql.Result, error) {
 	return mdb.conn.NamedExec(createReplicationTasksQry, rows)
@@ -291,5 +439,8 @@ func (mdb *DB) SelectFromReplicationTasks(filter *sqldb.ReplicationTasksFilter)
 
 // DeleteFromReplicationTasks deletes one or more rows from replication_tasks table
 func (mdb *DB) DeleteFromReplicationTasks(filter *sqldb.ReplicationTasksFilter) (sql.Result, error) {
+	if filter.MaxTaskID != nil {
+		return mdb.conn.Exec(rangeDeleteReplicationTaskQry, filter.ShardID, *filter.MaxTaskID)
+	}
 	return mdb.conn.Exec(deleteReplicationTaskQry, filter.ShardID, *filter.TaskID)
 }
@@ -309,6 +309,46 @@ func (mdb *DB) DeleteFromSignalInfoMaps(filter *sqldb.SignalInfoMapsFilter) (sql
 	return mdb.conn.Exec(deleteSignalInfoMapQry, filter.ShardID, filter.DomainID, filter.WorkflowID, filter.RunID)
 }
 
+var (
+	updateInfoColumns = []string{
+		"data",
+		"data_encoding",
+	}
+	updateInfoTableName = "update_info_maps"
+	updateInfoKey       = "update_id"
+
+	deleteUpdateInfoMapQry      = makeDeleteMapQry(updateInfoTableName)
+	setKeyInUpdateInfoMapQry    = makeSetKeyInMapQry(updateInfoTableName, updateInfoColumns, updateInfoKey)
+	deleteKeyInUpdateInfoMapQry = makeDeleteKeyInMapQry(updateInfoTableName, updateInfoKey)
+	getUpdateInfoMapQry         = makeGetMapQryTemplate(updateInfoTableName, updateInfoColumns, updateInfoKey)
+)
+
+// ReplaceIntoUpdateInfoMaps replaces one or more rows in update_info_maps table
+func (mdb *DB) ReplaceIntoUpdateInfoMaps(rows []sqldb.UpdateInfoMapsRow) (sql.Result, error) {
+	return mdb.conn.NamedExec(setKeyInUpdateInfoMapQry, rows)
+}
+
+// SelectFromUpdateInfoMaps reads one or more rows from update_info_maps table
+func (mdb *DB) SelectFromUpdateInfoMaps(filter *sqldb.UpdateInfoMapsFilter) ([]sqldb.UpdateInfoMapsRow, error) {
+	var rows []sqldb.UpdateInfoMapsRow
+	err := mdb.conn.Select(&rows, getUpdateInfoMapQry, filter.ShardID, filter.DomainID, filter.WorkflowID, filter.RunID)
+	for i := 0; i < len(rows); i++ {
+		rows[i].ShardID = int64(filter.ShardID)
+		rows[i].DomainID = filter.DomainID
+		rows[i].WorkflowID = filter.WorkflowID
+		rows[i].RunID = filter.RunID
+	}
+	return rows, err
+}
+
+// DeleteFromUpdateInfoMaps deletes one or more rows from update_info_maps table
+func (mdb *DB) DeleteFromUpdateInfoMaps(filter *sqldb.UpdateInfoMapsFilter) (sql.Result, error) {
+	if filter.UpdateID != nil {
+		return mdb.conn.Exec(deleteKeyInUpdateInfoMapQry, filter.ShardID, filter.DomainID, filter.WorkflowID, filter.RunID, *filter.UpdateID)
+	}
+	return mdb.conn.Exec(deleteUpdateInfoMapQry, filter.ShardID, filter.DomainID, filter.WorkflowID, filter.RunID)
+}
+
 const (
 	deleteAllSignalsRequestedSetQry = `DELETE FROM signals_requested_sets
 WHERE
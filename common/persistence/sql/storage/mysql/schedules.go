@@ -0,0 +1,153 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package mysql
+
+import (
+	"database/sql"
+
+	"github.com/uber/cadence/common/persistence/sql/storage/sqldb"
+)
+
+const (
+	schedulesColumns = `domain_id, schedule_id, cron_schedule, workflow_type_name, task_list, input, ` +
+		`execution_timeout, task_timeout, overlap_policy, catchup_window, paused, last_fire_time, ` +
+		`last_workflow_id, last_run_id, backfill_id, backfill_start_time, backfill_end_time, ` +
+		`backfill_runs_per_minute, backfill_last_run_time, last_backfill_workflow_id, last_backfill_run_id, ` +
+		`identity, version`
+
+	insertScheduleQry = `INSERT INTO schedules (` + schedulesColumns + `)
+ VALUES(?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+
+	updateScheduleQry = `UPDATE schedules SET
+ cron_schedule = ?, workflow_type_name = ?, task_list = ?, input = ?, execution_timeout = ?, task_timeout = ?,
+ overlap_policy = ?, catchup_window = ?, paused = ?, last_fire_time = ?, last_workflow_id = ?, last_run_id = ?,
+ backfill_id = ?, backfill_start_time = ?, backfill_end_time = ?, backfill_runs_per_minute = ?,
+ backfill_last_run_time = ?, last_backfill_workflow_id = ?, last_backfill_run_id = ?, identity = ?, version = ?
+ WHERE domain_id = ? AND schedule_id = ? AND version = ?`
+
+	getScheduleQry = `SELECT ` + schedulesColumns + ` FROM schedules WHERE domain_id = ? AND schedule_id = ?`
+
+	rangeGetSchedulesQry = `SELECT ` + schedulesColumns + ` FROM schedules
+ WHERE (domain_id, schedule_id) > (?, ?) ORDER BY domain_id, schedule_id LIMIT ?`
+
+	rangeGetDomainSchedulesQry = `SELECT ` + schedulesColumns + ` FROM schedules
+ WHERE domain_id = ? AND schedule_id > ? ORDER BY schedule_id LIMIT ?`
+
+	deleteScheduleQry = `DELETE FROM schedules WHERE domain_id = ? AND schedule_id = ?`
+)
+
+// InsertIntoSchedules inserts a row into schedules table
+func (mdb *DB) InsertIntoSchedules(row *sqldb.SchedulesRow) (sql.Result, error) {
+	return mdb.conn.Exec(insertScheduleQry,
+		row.DomainID,
+		row.ScheduleID,
+		row.CronSchedule,
+		row.WorkflowTypeName,
+		row.TaskList,
+		row.Input,
+		row.ExecutionTimeout,
+		row.TaskTimeout,
+		row.OverlapPolicy,
+		row.CatchupWindow,
+		row.Paused,
+		mdb.converter.ToMySQLDateTime(row.LastFireTime),
+		row.LastWorkflowID,
+		row.LastRunID,
+		row.BackfillID,
+		mdb.converter.ToMySQLDateTime(row.BackfillStartTime),
+		mdb.converter.ToMySQLDateTime(row.BackfillEndTime),
+		row.BackfillRunsPerMinute,
+		mdb.converter.ToMySQLDateTime(row.BackfillLastRunTime),
+		row.LastBackfillWorkflowID,
+		row.LastBackfillRunID,
+		row.Identity,
+		row.Version)
+}
+
+// UpdateSchedules updates a row in schedules table if its version is previousVersion
+func (mdb *DB) UpdateSchedules(row *sqldb.SchedulesRow, previousVersion int64) (sql.Result, error) {
+	return mdb.conn.Exec(updateScheduleQry,
+		row.CronSchedule,
+		row.WorkflowTypeName,
+		row.TaskList,
+		row.Input,
+		row.ExecutionTimeout,
+		row.TaskTimeout,
+		row.OverlapPolicy,
+		row.CatchupWindow,
+		row.Paused,
+		mdb.converter.ToMySQLDateTime(row.LastFireTime),
+		row.LastWorkflowID,
+		row.LastRunID,
+		row.BackfillID,
+		mdb.converter.ToMySQLDateTime(row.BackfillStartTime),
+		mdb.converter.ToMySQLDateTime(row.BackfillEndTime),
+		row.BackfillRunsPerMinute,
+		mdb.converter.ToMySQLDateTime(row.BackfillLastRunTime),
+		row.LastBackfillWorkflowID,
+		row.LastBackfillRunID,
+		row.Identity,
+		row.Version,
+		row.DomainID,
+		row.ScheduleID,
+		previousVersion)
+}
+
+// SelectFromSchedules reads a single row from schedules table
+func (mdb *DB) SelectFromSchedules(filter *sqldb.SchedulesFilter) (*sqldb.SchedulesRow, error) {
+	var row sqldb.SchedulesRow
+	err := mdb.conn.Get(&row, getScheduleQry, filter.DomainID, filter.ScheduleID)
+	if err != nil {
+		return nil, err
+	}
+	mdb.fromMySQLScheduleDateTimes(&row)
+	return &row, nil
+}
+
+// RangeSelectFromSchedules reads one or more rows from schedules table
+func (mdb *DB) RangeSelectFromSchedules(filter *sqldb.SchedulesFilter) ([]sqldb.SchedulesRow, error) {
+	var rows []sqldb.SchedulesRow
+	var err error
+	if filter.DomainID != nil {
+		err = mdb.conn.Select(&rows, rangeGetDomainSchedulesQry, filter.DomainID, filter.MinScheduleID, *filter.PageSize)
+	} else {
+		err = mdb.conn.Select(&rows, rangeGetSchedulesQry, filter.MinDomainID, filter.MinScheduleID, *filter.PageSize)
+	}
+	if err != nil {
+		return nil, err
+	}
+	for i := range rows {
+		mdb.fromMySQLScheduleDateTimes(&rows[i])
+	}
+	return rows, nil
+}
+
+// DeleteFromSchedules deletes a single row from schedules table
+func (mdb *DB) DeleteFromSchedules(filter *sqldb.SchedulesFilter) (sql.Result, error) {
+	return mdb.conn.Exec(deleteScheduleQry, filter.DomainID, filter.ScheduleID)
+}
+
+func (mdb *DB) fromMySQLScheduleDateTimes(row *sqldb.SchedulesRow) {
+	row.LastFireTime = mdb.converter.FromMySQLDateTime(row.LastFireTime)
+	row.BackfillStartTime = mdb.converter.FromMySQLDateTime(row.BackfillStartTime)
+	row.BackfillEndTime = mdb.converter.FromMySQLDateTime(row.BackfillEndTime)
+	row.BackfillLastRunTime = mdb.converter.FromMySQLDateTime(row.BackfillLastRunTime)
+}
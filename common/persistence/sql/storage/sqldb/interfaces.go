@@ -66,6 +66,74 @@ type (
 		ShardID int64
 	}
 
+	// ClusterMembershipRow represents a row in cluster_membership table
+	ClusterMembershipRow struct {
+		Role          string
+		RPCAddress    string
+		SessionStart  time.Time
+		LastHeartbeat time.Time
+		RecordExpiry  time.Time
+	}
+
+	// ClusterMembershipFilter contains the column names within cluster_membership table that
+	// can be used to filter results through a WHERE clause
+	ClusterMembershipFilter struct {
+		Role               string
+		LastHeartbeatAfter time.Time
+		RecordExpiryAfter  time.Time
+		RecordExpiryBefore time.Time
+		MaxRecordsAffected int
+	}
+
+	// AuditLogRow represents a row in audit_log table
+	AuditLogRow struct {
+		EntryID       UUID
+		Timestamp     time.Time
+		Caller        string
+		CallerService string
+		Operation     string
+		Domain        string
+		RequestHash   string
+		Error         string
+	}
+
+	// SchedulesRow represents a row in schedules table
+	SchedulesRow struct {
+		DomainID               UUID
+		ScheduleID             string
+		CronSchedule           string
+		WorkflowTypeName       string
+		TaskList               string
+		Input                  []byte
+		ExecutionTimeout       int32
+		TaskTimeout            int32
+		OverlapPolicy          int
+		CatchupWindow          int32
+		Paused                 bool
+		LastFireTime           time.Time
+		LastWorkflowID         string
+		LastRunID              string
+		BackfillID             string
+		BackfillStartTime      time.Time
+		BackfillEndTime        time.Time
+		BackfillRunsPerMinute  int32
+		BackfillLastRunTime    time.Time
+		LastBackfillWorkflowID string
+		LastBackfillRunID      string
+		Identity               string
+		Version                int64
+	}
+
+	// SchedulesFilter contains the column names within schedules table that
+	// can be used to filter results through a WHERE clause
+	SchedulesFilter struct {
+		DomainID      UUID
+		ScheduleID    string
+		MinDomainID   UUID
+		MinScheduleID string
+		PageSize      *int
+	}
+
 	// TransferTasksRow represents a row in transfer_tasks table
 	TransferTasksRow struct {
 		ShardID      int
@@ -83,6 +151,23 @@ type (
 		MaxTaskID *int64
 	}
 
+	// VisibilityTasksRow represents a row in visibility_tasks table
+	VisibilityTasksRow struct {
+		ShardID      int
+		TaskID       int64
+		Data         []byte
+		DataEncoding string
+	}
+
+	// VisibilityTasksFilter contains the column names within visibility_tasks table that
+	// can be used to filter results through a WHERE clause
+	VisibilityTasksFilter struct {
+		ShardID   int
+		TaskID    *int64
+		MinTaskID *int64
+		MaxTaskID *int64
+	}
+
 	// ExecutionsRow represents a row in executions table
 	ExecutionsRow struct {
 		ShardID          int
@@ -102,6 +187,7 @@ type (
 		DomainID   UUID
 		WorkflowID string
 		RunID      UUID
+		PageSize   *int
 	}
 
 	// CurrentExecutionsRow represents a row in current_executions table
@@ -115,6 +201,7 @@ type (
 		CloseStatus      int
 		LastWriteVersion int64
 		StartVersion     int64
+		BlockedUntil     time.Time
 	}
 
 	// CurrentExecutionsFilter contains the column names within domain table that
@@ -124,6 +211,7 @@ type (
 		DomainID   UUID
 		WorkflowID string
 		RunID      UUID
+		PageSize   *int
 	}
 
 	// BufferedEventsRow represents a row in buffered_events table
@@ -145,6 +233,26 @@ type (
 		RunID      UUID
 	}
 
+	// BufferedReplicationTaskMapsRow represents the buffered replication tasks of an execution
+	// in buffered_replication_task_maps table
+	BufferedReplicationTaskMapsRow struct {
+		ShardID    int
+		DomainID   UUID
+		WorkflowID string
+		RunID      UUID
+		TaskCount  int
+	}
+
+	// BufferedReplicationTaskMapsFilter contains the column names within buffered_replication_task_maps table that
+	// can be used to filter results through a WHERE clause
+	BufferedReplicationTaskMapsFilter struct {
+		ShardID    int
+		DomainID   UUID
+		WorkflowID string
+		RunID      UUID
+		PageSize   *int
+	}
+
 	// TasksRow represents a row in tasks table
 	TasksRow struct {
 		DomainID     UUID
@@ -232,6 +340,28 @@ type (
 		PageSize               *int
 	}
 
+	// ClosedExecutionsIndexRow represents a row in closed_executions_index table
+	ClosedExecutionsIndexRow struct {
+		ShardID    int
+		CloseTime  time.Time
+		DomainID   UUID
+		WorkflowID string
+		RunID      UUID
+		Version    int64
+	}
+
+	// ClosedExecutionsIndexFilter contains the column names within closed_executions_index table that
+	// can be used to filter results through a WHERE clause
+	ClosedExecutionsIndexFilter struct {
+		ShardID      int
+		CloseTime    time.Time
+		DomainID     UUID
+		WorkflowID   string
+		RunID        UUID
+		MaxCloseTime *time.Time
+		PageSize     *int
+	}
+
 	// EventsRow represents a row in events table
 	EventsRow struct {
 		DomainID     UUID
@@ -406,6 +536,27 @@ type (
 		InitiatedID *int64
 	}
 
+	// UpdateInfoMapsRow represents a row in update_info_maps table
+	UpdateInfoMapsRow struct {
+		ShardID      int64
+		DomainID     UUID
+		WorkflowID   string
+		RunID        UUID
+		UpdateID     string
+		Data         []byte
+		DataEncoding string
+	}
+
+	// UpdateInfoMapsFilter contains the column names within update_info_maps table that
+	// can be used to filter results through a WHERE clause
+	UpdateInfoMapsFilter struct {
+		ShardID    int64
+		DomainID   UUID
+		WorkflowID string
+		RunID      UUID
+		UpdateID   *string
+	}
+
 	// SignalsRequestedSetsRow represents a row in signals_requested_sets table
 	SignalsRequestedSetsRow struct {
 		ShardID    int64
@@ -475,6 +626,30 @@ type (
 		ReadLockShards(filter *ShardsFilter) (int, error)
 		WriteLockShards(filter *ShardsFilter) (int, error)
 
+		UpsertClusterMembership(row *ClusterMembershipRow) (sql.Result, error)
+		// SelectFromClusterMembership returns the rows from cluster_membership table
+		// Required filter params - {lastHeartbeatAfter, recordExpiryAfter}, role is optional
+		SelectFromClusterMembership(filter *ClusterMembershipFilter) ([]ClusterMembershipRow, error)
+		// DeleteFromClusterMembership deletes expired rows from cluster_membership table
+		// Required filter params - {recordExpiryBefore, maxRecordsAffected}
+		DeleteFromClusterMembership(filter *ClusterMembershipFilter) (sql.Result, error)
+
+		InsertIntoAuditLog(row *AuditLogRow) (sql.Result, error)
+
+		InsertIntoSchedules(row *SchedulesRow) (sql.Result, error)
+		// UpdateSchedules updates a single row in schedules table if its version is previousVersion
+		UpdateSchedules(row *SchedulesRow, previousVersion int64) (sql.Result, error)
+		// SelectFromSchedules returns a single row from schedules table
+		// Required filter params - {domainID, scheduleID}
+		SelectFromSchedules(filter *SchedulesFilter) (*SchedulesRow, error)
+		// RangeSelectFromSchedules returns the rows following {minDomainID, minScheduleID} ordered by domain and
+		// schedule ID, only the rows of domainID are returned if it is set
+		// Required filter params - {minDomainID, minScheduleID, pageSize}
+		RangeSelectFromSchedules(filter *SchedulesFilter) ([]SchedulesRow, error)
+		// DeleteFromSchedules deletes a single row from schedules table
+		// Required filter params - {domainID, scheduleID}
+		DeleteFromSchedules(filter *SchedulesFilter) (sql.Result, error)
+
 		InsertIntoTasks(rows []TasksRow) (sql.Result, error)
 		// SelectFromTasks retrieves one or more rows from the tasks table
 		// Required filter params - {domainID, tasklistName, taskType, minTaskID, maxTaskID, pageSize}
@@ -518,6 +693,9 @@ type (
 		InsertIntoExecutions(row *ExecutionsRow) (sql.Result, error)
 		UpdateExecutions(row *ExecutionsRow) (sql.Result, error)
 		SelectFromExecutions(filter *ExecutionsFilter) (*ExecutionsRow, error)
+		// SelectRunsFromExecutions returns the rows of a workflow ID ordered by run ID
+		// Required params - {shardID, domainID, workflowID, pageSize}, runID is the exclusive lower bound
+		SelectRunsFromExecutions(filter *ExecutionsFilter) ([]ExecutionsRow, error)
 		DeleteFromExecutions(filter *ExecutionsFilter) (sql.Result, error)
 		ReadLockExecutions(filter *ExecutionsFilter) (int, error)
 		WriteLockExecutions(filter *ExecutionsFilter) (int, error)
@@ -526,9 +704,16 @@ type (
 
 		InsertIntoCurrentExecutions(row *CurrentExecutionsRow) (sql.Result, error)
 		UpdateCurrentExecutions(row *CurrentExecutionsRow) (sql.Result, error)
+		// UpdateCurrentExecutionsBlockedUntil updates blocked_until of a single row in current_executions table
+		// Required filter params - {shardID, domainID, workflowID, runID}
+		UpdateCurrentExecutionsBlockedUntil(row *CurrentExecutionsRow) (sql.Result, error)
 		// SelectFromCurrentExecutions returns one or more rows from current_executions table
 		// Required params - {shardID, domainID, workflowID}
 		SelectFromCurrentExecutions(filter *CurrentExecutionsFilter) (*CurrentExecutionsRow, error)
+		// RangeSelectFromCurrentExecutions returns the rows of a shard ordered by domain and workflow ID,
+		// {domainID, workflowID} is the exclusive lower bound
+		// Required params - {shardID, domainID, workflowID, pageSize}
+		RangeSelectFromCurrentExecutions(filter *CurrentExecutionsFilter) ([]CurrentExecutionsRow, error)
 		// DeleteFromCurrentExecutions deletes a single row that matches the filter criteria
 		// If a row exist, that row will be deleted and this method will return success
 		// If there is no row matching the filter criteria, this method will still return success
@@ -546,6 +731,15 @@ type (
 		// When MinTaskID and MaxTaskID are not-nil, a range of rows are deleted.
 		DeleteFromTransferTasks(filter *TransferTasksFilter) (sql.Result, error)
 
+		InsertIntoVisibilityTasks(rows []VisibilityTasksRow) (sql.Result, error)
+		// SelectFromVisibilityTasks returns rows that match filter criteria from visibility_tasks table.
+		// Required filter params - {shardID, minTaskID, maxTaskID}
+		SelectFromVisibilityTasks(filter *VisibilityTasksFilter) ([]VisibilityTasksRow, error)
+		// DeleteFromVisibilityTasks deletes one or more rows from visibility_tasks table.
+		// Filter params - shardID is required. If TaskID is not nil, a single row is deleted.
+		// When MinTaskID and MaxTaskID are not-nil, a range of rows are deleted.
+		DeleteFromVisibilityTasks(filter *VisibilityTasksFilter) (sql.Result, error)
+
 		InsertIntoTimerTasks(rows []TimerTasksRow) (sql.Result, error)
 		// SelectFromTimerTasks returns one or more rows from timer_tasks table
 		// Required filter Params - {shardID, taskID, minVisibilityTimestamp, maxVisibilityTimestamp, pageSize}
@@ -556,16 +750,33 @@ type (
 		//  - to delete multiple rows - {shardID, minVisibilityTimestamp, maxVisibilityTimestamp}
 		DeleteFromTimerTasks(filter *TimerTasksFilter) (sql.Result, error)
 
+		InsertIntoClosedExecutionsIndex(rows []ClosedExecutionsIndexRow) (sql.Result, error)
+		// SelectFromClosedExecutionsIndex returns the rows starting at the given row key and closed before maxCloseTime
+		// Required filter params - {shardID, closeTime, domainID, workflowID, runID, maxCloseTime, pageSize}
+		SelectFromClosedExecutionsIndex(filter *ClosedExecutionsIndexFilter) ([]ClosedExecutionsIndexRow, error)
+		// DeleteFromClosedExecutionsIndex deletes a single row from closed_executions_index table
+		// Required filter params - {shardID, closeTime, domainID, workflowID, runID}
+		DeleteFromClosedExecutionsIndex(filter *ClosedExecutionsIndexFilter) (sql.Result, error)
+
 		InsertIntoBufferedEvents(rows []BufferedEventsRow) (sql.Result, error)
 		SelectFromBufferedEvents(filter *BufferedEventsFilter) ([]BufferedEventsRow, error)
 		DeleteFromBufferedEvents(filter *BufferedEventsFilter) (sql.Result, error)
 
+		// SelectCountsFromBufferedReplicationTaskMaps returns the buffered replication task count of every execution
+		// of a shard ordered by execution, {domainID, workflowID, runID} is the exclusive lower bound
+		// Required filter params - {shardID, domainID, workflowID, runID, pageSize}
+		SelectCountsFromBufferedReplicationTaskMaps(filter *BufferedReplicationTaskMapsFilter) ([]BufferedReplicationTaskMapsRow, error)
+		// DeleteFromBufferedReplicationTaskMaps deletes the buffered replication tasks of an execution
+		// Required filter params - {shardID, domainID, workflowID, runID}
+		DeleteFromBufferedReplicationTaskMaps(filter *BufferedReplicationTaskMapsFilter) (sql.Result, error)
+
 		InsertIntoReplicationTasks(rows []ReplicationTasksRow) (sql.Result, error)
 		// SelectFromReplicationTasks returns one or more rows from replication_tasks table
 		// Required filter params - {shardID, minTaskID, maxTaskID, pageSize}
 		SelectFromReplicationTasks(filter *ReplicationTasksFilter) ([]ReplicationTasksRow, error)
-		// DeleteFromReplicationTasks deletes a row from replication_tasks table
-		// Required filter params - {shardID, taskID}
+		// DeleteFromReplicationTasks deletes a row from replication_tasks table, or
+		// all rows up to maxTaskID (inclusive) if maxTaskID is set
+		// Required filter params - {shardID, taskID} or {shardID, maxTaskID}
 		DeleteFromReplicationTasks(filter *ReplicationTasksFilter) (sql.Result, error)
 
 		ReplaceIntoActivityInfoMaps(rows []ActivityInfoMapsRow) (sql.Result, error)
@@ -628,6 +839,16 @@ type (
 		// - multiple rows - {shardID, domainID, workflowID, runID}
 		DeleteFromSignalsRequestedSets(filter *SignalsRequestedSetsFilter) (sql.Result, error)
 
+		ReplaceIntoUpdateInfoMaps(rows []UpdateInfoMapsRow) (sql.Result, error)
+		// SelectFromUpdateInfoMaps returns one or more rows form update_info_maps table
+		// Required filter params - {shardID, domainID, workflowID, runID}
+		SelectFromUpdateInfoMaps(filter *UpdateInfoMapsFilter) ([]UpdateInfoMapsRow, error)
+		// DeleteFromUpdateInfoMaps deletes one or more rows from update_info_maps table
+		// Required filter params
+		// - single row - {shardID, domainID, workflowID, runID, updateID}
+		// - multiple rows - {shardID, domainID, workflowID, runID}
+		DeleteFromUpdateInfoMaps(filter *UpdateInfoMapsFilter) (sql.Result, error)
+
 		// InsertIntoVisibility inserts a row into visibility table. If a row already exist,
 		// no changes will be made by this API
 		InsertIntoVisibility(row *VisibilityRow) (sql.Result, error)
@@ -652,6 +873,13 @@ type (
 		Rollback() error
 	}
 
+	// ConflictReporter is implemented by the transactions of stores that abort
+	// conflicting transactions, it reports whether the transaction hit such a
+	// conflict, even when the error was wrapped before reaching the caller
+	ConflictReporter interface {
+		Conflicted() bool
+	}
+
 	// Interface defines the API for a SQL database
 	Interface interface {
 		tableCRUD
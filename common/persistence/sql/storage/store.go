@@ -28,6 +28,8 @@ import (
 
 	"github.com/iancoleman/strcase"
 	"github.com/jmoiron/sqlx"
+	_ "github.com/lib/pq" // needed to load the postgres driver used for cockroach
+	"github.com/uber/cadence/common/persistence/sql/storage/cockroach"
 	"github.com/uber/cadence/common/persistence/sql/storage/mysql"
 	"github.com/uber/cadence/common/persistence/sql/storage/sqldb"
 	"github.com/uber/cadence/common/service/config"
@@ -38,6 +40,10 @@ const (
 	isolationLevelAttrName       = "transaction_isolation"
 	isolationLevelAttrNameLegacy = "tx_isolation"
 	defaultIsolationLevel        = "'READ-COMMITTED'"
+
+	// CockroachDialect is the dialect for CockroachDB, which speaks the postgres wire protocol
+	CockroachDialect   = "cockroach"
+	cockroachDSNScheme = "postgresql"
 )
 
 var dsnAttrOverrides = map[string]string{
@@ -51,7 +57,11 @@ var dsnAttrOverrides = map[string]string{
 // SQL database and the object can be used to perform CRUD operations on
 // the tables in the database
 func NewSQLDB(cfg *config.SQL) (sqldb.Interface, error) {
-	db, err := sqlx.Connect(cfg.DriverName, buildDSN(cfg))
+	dsn := buildDSN(cfg)
+	if cfg.Dialect == CockroachDialect {
+		dsn = buildCockroachDSN(cfg)
+	}
+	db, err := sqlx.Connect(cfg.DriverName, dsn)
 	if err != nil {
 		return nil, err
 	}
@@ -66,9 +76,32 @@ func NewSQLDB(cfg *config.SQL) (sqldb.Interface, error) {
 	}
 	// Maps struct names in CamelCase to snake without need for db struct tags.
 	db.MapperFunc(strcase.ToSnake)
+	if cfg.Dialect == CockroachDialect {
+		return cockroach.NewDB(db, nil), nil
+	}
 	return mysql.NewDB(db, nil), nil
 }
 
+// buildCockroachDSN builds a postgres connection url. The mysql isolation level
+// attributes are left out since cockroach transactions are always serializable
+func buildCockroachDSN(cfg *config.SQL) string {
+	dsn := url.URL{
+		Scheme: cockroachDSNScheme,
+		User:   url.User(cfg.User),
+		Host:   cfg.ConnectAddr,
+		Path:   cfg.DatabaseName,
+	}
+	if cfg.Password != "" {
+		dsn.User = url.UserPassword(cfg.User, cfg.Password)
+	}
+	attrs := url.Values{}
+	for k, v := range cfg.ConnectAttributes {
+		attrs.Set(k, v)
+	}
+	dsn.RawQuery = attrs.Encode()
+	return dsn.String()
+}
+
 func buildDSN(cfg *config.SQL) string {
 	attrs := buildDSNAttrs(cfg)
 	dsn := fmt.Sprintf(dsnFmt, cfg.User, cfg.Password, cfg.ConnectProtocol, cfg.ConnectAddr, cfg.DatabaseName)
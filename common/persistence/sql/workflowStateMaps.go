@@ -79,6 +79,7 @@ func updateActivityInfos(
 				RetryNonRetryableErrors:       v.NonRetriableErrors,
 				RetryLastFailureReason:        &v.LastFailureReason,
 				RetryLastWorkerIdentity:       &v.LastWorkerIdentity,
+				Paused:                        &v.Paused,
 			}
 			blob, err := activityInfoToBlob(info)
 			if err != nil {
@@ -193,6 +194,7 @@ func getActivityInfoMap(
 			NonRetriableErrors:       decoded.GetRetryNonRetryableErrors(),
 			LastFailureReason:        decoded.GetRetryLastFailureReason(),
 			LastWorkerIdentity:       decoded.GetRetryLastWorkerIdentity(),
+			Paused:                   decoded.GetPaused(),
 		}
 		if decoded.StartedEvent != nil {
 			info.StartedEvent = persistence.NewDataBlob(decoded.StartedEvent, common.EncodingType(decoded.GetStartedEventEncoding()))
@@ -738,3 +740,132 @@ func deleteSignalInfoMap(
 	}
 	return nil
 }
+
+func updateUpdateInfos(
+	tx sqldb.Tx,
+	updateInfos []*persistence.UpdateInfo,
+	deleteInfo *string,
+	shardID int,
+	domainID sqldb.UUID,
+	workflowID string,
+	runID sqldb.UUID,
+) error {
+
+	if len(updateInfos) > 0 {
+		rows := make([]sqldb.UpdateInfoMapsRow, len(updateInfos))
+		for i, v := range updateInfos {
+			blob, err := updateInfoToBlob(&sqlblobs.UpdateInfo{
+				Version:   &v.Version,
+				Name:      &v.UpdateName,
+				Input:     v.Input,
+				Result:    v.Result,
+				Completed: &v.Completed,
+			})
+			if err != nil {
+				return err
+			}
+			rows[i] = sqldb.UpdateInfoMapsRow{
+				ShardID:      int64(shardID),
+				DomainID:     domainID,
+				WorkflowID:   workflowID,
+				RunID:        runID,
+				UpdateID:     v.UpdateID,
+				Data:         blob.Data,
+				DataEncoding: string(blob.Encoding),
+			}
+		}
+
+		if _, err := tx.ReplaceIntoUpdateInfoMaps(rows); err != nil {
+			return &workflow.InternalServiceError{
+				Message: fmt.Sprintf("Failed to update update info. Failed to execute update query. Error: %v", err),
+			}
+		}
+	}
+	if deleteInfo == nil {
+		return nil
+	}
+	result, err := tx.DeleteFromUpdateInfoMaps(&sqldb.UpdateInfoMapsFilter{
+		ShardID:    int64(shardID),
+		DomainID:   domainID,
+		WorkflowID: workflowID,
+		RunID:      runID,
+		UpdateID:   deleteInfo,
+	})
+	if err != nil {
+		return &workflow.InternalServiceError{
+			Message: fmt.Sprintf("Failed to update update info. Failed to execute delete query. Error: %v", err),
+		}
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return &workflow.InternalServiceError{
+			Message: fmt.Sprintf("Failed to update update info. Failed to verify number of rows deleted. Error: %v", err),
+		}
+	}
+	if int(rowsAffected) != 1 {
+		return &workflow.InternalServiceError{
+			Message: fmt.Sprintf("Failed to update update info. Deleted %v rows instead of 1", rowsAffected),
+		}
+	}
+	return nil
+}
+
+func getUpdateInfoMap(
+	db sqldb.Interface,
+	shardID int,
+	domainID sqldb.UUID,
+	workflowID string,
+	runID sqldb.UUID,
+) (map[string]*persistence.UpdateInfo, error) {
+
+	rows, err := db.SelectFromUpdateInfoMaps(&sqldb.UpdateInfoMapsFilter{
+		ShardID:    int64(shardID),
+		DomainID:   domainID,
+		WorkflowID: workflowID,
+		RunID:      runID,
+	})
+	if err != nil && err != sql.ErrNoRows {
+		return nil, &workflow.InternalServiceError{
+			Message: fmt.Sprintf("Failed to get update info. Error: %v", err),
+		}
+	}
+
+	ret := make(map[string]*persistence.UpdateInfo)
+	for _, v := range rows {
+		rowInfo, err := updateInfoFromBlob(v.Data, v.DataEncoding)
+		if err != nil {
+			return nil, err
+		}
+		ret[v.UpdateID] = &persistence.UpdateInfo{
+			Version:    rowInfo.GetVersion(),
+			UpdateID:   v.UpdateID,
+			UpdateName: rowInfo.GetName(),
+			Input:      rowInfo.GetInput(),
+			Result:     rowInfo.GetResult(),
+			Completed:  rowInfo.GetCompleted(),
+		}
+	}
+
+	return ret, nil
+}
+
+func deleteUpdateInfoMap(
+	tx sqldb.Tx,
+	shardID int,
+	domainID sqldb.UUID,
+	workflowID string,
+	runID sqldb.UUID,
+) error {
+
+	if _, err := tx.DeleteFromUpdateInfoMaps(&sqldb.UpdateInfoMapsFilter{
+		ShardID:    int64(shardID),
+		DomainID:   domainID,
+		WorkflowID: workflowID,
+		RunID:      runID,
+	}); err != nil {
+		return &workflow.InternalServiceError{
+			Message: fmt.Sprintf("Failed to delete update info map. Error: %v", err),
+		}
+	}
+	return nil
+}
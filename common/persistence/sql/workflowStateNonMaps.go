@@ -34,7 +34,7 @@ import (
 func updateSignalsRequested(
 	tx sqldb.Tx,
 	signalRequestedIDs []string,
-	deleteSignalRequestID string,
+	deleteSignalRequestIDs []string,
 	shardID int,
 	domainID sqldb.UUID,
 	workflowID string,
@@ -59,13 +59,13 @@ func updateSignalsRequested(
 		}
 	}
 
-	if deleteSignalRequestID != "" {
+	for i := range deleteSignalRequestIDs {
 		if _, err := tx.DeleteFromSignalsRequestedSets(&sqldb.SignalsRequestedSetsFilter{
 			ShardID:    int64(shardID),
 			DomainID:   domainID,
 			WorkflowID: workflowID,
 			RunID:      runID,
-			SignalID:   &deleteSignalRequestID,
+			SignalID:   &deleteSignalRequestIDs[i],
 		}); err != nil {
 			return &workflow.InternalServiceError{
 				Message: fmt.Sprintf("Failed to update signals requested. Failed to execute delete query. Error: %v", err),
@@ -154,6 +154,13 @@ func (sc *statsComputer) computeMutableStateUpdateStats(req *InternalUpdateWorkf
 	totalSize += signalInfoSize
 	totalSize += bufferedEventsSize
 
+	transactionStatementCount, transactionSize := sc.computeWorkflowMutationTransactionStats(&req.UpdateWorkflowMutation)
+	if req.NewWorkflowSnapshot != nil {
+		newStatementCount, newSize := sc.computeWorkflowSnapshotTransactionStats(req.NewWorkflowSnapshot)
+		transactionStatementCount += newStatementCount
+		transactionSize += newSize
+	}
+
 	return &MutableStateUpdateSessionStats{
 		MutableStateSize:             totalSize,
 		ExecutionInfoSize:            executionInfoSize,
@@ -172,7 +179,97 @@ func (sc *statsComputer) computeMutableStateUpdateStats(req *InternalUpdateWorkf
 		DeleteChildInfoCount:         deleteChildInfoCount,
 		DeleteSignalInfoCount:        deleteSignalInfoCount,
 		DeleteRequestCancelInfoCount: deleteRequestCancelInfoCount,
+		TransactionStatementCount:    transactionStatementCount,
+		TransactionSize:              transactionSize,
+	}
+}
+
+// computeWorkflowMutationTransactionStats approximates the number of statements and bytes
+// the datastore has to write in a single transaction to apply the mutation
+func (sc *statsComputer) computeWorkflowMutationTransactionStats(mutation *InternalWorkflowMutation) (int, int) {
+	// execution row and current execution row
+	statementCount := 2
+	size := computeExecutionInfoTransactionSize(mutation.ExecutionInfo)
+
+	for _, ai := range mutation.UpsertActivityInfos {
+		size += computeActivityInfoSize(ai)
+	}
+	for _, ti := range mutation.UpserTimerInfos {
+		size += computeTimerInfoSize(ti)
+	}
+	for _, ci := range mutation.UpsertChildExecutionInfos {
+		size += computeChildInfoSize(ci)
+	}
+	for _, si := range mutation.UpsertSignalInfos {
+		size += computeSignalInfoSize(si)
+	}
+	for _, ui := range mutation.UpsertUpdateInfos {
+		size += computeUpdateInfoSize(ui)
+	}
+	for _, id := range mutation.UpsertSignalRequestedIDs {
+		size += len(id)
+	}
+	if mutation.NewBufferedEvents != nil {
+		size += len(mutation.NewBufferedEvents.Data)
+	}
+
+	statementCount += len(mutation.UpsertActivityInfos) + len(mutation.DeleteActivityInfos)
+	statementCount += len(mutation.UpserTimerInfos) + len(mutation.DeleteTimerInfos)
+	statementCount += len(mutation.UpsertChildExecutionInfos) + len(mutation.UpsertRequestCancelInfos)
+	statementCount += len(mutation.UpsertSignalInfos) + len(mutation.UpsertUpdateInfos)
+	statementCount += len(mutation.TransferTasks) + len(mutation.TimerTasks) + len(mutation.ReplicationTasks)
+	statementCount += len(mutation.VisibilityTasks)
+	for _, written := range []bool{
+		mutation.DeleteChildExecutionInfo != nil,
+		mutation.DeleteRequestCancelInfo != nil,
+		mutation.DeleteSignalInfo != nil,
+		mutation.DeleteUpdateInfo != nil,
+		len(mutation.DeleteSignalRequestedIDs) > 0,
+		len(mutation.UpsertSignalRequestedIDs) > 0,
+		mutation.NewBufferedEvents != nil,
+		mutation.ClearBufferedEvents,
+	} {
+		if written {
+			statementCount++
+		}
+	}
+	return statementCount, size
+}
+
+// computeWorkflowSnapshotTransactionStats approximates the number of statements and bytes
+// the datastore has to write in a single transaction to persist the snapshot
+func (sc *statsComputer) computeWorkflowSnapshotTransactionStats(snapshot *InternalWorkflowSnapshot) (int, int) {
+	// execution row and current execution row
+	statementCount := 2
+	size := computeExecutionInfoTransactionSize(snapshot.ExecutionInfo)
+
+	for _, ai := range snapshot.ActivityInfos {
+		size += computeActivityInfoSize(ai)
 	}
+	for _, ti := range snapshot.TimerInfos {
+		size += computeTimerInfoSize(ti)
+	}
+	for _, ci := range snapshot.ChildExecutionInfos {
+		size += computeChildInfoSize(ci)
+	}
+	for _, si := range snapshot.SignalInfos {
+		size += computeSignalInfoSize(si)
+	}
+	for _, ui := range snapshot.UpdateInfos {
+		size += computeUpdateInfoSize(ui)
+	}
+	for _, id := range snapshot.SignalRequestedIDs {
+		size += len(id)
+	}
+
+	statementCount += len(snapshot.ActivityInfos) + len(snapshot.TimerInfos) + len(snapshot.ChildExecutionInfos)
+	statementCount += len(snapshot.RequestCancelInfos) + len(snapshot.SignalInfos) + len(snapshot.UpdateInfos)
+	statementCount += len(snapshot.TransferTasks) + len(snapshot.TimerTasks) + len(snapshot.ReplicationTasks)
+	statementCount += len(snapshot.VisibilityTasks)
+	if len(snapshot.SignalRequestedIDs) > 0 {
+		statementCount++
+	}
+	return statementCount, size
 }
 
 func computeExecutionInfoSize(executionInfo *InternalWorkflowExecutionInfo) int {
@@ -184,6 +281,22 @@ func computeExecutionInfoSize(executionInfo *InternalWorkflowExecutionInfo) int
 	return size
 }
 
+func computeExecutionInfoTransactionSize(executionInfo *InternalWorkflowExecutionInfo) int {
+	size := computeExecutionInfoSize(executionInfo)
+	size += len(executionInfo.ExecutionContext)
+	if executionInfo.CompletionEvent != nil {
+		size += len(executionInfo.CompletionEvent.Data)
+	}
+	if executionInfo.AutoResetPoints != nil {
+		size += len(executionInfo.AutoResetPoints.Data)
+	}
+	for _, value := range executionInfo.SearchAttributes {
+		size += len(value)
+	}
+
+	return size
+}
+
 func computeActivityInfoSize(ai *InternalActivityInfo) int {
 	size := len(ai.ActivityID)
 	if ai.ScheduledEvent != nil {
@@ -221,3 +334,12 @@ func computeSignalInfoSize(si *SignalInfo) int {
 
 	return size
 }
+
+func computeUpdateInfoSize(ui *UpdateInfo) int {
+	size := len(ui.UpdateID)
+	size += len(ui.UpdateName)
+	size += len(ui.Input)
+	size += len(ui.Result)
+
+	return size
+}
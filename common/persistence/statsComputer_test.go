@@ -84,3 +84,25 @@ func (s *statsComputerSuite) TestStatsWithStartedEvent() {
 	stats := s.sc.computeMutableStateUpdateStats(ms)
 	s.Equal(stats.ExecutionInfoSize, expectedSize)
 }
+
+func (s *statsComputerSuite) TestTransactionStats() {
+	ms := s.createRequest()
+	ms.UpdateWorkflowMutation.ExecutionInfo.WorkflowID = "test-workflow-id"
+	ms.UpdateWorkflowMutation.ExecutionInfo.ExecutionContext = []byte("context")
+	ms.UpdateWorkflowMutation.UpsertSignalInfos = []*SignalInfo{{SignalName: "signal", Input: []byte("input")}}
+	ms.UpdateWorkflowMutation.DeleteTimerInfos = []string{"timer-1", "timer-2"}
+	ms.UpdateWorkflowMutation.TransferTasks = []Task{&DecisionTask{}, &ActivityTask{}}
+	ms.UpdateWorkflowMutation.TimerTasks = []Task{&UserTimerTask{}}
+	ms.UpdateWorkflowMutation.NewBufferedEvents = &DataBlob{Data: []byte("events")}
+	ms.NewWorkflowSnapshot = &InternalWorkflowSnapshot{
+		ExecutionInfo: &InternalWorkflowExecutionInfo{WorkflowID: "new-workflow-id"},
+		TransferTasks: []Task{&DecisionTask{}},
+	}
+
+	stats := s.sc.computeMutableStateUpdateStats(ms)
+	// 2 execution rows, 1 signal info, 2 timer deletes, 3 tasks and 1 buffered events for the mutation,
+	// 2 execution rows and 1 task for the new workflow
+	s.Equal(12, stats.TransactionStatementCount)
+	s.Equal(len("test-workflow-id")+len("context")+len("signal")+len("input")+len("events")+len("new-workflow-id"),
+		stats.TransactionSize)
+}
@@ -34,48 +34,51 @@ type (
 	// RecordWorkflowExecutionStartedRequest is used to add a record of a newly
 	// started execution
 	RecordWorkflowExecutionStartedRequest struct {
-		DomainUUID         string
-		Domain             string // not persisted, used as config filter key
-		Execution          s.WorkflowExecution
-		WorkflowTypeName   string
-		StartTimestamp     int64
-		ExecutionTimestamp int64
-		WorkflowTimeout    int64 // not persisted, used for cassandra ttl
-		TaskID             int64 // not persisted, used as condition update version for ES
-		Memo               *s.Memo
-		SearchAttributes   map[string][]byte
+		DomainUUID          string
+		Domain              string // not persisted, used as config filter key
+		Execution           s.WorkflowExecution
+		WorkflowTypeName    string
+		StartTimestamp      int64
+		ExecutionTimestamp  int64
+		FirstExecutionRunID string // only persisted by advanced visibility
+		WorkflowTimeout     int64  // not persisted, used for cassandra ttl
+		TaskID              int64  // not persisted, used as condition update version for ES
+		Memo                *s.Memo
+		SearchAttributes    map[string][]byte
 	}
 
 	// RecordWorkflowExecutionClosedRequest is used to add a record of a newly
 	// closed execution
 	RecordWorkflowExecutionClosedRequest struct {
-		DomainUUID         string
-		Domain             string // not persisted, used as config filter key
-		Execution          s.WorkflowExecution
-		WorkflowTypeName   string
-		StartTimestamp     int64
-		ExecutionTimestamp int64
-		CloseTimestamp     int64
-		Status             s.WorkflowExecutionCloseStatus
-		HistoryLength      int64
-		RetentionSeconds   int64
-		TaskID             int64 // not persisted, used as condition update version for ES
-		Memo               *s.Memo
-		SearchAttributes   map[string][]byte
+		DomainUUID          string
+		Domain              string // not persisted, used as config filter key
+		Execution           s.WorkflowExecution
+		WorkflowTypeName    string
+		StartTimestamp      int64
+		ExecutionTimestamp  int64
+		FirstExecutionRunID string // only persisted by advanced visibility
+		CloseTimestamp      int64
+		Status              s.WorkflowExecutionCloseStatus
+		HistoryLength       int64
+		RetentionSeconds    int64
+		TaskID              int64 // not persisted, used as condition update version for ES
+		Memo                *s.Memo
+		SearchAttributes    map[string][]byte
 	}
 
 	// UpsertWorkflowExecutionRequest is used to upsert workflow execution
 	UpsertWorkflowExecutionRequest struct {
-		DomainUUID         string
-		Domain             string // not persisted, used as config filter key
-		Execution          s.WorkflowExecution
-		WorkflowTypeName   string
-		StartTimestamp     int64
-		ExecutionTimestamp int64
-		WorkflowTimeout    int64 // not persisted, used for cassandra ttl
-		TaskID             int64 // not persisted, used as condition update version for ES
-		Memo               *s.Memo
-		SearchAttributes   map[string][]byte
+		DomainUUID          string
+		Domain              string // not persisted, used as config filter key
+		Execution           s.WorkflowExecution
+		WorkflowTypeName    string
+		StartTimestamp      int64
+		ExecutionTimestamp  int64
+		FirstExecutionRunID string // only persisted by advanced visibility
+		WorkflowTimeout     int64  // not persisted, used for cassandra ttl
+		TaskID              int64  // not persisted, used as condition update version for ES
+		Memo                *s.Memo
+		SearchAttributes    map[string][]byte
 	}
 
 	// ListWorkflowExecutionsRequest is used to list executions in a domain
@@ -61,50 +61,53 @@ func (v *visibilityManagerImpl) GetName() string {
 
 func (v *visibilityManagerImpl) RecordWorkflowExecutionStarted(request *RecordWorkflowExecutionStartedRequest) error {
 	req := &InternalRecordWorkflowExecutionStartedRequest{
-		DomainUUID:         request.DomainUUID,
-		WorkflowID:         request.Execution.GetWorkflowId(),
-		RunID:              request.Execution.GetRunId(),
-		WorkflowTypeName:   request.WorkflowTypeName,
-		StartTimestamp:     request.StartTimestamp,
-		ExecutionTimestamp: request.ExecutionTimestamp,
-		WorkflowTimeout:    request.WorkflowTimeout,
-		TaskID:             request.TaskID,
-		Memo:               v.serializeMemo(request.Memo, request.DomainUUID, request.Execution.GetWorkflowId(), request.Execution.GetRunId()),
-		SearchAttributes:   request.SearchAttributes,
+		DomainUUID:          request.DomainUUID,
+		WorkflowID:          request.Execution.GetWorkflowId(),
+		RunID:               request.Execution.GetRunId(),
+		WorkflowTypeName:    request.WorkflowTypeName,
+		StartTimestamp:      request.StartTimestamp,
+		ExecutionTimestamp:  request.ExecutionTimestamp,
+		FirstExecutionRunID: request.FirstExecutionRunID,
+		WorkflowTimeout:     request.WorkflowTimeout,
+		TaskID:              request.TaskID,
+		Memo:                v.serializeMemo(request.Memo, request.DomainUUID, request.Execution.GetWorkflowId(), request.Execution.GetRunId()),
+		SearchAttributes:    request.SearchAttributes,
 	}
 	return v.persistence.RecordWorkflowExecutionStarted(req)
 }
 
 func (v *visibilityManagerImpl) RecordWorkflowExecutionClosed(request *RecordWorkflowExecutionClosedRequest) error {
 	req := &InternalRecordWorkflowExecutionClosedRequest{
-		DomainUUID:         request.DomainUUID,
-		WorkflowID:         request.Execution.GetWorkflowId(),
-		RunID:              request.Execution.GetRunId(),
-		WorkflowTypeName:   request.WorkflowTypeName,
-		StartTimestamp:     request.StartTimestamp,
-		ExecutionTimestamp: request.ExecutionTimestamp,
-		TaskID:             request.TaskID,
-		Memo:               v.serializeMemo(request.Memo, request.DomainUUID, request.Execution.GetWorkflowId(), request.Execution.GetRunId()),
-		SearchAttributes:   request.SearchAttributes,
-		CloseTimestamp:     request.CloseTimestamp,
-		Status:             request.Status,
-		HistoryLength:      request.HistoryLength,
-		RetentionSeconds:   request.RetentionSeconds,
+		DomainUUID:          request.DomainUUID,
+		WorkflowID:          request.Execution.GetWorkflowId(),
+		RunID:               request.Execution.GetRunId(),
+		WorkflowTypeName:    request.WorkflowTypeName,
+		StartTimestamp:      request.StartTimestamp,
+		ExecutionTimestamp:  request.ExecutionTimestamp,
+		FirstExecutionRunID: request.FirstExecutionRunID,
+		TaskID:              request.TaskID,
+		Memo:                v.serializeMemo(request.Memo, request.DomainUUID, request.Execution.GetWorkflowId(), request.Execution.GetRunId()),
+		SearchAttributes:    request.SearchAttributes,
+		CloseTimestamp:      request.CloseTimestamp,
+		Status:              request.Status,
+		HistoryLength:       request.HistoryLength,
+		RetentionSeconds:    request.RetentionSeconds,
 	}
 	return v.persistence.RecordWorkflowExecutionClosed(req)
 }
 
 func (v *visibilityManagerImpl) UpsertWorkflowExecution(request *UpsertWorkflowExecutionRequest) error {
 	req := &InternalUpsertWorkflowExecutionRequest{
-		DomainUUID:         request.DomainUUID,
-		WorkflowID:         request.Execution.GetWorkflowId(),
-		RunID:              request.Execution.GetRunId(),
-		WorkflowTypeName:   request.WorkflowTypeName,
-		StartTimestamp:     request.StartTimestamp,
-		ExecutionTimestamp: request.ExecutionTimestamp,
-		TaskID:             request.TaskID,
-		Memo:               v.serializeMemo(request.Memo, request.DomainUUID, request.Execution.GetWorkflowId(), request.Execution.GetRunId()),
-		SearchAttributes:   request.SearchAttributes,
+		DomainUUID:          request.DomainUUID,
+		WorkflowID:          request.Execution.GetWorkflowId(),
+		RunID:               request.Execution.GetRunId(),
+		WorkflowTypeName:    request.WorkflowTypeName,
+		StartTimestamp:      request.StartTimestamp,
+		ExecutionTimestamp:  request.ExecutionTimestamp,
+		FirstExecutionRunID: request.FirstExecutionRunID,
+		TaskID:              request.TaskID,
+		Memo:                v.serializeMemo(request.Memo, request.DomainUUID, request.Execution.GetWorkflowId(), request.Execution.GetRunId()),
+		SearchAttributes:    request.SearchAttributes,
 	}
 	return v.persistence.UpsertWorkflowExecution(req)
 }
@@ -277,6 +280,10 @@ func (v *visibilityManagerImpl) convertVisibilityWorkflowExecutionInfo(execution
 		SearchAttributes: searchAttributes,
 	}
 
+	if execution.FirstExecutionRunID != "" {
+		convertedExecution.FirstExecutionRunId = common.StringPtr(execution.FirstExecutionRunID)
+	}
+
 	// for close records
 	if execution.Status != nil {
 		convertedExecution.CloseTime = common.Int64Ptr(execution.CloseTime.UnixNano())
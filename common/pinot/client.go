@@ -0,0 +1,140 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package pinot
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	defaultQueryTimeout = 10 * time.Second
+	queryPath           = "/query/sql"
+)
+
+type (
+	// Client is a minimal client of the Pinot broker SQL query API.
+	Client interface {
+		Query(ctx context.Context, sql string) (*QueryResult, error)
+	}
+
+	// QueryResult is the result table of a broker query
+	QueryResult struct {
+		Columns []string
+		Rows    [][]interface{}
+	}
+
+	brokerClient struct {
+		url        string
+		httpClient *http.Client
+	}
+
+	brokerRequest struct {
+		SQL string `json:"sql"`
+	}
+
+	brokerResponse struct {
+		ResultTable *struct {
+			DataSchema struct {
+				ColumnNames []string `json:"columnNames"`
+			} `json:"dataSchema"`
+			Rows [][]interface{} `json:"rows"`
+		} `json:"resultTable"`
+		Exceptions []struct {
+			ErrorCode int    `json:"errorCode"`
+			Message   string `json:"message"`
+		} `json:"exceptions"`
+	}
+)
+
+var _ Client = (*brokerClient)(nil)
+
+// NewClient create a client sending queries to the Pinot broker of the config
+func NewClient(config *Config) (Client, error) {
+	if len(config.BrokerURL) == 0 {
+		return nil, errors.New("pinot config missing broker URL")
+	}
+	if len(config.Table) == 0 {
+		return nil, errors.New("pinot config missing table")
+	}
+	timeout := config.Timeout
+	if timeout == 0 {
+		timeout = defaultQueryTimeout
+	}
+	return &brokerClient{
+		url:        strings.TrimSuffix(config.BrokerURL, "/") + queryPath,
+		httpClient: &http.Client{Timeout: timeout},
+	}, nil
+}
+
+// Query runs the sql on the broker, numbers in the result rows are json.Number
+func (c *brokerClient) Query(ctx context.Context, sql string) (*QueryResult, error) {
+	body, err := json.Marshal(&brokerRequest{SQL: sql})
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequest(http.MethodPost, c.url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("pinot broker returned status %v: %s", resp.StatusCode, data)
+	}
+	return decodeBrokerResponse(data)
+}
+
+func decodeBrokerResponse(data []byte) (*QueryResult, error) {
+	var response brokerResponse
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	if err := dec.Decode(&response); err != nil {
+		return nil, fmt.Errorf("unable to decode pinot broker response: %v", err)
+	}
+	if len(response.Exceptions) > 0 {
+		return nil, fmt.Errorf("pinot query failed with error code %v: %v",
+			response.Exceptions[0].ErrorCode, response.Exceptions[0].Message)
+	}
+
+	result := &QueryResult{}
+	if response.ResultTable != nil {
+		result.Columns = response.ResultTable.DataSchema.ColumnNames
+		result.Rows = response.ResultTable.Rows
+	}
+	return result, nil
+}
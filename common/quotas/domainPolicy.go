@@ -0,0 +1,66 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package quotas
+
+import (
+	"github.com/uber/cadence/common/cache"
+	"github.com/uber/cadence/common/clock"
+	"github.com/uber/cadence/common/service/dynamicconfig"
+	"github.com/uber/cadence/common/tokenbucket"
+)
+
+// domainBucketsMaxSize bounds the number of domains a rate limiter keeps a bucket for,
+// the domain names come from the requests so they are not trusted to be a small set
+const domainBucketsMaxSize = 10000
+
+type domainRateLimitPolicy struct {
+	rps        func(domain string) int
+	timeSource clock.TimeSource
+	buckets    cache.Cache
+}
+
+// NewDomainRateLimiter returns a rate limiter which keeps a separate token
+// bucket for each domain, the rate of each bucket is evaluated dynamically
+func NewDomainRateLimiter(rps func(domain string) int, timeSource clock.TimeSource) DomainPolicy {
+	return &domainRateLimitPolicy{
+		rps:        rps,
+		timeSource: timeSource,
+		buckets:    cache.NewLRU(domainBucketsMaxSize),
+	}
+}
+
+func (d *domainRateLimitPolicy) Allow(domain string) bool {
+	ok, _ := d.getBucket(domain).TryConsume(1)
+	return ok
+}
+
+func (d *domainRateLimitPolicy) getBucket(domain string) tokenbucket.TokenBucket {
+	if tb := d.buckets.Get(domain); tb != nil {
+		return tb.(tokenbucket.TokenBucket)
+	}
+
+	tb := tokenbucket.NewDynamicTokenBucket(func(opts ...dynamicconfig.FilterOption) int {
+		return d.rps(domain)
+	}, d.timeSource)
+	// the buckets are not pinned so putting one never fails
+	existing, _ := d.buckets.PutIfNotExist(domain, tb)
+	return existing.(tokenbucket.TokenBucket)
+}
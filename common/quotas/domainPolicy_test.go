@@ -0,0 +1,84 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package quotas
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"github.com/uber/cadence/common/clock"
+)
+
+type (
+	DomainPolicySuite struct {
+		*require.Assertions
+		suite.Suite
+	}
+)
+
+func TestDomainPolicySuite(t *testing.T) {
+	suite.Run(t, new(DomainPolicySuite))
+}
+
+func (s *DomainPolicySuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+}
+
+func (s *DomainPolicySuite) TestDomainsAreLimitedSeparately() {
+	rps := map[string]int{"domain-a": 100, "domain-b": 100}
+	ts := clock.NewEventTimeSource().Update(time.Now())
+	limiter := NewDomainRateLimiter(func(domain string) int { return rps[domain] }, ts)
+
+	allowed := 0
+	for allowed < 1000 && limiter.Allow("domain-a") {
+		allowed++
+	}
+	s.Equal(10, allowed)
+	s.False(limiter.Allow("domain-a"))
+	s.True(limiter.Allow("domain-b"))
+	s.False(limiter.Allow("domain-c"))
+
+	ts.Update(ts.Now().Add(time.Second))
+	s.True(limiter.Allow("domain-a"))
+}
+
+func (s *DomainPolicySuite) TestRateIsUpdatedDynamically() {
+	rps := 0
+	ts := clock.NewEventTimeSource().Update(time.Now())
+	limiter := NewDomainRateLimiter(func(domain string) int { return rps }, ts)
+	s.False(limiter.Allow("domain-a"))
+
+	rps = 100
+	ts.Update(ts.Now().Add(time.Second))
+	s.True(limiter.Allow("domain-a"))
+}
+
+func (s *DomainPolicySuite) TestBucketsAreBounded() {
+	ts := clock.NewEventTimeSource().Update(time.Now())
+	limiter := NewDomainRateLimiter(func(domain string) int { return 100 }, ts)
+	for i := 0; i < domainBucketsMaxSize+10; i++ {
+		s.True(limiter.Allow(fmt.Sprintf("domain-%v", i)))
+	}
+	s.Equal(domainBucketsMaxSize, limiter.(*domainRateLimitPolicy).buckets.Size())
+}
@@ -28,3 +28,12 @@ type Policy interface {
 	// progress
 	Allow() bool
 }
+
+// DomainPolicy corresponds to a quota policy which is applied to each domain
+// separately.
+type DomainPolicy interface {
+	// Allow attempts to allow a request of the given domain to go through.
+	// The method returns immediately with a true or false indicating if the
+	// request can make progress
+	Allow(domain string) bool
+}
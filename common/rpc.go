@@ -43,6 +43,14 @@ const (
 	// ClientImplHeaderName refers to the name of the
 	// header that contains the client implementation
 	ClientImplHeaderName = "cadence-client-name"
+
+	// AuthorizationHeaderName refers to the name of the
+	// header that contains the bearer token of the caller
+	AuthorizationHeaderName = "authorization"
+
+	// ForwardedFromClusterHeaderName refers to the name of the header
+	// that contains the cluster a read was forwarded from
+	ForwardedFromClusterHeaderName = "cadence-forwarded-from-cluster"
 )
 
 type (
@@ -24,6 +24,8 @@ import (
 	"encoding/json"
 	"time"
 
+	"github.com/uber/cadence/common/audit"
+	"github.com/uber/cadence/common/authorization"
 	"github.com/uber/cadence/common/blobstore/filestore"
 	"github.com/uber/cadence/common/blobstore/s3store"
 
@@ -31,6 +33,7 @@ import (
 	"github.com/uber-go/tally/prometheus"
 	"github.com/uber/cadence/common/elasticsearch"
 	"github.com/uber/cadence/common/messaging"
+	"github.com/uber/cadence/common/pinot"
 	"github.com/uber/cadence/common/service/dynamicconfig"
 	"github.com/uber/ringpop-go/discovery"
 )
@@ -40,6 +43,8 @@ type (
 	Config struct {
 		// Ringpop is the ringpop related configuration
 		Ringpop Ringpop `yaml:"ringpop"`
+		// Membership selects how the hosts of the cluster discover each other
+		Membership Membership `yaml:"membership"`
 		// Persistence contains the configuration for cadence datastores
 		Persistence Persistence `yaml:"persistence"`
 		// Log is the logging config
@@ -56,11 +61,19 @@ type (
 		Archival Archival `yaml:"archival"`
 		// ElasticSearch is config for connecting to ElasticSearch
 		ElasticSearch elasticsearch.Config `yaml:"elasticsearch"`
+		// Pinot is config for connecting to the Pinot broker serving visibility queries
+		Pinot pinot.Config `yaml:"pinot"`
 		// PublicClient is config for connecting to cadence frontend
 		PublicClient PublicClient `yaml:"publicClient"`
 		// DynamicConfigClient is the config for setting up the file based dynamic config client
 		// Filepath should be relative to the root directory
 		DynamicConfigClient dynamicconfig.FileBasedClientConfig `yaml:"dynamicConfigClient"`
+		// TaskToken is the config for signing task tokens
+		TaskToken TaskToken `yaml:"taskToken"`
+		// Authentication is the config for authenticating frontend callers with bearer tokens
+		Authentication authorization.Config `yaml:"authentication"`
+		// Audit is the config for recording mutating admin and domain API calls
+		Audit audit.Config `yaml:"audit"`
 	}
 
 	// Service contains the service specific config items
@@ -73,6 +86,16 @@ type (
 		PProf PProf `yaml:"pprof"`
 	}
 
+	// TaskToken contains the config for signing task tokens
+	TaskToken struct {
+		// SigningKeys are the HMAC keys for task tokens, new tokens are signed with the first key
+		// and tokens signed with any of the keys are accepted, which allows rolling key rotation.
+		// Task tokens are not signed if no key is provided
+		SigningKeys []string `yaml:"signingKeys"`
+		// AllowUnsigned accepts tokens without signature, used while enabling signing on a running cluster
+		AllowUnsigned bool `yaml:"allowUnsigned"`
+	}
+
 	// PProf contains the rpc config items
 	PProf struct {
 		// Port is the port on which the PProf will bind to
@@ -111,6 +134,18 @@ type (
 		DiscoveryProvider discovery.DiscoverProvider `yaml:"-"`
 	}
 
+	// Membership contains the cluster membership config items
+	Membership struct {
+		// Provider is the membership implementation, one of ringpop (default) or heartbeat.
+		// The heartbeat provider records the hosts in the persistence store instead of using
+		// gossip, for environments where hosts cannot reach each other on ringpop ports
+		Provider string `yaml:"provider"`
+		// HeartbeatInterval is the interval at which a host records its heartbeat
+		HeartbeatInterval time.Duration `yaml:"heartbeatInterval"`
+		// HeartbeatTTL is the duration after which a host without heartbeat leaves the cluster
+		HeartbeatTTL time.Duration `yaml:"heartbeatTTL"`
+	}
+
 	// Persistence contains the configuration for data store / persistence layer
 	Persistence struct {
 		// DefaultStore is the name of the default data store to use
@@ -129,6 +164,36 @@ type (
 		VisibilityConfig *VisibilityConfig
 		// TransactionSizeLimit is the largest allowed transaction size
 		TransactionSizeLimit dynamicconfig.IntPropertyFn
+		// ExecutionTransactionStatementLimit is the largest allowed number of statements in a workflow execution write
+		ExecutionTransactionStatementLimit dynamicconfig.IntPropertyFn
+		// ExecutionTransactionSizeLimit is the largest allowed size of a workflow execution write
+		ExecutionTransactionSizeLimit dynamicconfig.IntPropertyFn
+		// ReadOnly serves the datastores as a restored snapshot: only the frontend service can be started,
+		// it rejects the APIs writing to the cluster and reads the executions from the execution store
+		ReadOnly bool `yaml:"readOnly"`
+		// DualWrite mirrors the writes to every store but visibility into a secondary datastore,
+		// to migrate a live cluster from the default store to another one
+		DualWrite *DualWrite `yaml:"dualWrite"`
+		// PayloadInspector scrubs or rejects the history events and search attributes before they are
+		// persisted, using the inspector registered by its name through persistence.RegisterPayloadInspector
+		PayloadInspector *PayloadInspectorConfig `yaml:"payloadInspector"`
+	}
+
+	// PayloadInspectorConfig is the configuration of a payload inspector
+	PayloadInspectorConfig struct {
+		// Name is the name the inspector is registered under
+		Name string `yaml:"name" validate:"nonzero"`
+		// Options are the inspector specific options handed to its constructor, e.g. its policy
+		Options map[string]string `yaml:"options"`
+	}
+
+	// DualWrite is the configuration for mirroring the writes into a secondary datastore
+	DualWrite struct {
+		// SecondaryStore is the name of the datastore receiving the writes after the default store
+		SecondaryStore string `yaml:"secondaryStore" validate:"nonzero"`
+		// VerifyReads reads the records also from the secondary store and reports the ones differing
+		// from the default store, the responses are always served from the default store
+		VerifyReads bool `yaml:"verifyReads"`
 	}
 
 	// DataStore is the configuration for a single datastore
@@ -137,6 +202,19 @@ type (
 		Cassandra *Cassandra `yaml:"cassandra"`
 		// SQL contains the config for a SQL based datastore
 		SQL *SQL `yaml:"sql"`
+		// CustomDataStoreConfig contains the config for a datastore registered by its name
+		// through persistence.RegisterStoreFactory
+		CustomDataStoreConfig *CustomDatastoreConfig `yaml:"customDatastore"`
+	}
+
+	// CustomDatastoreConfig is the configuration for connecting to a custom datastore
+	CustomDatastoreConfig struct {
+		// Name is the name the factory of the datastore is registered under
+		Name string `yaml:"name" validate:"nonzero"`
+		// Options are the datastore specific options handed to its factory
+		Options map[string]string `yaml:"options"`
+		// MaxQPS the max request rate on this datastore
+		MaxQPS int `yaml:"maxQPS"`
 	}
 
 	// VisibilityConfig is config for visibility sampling
@@ -145,6 +223,10 @@ type (
 		EnableSampling dynamicconfig.BoolPropertyFn
 		// EnableReadFromClosedExecutionV2 read closed from v2 table
 		EnableReadFromClosedExecutionV2 dynamicconfig.BoolPropertyFn
+		// EnableReadFromOpenExecutionsByDomain read open from the bucketed open_executions_by_domain table
+		EnableReadFromOpenExecutionsByDomain dynamicconfig.BoolPropertyFn
+		// EnableReadFromClosedExecutionsByFilter read filtered closed from the tables partitioned by type, status and workflow ID
+		EnableReadFromClosedExecutionsByFilter dynamicconfig.BoolPropertyFn
 		// VisibilityOpenMaxQPS max QPS for record open workflows
 		VisibilityOpenMaxQPS dynamicconfig.IntPropertyFnWithDomainFilter
 		// VisibilityClosedMaxQPS max QPS for record closed workflows
@@ -179,6 +261,50 @@ type (
 		MaxQPS int `yaml:"maxQPS"`
 		// MaxConns is the max number of connections to this datastore for a single keyspace
 		MaxConns int `yaml:"maxConns"`
+		// TransferTaskBuckets is the number of partitions the transfer tasks of a shard are spread over,
+		// zero keeps them in the shard partition of the executions table. A shard keeps using the count it persisted when it is lowered
+		TransferTaskBuckets int `yaml:"transferTaskBuckets"`
+		// Compression is the compression used for traffic between the gocql client and cassandra,
+		// one of snappy or lz4. Empty disables compression
+		Compression string `yaml:"compression"`
+		// OpenExecutionBuckets is the number of partitions the open executions of a domain are spread over
+		// in the open_executions_by_domain visibility table, zero means a single partition. The bucket is
+		// persisted with each open execution so that it is deleted on close after the count changed, but
+		// lowering the count hides the open executions of the removed buckets from listing until they close
+		OpenExecutionBuckets int `yaml:"openExecutionBuckets"`
+		// WriteSession overrides the settings of the session used by the execution store for
+		// conditional updates and write batches
+		WriteSession *CassandraSession `yaml:"writeSession"`
+		// ReadSession configures a separate session used by the execution store for scans, e.g.
+		// listing the runs or current executions, so heavy reads don't compete for connections
+		// with latency critical writes. Nil shares the write session. Queue reads and mutable
+		// state reads always use the write session
+		ReadSession *CassandraSession `yaml:"readSession"`
+		// ShardRanges moves the shards, executions and history events of ranges of history shards to
+		// other cassandra clusters or keyspaces, the shards out of every range stay on this one. Task
+		// lists are spread over the ranges by the shard their domain and name hash to. A range must not
+		// be reassigned while the shards it covers are owned by history hosts
+		ShardRanges []CassandraShardRange `yaml:"shardRanges"`
+	}
+
+	// CassandraShardRange assigns a range of history shards to a cassandra cluster or keyspace
+	CassandraShardRange struct {
+		// MinShardID is the first shard of the range
+		MinShardID int `yaml:"minShardID"`
+		// MaxShardID is the last shard of the range, inclusive
+		MaxShardID int `yaml:"maxShardID"`
+		// Cassandra is the cluster or keyspace the shards of the range are stored in
+		Cassandra Cassandra `yaml:"cassandra"`
+	}
+
+	// CassandraSession overrides the default settings of a cassandra session
+	CassandraSession struct {
+		// Consistency is the consistency level of the queries, e.g. LOCAL_QUORUM or LOCAL_ONE
+		Consistency string `yaml:"consistency"`
+		// Timeout is the timeout of the queries
+		Timeout time.Duration `yaml:"timeout"`
+		// MaxConns is the number of connections per host
+		MaxConns int `yaml:"maxConns"`
 	}
 
 	// SQL is the configuration for connecting to a SQL backed datastore
@@ -189,6 +315,9 @@ type (
 		Password string `yaml:"password"`
 		// DriverName is the name of SQL driver
 		DriverName string `yaml:"driverName" validate:"nonzero"`
+		// Dialect is the SQL dialect spoken by the database, either mysql (default) or cockroach.
+		// The cockroach dialect expects a postgres driver such as the one registered as postgres
+		Dialect string `yaml:"dialect"`
 		// DatabaseName is the name of SQL database to connect to
 		DatabaseName string `yaml:"databaseName" validate:"nonzero"`
 		// ConnectAddr is the remote addr of the database
@@ -235,6 +364,20 @@ type (
 		CurrentClusterName string `yaml:"currentClusterName"`
 		// ClusterInformation contains all cluster names to corresponding information about that cluster
 		ClusterInformation map[string]ClusterInformation `yaml:"clusterInformation"`
+		// ConnectionHealthCheck enables the health checks of the connections to the cluster frontends,
+		// the requests are only sent to the frontend hosts passing them
+		ConnectionHealthCheck *ClusterConnectionHealthCheck `yaml:"connectionHealthCheck"`
+	}
+
+	// ClusterConnectionHealthCheck is the configuration of the health checks of the connections to the cluster frontends
+	ClusterConnectionHealthCheck struct {
+		// Interval is the interval between the health checks of a frontend host. Default to 5s
+		Interval time.Duration `yaml:"interval"`
+		// Timeout is the timeout of a single health check. Default to 1s
+		Timeout time.Duration `yaml:"timeout"`
+		// UnhealthyThreshold is the number of consecutive failed health checks after which
+		// a frontend host stops receiving requests. Default to 3
+		UnhealthyThreshold int `yaml:"unhealthyThreshold"`
 	}
 
 	// ClusterInformation contains the information about each cluster which participated in cross DC
@@ -251,6 +394,8 @@ type (
 	DCRedirectionPolicy struct {
 		Policy string `yaml:"policy"`
 		ToDC   string `yaml:"toDC"`
+		// AllowedAPIs are the APIs forwarded to the active cluster of the domain by the allowed-apis-forwarding policy
+		AllowedAPIs []string `yaml:"allowedAPIs"`
 	}
 
 	// Metrics contains the config items for metrics subsystem
@@ -293,6 +438,9 @@ type (
 		Filestore *filestore.Config `yaml:"filestore"`
 		// S3store the configuration for amazon s3 based blobstore
 		S3store *s3store.Config `yaml:"s3store"`
+		// EncryptionKeys maps the encryption key aliases domains can use to base64 encoded 256 bit keys,
+		// domains can only be configured with an encryption key alias when keys are configured
+		EncryptionKeys map[string]string `yaml:"encryptionKeys"`
 	}
 
 	// PublicClient is config for connecting to cadence frontend
@@ -307,6 +455,13 @@ type (
 	BootstrapMode int
 )
 
+const (
+	// MembershipProviderRingpop discovers the cluster members through ringpop gossip
+	MembershipProviderRingpop = "ringpop"
+	// MembershipProviderHeartbeat discovers the cluster members through heartbeats in the persistence store
+	MembershipProviderHeartbeat = "heartbeat"
+)
+
 // Validate validates this config
 func (c *Config) Validate() error {
 	return c.Persistence.Validate()
@@ -20,53 +20,160 @@
 
 package config
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+)
 
 const (
 	// StoreTypeSQL refers to sql based storage as persistence store
 	StoreTypeSQL = "sql"
 	// StoreTypeCassandra refers to cassandra as persistence store
 	StoreTypeCassandra = "cassandra"
+	// StoreTypeCustom refers to a custom datastore registered by name as persistence store
+	StoreTypeCustom = "custom"
 )
 
+const (
+	// CassandraCompressionSnappy refers to snappy compression of cassandra client-server traffic
+	CassandraCompressionSnappy = "snappy"
+	// CassandraCompressionLZ4 refers to lz4 compression of cassandra client-server traffic
+	CassandraCompressionLZ4 = "lz4"
+)
+
+// cassandraConsistencies are the consistency levels accepted by the cassandra session settings,
+// mapped to whether they read the latest write of a LOCAL_QUORUM writer
+var cassandraConsistencies = map[string]bool{
+	"ANY":          false,
+	"ONE":          false,
+	"TWO":          false,
+	"THREE":        false,
+	"QUORUM":       true,
+	"ALL":          true,
+	"LOCAL_QUORUM": true,
+	"EACH_QUORUM":  true,
+	"LOCAL_ONE":    false,
+}
+
 // SetMaxQPS sets the MaxQPS value for the given datastore
 func (c *Persistence) SetMaxQPS(key string, qps int) {
 	ds, ok := c.DataStores[key]
 	if !ok {
 		return
 	}
-	if ds.Cassandra != nil {
+	switch {
+	case ds.Cassandra != nil:
 		ds.Cassandra.MaxQPS = qps
-		return
+	case ds.SQL != nil:
+		ds.SQL.MaxQPS = qps
+	case ds.CustomDataStoreConfig != nil:
+		ds.CustomDataStoreConfig.MaxQPS = qps
 	}
-	ds.SQL.MaxQPS = qps
 }
 
 // DefaultStoreType returns the storeType for the default persistence store
 func (c *Persistence) DefaultStoreType() string {
-	if c.DataStores[c.DefaultStore].SQL != nil {
+	ds := c.DataStores[c.DefaultStore]
+	if ds.SQL != nil {
 		return StoreTypeSQL
 	}
+	if ds.CustomDataStoreConfig != nil {
+		return StoreTypeCustom
+	}
 	return StoreTypeCassandra
 }
 
 // Validate validates the persistence config
 func (c *Persistence) Validate() error {
 	stores := []string{c.DefaultStore, c.VisibilityStore}
+	if c.DualWrite != nil {
+		if c.DualWrite.SecondaryStore == "" || c.DualWrite.SecondaryStore == c.DefaultStore {
+			return fmt.Errorf("persistence config: dual write secondary store must differ from the default store")
+		}
+		stores = append(stores, c.DualWrite.SecondaryStore)
+	}
 	for _, st := range stores {
 		ds, ok := c.DataStores[st]
 		if !ok {
 			return fmt.Errorf("persistence config: missing config for datastore %v", st)
 		}
-		if ds.SQL == nil && ds.Cassandra == nil {
-			return fmt.Errorf("persistence config: datastore %v: must provide config for one of cassandra or sql stores", st)
+		numConfigured := ds.numConfigured()
+		if numConfigured == 0 {
+			return fmt.Errorf("persistence config: datastore %v: must provide config for one of cassandra, sql or custom stores", st)
+		}
+		if numConfigured > 1 {
+			return fmt.Errorf("persistence config: datastore %v: only one of SQL, cassandra or custom can be specified", st)
 		}
-		if ds.SQL != nil && ds.Cassandra != nil {
-			return fmt.Errorf("persistence config: datastore %v: only one of SQL or cassandra can be specified", st)
+		if ds.CustomDataStoreConfig != nil && ds.CustomDataStoreConfig.Name == "" {
+			return fmt.Errorf("persistence config: datastore %v: custom datastore name is required", st)
 		}
 		if ds.SQL != nil && ds.SQL.NumShards == 0 {
 			ds.SQL.NumShards = 1
 		}
+		if ds.Cassandra != nil {
+			if err := ds.Cassandra.validate(); err != nil {
+				return fmt.Errorf("persistence config: datastore %v: %v", st, err)
+			}
+		}
+	}
+	return nil
+}
+
+// numConfigured returns the number of store configs set on the datastore
+func (ds DataStore) numConfigured() int {
+	count := 0
+	if ds.Cassandra != nil {
+		count++
+	}
+	if ds.SQL != nil {
+		count++
+	}
+	if ds.CustomDataStoreConfig != nil {
+		count++
+	}
+	return count
+}
+
+func (c *Cassandra) validate() error {
+	switch c.Compression {
+	case "", CassandraCompressionSnappy, CassandraCompressionLZ4:
+	default:
+		return fmt.Errorf("unknown cassandra compression %v", c.Compression)
+	}
+	for _, session := range []*CassandraSession{c.WriteSession, c.ReadSession} {
+		if session == nil || session.Consistency == "" {
+			continue
+		}
+		quorum, ok := cassandraConsistencies[strings.ToUpper(session.Consistency)]
+		if !ok {
+			return fmt.Errorf("unknown cassandra consistency %v", session.Consistency)
+		}
+		// the write session also serves the queue and mutable state reads
+		if session == c.WriteSession && !quorum {
+			return fmt.Errorf("cassandra write session consistency %v is weaker than LOCAL_QUORUM", session.Consistency)
+		}
+		if session == c.ReadSession && strings.EqualFold(session.Consistency, "ANY") {
+			return fmt.Errorf("cassandra read session consistency %v is write only", session.Consistency)
+		}
+	}
+	for i, r := range c.ShardRanges {
+		if r.MinShardID < 0 || r.MaxShardID < r.MinShardID {
+			return fmt.Errorf("invalid shard range [%v, %v]", r.MinShardID, r.MaxShardID)
+		}
+		for _, other := range c.ShardRanges[:i] {
+			if r.MinShardID <= other.MaxShardID && other.MinShardID <= r.MaxShardID {
+				return fmt.Errorf("shard range [%v, %v] overlaps [%v, %v]", r.MinShardID, r.MaxShardID, other.MinShardID, other.MaxShardID)
+			}
+		}
+		if r.Cassandra.Hosts == "" || r.Cassandra.Keyspace == "" {
+			return fmt.Errorf("shard range [%v, %v]: hosts and keyspace are required", r.MinShardID, r.MaxShardID)
+		}
+		if len(r.Cassandra.ShardRanges) > 0 {
+			return fmt.Errorf("shard range [%v, %v]: shard ranges can't be nested", r.MinShardID, r.MaxShardID)
+		}
+		if err := r.Cassandra.validate(); err != nil {
+			return fmt.Errorf("shard range [%v, %v]: %v", r.MinShardID, r.MaxShardID, err)
+		}
 	}
 	return nil
 }
@@ -0,0 +1,141 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type PersistenceSuite struct {
+	*require.Assertions
+	suite.Suite
+}
+
+func TestPersistenceSuite(t *testing.T) {
+	suite.Run(t, new(PersistenceSuite))
+}
+
+func (s *PersistenceSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+}
+
+func (s *PersistenceSuite) TestValidateCassandraCompression() {
+	for _, compression := range []string{"", CassandraCompressionSnappy, CassandraCompressionLZ4} {
+		s.NoError(s.newCassandraPersistence(compression).Validate())
+	}
+	s.Error(s.newCassandraPersistence("gzip").Validate())
+}
+
+func (s *PersistenceSuite) TestValidateCassandraSessionConsistency() {
+	for _, consistency := range []string{"", "LOCAL_QUORUM", "local_one"} {
+		cfg := s.newCassandraPersistence("")
+		cfg.DataStores["default"].Cassandra.ReadSession = &CassandraSession{Consistency: consistency}
+		s.NoError(cfg.Validate())
+	}
+	for _, consistency := range []string{"LOCAL_SERIAL", "LOCAL_ONE", "one"} {
+		cfg := s.newCassandraPersistence("")
+		cfg.DataStores["default"].Cassandra.WriteSession = &CassandraSession{Consistency: consistency}
+		s.Error(cfg.Validate())
+	}
+	cfg := s.newCassandraPersistence("")
+	cfg.DataStores["default"].Cassandra.ReadSession = &CassandraSession{Consistency: "ANY"}
+	s.Error(cfg.Validate())
+}
+
+func (s *PersistenceSuite) TestValidateCassandraShardRanges() {
+	shardRange := func(minShardID, maxShardID int) CassandraShardRange {
+		return CassandraShardRange{
+			MinShardID: minShardID,
+			MaxShardID: maxShardID,
+			Cassandra:  Cassandra{Hosts: "127.0.0.2", Keyspace: "cadence"},
+		}
+	}
+	cfg := s.newCassandraPersistence("")
+	cfg.DataStores["default"].Cassandra.ShardRanges = []CassandraShardRange{shardRange(0, 1023), shardRange(1024, 2047)}
+	s.NoError(cfg.Validate())
+
+	cfg.DataStores["default"].Cassandra.ShardRanges = []CassandraShardRange{shardRange(0, 1024), shardRange(1024, 2047)}
+	s.Error(cfg.Validate())
+
+	cfg.DataStores["default"].Cassandra.ShardRanges = []CassandraShardRange{shardRange(10, 9)}
+	s.Error(cfg.Validate())
+
+	cfg.DataStores["default"].Cassandra.ShardRanges = []CassandraShardRange{{MinShardID: 0, MaxShardID: 9}}
+	s.Error(cfg.Validate())
+}
+
+func (s *PersistenceSuite) TestValidateCustomDatastore() {
+	cfg := &Persistence{
+		DefaultStore:    "default",
+		VisibilityStore: "default",
+		DataStores: map[string]DataStore{
+			"default": {
+				CustomDataStoreConfig: &CustomDatastoreConfig{Name: "test", Options: map[string]string{"key": "value"}},
+			},
+		},
+	}
+	s.NoError(cfg.Validate())
+	s.Equal(StoreTypeCustom, cfg.DefaultStoreType())
+	cfg.SetMaxQPS("default", 100)
+	s.Equal(100, cfg.DataStores["default"].CustomDataStoreConfig.MaxQPS)
+
+	cfg.DataStores["default"].CustomDataStoreConfig.Name = ""
+	s.Error(cfg.Validate())
+
+	cfg = s.newCassandraPersistence("")
+	cfg.DataStores["default"] = DataStore{
+		Cassandra:             cfg.DataStores["default"].Cassandra,
+		CustomDataStoreConfig: &CustomDatastoreConfig{Name: "test"},
+	}
+	s.Error(cfg.Validate())
+}
+
+func (s *PersistenceSuite) TestValidateDualWrite() {
+	cfg := s.newCassandraPersistence("")
+	cfg.DualWrite = &DualWrite{SecondaryStore: "sql"}
+	s.Error(cfg.Validate())
+
+	cfg.DataStores["sql"] = DataStore{SQL: &SQL{DriverName: "mysql", DatabaseName: "cadence"}}
+	s.NoError(cfg.Validate())
+	s.Equal(1, cfg.DataStores["sql"].SQL.NumShards)
+
+	cfg.DualWrite.SecondaryStore = cfg.DefaultStore
+	s.Error(cfg.Validate())
+}
+
+func (s *PersistenceSuite) newCassandraPersistence(compression string) *Persistence {
+	return &Persistence{
+		DefaultStore:    "default",
+		VisibilityStore: "default",
+		DataStores: map[string]DataStore{
+			"default": {
+				Cassandra: &Cassandra{
+					Hosts:       "127.0.0.1",
+					Keyspace:    "cadence",
+					Compression: compression,
+				},
+			},
+		},
+	}
+}
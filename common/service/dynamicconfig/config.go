@@ -102,6 +102,15 @@ type BoolPropertyFnWithDomainFilter func(domain string) bool
 // BoolPropertyFnWithTaskListInfoFilters is a wrapper to get bool property from dynamic config with three filters: domain, taskList, taskType
 type BoolPropertyFnWithTaskListInfoFilters func(domain string, taskList string, taskType int) bool
 
+// IntPropertyFnWithActivityTypeFilters is a wrapper to get int property from dynamic config with two filters: domain, activityType
+type IntPropertyFnWithActivityTypeFilters func(domain string, activityType string) int
+
+// DurationPropertyFnWithActivityTypeFilters is a wrapper to get duration property from dynamic config with two filters: domain, activityType
+type DurationPropertyFnWithActivityTypeFilters func(domain string, activityType string) time.Duration
+
+// StringPropertyFnWithActivityTypeFilters is a wrapper to get string property from dynamic config with two filters: domain, activityType
+type StringPropertyFnWithActivityTypeFilters func(domain string, activityType string) string
+
 // GetProperty gets a interface property and returns defaultValue if property is not found
 func (c *Collection) GetProperty(key Key, defaultValue interface{}) PropertyFn {
 	return func() interface{} {
@@ -312,3 +321,51 @@ func (c *Collection) GetBoolPropertyFilteredByTaskListInfo(key Key, defaultValue
 		return val
 	}
 }
+
+// GetIntPropertyFilteredByActivityType gets property with domain and activity type as filters and asserts that it's an integer
+func (c *Collection) GetIntPropertyFilteredByActivityType(key Key, defaultValue int) IntPropertyFnWithActivityTypeFilters {
+	return func(domain string, activityType string) int {
+		val, err := c.client.GetIntValue(
+			key,
+			getFilterMap(DomainFilter(domain), ActivityTypeFilter(activityType)),
+			defaultValue,
+		)
+		if err != nil {
+			c.logNoValue(key, err)
+		}
+		c.logValue(key, val, defaultValue)
+		return val
+	}
+}
+
+// GetDurationPropertyFilteredByActivityType gets property with domain and activity type as filters and asserts that it's a duration
+func (c *Collection) GetDurationPropertyFilteredByActivityType(key Key, defaultValue time.Duration) DurationPropertyFnWithActivityTypeFilters {
+	return func(domain string, activityType string) time.Duration {
+		val, err := c.client.GetDurationValue(
+			key,
+			getFilterMap(DomainFilter(domain), ActivityTypeFilter(activityType)),
+			defaultValue,
+		)
+		if err != nil {
+			c.logNoValue(key, err)
+		}
+		c.logValue(key, val, defaultValue)
+		return val
+	}
+}
+
+// GetStringPropertyFilteredByActivityType gets property with domain and activity type as filters and asserts that it's a string
+func (c *Collection) GetStringPropertyFilteredByActivityType(key Key, defaultValue string) StringPropertyFnWithActivityTypeFilters {
+	return func(domain string, activityType string) string {
+		val, err := c.client.GetStringValue(
+			key,
+			getFilterMap(DomainFilter(domain), ActivityTypeFilter(activityType)),
+			defaultValue,
+		)
+		if err != nil {
+			c.logNoValue(key, err)
+		}
+		c.logValue(key, val, defaultValue)
+		return val
+	}
+}
@@ -230,6 +230,36 @@ func (s *configSuite) TestGetDurationPropertyFilteredByTaskListInfo() {
 	s.Equal(time.Minute, value(domain, taskList, taskType))
 }
 
+func (s *configSuite) TestGetIntPropertyFilteredByActivityType() {
+	key := testGetIntPropertyFilteredByActivityTypeKey
+	domain := "testDomain"
+	activityType := "testActivityType"
+	value := s.cln.GetIntPropertyFilteredByActivityType(key, 10)
+	s.Equal(10, value(domain, activityType))
+	s.client.SetValue(key, 50)
+	s.Equal(50, value(domain, activityType))
+}
+
+func (s *configSuite) TestGetDurationPropertyFilteredByActivityType() {
+	key := testGetDurationPropertyFilteredByActivityTypeKey
+	domain := "testDomain"
+	activityType := "testActivityType"
+	value := s.cln.GetDurationPropertyFilteredByActivityType(key, time.Second)
+	s.Equal(time.Second, value(domain, activityType))
+	s.client.SetValue(key, time.Minute)
+	s.Equal(time.Minute, value(domain, activityType))
+}
+
+func (s *configSuite) TestGetStringPropertyFilteredByActivityType() {
+	key := testGetStringPropertyFilteredByActivityTypeKey
+	domain := "testDomain"
+	activityType := "testActivityType"
+	value := s.cln.GetStringPropertyFilteredByActivityType(key, "abc")
+	s.Equal("abc", value(domain, activityType))
+	s.client.SetValue(key, "efg")
+	s.Equal("efg", value(domain, activityType))
+}
+
 func (s *configSuite) TestGetMapProperty() {
 	key := testGetMapPropertyKey
 	val := map[string]interface{}{
@@ -48,39 +48,58 @@ var keys = map[Key]string{
 	testGetIntPropertyFilteredByTaskListInfoKey:      "testGetIntPropertyFilteredByTaskListInfoKey",
 	testGetDurationPropertyFilteredByTaskListInfoKey: "testGetDurationPropertyFilteredByTaskListInfoKey",
 	testGetBoolPropertyFilteredByTaskListInfoKey:     "testGetBoolPropertyFilteredByTaskListInfoKey",
+	testGetIntPropertyFilteredByActivityTypeKey:      "testGetIntPropertyFilteredByActivityTypeKey",
+	testGetDurationPropertyFilteredByActivityTypeKey: "testGetDurationPropertyFilteredByActivityTypeKey",
+	testGetStringPropertyFilteredByActivityTypeKey:   "testGetStringPropertyFilteredByActivityTypeKey",
 
 	// system settings
-	EnableGlobalDomain:                  "system.enableGlobalDomain",
-	EnableNewKafkaClient:                "system.enableNewKafkaClient",
-	EnableVisibilitySampling:            "system.enableVisibilitySampling",
-	EnableReadFromClosedExecutionV2:     "system.enableReadFromClosedExecutionV2",
-	EnableVisibilityToKafka:             "system.enableVisibilityToKafka",
-	EnableReadVisibilityFromES:          "system.enableReadVisibilityFromES",
-	ArchivalStatus:                      "system.archivalStatus",
-	EnableReadFromArchival:              "system.enableReadFromArchival",
-	EnableDomainNotActiveAutoForwarding: "system.enableDomainNotActiveAutoForwarding",
-	TransactionSizeLimit:                "system.transactionSizeLimit",
-	MinRetentionDays:                    "system.minRetentionDays",
-	EnableBatcher:                       "worker.enableBatcher",
+	EnableGlobalDomain:                     "system.enableGlobalDomain",
+	EnableNewKafkaClient:                   "system.enableNewKafkaClient",
+	EnableVisibilitySampling:               "system.enableVisibilitySampling",
+	EnableReadFromClosedExecutionV2:        "system.enableReadFromClosedExecutionV2",
+	EnableReadFromOpenExecutionsByDomain:   "system.enableReadFromOpenExecutionsByDomain",
+	EnableReadFromClosedExecutionsByFilter: "system.enableReadFromClosedExecutionsByFilter",
+	EnableVisibilityToKafka:                "system.enableVisibilityToKafka",
+	EnableReadVisibilityFromES:             "system.enableReadVisibilityFromES",
+	EnableReadVisibilityFromPinot:          "system.enableReadVisibilityFromPinot",
+	ArchivalStatus:                         "system.archivalStatus",
+	EnableReadFromArchival:                 "system.enableReadFromArchival",
+	EnableDomainNotActiveAutoForwarding:    "system.enableDomainNotActiveAutoForwarding",
+	EnableStandbyReadForwarding:            "system.enableStandbyReadForwarding",
+	StandbyReadForwardingTimeout:           "system.standbyReadForwardingTimeout",
+	TransactionSizeLimit:                   "system.transactionSizeLimit",
+	ExecutionTransactionStatementLimit:     "system.executionTransactionStatementLimit",
+	ExecutionTransactionSizeLimit:          "system.executionTransactionSizeLimit",
+	MinRetentionDays:                       "system.minRetentionDays",
+	EnableBatcher:                          "worker.enableBatcher",
+	EnableCanary:                           "worker.enableCanary",
+	EnableScheduler:                        "worker.enableScheduler",
+	SchedulerScanInterval:                  "worker.schedulerScanInterval",
 
 	// size limit
-	BlobSizeLimitError:     "limit.blobSize.error",
-	BlobSizeLimitWarn:      "limit.blobSize.warn",
-	HistorySizeLimitError:  "limit.historySize.error",
-	HistorySizeLimitWarn:   "limit.historySize.warn",
-	HistoryCountLimitError: "limit.historyCount.error",
-	HistoryCountLimitWarn:  "limit.historyCount.warn",
-	MaxIDLengthLimit:       "limit.maxIDLength",
+	BlobSizeLimitError:               "limit.blobSize.error",
+	BlobSizeLimitWarn:                "limit.blobSize.warn",
+	HistorySizeLimitError:            "limit.historySize.error",
+	HistorySizeLimitWarn:             "limit.historySize.warn",
+	HistoryCountLimitError:           "limit.historyCount.error",
+	HistoryCountLimitWarn:            "limit.historyCount.warn",
+	HistoryLimitTerminate:            "limit.history.terminate",
+	HistorySizeSuggestContinueAsNew:  "limit.historySize.suggestContinueAsNew",
+	HistoryCountSuggestContinueAsNew: "limit.historyCount.suggestContinueAsNew",
+	MaxIDLengthLimit:                 "limit.maxIDLength",
 
 	// frontend settings
 	FrontendPersistenceMaxQPS:         "frontend.persistenceMaxQPS",
 	FrontendVisibilityMaxPageSize:     "frontend.visibilityMaxPageSize",
 	FrontendVisibilityListMaxQPS:      "frontend.visibilityListMaxQPS",
 	FrontendESVisibilityListMaxQPS:    "frontend.esVisibilityListMaxQPS",
+	FrontendPinotVisibilityListMaxQPS: "frontend.pinotVisibilityListMaxQPS",
 	FrontendMaxBadBinaries:            "frontend.maxBadBinaries",
 	FrontendESIndexMaxResultWindow:    "frontend.esIndexMaxResultWindow",
 	FrontendHistoryMaxPageSize:        "frontend.historyMaxPageSize",
 	FrontendRPS:                       "frontend.rps",
+	FrontendDomainRPS:                 "frontend.domainrps",
+	FrontendGlobalDomainRPS:           "frontend.globalDomainrps",
 	FrontendHistoryMgrNumConns:        "frontend.historyMgrNumConns",
 	MaxDecisionStartToCloseTimeout:    "frontend.maxDecisionStartToCloseTimeout",
 	DisableListVisibilityByFilter:     "frontend.disableListVisibilityByFilter",
@@ -90,12 +109,19 @@ var keys = map[Key]string{
 	SearchAttributesNumberOfKeysLimit: "frontend.searchAttributesNumberOfKeysLimit",
 	SearchAttributesSizeOfValueLimit:  "frontend.searchAttributesSizeOfValueLimit",
 	SearchAttributesTotalSizeLimit:    "frontend.searchAttributesTotalSizeLimit",
+	StickyQueryTimeout:                "frontend.stickyQueryTimeout",
+	StickyQueryPollerLivenessWindow:   "frontend.stickyQueryPollerLivenessWindow",
+	StickyQueryPollerCheckTimeout:     "frontend.stickyQueryPollerCheckTimeout",
+	FrontendMetadataCacheTTL:          "frontend.metadataCacheTTL",
+	FrontendMetadataCacheMaxSize:      "frontend.metadataCacheMaxSize",
+	FrontendRawHistoryMaxPageBytes:    "frontend.rawHistoryMaxPageBytes",
 
 	// matching settings
 	MatchingRPS:                             "matching.rps",
 	MatchingPersistenceMaxQPS:               "matching.persistenceMaxQPS",
 	MatchingMinTaskThrottlingBurstSize:      "matching.minTaskThrottlingBurstSize",
 	MatchingGetTasksBatchSize:               "matching.getTasksBatchSize",
+	MatchingTaskBufferLowWatermark:          "matching.taskBufferLowWatermark",
 	MatchingLongPollExpirationInterval:      "matching.longPollExpirationInterval",
 	MatchingEnableSyncMatch:                 "matching.enableSyncMatch",
 	MatchingUpdateAckInterval:               "matching.updateAckInterval",
@@ -103,6 +129,7 @@ var keys = map[Key]string{
 	MaxTasklistIdleTime:                     "matching.maxTasklistIdleTime",
 	MatchingOutstandingTaskAppendsThreshold: "matching.outstandingTaskAppendsThreshold",
 	MatchingMaxTaskBatchSize:                "matching.maxTaskBatchSize",
+	MatchingTaskBatchWindow:                 "matching.taskBatchWindow",
 	MatchingMaxTaskDeleteBatchSize:          "matching.maxTaskDeleteBatchSize",
 	MatchingThrottledLogRPS:                 "matching.throttledLogRPS",
 
@@ -116,12 +143,28 @@ var keys = map[Key]string{
 	HistoryMaxAutoResetPoints:                             "history.historyMaxAutoResetPoints",
 	HistoryCacheMaxSize:                                   "history.cacheMaxSize",
 	HistoryCacheTTL:                                       "history.cacheTTL",
+	HistoryCacheWarmupEnabled:                             "history.cacheWarmupEnabled",
+	HistoryCacheWarmupSize:                                "history.cacheWarmupSize",
+	HistoryCacheWarmupRecordInterval:                      "history.cacheWarmupRecordInterval",
 	EventsCacheInitialSize:                                "history.eventsCacheInitialSize",
 	EventsCacheMaxSize:                                    "history.eventsCacheMaxSize",
 	EventsCacheTTL:                                        "history.eventsCacheTTL",
+	ClientVersionTrackerMaxSize:                           "history.clientVersionTrackerMaxSize",
 	AcquireShardInterval:                                  "history.acquireShardInterval",
+	AcquireShardConcurrency:                               "history.acquireShardConcurrency",
+	AcquireShardJitter:                                    "history.acquireShardJitter",
+	ShardLeaseRenewInterval:                               "history.shardLeaseRenewInterval",
+	ShardLeaseRenewJitter:                                 "history.shardLeaseRenewJitter",
+	ShardLeaseTTL:                                         "history.shardLeaseTTL",
+	ShardLeaseStealTimeout:                                "history.shardLeaseStealTimeout",
+	EnableLoadAwareShardPlacement:                         "history.enableLoadAwareShardPlacement",
+	ShardPlacementRefreshInterval:                         "history.shardPlacementRefreshInterval",
+	ShardPlacementLoadTolerance:                           "history.shardPlacementLoadTolerance",
 	StandbyClusterDelay:                                   "history.standbyClusterDelay",
 	TimerTaskBatchSize:                                    "history.timerTaskBatchSize",
+	TimerTaskMinBatchSize:                                 "history.timerTaskMinBatchSize",
+	TimerTaskMaxBatchSize:                                 "history.timerTaskMaxBatchSize",
+	TimerProcessorReadLatencyTarget:                       "history.timerProcessorReadLatencyTarget",
 	TimerTaskWorkerCount:                                  "history.timerTaskWorkerCount",
 	TimerTaskMaxRetryCount:                                "history.timerTaskMaxRetryCount",
 	TimerProcessorStartDelay:                              "history.timerProcessorStartDelay",
@@ -137,7 +180,14 @@ var keys = map[Key]string{
 	TimerProcessorMaxPollInterval:                         "history.timerProcessorMaxPollInterval",
 	TimerProcessorMaxPollIntervalJitterCoefficient:        "history.timerProcessorMaxPollIntervalJitterCoefficient",
 	TimerProcessorMaxTimeShift:                            "history.timerProcessorMaxTimeShift",
+	UserTimerCoalescingWindow:                             "history.userTimerCoalescingWindow",
+	RetentionSweepInterval:                                "history.retentionSweepInterval",
+	RetentionSweepLookbackDays:                            "history.retentionSweepLookbackDays",
+	RetentionSweepBatchSize:                               "history.retentionSweepBatchSize",
 	TransferTaskBatchSize:                                 "history.transferTaskBatchSize",
+	TransferTaskMinBatchSize:                              "history.transferTaskMinBatchSize",
+	TransferTaskMaxBatchSize:                              "history.transferTaskMaxBatchSize",
+	TransferProcessorReadLatencyTarget:                    "history.transferProcessorReadLatencyTarget",
 	TransferProcessorFailoverMaxPollRPS:                   "history.transferProcessorFailoverMaxPollRPS",
 	TransferProcessorMaxPollRPS:                           "history.transferProcessorMaxPollRPS",
 	TransferTaskWorkerCount:                               "history.transferTaskWorkerCount",
@@ -151,6 +201,7 @@ var keys = map[Key]string{
 	TransferProcessorUpdateAckInterval:                    "history.transferProcessorUpdateAckInterval",
 	TransferProcessorUpdateAckIntervalJitterCoefficient:   "history.transferProcessorUpdateAckIntervalJitterCoefficient",
 	TransferProcessorCompleteTransferInterval:             "history.transferProcessorCompleteTransferInterval",
+	StuckQueueAlarmThreshold:                              "history.stuckQueueAlarmThreshold",
 	ReplicatorTaskBatchSize:                               "history.replicatorTaskBatchSize",
 	ReplicatorTaskWorkerCount:                             "history.replicatorTaskWorkerCount",
 	ReplicatorTaskMaxRetryCount:                           "history.replicatorTaskMaxRetryCount",
@@ -161,20 +212,41 @@ var keys = map[Key]string{
 	ReplicatorProcessorMaxPollIntervalJitterCoefficient:   "history.replicatorProcessorMaxPollIntervalJitterCoefficient",
 	ReplicatorProcessorUpdateAckInterval:                  "history.replicatorProcessorUpdateAckInterval",
 	ReplicatorProcessorUpdateAckIntervalJitterCoefficient: "history.replicatorProcessorUpdateAckIntervalJitterCoefficient",
+	ReplicatorRawHistoryPassthrough:                       "history.replicatorRawHistoryPassthrough",
+	ReplicatorPublishBatchSize:                            "history.replicatorPublishBatchSize",
+	ReplicatorPublishBatchMaxDelay:                        "history.replicatorPublishBatchMaxDelay",
+	ReplicatorPublishMaxInFlightBatches:                   "history.replicatorPublishMaxInFlightBatches",
+	EnableVisibilityTaskQueue:                             "history.enableVisibilityTaskQueue",
+	VisibilityTaskBatchSize:                               "history.visibilityTaskBatchSize",
+	VisibilityTaskWorkerCount:                             "history.visibilityTaskWorkerCount",
+	VisibilityTaskMaxRetryCount:                           "history.visibilityTaskMaxRetryCount",
+	VisibilityProcessorStartDelay:                         "history.visibilityProcessorStartDelay",
+	VisibilityProcessorMaxPollRPS:                         "history.visibilityProcessorMaxPollRPS",
+	VisibilityProcessorMaxPollInterval:                    "history.visibilityProcessorMaxPollInterval",
+	VisibilityProcessorMaxPollIntervalJitterCoefficient:   "history.visibilityProcessorMaxPollIntervalJitterCoefficient",
+	VisibilityProcessorUpdateAckInterval:                  "history.visibilityProcessorUpdateAckInterval",
+	VisibilityProcessorUpdateAckIntervalJitterCoefficient: "history.visibilityProcessorUpdateAckIntervalJitterCoefficient",
 	ExecutionMgrNumConns:                                  "history.executionMgrNumConns",
 	HistoryMgrNumConns:                                    "history.historyMgrNumConns",
 	MaximumBufferedEventsBatch:                            "history.maximumBufferedEventsBatch",
 	MaximumSignalsPerExecution:                            "history.maximumSignalsPerExecution",
+	MaximumHistoryBranchesPerExecution:                    "history.maximumHistoryBranchesPerExecution",
 	ShardUpdateMinInterval:                                "history.shardUpdateMinInterval",
+	ShardTaskThroughputWindow:                             "history.shardTaskThroughputWindow",
 	ShardSyncMinInterval:                                  "history.shardSyncMinInterval",
 	DefaultEventEncoding:                                  "history.defaultEventEncoding",
 	EnableAdminProtection:                                 "history.enableAdminProtection",
 	AdminOperationToken:                                   "history.adminOperationToken",
 	EnableEventsV2:                                        "history.enableEventsV2",
+	EnableTransientDecisionHeartbeat:                      "history.enableTransientDecisionHeartbeat",
 	NumArchiveSystemWorkflows:                             "history.numArchiveSystemWorkflows",
 	ArchiveRequestRPS:                                     "history.archiveRequestRPS",
 	EmitShardDiffLog:                                      "history.emitShardDiffLog",
 	HistoryThrottledLogRPS:                                "history.throttledLogRPS",
+	ActivityRetryMaximumAttemptsOverride:                  "history.activityRetryMaximumAttemptsOverride",
+	ActivityRetryMaximumIntervalOverride:                  "history.activityRetryMaximumIntervalOverride",
+	ActivityRetryNonRetriableErrorsOverride:               "history.activityRetryNonRetriableErrorsOverride",
+	DomainExecutionsFrozen:                                "history.domainExecutionsFrozen",
 
 	WorkerPersistenceMaxQPS:                         "worker.persistenceMaxQPS",
 	WorkerReplicatorMetaTaskConcurrency:             "worker.replicatorMetaTaskConcurrency",
@@ -199,6 +271,9 @@ var keys = map[Key]string{
 	WorkerTimeLimitPerArchivalIteration:             "worker.TimeLimitPerArchivalIteration",
 	WorkerThrottledLogRPS:                           "worker.throttledLogRPS",
 	ScannerPersistenceMaxQPS:                        "worker.scannerPersistenceMaxQPS",
+	ScannerBufferedReplicationTasksPurgeEnabled:     "worker.scannerBufferedReplicationTasksPurgeEnabled",
+	ScannerCurrentExecutionsFixEnabled:              "worker.scannerCurrentExecutionsFixEnabled",
+	ScannerOpenExecutionCountsReconcileEnabled:      "worker.scannerOpenExecutionCountsReconcileEnabled",
 }
 
 const (
@@ -217,6 +292,9 @@ const (
 	testGetIntPropertyFilteredByTaskListInfoKey
 	testGetDurationPropertyFilteredByTaskListInfoKey
 	testGetBoolPropertyFilteredByTaskListInfoKey
+	testGetIntPropertyFilteredByActivityTypeKey
+	testGetDurationPropertyFilteredByActivityTypeKey
+	testGetStringPropertyFilteredByActivityTypeKey
 
 	// EnableGlobalDomain is key for enable global domain
 	EnableGlobalDomain
@@ -226,12 +304,19 @@ const (
 	EnableVisibilitySampling
 	// EnableReadFromClosedExecutionV2 is key for enable read from cadence_visibility.closed_executions_v2
 	EnableReadFromClosedExecutionV2
+	// EnableReadFromOpenExecutionsByDomain is key for enable read from cadence_visibility.open_executions_by_domain
+	EnableReadFromOpenExecutionsByDomain
+	// EnableReadFromClosedExecutionsByFilter is key for enable read from the cadence_visibility closed executions tables
+	// partitioned by workflow type, close status and workflow ID
+	EnableReadFromClosedExecutionsByFilter
 	// EnableVisibilityToKafka is key for enable kafka
 	EnableVisibilityToKafka
 	// EmitShardDiffLog whether emit the shard diff log
 	EmitShardDiffLog
 	// EnableReadVisibilityFromES is key for enable read from elastic search
 	EnableReadVisibilityFromES
+	// EnableReadVisibilityFromPinot is key for enable read from pinot
+	EnableReadVisibilityFromPinot
 	// DisableListVisibilityByFilter is config to disable list open/close workflow using filter
 	DisableListVisibilityByFilter
 	// ArchivalStatus is key for the status of archival
@@ -241,8 +326,18 @@ const (
 	// EnableDomainNotActiveAutoForwarding whether enabling DC auto forwarding to active cluster
 	// for signal / start / signal with start API if domain is not active
 	EnableDomainNotActiveAutoForwarding
+	// EnableStandbyReadForwarding whether describe / query calls for a domain not active in the current
+	// cluster are forwarded to the active cluster, falling back to the current cluster if it cannot be reached
+	EnableStandbyReadForwarding
+	// StandbyReadForwardingTimeout is the timeout of a describe / query call forwarded to the active cluster,
+	// it is capped to half of the deadline of the call so that the current cluster has time to serve it
+	StandbyReadForwardingTimeout
 	// TransactionSizeLimit is the largest allowed transaction size to persistence
 	TransactionSizeLimit
+	// ExecutionTransactionStatementLimit is the largest allowed number of statements in a single workflow execution write
+	ExecutionTransactionStatementLimit
+	// ExecutionTransactionSizeLimit is the largest allowed size in bytes of a single workflow execution write
+	ExecutionTransactionSizeLimit
 	// MinRetentionDays is the minimal allowed retention days for domain
 	MinRetentionDays
 
@@ -258,6 +353,12 @@ const (
 	HistoryCountLimitError
 	// HistoryCountLimitWarn is the per workflow execution history event count limit for warning
 	HistoryCountLimitWarn
+	// HistoryLimitTerminate is whether to terminate workflow executions exceeding the history size / count error limits on update
+	HistoryLimitTerminate
+	// HistorySizeSuggestContinueAsNew is the per workflow execution history size above which decision tasks suggest continue as new
+	HistorySizeSuggestContinueAsNew
+	// HistoryCountSuggestContinueAsNew is the per workflow execution history event count above which decision tasks suggest continue as new
+	HistoryCountSuggestContinueAsNew
 
 	// MaxIDLengthLimit is the length limit for various IDs, including: Domain, TaskList, WorkflowID, ActivityID, TimerID,
 	// WorkflowType, ActivityType, SignalName, MarkerName, ErrorReason/FailureReason/CancelCause, Identity, RequestID
@@ -273,12 +374,20 @@ const (
 	FrontendVisibilityListMaxQPS
 	// FrontendESVisibilityListMaxQPS is max qps frontend can list open/close workflows from ElasticSearch
 	FrontendESVisibilityListMaxQPS
+	// FrontendPinotVisibilityListMaxQPS is max qps frontend can list open/close workflows from Pinot
+	FrontendPinotVisibilityListMaxQPS
 	// FrontendESIndexMaxResultWindow is ElasticSearch index setting max_result_window
 	FrontendESIndexMaxResultWindow
 	// FrontendHistoryMaxPageSize is default max size for GetWorkflowExecutionHistory in one page
 	FrontendHistoryMaxPageSize
 	// FrontendRPS is workflow rate limit per second
 	FrontendRPS
+	// FrontendDomainRPS is workflow domain rate limit per second per frontend host
+	FrontendDomainRPS
+	// FrontendGlobalDomainRPS is workflow domain rate limit per second for the whole cluster, it is split
+	// evenly among the frontend hosts regardless of how the requests of the domain are spread over them.
+	// When set it takes precedence over FrontendDomainRPS
+	FrontendGlobalDomainRPS
 	// FrontendHistoryMgrNumConns is for persistence cluster.NumConns
 	FrontendHistoryMgrNumConns
 	// FrontendThrottledLogRPS is the rate limit on number of log messages emitted per second for throttled logger
@@ -297,6 +406,22 @@ const (
 	SearchAttributesSizeOfValueLimit
 	// SearchAttributesTotalSizeLimit is the size limit of the whole map
 	SearchAttributesTotalSizeLimit
+	// StickyQueryTimeout is the max time frontend waits for a query dispatched to the sticky task list
+	// before falling back to the normal task list
+	StickyQueryTimeout
+	// StickyQueryPollerLivenessWindow is the window within which a sticky poller must have been seen
+	// for a query to be routed to the sticky task list
+	StickyQueryPollerLivenessWindow
+	// StickyQueryPollerCheckTimeout is the max time frontend waits to find out whether the sticky task list
+	// has a live poller, the query is dispatched to the sticky task list when the check times out
+	StickyQueryPollerCheckTimeout
+	// FrontendMetadataCacheTTL is how long DescribeDomain and GetSearchAttributes responses are cached,
+	// zero disables the cache
+	FrontendMetadataCacheTTL
+	// FrontendMetadataCacheMaxSize is the max number of domains kept in the DescribeDomain response cache
+	FrontendMetadataCacheMaxSize
+	// FrontendRawHistoryMaxPageBytes is the max size of the history batches returned in a page of raw history
+	FrontendRawHistoryMaxPageBytes
 
 	// key for matching
 
@@ -308,6 +433,8 @@ const (
 	MatchingMinTaskThrottlingBurstSize
 	// MatchingGetTasksBatchSize is the maximum batch size to fetch from the task buffer
 	MatchingGetTasksBatchSize
+	// MatchingTaskBufferLowWatermark is the number of buffered tasks below which the task reader reads ahead more tasks
+	MatchingTaskBufferLowWatermark
 	// MatchingLongPollExpirationInterval is the long poll expiration interval in the matching service
 	MatchingLongPollExpirationInterval
 	// MatchingEnableSyncMatch is to enable sync match
@@ -322,6 +449,9 @@ const (
 	MatchingOutstandingTaskAppendsThreshold
 	// MatchingMaxTaskBatchSize is max batch size for task writer
 	MatchingMaxTaskBatchSize
+	// MatchingTaskBatchWindow is how long the task writer waits for more tasks to write in the same batch,
+	// zero disables the wait
+	MatchingTaskBatchWindow
 	// MatchingMaxTaskDeleteBatchSize is the max batch size for range deletion of tasks
 	MatchingMaxTaskDeleteBatchSize
 	// MatchingThrottledLogRPS is the rate limit on number of log messages emitted per second for throttled logger
@@ -345,18 +475,51 @@ const (
 	HistoryCacheMaxSize
 	// HistoryCacheTTL is TTL of history cache
 	HistoryCacheTTL
+	// HistoryCacheWarmupEnabled is whether the recently active executions of a shard are recorded and loaded into the history cache when the shard is acquired
+	HistoryCacheWarmupEnabled
+	// HistoryCacheWarmupSize is the max number of recently active executions recorded per shard for the history cache warm-up
+	HistoryCacheWarmupSize
+	// HistoryCacheWarmupRecordInterval is the interval at which the recently active executions of a shard are recorded
+	HistoryCacheWarmupRecordInterval
 	// EventsCacheInitialSize is initial size of events cache
 	EventsCacheInitialSize
 	// EventsCacheMaxSize is max size of events cache
 	EventsCacheMaxSize
 	// EventsCacheTTL is TTL of events cache
 	EventsCacheTTL
+	// ClientVersionTrackerMaxSize is the max number of distinct domain and client version pairs a history host keeps track of
+	ClientVersionTrackerMaxSize
 	// AcquireShardInterval is interval that timer used to acquire shard
 	AcquireShardInterval
+	// AcquireShardConcurrency is number of goroutines that can be used to acquire shards in the shard controller
+	AcquireShardConcurrency
+	// AcquireShardJitter is the max random delay before a shard not yet owned by the host is loaded
+	AcquireShardJitter
+	// ShardLeaseRenewInterval is the interval at which a host renews the leases of its shards, 0 disables the renewal
+	ShardLeaseRenewInterval
+	// ShardLeaseRenewJitter is the max random delay added to the shard lease renew interval
+	ShardLeaseRenewJitter
+	// ShardLeaseTTL is how long after a renewal the lease of a shard is considered held by its owner
+	ShardLeaseTTL
+	// ShardLeaseStealTimeout is the max time a host acquiring a shard waits for the unexpired lease of
+	// the previous owner before stealing the shard, 0 steals the shard right away
+	ShardLeaseStealTimeout
+	// EnableLoadAwareShardPlacement rebalances the ring assignment of the shards using the task rate persisted by each shard
+	EnableLoadAwareShardPlacement
+	// ShardPlacementRefreshInterval is the interval at which the elected host persists the shard placement and all hosts read it
+	ShardPlacementRefreshInterval
+	// ShardPlacementLoadTolerance is the fraction above the average host load a host can reach before shards are moved away
+	ShardPlacementLoadTolerance
 	// StandbyClusterDelay is the atrificial delay added to standby cluster's view of active cluster's time
 	StandbyClusterDelay
-	// TimerTaskBatchSize is batch size for timer processor to process tasks
+	// TimerTaskBatchSize is the initial batch size for timer processor to process tasks
 	TimerTaskBatchSize
+	// TimerTaskMinBatchSize is the smallest batch size timer processor shrinks to when reads get slow
+	TimerTaskMinBatchSize
+	// TimerTaskMaxBatchSize is the largest batch size timer processor grows to when it falls behind
+	TimerTaskMaxBatchSize
+	// TimerProcessorReadLatencyTarget is the timer task read latency above which timer processor shrinks its batch size
+	TimerProcessorReadLatencyTarget
 	// TimerTaskWorkerCount is number of task workers for timer processor
 	TimerTaskWorkerCount
 	// TimerTaskMaxRetryCount is max retry count for timer processor
@@ -387,8 +550,23 @@ const (
 	TimerProcessorMaxPollIntervalJitterCoefficient
 	// TimerProcessorMaxTimeShift is the max shift timer processor can have
 	TimerProcessorMaxTimeShift
-	// TransferTaskBatchSize is batch size for transferQueueProcessor
+	// UserTimerCoalescingWindow is the window within which user timers of an execution are fired by the same timer task
+	UserTimerCoalescingWindow
+	// RetentionSweepInterval is the interval at which each shard sweeps its closed execution index for expired executions
+	RetentionSweepInterval
+	// RetentionSweepLookbackDays is how many days past the longest domain retention each retention sweep walks
+	// the closed execution index
+	RetentionSweepLookbackDays
+	// RetentionSweepBatchSize is the page size used when reading the closed execution index
+	RetentionSweepBatchSize
+	// TransferTaskBatchSize is the initial batch size for transferQueueProcessor
 	TransferTaskBatchSize
+	// TransferTaskMinBatchSize is the smallest batch size transferQueueProcessor shrinks to when reads get slow
+	TransferTaskMinBatchSize
+	// TransferTaskMaxBatchSize is the largest batch size transferQueueProcessor grows to when it falls behind
+	TransferTaskMaxBatchSize
+	// TransferProcessorReadLatencyTarget is the transfer task read latency above which transferQueueProcessor shrinks its batch size
+	TransferProcessorReadLatencyTarget
 	// TransferProcessorFailoverMaxPollRPS is max poll rate per second for transferQueueProcessor
 	TransferProcessorFailoverMaxPollRPS
 	// TransferProcessorMaxPollRPS is max poll rate per second for transferQueueProcessor
@@ -415,6 +593,8 @@ const (
 	TransferProcessorUpdateAckIntervalJitterCoefficient
 	// TransferProcessorCompleteTransferInterval is complete timer interval for transferQueueProcessor
 	TransferProcessorCompleteTransferInterval
+	// StuckQueueAlarmThreshold is how long a transfer or timer queue ack level can stay put while new tasks arrive before an alarm is raised
+	StuckQueueAlarmThreshold
 	// ReplicatorTaskBatchSize is batch size for ReplicatorProcessor
 	ReplicatorTaskBatchSize
 	// ReplicatorTaskWorkerCount is number of worker for ReplicatorProcessor
@@ -435,6 +615,34 @@ const (
 	ReplicatorProcessorUpdateAckInterval
 	// ReplicatorProcessorUpdateAckIntervalJitterCoefficient is the update interval jitter coefficient
 	ReplicatorProcessorUpdateAckIntervalJitterCoefficient
+	// ReplicatorRawHistoryPassthrough is whether history replication tasks carry the persisted event blobs instead of decoded events
+	ReplicatorRawHistoryPassthrough
+	// ReplicatorPublishBatchSize is the maximum number of replication tasks published in one batch, 1 disables batching
+	ReplicatorPublishBatchSize
+	// ReplicatorPublishBatchMaxDelay is the longest a replication task waits for its publishing batch to fill up
+	ReplicatorPublishBatchMaxDelay
+	// ReplicatorPublishMaxInFlightBatches is the maximum number of replication task batches published concurrently
+	ReplicatorPublishMaxInFlightBatches
+	// EnableVisibilityTaskQueue is whether visibility records are written through the dedicated visibility task queue
+	EnableVisibilityTaskQueue
+	// VisibilityTaskBatchSize is batch size for VisibilityQueueProcessor
+	VisibilityTaskBatchSize
+	// VisibilityTaskWorkerCount is number of worker for VisibilityQueueProcessor
+	VisibilityTaskWorkerCount
+	// VisibilityTaskMaxRetryCount is max times of retry for VisibilityQueueProcessor
+	VisibilityTaskMaxRetryCount
+	// VisibilityProcessorStartDelay is the start delay
+	VisibilityProcessorStartDelay
+	// VisibilityProcessorMaxPollRPS is max poll rate per second for VisibilityQueueProcessor
+	VisibilityProcessorMaxPollRPS
+	// VisibilityProcessorMaxPollInterval is max poll interval for VisibilityQueueProcessor
+	VisibilityProcessorMaxPollInterval
+	// VisibilityProcessorMaxPollIntervalJitterCoefficient is the max poll interval jitter coefficient
+	VisibilityProcessorMaxPollIntervalJitterCoefficient
+	// VisibilityProcessorUpdateAckInterval is update interval for VisibilityQueueProcessor
+	VisibilityProcessorUpdateAckInterval
+	// VisibilityProcessorUpdateAckIntervalJitterCoefficient is the update interval jitter coefficient
+	VisibilityProcessorUpdateAckIntervalJitterCoefficient
 	// ExecutionMgrNumConns is persistence connections number for ExecutionManager
 	ExecutionMgrNumConns
 	// HistoryMgrNumConns is persistence connections number for HistoryManager
@@ -443,8 +651,12 @@ const (
 	MaximumBufferedEventsBatch
 	// MaximumSignalsPerExecution is max number of signals supported by single execution
 	MaximumSignalsPerExecution
+	// MaximumHistoryBranchesPerExecution is max number of live history branches, one per reset, of a single workflow
+	MaximumHistoryBranchesPerExecution
 	// ShardUpdateMinInterval is the minimal time interval which the shard info can be updated
 	ShardUpdateMinInterval
+	// ShardTaskThroughputWindow is the time window over which the task rate persisted in the shard info is averaged
+	ShardTaskThroughputWindow
 	// ShardSyncMinInterval is the minimal time interval which the shard info should be sync to remote
 	ShardSyncMinInterval
 	// DefaultEventEncoding is the encoding type for history events
@@ -463,8 +675,18 @@ const (
 
 	// EnableEventsV2 is whether to use eventsV2
 	EnableEventsV2
+	// EnableTransientDecisionHeartbeat is whether decisions only heartbeating are kept in mutable state instead of history
+	EnableTransientDecisionHeartbeat
 	// HistoryThrottledLogRPS is the rate limit on number of log messages emitted per second for throttled logger
 	HistoryThrottledLogRPS
+	// ActivityRetryMaximumAttemptsOverride overrides the maximum attempts of activity retry policy by domain and activity type, 0 means no override
+	ActivityRetryMaximumAttemptsOverride
+	// ActivityRetryMaximumIntervalOverride overrides the maximum interval of activity retry policy by domain and activity type, 0 means no override
+	ActivityRetryMaximumIntervalOverride
+	// ActivityRetryNonRetriableErrorsOverride overrides the comma separated non retriable error reasons of activity retry policy by domain and activity type, empty means no override
+	ActivityRetryNonRetriableErrorsOverride
+	// DomainExecutionsFrozen is whether the workflow updates of the domain are rejected with a retryable error, used to quiesce a domain during migrations
+	DomainExecutionsFrozen
 
 	// key for worker
 
@@ -514,8 +736,23 @@ const (
 	WorkerThrottledLogRPS
 	// ScannerPersistenceMaxQPS is the maximum rate of persistence calls from worker.Scanner
 	ScannerPersistenceMaxQPS
+	// ScannerBufferedReplicationTasksPurgeEnabled is whether worker.Scanner purges the stale buffered replication tasks
+	// it finds, when disabled they are only reported
+	ScannerBufferedReplicationTasksPurgeEnabled
+	// ScannerCurrentExecutionsFixEnabled is whether worker.Scanner deletes the current rows pointing to
+	// runs that no longer exist, when disabled they are only reported
+	ScannerCurrentExecutionsFixEnabled
+	// ScannerOpenExecutionCountsReconcileEnabled is whether worker.Scanner scans the open executions of a domain
+	// to reconcile its open execution counters, the counters of the domains it is disabled for are left alone
+	ScannerOpenExecutionCountsReconcileEnabled
 	// EnableBatcher decides whether start batcher in our worker
 	EnableBatcher
+	// EnableCanary decides whether start the canary, which continuously runs probe workflows, in our worker
+	EnableCanary
+	// EnableScheduler decides whether start the scheduler, which fires the schedules, in our worker
+	EnableScheduler
+	// SchedulerScanInterval is the interval at which the scheduler checks whether schedules are due
+	SchedulerScanInterval
 
 	// lastKeyForTest must be the last one in this const group for testing purpose
 	lastKeyForTest
@@ -525,7 +762,7 @@ const (
 type Filter int
 
 func (f Filter) String() string {
-	if f <= unknownFilter || f > TaskType {
+	if f <= unknownFilter || f > ActivityType {
 		return filters[unknownFilter]
 	}
 	return filters[f]
@@ -536,6 +773,7 @@ var filters = []string{
 	"domainName",
 	"taskListName",
 	"taskType",
+	"activityType",
 }
 
 const (
@@ -546,6 +784,8 @@ const (
 	TaskListName
 	// TaskType is the task type (0:Decision, 1:Activity)
 	TaskType
+	// ActivityType is the activity type name
+	ActivityType
 
 	// lastFilterTypeForTest must be the last one in this const group for testing purpose
 	lastFilterTypeForTest
@@ -574,3 +814,10 @@ func TaskTypeFilter(taskType int) FilterOption {
 		filterMap[TaskType] = taskType
 	}
 }
+
+// ActivityTypeFilter filters by activity type name
+func ActivityTypeFilter(name string) FilterOption {
+	return func(filterMap map[Filter]interface{}) {
+		filterMap[ActivityType] = name
+	}
+}
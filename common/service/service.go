@@ -30,7 +30,10 @@ import (
 	"github.com/uber/cadence/client"
 	"github.com/uber/cadence/common"
 	"github.com/uber/cadence/common/archiver/provider"
+	"github.com/uber/cadence/common/audit"
+	"github.com/uber/cadence/common/authorization"
 	"github.com/uber/cadence/common/blobstore"
+	"github.com/uber/cadence/common/blobstore/blob"
 	"github.com/uber/cadence/common/clock"
 	"github.com/uber/cadence/common/cluster"
 	es "github.com/uber/cadence/common/elasticsearch"
@@ -39,6 +42,8 @@ import (
 	"github.com/uber/cadence/common/membership"
 	"github.com/uber/cadence/common/messaging"
 	"github.com/uber/cadence/common/metrics"
+	"github.com/uber/cadence/common/persistence"
+	"github.com/uber/cadence/common/pinot"
 	"github.com/uber/cadence/common/service/config"
 	"github.com/uber/cadence/common/service/dynamicconfig"
 	"go.uber.org/cadence/.gen/go/cadence/workflowserviceclient"
@@ -72,12 +77,20 @@ type (
 		MessagingClient     messaging.Client
 		ESClient            es.Client
 		ESConfig            *es.Config
+		PinotClient         pinot.Client
+		PinotConfig         *pinot.Config
 		DynamicConfig       dynamicconfig.Client
 		DispatcherProvider  client.DispatcherProvider
 		BlobstoreClient     blobstore.Client
+		BlobKeyProvider     blob.KeyProvider
+		PayloadInspector    persistence.PayloadInspector
 		DCRedirectionPolicy config.DCRedirectionPolicy
 		PublicClient        workflowserviceclient.Interface
 		ArchiverProvider    provider.ArchiverProvider
+		TaskTokenSerializer common.TaskTokenSerializer
+		Authentication      authorization.Config
+		Authorizer          authorization.Authorizer
+		Audit               audit.Config
 	}
 
 	// MembershipMonitorFactory provides a bootstrapped membership monitor
@@ -111,6 +124,7 @@ type (
 		messagingClient        messaging.Client
 		dynamicCollection      *dynamicconfig.Collection
 		dispatcherProvider     client.DispatcherProvider
+		tokenSerializer        common.TaskTokenSerializer
 	}
 )
 
@@ -135,6 +149,10 @@ func New(params *BootstrapParams) Service {
 		messagingClient:       params.MessagingClient,
 		dispatcherProvider:    params.DispatcherProvider,
 		dynamicCollection:     dynamicconfig.NewCollection(params.DynamicConfig, params.Logger),
+		tokenSerializer:       params.TaskTokenSerializer,
+	}
+	if sVice.tokenSerializer == nil {
+		sVice.tokenSerializer = common.NewJSONTaskTokenSerializer()
 	}
 
 	sVice.runtimeMetricsReporter = metrics.NewRuntimeMetricsReporter(params.MetricScope, time.Minute, sVice.GetLogger(), params.InstanceID)
@@ -227,6 +245,10 @@ func (h *serviceImpl) Stop() {
 		h.dispatcher.Stop()
 	}
 
+	if provider, ok := h.dispatcherProvider.(client.StoppableDispatcherProvider); ok {
+		provider.Stop()
+	}
+
 	h.runtimeMetricsReporter.Stop()
 }
 
@@ -272,6 +294,11 @@ func (h *serviceImpl) GetMessagingClient() messaging.Client {
 	return h.messagingClient
 }
 
+// GetTaskTokenSerializer returns the serializer used to create and validate task tokens
+func (h *serviceImpl) GetTaskTokenSerializer() common.TaskTokenSerializer {
+	return h.tokenSerializer
+}
+
 // GetMetricsServiceIdx returns the metrics name
 func GetMetricsServiceIdx(serviceName string, logger log.Logger) metrics.ServiceIdx {
 	switch serviceName {
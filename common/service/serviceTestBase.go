@@ -22,6 +22,7 @@ package service
 
 import (
 	"github.com/uber/cadence/client"
+	"github.com/uber/cadence/common"
 	"github.com/uber/cadence/common/clock"
 	"github.com/uber/cadence/common/cluster"
 	"github.com/uber/cadence/common/log"
@@ -141,3 +142,8 @@ func (s *serviceTestBase) GetClusterMetadata() cluster.Metadata {
 func (s *serviceTestBase) GetMessagingClient() messaging.Client {
 	return s.messagingClient
 }
+
+// GetTaskTokenSerializer returns the serializer used to create and validate task tokens
+func (s *serviceTestBase) GetTaskTokenSerializer() common.TaskTokenSerializer {
+	return common.NewJSONTaskTokenSerializer()
+}
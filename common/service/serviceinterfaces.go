@@ -22,6 +22,7 @@ package service
 
 import (
 	"github.com/uber/cadence/client"
+	"github.com/uber/cadence/common"
 	"github.com/uber/cadence/common/clock"
 	"github.com/uber/cadence/common/cluster"
 	"github.com/uber/cadence/common/log"
@@ -64,5 +65,8 @@ type (
 
 		// GetMessagingClient returns the messaging client against Kafka
 		GetMessagingClient() messaging.Client
+
+		// GetTaskTokenSerializer returns the serializer used to create and validate task tokens
+		GetTaskTokenSerializer() common.TaskTokenSerializer
 	}
 )
@@ -172,10 +172,17 @@ func CreateKafkaOperationRetryPolicy() backoff.RetryPolicy {
 	return policy
 }
 
+// StoreInternalError is implemented by the internal errors of the persistence stores, which carry structured
+// fields for logging and are returned to the callers of the service as internal service errors
+type StoreInternalError interface {
+	error
+	InternalServiceError() *workflow.InternalServiceError
+}
+
 // IsPersistenceTransientError checks if the error is a transient persistence error
 func IsPersistenceTransientError(err error) bool {
 	switch err.(type) {
-	case *workflow.InternalServiceError, *workflow.ServiceBusyError:
+	case *workflow.InternalServiceError, *workflow.ServiceBusyError, StoreInternalError:
 		return true
 	}
 
@@ -225,6 +232,8 @@ func IsWhitelistServiceTransientError(err error) bool {
 	switch err.(type) {
 	case *workflow.InternalServiceError:
 		return true
+	case StoreInternalError:
+		return true
 	case *workflow.ServiceBusyError:
 		return true
 	case *workflow.LimitExceededError:
@@ -307,6 +316,8 @@ func CreateMatchingPollForDecisionTaskResponse(historyResponse *h.RecordDecision
 		BranchToken:               historyResponse.BranchToken,
 		ScheduledTimestamp:        historyResponse.ScheduledTimestamp,
 		StartedTimestamp:          historyResponse.StartedTimestamp,
+		ContinueAsNewSuggested:    historyResponse.ContinueAsNewSuggested,
+		PendingUpdates:            historyResponse.PendingUpdates,
 	}
 	if historyResponse.GetPreviousStartedEventId() != EmptyEventID {
 		matchingResp.PreviousStartedEventId = historyResponse.PreviousStartedEventId
@@ -387,7 +398,7 @@ func CreateHistoryStartWorkflowRequest(domainID string, startRequest *workflow.S
 		deadline := time.Now().Add(time.Second * time.Duration(expirationInSeconds))
 		histRequest.ExpirationTimestamp = Int64Ptr(deadline.Round(time.Millisecond).UnixNano())
 	}
-	histRequest.FirstDecisionTaskBackoffSeconds = Int32Ptr(backoff.GetBackoffForNextScheduleInSeconds(startRequest.GetCronSchedule(), time.Now()))
+	histRequest.FirstDecisionTaskBackoffSeconds = Int32Ptr(backoff.GetBackoffForNextScheduleInSeconds(startRequest.GetCronSchedule(), startRequest.GetCronTimezone(), time.Now()))
 	return histRequest
 }
 
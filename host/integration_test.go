@@ -1360,6 +1360,11 @@ func (s *integrationSuite) TestDescribeWorkflowExecution() {
 	s.True(nil == dweResponse.WorkflowExecutionInfo.CloseTime)
 	s.Equal(int64(2), *dweResponse.WorkflowExecutionInfo.HistoryLength) // WorkflowStarted, DecisionScheduled
 	s.Equal(dweResponse.WorkflowExecutionInfo.GetStartTime(), dweResponse.WorkflowExecutionInfo.GetExecutionTime())
+	s.NotNil(dweResponse.PendingDecision)
+	s.Equal(int64(2), dweResponse.PendingDecision.GetScheduleID())
+	s.Equal(int64(0), dweResponse.PendingDecision.GetAttempt())
+	s.True(dweResponse.PendingDecision.GetScheduledTimestamp() > 0)
+	s.Nil(dweResponse.PendingDecision.StartedID)
 
 	// decider logic
 	workflowComplete := false
@@ -1422,6 +1427,10 @@ func (s *integrationSuite) TestDescribeWorkflowExecution() {
 	s.Equal(1, len(dweResponse.PendingActivities))
 	s.Equal("test-activity-type", dweResponse.PendingActivities[0].ActivityType.GetName())
 	s.Equal(int64(0), dweResponse.PendingActivities[0].GetLastHeartbeatTimestamp())
+	s.Equal(int32(1), dweResponse.ExecutionStatistics.GetPendingActivityCount())
+	s.Equal(int32(0), dweResponse.ExecutionStatistics.GetPendingTimerCount())
+	s.True(dweResponse.ExecutionStatistics.GetHistorySize() > 0)
+	s.Nil(dweResponse.PendingDecision)
 
 	// process activity task
 	err = poller.PollAndProcessActivityTask(false)
@@ -1431,6 +1440,9 @@ func (s *integrationSuite) TestDescribeWorkflowExecution() {
 	s.True(nil == dweResponse.WorkflowExecutionInfo.CloseStatus)
 	s.Equal(int64(8), *dweResponse.WorkflowExecutionInfo.HistoryLength) // ActivityTaskStarted, ActivityTaskCompleted, DecisionTaskScheduled
 	s.Equal(0, len(dweResponse.PendingActivities))
+	s.NotNil(dweResponse.PendingDecision)
+	s.Equal(int64(8), dweResponse.PendingDecision.GetScheduleID())
+	s.Equal(int32(0), dweResponse.ExecutionStatistics.GetPendingActivityCount())
 
 	// Process signal in decider
 	_, err = poller.PollAndProcessDecisionTask(true, false)
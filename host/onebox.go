@@ -33,6 +33,8 @@ import (
 	"github.com/uber/cadence/.gen/go/shared"
 	"github.com/uber/cadence/client"
 	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/audit"
+	"github.com/uber/cadence/common/authorization"
 	"github.com/uber/cadence/common/blobstore"
 	"github.com/uber/cadence/common/cache"
 	"github.com/uber/cadence/common/cluster"
@@ -90,6 +92,7 @@ type (
 		historyV2Mgr        persistence.HistoryV2Manager
 		taskMgr             persistence.TaskManager
 		visibilityMgr       persistence.VisibilityManager
+		scheduleMgr         persistence.ScheduleManager
 		executionMgrFactory persistence.ExecutionManagerFactory
 		shutdownCh          chan struct{}
 		shutdownWG          sync.WaitGroup
@@ -128,6 +131,7 @@ type (
 		ExecutionMgrFactory           persistence.ExecutionManagerFactory
 		TaskMgr                       persistence.TaskManager
 		VisibilityMgr                 persistence.VisibilityManager
+		ScheduleMgr                   persistence.ScheduleManager
 		Logger                        log.Logger
 		ClusterNo                     int
 		EnableEventsV2                bool
@@ -156,6 +160,7 @@ func NewCadence(params *CadenceParams) Cadence {
 		metadataMgr:         params.MetadataMgr,
 		metadataMgrV2:       params.MetadataMgrV2,
 		visibilityMgr:       params.VisibilityMgr,
+		scheduleMgr:         params.ScheduleMgr,
 		shardMgr:            params.ShardMgr,
 		historyMgr:          params.HistoryMgr,
 		historyV2Mgr:        params.HistoryV2Mgr,
@@ -417,14 +422,17 @@ func (c *cadenceImpl) startFrontend(hosts map[string][]string, startWG *sync.Wai
 	c.frontEndService = service.New(params)
 
 	c.adminHandler = frontend.NewAdminHandler(
-		c.frontEndService, c.historyConfig.NumHistoryShards, c.metadataMgr, c.historyMgr, c.historyV2Mgr)
+		c.frontEndService, c.historyConfig.NumHistoryShards, false, c.metadataMgr, c.historyMgr, c.historyV2Mgr, c.visibilityMgr, nil,
+		c.scheduleMgr, c.shardMgr, c.executionMgrFactory, params.BlobstoreClient, authorization.NewNopAuthenticator(),
+		audit.NewNopSink())
 	c.adminHandler.RegisterHandler()
 
 	dc := dynamicconfig.NewCollection(params.DynamicConfig, c.logger)
 	frontendConfig := frontend.NewConfig(dc, c.historyConfig.NumHistoryShards, c.workerConfig.EnableIndexer)
 	c.frontendHandler = frontend.NewWorkflowHandler(
 		c.frontEndService, frontendConfig, c.metadataMgr, c.historyMgr, c.historyV2Mgr,
-		c.visibilityMgr, kafkaProducer, params.BlobstoreClient, nil)
+		c.visibilityMgr, c.executionMgrFactory, kafkaProducer, params.BlobstoreClient, nil,
+		authorization.NewNopAuthenticator(), authorization.NewNopAuthorizer(), audit.NewNopSink(), nil)
 	dcRedirectionHandler := frontend.NewDCRedirectionHandler(c.frontendHandler, params.DCRedirectionPolicy)
 	dcRedirectionHandler.RegisterHandler()
 
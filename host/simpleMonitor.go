@@ -71,3 +71,11 @@ func (s *simpleMonitor) AddListener(service string, name string, notifyChannel c
 func (s *simpleMonitor) RemoveListener(service string, name string) error {
 	return nil
 }
+
+func (s *simpleMonitor) EvictSelf() error {
+	return nil
+}
+
+func (s *simpleMonitor) RejoinSelf() error {
+	return nil
+}
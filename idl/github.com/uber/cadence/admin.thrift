@@ -47,6 +47,273 @@ service AdminService {
       3: shared.AccessDeniedError     accessDeniedError,
     )
 
+  /**
+  * DrainHistoryHost makes a history host stop acquiring shards and release the shards it owns after
+  * finishing their in-flight writes and persisting their ack levels, so the host can be stopped without
+  * failing requests or reprocessing tasks.
+  **/
+  shared.DrainHistoryHostResponse DrainHistoryHost(1: shared.DrainHistoryHostRequest request)
+    throws (
+      1: shared.BadRequestError       badRequestError,
+      2: shared.InternalServiceError  internalServiceError,
+      3: shared.AccessDeniedError     accessDeniedError,
+    )
+
+  /**
+  * UndrainHistoryHost adds a drained history host back to the membership ring, so it acquires
+  * shards again.
+  **/
+  shared.UndrainHistoryHostResponse UndrainHistoryHost(1: shared.UndrainHistoryHostRequest request)
+    throws (
+      1: shared.BadRequestError       badRequestError,
+      2: shared.InternalServiceError  internalServiceError,
+      3: shared.AccessDeniedError     accessDeniedError,
+    )
+
+  /**
+  * DescribeClientVersions returns the client implementations and versions which completed decisions
+  * for the domain since the history hosts started, so operators can find domains running outdated clients.
+  **/
+  shared.DescribeClientVersionsResponse DescribeClientVersions(1: shared.DescribeClientVersionsRequest request)
+    throws (
+      1: shared.BadRequestError       badRequestError,
+      2: shared.InternalServiceError  internalServiceError,
+      3: shared.AccessDeniedError     accessDeniedError,
+    )
+
+  /**
+  * GetDomainOpenExecutionCount returns the approximate number of open executions of the domain. The count is
+  * maintained by the history shards and periodically reconciled by the scanner.
+  **/
+  shared.GetDomainOpenExecutionCountResponse GetDomainOpenExecutionCount(1: shared.GetDomainOpenExecutionCountRequest request)
+    throws (
+      1: shared.BadRequestError       badRequestError,
+      2: shared.InternalServiceError  internalServiceError,
+      3: shared.EntityNotExistsError  entityNotExistError,
+      4: shared.AccessDeniedError     accessDeniedError,
+    )
+
+  /**
+  * SetActivityPaused pauses or unpauses a pending activity. A paused activity is not dispatched to workers
+  * and its retries do not consume attempts until it is unpaused.
+  **/
+  void SetActivityPaused(1: shared.SetActivityPausedRequest request)
+    throws (
+      1: shared.BadRequestError         badRequestError,
+      2: shared.InternalServiceError    internalServiceError,
+      3: shared.EntityNotExistsError    entityNotExistError,
+      4: shared.DomainNotActiveError    domainNotActiveError,
+      5: shared.AccessDeniedError       accessDeniedError,
+    )
+
+  /**
+  * SetWorkflowExecutionNotes attaches free-form operator notes to a running workflow execution, replacing the
+  * previous ones, or removes them if the notes are empty. The notes are not part of the history and are returned
+  * by DescribeWorkflowExecution.
+  **/
+  void SetWorkflowExecutionNotes(1: shared.SetWorkflowExecutionNotesRequest request)
+    throws (
+      1: shared.BadRequestError         badRequestError,
+      2: shared.InternalServiceError    internalServiceError,
+      3: shared.EntityNotExistsError    entityNotExistError,
+      4: shared.AccessDeniedError       accessDeniedError,
+    )
+
+  /**
+  * SetWorkflowExecutionPaused pauses or resumes a running workflow execution. No decision task of a paused
+  * workflow is dispatched to workers, signals and other events keep being recorded and are delivered with the
+  * first decision task after the workflow is resumed.
+  **/
+  void SetWorkflowExecutionPaused(1: shared.SetWorkflowExecutionPausedRequest request)
+    throws (
+      1: shared.BadRequestError         badRequestError,
+      2: shared.InternalServiceError    internalServiceError,
+      3: shared.EntityNotExistsError    entityNotExistError,
+      4: shared.DomainNotActiveError    domainNotActiveError,
+      5: shared.AccessDeniedError       accessDeniedError,
+    )
+
+  /**
+  * SetCronSchedulePaused pauses or resumes the cron schedule of a workflow without terminating it. Runs which
+  * are waiting for their cron schedule do not start while the schedule is paused, the schedule carries over to
+  * the following runs.
+  **/
+  void SetCronSchedulePaused(1: shared.SetCronSchedulePausedRequest request)
+    throws (
+      1: shared.BadRequestError         badRequestError,
+      2: shared.InternalServiceError    internalServiceError,
+      3: shared.EntityNotExistsError    entityNotExistError,
+      4: shared.DomainNotActiveError    domainNotActiveError,
+      5: shared.AccessDeniedError       accessDeniedError,
+    )
+
+  /**
+  * CreateSchedule creates a schedule which periodically starts a workflow. Schedules are persisted in their own
+  * table and fired by the scheduler running in the worker service, independently of cron workflows.
+  **/
+  void CreateSchedule(1: shared.CreateScheduleRequest request)
+    throws (
+      1: shared.BadRequestError         badRequestError,
+      2: shared.InternalServiceError    internalServiceError,
+      3: shared.EntityNotExistsError    entityNotExistError,
+      4: shared.AccessDeniedError       accessDeniedError,
+    )
+
+  /**
+  * DescribeSchedule returns a schedule along with its next fire time.
+  **/
+  shared.DescribeScheduleResponse DescribeSchedule(1: shared.DescribeScheduleRequest request)
+    throws (
+      1: shared.BadRequestError         badRequestError,
+      2: shared.InternalServiceError    internalServiceError,
+      3: shared.EntityNotExistsError    entityNotExistError,
+      4: shared.AccessDeniedError       accessDeniedError,
+    )
+
+  /**
+  * DeleteSchedule deletes a schedule, workflows already started by the schedule are not affected.
+  **/
+  void DeleteSchedule(1: shared.DeleteScheduleRequest request)
+    throws (
+      1: shared.BadRequestError         badRequestError,
+      2: shared.InternalServiceError    internalServiceError,
+      3: shared.EntityNotExistsError    entityNotExistError,
+      4: shared.AccessDeniedError       accessDeniedError,
+    )
+
+  /**
+  * ListSchedules returns the schedules of a domain.
+  **/
+  shared.ListSchedulesResponse ListSchedules(1: shared.ListSchedulesRequest request)
+    throws (
+      1: shared.BadRequestError         badRequestError,
+      2: shared.InternalServiceError    internalServiceError,
+      3: shared.EntityNotExistsError    entityNotExistError,
+      4: shared.AccessDeniedError       accessDeniedError,
+    )
+
+  /**
+  * SetSchedulePaused pauses or resumes a schedule. Fire times missed while the schedule was paused are started
+  * when it is resumed if they are still within the catch-up window of the schedule.
+  **/
+  void SetSchedulePaused(1: shared.SetSchedulePausedRequest request)
+    throws (
+      1: shared.BadRequestError         badRequestError,
+      2: shared.InternalServiceError    internalServiceError,
+      3: shared.EntityNotExistsError    entityNotExistError,
+      4: shared.AccessDeniedError       accessDeniedError,
+    )
+
+  /**
+  * BackfillSchedule starts the workflows of every fire time of a schedule in the given time range.
+  **/
+  void BackfillSchedule(1: shared.BackfillScheduleRequest request)
+    throws (
+      1: shared.BadRequestError         badRequestError,
+      2: shared.InternalServiceError    internalServiceError,
+      3: shared.EntityNotExistsError    entityNotExistError,
+      4: shared.AccessDeniedError       accessDeniedError,
+    )
+
+  /**
+  * UpdateTaskListVersionSets replaces the worker build ID version sets of a decision task list. Decision tasks of an
+  * execution are dispatched to pollers of the default build of the set containing the build which last completed a
+  * decision of the execution, and decision tasks of new executions to the default build of the newest set.
+  **/
+  void UpdateTaskListVersionSets(1: shared.UpdateTaskListVersionSetsRequest request)
+    throws (
+      1: shared.BadRequestError         badRequestError,
+      2: shared.InternalServiceError    internalServiceError,
+      3: shared.EntityNotExistsError    entityNotExistError,
+      4: shared.AccessDeniedError       accessDeniedError,
+    )
+
+  /**
+  * DescribeTaskJourney returns the stages an activity or decision task recently went through in matching, from its
+  * creation to being recorded as started, to debug tasks reported as lost.
+  **/
+  shared.DescribeTaskJourneyResponse DescribeTaskJourney(1: shared.DescribeTaskJourneyRequest request)
+    throws (
+      1: shared.BadRequestError       badRequestError,
+      2: shared.InternalServiceError  internalServiceError,
+      3: shared.AccessDeniedError     accessDeniedError,
+    )
+
+  /**
+  * DescribeWorkflowShard returns the shard a workflow ID maps to, the history host owning it, the persisted
+  * ack levels of the shard, and whether the mutable state of the current run of the workflow exists.
+  **/
+  /**
+  * TerminateAllWorkflowRuns terminates the current run of a workflow ID together with the runs its chain continues
+  * as new into while it is being terminated, and temporarily rejects continue as new, cron and retry of the
+  * workflow ID so that a terminated cron workflow does not fire again.
+  **/
+  shared.TerminateAllWorkflowRunsResponse TerminateAllWorkflowRuns(1: shared.TerminateAllWorkflowRunsRequest request)
+    throws (
+      1: shared.BadRequestError         badRequestError,
+      2: shared.InternalServiceError    internalServiceError,
+      3: shared.EntityNotExistsError    entityNotExistError,
+      4: shared.DomainNotActiveError    domainNotActiveError,
+      5: shared.AccessDeniedError       accessDeniedError,
+    )
+
+  DescribeWorkflowShardResponse DescribeWorkflowShard(1: DescribeWorkflowShardRequest request)
+    throws (
+      1: shared.BadRequestError         badRequestError,
+      2: shared.InternalServiceError    internalServiceError,
+      3: shared.ServiceBusyError        serviceBusyError,
+      4: shared.AccessDeniedError       accessDeniedError,
+    )
+
+  /**
+  * ListWorkflowExecutionRuns returns all runs of a workflow ID known to the execution store, with their state and
+  * close status, ordered by start time.
+  **/
+  ListWorkflowExecutionRunsResponse ListWorkflowExecutionRuns(1: ListWorkflowExecutionRunsRequest request)
+    throws (
+      1: shared.BadRequestError         badRequestError,
+      2: shared.InternalServiceError    internalServiceError,
+      3: shared.ServiceBusyError        serviceBusyError,
+      4: shared.AccessDeniedError       accessDeniedError,
+    )
+
+  /**
+  * ListRecentlyClosedExecutions returns the executions of a history shard closed since the given time, in close
+  * time order a page at a time, from the closed execution index of the execution store.
+  **/
+  ListRecentlyClosedExecutionsResponse ListRecentlyClosedExecutions(1: ListRecentlyClosedExecutionsRequest request)
+    throws (
+      1: shared.BadRequestError         badRequestError,
+      2: shared.InternalServiceError    internalServiceError,
+      3: shared.ServiceBusyError        serviceBusyError,
+      4: shared.AccessDeniedError       accessDeniedError,
+    )
+
+  /**
+  * ImportWorkflowExecution recreates a closed workflow execution from a snapshot written by the export admin command
+  * as a new run of its workflow in the given domain, restoring its mutable state and history.
+  **/
+  ImportWorkflowExecutionResponse ImportWorkflowExecution(1: ImportWorkflowExecutionRequest request)
+    throws (
+      1: shared.BadRequestError         badRequestError,
+      2: shared.InternalServiceError    internalServiceError,
+      3: shared.ServiceBusyError        serviceBusyError,
+      4: shared.AccessDeniedError       accessDeniedError,
+    )
+
+  /**
+  * PurgeWorkflowExecution permanently deletes every trace of a closed workflow run: its mutable state, current
+  * record, history, visibility records, archived history blobs and buffered replication tasks, in every cluster of
+  * the domain. It returns a report of what was deleted and whether each deletion could be verified by reading it back.
+  **/
+  PurgeWorkflowExecutionResponse PurgeWorkflowExecution(1: PurgeWorkflowExecutionRequest request)
+    throws (
+      1: shared.BadRequestError         badRequestError,
+      2: shared.InternalServiceError    internalServiceError,
+      3: shared.ServiceBusyError        serviceBusyError,
+      4: shared.AccessDeniedError       accessDeniedError,
+    )
+
   /**
   * Returns the raw history of specified workflow execution.  It fails with 'EntityNotExistError' if speficied workflow
   * execution in unknown to the service.
@@ -70,6 +337,92 @@ struct DescribeWorkflowExecutionResponse{
   20: optional string historyAddr
   40: optional string mutableStateInCache
   50: optional string mutableStateInDatabase
+  60: optional i64 (js.type = "Long") lastFirstEventId
+  70: optional i64 (js.type = "Long") lastEventTaskId
+}
+
+struct DescribeWorkflowShardRequest {
+  10: optional string domainId
+  20: optional string workflowId
+}
+
+struct DescribeWorkflowShardResponse {
+  10: optional i32 shardId
+  20: optional string historyAddr
+  30: optional string shardOwner
+  40: optional i64 (js.type = "Long") rangeId
+  50: optional i64 (js.type = "Long") transferAckLevel
+  60: optional i64 (js.type = "Long") timerAckLevel
+  70: optional i64 (js.type = "Long") replicationAckLevel
+  80: optional map<string, i64> clusterTransferAckLevel
+  90: optional map<string, i64> clusterTimerAckLevel
+  100: optional string currentRunId
+  110: optional bool mutableStateExists
+}
+
+struct ListWorkflowExecutionRunsRequest {
+  10: optional string domain
+  20: optional string workflowId
+}
+
+struct WorkflowExecutionRunInfo {
+  10: optional string runId
+  20: optional string firstExecutionRunId
+  30: optional i64 (js.type = "Long") startTime
+  40: optional i32 state
+  50: optional shared.WorkflowExecutionCloseStatus closeStatus
+  60: optional bool isCurrent
+}
+
+struct ListWorkflowExecutionRunsResponse {
+  10: optional list<WorkflowExecutionRunInfo> runs
+}
+
+struct ListRecentlyClosedExecutionsRequest {
+  10: optional i32 shardId
+  20: optional i64 (js.type = "Long") closedAfter
+  30: optional i32 maximumPageSize
+  40: optional binary nextPageToken
+}
+
+struct ClosedExecutionInfo {
+  10: optional string domainId
+  20: optional string workflowId
+  30: optional string runId
+  40: optional i64 (js.type = "Long") closeTime
+}
+
+struct ListRecentlyClosedExecutionsResponse {
+  10: optional list<ClosedExecutionInfo> executions
+  20: optional binary nextPageToken
+}
+
+struct ImportWorkflowExecutionRequest {
+  10: optional string domain
+  20: optional binary snapshot
+}
+
+struct ImportWorkflowExecutionResponse {
+  10: optional string runId
+}
+
+struct PurgeWorkflowExecutionRequest {
+  10: optional string domain
+  20: optional shared.WorkflowExecution execution
+  // only purge the cluster receiving the request, set when the purge is forwarded to the other clusters of a global
+  // domain
+  30: optional bool currentClusterOnly
+}
+
+struct PurgeWorkflowExecutionStep {
+  10: optional string name
+  20: optional i64 deletedCount
+  30: optional bool verified
+  40: optional string details
+}
+
+struct PurgeWorkflowExecutionResponse {
+  10: optional list<PurgeWorkflowExecutionStep> steps
 }
 
 struct GetWorkflowExecutionRawHistoryRequest {
@@ -114,6 +114,7 @@ service WorkflowService {
       6: shared.LimitExceededError limitExceededError,
       7: shared.EntityNotExistsError entityNotExistError,
       8: shared.ClientVersionNotSupportedError clientVersionNotSupportedError,
+      9: shared.AccessDeniedError accessDeniedError,
     )
 
   /**
@@ -378,6 +379,23 @@ service WorkflowService {
       5: shared.DomainNotActiveError domainNotActiveError,
       6: shared.LimitExceededError limitExceededError,
       7: shared.ClientVersionNotSupportedError clientVersionNotSupportedError,
+      8: shared.AccessDeniedError accessDeniedError,
+    )
+
+  /**
+  * UpdateWorkflowExecution is used to send an update to a running workflow execution. Unlike a signal, the update
+  * is handed to the worker with the next decision task and the call blocks until the worker reports its result.
+  **/
+  shared.UpdateWorkflowExecutionResponse UpdateWorkflowExecution(1: shared.UpdateWorkflowExecutionRequest updateRequest)
+    throws (
+      1: shared.BadRequestError badRequestError,
+      2: shared.InternalServiceError internalServiceError,
+      3: shared.EntityNotExistsError entityNotExistError,
+      4: shared.ServiceBusyError serviceBusyError,
+      5: shared.DomainNotActiveError domainNotActiveError,
+      6: shared.LimitExceededError limitExceededError,
+      7: shared.ClientVersionNotSupportedError clientVersionNotSupportedError,
+      8: shared.AccessDeniedError accessDeniedError,
     )
 
   /**
@@ -397,6 +415,7 @@ service WorkflowService {
       6: shared.LimitExceededError limitExceededError,
       7: shared.WorkflowExecutionAlreadyStartedError workflowAlreadyStartedError,
       8: shared.ClientVersionNotSupportedError clientVersionNotSupportedError,
+      9: shared.AccessDeniedError accessDeniedError,
     )
 
   /**
@@ -427,6 +446,7 @@ service WorkflowService {
       5: shared.DomainNotActiveError domainNotActiveError,
       6: shared.LimitExceededError limitExceededError,
       7: shared.ClientVersionNotSupportedError clientVersionNotSupportedError,
+      8: shared.AccessDeniedError accessDeniedError,
     )
 
   /**
@@ -577,4 +597,18 @@ service WorkflowService {
       6: shared.ClientVersionNotSupportedError clientVersionNotSupportedError,
     )
 
+  /**
+  * GetTaskListScalingHints returns the backlog and dispatch rate of a tasklist as observed by the server. It is a
+  * lightweight alternative to DescribeTaskList meant to be polled by autoscalers and worker tuners.
+  **/
+  shared.GetTaskListScalingHintsResponse GetTaskListScalingHints(1: shared.GetTaskListScalingHintsRequest request)
+    throws (
+      1: shared.BadRequestError badRequestError,
+      2: shared.InternalServiceError internalServiceError,
+      3: shared.EntityNotExistsError entityNotExistError,
+      4: shared.LimitExceededError limitExceededError,
+      5: shared.ServiceBusyError serviceBusyError,
+      6: shared.ClientVersionNotSupportedError clientVersionNotSupportedError,
+    )
+
 }
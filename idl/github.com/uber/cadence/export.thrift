@@ -0,0 +1,220 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+namespace java com.uber.cadence.export
+
+include "shared.thrift"
+
+// ExecutionExport is the snapshot of a workflow execution written by the export tooling, independent
+// of the datastore it was read from. Fields are only added with new IDs, a change older readers can't
+// ignore bumps the version
+struct ExecutionExport {
+  10: optional i32 version
+  12: optional i64 (js.type = "Long") exportedTimeNanos
+  14: optional WorkflowExecutionInfo executionInfo
+  16: optional i64 (js.type = "Long") historySize
+  18: optional ReplicationState replicationState
+  20: optional list<ActivityInfo> activityInfos
+  22: optional list<TimerInfo> timerInfos
+  24: optional list<ChildExecutionInfo> childExecutionInfos
+  26: optional list<RequestCancelInfo> requestCancelInfos
+  28: optional list<SignalInfo> signalInfos
+  30: optional list<string> signalRequestedIDs
+  32: optional list<UpdateInfo> updateInfos
+  34: optional list<shared.HistoryEvent> bufferedEvents
+  36: optional list<HistoryBlob> history
+}
+
+// HistoryBlob is a batch of history events as persisted
+struct HistoryBlob {
+  10: optional string encoding
+  12: optional binary data
+}
+
+struct SearchAttribute {
+  10: optional string key
+  12: optional binary value
+}
+
+struct WorkflowExecutionInfo {
+  10: optional string domainID
+  12: optional string workflowID
+  14: optional string runID
+  16: optional string firstExecutionRunID
+  18: optional string parentDomainID
+  20: optional string parentWorkflowID
+  22: optional string parentRunID
+  24: optional i64 (js.type = "Long") initiatedID
+  26: optional i64 (js.type = "Long") completionEventBatchID
+  28: optional shared.HistoryEvent completionEvent
+  30: optional string taskList
+  32: optional string workflowTypeName
+  34: optional i32 workflowTimeoutSeconds
+  36: optional i32 decisionTaskTimeoutSeconds
+  38: optional binary executionContext
+  40: optional i32 state
+  42: optional i32 closeStatus
+  44: optional i64 (js.type = "Long") lastFirstEventID
+  46: optional i64 (js.type = "Long") lastEventTaskID
+  48: optional i64 (js.type = "Long") nextEventID
+  50: optional i64 (js.type = "Long") lastProcessedEvent
+  52: optional i64 (js.type = "Long") startTimeNanos
+  54: optional i64 (js.type = "Long") lastUpdatedTimeNanos
+  56: optional string createRequestID
+  58: optional i32 signalCount
+  60: optional i64 (js.type = "Long") decisionVersion
+  62: optional i64 (js.type = "Long") decisionScheduleID
+  64: optional i64 (js.type = "Long") decisionStartedID
+  66: optional string decisionRequestID
+  68: optional i32 decisionTimeout
+  70: optional i64 (js.type = "Long") decisionAttempt
+  72: optional i64 (js.type = "Long") decisionStartedTimestampNanos
+  74: optional i64 (js.type = "Long") decisionScheduledTimestampNanos
+  76: optional string decisionStartedIdentity
+  78: optional string decisionStartedBinaryChecksum
+  80: optional string resetBaseRunID
+  82: optional i64 (js.type = "Long") resetBaseEventID
+  84: optional string resetReason
+  86: optional bool cancelRequested
+  88: optional string cancelRequestID
+  90: optional string stickyTaskList
+  92: optional i32 stickyScheduleToStartTimeout
+  94: optional string clientLibraryVersion
+  96: optional string clientFeatureVersion
+  98: optional string clientImpl
+  100: optional shared.ResetPoints autoResetPoints
+  102: optional list<SearchAttribute> searchAttributes
+  104: optional i32 attempt
+  106: optional bool hasRetryPolicy
+  108: optional i32 retryInitialIntervalSeconds
+  110: optional double retryBackoffCoefficient
+  112: optional i32 retryMaximumIntervalSeconds
+  114: optional i64 (js.type = "Long") retryExpirationTimeNanos
+  116: optional i32 retryMaximumAttempts
+  118: optional list<string> retryNonRetryableErrors
+  120: optional i32 retryExpirationSeconds
+  122: optional i32 eventStoreVersion
+  124: optional binary branchToken
+  126: optional string cronSchedule
+  128: optional bool cronPaused
+  130: optional string cronTimezone
+  132: optional list<string> completedActivityRequestIDs
+  134: optional bool paused
+  136: optional string notes
+  138: optional string notesIdentity
+  140: optional i64 (js.type = "Long") notesLastUpdatedTimeNanos
+  142: optional i64 (js.type = "Long") decisionHeartbeatCount
+}
+
+struct ReplicationState {
+  10: optional i64 (js.type = "Long") currentVersion
+  12: optional i64 (js.type = "Long") startVersion
+  14: optional i64 (js.type = "Long") lastWriteVersion
+  16: optional i64 (js.type = "Long") lastWriteEventID
+  18: optional list<ReplicationInfo> lastReplicationInfo
+}
+
+struct ReplicationInfo {
+  10: optional string cluster
+  12: optional i64 (js.type = "Long") version
+  14: optional i64 (js.type = "Long") lastEventID
+}
+
+struct ActivityInfo {
+  10: optional i64 (js.type = "Long") version
+  12: optional i64 (js.type = "Long") scheduleID
+  14: optional i64 (js.type = "Long") scheduledEventBatchID
+  16: optional shared.HistoryEvent scheduledEvent
+  18: optional i64 (js.type = "Long") scheduledTimeNanos
+  20: optional i64 (js.type = "Long") startedID
+  22: optional shared.HistoryEvent startedEvent
+  24: optional i64 (js.type = "Long") startedTimeNanos
+  26: optional string activityID
+  28: optional string requestID
+  30: optional binary details
+  32: optional i32 scheduleToStartTimeoutSeconds
+  34: optional i32 scheduleToCloseTimeoutSeconds
+  36: optional i32 startToCloseTimeoutSeconds
+  38: optional i32 heartbeatTimeoutSeconds
+  40: optional bool cancelRequested
+  42: optional i64 (js.type = "Long") cancelRequestID
+  44: optional i64 (js.type = "Long") lastHeartbeatUpdatedTimeNanos
+  46: optional i32 timerTaskStatus
+  48: optional bool paused
+  50: optional i32 attempt
+  52: optional string domainID
+  54: optional string startedIdentity
+  56: optional string taskList
+  58: optional bool hasRetryPolicy
+  60: optional i32 retryInitialIntervalSeconds
+  62: optional double retryBackoffCoefficient
+  64: optional i32 retryMaximumIntervalSeconds
+  66: optional i64 (js.type = "Long") retryExpirationTimeNanos
+  68: optional i32 retryMaximumAttempts
+  70: optional list<string> retryNonRetryableErrors
+  72: optional string retryLastFailureReason
+  74: optional string retryLastWorkerIdentity
+}
+
+struct TimerInfo {
+  10: optional i64 (js.type = "Long") version
+  12: optional string timerID
+  14: optional i64 (js.type = "Long") startedID
+  16: optional i64 (js.type = "Long") expiryTimeNanos
+  18: optional i64 (js.type = "Long") taskID
+}
+
+struct ChildExecutionInfo {
+  10: optional i64 (js.type = "Long") version
+  12: optional i64 (js.type = "Long") initiatedID
+  14: optional i64 (js.type = "Long") initiatedEventBatchID
+  16: optional shared.HistoryEvent initiatedEvent
+  18: optional i64 (js.type = "Long") startedID
+  20: optional string startedWorkflowID
+  22: optional string startedRunID
+  24: optional shared.HistoryEvent startedEvent
+  26: optional string createRequestID
+  28: optional string domainName
+  30: optional string workflowTypeName
+}
+
+struct RequestCancelInfo {
+  10: optional i64 (js.type = "Long") version
+  12: optional i64 (js.type = "Long") initiatedID
+  14: optional string cancelRequestID
+}
+
+struct SignalInfo {
+  10: optional i64 (js.type = "Long") version
+  12: optional i64 (js.type = "Long") initiatedID
+  14: optional string requestID
+  16: optional string name
+  18: optional binary input
+  20: optional binary control
+}
+
+struct UpdateInfo {
+  10: optional i64 (js.type = "Long") version
+  12: optional string updateID
+  14: optional string name
+  16: optional binary input
+  18: optional binary result
+  20: optional bool completed
+}
@@ -31,6 +31,10 @@ exception ShardOwnershipLostError {
   20: optional string owner
 }
 
+exception WorkflowExecutionPausedError {
+  1: required string message
+}
+
 struct ParentExecutionInfo {
   10: optional string domainUUID
   15: optional string domain
@@ -59,6 +63,8 @@ struct DescribeMutableStateRequest{
 struct DescribeMutableStateResponse{
   30: optional string mutableStateInCache
   40: optional string mutableStateInDatabase
+  50: optional i64 (js.type = "Long") lastFirstEventId
+  60: optional i64 (js.type = "Long") lastEventTaskId
 }
 
 struct GetMutableStateRequest {
@@ -171,6 +177,10 @@ struct RecordDecisionTaskStartedResponse {
   110: optional binary branchToken
   120:  optional i64 (js.type = "Long") scheduledTimestamp
   130:  optional i64 (js.type = "Long") startedTimestamp
+  140: optional bool continueAsNewSuggested
+  150: optional list<shared.WorkflowUpdate> pendingUpdates
+  // attempt of the decision to put in its task token, attempt only counts failures while this also counts heartbeats
+  160: optional i64 (js.type = "Long") scheduleAttempt
 }
 
 struct SignalWorkflowExecutionRequest {
@@ -180,6 +190,11 @@ struct SignalWorkflowExecutionRequest {
   40: optional bool childWorkflowOnly
 }
 
+struct UpdateWorkflowExecutionRequest {
+  10: optional string domainUUID
+  20: optional shared.UpdateWorkflowExecutionRequest updateRequest
+}
+
 struct SignalWithStartWorkflowExecutionRequest {
   10: optional string domainUUID
   20: optional shared.SignalWithStartWorkflowExecutionRequest signalWithStartRequest
@@ -191,6 +206,26 @@ struct RemoveSignalMutableStateRequest {
   30: optional string requestId
 }
 
+struct SetActivityPausedRequest {
+  10: optional string domainUUID
+  20: optional shared.SetActivityPausedRequest pauseRequest
+}
+
+struct SetWorkflowExecutionNotesRequest {
+  10: optional string domainUUID
+  20: optional shared.SetWorkflowExecutionNotesRequest notesRequest
+}
+
+struct SetWorkflowExecutionPausedRequest {
+  10: optional string domainUUID
+  20: optional shared.SetWorkflowExecutionPausedRequest pauseRequest
+}
+
+struct SetCronSchedulePausedRequest {
+  10: optional string domainUUID
+  20: optional shared.SetCronSchedulePausedRequest pauseRequest
+}
+
 struct TerminateWorkflowExecutionRequest {
   10: optional string domainUUID
   20: optional shared.TerminateWorkflowExecutionRequest terminateRequest
@@ -201,6 +236,26 @@ struct ResetWorkflowExecutionRequest {
   20: optional shared.ResetWorkflowExecutionRequest resetRequest
 }
 
+struct TerminateAllWorkflowRunsRequest {
+  10: optional string domainUUID
+  20: optional shared.TerminateAllWorkflowRunsRequest terminateRequest
+}
+
+struct ImportWorkflowExecutionRequest {
+  10: optional string domainUUID
+  20: optional string workflowId
+  30: optional binary snapshot
+}
+
+struct ImportWorkflowExecutionResponse {
+  10: optional string runId
+}
+
+struct PurgeWorkflowExecutionRequest {
+  10: optional string domainUUID
+  20: optional shared.WorkflowExecution workflowExecution
+}
+
 struct RequestCancelWorkflowExecutionRequest {
   10: optional string domainUUID
   20: optional shared.RequestCancelWorkflowExecutionRequest cancelRequest
@@ -249,6 +304,8 @@ struct ReplicateEventsRequest {
   110: optional i32 eventStoreVersion
   120: optional i32 newRunEventStoreVersion
   130: optional bool resetWorkflow
+  140: optional shared.DataBlob historyBlob
+  150: optional shared.DataBlob newRunHistoryBlob
 }
 
 struct ReplicateRawEventsRequest {
@@ -267,6 +324,12 @@ struct SyncShardStatusRequest {
   30: optional i64 (js.type = "Long") timestamp
 }
 
+struct ReconcileDomainOpenExecutionCountsRequest {
+  10: optional i32 shardID
+  20: optional map<string, i64> domainOpenExecutionCounts
+  30: optional bool startScan
+}
+
 struct SyncActivityRequest {
   10: optional string domainId
   20: optional string workflowId
@@ -353,6 +416,7 @@ service HistoryService {
       6: shared.DomainNotActiveError domainNotActiveError,
       7: shared.LimitExceededError limitExceededError,
       8: shared.ServiceBusyError serviceBusyError,
+      9: WorkflowExecutionPausedError workflowExecutionPausedError,
     )
 
   /**
@@ -493,6 +557,21 @@ service HistoryService {
       7: shared.LimitExceededError limitExceededError,
     )
 
+  /**
+  * UpdateWorkflowExecution is used to send an update to a running workflow execution. The update is kept in
+  * mutable state until a worker reports its result on decision task completion, and the call blocks until then.
+  **/
+  shared.UpdateWorkflowExecutionResponse UpdateWorkflowExecution(1: UpdateWorkflowExecutionRequest updateRequest)
+    throws (
+      1: shared.BadRequestError badRequestError,
+      2: shared.InternalServiceError internalServiceError,
+      3: shared.EntityNotExistsError entityNotExistError,
+      4: ShardOwnershipLostError shardOwnershipLostError,
+      5: shared.DomainNotActiveError domainNotActiveError,
+      6: shared.ServiceBusyError serviceBusyError,
+      7: shared.LimitExceededError limitExceededError,
+    )
+
   /**
   * SignalWithStartWorkflowExecution is used to ensure sending a signal event to a workflow execution.
   * If workflow is running, this results in WorkflowExecutionSignaled event recorded in the history
@@ -527,6 +606,65 @@ service HistoryService {
       7: shared.ServiceBusyError serviceBusyError,
     )
 
+  /**
+  * SetActivityPaused pauses or unpauses a pending activity of a workflow execution.
+  **/
+  void SetActivityPaused(1: SetActivityPausedRequest pauseRequest)
+    throws (
+      1: shared.BadRequestError badRequestError,
+      2: shared.InternalServiceError internalServiceError,
+      3: shared.EntityNotExistsError entityNotExistError,
+      4: ShardOwnershipLostError shardOwnershipLostError,
+      5: shared.DomainNotActiveError domainNotActiveError,
+      6: shared.LimitExceededError limitExceededError,
+      7: shared.ServiceBusyError serviceBusyError,
+    )
+
+  /**
+  * SetWorkflowExecutionNotes attaches operator notes to a running workflow execution, replacing the previous
+  * ones, the notes are kept in the mutable state and are not part of history.
+  **/
+  void SetWorkflowExecutionNotes(1: SetWorkflowExecutionNotesRequest notesRequest)
+    throws (
+      1: shared.BadRequestError badRequestError,
+      2: shared.InternalServiceError internalServiceError,
+      3: shared.EntityNotExistsError entityNotExistError,
+      4: ShardOwnershipLostError shardOwnershipLostError,
+      5: shared.DomainNotActiveError domainNotActiveError,
+      6: shared.LimitExceededError limitExceededError,
+      7: shared.ServiceBusyError serviceBusyError,
+    )
+
+  /**
+  * SetWorkflowExecutionPaused pauses or resumes a workflow execution, no decision task of a paused
+  * workflow execution is dispatched.
+  **/
+  void SetWorkflowExecutionPaused(1: SetWorkflowExecutionPausedRequest pauseRequest)
+    throws (
+      1: shared.BadRequestError badRequestError,
+      2: shared.InternalServiceError internalServiceError,
+      3: shared.EntityNotExistsError entityNotExistError,
+      4: ShardOwnershipLostError shardOwnershipLostError,
+      5: shared.DomainNotActiveError domainNotActiveError,
+      6: shared.LimitExceededError limitExceededError,
+      7: shared.ServiceBusyError serviceBusyError,
+    )
+
+  /**
+  * SetCronSchedulePaused pauses or resumes the cron schedule of a workflow, the cron backoff timer of a run does
+  * not schedule its first decision while the schedule is paused.
+  **/
+  void SetCronSchedulePaused(1: SetCronSchedulePausedRequest pauseRequest)
+    throws (
+      1: shared.BadRequestError badRequestError,
+      2: shared.InternalServiceError internalServiceError,
+      3: shared.EntityNotExistsError entityNotExistError,
+      4: ShardOwnershipLostError shardOwnershipLostError,
+      5: shared.DomainNotActiveError domainNotActiveError,
+      6: shared.LimitExceededError limitExceededError,
+      7: shared.ServiceBusyError serviceBusyError,
+    )
+
   /**
   * TerminateWorkflowExecution terminates an existing workflow execution by recording WorkflowExecutionTerminated event
   * in the history and immediately terminating the execution instance.
@@ -542,6 +680,53 @@ service HistoryService {
       7: shared.ServiceBusyError serviceBusyError,
     )
 
+  /**
+  * TerminateAllWorkflowRuns terminates the current run of a workflow ID and every run the chain of the current run
+  * continues as new into while it is being terminated. Continue as new, cron and retry of the workflow ID are
+  * rejected for a while so that the chain cannot start a new run right after it is terminated.
+  **/
+  shared.TerminateAllWorkflowRunsResponse TerminateAllWorkflowRuns(1: TerminateAllWorkflowRunsRequest terminateRequest)
+    throws (
+      1: shared.BadRequestError badRequestError,
+      2: shared.InternalServiceError internalServiceError,
+      3: shared.EntityNotExistsError entityNotExistError,
+      4: ShardOwnershipLostError shardOwnershipLostError,
+      5: shared.DomainNotActiveError domainNotActiveError,
+      6: shared.LimitExceededError limitExceededError,
+      7: shared.ServiceBusyError serviceBusyError,
+    )
+
+  /**
+  * ImportWorkflowExecution recreates a closed workflow execution from an exported snapshot as a new run of the
+  * workflow, with its visibility record, retention timer and, for global domains, its replication tasks.
+  * It fails with 'BadRequestError' if a run of the workflow is open.
+  **/
+  ImportWorkflowExecutionResponse ImportWorkflowExecution(1: ImportWorkflowExecutionRequest importRequest)
+    throws (
+      1: shared.BadRequestError badRequestError,
+      2: shared.InternalServiceError internalServiceError,
+      3: shared.EntityNotExistsError entityNotExistError,
+      4: ShardOwnershipLostError shardOwnershipLostError,
+      5: shared.LimitExceededError limitExceededError,
+      6: shared.ServiceBusyError serviceBusyError,
+    )
+
+  /**
+  * PurgeWorkflowExecution deletes the mutable state, the current record, the history, the closed execution index
+  * and the buffered replication tasks of a closed run, and evicts the run from the cache of the host.
+  * It fails with 'BadRequestError' if the run is open.
+  **/
+  void PurgeWorkflowExecution(1: PurgeWorkflowExecutionRequest purgeRequest)
+    throws (
+      1: shared.BadRequestError badRequestError,
+      2: shared.InternalServiceError internalServiceError,
+      3: shared.EntityNotExistsError entityNotExistError,
+      4: ShardOwnershipLostError shardOwnershipLostError,
+      5: shared.DomainNotActiveError domainNotActiveError,
+      6: shared.LimitExceededError limitExceededError,
+      7: shared.ServiceBusyError serviceBusyError,
+    )
+
   /**
   * ResetWorkflowExecution reset an existing workflow execution by a firstEventID of a existing event batch
   * in the history and immediately terminating the current execution instance.
@@ -690,4 +875,59 @@ service HistoryService {
       2: shared.InternalServiceError internalServiceError,
       3: shared.AccessDeniedError accessDeniedError,
     )
+
+  /**
+  * DrainHistoryHost stops the history host from acquiring shards and releases the shards it owns
+  **/
+  shared.DrainHistoryHostResponse DrainHistoryHost(1: shared.DrainHistoryHostRequest request)
+    throws (
+      1: shared.BadRequestError badRequestError,
+      2: shared.InternalServiceError internalServiceError,
+      3: shared.AccessDeniedError accessDeniedError,
+    )
+
+  /**
+  * UndrainHistoryHost adds the drained history host back to the membership ring
+  **/
+  shared.UndrainHistoryHostResponse UndrainHistoryHost(1: shared.UndrainHistoryHostRequest request)
+    throws (
+      1: shared.BadRequestError badRequestError,
+      2: shared.InternalServiceError internalServiceError,
+      3: shared.AccessDeniedError accessDeniedError,
+    )
+
+  /**
+  * DescribeClientVersions returns the client versions which completed decisions on the history host
+  **/
+  shared.DescribeClientVersionsResponse DescribeClientVersions(1: shared.DescribeClientVersionsRequest request)
+    throws (
+      1: shared.BadRequestError badRequestError,
+      2: shared.InternalServiceError internalServiceError,
+      3: shared.AccessDeniedError accessDeniedError,
+    )
+
+  /**
+  * GetDomainOpenExecutionCount returns the approximate number of open executions of the domain
+  * owned by the shards of the history host
+  **/
+  shared.GetDomainOpenExecutionCountResponse GetDomainOpenExecutionCount(1: shared.GetDomainOpenExecutionCountRequest request)
+    throws (
+      1: shared.BadRequestError badRequestError,
+      2: shared.InternalServiceError internalServiceError,
+      3: shared.EntityNotExistsError entityNotExistError,
+      4: shared.AccessDeniedError accessDeniedError,
+    )
+
+  /**
+  * ReconcileDomainOpenExecutionCounts is called with startScan before the open executions are counted, so that the
+  * shard tracks the changes of its counters during the scan, then with the counts of the scanned domains, which
+  * replace their counters along with the changes tracked since the scan started
+  **/
+  void ReconcileDomainOpenExecutionCounts(1: ReconcileDomainOpenExecutionCountsRequest request)
+    throws (
+      1: shared.BadRequestError badRequestError,
+      2: shared.InternalServiceError internalServiceError,
+      3: ShardOwnershipLostError shardOwnershipLostError,
+      4: shared.AccessDeniedError accessDeniedError,
+    )
 }
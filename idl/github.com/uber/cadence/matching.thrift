@@ -45,6 +45,9 @@ struct PollForDecisionTaskResponse {
   120: optional binary branchToken
   130:  optional i64 (js.type = "Long") scheduledTimestamp
   140:  optional i64 (js.type = "Long") startedTimestamp
+  150: optional bool continueAsNewSuggested
+  160: optional list<shared.WorkflowUpdate> pendingUpdates
+  170: optional shared.TaskListScalingHints scalingHints
 }
 
 struct PollForActivityTaskRequest {
@@ -59,6 +62,8 @@ struct AddDecisionTaskRequest {
   30: optional shared.TaskList taskList
   40: optional i64 (js.type = "Long") scheduleId
   50: optional i32 scheduleToStartTimeoutSeconds
+  // binary checksum of the worker which last completed a decision of the execution
+  60: optional string workerBuildId
 }
 
 struct AddActivityTaskRequest {
@@ -95,6 +100,22 @@ struct DescribeTaskListRequest {
   20: optional shared.DescribeTaskListRequest descRequest
 }
 
+struct GetTaskListScalingHintsRequest {
+  10: optional string domainUUID
+  20: optional shared.GetTaskListScalingHintsRequest hintsRequest
+}
+
+struct DescribeTaskJourneyRequest {
+  10: optional string domainUUID
+  20: optional shared.DescribeTaskJourneyRequest journeyRequest
+}
+
+struct UpdateTaskListVersionSetsRequest {
+  10: optional string domainUUID
+  20: optional shared.TaskList taskList
+  30: optional list<shared.TaskListVersionSet> versionSets
+}
+
 /**
 * MatchingService API is exposed to provide support for polling from long running applications.
 * Such applications are expected to have a worker which regularly polls for DecisionTask and ActivityTask.  For each
@@ -205,4 +226,37 @@ service MatchingService {
         3: shared.EntityNotExistsError entityNotExistError,
         4: shared.ServiceBusyError serviceBusyError,
       )
+
+  /**
+  * GetTaskListScalingHints returns the backlog and dispatch rate of a tasklist partition, to scale its pollers.
+  **/
+  shared.GetTaskListScalingHintsResponse GetTaskListScalingHints(1: GetTaskListScalingHintsRequest request)
+    throws (
+        1: shared.BadRequestError badRequestError,
+        2: shared.InternalServiceError internalServiceError,
+        3: shared.EntityNotExistsError entityNotExistError,
+        4: shared.ServiceBusyError serviceBusyError,
+      )
+
+  /**
+  * UpdateTaskListVersionSets replaces the worker build ID version sets of a decision task list.
+  **/
+  void UpdateTaskListVersionSets(1: UpdateTaskListVersionSetsRequest request)
+    throws (
+        1: shared.BadRequestError badRequestError,
+        2: shared.InternalServiceError internalServiceError,
+        3: shared.ServiceBusyError serviceBusyError,
+      )
+
+  /**
+  * DescribeTaskJourney returns the stages recently reached by a task on the partitions of a task list owned by
+  * the host.
+  **/
+  shared.DescribeTaskJourneyResponse DescribeTaskJourney(1: DescribeTaskJourneyRequest request)
+    throws (
+        1: shared.BadRequestError badRequestError,
+        2: shared.InternalServiceError internalServiceError,
+        3: shared.EntityNotExistsError entityNotExistError,
+        4: shared.ServiceBusyError serviceBusyError,
+      )
 }
@@ -60,6 +60,8 @@ struct HistoryTaskAttributes {
   100: optional i32 eventStoreVersion
   110: optional i32 newRunEventStoreVersion
   120: optional bool resetWorkflow
+  130: optional shared.DataBlob historyBlob
+  140: optional shared.DataBlob newRunHistoryBlob
 }
 
 struct HistoryMetadataTaskAttributes {
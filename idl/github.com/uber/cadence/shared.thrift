@@ -177,6 +177,10 @@ enum EventType {
   SignalExternalWorkflowExecutionFailed,
   ExternalWorkflowExecutionSignaled,
   UpsertWorkflowSearchAttributes,
+  WorkflowExecutionUpdateRequested,
+  WorkflowExecutionUpdateCompleted,
+  WorkflowExecutionPauseUpdated,
+  CronSchedulePauseUpdated,
 }
 
 enum DecisionTaskFailedCause {
@@ -286,6 +290,7 @@ struct TaskList {
 
 enum EncodingType {
   ThriftRW,
+  JSON,
 }
 
 struct DataBlob {
@@ -328,6 +333,7 @@ struct WorkflowExecutionInfo {
   100: optional Memo memo
   101: optional SearchAttributes searchAttributes
   110: optional ResetPoints autoResetPoints
+  120: optional string firstExecutionRunId
 }
 
 struct WorkflowExecutionConfiguration {
@@ -422,6 +428,7 @@ struct ContinueAsNewWorkflowExecutionDecisionAttributes {
   100: optional binary failureDetails
   110: optional binary lastCompletionResult
   120: optional string cronSchedule
+  121: optional string cronTimezone
   130: optional Header header
 }
 
@@ -438,6 +445,7 @@ struct StartChildWorkflowExecutionDecisionAttributes {
   100: optional WorkflowIdReusePolicy workflowIdReusePolicy
   110: optional RetryPolicy retryPolicy
   120: optional string cronSchedule
+  121: optional string cronTimezone
   130: optional Header header
 }
 
@@ -480,11 +488,15 @@ struct WorkflowExecutionStartedEventAttributes {
   80: optional i32 attempt
   90: optional i64 (js.type = "Long") expirationTimestamp
   100: optional string cronSchedule
+  101: optional string cronTimezone
   110: optional i32 firstDecisionTaskBackoffSeconds
   120: optional Memo memo
   121: optional SearchAttributes searchAttributes
   130: optional ResetPoints prevAutoResetPoints
   140: optional Header header
+  150: optional i64 (js.type = "Long") slaDeadlineTimestamp
+  // carried over from the previous run of a paused cron schedule
+  160: optional bool cronPaused
 }
 
 struct ResetPoints{
@@ -749,6 +761,27 @@ struct UpsertWorkflowSearchAttributesEventAttributes {
   20: optional SearchAttributes searchAttributes
 }
 
+struct WorkflowExecutionUpdateRequestedEventAttributes {
+  10: optional string updateId
+  20: optional string updateName
+  30: optional binary input
+  40: optional string identity
+}
+
+struct WorkflowExecutionUpdateCompletedEventAttributes {
+  10: optional string updateId
+  20: optional binary result
+  30: optional i64 (js.type = "Long") decisionTaskCompletedEventId
+}
+
+struct WorkflowExecutionPauseUpdatedEventAttributes {
+  10: optional bool paused
+}
+
+struct CronSchedulePauseUpdatedEventAttributes {
+  10: optional bool paused
+}
+
 struct StartChildWorkflowExecutionInitiatedEventAttributes {
   10:  optional string domain
   20:  optional string workflowId
@@ -763,6 +796,7 @@ struct StartChildWorkflowExecutionInitiatedEventAttributes {
   110: optional WorkflowIdReusePolicy workflowIdReusePolicy
   120: optional RetryPolicy retryPolicy
   130: optional string cronSchedule
+  131: optional string cronTimezone
   140: optional Header header
 }
 
@@ -877,6 +911,10 @@ struct HistoryEvent {
   430: optional SignalExternalWorkflowExecutionFailedEventAttributes signalExternalWorkflowExecutionFailedEventAttributes
   440: optional ExternalWorkflowExecutionSignaledEventAttributes externalWorkflowExecutionSignaledEventAttributes
   450: optional UpsertWorkflowSearchAttributesEventAttributes upsertWorkflowSearchAttributesEventAttributes
+  460: optional WorkflowExecutionUpdateRequestedEventAttributes workflowExecutionUpdateRequestedEventAttributes
+  470: optional WorkflowExecutionUpdateCompletedEventAttributes workflowExecutionUpdateCompletedEventAttributes
+  480: optional WorkflowExecutionPauseUpdatedEventAttributes workflowExecutionPauseUpdatedEventAttributes
+  490: optional CronSchedulePauseUpdatedEventAttributes cronSchedulePauseUpdatedEventAttributes
 }
 
 struct History {
@@ -912,6 +950,30 @@ struct DomainConfiguration {
   30: optional string archivalBucketName
   50: optional ArchivalStatus archivalStatus
   70: optional BadBinaries badBinaries
+  // number of most recent completed runs kept per workflow ID, 0 keeps all runs within the retention period
+  80: optional i32 workflowExecutionRetentionRunCount
+  90: optional DomainTimeoutPolicy timeoutPolicy
+  // alias of the key management service key the archived blobs of the domain are encrypted with
+  100: optional string encryptionKeyAlias
+  110: optional DomainResidency residency
+}
+
+// defaults applied to the timeouts left unset by the requests of a domain, and maxima the requested timeouts are
+// lowered to, 0 means no default or no maximum
+struct DomainTimeoutPolicy {
+  10: optional i32 defaultExecutionStartToCloseTimeoutSeconds
+  20: optional i32 maxExecutionStartToCloseTimeoutSeconds
+  30: optional i32 defaultTaskStartToCloseTimeoutSeconds
+  40: optional i32 maxTaskStartToCloseTimeoutSeconds
+  50: optional i32 defaultActivityScheduleToCloseTimeoutSeconds
+  60: optional i32 maxActivityTimeoutSeconds
+}
+
+// data residency of a domain, the executions of the domain are only placed in and replicated to the allowed clusters,
+// an empty list of allowed clusters puts no restriction on the clusters
+struct DomainResidency {
+  10: optional string tag
+  20: optional list<string> allowedClusters
 }
 
 struct BadBinaries{
@@ -954,6 +1016,10 @@ struct RegisterDomainRequest {
   100: optional ArchivalStatus archivalStatus
   110: optional string archivalBucketName
   120: optional bool isGlobalDomain
+  130: optional i32 workflowExecutionRetentionRunCount
+  140: optional DomainTimeoutPolicy timeoutPolicy
+  150: optional string encryptionKeyAlias
+  160: optional DomainResidency residency
 }
 
 struct ListDomainsRequest {
@@ -1015,9 +1081,13 @@ struct StartWorkflowExecutionRequest {
   110: optional ChildPolicy childPolicy
   120: optional RetryPolicy retryPolicy
   130: optional string cronSchedule
+  // IANA time zone the cron schedule is evaluated in, UTC if not set
+  131: optional string cronTimezone
   140: optional Memo memo
   141: optional SearchAttributes searchAttributes
   150: optional Header header
+  // unix nano time after which the workflow is flagged as exceeding its SLA if still open
+  160: optional i64 (js.type = "Long") slaDeadlineTimestamp
 }
 
 struct StartWorkflowExecutionResponse {
@@ -1045,6 +1115,9 @@ struct PollForDecisionTaskResponse {
   90: optional TaskList WorkflowExecutionTaskList
   100:  optional i64 (js.type = "Long") scheduledTimestamp
   110:  optional i64 (js.type = "Long") startedTimestamp
+  120: optional bool continueAsNewSuggested
+  130: optional list<WorkflowUpdate> pendingUpdates
+  140: optional TaskListScalingHints scalingHints
 }
 
 struct StickyExecutionAttributes {
@@ -1061,6 +1134,7 @@ struct RespondDecisionTaskCompletedRequest {
   60: optional bool returnNewDecisionTask
   70: optional bool forceCreateNewDecisionTask
   80: optional string binaryChecksum
+  90: optional list<WorkflowUpdateResult> updateResults
 }
 
 struct RespondDecisionTaskCompletedResponse {
@@ -1171,6 +1245,7 @@ struct RequestCancelWorkflowExecutionRequest {
   20: optional WorkflowExecution workflowExecution
   30: optional string identity
   40: optional string requestId
+  50: optional string firstExecutionRunId // only cancel the current run if it belongs to the chain started by this run
 }
 
 struct GetWorkflowExecutionHistoryRequest {
@@ -1180,12 +1255,17 @@ struct GetWorkflowExecutionHistoryRequest {
   40: optional binary nextPageToken
   50: optional bool waitForNewEvent
   60: optional HistoryEventFilterType HistoryEventFilterType
+  // the history is returned as the persisted event batches in rawHistory, only when the client asks for it
+  70: optional bool rawHistory
+  // only the events of these types are returned when set, the filter is applied by the server
+  80: optional list<EventType> eventTypeFilter
 }
 
 struct GetWorkflowExecutionHistoryResponse {
   10: optional History history
   20: optional binary nextPageToken
   30: optional bool archived
+  40: optional list<DataBlob> rawHistory
 }
 
 struct SignalWorkflowExecutionRequest {
@@ -1198,6 +1278,30 @@ struct SignalWorkflowExecutionRequest {
   70: optional binary control
 }
 
+struct UpdateWorkflowExecutionRequest {
+  10: optional string domain
+  20: optional WorkflowExecution workflowExecution
+  30: optional string updateName
+  40: optional binary input
+  50: optional string identity
+  60: optional string requestId
+}
+
+struct UpdateWorkflowExecutionResponse {
+  10: optional binary result
+}
+
+struct WorkflowUpdate {
+  10: optional string updateId
+  20: optional string updateName
+  30: optional binary input
+}
+
+struct WorkflowUpdateResult {
+  10: optional string updateId
+  20: optional binary result
+}
+
 struct SignalWithStartWorkflowExecutionRequest {
   10: optional string domain
   20: optional string workflowId
@@ -1214,9 +1318,11 @@ struct SignalWithStartWorkflowExecutionRequest {
   130: optional binary control
   140: optional RetryPolicy retryPolicy
   150: optional string cronSchedule
+  151: optional string cronTimezone
   160: optional Memo memo
   161: optional SearchAttributes searchAttributes
   170: optional Header header
+  180: optional i64 (js.type = "Long") slaDeadlineTimestamp
 }
 
 struct TerminateWorkflowExecutionRequest {
@@ -1225,6 +1331,7 @@ struct TerminateWorkflowExecutionRequest {
   30: optional string reason
   40: optional binary details
   50: optional string identity
+  60: optional string firstExecutionRunId // only terminate the current run if it belongs to the chain started by this run
 }
 
 struct ResetWorkflowExecutionRequest {
@@ -1345,6 +1452,16 @@ struct PendingActivityInfo {
   120: optional string lastWorkerIdentity
 }
 
+struct PendingDecisionInfo {
+  10: optional i64 (js.type = "Long") scheduleID
+  20: optional i64 (js.type = "Long") startedID
+  30: optional i64 (js.type = "Long") attempt
+  40: optional i64 (js.type = "Long") scheduledTimestamp
+  50: optional i64 (js.type = "Long") startedTimestamp
+  60: optional string startedIdentity
+  70: optional string startedBinaryChecksum
+}
+
 struct PendingChildExecutionInfo {
   10: optional string workflowID
   20: optional string runID
@@ -1352,11 +1469,26 @@ struct PendingChildExecutionInfo {
   40: optional i64 (js.type = "Long") initiatedID
 }
 
+struct WorkflowExecutionStatistics {
+  10: optional i64 (js.type = "Long") historySize
+  20: optional i32 signalCount
+  30: optional i32 pendingActivityCount
+  40: optional i32 pendingTimerCount
+  50: optional i32 pendingChildExecutionCount
+  60: optional i32 pendingRequestCancelCount
+  70: optional i32 pendingSignalCount
+}
+
 struct DescribeWorkflowExecutionResponse {
   10: optional WorkflowExecutionConfiguration executionConfiguration
   20: optional WorkflowExecutionInfo workflowExecutionInfo
   30: optional list<PendingActivityInfo> pendingActivities
   40: optional list<PendingChildExecutionInfo> pendingChildren
+  50: optional WorkflowExecutionStatistics executionStatistics
+  60: optional WorkflowExecutionNotes executionNotes
+  70: optional PendingDecisionInfo pendingDecision
+  80: optional CronScheduleInfo cronScheduleInfo
+  90: optional ResetLineageInfo resetLineage
 }
 
 struct DescribeTaskListRequest {
@@ -1369,6 +1501,7 @@ struct DescribeTaskListRequest {
 struct DescribeTaskListResponse {
   10: optional list<PollerInfo> pollers
   20: optional TaskListStatus taskListStatus
+  30: optional list<TaskListVersionSet> versionSets
 }
 
 struct TaskListStatus {
@@ -1377,6 +1510,61 @@ struct TaskListStatus {
   30: optional i64 (js.type = "Long") ackLevel
   35: optional double ratePerSecond
   40: optional TaskIDBlock taskIDBlock
+  50: optional i64 (js.type = "Long") scheduleToStartLatencyP95Millis
+  60: optional i64 (js.type = "Long") scheduleToStartLatencyP99Millis
+}
+
+// TaskListScalingHints describe the demand on a task list partition as observed by the server, so that autoscalers
+// and workers can tune the number of pollers. backlogAgeMillis is the age of the oldest task waiting for a poller and
+// dispatchRatePerSecond the rate at which tasks were handed to pollers over the last minute.
+struct TaskListScalingHints {
+  10: optional i64 (js.type = "Long") backlogCountHint
+  20: optional i64 (js.type = "Long") backlogAgeMillis
+  30: optional double dispatchRatePerSecond
+  40: optional i32 pollerCount
+}
+
+struct GetTaskListScalingHintsRequest {
+  10: optional string domain
+  20: optional TaskList taskList
+  30: optional TaskListType taskListType
+}
+
+struct GetTaskListScalingHintsResponse {
+  10: optional TaskListScalingHints scalingHints
+}
+
+// TaskJourneyEvent is a stage reached by an activity or decision task in matching: created when the task is added to
+// the task list, persisted when it is written to the task list backlog, matched when it is handed to a poller and
+// started when it is recorded as started in history.
+struct TaskJourneyEvent {
+  10: optional string stage
+  20: optional i64 (js.type = "Long") timestamp
+  // name of the task list partition the stage was reached on
+  30: optional string taskList
+  // ID of the persisted task, not set for tasks matched before being persisted
+  40: optional i64 (js.type = "Long") taskId
+}
+
+// DescribeTaskJourneyRequest identifies a task either by the workflow execution and schedule ID it was created for,
+// or by the ID of the persisted task.
+struct DescribeTaskJourneyRequest {
+  10: optional string domain
+  20: optional TaskList taskList
+  30: optional TaskListType taskListType
+  40: optional WorkflowExecution execution
+  50: optional i64 (js.type = "Long") scheduleId
+  60: optional i64 (js.type = "Long") taskId
+}
+
+struct DescribeTaskJourneyResponse {
+  10: optional list<TaskJourneyEvent> events
+}
+
+// TaskListVersionSet is a set of worker build IDs whose workflow code is compatible with each other. The last build ID
+// of a set is the default of the set.
+struct TaskListVersionSet {
+  10: optional list<string> buildIds
 }
 
 struct TaskIDBlock {
@@ -1397,6 +1585,218 @@ struct DescribeHistoryHostResponse{
   30: optional DomainCacheInfo      domainCache
   40: optional string               shardControllerStatus
   50: optional string               address
+  60: optional i32                  numberOfCachedExecutions
+  70: optional list<HistoryShardInfo> shardInfos
+  80: optional BuildInfo            buildInfo
+  90: optional list<ShardMovement>  shardMovements
+}
+
+struct DrainHistoryHostRequest {
+  10: optional string               hostAddress //ip:port
+}
+
+struct DrainHistoryHostResponse {
+  10: optional string               address
+  20: optional list<i32>            drainedShardIDs
+  // shards released without persisting their ack levels
+  30: optional list<i32>            failedShardIDs
+}
+
+struct UndrainHistoryHostRequest {
+  10: optional string               hostAddress //ip:port
+}
+
+struct UndrainHistoryHostResponse {
+  10: optional string               address
+}
+
+struct ClientVersionInfo {
+  10: optional string               domain
+  20: optional string               clientImpl
+  30: optional string               featureVersion
+  40: optional string               libraryVersion
+  50: optional i64                  decisionCount
+  60: optional i64                  lastSeenTimestamp
+}
+
+struct DescribeClientVersionsRequest {
+  // all domains are returned when not set
+  10: optional string               domain
+  // only the versions seen by this history host are returned when set
+  20: optional string               hostAddress //ip:port
+}
+
+struct DescribeClientVersionsResponse {
+  10: optional list<ClientVersionInfo> clientVersions
+}
+
+struct GetDomainOpenExecutionCountRequest {
+  10: optional string               domain
+  // only the executions owned by this history host are counted when set
+  20: optional string               hostAddress //ip:port
+}
+
+struct GetDomainOpenExecutionCountResponse {
+  10: optional i64                  count
+}
+
+struct SetActivityPausedRequest {
+  10: optional string               domain
+  20: optional WorkflowExecution    execution
+  30: optional string               activityID
+  40: optional bool                 paused
+}
+
+struct SetWorkflowExecutionPausedRequest {
+  10: optional string               domain
+  20: optional WorkflowExecution    execution
+  30: optional bool                 paused
+}
+
+struct SetCronSchedulePausedRequest {
+  10: optional string               domain
+  20: optional WorkflowExecution    execution
+  30: optional bool                 paused
+}
+
+struct CronScheduleInfo {
+  10: optional string cronSchedule
+  20: optional bool paused
+  // unset while the schedule is paused
+  30: optional i64 (js.type = "Long") nextFireTime
+  40: optional string cronTimezone
+}
+
+// set on runs created by ResetWorkflowExecution
+struct ResetLineageInfo {
+  10: optional string baseRunId
+  // the decision finish event of the base run the reset was forked from
+  20: optional i64 (js.type = "Long") baseEventId
+  30: optional string reason
+}
+
+struct TerminateAllWorkflowRunsRequest {
+  10: optional string domain
+  20: optional string workflowId
+  30: optional string firstExecutionRunId // only terminate runs of the chain started by this run, defaults to the chain of the current run
+  40: optional string reason
+  50: optional string identity
+  60: optional i32 blockSeconds // new runs of the workflow ID are rejected for this long
+}
+
+struct TerminateAllWorkflowRunsResponse {
+  10: optional list<WorkflowExecution> terminatedExecutions
+}
+
+enum ScheduleOverlapPolicy {
+  // the fire is skipped while the workflow started by the previous fire is running
+  SKIP,
+  // the workflow is started even if the workflow started by the previous fire is running
+  ALLOW_ALL,
+  // the workflow started by the previous fire is terminated before starting the new one
+  TERMINATE_OTHER,
+  // cancellation of the workflow started by the previous fire is requested before starting the new one
+  CANCEL_OTHER,
+}
+
+struct ScheduleInfo {
+  10: optional string scheduleId
+  20: optional string cronSchedule
+  30: optional WorkflowType workflowType
+  40: optional TaskList taskList
+  50: optional binary input
+  60: optional i32 executionStartToCloseTimeoutSeconds
+  70: optional i32 taskStartToCloseTimeoutSeconds
+  80: optional ScheduleOverlapPolicy overlapPolicy
+  90: optional i32 catchupWindowSeconds
+  100: optional bool paused
+  110: optional i64 (js.type = "Long") lastFireTime
+  120: optional WorkflowExecution lastExecution
+  // unset while the schedule is paused
+  130: optional i64 (js.type = "Long") nextFireTime
+  140: optional i64 (js.type = "Long") backfillStartTime
+  150: optional i64 (js.type = "Long") backfillEndTime
+  160: optional string identity
+  170: optional i32 backfillRunsPerMinute
+  // last workflow started by a backfill, backfill runs are not subject to the overlap policy
+  180: optional WorkflowExecution lastBackfillExecution
+}
+
+struct CreateScheduleRequest {
+  10: optional string domain
+  20: optional string scheduleId
+  30: optional string cronSchedule
+  40: optional WorkflowType workflowType
+  50: optional TaskList taskList
+  60: optional binary input
+  70: optional i32 executionStartToCloseTimeoutSeconds
+  80: optional i32 taskStartToCloseTimeoutSeconds
+  90: optional ScheduleOverlapPolicy overlapPolicy
+  // fire times missed by more than the catch-up window, e.g. while the scheduler was down or the schedule
+  // was paused, are skipped
+  100: optional i32 catchupWindowSeconds
+  110: optional string identity
+}
+
+struct DescribeScheduleRequest {
+  10: optional string domain
+  20: optional string scheduleId
+}
+
+struct DescribeScheduleResponse {
+  10: optional ScheduleInfo schedule
+}
+
+struct DeleteScheduleRequest {
+  10: optional string domain
+  20: optional string scheduleId
+}
+
+struct ListSchedulesRequest {
+  10: optional string domain
+  20: optional i32 pageSize
+  30: optional binary nextPageToken
+}
+
+struct ListSchedulesResponse {
+  10: optional list<ScheduleInfo> schedules
+  20: optional binary nextPageToken
+}
+
+struct SetSchedulePausedRequest {
+  10: optional string domain
+  20: optional string scheduleId
+  30: optional bool paused
+}
+
+struct BackfillScheduleRequest {
+  10: optional string domain
+  20: optional string scheduleId
+  // every fire time of the schedule in [startTime, endTime] is started, regardless of the catch-up window
+  30: optional i64 (js.type = "Long") startTime
+  40: optional i64 (js.type = "Long") endTime
+  // maximum number of workflows started per minute by the backfill, defaults to 10
+  50: optional i32 runsPerMinute
+}
+
+struct UpdateTaskListVersionSetsRequest {
+  10: optional string domain
+  20: optional TaskList taskList
+  // ordered from the oldest to the newest, new workflow executions are dispatched to the default build of the last set
+  30: optional list<TaskListVersionSet> versionSets
+}
+
+struct WorkflowExecutionNotes {
+  10: optional string notes
+  20: optional string identity
+  30: optional i64 (js.type = "Long") lastUpdatedTimestamp
+}
+
+struct SetWorkflowExecutionNotesRequest {
+  10: optional string               domain
+  20: optional WorkflowExecution    execution
+  30: optional string               notes
+  40: optional string               identity
 }
 
 struct DomainCacheInfo{
@@ -1404,6 +1804,47 @@ struct DomainCacheInfo{
   20: optional i64 numOfItemsInCacheByName
 }
 
+struct HistoryShardInfo {
+  10: optional i32 shardID
+  20: optional i32 numberOfCachedExecutions
+  30: optional i64 transferAckLevel
+  40: optional i64 transferMaxReadLevel
+  50: optional i64 transferQueueLag
+  60: optional i64 timerAckLevel // unix nano
+  70: optional i64 timerQueueLagInMillis
+  80: optional i64 replicatorAckLevel
+  90: optional list<QueueAlarm> queueAlarms
+  100: optional double tasksPerSecond
+}
+
+// QueueAlarm is raised for a shard queue whose ack level stopped advancing
+// while new tasks kept being added, the blocking task is the earliest task not yet acked
+struct QueueAlarm {
+  10: optional string queue
+  20: optional i64 stuckSince // unix nano
+  30: optional i64 blockingTaskID
+  40: optional i64 blockingTaskTimestamp // unix nano
+}
+
+// ShardMovement is an ownership change of a shard observed by a history host,
+// owner is empty if the shard was released without knowing its new owner
+struct ShardMovement {
+  10: optional i32 shardID
+  20: optional string previousOwner
+  30: optional string owner
+  40: optional i64 rangeID
+  50: optional i32 stolenSinceRenew
+  60: optional i64 timestamp // unix nano
+}
+
+struct BuildInfo {
+  10: optional string revision
+  20: optional string branch
+  30: optional string version
+  40: optional string buildDate
+  50: optional string goVersion
+}
+
 enum TaskListType {
   /*
    * Decision type of tasklist
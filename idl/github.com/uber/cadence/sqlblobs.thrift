@@ -32,6 +32,22 @@ struct ShardInfo {
   34: optional map<string, i64> clusterTransferAckLevel
   36: optional map<string, i64> clusterTimerAckLevel
   38: optional string owner
+  40: optional map<string, i64> domainOpenExecutionCounts
+  44: optional i64 (js.type = "Long") visibilityAckLevel
+  46: optional map<string, i64> queueAlarmStuckSinceNanos
+  48: optional map<string, i64> queueAlarmBlockingTaskIDs
+  50: optional map<string, i64> queueAlarmBlockingTaskTimestampNanos
+  52: optional list<HotExecution> hotExecutions
+  54: optional i64 (js.type = "Long") leaseExpiresAtNanos
+  56: optional double tasksPerSecond
+  58: optional i64 (js.type = "Long") taskThroughputUpdatedAtNanos
+  60: optional list<string> placement
+}
+
+struct HotExecution {
+  10: optional binary domainID
+  12: optional string workflowID
+  14: optional binary runID
 }
 
 struct DomainInfo {
@@ -52,6 +68,16 @@ struct DomainInfo {
   38: optional map<string, string> data
   39: optional binary badBinaries
   40: optional string badBinariesEncoding
+  42: optional i32 retentionRunCount
+  44: optional i32 defaultExecutionTimeoutSeconds
+  46: optional i32 maxExecutionTimeoutSeconds
+  48: optional i32 defaultTaskTimeoutSeconds
+  50: optional i32 maxTaskTimeoutSeconds
+  52: optional i32 defaultActivityTimeoutSeconds
+  54: optional i32 maxActivityTimeoutSeconds
+  56: optional string encryptionKeyAlias
+  58: optional string residencyTag
+  60: optional list<string> residencyAllowedClusters
 }
 
 struct HistoryTreeInfo {
@@ -122,6 +148,20 @@ struct WorkflowExecutionInfo {
   115: optional binary autoResetPoints
   116: optional string autoResetPointsEncoding
   118: optional map<string, binary> searchAttributes
+  120: optional list<string> completedActivityRequestIDs
+  122: optional binary firstExecutionRunID
+  124: optional bool paused
+  126: optional bool cronPaused
+  128: optional string cronTimezone
+  130: optional string decisionStartedIdentity
+  132: optional string decisionStartedBinaryChecksum
+  134: optional binary resetBaseRunID
+  136: optional i64 (js.type = "Long") resetBaseEventID
+  138: optional string resetReason
+  140: optional string notes
+  142: optional string notesIdentity
+  144: optional i64 (js.type = "Long") notesLastUpdatedTimeNanos
+  146: optional i64 (js.type = "Long") decisionHeartbeatCount
 }
 
 struct ActivityInfo {
@@ -155,6 +195,7 @@ struct ActivityInfo {
   64: optional list<string> retryNonRetryableErrors
   66: optional string retryLastFailureReason
   68: optional string retryLastWorkerIdentity
+  70: optional bool paused
 }
 
 struct ChildExecutionInfo {
@@ -180,6 +221,14 @@ struct SignalInfo {
   18: optional binary control
 }
 
+struct UpdateInfo {
+  10: optional i64 (js.type = "Long") version
+  12: optional string name
+  14: optional binary input
+  16: optional binary result
+  18: optional bool completed
+}
+
 struct RequestCancelInfo {
   10: optional i64 (js.type = "Long") version
   12: optional string cancelRequestID
@@ -205,6 +254,7 @@ struct TaskListInfo {
   12: optional i64 (js.type = "Long") ackLevel
   14: optional i64 (js.type = "Long") expiryTimeNanos
   16: optional i64 (js.type = "Long") lastUpdatedNanos
+  18: optional list<shared.TaskListVersionSet> versionSets
 }
 
 struct TransferTaskInfo {
@@ -0,0 +1,326 @@
+CREATE TABLE domains(
+  shard_id INT NOT NULL DEFAULT 54321,
+  id BYTES NOT NULL,
+  name VARCHAR(255) UNIQUE NOT NULL,
+  --
+	data BYTES NOT NULL,
+	data_encoding VARCHAR(16) NOT NULL,
+	is_global BOOLEAN NOT NULL,
+  PRIMARY KEY(shard_id, id)
+);
+
+CREATE TABLE domain_metadata (
+  notification_version BIGINT NOT NULL
+);
+
+INSERT INTO domain_metadata (notification_version) VALUES (1);
+
+CREATE TABLE shards (
+  shard_id INT NOT NULL,
+  --
+  range_id BIGINT NOT NULL,
+  data BYTES NOT NULL,
+  data_encoding VARCHAR(16) NOT NULL,
+  PRIMARY KEY (shard_id)
+);
+
+CREATE TABLE transfer_tasks(
+  shard_id INT NOT NULL,
+  task_id BIGINT NOT NULL,
+  --
+  data BYTES NOT NULL,
+  data_encoding VARCHAR(16) NOT NULL,
+  PRIMARY KEY (shard_id, task_id)
+);
+
+CREATE TABLE executions(
+  shard_id INT NOT NULL,
+  domain_id BYTES NOT NULL,
+  workflow_id VARCHAR(255) NOT NULL,
+  run_id BYTES NOT NULL,
+  --
+  next_event_id BIGINT NOT NULL,
+  last_write_version BIGINT NOT NULL,
+  data BYTES NOT NULL,
+  data_encoding VARCHAR(16) NOT NULL,
+  PRIMARY KEY (shard_id, domain_id, workflow_id, run_id)
+);
+
+CREATE TABLE current_executions(
+  shard_id INT NOT NULL,
+  domain_id BYTES NOT NULL,
+  workflow_id VARCHAR(255) NOT NULL,
+  --
+  run_id BYTES NOT NULL,
+  create_request_id VARCHAR(64) NOT NULL,
+  state INT NOT NULL,
+  close_status INT NOT NULL,
+  start_version BIGINT NOT NULL,
+  last_write_version BIGINT NOT NULL,
+  blocked_until TIMESTAMP NOT NULL DEFAULT '1000-01-01 00:00:00',
+  PRIMARY KEY (shard_id, domain_id, workflow_id)
+);
+
+CREATE TABLE buffered_events (
+  id SERIAL NOT NULL,
+  shard_id INT NOT NULL,
+  domain_id BYTES NOT NULL,
+  workflow_id VARCHAR(255) NOT NULL,
+  run_id BYTES NOT NULL,
+  --
+  data BYTES NOT NULL,
+  data_encoding VARCHAR(16) NOT NULL,
+  PRIMARY KEY (id)
+);
+
+CREATE INDEX buffered_events_by_events_ids ON buffered_events(shard_id, domain_id, workflow_id, run_id);
+
+CREATE TABLE tasks (
+  domain_id BYTES NOT NULL,
+  task_list_name VARCHAR(255) NOT NULL,
+  task_type SMALLINT NOT NULL, -- {Activity, Decision}
+  task_id BIGINT NOT NULL,
+  --
+  data BYTES NOT NULL,
+  data_encoding VARCHAR(16) NOT NULL,
+  PRIMARY KEY (domain_id, task_list_name, task_type, task_id)
+);
+
+CREATE TABLE task_lists (
+  shard_id INT NOT NULL,
+  domain_id BYTES NOT NULL,
+  name VARCHAR(255) NOT NULL,
+  task_type SMALLINT NOT NULL, -- {Activity, Decision}
+  --
+  range_id BIGINT NOT NULL,
+  data BYTES NOT NULL,
+  data_encoding VARCHAR(16) NOT NULL,
+  PRIMARY KEY (shard_id, domain_id, name, task_type)
+);
+
+CREATE TABLE replication_tasks (
+  shard_id INT NOT NULL,
+  task_id BIGINT NOT NULL,
+  --
+  data BYTES NOT NULL,
+  data_encoding VARCHAR(16) NOT NULL,
+  PRIMARY KEY (shard_id, task_id)
+);
+
+CREATE TABLE visibility_tasks(
+  shard_id INT NOT NULL,
+  task_id BIGINT NOT NULL,
+  --
+  data BYTES NOT NULL,
+  data_encoding VARCHAR(16) NOT NULL,
+  PRIMARY KEY (shard_id, task_id)
+);
+
+CREATE TABLE timer_tasks (
+  shard_id INT NOT NULL,
+  visibility_timestamp TIMESTAMP NOT NULL,
+  task_id BIGINT NOT NULL,
+  --
+  data BYTES NOT NULL,
+  data_encoding VARCHAR(16) NOT NULL,
+  PRIMARY KEY (shard_id, visibility_timestamp, task_id)
+);
+
+CREATE TABLE closed_executions_index (
+  shard_id INT NOT NULL,
+  close_time TIMESTAMP NOT NULL,
+  domain_id BYTES NOT NULL,
+  workflow_id VARCHAR(255) NOT NULL,
+  run_id BYTES NOT NULL,
+  --
+  version BIGINT NOT NULL,
+  PRIMARY KEY (shard_id, close_time, domain_id, workflow_id, run_id)
+);
+
+-- Deprecated in favor of history eventsV2
+CREATE TABLE events (
+  domain_id      BYTES NOT NULL,
+  workflow_id    VARCHAR(255) NOT NULL,
+  run_id         BYTES NOT NULL,
+  first_event_id BIGINT NOT NULL,
+  --
+  batch_version  BIGINT,
+  range_id       BIGINT NOT NULL,
+  tx_id          BIGINT NOT NULL,
+  data BYTES NOT NULL,
+  data_encoding  VARCHAR(16) NOT NULL,
+	PRIMARY KEY (domain_id, workflow_id, run_id, first_event_id)
+);
+
+CREATE TABLE activity_info_maps (
+-- each row corresponds to one key of one map<string, ActivityInfo>
+  shard_id INT NOT NULL,
+  domain_id BYTES NOT NULL,
+  workflow_id VARCHAR(255) NOT NULL,
+  run_id BYTES NOT NULL,
+  schedule_id BIGINT NOT NULL,
+--
+  data BYTES NOT NULL,
+  data_encoding VARCHAR(16),
+  last_heartbeat_details BYTES,
+  last_heartbeat_updated_time TIMESTAMP NOT NULL,
+  PRIMARY KEY (shard_id, domain_id, workflow_id, run_id, schedule_id)
+);
+
+CREATE TABLE timer_info_maps (
+  shard_id INT NOT NULL,
+  domain_id BYTES NOT NULL,
+  workflow_id VARCHAR(255) NOT NULL,
+  run_id BYTES NOT NULL,
+  timer_id VARCHAR(255) NOT NULL,
+--
+  data BYTES NOT NULL,
+  data_encoding VARCHAR(16),
+  PRIMARY KEY (shard_id, domain_id, workflow_id, run_id, timer_id)
+);
+
+CREATE TABLE child_execution_info_maps (
+  shard_id INT NOT NULL,
+  domain_id BYTES NOT NULL,
+  workflow_id VARCHAR(255) NOT NULL,
+  run_id BYTES NOT NULL,
+  initiated_id BIGINT NOT NULL,
+--
+  data BYTES NOT NULL,
+  data_encoding VARCHAR(16),
+  PRIMARY KEY (shard_id, domain_id, workflow_id, run_id, initiated_id)
+);
+
+CREATE TABLE request_cancel_info_maps (
+  shard_id INT NOT NULL,
+  domain_id BYTES NOT NULL,
+  workflow_id VARCHAR(255) NOT NULL,
+  run_id BYTES NOT NULL,
+  initiated_id BIGINT NOT NULL,
+--
+  data BYTES NOT NULL,
+  data_encoding VARCHAR(16),
+  PRIMARY KEY (shard_id, domain_id, workflow_id, run_id, initiated_id)
+);
+
+CREATE TABLE signal_info_maps (
+  shard_id INT NOT NULL,
+  domain_id BYTES NOT NULL,
+  workflow_id VARCHAR(255) NOT NULL,
+  run_id BYTES NOT NULL,
+  initiated_id BIGINT NOT NULL,
+--
+  data BYTES NOT NULL,
+  data_encoding VARCHAR(16),
+  PRIMARY KEY (shard_id, domain_id, workflow_id, run_id, initiated_id)
+);
+
+CREATE TABLE update_info_maps (
+  shard_id INT NOT NULL,
+  domain_id BYTES NOT NULL,
+  workflow_id VARCHAR(255) NOT NULL,
+  run_id BYTES NOT NULL,
+  update_id VARCHAR(255) NOT NULL,
+--
+  data BYTES NOT NULL,
+  data_encoding VARCHAR(16),
+  PRIMARY KEY (shard_id, domain_id, workflow_id, run_id, update_id)
+);
+
+CREATE TABLE buffered_replication_task_maps (
+  shard_id INT NOT NULL,
+  domain_id BYTES NOT NULL,
+  workflow_id VARCHAR(255) NOT NULL,
+  run_id BYTES NOT NULL,
+  first_event_id BIGINT NOT NULL,
+--
+  version BIGINT NOT NULL,
+  next_event_id BIGINT NOT NULL,
+  history BYTES,
+  history_encoding VARCHAR(16) NOT NULL,
+  new_run_history BYTES,
+  new_run_history_encoding VARCHAR(16) NOT NULL DEFAULT 'json',
+  event_store_version          INT NOT NULL, -- indiciates which version of event store to query
+  new_run_event_store_version  INT NOT NULL, -- indiciates which version of event store to query for new run(continueAsNew)
+  PRIMARY KEY (shard_id, domain_id, workflow_id, run_id, first_event_id)
+);
+
+CREATE TABLE signals_requested_sets (
+  shard_id INT NOT NULL,
+  domain_id BYTES NOT NULL,
+  workflow_id VARCHAR(255) NOT NULL,
+  run_id BYTES NOT NULL,
+  signal_id VARCHAR(64) NOT NULL,
+  --
+  PRIMARY KEY (shard_id, domain_id, workflow_id, run_id, signal_id)
+);
+
+-- history eventsV2: history_node stores history event data
+CREATE TABLE history_node (
+  shard_id       INT NOT NULL,
+  tree_id        BYTES NOT NULL,
+  branch_id      BYTES NOT NULL,
+  node_id        BIGINT NOT NULL,
+  txn_id         BIGINT NOT NULL,
+  --
+  data           BYTES NOT NULL,
+  data_encoding  VARCHAR(16) NOT NULL,
+  PRIMARY KEY (shard_id, tree_id, branch_id, node_id, txn_id)
+);
+
+-- history eventsV2: history_tree stores branch metadata
+CREATE TABLE history_tree (
+  shard_id       INT NOT NULL,
+  tree_id        BYTES NOT NULL,
+  branch_id      BYTES NOT NULL,
+  --
+  in_progress    BOOLEAN NOT NULL,
+  data           BYTES NOT NULL,
+  data_encoding  VARCHAR(16) NOT NULL,
+  PRIMARY KEY (shard_id, tree_id, branch_id)
+);
+CREATE TABLE cluster_membership (
+  role VARCHAR(255) NOT NULL,
+  rpc_address VARCHAR(255) NOT NULL,
+  --
+  session_start TIMESTAMP NOT NULL,
+  last_heartbeat TIMESTAMP NOT NULL,
+  record_expiry TIMESTAMP NOT NULL,
+  PRIMARY KEY (role, rpc_address)
+);
+CREATE TABLE audit_log (
+  entry_id BYTES NOT NULL,
+  timestamp TIMESTAMP NOT NULL,
+  --
+  caller VARCHAR(255) NOT NULL,
+  caller_service VARCHAR(255) NOT NULL,
+  operation VARCHAR(255) NOT NULL,
+  domain VARCHAR(255) NOT NULL,
+  request_hash VARCHAR(64) NOT NULL,
+  error TEXT NOT NULL,
+  PRIMARY KEY (timestamp, entry_id)
+);
+CREATE TABLE schedules (
+  domain_id BYTES NOT NULL,
+  schedule_id VARCHAR(255) NOT NULL,
+  --
+  cron_schedule VARCHAR(255) NOT NULL,
+  workflow_type_name VARCHAR(255) NOT NULL,
+  task_list VARCHAR(255) NOT NULL,
+  input BYTES,
+  execution_timeout INT NOT NULL,
+  task_timeout INT NOT NULL,
+  overlap_policy INT NOT NULL,
+  catchup_window INT NOT NULL,
+  paused BOOLEAN NOT NULL,
+  last_fire_time TIMESTAMP NOT NULL,
+  last_workflow_id VARCHAR(255) NOT NULL,
+  last_run_id VARCHAR(64) NOT NULL,
+  backfill_start_time TIMESTAMP NOT NULL,
+  backfill_end_time TIMESTAMP NOT NULL,
+  backfill_runs_per_minute INT NOT NULL,
+  backfill_last_run_time TIMESTAMP NOT NULL,
+  identity VARCHAR(255) NOT NULL,
+  version BIGINT NOT NULL,
+  PRIMARY KEY (domain_id, schedule_id)
+);
@@ -0,0 +1 @@
+CREATE DATABASE cadence_visibility;
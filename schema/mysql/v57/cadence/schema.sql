@@ -57,6 +57,7 @@ CREATE TABLE current_executions(
   close_status INT NOT NULL,
   start_version BIGINT NOT NULL,
   last_write_version BIGINT NOT NULL,
+  blocked_until DATETIME(6) NOT NULL DEFAULT '1000-01-01 00:00:00',
   PRIMARY KEY (shard_id, domain_id, workflow_id)
 );
 
@@ -106,6 +107,15 @@ CREATE TABLE replication_tasks (
   PRIMARY KEY (shard_id, task_id)
 );
 
+CREATE TABLE visibility_tasks(
+  shard_id INT NOT NULL,
+  task_id BIGINT NOT NULL,
+  --
+  data BLOB NOT NULL,
+  data_encoding VARCHAR(16) NOT NULL,
+  PRIMARY KEY (shard_id, task_id)
+);
+
 CREATE TABLE timer_tasks (
   shard_id INT NOT NULL,
   visibility_timestamp DATETIME(6) NOT NULL,
@@ -116,6 +126,17 @@ CREATE TABLE timer_tasks (
   PRIMARY KEY (shard_id, visibility_timestamp, task_id)
 );
 
+CREATE TABLE closed_executions_index (
+  shard_id INT NOT NULL,
+  close_time DATETIME(6) NOT NULL,
+  domain_id BINARY(16) NOT NULL,
+  workflow_id VARCHAR(255) NOT NULL,
+  run_id BINARY(16) NOT NULL,
+  --
+  version BIGINT NOT NULL,
+  PRIMARY KEY (shard_id, close_time, domain_id, workflow_id, run_id)
+);
+
 -- Deprecated in favor of history eventsV2
 CREATE TABLE events (
   domain_id      BINARY(16) NOT NULL,
@@ -194,6 +215,18 @@ CREATE TABLE signal_info_maps (
   PRIMARY KEY (shard_id, domain_id, workflow_id, run_id, initiated_id)
 );
 
+CREATE TABLE update_info_maps (
+  shard_id INT NOT NULL,
+  domain_id BINARY(16) NOT NULL,
+  workflow_id VARCHAR(255) NOT NULL,
+  run_id BINARY(16) NOT NULL,
+  update_id VARCHAR(255) NOT NULL,
+--
+  data BLOB NOT NULL,
+  data_encoding VARCHAR(16),
+  PRIMARY KEY (shard_id, domain_id, workflow_id, run_id, update_id)
+);
+
 CREATE TABLE buffered_replication_task_maps (
   shard_id INT NOT NULL,
   domain_id BINARY(16) NOT NULL,
@@ -245,4 +278,52 @@ CREATE TABLE history_tree (
   data           BLOB NOT NULL,
   data_encoding  VARCHAR(16) NOT NULL,
   PRIMARY KEY (shard_id, tree_id, branch_id)
-);
\ No newline at end of file
+);
+CREATE TABLE cluster_membership (
+  role VARCHAR(255) NOT NULL,
+  rpc_address VARCHAR(255) NOT NULL,
+  --
+  session_start DATETIME(6) NOT NULL,
+  last_heartbeat DATETIME(6) NOT NULL,
+  record_expiry DATETIME(6) NOT NULL,
+  PRIMARY KEY (role, rpc_address)
+);
+CREATE TABLE audit_log (
+  entry_id BINARY(16) NOT NULL,
+  timestamp DATETIME(6) NOT NULL,
+  --
+  caller VARCHAR(255) NOT NULL,
+  caller_service VARCHAR(255) NOT NULL,
+  operation VARCHAR(255) NOT NULL,
+  domain VARCHAR(255) NOT NULL,
+  request_hash VARCHAR(64) NOT NULL,
+  error TEXT NOT NULL,
+  PRIMARY KEY (timestamp, entry_id)
+);
+CREATE TABLE schedules (
+  domain_id BINARY(16) NOT NULL,
+  schedule_id VARCHAR(255) NOT NULL,
+  --
+  cron_schedule VARCHAR(255) NOT NULL,
+  workflow_type_name VARCHAR(255) NOT NULL,
+  task_list VARCHAR(255) NOT NULL,
+  input BLOB,
+  execution_timeout INT NOT NULL,
+  task_timeout INT NOT NULL,
+  overlap_policy INT NOT NULL,
+  catchup_window INT NOT NULL,
+  paused BOOLEAN NOT NULL,
+  last_fire_time DATETIME(6) NOT NULL,
+  last_workflow_id VARCHAR(255) NOT NULL,
+  last_run_id VARCHAR(64) NOT NULL,
+  backfill_id VARCHAR(64) NOT NULL,
+  backfill_start_time DATETIME(6) NOT NULL,
+  backfill_end_time DATETIME(6) NOT NULL,
+  backfill_runs_per_minute INT NOT NULL,
+  backfill_last_run_time DATETIME(6) NOT NULL,
+  last_backfill_workflow_id VARCHAR(255) NOT NULL,
+  last_backfill_run_id VARCHAR(64) NOT NULL,
+  identity VARCHAR(255) NOT NULL,
+  version BIGINT NOT NULL,
+  PRIMARY KEY (domain_id, schedule_id)
+);
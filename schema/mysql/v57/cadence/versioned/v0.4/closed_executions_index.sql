@@ -0,0 +1,10 @@
+CREATE TABLE closed_executions_index (
+  shard_id INT NOT NULL,
+  close_time DATETIME(6) NOT NULL,
+  domain_id BINARY(16) NOT NULL,
+  workflow_id VARCHAR(255) NOT NULL,
+  run_id BINARY(16) NOT NULL,
+  --
+  version BIGINT NOT NULL,
+  PRIMARY KEY (shard_id, close_time, domain_id, workflow_id, run_id)
+);
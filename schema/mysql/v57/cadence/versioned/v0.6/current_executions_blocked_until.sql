@@ -0,0 +1 @@
+ALTER TABLE current_executions ADD blocked_until DATETIME(6) NOT NULL DEFAULT '1000-01-01 00:00:00';
@@ -0,0 +1,23 @@
+CREATE TABLE schedules (
+  domain_id BINARY(16) NOT NULL,
+  schedule_id VARCHAR(255) NOT NULL,
+  --
+  cron_schedule VARCHAR(255) NOT NULL,
+  workflow_type_name VARCHAR(255) NOT NULL,
+  task_list VARCHAR(255) NOT NULL,
+  input BLOB,
+  execution_timeout INT NOT NULL,
+  task_timeout INT NOT NULL,
+  overlap_policy INT NOT NULL,
+  catchup_window INT NOT NULL,
+  paused BOOLEAN NOT NULL,
+  last_fire_time DATETIME(6) NOT NULL,
+  last_workflow_id VARCHAR(255) NOT NULL,
+  last_run_id VARCHAR(64) NOT NULL,
+  backfill_id VARCHAR(64) NOT NULL,
+  backfill_start_time DATETIME(6) NOT NULL,
+  backfill_end_time DATETIME(6) NOT NULL,
+  identity VARCHAR(255) NOT NULL,
+  version BIGINT NOT NULL,
+  PRIMARY KEY (domain_id, schedule_id)
+);
@@ -0,0 +1,4 @@
+ALTER TABLE schedules ADD backfill_runs_per_minute INT NOT NULL DEFAULT 0;
+ALTER TABLE schedules ADD backfill_last_run_time DATETIME(6) NOT NULL DEFAULT '1000-01-01 00:00:00';
+ALTER TABLE schedules ADD last_backfill_workflow_id VARCHAR(255) NOT NULL DEFAULT '';
+ALTER TABLE schedules ADD last_backfill_run_id VARCHAR(64) NOT NULL DEFAULT '';
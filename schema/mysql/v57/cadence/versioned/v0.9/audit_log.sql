@@ -0,0 +1,12 @@
+CREATE TABLE audit_log (
+  entry_id BINARY(16) NOT NULL,
+  timestamp DATETIME(6) NOT NULL,
+  --
+  caller VARCHAR(255) NOT NULL,
+  caller_service VARCHAR(255) NOT NULL,
+  operation VARCHAR(255) NOT NULL,
+  domain VARCHAR(255) NOT NULL,
+  request_hash VARCHAR(64) NOT NULL,
+  error TEXT NOT NULL,
+  PRIMARY KEY (timestamp, entry_id)
+);
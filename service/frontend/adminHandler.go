@@ -22,6 +22,8 @@ package frontend
 
 import (
 	"context"
+	"encoding/json"
+	"sort"
 	"strconv"
 	"sync"
 	"sync/atomic"
@@ -33,9 +35,16 @@ import (
 	"github.com/uber/cadence/.gen/go/admin/adminserviceserver"
 	h "github.com/uber/cadence/.gen/go/history"
 	hist "github.com/uber/cadence/.gen/go/history"
+	m "github.com/uber/cadence/.gen/go/matching"
 	gen "github.com/uber/cadence/.gen/go/shared"
+	a "github.com/uber/cadence/client/admin"
 	"github.com/uber/cadence/client/history"
+	"github.com/uber/cadence/client/matching"
 	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/audit"
+	"github.com/uber/cadence/common/authorization"
+	"github.com/uber/cadence/common/backoff"
+	"github.com/uber/cadence/common/blobstore"
 	"github.com/uber/cadence/common/cache"
 	"github.com/uber/cadence/common/log"
 	"github.com/uber/cadence/common/log/tag"
@@ -43,36 +52,114 @@ import (
 	"github.com/uber/cadence/common/persistence"
 	"github.com/uber/cadence/common/service"
 	historyService "github.com/uber/cadence/service/history"
+	"github.com/uber/cadence/service/worker/scheduler"
 )
 
 var _ adminserviceserver.Interface = (*AdminHandler)(nil)
 
+const (
+	listWorkflowExecutionRunsPageSize = 100
+	listSchedulesPageSize             = 100
+	// listRecentlyClosedExecutionsPageSize is the number of executions returned when the request does not set it
+	listRecentlyClosedExecutionsPageSize    = 100
+	listRecentlyClosedExecutionsMaxPageSize = 1000
+	// listRecentlyClosedExecutionsMaxWindow bounds how far back the closed execution index is read
+	listRecentlyClosedExecutionsMaxWindow = 7 * 24 * time.Hour
+	// defaultScheduleCatchupWindowSeconds is the catch-up window of the schedules created without one
+	defaultScheduleCatchupWindowSeconds = 5 * 60
+	scheduleUpdateMaxAttempts           = 3
+	// defaultBackfillRunsPerMinute is the rate of the backfills requested without one
+	defaultBackfillRunsPerMinute = 10
+)
+
+var (
+	errScheduleIDNotSet         = &gen.BadRequestError{Message: "ScheduleId is not set on request."}
+	errInvalidShardID           = &gen.BadRequestError{Message: "Invalid ShardId."}
+	errInvalidClosedAfter       = &gen.BadRequestError{Message: "Invalid ClosedAfter."}
+	errScheduleAlreadyExists    = &gen.BadRequestError{Message: "Schedule already exists."}
+	errInvalidCronSchedule      = &gen.BadRequestError{Message: "Invalid CronSchedule."}
+	errInvalidCatchupWindow     = &gen.BadRequestError{Message: "CatchupWindowSeconds cannot be negative."}
+	errInvalidBackfillTimeRange = &gen.BadRequestError{Message: "A valid backfill time range in the past is not set on request."}
+	errBackfillInProgress       = &gen.BadRequestError{Message: "A backfill of the schedule is already in progress."}
+	errInvalidBackfillRate      = &gen.BadRequestError{Message: "RunsPerMinute cannot be negative."}
+	errTaskJourneyIDNotSet      = &gen.BadRequestError{Message: "Execution and ScheduleId or TaskId is not set on request."}
+	errSnapshotNotSet           = &gen.BadRequestError{Message: "Snapshot is not set on request."}
+	errPurgeWorkflowRunning     = &gen.BadRequestError{Message: "Workflow execution is still running, it must be closed to be purged."}
+)
+
+var thriftCloseStatus = map[int]gen.WorkflowExecutionCloseStatus{
+	persistence.WorkflowCloseStatusCompleted:      gen.WorkflowExecutionCloseStatusCompleted,
+	persistence.WorkflowCloseStatusFailed:         gen.WorkflowExecutionCloseStatusFailed,
+	persistence.WorkflowCloseStatusCanceled:       gen.WorkflowExecutionCloseStatusCanceled,
+	persistence.WorkflowCloseStatusTerminated:     gen.WorkflowExecutionCloseStatusTerminated,
+	persistence.WorkflowCloseStatusContinuedAsNew: gen.WorkflowExecutionCloseStatusContinuedAsNew,
+	persistence.WorkflowCloseStatusTimedOut:       gen.WorkflowExecutionCloseStatusTimedOut,
+}
+
 type (
 	// AdminHandler - Thrift handler inteface for admin service
 	AdminHandler struct {
 		status                int32
 		numberOfHistoryShards int
+		readOnly              bool
 		service.Service
-		history       history.Client
-		domainCache   cache.DomainCache
-		metricsClient metrics.Client
-		historyMgr    persistence.HistoryManager
-		historyV2Mgr  persistence.HistoryV2Manager
-		startWG       sync.WaitGroup
+		history             history.Client
+		matching            matching.Client
+		domainCache         cache.DomainCache
+		metricsClient       metrics.Client
+		historyMgr          persistence.HistoryManager
+		historyV2Mgr        persistence.HistoryV2Manager
+		visibilityMgr       persistence.VisibilityManager
+		scheduleMgr         persistence.ScheduleManager
+		shardMgr            persistence.ShardManager
+		executionMgrFactory persistence.ExecutionManagerFactory
+		blobstoreClient     blobstore.Client
+		startWG             sync.WaitGroup
+		authenticator       authorization.Authenticator
+		auditSink           audit.Sink
+
+		// asyncVisibilityStores are the visibility stores which don't delete records synchronously, keyed by store
+		// name with the reason their deletion can't be read back
+		asyncVisibilityStores map[string]string
+
+		// executionMgrs are the execution managers of the shards read so far, they share the connection of the
+		// factory so they are kept for the lifetime of the handler instead of being created per request
+		executionMgrsLock sync.Mutex
+		executionMgrs     map[int]persistence.ExecutionManager
+	}
+
+	// listRecentlyClosedExecutionsToken is the next page token of ListRecentlyClosedExecutions, the page
+	// starts at the page token of the closed execution index of the day
+	listRecentlyClosedExecutionsToken struct {
+		Day       time.Time
+		PageToken []byte
 	}
 )
 
 // NewAdminHandler creates a thrift handler for the cadence admin service
 func NewAdminHandler(
-	sVice service.Service, numberOfHistoryShards int, metadataMgr persistence.MetadataManager,
-	historyMgr persistence.HistoryManager, historyV2Mgr persistence.HistoryV2Manager) *AdminHandler {
+	sVice service.Service, numberOfHistoryShards int, readOnly bool, metadataMgr persistence.MetadataManager,
+	historyMgr persistence.HistoryManager, historyV2Mgr persistence.HistoryV2Manager, visibilityMgr persistence.VisibilityManager,
+	asyncVisibilityStores map[string]string, scheduleMgr persistence.ScheduleManager,
+	shardMgr persistence.ShardManager, executionMgrFactory persistence.ExecutionManagerFactory, blobstoreClient blobstore.Client,
+	authenticator authorization.Authenticator, auditSink audit.Sink) *AdminHandler {
 	handler := &AdminHandler{
 		status:                common.DaemonStatusInitialized,
 		numberOfHistoryShards: numberOfHistoryShards,
+		readOnly:              readOnly,
 		Service:               sVice,
 		domainCache:           cache.NewDomainCache(metadataMgr, sVice.GetClusterMetadata(), sVice.GetMetricsClient(), sVice.GetLogger()),
 		historyMgr:            historyMgr,
 		historyV2Mgr:          historyV2Mgr,
+		visibilityMgr:         visibilityMgr,
+		asyncVisibilityStores: asyncVisibilityStores,
+		scheduleMgr:           scheduleMgr,
+		shardMgr:              shardMgr,
+		executionMgrFactory:   executionMgrFactory,
+		executionMgrs:         make(map[int]persistence.ExecutionManager),
+		blobstoreClient:       blobstoreClient,
+		authenticator:         authenticator,
+		auditSink:             auditSink,
 	}
 	// prevent us from trying to serve requests before handler's Start() is complete
 	handler.startWG.Add(1)
@@ -93,6 +180,8 @@ func (adh *AdminHandler) Start() error {
 	adh.domainCache.Start()
 
 	adh.history = adh.GetClientBean().GetHistoryClient()
+	adh.matching = matching.NewRetryableClient(adh.GetClientBean().GetMatchingClient(), common.CreateMatchingServiceRetryPolicy(),
+		common.IsWhitelistServiceTransientError)
 	adh.metricsClient = adh.Service.GetMetricsClient()
 	adh.startWG.Done()
 	return nil
@@ -107,10 +196,28 @@ func (adh *AdminHandler) Stop() {
 	adh.domainCache.Stop()
 }
 
+// getExecutionManager returns the execution manager of the shard, creating it on the first use
+func (adh *AdminHandler) getExecutionManager(shardID int) (persistence.ExecutionManager, error) {
+	adh.executionMgrsLock.Lock()
+	defer adh.executionMgrsLock.Unlock()
+	if executionMgr, ok := adh.executionMgrs[shardID]; ok {
+		return executionMgr, nil
+	}
+	executionMgr, err := adh.executionMgrFactory.NewExecutionManager(shardID)
+	if err != nil {
+		return nil, err
+	}
+	adh.executionMgrs[shardID] = executionMgr
+	return executionMgr, nil
+}
+
 // DescribeWorkflowExecution returns information about the specified workflow execution.
 func (adh *AdminHandler) DescribeWorkflowExecution(ctx context.Context, request *admin.DescribeWorkflowExecutionRequest) (resp *admin.DescribeWorkflowExecutionResponse, retError error) {
 	defer log.CapturePanic(adh.GetLogger(), &retError)
 	scope := metrics.AdminDescribeWorkflowExecutionScope
+	if adh.readOnly {
+		return nil, adh.error(errReadOnlyClusterNotServed, scope)
+	}
 	if request == nil {
 		return nil, adh.error(errRequestNotSet, scope)
 	}
@@ -143,6 +250,8 @@ func (adh *AdminHandler) DescribeWorkflowExecution(ctx context.Context, request
 		HistoryAddr:            common.StringPtr(historyAddr),
 		MutableStateInDatabase: resp2.MutableStateInDatabase,
 		MutableStateInCache:    resp2.MutableStateInCache,
+		LastFirstEventId:       resp2.LastFirstEventId,
+		LastEventTaskId:        resp2.LastEventTaskId,
 	}, err
 }
 
@@ -150,6 +259,9 @@ func (adh *AdminHandler) DescribeWorkflowExecution(ctx context.Context, request
 func (adh *AdminHandler) DescribeHistoryHost(ctx context.Context, request *gen.DescribeHistoryHostRequest) (resp *gen.DescribeHistoryHostResponse, retError error) {
 	defer log.CapturePanic(adh.GetLogger(), &retError)
 	scope := metrics.AdminDescribeHistoryHostScope
+	if adh.readOnly {
+		return nil, adh.error(errReadOnlyClusterNotServed, scope)
+	}
 	if request == nil || (request.ShardIdForHost == nil && request.ExecutionForHost == nil && request.HostAddress == nil) {
 		return nil, adh.error(errRequestNotSet, scope)
 	}
@@ -164,6 +276,146 @@ func (adh *AdminHandler) DescribeHistoryHost(ctx context.Context, request *gen.D
 	return resp, err
 }
 
+// ImportWorkflowExecution recreates a closed workflow execution from an exported snapshot as a new run of its
+// workflow through the history service, the import fails if a run of the workflow is open
+func (adh *AdminHandler) ImportWorkflowExecution(ctx context.Context, request *admin.ImportWorkflowExecutionRequest) (resp *admin.ImportWorkflowExecutionResponse, retError error) {
+	defer log.CapturePanic(adh.GetLogger(), &retError)
+
+	scope := metrics.AdminImportWorkflowExecutionScope
+	sw := adh.startRequestProfile(scope)
+	defer sw.Stop()
+	defer func() {
+		adh.audit(ctx, "ImportWorkflowExecution", request.GetDomain(), request, retError)
+	}()
+
+	if adh.readOnly {
+		return nil, adh.error(errReadOnlyCluster, scope)
+	}
+	if request == nil {
+		return nil, adh.error(errRequestNotSet, scope)
+	}
+	if request.GetDomain() == "" {
+		return nil, adh.error(errDomainNotSet, scope)
+	}
+	if len(request.GetSnapshot()) == 0 {
+		return nil, adh.error(errSnapshotNotSet, scope)
+	}
+
+	domainID, err := adh.domainCache.GetDomainID(request.GetDomain())
+	if err != nil {
+		return nil, adh.error(err, scope)
+	}
+	// the workflow ID routes the import to the history host owning the shard of the workflow, which
+	// recreates the run with a new run ID so that the import never collides with the exported run
+	export, err := persistence.DecodeExecutionExport(request.GetSnapshot())
+	if err != nil {
+		return nil, adh.error(&gen.BadRequestError{Message: err.Error()}, scope)
+	}
+
+	importResp, err := adh.history.ImportWorkflowExecution(ctx, &hist.ImportWorkflowExecutionRequest{
+		DomainUUID: common.StringPtr(domainID),
+		WorkflowId: common.StringPtr(export.MutableState.ExecutionInfo.WorkflowID),
+		Snapshot:   request.GetSnapshot(),
+	})
+	if err != nil {
+		return nil, adh.error(err, scope)
+	}
+	return &admin.ImportWorkflowExecutionResponse{
+		RunId: importResp.RunId,
+	}, nil
+}
+
+// PurgeWorkflowExecution permanently deletes every trace of a closed workflow run and returns a report of the
+// deletions verified by reading them back, to serve right to erasure requests
+func (adh *AdminHandler) PurgeWorkflowExecution(ctx context.Context, request *admin.PurgeWorkflowExecutionRequest) (resp *admin.PurgeWorkflowExecutionResponse, retError error) {
+	defer log.CapturePanic(adh.GetLogger(), &retError)
+
+	scope := metrics.AdminPurgeWorkflowExecutionScope
+	sw := adh.startRequestProfile(scope)
+	defer sw.Stop()
+	defer func() {
+		adh.audit(ctx, "PurgeWorkflowExecution", request.GetDomain(), request, retError)
+	}()
+
+	if adh.readOnly {
+		return nil, adh.error(errReadOnlyCluster, scope)
+	}
+	if request == nil {
+		return nil, adh.error(errRequestNotSet, scope)
+	}
+	if request.GetDomain() == "" {
+		return nil, adh.error(errDomainNotSet, scope)
+	}
+	if err := validateExecution(request.Execution); err != nil {
+		return nil, adh.error(err, scope)
+	}
+	if request.Execution.GetRunId() == "" {
+		return nil, adh.error(errRunIDNotSet, scope)
+	}
+
+	domainEntry, err := adh.domainCache.GetDomain(request.GetDomain())
+	if err != nil {
+		return nil, adh.error(err, scope)
+	}
+	domainID := domainEntry.GetInfo().ID
+	shardID := common.WorkflowIDToHistoryShard(request.Execution.GetWorkflowId(), adh.numberOfHistoryShards)
+	executionMgr, err := adh.getExecutionManager(shardID)
+	if err != nil {
+		return nil, adh.error(err, scope)
+	}
+
+	purger := &executionPurger{
+		domainID:              domainID,
+		domainName:            request.GetDomain(),
+		archivalBucket:        domainEntry.GetConfig().ArchivalBucket,
+		execution:             *request.Execution,
+		shardID:               shardID,
+		executionMgr:          executionMgr,
+		historyMgr:            adh.historyMgr,
+		historyV2Mgr:          adh.historyV2Mgr,
+		visibilityMgr:         adh.visibilityMgr,
+		blobstoreClient:       adh.blobstoreClient,
+		historyClient:         adh.history,
+		remoteAdminClients:    map[string]a.Client{},
+		asyncVisibilityStores: adh.asyncVisibilityStores,
+	}
+	if domainEntry.IsGlobalDomain() && !request.GetCurrentClusterOnly() {
+		currentCluster := adh.GetClusterMetadata().GetCurrentClusterName()
+		for _, cluster := range domainEntry.GetReplicationConfig().Clusters {
+			if cluster.ClusterName != currentCluster {
+				purger.remoteAdminClients[cluster.ClusterName] = adh.GetClientBean().GetRemoteAdminClient(cluster.ClusterName)
+			}
+		}
+	}
+	msResp, err := executionMgr.GetWorkflowExecution(&persistence.GetWorkflowExecutionRequest{
+		DomainID:  domainID,
+		Execution: *request.Execution,
+		Context:   ctx,
+	})
+	if err != nil {
+		if _, ok := err.(*gen.EntityNotExistsError); !ok {
+			return nil, adh.error(err, scope)
+		}
+	} else {
+		// the history service could still update a running execution
+		if msResp.State.ExecutionInfo.State != persistence.WorkflowStateCompleted {
+			return nil, adh.error(errPurgeWorkflowRunning, scope)
+		}
+		purger.executionInfo = msResp.State.ExecutionInfo
+	}
+
+	resp, err = purger.purge(ctx)
+	if err != nil {
+		return nil, adh.error(err, scope)
+	}
+
+	adh.GetLogger().Info("Purged workflow execution.",
+		tag.WorkflowDomainID(domainID),
+		tag.WorkflowID(request.Execution.GetWorkflowId()),
+		tag.WorkflowRunID(request.Execution.GetRunId()))
+	return resp, nil
+}
+
 // GetWorkflowExecutionRawHistory - retrieves the history of workflow execution
 func (adh *AdminHandler) GetWorkflowExecutionRawHistory(
 	ctx context.Context, request *admin.GetWorkflowExecutionRawHistoryRequest) (resp *admin.GetWorkflowExecutionRawHistoryResponse, retError error) {
@@ -175,6 +427,10 @@ func (adh *AdminHandler) GetWorkflowExecutionRawHistory(
 	var err error
 	var size int
 
+	if adh.readOnly {
+		return nil, adh.error(errReadOnlyClusterNotServed, scope)
+	}
+
 	domainID, err := adh.domainCache.GetDomainID(request.GetDomain())
 	if err != nil {
 		return nil, adh.error(err, scope)
@@ -334,24 +590,1127 @@ func (adh *AdminHandler) GetWorkflowExecutionRawHistory(
 	return result, nil
 }
 
-// startRequestProfile initiates recording of request metrics
-func (adh *AdminHandler) startRequestProfile(scope int) tally.Stopwatch {
-	adh.startWG.Wait()
-	sw := adh.metricsClient.StartTimer(scope, metrics.CadenceLatency)
-	adh.metricsClient.IncCounter(scope, metrics.CadenceRequests)
-	return sw
+// SetActivityPaused pauses or unpauses a pending activity of a workflow execution
+func (adh *AdminHandler) SetActivityPaused(ctx context.Context, request *gen.SetActivityPausedRequest) (retError error) {
+	defer log.CapturePanic(adh.GetLogger(), &retError)
+
+	scope := metrics.AdminSetActivityPausedScope
+	sw := adh.startRequestProfile(scope)
+	defer sw.Stop()
+	defer func() {
+		adh.audit(ctx, "SetActivityPaused", request.GetDomain(), request, retError)
+	}()
+
+	if adh.readOnly {
+		return adh.error(errReadOnlyCluster, scope)
+	}
+	if request == nil {
+		return adh.error(errRequestNotSet, scope)
+	}
+	if request.GetDomain() == "" {
+		return adh.error(errDomainNotSet, scope)
+	}
+	if err := validateExecution(request.Execution); err != nil {
+		return adh.error(err, scope)
+	}
+	if request.GetActivityID() == "" {
+		return adh.error(errActivityIDNotSet, scope)
+	}
+
+	domainID, err := adh.domainCache.GetDomainID(request.GetDomain())
+	if err != nil {
+		return adh.error(err, scope)
+	}
+
+	err = adh.history.SetActivityPaused(ctx, &hist.SetActivityPausedRequest{
+		DomainUUID:   common.StringPtr(domainID),
+		PauseRequest: request,
+	})
+	if err != nil {
+		return adh.error(err, scope)
+	}
+	return nil
 }
 
-func (adh *AdminHandler) error(err error, scope int) error {
-	switch err.(type) {
-	case *gen.InternalServiceError:
-		adh.Service.GetLogger().Error("Internal service error", tag.Error(err))
-		return err
-	case *gen.BadRequestError:
-		return err
-	case *gen.ServiceBusyError:
-		return err
-	case *gen.EntityNotExistsError:
+// DrainHistoryHost releases the shards owned by a history host and stops it from acquiring new ones
+func (adh *AdminHandler) DrainHistoryHost(ctx context.Context, request *gen.DrainHistoryHostRequest) (resp *gen.DrainHistoryHostResponse, retError error) {
+	defer log.CapturePanic(adh.GetLogger(), &retError)
+
+	scope := metrics.AdminDrainHistoryHostScope
+	sw := adh.startRequestProfile(scope)
+	defer sw.Stop()
+	defer func() {
+		adh.audit(ctx, "DrainHistoryHost", "", request, retError)
+	}()
+
+	if adh.readOnly {
+		return nil, adh.error(errReadOnlyCluster, scope)
+	}
+	if request == nil {
+		return nil, adh.error(errRequestNotSet, scope)
+	}
+	if request.GetHostAddress() == "" {
+		return nil, adh.error(errHostAddressNotSet, scope)
+	}
+
+	resp, err := adh.history.DrainHistoryHost(ctx, request)
+	if err != nil {
+		return nil, adh.error(err, scope)
+	}
+	return resp, nil
+}
+
+// UndrainHistoryHost adds a drained history host back to the membership ring
+func (adh *AdminHandler) UndrainHistoryHost(ctx context.Context, request *gen.UndrainHistoryHostRequest) (resp *gen.UndrainHistoryHostResponse, retError error) {
+	defer log.CapturePanic(adh.GetLogger(), &retError)
+
+	scope := metrics.AdminUndrainHistoryHostScope
+	sw := adh.startRequestProfile(scope)
+	defer sw.Stop()
+	defer func() {
+		adh.audit(ctx, "UndrainHistoryHost", "", request, retError)
+	}()
+
+	if adh.readOnly {
+		return nil, adh.error(errReadOnlyCluster, scope)
+	}
+	if request == nil {
+		return nil, adh.error(errRequestNotSet, scope)
+	}
+	if request.GetHostAddress() == "" {
+		return nil, adh.error(errHostAddressNotSet, scope)
+	}
+
+	resp, err := adh.history.UndrainHistoryHost(ctx, request)
+	if err != nil {
+		return nil, adh.error(err, scope)
+	}
+	return resp, nil
+}
+
+// DescribeClientVersions returns the client versions which completed decisions for the domain, merged
+// across all the history hosts owning a shard unless a single host is requested
+func (adh *AdminHandler) DescribeClientVersions(ctx context.Context, request *gen.DescribeClientVersionsRequest) (resp *gen.DescribeClientVersionsResponse, retError error) {
+	defer log.CapturePanic(adh.GetLogger(), &retError)
+
+	scope := metrics.AdminDescribeClientVersionsScope
+	sw := adh.startRequestProfile(scope)
+	defer sw.Stop()
+
+	if adh.readOnly {
+		return nil, adh.error(errReadOnlyClusterNotServed, scope)
+	}
+
+	if request == nil {
+		return nil, adh.error(errRequestNotSet, scope)
+	}
+
+	hostAddresses := []string{request.GetHostAddress()}
+	if request.GetHostAddress() == "" {
+		var err error
+		if hostAddresses, err = adh.historyHostAddresses(); err != nil {
+			return nil, adh.error(err, scope)
+		}
+	}
+
+	var responses []*gen.DescribeClientVersionsResponse
+	for _, hostAddress := range hostAddresses {
+		hostResp, err := adh.history.DescribeClientVersions(ctx, &gen.DescribeClientVersionsRequest{
+			Domain:      request.Domain,
+			HostAddress: common.StringPtr(hostAddress),
+		})
+		if err != nil {
+			return nil, adh.error(err, scope)
+		}
+		responses = append(responses, hostResp)
+	}
+	return mergeClientVersions(responses), nil
+}
+
+// GetDomainOpenExecutionCount returns the approximate number of open executions of a domain, summed over the
+// history hosts, or reported by a single host if the request names one
+func (adh *AdminHandler) GetDomainOpenExecutionCount(ctx context.Context, request *gen.GetDomainOpenExecutionCountRequest) (resp *gen.GetDomainOpenExecutionCountResponse, retError error) {
+	defer log.CapturePanic(adh.GetLogger(), &retError)
+
+	scope := metrics.AdminGetDomainOpenExecutionCountScope
+	sw := adh.startRequestProfile(scope)
+	defer sw.Stop()
+
+	if adh.readOnly {
+		return nil, adh.error(errReadOnlyClusterNotServed, scope)
+	}
+
+	if request == nil {
+		return nil, adh.error(errRequestNotSet, scope)
+	}
+	if request.GetDomain() == "" {
+		return nil, adh.error(errDomainNotSet, scope)
+	}
+
+	hostAddresses := []string{request.GetHostAddress()}
+	if request.GetHostAddress() == "" {
+		var err error
+		if hostAddresses, err = adh.historyHostAddresses(); err != nil {
+			return nil, adh.error(err, scope)
+		}
+	}
+
+	var count int64
+	for _, hostAddress := range hostAddresses {
+		hostResp, err := adh.history.GetDomainOpenExecutionCount(ctx, &gen.GetDomainOpenExecutionCountRequest{
+			Domain:      request.Domain,
+			HostAddress: common.StringPtr(hostAddress),
+		})
+		if err != nil {
+			return nil, adh.error(err, scope)
+		}
+		count += hostResp.GetCount()
+	}
+	return &gen.GetDomainOpenExecutionCountResponse{
+		Count: common.Int64Ptr(count),
+	}, nil
+}
+
+// SetWorkflowExecutionNotes attaches operator notes to a workflow execution, or removes them if the notes are empty
+func (adh *AdminHandler) SetWorkflowExecutionNotes(ctx context.Context, request *gen.SetWorkflowExecutionNotesRequest) (retError error) {
+	defer log.CapturePanic(adh.GetLogger(), &retError)
+
+	scope := metrics.AdminSetWorkflowExecutionNotesScope
+	sw := adh.startRequestProfile(scope)
+	defer sw.Stop()
+	defer func() {
+		adh.audit(ctx, "SetWorkflowExecutionNotes", request.GetDomain(), request, retError)
+	}()
+
+	if adh.readOnly {
+		return adh.error(errReadOnlyCluster, scope)
+	}
+	if request == nil {
+		return adh.error(errRequestNotSet, scope)
+	}
+	if request.GetDomain() == "" {
+		return adh.error(errDomainNotSet, scope)
+	}
+	if err := validateExecution(request.Execution); err != nil {
+		return adh.error(err, scope)
+	}
+	if len(request.GetNotes()) > executionNotesSizeLimit {
+		return adh.error(errExecutionNotesTooLarge, scope)
+	}
+
+	domainID, err := adh.domainCache.GetDomainID(request.GetDomain())
+	if err != nil {
+		return adh.error(err, scope)
+	}
+
+	err = adh.history.SetWorkflowExecutionNotes(ctx, &hist.SetWorkflowExecutionNotesRequest{
+		DomainUUID:   common.StringPtr(domainID),
+		NotesRequest: request,
+	})
+	if err != nil {
+		return adh.error(err, scope)
+	}
+	return nil
+}
+
+// SetWorkflowExecutionPaused pauses or resumes a workflow execution
+func (adh *AdminHandler) SetWorkflowExecutionPaused(ctx context.Context, request *gen.SetWorkflowExecutionPausedRequest) (retError error) {
+	defer log.CapturePanic(adh.GetLogger(), &retError)
+
+	scope := metrics.AdminSetWorkflowExecutionPausedScope
+	sw := adh.startRequestProfile(scope)
+	defer sw.Stop()
+	defer func() {
+		adh.audit(ctx, "SetWorkflowExecutionPaused", request.GetDomain(), request, retError)
+	}()
+
+	if adh.readOnly {
+		return adh.error(errReadOnlyCluster, scope)
+	}
+	if request == nil {
+		return adh.error(errRequestNotSet, scope)
+	}
+	if request.GetDomain() == "" {
+		return adh.error(errDomainNotSet, scope)
+	}
+	if err := validateExecution(request.Execution); err != nil {
+		return adh.error(err, scope)
+	}
+
+	domainID, err := adh.domainCache.GetDomainID(request.GetDomain())
+	if err != nil {
+		return adh.error(err, scope)
+	}
+
+	err = adh.history.SetWorkflowExecutionPaused(ctx, &hist.SetWorkflowExecutionPausedRequest{
+		DomainUUID:   common.StringPtr(domainID),
+		PauseRequest: request,
+	})
+	if err != nil {
+		return adh.error(err, scope)
+	}
+	return nil
+}
+
+// SetCronSchedulePaused pauses or resumes the cron schedule of a workflow
+func (adh *AdminHandler) SetCronSchedulePaused(ctx context.Context, request *gen.SetCronSchedulePausedRequest) (retError error) {
+	defer log.CapturePanic(adh.GetLogger(), &retError)
+
+	scope := metrics.AdminSetCronSchedulePausedScope
+	sw := adh.startRequestProfile(scope)
+	defer sw.Stop()
+	defer func() {
+		adh.audit(ctx, "SetCronSchedulePaused", request.GetDomain(), request, retError)
+	}()
+
+	if adh.readOnly {
+		return adh.error(errReadOnlyCluster, scope)
+	}
+	if request == nil {
+		return adh.error(errRequestNotSet, scope)
+	}
+	if request.GetDomain() == "" {
+		return adh.error(errDomainNotSet, scope)
+	}
+	if err := validateExecution(request.Execution); err != nil {
+		return adh.error(err, scope)
+	}
+
+	domainID, err := adh.domainCache.GetDomainID(request.GetDomain())
+	if err != nil {
+		return adh.error(err, scope)
+	}
+
+	err = adh.history.SetCronSchedulePaused(ctx, &hist.SetCronSchedulePausedRequest{
+		DomainUUID:   common.StringPtr(domainID),
+		PauseRequest: request,
+	})
+	if err != nil {
+		return adh.error(err, scope)
+	}
+	return nil
+}
+
+// TerminateAllWorkflowRuns terminates the current run of a workflow ID and temporarily blocks it from starting new runs
+func (adh *AdminHandler) TerminateAllWorkflowRuns(ctx context.Context, request *gen.TerminateAllWorkflowRunsRequest) (resp *gen.TerminateAllWorkflowRunsResponse, retError error) {
+	defer log.CapturePanic(adh.GetLogger(), &retError)
+
+	scope := metrics.AdminTerminateAllWorkflowRunsScope
+	sw := adh.startRequestProfile(scope)
+	defer sw.Stop()
+	defer func() {
+		adh.audit(ctx, "TerminateAllWorkflowRuns", request.GetDomain(), request, retError)
+	}()
+
+	if adh.readOnly {
+		return nil, adh.error(errReadOnlyCluster, scope)
+	}
+	if request == nil {
+		return nil, adh.error(errRequestNotSet, scope)
+	}
+	if request.GetDomain() == "" {
+		return nil, adh.error(errDomainNotSet, scope)
+	}
+	if request.GetWorkflowId() == "" {
+		return nil, adh.error(errWorkflowIDNotSet, scope)
+	}
+
+	domainID, err := adh.domainCache.GetDomainID(request.GetDomain())
+	if err != nil {
+		return nil, adh.error(err, scope)
+	}
+
+	resp, err = adh.history.TerminateAllWorkflowRuns(ctx, &hist.TerminateAllWorkflowRunsRequest{
+		DomainUUID:       common.StringPtr(domainID),
+		TerminateRequest: request,
+	})
+	if err != nil {
+		return nil, adh.error(err, scope)
+	}
+	return resp, nil
+}
+
+// DescribeWorkflowShard returns the shard a workflow ID maps to, the history host owning it, the persisted ack
+// levels of the shard, and whether the mutable state of the current run of the workflow exists
+func (adh *AdminHandler) DescribeWorkflowShard(ctx context.Context, request *admin.DescribeWorkflowShardRequest) (resp *admin.DescribeWorkflowShardResponse, retError error) {
+	defer log.CapturePanic(adh.GetLogger(), &retError)
+
+	scope := metrics.AdminDescribeWorkflowShardScope
+	sw := adh.startRequestProfile(scope)
+	defer sw.Stop()
+
+	if request == nil {
+		return nil, adh.error(errRequestNotSet, scope)
+	}
+	if uuid.Parse(request.GetDomainId()) == nil {
+		return nil, adh.error(errInvalidDomainID, scope)
+	}
+	if request.GetWorkflowId() == "" {
+		return nil, adh.error(errWorkflowIDNotSet, scope)
+	}
+
+	shardID := common.WorkflowIDToHistoryShard(request.GetWorkflowId(), adh.numberOfHistoryShards)
+	historyHost, err := adh.GetMembershipMonitor().Lookup(common.HistoryServiceName, string(shardID))
+	if err != nil {
+		return nil, adh.error(err, scope)
+	}
+
+	shardResp, err := adh.shardMgr.GetShard(&persistence.GetShardRequest{ShardID: shardID})
+	if err != nil {
+		return nil, adh.error(err, scope)
+	}
+	shardInfo := shardResp.ShardInfo
+	clusterTimerAckLevel := make(map[string]int64, len(shardInfo.ClusterTimerAckLevel))
+	for clusterName, ackLevel := range shardInfo.ClusterTimerAckLevel {
+		clusterTimerAckLevel[clusterName] = ackLevel.UnixNano()
+	}
+	resp = &admin.DescribeWorkflowShardResponse{
+		ShardId:                 common.Int32Ptr(int32(shardID)),
+		HistoryAddr:             common.StringPtr(historyHost.GetAddress()),
+		ShardOwner:              common.StringPtr(shardInfo.Owner),
+		RangeId:                 common.Int64Ptr(shardInfo.RangeID),
+		TransferAckLevel:        common.Int64Ptr(shardInfo.TransferAckLevel),
+		TimerAckLevel:           common.Int64Ptr(shardInfo.TimerAckLevel.UnixNano()),
+		ReplicationAckLevel:     common.Int64Ptr(shardInfo.ReplicationAckLevel),
+		ClusterTransferAckLevel: shardInfo.ClusterTransferAckLevel,
+		ClusterTimerAckLevel:    clusterTimerAckLevel,
+		MutableStateExists:      common.BoolPtr(false),
+	}
+
+	executionMgr, err := adh.getExecutionManager(shardID)
+	if err != nil {
+		return nil, adh.error(err, scope)
+	}
+	currentResp, err := executionMgr.GetCurrentExecution(&persistence.GetCurrentExecutionRequest{
+		DomainID:   request.GetDomainId(),
+		WorkflowID: request.GetWorkflowId(),
+	})
+	if err != nil {
+		if _, ok := err.(*gen.EntityNotExistsError); ok {
+			return resp, nil
+		}
+		return nil, adh.error(err, scope)
+	}
+	resp.CurrentRunId = common.StringPtr(currentResp.RunID)
+
+	_, err = executionMgr.GetWorkflowExecution(&persistence.GetWorkflowExecutionRequest{
+		DomainID: request.GetDomainId(),
+		Execution: gen.WorkflowExecution{
+			WorkflowId: request.WorkflowId,
+			RunId:      common.StringPtr(currentResp.RunID),
+		},
+	})
+	if err != nil {
+		if _, ok := err.(*gen.EntityNotExistsError); ok {
+			return resp, nil
+		}
+		return nil, adh.error(err, scope)
+	}
+	resp.MutableStateExists = common.BoolPtr(true)
+	return resp, nil
+}
+
+// ListWorkflowExecutionRuns returns all runs of a workflow ID known to the execution store ordered by start time
+func (adh *AdminHandler) ListWorkflowExecutionRuns(ctx context.Context, request *admin.ListWorkflowExecutionRunsRequest) (resp *admin.ListWorkflowExecutionRunsResponse, retError error) {
+	defer log.CapturePanic(adh.GetLogger(), &retError)
+
+	scope := metrics.AdminListWorkflowExecutionRunsScope
+	sw := adh.startRequestProfile(scope)
+	defer sw.Stop()
+
+	if request == nil {
+		return nil, adh.error(errRequestNotSet, scope)
+	}
+	if request.GetDomain() == "" {
+		return nil, adh.error(errDomainNotSet, scope)
+	}
+	if request.GetWorkflowId() == "" {
+		return nil, adh.error(errWorkflowIDNotSet, scope)
+	}
+	domainID, err := adh.domainCache.GetDomainID(request.GetDomain())
+	if err != nil {
+		return nil, adh.error(err, scope)
+	}
+
+	// all runs of a workflow ID live in the shard the workflow ID maps to
+	shardID := common.WorkflowIDToHistoryShard(request.GetWorkflowId(), adh.numberOfHistoryShards)
+	executionMgr, err := adh.getExecutionManager(shardID)
+	if err != nil {
+		return nil, adh.error(err, scope)
+	}
+
+	currentRunID := ""
+	currentResp, err := executionMgr.GetCurrentExecution(&persistence.GetCurrentExecutionRequest{
+		DomainID:   domainID,
+		WorkflowID: request.GetWorkflowId(),
+	})
+	if err != nil {
+		if _, ok := err.(*gen.EntityNotExistsError); !ok {
+			return nil, adh.error(err, scope)
+		}
+	} else {
+		currentRunID = currentResp.RunID
+	}
+
+	var runs []*persistence.WorkflowExecutionRunInfo
+	listRequest := &persistence.ListWorkflowExecutionRunsRequest{
+		DomainID:   domainID,
+		WorkflowID: request.GetWorkflowId(),
+		PageSize:   listWorkflowExecutionRunsPageSize,
+	}
+	for {
+		listResp, err := executionMgr.ListWorkflowExecutionRuns(listRequest)
+		if err != nil {
+			return nil, adh.error(err, scope)
+		}
+		runs = append(runs, listResp.Runs...)
+		if len(listResp.NextPageToken) == 0 {
+			break
+		}
+		listRequest.NextPageToken = listResp.NextPageToken
+	}
+	sort.Slice(runs, func(i, j int) bool {
+		return runs[i].StartTimestamp.Before(runs[j].StartTimestamp)
+	})
+
+	resp = &admin.ListWorkflowExecutionRunsResponse{
+		Runs: make([]*admin.WorkflowExecutionRunInfo, 0, len(runs)),
+	}
+	for _, run := range runs {
+		runInfo := &admin.WorkflowExecutionRunInfo{
+			RunId:     common.StringPtr(run.RunID),
+			StartTime: common.Int64Ptr(run.StartTimestamp.UnixNano()),
+			State:     common.Int32Ptr(int32(run.State)),
+			IsCurrent: common.BoolPtr(run.RunID == currentRunID),
+		}
+		if run.FirstExecutionRunID != "" {
+			runInfo.FirstExecutionRunId = common.StringPtr(run.FirstExecutionRunID)
+		}
+		if closeStatus, ok := thriftCloseStatus[run.CloseStatus]; ok {
+			runInfo.CloseStatus = closeStatus.Ptr()
+		}
+		resp.Runs = append(resp.Runs, runInfo)
+	}
+	return resp, nil
+}
+
+// ListRecentlyClosedExecutions returns the executions of a history shard closed since the given time, in close time
+// order a page at a time, from the closed execution index of the execution store
+func (adh *AdminHandler) ListRecentlyClosedExecutions(ctx context.Context, request *admin.ListRecentlyClosedExecutionsRequest) (resp *admin.ListRecentlyClosedExecutionsResponse, retError error) {
+	defer log.CapturePanic(adh.GetLogger(), &retError)
+
+	scope := metrics.AdminListRecentlyClosedExecutionsScope
+	sw := adh.startRequestProfile(scope)
+	defer sw.Stop()
+
+	if request == nil {
+		return nil, adh.error(errRequestNotSet, scope)
+	}
+	shardID := int(request.GetShardId())
+	if request.ShardId == nil || shardID < 0 || shardID >= adh.numberOfHistoryShards {
+		return nil, adh.error(errInvalidShardID, scope)
+	}
+	now := time.Now()
+	closedAfter := time.Unix(0, request.GetClosedAfter())
+	if request.ClosedAfter == nil || !closedAfter.Before(now) || now.Sub(closedAfter) > listRecentlyClosedExecutionsMaxWindow {
+		return nil, adh.error(errInvalidClosedAfter, scope)
+	}
+	pageSize := int(request.GetMaximumPageSize())
+	if pageSize <= 0 {
+		pageSize = listRecentlyClosedExecutionsPageSize
+	}
+	if pageSize > listRecentlyClosedExecutionsMaxPageSize {
+		pageSize = listRecentlyClosedExecutionsMaxPageSize
+	}
+
+	token := &listRecentlyClosedExecutionsToken{Day: closedAfter.UTC().Truncate(24 * time.Hour)}
+	if len(request.NextPageToken) > 0 {
+		if err := json.Unmarshal(request.NextPageToken, token); err != nil {
+			return nil, adh.error(errInvalidNextPageToken, scope)
+		}
+	}
+
+	executionMgr, err := adh.getExecutionManager(shardID)
+	if err != nil {
+		return nil, adh.error(err, scope)
+	}
+
+	// the index is partitioned by the close day, the days are read from the one of closedAfter until the
+	// page is full, the token points at the page of the index the next page starts from
+	var executions []*persistence.ClosedExecutionIndexInfo
+	lastDay := now.UTC().Truncate(24 * time.Hour)
+	for len(executions) < pageSize && !token.Day.After(lastDay) {
+		indexResp, err := executionMgr.GetClosedExecutionIndex(&persistence.GetClosedExecutionIndexRequest{
+			Day:           token.Day,
+			MinCloseTime:  closedAfter,
+			BatchSize:     pageSize - len(executions),
+			NextPageToken: token.PageToken,
+		})
+		if err != nil {
+			return nil, adh.error(err, scope)
+		}
+		executions = append(executions, indexResp.Executions...)
+		if len(indexResp.NextPageToken) > 0 {
+			token.PageToken = indexResp.NextPageToken
+		} else {
+			token.Day = token.Day.Add(24 * time.Hour)
+			token.PageToken = nil
+		}
+	}
+
+	resp = &admin.ListRecentlyClosedExecutionsResponse{
+		Executions: make([]*admin.ClosedExecutionInfo, 0, len(executions)),
+	}
+	if !token.Day.After(lastDay) {
+		if resp.NextPageToken, err = json.Marshal(token); err != nil {
+			return nil, adh.error(err, scope)
+		}
+	}
+	for _, execution := range executions {
+		resp.Executions = append(resp.Executions, &admin.ClosedExecutionInfo{
+			DomainId:   common.StringPtr(execution.DomainID),
+			WorkflowId: common.StringPtr(execution.WorkflowID),
+			RunId:      common.StringPtr(execution.RunID),
+			CloseTime:  common.Int64Ptr(execution.CloseTime.UnixNano()),
+		})
+	}
+	return resp, nil
+}
+
+// CreateSchedule creates a schedule periodically starting a workflow
+func (adh *AdminHandler) CreateSchedule(ctx context.Context, request *gen.CreateScheduleRequest) (retError error) {
+	defer log.CapturePanic(adh.GetLogger(), &retError)
+
+	scope := metrics.AdminCreateScheduleScope
+	sw := adh.startRequestProfile(scope)
+	defer sw.Stop()
+	defer func() {
+		adh.audit(ctx, "CreateSchedule", request.GetDomain(), request, retError)
+	}()
+
+	if adh.readOnly {
+		return adh.error(errReadOnlyCluster, scope)
+	}
+	if request == nil {
+		return adh.error(errRequestNotSet, scope)
+	}
+	if request.GetDomain() == "" {
+		return adh.error(errDomainNotSet, scope)
+	}
+	if request.GetScheduleId() == "" {
+		return adh.error(errScheduleIDNotSet, scope)
+	}
+	if backoff.GetNextScheduleTime(request.GetCronSchedule(), time.Now()).IsZero() {
+		return adh.error(errInvalidCronSchedule, scope)
+	}
+	if request.WorkflowType == nil || request.WorkflowType.GetName() == "" {
+		return adh.error(errWorkflowTypeNotSet, scope)
+	}
+	if request.TaskList == nil || request.TaskList.GetName() == "" {
+		return adh.error(errTaskListNotSet, scope)
+	}
+	if request.GetExecutionStartToCloseTimeoutSeconds() <= 0 {
+		return adh.error(errInvalidExecutionStartToCloseTimeoutSeconds, scope)
+	}
+	if request.GetTaskStartToCloseTimeoutSeconds() <= 0 {
+		return adh.error(errInvalidTaskStartToCloseTimeoutSeconds, scope)
+	}
+	if request.GetCatchupWindowSeconds() < 0 {
+		return adh.error(errInvalidCatchupWindow, scope)
+	}
+
+	domainID, err := adh.domainCache.GetDomainID(request.GetDomain())
+	if err != nil {
+		return adh.error(err, scope)
+	}
+
+	catchupWindowSeconds := request.GetCatchupWindowSeconds()
+	if catchupWindowSeconds == 0 {
+		catchupWindowSeconds = defaultScheduleCatchupWindowSeconds
+	}
+	err = adh.scheduleMgr.CreateSchedule(&persistence.CreateScheduleRequest{
+		Schedule: &persistence.ScheduleInfo{
+			DomainID:                            domainID,
+			ScheduleID:                          request.GetScheduleId(),
+			CronSchedule:                        request.GetCronSchedule(),
+			WorkflowTypeName:                    request.WorkflowType.GetName(),
+			TaskList:                            request.TaskList.GetName(),
+			Input:                               request.Input,
+			ExecutionStartToCloseTimeoutSeconds: request.GetExecutionStartToCloseTimeoutSeconds(),
+			TaskStartToCloseTimeoutSeconds:      request.GetTaskStartToCloseTimeoutSeconds(),
+			OverlapPolicy:                       int(request.GetOverlapPolicy()),
+			CatchupWindowSeconds:                catchupWindowSeconds,
+			// the schedule fires for the first time at its first fire time after its creation
+			LastFireTime: time.Now(),
+			Identity:     request.GetIdentity(),
+		},
+	})
+	if err != nil {
+		if _, ok := err.(*persistence.ConditionFailedError); ok {
+			return adh.error(errScheduleAlreadyExists, scope)
+		}
+		return adh.error(err, scope)
+	}
+	return nil
+}
+
+// DescribeSchedule returns a schedule along with its next fire time
+func (adh *AdminHandler) DescribeSchedule(ctx context.Context, request *gen.DescribeScheduleRequest) (resp *gen.DescribeScheduleResponse, retError error) {
+	defer log.CapturePanic(adh.GetLogger(), &retError)
+
+	scope := metrics.AdminDescribeScheduleScope
+	sw := adh.startRequestProfile(scope)
+	defer sw.Stop()
+
+	if request == nil {
+		return nil, adh.error(errRequestNotSet, scope)
+	}
+	if request.GetDomain() == "" {
+		return nil, adh.error(errDomainNotSet, scope)
+	}
+	if request.GetScheduleId() == "" {
+		return nil, adh.error(errScheduleIDNotSet, scope)
+	}
+
+	domainID, err := adh.domainCache.GetDomainID(request.GetDomain())
+	if err != nil {
+		return nil, adh.error(err, scope)
+	}
+
+	response, err := adh.scheduleMgr.GetSchedule(&persistence.GetScheduleRequest{
+		DomainID:   domainID,
+		ScheduleID: request.GetScheduleId(),
+	})
+	if err != nil {
+		return nil, adh.error(err, scope)
+	}
+	return &gen.DescribeScheduleResponse{
+		Schedule: scheduleToThrift(response.Schedule, time.Now()),
+	}, nil
+}
+
+// DeleteSchedule deletes a schedule
+func (adh *AdminHandler) DeleteSchedule(ctx context.Context, request *gen.DeleteScheduleRequest) (retError error) {
+	defer log.CapturePanic(adh.GetLogger(), &retError)
+
+	scope := metrics.AdminDeleteScheduleScope
+	sw := adh.startRequestProfile(scope)
+	defer sw.Stop()
+	defer func() {
+		adh.audit(ctx, "DeleteSchedule", request.GetDomain(), request, retError)
+	}()
+
+	if adh.readOnly {
+		return adh.error(errReadOnlyCluster, scope)
+	}
+	if request == nil {
+		return adh.error(errRequestNotSet, scope)
+	}
+	if request.GetDomain() == "" {
+		return adh.error(errDomainNotSet, scope)
+	}
+	if request.GetScheduleId() == "" {
+		return adh.error(errScheduleIDNotSet, scope)
+	}
+
+	domainID, err := adh.domainCache.GetDomainID(request.GetDomain())
+	if err != nil {
+		return adh.error(err, scope)
+	}
+
+	err = adh.scheduleMgr.DeleteSchedule(&persistence.DeleteScheduleRequest{
+		DomainID:   domainID,
+		ScheduleID: request.GetScheduleId(),
+	})
+	if err != nil {
+		return adh.error(err, scope)
+	}
+	return nil
+}
+
+// ListSchedules returns the schedules of a domain
+func (adh *AdminHandler) ListSchedules(ctx context.Context, request *gen.ListSchedulesRequest) (resp *gen.ListSchedulesResponse, retError error) {
+	defer log.CapturePanic(adh.GetLogger(), &retError)
+
+	scope := metrics.AdminListSchedulesScope
+	sw := adh.startRequestProfile(scope)
+	defer sw.Stop()
+
+	if request == nil {
+		return nil, adh.error(errRequestNotSet, scope)
+	}
+	if request.GetDomain() == "" {
+		return nil, adh.error(errDomainNotSet, scope)
+	}
+
+	domainID, err := adh.domainCache.GetDomainID(request.GetDomain())
+	if err != nil {
+		return nil, adh.error(err, scope)
+	}
+
+	pageSize := int(request.GetPageSize())
+	if pageSize <= 0 {
+		pageSize = listSchedulesPageSize
+	}
+	response, err := adh.scheduleMgr.ListSchedules(&persistence.ListSchedulesRequest{
+		DomainID:      domainID,
+		PageSize:      pageSize,
+		NextPageToken: request.NextPageToken,
+	})
+	if err != nil {
+		return nil, adh.error(err, scope)
+	}
+
+	now := time.Now()
+	resp = &gen.ListSchedulesResponse{
+		Schedules:     make([]*gen.ScheduleInfo, 0, len(response.Schedules)),
+		NextPageToken: response.NextPageToken,
+	}
+	for _, schedule := range response.Schedules {
+		resp.Schedules = append(resp.Schedules, scheduleToThrift(schedule, now))
+	}
+	return resp, nil
+}
+
+// SetSchedulePaused pauses or resumes a schedule
+func (adh *AdminHandler) SetSchedulePaused(ctx context.Context, request *gen.SetSchedulePausedRequest) (retError error) {
+	defer log.CapturePanic(adh.GetLogger(), &retError)
+
+	scope := metrics.AdminSetSchedulePausedScope
+	sw := adh.startRequestProfile(scope)
+	defer sw.Stop()
+	defer func() {
+		adh.audit(ctx, "SetSchedulePaused", request.GetDomain(), request, retError)
+	}()
+
+	if adh.readOnly {
+		return adh.error(errReadOnlyCluster, scope)
+	}
+	if request == nil {
+		return adh.error(errRequestNotSet, scope)
+	}
+	if request.GetDomain() == "" {
+		return adh.error(errDomainNotSet, scope)
+	}
+	if request.GetScheduleId() == "" {
+		return adh.error(errScheduleIDNotSet, scope)
+	}
+
+	domainID, err := adh.domainCache.GetDomainID(request.GetDomain())
+	if err != nil {
+		return adh.error(err, scope)
+	}
+
+	err = adh.updateSchedule(domainID, request.GetScheduleId(), func(schedule *persistence.ScheduleInfo) error {
+		schedule.Paused = request.GetPaused()
+		return nil
+	})
+	if err != nil {
+		return adh.error(err, scope)
+	}
+	return nil
+}
+
+// BackfillSchedule starts the workflows of every fire time of a schedule in the given time range
+func (adh *AdminHandler) BackfillSchedule(ctx context.Context, request *gen.BackfillScheduleRequest) (retError error) {
+	defer log.CapturePanic(adh.GetLogger(), &retError)
+
+	scope := metrics.AdminBackfillScheduleScope
+	sw := adh.startRequestProfile(scope)
+	defer sw.Stop()
+	defer func() {
+		adh.audit(ctx, "BackfillSchedule", request.GetDomain(), request, retError)
+	}()
+
+	if adh.readOnly {
+		return adh.error(errReadOnlyCluster, scope)
+	}
+	if request == nil {
+		return adh.error(errRequestNotSet, scope)
+	}
+	if request.GetDomain() == "" {
+		return adh.error(errDomainNotSet, scope)
+	}
+	if request.GetScheduleId() == "" {
+		return adh.error(errScheduleIDNotSet, scope)
+	}
+	startTime := time.Unix(0, request.GetStartTime())
+	endTime := time.Unix(0, request.GetEndTime())
+	if request.GetStartTime() <= 0 || endTime.Before(startTime) || endTime.After(time.Now()) {
+		return adh.error(errInvalidBackfillTimeRange, scope)
+	}
+	if request.GetRunsPerMinute() < 0 {
+		return adh.error(errInvalidBackfillRate, scope)
+	}
+	runsPerMinute := request.GetRunsPerMinute()
+	if runsPerMinute == 0 {
+		runsPerMinute = defaultBackfillRunsPerMinute
+	}
+
+	domainID, err := adh.domainCache.GetDomainID(request.GetDomain())
+	if err != nil {
+		return adh.error(err, scope)
+	}
+
+	backfillID := uuid.New()
+	err = adh.updateSchedule(domainID, request.GetScheduleId(), func(schedule *persistence.ScheduleInfo) error {
+		if !schedule.BackfillEndTime.IsZero() {
+			return errBackfillInProgress
+		}
+		schedule.BackfillID = backfillID
+		schedule.BackfillStartTime = startTime
+		schedule.BackfillEndTime = endTime
+		schedule.BackfillRunsPerMinute = runsPerMinute
+		schedule.BackfillLastRunTime = time.Time{}
+		return nil
+	})
+	if err != nil {
+		return adh.error(err, scope)
+	}
+	return nil
+}
+
+// UpdateTaskListVersionSets replaces the worker build ID version sets of a decision task list
+func (adh *AdminHandler) UpdateTaskListVersionSets(ctx context.Context, request *gen.UpdateTaskListVersionSetsRequest) (retError error) {
+	defer log.CapturePanic(adh.GetLogger(), &retError)
+
+	scope := metrics.AdminUpdateTaskListVersionSetsScope
+	sw := adh.startRequestProfile(scope)
+	defer sw.Stop()
+	defer func() {
+		adh.audit(ctx, "UpdateTaskListVersionSets", request.GetDomain(), request, retError)
+	}()
+
+	if adh.readOnly {
+		return adh.error(errReadOnlyCluster, scope)
+	}
+	if request == nil {
+		return adh.error(errRequestNotSet, scope)
+	}
+	if request.GetDomain() == "" {
+		return adh.error(errDomainNotSet, scope)
+	}
+	if request.TaskList == nil || request.TaskList.GetName() == "" {
+		return adh.error(errTaskListNotSet, scope)
+	}
+
+	domainID, err := adh.domainCache.GetDomainID(request.GetDomain())
+	if err != nil {
+		return adh.error(err, scope)
+	}
+
+	err = adh.matching.UpdateTaskListVersionSets(ctx, &m.UpdateTaskListVersionSetsRequest{
+		DomainUUID:  common.StringPtr(domainID),
+		TaskList:    &gen.TaskList{Name: common.StringPtr(request.TaskList.GetName())},
+		VersionSets: request.VersionSets,
+	})
+	if err != nil {
+		return adh.error(err, scope)
+	}
+	return nil
+}
+
+// DescribeTaskJourney returns the stages an activity or decision task recently went through in matching
+func (adh *AdminHandler) DescribeTaskJourney(
+	ctx context.Context,
+	request *gen.DescribeTaskJourneyRequest,
+) (resp *gen.DescribeTaskJourneyResponse, retError error) {
+	defer log.CapturePanic(adh.GetLogger(), &retError)
+
+	scope := metrics.AdminDescribeTaskJourneyScope
+	sw := adh.startRequestProfile(scope)
+	defer sw.Stop()
+
+	if adh.readOnly {
+		return nil, adh.error(errReadOnlyClusterNotServed, scope)
+	}
+
+	if request == nil {
+		return nil, adh.error(errRequestNotSet, scope)
+	}
+	if request.GetDomain() == "" {
+		return nil, adh.error(errDomainNotSet, scope)
+	}
+	if request.TaskList == nil || request.TaskList.GetName() == "" {
+		return nil, adh.error(errTaskListNotSet, scope)
+	}
+	if request.TaskListType == nil {
+		return nil, adh.error(errTaskListTypeNotSet, scope)
+	}
+	if request.Execution != nil {
+		if err := validateExecution(request.Execution); err != nil {
+			return nil, adh.error(err, scope)
+		}
+		if request.ScheduleId == nil {
+			return nil, adh.error(errTaskJourneyIDNotSet, scope)
+		}
+	} else if request.TaskId == nil {
+		return nil, adh.error(errTaskJourneyIDNotSet, scope)
+	}
+
+	domainID, err := adh.domainCache.GetDomainID(request.GetDomain())
+	if err != nil {
+		return nil, adh.error(err, scope)
+	}
+
+	resp, err = adh.matching.DescribeTaskJourney(ctx, &m.DescribeTaskJourneyRequest{
+		DomainUUID:     common.StringPtr(domainID),
+		JourneyRequest: request,
+	})
+	if err != nil {
+		return nil, adh.error(err, scope)
+	}
+	return resp, nil
+}
+
+// updateSchedule applies an update to a schedule, retrying if the schedule is updated concurrently by the scheduler
+func (adh *AdminHandler) updateSchedule(domainID string, scheduleID string, update func(*persistence.ScheduleInfo) error) error {
+	for attempt := 1; ; attempt++ {
+		response, err := adh.scheduleMgr.GetSchedule(&persistence.GetScheduleRequest{
+			DomainID:   domainID,
+			ScheduleID: scheduleID,
+		})
+		if err != nil {
+			return err
+		}
+		schedule := response.Schedule
+		previousVersion := schedule.Version
+		if err := update(schedule); err != nil {
+			return err
+		}
+		schedule.Version = previousVersion + 1
+		err = adh.scheduleMgr.UpdateSchedule(&persistence.UpdateScheduleRequest{
+			Schedule:        schedule,
+			PreviousVersion: previousVersion,
+		})
+		if _, ok := err.(*persistence.ConditionFailedError); ok && attempt < scheduleUpdateMaxAttempts {
+			continue
+		}
+		return err
+	}
+}
+
+// historyHostAddresses returns the addresses of the history hosts currently owning a shard
+func (adh *AdminHandler) historyHostAddresses() ([]string, error) {
+	seen := make(map[string]struct{})
+	var addresses []string
+	for shardID := 0; shardID < adh.numberOfHistoryShards; shardID++ {
+		host, err := adh.GetMembershipMonitor().Lookup(common.HistoryServiceName, string(shardID))
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := seen[host.GetAddress()]; !ok {
+			seen[host.GetAddress()] = struct{}{}
+			addresses = append(addresses, host.GetAddress())
+		}
+	}
+	return addresses, nil
+}
+
+// scheduleToThrift converts a persisted schedule to its thrift representation
+func scheduleToThrift(schedule *persistence.ScheduleInfo, now time.Time) *gen.ScheduleInfo {
+	info := &gen.ScheduleInfo{
+		ScheduleId:                          common.StringPtr(schedule.ScheduleID),
+		CronSchedule:                        common.StringPtr(schedule.CronSchedule),
+		WorkflowType:                        &gen.WorkflowType{Name: common.StringPtr(schedule.WorkflowTypeName)},
+		TaskList:                            &gen.TaskList{Name: common.StringPtr(schedule.TaskList)},
+		Input:                               schedule.Input,
+		ExecutionStartToCloseTimeoutSeconds: common.Int32Ptr(schedule.ExecutionStartToCloseTimeoutSeconds),
+		TaskStartToCloseTimeoutSeconds:      common.Int32Ptr(schedule.TaskStartToCloseTimeoutSeconds),
+		OverlapPolicy:                       gen.ScheduleOverlapPolicy(schedule.OverlapPolicy).Ptr(),
+		CatchupWindowSeconds:                common.Int32Ptr(schedule.CatchupWindowSeconds),
+		Paused:                              common.BoolPtr(schedule.Paused),
+		LastFireTime:                        common.Int64Ptr(schedule.LastFireTime.UnixNano()),
+		Identity:                            common.StringPtr(schedule.Identity),
+	}
+	if schedule.LastWorkflowID != "" {
+		info.LastExecution = &gen.WorkflowExecution{
+			WorkflowId: common.StringPtr(schedule.LastWorkflowID),
+			RunId:      common.StringPtr(schedule.LastRunID),
+		}
+	}
+	if schedule.LastBackfillWorkflowID != "" {
+		info.LastBackfillExecution = &gen.WorkflowExecution{
+			WorkflowId: common.StringPtr(schedule.LastBackfillWorkflowID),
+			RunId:      common.StringPtr(schedule.LastBackfillRunID),
+		}
+	}
+	if nextFireTime := scheduler.GetNextFireTime(schedule, now); !nextFireTime.IsZero() {
+		info.NextFireTime = common.Int64Ptr(nextFireTime.UnixNano())
+	}
+	if !schedule.BackfillEndTime.IsZero() {
+		info.BackfillStartTime = common.Int64Ptr(schedule.BackfillStartTime.UnixNano())
+		info.BackfillEndTime = common.Int64Ptr(schedule.BackfillEndTime.UnixNano())
+		info.BackfillRunsPerMinute = common.Int32Ptr(schedule.BackfillRunsPerMinute)
+	}
+	return info
+}
+
+// mergeClientVersions sums up the decision counts reported by each history host for the same domain and
+// client version, keeping the latest last seen timestamp
+func mergeClientVersions(responses []*gen.DescribeClientVersionsResponse) *gen.DescribeClientVersionsResponse {
+	type clientVersionKey struct {
+		domain         string
+		clientImpl     string
+		featureVersion string
+		libraryVersion string
+	}
+
+	merged := make(map[clientVersionKey]*gen.ClientVersionInfo)
+	var infos []*gen.ClientVersionInfo
+	for _, resp := range responses {
+		for _, info := range resp.GetClientVersions() {
+			key := clientVersionKey{
+				domain:         info.GetDomain(),
+				clientImpl:     info.GetClientImpl(),
+				featureVersion: info.GetFeatureVersion(),
+				libraryVersion: info.GetLibraryVersion(),
+			}
+			existing, ok := merged[key]
+			if !ok {
+				merged[key] = info
+				infos = append(infos, info)
+				continue
+			}
+			existing.DecisionCount = common.Int64Ptr(existing.GetDecisionCount() + info.GetDecisionCount())
+			if info.GetLastSeenTimestamp() > existing.GetLastSeenTimestamp() {
+				existing.LastSeenTimestamp = info.LastSeenTimestamp
+			}
+		}
+	}
+
+	historyService.SortClientVersionInfos(infos)
+	return &gen.DescribeClientVersionsResponse{ClientVersions: infos}
+}
+
+// audit records a mutating admin API call to the audit sink
+func (adh *AdminHandler) audit(ctx context.Context, operation string, domainName string, request interface{}, err error) {
+	recordAudit(ctx, adh.authenticator, adh.auditSink, adh.GetLogger(), operation, domainName, request, err)
+}
+
+// startRequestProfile initiates recording of request metrics
+func (adh *AdminHandler) startRequestProfile(scope int) tally.Stopwatch {
+	adh.startWG.Wait()
+	sw := adh.metricsClient.StartTimer(scope, metrics.CadenceLatency)
+	adh.metricsClient.IncCounter(scope, metrics.CadenceRequests)
+	return sw
+}
+
+func (adh *AdminHandler) error(err error, scope int) error {
+	switch err.(type) {
+	case *gen.InternalServiceError:
+		adh.Service.GetLogger().Error("Internal service error", tag.Error(err))
+		return err
+	case common.StoreInternalError:
+		adh.Service.GetLogger().Error("Internal service error", tag.Error(err))
+		return err.(common.StoreInternalError).InternalServiceError()
+	case *gen.BadRequestError:
+		return err
+	case *gen.ServiceBusyError:
+		return err
+	case *gen.EntityNotExistsError:
+		return err
+	case *gen.DomainNotActiveError:
 		return err
 	default:
 		adh.Service.GetLogger().Error("Uncategorized error", tag.Error(err))
@@ -0,0 +1,323 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package frontend
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pborman/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"github.com/uber-go/tally"
+	"github.com/uber/cadence/.gen/go/admin"
+	"github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/audit"
+	"github.com/uber/cadence/common/authorization"
+	"github.com/uber/cadence/common/membership"
+	"github.com/uber/cadence/common/metrics"
+	"github.com/uber/cadence/common/mocks"
+	"github.com/uber/cadence/common/persistence"
+	"github.com/uber/cadence/common/service"
+)
+
+type (
+	adminHandlerSuite struct {
+		suite.Suite
+
+		mockClusterMetadata     *mocks.ClusterMetadata
+		mockMetadataMgr         *mocks.MetadataManager
+		mockExecutionMgrFactory *mocks.ExecutionManagerFactory
+		mockExecutionMgr        *mocks.ExecutionManager
+		mockShardMgr            *mocks.ShardManager
+		handler                 *AdminHandler
+	}
+
+	// membershipTestService is a test service with the given membership monitor
+	membershipTestService struct {
+		service.Service
+		monitor membership.Monitor
+	}
+
+	// historyHostTestMonitor is a membership monitor resolving every key to the history host
+	historyHostTestMonitor struct {
+		membership.Monitor
+		historyHost *membership.HostInfo
+	}
+)
+
+func TestAdminHandlerSuite(t *testing.T) {
+	suite.Run(t, new(adminHandlerSuite))
+}
+
+func (s *membershipTestService) GetMembershipMonitor() membership.Monitor {
+	return s.monitor
+}
+
+func (m *historyHostTestMonitor) Lookup(serviceName string, key string) (*membership.HostInfo, error) {
+	return m.historyHost, nil
+}
+
+func (s *adminHandlerSuite) SetupTest() {
+	s.mockClusterMetadata = &mocks.ClusterMetadata{}
+	s.mockMetadataMgr = &mocks.MetadataManager{}
+	s.mockExecutionMgrFactory = &mocks.ExecutionManagerFactory{}
+	s.mockExecutionMgr = &mocks.ExecutionManager{}
+	s.mockShardMgr = &mocks.ShardManager{}
+	metricsClient := metrics.NewClient(tally.NoopScope, metrics.Frontend)
+	svc := &membershipTestService{
+		Service: service.NewTestService(s.mockClusterMetadata, nil, metricsClient, nil),
+		monitor: &historyHostTestMonitor{historyHost: membership.NewHostInfo("history-host:7934", nil)},
+	}
+
+	s.handler = NewAdminHandler(svc, 4, false, s.mockMetadataMgr, nil, nil, nil, nil, nil, s.mockShardMgr,
+		s.mockExecutionMgrFactory, nil, authorization.NewNopAuthenticator(), audit.NewNopSink())
+	s.handler.metricsClient = metricsClient
+	s.handler.startWG.Done()
+}
+
+func (s *adminHandlerSuite) TearDownTest() {
+	s.mockExecutionMgrFactory.AssertExpectations(s.T())
+	s.mockExecutionMgr.AssertExpectations(s.T())
+	s.mockShardMgr.AssertExpectations(s.T())
+}
+
+func (s *adminHandlerSuite) TestListRecentlyClosedExecutions_Paginated() {
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	firstDay := today.Add(-48 * time.Hour)
+	closedAfter := firstDay.Add(time.Hour)
+	s.mockExecutionMgrFactory.On("NewExecutionManager", 1).Return(s.mockExecutionMgr, nil).Once()
+
+	indexRequest := func(day time.Time, batchSize int, token []byte) interface{} {
+		return mock.MatchedBy(func(request *persistence.GetClosedExecutionIndexRequest) bool {
+			return request.Day.Equal(day) &&
+				request.MinCloseTime.Equal(closedAfter) &&
+				request.BatchSize == batchSize &&
+				bytes.Equal(request.NextPageToken, token)
+		})
+	}
+	indexResponse := func(token []byte, runIDs ...string) *persistence.GetClosedExecutionIndexResponse {
+		resp := &persistence.GetClosedExecutionIndexResponse{NextPageToken: token}
+		for _, runID := range runIDs {
+			resp.Executions = append(resp.Executions, &persistence.ClosedExecutionIndexInfo{
+				DomainID:   "domain-id",
+				WorkflowID: "workflow-id",
+				RunID:      runID,
+				CloseTime:  closedAfter,
+			})
+		}
+		return resp
+	}
+	runIDs := func(resp *admin.ListRecentlyClosedExecutionsResponse) []string {
+		var ids []string
+		for _, execution := range resp.Executions {
+			ids = append(ids, execution.GetRunId())
+		}
+		return ids
+	}
+
+	// the first page stops in the middle of the second day
+	s.mockExecutionMgr.On("GetClosedExecutionIndex", indexRequest(firstDay, 2, nil)).
+		Return(indexResponse(nil, "run-1"), nil).Once()
+	s.mockExecutionMgr.On("GetClosedExecutionIndex", indexRequest(firstDay.Add(24*time.Hour), 1, nil)).
+		Return(indexResponse([]byte("day-2-page-2"), "run-2"), nil).Once()
+	resp, err := s.handler.ListRecentlyClosedExecutions(context.Background(), &admin.ListRecentlyClosedExecutionsRequest{
+		ShardId:         common.Int32Ptr(1),
+		ClosedAfter:     common.Int64Ptr(closedAfter.UnixNano()),
+		MaximumPageSize: common.Int32Ptr(2),
+	})
+	s.NoError(err)
+	s.Equal([]string{"run-1", "run-2"}, runIDs(resp))
+	s.NotEmpty(resp.NextPageToken)
+
+	// the second page resumes the second day and reads until today
+	s.mockExecutionMgr.On("GetClosedExecutionIndex", indexRequest(firstDay.Add(24*time.Hour), 2, []byte("day-2-page-2"))).
+		Return(indexResponse(nil, "run-3"), nil).Once()
+	s.mockExecutionMgr.On("GetClosedExecutionIndex", indexRequest(today, 1, nil)).
+		Return(indexResponse(nil), nil).Once()
+	resp, err = s.handler.ListRecentlyClosedExecutions(context.Background(), &admin.ListRecentlyClosedExecutionsRequest{
+		ShardId:         common.Int32Ptr(1),
+		ClosedAfter:     common.Int64Ptr(closedAfter.UnixNano()),
+		MaximumPageSize: common.Int32Ptr(2),
+		NextPageToken:   resp.NextPageToken,
+	})
+	s.NoError(err)
+	s.Equal([]string{"run-3"}, runIDs(resp))
+	s.Empty(resp.NextPageToken)
+}
+
+func (s *adminHandlerSuite) TestListRecentlyClosedExecutions_InvalidToken() {
+	_, err := s.handler.ListRecentlyClosedExecutions(context.Background(), &admin.ListRecentlyClosedExecutionsRequest{
+		ShardId:       common.Int32Ptr(1),
+		ClosedAfter:   common.Int64Ptr(time.Now().Add(-time.Hour).UnixNano()),
+		NextPageToken: []byte("not a token"),
+	})
+	s.IsType(&shared.BadRequestError{}, err)
+}
+
+func (s *adminHandlerSuite) TestDescribeWorkflowShard() {
+	domainID := uuid.New()
+	runID := uuid.New()
+	shardID := common.WorkflowIDToHistoryShard("workflow-id", 4)
+	timerAckLevel := time.Now().Add(-time.Minute)
+	s.mockShardMgr.On("GetShard", &persistence.GetShardRequest{ShardID: shardID}).Return(&persistence.GetShardResponse{
+		ShardInfo: &persistence.ShardInfo{
+			ShardID:                 shardID,
+			Owner:                   "history-host:7934",
+			RangeID:                 5,
+			TransferAckLevel:        10,
+			TimerAckLevel:           timerAckLevel,
+			ReplicationAckLevel:     8,
+			ClusterTransferAckLevel: map[string]int64{"active": 10},
+			ClusterTimerAckLevel:    map[string]time.Time{"active": timerAckLevel},
+		},
+	}, nil).Twice()
+	// the execution manager of the shard is reused by the following requests
+	s.mockExecutionMgrFactory.On("NewExecutionManager", shardID).Return(s.mockExecutionMgr, nil).Once()
+	s.mockExecutionMgr.On("GetCurrentExecution", &persistence.GetCurrentExecutionRequest{
+		DomainID:   domainID,
+		WorkflowID: "workflow-id",
+	}).Return(&persistence.GetCurrentExecutionResponse{RunID: runID}, nil).Once()
+	s.mockExecutionMgr.On("GetWorkflowExecution", &persistence.GetWorkflowExecutionRequest{
+		DomainID: domainID,
+		Execution: shared.WorkflowExecution{
+			WorkflowId: common.StringPtr("workflow-id"),
+			RunId:      common.StringPtr(runID),
+		},
+	}).Return(&persistence.GetWorkflowExecutionResponse{}, nil).Once()
+
+	request := &admin.DescribeWorkflowShardRequest{
+		DomainId:   common.StringPtr(domainID),
+		WorkflowId: common.StringPtr("workflow-id"),
+	}
+	resp, err := s.handler.DescribeWorkflowShard(context.Background(), request)
+	s.NoError(err)
+	s.Equal(&admin.DescribeWorkflowShardResponse{
+		ShardId:                 common.Int32Ptr(int32(shardID)),
+		HistoryAddr:             common.StringPtr("history-host:7934"),
+		ShardOwner:              common.StringPtr("history-host:7934"),
+		RangeId:                 common.Int64Ptr(5),
+		TransferAckLevel:        common.Int64Ptr(10),
+		TimerAckLevel:           common.Int64Ptr(timerAckLevel.UnixNano()),
+		ReplicationAckLevel:     common.Int64Ptr(8),
+		ClusterTransferAckLevel: map[string]int64{"active": 10},
+		ClusterTimerAckLevel:    map[string]int64{"active": timerAckLevel.UnixNano()},
+		CurrentRunId:            common.StringPtr(runID),
+		MutableStateExists:      common.BoolPtr(true),
+	}, resp)
+
+	// a workflow without a current run is described by its shard only
+	s.mockExecutionMgr.On("GetCurrentExecution", mock.Anything).Return(nil, &shared.EntityNotExistsError{}).Once()
+	resp, err = s.handler.DescribeWorkflowShard(context.Background(), request)
+	s.NoError(err)
+	s.Nil(resp.CurrentRunId)
+	s.False(resp.GetMutableStateExists())
+}
+
+func (s *adminHandlerSuite) TestDescribeWorkflowShard_InvalidRequest() {
+	_, err := s.handler.DescribeWorkflowShard(context.Background(), &admin.DescribeWorkflowShardRequest{
+		DomainId:   common.StringPtr("not a domain ID"),
+		WorkflowId: common.StringPtr("workflow-id"),
+	})
+	s.Equal(errInvalidDomainID, err)
+
+	_, err = s.handler.DescribeWorkflowShard(context.Background(), &admin.DescribeWorkflowShardRequest{
+		DomainId: common.StringPtr(uuid.New()),
+	})
+	s.Equal(errWorkflowIDNotSet, err)
+}
+
+func (s *adminHandlerSuite) TestDescribeWorkflowShard_PersistenceError() {
+	shardID := common.WorkflowIDToHistoryShard("workflow-id", 4)
+	request := &admin.DescribeWorkflowShardRequest{
+		DomainId:   common.StringPtr(uuid.New()),
+		WorkflowId: common.StringPtr("workflow-id"),
+	}
+
+	s.mockShardMgr.On("GetShard", &persistence.GetShardRequest{ShardID: shardID}).
+		Return(nil, &shared.InternalServiceError{Message: "persistence error"}).Once()
+	_, err := s.handler.DescribeWorkflowShard(context.Background(), request)
+	s.IsType(&shared.InternalServiceError{}, err)
+
+	s.mockShardMgr.On("GetShard", &persistence.GetShardRequest{ShardID: shardID}).
+		Return(&persistence.GetShardResponse{ShardInfo: &persistence.ShardInfo{ShardID: shardID}}, nil).Once()
+	s.mockExecutionMgrFactory.On("NewExecutionManager", shardID).Return(s.mockExecutionMgr, nil).Once()
+	s.mockExecutionMgr.On("GetCurrentExecution", mock.Anything).
+		Return(nil, &shared.InternalServiceError{Message: "persistence error"}).Once()
+	_, err = s.handler.DescribeWorkflowShard(context.Background(), request)
+	s.IsType(&shared.InternalServiceError{}, err)
+}
+
+func (s *adminHandlerSuite) TestHistoryReads_ReadOnly() {
+	s.handler.readOnly = true
+	execution := &shared.WorkflowExecution{WorkflowId: common.StringPtr("workflow-id"), RunId: common.StringPtr(uuid.New())}
+
+	_, err := s.handler.DescribeWorkflowExecution(context.Background(), &admin.DescribeWorkflowExecutionRequest{
+		Domain:    common.StringPtr("test-domain"),
+		Execution: execution,
+	})
+	s.Equal(errReadOnlyClusterNotServed, err)
+
+	_, err = s.handler.DescribeHistoryHost(context.Background(), &shared.DescribeHistoryHostRequest{
+		ExecutionForHost: execution,
+	})
+	s.Equal(errReadOnlyClusterNotServed, err)
+
+	_, err = s.handler.GetWorkflowExecutionRawHistory(context.Background(), &admin.GetWorkflowExecutionRawHistoryRequest{
+		Domain:    common.StringPtr("test-domain"),
+		Execution: execution,
+	})
+	s.Equal(errReadOnlyClusterNotServed, err)
+
+	_, err = s.handler.GetDomainOpenExecutionCount(context.Background(), &shared.GetDomainOpenExecutionCountRequest{
+		Domain: common.StringPtr("test-domain"),
+	})
+	s.Equal(errReadOnlyClusterNotServed, err)
+}
+
+func (s *adminHandlerSuite) TestAuditStoreSink() {
+	auditMgr := &mocks.AuditManager{}
+	defer auditMgr.AssertExpectations(s.T())
+	sink := newAuditStoreSink(auditMgr)
+
+	entry := audit.NewEntry("alice", "cadence-cli", "PurgeWorkflowExecution", "test-domain",
+		&admin.PurgeWorkflowExecutionRequest{Domain: common.StringPtr("test-domain")}, nil)
+	auditMgr.On("RecordAuditEntry", &persistence.RecordAuditEntryRequest{
+		Entry: &persistence.AuditEntry{
+			Timestamp:     entry.Timestamp,
+			Caller:        "alice",
+			CallerService: "cadence-cli",
+			Operation:     "PurgeWorkflowExecution",
+			Domain:        "test-domain",
+			RequestHash:   entry.RequestHash,
+		},
+	}).Return(nil).Once()
+	s.NoError(sink.Write(entry))
+
+	auditMgr.On("RecordAuditEntry", mock.Anything).Return(&shared.InternalServiceError{Message: "persistence error"}).Once()
+	s.Error(sink.Write(entry))
+
+	auditMgr.On("Close").Once()
+	s.NoError(sink.Close())
+}
@@ -0,0 +1,112 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package frontend
+
+import (
+	"context"
+
+	gen "github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common/audit"
+	"github.com/uber/cadence/common/authorization"
+	"github.com/uber/cadence/common/log"
+	"github.com/uber/cadence/common/log/tag"
+	"github.com/uber/cadence/common/persistence"
+	"go.uber.org/yarpc"
+)
+
+const (
+	// auditOperationFailoverDomain is recorded for domain updates changing the active cluster
+	auditOperationFailoverDomain = "FailoverDomain"
+)
+
+type (
+	// auditStoreSink persists audit entries to the audit log table of the persistence store
+	auditStoreSink struct {
+		auditMgr persistence.AuditManager
+	}
+)
+
+var _ audit.Sink = (*auditStoreSink)(nil)
+
+func newAuditStoreSink(auditMgr persistence.AuditManager) audit.Sink {
+	return &auditStoreSink{
+		auditMgr: auditMgr,
+	}
+}
+
+func (s *auditStoreSink) Write(entry *audit.Entry) error {
+	return s.auditMgr.RecordAuditEntry(&persistence.RecordAuditEntryRequest{
+		Entry: &persistence.AuditEntry{
+			Timestamp:     entry.Timestamp,
+			Caller:        entry.Caller,
+			CallerService: entry.CallerService,
+			Operation:     entry.Operation,
+			Domain:        entry.Domain,
+			RequestHash:   entry.RequestHash,
+			Error:         entry.Error,
+		},
+	})
+}
+
+func (s *auditStoreSink) Close() error {
+	s.auditMgr.Close()
+	return nil
+}
+
+// recordAudit writes the audit entry of a mutating API call, failing to record
+// the entry is logged but does not fail the call
+func recordAudit(
+	ctx context.Context,
+	authenticator authorization.Authenticator,
+	sink audit.Sink,
+	logger log.Logger,
+	operation string,
+	domain string,
+	request interface{},
+	err error,
+) {
+	caller := anonymousCaller
+	if identity, authErr := authenticator.Authenticate(ctx); authErr == nil && identity != nil {
+		caller = identity.Subject
+	}
+	callerService := ""
+	if call := yarpc.CallFromContext(ctx); call != nil {
+		callerService = call.Caller()
+	}
+
+	entry := audit.NewEntry(caller, callerService, operation, domain, request, err)
+	if writeErr := sink.Write(entry); writeErr != nil {
+		logger.Error("Failed to record audit entry",
+			tag.CallerIdentity(caller),
+			tag.WorkflowHandlerName(operation),
+			tag.WorkflowDomainName(domain),
+			tag.Error(writeErr))
+	}
+}
+
+// updateDomainAuditOperation distinguishes domain failovers from other domain updates
+func updateDomainAuditOperation(updateRequest *gen.UpdateDomainRequest) string {
+	if updateRequest != nil && updateRequest.ReplicationConfiguration != nil &&
+		updateRequest.ReplicationConfiguration.ActiveClusterName != nil {
+		return auditOperationFailoverDomain
+	}
+	return "UpdateDomain"
+}
@@ -36,6 +36,7 @@ import (
 	"github.com/uber/cadence/common/metrics"
 	"github.com/uber/cadence/common/service"
 	"github.com/uber/cadence/common/service/config"
+	"go.uber.org/yarpc"
 )
 
 type (
@@ -100,10 +101,9 @@ func (handler *DCRedirectionHandlerImpl) Stop() {
 	handler.stopFn()
 }
 
-// Health is for health check
+// Health is for health check, it reflects the health of the frontend handler
 func (handler *DCRedirectionHandlerImpl) Health(ctx context.Context) (*health.HealthStatus, error) {
-	hs := &health.HealthStatus{Ok: true, Msg: common.StringPtr("dc redirection good")}
-	return hs, nil
+	return handler.frontendHandler.Health(ctx)
 }
 
 // Domain APIs, domain APIs does not require redirection
@@ -220,6 +220,36 @@ func (handler *DCRedirectionHandlerImpl) DescribeTaskList(
 	return resp, err
 }
 
+// GetTaskListScalingHints API call
+func (handler *DCRedirectionHandlerImpl) GetTaskListScalingHints(
+	ctx context.Context,
+	request *shared.GetTaskListScalingHintsRequest,
+) (resp *shared.GetTaskListScalingHintsResponse, retError error) {
+
+	var apiName = "GetTaskListScalingHints"
+	var err error
+	var cluster string
+
+	scope, startTime := handler.beforeCall(metrics.DCRedirectionGetTaskListScalingHintsScope)
+	defer func() {
+		handler.afterCall(scope, startTime, cluster, &retError)
+	}()
+
+	err = handler.redirectionPolicy.WithDomainNameRedirect(request.GetDomain(), apiName, func(targetDC string) error {
+		cluster = targetDC
+		switch {
+		case targetDC == handler.currentClusterName:
+			resp, err = handler.frontendHandler.GetTaskListScalingHints(ctx, request)
+		default:
+			remoteClient := handler.clientBeanProvider().GetRemoteFrontendClient(targetDC)
+			resp, err = remoteClient.GetTaskListScalingHints(ctx, request)
+		}
+		return err
+	})
+
+	return resp, err
+}
+
 // DescribeWorkflowExecution API call
 func (handler *DCRedirectionHandlerImpl) DescribeWorkflowExecution(
 	ctx context.Context,
@@ -235,6 +265,12 @@ func (handler *DCRedirectionHandlerImpl) DescribeWorkflowExecution(
 		handler.afterCall(scope, startTime, cluster, &retError)
 	}()
 
+	if handler.isForwardedRead(ctx) {
+		// never forward again a read forwarded by another cluster, the clusters can disagree on the active one
+		cluster = handler.currentClusterName
+		return handler.frontendHandler.DescribeWorkflowExecution(ctx, request)
+	}
+
 	err = handler.redirectionPolicy.WithDomainNameRedirect(request.GetDomain(), apiName, func(targetDC string) error {
 		cluster = targetDC
 		switch {
@@ -242,7 +278,9 @@ func (handler *DCRedirectionHandlerImpl) DescribeWorkflowExecution(
 			resp, err = handler.frontendHandler.DescribeWorkflowExecution(ctx, request)
 		default:
 			remoteClient := handler.clientBeanProvider().GetRemoteFrontendClient(targetDC)
-			resp, err = remoteClient.DescribeWorkflowExecution(ctx, request)
+			forwardCtx, cancel := handler.forwardedReadContext(ctx, request.GetDomain())
+			defer cancel()
+			resp, err = remoteClient.DescribeWorkflowExecution(forwardCtx, request, handler.forwardedReadOption())
 		}
 		return err
 	})
@@ -519,6 +557,12 @@ func (handler *DCRedirectionHandlerImpl) QueryWorkflow(
 		handler.afterCall(scope, startTime, cluster, &retError)
 	}()
 
+	if handler.isForwardedRead(ctx) {
+		// never forward again a read forwarded by another cluster, the clusters can disagree on the active one
+		cluster = handler.currentClusterName
+		return handler.frontendHandler.QueryWorkflow(ctx, request)
+	}
+
 	err = handler.redirectionPolicy.WithDomainNameRedirect(request.GetDomain(), apiName, func(targetDC string) error {
 		cluster = targetDC
 		switch {
@@ -526,7 +570,9 @@ func (handler *DCRedirectionHandlerImpl) QueryWorkflow(
 			resp, err = handler.frontendHandler.QueryWorkflow(ctx, request)
 		default:
 			remoteClient := handler.clientBeanProvider().GetRemoteFrontendClient(targetDC)
-			resp, err = remoteClient.QueryWorkflow(ctx, request)
+			forwardCtx, cancel := handler.forwardedReadContext(ctx, request.GetDomain())
+			defer cancel()
+			resp, err = remoteClient.QueryWorkflow(forwardCtx, request, handler.forwardedReadOption())
 		}
 		return err
 	})
@@ -1108,6 +1154,64 @@ func (handler *DCRedirectionHandlerImpl) TerminateWorkflowExecution(
 	return err
 }
 
+// UpdateWorkflowExecution API call
+func (handler *DCRedirectionHandlerImpl) UpdateWorkflowExecution(
+	ctx context.Context,
+	request *shared.UpdateWorkflowExecutionRequest,
+) (resp *shared.UpdateWorkflowExecutionResponse, retError error) {
+
+	var apiName = "UpdateWorkflowExecution"
+	var err error
+	var cluster string
+
+	scope, startTime := handler.beforeCall(metrics.DCRedirectionUpdateWorkflowExecutionScope)
+	defer func() {
+		handler.afterCall(scope, startTime, cluster, &retError)
+	}()
+
+	err = handler.redirectionPolicy.WithDomainNameRedirect(request.GetDomain(), apiName, func(targetDC string) error {
+		cluster = targetDC
+		switch {
+		case targetDC == handler.currentClusterName:
+			resp, err = handler.frontendHandler.UpdateWorkflowExecution(ctx, request)
+		default:
+			remoteClient := handler.clientBeanProvider().GetRemoteFrontendClient(targetDC)
+			resp, err = remoteClient.UpdateWorkflowExecution(ctx, request)
+		}
+		return err
+	})
+
+	return resp, err
+}
+
+func (handler *DCRedirectionHandlerImpl) isForwardedRead(
+	ctx context.Context,
+) bool {
+
+	call := yarpc.CallFromContext(ctx)
+	return call != nil && call.Header(common.ForwardedFromClusterHeaderName) != ""
+}
+
+// forwardedReadContext bounds the read forwarded to the active cluster, so that the current cluster has
+// time left to serve the read when the active cluster cannot be reached
+func (handler *DCRedirectionHandlerImpl) forwardedReadContext(
+	ctx context.Context,
+	domainName string,
+) (context.Context, context.CancelFunc) {
+
+	timeout := handler.config.StandbyReadForwardingTimeout(domainName)
+	if deadline, ok := ctx.Deadline(); ok {
+		if remaining := deadline.Sub(handler.timeSource.Now()) / 2; remaining < timeout {
+			timeout = remaining
+		}
+	}
+	return context.WithTimeout(ctx, timeout)
+}
+
+func (handler *DCRedirectionHandlerImpl) forwardedReadOption() yarpc.CallOption {
+	return yarpc.WithHeader(common.ForwardedFromClusterHeaderName, handler.currentClusterName)
+}
+
 func (handler *DCRedirectionHandlerImpl) beforeCall(
 	scope int,
 ) (metrics.Scope, time.Time) {
@@ -23,6 +23,7 @@ package frontend
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/mock"
 
@@ -31,6 +32,8 @@ import (
 	"github.com/uber/cadence/.gen/go/shared"
 	"github.com/uber/cadence/client"
 	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/audit"
+	"github.com/uber/cadence/common/authorization"
 	"github.com/uber/cadence/common/cache"
 	"github.com/uber/cadence/common/cluster"
 	"github.com/uber/cadence/common/log"
@@ -98,7 +101,8 @@ func (s *dcRedirectionHandlerSuite) SetupTest() {
 	s.mockClientBean.On("GetRemoteFrontendClient", s.alternativeClusterName).Return(s.mockRemoteFrontendClient)
 	s.service = service.NewTestService(s.mockClusterMetadata, nil, metricsClient, s.mockClientBean)
 
-	frontendHandler := NewWorkflowHandler(s.service, s.config, s.mockMetadataMgr, nil, nil, nil, nil, nil, nil)
+	frontendHandler := NewWorkflowHandler(s.service, s.config, s.mockMetadataMgr, nil, nil, nil, nil, nil, nil, nil,
+		authorization.NewNopAuthenticator(), authorization.NewNopAuthorizer(), audit.NewNopSink(), nil)
 	frontendHandler.metricsClient = metricsClient
 	frontendHandler.startWG.Done()
 
@@ -158,11 +162,28 @@ func (s *dcRedirectionHandlerSuite) TestDescribeWorkflowExecution() {
 	s.mockFrontendHandler.On(apiName, mock.Anything, req).Return(&shared.DescribeWorkflowExecutionResponse{}, nil).Once()
 	err = callFn(s.currentClusterName)
 	s.Nil(err)
-	s.mockRemoteFrontendClient.On(apiName, mock.Anything, req).Return(&shared.DescribeWorkflowExecutionResponse{}, nil).Once()
+	s.mockRemoteFrontendClient.On(apiName, mock.Anything, req, mock.Anything).Return(&shared.DescribeWorkflowExecutionResponse{}, nil).Once()
 	err = callFn(s.alternativeClusterName)
 	s.Nil(err)
 }
 
+func (s *dcRedirectionHandlerSuite) TestForwardedReadContext() {
+	ctx, cancel := s.handler.forwardedReadContext(context.Background(), s.domainName)
+	defer cancel()
+	deadline, ok := ctx.Deadline()
+	s.True(ok)
+	s.WithinDuration(time.Now().Add(5*time.Second), deadline, time.Second)
+
+	// the forwarded read leaves half of the deadline of the call to the local fallback
+	parentCtx, parentCancel := context.WithTimeout(context.Background(), 4*time.Second)
+	defer parentCancel()
+	ctx, cancel = s.handler.forwardedReadContext(parentCtx, s.domainName)
+	defer cancel()
+	deadline, ok = ctx.Deadline()
+	s.True(ok)
+	s.WithinDuration(time.Now().Add(2*time.Second), deadline, time.Second)
+}
+
 func (s *dcRedirectionHandlerSuite) TestGetWorkflowExecutionHistory() {
 	apiName := "GetWorkflowExecutionHistory"
 
@@ -365,7 +386,7 @@ func (s *dcRedirectionHandlerSuite) TestQueryWorkflow() {
 	s.mockFrontendHandler.On(apiName, mock.Anything, req).Return(&shared.QueryWorkflowResponse{}, nil).Once()
 	err = callFn(s.currentClusterName)
 	s.Nil(err)
-	s.mockRemoteFrontendClient.On(apiName, mock.Anything, req).Return(&shared.QueryWorkflowResponse{}, nil).Once()
+	s.mockRemoteFrontendClient.On(apiName, mock.Anything, req, mock.Anything).Return(&shared.QueryWorkflowResponse{}, nil).Once()
 	err = callFn(s.alternativeClusterName)
 	s.Nil(err)
 }
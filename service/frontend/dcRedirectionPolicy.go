@@ -41,7 +41,11 @@ const (
 	// 4. RequestCancelWorkflowExecution
 	// 5. TerminateWorkflowExecution
 	// please also reference selectedAPIsForwardingRedirectionPolicyWhitelistedAPIs
+	// and, when standby read forwarding is enabled, selectedAPIsForwardingRedirectionPolicyStandbyReadAPIs
 	DCRedirectionPolicySelectedAPIsForwarding = "selected-apis-forwarding"
+	// DCRedirectionPolicyAllowedAPIsForwarding means forwarding the APIs configured in the policy based on domain,
+	// the APIs must be part of allowedAPIsForwardingRedirectionPolicyForwardableAPIs
+	DCRedirectionPolicyAllowedAPIsForwarding = "allowed-apis-forwarding"
 )
 
 type (
@@ -62,6 +66,7 @@ type (
 		currentClusterName string
 		config             *Config
 		domainCache        cache.DomainCache
+		allowedAPIs        map[string]struct{}
 	}
 )
 
@@ -72,6 +77,36 @@ var selectedAPIsForwardingRedirectionPolicyWhitelistedAPIs = map[string]struct{}
 	"SignalWorkflowExecution":          {},
 	"RequestCancelWorkflowExecution":   {},
 	"TerminateWorkflowExecution":       {},
+	"UpdateWorkflowExecution":          {},
+}
+
+// allowedAPIsForwardingRedirectionPolicyForwardableAPIs contains the APIs which can be configured to be redirected,
+// those failing with a domain not active error when the domain is active in another cluster
+var allowedAPIsForwardingRedirectionPolicyForwardableAPIs = map[string]struct{}{
+	"StartWorkflowExecution":           {},
+	"SignalWithStartWorkflowExecution": {},
+	"SignalWorkflowExecution":          {},
+	"RequestCancelWorkflowExecution":   {},
+	"TerminateWorkflowExecution":       {},
+	"UpdateWorkflowExecution":          {},
+	"ResetWorkflowExecution":           {},
+	"RecordActivityTaskHeartbeat":      {},
+	"RecordActivityTaskHeartbeatByID":  {},
+	"RespondActivityTaskCanceled":      {},
+	"RespondActivityTaskCanceledByID":  {},
+	"RespondActivityTaskCompleted":     {},
+	"RespondActivityTaskCompletedByID": {},
+	"RespondActivityTaskFailed":        {},
+	"RespondActivityTaskFailedByID":    {},
+	"RespondDecisionTaskCompleted":     {},
+	"RespondDecisionTaskFailed":        {},
+}
+
+// selectedAPIsForwardingRedirectionPolicyStandbyReadAPIs contains a list of read APIs which are served by the
+// active cluster when the domain is not active in the current cluster, so they do not see the replication lag
+var selectedAPIsForwardingRedirectionPolicyStandbyReadAPIs = map[string]struct{}{
+	"DescribeWorkflowExecution": {},
+	"QueryWorkflow":             {},
 }
 
 // RedirectionPolicyGenerator generate corresponding redirection policy
@@ -86,6 +121,13 @@ func RedirectionPolicyGenerator(clusterMetadata cluster.Metadata, config *Config
 	case DCRedirectionPolicySelectedAPIsForwarding:
 		currentClusterName := clusterMetadata.GetCurrentClusterName()
 		return NewSelectedAPIsForwardingPolicy(currentClusterName, config, domainCache)
+	case DCRedirectionPolicyAllowedAPIsForwarding:
+		currentClusterName := clusterMetadata.GetCurrentClusterName()
+		forwardingPolicy, err := NewAllowedAPIsForwardingPolicy(currentClusterName, config, domainCache, policy.AllowedAPIs)
+		if err != nil {
+			panic(err.Error())
+		}
+		return forwardingPolicy
 	default:
 		panic(fmt.Sprintf("Unknown DC redirection policy %v", policy.Policy))
 	}
@@ -114,7 +156,33 @@ func NewSelectedAPIsForwardingPolicy(currentClusterName string, config *Config,
 		currentClusterName: currentClusterName,
 		config:             config,
 		domainCache:        domainCache,
+		allowedAPIs:        selectedAPIsForwardingRedirectionPolicyWhitelistedAPIs,
+	}
+}
+
+// NewAllowedAPIsForwardingPolicy creates a forwarding policy for the given APIs based on domain
+func NewAllowedAPIsForwardingPolicy(
+	currentClusterName string,
+	config *Config,
+	domainCache cache.DomainCache,
+	allowedAPIs []string,
+) (*SelectedAPIsForwardingRedirectionPolicy, error) {
+	if len(allowedAPIs) == 0 {
+		return nil, fmt.Errorf("DC redirection policy %v requires allowed APIs", DCRedirectionPolicyAllowedAPIsForwarding)
 	}
+	apis := make(map[string]struct{}, len(allowedAPIs))
+	for _, apiName := range allowedAPIs {
+		if _, ok := allowedAPIsForwardingRedirectionPolicyForwardableAPIs[apiName]; !ok {
+			return nil, fmt.Errorf("DC redirection policy %v cannot forward API %v", DCRedirectionPolicyAllowedAPIsForwarding, apiName)
+		}
+		apis[apiName] = struct{}{}
+	}
+	return &SelectedAPIsForwardingRedirectionPolicy{
+		currentClusterName: currentClusterName,
+		config:             config,
+		domainCache:        domainCache,
+		allowedAPIs:        apis,
+	}, nil
 }
 
 // WithDomainIDRedirect redirect the API call based on domain ID
@@ -136,6 +204,15 @@ func (policy *SelectedAPIsForwardingRedirectionPolicy) WithDomainNameRedirect(do
 }
 
 func (policy *SelectedAPIsForwardingRedirectionPolicy) withRedirect(domainEntry *cache.DomainCacheEntry, apiName string, call func(string) error) error {
+	if targetDC, ok := policy.getStandbyReadTargetCluster(domainEntry, apiName); ok {
+		err := call(targetDC)
+		if err == nil || !policy.isStandbyReadFallbackError(err) {
+			return err
+		}
+		// the active cluster could not serve the read, the current cluster serves it from the replicated state
+		return call(policy.currentClusterName)
+	}
+
 	targetDC, enableDomainNotActiveForwarding := policy.getTargetClusterAndIsDomainNotActiveAutoForwarding(domainEntry, apiName)
 
 	err := call(targetDC)
@@ -170,7 +247,7 @@ func (policy *SelectedAPIsForwardingRedirectionPolicy) getTargetClusterAndIsDoma
 		return policy.currentClusterName, false
 	}
 
-	_, ok := selectedAPIsForwardingRedirectionPolicyWhitelistedAPIs[apiName]
+	_, ok := policy.allowedAPIs[apiName]
 	if !ok {
 		// do not do dc redirection if API is not whitelisted
 		return policy.currentClusterName, false
@@ -178,3 +255,31 @@ func (policy *SelectedAPIsForwardingRedirectionPolicy) getTargetClusterAndIsDoma
 
 	return domainEntry.GetReplicationConfig().ActiveClusterName, true
 }
+
+func (policy *SelectedAPIsForwardingRedirectionPolicy) getStandbyReadTargetCluster(domainEntry *cache.DomainCacheEntry, apiName string) (string, bool) {
+	if _, ok := selectedAPIsForwardingRedirectionPolicyStandbyReadAPIs[apiName]; !ok {
+		return "", false
+	}
+	if !domainEntry.IsGlobalDomain() || len(domainEntry.GetReplicationConfig().Clusters) == 1 {
+		return "", false
+	}
+	activeCluster := domainEntry.GetReplicationConfig().ActiveClusterName
+	if activeCluster == policy.currentClusterName {
+		return "", false
+	}
+	if !policy.config.EnableStandbyReadForwarding(domainEntry.GetInfo().Name) {
+		return "", false
+	}
+	return activeCluster, true
+}
+
+// isStandbyReadFallbackError returns whether the read forwarded to the active cluster should be served by the
+// current cluster instead, which is the case unless the active cluster answered the request itself
+func (policy *SelectedAPIsForwardingRedirectionPolicy) isStandbyReadFallbackError(err error) bool {
+	switch err.(type) {
+	case *shared.BadRequestError, *shared.EntityNotExistsError, *shared.QueryFailedError:
+		return false
+	default:
+		return true
+	}
+}
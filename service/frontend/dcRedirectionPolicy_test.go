@@ -330,6 +330,113 @@ func (s *selectedAPIsForwardingRedirectionPolicySuite) TestGetTargetDataCenter_G
 	s.Equal(2*len(selectedAPIsForwardingRedirectionPolicyWhitelistedAPIs), alternativeClustercallCount)
 }
 
+func (s *selectedAPIsForwardingRedirectionPolicySuite) TestWithDomainRedirect_GlobalDomain_AllowedAPIsForwarding() {
+	s.setupGlobalDomainWithTwoReplicationCluster(true, false)
+	policy, err := NewAllowedAPIsForwardingPolicy(
+		s.currentClusterName,
+		s.mockConfig,
+		s.policy.domainCache,
+		[]string{"SignalWorkflowExecution", "RespondActivityTaskCompleted"},
+	)
+	s.Nil(err)
+
+	targetClusters := make(map[string]string)
+	for _, apiName := range []string{"SignalWorkflowExecution", "RespondActivityTaskCompleted", "StartWorkflowExecution"} {
+		err = policy.WithDomainNameRedirect(s.domainName, apiName, func(targetCluster string) error {
+			targetClusters[apiName] = targetCluster
+			return nil
+		})
+		s.Nil(err)
+	}
+
+	s.Equal(map[string]string{
+		"SignalWorkflowExecution":      s.alternativeClusterName,
+		"RespondActivityTaskCompleted": s.alternativeClusterName,
+		"StartWorkflowExecution":       s.currentClusterName,
+	}, targetClusters)
+}
+
+func (s *selectedAPIsForwardingRedirectionPolicySuite) TestNewAllowedAPIsForwardingPolicy_InvalidAPIs() {
+	_, err := NewAllowedAPIsForwardingPolicy(s.currentClusterName, s.mockConfig, s.policy.domainCache, nil)
+	s.Error(err)
+
+	_, err = NewAllowedAPIsForwardingPolicy(s.currentClusterName, s.mockConfig, s.policy.domainCache, []string{"PollForDecisionTask"})
+	s.Error(err)
+}
+
+func (s *selectedAPIsForwardingRedirectionPolicySuite) TestWithDomainRedirect_GlobalDomain_StandbyReadForwarding() {
+	s.setupGlobalDomainWithTwoReplicationCluster(false, false)
+	s.mockConfig.EnableStandbyReadForwarding = dynamicconfig.GetBoolPropertyFnFilteredByDomain(true)
+
+	callCount := 0
+	callFn := func(targetCluster string) error {
+		callCount++
+		s.Equal(s.alternativeClusterName, targetCluster)
+		return nil
+	}
+
+	for apiName := range selectedAPIsForwardingRedirectionPolicyStandbyReadAPIs {
+		err := s.policy.WithDomainIDRedirect(s.domainID, apiName, callFn)
+		s.Nil(err)
+
+		err = s.policy.WithDomainNameRedirect(s.domainName, apiName, callFn)
+		s.Nil(err)
+	}
+
+	s.Equal(2*len(selectedAPIsForwardingRedirectionPolicyStandbyReadAPIs), callCount)
+}
+
+func (s *selectedAPIsForwardingRedirectionPolicySuite) TestWithDomainRedirect_GlobalDomain_StandbyReadForwarding_Fallback() {
+	s.setupGlobalDomainWithTwoReplicationCluster(false, false)
+	s.mockConfig.EnableStandbyReadForwarding = dynamicconfig.GetBoolPropertyFnFilteredByDomain(true)
+
+	currentClustercallCount := 0
+	alternativeClustercallCount := 0
+	var alternativeClusterErr error
+	callFn := func(targetCluster string) error {
+		switch targetCluster {
+		case s.currentClusterName:
+			currentClustercallCount++
+			return nil
+		case s.alternativeClusterName:
+			alternativeClustercallCount++
+			return alternativeClusterErr
+		default:
+			panic(fmt.Sprintf("unknown cluster name %v", targetCluster))
+		}
+	}
+
+	alternativeClusterErr = &shared.InternalServiceError{Message: "cluster unavailable"}
+	s.Nil(s.policy.WithDomainNameRedirect(s.domainName, "DescribeWorkflowExecution", callFn))
+	s.Equal(1, alternativeClustercallCount)
+	s.Equal(1, currentClustercallCount)
+
+	// errors answered by the active cluster are returned as is
+	alternativeClusterErr = &shared.EntityNotExistsError{Message: "workflow not found"}
+	s.Equal(alternativeClusterErr, s.policy.WithDomainNameRedirect(s.domainName, "DescribeWorkflowExecution", callFn))
+	s.Equal(2, alternativeClustercallCount)
+	s.Equal(1, currentClustercallCount)
+}
+
+func (s *selectedAPIsForwardingRedirectionPolicySuite) TestWithDomainRedirect_GlobalDomain_StandbyReadForwarding_ActiveCluster() {
+	s.setupGlobalDomainWithTwoReplicationCluster(false, true)
+	s.mockConfig.EnableStandbyReadForwarding = dynamicconfig.GetBoolPropertyFnFilteredByDomain(true)
+
+	callCount := 0
+	callFn := func(targetCluster string) error {
+		callCount++
+		s.Equal(s.currentClusterName, targetCluster)
+		return nil
+	}
+
+	for apiName := range selectedAPIsForwardingRedirectionPolicyStandbyReadAPIs {
+		err := s.policy.WithDomainNameRedirect(s.domainName, apiName, callFn)
+		s.Nil(err)
+	}
+
+	s.Equal(len(selectedAPIsForwardingRedirectionPolicyStandbyReadAPIs), callCount)
+}
+
 func (s *selectedAPIsForwardingRedirectionPolicySuite) setupLocalDomain() {
 	domainRecord := &persistence.GetDomainResponse{
 		Info:   &persistence.DomainInfo{ID: s.domainID, Name: s.domainName},
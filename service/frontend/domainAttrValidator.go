@@ -24,6 +24,7 @@ import (
 	"fmt"
 
 	"github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common/blobstore/blob"
 	"github.com/uber/cadence/common/cluster"
 	"github.com/uber/cadence/common/persistence"
 )
@@ -32,17 +33,20 @@ type (
 	domainAttrValidatorImpl struct {
 		clusterMetadata  cluster.Metadata
 		minRetentionDays int32
+		keyProvider      blob.KeyProvider
 	}
 )
 
 func newDomainAttrValidator(
 	clusterMetadata cluster.Metadata,
 	minRetentionDays int32,
+	keyProvider blob.KeyProvider,
 ) *domainAttrValidatorImpl {
 
 	return &domainAttrValidatorImpl{
 		clusterMetadata:  clusterMetadata,
 		minRetentionDays: minRetentionDays,
+		keyProvider:      keyProvider,
 	}
 }
 
@@ -52,6 +56,26 @@ func (d *domainAttrValidatorImpl) validateDomainConfig(
 	if config.Retention < int32(d.minRetentionDays) {
 		return errInvalidRetentionPeriod
 	}
+	if config.RetentionRunCount < 0 {
+		return errInvalidRetentionRunCount
+	}
+	if !isValidTimeoutPolicyLimit(config.TimeoutPolicy.DefaultExecutionTimeoutSeconds, config.TimeoutPolicy.MaxExecutionTimeoutSeconds) ||
+		!isValidTimeoutPolicyLimit(config.TimeoutPolicy.DefaultTaskTimeoutSeconds, config.TimeoutPolicy.MaxTaskTimeoutSeconds) ||
+		!isValidTimeoutPolicyLimit(config.TimeoutPolicy.DefaultActivityTimeoutSeconds, config.TimeoutPolicy.MaxActivityTimeoutSeconds) {
+		return errInvalidTimeoutPolicy
+	}
+	if config.EncryptionKeyAlias != "" {
+		if !blob.IsValidKeyAlias(config.EncryptionKeyAlias) {
+			return errInvalidEncryptionKeyAlias
+		}
+		// fail closed, the histories of the domain would otherwise be archived in plaintext
+		if d.keyProvider == nil {
+			return errEncryptionNotConfigured
+		}
+		if _, _, err := d.keyProvider.GenerateDataKey(config.EncryptionKeyAlias); err != nil {
+			return &shared.BadRequestError{Message: fmt.Sprintf("Encryption key alias %v cannot be used: %v", config.EncryptionKeyAlias, err)}
+		}
+	}
 	return nil
 }
 
@@ -112,6 +136,40 @@ func (d *domainAttrValidatorImpl) validateDomainReplicationConfigForGlobalDomain
 	return nil
 }
 
+// validateDomainResidency checks the allowed clusters of the residency are known, and the domain is neither active
+// in nor replicated to a cluster the residency does not allow
+func (d *domainAttrValidatorImpl) validateDomainResidency(
+	residency persistence.DomainResidency,
+	replicationConfig *persistence.DomainReplicationConfig,
+) error {
+
+	allClusters := d.clusterMetadata.GetAllClusterInfo()
+	for _, clusterName := range residency.AllowedClusters {
+		if _, ok := allClusters[clusterName]; !ok {
+			return &shared.BadRequestError{Message: fmt.Sprintf(
+				"Invalid residency allowed cluster name: %v",
+				clusterName,
+			)}
+		}
+	}
+
+	if !residency.IsClusterAllowed(replicationConfig.ActiveClusterName) {
+		return &shared.BadRequestError{Message: fmt.Sprintf(
+			"Active cluster %v is not allowed by the domain residency",
+			replicationConfig.ActiveClusterName,
+		)}
+	}
+	for _, clusterConfig := range replicationConfig.Clusters {
+		if !residency.IsClusterAllowed(clusterConfig.ClusterName) {
+			return &shared.BadRequestError{Message: fmt.Sprintf(
+				"Cluster %v is not allowed by the domain residency",
+				clusterConfig.ClusterName,
+			)}
+		}
+	}
+	return nil
+}
+
 func (d *domainAttrValidatorImpl) validateDomainReplicationConfigClustersDoesNotChange(
 	clustersOld []*persistence.ClusterReplicationConfig,
 	clustersNew []*persistence.ClusterReplicationConfig,
@@ -150,3 +208,12 @@ func (d *domainAttrValidatorImpl) validateClusterName(
 	}
 	return nil
 }
+
+// isValidTimeoutPolicyLimit returns true if the default and the maximum of a timeout are not negative, and the
+// default is not above the maximum when both are set
+func isValidTimeoutPolicyLimit(defaultTimeout int32, maxTimeout int32) bool {
+	if defaultTimeout < 0 || maxTimeout < 0 {
+		return false
+	}
+	return maxTimeout == 0 || defaultTimeout <= maxTimeout
+}
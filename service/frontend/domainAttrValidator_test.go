@@ -21,10 +21,13 @@
 package frontend
 
 import (
+	"encoding/base64"
+	"strings"
 	"testing"
 
 	"github.com/uber/cadence/.gen/go/shared"
 
+	"github.com/uber/cadence/common/blobstore/blob"
 	"github.com/uber/cadence/common/cluster"
 
 	"github.com/uber/cadence/common/persistence"
@@ -57,7 +60,11 @@ func (s *domainAttrValidatorSuite) TearDownSuite() {
 func (s *domainAttrValidatorSuite) SetupTest() {
 	s.minRetentionDays = 1
 	s.mockClusterMetadata = &mocks.ClusterMetadata{}
-	s.validator = newDomainAttrValidator(s.mockClusterMetadata, int32(s.minRetentionDays))
+	keyProvider, err := blob.NewStaticKeyProvider(map[string]string{
+		"alias/tenant-a": base64.StdEncoding.EncodeToString([]byte(strings.Repeat("k", 32))),
+	})
+	s.NoError(err)
+	s.validator = newDomainAttrValidator(s.mockClusterMetadata, int32(s.minRetentionDays), keyProvider)
 }
 
 func (s *domainAttrValidatorSuite) TearDownTest() {
@@ -89,6 +96,103 @@ func (s *domainAttrValidatorSuite) TestValidateConfigRetentionPeriod() {
 	}
 }
 
+func (s *domainAttrValidatorSuite) TestValidateConfigRetentionRunCount() {
+	testCases := []struct {
+		retentionRunCount int32
+		expectedErr       error
+	}{
+		{
+			retentionRunCount: 0,
+			expectedErr:       nil,
+		},
+		{
+			retentionRunCount: 5,
+			expectedErr:       nil,
+		},
+		{
+			retentionRunCount: -1,
+			expectedErr:       errInvalidRetentionRunCount,
+		},
+	}
+	for _, tc := range testCases {
+		actualErr := s.validator.validateDomainConfig(
+			&persistence.DomainConfig{Retention: 10, RetentionRunCount: tc.retentionRunCount},
+		)
+		s.Equal(tc.expectedErr, actualErr)
+	}
+}
+
+func (s *domainAttrValidatorSuite) TestValidateConfigTimeoutPolicy() {
+	testCases := []struct {
+		timeoutPolicy persistence.DomainTimeoutPolicy
+		expectedErr   error
+	}{
+		{
+			timeoutPolicy: persistence.DomainTimeoutPolicy{},
+			expectedErr:   nil,
+		},
+		{
+			timeoutPolicy: persistence.DomainTimeoutPolicy{
+				DefaultExecutionTimeoutSeconds: 60,
+				MaxExecutionTimeoutSeconds:     3600,
+				DefaultTaskTimeoutSeconds:      10,
+				MaxActivityTimeoutSeconds:      600,
+			},
+			expectedErr: nil,
+		},
+		{
+			timeoutPolicy: persistence.DomainTimeoutPolicy{MaxTaskTimeoutSeconds: -1},
+			expectedErr:   errInvalidTimeoutPolicy,
+		},
+		{
+			timeoutPolicy: persistence.DomainTimeoutPolicy{DefaultActivityTimeoutSeconds: 120, MaxActivityTimeoutSeconds: 60},
+			expectedErr:   errInvalidTimeoutPolicy,
+		},
+	}
+	for _, tc := range testCases {
+		actualErr := s.validator.validateDomainConfig(
+			&persistence.DomainConfig{Retention: 10, TimeoutPolicy: tc.timeoutPolicy},
+		)
+		s.Equal(tc.expectedErr, actualErr)
+	}
+}
+
+func (s *domainAttrValidatorSuite) TestValidateConfigEncryptionKeyAlias() {
+	testCases := []struct {
+		encryptionKeyAlias string
+		expectedErr        error
+	}{
+		{
+			encryptionKeyAlias: "",
+			expectedErr:        nil,
+		},
+		{
+			encryptionKeyAlias: "alias/tenant-a",
+			expectedErr:        nil,
+		},
+		{
+			encryptionKeyAlias: "arn:aws:kms:us-east-1:123456789012:alias/tenant-a",
+			expectedErr:        errInvalidEncryptionKeyAlias,
+		},
+	}
+	for _, tc := range testCases {
+		actualErr := s.validator.validateDomainConfig(
+			&persistence.DomainConfig{Retention: 10, EncryptionKeyAlias: tc.encryptionKeyAlias},
+		)
+		s.Equal(tc.expectedErr, actualErr)
+	}
+
+	// the alias must be known to the key provider
+	err := s.validator.validateDomainConfig(&persistence.DomainConfig{Retention: 10, EncryptionKeyAlias: "alias/tenant-b"})
+	s.IsType(&shared.BadRequestError{}, err)
+
+	// an alias cannot be set when no key provider is configured
+	validator := newDomainAttrValidator(s.mockClusterMetadata, int32(s.minRetentionDays), nil)
+	err = validator.validateDomainConfig(&persistence.DomainConfig{Retention: 10, EncryptionKeyAlias: "alias/tenant-a"})
+	s.Equal(errEncryptionNotConfigured, err)
+	s.NoError(validator.validateDomainConfig(&persistence.DomainConfig{Retention: 10}))
+}
+
 func (s *domainAttrValidatorSuite) TestClusterName() {
 	s.mockClusterMetadata.On("GetAllClusterInfo").Return(
 		cluster.TestAllClusterInfo,
@@ -206,6 +310,51 @@ func (s *domainAttrValidatorSuite) TestValidateDomainReplicationConfigForGlobalD
 	s.NoError(err)
 }
 
+func (s *domainAttrValidatorSuite) TestValidateDomainResidency() {
+	s.mockClusterMetadata.On("GetAllClusterInfo").Return(
+		cluster.TestAllClusterInfo,
+	)
+	replicationConfig := &persistence.DomainReplicationConfig{
+		ActiveClusterName: cluster.TestCurrentClusterName,
+		Clusters: []*persistence.ClusterReplicationConfig{
+			{ClusterName: cluster.TestCurrentClusterName},
+			{ClusterName: cluster.TestAlternativeClusterName},
+		},
+	}
+
+	err := s.validator.validateDomainResidency(persistence.DomainResidency{}, replicationConfig)
+	s.NoError(err)
+
+	err = s.validator.validateDomainResidency(
+		persistence.DomainResidency{Tag: "eu", AllowedClusters: cluster.TestAllClusterNames},
+		replicationConfig,
+	)
+	s.NoError(err)
+
+	err = s.validator.validateDomainResidency(
+		persistence.DomainResidency{Tag: "eu", AllowedClusters: []string{"some random foo bar"}},
+		replicationConfig,
+	)
+	s.IsType(&shared.BadRequestError{}, err)
+
+	err = s.validator.validateDomainResidency(
+		persistence.DomainResidency{Tag: "eu", AllowedClusters: []string{cluster.TestCurrentClusterName}},
+		replicationConfig,
+	)
+	s.IsType(&shared.BadRequestError{}, err)
+
+	err = s.validator.validateDomainResidency(
+		persistence.DomainResidency{Tag: "eu", AllowedClusters: []string{cluster.TestAlternativeClusterName}},
+		&persistence.DomainReplicationConfig{
+			ActiveClusterName: cluster.TestCurrentClusterName,
+			Clusters: []*persistence.ClusterReplicationConfig{
+				{ClusterName: cluster.TestAlternativeClusterName},
+			},
+		},
+	)
+	s.IsType(&shared.BadRequestError{}, err)
+}
+
 func (s *domainAttrValidatorSuite) TestValidateDomainReplicationConfigClustersDoesNotChange() {
 	err := s.validator.validateDomainReplicationConfigClustersDoesNotChange(
 		[]*persistence.ClusterReplicationConfig{
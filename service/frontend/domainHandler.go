@@ -31,6 +31,7 @@ import (
 	"github.com/uber/cadence/.gen/go/shared"
 	"github.com/uber/cadence/common"
 	"github.com/uber/cadence/common/blobstore"
+	"github.com/uber/cadence/common/blobstore/blob"
 	"github.com/uber/cadence/common/cluster"
 	"github.com/uber/cadence/common/log"
 	"github.com/uber/cadence/common/log/tag"
@@ -55,7 +56,8 @@ func newDomainHandler(config *Config,
 	metadataMgr persistence.MetadataManager,
 	clusterMetadata cluster.Metadata,
 	blobstoreClient blobstore.Client,
-	domainReplicator DomainReplicator) *domainHandlerImpl {
+	domainReplicator DomainReplicator,
+	keyProvider blob.KeyProvider) *domainHandlerImpl {
 
 	return &domainHandlerImpl{
 		config:              config,
@@ -64,7 +66,7 @@ func newDomainHandler(config *Config,
 		clusterMetadata:     clusterMetadata,
 		blobstoreClient:     blobstoreClient,
 		domainReplicator:    domainReplicator,
-		domainAttrValidator: newDomainAttrValidator(clusterMetadata, int32(config.MinRetentionDays())),
+		domainAttrValidator: newDomainAttrValidator(clusterMetadata, int32(config.MinRetentionDays()), keyProvider),
 	}
 }
 
@@ -149,11 +151,15 @@ func (d *domainHandlerImpl) registerDomain(
 		Data:        registerRequest.Data,
 	}
 	config := &persistence.DomainConfig{
-		Retention:      registerRequest.GetWorkflowExecutionRetentionPeriodInDays(),
-		EmitMetric:     registerRequest.GetEmitMetric(),
-		ArchivalBucket: nextArchivalState.bucket,
-		ArchivalStatus: nextArchivalState.status,
-		BadBinaries:    shared.BadBinaries{Binaries: map[string]*shared.BadBinaryInfo{}},
+		Retention:          registerRequest.GetWorkflowExecutionRetentionPeriodInDays(),
+		EmitMetric:         registerRequest.GetEmitMetric(),
+		ArchivalBucket:     nextArchivalState.bucket,
+		ArchivalStatus:     nextArchivalState.status,
+		BadBinaries:        shared.BadBinaries{Binaries: map[string]*shared.BadBinaryInfo{}},
+		RetentionRunCount:  registerRequest.GetWorkflowExecutionRetentionRunCount(),
+		TimeoutPolicy:      persistence.MergeDomainTimeoutPolicy(persistence.DomainTimeoutPolicy{}, registerRequest.TimeoutPolicy),
+		EncryptionKeyAlias: registerRequest.GetEncryptionKeyAlias(),
+		Residency:          persistence.MergeDomainResidency(persistence.DomainResidency{}, registerRequest.Residency),
 	}
 	replicationConfig := &persistence.DomainReplicationConfig{
 		ActiveClusterName: activeClusterName,
@@ -177,6 +183,12 @@ func (d *domainHandlerImpl) registerDomain(
 			return err
 		}
 	}
+	if err := d.domainAttrValidator.validateDomainResidency(
+		config.Residency,
+		replicationConfig,
+	); err != nil {
+		return err
+	}
 
 	failoverVersion := common.EmptyVersion
 	if registerRequest.GetIsGlobalDomain() {
@@ -383,6 +395,24 @@ func (d *domainHandlerImpl) updateDomain(
 			configurationChanged = true
 			config.Retention = updatedConfig.GetWorkflowExecutionRetentionPeriodInDays()
 		}
+		if updatedConfig.WorkflowExecutionRetentionRunCount != nil {
+			configurationChanged = true
+			config.RetentionRunCount = updatedConfig.GetWorkflowExecutionRetentionRunCount()
+		}
+		if updatedConfig.TimeoutPolicy != nil {
+			configurationChanged = true
+			// only the values set on the update are changed
+			config.TimeoutPolicy = persistence.MergeDomainTimeoutPolicy(config.TimeoutPolicy, updatedConfig.TimeoutPolicy)
+		}
+		if updatedConfig.EncryptionKeyAlias != nil {
+			configurationChanged = true
+			// blobs already written keep the alias they were encrypted with, so rotating the alias is safe
+			config.EncryptionKeyAlias = updatedConfig.GetEncryptionKeyAlias()
+		}
+		if updatedConfig.Residency != nil {
+			configurationChanged = true
+			config.Residency = persistence.MergeDomainResidency(config.Residency, updatedConfig.Residency)
+		}
 		if archivalConfigChanged {
 			configurationChanged = true
 			config.ArchivalBucket = nextArchivalState.bucket
@@ -454,6 +484,12 @@ func (d *domainHandlerImpl) updateDomain(
 			return nil, err
 		}
 	}
+	if err := d.domainAttrValidator.validateDomainResidency(
+		config.Residency,
+		replicationConfig,
+	); err != nil {
+		return nil, err
+	}
 
 	if configurationChanged && activeClusterChanged && isGlobalDomain {
 		return nil, errCannotDoDomainFailoverAndUpdate
@@ -615,6 +651,10 @@ func (d *domainHandlerImpl) createResponse(
 		ArchivalStatus:                         common.ArchivalStatusPtr(config.ArchivalStatus),
 		ArchivalBucketName:                     common.StringPtr(config.ArchivalBucket),
 		BadBinaries:                            &config.BadBinaries,
+		WorkflowExecutionRetentionRunCount:     common.Int32Ptr(config.RetentionRunCount),
+		TimeoutPolicy:                          config.TimeoutPolicy.ToThrift(),
+		EncryptionKeyAlias:                     common.StringPtr(config.EncryptionKeyAlias),
+		Residency:                              config.Residency.ToThrift(),
 	}
 
 	clusters := []*shared.ClusterReplicationConfiguration{}
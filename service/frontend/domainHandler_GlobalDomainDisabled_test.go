@@ -85,7 +85,7 @@ func (s *domainHandlerGlobalDomainDisabledSuite) SetupTest() {
 	s.mockDomainReplicator = NewDomainReplicator(s.mockProducer, logger)
 
 	s.handler = newDomainHandler(s.config, logger, s.metadataMgr, s.ClusterMetadata,
-		s.mockBlobstoreClient, s.mockDomainReplicator)
+		s.mockBlobstoreClient, s.mockDomainReplicator, nil)
 }
 
 func (s *domainHandlerGlobalDomainDisabledSuite) TearDownTest() {
@@ -189,6 +189,10 @@ func (s *domainHandlerGlobalDomainDisabledSuite) TestRegisterGetDomain_AllDefaul
 		ArchivalBucketName:                     common.StringPtr(""),
 		ArchivalStatus:                         shared.ArchivalStatusDisabled.Ptr(),
 		BadBinaries:                            &shared.BadBinaries{Binaries: map[string]*shared.BadBinaryInfo{}},
+		WorkflowExecutionRetentionRunCount:     common.Int32Ptr(0),
+		TimeoutPolicy:                          persistence.DomainTimeoutPolicy{}.ToThrift(),
+		EncryptionKeyAlias:                     common.StringPtr(""),
+		Residency:                              persistence.DomainResidency{}.ToThrift(),
 	}, resp.Configuration)
 	s.Equal(&shared.DomainReplicationConfiguration{
 		ActiveClusterName: common.StringPtr(s.ClusterMetadata.GetCurrentClusterName()),
@@ -254,6 +258,10 @@ func (s *domainHandlerGlobalDomainDisabledSuite) TestRegisterGetDomain_NoDefault
 		ArchivalBucketName:                     common.StringPtr(""),
 		ArchivalStatus:                         shared.ArchivalStatusDisabled.Ptr(),
 		BadBinaries:                            &shared.BadBinaries{Binaries: map[string]*shared.BadBinaryInfo{}},
+		WorkflowExecutionRetentionRunCount:     common.Int32Ptr(0),
+		TimeoutPolicy:                          persistence.DomainTimeoutPolicy{}.ToThrift(),
+		EncryptionKeyAlias:                     common.StringPtr(""),
+		Residency:                              persistence.DomainResidency{}.ToThrift(),
 	}, resp.Configuration)
 	s.Equal(&shared.DomainReplicationConfiguration{
 		ActiveClusterName: common.StringPtr(s.ClusterMetadata.GetCurrentClusterName()),
@@ -307,6 +315,10 @@ func (s *domainHandlerGlobalDomainDisabledSuite) TestUpdateGetDomain_NoAttrSet()
 			ArchivalBucketName:                     common.StringPtr(""),
 			ArchivalStatus:                         shared.ArchivalStatusDisabled.Ptr(),
 			BadBinaries:                            &shared.BadBinaries{Binaries: map[string]*shared.BadBinaryInfo{}},
+			WorkflowExecutionRetentionRunCount:     common.Int32Ptr(0),
+			TimeoutPolicy:                          persistence.DomainTimeoutPolicy{}.ToThrift(),
+			EncryptionKeyAlias:                     common.StringPtr(""),
+			Residency:                              persistence.DomainResidency{}.ToThrift(),
 		}, config)
 		s.Equal(&shared.DomainReplicationConfiguration{
 			ActiveClusterName: common.StringPtr(s.ClusterMetadata.GetCurrentClusterName()),
@@ -374,6 +386,10 @@ func (s *domainHandlerGlobalDomainDisabledSuite) TestUpdateGetDomain_AllAttrSet(
 			ArchivalBucketName:                     common.StringPtr(""),
 			ArchivalStatus:                         shared.ArchivalStatusDisabled.Ptr(),
 			BadBinaries:                            &shared.BadBinaries{Binaries: map[string]*shared.BadBinaryInfo{}},
+			WorkflowExecutionRetentionRunCount:     common.Int32Ptr(0),
+			TimeoutPolicy:                          persistence.DomainTimeoutPolicy{}.ToThrift(),
+			EncryptionKeyAlias:                     common.StringPtr(""),
+			Residency:                              persistence.DomainResidency{}.ToThrift(),
 		}, config)
 		s.Equal(&shared.DomainReplicationConfiguration{
 			ActiveClusterName: common.StringPtr(s.ClusterMetadata.GetCurrentClusterName()),
@@ -87,7 +87,7 @@ func (s *domainHandlerGlobalDomainEnabledMasterClusterSuite) SetupTest() {
 	s.mockDomainReplicator = NewDomainReplicator(s.mockProducer, logger)
 
 	s.handler = newDomainHandler(s.config, logger, s.metadataMgr, s.ClusterMetadata,
-		s.mockBlobstoreClient, s.mockDomainReplicator)
+		s.mockBlobstoreClient, s.mockDomainReplicator, nil)
 }
 
 func (s *domainHandlerGlobalDomainEnabledMasterClusterSuite) TearDownTest() {
@@ -163,6 +163,10 @@ func (s *domainHandlerGlobalDomainEnabledMasterClusterSuite) TestRegisterGetDoma
 		ArchivalBucketName:                     common.StringPtr(""),
 		ArchivalStatus:                         shared.ArchivalStatusDisabled.Ptr(),
 		BadBinaries:                            &shared.BadBinaries{Binaries: map[string]*shared.BadBinaryInfo{}},
+		WorkflowExecutionRetentionRunCount:     common.Int32Ptr(0),
+		TimeoutPolicy:                          persistence.DomainTimeoutPolicy{}.ToThrift(),
+		EncryptionKeyAlias:                     common.StringPtr(""),
+		Residency:                              persistence.DomainResidency{}.ToThrift(),
 	}, resp.Configuration)
 	s.Equal(&shared.DomainReplicationConfiguration{
 		ActiveClusterName: common.StringPtr(s.ClusterMetadata.GetCurrentClusterName()),
@@ -228,6 +232,10 @@ func (s *domainHandlerGlobalDomainEnabledMasterClusterSuite) TestRegisterGetDoma
 		ArchivalBucketName:                     common.StringPtr(""),
 		ArchivalStatus:                         shared.ArchivalStatusDisabled.Ptr(),
 		BadBinaries:                            &shared.BadBinaries{Binaries: map[string]*shared.BadBinaryInfo{}},
+		WorkflowExecutionRetentionRunCount:     common.Int32Ptr(0),
+		TimeoutPolicy:                          persistence.DomainTimeoutPolicy{}.ToThrift(),
+		EncryptionKeyAlias:                     common.StringPtr(""),
+		Residency:                              persistence.DomainResidency{}.ToThrift(),
 	}, resp.Configuration)
 	s.Equal(&shared.DomainReplicationConfiguration{
 		ActiveClusterName: common.StringPtr(s.ClusterMetadata.GetCurrentClusterName()),
@@ -283,6 +291,10 @@ func (s *domainHandlerGlobalDomainEnabledMasterClusterSuite) TestUpdateGetDomain
 			ArchivalBucketName:                     common.StringPtr(""),
 			ArchivalStatus:                         shared.ArchivalStatusDisabled.Ptr(),
 			BadBinaries:                            &shared.BadBinaries{Binaries: map[string]*shared.BadBinaryInfo{}},
+			WorkflowExecutionRetentionRunCount:     common.Int32Ptr(0),
+			TimeoutPolicy:                          persistence.DomainTimeoutPolicy{}.ToThrift(),
+			EncryptionKeyAlias:                     common.StringPtr(""),
+			Residency:                              persistence.DomainResidency{}.ToThrift(),
 		}, config)
 		s.Equal(&shared.DomainReplicationConfiguration{
 			ActiveClusterName: common.StringPtr(s.ClusterMetadata.GetCurrentClusterName()),
@@ -345,6 +357,10 @@ func (s *domainHandlerGlobalDomainEnabledMasterClusterSuite) TestUpdateGetDomain
 			ArchivalBucketName:                     common.StringPtr(""),
 			ArchivalStatus:                         shared.ArchivalStatusDisabled.Ptr(),
 			BadBinaries:                            &shared.BadBinaries{Binaries: map[string]*shared.BadBinaryInfo{}},
+			WorkflowExecutionRetentionRunCount:     common.Int32Ptr(0),
+			TimeoutPolicy:                          persistence.DomainTimeoutPolicy{}.ToThrift(),
+			EncryptionKeyAlias:                     common.StringPtr(""),
+			Residency:                              persistence.DomainResidency{}.ToThrift(),
 		}, config)
 		s.Equal(&shared.DomainReplicationConfiguration{
 			ActiveClusterName: common.StringPtr(s.ClusterMetadata.GetCurrentClusterName()),
@@ -424,6 +440,10 @@ func (s *domainHandlerGlobalDomainEnabledMasterClusterSuite) TestRegisterGetDoma
 		ArchivalBucketName:                     common.StringPtr(""),
 		ArchivalStatus:                         shared.ArchivalStatusDisabled.Ptr(),
 		BadBinaries:                            &shared.BadBinaries{Binaries: map[string]*shared.BadBinaryInfo{}},
+		WorkflowExecutionRetentionRunCount:     common.Int32Ptr(0),
+		TimeoutPolicy:                          persistence.DomainTimeoutPolicy{}.ToThrift(),
+		EncryptionKeyAlias:                     common.StringPtr(""),
+		Residency:                              persistence.DomainResidency{}.ToThrift(),
 	}, resp.Configuration)
 	s.Equal(&shared.DomainReplicationConfiguration{
 		ActiveClusterName: common.StringPtr(s.ClusterMetadata.GetCurrentClusterName()),
@@ -490,6 +510,10 @@ func (s *domainHandlerGlobalDomainEnabledMasterClusterSuite) TestRegisterGetDoma
 		ArchivalBucketName:                     common.StringPtr(""),
 		ArchivalStatus:                         shared.ArchivalStatusDisabled.Ptr(),
 		BadBinaries:                            &shared.BadBinaries{Binaries: map[string]*shared.BadBinaryInfo{}},
+		WorkflowExecutionRetentionRunCount:     common.Int32Ptr(0),
+		TimeoutPolicy:                          persistence.DomainTimeoutPolicy{}.ToThrift(),
+		EncryptionKeyAlias:                     common.StringPtr(""),
+		Residency:                              persistence.DomainResidency{}.ToThrift(),
 	}, resp.Configuration)
 	s.Equal(&shared.DomainReplicationConfiguration{
 		ActiveClusterName: common.StringPtr(activeClusterName),
@@ -553,6 +577,10 @@ func (s *domainHandlerGlobalDomainEnabledMasterClusterSuite) TestUpdateGetDomain
 			ArchivalBucketName:                     common.StringPtr(""),
 			ArchivalStatus:                         shared.ArchivalStatusDisabled.Ptr(),
 			BadBinaries:                            &shared.BadBinaries{Binaries: map[string]*shared.BadBinaryInfo{}},
+			WorkflowExecutionRetentionRunCount:     common.Int32Ptr(0),
+			TimeoutPolicy:                          persistence.DomainTimeoutPolicy{}.ToThrift(),
+			EncryptionKeyAlias:                     common.StringPtr(""),
+			Residency:                              persistence.DomainResidency{}.ToThrift(),
 		}, config)
 		s.Equal(&shared.DomainReplicationConfiguration{
 			ActiveClusterName: common.StringPtr(activeClusterName),
@@ -626,6 +654,10 @@ func (s *domainHandlerGlobalDomainEnabledMasterClusterSuite) TestUpdateGetDomain
 			ArchivalBucketName:                     common.StringPtr(""),
 			ArchivalStatus:                         shared.ArchivalStatusDisabled.Ptr(),
 			BadBinaries:                            &shared.BadBinaries{Binaries: map[string]*shared.BadBinaryInfo{}},
+			WorkflowExecutionRetentionRunCount:     common.Int32Ptr(0),
+			TimeoutPolicy:                          persistence.DomainTimeoutPolicy{}.ToThrift(),
+			EncryptionKeyAlias:                     common.StringPtr(""),
+			Residency:                              persistence.DomainResidency{}.ToThrift(),
 		}, config)
 		s.Equal(&shared.DomainReplicationConfiguration{
 			ActiveClusterName: common.StringPtr(activeClusterName),
@@ -719,6 +751,10 @@ func (s *domainHandlerGlobalDomainEnabledMasterClusterSuite) TestUpdateGetDomain
 			ArchivalBucketName:                     common.StringPtr(""),
 			ArchivalStatus:                         shared.ArchivalStatusDisabled.Ptr(),
 			BadBinaries:                            &shared.BadBinaries{Binaries: map[string]*shared.BadBinaryInfo{}},
+			WorkflowExecutionRetentionRunCount:     common.Int32Ptr(0),
+			TimeoutPolicy:                          persistence.DomainTimeoutPolicy{}.ToThrift(),
+			EncryptionKeyAlias:                     common.StringPtr(""),
+			Residency:                              persistence.DomainResidency{}.ToThrift(),
 		}, config)
 		s.Equal(&shared.DomainReplicationConfiguration{
 			ActiveClusterName: common.StringPtr(nextActiveClusterName),
@@ -87,7 +87,7 @@ func (s *domainHandlerGlobalDomainEnabledNotMasterClusterSuite) SetupTest() {
 	s.mockDomainReplicator = NewDomainReplicator(s.mockProducer, logger)
 
 	s.handler = newDomainHandler(s.config, logger, s.metadataMgr, s.ClusterMetadata,
-		s.mockBlobstoreClient, s.mockDomainReplicator)
+		s.mockBlobstoreClient, s.mockDomainReplicator, nil)
 }
 
 func (s *domainHandlerGlobalDomainEnabledNotMasterClusterSuite) TearDownTest() {
@@ -134,6 +134,10 @@ func (s *domainHandlerGlobalDomainEnabledNotMasterClusterSuite) TestRegisterGetD
 		ArchivalBucketName:                     common.StringPtr(""),
 		ArchivalStatus:                         shared.ArchivalStatusDisabled.Ptr(),
 		BadBinaries:                            &shared.BadBinaries{Binaries: map[string]*shared.BadBinaryInfo{}},
+		WorkflowExecutionRetentionRunCount:     common.Int32Ptr(0),
+		TimeoutPolicy:                          persistence.DomainTimeoutPolicy{}.ToThrift(),
+		EncryptionKeyAlias:                     common.StringPtr(""),
+		Residency:                              persistence.DomainResidency{}.ToThrift(),
 	}, resp.Configuration)
 	s.Equal(&shared.DomainReplicationConfiguration{
 		ActiveClusterName: common.StringPtr(s.ClusterMetadata.GetCurrentClusterName()),
@@ -199,6 +203,10 @@ func (s *domainHandlerGlobalDomainEnabledNotMasterClusterSuite) TestRegisterGetD
 		ArchivalBucketName:                     common.StringPtr(""),
 		ArchivalStatus:                         shared.ArchivalStatusDisabled.Ptr(),
 		BadBinaries:                            &shared.BadBinaries{Binaries: map[string]*shared.BadBinaryInfo{}},
+		WorkflowExecutionRetentionRunCount:     common.Int32Ptr(0),
+		TimeoutPolicy:                          persistence.DomainTimeoutPolicy{}.ToThrift(),
+		EncryptionKeyAlias:                     common.StringPtr(""),
+		Residency:                              persistence.DomainResidency{}.ToThrift(),
 	}, resp.Configuration)
 	s.Equal(&shared.DomainReplicationConfiguration{
 		ActiveClusterName: common.StringPtr(s.ClusterMetadata.GetCurrentClusterName()),
@@ -254,6 +262,10 @@ func (s *domainHandlerGlobalDomainEnabledNotMasterClusterSuite) TestUpdateGetDom
 			ArchivalBucketName:                     common.StringPtr(""),
 			ArchivalStatus:                         shared.ArchivalStatusDisabled.Ptr(),
 			BadBinaries:                            &shared.BadBinaries{Binaries: map[string]*shared.BadBinaryInfo{}},
+			WorkflowExecutionRetentionRunCount:     common.Int32Ptr(0),
+			TimeoutPolicy:                          persistence.DomainTimeoutPolicy{}.ToThrift(),
+			EncryptionKeyAlias:                     common.StringPtr(""),
+			Residency:                              persistence.DomainResidency{}.ToThrift(),
 		}, config)
 		s.Equal(&shared.DomainReplicationConfiguration{
 			ActiveClusterName: common.StringPtr(s.ClusterMetadata.GetCurrentClusterName()),
@@ -316,6 +328,10 @@ func (s *domainHandlerGlobalDomainEnabledNotMasterClusterSuite) TestUpdateGetDom
 			ArchivalBucketName:                     common.StringPtr(""),
 			ArchivalStatus:                         shared.ArchivalStatusDisabled.Ptr(),
 			BadBinaries:                            &shared.BadBinaries{Binaries: map[string]*shared.BadBinaryInfo{}},
+			WorkflowExecutionRetentionRunCount:     common.Int32Ptr(0),
+			TimeoutPolicy:                          persistence.DomainTimeoutPolicy{}.ToThrift(),
+			EncryptionKeyAlias:                     common.StringPtr(""),
+			Residency:                              persistence.DomainResidency{}.ToThrift(),
 		}, config)
 		s.Equal(&shared.DomainReplicationConfiguration{
 			ActiveClusterName: common.StringPtr(s.ClusterMetadata.GetCurrentClusterName()),
@@ -615,6 +631,10 @@ func (s *domainHandlerGlobalDomainEnabledNotMasterClusterSuite) TestUpdateGetDom
 			ArchivalBucketName:                     common.StringPtr(""),
 			ArchivalStatus:                         shared.ArchivalStatusDisabled.Ptr(),
 			BadBinaries:                            &shared.BadBinaries{Binaries: map[string]*shared.BadBinaryInfo{}},
+			WorkflowExecutionRetentionRunCount:     common.Int32Ptr(0),
+			TimeoutPolicy:                          persistence.DomainTimeoutPolicy{}.ToThrift(),
+			EncryptionKeyAlias:                     common.StringPtr(""),
+			Residency:                              persistence.DomainResidency{}.ToThrift(),
 		}, config)
 		s.Equal(&shared.DomainReplicationConfiguration{
 			ActiveClusterName: common.StringPtr(nextActiveClusterName),
@@ -90,7 +90,7 @@ func (s *domainHandlerCommonSuite) SetupTest() {
 	s.mockDomainReplicator = NewDomainReplicator(s.mockProducer, logger)
 
 	s.handler = newDomainHandler(s.config, logger, s.metadataMgr, s.ClusterMetadata,
-		s.mockBlobstoreClient, s.mockDomainReplicator)
+		s.mockBlobstoreClient, s.mockDomainReplicator, nil)
 }
 
 func (s *domainHandlerCommonSuite) TearDownTest() {
@@ -93,6 +93,10 @@ func (domainReplicator *domainReplicatorImpl) HandleTransmissionTask(domainOpera
 			ArchivalBucketName:                     common.StringPtr(config.ArchivalBucket),
 			ArchivalStatus:                         common.ArchivalStatusPtr(config.ArchivalStatus),
 			BadBinaries:                            &config.BadBinaries,
+			WorkflowExecutionRetentionRunCount:     common.Int32Ptr(config.RetentionRunCount),
+			TimeoutPolicy:                          config.TimeoutPolicy.ToThrift(),
+			EncryptionKeyAlias:                     common.StringPtr(config.EncryptionKeyAlias),
+			Residency:                              config.Residency.ToThrift(),
 		},
 		ReplicationConfig: &shared.DomainReplicationConfiguration{
 			ActiveClusterName: common.StringPtr(replicationConfig.ActiveClusterName),
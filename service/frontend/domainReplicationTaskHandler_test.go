@@ -130,6 +130,10 @@ func (s *domainReplicatorSuite) TestHandleTransmissionTask_RegisterDomainTask_Is
 				ArchivalBucketName:                     common.StringPtr(archivalBucket),
 				ArchivalStatus:                         common.ArchivalStatusPtr(archivalStatus),
 				BadBinaries:                            &shared.BadBinaries{Binaries: map[string]*shared.BadBinaryInfo{}},
+				WorkflowExecutionRetentionRunCount:     common.Int32Ptr(0),
+				TimeoutPolicy:                          persistence.DomainTimeoutPolicy{}.ToThrift(),
+				EncryptionKeyAlias:                     common.StringPtr(""),
+				Residency:                              persistence.DomainResidency{}.ToThrift(),
 			},
 			ReplicationConfig: &shared.DomainReplicationConfiguration{
 				ActiveClusterName: common.StringPtr(clusterActive),
@@ -258,6 +262,10 @@ func (s *domainReplicatorSuite) TestHandleTransmissionTask_UpdateDomainTask_IsGl
 				ArchivalBucketName:                     common.StringPtr(archivalBucket),
 				ArchivalStatus:                         common.ArchivalStatusPtr(archivalStatus),
 				BadBinaries:                            &shared.BadBinaries{Binaries: map[string]*shared.BadBinaryInfo{}},
+				WorkflowExecutionRetentionRunCount:     common.Int32Ptr(0),
+				TimeoutPolicy:                          persistence.DomainTimeoutPolicy{}.ToThrift(),
+				EncryptionKeyAlias:                     common.StringPtr(""),
+				Residency:                              persistence.DomainResidency{}.ToThrift(),
 			},
 			ReplicationConfig: &shared.DomainReplicationConfiguration{
 				ActiveClusterName: common.StringPtr(clusterActive),
@@ -0,0 +1,432 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package frontend
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/uber/cadence/.gen/go/admin"
+	h "github.com/uber/cadence/.gen/go/history"
+	gen "github.com/uber/cadence/.gen/go/shared"
+	a "github.com/uber/cadence/client/admin"
+	"github.com/uber/cadence/client/history"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/blobstore"
+	"github.com/uber/cadence/common/blobstore/blob"
+	"github.com/uber/cadence/common/persistence"
+	"github.com/uber/cadence/service/worker/archiver"
+)
+
+const (
+	purgeStepArchivedHistory          = "archived history"
+	purgeStepVisibility               = "visibility"
+	purgeStepClosedExecutionIndex     = "closed execution index"
+	purgeStepBufferedReplicationTasks = "buffered replication tasks"
+	purgeStepHistory                  = "history"
+	purgeStepCurrentExecution         = "current execution"
+	purgeStepMutableState             = "mutable state"
+	purgeStepReplicationQueue         = "replication queue"
+
+	purgeReadPageSize = 1000
+)
+
+type (
+	// executionPurger deletes every trace of a closed workflow run, counting each trace before and after its
+	// deletion so that the deletions are verified by reading them back. The traces owned by the history service are
+	// deleted by the host owning the workflow, which evicts the run from its cache, and the other clusters of a
+	// global domain purge their own copy of the run.
+	executionPurger struct {
+		domainID        string
+		domainName      string
+		archivalBucket  string
+		execution       gen.WorkflowExecution
+		shardID         int
+		executionMgr    persistence.ExecutionManager
+		historyMgr      persistence.HistoryManager
+		historyV2Mgr    persistence.HistoryV2Manager
+		visibilityMgr   persistence.VisibilityManager
+		blobstoreClient blobstore.Client
+		historyClient   history.Client
+		// remoteAdminClients are the admin clients of the other clusters of a global domain, keyed by cluster name
+		remoteAdminClients map[string]a.Client
+		// asyncVisibilityStores are the visibility stores which don't delete records synchronously, keyed by store
+		// name with the reason their deletion can't be read back
+		asyncVisibilityStores map[string]string
+		// executionInfo is nil if the mutable state of the run no longer exists, when a previous purge failed
+		// after deleting it
+		executionInfo *persistence.WorkflowExecutionInfo
+	}
+
+	purgeStep struct {
+		name string
+		// skipReason is set when the trace can't be located
+		skipReason string
+		// remainingReason explains why the trace may remain after its deletion
+		remainingReason string
+		count           func(ctx context.Context) (int64, error)
+		// delete is nil for the traces deleted by the history service
+		delete func(ctx context.Context) error
+		before int64
+	}
+)
+
+// purge deletes the traces of the run in the cluster then in the other clusters of the domain
+func (p *executionPurger) purge(ctx context.Context) (*admin.PurgeWorkflowExecutionResponse, error) {
+	steps := []*purgeStep{
+		{
+			name:   purgeStepArchivedHistory,
+			count:  p.countArchivedBlobs,
+			delete: p.deleteArchivedBlobs,
+		},
+		{
+			name:            purgeStepVisibility,
+			remainingReason: "the visibility store keeps the records until the retention of the domain expires",
+			count:           p.countVisibilityRecords,
+			delete:          p.deleteVisibilityRecords,
+		},
+		{
+			name:  purgeStepClosedExecutionIndex,
+			count: p.countClosedExecutionIndex,
+		},
+		{
+			name:  purgeStepBufferedReplicationTasks,
+			count: p.countBufferedReplicationTasks,
+		},
+		{
+			name:  purgeStepHistory,
+			count: p.countHistory,
+		},
+		{
+			name:  purgeStepCurrentExecution,
+			count: p.countCurrentExecution,
+		},
+		{
+			name:  purgeStepMutableState,
+			count: p.countMutableState,
+		},
+	}
+	if p.executionInfo == nil {
+		for _, step := range steps {
+			if step.name == purgeStepClosedExecutionIndex || step.name == purgeStepHistory {
+				step.skipReason = "the mutable state of the run no longer exists to locate it"
+			}
+		}
+	}
+
+	for _, step := range steps {
+		if step.skipReason == "" {
+			before, err := step.count(ctx)
+			if err != nil {
+				return nil, err
+			}
+			step.before = before
+		}
+	}
+	for _, step := range steps {
+		if step.skipReason == "" && step.delete != nil {
+			if err := step.delete(ctx); err != nil {
+				return nil, err
+			}
+		}
+	}
+	// the history host deletes the mutable state last, as it locates the history and the closed execution index of
+	// the run, so that a failed purge can be retried
+	if err := p.historyClient.PurgeWorkflowExecution(ctx, &h.PurgeWorkflowExecutionRequest{
+		DomainUUID:        common.StringPtr(p.domainID),
+		WorkflowExecution: &p.execution,
+	}); err != nil {
+		return nil, err
+	}
+
+	resp := &admin.PurgeWorkflowExecutionResponse{
+		Steps: make([]*admin.PurgeWorkflowExecutionStep, 0, len(steps)),
+	}
+	for _, step := range steps {
+		resp.Steps = append(resp.Steps, p.verifyStep(ctx, step))
+	}
+	resp.Steps = append(resp.Steps, p.unverifiableSteps()...)
+	resp.Steps = append(resp.Steps, p.purgeRemoteClusters(ctx)...)
+	return resp, nil
+}
+
+func (p *executionPurger) verifyStep(ctx context.Context, step *purgeStep) *admin.PurgeWorkflowExecutionStep {
+	result := &admin.PurgeWorkflowExecutionStep{
+		Name:         common.StringPtr(step.name),
+		DeletedCount: common.Int64Ptr(0),
+		Verified:     common.BoolPtr(false),
+	}
+	if step.skipReason != "" {
+		result.Details = common.StringPtr("not deleted, " + step.skipReason)
+		return result
+	}
+
+	after, err := step.count(ctx)
+	if err != nil {
+		result.Details = common.StringPtr(fmt.Sprintf("verification failed: %v", err))
+		return result
+	}
+
+	if step.before > after {
+		result.DeletedCount = common.Int64Ptr(step.before - after)
+	}
+	if after > 0 {
+		details := fmt.Sprintf("%v remaining after the deletion", after)
+		if step.remainingReason != "" {
+			details += ", " + step.remainingReason
+		}
+		result.Details = common.StringPtr(details)
+		return result
+	}
+	result.Verified = common.BoolPtr(true)
+	return result
+}
+
+// unverifiableSteps reports the traces whose deletion can't be read back
+func (p *executionPurger) unverifiableSteps() []*admin.PurgeWorkflowExecutionStep {
+	var results []*admin.PurgeWorkflowExecutionStep
+	stores := make([]string, 0, len(p.asyncVisibilityStores))
+	for store := range p.asyncVisibilityStores {
+		stores = append(stores, store)
+	}
+	sort.Strings(stores)
+	for _, store := range stores {
+		results = append(results, &admin.PurgeWorkflowExecutionStep{
+			Name:         common.StringPtr(fmt.Sprintf("%v (%v)", purgeStepVisibility, store)),
+			DeletedCount: common.Int64Ptr(0),
+			Verified:     common.BoolPtr(false),
+			Details:      common.StringPtr("not verified, " + p.asyncVisibilityStores[store]),
+		})
+	}
+	if len(p.remoteAdminClients) > 0 {
+		results = append(results, &admin.PurgeWorkflowExecutionStep{
+			Name:         common.StringPtr(purgeStepReplicationQueue),
+			DeletedCount: common.Int64Ptr(0),
+			Verified:     common.BoolPtr(false),
+			Details:      common.StringPtr("not deleted, the replication tasks already published for the run stay in the replication queue until it expires"),
+		})
+	}
+	return results
+}
+
+// purgeRemoteClusters forwards the purge to the other clusters of a global domain, their steps are prefixed by the
+// cluster name
+func (p *executionPurger) purgeRemoteClusters(ctx context.Context) []*admin.PurgeWorkflowExecutionStep {
+	clusters := make([]string, 0, len(p.remoteAdminClients))
+	for cluster := range p.remoteAdminClients {
+		clusters = append(clusters, cluster)
+	}
+	sort.Strings(clusters)
+
+	var results []*admin.PurgeWorkflowExecutionStep
+	for _, cluster := range clusters {
+		resp, err := p.remoteAdminClients[cluster].PurgeWorkflowExecution(ctx, &admin.PurgeWorkflowExecutionRequest{
+			Domain:             common.StringPtr(p.domainName),
+			Execution:          &p.execution,
+			CurrentClusterOnly: common.BoolPtr(true),
+		})
+		if err != nil {
+			results = append(results, &admin.PurgeWorkflowExecutionStep{
+				Name:         common.StringPtr(cluster),
+				DeletedCount: common.Int64Ptr(0),
+				Verified:     common.BoolPtr(false),
+				Details:      common.StringPtr(fmt.Sprintf("purge failed: %v", err)),
+			})
+			continue
+		}
+		for _, step := range resp.Steps {
+			step.Name = common.StringPtr(fmt.Sprintf("%v: %v", cluster, step.GetName()))
+			results = append(results, step)
+		}
+	}
+	return results
+}
+
+func (p *executionPurger) countArchivedBlobs(ctx context.Context) (int64, error) {
+	keys, err := p.listArchivedBlobs(ctx)
+	return int64(len(keys)), err
+}
+
+func (p *executionPurger) deleteArchivedBlobs(ctx context.Context) error {
+	keys, err := p.listArchivedBlobs(ctx)
+	if err != nil {
+		return err
+	}
+	for _, key := range keys {
+		if _, err := p.blobstoreClient.Delete(ctx, p.archivalBucket, key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// listArchivedBlobs returns the keys of the history, non-deterministic history and index blobs archived for
+// the run in the current archival bucket of the domain
+func (p *executionPurger) listArchivedBlobs(ctx context.Context) ([]blob.Key, error) {
+	if p.archivalBucket == "" || p.blobstoreClient == nil {
+		return nil, nil
+	}
+	prefix, err := archiver.NewHistoryBlobKeyPrefix(p.domainID, p.execution.GetWorkflowId(), p.execution.GetRunId())
+	if err != nil {
+		return nil, err
+	}
+	keys, err := p.blobstoreClient.ListByPrefix(ctx, p.archivalBucket, prefix)
+	if err != nil {
+		return nil, err
+	}
+	// the prefix of another run can start with the prefix of the run
+	var result []blob.Key
+	for _, key := range keys {
+		if pieces := key.Pieces(); len(pieces) > 0 && pieces[0] == prefix {
+			result = append(result, key)
+		}
+	}
+	return result, nil
+}
+
+func (p *executionPurger) countVisibilityRecords(_ context.Context) (int64, error) {
+	_, err := p.visibilityMgr.GetClosedWorkflowExecution(&persistence.GetClosedWorkflowExecutionRequest{
+		DomainUUID: p.domainID,
+		Domain:     p.domainName,
+		Execution:  p.execution,
+	})
+	return countFound(err)
+}
+
+func (p *executionPurger) deleteVisibilityRecords(_ context.Context) error {
+	return p.visibilityMgr.DeleteWorkflowExecution(&persistence.VisibilityDeleteWorkflowExecutionRequest{
+		DomainID:   p.domainID,
+		WorkflowID: p.execution.GetWorkflowId(),
+		RunID:      p.execution.GetRunId(),
+	})
+}
+
+func (p *executionPurger) countClosedExecutionIndex(_ context.Context) (int64, error) {
+	// the index is ordered by close time within a day, only the rows closed at the close time of the run are read
+	closeTime := p.executionInfo.LastUpdatedTimestamp
+	request := &persistence.GetClosedExecutionIndexRequest{
+		Day:          closeTime.UTC().Truncate(24 * time.Hour),
+		MinCloseTime: closeTime.Truncate(time.Millisecond),
+		BatchSize:    purgeReadPageSize,
+	}
+	for {
+		resp, err := p.executionMgr.GetClosedExecutionIndex(request)
+		if err != nil {
+			return 0, err
+		}
+		for _, execution := range resp.Executions {
+			if execution.CloseTime.After(closeTime) {
+				return 0, nil
+			}
+			if execution.DomainID == p.domainID && execution.WorkflowID == p.execution.GetWorkflowId() &&
+				execution.RunID == p.execution.GetRunId() {
+				return 1, nil
+			}
+		}
+		if len(resp.NextPageToken) == 0 {
+			return 0, nil
+		}
+		request.NextPageToken = resp.NextPageToken
+	}
+}
+
+func (p *executionPurger) countBufferedReplicationTasks(_ context.Context) (int64, error) {
+	request := &persistence.ListBufferedReplicationTasksRequest{
+		PageSize: purgeReadPageSize,
+	}
+	for {
+		resp, err := p.executionMgr.ListBufferedReplicationTasks(request)
+		if err != nil {
+			return 0, err
+		}
+		for _, execution := range resp.Executions {
+			if execution.DomainID == p.domainID && execution.WorkflowID == p.execution.GetWorkflowId() &&
+				execution.RunID == p.execution.GetRunId() {
+				return int64(execution.TaskCount), nil
+			}
+		}
+		if len(resp.NextPageToken) == 0 {
+			return 0, nil
+		}
+		request.NextPageToken = resp.NextPageToken
+	}
+}
+
+func (p *executionPurger) countHistory(_ context.Context) (int64, error) {
+	if p.executionInfo.EventStoreVersion == persistence.EventStoreVersionV2 {
+		resp, err := p.historyV2Mgr.ReadHistoryBranch(&persistence.ReadHistoryBranchRequest{
+			BranchToken: p.executionInfo.BranchToken,
+			MinEventID:  common.FirstEventID,
+			MaxEventID:  p.executionInfo.NextEventID,
+			PageSize:    1,
+			ShardID:     common.IntPtr(p.shardID),
+		})
+		if err == nil && len(resp.HistoryEvents) == 0 {
+			return 0, nil
+		}
+		return countFound(err)
+	}
+	resp, err := p.historyMgr.GetWorkflowExecutionHistory(&persistence.GetWorkflowExecutionHistoryRequest{
+		DomainID:     p.domainID,
+		Execution:    p.execution,
+		FirstEventID: common.FirstEventID,
+		NextEventID:  p.executionInfo.NextEventID,
+		PageSize:     1,
+	})
+	if err == nil && len(resp.History.GetEvents()) == 0 {
+		return 0, nil
+	}
+	return countFound(err)
+}
+
+func (p *executionPurger) countCurrentExecution(ctx context.Context) (int64, error) {
+	resp, err := p.executionMgr.GetCurrentExecution(&persistence.GetCurrentExecutionRequest{
+		DomainID:   p.domainID,
+		WorkflowID: p.execution.GetWorkflowId(),
+		Context:    ctx,
+	})
+	if err == nil && resp.RunID != p.execution.GetRunId() {
+		return 0, nil
+	}
+	return countFound(err)
+}
+
+func (p *executionPurger) countMutableState(ctx context.Context) (int64, error) {
+	_, err := p.executionMgr.GetWorkflowExecution(&persistence.GetWorkflowExecutionRequest{
+		DomainID:  p.domainID,
+		Execution: p.execution,
+		Context:   ctx,
+	})
+	return countFound(err)
+}
+
+// countFound returns 1 if the read of a trace found it and 0 if it doesn't exist
+func countFound(err error) (int64, error) {
+	if err == nil {
+		return 1, nil
+	}
+	if _, ok := err.(*gen.EntityNotExistsError); ok {
+		return 0, nil
+	}
+	return 0, err
+}
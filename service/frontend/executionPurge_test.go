@@ -0,0 +1,268 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package frontend
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"github.com/uber/cadence/.gen/go/admin"
+	h "github.com/uber/cadence/.gen/go/history"
+	gen "github.com/uber/cadence/.gen/go/shared"
+	a "github.com/uber/cadence/client/admin"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/mocks"
+	"github.com/uber/cadence/common/persistence"
+)
+
+type (
+	executionPurgeSuite struct {
+		suite.Suite
+
+		mockExecutionMgr  *mocks.ExecutionManager
+		mockHistoryV2Mgr  *mocks.HistoryV2Manager
+		mockVisibilityMgr *mocks.VisibilityManager
+		mockHistoryClient *mocks.HistoryClient
+		purger            *executionPurger
+	}
+)
+
+const (
+	testPurgeDomainID   = "deadbeef-0123-4567-890a-bcdef0123456"
+	testPurgeWorkflowID = "purge-workflow"
+	testPurgeRunID      = "0d00698f-08e1-4d36-a3e2-3bf109f5d2d6"
+)
+
+func TestExecutionPurgeSuite(t *testing.T) {
+	s := new(executionPurgeSuite)
+	suite.Run(t, s)
+}
+
+func (s *executionPurgeSuite) SetupTest() {
+	s.mockExecutionMgr = &mocks.ExecutionManager{}
+	s.mockHistoryV2Mgr = &mocks.HistoryV2Manager{}
+	s.mockVisibilityMgr = &mocks.VisibilityManager{}
+	s.mockHistoryClient = &mocks.HistoryClient{}
+	s.purger = &executionPurger{
+		domainID:   testPurgeDomainID,
+		domainName: "purge-domain",
+		execution: gen.WorkflowExecution{
+			WorkflowId: common.StringPtr(testPurgeWorkflowID),
+			RunId:      common.StringPtr(testPurgeRunID),
+		},
+		shardID:            1,
+		executionMgr:       s.mockExecutionMgr,
+		historyV2Mgr:       s.mockHistoryV2Mgr,
+		visibilityMgr:      s.mockVisibilityMgr,
+		historyClient:      s.mockHistoryClient,
+		remoteAdminClients: map[string]a.Client{},
+		executionInfo: &persistence.WorkflowExecutionInfo{
+			DomainID:             testPurgeDomainID,
+			WorkflowID:           testPurgeWorkflowID,
+			RunID:                testPurgeRunID,
+			NextEventID:          10,
+			EventStoreVersion:    persistence.EventStoreVersionV2,
+			BranchToken:          []byte("branch"),
+			LastUpdatedTimestamp: time.Unix(0, 0),
+		},
+	}
+}
+
+func (s *executionPurgeSuite) TearDownTest() {
+	s.mockExecutionMgr.AssertExpectations(s.T())
+	s.mockHistoryV2Mgr.AssertExpectations(s.T())
+	s.mockVisibilityMgr.AssertExpectations(s.T())
+	s.mockHistoryClient.AssertExpectations(s.T())
+}
+
+func (s *executionPurgeSuite) TestPurge() {
+	s.expectTraces(true)
+	s.expectDeletions()
+	s.expectTraces(false)
+
+	resp, err := s.purger.purge(context.Background())
+	s.NoError(err)
+	s.Len(resp.Steps, 7)
+	for _, step := range resp.Steps {
+		s.True(step.GetVerified(), step.GetName())
+	}
+	s.Equal(int64(3), s.findStep(resp, purgeStepBufferedReplicationTasks).GetDeletedCount())
+	s.Equal(int64(1), s.findStep(resp, purgeStepMutableState).GetDeletedCount())
+	// the traces owned by the history service are only deleted by the history host
+	s.mockExecutionMgr.AssertNotCalled(s.T(), "DeleteWorkflowExecution", mock.Anything)
+	s.mockExecutionMgr.AssertNotCalled(s.T(), "DeleteCurrentWorkflowExecution", mock.Anything)
+	s.mockHistoryV2Mgr.AssertNotCalled(s.T(), "DeleteHistoryBranch", mock.Anything)
+}
+
+func (s *executionPurgeSuite) TestPurge_TraceRemaining() {
+	s.expectTraces(true)
+	s.expectDeletions()
+	s.expectTraces(false)
+	s.mockExecutionMgr.ExpectedCalls = removeCalls(s.mockExecutionMgr.ExpectedCalls, "GetWorkflowExecution")
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(&persistence.GetWorkflowExecutionResponse{}, nil).Twice()
+
+	resp, err := s.purger.purge(context.Background())
+	s.NoError(err)
+	step := s.findStep(resp, purgeStepMutableState)
+	s.False(step.GetVerified())
+	s.Equal(int64(0), step.GetDeletedCount())
+	s.Equal("1 remaining after the deletion", step.GetDetails())
+}
+
+func (s *executionPurgeSuite) TestPurge_HistoryFailed() {
+	s.expectTraces(true)
+	s.mockVisibilityMgr.On("DeleteWorkflowExecution", mock.Anything).Return(nil).Once()
+	s.mockHistoryClient.On("PurgeWorkflowExecution", mock.Anything, mock.Anything).Return(errors.New("shard closed")).Once()
+
+	_, err := s.purger.purge(context.Background())
+	s.EqualError(err, "shard closed")
+}
+
+func (s *executionPurgeSuite) TestPurge_MutableStateDeleted() {
+	s.purger.executionInfo = nil
+	s.expectTraces(false)
+	s.expectDeletions()
+	s.expectTraces(false)
+	s.mockExecutionMgr.ExpectedCalls = removeCalls(s.mockExecutionMgr.ExpectedCalls, "GetClosedExecutionIndex")
+	s.mockHistoryV2Mgr.ExpectedCalls = removeCalls(s.mockHistoryV2Mgr.ExpectedCalls, "ReadHistoryBranch")
+
+	resp, err := s.purger.purge(context.Background())
+	s.NoError(err)
+	for _, name := range []string{purgeStepClosedExecutionIndex, purgeStepHistory} {
+		step := s.findStep(resp, name)
+		s.False(step.GetVerified())
+		s.Equal("not deleted, the mutable state of the run no longer exists to locate it", step.GetDetails())
+	}
+}
+
+func (s *executionPurgeSuite) TestPurge_AsyncVisibilityStores() {
+	s.purger.asyncVisibilityStores = map[string]string{
+		"elasticsearch": "the record is deleted asynchronously through kafka",
+	}
+	s.expectTraces(true)
+	s.expectDeletions()
+	s.expectTraces(false)
+
+	resp, err := s.purger.purge(context.Background())
+	s.NoError(err)
+	s.True(s.findStep(resp, purgeStepVisibility).GetVerified())
+	step := s.findStep(resp, "visibility (elasticsearch)")
+	s.False(step.GetVerified())
+	s.Equal("not verified, the record is deleted asynchronously through kafka", step.GetDetails())
+}
+
+func (s *executionPurgeSuite) TestPurge_RemoteClusters() {
+	standby := &mocks.AdminClient{}
+	unreachable := &mocks.AdminClient{}
+	s.purger.remoteAdminClients = map[string]a.Client{
+		"standby":     standby,
+		"unreachable": unreachable,
+	}
+	s.expectTraces(true)
+	s.expectDeletions()
+	s.expectTraces(false)
+	standby.On("PurgeWorkflowExecution", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		request := args.Get(1).(*admin.PurgeWorkflowExecutionRequest)
+		s.True(request.GetCurrentClusterOnly())
+		s.Equal("purge-domain", request.GetDomain())
+		s.Equal(testPurgeRunID, request.Execution.GetRunId())
+	}).Return(&admin.PurgeWorkflowExecutionResponse{
+		Steps: []*admin.PurgeWorkflowExecutionStep{
+			{Name: common.StringPtr(purgeStepMutableState), DeletedCount: common.Int64Ptr(1), Verified: common.BoolPtr(true)},
+		},
+	}, nil).Once()
+	unreachable.On("PurgeWorkflowExecution", mock.Anything, mock.Anything).Return(nil, errors.New("connection refused")).Once()
+
+	resp, err := s.purger.purge(context.Background())
+	s.NoError(err)
+	step := s.findStep(resp, purgeStepReplicationQueue)
+	s.False(step.GetVerified())
+	s.True(s.findStep(resp, "standby: "+purgeStepMutableState).GetVerified())
+	step = s.findStep(resp, "unreachable")
+	s.False(step.GetVerified())
+	s.Equal("purge failed: connection refused", step.GetDetails())
+	standby.AssertExpectations(s.T())
+	unreachable.AssertExpectations(s.T())
+}
+
+// expectTraces expects one read of every trace of the run, finding it or not
+func (s *executionPurgeSuite) expectTraces(found bool) {
+	notFound := &gen.EntityNotExistsError{}
+	if found {
+		s.mockVisibilityMgr.On("GetClosedWorkflowExecution", mock.Anything).Return(&persistence.GetClosedWorkflowExecutionResponse{}, nil).Once()
+		s.mockExecutionMgr.On("GetClosedExecutionIndex", mock.Anything).Return(&persistence.GetClosedExecutionIndexResponse{
+			Executions: []*persistence.ClosedExecutionIndexInfo{
+				{DomainID: testPurgeDomainID, WorkflowID: testPurgeWorkflowID, RunID: testPurgeRunID, CloseTime: time.Unix(0, 0)},
+			},
+		}, nil).Once()
+		s.mockExecutionMgr.On("ListBufferedReplicationTasks", mock.Anything).Return(&persistence.ListBufferedReplicationTasksResponse{
+			Executions: []*persistence.BufferedReplicationTasksInfo{
+				{DomainID: testPurgeDomainID, WorkflowID: testPurgeWorkflowID, RunID: testPurgeRunID, TaskCount: 3},
+			},
+		}, nil).Once()
+		s.mockHistoryV2Mgr.On("ReadHistoryBranch", mock.Anything).Return(&persistence.ReadHistoryBranchResponse{
+			HistoryEvents: []*gen.HistoryEvent{{EventId: common.Int64Ptr(common.FirstEventID)}},
+		}, nil).Once()
+		s.mockExecutionMgr.On("GetCurrentExecution", mock.Anything).Return(&persistence.GetCurrentExecutionResponse{
+			RunID: testPurgeRunID,
+		}, nil).Once()
+		s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(&persistence.GetWorkflowExecutionResponse{}, nil).Once()
+		return
+	}
+	s.mockVisibilityMgr.On("GetClosedWorkflowExecution", mock.Anything).Return(nil, notFound).Once()
+	s.mockExecutionMgr.On("GetClosedExecutionIndex", mock.Anything).Return(&persistence.GetClosedExecutionIndexResponse{}, nil).Once()
+	s.mockExecutionMgr.On("ListBufferedReplicationTasks", mock.Anything).Return(&persistence.ListBufferedReplicationTasksResponse{}, nil).Once()
+	s.mockHistoryV2Mgr.On("ReadHistoryBranch", mock.Anything).Return(nil, notFound).Once()
+	s.mockExecutionMgr.On("GetCurrentExecution", mock.Anything).Return(nil, notFound).Once()
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(nil, notFound).Once()
+}
+
+func (s *executionPurgeSuite) expectDeletions() {
+	s.mockVisibilityMgr.On("DeleteWorkflowExecution", mock.Anything).Return(nil).Once()
+	s.mockHistoryClient.On("PurgeWorkflowExecution", mock.Anything, &h.PurgeWorkflowExecutionRequest{
+		DomainUUID:        common.StringPtr(testPurgeDomainID),
+		WorkflowExecution: &s.purger.execution,
+	}).Return(nil).Once()
+}
+
+func (s *executionPurgeSuite) findStep(resp *admin.PurgeWorkflowExecutionResponse, name string) *admin.PurgeWorkflowExecutionStep {
+	for _, step := range resp.Steps {
+		if step.GetName() == name {
+			return step
+		}
+	}
+	s.Fail("step not found", name)
+	return nil
+}
+
+func removeCalls(calls []*mock.Call, method string) []*mock.Call {
+	var result []*mock.Call
+	for _, call := range calls {
+		if call.Method != method {
+			result = append(result, call)
+		}
+	}
+	return result
+}
@@ -0,0 +1,117 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package frontend
+
+import (
+	"time"
+
+	gen "github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common/cache"
+	"github.com/uber/cadence/common/clock"
+	"github.com/uber/cadence/common/service/dynamicconfig"
+)
+
+const (
+	searchAttributesCacheKey = "search-attributes"
+)
+
+type (
+	// metadataCache keeps recently served responses of read only metadata APIs,
+	// so SDK clients calling DescribeDomain on every worker start do not hit the metadata store each time
+	metadataCache struct {
+		describeDomainCache   cache.Cache
+		searchAttributesCache cache.Cache
+		ttl                   dynamicconfig.DurationPropertyFn
+		timeSource            clock.TimeSource
+	}
+
+	describeDomainCacheEntry struct {
+		response            *gen.DescribeDomainResponse
+		notificationVersion int64
+		expiry              time.Time
+	}
+
+	searchAttributesCacheEntry struct {
+		response *gen.GetSearchAttributesResponse
+		expiry   time.Time
+	}
+)
+
+func newMetadataCache(maxSize int, ttl dynamicconfig.DurationPropertyFn, timeSource clock.TimeSource) *metadataCache {
+	return &metadataCache{
+		describeDomainCache:   cache.New(maxSize, &cache.Options{}),
+		searchAttributesCache: cache.New(1, &cache.Options{}),
+		ttl:                   ttl,
+		timeSource:            timeSource,
+	}
+}
+
+// enabled returns false when the TTL is set to zero through dynamic config
+func (c *metadataCache) enabled() bool {
+	return c.ttl() > 0
+}
+
+// getDescribeDomain returns the cached response for the domain, as long as it has not expired and
+// it was cached for the same domain notification version
+func (c *metadataCache) getDescribeDomain(name string, notificationVersion int64) *gen.DescribeDomainResponse {
+	entry, ok := c.describeDomainCache.Get(name).(*describeDomainCacheEntry)
+	if !ok {
+		return nil
+	}
+	if entry.notificationVersion != notificationVersion || c.timeSource.Now().After(entry.expiry) {
+		c.describeDomainCache.Delete(name)
+		return nil
+	}
+	return entry.response
+}
+
+func (c *metadataCache) putDescribeDomain(name string, notificationVersion int64, response *gen.DescribeDomainResponse) {
+	c.describeDomainCache.Put(name, &describeDomainCacheEntry{
+		response:            response,
+		notificationVersion: notificationVersion,
+		expiry:              c.timeSource.Now().Add(c.ttl()),
+	})
+}
+
+// invalidateDomain drops the cached response of a domain updated through this host, without waiting
+// for the domain cache to pick up the new notification version
+func (c *metadataCache) invalidateDomain(name string) {
+	c.describeDomainCache.Delete(name)
+}
+
+func (c *metadataCache) getSearchAttributes() *gen.GetSearchAttributesResponse {
+	entry, ok := c.searchAttributesCache.Get(searchAttributesCacheKey).(*searchAttributesCacheEntry)
+	if !ok {
+		return nil
+	}
+	if c.timeSource.Now().After(entry.expiry) {
+		c.searchAttributesCache.Delete(searchAttributesCacheKey)
+		return nil
+	}
+	return entry.response
+}
+
+func (c *metadataCache) putSearchAttributes(response *gen.GetSearchAttributesResponse) {
+	c.searchAttributesCache.Put(searchAttributesCacheKey, &searchAttributesCacheEntry{
+		response: response,
+		expiry:   c.timeSource.Now().Add(c.ttl()),
+	})
+}
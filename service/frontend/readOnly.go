@@ -0,0 +1,176 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package frontend
+
+import (
+	gen "github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/persistence"
+)
+
+// the read only mode serves a restored snapshot of the datastores without the history service, so the
+// reads usually made through the history service are made from the execution store instead
+
+var persistenceCloseStatuses = map[int]gen.WorkflowExecutionCloseStatus{
+	persistence.WorkflowCloseStatusCompleted:      gen.WorkflowExecutionCloseStatusCompleted,
+	persistence.WorkflowCloseStatusFailed:         gen.WorkflowExecutionCloseStatusFailed,
+	persistence.WorkflowCloseStatusCanceled:       gen.WorkflowExecutionCloseStatusCanceled,
+	persistence.WorkflowCloseStatusTerminated:     gen.WorkflowExecutionCloseStatusTerminated,
+	persistence.WorkflowCloseStatusContinuedAsNew: gen.WorkflowExecutionCloseStatusContinuedAsNew,
+	persistence.WorkflowCloseStatusTimedOut:       gen.WorkflowExecutionCloseStatusTimedOut,
+}
+
+// getMutableStateFromPersistence reads the mutable state of the execution, the current run if no run ID is given
+func (wh *WorkflowHandler) getMutableStateFromPersistence(domainID string, execution *gen.WorkflowExecution) (*persistence.WorkflowMutableState, error) {
+	// the execution manager shares the session of the factory, so it is not closed here
+	shardID := common.WorkflowIDToHistoryShard(execution.GetWorkflowId(), wh.config.NumHistoryShards)
+	executionMgr, err := wh.executionMgrFactory.NewExecutionManager(shardID)
+	if err != nil {
+		return nil, err
+	}
+
+	runID := execution.GetRunId()
+	if runID == "" {
+		currentResp, err := executionMgr.GetCurrentExecution(&persistence.GetCurrentExecutionRequest{
+			DomainID:   domainID,
+			WorkflowID: execution.GetWorkflowId(),
+		})
+		if err != nil {
+			return nil, err
+		}
+		runID = currentResp.RunID
+	}
+
+	resp, err := executionMgr.GetWorkflowExecution(&persistence.GetWorkflowExecutionRequest{
+		DomainID: domainID,
+		Execution: gen.WorkflowExecution{
+			WorkflowId: execution.WorkflowId,
+			RunId:      common.StringPtr(runID),
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.State, nil
+}
+
+// describeWorkflowExecutionFromPersistence describes the execution from its mutable state, the fields derived
+// from the history events or the timers of the history service are left unset
+func (wh *WorkflowHandler) describeWorkflowExecutionFromPersistence(domainID string, execution *gen.WorkflowExecution) (*gen.DescribeWorkflowExecutionResponse, error) {
+	state, err := wh.getMutableStateFromPersistence(domainID, execution)
+	if err != nil {
+		return nil, err
+	}
+	executionInfo := state.ExecutionInfo
+
+	result := &gen.DescribeWorkflowExecutionResponse{
+		ExecutionConfiguration: &gen.WorkflowExecutionConfiguration{
+			TaskList:                            &gen.TaskList{Name: common.StringPtr(executionInfo.TaskList)},
+			ExecutionStartToCloseTimeoutSeconds: common.Int32Ptr(executionInfo.WorkflowTimeout),
+			TaskStartToCloseTimeoutSeconds:      common.Int32Ptr(executionInfo.DecisionTimeoutValue),
+			ChildPolicy:                         common.ChildPolicyPtr(gen.ChildPolicyTerminate),
+		},
+		WorkflowExecutionInfo: &gen.WorkflowExecutionInfo{
+			Execution: &gen.WorkflowExecution{
+				WorkflowId: common.StringPtr(executionInfo.WorkflowID),
+				RunId:      common.StringPtr(executionInfo.RunID),
+			},
+			Type:             &gen.WorkflowType{Name: common.StringPtr(executionInfo.WorkflowTypeName)},
+			StartTime:        common.Int64Ptr(executionInfo.StartTimestamp.UnixNano()),
+			HistoryLength:    common.Int64Ptr(executionInfo.NextEventID - common.FirstEventID),
+			AutoResetPoints:  executionInfo.AutoResetPoints,
+			SearchAttributes: &gen.SearchAttributes{IndexedFields: executionInfo.SearchAttributes},
+		},
+	}
+	if executionInfo.FirstExecutionRunID != "" {
+		result.WorkflowExecutionInfo.FirstExecutionRunId = common.StringPtr(executionInfo.FirstExecutionRunID)
+	}
+	if executionInfo.ParentRunID != "" {
+		result.WorkflowExecutionInfo.ParentExecution = &gen.WorkflowExecution{
+			WorkflowId: common.StringPtr(executionInfo.ParentWorkflowID),
+			RunId:      common.StringPtr(executionInfo.ParentRunID),
+		}
+		result.WorkflowExecutionInfo.ParentDomainId = common.StringPtr(executionInfo.ParentDomainID)
+	}
+	if executionInfo.Notes != "" {
+		result.ExecutionNotes = &gen.WorkflowExecutionNotes{
+			Notes:                common.StringPtr(executionInfo.Notes),
+			Identity:             common.StringPtr(executionInfo.NotesIdentity),
+			LastUpdatedTimestamp: common.Int64Ptr(executionInfo.NotesLastUpdatedTime.UnixNano()),
+		}
+	}
+	if executionInfo.State == persistence.WorkflowStateCompleted {
+		if closeStatus, ok := persistenceCloseStatuses[executionInfo.CloseStatus]; ok {
+			result.WorkflowExecutionInfo.CloseStatus = &closeStatus
+		}
+		if executionInfo.CompletionEvent != nil {
+			result.WorkflowExecutionInfo.CloseTime = common.Int64Ptr(executionInfo.CompletionEvent.GetTimestamp())
+		}
+	}
+
+	for _, ai := range state.ActivityInfos {
+		p := &gen.PendingActivityInfo{
+			ActivityID: common.StringPtr(ai.ActivityID),
+		}
+		activityState := gen.PendingActivityStateScheduled
+		if ai.CancelRequested {
+			activityState = gen.PendingActivityStateCancelRequested
+		} else if ai.StartedID != common.EmptyEventID {
+			activityState = gen.PendingActivityStateStarted
+		}
+		p.State = &activityState
+		if ai.ScheduledEvent != nil && ai.ScheduledEvent.ActivityTaskScheduledEventAttributes != nil {
+			p.ActivityType = ai.ScheduledEvent.ActivityTaskScheduledEventAttributes.ActivityType
+		}
+		if activityState == gen.PendingActivityStateScheduled {
+			p.ScheduledTimestamp = common.Int64Ptr(ai.ScheduledTime.UnixNano())
+		} else {
+			p.LastStartedTimestamp = common.Int64Ptr(ai.StartedTime.UnixNano())
+		}
+		if ai.HasRetryPolicy {
+			p.Attempt = common.Int32Ptr(ai.Attempt)
+		}
+		result.PendingActivities = append(result.PendingActivities, p)
+	}
+
+	for _, ch := range state.ChildExecutionInfos {
+		result.PendingChildren = append(result.PendingChildren, &gen.PendingChildExecutionInfo{
+			WorkflowID:      common.StringPtr(ch.StartedWorkflowID),
+			RunID:           common.StringPtr(ch.StartedRunID),
+			WorkflowTypName: common.StringPtr(ch.WorkflowTypeName),
+			InitiatedID:     common.Int64Ptr(ch.InitiatedID),
+		})
+	}
+	return result, nil
+}
+
+// getHistoryStateFromPersistence returns the history branch and event IDs of the execution like the
+// GetMutableState API of the history service
+func (wh *WorkflowHandler) getHistoryStateFromPersistence(domainID string, execution *gen.WorkflowExecution) (int32, []byte, string, int64, int64, bool, error) {
+	state, err := wh.getMutableStateFromPersistence(domainID, execution)
+	if err != nil {
+		return 0, nil, "", 0, 0, false, err
+	}
+	executionInfo := state.ExecutionInfo
+	isWorkflowRunning := executionInfo.State != persistence.WorkflowStateCompleted
+	return executionInfo.EventStoreVersion, executionInfo.GetCurrentBranch(), executionInfo.RunID,
+		executionInfo.LastFirstEventID, executionInfo.NextEventID, isWorkflowRunning, nil
+}
@@ -21,7 +21,11 @@
 package frontend
 
 import (
+	"time"
+
 	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/audit"
+	"github.com/uber/cadence/common/authorization"
 	"github.com/uber/cadence/common/blobstore"
 	"github.com/uber/cadence/common/definition"
 	"github.com/uber/cadence/common/log/loggerimpl"
@@ -31,6 +35,7 @@ import (
 	"github.com/uber/cadence/common/persistence"
 	espersistence "github.com/uber/cadence/common/persistence/elasticsearch"
 	persistencefactory "github.com/uber/cadence/common/persistence/persistence-factory"
+	pinotpersistence "github.com/uber/cadence/common/persistence/pinot"
 	"github.com/uber/cadence/common/service"
 	"github.com/uber/cadence/common/service/config"
 	"github.com/uber/cadence/common/service/dynamicconfig"
@@ -38,21 +43,29 @@ import (
 
 // Config represents configuration for cadence-frontend service
 type Config struct {
-	NumHistoryShards                int
-	PersistenceMaxQPS               dynamicconfig.IntPropertyFn
-	VisibilityMaxPageSize           dynamicconfig.IntPropertyFnWithDomainFilter
-	EnableVisibilitySampling        dynamicconfig.BoolPropertyFn
-	EnableReadFromClosedExecutionV2 dynamicconfig.BoolPropertyFn
-	VisibilityListMaxQPS            dynamicconfig.IntPropertyFnWithDomainFilter
-	EnableVisibilityToKafka         dynamicconfig.BoolPropertyFn
-	EnableReadVisibilityFromES      dynamicconfig.BoolPropertyFnWithDomainFilter
-	ESVisibilityListMaxQPS          dynamicconfig.IntPropertyFnWithDomainFilter
-	ESIndexMaxResultWindow          dynamicconfig.IntPropertyFn
-	HistoryMaxPageSize              dynamicconfig.IntPropertyFnWithDomainFilter
-	RPS                             dynamicconfig.IntPropertyFn
-	MaxIDLengthLimit                dynamicconfig.IntPropertyFn
-	EnableClientVersionCheck        dynamicconfig.BoolPropertyFn
-	MinRetentionDays                dynamicconfig.IntPropertyFn
+	NumHistoryShards                       int
+	ReadOnly                               bool
+	PersistenceMaxQPS                      dynamicconfig.IntPropertyFn
+	VisibilityMaxPageSize                  dynamicconfig.IntPropertyFnWithDomainFilter
+	EnableVisibilitySampling               dynamicconfig.BoolPropertyFn
+	EnableReadFromClosedExecutionV2        dynamicconfig.BoolPropertyFn
+	EnableReadFromOpenExecutionsByDomain   dynamicconfig.BoolPropertyFn
+	EnableReadFromClosedExecutionsByFilter dynamicconfig.BoolPropertyFn
+	VisibilityListMaxQPS                   dynamicconfig.IntPropertyFnWithDomainFilter
+	EnableVisibilityToKafka                dynamicconfig.BoolPropertyFn
+	EnableReadVisibilityFromES             dynamicconfig.BoolPropertyFnWithDomainFilter
+	EnableReadVisibilityFromPinot          dynamicconfig.BoolPropertyFnWithDomainFilter
+	ESVisibilityListMaxQPS                 dynamicconfig.IntPropertyFnWithDomainFilter
+	PinotVisibilityListMaxQPS              dynamicconfig.IntPropertyFnWithDomainFilter
+	ESIndexMaxResultWindow                 dynamicconfig.IntPropertyFn
+	HistoryMaxPageSize                     dynamicconfig.IntPropertyFnWithDomainFilter
+	RawHistoryMaxPageBytes                 dynamicconfig.IntPropertyFnWithDomainFilter
+	RPS                                    dynamicconfig.IntPropertyFn
+	DomainRPS                              dynamicconfig.IntPropertyFnWithDomainFilter
+	GlobalDomainRPS                        dynamicconfig.IntPropertyFnWithDomainFilter
+	MaxIDLengthLimit                       dynamicconfig.IntPropertyFn
+	EnableClientVersionCheck               dynamicconfig.BoolPropertyFn
+	MinRetentionDays                       dynamicconfig.IntPropertyFn
 
 	// Persistence settings
 	HistoryMgrNumConns dynamicconfig.IntPropertyFn
@@ -73,46 +86,71 @@ type Config struct {
 
 	// Domain specific config
 	EnableDomainNotActiveAutoForwarding dynamicconfig.BoolPropertyFnWithDomainFilter
+	EnableStandbyReadForwarding         dynamicconfig.BoolPropertyFnWithDomainFilter
+	StandbyReadForwardingTimeout        dynamicconfig.DurationPropertyFnWithDomainFilter
 
 	// ValidSearchAttributes is legal indexed keys that can be used in list APIs
 	ValidSearchAttributes             dynamicconfig.MapPropertyFn
 	SearchAttributesNumberOfKeysLimit dynamicconfig.IntPropertyFnWithDomainFilter
 	SearchAttributesSizeOfValueLimit  dynamicconfig.IntPropertyFnWithDomainFilter
 	SearchAttributesTotalSizeLimit    dynamicconfig.IntPropertyFnWithDomainFilter
+
+	// sticky query settings
+	StickyQueryTimeout              dynamicconfig.DurationPropertyFnWithDomainFilter
+	StickyQueryPollerLivenessWindow dynamicconfig.DurationPropertyFnWithDomainFilter
+	StickyQueryPollerCheckTimeout   dynamicconfig.DurationPropertyFnWithDomainFilter
+
+	// metadata API response cache settings
+	MetadataCacheTTL     dynamicconfig.DurationPropertyFn
+	MetadataCacheMaxSize dynamicconfig.IntPropertyFn
 }
 
 // NewConfig returns new service config with default values
 func NewConfig(dc *dynamicconfig.Collection, numHistoryShards int, enableVisibilityToKafka bool) *Config {
 	return &Config{
-		NumHistoryShards:                    numHistoryShards,
-		PersistenceMaxQPS:                   dc.GetIntProperty(dynamicconfig.FrontendPersistenceMaxQPS, 2000),
-		VisibilityMaxPageSize:               dc.GetIntPropertyFilteredByDomain(dynamicconfig.FrontendVisibilityMaxPageSize, 1000),
-		EnableVisibilitySampling:            dc.GetBoolProperty(dynamicconfig.EnableVisibilitySampling, true),
-		EnableReadFromClosedExecutionV2:     dc.GetBoolProperty(dynamicconfig.EnableReadFromClosedExecutionV2, false),
-		VisibilityListMaxQPS:                dc.GetIntPropertyFilteredByDomain(dynamicconfig.FrontendVisibilityListMaxQPS, 1),
-		EnableVisibilityToKafka:             dc.GetBoolProperty(dynamicconfig.EnableVisibilityToKafka, enableVisibilityToKafka),
-		EnableReadVisibilityFromES:          dc.GetBoolPropertyFnWithDomainFilter(dynamicconfig.EnableReadVisibilityFromES, false),
-		ESVisibilityListMaxQPS:              dc.GetIntPropertyFilteredByDomain(dynamicconfig.FrontendESVisibilityListMaxQPS, 3),
-		ESIndexMaxResultWindow:              dc.GetIntProperty(dynamicconfig.FrontendESIndexMaxResultWindow, 10000),
-		HistoryMaxPageSize:                  dc.GetIntPropertyFilteredByDomain(dynamicconfig.FrontendHistoryMaxPageSize, common.GetHistoryMaxPageSize),
-		RPS:                                 dc.GetIntProperty(dynamicconfig.FrontendRPS, 1200),
-		MaxIDLengthLimit:                    dc.GetIntProperty(dynamicconfig.MaxIDLengthLimit, 1000),
-		HistoryMgrNumConns:                  dc.GetIntProperty(dynamicconfig.FrontendHistoryMgrNumConns, 10),
-		MaxDecisionStartToCloseTimeout:      dc.GetIntPropertyFilteredByDomain(dynamicconfig.MaxDecisionStartToCloseTimeout, 600),
-		MaxBadBinaries:                      dc.GetIntPropertyFilteredByDomain(dynamicconfig.FrontendMaxBadBinaries, 10),
-		EnableAdminProtection:               dc.GetBoolProperty(dynamicconfig.EnableAdminProtection, false),
-		AdminOperationToken:                 dc.GetStringProperty(dynamicconfig.AdminOperationToken, common.DefaultAdminOperationToken),
-		DisableListVisibilityByFilter:       dc.GetBoolPropertyFnWithDomainFilter(dynamicconfig.DisableListVisibilityByFilter, false),
-		BlobSizeLimitError:                  dc.GetIntPropertyFilteredByDomain(dynamicconfig.BlobSizeLimitError, 2*1024*1024),
-		BlobSizeLimitWarn:                   dc.GetIntPropertyFilteredByDomain(dynamicconfig.BlobSizeLimitWarn, 256*1024),
-		ThrottledLogRPS:                     dc.GetIntProperty(dynamicconfig.FrontendThrottledLogRPS, 20),
-		EnableDomainNotActiveAutoForwarding: dc.GetBoolPropertyFnWithDomainFilter(dynamicconfig.EnableDomainNotActiveAutoForwarding, false),
-		EnableClientVersionCheck:            dc.GetBoolProperty(dynamicconfig.EnableClientVersionCheck, false),
-		ValidSearchAttributes:               dc.GetMapProperty(dynamicconfig.ValidSearchAttributes, definition.GetDefaultIndexedKeys()),
-		SearchAttributesNumberOfKeysLimit:   dc.GetIntPropertyFilteredByDomain(dynamicconfig.SearchAttributesNumberOfKeysLimit, 100),
-		SearchAttributesSizeOfValueLimit:    dc.GetIntPropertyFilteredByDomain(dynamicconfig.SearchAttributesSizeOfValueLimit, 2*1024),
-		SearchAttributesTotalSizeLimit:      dc.GetIntPropertyFilteredByDomain(dynamicconfig.SearchAttributesTotalSizeLimit, 40*1024),
-		MinRetentionDays:                    dc.GetIntProperty(dynamicconfig.MinRetentionDays, 1),
+		NumHistoryShards:                       numHistoryShards,
+		PersistenceMaxQPS:                      dc.GetIntProperty(dynamicconfig.FrontendPersistenceMaxQPS, 2000),
+		VisibilityMaxPageSize:                  dc.GetIntPropertyFilteredByDomain(dynamicconfig.FrontendVisibilityMaxPageSize, 1000),
+		EnableVisibilitySampling:               dc.GetBoolProperty(dynamicconfig.EnableVisibilitySampling, true),
+		EnableReadFromClosedExecutionV2:        dc.GetBoolProperty(dynamicconfig.EnableReadFromClosedExecutionV2, false),
+		EnableReadFromOpenExecutionsByDomain:   dc.GetBoolProperty(dynamicconfig.EnableReadFromOpenExecutionsByDomain, false),
+		EnableReadFromClosedExecutionsByFilter: dc.GetBoolProperty(dynamicconfig.EnableReadFromClosedExecutionsByFilter, false),
+		VisibilityListMaxQPS:                   dc.GetIntPropertyFilteredByDomain(dynamicconfig.FrontendVisibilityListMaxQPS, 1),
+		EnableVisibilityToKafka:                dc.GetBoolProperty(dynamicconfig.EnableVisibilityToKafka, enableVisibilityToKafka),
+		EnableReadVisibilityFromES:             dc.GetBoolPropertyFnWithDomainFilter(dynamicconfig.EnableReadVisibilityFromES, false),
+		EnableReadVisibilityFromPinot:          dc.GetBoolPropertyFnWithDomainFilter(dynamicconfig.EnableReadVisibilityFromPinot, false),
+		ESVisibilityListMaxQPS:                 dc.GetIntPropertyFilteredByDomain(dynamicconfig.FrontendESVisibilityListMaxQPS, 3),
+		PinotVisibilityListMaxQPS:              dc.GetIntPropertyFilteredByDomain(dynamicconfig.FrontendPinotVisibilityListMaxQPS, 3),
+		ESIndexMaxResultWindow:                 dc.GetIntProperty(dynamicconfig.FrontendESIndexMaxResultWindow, 10000),
+		HistoryMaxPageSize:                     dc.GetIntPropertyFilteredByDomain(dynamicconfig.FrontendHistoryMaxPageSize, common.GetHistoryMaxPageSize),
+		RawHistoryMaxPageBytes:                 dc.GetIntPropertyFilteredByDomain(dynamicconfig.FrontendRawHistoryMaxPageBytes, 4*1024*1024),
+		RPS:                                    dc.GetIntProperty(dynamicconfig.FrontendRPS, 1200),
+		DomainRPS:                              dc.GetIntPropertyFilteredByDomain(dynamicconfig.FrontendDomainRPS, 1200),
+		GlobalDomainRPS:                        dc.GetIntPropertyFilteredByDomain(dynamicconfig.FrontendGlobalDomainRPS, 0),
+		MaxIDLengthLimit:                       dc.GetIntProperty(dynamicconfig.MaxIDLengthLimit, 1000),
+		HistoryMgrNumConns:                     dc.GetIntProperty(dynamicconfig.FrontendHistoryMgrNumConns, 10),
+		MaxDecisionStartToCloseTimeout:         dc.GetIntPropertyFilteredByDomain(dynamicconfig.MaxDecisionStartToCloseTimeout, 600),
+		MaxBadBinaries:                         dc.GetIntPropertyFilteredByDomain(dynamicconfig.FrontendMaxBadBinaries, 10),
+		EnableAdminProtection:                  dc.GetBoolProperty(dynamicconfig.EnableAdminProtection, false),
+		AdminOperationToken:                    dc.GetStringProperty(dynamicconfig.AdminOperationToken, common.DefaultAdminOperationToken),
+		DisableListVisibilityByFilter:          dc.GetBoolPropertyFnWithDomainFilter(dynamicconfig.DisableListVisibilityByFilter, false),
+		BlobSizeLimitError:                     dc.GetIntPropertyFilteredByDomain(dynamicconfig.BlobSizeLimitError, 2*1024*1024),
+		BlobSizeLimitWarn:                      dc.GetIntPropertyFilteredByDomain(dynamicconfig.BlobSizeLimitWarn, 256*1024),
+		ThrottledLogRPS:                        dc.GetIntProperty(dynamicconfig.FrontendThrottledLogRPS, 20),
+		EnableDomainNotActiveAutoForwarding:    dc.GetBoolPropertyFnWithDomainFilter(dynamicconfig.EnableDomainNotActiveAutoForwarding, false),
+		EnableStandbyReadForwarding:            dc.GetBoolPropertyFnWithDomainFilter(dynamicconfig.EnableStandbyReadForwarding, false),
+		StandbyReadForwardingTimeout:           dc.GetDurationPropertyFilteredByDomain(dynamicconfig.StandbyReadForwardingTimeout, 5*time.Second),
+		EnableClientVersionCheck:               dc.GetBoolProperty(dynamicconfig.EnableClientVersionCheck, false),
+		ValidSearchAttributes:                  dc.GetMapProperty(dynamicconfig.ValidSearchAttributes, definition.GetDefaultIndexedKeys()),
+		SearchAttributesNumberOfKeysLimit:      dc.GetIntPropertyFilteredByDomain(dynamicconfig.SearchAttributesNumberOfKeysLimit, 100),
+		SearchAttributesSizeOfValueLimit:       dc.GetIntPropertyFilteredByDomain(dynamicconfig.SearchAttributesSizeOfValueLimit, 2*1024),
+		SearchAttributesTotalSizeLimit:         dc.GetIntPropertyFilteredByDomain(dynamicconfig.SearchAttributesTotalSizeLimit, 40*1024),
+		MinRetentionDays:                       dc.GetIntProperty(dynamicconfig.MinRetentionDays, 1),
+		StickyQueryTimeout:                     dc.GetDurationPropertyFilteredByDomain(dynamicconfig.StickyQueryTimeout, 5*time.Second),
+		StickyQueryPollerLivenessWindow:        dc.GetDurationPropertyFilteredByDomain(dynamicconfig.StickyQueryPollerLivenessWindow, 2*time.Minute),
+		StickyQueryPollerCheckTimeout:          dc.GetDurationPropertyFilteredByDomain(dynamicconfig.StickyQueryPollerCheckTimeout, 500*time.Millisecond),
+		MetadataCacheTTL:                       dc.GetDurationProperty(dynamicconfig.FrontendMetadataCacheTTL, 5*time.Second),
+		MetadataCacheMaxSize:                   dc.GetIntProperty(dynamicconfig.FrontendMetadataCacheMaxSize, 1000),
 	}
 }
 
@@ -126,6 +164,7 @@ type Service struct {
 // NewService builds a new cadence-frontend service
 func NewService(params *service.BootstrapParams) common.Daemon {
 	config := NewConfig(dynamicconfig.NewCollection(params.DynamicConfig, params.Logger), params.PersistenceConfig.NumHistoryShards, params.ESConfig.Enable)
+	config.ReadOnly = params.PersistenceConfig.ReadOnly
 	params.ThrottledLogger = loggerimpl.NewThrottledLogger(params.Logger, config.ThrottledLogRPS)
 	params.UpdateLoggerWithServiceName(common.FrontendServiceName)
 	return &Service{
@@ -149,9 +188,11 @@ func (s *Service) Start() {
 	pConfig.HistoryMaxConns = s.config.HistoryMgrNumConns()
 	pConfig.SetMaxQPS(pConfig.DefaultStore, s.config.PersistenceMaxQPS())
 	pConfig.VisibilityConfig = &config.VisibilityConfig{
-		VisibilityListMaxQPS:            s.config.VisibilityListMaxQPS,
-		EnableSampling:                  s.config.EnableVisibilitySampling,
-		EnableReadFromClosedExecutionV2: s.config.EnableReadFromClosedExecutionV2,
+		VisibilityListMaxQPS:                   s.config.VisibilityListMaxQPS,
+		EnableSampling:                         s.config.EnableVisibilitySampling,
+		EnableReadFromClosedExecutionV2:        s.config.EnableReadFromClosedExecutionV2,
+		EnableReadFromOpenExecutionsByDomain:   s.config.EnableReadFromOpenExecutionsByDomain,
+		EnableReadFromClosedExecutionsByFilter: s.config.EnableReadFromClosedExecutionsByFilter,
 	}
 	pFactory := persistencefactory.New(&pConfig, params.ClusterMetadata.GetCurrentClusterName(), base.GetMetricsClient(), log)
 
@@ -178,6 +219,21 @@ func (s *Service) Start() {
 			nil, base.GetMetricsClient(), log)
 	}
 	visibility := persistence.NewVisibilityManagerWrapper(visibilityFromDB, visibilityFromES, s.config.EnableReadVisibilityFromES)
+	// the purge of an execution can't read back the deletion of the advanced visibility stores
+	asyncVisibilityStores := map[string]string{}
+	if visibilityFromES != nil {
+		asyncVisibilityStores["elasticsearch"] = "the record is deleted asynchronously through kafka"
+	}
+	if params.PinotConfig != nil && params.PinotConfig.Enable {
+		asyncVisibilityStores["pinot"] = "pinot keeps the record until the retention of its table expires"
+		visibilityConfigForPinot := &config.VisibilityConfig{
+			MaxQPS:               s.config.PersistenceMaxQPS,
+			VisibilityListMaxQPS: s.config.PinotVisibilityListMaxQPS,
+		}
+		visibilityFromPinot := pinotpersistence.NewPinotVisibilityManager(params.PinotConfig.Table, params.PinotClient,
+			visibilityConfigForPinot, nil, base.GetMetricsClient(), log)
+		visibility = persistence.NewVisibilityManagerWrapper(visibility, visibilityFromPinot, s.config.EnableReadVisibilityFromPinot)
+	}
 
 	history, err := pFactory.NewHistoryManager()
 	if err != nil {
@@ -187,6 +243,14 @@ func (s *Service) Start() {
 	if err != nil {
 		log.Fatal("Creating historyV2 manager persistence failed", tag.Error(err))
 	}
+	schedules, err := pFactory.NewScheduleManager()
+	if err != nil {
+		log.Fatal("Creating schedule manager persistence failed", tag.Error(err))
+	}
+	shardMgr, err := pFactory.NewShardManager()
+	if err != nil {
+		log.Fatal("Creating shard manager persistence failed", tag.Error(err))
+	}
 
 	// TODO when global domain is enabled, uncomment the line below and remove the line after
 	var kafkaProducer messaging.Producer
@@ -200,11 +264,31 @@ func (s *Service) Start() {
 	}
 
 	metricsBlobstore := blobstore.NewMetricClient(params.BlobstoreClient, base.GetMetricsClient())
-	wfHandler := NewWorkflowHandler(base, s.config, metadata, history, historyV2, visibility, kafkaProducer, metricsBlobstore, params.ArchiverProvider)
+	authenticator := authorization.NewNopAuthenticator()
+	if params.Authentication.Enabled() {
+		authenticator = authorization.NewJWTAuthenticator(&params.Authentication, log)
+	}
+	authorizer := params.Authorizer
+	if authorizer == nil {
+		authorizer = authorization.NewNopAuthorizer()
+	}
+	auditSink, err := params.Audit.NewSink(log, base.GetMessagingClient(), func() (audit.Sink, error) {
+		auditMgr, err := pFactory.NewAuditManager()
+		if err != nil {
+			return nil, err
+		}
+		return newAuditStoreSink(auditMgr), nil
+	})
+	if err != nil {
+		log.Fatal("Creating audit sink failed", tag.Error(err))
+	}
+	wfHandler := NewWorkflowHandler(base, s.config, metadata, history, historyV2, visibility, pFactory, kafkaProducer, metricsBlobstore,
+		params.ArchiverProvider, authenticator, authorizer, auditSink, params.BlobKeyProvider)
 	dcRedirectionHandler := NewDCRedirectionHandler(wfHandler, params.DCRedirectionPolicy)
 	dcRedirectionHandler.RegisterHandler()
 
-	adminHandler := NewAdminHandler(base, pConfig.NumHistoryShards, metadata, history, historyV2)
+	adminHandler := NewAdminHandler(base, pConfig.NumHistoryShards, pConfig.ReadOnly, metadata, history, historyV2, visibility,
+		asyncVisibilityStores, schedules, shardMgr, pFactory, metricsBlobstore, authenticator, auditSink)
 	adminHandler.RegisterHandler()
 
 	// must start base service first
@@ -225,6 +309,7 @@ func (s *Service) Start() {
 	<-s.stopC
 
 	base.Stop()
+	auditSink.Close()
 }
 
 // Stop stops the service
@@ -0,0 +1,109 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package frontend
+
+import (
+	gen "github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/cache"
+	"github.com/uber/cadence/common/persistence"
+)
+
+// applyTimeoutPolicy returns the timeout to use for a requested timeout: the default of the domain if the timeout
+// is not set, the maximum of the domain if the timeout is above it, and the requested timeout otherwise
+func applyTimeoutPolicy(timeout *int32, defaultTimeout int32, maxTimeout int32) *int32 {
+	if timeout == nil || *timeout <= 0 {
+		if defaultTimeout > 0 {
+			return common.Int32Ptr(defaultTimeout)
+		}
+		return timeout
+	}
+	if maxTimeout > 0 && *timeout > maxTimeout {
+		return common.Int32Ptr(maxTimeout)
+	}
+	return timeout
+}
+
+// applyActivityTimeoutPolicy sets the schedule to close timeout of an activity scheduled without timeouts to
+// the default of the domain, and lowers the timeouts of the activity above the maximum of the domain to it
+func applyActivityTimeoutPolicy(policy persistence.DomainTimeoutPolicy, attributes *gen.ScheduleActivityTaskDecisionAttributes) {
+	if attributes.GetScheduleToCloseTimeoutSeconds() <= 0 &&
+		attributes.GetScheduleToStartTimeoutSeconds() <= 0 &&
+		attributes.GetStartToCloseTimeoutSeconds() <= 0 {
+		attributes.ScheduleToCloseTimeoutSeconds = applyTimeoutPolicy(attributes.ScheduleToCloseTimeoutSeconds, policy.DefaultActivityTimeoutSeconds, 0)
+	}
+	maxTimeout := policy.MaxActivityTimeoutSeconds
+	attributes.ScheduleToCloseTimeoutSeconds = applyTimeoutPolicy(attributes.ScheduleToCloseTimeoutSeconds, 0, maxTimeout)
+	attributes.ScheduleToStartTimeoutSeconds = applyTimeoutPolicy(attributes.ScheduleToStartTimeoutSeconds, 0, maxTimeout)
+	attributes.StartToCloseTimeoutSeconds = applyTimeoutPolicy(attributes.StartToCloseTimeoutSeconds, 0, maxTimeout)
+	attributes.HeartbeatTimeoutSeconds = applyTimeoutPolicy(attributes.HeartbeatTimeoutSeconds, 0, maxTimeout)
+}
+
+// applyDecisionsTimeoutPolicy applies the timeout policies of the domains targeted by the decisions of a workflow
+// to the activities and workflows they start, the decisions targeting an unknown domain are left for history to
+// reject
+func (wh *WorkflowHandler) applyDecisionsTimeoutPolicy(domainEntry *cache.DomainCacheEntry, decisions []*gen.Decision) {
+	policyOf := func(domainName string) (persistence.DomainTimeoutPolicy, bool) {
+		if domainName == "" || domainName == domainEntry.GetInfo().Name {
+			return domainEntry.GetConfig().TimeoutPolicy, true
+		}
+		targetEntry, err := wh.domainCache.GetDomain(domainName)
+		if err != nil {
+			return persistence.DomainTimeoutPolicy{}, false
+		}
+		return targetEntry.GetConfig().TimeoutPolicy, true
+	}
+
+	for _, decision := range decisions {
+		switch decision.GetDecisionType() {
+		case gen.DecisionTypeScheduleActivityTask:
+			attributes := decision.ScheduleActivityTaskDecisionAttributes
+			if attributes == nil {
+				continue
+			}
+			if policy, ok := policyOf(attributes.GetDomain()); ok {
+				applyActivityTimeoutPolicy(policy, attributes)
+			}
+		case gen.DecisionTypeStartChildWorkflowExecution:
+			attributes := decision.StartChildWorkflowExecutionDecisionAttributes
+			if attributes == nil {
+				continue
+			}
+			if policy, ok := policyOf(attributes.GetDomain()); ok {
+				attributes.ExecutionStartToCloseTimeoutSeconds = applyTimeoutPolicy(attributes.ExecutionStartToCloseTimeoutSeconds,
+					policy.DefaultExecutionTimeoutSeconds, policy.MaxExecutionTimeoutSeconds)
+				attributes.TaskStartToCloseTimeoutSeconds = applyTimeoutPolicy(attributes.TaskStartToCloseTimeoutSeconds,
+					policy.DefaultTaskTimeoutSeconds, policy.MaxTaskTimeoutSeconds)
+			}
+		case gen.DecisionTypeContinueAsNewWorkflowExecution:
+			attributes := decision.ContinueAsNewWorkflowExecutionDecisionAttributes
+			if attributes == nil {
+				continue
+			}
+			// the timeouts not set are inherited from the current run, only the maxima apply
+			policy := domainEntry.GetConfig().TimeoutPolicy
+			attributes.ExecutionStartToCloseTimeoutSeconds = applyTimeoutPolicy(attributes.ExecutionStartToCloseTimeoutSeconds,
+				0, policy.MaxExecutionTimeoutSeconds)
+			attributes.TaskStartToCloseTimeoutSeconds = applyTimeoutPolicy(attributes.TaskStartToCloseTimeoutSeconds,
+				0, policy.MaxTaskTimeoutSeconds)
+		}
+	}
+}
@@ -0,0 +1,73 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package frontend
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/persistence"
+)
+
+type (
+	timeoutPolicySuite struct {
+		suite.Suite
+	}
+)
+
+func TestTimeoutPolicySuite(t *testing.T) {
+	s := new(timeoutPolicySuite)
+	suite.Run(t, s)
+}
+
+func (s *timeoutPolicySuite) TestApplyTimeoutPolicy() {
+	s.Nil(applyTimeoutPolicy(nil, 0, 100))
+	s.Equal(int32(10), *applyTimeoutPolicy(nil, 10, 100))
+	s.Equal(int32(10), *applyTimeoutPolicy(common.Int32Ptr(0), 10, 100))
+	s.Equal(int32(50), *applyTimeoutPolicy(common.Int32Ptr(50), 10, 100))
+	s.Equal(int32(100), *applyTimeoutPolicy(common.Int32Ptr(500), 10, 100))
+	s.Equal(int32(500), *applyTimeoutPolicy(common.Int32Ptr(500), 10, 0))
+}
+
+func (s *timeoutPolicySuite) TestApplyActivityTimeoutPolicy() {
+	policy := persistence.DomainTimeoutPolicy{
+		DefaultActivityTimeoutSeconds: 30,
+		MaxActivityTimeoutSeconds:     60,
+	}
+
+	attributes := &shared.ScheduleActivityTaskDecisionAttributes{}
+	applyActivityTimeoutPolicy(policy, attributes)
+	s.Equal(int32(30), attributes.GetScheduleToCloseTimeoutSeconds())
+	s.Nil(attributes.StartToCloseTimeoutSeconds)
+
+	attributes = &shared.ScheduleActivityTaskDecisionAttributes{
+		ScheduleToStartTimeoutSeconds: common.Int32Ptr(10),
+		StartToCloseTimeoutSeconds:    common.Int32Ptr(120),
+		HeartbeatTimeoutSeconds:       common.Int32Ptr(90),
+	}
+	applyActivityTimeoutPolicy(policy, attributes)
+	s.Nil(attributes.ScheduleToCloseTimeoutSeconds)
+	s.Equal(int32(10), attributes.GetScheduleToStartTimeoutSeconds())
+	s.Equal(int32(60), attributes.GetStartToCloseTimeoutSeconds())
+	s.Equal(int32(60), attributes.GetHeartbeatTimeoutSeconds())
+}
@@ -39,8 +39,11 @@ import (
 	"github.com/uber/cadence/client/matching"
 	"github.com/uber/cadence/common"
 	"github.com/uber/cadence/common/archiver/provider"
+	"github.com/uber/cadence/common/audit"
+	"github.com/uber/cadence/common/authorization"
 	"github.com/uber/cadence/common/backoff"
 	"github.com/uber/cadence/common/blobstore"
+	"github.com/uber/cadence/common/blobstore/blob"
 	"github.com/uber/cadence/common/cache"
 	"github.com/uber/cadence/common/client"
 	"github.com/uber/cadence/common/clock"
@@ -57,6 +60,13 @@ import (
 	"go.uber.org/yarpc/yarpcerrors"
 )
 
+const (
+	// anonymousCaller identifies callers without bearer token
+	anonymousCaller = "anonymous"
+	// executionNotesSizeLimit is the max size of the notes operators can attach to a workflow execution
+	executionNotesSizeLimit = 4 * 1024
+)
+
 var _ workflowserviceserver.Interface = (*WorkflowHandler)(nil)
 
 type (
@@ -67,6 +77,7 @@ type (
 		historyMgr                persistence.HistoryManager
 		historyV2Mgr              persistence.HistoryV2Manager
 		visibilityMgr             persistence.VisibilityManager
+		executionMgrFactory       persistence.ExecutionManagerFactory
 		history                   history.Client
 		matching                  matching.Client
 		matchingRawClient         matching.Client
@@ -74,6 +85,7 @@ type (
 		metricsClient             metrics.Client
 		startWG                   sync.WaitGroup
 		rateLimiter               quotas.Policy
+		domainRateLimiter         quotas.DomainPolicy
 		config                    *Config
 		blobstoreClient           blobstore.Client
 		versionChecker            *versionChecker
@@ -82,6 +94,11 @@ type (
 		searchAttributesValidator *validator.SearchAttributesValidator
 		historyBlobDownloader     archiver.HistoryBlobDownloader
 		archiverProvider          provider.ArchiverProvider
+		authenticator             authorization.Authenticator
+		authorizer                authorization.Authorizer
+		auditSink                 audit.Sink
+		metadataCache             *metadataCache
+		pingCache                 *persistence.PingCache
 		service.Service
 	}
 
@@ -104,6 +121,7 @@ type (
 
 var (
 	errDomainNotSet                               = &gen.BadRequestError{Message: "Domain not set on request."}
+	errInvalidDomainID                            = &gen.BadRequestError{Message: "Invalid DomainId."}
 	errTaskTokenNotSet                            = &gen.BadRequestError{Message: "Task token not set on request."}
 	errInvalidTaskToken                           = &gen.BadRequestError{Message: "Invalid TaskToken."}
 	errInvalidRequestType                         = &gen.BadRequestError{Message: "Invalid request type."}
@@ -113,6 +131,8 @@ var (
 	errWorkflowIDNotSet                           = &gen.BadRequestError{Message: "WorkflowId is not set on request."}
 	errRunIDNotSet                                = &gen.BadRequestError{Message: "RunId is not set on request."}
 	errActivityIDNotSet                           = &gen.BadRequestError{Message: "ActivityID is not set on request."}
+	errHostAddressNotSet                          = &gen.BadRequestError{Message: "HostAddress is not set on request."}
+	errExecutionNotesTooLarge                     = &gen.BadRequestError{Message: "Notes exceed the size limit."}
 	errInvalidRunID                               = &gen.BadRequestError{Message: "Invalid RunId."}
 	errInvalidNextPageToken                       = &gen.BadRequestError{Message: "Invalid NextPageToken."}
 	errNextPageTokenRunIDMismatch                 = &gen.BadRequestError{Message: "RunID in the request does not match the NextPageToken."}
@@ -124,8 +144,14 @@ var (
 	errWorkflowTypeNotSet                         = &gen.BadRequestError{Message: "WorkflowType is not set on request."}
 	errInvalidExecutionStartToCloseTimeoutSeconds = &gen.BadRequestError{Message: "A valid ExecutionStartToCloseTimeoutSeconds is not set on request."}
 	errInvalidTaskStartToCloseTimeoutSeconds      = &gen.BadRequestError{Message: "A valid TaskStartToCloseTimeoutSeconds is not set on request."}
+	errInvalidSlaDeadlineTimestamp                = &gen.BadRequestError{Message: "SlaDeadlineTimestamp cannot be negative."}
+	errEventTypeFilterWithRawHistory              = &gen.BadRequestError{Message: "EventTypeFilter cannot be used along RawHistory."}
 	errClientVersionNotSet                        = &gen.BadRequestError{Message: "Client version is not set on request."}
 	errInvalidRetentionPeriod                     = &gen.BadRequestError{Message: "A valid retention period is not set on request."}
+	errInvalidRetentionRunCount                   = &gen.BadRequestError{Message: "Retention run count cannot be negative."}
+	errInvalidTimeoutPolicy                       = &gen.BadRequestError{Message: "Timeout policy values cannot be negative and defaults cannot be larger than maxima."}
+	errInvalidEncryptionKeyAlias                  = &gen.BadRequestError{Message: "Encryption key alias cannot contain ':' or ','."}
+	errEncryptionNotConfigured                    = &gen.BadRequestError{Message: "Encryption key alias cannot be set, no encryption key is configured on the cluster."}
 
 	// err for archival
 	errHistoryHasPassedRetentionPeriod = &gen.BadRequestError{Message: "Requested workflow history has passed retention period."}
@@ -135,6 +161,7 @@ var (
 	errWorkflowTypeTooLong = &gen.BadRequestError{Message: "WorkflowType length exceeds limit."}
 	errWorkflowIDTooLong   = &gen.BadRequestError{Message: "WorkflowID length exceeds limit."}
 	errSignalNameTooLong   = &gen.BadRequestError{Message: "SignalName length exceeds limit."}
+	errUpdateNameTooLong   = &gen.BadRequestError{Message: "UpdateName length exceeds limit."}
 	errTaskListTooLong     = &gen.BadRequestError{Message: "TaskList length exceeds limit."}
 	errRequestIDTooLong    = &gen.BadRequestError{Message: "RequestID length exceeds limit."}
 	errIdentityTooLong     = &gen.BadRequestError{Message: "Identity length exceeds limit."}
@@ -146,27 +173,34 @@ var (
 	errActiveClusterNotInClusters      = &gen.BadRequestError{Message: "Active cluster is not contained in all clusters."}
 	errCannotDoDomainFailoverAndUpdate = &gen.BadRequestError{Message: "Cannot set active cluster to current cluster when other parameters are set."}
 
+	// err indicating that this cluster serves a restored snapshot of the datastores
+	errReadOnlyCluster = &gen.BadRequestError{Message: "Cluster is read only, writes are rejected."}
+	// err indicating that the request needs the history or matching service, which a read only cluster does not run
+	errReadOnlyClusterNotServed = &gen.BadRequestError{Message: "Cluster is read only, requests served by the history or matching service are rejected."}
+
 	frontendServiceRetryPolicy = common.CreateFrontendServiceRetryPolicy()
 )
 
 // NewWorkflowHandler creates a thrift handler for the cadence service
 func NewWorkflowHandler(sVice service.Service, config *Config, metadataMgr persistence.MetadataManager,
 	historyMgr persistence.HistoryManager, historyV2Mgr persistence.HistoryV2Manager,
-	visibilityMgr persistence.VisibilityManager, kafkaProducer messaging.Producer,
-	blobstoreClient blobstore.Client, archiverProvider provider.ArchiverProvider) *WorkflowHandler {
+	visibilityMgr persistence.VisibilityManager,
+	executionMgrFactory persistence.ExecutionManagerFactory, kafkaProducer messaging.Producer, blobstoreClient blobstore.Client, archiverProvider provider.ArchiverProvider,
+	authenticator authorization.Authenticator, authorizer authorization.Authorizer, auditSink audit.Sink, blobKeyProvider blob.KeyProvider) *WorkflowHandler {
 	handler := &WorkflowHandler{
-		Service:         sVice,
-		config:          config,
-		metadataMgr:     metadataMgr,
-		historyMgr:      historyMgr,
-		historyV2Mgr:    historyV2Mgr,
-		visibilityMgr:   visibilityMgr,
-		tokenSerializer: common.NewJSONTaskTokenSerializer(),
-		metricsClient:   sVice.GetMetricsClient(),
-		domainCache:     cache.NewDomainCache(metadataMgr, sVice.GetClusterMetadata(), sVice.GetMetricsClient(), sVice.GetLogger()),
-		rateLimiter:     quotas.NewSimpleRateLimiter(tokenbucket.NewDynamicTokenBucket(config.RPS, clock.NewRealTimeSource())),
-		blobstoreClient: blobstoreClient,
-		versionChecker:  &versionChecker{checkVersion: config.EnableClientVersionCheck()},
+		Service:             sVice,
+		config:              config,
+		metadataMgr:         metadataMgr,
+		historyMgr:          historyMgr,
+		historyV2Mgr:        historyV2Mgr,
+		visibilityMgr:       visibilityMgr,
+		executionMgrFactory: executionMgrFactory,
+		tokenSerializer:     sVice.GetTaskTokenSerializer(),
+		metricsClient:       sVice.GetMetricsClient(),
+		domainCache:         cache.NewDomainCache(metadataMgr, sVice.GetClusterMetadata(), sVice.GetMetricsClient(), sVice.GetLogger()),
+		rateLimiter:         quotas.NewSimpleRateLimiter(tokenbucket.NewDynamicTokenBucket(config.RPS, clock.NewRealTimeSource())),
+		blobstoreClient:     blobstoreClient,
+		versionChecker:      &versionChecker{checkVersion: config.EnableClientVersionCheck()},
 		domainHandler: newDomainHandler(
 			config,
 			sVice.GetLogger(),
@@ -174,13 +208,20 @@ func NewWorkflowHandler(sVice service.Service, config *Config, metadataMgr persi
 			sVice.GetClusterMetadata(),
 			blobstoreClient,
 			NewDomainReplicator(kafkaProducer, sVice.GetLogger()),
+			blobKeyProvider,
 		),
 		visibilityQueryValidator: validator.NewQueryValidator(config.ValidSearchAttributes),
 		searchAttributesValidator: validator.NewSearchAttributesValidator(sVice.GetLogger(), config.ValidSearchAttributes,
 			config.SearchAttributesNumberOfKeysLimit, config.SearchAttributesSizeOfValueLimit, config.SearchAttributesTotalSizeLimit),
-		historyBlobDownloader: archiver.NewHistoryBlobDownloader(blobstoreClient),
+		historyBlobDownloader: archiver.NewHistoryBlobDownloader(blobstoreClient, blobKeyProvider),
 		archiverProvider:      archiverProvider,
+		authenticator:         authenticator,
+		authorizer:            authorizer,
+		auditSink:             auditSink,
+		metadataCache:         newMetadataCache(config.MetadataCacheMaxSize(), config.MetadataCacheTTL, clock.NewRealTimeSource()),
 	}
+	handler.domainRateLimiter = quotas.NewDomainRateLimiter(handler.getDomainRPS, clock.NewRealTimeSource())
+	handler.pingCache = persistence.NewPingCache(persistence.DefaultPingCacheTTL, clock.NewRealTimeSource(), metadataMgr.Ping)
 	// prevent us from trying to serve requests before handler's Start() is complete
 	handler.startWG.Add(1)
 	return handler
@@ -218,6 +259,11 @@ func (wh *WorkflowHandler) Stop() {
 func (wh *WorkflowHandler) Health(ctx context.Context) (*health.HealthStatus, error) {
 	wh.startWG.Wait()
 	wh.GetLogger().Debug("Frontend health check endpoint reached.")
+	if err := wh.pingCache.Ping(); err != nil {
+		wh.GetLogger().Warn("Frontend health check failed to reach persistence.", tag.Error(err))
+		hs := &health.HealthStatus{Ok: false, Msg: common.StringPtr("frontend persistence unreachable")}
+		return hs, nil
+	}
 	hs := &health.HealthStatus{Ok: true, Msg: common.StringPtr("frontend good")}
 	return hs, nil
 }
@@ -231,11 +277,18 @@ func (wh *WorkflowHandler) RegisterDomain(ctx context.Context, registerRequest *
 
 	scope, sw := wh.startRequestProfile(metrics.FrontendRegisterDomainScope)
 	defer sw.Stop()
+	defer func() {
+		wh.audit(ctx, "RegisterDomain", registerRequest.GetName(), registerRequest, retError)
+	}()
 
 	if err := wh.versionChecker.checkClientVersion(ctx); err != nil {
 		return wh.error(err, scope)
 	}
 
+	if wh.config.ReadOnly {
+		return wh.error(errReadOnlyCluster, scope)
+	}
+
 	err := wh.domainHandler.registerDomain(ctx, registerRequest)
 	if err != nil {
 		return wh.error(err, scope)
@@ -274,13 +327,50 @@ func (wh *WorkflowHandler) DescribeDomain(ctx context.Context,
 		return nil, wh.error(err, scope)
 	}
 
-	resp, err := wh.domainHandler.describeDomain(ctx, describeRequest)
+	resp, err := wh.describeDomainWithCache(ctx, describeRequest, scope)
 	if err != nil {
 		return resp, wh.error(err, scope)
 	}
 	return resp, err
 }
 
+// describeDomainWithCache serves DescribeDomain from the metadata cache, as long as the domain cache still
+// reports the notification version the response was cached with. Requests setting both name and UUID
+// are passed through, so the store keeps rejecting them.
+func (wh *WorkflowHandler) describeDomainWithCache(ctx context.Context,
+	describeRequest *gen.DescribeDomainRequest, scope metrics.Scope) (*gen.DescribeDomainResponse, error) {
+	if !wh.metadataCache.enabled() || describeRequest == nil ||
+		(describeRequest.GetName() == "") == (describeRequest.GetUUID() == "") {
+		return wh.domainHandler.describeDomain(ctx, describeRequest)
+	}
+
+	var entry *cache.DomainCacheEntry
+	var err error
+	if describeRequest.GetName() != "" {
+		entry, err = wh.domainCache.GetDomain(describeRequest.GetName())
+	} else {
+		entry, err = wh.domainCache.GetDomainByID(describeRequest.GetUUID())
+	}
+	if err != nil {
+		// let the domain handler surface the error from the store
+		return wh.domainHandler.describeDomain(ctx, describeRequest)
+	}
+
+	name := entry.GetInfo().Name
+	scope.IncCounter(metrics.CacheRequests)
+	if resp := wh.metadataCache.getDescribeDomain(name, entry.GetNotificationVersion()); resp != nil {
+		return resp, nil
+	}
+	scope.IncCounter(metrics.CacheMissCounter)
+
+	resp, err := wh.domainHandler.describeDomain(ctx, describeRequest)
+	if err != nil {
+		return nil, err
+	}
+	wh.metadataCache.putDescribeDomain(name, entry.GetNotificationVersion(), resp)
+	return resp, nil
+}
+
 // UpdateDomain is used to update the information and configuration for a registered domain.
 func (wh *WorkflowHandler) UpdateDomain(ctx context.Context,
 	updateRequest *gen.UpdateDomainRequest) (resp *gen.UpdateDomainResponse, retError error) {
@@ -288,15 +378,23 @@ func (wh *WorkflowHandler) UpdateDomain(ctx context.Context,
 
 	scope, sw := wh.startRequestProfile(metrics.FrontendUpdateDomainScope)
 	defer sw.Stop()
+	defer func() {
+		wh.audit(ctx, updateDomainAuditOperation(updateRequest), updateRequest.GetName(), updateRequest, retError)
+	}()
 
 	if err := wh.versionChecker.checkClientVersion(ctx); err != nil {
 		return nil, wh.error(err, scope)
 	}
 
+	if wh.config.ReadOnly {
+		return nil, wh.error(errReadOnlyCluster, scope)
+	}
+
 	resp, err := wh.domainHandler.updateDomain(ctx, updateRequest)
 	if err != nil {
 		return resp, wh.error(err, scope)
 	}
+	wh.metadataCache.invalidateDomain(updateRequest.GetName())
 	return resp, err
 }
 
@@ -308,15 +406,23 @@ func (wh *WorkflowHandler) DeprecateDomain(ctx context.Context, deprecateRequest
 
 	scope, sw := wh.startRequestProfile(metrics.FrontendDeprecateDomainScope)
 	defer sw.Stop()
+	defer func() {
+		wh.audit(ctx, "DeprecateDomain", deprecateRequest.GetName(), deprecateRequest, retError)
+	}()
 
 	if err := wh.versionChecker.checkClientVersion(ctx); err != nil {
 		return wh.error(err, scope)
 	}
 
+	if wh.config.ReadOnly {
+		return wh.error(errReadOnlyCluster, scope)
+	}
+
 	err := wh.domainHandler.deprecateDomain(ctx, deprecateRequest)
 	if err != nil {
 		return wh.error(err, scope)
 	}
+	wh.metadataCache.invalidateDomain(deprecateRequest.GetName())
 	return err
 }
 
@@ -335,11 +441,15 @@ func (wh *WorkflowHandler) PollForActivityTask(
 		return nil, wh.error(err, scope)
 	}
 
+	if wh.config.ReadOnly {
+		return nil, wh.error(errReadOnlyCluster, scope)
+	}
+
 	if pollRequest == nil {
 		return nil, wh.error(errRequestNotSet, scope)
 	}
 
-	if ok := wh.rateLimiter.Allow(); !ok {
+	if ok := wh.allow(pollRequest); !ok {
 		return nil, wh.error(createServiceBusyError(), scope)
 	}
 
@@ -414,11 +524,15 @@ func (wh *WorkflowHandler) PollForDecisionTask(
 		return nil, wh.error(err, scope)
 	}
 
+	if wh.config.ReadOnly {
+		return nil, wh.error(errReadOnlyCluster, scope)
+	}
+
 	if pollRequest == nil {
 		return nil, wh.error(errRequestNotSet, scope)
 	}
 
-	if ok := wh.rateLimiter.Allow(); !ok {
+	if ok := wh.allow(pollRequest); !ok {
 		return nil, wh.error(createServiceBusyError(), scope)
 	}
 
@@ -546,6 +660,10 @@ func (wh *WorkflowHandler) RecordActivityTaskHeartbeat(
 		return nil, wh.error(err, scope)
 	}
 
+	if wh.config.ReadOnly {
+		return nil, wh.error(errReadOnlyCluster, scope)
+	}
+
 	if heartbeatRequest == nil {
 		return nil, wh.error(errRequestNotSet, scope)
 	}
@@ -627,6 +745,10 @@ func (wh *WorkflowHandler) RecordActivityTaskHeartbeatByID(
 		return nil, wh.error(err, scope)
 	}
 
+	if wh.config.ReadOnly {
+		return nil, wh.error(errReadOnlyCluster, scope)
+	}
+
 	if heartbeatRequest == nil {
 		return nil, wh.error(errRequestNotSet, scope)
 	}
@@ -733,6 +855,10 @@ func (wh *WorkflowHandler) RespondActivityTaskCompleted(
 		return wh.error(err, scope)
 	}
 
+	if wh.config.ReadOnly {
+		return wh.error(errReadOnlyCluster, scope)
+	}
+
 	if completeRequest == nil {
 		return wh.error(errRequestNotSet, scope)
 	}
@@ -815,6 +941,10 @@ func (wh *WorkflowHandler) RespondActivityTaskCompletedByID(
 		return wh.error(err, scope)
 	}
 
+	if wh.config.ReadOnly {
+		return wh.error(errReadOnlyCluster, scope)
+	}
+
 	if completeRequest == nil {
 		return wh.error(errRequestNotSet, scope)
 	}
@@ -923,6 +1053,10 @@ func (wh *WorkflowHandler) RespondActivityTaskFailed(
 		return wh.error(err, scope)
 	}
 
+	if wh.config.ReadOnly {
+		return wh.error(errReadOnlyCluster, scope)
+	}
+
 	if failedRequest == nil {
 		return wh.error(errRequestNotSet, scope)
 	}
@@ -994,6 +1128,10 @@ func (wh *WorkflowHandler) RespondActivityTaskFailedByID(
 		return wh.error(err, scope)
 	}
 
+	if wh.config.ReadOnly {
+		return wh.error(errReadOnlyCluster, scope)
+	}
+
 	if failedRequest == nil {
 		return wh.error(errRequestNotSet, scope)
 	}
@@ -1090,6 +1228,10 @@ func (wh *WorkflowHandler) RespondActivityTaskCanceled(
 		return wh.error(err, scope)
 	}
 
+	if wh.config.ReadOnly {
+		return wh.error(errReadOnlyCluster, scope)
+	}
+
 	if cancelRequest == nil {
 		return wh.error(errRequestNotSet, scope)
 	}
@@ -1173,6 +1315,10 @@ func (wh *WorkflowHandler) RespondActivityTaskCanceledByID(
 		return wh.error(err, scope)
 	}
 
+	if wh.config.ReadOnly {
+		return wh.error(errReadOnlyCluster, scope)
+	}
+
 	if cancelRequest == nil {
 		return wh.error(errRequestNotSet, scope)
 	}
@@ -1280,6 +1426,10 @@ func (wh *WorkflowHandler) RespondDecisionTaskCompleted(
 		return nil, wh.error(err, scope)
 	}
 
+	if wh.config.ReadOnly {
+		return nil, wh.error(errReadOnlyCluster, scope)
+	}
+
 	if completeRequest == nil {
 		return nil, wh.error(errRequestNotSet, scope)
 	}
@@ -1306,6 +1456,8 @@ func (wh *WorkflowHandler) RespondDecisionTaskCompleted(
 	// add domain tag to scope, so further metrics will have the domain tag
 	scope = scope.Tagged(metrics.DomainTag(domainEntry.GetInfo().Name))
 
+	wh.applyDecisionsTimeoutPolicy(domainEntry, completeRequest.Decisions)
+
 	histResp, err := wh.history.RespondDecisionTaskCompleted(ctx, &h.RespondDecisionTaskCompletedRequest{
 		DomainUUID:      common.StringPtr(taskToken.DomainID),
 		CompleteRequest: completeRequest},
@@ -1325,7 +1477,7 @@ func (wh *WorkflowHandler) RespondDecisionTaskCompleted(
 			WorkflowID:      taskToken.WorkflowID,
 			RunID:           taskToken.RunID,
 			ScheduleID:      histResp.StartedResponse.GetScheduledEventId(),
-			ScheduleAttempt: histResp.StartedResponse.GetAttempt(),
+			ScheduleAttempt: histResp.StartedResponse.GetScheduleAttempt(),
 		}
 		token, _ := wh.tokenSerializer.Serialize(taskToken)
 		workflowExecution := &gen.WorkflowExecution{
@@ -1358,6 +1510,10 @@ func (wh *WorkflowHandler) RespondDecisionTaskFailed(
 		return wh.error(err, scope)
 	}
 
+	if wh.config.ReadOnly {
+		return wh.error(errReadOnlyCluster, scope)
+	}
+
 	if failedRequest == nil {
 		return wh.error(errRequestNotSet, scope)
 	}
@@ -1428,6 +1584,10 @@ func (wh *WorkflowHandler) RespondQueryTaskCompleted(
 		return wh.error(err, scope)
 	}
 
+	if wh.config.ReadOnly {
+		return wh.error(errReadOnlyCluster, scope)
+	}
+
 	if completeRequest == nil {
 		return wh.error(errRequestNotSet, scope)
 	}
@@ -1481,11 +1641,15 @@ func (wh *WorkflowHandler) StartWorkflowExecution(
 		return nil, wh.error(err, scope)
 	}
 
+	if wh.config.ReadOnly {
+		return nil, wh.error(errReadOnlyCluster, scope)
+	}
+
 	if startRequest == nil {
 		return nil, wh.error(errRequestNotSet, scope)
 	}
 
-	if ok := wh.rateLimiter.Allow(); !ok {
+	if ok := wh.allow(startRequest); !ok {
 		return nil, wh.error(createServiceBusyError(), scope)
 	}
 
@@ -1506,6 +1670,10 @@ func (wh *WorkflowHandler) StartWorkflowExecution(
 		return nil, wh.error(errWorkflowIDTooLong, scope)
 	}
 
+	if err := wh.authorize(ctx, "StartWorkflowExecution", domainName, startRequest.GetWorkflowId()); err != nil {
+		return nil, wh.error(err, scope)
+	}
+
 	if err := common.ValidateRetryPolicy(startRequest.RetryPolicy); err != nil {
 		return nil, wh.error(err, scope)
 	}
@@ -1514,6 +1682,10 @@ func (wh *WorkflowHandler) StartWorkflowExecution(
 		return nil, wh.error(err, scope)
 	}
 
+	if err := backoff.ValidateScheduleTimezone(startRequest.GetCronSchedule(), startRequest.GetCronTimezone()); err != nil {
+		return nil, wh.error(err, scope)
+	}
+
 	wh.Service.GetLogger().Debug(
 		"Received StartWorkflowExecution. WorkflowID",
 		tag.WorkflowID(startRequest.GetWorkflowId()))
@@ -1530,6 +1702,16 @@ func (wh *WorkflowHandler) StartWorkflowExecution(
 		return nil, err
 	}
 
+	domainEntry, err := wh.domainCache.GetDomain(domainName)
+	if err != nil {
+		return nil, wh.error(err, scope)
+	}
+	timeoutPolicy := domainEntry.GetConfig().TimeoutPolicy
+	startRequest.ExecutionStartToCloseTimeoutSeconds = applyTimeoutPolicy(startRequest.ExecutionStartToCloseTimeoutSeconds,
+		timeoutPolicy.DefaultExecutionTimeoutSeconds, timeoutPolicy.MaxExecutionTimeoutSeconds)
+	startRequest.TaskStartToCloseTimeoutSeconds = applyTimeoutPolicy(startRequest.TaskStartToCloseTimeoutSeconds,
+		timeoutPolicy.DefaultTaskTimeoutSeconds, timeoutPolicy.MaxTaskTimeoutSeconds)
+
 	if startRequest.GetExecutionStartToCloseTimeoutSeconds() <= 0 {
 		return nil, wh.error(errInvalidExecutionStartToCloseTimeoutSeconds, scope)
 	}
@@ -1538,6 +1720,10 @@ func (wh *WorkflowHandler) StartWorkflowExecution(
 		return nil, wh.error(errInvalidTaskStartToCloseTimeoutSeconds, scope)
 	}
 
+	if startRequest.GetSlaDeadlineTimestamp() < 0 {
+		return nil, wh.error(errInvalidSlaDeadlineTimestamp, scope)
+	}
+
 	if startRequest.GetRequestId() == "" {
 		return nil, wh.error(errRequestIDNotSet, scope)
 	}
@@ -1628,7 +1814,7 @@ func (wh *WorkflowHandler) GetWorkflowExecutionHistory(
 		return nil, wh.error(errRequestNotSet, scope)
 	}
 
-	if ok := wh.rateLimiter.Allow(); !ok {
+	if ok := wh.allow(getRequest); !ok {
 		return nil, wh.error(createServiceBusyError(), scope)
 	}
 
@@ -1659,6 +1845,12 @@ func (wh *WorkflowHandler) GetWorkflowExecutionHistory(
 		getRequest.MaximumPageSize = common.Int32Ptr(common.GetHistoryMaxPageSize)
 	}
 
+	// raw history batches are forwarded as persisted, so they cannot be filtered by event type
+	eventTypeFilter := getRequest.GetEventTypeFilter()
+	if len(eventTypeFilter) != 0 && getRequest.GetRawHistory() {
+		return nil, wh.error(errEventTypeFilterWithRawHistory, scope)
+	}
+
 	configuredForArchival := wh.GetClusterMetadata().ArchivalConfig().ConfiguredForArchival()
 	enableArchivalRead := wh.GetClusterMetadata().ArchivalConfig().EnableReadFromArchival()
 	historyArchived := wh.historyArchived(ctx, getRequest, domainID)
@@ -1673,6 +1865,9 @@ func (wh *WorkflowHandler) GetWorkflowExecutionHistory(
 	// 4. whether the workflow is closed
 	// 5. error if any
 	queryHistory := func(domainUUID string, execution *gen.WorkflowExecution, expectedNextEventID int64) (int32, []byte, string, int64, int64, bool, error) {
+		if wh.config.ReadOnly {
+			return wh.getHistoryStateFromPersistence(domainUUID, execution)
+		}
 		response, err := wh.history.GetMutableState(ctx, &h.GetMutableStateRequest{
 			DomainUUID:          common.StringPtr(domainUUID),
 			Execution:           execution,
@@ -1742,9 +1937,11 @@ func (wh *WorkflowHandler) GetWorkflowExecutionHistory(
 
 	history := &gen.History{}
 	history.Events = []*gen.HistoryEvent{}
+	var rawHistory []*gen.DataBlob
 	if isCloseEventOnly {
 		if !isWorkflowRunning {
 			history, _, err = wh.getHistory(
+				ctx,
 				scope,
 				domainID,
 				*execution,
@@ -1776,8 +1973,32 @@ func (wh *WorkflowHandler) GetWorkflowExecutionHistory(
 			if !isWorkflowRunning {
 				token = nil
 			}
+		} else if token.EventStoreVersion == persistence.EventStoreVersionV2 && getRequest.GetRawHistory() {
+			// only clients asking for raw history can decode it, the batches are forwarded as persisted
+			// so that large histories are not deserialized and held in memory as events by frontend
+			rawHistory, token.PersistenceToken, err = wh.getRawHistory(
+				ctx,
+				scope,
+				domainID,
+				*execution,
+				token.FirstEventID,
+				token.NextEventID,
+				getRequest.GetMaximumPageSize(),
+				wh.config.RawHistoryMaxPageBytes(getRequest.GetDomain()),
+				token.PersistenceToken,
+				token.TransientDecision,
+				token.BranchToken,
+			)
+			if err != nil {
+				return nil, wh.error(err, scope)
+			}
+
+			if len(token.PersistenceToken) == 0 && (!token.IsWorkflowRunning || !isLongPoll) {
+				token = nil
+			}
 		} else {
 			history, token.PersistenceToken, err = wh.getHistory(
+				ctx,
 				scope,
 				domainID,
 				*execution,
@@ -1802,12 +2023,15 @@ func (wh *WorkflowHandler) GetWorkflowExecutionHistory(
 		}
 	}
 
+	history.Events = filterHistoryEvents(history.Events, eventTypeFilter)
+
 	nextToken, err := serializeHistoryToken(token)
 	if err != nil {
 		return nil, wh.error(err, scope)
 	}
 	return &gen.GetWorkflowExecutionHistoryResponse{
 		History:       history,
+		RawHistory:    rawHistory,
 		NextPageToken: nextToken,
 		Archived:      common.BoolPtr(false),
 	}, nil
@@ -1826,11 +2050,15 @@ func (wh *WorkflowHandler) SignalWorkflowExecution(ctx context.Context,
 		return wh.error(err, scope)
 	}
 
+	if wh.config.ReadOnly {
+		return wh.error(errReadOnlyCluster, scope)
+	}
+
 	if signalRequest == nil {
 		return wh.error(errRequestNotSet, scope)
 	}
 
-	if ok := wh.rateLimiter.Allow(); !ok {
+	if ok := wh.allow(signalRequest); !ok {
 		return wh.error(createServiceBusyError(), scope)
 	}
 
@@ -1846,6 +2074,11 @@ func (wh *WorkflowHandler) SignalWorkflowExecution(ctx context.Context,
 		return err
 	}
 
+	if err := wh.authorize(ctx, "SignalWorkflowExecution", signalRequest.GetDomain(),
+		signalRequest.WorkflowExecution.GetWorkflowId()); err != nil {
+		return wh.error(err, scope)
+	}
+
 	if signalRequest.GetSignalName() == "" {
 		return wh.error(&gen.BadRequestError{Message: "SignalName is not set on request."}, scope)
 	}
@@ -1889,6 +2122,91 @@ func (wh *WorkflowHandler) SignalWorkflowExecution(ctx context.Context,
 	return nil
 }
 
+// UpdateWorkflowExecution is used to send a synchronous update to a running workflow execution.  The update is
+// delivered to the worker on the next decision task and the call blocks until the worker responds with the result.
+func (wh *WorkflowHandler) UpdateWorkflowExecution(ctx context.Context,
+	updateRequest *gen.UpdateWorkflowExecutionRequest) (resp *gen.UpdateWorkflowExecutionResponse, retError error) {
+	defer log.CapturePanic(wh.GetLogger(), &retError)
+
+	scope, sw := wh.startRequestProfileWithDomain(metrics.FrontendUpdateWorkflowExecutionScope, updateRequest)
+	defer sw.Stop()
+
+	if err := wh.versionChecker.checkClientVersion(ctx); err != nil {
+		return nil, wh.error(err, scope)
+	}
+
+	if wh.config.ReadOnly {
+		return nil, wh.error(errReadOnlyCluster, scope)
+	}
+
+	if updateRequest == nil {
+		return nil, wh.error(errRequestNotSet, scope)
+	}
+
+	if ok := wh.allow(updateRequest); !ok {
+		return nil, wh.error(createServiceBusyError(), scope)
+	}
+
+	if updateRequest.GetDomain() == "" {
+		return nil, wh.error(errDomainNotSet, scope)
+	}
+
+	if len(updateRequest.GetDomain()) > wh.config.MaxIDLengthLimit() {
+		return nil, wh.error(errDomainTooLong, scope)
+	}
+
+	if err := wh.validateExecutionAndEmitMetrics(updateRequest.WorkflowExecution, scope); err != nil {
+		return nil, err
+	}
+
+	if err := wh.authorize(ctx, "UpdateWorkflowExecution", updateRequest.GetDomain(),
+		updateRequest.WorkflowExecution.GetWorkflowId()); err != nil {
+		return nil, wh.error(err, scope)
+	}
+
+	if updateRequest.GetUpdateName() == "" {
+		return nil, wh.error(&gen.BadRequestError{Message: "UpdateName is not set on request."}, scope)
+	}
+
+	if len(updateRequest.GetUpdateName()) > wh.config.MaxIDLengthLimit() {
+		return nil, wh.error(errUpdateNameTooLong, scope)
+	}
+
+	if len(updateRequest.GetRequestId()) > wh.config.MaxIDLengthLimit() {
+		return nil, wh.error(errRequestIDTooLong, scope)
+	}
+
+	domainID, err := wh.domainCache.GetDomainID(updateRequest.GetDomain())
+	if err != nil {
+		return nil, wh.error(err, scope)
+	}
+
+	sizeLimitError := wh.config.BlobSizeLimitError(updateRequest.GetDomain())
+	sizeLimitWarn := wh.config.BlobSizeLimitWarn(updateRequest.GetDomain())
+	if err := common.CheckEventBlobSizeLimit(
+		len(updateRequest.Input),
+		sizeLimitWarn,
+		sizeLimitError,
+		domainID,
+		updateRequest.GetWorkflowExecution().GetWorkflowId(),
+		updateRequest.GetWorkflowExecution().GetWorkflowId(),
+		scope,
+		wh.GetThrottledLogger(),
+	); err != nil {
+		return nil, wh.error(err, scope)
+	}
+
+	resp, err = wh.history.UpdateWorkflowExecution(ctx, &h.UpdateWorkflowExecutionRequest{
+		DomainUUID:    common.StringPtr(domainID),
+		UpdateRequest: updateRequest,
+	})
+	if err != nil {
+		return nil, wh.error(err, scope)
+	}
+
+	return resp, nil
+}
+
 // SignalWithStartWorkflowExecution is used to ensure sending a signal event to a workflow execution.
 // If workflow is running, this results in WorkflowExecutionSignaled event recorded in the history
 // and a decision task being created for the execution.
@@ -1905,11 +2223,15 @@ func (wh *WorkflowHandler) SignalWithStartWorkflowExecution(ctx context.Context,
 		return nil, wh.error(err, scope)
 	}
 
+	if wh.config.ReadOnly {
+		return nil, wh.error(errReadOnlyCluster, scope)
+	}
+
 	if signalWithStartRequest == nil {
 		return nil, wh.error(errRequestNotSet, scope)
 	}
 
-	if ok := wh.rateLimiter.Allow(); !ok {
+	if ok := wh.allow(signalWithStartRequest); !ok {
 		return nil, wh.error(createServiceBusyError(), scope)
 	}
 
@@ -1930,6 +2252,10 @@ func (wh *WorkflowHandler) SignalWithStartWorkflowExecution(ctx context.Context,
 		return nil, wh.error(errWorkflowIDTooLong, scope)
 	}
 
+	if err := wh.authorize(ctx, "SignalWithStartWorkflowExecution", domainName, signalWithStartRequest.GetWorkflowId()); err != nil {
+		return nil, wh.error(err, scope)
+	}
+
 	if signalWithStartRequest.GetSignalName() == "" {
 		return nil, wh.error(&gen.BadRequestError{Message: "SignalName is not set on request."}, scope)
 	}
@@ -1954,6 +2280,16 @@ func (wh *WorkflowHandler) SignalWithStartWorkflowExecution(ctx context.Context,
 		return nil, wh.error(errRequestIDTooLong, scope)
 	}
 
+	domainEntry, err := wh.domainCache.GetDomain(domainName)
+	if err != nil {
+		return nil, wh.error(err, scope)
+	}
+	timeoutPolicy := domainEntry.GetConfig().TimeoutPolicy
+	signalWithStartRequest.ExecutionStartToCloseTimeoutSeconds = applyTimeoutPolicy(signalWithStartRequest.ExecutionStartToCloseTimeoutSeconds,
+		timeoutPolicy.DefaultExecutionTimeoutSeconds, timeoutPolicy.MaxExecutionTimeoutSeconds)
+	signalWithStartRequest.TaskStartToCloseTimeoutSeconds = applyTimeoutPolicy(signalWithStartRequest.TaskStartToCloseTimeoutSeconds,
+		timeoutPolicy.DefaultTaskTimeoutSeconds, timeoutPolicy.MaxTaskTimeoutSeconds)
+
 	if signalWithStartRequest.GetExecutionStartToCloseTimeoutSeconds() <= 0 {
 		return nil, wh.error(&gen.BadRequestError{
 			Message: "A valid ExecutionStartToCloseTimeoutSeconds is not set on request."}, scope)
@@ -1964,6 +2300,10 @@ func (wh *WorkflowHandler) SignalWithStartWorkflowExecution(ctx context.Context,
 			Message: "A valid TaskStartToCloseTimeoutSeconds is not set on request."}, scope)
 	}
 
+	if signalWithStartRequest.GetSlaDeadlineTimestamp() < 0 {
+		return nil, wh.error(errInvalidSlaDeadlineTimestamp, scope)
+	}
+
 	if err := common.ValidateRetryPolicy(signalWithStartRequest.RetryPolicy); err != nil {
 		return nil, wh.error(err, scope)
 	}
@@ -1972,6 +2312,10 @@ func (wh *WorkflowHandler) SignalWithStartWorkflowExecution(ctx context.Context,
 		return nil, wh.error(err, scope)
 	}
 
+	if err := backoff.ValidateScheduleTimezone(signalWithStartRequest.GetCronSchedule(), signalWithStartRequest.GetCronTimezone()); err != nil {
+		return nil, wh.error(err, scope)
+	}
+
 	if err := wh.searchAttributesValidator.ValidateSearchAttributes(signalWithStartRequest.SearchAttributes, domainName); err != nil {
 		return nil, wh.error(err, scope)
 	}
@@ -2063,11 +2407,15 @@ func (wh *WorkflowHandler) TerminateWorkflowExecution(ctx context.Context,
 		return wh.error(err, scope)
 	}
 
+	if wh.config.ReadOnly {
+		return wh.error(errReadOnlyCluster, scope)
+	}
+
 	if terminateRequest == nil {
 		return wh.error(errRequestNotSet, scope)
 	}
 
-	if ok := wh.rateLimiter.Allow(); !ok {
+	if ok := wh.allow(terminateRequest); !ok {
 		return wh.error(createServiceBusyError(), scope)
 	}
 
@@ -2079,6 +2427,11 @@ func (wh *WorkflowHandler) TerminateWorkflowExecution(ctx context.Context,
 		return err
 	}
 
+	if err := wh.authorize(ctx, "TerminateWorkflowExecution", terminateRequest.GetDomain(),
+		terminateRequest.WorkflowExecution.GetWorkflowId()); err != nil {
+		return wh.error(err, scope)
+	}
+
 	domainID, err := wh.domainCache.GetDomainID(terminateRequest.GetDomain())
 	if err != nil {
 		return wh.error(err, scope)
@@ -2108,11 +2461,15 @@ func (wh *WorkflowHandler) ResetWorkflowExecution(ctx context.Context,
 		return nil, wh.error(err, scope)
 	}
 
+	if wh.config.ReadOnly {
+		return nil, wh.error(errReadOnlyCluster, scope)
+	}
+
 	if resetRequest == nil {
 		return nil, wh.error(errRequestNotSet, scope)
 	}
 
-	if ok := wh.rateLimiter.Allow(); !ok {
+	if ok := wh.allow(resetRequest); !ok {
 		return nil, wh.error(createServiceBusyError(), scope)
 	}
 
@@ -2153,11 +2510,15 @@ func (wh *WorkflowHandler) RequestCancelWorkflowExecution(
 		return wh.error(err, scope)
 	}
 
+	if wh.config.ReadOnly {
+		return wh.error(errReadOnlyCluster, scope)
+	}
+
 	if cancelRequest == nil {
 		return wh.error(errRequestNotSet, scope)
 	}
 
-	if ok := wh.rateLimiter.Allow(); !ok {
+	if ok := wh.allow(cancelRequest); !ok {
 		return wh.error(createServiceBusyError(), scope)
 	}
 
@@ -2201,7 +2562,7 @@ func (wh *WorkflowHandler) ListOpenWorkflowExecutions(ctx context.Context,
 		return nil, wh.error(errRequestNotSet, scope)
 	}
 
-	if ok := wh.rateLimiter.Allow(); !ok {
+	if ok := wh.allow(listRequest); !ok {
 		return nil, wh.error(createServiceBusyError(), scope)
 	}
 
@@ -2308,7 +2669,7 @@ func (wh *WorkflowHandler) ListClosedWorkflowExecutions(ctx context.Context,
 		return nil, wh.error(errRequestNotSet, scope)
 	}
 
-	if ok := wh.rateLimiter.Allow(); !ok {
+	if ok := wh.allow(listRequest); !ok {
 		return nil, wh.error(createServiceBusyError(), scope)
 	}
 
@@ -2433,7 +2794,7 @@ func (wh *WorkflowHandler) ListWorkflowExecutions(ctx context.Context, listReque
 		return nil, wh.error(errRequestNotSet, scope)
 	}
 
-	if ok := wh.rateLimiter.Allow(); !ok {
+	if ok := wh.allow(listRequest); !ok {
 		return nil, wh.error(createServiceBusyError(), scope)
 	}
 
@@ -2493,7 +2854,7 @@ func (wh *WorkflowHandler) ScanWorkflowExecutions(ctx context.Context, listReque
 		return nil, wh.error(errRequestNotSet, scope)
 	}
 
-	if ok := wh.rateLimiter.Allow(); !ok {
+	if ok := wh.allow(listRequest); !ok {
 		return nil, wh.error(createServiceBusyError(), scope)
 	}
 
@@ -2553,7 +2914,7 @@ func (wh *WorkflowHandler) CountWorkflowExecutions(ctx context.Context, countReq
 		return nil, wh.error(errRequestNotSet, scope)
 	}
 
-	if ok := wh.rateLimiter.Allow(); !ok {
+	if ok := wh.allow(countRequest); !ok {
 		return nil, wh.error(createServiceBusyError(), scope)
 	}
 
@@ -2598,11 +2959,24 @@ func (wh *WorkflowHandler) GetSearchAttributes(ctx context.Context) (resp *gen.G
 		return nil, wh.error(err, scope)
 	}
 
+	if !wh.metadataCache.enabled() {
+		return wh.getSearchAttributesResponse(), nil
+	}
+	scope.IncCounter(metrics.CacheRequests)
+	if resp = wh.metadataCache.getSearchAttributes(); resp != nil {
+		return resp, nil
+	}
+	scope.IncCounter(metrics.CacheMissCounter)
+	resp = wh.getSearchAttributesResponse()
+	wh.metadataCache.putSearchAttributes(resp)
+	return resp, nil
+}
+
+func (wh *WorkflowHandler) getSearchAttributesResponse() *gen.GetSearchAttributesResponse {
 	keys := wh.config.ValidSearchAttributes()
-	resp = &gen.GetSearchAttributesResponse{
+	return &gen.GetSearchAttributesResponse{
 		Keys: wh.convertIndexedKeyToThrift(keys),
 	}
-	return resp, nil
 }
 
 // ResetStickyTaskList reset the volatile information in mutable state of a given workflow.
@@ -2616,6 +2990,10 @@ func (wh *WorkflowHandler) ResetStickyTaskList(ctx context.Context, resetRequest
 		return nil, wh.error(err, scope)
 	}
 
+	if wh.config.ReadOnly {
+		return nil, wh.error(errReadOnlyCluster, scope)
+	}
+
 	if resetRequest == nil {
 		return nil, wh.error(errRequestNotSet, scope)
 	}
@@ -2658,6 +3036,10 @@ func (wh *WorkflowHandler) QueryWorkflow(ctx context.Context,
 		return nil, wh.error(err, scope)
 	}
 
+	if wh.config.ReadOnly {
+		return nil, wh.error(errReadOnlyClusterNotServed, scope)
+	}
+
 	if queryRequest == nil {
 		return nil, wh.error(errRequestNotSet, scope)
 	}
@@ -2704,25 +3086,30 @@ func (wh *WorkflowHandler) QueryWorkflow(ctx context.Context,
 
 	queryRequest.Execution.RunId = response.Execution.RunId
 	if len(response.StickyTaskList.GetName()) != 0 && clientFeature.SupportStickyQuery() {
-		matchingRequest.TaskList = response.StickyTaskList
-		stickyDecisionTimeout := response.GetStickyTaskListScheduleToStartTimeout()
-		// using a clean new context in case customer provide a context which has
-		// a really short deadline, causing we clear the stickyness
-		stickyContext, cancel := context.WithTimeout(context.Background(), time.Duration(stickyDecisionTimeout)*time.Second)
-		matchingResp, err := wh.matchingRawClient.QueryWorkflow(stickyContext, matchingRequest)
-		cancel()
-		if err == nil {
-			return matchingResp, nil
-		}
-		if yarpcError, ok := err.(*yarpcerrors.Status); !ok || yarpcError.Code() != yarpcerrors.CodeDeadlineExceeded {
-			wh.Service.GetLogger().Info("QueryWorkflowFailed.",
-				tag.WorkflowDomainName(queryRequest.GetDomain()),
-				tag.WorkflowID(queryRequest.Execution.GetWorkflowId()),
-				tag.WorkflowRunID(queryRequest.Execution.GetRunId()),
-				tag.WorkflowQueryType(queryRequest.Query.GetQueryType()))
-			return nil, wh.error(err, scope)
+		if wh.isStickyPollerAlive(domainID, queryRequest.GetDomain(), response.StickyTaskList) {
+			matchingRequest.TaskList = response.StickyTaskList
+			stickyTimeout := time.Duration(response.GetStickyTaskListScheduleToStartTimeout()) * time.Second
+			if maxStickyTimeout := wh.config.StickyQueryTimeout(queryRequest.GetDomain()); stickyTimeout > maxStickyTimeout {
+				stickyTimeout = maxStickyTimeout
+			}
+			// using a clean new context in case customer provide a context which has
+			// a really short deadline, causing we clear the stickyness
+			stickyContext, cancel := context.WithTimeout(context.Background(), stickyTimeout)
+			matchingResp, err := wh.matchingRawClient.QueryWorkflow(stickyContext, matchingRequest)
+			cancel()
+			if err == nil {
+				return matchingResp, nil
+			}
+			if yarpcError, ok := err.(*yarpcerrors.Status); !ok || yarpcError.Code() != yarpcerrors.CodeDeadlineExceeded {
+				wh.Service.GetLogger().Info("QueryWorkflowFailed.",
+					tag.WorkflowDomainName(queryRequest.GetDomain()),
+					tag.WorkflowID(queryRequest.Execution.GetWorkflowId()),
+					tag.WorkflowRunID(queryRequest.Execution.GetRunId()),
+					tag.WorkflowQueryType(queryRequest.Query.GetQueryType()))
+				return nil, wh.error(err, scope)
+			}
 		}
-		// this means sticky timeout, should try using the normal tasklist
+		// this means either sticky timeout or no live sticky poller, should try using the normal tasklist
 		// we should clear the stickyness of this workflow
 		resetContext, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 		_, err = wh.history.ResetStickyTaskList(resetContext, &h.ResetStickyTaskListRequest{
@@ -2749,6 +3136,33 @@ func (wh *WorkflowHandler) QueryWorkflow(ctx context.Context,
 	return matchingResp, nil
 }
 
+// isStickyPollerAlive checks whether any poller has recently polled the sticky tasklist,
+// a query dispatched to a sticky tasklist without live poller will only timeout
+func (wh *WorkflowHandler) isStickyPollerAlive(domainID string, domainName string, stickyTaskList *gen.TaskList) bool {
+	describeContext, cancel := context.WithTimeout(context.Background(), wh.config.StickyQueryPollerCheckTimeout(domainName))
+	defer cancel()
+	resp, err := wh.matchingRawClient.DescribeTaskList(describeContext, &m.DescribeTaskListRequest{
+		DomainUUID: common.StringPtr(domainID),
+		DescRequest: &gen.DescribeTaskListRequest{
+			Domain:       common.StringPtr(domainName),
+			TaskList:     stickyTaskList,
+			TaskListType: common.TaskListTypePtr(gen.TaskListTypeDecision),
+		},
+	})
+	if err != nil {
+		// unable to tell, fallback to the sticky query with timeout
+		return true
+	}
+
+	livenessThreshold := time.Now().Add(-wh.config.StickyQueryPollerLivenessWindow(domainName)).UnixNano()
+	for _, poller := range resp.Pollers {
+		if poller.GetLastAccessTime() >= livenessThreshold {
+			return true
+		}
+	}
+	return false
+}
+
 // DescribeWorkflowExecution returns information about the specified workflow execution.
 func (wh *WorkflowHandler) DescribeWorkflowExecution(ctx context.Context, request *gen.DescribeWorkflowExecutionRequest) (resp *gen.DescribeWorkflowExecutionResponse, retError error) {
 	defer log.CapturePanic(wh.GetLogger(), &retError)
@@ -2764,7 +3178,7 @@ func (wh *WorkflowHandler) DescribeWorkflowExecution(ctx context.Context, reques
 		return nil, wh.error(errRequestNotSet, scope)
 	}
 
-	if ok := wh.rateLimiter.Allow(); !ok {
+	if ok := wh.allow(request); !ok {
 		return nil, wh.error(createServiceBusyError(), scope)
 	}
 
@@ -2780,11 +3194,15 @@ func (wh *WorkflowHandler) DescribeWorkflowExecution(ctx context.Context, reques
 		return nil, err
 	}
 
-	response, err := wh.history.DescribeWorkflowExecution(ctx, &h.DescribeWorkflowExecutionRequest{
-		DomainUUID: common.StringPtr(domainID),
-		Request:    request,
-	})
-
+	var response *gen.DescribeWorkflowExecutionResponse
+	if wh.config.ReadOnly {
+		response, err = wh.describeWorkflowExecutionFromPersistence(domainID, request.Execution)
+	} else {
+		response, err = wh.history.DescribeWorkflowExecution(ctx, &h.DescribeWorkflowExecutionRequest{
+			DomainUUID: common.StringPtr(domainID),
+			Request:    request,
+		})
+	}
 	if err != nil {
 		return nil, wh.error(err, scope)
 	}
@@ -2805,11 +3223,15 @@ func (wh *WorkflowHandler) DescribeTaskList(ctx context.Context, request *gen.De
 		return nil, wh.error(err, scope)
 	}
 
+	if wh.config.ReadOnly {
+		return nil, wh.error(errReadOnlyClusterNotServed, scope)
+	}
+
 	if request == nil {
 		return nil, wh.error(errRequestNotSet, scope)
 	}
 
-	if ok := wh.rateLimiter.Allow(); !ok {
+	if ok := wh.allow(request); !ok {
 		return nil, wh.error(createServiceBusyError(), scope)
 	}
 
@@ -2847,7 +3269,69 @@ func (wh *WorkflowHandler) DescribeTaskList(ctx context.Context, request *gen.De
 	return response, nil
 }
 
+// GetTaskListScalingHints returns the backlog and dispatch rate of a tasklist as observed by the server,
+// for autoscalers and worker tuners to scale the pollers of the tasklist.
+func (wh *WorkflowHandler) GetTaskListScalingHints(
+	ctx context.Context,
+	request *gen.GetTaskListScalingHintsRequest,
+) (resp *gen.GetTaskListScalingHintsResponse, retError error) {
+	defer log.CapturePanic(wh.GetLogger(), &retError)
+
+	scope, sw := wh.startRequestProfileWithDomain(metrics.FrontendGetTaskListScalingHintsScope, request)
+	defer sw.Stop()
+
+	if err := wh.versionChecker.checkClientVersion(ctx); err != nil {
+		return nil, wh.error(err, scope)
+	}
+
+	if wh.config.ReadOnly {
+		return nil, wh.error(errReadOnlyClusterNotServed, scope)
+	}
+
+	if request == nil {
+		return nil, wh.error(errRequestNotSet, scope)
+	}
+
+	if ok := wh.allow(request); !ok {
+		return nil, wh.error(createServiceBusyError(), scope)
+	}
+
+	if request.GetDomain() == "" {
+		return nil, wh.error(errDomainNotSet, scope)
+	}
+	domainID, err := wh.domainCache.GetDomainID(request.GetDomain())
+	if err != nil {
+		return nil, wh.error(err, scope)
+	}
+
+	if err := wh.validateTaskList(request.TaskList, scope); err != nil {
+		return nil, err
+	}
+
+	if err := wh.validateTaskListType(request.TaskListType, scope); err != nil {
+		return nil, err
+	}
+
+	var response *gen.GetTaskListScalingHintsResponse
+	op := func() error {
+		var err error
+		response, err = wh.matching.GetTaskListScalingHints(ctx, &m.GetTaskListScalingHintsRequest{
+			DomainUUID:   common.StringPtr(domainID),
+			HintsRequest: request,
+		})
+		return err
+	}
+
+	err = backoff.Retry(op, frontendServiceRetryPolicy, common.IsServiceTransientError)
+	if err != nil {
+		return nil, wh.error(err, scope)
+	}
+
+	return response, nil
+}
+
 func (wh *WorkflowHandler) getHistory(
+	ctx context.Context,
 	scope metrics.Scope,
 	domainID string,
 	execution gen.WorkflowExecution,
@@ -2871,6 +3355,7 @@ func (wh *WorkflowHandler) getHistory(
 			PageSize:      int(pageSize),
 			NextPageToken: nextPageToken,
 			ShardID:       common.IntPtr(shardID),
+			Context:       ctx,
 		})
 		if err != nil {
 			return nil, nil, err
@@ -2915,6 +3400,89 @@ func (wh *WorkflowHandler) getHistory(
 	return executionHistory, nextPageToken, nil
 }
 
+// getRawHistory is the counterpart of getHistory returning the history event batches as persisted,
+// only batches with an encoding unknown to clients are re-encoded with thriftrw. The batches are streamed
+// from persistence until the page holds pageSize batches or maxPageBytes bytes.
+func (wh *WorkflowHandler) getRawHistory(
+	ctx context.Context,
+	scope metrics.Scope,
+	domainID string,
+	execution gen.WorkflowExecution,
+	firstEventID, nextEventID int64,
+	pageSize int32,
+	maxPageBytes int,
+	nextPageToken []byte,
+	transientDecision *gen.TransientDecisionInfo,
+	branchToken []byte,
+) ([]*gen.DataBlob, []byte, error) {
+
+	serializer := persistence.NewPayloadSerializer()
+	var rawHistory []*gen.DataBlob
+	shardID := common.WorkflowIDToHistoryShard(*execution.WorkflowId, wh.config.NumHistoryShards)
+	size, nextPageToken, err := persistence.StreamRawHistoryBranch(wh.historyV2Mgr, &persistence.ReadHistoryBranchRequest{
+		BranchToken:   branchToken,
+		MinEventID:    firstEventID,
+		MaxEventID:    nextEventID,
+		PageSize:      int(pageSize),
+		NextPageToken: nextPageToken,
+		ShardID:       common.IntPtr(shardID),
+		Context:       ctx,
+	}, maxPageBytes, func(blob *persistence.DataBlob) error {
+		encodingType := gen.EncodingTypeThriftRW
+		switch blob.GetEncoding() {
+		case common.EncodingTypeThriftRW:
+		case common.EncodingTypeJSON:
+			encodingType = gen.EncodingTypeJSON
+		default:
+			events, err := serializer.DeserializeBatchEvents(blob)
+			if err != nil {
+				return err
+			}
+			blob, err = serializer.SerializeBatchEvents(events, common.EncodingTypeThriftRW)
+			if err != nil {
+				return err
+			}
+		}
+		rawHistory = append(rawHistory, &gen.DataBlob{
+			EncodingType: encodingType.Ptr(),
+			Data:         blob.Data,
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if len(rawHistory) > 0 {
+		scope.RecordTimer(metrics.HistorySize, time.Duration(size))
+
+		if size > common.GetHistoryWarnSizeLimit {
+			wh.GetThrottledLogger().Warn("GetHistory size threshold breached",
+				tag.WorkflowID(execution.GetWorkflowId()),
+				tag.WorkflowRunID(execution.GetRunId()),
+				tag.WorkflowDomainID(domainID),
+				tag.WorkflowSize(int64(size)))
+		}
+	}
+
+	if len(nextPageToken) == 0 && transientDecision != nil {
+		// Append the transient decision events as their own batch once we are done enumerating everything from the events table
+		blob, err := serializer.SerializeBatchEvents(
+			[]*gen.HistoryEvent{transientDecision.ScheduledEvent, transientDecision.StartedEvent},
+			common.EncodingTypeThriftRW,
+		)
+		if err != nil {
+			return nil, nil, err
+		}
+		rawHistory = append(rawHistory, &gen.DataBlob{
+			EncodingType: gen.EncodingTypeThriftRW.Ptr(),
+			Data:         blob.Data,
+		})
+	}
+
+	return rawHistory, nextPageToken, nil
+}
+
 func (wh *WorkflowHandler) getLoggerForTask(taskToken []byte) log.Logger {
 	logger := wh.Service.GetLogger()
 	task, err := wh.tokenSerializer.Deserialize(taskToken)
@@ -2926,6 +3494,72 @@ func (wh *WorkflowHandler) getLoggerForTask(taskToken []byte) log.Logger {
 	return logger
 }
 
+// allow checks the request against the domain level then the host level rate limits, so that the
+// requests of a domain over its limit don't take the host tokens the requests of other domains need
+func (wh *WorkflowHandler) allow(d domainGetter) bool {
+	return wh.domainRateLimiter.Allow(d.GetDomain()) && wh.rateLimiter.Allow()
+}
+
+// authorize authenticates the caller of the API and checks it against the authorizer,
+// calls of authenticated callers are logged so that they can be audited
+func (wh *WorkflowHandler) authorize(ctx context.Context, apiName string, domainName string, workflowID string) error {
+	identity, err := wh.authenticator.Authenticate(ctx)
+	if err != nil {
+		return &gen.AccessDeniedError{Message: err.Error()}
+	}
+
+	result, err := wh.authorizer.Authorize(ctx, &authorization.Attributes{
+		Identity:   identity,
+		APIName:    apiName,
+		DomainName: domainName,
+	})
+	if err != nil {
+		return err
+	}
+
+	caller := anonymousCaller
+	if identity != nil {
+		caller = identity.Subject
+	}
+	if result.Decision != authorization.DecisionAllow {
+		return &gen.AccessDeniedError{Message: fmt.Sprintf("Caller %v is not authorized to call %v on domain %v.", caller, apiName, domainName)}
+	}
+	if identity != nil {
+		wh.GetLogger().Debug("Authorized API call",
+			tag.CallerIdentity(caller),
+			tag.WorkflowHandlerName(apiName),
+			tag.WorkflowDomainName(domainName),
+			tag.WorkflowID(workflowID))
+	}
+	return nil
+}
+
+// audit records a mutating domain API call to the audit sink
+func (wh *WorkflowHandler) audit(ctx context.Context, operation string, domainName string, request interface{}, err error) {
+	recordAudit(ctx, wh.authenticator, wh.auditSink, wh.GetLogger(), operation, domainName, request, err)
+}
+
+// getDomainRPS returns the rate limit of the domain on this host, a cluster wide limit if configured
+// is evenly shared among the frontend hosts in the ring. The usage of the domain on the other hosts is
+// not taken into account, so a host receiving more than its share of the requests throttles them early
+func (wh *WorkflowHandler) getDomainRPS(domain string) int {
+	globalRPS := wh.config.GlobalDomainRPS(domain)
+	if globalRPS <= 0 {
+		return wh.config.DomainRPS(domain)
+	}
+
+	hostCount := 1
+	if resolver, err := wh.GetMembershipMonitor().GetResolver(common.FrontendServiceName); err == nil {
+		if count := resolver.MemberCount(); count > 0 {
+			hostCount = count
+		}
+	}
+	if rps := globalRPS / hostCount; rps > 0 {
+		return rps
+	}
+	return 1
+}
+
 // startRequestProfile initiates recording of request metrics
 func (wh *WorkflowHandler) startRequestProfile(scope int) (metrics.Scope, metrics.Stopwatch) {
 	wh.startWG.Wait()
@@ -2990,6 +3624,9 @@ func (wh *WorkflowHandler) error(err error, scope metrics.Scope) error {
 	case *gen.ClientVersionNotSupportedError:
 		scope.IncCounter(metrics.CadenceErrClientVersionNotSupportedCounter)
 		return err
+	case *gen.AccessDeniedError:
+		scope.IncCounter(metrics.CadenceErrAccessDeniedCounter)
+		return err
 	case *yarpcerrors.Status:
 		if err.Code() == yarpcerrors.CodeDeadlineExceeded {
 			scope.IncCounter(metrics.CadenceErrContextTimeoutCounter)
@@ -3102,6 +3739,7 @@ func (wh *WorkflowHandler) createPollForDecisionTaskResponse(
 		}
 		scope = scope.Tagged(metrics.DomainTag(domain.GetInfo().Name))
 		history, persistenceToken, err = wh.getHistory(
+			ctx,
 			scope,
 			domainID,
 			*matchingResp.WorkflowExecution,
@@ -3145,6 +3783,9 @@ func (wh *WorkflowHandler) createPollForDecisionTaskResponse(
 		WorkflowExecutionTaskList: matchingResp.WorkflowExecutionTaskList,
 		ScheduledTimestamp:        matchingResp.ScheduledTimestamp,
 		StartedTimestamp:          matchingResp.StartedTimestamp,
+		ContinueAsNewSuggested:    matchingResp.ContinueAsNewSuggested,
+		PendingUpdates:            matchingResp.PendingUpdates,
+		ScalingHints:              matchingResp.ScalingHints,
 	}
 
 	return resp, nil
@@ -3183,7 +3824,12 @@ func (wh *WorkflowHandler) historyArchived(ctx context.Context, request *gen.Get
 		DomainUUID: common.StringPtr(domainID),
 		Execution:  request.Execution,
 	}
-	_, err := wh.history.GetMutableState(ctx, getMutableStateRequest)
+	var err error
+	if wh.config.ReadOnly {
+		_, err = wh.getMutableStateFromPersistence(domainID, request.Execution)
+	} else {
+		_, err = wh.history.GetMutableState(ctx, getMutableStateRequest)
+	}
 	if err == nil {
 		return false
 	}
@@ -3220,6 +3866,9 @@ func (wh *WorkflowHandler) getArchivedHistory(
 	if err != nil {
 		return nil, wh.error(err, scope)
 	}
+	if resp.HistoryBlob.Body != nil {
+		resp.HistoryBlob.Body.Events = filterHistoryEvents(resp.HistoryBlob.Body.Events, request.GetEventTypeFilter())
+	}
 	return &gen.GetWorkflowExecutionHistoryResponse{
 		History:       resp.HistoryBlob.Body,
 		NextPageToken: resp.NextPageToken,
@@ -3227,6 +3876,23 @@ func (wh *WorkflowHandler) getArchivedHistory(
 	}, nil
 }
 
+// filterHistoryEvents returns the events whose type is one of eventTypes, all the events when eventTypes is empty
+func filterHistoryEvents(events []*gen.HistoryEvent, eventTypes []gen.EventType) []*gen.HistoryEvent {
+	if len(eventTypes) == 0 {
+		return events
+	}
+	filtered := make([]*gen.HistoryEvent, 0, len(events))
+	for _, event := range events {
+		for _, eventType := range eventTypes {
+			if event.GetEventType() == eventType {
+				filtered = append(filtered, event)
+				break
+			}
+		}
+	}
+	return filtered
+}
+
 func (wh *WorkflowHandler) convertIndexedKeyToThrift(keys map[string]interface{}) map[string]gen.IndexedValueType {
 	converted := make(map[string]gen.IndexedValueType)
 	for k, v := range keys {
@@ -161,6 +161,29 @@ func (_m *MockWorkflowHandler) GetSearchAttributes(ctx context.Context) (*shared
 	return r0, r1
 }
 
+// GetTaskListScalingHints provides a mock function with given fields: ctx, Request
+func (_m *MockWorkflowHandler) GetTaskListScalingHints(ctx context.Context, Request *shared.GetTaskListScalingHintsRequest) (*shared.GetTaskListScalingHintsResponse, error) {
+	ret := _m.Called(ctx, Request)
+
+	var r0 *shared.GetTaskListScalingHintsResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *shared.GetTaskListScalingHintsRequest) *shared.GetTaskListScalingHintsResponse); ok {
+		r0 = rf(ctx, Request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*shared.GetTaskListScalingHintsResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *shared.GetTaskListScalingHintsRequest) error); ok {
+		r1 = rf(ctx, Request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetWorkflowExecutionHistory provides a mock function with given fields: ctx, GetRequest
 func (_m *MockWorkflowHandler) GetWorkflowExecutionHistory(ctx context.Context, GetRequest *shared.GetWorkflowExecutionHistoryRequest) (*shared.GetWorkflowExecutionHistoryResponse, error) {
 	ret := _m.Called(ctx, GetRequest)
@@ -719,3 +742,26 @@ func (_m *MockWorkflowHandler) UpdateDomain(ctx context.Context, UpdateRequest *
 
 	return r0, r1
 }
+
+// UpdateWorkflowExecution provides a mock function with given fields: ctx, UpdateRequest
+func (_m *MockWorkflowHandler) UpdateWorkflowExecution(ctx context.Context, UpdateRequest *shared.UpdateWorkflowExecutionRequest) (*shared.UpdateWorkflowExecutionResponse, error) {
+	ret := _m.Called(ctx, UpdateRequest)
+
+	var r0 *shared.UpdateWorkflowExecutionResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *shared.UpdateWorkflowExecutionRequest) *shared.UpdateWorkflowExecutionResponse); ok {
+		r0 = rf(ctx, UpdateRequest)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*shared.UpdateWorkflowExecutionResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *shared.UpdateWorkflowExecutionRequest) error); ok {
+		r1 = rf(ctx, UpdateRequest)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
@@ -32,14 +32,19 @@ import (
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 	"github.com/uber-go/tally"
+	h "github.com/uber/cadence/.gen/go/history"
+	m "github.com/uber/cadence/.gen/go/matching"
 	"github.com/uber/cadence/.gen/go/shared"
 	gen "github.com/uber/cadence/.gen/go/shared"
 	workflow "github.com/uber/cadence/.gen/go/shared"
 	"github.com/uber/cadence/client"
 	"github.com/uber/cadence/common"
 	"github.com/uber/cadence/common/archiver/provider"
+	"github.com/uber/cadence/common/audit"
+	"github.com/uber/cadence/common/authorization"
 	"github.com/uber/cadence/common/blobstore/blob"
 	"github.com/uber/cadence/common/cache"
+	"github.com/uber/cadence/common/clock"
 	"github.com/uber/cadence/common/cluster"
 	"github.com/uber/cadence/common/log"
 	"github.com/uber/cadence/common/log/loggerimpl"
@@ -50,6 +55,7 @@ import (
 	cs "github.com/uber/cadence/common/service"
 	dc "github.com/uber/cadence/common/service/dynamicconfig"
 	"github.com/uber/cadence/service/worker/archiver"
+	"go.uber.org/yarpc/yarpcerrors"
 )
 
 const (
@@ -63,23 +69,24 @@ const (
 type (
 	workflowHandlerSuite struct {
 		suite.Suite
-		testDomain           string
-		testDomainID         string
-		logger               log.Logger
-		config               *Config
-		mockClusterMetadata  *mocks.ClusterMetadata
-		mockProducer         *mocks.KafkaProducer
-		mockMetricClient     metrics.Client
-		mockMessagingClient  messaging.Client
-		mockMetadataMgr      *mocks.MetadataManager
-		mockHistoryMgr       *mocks.HistoryManager
-		mockHistoryV2Mgr     *mocks.HistoryV2Manager
-		mockVisibilityMgr    *mocks.VisibilityManager
-		mockDomainCache      *cache.DomainCacheMock
-		mockClientBean       *client.MockClientBean
-		mockService          cs.Service
-		mockBlobstoreClient  *mocks.BlobstoreClient
-		mockArchiverProvider *provider.ArchiverProviderMock
+		testDomain              string
+		testDomainID            string
+		logger                  log.Logger
+		config                  *Config
+		mockClusterMetadata     *mocks.ClusterMetadata
+		mockProducer            *mocks.KafkaProducer
+		mockMetricClient        metrics.Client
+		mockMessagingClient     messaging.Client
+		mockMetadataMgr         *mocks.MetadataManager
+		mockHistoryMgr          *mocks.HistoryManager
+		mockHistoryV2Mgr        *mocks.HistoryV2Manager
+		mockVisibilityMgr       *mocks.VisibilityManager
+		mockExecutionMgrFactory *mocks.ExecutionManagerFactory
+		mockDomainCache         *cache.DomainCacheMock
+		mockClientBean          *client.MockClientBean
+		mockService             cs.Service
+		mockBlobstoreClient     *mocks.BlobstoreClient
+		mockArchiverProvider    *provider.ArchiverProviderMock
 	}
 )
 
@@ -107,6 +114,7 @@ func (s *workflowHandlerSuite) SetupTest() {
 	s.mockHistoryMgr = &mocks.HistoryManager{}
 	s.mockHistoryV2Mgr = &mocks.HistoryV2Manager{}
 	s.mockVisibilityMgr = &mocks.VisibilityManager{}
+	s.mockExecutionMgrFactory = &mocks.ExecutionManagerFactory{}
 	s.mockDomainCache = &cache.DomainCacheMock{}
 	s.mockClientBean = &client.MockClientBean{}
 	s.mockService = cs.NewTestService(s.mockClusterMetadata, s.mockMessagingClient, s.mockMetricClient, s.mockClientBean)
@@ -120,6 +128,7 @@ func (s *workflowHandlerSuite) TearDownTest() {
 	s.mockHistoryMgr.AssertExpectations(s.T())
 	s.mockHistoryV2Mgr.AssertExpectations(s.T())
 	s.mockVisibilityMgr.AssertExpectations(s.T())
+	s.mockExecutionMgrFactory.AssertExpectations(s.T())
 	s.mockDomainCache.AssertExpectations(s.T())
 	s.mockClientBean.AssertExpectations(s.T())
 	s.mockBlobstoreClient.AssertExpectations(s.T())
@@ -127,7 +136,8 @@ func (s *workflowHandlerSuite) TearDownTest() {
 
 func (s *workflowHandlerSuite) getWorkflowHandler(config *Config) *WorkflowHandler {
 	return NewWorkflowHandler(s.mockService, config, s.mockMetadataMgr, s.mockHistoryMgr,
-		s.mockHistoryV2Mgr, s.mockVisibilityMgr, s.mockProducer, s.mockBlobstoreClient, s.mockArchiverProvider)
+		s.mockHistoryV2Mgr, s.mockVisibilityMgr, s.mockExecutionMgrFactory, s.mockProducer, s.mockBlobstoreClient, s.mockArchiverProvider,
+		authorization.NewNopAuthenticator(), authorization.NewNopAuthorizer(), audit.NewNopSink(), nil)
 }
 
 func (s *workflowHandlerSuite) getWorkflowHandlerHelper() *WorkflowHandler {
@@ -269,6 +279,22 @@ func (s *workflowHandlerSuite) TestStartWorkflowExecution_Failed_RequestIdNotSet
 	assert.Equal(s.T(), errRequestIDNotSet, err)
 }
 
+func (s *workflowHandlerSuite) TestStartWorkflowExecution_Failed_ReadOnly() {
+	config := s.newConfig()
+	config.ReadOnly = true
+	wh := s.getWorkflowHandler(config)
+	wh.metricsClient = wh.Service.GetMetricsClient()
+	wh.startWG.Done()
+
+	startWorkflowExecutionRequest := &shared.StartWorkflowExecutionRequest{
+		Domain:     common.StringPtr("test-domain"),
+		WorkflowId: common.StringPtr("workflow-id"),
+		RequestId:  common.StringPtr(uuid.New()),
+	}
+	_, err := wh.StartWorkflowExecution(context.Background(), startWorkflowExecutionRequest)
+	assert.Equal(s.T(), errReadOnlyCluster, err)
+}
+
 func (s *workflowHandlerSuite) TestStartWorkflowExecution_Failed_StartRequestNotSet() {
 	config := s.newConfig()
 	config.RPS = dc.GetIntPropertyFn(10)
@@ -412,8 +438,11 @@ func (s *workflowHandlerSuite) TestStartWorkflowExecution_Failed_InvalidExecutio
 	config.RPS = dc.GetIntPropertyFn(10)
 	wh := s.getWorkflowHandler(config)
 	wh.metricsClient = wh.Service.GetMetricsClient()
+	wh.domainCache = s.mockDomainCache
 	wh.startWG.Done()
 
+	s.mockDomainCache.On("GetDomain", "test-domain").Return(s.newTestDomainEntry(persistence.DomainTimeoutPolicy{}), nil)
+
 	startWorkflowExecutionRequest := &shared.StartWorkflowExecutionRequest{
 		Domain:     common.StringPtr("test-domain"),
 		WorkflowId: common.StringPtr("workflow-id"),
@@ -444,8 +473,11 @@ func (s *workflowHandlerSuite) TestStartWorkflowExecution_Failed_InvalidTaskStar
 	config.RPS = dc.GetIntPropertyFn(10)
 	wh := s.getWorkflowHandler(config)
 	wh.metricsClient = wh.Service.GetMetricsClient()
+	wh.domainCache = s.mockDomainCache
 	wh.startWG.Done()
 
+	s.mockDomainCache.On("GetDomain", "test-domain").Return(s.newTestDomainEntry(persistence.DomainTimeoutPolicy{}), nil)
+
 	startWorkflowExecutionRequest := &shared.StartWorkflowExecutionRequest{
 		Domain:     common.StringPtr("test-domain"),
 		WorkflowId: common.StringPtr("workflow-id"),
@@ -475,7 +507,54 @@ func (s *workflowHandlerSuite) getWorkflowHandlerWithParams(mService cs.Service,
 	mMetadataManager persistence.MetadataManager, blobStore *mocks.BlobstoreClient) *WorkflowHandler {
 	s.mockBlobstoreClient = blobStore
 	return NewWorkflowHandler(mService, config, mMetadataManager, s.mockHistoryMgr, s.mockHistoryV2Mgr,
-		s.mockVisibilityMgr, s.mockProducer, blobStore, s.mockArchiverProvider)
+		s.mockVisibilityMgr, s.mockExecutionMgrFactory, s.mockProducer, blobStore, s.mockArchiverProvider,
+		authorization.NewNopAuthenticator(), authorization.NewNopAuthorizer(), audit.NewNopSink(), nil)
+}
+
+func (s *workflowHandlerSuite) TestStartWorkflowExecution_DomainDefaultExecutionStartToCloseTimeout() {
+	config := s.newConfig()
+	config.RPS = dc.GetIntPropertyFn(10)
+	wh := s.getWorkflowHandler(config)
+	wh.metricsClient = wh.Service.GetMetricsClient()
+	wh.domainCache = s.mockDomainCache
+	wh.startWG.Done()
+
+	timeoutPolicy := persistence.DomainTimeoutPolicy{DefaultExecutionTimeoutSeconds: 60}
+	s.mockDomainCache.On("GetDomain", "test-domain").Return(s.newTestDomainEntry(timeoutPolicy), nil)
+
+	startWorkflowExecutionRequest := &shared.StartWorkflowExecutionRequest{
+		Domain:     common.StringPtr("test-domain"),
+		WorkflowId: common.StringPtr("workflow-id"),
+		WorkflowType: &shared.WorkflowType{
+			Name: common.StringPtr("workflow-type"),
+		},
+		TaskList: &shared.TaskList{
+			Name: common.StringPtr("task-list"),
+		},
+		TaskStartToCloseTimeoutSeconds: common.Int32Ptr(0),
+		RequestId:                      common.StringPtr(uuid.New()),
+	}
+	_, err := wh.StartWorkflowExecution(context.Background(), startWorkflowExecutionRequest)
+	// the execution timeout is set to the default of the domain, the task timeout has no default
+	assert.Equal(s.T(), errInvalidTaskStartToCloseTimeoutSeconds, err)
+	assert.Equal(s.T(), int32(60), startWorkflowExecutionRequest.GetExecutionStartToCloseTimeoutSeconds())
+}
+
+func (s *workflowHandlerSuite) TestStartWorkflowExecution_Failed_NotAuthorized() {
+	config := s.newConfig()
+	config.RPS = dc.GetIntPropertyFn(10)
+	wh := s.getWorkflowHandler(config)
+	wh.metricsClient = wh.Service.GetMetricsClient()
+	wh.authorizer = &denyAuthorizer{}
+	wh.startWG.Done()
+
+	startWorkflowExecutionRequest := &shared.StartWorkflowExecutionRequest{
+		Domain:     common.StringPtr("test-domain"),
+		WorkflowId: common.StringPtr("workflow-id"),
+	}
+	_, err := wh.StartWorkflowExecution(context.Background(), startWorkflowExecutionRequest)
+	assert.Error(s.T(), err)
+	assert.IsType(s.T(), &shared.AccessDeniedError{}, err)
 }
 
 func (s *workflowHandlerSuite) TestRegisterDomain_Failure_BucketNotExists() {
@@ -891,6 +970,64 @@ func (s *workflowHandlerSuite) TestUpdateDomain_Success_ArchivalNeverEnabledToEn
 	assert.Equal(s.T(), "custom-bucket", result.GetConfiguration().GetArchivalBucketName())
 }
 
+func (s *workflowHandlerSuite) TestDescribeDomain_ServedFromMetadataCache() {
+	config := s.newConfig()
+	clusterMetadata := &mocks.ClusterMetadata{}
+	clusterMetadata.On("ArchivalConfig").Return(cluster.NewArchivalConfig(cluster.ArchivalDisabled, "", false))
+	mMetadataManager := &mocks.MetadataManager{}
+	domainResponse := persistenceGetDomainResponse("", shared.ArchivalStatusDisabled)
+	mMetadataManager.On("GetDomain", mock.Anything).Return(domainResponse, nil).Once()
+	mService := cs.NewTestService(clusterMetadata, s.mockMessagingClient, s.mockMetricClient, s.mockClientBean)
+	wh := s.getWorkflowHandlerWithParams(mService, config, mMetadataManager, s.mockBlobstoreClient)
+	wh.metricsClient = wh.Service.GetMetricsClient()
+	wh.domainCache = s.mockDomainCache
+	wh.startWG.Done()
+
+	entry := cache.NewLocalDomainCacheEntryForTest(domainResponse.Info, domainResponse.Config, cluster.TestCurrentClusterName, nil)
+	s.mockDomainCache.On("GetDomain", domainResponse.Info.Name).Return(entry, nil).Times(2)
+
+	req := &shared.DescribeDomainRequest{
+		Name: common.StringPtr(domainResponse.Info.Name),
+	}
+	first, err := wh.DescribeDomain(context.Background(), req)
+	s.NoError(err)
+	second, err := wh.DescribeDomain(context.Background(), req)
+	s.NoError(err)
+	s.Equal(first, second)
+	mMetadataManager.AssertExpectations(s.T())
+}
+
+func (s *workflowHandlerSuite) TestMetadataCache() {
+	timeSource := clock.NewEventTimeSource().Update(time.Now())
+	ttl := time.Minute
+	metadataCache := newMetadataCache(10, func(...dc.FilterOption) time.Duration { return ttl }, timeSource)
+	s.True(metadataCache.enabled())
+
+	response := &shared.DescribeDomainResponse{}
+	s.Nil(metadataCache.getDescribeDomain(s.testDomain, 1))
+	metadataCache.putDescribeDomain(s.testDomain, 1, response)
+	s.Equal(response, metadataCache.getDescribeDomain(s.testDomain, 1))
+
+	// a new notification version drops the cached response
+	s.Nil(metadataCache.getDescribeDomain(s.testDomain, 2))
+	s.Nil(metadataCache.getDescribeDomain(s.testDomain, 1))
+
+	metadataCache.putDescribeDomain(s.testDomain, 2, response)
+	metadataCache.invalidateDomain(s.testDomain)
+	s.Nil(metadataCache.getDescribeDomain(s.testDomain, 2))
+
+	searchAttributes := &shared.GetSearchAttributesResponse{}
+	metadataCache.putSearchAttributes(searchAttributes)
+	metadataCache.putDescribeDomain(s.testDomain, 2, response)
+	s.Equal(searchAttributes, metadataCache.getSearchAttributes())
+	timeSource.Update(timeSource.Now().Add(ttl + time.Second))
+	s.Nil(metadataCache.getSearchAttributes())
+	s.Nil(metadataCache.getDescribeDomain(s.testDomain, 2))
+
+	ttl = 0
+	s.False(metadataCache.enabled())
+}
+
 func (s *workflowHandlerSuite) TestHistoryArchived() {
 	wh := &WorkflowHandler{}
 	getHistoryRequest := &shared.GetWorkflowExecutionHistoryRequest{}
@@ -1095,6 +1232,7 @@ func (s *workflowHandlerSuite) TestGetHistory() {
 		PageSize:      0,
 		NextPageToken: []byte{},
 		ShardID:       common.IntPtr(shardID),
+		Context:       context.Background(),
 	}
 	s.mockHistoryV2Mgr.On("ReadHistoryBranch", req).Return(&persistence.ReadHistoryBranchResponse{
 		HistoryEvents: []*workflow.HistoryEvent{
@@ -1113,12 +1251,379 @@ func (s *workflowHandlerSuite) TestGetHistory() {
 	wh := s.getWorkflowHandlerWithParams(mService, config, mMetadataManager, mBlobstore)
 	wh.metricsClient = wh.Service.GetMetricsClient()
 	scope := wh.metricsClient.Scope(0)
-	history, token, err := wh.getHistory(scope, domainID, we, firstEventID, nextEventID, 0, []byte{}, nil, persistence.EventStoreVersionV2, []byte{})
+	history, token, err := wh.getHistory(context.Background(), scope, domainID, we, firstEventID, nextEventID, 0, []byte{}, nil, persistence.EventStoreVersionV2, []byte{})
 	s.NotNil(history)
 	s.Equal([]byte{}, token)
 	s.NoError(err)
 }
 
+func (s *workflowHandlerSuite) TestGetRawHistory() {
+	config := s.newConfig()
+	domainID := uuid.New()
+	firstEventID := int64(1)
+	nextEventID := int64(5)
+	we := gen.WorkflowExecution{
+		WorkflowId: common.StringPtr("wid"),
+		RunId:      common.StringPtr("rid"),
+	}
+	shardID := common.WorkflowIDToHistoryShard(*we.WorkflowId, numHistoryShards)
+	req := &persistence.ReadHistoryBranchRequest{
+		BranchToken:   []byte{},
+		MinEventID:    firstEventID,
+		MaxEventID:    nextEventID,
+		PageSize:      10,
+		NextPageToken: []byte{},
+		ShardID:       common.IntPtr(shardID),
+		Context:       context.Background(),
+	}
+	serializer := persistence.NewPayloadSerializer()
+	thriftBatch := []*workflow.HistoryEvent{{EventId: common.Int64Ptr(1)}, {EventId: common.Int64Ptr(2)}}
+	thriftBlob, err := serializer.SerializeBatchEvents(thriftBatch, common.EncodingTypeThriftRW)
+	s.NoError(err)
+	jsonBatch := []*workflow.HistoryEvent{{EventId: common.Int64Ptr(3)}}
+	jsonBlob, err := serializer.SerializeBatchEvents(jsonBatch, common.EncodingTypeJSON)
+	s.NoError(err)
+	s.mockHistoryV2Mgr.On("ReadRawHistoryBranch", req).Return(&persistence.ReadRawHistoryBranchResponse{
+		HistoryEventBlobs: []*persistence.DataBlob{thriftBlob, jsonBlob},
+		NextPageToken:     []byte{},
+		Size:              len(thriftBlob.Data) + len(jsonBlob.Data),
+	}, nil).Once()
+	transientDecision := &gen.TransientDecisionInfo{
+		ScheduledEvent: &gen.HistoryEvent{EventId: common.Int64Ptr(4)},
+		StartedEvent:   &gen.HistoryEvent{EventId: common.Int64Ptr(5)},
+	}
+
+	clusterMetadata := &mocks.ClusterMetadata{}
+	mService := cs.NewTestService(clusterMetadata, s.mockMessagingClient, s.mockMetricClient, s.mockClientBean)
+	mMetadataManager := &mocks.MetadataManager{}
+	mBlobstore := &mocks.BlobstoreClient{}
+	wh := s.getWorkflowHandlerWithParams(mService, config, mMetadataManager, mBlobstore)
+	wh.metricsClient = wh.Service.GetMetricsClient()
+	scope := wh.metricsClient.Scope(0)
+	rawHistory, token, err := wh.getRawHistory(context.Background(), scope, domainID, we, firstEventID, nextEventID, 10, 1024*1024, []byte{}, transientDecision, []byte{})
+	s.NoError(err)
+	s.Equal([]byte{}, token)
+	s.Len(rawHistory, 3)
+	s.Equal(gen.EncodingTypeThriftRW, rawHistory[0].GetEncodingType())
+	s.Equal(gen.EncodingTypeJSON, rawHistory[1].GetEncodingType())
+	s.Equal(gen.EncodingTypeThriftRW, rawHistory[2].GetEncodingType())
+
+	var eventIDs []int64
+	for _, blob := range rawHistory {
+		encoding := common.EncodingTypeThriftRW
+		if blob.GetEncodingType() == gen.EncodingTypeJSON {
+			encoding = common.EncodingTypeJSON
+		}
+		events, err := serializer.DeserializeBatchEvents(persistence.NewDataBlob(blob.Data, encoding))
+		s.NoError(err)
+		for _, event := range events {
+			eventIDs = append(eventIDs, event.GetEventId())
+		}
+	}
+	s.Equal([]int64{1, 2, 3, 4, 5}, eventIDs)
+}
+
+func (s *workflowHandlerSuite) TestGetRawHistoryStopsAtMaxPageBytes() {
+	config := s.newConfig()
+	we := gen.WorkflowExecution{
+		WorkflowId: common.StringPtr("wid"),
+		RunId:      common.StringPtr("rid"),
+	}
+	serializer := persistence.NewPayloadSerializer()
+	blob, err := serializer.SerializeBatchEvents([]*workflow.HistoryEvent{{EventId: common.Int64Ptr(1)}}, common.EncodingTypeThriftRW)
+	s.NoError(err)
+	s.mockHistoryV2Mgr.On("ReadRawHistoryBranch", mock.Anything).Return(&persistence.ReadRawHistoryBranchResponse{
+		HistoryEventBlobs: []*persistence.DataBlob{blob},
+		NextPageToken:     []byte("next"),
+		Size:              len(blob.Data),
+	}, nil).Twice()
+	transientDecision := &gen.TransientDecisionInfo{
+		ScheduledEvent: &gen.HistoryEvent{EventId: common.Int64Ptr(4)},
+		StartedEvent:   &gen.HistoryEvent{EventId: common.Int64Ptr(5)},
+	}
+
+	clusterMetadata := &mocks.ClusterMetadata{}
+	mService := cs.NewTestService(clusterMetadata, s.mockMessagingClient, s.mockMetricClient, s.mockClientBean)
+	wh := s.getWorkflowHandlerWithParams(mService, config, &mocks.MetadataManager{}, &mocks.BlobstoreClient{})
+	wh.metricsClient = wh.Service.GetMetricsClient()
+	scope := wh.metricsClient.Scope(0)
+	// the pages are streamed until the byte limit is reached, even though the page size is not
+	rawHistory, token, err := wh.getRawHistory(context.Background(), scope, uuid.New(), we, 1, 10, 10, 2*len(blob.Data), nil, transientDecision, []byte{})
+	s.NoError(err)
+	s.Equal([]byte("next"), token)
+	s.Len(rawHistory, 2)
+}
+
+func (s *workflowHandlerSuite) TestGetWorkflowExecutionHistory_RawHistoryIsOptIn() {
+	config := s.newConfig()
+	wh := s.getWorkflowHandler(config)
+	wh.metricsClient = wh.Service.GetMetricsClient()
+	wh.domainCache = s.mockDomainCache
+	mockHistoryClient := &mocks.HistoryClient{}
+	wh.history = mockHistoryClient
+	wh.startWG.Done()
+
+	s.mockClusterMetadata.On("ArchivalConfig").Return(cluster.NewArchivalConfig(cluster.ArchivalDisabled, "", false))
+	s.mockDomainCache.On("GetDomainID", s.testDomain).Return(s.testDomainID, nil)
+	mockHistoryClient.On("GetMutableState", mock.Anything, mock.Anything).Return(&h.GetMutableStateResponse{
+		Execution:         &shared.WorkflowExecution{WorkflowId: common.StringPtr(testWorkflowID), RunId: common.StringPtr(testRunID)},
+		EventStoreVersion: common.Int32Ptr(persistence.EventStoreVersionV2),
+		BranchToken:       []byte("branch-token"),
+		LastFirstEventId:  common.Int64Ptr(1),
+		NextEventId:       common.Int64Ptr(2),
+		IsWorkflowRunning: common.BoolPtr(false),
+	}, nil)
+	event := &shared.HistoryEvent{EventId: common.Int64Ptr(1), Version: common.Int64Ptr(0)}
+	blob, err := persistence.NewPayloadSerializer().SerializeBatchEvents([]*shared.HistoryEvent{event}, common.EncodingTypeThriftRW)
+	s.NoError(err)
+	s.mockHistoryV2Mgr.On("ReadHistoryBranch", mock.Anything).Return(&persistence.ReadHistoryBranchResponse{
+		HistoryEvents: []*shared.HistoryEvent{event},
+		Size:          len(blob.Data),
+	}, nil).Once()
+	s.mockHistoryV2Mgr.On("ReadRawHistoryBranch", mock.Anything).Return(&persistence.ReadRawHistoryBranchResponse{
+		HistoryEventBlobs: []*persistence.DataBlob{blob},
+		Size:              len(blob.Data),
+	}, nil).Once()
+
+	newRequest := func(rawHistory *bool) *shared.GetWorkflowExecutionHistoryRequest {
+		return &shared.GetWorkflowExecutionHistoryRequest{
+			Domain:     common.StringPtr(s.testDomain),
+			Execution:  &shared.WorkflowExecution{WorkflowId: common.StringPtr(testWorkflowID)},
+			RawHistory: rawHistory,
+		}
+	}
+
+	// clients not asking for raw history get the events
+	resp, err := wh.GetWorkflowExecutionHistory(context.Background(), newRequest(nil))
+	s.NoError(err)
+	s.Equal([]*shared.HistoryEvent{event}, resp.History.Events)
+	s.Nil(resp.RawHistory)
+
+	resp, err = wh.GetWorkflowExecutionHistory(context.Background(), newRequest(common.BoolPtr(true)))
+	s.NoError(err)
+	s.Empty(resp.History.Events)
+	s.Equal([]*shared.DataBlob{{EncodingType: shared.EncodingTypeThriftRW.Ptr(), Data: blob.Data}}, resp.RawHistory)
+}
+
+func (s *workflowHandlerSuite) TestFilterHistoryEvents() {
+	events := []*gen.HistoryEvent{
+		{EventId: common.Int64Ptr(1), EventType: gen.EventTypeWorkflowExecutionStarted.Ptr()},
+		{EventId: common.Int64Ptr(2), EventType: gen.EventTypeActivityTaskFailed.Ptr()},
+		{EventId: common.Int64Ptr(3), EventType: gen.EventTypeActivityTaskTimedOut.Ptr()},
+		{EventId: common.Int64Ptr(4), EventType: gen.EventTypeWorkflowExecutionCompleted.Ptr()},
+	}
+
+	s.Equal(events, filterHistoryEvents(events, nil))
+
+	filtered := filterHistoryEvents(events, []gen.EventType{gen.EventTypeActivityTaskFailed, gen.EventTypeActivityTaskTimedOut})
+	s.Equal([]*gen.HistoryEvent{events[1], events[2]}, filtered)
+
+	filtered = filterHistoryEvents(events, []gen.EventType{gen.EventTypeWorkflowExecutionCanceled})
+	s.Empty(filtered)
+}
+
+func (s *workflowHandlerSuite) TestDescribeWorkflowExecution_ReadOnly() {
+	wh := s.getWorkflowHandlerHelper()
+	wh.config.ReadOnly = true
+	runID := uuid.New()
+	startTime := time.Now()
+
+	executionMgr := &mocks.ExecutionManager{}
+	s.mockDomainCache.On("GetDomainID", s.testDomain).Return(s.testDomainID, nil)
+	s.mockExecutionMgrFactory.On("NewExecutionManager", common.WorkflowIDToHistoryShard(testWorkflowID, numHistoryShards)).Return(executionMgr, nil)
+	executionMgr.On("GetCurrentExecution", &persistence.GetCurrentExecutionRequest{
+		DomainID:   s.testDomainID,
+		WorkflowID: testWorkflowID,
+	}).Return(&persistence.GetCurrentExecutionResponse{RunID: runID}, nil)
+	executionMgr.On("GetWorkflowExecution", &persistence.GetWorkflowExecutionRequest{
+		DomainID: s.testDomainID,
+		Execution: shared.WorkflowExecution{
+			WorkflowId: common.StringPtr(testWorkflowID),
+			RunId:      common.StringPtr(runID),
+		},
+	}).Return(&persistence.GetWorkflowExecutionResponse{State: &persistence.WorkflowMutableState{
+		ExecutionInfo: &persistence.WorkflowExecutionInfo{
+			DomainID:         s.testDomainID,
+			WorkflowID:       testWorkflowID,
+			RunID:            runID,
+			WorkflowTypeName: "workflow-type",
+			TaskList:         "task-list",
+			StartTimestamp:   startTime,
+			NextEventID:      10,
+			State:            persistence.WorkflowStateRunning,
+			Notes:            "stuck on a bad deploy, do not reset",
+			NotesIdentity:    "oncall",
+		},
+		ActivityInfos: map[int64]*persistence.ActivityInfo{
+			5: {ScheduleID: 5, ActivityID: "activity-id", StartedID: common.EmptyEventID, ScheduledTime: startTime},
+		},
+	}}, nil)
+
+	resp, err := wh.DescribeWorkflowExecution(context.Background(), &shared.DescribeWorkflowExecutionRequest{
+		Domain:    common.StringPtr(s.testDomain),
+		Execution: &shared.WorkflowExecution{WorkflowId: common.StringPtr(testWorkflowID)},
+	})
+	s.NoError(err)
+	s.Equal(runID, resp.WorkflowExecutionInfo.Execution.GetRunId())
+	s.Equal(int64(9), resp.WorkflowExecutionInfo.GetHistoryLength())
+	s.Nil(resp.WorkflowExecutionInfo.CloseStatus)
+	s.Len(resp.PendingActivities, 1)
+	s.Equal(shared.PendingActivityStateScheduled, resp.PendingActivities[0].GetState())
+	s.Equal("stuck on a bad deploy, do not reset", resp.ExecutionNotes.GetNotes())
+	s.Equal("oncall", resp.ExecutionNotes.GetIdentity())
+	executionMgr.AssertExpectations(s.T())
+}
+
+func (s *workflowHandlerSuite) TestAllow_DomainLimitedFirst() {
+	wh := s.getWorkflowHandlerHelper()
+	hostLimiter := &testRateLimiter{tokens: 1}
+	wh.rateLimiter = hostLimiter
+	wh.domainRateLimiter = &testDomainRateLimiter{denied: "limited-domain"}
+
+	// the denied requests of a domain over its limit take no host token
+	s.False(wh.allow(&shared.PollForDecisionTaskRequest{Domain: common.StringPtr("limited-domain")}))
+	s.Equal(1, hostLimiter.tokens)
+	s.True(wh.allow(&shared.PollForDecisionTaskRequest{Domain: common.StringPtr(s.testDomain)}))
+	s.Equal(0, hostLimiter.tokens)
+	s.False(wh.allow(&shared.PollForDecisionTaskRequest{Domain: common.StringPtr(s.testDomain)}))
+}
+
+func (s *workflowHandlerSuite) TestMatchingReads_ReadOnly() {
+	wh := s.getWorkflowHandlerHelper()
+	wh.config.ReadOnly = true
+
+	_, err := wh.QueryWorkflow(context.Background(), &shared.QueryWorkflowRequest{
+		Domain:    common.StringPtr(s.testDomain),
+		Execution: &shared.WorkflowExecution{WorkflowId: common.StringPtr(testWorkflowID)},
+		Query:     &shared.WorkflowQuery{QueryType: common.StringPtr("query-type")},
+	})
+	s.Equal(errReadOnlyClusterNotServed, err)
+
+	_, err = wh.DescribeTaskList(context.Background(), &shared.DescribeTaskListRequest{
+		Domain:       common.StringPtr(s.testDomain),
+		TaskList:     &shared.TaskList{Name: common.StringPtr("task-list")},
+		TaskListType: common.TaskListTypePtr(shared.TaskListTypeDecision),
+	})
+	s.Equal(errReadOnlyClusterNotServed, err)
+
+	_, err = wh.GetTaskListScalingHints(context.Background(), &shared.GetTaskListScalingHintsRequest{
+		Domain:   common.StringPtr(s.testDomain),
+		TaskList: &shared.TaskList{Name: common.StringPtr("task-list")},
+	})
+	s.Equal(errReadOnlyClusterNotServed, err)
+}
+
+func (s *workflowHandlerSuite) TestQueryWorkflow_StickyPollerAlive() {
+	wh, historyClient, matchingClient, matchingRawClient := s.getWorkflowHandlerForQuery()
+	matchingRawClient.On("DescribeTaskList", mock.Anything, mock.Anything).Return(&shared.DescribeTaskListResponse{
+		Pollers: []*shared.PollerInfo{{LastAccessTime: common.Int64Ptr(time.Now().UnixNano())}},
+	}, nil).Once()
+	queryResp := &shared.QueryWorkflowResponse{QueryResult: []byte("sticky result")}
+	matchingRawClient.On("QueryWorkflow", mock.Anything, s.queryOnTaskList("sticky-task-list")).Return(queryResp, nil).Once()
+
+	resp, err := wh.QueryWorkflow(context.Background(), s.newQueryRequest())
+	s.NoError(err)
+	s.Equal(queryResp, resp)
+	historyClient.AssertExpectations(s.T())
+	matchingClient.AssertExpectations(s.T())
+	matchingRawClient.AssertExpectations(s.T())
+}
+
+func (s *workflowHandlerSuite) TestQueryWorkflow_NoLiveStickyPoller() {
+	wh, historyClient, matchingClient, matchingRawClient := s.getWorkflowHandlerForQuery()
+	// the sticky poller was last seen before the liveness window, the sticky task list is not even tried
+	matchingRawClient.On("DescribeTaskList", mock.Anything, mock.Anything).Return(&shared.DescribeTaskListResponse{
+		Pollers: []*shared.PollerInfo{{LastAccessTime: common.Int64Ptr(time.Now().Add(-time.Hour).UnixNano())}},
+	}, nil).Once()
+	historyClient.On("ResetStickyTaskList", mock.Anything, &h.ResetStickyTaskListRequest{
+		DomainUUID: common.StringPtr(s.testDomainID),
+		Execution:  &shared.WorkflowExecution{WorkflowId: common.StringPtr(testWorkflowID), RunId: common.StringPtr(testRunID)},
+	}).Return(&h.ResetStickyTaskListResponse{}, nil).Once()
+	queryResp := &shared.QueryWorkflowResponse{QueryResult: []byte("normal result")}
+	matchingClient.On("QueryWorkflow", mock.Anything, s.queryOnTaskList("task-list")).Return(queryResp, nil).Once()
+
+	resp, err := wh.QueryWorkflow(context.Background(), s.newQueryRequest())
+	s.NoError(err)
+	s.Equal(queryResp, resp)
+	historyClient.AssertExpectations(s.T())
+	matchingClient.AssertExpectations(s.T())
+	matchingRawClient.AssertExpectations(s.T())
+}
+
+func (s *workflowHandlerSuite) TestQueryWorkflow_StickyQueryTimeout() {
+	wh, historyClient, matchingClient, matchingRawClient := s.getWorkflowHandlerForQuery()
+	// the sticky poller is alive but does not answer the query in time, the poller check has its own shorter timeout
+	checkContext := mock.MatchedBy(func(ctx context.Context) bool {
+		deadline, ok := ctx.Deadline()
+		return ok && time.Until(deadline) <= wh.config.StickyQueryPollerCheckTimeout(s.testDomain)
+	})
+	matchingRawClient.On("DescribeTaskList", checkContext, mock.Anything).Return(&shared.DescribeTaskListResponse{
+		Pollers: []*shared.PollerInfo{{LastAccessTime: common.Int64Ptr(time.Now().UnixNano())}},
+	}, nil).Once()
+	matchingRawClient.On("QueryWorkflow", mock.Anything, s.queryOnTaskList("sticky-task-list")).
+		Return(nil, yarpcerrors.Newf(yarpcerrors.CodeDeadlineExceeded, "sticky query timed out")).Once()
+	historyClient.On("ResetStickyTaskList", mock.Anything, mock.Anything).Return(&h.ResetStickyTaskListResponse{}, nil).Once()
+	queryResp := &shared.QueryWorkflowResponse{QueryResult: []byte("normal result")}
+	matchingClient.On("QueryWorkflow", mock.Anything, s.queryOnTaskList("task-list")).Return(queryResp, nil).Once()
+
+	resp, err := wh.QueryWorkflow(context.Background(), s.newQueryRequest())
+	s.NoError(err)
+	s.Equal(queryResp, resp)
+	historyClient.AssertExpectations(s.T())
+	matchingClient.AssertExpectations(s.T())
+	matchingRawClient.AssertExpectations(s.T())
+}
+
+func (s *workflowHandlerSuite) TestQueryWorkflow_StickyQueryFailed() {
+	wh, historyClient, matchingClient, matchingRawClient := s.getWorkflowHandlerForQuery()
+	// the pollers of the sticky task list cannot be described, the sticky task list is tried anyway
+	matchingRawClient.On("DescribeTaskList", mock.Anything, mock.Anything).Return(nil, errors.New("matching unavailable")).Once()
+	matchingRawClient.On("QueryWorkflow", mock.Anything, s.queryOnTaskList("sticky-task-list")).
+		Return(nil, &shared.QueryFailedError{Message: "query failed"}).Once()
+
+	_, err := wh.QueryWorkflow(context.Background(), s.newQueryRequest())
+	s.IsType(&shared.QueryFailedError{}, err)
+	historyClient.AssertExpectations(s.T())
+	matchingClient.AssertExpectations(s.T())
+	matchingRawClient.AssertExpectations(s.T())
+}
+
+func (s *workflowHandlerSuite) getWorkflowHandlerForQuery() (*WorkflowHandler, *mocks.HistoryClient, *mocks.MatchingClient, *mocks.MatchingClient) {
+	wh := s.getWorkflowHandlerHelper()
+	historyClient := &mocks.HistoryClient{}
+	matchingClient := &mocks.MatchingClient{}
+	matchingRawClient := &mocks.MatchingClient{}
+	wh.history = historyClient
+	wh.matching = matchingClient
+	wh.matchingRawClient = matchingRawClient
+
+	s.mockDomainCache.On("GetDomainID", s.testDomain).Return(s.testDomainID, nil)
+	historyClient.On("GetMutableState", mock.Anything, mock.Anything).Return(&h.GetMutableStateResponse{
+		Execution:                            &shared.WorkflowExecution{WorkflowId: common.StringPtr(testWorkflowID), RunId: common.StringPtr(testRunID)},
+		TaskList:                             &shared.TaskList{Name: common.StringPtr("task-list")},
+		StickyTaskList:                       &shared.TaskList{Name: common.StringPtr("sticky-task-list")},
+		StickyTaskListScheduleToStartTimeout: common.Int32Ptr(10),
+		ClientLibraryVersion:                 common.StringPtr("0.7.0"),
+		ClientFeatureVersion:                 common.StringPtr("1.0.0"),
+		ClientImpl:                           common.StringPtr("uber-go"),
+	}, nil).Once()
+	return wh, historyClient, matchingClient, matchingRawClient
+}
+
+func (s *workflowHandlerSuite) newQueryRequest() *shared.QueryWorkflowRequest {
+	return &shared.QueryWorkflowRequest{
+		Domain:    common.StringPtr(s.testDomain),
+		Execution: &shared.WorkflowExecution{WorkflowId: common.StringPtr(testWorkflowID)},
+		Query:     &shared.WorkflowQuery{QueryType: common.StringPtr("query-type")},
+	}
+}
+
+func (s *workflowHandlerSuite) queryOnTaskList(taskList string) interface{} {
+	return mock.MatchedBy(func(request *m.QueryWorkflowRequest) bool {
+		return request.TaskList.GetName() == taskList
+	})
+}
+
 func (s *workflowHandlerSuite) TestGetSearchAttributes() {
 	wh := s.getWorkflowHandlerHelper()
 
@@ -1211,6 +1716,15 @@ func (s *workflowHandlerSuite) newConfig() *Config {
 	return NewConfig(dc.NewCollection(dc.NewNopClient(), s.logger), numHistoryShards, false)
 }
 
+func (s *workflowHandlerSuite) newTestDomainEntry(timeoutPolicy persistence.DomainTimeoutPolicy) *cache.DomainCacheEntry {
+	return cache.NewLocalDomainCacheEntryForTest(
+		&persistence.DomainInfo{ID: uuid.New(), Name: "test-domain"},
+		&persistence.DomainConfig{Retention: 1, TimeoutPolicy: timeoutPolicy},
+		cluster.TestCurrentClusterName,
+		nil,
+	)
+}
+
 func updateRequest(archivalBucketName *string, archivalStatus *shared.ArchivalStatus, archivalRetentionDays *int32, archivalOwner *string) *shared.UpdateDomainRequest {
 	return &shared.UpdateDomainRequest{
 		Name: common.StringPtr("test-name"),
@@ -1284,3 +1798,31 @@ func getHistoryRequest(nextPageToken []byte) *shared.GetWorkflowExecutionHistory
 		NextPageToken: nextPageToken,
 	}
 }
+
+type (
+	denyAuthorizer struct{}
+
+	testRateLimiter struct {
+		tokens int
+	}
+
+	testDomainRateLimiter struct {
+		denied string
+	}
+)
+
+func (l *testRateLimiter) Allow() bool {
+	if l.tokens == 0 {
+		return false
+	}
+	l.tokens--
+	return true
+}
+
+func (l *testDomainRateLimiter) Allow(domain string) bool {
+	return domain != l.denied
+}
+
+func (a *denyAuthorizer) Authorize(ctx context.Context, attributes *authorization.Attributes) (authorization.Result, error) {
+	return authorization.Result{Decision: authorization.DecisionDeny}, nil
+}
@@ -112,6 +112,43 @@ func (_m *MockHistoryEngine) GetMutableState(ctx context.Context, request *gohis
 	return r0, r1
 }
 
+// ImportWorkflowExecution is mock implementation for ImportWorkflowExecution of HistoryEngine
+func (_m *MockHistoryEngine) ImportWorkflowExecution(ctx context.Context, request *gohistory.ImportWorkflowExecutionRequest) (*gohistory.ImportWorkflowExecutionResponse, error) {
+	ret := _m.Called(ctx, request)
+
+	var r0 *gohistory.ImportWorkflowExecutionResponse
+	if rf, ok := ret.Get(0).(func(*gohistory.ImportWorkflowExecutionRequest) *gohistory.ImportWorkflowExecutionResponse); ok {
+		r0 = rf(request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gohistory.ImportWorkflowExecutionResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*gohistory.ImportWorkflowExecutionRequest) error); ok {
+		r1 = rf(request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PurgeWorkflowExecution is mock implementation for PurgeWorkflowExecution of HistoryEngine
+func (_m *MockHistoryEngine) PurgeWorkflowExecution(ctx context.Context, request *gohistory.PurgeWorkflowExecutionRequest) error {
+	ret := _m.Called(ctx, request)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*gohistory.PurgeWorkflowExecutionRequest) error); ok {
+		r0 = rf(request)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // ResetStickyTaskList is mock implementation for ResetStickyTaskList of HistoryEngine
 func (_m *MockHistoryEngine) ResetStickyTaskList(ctx context.Context, request *gohistory.ResetStickyTaskListRequest) (*gohistory.ResetStickyTaskListResponse, error) {
 	ret := _m.Called(request)
@@ -334,6 +371,29 @@ func (_m *MockHistoryEngine) SignalWorkflowExecution(ctx context.Context, reques
 	return r0
 }
 
+// UpdateWorkflowExecution is mock implementation for UpdateWorkflowExecution of HistoryEngine
+func (_m *MockHistoryEngine) UpdateWorkflowExecution(ctx context.Context, request *gohistory.UpdateWorkflowExecutionRequest) (*shared.UpdateWorkflowExecutionResponse, error) {
+	ret := _m.Called(request)
+
+	var r0 *shared.UpdateWorkflowExecutionResponse
+	if rf, ok := ret.Get(0).(func(*gohistory.UpdateWorkflowExecutionRequest) *shared.UpdateWorkflowExecutionResponse); ok {
+		r0 = rf(request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*shared.UpdateWorkflowExecutionResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*gohistory.UpdateWorkflowExecutionRequest) error); ok {
+		r1 = rf(request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // SignalWithStartWorkflowExecution is mock implementation for SignalWithStartWorkflowExecution of HistoryEngine
 func (_m *MockHistoryEngine) SignalWithStartWorkflowExecution(ctx context.Context, request *gohistory.SignalWithStartWorkflowExecutionRequest) (
 	*shared.StartWorkflowExecutionResponse, error) {
@@ -372,6 +432,85 @@ func (_m *MockHistoryEngine) RemoveSignalMutableState(ctx context.Context, reque
 	return r0
 }
 
+// SetActivityPaused is mock implementation for SetActivityPaused of HistoryEngine
+func (_m *MockHistoryEngine) SetActivityPaused(ctx context.Context, request *gohistory.SetActivityPausedRequest) error {
+	ret := _m.Called(request)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*gohistory.SetActivityPausedRequest) error); ok {
+		r0 = rf(request)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetWorkflowExecutionNotes is mock implementation for SetWorkflowExecutionNotes of HistoryEngine
+func (_m *MockHistoryEngine) SetWorkflowExecutionNotes(ctx context.Context, request *gohistory.SetWorkflowExecutionNotesRequest) error {
+	ret := _m.Called(request)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*gohistory.SetWorkflowExecutionNotesRequest) error); ok {
+		r0 = rf(request)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetWorkflowExecutionPaused is mock implementation for SetWorkflowExecutionPaused of HistoryEngine
+func (_m *MockHistoryEngine) SetWorkflowExecutionPaused(ctx context.Context, request *gohistory.SetWorkflowExecutionPausedRequest) error {
+	ret := _m.Called(request)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*gohistory.SetWorkflowExecutionPausedRequest) error); ok {
+		r0 = rf(request)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetCronSchedulePaused is mock implementation for SetCronSchedulePaused of HistoryEngine
+func (_m *MockHistoryEngine) SetCronSchedulePaused(ctx context.Context, request *gohistory.SetCronSchedulePausedRequest) error {
+	ret := _m.Called(request)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*gohistory.SetCronSchedulePausedRequest) error); ok {
+		r0 = rf(request)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// TerminateAllWorkflowRuns is mock implementation for TerminateAllWorkflowRuns of HistoryEngine
+func (_m *MockHistoryEngine) TerminateAllWorkflowRuns(ctx context.Context, request *gohistory.TerminateAllWorkflowRunsRequest) (*shared.TerminateAllWorkflowRunsResponse, error) {
+	ret := _m.Called(request)
+
+	var r0 *shared.TerminateAllWorkflowRunsResponse
+	if rf, ok := ret.Get(0).(func(*gohistory.TerminateAllWorkflowRunsRequest) *shared.TerminateAllWorkflowRunsResponse); ok {
+		r0 = rf(request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*shared.TerminateAllWorkflowRunsResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*gohistory.TerminateAllWorkflowRunsRequest) error); ok {
+		r1 = rf(request)
+	} else {
+		r1 = ret.Error(0)
+	}
+
+	return r0, r1
+}
+
 // TerminateWorkflowExecution is mock implementation for TerminateWorkflowExecution of HistoryEngine
 func (_m *MockHistoryEngine) TerminateWorkflowExecution(ctx context.Context, request *gohistory.TerminateWorkflowExecutionRequest) error {
 	ret := _m.Called(request)
@@ -493,4 +632,48 @@ func (_m *MockHistoryEngine) SyncActivity(ctx context.Context, request *gohistor
 	return r0
 }
 
+// DescribeShard is mock implementation for DescribeShard of HistoryEngine
+func (_m *MockHistoryEngine) DescribeShard() *shared.HistoryShardInfo {
+	ret := _m.Called()
+
+	var r0 *shared.HistoryShardInfo
+	if rf, ok := ret.Get(0).(func() *shared.HistoryShardInfo); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*shared.HistoryShardInfo)
+		}
+	}
+
+	return r0
+}
+
+// DomainOpenExecutionCount is mock implementation for DomainOpenExecutionCount of HistoryEngine
+func (_m *MockHistoryEngine) DomainOpenExecutionCount(domainID string) int64 {
+	ret := _m.Called(domainID)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(string) int64); ok {
+		r0 = rf(domainID)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	return r0
+}
+
+// ReconcileDomainOpenExecutionCounts is mock implementation for ReconcileDomainOpenExecutionCounts of HistoryEngine
+func (_m *MockHistoryEngine) ReconcileDomainOpenExecutionCounts(ctx context.Context, request *gohistory.ReconcileDomainOpenExecutionCountsRequest) error {
+	ret := _m.Called(request)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*gohistory.ReconcileDomainOpenExecutionCountsRequest) error); ok {
+		r0 = rf(request)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 var _ Engine = (*MockHistoryEngine)(nil)
@@ -432,6 +432,29 @@ func (_m *mockMutableState) AddContinueAsNewEvent(_a0 int64, _a1 int64, _a2 *cac
 	return r0, r1, r2
 }
 
+// AddCronSchedulePauseUpdatedEvent provides a mock function with given fields: paused
+func (_m *mockMutableState) AddCronSchedulePauseUpdatedEvent(paused bool) (*shared.HistoryEvent, error) {
+	ret := _m.Called(paused)
+
+	var r0 *shared.HistoryEvent
+	if rf, ok := ret.Get(0).(func(bool) *shared.HistoryEvent); ok {
+		r0 = rf(paused)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*shared.HistoryEvent)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(bool) error); ok {
+		r1 = rf(paused)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // AddDecisionTaskCompletedEvent provides a mock function with given fields: _a0, _a1, _a2, _a3
 func (_m *mockMutableState) AddDecisionTaskCompletedEvent(_a0 int64, _a1 int64, _a2 *shared.RespondDecisionTaskCompletedRequest, _a3 int) (*shared.HistoryEvent, error) {
 	ret := _m.Called(_a0, _a1, _a2, _a3)
@@ -478,6 +501,29 @@ func (_m *mockMutableState) AddDecisionTaskFailedEvent(scheduleEventID int64, st
 	return r0, r1
 }
 
+// AddDecisionTaskHeartbeatEvent provides a mock function with given fields: _a0, _a1, _a2, _a3
+func (_m *mockMutableState) AddDecisionTaskHeartbeatEvent(_a0 int64, _a1 int64, _a2 *shared.RespondDecisionTaskCompletedRequest, _a3 int) (*shared.HistoryEvent, error) {
+	ret := _m.Called(_a0, _a1, _a2, _a3)
+
+	var r0 *shared.HistoryEvent
+	if rf, ok := ret.Get(0).(func(int64, int64, *shared.RespondDecisionTaskCompletedRequest, int) *shared.HistoryEvent); ok {
+		r0 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*shared.HistoryEvent)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int64, int64, *shared.RespondDecisionTaskCompletedRequest, int) error); ok {
+		r1 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // AddDecisionTaskScheduleToStartTimeoutEvent provides a mock function with given fields: _a0
 func (_m *mockMutableState) AddDecisionTaskScheduleToStartTimeoutEvent(_a0 int64) (*shared.HistoryEvent, error) {
 	ret := _m.Called(_a0)
@@ -1034,6 +1080,29 @@ func (_m *mockMutableState) AddWorkflowExecutionCanceledEvent(_a0 int64, _a1 *sh
 	return r0, r1
 }
 
+// AddWorkflowExecutionPauseUpdatedEvent provides a mock function with given fields: paused
+func (_m *mockMutableState) AddWorkflowExecutionPauseUpdatedEvent(paused bool) (*shared.HistoryEvent, error) {
+	ret := _m.Called(paused)
+
+	var r0 *shared.HistoryEvent
+	if rf, ok := ret.Get(0).(func(bool) *shared.HistoryEvent); ok {
+		r0 = rf(paused)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*shared.HistoryEvent)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(bool) error); ok {
+		r1 = rf(paused)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // AddWorkflowExecutionSignaled provides a mock function with given fields: signalName, input, identity
 func (_m *mockMutableState) AddWorkflowExecutionSignaled(signalName string, input []byte, identity string) (*shared.HistoryEvent, error) {
 	ret := _m.Called(signalName, input, identity)
@@ -1103,6 +1172,52 @@ func (_m *mockMutableState) AddWorkflowExecutionTerminatedEvent(_a0 string, _a1
 	return r0, r1
 }
 
+// AddWorkflowExecutionUpdateCompletedEvent provides a mock function with given fields: decisionCompletedEventID, updateID, result
+func (_m *mockMutableState) AddWorkflowExecutionUpdateCompletedEvent(decisionCompletedEventID int64, updateID string, result []byte) (*shared.HistoryEvent, error) {
+	ret := _m.Called(decisionCompletedEventID, updateID, result)
+
+	var r0 *shared.HistoryEvent
+	if rf, ok := ret.Get(0).(func(int64, string, []byte) *shared.HistoryEvent); ok {
+		r0 = rf(decisionCompletedEventID, updateID, result)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*shared.HistoryEvent)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int64, string, []byte) error); ok {
+		r1 = rf(decisionCompletedEventID, updateID, result)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AddWorkflowExecutionUpdateRequestedEvent provides a mock function with given fields: updateID, request
+func (_m *mockMutableState) AddWorkflowExecutionUpdateRequestedEvent(updateID string, request *shared.UpdateWorkflowExecutionRequest) (*shared.HistoryEvent, error) {
+	ret := _m.Called(updateID, request)
+
+	var r0 *shared.HistoryEvent
+	if rf, ok := ret.Get(0).(func(string, *shared.UpdateWorkflowExecutionRequest) *shared.HistoryEvent); ok {
+		r0 = rf(updateID, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*shared.HistoryEvent)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, *shared.UpdateWorkflowExecutionRequest) error); ok {
+		r1 = rf(updateID, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CheckResettable provides a mock function with given fields:
 func (_m *mockMutableState) CheckResettable() error {
 	ret := _m.Called()
@@ -1709,6 +1824,22 @@ func (_m *mockMutableState) GetPendingTimerInfos() map[string]*persistence.Timer
 	return r0
 }
 
+// GetPendingUpdateInfos provides a mock function with given fields:
+func (_m *mockMutableState) GetPendingUpdateInfos() map[string]*persistence.UpdateInfo {
+	ret := _m.Called()
+
+	var r0 map[string]*persistence.UpdateInfo
+	if rf, ok := ret.Get(0).(func() map[string]*persistence.UpdateInfo); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]*persistence.UpdateInfo)
+		}
+	}
+
+	return r0
+}
+
 // GetPreviousStartedEventID provides a mock function with given fields:
 func (_m *mockMutableState) GetPreviousStartedEventID() int64 {
 	ret := _m.Called()
@@ -1857,6 +1988,29 @@ func (_m *mockMutableState) GetStartVersion() int64 {
 	return r0
 }
 
+// GetUpdateInfo provides a mock function with given fields: _a0
+func (_m *mockMutableState) GetUpdateInfo(_a0 string) (*persistence.UpdateInfo, bool) {
+	ret := _m.Called(_a0)
+
+	var r0 *persistence.UpdateInfo
+	if rf, ok := ret.Get(0).(func(string) *persistence.UpdateInfo); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*persistence.UpdateInfo)
+		}
+	}
+
+	var r1 bool
+	if rf, ok := ret.Get(1).(func(string) bool); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	return r0, r1
+}
+
 // GetUserTimer provides a mock function with given fields: _a0
 func (_m *mockMutableState) GetUserTimer(_a0 string) (bool, *persistence.TimerInfo) {
 	ret := _m.Called(_a0)
@@ -1966,6 +2120,20 @@ func (_m *mockMutableState) HasProcessedOrPendingDecisionTask() bool {
 	return r0
 }
 
+// IsActivityRequestCompleted provides a mock function with given fields: requestID
+func (_m *mockMutableState) IsActivityRequestCompleted(requestID string) bool {
+	ret := _m.Called(requestID)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(string) bool); ok {
+		r0 = rf(requestID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
 // IsCancelRequested provides a mock function with given fields:
 func (_m *mockMutableState) IsCancelRequested() (bool, string) {
 	ret := _m.Called()
@@ -2239,6 +2407,20 @@ func (_m *mockMutableState) ReplicateChildWorkflowExecutionTimedOutEvent(_a0 *sh
 	return r0
 }
 
+// ReplicateCronSchedulePauseUpdatedEvent provides a mock function with given fields: _a0
+func (_m *mockMutableState) ReplicateCronSchedulePauseUpdatedEvent(_a0 *shared.HistoryEvent) error {
+	ret := _m.Called(_a0)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*shared.HistoryEvent) error); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // ReplicateDecisionTaskCompletedEvent provides a mock function with given fields: _a0
 func (_m *mockMutableState) ReplicateDecisionTaskCompletedEvent(_a0 *shared.HistoryEvent) error {
 	ret := _m.Called(_a0)
@@ -2615,6 +2797,20 @@ func (_m *mockMutableState) ReplicateWorkflowExecutionFailedEvent(_a0 int64, _a1
 	return r0
 }
 
+// ReplicateWorkflowExecutionPauseUpdatedEvent provides a mock function with given fields: _a0
+func (_m *mockMutableState) ReplicateWorkflowExecutionPauseUpdatedEvent(_a0 *shared.HistoryEvent) error {
+	ret := _m.Called(_a0)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*shared.HistoryEvent) error); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // ReplicateWorkflowExecutionSignaled provides a mock function with given fields: _a0
 func (_m *mockMutableState) ReplicateWorkflowExecutionSignaled(_a0 *shared.HistoryEvent) error {
 	ret := _m.Called(_a0)
@@ -2671,6 +2867,34 @@ func (_m *mockMutableState) ReplicateWorkflowExecutionTimedoutEvent(_a0 int64, _
 	return r0
 }
 
+// ReplicateWorkflowExecutionUpdateCompletedEvent provides a mock function with given fields: _a0
+func (_m *mockMutableState) ReplicateWorkflowExecutionUpdateCompletedEvent(_a0 *shared.HistoryEvent) error {
+	ret := _m.Called(_a0)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*shared.HistoryEvent) error); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ReplicateWorkflowExecutionUpdateRequestedEvent provides a mock function with given fields: _a0
+func (_m *mockMutableState) ReplicateWorkflowExecutionUpdateRequestedEvent(_a0 *shared.HistoryEvent) error {
+	ret := _m.Called(_a0)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*shared.HistoryEvent) error); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // ResetSnapshot provides a mock function with given fields: _a0, _a1, _a2
 func (_m *mockMutableState) ResetSnapshot(_a0 string, _a1 int64, _a2 int, _a3 []persistence.Task, _a4 []persistence.Task, _a5 []persistence.Task) *persistence.ResetMutableStateRequest {
 	ret := _m.Called(_a0, _a1, _a2, _a3, _a4, _a5)
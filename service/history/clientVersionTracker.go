@@ -0,0 +1,138 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package history
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	workflow "github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/clock"
+	"github.com/uber/cadence/common/metrics"
+	"github.com/uber/cadence/common/service/dynamicconfig"
+)
+
+type (
+	// clientVersionTracker aggregates the client implementations and versions persisted into the
+	// execution rows when decisions complete on this host, so operators can find the domains still
+	// running outdated clients before deprecating a feature
+	clientVersionTracker struct {
+		sync.Mutex
+		maxSize       dynamicconfig.IntPropertyFn
+		timeSource    clock.TimeSource
+		metricsClient metrics.Client
+		versions      map[clientVersionKey]*clientVersionStats
+	}
+
+	clientVersionKey struct {
+		domain         string
+		clientImpl     string
+		featureVersion string
+		libraryVersion string
+	}
+
+	clientVersionStats struct {
+		decisionCount int64
+		lastSeen      time.Time
+	}
+)
+
+func newClientVersionTracker(maxSize dynamicconfig.IntPropertyFn, timeSource clock.TimeSource,
+	metricsClient metrics.Client) *clientVersionTracker {
+	return &clientVersionTracker{
+		maxSize:       maxSize,
+		timeSource:    timeSource,
+		metricsClient: metricsClient,
+		versions:      make(map[clientVersionKey]*clientVersionStats),
+	}
+}
+
+// record counts a decision completed by the given client, versions first seen after the tracker is
+// full are only reported through metrics
+func (t *clientVersionTracker) record(domain, clientImpl, featureVersion, libraryVersion string) {
+	t.metricsClient.Scope(metrics.HistoryRespondDecisionTaskCompletedScope,
+		metrics.DomainTag(domain),
+		metrics.ClientImplTag(clientImpl),
+		metrics.ClientFeatureVersionTag(featureVersion),
+	).IncCounter(metrics.CompleteDecisionByClientVersionCounter)
+
+	key := clientVersionKey{
+		domain:         domain,
+		clientImpl:     clientImpl,
+		featureVersion: featureVersion,
+		libraryVersion: libraryVersion,
+	}
+
+	t.Lock()
+	defer t.Unlock()
+	stats, ok := t.versions[key]
+	if !ok {
+		if len(t.versions) >= t.maxSize() {
+			return
+		}
+		stats = &clientVersionStats{}
+		t.versions[key] = stats
+	}
+	stats.decisionCount++
+	stats.lastSeen = t.timeSource.Now()
+}
+
+// describe returns the client versions seen for the domain, or for all domains when domain is empty
+func (t *clientVersionTracker) describe(domain string) []*workflow.ClientVersionInfo {
+	t.Lock()
+	defer t.Unlock()
+
+	var infos []*workflow.ClientVersionInfo
+	for key, stats := range t.versions {
+		if domain != "" && key.domain != domain {
+			continue
+		}
+		infos = append(infos, &workflow.ClientVersionInfo{
+			Domain:            common.StringPtr(key.domain),
+			ClientImpl:        common.StringPtr(key.clientImpl),
+			FeatureVersion:    common.StringPtr(key.featureVersion),
+			LibraryVersion:    common.StringPtr(key.libraryVersion),
+			DecisionCount:     common.Int64Ptr(stats.decisionCount),
+			LastSeenTimestamp: common.Int64Ptr(stats.lastSeen.UnixNano()),
+		})
+	}
+	SortClientVersionInfos(infos)
+	return infos
+}
+
+// SortClientVersionInfos orders the infos by domain, client implementation and versions
+func SortClientVersionInfos(infos []*workflow.ClientVersionInfo) {
+	sort.Slice(infos, func(i, j int) bool {
+		a, b := infos[i], infos[j]
+		if a.GetDomain() != b.GetDomain() {
+			return a.GetDomain() < b.GetDomain()
+		}
+		if a.GetClientImpl() != b.GetClientImpl() {
+			return a.GetClientImpl() < b.GetClientImpl()
+		}
+		if a.GetFeatureVersion() != b.GetFeatureVersion() {
+			return a.GetFeatureVersion() < b.GetFeatureVersion()
+		}
+		return a.GetLibraryVersion() < b.GetLibraryVersion()
+	})
+}
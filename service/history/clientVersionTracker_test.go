@@ -0,0 +1,97 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package history
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/uber-go/tally"
+	"github.com/uber/cadence/common/clock"
+	"github.com/uber/cadence/common/metrics"
+	"github.com/uber/cadence/common/service/dynamicconfig"
+)
+
+type (
+	clientVersionTrackerSuite struct {
+		suite.Suite
+		timeSource *clock.EventTimeSource
+		tracker    *clientVersionTracker
+	}
+)
+
+func TestClientVersionTrackerSuite(t *testing.T) {
+	s := new(clientVersionTrackerSuite)
+	suite.Run(t, s)
+}
+
+func (s *clientVersionTrackerSuite) SetupTest() {
+	s.timeSource = clock.NewEventTimeSource().Update(time.Now())
+	s.tracker = newClientVersionTracker(
+		dynamicconfig.GetIntPropertyFn(3),
+		s.timeSource,
+		metrics.NewClient(tally.NoopScope, metrics.History),
+	)
+}
+
+func (s *clientVersionTrackerSuite) TestRecordAndDescribe() {
+	s.tracker.record("domain-b", "uber-go", "1.0.0", "0.9.0")
+	s.tracker.record("domain-a", "uber-java", "1.0.0", "0.5.0")
+	firstSeen := s.timeSource.Now()
+	s.timeSource.Update(firstSeen.Add(time.Minute))
+	s.tracker.record("domain-a", "uber-java", "1.0.0", "0.5.0")
+
+	infos := s.tracker.describe("")
+	s.Len(infos, 2)
+	s.Equal("domain-a", infos[0].GetDomain())
+	s.Equal("uber-java", infos[0].GetClientImpl())
+	s.Equal("1.0.0", infos[0].GetFeatureVersion())
+	s.Equal("0.5.0", infos[0].GetLibraryVersion())
+	s.Equal(int64(2), infos[0].GetDecisionCount())
+	s.Equal(s.timeSource.Now().UnixNano(), infos[0].GetLastSeenTimestamp())
+	s.Equal("domain-b", infos[1].GetDomain())
+	s.Equal(int64(1), infos[1].GetDecisionCount())
+	s.Equal(firstSeen.UnixNano(), infos[1].GetLastSeenTimestamp())
+
+	infos = s.tracker.describe("domain-b")
+	s.Len(infos, 1)
+	s.Equal("uber-go", infos[0].GetClientImpl())
+
+	s.Empty(s.tracker.describe("domain-c"))
+}
+
+func (s *clientVersionTrackerSuite) TestMaxSize() {
+	s.tracker.record("domain", "uber-go", "1.0.0", "0.7.0")
+	s.tracker.record("domain", "uber-go", "1.0.0", "0.8.0")
+	s.tracker.record("domain", "uber-go", "1.0.0", "0.9.0")
+	// new versions are dropped once the tracker is full, known ones keep being counted
+	s.tracker.record("domain", "uber-go", "1.0.0", "0.10.0")
+	s.tracker.record("domain", "uber-go", "1.0.0", "0.9.0")
+
+	infos := s.tracker.describe("domain")
+	s.Len(infos, 3)
+	for _, info := range infos {
+		s.NotEqual("0.10.0", info.GetLibraryVersion())
+	}
+	s.Equal("0.9.0", infos[2].GetLibraryVersion())
+	s.Equal(int64(2), infos[2].GetDecisionCount())
+}
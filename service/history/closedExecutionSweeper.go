@@ -0,0 +1,285 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package history
+
+import (
+	"sort"
+	"strconv"
+	"time"
+
+	workflow "github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common/backoff"
+	"github.com/uber/cadence/common/cache"
+	"github.com/uber/cadence/common/log"
+	"github.com/uber/cadence/common/log/tag"
+	"github.com/uber/cadence/common/metrics"
+	"github.com/uber/cadence/common/persistence"
+)
+
+const (
+	retentionSweepJitterCoefficient = 0.2
+)
+
+type (
+	// closedExecutionSweeper periodically walks the closed execution index of a shard
+	// and cleans up executions whose retention has expired. It is a safety net for
+	// executions whose delete history timer was lost, so every candidate is verified
+	// against the execution record before it is deleted. Domains configured with a
+	// retention run count also have their older closed runs deleted here, ahead of
+	// their time based retention.
+	closedExecutionSweeper struct {
+		shard           ShardContext
+		config          *Config
+		executionMgr    persistence.ExecutionManager
+		metricsClient   metrics.Client
+		logger          log.Logger
+		timeSource      timeNow
+		deleteExecution func(task *persistence.TimerTaskInfo) error
+		shutdownChan    <-chan struct{}
+	}
+
+	runCountKey struct {
+		domainID   string
+		workflowID string
+	}
+)
+
+func newClosedExecutionSweeper(
+	shard ShardContext,
+	deleteExecution func(task *persistence.TimerTaskInfo) error,
+	metricsClient metrics.Client,
+	shutdownChan <-chan struct{},
+	logger log.Logger,
+) *closedExecutionSweeper {
+	return &closedExecutionSweeper{
+		shard:           shard,
+		config:          shard.GetConfig(),
+		executionMgr:    shard.GetExecutionManager(),
+		metricsClient:   metricsClient,
+		logger:          logger,
+		timeSource:      time.Now,
+		deleteExecution: deleteExecution,
+		shutdownChan:    shutdownChan,
+	}
+}
+
+func (s *closedExecutionSweeper) sweepLoop() {
+	jitter := backoff.NewJitter()
+	timer := time.NewTimer(jitter.JitDuration(s.config.RetentionSweepInterval(), retentionSweepJitterCoefficient))
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-s.shutdownChan:
+			return
+		case <-timer.C:
+			s.sweep()
+			timer.Reset(jitter.JitDuration(s.config.RetentionSweepInterval(), retentionSweepJitterCoefficient))
+		}
+	}
+}
+
+// sweep walks the days of the index from the newest, so that the closed runs of a workflow ID are seen
+// from the most recently closed and the runs exceeding the retention run count of their domain are
+// found without listing the runs of every workflow ID
+func (s *closedExecutionSweeper) sweep() {
+	now := s.timeSource()
+	today := now.UTC().Truncate(24 * time.Hour)
+	oldestDay := today.AddDate(0, 0, -s.getSweepDays())
+	// closed runs kept so far by workflow ID, for the domains with a retention run count
+	runCounts := make(map[runCountKey]int)
+	for day := today; !day.Before(oldestDay); day = day.AddDate(0, 0, -1) {
+		if s.isStopped() {
+			return
+		}
+		if err := s.sweepDay(day, now, runCounts); err != nil {
+			s.logger.Warn("Failed to sweep closed execution index.", tag.Timestamp(day), tag.Error(err))
+		}
+	}
+}
+
+// getSweepDays returns how many days of the closed execution index a sweep walks. It covers the longest
+// retention of the domains plus the lookback, so that every execution is visited after its retention expired.
+func (s *closedExecutionSweeper) getSweepDays() int {
+	maxRetentionDays := 0
+	for _, domainEntry := range s.shard.GetDomainCache().GetAllDomain() {
+		retentionDays := int(domainEntry.GetConfig().Retention)
+		if value, ok := domainEntry.GetInfo().Data[cache.SampleRetentionKey]; ok {
+			if sampledRetentionDays, err := strconv.Atoi(value); err == nil && sampledRetentionDays > retentionDays {
+				retentionDays = sampledRetentionDays
+			}
+		}
+		if retentionDays > maxRetentionDays {
+			maxRetentionDays = retentionDays
+		}
+	}
+	return maxRetentionDays + s.config.RetentionSweepLookbackDays()
+}
+
+func (s *closedExecutionSweeper) sweepDay(day time.Time, now time.Time, runCounts map[runCountKey]int) error {
+	// the runs of the domains with a retention run count are swept once the whole day is read, from the most recently closed
+	var countedRuns []*persistence.ClosedExecutionIndexInfo
+	request := &persistence.GetClosedExecutionIndexRequest{
+		Day:       day,
+		BatchSize: s.config.RetentionSweepBatchSize(),
+	}
+	for {
+		response, err := s.executionMgr.GetClosedExecutionIndex(request)
+		if err != nil {
+			return err
+		}
+		for _, info := range response.Executions {
+			if s.isStopped() {
+				return nil
+			}
+			if s.getRetentionRunCount(info.DomainID) > 0 {
+				countedRuns = append(countedRuns, info)
+				continue
+			}
+			if _, err := s.sweepExecution(info, now, 0); err != nil {
+				s.logSweepError(info, err)
+			}
+		}
+		if len(response.NextPageToken) == 0 {
+			break
+		}
+		request.NextPageToken = response.NextPageToken
+	}
+
+	sort.Slice(countedRuns, func(i, j int) bool {
+		if !countedRuns[i].CloseTime.Equal(countedRuns[j].CloseTime) {
+			return countedRuns[i].CloseTime.After(countedRuns[j].CloseTime)
+		}
+		return countedRuns[i].RunID > countedRuns[j].RunID
+	})
+	for _, info := range countedRuns {
+		if s.isStopped() {
+			return nil
+		}
+		key := runCountKey{domainID: info.DomainID, workflowID: info.WorkflowID}
+		kept, err := s.sweepExecution(info, now, runCounts[key])
+		if err != nil {
+			s.logSweepError(info, err)
+		}
+		if kept || err != nil {
+			runCounts[key]++
+		}
+	}
+	return nil
+}
+
+// sweepExecution deletes the execution if its retention expired, or if the domain keeps only the last N closed
+// runs per workflow ID and newerRuns of them closed after this one. It returns true if the execution is kept
+func (s *closedExecutionSweeper) sweepExecution(
+	info *persistence.ClosedExecutionIndexInfo,
+	now time.Time,
+	newerRuns int,
+) (bool, error) {
+
+	domainEntry, err := s.shard.GetDomainCache().GetDomainByID(info.DomainID)
+	if err != nil {
+		if _, ok := err.(*workflow.EntityNotExistsError); ok {
+			return false, s.deleteIndex(info)
+		}
+		return false, err
+	}
+
+	response, err := s.executionMgr.GetWorkflowExecution(&persistence.GetWorkflowExecutionRequest{
+		DomainID: info.DomainID,
+		Execution: workflow.WorkflowExecution{
+			WorkflowId: &info.WorkflowID,
+			RunId:      &info.RunID,
+		},
+	})
+	if err != nil {
+		if _, ok := err.(*workflow.EntityNotExistsError); ok {
+			// already deleted, most likely by its delete history timer
+			return false, s.deleteIndex(info)
+		}
+		return false, err
+	}
+	executionInfo := response.State.ExecutionInfo
+	if executionInfo.State != persistence.WorkflowStateCompleted {
+		// execution was reopened, closing it again will write a new index entry
+		return false, s.deleteIndex(info)
+	}
+
+	closeTime := info.CloseTime
+	if executionInfo.LastUpdatedTimestamp.After(closeTime) {
+		closeTime = executionInfo.LastUpdatedTimestamp
+	}
+	retention := time.Duration(domainEntry.GetRetentionDays(info.WorkflowID)) * 24 * time.Hour
+	if closeTime.Add(retention).After(now) {
+		runCount := int(domainEntry.GetConfig().RetentionRunCount)
+		if runCount <= 0 || newerRuns < runCount {
+			return true, nil
+		}
+		s.metricsClient.IncCounter(metrics.HistoryRetentionSweepScope, metrics.RetentionSweepRunCountExceededCount)
+	} else {
+		s.metricsClient.IncCounter(metrics.HistoryRetentionSweepScope, metrics.RetentionSweepExpiredCount)
+	}
+
+	if err := s.deleteExecution(&persistence.TimerTaskInfo{
+		DomainID:            info.DomainID,
+		WorkflowID:          info.WorkflowID,
+		RunID:               info.RunID,
+		VisibilityTimestamp: closeTime,
+		TaskType:            persistence.TaskTypeDeleteHistoryEvent,
+		Version:             info.Version,
+	}); err != nil {
+		return false, err
+	}
+	return false, s.deleteIndex(info)
+}
+
+func (s *closedExecutionSweeper) getRetentionRunCount(domainID string) int32 {
+	domainEntry, err := s.shard.GetDomainCache().GetDomainByID(domainID)
+	if err != nil {
+		return 0
+	}
+	return domainEntry.GetConfig().RetentionRunCount
+}
+
+func (s *closedExecutionSweeper) logSweepError(info *persistence.ClosedExecutionIndexInfo, err error) {
+	s.logger.Warn("Failed to sweep closed execution.",
+		tag.WorkflowDomainID(info.DomainID),
+		tag.WorkflowID(info.WorkflowID),
+		tag.WorkflowRunID(info.RunID),
+		tag.Error(err))
+}
+
+func (s *closedExecutionSweeper) deleteIndex(info *persistence.ClosedExecutionIndexInfo) error {
+	return s.executionMgr.DeleteClosedExecutionIndex(&persistence.DeleteClosedExecutionIndexRequest{
+		DomainID:   info.DomainID,
+		WorkflowID: info.WorkflowID,
+		RunID:      info.RunID,
+		CloseTime:  info.CloseTime,
+	})
+}
+
+func (s *closedExecutionSweeper) isStopped() bool {
+	select {
+	case <-s.shutdownChan:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,288 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package history
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"github.com/uber-go/tally"
+	workflow "github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common/cache"
+	"github.com/uber/cadence/common/cluster"
+	"github.com/uber/cadence/common/log/loggerimpl"
+	"github.com/uber/cadence/common/metrics"
+	"github.com/uber/cadence/common/mocks"
+	"github.com/uber/cadence/common/persistence"
+)
+
+type (
+	closedExecutionSweeperSuite struct {
+		suite.Suite
+		mockExecutionMgr *mocks.ExecutionManager
+		mockDomainCache  *cache.DomainCacheMock
+		deletedTasks     []*persistence.TimerTaskInfo
+		now              time.Time
+		sweeper          *closedExecutionSweeper
+	}
+)
+
+func TestClosedExecutionSweeperSuite(t *testing.T) {
+	s := new(closedExecutionSweeperSuite)
+	suite.Run(t, s)
+}
+
+func (s *closedExecutionSweeperSuite) SetupTest() {
+	s.mockExecutionMgr = &mocks.ExecutionManager{}
+	s.mockDomainCache = &cache.DomainCacheMock{}
+	s.deletedTasks = nil
+	s.now = time.Date(2019, 6, 10, 12, 0, 0, 0, time.UTC)
+
+	logger := loggerimpl.NewDevelopmentForTest(s.Suite)
+	shard := &shardContextImpl{
+		executionManager: s.mockExecutionMgr,
+		domainCache:      s.mockDomainCache,
+		config:           NewDynamicConfigForTest(),
+		logger:           logger,
+	}
+	s.sweeper = newClosedExecutionSweeper(
+		shard,
+		func(task *persistence.TimerTaskInfo) error {
+			s.deletedTasks = append(s.deletedTasks, task)
+			return nil
+		},
+		metrics.NewClient(tally.NoopScope, metrics.History),
+		make(chan struct{}),
+		logger,
+	)
+	s.sweeper.timeSource = func() time.Time { return s.now }
+
+	s.mockDomainCache.On("GetDomainByID", "domainID").Return(cache.NewLocalDomainCacheEntryForTest(
+		&persistence.DomainInfo{ID: "domainID"},
+		&persistence.DomainConfig{Retention: 7},
+		cluster.TestCurrentClusterName,
+		nil,
+	), nil)
+}
+
+func (s *closedExecutionSweeperSuite) TearDownTest() {
+	s.mockExecutionMgr.AssertExpectations(s.T())
+}
+
+func (s *closedExecutionSweeperSuite) TestSweepExecution_Expired() {
+	closeTime := s.now.Add(-8 * 24 * time.Hour)
+	info := s.newIndexInfo(closeTime)
+	s.expectExecution(persistence.WorkflowStateCompleted, closeTime)
+	s.mockExecutionMgr.On("DeleteClosedExecutionIndex", s.deleteRequest(info)).Return(nil).Once()
+
+	kept, err := s.sweeper.sweepExecution(info, s.now, 0)
+	s.NoError(err)
+	s.False(kept)
+	s.Equal(1, len(s.deletedTasks))
+	s.Equal(persistence.TaskTypeDeleteHistoryEvent, s.deletedTasks[0].TaskType)
+	s.Equal(info.RunID, s.deletedTasks[0].RunID)
+	s.Equal(info.Version, s.deletedTasks[0].Version)
+}
+
+func (s *closedExecutionSweeperSuite) TestSweepExecution_NotExpired() {
+	closeTime := s.now.Add(-6 * 24 * time.Hour)
+	info := s.newIndexInfo(closeTime)
+	s.expectExecution(persistence.WorkflowStateCompleted, closeTime)
+
+	kept, err := s.sweeper.sweepExecution(info, s.now, 0)
+	s.NoError(err)
+	s.True(kept)
+	s.Empty(s.deletedTasks)
+}
+
+func (s *closedExecutionSweeperSuite) TestSweepExecution_RunCountExceeded() {
+	s.setRetentionRunCount(2)
+	closeTime := s.now.Add(-3 * 24 * time.Hour)
+	info := s.newIndexInfo(closeTime)
+	s.expectExecution(persistence.WorkflowStateCompleted, closeTime)
+	s.mockExecutionMgr.On("DeleteClosedExecutionIndex", s.deleteRequest(info)).Return(nil).Once()
+
+	kept, err := s.sweeper.sweepExecution(info, s.now, 2)
+	s.NoError(err)
+	s.False(kept)
+	s.Equal(1, len(s.deletedTasks))
+	s.Equal(info.RunID, s.deletedTasks[0].RunID)
+}
+
+func (s *closedExecutionSweeperSuite) TestSweepExecution_RunCountNotExceeded() {
+	s.setRetentionRunCount(2)
+	closeTime := s.now.Add(-3 * 24 * time.Hour)
+	info := s.newIndexInfo(closeTime)
+	s.expectExecution(persistence.WorkflowStateCompleted, closeTime)
+
+	kept, err := s.sweeper.sweepExecution(info, s.now, 1)
+	s.NoError(err)
+	s.True(kept)
+	s.Empty(s.deletedTasks)
+}
+
+func (s *closedExecutionSweeperSuite) TestSweep_RunCount() {
+	s.setRetentionRunCount(2)
+	s.mockDomainCache.On("GetAllDomain").Return(map[string]*cache.DomainCacheEntry{}).Once()
+	today := s.now.Truncate(24 * time.Hour)
+	yesterday := today.AddDate(0, 0, -1)
+	// the runs of a day are read in no particular order, and the newest run of the workflow ID closed today
+	s.expectIndex(yesterday,
+		s.newRunIndexInfo("olderRunID", yesterday.Add(time.Hour)),
+		s.newRunIndexInfo("oldestRunID", yesterday.Add(time.Minute)),
+		s.newRunIndexInfo("newerRunID", yesterday.Add(2*time.Hour)),
+	)
+	s.expectIndex(today, s.newRunIndexInfo("newestRunID", today.Add(time.Hour)))
+	s.mockExecutionMgr.On("GetClosedExecutionIndex", mock.Anything).Return(&persistence.GetClosedExecutionIndexResponse{}, nil)
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(func(request *persistence.GetWorkflowExecutionRequest) *persistence.GetWorkflowExecutionResponse {
+		return &persistence.GetWorkflowExecutionResponse{
+			State: &persistence.WorkflowMutableState{
+				ExecutionInfo: &persistence.WorkflowExecutionInfo{
+					DomainID:   "domainID",
+					WorkflowID: "workflowID",
+					RunID:      request.Execution.GetRunId(),
+					State:      persistence.WorkflowStateCompleted,
+				},
+			},
+		}
+	}, nil).Times(4)
+	s.mockExecutionMgr.On("DeleteClosedExecutionIndex", mock.Anything).Return(nil).Twice()
+
+	s.sweeper.sweep()
+	s.Equal(2, len(s.deletedTasks))
+	s.Equal("olderRunID", s.deletedTasks[0].RunID)
+	s.Equal("oldestRunID", s.deletedTasks[1].RunID)
+}
+
+func (s *closedExecutionSweeperSuite) TestSweepExecution_UpdatedAfterClose() {
+	info := s.newIndexInfo(s.now.Add(-8 * 24 * time.Hour))
+	s.expectExecution(persistence.WorkflowStateCompleted, s.now.Add(-time.Hour))
+
+	kept, err := s.sweeper.sweepExecution(info, s.now, 0)
+	s.NoError(err)
+	s.True(kept)
+	s.Empty(s.deletedTasks)
+}
+
+func (s *closedExecutionSweeperSuite) TestSweepExecution_Reopened() {
+	info := s.newIndexInfo(s.now.Add(-8 * 24 * time.Hour))
+	s.expectExecution(persistence.WorkflowStateRunning, s.now.Add(-time.Hour))
+	s.mockExecutionMgr.On("DeleteClosedExecutionIndex", s.deleteRequest(info)).Return(nil).Once()
+
+	kept, err := s.sweeper.sweepExecution(info, s.now, 0)
+	s.NoError(err)
+	s.False(kept)
+	s.Empty(s.deletedTasks)
+}
+
+func (s *closedExecutionSweeperSuite) TestSweepExecution_AlreadyDeleted() {
+	info := s.newIndexInfo(s.now.Add(-8 * 24 * time.Hour))
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(nil, &workflow.EntityNotExistsError{}).Once()
+	s.mockExecutionMgr.On("DeleteClosedExecutionIndex", s.deleteRequest(info)).Return(nil).Once()
+
+	kept, err := s.sweeper.sweepExecution(info, s.now, 0)
+	s.NoError(err)
+	s.False(kept)
+	s.Empty(s.deletedTasks)
+}
+
+func (s *closedExecutionSweeperSuite) TestGetSweepDays() {
+	s.mockDomainCache.On("GetAllDomain").Return(map[string]*cache.DomainCacheEntry{
+		"domainID": cache.NewLocalDomainCacheEntryForTest(
+			&persistence.DomainInfo{ID: "domainID"},
+			&persistence.DomainConfig{Retention: 7},
+			cluster.TestCurrentClusterName,
+			nil,
+		),
+		"longRetentionDomainID": cache.NewLocalDomainCacheEntryForTest(
+			&persistence.DomainInfo{ID: "longRetentionDomainID"},
+			&persistence.DomainConfig{Retention: 90},
+			cluster.TestCurrentClusterName,
+			nil,
+		),
+		"sampledDomainID": cache.NewLocalDomainCacheEntryForTest(
+			&persistence.DomainInfo{ID: "sampledDomainID", Data: map[string]string{cache.SampleRetentionKey: "120"}},
+			&persistence.DomainConfig{Retention: 3},
+			cluster.TestCurrentClusterName,
+			nil,
+		),
+	}).Once()
+
+	// the executions of the longest retention are visited past the end of their retention
+	s.Equal(120+s.sweeper.config.RetentionSweepLookbackDays(), s.sweeper.getSweepDays())
+}
+
+func (s *closedExecutionSweeperSuite) newIndexInfo(closeTime time.Time) *persistence.ClosedExecutionIndexInfo {
+	return &persistence.ClosedExecutionIndexInfo{
+		DomainID:   "domainID",
+		WorkflowID: "workflowID",
+		RunID:      "runID",
+		CloseTime:  closeTime,
+		Version:    12,
+	}
+}
+
+func (s *closedExecutionSweeperSuite) expectExecution(state int, lastUpdated time.Time) {
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(&persistence.GetWorkflowExecutionResponse{
+		State: &persistence.WorkflowMutableState{
+			ExecutionInfo: &persistence.WorkflowExecutionInfo{
+				DomainID:             "domainID",
+				WorkflowID:           "workflowID",
+				RunID:                "runID",
+				State:                state,
+				LastUpdatedTimestamp: lastUpdated,
+			},
+		},
+	}, nil).Once()
+}
+
+func (s *closedExecutionSweeperSuite) setRetentionRunCount(runCount int32) {
+	s.mockDomainCache.ExpectedCalls = nil
+	s.mockDomainCache.On("GetDomainByID", "domainID").Return(cache.NewLocalDomainCacheEntryForTest(
+		&persistence.DomainInfo{ID: "domainID", Name: "domain"},
+		&persistence.DomainConfig{Retention: 7, RetentionRunCount: runCount},
+		cluster.TestCurrentClusterName,
+		nil,
+	), nil)
+}
+
+func (s *closedExecutionSweeperSuite) newRunIndexInfo(runID string, closeTime time.Time) *persistence.ClosedExecutionIndexInfo {
+	info := s.newIndexInfo(closeTime)
+	info.RunID = runID
+	return info
+}
+
+func (s *closedExecutionSweeperSuite) expectIndex(day time.Time, executions ...*persistence.ClosedExecutionIndexInfo) {
+	s.mockExecutionMgr.On("GetClosedExecutionIndex", mock.MatchedBy(func(request *persistence.GetClosedExecutionIndexRequest) bool {
+		return request.Day.Equal(day)
+	})).Return(&persistence.GetClosedExecutionIndexResponse{Executions: executions}, nil).Once()
+}
+
+func (s *closedExecutionSweeperSuite) deleteRequest(info *persistence.ClosedExecutionIndexInfo) *persistence.DeleteClosedExecutionIndexRequest {
+	return &persistence.DeleteClosedExecutionIndexRequest{
+		DomainID:   info.DomainID,
+		WorkflowID: info.WorkflowID,
+		RunID:      info.RunID,
+		CloseTime:  info.CloseTime,
+	}
+}
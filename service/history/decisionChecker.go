@@ -181,6 +181,51 @@ func (c *workflowSizeChecker) failWorkflowSizeExceedsLimit() (bool, error) {
 	return false, nil
 }
 
+// terminateWorkflowSizeExceedsLimit is checked on every update of a running workflow, unlike
+// failWorkflowSizeExceedsLimit it also stops workflows whose history keeps growing without
+// completing decisions, e.g. when flooded by signals or activity retries
+func (c *workflowSizeChecker) terminateWorkflowSizeExceedsLimit() (bool, error) {
+	if !c.mutableState.IsWorkflowExecutionRunning() {
+		return false, nil
+	}
+
+	historyCount := int(c.mutableState.GetNextEventID()) - 1
+	historySize := int(c.executionStats.HistorySize)
+
+	if historySize > c.historySizeLimitError || historyCount > c.historyCountLimitError {
+		executionInfo := c.mutableState.GetExecutionInfo()
+		c.logger.Warn("history size exceeds limit, terminating workflow.",
+			tag.WorkflowDomainID(executionInfo.DomainID),
+			tag.WorkflowID(executionInfo.WorkflowID),
+			tag.WorkflowRunID(executionInfo.RunID),
+			tag.WorkflowHistorySize(historySize),
+			tag.WorkflowEventCount(historyCount))
+		c.metricsClient.IncCounter(metrics.ExecutionSizeStatsScope, metrics.WorkflowHistoryLimitTerminateCount)
+
+		if _, err := c.mutableState.AddWorkflowExecutionTerminatedEvent(
+			common.FailureReasonSizeExceedsLimit,
+			[]byte("Workflow history size / count exceeds limit."),
+			identityHistoryService,
+		); err != nil {
+			return false, err
+		}
+		return true, nil
+	}
+
+	if historySize > c.historySizeLimitWarn || historyCount > c.historyCountLimitWarn {
+		executionInfo := c.mutableState.GetExecutionInfo()
+		c.logger.Warn("history size exceeds limit.",
+			tag.WorkflowDomainID(executionInfo.DomainID),
+			tag.WorkflowID(executionInfo.WorkflowID),
+			tag.WorkflowRunID(executionInfo.RunID),
+			tag.WorkflowHistorySize(historySize),
+			tag.WorkflowEventCount(historyCount))
+		c.metricsClient.IncCounter(metrics.ExecutionSizeStatsScope, metrics.WorkflowHistoryLimitWarnCount)
+	}
+
+	return false, nil
+}
+
 func (v *decisionAttrValidator) validateActivityScheduleAttributes(
 	domainID string,
 	targetDomainID string,
@@ -517,6 +562,14 @@ func (v *decisionAttrValidator) validateContinueAsNewWorkflowExecutionAttributes
 		attributes.TaskStartToCloseTimeoutSeconds = common.Int32Ptr(executionInfo.DecisionTimeoutValue)
 	}
 
+	if err := backoff.ValidateSchedule(attributes.GetCronSchedule()); err != nil {
+		return err
+	}
+
+	if err := backoff.ValidateScheduleTimezone(attributes.GetCronSchedule(), attributes.GetCronTimezone()); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -570,6 +623,10 @@ func (v *decisionAttrValidator) validateStartChildExecutionAttributes(
 		return err
 	}
 
+	if err := backoff.ValidateScheduleTimezone(attributes.GetCronSchedule(), attributes.GetCronTimezone()); err != nil {
+		return err
+	}
+
 	// Inherit tasklist from parent workflow execution if not provided on decision
 	tl, err := v.validatedTaskList(attributes.TaskList, parentInfo.TaskList)
 	if err != nil {
@@ -23,13 +23,17 @@ package history
 import (
 	"testing"
 
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
+	"github.com/uber-go/tally"
 	workflow "github.com/uber/cadence/.gen/go/shared"
 	"github.com/uber/cadence/common"
 	"github.com/uber/cadence/common/cache"
 	"github.com/uber/cadence/common/cluster"
 	"github.com/uber/cadence/common/definition"
 	"github.com/uber/cadence/common/log"
+	"github.com/uber/cadence/common/metrics"
 	"github.com/uber/cadence/common/persistence"
 	"github.com/uber/cadence/common/service/dynamicconfig"
 )
@@ -534,3 +538,62 @@ func (s *decisionAttrValidatorSuite) TestValidateTaskListName() {
 		})
 	}
 }
+
+func TestTerminateWorkflowSizeExceedsLimit(t *testing.T) {
+	s := assert.New(t)
+	executionInfo := &persistence.WorkflowExecutionInfo{
+		DomainID:   "test domain ID",
+		WorkflowID: "test workflow ID",
+		RunID:      validRunID,
+	}
+	newChecker := func(msBuilder mutableState, historySize int64) *workflowSizeChecker {
+		return newWorkflowSizeChecker(
+			0, 0,
+			100, 200,
+			10, 20,
+			common.EmptyEventID,
+			msBuilder,
+			&persistence.ExecutionStats{HistorySize: historySize},
+			metrics.NewClient(tally.NoopScope, metrics.History),
+			log.NewNoop(),
+		)
+	}
+
+	msBuilder := &mockMutableState{}
+	msBuilder.On("IsWorkflowExecutionRunning").Return(false).Once()
+	terminated, err := newChecker(msBuilder, 1000).terminateWorkflowSizeExceedsLimit()
+	s.NoError(err)
+	s.False(terminated)
+	msBuilder.AssertExpectations(t)
+
+	msBuilder = &mockMutableState{}
+	msBuilder.On("IsWorkflowExecutionRunning").Return(true).Once()
+	msBuilder.On("GetNextEventID").Return(int64(16)).Once()
+	msBuilder.On("GetExecutionInfo").Return(executionInfo).Once()
+	terminated, err = newChecker(msBuilder, 150).terminateWorkflowSizeExceedsLimit()
+	s.NoError(err)
+	s.False(terminated)
+	msBuilder.AssertExpectations(t)
+
+	msBuilder = &mockMutableState{}
+	msBuilder.On("IsWorkflowExecutionRunning").Return(true).Once()
+	msBuilder.On("GetNextEventID").Return(int64(22)).Once()
+	msBuilder.On("GetExecutionInfo").Return(executionInfo).Once()
+	msBuilder.On("AddWorkflowExecutionTerminatedEvent", common.FailureReasonSizeExceedsLimit, mock.Anything, identityHistoryService).
+		Return(&workflow.HistoryEvent{}, nil).Once()
+	terminated, err = newChecker(msBuilder, 150).terminateWorkflowSizeExceedsLimit()
+	s.NoError(err)
+	s.True(terminated)
+	msBuilder.AssertExpectations(t)
+
+	msBuilder = &mockMutableState{}
+	msBuilder.On("IsWorkflowExecutionRunning").Return(true).Once()
+	msBuilder.On("GetNextEventID").Return(int64(5)).Once()
+	msBuilder.On("GetExecutionInfo").Return(executionInfo).Once()
+	msBuilder.On("AddWorkflowExecutionTerminatedEvent", common.FailureReasonSizeExceedsLimit, mock.Anything, identityHistoryService).
+		Return(&workflow.HistoryEvent{}, nil).Once()
+	terminated, err = newChecker(msBuilder, 250).terminateWorkflowSizeExceedsLimit()
+	s.NoError(err)
+	s.True(terminated)
+	msBuilder.AssertExpectations(t)
+}
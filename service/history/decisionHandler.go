@@ -23,6 +23,7 @@ package history
 import (
 	ctx "context"
 	"fmt"
+	"sort"
 
 	h "github.com/uber/cadence/.gen/go/history"
 	workflow "github.com/uber/cadence/.gen/go/shared"
@@ -64,6 +65,7 @@ type (
 		logger                log.Logger
 		throttledLogger       log.Logger
 		decisionAttrValidator *decisionAttrValidator
+		clientVersions        *clientVersionTracker
 	}
 )
 
@@ -87,6 +89,7 @@ func newDecisionHandler(historyEngine *historyEngineImpl) *decisionHandlerImpl {
 			historyEngine.config,
 			historyEngine.logger,
 		),
+		clientVersions: historyEngine.clientVersions,
 	}
 }
 
@@ -154,11 +157,15 @@ func (handler *decisionHandlerImpl) handleDecisionTaskStarted(
 	requestID := req.GetRequestId()
 
 	var resp *h.RecordDecisionTaskStartedResponse
-	err = handler.historyEngine.updateWorkflowExecutionWithAction(ctx, domainID, execution,
-		func(msBuilder mutableState, tBuilder *timerBuilder) (*updateWorkflowAction, error) {
+	err = handler.historyEngine.updateWorkflowExecutionWithContext(ctx, domainID, execution,
+		func(context workflowExecutionContext, msBuilder mutableState, tBuilder *timerBuilder) (*updateWorkflowAction, error) {
 			if !msBuilder.IsWorkflowExecutionRunning() {
 				return nil, ErrWorkflowCompleted
 			}
+			executionStats, err := context.loadExecutionStats()
+			if err != nil {
+				return nil, err
+			}
 
 			di, isRunning := msBuilder.GetPendingDecision(scheduleID)
 
@@ -190,7 +197,7 @@ func (handler *decisionHandlerImpl) handleDecisionTaskStarted(
 			if di.StartedID != common.EmptyEventID {
 				// If decision is started as part of the current request scope then return a positive response
 				if di.RequestID == requestID {
-					resp = handler.createRecordDecisionTaskStartedResponse(domainID, msBuilder, di, req.PollRequest.GetIdentity())
+					resp = handler.createRecordDecisionTaskStartedResponse(domainEntry, msBuilder, executionStats, di, req.PollRequest.GetIdentity())
 					updateAction.noop = true
 					return updateAction, nil
 				}
@@ -200,16 +207,21 @@ func (handler *decisionHandlerImpl) handleDecisionTaskStarted(
 				return nil, &h.EventAlreadyStartedError{Message: "Decision task already started."}
 			}
 
+			if msBuilder.GetExecutionInfo().Paused {
+				// It is OK to drop the task, it will be dispatched again when the workflow is resumed.
+				return nil, ErrWorkflowPaused
+			}
+
 			_, di, err = msBuilder.AddDecisionTaskStartedEvent(scheduleID, requestID, req.PollRequest)
 			if err != nil {
 				// Unable to add DecisionTaskStarted event to history
 				return nil, &workflow.InternalServiceError{Message: "Unable to add DecisionTaskStarted event to history."}
 			}
 
-			resp = handler.createRecordDecisionTaskStartedResponse(domainID, msBuilder, di, req.PollRequest.GetIdentity())
+			resp = handler.createRecordDecisionTaskStartedResponse(domainEntry, msBuilder, executionStats, di, req.PollRequest.GetIdentity())
 			updateAction.timerTasks = []persistence.Task{tBuilder.AddStartToCloseDecisionTimoutTask(
 				di.ScheduleID,
-				di.Attempt,
+				di.scheduleAttempt(),
 				di.DecisionTimeout,
 			)}
 			return updateAction, nil
@@ -251,7 +263,7 @@ func (handler *decisionHandlerImpl) handleDecisionTaskFailed(
 
 			scheduleID := token.ScheduleID
 			di, isRunning := msBuilder.GetPendingDecision(scheduleID)
-			if !isRunning || di.Attempt != token.ScheduleAttempt || di.StartedID == common.EmptyEventID {
+			if !isRunning || di.scheduleAttempt() != token.ScheduleAttempt || di.StartedID == common.EmptyEventID {
 				return nil, &workflow.EntityNotExistsError{Message: "Decision task not found."}
 			}
 
@@ -329,7 +341,7 @@ Update_History_Loop:
 			continue Update_History_Loop
 		}
 
-		if !msBuilder.IsWorkflowExecutionRunning() || !isRunning || di.Attempt != token.ScheduleAttempt ||
+		if !msBuilder.IsWorkflowExecutionRunning() || !isRunning || di.scheduleAttempt() != token.ScheduleAttempt ||
 			di.StartedID == common.EmptyEventID {
 			return nil, &workflow.EntityNotExistsError{Message: "Decision task not found."}
 		}
@@ -339,10 +351,19 @@ Update_History_Loop:
 		if msBuilder.GetExecutionInfo().AutoResetPoints != nil && maxResetPoints == len(msBuilder.GetExecutionInfo().AutoResetPoints.Points) {
 			handler.metricsClient.IncCounter(metrics.HistoryRespondDecisionTaskCompletedScope, metrics.AutoResetPointsLimitExceededCounter)
 		}
-		completedEvent, err := msBuilder.AddDecisionTaskCompletedEvent(scheduleID, startedID, request, maxResetPoints)
+		var completedEvent *workflow.HistoryEvent
+		isHeartbeat := handler.isTransientDecisionHeartbeat(domainEntry, request)
+		if isHeartbeat {
+			completedEvent, err = msBuilder.AddDecisionTaskHeartbeatEvent(scheduleID, startedID, request, maxResetPoints)
+		} else {
+			completedEvent, err = msBuilder.AddDecisionTaskCompletedEvent(scheduleID, startedID, request, maxResetPoints)
+		}
 		if err != nil {
 			return nil, &workflow.InternalServiceError{Message: "Unable to add DecisionTaskCompleted event to history."}
 		}
+		if !isHeartbeat {
+			logTaskJourneyCompleted(handler.logger, domainID, workflowExecution, scheduleID)
+		}
 
 		var (
 			failDecision                bool
@@ -379,6 +400,9 @@ Update_History_Loop:
 			failDecision = true
 			failCause = workflow.DecisionTaskFailedCauseBadBinary
 			failMessage = fmt.Sprintf("binary %v is already marked as bad deployment", binChecksum)
+		} else if isHeartbeat && completedEvent == nil {
+			// transient heartbeat, there are no decisions to handle and nothing was written to history
+			executionInfo.ExecutionContext = request.ExecutionContext
 		} else {
 
 			domainName := domainEntry.GetInfo().Name
@@ -408,8 +432,14 @@ Update_History_Loop:
 				timerBuilderProvider,
 				handler.domainCache,
 				handler.metricsClient,
+				handler.config,
 			)
 
+			// the update results are recorded ahead of the decisions, which may close the workflow
+			if err := recordWorkflowUpdateResults(msBuilder, completedEvent.GetEventId(), request.UpdateResults); err != nil {
+				return nil, err
+			}
+
 			if err := decisionTaskHandler.handleDecisions(
 				request.ExecutionContext,
 				request.Decisions,
@@ -439,6 +469,11 @@ Update_History_Loop:
 			hasUnhandledEvents = decisionTaskHandler.hasUnhandledEventsBeforeDecisions
 		}
 
+		// updates which arrived while this decision was in flight were not delivered to the worker
+		if !failDecision && len(getPendingWorkflowUpdates(msBuilder)) > 0 {
+			hasUnhandledEvents = true
+		}
+
 		if failDecision {
 			handler.metricsClient.IncCounter(metrics.HistoryRespondDecisionTaskCompletedScope, metrics.FailedDecisionsCounter)
 			handler.logger.Info("Failing the decision.", tag.WorkflowDecisionFailCause(int64(failCause)),
@@ -481,7 +516,7 @@ Update_History_Loop:
 				})
 				if msBuilder.IsStickyTaskListEnabled() {
 					tBuilder := handler.historyEngine.getTimerBuilder(context.getExecution())
-					stickyTaskTimeoutTimer := tBuilder.AddScheduleToStartDecisionTimoutTask(di.ScheduleID, di.Attempt,
+					stickyTaskTimeoutTimer := tBuilder.AddScheduleToStartDecisionTimoutTask(di.ScheduleID, di.scheduleAttempt(),
 						executionInfo.StickyScheduleToStartTimeout)
 					timerTasks = append(timerTasks, stickyTaskTimeoutTimer)
 				}
@@ -495,7 +530,7 @@ Update_History_Loop:
 				if err != nil {
 					return nil, err
 				}
-				timeOutTask := tBuilder.AddStartToCloseDecisionTimoutTask(di.ScheduleID, di.Attempt, di.DecisionTimeout)
+				timeOutTask := tBuilder.AddStartToCloseDecisionTimoutTask(di.ScheduleID, di.scheduleAttempt(), di.DecisionTimeout)
 				timerTasks = append(timerTasks, timeOutTask)
 			}
 		}
@@ -580,11 +615,12 @@ Update_History_Loop:
 		timerTasks = append(timerTasks, continueAsNewTimerTasks...)
 		// Inform timer about the new ones.
 		handler.timerProcessor.NotifyNewTimers(handler.currentClusterName, handler.shard.GetCurrentTime(handler.currentClusterName), timerTasks)
+		handler.clientVersions.record(domainEntry.GetInfo().Name, clientImpl, clientFeatureVersion, clientLibVersion)
 
 		resp = &h.RespondDecisionTaskCompletedResponse{}
 		if request.GetReturnNewDecisionTask() && createNewDecisionTask {
 			di, _ := msBuilder.GetPendingDecision(newDecisionTaskScheduledID)
-			resp.StartedResponse = handler.createRecordDecisionTaskStartedResponse(domainID, msBuilder, di, request.GetIdentity())
+			resp.StartedResponse = handler.createRecordDecisionTaskStartedResponse(domainEntry, msBuilder, executionStats, di, request.GetIdentity())
 			// sticky is always enabled when worker request for new decision task from RespondDecisionTaskCompleted
 			resp.StartedResponse.StickyExecutionEnabled = common.BoolPtr(true)
 		}
@@ -595,9 +631,22 @@ Update_History_Loop:
 	return nil, ErrMaxAttemptsExceeded
 }
 
+// isTransientDecisionHeartbeat returns whether the decision completion only asks for a new decision
+// task, without any decision, and can therefore be kept out of history
+func (handler *decisionHandlerImpl) isTransientDecisionHeartbeat(
+	domainEntry *cache.DomainCacheEntry,
+	request *workflow.RespondDecisionTaskCompletedRequest,
+) bool {
+
+	return handler.config.EnableTransientDecisionHeartbeat(domainEntry.GetInfo().Name) &&
+		request.GetForceCreateNewDecisionTask() &&
+		len(request.Decisions) == 0
+}
+
 func (handler *decisionHandlerImpl) createRecordDecisionTaskStartedResponse(
-	domainID string,
+	domainEntry *cache.DomainCacheEntry,
 	msBuilder mutableState,
+	executionStats *persistence.ExecutionStats,
 	di *decisionInfo,
 	identity string,
 ) *h.RecordDecisionTaskStartedResponse {
@@ -616,6 +665,7 @@ func (handler *decisionHandlerImpl) createRecordDecisionTaskStartedResponse(
 	response.StickyExecutionEnabled = common.BoolPtr(msBuilder.IsStickyTaskListEnabled())
 	response.NextEventId = common.Int64Ptr(msBuilder.GetNextEventID())
 	response.Attempt = common.Int64Ptr(di.Attempt)
+	response.ScheduleAttempt = common.Int64Ptr(di.scheduleAttempt())
 	response.WorkflowExecutionTaskList = common.TaskListPtr(workflow.TaskList{
 		Name: &executionInfo.TaskList,
 		Kind: common.TaskListKindPtr(workflow.TaskListKindNormal),
@@ -623,8 +673,8 @@ func (handler *decisionHandlerImpl) createRecordDecisionTaskStartedResponse(
 	response.ScheduledTimestamp = common.Int64Ptr(di.ScheduledTimestamp)
 	response.StartedTimestamp = common.Int64Ptr(di.StartedTimestamp)
 
-	if di.Attempt > 0 {
-		// This decision is retried from mutable state
+	if di.isTransient() {
+		// This decision is retried or follows heartbeats from mutable state
 		// Also return schedule and started which are not written to history yet
 		scheduledEvent, startedEvent := msBuilder.CreateTransientDecisionEvents(di, identity)
 		response.DecisionInfo = &workflow.TransientDecisionInfo{}
@@ -634,5 +684,60 @@ func (handler *decisionHandlerImpl) createRecordDecisionTaskStartedResponse(
 	response.EventStoreVersion = common.Int32Ptr(msBuilder.GetEventStoreVersion())
 	response.BranchToken = msBuilder.GetCurrentBranch()
 
+	// let workers roll the workflow over with continue as new before its history gets near the limits
+	domainName := domainEntry.GetInfo().Name
+	historyCount := int(msBuilder.GetNextEventID()) - 1
+	historySize := int(executionStats.HistorySize)
+	if historySize > handler.config.HistorySizeSuggestContinueAsNew(domainName) ||
+		historyCount > handler.config.HistoryCountSuggestContinueAsNew(domainName) {
+		response.ContinueAsNewSuggested = common.BoolPtr(true)
+	}
+	response.PendingUpdates = getPendingWorkflowUpdates(msBuilder)
+
 	return response
 }
+
+// recordWorkflowUpdateResults records the results reported by the worker, results of updates which are
+// unknown or already completed, e.g. reported again by a retried decision, are ignored
+func recordWorkflowUpdateResults(
+	msBuilder mutableState,
+	decisionCompletedEventID int64,
+	results []*workflow.WorkflowUpdateResult,
+) error {
+
+	for _, result := range results {
+		ui, ok := msBuilder.GetUpdateInfo(result.GetUpdateId())
+		if !ok || ui.Completed {
+			continue
+		}
+		if _, err := msBuilder.AddWorkflowExecutionUpdateCompletedEvent(
+			decisionCompletedEventID,
+			result.GetUpdateId(),
+			result.Result,
+		); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func getPendingWorkflowUpdates(
+	msBuilder mutableState,
+) []*workflow.WorkflowUpdate {
+
+	var updates []*workflow.WorkflowUpdate
+	for _, ui := range msBuilder.GetPendingUpdateInfos() {
+		if ui.Completed {
+			continue
+		}
+		updates = append(updates, &workflow.WorkflowUpdate{
+			UpdateId:   common.StringPtr(ui.UpdateID),
+			UpdateName: common.StringPtr(ui.UpdateName),
+			Input:      ui.Input,
+		})
+	}
+	sort.Slice(updates, func(i, j int) bool {
+		return updates[i].GetUpdateId() < updates[j].GetUpdateId()
+	})
+	return updates
+}
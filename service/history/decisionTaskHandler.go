@@ -66,6 +66,7 @@ type (
 		timerBuilderProvider timerBuilderProvider
 		domainCache          cache.DomainCache
 		metricsClient        metrics.Client
+		config               *Config
 	}
 )
 
@@ -81,6 +82,7 @@ func newDecisionTaskHandler(
 	timerBuilderProvider timerBuilderProvider,
 	domainCache cache.DomainCache,
 	metricsClient metrics.Client,
+	config *Config,
 ) *decisionTaskHandlerImpl {
 
 	return &decisionTaskHandlerImpl{
@@ -110,6 +112,7 @@ func newDecisionTaskHandler(
 		timerBuilderProvider: timerBuilderProvider,
 		domainCache:          domainCache,
 		metricsClient:        metricsClient,
+		config:               config,
 	}
 }
 
@@ -227,6 +230,12 @@ func (handler *decisionTaskHandlerImpl) handleDecisionScheduleActivity(
 		return err
 	}
 
+	attr.RetryPolicy = applyActivityRetryPolicyOverrides(
+		handler.config,
+		handler.domainEntry.GetInfo().Name,
+		attr.ActivityType.GetName(),
+		attr.RetryPolicy,
+	)
 	scheduleEvent, _, err := handler.mutableState.AddActivityTaskScheduledEvent(handler.decisionTaskCompletedID, attr)
 	switch err.(type) {
 	case nil:
@@ -931,6 +940,7 @@ func (handler *decisionTaskHandlerImpl) retryCronContinueAsNew(
 		ExecutionStartToCloseTimeoutSeconds: attr.ExecutionStartToCloseTimeoutSeconds,
 		TaskStartToCloseTimeoutSeconds:      attr.TaskStartToCloseTimeoutSeconds,
 		CronSchedule:                        attr.CronSchedule,
+		CronTimezone:                        attr.CronTimezone,
 		BackoffStartIntervalInSeconds:       common.Int32Ptr(backoffInterval),
 		Initiator:                           continueAsNewIter,
 		FailureReason:                       failureReason,
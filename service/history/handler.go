@@ -23,6 +23,7 @@ package history
 import (
 	"context"
 	"fmt"
+	"runtime"
 	"sync"
 
 	"github.com/pborman/uuid"
@@ -69,9 +70,11 @@ type (
 		metricsClient         metrics.Client
 		config                *Config
 		historyEventNotifier  historyEventNotifier
+		clientVersions        *clientVersionTracker
 		publisher             messaging.Producer
 		rateLimiter           tokenbucket.TokenBucket
 		archiverProvider      provider.ArchiverProvider
+		pingCache             *persistence.PingCache
 		service.Service
 	}
 )
@@ -106,7 +109,7 @@ func NewHandler(sVice service.Service, config *Config, shardManager persistence.
 		historyV2Mgr:        historyV2Mgr,
 		visibilityMgr:       visibilityMgr,
 		executionMgrFactory: executionMgrFactory,
-		tokenSerializer:     common.NewJSONTaskTokenSerializer(),
+		tokenSerializer:     sVice.GetTaskTokenSerializer(),
 		rateLimiter:         tokenbucket.NewDynamicTokenBucket(config.RPS, clock.NewRealTimeSource()),
 		publicClient:        publicClient,
 		archiverProvider:    archiverProvider,
@@ -152,14 +155,28 @@ func (h *Handler) Start() error {
 		if err != nil {
 			h.GetLogger().Fatal("Creating kafka producer failed", tag.Error(err))
 		}
+		h.publisher = messaging.NewBatchingProducer(h.publisher, &messaging.BatchingProducerOptions{
+			MaxBatchSize:       h.config.ReplicatorPublishBatchSize,
+			MaxBatchDelay:      h.config.ReplicatorPublishBatchMaxDelay,
+			MaxInFlightBatches: h.config.ReplicatorPublishMaxInFlightBatches,
+		}, h.GetLogger())
 	}
 
+	// the execution store is shared by the shards, the manager of the first shard pings it for the health checks
+	executionMgr, err := h.executionMgrFactory.NewExecutionManager(0)
+	if err != nil {
+		h.GetLogger().Fatal("Creating execution manager failed", tag.Error(err))
+	}
+	h.pingCache = persistence.NewPingCache(persistence.DefaultPingCacheTTL, h.Service.GetTimeSource(),
+		h.metadataMgr.Ping, executionMgr.Ping)
+
 	h.domainCache = cache.NewDomainCache(h.metadataMgr, h.GetClusterMetadata(), h.GetMetricsClient(), h.GetLogger())
 	h.domainCache.Start()
 	h.controller = newShardController(h.Service, h.GetHostInfo(), hServiceResolver, h.shardManager, h.historyMgr, h.historyV2Mgr,
 		h.domainCache, h.executionMgrFactory, h, h.config, h.GetLogger(), h.GetMetricsClient())
 	h.metricsClient = h.GetMetricsClient()
 	h.historyEventNotifier = newHistoryEventNotifier(h.Service.GetTimeSource(), h.GetMetricsClient(), h.config.GetShardID)
+	h.clientVersions = newClientVersionTracker(h.config.ClientVersionTrackerMaxSize, h.Service.GetTimeSource(), h.GetMetricsClient())
 	// events notifier must starts before controller
 	h.historyEventNotifier.Start()
 	h.controller.Start()
@@ -171,6 +188,9 @@ func (h *Handler) Start() error {
 func (h *Handler) Stop() {
 	h.domainCache.Stop()
 	h.controller.Stop()
+	if h.publisher != nil {
+		h.publisher.Close()
+	}
 	h.shardManager.Close()
 	h.historyMgr.Close()
 	if h.historyV2Mgr != nil {
@@ -186,13 +206,18 @@ func (h *Handler) Stop() {
 // CreateEngine is implementation for HistoryEngineFactory used for creating the engine instance for shard
 func (h *Handler) CreateEngine(context ShardContext) Engine {
 	return NewEngineWithShardContext(context, h.visibilityMgr, h.matchingServiceClient, h.historyServiceClient,
-		h.publicClient, h.historyEventNotifier, h.publisher, h.config, h.archiverProvider)
+		h.publicClient, h.historyEventNotifier, h.publisher, h.config, h.archiverProvider, h.clientVersions)
 }
 
 // Health is for health check
 func (h *Handler) Health(ctx context.Context) (*health.HealthStatus, error) {
 	h.startWG.Wait()
 	h.GetLogger().Debug("History health check endpoint reached.")
+	if err := h.pingCache.Ping(); err != nil {
+		h.GetLogger().Warn("History health check failed to reach persistence.", tag.Error(err))
+		hs := &health.HealthStatus{Ok: false, Msg: common.StringPtr("history persistence unreachable")}
+		return hs, nil
+	}
 	hs := &health.HealthStatus{Ok: true, Msg: common.StringPtr("history good")}
 	return hs, nil
 }
@@ -628,6 +653,15 @@ func (h *Handler) DescribeHistoryHost(ctx context.Context,
 	} else {
 		status += "not stopping"
 	}
+	if h.controller.isDrainingHost() {
+		status += ",draining"
+	}
+
+	shardInfos := h.controller.shardInfos()
+	numOfCachedExecutions := int32(0)
+	for _, info := range shardInfos {
+		numOfCachedExecutions += info.GetNumberOfCachedExecutions()
+	}
 
 	resp = &gen.DescribeHistoryHostResponse{
 		NumberOfShards: common.Int32Ptr(int32(h.controller.numShards())),
@@ -636,12 +670,109 @@ func (h *Handler) DescribeHistoryHost(ctx context.Context,
 			NumOfItemsInCacheByID:   &numOfItemsInCacheByID,
 			NumOfItemsInCacheByName: &numOfItemsInCacheByName,
 		},
-		ShardControllerStatus: &status,
-		Address:               common.StringPtr(h.GetHostInfo().GetAddress()),
+		ShardControllerStatus:    &status,
+		Address:                  common.StringPtr(h.GetHostInfo().GetAddress()),
+		NumberOfCachedExecutions: common.Int32Ptr(numOfCachedExecutions),
+		ShardInfos:               shardInfos,
+		ShardMovements:           h.controller.shardMovements(),
+		BuildInfo: &gen.BuildInfo{
+			Revision:  common.StringPtr(metrics.Revision),
+			Branch:    common.StringPtr(metrics.Branch),
+			Version:   common.StringPtr(metrics.Version),
+			BuildDate: common.StringPtr(metrics.BuildDate),
+			GoVersion: common.StringPtr(runtime.Version()),
+		},
 	}
 	return resp, nil
 }
 
+// DrainHistoryHost stops this host from acquiring shards and releases the shards it owns after
+// finishing their in-flight writes and persisting their ack levels, then removes the host from
+// the membership ring so the shards are acquired by the other hosts
+func (h *Handler) DrainHistoryHost(ctx context.Context,
+	request *gen.DrainHistoryHostRequest) (resp *gen.DrainHistoryHostResponse, retError error) {
+	defer log.CapturePanic(h.GetLogger(), &retError)
+	h.startWG.Wait()
+
+	scope := metrics.HistoryDrainHistoryHostScope
+	h.metricsClient.IncCounter(scope, metrics.CadenceRequests)
+	sw := h.metricsClient.StartTimer(scope, metrics.CadenceLatency)
+	defer sw.Stop()
+
+	drained, failed := h.controller.drain()
+	if err := h.GetMembershipMonitor().EvictSelf(); err != nil {
+		return nil, h.error(err, scope, "", "")
+	}
+	return &gen.DrainHistoryHostResponse{
+		Address:         common.StringPtr(h.GetHostInfo().GetAddress()),
+		DrainedShardIDs: drained,
+		FailedShardIDs:  failed,
+	}, nil
+}
+
+// UndrainHistoryHost adds this host back to the membership ring and lets it acquire the shards
+// the ring assigns to it again
+func (h *Handler) UndrainHistoryHost(ctx context.Context,
+	request *gen.UndrainHistoryHostRequest) (resp *gen.UndrainHistoryHostResponse, retError error) {
+	defer log.CapturePanic(h.GetLogger(), &retError)
+	h.startWG.Wait()
+
+	scope := metrics.HistoryUndrainHistoryHostScope
+	h.metricsClient.IncCounter(scope, metrics.CadenceRequests)
+	sw := h.metricsClient.StartTimer(scope, metrics.CadenceLatency)
+	defer sw.Stop()
+
+	h.controller.undrain()
+	if err := h.GetMembershipMonitor().RejoinSelf(); err != nil {
+		return nil, h.error(err, scope, "", "")
+	}
+	return &gen.UndrainHistoryHostResponse{
+		Address: common.StringPtr(h.GetHostInfo().GetAddress()),
+	}, nil
+}
+
+// DescribeClientVersions returns the client versions which completed decisions on this host since it started
+func (h *Handler) DescribeClientVersions(ctx context.Context,
+	request *gen.DescribeClientVersionsRequest) (resp *gen.DescribeClientVersionsResponse, retError error) {
+	defer log.CapturePanic(h.GetLogger(), &retError)
+	h.startWG.Wait()
+
+	scope := metrics.HistoryDescribeClientVersionsScope
+	h.metricsClient.IncCounter(scope, metrics.CadenceRequests)
+	sw := h.metricsClient.StartTimer(scope, metrics.CadenceLatency)
+	defer sw.Stop()
+
+	return &gen.DescribeClientVersionsResponse{
+		ClientVersions: h.clientVersions.describe(request.GetDomain()),
+	}, nil
+}
+
+// GetDomainOpenExecutionCount returns the approximate number of open executions of the domain
+// in the shards owned by this host
+func (h *Handler) GetDomainOpenExecutionCount(ctx context.Context,
+	request *gen.GetDomainOpenExecutionCountRequest) (resp *gen.GetDomainOpenExecutionCountResponse, retError error) {
+	defer log.CapturePanic(h.GetLogger(), &retError)
+	h.startWG.Wait()
+
+	scope := metrics.HistoryGetDomainOpenExecutionCountScope
+	h.metricsClient.IncCounter(scope, metrics.CadenceRequests)
+	sw := h.metricsClient.StartTimer(scope, metrics.CadenceLatency)
+	defer sw.Stop()
+
+	if request.GetDomain() == "" {
+		return nil, h.error(errDomainNotSet, scope, "", "")
+	}
+
+	domainID, err := h.domainCache.GetDomainID(request.GetDomain())
+	if err != nil {
+		return nil, h.error(err, scope, "", "")
+	}
+
+	return &gen.GetDomainOpenExecutionCountResponse{
+		Count: common.Int64Ptr(h.controller.domainOpenExecutionCount(domainID)),
+	}, nil
+}
+
 // DescribeMutableState - returns the internal analysis of workflow execution state
 func (h *Handler) DescribeMutableState(ctx context.Context,
 	request *hist.DescribeMutableStateRequest) (resp *hist.DescribeMutableStateResponse, retError error) {
@@ -816,6 +947,42 @@ func (h *Handler) SignalWorkflowExecution(ctx context.Context,
 	return nil
 }
 
+// UpdateWorkflowExecution is used to send an update to running workflow execution. The update is handed
+// to the worker with the next decision task and the call blocks until the worker reports its result.
+func (h *Handler) UpdateWorkflowExecution(ctx context.Context,
+	wrappedRequest *hist.UpdateWorkflowExecutionRequest) (resp *gen.UpdateWorkflowExecutionResponse, retError error) {
+	defer log.CapturePanic(h.GetLogger(), &retError)
+	h.startWG.Wait()
+
+	scope := metrics.HistoryUpdateWorkflowExecutionScope
+	h.metricsClient.IncCounter(scope, metrics.CadenceRequests)
+	sw := h.metricsClient.StartTimer(scope, metrics.CadenceLatency)
+	defer sw.Stop()
+
+	domainID := wrappedRequest.GetDomainUUID()
+	if domainID == "" {
+		return nil, h.error(errDomainNotSet, scope, domainID, "")
+	}
+
+	if ok, _ := h.rateLimiter.TryConsume(1); !ok {
+		return nil, h.error(errHistoryHostThrottle, scope, domainID, "")
+	}
+
+	workflowExecution := wrappedRequest.UpdateRequest.WorkflowExecution
+	workflowID := workflowExecution.GetWorkflowId()
+	engine, err1 := h.controller.GetEngine(workflowID)
+	if err1 != nil {
+		return nil, h.error(err1, scope, domainID, workflowID)
+	}
+
+	resp, err2 := engine.UpdateWorkflowExecution(ctx, wrappedRequest)
+	if err2 != nil {
+		return nil, h.error(err2, scope, domainID, workflowID)
+	}
+
+	return resp, nil
+}
+
 // SignalWithStartWorkflowExecution is used to ensure sending a signal event to a workflow execution.
 // If workflow is running, this results in WorkflowExecutionSignaled event recorded in the history
 // and a decision task being created for the execution.
@@ -891,6 +1058,260 @@ func (h *Handler) RemoveSignalMutableState(ctx context.Context,
 	return nil
 }
 
+// SetActivityPaused pauses or unpauses a pending activity of a workflow execution.
+func (h *Handler) SetActivityPaused(ctx context.Context,
+	wrappedRequest *hist.SetActivityPausedRequest) (retError error) {
+	defer log.CapturePanic(h.GetLogger(), &retError)
+	h.startWG.Wait()
+
+	scope := metrics.HistorySetActivityPausedScope
+	h.metricsClient.IncCounter(scope, metrics.CadenceRequests)
+	sw := h.metricsClient.StartTimer(scope, metrics.CadenceLatency)
+	defer sw.Stop()
+
+	domainID := wrappedRequest.GetDomainUUID()
+	if domainID == "" {
+		return h.error(errDomainNotSet, scope, domainID, "")
+	}
+
+	if ok, _ := h.rateLimiter.TryConsume(1); !ok {
+		return h.error(errHistoryHostThrottle, scope, domainID, "")
+	}
+
+	workflowExecution := wrappedRequest.PauseRequest.Execution
+	workflowID := workflowExecution.GetWorkflowId()
+	engine, err1 := h.controller.GetEngine(workflowID)
+	if err1 != nil {
+		return h.error(err1, scope, domainID, workflowID)
+	}
+
+	err2 := engine.SetActivityPaused(ctx, wrappedRequest)
+	if err2 != nil {
+		return h.error(err2, scope, domainID, workflowID)
+	}
+
+	return nil
+}
+
+// SetWorkflowExecutionNotes attaches operator notes to a workflow execution.
+func (h *Handler) SetWorkflowExecutionNotes(ctx context.Context,
+	wrappedRequest *hist.SetWorkflowExecutionNotesRequest) (retError error) {
+	defer log.CapturePanic(h.GetLogger(), &retError)
+	h.startWG.Wait()
+
+	scope := metrics.HistorySetWorkflowExecutionNotesScope
+	h.metricsClient.IncCounter(scope, metrics.CadenceRequests)
+	sw := h.metricsClient.StartTimer(scope, metrics.CadenceLatency)
+	defer sw.Stop()
+
+	domainID := wrappedRequest.GetDomainUUID()
+	if domainID == "" {
+		return h.error(errDomainNotSet, scope, domainID, "")
+	}
+
+	if ok, _ := h.rateLimiter.TryConsume(1); !ok {
+		return h.error(errHistoryHostThrottle, scope, domainID, "")
+	}
+
+	workflowExecution := wrappedRequest.NotesRequest.Execution
+	workflowID := workflowExecution.GetWorkflowId()
+	engine, err1 := h.controller.GetEngine(workflowID)
+	if err1 != nil {
+		return h.error(err1, scope, domainID, workflowID)
+	}
+
+	err2 := engine.SetWorkflowExecutionNotes(ctx, wrappedRequest)
+	if err2 != nil {
+		return h.error(err2, scope, domainID, workflowID)
+	}
+
+	return nil
+}
+
+// SetWorkflowExecutionPaused pauses or resumes a workflow execution.
+func (h *Handler) SetWorkflowExecutionPaused(ctx context.Context,
+	wrappedRequest *hist.SetWorkflowExecutionPausedRequest) (retError error) {
+	defer log.CapturePanic(h.GetLogger(), &retError)
+	h.startWG.Wait()
+
+	scope := metrics.HistorySetWorkflowExecutionPausedScope
+	h.metricsClient.IncCounter(scope, metrics.CadenceRequests)
+	sw := h.metricsClient.StartTimer(scope, metrics.CadenceLatency)
+	defer sw.Stop()
+
+	domainID := wrappedRequest.GetDomainUUID()
+	if domainID == "" {
+		return h.error(errDomainNotSet, scope, domainID, "")
+	}
+
+	if ok, _ := h.rateLimiter.TryConsume(1); !ok {
+		return h.error(errHistoryHostThrottle, scope, domainID, "")
+	}
+
+	workflowExecution := wrappedRequest.PauseRequest.Execution
+	workflowID := workflowExecution.GetWorkflowId()
+	engine, err1 := h.controller.GetEngine(workflowID)
+	if err1 != nil {
+		return h.error(err1, scope, domainID, workflowID)
+	}
+
+	err2 := engine.SetWorkflowExecutionPaused(ctx, wrappedRequest)
+	if err2 != nil {
+		return h.error(err2, scope, domainID, workflowID)
+	}
+
+	return nil
+}
+
+// SetCronSchedulePaused pauses or resumes the cron schedule of a workflow.
+func (h *Handler) SetCronSchedulePaused(ctx context.Context,
+	wrappedRequest *hist.SetCronSchedulePausedRequest) (retError error) {
+	defer log.CapturePanic(h.GetLogger(), &retError)
+	h.startWG.Wait()
+
+	scope := metrics.HistorySetCronSchedulePausedScope
+	h.metricsClient.IncCounter(scope, metrics.CadenceRequests)
+	sw := h.metricsClient.StartTimer(scope, metrics.CadenceLatency)
+	defer sw.Stop()
+
+	domainID := wrappedRequest.GetDomainUUID()
+	if domainID == "" {
+		return h.error(errDomainNotSet, scope, domainID, "")
+	}
+
+	if ok, _ := h.rateLimiter.TryConsume(1); !ok {
+		return h.error(errHistoryHostThrottle, scope, domainID, "")
+	}
+
+	workflowExecution := wrappedRequest.PauseRequest.Execution
+	workflowID := workflowExecution.GetWorkflowId()
+	engine, err1 := h.controller.GetEngine(workflowID)
+	if err1 != nil {
+		return h.error(err1, scope, domainID, workflowID)
+	}
+
+	err2 := engine.SetCronSchedulePaused(ctx, wrappedRequest)
+	if err2 != nil {
+		return h.error(err2, scope, domainID, workflowID)
+	}
+
+	return nil
+}
+
+// TerminateAllWorkflowRuns terminates the current run of a workflow ID and temporarily
+// blocks it from starting new runs.
+func (h *Handler) TerminateAllWorkflowRuns(ctx context.Context,
+	wrappedRequest *hist.TerminateAllWorkflowRunsRequest) (resp *gen.TerminateAllWorkflowRunsResponse, retError error) {
+	defer log.CapturePanic(h.GetLogger(), &retError)
+	h.startWG.Wait()
+
+	scope := metrics.HistoryTerminateAllWorkflowRunsScope
+	h.metricsClient.IncCounter(scope, metrics.CadenceRequests)
+	sw := h.metricsClient.StartTimer(scope, metrics.CadenceLatency)
+	defer sw.Stop()
+
+	domainID := wrappedRequest.GetDomainUUID()
+	if domainID == "" {
+		return nil, h.error(errDomainNotSet, scope, domainID, "")
+	}
+
+	if ok, _ := h.rateLimiter.TryConsume(1); !ok {
+		return nil, h.error(errHistoryHostThrottle, scope, domainID, "")
+	}
+
+	workflowID := wrappedRequest.TerminateRequest.GetWorkflowId()
+	engine, err1 := h.controller.GetEngine(workflowID)
+	if err1 != nil {
+		return nil, h.error(err1, scope, domainID, workflowID)
+	}
+
+	resp, err2 := engine.TerminateAllWorkflowRuns(ctx, wrappedRequest)
+	if err2 != nil {
+		return nil, h.error(err2, scope, domainID, workflowID)
+	}
+
+	return resp, nil
+}
+
+// ImportWorkflowExecution recreates a closed workflow execution from an exported snapshot as a new run of the workflow
+func (h *Handler) ImportWorkflowExecution(ctx context.Context,
+	wrappedRequest *hist.ImportWorkflowExecutionRequest) (resp *hist.ImportWorkflowExecutionResponse, retError error) {
+	defer log.CapturePanic(h.GetLogger(), &retError)
+	h.startWG.Wait()
+
+	scope := metrics.HistoryImportWorkflowExecutionScope
+	h.metricsClient.IncCounter(scope, metrics.CadenceRequests)
+	sw := h.metricsClient.StartTimer(scope, metrics.CadenceLatency)
+	defer sw.Stop()
+
+	domainID := wrappedRequest.GetDomainUUID()
+	if domainID == "" {
+		return nil, h.error(errDomainNotSet, scope, domainID, "")
+	}
+
+	if ok, _ := h.rateLimiter.TryConsume(1); !ok {
+		return nil, h.error(errHistoryHostThrottle, scope, domainID, "")
+	}
+
+	workflowID := wrappedRequest.GetWorkflowId()
+	if workflowID == "" {
+		return nil, h.error(errWorkflowIDNotSet, scope, domainID, "")
+	}
+	engine, err1 := h.controller.GetEngine(workflowID)
+	if err1 != nil {
+		return nil, h.error(err1, scope, domainID, workflowID)
+	}
+
+	resp, err2 := engine.ImportWorkflowExecution(ctx, wrappedRequest)
+	if err2 != nil {
+		return nil, h.error(err2, scope, domainID, workflowID)
+	}
+
+	return resp, nil
+}
+
+// PurgeWorkflowExecution deletes a closed run from the persistence of the cluster and evicts it from the cache of
+// the host owning the workflow
+func (h *Handler) PurgeWorkflowExecution(ctx context.Context,
+	wrappedRequest *hist.PurgeWorkflowExecutionRequest) (retError error) {
+	defer log.CapturePanic(h.GetLogger(), &retError)
+	h.startWG.Wait()
+
+	scope := metrics.HistoryPurgeWorkflowExecutionScope
+	h.metricsClient.IncCounter(scope, metrics.CadenceRequests)
+	sw := h.metricsClient.StartTimer(scope, metrics.CadenceLatency)
+	defer sw.Stop()
+
+	domainID := wrappedRequest.GetDomainUUID()
+	if domainID == "" {
+		return h.error(errDomainNotSet, scope, domainID, "")
+	}
+
+	if ok, _ := h.rateLimiter.TryConsume(1); !ok {
+		return h.error(errHistoryHostThrottle, scope, domainID, "")
+	}
+
+	workflowExecution := wrappedRequest.WorkflowExecution
+	workflowID := workflowExecution.GetWorkflowId()
+	if workflowID == "" {
+		return h.error(errWorkflowIDNotSet, scope, domainID, "")
+	}
+	if workflowExecution.GetRunId() == "" {
+		return h.error(errRunIDNotValid, scope, domainID, workflowID)
+	}
+	engine, err1 := h.controller.GetEngine(workflowID)
+	if err1 != nil {
+		return h.error(err1, scope, domainID, workflowID)
+	}
+
+	err2 := engine.PurgeWorkflowExecution(ctx, wrappedRequest)
+	if err2 != nil {
+		return h.error(err2, scope, domainID, workflowID)
+	}
+
+	return nil
+}
+
 // TerminateWorkflowExecution terminates an existing workflow execution by recording WorkflowExecutionTerminated event
 // in the history and immediately terminating the execution instance.
 func (h *Handler) TerminateWorkflowExecution(ctx context.Context,
@@ -1231,6 +1652,36 @@ func (h *Handler) SyncActivity(ctx context.Context, syncActivityRequest *hist.Sy
 	return nil
 }
 
+// ReconcileDomainOpenExecutionCounts is called by the scanner to reconcile the approximate
+// open execution counters of a shard
+func (h *Handler) ReconcileDomainOpenExecutionCounts(ctx context.Context,
+	request *hist.ReconcileDomainOpenExecutionCountsRequest) (retError error) {
+	defer log.CapturePanic(h.GetLogger(), &retError)
+	h.startWG.Wait()
+
+	scope := metrics.HistoryReconcileDomainOpenExecutionCountsScope
+	h.metricsClient.IncCounter(scope, metrics.CadenceRequests)
+	sw := h.metricsClient.StartTimer(scope, metrics.CadenceLatency)
+	defer sw.Stop()
+
+	if request.ShardID == nil {
+		return h.error(errShardIDNotSet, scope, "", "")
+	}
+
+	// shard ID is already provided in the request
+	engine, err := h.controller.getEngineForShard(int(request.GetShardID()))
+	if err != nil {
+		return h.error(err, scope, "", "")
+	}
+
+	err = engine.ReconcileDomainOpenExecutionCounts(ctx, request)
+	if err != nil {
+		return h.error(err, scope, "", "")
+	}
+
+	return nil
+}
+
 // convertError is a helper method to convert ShardOwnershipLostError from persistence layer returned by various
 // HistoryEngine API calls to ShardOwnershipLost error return by HistoryService for client to be redirected to the
 // correct shard.
@@ -1256,6 +1707,8 @@ func (h *Handler) convertError(err error) error {
 	case *persistence.TransactionSizeLimitError:
 		err := err.(*persistence.TransactionSizeLimitError)
 		return &gen.BadRequestError{Message: err.Msg}
+	case common.StoreInternalError:
+		return err.(common.StoreInternalError).InternalServiceError()
 	}
 
 	return err
@@ -1272,6 +1725,8 @@ func (h *Handler) updateErrorMetric(scope int, domainID, workflowID string, err
 		h.metricsClient.IncCounter(scope, metrics.CadenceErrShardOwnershipLostCounter)
 	case *hist.EventAlreadyStartedError:
 		h.metricsClient.IncCounter(scope, metrics.CadenceErrEventAlreadyStartedCounter)
+	case *hist.WorkflowExecutionPausedError:
+		h.metricsClient.IncCounter(scope, metrics.CadenceErrWorkflowExecutionPausedCounter)
 	case *gen.BadRequestError:
 		h.metricsClient.IncCounter(scope, metrics.CadenceErrBadRequestCounter)
 	case *gen.DomainNotActiveError:
@@ -382,6 +382,32 @@ func (b *historyBuilder) AddWorkflowExecutionSignaledEvent(
 	return b.addEventToHistory(event)
 }
 
+func (b *historyBuilder) AddWorkflowExecutionUpdateRequestedEvent(
+	updateID string, request *workflow.UpdateWorkflowExecutionRequest) *workflow.HistoryEvent {
+	event := b.newWorkflowExecutionUpdateRequestedEvent(updateID, request)
+
+	return b.addEventToHistory(event)
+}
+
+func (b *historyBuilder) AddWorkflowExecutionUpdateCompletedEvent(
+	decisionTaskCompletedEventID int64, updateID string, result []byte) *workflow.HistoryEvent {
+	event := b.newWorkflowExecutionUpdateCompletedEvent(decisionTaskCompletedEventID, updateID, result)
+
+	return b.addEventToHistory(event)
+}
+
+func (b *historyBuilder) AddWorkflowExecutionPauseUpdatedEvent(paused bool) *workflow.HistoryEvent {
+	event := b.newWorkflowExecutionPauseUpdatedEvent(paused)
+
+	return b.addEventToHistory(event)
+}
+
+func (b *historyBuilder) AddCronSchedulePauseUpdatedEvent(paused bool) *workflow.HistoryEvent {
+	event := b.newCronSchedulePauseUpdatedEvent(paused)
+
+	return b.addEventToHistory(event)
+}
+
 func (b *historyBuilder) AddStartChildWorkflowExecutionInitiatedEvent(decisionCompletedEventID int64,
 	attributes *workflow.StartChildWorkflowExecutionDecisionAttributes) *workflow.HistoryEvent {
 	event := b.newStartChildWorkflowExecutionInitiatedEvent(decisionCompletedEventID, attributes)
@@ -489,6 +515,11 @@ func (b *historyBuilder) newWorkflowExecutionStartedEvent(
 	attributes.Attempt = common.Int32Ptr(startRequest.GetAttempt())
 	attributes.ExpirationTimestamp = startRequest.ExpirationTimestamp
 	attributes.CronSchedule = request.CronSchedule
+	attributes.CronTimezone = request.CronTimezone
+	if previousExecution != nil && previousExecution.CronPaused && len(request.GetCronSchedule()) != 0 {
+		attributes.CronPaused = common.BoolPtr(true)
+	}
+	attributes.SlaDeadlineTimestamp = request.SlaDeadlineTimestamp
 	attributes.LastCompletionResult = startRequest.LastCompletionResult
 	attributes.ContinuedFailureReason = startRequest.ContinuedFailureReason
 	attributes.ContinuedFailureDetails = startRequest.ContinuedFailureDetails
@@ -686,6 +717,49 @@ func (b *historyBuilder) newWorkflowExecutionSignaledEvent(
 	return historyEvent
 }
 
+func (b *historyBuilder) newWorkflowExecutionUpdateRequestedEvent(
+	updateID string, request *workflow.UpdateWorkflowExecutionRequest) *workflow.HistoryEvent {
+	historyEvent := b.msBuilder.CreateNewHistoryEvent(workflow.EventTypeWorkflowExecutionUpdateRequested)
+	attributes := &workflow.WorkflowExecutionUpdateRequestedEventAttributes{}
+	attributes.UpdateId = common.StringPtr(updateID)
+	attributes.UpdateName = common.StringPtr(request.GetUpdateName())
+	attributes.Input = request.Input
+	attributes.Identity = common.StringPtr(request.GetIdentity())
+	historyEvent.WorkflowExecutionUpdateRequestedEventAttributes = attributes
+
+	return historyEvent
+}
+
+func (b *historyBuilder) newWorkflowExecutionUpdateCompletedEvent(
+	decisionTaskCompletedEventID int64, updateID string, result []byte) *workflow.HistoryEvent {
+	historyEvent := b.msBuilder.CreateNewHistoryEvent(workflow.EventTypeWorkflowExecutionUpdateCompleted)
+	attributes := &workflow.WorkflowExecutionUpdateCompletedEventAttributes{}
+	attributes.UpdateId = common.StringPtr(updateID)
+	attributes.Result = result
+	attributes.DecisionTaskCompletedEventId = common.Int64Ptr(decisionTaskCompletedEventID)
+	historyEvent.WorkflowExecutionUpdateCompletedEventAttributes = attributes
+
+	return historyEvent
+}
+
+func (b *historyBuilder) newWorkflowExecutionPauseUpdatedEvent(paused bool) *workflow.HistoryEvent {
+	historyEvent := b.msBuilder.CreateNewHistoryEvent(workflow.EventTypeWorkflowExecutionPauseUpdated)
+	attributes := &workflow.WorkflowExecutionPauseUpdatedEventAttributes{}
+	attributes.Paused = common.BoolPtr(paused)
+	historyEvent.WorkflowExecutionPauseUpdatedEventAttributes = attributes
+
+	return historyEvent
+}
+
+func (b *historyBuilder) newCronSchedulePauseUpdatedEvent(paused bool) *workflow.HistoryEvent {
+	historyEvent := b.msBuilder.CreateNewHistoryEvent(workflow.EventTypeCronSchedulePauseUpdated)
+	attributes := &workflow.CronSchedulePauseUpdatedEventAttributes{}
+	attributes.Paused = common.BoolPtr(paused)
+	historyEvent.CronSchedulePauseUpdatedEventAttributes = attributes
+
+	return historyEvent
+}
+
 func (b *historyBuilder) newWorkflowExecutionTerminatedEvent(
 	reason string, details []byte, identity string) *workflow.HistoryEvent {
 	historyEvent := b.msBuilder.CreateNewHistoryEvent(workflow.EventTypeWorkflowExecutionTerminated)
@@ -895,6 +969,7 @@ func (b *historyBuilder) newStartChildWorkflowExecutionInitiatedEvent(decisionTa
 	attributes.WorkflowIdReusePolicy = startAttributes.WorkflowIdReusePolicy
 	attributes.RetryPolicy = startAttributes.RetryPolicy
 	attributes.CronSchedule = startAttributes.CronSchedule
+	attributes.CronTimezone = startAttributes.CronTimezone
 	historyEvent.StartChildWorkflowExecutionInitiatedEventAttributes = attributes
 
 	return historyEvent
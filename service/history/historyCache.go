@@ -111,7 +111,7 @@ func (c *historyCache) getAndCreateWorkflowExecution(
 	sw := c.metricsClient.StartTimer(scope, metrics.CacheLatency)
 	defer sw.Stop()
 
-	if err := c.validateWorkflowExecutionInfo(domainID, &execution); err != nil {
+	if err := c.validateWorkflowExecutionInfo(ctx, domainID, &execution); err != nil {
 		c.metricsClient.IncCounter(scope, metrics.CacheFailures)
 		return nil, nil, nil, false, err
 	}
@@ -159,7 +159,7 @@ func (c *historyCache) getOrCreateWorkflowExecution(
 	sw := c.metricsClient.StartTimer(scope, metrics.CacheLatency)
 	defer sw.Stop()
 
-	if err := c.validateWorkflowExecutionInfo(domainID, &execution); err != nil {
+	if err := c.validateWorkflowExecutionInfo(ctx, domainID, &execution); err != nil {
 		c.metricsClient.IncCounter(scope, metrics.CacheFailures)
 		return nil, nil, err
 	}
@@ -215,6 +215,7 @@ func (c *historyCache) getOrCreateWorkflowExecutionInternal(
 }
 
 func (c *historyCache) validateWorkflowExecutionInfo(
+	ctx context.Context,
 	domainID string,
 	execution *workflow.WorkflowExecution,
 ) error {
@@ -228,6 +229,7 @@ func (c *historyCache) validateWorkflowExecutionInfo(
 		response, err := c.getCurrentExecutionWithRetry(&persistence.GetCurrentExecutionRequest{
 			DomainID:   domainID,
 			WorkflowID: execution.GetWorkflowId(),
+			Context:    persistenceRequestContext(ctx),
 		})
 
 		if err != nil {
@@ -284,3 +286,23 @@ func (c *historyCache) getCurrentExecutionWithRetry(
 
 	return response, nil
 }
+
+// getHotExecutions returns up to limit executions of the cache, the most recently accessed first
+func (c *historyCache) getHotExecutions(limit int) []persistence.HotExecution {
+	var executions []persistence.HotExecution
+	it := c.Iterator()
+	defer it.Close()
+	for it.HasNext() && len(executions) < limit {
+		key := it.Next().Key().(definition.WorkflowIdentifier)
+		if key.RunID == "" {
+			// entries of the current run are only used to serialize the lookup of the current run
+			continue
+		}
+		executions = append(executions, persistence.HotExecution{
+			DomainID:   key.DomainID,
+			WorkflowID: key.WorkflowID,
+			RunID:      key.RunID,
+		})
+	}
+	return executions
+}
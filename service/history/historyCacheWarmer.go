@@ -0,0 +1,151 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package history
+
+import (
+	"sync"
+	"time"
+
+	workflow "github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/log"
+	"github.com/uber/cadence/common/log/tag"
+	"github.com/uber/cadence/common/metrics"
+	"github.com/uber/cadence/common/persistence"
+)
+
+type (
+	// historyCacheWarmer periodically records the most recently active executions of the shard into
+	// the shard info and, when the shard is acquired, loads the executions recorded by the previous
+	// owner into the history cache to avoid the latency spike of cold cache misses after a deploy
+	historyCacheWarmer struct {
+		shard         ShardContext
+		historyCache  *historyCache
+		config        *Config
+		metricsClient metrics.Client
+		logger        log.Logger
+
+		shutdownCh chan struct{}
+		shutdownWG sync.WaitGroup
+	}
+)
+
+func newHistoryCacheWarmer(shard ShardContext, historyCache *historyCache, logger log.Logger) *historyCacheWarmer {
+	return &historyCacheWarmer{
+		shard:         shard,
+		historyCache:  historyCache,
+		config:        shard.GetConfig(),
+		metricsClient: shard.GetMetricsClient(),
+		logger:        logger.WithTags(tag.ComponentHistoryCache),
+		shutdownCh:    make(chan struct{}),
+	}
+}
+
+func (w *historyCacheWarmer) Start() {
+	w.shutdownWG.Add(1)
+	go w.warmUpAndRecordLoop()
+}
+
+func (w *historyCacheWarmer) Stop() {
+	close(w.shutdownCh)
+	w.shutdownWG.Wait()
+}
+
+func (w *historyCacheWarmer) warmUpAndRecordLoop() {
+	defer w.shutdownWG.Done()
+
+	if w.config.HistoryCacheWarmupEnabled() {
+		w.warmUp()
+	}
+
+	timer := time.NewTimer(w.config.HistoryCacheWarmupRecordInterval())
+	defer timer.Stop()
+	for {
+		select {
+		case <-w.shutdownCh:
+			return
+		case <-timer.C:
+			if w.config.HistoryCacheWarmupEnabled() {
+				w.record()
+			}
+			timer.Reset(w.config.HistoryCacheWarmupRecordInterval())
+		}
+	}
+}
+
+// warmUp loads the hot executions recorded in the shard info into the history cache
+func (w *historyCacheWarmer) warmUp() {
+	executions := w.shard.GetHotExecutions()
+	if size := w.config.HistoryCacheWarmupSize(); len(executions) > size {
+		executions = executions[:size]
+	}
+	if len(executions) == 0 {
+		return
+	}
+
+	sw := w.metricsClient.StartTimer(metrics.HistoryCacheWarmupScope, metrics.CacheLatency)
+	defer sw.Stop()
+
+	loaded := 0
+	for _, execution := range executions {
+		select {
+		case <-w.shutdownCh:
+			return
+		default:
+		}
+
+		w.metricsClient.IncCounter(metrics.HistoryCacheWarmupScope, metrics.CacheRequests)
+		if err := w.load(execution); err != nil {
+			w.metricsClient.IncCounter(metrics.HistoryCacheWarmupScope, metrics.CacheFailures)
+			w.logger.Debug("Unable to load hot execution into history cache.",
+				tag.WorkflowDomainID(execution.DomainID),
+				tag.WorkflowID(execution.WorkflowID),
+				tag.WorkflowRunID(execution.RunID),
+				tag.Error(err))
+			continue
+		}
+		loaded++
+	}
+	w.logger.Info("History cache warmed up.", tag.Counter(loaded))
+}
+
+func (w *historyCacheWarmer) load(execution persistence.HotExecution) (retError error) {
+	context, release, err := w.historyCache.getOrCreateWorkflowExecutionForBackground(
+		execution.DomainID,
+		workflow.WorkflowExecution{
+			WorkflowId: common.StringPtr(execution.WorkflowID),
+			RunId:      common.StringPtr(execution.RunID),
+		},
+	)
+	if err != nil {
+		return err
+	}
+	defer func() { release(retError) }()
+
+	_, err = context.loadWorkflowExecution()
+	return err
+}
+
+// record replaces the hot executions of the shard info with the most recently accessed executions of
+// the history cache, they are persisted along with the next shard info update
+func (w *historyCacheWarmer) record() {
+	w.shard.UpdateHotExecutions(w.historyCache.getHotExecutions(w.config.HistoryCacheWarmupSize()))
+}
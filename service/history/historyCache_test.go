@@ -21,9 +21,11 @@
 package history
 
 import (
+	"context"
 	"errors"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/pborman/uuid"
 	"github.com/stretchr/testify/require"
@@ -173,6 +175,59 @@ func (s *historyCacheSuite) TestHistoryCachePinning() {
 	release(err4)
 }
 
+func (s *historyCacheSuite) TestHistoryCacheHotExecutions() {
+	s.mockShard.GetConfig().HistoryCacheMaxSize = dynamicconfig.GetIntPropertyFn(20)
+	domainID := "test_domain_id"
+	s.cache = newHistoryCache(s.mockShard)
+
+	var executions []workflow.WorkflowExecution
+	for i := 0; i < 3; i++ {
+		we := workflow.WorkflowExecution{
+			WorkflowId: common.StringPtr("wf-cache-test-hot-executions"),
+			RunId:      common.StringPtr(uuid.New()),
+		}
+		_, release, err := s.cache.getOrCreateWorkflowExecutionForBackground(domainID, we)
+		s.Nil(err)
+		release(nil)
+		executions = append(executions, we)
+	}
+	// access the first execution again so it becomes the most recently accessed
+	_, release, err := s.cache.getOrCreateWorkflowExecutionForBackground(domainID, executions[0])
+	s.Nil(err)
+	release(nil)
+
+	warmer := newHistoryCacheWarmer(s.mockShard, s.cache, s.logger)
+	s.mockShard.GetConfig().HistoryCacheWarmupSize = dynamicconfig.GetIntPropertyFn(2)
+	warmer.record()
+	s.Equal([]persistence.HotExecution{
+		{DomainID: domainID, WorkflowID: executions[0].GetWorkflowId(), RunID: executions[0].GetRunId()},
+		{DomainID: domainID, WorkflowID: executions[2].GetWorkflowId(), RunID: executions[2].GetRunId()},
+	}, s.mockShard.GetHotExecutions())
+}
+
+func (s *historyCacheSuite) TestHistoryCacheRequestContext() {
+	s.cache = newHistoryCache(s.mockShard)
+
+	domainID := "test_domain_id"
+	execution := workflow.WorkflowExecution{
+		WorkflowId: common.StringPtr("some random workflow ID"),
+		RunId:      common.StringPtr(uuid.New()),
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	workflowCtx, release, err := s.cache.getOrCreateWorkflowExecution(ctx, domainID, execution)
+	s.Nil(err)
+	s.Equal(ctx, workflowCtx.(*workflowExecutionContextImpl).requestCtx)
+	release(nil)
+	s.Nil(workflowCtx.(*workflowExecutionContextImpl).requestCtx)
+
+	workflowCtx, release, err = s.cache.getOrCreateWorkflowExecutionForBackground(domainID, execution)
+	s.Nil(err)
+	s.Nil(workflowCtx.(*workflowExecutionContextImpl).requestCtx)
+	release(nil)
+}
+
 func (s *historyCacheSuite) TestHistoryCacheClear() {
 	s.mockShard.GetConfig().HistoryCacheMaxSize = dynamicconfig.GetIntPropertyFn(20)
 	domainID := "test_domain_id"
@@ -55,6 +55,7 @@ const (
 	activityCancellationMsgActivityIDUnknown  = "ACTIVITY_ID_UNKNOWN"
 	activityCancellationMsgActivityNotStarted = "ACTIVITY_ID_NOT_STARTED"
 	timerCancellationMsgTimerIDUnknown        = "TIMER_ID_UNKNOWN"
+	defaultTerminateAllWorkflowRunsBlock      = time.Minute
 )
 
 type (
@@ -72,9 +73,11 @@ type (
 		taskAllocator        taskAllocator
 		replicator           *historyReplicator
 		replicatorProcessor  queueProcessor
+		visibilityProcessor  queueProcessor
 		historyEventNotifier historyEventNotifier
 		tokenSerializer      common.TaskTokenSerializer
 		historyCache         *historyCache
+		historyCacheWarmer   *historyCacheWarmer
 		metricsClient        metrics.Client
 		logger               log.Logger
 		throttledLogger      log.Logger
@@ -82,6 +85,7 @@ type (
 		archivalClient       archiver.Client
 		resetor              workflowResetor
 		archiverProvider     provider.ArchiverProvider
+		clientVersions       *clientVersionTracker
 	}
 
 	// shardContextWrapper wraps ShardContext to notify transferQueueProcessor on new tasks.
@@ -91,6 +95,7 @@ type (
 		ShardContext
 		txProcessor          transferQueueProcessor
 		replicatorProcessor  queueProcessor
+		visibilityProcessor  queueProcessor
 		historyEventNotifier historyEventNotifier
 	}
 )
@@ -112,10 +117,22 @@ var (
 	ErrStaleState = errors.New("Cache mutable state could potentially be stale")
 	// ErrActivityTaskNotFound is the error to indicate activity task could be duplicate and activity already completed
 	ErrActivityTaskNotFound = &workflow.EntityNotExistsError{Message: "Activity task not found."}
+	// ErrActivityTaskPaused is the error to indicate activity task is paused and should not be dispatched
+	ErrActivityTaskPaused = &workflow.EntityNotExistsError{Message: "Activity task is paused."}
+	// ErrWorkflowPaused is the error to indicate workflow execution is paused and decisions should not be dispatched
+	ErrWorkflowPaused = &h.WorkflowExecutionPausedError{Message: "Workflow execution is paused."}
 	// ErrWorkflowCompleted is the error to indicate workflow execution already completed
 	ErrWorkflowCompleted = &workflow.EntityNotExistsError{Message: "Workflow execution already completed."}
 	// ErrWorkflowParent is the error to parent execution is given and mismatch
 	ErrWorkflowParent = &workflow.EntityNotExistsError{Message: "Workflow parent does not match."}
+	// ErrWorkflowChain is the error to first execution run ID is given and mismatch
+	ErrWorkflowChain = &workflow.EntityNotExistsError{Message: "Workflow first execution run ID does not match."}
+	// ErrWorkflowRunsBlocked is the error to indicate the workflow ID is blocked from starting new runs by TerminateAllWorkflowRuns
+	ErrWorkflowRunsBlocked = &workflow.ServiceBusyError{Message: "Workflow ID is temporarily blocked from starting new runs."}
+	// ErrWorkflowNotCron is the error to indicate the workflow execution does not have a cron schedule
+	ErrWorkflowNotCron = &workflow.BadRequestError{Message: "Workflow execution does not have a cron schedule."}
+	// ErrUpdateNotFound is the error to indicate workflow update is no longer pending
+	ErrUpdateNotFound = &workflow.EntityNotExistsError{Message: "Workflow update not found."}
 	// ErrDeserializingToken is the error to indicate task token is invalid
 	ErrDeserializingToken = &workflow.BadRequestError{Message: "Error deserializing task token."}
 	// ErrSignalOverSize is the error to indicate signal input size is > 256K
@@ -150,6 +167,7 @@ func NewEngineWithShardContext(
 	publisher messaging.Producer,
 	config *Config,
 	archiverProvider provider.ArchiverProvider,
+	clientVersions *clientVersionTracker,
 ) Engine {
 	currentClusterName := shard.GetService().GetClusterMetadata().GetCurrentClusterName()
 	shardWrapper := &shardContextWrapper{
@@ -171,7 +189,7 @@ func NewEngineWithShardContext(
 		historyV2Mgr:         historyV2Manager,
 		executionManager:     executionManager,
 		visibilityMgr:        visibilityMgr,
-		tokenSerializer:      common.NewJSONTaskTokenSerializer(),
+		tokenSerializer:      shard.GetService().GetTaskTokenSerializer(),
 		historyCache:         historyCache,
 		logger:               logger.WithTags(tag.ComponentHistoryEngine),
 		throttledLogger:      shard.GetThrottledLogger().WithTags(tag.ComponentHistoryEngine),
@@ -180,12 +198,16 @@ func NewEngineWithShardContext(
 		config:               config,
 		archivalClient:       archiver.NewClient(shard.GetMetricsClient(), shard.GetLogger(), publicClient, shard.GetConfig().NumArchiveSystemWorkflows, shard.GetConfig().ArchiveRequestRPS),
 		archiverProvider:     archiverProvider,
+		clientVersions:       clientVersions,
 	}
 
 	txProcessor := newTransferQueueProcessor(shard, historyEngImpl, visibilityMgr, matching, historyClient, logger)
 	historyEngImpl.timerProcessor = newTimerQueueProcessor(shard, historyEngImpl, matching, logger)
 	historyEngImpl.txProcessor = txProcessor
 	shardWrapper.txProcessor = txProcessor
+	visibilityProcessor := newVisibilityQueueProcessor(shard, historyEngImpl.historyCache, visibilityMgr, executionManager, logger)
+	historyEngImpl.visibilityProcessor = visibilityProcessor
+	shardWrapper.visibilityProcessor = visibilityProcessor
 
 	// Only start the replicator processor if valid publisher is passed in
 	if publisher != nil {
@@ -197,6 +219,7 @@ func NewEngineWithShardContext(
 	}
 	historyEngImpl.resetor = newWorkflowResetor(historyEngImpl)
 	historyEngImpl.decisionHandler = newDecisionHandler(historyEngImpl)
+	historyEngImpl.historyCacheWarmer = newHistoryCacheWarmer(shard, historyCache, logger)
 
 	return historyEngImpl
 }
@@ -208,6 +231,7 @@ func (e *historyEngineImpl) Start() {
 	e.logger.Info("", tag.LifeCycleStarting)
 	defer e.logger.Info("", tag.LifeCycleStarted)
 
+	e.refreshStaleDomainCache()
 	e.registerDomainFailoverCallback()
 
 	e.txProcessor.Start()
@@ -215,6 +239,12 @@ func (e *historyEngineImpl) Start() {
 	if e.replicatorProcessor != nil {
 		e.replicatorProcessor.Start()
 	}
+	if e.visibilityProcessor != nil {
+		e.visibilityProcessor.Start()
+	}
+	if e.historyCacheWarmer != nil {
+		e.historyCacheWarmer.Start()
+	}
 }
 
 // Stop the service.
@@ -227,11 +257,88 @@ func (e *historyEngineImpl) Stop() {
 	if e.replicatorProcessor != nil {
 		e.replicatorProcessor.Stop()
 	}
+	if e.visibilityProcessor != nil {
+		e.visibilityProcessor.Stop()
+	}
+	if e.historyCacheWarmer != nil {
+		e.historyCacheWarmer.Stop()
+	}
 
 	// unset the failover callback
 	e.shard.GetDomainCache().UnregisterDomainChangeCallback(e.shard.GetShardID())
 }
 
+// DescribeShard returns the cache and queue processing state of the shard owned by this engine
+func (e *historyEngineImpl) DescribeShard() *workflow.HistoryShardInfo {
+	transferAckLevel := e.shard.GetTransferAckLevel()
+	transferMaxReadLevel := e.shard.GetTransferMaxReadLevel()
+	timerAckLevel := e.shard.GetTimerAckLevel()
+	timerQueueLag := e.shard.GetTimeSource().Now().Sub(timerAckLevel)
+	if timerQueueLag < 0 {
+		timerQueueLag = 0
+	}
+
+	var queueAlarms []*workflow.QueueAlarm
+	for queue, alarm := range e.shard.GetQueueAlarms() {
+		queueAlarms = append(queueAlarms, &workflow.QueueAlarm{
+			Queue:                 common.StringPtr(queue),
+			StuckSince:            common.Int64Ptr(alarm.StuckSince.UnixNano()),
+			BlockingTaskID:        common.Int64Ptr(alarm.BlockingTaskID),
+			BlockingTaskTimestamp: common.Int64Ptr(alarm.BlockingTaskTimestamp.UnixNano()),
+		})
+	}
+
+	return &workflow.HistoryShardInfo{
+		ShardID:                  common.Int32Ptr(int32(e.shard.GetShardID())),
+		NumberOfCachedExecutions: common.Int32Ptr(int32(e.historyCache.Size())),
+		TransferAckLevel:         common.Int64Ptr(transferAckLevel),
+		TransferMaxReadLevel:     common.Int64Ptr(transferMaxReadLevel),
+		TransferQueueLag:         common.Int64Ptr(transferMaxReadLevel - transferAckLevel),
+		TimerAckLevel:            common.Int64Ptr(timerAckLevel.UnixNano()),
+		TimerQueueLagInMillis:    common.Int64Ptr(int64(timerQueueLag / time.Millisecond)),
+		ReplicatorAckLevel:       common.Int64Ptr(e.shard.GetReplicatorAckLevel()),
+		QueueAlarms:              queueAlarms,
+		TasksPerSecond:           common.Float64Ptr(e.shard.GetTasksPerSecond()),
+	}
+}
+
+// DomainOpenExecutionCount returns the approximate number of open executions of the domain in the shard owned by this engine
+func (e *historyEngineImpl) DomainOpenExecutionCount(domainID string) int64 {
+	return e.shard.GetDomainOpenExecutionCount(domainID)
+}
+
+// ReconcileDomainOpenExecutionCounts starts the scan of the scanner, or reconciles the approximate open
+// execution counters of the shard with the counts of the scan
+func (e *historyEngineImpl) ReconcileDomainOpenExecutionCounts(
+	ctx context.Context,
+	request *h.ReconcileDomainOpenExecutionCountsRequest,
+) error {
+
+	if request.GetStartScan() {
+		e.shard.StartOpenExecutionCountsScan()
+		return nil
+	}
+	return e.shard.ReconcileDomainOpenExecutionCounts(request.GetDomainOpenExecutionCounts())
+}
+
+// refreshStaleDomainCache makes sure the domain cache has caught up with the domain changes
+// already applied to this shard, which may have been done by another host before the shard moved.
+// Processing tasks against an older domain cache could use a stale failover version.
+func (e *historyEngineImpl) refreshStaleDomainCache() {
+	shardNotificationVersion := e.shard.GetDomainNotificationVersion()
+	cacheNotificationVersion := e.shard.GetDomainCache().GetDomainNotificationVersion()
+	if cacheNotificationVersion >= shardNotificationVersion {
+		return
+	}
+
+	e.logger.Warn("Domain cache is stale, refreshing before processing tasks.",
+		tag.ShardDomainNotificationVersion(shardNotificationVersion),
+		tag.DomainCacheNotificationVersion(cacheNotificationVersion))
+	if err := e.shard.GetDomainCache().Refresh(); err != nil {
+		e.logger.Error("Failed to refresh stale domain cache.", tag.Error(err))
+	}
+}
+
 func (e *historyEngineImpl) registerDomainFailoverCallback() {
 
 	// NOTE: READ BEFORE MODIFICATION
@@ -446,6 +553,7 @@ func (e *historyEngineImpl) StartWorkflowExecution(
 			TimeoutType:         persistence.WorkflowBackoffTimeoutTypeCron,
 		})
 	}
+	timerTasks = append(timerTasks, getWorkflowSLATimerTasks(request.GetSlaDeadlineTimestamp())...)
 
 	context := newWorkflowExecutionContext(domainID, execution, e.shard, e.executionManager, e.logger)
 	createReplicationTask := domainEntry.CanReplicateEvent()
@@ -477,6 +585,11 @@ func (e *historyEngineImpl) StartWorkflowExecution(
 				// delete history is expected here because duplicate start request will create history with different rid
 			}
 
+			if t.State == persistence.WorkflowStateCompleted && t.BlockedUntil.After(e.timeSource.Now()) {
+				e.deleteEvents(domainID, execution, eventStoreVersion, msBuilder.GetCurrentBranch())
+				return nil, ErrWorkflowRunsBlocked
+			}
+
 			if msBuilder.GetCurrentVersion() < t.LastWriteVersion {
 				e.deleteEvents(domainID, execution, eventStoreVersion, msBuilder.GetCurrentBranch())
 				return nil, ce.NewDomainNotActiveError(
@@ -665,7 +778,14 @@ func (e *historyEngineImpl) DescribeMutableState(
 	}
 
 	msb, retError := dbCtx.loadWorkflowExecution()
+	if retError != nil {
+		return nil, retError
+	}
 	retResp.MutableStateInDatabase, retError = e.toMutableStateJSON(msb)
+	// the last applied event batch, used to find which batch a standby cluster is missing
+	executionInfo := msb.GetExecutionInfo()
+	retResp.LastFirstEventId = common.Int64Ptr(executionInfo.LastFirstEventID)
+	retResp.LastEventTaskId = common.Int64Ptr(executionInfo.LastEventTaskID)
 
 	return
 }
@@ -765,10 +885,38 @@ func (e *historyEngineImpl) DescribeWorkflowExecution(
 	if executionInfo.HasRetryPolicy && (executionInfo.Attempt > 0) {
 		backoffDuration = time.Duration(float64(executionInfo.InitialInterval)*math.Pow(executionInfo.BackoffCoefficient, float64(executionInfo.Attempt-1))) * time.Second
 	} else if len(executionInfo.CronSchedule) != 0 {
-		backoffDuration = backoff.GetBackoffForNextSchedule(executionInfo.CronSchedule, executionInfo.StartTimestamp)
+		backoffDuration = backoff.GetBackoffForNextSchedule(executionInfo.CronSchedule, executionInfo.CronTimezone, executionInfo.StartTimestamp)
 	}
 	result.WorkflowExecutionInfo.ExecutionTime = common.Int64Ptr(result.WorkflowExecutionInfo.GetStartTime() + backoffDuration.Nanoseconds())
 
+	if executionInfo.FirstExecutionRunID != "" {
+		result.WorkflowExecutionInfo.FirstExecutionRunId = common.StringPtr(executionInfo.FirstExecutionRunID)
+	}
+	if len(executionInfo.CronSchedule) != 0 {
+		result.CronScheduleInfo = &workflow.CronScheduleInfo{
+			CronSchedule: common.StringPtr(executionInfo.CronSchedule),
+			Paused:       common.BoolPtr(executionInfo.CronPaused),
+			CronTimezone: common.StringPtr(executionInfo.CronTimezone),
+		}
+		if !executionInfo.CronPaused && msBuilder.IsWorkflowExecutionRunning() {
+			nextFireTime := getNextCronFireTime(msBuilder, e.timeSource.Now())
+			result.CronScheduleInfo.NextFireTime = common.Int64Ptr(nextFireTime.UnixNano())
+		}
+	}
+	if executionInfo.ResetBaseRunID != "" {
+		result.ResetLineage = &workflow.ResetLineageInfo{
+			BaseRunId:   common.StringPtr(executionInfo.ResetBaseRunID),
+			BaseEventId: common.Int64Ptr(executionInfo.ResetBaseEventID),
+			Reason:      common.StringPtr(executionInfo.ResetReason),
+		}
+	}
+	if executionInfo.Notes != "" {
+		result.ExecutionNotes = &workflow.WorkflowExecutionNotes{
+			Notes:                common.StringPtr(executionInfo.Notes),
+			Identity:             common.StringPtr(executionInfo.NotesIdentity),
+			LastUpdatedTimestamp: common.Int64Ptr(executionInfo.NotesLastUpdatedTime.UnixNano()),
+		}
+	}
 	if executionInfo.ParentRunID != "" {
 		result.WorkflowExecutionInfo.ParentExecution = &workflow.WorkflowExecution{
 			WorkflowId: common.StringPtr(executionInfo.ParentWorkflowID),
@@ -832,6 +980,20 @@ func (e *historyEngineImpl) DescribeWorkflowExecution(
 		}
 	}
 
+	executionStats, err := context.loadExecutionStats()
+	if err != nil {
+		return nil, err
+	}
+	result.ExecutionStatistics = &workflow.WorkflowExecutionStatistics{
+		HistorySize:                common.Int64Ptr(executionStats.HistorySize),
+		SignalCount:                common.Int32Ptr(executionInfo.SignalCount),
+		PendingActivityCount:       common.Int32Ptr(int32(len(msBuilder.GetPendingActivityInfos()))),
+		PendingTimerCount:          common.Int32Ptr(int32(len(msBuilder.GetPendingTimerInfos()))),
+		PendingChildExecutionCount: common.Int32Ptr(int32(len(msBuilder.GetPendingChildExecutionInfos()))),
+		PendingRequestCancelCount:  common.Int32Ptr(int32(len(msBuilder.GetAllRequestCancels()))),
+		PendingSignalCount:         common.Int32Ptr(int32(len(msBuilder.GetAllSignalsToSend()))),
+	}
+
 	if len(msBuilder.GetPendingChildExecutionInfos()) > 0 {
 		for _, ch := range msBuilder.GetPendingChildExecutionInfos() {
 			p := &workflow.PendingChildExecutionInfo{
@@ -844,6 +1006,28 @@ func (e *historyEngineImpl) DescribeWorkflowExecution(
 		}
 	}
 
+	if msBuilder.HasPendingDecisionTask() {
+		di, ok := msBuilder.GetPendingDecision(executionInfo.DecisionScheduleID)
+		if !ok {
+			return nil, &workflow.InternalServiceError{Message: "Unable to get pending decision."}
+		}
+		result.PendingDecision = &workflow.PendingDecisionInfo{
+			ScheduleID:         common.Int64Ptr(di.ScheduleID),
+			Attempt:            common.Int64Ptr(di.Attempt),
+			ScheduledTimestamp: common.Int64Ptr(di.ScheduledTimestamp),
+		}
+		if di.StartedID != common.EmptyEventID {
+			result.PendingDecision.StartedID = common.Int64Ptr(di.StartedID)
+			result.PendingDecision.StartedTimestamp = common.Int64Ptr(di.StartedTimestamp)
+			if di.StartedIdentity != "" {
+				result.PendingDecision.StartedIdentity = common.StringPtr(di.StartedIdentity)
+			}
+			if di.StartedBinaryChecksum != "" {
+				result.PendingDecision.StartedBinaryChecksum = common.StringPtr(di.StartedBinaryChecksum)
+			}
+		}
+	}
+
 	return result, nil
 }
 
@@ -915,6 +1099,11 @@ func (e *historyEngineImpl) RecordActivityTaskStarted(
 				return nil, &h.EventAlreadyStartedError{Message: "Activity task already started."}
 			}
 
+			if ai.Paused {
+				// It is OK to drop the task, it will be dispatched again when the activity is unpaused.
+				return nil, ErrActivityTaskPaused
+			}
+
 			if _, err := msBuilder.AddActivityTaskStartedEvent(
 				ai, scheduleID, requestID, request.PollRequest.GetIdentity(),
 			); err != nil {
@@ -999,8 +1188,13 @@ func (e *historyEngineImpl) RespondActivityTaskCompleted(
 		RunId:      common.StringPtr(token.RunID),
 	}
 
-	return e.updateWorkflowExecution(ctx, domainID, workflowExecution, false, true,
-		func(msBuilder mutableState, tBuilder *timerBuilder) ([]persistence.Task, error) {
+	return e.updateWorkflowExecutionWithAction(ctx, domainID, workflowExecution,
+		func(msBuilder mutableState, tBuilder *timerBuilder) (*updateWorkflowAction, error) {
+			// Duplicate completion of an already completed activity, e.g. a client retry after timeout
+			if token.RequestID != "" && msBuilder.IsActivityRequestCompleted(token.RequestID) {
+				return &updateWorkflowAction{noop: true}, nil
+			}
+
 			if !msBuilder.IsWorkflowExecutionRunning() {
 				return nil, ErrWorkflowCompleted
 			}
@@ -1030,7 +1224,8 @@ func (e *historyEngineImpl) RespondActivityTaskCompleted(
 				// Unable to add ActivityTaskCompleted event to history
 				return nil, &workflow.InternalServiceError{Message: "Unable to add ActivityTaskCompleted event to history."}
 			}
-			return nil, nil
+			logTaskJourneyCompleted(e.logger, domainID, workflowExecution, scheduleID)
+			return &updateWorkflowAction{createDecision: true}, nil
 		})
 }
 
@@ -1097,6 +1292,7 @@ func (e *historyEngineImpl) RespondActivityTaskFailed(
 				}
 				postActions.createDecision = true
 			}
+			logTaskJourneyCompleted(e.logger, domainID, workflowExecution, scheduleID)
 
 			return postActions, nil
 		})
@@ -1161,6 +1357,7 @@ func (e *historyEngineImpl) RespondActivityTaskCanceled(
 				// Unable to add ActivityTaskCanceled event to history
 				return nil, &workflow.InternalServiceError{Message: "Unable to add ActivityTaskCanceled event to history."}
 			}
+			logTaskJourneyCompleted(e.logger, domainID, workflowExecution, scheduleID)
 
 			return nil, nil
 		})
@@ -1277,6 +1474,9 @@ func (e *historyEngineImpl) RequestCancelWorkflowExecution(
 					return nil, ErrWorkflowParent
 				}
 			}
+			if request.FirstExecutionRunId != nil && !isInExecutionChain(executionInfo, request.GetFirstExecutionRunId()) {
+				return nil, ErrWorkflowChain
+			}
 
 			isCancelRequested, cancelRequestID := msBuilder.IsCancelRequested()
 			if isCancelRequested {
@@ -1373,6 +1573,122 @@ func (e *historyEngineImpl) SignalWorkflowExecution(
 	})
 }
 
+// UpdateWorkflowExecution records the update in mutable state, schedules a decision task for the worker
+// to pick it up and blocks until the worker reports the result of the update
+func (e *historyEngineImpl) UpdateWorkflowExecution(
+	ctx ctx.Context,
+	updateRequest *h.UpdateWorkflowExecutionRequest,
+) (*workflow.UpdateWorkflowExecutionResponse, error) {
+
+	domainEntry, err := e.getActiveDomainEntry(updateRequest.DomainUUID)
+	if err != nil {
+		return nil, err
+	}
+	domainID := domainEntry.GetInfo().ID
+
+	request := updateRequest.UpdateRequest
+	execution := workflow.WorkflowExecution{
+		WorkflowId: request.WorkflowExecution.WorkflowId,
+		RunId:      request.WorkflowExecution.RunId,
+	}
+	// request id doubles as update id, so a retried call waits on the same update
+	updateID := request.GetRequestId()
+	if updateID == "" {
+		updateID = uuid.New()
+	}
+
+	err = e.updateWorkflowExecutionWithContext(ctx, domainID, execution, func(context workflowExecutionContext, msBuilder mutableState, tBuilder *timerBuilder) (*updateWorkflowAction, error) {
+		// set the run id in case of updating the current running workflow
+		execution.RunId = context.getExecution().RunId
+
+		if !msBuilder.IsWorkflowExecutionRunning() {
+			return nil, ErrWorkflowCompleted
+		}
+
+		if _, ok := msBuilder.GetUpdateInfo(updateID); ok {
+			return &updateWorkflowAction{noop: true}, nil
+		}
+		if _, err := msBuilder.AddWorkflowExecutionUpdateRequestedEvent(updateID, request); err != nil {
+			return nil, &workflow.InternalServiceError{Message: "Unable to update workflow execution."}
+		}
+
+		createDecisionTask := true
+		// Do not create decision task when the workflow is cron and the cron has not been started yet
+		if msBuilder.GetExecutionInfo().CronSchedule != "" && !msBuilder.HasProcessedOrPendingDecisionTask() {
+			createDecisionTask = false
+		}
+		return &updateWorkflowAction{
+			createDecision: createDecisionTask,
+		}, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return e.waitForUpdateResult(ctx, domainID, execution, updateID)
+}
+
+func (e *historyEngineImpl) waitForUpdateResult(
+	ctx ctx.Context,
+	domainID string,
+	execution workflow.WorkflowExecution,
+	updateID string,
+) (*workflow.UpdateWorkflowExecutionResponse, error) {
+
+	workflowIdentifier := definition.NewWorkflowIdentifier(domainID, execution.GetWorkflowId(), execution.GetRunId())
+	subscriberID, channel, err := e.historyEventNotifier.WatchHistoryEvent(workflowIdentifier)
+	if err != nil {
+		return nil, err
+	}
+	defer e.historyEventNotifier.UnwatchHistoryEvent(workflowIdentifier, subscriberID)
+
+	for {
+		response, err := e.pickUpUpdateResult(ctx, domainID, execution, updateID)
+		if err != nil || response != nil {
+			return response, err
+		}
+
+		select {
+		case <-channel:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// pickUpUpdateResult returns the result of a completed update, nil response means the update is still pending.
+// Completed updates are kept in mutable state, so a retried request with the same request id gets the same result
+func (e *historyEngineImpl) pickUpUpdateResult(
+	ctx ctx.Context,
+	domainID string,
+	execution workflow.WorkflowExecution,
+	updateID string,
+) (retResp *workflow.UpdateWorkflowExecutionResponse, retError error) {
+
+	context, release, retError := e.historyCache.getOrCreateWorkflowExecution(ctx, domainID, execution)
+	if retError != nil {
+		return
+	}
+	defer func() { release(retError) }()
+
+	msBuilder, retError := context.loadWorkflowExecution()
+	if retError != nil {
+		return
+	}
+
+	ui, ok := msBuilder.GetUpdateInfo(updateID)
+	if !ok {
+		return nil, ErrUpdateNotFound
+	}
+	if !ui.Completed {
+		if !msBuilder.IsWorkflowExecutionRunning() {
+			return nil, ErrWorkflowCompleted
+		}
+		return nil, nil
+	}
+	return &workflow.UpdateWorkflowExecutionResponse{Result: ui.Result}, nil
+}
+
 func (e *historyEngineImpl) SignalWithStartWorkflowExecution(
 	ctx ctx.Context,
 	signalWithStartRequest *h.SignalWithStartWorkflowExecutionRequest,
@@ -1444,7 +1760,7 @@ func (e *historyEngineImpl) SignalWithStartWorkflowExecution(
 				})
 				if msBuilder.IsStickyTaskListEnabled() {
 					tBuilder := e.getTimerBuilder(context.getExecution())
-					stickyTaskTimeoutTimer := tBuilder.AddScheduleToStartDecisionTimoutTask(di.ScheduleID, di.Attempt,
+					stickyTaskTimeoutTimer := tBuilder.AddScheduleToStartDecisionTimoutTask(di.ScheduleID, di.scheduleAttempt(),
 						executionInfo.StickyScheduleToStartTimeout)
 					timerTasks = append(timerTasks, stickyTaskTimeoutTimer)
 				}
@@ -1559,6 +1875,7 @@ func (e *historyEngineImpl) SignalWithStartWorkflowExecution(
 	timerTasks := []persistence.Task{&persistence.WorkflowTimeoutTask{
 		VisibilityTimestamp: e.shard.GetTimeSource().Now().Add(duration),
 	}}
+	timerTasks = append(timerTasks, getWorkflowSLATimerTasks(request.GetSlaDeadlineTimestamp())...)
 
 	context = newWorkflowExecutionContext(domainID, execution, e.shard, e.executionManager, e.logger)
 	createReplicationTask := domainEntry.CanReplicateEvent()
@@ -1634,6 +1951,217 @@ func (e *historyEngineImpl) RemoveSignalMutableState(
 		})
 }
 
+// SetActivityPaused pauses or unpauses a pending activity, a paused activity is not dispatched to workers
+func (e *historyEngineImpl) SetActivityPaused(
+	ctx ctx.Context,
+	pauseRequest *h.SetActivityPausedRequest,
+) error {
+
+	domainEntry, err := e.getActiveDomainEntry(pauseRequest.DomainUUID)
+	if err != nil {
+		return err
+	}
+	domainID := domainEntry.GetInfo().ID
+
+	request := pauseRequest.PauseRequest
+	execution := workflow.WorkflowExecution{
+		WorkflowId: request.Execution.WorkflowId,
+		RunId:      request.Execution.RunId,
+	}
+
+	return e.updateWorkflowExecutionWithAction(ctx, domainID, execution,
+		func(msBuilder mutableState, tBuilder *timerBuilder) (*updateWorkflowAction, error) {
+			if !msBuilder.IsWorkflowExecutionRunning() {
+				return nil, ErrWorkflowCompleted
+			}
+
+			ai, ok := msBuilder.GetActivityByActivityID(request.GetActivityID())
+			if !ok {
+				return nil, ErrActivityTaskNotFound
+			}
+			if ai.Paused == request.GetPaused() {
+				return &updateWorkflowAction{noop: true}, nil
+			}
+
+			ai.Version = msBuilder.GetCurrentVersion()
+			ai.Paused = request.GetPaused()
+			if err := msBuilder.UpdateActivity(ai); err != nil {
+				return nil, err
+			}
+
+			postActions := &updateWorkflowAction{}
+			if ai.Paused || ai.StartedID != common.EmptyEventID {
+				return postActions, nil
+			}
+
+			// the activity task may have been dropped while the activity was paused, dispatch it again
+			targetDomainID := domainID
+			scheduledEvent, ok := msBuilder.GetActivityScheduledEvent(ai.ScheduleID)
+			if !ok {
+				return nil, &workflow.InternalServiceError{Message: "Unable to get activity schedule event."}
+			}
+			if scheduledEvent.ActivityTaskScheduledEventAttributes.Domain != nil {
+				targetDomainEntry, err := e.shard.GetDomainCache().GetDomain(scheduledEvent.ActivityTaskScheduledEventAttributes.GetDomain())
+				if err != nil {
+					return nil, err
+				}
+				targetDomainID = targetDomainEntry.GetInfo().ID
+			}
+
+			postActions.transferTasks = []persistence.Task{&persistence.ActivityTask{
+				DomainID:   targetDomainID,
+				TaskList:   ai.TaskList,
+				ScheduleID: ai.ScheduleID,
+			}}
+			return postActions, nil
+		})
+}
+
+func (e *historyEngineImpl) SetWorkflowExecutionNotes(
+	ctx ctx.Context,
+	notesRequest *h.SetWorkflowExecutionNotesRequest,
+) error {
+
+	domainEntry, err := e.getActiveDomainEntry(notesRequest.DomainUUID)
+	if err != nil {
+		return err
+	}
+	domainID := domainEntry.GetInfo().ID
+
+	request := notesRequest.NotesRequest
+	execution := workflow.WorkflowExecution{
+		WorkflowId: request.Execution.WorkflowId,
+		RunId:      request.Execution.RunId,
+	}
+
+	return e.updateWorkflowExecutionWithAction(ctx, domainID, execution,
+		func(msBuilder mutableState, tBuilder *timerBuilder) (*updateWorkflowAction, error) {
+			if !msBuilder.IsWorkflowExecutionRunning() {
+				return nil, ErrWorkflowCompleted
+			}
+
+			// notes are not part of the history, they only live in the mutable state
+			executionInfo := msBuilder.GetExecutionInfo()
+			if request.GetNotes() == "" {
+				// empty notes clear the notes of the execution
+				if executionInfo.Notes == "" {
+					return &updateWorkflowAction{noop: true}, nil
+				}
+				executionInfo.Notes = ""
+				executionInfo.NotesIdentity = ""
+				executionInfo.NotesLastUpdatedTime = time.Time{}
+				return &updateWorkflowAction{}, nil
+			}
+
+			if executionInfo.Notes == request.GetNotes() && executionInfo.NotesIdentity == request.GetIdentity() {
+				return &updateWorkflowAction{noop: true}, nil
+			}
+			executionInfo.Notes = request.GetNotes()
+			executionInfo.NotesIdentity = request.GetIdentity()
+			executionInfo.NotesLastUpdatedTime = e.timeSource.Now()
+			return &updateWorkflowAction{}, nil
+		})
+}
+
+func (e *historyEngineImpl) SetWorkflowExecutionPaused(
+	ctx ctx.Context,
+	pauseRequest *h.SetWorkflowExecutionPausedRequest,
+) error {
+
+	domainEntry, err := e.getActiveDomainEntry(pauseRequest.DomainUUID)
+	if err != nil {
+		return err
+	}
+	domainID := domainEntry.GetInfo().ID
+
+	request := pauseRequest.PauseRequest
+	execution := workflow.WorkflowExecution{
+		WorkflowId: request.Execution.WorkflowId,
+		RunId:      request.Execution.RunId,
+	}
+
+	return e.updateWorkflowExecutionWithAction(ctx, domainID, execution,
+		func(msBuilder mutableState, tBuilder *timerBuilder) (*updateWorkflowAction, error) {
+			if !msBuilder.IsWorkflowExecutionRunning() {
+				return nil, ErrWorkflowCompleted
+			}
+
+			executionInfo := msBuilder.GetExecutionInfo()
+			if executionInfo.Paused == request.GetPaused() {
+				return &updateWorkflowAction{noop: true}, nil
+			}
+			if _, err := msBuilder.AddWorkflowExecutionPauseUpdatedEvent(request.GetPaused()); err != nil {
+				return nil, &workflow.InternalServiceError{Message: "Unable to pause workflow execution."}
+			}
+
+			postActions := &updateWorkflowAction{}
+			if executionInfo.Paused || !msBuilder.HasPendingDecisionTask() || msBuilder.HasInFlightDecisionTask() {
+				return postActions, nil
+			}
+
+			// the decision task may have been dropped while the workflow was paused, dispatch it again
+			postActions.transferTasks = []persistence.Task{&persistence.DecisionTask{
+				DomainID:   domainID,
+				TaskList:   executionInfo.TaskList,
+				ScheduleID: executionInfo.DecisionScheduleID,
+			}}
+			return postActions, nil
+		})
+}
+
+func (e *historyEngineImpl) SetCronSchedulePaused(
+	ctx ctx.Context,
+	pauseRequest *h.SetCronSchedulePausedRequest,
+) error {
+
+	domainEntry, err := e.getActiveDomainEntry(pauseRequest.DomainUUID)
+	if err != nil {
+		return err
+	}
+	domainID := domainEntry.GetInfo().ID
+
+	request := pauseRequest.PauseRequest
+	execution := workflow.WorkflowExecution{
+		WorkflowId: request.Execution.WorkflowId,
+		RunId:      request.Execution.RunId,
+	}
+
+	return e.updateWorkflowExecutionWithAction(ctx, domainID, execution,
+		func(msBuilder mutableState, tBuilder *timerBuilder) (*updateWorkflowAction, error) {
+			if !msBuilder.IsWorkflowExecutionRunning() {
+				return nil, ErrWorkflowCompleted
+			}
+
+			executionInfo := msBuilder.GetExecutionInfo()
+			if len(executionInfo.CronSchedule) == 0 {
+				return nil, ErrWorkflowNotCron
+			}
+			if executionInfo.CronPaused == request.GetPaused() {
+				return &updateWorkflowAction{noop: true}, nil
+			}
+			if _, err := msBuilder.AddCronSchedulePauseUpdatedEvent(request.GetPaused()); err != nil {
+				return nil, &workflow.InternalServiceError{Message: "Unable to pause cron schedule."}
+			}
+
+			postActions := &updateWorkflowAction{}
+			if executionInfo.CronPaused || msBuilder.HasProcessedOrPendingDecisionTask() {
+				return postActions, nil
+			}
+
+			// the run is still waiting for its cron schedule and the backoff timer may have fired
+			// while the schedule was paused, fire it again once the schedule is due
+			fireTime := getNextCronFireTime(msBuilder, e.timeSource.Now())
+			if now := e.timeSource.Now(); fireTime.Before(now) {
+				fireTime = now
+			}
+			postActions.timerTasks = []persistence.Task{&persistence.WorkflowBackoffTimerTask{
+				VisibilityTimestamp: fireTime,
+				TimeoutType:         persistence.WorkflowBackoffTimeoutTypeCron,
+			}}
+			return postActions, nil
+		})
+}
+
 func (e *historyEngineImpl) TerminateWorkflowExecution(
 	ctx ctx.Context,
 	terminateRequest *h.TerminateWorkflowExecutionRequest,
@@ -1656,6 +2184,9 @@ func (e *historyEngineImpl) TerminateWorkflowExecution(
 			if !msBuilder.IsWorkflowExecutionRunning() {
 				return nil, ErrWorkflowCompleted
 			}
+			if request.FirstExecutionRunId != nil && !isInExecutionChain(msBuilder.GetExecutionInfo(), request.GetFirstExecutionRunId()) {
+				return nil, ErrWorkflowChain
+			}
 
 			if _, err := msBuilder.AddWorkflowExecutionTerminatedEvent(
 				request.GetReason(),
@@ -1669,6 +2200,348 @@ func (e *historyEngineImpl) TerminateWorkflowExecution(
 		})
 }
 
+// TerminateAllWorkflowRuns terminates the current run of the workflow ID and then blocks the current row, so no
+// new run of the workflow ID can be started for a while. A continue as new racing with the termination either
+// conflicts with it and finds the run closed, or commits first and its new run is terminated in the next attempt
+func (e *historyEngineImpl) TerminateAllWorkflowRuns(
+	ctx ctx.Context,
+	terminateRequest *h.TerminateAllWorkflowRunsRequest,
+) (*workflow.TerminateAllWorkflowRunsResponse, error) {
+
+	domainEntry, err := e.getActiveDomainEntry(terminateRequest.DomainUUID)
+	if err != nil {
+		return nil, err
+	}
+	domainID := domainEntry.GetInfo().ID
+
+	request := terminateRequest.TerminateRequest
+	blockDuration := defaultTerminateAllWorkflowRunsBlock
+	if request.GetBlockSeconds() > 0 {
+		blockDuration = time.Duration(request.GetBlockSeconds()) * time.Second
+	}
+
+	response := &workflow.TerminateAllWorkflowRunsResponse{}
+	for attempt := 0; attempt < conditionalRetryCount; attempt++ {
+		current, err := e.executionManager.GetCurrentExecution(&persistence.GetCurrentExecutionRequest{
+			DomainID:   domainID,
+			WorkflowID: request.GetWorkflowId(),
+			Context:    persistenceRequestContext(ctx),
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		if current.State != persistence.WorkflowStateCompleted {
+			execution := workflow.WorkflowExecution{
+				WorkflowId: request.WorkflowId,
+				RunId:      common.StringPtr(current.RunID),
+			}
+			err = e.updateWorkflowExecution(ctx, domainID, execution, true, false,
+				func(msBuilder mutableState, tBuilder *timerBuilder) ([]persistence.Task, error) {
+					if !msBuilder.IsWorkflowExecutionRunning() {
+						return nil, ErrWorkflowCompleted
+					}
+					if request.FirstExecutionRunId != nil && !isInExecutionChain(msBuilder.GetExecutionInfo(), request.GetFirstExecutionRunId()) {
+						return nil, ErrWorkflowChain
+					}
+
+					if _, err := msBuilder.AddWorkflowExecutionTerminatedEvent(
+						request.GetReason(),
+						nil,
+						request.GetIdentity(),
+					); err != nil {
+						return nil, &workflow.InternalServiceError{Message: "Unable to terminate workflow execution."}
+					}
+
+					return nil, nil
+				})
+			switch err {
+			case nil:
+				response.TerminatedExecutions = append(response.TerminatedExecutions, &execution)
+			case ErrWorkflowCompleted:
+				// the run closed before it could be terminated, check whether another run took its place
+				continue
+			case ErrWorkflowChain:
+				// the current run belongs to a chain started after the one being terminated
+				return response, nil
+			default:
+				return nil, err
+			}
+		}
+
+		err = e.executionManager.BlockCurrentWorkflowExecution(&persistence.BlockCurrentWorkflowExecutionRequest{
+			DomainID:     domainID,
+			WorkflowID:   request.GetWorkflowId(),
+			RunID:        current.RunID,
+			BlockedUntil: e.timeSource.Now().Add(blockDuration),
+		})
+		if err != nil {
+			if _, ok := err.(*persistence.ConditionFailedError); ok {
+				// a new run was started in between, terminate it as well
+				continue
+			}
+			return nil, err
+		}
+		return response, nil
+	}
+
+	return nil, ErrMaxAttemptsExceeded
+}
+
+// ImportWorkflowExecution recreates a closed workflow execution from an exported snapshot as a new run of the
+// workflow. The run is written as if it was closed by this cluster: its events get the failover version of the
+// domain, and it gets a close visibility record, a retention timer and, in a global domain, replication tasks
+func (e *historyEngineImpl) ImportWorkflowExecution(
+	ctx ctx.Context,
+	importRequest *h.ImportWorkflowExecutionRequest,
+) (retResp *h.ImportWorkflowExecutionResponse, retError error) {
+
+	domainEntry, err := e.getActiveDomainEntry(importRequest.DomainUUID)
+	if err != nil {
+		if _, ok := err.(*workflow.DomainNotActiveError); ok {
+			return nil, &workflow.BadRequestError{Message: err.Error()}
+		}
+		return nil, err
+	}
+	domainID := domainEntry.GetInfo().ID
+
+	export, err := persistence.DecodeExecutionExport(importRequest.Snapshot)
+	if err != nil {
+		return nil, &workflow.BadRequestError{Message: err.Error()}
+	}
+	state := export.MutableState
+	switch {
+	case state.ExecutionInfo.State != persistence.WorkflowStateCompleted:
+		return nil, &workflow.BadRequestError{Message: persistence.ErrExecutionExportRunning.Error()}
+	case state.ExecutionInfo.EventStoreVersion != persistence.EventStoreVersionV2:
+		return nil, &workflow.BadRequestError{Message: persistence.ErrExecutionExportEventStoreVersion.Error()}
+	case state.ExecutionInfo.WorkflowID != importRequest.GetWorkflowId():
+		return nil, &workflow.BadRequestError{Message: "WorkflowId of the request doesn't match the snapshot."}
+	}
+
+	workflowID := importRequest.GetWorkflowId()
+	runID := uuid.New()
+	batches, err := persistence.RewriteExecutionExportRun(export, domainID, runID)
+	if err != nil {
+		return nil, &workflow.BadRequestError{Message: err.Error()}
+	}
+	if len(batches) == 0 {
+		return nil, &workflow.BadRequestError{Message: "Snapshot has no history events."}
+	}
+
+	version := common.EmptyVersion
+	state.ReplicationState = nil
+	if domainEntry.IsGlobalDomain() {
+		version = domainEntry.GetFailoverVersion()
+		lastEvents := batches[len(batches)-1]
+		state.ReplicationState = &persistence.ReplicationState{
+			CurrentVersion:      version,
+			StartVersion:        version,
+			LastWriteVersion:    version,
+			LastWriteEventID:    lastEvents[len(lastEvents)-1].GetEventId(),
+			LastReplicationInfo: map[string]*persistence.ReplicationInfo{},
+		}
+	}
+	for _, events := range batches {
+		for _, event := range events {
+			event.Version = common.Int64Ptr(version)
+		}
+	}
+
+	// grab the current context as a lock, nothing more
+	_, currentRelease, err := e.historyCache.getOrCreateCurrentWorkflowExecution(
+		ctx,
+		domainID,
+		workflowID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { currentRelease(retError) }()
+
+	createMode := persistence.CreateWorkflowModeBrandNew
+	prevRunID := ""
+	prevLastWriteVersion := common.EmptyVersion
+	current, err := e.executionManager.GetCurrentExecution(&persistence.GetCurrentExecutionRequest{
+		DomainID:   domainID,
+		WorkflowID: workflowID,
+		Context:    persistenceRequestContext(ctx),
+	})
+	switch err.(type) {
+	case nil:
+		if current.State != persistence.WorkflowStateCompleted {
+			return nil, &workflow.BadRequestError{
+				Message: fmt.Sprintf("Workflow execution is already running. WorkflowId: %v, RunId: %v.", workflowID, current.RunID),
+			}
+		}
+		createMode = persistence.CreateWorkflowModeWorkflowIDReuse
+		prevRunID = current.RunID
+		prevLastWriteVersion = current.LastWriteVersion
+	case *workflow.EntityNotExistsError:
+	default:
+		return nil, err
+	}
+
+	execution := workflow.WorkflowExecution{
+		WorkflowId: common.StringPtr(workflowID),
+		RunId:      common.StringPtr(runID),
+	}
+	branchToken, err := persistence.NewHistoryBranchToken(runID)
+	if err != nil {
+		return nil, err
+	}
+	historySize := int64(0)
+	for i, events := range batches {
+		transactionID, err := e.shard.GetNextTransferTaskID()
+		if err == nil {
+			var size int
+			size, err = e.shard.AppendHistoryV2Events(&persistence.AppendHistoryNodesRequest{
+				IsNewBranch:   i == 0,
+				Info:          historyGarbageCleanupInfo(domainID, workflowID, runID),
+				BranchToken:   branchToken,
+				Events:        events,
+				TransactionID: transactionID,
+			}, domainID, execution)
+			historySize += int64(size)
+		}
+		if err != nil {
+			e.deleteEvents(domainID, execution, persistence.EventStoreVersionV2, branchToken)
+			return nil, err
+		}
+	}
+	state.ExecutionInfo.BranchToken = branchToken
+
+	closeTask, deleteTask, err := e.getWorkflowHistoryCleanupTasks(domainID, workflowID, nil)
+	if err != nil {
+		return nil, err
+	}
+	transferTasks := []persistence.Task{closeTask}
+	timerTasks := []persistence.Task{deleteTask}
+	setTaskInfo(version, e.timeSource.Now(), transferTasks, timerTasks)
+	replicationTasks := []persistence.Task{}
+	if domainEntry.CanReplicateEvent() {
+		for _, events := range batches {
+			replicationTasks = append(replicationTasks, &persistence.HistoryReplicationTask{
+				FirstEventID:        events[0].GetEventId(),
+				NextEventID:         events[len(events)-1].GetEventId() + 1,
+				Version:             version,
+				LastReplicationInfo: state.ReplicationState.LastReplicationInfo,
+				EventStoreVersion:   persistence.EventStoreVersionV2,
+				BranchToken:         branchToken,
+			})
+		}
+	}
+
+	snapshot := persistence.NewExecutionExportSnapshot(export)
+	snapshot.ExecutionStats = &persistence.ExecutionStats{HistorySize: historySize}
+	snapshot.TransferTasks = transferTasks
+	snapshot.TimerTasks = timerTasks
+	snapshot.ReplicationTasks = replicationTasks
+	_, err = e.shard.CreateWorkflowExecution(&persistence.CreateWorkflowExecutionRequest{
+		CreateWorkflowMode:       createMode,
+		PreviousRunID:            prevRunID,
+		PreviousLastWriteVersion: prevLastWriteVersion,
+		NewWorkflowSnapshot:      snapshot,
+	})
+	if err != nil {
+		if _, ok := err.(*persistence.WorkflowExecutionAlreadyStartedError); ok {
+			e.deleteEvents(domainID, execution, persistence.EventStoreVersionV2, branchToken)
+			return nil, &workflow.BadRequestError{Message: "Workflow execution was started while it was being imported."}
+		}
+		return nil, err
+	}
+
+	e.timerProcessor.NotifyNewTimers(e.currentClusterName, e.shard.GetCurrentTime(e.currentClusterName), timerTasks)
+	e.logger.Info("Imported workflow execution.",
+		tag.WorkflowDomainID(domainID),
+		tag.WorkflowID(workflowID),
+		tag.WorkflowRunID(runID))
+	return &h.ImportWorkflowExecutionResponse{
+		RunId: common.StringPtr(runID),
+	}, nil
+}
+
+// PurgeWorkflowExecution deletes the traces of a closed run owned by the shard while holding the lock of the run,
+// the mutable state is deleted last as it locates the history and the closed execution index of the run, so that
+// a failed purge can be retried. The purge is served by every cluster of a global domain, active or not.
+func (e *historyEngineImpl) PurgeWorkflowExecution(
+	ctx ctx.Context,
+	request *h.PurgeWorkflowExecutionRequest,
+) (retError error) {
+
+	domainID, err := validateDomainUUID(request.DomainUUID)
+	if err != nil {
+		return err
+	}
+	execution := workflow.WorkflowExecution{
+		WorkflowId: request.WorkflowExecution.WorkflowId,
+		RunId:      request.WorkflowExecution.RunId,
+	}
+
+	context, release, err := e.historyCache.getOrCreateWorkflowExecution(ctx, domainID, execution)
+	if err != nil {
+		return err
+	}
+	defer func() { release(retError) }()
+	// the cached mutable state is dropped whatever the outcome, so that the host reads the run back from the database
+	defer context.clear()
+
+	msBuilder, err := context.loadWorkflowExecution()
+	if err != nil {
+		if _, ok := err.(*workflow.EntityNotExistsError); !ok {
+			return err
+		}
+		// a previous purge deleted the mutable state, only the traces located without it remain
+		msBuilder = nil
+	} else if msBuilder.IsWorkflowExecutionRunning() {
+		return &workflow.BadRequestError{Message: "Workflow execution is still running."}
+	}
+
+	if err := e.executionManager.DeleteBufferedReplicationTasks(&persistence.DeleteBufferedReplicationTasksRequest{
+		DomainID:   domainID,
+		WorkflowID: execution.GetWorkflowId(),
+		RunID:      execution.GetRunId(),
+	}); err != nil {
+		return err
+	}
+	if msBuilder != nil {
+		executionInfo := msBuilder.GetExecutionInfo()
+		if err := e.executionManager.DeleteClosedExecutionIndex(&persistence.DeleteClosedExecutionIndexRequest{
+			DomainID:   domainID,
+			WorkflowID: execution.GetWorkflowId(),
+			RunID:      execution.GetRunId(),
+			CloseTime:  executionInfo.LastUpdatedTimestamp,
+		}); err != nil {
+			return err
+		}
+		if msBuilder.GetEventStoreVersion() == persistence.EventStoreVersionV2 {
+			err = persistence.DeleteWorkflowExecutionHistoryV2(e.historyV2Mgr, msBuilder.GetCurrentBranch(),
+				common.IntPtr(e.shard.GetShardID()), e.logger)
+		} else {
+			err = e.historyMgr.DeleteWorkflowExecutionHistory(&persistence.DeleteWorkflowExecutionHistoryRequest{
+				DomainID:  domainID,
+				Execution: execution,
+			})
+		}
+		if err != nil {
+			return err
+		}
+	}
+	// the current record is only deleted if it points to the run
+	if _, err := e.executionManager.DeleteCurrentWorkflowExecution(&persistence.DeleteCurrentWorkflowExecutionRequest{
+		DomainID:   domainID,
+		WorkflowID: execution.GetWorkflowId(),
+		RunID:      execution.GetRunId(),
+	}); err != nil {
+		return err
+	}
+	return e.executionManager.DeleteWorkflowExecution(&persistence.DeleteWorkflowExecutionRequest{
+		DomainID:   domainID,
+		WorkflowID: execution.GetWorkflowId(),
+		RunID:      execution.GetRunId(),
+	})
+}
+
 // RecordChildExecutionCompleted records the completion of child execution into parent execution history
 func (e *historyEngineImpl) RecordChildExecutionCompleted(
 	ctx ctx.Context,
@@ -1730,6 +2603,12 @@ func (e *historyEngineImpl) ReplicateEvents(
 	replicateRequest *h.ReplicateEventsRequest,
 ) error {
 
+	if disallowed, err := e.isDisallowedByResidency(
+		metrics.HistoryReplicateEventsScope,
+		replicateRequest.GetDomainUUID(),
+	); err != nil || disallowed {
+		return err
+	}
 	return e.replicator.ApplyEvents(ctx, replicateRequest)
 }
 
@@ -1738,6 +2617,12 @@ func (e *historyEngineImpl) ReplicateRawEvents(
 	replicateRequest *h.ReplicateRawEventsRequest,
 ) error {
 
+	if disallowed, err := e.isDisallowedByResidency(
+		metrics.HistoryReplicateRawEventsScope,
+		replicateRequest.GetDomainUUID(),
+	); err != nil || disallowed {
+		return err
+	}
 	return e.replicator.ApplyRawEvents(ctx, replicateRequest)
 }
 
@@ -1764,9 +2649,34 @@ func (e *historyEngineImpl) SyncActivity(
 	request *h.SyncActivityRequest,
 ) (retError error) {
 
+	if disallowed, err := e.isDisallowedByResidency(
+		metrics.HistorySyncActivityScope,
+		request.GetDomainId(),
+	); err != nil || disallowed {
+		return err
+	}
 	return e.replicator.SyncActivity(ctx, request)
 }
 
+// isDisallowedByResidency returns true if the residency of the domain does not allow the current cluster,
+// the replication tasks of such a domain are dropped instead of being applied
+func (e *historyEngineImpl) isDisallowedByResidency(
+	scope int,
+	domainID string,
+) (bool, error) {
+
+	domainEntry, err := e.shard.GetDomainCache().GetDomainByID(domainID)
+	if err != nil {
+		return false, err
+	}
+	if domainEntry.GetConfig().Residency.IsClusterAllowed(e.currentClusterName) {
+		return false, nil
+	}
+	e.logger.Warn("Dropping replication task disallowed by the domain residency.", tag.WorkflowDomainID(domainID))
+	e.metricsClient.IncCounter(scope, metrics.ResidencyDroppedReplicationTasksCounter)
+	return true, nil
+}
+
 func (e *historyEngineImpl) ResetWorkflowExecution(
 	ctx ctx.Context,
 	resetRequest *h.ResetWorkflowExecutionRequest,
@@ -1811,6 +2721,7 @@ func (e *historyEngineImpl) ResetWorkflowExecution(
 	resp, retError := e.executionManager.GetCurrentExecution(&persistence.GetCurrentExecutionRequest{
 		DomainID:   domainID,
 		WorkflowID: request.WorkflowExecution.GetWorkflowId(),
+		Context:    persistenceRequestContext(ctx),
 	})
 	if retError != nil {
 		return
@@ -1881,6 +2792,19 @@ func (e *historyEngineImpl) updateWorkflowExecutionWithAction(
 	domainID string,
 	execution workflow.WorkflowExecution,
 	action func(builder mutableState, tBuilder *timerBuilder) (*updateWorkflowAction, error),
+) error {
+
+	return e.updateWorkflowExecutionWithContext(ctx, domainID, execution,
+		func(_ workflowExecutionContext, builder mutableState, tBuilder *timerBuilder) (*updateWorkflowAction, error) {
+			return action(builder, tBuilder)
+		})
+}
+
+func (e *historyEngineImpl) updateWorkflowExecutionWithContext(
+	ctx ctx.Context,
+	domainID string,
+	execution workflow.WorkflowExecution,
+	action func(context workflowExecutionContext, builder mutableState, tBuilder *timerBuilder) (*updateWorkflowAction, error),
 ) (retError error) {
 
 	context, release, err0 := e.historyCache.getOrCreateWorkflowExecution(ctx, domainID, execution)
@@ -1898,7 +2822,7 @@ Update_History_Loop:
 		tBuilder := e.getTimerBuilder(context.getExecution())
 
 		// conduct caller action
-		postActions, err := action(msBuilder, tBuilder)
+		postActions, err := action(context, msBuilder, tBuilder)
 		if err != nil {
 			if err == ErrStaleState {
 				// Handler detected that cached workflow mutable could potentially be stale
@@ -1941,7 +2865,7 @@ Update_History_Loop:
 				})
 				if msBuilder.IsStickyTaskListEnabled() {
 					tBuilder := e.getTimerBuilder(context.getExecution())
-					stickyTaskTimeoutTimer := tBuilder.AddScheduleToStartDecisionTimoutTask(di.ScheduleID, di.Attempt,
+					stickyTaskTimeoutTimer := tBuilder.AddScheduleToStartDecisionTimoutTask(di.ScheduleID, di.scheduleAttempt(),
 						msBuilder.GetExecutionInfo().StickyScheduleToStartTimeout)
 					timerTasks = append(timerTasks, stickyTaskTimeoutTimer)
 				}
@@ -2018,6 +2942,22 @@ func getWorkflowHistoryCleanupTasksFromShard(
 	return &persistence.CloseExecutionTask{}, deleteTask, nil
 }
 
+func getRecordChildExecutionCompletedTask(
+	msBuilder mutableState,
+) persistence.Task {
+
+	executionInfo := msBuilder.GetExecutionInfo()
+	if !msBuilder.HasParentExecution() || executionInfo.CloseStatus == persistence.WorkflowCloseStatusContinuedAsNew {
+		return nil
+	}
+	return &persistence.RecordChildExecutionCompletedTask{
+		TargetDomainID:   executionInfo.ParentDomainID,
+		TargetWorkflowID: executionInfo.ParentWorkflowID,
+		TargetRunID:      executionInfo.ParentRunID,
+		InitiatedID:      executionInfo.InitiatedID,
+	}
+}
+
 func createDeleteHistoryEventTimerTask(
 	tBuilder *timerBuilder,
 	retentionInDays int32,
@@ -2103,6 +3043,9 @@ func (s *shardContextWrapper) UpdateWorkflowExecution(
 		if len(request.UpdateWorkflowMutation.ReplicationTasks) > 0 {
 			s.replicatorProcessor.notifyNewTask()
 		}
+		if len(request.UpdateWorkflowMutation.VisibilityTasks) > 0 && s.visibilityProcessor != nil {
+			s.visibilityProcessor.notifyNewTask()
+		}
 	}
 	return resp, err
 }
@@ -2117,6 +3060,9 @@ func (s *shardContextWrapper) CreateWorkflowExecution(
 		if len(request.NewWorkflowSnapshot.ReplicationTasks) > 0 {
 			s.replicatorProcessor.notifyNewTask()
 		}
+		if len(request.NewWorkflowSnapshot.VisibilityTasks) > 0 && s.visibilityProcessor != nil {
+			s.visibilityProcessor.notifyNewTask()
+		}
 	}
 	return resp, err
 }
@@ -2178,6 +3124,48 @@ func validateDomainUUID(
 	return *domainUUID, nil
 }
 
+// isInExecutionChain returns true if the execution belongs to the chain of ContinueAsNew and Reset
+// started by the given first run, executions persisted without a first run ID only match themselves
+func isInExecutionChain(
+	executionInfo *persistence.WorkflowExecutionInfo,
+	firstExecutionRunID string,
+) bool {
+
+	if executionInfo.FirstExecutionRunID == "" {
+		return executionInfo.RunID == firstExecutionRunID
+	}
+	return executionInfo.FirstExecutionRunID == firstExecutionRunID
+}
+
+// getWorkflowSLATimerTasks returns the timer task firing at the SLA deadline of the workflow, if it has one
+func getWorkflowSLATimerTasks(slaDeadlineTimestamp int64) []persistence.Task {
+	if slaDeadlineTimestamp <= 0 {
+		return nil
+	}
+	return []persistence.Task{&persistence.WorkflowSLATimerTask{
+		VisibilityTimestamp: time.Unix(0, slaDeadlineTimestamp),
+	}}
+}
+
+// getNextCronFireTime returns when the cron schedule of the run fires next: the end of the cron backoff if
+// the run has not scheduled its first decision yet, otherwise the first tick of the schedule after now,
+// which starts the following run if this one has closed by then
+func getNextCronFireTime(
+	msBuilder mutableState,
+	now time.Time,
+) time.Time {
+
+	executionInfo := msBuilder.GetExecutionInfo()
+	if !msBuilder.HasProcessedOrPendingDecisionTask() {
+		// the run is waiting for the backoff timer created along with its started event
+		if startEvent, ok := msBuilder.GetStartEvent(); ok {
+			backoffSeconds := startEvent.WorkflowExecutionStartedEventAttributes.GetFirstDecisionTaskBackoffSeconds()
+			return time.Unix(0, startEvent.GetTimestamp()).Add(time.Duration(backoffSeconds) * time.Second)
+		}
+	}
+	return now.Add(backoff.GetBackoffForNextSchedule(executionInfo.CronSchedule, executionInfo.CronTimezone, now))
+}
+
 func (e *historyEngineImpl) getActiveDomainEntry(
 	domainUUID *string,
 ) (*cache.DomainCacheEntry, error) {
@@ -2238,9 +3226,11 @@ func getStartRequest(
 		WorkflowIdReusePolicy:               request.WorkflowIdReusePolicy,
 		RetryPolicy:                         request.RetryPolicy,
 		CronSchedule:                        request.CronSchedule,
+		CronTimezone:                        request.CronTimezone,
 		Memo:                                request.Memo,
 		SearchAttributes:                    request.SearchAttributes,
 		Header:                              request.Header,
+		SlaDeadlineTimestamp:                request.SlaDeadlineTimestamp,
 	}
 
 	startRequest := common.CreateHistoryStartWorkflowRequest(domainID, req)
@@ -2314,6 +3304,17 @@ func (e *historyEngineImpl) applyWorkflowIDReusePolicyHelper(
 	return nil
 }
 
+// logTaskJourneyCompleted logs the last stage of the journey of an activity or decision task, which
+// matching logs the previous stages of with the same tags
+func logTaskJourneyCompleted(logger log.Logger, domainID string, execution workflow.WorkflowExecution, scheduleID int64) {
+	logger.Debug("Task journey",
+		tag.WorkflowTaskJourneyStage(common.TaskJourneyStageCompleted),
+		tag.WorkflowDomainID(domainID),
+		tag.WorkflowID(execution.GetWorkflowId()),
+		tag.WorkflowRunID(execution.GetRunId()),
+		tag.WorkflowScheduleID(scheduleID))
+}
+
 func getWorkflowAlreadyStartedError(errMsg string, createRequestID string, workflowID string, runID string) error {
 	return &workflow.WorkflowExecutionAlreadyStartedError{
 		Message:        common.StringPtr(fmt.Sprintf(errMsg, workflowID, runID)),
@@ -150,6 +150,7 @@ func (s *engine3Suite) SetupTest() {
 		tokenSerializer:    common.NewJSONTaskTokenSerializer(),
 		config:             s.config,
 		archivalClient:     s.mockArchivalClient,
+		clientVersions:     newClientVersionTracker(s.config.ClientVersionTrackerMaxSize, clock.NewRealTimeSource(), metrics.NewClient(tally.NoopScope, metrics.History)),
 		timeSource:         mockShard.timeSource,
 	}
 	h.txProcessor = newTransferQueueProcessor(mockShard, h, s.mockVisibilityMgr, s.mockMatchingClient, s.mockHistoryClient, s.logger)
@@ -62,10 +62,18 @@ type (
 		RecordActivityTaskHeartbeat(ctx context.Context, request *h.RecordActivityTaskHeartbeatRequest) (*workflow.RecordActivityTaskHeartbeatResponse, error)
 		RequestCancelWorkflowExecution(ctx context.Context, request *h.RequestCancelWorkflowExecutionRequest) error
 		SignalWorkflowExecution(ctx context.Context, request *h.SignalWorkflowExecutionRequest) error
+		UpdateWorkflowExecution(ctx context.Context, request *h.UpdateWorkflowExecutionRequest) (*workflow.UpdateWorkflowExecutionResponse, error)
 		SignalWithStartWorkflowExecution(ctx context.Context, request *h.SignalWithStartWorkflowExecutionRequest) (
 			*workflow.StartWorkflowExecutionResponse, error)
 		RemoveSignalMutableState(ctx context.Context, request *h.RemoveSignalMutableStateRequest) error
+		SetActivityPaused(ctx context.Context, request *h.SetActivityPausedRequest) error
+		SetWorkflowExecutionNotes(ctx context.Context, request *h.SetWorkflowExecutionNotesRequest) error
+		SetWorkflowExecutionPaused(ctx context.Context, request *h.SetWorkflowExecutionPausedRequest) error
+		SetCronSchedulePaused(ctx context.Context, request *h.SetCronSchedulePausedRequest) error
 		TerminateWorkflowExecution(ctx context.Context, request *h.TerminateWorkflowExecutionRequest) error
+		TerminateAllWorkflowRuns(ctx context.Context, request *h.TerminateAllWorkflowRunsRequest) (*workflow.TerminateAllWorkflowRunsResponse, error)
+		ImportWorkflowExecution(ctx context.Context, request *h.ImportWorkflowExecutionRequest) (*h.ImportWorkflowExecutionResponse, error)
+		PurgeWorkflowExecution(ctx context.Context, request *h.PurgeWorkflowExecutionRequest) error
 		ResetWorkflowExecution(ctx context.Context, request *h.ResetWorkflowExecutionRequest) (*workflow.ResetWorkflowExecutionResponse, error)
 		ScheduleDecisionTask(ctx context.Context, request *h.ScheduleDecisionTaskRequest) error
 		RecordChildExecutionCompleted(ctx context.Context, request *h.RecordChildExecutionCompletedRequest) error
@@ -73,6 +81,11 @@ type (
 		ReplicateRawEvents(ctx context.Context, request *h.ReplicateRawEventsRequest) error
 		SyncShardStatus(ctx context.Context, request *h.SyncShardStatusRequest) error
 		SyncActivity(ctx context.Context, request *h.SyncActivityRequest) error
+		ReconcileDomainOpenExecutionCounts(ctx context.Context, request *h.ReconcileDomainOpenExecutionCountsRequest) error
+		// DescribeShard returns the cache and queue processing state of the shard
+		DescribeShard() *workflow.HistoryShardInfo
+		// DomainOpenExecutionCount returns the approximate number of open executions of the domain in the shard
+		DomainOpenExecutionCount(domainID string) int64
 	}
 
 	// EngineFactory is used to create an instance of sharded history engine
@@ -176,6 +176,7 @@ func (s *engineSuite) SetupTest() {
 		historyEventNotifier: historyEventNotifier,
 		config:               NewDynamicConfigForTest(),
 		archivalClient:       s.mockArchivalClient,
+		clientVersions:       newClientVersionTracker(s.config.ClientVersionTrackerMaxSize, clock.NewRealTimeSource(), metrics.NewClient(tally.NoopScope, metrics.History)),
 	}
 	h.txProcessor = newTransferQueueProcessor(shardContextWrapper, h, s.mockVisibilityMgr, s.mockMatchingClient, s.mockHistoryClient, s.logger)
 	h.timerProcessor = newTimerQueueProcessor(shardContextWrapper, h, s.mockMatchingClient, s.logger)
@@ -414,6 +415,68 @@ func (s *engineSuite) TestGetMutableStateLongPollTimeout() {
 	s.Equal(int64(4), *response.NextEventId)
 }
 
+func (s *engineSuite) TestDescribeMutableState() {
+	ctx := context.Background()
+	domainID := validDomainID
+	execution := workflow.WorkflowExecution{
+		WorkflowId: common.StringPtr("test-describe-mutable-state"),
+		RunId:      common.StringPtr(validRunID),
+	}
+	tasklist := "testTaskList"
+	identity := "testIdentity"
+
+	msBuilder := newMutableStateBuilderWithEventV2(s.mockHistoryEngine.shard, s.eventsCache,
+		loggerimpl.NewDevelopmentForTest(s.Suite), execution.GetRunId())
+	addWorkflowExecutionStartedEvent(msBuilder, execution, "wType", tasklist, []byte("input"), 100, 200, identity)
+	addDecisionTaskScheduledEvent(msBuilder)
+	ms := createMutableState(msBuilder)
+	ms.ExecutionInfo.LastFirstEventID = 2
+	ms.ExecutionInfo.LastEventTaskID = 1234
+	gweResponse := &persistence.GetWorkflowExecutionResponse{State: ms}
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(gweResponse, nil).Once()
+	s.mockMetadataMgr.On("GetDomain", mock.Anything).Return(
+		&persistence.GetDomainResponse{
+			Info:   &persistence.DomainInfo{ID: domainID, Name: "testDomain"},
+			Config: &persistence.DomainConfig{Retention: 1},
+			ReplicationConfig: &persistence.DomainReplicationConfig{
+				ActiveClusterName: cluster.TestCurrentClusterName,
+				Clusters: []*persistence.ClusterReplicationConfig{
+					{ClusterName: cluster.TestCurrentClusterName},
+				},
+			},
+			TableVersion: persistence.DomainTableVersionV1,
+		},
+		nil,
+	)
+
+	response, err := s.mockHistoryEngine.DescribeMutableState(ctx, &history.DescribeMutableStateRequest{
+		DomainUUID: common.StringPtr(domainID),
+		Execution:  &execution,
+	})
+	s.NoError(err)
+	s.NotNil(response.MutableStateInDatabase)
+	s.Equal(int64(2), response.GetLastFirstEventId())
+	s.Equal(int64(1234), response.GetLastEventTaskId())
+}
+
+func (s *engineSuite) TestDescribeMutableState_LoadFailed() {
+	ctx := context.Background()
+	domainID := validDomainID
+	execution := workflow.WorkflowExecution{
+		WorkflowId: common.StringPtr("test-describe-mutable-state"),
+		RunId:      common.StringPtr(validRunID),
+	}
+
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(nil, &workflow.EntityNotExistsError{}).Once()
+
+	response, err := s.mockHistoryEngine.DescribeMutableState(ctx, &history.DescribeMutableStateRequest{
+		DomainUUID: common.StringPtr(domainID),
+		Execution:  &execution,
+	})
+	s.Equal(&workflow.EntityNotExistsError{}, err)
+	s.Nil(response)
+}
+
 func (s *engineSuite) TestRespondDecisionTaskCompletedInvalidToken() {
 	domainID := validDomainID
 	invalidToken, _ := json.Marshal("bad token")
@@ -2151,6 +2214,70 @@ func (s *engineSuite) TestRespondActivityTaskCompletedIfTaskCompleted() {
 	s.IsType(&workflow.EntityNotExistsError{}, err)
 }
 
+func (s *engineSuite) TestRespondActivityTaskCompletedDuplicateRequest() {
+	domainID := validDomainID
+	we := workflow.WorkflowExecution{
+		WorkflowId: common.StringPtr("wId"),
+		RunId:      common.StringPtr(validRunID),
+	}
+	tl := "testTaskList"
+	taskToken, _ := json.Marshal(&common.TaskToken{
+		WorkflowID: *we.WorkflowId,
+		RunID:      *we.RunId,
+		ScheduleID: 5,
+		RequestID:  validRunID,
+	})
+	identity := "testIdentity"
+	activityID := "activity1_id"
+	activityType := "activity_type1"
+	activityInput := []byte("input1")
+	activityResult := []byte("activity result")
+
+	msBuilder := newMutableStateBuilderWithEventV2(s.mockHistoryEngine.shard, s.eventsCache,
+		loggerimpl.NewDevelopmentForTest(s.Suite), we.GetRunId())
+	addWorkflowExecutionStartedEvent(msBuilder, we, "wType", tl, []byte("input"), 100, 200, identity)
+	di := addDecisionTaskScheduledEvent(msBuilder)
+	decisionStartedEvent := addDecisionTaskStartedEvent(msBuilder, di.ScheduleID, tl, identity)
+	decisionCompletedEvent := addDecisionTaskCompletedEvent(msBuilder, di.ScheduleID,
+		*decisionStartedEvent.EventId, nil, identity)
+	activityScheduledEvent, _ := addActivityTaskScheduledEvent(msBuilder, *decisionCompletedEvent.EventId, activityID,
+		activityType, tl, activityInput, 100, 10, 5)
+	activityStartedEvent := addActivityTaskStartedEvent(msBuilder, *activityScheduledEvent.EventId, identity)
+	addActivityTaskCompletedEvent(msBuilder, *activityScheduledEvent.EventId, *activityStartedEvent.EventId,
+		activityResult, identity)
+	addDecisionTaskScheduledEvent(msBuilder)
+
+	ms := createMutableState(msBuilder)
+	gwmsResponse := &persistence.GetWorkflowExecutionResponse{State: ms}
+
+	s.mockMetadataMgr.On("GetDomain", mock.Anything).Return(
+		&persistence.GetDomainResponse{
+			Info:   &persistence.DomainInfo{ID: domainID},
+			Config: &persistence.DomainConfig{Retention: 1},
+			ReplicationConfig: &persistence.DomainReplicationConfig{
+				ActiveClusterName: cluster.TestCurrentClusterName,
+				Clusters: []*persistence.ClusterReplicationConfig{
+					{ClusterName: cluster.TestCurrentClusterName},
+				},
+			},
+			TableVersion: persistence.DomainTableVersionV1,
+		},
+		nil,
+	)
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(gwmsResponse, nil).Once()
+
+	err := s.mockHistoryEngine.RespondActivityTaskCompleted(context.Background(), &history.RespondActivityTaskCompletedRequest{
+		DomainUUID: common.StringPtr(domainID),
+		CompleteRequest: &workflow.RespondActivityTaskCompletedRequest{
+			TaskToken: taskToken,
+			Result:    activityResult,
+			Identity:  &identity,
+		},
+	})
+	s.Nil(err)
+	s.mockExecutionMgr.AssertNotCalled(s.T(), "UpdateWorkflowExecution", mock.Anything)
+}
+
 func (s *engineSuite) TestRespondActivityTaskCompletedIfTaskNotStarted() {
 	domainID := validDomainID
 	we := workflow.WorkflowExecution{
@@ -4786,6 +4913,861 @@ func (s *engineSuite) TestSignalWorkflowExecution_Failed() {
 	s.EqualError(err, "EntityNotExistsError{Message: Workflow execution already completed.}")
 }
 
+func (s *engineSuite) TestTerminateWorkflowExecution_NotInChain() {
+	domainID := validDomainID
+	we := &workflow.WorkflowExecution{
+		WorkflowId: common.StringPtr("wId"),
+		RunId:      common.StringPtr(validRunID),
+	}
+	terminateRequest := &history.TerminateWorkflowExecutionRequest{
+		DomainUUID: common.StringPtr(domainID),
+		TerminateRequest: &workflow.TerminateWorkflowExecutionRequest{
+			Domain:              common.StringPtr(domainID),
+			WorkflowExecution:   we,
+			Reason:              common.StringPtr("test reason"),
+			FirstExecutionRunId: common.StringPtr(uuid.New()),
+		},
+	}
+
+	msBuilder := newMutableStateBuilderWithEventV2(s.mockHistoryEngine.shard, s.eventsCache,
+		loggerimpl.NewDevelopmentForTest(s.Suite), we.GetRunId())
+	ms := createMutableState(msBuilder)
+	ms.ExecutionInfo.FirstExecutionRunID = uuid.New()
+	gwmsResponse := &persistence.GetWorkflowExecutionResponse{State: ms}
+
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(gwmsResponse, nil).Once()
+
+	s.mockMetadataMgr.On("GetDomain", mock.Anything).Return(
+		&persistence.GetDomainResponse{
+			Info:   &persistence.DomainInfo{ID: domainID},
+			Config: &persistence.DomainConfig{Retention: 1},
+			ReplicationConfig: &persistence.DomainReplicationConfig{
+				ActiveClusterName: cluster.TestCurrentClusterName,
+				Clusters: []*persistence.ClusterReplicationConfig{
+					{ClusterName: cluster.TestCurrentClusterName},
+				},
+			},
+			TableVersion: persistence.DomainTableVersionV1,
+		},
+		nil,
+	)
+	err := s.mockHistoryEngine.TerminateWorkflowExecution(context.Background(), terminateRequest)
+	s.Equal(ErrWorkflowChain, err)
+}
+
+func (s *engineSuite) TestTerminateAllWorkflowRuns_ContinuedAsNewBeforeTerminate() {
+	domainID := validDomainID
+	we := &workflow.WorkflowExecution{
+		WorkflowId: common.StringPtr("wId"),
+		RunId:      common.StringPtr(validRunID),
+	}
+	newRunID := uuid.New()
+	terminateRequest := &history.TerminateAllWorkflowRunsRequest{
+		DomainUUID: common.StringPtr(domainID),
+		TerminateRequest: &workflow.TerminateAllWorkflowRunsRequest{
+			Domain:     common.StringPtr(domainID),
+			WorkflowId: we.WorkflowId,
+			Reason:     common.StringPtr("test reason"),
+		},
+	}
+
+	msBuilder := newMutableStateBuilderWithEventV2(s.mockHistoryEngine.shard, s.eventsCache,
+		loggerimpl.NewDevelopmentForTest(s.Suite), we.GetRunId())
+	ms := createMutableState(msBuilder)
+	ms.ExecutionInfo.State = persistence.WorkflowStateCompleted
+	ms.ExecutionInfo.CloseStatus = persistence.WorkflowCloseStatusContinuedAsNew
+	gwmsResponse := &persistence.GetWorkflowExecutionResponse{State: ms}
+
+	s.mockExecutionMgr.On("GetCurrentExecution", mock.Anything).Return(
+		&persistence.GetCurrentExecutionResponse{RunID: validRunID, State: persistence.WorkflowStateRunning}, nil,
+	).Once()
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(gwmsResponse, nil).Once()
+	s.mockExecutionMgr.On("GetCurrentExecution", mock.Anything).Return(
+		&persistence.GetCurrentExecutionResponse{RunID: newRunID, State: persistence.WorkflowStateCompleted}, nil,
+	).Once()
+	s.mockExecutionMgr.On("BlockCurrentWorkflowExecution", mock.MatchedBy(func(request *persistence.BlockCurrentWorkflowExecutionRequest) bool {
+		return request.RunID == newRunID
+	})).Return(nil).Once()
+	s.mockMetadataMgr.On("GetDomain", mock.Anything).Return(
+		&persistence.GetDomainResponse{
+			Info:   &persistence.DomainInfo{ID: domainID},
+			Config: &persistence.DomainConfig{Retention: 1},
+			ReplicationConfig: &persistence.DomainReplicationConfig{
+				ActiveClusterName: cluster.TestCurrentClusterName,
+				Clusters: []*persistence.ClusterReplicationConfig{
+					{ClusterName: cluster.TestCurrentClusterName},
+				},
+			},
+			TableVersion: persistence.DomainTableVersionV1,
+		},
+		nil,
+	)
+	resp, err := s.mockHistoryEngine.TerminateAllWorkflowRuns(context.Background(), terminateRequest)
+	s.NoError(err)
+	s.Empty(resp.TerminatedExecutions)
+}
+
+func (s *engineSuite) TestTerminateAllWorkflowRuns_StartedBeforeBlock() {
+	domainID := validDomainID
+	newRunID := uuid.New()
+	terminateRequest := &history.TerminateAllWorkflowRunsRequest{
+		DomainUUID: common.StringPtr(domainID),
+		TerminateRequest: &workflow.TerminateAllWorkflowRunsRequest{
+			Domain:       common.StringPtr(domainID),
+			WorkflowId:   common.StringPtr("wId"),
+			Reason:       common.StringPtr("test reason"),
+			BlockSeconds: common.Int32Ptr(30),
+		},
+	}
+
+	s.mockExecutionMgr.On("GetCurrentExecution", mock.Anything).Return(
+		&persistence.GetCurrentExecutionResponse{RunID: validRunID, State: persistence.WorkflowStateCompleted}, nil,
+	).Once()
+	s.mockExecutionMgr.On("BlockCurrentWorkflowExecution", mock.MatchedBy(func(request *persistence.BlockCurrentWorkflowExecutionRequest) bool {
+		return request.RunID == validRunID
+	})).Return(&persistence.ConditionFailedError{}).Once()
+	s.mockExecutionMgr.On("GetCurrentExecution", mock.Anything).Return(
+		&persistence.GetCurrentExecutionResponse{RunID: newRunID, State: persistence.WorkflowStateCompleted}, nil,
+	).Once()
+	s.mockExecutionMgr.On("BlockCurrentWorkflowExecution", mock.MatchedBy(func(request *persistence.BlockCurrentWorkflowExecutionRequest) bool {
+		return request.RunID == newRunID && request.BlockedUntil.After(time.Now().Add(20*time.Second))
+	})).Return(nil).Once()
+	s.mockMetadataMgr.On("GetDomain", mock.Anything).Return(
+		&persistence.GetDomainResponse{
+			Info:   &persistence.DomainInfo{ID: domainID},
+			Config: &persistence.DomainConfig{Retention: 1},
+			ReplicationConfig: &persistence.DomainReplicationConfig{
+				ActiveClusterName: cluster.TestCurrentClusterName,
+				Clusters: []*persistence.ClusterReplicationConfig{
+					{ClusterName: cluster.TestCurrentClusterName},
+				},
+			},
+			TableVersion: persistence.DomainTableVersionV1,
+		},
+		nil,
+	)
+	resp, err := s.mockHistoryEngine.TerminateAllWorkflowRuns(context.Background(), terminateRequest)
+	s.NoError(err)
+	s.Empty(resp.TerminatedExecutions)
+}
+
+func (s *engineSuite) TestTerminateAllWorkflowRuns_NotInChain() {
+	domainID := validDomainID
+	we := &workflow.WorkflowExecution{
+		WorkflowId: common.StringPtr("wId"),
+		RunId:      common.StringPtr(validRunID),
+	}
+	terminateRequest := &history.TerminateAllWorkflowRunsRequest{
+		DomainUUID: common.StringPtr(domainID),
+		TerminateRequest: &workflow.TerminateAllWorkflowRunsRequest{
+			Domain:              common.StringPtr(domainID),
+			WorkflowId:          we.WorkflowId,
+			FirstExecutionRunId: common.StringPtr(uuid.New()),
+			Reason:              common.StringPtr("test reason"),
+			BlockSeconds:        common.Int32Ptr(30),
+		},
+	}
+
+	msBuilder := newMutableStateBuilderWithEventV2(s.mockHistoryEngine.shard, s.eventsCache,
+		loggerimpl.NewDevelopmentForTest(s.Suite), we.GetRunId())
+	ms := createMutableState(msBuilder)
+	ms.ExecutionInfo.FirstExecutionRunID = uuid.New()
+	gwmsResponse := &persistence.GetWorkflowExecutionResponse{State: ms}
+
+	s.mockExecutionMgr.On("GetCurrentExecution", mock.Anything).Return(
+		&persistence.GetCurrentExecutionResponse{RunID: validRunID, State: persistence.WorkflowStateRunning}, nil,
+	).Once()
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(gwmsResponse, nil).Once()
+	s.mockMetadataMgr.On("GetDomain", mock.Anything).Return(
+		&persistence.GetDomainResponse{
+			Info:   &persistence.DomainInfo{ID: domainID},
+			Config: &persistence.DomainConfig{Retention: 1},
+			ReplicationConfig: &persistence.DomainReplicationConfig{
+				ActiveClusterName: cluster.TestCurrentClusterName,
+				Clusters: []*persistence.ClusterReplicationConfig{
+					{ClusterName: cluster.TestCurrentClusterName},
+				},
+			},
+			TableVersion: persistence.DomainTableVersionV1,
+		},
+		nil,
+	)
+	resp, err := s.mockHistoryEngine.TerminateAllWorkflowRuns(context.Background(), terminateRequest)
+	s.NoError(err)
+	s.Empty(resp.TerminatedExecutions)
+	s.mockExecutionMgr.AssertNotCalled(s.T(), "BlockCurrentWorkflowExecution", mock.Anything)
+}
+
+func (s *engineSuite) TestPurgeWorkflowExecution() {
+	domainID := validDomainID
+	we := workflow.WorkflowExecution{
+		WorkflowId: common.StringPtr("wId"),
+		RunId:      common.StringPtr(validRunID),
+	}
+	msBuilder := newMutableStateBuilderWithEventV2(s.mockHistoryEngine.shard, s.eventsCache,
+		loggerimpl.NewDevelopmentForTest(s.Suite), we.GetRunId())
+	ms := createMutableState(msBuilder)
+	ms.ExecutionInfo.State = persistence.WorkflowStateCompleted
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(
+		&persistence.GetWorkflowExecutionResponse{State: ms}, nil,
+	).Once()
+
+	var deleted []string
+	record := func(name string) func(mock.Arguments) {
+		return func(mock.Arguments) { deleted = append(deleted, name) }
+	}
+	s.mockExecutionMgr.On("DeleteBufferedReplicationTasks", mock.Anything).Run(record("buffered replication tasks")).Return(nil).Once()
+	s.mockExecutionMgr.On("DeleteClosedExecutionIndex", mock.Anything).Run(record("closed execution index")).Return(nil).Once()
+	s.mockHistoryV2Mgr.On("DeleteHistoryBranch", mock.Anything).Run(record("history")).Return(nil).Once()
+	s.mockExecutionMgr.On("DeleteCurrentWorkflowExecution", mock.Anything).Run(record("current execution")).Return(
+		&persistence.DeleteCurrentWorkflowExecutionResponse{}, nil,
+	).Once()
+	s.mockExecutionMgr.On("DeleteWorkflowExecution", mock.Anything).Run(record("mutable state")).Return(nil).Once()
+
+	err := s.mockHistoryEngine.PurgeWorkflowExecution(context.Background(), &history.PurgeWorkflowExecutionRequest{
+		DomainUUID:        common.StringPtr(domainID),
+		WorkflowExecution: &we,
+	})
+	s.NoError(err)
+	s.Equal([]string{"buffered replication tasks", "closed execution index", "history", "current execution", "mutable state"}, deleted)
+
+	// the host doesn't keep serving the purged run from its cache
+	context, release, err := s.mockHistoryEngine.historyCache.getOrCreateWorkflowExecutionForBackground(domainID, we)
+	s.NoError(err)
+	s.Nil(context.(*workflowExecutionContextImpl).msBuilder)
+	release(nil)
+}
+
+func (s *engineSuite) TestPurgeWorkflowExecution_Running() {
+	we := workflow.WorkflowExecution{
+		WorkflowId: common.StringPtr("wId"),
+		RunId:      common.StringPtr(validRunID),
+	}
+	msBuilder := newMutableStateBuilderWithEventV2(s.mockHistoryEngine.shard, s.eventsCache,
+		loggerimpl.NewDevelopmentForTest(s.Suite), we.GetRunId())
+	addWorkflowExecutionStartedEvent(msBuilder, we, "wType", "testTaskList", []byte("input"), 100, 200, "identity")
+	ms := createMutableState(msBuilder)
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(
+		&persistence.GetWorkflowExecutionResponse{State: ms}, nil,
+	).Once()
+
+	err := s.mockHistoryEngine.PurgeWorkflowExecution(context.Background(), &history.PurgeWorkflowExecutionRequest{
+		DomainUUID:        common.StringPtr(validDomainID),
+		WorkflowExecution: &we,
+	})
+	s.IsType(&workflow.BadRequestError{}, err)
+	s.mockExecutionMgr.AssertNotCalled(s.T(), "DeleteWorkflowExecution", mock.Anything)
+}
+
+func (s *engineSuite) TestPurgeWorkflowExecution_MutableStateDeleted() {
+	domainID := validDomainID
+	we := workflow.WorkflowExecution{
+		WorkflowId: common.StringPtr("wId"),
+		RunId:      common.StringPtr(validRunID),
+	}
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(
+		nil, &workflow.EntityNotExistsError{},
+	).Once()
+	s.mockExecutionMgr.On("DeleteBufferedReplicationTasks", mock.Anything).Return(nil).Once()
+	s.mockExecutionMgr.On("DeleteCurrentWorkflowExecution", mock.Anything).Return(
+		&persistence.DeleteCurrentWorkflowExecutionResponse{}, nil,
+	).Once()
+	s.mockExecutionMgr.On("DeleteWorkflowExecution", mock.Anything).Return(nil).Once()
+
+	err := s.mockHistoryEngine.PurgeWorkflowExecution(context.Background(), &history.PurgeWorkflowExecutionRequest{
+		DomainUUID:        common.StringPtr(domainID),
+		WorkflowExecution: &we,
+	})
+	s.NoError(err)
+	s.mockExecutionMgr.AssertNotCalled(s.T(), "DeleteClosedExecutionIndex", mock.Anything)
+	s.mockHistoryV2Mgr.AssertNotCalled(s.T(), "DeleteHistoryBranch", mock.Anything)
+}
+
+func (s *engineSuite) TestImportWorkflowExecution() {
+	domainID := validDomainID
+	exportedRunID := uuid.New()
+	s.mockMetadataMgr.On("GetDomain", mock.Anything).Return(
+		&persistence.GetDomainResponse{
+			Info:   &persistence.DomainInfo{ID: domainID},
+			Config: &persistence.DomainConfig{Retention: 1},
+			ReplicationConfig: &persistence.DomainReplicationConfig{
+				ActiveClusterName: cluster.TestCurrentClusterName,
+				Clusters: []*persistence.ClusterReplicationConfig{
+					{ClusterName: cluster.TestCurrentClusterName},
+				},
+			},
+			TableVersion: persistence.DomainTableVersionV1,
+		},
+		nil,
+	)
+	s.mockExecutionMgr.On("GetCurrentExecution", mock.Anything).Return(
+		&persistence.GetCurrentExecutionResponse{RunID: exportedRunID, State: persistence.WorkflowStateCompleted, LastWriteVersion: common.EmptyVersion}, nil,
+	).Once()
+
+	var appended [][]*workflow.HistoryEvent
+	s.mockHistoryV2Mgr.On("AppendHistoryNodes", mock.Anything).Run(func(args mock.Arguments) {
+		appended = append(appended, args.Get(0).(*persistence.AppendHistoryNodesRequest).Events)
+	}).Return(&persistence.AppendHistoryNodesResponse{Size: 10}, nil).Times(2)
+	var createRequest *persistence.CreateWorkflowExecutionRequest
+	s.mockExecutionMgr.On("CreateWorkflowExecution", mock.Anything).Run(func(args mock.Arguments) {
+		createRequest = args.Get(0).(*persistence.CreateWorkflowExecutionRequest)
+	}).Return(&persistence.CreateWorkflowExecutionResponse{}, nil).Once()
+
+	resp, err := s.mockHistoryEngine.ImportWorkflowExecution(context.Background(), &history.ImportWorkflowExecutionRequest{
+		DomainUUID: common.StringPtr(domainID),
+		WorkflowId: common.StringPtr("wId"),
+		Snapshot:   s.newExecutionExport("wId", exportedRunID),
+	})
+	s.NoError(err)
+	runID := resp.GetRunId()
+	s.NotEqual(exportedRunID, runID)
+
+	s.Len(appended, 2)
+	attributes := appended[0][0].WorkflowExecutionStartedEventAttributes
+	s.Equal(runID, attributes.GetOriginalExecutionRunId())
+	s.Equal(runID, attributes.GetFirstExecutionRunId())
+	s.Nil(attributes.ContinuedExecutionRunId)
+
+	s.Equal(persistence.CreateWorkflowModeWorkflowIDReuse, createRequest.CreateWorkflowMode)
+	s.Equal(exportedRunID, createRequest.PreviousRunID)
+	snapshot := createRequest.NewWorkflowSnapshot
+	s.Equal(domainID, snapshot.ExecutionInfo.DomainID)
+	s.Equal(runID, snapshot.ExecutionInfo.RunID)
+	s.Equal(runID, snapshot.ExecutionInfo.FirstExecutionRunID)
+	s.Equal(int64(20), snapshot.ExecutionStats.HistorySize)
+	s.Nil(snapshot.ReplicationState)
+	s.Empty(snapshot.ReplicationTasks)
+	closeTasks := 0
+	for _, task := range append(snapshot.TransferTasks, snapshot.VisibilityTasks...) {
+		switch task.(type) {
+		case *persistence.CloseExecutionTask, *persistence.CloseExecutionVisibilityTask:
+			closeTasks++
+		}
+	}
+	s.Equal(1, closeTasks)
+	s.Len(snapshot.TimerTasks, 1)
+	s.IsType(&persistence.DeleteHistoryEventTask{}, snapshot.TimerTasks[0])
+}
+
+func (s *engineSuite) TestImportWorkflowExecution_GlobalDomain() {
+	domainID := validDomainID
+	failoverVersion := int64(100)
+	s.mockMetadataMgr.On("GetDomain", mock.Anything).Return(
+		&persistence.GetDomainResponse{
+			Info:   &persistence.DomainInfo{ID: domainID},
+			Config: &persistence.DomainConfig{Retention: 1},
+			ReplicationConfig: &persistence.DomainReplicationConfig{
+				ActiveClusterName: cluster.TestCurrentClusterName,
+				Clusters: []*persistence.ClusterReplicationConfig{
+					{ClusterName: cluster.TestCurrentClusterName},
+					{ClusterName: cluster.TestAlternativeClusterName},
+				},
+			},
+			IsGlobalDomain:  true,
+			FailoverVersion: failoverVersion,
+			TableVersion:    persistence.DomainTableVersionV1,
+		},
+		nil,
+	)
+	s.mockExecutionMgr.On("GetCurrentExecution", mock.Anything).Return(nil, &workflow.EntityNotExistsError{}).Once()
+	var appended [][]*workflow.HistoryEvent
+	s.mockHistoryV2Mgr.On("AppendHistoryNodes", mock.Anything).Run(func(args mock.Arguments) {
+		appended = append(appended, args.Get(0).(*persistence.AppendHistoryNodesRequest).Events)
+	}).Return(&persistence.AppendHistoryNodesResponse{Size: 10}, nil).Times(2)
+	var createRequest *persistence.CreateWorkflowExecutionRequest
+	s.mockExecutionMgr.On("CreateWorkflowExecution", mock.Anything).Run(func(args mock.Arguments) {
+		createRequest = args.Get(0).(*persistence.CreateWorkflowExecutionRequest)
+	}).Return(&persistence.CreateWorkflowExecutionResponse{}, nil).Once()
+
+	_, err := s.mockHistoryEngine.ImportWorkflowExecution(context.Background(), &history.ImportWorkflowExecutionRequest{
+		DomainUUID: common.StringPtr(domainID),
+		WorkflowId: common.StringPtr("wId"),
+		Snapshot:   s.newExecutionExport("wId", uuid.New()),
+	})
+	s.NoError(err)
+
+	s.Equal(persistence.CreateWorkflowModeBrandNew, createRequest.CreateWorkflowMode)
+	for _, events := range appended {
+		for _, event := range events {
+			s.Equal(failoverVersion, event.GetVersion())
+		}
+	}
+	snapshot := createRequest.NewWorkflowSnapshot
+	s.Equal(failoverVersion, snapshot.ReplicationState.LastWriteVersion)
+	s.Equal(int64(2), snapshot.ReplicationState.LastWriteEventID)
+	s.Len(snapshot.ReplicationTasks, 2)
+	for i, task := range snapshot.ReplicationTasks {
+		replicationTask := task.(*persistence.HistoryReplicationTask)
+		s.Equal(int64(i+1), replicationTask.FirstEventID)
+		s.Equal(int64(i+2), replicationTask.NextEventID)
+		s.Equal(failoverVersion, replicationTask.Version)
+		s.Equal(snapshot.ExecutionInfo.BranchToken, replicationTask.BranchToken)
+	}
+}
+
+func (s *engineSuite) TestImportWorkflowExecution_WorkflowRunning() {
+	domainID := validDomainID
+	s.mockMetadataMgr.On("GetDomain", mock.Anything).Return(
+		&persistence.GetDomainResponse{
+			Info:   &persistence.DomainInfo{ID: domainID},
+			Config: &persistence.DomainConfig{Retention: 1},
+			ReplicationConfig: &persistence.DomainReplicationConfig{
+				ActiveClusterName: cluster.TestCurrentClusterName,
+				Clusters: []*persistence.ClusterReplicationConfig{
+					{ClusterName: cluster.TestCurrentClusterName},
+				},
+			},
+			TableVersion: persistence.DomainTableVersionV1,
+		},
+		nil,
+	)
+	s.mockExecutionMgr.On("GetCurrentExecution", mock.Anything).Return(
+		&persistence.GetCurrentExecutionResponse{RunID: validRunID, State: persistence.WorkflowStateRunning}, nil,
+	).Once()
+
+	_, err := s.mockHistoryEngine.ImportWorkflowExecution(context.Background(), &history.ImportWorkflowExecutionRequest{
+		DomainUUID: common.StringPtr(domainID),
+		WorkflowId: common.StringPtr("wId"),
+		Snapshot:   s.newExecutionExport("wId", uuid.New()),
+	})
+	s.IsType(&workflow.BadRequestError{}, err)
+}
+
+func (s *engineSuite) newExecutionExport(workflowID string, runID string) []byte {
+	serializer := persistence.NewPayloadSerializer()
+	started, err := serializer.SerializeBatchEvents([]*workflow.HistoryEvent{{
+		EventId:   common.Int64Ptr(common.FirstEventID),
+		EventType: workflow.EventTypeWorkflowExecutionStarted.Ptr(),
+		Version:   common.Int64Ptr(7),
+		WorkflowExecutionStartedEventAttributes: &workflow.WorkflowExecutionStartedEventAttributes{
+			OriginalExecutionRunId:  common.StringPtr(runID),
+			FirstExecutionRunId:     common.StringPtr(runID),
+			ContinuedExecutionRunId: common.StringPtr(uuid.New()),
+		},
+	}}, common.EncodingTypeThriftRW)
+	s.NoError(err)
+	completed, err := serializer.SerializeBatchEvents([]*workflow.HistoryEvent{{
+		EventId:   common.Int64Ptr(common.FirstEventID + 1),
+		EventType: workflow.EventTypeWorkflowExecutionCompleted.Ptr(),
+		Version:   common.Int64Ptr(7),
+	}}, common.EncodingTypeThriftRW)
+	s.NoError(err)
+
+	data, err := persistence.EncodeExecutionExport(&persistence.ExecutionExport{
+		Version:      persistence.ExecutionExportVersion,
+		ExportedTime: time.Now(),
+		MutableState: &persistence.WorkflowMutableState{
+			ExecutionInfo: &persistence.WorkflowExecutionInfo{
+				DomainID:            uuid.New(),
+				WorkflowID:          workflowID,
+				RunID:               runID,
+				FirstExecutionRunID: runID,
+				NextEventID:         common.FirstEventID + 2,
+				State:               persistence.WorkflowStateCompleted,
+				CloseStatus:         persistence.WorkflowCloseStatusCompleted,
+				EventStoreVersion:   persistence.EventStoreVersionV2,
+			},
+			ReplicationState: &persistence.ReplicationState{LastWriteVersion: 7},
+		},
+		History: []*persistence.DataBlob{started, completed},
+	})
+	s.NoError(err)
+	return data
+}
+
+func (s *engineSuite) TestSetCronSchedulePaused_NotCron() {
+	domainID := validDomainID
+	we := &workflow.WorkflowExecution{
+		WorkflowId: common.StringPtr("wId"),
+		RunId:      common.StringPtr(validRunID),
+	}
+	pauseRequest := &history.SetCronSchedulePausedRequest{
+		DomainUUID: common.StringPtr(domainID),
+		PauseRequest: &workflow.SetCronSchedulePausedRequest{
+			Domain:    common.StringPtr(domainID),
+			Execution: we,
+			Paused:    common.BoolPtr(true),
+		},
+	}
+
+	msBuilder := newMutableStateBuilderWithEventV2(s.mockHistoryEngine.shard, s.eventsCache,
+		loggerimpl.NewDevelopmentForTest(s.Suite), we.GetRunId())
+	ms := createMutableState(msBuilder)
+	gwmsResponse := &persistence.GetWorkflowExecutionResponse{State: ms}
+
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(gwmsResponse, nil).Once()
+	s.mockMetadataMgr.On("GetDomain", mock.Anything).Return(
+		&persistence.GetDomainResponse{
+			Info:   &persistence.DomainInfo{ID: domainID},
+			Config: &persistence.DomainConfig{Retention: 1},
+			ReplicationConfig: &persistence.DomainReplicationConfig{
+				ActiveClusterName: cluster.TestCurrentClusterName,
+				Clusters: []*persistence.ClusterReplicationConfig{
+					{ClusterName: cluster.TestCurrentClusterName},
+				},
+			},
+			TableVersion: persistence.DomainTableVersionV1,
+		},
+		nil,
+	)
+	err := s.mockHistoryEngine.SetCronSchedulePaused(context.Background(), pauseRequest)
+	s.Equal(ErrWorkflowNotCron, err)
+}
+
+func (s *engineSuite) TestSetWorkflowExecutionNotes() {
+	domainID := validDomainID
+	we := &workflow.WorkflowExecution{
+		WorkflowId: common.StringPtr("wId"),
+		RunId:      common.StringPtr(validRunID),
+	}
+	notesRequest := &history.SetWorkflowExecutionNotesRequest{
+		DomainUUID: common.StringPtr(domainID),
+		NotesRequest: &workflow.SetWorkflowExecutionNotesRequest{
+			Domain:    common.StringPtr(domainID),
+			Execution: we,
+			Notes:     common.StringPtr("stuck on a bad deploy, do not reset"),
+			Identity:  common.StringPtr("oncall"),
+		},
+	}
+
+	msBuilder := newMutableStateBuilderWithEventV2(s.mockHistoryEngine.shard, s.eventsCache,
+		loggerimpl.NewDevelopmentForTest(s.Suite), we.GetRunId())
+	addWorkflowExecutionStartedEvent(msBuilder, *we, "wType", "testTaskList", []byte("input"), 100, 200, "identity")
+	addDecisionTaskScheduledEvent(msBuilder)
+	ms := createMutableState(msBuilder)
+	gwmsResponse := &persistence.GetWorkflowExecutionResponse{State: ms}
+
+	// the notes are not part of the history, only the mutable state is updated
+	var updateRequest *persistence.UpdateWorkflowExecutionRequest
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(gwmsResponse, nil).Once()
+	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.Anything).Run(func(args mock.Arguments) {
+		updateRequest = args.Get(0).(*persistence.UpdateWorkflowExecutionRequest)
+	}).Return(&p.UpdateWorkflowExecutionResponse{MutableStateUpdateSessionStats: &p.MutableStateUpdateSessionStats{}}, nil).Once()
+	s.mockMetadataMgr.On("GetDomain", mock.Anything).Return(
+		&persistence.GetDomainResponse{
+			Info:   &persistence.DomainInfo{ID: domainID},
+			Config: &persistence.DomainConfig{Retention: 1},
+			ReplicationConfig: &persistence.DomainReplicationConfig{
+				ActiveClusterName: cluster.TestCurrentClusterName,
+				Clusters: []*persistence.ClusterReplicationConfig{
+					{ClusterName: cluster.TestCurrentClusterName},
+				},
+			},
+			TableVersion: persistence.DomainTableVersionV1,
+		},
+		nil,
+	)
+
+	err := s.mockHistoryEngine.SetWorkflowExecutionNotes(context.Background(), notesRequest)
+	s.NoError(err)
+	executionInfo := updateRequest.UpdateWorkflowMutation.ExecutionInfo
+	s.Equal("stuck on a bad deploy, do not reset", executionInfo.Notes)
+	s.Equal("oncall", executionInfo.NotesIdentity)
+	s.False(executionInfo.NotesLastUpdatedTime.IsZero())
+
+	// the notes are described from the mutable state already in the cache
+	resp, err := s.mockHistoryEngine.DescribeWorkflowExecution(context.Background(), &history.DescribeWorkflowExecutionRequest{
+		DomainUUID: common.StringPtr(domainID),
+		Request: &workflow.DescribeWorkflowExecutionRequest{
+			Domain:    common.StringPtr(domainID),
+			Execution: we,
+		},
+	})
+	s.NoError(err)
+	s.Equal("stuck on a bad deploy, do not reset", resp.ExecutionNotes.GetNotes())
+	s.Equal("oncall", resp.ExecutionNotes.GetIdentity())
+	s.Equal(executionInfo.NotesLastUpdatedTime.UnixNano(), resp.ExecutionNotes.GetLastUpdatedTimestamp())
+}
+
+func (s *engineSuite) TestSetWorkflowExecutionNotes_Clear() {
+	domainID := validDomainID
+	we := &workflow.WorkflowExecution{
+		WorkflowId: common.StringPtr("wId"),
+		RunId:      common.StringPtr(validRunID),
+	}
+	notesRequest := &history.SetWorkflowExecutionNotesRequest{
+		DomainUUID: common.StringPtr(domainID),
+		NotesRequest: &workflow.SetWorkflowExecutionNotesRequest{
+			Domain:    common.StringPtr(domainID),
+			Execution: we,
+			Notes:     common.StringPtr(""),
+			Identity:  common.StringPtr("oncall"),
+		},
+	}
+
+	msBuilder := newMutableStateBuilderWithEventV2(s.mockHistoryEngine.shard, s.eventsCache,
+		loggerimpl.NewDevelopmentForTest(s.Suite), we.GetRunId())
+	addWorkflowExecutionStartedEvent(msBuilder, *we, "wType", "testTaskList", []byte("input"), 100, 200, "identity")
+	addDecisionTaskScheduledEvent(msBuilder)
+	ms := createMutableState(msBuilder)
+	ms.ExecutionInfo.Notes = "stuck on a bad deploy, do not reset"
+	ms.ExecutionInfo.NotesIdentity = "oncall"
+	ms.ExecutionInfo.NotesLastUpdatedTime = time.Now()
+	gwmsResponse := &persistence.GetWorkflowExecutionResponse{State: ms}
+
+	var updateRequest *persistence.UpdateWorkflowExecutionRequest
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(gwmsResponse, nil).Once()
+	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.Anything).Run(func(args mock.Arguments) {
+		updateRequest = args.Get(0).(*persistence.UpdateWorkflowExecutionRequest)
+	}).Return(&p.UpdateWorkflowExecutionResponse{MutableStateUpdateSessionStats: &p.MutableStateUpdateSessionStats{}}, nil).Once()
+	s.mockMetadataMgr.On("GetDomain", mock.Anything).Return(
+		&persistence.GetDomainResponse{
+			Info:   &persistence.DomainInfo{ID: domainID},
+			Config: &persistence.DomainConfig{Retention: 1},
+			ReplicationConfig: &persistence.DomainReplicationConfig{
+				ActiveClusterName: cluster.TestCurrentClusterName,
+				Clusters: []*persistence.ClusterReplicationConfig{
+					{ClusterName: cluster.TestCurrentClusterName},
+				},
+			},
+			TableVersion: persistence.DomainTableVersionV1,
+		},
+		nil,
+	)
+
+	err := s.mockHistoryEngine.SetWorkflowExecutionNotes(context.Background(), notesRequest)
+	s.NoError(err)
+	executionInfo := updateRequest.UpdateWorkflowMutation.ExecutionInfo
+	s.Empty(executionInfo.Notes)
+	s.Empty(executionInfo.NotesIdentity)
+	s.True(executionInfo.NotesLastUpdatedTime.IsZero())
+
+	// clearing the notes again is a noop
+	err = s.mockHistoryEngine.SetWorkflowExecutionNotes(context.Background(), notesRequest)
+	s.NoError(err)
+}
+
+func (s *engineSuite) TestSetWorkflowExecutionPaused() {
+	domainID := validDomainID
+	we := &workflow.WorkflowExecution{
+		WorkflowId: common.StringPtr("wId"),
+		RunId:      common.StringPtr(validRunID),
+	}
+	pauseRequest := &history.SetWorkflowExecutionPausedRequest{
+		DomainUUID: common.StringPtr(domainID),
+		PauseRequest: &workflow.SetWorkflowExecutionPausedRequest{
+			Domain:    common.StringPtr(domainID),
+			Execution: we,
+			Paused:    common.BoolPtr(true),
+		},
+	}
+
+	msBuilder := newMutableStateBuilderWithEventV2(s.mockHistoryEngine.shard, s.eventsCache,
+		loggerimpl.NewDevelopmentForTest(s.Suite), we.GetRunId())
+	addWorkflowExecutionStartedEvent(msBuilder, *we, "wType", "testTaskList", []byte("input"), 100, 200, "identity")
+	addDecisionTaskScheduledEvent(msBuilder)
+	ms := createMutableState(msBuilder)
+	gwmsResponse := &persistence.GetWorkflowExecutionResponse{State: ms}
+
+	var appended []*workflow.HistoryEvent
+	var updateRequest *persistence.UpdateWorkflowExecutionRequest
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(gwmsResponse, nil).Once()
+	s.mockHistoryV2Mgr.On("AppendHistoryNodes", mock.Anything).Run(func(args mock.Arguments) {
+		appended = args.Get(0).(*persistence.AppendHistoryNodesRequest).Events
+	}).Return(&p.AppendHistoryNodesResponse{Size: 0}, nil).Once()
+	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.Anything).Run(func(args mock.Arguments) {
+		updateRequest = args.Get(0).(*persistence.UpdateWorkflowExecutionRequest)
+	}).Return(&p.UpdateWorkflowExecutionResponse{MutableStateUpdateSessionStats: &p.MutableStateUpdateSessionStats{}}, nil).Once()
+	s.mockMetadataMgr.On("GetDomain", mock.Anything).Return(
+		&persistence.GetDomainResponse{
+			Info:   &persistence.DomainInfo{ID: domainID},
+			Config: &persistence.DomainConfig{Retention: 1},
+			ReplicationConfig: &persistence.DomainReplicationConfig{
+				ActiveClusterName: cluster.TestCurrentClusterName,
+				Clusters: []*persistence.ClusterReplicationConfig{
+					{ClusterName: cluster.TestCurrentClusterName},
+				},
+			},
+			TableVersion: persistence.DomainTableVersionV1,
+		},
+		nil,
+	)
+
+	err := s.mockHistoryEngine.SetWorkflowExecutionPaused(context.Background(), pauseRequest)
+	s.NoError(err)
+	s.Len(appended, 1)
+	s.Equal(workflow.EventTypeWorkflowExecutionPauseUpdated, appended[0].GetEventType())
+	s.True(appended[0].WorkflowExecutionPauseUpdatedEventAttributes.GetPaused())
+	s.True(updateRequest.UpdateWorkflowMutation.ExecutionInfo.Paused)
+	s.Empty(updateRequest.UpdateWorkflowMutation.TransferTasks)
+}
+
+func (s *engineSuite) TestSetWorkflowExecutionPaused_Resume() {
+	domainID := validDomainID
+	we := &workflow.WorkflowExecution{
+		WorkflowId: common.StringPtr("wId"),
+		RunId:      common.StringPtr(validRunID),
+	}
+	pauseRequest := &history.SetWorkflowExecutionPausedRequest{
+		DomainUUID: common.StringPtr(domainID),
+		PauseRequest: &workflow.SetWorkflowExecutionPausedRequest{
+			Domain:    common.StringPtr(domainID),
+			Execution: we,
+			Paused:    common.BoolPtr(false),
+		},
+	}
+
+	msBuilder := newMutableStateBuilderWithEventV2(s.mockHistoryEngine.shard, s.eventsCache,
+		loggerimpl.NewDevelopmentForTest(s.Suite), we.GetRunId())
+	addWorkflowExecutionStartedEvent(msBuilder, *we, "wType", "testTaskList", []byte("input"), 100, 200, "identity")
+	di := addDecisionTaskScheduledEvent(msBuilder)
+	ms := createMutableState(msBuilder)
+	ms.ExecutionInfo.Paused = true
+	gwmsResponse := &persistence.GetWorkflowExecutionResponse{State: ms}
+
+	var appended []*workflow.HistoryEvent
+	var updateRequest *persistence.UpdateWorkflowExecutionRequest
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(gwmsResponse, nil).Once()
+	s.mockHistoryV2Mgr.On("AppendHistoryNodes", mock.Anything).Run(func(args mock.Arguments) {
+		appended = args.Get(0).(*persistence.AppendHistoryNodesRequest).Events
+	}).Return(&p.AppendHistoryNodesResponse{Size: 0}, nil).Once()
+	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.Anything).Run(func(args mock.Arguments) {
+		updateRequest = args.Get(0).(*persistence.UpdateWorkflowExecutionRequest)
+	}).Return(&p.UpdateWorkflowExecutionResponse{MutableStateUpdateSessionStats: &p.MutableStateUpdateSessionStats{}}, nil).Once()
+	s.mockMetadataMgr.On("GetDomain", mock.Anything).Return(
+		&persistence.GetDomainResponse{
+			Info:   &persistence.DomainInfo{ID: domainID},
+			Config: &persistence.DomainConfig{Retention: 1},
+			ReplicationConfig: &persistence.DomainReplicationConfig{
+				ActiveClusterName: cluster.TestCurrentClusterName,
+				Clusters: []*persistence.ClusterReplicationConfig{
+					{ClusterName: cluster.TestCurrentClusterName},
+				},
+			},
+			TableVersion: persistence.DomainTableVersionV1,
+		},
+		nil,
+	)
+
+	err := s.mockHistoryEngine.SetWorkflowExecutionPaused(context.Background(), pauseRequest)
+	s.NoError(err)
+	s.Len(appended, 1)
+	s.False(appended[0].WorkflowExecutionPauseUpdatedEventAttributes.GetPaused())
+	s.False(updateRequest.UpdateWorkflowMutation.ExecutionInfo.Paused)
+	// the pending decision task is dispatched again
+	s.Len(updateRequest.UpdateWorkflowMutation.TransferTasks, 1)
+	decisionTask, ok := updateRequest.UpdateWorkflowMutation.TransferTasks[0].(*persistence.DecisionTask)
+	s.True(ok)
+	s.Equal(di.ScheduleID, decisionTask.ScheduleID)
+}
+
+func (s *engineSuite) TestSetCronSchedulePaused() {
+	domainID := validDomainID
+	we := &workflow.WorkflowExecution{
+		WorkflowId: common.StringPtr("wId"),
+		RunId:      common.StringPtr(validRunID),
+	}
+	pauseRequest := &history.SetCronSchedulePausedRequest{
+		DomainUUID: common.StringPtr(domainID),
+		PauseRequest: &workflow.SetCronSchedulePausedRequest{
+			Domain:    common.StringPtr(domainID),
+			Execution: we,
+			Paused:    common.BoolPtr(true),
+		},
+	}
+
+	msBuilder := newMutableStateBuilderWithEventV2(s.mockHistoryEngine.shard, s.eventsCache,
+		loggerimpl.NewDevelopmentForTest(s.Suite), we.GetRunId())
+	addWorkflowExecutionStartedEvent(msBuilder, *we, "wType", "testTaskList", []byte("input"), 100, 200, "identity")
+	ms := createMutableState(msBuilder)
+	ms.ExecutionInfo.CronSchedule = "@every 1m"
+	gwmsResponse := &persistence.GetWorkflowExecutionResponse{State: ms}
+
+	var appended []*workflow.HistoryEvent
+	var updateRequest *persistence.UpdateWorkflowExecutionRequest
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(gwmsResponse, nil).Once()
+	s.mockHistoryV2Mgr.On("AppendHistoryNodes", mock.Anything).Run(func(args mock.Arguments) {
+		appended = args.Get(0).(*persistence.AppendHistoryNodesRequest).Events
+	}).Return(&p.AppendHistoryNodesResponse{Size: 0}, nil).Once()
+	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.Anything).Run(func(args mock.Arguments) {
+		updateRequest = args.Get(0).(*persistence.UpdateWorkflowExecutionRequest)
+	}).Return(&p.UpdateWorkflowExecutionResponse{MutableStateUpdateSessionStats: &p.MutableStateUpdateSessionStats{}}, nil).Once()
+	s.mockMetadataMgr.On("GetDomain", mock.Anything).Return(
+		&persistence.GetDomainResponse{
+			Info:   &persistence.DomainInfo{ID: domainID},
+			Config: &persistence.DomainConfig{Retention: 1},
+			ReplicationConfig: &persistence.DomainReplicationConfig{
+				ActiveClusterName: cluster.TestCurrentClusterName,
+				Clusters: []*persistence.ClusterReplicationConfig{
+					{ClusterName: cluster.TestCurrentClusterName},
+				},
+			},
+			TableVersion: persistence.DomainTableVersionV1,
+		},
+		nil,
+	)
+
+	err := s.mockHistoryEngine.SetCronSchedulePaused(context.Background(), pauseRequest)
+	s.NoError(err)
+	s.Len(appended, 1)
+	s.Equal(workflow.EventTypeCronSchedulePauseUpdated, appended[0].GetEventType())
+	s.True(appended[0].CronSchedulePauseUpdatedEventAttributes.GetPaused())
+	s.True(updateRequest.UpdateWorkflowMutation.ExecutionInfo.CronPaused)
+	s.Empty(updateRequest.UpdateWorkflowMutation.TimerTasks)
+}
+
+func (s *engineSuite) TestSetCronSchedulePaused_Resume() {
+	domainID := validDomainID
+	we := &workflow.WorkflowExecution{
+		WorkflowId: common.StringPtr("wId"),
+		RunId:      common.StringPtr(validRunID),
+	}
+	pauseRequest := &history.SetCronSchedulePausedRequest{
+		DomainUUID: common.StringPtr(domainID),
+		PauseRequest: &workflow.SetCronSchedulePausedRequest{
+			Domain:    common.StringPtr(domainID),
+			Execution: we,
+			Paused:    common.BoolPtr(false),
+		},
+	}
+
+	msBuilder := newMutableStateBuilderWithEventV2(s.mockHistoryEngine.shard, s.eventsCache,
+		loggerimpl.NewDevelopmentForTest(s.Suite), we.GetRunId())
+	startEvent := addWorkflowExecutionStartedEvent(msBuilder, *we, "wType", "testTaskList", []byte("input"), 100, 200, "identity")
+	startEvent.WorkflowExecutionStartedEventAttributes.FirstDecisionTaskBackoffSeconds = common.Int32Ptr(3600)
+	s.eventsCache.putEvent(domainID, we.GetWorkflowId(), we.GetRunId(), common.FirstEventID, startEvent)
+	ms := createMutableState(msBuilder)
+	ms.ExecutionInfo.CronSchedule = "@every 1h"
+	ms.ExecutionInfo.CronPaused = true
+	gwmsResponse := &persistence.GetWorkflowExecutionResponse{State: ms}
+
+	var appended []*workflow.HistoryEvent
+	var updateRequest *persistence.UpdateWorkflowExecutionRequest
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(gwmsResponse, nil).Once()
+	s.mockHistoryV2Mgr.On("AppendHistoryNodes", mock.Anything).Run(func(args mock.Arguments) {
+		appended = args.Get(0).(*persistence.AppendHistoryNodesRequest).Events
+	}).Return(&p.AppendHistoryNodesResponse{Size: 0}, nil).Once()
+	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.Anything).Run(func(args mock.Arguments) {
+		updateRequest = args.Get(0).(*persistence.UpdateWorkflowExecutionRequest)
+	}).Return(&p.UpdateWorkflowExecutionResponse{MutableStateUpdateSessionStats: &p.MutableStateUpdateSessionStats{}}, nil).Once()
+	s.mockMetadataMgr.On("GetDomain", mock.Anything).Return(
+		&persistence.GetDomainResponse{
+			Info:   &persistence.DomainInfo{ID: domainID},
+			Config: &persistence.DomainConfig{Retention: 1},
+			ReplicationConfig: &persistence.DomainReplicationConfig{
+				ActiveClusterName: cluster.TestCurrentClusterName,
+				Clusters: []*persistence.ClusterReplicationConfig{
+					{ClusterName: cluster.TestCurrentClusterName},
+				},
+			},
+			TableVersion: persistence.DomainTableVersionV1,
+		},
+		nil,
+	)
+
+	err := s.mockHistoryEngine.SetCronSchedulePaused(context.Background(), pauseRequest)
+	s.NoError(err)
+	s.Len(appended, 1)
+	s.False(appended[0].CronSchedulePauseUpdatedEventAttributes.GetPaused())
+	s.False(updateRequest.UpdateWorkflowMutation.ExecutionInfo.CronPaused)
+	// the backoff timer is created again for the end of the cron backoff
+	s.Len(updateRequest.UpdateWorkflowMutation.TimerTasks, 1)
+	timerTask, ok := updateRequest.UpdateWorkflowMutation.TimerTasks[0].(*persistence.WorkflowBackoffTimerTask)
+	s.True(ok)
+	s.Equal(persistence.WorkflowBackoffTimeoutTypeCron, timerTask.TimeoutType)
+	s.Equal(time.Unix(0, startEvent.GetTimestamp()).Add(time.Hour).UnixNano(), timerTask.VisibilityTimestamp.UnixNano())
+}
+
 func (s *engineSuite) TestRemoveSignalMutableState() {
 	removeRequest := &history.RemoveSignalMutableStateRequest{}
 	err := s.mockHistoryEngine.RemoveSignalMutableState(context.Background(), removeRequest)
@@ -5183,6 +6165,7 @@ func copyWorkflowExecutionInfo(sourceInfo *persistence.WorkflowExecutionInfo) *p
 		EventStoreVersion:            sourceInfo.EventStoreVersion,
 		BranchToken:                  sourceInfo.BranchToken,
 		ExpirationSeconds:            sourceInfo.ExpirationSeconds,
+		CompletedActivityRequestIDs:  sourceInfo.CompletedActivityRequestIDs,
 	}
 }
 
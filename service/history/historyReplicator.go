@@ -340,6 +340,15 @@ func (r *historyReplicator) ApplyEvents(
 		tag.WorkflowFirstEventID(request.GetFirstEventId()),
 		tag.WorkflowNextEventID(request.GetNextEventId()))
 
+	ok, err := r.deserializeHistoryBlobs(request)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		logger.Info("Dropping stale replication task.")
+		return nil
+	}
+
 	r.metricsClient.RecordTimer(
 		metrics.ReplicateHistoryEventsScope,
 		metrics.ReplicationEventsSizeTimer,
@@ -661,6 +670,11 @@ func (r *historyReplicator) ApplyOtherEvents(
 			return nil
 		}
 
+		// the events between the last applied batch and this one are missing on this cluster
+		executionInfo := msBuilder.GetExecutionInfo()
+		logger.Debug("Replication task is ahead of the last applied event batch.",
+			tag.WorkflowLastFirstEventID(executionInfo.LastFirstEventID),
+			tag.WorkflowLastEventTaskID(executionInfo.LastEventTaskID))
 		return newRetryTaskErrorWithHint(
 			ErrRetryBufferEventsMsg,
 			context.getDomainID(),
@@ -1217,6 +1231,38 @@ func (r *historyReplicator) deserializeBlob(
 	return historyEvents, nil
 }
 
+// deserializeHistoryBlobs decodes the event batches of a replication request which were sent as persisted.
+// Those batches are not decoded by the source cluster, so the check that the events still carry the version
+// of the replication task is done here, returning false if they do not.
+func (r *historyReplicator) deserializeHistoryBlobs(
+	request *h.ReplicateEventsRequest,
+) (bool, error) {
+
+	if request.HistoryBlob == nil {
+		return true, nil
+	}
+
+	events, err := r.deserializeBlob(request.HistoryBlob)
+	if err != nil {
+		return false, err
+	}
+	for _, event := range events {
+		if event.GetVersion() != request.GetVersion() {
+			return false, nil
+		}
+	}
+	request.History = &shared.History{Events: events}
+
+	if request.NewRunHistoryBlob != nil {
+		newRunEvents, err := r.deserializeBlob(request.NewRunHistoryBlob)
+		if err != nil {
+			return false, err
+		}
+		request.NewRunHistory = &shared.History{Events: newRunEvents}
+	}
+	return true, nil
+}
+
 func (r *historyReplicator) flushEventsBuffer(
 	context workflowExecutionContext,
 	msBuilder mutableState,
@@ -1435,7 +1481,7 @@ func (r *historyReplicator) persistWorkflowMutation(
 			tBuilder := newTimerBuilder(r.shard.GetConfig(), r.logger, r.timeSource)
 			stickyTaskTimeoutTimer := tBuilder.AddScheduleToStartDecisionTimoutTask(
 				di.ScheduleID,
-				di.Attempt,
+				di.scheduleAttempt(),
 				executionInfo.StickyScheduleToStartTimeout,
 			)
 			timerTasks = append(timerTasks, stickyTaskTimeoutTimer)
@@ -43,6 +43,7 @@ import (
 	"github.com/uber/cadence/common/definition"
 	"github.com/uber/cadence/common/log"
 	"github.com/uber/cadence/common/log/loggerimpl"
+	"github.com/uber/cadence/common/log/tag"
 	"github.com/uber/cadence/common/messaging"
 	"github.com/uber/cadence/common/metrics"
 	"github.com/uber/cadence/common/mocks"
@@ -2416,8 +2417,22 @@ func (s *historyReplicatorSuite) TestApplyOtherEvents_IncomingGreaterThanCurrent
 
 	msBuilder.On("GetNextEventID").Return(currentNextEventID)
 	msBuilder.On("IsWorkflowExecutionRunning").Return(true)
+	lastFirstEventID := currentNextEventID - 3
+	lastEventTaskID := int64(1234)
+	msBuilder.On("GetExecutionInfo").Return(&persistence.WorkflowExecutionInfo{
+		LastFirstEventID: lastFirstEventID,
+		LastEventTaskID:  lastEventTaskID,
+	})
 
-	err := s.historyReplicator.ApplyOtherEvents(ctx.Background(), context, msBuilder, request, s.logger)
+	// the last applied event batch is logged to find the batch missing on this cluster
+	logger := &log.MockLogger{}
+	defer logger.AssertExpectations(s.T())
+	logger.On("Debug", mock.Anything, []tag.Tag{
+		tag.WorkflowLastFirstEventID(lastFirstEventID),
+		tag.WorkflowLastEventTaskID(lastEventTaskID),
+	}).Once()
+
+	err := s.historyReplicator.ApplyOtherEvents(ctx.Background(), context, msBuilder, request, logger)
 	s.Equal(newRetryTaskErrorWithHint(ErrRetryBufferEventsMsg, domainID, workflowID, runID, currentNextEventID), err)
 }
 
@@ -254,6 +254,73 @@ func (s *TestShardContext) UpdateReplicatorAckLevel(ackLevel int64) error {
 	return nil
 }
 
+// GetVisibilityAckLevel test implementation
+func (s *TestShardContext) GetVisibilityAckLevel() int64 {
+	return atomic.LoadInt64(&s.shardInfo.VisibilityAckLevel)
+}
+
+// UpdateVisibilityAckLevel test implementation
+func (s *TestShardContext) UpdateVisibilityAckLevel(ackLevel int64) error {
+	atomic.StoreInt64(&s.shardInfo.VisibilityAckLevel, ackLevel)
+	return nil
+}
+
+// GetQueueAlarms test implementation
+func (s *TestShardContext) GetQueueAlarms() map[string]persistence.QueueAlarm {
+	s.RLock()
+	defer s.RUnlock()
+
+	alarms := make(map[string]persistence.QueueAlarm, len(s.shardInfo.QueueAlarms))
+	for queue, alarm := range s.shardInfo.QueueAlarms {
+		alarms[queue] = alarm
+	}
+	return alarms
+}
+
+// UpdateQueueAlarm test implementation
+func (s *TestShardContext) UpdateQueueAlarm(queue string, alarm *persistence.QueueAlarm) error {
+	s.Lock()
+	defer s.Unlock()
+
+	if alarm == nil {
+		delete(s.shardInfo.QueueAlarms, queue)
+		return nil
+	}
+	if s.shardInfo.QueueAlarms == nil {
+		s.shardInfo.QueueAlarms = make(map[string]persistence.QueueAlarm)
+	}
+	s.shardInfo.QueueAlarms[queue] = *alarm
+	return nil
+}
+
+// GetHotExecutions test implementation
+func (s *TestShardContext) GetHotExecutions() []persistence.HotExecution {
+	s.RLock()
+	defer s.RUnlock()
+
+	return append([]persistence.HotExecution(nil), s.shardInfo.HotExecutions...)
+}
+
+// UpdateHotExecutions test implementation
+func (s *TestShardContext) UpdateHotExecutions(executions []persistence.HotExecution) {
+	s.Lock()
+	defer s.Unlock()
+
+	s.shardInfo.HotExecutions = executions
+}
+
+// RecordTaskProcessed test implementation
+func (s *TestShardContext) RecordTaskProcessed() {
+}
+
+// GetTasksPerSecond test implementation
+func (s *TestShardContext) GetTasksPerSecond() float64 {
+	s.RLock()
+	defer s.RUnlock()
+
+	return s.shardInfo.TasksPerSecond
+}
+
 // GetTimerAckLevel test implementation
 func (s *TestShardContext) GetTimerAckLevel() time.Time {
 	s.RLock()
@@ -362,6 +429,32 @@ func (s *TestShardContext) GetDomainNotificationVersion() int64 {
 	return s.shardInfo.DomainNotificationVersion
 }
 
+// GetDomainOpenExecutionCount test implementation
+func (s *TestShardContext) GetDomainOpenExecutionCount(domainID string) int64 {
+	s.RLock()
+	defer s.RUnlock()
+
+	return s.shardInfo.DomainOpenExecutionCounts[domainID]
+}
+
+// StartOpenExecutionCountsScan test implementation
+func (s *TestShardContext) StartOpenExecutionCountsScan() {
+}
+
+// ReconcileDomainOpenExecutionCounts test implementation
+func (s *TestShardContext) ReconcileDomainOpenExecutionCounts(counts map[string]int64) error {
+	s.Lock()
+	defer s.Unlock()
+
+	if s.shardInfo.DomainOpenExecutionCounts == nil {
+		s.shardInfo.DomainOpenExecutionCounts = make(map[string]int64)
+	}
+	for domainID, count := range counts {
+		s.shardInfo.DomainOpenExecutionCounts[domainID] = count
+	}
+	return nil
+}
+
 // UpdateDomainNotificationVersion test implementation
 func (s *TestShardContext) UpdateDomainNotificationVersion(domainNotificationVersion int64) error {
 	s.Lock()
@@ -39,10 +39,16 @@ type (
 		DecisionTimeout int32
 		TaskList        string // This is only needed to communicate tasklist used after AddDecisionTaskScheduledEvent
 		Attempt         int64
+		// HeartbeatCount is the number of heartbeat only decisions kept out of history since the decision was
+		// last written to history, heartbeats are not failures so they do not count as attempts
+		HeartbeatCount int64
 		// They are useful for transient decision: when transient decision finally completes, use these timestamp to create scheduled/started events.
 		// Also used for recording latency metrics
 		ScheduledTimestamp int64
 		StartedTimestamp   int64
+		// Identity and binary checksum of the worker which started the decision, used to attribute stuck decisions
+		StartedIdentity       string
+		StartedBinaryChecksum string
 	}
 
 	mutableState interface {
@@ -64,6 +70,7 @@ type (
 		AddContinueAsNewEvent(int64, int64, *cache.DomainCacheEntry, string, *workflow.ContinueAsNewWorkflowExecutionDecisionAttributes, int32) (*workflow.HistoryEvent, mutableState, error)
 		AddDecisionTaskCompletedEvent(int64, int64, *workflow.RespondDecisionTaskCompletedRequest, int) (*workflow.HistoryEvent, error)
 		AddDecisionTaskFailedEvent(scheduleEventID int64, startedEventID int64, cause workflow.DecisionTaskFailedCause, details []byte, identity, reason, baseRunID, newRunID string, forkEventVersion int64) (*workflow.HistoryEvent, error)
+		AddDecisionTaskHeartbeatEvent(int64, int64, *workflow.RespondDecisionTaskCompletedRequest, int) (*workflow.HistoryEvent, error)
 		AddDecisionTaskScheduleToStartTimeoutEvent(int64) (*workflow.HistoryEvent, error)
 		AddDecisionTaskScheduledEvent() (*decisionInfo, error)
 		AddDecisionTaskStartedEvent(int64, string, *workflow.PollForDecisionTaskRequest) (*workflow.HistoryEvent, *decisionInfo, error)
@@ -90,6 +97,10 @@ type (
 		AddWorkflowExecutionSignaled(signalName string, input []byte, identity string) (*workflow.HistoryEvent, error)
 		AddWorkflowExecutionStartedEvent(workflow.WorkflowExecution, *h.StartWorkflowExecutionRequest) (*workflow.HistoryEvent, error)
 		AddWorkflowExecutionTerminatedEvent(reason string, details []byte, identity string) (*workflow.HistoryEvent, error)
+		AddWorkflowExecutionUpdateCompletedEvent(decisionCompletedEventID int64, updateID string, result []byte) (*workflow.HistoryEvent, error)
+		AddWorkflowExecutionUpdateRequestedEvent(updateID string, request *workflow.UpdateWorkflowExecutionRequest) (*workflow.HistoryEvent, error)
+		AddWorkflowExecutionPauseUpdatedEvent(paused bool) (*workflow.HistoryEvent, error)
+		AddCronSchedulePauseUpdatedEvent(paused bool) (*workflow.HistoryEvent, error)
 		ClearStickyness()
 		CloseUpdateSession() (*mutableStateSessionUpdates, error)
 		CheckResettable() error
@@ -129,6 +140,7 @@ type (
 		GetPendingActivityInfos() map[int64]*persistence.ActivityInfo
 		GetPendingTimerInfos() map[string]*persistence.TimerInfo
 		GetPendingChildExecutionInfos() map[int64]*persistence.ChildExecutionInfo
+		GetPendingUpdateInfos() map[string]*persistence.UpdateInfo
 		GetReplicationState() *persistence.ReplicationState
 		GetRequestCancelInfo(int64) (*persistence.RequestCancelInfo, bool)
 		GetRetryBackoffDuration(errReason string) time.Duration
@@ -138,6 +150,7 @@ type (
 		GetAllSignalsToSend() map[int64]*persistence.SignalInfo
 		GetAllRequestCancels() map[int64]*persistence.RequestCancelInfo
 		GetStartVersion() int64
+		GetUpdateInfo(string) (*persistence.UpdateInfo, bool)
 		GetUserTimer(string) (bool, *persistence.TimerInfo)
 		GetWorkflowType() *workflow.WorkflowType
 		HasBufferedEvents() bool
@@ -145,6 +158,7 @@ type (
 		HasParentExecution() bool
 		HasPendingDecisionTask() bool
 		HasProcessedOrPendingDecisionTask() bool
+		IsActivityRequestCompleted(requestID string) bool
 		IsCancelRequested() (bool, string)
 		IsSignalRequested(requestID string) bool
 		IsStickyTaskListEnabled() bool
@@ -191,6 +205,10 @@ type (
 		ReplicateWorkflowExecutionStartedEvent(string, *string, workflow.WorkflowExecution, string, *workflow.HistoryEvent) error
 		ReplicateWorkflowExecutionTerminatedEvent(int64, *workflow.HistoryEvent) error
 		ReplicateWorkflowExecutionTimedoutEvent(int64, *workflow.HistoryEvent) error
+		ReplicateWorkflowExecutionUpdateCompletedEvent(*workflow.HistoryEvent) error
+		ReplicateWorkflowExecutionUpdateRequestedEvent(*workflow.HistoryEvent) error
+		ReplicateWorkflowExecutionPauseUpdatedEvent(*workflow.HistoryEvent) error
+		ReplicateCronSchedulePauseUpdatedEvent(*workflow.HistoryEvent) error
 		ResetSnapshot(string, int64, int, []persistence.Task, []persistence.Task, []persistence.Task) *persistence.ResetMutableStateRequest
 		SetHistoryBuilder(hBuilder *historyBuilder)
 		SetHistoryTree(treeID string) error
@@ -202,3 +220,14 @@ type (
 		UpdateUserTimer(string, *persistence.TimerInfo)
 	}
 )
+
+// isTransient returns true if the scheduled and started events of the decision are not written to history
+func (d *decisionInfo) isTransient() bool {
+	return d.Attempt > 0 || d.HeartbeatCount > 0
+}
+
+// scheduleAttempt returns the value fencing the task tokens and timeout timers of the decision, it increases
+// with every failure and every heartbeat keeping the same schedule ID
+func (d *decisionInfo) scheduleAttempt() int64 {
+	return d.Attempt + d.HeartbeatCount
+}
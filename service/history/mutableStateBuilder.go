@@ -42,6 +42,9 @@ import (
 const (
 	emptyUUID = "emptyUuid"
 
+	// maxCompletedActivityRequestIDs bounds the number of completed activity request IDs kept for deduplication
+	maxCompletedActivityRequestIDs = 100
+
 	mutableStateInvalidHistoryActionMsg         = "invalid history builder state for action"
 	mutableStateInvalidHistoryActionMsgTemplate = mutableStateInvalidHistoryActionMsg + ": %v"
 )
@@ -77,7 +80,10 @@ type (
 
 		pendingSignalRequestedIDs map[string]struct{} // Set of signaled requestIds
 		updateSignalRequestedIDs  map[string]struct{} // Set of signaled requestIds since last update
-		deleteSignalRequestedID   string              // Deleted signaled requestId
+		deleteSignalRequestedIDs  map[string]struct{} // Set of deleted signaled requestIds since last update
+
+		pendingUpdateInfoIDs map[string]*persistence.UpdateInfo   // Update ID -> UpdateInfo
+		updateUpdateInfos    map[*persistence.UpdateInfo]struct{} // Modified UpdateInfo since last update
 
 		bufferedEvents       []*workflow.HistoryEvent // buffered history events that are already persisted
 		updateBufferedEvents []*workflow.HistoryEvent // buffered history events that needs to be persisted
@@ -133,7 +139,10 @@ func newMutableStateBuilder(
 
 		updateSignalRequestedIDs:  make(map[string]struct{}),
 		pendingSignalRequestedIDs: make(map[string]struct{}),
-		deleteSignalRequestedID:   "",
+		deleteSignalRequestedIDs:  make(map[string]struct{}),
+
+		updateUpdateInfos:    make(map[*persistence.UpdateInfo]struct{}),
+		pendingUpdateInfoIDs: make(map[string]*persistence.UpdateInfo),
 
 		hasBufferedEventsInPersistence: false,
 
@@ -181,6 +190,7 @@ func (e *mutableStateBuilder) CopyToPersistence() *persistence.WorkflowMutableSt
 	state.RequestCancelInfos = e.pendingRequestCancelInfoIDs
 	state.SignalInfos = e.pendingSignalInfoIDs
 	state.SignalRequestedIDs = e.pendingSignalRequestedIDs
+	state.UpdateInfos = e.pendingUpdateInfoIDs
 	state.ExecutionInfo = e.executionInfo
 	state.ReplicationState = e.replicationState
 	state.BufferedEvents = e.bufferedEvents
@@ -195,6 +205,7 @@ func (e *mutableStateBuilder) Load(state *persistence.WorkflowMutableState) {
 	e.pendingRequestCancelInfoIDs = state.RequestCancelInfos
 	e.pendingSignalInfoIDs = state.SignalInfos
 	e.pendingSignalRequestedIDs = state.SignalRequestedIDs
+	e.pendingUpdateInfoIDs = state.UpdateInfos
 	e.executionInfo = state.ExecutionInfo
 
 	e.replicationState = state.ReplicationState
@@ -282,6 +293,11 @@ func (e *mutableStateBuilder) ResetSnapshot(
 		insertSignalRequested = append(insertSignalRequested, id)
 	}
 
+	insertUpdates := make([]*persistence.UpdateInfo, 0, len(e.pendingUpdateInfoIDs))
+	for _, info := range e.pendingUpdateInfoIDs {
+		insertUpdates = append(insertUpdates, info)
+	}
+
 	return &persistence.ResetMutableStateRequest{
 		PrevRunID:            prevRunID,
 		PrevLastWriteVersion: prevLastWriteVersion,
@@ -295,6 +311,7 @@ func (e *mutableStateBuilder) ResetSnapshot(
 			RequestCancelInfos:  insertRequestCancels,
 			SignalInfos:         insertSignals,
 			SignalRequestedIDs:  insertSignalRequested,
+			UpdateInfos:         insertUpdates,
 			ReplicationTasks:    replicationTasks,
 			TransferTasks:       transferTasks,
 			TimerTasks:          timerTasks,
@@ -454,7 +471,8 @@ func (e *mutableStateBuilder) CloseUpdateSession() (*mutableStateSessionUpdates,
 		updateSignalInfos:          convertUpdateSignalInfos(e.updateSignalInfos),
 		deleteSignalInfo:           e.deleteSignalInfo,
 		updateSignalRequestedIDs:   convertSignalRequestedIDs(e.updateSignalRequestedIDs),
-		deleteSignalRequestedID:    e.deleteSignalRequestedID,
+		deleteSignalRequestedIDs:   convertSignalRequestedIDs(e.deleteSignalRequestedIDs),
+		updateUpdateInfos:          convertUpdateUpdateInfos(e.updateUpdateInfos),
 		continueAsNew:              e.continueAsNew,
 		newBufferedEvents:          e.updateBufferedEvents,
 		clearBufferedEvents:        e.clearBufferedEvents,
@@ -474,7 +492,8 @@ func (e *mutableStateBuilder) CloseUpdateSession() (*mutableStateSessionUpdates,
 	e.updateSignalInfos = make(map[*persistence.SignalInfo]struct{})
 	e.deleteSignalInfo = nil
 	e.updateSignalRequestedIDs = make(map[string]struct{})
-	e.deleteSignalRequestedID = ""
+	e.deleteSignalRequestedIDs = make(map[string]struct{})
+	e.updateUpdateInfos = make(map[*persistence.UpdateInfo]struct{})
 	e.continueAsNew = nil
 	e.clearBufferedEvents = false
 	if e.updateBufferedEvents != nil {
@@ -597,6 +616,14 @@ func convertUpdateSignalInfos(inputs map[*persistence.SignalInfo]struct{}) []*pe
 	return outputs
 }
 
+func convertUpdateUpdateInfos(inputs map[*persistence.UpdateInfo]struct{}) []*persistence.UpdateInfo {
+	outputs := []*persistence.UpdateInfo{}
+	for item := range inputs {
+		outputs = append(outputs, item)
+	}
+	return outputs
+}
+
 func convertSignalRequestedIDs(inputs map[string]struct{}) []string {
 	outputs := []string{}
 	for item := range inputs {
@@ -818,7 +845,8 @@ func (e *mutableStateBuilder) shouldBufferEvent(eventType workflow.EventType) bo
 		workflow.EventTypeMarkerRecorded,
 		workflow.EventTypeStartChildWorkflowExecutionInitiated,
 		workflow.EventTypeSignalExternalWorkflowExecutionInitiated,
-		workflow.EventTypeUpsertWorkflowSearchAttributes:
+		workflow.EventTypeUpsertWorkflowSearchAttributes,
+		workflow.EventTypeWorkflowExecutionUpdateCompleted:
 		// do not buffer event if event is directly generated from a corresponding decision
 
 		// sanity check there is no decision on the fly
@@ -931,7 +959,7 @@ func (e *mutableStateBuilder) GetCronBackoffDuration() time.Duration {
 	if len(info.CronSchedule) == 0 {
 		return backoff.NoBackoff
 	}
-	return backoff.GetBackoffForNextSchedule(info.CronSchedule, e.timeSource.Now())
+	return backoff.GetBackoffForNextSchedule(info.CronSchedule, info.CronTimezone, e.timeSource.Now())
 }
 
 // GetSignalInfo get details about a signal request that is currently in progress.
@@ -944,6 +972,16 @@ func (e *mutableStateBuilder) GetAllSignalsToSend() map[int64]*persistence.Signa
 	return e.pendingSignalInfoIDs
 }
 
+// GetUpdateInfo get details about a workflow update that is pending or waiting to be picked up by its caller.
+func (e *mutableStateBuilder) GetUpdateInfo(updateID string) (*persistence.UpdateInfo, bool) {
+	ui, ok := e.pendingUpdateInfoIDs[updateID]
+	return ui, ok
+}
+
+func (e *mutableStateBuilder) GetPendingUpdateInfos() map[string]*persistence.UpdateInfo {
+	return e.pendingUpdateInfoIDs
+}
+
 func (e *mutableStateBuilder) GetAllRequestCancels() map[int64]*persistence.RequestCancelInfo {
 	return e.pendingRequestCancelInfoIDs
 }
@@ -1118,14 +1156,17 @@ func (e *mutableStateBuilder) DeleteUserTimer(timerID string) {
 
 func (e *mutableStateBuilder) getDecisionInfo() *decisionInfo {
 	return &decisionInfo{
-		Version:            e.executionInfo.DecisionVersion,
-		ScheduleID:         e.executionInfo.DecisionScheduleID,
-		StartedID:          e.executionInfo.DecisionStartedID,
-		RequestID:          e.executionInfo.DecisionRequestID,
-		DecisionTimeout:    e.executionInfo.DecisionTimeout,
-		Attempt:            e.executionInfo.DecisionAttempt,
-		StartedTimestamp:   e.executionInfo.DecisionStartedTimestamp,
-		ScheduledTimestamp: e.executionInfo.DecisionScheduledTimestamp,
+		Version:               e.executionInfo.DecisionVersion,
+		ScheduleID:            e.executionInfo.DecisionScheduleID,
+		StartedID:             e.executionInfo.DecisionStartedID,
+		RequestID:             e.executionInfo.DecisionRequestID,
+		DecisionTimeout:       e.executionInfo.DecisionTimeout,
+		Attempt:               e.executionInfo.DecisionAttempt,
+		HeartbeatCount:        e.executionInfo.DecisionHeartbeatCount,
+		StartedTimestamp:      e.executionInfo.DecisionStartedTimestamp,
+		ScheduledTimestamp:    e.executionInfo.DecisionScheduledTimestamp,
+		StartedIdentity:       e.executionInfo.DecisionStartedIdentity,
+		StartedBinaryChecksum: e.executionInfo.DecisionStartedBinaryChecksum,
 	}
 }
 
@@ -1194,11 +1235,14 @@ func (e *mutableStateBuilder) UpdateDecision(di *decisionInfo) {
 	e.executionInfo.DecisionRequestID = di.RequestID
 	e.executionInfo.DecisionTimeout = di.DecisionTimeout
 	e.executionInfo.DecisionAttempt = di.Attempt
+	e.executionInfo.DecisionHeartbeatCount = di.HeartbeatCount
 	e.executionInfo.DecisionStartedTimestamp = di.StartedTimestamp
 	e.executionInfo.DecisionScheduledTimestamp = di.ScheduledTimestamp
+	e.executionInfo.DecisionStartedIdentity = di.StartedIdentity
+	e.executionInfo.DecisionStartedBinaryChecksum = di.StartedBinaryChecksum
 
-	e.logger.Debug(fmt.Sprintf("Decision Updated: {Schedule: %v, Started: %v, ID: %v, Timeout: %v, Attempt: %v, Timestamp: %v}",
-		di.ScheduleID, di.StartedID, di.RequestID, di.DecisionTimeout, di.Attempt, di.StartedTimestamp))
+	e.logger.Debug(fmt.Sprintf("Decision Updated: {Schedule: %v, Started: %v, ID: %v, Timeout: %v, Attempt: %v, Heartbeats: %v, Timestamp: %v}",
+		di.ScheduleID, di.StartedID, di.RequestID, di.DecisionTimeout, di.Attempt, di.HeartbeatCount, di.StartedTimestamp))
 }
 
 // DeleteDecision deletes a decision task.
@@ -1229,7 +1273,9 @@ func (e *mutableStateBuilder) FailDecision(incrementAttempt bool) {
 		StartedTimestamp: 0,
 	}
 	if incrementAttempt {
+		// the heartbeat count is kept so the schedule attempt fencing the tokens of the failed decision keeps increasing
 		failDecisionInfo.Attempt = e.executionInfo.DecisionAttempt + 1
+		failDecisionInfo.HeartbeatCount = e.executionInfo.DecisionHeartbeatCount
 		failDecisionInfo.ScheduledTimestamp = e.timeSource.Now().UnixNano()
 	}
 	e.UpdateDecision(failDecisionInfo)
@@ -1263,6 +1309,23 @@ func (e *mutableStateBuilder) IsWorkflowExecutionRunning() bool {
 	return e.executionInfo.State != persistence.WorkflowStateCompleted
 }
 
+func (e *mutableStateBuilder) IsActivityRequestCompleted(requestID string) bool {
+	for _, id := range e.executionInfo.CompletedActivityRequestIDs {
+		if id == requestID {
+			return true
+		}
+	}
+	return false
+}
+
+func (e *mutableStateBuilder) addCompletedActivityRequestID(requestID string) {
+	requestIDs := append(e.executionInfo.CompletedActivityRequestIDs, requestID)
+	if len(requestIDs) > maxCompletedActivityRequestIDs {
+		requestIDs = requestIDs[len(requestIDs)-maxCompletedActivityRequestIDs:]
+	}
+	e.executionInfo.CompletedActivityRequestIDs = requestIDs
+}
+
 func (e *mutableStateBuilder) IsCancelRequested() (bool, string) {
 	if e.executionInfo.CancelRequested {
 		return e.executionInfo.CancelRequested, e.executionInfo.CancelRequestID
@@ -1287,11 +1350,157 @@ func (e *mutableStateBuilder) AddSignalRequested(requestID string) {
 	}
 	e.pendingSignalRequestedIDs[requestID] = struct{}{} // add requestID to set
 	e.updateSignalRequestedIDs[requestID] = struct{}{}
+	delete(e.deleteSignalRequestedIDs, requestID)
 }
 
 func (e *mutableStateBuilder) DeleteSignalRequested(requestID string) {
+	if e.deleteSignalRequestedIDs == nil {
+		e.deleteSignalRequestedIDs = make(map[string]struct{})
+	}
 	delete(e.pendingSignalRequestedIDs, requestID)
-	e.deleteSignalRequestedID = requestID
+	// the additions and deletions of a transaction are coalesced, an ID added and deleted
+	// within the same transaction is never written
+	delete(e.updateSignalRequestedIDs, requestID)
+	e.deleteSignalRequestedIDs[requestID] = struct{}{}
+}
+
+func (e *mutableStateBuilder) AddWorkflowExecutionUpdateRequestedEvent(
+	updateID string,
+	request *workflow.UpdateWorkflowExecutionRequest,
+) (*workflow.HistoryEvent, error) {
+
+	opTag := tag.WorkflowActionWorkflowUpdateRequested
+	if err := e.checkMutability(opTag); err != nil {
+		return nil, err
+	}
+
+	event := e.hBuilder.AddWorkflowExecutionUpdateRequestedEvent(updateID, request)
+	if err := e.ReplicateWorkflowExecutionUpdateRequestedEvent(event); err != nil {
+		return nil, err
+	}
+	return event, nil
+}
+
+func (e *mutableStateBuilder) ReplicateWorkflowExecutionUpdateRequestedEvent(
+	event *workflow.HistoryEvent,
+) error {
+
+	attributes := event.WorkflowExecutionUpdateRequestedEventAttributes
+	if e.pendingUpdateInfoIDs == nil {
+		e.pendingUpdateInfoIDs = make(map[string]*persistence.UpdateInfo)
+	}
+	if e.updateUpdateInfos == nil {
+		e.updateUpdateInfos = make(map[*persistence.UpdateInfo]struct{})
+	}
+	ui := &persistence.UpdateInfo{
+		Version:    event.GetVersion(),
+		UpdateID:   attributes.GetUpdateId(),
+		UpdateName: attributes.GetUpdateName(),
+		Input:      attributes.Input,
+	}
+	e.pendingUpdateInfoIDs[ui.UpdateID] = ui
+	e.updateUpdateInfos[ui] = struct{}{}
+	return nil
+}
+
+// AddWorkflowExecutionUpdateCompletedEvent records the result reported by the worker, the update stays
+// in mutable state with its result until the run closes so that a retried request gets the same result
+func (e *mutableStateBuilder) AddWorkflowExecutionUpdateCompletedEvent(
+	decisionCompletedEventID int64,
+	updateID string,
+	result []byte,
+) (*workflow.HistoryEvent, error) {
+
+	opTag := tag.WorkflowActionWorkflowUpdateCompleted
+	if err := e.checkMutability(opTag); err != nil {
+		return nil, err
+	}
+
+	ui, ok := e.pendingUpdateInfoIDs[updateID]
+	if !ok || ui.Completed {
+		e.logger.Warn(mutableStateInvalidHistoryActionMsg, opTag,
+			tag.WorkflowEventID(e.GetNextEventID()),
+			tag.ErrorTypeInvalidHistoryAction,
+			tag.Bool(ok))
+		return nil, e.createCallerError(opTag)
+	}
+
+	event := e.hBuilder.AddWorkflowExecutionUpdateCompletedEvent(decisionCompletedEventID, updateID, result)
+	if err := e.ReplicateWorkflowExecutionUpdateCompletedEvent(event); err != nil {
+		return nil, err
+	}
+	return event, nil
+}
+
+func (e *mutableStateBuilder) ReplicateWorkflowExecutionUpdateCompletedEvent(
+	event *workflow.HistoryEvent,
+) error {
+
+	attributes := event.WorkflowExecutionUpdateCompletedEventAttributes
+	ui, ok := e.pendingUpdateInfoIDs[attributes.GetUpdateId()]
+	if !ok {
+		return errors.NewInternalFailureError(fmt.Sprintf("unable to find update: %v", attributes.GetUpdateId()))
+	}
+	ui.Version = event.GetVersion()
+	ui.Result = attributes.Result
+	ui.Completed = true
+	if e.updateUpdateInfos == nil {
+		e.updateUpdateInfos = make(map[*persistence.UpdateInfo]struct{})
+	}
+	e.updateUpdateInfos[ui] = struct{}{}
+	return nil
+}
+
+// AddWorkflowExecutionPauseUpdatedEvent records the pause or resume of the workflow execution, decisions
+// of a paused workflow execution are not dispatched to workers
+func (e *mutableStateBuilder) AddWorkflowExecutionPauseUpdatedEvent(
+	paused bool,
+) (*workflow.HistoryEvent, error) {
+
+	opTag := tag.WorkflowActionWorkflowPauseUpdated
+	if err := e.checkMutability(opTag); err != nil {
+		return nil, err
+	}
+
+	event := e.hBuilder.AddWorkflowExecutionPauseUpdatedEvent(paused)
+	if err := e.ReplicateWorkflowExecutionPauseUpdatedEvent(event); err != nil {
+		return nil, err
+	}
+	return event, nil
+}
+
+func (e *mutableStateBuilder) ReplicateWorkflowExecutionPauseUpdatedEvent(
+	event *workflow.HistoryEvent,
+) error {
+
+	e.executionInfo.Paused = event.WorkflowExecutionPauseUpdatedEventAttributes.GetPaused()
+	return nil
+}
+
+// AddCronSchedulePauseUpdatedEvent records the pause or resume of the cron schedule, the pause is carried over
+// to the following runs of the schedule
+func (e *mutableStateBuilder) AddCronSchedulePauseUpdatedEvent(
+	paused bool,
+) (*workflow.HistoryEvent, error) {
+
+	opTag := tag.WorkflowActionCronSchedulePauseUpdated
+	if err := e.checkMutability(opTag); err != nil {
+		return nil, err
+	}
+
+	event := e.hBuilder.AddCronSchedulePauseUpdatedEvent(paused)
+	if err := e.ReplicateCronSchedulePauseUpdatedEvent(event); err != nil {
+		return nil, err
+	}
+	return event, nil
+}
+
+func (e *mutableStateBuilder) ReplicateCronSchedulePauseUpdatedEvent(
+	event *workflow.HistoryEvent,
+) error {
+
+	e.executionInfo.CronPaused = event.CronSchedulePauseUpdatedEventAttributes.GetPaused()
+	return nil
 }
 
 func (e *mutableStateBuilder) addWorkflowExecutionStartedEventForContinueAsNew(
@@ -1335,6 +1544,7 @@ func (e *mutableStateBuilder) addWorkflowExecutionStartedEventForContinueAsNew(
 		Header:                              attributes.Header,
 		RetryPolicy:                         attributes.RetryPolicy,
 		CronSchedule:                        attributes.CronSchedule,
+		CronTimezone:                        attributes.CronTimezone,
 	}
 
 	req := &h.StartWorkflowExecutionRequest{
@@ -1431,6 +1641,11 @@ func (e *mutableStateBuilder) ReplicateWorkflowExecutionStartedEvent(
 	e.executionInfo.DomainID = domainID
 	e.executionInfo.WorkflowID = execution.GetWorkflowId()
 	e.executionInfo.RunID = execution.GetRunId()
+	e.executionInfo.FirstExecutionRunID = event.GetFirstExecutionRunId()
+	if e.executionInfo.FirstExecutionRunID == "" {
+		// started events written before the first run ID was recorded start a new chain
+		e.executionInfo.FirstExecutionRunID = execution.GetRunId()
+	}
 	e.executionInfo.TaskList = event.TaskList.GetName()
 	e.executionInfo.WorkflowTypeName = event.WorkflowType.GetName()
 	e.executionInfo.WorkflowTimeout = event.GetExecutionStartToCloseTimeoutSeconds()
@@ -1448,6 +1663,8 @@ func (e *mutableStateBuilder) ReplicateWorkflowExecutionStartedEvent(
 	e.executionInfo.DecisionTimeout = 0
 
 	e.executionInfo.CronSchedule = event.GetCronSchedule()
+	e.executionInfo.CronTimezone = event.GetCronTimezone()
+	e.executionInfo.CronPaused = event.GetCronPaused()
 
 	if parentDomainID != nil {
 		e.executionInfo.ParentDomainID = *parentDomainID
@@ -1516,6 +1733,7 @@ func (e *mutableStateBuilder) AddDecisionTaskScheduledEvent() (*decisionInfo, er
 		// if creating a decision and in the mean time events are flushed from buffered events
 		// than this decision cannot be a transient decision
 		e.executionInfo.DecisionAttempt = 0
+		e.executionInfo.DecisionHeartbeatCount = 0
 		if err := e.FlushBufferedEvents(); err != nil {
 			return nil, err
 		}
@@ -1525,14 +1743,15 @@ func (e *mutableStateBuilder) AddDecisionTaskScheduledEvent() (*decisionInfo, er
 	scheduleID := e.GetNextEventID() // we will generate the schedule event later for repeatedly failing decisions
 	// Avoid creating new history events when decisions are continuously failing
 	scheduleTime := e.timeSource.Now().UnixNano()
-	if e.executionInfo.DecisionAttempt == 0 {
+	heartbeatCount := e.executionInfo.DecisionHeartbeatCount
+	if e.executionInfo.DecisionAttempt == 0 && heartbeatCount == 0 {
 		newDecisionEvent = e.hBuilder.AddDecisionTaskScheduledEvent(taskList, startToCloseTimeoutSeconds,
 			e.executionInfo.DecisionAttempt)
 		scheduleID = newDecisionEvent.GetEventId()
 		scheduleTime = newDecisionEvent.GetTimestamp()
 	}
 
-	return e.ReplicateDecisionTaskScheduledEvent(
+	di, err := e.ReplicateDecisionTaskScheduledEvent(
 		e.GetCurrentVersion(),
 		scheduleID,
 		taskList,
@@ -1540,10 +1759,17 @@ func (e *mutableStateBuilder) AddDecisionTaskScheduledEvent() (*decisionInfo, er
 		e.executionInfo.DecisionAttempt,
 		scheduleTime,
 	)
+	if err != nil {
+		return nil, err
+	}
+	// heartbeats are not part of history, the count carries over to the decision scheduled after them
+	di.HeartbeatCount = heartbeatCount
+	e.UpdateDecision(di)
+	return di, nil
 }
 
 func (e *mutableStateBuilder) ReplicateTransientDecisionTaskScheduled() (*decisionInfo, error) {
-	if e.HasPendingDecisionTask() || e.GetExecutionInfo().DecisionAttempt == 0 {
+	if e.HasPendingDecisionTask() || !e.getDecisionInfo().isTransient() {
 		return nil, nil
 	}
 
@@ -1565,6 +1791,7 @@ func (e *mutableStateBuilder) ReplicateTransientDecisionTaskScheduled() (*decisi
 		DecisionTimeout:    e.GetExecutionInfo().DecisionTimeoutValue,
 		TaskList:           e.GetExecutionInfo().TaskList,
 		Attempt:            e.GetExecutionInfo().DecisionAttempt,
+		HeartbeatCount:     e.GetExecutionInfo().DecisionHeartbeatCount,
 		ScheduledTimestamp: e.timeSource.Now().UnixNano(),
 		StartedTimestamp:   0,
 	}
@@ -1625,15 +1852,16 @@ func (e *mutableStateBuilder) AddDecisionTaskStartedEvent(
 	tasklist := request.TaskList.GetName()
 	timestamp := e.timeSource.Now().UnixNano()
 	// First check to see if new events came since transient decision was scheduled
-	if di.Attempt > 0 && di.ScheduleID != e.GetNextEventID() {
+	if di.isTransient() && di.ScheduleID != e.GetNextEventID() {
 		// Also create a new DecisionTaskScheduledEvent since new events came in when it was scheduled
 		scheduleEvent := e.hBuilder.AddDecisionTaskScheduledEvent(tasklist, di.DecisionTimeout, 0)
 		scheduleID = scheduleEvent.GetEventId()
 		di.Attempt = 0
+		di.HeartbeatCount = 0
 	}
 
-	// Avoid creating new history events when decisions are continuously failing
-	if di.Attempt == 0 {
+	// Avoid creating new history events when decisions are continuously failing or heartbeating
+	if !di.isTransient() {
 		// Now create DecisionTaskStartedEvent
 		event = e.hBuilder.AddDecisionTaskStartedEvent(scheduleID, requestID, request.GetIdentity())
 		startedID = event.GetEventId()
@@ -1641,7 +1869,14 @@ func (e *mutableStateBuilder) AddDecisionTaskStartedEvent(
 	}
 
 	di, err := e.ReplicateDecisionTaskStartedEvent(di, e.GetCurrentVersion(), scheduleID, startedID, requestID, timestamp)
-	return event, di, err
+	if err != nil {
+		return nil, nil, err
+	}
+
+	di.StartedIdentity = request.GetIdentity()
+	di.StartedBinaryChecksum = request.GetBinaryChecksum()
+	e.UpdateDecision(di)
+	return event, di, nil
 }
 
 func (e *mutableStateBuilder) ReplicateDecisionTaskStartedEvent(
@@ -1669,6 +1904,7 @@ func (e *mutableStateBuilder) ReplicateDecisionTaskStartedEvent(
 		// certain "magic" needs to be done, i.e. setting attempt to 0 so
 		// if first batch is replicated, but not the second one, decision can be correctly timed out
 		di.Attempt = 0
+		di.HeartbeatCount = 0
 	}
 
 	e.executionInfo.State = persistence.WorkflowStateRunning
@@ -1680,6 +1916,7 @@ func (e *mutableStateBuilder) ReplicateDecisionTaskStartedEvent(
 		RequestID:          requestID,
 		DecisionTimeout:    di.DecisionTimeout,
 		Attempt:            di.Attempt,
+		HeartbeatCount:     di.HeartbeatCount,
 		StartedTimestamp:   timestamp,
 		ScheduledTimestamp: di.ScheduledTimestamp,
 	}
@@ -1815,8 +2052,9 @@ func (e *mutableStateBuilder) AddDecisionTaskCompletedEvent(
 	}
 
 	e.beforeAddDecisionTaskCompletedEvent()
-	if di.Attempt > 0 {
+	if di.isTransient() {
 		// Create corresponding DecisionTaskSchedule and DecisionTaskStarted events for decisions we have been retrying
+		// or which followed heartbeats
 		scheduledEvent := e.hBuilder.AddTransientDecisionTaskScheduledEvent(e.executionInfo.TaskList, di.DecisionTimeout,
 			di.Attempt, di.ScheduledTimestamp)
 		startedEvent := e.hBuilder.AddTransientDecisionTaskStartedEvent(scheduledEvent.GetEventId(), di.RequestID,
@@ -1830,6 +2068,59 @@ func (e *mutableStateBuilder) AddDecisionTaskCompletedEvent(
 	return event, nil
 }
 
+// AddDecisionTaskHeartbeatEvent completes a decision which only heartbeats to keep the decision task going, e.g.
+// while local activities are running. The decision following it is scheduled as a transient one, so it is kept in
+// mutable state only and completing it with yet another heartbeat does not write any event to history.
+func (e *mutableStateBuilder) AddDecisionTaskHeartbeatEvent(
+	scheduleEventID int64,
+	startedEventID int64,
+	request *workflow.RespondDecisionTaskCompletedRequest,
+	maxResetPoints int,
+) (*workflow.HistoryEvent, error) {
+
+	opTag := tag.WorkflowActionDecisionTaskCompleted
+	if err := e.checkMutability(opTag); err != nil {
+		return nil, err
+	}
+
+	hasPendingDecision := e.HasPendingDecisionTask()
+	di, ok := e.GetPendingDecision(scheduleEventID)
+	if !hasPendingDecision || !ok || di.StartedID != startedEventID {
+		e.logger.Warn(mutableStateInvalidHistoryActionMsg, opTag,
+			tag.WorkflowEventID(e.GetNextEventID()),
+			tag.ErrorTypeInvalidHistoryAction,
+			tag.Bool(hasPendingDecision),
+			tag.WorkflowScheduleID(scheduleEventID),
+			tag.WorkflowStartedID(startedEventID))
+
+		return nil, e.createInternalServerError(opTag)
+	}
+
+	if !di.isTransient() {
+		// decision is already written to history, it has to be completed there
+		event, err := e.AddDecisionTaskCompletedEvent(scheduleEventID, startedEventID, request, maxResetPoints)
+		if err != nil {
+			return nil, err
+		}
+		e.executionInfo.DecisionHeartbeatCount = 1
+		return event, nil
+	}
+
+	// heartbeats are not failures, the attempt is left as is and only the heartbeat count goes up
+	e.UpdateDecision(&decisionInfo{
+		Version:            common.EmptyVersion,
+		ScheduleID:         common.EmptyEventID,
+		StartedID:          common.EmptyEventID,
+		RequestID:          emptyUUID,
+		DecisionTimeout:    0,
+		Attempt:            di.Attempt,
+		HeartbeatCount:     di.HeartbeatCount + 1,
+		StartedTimestamp:   0,
+		ScheduledTimestamp: e.timeSource.Now().UnixNano(),
+	})
+	return nil, nil
+}
+
 func (e *mutableStateBuilder) ReplicateDecisionTaskCompletedEvent(event *workflow.HistoryEvent) error {
 	e.beforeAddDecisionTaskCompletedEvent()
 	e.afterAddDecisionTaskCompletedEvent(event, math.MaxInt32)
@@ -1860,7 +2151,7 @@ func (e *mutableStateBuilder) AddDecisionTaskTimedOutEvent(
 
 	var event *workflow.HistoryEvent
 	// Avoid creating new history events when decisions are continuously timing out
-	if dt.Attempt == 0 {
+	if !dt.isTransient() {
 		event = e.hBuilder.AddDecisionTaskTimedOutEvent(scheduleEventID, startedEventID, workflow.TimeoutTypeStartToClose)
 	}
 
@@ -1901,7 +2192,11 @@ func (e *mutableStateBuilder) AddDecisionTaskScheduleToStartTimeoutEvent(
 	// Clear stickiness whenever decision fails
 	e.ClearStickyness()
 
-	event := e.hBuilder.AddDecisionTaskTimedOutEvent(scheduleEventID, 0, workflow.TimeoutTypeScheduleToStart)
+	var event *workflow.HistoryEvent
+	// a decision following heartbeats is not written to history, neither is its timeout
+	if !e.getDecisionInfo().isTransient() {
+		event = e.hBuilder.AddDecisionTaskTimedOutEvent(scheduleEventID, 0, workflow.TimeoutTypeScheduleToStart)
+	}
 
 	if err := e.ReplicateDecisionTaskTimedOutEvent(workflow.TimeoutTypeScheduleToStart); err != nil {
 		return nil, err
@@ -1951,7 +2246,7 @@ func (e *mutableStateBuilder) AddDecisionTaskFailedEvent(
 
 	var event *workflow.HistoryEvent
 	// Only emit DecisionTaskFailedEvent for the very first time
-	if dt.Attempt == 0 || cause == workflow.DecisionTaskFailedCauseResetWorkflow {
+	if !dt.isTransient() || cause == workflow.DecisionTaskFailedCauseResetWorkflow {
 		event = e.hBuilder.AddDecisionTaskFailedEvent(attr)
 	}
 
@@ -1962,6 +2257,11 @@ func (e *mutableStateBuilder) AddDecisionTaskFailedEvent(
 	// always clear decision attempt for reset
 	if cause == workflow.DecisionTaskFailedCauseResetWorkflow {
 		e.executionInfo.DecisionAttempt = 0
+		e.executionInfo.DecisionHeartbeatCount = 0
+		// the reset decision failed event takes the ID of the base run event the reset was forked from
+		e.executionInfo.ResetBaseRunID = baseRunID
+		e.executionInfo.ResetBaseEventID = event.GetEventId()
+		e.executionInfo.ResetReason = reason
 	}
 	return event, nil
 }
@@ -2148,6 +2448,9 @@ func (e *mutableStateBuilder) ReplicateActivityTaskCompletedEvent(event *workflo
 	attributes := event.ActivityTaskCompletedEventAttributes
 	scheduleID := attributes.GetScheduledEventId()
 
+	if ai, ok := e.GetActivityInfo(scheduleID); ok && ai.RequestID != "" {
+		e.addCompletedActivityRequestID(ai.RequestID)
+	}
 	return e.DeleteActivity(scheduleID)
 }
 
@@ -3167,6 +3470,8 @@ func (e *mutableStateBuilder) ReplicateWorkflowExecutionContinuedAsNewEvent(
 			LastProcessedEvent:   common.EmptyEventID,
 			HasRetryPolicy:       startedAttributes.RetryPolicy != nil,
 			CronSchedule:         startedAttributes.GetCronSchedule(),
+			CronPaused:           e.executionInfo.CronPaused && len(startedAttributes.GetCronSchedule()) != 0,
+			CronTimezone:         startedAttributes.GetCronTimezone(),
 			EventStoreVersion:    newStateBuilder.GetEventStoreVersion(),
 			BranchToken:          newStateBuilder.GetCurrentBranch(),
 			AutoResetPoints: rolloverAutoResetPointsWithExpiringTime(
@@ -386,6 +386,124 @@ func (s *mutableStateSuite) TestMergeMapOfByteArray() {
 	s.Equal(2, len(resultMap))
 }
 
+func (s *mutableStateSuite) TestDecisionTaskHeartbeat() {
+	execution := workflow.WorkflowExecution{
+		WorkflowId: common.StringPtr("test-decision-heartbeat"),
+		RunId:      common.StringPtr(validRunID),
+	}
+	tl := "testTaskList"
+	identity := "testIdentity"
+	request := &workflow.RespondDecisionTaskCompletedRequest{
+		Identity: common.StringPtr(identity),
+	}
+	addWorkflowExecutionStartedEvent(s.msBuilder, execution, "wType", tl, []byte("input"), 100, 10, identity)
+
+	// first heartbeat completes the decision already in history and makes the next one transient
+	di := addDecisionTaskScheduledEvent(s.msBuilder)
+	addDecisionTaskStartedEvent(s.msBuilder, di.ScheduleID, tl, identity)
+	event, err := s.msBuilder.AddDecisionTaskHeartbeatEvent(di.ScheduleID, di.ScheduleID+1, request, defaultHistoryMaxAutoResetPoints)
+	s.NoError(err)
+	s.NotNil(event)
+	s.Equal(workflow.EventTypeDecisionTaskCompleted, event.GetEventType())
+	s.Equal(int64(0), s.msBuilder.GetExecutionInfo().DecisionAttempt)
+	s.Equal(int64(1), s.msBuilder.GetExecutionInfo().DecisionHeartbeatCount)
+	s.False(s.msBuilder.HasPendingDecisionTask())
+
+	// subsequent heartbeats leave no trace in history and are not counted as attempts
+	nextEventID := s.msBuilder.GetNextEventID()
+	di = addDecisionTaskScheduledEvent(s.msBuilder)
+	s.Equal(int64(0), di.Attempt)
+	s.True(di.isTransient())
+	s.Equal(int64(1), di.scheduleAttempt())
+	started := addDecisionTaskStartedEvent(s.msBuilder, di.ScheduleID, tl, identity)
+	s.Nil(started)
+	di, ok := s.msBuilder.GetPendingDecision(di.ScheduleID)
+	s.True(ok)
+	event, err = s.msBuilder.AddDecisionTaskHeartbeatEvent(di.ScheduleID, di.StartedID, request, defaultHistoryMaxAutoResetPoints)
+	s.NoError(err)
+	s.Nil(event)
+	s.Equal(int64(0), s.msBuilder.GetExecutionInfo().DecisionAttempt)
+	s.Equal(int64(2), s.msBuilder.GetExecutionInfo().DecisionHeartbeatCount)
+	s.False(s.msBuilder.HasPendingDecisionTask())
+	s.Equal(nextEventID, s.msBuilder.GetNextEventID())
+
+	// a failure after heartbeats is the first attempt and the schedule attempt keeps increasing
+	di = addDecisionTaskScheduledEvent(s.msBuilder)
+	s.Equal(int64(2), di.scheduleAttempt())
+	addDecisionTaskStartedEvent(s.msBuilder, di.ScheduleID, tl, identity)
+	di, ok = s.msBuilder.GetPendingDecision(di.ScheduleID)
+	s.True(ok)
+	event, err = s.msBuilder.AddDecisionTaskTimedOutEvent(di.ScheduleID, di.StartedID)
+	s.NoError(err)
+	s.Nil(event)
+	s.Equal(int64(1), s.msBuilder.GetExecutionInfo().DecisionAttempt)
+	s.Equal(nextEventID, s.msBuilder.GetNextEventID())
+
+	// completing the decision writes it to history with the attempt counting failures only
+	di = addDecisionTaskScheduledEvent(s.msBuilder)
+	s.Equal(int64(3), di.scheduleAttempt())
+	addDecisionTaskStartedEvent(s.msBuilder, di.ScheduleID, tl, identity)
+	di, ok = s.msBuilder.GetPendingDecision(di.ScheduleID)
+	s.True(ok)
+	event, err = s.msBuilder.AddDecisionTaskCompletedEvent(di.ScheduleID, di.StartedID, request, defaultHistoryMaxAutoResetPoints)
+	s.NoError(err)
+	s.NotNil(event)
+	scheduledEvent := s.msBuilder.hBuilder.history[len(s.msBuilder.hBuilder.history)-3]
+	s.Equal(workflow.EventTypeDecisionTaskScheduled, scheduledEvent.GetEventType())
+	s.Equal(int64(1), scheduledEvent.DecisionTaskScheduledEventAttributes.GetAttempt())
+	s.Equal(int64(0), s.msBuilder.GetExecutionInfo().DecisionAttempt)
+	s.Equal(int64(0), s.msBuilder.GetExecutionInfo().DecisionHeartbeatCount)
+}
+
+func (s *mutableStateSuite) TestWorkflowUpdates() {
+	updateID := "test-update-id"
+	request := &workflow.UpdateWorkflowExecutionRequest{
+		UpdateName: common.StringPtr("test-update"),
+		Input:      []byte("input"),
+	}
+
+	event, err := s.msBuilder.AddWorkflowExecutionUpdateRequestedEvent(updateID, request)
+	s.NoError(err)
+	s.Equal(workflow.EventTypeWorkflowExecutionUpdateRequested, event.GetEventType())
+	ui, ok := s.msBuilder.GetUpdateInfo(updateID)
+	s.True(ok)
+	s.Equal("test-update", ui.UpdateName)
+	s.False(ui.Completed)
+	s.Equal(1, len(getPendingWorkflowUpdates(s.msBuilder)))
+
+	_, err = s.msBuilder.AddWorkflowExecutionUpdateCompletedEvent(int64(4), "unknown-update-id", []byte("result"))
+	s.Error(err)
+	event, err = s.msBuilder.AddWorkflowExecutionUpdateCompletedEvent(int64(4), updateID, []byte("result"))
+	s.NoError(err)
+	s.Equal(workflow.EventTypeWorkflowExecutionUpdateCompleted, event.GetEventType())
+	_, err = s.msBuilder.AddWorkflowExecutionUpdateCompletedEvent(int64(4), updateID, []byte("another result"))
+	s.Error(err)
+
+	// the completed update is kept, so a retried request picks up the same result
+	ui, ok = s.msBuilder.GetUpdateInfo(updateID)
+	s.True(ok)
+	s.True(ui.Completed)
+	s.Equal([]byte("result"), ui.Result)
+	s.Equal(0, len(getPendingWorkflowUpdates(s.msBuilder)))
+}
+
+func (s *mutableStateSuite) TestSignalRequestedCoalesced() {
+	s.msBuilder.AddSignalRequested("request-1")
+	s.msBuilder.AddSignalRequested("request-2")
+	s.msBuilder.AddSignalRequested("request-3")
+	s.msBuilder.DeleteSignalRequested("request-2")
+	s.msBuilder.DeleteSignalRequested("request-4")
+	s.msBuilder.DeleteSignalRequested("request-5")
+
+	s.True(s.msBuilder.IsSignalRequested("request-1"))
+	s.False(s.msBuilder.IsSignalRequested("request-2"))
+	s.Equal(map[string]struct{}{"request-1": {}, "request-3": {}}, s.msBuilder.updateSignalRequestedIDs)
+	s.Equal(map[string]struct{}{"request-2": {}, "request-4": {}, "request-5": {}}, s.msBuilder.deleteSignalRequestedIDs)
+
+	s.msBuilder.AddSignalRequested("request-4")
+	s.Equal(map[string]struct{}{"request-2": {}, "request-5": {}}, s.msBuilder.deleteSignalRequestedIDs)
+}
+
 func (s *mutableStateSuite) prepareTransientDecisionCompletionFirstBatchReplicated(version int64, runID string) (*shared.HistoryEvent, *shared.HistoryEvent) {
 	domainID := validDomainID
 	execution := shared.WorkflowExecution{
@@ -41,7 +41,8 @@ type (
 		updateSignalInfos          []*persistence.SignalInfo
 		deleteSignalInfo           *int64
 		updateSignalRequestedIDs   []string
-		deleteSignalRequestedID    string
+		deleteSignalRequestedIDs   []string
+		updateUpdateInfos          []*persistence.UpdateInfo
 		continueAsNew              *persistence.WorkflowSnapshot
 		newBufferedEvents          []*workflow.HistoryEvent
 		clearBufferedEvents        bool
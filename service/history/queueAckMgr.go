@@ -46,6 +46,8 @@ type (
 		logger        log.Logger
 		metricsClient metrics.Client
 		finishedChan  chan struct{}
+		// stuckQueueDetector is optional, failover ack managers do not raise alarms
+		stuckQueueDetector *stuckQueueDetector
 
 		sync.RWMutex
 		outstandingTasks map[int64]bool
@@ -190,6 +192,8 @@ func (a *queueAckMgrImpl) updateQueueAckLevel() {
 		a.metricsClient.RecordTimer(metrics.ShardInfoScope, metrics.ShardInfoTransferStandbyPendingTasksTimer, time.Duration(pendingTasks))
 	}
 
+	hasBlockingTask := false
+	blockingTaskID := int64(0)
 MoveAckLevelLoop:
 	for _, current := range taskIDs {
 		acked := a.outstandingTasks[current]
@@ -198,6 +202,8 @@ MoveAckLevelLoop:
 			delete(a.outstandingTasks, current)
 			a.logger.Debug(fmt.Sprintf("Moving timer ack level to %v.", ackLevel))
 		} else {
+			hasBlockingTask = true
+			blockingTaskID = current
 			break MoveAckLevelLoop
 		}
 	}
@@ -214,6 +220,9 @@ MoveAckLevelLoop:
 	}
 
 	a.Unlock()
+	if a.stuckQueueDetector != nil {
+		a.stuckQueueDetector.check(ackLevel, hasBlockingTask, blockingTaskID, time.Time{})
+	}
 	if err := a.processor.updateAckLevel(ackLevel); err != nil {
 		a.metricsClient.IncCounter(a.options.MetricScope, metrics.AckLevelUpdateFailedCounter)
 		a.logger.Error("Error updating ack level for shard", tag.Error(err), tag.OperationFailed)
@@ -0,0 +1,83 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package history
+
+import (
+	"sync"
+	"time"
+
+	"github.com/uber/cadence/common/service/dynamicconfig"
+)
+
+type (
+	// queueBatchSizer adapts the number of tasks a queue processor reads per persistence call.
+	// The batch grows while reads keep returning full pages, i.e. the queue is behind, and is
+	// halved whenever a read takes longer than the latency target
+	queueBatchSizer struct {
+		sync.Mutex
+		minBatchSize  dynamicconfig.IntPropertyFn
+		maxBatchSize  dynamicconfig.IntPropertyFn
+		latencyTarget dynamicconfig.DurationPropertyFn
+		batchSize     int
+	}
+)
+
+func newQueueBatchSizer(initialBatchSize int, minBatchSize dynamicconfig.IntPropertyFn,
+	maxBatchSize dynamicconfig.IntPropertyFn, latencyTarget dynamicconfig.DurationPropertyFn) *queueBatchSizer {
+	return &queueBatchSizer{
+		minBatchSize:  minBatchSize,
+		maxBatchSize:  maxBatchSize,
+		latencyTarget: latencyTarget,
+		batchSize:     initialBatchSize,
+	}
+}
+
+// get returns the batch size for the next read
+func (s *queueBatchSizer) get() int {
+	s.Lock()
+	defer s.Unlock()
+
+	s.batchSize = s.bound(s.batchSize)
+	return s.batchSize
+}
+
+// update adjusts the batch size after a read which took the given latency
+func (s *queueBatchSizer) update(latency time.Duration, morePage bool) {
+	s.Lock()
+	defer s.Unlock()
+
+	switch {
+	case latency > s.latencyTarget():
+		s.batchSize = s.bound(s.batchSize / 2)
+	case morePage:
+		s.batchSize = s.bound(s.batchSize + s.batchSize/4 + 1)
+	}
+}
+
+func (s *queueBatchSizer) bound(batchSize int) int {
+	if maxBatchSize := s.maxBatchSize(); batchSize > maxBatchSize {
+		batchSize = maxBatchSize
+	}
+	if minBatchSize := s.minBatchSize(); batchSize < minBatchSize {
+		batchSize = minBatchSize
+	}
+	return batchSize
+}
@@ -0,0 +1,86 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package history
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/uber/cadence/common/service/dynamicconfig"
+)
+
+type (
+	queueBatchSizerSuite struct {
+		suite.Suite
+		sizer *queueBatchSizer
+	}
+)
+
+func TestQueueBatchSizerSuite(t *testing.T) {
+	s := new(queueBatchSizerSuite)
+	suite.Run(t, s)
+}
+
+func (s *queueBatchSizerSuite) SetupTest() {
+	s.sizer = newQueueBatchSizer(
+		100,
+		dynamicconfig.GetIntPropertyFn(10),
+		dynamicconfig.GetIntPropertyFn(200),
+		dynamicconfig.GetDurationPropertyFn(100*time.Millisecond),
+	)
+}
+
+func (s *queueBatchSizerSuite) TestGrowWhenBehind() {
+	s.Equal(100, s.sizer.get())
+	s.sizer.update(10*time.Millisecond, true)
+	s.Equal(126, s.sizer.get())
+
+	for i := 0; i < 10; i++ {
+		s.sizer.update(10*time.Millisecond, true)
+	}
+	s.Equal(200, s.sizer.get())
+}
+
+func (s *queueBatchSizerSuite) TestKeepWhenCaughtUp() {
+	s.sizer.update(10*time.Millisecond, false)
+	s.Equal(100, s.sizer.get())
+}
+
+func (s *queueBatchSizerSuite) TestShrinkWhenSlow() {
+	s.sizer.update(time.Second, true)
+	s.Equal(50, s.sizer.get())
+
+	for i := 0; i < 10; i++ {
+		s.sizer.update(time.Second, false)
+	}
+	s.Equal(10, s.sizer.get())
+}
+
+func (s *queueBatchSizerSuite) TestBoundedByConfig() {
+	sizer := newQueueBatchSizer(
+		1000,
+		dynamicconfig.GetIntPropertyFn(10),
+		dynamicconfig.GetIntPropertyFn(200),
+		dynamicconfig.GetDurationPropertyFn(100*time.Millisecond),
+	)
+	s.Equal(200, sizer.get())
+}
@@ -83,6 +83,7 @@ var (
 
 	loadDomainEntryForQueueTaskRetryDelay = 100 * time.Millisecond
 	loadQueueTaskThrottleRetryDelay       = 5 * time.Second
+	frozenDomainQueueTaskRetryDelay       = 30 * time.Second
 )
 
 func newQueueProcessorBase(clusterName string, shard ShardContext, options *QueueProcessorOptions, processor processor, queueAckMgr queueAckMgr, logger log.Logger) *queueProcessorBase {
@@ -334,6 +335,9 @@ FilterLoop:
 				p.ackTaskOnce(task, scope, shouldProcessTask, startTime, attempt)
 				return
 			}
+			if err == persistence.ErrPersistenceDomainFrozen {
+				continue
+			}
 			incAttempt()
 		}
 	}
@@ -349,6 +353,7 @@ func (p *queueProcessorBase) processTaskOnce(notificationChan <-chan struct{}, t
 	scope, err := p.processor.process(task, shouldProcessTask)
 	if shouldProcessTask {
 		p.metricsClient.IncCounter(scope, metrics.TaskRequests)
+		p.shard.RecordTaskProcessed()
 		p.metricsClient.RecordTimer(scope, metrics.TaskProcessingLatency, time.Since(startTime))
 	}
 	return scope, err
@@ -377,6 +382,16 @@ func (p *queueProcessorBase) handleTaskError(scope int, startTime time.Time,
 		err = nil
 	}
 
+	// the writes of the domain are frozen for a migration, park the task until the freeze is lifted
+	if err == persistence.ErrPersistenceDomainFrozen {
+		p.metricsClient.IncCounter(scope, metrics.TaskDomainFrozenCounter)
+		select {
+		case <-p.shutdownCh:
+		case <-time.After(frozenDomainQueueTaskRetryDelay):
+		}
+		return err
+	}
+
 	// this is a transient error
 	if _, ok := err.(*workflow.DomainNotActiveError); ok {
 		if p.timeSource.Now().Sub(startTime) > cache.DomainCacheRefreshInterval {
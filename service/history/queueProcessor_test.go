@@ -207,6 +207,17 @@ func (s *queueProcessorSuite) TestHandleTaskError_ErrTaskDiscarded() {
 	s.Nil(s.queueProcessor.handleTaskError(s.scope, time.Now(), s.notificationChan, err, s.logger))
 }
 
+func (s *queueProcessorSuite) TestHandleTaskError_DomainFrozen() {
+	delay := frozenDomainQueueTaskRetryDelay
+	defer func() { frozenDomainQueueTaskRetryDelay = delay }()
+	frozenDomainQueueTaskRetryDelay = time.Second
+
+	startTime := time.Now()
+	err := s.queueProcessor.handleTaskError(s.scope, time.Now(), s.notificationChan, persistence.ErrPersistenceDomainFrozen, s.logger)
+	s.True(time.Since(startTime) >= time.Second)
+	s.Equal(persistence.ErrPersistenceDomainFrozen, err)
+}
+
 func (s *queueProcessorSuite) TestHandleTaskError_DomainNotActiveError() {
 	err := &workflow.DomainNotActiveError{}
 
@@ -27,6 +27,7 @@ import (
 	"github.com/uber/cadence/.gen/go/replicator"
 	"github.com/uber/cadence/.gen/go/shared"
 	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/cache"
 	"github.com/uber/cadence/common/clock"
 	"github.com/uber/cadence/common/log"
 	"github.com/uber/cadence/common/log/tag"
@@ -52,6 +53,7 @@ type (
 		queueAckMgr
 
 		lastShardSyncTimestamp time.Time
+		lastCompletedTaskID    int64
 	}
 )
 
@@ -123,18 +125,12 @@ func (p *replicatorQueueProcessorImpl) process(qTask queueTaskInfo, shouldProces
 	switch task.TaskType {
 	case persistence.ReplicationTaskTypeSyncActivity:
 		err := p.processSyncActivityTask(task)
-		if err == nil {
-			err = p.executionMgr.CompleteReplicationTask(&persistence.CompleteReplicationTaskRequest{TaskID: task.GetTaskID()})
-		}
 		return metrics.ReplicatorTaskSyncActivityScope, err
 	case persistence.ReplicationTaskTypeHistory:
 		err := p.processHistoryReplicationTask(task)
 		if _, ok := err.(*shared.EntityNotExistsError); ok {
 			err = errHistoryNotFoundTask
 		}
-		if err == nil {
-			err = p.executionMgr.CompleteReplicationTask(&persistence.CompleteReplicationTaskRequest{TaskID: task.GetTaskID()})
-		}
 		return metrics.ReplicatorTaskHistoryScope, err
 	default:
 		return metrics.ReplicatorQueueProcessorScope, errUnknownReplicationTask
@@ -184,6 +180,14 @@ func (p *replicatorQueueProcessorImpl) processSyncActivityTask(task *persistence
 	// LastHeartBeatUpdatedTime must be valid when getting the sync activity replication task
 	heartbeatTime = common.Int64Ptr(activityInfo.LastHeartBeatUpdatedTime.UnixNano())
 
+	domainEntry, err := p.shard.GetDomainCache().GetDomainByID(domainID)
+	if err != nil {
+		return err
+	}
+	if _, replicate := p.getTargetClusters(domainEntry); !replicate {
+		return nil
+	}
+
 	replicationTask := &replicator.ReplicationTask{
 		TaskType: replicator.ReplicationTaskType.Ptr(replicator.ReplicationTaskTypeSyncActivity),
 		SyncActicvityTaskAttributes: &replicator.SyncActicvityTaskAttributes{
@@ -212,13 +216,22 @@ func (p *replicatorQueueProcessorImpl) processHistoryReplicationTask(task *persi
 	if err != nil {
 		return err
 	}
-	targetClusters := []string{}
-	for _, cluster := range domainEntry.GetReplicationConfig().Clusters {
-		targetClusters = append(targetClusters, cluster.ClusterName)
+	targetClusters, replicate := p.getTargetClusters(domainEntry)
+	if !replicate {
+		return nil
 	}
-	replicationTask, err := GenerateReplicationTask(targetClusters, task, p.historyMgr, p.historyV2Mgr, p.metricsClient, p.logger, nil, common.IntPtr(p.shard.GetShardID()))
-	if err != nil || replicationTask == nil {
-		return err
+	var replicationTask *replicator.ReplicationTask
+	if p.shard.GetConfig().ReplicatorRawHistoryPassthrough() {
+		replicationTask, err = p.generateRawHistoryReplicationTask(targetClusters, task)
+		if err != nil {
+			return err
+		}
+	}
+	if replicationTask == nil {
+		replicationTask, err = GenerateReplicationTask(targetClusters, task, p.historyMgr, p.historyV2Mgr, p.metricsClient, p.logger, nil, common.IntPtr(p.shard.GetShardID()))
+		if err != nil || replicationTask == nil {
+			return err
+		}
 	}
 
 	err = p.replicator.Publish(replicationTask)
@@ -231,6 +244,92 @@ func (p *replicatorQueueProcessorImpl) processHistoryReplicationTask(task *persi
 	return err
 }
 
+// getTargetClusters returns the replication clusters of the domain allowed by its residency, and false if the
+// residency leaves no cluster other than the current one to replicate to
+func (p *replicatorQueueProcessorImpl) getTargetClusters(domainEntry *cache.DomainCacheEntry) ([]string, bool) {
+	residency := domainEntry.GetConfig().Residency
+	targetClusters := []string{}
+	replicate := len(residency.AllowedClusters) == 0
+	for _, cluster := range domainEntry.GetReplicationConfig().Clusters {
+		if !residency.IsClusterAllowed(cluster.ClusterName) {
+			continue
+		}
+		targetClusters = append(targetClusters, cluster.ClusterName)
+		if cluster.ClusterName != p.currentClusterNamer {
+			replicate = true
+		}
+	}
+	return targetClusters, replicate
+}
+
+// generateRawHistoryReplicationTask generates a history replication task carrying the event batches
+// as persisted, so they are not decoded and encoded again before being published.
+// It returns nil if the events are not stored as a single thriftrw batch in the v2 event store.
+func (p *replicatorQueueProcessorImpl) generateRawHistoryReplicationTask(targetClusters []string, task *persistence.ReplicationTaskInfo) (*replicator.ReplicationTask, error) {
+	if task.EventStoreVersion != persistence.EventStoreVersionV2 {
+		return nil, nil
+	}
+	historyBlob, err := p.readRawHistoryBatch(task.BranchToken, task.FirstEventID, task.NextEventID)
+	if err != nil || historyBlob == nil {
+		return nil, err
+	}
+
+	var newRunHistoryBlob *shared.DataBlob
+	if len(task.NewRunBranchToken) != 0 {
+		if task.NewRunEventStoreVersion != persistence.EventStoreVersionV2 {
+			return nil, nil
+		}
+		// [common.FirstEventID to common.FirstEventID+1) will get the first batch
+		newRunHistoryBlob, err = p.readRawHistoryBatch(task.NewRunBranchToken, common.FirstEventID, common.FirstEventID+1)
+		if err != nil || newRunHistoryBlob == nil {
+			return nil, err
+		}
+	}
+
+	return &replicator.ReplicationTask{
+		TaskType: replicator.ReplicationTaskType.Ptr(replicator.ReplicationTaskTypeHistory),
+		HistoryTaskAttributes: &replicator.HistoryTaskAttributes{
+			TargetClusters:          targetClusters,
+			DomainId:                common.StringPtr(task.DomainID),
+			WorkflowId:              common.StringPtr(task.WorkflowID),
+			RunId:                   common.StringPtr(task.RunID),
+			FirstEventId:            common.Int64Ptr(task.FirstEventID),
+			NextEventId:             common.Int64Ptr(task.NextEventID),
+			Version:                 common.Int64Ptr(task.Version),
+			ReplicationInfo:         convertLastReplicationInfo(task.LastReplicationInfo),
+			HistoryBlob:             historyBlob,
+			NewRunHistoryBlob:       newRunHistoryBlob,
+			EventStoreVersion:       common.Int32Ptr(task.EventStoreVersion),
+			NewRunEventStoreVersion: common.Int32Ptr(task.NewRunEventStoreVersion),
+			ResetWorkflow:           common.BoolPtr(task.ResetWorkflow),
+		},
+	}, nil
+}
+
+func (p *replicatorQueueProcessorImpl) readRawHistoryBatch(branchToken []byte, firstEventID int64, nextEventID int64) (*shared.DataBlob, error) {
+	response, err := p.historyV2Mgr.ReadRawHistoryBranch(&persistence.ReadHistoryBranchRequest{
+		BranchToken: branchToken,
+		MinEventID:  firstEventID,
+		MaxEventID:  nextEventID,
+		PageSize:    defaultHistoryPageSize,
+		ShardID:     common.IntPtr(p.shard.GetShardID()),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(response.HistoryEventBlobs) != 1 || len(response.NextPageToken) != 0 {
+		return nil, nil
+	}
+	blob := response.HistoryEventBlobs[0]
+	if blob.Encoding != common.EncodingTypeThriftRW {
+		return nil, nil
+	}
+	return &shared.DataBlob{
+		EncodingType: shared.EncodingTypeThriftRW.Ptr(),
+		Data:         blob.Data,
+	}, nil
+}
+
 func (p *replicatorQueueProcessorImpl) generateHistoryMetadataTask(targetClusters []string, task *persistence.ReplicationTaskInfo) *replicator.ReplicationTask {
 	return &replicator.ReplicationTask{
 		TaskType: replicator.ReplicationTaskTypeHistoryMetadata.Ptr(),
@@ -333,6 +432,9 @@ func (p *replicatorQueueProcessorImpl) readTasks(readLevel int64) ([]queueTaskIn
 
 func (p *replicatorQueueProcessorImpl) updateAckLevel(ackLevel int64) error {
 	err := p.shard.UpdateReplicatorAckLevel(ackLevel)
+	if err == nil {
+		err = p.completeReplicationTasks(ackLevel)
+	}
 
 	// this is a hack, since there is not dedicated ticker on the queue processor
 	// to periodically send out sync shard message, put it here
@@ -354,6 +456,21 @@ func (p *replicatorQueueProcessorImpl) updateAckLevel(ackLevel int64) error {
 	return err
 }
 
+// completeReplicationTasks range deletes the replication tasks up to the ack level, they are published
+// to the replication topic consumed by all the remote clusters
+func (p *replicatorQueueProcessorImpl) completeReplicationTasks(ackLevel int64) error {
+	if ackLevel <= p.lastCompletedTaskID {
+		return nil
+	}
+	if err := p.executionMgr.RangeCompleteReplicationTask(&persistence.RangeCompleteReplicationTaskRequest{
+		InclusiveEndTaskID: ackLevel,
+	}); err != nil {
+		return err
+	}
+	p.lastCompletedTaskID = ackLevel
+	return nil
+}
+
 // GetAllHistory return history
 func GetAllHistory(historyMgr persistence.HistoryManager, historyV2Mgr persistence.HistoryV2Manager,
 	metricsClient metrics.Client, logger log.Logger, byBatch bool,
@@ -25,6 +25,7 @@ import (
 	"time"
 
 	"github.com/pborman/uuid"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 	"github.com/uber-go/tally"
 	"github.com/uber/cadence/.gen/go/replicator"
@@ -136,7 +137,6 @@ func (s *replicatorQueueProcessorSuite) TestSyncActivity_WorkflowMissing() {
 		RunID:       runID,
 		ScheduledID: scheduleID,
 	}
-	s.mockExecutionMgr.On("CompleteReplicationTask", &persistence.CompleteReplicationTaskRequest{TaskID: taskID}).Return(nil).Once()
 	s.mockExecutionMgr.On("GetWorkflowExecution", &persistence.GetWorkflowExecutionRequest{
 		DomainID: domainID,
 		Execution: shared.WorkflowExecution{
@@ -165,7 +165,6 @@ func (s *replicatorQueueProcessorSuite) TestSyncActivity_WorkflowCompleted() {
 		RunID:       runID,
 		ScheduledID: scheduleID,
 	}
-	s.mockExecutionMgr.On("CompleteReplicationTask", &persistence.CompleteReplicationTaskRequest{TaskID: taskID}).Return(nil).Once()
 
 	context, release, _ := s.replicatorQueueProcessor.historyCache.getOrCreateWorkflowExecutionForBackground(
 		domainID,
@@ -206,7 +205,6 @@ func (s *replicatorQueueProcessorSuite) TestSyncActivity_ActivityCompleted() {
 		RunID:       runID,
 		ScheduledID: scheduleID,
 	}
-	s.mockExecutionMgr.On("CompleteReplicationTask", &persistence.CompleteReplicationTaskRequest{TaskID: taskID}).Return(nil).Once()
 
 	context, release, _ := s.replicatorQueueProcessor.historyCache.getOrCreateWorkflowExecutionForBackground(
 		domainID,
@@ -266,7 +264,6 @@ func (s *replicatorQueueProcessorSuite) TestSyncActivity_ActivityRetry() {
 		RunID:       runID,
 		ScheduledID: scheduleID,
 	}
-	s.mockExecutionMgr.On("CompleteReplicationTask", &persistence.CompleteReplicationTaskRequest{TaskID: taskID}).Return(nil).Once()
 
 	context, release, _ := s.replicatorQueueProcessor.historyCache.getOrCreateWorkflowExecutionForBackground(
 		domainID,
@@ -366,7 +363,6 @@ func (s *replicatorQueueProcessorSuite) TestSyncActivity_ActivityRunning() {
 		RunID:       runID,
 		ScheduledID: scheduleID,
 	}
-	s.mockExecutionMgr.On("CompleteReplicationTask", &persistence.CompleteReplicationTaskRequest{TaskID: taskID}).Return(nil).Once()
 
 	context, release, _ := s.replicatorQueueProcessor.historyCache.getOrCreateWorkflowExecutionForBackground(
 		domainID,
@@ -449,6 +445,43 @@ func (s *replicatorQueueProcessorSuite) TestSyncActivity_ActivityRunning() {
 	s.Nil(err)
 }
 
+func (s *replicatorQueueProcessorSuite) TestGetTargetClusters() {
+	replicationConfig := &persistence.DomainReplicationConfig{
+		ActiveClusterName: cluster.TestCurrentClusterName,
+		Clusters: []*persistence.ClusterReplicationConfig{
+			{ClusterName: cluster.TestCurrentClusterName},
+			{ClusterName: cluster.TestAlternativeClusterName},
+		},
+	}
+	newDomainEntry := func(residency persistence.DomainResidency) *cache.DomainCacheEntry {
+		return cache.NewGlobalDomainCacheEntryForTest(
+			&persistence.DomainInfo{ID: "some random domain ID"},
+			&persistence.DomainConfig{Retention: 1, Residency: residency},
+			replicationConfig,
+			0,
+			s.mockClusterMetadata,
+		)
+	}
+
+	targetClusters, replicate := s.replicatorQueueProcessor.getTargetClusters(newDomainEntry(persistence.DomainResidency{}))
+	s.True(replicate)
+	s.Equal(cluster.TestAllClusterNames, targetClusters)
+
+	targetClusters, replicate = s.replicatorQueueProcessor.getTargetClusters(newDomainEntry(persistence.DomainResidency{
+		Tag:             "eu",
+		AllowedClusters: []string{cluster.TestAlternativeClusterName},
+	}))
+	s.True(replicate)
+	s.Equal([]string{cluster.TestAlternativeClusterName}, targetClusters)
+
+	targetClusters, replicate = s.replicatorQueueProcessor.getTargetClusters(newDomainEntry(persistence.DomainResidency{
+		Tag:             "eu",
+		AllowedClusters: []string{cluster.TestCurrentClusterName},
+	}))
+	s.False(replicate)
+	s.Equal([]string{cluster.TestCurrentClusterName}, targetClusters)
+}
+
 func (s *replicatorQueueProcessorSuite) TestPaginateHistoryWithShardID() {
 	domainID := validDomainID
 	workflowID := "some random workflow ID"
@@ -485,3 +518,78 @@ func (s *replicatorQueueProcessorSuite) TestPaginateHistoryWithShardID() {
 	s.Equal(1, size)
 	s.NoError(err)
 }
+
+func (s *replicatorQueueProcessorSuite) TestGenerateRawHistoryReplicationTask() {
+	branchToken := []byte("some random branch token")
+	task := &persistence.ReplicationTaskInfo{
+		TaskType:          persistence.ReplicationTaskTypeHistory,
+		DomainID:          validDomainID,
+		WorkflowID:        "some random workflow ID",
+		RunID:             uuid.New(),
+		FirstEventID:      int64(133),
+		NextEventID:       int64(136),
+		Version:           int64(2333),
+		EventStoreVersion: persistence.EventStoreVersionV2,
+		BranchToken:       branchToken,
+	}
+	data := []byte("some random history blob")
+
+	s.mockHistoryV2Mgr.On("ReadRawHistoryBranch", &persistence.ReadHistoryBranchRequest{
+		BranchToken: branchToken,
+		MinEventID:  task.FirstEventID,
+		MaxEventID:  task.NextEventID,
+		PageSize:    defaultHistoryPageSize,
+		ShardID:     common.IntPtr(s.mockShard.GetShardID()),
+	}).Return(&persistence.ReadRawHistoryBranchResponse{
+		HistoryEventBlobs: []*persistence.DataBlob{{Encoding: common.EncodingTypeThriftRW, Data: data}},
+	}, nil).Once()
+
+	replicationTask, err := s.replicatorQueueProcessor.generateRawHistoryReplicationTask([]string{cluster.TestCurrentClusterName}, task)
+	s.NoError(err)
+	s.NotNil(replicationTask)
+	attr := replicationTask.HistoryTaskAttributes
+	s.Nil(attr.History)
+	s.Nil(attr.NewRunHistoryBlob)
+	s.Equal(&workflow.DataBlob{EncodingType: workflow.EncodingTypeThriftRW.Ptr(), Data: data}, attr.HistoryBlob)
+	s.Equal(task.Version, attr.GetVersion())
+	s.Equal(task.FirstEventID, attr.GetFirstEventId())
+	s.Equal(task.NextEventID, attr.GetNextEventId())
+}
+
+func (s *replicatorQueueProcessorSuite) TestGenerateRawHistoryReplicationTask_FallbackToEvents() {
+	task := &persistence.ReplicationTaskInfo{
+		TaskType:          persistence.ReplicationTaskTypeHistory,
+		DomainID:          validDomainID,
+		WorkflowID:        "some random workflow ID",
+		RunID:             uuid.New(),
+		FirstEventID:      int64(133),
+		NextEventID:       int64(136),
+		Version:           int64(2333),
+		EventStoreVersion: persistence.EventStoreVersionV2,
+		BranchToken:       []byte("some random branch token"),
+	}
+
+	s.mockHistoryV2Mgr.On("ReadRawHistoryBranch", mock.Anything).Return(&persistence.ReadRawHistoryBranchResponse{
+		HistoryEventBlobs: []*persistence.DataBlob{{Encoding: common.EncodingTypeJSON, Data: []byte("{}")}},
+	}, nil).Once()
+	replicationTask, err := s.replicatorQueueProcessor.generateRawHistoryReplicationTask([]string{cluster.TestCurrentClusterName}, task)
+	s.NoError(err)
+	s.Nil(replicationTask)
+
+	task.EventStoreVersion = 0
+	replicationTask, err = s.replicatorQueueProcessor.generateRawHistoryReplicationTask([]string{cluster.TestCurrentClusterName}, task)
+	s.NoError(err)
+	s.Nil(replicationTask)
+}
+
+func (s *replicatorQueueProcessorSuite) TestCompleteReplicationTasks() {
+	ackLevel := int64(1234)
+	s.mockExecutionMgr.On("RangeCompleteReplicationTask", &persistence.RangeCompleteReplicationTaskRequest{
+		InclusiveEndTaskID: ackLevel,
+	}).Return(nil).Once()
+	s.NoError(s.replicatorQueueProcessor.completeReplicationTasks(ackLevel))
+	s.Equal(ackLevel, s.replicatorQueueProcessor.lastCompletedTaskID)
+
+	// no range delete if the level does not advance
+	s.NoError(s.replicatorQueueProcessor.completeReplicationTasks(ackLevel))
+}
@@ -23,6 +23,7 @@ package history
 import (
 	"fmt"
 	"math"
+	"strings"
 	"time"
 
 	"github.com/uber/cadence/.gen/go/shared"
@@ -44,7 +45,10 @@ func prepareActivityNextRetryWithTime(version int64, a *persistence.ActivityInfo
 
 	// a retry is needed, update activity info for next retry
 	a.Version = version
-	a.Attempt++
+	if !a.Paused {
+		// retries of a paused activity do not count against its retry policy
+		a.Attempt++
+	}
 	a.ScheduledTime = nextScheduleTime // update to next schedule time
 	a.StartedID = common.EmptyEventID
 	a.RequestID = ""
@@ -61,6 +65,33 @@ func prepareActivityNextRetryWithTime(version int64, a *persistence.ActivityInfo
 	}
 }
 
+// applyActivityRetryPolicyOverrides returns the retry policy of a newly scheduled activity overridden with the
+// values configured in dynamic config for its domain and activity type. The result is recorded in the
+// ActivityTaskScheduled event, so that standby clusters and rebuilt mutable states see the same policy.
+func applyActivityRetryPolicyOverrides(config *Config, domainName string, activityType string, policy *shared.RetryPolicy) *shared.RetryPolicy {
+	if policy == nil {
+		return nil
+	}
+
+	// the decision attributes are not modified
+	overridden := *policy
+	if maximumAttempts := config.ActivityRetryMaximumAttemptsOverride(domainName, activityType); maximumAttempts > 0 {
+		overridden.MaximumAttempts = common.Int32Ptr(int32(maximumAttempts))
+	}
+	if maximumInterval := int32(config.ActivityRetryMaximumIntervalOverride(domainName, activityType) / time.Second); maximumInterval > 0 {
+		overridden.MaximumIntervalInSeconds = common.Int32Ptr(maximumInterval)
+	}
+	if nonRetriableErrors := config.ActivityRetryNonRetriableErrorsOverride(domainName, activityType); nonRetriableErrors != "" {
+		overridden.NonRetriableErrorReasons = nil
+		for _, reason := range strings.Split(nonRetriableErrors, ",") {
+			if reason = strings.TrimSpace(reason); reason != "" {
+				overridden.NonRetriableErrorReasons = append(overridden.NonRetriableErrorReasons, reason)
+			}
+		}
+	}
+	return &overridden
+}
+
 func getBackoffInterval(currAttempt, maxAttempts, initInterval, maxInterval int32, backoffCoefficient float64, now, expirationTime time.Time, errReason string, nonRetriableErrors []string) time.Duration {
 	if maxAttempts == 0 && expirationTime.IsZero() {
 		return backoff.NoBackoff
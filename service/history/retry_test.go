@@ -25,6 +25,8 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common"
 	"github.com/uber/cadence/common/clock"
 	"github.com/uber/cadence/common/persistence"
 )
@@ -121,4 +123,59 @@ func Test_NextRetry(t *testing.T) {
 	ai.MaximumAttempts = 100
 	retryTask = prepareActivityNextRetryWithTime(version, ai, reason, now)
 	a.Equal(now.Add(time.Second*10), retryTask.(*persistence.ActivityRetryTimerTask).VisibilityTimestamp)
+
+	// retry of a paused activity does not consume an attempt
+	ai.Paused = true
+	attempt := ai.Attempt
+	retryTask = prepareActivityNextRetryWithTime(version, ai, reason, now)
+	a.NotNil(retryTask)
+	a.Equal(attempt, ai.Attempt)
+}
+
+func Test_ApplyActivityRetryPolicyOverrides(t *testing.T) {
+	a := assert.New(t)
+	domain := "some-domain"
+	activityType := "some-activity-type"
+
+	config := NewDynamicConfigForTest()
+	config.ActivityRetryMaximumAttemptsOverride = func(d string, at string) int {
+		if d == domain && at == activityType {
+			return 3
+		}
+		return 0
+	}
+	config.ActivityRetryMaximumIntervalOverride = func(d string, at string) time.Duration {
+		if d == domain && at == activityType {
+			return time.Minute
+		}
+		return 0
+	}
+	config.ActivityRetryNonRetriableErrorsOverride = func(d string, at string) string {
+		if d == domain && at == activityType {
+			return "bad-reason, ugly-reason"
+		}
+		return ""
+	}
+
+	// no override without retry policy
+	a.Nil(applyActivityRetryPolicyOverrides(config, domain, activityType, nil))
+
+	// no override for other activity types
+	policy := &shared.RetryPolicy{
+		InitialIntervalInSeconds: common.Int32Ptr(1),
+		MaximumAttempts:          common.Int32Ptr(10),
+		MaximumIntervalInSeconds: common.Int32Ptr(600),
+		NonRetriableErrorReasons: []string{"other-reason"},
+	}
+	overridden := applyActivityRetryPolicyOverrides(config, domain, "other-activity-type", policy)
+	a.Equal(policy, overridden)
+
+	overridden = applyActivityRetryPolicyOverrides(config, domain, activityType, policy)
+	a.Equal(int32(1), overridden.GetInitialIntervalInSeconds())
+	a.Equal(int32(3), overridden.GetMaximumAttempts())
+	a.Equal(int32(60), overridden.GetMaximumIntervalInSeconds())
+	a.Equal([]string{"bad-reason", "ugly-reason"}, overridden.NonRetriableErrorReasons)
+	// the policy of the decision is left untouched
+	a.Equal(int32(10), policy.GetMaximumAttempts())
+	a.Equal([]string{"other-reason"}, policy.NonRetriableErrorReasons)
 }
@@ -31,6 +31,7 @@ import (
 	"github.com/uber/cadence/common/persistence"
 	espersistence "github.com/uber/cadence/common/persistence/elasticsearch"
 	persistencefactory "github.com/uber/cadence/common/persistence/persistence-factory"
+	pinotpersistence "github.com/uber/cadence/common/persistence/pinot"
 	"github.com/uber/cadence/common/service"
 	"github.com/uber/cadence/common/service/config"
 	"github.com/uber/cadence/common/service/dynamicconfig"
@@ -40,16 +41,18 @@ import (
 type Config struct {
 	NumberOfShards int
 
-	RPS                             dynamicconfig.IntPropertyFn
-	MaxIDLengthLimit                dynamicconfig.IntPropertyFn
-	PersistenceMaxQPS               dynamicconfig.IntPropertyFn
-	EnableVisibilitySampling        dynamicconfig.BoolPropertyFn
-	EnableReadFromClosedExecutionV2 dynamicconfig.BoolPropertyFn
-	VisibilityOpenMaxQPS            dynamicconfig.IntPropertyFnWithDomainFilter
-	VisibilityClosedMaxQPS          dynamicconfig.IntPropertyFnWithDomainFilter
-	EnableVisibilityToKafka         dynamicconfig.BoolPropertyFn
-	EmitShardDiffLog                dynamicconfig.BoolPropertyFn
-	MaxAutoResetPoints              dynamicconfig.IntPropertyFnWithDomainFilter
+	RPS                                    dynamicconfig.IntPropertyFn
+	MaxIDLengthLimit                       dynamicconfig.IntPropertyFn
+	PersistenceMaxQPS                      dynamicconfig.IntPropertyFn
+	EnableVisibilitySampling               dynamicconfig.BoolPropertyFn
+	EnableReadFromClosedExecutionV2        dynamicconfig.BoolPropertyFn
+	EnableReadFromOpenExecutionsByDomain   dynamicconfig.BoolPropertyFn
+	EnableReadFromClosedExecutionsByFilter dynamicconfig.BoolPropertyFn
+	VisibilityOpenMaxQPS                   dynamicconfig.IntPropertyFnWithDomainFilter
+	VisibilityClosedMaxQPS                 dynamicconfig.IntPropertyFnWithDomainFilter
+	EnableVisibilityToKafka                dynamicconfig.BoolPropertyFn
+	EmitShardDiffLog                       dynamicconfig.BoolPropertyFn
+	MaxAutoResetPoints                     dynamicconfig.IntPropertyFnWithDomainFilter
 
 	// HistoryCache settings
 	// Change of these configs require shard restart
@@ -57,23 +60,45 @@ type Config struct {
 	HistoryCacheMaxSize     dynamicconfig.IntPropertyFn
 	HistoryCacheTTL         dynamicconfig.DurationPropertyFn
 
+	// HistoryCache warm-up settings
+	HistoryCacheWarmupEnabled        dynamicconfig.BoolPropertyFn
+	HistoryCacheWarmupSize           dynamicconfig.IntPropertyFn
+	HistoryCacheWarmupRecordInterval dynamicconfig.DurationPropertyFn
+
 	// EventsCache settings
 	// Change of these configs require shard restart
 	EventsCacheInitialSize dynamicconfig.IntPropertyFn
 	EventsCacheMaxSize     dynamicconfig.IntPropertyFn
 	EventsCacheTTL         dynamicconfig.DurationPropertyFn
 
+	// ClientVersionTrackerMaxSize is the max number of distinct domain and client version pairs tracked by the host
+	ClientVersionTrackerMaxSize dynamicconfig.IntPropertyFn
+
 	// ShardController settings
-	RangeSizeBits        uint
-	AcquireShardInterval dynamicconfig.DurationPropertyFn
+	RangeSizeBits           uint
+	AcquireShardInterval    dynamicconfig.DurationPropertyFn
+	AcquireShardConcurrency dynamicconfig.IntPropertyFn
+	AcquireShardJitter      dynamicconfig.DurationPropertyFn
+	ShardLeaseRenewInterval dynamicconfig.DurationPropertyFn
+	ShardLeaseRenewJitter   dynamicconfig.DurationPropertyFn
+	ShardLeaseTTL           dynamicconfig.DurationPropertyFn
+	ShardLeaseStealTimeout  dynamicconfig.DurationPropertyFn
+
+	// ShardPlacement settings
+	EnableLoadAwareShardPlacement dynamicconfig.BoolPropertyFn
+	ShardPlacementRefreshInterval dynamicconfig.DurationPropertyFn
+	ShardPlacementLoadTolerance   dynamicconfig.FloatPropertyFn
 
 	// the artificial delay added to standby cluster's view of active cluster's time
 	StandbyClusterDelay dynamicconfig.DurationPropertyFn
 
 	// TimerQueueProcessor settings
 	TimerTaskBatchSize                               dynamicconfig.IntPropertyFn
+	TimerTaskMinBatchSize                            dynamicconfig.IntPropertyFn
+	TimerTaskMaxBatchSize                            dynamicconfig.IntPropertyFn
 	TimerTaskWorkerCount                             dynamicconfig.IntPropertyFn
 	TimerTaskMaxRetryCount                           dynamicconfig.IntPropertyFn
+	TimerProcessorReadLatencyTarget                  dynamicconfig.DurationPropertyFn
 	TimerProcessorStartDelay                         dynamicconfig.DurationPropertyFn
 	TimerProcessorFailoverStartDelay                 dynamicconfig.DurationPropertyFn
 	TimerProcessorGetFailureRetryCount               dynamicconfig.IntPropertyFn
@@ -86,11 +111,18 @@ type Config struct {
 	TimerProcessorMaxPollInterval                    dynamicconfig.DurationPropertyFn
 	TimerProcessorMaxPollIntervalJitterCoefficient   dynamicconfig.FloatPropertyFn
 	TimerProcessorMaxTimeShift                       dynamicconfig.DurationPropertyFn
+	UserTimerCoalescingWindow                        dynamicconfig.DurationPropertyFn
+	RetentionSweepInterval                           dynamicconfig.DurationPropertyFn
+	RetentionSweepLookbackDays                       dynamicconfig.IntPropertyFn
+	RetentionSweepBatchSize                          dynamicconfig.IntPropertyFn
 
 	// TransferQueueProcessor settings
 	TransferTaskBatchSize                               dynamicconfig.IntPropertyFn
+	TransferTaskMinBatchSize                            dynamicconfig.IntPropertyFn
+	TransferTaskMaxBatchSize                            dynamicconfig.IntPropertyFn
 	TransferTaskWorkerCount                             dynamicconfig.IntPropertyFn
 	TransferTaskMaxRetryCount                           dynamicconfig.IntPropertyFn
+	TransferProcessorReadLatencyTarget                  dynamicconfig.DurationPropertyFn
 	TransferProcessorStartDelay                         dynamicconfig.DurationPropertyFn
 	TransferProcessorFailoverStartDelay                 dynamicconfig.DurationPropertyFn
 	TransferProcessorCompleteTransferFailureRetryCount  dynamicconfig.IntPropertyFn
@@ -101,6 +133,7 @@ type Config struct {
 	TransferProcessorUpdateAckInterval                  dynamicconfig.DurationPropertyFn
 	TransferProcessorUpdateAckIntervalJitterCoefficient dynamicconfig.FloatPropertyFn
 	TransferProcessorCompleteTransferInterval           dynamicconfig.DurationPropertyFn
+	StuckQueueAlarmThreshold                            dynamicconfig.DurationPropertyFn
 
 	// ReplicatorQueueProcessor settings
 	ReplicatorTaskBatchSize                               dynamicconfig.IntPropertyFn
@@ -112,6 +145,22 @@ type Config struct {
 	ReplicatorProcessorMaxPollIntervalJitterCoefficient   dynamicconfig.FloatPropertyFn
 	ReplicatorProcessorUpdateAckInterval                  dynamicconfig.DurationPropertyFn
 	ReplicatorProcessorUpdateAckIntervalJitterCoefficient dynamicconfig.FloatPropertyFn
+	ReplicatorRawHistoryPassthrough                       dynamicconfig.BoolPropertyFn
+	ReplicatorPublishBatchSize                            dynamicconfig.IntPropertyFn
+	ReplicatorPublishBatchMaxDelay                        dynamicconfig.DurationPropertyFn
+	ReplicatorPublishMaxInFlightBatches                   dynamicconfig.IntPropertyFn
+
+	// VisibilityQueueProcessor settings
+	EnableVisibilityTaskQueue                             dynamicconfig.BoolPropertyFn
+	VisibilityTaskBatchSize                               dynamicconfig.IntPropertyFn
+	VisibilityTaskWorkerCount                             dynamicconfig.IntPropertyFn
+	VisibilityTaskMaxRetryCount                           dynamicconfig.IntPropertyFn
+	VisibilityProcessorStartDelay                         dynamicconfig.DurationPropertyFn
+	VisibilityProcessorMaxPollRPS                         dynamicconfig.IntPropertyFn
+	VisibilityProcessorMaxPollInterval                    dynamicconfig.DurationPropertyFn
+	VisibilityProcessorMaxPollIntervalJitterCoefficient   dynamicconfig.FloatPropertyFn
+	VisibilityProcessorUpdateAckInterval                  dynamicconfig.DurationPropertyFn
+	VisibilityProcessorUpdateAckIntervalJitterCoefficient dynamicconfig.FloatPropertyFn
 
 	// Persistence settings
 	ExecutionMgrNumConns dynamicconfig.IntPropertyFn
@@ -120,9 +169,13 @@ type Config struct {
 	// System Limits
 	MaximumBufferedEventsBatch dynamicconfig.IntPropertyFn
 	MaximumSignalsPerExecution dynamicconfig.IntPropertyFnWithDomainFilter
+	// MaximumHistoryBranchesPerExecution is max number of live history branches of a workflow, a reset adds a branch
+	MaximumHistoryBranchesPerExecution dynamicconfig.IntPropertyFnWithDomainFilter
 
 	// ShardUpdateMinInterval the minimal time interval which the shard info can be updated
 	ShardUpdateMinInterval dynamicconfig.DurationPropertyFn
+	// ShardTaskThroughputWindow the time window over which the task rate of a shard is averaged
+	ShardTaskThroughputWindow dynamicconfig.DurationPropertyFn
 	// ShardSyncMinInterval the minimal time interval which the shard info should be sync to remote
 	ShardSyncMinInterval dynamicconfig.DurationPropertyFn
 
@@ -134,6 +187,10 @@ type Config struct {
 	EventEncodingType dynamicconfig.StringPropertyFnWithDomainFilter
 	// whether or not using eventsV2
 	EnableEventsV2 dynamicconfig.BoolPropertyFnWithDomainFilter
+	// whether or not decision heartbeats are kept as transient decisions
+	EnableTransientDecisionHeartbeat dynamicconfig.BoolPropertyFnWithDomainFilter
+	// whether or not the workflow updates of the domain are rejected, reads are still served
+	DomainExecutionsFrozen dynamicconfig.BoolPropertyFnWithDomainFilter
 
 	NumArchiveSystemWorkflows dynamicconfig.IntPropertyFn
 	ArchiveRequestRPS         dynamicconfig.IntPropertyFn
@@ -144,6 +201,10 @@ type Config struct {
 	HistorySizeLimitWarn   dynamicconfig.IntPropertyFnWithDomainFilter
 	HistoryCountLimitError dynamicconfig.IntPropertyFnWithDomainFilter
 	HistoryCountLimitWarn  dynamicconfig.IntPropertyFnWithDomainFilter
+	HistoryLimitTerminate  dynamicconfig.BoolPropertyFnWithDomainFilter
+
+	HistorySizeSuggestContinueAsNew  dynamicconfig.IntPropertyFnWithDomainFilter
+	HistoryCountSuggestContinueAsNew dynamicconfig.IntPropertyFnWithDomainFilter
 
 	ThrottledLogRPS dynamicconfig.IntPropertyFn
 
@@ -152,6 +213,11 @@ type Config struct {
 	SearchAttributesNumberOfKeysLimit dynamicconfig.IntPropertyFnWithDomainFilter
 	SearchAttributesSizeOfValueLimit  dynamicconfig.IntPropertyFnWithDomainFilter
 	SearchAttributesTotalSizeLimit    dynamicconfig.IntPropertyFnWithDomainFilter
+
+	// Activity retry policy overrides by domain and activity type
+	ActivityRetryMaximumAttemptsOverride    dynamicconfig.IntPropertyFnWithActivityTypeFilters
+	ActivityRetryMaximumIntervalOverride    dynamicconfig.DurationPropertyFnWithActivityTypeFilters
+	ActivityRetryNonRetriableErrorsOverride dynamicconfig.StringPropertyFnWithActivityTypeFilters
 }
 
 const (
@@ -167,6 +233,8 @@ func NewConfig(dc *dynamicconfig.Collection, numberOfShards int, enableVisibilit
 		PersistenceMaxQPS:                                     dc.GetIntProperty(dynamicconfig.HistoryPersistenceMaxQPS, 9000),
 		EnableVisibilitySampling:                              dc.GetBoolProperty(dynamicconfig.EnableVisibilitySampling, true),
 		EnableReadFromClosedExecutionV2:                       dc.GetBoolProperty(dynamicconfig.EnableReadFromClosedExecutionV2, false),
+		EnableReadFromOpenExecutionsByDomain:                  dc.GetBoolProperty(dynamicconfig.EnableReadFromOpenExecutionsByDomain, false),
+		EnableReadFromClosedExecutionsByFilter:                dc.GetBoolProperty(dynamicconfig.EnableReadFromClosedExecutionsByFilter, false),
 		VisibilityOpenMaxQPS:                                  dc.GetIntPropertyFilteredByDomain(dynamicconfig.HistoryVisibilityOpenMaxQPS, 300),
 		VisibilityClosedMaxQPS:                                dc.GetIntPropertyFilteredByDomain(dynamicconfig.HistoryVisibilityClosedMaxQPS, 300),
 		MaxAutoResetPoints:                                    dc.GetIntPropertyFilteredByDomain(dynamicconfig.HistoryMaxAutoResetPoints, defaultHistoryMaxAutoResetPoints),
@@ -175,13 +243,29 @@ func NewConfig(dc *dynamicconfig.Collection, numberOfShards int, enableVisibilit
 		HistoryCacheInitialSize:                               dc.GetIntProperty(dynamicconfig.HistoryCacheInitialSize, 128),
 		HistoryCacheMaxSize:                                   dc.GetIntProperty(dynamicconfig.HistoryCacheMaxSize, 512),
 		HistoryCacheTTL:                                       dc.GetDurationProperty(dynamicconfig.HistoryCacheTTL, time.Hour),
+		HistoryCacheWarmupEnabled:                             dc.GetBoolProperty(dynamicconfig.HistoryCacheWarmupEnabled, false),
+		HistoryCacheWarmupSize:                                dc.GetIntProperty(dynamicconfig.HistoryCacheWarmupSize, 100),
+		HistoryCacheWarmupRecordInterval:                      dc.GetDurationProperty(dynamicconfig.HistoryCacheWarmupRecordInterval, time.Minute),
 		EventsCacheInitialSize:                                dc.GetIntProperty(dynamicconfig.EventsCacheInitialSize, 128),
 		EventsCacheMaxSize:                                    dc.GetIntProperty(dynamicconfig.EventsCacheMaxSize, 512),
 		EventsCacheTTL:                                        dc.GetDurationProperty(dynamicconfig.EventsCacheTTL, time.Hour),
+		ClientVersionTrackerMaxSize:                           dc.GetIntProperty(dynamicconfig.ClientVersionTrackerMaxSize, 10000),
 		RangeSizeBits:                                         20, // 20 bits for sequencer, 2^20 sequence number for any range
 		AcquireShardInterval:                                  dc.GetDurationProperty(dynamicconfig.AcquireShardInterval, time.Minute),
+		AcquireShardConcurrency:                               dc.GetIntProperty(dynamicconfig.AcquireShardConcurrency, 10),
+		AcquireShardJitter:                                    dc.GetDurationProperty(dynamicconfig.AcquireShardJitter, 100*time.Millisecond),
+		ShardLeaseRenewInterval:                               dc.GetDurationProperty(dynamicconfig.ShardLeaseRenewInterval, time.Minute),
+		ShardLeaseRenewJitter:                                 dc.GetDurationProperty(dynamicconfig.ShardLeaseRenewJitter, 10*time.Second),
+		ShardLeaseTTL:                                         dc.GetDurationProperty(dynamicconfig.ShardLeaseTTL, 3*time.Minute),
+		ShardLeaseStealTimeout:                                dc.GetDurationProperty(dynamicconfig.ShardLeaseStealTimeout, 0),
+		EnableLoadAwareShardPlacement:                         dc.GetBoolProperty(dynamicconfig.EnableLoadAwareShardPlacement, false),
+		ShardPlacementRefreshInterval:                         dc.GetDurationProperty(dynamicconfig.ShardPlacementRefreshInterval, 5*time.Minute),
+		ShardPlacementLoadTolerance:                           dc.GetFloat64Property(dynamicconfig.ShardPlacementLoadTolerance, 0.1),
 		StandbyClusterDelay:                                   dc.GetDurationProperty(dynamicconfig.AcquireShardInterval, 5*time.Minute),
 		TimerTaskBatchSize:                                    dc.GetIntProperty(dynamicconfig.TimerTaskBatchSize, 100),
+		TimerTaskMinBatchSize:                                 dc.GetIntProperty(dynamicconfig.TimerTaskMinBatchSize, 10),
+		TimerTaskMaxBatchSize:                                 dc.GetIntProperty(dynamicconfig.TimerTaskMaxBatchSize, 1000),
+		TimerProcessorReadLatencyTarget:                       dc.GetDurationProperty(dynamicconfig.TimerProcessorReadLatencyTarget, 200*time.Millisecond),
 		TimerTaskWorkerCount:                                  dc.GetIntProperty(dynamicconfig.TimerTaskWorkerCount, 10),
 		TimerTaskMaxRetryCount:                                dc.GetIntProperty(dynamicconfig.TimerTaskMaxRetryCount, 100),
 		TimerProcessorStartDelay:                              dc.GetDurationProperty(dynamicconfig.TimerProcessorStartDelay, 1*time.Microsecond),
@@ -196,7 +280,14 @@ func NewConfig(dc *dynamicconfig.Collection, numberOfShards int, enableVisibilit
 		TimerProcessorMaxPollInterval:                         dc.GetDurationProperty(dynamicconfig.TimerProcessorMaxPollInterval, 5*time.Minute),
 		TimerProcessorMaxPollIntervalJitterCoefficient:        dc.GetFloat64Property(dynamicconfig.TimerProcessorMaxPollIntervalJitterCoefficient, 0.15),
 		TimerProcessorMaxTimeShift:                            dc.GetDurationProperty(dynamicconfig.TimerProcessorMaxTimeShift, 1*time.Second),
+		UserTimerCoalescingWindow:                             dc.GetDurationProperty(dynamicconfig.UserTimerCoalescingWindow, 0),
+		RetentionSweepInterval:                                dc.GetDurationProperty(dynamicconfig.RetentionSweepInterval, 6*time.Hour),
+		RetentionSweepLookbackDays:                            dc.GetIntProperty(dynamicconfig.RetentionSweepLookbackDays, 7),
+		RetentionSweepBatchSize:                               dc.GetIntProperty(dynamicconfig.RetentionSweepBatchSize, 100),
 		TransferTaskBatchSize:                                 dc.GetIntProperty(dynamicconfig.TransferTaskBatchSize, 100),
+		TransferTaskMinBatchSize:                              dc.GetIntProperty(dynamicconfig.TransferTaskMinBatchSize, 10),
+		TransferTaskMaxBatchSize:                              dc.GetIntProperty(dynamicconfig.TransferTaskMaxBatchSize, 1000),
+		TransferProcessorReadLatencyTarget:                    dc.GetDurationProperty(dynamicconfig.TransferProcessorReadLatencyTarget, 200*time.Millisecond),
 		TransferProcessorFailoverMaxPollRPS:                   dc.GetIntProperty(dynamicconfig.TransferProcessorFailoverMaxPollRPS, 1),
 		TransferProcessorMaxPollRPS:                           dc.GetIntProperty(dynamicconfig.TransferProcessorMaxPollRPS, 20),
 		TransferTaskWorkerCount:                               dc.GetIntProperty(dynamicconfig.TransferTaskWorkerCount, 10),
@@ -209,6 +300,7 @@ func NewConfig(dc *dynamicconfig.Collection, numberOfShards int, enableVisibilit
 		TransferProcessorUpdateAckInterval:                    dc.GetDurationProperty(dynamicconfig.TransferProcessorUpdateAckInterval, 30*time.Second),
 		TransferProcessorUpdateAckIntervalJitterCoefficient:   dc.GetFloat64Property(dynamicconfig.TransferProcessorUpdateAckIntervalJitterCoefficient, 0.15),
 		TransferProcessorCompleteTransferInterval:             dc.GetDurationProperty(dynamicconfig.TransferProcessorCompleteTransferInterval, 60*time.Second),
+		StuckQueueAlarmThreshold:                              dc.GetDurationProperty(dynamicconfig.StuckQueueAlarmThreshold, 10*time.Minute),
 		ReplicatorTaskBatchSize:                               dc.GetIntProperty(dynamicconfig.ReplicatorTaskBatchSize, 100),
 		ReplicatorTaskWorkerCount:                             dc.GetIntProperty(dynamicconfig.ReplicatorTaskWorkerCount, 10),
 		ReplicatorTaskMaxRetryCount:                           dc.GetIntProperty(dynamicconfig.ReplicatorTaskMaxRetryCount, 100),
@@ -218,11 +310,28 @@ func NewConfig(dc *dynamicconfig.Collection, numberOfShards int, enableVisibilit
 		ReplicatorProcessorMaxPollIntervalJitterCoefficient:   dc.GetFloat64Property(dynamicconfig.ReplicatorProcessorMaxPollIntervalJitterCoefficient, 0.15),
 		ReplicatorProcessorUpdateAckInterval:                  dc.GetDurationProperty(dynamicconfig.ReplicatorProcessorUpdateAckInterval, 5*time.Second),
 		ReplicatorProcessorUpdateAckIntervalJitterCoefficient: dc.GetFloat64Property(dynamicconfig.ReplicatorProcessorUpdateAckIntervalJitterCoefficient, 0.15),
+		ReplicatorRawHistoryPassthrough:                       dc.GetBoolProperty(dynamicconfig.ReplicatorRawHistoryPassthrough, false),
+		ReplicatorPublishBatchSize:                            dc.GetIntProperty(dynamicconfig.ReplicatorPublishBatchSize, 1),
+		ReplicatorPublishBatchMaxDelay:                        dc.GetDurationProperty(dynamicconfig.ReplicatorPublishBatchMaxDelay, 10*time.Millisecond),
+		ReplicatorPublishMaxInFlightBatches:                   dc.GetIntProperty(dynamicconfig.ReplicatorPublishMaxInFlightBatches, 4),
+
+		EnableVisibilityTaskQueue:                             dc.GetBoolProperty(dynamicconfig.EnableVisibilityTaskQueue, false),
+		VisibilityTaskBatchSize:                               dc.GetIntProperty(dynamicconfig.VisibilityTaskBatchSize, 100),
+		VisibilityTaskWorkerCount:                             dc.GetIntProperty(dynamicconfig.VisibilityTaskWorkerCount, 10),
+		VisibilityTaskMaxRetryCount:                           dc.GetIntProperty(dynamicconfig.VisibilityTaskMaxRetryCount, 100),
+		VisibilityProcessorStartDelay:                         dc.GetDurationProperty(dynamicconfig.VisibilityProcessorStartDelay, 1*time.Microsecond),
+		VisibilityProcessorMaxPollRPS:                         dc.GetIntProperty(dynamicconfig.VisibilityProcessorMaxPollRPS, 20),
+		VisibilityProcessorMaxPollInterval:                    dc.GetDurationProperty(dynamicconfig.VisibilityProcessorMaxPollInterval, 1*time.Minute),
+		VisibilityProcessorMaxPollIntervalJitterCoefficient:   dc.GetFloat64Property(dynamicconfig.VisibilityProcessorMaxPollIntervalJitterCoefficient, 0.15),
+		VisibilityProcessorUpdateAckInterval:                  dc.GetDurationProperty(dynamicconfig.VisibilityProcessorUpdateAckInterval, 5*time.Second),
+		VisibilityProcessorUpdateAckIntervalJitterCoefficient: dc.GetFloat64Property(dynamicconfig.VisibilityProcessorUpdateAckIntervalJitterCoefficient, 0.15),
 		ExecutionMgrNumConns:                                  dc.GetIntProperty(dynamicconfig.ExecutionMgrNumConns, 50),
 		HistoryMgrNumConns:                                    dc.GetIntProperty(dynamicconfig.HistoryMgrNumConns, 50),
 		MaximumBufferedEventsBatch:                            dc.GetIntProperty(dynamicconfig.MaximumBufferedEventsBatch, 100),
 		MaximumSignalsPerExecution:                            dc.GetIntPropertyFilteredByDomain(dynamicconfig.MaximumSignalsPerExecution, 0),
+		MaximumHistoryBranchesPerExecution:                    dc.GetIntPropertyFilteredByDomain(dynamicconfig.MaximumHistoryBranchesPerExecution, 0),
 		ShardUpdateMinInterval:                                dc.GetDurationProperty(dynamicconfig.ShardUpdateMinInterval, 5*time.Minute),
+		ShardTaskThroughputWindow:                             dc.GetDurationProperty(dynamicconfig.ShardTaskThroughputWindow, 15*time.Minute),
 		ShardSyncMinInterval:                                  dc.GetDurationProperty(dynamicconfig.ShardSyncMinInterval, 5*time.Minute),
 
 		// history client: client/history/client.go set the client timeout 30s
@@ -230,6 +339,9 @@ func NewConfig(dc *dynamicconfig.Collection, numberOfShards int, enableVisibilit
 		EventEncodingType:          dc.GetStringPropertyFnWithDomainFilter(dynamicconfig.DefaultEventEncoding, string(common.EncodingTypeThriftRW)),
 		EnableEventsV2:             dc.GetBoolPropertyFnWithDomainFilter(dynamicconfig.EnableEventsV2, true),
 
+		EnableTransientDecisionHeartbeat: dc.GetBoolPropertyFilteredByDomain(dynamicconfig.EnableTransientDecisionHeartbeat, false),
+		DomainExecutionsFrozen:           dc.GetBoolPropertyFnWithDomainFilter(dynamicconfig.DomainExecutionsFrozen, false),
+
 		NumArchiveSystemWorkflows: dc.GetIntProperty(dynamicconfig.NumArchiveSystemWorkflows, 1000),
 		ArchiveRequestRPS:         dc.GetIntProperty(dynamicconfig.ArchiveRequestRPS, 300), // should be much smaller than frontend RPS
 
@@ -239,6 +351,10 @@ func NewConfig(dc *dynamicconfig.Collection, numberOfShards int, enableVisibilit
 		HistorySizeLimitWarn:   dc.GetIntPropertyFilteredByDomain(dynamicconfig.HistorySizeLimitWarn, 50*1024*1024),
 		HistoryCountLimitError: dc.GetIntPropertyFilteredByDomain(dynamicconfig.HistoryCountLimitError, 200*1024),
 		HistoryCountLimitWarn:  dc.GetIntPropertyFilteredByDomain(dynamicconfig.HistoryCountLimitWarn, 50*1024),
+		HistoryLimitTerminate:  dc.GetBoolPropertyFilteredByDomain(dynamicconfig.HistoryLimitTerminate, false),
+
+		HistorySizeSuggestContinueAsNew:  dc.GetIntPropertyFilteredByDomain(dynamicconfig.HistorySizeSuggestContinueAsNew, 40*1024*1024),
+		HistoryCountSuggestContinueAsNew: dc.GetIntPropertyFilteredByDomain(dynamicconfig.HistoryCountSuggestContinueAsNew, 40*1024),
 
 		ThrottledLogRPS: dc.GetIntProperty(dynamicconfig.HistoryThrottledLogRPS, 20),
 
@@ -246,6 +362,10 @@ func NewConfig(dc *dynamicconfig.Collection, numberOfShards int, enableVisibilit
 		SearchAttributesNumberOfKeysLimit: dc.GetIntPropertyFilteredByDomain(dynamicconfig.SearchAttributesNumberOfKeysLimit, 100),
 		SearchAttributesSizeOfValueLimit:  dc.GetIntPropertyFilteredByDomain(dynamicconfig.SearchAttributesSizeOfValueLimit, 2*1024),
 		SearchAttributesTotalSizeLimit:    dc.GetIntPropertyFilteredByDomain(dynamicconfig.SearchAttributesTotalSizeLimit, 40*1024),
+
+		ActivityRetryMaximumAttemptsOverride:    dc.GetIntPropertyFilteredByActivityType(dynamicconfig.ActivityRetryMaximumAttemptsOverride, 0),
+		ActivityRetryMaximumIntervalOverride:    dc.GetDurationPropertyFilteredByActivityType(dynamicconfig.ActivityRetryMaximumIntervalOverride, 0),
+		ActivityRetryNonRetriableErrorsOverride: dc.GetStringPropertyFilteredByActivityType(dynamicconfig.ActivityRetryNonRetriableErrorsOverride, ""),
 	}
 
 	return cfg
@@ -296,10 +416,12 @@ func (s *Service) Start() {
 	pConfig.HistoryMaxConns = s.config.HistoryMgrNumConns()
 	pConfig.SetMaxQPS(pConfig.DefaultStore, s.config.PersistenceMaxQPS())
 	pConfig.VisibilityConfig = &config.VisibilityConfig{
-		VisibilityOpenMaxQPS:            s.config.VisibilityOpenMaxQPS,
-		VisibilityClosedMaxQPS:          s.config.VisibilityClosedMaxQPS,
-		EnableSampling:                  s.config.EnableVisibilitySampling,
-		EnableReadFromClosedExecutionV2: s.config.EnableReadFromClosedExecutionV2,
+		VisibilityOpenMaxQPS:                   s.config.VisibilityOpenMaxQPS,
+		VisibilityClosedMaxQPS:                 s.config.VisibilityClosedMaxQPS,
+		EnableSampling:                         s.config.EnableVisibilitySampling,
+		EnableReadFromClosedExecutionV2:        s.config.EnableReadFromClosedExecutionV2,
+		EnableReadFromOpenExecutionsByDomain:   s.config.EnableReadFromOpenExecutionsByDomain,
+		EnableReadFromClosedExecutionsByFilter: s.config.EnableReadFromClosedExecutionsByFilter,
 	}
 	pFactory := persistencefactory.New(&pConfig, params.ClusterMetadata.GetCurrentClusterName(), s.metricsClient, log)
 
@@ -326,6 +448,13 @@ func (s *Service) Start() {
 		}
 		esVisibility = espersistence.NewESVisibilityManager("", nil, nil, visibilityProducer,
 			s.metricsClient, log)
+	} else if params.PinotConfig != nil && params.PinotConfig.Enable {
+		visibilityProducer, err := s.params.MessagingClient.NewProducer(common.PinotVisibilityAppName)
+		if err != nil {
+			log.Fatal("Creating pinot visibility producer failed", tag.Error(err))
+		}
+		esVisibility = pinotpersistence.NewPinotVisibilityManager("", nil, nil, visibilityProducer,
+			s.metricsClient, log)
 	}
 	visibility = persistence.NewVisibilityManagerWrapper(visibility, esVisibility, dynamicconfig.GetBoolPropertyFnFilteredByDomain(false))
 
@@ -339,7 +468,15 @@ func (s *Service) Start() {
 		log.Fatal("Creating historyV2 manager persistence failed", tag.Error(err))
 	}
 
-	handler := NewHandler(base, s.config, shardMgr, metadata, visibility, history, historyV2, pFactory, params.PublicClient, params.ArchiverProvider)
+	var executionMgrFactory persistence.ExecutionManagerFactory = pFactory
+	if params.PayloadInspector != nil {
+		visibility = persistence.NewVisibilityPersistencePayloadInspectionClient(visibility, params.PayloadInspector)
+		history = persistence.NewHistoryPersistencePayloadInspectionClient(history, params.PayloadInspector)
+		historyV2 = persistence.NewHistoryV2PersistencePayloadInspectionClient(historyV2, params.PayloadInspector)
+		executionMgrFactory = persistence.NewExecutionManagerFactoryPayloadInspectionClient(pFactory, params.PayloadInspector)
+	}
+
+	handler := NewHandler(base, s.config, shardMgr, metadata, visibility, history, historyV2, executionMgrFactory, params.PublicClient, params.ArchiverProvider)
 	handler.RegisterHandler()
 
 	// must start base service first
@@ -22,6 +22,7 @@ package history
 
 import (
 	"fmt"
+	"math"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -58,10 +59,18 @@ type (
 		UpdateTransferClusterAckLevel(cluster string, ackLevel int64) error
 		GetReplicatorAckLevel() int64
 		UpdateReplicatorAckLevel(ackLevel int64) error
+		GetVisibilityAckLevel() int64
+		UpdateVisibilityAckLevel(ackLevel int64) error
 		GetTimerAckLevel() time.Time
 		UpdateTimerAckLevel(ackLevel time.Time) error
 		GetTimerClusterAckLevel(cluster string) time.Time
 		UpdateTimerClusterAckLevel(cluster string, ackLevel time.Time) error
+		GetQueueAlarms() map[string]persistence.QueueAlarm
+		UpdateQueueAlarm(queue string, alarm *persistence.QueueAlarm) error
+		GetHotExecutions() []persistence.HotExecution
+		UpdateHotExecutions(executions []persistence.HotExecution)
+		RecordTaskProcessed()
+		GetTasksPerSecond() float64
 		UpdateTransferFailoverLevel(failoverID string, level persistence.TransferFailoverLevel) error
 		DeleteTransferFailoverLevel(failoverID string) error
 		GetAllTransferFailoverLevels() map[string]persistence.TransferFailoverLevel
@@ -70,6 +79,9 @@ type (
 		GetAllTimerFailoverLevels() map[string]persistence.TimerFailoverLevel
 		GetDomainNotificationVersion() int64
 		UpdateDomainNotificationVersion(domainNotificationVersion int64) error
+		GetDomainOpenExecutionCount(domainID string) int64
+		StartOpenExecutionCountsScan()
+		ReconcileDomainOpenExecutionCounts(counts map[string]int64) error
 		CreateWorkflowExecution(request *persistence.CreateWorkflowExecutionRequest) (
 			*persistence.CreateWorkflowExecutionResponse, error)
 		UpdateWorkflowExecution(request *persistence.UpdateWorkflowExecutionRequest) (*persistence.UpdateWorkflowExecutionResponse, error)
@@ -96,6 +108,7 @@ type (
 		clusterMetadata  cluster.Metadata
 		service          service.Service
 		rangeID          int64
+		tasksProcessed   int64
 		shardManager     persistence.ShardManager
 		historyMgr       persistence.HistoryManager
 		historyV2Mgr     persistence.HistoryV2Manager
@@ -112,6 +125,7 @@ type (
 
 		sync.RWMutex
 		lastUpdated               time.Time
+		taskThroughputUpdatedAt   time.Time
 		shardInfo                 *persistence.ShardInfo
 		transferSequenceNumber    int64
 		maxTransferSequenceNumber int64
@@ -120,11 +134,16 @@ type (
 
 		// exist only in memory
 		standbyClusterCurrentTime map[string]time.Time
+		// changes of the open execution counters since the scan of the reconciler started,
+		// nil when no scan is in progress
+		openExecutionCountDeltas map[string]int64
 	}
 )
 
 var _ ShardContext = (*shardContextImpl)(nil)
 
+var errOpenExecutionCountsScanNotStarted = &shared.BadRequestError{Message: "Open execution counts scan not started on the shard."}
+
 const (
 	logWarnTransferLevelDiff = 3000000 // 3 million
 	logWarnTimerLevelDiff    = time.Duration(30 * time.Minute)
@@ -240,6 +259,21 @@ func (s *shardContextImpl) UpdateReplicatorAckLevel(ackLevel int64) error {
 	return s.updateShardInfoLocked()
 }
 
+func (s *shardContextImpl) GetVisibilityAckLevel() int64 {
+	s.RLock()
+	defer s.RUnlock()
+
+	return s.shardInfo.VisibilityAckLevel
+}
+
+func (s *shardContextImpl) UpdateVisibilityAckLevel(ackLevel int64) error {
+	s.Lock()
+	defer s.Unlock()
+	s.shardInfo.VisibilityAckLevel = ackLevel
+	s.shardInfo.StolenSinceRenew = 0
+	return s.updateShardInfoLocked()
+}
+
 func (s *shardContextImpl) GetTimerAckLevel() time.Time {
 	s.RLock()
 	defer s.RUnlock()
@@ -278,6 +312,64 @@ func (s *shardContextImpl) UpdateTimerClusterAckLevel(cluster string, ackLevel t
 	return s.updateShardInfoLocked()
 }
 
+func (s *shardContextImpl) GetQueueAlarms() map[string]persistence.QueueAlarm {
+	s.RLock()
+	defer s.RUnlock()
+
+	alarms := make(map[string]persistence.QueueAlarm, len(s.shardInfo.QueueAlarms))
+	for queue, alarm := range s.shardInfo.QueueAlarms {
+		alarms[queue] = alarm
+	}
+	return alarms
+}
+
+func (s *shardContextImpl) UpdateQueueAlarm(queue string, alarm *persistence.QueueAlarm) error {
+	s.Lock()
+	defer s.Unlock()
+
+	if alarm == nil {
+		if _, ok := s.shardInfo.QueueAlarms[queue]; !ok {
+			return nil
+		}
+		delete(s.shardInfo.QueueAlarms, queue)
+	} else {
+		if s.shardInfo.QueueAlarms == nil {
+			s.shardInfo.QueueAlarms = make(map[string]persistence.QueueAlarm)
+		}
+		s.shardInfo.QueueAlarms[queue] = *alarm
+	}
+	return s.updateShardInfoLocked()
+}
+
+func (s *shardContextImpl) GetHotExecutions() []persistence.HotExecution {
+	s.RLock()
+	defer s.RUnlock()
+
+	return append([]persistence.HotExecution(nil), s.shardInfo.HotExecutions...)
+}
+
+// UpdateHotExecutions only changes the in memory hot set, it is persisted along with the next
+// shard info update
+func (s *shardContextImpl) UpdateHotExecutions(executions []persistence.HotExecution) {
+	s.Lock()
+	defer s.Unlock()
+
+	s.shardInfo.HotExecutions = executions
+}
+
+// RecordTaskProcessed counts a task processed by one of the queues of the shard, the count is folded
+// into the rolling task rate of the shard along with the next shard info update
+func (s *shardContextImpl) RecordTaskProcessed() {
+	atomic.AddInt64(&s.tasksProcessed, 1)
+}
+
+func (s *shardContextImpl) GetTasksPerSecond() float64 {
+	s.RLock()
+	defer s.RUnlock()
+
+	return s.shardInfo.TasksPerSecond
+}
+
 func (s *shardContextImpl) UpdateTransferFailoverLevel(failoverID string, level persistence.TransferFailoverLevel) error {
 	s.Lock()
 	defer s.Unlock()
@@ -353,6 +445,67 @@ func (s *shardContextImpl) UpdateDomainNotificationVersion(domainNotificationVer
 	return s.updateShardInfoLocked()
 }
 
+func (s *shardContextImpl) GetDomainOpenExecutionCount(domainID string) int64 {
+	s.RLock()
+	defer s.RUnlock()
+
+	return s.shardInfo.DomainOpenExecutionCounts[domainID]
+}
+
+// StartOpenExecutionCountsScan starts tracking the changes of the open execution counters, so that the
+// counts of the scan can be reconciled without losing the executions opened or closed during the scan
+func (s *shardContextImpl) StartOpenExecutionCountsScan() {
+	s.Lock()
+	defer s.Unlock()
+
+	s.openExecutionCountDeltas = make(map[string]int64)
+}
+
+// ReconcileDomainOpenExecutionCounts replaces the open execution counters of the scanned domains with their counts
+// plus the changes made since the scan started, the counters of the other domains are left alone
+func (s *shardContextImpl) ReconcileDomainOpenExecutionCounts(counts map[string]int64) error {
+	s.Lock()
+	defer s.Unlock()
+
+	if s.openExecutionCountDeltas == nil {
+		// the shard was reloaded since the scan started, the changes made during the scan are unknown
+		return errOpenExecutionCountsScanNotStarted
+	}
+	if s.shardInfo.DomainOpenExecutionCounts == nil {
+		s.shardInfo.DomainOpenExecutionCounts = make(map[string]int64)
+	}
+	for domainID, count := range counts {
+		count += s.openExecutionCountDeltas[domainID]
+		if count > 0 {
+			s.shardInfo.DomainOpenExecutionCounts[domainID] = count
+		} else {
+			delete(s.shardInfo.DomainOpenExecutionCounts, domainID)
+		}
+	}
+	s.openExecutionCountDeltas = nil
+	return s.updateShardInfoLocked()
+}
+
+// updateDomainOpenExecutionCountLocked only changes the in memory counter, it is persisted
+// along with the next shard info update
+func (s *shardContextImpl) updateDomainOpenExecutionCountLocked(domainID string, delta int64) {
+	if delta == 0 {
+		return
+	}
+	if s.openExecutionCountDeltas != nil {
+		s.openExecutionCountDeltas[domainID] += delta
+	}
+	if s.shardInfo.DomainOpenExecutionCounts == nil {
+		s.shardInfo.DomainOpenExecutionCounts = make(map[string]int64)
+	}
+	count := s.shardInfo.DomainOpenExecutionCounts[domainID] + delta
+	if count <= 0 {
+		delete(s.shardInfo.DomainOpenExecutionCounts, domainID)
+		return
+	}
+	s.shardInfo.DomainOpenExecutionCounts[domainID] = count
+}
+
 func (s *shardContextImpl) GetTimerMaxReadLevel(cluster string) time.Time {
 	s.RLock()
 	defer s.RUnlock()
@@ -393,11 +546,13 @@ func (s *shardContextImpl) CreateWorkflowExecution(
 	// assign IDs for the transfer tasks
 	// Must be done under the shard lock to ensure transfer tasks are written to persistence in increasing
 	// ID order
+	s.splitVisibilityTasks(&request.NewWorkflowSnapshot.TransferTasks, &request.NewWorkflowSnapshot.VisibilityTasks)
 	if err := s.allocateTaskIDsLocked(
 		domainEntry,
 		workflowID,
 		request.NewWorkflowSnapshot.TransferTasks,
 		request.NewWorkflowSnapshot.ReplicationTasks,
+		request.NewWorkflowSnapshot.VisibilityTasks,
 		request.NewWorkflowSnapshot.TimerTasks,
 		&transferMaxReadLevel,
 	); err != nil {
@@ -411,6 +566,9 @@ Create_Loop:
 		request.RangeID = currentRangeID
 
 		response, err := s.executionManager.CreateWorkflowExecution(request)
+		if err == nil && request.NewWorkflowSnapshot.ExecutionInfo.State != persistence.WorkflowStateCompleted {
+			s.updateDomainOpenExecutionCountLocked(domainID, 1)
+		}
 		if err != nil {
 			switch err.(type) {
 			case *shared.WorkflowExecutionAlreadyStartedError,
@@ -476,22 +634,26 @@ func (s *shardContextImpl) UpdateWorkflowExecution(request *persistence.UpdateWo
 	// assign IDs for the transfer tasks
 	// Must be done under the shard lock to ensure transfer tasks are written to persistence in increasing
 	// ID order
+	s.splitVisibilityTasks(&request.UpdateWorkflowMutation.TransferTasks, &request.UpdateWorkflowMutation.VisibilityTasks)
 	if err := s.allocateTaskIDsLocked(
 		domainEntry,
 		workflowID,
 		request.UpdateWorkflowMutation.TransferTasks,
 		request.UpdateWorkflowMutation.ReplicationTasks,
+		request.UpdateWorkflowMutation.VisibilityTasks,
 		request.UpdateWorkflowMutation.TimerTasks,
 		&transferMaxReadLevel,
 	); err != nil {
 		return nil, err
 	}
 	if request.NewWorkflowSnapshot != nil {
+		s.splitVisibilityTasks(&request.NewWorkflowSnapshot.TransferTasks, &request.NewWorkflowSnapshot.VisibilityTasks)
 		if err := s.allocateTaskIDsLocked(
 			domainEntry,
 			workflowID,
 			request.NewWorkflowSnapshot.TransferTasks,
 			request.NewWorkflowSnapshot.ReplicationTasks,
+			request.NewWorkflowSnapshot.VisibilityTasks,
 			request.NewWorkflowSnapshot.TimerTasks,
 			&transferMaxReadLevel,
 		); err != nil {
@@ -505,6 +667,13 @@ Update_Loop:
 		currentRangeID := s.getRangeID()
 		request.RangeID = currentRangeID
 		resp, err := s.executionManager.UpdateWorkflowExecution(request)
+		if err == nil {
+			delta := -countCloseExecutionTasks(request.UpdateWorkflowMutation.TransferTasks, request.UpdateWorkflowMutation.VisibilityTasks)
+			if request.NewWorkflowSnapshot != nil {
+				delta++
+			}
+			s.updateDomainOpenExecutionCountLocked(domainID, delta)
+		}
 		if err != nil {
 			switch err.(type) {
 			case *persistence.ConditionFailedError,
@@ -564,22 +733,26 @@ func (s *shardContextImpl) ResetWorkflowExecution(request *persistence.ResetWork
 	// assign IDs for the transfer/replication tasks
 	// Must be done under the shard lock to ensure transfer tasks are written to persistence in increasing
 	// ID order
+	s.splitVisibilityTasks(&request.NewWorkflowSnapshot.TransferTasks, &request.NewWorkflowSnapshot.VisibilityTasks)
 	if err := s.allocateTaskIDsLocked(
 		domainEntry,
 		workflowID,
 		request.NewWorkflowSnapshot.TransferTasks,
 		request.NewWorkflowSnapshot.ReplicationTasks,
+		request.NewWorkflowSnapshot.VisibilityTasks,
 		request.NewWorkflowSnapshot.TimerTasks,
 		&transferMaxReadLevel,
 	); err != nil {
 		return err
 	}
 	if request.CurrentWorkflowMutation != nil {
+		s.splitVisibilityTasks(&request.CurrentWorkflowMutation.TransferTasks, &request.CurrentWorkflowMutation.VisibilityTasks)
 		if err := s.allocateTaskIDsLocked(
 			domainEntry,
 			workflowID,
 			request.CurrentWorkflowMutation.TransferTasks,
 			request.CurrentWorkflowMutation.ReplicationTasks,
+			request.CurrentWorkflowMutation.VisibilityTasks,
 			request.CurrentWorkflowMutation.TimerTasks,
 			&transferMaxReadLevel,
 		); err != nil {
@@ -593,6 +766,13 @@ Reset_Loop:
 		currentRangeID := s.getRangeID()
 		request.RangeID = currentRangeID
 		err := s.executionManager.ResetWorkflowExecution(request)
+		if err == nil {
+			delta := int64(1)
+			if request.CurrentWorkflowMutation != nil {
+				delta -= countCloseExecutionTasks(request.CurrentWorkflowMutation.TransferTasks, request.CurrentWorkflowMutation.VisibilityTasks)
+			}
+			s.updateDomainOpenExecutionCountLocked(domainID, delta)
+		}
 		if err != nil {
 			switch err.(type) {
 			case *persistence.ConditionFailedError,
@@ -653,22 +833,26 @@ func (s *shardContextImpl) ResetMutableState(request *persistence.ResetMutableSt
 	// assign IDs for the transfer/replication tasks
 	// Must be done under the shard lock to ensure transfer tasks are written to persistence in increasing
 	// ID order
+	s.splitVisibilityTasks(&request.ResetWorkflowSnapshot.TransferTasks, &request.ResetWorkflowSnapshot.VisibilityTasks)
 	if err := s.allocateTaskIDsLocked(
 		domainEntry,
 		workflowID,
 		request.ResetWorkflowSnapshot.TransferTasks,
 		request.ResetWorkflowSnapshot.ReplicationTasks,
+		request.ResetWorkflowSnapshot.VisibilityTasks,
 		request.ResetWorkflowSnapshot.TimerTasks,
 		&transferMaxReadLevel,
 	); err != nil {
 		return err
 	}
 	if request.CurrentWorkflowMutation != nil {
+		s.splitVisibilityTasks(&request.CurrentWorkflowMutation.TransferTasks, &request.CurrentWorkflowMutation.VisibilityTasks)
 		if err := s.allocateTaskIDsLocked(
 			domainEntry,
 			workflowID,
 			request.CurrentWorkflowMutation.TransferTasks,
 			request.CurrentWorkflowMutation.ReplicationTasks,
+			request.CurrentWorkflowMutation.VisibilityTasks,
 			request.CurrentWorkflowMutation.TimerTasks,
 			&transferMaxReadLevel,
 		); err != nil {
@@ -731,6 +915,7 @@ func (s *shardContextImpl) AppendHistoryV2Events(
 	}
 	request.Encoding = s.getDefaultEncoding(domainEntry)
 	request.ShardID = common.IntPtr(s.shardID)
+	request.DomainID = domainID
 	size := 0
 	defer func() {
 		// N.B. - Dual emit here makes sense so that we can see aggregate timer stats across all
@@ -853,6 +1038,30 @@ func (s *shardContextImpl) closeShard() {
 	}
 }
 
+// release waits for the in-flight writes of the shard, persists its ack levels and clears the
+// shard owner so the next owner does not report the move as a steal. Writes started after
+// release fail as the shard is closed.
+func (s *shardContextImpl) release() error {
+	s.Lock()
+	defer s.Unlock()
+
+	if s.isClosed {
+		return nil
+	}
+
+	updatedShardInfo := copyShardInfo(s.shardInfo)
+	updatedShardInfo.Owner = ""
+	err := s.shardManager.UpdateShard(&persistence.UpdateShardRequest{
+		ShardInfo:       updatedShardInfo,
+		PreviousRangeID: s.shardInfo.RangeID,
+	})
+
+	s.isClosed = true
+	s.shardInfo.RangeID = -1
+	atomic.StoreInt64(&s.rangeID, s.shardInfo.RangeID)
+	return err
+}
+
 func (s *shardContextImpl) getNextTransferTaskIDLocked() (int64, error) {
 	if err := s.updateRangeIfNeededLocked(); err != nil {
 		return -1, err
@@ -912,6 +1121,34 @@ func (s *shardContextImpl) renewRangeLocked(isStealing bool) error {
 	return nil
 }
 
+// renewLease persists a new lease expiry hint for the shard, so that a host acquiring the shard
+// can tell whether this host is gone or still alive
+func (s *shardContextImpl) renewLease() error {
+	s.Lock()
+	defer s.Unlock()
+
+	if s.isClosed {
+		return nil
+	}
+
+	updatedShardInfo := copyShardInfo(s.shardInfo)
+	updatedShardInfo.LeaseExpiresAt = s.timeSource.Now().Add(s.config.ShardLeaseTTL())
+	err := s.shardManager.UpdateShard(&persistence.UpdateShardRequest{
+		ShardInfo:       updatedShardInfo,
+		PreviousRangeID: s.shardInfo.RangeID,
+	})
+	if err != nil {
+		// Shard is stolen, trigger history engine shutdown
+		if _, ok := err.(*persistence.ShardOwnershipLostError); ok {
+			s.closeShard()
+		}
+		return err
+	}
+
+	s.shardInfo.LeaseExpiresAt = updatedShardInfo.LeaseExpiresAt
+	return nil
+}
+
 func (s *shardContextImpl) updateMaxReadLevelLocked(rl int64) {
 	if rl > s.transferMaxReadLevel {
 		s.logger.Debug(fmt.Sprintf("Updating MaxReadLevel: %v", rl))
@@ -925,6 +1162,7 @@ func (s *shardContextImpl) updateShardInfoLocked() error {
 	if s.lastUpdated.Add(s.config.ShardUpdateMinInterval()).After(now) {
 		return nil
 	}
+	s.updateTaskThroughputLocked(now)
 	updatedShardInfo := copyShardInfo(s.shardInfo)
 	s.emitShardInfoMetricsLogsLocked()
 
@@ -945,6 +1183,26 @@ func (s *shardContextImpl) updateShardInfoLocked() error {
 	return err
 }
 
+// updateTaskThroughputLocked folds the tasks processed since the previous call into the rolling task
+// rate of the shard, an exponentially weighted moving average over the task throughput window
+func (s *shardContextImpl) updateTaskThroughputLocked(now time.Time) {
+	elapsed := now.Sub(s.taskThroughputUpdatedAt)
+	if elapsed <= 0 {
+		return
+	}
+
+	processed := atomic.SwapInt64(&s.tasksProcessed, 0)
+	rate := float64(processed) / elapsed.Seconds()
+	weight := 1.0
+	if window := s.config.ShardTaskThroughputWindow(); window > 0 {
+		weight = 1 - math.Exp(-float64(elapsed)/float64(window))
+	}
+	s.shardInfo.TasksPerSecond += weight * (rate - s.shardInfo.TasksPerSecond)
+	s.shardInfo.TaskThroughputUpdatedAt = now
+	s.taskThroughputUpdatedAt = now
+	s.metricsClient.UpdateGauge(metrics.ShardInfoScope, metrics.ShardInfoTasksPerSecondGauge, s.shardInfo.TasksPerSecond)
+}
+
 func (s *shardContextImpl) emitShardInfoMetricsLogsLocked() {
 	currentCluster := s.clusterMetadata.GetCurrentClusterName()
 
@@ -1010,6 +1268,7 @@ func (s *shardContextImpl) allocateTaskIDsLocked(
 	workflowID string,
 	transferTasks []persistence.Task,
 	replicationTasks []persistence.Task,
+	visibilityTasks []persistence.Task,
 	timerTasks []persistence.Task,
 	transferMaxReadLevel *int64,
 ) error {
@@ -1024,12 +1283,57 @@ func (s *shardContextImpl) allocateTaskIDsLocked(
 		transferMaxReadLevel); err != nil {
 		return err
 	}
+	if err := s.allocateTransferIDsLocked(
+		visibilityTasks,
+		transferMaxReadLevel); err != nil {
+		return err
+	}
 	return s.allocateTimerIDsLocked(
 		domainEntry,
 		workflowID,
 		timerTasks)
 }
 
+// splitVisibilityTasks moves visibility records out of the transfer tasks and into
+// dedicated visibility tasks when the visibility task queue is enabled. The decision is
+// made once here, when the tasks are created: a task is processed by the queue it was
+// written to, so flipping the flag never drops nor duplicates a visibility record.
+func (s *shardContextImpl) splitVisibilityTasks(
+	transferTasks *[]persistence.Task,
+	visibilityTasks *[]persistence.Task,
+) {
+
+	if !s.config.EnableVisibilityTaskQueue() {
+		return
+	}
+
+	remaining := make([]persistence.Task, 0, len(*transferTasks))
+	for _, task := range *transferTasks {
+		switch task.GetType() {
+		case persistence.TransferTaskTypeRecordWorkflowStarted:
+			*visibilityTasks = append(*visibilityTasks, &persistence.RecordWorkflowStartedVisibilityTask{
+				VisibilityTimestamp: task.GetVisibilityTimestamp(),
+				Version:             task.GetVersion(),
+			})
+			continue
+		case persistence.TransferTaskTypeUpsertWorkflowSearchAttributes:
+			*visibilityTasks = append(*visibilityTasks, &persistence.UpsertWorkflowSearchAttributesVisibilityTask{
+				VisibilityTimestamp: task.GetVisibilityTimestamp(),
+				Version:             task.GetVersion(),
+			})
+			continue
+		case persistence.TransferTaskTypeCloseExecution:
+			*visibilityTasks = append(*visibilityTasks, &persistence.CloseExecutionVisibilityTask{
+				VisibilityTimestamp: task.GetVisibilityTimestamp(),
+				Version:             task.GetVersion(),
+			})
+			continue
+		}
+		remaining = append(remaining, task)
+	}
+	*transferTasks = remaining
+}
+
 func (s *shardContextImpl) allocateTransferIDsLocked(
 	tasks []persistence.Task,
 	transferMaxReadLevel *int64,
@@ -1117,8 +1421,10 @@ func (s *shardContextImpl) GetCurrentTime(cluster string) time.Time {
 	return s.GetTimeSource().Now()
 }
 
-func acquireShard(shardItem *historyShardsItem, closeCh chan<- int) (ShardContext,
-	error) {
+// acquireShard loads the shard, when waitForLease is set and the shard is owned by another host whose lease
+// has not expired it returns how long to wait for the lease instead
+func acquireShard(shardItem *historyShardsItem, closeCh chan<- int, waitForLease bool) (*shardContextImpl,
+	time.Duration, error) {
 
 	var shardInfo *persistence.ShardInfo
 
@@ -1159,7 +1465,18 @@ func acquireShard(shardItem *historyShardsItem, closeCh chan<- int) (ShardContex
 	err := backoff.Retry(getShard, retryPolicy, retryPredicate)
 	if err != nil {
 		shardItem.logger.Error("Fail to acquire shard.", tag.ShardID(shardItem.shardID), tag.Error(err))
-		return nil, err
+		return nil, 0, err
+	}
+
+	if waitForLease {
+		now := shardItem.service.GetTimeSource().Now()
+		if delay := shardStealDelay(shardInfo, shardItem.host.Identity(), now, shardItem.config.ShardLeaseStealTimeout()); delay > 0 {
+			shardItem.logger.Info("Waiting for the shard lease of the previous owner to expire.",
+				tag.ShardID(shardItem.shardID),
+				tag.PreviousShardOwner(shardInfo.Owner),
+				tag.Timestamp(shardInfo.LeaseExpiresAt))
+			return nil, delay, nil
+		}
 	}
 
 	updatedShardInfo := copyShardInfo(shardInfo)
@@ -1206,14 +1523,66 @@ func acquireShard(shardItem *historyShardsItem, closeCh chan<- int) (ShardContex
 	}
 	context.logger = shardItem.logger
 	context.throttledLogger = shardItem.throttledLogger
+	context.taskThroughputUpdatedAt = context.timeSource.Now()
 	context.eventsCache = newEventsCache(context)
 
 	err1 := context.renewRangeLocked(true)
 	if err1 != nil {
-		return nil, err1
+		return nil, 0, err1
 	}
 
-	return context, nil
+	context.recordShardAcquired(shardInfo.Owner)
+	return context, 0, nil
+}
+
+// shardStealDelay returns how long a host acquiring a shard owned by another host waits for the lease
+// of that owner to expire, so that a shard is stolen from a live owner only after the steal timeout
+func shardStealDelay(
+	shardInfo *persistence.ShardInfo,
+	owner string,
+	now time.Time,
+	stealTimeout time.Duration,
+) time.Duration {
+
+	if stealTimeout <= 0 || shardInfo.Owner == "" || shardInfo.Owner == owner {
+		return 0
+	}
+
+	delay := shardInfo.LeaseExpiresAt.Sub(now)
+	if delay <= 0 {
+		return 0
+	}
+	if delay > stealTimeout {
+		return stealTimeout
+	}
+	return delay
+}
+
+// recordShardAcquired reports the ownership change of a newly acquired shard, a shard
+// acquired from another host is a steal and a high stolen since renew count indicates
+// the shard is flapping between hosts
+func (s *shardContextImpl) recordShardAcquired(previousOwner string) {
+	owner := s.shardItem.host.Identity()
+	stolenSinceRenew := s.shardInfo.StolenSinceRenew
+
+	if previousOwner != "" && previousOwner != owner {
+		s.metricsClient.IncCounter(metrics.HistoryShardControllerScope, metrics.ShardStolenCounter)
+	}
+	s.metricsClient.UpdateGauge(metrics.ShardInfoScope, metrics.ShardInfoStolenSinceRenewGauge, float64(stolenSinceRenew))
+	s.logger.Info("Shard acquired",
+		tag.ShardID(s.shardID),
+		tag.ShardRangeID(s.shardInfo.RangeID),
+		tag.PreviousShardOwner(previousOwner),
+		tag.Counter(stolenSinceRenew))
+
+	s.shardItem.movements.record(&shared.ShardMovement{
+		ShardID:          common.Int32Ptr(int32(s.shardID)),
+		PreviousOwner:    common.StringPtr(previousOwner),
+		Owner:            common.StringPtr(owner),
+		RangeID:          common.Int64Ptr(s.shardInfo.RangeID),
+		StolenSinceRenew: common.Int32Ptr(int32(stolenSinceRenew)),
+		Timestamp:        common.Int64Ptr(s.timeSource.Now().UnixNano()),
+	})
 }
 
 func copyShardInfo(shardInfo *persistence.ShardInfo) *persistence.ShardInfo {
@@ -1233,12 +1602,22 @@ func copyShardInfo(shardInfo *persistence.ShardInfo) *persistence.ShardInfo {
 	for k, v := range shardInfo.ClusterTimerAckLevel {
 		clusterTimerAckLevel[k] = v
 	}
+	domainOpenExecutionCounts := make(map[string]int64)
+	for k, v := range shardInfo.DomainOpenExecutionCounts {
+		domainOpenExecutionCounts[k] = v
+	}
+	queueAlarms := make(map[string]persistence.QueueAlarm)
+	for k, v := range shardInfo.QueueAlarms {
+		queueAlarms[k] = v
+	}
+	hotExecutions := append([]persistence.HotExecution(nil), shardInfo.HotExecutions...)
 	shardInfoCopy := &persistence.ShardInfo{
 		ShardID:                   shardInfo.ShardID,
 		Owner:                     shardInfo.Owner,
 		RangeID:                   shardInfo.RangeID,
 		StolenSinceRenew:          shardInfo.StolenSinceRenew,
 		ReplicationAckLevel:       shardInfo.ReplicationAckLevel,
+		VisibilityAckLevel:        shardInfo.VisibilityAckLevel,
 		TransferAckLevel:          shardInfo.TransferAckLevel,
 		TimerAckLevel:             shardInfo.TimerAckLevel,
 		TransferFailoverLevels:    transferFailoverLevels,
@@ -1246,7 +1625,32 @@ func copyShardInfo(shardInfo *persistence.ShardInfo) *persistence.ShardInfo {
 		ClusterTransferAckLevel:   clusterTransferAckLevel,
 		ClusterTimerAckLevel:      clusterTimerAckLevel,
 		DomainNotificationVersion: shardInfo.DomainNotificationVersion,
+		DomainOpenExecutionCounts: domainOpenExecutionCounts,
+		QueueAlarms:               queueAlarms,
+		HotExecutions:             hotExecutions,
+		LeaseExpiresAt:            shardInfo.LeaseExpiresAt,
+		TasksPerSecond:            shardInfo.TasksPerSecond,
+		TaskThroughputUpdatedAt:   shardInfo.TaskThroughputUpdatedAt,
+		Placement:                 shardInfo.Placement,
+		TransferTaskBuckets:       shardInfo.TransferTaskBuckets,
 	}
 
 	return shardInfoCopy
 }
+
+// countCloseExecutionTasks counts the closed executions, whose close task is either on the transfer queue
+// or on the visibility queue depending on where splitVisibilityTasks put it
+func countCloseExecutionTasks(transferTasks []persistence.Task, visibilityTasks []persistence.Task) int64 {
+	count := int64(0)
+	for _, task := range transferTasks {
+		if _, ok := task.(*persistence.CloseExecutionTask); ok {
+			count++
+		}
+	}
+	for _, task := range visibilityTasks {
+		if _, ok := task.(*persistence.CloseExecutionVisibilityTask); ok {
+			count++
+		}
+	}
+	return count
+}
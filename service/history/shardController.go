@@ -22,10 +22,12 @@ package history
 
 import (
 	"fmt"
+	"math/rand"
 	"sync"
 	"sync/atomic"
 	"time"
 
+	workflow "github.com/uber/cadence/.gen/go/shared"
 	"github.com/uber/cadence/common"
 	"github.com/uber/cadence/common/cache"
 	"github.com/uber/cadence/common/log"
@@ -65,6 +67,9 @@ type (
 		sync.RWMutex
 		historyShards map[int]*historyShardsItem
 		isStopping    bool
+		isDraining    bool
+		movements     *shardMovementHistory
+		placement     *shardPlacement
 	}
 
 	historyShardsItemStatus int
@@ -82,10 +87,17 @@ type (
 		engineFactory   EngineFactory
 		host            *membership.HostInfo
 		engine          Engine
+		shard           *shardContextImpl
 		config          *Config
 		logger          log.Logger
 		throttledLogger log.Logger
 		metricsClient   metrics.Client
+		movements       *shardMovementHistory
+		// leaseWaitUntil is when the lease of the previous owner of the shard expires, the shard
+		// is acquired once it has passed
+		leaseWaitUntil time.Time
+		// stoppedCh is closed when the item is stopped to interrupt the callers waiting for the lease
+		stoppedCh chan struct{}
 	}
 )
 
@@ -118,18 +130,26 @@ func newShardController(svc service.Service, host *membership.HostInfo, resolver
 		throttledLoggger:    svc.GetThrottledLogger(),
 		config:              config,
 		metricsClient:       metricsClient,
+		movements:           newShardMovementHistory(shardMovementHistorySize),
+		placement:           newShardPlacement(host, resolver, shardMgr, config, logger),
 	}
 }
 
 func newHistoryShardsItem(shardID int, svc service.Service, shardMgr persistence.ShardManager,
 	historyMgr persistence.HistoryManager, historyV2Mgr persistence.HistoryV2Manager, domainCache cache.DomainCache,
 	executionMgrFactory persistence.ExecutionManagerFactory, factory EngineFactory, host *membership.HostInfo,
-	config *Config, logger log.Logger, throttledLog log.Logger, metricsClient metrics.Client) (*historyShardsItem, error) {
+	config *Config, logger log.Logger, throttledLog log.Logger, metricsClient metrics.Client,
+	movements *shardMovementHistory) (*historyShardsItem, error) {
 
 	executionMgr, err := executionMgrFactory.NewExecutionManager(shardID)
 	if err != nil {
 		return nil, err
 	}
+	executionMgr = persistence.NewWorkflowExecutionPersistenceFreezeClient(
+		executionMgr,
+		newDomainFrozenFn(domainCache, config),
+		logger.WithTags(tag.ShardID(shardID)),
+	)
 
 	return &historyShardsItem{
 		service:         svc,
@@ -146,9 +166,23 @@ func newHistoryShardsItem(shardID int, svc service.Service, shardMgr persistence
 		logger:          logger.WithTags(tag.ShardID(shardID)),
 		throttledLogger: throttledLog.WithTags(tag.ShardID(shardID)),
 		metricsClient:   metricsClient,
+		movements:       movements,
+		stoppedCh:       make(chan struct{}),
 	}, nil
 }
 
+// newDomainFrozenFn resolves the domain name used by the dynamic config, writes of domains that
+// cannot be resolved are not frozen
+func newDomainFrozenFn(domainCache cache.DomainCache, config *Config) persistence.DomainFrozenFn {
+	return func(domainID string) bool {
+		domainEntry, err := domainCache.GetDomainByID(domainID)
+		if err != nil {
+			return false
+		}
+		return config.DomainExecutionsFrozen(domainEntry.GetInfo().Name)
+	}
+}
+
 func (c *shardController) Start() {
 	if !atomic.CompareAndSwapInt32(&c.isStarted, 0, 1) {
 		return
@@ -198,15 +232,23 @@ func (c *shardController) getEngineForShard(shardID int) (Engine, error) {
 	if err != nil {
 		return nil, err
 	}
-	return item.getOrCreateEngine(c.shardClosedCh)
+	return item.getOrCreateEngine(c.shardClosedCh, c.shutdownCh)
 }
 
-func (c *shardController) removeEngineForShard(shardID int) {
+// removeEngineForShard stops the engine of the shard, newOwner is the host the
+// shard moved to or empty if unknown
+func (c *shardController) removeEngineForShard(shardID int, newOwner string) {
 	sw := c.metricsClient.StartTimer(metrics.HistoryShardControllerScope, metrics.RemoveEngineForShardLatency)
 	defer sw.Stop()
 	item, _ := c.removeHistoryShardItem(shardID)
 	if item != nil {
 		item.stopEngine()
+		c.movements.record(&workflow.ShardMovement{
+			ShardID:       common.Int32Ptr(int32(shardID)),
+			PreviousOwner: common.StringPtr(c.host.Identity()),
+			Owner:         common.StringPtr(newOwner),
+			Timestamp:     common.Int64Ptr(c.service.GetTimeSource().Now().UnixNano()),
+		})
 	}
 }
 
@@ -234,14 +276,18 @@ func (c *shardController) getOrCreateHistoryShardItem(shardID int) (*historyShar
 	if c.isStopping {
 		return nil, fmt.Errorf("shardController for host '%v' shutting down", c.host.Identity())
 	}
-	info, err := c.hServiceResolver.Lookup(string(shardID))
+	if c.isDraining {
+		return nil, fmt.Errorf("shardController for host '%v' draining", c.host.Identity())
+	}
+	info, err := c.placement.lookup(shardID)
 	if err != nil {
 		return nil, err
 	}
 
 	if info.Identity() == c.host.Identity() {
 		shardItem, err := newHistoryShardsItem(shardID, c.service, c.shardMgr, c.historyMgr, c.historyV2Mgr, c.domainCache,
-			c.executionMgrFactory, c.engineFactory, c.host, c.config, c.logger, c.throttledLoggger, c.metricsClient)
+			c.executionMgrFactory, c.engineFactory, c.host, c.config, c.logger, c.throttledLoggger, c.metricsClient,
+			c.movements)
 		if err != nil {
 			return nil, err
 		}
@@ -287,6 +333,12 @@ func (c *shardController) shardManagementPump() {
 	acquireTicker := time.NewTicker(c.config.AcquireShardInterval())
 	defer acquireTicker.Stop()
 
+	leaseTimer := time.NewTimer(c.shardLeaseRenewDelay())
+	defer leaseTimer.Stop()
+
+	placementTicker := time.NewTicker(c.config.ShardPlacementRefreshInterval())
+	defer placementTicker.Stop()
+
 	for {
 
 		select {
@@ -295,6 +347,16 @@ func (c *shardController) shardManagementPump() {
 			return
 		case <-acquireTicker.C:
 			c.acquireShards()
+		case <-leaseTimer.C:
+			if c.config.ShardLeaseRenewInterval() > 0 {
+				c.renewShardLeases()
+			}
+			leaseTimer.Reset(c.shardLeaseRenewDelay())
+		case <-placementTicker.C:
+			if c.config.EnableLoadAwareShardPlacement() {
+				c.placement.refresh()
+				c.acquireShards()
+			}
 		case changedEvent := <-c.membershipUpdateCh:
 			c.metricsClient.IncCounter(metrics.HistoryShardControllerScope, metrics.MembershipChangedCounter)
 
@@ -303,11 +365,12 @@ func (c *shardController) shardManagementPump() {
 				tag.NumberProcessed(len(changedEvent.HostsAdded)),
 				tag.NumberDeleted(len(changedEvent.HostsRemoved)),
 				tag.Number(int64(len(changedEvent.HostsUpdated))))
+			c.placement.invalidate()
 			c.acquireShards()
 		case shardID := <-c.shardClosedCh:
 			c.metricsClient.IncCounter(metrics.HistoryShardControllerScope, metrics.ShardClosedCounter)
 			c.logger.Info("", tag.LifeCycleStopping, tag.ComponentShard, tag.ShardID(shardID), tag.Address(c.host.Identity()))
-			c.removeEngineForShard(shardID)
+			c.removeEngineForShard(shardID, "")
 			// The async close notifications can cause a race
 			// between acquire/release when nodes are flapping
 			// The impact of this race is un-necessary shard load/unloads
@@ -322,31 +385,124 @@ func (c *shardController) shardManagementPump() {
 
 func (c *shardController) acquireShards() {
 
+	if c.isDrainingHost() {
+		return
+	}
+
 	c.metricsClient.IncCounter(metrics.HistoryShardControllerScope, metrics.AcquireShardsCounter)
 	sw := c.metricsClient.StartTimer(metrics.HistoryShardControllerScope, metrics.AcquireShardsLatency)
 	defer sw.Stop()
 
-AcquireLoop:
+	concurrency := c.config.AcquireShardConcurrency()
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	shardActionCh := make(chan int, concurrency)
+	var wg sync.WaitGroup
+	wg.Add(concurrency)
+	// Spawn workers that would lookup and add/remove shards concurrently.
+	for i := 0; i < concurrency; i++ {
+		go func() {
+			defer wg.Done()
+			for shardID := range shardActionCh {
+				c.acquireShard(shardID)
+			}
+		}()
+	}
+	// Submit tasks to the channel.
 	for shardID := 0; shardID < c.config.NumberOfShards; shardID++ {
-		info, err := c.hServiceResolver.Lookup(string(shardID))
-		if err != nil {
-			c.logger.Error("Error looking up host for shardID", tag.Error(err), tag.OperationFailed, tag.ShardID(shardID))
-			continue AcquireLoop
-		}
+		shardActionCh <- shardID
+	}
+	close(shardActionCh)
+	// Wait until all shards are processed.
+	wg.Wait()
 
-		if info.Identity() == c.host.Identity() {
-			_, err1 := c.getEngineForShard(shardID)
-			if err1 != nil {
-				c.metricsClient.IncCounter(metrics.HistoryShardControllerScope, metrics.GetEngineForShardErrorCounter)
-				c.logger.Error("Unable to create history shard engine", tag.Error(err1), tag.OperationFailed, tag.ShardID(shardID))
-				continue AcquireLoop
+	c.metricsClient.UpdateGauge(metrics.HistoryShardControllerScope, metrics.NumShardsGauge, float64(c.numShards()))
+}
+
+func (c *shardController) acquireShard(shardID int) {
+	info, err := c.placement.lookup(shardID)
+	if err != nil {
+		c.logger.Error("Error looking up host for shardID", tag.Error(err), tag.OperationFailed, tag.ShardID(shardID))
+		return
+	}
+
+	if info.Identity() != c.host.Identity() {
+		c.removeEngineForShard(shardID, info.Identity())
+		return
+	}
+
+	// spread the shard loads of a restarting host, so that its shards do not all hit
+	// persistence at once; shards that are already loaded are not delayed
+	if !c.hasShard(shardID) {
+		if maxJitter := c.config.AcquireShardJitter(); maxJitter > 0 {
+			select {
+			case <-c.shutdownCh:
+				return
+			case <-time.After(time.Duration(rand.Int63n(int64(maxJitter)))):
 			}
-		} else {
-			c.removeEngineForShard(shardID)
 		}
 	}
 
-	c.metricsClient.UpdateGauge(metrics.HistoryShardControllerScope, metrics.NumShardsGauge, float64(c.numShards()))
+	if _, err := c.getEngineForShard(shardID); err != nil {
+		c.metricsClient.IncCounter(metrics.HistoryShardControllerScope, metrics.GetEngineForShardErrorCounter)
+		c.logger.Error("Unable to create history shard engine", tag.Error(err), tag.OperationFailed, tag.ShardID(shardID))
+	}
+}
+
+// shardLeaseRenewDelay returns the delay until the next renewal of the shard leases, jittered so that
+// the hosts do not renew at the same time. When the renewal is disabled the config is checked again
+// after the acquire shard interval.
+func (c *shardController) shardLeaseRenewDelay() time.Duration {
+	interval := c.config.ShardLeaseRenewInterval()
+	if interval <= 0 {
+		return c.config.AcquireShardInterval()
+	}
+	if maxJitter := c.config.ShardLeaseRenewJitter(); maxJitter > 0 {
+		interval += time.Duration(rand.Int63n(int64(maxJitter)))
+	}
+	return interval
+}
+
+// renewShardLeases persists a new lease expiry hint for the shards whose engine is started on this host
+func (c *shardController) renewShardLeases() {
+	c.RLock()
+	items := make([]*historyShardsItem, 0, len(c.historyShards))
+	for _, item := range c.historyShards {
+		items = append(items, item)
+	}
+	c.RUnlock()
+
+	concurrency := c.config.AcquireShardConcurrency()
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	itemCh := make(chan *historyShardsItem, concurrency)
+	var wg sync.WaitGroup
+	wg.Add(concurrency)
+	for i := 0; i < concurrency; i++ {
+		go func() {
+			defer wg.Done()
+			for item := range itemCh {
+				if err := item.renewLease(); err != nil {
+					c.metricsClient.IncCounter(metrics.HistoryShardControllerScope, metrics.ShardLeaseRenewFailedCounter)
+					c.logger.Warn("Failed to renew shard lease", tag.Error(err), tag.ShardID(item.shardID))
+				}
+			}
+		}()
+	}
+	for _, item := range items {
+		itemCh <- item
+	}
+	close(itemCh)
+	wg.Wait()
+}
+
+func (c *shardController) hasShard(shardID int) bool {
+	c.RLock()
+	defer c.RUnlock()
+	_, ok := c.historyShards[shardID]
+	return ok
 }
 
 func (c *shardController) doShutdown() {
@@ -359,13 +515,66 @@ func (c *shardController) doShutdown() {
 	c.historyShards = nil
 }
 
+// drain stops the controller from acquiring shards and releases the shards owned by this host,
+// it returns the shards released with and without persisting their ack levels
+func (c *shardController) drain() ([]int32, []int32) {
+	c.Lock()
+	c.isDraining = true
+	items := make([]*historyShardsItem, 0, len(c.historyShards))
+	for shardID, item := range c.historyShards {
+		items = append(items, item)
+		delete(c.historyShards, shardID)
+	}
+	c.Unlock()
+
+	c.logger.Info("Draining history host", tag.Address(c.host.Identity()), tag.Number(int64(len(items))))
+
+	drained := []int32{}
+	failed := []int32{}
+	for _, item := range items {
+		if err := item.drain(); err != nil {
+			c.logger.Error("Failed to persist shard before release", tag.Error(err), tag.OperationFailed, tag.ShardID(item.shardID))
+			failed = append(failed, int32(item.shardID))
+		} else {
+			drained = append(drained, int32(item.shardID))
+		}
+		c.metricsClient.IncCounter(metrics.HistoryShardControllerScope, metrics.ShardItemRemovedCounter)
+		c.movements.record(&workflow.ShardMovement{
+			ShardID:       common.Int32Ptr(int32(item.shardID)),
+			PreviousOwner: common.StringPtr(c.host.Identity()),
+			Owner:         common.StringPtr(""),
+			Timestamp:     common.Int64Ptr(c.service.GetTimeSource().Now().UnixNano()),
+		})
+	}
+
+	c.metricsClient.UpdateGauge(metrics.HistoryShardControllerScope, metrics.NumShardsGauge, float64(c.numShards()))
+	c.logger.Info("Drained history host", tag.Address(c.host.Identity()),
+		tag.NumberProcessed(len(drained)), tag.NumberDeleted(len(failed)))
+	return drained, failed
+}
+
+// undrain lets the controller acquire the shards the ring assigns to this host again
+func (c *shardController) undrain() {
+	c.Lock()
+	c.isDraining = false
+	c.Unlock()
+
+	c.logger.Info("Undrained history host", tag.Address(c.host.Identity()))
+}
+
+func (c *shardController) isDrainingHost() bool {
+	c.RLock()
+	defer c.RUnlock()
+	return c.isDraining
+}
+
 func (c *shardController) processShardClosedEvents() {
 	for {
 		select {
 		case shardID := <-c.shardClosedCh:
 			c.metricsClient.IncCounter(metrics.HistoryShardControllerScope, metrics.ShardClosedCounter)
 			c.logger.Info("", tag.LifeCycleStopping, tag.ComponentShard, tag.ShardID(shardID), tag.Address(c.host.Identity()))
-			c.removeEngineForShard(shardID)
+			c.removeEngineForShard(shardID, "")
 		default:
 			return
 		}
@@ -391,7 +600,77 @@ func (c *shardController) shardIDs() []int32 {
 	return ids
 }
 
-func (i *historyShardsItem) getOrCreateEngine(shardClosedCh chan<- int) (Engine, error) {
+// shardMovements returns the most recent shard ownership changes observed by this host
+func (c *shardController) shardMovements() []*workflow.ShardMovement {
+	return c.movements.list()
+}
+
+// shardInfos returns the state of the shards whose engine is started on this host
+func (c *shardController) shardInfos() []*workflow.HistoryShardInfo {
+	c.RLock()
+	items := make([]*historyShardsItem, 0, len(c.historyShards))
+	for _, item := range c.historyShards {
+		items = append(items, item)
+	}
+	c.RUnlock()
+
+	infos := []*workflow.HistoryShardInfo{}
+	for _, item := range items {
+		if info := item.describeShard(); info != nil {
+			infos = append(infos, info)
+		}
+	}
+	return infos
+}
+
+// domainOpenExecutionCount returns the approximate number of open executions of the domain
+// summed over the shards whose engine is started on this host
+func (c *shardController) domainOpenExecutionCount(domainID string) int64 {
+	c.RLock()
+	items := make([]*historyShardsItem, 0, len(c.historyShards))
+	for _, item := range c.historyShards {
+		items = append(items, item)
+	}
+	c.RUnlock()
+
+	var count int64
+	for _, item := range items {
+		count += item.domainOpenExecutionCount(domainID)
+	}
+	return count
+}
+
+func (i *historyShardsItem) describeShard() *workflow.HistoryShardInfo {
+	i.RLock()
+	defer i.RUnlock()
+
+	if i.status != historyShardsItemStatusStarted {
+		return nil
+	}
+	return i.engine.DescribeShard()
+}
+
+func (i *historyShardsItem) domainOpenExecutionCount(domainID string) int64 {
+	i.RLock()
+	defer i.RUnlock()
+
+	if i.status != historyShardsItemStatusStarted {
+		return 0
+	}
+	return i.engine.DomainOpenExecutionCount(domainID)
+}
+
+func (i *historyShardsItem) renewLease() error {
+	i.RLock()
+	defer i.RUnlock()
+
+	if i.status != historyShardsItemStatusStarted {
+		return nil
+	}
+	return i.shard.renewLease()
+}
+
+func (i *historyShardsItem) getOrCreateEngine(shardClosedCh chan<- int, shutdownCh <-chan struct{}) (Engine, error) {
 	i.RLock()
 	if i.status == historyShardsItemStatusStarted {
 		defer i.RUnlock()
@@ -399,24 +678,52 @@ func (i *historyShardsItem) getOrCreateEngine(shardClosedCh chan<- int) (Engine,
 	}
 	i.RUnlock()
 
+	for {
+		engine, leaseWait, err := i.tryCreateEngine(shardClosedCh)
+		if err != nil || leaseWait <= 0 {
+			return engine, err
+		}
+		// wait for the lease of the previous owner without holding the lock, so that the shard can be stopped meanwhile
+		select {
+		case <-shutdownCh:
+			return nil, fmt.Errorf("shard %v for host '%v' is shut down", i.shardID, i.host.Identity())
+		case <-i.stoppedCh:
+		case <-time.After(leaseWait):
+		}
+	}
+}
+
+// tryCreateEngine starts the engine of the shard, it returns how long to wait instead when the lease of the
+// previous owner of the shard has not expired yet
+func (i *historyShardsItem) tryCreateEngine(shardClosedCh chan<- int) (Engine, time.Duration, error) {
 	i.Lock()
 	defer i.Unlock()
 	switch i.status {
 	case historyShardsItemStatusInitialized:
+		now := i.service.GetTimeSource().Now()
+		if now.Before(i.leaseWaitUntil) {
+			return nil, i.leaseWaitUntil.Sub(now), nil
+		}
 		i.logger.Info("", tag.LifeCycleStarting, tag.ComponentShardEngine, tag.ShardID(i.shardID), tag.Address(i.host.Identity()))
-		context, err := acquireShard(i, shardClosedCh)
+		// the lease is waited for once, the shard is stolen when the previous owner renewed it meanwhile
+		context, leaseWait, err := acquireShard(i, shardClosedCh, i.leaseWaitUntil.IsZero())
 		if err != nil {
-			return nil, err
+			return nil, 0, err
+		}
+		if leaseWait > 0 {
+			i.leaseWaitUntil = now.Add(leaseWait)
+			return nil, leaseWait, nil
 		}
 		i.engine = i.engineFactory.CreateEngine(context)
+		i.shard = context
 		i.engine.Start()
 		i.logger.Info("", tag.LifeCycleStarted, tag.ComponentShardEngine, tag.ShardID(i.shardID), tag.Address(i.host.Identity()))
 		i.status = historyShardsItemStatusStarted
-		return i.engine, nil
+		return i.engine, 0, nil
 	case historyShardsItemStatusStarted:
-		return i.engine, nil
+		return i.engine, 0, nil
 	case historyShardsItemStatusStopped:
-		return nil, fmt.Errorf("shard %v for host '%v' is shut down", i.shardID, i.host.Identity())
+		return nil, 0, fmt.Errorf("shard %v for host '%v' is shut down", i.shardID, i.host.Identity())
 	default:
 		panic(i.logInvalidStatus())
 	}
@@ -429,12 +736,15 @@ func (i *historyShardsItem) stopEngine() {
 	switch i.status {
 	case historyShardsItemStatusInitialized:
 		i.status = historyShardsItemStatusStopped
+		close(i.stoppedCh)
 	case historyShardsItemStatusStarted:
 		i.logger.Info("", tag.LifeCycleStopping, tag.ComponentShardEngine, tag.ShardID(i.shardID), tag.Address(i.host.Identity()))
 		i.engine.Stop()
 		i.engine = nil
+		i.shard = nil
 		i.logger.Info("", tag.LifeCycleStopped, tag.ComponentShardEngine, tag.ShardID(i.shardID), tag.Address(i.host.Identity()))
 		i.status = historyShardsItemStatusStopped
+		close(i.stoppedCh)
 	case historyShardsItemStatusStopped:
 		// no op
 	default:
@@ -442,6 +752,31 @@ func (i *historyShardsItem) stopEngine() {
 	}
 }
 
+// drain stops the engine of the shard and releases the shard once its in-flight writes are done
+func (i *historyShardsItem) drain() error {
+	i.Lock()
+	defer i.Unlock()
+
+	switch i.status {
+	case historyShardsItemStatusInitialized:
+		i.status = historyShardsItemStatusStopped
+		return nil
+	case historyShardsItemStatusStarted:
+		i.logger.Info("", tag.LifeCycleStopping, tag.ComponentShardEngine, tag.ShardID(i.shardID), tag.Address(i.host.Identity()))
+		i.engine.Stop()
+		err := i.shard.release()
+		i.engine = nil
+		i.shard = nil
+		i.logger.Info("", tag.LifeCycleStopped, tag.ComponentShardEngine, tag.ShardID(i.shardID), tag.Address(i.host.Identity()))
+		i.status = historyShardsItemStatusStopped
+		return err
+	case historyShardsItemStatusStopped:
+		return nil
+	default:
+		panic(i.logInvalidStatus())
+	}
+}
+
 func (i *historyShardsItem) isValid() bool {
 	i.RLock()
 	defer i.RUnlock()
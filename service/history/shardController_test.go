@@ -23,14 +23,18 @@ package history
 import (
 	"errors"
 	"fmt"
+	"math"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 	"github.com/uber-go/tally"
+	workflow "github.com/uber/cadence/.gen/go/shared"
 	"github.com/uber/cadence/client"
+	"github.com/uber/cadence/common"
 	"github.com/uber/cadence/common/cache"
 	"github.com/uber/cadence/common/cluster"
 	"github.com/uber/cadence/common/log"
@@ -42,6 +46,7 @@ import (
 	mmocks "github.com/uber/cadence/common/mocks"
 	"github.com/uber/cadence/common/persistence"
 	"github.com/uber/cadence/common/service"
+	"github.com/uber/cadence/common/service/dynamicconfig"
 )
 
 type (
@@ -198,6 +203,101 @@ func (s *shardControllerSuite) TestAcquireShardLookupFailure() {
 	}
 }
 
+func (s *shardControllerSuite) TestAcquireShardsConcurrently() {
+	concurrency := 4
+	s.config.NumberOfShards = concurrency
+	s.config.AcquireShardConcurrency = dynamicconfig.GetIntPropertyFn(concurrency)
+	s.config.AcquireShardJitter = dynamicconfig.GetDurationPropertyFn(0)
+	for shardID := 0; shardID < concurrency; shardID++ {
+		s.setupMocksForAcquireShard(shardID, &MockHistoryEngine{}, 5, 6)
+	}
+
+	// every shard load waits until all of them are in flight, which only happens when they are acquired concurrently
+	var arrivedWG sync.WaitGroup
+	arrivedWG.Add(concurrency)
+	allArrivedCh := make(chan struct{})
+	go func() {
+		arrivedWG.Wait()
+		close(allArrivedCh)
+	}()
+	var timedOut int32
+	for _, call := range s.mockShardManager.ExpectedCalls {
+		if call.Method == "GetShard" {
+			call.Run(func(_ mock.Arguments) {
+				arrivedWG.Done()
+				select {
+				case <-allArrivedCh:
+				case <-time.After(5 * time.Second):
+					atomic.StoreInt32(&timedOut, 1)
+				}
+			})
+		}
+	}
+
+	// when shard is initialized, it will use the 2 mock function below to initialize the "current" time of each cluster
+	s.mockClusterMetadata.On("GetCurrentClusterName").Return(cluster.TestCurrentClusterName)
+	s.mockClusterMetadata.On("GetAllClusterInfo").Return(cluster.TestSingleDCClusterInfo)
+	s.controller.acquireShards()
+	s.Equal(int32(0), atomic.LoadInt32(&timedOut))
+	for shardID := 0; shardID < concurrency; shardID++ {
+		s.True(s.controller.hasShard(shardID))
+		engine, err := s.controller.getEngineForShard(shardID)
+		s.NoError(err)
+		s.NotNil(engine)
+	}
+}
+
+func (s *shardControllerSuite) TestAcquireShardsSkipsJitterOfLoadedShards() {
+	s.config.NumberOfShards = 1
+	s.config.AcquireShardJitter = dynamicconfig.GetDurationPropertyFn(0)
+	s.setupMocksForAcquireShard(0, &MockHistoryEngine{}, 5, 6)
+
+	// when shard is initialized, it will use the 2 mock function below to initialize the "current" time of each cluster
+	s.mockClusterMetadata.On("GetCurrentClusterName").Return(cluster.TestCurrentClusterName)
+	s.mockClusterMetadata.On("GetAllClusterInfo").Return(cluster.TestSingleDCClusterInfo)
+	s.controller.acquireShards()
+	s.True(s.controller.hasShard(0))
+
+	// the shard is already loaded, so it is neither delayed nor loaded again
+	s.config.AcquireShardJitter = dynamicconfig.GetDurationPropertyFn(time.Hour)
+	s.mockServiceResolver.On("Lookup", string(0)).Return(s.hostInfo, nil).Once()
+	doneCh := make(chan struct{})
+	go func() {
+		s.controller.acquireShards()
+		close(doneCh)
+	}()
+	select {
+	case <-doneCh:
+	case <-time.After(5 * time.Second):
+		s.Fail("the loaded shard was delayed by the jitter")
+	}
+	s.True(s.controller.hasShard(0))
+}
+
+func (s *shardControllerSuite) TestAcquireShardsInterruptedByShutdown() {
+	numShards := 2
+	s.config.NumberOfShards = numShards
+	s.config.AcquireShardJitter = dynamicconfig.GetDurationPropertyFn(time.Hour)
+	for shardID := 0; shardID < numShards; shardID++ {
+		s.mockServiceResolver.On("Lookup", string(shardID)).Return(s.hostInfo, nil).Once()
+	}
+
+	doneCh := make(chan struct{})
+	go func() {
+		s.controller.acquireShards()
+		close(doneCh)
+	}()
+	close(s.controller.shutdownCh)
+	select {
+	case <-doneCh:
+	case <-time.After(5 * time.Second):
+		s.Fail("the shard acquisition was not interrupted by the shutdown")
+	}
+	for shardID := 0; shardID < numShards; shardID++ {
+		s.False(s.controller.hasShard(shardID))
+	}
+}
+
 func (s *shardControllerSuite) TestAcquireShardRenewSuccess() {
 	numShards := 2
 	s.config.NumberOfShards = numShards
@@ -441,6 +541,260 @@ func (s *shardControllerSuite) TestHistoryEngineClosed() {
 	}
 }
 
+func (s *shardControllerSuite) TestShardInfos() {
+	numShards := 2
+	s.config.NumberOfShards = numShards
+	for shardID := 0; shardID < numShards; shardID++ {
+		mockEngine := &MockHistoryEngine{}
+		s.setupMocksForAcquireShard(shardID, mockEngine, 5, 6)
+		mockEngine.On("DescribeShard").Return(&workflow.HistoryShardInfo{
+			ShardID:                  common.Int32Ptr(int32(shardID)),
+			NumberOfCachedExecutions: common.Int32Ptr(int32(shardID + 1)),
+		}).Once()
+	}
+
+	// when shard is initialized, it will use the 2 mock function below to initialize the "current" time of each cluster
+	s.mockClusterMetadata.On("GetCurrentClusterName").Return(cluster.TestCurrentClusterName)
+	s.mockClusterMetadata.On("GetAllClusterInfo").Return(cluster.TestSingleDCClusterInfo)
+	s.controller.acquireShards()
+
+	infos := s.controller.shardInfos()
+	s.Len(infos, numShards)
+	numOfCachedExecutions := map[int32]int32{}
+	for _, info := range infos {
+		numOfCachedExecutions[info.GetShardID()] = info.GetNumberOfCachedExecutions()
+	}
+	s.Equal(map[int32]int32{0: 1, 1: 2}, numOfCachedExecutions)
+}
+
+func (s *shardControllerSuite) TestShardMovements() {
+	numShards := 2
+	s.config.NumberOfShards = numShards
+	historyEngines := make(map[int]*MockHistoryEngine)
+	for shardID := 0; shardID < numShards; shardID++ {
+		mockEngine := &MockHistoryEngine{}
+		historyEngines[shardID] = mockEngine
+		s.setupMocksForAcquireShard(shardID, mockEngine, 5, 6)
+	}
+
+	// when shard is initialized, it will use the 2 mock function below to initialize the "current" time of each cluster
+	s.mockClusterMetadata.On("GetCurrentClusterName").Return(cluster.TestCurrentClusterName)
+	s.mockClusterMetadata.On("GetAllClusterInfo").Return(cluster.TestSingleDCClusterInfo)
+	s.controller.acquireShards()
+
+	movements := s.controller.shardMovements()
+	s.Len(movements, numShards)
+	for _, movement := range movements {
+		s.Equal(s.hostInfo.Identity(), movement.GetPreviousOwner())
+		s.Equal(s.hostInfo.Identity(), movement.GetOwner())
+		s.Equal(int64(6), movement.GetRangeID())
+		s.Equal(int32(1), movement.GetStolenSinceRenew())
+	}
+
+	differentHostInfo := membership.NewHostInfo("another-host", nil)
+	historyEngines[0].On("Stop").Return().Once()
+	s.mockServiceResolver.On("Lookup", string(0)).Return(differentHostInfo, nil).Once()
+	s.mockServiceResolver.On("Lookup", string(1)).Return(s.hostInfo, nil).Once()
+	s.controller.acquireShards()
+
+	movements = s.controller.shardMovements()
+	s.Len(movements, numShards+1)
+	s.Equal(int32(0), movements[numShards].GetShardID())
+	s.Equal(s.hostInfo.Identity(), movements[numShards].GetPreviousOwner())
+	s.Equal(differentHostInfo.Identity(), movements[numShards].GetOwner())
+	historyEngines[0].AssertExpectations(s.T())
+}
+
+func (s *shardControllerSuite) TestDrain() {
+	numShards := 2
+	s.config.NumberOfShards = numShards
+	historyEngines := make(map[int]*MockHistoryEngine)
+	for shardID := 0; shardID < numShards; shardID++ {
+		mockEngine := &MockHistoryEngine{}
+		historyEngines[shardID] = mockEngine
+		s.setupMocksForAcquireShard(shardID, mockEngine, 5, 6)
+	}
+
+	// when shard is initialized, it will use the 2 mock function below to initialize the "current" time of each cluster
+	s.mockClusterMetadata.On("GetCurrentClusterName").Return(cluster.TestCurrentClusterName)
+	s.mockClusterMetadata.On("GetAllClusterInfo").Return(cluster.TestSingleDCClusterInfo)
+	s.controller.acquireShards()
+	s.Equal(numShards, s.controller.numShards())
+
+	releaseRequest := func(shardID int) interface{} {
+		return mock.MatchedBy(func(request *persistence.UpdateShardRequest) bool {
+			return request.ShardInfo.ShardID == shardID &&
+				request.ShardInfo.Owner == "" &&
+				request.ShardInfo.RangeID == 6 &&
+				request.PreviousRangeID == 6
+		})
+	}
+	for shardID := 0; shardID < numShards; shardID++ {
+		historyEngines[shardID].On("Stop").Return().Once()
+	}
+	s.mockShardManager.On("UpdateShard", releaseRequest(0)).Return(nil).Once()
+	s.mockShardManager.On("UpdateShard", releaseRequest(1)).Return(errors.New("update shard failed")).Once()
+
+	drained, failed := s.controller.drain()
+	s.Equal([]int32{0}, drained)
+	s.Equal([]int32{1}, failed)
+	s.Equal(0, s.controller.numShards())
+	s.True(s.controller.isDrainingHost())
+
+	// a draining host does not acquire shards anymore
+	s.controller.acquireShards()
+	_, err := s.controller.getEngineForShard(0)
+	s.Error(err)
+	s.Equal(0, s.controller.numShards())
+
+	movements := s.controller.shardMovements()
+	s.Len(movements, 2*numShards)
+	for _, movement := range movements[numShards:] {
+		s.Equal(s.hostInfo.Identity(), movement.GetPreviousOwner())
+		s.Equal("", movement.GetOwner())
+	}
+	for _, engine := range historyEngines {
+		engine.AssertExpectations(s.T())
+	}
+	s.mockShardManager.AssertExpectations(s.T())
+}
+
+func (s *shardControllerSuite) TestUndrain() {
+	numShards := 2
+	s.config.NumberOfShards = numShards
+	s.mockClusterMetadata.On("GetCurrentClusterName").Return(cluster.TestCurrentClusterName)
+	s.mockClusterMetadata.On("GetAllClusterInfo").Return(cluster.TestSingleDCClusterInfo)
+
+	s.controller.drain()
+	s.True(s.controller.isDrainingHost())
+
+	historyEngines := make(map[int]*MockHistoryEngine)
+	for shardID := 0; shardID < numShards; shardID++ {
+		mockEngine := &MockHistoryEngine{}
+		historyEngines[shardID] = mockEngine
+		s.setupMocksForAcquireShard(shardID, mockEngine, 5, 6)
+	}
+
+	// an undrained host acquires the shards the ring assigns to it again
+	s.controller.undrain()
+	s.False(s.controller.isDrainingHost())
+	s.controller.acquireShards()
+	s.Equal(numShards, s.controller.numShards())
+	for _, engine := range historyEngines {
+		engine.AssertExpectations(s.T())
+	}
+}
+
+func (s *shardControllerSuite) TestRenewShardLeases() {
+	numShards := 2
+	s.config.NumberOfShards = numShards
+	s.config.ShardLeaseTTL = dynamicconfig.GetDurationPropertyFn(time.Minute)
+	for shardID := 0; shardID < numShards; shardID++ {
+		s.setupMocksForAcquireShard(shardID, &MockHistoryEngine{}, 5, 6)
+	}
+
+	// when shard is initialized, it will use the 2 mock function below to initialize the "current" time of each cluster
+	s.mockClusterMetadata.On("GetCurrentClusterName").Return(cluster.TestCurrentClusterName)
+	s.mockClusterMetadata.On("GetAllClusterInfo").Return(cluster.TestSingleDCClusterInfo)
+	s.controller.acquireShards()
+
+	now := time.Now()
+	renewRequest := func(shardID int) interface{} {
+		return mock.MatchedBy(func(request *persistence.UpdateShardRequest) bool {
+			return request.ShardInfo.ShardID == shardID &&
+				request.ShardInfo.Owner == s.hostInfo.Identity() &&
+				request.ShardInfo.LeaseExpiresAt.After(now) &&
+				request.PreviousRangeID == 6
+		})
+	}
+	s.mockShardManager.On("UpdateShard", renewRequest(0)).Return(nil).Once()
+	s.mockShardManager.On("UpdateShard", renewRequest(1)).Return(errors.New("update shard failed")).Once()
+
+	s.controller.renewShardLeases()
+	s.mockShardManager.AssertExpectations(s.T())
+}
+
+func (s *shardControllerSuite) TestGetEngineForShard_LeaseWaitInterruptedByStop() {
+	s.config.NumberOfShards = 1
+	s.config.ShardLeaseStealTimeout = dynamicconfig.GetDurationPropertyFn(5 * time.Minute)
+
+	mockExecutionMgr := &mmocks.ExecutionManager{}
+	s.mockExecutionMgrFactory.On("NewExecutionManager", 0).Return(mockExecutionMgr, nil).Once()
+	s.mockServiceResolver.On("Lookup", string(0)).Return(s.hostInfo, nil)
+	shardLoadedCh := make(chan struct{})
+	s.mockShardManager.On("GetShard", &persistence.GetShardRequest{ShardID: 0}).Return(
+		&persistence.GetShardResponse{
+			ShardInfo: &persistence.ShardInfo{
+				ShardID:        0,
+				Owner:          "another-host",
+				RangeID:        5,
+				LeaseExpiresAt: time.Now().Add(time.Minute),
+			},
+		}, nil).Run(func(_ mock.Arguments) { close(shardLoadedCh) }).Once()
+
+	item, err := s.controller.getOrCreateHistoryShardItem(0)
+	s.NoError(err)
+	errCh := make(chan error, 1)
+	go func() {
+		_, err := s.controller.getEngineForShard(0)
+		errCh <- err
+	}()
+
+	// the lock of the shard is not held while waiting for the lease of the previous owner
+	<-shardLoadedCh
+	item.stopEngine()
+	select {
+	case err := <-errCh:
+		s.Error(err)
+	case <-time.After(5 * time.Second):
+		s.Fail("the lease wait was not interrupted")
+	}
+}
+
+func (s *shardControllerSuite) TestShardTaskThroughput() {
+	s.config.NumberOfShards = 1
+	s.config.ShardTaskThroughputWindow = dynamicconfig.GetDurationPropertyFn(0)
+	s.setupMocksForAcquireShard(0, &MockHistoryEngine{}, 5, 6)
+
+	// when shard is initialized, it will use the 2 mock function below to initialize the "current" time of each cluster
+	s.mockClusterMetadata.On("GetCurrentClusterName").Return(cluster.TestCurrentClusterName)
+	s.mockClusterMetadata.On("GetAllClusterInfo").Return(cluster.TestSingleDCClusterInfo)
+	s.controller.acquireShards()
+
+	item, err := s.controller.getOrCreateHistoryShardItem(0)
+	s.NoError(err)
+	shard := item.shard
+	for i := 0; i < 600; i++ {
+		shard.RecordTaskProcessed()
+	}
+	shard.updateTaskThroughputLocked(shard.taskThroughputUpdatedAt.Add(time.Minute))
+	s.InDelta(10.0, shard.GetTasksPerSecond(), 0.001)
+
+	// with a window the rate decays towards the recent throughput
+	s.config.ShardTaskThroughputWindow = dynamicconfig.GetDurationPropertyFn(time.Minute)
+	shard.updateTaskThroughputLocked(shard.taskThroughputUpdatedAt.Add(time.Minute))
+	s.InDelta(10.0*math.Exp(-1), shard.GetTasksPerSecond(), 0.001)
+	s.Equal(shard.taskThroughputUpdatedAt, shard.shardInfo.TaskThroughputUpdatedAt)
+}
+
+func (s *shardControllerSuite) TestShardStealDelay() {
+	now := time.Now()
+	owner := s.hostInfo.Identity()
+	shardInfo := &persistence.ShardInfo{
+		Owner:          "another-host",
+		LeaseExpiresAt: now.Add(time.Minute),
+	}
+
+	s.Equal(time.Duration(0), shardStealDelay(shardInfo, owner, now, 0))
+	s.Equal(time.Minute, shardStealDelay(shardInfo, owner, now, 5*time.Minute))
+	s.Equal(10*time.Second, shardStealDelay(shardInfo, owner, now, 10*time.Second))
+	s.Equal(time.Duration(0), shardStealDelay(shardInfo, owner, now.Add(2*time.Minute), 5*time.Minute))
+	s.Equal(time.Duration(0), shardStealDelay(shardInfo, "another-host", now, 5*time.Minute))
+
+	shardInfo.Owner = ""
+	s.Equal(time.Duration(0), shardStealDelay(shardInfo, owner, now, 5*time.Minute))
+}
+
 func (s *shardControllerSuite) TestRingUpdated() {
 	numShards := 4
 	s.config.NumberOfShards = numShards
@@ -0,0 +1,68 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package history
+
+import (
+	"sync"
+
+	workflow "github.com/uber/cadence/.gen/go/shared"
+)
+
+const (
+	// shardMovementHistorySize is the number of most recent shard movements kept by a host
+	shardMovementHistorySize = 100
+)
+
+type (
+	// shardMovementHistory keeps the most recent shard ownership changes observed by this host,
+	// it is used to diagnose shards flapping between hosts
+	shardMovementHistory struct {
+		sync.Mutex
+		size      int
+		movements []*workflow.ShardMovement
+	}
+)
+
+func newShardMovementHistory(size int) *shardMovementHistory {
+	return &shardMovementHistory{
+		size: size,
+	}
+}
+
+func (h *shardMovementHistory) record(movement *workflow.ShardMovement) {
+	h.Lock()
+	defer h.Unlock()
+
+	h.movements = append(h.movements, movement)
+	if len(h.movements) > h.size {
+		h.movements = h.movements[len(h.movements)-h.size:]
+	}
+}
+
+// list returns the recorded movements, oldest first
+func (h *shardMovementHistory) list() []*workflow.ShardMovement {
+	h.Lock()
+	defer h.Unlock()
+
+	movements := make([]*workflow.ShardMovement, len(h.movements))
+	copy(movements, h.movements)
+	return movements
+}
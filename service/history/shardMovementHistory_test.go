@@ -0,0 +1,49 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package history
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	workflow "github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common"
+)
+
+func TestShardMovementHistory(t *testing.T) {
+	history := newShardMovementHistory(3)
+	require.Empty(t, history.list())
+
+	for shardID := 0; shardID < 5; shardID++ {
+		history.record(&workflow.ShardMovement{ShardID: common.Int32Ptr(int32(shardID))})
+	}
+
+	movements := history.list()
+	require.Len(t, movements, 3)
+	for i, movement := range movements {
+		require.Equal(t, int32(i+2), movement.GetShardID())
+	}
+
+	// the returned list must not be affected by new movements
+	history.record(&workflow.ShardMovement{ShardID: common.Int32Ptr(5)})
+	require.Equal(t, int32(2), movements[0].GetShardID())
+	require.Equal(t, int32(3), history.list()[0].GetShardID())
+}
@@ -0,0 +1,283 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package history
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common/log"
+	"github.com/uber/cadence/common/log/tag"
+	"github.com/uber/cadence/common/membership"
+	"github.com/uber/cadence/common/persistence"
+)
+
+const (
+	// shardPlacementShardID is the reserved shard record holding the persisted placement
+	shardPlacementShardID = -1
+	// shardPlacementLeaderKey is hashed on the membership ring to elect the host computing the placement
+	shardPlacementLeaderKey = "shard-placement-leader"
+)
+
+type (
+	// shardPlacement assigns shards to history hosts. Without a persisted placement, or when load aware
+	// placement is disabled, a shard is owned by the host the membership ring hashes it to. Otherwise the
+	// ring assignment is rebalanced using the task rate persisted by each shard, so that hosts end up with
+	// comparable aggregate load rather than comparable shard counts.
+	// The rebalanced assignment is only computed by the host owning the leader key on the ring, which
+	// persists it in a reserved shard record. Every host serves its lookups from that single record, so
+	// hosts agree on the owner of a shard and clients sent to the ring owner are redirected to it by the
+	// shard ownership lost error. Hosts of the record which left the ring fall back to the ring owner.
+	shardPlacement struct {
+		sync.Mutex
+		numberOfShards int
+		host           *membership.HostInfo
+		resolver       membership.ServiceResolver
+		shardMgr       persistence.ShardManager
+		config         *Config
+		logger         log.Logger
+
+		placement  []string                     // shardID -> host identity, as persisted by the leader
+		assignment map[int]*membership.HostInfo // nil when it has to be recomputed
+	}
+)
+
+func newShardPlacement(host *membership.HostInfo, resolver membership.ServiceResolver,
+	shardMgr persistence.ShardManager, config *Config, logger log.Logger) *shardPlacement {
+	return &shardPlacement{
+		numberOfShards: config.NumberOfShards,
+		host:           host,
+		resolver:       resolver,
+		shardMgr:       shardMgr,
+		config:         config,
+		logger:         logger,
+	}
+}
+
+// lookup returns the host owning the shard
+func (p *shardPlacement) lookup(shardID int) (*membership.HostInfo, error) {
+	if !p.config.EnableLoadAwareShardPlacement() {
+		return p.resolver.Lookup(string(shardID))
+	}
+
+	p.Lock()
+	defer p.Unlock()
+
+	if len(p.placement) == 0 {
+		return p.resolver.Lookup(string(shardID))
+	}
+	if p.assignment == nil {
+		owners, err := p.ringOwners()
+		if err != nil {
+			return nil, err
+		}
+		p.assignment = applyShardPlacement(owners, p.placement)
+	}
+	return p.assignment[shardID], nil
+}
+
+// invalidate drops the computed assignment, it is called when the ring membership changes
+func (p *shardPlacement) invalidate() {
+	p.Lock()
+	defer p.Unlock()
+
+	p.assignment = nil
+}
+
+// refresh persists a new placement if this host is the leader, then reads the persisted placement
+func (p *shardPlacement) refresh() {
+	leader, err := p.resolver.Lookup(shardPlacementLeaderKey)
+	if err != nil {
+		p.logger.Warn("Failed to look up the shard placement leader", tag.Error(err))
+		return
+	}
+	if leader.Identity() == p.host.Identity() {
+		if err := p.publish(); err != nil {
+			p.logger.Warn("Failed to persist the shard placement", tag.Error(err))
+		}
+	}
+
+	resp, err := p.shardMgr.GetShard(&persistence.GetShardRequest{ShardID: shardPlacementShardID})
+	if err != nil {
+		if _, ok := err.(*shared.EntityNotExistsError); !ok {
+			p.logger.Warn("Failed to read the shard placement", tag.Error(err))
+		}
+		return
+	}
+
+	p.Lock()
+	defer p.Unlock()
+
+	p.placement = resp.ShardInfo.Placement
+	p.assignment = nil
+}
+
+// publish computes the placement from the ring and the task rate persisted by every shard, and
+// persists it. Nothing is persisted if a shard can not be read, so that the placement is only computed
+// from a complete snapshot. The write is fenced by the range ID of the record, a concurrent leader
+// loses the write and picks up the persisted placement instead.
+func (p *shardPlacement) publish() error {
+	loads := make(map[int]float64, p.numberOfShards)
+	for shardID := 0; shardID < p.numberOfShards; shardID++ {
+		resp, err := p.shardMgr.GetShard(&persistence.GetShardRequest{ShardID: shardID})
+		if err != nil {
+			if _, ok := err.(*shared.EntityNotExistsError); ok {
+				continue
+			}
+			return err
+		}
+		loads[shardID] = resp.ShardInfo.TasksPerSecond
+	}
+
+	p.Lock()
+	owners, err := p.ringOwners()
+	p.Unlock()
+	if err != nil {
+		return err
+	}
+	assignment := computeShardPlacement(owners, loads, p.config.ShardPlacementLoadTolerance())
+	placement := make([]string, p.numberOfShards)
+	for shardID, host := range assignment {
+		placement[shardID] = host.Identity()
+	}
+
+	resp, err := p.shardMgr.GetShard(&persistence.GetShardRequest{ShardID: shardPlacementShardID})
+	if err != nil {
+		if _, ok := err.(*shared.EntityNotExistsError); !ok {
+			return err
+		}
+		return p.shardMgr.CreateShard(&persistence.CreateShardRequest{
+			ShardInfo: &persistence.ShardInfo{
+				ShardID:   shardPlacementShardID,
+				Owner:     p.host.Identity(),
+				RangeID:   0,
+				Placement: placement,
+			},
+		})
+	}
+	return p.shardMgr.UpdateShard(&persistence.UpdateShardRequest{
+		ShardInfo: &persistence.ShardInfo{
+			ShardID:   shardPlacementShardID,
+			Owner:     p.host.Identity(),
+			RangeID:   resp.ShardInfo.RangeID + 1,
+			UpdatedAt: time.Now(),
+			Placement: placement,
+		},
+		PreviousRangeID: resp.ShardInfo.RangeID,
+	})
+}
+
+// ringOwners returns the ring owner of every shard, the caller holds the lock
+func (p *shardPlacement) ringOwners() (map[int]*membership.HostInfo, error) {
+	owners := make(map[int]*membership.HostInfo, p.numberOfShards)
+	for shardID := 0; shardID < p.numberOfShards; shardID++ {
+		owner, err := p.resolver.Lookup(string(shardID))
+		if err != nil {
+			return nil, err
+		}
+		owners[shardID] = owner
+	}
+	return owners, nil
+}
+
+// applyShardPlacement overrides the ring owners with the persisted placement. Shards placed on a host
+// which no longer owns any shard on the ring are left to their ring owner.
+func applyShardPlacement(owners map[int]*membership.HostInfo, placement []string) map[int]*membership.HostInfo {
+	hosts := make(map[string]*membership.HostInfo, len(owners))
+	for _, owner := range owners {
+		hosts[owner.Identity()] = owner
+	}
+	assignment := make(map[int]*membership.HostInfo, len(owners))
+	for shardID, owner := range owners {
+		assignment[shardID] = owner
+		if shardID < len(placement) {
+			if host, ok := hosts[placement[shardID]]; ok {
+				assignment[shardID] = host
+			}
+		}
+	}
+	return assignment
+}
+
+// computeShardPlacement rebalances the ring assignment of the shards. Shards are considered from the
+// most loaded one and moved from hosts loaded above the average by more than the tolerance to the
+// least loaded host, as long as the move does not overload that host. The result only depends on
+// its input so that a new leader computes the same assignment.
+func computeShardPlacement(
+	owners map[int]*membership.HostInfo,
+	loads map[int]float64,
+	tolerance float64,
+) map[int]*membership.HostInfo {
+
+	assignment := make(map[int]*membership.HostInfo, len(owners))
+	hosts := make(map[string]*membership.HostInfo)
+	hostLoads := make(map[string]float64)
+	shardIDs := make([]int, 0, len(owners))
+	totalLoad := 0.0
+	for shardID, owner := range owners {
+		assignment[shardID] = owner
+		hosts[owner.Identity()] = owner
+		hostLoads[owner.Identity()] += loads[shardID]
+		shardIDs = append(shardIDs, shardID)
+		totalLoad += loads[shardID]
+	}
+	if len(hosts) < 2 || totalLoad <= 0 {
+		return assignment
+	}
+
+	identities := make([]string, 0, len(hosts))
+	for identity := range hosts {
+		identities = append(identities, identity)
+	}
+	sort.Strings(identities)
+	sort.Slice(shardIDs, func(i, j int) bool {
+		if loads[shardIDs[i]] != loads[shardIDs[j]] {
+			return loads[shardIDs[i]] > loads[shardIDs[j]]
+		}
+		return shardIDs[i] < shardIDs[j]
+	})
+
+	maxHostLoad := totalLoad / float64(len(hosts)) * (1 + tolerance)
+	for _, shardID := range shardIDs {
+		load := loads[shardID]
+		from := assignment[shardID].Identity()
+		if load <= 0 || hostLoads[from] <= maxHostLoad {
+			continue
+		}
+
+		to := identities[0]
+		for _, identity := range identities[1:] {
+			if hostLoads[identity] < hostLoads[to] {
+				to = identity
+			}
+		}
+		if hostLoads[to]+load > maxHostLoad {
+			continue
+		}
+
+		assignment[shardID] = hosts[to]
+		hostLoads[from] -= load
+		hostLoads[to] += load
+	}
+	return assignment
+}
@@ -0,0 +1,141 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package history
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common/log/loggerimpl"
+	"github.com/uber/cadence/common/membership"
+	"github.com/uber/cadence/common/mocks"
+	"github.com/uber/cadence/common/persistence"
+	"github.com/uber/cadence/common/service/dynamicconfig"
+)
+
+func TestComputeShardPlacement(t *testing.T) {
+	hostA := membership.NewHostInfo("host-a", nil)
+	hostB := membership.NewHostInfo("host-b", nil)
+	owners := map[int]*membership.HostInfo{
+		0: hostA, 1: hostA, 2: hostA, 3: hostA, 4: hostB, 5: hostB,
+	}
+	loads := map[int]float64{
+		0: 10, 1: 10, 2: 5, 3: 5, 4: 1, 5: 1,
+	}
+
+	assignment := computeShardPlacement(owners, loads, 0.1)
+	require.Len(t, assignment, len(owners))
+	require.Equal(t, map[int]string{
+		0: "host-b", 1: "host-a", 2: "host-b", 3: "host-a", 4: "host-b", 5: "host-b",
+	}, assignmentIdentities(assignment))
+
+	// the ring assignment is kept when there is no load hint
+	require.Equal(t, assignmentIdentities(owners), assignmentIdentities(computeShardPlacement(owners, nil, 0.1)))
+
+	// or when the load is already balanced within the tolerance
+	balanced := map[int]float64{0: 5, 1: 5, 2: 5, 3: 5, 4: 10, 5: 10}
+	require.Equal(t, assignmentIdentities(owners), assignmentIdentities(computeShardPlacement(owners, balanced, 0.1)))
+}
+
+func TestShardPlacementPersistedByLeader(t *testing.T) {
+	hostA := membership.NewHostInfo("host-a", nil)
+	hostB := membership.NewHostInfo("host-b", nil)
+	resolver := &mocks.ServiceResolver{}
+	resolver.On("Lookup", shardPlacementLeaderKey).Return(hostA, nil)
+	resolver.On("Lookup", string(0)).Return(hostA, nil)
+	resolver.On("Lookup", string(1)).Return(hostA, nil)
+	resolver.On("Lookup", string(2)).Return(hostB, nil)
+	shardMgr := &mocks.ShardManager{}
+	for shardID, load := range []float64{10, 10, 1} {
+		shardMgr.On("GetShard", &persistence.GetShardRequest{ShardID: shardID}).Return(&persistence.GetShardResponse{
+			ShardInfo: &persistence.ShardInfo{ShardID: shardID, TasksPerSecond: load},
+		}, nil)
+	}
+	placementRequest := &persistence.GetShardRequest{ShardID: shardPlacementShardID}
+	shardMgr.On("GetShard", placementRequest).Return(nil, &shared.EntityNotExistsError{}).Once()
+	shardMgr.On("CreateShard", mock.MatchedBy(func(request *persistence.CreateShardRequest) bool {
+		return request.ShardInfo.ShardID == shardPlacementShardID &&
+			assert.ObjectsAreEqual([]string{"host-b", "host-a", "host-b"}, request.ShardInfo.Placement)
+	})).Return(nil).Once()
+	shardMgr.On("GetShard", placementRequest).Return(&persistence.GetShardResponse{
+		ShardInfo: &persistence.ShardInfo{ShardID: shardPlacementShardID, Placement: []string{"host-b", "host-a", "host-b"}},
+	}, nil).Once()
+
+	placement := newTestShardPlacement(hostA, resolver, shardMgr)
+	placement.refresh()
+	shardMgr.AssertExpectations(t)
+
+	owner, err := placement.lookup(0)
+	require.NoError(t, err)
+	require.Equal(t, "host-b", owner.Identity())
+}
+
+func TestShardPlacementReadByFollower(t *testing.T) {
+	hostA := membership.NewHostInfo("host-a", nil)
+	hostB := membership.NewHostInfo("host-b", nil)
+	resolver := &mocks.ServiceResolver{}
+	resolver.On("Lookup", shardPlacementLeaderKey).Return(hostA, nil)
+	resolver.On("Lookup", string(0)).Return(hostA, nil)
+	resolver.On("Lookup", string(1)).Return(hostA, nil)
+	resolver.On("Lookup", string(2)).Return(hostB, nil)
+	shardMgr := &mocks.ShardManager{}
+	shardMgr.On("GetShard", &persistence.GetShardRequest{ShardID: shardPlacementShardID}).Return(&persistence.GetShardResponse{
+		ShardInfo: &persistence.ShardInfo{ShardID: shardPlacementShardID, Placement: []string{"host-b", "host-c", "host-a"}},
+	}, nil).Once()
+
+	// the follower only reads the placement persisted by the leader
+	placement := newTestShardPlacement(hostB, resolver, shardMgr)
+	placement.refresh()
+	shardMgr.AssertExpectations(t)
+
+	// host-c left the ring, its shard falls back to the ring owner
+	require.Equal(t, map[int]string{0: "host-b", 1: "host-a", 2: "host-a"}, assignmentIdentities(lookupAll(t, placement)))
+}
+
+func newTestShardPlacement(host *membership.HostInfo, resolver membership.ServiceResolver,
+	shardMgr persistence.ShardManager) *shardPlacement {
+	config := NewDynamicConfigForTest()
+	config.NumberOfShards = 3
+	config.EnableLoadAwareShardPlacement = dynamicconfig.GetBoolPropertyFn(true)
+	config.ShardPlacementLoadTolerance = dynamicconfig.GetFloatPropertyFn(0.1)
+	return newShardPlacement(host, resolver, shardMgr, config, loggerimpl.NewNopLogger())
+}
+
+func lookupAll(t *testing.T, placement *shardPlacement) map[int]*membership.HostInfo {
+	assignment := make(map[int]*membership.HostInfo, placement.numberOfShards)
+	for shardID := 0; shardID < placement.numberOfShards; shardID++ {
+		owner, err := placement.lookup(shardID)
+		require.NoError(t, err)
+		assignment[shardID] = owner
+	}
+	return assignment
+}
+
+func assignmentIdentities(assignment map[int]*membership.HostInfo) map[int]string {
+	identities := make(map[int]string, len(assignment))
+	for shardID, host := range assignment {
+		identities[shardID] = host.Identity()
+	}
+	return identities
+}
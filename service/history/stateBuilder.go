@@ -172,8 +172,10 @@ func (b *stateBuilderImpl) applyEvents(domainID, requestID string, execution sha
 			if err != nil {
 				return nil, nil, nil, err
 			}
+			di.StartedIdentity = attributes.GetIdentity()
+			b.msBuilder.UpdateDecision(di)
 
-			b.timerTasks = append(b.timerTasks, b.scheduleDecisionTimerTask(event, di.ScheduleID, di.Attempt,
+			b.timerTasks = append(b.timerTasks, b.scheduleDecisionTimerTask(event, di.ScheduleID, di.scheduleAttempt(),
 				di.DecisionTimeout))
 
 			lastDecision = di
@@ -208,6 +210,12 @@ func (b *stateBuilderImpl) applyEvents(domainID, requestID string, execution sha
 			if err := b.msBuilder.ReplicateDecisionTaskFailedEvent(); err != nil {
 				return nil, nil, nil, err
 			}
+			if attributes := event.DecisionTaskFailedEventAttributes; attributes.GetCause() == shared.DecisionTaskFailedCauseResetWorkflow {
+				executionInfo := b.msBuilder.GetExecutionInfo()
+				executionInfo.ResetBaseRunID = attributes.GetBaseRunId()
+				executionInfo.ResetBaseEventID = event.GetEventId()
+				executionInfo.ResetReason = attributes.GetReason()
+			}
 
 			// this is for transient decision
 			di, err := b.msBuilder.ReplicateTransientDecisionTaskScheduled()
@@ -500,6 +508,26 @@ func (b *stateBuilderImpl) applyEvents(domainID, requestID string, execution sha
 			b.msBuilder.ReplicateUpsertWorkflowSearchAttributesEvent(event)
 			b.transferTasks = append(b.transferTasks, b.scheduleUpsertSearchAttributesTask())
 
+		case shared.EventTypeWorkflowExecutionUpdateRequested:
+			if err := b.msBuilder.ReplicateWorkflowExecutionUpdateRequestedEvent(event); err != nil {
+				return nil, nil, nil, err
+			}
+
+		case shared.EventTypeWorkflowExecutionUpdateCompleted:
+			if err := b.msBuilder.ReplicateWorkflowExecutionUpdateCompletedEvent(event); err != nil {
+				return nil, nil, nil, err
+			}
+
+		case shared.EventTypeWorkflowExecutionPauseUpdated:
+			if err := b.msBuilder.ReplicateWorkflowExecutionPauseUpdatedEvent(event); err != nil {
+				return nil, nil, nil, err
+			}
+
+		case shared.EventTypeCronSchedulePauseUpdated:
+			if err := b.msBuilder.ReplicateCronSchedulePauseUpdatedEvent(event); err != nil {
+				return nil, nil, nil, err
+			}
+
 		case shared.EventTypeWorkflowExecutionContinuedAsNew:
 			if len(newRunHistory) == 0 {
 				return nil, nil, nil, errors.NewInternalFailureError(ErrMessageNewRunHistorySizeZero)
@@ -660,8 +688,8 @@ func (b *stateBuilderImpl) scheduleWorkflowTimerTask(event *shared.HistoryEvent,
 	now := time.Unix(0, event.GetTimestamp())
 	timeout := now.Add(time.Duration(msBuilder.GetExecutionInfo().WorkflowTimeout) * time.Second)
 
-	cronSchedule := b.msBuilder.GetExecutionInfo().CronSchedule
-	cronBackoffDuration := backoff.GetBackoffForNextSchedule(cronSchedule, now)
+	executionInfo := b.msBuilder.GetExecutionInfo()
+	cronBackoffDuration := backoff.GetBackoffForNextSchedule(executionInfo.CronSchedule, executionInfo.CronTimezone, now)
 	if cronBackoffDuration != backoff.NoBackoff {
 		timeout = timeout.Add(cronBackoffDuration)
 		timerTasks = append(timerTasks, &persistence.WorkflowBackoffTimerTask{
@@ -671,6 +699,9 @@ func (b *stateBuilderImpl) scheduleWorkflowTimerTask(event *shared.HistoryEvent,
 	}
 
 	timerTasks = append(timerTasks, &persistence.WorkflowTimeoutTask{VisibilityTimestamp: timeout})
+	timerTasks = append(timerTasks, getWorkflowSLATimerTasks(
+		event.WorkflowExecutionStartedEventAttributes.GetSlaDeadlineTimestamp(),
+	)...)
 	return timerTasks
 }
 
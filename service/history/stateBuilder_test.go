@@ -203,7 +203,7 @@ func (s *stateBuilderSuite) applyWorkflowExecutionStartedEventTest(cronSchedule
 
 	expectedTimerTasksLength := 1
 	timeout := now.Add(time.Duration(executionInfo.WorkflowTimeout) * time.Second)
-	backoffDuration := backoff.GetBackoffForNextSchedule(cronSchedule, now)
+	backoffDuration := backoff.GetBackoffForNextSchedule(cronSchedule, "", now)
 	if backoffDuration != backoff.NoBackoff {
 		expectedTimerTasksLength = 2
 		timeout = timeout.Add(backoffDuration)
@@ -1611,6 +1611,7 @@ func (s *stateBuilderSuite) TestApplyEvents_EventTypeDecisionTaskStarted() {
 		DecisionTaskStartedEventAttributes: &shared.DecisionTaskStartedEventAttributes{
 			ScheduledEventId: common.Int64Ptr(scheduleID),
 			RequestId:        common.StringPtr(decisionRequestID),
+			Identity:         common.StringPtr("some random identity"),
 		},
 	}
 	di := &decisionInfo{
@@ -1637,6 +1638,7 @@ func (s *stateBuilderSuite) TestApplyEvents_EventTypeDecisionTaskStarted() {
 	s.True(ok)
 	s.True(timerTask.VisibilityTimestamp.Equal(now.Add(time.Duration(timeoutSecond) * time.Second)))
 	s.Equal(di.ScheduleID, timerTask.EventID)
+	s.Equal("some random identity", di.StartedIdentity)
 
 	s.Empty(s.stateBuilder.transferTasks)
 	s.Empty(s.stateBuilder.newRunTimerTasks)
@@ -1754,6 +1756,65 @@ func (s *stateBuilderSuite) TestApplyEvents_EventTypeDecisionTaskFailed() {
 	s.Empty(s.stateBuilder.newRunTransferTasks)
 }
 
+func (s *stateBuilderSuite) TestApplyEvents_EventTypeDecisionTaskFailed_ResetWorkflow() {
+	version := int64(1)
+	requestID := uuid.New()
+	domainID := validDomainID
+	execution := shared.WorkflowExecution{
+		WorkflowId: common.StringPtr("some random workflow ID"),
+		RunId:      common.StringPtr(validRunID),
+	}
+
+	now := time.Now()
+	scheduleID := int64(12)
+	startedID := int64(28)
+	baseRunID := uuid.New()
+	evenType := shared.EventTypeDecisionTaskFailed
+	event := &shared.HistoryEvent{
+		Version:   common.Int64Ptr(version),
+		EventId:   common.Int64Ptr(130),
+		Timestamp: common.Int64Ptr(now.UnixNano()),
+		EventType: &evenType,
+		DecisionTaskFailedEventAttributes: &shared.DecisionTaskFailedEventAttributes{
+			ScheduledEventId: common.Int64Ptr(scheduleID),
+			StartedEventId:   common.Int64Ptr(startedID),
+			Cause:            common.DecisionTaskFailedCausePtr(shared.DecisionTaskFailedCauseResetWorkflow),
+			Reason:           common.StringPtr("some random reset reason"),
+			BaseRunId:        common.StringPtr(baseRunID),
+		},
+	}
+	s.mockMutableState.On("ReplicateDecisionTaskFailedEvent").Return(nil).Once()
+	tasklist := "some random tasklist"
+	newScheduleID := int64(233)
+	executionInfo := &persistence.WorkflowExecutionInfo{
+		TaskList: tasklist,
+	}
+	s.mockMutableState.On("GetExecutionInfo").Return(executionInfo)
+	s.mockMutableState.On("ReplicateTransientDecisionTaskScheduled").Return(&decisionInfo{
+		Version:    version,
+		ScheduleID: newScheduleID,
+		TaskList:   tasklist,
+	}, nil)
+	s.mockUpdateVersion(event)
+
+	s.mockMutableState.On("ClearStickyness").Once()
+	_, _, _, err := s.stateBuilder.applyEvents(domainID, requestID, execution, s.toHistory(event), nil, 0, 0)
+	s.Nil(err)
+
+	s.Equal([]persistence.Task{&persistence.DecisionTask{
+		DomainID:   domainID,
+		TaskList:   tasklist,
+		ScheduleID: newScheduleID,
+	}}, s.stateBuilder.transferTasks)
+
+	s.Empty(s.stateBuilder.timerTasks)
+	s.Empty(s.stateBuilder.newRunTimerTasks)
+	s.Empty(s.stateBuilder.newRunTransferTasks)
+	s.Equal(baseRunID, executionInfo.ResetBaseRunID)
+	s.Equal(event.GetEventId(), executionInfo.ResetBaseEventID)
+	s.Equal("some random reset reason", executionInfo.ResetReason)
+}
+
 func (s *stateBuilderSuite) TestApplyEvents_EventTypeDecisionTaskCompleted() {
 	version := int64(1)
 	requestID := uuid.New()
@@ -0,0 +1,117 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package history
+
+import (
+	"time"
+
+	"github.com/uber/cadence/common/log"
+	"github.com/uber/cadence/common/log/tag"
+	"github.com/uber/cadence/common/metrics"
+	"github.com/uber/cadence/common/persistence"
+)
+
+type (
+	// stuckQueueDetector watches the ack level of a single shard queue and raises a persisted alarm,
+	// identifying the blocking task, when the ack level stops advancing while new tasks keep being added
+	stuckQueueDetector struct {
+		queue         string
+		scope         int
+		shard         ShardContext
+		config        *Config
+		metricsClient metrics.Client
+		logger        log.Logger
+		maxReadLevel  func() int64
+
+		ackLevel    int64
+		readLevel   int64
+		stuckSince  time.Time
+		alarmRaised bool
+	}
+)
+
+func transferQueueName(cluster string) string {
+	return "transfer:" + cluster
+}
+
+func timerQueueName(cluster string) string {
+	return "timer:" + cluster
+}
+
+func newStuckQueueDetector(queue string, scope int, shard ShardContext,
+	ackLevel int64, maxReadLevel func() int64, logger log.Logger) *stuckQueueDetector {
+	_, alarmRaised := shard.GetQueueAlarms()[queue]
+	return &stuckQueueDetector{
+		queue:         queue,
+		scope:         scope,
+		shard:         shard,
+		config:        shard.GetConfig(),
+		metricsClient: shard.GetMetricsClient(),
+		logger:        logger.WithTags(tag.QueueName(queue)),
+		maxReadLevel:  maxReadLevel,
+		ackLevel:      ackLevel,
+		readLevel:     maxReadLevel(),
+		stuckSince:    shard.GetTimeSource().Now(),
+		alarmRaised:   alarmRaised,
+	}
+}
+
+// check is called by the queue ack manager every time it tries to move the ack level,
+// hasBlockingTask is false when there is no outstanding task holding the ack level back
+func (d *stuckQueueDetector) check(ackLevel int64, hasBlockingTask bool, blockingTaskID int64, blockingTaskTimestamp time.Time) {
+	now := d.shard.GetTimeSource().Now()
+	maxReadLevel := d.maxReadLevel()
+
+	if ackLevel != d.ackLevel || !hasBlockingTask {
+		d.ackLevel = ackLevel
+		d.readLevel = maxReadLevel
+		d.stuckSince = now
+		if d.alarmRaised {
+			if err := d.shard.UpdateQueueAlarm(d.queue, nil); err != nil {
+				d.logger.Error("Error clearing stuck queue alarm", tag.Error(err))
+				return
+			}
+			d.alarmRaised = false
+			d.logger.Info("Stuck queue alarm cleared.")
+		}
+		return
+	}
+
+	if d.alarmRaised || now.Sub(d.stuckSince) < d.config.StuckQueueAlarmThreshold() || maxReadLevel <= d.readLevel {
+		return
+	}
+
+	alarm := &persistence.QueueAlarm{
+		StuckSince:            d.stuckSince,
+		BlockingTaskID:        blockingTaskID,
+		BlockingTaskTimestamp: blockingTaskTimestamp,
+	}
+	if err := d.shard.UpdateQueueAlarm(d.queue, alarm); err != nil {
+		d.logger.Error("Error raising stuck queue alarm", tag.Error(err))
+		return
+	}
+	d.alarmRaised = true
+	d.metricsClient.IncCounter(d.scope, metrics.StuckQueueAlarmCounter)
+	d.logger.Warn("Queue ack level is stuck.",
+		tag.TaskID(blockingTaskID),
+		tag.Timestamp(d.stuckSince),
+		tag.ReadLevel(maxReadLevel))
+}
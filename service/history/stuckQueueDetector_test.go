@@ -0,0 +1,139 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package history
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"github.com/uber-go/tally"
+	"github.com/uber/cadence/common/clock"
+	"github.com/uber/cadence/common/cluster"
+	"github.com/uber/cadence/common/log/loggerimpl"
+	"github.com/uber/cadence/common/metrics"
+	"github.com/uber/cadence/common/mocks"
+	p "github.com/uber/cadence/common/persistence"
+	"github.com/uber/cadence/common/service/dynamicconfig"
+)
+
+type (
+	stuckQueueDetectorSuite struct {
+		suite.Suite
+
+		mockShardMgr        *mocks.ShardManager
+		mockClusterMetadata *mocks.ClusterMetadata
+		timeSource          *clock.EventTimeSource
+		mockShard           *shardContextImpl
+		maxReadLevel        int64
+	}
+)
+
+func TestStuckQueueDetectorSuite(t *testing.T) {
+	s := new(stuckQueueDetectorSuite)
+	suite.Run(t, s)
+}
+
+func (s *stuckQueueDetectorSuite) SetupTest() {
+	s.mockShardMgr = &mocks.ShardManager{}
+	s.mockShardMgr.On("UpdateShard", mock.Anything).Return(nil)
+	s.mockClusterMetadata = &mocks.ClusterMetadata{}
+	s.mockClusterMetadata.On("GetCurrentClusterName").Return(cluster.TestCurrentClusterName)
+	s.timeSource = clock.NewEventTimeSource().Update(time.Now())
+	s.maxReadLevel = 100
+
+	logger := loggerimpl.NewDevelopmentForTest(s.Suite)
+	config := NewDynamicConfigForTest()
+	config.ShardUpdateMinInterval = dynamicconfig.GetDurationPropertyFn(0)
+	config.StuckQueueAlarmThreshold = dynamicconfig.GetDurationPropertyFn(time.Minute)
+	s.mockShard = &shardContextImpl{
+		clusterMetadata: s.mockClusterMetadata,
+		shardInfo: copyShardInfo(&p.ShardInfo{
+			ShardID: 0,
+			RangeID: 1,
+		}),
+		shardManager:  s.mockShardMgr,
+		closeCh:       make(chan int, 100),
+		config:        config,
+		logger:        logger,
+		metricsClient: metrics.NewClient(tally.NoopScope, metrics.History),
+		timeSource:    s.timeSource,
+	}
+}
+
+func (s *stuckQueueDetectorSuite) TearDownTest() {
+	s.mockShardMgr.AssertExpectations(s.T())
+}
+
+func (s *stuckQueueDetectorSuite) newDetector(ackLevel int64) *stuckQueueDetector {
+	return newStuckQueueDetector(
+		transferQueueName(cluster.TestCurrentClusterName),
+		metrics.TransferActiveQueueProcessorScope,
+		s.mockShard,
+		ackLevel,
+		func() int64 { return s.maxReadLevel },
+		s.mockShard.logger,
+	)
+}
+
+func (s *stuckQueueDetectorSuite) TestCheck_RaiseAndClearAlarm() {
+	queue := transferQueueName(cluster.TestCurrentClusterName)
+	stuckSince := s.timeSource.Now()
+	detector := s.newDetector(10)
+
+	detector.check(10, true, 11, time.Time{})
+	s.Empty(s.mockShard.GetQueueAlarms())
+
+	s.maxReadLevel = 200
+	s.timeSource.Update(stuckSince.Add(30 * time.Second))
+	detector.check(10, true, 11, time.Time{})
+	s.Empty(s.mockShard.GetQueueAlarms())
+
+	s.timeSource.Update(stuckSince.Add(2 * time.Minute))
+	detector.check(10, true, 11, time.Time{})
+	alarm, ok := s.mockShard.GetQueueAlarms()[queue]
+	s.True(ok)
+	s.Equal(int64(11), alarm.BlockingTaskID)
+	s.Equal(stuckSince, alarm.StuckSince)
+
+	detector.check(20, true, 21, time.Time{})
+	s.Empty(s.mockShard.GetQueueAlarms())
+}
+
+func (s *stuckQueueDetectorSuite) TestCheck_NoNewTasks() {
+	detector := s.newDetector(10)
+
+	s.timeSource.Update(s.timeSource.Now().Add(2 * time.Minute))
+	detector.check(10, true, 11, time.Time{})
+	s.Empty(s.mockShard.GetQueueAlarms())
+}
+
+func (s *stuckQueueDetectorSuite) TestCheck_ClearPersistedAlarm() {
+	queue := transferQueueName(cluster.TestCurrentClusterName)
+	s.mockShard.shardInfo.QueueAlarms = map[string]p.QueueAlarm{
+		queue: {StuckSince: s.timeSource.Now(), BlockingTaskID: 11},
+	}
+	detector := s.newDetector(10)
+
+	detector.check(10, false, 0, time.Time{})
+	s.Empty(s.mockShard.GetQueueAlarms())
+}
@@ -421,7 +421,7 @@ func (tb *timerBuilder) createNewTask(td *timerDetails) persistence.Task {
 	if td.TimerID != "" {
 		tt := tb.pendingUserTimers[td.TimerID]
 		return &persistence.UserTimerTask{
-			VisibilityTimestamp: td.TimerSequenceID.VisibilityTimestamp,
+			VisibilityTimestamp: tb.coalesceUserTimer(td.TimerSequenceID.VisibilityTimestamp),
 			EventID:             tt.StartedID,
 		}
 	} else if td.ActivityID != 0 && td.ActivityID != common.EmptyEventID {
@@ -435,6 +435,21 @@ func (tb *timerBuilder) createNewTask(td *timerDetails) persistence.Task {
 	return nil
 }
 
+// coalesceUserTimer rounds the user timer task up to the end of its coalescing window, the task
+// then fires every user timer of the execution expiring within that window in one go, instead of
+// writing and reading a timer task per user timer
+func (tb *timerBuilder) coalesceUserTimer(visibilityTimestamp time.Time) time.Time {
+	window := tb.config.UserTimerCoalescingWindow()
+	if window <= 0 {
+		return visibilityTimestamp
+	}
+	coalesced := visibilityTimestamp.Truncate(window)
+	if coalesced.Before(visibilityTimestamp) {
+		coalesced = coalesced.Add(window)
+	}
+	return coalesced
+}
+
 func compareTimerIDLess(first *TimerSequenceID, second *TimerSequenceID) bool {
 	if first.VisibilityTimestamp.Before(second.VisibilityTimestamp) {
 		return true
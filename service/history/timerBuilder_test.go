@@ -37,6 +37,7 @@ import (
 	"github.com/uber/cadence/common/log/loggerimpl"
 	"github.com/uber/cadence/common/log/tag"
 	"github.com/uber/cadence/common/persistence"
+	"github.com/uber/cadence/common/service/dynamicconfig"
 )
 
 type (
@@ -173,6 +174,41 @@ func (s *timerBuilderProcessorSuite) TestTimerBuilderMulitpleUserTimer() {
 	s.Equal(int64(203), ti.StartedID)
 }
 
+func (s *timerBuilderProcessorSuite) TestTimerBuilderCoalesceUserTimers() {
+	config := NewDynamicConfigForTest()
+	config.UserTimerCoalescingWindow = dynamicconfig.GetDurationPropertyFn(10 * time.Second)
+	tb := newTimerBuilder(config, s.logger, &mockTimeSource{currTime: time.Now()})
+
+	now := time.Unix(1000, 0)
+	tp1 := &persistence.TimerInfo{TimerID: "tid1", StartedID: 201, TaskID: TimerTaskStatusNone, ExpiryTime: now.Add(1 * time.Second)}
+	tp2 := &persistence.TimerInfo{TimerID: "tid2", StartedID: 202, TaskID: TimerTaskStatusNone, ExpiryTime: now.Add(9 * time.Second)}
+	tp3 := &persistence.TimerInfo{TimerID: "tid3", StartedID: 203, TaskID: TimerTaskStatusNone, ExpiryTime: now.Add(10 * time.Second)}
+	timerInfos := map[string]*persistence.TimerInfo{"tid1": tp1, "tid2": tp2, "tid3": tp3}
+	msb := newMutableStateBuilder(s.mockShard, s.mockEventsCache, s.logger)
+	msb.Load(&persistence.WorkflowMutableState{
+		ExecutionInfo: &persistence.WorkflowExecutionInfo{NextEventID: int64(204)},
+		TimerInfos:    timerInfos,
+	})
+
+	userTimers := tb.GetUserTimers(msb)
+	s.Equal(3, len(userTimers))
+	t1 := tb.GetUserTimerTaskIfNeeded(msb)
+	s.NotNil(t1)
+	s.Equal(int64(201), t1.(*persistence.UserTimerTask).EventID)
+	// all timers expiring within the window are fired by a single task at the end of it
+	s.Equal(now.Add(10*time.Second).Unix(), t1.(*persistence.UserTimerTask).VisibilityTimestamp.Unix())
+	s.True(tb.IsTimerExpired(userTimers[0], t1.GetVisibilityTimestamp()))
+	s.True(tb.IsTimerExpired(userTimers[1], t1.GetVisibilityTimestamp()))
+	s.True(tb.IsTimerExpired(userTimers[2], t1.GetVisibilityTimestamp()))
+
+	t3 := tb.createNewTask(userTimers[2])
+	s.Equal(now.Add(10*time.Second).Unix(), t3.GetVisibilityTimestamp().Unix())
+
+	config.UserTimerCoalescingWindow = dynamicconfig.GetDurationPropertyFn(0)
+	t1 = tb.createNewTask(userTimers[0])
+	s.Equal(tp1.ExpiryTime.Unix(), t1.GetVisibilityTimestamp().Unix())
+}
+
 func (s *timerBuilderProcessorSuite) TestTimerBuilderDuplicateTimerID() {
 	tp := &persistence.TimerInfo{TimerID: "tid-exist", StartedID: 201, TaskID: 101, ExpiryTime: time.Now().Add(10 * time.Second)}
 	timerInfos := map[string]*persistence.TimerInfo{"tid-exist": tp}
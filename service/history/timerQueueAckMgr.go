@@ -70,8 +70,11 @@ type (
 		minQueryLevel time.Time
 		maxQueryLevel time.Time
 		pageToken     []byte
+		batchSizer    *queueBatchSizer
 
 		clusterName string
+		// stuckQueueDetector is optional, failover ack managers do not raise alarms
+		stuckQueueDetector *stuckQueueDetector
 	}
 	// for each cluster, the ack level is the point in time when
 	// all timers before the ack level are processed.
@@ -118,6 +121,7 @@ func newTimerQueueAckMgr(scope int, shard ShardContext, metricsClient metrics.Cl
 		readLevel:           ackLevel,
 		minQueryLevel:       ackLevel.VisibilityTimestamp,
 		pageToken:           nil,
+		batchSizer:          newTimerQueueBatchSizer(shard.GetConfig()),
 		maxQueryLevel:       ackLevel.VisibilityTimestamp,
 		isReadFinished:      false,
 		finishedChan:        nil,
@@ -149,6 +153,7 @@ func newTimerQueueFailoverAckMgr(shard ShardContext, metricsClient metrics.Clien
 		readLevel:           ackLevel,
 		minQueryLevel:       ackLevel.VisibilityTimestamp,
 		pageToken:           nil,
+		batchSizer:          newTimerQueueBatchSizer(shard.GetConfig()),
 		maxQueryLevel:       maxLevel,
 		isReadFinished:      false,
 		finishedChan:        make(chan struct{}, 1),
@@ -157,6 +162,15 @@ func newTimerQueueFailoverAckMgr(shard ShardContext, metricsClient metrics.Clien
 	return timerQueueAckMgrImpl
 }
 
+func newTimerQueueBatchSizer(config *Config) *queueBatchSizer {
+	return newQueueBatchSizer(
+		config.TimerTaskBatchSize(),
+		config.TimerTaskMinBatchSize,
+		config.TimerTaskMaxBatchSize,
+		config.TimerProcessorReadLatencyTarget,
+	)
+}
+
 func (t *timerQueueAckMgrImpl) getFinishedChan() <-chan struct{} {
 	return t.finishedChan
 }
@@ -173,7 +187,7 @@ func (t *timerQueueAckMgrImpl) readTimerTasks() ([]*persistence.TimerTaskInfo, *
 	morePage := false
 	var err error
 	if minQueryLevel.Before(maxQueryLevel) {
-		tasks, pageToken, err = t.getTimerTasks(minQueryLevel, maxQueryLevel, t.config.TimerTaskBatchSize(), pageToken)
+		tasks, pageToken, err = t.getTimerTasks(minQueryLevel, maxQueryLevel, t.batchSizer.get(), pageToken)
 		if err != nil {
 			return nil, nil, false, err
 		}
@@ -313,6 +327,7 @@ func (t *timerQueueAckMgrImpl) updateAckLevel() {
 		t.metricsClient.RecordTimer(metrics.ShardInfoScope, metrics.ShardInfoTimerStandbyPendingTasksTimer, time.Duration(pendingTasks))
 	}
 
+	var blockingTask *TimerSequenceID
 MoveAckLevelLoop:
 	for _, current := range sequenceIDs {
 		acked := outstandingTasks[current]
@@ -321,6 +336,7 @@ MoveAckLevelLoop:
 			delete(outstandingTasks, current)
 			t.logger.Debug(fmt.Sprintf("Moving timer ack level to %v.", ackLevel))
 		} else {
+			blockingTask = &current
 			break MoveAckLevelLoop
 		}
 	}
@@ -337,6 +353,13 @@ MoveAckLevelLoop:
 	}
 
 	t.Unlock()
+	if t.stuckQueueDetector != nil {
+		if blockingTask != nil {
+			t.stuckQueueDetector.check(ackLevel.VisibilityTimestamp.UnixNano(), true, blockingTask.TaskID, blockingTask.VisibilityTimestamp)
+		} else {
+			t.stuckQueueDetector.check(ackLevel.VisibilityTimestamp.UnixNano(), false, 0, time.Time{})
+		}
+	}
 	if err := t.updateTimerAckLevel(ackLevel); err != nil {
 		t.metricsClient.IncCounter(t.scope, metrics.AckLevelUpdateFailedCounter)
 		t.logger.Error("Error updating timer ack level for shard", tag.Error(err))
@@ -355,10 +378,13 @@ func (t *timerQueueAckMgrImpl) getTimerTasks(minTimestamp time.Time, maxTimestam
 
 	retryCount := t.config.TimerProcessorGetFailureRetryCount()
 	for attempt := 0; attempt < retryCount; attempt++ {
+		startTime := time.Now()
 		response, err := t.executionMgr.GetTimerIndexTasks(request)
 		if err == nil {
+			t.batchSizer.update(time.Since(startTime), len(response.NextPageToken) != 0)
 			return response.Timers, response.NextPageToken, nil
 		}
+		t.batchSizer.update(time.Since(startTime), false)
 		backoff := time.Duration(attempt * 100)
 		time.Sleep(backoff * time.Millisecond)
 	}
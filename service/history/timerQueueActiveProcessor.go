@@ -30,6 +30,7 @@ import (
 	"github.com/uber/cadence/client/matching"
 	"github.com/uber/cadence/common"
 	"github.com/uber/cadence/common/backoff"
+	"github.com/uber/cadence/common/definition"
 	"github.com/uber/cadence/common/log"
 	"github.com/uber/cadence/common/log/tag"
 	"github.com/uber/cadence/common/metrics"
@@ -77,6 +78,12 @@ func newTimerQueueActiveProcessor(shard ShardContext, historyService *historyEng
 		logger,
 		currentClusterName,
 	)
+	timerQueueAckMgr.stuckQueueDetector = newStuckQueueDetector(
+		timerQueueName(currentClusterName), metrics.TimerActiveQueueProcessorScope, shard,
+		timerQueueAckMgr.ackLevel.VisibilityTimestamp.UnixNano(),
+		func() int64 { return shard.GetTimerMaxReadLevel(currentClusterName).UnixNano() },
+		logger,
+	)
 
 	timerGate := NewLocalTimerGate(shard.GetTimeSource())
 	processor := &timerQueueActiveProcessorImpl{
@@ -242,6 +249,12 @@ func (t *timerQueueActiveProcessorImpl) process(timerTask *persistence.TimerTask
 		}
 		return metrics.TimerActiveTaskWorkflowBackoffTimerScope, err
 
+	case persistence.TaskTypeWorkflowSLATimer:
+		if shouldProcessTask {
+			err = t.processWorkflowSLATimer(timerTask)
+		}
+		return metrics.TimerActiveTaskWorkflowSLATimerScope, err
+
 	case persistence.TaskTypeDeleteHistoryEvent:
 		if shouldProcessTask {
 			err = t.timerQueueProcessorBase.processDeleteHistoryEvent(timerTask)
@@ -512,7 +525,7 @@ Update_History_Loop:
 		switch task.TimeoutType {
 		case int(workflow.TimeoutTypeStartToClose):
 			t.metricsClient.IncCounter(metrics.TimerActiveTaskDecisionTimeoutScope, metrics.StartToCloseTimeoutCounter)
-			if di.Attempt == task.ScheduleAttempt {
+			if di.scheduleAttempt() == task.ScheduleAttempt {
 				// Add a decision task timeout event.
 				msBuilder.AddDecisionTaskTimedOutEvent(scheduleID, di.StartedID)
 				scheduleNewDecision = true
@@ -520,7 +533,7 @@ Update_History_Loop:
 		case int(workflow.TimeoutTypeScheduleToStart):
 			t.metricsClient.IncCounter(metrics.TimerActiveTaskDecisionTimeoutScope, metrics.ScheduleToStartTimeoutCounter)
 			// check if scheduled decision still pending and not started yet
-			if di.Attempt == task.ScheduleAttempt && di.StartedID == common.EmptyEventID {
+			if di.scheduleAttempt() == task.ScheduleAttempt && di.StartedID == common.EmptyEventID {
 				_, err := msBuilder.AddDecisionTaskScheduleToStartTimeoutEvent(scheduleID)
 				if err != nil {
 					// Unable to add DecisionTaskTimeout event to history
@@ -577,6 +590,10 @@ Update_History_Loop:
 			// already has decision task
 			return nil
 		}
+		if task.TimeoutType == persistence.WorkflowBackoffTimeoutTypeCron && msBuilder.GetExecutionInfo().CronPaused {
+			// resuming the cron schedule creates a new backoff timer
+			return nil
+		}
 
 		// schedule first decision task
 		err = t.updateWorkflowExecution(context, msBuilder, true, false, nil)
@@ -591,6 +608,73 @@ Update_History_Loop:
 	return ErrMaxAttemptsExceeded
 }
 
+func (t *timerQueueActiveProcessorImpl) processWorkflowSLATimer(task *persistence.TimerTaskInfo) (retError error) {
+
+	context, release, err0 := t.cache.getOrCreateWorkflowExecutionForBackground(t.timerQueueProcessorBase.getDomainIDAndWorkflowExecution(task))
+	if err0 != nil {
+		return err0
+	}
+	defer func() { release(retError) }()
+
+Update_History_Loop:
+	for attempt := 0; attempt < conditionalRetryCount; attempt++ {
+		msBuilder, err := loadMutableStateForTimerTask(context, task, t.metricsClient, t.logger)
+		if err != nil {
+			return err
+		} else if msBuilder == nil || !msBuilder.IsWorkflowExecutionRunning() {
+			return nil
+		}
+
+		executionInfo := msBuilder.GetExecutionInfo()
+		if _, ok := executionInfo.SearchAttributes[definition.SLAExceeded]; ok {
+			return nil
+		}
+		if msBuilder.HasInFlightDecisionTask() {
+			// the upsert event cannot be buffered, retry once the decision is done
+			return ErrTaskRetry
+		}
+
+		// the flag is recorded with an upsert event so that it is replicated to the standby clusters
+		if _, err := msBuilder.AddUpsertWorkflowSearchAttributesEvent(
+			common.EmptyEventID,
+			&workflow.UpsertWorkflowSearchAttributesDecisionAttributes{
+				SearchAttributes: &workflow.SearchAttributes{
+					IndexedFields: map[string][]byte{definition.SLAExceeded: []byte("true")},
+				},
+			},
+		); err != nil {
+			return err
+		}
+
+		// update the visibility record so that the workflow can be listed by its SLAExceeded flag
+		transferTasks := []persistence.Task{&persistence.UpsertWorkflowSearchAttributesTask{}}
+		transactionID, err := t.shard.GetNextTransferTaskID()
+		if err != nil {
+			return err
+		}
+		if err := context.updateAsActive(transferTasks, nil, transactionID); err != nil {
+			if err == ErrConflict {
+				continue Update_History_Loop
+			}
+			if isShardOwnershiptLostError(err) {
+				// Shard is stolen.  Stop timer processing to reduce duplicates
+				t.timerQueueProcessorBase.Stop()
+			}
+			return err
+		}
+
+		domainName := ""
+		if domainEntry, err := t.shard.GetDomainCache().GetDomainByID(task.DomainID); err == nil {
+			domainName = domainEntry.GetInfo().Name
+		}
+		t.metricsClient.Scope(metrics.TimerActiveTaskWorkflowSLATimerScope, metrics.DomainTag(domainName)).
+			IncCounter(metrics.WorkflowSLAExceededCount)
+		return nil
+	}
+
+	return ErrMaxAttemptsExceeded
+}
+
 func (t *timerQueueActiveProcessorImpl) processActivityRetryTimer(task *persistence.TimerTaskInfo) error {
 
 	processFn := func() error {
@@ -630,6 +714,11 @@ func (t *timerQueueActiveProcessorImpl) processActivityRetryTimer(task *persiste
 			return nil
 		}
 
+		if ai.Paused {
+			// activity will be dispatched again when it is unpaused
+			return nil
+		}
+
 		domainID := task.DomainID
 		targetDomainID := domainID
 		scheduledEvent, ok := msBuilder.GetActivityScheduledEvent(scheduledID)
@@ -748,6 +837,7 @@ Update_History_Loop:
 			Initiator:                           continueAsNewInitiator.Ptr(),
 			FailureReason:                       common.StringPtr(timeoutReason),
 			CronSchedule:                        common.StringPtr(msBuilder.GetExecutionInfo().CronSchedule),
+			CronTimezone:                        common.StringPtr(msBuilder.GetExecutionInfo().CronTimezone),
 		}
 		domainEntry, err := getActiveDomainEntryFromShard(t.shard, &domainID)
 		if err != nil {
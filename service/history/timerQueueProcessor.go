@@ -61,6 +61,7 @@ type (
 		shutdownChan           chan struct{}
 		activeTimerProcessor   *timerQueueActiveProcessorImpl
 		standbyTimerProcessors map[string]*timerQueueStandbyProcessorImpl
+		retentionSweeper       *closedExecutionSweeper
 	}
 )
 
@@ -92,6 +93,16 @@ func newTimerQueueProcessor(shard ShardContext, historyService *historyEngineImp
 		}
 	}
 
+	shutdownChan := make(chan struct{})
+	activeTimerProcessor := newTimerQueueActiveProcessor(shard, historyService, matchingClient, taskAllocator, logger)
+	retentionSweeper := newClosedExecutionSweeper(
+		shard,
+		activeTimerProcessor.timerQueueProcessorBase.processDeleteHistoryEvent,
+		historyService.metricsClient,
+		shutdownChan,
+		logger,
+	)
+
 	return &timerQueueProcessorImpl{
 		isGlobalDomainEnabled:  shard.GetService().GetClusterMetadata().IsGlobalDomainEnabled(),
 		currentClusterName:     currentClusterName,
@@ -103,9 +114,10 @@ func newTimerQueueProcessor(shard ShardContext, historyService *historyEngineImp
 		ackLevel:               TimerSequenceID{VisibilityTimestamp: shard.GetTimerAckLevel()},
 		logger:                 logger,
 		matchingClient:         matchingClient,
-		shutdownChan:           make(chan struct{}),
-		activeTimerProcessor:   newTimerQueueActiveProcessor(shard, historyService, matchingClient, taskAllocator, logger),
+		shutdownChan:           shutdownChan,
+		activeTimerProcessor:   activeTimerProcessor,
 		standbyTimerProcessors: standbyTimerProcessors,
+		retentionSweeper:       retentionSweeper,
 	}
 }
 
@@ -120,6 +132,7 @@ func (t *timerQueueProcessorImpl) Start() {
 		}
 	}
 	go t.completeTimersLoop()
+	go t.retentionSweeper.sweepLoop()
 }
 
 func (t *timerQueueProcessorImpl) Stop() {
@@ -37,6 +37,7 @@ import (
 	"github.com/uber/cadence/common/cache"
 	"github.com/uber/cadence/common/clock"
 	"github.com/uber/cadence/common/cluster"
+	"github.com/uber/cadence/common/definition"
 	"github.com/uber/cadence/common/log"
 	"github.com/uber/cadence/common/log/loggerimpl"
 	"github.com/uber/cadence/common/messaging"
@@ -322,6 +323,90 @@ func (s *timerQueueProcessor2Suite) TestWorkflowTimeout() {
 	s.mockHistoryEngine.timerProcessor.(*timerQueueProcessorImpl).activeTimerProcessor.Stop()
 }
 
+func (s *timerQueueProcessor2Suite) TestWorkflowSLATimer() {
+	domainID := testDomainActiveID
+	we := workflow.WorkflowExecution{WorkflowId: common.StringPtr("workflow-sla-test"),
+		RunId: common.StringPtr(validRunID)}
+	taskList := "task-workflow-sla"
+
+	builder := newMutableStateBuilderWithEventV2(s.mockShard, s.mockEventsCache, s.logger, we.GetRunId())
+	s.mockEventsCache.On("putEvent", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything,
+		mock.Anything).Return().Once()
+	mockTS := &mockTimeSource{currTime: time.Now()}
+	startRequest := &workflow.StartWorkflowExecutionRequest{
+		WorkflowType:                        &workflow.WorkflowType{Name: common.StringPtr("wType")},
+		TaskList:                            common.TaskListPtr(workflow.TaskList{Name: common.StringPtr(taskList)}),
+		ExecutionStartToCloseTimeoutSeconds: common.Int32Ptr(100),
+		TaskStartToCloseTimeoutSeconds:      common.Int32Ptr(1),
+		SlaDeadlineTimestamp:                common.Int64Ptr(mockTS.Now().UnixNano()),
+	}
+	builder.AddWorkflowExecutionStartedEvent(we, &history.StartWorkflowExecutionRequest{
+		DomainUUID:   common.StringPtr(domainID),
+		StartRequest: startRequest,
+	})
+
+	addDecisionTaskScheduledEvent(builder)
+
+	waitCh := make(chan struct{})
+
+	taskID := int64(100)
+	timerTask := &persistence.TimerTaskInfo{
+		DomainID:            domainID,
+		WorkflowID:          "wid",
+		RunID:               validRunID,
+		TaskID:              taskID,
+		TaskType:            persistence.TaskTypeWorkflowSLATimer,
+		VisibilityTimestamp: mockTS.Now(),
+	}
+	timerIndexResponse := &persistence.GetTimerIndexTasksResponse{Timers: []*persistence.TimerTaskInfo{timerTask}}
+
+	ms := createMutableState(builder)
+	wfResponse := &persistence.GetWorkflowExecutionResponse{State: ms}
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(wfResponse, nil).Once()
+
+	var appended []*workflow.HistoryEvent
+	s.mockHistoryV2Mgr.On("AppendHistoryNodes", mock.Anything).Return(&p.AppendHistoryNodesResponse{Size: 0}, nil).Run(func(arguments mock.Arguments) {
+		appended = arguments.Get(0).(*p.AppendHistoryNodesRequest).Events
+	}).Once()
+	var updateRequest *p.UpdateWorkflowExecutionRequest
+	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.Anything).Return(&p.UpdateWorkflowExecutionResponse{MutableStateUpdateSessionStats: &p.MutableStateUpdateSessionStats{}}, nil).Run(func(arguments mock.Arguments) {
+		updateRequest = arguments.Get(0).(*p.UpdateWorkflowExecutionRequest)
+		// Done.
+		waitCh <- struct{}{}
+	}).Once()
+
+	// Start timer Processor.
+	emptyResponse := &persistence.GetTimerIndexTasksResponse{Timers: []*persistence.TimerTaskInfo{}}
+	s.mockExecutionMgr.On("GetTimerIndexTasks", mock.Anything).Return(emptyResponse, nil).Run(func(arguments mock.Arguments) {
+		waitCh <- struct{}{}
+	}).Once()
+	s.mockExecutionMgr.On("GetTimerIndexTasks", mock.Anything).Return(emptyResponse, nil).Run(func(arguments mock.Arguments) {
+		waitCh <- struct{}{}
+	}).Once() // for lookAheadTask
+	s.mockHistoryEngine.timerProcessor.(*timerQueueProcessorImpl).activeTimerProcessor.Start()
+	<-waitCh
+	<-waitCh
+
+	s.mockExecutionMgr.On("GetTimerIndexTasks", mock.Anything).Return(timerIndexResponse, nil).Once()
+	s.mockExecutionMgr.On("GetTimerIndexTasks", mock.Anything).Return(emptyResponse, nil) // for lookAheadTask
+	s.mockHistoryEngine.timerProcessor.NotifyNewTimers(
+		cluster.TestCurrentClusterName,
+		s.mockShard.GetCurrentTime(cluster.TestCurrentClusterName),
+		[]persistence.Task{&persistence.WorkflowSLATimerTask{
+			VisibilityTimestamp: timerTask.VisibilityTimestamp,
+		}})
+
+	<-waitCh
+	s.mockHistoryEngine.timerProcessor.(*timerQueueProcessorImpl).activeTimerProcessor.Stop()
+
+	executionInfo := updateRequest.UpdateWorkflowMutation.ExecutionInfo
+	s.Equal([]byte("true"), executionInfo.SearchAttributes[definition.SLAExceeded])
+	s.Equal(persistence.WorkflowStateRunning, executionInfo.State)
+	s.Len(appended, 1)
+	s.Equal(workflow.EventTypeUpsertWorkflowSearchAttributes, appended[0].GetEventType())
+	s.Equal([]byte("true"), appended[0].UpsertWorkflowSearchAttributesEventAttributes.SearchAttributes.IndexedFields[definition.SLAExceeded])
+}
+
 func (s *timerQueueProcessor2Suite) TestWorkflowTimeout_Cron() {
 	domainID := testDomainActiveID
 	we := workflow.WorkflowExecution{WorkflowId: common.StringPtr("workflow-timesout-test"),
@@ -404,3 +489,80 @@ func (s *timerQueueProcessor2Suite) TestWorkflowTimeout_Cron() {
 	<-waitCh
 	s.mockHistoryEngine.timerProcessor.(*timerQueueProcessorImpl).activeTimerProcessor.Stop()
 }
+
+func (s *timerQueueProcessor2Suite) TestWorkflowTimeout_CronTerminated() {
+	domainID := testDomainActiveID
+	we := workflow.WorkflowExecution{WorkflowId: common.StringPtr("workflow-timesout-test"),
+		RunId: common.StringPtr(validRunID)}
+	taskList := "task-workflow-times-out"
+	schedule := "@every 30s"
+
+	builder := newMutableStateBuilderWithEventV2(s.mockShard, s.mockEventsCache, s.logger, we.GetRunId())
+	s.mockEventsCache.On("putEvent", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything,
+		mock.Anything).Return().Once()
+	s.mockEventsCache.On("putEvent", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return().Once()
+	startRequest := &workflow.StartWorkflowExecutionRequest{
+		WorkflowType:                        &workflow.WorkflowType{Name: common.StringPtr("wType")},
+		TaskList:                            common.TaskListPtr(workflow.TaskList{Name: common.StringPtr(taskList)}),
+		ExecutionStartToCloseTimeoutSeconds: common.Int32Ptr(1),
+		TaskStartToCloseTimeoutSeconds:      common.Int32Ptr(1),
+		CronSchedule:                        &schedule,
+	}
+	builder.AddWorkflowExecutionStartedEvent(we, &history.StartWorkflowExecutionRequest{
+		DomainUUID:   common.StringPtr(domainID),
+		StartRequest: startRequest,
+	})
+
+	di := addDecisionTaskScheduledEvent(builder)
+	addDecisionTaskStartedEvent(builder, di.ScheduleID, taskList, uuid.New())
+	// the run was terminated by TerminateAllWorkflowRuns before its timeout fired
+	_, err := builder.AddWorkflowExecutionTerminatedEvent("test reason", nil, "identity")
+	s.NoError(err)
+
+	waitCh := make(chan struct{})
+
+	mockTS := &mockTimeSource{currTime: time.Now()}
+
+	taskID := int64(100)
+	timerTask := &persistence.TimerTaskInfo{
+		DomainID:            domainID,
+		WorkflowID:          "wid",
+		RunID:               validRunID,
+		TaskID:              taskID,
+		TaskType:            persistence.TaskTypeWorkflowTimeout,
+		VisibilityTimestamp: mockTS.Now(),
+		EventID:             di.ScheduleID}
+	timerIndexResponse := &persistence.GetTimerIndexTasksResponse{Timers: []*persistence.TimerTaskInfo{timerTask}}
+
+	ms := createMutableState(builder)
+	wfResponse := &persistence.GetWorkflowExecutionResponse{State: ms}
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(wfResponse, nil).Run(func(arguments mock.Arguments) {
+		waitCh <- struct{}{}
+	}).Once()
+
+	// Start timer Processor.
+	emptyResponse := &persistence.GetTimerIndexTasksResponse{Timers: []*persistence.TimerTaskInfo{}}
+	s.mockExecutionMgr.On("GetTimerIndexTasks", mock.Anything).Return(emptyResponse, nil).Run(func(arguments mock.Arguments) {
+		waitCh <- struct{}{}
+	}).Once()
+	s.mockExecutionMgr.On("GetTimerIndexTasks", mock.Anything).Return(emptyResponse, nil).Run(func(arguments mock.Arguments) {
+		waitCh <- struct{}{}
+	}).Once() // for lookAheadTask
+	s.mockHistoryEngine.timerProcessor.(*timerQueueProcessorImpl).activeTimerProcessor.Start()
+	<-waitCh
+	<-waitCh
+
+	s.mockExecutionMgr.On("GetTimerIndexTasks", mock.Anything).Return(timerIndexResponse, nil).Once()
+	s.mockExecutionMgr.On("GetTimerIndexTasks", mock.Anything).Return(emptyResponse, nil) // for lookAheadTask
+	s.mockHistoryEngine.timerProcessor.NotifyNewTimers(
+		cluster.TestCurrentClusterName,
+		s.mockShard.GetCurrentTime(cluster.TestCurrentClusterName),
+		[]persistence.Task{&persistence.WorkflowTimeoutTask{
+			VisibilityTimestamp: timerTask.VisibilityTimestamp,
+		}})
+
+	<-waitCh
+	s.mockHistoryEngine.timerProcessor.(*timerQueueProcessorImpl).activeTimerProcessor.Stop()
+	s.mockExecutionMgr.AssertNotCalled(s.T(), "UpdateWorkflowExecution", mock.Anything)
+	s.mockHistoryV2Mgr.AssertNotCalled(s.T(), "AppendHistoryNodes", mock.Anything)
+}
@@ -51,6 +51,7 @@ var (
 
 	loadDomainEntryForTimerTaskRetryDelay = 100 * time.Millisecond
 	loadTimerTaskThrottleRetryDelay       = 5 * time.Second
+	frozenDomainTimerTaskRetryDelay       = 30 * time.Second
 )
 
 type (
@@ -267,6 +268,12 @@ func (t *timerQueueProcessorBase) notifyNewTimers(timerTasks []persistence.Task)
 			} else {
 				t.metricsClient.IncCounter(metrics.TimerStandbyTaskWorkflowBackoffTimerScope, metrics.NewTimerCounter)
 			}
+		case persistence.TaskTypeWorkflowSLATimer:
+			if isActive {
+				t.metricsClient.IncCounter(metrics.TimerActiveTaskWorkflowSLATimerScope, metrics.NewTimerCounter)
+			} else {
+				t.metricsClient.IncCounter(metrics.TimerStandbyTaskWorkflowSLATimerScope, metrics.NewTimerCounter)
+			}
 			// TODO add default
 		}
 	}
@@ -454,6 +461,9 @@ FilterLoop:
 				t.ackTaskOnce(task, scope, shouldProcessTask, startTime, attempt)
 				return
 			}
+			if err == persistence.ErrPersistenceDomainFrozen {
+				continue
+			}
 			incAttempt()
 		}
 	}
@@ -469,6 +479,7 @@ func (t *timerQueueProcessorBase) processTaskOnce(notificationChan <-chan struct
 	scope, err := t.timerProcessor.process(task, shouldProcessTask)
 	if shouldProcessTask {
 		t.metricsClient.IncCounter(scope, metrics.TaskRequests)
+		t.shard.RecordTaskProcessed()
 		t.metricsClient.RecordTimer(scope, metrics.TaskProcessingLatency, time.Since(startTime))
 	}
 
@@ -498,6 +509,16 @@ func (t *timerQueueProcessorBase) handleTaskError(scope int, startTime time.Time
 		err = nil
 	}
 
+	// the writes of the domain are frozen for a migration, park the task until the freeze is lifted
+	if err == persistence.ErrPersistenceDomainFrozen {
+		t.metricsClient.IncCounter(scope, metrics.TaskDomainFrozenCounter)
+		select {
+		case <-t.shutdownCh:
+		case <-time.After(frozenDomainTimerTaskRetryDelay):
+		}
+		return err
+	}
+
 	// this is a transient error
 	if _, ok := err.(*workflow.DomainNotActiveError); ok {
 		if t.timeSource.Now().Sub(startTime) > cache.DomainCacheRefreshInterval {
@@ -691,11 +712,12 @@ func (t *timerQueueProcessorBase) deleteWorkflowExecution(task *persistence.Time
 
 func (t *timerQueueProcessorBase) deleteCurrentWorkflowExecution(task *persistence.TimerTaskInfo) error {
 	op := func() error {
-		return t.executionManager.DeleteCurrentWorkflowExecution(&persistence.DeleteCurrentWorkflowExecutionRequest{
+		_, err := t.executionManager.DeleteCurrentWorkflowExecution(&persistence.DeleteCurrentWorkflowExecutionRequest{
 			DomainID:   task.DomainID,
 			WorkflowID: task.WorkflowID,
 			RunID:      task.RunID,
 		})
+		return err
 	}
 	return backoff.Retry(op, persistenceOperationRetryPolicy, common.IsPersistenceTransientError)
 }
@@ -745,6 +767,8 @@ func (t *timerQueueProcessorBase) getTimerTaskType(taskType int) string {
 		return "ActivityRetryTimerTask"
 	case persistence.TaskTypeWorkflowBackoffTimer:
 		return "WorkflowBackoffTimerTask"
+	case persistence.TaskTypeWorkflowSLATimer:
+		return "WorkflowSLATimerTask"
 	}
 	return "UnKnown"
 }
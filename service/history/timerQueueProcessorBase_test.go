@@ -202,7 +202,7 @@ func (s *timerQueueProcessorBaseSuite) TestDeleteWorkflow_NoErr() {
 	}
 	ctx := newWorkflowExecutionContext(task.DomainID, executionInfo, s.mockShard, s.mockExecutionManager, log.NewNoop())
 	ms := &mockMutableState{}
-	s.mockExecutionManager.On("DeleteCurrentWorkflowExecution", mock.Anything).Return(nil).Once()
+	s.mockExecutionManager.On("DeleteCurrentWorkflowExecution", mock.Anything).Return(&persistence.DeleteCurrentWorkflowExecutionResponse{}, nil).Once()
 	s.mockExecutionManager.On("DeleteWorkflowExecution", mock.Anything).Return(nil).Once()
 	s.mockExecutionManager.On("DeleteWorkflowExecutionHistoryV2", mock.Anything, mock.Anything).Return(nil).Once()
 	s.mockExecutionManager.On("DeleteExecutionFromVisibility", mock.Anything).Return(nil).Once()
@@ -239,6 +239,17 @@ func (s *timerQueueProcessorBaseSuite) TestHandleTaskError_ErrTaskDiscarded() {
 	s.Nil(s.timerQueueProcessor.handleTaskError(s.scope, time.Now(), s.notificationChan, err, s.logger))
 }
 
+func (s *timerQueueProcessorBaseSuite) TestHandleTaskError_DomainFrozen() {
+	delay := frozenDomainTimerTaskRetryDelay
+	defer func() { frozenDomainTimerTaskRetryDelay = delay }()
+	frozenDomainTimerTaskRetryDelay = time.Second
+
+	startTime := time.Now()
+	err := s.timerQueueProcessor.handleTaskError(s.scope, time.Now(), s.notificationChan, persistence.ErrPersistenceDomainFrozen, s.logger)
+	s.True(time.Since(startTime) >= time.Second)
+	s.Equal(persistence.ErrPersistenceDomainFrozen, err)
+}
+
 func (s *timerQueueProcessorBaseSuite) TestHandleTaskError_DomainNotActiveError() {
 	err := &workflow.DomainNotActiveError{}
 
@@ -82,6 +82,12 @@ func newTimerQueueStandbyProcessor(shard ShardContext, historyService *historyEn
 		logger,
 		clusterName,
 	)
+	timerQueueAckMgr.stuckQueueDetector = newStuckQueueDetector(
+		timerQueueName(clusterName), metrics.TimerStandbyQueueProcessorScope, shard,
+		timerQueueAckMgr.ackLevel.VisibilityTimestamp.UnixNano(),
+		func() int64 { return shard.GetTimerMaxReadLevel(clusterName).UnixNano() },
+		logger,
+	)
 	processor := &timerQueueStandbyProcessorImpl{
 		shard:           shard,
 		clusterMetadata: shard.GetService().GetClusterMetadata(),
@@ -177,6 +183,10 @@ func (t *timerQueueStandbyProcessorImpl) process(timerTask *persistence.TimerTas
 		}
 		return metrics.TimerStandbyTaskWorkflowBackoffTimerScope, err
 
+	case persistence.TaskTypeWorkflowSLATimer:
+		// the SLA is only flagged by the active cluster, there is no history to verify
+		return metrics.TimerStandbyTaskWorkflowSLATimerScope, err
+
 	case persistence.TaskTypeDeleteHistoryEvent:
 		// guarantee the processing of workflow execution history deletion
 		return metrics.TimerStandbyTaskDeleteHistoryEventScope, t.timerQueueProcessorBase.processDeleteHistoryEvent(timerTask)
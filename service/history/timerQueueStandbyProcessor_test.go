@@ -589,7 +589,7 @@ func (s *timerQueueStandbyProcessorSuite) TestProcessActivityTimeout_Multiple_Ca
 				UpsertSignalInfos:         []*persistence.SignalInfo{},
 				DeleteSignalInfo:          nil,
 				UpsertSignalRequestedIDs:  []string{},
-				DeleteSignalRequestedID:   "",
+				DeleteSignalRequestedIDs:  []string{},
 				NewBufferedEvents:         nil,
 				ClearBufferedEvents:       false,
 			},
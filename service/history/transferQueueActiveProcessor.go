@@ -108,6 +108,10 @@ func newTransferQueueActiveProcessor(shard ShardContext, historyService *history
 	}
 
 	queueAckMgr := newQueueAckMgr(shard, options, processor, shard.GetTransferClusterAckLevel(currentClusterName), logger)
+	queueAckMgr.stuckQueueDetector = newStuckQueueDetector(
+		transferQueueName(currentClusterName), options.MetricScope, shard,
+		queueAckMgr.ackLevel, shard.GetTransferMaxReadLevel, logger,
+	)
 	queueProcessorBase := newQueueProcessorBase(currentClusterName, shard, options, processor, queueAckMgr, logger)
 	processor.queueAckMgr = queueAckMgr
 	processor.queueProcessorBase = queueProcessorBase
@@ -260,6 +264,12 @@ func (t *transferQueueActiveProcessorImpl) process(qTask queueTaskInfo, shouldPr
 		}
 		return metrics.TransferActiveTaskUpsertWorkflowSearchAttributesScope, err
 
+	case persistence.TransferTaskTypeRecordChildExecutionCompleted:
+		if shouldProcessTask {
+			err = t.processRecordChildExecutionCompleted(task)
+		}
+		return metrics.TransferActiveTaskRecordChildExecutionCompletedScope, err
+
 	default:
 		return metrics.TransferActiveQueueProcessorScope, errUnknownTransferTask
 	}
@@ -298,6 +308,11 @@ func (t *transferQueueActiveProcessorImpl) processActivityTask(task *persistence
 		return nil
 	}
 
+	if ai.Paused {
+		// activity will be dispatched again when it is unpaused
+		return nil
+	}
+
 	timeout := common.MinInt32(ai.ScheduleToStartTimeout, common.MaxTaskTimeout)
 	// release the context lock since we no longer need mutable state builder and
 	// the rest of logic is making RPC call, which takes time.
@@ -343,8 +358,14 @@ func (t *transferQueueActiveProcessorImpl) processDecisionTask(task *persistence
 	}
 
 	executionInfo := msBuilder.GetExecutionInfo()
+	if executionInfo.Paused {
+		// decision will be dispatched again when the workflow is resumed
+		return nil
+	}
+
 	workflowTimeout := executionInfo.WorkflowTimeout
 	decisionTimeout := common.MinInt32(workflowTimeout, common.MaxTaskTimeout)
+	workerBuildID := getWorkerBuildID(executionInfo)
 
 	// NOTE: previously this section check whether mutable state has enabled
 	// sticky decision, if so convert the decision to a sticky decision.
@@ -361,7 +382,7 @@ func (t *transferQueueActiveProcessorImpl) processDecisionTask(task *persistence
 	// release the context lock since we no longer need mutable state builder and
 	// the rest of logic is making RPC call, which takes time.
 	release(nil)
-	return t.pushDecision(task, tasklist, decisionTimeout)
+	return t.pushDecision(task, tasklist, decisionTimeout, workerBuildID)
 }
 
 func (t *transferQueueActiveProcessorImpl) processCloseExecution(task *persistence.TransferTaskInfo) (retError error) {
@@ -396,18 +417,12 @@ func (t *transferQueueActiveProcessorImpl) processCloseExecution(task *persisten
 	}
 
 	executionInfo := msBuilder.GetExecutionInfo()
-	replyToParentWorkflow := msBuilder.HasParentExecution() && executionInfo.CloseStatus != persistence.WorkflowCloseStatusContinuedAsNew
 	completionEvent, ok := msBuilder.GetCompletionEvent()
 	if !ok {
 		return &workflow.InternalServiceError{Message: "Unable to get workflow completion event."}
 	}
 	wfCloseTime := completionEvent.GetTimestamp()
 
-	parentDomainID := executionInfo.ParentDomainID
-	parentWorkflowID := executionInfo.ParentWorkflowID
-	parentRunID := executionInfo.ParentRunID
-	initiatedID := executionInfo.InitiatedID
-
 	workflowTypeName := executionInfo.WorkflowTypeName
 	workflowStartTimestamp := executionInfo.StartTimestamp.UnixNano()
 	workflowCloseTimestamp := wfCloseTime
@@ -425,36 +440,67 @@ func (t *transferQueueActiveProcessorImpl) processCloseExecution(task *persisten
 	// release the context lock since we no longer need mutable state builder and
 	// the rest of logic is making RPC call, which takes time.
 	release(nil)
-	err = t.recordWorkflowClosed(
+	return t.recordWorkflowClosed(
 		domainID, execution, workflowTypeName, workflowStartTimestamp, workflowExecutionTimestamp.UnixNano(),
 		workflowCloseTimestamp, workflowCloseStatus, workflowHistoryLength, task.GetTaskID(), visibilityMemo,
-		searchAttr,
+		searchAttr, executionInfo.FirstExecutionRunID,
 	)
+}
+
+func (t *transferQueueActiveProcessorImpl) processRecordChildExecutionCompleted(task *persistence.TransferTaskInfo) (retError error) {
+
+	var err error
+	domainID := task.DomainID
+	execution := workflow.WorkflowExecution{
+		WorkflowId: common.StringPtr(task.WorkflowID),
+		RunId:      common.StringPtr(task.RunID),
+	}
+
+	context, release, err := t.cache.getOrCreateWorkflowExecutionForBackground(domainID, execution)
 	if err != nil {
 		return err
 	}
+	defer func() { release(retError) }()
 
-	// Communicate the result to parent execution if this is Child Workflow execution
-	if replyToParentWorkflow {
-		err = t.historyClient.RecordChildExecutionCompleted(nil, &h.RecordChildExecutionCompletedRequest{
-			DomainUUID: common.StringPtr(parentDomainID),
-			WorkflowExecution: &workflow.WorkflowExecution{
-				WorkflowId: common.StringPtr(parentWorkflowID),
-				RunId:      common.StringPtr(parentRunID),
-			},
-			InitiatedId: common.Int64Ptr(initiatedID),
-			CompletedExecution: &workflow.WorkflowExecution{
-				WorkflowId: common.StringPtr(task.WorkflowID),
-				RunId:      common.StringPtr(task.RunID),
-			},
-			CompletionEvent: completionEvent,
-		})
+	var msBuilder mutableState
+	msBuilder, err = loadMutableStateForTransferTask(context, task, t.metricsClient, t.logger)
+	if err != nil {
+		return err
+	} else if msBuilder == nil || msBuilder.IsWorkflowExecutionRunning() {
+		// this can happen if workflow is reset.
+		return nil
+	}
 
-		// Check to see if the error is non-transient, in which case reset the error and continue with processing
-		switch err.(type) {
-		case *workflow.EntityNotExistsError:
-			err = nil
-		}
+	ok, err := verifyTaskVersion(t.shard, t.logger, domainID, msBuilder.GetLastWriteVersion(), task.Version, task)
+	if err != nil {
+		return err
+	} else if !ok {
+		return nil
+	}
+
+	completionEvent, ok := msBuilder.GetCompletionEvent()
+	if !ok {
+		return &workflow.InternalServiceError{Message: "Unable to get workflow completion event."}
+	}
+
+	// release the context lock since we no longer need mutable state builder and
+	// the rest of logic is making RPC call, which takes time.
+	release(nil)
+	err = t.historyClient.RecordChildExecutionCompleted(nil, &h.RecordChildExecutionCompletedRequest{
+		DomainUUID: common.StringPtr(task.TargetDomainID),
+		WorkflowExecution: &workflow.WorkflowExecution{
+			WorkflowId: common.StringPtr(task.TargetWorkflowID),
+			RunId:      common.StringPtr(task.TargetRunID),
+		},
+		InitiatedId:        common.Int64Ptr(task.ScheduleID),
+		CompletedExecution: &execution,
+		CompletionEvent:    completionEvent,
+	})
+
+	// Check to see if the error is non-transient, in which case reset the error and continue with processing
+	switch err.(type) {
+	case *workflow.EntityNotExistsError:
+		err = nil
 	}
 	return err
 }
@@ -792,6 +838,7 @@ func (t *transferQueueActiveProcessorImpl) processStartChildExecution(task *pers
 				ChildPolicy:           attributes.ChildPolicy,
 				RetryPolicy:           attributes.RetryPolicy,
 				CronSchedule:          attributes.CronSchedule,
+				CronTimezone:          attributes.CronTimezone,
 			},
 			ParentExecutionInfo: &h.ParentExecutionInfo{
 				DomainUUID: common.StringPtr(domainID),
@@ -802,7 +849,7 @@ func (t *transferQueueActiveProcessorImpl) processStartChildExecution(task *pers
 				},
 				InitiatedId: common.Int64Ptr(initiatedEventID),
 			},
-			FirstDecisionTaskBackoffSeconds: common.Int32Ptr(backoff.GetBackoffForNextScheduleInSeconds(attributes.GetCronSchedule(), t.timeSource.Now())),
+			FirstDecisionTaskBackoffSeconds: common.Int32Ptr(backoff.GetBackoffForNextScheduleInSeconds(attributes.GetCronSchedule(), attributes.GetCronTimezone(), t.timeSource.Now())),
 		}
 
 		var startResponse *workflow.StartWorkflowExecutionResponse
@@ -904,10 +951,10 @@ func (t *transferQueueActiveProcessorImpl) processRecordWorkflowStartedOrUpsertH
 
 	if isRecordStart {
 		return t.recordWorkflowStarted(task.DomainID, execution, wfTypeName, startTimestamp, executionTimestamp.UnixNano(),
-			workflowTimeout, task.GetTaskID(), visibilityMemo, searchAttr)
+			workflowTimeout, task.GetTaskID(), visibilityMemo, searchAttr, executionInfo.FirstExecutionRunID)
 	}
 	return t.upsertWorkflowExecution(task.DomainID, execution, wfTypeName, startTimestamp, executionTimestamp.UnixNano(),
-		workflowTimeout, task.GetTaskID(), visibilityMemo, searchAttr)
+		workflowTimeout, task.GetTaskID(), visibilityMemo, searchAttr, executionInfo.FirstExecutionRunID)
 }
 
 func copySearchAttributes(input map[string][]byte) map[string][]byte {
@@ -632,13 +632,6 @@ func (s *transferQueueActiveProcessorSuite) TestProcessCloseExecution_HasParent(
 
 	persistenceMutableState := createMutableState(msBuilder)
 	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(&persistence.GetWorkflowExecutionResponse{State: persistenceMutableState}, nil)
-	s.mockHistoryClient.On("RecordChildExecutionCompleted", nil, &history.RecordChildExecutionCompletedRequest{
-		DomainUUID:         common.StringPtr(parentDomainID),
-		WorkflowExecution:  &parentExecution,
-		InitiatedId:        common.Int64Ptr(parentInitiatedID),
-		CompletedExecution: &execution,
-		CompletionEvent:    event,
-	}).Return(nil).Once()
 	s.mockVisibilityMgr.On("RecordWorkflowExecutionClosed", mock.Anything).Return(nil).Once()
 
 	_, err = s.transferQueueActiveProcessor.process(transferTask, true)
@@ -698,6 +691,81 @@ func (s *transferQueueActiveProcessorSuite) TestProcessCloseExecution_NoParent()
 	s.Nil(err)
 }
 
+func (s *transferQueueActiveProcessorSuite) TestProcessRecordChildExecutionCompleted() {
+	domainID := "some random domain ID"
+	execution := workflow.WorkflowExecution{
+		WorkflowId: common.StringPtr("some random workflow ID"),
+		RunId:      common.StringPtr(uuid.New()),
+	}
+	workflowType := "some random workflow type"
+	taskListName := "some random task list"
+
+	parentDomainID := "some random parent domain ID"
+	parentInitiatedID := int64(3222)
+	parentDomainName := "some random parent domain Name"
+	parentExecution := workflow.WorkflowExecution{
+		WorkflowId: common.StringPtr("some random parent workflow ID"),
+		RunId:      common.StringPtr(uuid.New()),
+	}
+
+	msBuilder := newMutableStateBuilderWithReplicationStateWithEventV2(s.mockShard, s.mockShard.GetEventsCache(), s.logger, s.version, execution.GetRunId())
+	_, err := msBuilder.AddWorkflowExecutionStartedEvent(
+		execution,
+		&history.StartWorkflowExecutionRequest{
+			DomainUUID: common.StringPtr(domainID),
+			StartRequest: &workflow.StartWorkflowExecutionRequest{
+				WorkflowType:                        &workflow.WorkflowType{Name: common.StringPtr(workflowType)},
+				TaskList:                            &workflow.TaskList{Name: common.StringPtr(taskListName)},
+				ExecutionStartToCloseTimeoutSeconds: common.Int32Ptr(2),
+				TaskStartToCloseTimeoutSeconds:      common.Int32Ptr(1),
+			},
+			ParentExecutionInfo: &history.ParentExecutionInfo{
+				DomainUUID:  common.StringPtr(parentDomainID),
+				Domain:      common.StringPtr(parentDomainName),
+				Execution:   &parentExecution,
+				InitiatedId: common.Int64Ptr(parentInitiatedID),
+			},
+		},
+	)
+	s.Nil(err)
+
+	di := addDecisionTaskScheduledEvent(msBuilder)
+	event := addDecisionTaskStartedEvent(msBuilder, di.ScheduleID, taskListName, uuid.New())
+	di.StartedID = event.GetEventId()
+	event = addDecisionTaskCompletedEvent(msBuilder, di.ScheduleID, di.StartedID, nil, "some random identity")
+
+	taskID := int64(59)
+	event = addCompleteWorkflowEvent(msBuilder, event.GetEventId(), nil)
+	s.mockClusterMetadata.On("ClusterNameForFailoverVersion", s.version).Return(s.mockClusterMetadata.GetCurrentClusterName())
+	msBuilder.UpdateReplicationStateLastEventID(s.version, event.GetEventId())
+
+	transferTask := &persistence.TransferTaskInfo{
+		Version:          s.version,
+		DomainID:         domainID,
+		WorkflowID:       execution.GetWorkflowId(),
+		RunID:            execution.GetRunId(),
+		TargetDomainID:   parentDomainID,
+		TargetWorkflowID: parentExecution.GetWorkflowId(),
+		TargetRunID:      parentExecution.GetRunId(),
+		TaskID:           taskID,
+		TaskType:         persistence.TransferTaskTypeRecordChildExecutionCompleted,
+		ScheduleID:       parentInitiatedID,
+	}
+
+	persistenceMutableState := createMutableState(msBuilder)
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(&persistence.GetWorkflowExecutionResponse{State: persistenceMutableState}, nil)
+	s.mockHistoryClient.On("RecordChildExecutionCompleted", nil, &history.RecordChildExecutionCompletedRequest{
+		DomainUUID:         common.StringPtr(parentDomainID),
+		WorkflowExecution:  &parentExecution,
+		InitiatedId:        common.Int64Ptr(parentInitiatedID),
+		CompletedExecution: &execution,
+		CompletionEvent:    event,
+	}).Return(nil).Once()
+
+	_, err = s.transferQueueActiveProcessor.process(transferTask, true)
+	s.Nil(err)
+}
+
 func (s *transferQueueActiveProcessorSuite) TestProcessCancelExecution_Success() {
 	domainID := "some random domain ID"
 	execution := workflow.WorkflowExecution{
@@ -1622,6 +1690,7 @@ func (s *transferQueueActiveProcessorSuite) createAddDecisionTaskRequest(task *p
 		TaskList:                      taskList,
 		ScheduleId:                    common.Int64Ptr(task.ScheduleID),
 		ScheduleToStartTimeoutSeconds: common.Int32Ptr(timeout),
+		WorkerBuildId:                 getWorkerBuildID(executionInfo),
 	}
 }
 
@@ -1733,7 +1802,7 @@ func (s *transferQueueActiveProcessorSuite) createChildWorkflowExecutionRequest(
 			Execution:   &execution,
 			InitiatedId: common.Int64Ptr(task.ScheduleID),
 		},
-		FirstDecisionTaskBackoffSeconds: common.Int32Ptr(backoff.GetBackoffForNextScheduleInSeconds(attributes.GetCronSchedule(), time.Now())),
+		FirstDecisionTaskBackoffSeconds: common.Int32Ptr(backoff.GetBackoffForNextScheduleInSeconds(attributes.GetCronSchedule(), attributes.GetCronTimezone(), time.Now())),
 	}
 }
 
@@ -91,7 +91,7 @@ func newTransferQueueProcessor(shard ShardContext, historyService *historyEngine
 			)
 			standbyTaskProcessors[clusterName] = newTransferQueueStandbyProcessor(
 				clusterName, shard, historyService, visibilityMgr,
-				matchingClient, taskAllocator, historyRereplicator, logger,
+				matchingClient, historyClient, taskAllocator, historyRereplicator, logger,
 			)
 		}
 	}
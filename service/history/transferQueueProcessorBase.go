@@ -47,7 +47,16 @@ type (
 		maxReadAckLevel        maxReadAckLevel
 		updateTransferAckLevel updateTransferAckLevel
 		transferQueueShutdown  transferQueueShutdown
+		batchSizer             *queueBatchSizer
 		logger                 log.Logger
+		*visibilityRecorder
+	}
+
+	// visibilityRecorder writes workflow executions to the visibility store,
+	// shared by the transfer and visibility queue processors
+	visibilityRecorder struct {
+		shard         ShardContext
+		visibilityMgr persistence.VisibilityManager
 	}
 )
 
@@ -57,6 +66,7 @@ func newTransferQueueProcessorBase(shard ShardContext, options *QueueProcessorOp
 	visibilityMgr persistence.VisibilityManager, matchingClient matching.Client,
 	maxReadAckLevel maxReadAckLevel, updateTransferAckLevel updateTransferAckLevel,
 	transferQueueShutdown transferQueueShutdown, logger log.Logger) *transferQueueProcessorBase {
+	config := shard.GetConfig()
 	return &transferQueueProcessorBase{
 		shard:                  shard,
 		options:                options,
@@ -66,27 +76,45 @@ func newTransferQueueProcessorBase(shard ShardContext, options *QueueProcessorOp
 		maxReadAckLevel:        maxReadAckLevel,
 		updateTransferAckLevel: updateTransferAckLevel,
 		transferQueueShutdown:  transferQueueShutdown,
-		logger:                 logger,
+		batchSizer: newQueueBatchSizer(
+			options.BatchSize(),
+			config.TransferTaskMinBatchSize,
+			config.TransferTaskMaxBatchSize,
+			config.TransferProcessorReadLatencyTarget,
+		),
+		logger:             logger,
+		visibilityRecorder: newVisibilityRecorder(shard, visibilityMgr),
+	}
+}
+
+func newVisibilityRecorder(shard ShardContext, visibilityMgr persistence.VisibilityManager) *visibilityRecorder {
+	return &visibilityRecorder{
+		shard:         shard,
+		visibilityMgr: visibilityMgr,
 	}
 }
 
 func (t *transferQueueProcessorBase) readTasks(readLevel int64) ([]queueTaskInfo, bool, error) {
+	startTime := time.Now()
 	response, err := t.executionManager.GetTransferTasks(&persistence.GetTransferTasksRequest{
 		ReadLevel:    readLevel,
 		MaxReadLevel: t.maxReadAckLevel(),
-		BatchSize:    t.options.BatchSize(),
+		BatchSize:    t.batchSizer.get(),
 	})
 
 	if err != nil {
+		t.batchSizer.update(time.Since(startTime), false)
 		return nil, false, err
 	}
+	morePage := len(response.NextPageToken) != 0
+	t.batchSizer.update(time.Since(startTime), morePage)
 
 	tasks := make([]queueTaskInfo, len(response.Tasks))
 	for i := range response.Tasks {
 		tasks[i] = response.Tasks[i]
 	}
 
-	return tasks, len(response.NextPageToken) != 0, nil
+	return tasks, morePage, nil
 }
 
 func (t *transferQueueProcessorBase) updateAckLevel(ackLevel int64) error {
@@ -117,7 +145,12 @@ func (t *transferQueueProcessorBase) pushActivity(task *persistence.TransferTask
 	return err
 }
 
-func (t *transferQueueProcessorBase) pushDecision(task *persistence.TransferTaskInfo, tasklist *workflow.TaskList, decisionScheduleToStartTimeout int32) error {
+func (t *transferQueueProcessorBase) pushDecision(
+	task *persistence.TransferTaskInfo,
+	tasklist *workflow.TaskList,
+	decisionScheduleToStartTimeout int32,
+	workerBuildID *string,
+) error {
 	if task.TaskType != persistence.TransferTaskTypeDecisionTask {
 		t.logger.Fatal("Cannot process non decision task", tag.TaskType(task.GetTaskType()))
 	}
@@ -131,21 +164,32 @@ func (t *transferQueueProcessorBase) pushDecision(task *persistence.TransferTask
 		TaskList:                      tasklist,
 		ScheduleId:                    common.Int64Ptr(task.ScheduleID),
 		ScheduleToStartTimeoutSeconds: common.Int32Ptr(decisionScheduleToStartTimeout),
+		WorkerBuildId:                 workerBuildID,
 	})
 
 	return err
 }
 
-func (t *transferQueueProcessorBase) recordWorkflowStarted(
+// getWorkerBuildID returns the binary checksum of the worker which last completed a decision
+// with a new binary, used by matching to dispatch the decision to a compatible worker build
+func getWorkerBuildID(executionInfo *persistence.WorkflowExecutionInfo) *string {
+	if executionInfo.AutoResetPoints == nil || len(executionInfo.AutoResetPoints.Points) == 0 {
+		return nil
+	}
+	points := executionInfo.AutoResetPoints.Points
+	return points[len(points)-1].BinaryChecksum
+}
+
+func (v *visibilityRecorder) recordWorkflowStarted(
 	domainID string, execution workflow.WorkflowExecution, workflowTypeName string, startTimeUnixNano,
 	executionTimeUnixNano int64, workflowTimeout int32, taskID int64, visibilityMemo *workflow.Memo,
-	searchAttributes map[string][]byte) error {
+	searchAttributes map[string][]byte, firstExecutionRunID string) error {
 
 	domain := defaultDomainName
 	isSampledEnabled := false
 	wid := execution.GetWorkflowId()
 
-	domainEntry, err := t.shard.GetDomainCache().GetDomainByID(domainID)
+	domainEntry, err := v.shard.GetDomainCache().GetDomainByID(domainID)
 	if err != nil {
 		if _, ok := err.(*workflow.EntityNotExistsError); !ok {
 			return err
@@ -161,28 +205,29 @@ func (t *transferQueueProcessorBase) recordWorkflowStarted(
 	}
 
 	request := &persistence.RecordWorkflowExecutionStartedRequest{
-		DomainUUID:         domainID,
-		Domain:             domain,
-		Execution:          execution,
-		WorkflowTypeName:   workflowTypeName,
-		StartTimestamp:     startTimeUnixNano,
-		ExecutionTimestamp: executionTimeUnixNano,
-		WorkflowTimeout:    int64(workflowTimeout),
-		TaskID:             taskID,
-		Memo:               visibilityMemo,
-		SearchAttributes:   searchAttributes,
+		DomainUUID:          domainID,
+		Domain:              domain,
+		Execution:           execution,
+		WorkflowTypeName:    workflowTypeName,
+		StartTimestamp:      startTimeUnixNano,
+		ExecutionTimestamp:  executionTimeUnixNano,
+		WorkflowTimeout:     int64(workflowTimeout),
+		TaskID:              taskID,
+		Memo:                visibilityMemo,
+		SearchAttributes:    searchAttributes,
+		FirstExecutionRunID: firstExecutionRunID,
 	}
 
-	return t.visibilityMgr.RecordWorkflowExecutionStarted(request)
+	return v.visibilityMgr.RecordWorkflowExecutionStarted(request)
 }
 
-func (t *transferQueueProcessorBase) upsertWorkflowExecution(
+func (v *visibilityRecorder) upsertWorkflowExecution(
 	domainID string, execution workflow.WorkflowExecution, workflowTypeName string, startTimeUnixNano,
 	executionTimeUnixNano int64, workflowTimeout int32, taskID int64, visibilityMemo *workflow.Memo,
-	searchAttributes map[string][]byte) error {
+	searchAttributes map[string][]byte, firstExecutionRunID string) error {
 
 	domain := defaultDomainName
-	domainEntry, err := t.shard.GetDomainCache().GetDomainByID(domainID)
+	domainEntry, err := v.shard.GetDomainCache().GetDomainByID(domainID)
 	if err != nil {
 		if _, ok := err.(*workflow.EntityNotExistsError); !ok {
 			return err
@@ -192,25 +237,27 @@ func (t *transferQueueProcessorBase) upsertWorkflowExecution(
 	}
 
 	request := &persistence.UpsertWorkflowExecutionRequest{
-		DomainUUID:         domainID,
-		Domain:             domain,
-		Execution:          execution,
-		WorkflowTypeName:   workflowTypeName,
-		StartTimestamp:     startTimeUnixNano,
-		ExecutionTimestamp: executionTimeUnixNano,
-		WorkflowTimeout:    int64(workflowTimeout),
-		TaskID:             taskID,
-		Memo:               visibilityMemo,
-		SearchAttributes:   searchAttributes,
+		DomainUUID:          domainID,
+		Domain:              domain,
+		Execution:           execution,
+		WorkflowTypeName:    workflowTypeName,
+		StartTimestamp:      startTimeUnixNano,
+		ExecutionTimestamp:  executionTimeUnixNano,
+		WorkflowTimeout:     int64(workflowTimeout),
+		TaskID:              taskID,
+		Memo:                visibilityMemo,
+		SearchAttributes:    searchAttributes,
+		FirstExecutionRunID: firstExecutionRunID,
 	}
 
-	return t.visibilityMgr.UpsertWorkflowExecution(request)
+	return v.visibilityMgr.UpsertWorkflowExecution(request)
 }
 
-func (t *transferQueueProcessorBase) recordWorkflowClosed(
+func (v *visibilityRecorder) recordWorkflowClosed(
 	domainID string, execution workflow.WorkflowExecution, workflowTypeName string,
 	startTimeUnixNano int64, executionTimeUnixNano int64, endTimeUnixNano int64, closeStatus workflow.WorkflowExecutionCloseStatus,
-	historyLength int64, taskID int64, visibilityMemo *workflow.Memo, searchAttributes map[string][]byte) error {
+	historyLength int64, taskID int64, visibilityMemo *workflow.Memo, searchAttributes map[string][]byte,
+	firstExecutionRunID string) error {
 
 	// Record closing in visibility store
 	retentionSeconds := int64(0)
@@ -218,7 +265,7 @@ func (t *transferQueueProcessorBase) recordWorkflowClosed(
 	isSampledEnabled := false
 	wid := execution.GetWorkflowId()
 
-	domainEntry, err := t.shard.GetDomainCache().GetDomainByID(domainID)
+	domainEntry, err := v.shard.GetDomainCache().GetDomainByID(domainID)
 	if err != nil {
 		if _, ok := err.(*workflow.EntityNotExistsError); !ok {
 			return err
@@ -237,22 +284,23 @@ func (t *transferQueueProcessorBase) recordWorkflowClosed(
 	}
 
 	request := &persistence.RecordWorkflowExecutionClosedRequest{
-		DomainUUID:         domainID,
-		Domain:             domain,
-		Execution:          execution,
-		WorkflowTypeName:   workflowTypeName,
-		StartTimestamp:     startTimeUnixNano,
-		ExecutionTimestamp: executionTimeUnixNano,
-		CloseTimestamp:     endTimeUnixNano,
-		Status:             closeStatus,
-		HistoryLength:      historyLength,
-		RetentionSeconds:   retentionSeconds,
-		TaskID:             taskID,
-		Memo:               visibilityMemo,
-		SearchAttributes:   searchAttributes,
+		DomainUUID:          domainID,
+		Domain:              domain,
+		Execution:           execution,
+		WorkflowTypeName:    workflowTypeName,
+		StartTimestamp:      startTimeUnixNano,
+		ExecutionTimestamp:  executionTimeUnixNano,
+		CloseTimestamp:      endTimeUnixNano,
+		Status:              closeStatus,
+		HistoryLength:       historyLength,
+		RetentionSeconds:    retentionSeconds,
+		TaskID:              taskID,
+		Memo:                visibilityMemo,
+		SearchAttributes:    searchAttributes,
+		FirstExecutionRunID: firstExecutionRunID,
 	}
 
-	return t.visibilityMgr.RecordWorkflowExecutionClosed(request)
+	return v.visibilityMgr.RecordWorkflowExecutionClosed(request)
 }
 
 // Argument startEvent is to save additional call of msBuilder.GetStartEvent
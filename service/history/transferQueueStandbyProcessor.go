@@ -21,7 +21,9 @@
 package history
 
 import (
+	h "github.com/uber/cadence/.gen/go/history"
 	workflow "github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/client/history"
 	"github.com/uber/cadence/client/matching"
 	"github.com/uber/cadence/common"
 	"github.com/uber/cadence/common/log"
@@ -38,6 +40,7 @@ type (
 		historyService     *historyEngineImpl
 		options            *QueueProcessorOptions
 		executionManager   persistence.ExecutionManager
+		historyClient      history.Client
 		cache              *historyCache
 		transferTaskFilter queueTaskFilter
 		logger             log.Logger
@@ -50,7 +53,7 @@ type (
 )
 
 func newTransferQueueStandbyProcessor(clusterName string, shard ShardContext, historyService *historyEngineImpl,
-	visibilityMgr persistence.VisibilityManager, matchingClient matching.Client, taskAllocator taskAllocator,
+	visibilityMgr persistence.VisibilityManager, matchingClient matching.Client, historyClient history.Client, taskAllocator taskAllocator,
 	historyRereplicator xdc.HistoryRereplicator, logger log.Logger) *transferQueueStandbyProcessorImpl {
 	config := shard.GetConfig()
 	options := &QueueProcessorOptions{
@@ -90,6 +93,7 @@ func newTransferQueueStandbyProcessor(clusterName string, shard ShardContext, hi
 		historyService:     historyService,
 		options:            options,
 		executionManager:   shard.GetExecutionManager(),
+		historyClient:      historyClient,
 		cache:              historyService.historyCache,
 		transferTaskFilter: transferTaskFilter,
 		logger:             logger,
@@ -102,6 +106,10 @@ func newTransferQueueStandbyProcessor(clusterName string, shard ShardContext, hi
 	}
 
 	queueAckMgr := newQueueAckMgr(shard, options, processor, shard.GetTransferClusterAckLevel(clusterName), logger)
+	queueAckMgr.stuckQueueDetector = newStuckQueueDetector(
+		transferQueueName(clusterName), options.MetricScope, shard,
+		queueAckMgr.ackLevel, shard.GetTransferMaxReadLevel, logger,
+	)
 	queueProcessorBase := newQueueProcessorBase(clusterName, shard, options, processor, queueAckMgr, logger)
 	processor.queueAckMgr = queueAckMgr
 	processor.queueProcessorBase = queueProcessorBase
@@ -177,6 +185,12 @@ func (t *transferQueueStandbyProcessorImpl) process(qTask queueTaskInfo, shouldP
 		}
 		return metrics.TransferStandbyTaskUpsertWorkflowSearchAttributesScope, err
 
+	case persistence.TransferTaskTypeRecordChildExecutionCompleted:
+		if shouldProcessTask {
+			err = t.processRecordChildExecutionCompleted(task, lastAttempt)
+		}
+		return metrics.TransferStandbyTaskRecordChildExecutionCompletedScope, err
+
 	default:
 		return metrics.TransferStandbyQueueProcessorScope, errUnknownTransferTask
 	}
@@ -225,6 +239,7 @@ func (t *transferQueueStandbyProcessorImpl) processActivityTask(transferTask *pe
 func (t *transferQueueStandbyProcessorImpl) processDecisionTask(transferTask *persistence.TransferTaskInfo) error {
 	var decisionScheduleToStartTimeout *int32
 	var tasklist *workflow.TaskList
+	var workerBuildID *string
 	processTaskIfClosed := false
 
 	return t.processTransfer(processTaskIfClosed, transferTask, func(msBuilder mutableState) error {
@@ -253,6 +268,7 @@ func (t *transferQueueStandbyProcessorImpl) processDecisionTask(transferTask *pe
 
 			decisionScheduleToStartTimeout = common.Int32Ptr(decisionTimeout)
 			tasklist = &workflow.TaskList{Name: &transferTask.TaskList}
+			workerBuildID = getWorkerBuildID(executionInfo)
 			return nil
 		}
 
@@ -263,7 +279,7 @@ func (t *transferQueueStandbyProcessorImpl) processDecisionTask(transferTask *pe
 		}
 
 		timeout := common.MinInt32(*decisionScheduleToStartTimeout, common.MaxTaskTimeout)
-		err := t.pushDecision(transferTask, tasklist, timeout)
+		err := t.pushDecision(transferTask, tasklist, timeout, workerBuildID)
 		return err
 	})
 }
@@ -316,6 +332,7 @@ func (t *transferQueueStandbyProcessorImpl) processCloseExecution(transferTask *
 		return t.recordWorkflowClosed(
 			transferTask.DomainID, execution, workflowTypeName, workflowStartTimestamp, workflowExecutionTimestamp.UnixNano(),
 			workflowCloseTimestamp, workflowCloseStatus, workflowHistoryLength, transferTask.GetTaskID(), visibilityMemo, searchAttr,
+			executionInfo.FirstExecutionRunID,
 		)
 	}, standbyTaskPostActionNoOp) // no op post action, since the entire workflow is finished
 }
@@ -432,6 +449,89 @@ func (t *transferQueueStandbyProcessorImpl) processStartChildExecution(transferT
 	}, postProcessingFn)
 }
 
+func (t *transferQueueStandbyProcessorImpl) processRecordChildExecutionCompleted(transferTask *persistence.TransferTaskInfo, lastAttempt bool) error {
+
+	verifyParent := false
+	processTaskIfClosed := true
+	return t.processTransfer(processTaskIfClosed, transferTask, func(msBuilder mutableState) error {
+		if msBuilder.IsWorkflowExecutionRunning() {
+			// this can happen if workflow is reset.
+			return nil
+		}
+
+		ok, err := verifyTaskVersion(t.shard, t.logger, transferTask.DomainID, msBuilder.GetLastWriteVersion(), transferTask.Version, transferTask)
+		if err != nil {
+			return err
+		}
+		verifyParent = ok
+		return nil
+	}, func() error {
+		if !verifyParent {
+			return nil
+		}
+
+		// the child keeps no trace of the notification, the parent is checked for the replicated completion instead
+		nextEventID, err := t.getParentNextEventIDIfChildPending(transferTask)
+		if err != nil || nextEventID == nil {
+			return err
+		}
+		if lastAttempt {
+			return standbyTrensferTaskPostActionTaskDiscarded(nextEventID, transferTask, t.logger)
+		}
+		if !t.discardTask(transferTask) {
+			return ErrTaskRetry
+		}
+
+		err = t.historyRereplicator.SendMultiWorkflowHistory(
+			transferTask.TargetDomainID, transferTask.TargetWorkflowID,
+			transferTask.TargetRunID, *nextEventID,
+			transferTask.TargetRunID, common.EndEventID, // use common.EndEventID since we do not know where is the end
+		)
+		if err != nil {
+			t.logger.Error("Error re-replicating parent history from remote.",
+				tag.WorkflowID(transferTask.TargetWorkflowID),
+				tag.WorkflowRunID(transferTask.TargetRunID),
+				tag.WorkflowDomainID(transferTask.TargetDomainID),
+				tag.WorkflowNextEventID(*nextEventID),
+				tag.SourceCluster(t.clusterName))
+			// fail to fetch events from remote, just discard the task
+			return ErrTaskDiscarded
+		}
+		return t.processRecordChildExecutionCompleted(transferTask, true)
+	})
+}
+
+// getParentNextEventIDIfChildPending returns the next event ID of the parent of the task if the parent still has the
+// child pending, i.e. the completion of the child is not replicated to the parent yet, and nil otherwise.
+func (t *transferQueueStandbyProcessorImpl) getParentNextEventIDIfChildPending(transferTask *persistence.TransferTaskInfo) (*int64, error) {
+	resp, err := t.historyClient.DescribeWorkflowExecution(nil, &h.DescribeWorkflowExecutionRequest{
+		DomainUUID: common.StringPtr(transferTask.TargetDomainID),
+		Request: &workflow.DescribeWorkflowExecutionRequest{
+			Execution: &workflow.WorkflowExecution{
+				WorkflowId: common.StringPtr(transferTask.TargetWorkflowID),
+				RunId:      common.StringPtr(transferTask.TargetRunID),
+			},
+		},
+	})
+	if err != nil {
+		if _, ok := err.(*workflow.EntityNotExistsError); ok {
+			// the parent is gone, there is nothing to verify
+			return nil, nil
+		}
+		return nil, err
+	}
+	if resp.WorkflowExecutionInfo.CloseStatus != nil {
+		return nil, nil
+	}
+
+	for _, child := range resp.PendingChildren {
+		if child.GetInitiatedID() == transferTask.ScheduleID {
+			return common.Int64Ptr(resp.WorkflowExecutionInfo.GetHistoryLength() + 1), nil
+		}
+	}
+	return nil, nil
+}
+
 func (t *transferQueueStandbyProcessorImpl) processRecordWorkflowStarted(transferTask *persistence.TransferTaskInfo) error {
 	processTaskIfClosed := false
 
@@ -479,10 +579,10 @@ func (t *transferQueueStandbyProcessorImpl) processRecordWorkflowStartedOrUpsert
 
 	if isRecordStart {
 		return t.recordWorkflowStarted(transferTask.DomainID, execution, wfTypeName, startTimestamp, executionTimestamp.UnixNano(),
-			workflowTimeout, transferTask.GetTaskID(), visibilityMemo, searchAttr)
+			workflowTimeout, transferTask.GetTaskID(), visibilityMemo, searchAttr, executionInfo.FirstExecutionRunID)
 	}
 	return t.upsertWorkflowExecution(transferTask.DomainID, execution, wfTypeName, startTimestamp, executionTimestamp.UnixNano(),
-		workflowTimeout, transferTask.GetTaskID(), visibilityMemo, searchAttr)
+		workflowTimeout, transferTask.GetTaskID(), visibilityMemo, searchAttr, executionInfo.FirstExecutionRunID)
 
 }
 
@@ -56,6 +56,7 @@ type (
 		mockMetadataMgr         *mocks.MetadataManager
 		mockVisibilityMgr       *mocks.VisibilityManager
 		mockMatchingClient      *mocks.MatchingClient
+		mockHistoryClient       *mocks.HistoryClient
 		mockExecutionMgr        *mocks.ExecutionManager
 		mockHistoryMgr          *mocks.HistoryManager
 		mockShard               ShardContext
@@ -89,6 +90,7 @@ func (s *transferQueueStandbyProcessorSuite) SetupTest() {
 	s.mockHistoryMgr = &mocks.HistoryManager{}
 	s.mockVisibilityMgr = &mocks.VisibilityManager{}
 	s.mockMatchingClient = &mocks.MatchingClient{}
+	s.mockHistoryClient = &mocks.HistoryClient{}
 	s.mockMetadataMgr = &mocks.MetadataManager{}
 	s.mockClusterMetadata = &mocks.ClusterMetadata{}
 	s.mockHistoryRereplicator = &xdc.MockHistoryRereplicator{}
@@ -153,7 +155,7 @@ func (s *transferQueueStandbyProcessorSuite) SetupTest() {
 	s.mockHistoryEngine = h
 	s.clusterName = cluster.TestAlternativeClusterName
 	s.transferQueueStandbyProcessor = newTransferQueueStandbyProcessor(
-		s.clusterName, s.mockShard, h, s.mockVisibilityMgr, s.mockMatchingClient,
+		s.clusterName, s.mockShard, h, s.mockVisibilityMgr, s.mockMatchingClient, s.mockHistoryClient,
 		newTaskAllocator(s.mockShard), s.mockHistoryRereplicator, s.logger,
 	)
 	s.mockQueueAckMgr = &MockQueueAckMgr{}
@@ -168,6 +170,7 @@ func (s *transferQueueStandbyProcessorSuite) TearDownTest() {
 	s.mockProducer.AssertExpectations(s.T())
 	s.mockClientBean.AssertExpectations(s.T())
 	s.mockHistoryRereplicator.AssertExpectations(s.T())
+	s.mockHistoryClient.AssertExpectations(s.T())
 }
 
 func (s *transferQueueStandbyProcessorSuite) TestProcessActivityTask_Pending() {
@@ -592,6 +595,118 @@ func (s *transferQueueStandbyProcessorSuite) TestProcessCloseExecution() {
 	s.Nil(err)
 }
 
+func (s *transferQueueStandbyProcessorSuite) TestProcessRecordChildExecutionCompleted_Pending() {
+	transferTask, describeRequest := s.prepareRecordChildExecutionCompletedTask()
+
+	parentNextEventID := int64(12)
+	s.mockHistoryClient.On("DescribeWorkflowExecution", nil, describeRequest).Return(&workflow.DescribeWorkflowExecutionResponse{
+		WorkflowExecutionInfo: &workflow.WorkflowExecutionInfo{HistoryLength: common.Int64Ptr(parentNextEventID - 1)},
+		PendingChildren:       []*workflow.PendingChildExecutionInfo{{InitiatedID: common.Int64Ptr(transferTask.ScheduleID)}},
+	}, nil)
+
+	_, err := s.transferQueueStandbyProcessor.process(transferTask, true)
+	s.Equal(ErrTaskRetry, err)
+
+	s.mockShard.SetCurrentTime(s.clusterName, time.Now().Add(3*s.mockShard.GetConfig().StandbyClusterDelay()))
+	s.mockHistoryRereplicator.On("SendMultiWorkflowHistory",
+		transferTask.TargetDomainID, transferTask.TargetWorkflowID,
+		transferTask.TargetRunID, parentNextEventID,
+		transferTask.TargetRunID, common.EndEventID,
+	).Return(nil).Once()
+	_, err = s.transferQueueStandbyProcessor.process(transferTask, true)
+	s.Equal(ErrTaskDiscarded, err)
+}
+
+func (s *transferQueueStandbyProcessorSuite) TestProcessRecordChildExecutionCompleted_Success() {
+	transferTask, describeRequest := s.prepareRecordChildExecutionCompletedTask()
+
+	// the completion of the child is replicated to the parent, which has no pending child anymore
+	s.mockHistoryClient.On("DescribeWorkflowExecution", nil, describeRequest).Return(&workflow.DescribeWorkflowExecutionResponse{
+		WorkflowExecutionInfo: &workflow.WorkflowExecutionInfo{HistoryLength: common.Int64Ptr(11)},
+	}, nil).Once()
+	_, err := s.transferQueueStandbyProcessor.process(transferTask, true)
+	s.Nil(err)
+
+	// the parent is gone
+	s.mockHistoryClient.On("DescribeWorkflowExecution", nil, describeRequest).Return(nil, &workflow.EntityNotExistsError{}).Once()
+	_, err = s.transferQueueStandbyProcessor.process(transferTask, true)
+	s.Nil(err)
+}
+
+func (s *transferQueueStandbyProcessorSuite) prepareRecordChildExecutionCompletedTask() (*persistence.TransferTaskInfo, *history.DescribeWorkflowExecutionRequest) {
+	domainID := "some random domain ID"
+	execution := workflow.WorkflowExecution{
+		WorkflowId: common.StringPtr("some random workflow ID"),
+		RunId:      common.StringPtr(uuid.New()),
+	}
+	workflowType := "some random workflow type"
+	taskListName := "some random task list"
+
+	parentDomainID := "some random parent domain ID"
+	parentExecution := workflow.WorkflowExecution{
+		WorkflowId: common.StringPtr("some random parent workflow ID"),
+		RunId:      common.StringPtr(uuid.New()),
+	}
+	parentInitiatedID := int64(10)
+
+	version := int64(4096)
+	msBuilder := newMutableStateBuilderWithReplicationStateWithEventV2(s.mockShard, s.mockShard.GetEventsCache(), s.logger, version, execution.GetRunId())
+	_, err := msBuilder.AddWorkflowExecutionStartedEvent(
+		execution,
+		&history.StartWorkflowExecutionRequest{
+			DomainUUID: common.StringPtr(domainID),
+			StartRequest: &workflow.StartWorkflowExecutionRequest{
+				WorkflowType:                        &workflow.WorkflowType{Name: common.StringPtr(workflowType)},
+				TaskList:                            &workflow.TaskList{Name: common.StringPtr(taskListName)},
+				ExecutionStartToCloseTimeoutSeconds: common.Int32Ptr(2),
+				TaskStartToCloseTimeoutSeconds:      common.Int32Ptr(1),
+			},
+			ParentExecutionInfo: &history.ParentExecutionInfo{
+				DomainUUID:  common.StringPtr(parentDomainID),
+				Domain:      common.StringPtr("some random parent domain"),
+				Execution:   &parentExecution,
+				InitiatedId: common.Int64Ptr(parentInitiatedID),
+			},
+		},
+	)
+	s.Nil(err)
+
+	di := addDecisionTaskScheduledEvent(msBuilder)
+	event := addDecisionTaskStartedEvent(msBuilder, di.ScheduleID, taskListName, uuid.New())
+	di.StartedID = event.GetEventId()
+	event = addDecisionTaskCompletedEvent(msBuilder, di.ScheduleID, di.StartedID, nil, "some random identity")
+
+	event = addCompleteWorkflowEvent(msBuilder, event.GetEventId(), nil)
+	s.mockClusterMetadata.On("ClusterNameForFailoverVersion", version).Return(s.clusterName)
+	msBuilder.UpdateReplicationStateLastEventID(version, event.GetEventId())
+
+	transferTask := &persistence.TransferTaskInfo{
+		Version:             version,
+		DomainID:            domainID,
+		WorkflowID:          execution.GetWorkflowId(),
+		RunID:               execution.GetRunId(),
+		VisibilityTimestamp: time.Now(),
+		TargetDomainID:      parentDomainID,
+		TargetWorkflowID:    parentExecution.GetWorkflowId(),
+		TargetRunID:         parentExecution.GetRunId(),
+		TaskID:              int64(59),
+		TaskList:            taskListName,
+		TaskType:            persistence.TransferTaskTypeRecordChildExecutionCompleted,
+		ScheduleID:          parentInitiatedID,
+	}
+
+	persistenceMutableState := createMutableState(msBuilder)
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(&persistence.GetWorkflowExecutionResponse{State: persistenceMutableState}, nil)
+
+	describeRequest := &history.DescribeWorkflowExecutionRequest{
+		DomainUUID: common.StringPtr(parentDomainID),
+		Request: &workflow.DescribeWorkflowExecutionRequest{
+			Execution: &parentExecution,
+		},
+	}
+	return transferTask, describeRequest
+}
+
 func (s *transferQueueStandbyProcessorSuite) TestProcessCancelExecution_Pending() {
 	domainID := "some random domain ID"
 	execution := workflow.WorkflowExecution{
@@ -0,0 +1,287 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package history
+
+import (
+	"errors"
+
+	workflow "github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/log"
+	"github.com/uber/cadence/common/log/tag"
+	"github.com/uber/cadence/common/metrics"
+	"github.com/uber/cadence/common/persistence"
+)
+
+type (
+	visibilityQueueProcessorImpl struct {
+		shard                ShardContext
+		historyCache         *historyCache
+		visibilityTaskFilter queueTaskFilter
+		executionMgr         persistence.ExecutionManager
+		metricsClient        metrics.Client
+		options              *QueueProcessorOptions
+		logger               log.Logger
+		*visibilityRecorder
+		*queueProcessorBase
+		queueAckMgr
+
+		lastCompletedTaskID int64
+	}
+)
+
+var (
+	errUnknownVisibilityTask = errors.New("Unknown visibility task")
+)
+
+func newVisibilityQueueProcessor(shard ShardContext, historyCache *historyCache, visibilityMgr persistence.VisibilityManager,
+	executionMgr persistence.ExecutionManager, logger log.Logger) queueProcessor {
+
+	currentClusterName := shard.GetService().GetClusterMetadata().GetCurrentClusterName()
+
+	config := shard.GetConfig()
+	options := &QueueProcessorOptions{
+		StartDelay:                         config.VisibilityProcessorStartDelay,
+		BatchSize:                          config.VisibilityTaskBatchSize,
+		WorkerCount:                        config.VisibilityTaskWorkerCount,
+		MaxPollRPS:                         config.VisibilityProcessorMaxPollRPS,
+		MaxPollInterval:                    config.VisibilityProcessorMaxPollInterval,
+		MaxPollIntervalJitterCoefficient:   config.VisibilityProcessorMaxPollIntervalJitterCoefficient,
+		UpdateAckInterval:                  config.VisibilityProcessorUpdateAckInterval,
+		UpdateAckIntervalJitterCoefficient: config.VisibilityProcessorUpdateAckIntervalJitterCoefficient,
+		MaxRetryCount:                      config.VisibilityTaskMaxRetryCount,
+		MetricScope:                        metrics.VisibilityQueueProcessorScope,
+	}
+
+	logger = logger.WithTags(tag.ComponentVisibilityQueue)
+
+	visibilityTaskFilter := func(qTask queueTaskInfo) (bool, error) {
+		return true, nil
+	}
+
+	processor := &visibilityQueueProcessorImpl{
+		shard:                shard,
+		historyCache:         historyCache,
+		visibilityTaskFilter: visibilityTaskFilter,
+		executionMgr:         executionMgr,
+		metricsClient:        shard.GetMetricsClient(),
+		options:              options,
+		logger:               logger,
+		visibilityRecorder:   newVisibilityRecorder(shard, visibilityMgr),
+	}
+
+	queueAckMgr := newQueueAckMgr(shard, options, processor, shard.GetVisibilityAckLevel(), logger)
+	queueProcessorBase := newQueueProcessorBase(currentClusterName, shard, options, processor, queueAckMgr, logger)
+	processor.queueAckMgr = queueAckMgr
+	processor.queueProcessorBase = queueProcessorBase
+
+	return processor
+}
+
+func (p *visibilityQueueProcessorImpl) getTaskFilter() queueTaskFilter {
+	return p.visibilityTaskFilter
+}
+
+func (p *visibilityQueueProcessorImpl) process(qTask queueTaskInfo, shouldProcessTask bool) (int, error) {
+	task, ok := qTask.(*persistence.VisibilityTaskInfo)
+	if !ok {
+		return metrics.VisibilityQueueProcessorScope, errUnexpectedQueueTask
+	}
+	// visibility records are kept in every cluster, regardless of which one is active,
+	// so should not do anything to shouldProcessTask variable
+
+	switch task.TaskType {
+	case persistence.VisibilityTaskTypeRecordWorkflowStarted:
+		err := p.processRecordWorkflowStartedOrUpsert(task, true)
+		return metrics.VisibilityTaskRecordWorkflowStartedScope, err
+	case persistence.VisibilityTaskTypeUpsertWorkflowSearchAttributes:
+		err := p.processRecordWorkflowStartedOrUpsert(task, false)
+		return metrics.VisibilityTaskUpsertWorkflowSearchAttributesScope, err
+	case persistence.VisibilityTaskTypeCloseExecution:
+		err := p.processCloseExecution(task)
+		return metrics.VisibilityTaskCloseExecutionScope, err
+	default:
+		return metrics.VisibilityQueueProcessorScope, errUnknownVisibilityTask
+	}
+}
+
+func (p *visibilityQueueProcessorImpl) queueShutdown() error {
+	// there is no shutdown specific behavior for visibility queue
+	return nil
+}
+
+func (p *visibilityQueueProcessorImpl) processRecordWorkflowStartedOrUpsert(task *persistence.VisibilityTaskInfo, isRecordStart bool) (retError error) {
+	execution := workflow.WorkflowExecution{
+		WorkflowId: common.StringPtr(task.WorkflowID),
+		RunId:      common.StringPtr(task.RunID),
+	}
+	context, release, err := p.historyCache.getOrCreateWorkflowExecutionForBackground(task.DomainID, execution)
+	if err != nil {
+		return err
+	}
+	defer func() { release(retError) }()
+
+	msBuilder, err := p.loadMutableState(context)
+	if err != nil {
+		return err
+	} else if msBuilder == nil || !msBuilder.IsWorkflowExecutionRunning() {
+		return nil
+	}
+
+	// verify task version for RecordWorkflowStarted.
+	// upsert doesn't require verifyTask, because it is just a sync of mutableState.
+	if isRecordStart {
+		ok, err := verifyTaskVersion(p.shard, p.logger, task.DomainID, msBuilder.GetStartVersion(), task.Version, task)
+		if err != nil {
+			return err
+		} else if !ok {
+			return nil
+		}
+	}
+
+	executionInfo := msBuilder.GetExecutionInfo()
+	workflowTimeout := executionInfo.WorkflowTimeout
+	wfTypeName := executionInfo.WorkflowTypeName
+	startTimestamp := executionInfo.StartTimestamp.UnixNano()
+	startEvent, found := msBuilder.GetStartEvent()
+	if !found {
+		return &workflow.InternalServiceError{Message: "Failed to load start event."}
+	}
+	executionTimestamp := getWorkflowExecutionTimestamp(msBuilder, startEvent)
+	visibilityMemo := getVisibilityMemo(startEvent)
+	searchAttr := copySearchAttributes(executionInfo.SearchAttributes)
+
+	// release the context lock since we no longer need mutable state builder and
+	// the rest of logic is making RPC call, which takes time.
+	release(nil)
+
+	if isRecordStart {
+		return p.recordWorkflowStarted(task.DomainID, execution, wfTypeName, startTimestamp, executionTimestamp.UnixNano(),
+			workflowTimeout, task.GetTaskID(), visibilityMemo, searchAttr, executionInfo.FirstExecutionRunID)
+	}
+	return p.upsertWorkflowExecution(task.DomainID, execution, wfTypeName, startTimestamp, executionTimestamp.UnixNano(),
+		workflowTimeout, task.GetTaskID(), visibilityMemo, searchAttr, executionInfo.FirstExecutionRunID)
+}
+
+func (p *visibilityQueueProcessorImpl) processCloseExecution(task *persistence.VisibilityTaskInfo) (retError error) {
+	execution := workflow.WorkflowExecution{
+		WorkflowId: common.StringPtr(task.WorkflowID),
+		RunId:      common.StringPtr(task.RunID),
+	}
+	context, release, err := p.historyCache.getOrCreateWorkflowExecutionForBackground(task.DomainID, execution)
+	if err != nil {
+		return err
+	}
+	defer func() { release(retError) }()
+
+	msBuilder, err := p.loadMutableState(context)
+	if err != nil {
+		return err
+	} else if msBuilder == nil || msBuilder.IsWorkflowExecutionRunning() {
+		// this can happen if workflow is reset.
+		return nil
+	}
+
+	ok, err := verifyTaskVersion(p.shard, p.logger, task.DomainID, msBuilder.GetLastWriteVersion(), task.Version, task)
+	if err != nil {
+		return err
+	} else if !ok {
+		return nil
+	}
+
+	executionInfo := msBuilder.GetExecutionInfo()
+	completionEvent, ok := msBuilder.GetCompletionEvent()
+	if !ok {
+		return &workflow.InternalServiceError{Message: "Unable to get workflow completion event."}
+	}
+	workflowTypeName := executionInfo.WorkflowTypeName
+	workflowStartTimestamp := executionInfo.StartTimestamp.UnixNano()
+	workflowCloseTimestamp := completionEvent.GetTimestamp()
+	workflowCloseStatus := getWorkflowExecutionCloseStatus(executionInfo.CloseStatus)
+	workflowHistoryLength := msBuilder.GetNextEventID() - 1
+
+	startEvent, ok := msBuilder.GetStartEvent()
+	if !ok {
+		return &workflow.InternalServiceError{Message: "Unable to get workflow start event."}
+	}
+	workflowExecutionTimestamp := getWorkflowExecutionTimestamp(msBuilder, startEvent)
+	visibilityMemo := getVisibilityMemo(startEvent)
+	searchAttr := executionInfo.SearchAttributes
+
+	// release the context lock since we no longer need mutable state builder and
+	// the rest of logic is making RPC call, which takes time.
+	release(nil)
+	return p.recordWorkflowClosed(
+		task.DomainID, execution, workflowTypeName, workflowStartTimestamp, workflowExecutionTimestamp.UnixNano(),
+		workflowCloseTimestamp, workflowCloseStatus, workflowHistoryLength, task.GetTaskID(), visibilityMemo,
+		searchAttr, executionInfo.FirstExecutionRunID,
+	)
+}
+
+// loadMutableState returns nil, nil if the workflow execution no longer exists,
+// which can happen if this is a duplicate processing of the task
+func (p *visibilityQueueProcessorImpl) loadMutableState(context workflowExecutionContext) (mutableState, error) {
+	msBuilder, err := context.loadWorkflowExecution()
+	if err != nil {
+		if _, ok := err.(*workflow.EntityNotExistsError); ok {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return msBuilder, nil
+}
+
+func (p *visibilityQueueProcessorImpl) readTasks(readLevel int64) ([]queueTaskInfo, bool, error) {
+	response, err := p.executionMgr.GetVisibilityTasks(&persistence.GetVisibilityTasksRequest{
+		ReadLevel:    readLevel,
+		MaxReadLevel: p.shard.GetTransferMaxReadLevel(),
+		BatchSize:    p.options.BatchSize(),
+	})
+
+	if err != nil {
+		return nil, false, err
+	}
+
+	tasks := make([]queueTaskInfo, len(response.Tasks))
+	for i := range response.Tasks {
+		tasks[i] = response.Tasks[i]
+	}
+
+	return tasks, len(response.NextPageToken) != 0, nil
+}
+
+func (p *visibilityQueueProcessorImpl) updateAckLevel(ackLevel int64) error {
+	if err := p.shard.UpdateVisibilityAckLevel(ackLevel); err != nil {
+		return err
+	}
+
+	if ackLevel <= p.lastCompletedTaskID {
+		return nil
+	}
+	if err := p.executionMgr.RangeCompleteVisibilityTask(&persistence.RangeCompleteVisibilityTaskRequest{
+		ExclusiveBeginTaskID: p.lastCompletedTaskID,
+		InclusiveEndTaskID:   ackLevel,
+	}); err != nil {
+		return err
+	}
+	p.lastCompletedTaskID = ackLevel
+	return nil
+}
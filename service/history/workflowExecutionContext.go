@@ -160,6 +160,9 @@ type (
 		stats                 *persistence.ExecutionStats
 		updateCondition       int64
 		createReplicationTask bool
+
+		// requestCtx is the context of the lock holder, it bounds the reads made on its behalf
+		requestCtx context.Context
 	}
 )
 
@@ -169,6 +172,16 @@ var (
 	persistenceOperationRetryPolicy = common.CreatePersistanceRetryPolicy()
 )
 
+// persistenceRequestContext returns the context bounding the persistence reads made on behalf of an RPC caller.
+// Contexts which can never be done, like the one of background work, are not attached to the requests.
+// Writes are never bounded, a conditional update abandoned half way leaves its outcome unknown.
+func persistenceRequestContext(ctx context.Context) context.Context {
+	if ctx == nil || ctx.Done() == nil {
+		return nil
+	}
+	return ctx
+}
+
 func newWorkflowExecutionContext(
 	domainID string,
 	execution workflow.WorkflowExecution,
@@ -197,10 +210,15 @@ func newWorkflowExecutionContext(
 }
 
 func (c *workflowExecutionContextImpl) lock(ctx context.Context) error {
-	return c.locker.Lock(ctx)
+	if err := c.locker.Lock(ctx); err != nil {
+		return err
+	}
+	c.requestCtx = persistenceRequestContext(ctx)
+	return nil
 }
 
 func (c *workflowExecutionContextImpl) unlock() {
+	c.requestCtx = nil
 	c.locker.Unlock()
 }
 
@@ -244,6 +262,7 @@ func (c *workflowExecutionContextImpl) loadWorkflowExecutionInternal() error {
 	response, err := c.getWorkflowExecutionWithRetry(&persistence.GetWorkflowExecutionRequest{
 		DomainID:  c.domainID,
 		Execution: c.workflowExecution,
+		Context:   c.requestCtx,
 	})
 	if err != nil {
 		if common.IsPersistenceTransientError(err) {
@@ -418,6 +437,9 @@ func (c *workflowExecutionContextImpl) resetWorkflowExecution(
 	currTimerTasks := []persistence.Task{}
 	if closeTask != nil {
 		currTransferTasks = append(currTransferTasks, closeTask)
+		if parentTask := getRecordChildExecutionCompletedTask(currMutableState); parentTask != nil {
+			currTransferTasks = append(currTransferTasks, parentTask)
+		}
 	}
 	if cleanupTask != nil {
 		currTimerTasks = append(currTimerTasks, cleanupTask)
@@ -533,7 +555,7 @@ func (c *workflowExecutionContextImpl) resetWorkflowExecution(
 			UpsertSignalInfos:         []*persistence.SignalInfo{},
 			DeleteSignalInfo:          nil,
 			UpsertSignalRequestedIDs:  []string{},
-			DeleteSignalRequestedID:   "",
+			DeleteSignalRequestedIDs:  []string{},
 			NewBufferedEvents:         []*workflow.HistoryEvent{},
 			ClearBufferedEvents:       false,
 
@@ -687,7 +709,7 @@ func (c *workflowExecutionContextImpl) updateAsActiveWithNew(
 	}
 
 	now := c.timeSource.Now()
-	return c.update(
+	if err := c.update(
 		transferTasks,
 		timerTasks,
 		transactionID,
@@ -697,6 +719,76 @@ func (c *workflowExecutionContextImpl) updateAsActiveWithNew(
 		"",
 		newStateBuilder,
 		newHistorySize,
+	); err != nil {
+		return err
+	}
+
+	// stop runaway workflows before they create unmanageable rows and histories, the history size
+	// only includes the events of this update once they are appended
+	if newStateBuilder == nil {
+		if err := c.terminateWorkflowIfHistoryExceedsLimit(); err != nil {
+			// the update went through, the limit is checked again on the next update
+			c.logger.Warn("Unable to terminate workflow exceeding history limit.", tag.Error(err))
+		}
+	}
+	return nil
+}
+
+func (c *workflowExecutionContextImpl) terminateWorkflowIfHistoryExceedsLimit() (retError error) {
+	defer func() {
+		if retError != nil {
+			// the termination may be applied to the mutable state but not persisted
+			c.clear()
+		}
+	}()
+
+	domainName := c.getDomainName()
+	config := c.shard.GetConfig()
+	if c.stats == nil || !config.HistoryLimitTerminate(domainName) {
+		return nil
+	}
+
+	workflowSizeChecker := newWorkflowSizeChecker(
+		config.BlobSizeLimitWarn(domainName),
+		config.BlobSizeLimitError(domainName),
+		config.HistorySizeLimitWarn(domainName),
+		config.HistorySizeLimitError(domainName),
+		config.HistoryCountLimitWarn(domainName),
+		config.HistoryCountLimitError(domainName),
+		common.EmptyEventID,
+		c.msBuilder,
+		c.stats,
+		c.metricsClient,
+		c.shard.GetThrottledLogger(),
+	)
+	terminated, err := workflowSizeChecker.terminateWorkflowSizeExceedsLimit()
+	if err != nil || !terminated {
+		return err
+	}
+
+	tranT, timerT, err := getWorkflowHistoryCleanupTasksFromShard(
+		c.shard,
+		c.domainID,
+		c.workflowExecution.GetWorkflowId(),
+		nil,
+	)
+	if err != nil {
+		return err
+	}
+	transactionID, err := c.shard.GetNextTransferTaskID()
+	if err != nil {
+		return err
+	}
+	return c.update(
+		[]persistence.Task{tranT},
+		[]persistence.Task{timerT},
+		transactionID,
+		c.timeSource.Now(),
+		c.createReplicationTask,
+		nil,
+		"",
+		nil,
+		0,
 	)
 }
 
@@ -850,6 +942,7 @@ func (c *workflowExecutionContextImpl) update(
 	if createReplicationTask {
 		replicationTasks = append(replicationTasks, updates.syncActivityTasks...)
 	}
+	transferTasks = c.appendRecordChildExecutionCompletedTask(transferTasks)
 	setTaskInfo(c.msBuilder.GetCurrentVersion(), now, transferTasks, timerTasks)
 
 	// Update history size on mutableState before calling UpdateWorkflowExecution
@@ -882,7 +975,8 @@ func (c *workflowExecutionContextImpl) update(
 			UpsertSignalInfos:         updates.updateSignalInfos,
 			DeleteSignalInfo:          updates.deleteSignalInfo,
 			UpsertSignalRequestedIDs:  updates.updateSignalRequestedIDs,
-			DeleteSignalRequestedID:   updates.deleteSignalRequestedID,
+			DeleteSignalRequestedIDs:  updates.deleteSignalRequestedIDs,
+			UpsertUpdateInfos:         updates.updateUpdateInfos,
 			NewBufferedEvents:         updates.newBufferedEvents,
 			ClearBufferedEvents:       updates.clearBufferedEvents,
 		},
@@ -1200,7 +1294,7 @@ func (c *workflowExecutionContextImpl) scheduleNewDecision(
 		})
 		if msBuilder.IsStickyTaskListEnabled() {
 			tBuilder := newTimerBuilder(c.shard.GetConfig(), c.logger, clock.NewRealTimeSource())
-			stickyTaskTimeoutTimer := tBuilder.AddScheduleToStartDecisionTimoutTask(di.ScheduleID, di.Attempt,
+			stickyTaskTimeoutTimer := tBuilder.AddScheduleToStartDecisionTimoutTask(di.ScheduleID, di.scheduleAttempt(),
 				executionInfo.StickyScheduleToStartTimeout)
 			timerTasks = append(timerTasks, stickyTaskTimeoutTimer)
 		}
@@ -1209,6 +1303,24 @@ func (c *workflowExecutionContextImpl) scheduleNewDecision(
 	return transferTasks, timerTasks, nil
 }
 
+// appendRecordChildExecutionCompletedTask makes sure the parent notification of a closing child workflow
+// is persisted together with the close execution task, so the parent will always learn about the completion
+func (c *workflowExecutionContextImpl) appendRecordChildExecutionCompletedTask(
+	transferTasks []persistence.Task,
+) []persistence.Task {
+
+	for _, task := range transferTasks {
+		if task.GetType() != persistence.TransferTaskTypeCloseExecution {
+			continue
+		}
+		if parentTask := getRecordChildExecutionCompletedTask(c.msBuilder); parentTask != nil {
+			transferTasks = append(transferTasks, parentTask)
+		}
+		break
+	}
+	return transferTasks
+}
+
 func (c *workflowExecutionContextImpl) failInflightDecision() error {
 	c.clear()
 
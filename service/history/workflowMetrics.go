@@ -94,12 +94,14 @@ func emitSessionUpdateStats(
 	sizeScope.RecordTimer(metrics.ChildInfoSize, time.Duration(stats.ChildInfoSize))
 	sizeScope.RecordTimer(metrics.SignalInfoSize, time.Duration(stats.SignalInfoSize))
 	sizeScope.RecordTimer(metrics.BufferedEventsSize, time.Duration(stats.BufferedEventsSize))
+	sizeScope.RecordTimer(metrics.TransactionSize, time.Duration(stats.TransactionSize))
 
 	countScope.RecordTimer(metrics.ActivityInfoCount, time.Duration(stats.ActivityInfoCount))
 	countScope.RecordTimer(metrics.TimerInfoCount, time.Duration(stats.TimerInfoCount))
 	countScope.RecordTimer(metrics.ChildInfoCount, time.Duration(stats.ChildInfoCount))
 	countScope.RecordTimer(metrics.SignalInfoCount, time.Duration(stats.SignalInfoCount))
 	countScope.RecordTimer(metrics.RequestCancelInfoCount, time.Duration(stats.RequestCancelInfoCount))
+	countScope.RecordTimer(metrics.TransactionStatementCount, time.Duration(stats.TransactionStatementCount))
 	countScope.RecordTimer(metrics.DeleteActivityInfoCount, time.Duration(stats.DeleteActivityInfoCount))
 	countScope.RecordTimer(metrics.DeleteTimerInfoCount, time.Duration(stats.DeleteTimerInfoCount))
 	countScope.RecordTimer(metrics.DeleteChildInfoCount, time.Duration(stats.DeleteChildInfoCount))
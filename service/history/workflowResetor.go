@@ -23,6 +23,8 @@ package history
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/pborman/uuid"
@@ -32,6 +34,7 @@ import (
 	"github.com/uber/cadence/common/cache"
 	"github.com/uber/cadence/common/clock"
 	ce "github.com/uber/cadence/common/errors"
+	"github.com/uber/cadence/common/log/tag"
 	"github.com/uber/cadence/common/persistence"
 )
 
@@ -61,6 +64,13 @@ type (
 
 var _ workflowResetor = (*workflowResetorImpl)(nil)
 
+const (
+	// historyForkTimeout is how long a fork can be in progress before the branch is considered abandoned
+	historyForkTimeout = time.Minute
+	// listExecutionRunsPageSize is the page size used to list the runs of a workflow when pruning superseded branches
+	listExecutionRunsPageSize = 100
+)
+
 func newWorkflowResetor(historyEngine *historyEngineImpl) *workflowResetorImpl {
 	return &workflowResetorImpl{
 		eng:        historyEngine,
@@ -89,6 +99,11 @@ func (w *workflowResetorImpl) ResetWorkflowExecution(
 		RunId: common.StringPtr(resetNewRunID),
 	}
 
+	retError = w.checkHistoryBranchLimit(ctx, domainEntry.GetInfo().Name, baseMutableState, currMutableState.GetExecutionInfo().RunID)
+	if retError != nil {
+		return response, retError
+	}
+
 	// before changing mutable state
 	currPrevRunVersion := currMutableState.GetLastWriteVersion()
 	// terminate the current run if it is running
@@ -146,6 +161,235 @@ func (w *workflowResetorImpl) ResetWorkflowExecution(
 	return response, retError
 }
 
+// checkHistoryBranchLimit rejects the reset when the history tree of the workflow already has the maximum number
+// of live branches allowed for the domain. Branches abandoned by resets which failed before creating their run
+// are pruned and not counted against the limit, then the branches of the closed runs superseded by later resets
+// are pruned, oldest first, to make room for the new branch.
+func (w *workflowResetorImpl) checkHistoryBranchLimit(
+	ctx context.Context,
+	domain string,
+	baseMutableState mutableState,
+	currentRunID string,
+) error {
+	maxBranches := w.eng.config.MaximumHistoryBranchesPerExecution(domain)
+	if maxBranches <= 0 {
+		return nil
+	}
+
+	shardID := common.IntPtr(w.eng.shard.GetShardID())
+	baseBranchToken := baseMutableState.GetCurrentBranch()
+	resp, err := w.eng.historyV2Mgr.GetHistoryTree(&persistence.GetHistoryTreeRequest{
+		BranchToken: baseBranchToken,
+		ShardID:     shardID,
+	})
+	if err != nil {
+		return err
+	}
+
+	liveBranches := len(resp.Branches)
+	for _, branch := range resp.ForkingInProgressBranches {
+		pruned, err := w.pruneAbandonedBranch(baseBranchToken, branch, shardID)
+		if err != nil {
+			return err
+		}
+		if pruned {
+			liveBranches--
+		}
+	}
+
+	if liveBranches >= maxBranches {
+		pruned, err := w.pruneSupersededBranches(ctx, baseMutableState, currentRunID, liveBranches-maxBranches+1)
+		if err != nil {
+			return err
+		}
+		liveBranches -= pruned
+	}
+
+	if liveBranches >= maxBranches {
+		return &workflow.BadRequestError{
+			Message: fmt.Sprintf("reset is not allowed, workflow history already has %v branches, the limit is %v.", liveBranches, maxBranches),
+		}
+	}
+	return nil
+}
+
+// pruneAbandonedBranch deletes a branch whose fork has been in progress for longer than the fork timeout
+// and whose run does not exist, it returns true if the branch is deleted
+func (w *workflowResetorImpl) pruneAbandonedBranch(
+	baseBranchToken []byte,
+	branch persistence.ForkingInProgressBranch,
+	shardID *int,
+) (bool, error) {
+
+	if w.timeSource.Now().Before(branch.ForkTime.Add(historyForkTimeout)) {
+		return false, nil
+	}
+	domainID, workflowID, runID, ok := splitHistoryGarbageCleanupInfo(branch.Info)
+	if !ok {
+		return false, nil
+	}
+
+	_, err := w.eng.executionManager.GetWorkflowExecution(&persistence.GetWorkflowExecutionRequest{
+		DomainID: domainID,
+		Execution: workflow.WorkflowExecution{
+			WorkflowId: common.StringPtr(workflowID),
+			RunId:      common.StringPtr(runID),
+		},
+	})
+	if err == nil {
+		return false, nil
+	}
+	if _, ok := err.(*workflow.EntityNotExistsError); !ok {
+		return false, err
+	}
+
+	branchToken, err := persistence.NewHistoryBranchTokenFromAnother(branch.BranchID, baseBranchToken)
+	if err != nil {
+		return false, err
+	}
+	err = w.eng.historyV2Mgr.CompleteForkBranch(&persistence.CompleteForkBranchRequest{
+		BranchToken: branchToken,
+		Success:     false,
+		ShardID:     shardID,
+	})
+	if err != nil {
+		return false, err
+	}
+
+	w.eng.logger.Info("Pruned abandoned history branch.",
+		tag.WorkflowDomainID(domainID),
+		tag.WorkflowID(workflowID),
+		tag.WorkflowRunID(runID))
+	return true, nil
+}
+
+// pruneSupersededBranches deletes up to count closed runs of the history tree of the base run, oldest first,
+// together with their history branch. The base run and the current run of the reset are never pruned, the
+// other closed runs of the tree were replaced by later resets. It returns the number of pruned branches.
+func (w *workflowResetorImpl) pruneSupersededBranches(
+	ctx context.Context,
+	baseMutableState mutableState,
+	currentRunID string,
+	count int,
+) (int, error) {
+
+	executionInfo := baseMutableState.GetExecutionInfo()
+	treeID, err := persistence.HistoryBranchTreeID(baseMutableState.GetCurrentBranch())
+	if err != nil {
+		return 0, err
+	}
+
+	var candidates []*persistence.WorkflowExecutionRunInfo
+	var pageToken []byte
+	for {
+		resp, err := w.eng.executionManager.ListWorkflowExecutionRuns(&persistence.ListWorkflowExecutionRunsRequest{
+			DomainID:      executionInfo.DomainID,
+			WorkflowID:    executionInfo.WorkflowID,
+			PageSize:      listExecutionRunsPageSize,
+			NextPageToken: pageToken,
+		})
+		if err != nil {
+			return 0, err
+		}
+		for _, run := range resp.Runs {
+			if run.State == persistence.WorkflowStateCompleted && run.RunID != executionInfo.RunID && run.RunID != currentRunID {
+				candidates = append(candidates, run)
+			}
+		}
+		if len(resp.NextPageToken) == 0 {
+			break
+		}
+		pageToken = resp.NextPageToken
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		return candidates[i].StartTimestamp.Before(candidates[j].StartTimestamp)
+	})
+
+	pruned := 0
+	for _, run := range candidates {
+		if pruned == count {
+			break
+		}
+		ok, err := w.pruneSupersededRun(ctx, executionInfo.DomainID, executionInfo.WorkflowID, run.RunID, treeID)
+		if err != nil {
+			return pruned, err
+		}
+		if ok {
+			pruned++
+		}
+	}
+	return pruned, nil
+}
+
+// pruneSupersededRun deletes a closed run whose history branch belongs to the given tree, the run is deleted the
+// same way retention deletes it. It returns true if the run is deleted.
+func (w *workflowResetorImpl) pruneSupersededRun(
+	ctx context.Context,
+	domainID string,
+	workflowID string,
+	runID string,
+	treeID string,
+) (pruned bool, retError error) {
+
+	execution := workflow.WorkflowExecution{
+		WorkflowId: common.StringPtr(workflowID),
+		RunId:      common.StringPtr(runID),
+	}
+	runContext, release, err := w.eng.historyCache.getOrCreateWorkflowExecution(ctx, domainID, execution)
+	if err != nil {
+		return false, err
+	}
+	defer func() { release(retError) }()
+
+	msBuilder, err := runContext.loadWorkflowExecution()
+	if err != nil {
+		if _, ok := err.(*workflow.EntityNotExistsError); ok {
+			return false, nil
+		}
+		return false, err
+	}
+	if msBuilder.IsWorkflowExecutionRunning() || msBuilder.GetEventStoreVersion() != persistence.EventStoreVersionV2 {
+		return false, nil
+	}
+	// a run continued as new starts a tree of its own
+	runTreeID, err := persistence.HistoryBranchTreeID(msBuilder.GetCurrentBranch())
+	if err != nil {
+		return false, err
+	}
+	if runTreeID != treeID {
+		return false, nil
+	}
+
+	if err := w.eng.executionManager.DeleteWorkflowExecution(&persistence.DeleteWorkflowExecutionRequest{
+		DomainID:   domainID,
+		WorkflowID: workflowID,
+		RunID:      runID,
+	}); err != nil {
+		return false, err
+	}
+	// the nodes shared with the branches of the other runs are kept
+	if err := persistence.DeleteWorkflowExecutionHistoryV2(
+		w.eng.historyV2Mgr, msBuilder.GetCurrentBranch(), common.IntPtr(w.eng.shard.GetShardID()), w.eng.logger,
+	); err != nil {
+		return false, err
+	}
+	if err := w.eng.visibilityMgr.DeleteWorkflowExecution(&persistence.VisibilityDeleteWorkflowExecutionRequest{
+		DomainID:   domainID,
+		WorkflowID: workflowID,
+		RunID:      runID,
+	}); err != nil {
+		return false, err
+	}
+	// force the next access of the run to read the database
+	runContext.clear()
+
+	w.eng.logger.Info("Pruned superseded history branch.",
+		tag.WorkflowDomainID(domainID),
+		tag.WorkflowID(workflowID),
+		tag.WorkflowRunID(runID))
+	return true, nil
+}
+
 func (w *workflowResetorImpl) checkDomainStatus(newMutableState mutableState, prevRunVersion int64, domain string) error {
 	if newMutableState.GetReplicationState() != nil {
 		clusterMetadata := w.eng.shard.GetService().GetClusterMetadata()
@@ -259,7 +503,7 @@ func (w *workflowResetorImpl) buildNewMutableStateForReset(
 
 	// replay history to reset point(exclusive) to rebuild mutableState
 	forkEventVersion, wfTimeoutSecs, receivedSignals, continueRunID, newStateBuilder, historySize, retError := w.replayHistoryEvents(
-		resetDecisionCompletedEventID, requestedID, baseMutableState, newRunID,
+		ctx, resetDecisionCompletedEventID, requestedID, baseMutableState, newRunID,
 	)
 	if retError != nil {
 		return
@@ -377,6 +621,16 @@ func historyGarbageCleanupInfo(domainID, workflowID, runID string) string {
 	return fmt.Sprintf("%v:%v:%v", domainID, workflowID, runID)
 }
 
+// splitHistoryGarbageCleanupInfo is the reverse of historyGarbageCleanupInfo, the workflow ID may contain ':'
+func splitHistoryGarbageCleanupInfo(info string) (domainID, workflowID, runID string, ok bool) {
+	first := strings.Index(info, ":")
+	last := strings.LastIndex(info, ":")
+	if first < 0 || first == last {
+		return "", "", "", false
+	}
+	return info[:first], info[first+1 : last], info[last+1:], true
+}
+
 func (w *workflowResetorImpl) setEventIDsWithHistory(msBuilder mutableState) int64 {
 	history := msBuilder.GetHistoryBuilder().GetHistory().Events
 	firstEvent := history[0]
@@ -551,6 +805,7 @@ func getRespondActivityTaskFailedRequestFromActivity(ai *persistence.ActivityInf
 
 // TODO: @shreyassrivatsan reduce the number of return parameters from this method or return a struct
 func (w *workflowResetorImpl) replayHistoryEvents(
+	ctx context.Context,
 	decisionFinishEventID int64,
 	requestID string,
 	prevMutableState mutableState,
@@ -571,6 +826,8 @@ func (w *workflowResetorImpl) replayHistoryEvents(
 		PageSize:      defaultHistoryPageSize,
 		NextPageToken: nextPageToken,
 		ShardID:       common.IntPtr(w.eng.shard.GetShardID()),
+		// the replay only reads, the reset is not written yet when the caller gives up
+		Context: persistenceRequestContext(ctx),
 	}
 	var resetMutableState *mutableStateBuilder
 	var lastBatch []*workflow.HistoryEvent
@@ -880,6 +1137,7 @@ func (w *workflowResetorImpl) replicateResetEvent(
 	// always enforce the attempt to zero so that we can always schedule a new decision(skip trasientDecision logic)
 	di, _ := newMsBuilder.GetInFlightDecisionTask()
 	di.Attempt = 0
+	di.HeartbeatCount = 0
 	newMsBuilder.UpdateDecision(di)
 
 	lastEvent = newRunHistory[len(newRunHistory)-1]
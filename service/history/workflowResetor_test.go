@@ -21,6 +21,7 @@
 package history
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"math"
@@ -48,6 +49,7 @@ import (
 	"github.com/uber/cadence/common/mocks"
 	p "github.com/uber/cadence/common/persistence"
 	"github.com/uber/cadence/common/service"
+	"github.com/uber/cadence/common/service/dynamicconfig"
 	"github.com/uber/cadence/service/worker/archiver"
 	"go.uber.org/cadence/.gen/go/shared"
 )
@@ -160,6 +162,7 @@ func (s *resetorSuite) SetupTest() {
 		tokenSerializer:    common.NewJSONTaskTokenSerializer(),
 		config:             s.config,
 		archivalClient:     s.mockArchivalClient,
+		visibilityMgr:      s.mockVisibilityMgr,
 	}
 	h.txProcessor = newTransferQueueProcessor(mockShard, h, s.mockVisibilityMgr, s.mockMatchingClient, s.mockHistoryClient, s.logger)
 	h.timerProcessor = newTimerQueueProcessor(mockShard, h, s.mockMatchingClient, s.logger)
@@ -4204,6 +4207,166 @@ func (s *resetorSuite) TestApplyReset() {
 	s.Equal(0, len(resetReq.NewWorkflowSnapshot.RequestCancelInfos))
 }
 
+func (s *resetorSuite) TestCheckHistoryBranchLimit() {
+	defer func(maxBranches dynamicconfig.IntPropertyFnWithDomainFilter) {
+		s.config.MaximumHistoryBranchesPerExecution = maxBranches
+	}(s.config.MaximumHistoryBranchesPerExecution)
+	s.config.MaximumHistoryBranchesPerExecution = dynamicconfig.GetIntPropertyFilteredByDomain(3)
+
+	domainID := validDomainID
+	wid := "wId"
+	baseRunID := uuid.New().String()
+	currentRunID := uuid.New().String()
+	abandonedRunID := uuid.New().String()
+	branchToken, err := p.NewHistoryBranchToken(uuid.New().String())
+	s.NoError(err)
+	baseMutableState := &mockMutableState{}
+	defer baseMutableState.AssertExpectations(s.T())
+	baseMutableState.On("GetCurrentBranch").Return(branchToken)
+	baseMutableState.On("GetExecutionInfo").Return(&p.WorkflowExecutionInfo{
+		DomainID:   domainID,
+		WorkflowID: wid,
+		RunID:      baseRunID,
+	})
+
+	branches := []*workflow.HistoryBranch{{}, {}, {}}
+	abandonedBranch := p.ForkingInProgressBranch{
+		BranchID: uuid.New().String(),
+		ForkTime: time.Now().Add(-time.Hour),
+		Info:     historyGarbageCleanupInfo(domainID, wid, abandonedRunID),
+	}
+	inProgressBranch := p.ForkingInProgressBranch{
+		BranchID: uuid.New().String(),
+		ForkTime: time.Now(),
+		Info:     historyGarbageCleanupInfo(domainID, wid, uuid.New().String()),
+	}
+	s.mockHistoryV2Mgr.On("GetHistoryTree", mock.Anything).Return(&p.GetHistoryTreeResponse{
+		Branches:                  branches,
+		ForkingInProgressBranches: []p.ForkingInProgressBranch{abandonedBranch, inProgressBranch},
+	}, nil).Once()
+	s.mockExecutionMgr.On("GetWorkflowExecution", &p.GetWorkflowExecutionRequest{
+		DomainID: domainID,
+		Execution: workflow.WorkflowExecution{
+			WorkflowId: common.StringPtr(wid),
+			RunId:      common.StringPtr(abandonedRunID),
+		},
+	}).Return(nil, &workflow.EntityNotExistsError{}).Once()
+	s.mockHistoryV2Mgr.On("CompleteForkBranch", mock.MatchedBy(func(request *p.CompleteForkBranchRequest) bool {
+		return !request.Success
+	})).Return(nil).Once()
+
+	// the abandoned branch is pruned and not counted against the limit
+	s.NoError(s.resetor.(*workflowResetorImpl).checkHistoryBranchLimit(
+		context.Background(), "some random domain name", baseMutableState, currentRunID,
+	))
+
+	// neither the base run nor the current run is pruned
+	s.mockHistoryV2Mgr.On("GetHistoryTree", mock.Anything).Return(&p.GetHistoryTreeResponse{
+		Branches: branches,
+	}, nil).Once()
+	s.mockExecutionMgr.On("ListWorkflowExecutionRuns", mock.Anything).Return(&p.ListWorkflowExecutionRunsResponse{
+		Runs: []*p.WorkflowExecutionRunInfo{
+			{RunID: baseRunID, State: p.WorkflowStateCompleted},
+			{RunID: currentRunID, State: p.WorkflowStateCompleted},
+		},
+	}, nil).Once()
+	err = s.resetor.(*workflowResetorImpl).checkHistoryBranchLimit(
+		context.Background(), "some random domain name", baseMutableState, currentRunID,
+	)
+	s.IsType(&workflow.BadRequestError{}, err)
+}
+
+func (s *resetorSuite) TestCheckHistoryBranchLimit_PruneSupersededRun() {
+	defer func(maxBranches dynamicconfig.IntPropertyFnWithDomainFilter) {
+		s.config.MaximumHistoryBranchesPerExecution = maxBranches
+	}(s.config.MaximumHistoryBranchesPerExecution)
+	s.config.MaximumHistoryBranchesPerExecution = dynamicconfig.GetIntPropertyFilteredByDomain(3)
+
+	testDomainEntry := cache.NewLocalDomainCacheEntryForTest(
+		&p.DomainInfo{ID: validDomainID}, &p.DomainConfig{Retention: 1}, "", nil,
+	)
+	s.mockDomainCache.On("GetDomainByID", mock.Anything).Return(testDomainEntry, nil)
+
+	domainID := validDomainID
+	wid := "wId"
+	baseRunID := uuid.New().String()
+	currentRunID := uuid.New().String()
+	oldestRunID := uuid.New().String()
+	olderRunID := uuid.New().String()
+	branchToken, err := p.NewHistoryBranchToken(uuid.New().String())
+	s.NoError(err)
+	oldestBranchToken, err := p.NewHistoryBranchTokenFromAnother(uuid.New().String(), branchToken)
+	s.NoError(err)
+	baseMutableState := &mockMutableState{}
+	defer baseMutableState.AssertExpectations(s.T())
+	baseMutableState.On("GetCurrentBranch").Return(branchToken)
+	baseMutableState.On("GetExecutionInfo").Return(&p.WorkflowExecutionInfo{
+		DomainID:   domainID,
+		WorkflowID: wid,
+		RunID:      baseRunID,
+	})
+
+	s.mockHistoryV2Mgr.On("GetHistoryTree", mock.Anything).Return(&p.GetHistoryTreeResponse{
+		Branches: []*workflow.HistoryBranch{{}, {}, {}},
+	}, nil).Once()
+	now := time.Now()
+	s.mockExecutionMgr.On("ListWorkflowExecutionRuns", mock.Anything).Return(&p.ListWorkflowExecutionRunsResponse{
+		Runs: []*p.WorkflowExecutionRunInfo{
+			{RunID: currentRunID, StartTimestamp: now, State: p.WorkflowStateRunning},
+			{RunID: olderRunID, StartTimestamp: now.Add(-time.Hour), State: p.WorkflowStateCompleted},
+			{RunID: oldestRunID, StartTimestamp: now.Add(-2 * time.Hour), State: p.WorkflowStateCompleted},
+			{RunID: baseRunID, StartTimestamp: now.Add(-3 * time.Hour), State: p.WorkflowStateCompleted},
+		},
+	}, nil).Once()
+	// only the oldest superseded run is pruned, one branch is enough to fit the limit
+	s.mockExecutionMgr.On("GetWorkflowExecution", &p.GetWorkflowExecutionRequest{
+		DomainID: domainID,
+		Execution: workflow.WorkflowExecution{
+			WorkflowId: common.StringPtr(wid),
+			RunId:      common.StringPtr(oldestRunID),
+		},
+	}).Return(&p.GetWorkflowExecutionResponse{State: &p.WorkflowMutableState{
+		ExecutionInfo: &p.WorkflowExecutionInfo{
+			DomainID:          domainID,
+			WorkflowID:        wid,
+			RunID:             oldestRunID,
+			BranchToken:       oldestBranchToken,
+			State:             p.WorkflowStateCompleted,
+			CloseStatus:       p.WorkflowCloseStatusCompleted,
+			EventStoreVersion: p.EventStoreVersionV2,
+		},
+		ExecutionStats: &p.ExecutionStats{},
+	}}, nil).Once()
+	s.mockExecutionMgr.On("DeleteWorkflowExecution", &p.DeleteWorkflowExecutionRequest{
+		DomainID:   domainID,
+		WorkflowID: wid,
+		RunID:      oldestRunID,
+	}).Return(nil).Once()
+	s.mockHistoryV2Mgr.On("DeleteHistoryBranch", mock.MatchedBy(func(request *p.DeleteHistoryBranchRequest) bool {
+		return bytes.Equal(request.BranchToken, oldestBranchToken)
+	})).Return(nil).Once()
+	s.mockVisibilityMgr.On("DeleteWorkflowExecution", &p.VisibilityDeleteWorkflowExecutionRequest{
+		DomainID:   domainID,
+		WorkflowID: wid,
+		RunID:      oldestRunID,
+	}).Return(nil).Once()
+
+	s.NoError(s.resetor.(*workflowResetorImpl).checkHistoryBranchLimit(
+		context.Background(), "some random domain name", baseMutableState, currentRunID,
+	))
+}
+
+func TestSplitHistoryGarbageCleanupInfo(t *testing.T) {
+	domainID, workflowID, runID, ok := splitHistoryGarbageCleanupInfo(historyGarbageCleanupInfo("domain", "workflow:id", "run"))
+	assert.True(t, ok)
+	assert.Equal(t, "domain", domainID)
+	assert.Equal(t, "workflow:id", workflowID)
+	assert.Equal(t, "run", runID)
+
+	_, _, _, ok = splitHistoryGarbageCleanupInfo("invalid")
+	assert.False(t, ok)
+}
+
 func TestFindAutoResetPoint(t *testing.T) {
 	timeSource := clock.NewRealTimeSource()
 
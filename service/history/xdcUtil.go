@@ -102,11 +102,11 @@ func loadMutableStateForTransferTask(context workflowExecutionContext, transferT
 	executionInfo := msBuilder.GetExecutionInfo()
 
 	// check to see if cache needs to be refreshed as we could potentially have stale workflow execution
-	// the exception is decision consistently fail
+	// the exception is decision consistently fail or heartbeat
 	// there will be no event generated, thus making the decision schedule ID == next event ID
 	isDecisionRetry := transferTask.TaskType == persistence.TransferTaskTypeDecisionTask &&
 		executionInfo.DecisionScheduleID == transferTask.ScheduleID &&
-		executionInfo.DecisionAttempt > 0
+		(executionInfo.DecisionAttempt > 0 || executionInfo.DecisionHeartbeatCount > 0)
 
 	if transferTask.ScheduleID >= msBuilder.GetNextEventID() && !isDecisionRetry {
 		metricsClient.IncCounter(metrics.TransferQueueProcessorScope, metrics.StaleMutableStateCounter)
@@ -142,11 +142,11 @@ func loadMutableStateForTimerTask(context workflowExecutionContext, timerTask *p
 	executionInfo := msBuilder.GetExecutionInfo()
 
 	// check to see if cache needs to be refreshed as we could potentially have stale workflow execution
-	// the exception is decision consistently fail
+	// the exception is decision consistently fail or heartbeat
 	// there will be no event generated, thus making the decision schedule ID == next event ID
 	isDecisionRetry := timerTask.TaskType == persistence.TaskTypeDecisionTimeout &&
 		executionInfo.DecisionScheduleID == timerTask.EventID &&
-		executionInfo.DecisionAttempt > 0
+		(executionInfo.DecisionAttempt > 0 || executionInfo.DecisionHeartbeatCount > 0)
 
 	if timerTask.EventID >= msBuilder.GetNextEventID() && !isDecisionRetry {
 		metricsClient.IncCounter(metrics.TimerQueueProcessorScope, metrics.StaleMutableStateCounter)
@@ -44,10 +44,14 @@ type (
 		LongPollExpirationInterval dynamicconfig.DurationPropertyFnWithTaskListInfoFilters
 		MinTaskThrottlingBurstSize dynamicconfig.IntPropertyFnWithTaskListInfoFilters
 		MaxTaskDeleteBatchSize     dynamicconfig.IntPropertyFnWithTaskListInfoFilters
+		// Number of buffered tasks below which more tasks are read ahead from persistence
+		TaskBufferLowWatermark dynamicconfig.IntPropertyFnWithTaskListInfoFilters
 
 		// taskWriter configuration
 		OutstandingTaskAppendsThreshold dynamicconfig.IntPropertyFnWithTaskListInfoFilters
 		MaxTaskBatchSize                dynamicconfig.IntPropertyFnWithTaskListInfoFilters
+		// Time to wait for more tasks added to the task list to write them in the same batch
+		TaskBatchWindow dynamicconfig.DurationPropertyFnWithTaskListInfoFilters
 
 		ThrottledLogRPS dynamicconfig.IntPropertyFn
 	}
@@ -58,6 +62,7 @@ type (
 		LongPollExpirationInterval func() time.Duration
 		RangeSize                  int64
 		GetTasksBatchSize          func() int
+		TaskBufferLowWatermark     func() int
 		UpdateAckInterval          func() time.Duration
 		IdleTasklistCheckInterval  func() time.Duration
 		MaxTasklistIdleTime        func() time.Duration
@@ -66,6 +71,7 @@ type (
 		// taskWriter configuration
 		OutstandingTaskAppendsThreshold func() int
 		MaxTaskBatchSize                func() int
+		TaskBatchWindow                 func() time.Duration
 	}
 )
 
@@ -77,6 +83,7 @@ func NewConfig(dc *dynamicconfig.Collection) *Config {
 		RPS:                             dc.GetIntProperty(dynamicconfig.MatchingRPS, 1200),
 		RangeSize:                       100000,
 		GetTasksBatchSize:               dc.GetIntPropertyFilteredByTaskListInfo(dynamicconfig.MatchingGetTasksBatchSize, 1000),
+		TaskBufferLowWatermark:          dc.GetIntPropertyFilteredByTaskListInfo(dynamicconfig.MatchingTaskBufferLowWatermark, 200),
 		UpdateAckInterval:               dc.GetDurationPropertyFilteredByTaskListInfo(dynamicconfig.MatchingUpdateAckInterval, 1*time.Minute),
 		IdleTasklistCheckInterval:       dc.GetDurationPropertyFilteredByTaskListInfo(dynamicconfig.MatchingIdleTasklistCheckInterval, 5*time.Minute),
 		MaxTasklistIdleTime:             dc.GetDurationPropertyFilteredByTaskListInfo(dynamicconfig.MaxTasklistIdleTime, 5*time.Minute),
@@ -85,6 +92,7 @@ func NewConfig(dc *dynamicconfig.Collection) *Config {
 		MaxTaskDeleteBatchSize:          dc.GetIntPropertyFilteredByTaskListInfo(dynamicconfig.MatchingMaxTaskDeleteBatchSize, 100),
 		OutstandingTaskAppendsThreshold: dc.GetIntPropertyFilteredByTaskListInfo(dynamicconfig.MatchingOutstandingTaskAppendsThreshold, 250),
 		MaxTaskBatchSize:                dc.GetIntPropertyFilteredByTaskListInfo(dynamicconfig.MatchingMaxTaskBatchSize, 100),
+		TaskBatchWindow:                 dc.GetDurationPropertyFilteredByTaskListInfo(dynamicconfig.MatchingTaskBatchWindow, 0),
 		ThrottledLogRPS:                 dc.GetIntProperty(dynamicconfig.MatchingThrottledLogRPS, 20),
 	}
 }
@@ -103,6 +111,9 @@ func newTaskListConfig(id *taskListID, config *Config, domainCache cache.DomainC
 		GetTasksBatchSize: func() int {
 			return config.GetTasksBatchSize(domain, taskListName, taskType)
 		},
+		TaskBufferLowWatermark: func() int {
+			return config.TaskBufferLowWatermark(domain, taskListName, taskType)
+		},
 		UpdateAckInterval: func() time.Duration {
 			return config.UpdateAckInterval(domain, taskListName, taskType)
 		},
@@ -130,5 +141,8 @@ func newTaskListConfig(id *taskListID, config *Config, domainCache cache.DomainC
 		MaxTaskBatchSize: func() int {
 			return config.MaxTaskBatchSize(domain, taskListName, taskType)
 		},
+		TaskBatchWindow: func() time.Duration {
+			return config.TaskBatchWindow(domain, taskListName, taskType)
+		},
 	}, nil
 }
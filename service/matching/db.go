@@ -21,6 +21,7 @@
 package matching
 
 import (
+	"context"
 	"sync"
 	"sync/atomic"
 
@@ -38,6 +39,7 @@ type (
 		taskType     int
 		rangeID      int64
 		ackLevel     int64
+		versionSets  [][]string
 		store        persistence.TaskManager
 		logger       log.Logger
 	}
@@ -75,6 +77,13 @@ func (db *taskListDB) RangeID() int64 {
 	return db.rangeID
 }
 
+// VersionSets returns the current persistence view of the worker build ID version sets
+func (db *taskListDB) VersionSets() [][]string {
+	db.Lock()
+	defer db.Unlock()
+	return db.versionSets
+}
+
 // RenewLease renews the lease on a tasklist. If there is no previous lease,
 // this method will attempt to steal tasklist from current owner
 func (db *taskListDB) RenewLease() (taskListState, error) {
@@ -92,6 +101,7 @@ func (db *taskListDB) RenewLease() (taskListState, error) {
 	}
 	db.ackLevel = resp.TaskListInfo.AckLevel
 	db.rangeID = resp.TaskListInfo.RangeID
+	db.versionSets = resp.TaskListInfo.VersionSets
 	return taskListState{rangeID: db.rangeID, ackLevel: db.ackLevel}, nil
 }
 
@@ -101,12 +111,13 @@ func (db *taskListDB) UpdateState(ackLevel int64) error {
 	defer db.Unlock()
 	_, err := db.store.UpdateTaskList(&persistence.UpdateTaskListRequest{
 		TaskListInfo: &persistence.TaskListInfo{
-			DomainID: db.domainID,
-			Name:     db.taskListName,
-			TaskType: db.taskType,
-			AckLevel: ackLevel,
-			RangeID:  db.rangeID,
-			Kind:     db.taskListKind,
+			DomainID:    db.domainID,
+			Name:        db.taskListName,
+			TaskType:    db.taskType,
+			AckLevel:    ackLevel,
+			RangeID:     db.rangeID,
+			Kind:        db.taskListKind,
+			VersionSets: db.versionSets,
 		},
 	})
 	if err == nil {
@@ -115,25 +126,47 @@ func (db *taskListDB) UpdateState(ackLevel int64) error {
 	return err
 }
 
+// UpdateVersionSets replaces the worker build ID version sets of the taskList
+func (db *taskListDB) UpdateVersionSets(versionSets [][]string) error {
+	db.Lock()
+	defer db.Unlock()
+	_, err := db.store.UpdateTaskList(&persistence.UpdateTaskListRequest{
+		TaskListInfo: &persistence.TaskListInfo{
+			DomainID:    db.domainID,
+			Name:        db.taskListName,
+			TaskType:    db.taskType,
+			AckLevel:    db.ackLevel,
+			RangeID:     db.rangeID,
+			Kind:        db.taskListKind,
+			VersionSets: versionSets,
+		},
+	})
+	if err == nil {
+		db.versionSets = versionSets
+	}
+	return err
+}
+
 // CreateTasks creates a batch of given tasks for this task list
 func (db *taskListDB) CreateTasks(tasks []*persistence.CreateTaskInfo) (*persistence.CreateTasksResponse, error) {
 	db.Lock()
 	defer db.Unlock()
 	return db.store.CreateTasks(&persistence.CreateTasksRequest{
 		TaskListInfo: &persistence.TaskListInfo{
-			DomainID: db.domainID,
-			Name:     db.taskListName,
-			TaskType: db.taskType,
-			AckLevel: db.ackLevel,
-			RangeID:  db.rangeID,
-			Kind:     db.taskListKind,
+			DomainID:    db.domainID,
+			Name:        db.taskListName,
+			TaskType:    db.taskType,
+			AckLevel:    db.ackLevel,
+			RangeID:     db.rangeID,
+			Kind:        db.taskListKind,
+			VersionSets: db.versionSets,
 		},
 		Tasks: tasks,
 	})
 }
 
-// GetTasks returns a batch of tasks between the given range
-func (db *taskListDB) GetTasks(minTaskID int64, maxTaskID int64, batchSize int) (*persistence.GetTasksResponse, error) {
+// GetTasks returns a batch of tasks between the given range, the read is abandoned once ctx is done
+func (db *taskListDB) GetTasks(ctx context.Context, minTaskID int64, maxTaskID int64, batchSize int) (*persistence.GetTasksResponse, error) {
 	return db.store.GetTasks(&persistence.GetTasksRequest{
 		DomainID:     db.domainID,
 		TaskList:     db.taskListName,
@@ -141,6 +174,7 @@ func (db *taskListDB) GetTasks(minTaskID int64, maxTaskID int64, batchSize int)
 		BatchSize:    batchSize,
 		ReadLevel:    minTaskID,  // exclusive
 		MaxReadLevel: &maxTaskID, // inclusive
+		Context:      ctx,
 	})
 }
 
@@ -0,0 +1,81 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package matching
+
+import (
+	"sync"
+	"time"
+
+	"github.com/uber/cadence/common/clock"
+)
+
+const (
+	// dispatchRateWindow is the period over which the dispatch rate of a task list is measured
+	dispatchRateWindow      = time.Minute
+	dispatchRateBucketCount = 12
+	dispatchRateBucketSize  = dispatchRateWindow / dispatchRateBucketCount
+)
+
+// dispatchRateTracker measures the rate at which tasks are handed to the pollers of a task list,
+// counting dispatches in time buckets covering the last dispatchRateWindow
+type dispatchRateTracker struct {
+	sync.Mutex
+	timeSource clock.TimeSource
+	counts     [dispatchRateBucketCount]int64
+	// index of the bucket interval since epoch each count belongs to
+	intervals [dispatchRateBucketCount]int64
+}
+
+func newDispatchRateTracker(timeSource clock.TimeSource) *dispatchRateTracker {
+	return &dispatchRateTracker{timeSource: timeSource}
+}
+
+func (t *dispatchRateTracker) recordDispatch() {
+	interval := t.currentInterval()
+	slot := interval % dispatchRateBucketCount
+
+	t.Lock()
+	defer t.Unlock()
+	if t.intervals[slot] != interval {
+		t.intervals[slot] = interval
+		t.counts[slot] = 0
+	}
+	t.counts[slot]++
+}
+
+// ratePerSecond returns the number of tasks dispatched per second over the last dispatchRateWindow
+func (t *dispatchRateTracker) ratePerSecond() float64 {
+	interval := t.currentInterval()
+
+	t.Lock()
+	defer t.Unlock()
+	var total int64
+	for slot, count := range t.counts {
+		if interval-t.intervals[slot] < dispatchRateBucketCount {
+			total += count
+		}
+	}
+	return float64(total) / dispatchRateWindow.Seconds()
+}
+
+func (t *dispatchRateTracker) currentInterval() int64 {
+	return t.timeSource.Now().UnixNano() / int64(dispatchRateBucketSize)
+}
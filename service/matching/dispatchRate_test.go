@@ -0,0 +1,54 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package matching
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/uber/cadence/common/clock"
+)
+
+func TestDispatchRateTracker(t *testing.T) {
+	timeSource := clock.NewEventTimeSource().Update(time.Unix(1000, 0))
+	tracker := newDispatchRateTracker(timeSource)
+	require.Zero(t, tracker.ratePerSecond())
+
+	for i := 0; i < 60; i++ {
+		tracker.recordDispatch()
+	}
+	require.Equal(t, 1.0, tracker.ratePerSecond())
+
+	timeSource.Update(time.Unix(1030, 0))
+	for i := 0; i < 30; i++ {
+		tracker.recordDispatch()
+	}
+	require.Equal(t, 1.5, tracker.ratePerSecond())
+
+	// the first dispatches are out of the window
+	timeSource.Update(time.Unix(1065, 0))
+	require.Equal(t, 0.5, tracker.ratePerSecond())
+
+	timeSource.Update(time.Unix(1100, 0))
+	require.Zero(t, tracker.ratePerSecond())
+}
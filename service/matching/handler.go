@@ -34,6 +34,7 @@ import (
 	"github.com/uber/cadence/common/cache"
 	"github.com/uber/cadence/common/clock"
 	"github.com/uber/cadence/common/log"
+	"github.com/uber/cadence/common/log/tag"
 	"github.com/uber/cadence/common/metrics"
 	"github.com/uber/cadence/common/persistence"
 	"github.com/uber/cadence/common/service"
@@ -52,6 +53,7 @@ type Handler struct {
 	startWG         sync.WaitGroup
 	domainCache     cache.DomainCache
 	rateLimiter     tokenbucket.TokenBucket
+	pingCache       *persistence.PingCache
 	service.Service
 }
 
@@ -67,6 +69,8 @@ func NewHandler(sVice service.Service, config *Config, taskPersistence persisten
 		metadataMgr:     metadataMgr,
 		config:          config,
 		rateLimiter:     tokenbucket.NewDynamicTokenBucket(config.RPS, clock.NewRealTimeSource()),
+		pingCache: persistence.NewPingCache(persistence.DefaultPingCacheTTL, clock.NewRealTimeSource(),
+			metadataMgr.Ping, taskPersistence.Ping),
 	}
 	// prevent us from trying to serve requests before matching engine is started and ready
 	handler.startWG.Add(1)
@@ -87,6 +91,7 @@ func (h *Handler) Start() error {
 	h.metricsClient = h.Service.GetMetricsClient()
 	h.engine = NewEngine(
 		h.taskPersistence, h.GetClientBean().GetHistoryClient(), h.config, h.Service.GetLogger(), h.Service.GetMetricsClient(), h.domainCache,
+		h.Service.GetTaskTokenSerializer(),
 	)
 	h.startWG.Done()
 	return nil
@@ -105,6 +110,11 @@ func (h *Handler) Stop() {
 func (h *Handler) Health(ctx context.Context) (*health.HealthStatus, error) {
 	h.startWG.Wait()
 	h.GetLogger().Debug("Matching service health check endpoint reached.")
+	if err := h.pingCache.Ping(); err != nil {
+		h.GetLogger().Warn("Matching service health check failed to reach persistence.", tag.Error(err))
+		hs := &health.HealthStatus{Ok: false, Msg: common.StringPtr("matching persistence unreachable")}
+		return hs, nil
+	}
 	hs := &health.HealthStatus{Ok: true, Msg: common.StringPtr("matching good")}
 	return hs, nil
 }
@@ -260,6 +270,54 @@ func (h *Handler) DescribeTaskList(ctx context.Context, request *m.DescribeTaskL
 	return response, h.handleErr(err, scope)
 }
 
+// GetTaskListScalingHints returns the backlog and dispatch rate of a tasklist partition
+func (h *Handler) GetTaskListScalingHints(ctx context.Context,
+	request *m.GetTaskListScalingHintsRequest) (resp *gen.GetTaskListScalingHintsResponse, retError error) {
+	defer log.CapturePanic(h.GetLogger(), &retError)
+	scope := metrics.MatchingGetTaskListScalingHintsScope
+	sw := h.startRequestProfile("GetTaskListScalingHints", scope)
+	defer sw.Stop()
+
+	if ok, _ := h.rateLimiter.TryConsume(1); !ok {
+		return nil, h.handleErr(errMatchingHostThrottle, scope)
+	}
+
+	response, err := h.engine.GetTaskListScalingHints(ctx, request)
+	return response, h.handleErr(err, scope)
+}
+
+// UpdateTaskListVersionSets replaces the worker build ID version sets of a decision task list
+func (h *Handler) UpdateTaskListVersionSets(ctx context.Context,
+	request *m.UpdateTaskListVersionSetsRequest) (retError error) {
+	defer log.CapturePanic(h.GetLogger(), &retError)
+	scope := metrics.MatchingUpdateTaskListVersionSetsScope
+	sw := h.startRequestProfile("UpdateTaskListVersionSets", scope)
+	defer sw.Stop()
+
+	if ok, _ := h.rateLimiter.TryConsume(1); !ok {
+		return h.handleErr(errMatchingHostThrottle, scope)
+	}
+
+	err := h.engine.UpdateTaskListVersionSets(ctx, request)
+	return h.handleErr(err, scope)
+}
+
+// DescribeTaskJourney returns the stages recently reached by a task of a task list
+func (h *Handler) DescribeTaskJourney(ctx context.Context,
+	request *m.DescribeTaskJourneyRequest) (resp *gen.DescribeTaskJourneyResponse, retError error) {
+	defer log.CapturePanic(h.GetLogger(), &retError)
+	scope := metrics.MatchingDescribeTaskJourneyScope
+	sw := h.startRequestProfile("DescribeTaskJourney", scope)
+	defer sw.Stop()
+
+	if ok, _ := h.rateLimiter.TryConsume(1); !ok {
+		return nil, h.handleErr(errMatchingHostThrottle, scope)
+	}
+
+	response, err := h.engine.DescribeTaskJourney(ctx, request)
+	return response, h.handleErr(err, scope)
+}
+
 func (h *Handler) handleErr(err error, scope int) error {
 
 	if err == nil {
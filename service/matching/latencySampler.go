@@ -0,0 +1,75 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package matching
+
+import (
+	"math"
+	"sort"
+	"sync"
+	"time"
+)
+
+// latencySampleCount is the number of most recent schedule to start latencies kept per task list
+const latencySampleCount = 1000
+
+// latencySampler keeps the most recent latencies observed on a task list and computes their percentiles
+type latencySampler struct {
+	sync.Mutex
+	samples []time.Duration
+	next    int
+}
+
+func newLatencySampler() *latencySampler {
+	return &latencySampler{samples: make([]time.Duration, 0, latencySampleCount)}
+}
+
+func (l *latencySampler) record(latency time.Duration) {
+	l.Lock()
+	defer l.Unlock()
+	if len(l.samples) < latencySampleCount {
+		l.samples = append(l.samples, latency)
+		return
+	}
+	l.samples[l.next] = latency
+	l.next = (l.next + 1) % latencySampleCount
+}
+
+// percentile returns the latency below which the given percentage of the samples fall,
+// using the nearest rank method. Returns zero when nothing was sampled yet.
+func (l *latencySampler) percentile(percent float64) time.Duration {
+	l.Lock()
+	sorted := make([]time.Duration, len(l.samples))
+	copy(sorted, l.samples)
+	l.Unlock()
+
+	if len(sorted) == 0 {
+		return 0
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	rank := int(math.Ceil(percent/100*float64(len(sorted)))) - 1
+	if rank < 0 {
+		rank = 0
+	}
+	if rank >= len(sorted) {
+		rank = len(sorted) - 1
+	}
+	return sorted[rank]
+}
@@ -0,0 +1,47 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package matching
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLatencySampler(t *testing.T) {
+	sampler := newLatencySampler()
+	require.Equal(t, time.Duration(0), sampler.percentile(99))
+
+	for i := 100; i > 0; i-- {
+		sampler.record(time.Duration(i) * time.Millisecond)
+	}
+	require.Equal(t, 95*time.Millisecond, sampler.percentile(95))
+	require.Equal(t, 99*time.Millisecond, sampler.percentile(99))
+	require.Equal(t, time.Millisecond, sampler.percentile(0))
+
+	// only the most recent samples are kept
+	for i := 0; i < latencySampleCount; i++ {
+		sampler.record(time.Second)
+	}
+	require.Equal(t, time.Second, sampler.percentile(95))
+	require.Equal(t, time.Second, sampler.percentile(0))
+}
@@ -26,6 +26,7 @@ import (
 	"errors"
 	"fmt"
 	"math"
+	"strings"
 	"sync"
 	"time"
 
@@ -96,12 +97,13 @@ func NewEngine(taskManager persistence.TaskManager,
 	logger log.Logger,
 	metricsClient metrics.Client,
 	domainCache cache.DomainCache,
+	tokenSerializer common.TaskTokenSerializer,
 ) Engine {
 
 	return &matchingEngineImpl{
 		taskManager:     taskManager,
 		historyService:  historyService,
-		tokenSerializer: common.NewJSONTaskTokenSerializer(),
+		tokenSerializer: tokenSerializer,
 		taskLists:       make(map[taskListID]taskListManager),
 		logger:          logger.WithTags(tag.ComponentMatchingEngine),
 		metricsClient:   metricsClient,
@@ -213,6 +215,17 @@ func (e *matchingEngineImpl) AddDecisionTask(ctx context.Context, addRequest *m.
 		return false, err
 	}
 
+	versionSets, err := e.getVersionSets(taskList, taskListKind)
+	if err != nil {
+		return false, err
+	}
+	if buildID, ok := getDispatchBuildID(versionSets, addRequest.GetWorkerBuildId()); ok {
+		taskList, err = newVersionedTaskListID(taskList, buildID)
+		if err != nil {
+			return false, err
+		}
+	}
+
 	tlMgr, err := e.getTaskListManager(taskList, taskListKind)
 	if err != nil {
 		return false, err
@@ -294,6 +307,16 @@ pollLoop:
 			return nil, err
 		}
 		taskListKind := common.TaskListKindPtr(request.TaskList.GetKind())
+		versionSets, err := e.getVersionSets(taskList, taskListKind)
+		if err != nil {
+			return nil, err
+		}
+		if containsBuildID(versionSets, request.GetBinaryChecksum()) {
+			taskList, err = newVersionedTaskListID(taskList, request.GetBinaryChecksum())
+			if err != nil {
+				return nil, err
+			}
+		}
 		task, err := e.getTask(pollerCtx, taskList, nil, taskListKind)
 		if err != nil {
 			// TODO: Is empty poll the best reply for errPumpClosed?
@@ -354,12 +377,16 @@ pollLoop:
 				e.logger.Debug(fmt.Sprintf("Duplicated decision task taskList=%v, taskID=%v",
 					taskListName, task.info.TaskID))
 				task.finish(nil)
+			case *h.WorkflowExecutionPausedError:
+				// the decision task is dispatched again once the workflow execution is resumed
+				task.finish(nil)
 			default:
 				task.finish(err)
 			}
 
 			continue pollLoop
 		}
+		task.journeys.record(common.TaskJourneyStageStarted, task.info, task.info.TaskID)
 		task.finish(nil)
 		return e.createPollForDecisionTaskResponse(task, resp), nil
 	}
@@ -404,7 +431,8 @@ pollLoop:
 			}
 			return nil, err
 		}
-		resp, err := e.recordActivityTaskStarted(ctx, request, task)
+		requestID := uuid.New()
+		resp, err := e.recordActivityTaskStarted(ctx, request, task, requestID)
 		if err != nil {
 			switch err.(type) {
 			case *workflow.EntityNotExistsError, *h.EventAlreadyStartedError:
@@ -417,8 +445,9 @@ pollLoop:
 
 			continue pollLoop
 		}
+		task.journeys.record(common.TaskJourneyStageStarted, task.info, task.info.TaskID)
 		task.finish(nil)
-		return e.createPollForActivityTaskResponse(task, resp), nil
+		return e.createPollForActivityTaskResponse(task, resp, requestID), nil
 	}
 }
 
@@ -433,6 +462,19 @@ func (e *matchingEngineImpl) QueryWorkflow(ctx context.Context, queryRequest *m.
 		return nil, err
 	}
 
+	// queries do not know which build last completed a decision of the execution,
+	// so they are dispatched to the default build of a versioned task list
+	versionSets, err := e.getVersionSets(taskList, taskListKind)
+	if err != nil {
+		return nil, err
+	}
+	if buildID, ok := getDispatchBuildID(versionSets, ""); ok {
+		taskList, err = newVersionedTaskListID(taskList, buildID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	var lastErr error
 query_loop:
 	for i := 0; i < maxQueryWaitCount; i++ {
@@ -578,6 +620,108 @@ func (e *matchingEngineImpl) DescribeTaskList(ctx context.Context, request *m.De
 	return tlMgr.DescribeTaskList(request.DescRequest.GetIncludeTaskListStatus()), nil
 }
 
+// GetTaskListScalingHints returns the backlog and dispatch rate of a task list partition
+func (e *matchingEngineImpl) GetTaskListScalingHints(
+	ctx context.Context,
+	request *m.GetTaskListScalingHintsRequest,
+) (*workflow.GetTaskListScalingHintsResponse, error) {
+	domainID := request.GetDomainUUID()
+	taskListType := persistence.TaskListTypeDecision
+	if request.HintsRequest.GetTaskListType() == workflow.TaskListTypeActivity {
+		taskListType = persistence.TaskListTypeActivity
+	}
+	taskList, err := newTaskListID(domainID, request.HintsRequest.TaskList.GetName(), taskListType)
+	if err != nil {
+		return nil, err
+	}
+	taskListKind := common.TaskListKindPtr(request.HintsRequest.TaskList.GetKind())
+	tlMgr, err := e.getTaskListManager(taskList, taskListKind)
+	if err != nil {
+		return nil, err
+	}
+
+	return &workflow.GetTaskListScalingHintsResponse{ScalingHints: tlMgr.GetScalingHints()}, nil
+}
+
+// DescribeTaskJourney returns the stages recently reached by a task on the partitions and versioned
+// task lists of a task list loaded by the host
+func (e *matchingEngineImpl) DescribeTaskJourney(
+	ctx context.Context,
+	request *m.DescribeTaskJourneyRequest,
+) (*workflow.DescribeTaskJourneyResponse, error) {
+	journeyRequest := request.JourneyRequest
+	taskListType := persistence.TaskListTypeDecision
+	if journeyRequest.GetTaskListType() == workflow.TaskListTypeActivity {
+		taskListType = persistence.TaskListTypeActivity
+	}
+	taskListName := journeyRequest.TaskList.GetName()
+
+	var tlMgrs []taskListManager
+	e.taskListsLock.RLock()
+	for id, tlMgr := range e.taskLists {
+		if id.domainID != request.GetDomainUUID() || id.taskType != taskListType {
+			continue
+		}
+		if id.baseName == taskListName ||
+			(strings.HasPrefix(id.name, taskListBuildPrefix) && strings.HasSuffix(id.name, "/"+taskListName)) {
+			tlMgrs = append(tlMgrs, tlMgr)
+		}
+	}
+	e.taskListsLock.RUnlock()
+
+	events := []*workflow.TaskJourneyEvent{}
+	for _, tlMgr := range tlMgrs {
+		events = append(events, tlMgr.GetTaskJourney(journeyRequest)...)
+	}
+	sortTaskJourney(events)
+	return &workflow.DescribeTaskJourneyResponse{Events: events}, nil
+}
+
+// UpdateTaskListVersionSets replaces the worker build ID version sets of a decision task list
+func (e *matchingEngineImpl) UpdateTaskListVersionSets(ctx context.Context, request *m.UpdateTaskListVersionSetsRequest) error {
+	versionSets := versionSetsFromThrift(request.VersionSets)
+	if err := validateVersionSets(versionSets); err != nil {
+		return err
+	}
+
+	taskListName := request.TaskList.GetName()
+	if strings.HasPrefix(taskListName, taskListBuildPrefix) {
+		return &workflow.BadRequestError{Message: fmt.Sprintf("Task list %v is already versioned.", taskListName)}
+	}
+	taskList, err := newTaskListID(request.GetDomainUUID(), taskListName, persistence.TaskListTypeDecision)
+	if err != nil {
+		return err
+	}
+	if !taskList.IsRoot() {
+		return &workflow.BadRequestError{Message: fmt.Sprintf("Task list %v is a partition.", taskListName)}
+	}
+
+	tlMgr, err := e.getTaskListManager(taskList, common.TaskListKindPtr(workflow.TaskListKindNormal))
+	if err != nil {
+		return err
+	}
+	return tlMgr.UpdateVersionSets(versionSets)
+}
+
+// getVersionSets returns the worker build ID version sets of a decision task list. Only
+// the root partition of a normal task list can be versioned.
+func (e *matchingEngineImpl) getVersionSets(taskList *taskListID, taskListKind *workflow.TaskListKind) ([][]string, error) {
+	if !taskList.IsRoot() || (taskListKind != nil && *taskListKind == workflow.TaskListKindSticky) {
+		return nil, nil
+	}
+	tlMgr, err := e.getTaskListManager(taskList, taskListKind)
+	if err != nil {
+		return nil, err
+	}
+	return tlMgr.GetVersionSets(), nil
+}
+
+// newVersionedTaskListID returns the id of the task list dispatching the decision tasks of
+// the given task list to the pollers of a single worker build
+func newVersionedTaskListID(taskList *taskListID, buildID string) (*taskListID, error) {
+	return newTaskListID(taskList.domainID, versionedTaskListName(taskList.name, buildID), taskList.taskType)
+}
+
 // Loads a task from persistence and wraps it in a task context
 func (e *matchingEngineImpl) getTask(
 	ctx context.Context, taskList *taskListID, maxDispatchPerSecond *float64, taskListKind *workflow.TaskListKind,
@@ -623,7 +767,7 @@ func (e *matchingEngineImpl) createPollForDecisionTaskResponse(
 			WorkflowID:      task.info.WorkflowID,
 			RunID:           task.info.RunID,
 			ScheduleID:      historyResponse.GetScheduledEventId(),
-			ScheduleAttempt: historyResponse.GetAttempt(),
+			ScheduleAttempt: historyResponse.GetScheduleAttempt(),
 		}
 		token, _ = e.tokenSerializer.Serialize(taskoken)
 		if task.syncResponseCh == nil {
@@ -637,6 +781,7 @@ func (e *matchingEngineImpl) createPollForDecisionTaskResponse(
 		response.Query = task.queryInfo.queryRequest.QueryRequest.Query
 	}
 	response.BacklogCountHint = common.Int64Ptr(task.backlogCountHint)
+	response.ScalingHints = task.scalingHints
 	return response
 }
 
@@ -644,6 +789,7 @@ func (e *matchingEngineImpl) createPollForDecisionTaskResponse(
 func (e *matchingEngineImpl) createPollForActivityTaskResponse(
 	task *internalTask,
 	historyResponse *h.RecordActivityTaskStartedResponse,
+	requestID string,
 ) *workflow.PollForActivityTaskResponse {
 
 	scheduledEvent := historyResponse.ScheduledEvent
@@ -678,6 +824,7 @@ func (e *matchingEngineImpl) createPollForActivityTaskResponse(
 		RunID:           task.info.RunID,
 		ScheduleID:      task.info.ScheduleID,
 		ScheduleAttempt: historyResponse.GetAttempt(),
+		RequestID:       requestID,
 	}
 
 	response.TaskToken, _ = e.tokenSerializer.Serialize(token)
@@ -709,7 +856,7 @@ func (e *matchingEngineImpl) recordDecisionTaskStarted(
 	}
 	err := backoff.Retry(op, historyServiceOperationRetryPolicy, func(err error) bool {
 		switch err.(type) {
-		case *workflow.EntityNotExistsError, *h.EventAlreadyStartedError:
+		case *workflow.EntityNotExistsError, *h.EventAlreadyStartedError, *h.WorkflowExecutionPausedError:
 			return false
 		}
 		return true
@@ -721,13 +868,14 @@ func (e *matchingEngineImpl) recordActivityTaskStarted(
 	ctx context.Context,
 	pollReq *workflow.PollForActivityTaskRequest,
 	task *internalTask,
+	requestID string,
 ) (*h.RecordActivityTaskStartedResponse, error) {
 	request := &h.RecordActivityTaskStartedRequest{
 		DomainUUID:        &task.info.DomainID,
 		WorkflowExecution: &task.workflowExecution,
 		ScheduleId:        &task.info.ScheduleID,
 		TaskId:            &task.info.TaskID,
-		RequestId:         common.StringPtr(uuid.New()),
+		RequestId:         common.StringPtr(requestID),
 		PollRequest:       pollReq,
 	}
 	var resp *h.RecordActivityTaskStartedResponse
@@ -39,5 +39,8 @@ type (
 		RespondQueryTaskCompleted(ctx context.Context, request *m.RespondQueryTaskCompletedRequest) error
 		CancelOutstandingPoll(ctx context.Context, request *m.CancelOutstandingPollRequest) error
 		DescribeTaskList(ctx context.Context, request *m.DescribeTaskListRequest) (*workflow.DescribeTaskListResponse, error)
+		GetTaskListScalingHints(ctx context.Context, request *m.GetTaskListScalingHintsRequest) (*workflow.GetTaskListScalingHintsResponse, error)
+		UpdateTaskListVersionSets(ctx context.Context, request *m.UpdateTaskListVersionSetsRequest) error
+		DescribeTaskJourney(ctx context.Context, request *m.DescribeTaskJourneyRequest) (*workflow.DescribeTaskJourneyResponse, error)
 	}
 )
@@ -288,10 +288,14 @@ func (s *matchingEngineSuite) PollForDecisionTasksResultTest() {
 			Name: &tl,
 			Kind: common.TaskListKindPtr(workflow.TaskListKindNormal),
 		}),
+		ScalingHints: resp.ScalingHints,
 	}
 
 	s.Nil(err)
 	s.Equal(expectedResp, resp)
+	s.Equal(int64(1), resp.ScalingHints.GetBacklogCountHint())
+	s.Equal(int32(1), resp.ScalingHints.GetPollerCount())
+	s.True(resp.ScalingHints.GetDispatchRatePerSecond() > 0)
 }
 
 func (s *matchingEngineSuite) PollForTasksEmptyResultTest(callContext context.Context, taskType int) {
@@ -411,6 +415,50 @@ func (s *matchingEngineSuite) AddTasksTest(taskType int) {
 	s.EqualValues(taskCount, s.taskManager.getTaskCount(newTestTaskListID(domainID, tl, taskType)))
 }
 
+func (s *matchingEngineSuite) TestAddDecisionTasksVersionSets() {
+	domainID := "domainId"
+	tl := "makeToast"
+	taskList := &workflow.TaskList{Name: &tl}
+
+	err := s.matchingEngine.UpdateTaskListVersionSets(context.Background(), &matching.UpdateTaskListVersionSetsRequest{
+		DomainUUID: common.StringPtr(domainID),
+		TaskList:   taskList,
+		VersionSets: []*workflow.TaskListVersionSet{
+			{BuildIds: []string{"v1", "v2"}},
+			{BuildIds: []string{"v3"}},
+		},
+	})
+	s.NoError(err)
+
+	runID := "run1"
+	workflowID := "workflow1"
+	execution := workflow.WorkflowExecution{RunId: &runID, WorkflowId: &workflowID}
+	for i, workerBuildID := range []string{"", "v1", "v2", "v3", "unknown"} {
+		addRequest := matching.AddDecisionTaskRequest{
+			DomainUUID:                    common.StringPtr(domainID),
+			Execution:                     &execution,
+			ScheduleId:                    common.Int64Ptr(int64(i)),
+			TaskList:                      taskList,
+			ScheduleToStartTimeoutSeconds: common.Int32Ptr(1),
+			WorkerBuildId:                 common.StringPtr(workerBuildID),
+		}
+		_, err = s.matchingEngine.AddDecisionTask(context.Background(), &addRequest)
+		s.NoError(err)
+	}
+
+	taskType := persistence.TaskListTypeDecision
+	s.EqualValues(1, s.taskManager.getTaskCount(newTestTaskListID(domainID, tl, taskType)))
+	s.EqualValues(2, s.taskManager.getTaskCount(newTestTaskListID(domainID, versionedTaskListName(tl, "v2"), taskType)))
+	s.EqualValues(2, s.taskManager.getTaskCount(newTestTaskListID(domainID, versionedTaskListName(tl, "v3"), taskType)))
+	s.EqualValues(0, s.taskManager.getTaskCount(newTestTaskListID(domainID, versionedTaskListName(tl, "v1"), taskType)))
+
+	// version sets are reloaded from persistence with the task list
+	s.matchingEngine.unloadTaskList(newTestTaskListID(domainID, tl, taskType))
+	tlMgr, err := s.matchingEngine.getTaskListManager(newTestTaskListID(domainID, tl, taskType), nil)
+	s.NoError(err)
+	s.Equal([][]string{{"v1", "v2"}, {"v3"}}, tlMgr.GetVersionSets())
+}
+
 func (s *matchingEngineSuite) TestTaskWriterShutdown() {
 	s.matchingEngine.config.RangeSize = 300 // override to low number for the test
 
@@ -498,10 +546,12 @@ func (s *matchingEngineSuite) TestAddThenConsumeActivities() {
 
 	identity := "nobody"
 
+	var lastRequestID string
 	// History service is using mock
 	s.historyClient.On("RecordActivityTaskStarted", mock.Anything,
 		mock.AnythingOfType("*history.RecordActivityTaskStartedRequest")).Return(
 		func(ctx context.Context, taskRequest *gohistory.RecordActivityTaskStartedRequest) *gohistory.RecordActivityTaskStartedResponse {
+			lastRequestID = taskRequest.GetRequestId()
 			s.logger.Debug("Mock Received RecordActivityTaskStartedRequest")
 			resp := &gohistory.RecordActivityTaskStartedResponse{
 				ScheduledEvent: newActivityTaskScheduledEvent(*taskRequest.ScheduleId, 0,
@@ -550,6 +600,7 @@ func (s *matchingEngineSuite) TestAddThenConsumeActivities() {
 			WorkflowID: workflowID,
 			RunID:      runID,
 			ScheduleID: scheduleID,
+			RequestID:  lastRequestID,
 		}
 
 		taskToken, _ := s.matchingEngine.tokenSerializer.Serialize(token)
@@ -610,10 +661,12 @@ func (s *matchingEngineSuite) TestSyncMatchActivities() {
 
 	identity := "nobody"
 
+	var lastRequestID string
 	// History service is using mock
 	s.historyClient.On("RecordActivityTaskStarted", mock.Anything,
 		mock.AnythingOfType("*history.RecordActivityTaskStartedRequest")).Return(
 		func(ctx context.Context, taskRequest *gohistory.RecordActivityTaskStartedRequest) *gohistory.RecordActivityTaskStartedResponse {
+			lastRequestID = taskRequest.GetRequestId()
 			s.logger.Debug("Mock Received RecordActivityTaskStartedRequest")
 			return &gohistory.RecordActivityTaskStartedResponse{
 				ScheduledEvent: newActivityTaskScheduledEvent(*taskRequest.ScheduleId, 0,
@@ -698,6 +751,7 @@ func (s *matchingEngineSuite) TestSyncMatchActivities() {
 			WorkflowID: workflowID,
 			RunID:      runID,
 			ScheduleID: scheduleID,
+			RequestID:  lastRequestID,
 		}
 
 		taskToken, _ := s.matchingEngine.tokenSerializer.Serialize(token)
@@ -831,10 +885,12 @@ func (s *matchingEngineSuite) concurrentPublishConsumeActivities(
 
 	identity := "nobody"
 
+	var requestIDs sync.Map
 	// History service is using mock
 	s.historyClient.On("RecordActivityTaskStarted", mock.Anything,
 		mock.AnythingOfType("*history.RecordActivityTaskStartedRequest")).Return(
 		func(ctx context.Context, taskRequest *gohistory.RecordActivityTaskStartedRequest) *gohistory.RecordActivityTaskStartedResponse {
+			requestIDs.Store(taskRequest.GetRequestId(), struct{}{})
 			s.logger.Debug("Mock Received RecordActivityTaskStartedRequest")
 			return &gohistory.RecordActivityTaskStartedResponse{
 				ScheduledEvent: newActivityTaskScheduledEvent(*taskRequest.ScheduleId, 0,
@@ -884,6 +940,9 @@ func (s *matchingEngineSuite) concurrentPublishConsumeActivities(
 				}
 				resultToken, err := s.matchingEngine.tokenSerializer.Deserialize(result.TaskToken)
 				s.NoError(err)
+				_, ok := requestIDs.Load(resultToken.RequestID)
+				s.True(ok)
+				token.RequestID = resultToken.RequestID
 
 				//taskToken, _ := s.matchingEngine.tokenSerializer.Serialize(token)
 				//s.EqualValues(taskToken, result.TaskToken, fmt.Sprintf("%v!=%v", string(taskToken)))
@@ -1128,10 +1187,12 @@ func (s *matchingEngineSuite) TestMultipleEnginesActivitiesRangeStealing() {
 
 	startedTasks := make(map[int64]bool)
 
+	var requestIDs sync.Map
 	// History service is using mock
 	s.historyClient.On("RecordActivityTaskStarted", mock.Anything,
 		mock.AnythingOfType("*history.RecordActivityTaskStartedRequest")).Return(
 		func(ctx context.Context, taskRequest *gohistory.RecordActivityTaskStartedRequest) *gohistory.RecordActivityTaskStartedResponse {
+			requestIDs.Store(taskRequest.GetRequestId(), struct{}{})
 			if _, ok := startedTasks[*taskRequest.TaskId]; ok {
 				return nil
 			}
@@ -1191,6 +1252,9 @@ func (s *matchingEngineSuite) TestMultipleEnginesActivitiesRangeStealing() {
 				if err != nil {
 					panic(err)
 				}
+				_, ok := requestIDs.Load(resultToken.RequestID)
+				s.True(ok)
+				token.RequestID = resultToken.RequestID
 				//taskToken, _ := s.matchingEngine.tokenSerializer.Serialize(token)
 				//s.EqualValues(taskToken, result.TaskToken, fmt.Sprintf("%v!=%v", string(taskToken)))
 				s.EqualValues(token, resultToken, fmt.Sprintf("%v!=%v", token, resultToken))
@@ -1535,6 +1599,24 @@ func (s *matchingEngineSuite) TestTaskListManagerGetTaskBatch_ReadBatchDone() {
 	s.NoError(err)
 }
 
+func (s *matchingEngineSuite) TestTaskListManagerGetTaskBatch_AbandonedOnStop() {
+	domainID := "domainId"
+	tl := "makeToast"
+	tlID := newTestTaskListID(domainID, tl, persistence.TaskListTypeActivity)
+	tlNormal := workflow.TaskListKindNormal
+
+	tlMgr0, err := newTaskListManager(s.matchingEngine, tlID, &tlNormal, defaultTestConfig())
+	s.NoError(err)
+
+	tlMgr, ok := tlMgr0.(*taskListManagerImpl)
+	s.True(ok)
+
+	atomic.StoreInt64(&tlMgr.taskWriter.maxReadLevel, 10)
+	tlMgr.taskReader.Stop()
+	_, _, _, err = tlMgr.taskReader.getTaskBatch()
+	s.Equal(context.Canceled, err)
+}
+
 func (s *matchingEngineSuite) TestTaskExpiryAndCompletion() {
 	runID := uuid.New()
 	workflowID := uuid.New()
@@ -1714,6 +1796,10 @@ func (m *testTaskManager) Close() {
 	return
 }
 
+func (m *testTaskManager) Ping() error {
+	return nil
+}
+
 func (m *testTaskManager) getTaskListManager(id *taskListID) *testTaskListManager {
 	m.Lock()
 	defer m.Unlock()
@@ -1731,6 +1817,7 @@ type testTaskListManager struct {
 	rangeID         int64
 	ackLevel        int64
 	createTaskCount int
+	versionSets     [][]string
 	tasks           *treemap.Map
 }
 
@@ -1769,12 +1856,13 @@ func (m *testTaskManager) LeaseTaskList(request *persistence.LeaseTaskListReques
 
 	return &persistence.LeaseTaskListResponse{
 		TaskListInfo: &persistence.TaskListInfo{
-			AckLevel: tlm.ackLevel,
-			DomainID: request.DomainID,
-			Name:     request.TaskList,
-			TaskType: request.TaskType,
-			RangeID:  tlm.rangeID,
-			Kind:     request.TaskListKind,
+			AckLevel:    tlm.ackLevel,
+			DomainID:    request.DomainID,
+			Name:        request.TaskList,
+			TaskType:    request.TaskType,
+			RangeID:     tlm.rangeID,
+			Kind:        request.TaskListKind,
+			VersionSets: tlm.versionSets,
 		},
 	}, nil
 }
@@ -1794,6 +1882,7 @@ func (m *testTaskManager) UpdateTaskList(request *persistence.UpdateTaskListRequ
 		}
 	}
 	tlm.ackLevel = tli.AckLevel
+	tlm.versionSets = tli.VersionSets
 	return &persistence.UpdateTaskListResponse{}, nil
 }
 
@@ -1897,6 +1986,9 @@ func (m *testTaskManager) CreateTasks(request *persistence.CreateTasksRequest) (
 // GetTasks provides a mock function with given fields: request
 func (m *testTaskManager) GetTasks(request *persistence.GetTasksRequest) (*persistence.GetTasksResponse, error) {
 	m.logger.Debug(fmt.Sprintf("testTaskManager.GetTasks readLevel=%v, maxReadLevel=%v", request.ReadLevel, request.MaxReadLevel))
+	if request.Context != nil && request.Context.Err() != nil {
+		return nil, request.Context.Err()
+	}
 
 	tlm := m.getTaskListManager(newTestTaskListID(request.DomainID, request.TaskList, request.TaskType))
 	tlm.Lock()
@@ -68,6 +68,10 @@ func (pollers *pollerHistory) updatePollerInfo(id pollerIdentity, ratePerSecond
 	pollers.history.Put(id, &pollerInfo{ratePerSecond: rps})
 }
 
+func (pollers *pollerHistory) getPollerCount() int {
+	return pollers.history.Size()
+}
+
 func (pollers *pollerHistory) getAllPollerInfo() []*shared.PollerInfo {
 	var result []*shared.PollerInfo
 
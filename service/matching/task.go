@@ -39,6 +39,8 @@ type (
 		workflowExecution s.WorkflowExecution
 		queryInfo         *queryTaskInfo
 		backlogCountHint  int64
+		scalingHints      *s.TaskListScalingHints
+		journeys          *taskJourneyRecorder // records the stages of the task, set once matched
 		domainName        string
 		completionFunc    func(*internalTask, error)
 	}
@@ -0,0 +1,139 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package matching
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	s "github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/cache"
+	"github.com/uber/cadence/common/log"
+	"github.com/uber/cadence/common/log/tag"
+	"github.com/uber/cadence/common/persistence"
+)
+
+// taskJourneyCacheSize is the number of most recent tasks whose journey is kept per task list
+const taskJourneyCacheSize = 1000
+
+type (
+	taskJourneyKey struct {
+		workflowID string
+		runID      string
+		scheduleID int64
+	}
+
+	taskJourney struct {
+		sync.Mutex
+		events []*s.TaskJourneyEvent
+	}
+
+	// taskJourneyRecorder logs the stages reached by the tasks of a task list and keeps
+	// the journeys of the most recent tasks in memory, to be returned by DescribeTaskJourney
+	taskJourneyRecorder struct {
+		taskListName string
+		logger       log.Logger
+		journeys     cache.Cache
+	}
+)
+
+func newTaskJourneyRecorder(taskListName string, logger log.Logger) *taskJourneyRecorder {
+	return &taskJourneyRecorder{
+		taskListName: taskListName,
+		logger:       logger,
+		journeys:     cache.NewLRU(taskJourneyCacheSize),
+	}
+}
+
+// record logs that a task reached a stage of its journey. taskID is zero when the task is not persisted.
+func (r *taskJourneyRecorder) record(stage string, info *persistence.TaskInfo, taskID int64) {
+	r.logger.Debug("Task journey",
+		tag.WorkflowTaskJourneyStage(stage),
+		tag.WorkflowDomainID(info.DomainID),
+		tag.WorkflowID(info.WorkflowID),
+		tag.WorkflowRunID(info.RunID),
+		tag.WorkflowScheduleID(info.ScheduleID),
+		tag.TaskID(taskID))
+
+	event := &s.TaskJourneyEvent{
+		Stage:     common.StringPtr(stage),
+		Timestamp: common.Int64Ptr(time.Now().UnixNano()),
+		TaskList:  common.StringPtr(r.taskListName),
+	}
+	if taskID != 0 {
+		event.TaskId = common.Int64Ptr(taskID)
+	}
+	key := taskJourneyKey{workflowID: info.WorkflowID, runID: info.RunID, scheduleID: info.ScheduleID}
+	value, err := r.journeys.PutIfNotExist(key, &taskJourney{})
+	if err != nil {
+		return
+	}
+	journey := value.(*taskJourney)
+	journey.Lock()
+	journey.events = append(journey.events, event)
+	journey.Unlock()
+}
+
+// getJourney returns the recorded stages of the task created for a schedule ID of a workflow execution
+func (r *taskJourneyRecorder) getJourney(workflowID string, runID string, scheduleID int64) []*s.TaskJourneyEvent {
+	value := r.journeys.Get(taskJourneyKey{workflowID: workflowID, runID: runID, scheduleID: scheduleID})
+	if value == nil {
+		return nil
+	}
+	return value.(*taskJourney).copyEvents()
+}
+
+// getJourneyByTaskID returns the recorded stages of the task persisted with the given task ID
+func (r *taskJourneyRecorder) getJourneyByTaskID(taskID int64) []*s.TaskJourneyEvent {
+	var journeys []*taskJourney
+	it := r.journeys.Iterator()
+	for it.HasNext() {
+		journeys = append(journeys, it.Next().Value().(*taskJourney))
+	}
+	it.Close()
+
+	for _, journey := range journeys {
+		events := journey.copyEvents()
+		for _, event := range events {
+			if event.GetTaskId() == taskID {
+				return events
+			}
+		}
+	}
+	return nil
+}
+
+func (j *taskJourney) copyEvents() []*s.TaskJourneyEvent {
+	j.Lock()
+	defer j.Unlock()
+	events := make([]*s.TaskJourneyEvent, len(j.events))
+	copy(events, j.events)
+	return events
+}
+
+// sortTaskJourney orders the stages of a task journey recorded on several task list partitions by time
+func sortTaskJourney(events []*s.TaskJourneyEvent) {
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].GetTimestamp() < events[j].GetTimestamp()
+	})
+}
@@ -0,0 +1,71 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package matching
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	s "github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/log/loggerimpl"
+	"github.com/uber/cadence/common/persistence"
+)
+
+func TestTaskJourneyRecorder(t *testing.T) {
+	logger, err := loggerimpl.NewDevelopment()
+	require.NoError(t, err)
+	recorder := newTaskJourneyRecorder("tl", logger)
+
+	info := &persistence.TaskInfo{DomainID: "domain", WorkflowID: "wid", RunID: "rid", ScheduleID: 5}
+	recorder.record(common.TaskJourneyStageCreated, info, 0)
+	recorder.record(common.TaskJourneyStagePersisted, info, 42)
+	recorder.record(common.TaskJourneyStageMatched, info, 42)
+	other := &persistence.TaskInfo{DomainID: "domain", WorkflowID: "wid", RunID: "rid", ScheduleID: 7}
+	recorder.record(common.TaskJourneyStageCreated, other, 0)
+
+	events := recorder.getJourney("wid", "rid", 5)
+	require.Len(t, events, 3)
+	var stages []string
+	for _, event := range events {
+		require.Equal(t, "tl", event.GetTaskList())
+		stages = append(stages, event.GetStage())
+	}
+	require.Equal(t, []string{common.TaskJourneyStageCreated, common.TaskJourneyStagePersisted, common.TaskJourneyStageMatched}, stages)
+	require.Nil(t, events[0].TaskId)
+	require.Equal(t, int64(42), events[1].GetTaskId())
+
+	require.Equal(t, events, recorder.getJourneyByTaskID(42))
+	require.Empty(t, recorder.getJourneyByTaskID(43))
+	require.Empty(t, recorder.getJourney("wid", "rid", 6))
+}
+
+func TestSortTaskJourney(t *testing.T) {
+	events := []*s.TaskJourneyEvent{
+		{Stage: common.StringPtr(common.TaskJourneyStageMatched), Timestamp: common.Int64Ptr(3)},
+		{Stage: common.StringPtr(common.TaskJourneyStageCreated), Timestamp: common.Int64Ptr(1)},
+		{Stage: common.StringPtr(common.TaskJourneyStagePersisted), Timestamp: common.Int64Ptr(2)},
+	}
+	sortTaskJourney(events)
+	for i, stage := range []string{common.TaskJourneyStageCreated, common.TaskJourneyStagePersisted, common.TaskJourneyStageMatched} {
+		require.Equal(t, stage, events[i].GetStage())
+	}
+}
@@ -32,6 +32,7 @@ import (
 	"github.com/uber/cadence/common"
 	"github.com/uber/cadence/common/backoff"
 	"github.com/uber/cadence/common/cache"
+	"github.com/uber/cadence/common/clock"
 	"github.com/uber/cadence/common/log"
 	"github.com/uber/cadence/common/log/tag"
 	"github.com/uber/cadence/common/metrics"
@@ -72,6 +73,15 @@ type (
 		GetAllPollerInfo() []*s.PollerInfo
 		// DescribeTaskList returns information about the target tasklist
 		DescribeTaskList(includeTaskListStatus bool) *s.DescribeTaskListResponse
+		// GetVersionSets returns the worker build ID version sets of the task list
+		GetVersionSets() [][]string
+		// UpdateVersionSets persists new worker build ID version sets of the task list
+		UpdateVersionSets(versionSets [][]string) error
+		// GetScalingHints returns the backlog and dispatch rate of the task list, for
+		// autoscaling its pollers
+		GetScalingHints() *s.TaskListScalingHints
+		// GetTaskJourney returns the recorded stages of the task identified by the request
+		GetTaskJourney(request *s.DescribeTaskJourneyRequest) []*s.TaskJourneyEvent
 		String() string
 	}
 
@@ -94,6 +104,14 @@ type (
 		domainScopeValue atomic.Value // domain tagged metric scope
 		// pollerHistory stores poller which poll from this tasklist in last few minutes
 		pollerHistory *pollerHistory
+		dispatchRate  *dispatchRateTracker
+		// scheduleToStartLatency samples the time tasks spent in the task list before dispatch
+		scheduleToStartLatency *latencySampler
+		// journeys records the stages reached by the tasks of the task list
+		journeys *taskJourneyRecorder
+		// backlogHeadCreatedTime is the creation time in unix nanos of the backlog task
+		// waiting for a poller, zero when no backlog task is waiting
+		backlogHeadCreatedTime int64
 		// outstandingPollsMap is needed to keep track of all outstanding pollers for a
 		// particular tasklist.  PollerID generated by frontend is used as the key and
 		// CancelFunc is the value.  This is used to cancel the context to unblock any
@@ -140,17 +158,20 @@ func newTaskListManager(
 		taskListID:    taskList,
 		logger: e.logger.WithTags(tag.WorkflowTaskListName(taskList.name),
 			tag.WorkflowTaskListType(taskList.taskType)),
-		db:                  db,
-		taskAckManager:      newAckManager(e.logger),
-		taskGC:              newTaskGC(db, taskListConfig),
-		config:              taskListConfig,
-		pollerHistory:       newPollerHistory(),
-		outstandingPollsMap: make(map[string]context.CancelFunc),
-		taskListKind:        int(*taskListKind),
+		db:                     db,
+		taskAckManager:         newAckManager(e.logger),
+		taskGC:                 newTaskGC(db, taskListConfig),
+		config:                 taskListConfig,
+		pollerHistory:          newPollerHistory(),
+		dispatchRate:           newDispatchRateTracker(clock.NewRealTimeSource()),
+		scheduleToStartLatency: newLatencySampler(),
+		outstandingPollsMap:    make(map[string]context.CancelFunc),
+		taskListKind:           int(*taskListKind),
 	}
 	tlMgr.domainNameValue.Store("")
 	tlMgr.domainScopeValue.Store(e.metricsClient.Scope(metrics.MatchingTaskListMgrScope, metrics.DomainUnknownTag()))
 	tlMgr.tryInitDomainNameAndScope()
+	tlMgr.journeys = newTaskJourneyRecorder(taskList.name, tlMgr.logger)
 	tlMgr.taskWriter = newTaskWriter(tlMgr)
 	tlMgr.taskReader = newTaskReader(tlMgr)
 	tlMgr.matcher = newTaskMatcher(taskListConfig, tlMgr.domainScope)
@@ -195,6 +216,7 @@ func (c *taskListManagerImpl) Stop() {
 // be written to database and later asynchronously matched with a poller
 func (c *taskListManagerImpl) AddTask(ctx context.Context, params addTaskParams) (bool, error) {
 	c.startWG.Wait()
+	c.journeys.record(common.TaskJourneyStageCreated, params.taskInfo, 0)
 	var syncMatch bool
 	_, err := c.executeWithRetry(func() (interface{}, error) {
 
@@ -256,8 +278,19 @@ func (c *taskListManagerImpl) GetTask(
 	if err != nil {
 		return nil, err
 	}
+	c.dispatchRate.recordDispatch()
+	if !task.isQuery() {
+		task.journeys = c.journeys
+		c.journeys.record(common.TaskJourneyStageMatched, task.info, task.info.TaskID)
+		latency := time.Since(task.info.CreatedTime)
+		c.scheduleToStartLatency.record(latency)
+		c.domainScope().Tagged(metrics.TaskListTag(c.taskListID.name)).RecordTimer(metrics.ScheduleToStartLatency, latency)
+	}
 	task.domainName = c.domainName()
 	task.backlogCountHint = c.taskAckManager.getBacklogCountHint()
+	if c.taskListID.taskType == persistence.TaskListTypeDecision {
+		task.scalingHints = c.GetScalingHints()
+	}
 	return task, nil
 }
 
@@ -326,7 +359,10 @@ func (c *taskListManagerImpl) CancelPoller(pollerID string) {
 // pollers which polled this tasklist in last few minutes and status of tasklist's ackManager
 // (readLevel, ackLevel, backlogCountHint and taskIDBlock).
 func (c *taskListManagerImpl) DescribeTaskList(includeTaskListStatus bool) *s.DescribeTaskListResponse {
-	response := &s.DescribeTaskListResponse{Pollers: c.GetAllPollerInfo()}
+	response := &s.DescribeTaskListResponse{
+		Pollers:     c.GetAllPollerInfo(),
+		VersionSets: versionSetsToThrift(c.db.VersionSets()),
+	}
 	if !includeTaskListStatus {
 		return response
 	}
@@ -341,11 +377,51 @@ func (c *taskListManagerImpl) DescribeTaskList(includeTaskListStatus bool) *s.De
 			StartID: common.Int64Ptr(taskIDBlock.start),
 			EndID:   common.Int64Ptr(taskIDBlock.end),
 		},
+		ScheduleToStartLatencyP95Millis: common.Int64Ptr(int64(c.scheduleToStartLatency.percentile(95) / time.Millisecond)),
+		ScheduleToStartLatencyP99Millis: common.Int64Ptr(int64(c.scheduleToStartLatency.percentile(99) / time.Millisecond)),
 	}
 
 	return response
 }
 
+// GetVersionSets returns the worker build ID version sets of the task list
+func (c *taskListManagerImpl) GetVersionSets() [][]string {
+	c.startWG.Wait()
+	return c.db.VersionSets()
+}
+
+// UpdateVersionSets persists new worker build ID version sets of the task list
+func (c *taskListManagerImpl) UpdateVersionSets(versionSets [][]string) error {
+	c.startWG.Wait()
+	_, err := c.executeWithRetry(func() (interface{}, error) {
+		return nil, c.db.UpdateVersionSets(versionSets)
+	})
+	return err
+}
+
+// GetTaskJourney returns the recorded stages of the task identified by the request, either by the
+// schedule ID of its workflow execution or by its task ID
+func (c *taskListManagerImpl) GetTaskJourney(request *s.DescribeTaskJourneyRequest) []*s.TaskJourneyEvent {
+	if request.Execution != nil {
+		return c.journeys.getJourney(request.Execution.GetWorkflowId(), request.Execution.GetRunId(), request.GetScheduleId())
+	}
+	return c.journeys.getJourneyByTaskID(request.GetTaskId())
+}
+
+// GetScalingHints returns the backlog and dispatch rate of the task list, for autoscaling its pollers
+func (c *taskListManagerImpl) GetScalingHints() *s.TaskListScalingHints {
+	var backlogAge time.Duration
+	if createdTime := atomic.LoadInt64(&c.backlogHeadCreatedTime); createdTime != 0 {
+		backlogAge = time.Since(time.Unix(0, createdTime))
+	}
+	return &s.TaskListScalingHints{
+		BacklogCountHint:      common.Int64Ptr(c.taskAckManager.getBacklogCountHint()),
+		BacklogAgeMillis:      common.Int64Ptr(int64(backlogAge / time.Millisecond)),
+		DispatchRatePerSecond: common.Float64Ptr(c.dispatchRate.ratePerSecond()),
+		PollerCount:           common.Int32Ptr(int32(c.pollerHistory.getPollerCount())),
+	}
+}
+
 func (c *taskListManagerImpl) String() string {
 	buf := new(bytes.Buffer)
 	if c.taskListID.taskType == persistence.TaskListTypeActivity {
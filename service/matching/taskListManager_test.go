@@ -84,6 +84,37 @@ func TestDeliverBufferTasks_NoPollers(t *testing.T) {
 	wg.Wait()
 }
 
+func TestDeliverBufferTasks_SignalsReadAhead(t *testing.T) {
+	tests := []struct {
+		lowWatermark int
+		signaled     bool
+	}{
+		{1, false}, // two tasks remain buffered above the low watermark
+		{2, true},
+	}
+	for _, test := range tests {
+		cfg := defaultTestConfig()
+		cfg.TaskBufferLowWatermark = dynamicconfig.GetIntPropertyFilteredByTaskListInfo(test.lowWatermark)
+		tlm := createTestTaskListManagerWithConfig(cfg)
+		for i := 0; i < 3; i++ {
+			tlm.taskReader.taskBuffer <- &persistence.TaskInfo{CreatedTime: time.Now()}
+		}
+		var wg sync.WaitGroup
+		wg.Add(1)
+		go func() {
+			tlm.taskReader.dispatchBufferedTasks()
+			wg.Done()
+		}()
+		// the backlog head is recorded after the read ahead check, when the head is blocked on dispatching
+		require.Eventually(t, func() bool {
+			return atomic.LoadInt64(&tlm.backlogHeadCreatedTime) != 0
+		}, 5*time.Second, time.Millisecond)
+		tlm.taskReader.cancelFunc()
+		wg.Wait()
+		require.Equal(t, test.signaled, len(tlm.taskReader.notifyC) == 1)
+	}
+}
+
 func TestNewRateLimiter(t *testing.T) {
 	maxDispatch := float64(0.01)
 	rl := newRateLimiter(&maxDispatch, time.Second, _minBurst)
@@ -156,6 +187,7 @@ func TestDescribeTaskList(t *testing.T) {
 	taskIDBlock := taskListStatus.GetTaskIDBlock()
 	require.Equal(t, int64(1), taskIDBlock.GetStartID())
 	require.Equal(t, tlm.config.RangeSize, taskIDBlock.GetEndID())
+	require.Zero(t, taskListStatus.GetScheduleToStartLatencyP99Millis())
 
 	// Add a poller and complete all tasks
 	tlm.pollerHistory.updatePollerInfo(pollerIdentity(PollerIdentity), nil)
@@ -180,6 +212,13 @@ func TestDescribeTaskList(t *testing.T) {
 	require.NotNil(t, taskListStatus)
 	require.Equal(t, taskCount, taskListStatus.GetAckLevel())
 	require.Zero(t, taskListStatus.GetBacklogCountHint())
+
+	for i := 1; i <= 100; i++ {
+		tlm.scheduleToStartLatency.record(time.Duration(i) * time.Millisecond)
+	}
+	taskListStatus = tlm.DescribeTaskList(includeTaskStatus).GetTaskListStatus()
+	require.Equal(t, int64(95), taskListStatus.GetScheduleToStartLatencyP95Millis())
+	require.Equal(t, int64(99), taskListStatus.GetScheduleToStartLatencyP99Millis())
 }
 
 func tlMgrStartWithoutNotifyEvent(tlm *taskListManagerImpl) {
@@ -219,3 +258,43 @@ func TestCheckIdleTaskList(t *testing.T) {
 	tlm.Stop()
 	require.Equal(t, int32(1), tlm.stopped)
 }
+
+func TestTaskWriterBatchWindow(t *testing.T) {
+	cfg := defaultTestConfig()
+	cfg.MaxTaskBatchSize = dynamicconfig.GetIntPropertyFilteredByTaskListInfo(3)
+	tlm := createTestTaskListManagerWithConfig(cfg)
+	w := tlm.taskWriter
+	windowC := make(chan time.Time, 1)
+	timers := 0
+	w.newWindowTimer = func(d time.Duration) (<-chan time.Time, func() bool) {
+		require.Equal(t, time.Minute, d)
+		timers++
+		return windowC, func() bool { return true }
+	}
+
+	// without a window only the queued requests are batched
+	w.appendCh <- &writeTaskRequest{}
+	reqs := w.getWriteBatch([]*writeTaskRequest{{}})
+	require.Len(t, reqs, 2)
+	require.Equal(t, 0, timers)
+
+	// the batch is closed as soon as it is full
+	w.config.TaskBatchWindow = func() time.Duration { return time.Minute }
+	go func() {
+		for i := 0; i < 5; i++ {
+			w.appendCh <- &writeTaskRequest{}
+		}
+	}()
+	reqs = w.getWriteBatch([]*writeTaskRequest{{}})
+	require.Len(t, reqs, 4)
+	require.Equal(t, 1, timers)
+
+	// the batch is closed when the window elapses
+	for len(w.appendCh) < 2 {
+		time.Sleep(time.Millisecond)
+	}
+	windowC <- time.Now()
+	reqs = w.getWriteBatch([]*writeTaskRequest{{}})
+	require.Len(t, reqs, 3)
+	require.Equal(t, 2, timers)
+}
@@ -23,6 +23,7 @@ package matching
 import (
 	"context"
 	"runtime"
+	"sync/atomic"
 	"time"
 
 	"github.com/uber/cadence/common/log"
@@ -92,10 +93,15 @@ dispatchLoop:
 			if !ok { // Task list getTasks pump is shutdown
 				break dispatchLoop
 			}
+			if len(tr.taskBuffer) <= tr.tlMgr.config.TaskBufferLowWatermark() {
+				tr.Signal() // read ahead more tasks before the buffer runs dry
+			}
 			task := newInternalTask(taskInfo, tr.tlMgr.completeTask, false)
+			atomic.StoreInt64(&tr.tlMgr.backlogHeadCreatedTime, taskInfo.CreatedTime.UnixNano())
 			for {
 				err := tr.tlMgr.DispatchTask(tr.cancelCtx, task)
 				if err == nil {
+					atomic.StoreInt64(&tr.tlMgr.backlogHeadCreatedTime, 0)
 					break
 				}
 				if err == context.Canceled {
@@ -129,6 +135,12 @@ getTasksPumpLoop:
 			{
 				lastTimeWriteTask = time.Now()
 
+				if len(tr.taskBuffer) > tr.tlMgr.config.TaskBufferLowWatermark() {
+					// enough tasks are buffered ahead of pollers, the dispatcher signals
+					// the pump again once the buffer drains to the low watermark
+					continue getTasksPumpLoop
+				}
+
 				tasks, readLevel, isReadBatchDone, err := tr.getTaskBatch()
 				if err != nil {
 					tr.Signal() // re-enqueue the event
@@ -184,7 +196,8 @@ getTasksPumpLoop:
 
 func (tr *taskReader) getTaskBatchWithRange(readLevel int64, maxReadLevel int64) ([]*persistence.TaskInfo, error) {
 	response, err := tr.tlMgr.executeWithRetry(func() (interface{}, error) {
-		return tr.tlMgr.db.GetTasks(readLevel, maxReadLevel, tr.tlMgr.config.GetTasksBatchSize())
+		// the read is abandoned when the task list manager stops
+		return tr.tlMgr.db.GetTasks(tr.cancelCtx, readLevel, maxReadLevel, tr.tlMgr.config.GetTasksBatchSize())
 	})
 	if err != nil {
 		return nil, err
@@ -23,8 +23,10 @@ package matching
 import (
 	"errors"
 	"sync/atomic"
+	"time"
 
 	s "github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common"
 	"github.com/uber/cadence/common/log"
 	"github.com/uber/cadence/common/log/tag"
 	"github.com/uber/cadence/common/persistence"
@@ -58,6 +60,9 @@ type (
 		stopped      int64 // set to 1 if the writer is stopped or is shutting down
 		logger       log.Logger
 		stopCh       chan struct{} // shutdown signal for all routines in this class
+		// newWindowTimer starts the timer closing the batch window, it returns
+		// the timer channel and the function to stop the timer
+		newWindowTimer func(time.Duration) (<-chan time.Time, func() bool)
 	}
 )
 
@@ -72,6 +77,10 @@ func newTaskWriter(tlMgr *taskListManagerImpl) *taskWriter {
 		stopCh:     make(chan struct{}),
 		appendCh:   make(chan *writeTaskRequest, tlMgr.config.OutstandingTaskAppendsThreshold()),
 		logger:     tlMgr.logger,
+		newWindowTimer: func(d time.Duration) (<-chan time.Time, func() bool) {
+			timer := time.NewTimer(d)
+			return timer.C, timer.Stop
+		},
 	}
 }
 
@@ -181,6 +190,10 @@ writerLoop:
 						tag.Number(taskIDs[0]),
 						tag.NextNumber(taskIDs[batchSize-1]),
 					)
+				} else {
+					for i, req := range reqs {
+						w.tlMgr.journeys.record(common.TaskJourneyStagePersisted, req.taskInfo, taskIDs[i])
+					}
 				}
 
 				// Update the maxReadLevel after the writes are completed.
@@ -199,14 +212,35 @@ writerLoop:
 	}
 }
 
+// getWriteBatch reads the requests to write in the same batch. Requests arriving within the
+// batch window are added to the batch, so that bursts of tasks added to the task list are
+// written with a single CreateTasks call, i.e. a single range ID conditional update.
+// The batch window is disabled by default, only the requests already queued are batched then.
 func (w *taskWriter) getWriteBatch(reqs []*writeTaskRequest) []*writeTaskRequest {
+	var windowC <-chan time.Time
+	if window := w.config.TaskBatchWindow(); window > 0 {
+		var stopTimer func() bool
+		windowC, stopTimer = w.newWindowTimer(window)
+		defer stopTimer()
+	}
+
 readLoop:
 	for i := 0; i < w.config.MaxTaskBatchSize(); i++ {
 		select {
 		case req := <-w.appendCh:
 			reqs = append(reqs, req)
-		default: // channel is empty, don't block
-			break readLoop
+		default: // channel is empty
+			if windowC == nil {
+				break readLoop
+			}
+			select {
+			case req := <-w.appendCh:
+				reqs = append(reqs, req)
+			case <-windowC:
+				break readLoop
+			case <-w.stopCh:
+				break readLoop
+			}
 		}
 	}
 	return reqs
@@ -47,6 +47,8 @@ type (
 const (
 	// taskListPartitionPrefix is the required naming prefix for any task list partition other than partition 0
 	taskListPartitionPrefix = "/__cadence_sys/"
+	// taskListBuildPrefix is the naming prefix of the task lists dispatching decision tasks to a single worker build
+	taskListBuildPrefix = "/__cadence_build/"
 )
 
 // newTaskListName returns a fully qualified task list name.
@@ -121,6 +123,15 @@ func (tn *qualifiedTaskListName) init() error {
 	return nil
 }
 
+// versionedTaskListName returns the internal name of the task list dispatching the decision
+// tasks of a versioned task list to the pollers of a single worker build. Versioned names are
+// of the form
+//
+//     /__cadence_build/[build-id]/[original-name]
+func versionedTaskListName(name string, buildID string) string {
+	return fmt.Sprintf("%v%v/%v", taskListBuildPrefix, buildID, name)
+}
+
 // newTaskListID returns taskListID which uniquely identfies as task list
 func newTaskListID(domainID, taskListName string, taskType int) (*taskListID, error) {
 	name, err := newTaskListName(taskListName)
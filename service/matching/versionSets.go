@@ -0,0 +1,113 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package matching
+
+import (
+	"fmt"
+	"strings"
+
+	workflow "github.com/uber/cadence/.gen/go/shared"
+)
+
+// Version sets are the ordered sets of worker build IDs of a decision task list whose workflow
+// code is compatible with each other. The last set is the newest one and the last build ID of a
+// set is the default build of the set. When a decision task list has version sets, each build ID
+// in the sets gets its own task list, see versionedTaskListName, so that:
+//   - decision tasks of an execution last completed by a build of a set are dispatched to the
+//     default build of that set, letting executions started by old builds drain on old workers
+//   - decision tasks of new executions are dispatched to the default build of the newest set
+//   - decision tasks of executions last completed by an unknown build, and pollers whose build
+//     is in no set, keep using the unversioned task list
+
+// validateVersionSets returns an error if the given version sets are not well formed
+func validateVersionSets(versionSets [][]string) error {
+	seen := make(map[string]struct{})
+	for _, buildIDs := range versionSets {
+		if len(buildIDs) == 0 {
+			return &workflow.BadRequestError{Message: "Version set does not have any build ID."}
+		}
+		for _, buildID := range buildIDs {
+			if len(buildID) == 0 || strings.Contains(buildID, "/") {
+				return &workflow.BadRequestError{Message: fmt.Sprintf("Invalid build ID %q.", buildID)}
+			}
+			if _, ok := seen[buildID]; ok {
+				return &workflow.BadRequestError{Message: fmt.Sprintf("Build ID %v is in more than one version set.", buildID)}
+			}
+			seen[buildID] = struct{}{}
+		}
+	}
+	return nil
+}
+
+// getDispatchBuildID returns the build whose pollers are dispatched the decision tasks of an
+// execution last completed by the given worker build, an empty worker build ID denoting a new
+// execution. Returns false if the tasks are dispatched to the unversioned task list.
+func getDispatchBuildID(versionSets [][]string, workerBuildID string) (string, bool) {
+	if len(versionSets) == 0 {
+		return "", false
+	}
+	if len(workerBuildID) == 0 {
+		newest := versionSets[len(versionSets)-1]
+		return newest[len(newest)-1], true
+	}
+	for _, buildIDs := range versionSets {
+		for _, buildID := range buildIDs {
+			if buildID == workerBuildID {
+				return buildIDs[len(buildIDs)-1], true
+			}
+		}
+	}
+	return "", false
+}
+
+// containsBuildID returns true if the given build is in one of the version sets
+func containsBuildID(versionSets [][]string, buildID string) bool {
+	for _, buildIDs := range versionSets {
+		for _, id := range buildIDs {
+			if id == buildID {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func versionSetsToThrift(versionSets [][]string) []*workflow.TaskListVersionSet {
+	if len(versionSets) == 0 {
+		return nil
+	}
+	result := make([]*workflow.TaskListVersionSet, 0, len(versionSets))
+	for _, buildIDs := range versionSets {
+		result = append(result, &workflow.TaskListVersionSet{BuildIds: buildIDs})
+	}
+	return result
+}
+
+func versionSetsFromThrift(versionSets []*workflow.TaskListVersionSet) [][]string {
+	if len(versionSets) == 0 {
+		return nil
+	}
+	result := make([][]string, 0, len(versionSets))
+	for _, set := range versionSets {
+		result = append(result, set.GetBuildIds())
+	}
+	return result
+}
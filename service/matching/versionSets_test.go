@@ -0,0 +1,77 @@
+// Copyright (c) 2019 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package matching
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateVersionSets(t *testing.T) {
+	testCases := []struct {
+		name        string
+		versionSets [][]string
+		valid       bool
+	}{
+		{"no sets", nil, true},
+		{"single set", [][]string{{"v1"}}, true},
+		{"several sets", [][]string{{"v1", "v2"}, {"v3"}}, true},
+		{"empty set", [][]string{{"v1"}, {}}, false},
+		{"empty build", [][]string{{"v1", ""}}, false},
+		{"build with slash", [][]string{{"v1/2"}}, false},
+		{"duplicate build", [][]string{{"v1", "v2"}, {"v2"}}, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateVersionSets(tc.versionSets)
+			require.Equal(t, tc.valid, err == nil)
+		})
+	}
+}
+
+func TestGetDispatchBuildID(t *testing.T) {
+	versionSets := [][]string{{"v1", "v2"}, {"v3", "v4"}}
+	testCases := []struct {
+		workerBuildID string
+		buildID       string
+		versioned     bool
+	}{
+		{"", "v4", true},
+		{"v1", "v2", true},
+		{"v2", "v2", true},
+		{"v3", "v4", true},
+		{"v4", "v4", true},
+		{"v5", "", false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.workerBuildID, func(t *testing.T) {
+			buildID, versioned := getDispatchBuildID(versionSets, tc.workerBuildID)
+			require.Equal(t, tc.versioned, versioned)
+			require.Equal(t, tc.buildID, buildID)
+		})
+	}
+
+	_, versioned := getDispatchBuildID(nil, "")
+	require.False(t, versioned)
+}
@@ -66,6 +66,7 @@ const (
 	errReadBlob       = "could not read blob"
 	errEmptyBucket    = "domain is enabled for archival but bucket is not set"
 	errConstructBlob  = "failed to construct blob"
+	errNoKeyProvider  = "domain is encrypted but no encryption key provider is configured"
 	errDownloadBlob   = "could not download existing blob"
 	errDeleteBlob     = "could not delete existing blob"
 
@@ -78,7 +79,7 @@ const (
 )
 
 var (
-	uploadHistoryActivityNonRetryableErrors = []string{errGetDomainByID, errConstructKey, errGetTags, errUploadBlob, errReadBlob, errEmptyBucket, errConstructBlob, errDownloadBlob, errHistoryMutated, errActivityPanic}
+	uploadHistoryActivityNonRetryableErrors = []string{errGetDomainByID, errConstructKey, errGetTags, errUploadBlob, errReadBlob, errEmptyBucket, errConstructBlob, errNoKeyProvider, errDownloadBlob, errHistoryMutated, errActivityPanic}
 	deleteBlobActivityNonRetryableErrors    = []string{errConstructKey, errGetTags, errUploadBlob, errEmptyBucket, errDeleteBlob}
 	deleteHistoryActivityNonRetryableErrors = []string{errDeleteHistoryV1, errDeleteHistoryV2}
 	errContextTimeout                       = errors.New("activity aborted because context timed out")
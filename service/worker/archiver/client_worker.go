@@ -27,6 +27,7 @@ import (
 	"github.com/uber/cadence/common"
 	"github.com/uber/cadence/common/archiver/provider"
 	"github.com/uber/cadence/common/blobstore"
+	"github.com/uber/cadence/common/blobstore/blob"
 	"github.com/uber/cadence/common/cache"
 	"github.com/uber/cadence/common/cluster"
 	"github.com/uber/cadence/common/log"
@@ -64,6 +65,8 @@ type (
 		DomainCache      cache.DomainCache
 		Config           *Config
 		ArchiverProvider provider.ArchiverProvider
+		// KeyProvider encrypts the blobs of the domains with an encryption key alias, nil disables encryption
+		KeyProvider blob.KeyProvider
 
 		// the following are only set in testing code
 		HistoryBlobReader     HistoryBlobReader
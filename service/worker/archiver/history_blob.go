@@ -66,17 +66,26 @@ var (
 
 // NewHistoryBlobKey returns a key for history blob
 func NewHistoryBlobKey(domainID, workflowID, runID string, closeFailoverVersion int64, pageToken int) (blob.Key, error) {
-	if len(domainID) == 0 || len(workflowID) == 0 || len(runID) == 0 {
-		return nil, errInvalidKeyInput
-	}
 	if pageToken < common.FirstBlobPageToken {
 		return nil, errInvalidKeyInput
 	}
+	combinedHash, err := NewHistoryBlobKeyPrefix(domainID, workflowID, runID)
+	if err != nil {
+		return nil, err
+	}
+	return blob.NewKey("history", combinedHash, strconv.FormatInt(closeFailoverVersion, 10), strconv.Itoa(pageToken))
+}
+
+// NewHistoryBlobKeyPrefix returns the first piece of the keys of all the blobs archived for a workflow run,
+// its history, non-deterministic history and index blobs
+func NewHistoryBlobKeyPrefix(domainID, workflowID, runID string) (string, error) {
+	if len(domainID) == 0 || len(workflowID) == 0 || len(runID) == 0 {
+		return "", errInvalidKeyInput
+	}
 	domainIDHash := fmt.Sprintf("%v", farm.Fingerprint64([]byte(domainID)))
 	workflowIDHash := fmt.Sprintf("%v", farm.Fingerprint64([]byte(workflowID)))
 	runIDHash := fmt.Sprintf("%v", farm.Fingerprint64([]byte(runID)))
-	combinedHash := strings.Join([]string{domainIDHash, workflowIDHash, runIDHash}, "")
-	return blob.NewKey("history", combinedHash, strconv.FormatInt(closeFailoverVersion, 10), strconv.Itoa(pageToken))
+	return strings.Join([]string{domainIDHash, workflowIDHash, runIDHash}, ""), nil
 }
 
 // NewNonDeterministicBlobKey returns a key for the non-deterministic history blob given the key for the other blob
@@ -107,7 +116,7 @@ func IsLast(tags map[string]string) bool {
 	return ok && last == "true"
 }
 
-func constructBlob(historyBlob *HistoryBlob, enableCompression bool) (*blob.Blob, string, error) {
+func constructBlob(historyBlob *HistoryBlob, enableCompression bool, encryptionKeyAlias string, keys blob.KeyProvider) (*blob.Blob, string, error) {
 	body, err := json.Marshal(historyBlob)
 	if err != nil {
 		return nil, "failed to serialize blob", err
@@ -120,6 +129,13 @@ func constructBlob(historyBlob *HistoryBlob, enableCompression bool) (*blob.Blob
 	if enableCompression {
 		wrapFunctions = append(wrapFunctions, blob.GzipCompressed())
 	}
+	// encryption must be the outermost layer, compressing encrypted data is pointless
+	if encryptionKeyAlias != "" {
+		if keys == nil {
+			return nil, "no encryption key provider", errors.New(errNoKeyProvider)
+		}
+		wrapFunctions = append(wrapFunctions, blob.Encrypted(encryptionKeyAlias, keys))
+	}
 	blob, err := blob.Wrap(blob.NewBlob(body, tags), wrapFunctions...)
 	if err != nil {
 		return nil, "failed to wrap blob", err
@@ -127,6 +143,12 @@ func constructBlob(historyBlob *HistoryBlob, enableCompression bool) (*blob.Blob
 	return blob, "", nil
 }
 
+// decryptBlob returns the unwrapped body and tags of an encrypted blob
+func decryptBlob(b *blob.Blob, keys blob.KeyProvider) (*blob.Blob, error) {
+	unwrappedBlob, _, err := blob.UnwrapWithKeys(b, keys)
+	return unwrappedBlob, err
+}
+
 func modifyBlobForConstCheck(historyBlob *HistoryBlob, existingTags map[string]string) {
 	historyBlob.Header.UploadCluster = common.StringPtr(existingTags["upload_cluster"])
 	historyBlob.Header.UploadDateTime = common.StringPtr(existingTags["upload_date_time"])
@@ -55,6 +55,7 @@ type (
 
 	historyBlobDownloader struct {
 		blobstoreClient blobstore.Client
+		keys            blob.KeyProvider
 	}
 
 	archivalToken struct {
@@ -68,10 +69,11 @@ var (
 	errHistoryNotExist        = &gen.BadRequestError{Message: "Requested workflow history does not exist."}
 )
 
-// NewHistoryBlobDownloader returns a new HistoryBlobDownloader
-func NewHistoryBlobDownloader(blobstoreClient blobstore.Client) HistoryBlobDownloader {
+// NewHistoryBlobDownloader returns a new HistoryBlobDownloader, keys decrypts the blobs of domains with an encryption key alias
+func NewHistoryBlobDownloader(blobstoreClient blobstore.Client, keys blob.KeyProvider) HistoryBlobDownloader {
 	return &historyBlobDownloader{
 		blobstoreClient: blobstoreClient,
+		keys:            keys,
 	}
 }
 
@@ -120,7 +122,7 @@ func (d *historyBlobDownloader) DownloadBlob(ctx context.Context, request *Downl
 	} else if err != nil {
 		return nil, err
 	}
-	unwrappedBlob, wrappingLayers, err := blob.Unwrap(b)
+	unwrappedBlob, wrappingLayers, err := blob.UnwrapWithKeys(b, d.keys)
 	if err != nil {
 		return nil, err
 	}
@@ -58,7 +58,7 @@ func (s *historyBlobDownloaderSuite) TearDownTest() {
 }
 
 func (s *historyBlobDownloaderSuite) TestDownloadBlob_Failed_CouldNotDeserializeToken() {
-	blobDownloader := NewHistoryBlobDownloader(s.blobstoreClient)
+	blobDownloader := NewHistoryBlobDownloader(s.blobstoreClient, nil)
 	resp, err := blobDownloader.DownloadBlob(context.Background(), &DownloadBlobRequest{
 		NextPageToken: []byte{1},
 	})
@@ -68,7 +68,7 @@ func (s *historyBlobDownloaderSuite) TestDownloadBlob_Failed_CouldNotDeserialize
 
 func (s *historyBlobDownloaderSuite) TestDownloadBlob_Failed_CouldNotGetHighestVersion() {
 	s.blobstoreClient.On("GetTags", mock.Anything, mock.Anything, mock.Anything).Return(nil, errors.New("failed to get tags")).Once()
-	blobDownloader := NewHistoryBlobDownloader(s.blobstoreClient)
+	blobDownloader := NewHistoryBlobDownloader(s.blobstoreClient, nil)
 	resp, err := blobDownloader.DownloadBlob(context.Background(), &DownloadBlobRequest{
 		ArchivalBucket: testArchivalBucket,
 		DomainID:       testDomainID,
@@ -82,7 +82,7 @@ func (s *historyBlobDownloaderSuite) TestDownloadBlob_Failed_CouldNotGetHighestV
 func (s *historyBlobDownloaderSuite) TestDownloadBlob_Failed_CouldNotDownloadBlob() {
 	s.blobstoreClient.On("GetTags", mock.Anything, mock.Anything, mock.Anything).Return(map[string]string{testLowVersionStr: ""}, nil).Once()
 	s.blobstoreClient.On("Download", mock.Anything, mock.Anything, mock.Anything).Return(nil, errors.New("failed to download blob")).Once()
-	blobDownloader := NewHistoryBlobDownloader(s.blobstoreClient)
+	blobDownloader := NewHistoryBlobDownloader(s.blobstoreClient, nil)
 	resp, err := blobDownloader.DownloadBlob(context.Background(), &DownloadBlobRequest{
 		ArchivalBucket: testArchivalBucket,
 		DomainID:       testDomainID,
@@ -98,7 +98,7 @@ func (s *historyBlobDownloaderSuite) TestDownloadBlob_Success_GetFirstPage() {
 	key := s.getHistoryKey(testHighVersion, common.FirstBlobPageToken)
 	s.blobstoreClient.On("GetTags", mock.Anything, mock.Anything, mock.Anything).Return(map[string]string{testLowVersionStr: "", testHighVersionStr: ""}, nil).Once()
 	s.blobstoreClient.On("Download", mock.Anything, mock.Anything, key).Return(page, nil).Once()
-	blobDownloader := NewHistoryBlobDownloader(s.blobstoreClient)
+	blobDownloader := NewHistoryBlobDownloader(s.blobstoreClient, nil)
 	resp, err := blobDownloader.DownloadBlob(context.Background(), &DownloadBlobRequest{
 		ArchivalBucket: testArchivalBucket,
 		DomainID:       testDomainID,
@@ -114,7 +114,7 @@ func (s *historyBlobDownloaderSuite) TestDownloadBlob_Success_ProvidedVersion()
 	expected, page := s.getBlob(common.FirstBlobPageToken, false)
 	key := s.getHistoryKey(testHighVersion, common.FirstBlobPageToken)
 	s.blobstoreClient.On("Download", mock.Anything, mock.Anything, key).Return(page, nil).Once()
-	blobDownloader := NewHistoryBlobDownloader(s.blobstoreClient)
+	blobDownloader := NewHistoryBlobDownloader(s.blobstoreClient, nil)
 	resp, err := blobDownloader.DownloadBlob(context.Background(), &DownloadBlobRequest{
 		ArchivalBucket:       testArchivalBucket,
 		DomainID:             testDomainID,
@@ -131,7 +131,7 @@ func (s *historyBlobDownloaderSuite) TestDownloadBlob_Success_UsePageToken() {
 	expected, page := s.getBlob(common.FirstBlobPageToken+1, false)
 	key := s.getHistoryKey(testHighVersion, common.FirstBlobPageToken+1)
 	s.blobstoreClient.On("Download", mock.Anything, mock.Anything, key).Return(page, nil).Once()
-	blobDownloader := NewHistoryBlobDownloader(s.blobstoreClient)
+	blobDownloader := NewHistoryBlobDownloader(s.blobstoreClient, nil)
 	resp, err := blobDownloader.DownloadBlob(context.Background(), &DownloadBlobRequest{
 		ArchivalBucket: testArchivalBucket,
 		DomainID:       testDomainID,
@@ -148,7 +148,7 @@ func (s *historyBlobDownloaderSuite) TestDownloadBlob_Success_GetLastPage() {
 	expected, page := s.getBlob(common.FirstBlobPageToken+1, true)
 	key := s.getHistoryKey(testHighVersion, common.FirstBlobPageToken+1)
 	s.blobstoreClient.On("Download", mock.Anything, mock.Anything, key).Return(page, nil).Once()
-	blobDownloader := NewHistoryBlobDownloader(s.blobstoreClient)
+	blobDownloader := NewHistoryBlobDownloader(s.blobstoreClient, nil)
 	resp, err := blobDownloader.DownloadBlob(context.Background(), &DownloadBlobRequest{
 		ArchivalBucket: testArchivalBucket,
 		DomainID:       testDomainID,
@@ -170,7 +170,7 @@ func (s *historyBlobDownloaderSuite) TestDownloadBlob_Success_DownloadMultiple()
 		expectedBlobs = append(expectedBlobs, currExpected)
 	}
 	s.blobstoreClient.On("GetTags", mock.Anything, mock.Anything, mock.Anything).Return(map[string]string{testLowVersionStr: "", testHighVersionStr: ""}, nil).Once()
-	blobDownloader := NewHistoryBlobDownloader(s.blobstoreClient)
+	blobDownloader := NewHistoryBlobDownloader(s.blobstoreClient, nil)
 	resp, err := blobDownloader.DownloadBlob(context.Background(), &DownloadBlobRequest{
 		ArchivalBucket: testArchivalBucket,
 		DomainID:       testDomainID,
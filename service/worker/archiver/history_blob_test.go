@@ -86,6 +86,19 @@ func (s *HistoryBlobSuite) TestNewHistoryBlobKey() {
 	}
 }
 
+func (s *HistoryBlobSuite) TestNewHistoryBlobKeyPrefix() {
+	prefix, err := NewHistoryBlobKeyPrefix("testDomainID", "testWorkflowID", "testRunID")
+	s.NoError(err)
+	s.Equal("17971674567288329890367046253745284795510285995943906173973", prefix)
+
+	key, err := NewHistoryBlobKey("testDomainID", "testWorkflowID", "testRunID", 5, common.FirstBlobPageToken)
+	s.NoError(err)
+	s.Equal(prefix, key.Pieces()[0])
+
+	_, err = NewHistoryBlobKeyPrefix("testDomainID", "", "testRunID")
+	s.Error(err)
+}
+
 func (s *HistoryBlobSuite) TestConvertHeaderToTags() {
 	testCases := []struct {
 		header     *HistoryBlobHeader
@@ -160,3 +173,13 @@ func (s *HistoryBlobSuite) TestIsLast() {
 		s.Equal(tc.isLast, IsLast(tags))
 	}
 }
+
+func (s *HistoryBlobSuite) TestConstructBlobFailsClosedWithoutKeyProvider() {
+	historyBlob := &HistoryBlob{Header: &HistoryBlobHeader{IsLast: common.BoolPtr(true)}}
+	_, _, err := constructBlob(historyBlob, true, "alias/tenant-a", nil)
+	s.Error(err)
+
+	blob, _, err := constructBlob(historyBlob, true, "", nil)
+	s.NoError(err)
+	s.NotNil(blob)
+}
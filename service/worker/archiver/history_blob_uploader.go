@@ -52,6 +52,7 @@ type (
 		historyBlobIterator        HistoryBlobIterator
 		uploadedHistoryEventHashes []uint64
 		uploadedBlobs              []string
+		encryptionKeyAlias         string
 	}
 )
 
@@ -107,6 +108,12 @@ func (h *historyBlobUploader) UploadHistory(ctx context.Context, request *Archiv
 
 	domainName := domainCacheEntry.GetInfo().Name
 	clusterName := h.container.ClusterMetadata.GetCurrentClusterName()
+	h.encryptionKeyAlias = domainCacheEntry.GetConfig().EncryptionKeyAlias
+	if h.encryptionKeyAlias != "" && h.container.KeyProvider == nil {
+		// fail closed, the history of an encrypted domain is never archived in plaintext
+		h.logger.Error(uploadErrorMsg, tag.ArchivalUploadFailReason(errNoKeyProvider))
+		return result, cadence.NewCustomError(errNoKeyProvider)
+	}
 
 	if err := h.construtHistoryReaderAndIterator(domainName, clusterName, progress.IteratorState); err != nil {
 		return result, err
@@ -223,7 +230,7 @@ func (h *historyBlobUploader) uploadSingleHistoryBlob(ctx context.Context, domai
 		modifyBlobForConstCheck(historyBlob, tags)
 	}
 
-	blob, reason, err := constructBlob(historyBlob, h.container.Config.EnableArchivalCompression(domainName))
+	blob, reason, err := constructBlob(historyBlob, h.container.Config.EnableArchivalCompression(domainName), h.encryptionKeyAlias, h.container.KeyProvider)
 	if err != nil {
 		h.logger.Error(uploadErrorMsg, tag.ArchivalUploadFailReason(reason), tag.ArchivalBlobKey(key.String()))
 		return 0, false, cadence.NewCustomError(errConstructBlob, err.Error())
@@ -273,6 +280,17 @@ func (h *historyBlobUploader) deterministicTestHelper(ctx context.Context, blob
 		h.scope.IncCounter(metrics.ArchiverCouldNotRunDeterministicConstructionCheckCount)
 		return
 	}
+	if h.encryptionKeyAlias != "" {
+		// encrypted blobs are never equal, compare them once decrypted
+		if blob, err = decryptBlob(blob, h.container.KeyProvider); err == nil {
+			existingBlob, err = decryptBlob(existingBlob, h.container.KeyProvider)
+		}
+		if err != nil {
+			h.logger.Error("failed to decrypt blob for deterministic construction verification", tag.Error(err))
+			h.scope.IncCounter(metrics.ArchiverCouldNotRunDeterministicConstructionCheckCount)
+			return
+		}
+	}
 	if equal, reason := blob.EqualWithDetails(existingBlob); !equal {
 		h.logger.Error("deterministic construction check failed",
 			tag.ArchivalBlobKey(key.String()),
@@ -315,7 +333,7 @@ func (h *historyBlobUploader) integrityCheckHelper(ctx context.Context) {
 	h.scope.IncCounter(metrics.ArchiverRunningBlobIntegrityCheckCount)
 	blobDownloader := h.container.HistoryBlobDownloader
 	if blobDownloader == nil {
-		blobDownloader = NewHistoryBlobDownloader(h.container.Blobstore)
+		blobDownloader = NewHistoryBlobDownloader(h.container.Blobstore, h.container.KeyProvider)
 	}
 	req := &DownloadBlobRequest{
 		ArchivalBucket:       h.request.BucketName,
@@ -22,11 +22,8 @@ package archiver
 
 import (
 	"errors"
-	"fmt"
 	"strconv"
-	"strings"
 
-	"github.com/dgryski/go-farm"
 	"github.com/uber/cadence/common/blobstore/blob"
 )
 
@@ -36,13 +33,10 @@ var (
 
 // NewHistoryIndexBlobKey returns a key for history index blob
 func NewHistoryIndexBlobKey(domainID, workflowID, runID string) (blob.Key, error) {
-	if len(domainID) == 0 || len(workflowID) == 0 || len(runID) == 0 {
-		return nil, errInvalidKeyInput
+	combinedHash, err := NewHistoryBlobKeyPrefix(domainID, workflowID, runID)
+	if err != nil {
+		return nil, err
 	}
-	domainIDHash := fmt.Sprintf("%v", farm.Fingerprint64([]byte(domainID)))
-	workflowIDHash := fmt.Sprintf("%v", farm.Fingerprint64([]byte(workflowID)))
-	runIDHash := fmt.Sprintf("%v", farm.Fingerprint64([]byte(runID)))
-	combinedHash := strings.Join([]string{domainIDHash, workflowIDHash, runIDHash}, "")
 	return blob.NewKey("index", combinedHash)
 }
 
@@ -0,0 +1,165 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package canary
+
+import (
+	"context"
+	"time"
+
+	"github.com/pborman/uuid"
+	"github.com/uber-go/tally"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/backoff"
+	"github.com/uber/cadence/common/log"
+	"github.com/uber/cadence/common/log/tag"
+	"github.com/uber/cadence/common/metrics"
+	"go.uber.org/cadence/.gen/go/cadence/workflowserviceclient"
+	"go.uber.org/cadence/.gen/go/shared"
+	cclient "go.uber.org/cadence/client"
+	"go.uber.org/cadence/worker"
+	"go.uber.org/cadence/workflow"
+)
+
+type (
+	// BootstrapParams contains the set of params needed to bootstrap
+	// the canary sub-system
+	BootstrapParams struct {
+		// SDKClient is an instance of cadence sdk client
+		SDKClient workflowserviceclient.Interface
+		// MetricsClient is an instance of metrics object for emitting stats
+		MetricsClient metrics.Client
+		Logger        log.Logger
+		// TallyScope is an instance of tally metrics scope
+		TallyScope tally.Scope
+	}
+
+	// Canary is the background sub-system that continuously runs small probe workflows
+	// against the cluster and emits end to end success and latency metrics for them.
+	// It is also the context object that get's passed around within the canary workflows / activities
+	Canary struct {
+		sdkClient     workflowserviceclient.Interface
+		metricsClient metrics.Client
+		tallyScope    tally.Scope
+		logger        log.Logger
+	}
+)
+
+// New returns a new instance of canary daemon Canary
+func New(params *BootstrapParams) *Canary {
+	return &Canary{
+		sdkClient:     params.SDKClient,
+		metricsClient: params.MetricsClient,
+		tallyScope:    params.TallyScope,
+		logger:        params.Logger.WithTags(tag.ComponentCanary),
+	}
+}
+
+// Start starts the canary
+func (c *Canary) Start() error {
+	workerOpts := worker.Options{
+		MetricsScope:              c.tallyScope,
+		BackgroundActivityContext: context.WithValue(context.Background(), canaryContextKey, c),
+	}
+	go c.startWorkflowWithRetry()
+	worker := worker.New(c.sdkClient, common.SystemLocalDomainName, canaryTaskListName, workerOpts)
+	return worker.Start()
+}
+
+func (c *Canary) startWorkflowWithRetry() error {
+	client := cclient.NewClient(c.sdkClient, common.SystemLocalDomainName, &cclient.Options{})
+	policy := backoff.NewExponentialRetryPolicy(time.Second)
+	policy.SetMaximumInterval(time.Minute)
+	policy.SetExpirationInterval(backoff.NoInterval)
+	return backoff.Retry(func() error {
+		return c.startWorkflow(client)
+	}, policy, func(err error) bool {
+		return true
+	})
+}
+
+func (c *Canary) startWorkflow(client cclient.Client) error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	_, err := client.StartWorkflow(ctx, canaryWFStartOptions, canaryWFTypeName)
+	cancel()
+	if err != nil {
+		if _, ok := err.(*shared.WorkflowExecutionAlreadyStartedError); ok {
+			return nil
+		}
+		c.logger.Error("error starting canary workflow", tag.Error(err))
+		return err
+	}
+	c.logger.Info("Canary workflow successfully started")
+	return nil
+}
+
+// probe starts a probe workflow, signals and queries it and waits for its completion,
+// emitting the success and latency of each step as well as of the whole probe
+func (c *Canary) probe(ctx context.Context) error {
+	client := cclient.NewClient(c.sdkClient, common.SystemLocalDomainName, &cclient.Options{})
+	return c.measure(metrics.CanaryScope, func() error {
+		options := cclient.StartWorkflowOptions{
+			ID:                              probeWFIDPrefix + uuid.New(),
+			TaskList:                        canaryTaskListName,
+			ExecutionStartToCloseTimeout:    probeTimeout,
+			DecisionTaskStartToCloseTimeout: probeDecisionTimeout,
+		}
+		var execution *workflow.Execution
+		if err := c.measure(metrics.CanaryStartWorkflowScope, func() error {
+			var err error
+			execution, err = client.StartWorkflow(ctx, options, probeWFTypeName)
+			return err
+		}); err != nil {
+			return err
+		}
+
+		if err := c.measure(metrics.CanarySignalWorkflowScope, func() error {
+			return client.SignalWorkflow(ctx, execution.ID, execution.RunID, probeSignalName, nil)
+		}); err != nil {
+			return err
+		}
+
+		if err := c.measure(metrics.CanaryQueryWorkflowScope, func() error {
+			value, err := client.QueryWorkflow(ctx, execution.ID, execution.RunID, probeQueryType)
+			if err != nil {
+				return err
+			}
+			var state string
+			return value.Get(&state)
+		}); err != nil {
+			return err
+		}
+
+		return client.GetWorkflow(ctx, execution.ID, execution.RunID).Get(ctx, nil)
+	})
+}
+
+func (c *Canary) measure(scope int, op func() error) error {
+	c.metricsClient.IncCounter(scope, metrics.CadenceRequests)
+	sw := c.metricsClient.StartTimer(scope, metrics.CadenceLatency)
+	defer sw.Stop()
+
+	err := op()
+	if err != nil {
+		c.metricsClient.IncCounter(scope, metrics.CadenceFailures)
+		c.logger.Error("canary probe failed", tag.Error(err))
+	}
+	return err
+}
@@ -0,0 +1,121 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package canary
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/cadence/activity"
+	cclient "go.uber.org/cadence/client"
+	"go.uber.org/cadence/workflow"
+)
+
+type contextKey int
+
+const (
+	canaryContextKey = contextKey(0)
+
+	canaryTaskListName = "cadence-sys-canary-tasklist"
+	canaryWFID         = "cadence-sys-canary"
+	canaryWFTypeName   = "cadence-sys-canary-workflow"
+	canaryActivityName = "cadence-sys-canary-activity"
+
+	probeWFIDPrefix      = "cadence-sys-canary-probe-"
+	probeWFTypeName      = "cadence-sys-canary-probe-workflow"
+	probeActivityName    = "cadence-sys-canary-probe-activity"
+	probeSignalName      = "cadence-sys-canary-probe-signal"
+	probeQueryType       = "cadence-sys-canary-probe-state"
+	probeTimeout         = 2 * time.Minute
+	probeDecisionTimeout = 10 * time.Second
+	probeTimerDuration   = time.Second
+
+	probeStateStarted           = "started"
+	probeStateActivityCompleted = "activity-completed"
+	probeStateTimerFired        = "timer-fired"
+)
+
+var (
+	canaryWFStartOptions = cclient.StartWorkflowOptions{
+		ID:                           canaryWFID,
+		TaskList:                     canaryTaskListName,
+		ExecutionStartToCloseTimeout: 2 * probeTimeout,
+		WorkflowIDReusePolicy:        cclient.WorkflowIDReusePolicyAllowDuplicate,
+		CronSchedule:                 "* * * * *",
+	}
+)
+
+func init() {
+	workflow.RegisterWithOptions(CanaryWorkflow, workflow.RegisterOptions{Name: canaryWFTypeName})
+	activity.RegisterWithOptions(CanaryActivity, activity.RegisterOptions{Name: canaryActivityName})
+	workflow.RegisterWithOptions(ProbeWorkflow, workflow.RegisterOptions{Name: probeWFTypeName})
+	activity.RegisterWithOptions(ProbeActivity, activity.RegisterOptions{Name: probeActivityName})
+}
+
+// CanaryWorkflow is the cron workflow that runs a canary probe every minute
+func CanaryWorkflow(ctx workflow.Context) error {
+	opts := workflow.ActivityOptions{
+		ScheduleToStartTimeout: time.Minute,
+		StartToCloseTimeout:    probeTimeout,
+	}
+	// a failed probe is already reported by the metrics, the next run of the cron workflow probes again
+	_ = workflow.ExecuteActivity(workflow.WithActivityOptions(ctx, opts), canaryActivityName).Get(ctx, nil)
+	return nil
+}
+
+// CanaryActivity is the activity that runs one canary probe
+func CanaryActivity(ctx context.Context) error {
+	canary := ctx.Value(canaryContextKey).(*Canary)
+	return canary.probe(ctx)
+}
+
+// ProbeWorkflow is the workflow started by the canary probe, it exercises an activity, a timer,
+// a signal and a query before completing
+func ProbeWorkflow(ctx workflow.Context) error {
+	state := probeStateStarted
+	if err := workflow.SetQueryHandler(ctx, probeQueryType, func() (string, error) {
+		return state, nil
+	}); err != nil {
+		return err
+	}
+
+	opts := workflow.ActivityOptions{
+		ScheduleToStartTimeout: time.Minute,
+		StartToCloseTimeout:    probeDecisionTimeout,
+	}
+	if err := workflow.ExecuteActivity(workflow.WithActivityOptions(ctx, opts), probeActivityName).Get(ctx, nil); err != nil {
+		return err
+	}
+	state = probeStateActivityCompleted
+
+	if err := workflow.NewTimer(ctx, probeTimerDuration).Get(ctx, nil); err != nil {
+		return err
+	}
+	state = probeStateTimerFired
+
+	workflow.GetSignalChannel(ctx, probeSignalName).Receive(ctx, nil)
+	return nil
+}
+
+// ProbeActivity is the activity executed by the probe workflow
+func ProbeActivity(ctx context.Context) error {
+	return nil
+}
@@ -0,0 +1,79 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package canary
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/cadence/testsuite"
+)
+
+type canaryWorkflowTestSuite struct {
+	suite.Suite
+	testsuite.WorkflowTestSuite
+}
+
+func TestCanaryWorkflowTestSuite(t *testing.T) {
+	suite.Run(t, new(canaryWorkflowTestSuite))
+}
+
+func (s *canaryWorkflowTestSuite) TestCanaryWorkflow_ProbeFailed() {
+	env := s.NewTestWorkflowEnvironment()
+	env.OnActivity(canaryActivityName, mock.Anything).Return(errors.New("probe failed"))
+	env.ExecuteWorkflow(canaryWFTypeName)
+	s.True(env.IsWorkflowCompleted())
+	s.NoError(env.GetWorkflowError())
+}
+
+func (s *canaryWorkflowTestSuite) TestProbeWorkflow() {
+	env := s.NewTestWorkflowEnvironment()
+	env.OnActivity(probeActivityName, mock.Anything).Return(nil)
+	env.RegisterDelayedCallback(func() {
+		value, err := env.QueryWorkflow(probeQueryType)
+		s.NoError(err)
+		var state string
+		s.NoError(value.Get(&state))
+		s.Equal(probeStateTimerFired, state)
+		env.SignalWorkflow(probeSignalName, nil)
+	}, 2*probeTimerDuration)
+	env.ExecuteWorkflow(probeWFTypeName)
+	s.True(env.IsWorkflowCompleted())
+	s.NoError(env.GetWorkflowError())
+}
+
+func (s *canaryWorkflowTestSuite) TestProbeWorkflow_StateBeforeTimer() {
+	env := s.NewTestWorkflowEnvironment()
+	env.OnActivity(probeActivityName, mock.Anything).Return(nil)
+	env.RegisterDelayedCallback(func() {
+		value, err := env.QueryWorkflow(probeQueryType)
+		s.NoError(err)
+		var state string
+		s.NoError(value.Get(&state))
+		s.Equal(probeStateActivityCompleted, state)
+		env.SignalWorkflow(probeSignalName, nil)
+	}, probeTimerDuration/2)
+	env.ExecuteWorkflow(probeWFTypeName)
+	s.True(env.IsWorkflowCompleted())
+	s.NoError(env.GetWorkflowError())
+}
@@ -106,10 +106,14 @@ func (domainReplicator *domainReplicatorImpl) handleDomainCreationReplicationTas
 			Data:        task.Info.Data,
 		},
 		Config: &persistence.DomainConfig{
-			Retention:      task.Config.GetWorkflowExecutionRetentionPeriodInDays(),
-			EmitMetric:     task.Config.GetEmitMetric(),
-			ArchivalBucket: task.Config.GetArchivalBucketName(),
-			ArchivalStatus: task.Config.GetArchivalStatus(),
+			Retention:          task.Config.GetWorkflowExecutionRetentionPeriodInDays(),
+			EmitMetric:         task.Config.GetEmitMetric(),
+			ArchivalBucket:     task.Config.GetArchivalBucketName(),
+			ArchivalStatus:     task.Config.GetArchivalStatus(),
+			RetentionRunCount:  task.Config.GetWorkflowExecutionRetentionRunCount(),
+			TimeoutPolicy:      persistence.MergeDomainTimeoutPolicy(persistence.DomainTimeoutPolicy{}, task.Config.TimeoutPolicy),
+			EncryptionKeyAlias: task.Config.GetEncryptionKeyAlias(),
+			Residency:          persistence.MergeDomainResidency(persistence.DomainResidency{}, task.Config.Residency),
 		},
 		ReplicationConfig: &persistence.DomainReplicationConfig{
 			ActiveClusterName: task.ReplicationConfig.GetActiveClusterName(),
@@ -220,10 +224,14 @@ func (domainReplicator *domainReplicatorImpl) handleDomainUpdateReplicationTask(
 			Data:        task.Info.Data,
 		}
 		request.Config = &persistence.DomainConfig{
-			Retention:      task.Config.GetWorkflowExecutionRetentionPeriodInDays(),
-			EmitMetric:     task.Config.GetEmitMetric(),
-			ArchivalBucket: task.Config.GetArchivalBucketName(),
-			ArchivalStatus: task.Config.GetArchivalStatus(),
+			Retention:          task.Config.GetWorkflowExecutionRetentionPeriodInDays(),
+			EmitMetric:         task.Config.GetEmitMetric(),
+			ArchivalBucket:     task.Config.GetArchivalBucketName(),
+			ArchivalStatus:     task.Config.GetArchivalStatus(),
+			RetentionRunCount:  task.Config.GetWorkflowExecutionRetentionRunCount(),
+			TimeoutPolicy:      persistence.MergeDomainTimeoutPolicy(persistence.DomainTimeoutPolicy{}, task.Config.TimeoutPolicy),
+			EncryptionKeyAlias: task.Config.GetEncryptionKeyAlias(),
+			Residency:          persistence.MergeDomainResidency(persistence.DomainResidency{}, task.Config.Residency),
 		}
 		if task.Config.GetBadBinaries() != nil {
 			request.Config.BadBinaries = *task.Config.GetBadBinaries()
@@ -175,6 +175,8 @@ func newHistoryReplicationTask(task *replicator.ReplicationTask, msg messaging.M
 			EventStoreVersion:       attr.EventStoreVersion,
 			NewRunEventStoreVersion: attr.NewRunEventStoreVersion,
 			ResetWorkflow:           attr.ResetWorkflow,
+			HistoryBlob:             attr.HistoryBlob,
+			NewRunHistoryBlob:       attr.NewRunHistoryBlob,
 		},
 	}
 }
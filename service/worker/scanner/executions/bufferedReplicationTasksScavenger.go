@@ -0,0 +1,143 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package executions
+
+import (
+	"context"
+
+	"github.com/uber/cadence/common/log"
+	"github.com/uber/cadence/common/log/tag"
+	"github.com/uber/cadence/common/metrics"
+	p "github.com/uber/cadence/common/persistence"
+	"github.com/uber/cadence/common/service/dynamicconfig"
+)
+
+type (
+	// BufferedReplicationTasksScavenger is the type that reports and purges the buffered replication
+	// tasks left behind in the execution store. Buffered replication tasks are no longer read or
+	// written by the history service, so every remaining entry is stale and only holds row space.
+	BufferedReplicationTasksScavenger struct {
+		executionMgrFactory p.ExecutionManagerFactory
+		numShards           int
+		purgeEnabled        dynamicconfig.BoolPropertyFn
+		heartbeat           func(shardID int)
+		metricsClient       metrics.Client
+		logger              log.Logger
+	}
+)
+
+// NewBufferedReplicationTasksScavenger returns a new instance of buffered replication tasks scavenger,
+// heartbeat is invoked with the shard being scanned after every page so the caller can report progress
+func NewBufferedReplicationTasksScavenger(
+	executionMgrFactory p.ExecutionManagerFactory,
+	numShards int,
+	purgeEnabled dynamicconfig.BoolPropertyFn,
+	heartbeat func(shardID int),
+	metricsClient metrics.Client,
+	logger log.Logger,
+) *BufferedReplicationTasksScavenger {
+	return &BufferedReplicationTasksScavenger{
+		executionMgrFactory: executionMgrFactory,
+		numShards:           numShards,
+		purgeEnabled:        purgeEnabled,
+		heartbeat:           heartbeat,
+		metricsClient:       metricsClient,
+		logger:              logger,
+	}
+}
+
+// Run scans every shard, reports the executions with buffered replication tasks and
+// purges them unless purging is disabled through dynamic config
+func (s *BufferedReplicationTasksScavenger) Run(ctx context.Context) error {
+	for shardID := 0; shardID < s.numShards; shardID++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := s.scavengeShard(ctx, shardID); err != nil {
+			s.logger.Error("failed to scavenge buffered replication tasks", tag.ShardID(shardID), tag.Error(err))
+			return err
+		}
+	}
+	s.logger.Info("buffered replication tasks scavenged", tag.Number(int64(s.numShards)))
+	return nil
+}
+
+func (s *BufferedReplicationTasksScavenger) scavengeShard(ctx context.Context, shardID int) error {
+	executionMgr, err := s.executionMgrFactory.NewExecutionManager(shardID)
+	if err != nil {
+		return err
+	}
+	defer executionMgr.Close()
+
+	var pageToken []byte
+	for {
+		s.heartbeat(shardID)
+		resp, err := executionMgr.ListBufferedReplicationTasks(&p.ListBufferedReplicationTasksRequest{
+			PageSize:      listPageSize,
+			NextPageToken: pageToken,
+		})
+		if err != nil {
+			return err
+		}
+		for _, execution := range resp.Executions {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			if err := s.scavengeExecution(executionMgr, shardID, execution); err != nil {
+				return err
+			}
+		}
+		if len(resp.NextPageToken) == 0 {
+			return nil
+		}
+		pageToken = resp.NextPageToken
+	}
+}
+
+func (s *BufferedReplicationTasksScavenger) scavengeExecution(
+	executionMgr p.ExecutionManager,
+	shardID int,
+	execution *p.BufferedReplicationTasksInfo,
+) error {
+
+	s.logger.Info("stale buffered replication tasks found",
+		tag.ShardID(shardID),
+		tag.WorkflowDomainID(execution.DomainID),
+		tag.WorkflowID(execution.WorkflowID),
+		tag.WorkflowRunID(execution.RunID),
+		tag.Counter(execution.TaskCount))
+	s.metricsClient.AddCounter(metrics.BufferedReplicationTasksScavengerScope,
+		metrics.BufferedReplicationTasksCount, int64(execution.TaskCount))
+	if !s.purgeEnabled() {
+		return nil
+	}
+
+	if err := executionMgr.DeleteBufferedReplicationTasks(&p.DeleteBufferedReplicationTasksRequest{
+		DomainID:   execution.DomainID,
+		WorkflowID: execution.WorkflowID,
+		RunID:      execution.RunID,
+	}); err != nil {
+		return err
+	}
+	s.metricsClient.AddCounter(metrics.BufferedReplicationTasksScavengerScope,
+		metrics.BufferedReplicationTasksPurgedCount, int64(execution.TaskCount))
+	return nil
+}
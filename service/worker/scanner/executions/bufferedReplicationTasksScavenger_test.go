@@ -0,0 +1,125 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package executions
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"github.com/uber-go/tally"
+	"github.com/uber/cadence/common/log/loggerimpl"
+	"github.com/uber/cadence/common/metrics"
+	"github.com/uber/cadence/common/mocks"
+	p "github.com/uber/cadence/common/persistence"
+	"github.com/uber/cadence/common/service/dynamicconfig"
+	"go.uber.org/zap"
+)
+
+type (
+	BufferedReplicationTasksScavengerTestSuite struct {
+		suite.Suite
+		executionMgrFactory *mocks.ExecutionManagerFactory
+		executionMgr        *mocks.ExecutionManager
+		heartbeats          []int
+	}
+)
+
+func TestBufferedReplicationTasksScavengerTestSuite(t *testing.T) {
+	suite.Run(t, new(BufferedReplicationTasksScavengerTestSuite))
+}
+
+func (s *BufferedReplicationTasksScavengerTestSuite) SetupTest() {
+	s.heartbeats = nil
+	s.executionMgrFactory = &mocks.ExecutionManagerFactory{}
+	s.executionMgr = &mocks.ExecutionManager{}
+	s.executionMgrFactory.On("NewExecutionManager", mock.Anything).Return(s.executionMgr, nil)
+	s.executionMgr.On("Close").Return()
+}
+
+func (s *BufferedReplicationTasksScavengerTestSuite) TearDownTest() {
+	s.executionMgrFactory.AssertExpectations(s.T())
+	s.executionMgr.AssertExpectations(s.T())
+}
+
+func (s *BufferedReplicationTasksScavengerTestSuite) TestRun_Purge() {
+	s.executionMgr.On("ListBufferedReplicationTasks", mock.MatchedBy(func(request *p.ListBufferedReplicationTasksRequest) bool {
+		return len(request.NextPageToken) == 0
+	})).Return(&p.ListBufferedReplicationTasksResponse{
+		Executions:    []*p.BufferedReplicationTasksInfo{s.execution("wf-1", 2)},
+		NextPageToken: []byte("next"),
+	}, nil).Once()
+	s.executionMgr.On("ListBufferedReplicationTasks", mock.MatchedBy(func(request *p.ListBufferedReplicationTasksRequest) bool {
+		return len(request.NextPageToken) != 0
+	})).Return(&p.ListBufferedReplicationTasksResponse{
+		Executions: []*p.BufferedReplicationTasksInfo{s.execution("wf-2", 1)},
+	}, nil).Once()
+	s.executionMgr.On("ListBufferedReplicationTasks", mock.Anything).Return(&p.ListBufferedReplicationTasksResponse{}, nil)
+
+	purged := make(map[string]bool)
+	s.executionMgr.On("DeleteBufferedReplicationTasks", mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		request := args.Get(0).(*p.DeleteBufferedReplicationTasksRequest)
+		purged[request.WorkflowID] = true
+	}).Twice()
+
+	s.NoError(s.newScavenger(true).Run(context.Background()))
+	s.Equal(map[string]bool{"wf-1": true, "wf-2": true}, purged)
+	s.Equal([]int{0, 0, 1, 2, 3}, s.heartbeats)
+}
+
+func (s *BufferedReplicationTasksScavengerTestSuite) TestRun_PurgeDisabled() {
+	s.executionMgr.On("ListBufferedReplicationTasks", mock.Anything).Return(&p.ListBufferedReplicationTasksResponse{
+		Executions: []*p.BufferedReplicationTasksInfo{s.execution("wf-1", 2)},
+	}, nil).Times(testNumShards)
+
+	s.NoError(s.newScavenger(false).Run(context.Background()))
+	s.executionMgr.AssertNotCalled(s.T(), "DeleteBufferedReplicationTasks", mock.Anything)
+}
+
+func (s *BufferedReplicationTasksScavengerTestSuite) TestRun_ListError() {
+	s.executionMgr.On("ListBufferedReplicationTasks", mock.Anything).Return(nil, errors.New("persistence error")).Once()
+
+	s.Error(s.newScavenger(true).Run(context.Background()))
+}
+
+func (s *BufferedReplicationTasksScavengerTestSuite) newScavenger(purgeEnabled bool) *BufferedReplicationTasksScavenger {
+	zapLogger, err := zap.NewDevelopment()
+	s.Require().NoError(err)
+	return NewBufferedReplicationTasksScavenger(
+		s.executionMgrFactory,
+		testNumShards,
+		dynamicconfig.GetBoolPropertyFn(purgeEnabled),
+		func(shardID int) { s.heartbeats = append(s.heartbeats, shardID) },
+		metrics.NewClient(tally.NoopScope, metrics.Worker),
+		loggerimpl.NewLogger(zapLogger),
+	)
+}
+
+func (s *BufferedReplicationTasksScavengerTestSuite) execution(workflowID string, taskCount int) *p.BufferedReplicationTasksInfo {
+	return &p.BufferedReplicationTasksInfo{
+		DomainID:   "domain-1",
+		WorkflowID: workflowID,
+		RunID:      "run-id",
+		TaskCount:  taskCount,
+	}
+}
@@ -0,0 +1,178 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package executions
+
+import (
+	"context"
+
+	workflow "github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common/log"
+	"github.com/uber/cadence/common/log/tag"
+	"github.com/uber/cadence/common/metrics"
+	p "github.com/uber/cadence/common/persistence"
+	"github.com/uber/cadence/common/service/dynamicconfig"
+)
+
+type (
+	// CurrentExecutionsScavenger is the type that checks the current row of every workflow ID
+	// against the run it points to. A current row pointing to a missing run makes new runs fail
+	// with "already started" while the workflow cannot be found, such rows are deleted when
+	// fixing is enabled. A current row whose state disagrees with its run is only reported.
+	CurrentExecutionsScavenger struct {
+		executionMgrFactory p.ExecutionManagerFactory
+		numShards           int
+		fixEnabled          dynamicconfig.BoolPropertyFn
+		metricsClient       metrics.Client
+		logger              log.Logger
+	}
+)
+
+// NewCurrentExecutionsScavenger returns a new instance of current executions scavenger
+func NewCurrentExecutionsScavenger(
+	executionMgrFactory p.ExecutionManagerFactory,
+	numShards int,
+	fixEnabled dynamicconfig.BoolPropertyFn,
+	metricsClient metrics.Client,
+	logger log.Logger,
+) *CurrentExecutionsScavenger {
+	return &CurrentExecutionsScavenger{
+		executionMgrFactory: executionMgrFactory,
+		numShards:           numShards,
+		fixEnabled:          fixEnabled,
+		metricsClient:       metricsClient,
+		logger:              logger,
+	}
+}
+
+// Run scans every shard, reports the current rows disagreeing with their runs and
+// deletes the dangling ones unless fixing is disabled through dynamic config
+func (s *CurrentExecutionsScavenger) Run(ctx context.Context) error {
+	for shardID := 0; shardID < s.numShards; shardID++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := s.scavengeShard(ctx, shardID); err != nil {
+			s.logger.Error("failed to scavenge current executions", tag.ShardID(shardID), tag.Error(err))
+			return err
+		}
+	}
+	s.logger.Info("current executions scavenged", tag.Number(int64(s.numShards)))
+	return nil
+}
+
+func (s *CurrentExecutionsScavenger) scavengeShard(ctx context.Context, shardID int) error {
+	executionMgr, err := s.executionMgrFactory.NewExecutionManager(shardID)
+	if err != nil {
+		return err
+	}
+	defer executionMgr.Close()
+
+	var pageToken []byte
+	for {
+		resp, err := executionMgr.ListCurrentExecutions(&p.ListCurrentExecutionsRequest{
+			PageSize:      listPageSize,
+			NextPageToken: pageToken,
+		})
+		if err != nil {
+			return err
+		}
+		for _, current := range resp.Executions {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			if err := s.scavengeExecution(executionMgr, shardID, current); err != nil {
+				return err
+			}
+		}
+		if len(resp.NextPageToken) == 0 {
+			return nil
+		}
+		pageToken = resp.NextPageToken
+	}
+}
+
+func (s *CurrentExecutionsScavenger) scavengeExecution(
+	executionMgr p.ExecutionManager,
+	shardID int,
+	current *p.CurrentExecutionInfo,
+) error {
+
+	resp, err := executionMgr.GetWorkflowExecution(&p.GetWorkflowExecutionRequest{
+		DomainID: current.DomainID,
+		Execution: workflow.WorkflowExecution{
+			WorkflowId: &current.WorkflowID,
+			RunId:      &current.RunID,
+		},
+	})
+	switch err.(type) {
+	case nil:
+	case *workflow.EntityNotExistsError:
+		return s.deleteDanglingExecution(executionMgr, shardID, current)
+	default:
+		return err
+	}
+
+	run := resp.State.ExecutionInfo
+	if run.State == current.State && run.CloseStatus == current.CloseStatus {
+		return nil
+	}
+	s.logger.Warn("current execution state disagrees with its run",
+		tag.ShardID(shardID),
+		tag.WorkflowDomainID(current.DomainID),
+		tag.WorkflowID(current.WorkflowID),
+		tag.WorkflowRunID(current.RunID),
+		tag.WorkflowState(current.State),
+		tag.Value(run.State))
+	s.metricsClient.IncCounter(metrics.CurrentExecutionsScavengerScope, metrics.CurrentExecutionStateMismatchCount)
+	return nil
+}
+
+func (s *CurrentExecutionsScavenger) deleteDanglingExecution(
+	executionMgr p.ExecutionManager,
+	shardID int,
+	current *p.CurrentExecutionInfo,
+) error {
+
+	s.logger.Warn("current execution points to a missing run",
+		tag.ShardID(shardID),
+		tag.WorkflowDomainID(current.DomainID),
+		tag.WorkflowID(current.WorkflowID),
+		tag.WorkflowRunID(current.RunID))
+	s.metricsClient.IncCounter(metrics.CurrentExecutionsScavengerScope, metrics.DanglingCurrentExecutionsCount)
+	if !s.fixEnabled() {
+		return nil
+	}
+
+	// the delete is conditioned on the run ID, so a current row moved to a new run meanwhile is kept
+	resp, err := executionMgr.DeleteCurrentWorkflowExecution(&p.DeleteCurrentWorkflowExecutionRequest{
+		DomainID:   current.DomainID,
+		WorkflowID: current.WorkflowID,
+		RunID:      current.RunID,
+	})
+	if err != nil {
+		return err
+	}
+	if resp.Result != p.DeleteCurrentWorkflowExecutionResultDeleted {
+		return nil
+	}
+	s.metricsClient.IncCounter(metrics.CurrentExecutionsScavengerScope, metrics.DanglingCurrentExecutionsDeletedCount)
+	return nil
+}
@@ -0,0 +1,154 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package executions
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"github.com/uber-go/tally"
+	workflow "github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common/log/loggerimpl"
+	"github.com/uber/cadence/common/metrics"
+	"github.com/uber/cadence/common/mocks"
+	p "github.com/uber/cadence/common/persistence"
+	"github.com/uber/cadence/common/service/dynamicconfig"
+	"go.uber.org/zap"
+)
+
+type (
+	CurrentExecutionsScavengerTestSuite struct {
+		suite.Suite
+		executionMgrFactory *mocks.ExecutionManagerFactory
+		executionMgr        *mocks.ExecutionManager
+	}
+)
+
+func TestCurrentExecutionsScavengerTestSuite(t *testing.T) {
+	suite.Run(t, new(CurrentExecutionsScavengerTestSuite))
+}
+
+func (s *CurrentExecutionsScavengerTestSuite) SetupTest() {
+	s.executionMgrFactory = &mocks.ExecutionManagerFactory{}
+	s.executionMgr = &mocks.ExecutionManager{}
+	s.executionMgrFactory.On("NewExecutionManager", mock.Anything).Return(s.executionMgr, nil)
+	s.executionMgr.On("Close").Return()
+}
+
+func (s *CurrentExecutionsScavengerTestSuite) TearDownTest() {
+	s.executionMgrFactory.AssertExpectations(s.T())
+	s.executionMgr.AssertExpectations(s.T())
+}
+
+func (s *CurrentExecutionsScavengerTestSuite) TestRun_DeleteDangling() {
+	s.executionMgr.On("ListCurrentExecutions", mock.MatchedBy(func(request *p.ListCurrentExecutionsRequest) bool {
+		return len(request.NextPageToken) == 0
+	})).Return(&p.ListCurrentExecutionsResponse{
+		Executions:    []*p.CurrentExecutionInfo{s.current("wf-1", p.WorkflowStateRunning)},
+		NextPageToken: []byte("next"),
+	}, nil).Once()
+	s.executionMgr.On("ListCurrentExecutions", mock.MatchedBy(func(request *p.ListCurrentExecutionsRequest) bool {
+		return len(request.NextPageToken) != 0
+	})).Return(&p.ListCurrentExecutionsResponse{
+		Executions: []*p.CurrentExecutionInfo{s.current("wf-2", p.WorkflowStateRunning)},
+	}, nil).Once()
+	s.executionMgr.On("ListCurrentExecutions", mock.Anything).Return(&p.ListCurrentExecutionsResponse{}, nil)
+
+	s.executionMgr.On("GetWorkflowExecution", mock.MatchedBy(func(request *p.GetWorkflowExecutionRequest) bool {
+		return request.Execution.GetWorkflowId() == "wf-1"
+	})).Return(nil, &workflow.EntityNotExistsError{}).Once()
+	s.executionMgr.On("GetWorkflowExecution", mock.MatchedBy(func(request *p.GetWorkflowExecutionRequest) bool {
+		return request.Execution.GetWorkflowId() == "wf-2"
+	})).Return(s.run(p.WorkflowStateRunning), nil).Once()
+
+	deleted := make(map[string]bool)
+	s.executionMgr.On("DeleteCurrentWorkflowExecution", mock.Anything).Return(&p.DeleteCurrentWorkflowExecutionResponse{
+		Result: p.DeleteCurrentWorkflowExecutionResultDeleted,
+	}, nil).Run(func(args mock.Arguments) {
+		request := args.Get(0).(*p.DeleteCurrentWorkflowExecutionRequest)
+		deleted[request.WorkflowID] = true
+	}).Once()
+
+	s.NoError(s.newScavenger(true).Run(context.Background()))
+	s.Equal(map[string]bool{"wf-1": true}, deleted)
+}
+
+func (s *CurrentExecutionsScavengerTestSuite) TestRun_FixDisabled() {
+	s.executionMgr.On("ListCurrentExecutions", mock.Anything).Return(&p.ListCurrentExecutionsResponse{
+		Executions: []*p.CurrentExecutionInfo{s.current("wf-1", p.WorkflowStateRunning)},
+	}, nil).Times(testNumShards)
+	s.executionMgr.On("GetWorkflowExecution", mock.Anything).Return(nil, &workflow.EntityNotExistsError{}).Times(testNumShards)
+
+	s.NoError(s.newScavenger(false).Run(context.Background()))
+	s.executionMgr.AssertNotCalled(s.T(), "DeleteCurrentWorkflowExecution", mock.Anything)
+}
+
+func (s *CurrentExecutionsScavengerTestSuite) TestRun_StateMismatchReported() {
+	s.executionMgr.On("ListCurrentExecutions", mock.Anything).Return(&p.ListCurrentExecutionsResponse{
+		Executions: []*p.CurrentExecutionInfo{s.current("wf-1", p.WorkflowStateRunning)},
+	}, nil).Times(testNumShards)
+	s.executionMgr.On("GetWorkflowExecution", mock.Anything).Return(s.run(p.WorkflowStateCompleted), nil).Times(testNumShards)
+
+	s.NoError(s.newScavenger(true).Run(context.Background()))
+	s.executionMgr.AssertNotCalled(s.T(), "DeleteCurrentWorkflowExecution", mock.Anything)
+}
+
+func (s *CurrentExecutionsScavengerTestSuite) TestRun_GetError() {
+	s.executionMgr.On("ListCurrentExecutions", mock.Anything).Return(&p.ListCurrentExecutionsResponse{
+		Executions: []*p.CurrentExecutionInfo{s.current("wf-1", p.WorkflowStateRunning)},
+	}, nil).Once()
+	s.executionMgr.On("GetWorkflowExecution", mock.Anything).Return(nil, errors.New("persistence error")).Once()
+
+	s.Error(s.newScavenger(true).Run(context.Background()))
+	s.executionMgr.AssertNotCalled(s.T(), "DeleteCurrentWorkflowExecution", mock.Anything)
+}
+
+func (s *CurrentExecutionsScavengerTestSuite) newScavenger(fixEnabled bool) *CurrentExecutionsScavenger {
+	zapLogger, err := zap.NewDevelopment()
+	s.Require().NoError(err)
+	return NewCurrentExecutionsScavenger(
+		s.executionMgrFactory,
+		testNumShards,
+		dynamicconfig.GetBoolPropertyFn(fixEnabled),
+		metrics.NewClient(tally.NoopScope, metrics.Worker),
+		loggerimpl.NewLogger(zapLogger),
+	)
+}
+
+func (s *CurrentExecutionsScavengerTestSuite) current(workflowID string, state int) *p.CurrentExecutionInfo {
+	return &p.CurrentExecutionInfo{
+		DomainID:   "domain-1",
+		WorkflowID: workflowID,
+		RunID:      "run-id",
+		State:      state,
+	}
+}
+
+func (s *CurrentExecutionsScavengerTestSuite) run(state int) *p.GetWorkflowExecutionResponse {
+	return &p.GetWorkflowExecutionResponse{
+		State: &p.WorkflowMutableState{
+			ExecutionInfo: &p.WorkflowExecutionInfo{State: state},
+		},
+	}
+}
@@ -0,0 +1,195 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package executions
+
+import (
+	"context"
+	"time"
+
+	h "github.com/uber/cadence/.gen/go/history"
+	"github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/client/history"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/log"
+	"github.com/uber/cadence/common/log/tag"
+	p "github.com/uber/cadence/common/persistence"
+	"github.com/uber/cadence/common/service/dynamicconfig"
+)
+
+type (
+	// Reconciler is the type that recounts the open executions of the domains from visibility
+	// and reconciles the approximate counters kept by the history shards
+	Reconciler struct {
+		domainDB      p.MetadataManager
+		visibilityDB  p.VisibilityManager
+		historyClient history.Client
+		numShards     int
+		enabled       dynamicconfig.BoolPropertyFnWithDomainFilter
+		logger        log.Logger
+	}
+)
+
+var (
+	listPageSize = 1000
+)
+
+// NewReconciler returns a new instance of open execution counts reconciler
+func NewReconciler(
+	domainDB p.MetadataManager,
+	visibilityDB p.VisibilityManager,
+	historyClient history.Client,
+	numShards int,
+	enabled dynamicconfig.BoolPropertyFnWithDomainFilter,
+	logger log.Logger,
+) *Reconciler {
+	return &Reconciler{
+		domainDB:      domainDB,
+		visibilityDB:  visibilityDB,
+		historyClient: historyClient,
+		numShards:     numShards,
+		enabled:       enabled,
+		logger:        logger,
+	}
+}
+
+// Run counts the open executions of the domains it is enabled for and reconciles the counters of every shard.
+// The shards track the changes of their counters from the start of the scan, and add them to the counts, so
+// the executions opened or closed while the scan runs are not lost
+func (r *Reconciler) Run(ctx context.Context) error {
+	scanStartTime := time.Now()
+	scanned := make(map[int]bool, r.numShards)
+	for shardID := 0; shardID < r.numShards; shardID++ {
+		err := r.historyClient.ReconcileDomainOpenExecutionCounts(ctx, &h.ReconcileDomainOpenExecutionCountsRequest{
+			ShardID:   common.Int32Ptr(int32(shardID)),
+			StartScan: common.BoolPtr(true),
+		})
+		if err != nil {
+			if ctx.Err() != nil {
+				return err
+			}
+			r.logger.Warn("failed to start open execution counts scan", tag.ShardID(shardID), tag.Error(err))
+			continue
+		}
+		scanned[shardID] = true
+	}
+
+	counts, err := r.countOpenExecutions(ctx, scanStartTime.UnixNano())
+	if err != nil {
+		return err
+	}
+	reconciled := 0
+	for shardID := 0; shardID < r.numShards; shardID++ {
+		if !scanned[shardID] {
+			continue
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		err := r.historyClient.ReconcileDomainOpenExecutionCounts(ctx, &h.ReconcileDomainOpenExecutionCountsRequest{
+			ShardID:                   common.Int32Ptr(int32(shardID)),
+			DomainOpenExecutionCounts: counts[shardID],
+		})
+		if err != nil {
+			if _, ok := err.(*shared.BadRequestError); ok {
+				// the shard was reloaded during the scan, its counters are reconciled by the next run
+				r.logger.Warn("open execution counts scan lost by the shard", tag.ShardID(shardID), tag.Error(err))
+				continue
+			}
+			r.logger.Error("failed to reconcile open execution counts", tag.ShardID(shardID), tag.Error(err))
+			return err
+		}
+		reconciled++
+	}
+	r.logger.Info("open execution counts reconciled", tag.Number(int64(reconciled)))
+	return nil
+}
+
+// countOpenExecutions returns the open execution counts by shardID and domainID, every shard gets
+// the counts of all the scanned domains, zero included, so that their stale counters are reset
+func (r *Reconciler) countOpenExecutions(ctx context.Context, latestStartTime int64) (map[int]map[string]int64, error) {
+	counts := make(map[int]map[string]int64, r.numShards)
+	for shardID := 0; shardID < r.numShards; shardID++ {
+		counts[shardID] = make(map[string]int64)
+	}
+
+	var domainPageToken []byte
+	for {
+		domains, err := r.domainDB.ListDomains(&p.ListDomainsRequest{
+			PageSize:      listPageSize,
+			NextPageToken: domainPageToken,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, domain := range domains.Domains {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+			if !r.enabled(domain.Info.Name) {
+				continue
+			}
+			for shardID := range counts {
+				counts[shardID][domain.Info.ID] = 0
+			}
+			if err := r.countDomainOpenExecutions(domain.Info.ID, domain.Info.Name, latestStartTime, counts); err != nil {
+				r.logger.Error("failed to count open executions", tag.WorkflowDomainName(domain.Info.Name), tag.Error(err))
+				return nil, err
+			}
+		}
+		if len(domains.NextPageToken) == 0 {
+			return counts, nil
+		}
+		domainPageToken = domains.NextPageToken
+	}
+}
+
+// countDomainOpenExecutions counts the open executions of the domain started before the scan, the
+// executions started since then are counted by the shards
+func (r *Reconciler) countDomainOpenExecutions(
+	domainID string,
+	domainName string,
+	latestStartTime int64,
+	counts map[int]map[string]int64,
+) error {
+
+	var pageToken []byte
+	for {
+		resp, err := r.visibilityDB.ListOpenWorkflowExecutions(&p.ListWorkflowExecutionsRequest{
+			DomainUUID:        domainID,
+			Domain:            domainName,
+			EarliestStartTime: 0,
+			LatestStartTime:   latestStartTime,
+			PageSize:          listPageSize,
+			NextPageToken:     pageToken,
+		})
+		if err != nil {
+			return err
+		}
+		for _, execution := range resp.Executions {
+			shardID := common.WorkflowIDToHistoryShard(execution.Execution.GetWorkflowId(), r.numShards)
+			counts[shardID][domainID]++
+		}
+		if len(resp.NextPageToken) == 0 {
+			return nil
+		}
+		pageToken = resp.NextPageToken
+	}
+}
@@ -0,0 +1,225 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package executions
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	h "github.com/uber/cadence/.gen/go/history"
+	"github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/log/loggerimpl"
+	"github.com/uber/cadence/common/mocks"
+	p "github.com/uber/cadence/common/persistence"
+	"go.uber.org/zap"
+)
+
+type (
+	ReconcilerTestSuite struct {
+		suite.Suite
+		domainDB      *mocks.MetadataManager
+		visibilityDB  *mocks.VisibilityManager
+		historyClient *mocks.HistoryClient
+		disabled      map[string]bool
+		reconciler    *Reconciler
+	}
+)
+
+const testNumShards = 4
+
+func TestReconcilerTestSuite(t *testing.T) {
+	suite.Run(t, new(ReconcilerTestSuite))
+}
+
+func (s *ReconcilerTestSuite) SetupTest() {
+	s.domainDB = &mocks.MetadataManager{}
+	s.visibilityDB = &mocks.VisibilityManager{}
+	s.historyClient = &mocks.HistoryClient{}
+	zapLogger, err := zap.NewDevelopment()
+	s.Require().NoError(err)
+	s.disabled = make(map[string]bool)
+	enabled := func(domain string) bool { return !s.disabled[domain] }
+	s.reconciler = NewReconciler(s.domainDB, s.visibilityDB, s.historyClient, testNumShards, enabled, loggerimpl.NewLogger(zapLogger))
+}
+
+func (s *ReconcilerTestSuite) TearDownTest() {
+	s.domainDB.AssertExpectations(s.T())
+	s.visibilityDB.AssertExpectations(s.T())
+	s.historyClient.AssertExpectations(s.T())
+}
+
+func (s *ReconcilerTestSuite) TestRun() {
+	s.domainDB.On("ListDomains", mock.Anything).Return(&p.ListDomainsResponse{
+		Domains: []*p.GetDomainResponse{
+			{Info: &p.DomainInfo{ID: "domain-1", Name: "domain-1-name"}},
+			{Info: &p.DomainInfo{ID: "domain-2", Name: "domain-2-name"}},
+		},
+	}, nil).Once()
+
+	workflowIDs := []string{"wf-1", "wf-2", "wf-3"}
+	s.visibilityDB.On("ListOpenWorkflowExecutions", mock.MatchedBy(func(request *p.ListWorkflowExecutionsRequest) bool {
+		return request.DomainUUID == "domain-1" && len(request.NextPageToken) == 0
+	})).Return(&p.ListWorkflowExecutionsResponse{
+		Executions:    s.openExecutions(workflowIDs[:2]),
+		NextPageToken: []byte("next"),
+	}, nil).Once()
+	s.visibilityDB.On("ListOpenWorkflowExecutions", mock.MatchedBy(func(request *p.ListWorkflowExecutionsRequest) bool {
+		return request.DomainUUID == "domain-1" && len(request.NextPageToken) != 0
+	})).Return(&p.ListWorkflowExecutionsResponse{
+		Executions: s.openExecutions(workflowIDs[2:]),
+	}, nil).Once()
+	s.visibilityDB.On("ListOpenWorkflowExecutions", mock.MatchedBy(func(request *p.ListWorkflowExecutionsRequest) bool {
+		return request.DomainUUID == "domain-2"
+	})).Return(&p.ListWorkflowExecutionsResponse{}, nil).Once()
+
+	expected := make(map[int32]map[string]int64)
+	for shardID := int32(0); shardID < testNumShards; shardID++ {
+		expected[shardID] = map[string]int64{"domain-1": 0, "domain-2": 0}
+	}
+	for _, workflowID := range workflowIDs {
+		shardID := int32(common.WorkflowIDToHistoryShard(workflowID, testNumShards))
+		expected[shardID]["domain-1"]++
+	}
+	started := s.expectStartScan(nil)
+	reconciled := s.expectReconcile(nil)
+
+	s.NoError(s.reconciler.Run(context.Background()))
+	s.Len(started, testNumShards)
+	s.Equal(expected, reconciled)
+}
+
+func (s *ReconcilerTestSuite) TestRun_DomainDisabled() {
+	s.domainDB.On("ListDomains", mock.Anything).Return(&p.ListDomainsResponse{
+		Domains: []*p.GetDomainResponse{
+			{Info: &p.DomainInfo{ID: "domain-1", Name: "domain-1-name"}},
+			{Info: &p.DomainInfo{ID: "domain-2", Name: "domain-2-name"}},
+		},
+	}, nil).Once()
+	s.disabled["domain-2-name"] = true
+	s.visibilityDB.On("ListOpenWorkflowExecutions", mock.MatchedBy(func(request *p.ListWorkflowExecutionsRequest) bool {
+		return request.DomainUUID == "domain-1"
+	})).Return(&p.ListWorkflowExecutionsResponse{}, nil).Once()
+
+	s.expectStartScan(nil)
+	reconciled := s.expectReconcile(nil)
+
+	s.NoError(s.reconciler.Run(context.Background()))
+	s.Len(reconciled, testNumShards)
+	for _, counts := range reconciled {
+		s.Equal(map[string]int64{"domain-1": 0}, counts)
+	}
+}
+
+func (s *ReconcilerTestSuite) TestRun_ShardScanLost() {
+	s.domainDB.On("ListDomains", mock.Anything).Return(&p.ListDomainsResponse{}, nil).Once()
+
+	s.expectStartScan(func(shardID int32) error {
+		if shardID == 1 {
+			return &shared.ServiceBusyError{}
+		}
+		return nil
+	})
+	reconciled := s.expectReconcile(func(shardID int32) error {
+		if shardID == 2 {
+			return &shared.BadRequestError{}
+		}
+		return nil
+	})
+
+	s.NoError(s.reconciler.Run(context.Background()))
+	s.Len(reconciled, testNumShards-2)
+	s.NotContains(reconciled, int32(1))
+	s.NotContains(reconciled, int32(2))
+}
+
+func (s *ReconcilerTestSuite) TestRun_ReconcileError() {
+	s.domainDB.On("ListDomains", mock.Anything).Return(&p.ListDomainsResponse{}, nil).Once()
+
+	s.expectStartScan(nil)
+	s.historyClient.On("ReconcileDomainOpenExecutionCounts", mock.Anything, mock.MatchedBy(func(request *h.ReconcileDomainOpenExecutionCountsRequest) bool {
+		return !request.GetStartScan()
+	})).Return(&shared.InternalServiceError{}).Once()
+
+	s.Error(s.reconciler.Run(context.Background()))
+}
+
+func (s *ReconcilerTestSuite) TestRun_VisibilityError() {
+	s.domainDB.On("ListDomains", mock.Anything).Return(&p.ListDomainsResponse{
+		Domains: []*p.GetDomainResponse{
+			{Info: &p.DomainInfo{ID: "domain-1", Name: "domain-1-name"}},
+		},
+	}, nil).Once()
+	s.visibilityDB.On("ListOpenWorkflowExecutions", mock.Anything).Return(nil, errors.New("visibility error")).Once()
+	s.expectStartScan(nil)
+
+	s.Error(s.reconciler.Run(context.Background()))
+}
+
+// expectStartScan expects the scan to be started on every shard, and returns the shards it was started on
+func (s *ReconcilerTestSuite) expectStartScan(errFn func(shardID int32) error) map[int32]bool {
+	started := make(map[int32]bool)
+	s.historyClient.On("ReconcileDomainOpenExecutionCounts", mock.Anything, mock.MatchedBy(func(request *h.ReconcileDomainOpenExecutionCountsRequest) bool {
+		return request.GetStartScan()
+	})).Return(func(ctx context.Context, request *h.ReconcileDomainOpenExecutionCountsRequest) error {
+		if errFn != nil {
+			if err := errFn(request.GetShardID()); err != nil {
+				return err
+			}
+		}
+		started[request.GetShardID()] = true
+		return nil
+	}).Times(testNumShards)
+	return started
+}
+
+// expectReconcile expects the counts of the scan, and returns the counts reconciled by shard
+func (s *ReconcilerTestSuite) expectReconcile(errFn func(shardID int32) error) map[int32]map[string]int64 {
+	reconciled := make(map[int32]map[string]int64)
+	s.historyClient.On("ReconcileDomainOpenExecutionCounts", mock.Anything, mock.MatchedBy(func(request *h.ReconcileDomainOpenExecutionCountsRequest) bool {
+		return !request.GetStartScan()
+	})).Return(func(ctx context.Context, request *h.ReconcileDomainOpenExecutionCountsRequest) error {
+		if errFn != nil {
+			if err := errFn(request.GetShardID()); err != nil {
+				return err
+			}
+		}
+		reconciled[request.GetShardID()] = request.DomainOpenExecutionCounts
+		return nil
+	})
+	return reconciled
+}
+
+func (s *ReconcilerTestSuite) openExecutions(workflowIDs []string) []*shared.WorkflowExecutionInfo {
+	executions := make([]*shared.WorkflowExecutionInfo, 0, len(workflowIDs))
+	for _, workflowID := range workflowIDs {
+		executions = append(executions, &shared.WorkflowExecutionInfo{
+			Execution: &shared.WorkflowExecution{
+				WorkflowId: common.StringPtr(workflowID),
+				RunId:      common.StringPtr("run-id"),
+			},
+		})
+	}
+	return executions
+}
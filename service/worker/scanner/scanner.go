@@ -25,6 +25,7 @@ import (
 	"time"
 
 	"github.com/uber-go/tally"
+	"github.com/uber/cadence/client/history"
 	"github.com/uber/cadence/common"
 	"github.com/uber/cadence/common/backoff"
 	"github.com/uber/cadence/common/cluster"
@@ -47,6 +48,12 @@ type (
 	Config struct {
 		// PersistenceMaxQPS the max rate of calls to persistence
 		PersistenceMaxQPS dynamicconfig.IntPropertyFn
+		// BufferedReplicationTasksPurgeEnabled is whether stale buffered replication tasks are purged or only reported
+		BufferedReplicationTasksPurgeEnabled dynamicconfig.BoolPropertyFn
+		// CurrentExecutionsFixEnabled is whether dangling current rows are deleted or only reported
+		CurrentExecutionsFixEnabled dynamicconfig.BoolPropertyFn
+		// OpenExecutionCountsReconcileEnabled is whether the open executions of a domain are scanned to reconcile its counters
+		OpenExecutionCountsReconcileEnabled dynamicconfig.BoolPropertyFnWithDomainFilter
 		// Persistence contains the persistence configuration
 		Persistence *config.Persistence
 		// ClusterMetadata contains the metadata for this cluster
@@ -60,6 +67,8 @@ type (
 		Config Config
 		// SDKClient is an instance of cadence sdk client
 		SDKClient workflowserviceclient.Interface
+		// HistoryClient is an instance of history service client
+		HistoryClient history.Client
 		// MetricsClient is an instance of metrics object for emitting stats
 		MetricsClient metrics.Client
 		Logger        log.Logger
@@ -70,14 +79,17 @@ type (
 	// scannerContext is the context object that get's
 	// passed around within the scanner workflows / activities
 	scannerContext struct {
-		taskDB        p.TaskManager
-		domainDB      p.MetadataManager
-		cfg           Config
-		sdkClient     workflowserviceclient.Interface
-		metricsClient metrics.Client
-		tallyScope    tally.Scope
-		logger        log.Logger
-		zapLogger     *zap.Logger
+		taskDB              p.TaskManager
+		domainDB            p.MetadataManager
+		visibilityDB        p.VisibilityManager
+		executionMgrFactory p.ExecutionManagerFactory
+		cfg                 Config
+		sdkClient           workflowserviceclient.Interface
+		historyClient       history.Client
+		metricsClient       metrics.Client
+		tallyScope          tally.Scope
+		logger              log.Logger
+		zapLogger           *zap.Logger
 	}
 
 	// Scanner is the background sub-system that does full scans
@@ -104,6 +116,7 @@ func New(params *BootstrapParams) *Scanner {
 		context: scannerContext{
 			cfg:           cfg,
 			sdkClient:     params.SDKClient,
+			historyClient: params.HistoryClient,
 			metricsClient: params.MetricsClient,
 			tallyScope:    params.TallyScope,
 			zapLogger:     zapLogger,
@@ -124,35 +137,38 @@ func (s *Scanner) Start() error {
 		MaxConcurrentDecisionTaskExecutionSize: maxConcurrentDecisionTaskExecutionSize,
 		BackgroundActivityContext:              context.WithValue(context.Background(), scannerContextKey, s.context),
 	}
-	go s.startWorkflowWithRetry()
+	go s.startWorkflowWithRetry(tlScannerWFStartOptions, tlScannerWFTypeName)
+	go s.startWorkflowWithRetry(executionsReconcilerWFStartOptions, executionsReconcilerWFTypeName)
+	go s.startWorkflowWithRetry(bufferedReplicationTasksScavengerWFStartOptions, bufferedReplicationTasksScavengerWFTypeName)
+	go s.startWorkflowWithRetry(currentExecutionsScavengerWFStartOptions, currentExecutionsScavengerWFTypeName)
 	worker := worker.New(s.context.sdkClient, common.SystemLocalDomainName, tlScannerTaskListName, workerOpts)
 	return worker.Start()
 }
 
-func (s *Scanner) startWorkflowWithRetry() error {
+func (s *Scanner) startWorkflowWithRetry(options cclient.StartWorkflowOptions, workflowType string) error {
 	client := cclient.NewClient(s.context.sdkClient, common.SystemLocalDomainName, &cclient.Options{})
 	policy := backoff.NewExponentialRetryPolicy(time.Second)
 	policy.SetMaximumInterval(time.Minute)
 	policy.SetExpirationInterval(backoff.NoInterval)
 	return backoff.Retry(func() error {
-		return s.startWorkflow(client)
+		return s.startWorkflow(client, options, workflowType)
 	}, policy, func(err error) bool {
 		return true
 	})
 }
 
-func (s *Scanner) startWorkflow(client cclient.Client) error {
+func (s *Scanner) startWorkflow(client cclient.Client, options cclient.StartWorkflowOptions, workflowType string) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
-	_, err := client.StartWorkflow(ctx, tlScannerWFStartOptions, tlScannerWFTypeName)
+	_, err := client.StartWorkflow(ctx, options, workflowType)
 	cancel()
 	if err != nil {
 		if _, ok := err.(*shared.WorkflowExecutionAlreadyStartedError); ok {
 			return nil
 		}
-		s.context.logger.Error("error starting scanner workflow", tag.Error(err))
+		s.context.logger.Error("error starting scanner workflow", tag.WorkflowType(workflowType), tag.Error(err))
 		return err
 	}
-	s.context.logger.Info("Scanner workflow successfully started")
+	s.context.logger.Info("Scanner workflow successfully started", tag.WorkflowType(workflowType))
 	return nil
 }
 
@@ -167,7 +183,13 @@ func (s *Scanner) buildContext() error {
 	if err != nil {
 		return err
 	}
+	visibilityDB, err := pFactory.NewVisibilityManager()
+	if err != nil {
+		return err
+	}
 	s.context.taskDB = taskDB
 	s.context.domainDB = domainDB
+	s.context.visibilityDB = visibilityDB
+	s.context.executionMgrFactory = pFactory
 	return nil
 }
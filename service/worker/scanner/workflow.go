@@ -25,6 +25,7 @@ import (
 	"time"
 
 	"github.com/uber/cadence/common/log/tag"
+	"github.com/uber/cadence/service/worker/scanner/executions"
 	"github.com/uber/cadence/service/worker/scanner/tasklist"
 	"go.uber.org/cadence"
 	"go.uber.org/cadence/activity"
@@ -45,6 +46,18 @@ const (
 	tlScannerWFTypeName           = "cadence-sys-tl-scanner-workflow"
 	tlScannerTaskListName         = "cadence-sys-tl-scanner-tasklist-0"
 	taskListScavengerActivityName = "cadence-sys-tl-scanner-scvg-activity"
+
+	executionsReconcilerWFID         = "cadence-sys-executions-reconciler"
+	executionsReconcilerWFTypeName   = "cadence-sys-executions-reconciler-workflow"
+	executionsReconcilerActivityName = "cadence-sys-executions-reconciler-activity"
+
+	bufferedReplicationTasksScavengerWFID         = "cadence-sys-buffered-replication-tasks-scavenger"
+	bufferedReplicationTasksScavengerWFTypeName   = "cadence-sys-buffered-replication-tasks-scavenger-workflow"
+	bufferedReplicationTasksScavengerActivityName = "cadence-sys-buffered-replication-tasks-scavenger-activity"
+
+	currentExecutionsScavengerWFID         = "cadence-sys-current-executions-scavenger"
+	currentExecutionsScavengerWFTypeName   = "cadence-sys-current-executions-scavenger-workflow"
+	currentExecutionsScavengerActivityName = "cadence-sys-current-executions-scavenger-activity"
 )
 
 var (
@@ -63,11 +76,38 @@ var (
 		WorkflowIDReusePolicy:        cclient.WorkflowIDReusePolicyAllowDuplicate,
 		CronSchedule:                 "0 */12 * * *",
 	}
+	executionsReconcilerWFStartOptions = cclient.StartWorkflowOptions{
+		ID:                           executionsReconcilerWFID,
+		TaskList:                     tlScannerTaskListName,
+		ExecutionStartToCloseTimeout: 24 * time.Hour,
+		WorkflowIDReusePolicy:        cclient.WorkflowIDReusePolicyAllowDuplicate,
+		CronSchedule:                 "0 3 * * *",
+	}
+	bufferedReplicationTasksScavengerWFStartOptions = cclient.StartWorkflowOptions{
+		ID:                           bufferedReplicationTasksScavengerWFID,
+		TaskList:                     tlScannerTaskListName,
+		ExecutionStartToCloseTimeout: 5 * 24 * time.Hour,
+		WorkflowIDReusePolicy:        cclient.WorkflowIDReusePolicyAllowDuplicate,
+		CronSchedule:                 "0 5 * * 0",
+	}
+	currentExecutionsScavengerWFStartOptions = cclient.StartWorkflowOptions{
+		ID:                           currentExecutionsScavengerWFID,
+		TaskList:                     tlScannerTaskListName,
+		ExecutionStartToCloseTimeout: 5 * 24 * time.Hour,
+		WorkflowIDReusePolicy:        cclient.WorkflowIDReusePolicyAllowDuplicate,
+		CronSchedule:                 "0 5 * * 3",
+	}
 )
 
 func init() {
 	workflow.RegisterWithOptions(TaskListScannerWorkflow, workflow.RegisterOptions{Name: tlScannerWFTypeName})
 	activity.RegisterWithOptions(TaskListScavengerActivity, activity.RegisterOptions{Name: taskListScavengerActivityName})
+	workflow.RegisterWithOptions(ExecutionsReconcilerWorkflow, workflow.RegisterOptions{Name: executionsReconcilerWFTypeName})
+	activity.RegisterWithOptions(ExecutionsReconcilerActivity, activity.RegisterOptions{Name: executionsReconcilerActivityName})
+	workflow.RegisterWithOptions(BufferedReplicationTasksScavengerWorkflow, workflow.RegisterOptions{Name: bufferedReplicationTasksScavengerWFTypeName})
+	activity.RegisterWithOptions(BufferedReplicationTasksScavengerActivity, activity.RegisterOptions{Name: bufferedReplicationTasksScavengerActivityName})
+	workflow.RegisterWithOptions(CurrentExecutionsScavengerWorkflow, workflow.RegisterOptions{Name: currentExecutionsScavengerWFTypeName})
+	activity.RegisterWithOptions(CurrentExecutionsScavengerActivity, activity.RegisterOptions{Name: currentExecutionsScavengerActivityName})
 }
 
 // TaskListScannerWorkflow is the workflow that runs the task-list scanner background daemon
@@ -99,3 +139,86 @@ func TaskListScavengerActivity(aCtx context.Context) error {
 	}
 	return nil
 }
+
+// ExecutionsReconcilerWorkflow is the workflow that periodically reconciles the open execution counts kept by history
+func ExecutionsReconcilerWorkflow(ctx workflow.Context) error {
+	opts := workflow.ActivityOptions{
+		ScheduleToStartTimeout: 5 * time.Minute,
+		StartToCloseTimeout:    12 * time.Hour,
+		RetryPolicy:            &tlScavengerActivityRetryPolicy,
+	}
+	future := workflow.ExecuteActivity(workflow.WithActivityOptions(ctx, opts), executionsReconcilerActivityName)
+	return future.Get(ctx, nil)
+}
+
+// ExecutionsReconcilerActivity is the activity that recounts open executions from visibility
+// and reconciles the approximate counters of the history shards
+func ExecutionsReconcilerActivity(aCtx context.Context) error {
+	ctx := aCtx.Value(scannerContextKey).(scannerContext)
+	reconciler := executions.NewReconciler(
+		ctx.domainDB,
+		ctx.visibilityDB,
+		ctx.historyClient,
+		ctx.cfg.Persistence.NumHistoryShards,
+		ctx.cfg.OpenExecutionCountsReconcileEnabled,
+		ctx.logger,
+	)
+	ctx.logger.Info("Starting open execution counts reconciler")
+	return reconciler.Run(aCtx)
+}
+
+// BufferedReplicationTasksScavengerWorkflow is the workflow that periodically reports and purges
+// the stale buffered replication tasks left in the execution store
+func BufferedReplicationTasksScavengerWorkflow(ctx workflow.Context) error {
+	opts := workflow.ActivityOptions{
+		ScheduleToStartTimeout: 5 * time.Minute,
+		StartToCloseTimeout:    4 * 24 * time.Hour,
+		HeartbeatTimeout:       5 * time.Minute,
+		RetryPolicy:            &tlScavengerActivityRetryPolicy,
+	}
+	future := workflow.ExecuteActivity(workflow.WithActivityOptions(ctx, opts), bufferedReplicationTasksScavengerActivityName)
+	return future.Get(ctx, nil)
+}
+
+// BufferedReplicationTasksScavengerActivity is the activity that scans every shard for
+// buffered replication tasks and purges them
+func BufferedReplicationTasksScavengerActivity(aCtx context.Context) error {
+	ctx := aCtx.Value(scannerContextKey).(scannerContext)
+	scavenger := executions.NewBufferedReplicationTasksScavenger(
+		ctx.executionMgrFactory,
+		ctx.cfg.Persistence.NumHistoryShards,
+		ctx.cfg.BufferedReplicationTasksPurgeEnabled,
+		func(shardID int) { activity.RecordHeartbeat(aCtx, shardID) },
+		ctx.metricsClient,
+		ctx.logger,
+	)
+	ctx.logger.Info("Starting buffered replication tasks scavenger")
+	return scavenger.Run(aCtx)
+}
+
+// CurrentExecutionsScavengerWorkflow is the workflow that periodically checks the current rows of
+// the execution store against the runs they point to
+func CurrentExecutionsScavengerWorkflow(ctx workflow.Context) error {
+	opts := workflow.ActivityOptions{
+		ScheduleToStartTimeout: 5 * time.Minute,
+		StartToCloseTimeout:    4 * 24 * time.Hour,
+		RetryPolicy:            &tlScavengerActivityRetryPolicy,
+	}
+	future := workflow.ExecuteActivity(workflow.WithActivityOptions(ctx, opts), currentExecutionsScavengerActivityName)
+	return future.Get(ctx, nil)
+}
+
+// CurrentExecutionsScavengerActivity is the activity that scans every shard for current rows
+// disagreeing with their runs
+func CurrentExecutionsScavengerActivity(aCtx context.Context) error {
+	ctx := aCtx.Value(scannerContextKey).(scannerContext)
+	scavenger := executions.NewCurrentExecutionsScavenger(
+		ctx.executionMgrFactory,
+		ctx.cfg.Persistence.NumHistoryShards,
+		ctx.cfg.CurrentExecutionsFixEnabled,
+		ctx.metricsClient,
+		ctx.logger,
+	)
+	ctx.logger.Info("Starting current executions scavenger")
+	return scavenger.Run(aCtx)
+}
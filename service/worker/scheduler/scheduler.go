@@ -0,0 +1,499 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/pborman/uuid"
+	h "github.com/uber/cadence/.gen/go/history"
+	"github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/client/history"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/backoff"
+	"github.com/uber/cadence/common/cache"
+	"github.com/uber/cadence/common/clock"
+	"github.com/uber/cadence/common/log"
+	"github.com/uber/cadence/common/log/tag"
+	"github.com/uber/cadence/common/membership"
+	"github.com/uber/cadence/common/metrics"
+	"github.com/uber/cadence/common/persistence"
+	"github.com/uber/cadence/common/service/dynamicconfig"
+)
+
+type (
+	// Config defines the configuration for the scheduler
+	Config struct {
+		// ScanInterval is the interval at which the schedules are checked
+		ScanInterval dynamicconfig.DurationPropertyFn
+	}
+
+	// BootstrapParams contains the set of params needed to bootstrap
+	// the scheduler sub-system
+	BootstrapParams struct {
+		// Config contains the configuration for scheduler
+		Config Config
+		// ScheduleManager is the store of the schedules
+		ScheduleManager persistence.ScheduleManager
+		// HistoryClient is used to start the workflows of the schedules
+		HistoryClient history.Client
+		// DomainCache is used to resolve the domains of the schedules
+		DomainCache cache.DomainCache
+		// ServiceResolver resolves the worker host owning a schedule, each host only fires the schedules it owns
+		ServiceResolver membership.ServiceResolver
+		// HostInfo is the info of this worker host
+		HostInfo *membership.HostInfo
+		// MetricsClient is an instance of metrics object for emitting stats
+		MetricsClient metrics.Client
+		Logger        log.Logger
+	}
+
+	// Scheduler is the background sub-system that fires the schedules persisted in the schedules
+	// table, starting their target workflows according to their overlap policy and catch-up window
+	Scheduler struct {
+		status        int32
+		cfg           Config
+		scheduleMgr   persistence.ScheduleManager
+		historyClient history.Client
+		domainCache   cache.DomainCache
+		resolver      membership.ServiceResolver
+		hostInfo      *membership.HostInfo
+		metricsClient metrics.Client
+		logger        log.Logger
+		timeSource    clock.TimeSource
+		stopC         chan struct{}
+		stopWG        sync.WaitGroup
+	}
+)
+
+const (
+	scanPageSize = 100
+	rpcTimeout   = 10 * time.Second
+	// maxFiresPerScan bounds the number of workflows started for a schedule in one scan so a large
+	// backfill or catch-up does not hold up the other schedules, the remaining ones are started on
+	// the next scans
+	maxFiresPerScan = 10
+	// maxMissedFireTimes bounds the number of missed fire times iterated over to find the last one
+	maxMissedFireTimes = 1000
+	identity           = "cadence-scheduler"
+)
+
+// New returns a new instance of the scheduler daemon
+func New(params *BootstrapParams) *Scheduler {
+	return &Scheduler{
+		status:        common.DaemonStatusInitialized,
+		cfg:           params.Config,
+		scheduleMgr:   params.ScheduleManager,
+		historyClient: params.HistoryClient,
+		domainCache:   params.DomainCache,
+		resolver:      params.ServiceResolver,
+		hostInfo:      params.HostInfo,
+		metricsClient: params.MetricsClient,
+		logger:        params.Logger.WithTags(tag.ComponentScheduler),
+		timeSource:    clock.NewRealTimeSource(),
+		stopC:         make(chan struct{}),
+	}
+}
+
+// Start starts the scheduler
+func (s *Scheduler) Start() error {
+	if !atomic.CompareAndSwapInt32(&s.status, common.DaemonStatusInitialized, common.DaemonStatusStarted) {
+		return nil
+	}
+	s.stopWG.Add(1)
+	go s.scanLoop()
+	s.logger.Info("scheduler started")
+	return nil
+}
+
+// Stop stops the scheduler
+func (s *Scheduler) Stop() {
+	if !atomic.CompareAndSwapInt32(&s.status, common.DaemonStatusStarted, common.DaemonStatusStopped) {
+		return
+	}
+	close(s.stopC)
+	s.stopWG.Wait()
+	s.logger.Info("scheduler stopped")
+}
+
+func (s *Scheduler) scanLoop() {
+	defer s.stopWG.Done()
+
+	timer := time.NewTimer(s.cfg.ScanInterval())
+	defer timer.Stop()
+	for {
+		select {
+		case <-s.stopC:
+			return
+		case <-timer.C:
+			s.scan()
+			timer.Reset(s.cfg.ScanInterval())
+		}
+	}
+}
+
+// scan fires the due schedules owned by this host
+func (s *Scheduler) scan() {
+	request := &persistence.ListSchedulesRequest{PageSize: scanPageSize}
+	for {
+		response, err := s.scheduleMgr.ListSchedules(request)
+		if err != nil {
+			s.metricsClient.IncCounter(metrics.SchedulerScope, metrics.CadenceFailures)
+			s.logger.Error("failed to list schedules", tag.Error(err))
+			return
+		}
+		for _, schedule := range response.Schedules {
+			select {
+			case <-s.stopC:
+				return
+			default:
+			}
+			if !s.isOwner(schedule) {
+				continue
+			}
+			if err := s.processSchedule(schedule); err != nil {
+				s.metricsClient.IncCounter(metrics.SchedulerScope, metrics.CadenceFailures)
+				s.logger.Error("failed to process schedule",
+					tag.WorkflowDomainID(schedule.DomainID),
+					tag.ScheduleID(schedule.ScheduleID),
+					tag.Error(err))
+			}
+		}
+		if len(response.NextPageToken) == 0 {
+			return
+		}
+		request.NextPageToken = response.NextPageToken
+	}
+}
+
+// isOwner returns whether this host owns the schedule, the schedules are spread over the worker hosts by the
+// membership ring. Two hosts may briefly both own a schedule while the ring changes, this is safe as the
+// updates of the schedule are conditional and its workflow IDs are derived from its fire times.
+func (s *Scheduler) isOwner(schedule *persistence.ScheduleInfo) bool {
+	owner, err := s.resolver.Lookup(schedule.DomainID + schedule.ScheduleID)
+	if err != nil {
+		s.logger.Warn("failed to lookup the owner of schedule",
+			tag.WorkflowDomainID(schedule.DomainID),
+			tag.ScheduleID(schedule.ScheduleID),
+			tag.Error(err))
+		return false
+	}
+	return owner.Identity() == s.hostInfo.Identity()
+}
+
+// processSchedule starts the workflows of the due fire times and of the pending backfill of a schedule,
+// and persists the progress made. Schedules are only fired in the active cluster of their domain.
+func (s *Scheduler) processSchedule(schedule *persistence.ScheduleInfo) error {
+	domainEntry, err := s.domainCache.GetDomainByID(schedule.DomainID)
+	if err != nil {
+		return err
+	}
+	if domainEntry.GetDomainNotActiveErr() != nil {
+		return nil
+	}
+
+	now := s.timeSource.Now()
+	updated := *schedule
+	changed := false
+	fires := 0
+	var fireErr error
+
+	if !schedule.Paused {
+		lastMissed, dueFireTimes := getDueFireTimes(schedule, now)
+		if !lastMissed.IsZero() {
+			s.metricsClient.IncCounter(metrics.SchedulerScope, metrics.ScheduleMissedCount)
+			updated.LastFireTime = lastMissed
+			changed = true
+		}
+		for _, fireTime := range dueFireTimes {
+			if fires >= maxFiresPerScan {
+				break
+			}
+			if fireErr = s.fire(domainEntry, &updated, fireTime, false); fireErr != nil {
+				break
+			}
+			updated.LastFireTime = fireTime
+			changed = true
+			fires++
+		}
+	}
+
+	// backfills are explicitly requested by operators, so they are run even if the schedule is paused,
+	// at the rate requested for them
+	if fireErr == nil && !updated.BackfillEndTime.IsZero() {
+		for runs := getAllowedBackfillRuns(&updated, now); runs > 0 && fires < maxFiresPerScan; runs-- {
+			fireTime := getNextBackfillFireTime(&updated)
+			if fireTime.IsZero() {
+				break
+			}
+			if fireErr = s.fire(domainEntry, &updated, fireTime, true); fireErr != nil {
+				break
+			}
+			updated.BackfillStartTime = fireTime.Add(time.Second)
+			updated.BackfillLastRunTime = getNextBackfillRunTime(&updated, now)
+			changed = true
+			fires++
+		}
+		if fireErr == nil && getNextBackfillFireTime(&updated).IsZero() {
+			updated.BackfillID = ""
+			updated.BackfillStartTime = time.Time{}
+			updated.BackfillEndTime = time.Time{}
+			updated.BackfillRunsPerMinute = 0
+			updated.BackfillLastRunTime = time.Time{}
+			changed = true
+		}
+	}
+
+	if changed {
+		updated.Version = schedule.Version + 1
+		err := s.scheduleMgr.UpdateSchedule(&persistence.UpdateScheduleRequest{
+			Schedule:        &updated,
+			PreviousVersion: schedule.Version,
+		})
+		if err != nil {
+			if _, ok := err.(*persistence.ConditionFailedError); ok {
+				// the schedule was updated concurrently, it is processed again on the next scan and the
+				// workflows already started are not started twice as their workflow IDs are derived from
+				// the fire time
+				return nil
+			}
+			return err
+		}
+	}
+	return fireErr
+}
+
+// fire starts the workflow of a fire time of the schedule, after applying the overlap policy of the schedule
+// to the workflow started by the previous fire time unless the fire time is part of a backfill. The runs of
+// backfills are tracked apart from the runs of the schedule so they do not affect the overlap policy.
+func (s *Scheduler) fire(
+	domainEntry *cache.DomainCacheEntry,
+	schedule *persistence.ScheduleInfo,
+	fireTime time.Time,
+	isBackfill bool,
+) error {
+
+	ctx, cancel := context.WithTimeout(context.Background(), rpcTimeout)
+	defer cancel()
+
+	domainID := domainEntry.GetInfo().ID
+	domainName := domainEntry.GetInfo().Name
+	policy := shared.ScheduleOverlapPolicy(schedule.OverlapPolicy)
+	if !isBackfill && schedule.LastWorkflowID != "" && policy != shared.ScheduleOverlapPolicyAllowAll {
+		previous := &shared.WorkflowExecution{
+			WorkflowId: common.StringPtr(schedule.LastWorkflowID),
+			RunId:      common.StringPtr(schedule.LastRunID),
+		}
+		running, err := s.isWorkflowRunning(ctx, domainID, previous)
+		if err != nil {
+			return err
+		}
+		if running {
+			switch policy {
+			case shared.ScheduleOverlapPolicySkip:
+				s.metricsClient.IncCounter(metrics.SchedulerScope, metrics.ScheduleSkippedCount)
+				return nil
+			case shared.ScheduleOverlapPolicyTerminateOther:
+				err = s.historyClient.TerminateWorkflowExecution(ctx, &h.TerminateWorkflowExecutionRequest{
+					DomainUUID: common.StringPtr(domainID),
+					TerminateRequest: &shared.TerminateWorkflowExecutionRequest{
+						Domain:            common.StringPtr(domainName),
+						WorkflowExecution: previous,
+						Reason:            common.StringPtr("terminated by the overlap policy of schedule " + schedule.ScheduleID),
+						Identity:          common.StringPtr(identity),
+					},
+				})
+			case shared.ScheduleOverlapPolicyCancelOther:
+				err = s.historyClient.RequestCancelWorkflowExecution(ctx, &h.RequestCancelWorkflowExecutionRequest{
+					DomainUUID: common.StringPtr(domainID),
+					CancelRequest: &shared.RequestCancelWorkflowExecutionRequest{
+						Domain:            common.StringPtr(domainName),
+						WorkflowExecution: previous,
+						Identity:          common.StringPtr(identity),
+						RequestId:         common.StringPtr(uuid.New()),
+					},
+				})
+			}
+			if err != nil {
+				if _, ok := err.(*shared.EntityNotExistsError); !ok {
+					return err
+				}
+			}
+		}
+	}
+
+	workflowID := getWorkflowID(schedule.ScheduleID, fireTime)
+	if isBackfill {
+		// the runs of a backfill have their own workflow IDs, so a backfill over fire times which already ran
+		// starts them again instead of being deduplicated against the previous runs
+		workflowID = getBackfillWorkflowID(schedule.ScheduleID, schedule.BackfillID, fireTime)
+	}
+	response, err := s.historyClient.StartWorkflowExecution(ctx, &h.StartWorkflowExecutionRequest{
+		DomainUUID: common.StringPtr(domainID),
+		StartRequest: &shared.StartWorkflowExecutionRequest{
+			Domain:                              common.StringPtr(domainName),
+			WorkflowId:                          common.StringPtr(workflowID),
+			WorkflowType:                        &shared.WorkflowType{Name: common.StringPtr(schedule.WorkflowTypeName)},
+			TaskList:                            &shared.TaskList{Name: common.StringPtr(schedule.TaskList)},
+			Input:                               schedule.Input,
+			ExecutionStartToCloseTimeoutSeconds: common.Int32Ptr(schedule.ExecutionStartToCloseTimeoutSeconds),
+			TaskStartToCloseTimeoutSeconds:      common.Int32Ptr(schedule.TaskStartToCloseTimeoutSeconds),
+			Identity:                            common.StringPtr(identity),
+			RequestId:                           common.StringPtr(uuid.New()),
+			// a fire time is never started twice by the schedule or by a backfill, even by concurrent scans
+			WorkflowIdReusePolicy: shared.WorkflowIdReusePolicyRejectDuplicate.Ptr(),
+		},
+	})
+	runID := ""
+	switch err := err.(type) {
+	case nil:
+		runID = response.GetRunId()
+		s.metricsClient.IncCounter(metrics.SchedulerScope, metrics.ScheduleFiredCount)
+	case *shared.WorkflowExecutionAlreadyStartedError:
+		runID = err.GetRunId()
+	default:
+		return err
+	}
+	if isBackfill {
+		schedule.LastBackfillWorkflowID = workflowID
+		schedule.LastBackfillRunID = runID
+		return nil
+	}
+	schedule.LastWorkflowID = workflowID
+	schedule.LastRunID = runID
+	return nil
+}
+
+func (s *Scheduler) isWorkflowRunning(ctx context.Context, domainID string, execution *shared.WorkflowExecution) (bool, error) {
+	response, err := s.historyClient.GetMutableState(ctx, &h.GetMutableStateRequest{
+		DomainUUID:          common.StringPtr(domainID),
+		Execution:           execution,
+		ExpectedNextEventId: common.Int64Ptr(common.FirstEventID), // common.FirstEventID means no long poll
+	})
+	if err != nil {
+		if _, ok := err.(*shared.EntityNotExistsError); ok {
+			return false, nil
+		}
+		return false, err
+	}
+	return response.GetIsWorkflowRunning(), nil
+}
+
+// getDueFireTimes returns the fire times of the schedule after its last fire time and up to now which are
+// within its catch-up window, and the last fire time missed because it was out of the catch-up window if any
+func getDueFireTimes(schedule *persistence.ScheduleInfo, now time.Time) (time.Time, []time.Time) {
+	windowStart := now.Add(-time.Duration(schedule.CatchupWindowSeconds) * time.Second)
+
+	var lastMissed time.Time
+	fireTime := backoff.GetNextScheduleTime(schedule.CronSchedule, schedule.LastFireTime)
+	for i := 0; !fireTime.IsZero() && fireTime.Before(windowStart); i++ {
+		if i == maxMissedFireTimes {
+			// the schedule was down for too long to find the last missed fire time in a reasonable time,
+			// skip to the start of the window, it is only used as the lower bound of the next fire time
+			lastMissed = windowStart
+			fireTime = backoff.GetNextScheduleTime(schedule.CronSchedule, windowStart)
+			break
+		}
+		lastMissed = fireTime
+		fireTime = backoff.GetNextScheduleTime(schedule.CronSchedule, fireTime)
+	}
+
+	var dueFireTimes []time.Time
+	for !fireTime.IsZero() && !fireTime.After(now) && len(dueFireTimes) < maxFiresPerScan {
+		dueFireTimes = append(dueFireTimes, fireTime)
+		fireTime = backoff.GetNextScheduleTime(schedule.CronSchedule, fireTime)
+	}
+	return lastMissed, dueFireTimes
+}
+
+// getNextBackfillFireTime returns the first fire time of the pending backfill of the schedule,
+// or the zero time if there is none left
+func getNextBackfillFireTime(schedule *persistence.ScheduleInfo) time.Time {
+	// the start of the backfill range is inclusive
+	fireTime := backoff.GetNextScheduleTime(schedule.CronSchedule, schedule.BackfillStartTime.Add(-time.Nanosecond))
+	if fireTime.IsZero() || fireTime.After(schedule.BackfillEndTime) {
+		return time.Time{}
+	}
+	return fireTime
+}
+
+// getAllowedBackfillRuns returns how many workflows of the pending backfill of the schedule can be started
+// now without exceeding the rate of the backfill
+func getAllowedBackfillRuns(schedule *persistence.ScheduleInfo, now time.Time) int {
+	if schedule.BackfillRunsPerMinute <= 0 {
+		return maxFiresPerScan
+	}
+	interval := time.Minute / time.Duration(schedule.BackfillRunsPerMinute)
+	runs := int(now.Sub(getBackfillRunTimeFloor(schedule, now)) / interval)
+	if runs > maxFiresPerScan {
+		runs = maxFiresPerScan
+	}
+	return runs
+}
+
+// getNextBackfillRunTime returns the run time to account a workflow started now by the pending backfill of the
+// schedule at, each run moves it forward by the interval between the runs of the backfill
+func getNextBackfillRunTime(schedule *persistence.ScheduleInfo, now time.Time) time.Time {
+	if schedule.BackfillRunsPerMinute <= 0 {
+		return now
+	}
+	interval := time.Minute / time.Duration(schedule.BackfillRunsPerMinute)
+	return getBackfillRunTimeFloor(schedule, now).Add(interval)
+}
+
+// getBackfillRunTimeFloor returns the last run time of the pending backfill of the schedule, bounded so that
+// the runs not made while the backfill was not processed do not add up to more than a minute worth of runs
+func getBackfillRunTimeFloor(schedule *persistence.ScheduleInfo, now time.Time) time.Time {
+	floor := now.Add(-time.Minute)
+	if schedule.BackfillLastRunTime.Before(floor) {
+		return floor
+	}
+	return schedule.BackfillLastRunTime
+}
+
+// GetNextFireTime returns when the schedule fires next, or the zero time if it is paused
+func GetNextFireTime(schedule *persistence.ScheduleInfo, now time.Time) time.Time {
+	if schedule.Paused {
+		return time.Time{}
+	}
+	if _, dueFireTimes := getDueFireTimes(schedule, now); len(dueFireTimes) > 0 {
+		return dueFireTimes[0]
+	}
+	after := schedule.LastFireTime
+	if now.After(after) {
+		after = now
+	}
+	return backoff.GetNextScheduleTime(schedule.CronSchedule, after)
+}
+
+// getWorkflowID returns the ID of the workflow started by the schedule for a fire time
+func getWorkflowID(scheduleID string, fireTime time.Time) string {
+	return fmt.Sprintf("%v-%v", scheduleID, fireTime.UTC().Format(time.RFC3339))
+}
+
+// getBackfillWorkflowID returns the ID of the workflow started by a backfill of the schedule for a fire time
+func getBackfillWorkflowID(scheduleID string, backfillID string, fireTime time.Time) string {
+	return fmt.Sprintf("%v-backfill-%v-%v", scheduleID, backfillID, fireTime.UTC().Format(time.RFC3339))
+}
@@ -0,0 +1,288 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package scheduler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"github.com/uber-go/tally"
+	h "github.com/uber/cadence/.gen/go/history"
+	"github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/cache"
+	"github.com/uber/cadence/common/clock"
+	"github.com/uber/cadence/common/log/loggerimpl"
+	"github.com/uber/cadence/common/membership"
+	"github.com/uber/cadence/common/metrics"
+	"github.com/uber/cadence/common/mocks"
+	"github.com/uber/cadence/common/persistence"
+	"github.com/uber/cadence/common/service/dynamicconfig"
+	"go.uber.org/zap"
+)
+
+type (
+	schedulerSuite struct {
+		suite.Suite
+		mockScheduleMgr   *mocks.ScheduleManager
+		mockHistoryClient *mocks.HistoryClient
+		mockDomainCache   *cache.DomainCacheMock
+		mockResolver      *mocks.ServiceResolver
+		timeSource        *clock.EventTimeSource
+		scheduler         *Scheduler
+	}
+)
+
+const (
+	testDomainID   = "deadbeef-0123-4567-890a-bcdef0123456"
+	testDomainName = "test-domain"
+	testBackfillID = "0ddba11c-0123-4567-890a-bcdef0123456"
+)
+
+func TestSchedulerSuite(t *testing.T) {
+	suite.Run(t, new(schedulerSuite))
+}
+
+func (s *schedulerSuite) SetupTest() {
+	s.mockScheduleMgr = &mocks.ScheduleManager{}
+	s.mockHistoryClient = &mocks.HistoryClient{}
+	s.mockDomainCache = &cache.DomainCacheMock{}
+	s.mockResolver = &mocks.ServiceResolver{}
+	s.timeSource = clock.NewEventTimeSource()
+	s.scheduler = New(&BootstrapParams{
+		Config:          Config{ScanInterval: dynamicconfig.GetDurationPropertyFn(time.Minute)},
+		ScheduleManager: s.mockScheduleMgr,
+		HistoryClient:   s.mockHistoryClient,
+		DomainCache:     s.mockDomainCache,
+		ServiceResolver: s.mockResolver,
+		HostInfo:        membership.NewHostInfo("host-a", nil),
+		MetricsClient:   metrics.NewClient(tally.NoopScope, metrics.Worker),
+		Logger:          loggerimpl.NewLogger(zap.NewNop()),
+	})
+	s.scheduler.timeSource = s.timeSource
+
+	domainEntry := cache.NewLocalDomainCacheEntryForTest(
+		&persistence.DomainInfo{ID: testDomainID, Name: testDomainName}, &persistence.DomainConfig{Retention: 1}, "", nil,
+	)
+	s.mockDomainCache.On("GetDomainByID", testDomainID).Return(domainEntry, nil)
+}
+
+func (s *schedulerSuite) TearDownTest() {
+	s.mockScheduleMgr.AssertExpectations(s.T())
+	s.mockHistoryClient.AssertExpectations(s.T())
+	s.mockResolver.AssertExpectations(s.T())
+}
+
+func (s *schedulerSuite) TestGetDueFireTimes() {
+	schedule := &persistence.ScheduleInfo{
+		CronSchedule:         "0 * * * *",
+		CatchupWindowSeconds: int32((90 * time.Minute).Seconds()),
+		LastFireTime:         parseTime("2019-05-01T08:00:00Z"),
+	}
+
+	// fire times older than the catch-up window are missed
+	lastMissed, dueFireTimes := getDueFireTimes(schedule, parseTime("2019-05-01T12:10:00Z"))
+	s.Equal(parseTime("2019-05-01T10:00:00Z"), lastMissed)
+	s.Equal([]time.Time{parseTime("2019-05-01T11:00:00Z"), parseTime("2019-05-01T12:00:00Z")}, dueFireTimes)
+
+	lastMissed, dueFireTimes = getDueFireTimes(schedule, parseTime("2019-05-01T08:30:00Z"))
+	s.True(lastMissed.IsZero())
+	s.Empty(dueFireTimes)
+
+	schedule.Paused = true
+	s.True(GetNextFireTime(schedule, parseTime("2019-05-01T08:30:00Z")).IsZero())
+	schedule.Paused = false
+	s.Equal(parseTime("2019-05-01T09:00:00Z"), GetNextFireTime(schedule, parseTime("2019-05-01T08:30:00Z")))
+	s.Equal(parseTime("2019-05-01T11:00:00Z"), GetNextFireTime(schedule, parseTime("2019-05-01T12:10:00Z")))
+}
+
+func (s *schedulerSuite) TestGetNextBackfillFireTime() {
+	schedule := &persistence.ScheduleInfo{
+		CronSchedule:      "0 * * * *",
+		BackfillStartTime: parseTime("2019-05-01T08:00:00Z"),
+		BackfillEndTime:   parseTime("2019-05-01T09:30:00Z"),
+	}
+	s.Equal(parseTime("2019-05-01T08:00:00Z"), getNextBackfillFireTime(schedule))
+	schedule.BackfillStartTime = parseTime("2019-05-01T08:00:01Z")
+	s.Equal(parseTime("2019-05-01T09:00:00Z"), getNextBackfillFireTime(schedule))
+	schedule.BackfillStartTime = parseTime("2019-05-01T09:00:01Z")
+	s.True(getNextBackfillFireTime(schedule).IsZero())
+}
+
+func (s *schedulerSuite) TestGetAllowedBackfillRuns() {
+	now := parseTime("2019-05-01T10:00:00Z")
+	schedule := &persistence.ScheduleInfo{BackfillRunsPerMinute: 4}
+
+	// a new backfill starts at most a minute worth of runs at once
+	s.Equal(4, getAllowedBackfillRuns(schedule, now))
+	s.Equal(parseTime("2019-05-01T09:59:15Z"), getNextBackfillRunTime(schedule, now))
+
+	schedule.BackfillLastRunTime = parseTime("2019-05-01T09:59:50Z")
+	s.Equal(0, getAllowedBackfillRuns(schedule, now))
+	schedule.BackfillLastRunTime = parseTime("2019-05-01T09:59:30Z")
+	s.Equal(2, getAllowedBackfillRuns(schedule, now))
+	s.Equal(parseTime("2019-05-01T09:59:45Z"), getNextBackfillRunTime(schedule, now))
+
+	schedule.BackfillRunsPerMinute = 600
+	schedule.BackfillLastRunTime = time.Time{}
+	s.Equal(maxFiresPerScan, getAllowedBackfillRuns(schedule, now))
+}
+
+func (s *schedulerSuite) TestProcessSchedule_BackfillRate() {
+	s.timeSource.Update(parseTime("2019-05-01T09:30:00Z"))
+	schedule := s.newSchedule(shared.ScheduleOverlapPolicySkip)
+	schedule.BackfillID = testBackfillID
+	schedule.BackfillStartTime = parseTime("2019-05-01T00:00:00Z")
+	schedule.BackfillEndTime = parseTime("2019-05-01T09:00:00Z")
+	schedule.BackfillRunsPerMinute = 2
+
+	s.mockHistoryClient.On("StartWorkflowExecution", mock.Anything, mock.Anything).
+		Return(&shared.StartWorkflowExecutionResponse{RunId: common.StringPtr("backfill-rid")}, nil).Twice()
+	s.mockScheduleMgr.On("UpdateSchedule", mock.MatchedBy(func(request *persistence.UpdateScheduleRequest) bool {
+		return request.Schedule.LastFireTime.Equal(parseTime("2019-05-01T09:00:00Z")) &&
+			request.Schedule.LastWorkflowID == "previous-wid" &&
+			request.Schedule.LastBackfillWorkflowID == "test-schedule-backfill-"+testBackfillID+"-2019-05-01T01:00:00Z" &&
+			request.Schedule.BackfillStartTime.Equal(parseTime("2019-05-01T01:00:01Z")) &&
+			request.Schedule.BackfillLastRunTime.Equal(parseTime("2019-05-01T09:30:00Z"))
+	})).Return(nil).Once()
+
+	s.NoError(s.scheduler.processSchedule(schedule))
+}
+
+func (s *schedulerSuite) TestProcessSchedule_SkipOverlap() {
+	s.timeSource.Update(parseTime("2019-05-01T10:00:30Z"))
+	schedule := s.newSchedule(shared.ScheduleOverlapPolicySkip)
+
+	s.mockHistoryClient.On("GetMutableState", mock.Anything, mock.Anything).Return(&h.GetMutableStateResponse{
+		IsWorkflowRunning: common.BoolPtr(true),
+	}, nil).Once()
+	s.mockScheduleMgr.On("UpdateSchedule", mock.MatchedBy(func(request *persistence.UpdateScheduleRequest) bool {
+		return request.PreviousVersion == 3 &&
+			request.Schedule.Version == 4 &&
+			request.Schedule.LastFireTime.Equal(parseTime("2019-05-01T10:00:00Z")) &&
+			request.Schedule.LastWorkflowID == "previous-wid"
+	})).Return(nil).Once()
+
+	s.NoError(s.scheduler.processSchedule(schedule))
+}
+
+func (s *schedulerSuite) TestProcessSchedule_StartWorkflow() {
+	s.timeSource.Update(parseTime("2019-05-01T10:00:30Z"))
+	schedule := s.newSchedule(shared.ScheduleOverlapPolicyTerminateOther)
+	schedule.BackfillID = testBackfillID
+	schedule.BackfillStartTime = parseTime("2019-05-01T06:00:00Z")
+	schedule.BackfillEndTime = parseTime("2019-05-01T06:00:00Z")
+
+	s.mockHistoryClient.On("GetMutableState", mock.Anything, mock.Anything).Return(&h.GetMutableStateResponse{
+		IsWorkflowRunning: common.BoolPtr(true),
+	}, nil).Once()
+	s.mockHistoryClient.On("TerminateWorkflowExecution", mock.Anything, mock.MatchedBy(func(request *h.TerminateWorkflowExecutionRequest) bool {
+		return request.TerminateRequest.WorkflowExecution.GetWorkflowId() == "previous-wid"
+	})).Return(nil).Once()
+	s.mockHistoryClient.On("StartWorkflowExecution", mock.Anything, mock.MatchedBy(func(request *h.StartWorkflowExecutionRequest) bool {
+		return request.StartRequest.GetWorkflowId() == "test-schedule-2019-05-01T10:00:00Z" &&
+			request.StartRequest.GetDomain() == testDomainName
+	})).Return(&shared.StartWorkflowExecutionResponse{RunId: common.StringPtr("new-rid")}, nil).Once()
+	// the backfilled run was already started by a previous scan
+	s.mockHistoryClient.On("StartWorkflowExecution", mock.Anything, mock.MatchedBy(func(request *h.StartWorkflowExecutionRequest) bool {
+		return request.StartRequest.GetWorkflowId() == "test-schedule-backfill-"+testBackfillID+"-2019-05-01T06:00:00Z"
+	})).Return(nil, &shared.WorkflowExecutionAlreadyStartedError{RunId: common.StringPtr("backfill-rid")}).Once()
+	s.mockScheduleMgr.On("UpdateSchedule", mock.MatchedBy(func(request *persistence.UpdateScheduleRequest) bool {
+		return request.Schedule.LastFireTime.Equal(parseTime("2019-05-01T10:00:00Z")) &&
+			request.Schedule.LastWorkflowID == "test-schedule-2019-05-01T10:00:00Z" &&
+			request.Schedule.LastRunID == "new-rid" &&
+			request.Schedule.LastBackfillWorkflowID == "test-schedule-backfill-"+testBackfillID+"-2019-05-01T06:00:00Z" &&
+			request.Schedule.LastBackfillRunID == "backfill-rid" &&
+			request.Schedule.BackfillID == "" &&
+			request.Schedule.BackfillStartTime.IsZero() &&
+			request.Schedule.BackfillEndTime.IsZero()
+	})).Return(nil).Once()
+
+	s.NoError(s.scheduler.processSchedule(schedule))
+}
+
+func (s *schedulerSuite) TestProcessSchedule_Paused() {
+	s.timeSource.Update(parseTime("2019-05-01T10:00:30Z"))
+	schedule := s.newSchedule(shared.ScheduleOverlapPolicyAllowAll)
+	schedule.Paused = true
+
+	s.NoError(s.scheduler.processSchedule(schedule))
+}
+
+func (s *schedulerSuite) TestProcessSchedule_ConcurrentUpdate() {
+	s.timeSource.Update(parseTime("2019-05-01T10:00:30Z"))
+	schedule := s.newSchedule(shared.ScheduleOverlapPolicyAllowAll)
+
+	s.mockHistoryClient.On("StartWorkflowExecution", mock.Anything, mock.Anything).
+		Return(&shared.StartWorkflowExecutionResponse{RunId: common.StringPtr("new-rid")}, nil).Once()
+	s.mockScheduleMgr.On("UpdateSchedule", mock.Anything).Return(&persistence.ConditionFailedError{}).Once()
+
+	s.NoError(s.scheduler.processSchedule(schedule))
+}
+
+func (s *schedulerSuite) TestScan_OwnedSchedulesOnly() {
+	s.timeSource.Update(parseTime("2019-05-01T10:00:30Z"))
+	owned := s.newSchedule(shared.ScheduleOverlapPolicyAllowAll)
+	notOwned := s.newSchedule(shared.ScheduleOverlapPolicyAllowAll)
+	notOwned.ScheduleID = "other-schedule"
+
+	s.mockScheduleMgr.On("ListSchedules", mock.Anything).Return(&persistence.ListSchedulesResponse{
+		Schedules: []*persistence.ScheduleInfo{owned, notOwned},
+	}, nil).Once()
+	s.mockResolver.On("Lookup", testDomainID+"test-schedule").Return(membership.NewHostInfo("host-a", nil), nil).Once()
+	s.mockResolver.On("Lookup", testDomainID+"other-schedule").Return(membership.NewHostInfo("host-b", nil), nil).Once()
+	s.mockHistoryClient.On("StartWorkflowExecution", mock.Anything, mock.MatchedBy(func(request *h.StartWorkflowExecutionRequest) bool {
+		return request.StartRequest.GetWorkflowId() == "test-schedule-2019-05-01T10:00:00Z"
+	})).Return(&shared.StartWorkflowExecutionResponse{RunId: common.StringPtr("new-rid")}, nil).Once()
+	s.mockScheduleMgr.On("UpdateSchedule", mock.MatchedBy(func(request *persistence.UpdateScheduleRequest) bool {
+		return request.Schedule.ScheduleID == "test-schedule"
+	})).Return(nil).Once()
+
+	s.scheduler.scan()
+}
+
+func (s *schedulerSuite) newSchedule(policy shared.ScheduleOverlapPolicy) *persistence.ScheduleInfo {
+	return &persistence.ScheduleInfo{
+		DomainID:                            testDomainID,
+		ScheduleID:                          "test-schedule",
+		CronSchedule:                        "0 * * * *",
+		WorkflowTypeName:                    "test-workflow-type",
+		TaskList:                            "test-task-list",
+		ExecutionStartToCloseTimeoutSeconds: 60,
+		TaskStartToCloseTimeoutSeconds:      10,
+		OverlapPolicy:                       int(policy),
+		CatchupWindowSeconds:                60,
+		LastFireTime:                        parseTime("2019-05-01T09:00:00Z"),
+		LastWorkflowID:                      "previous-wid",
+		LastRunID:                           "previous-rid",
+		Version:                             3,
+	}
+}
+
+func parseTime(value string) time.Time {
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
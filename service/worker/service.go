@@ -27,6 +27,7 @@ import (
 	"github.com/uber/cadence/common/archiver/provider"
 
 	"github.com/uber/cadence/service/worker/batcher"
+	"github.com/uber/cadence/service/worker/canary"
 
 	"github.com/uber/cadence/.gen/go/shared"
 	"github.com/uber/cadence/common"
@@ -46,6 +47,7 @@ import (
 	"github.com/uber/cadence/service/worker/indexer"
 	"github.com/uber/cadence/service/worker/replicator"
 	"github.com/uber/cadence/service/worker/scanner"
+	"github.com/uber/cadence/service/worker/scheduler"
 )
 
 type (
@@ -53,6 +55,8 @@ type (
 	// 1. Replicator: Handles applying replication tasks generated by remote clusters.
 	// 2. Indexer: Handles uploading of visibility records to elastic search.
 	// 3. Archiver: Handles archival of workflow histories.
+	// 4. Canary: Continuously runs probe workflows and emits end to end metrics for them.
+	// 5. Scheduler: Fires the schedules, starting their target workflows.
 	Service struct {
 		stopC         chan struct{}
 		isStopped     int32
@@ -69,8 +73,11 @@ type (
 		IndexerCfg      *indexer.Config
 		ScannerCfg      *scanner.Config
 		BatcherCfg      *batcher.Config
+		SchedulerCfg    *scheduler.Config
 		ThrottledLogRPS dynamicconfig.IntPropertyFn
 		EnableBatcher   dynamicconfig.BoolPropertyFn
+		EnableCanary    dynamicconfig.BoolPropertyFn
+		EnableScheduler dynamicconfig.BoolPropertyFn
 	}
 )
 
@@ -121,15 +128,23 @@ func NewConfig(params *service.BootstrapParams) *Config {
 			ValidSearchAttributes:    dc.GetMapProperty(dynamicconfig.ValidSearchAttributes, definition.GetDefaultIndexedKeys()),
 		},
 		ScannerCfg: &scanner.Config{
-			PersistenceMaxQPS: dc.GetIntProperty(dynamicconfig.ScannerPersistenceMaxQPS, 100),
-			Persistence:       &params.PersistenceConfig,
-			ClusterMetadata:   params.ClusterMetadata,
+			PersistenceMaxQPS:                    dc.GetIntProperty(dynamicconfig.ScannerPersistenceMaxQPS, 100),
+			BufferedReplicationTasksPurgeEnabled: dc.GetBoolProperty(dynamicconfig.ScannerBufferedReplicationTasksPurgeEnabled, false),
+			CurrentExecutionsFixEnabled:          dc.GetBoolProperty(dynamicconfig.ScannerCurrentExecutionsFixEnabled, false),
+			OpenExecutionCountsReconcileEnabled:  dc.GetBoolPropertyFnWithDomainFilter(dynamicconfig.ScannerOpenExecutionCountsReconcileEnabled, true),
+			Persistence:                          &params.PersistenceConfig,
+			ClusterMetadata:                      params.ClusterMetadata,
 		},
 		BatcherCfg: &batcher.Config{
 			AdminOperationToken: dc.GetStringProperty(dynamicconfig.AdminOperationToken, common.DefaultAdminOperationToken),
 			ClusterMetadata:     params.ClusterMetadata,
 		},
+		SchedulerCfg: &scheduler.Config{
+			ScanInterval: dc.GetDurationProperty(dynamicconfig.SchedulerScanInterval, 10*time.Second),
+		},
 		EnableBatcher:   dc.GetBoolProperty(dynamicconfig.EnableBatcher, false),
+		EnableCanary:    dc.GetBoolProperty(dynamicconfig.EnableCanary, false),
+		EnableScheduler: dc.GetBoolProperty(dynamicconfig.EnableScheduler, true),
 		ThrottledLogRPS: dc.GetIntProperty(dynamicconfig.WorkerThrottledLogRPS, 20),
 	}
 }
@@ -150,13 +165,15 @@ func (s *Service) Start() {
 	archiverEnabled := base.GetClusterMetadata().ArchivalConfig().ConfiguredForArchival()
 	scannerEnabled := s.config.ScannerCfg.Persistence.DefaultStoreType() == config.StoreTypeSQL
 	batcherEnabled := s.config.EnableBatcher()
+	canaryEnabled := s.config.EnableCanary()
+	schedulerEnabled := s.config.EnableScheduler()
 
-	if replicatorEnabled || archiverEnabled || scannerEnabled || batcherEnabled {
+	if replicatorEnabled || archiverEnabled || scannerEnabled || batcherEnabled || canaryEnabled || schedulerEnabled {
 		pConfig := s.params.PersistenceConfig
 		pConfig.SetMaxQPS(pConfig.DefaultStore, s.config.ReplicationCfg.PersistenceMaxQPS())
 		pFactory := persistencefactory.New(&pConfig, s.params.ClusterMetadata.GetCurrentClusterName(), s.metricsClient, s.logger)
 
-		if archiverEnabled || scannerEnabled {
+		if archiverEnabled || scannerEnabled || canaryEnabled {
 			s.ensureSystemDomainExists(pFactory, base.GetClusterMetadata().GetCurrentClusterName())
 		}
 		if replicatorEnabled {
@@ -171,6 +188,12 @@ func (s *Service) Start() {
 		if batcherEnabled {
 			s.startBatcher(base)
 		}
+		if canaryEnabled {
+			s.startCanary()
+		}
+		if schedulerEnabled {
+			s.startScheduler(base, pFactory)
+		}
 	}
 
 	s.logger.Info("service started", tag.ComponentWorker)
@@ -201,10 +224,56 @@ func (s *Service) startBatcher(base service.Service) {
 	}
 }
 
+func (s *Service) startCanary() {
+	params := &canary.BootstrapParams{
+		SDKClient:     s.params.PublicClient,
+		MetricsClient: s.metricsClient,
+		Logger:        s.logger,
+		TallyScope:    s.params.MetricScope,
+	}
+	canary := canary.New(params)
+	if err := canary.Start(); err != nil {
+		s.logger.Fatal("error starting canary", tag.Error(err))
+	}
+}
+
+func (s *Service) startScheduler(base service.Service, pFactory persistencefactory.Factory) {
+	scheduleMgr, err := pFactory.NewScheduleManager()
+	if err != nil {
+		s.logger.Fatal("failed to start scheduler, could not create ScheduleManager", tag.Error(err))
+	}
+	metadataMgr, err := pFactory.NewMetadataManager(persistencefactory.MetadataV1V2)
+	if err != nil {
+		s.logger.Fatal("failed to start scheduler, could not create MetadataManager", tag.Error(err))
+	}
+	resolver, err := base.GetMembershipMonitor().GetResolver(common.WorkerServiceName)
+	if err != nil {
+		s.logger.Fatal("failed to start scheduler, could not get worker service resolver", tag.Error(err))
+	}
+	domainCache := cache.NewDomainCache(metadataMgr, base.GetClusterMetadata(), s.metricsClient, s.logger)
+	domainCache.Start()
+
+	params := &scheduler.BootstrapParams{
+		Config:          *s.config.SchedulerCfg,
+		ScheduleManager: scheduleMgr,
+		HistoryClient:   base.GetClientBean().GetHistoryClient(),
+		DomainCache:     domainCache,
+		ServiceResolver: resolver,
+		HostInfo:        base.GetHostInfo(),
+		MetricsClient:   s.metricsClient,
+		Logger:          s.logger,
+	}
+	scheduler := scheduler.New(params)
+	if err := scheduler.Start(); err != nil {
+		s.logger.Fatal("error starting scheduler", tag.Error(err))
+	}
+}
+
 func (s *Service) startScanner(base service.Service) {
 	params := &scanner.BootstrapParams{
 		Config:        *s.config.ScannerCfg,
 		SDKClient:     s.params.PublicClient,
+		HistoryClient: base.GetClientBean().GetHistoryClient(),
 		MetricsClient: s.metricsClient,
 		Logger:        s.logger,
 		TallyScope:    s.params.MetricScope,
@@ -286,6 +355,7 @@ func (s *Service) startArchiver(base service.Service, pFactory persistencefactor
 		DomainCache:      domainCache,
 		Config:           s.config.ArchiverConfig,
 		ArchiverProvider: archiverProvider,
+		KeyProvider:      s.params.BlobKeyProvider,
 	}
 	clientWorker := archiver.NewClientWorker(bc)
 	if err := clientWorker.Start(); err != nil {
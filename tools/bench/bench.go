@@ -0,0 +1,480 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package bench
+
+import (
+	"fmt"
+	"math/rand"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/pborman/uuid"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/log"
+	"github.com/uber/cadence/common/log/tag"
+	p "github.com/uber/cadence/common/persistence"
+	"github.com/uber/cadence/common/persistence/persistence-factory"
+)
+
+const (
+	benchShardOwner   = "cadence-bench"
+	benchTaskList     = "cadence-bench-tl"
+	benchWorkflowType = "cadence-bench-workflow"
+
+	// rangeSizeBits mirrors the history service default, each range holds 2^20 task IDs
+	rangeSizeBits = 20
+	// maxExecutionsPerShard bounds the pool of executions kept around as update targets
+	maxExecutionsPerShard = 1000
+	// eventsPerUpdate approximates a decision task started / completed / scheduled round trip
+	eventsPerUpdate = 3
+)
+
+const (
+	opCreateWorkflowExecution = "CreateWorkflowExecution"
+	opUpdateWorkflowExecution = "UpdateWorkflowExecution"
+	opGetTransferTasks        = "GetTransferTasks"
+	opGetTimerIndexTasks      = "GetTimerIndexTasks"
+)
+
+var benchOperations = []string{
+	opCreateWorkflowExecution, opUpdateWorkflowExecution, opGetTransferTasks, opGetTimerIndexTasks,
+}
+
+type (
+	// benchConfig is the load shape of a single bench run
+	benchConfig struct {
+		NumShards      int
+		FirstShardID   int
+		Workers        int
+		Duration       time.Duration
+		ReportInterval time.Duration
+		DomainID       string
+		// CreateWeight, UpdateWeight and GetTasksWeight are the relative frequencies of each operation
+		CreateWeight   int
+		UpdateWeight   int
+		GetTasksWeight int
+		BatchSize      int
+		// TimerTasksPerUpdate is the number of user timer tasks written with every update
+		TimerTasksPerUpdate int
+	}
+
+	// benchShard is a shard owned by the bench for the duration of the run
+	benchShard struct {
+		sync.Mutex
+		shardID            int
+		info               *p.ShardInfo
+		shardMgr           p.ShardManager
+		executionMgr       p.ExecutionManager
+		taskSequence       int64
+		maxTaskSequence    int64
+		transferReadLevel  int64
+		timerReadLevel     time.Time
+		executions         []*p.WorkflowExecutionInfo
+		transferReadLocked int32
+		timerReadLocked    int32
+	}
+
+	bench struct {
+		config     *benchConfig
+		logger     log.Logger
+		shards     []*benchShard
+		histograms map[string]*histogram
+	}
+)
+
+func newBench(
+	config *benchConfig,
+	factory persistence.Factory,
+	logger log.Logger,
+) (*bench, error) {
+	shardMgr, err := factory.NewShardManager()
+	if err != nil {
+		return nil, err
+	}
+
+	b := &bench{
+		config:     config,
+		logger:     logger,
+		histograms: make(map[string]*histogram),
+	}
+	for _, op := range benchOperations {
+		b.histograms[op] = newHistogram()
+	}
+
+	for i := 0; i < config.NumShards; i++ {
+		shardID := config.FirstShardID + i
+		executionMgr, err := factory.NewExecutionManager(shardID)
+		if err != nil {
+			return nil, err
+		}
+		shard := &benchShard{
+			shardID:        shardID,
+			shardMgr:       shardMgr,
+			executionMgr:   executionMgr,
+			timerReadLevel: time.Now(),
+		}
+		if err := shard.acquire(); err != nil {
+			return nil, fmt.Errorf("unable to acquire shard %v: %v", shardID, err)
+		}
+		b.shards = append(b.shards, shard)
+	}
+	return b, nil
+}
+
+// run drives the configured operation mix from all workers until the duration elapses
+func (b *bench) run() {
+	b.logger.Info("Starting persistence bench.",
+		tag.Number(int64(b.config.Workers)), tag.Value(b.config.Duration))
+
+	doneC := make(chan struct{})
+	var wg sync.WaitGroup
+	for i := 0; i < b.config.Workers; i++ {
+		wg.Add(1)
+		go b.worker(&wg, doneC, rand.New(rand.NewSource(time.Now().UnixNano()+int64(i))))
+	}
+
+	ticker := time.NewTicker(b.config.ReportInterval)
+	defer ticker.Stop()
+	timer := time.NewTimer(b.config.Duration)
+	defer timer.Stop()
+
+Loop:
+	for {
+		select {
+		case <-ticker.C:
+			b.report()
+		case <-timer.C:
+			break Loop
+		}
+	}
+	close(doneC)
+	wg.Wait()
+
+	fmt.Println("final results:")
+	b.report()
+}
+
+func (b *bench) worker(wg *sync.WaitGroup, doneC <-chan struct{}, rnd *rand.Rand) {
+	defer wg.Done()
+
+	totalWeight := b.config.CreateWeight + b.config.UpdateWeight + b.config.GetTasksWeight
+	for {
+		select {
+		case <-doneC:
+			return
+		default:
+		}
+
+		shard := b.shards[rnd.Intn(len(b.shards))]
+		n := rnd.Intn(totalWeight)
+		switch {
+		case n < b.config.CreateWeight:
+			b.timed(opCreateWorkflowExecution, func() error { return shard.createWorkflowExecution(b.config) })
+		case n < b.config.CreateWeight+b.config.UpdateWeight:
+			info := shard.takeExecution(rnd)
+			if info == nil {
+				// nothing to update yet, seed the shard with a new execution instead
+				b.timed(opCreateWorkflowExecution, func() error { return shard.createWorkflowExecution(b.config) })
+				continue
+			}
+			b.timed(opUpdateWorkflowExecution, func() error { return shard.updateWorkflowExecution(b.config, info) })
+		default:
+			if rnd.Intn(2) == 0 {
+				b.timed(opGetTransferTasks, func() error { return shard.getTransferTasks(b.config.BatchSize) })
+			} else {
+				b.timed(opGetTimerIndexTasks, func() error { return shard.getTimerIndexTasks(b.config.BatchSize) })
+			}
+		}
+	}
+}
+
+func (b *bench) timed(op string, fn func() error) {
+	start := time.Now()
+	err := fn()
+	b.histograms[op].record(time.Since(start), err)
+	if err != nil {
+		b.logger.Debug("Persistence bench operation failed.", tag.Value(op), tag.Error(err))
+	}
+}
+
+func (b *bench) report() {
+	for _, op := range benchOperations {
+		fmt.Printf("%-26v %v\n", op, b.histograms[op])
+	}
+}
+
+// acquire takes ownership of the shard, creating it if needed and bumping its range otherwise
+func (s *benchShard) acquire() error {
+	info := &p.ShardInfo{
+		ShardID:                 s.shardID,
+		Owner:                   benchShardOwner,
+		RangeID:                 1,
+		TimerAckLevel:           time.Time{},
+		ClusterTransferAckLevel: map[string]int64{},
+		ClusterTimerAckLevel:    map[string]time.Time{},
+	}
+	err := s.shardMgr.CreateShard(&p.CreateShardRequest{ShardInfo: info})
+	if err == nil {
+		s.setRange(info)
+		return nil
+	}
+	if _, ok := err.(*p.ShardAlreadyExistError); !ok {
+		return err
+	}
+
+	resp, err := s.shardMgr.GetShard(&p.GetShardRequest{ShardID: s.shardID})
+	if err != nil {
+		return err
+	}
+	return s.renewRange(resp.ShardInfo)
+}
+
+func (s *benchShard) renewRange(info *p.ShardInfo) error {
+	updatedInfo := *info
+	updatedInfo.Owner = benchShardOwner
+	updatedInfo.RangeID = info.RangeID + 1
+	if err := s.shardMgr.UpdateShard(&p.UpdateShardRequest{
+		ShardInfo:       &updatedInfo,
+		PreviousRangeID: info.RangeID,
+	}); err != nil {
+		return err
+	}
+	s.setRange(&updatedInfo)
+	return nil
+}
+
+func (s *benchShard) setRange(info *p.ShardInfo) {
+	s.info = info
+	s.taskSequence = info.RangeID << rangeSizeBits
+	s.maxTaskSequence = (info.RangeID + 1) << rangeSizeBits
+	if s.transferReadLevel < info.TransferAckLevel {
+		s.transferReadLevel = info.TransferAckLevel
+	}
+}
+
+// nextTaskIDs allocates count task IDs along with the range ID they are valid under
+func (s *benchShard) nextTaskIDs(count int) ([]int64, int64, error) {
+	s.Lock()
+	defer s.Unlock()
+
+	if s.taskSequence+int64(count) >= s.maxTaskSequence {
+		if err := s.renewRange(s.info); err != nil {
+			return nil, 0, err
+		}
+	}
+	taskIDs := make([]int64, count)
+	for i := range taskIDs {
+		s.taskSequence++
+		taskIDs[i] = s.taskSequence
+	}
+	return taskIDs, s.info.RangeID, nil
+}
+
+func (s *benchShard) maxReadLevel() int64 {
+	s.Lock()
+	defer s.Unlock()
+
+	return s.taskSequence
+}
+
+func (s *benchShard) putExecution(info *p.WorkflowExecutionInfo) {
+	s.Lock()
+	defer s.Unlock()
+
+	if len(s.executions) < maxExecutionsPerShard {
+		s.executions = append(s.executions, info)
+	}
+}
+
+// takeExecution removes a random execution from the pool so a single worker updates it at a time
+func (s *benchShard) takeExecution(rnd *rand.Rand) *p.WorkflowExecutionInfo {
+	s.Lock()
+	defer s.Unlock()
+
+	if len(s.executions) == 0 {
+		return nil
+	}
+	i := rnd.Intn(len(s.executions))
+	info := s.executions[i]
+	last := len(s.executions) - 1
+	s.executions[i] = s.executions[last]
+	s.executions = s.executions[:last]
+	return info
+}
+
+func (s *benchShard) createWorkflowExecution(config *benchConfig) error {
+	taskIDs, rangeID, err := s.nextTaskIDs(1)
+	if err != nil {
+		return err
+	}
+
+	decisionScheduleID := int64(2)
+	info := &p.WorkflowExecutionInfo{
+		CreateRequestID:      uuid.New(),
+		DomainID:             config.DomainID,
+		WorkflowID:           uuid.New(),
+		RunID:                uuid.New(),
+		TaskList:             benchTaskList,
+		WorkflowTypeName:     benchWorkflowType,
+		WorkflowTimeout:      3600,
+		DecisionTimeoutValue: 10,
+		State:                p.WorkflowStateRunning,
+		CloseStatus:          p.WorkflowCloseStatusNone,
+		LastFirstEventID:     common.FirstEventID,
+		NextEventID:          decisionScheduleID + 1,
+		LastProcessedEvent:   common.EmptyEventID,
+		DecisionScheduleID:   decisionScheduleID,
+		DecisionStartedID:    common.EmptyEventID,
+		DecisionTimeout:      10,
+		StartTimestamp:       time.Now(),
+		LastUpdatedTimestamp: time.Now(),
+	}
+	_, err = s.executionMgr.CreateWorkflowExecution(&p.CreateWorkflowExecutionRequest{
+		RangeID:            rangeID,
+		CreateWorkflowMode: p.CreateWorkflowModeBrandNew,
+		NewWorkflowSnapshot: p.WorkflowSnapshot{
+			ExecutionInfo:  info,
+			ExecutionStats: &p.ExecutionStats{},
+			TransferTasks: []p.Task{
+				&p.DecisionTask{
+					TaskID:              taskIDs[0],
+					DomainID:            info.DomainID,
+					TaskList:            info.TaskList,
+					ScheduleID:          decisionScheduleID,
+					VisibilityTimestamp: time.Now(),
+				},
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	s.putExecution(info)
+	return nil
+}
+
+func (s *benchShard) updateWorkflowExecution(config *benchConfig, info *p.WorkflowExecutionInfo) error {
+	taskIDs, rangeID, err := s.nextTaskIDs(1 + config.TimerTasksPerUpdate)
+	if err != nil {
+		return err
+	}
+
+	condition := info.NextEventID
+	updatedInfo := *info
+	updatedInfo.LastFirstEventID = info.NextEventID
+	updatedInfo.LastProcessedEvent = info.NextEventID + 1
+	updatedInfo.DecisionScheduleID = info.NextEventID + eventsPerUpdate - 1
+	updatedInfo.NextEventID = info.NextEventID + eventsPerUpdate
+	updatedInfo.LastUpdatedTimestamp = time.Now()
+
+	transferTasks := []p.Task{
+		&p.DecisionTask{
+			TaskID:              taskIDs[0],
+			DomainID:            updatedInfo.DomainID,
+			TaskList:            updatedInfo.TaskList,
+			ScheduleID:          updatedInfo.DecisionScheduleID,
+			VisibilityTimestamp: time.Now(),
+		},
+	}
+	var timerTasks []p.Task
+	for _, taskID := range taskIDs[1:] {
+		timerTasks = append(timerTasks, &p.UserTimerTask{
+			TaskID:              taskID,
+			VisibilityTimestamp: time.Now().Add(time.Minute),
+			EventID:             updatedInfo.DecisionScheduleID,
+		})
+	}
+
+	_, err = s.executionMgr.UpdateWorkflowExecution(&p.UpdateWorkflowExecutionRequest{
+		RangeID: rangeID,
+		UpdateWorkflowMutation: p.WorkflowMutation{
+			ExecutionInfo:  &updatedInfo,
+			ExecutionStats: &p.ExecutionStats{},
+			TransferTasks:  transferTasks,
+			TimerTasks:     timerTasks,
+			Condition:      condition,
+		},
+	})
+	if err != nil {
+		// the execution is dropped from the pool, its state is unknown after a failed update
+		return err
+	}
+	s.putExecution(&updatedInfo)
+	return nil
+}
+
+// getTransferTasks reads and completes a batch of transfer tasks, the way the transfer queue processor would
+func (s *benchShard) getTransferTasks(batchSize int) error {
+	if !atomic.CompareAndSwapInt32(&s.transferReadLocked, 0, 1) {
+		return nil
+	}
+	defer atomic.StoreInt32(&s.transferReadLocked, 0)
+
+	resp, err := s.executionMgr.GetTransferTasks(&p.GetTransferTasksRequest{
+		ReadLevel:    s.transferReadLevel,
+		MaxReadLevel: s.maxReadLevel(),
+		BatchSize:    batchSize,
+	})
+	if err != nil {
+		return err
+	}
+	if len(resp.Tasks) == 0 {
+		return nil
+	}
+
+	readLevel := resp.Tasks[len(resp.Tasks)-1].TaskID
+	if err := s.executionMgr.RangeCompleteTransferTask(&p.RangeCompleteTransferTaskRequest{
+		ExclusiveBeginTaskID: s.transferReadLevel,
+		InclusiveEndTaskID:   readLevel,
+	}); err != nil {
+		return err
+	}
+	s.transferReadLevel = readLevel
+	return nil
+}
+
+// getTimerIndexTasks reads a batch of due timer tasks, the way the timer queue processor would
+func (s *benchShard) getTimerIndexTasks(batchSize int) error {
+	if !atomic.CompareAndSwapInt32(&s.timerReadLocked, 0, 1) {
+		return nil
+	}
+	defer atomic.StoreInt32(&s.timerReadLocked, 0)
+
+	resp, err := s.executionMgr.GetTimerIndexTasks(&p.GetTimerIndexTasksRequest{
+		MinTimestamp: s.timerReadLevel,
+		MaxTimestamp: time.Now(),
+		BatchSize:    batchSize,
+	})
+	if err != nil {
+		return err
+	}
+	for _, timer := range resp.Timers {
+		if err := s.executionMgr.CompleteTimerTask(&p.CompleteTimerTaskRequest{
+			VisibilityTimestamp: timer.VisibilityTimestamp,
+			TaskID:              timer.TaskID,
+		}); err != nil {
+			return err
+		}
+		s.timerReadLevel = timer.VisibilityTimestamp
+	}
+	return nil
+}
@@ -0,0 +1,122 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package bench
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+const (
+	// histogramMinBucket is the upper bound of the first latency bucket
+	histogramMinBucket = 100 * time.Microsecond
+	// histogramNumBuckets buckets grow by a factor of two, covering up to ~100s
+	histogramNumBuckets = 21
+)
+
+type (
+	// histogram is a concurrency safe latency histogram with exponentially sized buckets
+	histogram struct {
+		sync.Mutex
+		buckets [histogramNumBuckets + 1]int64
+		count   int64
+		errors  int64
+		sum     time.Duration
+		max     time.Duration
+	}
+)
+
+func newHistogram() *histogram {
+	return &histogram{}
+}
+
+// record adds one observed operation latency to the histogram
+func (h *histogram) record(latency time.Duration, err error) {
+	h.Lock()
+	defer h.Unlock()
+
+	if err != nil {
+		h.errors++
+	}
+	h.count++
+	h.sum += latency
+	if latency > h.max {
+		h.max = latency
+	}
+	h.buckets[bucketIndex(latency)]++
+}
+
+// percentile returns the upper bound of the bucket holding the given percentile,
+// capped by the largest observed latency
+func (h *histogram) percentile(p float64) time.Duration {
+	h.Lock()
+	defer h.Unlock()
+
+	if h.count == 0 {
+		return 0
+	}
+	target := int64(float64(h.count)*p/100 + 0.5)
+	if target < 1 {
+		target = 1
+	}
+	var seen int64
+	for i, n := range h.buckets {
+		seen += n
+		if seen >= target {
+			if bound := bucketUpperBound(i); bound < h.max {
+				return bound
+			}
+			return h.max
+		}
+	}
+	return h.max
+}
+
+func (h *histogram) String() string {
+	h.Lock()
+	count, errors, sum, max := h.count, h.errors, h.sum, h.max
+	h.Unlock()
+
+	if count == 0 {
+		return "count=0"
+	}
+	return fmt.Sprintf("count=%v errors=%v avg=%v p50=%v p90=%v p99=%v max=%v",
+		count, errors, sum/time.Duration(count), h.percentile(50), h.percentile(90), h.percentile(99), max)
+}
+
+func bucketIndex(latency time.Duration) int {
+	bound := histogramMinBucket
+	for i := 0; i < histogramNumBuckets; i++ {
+		if latency <= bound {
+			return i
+		}
+		bound *= 2
+	}
+	return histogramNumBuckets
+}
+
+func bucketUpperBound(index int) time.Duration {
+	if index >= histogramNumBuckets {
+		return time.Duration(1<<63 - 1)
+	}
+	return histogramMinBucket << uint(index)
+}
@@ -0,0 +1,74 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package bench
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type (
+	histogramSuite struct {
+		suite.Suite
+		*require.Assertions
+	}
+)
+
+func TestHistogramSuite(t *testing.T) {
+	suite.Run(t, new(histogramSuite))
+}
+
+func (s *histogramSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+}
+
+func (s *histogramSuite) TestEmpty() {
+	h := newHistogram()
+	s.Equal(time.Duration(0), h.percentile(99))
+	s.Equal("count=0", h.String())
+}
+
+func (s *histogramSuite) TestPercentile() {
+	h := newHistogram()
+	for i := 0; i < 90; i++ {
+		h.record(50*time.Microsecond, nil)
+	}
+	for i := 0; i < 10; i++ {
+		h.record(3*time.Millisecond, errors.New("some random error"))
+	}
+
+	s.Equal(histogramMinBucket, h.percentile(50))
+	s.Equal(histogramMinBucket, h.percentile(90))
+	s.Equal(3*time.Millisecond, h.percentile(99))
+	s.Equal(int64(100), h.count)
+	s.Equal(int64(10), h.errors)
+}
+
+func (s *histogramSuite) TestBucketIndex() {
+	s.Equal(0, bucketIndex(0))
+	s.Equal(0, bucketIndex(histogramMinBucket))
+	s.Equal(1, bucketIndex(histogramMinBucket+1))
+	s.Equal(histogramNumBuckets, bucketIndex(time.Hour))
+}
@@ -0,0 +1,250 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package bench
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/pborman/uuid"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/log/loggerimpl"
+	"github.com/uber/cadence/common/persistence/persistence-factory"
+	"github.com/uber/cadence/common/service/config"
+	"github.com/uber/cadence/common/service/dynamicconfig"
+	"github.com/urfave/cli"
+)
+
+const (
+	flagRoot                = "root"
+	flagConfig              = "config"
+	flagEnv                 = "env"
+	flagZone                = "zone"
+	flagShards              = "shards"
+	flagFirstShardID        = "first-shard-id"
+	flagWorkers             = "workers"
+	flagDuration            = "duration"
+	flagReportInterval      = "report-interval"
+	flagDomainID            = "domain-id"
+	flagCreateWeight        = "create-weight"
+	flagUpdateWeight        = "update-weight"
+	flagGetTasksWeight      = "get-tasks-weight"
+	flagBatchSize           = "batch-size"
+	flagTimerTasksPerUpdate = "timer-tasks-per-update"
+)
+
+// RunTool runs the cadence-bench command line tool
+func RunTool(args []string) error {
+	app := buildCLIOptions()
+	return app.Run(args)
+}
+
+// root handler for all cli commands
+func cliHandler(c *cli.Context, handler func(c *cli.Context) error) {
+	if err := handler(c); err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		os.Exit(1)
+	}
+}
+
+func runBench(c *cli.Context) error {
+	benchConfig, err := newBenchConfig(c)
+	if err != nil {
+		return err
+	}
+
+	var cfg config.Config
+	configDir := c.GlobalString(flagRoot) + "/" + c.GlobalString(flagConfig)
+	if err := config.Load(c.GlobalString(flagEnv), configDir, c.GlobalString(flagZone), &cfg); err != nil {
+		return fmt.Errorf("unable to load config: %v", err)
+	}
+	if err := cfg.Persistence.Validate(); err != nil {
+		return fmt.Errorf("invalid persistence config: %v", err)
+	}
+
+	cfg.Persistence.TransactionSizeLimit = dynamicconfig.GetIntPropertyFn(common.DefaultTransactionSizeLimit)
+	cfg.Persistence.ExecutionTransactionStatementLimit = dynamicconfig.GetIntPropertyFn(common.DefaultExecutionTransactionStatementLimit)
+	cfg.Persistence.ExecutionTransactionSizeLimit = dynamicconfig.GetIntPropertyFn(common.DefaultExecutionTransactionSizeLimit)
+
+	logger := loggerimpl.NewLogger(cfg.Log.NewZapLogger())
+	factory := persistence.New(&cfg.Persistence, cfg.ClusterMetadata.CurrentClusterName, nil, logger)
+	defer factory.Close()
+
+	b, err := newBench(benchConfig, factory, logger)
+	if err != nil {
+		return err
+	}
+	b.run()
+	return nil
+}
+
+func newBenchConfig(c *cli.Context) (*benchConfig, error) {
+	benchConfig := &benchConfig{
+		NumShards:           c.Int(flagShards),
+		FirstShardID:        c.Int(flagFirstShardID),
+		Workers:             c.Int(flagWorkers),
+		Duration:            c.Duration(flagDuration),
+		ReportInterval:      c.Duration(flagReportInterval),
+		DomainID:            c.String(flagDomainID),
+		CreateWeight:        c.Int(flagCreateWeight),
+		UpdateWeight:        c.Int(flagUpdateWeight),
+		GetTasksWeight:      c.Int(flagGetTasksWeight),
+		BatchSize:           c.Int(flagBatchSize),
+		TimerTasksPerUpdate: c.Int(flagTimerTasksPerUpdate),
+	}
+	if benchConfig.DomainID == "" {
+		benchConfig.DomainID = uuid.New()
+	}
+	if err := benchConfig.validate(); err != nil {
+		return nil, err
+	}
+	return benchConfig, nil
+}
+
+func (c *benchConfig) validate() error {
+	if c.NumShards <= 0 {
+		return fmt.Errorf("%v must be positive", flagShards)
+	}
+	if c.FirstShardID < 0 {
+		return fmt.Errorf("%v cannot be negative", flagFirstShardID)
+	}
+	if c.Workers <= 0 {
+		return fmt.Errorf("%v must be positive", flagWorkers)
+	}
+	if c.Duration <= 0 || c.ReportInterval <= 0 {
+		return fmt.Errorf("%v and %v must be positive", flagDuration, flagReportInterval)
+	}
+	if c.CreateWeight < 0 || c.UpdateWeight < 0 || c.GetTasksWeight < 0 ||
+		c.CreateWeight+c.UpdateWeight+c.GetTasksWeight == 0 {
+		return fmt.Errorf("operation weights cannot be negative and at least one must be positive")
+	}
+	if c.BatchSize <= 0 {
+		return fmt.Errorf("%v must be positive", flagBatchSize)
+	}
+	if c.TimerTasksPerUpdate < 0 {
+		return fmt.Errorf("%v cannot be negative", flagTimerTasksPerUpdate)
+	}
+	return nil
+}
+
+func buildCLIOptions() *cli.App {
+
+	app := cli.NewApp()
+	app.Name = "cadence-bench"
+	app.Usage = "Synthetic load tool for the cadence persistence layer"
+	app.Version = "0.0.1"
+
+	app.Flags = []cli.Flag{
+		cli.StringFlag{
+			Name:   flagRoot,
+			Value:  ".",
+			Usage:  "root directory of execution environment",
+			EnvVar: config.EnvKeyRoot,
+		},
+		cli.StringFlag{
+			Name:   flagConfig,
+			Value:  "config",
+			Usage:  "config dir path relative to root",
+			EnvVar: config.EnvKeyConfigDir,
+		},
+		cli.StringFlag{
+			Name:   flagEnv,
+			Value:  "development",
+			Usage:  "runtime environment",
+			EnvVar: config.EnvKeyEnvironment,
+		},
+		cli.StringFlag{
+			Name:   flagZone,
+			Value:  "",
+			Usage:  "availability zone",
+			EnvVar: config.EnvKeyAvailabilityZone,
+		},
+	}
+
+	app.Commands = []cli.Command{
+		{
+			Name:  "execution",
+			Usage: "drive a mix of workflow execution writes and task reads against the execution store",
+			Flags: []cli.Flag{
+				cli.IntFlag{
+					Name:  flagShards,
+					Value: 16,
+					Usage: "number of shards to spread the load over",
+				},
+				cli.IntFlag{
+					Name:  flagFirstShardID,
+					Value: 0,
+					Usage: "ID of the first shard used; shards are taken over from their current owner",
+				},
+				cli.IntFlag{
+					Name:  flagWorkers,
+					Value: 32,
+					Usage: "number of concurrent workers issuing requests",
+				},
+				cli.DurationFlag{
+					Name:  flagDuration,
+					Value: time.Minute,
+					Usage: "duration of the run",
+				},
+				cli.DurationFlag{
+					Name:  flagReportInterval,
+					Value: 10 * time.Second,
+					Usage: "interval between intermediate latency reports",
+				},
+				cli.StringFlag{
+					Name:  flagDomainID,
+					Usage: "domain ID the executions are created in, defaults to a random ID",
+				},
+				cli.IntFlag{
+					Name:  flagCreateWeight,
+					Value: 1,
+					Usage: "relative frequency of CreateWorkflowExecution",
+				},
+				cli.IntFlag{
+					Name:  flagUpdateWeight,
+					Value: 6,
+					Usage: "relative frequency of UpdateWorkflowExecution",
+				},
+				cli.IntFlag{
+					Name:  flagGetTasksWeight,
+					Value: 3,
+					Usage: "relative frequency of GetTransferTasks and GetTimerIndexTasks",
+				},
+				cli.IntFlag{
+					Name:  flagBatchSize,
+					Value: 100,
+					Usage: "batch size used when reading tasks",
+				},
+				cli.IntFlag{
+					Name:  flagTimerTasksPerUpdate,
+					Value: 1,
+					Usage: "number of timer tasks written with every update",
+				},
+			},
+			Action: func(c *cli.Context) {
+				cliHandler(c, runBench)
+			},
+		},
+	}
+
+	return app
+}
@@ -60,6 +60,58 @@ func VerifyCompatibleVersion(cfg config.Persistence, rootPath string) error {
 	return nil
 }
 
+// SetupLatestSchema creates the cadence and visibility keyspaces if they do not exist and updates
+// their schema to the latest version, setting up the version tables on first use.
+// It is meant for local development, where the server owns its keyspaces.
+func SetupLatestSchema(cfg config.Persistence, rootPath string) error {
+	ds, ok := cfg.DataStores[cfg.DefaultStore]
+	if ok && ds.Cassandra != nil {
+		schemaPath := path.Join(rootPath, "schema/cassandra/cadence/versioned")
+		if err := setupLatestSchema(*ds.Cassandra, schemaPath); err != nil {
+			return err
+		}
+	}
+	ds, ok = cfg.DataStores[cfg.VisibilityStore]
+	if ok && ds.Cassandra != nil {
+		schemaPath := path.Join(rootPath, "schema/cassandra/visibility/versioned")
+		return setupLatestSchema(*ds.Cassandra, schemaPath)
+	}
+	return nil
+}
+
+// setupLatestSchema creates the keyspace if needed and updates its schema to the latest version
+func setupLatestSchema(cfg config.Cassandra, dirPath string) error {
+	clientCfg := CQLClientConfig{
+		Hosts:    cfg.Hosts,
+		Port:     cfg.Port,
+		User:     cfg.User,
+		Password: cfg.Password,
+		Keyspace: cfg.Keyspace,
+		Timeout:  defaultTimeout,
+	}
+	if err := validateCQLClientConfig(&clientCfg, false); err != nil {
+		return err
+	}
+	if err := doCreateKeyspace(clientCfg, cfg.Keyspace); err != nil {
+		return fmt.Errorf("unable to create keyspace %v: %v", cfg.Keyspace, err.Error())
+	}
+	client, err := newCQLClient(&clientCfg)
+	if err != nil {
+		return fmt.Errorf("unable to create CQL Client: %v", err.Error())
+	}
+	defer client.Close()
+
+	// a keyspace without schema version is new, start it from the initial version
+	if _, err := client.ReadSchemaVersion(); err != nil {
+		setupConfig := &schema.SetupConfig{InitialVersion: "0.0"}
+		if err := schema.SetupFromConfig(setupConfig, client); err != nil {
+			return err
+		}
+	}
+	updateConfig := &schema.UpdateConfig{DBName: cfg.Keyspace, SchemaDir: dirPath}
+	return schema.UpdateFromConfig(updateConfig, client)
+}
+
 // checkCompatibleVersion check the version compatibility
 func checkCompatibleVersion(cfg config.Cassandra, keyspace string, dirPath string) error {
 	client, err := newCQLClient(&CQLClientConfig{
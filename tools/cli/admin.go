@@ -106,6 +106,58 @@ func newAdminWorkflowCommands() []cli.Command {
 				AdminDescribeWorkflow(c)
 			},
 		},
+		{
+			Name:  "describe-shard",
+			Usage: "Describe the shard of a workflow: owner, ack levels and whether the mutable state exists",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  FlagDomainID,
+					Usage: "DomainID",
+				},
+				cli.StringFlag{
+					Name:  FlagWorkflowIDWithAlias,
+					Usage: "WorkflowID",
+				},
+			},
+			Action: func(c *cli.Context) {
+				AdminDescribeWorkflowShard(c)
+			},
+		},
+		{
+			Name:  "list-runs",
+			Usage: "List all runs of a workflow ID known to the execution store, ordered by start time",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  FlagWorkflowIDWithAlias,
+					Usage: "WorkflowID",
+				},
+			},
+			Action: func(c *cli.Context) {
+				AdminListWorkflowExecutionRuns(c)
+			},
+		},
+		{
+			Name:  "list-recently-closed",
+			Usage: "List the executions of a shard closed since the earliest time, in close time order",
+			Flags: []cli.Flag{
+				cli.IntFlag{
+					Name:  FlagShardIDWithAlias,
+					Usage: "ShardID",
+				},
+				cli.StringFlag{
+					Name:  FlagEarliestTimeWithAlias,
+					Usage: "EarliestTime of close time, at most 7 days ago, supported formats are '2006-01-02T15:04:05Z07:00' and raw UnixNano",
+				},
+				cli.IntFlag{
+					Name:  FlagPageSizeWithAlias,
+					Value: 100,
+					Usage: "Number of executions read per page",
+				},
+			},
+			Action: func(c *cli.Context) {
+				AdminListRecentlyClosedExecutions(c)
+			},
+		},
 		{
 			Name:    "delete",
 			Aliases: []string{"del"},
@@ -123,6 +175,10 @@ func newAdminWorkflowCommands() []cli.Command {
 					Name:  FlagSkipErrorModeWithAlias,
 					Usage: "skip errors when deleting history",
 				},
+				cli.BoolFlag{
+					Name:  FlagForceCurrent,
+					Usage: "delete the current row even if it points to another run",
+				},
 
 				// for cassandra connection
 				cli.StringFlag{
@@ -151,6 +207,273 @@ func newAdminWorkflowCommands() []cli.Command {
 				AdminDeleteWorkflow(c)
 			},
 		},
+		{
+			Name:  "export",
+			Usage: "Export the mutable state and history of a workflow execution to a snapshot file",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  FlagWorkflowIDWithAlias,
+					Usage: "WorkflowID",
+				},
+				cli.StringFlag{
+					Name:  FlagRunIDWithAlias,
+					Usage: "RunID",
+				},
+				cli.StringFlag{
+					Name:  FlagOutputFilenameWithAlias,
+					Usage: "Snapshot file to write",
+				},
+
+				// for cassandra connection
+				cli.StringFlag{
+					Name:  FlagAddress,
+					Usage: "cassandra host address",
+				},
+				cli.IntFlag{
+					Name:  FlagPort,
+					Value: 9042,
+					Usage: "cassandra port for the host",
+				},
+				cli.StringFlag{
+					Name:  FlagUsername,
+					Usage: "cassandra username",
+				},
+				cli.StringFlag{
+					Name:  FlagPassword,
+					Usage: "cassandra password",
+				},
+				cli.StringFlag{
+					Name:  FlagKeyspace,
+					Usage: "cassandra keyspace",
+				},
+			},
+			Action: func(c *cli.Context) {
+				AdminExportWorkflow(c)
+			},
+		},
+		{
+			Name:  "import",
+			Usage: "Import a closed workflow execution from a snapshot file, the run becomes the current run of the workflow",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  FlagInputFileWithAlias,
+					Usage: "Snapshot file to read",
+				},
+
+				// for cassandra connection
+				cli.StringFlag{
+					Name:  FlagAddress,
+					Usage: "cassandra host address",
+				},
+				cli.IntFlag{
+					Name:  FlagPort,
+					Value: 9042,
+					Usage: "cassandra port for the host",
+				},
+				cli.StringFlag{
+					Name:  FlagUsername,
+					Usage: "cassandra username",
+				},
+				cli.StringFlag{
+					Name:  FlagPassword,
+					Usage: "cassandra password",
+				},
+				cli.StringFlag{
+					Name:  FlagKeyspace,
+					Usage: "cassandra keyspace",
+				},
+			},
+			Action: func(c *cli.Context) {
+				AdminImportWorkflow(c)
+			},
+		},
+		{
+			Name:  "restore",
+			Usage: "Recreate a closed workflow execution from a snapshot file as a new run of its workflow in the domain",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  FlagInputFileWithAlias,
+					Usage: "Snapshot file to read",
+				},
+			},
+			Action: func(c *cli.Context) {
+				AdminRestoreWorkflow(c)
+			},
+		},
+		{
+			Name:  "purge",
+			Usage: "Permanently delete every trace of a closed workflow run and show the verified deletions",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  FlagWorkflowIDWithAlias,
+					Usage: "WorkflowID",
+				},
+				cli.StringFlag{
+					Name:  FlagRunIDWithAlias,
+					Usage: "RunID",
+				},
+			},
+			Action: func(c *cli.Context) {
+				AdminPurgeWorkflow(c)
+			},
+		},
+		{
+			Name:  "pause-activity",
+			Usage: "Pause a pending activity so that it is not dispatched to workers",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  FlagWorkflowIDWithAlias,
+					Usage: "WorkflowID",
+				},
+				cli.StringFlag{
+					Name:  FlagRunIDWithAlias,
+					Usage: "RunID",
+				},
+				cli.StringFlag{
+					Name:  FlagActivityIDWithAlias,
+					Usage: "ActivityID",
+				},
+			},
+			Action: func(c *cli.Context) {
+				AdminSetActivityPaused(c, true)
+			},
+		},
+		{
+			Name:  "unpause-activity",
+			Usage: "Unpause a paused activity and dispatch it again",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  FlagWorkflowIDWithAlias,
+					Usage: "WorkflowID",
+				},
+				cli.StringFlag{
+					Name:  FlagRunIDWithAlias,
+					Usage: "RunID",
+				},
+				cli.StringFlag{
+					Name:  FlagActivityIDWithAlias,
+					Usage: "ActivityID",
+				},
+			},
+			Action: func(c *cli.Context) {
+				AdminSetActivityPaused(c, false)
+			},
+		},
+		{
+			Name:  "set-notes",
+			Usage: "Attach operator notes to a workflow execution, empty notes clear them",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  FlagWorkflowIDWithAlias,
+					Usage: "WorkflowID",
+				},
+				cli.StringFlag{
+					Name:  FlagRunIDWithAlias,
+					Usage: "RunID",
+				},
+				cli.StringFlag{
+					Name:  FlagNotes,
+					Usage: "Notes to attach to the workflow execution",
+				},
+			},
+			Action: func(c *cli.Context) {
+				AdminSetWorkflowExecutionNotes(c)
+			},
+		},
+		{
+			Name:  "pause",
+			Usage: "Pause a workflow execution so that no decision task is dispatched to workers",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  FlagWorkflowIDWithAlias,
+					Usage: "WorkflowID",
+				},
+				cli.StringFlag{
+					Name:  FlagRunIDWithAlias,
+					Usage: "RunID",
+				},
+			},
+			Action: func(c *cli.Context) {
+				AdminSetWorkflowExecutionPaused(c, true)
+			},
+		},
+		{
+			Name:  "resume",
+			Usage: "Resume a paused workflow execution and dispatch its pending decision again",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  FlagWorkflowIDWithAlias,
+					Usage: "WorkflowID",
+				},
+				cli.StringFlag{
+					Name:  FlagRunIDWithAlias,
+					Usage: "RunID",
+				},
+			},
+			Action: func(c *cli.Context) {
+				AdminSetWorkflowExecutionPaused(c, false)
+			},
+		},
+		{
+			Name:  "pause-cron",
+			Usage: "Pause the cron schedule of a workflow, runs waiting for the schedule do not start until it is resumed",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  FlagWorkflowIDWithAlias,
+					Usage: "WorkflowID",
+				},
+				cli.StringFlag{
+					Name:  FlagRunIDWithAlias,
+					Usage: "RunID",
+				},
+			},
+			Action: func(c *cli.Context) {
+				AdminSetCronSchedulePaused(c, true)
+			},
+		},
+		{
+			Name:  "resume-cron",
+			Usage: "Resume the cron schedule of a workflow",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  FlagWorkflowIDWithAlias,
+					Usage: "WorkflowID",
+				},
+				cli.StringFlag{
+					Name:  FlagRunIDWithAlias,
+					Usage: "RunID",
+				},
+			},
+			Action: func(c *cli.Context) {
+				AdminSetCronSchedulePaused(c, false)
+			},
+		},
+		{
+			Name:  "terminate-all",
+			Usage: "Terminate the current run of a workflow ID and block it from starting new runs for a while",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  FlagWorkflowIDWithAlias,
+					Usage: "WorkflowID",
+				},
+				cli.StringFlag{
+					Name:  FlagFirstExecutionRunID,
+					Usage: "Only terminate if the current run belongs to the chain started by this run",
+				},
+				cli.StringFlag{
+					Name:  FlagReasonWithAlias,
+					Usage: "The reason you want to terminate the workflow",
+				},
+				cli.IntFlag{
+					Name:  FlagBlockSeconds,
+					Value: 60,
+					Usage: "Seconds during which the workflow ID cannot start new runs",
+				},
+			},
+			Action: func(c *cli.Context) {
+				AdminTerminateAllWorkflowRuns(c)
+			},
+		},
 	}
 }
 
@@ -182,6 +505,32 @@ func newAdminHistoryHostCommands() []cli.Command {
 				AdminDescribeHistoryHost(c)
 			},
 		},
+		{
+			Name:  "drain",
+			Usage: "Release the shards of a history host and stop it from acquiring shards, before stopping the host",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  FlagHistoryAddressWithAlias,
+					Usage: "History Host address(IP:PORT)",
+				},
+			},
+			Action: func(c *cli.Context) {
+				AdminDrainHistoryHost(c)
+			},
+		},
+		{
+			Name:  "undrain",
+			Usage: "Add a drained history host back to the membership ring, so it acquires shards again",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  FlagHistoryAddressWithAlias,
+					Usage: "History Host address(IP:PORT)",
+				},
+			},
+			Action: func(c *cli.Context) {
+				AdminUndrainHistoryHost(c)
+			},
+		},
 		{
 			Name:    "getshard",
 			Aliases: []string{"gsh"},
@@ -245,6 +594,42 @@ func newAdminDomainCommands() []cli.Command {
 				AdminGetDomainIDOrName(c)
 			},
 		},
+		{
+			Name:    "clientversions",
+			Aliases: []string{"cv"},
+			Usage:   "List the client implementations and versions which completed decisions, per domain",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  FlagDomain,
+					Usage: "DomainName, all domains are listed when not set",
+				},
+				cli.StringFlag{
+					Name:  FlagHistoryAddressWithAlias,
+					Usage: "History Host address(IP:PORT), all history hosts are queried when not set",
+				},
+			},
+			Action: func(c *cli.Context) {
+				AdminDescribeClientVersions(c)
+			},
+		},
+		{
+			Name:    "openexecutions",
+			Aliases: []string{"oe"},
+			Usage:   "Show the approximate number of open executions of a domain",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  FlagDomain,
+					Usage: "DomainName",
+				},
+				cli.StringFlag{
+					Name:  FlagHistoryAddressWithAlias,
+					Usage: "History Host address(IP:PORT), all history hosts are queried when not set",
+				},
+			},
+			Action: func(c *cli.Context) {
+				AdminGetDomainOpenExecutionCount(c)
+			},
+		},
 	}
 }
 
@@ -544,5 +929,228 @@ func newAdminTaskListCommands() []cli.Command {
 				AdminDescribeTaskList(c)
 			},
 		},
+		{
+			Name:    "scaling-hints",
+			Aliases: []string{"sh"},
+			Usage:   "Show the backlog and dispatch rate of tasklist, used to scale its pollers",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  FlagTaskListWithAlias,
+					Usage: "TaskList",
+				},
+				cli.StringFlag{
+					Name:  FlagTaskListTypeWithAlias,
+					Value: "decision",
+					Usage: "Optional TaskList type [decision|activity]",
+				},
+			},
+			Action: func(c *cli.Context) {
+				AdminGetTaskListScalingHints(c)
+			},
+		},
+		{
+			Name:    "journey",
+			Aliases: []string{"j"},
+			Usage:   "Show the stages an activity or decision task of tasklist recently went through in matching",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  FlagTaskListWithAlias,
+					Usage: "TaskList",
+				},
+				cli.StringFlag{
+					Name:  FlagTaskListTypeWithAlias,
+					Value: "decision",
+					Usage: "Optional TaskList type [decision|activity]",
+				},
+				cli.StringFlag{
+					Name:  FlagWorkflowIDWithAlias,
+					Usage: "WorkflowID of the execution the task was created for",
+				},
+				cli.StringFlag{
+					Name:  FlagRunIDWithAlias,
+					Usage: "RunID of the execution the task was created for",
+				},
+				cli.Int64Flag{
+					Name:  FlagEventIDWithAlias,
+					Usage: "ID of the event which scheduled the task, used with workflow_id and run_id",
+				},
+				cli.Int64Flag{
+					Name:  FlagTaskID,
+					Usage: "ID of the persisted task, used instead of workflow_id, run_id and event_id",
+				},
+			},
+			Action: func(c *cli.Context) {
+				AdminDescribeTaskJourney(c)
+			},
+		},
+		{
+			Name:    "update-version-sets",
+			Aliases: []string{"uvs"},
+			Usage:   "Replace the worker build ID version sets routing the decision tasks of tasklist",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  FlagTaskListWithAlias,
+					Usage: "TaskList",
+				},
+				cli.StringSliceFlag{
+					Name: FlagVersionSet,
+					Usage: "Comma separated compatible worker build IDs, default build last. Repeat the flag for each set, " +
+						"newest set last. Without this flag the version sets are removed",
+				},
+			},
+			Action: func(c *cli.Context) {
+				AdminUpdateTaskListVersionSets(c)
+			},
+		},
+	}
+}
+
+func newAdminScheduleCommands() []cli.Command {
+	return []cli.Command{
+		{
+			Name:  "create",
+			Usage: "Create a schedule periodically starting a workflow",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  FlagScheduleIDWithAlias,
+					Usage: "ScheduleID",
+				},
+				cli.StringFlag{
+					Name:  FlagCronSchedule,
+					Usage: "Cron schedule of the workflow starts, in the standard cron format",
+				},
+				cli.StringFlag{
+					Name:  FlagTaskListWithAlias,
+					Usage: "TaskList",
+				},
+				cli.StringFlag{
+					Name:  FlagWorkflowTypeWithAlias,
+					Usage: "WorkflowTypeName",
+				},
+				cli.IntFlag{
+					Name:  FlagExecutionTimeoutWithAlias,
+					Usage: "Execution start to close timeout in seconds",
+				},
+				cli.IntFlag{
+					Name:  FlagDecisionTimeoutWithAlias,
+					Value: defaultDecisionTimeoutInSeconds,
+					Usage: "Decision task start to close timeout in seconds",
+				},
+				cli.StringFlag{
+					Name:  FlagInputWithAlias,
+					Usage: "Optional input for the workflow, in JSON format. If there are multiple parameters, concatenate them and separate by space.",
+				},
+				cli.StringFlag{
+					Name: FlagInputFileWithAlias,
+					Usage: "Optional input for the workflow from JSON file. If there are multiple JSON, concatenate them and separate by space or newline. " +
+						"Input from file will be overwrite by input from command line",
+				},
+				cli.StringFlag{
+					Name:  FlagOverlapPolicy,
+					Value: "skip",
+					Usage: "What to do when a fire time comes while the previous workflow is running: skip, allow_all, terminate_other or cancel_other",
+				},
+				cli.IntFlag{
+					Name:  FlagCatchupWindow,
+					Usage: "Seconds during which a missed fire time is still started, default to 5 minutes",
+				},
+			},
+			Action: func(c *cli.Context) {
+				AdminCreateSchedule(c)
+			},
+		},
+		{
+			Name:    "describe",
+			Aliases: []string{"desc"},
+			Usage:   "Describe a schedule",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  FlagScheduleIDWithAlias,
+					Usage: "ScheduleID",
+				},
+			},
+			Action: func(c *cli.Context) {
+				AdminDescribeSchedule(c)
+			},
+		},
+		{
+			Name:  "delete",
+			Usage: "Delete a schedule",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  FlagScheduleIDWithAlias,
+					Usage: "ScheduleID",
+				},
+			},
+			Action: func(c *cli.Context) {
+				AdminDeleteSchedule(c)
+			},
+		},
+		{
+			Name:    "list",
+			Aliases: []string{"l"},
+			Usage:   "List the schedules of a domain",
+			Flags: []cli.Flag{
+				cli.IntFlag{
+					Name:  FlagPageSizeWithAlias,
+					Value: 100,
+					Usage: "Result page size",
+				},
+			},
+			Action: func(c *cli.Context) {
+				AdminListSchedules(c)
+			},
+		},
+		{
+			Name:  "pause",
+			Usage: "Pause a schedule, its fire times are skipped until it is resumed",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  FlagScheduleIDWithAlias,
+					Usage: "ScheduleID",
+				},
+			},
+			Action: func(c *cli.Context) {
+				AdminSetSchedulePaused(c, true)
+			},
+		},
+		{
+			Name:  "resume",
+			Usage: "Resume a paused schedule",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  FlagScheduleIDWithAlias,
+					Usage: "ScheduleID",
+				},
+			},
+			Action: func(c *cli.Context) {
+				AdminSetSchedulePaused(c, false)
+			},
+		},
+		{
+			Name:  "backfill",
+			Usage: "Start the workflows of every fire time of a schedule in a past time range",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  FlagScheduleIDWithAlias,
+					Usage: "ScheduleID",
+				},
+				cli.StringFlag{
+					Name:  FlagEarliestTimeWithAlias,
+					Usage: "Start of the time range, in time format '2006-01-02T15:04:05+07:00' or raw UnixNano",
+				},
+				cli.StringFlag{
+					Name:  FlagLatestTimeWithAlias,
+					Usage: "End of the time range, in time format '2006-01-02T15:04:05+07:00' or raw UnixNano",
+				},
+				cli.IntFlag{
+					Name:  FlagRunsPerMinute,
+					Usage: "Maximum number of workflows started per minute by the backfill, default to 10",
+				},
+			},
+			Action: func(c *cli.Context) {
+				AdminBackfillSchedule(c)
+			},
+		},
 	}
 }
@@ -24,10 +24,12 @@ import (
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
+	"os"
 	"strconv"
 	"time"
 
 	"github.com/gocql/gocql"
+	"github.com/olekukonko/tablewriter"
 	"github.com/uber/cadence/.gen/go/admin"
 	"github.com/uber/cadence/.gen/go/shared"
 	"github.com/uber/cadence/common"
@@ -35,6 +37,7 @@ import (
 	"github.com/uber/cadence/common/log/loggerimpl"
 	"github.com/uber/cadence/common/persistence"
 	cassp "github.com/uber/cadence/common/persistence/cassandra"
+	"github.com/uber/cadence/common/service/dynamicconfig"
 	"github.com/uber/cadence/tools/cassandra"
 	"github.com/urfave/cli"
 )
@@ -182,6 +185,219 @@ func describeMutableState(c *cli.Context) *admin.DescribeWorkflowExecutionRespon
 	return resp
 }
 
+// AdminDescribeWorkflowShard describes the shard of a workflow
+func AdminDescribeWorkflowShard(c *cli.Context) {
+	adminClient := cFactory.ServerAdminClient(c)
+
+	domainID := getRequiredOption(c, FlagDomainID)
+	wid := getRequiredOption(c, FlagWorkflowID)
+
+	ctx, cancel := newContext(c)
+	defer cancel()
+
+	resp, err := adminClient.DescribeWorkflowShard(ctx, &admin.DescribeWorkflowShardRequest{
+		DomainId:   common.StringPtr(domainID),
+		WorkflowId: common.StringPtr(wid),
+	})
+	if err != nil {
+		ErrorAndExit("Describe workflow shard failed", err)
+	}
+	prettyPrintJSONObject(resp)
+}
+
+// AdminListWorkflowExecutionRuns lists all runs of a workflow ID
+func AdminListWorkflowExecutionRuns(c *cli.Context) {
+	adminClient := cFactory.ServerAdminClient(c)
+
+	domain := getRequiredGlobalOption(c, FlagDomain)
+	wid := getRequiredOption(c, FlagWorkflowID)
+
+	ctx, cancel := newContext(c)
+	defer cancel()
+
+	resp, err := adminClient.ListWorkflowExecutionRuns(ctx, &admin.ListWorkflowExecutionRunsRequest{
+		Domain:     common.StringPtr(domain),
+		WorkflowId: common.StringPtr(wid),
+	})
+	if err != nil {
+		ErrorAndExit("List workflow execution runs failed", err)
+	}
+	prettyPrintJSONObject(resp)
+}
+
+// AdminListRecentlyClosedExecutions lists the executions of a shard closed since the earliest time
+func AdminListRecentlyClosedExecutions(c *cli.Context) {
+	adminClient := cFactory.ServerAdminClient(c)
+
+	if !c.IsSet(FlagShardID) {
+		ErrorAndExit("shardID is required", nil)
+	}
+	sid := c.Int(FlagShardID)
+	closedAfter := parseTime(getRequiredOption(c, FlagEarliestTime), 0)
+
+	var token []byte
+	for {
+		ctx, cancel := newContext(c)
+		resp, err := adminClient.ListRecentlyClosedExecutions(ctx, &admin.ListRecentlyClosedExecutionsRequest{
+			ShardId:         common.Int32Ptr(int32(sid)),
+			ClosedAfter:     common.Int64Ptr(closedAfter),
+			MaximumPageSize: common.Int32Ptr(int32(c.Int(FlagPageSize))),
+			NextPageToken:   token,
+		})
+		cancel()
+		if err != nil {
+			ErrorAndExit("List recently closed executions failed", err)
+		}
+		for _, execution := range resp.Executions {
+			prettyPrintJSONObject(execution)
+		}
+		token = resp.NextPageToken
+		if len(token) == 0 {
+			break
+		}
+	}
+}
+
+// AdminExportWorkflow exports a workflow execution to a snapshot file
+func AdminExportWorkflow(c *cli.Context) {
+	wid := getRequiredOption(c, FlagWorkflowID)
+	outputFileName := getRequiredOption(c, FlagOutputFilename)
+
+	resp := describeMutableState(c)
+	ms := persistence.WorkflowMutableState{}
+	if err := json.Unmarshal([]byte(resp.GetMutableStateInDatabase()), &ms); err != nil {
+		ErrorAndExit("json.Unmarshal err", err)
+	}
+	shardID, err := strconv.Atoi(resp.GetShardId())
+	if err != nil {
+		ErrorAndExit("strconv.Atoi(shardID) err", err)
+	}
+
+	session := connectToCassandra(c)
+	exeMgr, historyV2Mgr := newExportExecutionManagers(shardID, session)
+	export, err := persistence.ExportWorkflowExecution(exeMgr, historyV2Mgr, ms.ExecutionInfo.DomainID, shared.WorkflowExecution{
+		WorkflowId: common.StringPtr(wid),
+		RunId:      common.StringPtr(ms.ExecutionInfo.RunID),
+	})
+	if err != nil {
+		ErrorAndExit("Export workflow execution failed", err)
+	}
+	data, err := persistence.EncodeExecutionExport(export)
+	if err != nil {
+		ErrorAndExit("Encode workflow execution export failed", err)
+	}
+	if err := ioutil.WriteFile(outputFileName, data, 0666); err != nil {
+		ErrorAndExit("Failed to write snapshot file.", err)
+	}
+	fmt.Printf("exported workflow %v run %v to %v\n", wid, ms.ExecutionInfo.RunID, outputFileName)
+}
+
+// AdminImportWorkflow imports a closed workflow execution from a snapshot file
+func AdminImportWorkflow(c *cli.Context) {
+	adminClient := cFactory.ServerAdminClient(c)
+
+	inputFileName := getRequiredOption(c, FlagInputFile)
+	data, err := ioutil.ReadFile(inputFileName)
+	if err != nil {
+		ErrorAndExit("Failed to read snapshot file.", err)
+	}
+	export, err := persistence.DecodeExecutionExport(data)
+	if err != nil {
+		ErrorAndExit("Decode workflow execution export failed", err)
+	}
+	executionInfo := export.MutableState.ExecutionInfo
+
+	ctx, cancel := newContext(c)
+	defer cancel()
+	shard, err := adminClient.DescribeWorkflowShard(ctx, &admin.DescribeWorkflowShardRequest{
+		DomainId:   common.StringPtr(executionInfo.DomainID),
+		WorkflowId: common.StringPtr(executionInfo.WorkflowID),
+	})
+	if err != nil {
+		ErrorAndExit("Describe workflow shard failed", err)
+	}
+
+	session := connectToCassandra(c)
+	exeMgr, historyV2Mgr := newExportExecutionManagers(int(shard.GetShardId()), session)
+	if err := persistence.ImportWorkflowExecution(exeMgr, historyV2Mgr, shard.GetRangeId(), export); err != nil {
+		ErrorAndExit("Import workflow execution failed", err)
+	}
+	fmt.Printf("imported workflow %v run %v\n", executionInfo.WorkflowID, executionInfo.RunID)
+}
+
+// AdminRestoreWorkflow recreates a closed workflow execution from a snapshot file through the admin service
+func AdminRestoreWorkflow(c *cli.Context) {
+	domain := getRequiredGlobalOption(c, FlagDomain)
+	inputFileName := getRequiredOption(c, FlagInputFile)
+	data, err := ioutil.ReadFile(inputFileName)
+	if err != nil {
+		ErrorAndExit("Failed to read snapshot file.", err)
+	}
+
+	adminClient := cFactory.ServerAdminClient(c)
+	ctx, cancel := newContext(c)
+	defer cancel()
+	resp, err := adminClient.ImportWorkflowExecution(ctx, &admin.ImportWorkflowExecutionRequest{
+		Domain:   common.StringPtr(domain),
+		Snapshot: data,
+	})
+	if err != nil {
+		ErrorAndExit("Restore workflow execution failed", err)
+	}
+	fmt.Printf("restored workflow as run %v\n", resp.GetRunId())
+}
+
+// AdminPurgeWorkflow permanently deletes every trace of a closed workflow run through the admin service
+func AdminPurgeWorkflow(c *cli.Context) {
+	domain := getRequiredGlobalOption(c, FlagDomain)
+	wid := getRequiredOption(c, FlagWorkflowID)
+	rid := getRequiredOption(c, FlagRunID)
+
+	adminClient := cFactory.ServerAdminClient(c)
+	ctx, cancel := newContext(c)
+	defer cancel()
+	resp, err := adminClient.PurgeWorkflowExecution(ctx, &admin.PurgeWorkflowExecutionRequest{
+		Domain: common.StringPtr(domain),
+		Execution: &shared.WorkflowExecution{
+			WorkflowId: common.StringPtr(wid),
+			RunId:      common.StringPtr(rid),
+		},
+	})
+	if err != nil {
+		ErrorAndExit("Purge workflow execution failed", err)
+	}
+
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetBorder(false)
+	table.SetColumnSeparator("|")
+	table.SetHeader([]string{"Step", "Deleted", "Verified", "Details"})
+	table.SetHeaderLine(false)
+	table.SetHeaderColor(tableHeaderBlue, tableHeaderBlue, tableHeaderBlue, tableHeaderBlue)
+	for _, step := range resp.GetSteps() {
+		table.Append([]string{
+			step.GetName(),
+			strconv.FormatInt(step.GetDeletedCount(), 10),
+			strconv.FormatBool(step.GetVerified()),
+			step.GetDetails(),
+		})
+	}
+	table.Render()
+}
+
+func newExportExecutionManagers(shardID int, session *gocql.Session) (persistence.ExecutionManager, persistence.HistoryV2Manager) {
+	histV2 := cassp.NewHistoryV2PersistenceFromSession(session, loggerimpl.NewNopLogger())
+	historyV2Mgr := persistence.NewHistoryV2ManagerImpl(histV2, loggerimpl.NewNopLogger(), dynamicconfig.GetIntPropertyFn(common.DefaultTransactionSizeLimit))
+
+	exeStore, err := cassp.NewWorkflowExecutionPersistence(shardID, session, loggerimpl.NewNopLogger())
+	if err != nil {
+		ErrorAndExit("Failed to create execution store", err)
+	}
+	exeMgr := persistence.NewExecutionManagerImpl(exeStore, loggerimpl.NewNopLogger(),
+		dynamicconfig.GetIntPropertyFn(common.DefaultExecutionTransactionStatementLimit),
+		dynamicconfig.GetIntPropertyFn(common.DefaultExecutionTransactionSizeLimit))
+	return exeMgr, historyV2Mgr
+}
+
 // AdminDeleteWorkflow describe a new workflow execution for admin
 func AdminDeleteWorkflow(c *cli.Context) {
 	wid := getRequiredOption(c, FlagWorkflowID)
@@ -262,17 +478,25 @@ func AdminDeleteWorkflow(c *cli.Context) {
 		DomainID:   domainID,
 		WorkflowID: wid,
 		RunID:      rid,
+		Force:      c.Bool(FlagForceCurrent),
 	}
 
-	err = exeStore.DeleteCurrentWorkflowExecution(deleteCurrentReq)
+	deleteCurrentResp, err := exeStore.DeleteCurrentWorkflowExecution(deleteCurrentReq)
 	if err != nil {
 		if skipError {
 			fmt.Println("delete current row failed, ", err)
-		} else {
-			ErrorAndExit("delete current row failed", err)
+			return
 		}
+		ErrorAndExit("delete current row failed", err)
+	}
+	switch deleteCurrentResp.Result {
+	case persistence.DeleteCurrentWorkflowExecutionResultMismatched:
+		fmt.Printf("current row points to run %v, skipped deleting it\n", deleteCurrentResp.CurrentRunID)
+	case persistence.DeleteCurrentWorkflowExecutionResultMissing:
+		fmt.Println("current row not found, skipped deleting it")
+	default:
+		fmt.Println("delete current row successfully")
 	}
-	fmt.Println("delete current row successfully")
 }
 
 func readOneRow(query *gocql.Query) (map[string]interface{}, error) {
@@ -304,6 +528,141 @@ func connectToCassandra(c *cli.Context) *gocql.Session {
 	return session
 }
 
+// AdminSetActivityPaused pauses or unpauses a pending activity
+func AdminSetActivityPaused(c *cli.Context, paused bool) {
+	adminClient := cFactory.ServerAdminClient(c)
+
+	domain := getRequiredGlobalOption(c, FlagDomain)
+	wid := getRequiredOption(c, FlagWorkflowID)
+	rid := c.String(FlagRunID)
+	aid := getRequiredOption(c, FlagActivityID)
+
+	ctx, cancel := newContext(c)
+	defer cancel()
+
+	err := adminClient.SetActivityPaused(ctx, &shared.SetActivityPausedRequest{
+		Domain: common.StringPtr(domain),
+		Execution: &shared.WorkflowExecution{
+			WorkflowId: common.StringPtr(wid),
+			RunId:      common.StringPtr(rid),
+		},
+		ActivityID: common.StringPtr(aid),
+		Paused:     common.BoolPtr(paused),
+	})
+	if err != nil {
+		ErrorAndExit("Set activity paused failed", err)
+	}
+	fmt.Println("Success.")
+}
+
+// AdminSetWorkflowExecutionNotes attaches notes to a workflow execution
+func AdminSetWorkflowExecutionNotes(c *cli.Context) {
+	adminClient := cFactory.ServerAdminClient(c)
+
+	domain := getRequiredGlobalOption(c, FlagDomain)
+	wid := getRequiredOption(c, FlagWorkflowID)
+	rid := c.String(FlagRunID)
+	notes := c.String(FlagNotes)
+
+	ctx, cancel := newContext(c)
+	defer cancel()
+
+	err := adminClient.SetWorkflowExecutionNotes(ctx, &shared.SetWorkflowExecutionNotesRequest{
+		Domain: common.StringPtr(domain),
+		Execution: &shared.WorkflowExecution{
+			WorkflowId: common.StringPtr(wid),
+			RunId:      common.StringPtr(rid),
+		},
+		Notes:    common.StringPtr(notes),
+		Identity: common.StringPtr(getCliIdentity()),
+	})
+	if err != nil {
+		ErrorAndExit("Set workflow execution notes failed", err)
+	}
+	fmt.Println("Success.")
+}
+
+// AdminSetWorkflowExecutionPaused pauses or resumes a workflow execution
+func AdminSetWorkflowExecutionPaused(c *cli.Context, paused bool) {
+	adminClient := cFactory.ServerAdminClient(c)
+
+	domain := getRequiredGlobalOption(c, FlagDomain)
+	wid := getRequiredOption(c, FlagWorkflowID)
+	rid := c.String(FlagRunID)
+
+	ctx, cancel := newContext(c)
+	defer cancel()
+
+	err := adminClient.SetWorkflowExecutionPaused(ctx, &shared.SetWorkflowExecutionPausedRequest{
+		Domain: common.StringPtr(domain),
+		Execution: &shared.WorkflowExecution{
+			WorkflowId: common.StringPtr(wid),
+			RunId:      common.StringPtr(rid),
+		},
+		Paused: common.BoolPtr(paused),
+	})
+	if err != nil {
+		ErrorAndExit("Set workflow execution paused failed", err)
+	}
+	fmt.Println("Success.")
+}
+
+// AdminSetCronSchedulePaused pauses or resumes the cron schedule of a workflow
+func AdminSetCronSchedulePaused(c *cli.Context, paused bool) {
+	adminClient := cFactory.ServerAdminClient(c)
+
+	domain := getRequiredGlobalOption(c, FlagDomain)
+	wid := getRequiredOption(c, FlagWorkflowID)
+	rid := c.String(FlagRunID)
+
+	ctx, cancel := newContext(c)
+	defer cancel()
+
+	err := adminClient.SetCronSchedulePaused(ctx, &shared.SetCronSchedulePausedRequest{
+		Domain: common.StringPtr(domain),
+		Execution: &shared.WorkflowExecution{
+			WorkflowId: common.StringPtr(wid),
+			RunId:      common.StringPtr(rid),
+		},
+		Paused: common.BoolPtr(paused),
+	})
+	if err != nil {
+		ErrorAndExit("Set cron schedule paused failed", err)
+	}
+	fmt.Println("Success.")
+}
+
+// AdminTerminateAllWorkflowRuns terminates the current run of a workflow ID and blocks it from starting new runs
+func AdminTerminateAllWorkflowRuns(c *cli.Context) {
+	adminClient := cFactory.ServerAdminClient(c)
+
+	domain := getRequiredGlobalOption(c, FlagDomain)
+	wid := getRequiredOption(c, FlagWorkflowID)
+
+	request := &shared.TerminateAllWorkflowRunsRequest{
+		Domain:       common.StringPtr(domain),
+		WorkflowId:   common.StringPtr(wid),
+		Reason:       common.StringPtr(c.String(FlagReason)),
+		Identity:     common.StringPtr(getCliIdentity()),
+		BlockSeconds: common.Int32Ptr(int32(c.Int(FlagBlockSeconds))),
+	}
+	if c.IsSet(FlagFirstExecutionRunID) {
+		request.FirstExecutionRunId = common.StringPtr(c.String(FlagFirstExecutionRunID))
+	}
+
+	ctx, cancel := newContext(c)
+	defer cancel()
+
+	resp, err := adminClient.TerminateAllWorkflowRuns(ctx, request)
+	if err != nil {
+		ErrorAndExit("Terminate all workflow runs failed", err)
+	}
+	for _, execution := range resp.TerminatedExecutions {
+		fmt.Printf("Terminated run %v.\n", execution.GetRunId())
+	}
+	fmt.Println("Success.")
+}
+
 // AdminGetDomainIDOrName map domain
 func AdminGetDomainIDOrName(c *cli.Context) {
 	domainID := c.String(FlagDomainID)
@@ -397,6 +756,104 @@ func AdminDescribeHistoryHost(c *cli.Context) {
 
 	if !printFully {
 		resp.ShardIDs = nil
+		resp.ShardInfos = nil
+		resp.ShardMovements = nil
+	}
+	prettyPrintJSONObject(resp)
+}
+
+// AdminDrainHistoryHost releases the shards of a history host
+func AdminDrainHistoryHost(c *cli.Context) {
+	adminClient := cFactory.ServerAdminClient(c)
+
+	addr := getRequiredOption(c, FlagHistoryAddress)
+
+	ctx, cancel := newContext(c)
+	defer cancel()
+
+	resp, err := adminClient.DrainHistoryHost(ctx, &shared.DrainHistoryHostRequest{
+		HostAddress: common.StringPtr(addr),
+	})
+	if err != nil {
+		ErrorAndExit("Drain history host failed", err)
+	}
+	prettyPrintJSONObject(resp)
+}
+
+// AdminUndrainHistoryHost adds a drained history host back to the membership ring
+func AdminUndrainHistoryHost(c *cli.Context) {
+	adminClient := cFactory.ServerAdminClient(c)
+
+	addr := getRequiredOption(c, FlagHistoryAddress)
+
+	ctx, cancel := newContext(c)
+	defer cancel()
+
+	resp, err := adminClient.UndrainHistoryHost(ctx, &shared.UndrainHistoryHostRequest{
+		HostAddress: common.StringPtr(addr),
+	})
+	if err != nil {
+		ErrorAndExit("Undrain history host failed", err)
 	}
 	prettyPrintJSONObject(resp)
 }
+
+// AdminDescribeClientVersions lists the client versions which completed decisions per domain
+func AdminDescribeClientVersions(c *cli.Context) {
+	adminClient := cFactory.ServerAdminClient(c)
+
+	request := &shared.DescribeClientVersionsRequest{}
+	if c.IsSet(FlagDomain) {
+		request.Domain = common.StringPtr(c.String(FlagDomain))
+	}
+	if c.IsSet(FlagHistoryAddress) {
+		request.HostAddress = common.StringPtr(c.String(FlagHistoryAddress))
+	}
+
+	ctx, cancel := newContext(c)
+	defer cancel()
+
+	resp, err := adminClient.DescribeClientVersions(ctx, request)
+	if err != nil {
+		ErrorAndExit("Describe client versions failed", err)
+	}
+
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetBorder(false)
+	table.SetColumnSeparator("|")
+	table.SetHeader([]string{"Domain", "Client Impl", "Feature Version", "Library Version", "Decisions", "Last Seen"})
+	table.SetHeaderLine(false)
+	table.SetHeaderColor(tableHeaderBlue, tableHeaderBlue, tableHeaderBlue, tableHeaderBlue, tableHeaderBlue, tableHeaderBlue)
+	for _, info := range resp.GetClientVersions() {
+		table.Append([]string{
+			info.GetDomain(),
+			info.GetClientImpl(),
+			info.GetFeatureVersion(),
+			info.GetLibraryVersion(),
+			strconv.FormatInt(info.GetDecisionCount(), 10),
+			convertTime(info.GetLastSeenTimestamp(), false),
+		})
+	}
+	table.Render()
+}
+
+// AdminGetDomainOpenExecutionCount shows the approximate number of open executions of a domain
+func AdminGetDomainOpenExecutionCount(c *cli.Context) {
+	adminClient := cFactory.ServerAdminClient(c)
+
+	request := &shared.GetDomainOpenExecutionCountRequest{
+		Domain: common.StringPtr(getRequiredOption(c, FlagDomain)),
+	}
+	if c.IsSet(FlagHistoryAddress) {
+		request.HostAddress = common.StringPtr(c.String(FlagHistoryAddress))
+	}
+
+	ctx, cancel := newContext(c)
+	defer cancel()
+
+	resp, err := adminClient.GetDomainOpenExecutionCount(ctx, request)
+	if err != nil {
+		ErrorAndExit("Get domain open execution count failed", err)
+	}
+	fmt.Println(resp.GetCount())
+}
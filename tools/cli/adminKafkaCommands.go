@@ -477,7 +477,9 @@ func doRereplicate(shardID int, domainID, wid, rid string, minID, maxID int64, t
 	historyV2Mgr := persistence.NewHistoryV2ManagerImpl(histV2, loggerimpl.NewNopLogger(), dynamicconfig.GetIntPropertyFn(common.DefaultTransactionSizeLimit))
 
 	exeM, _ := cassandra.NewWorkflowExecutionPersistence(shardID, session, loggerimpl.NewNopLogger())
-	exeMgr := persistence.NewExecutionManagerImpl(exeM, loggerimpl.NewNopLogger())
+	exeMgr := persistence.NewExecutionManagerImpl(exeM, loggerimpl.NewNopLogger(),
+		dynamicconfig.GetIntPropertyFn(common.DefaultExecutionTransactionStatementLimit),
+		dynamicconfig.GetIntPropertyFn(common.DefaultExecutionTransactionSizeLimit))
 
 	for {
 		fmt.Printf("Start rereplicate for wid: %v, rid:%v \n", wid, rid)
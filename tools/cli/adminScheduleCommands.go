@@ -0,0 +1,219 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cli
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/olekukonko/tablewriter"
+	s "github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common"
+	"github.com/urfave/cli"
+)
+
+var overlapPoliciesMap = map[string]s.ScheduleOverlapPolicy{
+	"skip":            s.ScheduleOverlapPolicySkip,
+	"allow_all":       s.ScheduleOverlapPolicyAllowAll,
+	"terminate_other": s.ScheduleOverlapPolicyTerminateOther,
+	"cancel_other":    s.ScheduleOverlapPolicyCancelOther,
+}
+
+// AdminCreateSchedule creates a schedule periodically starting a workflow
+func AdminCreateSchedule(c *cli.Context) {
+	adminClient := cFactory.ServerAdminClient(c)
+
+	domain := getRequiredGlobalOption(c, FlagDomain)
+	scheduleID := getRequiredOption(c, FlagScheduleID)
+	cronSchedule := getRequiredOption(c, FlagCronSchedule)
+	taskList := getRequiredOption(c, FlagTaskList)
+	workflowType := getRequiredOption(c, FlagWorkflowType)
+	et := c.Int(FlagExecutionTimeout)
+	if et == 0 {
+		ErrorAndExit(fmt.Sprintf("Option %s format is invalid.", FlagExecutionTimeout), nil)
+	}
+	dt := c.Int(FlagDecisionTimeout)
+	overlapPolicy, ok := overlapPoliciesMap[strings.ToLower(c.String(FlagOverlapPolicy))]
+	if !ok {
+		ErrorAndExit(fmt.Sprintf("Option %s format is invalid.", FlagOverlapPolicy), nil)
+	}
+	input := processJSONInput(c)
+
+	ctx, cancel := newContext(c)
+	defer cancel()
+
+	err := adminClient.CreateSchedule(ctx, &s.CreateScheduleRequest{
+		Domain:       common.StringPtr(domain),
+		ScheduleId:   common.StringPtr(scheduleID),
+		CronSchedule: common.StringPtr(cronSchedule),
+		WorkflowType: &s.WorkflowType{
+			Name: common.StringPtr(workflowType),
+		},
+		TaskList: &s.TaskList{
+			Name: common.StringPtr(taskList),
+		},
+		Input:                               []byte(input),
+		ExecutionStartToCloseTimeoutSeconds: common.Int32Ptr(int32(et)),
+		TaskStartToCloseTimeoutSeconds:      common.Int32Ptr(int32(dt)),
+		OverlapPolicy:                       overlapPolicy.Ptr(),
+		CatchupWindowSeconds:                common.Int32Ptr(int32(c.Int(FlagCatchupWindow))),
+		Identity:                            common.StringPtr(getCliIdentity()),
+	})
+	if err != nil {
+		ErrorAndExit("Create schedule failed", err)
+	}
+	fmt.Println("Success.")
+}
+
+// AdminDescribeSchedule describes a schedule
+func AdminDescribeSchedule(c *cli.Context) {
+	adminClient := cFactory.ServerAdminClient(c)
+
+	domain := getRequiredGlobalOption(c, FlagDomain)
+	scheduleID := getRequiredOption(c, FlagScheduleID)
+
+	ctx, cancel := newContext(c)
+	defer cancel()
+
+	response, err := adminClient.DescribeSchedule(ctx, &s.DescribeScheduleRequest{
+		Domain:     common.StringPtr(domain),
+		ScheduleId: common.StringPtr(scheduleID),
+	})
+	if err != nil {
+		ErrorAndExit("Describe schedule failed", err)
+	}
+	prettyPrintJSONObject(response.Schedule)
+}
+
+// AdminDeleteSchedule deletes a schedule
+func AdminDeleteSchedule(c *cli.Context) {
+	adminClient := cFactory.ServerAdminClient(c)
+
+	domain := getRequiredGlobalOption(c, FlagDomain)
+	scheduleID := getRequiredOption(c, FlagScheduleID)
+
+	ctx, cancel := newContext(c)
+	defer cancel()
+
+	err := adminClient.DeleteSchedule(ctx, &s.DeleteScheduleRequest{
+		Domain:     common.StringPtr(domain),
+		ScheduleId: common.StringPtr(scheduleID),
+	})
+	if err != nil {
+		ErrorAndExit("Delete schedule failed", err)
+	}
+	fmt.Println("Success.")
+}
+
+// AdminListSchedules lists the schedules of a domain
+func AdminListSchedules(c *cli.Context) {
+	adminClient := cFactory.ServerAdminClient(c)
+
+	domain := getRequiredGlobalOption(c, FlagDomain)
+
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetBorder(false)
+	table.SetColumnSeparator("|")
+	table.SetHeader([]string{"Schedule ID", "Cron Schedule", "Workflow Type", "Paused", "Last Fire Time", "Next Fire Time"})
+	table.SetHeaderLine(false)
+	table.SetHeaderColor(tableHeaderBlue, tableHeaderBlue, tableHeaderBlue, tableHeaderBlue, tableHeaderBlue, tableHeaderBlue)
+
+	var nextPageToken []byte
+	for {
+		ctx, cancel := newContext(c)
+		response, err := adminClient.ListSchedules(ctx, &s.ListSchedulesRequest{
+			Domain:        common.StringPtr(domain),
+			PageSize:      common.Int32Ptr(int32(c.Int(FlagPageSize))),
+			NextPageToken: nextPageToken,
+		})
+		cancel()
+		if err != nil {
+			ErrorAndExit("List schedules failed", err)
+		}
+		for _, schedule := range response.Schedules {
+			nextFireTime := ""
+			if schedule.NextFireTime != nil {
+				nextFireTime = convertTime(schedule.GetNextFireTime(), false)
+			}
+			table.Append([]string{
+				schedule.GetScheduleId(),
+				schedule.GetCronSchedule(),
+				schedule.WorkflowType.GetName(),
+				strconv.FormatBool(schedule.GetPaused()),
+				convertTime(schedule.GetLastFireTime(), false),
+				nextFireTime,
+			})
+		}
+		nextPageToken = response.NextPageToken
+		if len(nextPageToken) == 0 {
+			break
+		}
+	}
+	table.Render()
+}
+
+// AdminSetSchedulePaused pauses or resumes a schedule
+func AdminSetSchedulePaused(c *cli.Context, paused bool) {
+	adminClient := cFactory.ServerAdminClient(c)
+
+	domain := getRequiredGlobalOption(c, FlagDomain)
+	scheduleID := getRequiredOption(c, FlagScheduleID)
+
+	ctx, cancel := newContext(c)
+	defer cancel()
+
+	err := adminClient.SetSchedulePaused(ctx, &s.SetSchedulePausedRequest{
+		Domain:     common.StringPtr(domain),
+		ScheduleId: common.StringPtr(scheduleID),
+		Paused:     common.BoolPtr(paused),
+	})
+	if err != nil {
+		ErrorAndExit("Set schedule paused failed", err)
+	}
+	fmt.Println("Success.")
+}
+
+// AdminBackfillSchedule starts the workflows of every fire time of a schedule in a time range
+func AdminBackfillSchedule(c *cli.Context) {
+	adminClient := cFactory.ServerAdminClient(c)
+
+	domain := getRequiredGlobalOption(c, FlagDomain)
+	scheduleID := getRequiredOption(c, FlagScheduleID)
+	startTime := parseTime(getRequiredOption(c, FlagEarliestTime), 0)
+	endTime := parseTime(getRequiredOption(c, FlagLatestTime), 0)
+
+	ctx, cancel := newContext(c)
+	defer cancel()
+
+	err := adminClient.BackfillSchedule(ctx, &s.BackfillScheduleRequest{
+		Domain:        common.StringPtr(domain),
+		ScheduleId:    common.StringPtr(scheduleID),
+		StartTime:     common.Int64Ptr(startTime),
+		EndTime:       common.Int64Ptr(endTime),
+		RunsPerMinute: common.Int32Ptr(int32(c.Int(FlagRunsPerMinute))),
+	})
+	if err != nil {
+		ErrorAndExit("Backfill schedule failed", err)
+	}
+	fmt.Println("Success.")
+}
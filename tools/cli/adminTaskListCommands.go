@@ -25,6 +25,7 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/olekukonko/tablewriter"
 	s "github.com/uber/cadence/.gen/go/shared"
@@ -63,6 +64,11 @@ func AdminDescribeTaskList(c *cli.Context) {
 	printTaskListStatus(taskListStatus)
 	fmt.Printf("\n")
 
+	if len(response.VersionSets) > 0 {
+		printVersionSets(response.VersionSets)
+		fmt.Printf("\n")
+	}
+
 	pollers := response.Pollers
 	if len(pollers) == 0 {
 		ErrorAndExit(colorMagenta("No poller for tasklist: "+taskList), nil)
@@ -70,20 +76,161 @@ func AdminDescribeTaskList(c *cli.Context) {
 	printPollerInfo(pollers, taskListType)
 }
 
+// AdminGetTaskListScalingHints displays the backlog and dispatch rate of a task list
+func AdminGetTaskListScalingHints(c *cli.Context) {
+	frontendClient := cFactory.ServerFrontendClient(c)
+	domain := getRequiredGlobalOption(c, FlagDomain)
+	taskList := getRequiredOption(c, FlagTaskList)
+	taskListType := s.TaskListTypeDecision
+	if strings.ToLower(c.String(FlagTaskListType)) == "activity" {
+		taskListType = s.TaskListTypeActivity
+	}
+
+	ctx, cancel := newContext(c)
+	defer cancel()
+	response, err := frontendClient.GetTaskListScalingHints(ctx, &s.GetTaskListScalingHintsRequest{
+		Domain:       common.StringPtr(domain),
+		TaskList:     &s.TaskList{Name: common.StringPtr(taskList)},
+		TaskListType: &taskListType,
+	})
+	if err != nil {
+		ErrorAndExit("Operation GetTaskListScalingHints failed.", err)
+	}
+
+	hints := response.GetScalingHints()
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetBorder(false)
+	table.SetColumnSeparator("|")
+	table.SetHeader([]string{"Backlog", "Backlog Age", "Dispatch Rate Per Second", "Pollers"})
+	table.SetHeaderLine(false)
+	table.SetHeaderColor(tableHeaderBlue, tableHeaderBlue, tableHeaderBlue, tableHeaderBlue)
+	table.Append([]string{strconv.FormatInt(hints.GetBacklogCountHint(), 10),
+		(time.Duration(hints.GetBacklogAgeMillis()) * time.Millisecond).String(),
+		strconv.FormatFloat(hints.GetDispatchRatePerSecond(), 'f', 2, 64),
+		strconv.Itoa(int(hints.GetPollerCount()))})
+	table.Render()
+}
+
+// AdminDescribeTaskJourney displays the stages a task of a task list recently went through in matching
+func AdminDescribeTaskJourney(c *cli.Context) {
+	adminClient := cFactory.ServerAdminClient(c)
+	domain := getRequiredGlobalOption(c, FlagDomain)
+	taskList := getRequiredOption(c, FlagTaskList)
+	taskListType := s.TaskListTypeDecision
+	if strings.ToLower(c.String(FlagTaskListType)) == "activity" {
+		taskListType = s.TaskListTypeActivity
+	}
+
+	request := &s.DescribeTaskJourneyRequest{
+		Domain:       common.StringPtr(domain),
+		TaskList:     &s.TaskList{Name: common.StringPtr(taskList)},
+		TaskListType: &taskListType,
+	}
+	if c.IsSet(FlagTaskID) {
+		request.TaskId = common.Int64Ptr(c.Int64(FlagTaskID))
+	} else {
+		request.Execution = &s.WorkflowExecution{
+			WorkflowId: common.StringPtr(getRequiredOption(c, FlagWorkflowID)),
+			RunId:      common.StringPtr(getRequiredOption(c, FlagRunID)),
+		}
+		if !c.IsSet(FlagEventID) {
+			ErrorAndExit(fmt.Sprintf("Option %s or %s is required", FlagEventID, FlagTaskID), nil)
+		}
+		request.ScheduleId = common.Int64Ptr(c.Int64(FlagEventID))
+	}
+
+	ctx, cancel := newContext(c)
+	defer cancel()
+	response, err := adminClient.DescribeTaskJourney(ctx, request)
+	if err != nil {
+		ErrorAndExit("Operation DescribeTaskJourney failed.", err)
+	}
+	if len(response.Events) == 0 {
+		ErrorAndExit(colorMagenta("No journey recorded for the task, the task list may be owned by another host."), nil)
+	}
+
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetBorder(false)
+	table.SetColumnSeparator("|")
+	table.SetHeader([]string{"Time", "Stage", "TaskList", "TaskID"})
+	table.SetHeaderLine(false)
+	table.SetHeaderColor(tableHeaderBlue, tableHeaderBlue, tableHeaderBlue, tableHeaderBlue)
+	for _, event := range response.Events {
+		taskID := ""
+		if event.TaskId != nil {
+			taskID = strconv.FormatInt(event.GetTaskId(), 10)
+		}
+		table.Append([]string{convertTime(event.GetTimestamp(), false), event.GetStage(), event.GetTaskList(), taskID})
+	}
+	table.Render()
+}
+
+// AdminUpdateTaskListVersionSets replaces the worker build ID version sets of a decision task list
+func AdminUpdateTaskListVersionSets(c *cli.Context) {
+	adminClient := cFactory.ServerAdminClient(c)
+	domain := getRequiredGlobalOption(c, FlagDomain)
+	taskList := getRequiredOption(c, FlagTaskList)
+
+	var versionSets []*s.TaskListVersionSet
+	for _, set := range c.StringSlice(FlagVersionSet) {
+		var buildIDs []string
+		for _, buildID := range strings.Split(set, ",") {
+			if buildID = strings.TrimSpace(buildID); buildID != "" {
+				buildIDs = append(buildIDs, buildID)
+			}
+		}
+		versionSets = append(versionSets, &s.TaskListVersionSet{BuildIds: buildIDs})
+	}
+
+	ctx, cancel := newContext(c)
+	defer cancel()
+	err := adminClient.UpdateTaskListVersionSets(ctx, &s.UpdateTaskListVersionSetsRequest{
+		Domain:      common.StringPtr(domain),
+		TaskList:    &s.TaskList{Name: common.StringPtr(taskList)},
+		VersionSets: versionSets,
+	})
+	if err != nil {
+		ErrorAndExit("Operation UpdateTaskListVersionSets failed.", err)
+	}
+	fmt.Println("Success.")
+}
+
+func printVersionSets(versionSets []*s.TaskListVersionSet) {
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetBorder(false)
+	table.SetColumnSeparator("|")
+	table.SetHeader([]string{"Version Set", "Build IDs", "Default Build ID"})
+	table.SetHeaderLine(false)
+	table.SetHeaderColor(tableHeaderBlue, tableHeaderBlue, tableHeaderBlue)
+	for i, set := range versionSets {
+		buildIDs := set.GetBuildIds()
+		defaultBuildID := ""
+		if len(buildIDs) > 0 {
+			defaultBuildID = buildIDs[len(buildIDs)-1]
+		}
+		table.Append([]string{strconv.Itoa(i), strings.Join(buildIDs, ", "), defaultBuildID})
+	}
+	table.Render()
+}
+
 func printTaskListStatus(taskListStatus *s.TaskListStatus) {
 	taskIDBlock := taskListStatus.GetTaskIDBlock()
 
 	table := tablewriter.NewWriter(os.Stdout)
 	table.SetBorder(false)
 	table.SetColumnSeparator("|")
-	table.SetHeader([]string{"Read Level", "Ack Level", "Backlog", "Lease Start TaskID", "Lease End TaskID"})
+	table.SetHeader([]string{"Read Level", "Ack Level", "Backlog", "Lease Start TaskID", "Lease End TaskID",
+		"Schedule To Start P95 (ms)", "Schedule To Start P99 (ms)"})
 	table.SetHeaderLine(false)
-	table.SetHeaderColor(tableHeaderBlue, tableHeaderBlue, tableHeaderBlue, tableHeaderBlue, tableHeaderBlue)
+	table.SetHeaderColor(tableHeaderBlue, tableHeaderBlue, tableHeaderBlue, tableHeaderBlue, tableHeaderBlue,
+		tableHeaderBlue, tableHeaderBlue)
 	table.Append([]string{strconv.FormatInt(taskListStatus.GetReadLevel(), 10),
 		strconv.FormatInt(taskListStatus.GetAckLevel(), 10),
 		strconv.FormatInt(taskListStatus.GetBacklogCountHint(), 10),
 		strconv.FormatInt(taskIDBlock.GetStartID(), 10),
-		strconv.FormatInt(taskIDBlock.GetEndID(), 10)})
+		strconv.FormatInt(taskIDBlock.GetEndID(), 10),
+		strconv.FormatInt(taskListStatus.GetScheduleToStartLatencyP95Millis(), 10),
+		strconv.FormatInt(taskListStatus.GetScheduleToStartLatencyP99Millis(), 10)})
 	table.Render()
 }
 
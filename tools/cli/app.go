@@ -120,6 +120,12 @@ func NewCliApp() *cli.App {
 					Usage:       "Run admin operation on taskList",
 					Subcommands: newAdminTaskListCommands(),
 				},
+				{
+					Name:        "schedule",
+					Aliases:     []string{"sch"},
+					Usage:       "Run admin operation on schedules",
+					Subcommands: newAdminScheduleCommands(),
+				},
 			},
 		},
 	}
@@ -81,6 +81,46 @@ func newDomainCommands() []cli.Command {
 					Name:  FlagRetentionDaysWithAlias,
 					Usage: "Workflow execution retention in days",
 				},
+				cli.IntFlag{
+					Name:  FlagRetentionRunCountWithAlias,
+					Usage: "Number of most recent closed runs to retain per workflow ID, 0 means unlimited",
+				},
+				cli.IntFlag{
+					Name:  FlagDefaultExecutionTimeout,
+					Usage: "Workflow execution timeout in seconds of the workflows started without one, 0 means no default",
+				},
+				cli.IntFlag{
+					Name:  FlagMaxExecutionTimeout,
+					Usage: "Maximum workflow execution timeout in seconds, larger timeouts are lowered to it, 0 means unlimited",
+				},
+				cli.IntFlag{
+					Name:  FlagDefaultDecisionTimeout,
+					Usage: "Decision task timeout in seconds of the workflows started without one, 0 means no default",
+				},
+				cli.IntFlag{
+					Name:  FlagMaxDecisionTimeout,
+					Usage: "Maximum decision task timeout in seconds, larger timeouts are lowered to it, 0 means unlimited",
+				},
+				cli.IntFlag{
+					Name:  FlagDefaultActivityTimeout,
+					Usage: "Schedule to close timeout in seconds of the activities scheduled without timeouts, 0 means no default",
+				},
+				cli.IntFlag{
+					Name:  FlagMaxActivityTimeout,
+					Usage: "Maximum activity timeout in seconds, larger timeouts are lowered to it, 0 means unlimited",
+				},
+				cli.StringFlag{
+					Name:  FlagEncryptionKeyAlias,
+					Usage: "Alias of the KMS key used to encrypt the domain blobs",
+				},
+				cli.StringFlag{
+					Name:  FlagResidencyTag,
+					Usage: "Data residency tag of the domain",
+				},
+				cli.StringFlag{
+					Name:  FlagResidencyAllowedClusters,
+					Usage: "Comma separated clusters the domain executions may be placed in and replicated to, empty means no restriction",
+				},
 				cli.StringFlag{
 					Name:  FlagEmitMetricWithAlias,
 					Usage: "Flag to emit metric",
@@ -135,6 +175,46 @@ func newDomainCommands() []cli.Command {
 					Name:  FlagRetentionDaysWithAlias,
 					Usage: "Workflow execution retention in days",
 				},
+				cli.IntFlag{
+					Name:  FlagRetentionRunCountWithAlias,
+					Usage: "Number of most recent closed runs to retain per workflow ID, 0 means unlimited",
+				},
+				cli.IntFlag{
+					Name:  FlagDefaultExecutionTimeout,
+					Usage: "Workflow execution timeout in seconds of the workflows started without one, 0 means no default",
+				},
+				cli.IntFlag{
+					Name:  FlagMaxExecutionTimeout,
+					Usage: "Maximum workflow execution timeout in seconds, larger timeouts are lowered to it, 0 means unlimited",
+				},
+				cli.IntFlag{
+					Name:  FlagDefaultDecisionTimeout,
+					Usage: "Decision task timeout in seconds of the workflows started without one, 0 means no default",
+				},
+				cli.IntFlag{
+					Name:  FlagMaxDecisionTimeout,
+					Usage: "Maximum decision task timeout in seconds, larger timeouts are lowered to it, 0 means unlimited",
+				},
+				cli.IntFlag{
+					Name:  FlagDefaultActivityTimeout,
+					Usage: "Schedule to close timeout in seconds of the activities scheduled without timeouts, 0 means no default",
+				},
+				cli.IntFlag{
+					Name:  FlagMaxActivityTimeout,
+					Usage: "Maximum activity timeout in seconds, larger timeouts are lowered to it, 0 means unlimited",
+				},
+				cli.StringFlag{
+					Name:  FlagEncryptionKeyAlias,
+					Usage: "Alias of the KMS key used to encrypt the domain blobs",
+				},
+				cli.StringFlag{
+					Name:  FlagResidencyTag,
+					Usage: "Data residency tag of the domain",
+				},
+				cli.StringFlag{
+					Name:  FlagResidencyAllowedClusters,
+					Usage: "Comma separated clusters the domain executions may be placed in and replicated to, empty means no restriction",
+				},
 				cli.StringFlag{
 					Name:  FlagEmitMetricWithAlias,
 					Usage: "Flag to emit metric",
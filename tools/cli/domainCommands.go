@@ -25,6 +25,7 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/olekukonko/tablewriter"
@@ -46,6 +47,10 @@ func RegisterDomain(c *cli.Context) {
 	if c.IsSet(FlagRetentionDays) {
 		retentionDays = c.Int(FlagRetentionDays)
 	}
+	var retentionRunCount *int32
+	if c.IsSet(FlagRetentionRunCount) {
+		retentionRunCount = common.Int32Ptr(int32(c.Int(FlagRetentionRunCount)))
+	}
 	securityToken := c.String(FlagSecurityToken)
 	emitMetric := false
 	var err error
@@ -103,7 +108,11 @@ func RegisterDomain(c *cli.Context) {
 		OwnerEmail:                             common.StringPtr(ownerEmail),
 		Data:                                   domainData,
 		WorkflowExecutionRetentionPeriodInDays: common.Int32Ptr(int32(retentionDays)),
+		WorkflowExecutionRetentionRunCount:     retentionRunCount,
+		TimeoutPolicy:                          timeoutPolicyFromFlags(c),
 		EmitMetric:                             common.BoolPtr(emitMetric),
+		EncryptionKeyAlias:                     encryptionKeyAliasFromFlags(c),
+		Residency:                              residencyFromFlags(c),
 		Clusters:                               clusters,
 		ActiveClusterName:                      activeClusterName,
 		SecurityToken:                          common.StringPtr(securityToken),
@@ -181,6 +190,10 @@ func UpdateDomain(c *cli.Context) {
 		if c.IsSet(FlagRetentionDays) {
 			retentionDays = int32(c.Int(FlagRetentionDays))
 		}
+		var retentionRunCount *int32
+		if c.IsSet(FlagRetentionRunCount) {
+			retentionRunCount = common.Int32Ptr(int32(c.Int(FlagRetentionRunCount)))
+		}
 		if c.IsSet(FlagEmitMetric) {
 			emitMetric, err = strconv.ParseBool(c.String(FlagEmitMetric))
 			if err != nil {
@@ -229,7 +242,11 @@ func UpdateDomain(c *cli.Context) {
 		}
 		updateConfig := &shared.DomainConfiguration{
 			WorkflowExecutionRetentionPeriodInDays: common.Int32Ptr(int32(retentionDays)),
+			WorkflowExecutionRetentionRunCount:     retentionRunCount,
+			TimeoutPolicy:                          timeoutPolicyFromFlags(c),
 			EmitMetric:                             common.BoolPtr(emitMetric),
+			EncryptionKeyAlias:                     encryptionKeyAliasFromFlags(c),
+			Residency:                              residencyFromFlags(c),
 			ArchivalStatus:                         archivalStatus(c),
 			ArchivalBucketName:                     common.StringPtr(c.String(FlagArchivalBucketName)),
 			BadBinaries:                            binBinaries,
@@ -283,7 +300,7 @@ func DescribeDomain(c *cli.Context) {
 	}
 
 	var formatStr = "Name: %v\nUUID: %v\nDescription: %v\nOwnerEmail: %v\nDomainData: %v\nStatus: %v\nRetentionInDays: %v\n" +
-		"EmitMetrics: %v\nActiveClusterName: %v\nClusters: %v\nArchivalStatus: %v\n"
+		"RetentionRunCount: %v\nTimeoutPolicy: %v\nEmitMetrics: %v\nActiveClusterName: %v\nClusters: %v\nArchivalStatus: %v\n"
 	descValues := []interface{}{
 		resp.DomainInfo.GetName(),
 		resp.DomainInfo.GetUUID(),
@@ -292,6 +309,8 @@ func DescribeDomain(c *cli.Context) {
 		resp.DomainInfo.Data,
 		resp.DomainInfo.GetStatus(),
 		resp.Configuration.GetWorkflowExecutionRetentionPeriodInDays(),
+		resp.Configuration.GetWorkflowExecutionRetentionRunCount(),
+		timeoutPolicyToString(resp.Configuration.TimeoutPolicy),
 		resp.Configuration.GetEmitMetric(),
 		resp.ReplicationConfiguration.GetActiveClusterName(),
 		clustersToString(resp.ReplicationConfiguration.Clusters),
@@ -301,6 +320,14 @@ func DescribeDomain(c *cli.Context) {
 		formatStr = formatStr + "BucketName: %v\n"
 		descValues = append(descValues, resp.Configuration.GetArchivalBucketName())
 	}
+	if resp.Configuration.GetEncryptionKeyAlias() != "" {
+		formatStr = formatStr + "EncryptionKeyAlias: %v\n"
+		descValues = append(descValues, resp.Configuration.GetEncryptionKeyAlias())
+	}
+	if residency := resp.Configuration.Residency; residency.GetTag() != "" || len(residency.GetAllowedClusters()) != 0 {
+		formatStr = formatStr + "Residency: %v\n"
+		descValues = append(descValues, residencyToString(residency))
+	}
 	fmt.Printf(formatStr, descValues...)
 	if resp.Configuration.BadBinaries != nil {
 		fmt.Println("Bad binaries to reset:")
@@ -347,3 +374,68 @@ func clustersToString(clusters []*shared.ClusterReplicationConfiguration) string
 	}
 	return res
 }
+
+// encryptionKeyAliasFromFlags returns the encryption key alias set by the flag, or nil if it is not set
+func encryptionKeyAliasFromFlags(c *cli.Context) *string {
+	if !c.IsSet(FlagEncryptionKeyAlias) {
+		return nil
+	}
+	return common.StringPtr(c.String(FlagEncryptionKeyAlias))
+}
+
+// residencyFromFlags returns the residency values set by the flags, or nil if none is set
+func residencyFromFlags(c *cli.Context) *shared.DomainResidency {
+	if !c.IsSet(FlagResidencyTag) && !c.IsSet(FlagResidencyAllowedClusters) {
+		return nil
+	}
+	residency := &shared.DomainResidency{}
+	if c.IsSet(FlagResidencyTag) {
+		residency.Tag = common.StringPtr(c.String(FlagResidencyTag))
+	}
+	if c.IsSet(FlagResidencyAllowedClusters) {
+		residency.AllowedClusters = []string{}
+		for _, clusterName := range strings.Split(c.String(FlagResidencyAllowedClusters), ",") {
+			if clusterName = strings.TrimSpace(clusterName); clusterName != "" {
+				residency.AllowedClusters = append(residency.AllowedClusters, clusterName)
+			}
+		}
+	}
+	return residency
+}
+
+// timeoutPolicyFromFlags returns the timeout policy values set by the flags, or nil if none is set
+func timeoutPolicyFromFlags(c *cli.Context) *shared.DomainTimeoutPolicy {
+	flagValue := func(name string) *int32 {
+		if !c.IsSet(name) {
+			return nil
+		}
+		return common.Int32Ptr(int32(c.Int(name)))
+	}
+	policy := &shared.DomainTimeoutPolicy{
+		DefaultExecutionStartToCloseTimeoutSeconds:   flagValue(FlagDefaultExecutionTimeout),
+		MaxExecutionStartToCloseTimeoutSeconds:       flagValue(FlagMaxExecutionTimeout),
+		DefaultTaskStartToCloseTimeoutSeconds:        flagValue(FlagDefaultDecisionTimeout),
+		MaxTaskStartToCloseTimeoutSeconds:            flagValue(FlagMaxDecisionTimeout),
+		DefaultActivityScheduleToCloseTimeoutSeconds: flagValue(FlagDefaultActivityTimeout),
+		MaxActivityTimeoutSeconds:                    flagValue(FlagMaxActivityTimeout),
+	}
+	if policy.Equals(&shared.DomainTimeoutPolicy{}) {
+		return nil
+	}
+	return policy
+}
+
+func residencyToString(residency *shared.DomainResidency) string {
+	allowedClusters := "all clusters"
+	if len(residency.GetAllowedClusters()) != 0 {
+		allowedClusters = strings.Join(residency.GetAllowedClusters(), ", ")
+	}
+	return fmt.Sprintf("tag %v, allowed clusters %v", residency.GetTag(), allowedClusters)
+}
+
+func timeoutPolicyToString(policy *shared.DomainTimeoutPolicy) string {
+	return fmt.Sprintf("execution default %vs max %vs, decision default %vs max %vs, activity default %vs max %vs",
+		policy.GetDefaultExecutionStartToCloseTimeoutSeconds(), policy.GetMaxExecutionStartToCloseTimeoutSeconds(),
+		policy.GetDefaultTaskStartToCloseTimeoutSeconds(), policy.GetMaxTaskStartToCloseTimeoutSeconds(),
+		policy.GetDefaultActivityScheduleToCloseTimeoutSeconds(), policy.GetMaxActivityTimeoutSeconds())
+}
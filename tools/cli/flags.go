@@ -54,6 +54,8 @@ const (
 	FlagWorkflowIDReusePolicy       = "workflowidreusepolicy"
 	FlagWorkflowIDReusePolicyAlias  = FlagWorkflowIDReusePolicy + ", wrp"
 	FlagCronSchedule                = "cron"
+	FlagCronTimezone                = "cron_timezone"
+	FlagSLADeadline                 = "sla_deadline"
 	FlagWorkflowType                = "workflow_type"
 	FlagWorkflowTypeWithAlias       = FlagWorkflowType + ", wt"
 	FlagWorkflowStatus              = "status"
@@ -117,6 +119,17 @@ const (
 	FlagOwnerEmailWithAlias         = FlagOwnerEmail + ", oe"
 	FlagRetentionDays               = "retention"
 	FlagRetentionDaysWithAlias      = FlagRetentionDays + ", rd"
+	FlagRetentionRunCount           = "retention_run_count"
+	FlagRetentionRunCountWithAlias  = FlagRetentionRunCount + ", rrc"
+	FlagDefaultExecutionTimeout     = "default_execution_timeout"
+	FlagMaxExecutionTimeout         = "max_execution_timeout"
+	FlagDefaultDecisionTimeout      = "default_decision_timeout"
+	FlagMaxDecisionTimeout          = "max_decision_timeout"
+	FlagDefaultActivityTimeout      = "default_activity_timeout"
+	FlagMaxActivityTimeout          = "max_activity_timeout"
+	FlagEncryptionKeyAlias          = "encryption_key_alias"
+	FlagResidencyTag                = "residency_tag"
+	FlagResidencyAllowedClusters    = "residency_allowed_clusters"
 	FlagEmitMetric                  = "emit_metric"
 	FlagEmitMetricWithAlias         = FlagEmitMetric + ", em"
 	FlagArchivalStatus              = "archival_status"
@@ -149,6 +162,7 @@ const (
 	FlagSecurityTokenWithAlias      = FlagSecurityToken + ", st"
 	FlagSkipErrorMode               = "skip_errors"
 	FlagSkipErrorModeWithAlias      = FlagSkipErrorMode + ", serr"
+	FlagForceCurrent                = "force_current"
 	FlagHeadersMode                 = "headers"
 	FlagHeadersModeWithAlias        = FlagHeadersMode + ", he"
 	FlagMessageType                 = "message_type"
@@ -171,6 +185,16 @@ const (
 	FlagResetBadBinaryChecksum      = "reset_bad_binary_checksum"
 	FlagListQuery                   = "query"
 	FlagListQueryWithAlias          = FlagListQuery + ", q"
+	FlagNotes                       = "notes"
+	FlagFirstExecutionRunID         = "first_run_id"
+	FlagBlockSeconds                = "block_seconds"
+	FlagScheduleID                  = "schedule_id"
+	FlagScheduleIDWithAlias         = FlagScheduleID + ", scid"
+	FlagOverlapPolicy               = "overlap_policy"
+	FlagCatchupWindow               = "catchup_window"
+	FlagRunsPerMinute               = "runs_per_minute"
+	FlagVersionSet                  = "version_set"
+	FlagTaskID                      = "task_id"
 )
 
 var flagsForExecution = []cli.Flag{
@@ -260,6 +284,15 @@ func getFlagsForStart() []cli.Flag {
 				"\t│ │ │ │ │ \n" +
 				"\t* * * * *",
 		},
+		cli.StringFlag{
+			Name:  FlagCronTimezone,
+			Usage: "Optional IANA time zone the cron schedule is evaluated in, e.g. America/New_York. Defaults to UTC",
+		},
+		cli.StringFlag{
+			Name: FlagSLADeadline,
+			Usage: "Optional deadline after which the workflow is flagged with the SLAExceeded search attribute if still open, " +
+				"supports formats '2006-01-02T15:04:05+07:00' and raw UnixNano",
+		},
 		cli.IntFlag{
 			Name: FlagWorkflowIDReusePolicyAlias,
 			Usage: "Optional input to configure if the same workflow ID is allow to use for new workflow execution. " +
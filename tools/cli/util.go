@@ -334,6 +334,18 @@ func ColorEvent(e *s.HistoryEvent) string {
 	case s.EventTypeUpsertWorkflowSearchAttributes:
 		data = e.EventType.String()
 
+	case s.EventTypeWorkflowExecutionUpdateRequested:
+		data = e.EventType.String()
+
+	case s.EventTypeWorkflowExecutionUpdateCompleted:
+		data = color.GreenString(e.EventType.String())
+
+	case s.EventTypeWorkflowExecutionPauseUpdated:
+		data = color.YellowString(e.EventType.String())
+
+	case s.EventTypeCronSchedulePauseUpdated:
+		data = color.YellowString(e.EventType.String())
+
 	default:
 		data = e.EventType.String()
 	}
@@ -463,6 +475,18 @@ func getEventAttributes(e *s.HistoryEvent) interface{} {
 	case s.EventTypeExternalWorkflowExecutionSignaled:
 		data = e.ExternalWorkflowExecutionSignaledEventAttributes
 
+	case s.EventTypeWorkflowExecutionUpdateRequested:
+		data = e.WorkflowExecutionUpdateRequestedEventAttributes
+
+	case s.EventTypeWorkflowExecutionUpdateCompleted:
+		data = e.WorkflowExecutionUpdateCompletedEventAttributes
+
+	case s.EventTypeWorkflowExecutionPauseUpdated:
+		data = e.WorkflowExecutionPauseUpdatedEventAttributes
+
+	case s.EventTypeCronSchedulePauseUpdated:
+		data = e.CronSchedulePauseUpdatedEventAttributes
+
 	default:
 		data = e
 	}
@@ -106,6 +106,35 @@ func newWorkflowCommands() []cli.Command {
 				SignalWorkflow(c)
 			},
 		},
+		{
+			Name:  "update",
+			Usage: "send an update to a workflow execution and wait for its result",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  FlagWorkflowIDWithAlias,
+					Usage: "WorkflowID",
+				},
+				cli.StringFlag{
+					Name:  FlagRunIDWithAlias,
+					Usage: "RunID",
+				},
+				cli.StringFlag{
+					Name:  FlagNameWithAlias,
+					Usage: "UpdateName",
+				},
+				cli.StringFlag{
+					Name:  FlagInputWithAlias,
+					Usage: "Input for the update, in JSON format.",
+				},
+				cli.StringFlag{
+					Name:  FlagInputFileWithAlias,
+					Usage: "Input for the update from JSON file.",
+				},
+			},
+			Action: func(c *cli.Context) {
+				UpdateWorkflow(c)
+			},
+		},
 		{
 			Name:    "terminate",
 			Aliases: []string{"term"},
@@ -207,6 +207,12 @@ func startWorkflowHelper(c *cli.Context, shouldPrintProgress bool) {
 	if c.IsSet(FlagCronSchedule) {
 		startRequest.CronSchedule = common.StringPtr(c.String(FlagCronSchedule))
 	}
+	if c.IsSet(FlagCronTimezone) {
+		startRequest.CronTimezone = common.StringPtr(c.String(FlagCronTimezone))
+	}
+	if c.IsSet(FlagSLADeadline) {
+		startRequest.SlaDeadlineTimestamp = common.Int64Ptr(parseTime(c.String(FlagSLADeadline), 0))
+	}
 
 	memoFields := processMemo(c)
 	if len(memoFields) != 0 {
@@ -471,6 +477,38 @@ func SignalWorkflow(c *cli.Context) {
 	}
 }
 
+// UpdateWorkflow sends an update to a workflow execution and prints its result
+func UpdateWorkflow(c *cli.Context) {
+	serviceClient := cFactory.ClientFrontendClient(c)
+
+	domain := getRequiredGlobalOption(c, FlagDomain)
+	wid := getRequiredOption(c, FlagWorkflowID)
+	rid := c.String(FlagRunID)
+	name := getRequiredOption(c, FlagName)
+	input := processJSONInput(c)
+
+	tcCtx, cancel := newContextForLongPoll(c)
+	defer cancel()
+	resp, err := serviceClient.UpdateWorkflowExecution(tcCtx, &s.UpdateWorkflowExecutionRequest{
+		Domain: common.StringPtr(domain),
+		WorkflowExecution: &s.WorkflowExecution{
+			WorkflowId: common.StringPtr(wid),
+			RunId:      getPtrOrNilIfEmpty(rid),
+		},
+		UpdateName: common.StringPtr(name),
+		Input:      []byte(input),
+		Identity:   common.StringPtr(getCliIdentity()),
+		RequestId:  common.StringPtr(uuid.New()),
+	})
+	if err != nil {
+		ErrorAndExit("Update workflow failed.", err)
+		return
+	}
+
+	// assume it is json encoded
+	fmt.Printf("Update result as JSON:\n%v\n", string(resp.Result))
+}
+
 // QueryWorkflow query workflow execution
 func QueryWorkflow(c *cli.Context) {
 	getRequiredGlobalOption(c, FlagDomain) // for pre-check and alert if not provided
@@ -707,6 +745,8 @@ type describeWorkflowExecutionResponse struct {
 	WorkflowExecutionInfo  workflowExecutionInfo
 	PendingActivities      []*pendingActivityInfo
 	PendingChildren        []*shared.PendingChildExecutionInfo
+	PendingDecision        *pendingDecisionInfo
+	ResetLineage           *shared.ResetLineageInfo `json:",omitempty"`
 }
 
 // workflowExecutionInfo has same fields as shared.WorkflowExecutionInfo, but has datetime instead of raw time
@@ -739,6 +779,17 @@ type pendingActivityInfo struct {
 	LastWorkerIdentity     *string `json:",omitempty"`
 }
 
+// pendingDecisionInfo has same fields as shared.PendingDecisionInfo, but has datetime instead of raw time
+type pendingDecisionInfo struct {
+	ScheduleID            *int64
+	StartedID             *int64 `json:",omitempty"`
+	Attempt               *int64
+	ScheduledTimestamp    *string // change from *int64
+	StartedTimestamp      *string `json:",omitempty"` // change from *int64
+	StartedIdentity       *string `json:",omitempty"`
+	StartedBinaryChecksum *string `json:",omitempty"`
+}
+
 func convertDescribeWorkflowExecutionResponse(resp *shared.DescribeWorkflowExecutionResponse,
 	wfClient workflowserviceclient.Interface, c *cli.Context) *describeWorkflowExecutionResponse {
 
@@ -778,11 +829,26 @@ func convertDescribeWorkflowExecutionResponse(resp *shared.DescribeWorkflowExecu
 		pendingActs = append(pendingActs, tmpAct)
 	}
 
+	var pendingDecision *pendingDecisionInfo
+	if pd := resp.PendingDecision; pd != nil {
+		pendingDecision = &pendingDecisionInfo{
+			ScheduleID:            pd.ScheduleID,
+			StartedID:             pd.StartedID,
+			Attempt:               pd.Attempt,
+			ScheduledTimestamp:    timestampPtrToStringPtr(pd.ScheduledTimestamp, false),
+			StartedTimestamp:      timestampPtrToStringPtr(pd.StartedTimestamp, false),
+			StartedIdentity:       pd.StartedIdentity,
+			StartedBinaryChecksum: pd.StartedBinaryChecksum,
+		}
+	}
+
 	return &describeWorkflowExecutionResponse{
 		ExecutionConfiguration: resp.ExecutionConfiguration,
 		WorkflowExecutionInfo:  executionInfo,
 		PendingActivities:      pendingActs,
 		PendingChildren:        resp.PendingChildren,
+		PendingDecision:        pendingDecision,
+		ResetLineage:           resp.ResetLineage,
 	}
 }
 
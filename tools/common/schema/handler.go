@@ -57,6 +57,14 @@ func SetupFromConfig(config *SetupConfig, db DB) error {
 	return newSetupSchemaTask(db, config).Run()
 }
 
+// UpdateFromConfig updates the schema for the specified database based on the given config
+func UpdateFromConfig(config *UpdateConfig, db DB) error {
+	if err := validateUpdateConfig(config); err != nil {
+		return err
+	}
+	return newUpdateSchemaTask(db, config).Run()
+}
+
 // Setup sets up schema tables
 func Setup(cli *cli.Context, db DB) error {
 	cfg, err := newSetupConfig(cli)